@@ -0,0 +1,278 @@
+package main
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/haasonsaas/nexus/internal/channels"
+	"github.com/haasonsaas/nexus/internal/edge"
+	"github.com/haasonsaas/nexus/pkg/models"
+	pb "github.com/haasonsaas/nexus/pkg/proto"
+)
+
+const (
+	// channelOutboxSize bounds inbound messages queued while disconnected.
+	channelOutboxSize = 500
+	// maxRelayedAttachmentBytes caps local attachments inlined for the core.
+	maxRelayedAttachmentBytes = 10 << 20
+	// channelSendTimeout bounds a single outbound delivery.
+	channelSendTimeout = 60 * time.Second
+)
+
+// hostedChannel is a channel adapter running on this machine whose messages
+// are relayed to the core over the edge stream.
+type hostedChannel interface {
+	channels.Adapter
+	channels.LifecycleAdapter
+	channels.InboundAdapter
+	channels.OutboundAdapter
+}
+
+// startChannels starts adapters for the configured channel types. Types
+// without a local adapter are advertised as before; types whose adapter
+// fails to start are not advertised so the core does not route to them.
+func (d *EdgeDaemon) startChannels(ctx context.Context) {
+	for _, channelType := range normalizeChannelTypes(d.config.ChannelTypes) {
+		adapter, err := newHostedChannel(channelType, d.config, d.logger)
+		if err == nil && adapter != nil {
+			err = adapter.Start(ctx)
+		}
+		if err != nil {
+			d.logger.Error("channel unavailable", "channel", channelType, "error", err)
+			d.unavailableChannels[channelType] = true
+			continue
+		}
+		if adapter == nil {
+			continue
+		}
+		d.channels[channelType] = adapter
+		d.logger.Info("hosting channel", "channel", channelType)
+		go d.relayChannel(ctx, channelType, adapter)
+	}
+}
+
+func (d *EdgeDaemon) stopChannels() {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	for channelType, adapter := range d.channels {
+		if err := adapter.Stop(ctx); err != nil {
+			d.logger.Warn("failed to stop channel", "channel", channelType, "error", err)
+		}
+	}
+}
+
+// advertisedChannelTypes returns the channel types to register with the core.
+func (d *EdgeDaemon) advertisedChannelTypes() []string {
+	configured := normalizeChannelTypes(d.config.ChannelTypes)
+	out := make([]string, 0, len(configured))
+	for _, channelType := range configured {
+		if !d.unavailableChannels[channelType] {
+			out = append(out, channelType)
+		}
+	}
+	if len(out) == 0 {
+		return nil
+	}
+	return out
+}
+
+// relayChannel forwards messages from a hosted channel to the core.
+func (d *EdgeDaemon) relayChannel(ctx context.Context, channelType string, adapter hostedChannel) {
+	protoType := edge.ChannelTypeToProto(models.ChannelType(channelType))
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case msg, ok := <-adapter.Messages():
+			if !ok {
+				return
+			}
+			d.relayInbound(channelInboundFromMessage(d.config.EdgeID, protoType, msg))
+		}
+	}
+}
+
+// relayInbound sends a message to the core, queueing it while disconnected.
+func (d *EdgeDaemon) relayInbound(in *pb.EdgeChannelInbound) {
+	d.channelMu.Lock()
+	defer d.channelMu.Unlock()
+	if d.channelReady {
+		err := d.send(&pb.EdgeMessage{
+			Message: &pb.EdgeMessage_ChannelInbound{ChannelInbound: in},
+		})
+		if err == nil {
+			return
+		}
+		d.logger.Warn("failed to relay channel message; queueing", "channel_id", in.ChannelId, "error", err)
+	}
+	if len(d.channelOutbox) >= channelOutboxSize {
+		d.logger.Warn("channel outbox full, dropping oldest message")
+		d.channelOutbox = d.channelOutbox[1:]
+	}
+	d.channelOutbox = append(d.channelOutbox, in)
+}
+
+// setChannelsReady marks the stream as registered and flushes queued
+// messages once it is.
+func (d *EdgeDaemon) setChannelsReady(ready bool) {
+	d.channelMu.Lock()
+	defer d.channelMu.Unlock()
+	d.channelReady = ready
+	if !ready {
+		return
+	}
+	for len(d.channelOutbox) > 0 {
+		in := d.channelOutbox[0]
+		if err := d.send(&pb.EdgeMessage{
+			Message: &pb.EdgeMessage_ChannelInbound{ChannelInbound: in},
+		}); err != nil {
+			d.logger.Warn("failed to flush channel outbox", "pending", len(d.channelOutbox), "error", err)
+			return
+		}
+		d.channelOutbox = d.channelOutbox[1:]
+	}
+}
+
+// handleChannelOutbound delivers a message from the core and acknowledges it.
+func (d *EdgeDaemon) handleChannelOutbound(ctx context.Context, out *pb.CoreChannelOutbound) {
+	ack := &pb.EdgeChannelAck{MessageId: out.MessageId}
+	channelType := strings.ToLower(strings.TrimPrefix(out.ChannelType.String(), "CHANNEL_TYPE_"))
+	adapter, ok := d.channels[channelType]
+	if !ok {
+		ack.Status = pb.ChannelDeliveryStatus_CHANNEL_DELIVERY_STATUS_FAILED
+		ack.Error = fmt.Sprintf("channel %s is not hosted on this edge", channelType)
+	} else {
+		sendCtx, cancel := context.WithTimeout(ctx, channelSendTimeout)
+		err := adapter.Send(sendCtx, messageFromChannelOutbound(out))
+		cancel()
+		if err != nil {
+			d.logger.Warn("channel delivery failed", "channel", channelType, "message_id", out.MessageId, "error", err)
+			ack.Status = pb.ChannelDeliveryStatus_CHANNEL_DELIVERY_STATUS_FAILED
+			ack.Error = err.Error()
+		} else {
+			ack.Status = pb.ChannelDeliveryStatus_CHANNEL_DELIVERY_STATUS_SENT
+			ack.DeliveredAt = timestamppb.Now()
+		}
+	}
+	if err := d.send(&pb.EdgeMessage{
+		Message: &pb.EdgeMessage_ChannelAck{ChannelAck: ack},
+	}); err != nil {
+		d.logger.Error("failed to send channel ack", "message_id", out.MessageId, "error", err)
+	}
+}
+
+// channelInboundFromMessage converts an adapter message for the core. The
+// conversation is the group chat when there is one, otherwise the sender.
+func channelInboundFromMessage(edgeID string, protoType pb.ChannelType, msg *models.Message) *pb.EdgeChannelInbound {
+	metadata := make(map[string]string, len(msg.Metadata)+1)
+	for key, value := range msg.Metadata {
+		if text, ok := value.(string); ok && text != "" {
+			metadata[key] = text
+		}
+	}
+	if msg.ID != "" {
+		metadata["message_id"] = msg.ID
+	}
+	channelID := metadata["group_id"]
+	if channelID == "" {
+		channelID = metadata["peer_id"]
+	}
+	if channelID == "" {
+		channelID = msg.ChannelID
+	}
+	in := &pb.EdgeChannelInbound{
+		EdgeId:      edgeID,
+		ChannelType: protoType,
+		ChannelId:   channelID,
+		Content:     msg.Content,
+		SenderId:    metadata["sender_id"],
+		SenderName:  metadata["sender_name"],
+		Metadata:    metadata,
+		ReceivedAt:  timestamppb.Now(),
+	}
+	if !msg.CreatedAt.IsZero() {
+		in.ReceivedAt = timestamppb.New(msg.CreatedAt)
+	}
+	for _, att := range msg.Attachments {
+		in.Attachments = append(in.Attachments, relayAttachment(att))
+	}
+	return in
+}
+
+// relayAttachment inlines local files as data URLs, since the core cannot
+// read this machine's disk. Files over the cap are sent without content.
+func relayAttachment(att models.Attachment) *pb.Attachment {
+	out := &pb.Attachment{
+		Id:       att.ID,
+		Type:     att.Type,
+		Filename: att.Filename,
+		MimeType: att.MimeType,
+		Size:     att.Size,
+	}
+	if !strings.HasPrefix(att.URL, "file://") {
+		out.Url = att.URL
+		return out
+	}
+	path := strings.TrimPrefix(att.URL, "file://")
+	if decoded, err := url.PathUnescape(path); err == nil {
+		path = decoded
+	}
+	info, err := os.Stat(expandUserPath(path))
+	if err != nil || info.Size() > maxRelayedAttachmentBytes {
+		return out
+	}
+	data, err := os.ReadFile(expandUserPath(path))
+	if err != nil {
+		return out
+	}
+	mimeType := att.MimeType
+	if mimeType == "" {
+		mimeType = "application/octet-stream"
+	}
+	out.Url = "data:" + mimeType + ";base64," + base64.StdEncoding.EncodeToString(data)
+	out.Size = int64(len(data))
+	return out
+}
+
+// messageFromChannelOutbound converts a core delivery for a local adapter.
+func messageFromChannelOutbound(out *pb.CoreChannelOutbound) *models.Message {
+	msg := &models.Message{
+		ID:        out.MessageId,
+		SessionID: out.SessionId,
+		ChannelID: out.ChannelId,
+		Direction: models.DirectionOutbound,
+		Role:      models.RoleAssistant,
+		Content:   out.Content,
+		Metadata:  map[string]any{"peer_id": out.ChannelId},
+		CreatedAt: time.Now(),
+	}
+	for key, value := range out.Options {
+		if _, exists := msg.Metadata[key]; !exists {
+			msg.Metadata[key] = value
+		}
+	}
+	if out.ReplyToId != "" {
+		msg.Metadata["reply_to"] = out.ReplyToId
+	}
+	for _, att := range out.Attachments {
+		if att == nil {
+			continue
+		}
+		msg.Attachments = append(msg.Attachments, models.Attachment{
+			ID:       att.Id,
+			Type:     att.Type,
+			URL:      att.Url,
+			Filename: att.Filename,
+			MimeType: att.MimeType,
+			Size:     att.Size,
+		})
+	}
+	return msg
+}
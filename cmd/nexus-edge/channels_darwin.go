@@ -0,0 +1,31 @@
+//go:build darwin
+
+package main
+
+import (
+	"log/slog"
+
+	"github.com/haasonsaas/nexus/internal/channels/imessage"
+)
+
+// newHostedChannel builds the local adapter for a channel type, or returns
+// nil when this edge has no adapter for it.
+func newHostedChannel(channelType string, cfg Config, logger *slog.Logger) (hostedChannel, error) {
+	switch channelType {
+	case "imessage":
+		imCfg := imessage.DefaultConfig()
+		imCfg.Enabled = true
+		if cfg.IMessage.DatabasePath != "" {
+			imCfg.DatabasePath = cfg.IMessage.DatabasePath
+		}
+		if cfg.IMessage.PollInterval > 0 {
+			imCfg.PollInterval = cfg.IMessage.PollInterval.String()
+		}
+		if err := imCfg.Validate(); err != nil {
+			return nil, err
+		}
+		return imessage.New(imCfg, logger)
+	default:
+		return nil, nil
+	}
+}
@@ -0,0 +1,19 @@
+//go:build !darwin
+
+package main
+
+import (
+	"errors"
+	"log/slog"
+)
+
+// newHostedChannel builds the local adapter for a channel type, or returns
+// nil when this edge has no adapter for it.
+func newHostedChannel(channelType string, cfg Config, logger *slog.Logger) (hostedChannel, error) {
+	switch channelType {
+	case "imessage":
+		return nil, errors.New("imessage can only be hosted on a macOS edge")
+	default:
+		return nil, nil
+	}
+}
@@ -0,0 +1,96 @@
+package main
+
+import (
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/haasonsaas/nexus/pkg/models"
+	pb "github.com/haasonsaas/nexus/pkg/proto"
+)
+
+func TestChannelInboundFromMessage(t *testing.T) {
+	dir := t.TempDir()
+	photo := filepath.Join(dir, "photo.jpg")
+	if err := os.WriteFile(photo, []byte("jpeg"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	msg := &models.Message{
+		ID:      "guid-1",
+		Content: "look",
+		Metadata: map[string]any{
+			"peer_id":     "+15550100",
+			"sender_id":   "+15550100",
+			"sender_name": "Sam",
+			"group_id":    "chat123",
+			"ignored":     42,
+		},
+		Attachments: []models.Attachment{{ID: "att-1", MimeType: "image/jpeg", URL: "file://" + photo}},
+		CreatedAt:   time.Date(2026, 5, 1, 9, 0, 0, 0, time.UTC),
+	}
+
+	in := channelInboundFromMessage("macbook", pb.ChannelType_CHANNEL_TYPE_IMESSAGE, msg)
+	if in.ChannelId != "chat123" || in.SenderId != "+15550100" || in.SenderName != "Sam" {
+		t.Fatalf("unexpected routing fields: %+v", in)
+	}
+	if in.Metadata["message_id"] != "guid-1" || in.Metadata["peer_id"] != "+15550100" {
+		t.Fatalf("unexpected metadata: %+v", in.Metadata)
+	}
+	if _, ok := in.Metadata["ignored"]; ok {
+		t.Fatal("non-string metadata should be dropped")
+	}
+	if !in.ReceivedAt.AsTime().Equal(msg.CreatedAt) {
+		t.Fatalf("received_at = %v", in.ReceivedAt.AsTime())
+	}
+	if len(in.Attachments) != 1 || !strings.HasPrefix(in.Attachments[0].Url, "data:image/jpeg;base64,") {
+		t.Fatalf("expected local attachment to be inlined, got %+v", in.Attachments)
+	}
+}
+
+func TestMessageFromChannelOutbound(t *testing.T) {
+	msg := messageFromChannelOutbound(&pb.CoreChannelOutbound{
+		MessageId: "out-1",
+		SessionId: "session-1",
+		ChannelId: "+15550100",
+		Content:   "hi",
+		Options:   map[string]string{"group_id": "chat123", "peer_id": "spoofed"},
+	})
+	if msg.Metadata["peer_id"] != "+15550100" || msg.Metadata["group_id"] != "chat123" {
+		t.Fatalf("unexpected metadata: %+v", msg.Metadata)
+	}
+	if msg.Content != "hi" || msg.SessionID != "session-1" {
+		t.Fatalf("unexpected message: %+v", msg)
+	}
+}
+
+func TestRelayInboundQueuesWhileDisconnected(t *testing.T) {
+	daemon := NewEdgeDaemon(DefaultConfig(), slog.New(slog.NewTextHandler(io.Discard, nil)))
+	for i := 0; i < channelOutboxSize+5; i++ {
+		daemon.relayInbound(&pb.EdgeChannelInbound{ChannelId: "+15550100"})
+	}
+	if len(daemon.channelOutbox) != channelOutboxSize {
+		t.Fatalf("outbox = %d, want %d", len(daemon.channelOutbox), channelOutboxSize)
+	}
+
+	// Without a stream the flush fails and keeps the queue intact.
+	daemon.setChannelsReady(true)
+	if len(daemon.channelOutbox) != channelOutboxSize {
+		t.Fatalf("outbox drained without a stream: %d", len(daemon.channelOutbox))
+	}
+}
+
+func TestAdvertisedChannelTypesSkipsUnavailable(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.ChannelTypes = []string{"iMessage", "signal"}
+	daemon := NewEdgeDaemon(cfg, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	daemon.unavailableChannels["imessage"] = true
+
+	got := daemon.advertisedChannelTypes()
+	if len(got) != 1 || got[0] != "signal" {
+		t.Fatalf("advertisedChannelTypes() = %v", got)
+	}
+}
@@ -0,0 +1,415 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	defaultCommandShell           = "/bin/sh"
+	defaultCommandOutputBytes     = 256 << 10
+	defaultCommandMaxTimeout      = 10 * time.Minute
+	defaultCommandApprovalTTL     = 10 * time.Minute
+	defaultCommandApprovalTimeout = 60 * time.Second
+)
+
+// commandBuiltins are shell builtins that cannot run other programs, so they
+// never need to be allowlisted.
+var commandBuiltins = map[string]bool{
+	"cd":    true,
+	"true":  true,
+	"false": true,
+	":":     true,
+	"pwd":   true,
+	"exit":  true,
+}
+
+// commandDecision is the policy outcome for a command.
+type commandDecision string
+
+const (
+	commandAllowed       commandDecision = "allowed"
+	commandNeedsApproval commandDecision = "needs_approval"
+	commandDenied        commandDecision = "denied"
+)
+
+// commandVerdict explains how the policy treats a command.
+type commandVerdict struct {
+	Decision commandDecision `json:"decision"`
+	Binaries []string        `json:"binaries"`
+	Reasons  []string        `json:"reasons,omitempty"`
+}
+
+// parsedCommand is the result of scanning a shell command string.
+type parsedCommand struct {
+	// binaries are the command names of each pipeline stage.
+	binaries []string
+	// unsafe lists constructs the scanner cannot vet, such as command
+	// substitution or output redirection to a file.
+	unsafe []string
+}
+
+// parseShellCommand scans a POSIX shell command into the binaries it runs.
+// It understands quoting, escapes, pipelines, command lists, leading
+// variable assignments, and redirections; constructs that could run or
+// write anything else are reported as unsafe rather than interpreted.
+func parseShellCommand(command string) parsedCommand {
+	var (
+		out      parsedCommand
+		words    []string
+		word     strings.Builder
+		inWord   bool
+		expanded bool
+		redirect string
+		quote    rune
+		escaped  bool
+		// substDepth counts open "$(", "<(", and ">(" so their closing
+		// parentheses are not reported again as subshells.
+		substDepth int
+		unsafeSet  = map[string]bool{}
+	)
+	markUnsafe := func(reason string) {
+		if !unsafeSet[reason] {
+			unsafeSet[reason] = true
+			out.unsafe = append(out.unsafe, reason)
+		}
+	}
+	endWord := func() {
+		if !inWord {
+			return
+		}
+		text := word.String()
+		switch redirect {
+		case "":
+			if expanded && !isAssignment(text) && onlyAssignments(words) {
+				markUnsafe("command name uses expansion")
+			}
+			words = append(words, text)
+		case "out":
+			if text != "/dev/null" {
+				markUnsafe("output redirected to " + text)
+			}
+		case "dup":
+			if strings.Trim(text, "0123456789-") != "" {
+				markUnsafe("output redirected to " + text)
+			}
+		}
+		redirect = ""
+		word.Reset()
+		inWord = false
+		expanded = false
+	}
+	endSegment := func() {
+		endWord()
+		for _, w := range words {
+			if isAssignment(w) {
+				continue
+			}
+			out.binaries = append(out.binaries, w)
+			break
+		}
+		words = nil
+	}
+
+	runes := []rune(command)
+	for i := 0; i < len(runes); i++ {
+		c := runes[i]
+		next := rune(0)
+		if i+1 < len(runes) {
+			next = runes[i+1]
+		}
+		if escaped {
+			escaped = false
+			if c != '\n' {
+				word.WriteRune(c)
+				inWord = true
+			}
+			continue
+		}
+		switch quote {
+		case '\'':
+			if c == '\'' {
+				quote = 0
+			} else {
+				word.WriteRune(c)
+			}
+			continue
+		case '"':
+			switch {
+			case c == '"':
+				quote = 0
+			case c == '\\' && strings.ContainsRune("$`\"\\\n", next):
+				escaped = true
+			case c == '`':
+				markUnsafe("command substitution")
+				word.WriteRune(c)
+			case c == '$' && next == '(':
+				markUnsafe("command substitution")
+				substDepth++
+				word.WriteRune(c)
+				i++
+			case c == ')' && substDepth > 0:
+				substDepth--
+				word.WriteRune(c)
+			case c == '$':
+				expanded = true
+				word.WriteRune(c)
+			default:
+				word.WriteRune(c)
+			}
+			continue
+		}
+
+		switch {
+		case c == '\\':
+			escaped = true
+			inWord = true
+		case c == '\'' || c == '"':
+			quote = c
+			inWord = true
+		case c == '`':
+			markUnsafe("command substitution")
+			word.WriteRune(c)
+			inWord = true
+		case c == '$' && next == '(':
+			markUnsafe("command substitution")
+			substDepth++
+			word.WriteRune(c)
+			inWord = true
+			i++
+		case c == ')' && substDepth > 0:
+			substDepth--
+		case c == '$':
+			expanded = true
+			word.WriteRune(c)
+			inWord = true
+		case (c == '<' || c == '>') && next == '(':
+			markUnsafe("process substitution")
+			substDepth++
+			i++
+		case c == '(' || c == ')' || c == '{' && !inWord && next == ' ':
+			markUnsafe("subshell or command group")
+		case c == '>':
+			// A bare fd number before ">" belongs to the redirection.
+			if inWord && strings.Trim(word.String(), "0123456789") == "" {
+				word.Reset()
+				inWord = false
+			}
+			endWord()
+			if next == '>' || next == '|' {
+				i++
+			}
+			redirect = "out"
+			if i+1 < len(runes) && runes[i+1] == '&' {
+				i++
+				redirect = "dup"
+			}
+		case c == '<':
+			endWord()
+			if next == '<' {
+				markUnsafe("here-document")
+				i++
+			}
+			redirect = "in"
+		case c == '|' || c == ';' || c == '&' || c == '\n':
+			if c == '&' && next == '>' {
+				endWord()
+				redirect = "out"
+				i++
+				continue
+			}
+			endSegment()
+			if (c == '|' || c == '&') && (next == c || (c == '|' && next == '&')) {
+				i++
+			}
+		case c == ' ' || c == '\t':
+			endWord()
+		case c == '#' && !inWord:
+			// Comment until end of line.
+			for i+1 < len(runes) && runes[i+1] != '\n' {
+				i++
+			}
+		default:
+			word.WriteRune(c)
+			inWord = true
+		}
+	}
+	if quote != 0 {
+		markUnsafe("unterminated quote")
+	}
+	endSegment()
+	return out
+}
+
+// isAssignment reports whether a word is a NAME=value variable assignment.
+func isAssignment(word string) bool {
+	name, _, ok := strings.Cut(word, "=")
+	if !ok || name == "" {
+		return false
+	}
+	for i, c := range name {
+		if c != '_' && (c < 'a' || c > 'z') && (c < 'A' || c > 'Z') && (i == 0 || c < '0' || c > '9') {
+			return false
+		}
+	}
+	return true
+}
+
+func onlyAssignments(words []string) bool {
+	for _, w := range words {
+		if !isAssignment(w) {
+			return false
+		}
+	}
+	return true
+}
+
+// evaluateCommand applies the policy to a command string.
+func evaluateCommand(policy *CommandPolicy, command string) commandVerdict {
+	parsed := parseShellCommand(command)
+	verdict := commandVerdict{Decision: commandAllowed, Binaries: parsed.binaries}
+	if verdict.Binaries == nil {
+		verdict.Binaries = []string{}
+	}
+	var allowlist, denylist []string
+	if policy != nil {
+		allowlist = filterPatterns(policy.Allowlist)
+		denylist = filterPatterns(policy.Denylist)
+	}
+
+	for _, binary := range parsed.binaries {
+		candidates := commandCandidates(binary)
+		if matchesAnyPattern(denylist, candidates) {
+			verdict.Decision = commandDenied
+			verdict.Reasons = append(verdict.Reasons, binary+" is denied by local policy")
+			continue
+		}
+		if commandBuiltins[binary] || matchesAnyPattern(allowlist, candidates) {
+			continue
+		}
+		verdict.Reasons = append(verdict.Reasons, binary+" is not allowlisted")
+	}
+	verdict.Reasons = append(verdict.Reasons, parsed.unsafe...)
+	if len(parsed.binaries) == 0 && len(parsed.unsafe) == 0 {
+		verdict.Reasons = append(verdict.Reasons, "no command found")
+	}
+	if verdict.Decision != commandDenied && len(verdict.Reasons) > 0 {
+		verdict.Decision = commandNeedsApproval
+	}
+	return verdict
+}
+
+func commandCandidates(binary string) []string {
+	resolved := binary
+	if !strings.Contains(binary, "/") {
+		if path, err := exec.LookPath(binary); err == nil {
+			resolved = path
+		}
+	}
+	return []string{binary, resolved, filepath.Base(resolved)}
+}
+
+// commandGuard applies CommandPolicy and asks the person at the machine to
+// approve commands the policy does not allow outright.
+type commandGuard struct {
+	policy  CommandPolicy
+	approve localApprover
+	logger  *slog.Logger
+	now     func() time.Time
+
+	// promptMu keeps a single approval prompt on screen at a time.
+	promptMu sync.Mutex
+
+	mu sync.Mutex
+	// grants maps a session to when its "Allow for Session" approval expires.
+	grants map[string]time.Time
+}
+
+func newCommandGuard(policy *CommandPolicy, logger *slog.Logger) *commandGuard {
+	var effective CommandPolicy
+	if policy != nil {
+		effective = *policy
+	}
+	if strings.TrimSpace(effective.Shell) == "" {
+		effective.Shell = defaultCommandShell
+	}
+	if effective.MaxOutputBytes <= 0 {
+		effective.MaxOutputBytes = defaultCommandOutputBytes
+	}
+	if effective.MaxTimeout <= 0 {
+		effective.MaxTimeout = defaultCommandMaxTimeout
+	}
+	if effective.ApprovalTimeout <= 0 {
+		effective.ApprovalTimeout = defaultCommandApprovalTimeout
+	}
+	return &commandGuard{
+		policy:  effective,
+		approve: newLocalApprover("Nexus command"),
+		logger:  logger,
+		now:     time.Now,
+		grants:  make(map[string]time.Time),
+	}
+}
+
+// authorize returns how a command that needs approval was approved. The
+// core's approved flag is not consulted: allowlisted commands must run
+// without a core prompt, so the tool does not ask the core for approval.
+func (g *commandGuard) authorize(ctx context.Context, sessionID, command string, verdict commandVerdict) (string, error) {
+	if g.hasGrant(sessionID) {
+		return "session", nil
+	}
+	if g.approve == nil {
+		return "", fmt.Errorf("approval required (%s): this edge cannot prompt locally; add the binary to node_policy.commands.allowlist", strings.Join(verdict.Reasons, "; "))
+	}
+
+	g.promptMu.Lock()
+	defer g.promptMu.Unlock()
+	if g.hasGrant(sessionID) {
+		return "session", nil
+	}
+	prompt := fmt.Sprintf("Nexus wants to run:\n\n%s\n\nReason: %s", command, strings.Join(verdict.Reasons, "; "))
+	if sessionID != "" {
+		prompt += "\n\nSession: " + sessionID
+	}
+	decision, err := g.approve(ctx, prompt, g.policy.ApprovalTimeout)
+	if err != nil {
+		return "", fmt.Errorf("approval prompt failed: %w", err)
+	}
+	switch decision {
+	case approvalSession:
+		if sessionID != "" {
+			g.mu.Lock()
+			g.grants[sessionID] = g.now().Add(defaultCommandApprovalTTL)
+			g.mu.Unlock()
+		}
+		return "session", nil
+	case approvalOnce:
+		return "once", nil
+	default:
+		return "", errors.New("command denied by the user at this machine")
+	}
+}
+
+func (g *commandGuard) hasGrant(sessionID string) bool {
+	if sessionID == "" {
+		return false
+	}
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	expires, ok := g.grants[sessionID]
+	if !ok {
+		return false
+	}
+	if !g.now().Before(expires) {
+		delete(g.grants, sessionID)
+		return false
+	}
+	return true
+}
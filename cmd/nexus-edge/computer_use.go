@@ -31,7 +31,7 @@ type computerUseParams struct {
 }
 
 // computerUseTool performs direct mouse/keyboard/screenshot actions for computer use.
-func computerUseTool(policy *ComputerUsePolicy) *Tool {
+func computerUseTool(guard *computerUseGuard) *Tool {
 	return &Tool{
 		Name:              "nodes.computer_use",
 		Description:       "Perform computer-use actions (mouse, keyboard, scroll, screenshot). Intended for Claude computer use.",
@@ -40,12 +40,14 @@ func computerUseTool(policy *ComputerUsePolicy) *Tool {
 		TimeoutSeconds:    60,
 		ProducesArtifacts: true,
 		Handler: func(ctx context.Context, input string) (*ToolResult, error) {
-			return handleComputerUse(ctx, input, policy)
+			return handleComputerUse(ctx, input, guard)
 		},
 	}
 }
 
-func handleComputerUse(ctx context.Context, input string, policy *ComputerUsePolicy) (*ToolResult, error) {
+// handleComputerUse checks the action against the local policy, rate limit,
+// and approval rules, runs it, and attaches the session recording.
+func handleComputerUse(ctx context.Context, input string, guard *computerUseGuard) (*ToolResult, error) {
 	var params computerUseParams
 	if err := json.Unmarshal([]byte(input), &params); err != nil {
 		return nil, fmt.Errorf("invalid params: %w", err)
@@ -55,13 +57,66 @@ func handleComputerUse(ctx context.Context, input string, policy *ComputerUsePol
 	if action == "" {
 		return &ToolResult{Content: "action is required", IsError: true}, nil
 	}
-	if !computerUseActionAllowed(policy, action) {
-		return &ToolResult{Content: "action denied by computer_use policy", IsError: true}, nil
+
+	sessionID := "local"
+	coreApproved := false
+	entry := computerUseEntry{
+		Action:          action,
+		Coordinate:      params.Coordinate,
+		StartCoordinate: params.StartCoordinate,
+		EndCoordinate:   params.EndCoordinate,
+		Text:            params.Text,
+		ScrollDirection: params.ScrollDirection,
+		ScrollAmount:    params.ScrollAmount,
+	}
+	if req := toolRequestFromContext(ctx); req != nil {
+		if strings.TrimSpace(req.SessionId) != "" {
+			sessionID = req.SessionId
+		}
+		coreApproved = req.Approved
+		entry.ExecutionID = req.ExecutionId
+		entry.RunID = req.RunId
 	}
 
+	finish := func(result *ToolResult, outcome string) (*ToolResult, error) {
+		entry.Outcome = outcome
+		if result.IsError {
+			entry.Error = result.Content
+		}
+		for _, art := range result.Artifacts {
+			entry.ArtifactIDs = append(entry.ArtifactIDs, art.Id)
+		}
+		result.Artifacts = append(result.Artifacts, guard.record(sessionID, entry))
+		return result, nil
+	}
+
+	if !computerUseActionAllowed(&guard.policy, action) {
+		return finish(&ToolResult{Content: "action denied by computer_use policy", IsError: true}, "denied")
+	}
+	if err := guard.allow(sessionID); err != nil {
+		return finish(&ToolResult{Content: err.Error(), IsError: true}, "rate_limited")
+	}
+	approval, err := guard.authorize(ctx, sessionID, action, params, coreApproved)
+	if err != nil {
+		return finish(&ToolResult{Content: err.Error(), IsError: true}, "denied")
+	}
+	entry.Approval = approval
+
+	result, err := runComputerAction(ctx, action, params)
+	if err != nil {
+		result = &ToolResult{Content: fmt.Sprintf("computer_use failed: %v", err), IsError: true}
+	}
+	outcome := "ok"
+	if result.IsError {
+		outcome = "error"
+	}
+	return finish(result, outcome)
+}
+
+func runComputerAction(ctx context.Context, action string, params computerUseParams) (*ToolResult, error) {
 	switch action {
 	case "screenshot":
-		return handleScreenCapture(ctx, "{}")
+		return handleComputerScreenshot(ctx)
 	case "wait":
 		wait := time.Duration(params.DurationMs) * time.Millisecond
 		if wait <= 0 && params.DurationSeconds > 0 {
@@ -83,6 +138,18 @@ func handleComputerUse(ctx context.Context, input string, policy *ComputerUsePol
 	}
 }
 
+// handleComputerScreenshot captures the display that input actions target.
+// screencapture numbers displays from 1; the display metadata is 0-based.
+func handleComputerScreenshot(ctx context.Context) (*ToolResult, error) {
+	input := "{}"
+	if runtime.GOOS == "darwin" {
+		if number := displayInfo().DisplayNumber; number > 0 {
+			input = fmt.Sprintf(`{"display":%d}`, number+1)
+		}
+	}
+	return handleScreenCapture(ctx, input)
+}
+
 func handleCursorPosition(ctx context.Context) (*ToolResult, error) {
 	switch runtime.GOOS {
 	case "darwin":
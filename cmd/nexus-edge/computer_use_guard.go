@@ -0,0 +1,383 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/haasonsaas/nexus/internal/tools/computeruse"
+	pb "github.com/haasonsaas/nexus/pkg/proto"
+)
+
+const (
+	defaultComputerUseActionsPerMinute = 60
+	defaultComputerUseApprovalTimeout  = 60 * time.Second
+	defaultComputerUseSessionApproval  = 10 * time.Minute
+
+	// computerUseRecordingMaxEntries caps each session's recording; the
+	// oldest entries are dropped first.
+	computerUseRecordingMaxEntries = 2000
+	// computerUseRecordingIdle is how long an idle session's recording and
+	// approval state are kept.
+	computerUseRecordingIdle = 24 * time.Hour
+	// computerUseRecordingType is the artifact type of session recordings.
+	computerUseRecordingType = computeruse.RecordingArtifactType
+	// computerUseRecordingTTL keeps recording artifacts for a week.
+	computerUseRecordingTTL = 7 * 24 * 60 * 60
+)
+
+// computerUseInputActions send clicks or keystrokes to the machine. They
+// always need approval, either granted by the core or by the person at the
+// machine.
+var computerUseInputActions = map[string]bool{
+	"left_click":      true,
+	"right_click":     true,
+	"middle_click":    true,
+	"double_click":    true,
+	"triple_click":    true,
+	"left_click_drag": true,
+	"left_mouse_down": true,
+	"left_mouse_up":   true,
+	"type":            true,
+	"key":             true,
+	"hold_key":        true,
+}
+
+// approvalDecision is the answer to a local approval prompt.
+type approvalDecision int
+
+const (
+	approvalDenied approvalDecision = iota
+	approvalOnce
+	approvalSession
+)
+
+// localApprover asks the person at the machine to allow an action.
+type localApprover func(ctx context.Context, prompt string, timeout time.Duration) (approvalDecision, error)
+
+// computerUseEntry is one line of a session recording.
+type computerUseEntry struct {
+	Time            time.Time `json:"time"`
+	ExecutionID     string    `json:"execution_id,omitempty"`
+	RunID           string    `json:"run_id,omitempty"`
+	Action          string    `json:"action"`
+	Coordinate      []int     `json:"coordinate,omitempty"`
+	StartCoordinate []int     `json:"start_coordinate,omitempty"`
+	EndCoordinate   []int     `json:"end_coordinate,omitempty"`
+	Text            string    `json:"text,omitempty"`
+	ScrollDirection string    `json:"scroll_direction,omitempty"`
+	ScrollAmount    int       `json:"scroll_amount,omitempty"`
+	// Approval is how an input action was allowed: core, once, or session.
+	Approval string `json:"approval,omitempty"`
+	// Outcome is ok, error, denied, or rate_limited.
+	Outcome     string   `json:"outcome"`
+	Error       string   `json:"error,omitempty"`
+	ArtifactIDs []string `json:"artifact_ids,omitempty"`
+}
+
+type computerUseRecording struct {
+	entries []computerUseEntry
+	lastAt  time.Time
+}
+
+// computerUseGuard enforces approval and rate limits for nodes.computer_use
+// and records every action per session.
+type computerUseGuard struct {
+	policy  ComputerUsePolicy
+	approve localApprover
+	logger  *slog.Logger
+	now     func() time.Time
+
+	// promptMu keeps a single approval prompt on screen at a time.
+	promptMu sync.Mutex
+
+	mu         sync.Mutex
+	actions    map[string][]time.Time
+	grants     map[string]time.Time
+	recordings map[string]*computerUseRecording
+}
+
+func newComputerUseGuard(policy *ComputerUsePolicy, logger *slog.Logger) *computerUseGuard {
+	var effective ComputerUsePolicy
+	if policy != nil {
+		effective = *policy
+	}
+	if effective.MaxActionsPerMinute == 0 {
+		effective.MaxActionsPerMinute = defaultComputerUseActionsPerMinute
+	}
+	if effective.ApprovalTimeout <= 0 {
+		effective.ApprovalTimeout = defaultComputerUseApprovalTimeout
+	}
+	if effective.SessionApprovalTTL <= 0 {
+		effective.SessionApprovalTTL = defaultComputerUseSessionApproval
+	}
+	effective.RecordingDir = expandUserPath(strings.TrimSpace(effective.RecordingDir))
+
+	guard := &computerUseGuard{
+		policy:     effective,
+		logger:     logger,
+		now:        time.Now,
+		actions:    make(map[string][]time.Time),
+		grants:     make(map[string]time.Time),
+		recordings: make(map[string]*computerUseRecording),
+	}
+	guard.approve = newLocalApprover("Nexus computer use")
+	return guard
+}
+
+// allow counts an action against the session's per-minute budget. A negative
+// limit disables rate limiting.
+func (g *computerUseGuard) allow(sessionID string) error {
+	limit := g.policy.MaxActionsPerMinute
+	if limit < 0 {
+		return nil
+	}
+	now := g.now()
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	window := g.actions[sessionID]
+	cutoff := now.Add(-time.Minute)
+	kept := window[:0]
+	for _, at := range window {
+		if at.After(cutoff) {
+			kept = append(kept, at)
+		}
+	}
+	if len(kept) >= limit {
+		g.actions[sessionID] = kept
+		retry := kept[0].Add(time.Minute).Sub(now).Round(time.Second)
+		return fmt.Errorf("rate limit exceeded: %d actions per minute; retry in %s", limit, retry)
+	}
+	g.actions[sessionID] = append(kept, now)
+	return nil
+}
+
+// authorize returns how an input action was approved. Actions that do not
+// send input need no approval and return "".
+func (g *computerUseGuard) authorize(ctx context.Context, sessionID, action string, params computerUseParams, coreApproved bool) (string, error) {
+	if !computerUseInputActions[action] {
+		return "", nil
+	}
+	if coreApproved {
+		return "core", nil
+	}
+	if g.hasGrant(sessionID) {
+		return "session", nil
+	}
+	if g.approve == nil {
+		return "", errors.New("approval required: this edge cannot prompt locally and the core did not approve the action")
+	}
+
+	g.promptMu.Lock()
+	defer g.promptMu.Unlock()
+	// Another prompt may have granted the session while this one waited.
+	if g.hasGrant(sessionID) {
+		return "session", nil
+	}
+	decision, err := g.approve(ctx, describeComputerAction(sessionID, action, params), g.policy.ApprovalTimeout)
+	if err != nil {
+		return "", fmt.Errorf("approval prompt failed: %w", err)
+	}
+	switch decision {
+	case approvalSession:
+		g.mu.Lock()
+		g.grants[sessionID] = g.now().Add(g.policy.SessionApprovalTTL)
+		g.mu.Unlock()
+		return "session", nil
+	case approvalOnce:
+		return "once", nil
+	default:
+		return "", errors.New("action denied by the user at this machine")
+	}
+}
+
+func (g *computerUseGuard) hasGrant(sessionID string) bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	expires, ok := g.grants[sessionID]
+	if !ok {
+		return false
+	}
+	if !g.now().Before(expires) {
+		delete(g.grants, sessionID)
+		return false
+	}
+	return true
+}
+
+// record appends entry to the session recording and returns the recording
+// as an artifact.
+func (g *computerUseGuard) record(sessionID string, entry computerUseEntry) *pb.Artifact {
+	now := g.now()
+	entry.Time = now
+
+	g.mu.Lock()
+	g.pruneIdleLocked(now)
+	rec := g.recordings[sessionID]
+	if rec == nil {
+		rec = &computerUseRecording{}
+		g.recordings[sessionID] = rec
+	}
+	rec.entries = append(rec.entries, entry)
+	if over := len(rec.entries) - computerUseRecordingMaxEntries; over > 0 {
+		rec.entries = append([]computerUseEntry(nil), rec.entries[over:]...)
+	}
+	rec.lastAt = now
+	data := encodeComputerUseRecording(rec.entries)
+	g.mu.Unlock()
+
+	if dir := g.policy.RecordingDir; dir != "" {
+		if err := appendComputerUseRecording(dir, sessionID, entry); err != nil {
+			// The artifact still carries the recording.
+			g.logger.Warn("failed to write computer use recording", "dir", dir, "error", err)
+		}
+	}
+
+	name := recordingFileName(sessionID)
+	return &pb.Artifact{
+		Id:         "computer-use-recording-" + name,
+		Type:       computerUseRecordingType,
+		MimeType:   "application/x-ndjson",
+		Filename:   "computer_use_" + name + ".jsonl",
+		Size:       int64(len(data)),
+		Data:       data,
+		TtlSeconds: computerUseRecordingTTL,
+	}
+}
+
+func (g *computerUseGuard) pruneIdleLocked(now time.Time) {
+	for sessionID, rec := range g.recordings {
+		if now.Sub(rec.lastAt) > computerUseRecordingIdle {
+			delete(g.recordings, sessionID)
+			delete(g.actions, sessionID)
+			delete(g.grants, sessionID)
+		}
+	}
+}
+
+func encodeComputerUseRecording(entries []computerUseEntry) []byte {
+	var buf []byte
+	for _, entry := range entries {
+		line, err := json.Marshal(entry)
+		if err != nil {
+			continue
+		}
+		buf = append(buf, line...)
+		buf = append(buf, '\n')
+	}
+	return buf
+}
+
+func appendComputerUseRecording(dir, sessionID string, entry computerUseEntry) error {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return err
+	}
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	path := filepath.Join(dir, recordingFileName(sessionID)+".jsonl")
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	_, err = f.Write(append(line, '\n'))
+	return err
+}
+
+var unsafeFileChars = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+func recordingFileName(sessionID string) string {
+	name := unsafeFileChars.ReplaceAllString(sessionID, "_")
+	if name == "" {
+		return "local"
+	}
+	return name
+}
+
+// describeComputerAction renders the approval prompt for an action.
+func describeComputerAction(sessionID, action string, params computerUseParams) string {
+	var target string
+	switch {
+	case action == "left_click_drag":
+		target = fmt.Sprintf(" from %s to %s", formatCoordinate(params.StartCoordinate), formatCoordinate(params.EndCoordinate))
+	case len(params.Coordinate) >= 2:
+		target = " at " + formatCoordinate(params.Coordinate)
+	case params.Text != "":
+		text := params.Text
+		if len(text) > 80 {
+			text = text[:80] + "…"
+		}
+		target = fmt.Sprintf(" %q", text)
+	}
+	return fmt.Sprintf("Nexus wants to %s%s on this computer.\n\nSession: %s", action, target, sessionID)
+}
+
+func formatCoordinate(coord []int) string {
+	if len(coord) < 2 {
+		return "the cursor"
+	}
+	return fmt.Sprintf("(%d, %d)", coord[0], coord[1])
+}
+
+// newLocalApprover returns a dialog-backed approver on macOS, or nil when
+// this machine cannot prompt.
+func newLocalApprover(title string) localApprover {
+	if runtime.GOOS != "darwin" {
+		return nil
+	}
+	if _, err := exec.LookPath("osascript"); err != nil {
+		return nil
+	}
+	return func(ctx context.Context, prompt string, timeout time.Duration) (approvalDecision, error) {
+		return macApprovalDialog(ctx, title, prompt, timeout)
+	}
+}
+
+// macApprovalDialog shows a dialog with Deny, Allow Once, and Allow for
+// Session buttons. Timing out denies.
+func macApprovalDialog(ctx context.Context, title, prompt string, timeout time.Duration) (approvalDecision, error) {
+	seconds := int(timeout.Seconds())
+	if seconds < 1 {
+		seconds = 1
+	}
+	script := fmt.Sprintf(`display dialog %s with title %s buttons {"Deny", "Allow Once", "Allow for Session"} default button "Deny" with icon caution giving up after %d`,
+		appleScriptString(prompt), appleScriptString(title), seconds)
+	output, err := exec.CommandContext(ctx, "osascript", "-e", script).Output()
+	if err != nil {
+		return approvalDenied, err
+	}
+	return parseDialogDecision(string(output)), nil
+}
+
+// parseDialogDecision reads osascript's "button returned:X, gave up:Y".
+func parseDialogDecision(output string) approvalDecision {
+	if strings.Contains(output, "gave up:true") {
+		return approvalDenied
+	}
+	switch {
+	case strings.Contains(output, "button returned:Allow for Session"):
+		return approvalSession
+	case strings.Contains(output, "button returned:Allow Once"):
+		return approvalOnce
+	default:
+		return approvalDenied
+	}
+}
+
+func appleScriptString(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, `"`, `\"`)
+	return `"` + s + `"`
+}
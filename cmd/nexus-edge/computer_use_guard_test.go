@@ -0,0 +1,133 @@
+package main
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	pb "github.com/haasonsaas/nexus/pkg/proto"
+)
+
+func newTestGuard(policy *ComputerUsePolicy, approve localApprover) *computerUseGuard {
+	guard := newComputerUseGuard(policy, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	guard.approve = approve
+	return guard
+}
+
+func TestComputerUseGuardRateLimit(t *testing.T) {
+	guard := newTestGuard(&ComputerUsePolicy{MaxActionsPerMinute: 2}, nil)
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	guard.now = func() time.Time { return now }
+
+	for i := 0; i < 2; i++ {
+		if err := guard.allow("s1"); err != nil {
+			t.Fatalf("allow() #%d error = %v", i, err)
+		}
+	}
+	if err := guard.allow("s1"); err == nil || !strings.Contains(err.Error(), "rate limit") {
+		t.Fatalf("expected rate limit error, got %v", err)
+	}
+	if err := guard.allow("s2"); err != nil {
+		t.Fatalf("other session should have its own budget: %v", err)
+	}
+	now = now.Add(61 * time.Second)
+	if err := guard.allow("s1"); err != nil {
+		t.Fatalf("expected budget to refill after a minute: %v", err)
+	}
+}
+
+func TestComputerUseGuardApproval(t *testing.T) {
+	prompts := 0
+	decision := approvalOnce
+	guard := newTestGuard(nil, func(ctx context.Context, prompt string, timeout time.Duration) (approvalDecision, error) {
+		prompts++
+		if !strings.Contains(prompt, "left_click at (10, 20)") {
+			t.Errorf("unexpected prompt %q", prompt)
+		}
+		return decision, nil
+	})
+	ctx := context.Background()
+	click := computerUseParams{Coordinate: []int{10, 20}}
+
+	if how, err := guard.authorize(ctx, "s1", "screenshot", computerUseParams{}, false); err != nil || how != "" {
+		t.Fatalf("screenshot should not need approval: %q %v", how, err)
+	}
+	if how, err := guard.authorize(ctx, "s1", "left_click", click, true); err != nil || how != "core" {
+		t.Fatalf("core approval not honoured: %q %v", how, err)
+	}
+	if prompts != 0 {
+		t.Fatalf("expected no prompts yet, got %d", prompts)
+	}
+
+	if how, err := guard.authorize(ctx, "s1", "left_click", click, false); err != nil || how != "once" {
+		t.Fatalf("authorize() = %q, %v; want once", how, err)
+	}
+	decision = approvalSession
+	if how, err := guard.authorize(ctx, "s1", "left_click", click, false); err != nil || how != "session" {
+		t.Fatalf("authorize() = %q, %v; want session", how, err)
+	}
+	if how, err := guard.authorize(ctx, "s1", "left_click", click, false); err != nil || how != "session" || prompts != 2 {
+		t.Fatalf("session grant not reused: %q, %v, prompts=%d", how, err, prompts)
+	}
+
+	decision = approvalDenied
+	if _, err := guard.authorize(ctx, "s2", "left_click", click, false); err == nil {
+		t.Fatal("expected denial")
+	}
+
+	noPrompt := newTestGuard(nil, nil)
+	if _, err := noPrompt.authorize(ctx, "s1", "type", computerUseParams{Text: "hi"}, false); err == nil {
+		t.Fatal("expected input actions to be refused without a way to approve")
+	}
+}
+
+func TestHandleComputerUseRecordsSession(t *testing.T) {
+	guard := newTestGuard(&ComputerUsePolicy{Denylist: []string{"right_click"}}, func(context.Context, string, time.Duration) (approvalDecision, error) {
+		return approvalDenied, nil
+	})
+	ctx := withToolRequest(context.Background(), &pb.ToolExecutionRequest{ExecutionId: "exec-1", SessionId: "session/1"})
+
+	result, err := handleComputerUse(ctx, `{"action":"wait","duration_ms":1}`, guard)
+	if err != nil || result.IsError {
+		t.Fatalf("wait: %v %+v", err, result)
+	}
+	result, _ = handleComputerUse(ctx, `{"action":"right_click","coordinate":[1,2]}`, guard)
+	if !result.IsError {
+		t.Fatal("expected right_click denied by policy")
+	}
+	result, _ = handleComputerUse(ctx, `{"action":"type","text":"secret"}`, guard)
+	if !result.IsError || !strings.Contains(result.Content, "denied") {
+		t.Fatalf("expected type denied at the machine, got %+v", result)
+	}
+
+	recording := result.Artifacts[len(result.Artifacts)-1]
+	if recording.Type != computerUseRecordingType || recording.Id != "computer-use-recording-session_1" {
+		t.Fatalf("unexpected recording artifact: %+v", recording)
+	}
+	lines := strings.Split(strings.TrimSpace(string(recording.Data)), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("expected 3 recorded actions, got %d:\n%s", len(lines), recording.Data)
+	}
+	for i, want := range []string{`"outcome":"ok"`, `"outcome":"denied"`, `"outcome":"denied"`} {
+		if !strings.Contains(lines[i], want) || !strings.Contains(lines[i], `"execution_id":"exec-1"`) {
+			t.Errorf("line %d = %s; want %s", i, lines[i], want)
+		}
+	}
+}
+
+func TestParseDialogDecision(t *testing.T) {
+	tests := map[string]approvalDecision{
+		"button returned:Allow Once, gave up:false\n":        approvalOnce,
+		"button returned:Allow for Session, gave up:false\n": approvalSession,
+		"button returned:Deny, gave up:false\n":              approvalDenied,
+		"button returned:, gave up:true\n":                   approvalDenied,
+	}
+	for output, want := range tests {
+		if got := parseDialogDecision(output); got != want {
+			t.Errorf("parseDialogDecision(%q) = %v, want %v", output, got, want)
+		}
+	}
+}
@@ -93,6 +93,18 @@ func mergeConfig(base, override Config) Config {
 	if len(override.ChannelTypes) > 0 {
 		base.ChannelTypes = override.ChannelTypes
 	}
+	if strings.TrimSpace(override.IMessage.DatabasePath) != "" {
+		base.IMessage.DatabasePath = override.IMessage.DatabasePath
+	}
+	if override.IMessage.PollInterval > 0 {
+		base.IMessage.PollInterval = override.IMessage.PollInterval
+	}
+	if strings.TrimSpace(override.Tracing.Endpoint) != "" {
+		base.Tracing = override.Tracing
+	}
+	if len(override.Nodes) > 0 {
+		base.Nodes = override.Nodes
+	}
 	if override.NodePolicy.Shell != nil {
 		if base.NodePolicy.Shell == nil {
 			base.NodePolicy.Shell = &ShellPolicy{}
@@ -114,6 +126,66 @@ func mergeConfig(base, override Config) Config {
 		if len(override.NodePolicy.ComputerUse.Denylist) > 0 {
 			base.NodePolicy.ComputerUse.Denylist = override.NodePolicy.ComputerUse.Denylist
 		}
+		if override.NodePolicy.ComputerUse.MaxActionsPerMinute != 0 {
+			base.NodePolicy.ComputerUse.MaxActionsPerMinute = override.NodePolicy.ComputerUse.MaxActionsPerMinute
+		}
+		if override.NodePolicy.ComputerUse.ApprovalTimeout > 0 {
+			base.NodePolicy.ComputerUse.ApprovalTimeout = override.NodePolicy.ComputerUse.ApprovalTimeout
+		}
+		if override.NodePolicy.ComputerUse.SessionApprovalTTL > 0 {
+			base.NodePolicy.ComputerUse.SessionApprovalTTL = override.NodePolicy.ComputerUse.SessionApprovalTTL
+		}
+		if strings.TrimSpace(override.NodePolicy.ComputerUse.RecordingDir) != "" {
+			base.NodePolicy.ComputerUse.RecordingDir = override.NodePolicy.ComputerUse.RecordingDir
+		}
+	}
+	if override.NodePolicy.Files != nil {
+		if base.NodePolicy.Files == nil {
+			base.NodePolicy.Files = &FilesPolicy{}
+		}
+		files := override.NodePolicy.Files
+		if len(files.Read) > 0 {
+			base.NodePolicy.Files.Read = files.Read
+		}
+		if len(files.Write) > 0 {
+			base.NodePolicy.Files.Write = files.Write
+		}
+		if len(files.Exclude) > 0 {
+			base.NodePolicy.Files.Exclude = files.Exclude
+		}
+		if files.MaxReadBytes > 0 {
+			base.NodePolicy.Files.MaxReadBytes = files.MaxReadBytes
+		}
+		if files.ApprovalTimeout > 0 {
+			base.NodePolicy.Files.ApprovalTimeout = files.ApprovalTimeout
+		}
+		if strings.TrimSpace(files.AuditLog) != "" {
+			base.NodePolicy.Files.AuditLog = files.AuditLog
+		}
+	}
+	if override.NodePolicy.Commands != nil {
+		if base.NodePolicy.Commands == nil {
+			base.NodePolicy.Commands = &CommandPolicy{}
+		}
+		commands := override.NodePolicy.Commands
+		if len(commands.Allowlist) > 0 {
+			base.NodePolicy.Commands.Allowlist = commands.Allowlist
+		}
+		if len(commands.Denylist) > 0 {
+			base.NodePolicy.Commands.Denylist = commands.Denylist
+		}
+		if strings.TrimSpace(commands.Shell) != "" {
+			base.NodePolicy.Commands.Shell = commands.Shell
+		}
+		if commands.MaxOutputBytes > 0 {
+			base.NodePolicy.Commands.MaxOutputBytes = commands.MaxOutputBytes
+		}
+		if commands.MaxTimeout > 0 {
+			base.NodePolicy.Commands.MaxTimeout = commands.MaxTimeout
+		}
+		if commands.ApprovalTimeout > 0 {
+			base.NodePolicy.Commands.ApprovalTimeout = commands.ApprovalTimeout
+		}
 	}
 	return base
 }
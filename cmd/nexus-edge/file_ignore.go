@@ -0,0 +1,147 @@
+package main
+
+import (
+	"bufio"
+	"os"
+	"path"
+	"regexp"
+	"strings"
+)
+
+// ignoreRule is one .gitignore-style pattern. base is the slash-separated
+// directory the pattern is relative to ("" for the search root).
+type ignoreRule struct {
+	base    string
+	re      *regexp.Regexp
+	negate  bool
+	dirOnly bool
+}
+
+// ignoreMatcher applies .gitignore semantics: the last matching rule wins,
+// "!" re-includes, a trailing "/" matches directories only, and patterns
+// without a slash match at any depth below their base.
+type ignoreMatcher struct {
+	rules []ignoreRule
+}
+
+// add parses patterns relative to base.
+func (m *ignoreMatcher) add(base string, patterns []string) {
+	for _, raw := range patterns {
+		line := strings.TrimRight(raw, " \t\r")
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		rule := ignoreRule{base: base}
+		if strings.HasPrefix(line, "!") {
+			rule.negate = true
+			line = line[1:]
+		} else if strings.HasPrefix(line, `\`) {
+			line = line[1:]
+		}
+		if strings.HasSuffix(line, "/") {
+			rule.dirOnly = true
+			line = strings.TrimRight(line, "/")
+		}
+		if line == "" {
+			continue
+		}
+		anchored := strings.Contains(line, "/")
+		line = strings.TrimPrefix(line, "/")
+		expr := globToRegexp(line)
+		if !anchored {
+			expr = "(?:.*/)?" + expr
+		}
+		re, err := regexp.Compile("^" + expr + "$")
+		if err != nil {
+			continue
+		}
+		rule.re = re
+		m.rules = append(m.rules, rule)
+	}
+}
+
+// addFile loads a .gitignore file relative to base. Missing files are fine.
+func (m *ignoreMatcher) addFile(base, file string) {
+	f, err := os.Open(file)
+	if err != nil {
+		return
+	}
+	defer f.Close()
+	var patterns []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		patterns = append(patterns, scanner.Text())
+	}
+	m.add(base, patterns)
+}
+
+// ignored reports whether the slash-separated path, relative to the search
+// root, is excluded.
+func (m *ignoreMatcher) ignored(rel string, isDir bool) bool {
+	excluded := false
+	for _, rule := range m.rules {
+		if rule.dirOnly && !isDir {
+			continue
+		}
+		target := rel
+		if rule.base != "" {
+			if !strings.HasPrefix(rel, rule.base+"/") {
+				continue
+			}
+			target = strings.TrimPrefix(rel, rule.base+"/")
+		}
+		if rule.re.MatchString(target) {
+			excluded = !rule.negate
+		}
+	}
+	return excluded
+}
+
+// globToRegexp converts a slash-separated glob to a regular expression. "*"
+// and "?" stay within one path segment, "**" spans segments, and bracket
+// classes are supported.
+func globToRegexp(glob string) string {
+	var b strings.Builder
+	for i := 0; i < len(glob); i++ {
+		c := glob[i]
+		switch c {
+		case '*':
+			if i+1 < len(glob) && glob[i+1] == '*' {
+				atStart := i == 0 || glob[i-1] == '/'
+				i++
+				if i+1 < len(glob) && glob[i+1] == '/' && atStart {
+					// "**/" matches zero or more directories.
+					i++
+					b.WriteString("(?:.*/)?")
+				} else {
+					b.WriteString(".*")
+				}
+				continue
+			}
+			b.WriteString("[^/]*")
+		case '?':
+			b.WriteString("[^/]")
+		case '[':
+			end := strings.IndexByte(glob[i+1:], ']')
+			if end < 0 {
+				b.WriteString(`\[`)
+				continue
+			}
+			class := glob[i+1 : i+1+end]
+			if strings.HasPrefix(class, "!") {
+				class = "^" + class[1:]
+			}
+			b.WriteString("[" + strings.ReplaceAll(class, `\`, `\\`) + "]")
+			i += end + 1
+		default:
+			b.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	return b.String()
+}
+
+// compileGlob compiles a glob matched against the whole relative path.
+func compileGlob(glob string) (*regexp.Regexp, error) {
+	glob = path.Clean(strings.TrimPrefix(glob, "./"))
+	return regexp.Compile("^" + globToRegexp(glob) + "$")
+}
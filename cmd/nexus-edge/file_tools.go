@@ -0,0 +1,609 @@
+package main
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	defaultFileMaxReadBytes    = 1 << 20
+	defaultFileApprovalTimeout = 60 * time.Second
+	defaultFileGlobResults     = 200
+	maxFileGlobResults         = 1000
+	// maxFileGlobVisited bounds how many entries one glob walks.
+	maxFileGlobVisited = 100000
+)
+
+// defaultFileExcludes are always left out of glob results.
+var defaultFileExcludes = []string{".git/"}
+
+type fileAccessMode string
+
+const (
+	fileAccessRead  fileAccessMode = "read"
+	fileAccessWrite fileAccessMode = "write"
+)
+
+// fileAuditEntry is one line of the file access audit log.
+type fileAuditEntry struct {
+	Time        time.Time      `json:"time"`
+	Tool        string         `json:"tool"`
+	Mode        fileAccessMode `json:"mode"`
+	Path        string         `json:"path"`
+	SessionID   string         `json:"session_id,omitempty"`
+	ExecutionID string         `json:"execution_id,omitempty"`
+	// Grant is how access was allowed: config, session, or once.
+	Grant string `json:"grant,omitempty"`
+	// Outcome is ok, denied, or error.
+	Outcome string `json:"outcome"`
+	Error   string `json:"error,omitempty"`
+	Bytes   int64  `json:"bytes,omitempty"`
+	Matches int    `json:"matches,omitempty"`
+}
+
+// fileAccessGuard enforces per-directory grants for the file tools and
+// audit-logs every access.
+type fileAccessGuard struct {
+	readDirs        []string
+	writeDirs       []string
+	exclude         []string
+	maxReadBytes    int64
+	approvalTimeout time.Duration
+	auditPath       string
+	approve         localApprover
+	logger          *slog.Logger
+	now             func() time.Time
+
+	// promptMu keeps a single approval prompt on screen at a time.
+	promptMu sync.Mutex
+
+	mu     sync.Mutex
+	grants map[string]fileAccessMode
+
+	auditMu sync.Mutex
+}
+
+func newFileAccessGuard(policy *FilesPolicy, logger *slog.Logger) *fileAccessGuard {
+	var effective FilesPolicy
+	if policy != nil {
+		effective = *policy
+	}
+	guard := &fileAccessGuard{
+		readDirs:        resolveGrantDirs(effective.Read),
+		writeDirs:       resolveGrantDirs(effective.Write),
+		exclude:         append(append([]string(nil), defaultFileExcludes...), effective.Exclude...),
+		maxReadBytes:    effective.MaxReadBytes,
+		approvalTimeout: effective.ApprovalTimeout,
+		auditPath:       expandUserPath(strings.TrimSpace(effective.AuditLog)),
+		approve:         newLocalApprover("Nexus file access"),
+		logger:          logger,
+		now:             time.Now,
+		grants:          make(map[string]fileAccessMode),
+	}
+	if guard.maxReadBytes <= 0 {
+		guard.maxReadBytes = defaultFileMaxReadBytes
+	}
+	if guard.approvalTimeout <= 0 {
+		guard.approvalTimeout = defaultFileApprovalTimeout
+	}
+	if guard.auditPath == "" {
+		if home, err := os.UserHomeDir(); err == nil && home != "" {
+			guard.auditPath = filepath.Join(home, defaultEdgeConfigDir, "file_audit.jsonl")
+		}
+	}
+	return guard
+}
+
+func resolveGrantDirs(dirs []string) []string {
+	out := make([]string, 0, len(dirs))
+	for _, dir := range dirs {
+		if strings.TrimSpace(dir) == "" {
+			continue
+		}
+		resolved, err := resolveFilePath(dir)
+		if err != nil {
+			continue
+		}
+		out = append(out, resolved)
+	}
+	return out
+}
+
+// resolveFilePath returns the absolute, symlink-free form of a path so grants
+// cannot be escaped through links. Paths that do not exist yet resolve
+// through their nearest existing ancestor.
+func resolveFilePath(raw string) (string, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return "", errors.New("path is required")
+	}
+	expanded := expandUserPath(raw)
+	if !filepath.IsAbs(expanded) {
+		return "", fmt.Errorf("path must be absolute or start with ~/: %s", raw)
+	}
+	clean := filepath.Clean(expanded)
+
+	existing := clean
+	var rest []string
+	for {
+		if _, err := os.Lstat(existing); err == nil {
+			break
+		}
+		parent := filepath.Dir(existing)
+		if parent == existing {
+			return clean, nil
+		}
+		rest = append([]string{filepath.Base(existing)}, rest...)
+		existing = parent
+	}
+	resolved, err := filepath.EvalSymlinks(existing)
+	if err != nil {
+		return "", fmt.Errorf("resolve %s: %w", raw, err)
+	}
+	return filepath.Join(append([]string{resolved}, rest...)...), nil
+}
+
+func withinDir(dir, target string) bool {
+	rel, err := filepath.Rel(dir, target)
+	if err != nil {
+		return false
+	}
+	return rel == "." || (rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)))
+}
+
+// authorize checks that dir may be accessed in mode. It returns how access
+// was granted: "config", "session", or "once".
+func (g *fileAccessGuard) authorize(ctx context.Context, mode fileAccessMode, dir, sessionID string) (string, error) {
+	if g.configured(mode, dir) {
+		return "config", nil
+	}
+	if g.hasGrant(mode, dir) {
+		return "session", nil
+	}
+	if g.approve == nil {
+		return "", fmt.Errorf("no %s grant for %s: add it to node_policy.files.%s in the edge config", mode, dir, mode)
+	}
+
+	g.promptMu.Lock()
+	defer g.promptMu.Unlock()
+	// Another prompt may have granted the directory while this one waited.
+	if g.hasGrant(mode, dir) {
+		return "session", nil
+	}
+	prompt := fmt.Sprintf("Nexus wants to %s files in:\n\n%s\n\n\"Allow for Session\" keeps this access until nexus-edge restarts.", mode, dir)
+	if sessionID != "" {
+		prompt += "\n\nSession: " + sessionID
+	}
+	decision, err := g.approve(ctx, prompt, g.approvalTimeout)
+	if err != nil {
+		return "", fmt.Errorf("approval prompt failed: %w", err)
+	}
+	switch decision {
+	case approvalSession:
+		g.mu.Lock()
+		if g.grants[dir] != fileAccessWrite {
+			g.grants[dir] = mode
+		}
+		g.mu.Unlock()
+		return "session", nil
+	case approvalOnce:
+		return "once", nil
+	default:
+		return "", fmt.Errorf("%s access to %s denied by the user at this machine", mode, dir)
+	}
+}
+
+func (g *fileAccessGuard) configured(mode fileAccessMode, dir string) bool {
+	for _, granted := range g.writeDirs {
+		if withinDir(granted, dir) {
+			return true
+		}
+	}
+	if mode == fileAccessWrite {
+		return false
+	}
+	for _, granted := range g.readDirs {
+		if withinDir(granted, dir) {
+			return true
+		}
+	}
+	return false
+}
+
+func (g *fileAccessGuard) hasGrant(mode fileAccessMode, dir string) bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	for granted, grantedMode := range g.grants {
+		if !withinDir(granted, dir) {
+			continue
+		}
+		if grantedMode == fileAccessWrite || mode == fileAccessRead {
+			return true
+		}
+	}
+	return false
+}
+
+// audit appends an entry to the audit log and the daemon log.
+func (g *fileAccessGuard) audit(ctx context.Context, entry fileAuditEntry) {
+	entry.Time = g.now()
+	if req := toolRequestFromContext(ctx); req != nil {
+		entry.SessionID = req.SessionId
+		entry.ExecutionID = req.ExecutionId
+	}
+	g.logger.Info("file access",
+		"tool", entry.Tool,
+		"mode", entry.Mode,
+		"path", entry.Path,
+		"grant", entry.Grant,
+		"outcome", entry.Outcome,
+		"session_id", entry.SessionID,
+	)
+	if g.auditPath == "" {
+		return
+	}
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return
+	}
+	g.auditMu.Lock()
+	defer g.auditMu.Unlock()
+	if err := os.MkdirAll(filepath.Dir(g.auditPath), 0o700); err != nil {
+		g.logger.Warn("failed to write file audit log", "path", g.auditPath, "error", err)
+		return
+	}
+	f, err := os.OpenFile(g.auditPath, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		g.logger.Warn("failed to write file audit log", "path", g.auditPath, "error", err)
+		return
+	}
+	defer f.Close()
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		g.logger.Warn("failed to write file audit log", "path", g.auditPath, "error", err)
+	}
+}
+
+// access resolves a path, authorizes its grant directory, and audits a
+// denial. The caller audits the outcome of the access itself.
+func (g *fileAccessGuard) access(ctx context.Context, tool string, mode fileAccessMode, raw string, grantDir func(string) string) (string, string, *ToolResult) {
+	sessionID := ""
+	if req := toolRequestFromContext(ctx); req != nil {
+		sessionID = req.SessionId
+	}
+	resolved, err := resolveFilePath(raw)
+	if err != nil {
+		return "", "", &ToolResult{Content: err.Error(), IsError: true}
+	}
+	grant, err := g.authorize(ctx, mode, grantDir(resolved), sessionID)
+	if err != nil {
+		g.audit(ctx, fileAuditEntry{Tool: tool, Mode: mode, Path: resolved, Outcome: "denied", Error: err.Error()})
+		return "", "", &ToolResult{Content: err.Error(), IsError: true}
+	}
+	return resolved, grant, nil
+}
+
+func fileResult(result map[string]any) (*ToolResult, error) {
+	payload, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("encode result: %w", err)
+	}
+	return &ToolResult{Content: string(payload)}, nil
+}
+
+// fileReadTool reads a file inside a granted directory.
+func fileReadTool(guard *fileAccessGuard) *Tool {
+	return &Tool{
+		Name:        "nodes.file_read",
+		Description: "Read a file on this machine. The file's directory must be granted in the edge config or approved on the machine. Binary files are returned base64-encoded.",
+		InputSchema: `{
+			"type": "object",
+			"properties": {
+				"path": {"type": "string", "description": "Absolute path or ~/ path to the file"},
+				"offset": {"type": "integer", "minimum": 0, "description": "Byte offset to start reading from (default: 0)"},
+				"max_bytes": {"type": "integer", "minimum": 0, "description": "Maximum bytes to read (capped by edge policy)"}
+			},
+			"required": ["path"]
+		}`,
+		TimeoutSeconds: 30,
+		Handler: func(ctx context.Context, input string) (*ToolResult, error) {
+			return handleFileRead(ctx, input, guard)
+		},
+	}
+}
+
+func handleFileRead(ctx context.Context, input string, guard *fileAccessGuard) (*ToolResult, error) {
+	var params struct {
+		Path     string `json:"path"`
+		Offset   int64  `json:"offset"`
+		MaxBytes int64  `json:"max_bytes"`
+	}
+	if err := json.Unmarshal([]byte(input), &params); err != nil {
+		return nil, fmt.Errorf("invalid params: %w", err)
+	}
+	if params.Offset < 0 {
+		return &ToolResult{Content: "offset must be >= 0", IsError: true}, nil
+	}
+	const tool = "nodes.file_read"
+	resolved, grant, denied := guard.access(ctx, tool, fileAccessRead, params.Path, filepath.Dir)
+	if denied != nil {
+		return denied, nil
+	}
+	entry := fileAuditEntry{Tool: tool, Mode: fileAccessRead, Path: resolved, Grant: grant}
+	fail := func(err error) (*ToolResult, error) {
+		entry.Outcome = "error"
+		entry.Error = err.Error()
+		guard.audit(ctx, entry)
+		return &ToolResult{Content: err.Error(), IsError: true}, nil
+	}
+
+	file, err := os.Open(resolved)
+	if err != nil {
+		return fail(err)
+	}
+	defer file.Close()
+	info, err := file.Stat()
+	if err != nil {
+		return fail(err)
+	}
+	if info.IsDir() {
+		return fail(fmt.Errorf("%s is a directory; use nodes.file_glob to list it", resolved))
+	}
+	if params.Offset > 0 {
+		if _, err := file.Seek(params.Offset, io.SeekStart); err != nil {
+			return fail(err)
+		}
+	}
+	limit := guard.maxReadBytes
+	if params.MaxBytes > 0 && params.MaxBytes < limit {
+		limit = params.MaxBytes
+	}
+	buf, err := io.ReadAll(io.LimitReader(file, limit))
+	if err != nil {
+		return fail(err)
+	}
+
+	entry.Outcome = "ok"
+	entry.Bytes = int64(len(buf))
+	guard.audit(ctx, entry)
+
+	result := map[string]any{
+		"path":      resolved,
+		"offset":    params.Offset,
+		"bytes":     len(buf),
+		"size":      info.Size(),
+		"truncated": params.Offset+int64(len(buf)) < info.Size(),
+	}
+	if utf8.Valid(buf) {
+		result["content"] = string(buf)
+	} else {
+		result["content"] = base64.StdEncoding.EncodeToString(buf)
+		result["encoding"] = "base64"
+	}
+	return fileResult(result)
+}
+
+// fileWriteTool writes a file inside a directory granted for writing.
+func fileWriteTool(guard *fileAccessGuard) *Tool {
+	return &Tool{
+		Name:        "nodes.file_write",
+		Description: "Write a file on this machine, creating parent directories as needed. The directory must be granted for writing in the edge config or approved on the machine.",
+		InputSchema: `{
+			"type": "object",
+			"properties": {
+				"path": {"type": "string", "description": "Absolute path or ~/ path to the file"},
+				"content": {"type": "string", "description": "File contents"},
+				"encoding": {"type": "string", "enum": ["utf8", "base64"], "description": "Encoding of content (default: utf8)"},
+				"append": {"type": "boolean", "description": "Append instead of overwrite (default: false)"}
+			},
+			"required": ["path", "content"]
+		}`,
+		RequiresApproval: true,
+		TimeoutSeconds:   30,
+		Handler: func(ctx context.Context, input string) (*ToolResult, error) {
+			return handleFileWrite(ctx, input, guard)
+		},
+	}
+}
+
+func handleFileWrite(ctx context.Context, input string, guard *fileAccessGuard) (*ToolResult, error) {
+	var params struct {
+		Path     string `json:"path"`
+		Content  string `json:"content"`
+		Encoding string `json:"encoding"`
+		Append   bool   `json:"append"`
+	}
+	if err := json.Unmarshal([]byte(input), &params); err != nil {
+		return nil, fmt.Errorf("invalid params: %w", err)
+	}
+	data := []byte(params.Content)
+	switch params.Encoding {
+	case "", "utf8":
+	case "base64":
+		decoded, err := base64.StdEncoding.DecodeString(params.Content)
+		if err != nil {
+			return &ToolResult{Content: fmt.Sprintf("invalid base64 content: %v", err), IsError: true}, nil
+		}
+		data = decoded
+	default:
+		return &ToolResult{Content: fmt.Sprintf("unsupported encoding %q", params.Encoding), IsError: true}, nil
+	}
+
+	const tool = "nodes.file_write"
+	resolved, grant, denied := guard.access(ctx, tool, fileAccessWrite, params.Path, filepath.Dir)
+	if denied != nil {
+		return denied, nil
+	}
+	entry := fileAuditEntry{Tool: tool, Mode: fileAccessWrite, Path: resolved, Grant: grant}
+	fail := func(err error) (*ToolResult, error) {
+		entry.Outcome = "error"
+		entry.Error = err.Error()
+		guard.audit(ctx, entry)
+		return &ToolResult{Content: err.Error(), IsError: true}, nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(resolved), 0o755); err != nil {
+		return fail(err)
+	}
+	flags := os.O_CREATE | os.O_WRONLY
+	if params.Append {
+		flags |= os.O_APPEND
+	} else {
+		flags |= os.O_TRUNC
+	}
+	file, err := os.OpenFile(resolved, flags, 0o644)
+	if err != nil {
+		return fail(err)
+	}
+	n, err := file.Write(data)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fail(err)
+	}
+
+	entry.Outcome = "ok"
+	entry.Bytes = int64(n)
+	guard.audit(ctx, entry)
+	return fileResult(map[string]any{
+		"path":          resolved,
+		"bytes_written": n,
+		"append":        params.Append,
+	})
+}
+
+// fileGlobTool lists files under a granted directory.
+func fileGlobTool(guard *fileAccessGuard) *Tool {
+	return &Tool{
+		Name:        "nodes.file_glob",
+		Description: "Find files on this machine matching a glob (supports ** across directories). Results skip paths excluded by .gitignore files and the edge's exclude patterns. The root must be granted in the edge config or approved on the machine.",
+		InputSchema: `{
+			"type": "object",
+			"properties": {
+				"root": {"type": "string", "description": "Absolute path or ~/ path of the directory to search"},
+				"pattern": {"type": "string", "description": "Glob relative to root, e.g. **/*.go (default: **/*)"},
+				"max_results": {"type": "integer", "minimum": 1, "description": "Maximum paths to return (default: 200, max: 1000)"}
+			},
+			"required": ["root"]
+		}`,
+		TimeoutSeconds: 60,
+		Handler: func(ctx context.Context, input string) (*ToolResult, error) {
+			return handleFileGlob(ctx, input, guard)
+		},
+	}
+}
+
+func handleFileGlob(ctx context.Context, input string, guard *fileAccessGuard) (*ToolResult, error) {
+	var params struct {
+		Root       string `json:"root"`
+		Pattern    string `json:"pattern"`
+		MaxResults int    `json:"max_results"`
+	}
+	if err := json.Unmarshal([]byte(input), &params); err != nil {
+		return nil, fmt.Errorf("invalid params: %w", err)
+	}
+	pattern := strings.TrimSpace(params.Pattern)
+	if pattern == "" {
+		pattern = "**/*"
+	}
+	if strings.HasPrefix(pattern, "/") || strings.HasPrefix(pattern, "../") || strings.Contains(pattern, "/../") {
+		return &ToolResult{Content: "pattern must be relative to root", IsError: true}, nil
+	}
+	matcher, err := compileGlob(pattern)
+	if err != nil {
+		return &ToolResult{Content: fmt.Sprintf("invalid pattern: %v", err), IsError: true}, nil
+	}
+	limit := params.MaxResults
+	if limit <= 0 {
+		limit = defaultFileGlobResults
+	}
+	if limit > maxFileGlobResults {
+		limit = maxFileGlobResults
+	}
+
+	const tool = "nodes.file_glob"
+	root, grant, denied := guard.access(ctx, tool, fileAccessRead, params.Root, func(dir string) string { return dir })
+	if denied != nil {
+		return denied, nil
+	}
+	entry := fileAuditEntry{Tool: tool, Mode: fileAccessRead, Path: filepath.Join(root, pattern), Grant: grant}
+
+	ignore := &ignoreMatcher{}
+	ignore.add("", guard.exclude)
+	matches := make([]string, 0)
+	truncated := false
+	visited := 0
+	walkErr := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if path == root {
+				return err
+			}
+			// Skip unreadable entries rather than failing the whole glob.
+			if d != nil && d.IsDir() {
+				return fs.SkipDir
+			}
+			return nil
+		}
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return ctxErr
+		}
+		visited++
+		if visited > maxFileGlobVisited {
+			truncated = true
+			return fs.SkipAll
+		}
+		rel, relErr := filepath.Rel(root, path)
+		if relErr != nil {
+			return nil
+		}
+		rel = filepath.ToSlash(rel)
+		if d.IsDir() {
+			if rel != "." {
+				if ignore.ignored(rel, true) {
+					return fs.SkipDir
+				}
+			} else {
+				rel = ""
+			}
+			ignore.addFile(rel, filepath.Join(path, ".gitignore"))
+			return nil
+		}
+		if ignore.ignored(rel, false) || !matcher.MatchString(rel) {
+			return nil
+		}
+		if len(matches) >= limit {
+			truncated = true
+			return fs.SkipAll
+		}
+		matches = append(matches, rel)
+		return nil
+	})
+	if walkErr != nil {
+		entry.Outcome = "error"
+		entry.Error = walkErr.Error()
+		guard.audit(ctx, entry)
+		return &ToolResult{Content: walkErr.Error(), IsError: true}, nil
+	}
+
+	entry.Outcome = "ok"
+	entry.Matches = len(matches)
+	guard.audit(ctx, entry)
+	return fileResult(map[string]any{
+		"root":      root,
+		"pattern":   pattern,
+		"matches":   matches,
+		"truncated": truncated,
+	})
+}
@@ -0,0 +1,251 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	pb "github.com/haasonsaas/nexus/pkg/proto"
+)
+
+func newTestFileGuard(t *testing.T, policy FilesPolicy, approve localApprover) *fileAccessGuard {
+	t.Helper()
+	if policy.AuditLog == "" {
+		policy.AuditLog = filepath.Join(t.TempDir(), "audit.jsonl")
+	}
+	guard := newFileAccessGuard(&policy, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	guard.approve = approve
+	return guard
+}
+
+func writeTestFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestIgnoreMatcher(t *testing.T) {
+	m := &ignoreMatcher{}
+	m.add("", []string{"# comment", "*.log", "!keep.log", "build/", "/root.txt", "docs/**/draft.md"})
+	m.add("sub", []string{"local.txt"})
+
+	tests := []struct {
+		path  string
+		isDir bool
+		want  bool
+	}{
+		{"app.log", false, true},
+		{"nested/deep/app.log", false, true},
+		{"keep.log", false, false},
+		{"build", true, true},
+		{"build", false, false},
+		{"src/build", true, true},
+		{"root.txt", false, true},
+		{"src/root.txt", false, false},
+		{"docs/draft.md", false, true},
+		{"docs/a/b/draft.md", false, true},
+		{"sub/local.txt", false, true},
+		{"sub/x/local.txt", false, true},
+		{"local.txt", false, false},
+		{"main.go", false, false},
+	}
+	for _, tt := range tests {
+		if got := m.ignored(tt.path, tt.isDir); got != tt.want {
+			t.Errorf("ignored(%q, dir=%v) = %v, want %v", tt.path, tt.isDir, got, tt.want)
+		}
+	}
+}
+
+func TestCompileGlob(t *testing.T) {
+	tests := []struct {
+		glob, path string
+		want       bool
+	}{
+		{"**/*.go", "main.go", true},
+		{"**/*.go", "cmd/edge/main.go", true},
+		{"*.go", "cmd/main.go", false},
+		{"cmd/*/main.go", "cmd/edge/main.go", true},
+		{"./src/[a-c]?.txt", "src/b1.txt", true},
+		{"src/[!a-c]?.txt", "src/b1.txt", false},
+	}
+	for _, tt := range tests {
+		re, err := compileGlob(tt.glob)
+		if err != nil {
+			t.Fatalf("compileGlob(%q): %v", tt.glob, err)
+		}
+		if got := re.MatchString(tt.path); got != tt.want {
+			t.Errorf("%q matching %q = %v, want %v", tt.glob, tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestFileGlobRespectsIgnores(t *testing.T) {
+	root := t.TempDir()
+	writeTestFile(t, filepath.Join(root, ".gitignore"), "*.log\nvendor/\n")
+	writeTestFile(t, filepath.Join(root, "main.go"), "package main")
+	writeTestFile(t, filepath.Join(root, "debug.log"), "x")
+	writeTestFile(t, filepath.Join(root, "vendor", "dep.go"), "package dep")
+	writeTestFile(t, filepath.Join(root, "pkg", ".gitignore"), "gen.go\n")
+	writeTestFile(t, filepath.Join(root, "pkg", "lib.go"), "package pkg")
+	writeTestFile(t, filepath.Join(root, "pkg", "gen.go"), "package pkg")
+	writeTestFile(t, filepath.Join(root, "secrets", "key.go"), "package secrets")
+	writeTestFile(t, filepath.Join(root, ".git", "hooks.go"), "package git")
+
+	guard := newTestFileGuard(t, FilesPolicy{Read: []string{root}, Exclude: []string{"secrets/"}}, nil)
+	input, _ := json.Marshal(map[string]any{"root": root, "pattern": "**/*.go"})
+	result, err := handleFileGlob(context.Background(), string(input), guard)
+	if err != nil || result.IsError {
+		t.Fatalf("handleFileGlob() = %+v, %v", result, err)
+	}
+	var out struct {
+		Matches []string `json:"matches"`
+	}
+	if err := json.Unmarshal([]byte(result.Content), &out); err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"main.go", "pkg/lib.go"}
+	if !reflect.DeepEqual(out.Matches, want) {
+		t.Fatalf("matches = %v, want %v", out.Matches, want)
+	}
+}
+
+func TestFileReadWriteWithConfiguredGrants(t *testing.T) {
+	readDir := t.TempDir()
+	writeDir := t.TempDir()
+	writeTestFile(t, filepath.Join(readDir, "notes.txt"), "hello world")
+	guard := newTestFileGuard(t, FilesPolicy{Read: []string{readDir}, Write: []string{writeDir}}, nil)
+	ctx := withToolRequest(context.Background(), &pb.ToolExecutionRequest{SessionId: "s1", ExecutionId: "e1"})
+
+	input, _ := json.Marshal(map[string]any{"path": filepath.Join(readDir, "notes.txt"), "max_bytes": 5})
+	result, err := handleFileRead(ctx, string(input), guard)
+	if err != nil || result.IsError {
+		t.Fatalf("handleFileRead() = %+v, %v", result, err)
+	}
+	var read struct {
+		Content   string `json:"content"`
+		Truncated bool   `json:"truncated"`
+	}
+	if err := json.Unmarshal([]byte(result.Content), &read); err != nil {
+		t.Fatal(err)
+	}
+	if read.Content != "hello" || !read.Truncated {
+		t.Fatalf("unexpected read result %+v", read)
+	}
+
+	target := filepath.Join(writeDir, "out", "result.txt")
+	input, _ = json.Marshal(map[string]any{"path": target, "content": "written"})
+	if result, err := handleFileWrite(ctx, string(input), guard); err != nil || result.IsError {
+		t.Fatalf("handleFileWrite() = %+v, %v", result, err)
+	}
+	if data, _ := os.ReadFile(target); string(data) != "written" {
+		t.Fatalf("file content = %q", data)
+	}
+
+	// Read grants do not allow writes.
+	input, _ = json.Marshal(map[string]any{"path": filepath.Join(readDir, "new.txt"), "content": "x"})
+	if result, _ := handleFileWrite(ctx, string(input), guard); !result.IsError {
+		t.Fatal("expected write to a read-only directory to be denied")
+	}
+
+	audit, err := os.ReadFile(guard.auditPath)
+	if err != nil {
+		t.Fatalf("read audit log: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(audit)), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("expected 3 audit entries, got %d:\n%s", len(lines), audit)
+	}
+	var entry fileAuditEntry
+	if err := json.Unmarshal([]byte(lines[0]), &entry); err != nil {
+		t.Fatal(err)
+	}
+	if entry.Tool != "nodes.file_read" || entry.Grant != "config" || entry.Outcome != "ok" || entry.SessionID != "s1" || entry.Bytes != 5 {
+		t.Fatalf("unexpected audit entry %+v", entry)
+	}
+	if err := json.Unmarshal([]byte(lines[2]), &entry); err != nil {
+		t.Fatal(err)
+	}
+	if entry.Outcome != "denied" || entry.Mode != fileAccessWrite {
+		t.Fatalf("unexpected denial audit entry %+v", entry)
+	}
+}
+
+func TestFileAccessInteractiveGrants(t *testing.T) {
+	dir := t.TempDir()
+	writeTestFile(t, filepath.Join(dir, "a.txt"), "a")
+	writeTestFile(t, filepath.Join(dir, "sub", "b.txt"), "b")
+	ctx := context.Background()
+	read := func(guard *fileAccessGuard, path string) *ToolResult {
+		input, _ := json.Marshal(map[string]any{"path": path})
+		result, err := handleFileRead(ctx, string(input), guard)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return result
+	}
+
+	denied := newTestFileGuard(t, FilesPolicy{}, nil)
+	if result := read(denied, filepath.Join(dir, "a.txt")); !result.IsError || !strings.Contains(result.Content, "node_policy.files.read") {
+		t.Fatalf("expected denial without a grant, got %+v", result)
+	}
+
+	prompts := 0
+	decision := approvalOnce
+	guard := newTestFileGuard(t, FilesPolicy{}, func(ctx context.Context, prompt string, timeout time.Duration) (approvalDecision, error) {
+		prompts++
+		return decision, nil
+	})
+	if result := read(guard, filepath.Join(dir, "a.txt")); result.IsError {
+		t.Fatalf("allow once: %s", result.Content)
+	}
+	decision = approvalSession
+	read(guard, filepath.Join(dir, "a.txt"))
+	decision = approvalDenied
+	if result := read(guard, filepath.Join(dir, "sub", "b.txt")); result.IsError {
+		t.Fatalf("session grant should cover subdirectories: %s", result.Content)
+	}
+	if prompts != 2 {
+		t.Fatalf("expected 2 prompts, got %d", prompts)
+	}
+
+	// A read grant does not cover writes.
+	input, _ := json.Marshal(map[string]any{"path": filepath.Join(dir, "c.txt"), "content": "c"})
+	if result, _ := handleFileWrite(ctx, string(input), guard); !result.IsError {
+		t.Fatal("expected write to prompt and be denied")
+	}
+}
+
+func TestFileAccessRejectsSymlinkEscape(t *testing.T) {
+	granted := t.TempDir()
+	outside := t.TempDir()
+	writeTestFile(t, filepath.Join(outside, "secret.txt"), "secret")
+	if err := os.Symlink(outside, filepath.Join(granted, "link")); err != nil {
+		t.Skipf("symlinks unsupported: %v", err)
+	}
+	guard := newTestFileGuard(t, FilesPolicy{Read: []string{granted}}, nil)
+
+	input, _ := json.Marshal(map[string]any{"path": filepath.Join(granted, "link", "secret.txt")})
+	result, err := handleFileRead(context.Background(), string(input), guard)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !result.IsError {
+		t.Fatalf("expected symlink escape to be denied, got %s", result.Content)
+	}
+
+	input, _ = json.Marshal(map[string]any{"path": "relative/path.txt"})
+	if result, _ := handleFileRead(context.Background(), string(input), guard); !result.IsError {
+		t.Fatal("expected relative path to be rejected")
+	}
+}
@@ -25,22 +25,33 @@ import (
 	"os/signal"
 	"runtime"
 	"strings"
+	"sync"
 	"syscall"
 	"time"
 
 	"github.com/spf13/cobra"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/grpc/keepalive"
 	"google.golang.org/protobuf/types/known/structpb"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
+	"github.com/haasonsaas/nexus/internal/edge"
+	"github.com/haasonsaas/nexus/internal/observability"
 	pb "github.com/haasonsaas/nexus/pkg/proto"
 )
 
 // Version is set at build time.
 var Version = "dev"
 
+// toolTracer records tool executions. It resolves to the global provider,
+// which exports only when tracing is configured.
+var toolTracer = otel.Tracer("github.com/haasonsaas/nexus/cmd/nexus-edge")
+
 // Config holds edge daemon configuration.
 type Config struct {
 	// CoreURL is the address of the Nexus core.
@@ -70,8 +81,43 @@ type Config struct {
 	// ChannelTypes lists channel types this edge can host (e.g., "imessage", "signal").
 	ChannelTypes []string `json:"channel_types" yaml:"channel_types"`
 
+	// Nodes declares the devices this edge fronts (for example a paired phone)
+	// and which of its tools belong to each. When empty the edge registers as a
+	// single node named after EdgeID.
+	Nodes []edge.NodeDescriptor `json:"nodes,omitempty" yaml:"nodes,omitempty"`
+
 	// NodePolicy controls local tool execution policies.
 	NodePolicy NodePolicy `json:"node_policy,omitempty" yaml:"node_policy,omitempty"`
+
+	// IMessage configures the iMessage channel when "imessage" is listed in
+	// ChannelTypes.
+	IMessage IMessageChannelConfig `json:"imessage,omitempty" yaml:"imessage,omitempty"`
+
+	// Tracing exports spans for tool executions. Trace context from the core
+	// is always honored; spans are only exported when an endpoint is set.
+	Tracing TracingConfig `json:"tracing,omitempty" yaml:"tracing,omitempty"`
+}
+
+// TracingConfig configures OTLP span export from the edge.
+type TracingConfig struct {
+	// Endpoint is the OTLP gRPC collector address, e.g. localhost:4317.
+	Endpoint string `json:"endpoint,omitempty" yaml:"endpoint,omitempty"`
+
+	// Insecure disables TLS to the collector.
+	Insecure bool `json:"insecure,omitempty" yaml:"insecure,omitempty"`
+
+	// SamplingRate applies to traces started on the edge; tool executions
+	// follow the core's sampling decision (default 1.0).
+	SamplingRate float64 `json:"sampling_rate,omitempty" yaml:"sampling_rate,omitempty"`
+}
+
+// IMessageChannelConfig configures the edge-hosted iMessage channel.
+type IMessageChannelConfig struct {
+	// DatabasePath is the Messages database (default ~/Library/Messages/chat.db).
+	DatabasePath string `json:"database_path,omitempty" yaml:"database_path,omitempty"`
+
+	// PollInterval is how often the database is checked for new messages.
+	PollInterval time.Duration `json:"poll_interval,omitempty" yaml:"poll_interval,omitempty"`
 }
 
 // DefaultConfig returns sensible defaults.
@@ -100,6 +146,17 @@ type EdgeDaemon struct {
 	stream      pb.EdgeService_ConnectClient
 	startTime   time.Time
 	activeCalls map[string]context.CancelFunc
+
+	// sendMu serializes writes to the stream; gRPC streams do not allow
+	// concurrent sends.
+	sendMu sync.Mutex
+
+	// Hosted channels relayed to the core.
+	channels            map[string]hostedChannel
+	unavailableChannels map[string]bool
+	channelMu           sync.Mutex
+	channelReady        bool
+	channelOutbox       []*pb.EdgeChannelInbound
 }
 
 // Tool represents a tool provided by this edge.
@@ -131,6 +188,9 @@ func NewEdgeDaemon(config Config, logger *slog.Logger) *EdgeDaemon {
 		tools:       make([]*Tool, 0),
 		activeCalls: make(map[string]context.CancelFunc),
 		startTime:   time.Now(),
+
+		channels:            make(map[string]hostedChannel),
+		unavailableChannels: make(map[string]bool),
 	}
 }
 
@@ -141,6 +201,9 @@ func (d *EdgeDaemon) RegisterTool(tool *Tool) {
 
 // Run starts the edge daemon and blocks until stopped.
 func (d *EdgeDaemon) Run(ctx context.Context) error {
+	d.startChannels(ctx)
+	defer d.stopChannels()
+
 	for {
 		select {
 		case <-ctx.Done():
@@ -190,7 +253,14 @@ func (d *EdgeDaemon) connect(ctx context.Context) error {
 	if err != nil {
 		return fmt.Errorf("failed to open stream: %w", err)
 	}
+	d.sendMu.Lock()
 	d.stream = stream
+	d.sendMu.Unlock()
+	defer func() {
+		d.sendMu.Lock()
+		d.stream = nil
+		d.sendMu.Unlock()
+	}()
 
 	// Send registration
 	if err := d.register(); err != nil {
@@ -222,6 +292,10 @@ func (d *EdgeDaemon) connect(ctx context.Context) error {
 		d.config.HeartbeatInterval = time.Duration(registered.HeartbeatIntervalSeconds) * time.Second
 	}
 
+	// Relay channel messages queued while disconnected
+	d.setChannelsReady(true)
+	defer d.setChannelsReady(false)
+
 	// Start heartbeat goroutine
 	heartbeatCtx, cancelHeartbeat := context.WithCancel(ctx)
 	defer cancelHeartbeat()
@@ -233,7 +307,7 @@ func (d *EdgeDaemon) connect(ctx context.Context) error {
 
 // register sends the registration message.
 func (d *EdgeDaemon) register() error {
-	channelTypes := normalizeChannelTypes(d.config.ChannelTypes)
+	channelTypes := d.advertisedChannelTypes()
 	toolDefs := make([]*pb.EdgeToolDefinition, len(d.tools))
 	for i, t := range d.tools {
 		timeoutSeconds := t.TimeoutSeconds
@@ -253,7 +327,7 @@ func (d *EdgeDaemon) register() error {
 		}
 	}
 
-	return d.stream.Send(&pb.EdgeMessage{
+	return d.send(&pb.EdgeMessage{
 		Message: &pb.EdgeMessage_Register{
 			Register: &pb.EdgeRegister{
 				EdgeId:       d.config.EdgeID,
@@ -272,7 +346,7 @@ func (d *EdgeDaemon) register() error {
 					"os":       runtime.GOOS,
 					"arch":     runtime.GOARCH,
 					"hostname": d.config.Name,
-				}, computerUseMetadata()),
+				}, mergeMetadata(computerUseMetadata(), nodesMetadata(d.config.Nodes, d.logger))),
 			},
 		},
 	})
@@ -311,6 +385,19 @@ func mergeMetadata(base, extra map[string]string) map[string]string {
 	return base
 }
 
+// nodesMetadata encodes the configured node declarations for registration.
+func nodesMetadata(nodes []edge.NodeDescriptor, logger *slog.Logger) map[string]string {
+	if len(nodes) == 0 {
+		return nil
+	}
+	data, err := json.Marshal(nodes)
+	if err != nil {
+		logger.Warn("failed to encode node declarations", "error", err)
+		return nil
+	}
+	return map[string]string{edge.NodesMetadataKey: string(data)}
+}
+
 // heartbeatLoop sends periodic heartbeats.
 func (d *EdgeDaemon) heartbeatLoop(ctx context.Context) {
 	ticker := time.NewTicker(d.config.HeartbeatInterval)
@@ -335,7 +422,7 @@ func (d *EdgeDaemon) sendHeartbeat() error {
 		activeTools = append(activeTools, name)
 	}
 
-	return d.stream.Send(&pb.EdgeMessage{
+	return d.send(&pb.EdgeMessage{
 		Message: &pb.EdgeMessage_Heartbeat{
 			Heartbeat: &pb.EdgeHeartbeat{
 				EdgeId:    d.config.EdgeID,
@@ -368,6 +455,9 @@ func (d *EdgeDaemon) handleMessages(ctx context.Context) error {
 
 		case *pb.CoreMessage_Event:
 			d.handleCoreEvent(payload.Event)
+
+		case *pb.CoreMessage_ChannelOutbound:
+			go d.handleChannelOutbound(ctx, payload.ChannelOutbound)
 		}
 	}
 }
@@ -376,6 +466,19 @@ func (d *EdgeDaemon) handleMessages(ctx context.Context) error {
 func (d *EdgeDaemon) handleToolRequest(ctx context.Context, req *pb.ToolExecutionRequest) {
 	startTime := time.Now()
 
+	// Continue the core's trace so the execution shows up as a child of
+	// the core's edge.tool span.
+	ctx, span := toolTracer.Start(observability.ExtractTraceContext(ctx, req.Metadata), "edge.execute "+req.ToolName,
+		trace.WithSpanKind(trace.SpanKindServer),
+		trace.WithAttributes(
+			attribute.String("edge.id", d.config.EdgeID),
+			attribute.String("tool.name", req.ToolName),
+			attribute.String("tool.execution_id", req.ExecutionId),
+			attribute.String("run_id", req.RunId),
+		),
+	)
+	defer span.End()
+
 	// Find the tool
 	var tool *Tool
 	for _, t := range d.tools {
@@ -418,13 +521,17 @@ func (d *EdgeDaemon) handleToolRequest(ctx context.Context, req *pb.ToolExecutio
 	)
 
 	// Execute the tool
-	result, err := tool.Handler(toolCtx, req.Input)
+	result, err := tool.Handler(withToolRequest(toolCtx, req), req.Input)
 	if err != nil {
+		span.RecordError(err)
 		result = &ToolResult{
 			Content: fmt.Sprintf("tool execution error: %v", err),
 			IsError: true,
 		}
 	}
+	if result.IsError {
+		span.SetStatus(codes.Error, "tool returned an error")
+	}
 
 	// Send result
 	d.sendToolResult(req.ExecutionId, result, time.Since(startTime))
@@ -443,9 +550,33 @@ func (d *EdgeDaemon) handleToolRequest(ctx context.Context, req *pb.ToolExecutio
 	}
 }
 
+// toolRequestKey carries the execution request into tool handlers.
+type toolRequestKey struct{}
+
+func withToolRequest(ctx context.Context, req *pb.ToolExecutionRequest) context.Context {
+	return context.WithValue(ctx, toolRequestKey{}, req)
+}
+
+// toolRequestFromContext returns the request being executed, or nil outside
+// a tool call.
+func toolRequestFromContext(ctx context.Context) *pb.ToolExecutionRequest {
+	req, _ := ctx.Value(toolRequestKey{}).(*pb.ToolExecutionRequest)
+	return req
+}
+
+// send writes a message to the core stream.
+func (d *EdgeDaemon) send(msg *pb.EdgeMessage) error {
+	d.sendMu.Lock()
+	defer d.sendMu.Unlock()
+	if d.stream == nil {
+		return errors.New("not connected to core")
+	}
+	return d.stream.Send(msg)
+}
+
 // sendToolResult sends the tool result back to the core.
 func (d *EdgeDaemon) sendToolResult(execID string, result *ToolResult, duration time.Duration) {
-	if err := d.stream.Send(&pb.EdgeMessage{
+	if err := d.send(&pb.EdgeMessage{
 		Message: &pb.EdgeMessage_ToolResult{
 			ToolResult: &pb.ToolExecutionResult{
 				ExecutionId: execID,
@@ -497,7 +628,7 @@ func (d *EdgeDaemon) sendEvent(eventType pb.EdgeEventType, data map[string]inter
 		}
 		payload = converted
 	}
-	return d.stream.Send(&pb.EdgeMessage{
+	return d.send(&pb.EdgeMessage{
 		Message: &pb.EdgeMessage_Event{
 			Event: &pb.EdgeEvent{
 				EdgeId:    d.config.EdgeID,
@@ -560,6 +691,24 @@ local capabilities like device access, browser relay, and edge-only channels.`,
 				Level: level,
 			}))
 
+			if config.Tracing.Endpoint != "" {
+				_, shutdownTracing := observability.NewTracer(observability.TraceConfig{
+					ServiceName:    "nexus-edge",
+					ServiceVersion: Version,
+					Endpoint:       config.Tracing.Endpoint,
+					SamplingRate:   config.Tracing.SamplingRate,
+					EnableInsecure: config.Tracing.Insecure,
+					Attributes:     map[string]string{"edge.id": config.EdgeID},
+				})
+				defer func() {
+					shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+					defer cancel()
+					if err := shutdownTracing(shutdownCtx); err != nil {
+						logger.Warn("failed to flush traces", "error", err)
+					}
+				}()
+			}
+
 			// Create daemon
 			daemon := NewEdgeDaemon(config, logger)
 
@@ -1,6 +1,14 @@
 package main
 
-import "testing"
+import (
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+
+	pb "github.com/haasonsaas/nexus/pkg/proto"
+	"go.opentelemetry.io/otel/trace"
+)
 
 func TestDefaultConfig(t *testing.T) {
 	cfg := DefaultConfig()
@@ -20,3 +28,25 @@ func TestDefaultConfig(t *testing.T) {
 		t.Fatalf("expected HeartbeatInterval to be set")
 	}
 }
+
+func TestHandleToolRequestContinuesCoreTrace(t *testing.T) {
+	daemon := NewEdgeDaemon(DefaultConfig(), slog.New(slog.NewTextHandler(io.Discard, nil)))
+	traceIDs := make(chan trace.TraceID, 1)
+	daemon.RegisterTool(&Tool{
+		Name: "probe",
+		Handler: func(ctx context.Context, input string) (*ToolResult, error) {
+			traceIDs <- trace.SpanContextFromContext(ctx).TraceID()
+			return &ToolResult{Content: "ok"}, nil
+		},
+	})
+
+	daemon.handleToolRequest(context.Background(), &pb.ToolExecutionRequest{
+		ExecutionId: "exec-1",
+		ToolName:    "probe",
+		Metadata:    map[string]string{"traceparent": "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"},
+	})
+
+	if got := <-traceIDs; got.String() != "4bf92f3577b34da6a3ce929d0e0e4736" {
+		t.Fatalf("tool ran in trace %s, want the core's trace", got)
+	}
+}
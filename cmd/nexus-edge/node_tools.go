@@ -6,6 +6,8 @@
 // - location_get: Get current GPS location
 // - shell_run: Execute shell commands
 // - computer_use: Mouse/keyboard/screenshot automation
+// - file_read, file_write, file_glob: File access in granted directories
+// - edge.run_command: Policy-checked shell commands with streamed output
 package main
 
 import (
@@ -31,7 +33,16 @@ func RegisterNodeTools(daemon *EdgeDaemon, policy NodePolicy) {
 	daemon.RegisterTool(screenCaptureTool())
 	daemon.RegisterTool(locationGetTool())
 	daemon.RegisterTool(shellRunTool(policy.Shell))
-	daemon.RegisterTool(computerUseTool(policy.ComputerUse))
+	daemon.RegisterTool(computerUseTool(newComputerUseGuard(policy.ComputerUse, daemon.logger)))
+
+	files := newFileAccessGuard(policy.Files, daemon.logger)
+	daemon.RegisterTool(fileReadTool(files))
+	daemon.RegisterTool(fileWriteTool(files))
+	daemon.RegisterTool(fileGlobTool(files))
+
+	daemon.RegisterTool(runCommandTool(newCommandGuard(policy.Commands, daemon.logger), func(data map[string]interface{}) error {
+		return daemon.sendEvent(pb.EdgeEventType_EDGE_EVENT_TYPE_TOOL_PROGRESS, data)
+	}))
 }
 
 // cameraSnapTool takes a photo using the device camera.
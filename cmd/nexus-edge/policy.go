@@ -1,9 +1,13 @@
 package main
 
+import "time"
+
 // NodePolicy defines local policy controls for node tools.
 type NodePolicy struct {
 	Shell       *ShellPolicy       `json:"shell,omitempty" yaml:"shell,omitempty"`
 	ComputerUse *ComputerUsePolicy `json:"computer_use,omitempty" yaml:"computer_use,omitempty"`
+	Files       *FilesPolicy       `json:"files,omitempty" yaml:"files,omitempty"`
+	Commands    *CommandPolicy     `json:"commands,omitempty" yaml:"commands,omitempty"`
 }
 
 // ShellPolicy controls command execution for nodes.shell_run.
@@ -16,4 +20,70 @@ type ShellPolicy struct {
 type ComputerUsePolicy struct {
 	Allowlist []string `json:"allowlist,omitempty" yaml:"allowlist,omitempty"`
 	Denylist  []string `json:"denylist,omitempty" yaml:"denylist,omitempty"`
+
+	// MaxActionsPerMinute limits actions per session. Default: 60.
+	MaxActionsPerMinute int `json:"max_actions_per_minute,omitempty" yaml:"max_actions_per_minute,omitempty"`
+
+	// ApprovalTimeout is how long the local approval prompt waits before
+	// denying. Default: 60s.
+	ApprovalTimeout time.Duration `json:"approval_timeout,omitempty" yaml:"approval_timeout,omitempty"`
+
+	// SessionApprovalTTL is how long "Allow for Session" covers further
+	// input actions in that session. Default: 10m.
+	SessionApprovalTTL time.Duration `json:"session_approval_ttl,omitempty" yaml:"session_approval_ttl,omitempty"`
+
+	// RecordingDir additionally writes each session's recording to
+	// <dir>/<session>.jsonl on this machine.
+	RecordingDir string `json:"recording_dir,omitempty" yaml:"recording_dir,omitempty"`
+}
+
+// FilesPolicy controls directory grants for the nodes.file_* tools. Paths
+// outside granted directories need interactive approval on this machine.
+type FilesPolicy struct {
+	// Read lists directories that may be read and globbed.
+	Read []string `json:"read,omitempty" yaml:"read,omitempty"`
+
+	// Write lists directories that may be written. Write implies read.
+	Write []string `json:"write,omitempty" yaml:"write,omitempty"`
+
+	// Exclude lists .gitignore-style patterns left out of glob results, in
+	// addition to .gitignore files found while globbing. Default: .git/.
+	Exclude []string `json:"exclude,omitempty" yaml:"exclude,omitempty"`
+
+	// MaxReadBytes caps a single read. Default: 1MB.
+	MaxReadBytes int64 `json:"max_read_bytes,omitempty" yaml:"max_read_bytes,omitempty"`
+
+	// ApprovalTimeout is how long the local approval prompt waits before
+	// denying. Default: 60s.
+	ApprovalTimeout time.Duration `json:"approval_timeout,omitempty" yaml:"approval_timeout,omitempty"`
+
+	// AuditLog is the JSONL file every access is appended to.
+	// Default: ~/.nexus-edge/file_audit.jsonl.
+	AuditLog string `json:"audit_log,omitempty" yaml:"audit_log,omitempty"`
+}
+
+// CommandPolicy controls edge.run_command. Commands whose binaries are all
+// allowlisted run without approval; anything else needs approval on this
+// machine.
+type CommandPolicy struct {
+	// Allowlist lists binaries (filepath-style globs matched against the
+	// name and resolved path) that run without approval.
+	Allowlist []string `json:"allowlist,omitempty" yaml:"allowlist,omitempty"`
+
+	// Denylist lists binaries that never run, even with approval.
+	Denylist []string `json:"denylist,omitempty" yaml:"denylist,omitempty"`
+
+	// Shell runs the command string. Default: /bin/sh.
+	Shell string `json:"shell,omitempty" yaml:"shell,omitempty"`
+
+	// MaxOutputBytes caps captured and streamed output per stream.
+	// Default: 256KB.
+	MaxOutputBytes int `json:"max_output_bytes,omitempty" yaml:"max_output_bytes,omitempty"`
+
+	// MaxTimeout caps the timeout a caller may request. Default: 10m.
+	MaxTimeout time.Duration `json:"max_timeout,omitempty" yaml:"max_timeout,omitempty"`
+
+	// ApprovalTimeout is how long the local approval prompt waits before
+	// denying. Default: 60s.
+	ApprovalTimeout time.Duration `json:"approval_timeout,omitempty" yaml:"approval_timeout,omitempty"`
 }
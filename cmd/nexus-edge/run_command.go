@@ -0,0 +1,276 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	defaultCommandTimeoutSeconds = 60
+	// commandWaitDelay bounds how long to wait for output pipes after the
+	// shell exits or is killed, since children may keep them open.
+	commandWaitDelay = 2 * time.Second
+)
+
+// progressEmitter streams a progress event for the running tool.
+type progressEmitter func(data map[string]interface{}) error
+
+// runCommandTool executes shell commands under the local command policy.
+func runCommandTool(guard *commandGuard, emit progressEmitter) *Tool {
+	return &Tool{
+		Name:        "edge.run_command",
+		Description: "Run a shell command on this machine. Commands whose binaries are allowlisted run immediately; anything else needs approval at the machine. Output is streamed while the command runs and capped in size. Set dry_run to see what would be executed and whether it needs approval.",
+		InputSchema: `{
+			"type": "object",
+			"properties": {
+				"command": {
+					"type": "string",
+					"description": "Shell command to run"
+				},
+				"working_dir": {
+					"type": "string",
+					"description": "Working directory (absolute or ~/ path)"
+				},
+				"env": {
+					"type": "object",
+					"description": "Environment variables to set",
+					"additionalProperties": {"type": "string"}
+				},
+				"timeout_seconds": {
+					"type": "integer",
+					"description": "Command timeout in seconds (default: 60)",
+					"default": 60
+				},
+				"dry_run": {
+					"type": "boolean",
+					"description": "Report what would be executed and the policy decision without running it",
+					"default": false
+				}
+			},
+			"required": ["command"]
+		}`,
+		// Approval is decided on the edge so allowlisted commands run
+		// without a core prompt.
+		RequiresApproval: false,
+		TimeoutSeconds:   int((guard.policy.MaxTimeout + guard.policy.ApprovalTimeout).Seconds()) + 30,
+		Handler: func(ctx context.Context, input string) (*ToolResult, error) {
+			return handleRunCommand(ctx, input, guard, emit)
+		},
+	}
+}
+
+func handleRunCommand(ctx context.Context, input string, guard *commandGuard, emit progressEmitter) (*ToolResult, error) {
+	var params struct {
+		Command        string            `json:"command"`
+		WorkingDir     string            `json:"working_dir"`
+		Env            map[string]string `json:"env"`
+		TimeoutSeconds int               `json:"timeout_seconds"`
+		DryRun         bool              `json:"dry_run"`
+	}
+	params.TimeoutSeconds = defaultCommandTimeoutSeconds
+	if err := json.Unmarshal([]byte(input), &params); err != nil {
+		return nil, fmt.Errorf("invalid params: %w", err)
+	}
+	if strings.TrimSpace(params.Command) == "" {
+		return &ToolResult{Content: "command is required", IsError: true}, nil
+	}
+	timeout := time.Duration(params.TimeoutSeconds) * time.Second
+	if timeout <= 0 {
+		timeout = defaultCommandTimeoutSeconds * time.Second
+	}
+	if timeout > guard.policy.MaxTimeout {
+		timeout = guard.policy.MaxTimeout
+	}
+	workingDir := expandUserPath(strings.TrimSpace(params.WorkingDir))
+
+	verdict := evaluateCommand(&guard.policy, params.Command)
+	if params.DryRun {
+		return commandResult(map[string]interface{}{
+			"dry_run":         true,
+			"command":         params.Command,
+			"argv":            []string{guard.policy.Shell, "-c", params.Command},
+			"working_dir":     workingDir,
+			"timeout_seconds": int(timeout.Seconds()),
+			"decision":        verdict.Decision,
+			"binaries":        verdict.Binaries,
+			"reasons":         verdict.Reasons,
+		}, false)
+	}
+
+	sessionID, executionID := "", ""
+	if req := toolRequestFromContext(ctx); req != nil {
+		sessionID = req.SessionId
+		executionID = req.ExecutionId
+	}
+	approval := "allowlist"
+	switch verdict.Decision {
+	case commandDenied:
+		return &ToolResult{
+			Content: fmt.Sprintf("command blocked by local policy: %s", strings.Join(verdict.Reasons, "; ")),
+			IsError: true,
+		}, nil
+	case commandNeedsApproval:
+		how, err := guard.authorize(ctx, sessionID, params.Command, verdict)
+		if err != nil {
+			guard.logger.Warn("command not approved", "session_id", sessionID, "command", params.Command, "error", err)
+			return &ToolResult{Content: err.Error(), IsError: true}, nil
+		}
+		approval = how
+	}
+	guard.logger.Info("running command",
+		"session_id", sessionID,
+		"execution_id", executionID,
+		"command", params.Command,
+		"approval", approval,
+	)
+
+	cmdCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	cmd := exec.CommandContext(cmdCtx, guard.policy.Shell, "-c", params.Command)
+	cmd.Dir = workingDir
+	cmd.WaitDelay = commandWaitDelay
+	if len(params.Env) > 0 {
+		cmd.Env = os.Environ()
+		for k, v := range params.Env {
+			cmd.Env = append(cmd.Env, k+"="+v)
+		}
+	}
+	var seqMu sync.Mutex
+	seq := 0
+	nextSeq := func() int {
+		seqMu.Lock()
+		defer seqMu.Unlock()
+		seq++
+		return seq
+	}
+	stdout := &commandOutput{stream: "stdout", limit: guard.policy.MaxOutputBytes, executionID: executionID, emit: emit, nextSeq: nextSeq}
+	stderr := &commandOutput{stream: "stderr", limit: guard.policy.MaxOutputBytes, executionID: executionID, emit: emit, nextSeq: nextSeq}
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+
+	start := time.Now()
+	err := cmd.Run()
+	duration := time.Since(start)
+	stdout.flush()
+	stderr.flush()
+
+	exitCode := 0
+	timedOut := errors.Is(cmdCtx.Err(), context.DeadlineExceeded)
+	if err != nil {
+		var exitErr *exec.ExitError
+		if !errors.As(err, &exitErr) && !timedOut {
+			return &ToolResult{Content: fmt.Sprintf("command execution failed: %v", err), IsError: true}, nil
+		}
+		exitCode = -1
+		if exitErr != nil {
+			exitCode = exitErr.ExitCode()
+		}
+	}
+
+	result := map[string]interface{}{
+		"command":     params.Command,
+		"exit_code":   exitCode,
+		"duration_ms": duration.Milliseconds(),
+		"approval":    approval,
+		"stdout":      stdout.String(),
+		"stderr":      stderr.String(),
+	}
+	if timedOut {
+		result["timed_out"] = true
+	}
+	for _, out := range []*commandOutput{stdout, stderr} {
+		if out.truncated() {
+			result[out.stream+"_truncated"] = true
+			result[out.stream+"_bytes"] = out.total
+		}
+	}
+	return commandResult(result, exitCode != 0 || timedOut)
+}
+
+func commandResult(result map[string]interface{}, isError bool) (*ToolResult, error) {
+	payload, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("encode result: %w", err)
+	}
+	return &ToolResult{Content: string(payload), IsError: isError}, nil
+}
+
+// commandOutput captures one output stream up to limit bytes and streams
+// the captured bytes as progress events. Output past the limit is counted
+// but dropped.
+type commandOutput struct {
+	stream      string
+	limit       int
+	executionID string
+	emit        progressEmitter
+	nextSeq     func() int
+
+	buf   bytes.Buffer
+	total int64
+	// pending holds a trailing partial UTF-8 sequence until the rest of it
+	// arrives, so streamed chunks are always valid text.
+	pending []byte
+}
+
+func (o *commandOutput) Write(p []byte) (int, error) {
+	o.total += int64(len(p))
+	room := o.limit - o.buf.Len()
+	if room <= 0 {
+		return len(p), nil
+	}
+	chunk := p[:min(room, len(p))]
+	o.buf.Write(chunk)
+	if o.emit != nil {
+		data := append(o.pending, chunk...)
+		cut := len(data)
+		for i := 1; i <= utf8.UTFMax && i <= len(data); i++ {
+			if utf8.RuneStart(data[len(data)-i]) {
+				if !utf8.FullRune(data[len(data)-i:]) {
+					cut = len(data) - i
+				}
+				break
+			}
+		}
+		o.pending = append([]byte(nil), data[cut:]...)
+		o.send(data[:cut])
+	}
+	return len(p), nil
+}
+
+func (o *commandOutput) flush() {
+	if o.emit != nil && len(o.pending) > 0 {
+		o.send(o.pending)
+		o.pending = nil
+	}
+}
+
+// send emits a chunk. Streaming is best effort: the final result always
+// carries the captured output.
+func (o *commandOutput) send(chunk []byte) {
+	if len(chunk) == 0 {
+		return
+	}
+	_ = o.emit(map[string]interface{}{ //nolint:errcheck // best effort
+		"execution_id": o.executionID,
+		"stream":       o.stream,
+		"seq":          o.nextSeq(),
+		"data":         strings.ToValidUTF8(string(chunk), "�"),
+	})
+}
+
+func (o *commandOutput) truncated() bool {
+	return o.total > int64(o.buf.Len())
+}
+
+func (o *commandOutput) String() string {
+	return strings.ToValidUTF8(o.buf.String(), "�")
+}
@@ -0,0 +1,177 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	pb "github.com/haasonsaas/nexus/pkg/proto"
+)
+
+func newTestCommandGuard(policy *CommandPolicy, approve localApprover) *commandGuard {
+	guard := newCommandGuard(policy, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	guard.approve = approve
+	return guard
+}
+
+func TestParseShellCommand(t *testing.T) {
+	tests := []struct {
+		command  string
+		binaries []string
+		unsafe   []string
+	}{
+		{"ls -la", []string{"ls"}, nil},
+		{"git status && git diff | less; echo done", []string{"git", "git", "less", "echo"}, nil},
+		{"FOO=1 BAR='a b' make test", []string{"make"}, nil},
+		{`grep "a | b" file.txt`, []string{"grep"}, nil},
+		{`echo 'rm -rf /' # comment | sh`, []string{"echo"}, nil},
+		{"go test ./... 2>&1 | tail -5", []string{"go", "tail"}, nil},
+		{"cat < in.txt > /dev/null", []string{"cat"}, nil},
+		{"echo hi > out.txt", []string{"echo"}, []string{"output redirected to out.txt"}},
+		{"echo $(whoami)", []string{"echo"}, []string{"command substitution"}},
+		{"echo \"`id`\"", []string{"echo"}, []string{"command substitution"}},
+		{"$CMD arg", []string{"$CMD"}, []string{"command name uses expansion"}},
+		{"echo $HOME", []string{"echo"}, nil},
+		{"(cd /tmp && ls)", []string{"cd", "ls"}, []string{"subshell or command group"}},
+		{"diff <(ls a) <(ls b)", []string{"diff"}, []string{"process substitution"}},
+	}
+	for _, tt := range tests {
+		got := parseShellCommand(tt.command)
+		if !reflect.DeepEqual(got.binaries, tt.binaries) || !reflect.DeepEqual(got.unsafe, tt.unsafe) {
+			t.Errorf("parseShellCommand(%q) = %v %v, want %v %v", tt.command, got.binaries, got.unsafe, tt.binaries, tt.unsafe)
+		}
+	}
+}
+
+func TestEvaluateCommand(t *testing.T) {
+	policy := &CommandPolicy{Allowlist: []string{"echo", "ls", "/opt/tools/*"}, Denylist: []string{"rm"}}
+	tests := []struct {
+		command string
+		want    commandDecision
+	}{
+		{"echo hi | ls", commandAllowed},
+		{"cd /tmp && ls", commandAllowed},
+		{"/opt/tools/build --fast", commandAllowed},
+		{"curl example.com", commandNeedsApproval},
+		{"echo hi > notes.txt", commandNeedsApproval},
+		{"ls && rm -rf build", commandDenied},
+	}
+	for _, tt := range tests {
+		if got := evaluateCommand(policy, tt.command); got.Decision != tt.want {
+			t.Errorf("evaluateCommand(%q) = %s (%v), want %s", tt.command, got.Decision, got.Reasons, tt.want)
+		}
+	}
+}
+
+func TestRunCommandDryRun(t *testing.T) {
+	guard := newTestCommandGuard(&CommandPolicy{Allowlist: []string{"echo"}}, func(ctx context.Context, prompt string, timeout time.Duration) (approvalDecision, error) {
+		t.Fatal("dry run must not prompt")
+		return approvalDenied, nil
+	})
+	result, err := handleRunCommand(context.Background(), `{"command":"echo hi && touch /tmp/x","dry_run":true}`, guard, nil)
+	if err != nil || result.IsError {
+		t.Fatalf("handleRunCommand() = %+v, %v", result, err)
+	}
+	var out struct {
+		DryRun   bool     `json:"dry_run"`
+		Argv     []string `json:"argv"`
+		Decision string   `json:"decision"`
+		Reasons  []string `json:"reasons"`
+	}
+	if err := json.Unmarshal([]byte(result.Content), &out); err != nil {
+		t.Fatal(err)
+	}
+	if !out.DryRun || out.Decision != string(commandNeedsApproval) || len(out.Reasons) != 1 || out.Reasons[0] != "touch is not allowlisted" {
+		t.Fatalf("unexpected dry run result %+v", out)
+	}
+	if !reflect.DeepEqual(out.Argv, []string{"/bin/sh", "-c", "echo hi && touch /tmp/x"}) {
+		t.Fatalf("unexpected argv %v", out.Argv)
+	}
+}
+
+func TestRunCommandStreamsCappedOutput(t *testing.T) {
+	guard := newTestCommandGuard(&CommandPolicy{Allowlist: []string{"printf"}, MaxOutputBytes: 8}, nil)
+	var mu sync.Mutex
+	var streamed strings.Builder
+	emit := func(data map[string]interface{}) error {
+		mu.Lock()
+		defer mu.Unlock()
+		if data["execution_id"] != "exec-1" || data["stream"] != "stdout" {
+			t.Errorf("unexpected progress event %v", data)
+		}
+		streamed.WriteString(data["data"].(string))
+		return nil
+	}
+	ctx := withToolRequest(context.Background(), &pb.ToolExecutionRequest{SessionId: "s1", ExecutionId: "exec-1"})
+
+	result, err := handleRunCommand(ctx, `{"command":"printf 'hello world'"}`, guard, emit)
+	if err != nil || result.IsError {
+		t.Fatalf("handleRunCommand() = %+v, %v", result, err)
+	}
+	var out struct {
+		Stdout    string `json:"stdout"`
+		Truncated bool   `json:"stdout_truncated"`
+		Bytes     int64  `json:"stdout_bytes"`
+		Approval  string `json:"approval"`
+	}
+	if err := json.Unmarshal([]byte(result.Content), &out); err != nil {
+		t.Fatal(err)
+	}
+	if out.Stdout != "hello wo" || !out.Truncated || out.Bytes != 11 || out.Approval != "allowlist" {
+		t.Fatalf("unexpected result %+v", out)
+	}
+	if streamed.String() != "hello wo" {
+		t.Fatalf("streamed %q", streamed.String())
+	}
+
+	result, _ = handleRunCommand(ctx, `{"command":"printf x; exit 3"}`, guard, nil)
+	if !result.IsError || !strings.Contains(result.Content, `"exit_code": 3`) {
+		t.Fatalf("expected non-zero exit to be an error, got %+v", result)
+	}
+}
+
+func TestRunCommandApproval(t *testing.T) {
+	ctx := withToolRequest(context.Background(), &pb.ToolExecutionRequest{SessionId: "s1", Approved: true})
+	input := `{"command":"printf ok"}`
+
+	// Core approval alone does not run commands outside the allowlist.
+	result, err := handleRunCommand(ctx, input, newTestCommandGuard(nil, nil), nil)
+	if err != nil || !result.IsError || !strings.Contains(result.Content, "approval required") {
+		t.Fatalf("expected approval error, got %+v, %v", result, err)
+	}
+
+	prompts := 0
+	decision := approvalSession
+	guard := newTestCommandGuard(&CommandPolicy{Denylist: []string{"rm"}}, func(ctx context.Context, prompt string, timeout time.Duration) (approvalDecision, error) {
+		prompts++
+		if !strings.Contains(prompt, "printf") {
+			t.Errorf("prompt %q does not show the command", prompt)
+		}
+		return decision, nil
+	})
+	for i := 0; i < 2; i++ {
+		result, err := handleRunCommand(ctx, input, guard, nil)
+		if err != nil || result.IsError || !strings.Contains(result.Content, `"approval": "session"`) {
+			t.Fatalf("run #%d = %+v, %v", i, result, err)
+		}
+	}
+	if prompts != 1 {
+		t.Fatalf("expected one prompt for the session, got %d", prompts)
+	}
+
+	if result, _ := handleRunCommand(ctx, `{"command":"rm -rf /tmp/nothing"}`, guard, nil); !result.IsError || !strings.Contains(result.Content, "blocked by local policy") {
+		t.Fatalf("expected denylisted command to be blocked, got %+v", result)
+	}
+
+	guard.now = func() time.Time { return time.Now().Add(time.Hour) }
+	decision = approvalDenied
+	if result, _ := handleRunCommand(ctx, input, guard, nil); !result.IsError {
+		t.Fatal("expected expired session grant to prompt and be denied")
+	}
+}
@@ -8,9 +8,15 @@ import (
 	"log/slog"
 	"os"
 	"strings"
+	"time"
 
+	"github.com/haasonsaas/nexus/internal/observability"
 	"github.com/haasonsaas/nexus/internal/plugins"
 	"github.com/haasonsaas/nexus/pkg/pluginsdk"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 )
 
 func main() {
@@ -71,6 +77,9 @@ func runExecTool(args []string) {
 	paramsFile := flags.String("params-file", "", "Tool params file")
 	configJSON := flags.String("config", "", "Plugin config JSON")
 	configFile := flags.String("config-file", "", "Plugin config file path")
+	traceparent := flags.String("traceparent", "", "W3C traceparent of the calling span")
+	otlpEndpoint := flags.String("otlp-endpoint", "", "OTLP gRPC collector for the execution span")
+	otlpInsecure := flags.Bool("otlp-insecure", false, "Disable TLS to the OTLP collector")
 	_ = flags.Parse(args)
 
 	if strings.TrimSpace(*toolName) == "" {
@@ -108,7 +117,7 @@ func runExecTool(args []string) {
 		return
 	}
 
-	result, err := handler(context.Background(), params)
+	result, err := execTraced(*toolName, handler, params, *traceparent, *otlpEndpoint, *otlpInsecure)
 	if err != nil {
 		writeError(err)
 		return
@@ -116,6 +125,41 @@ func runExecTool(args []string) {
 	writeJSON(toolExecResponse{Result: result})
 }
 
+// execTraced runs handler in the caller's trace. The execution span is
+// exported when otlpEndpoint is set and flushed before returning, since the
+// process exits right after.
+func execTraced(toolName string, handler pluginsdk.ToolHandler, params json.RawMessage, traceparent, otlpEndpoint string, otlpInsecure bool) (*pluginsdk.ToolResult, error) {
+	ctx := observability.ExtractTraceContext(context.Background(), map[string]string{"traceparent": strings.TrimSpace(traceparent)})
+	shutdown := func(context.Context) error { return nil }
+	if strings.TrimSpace(otlpEndpoint) != "" {
+		_, shutdown = observability.NewTracer(observability.TraceConfig{
+			ServiceName:    "nexus-plugin-runner",
+			Endpoint:       strings.TrimSpace(otlpEndpoint),
+			EnableInsecure: otlpInsecure,
+		})
+	}
+
+	ctx, span := otel.Tracer("github.com/haasonsaas/nexus/cmd/nexus-plugin-runner").Start(ctx, "plugin.tool "+toolName,
+		trace.WithSpanKind(trace.SpanKindServer),
+		trace.WithAttributes(attribute.String("tool.name", toolName)),
+	)
+	result, err := handler(ctx, params)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	} else if result != nil && result.IsError {
+		span.SetStatus(codes.Error, "tool returned an error")
+	}
+	span.End()
+
+	flushCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if flushErr := shutdown(flushCtx); flushErr != nil {
+		fmt.Fprintln(os.Stderr, "flush traces:", flushErr)
+	}
+	return result, err
+}
+
 // loadRawInput resolves a raw string or file path into bytes.
 // Returns nil if both are empty. Returns an error if both are set.
 func loadRawInput(raw string, file string, label string) ([]byte, error) {
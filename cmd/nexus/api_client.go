@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"os"
 	"strings"
 	"time"
 
@@ -15,17 +16,37 @@ import (
 )
 
 type systemStatus struct {
-	Uptime         time.Duration       `json:"uptime"`
-	UptimeString   string              `json:"uptime_string"`
-	GoVersion      string              `json:"go_version"`
-	NumGoroutines  int                 `json:"num_goroutines"`
-	MemAllocMB     float64             `json:"mem_alloc_mb"`
-	MemSysMB       float64             `json:"mem_sys_mb"`
-	NumCPU         int                 `json:"num_cpu"`
-	SessionCount   int                 `json:"session_count"`
-	DatabaseStatus string              `json:"database_status"`
-	Channels       []channelStatus     `json:"channels"`
-	HealthChecks   *infra.HealthReport `json:"health_checks,omitempty"`
+	Uptime         time.Duration        `json:"uptime"`
+	UptimeString   string               `json:"uptime_string"`
+	GoVersion      string               `json:"go_version"`
+	NumGoroutines  int                  `json:"num_goroutines"`
+	MemAllocMB     float64              `json:"mem_alloc_mb"`
+	MemSysMB       float64              `json:"mem_sys_mb"`
+	NumCPU         int                  `json:"num_cpu"`
+	SessionCount   int                  `json:"session_count"`
+	DatabaseStatus string               `json:"database_status"`
+	Channels       []channelStatus      `json:"channels"`
+	HealthChecks   *infra.HealthReport  `json:"health_checks,omitempty"`
+	Steering       []steeringRuleStatus `json:"steering,omitempty"`
+	Anomalies      []anomalyStatus      `json:"anomalies,omitempty"`
+}
+
+type anomalyStatus struct {
+	Signal   string    `json:"signal"`
+	Key      string    `json:"key,omitempty"`
+	Value    float64   `json:"value"`
+	Baseline float64   `json:"baseline"`
+	ZScore   float64   `json:"z_score"`
+	Since    time.Time `json:"since"`
+}
+
+type steeringRuleStatus struct {
+	ID       string    `json:"id"`
+	Name     string    `json:"name,omitempty"`
+	Enabled  bool      `json:"enabled"`
+	Priority int       `json:"priority,omitempty"`
+	Hits     int64     `json:"hits"`
+	LastHit  time.Time `json:"last_hit,omitempty"`
 }
 
 type channelStatus struct {
@@ -63,7 +84,13 @@ type apiClient struct {
 	httpClient *http.Client
 }
 
+// apiKeyEnv supplies the API key when neither --token nor --api-key is set.
+const apiKeyEnv = "NEXUS_API_KEY"
+
 func newAPIClient(baseURL, token, apiKey string) *apiClient {
+	if token == "" && apiKey == "" {
+		apiKey = strings.TrimSpace(os.Getenv(apiKeyEnv))
+	}
 	return &apiClient{
 		baseURL: strings.TrimRight(baseURL, "/"),
 		token:   token,
@@ -92,14 +119,7 @@ func (c *apiClient) getJSON(ctx context.Context, path string, out any) error {
 	defer resp.Body.Close()
 
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		body, readErr := io.ReadAll(io.LimitReader(resp.Body, 4096))
-		if readErr != nil {
-			return fmt.Errorf("request %s failed: %s (read body: %w)", path, resp.Status, readErr)
-		}
-		if len(body) > 0 {
-			return fmt.Errorf("request %s failed: %s (%s)", path, resp.Status, strings.TrimSpace(string(body)))
-		}
-		return fmt.Errorf("request %s failed: %s", path, resp.Status)
+		return responseError(path, resp)
 	}
 
 	decoder := json.NewDecoder(resp.Body)
@@ -132,14 +152,7 @@ func (c *apiClient) postJSON(ctx context.Context, path string, payload any, out
 	defer resp.Body.Close()
 
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		bodyBytes, readErr := io.ReadAll(io.LimitReader(resp.Body, 4096))
-		if readErr != nil {
-			return fmt.Errorf("request %s failed: %s (read body: %w)", path, resp.Status, readErr)
-		}
-		if len(bodyBytes) > 0 {
-			return fmt.Errorf("request %s failed: %s (%s)", path, resp.Status, strings.TrimSpace(string(bodyBytes)))
-		}
-		return fmt.Errorf("request %s failed: %s", path, resp.Status)
+		return responseError(path, resp)
 	}
 
 	if out == nil {
@@ -152,6 +165,52 @@ func (c *apiClient) postJSON(ctx context.Context, path string, payload any, out
 	return nil
 }
 
+// download streams the response body of a GET request to w.
+func (c *apiClient) download(ctx context.Context, path string, w io.Writer) (int64, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+path, nil)
+	if err != nil {
+		return 0, err
+	}
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+	if c.apiKey != "" {
+		req.Header.Set("X-API-Key", c.apiKey)
+	}
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return 0, responseError(path, resp)
+	}
+	return io.Copy(w, resp.Body)
+}
+
+// responseError describes a non-2xx response. Auth failures say how to fix
+// them, since read-only and operator keys are refused admin operations.
+func responseError(path string, resp *http.Response) error {
+	var err error
+	body, readErr := io.ReadAll(io.LimitReader(resp.Body, 4096))
+	switch {
+	case readErr != nil:
+		err = fmt.Errorf("request %s failed: %s (read body: %w)", path, resp.Status, readErr)
+	case len(body) > 0:
+		err = fmt.Errorf("request %s failed: %s (%s)", path, resp.Status, strings.TrimSpace(string(body)))
+	default:
+		err = fmt.Errorf("request %s failed: %s", path, resp.Status)
+	}
+	switch resp.StatusCode {
+	case http.StatusUnauthorized:
+		return fmt.Errorf("%w; pass --token or --api-key, or set %s", err, apiKeyEnv)
+	case http.StatusForbidden:
+		return fmt.Errorf("%w; the API key's role or scopes do not allow this operation", err)
+	}
+	return err
+}
+
 func resolveHTTPBaseURL(configPath, serverAddr string) (string, error) {
 	addr := strings.TrimSpace(serverAddr)
 	if addr == "" {
@@ -0,0 +1,129 @@
+package main
+
+import (
+	"github.com/haasonsaas/nexus/internal/profile"
+	"github.com/spf13/cobra"
+)
+
+// =============================================================================
+// Access Commands
+// =============================================================================
+
+// buildAccessCmd creates the "access" command group.
+func buildAccessCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "access",
+		Short: "Manage runtime access grants",
+		Long: `Manage grants to the gateway's access-control lists.
+
+Grants are stored in the database (database.url) and shared by every gateway
+node. They extend the allowlists in the config file:
+
+  commands   commands.allow_from, also managed with /allow in chat
+  elevated   tools.elevated.allow_from, also managed with /elevate
+  pairing    channels.<channel>.dm.allow_from, also managed with /pair
+
+Senders are written as channel:sender_id; the channel "default" applies on
+every channel.`,
+	}
+	cmd.AddCommand(
+		buildAccessListCmd(),
+		buildAccessGrantCmd(),
+		buildAccessRevokeCmd(),
+		buildAccessImportCmd(),
+		buildAccessExportCmd(),
+	)
+	return cmd
+}
+
+func buildAccessListCmd() *cobra.Command {
+	var (
+		configPath string
+		list       string
+		jsonOutput bool
+	)
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List active grants",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runAccessList(cmd, configPath, list, jsonOutput)
+		},
+	}
+	cmd.Flags().StringVarP(&configPath, "config", "c", profile.DefaultConfigPath(), "Path to config file")
+	cmd.Flags().StringVar(&list, "list", "", "Only show one list (commands, elevated, pairing)")
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output in JSON format")
+	return cmd
+}
+
+func buildAccessGrantCmd() *cobra.Command {
+	var (
+		configPath string
+		duration   string
+	)
+	cmd := &cobra.Command{
+		Use:   "grant <list> <channel:sender>",
+		Short: "Grant a sender access",
+		Example: `  # Let a Telegram user run commands
+  nexus access grant commands telegram:12345
+
+  # Let a Slack user use elevated tools for an hour
+  nexus access grant elevated slack:U123 --for 1h`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runAccessGrant(cmd, configPath, args[0], args[1], duration)
+		},
+	}
+	cmd.Flags().StringVarP(&configPath, "config", "c", profile.DefaultConfigPath(), "Path to config file")
+	cmd.Flags().StringVar(&duration, "for", "", "Expire the grant after this long (e.g. 30m, 1h, 7d)")
+	return cmd
+}
+
+func buildAccessRevokeCmd() *cobra.Command {
+	var configPath string
+	cmd := &cobra.Command{
+		Use:   "revoke <list> <channel:sender>",
+		Short: "Revoke a sender's grant",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runAccessRevoke(cmd, configPath, args[0], args[1])
+		},
+	}
+	cmd.Flags().StringVarP(&configPath, "config", "c", profile.DefaultConfigPath(), "Path to config file")
+	return cmd
+}
+
+func buildAccessImportCmd() *cobra.Command {
+	var configPath string
+	cmd := &cobra.Command{
+		Use:   "import",
+		Short: "Seed grants from the config file and pairing allowlists",
+		Long: `Copy commands.allow_from, tools.elevated.allow_from, and each channel's
+dm.allow_from into the grant store, along with senders approved through
+pairing codes on this host. Existing grants for the same senders are
+replaced with permanent ones. The config file is not changed.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runAccessImport(cmd, configPath)
+		},
+	}
+	cmd.Flags().StringVarP(&configPath, "config", "c", profile.DefaultConfigPath(), "Path to config file")
+	return cmd
+}
+
+func buildAccessExportCmd() *cobra.Command {
+	var (
+		configPath string
+		output     string
+	)
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Export permanent grants as a config fragment",
+		Long: `Print permanent grants as YAML with the same shape as the config file, for
+bootstrapping another deployment. Grants that expire are left out.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runAccessExport(cmd, configPath, output)
+		},
+	}
+	cmd.Flags().StringVarP(&configPath, "config", "c", profile.DefaultConfigPath(), "Path to config file")
+	cmd.Flags().StringVarP(&output, "output", "o", "", "Write to a file instead of stdout")
+	return cmd
+}
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"github.com/haasonsaas/nexus/internal/profile"
+	"github.com/spf13/cobra"
+)
+
+// =============================================================================
+// Admin Commands
+// =============================================================================
+
+// buildAdminCmd creates the "admin" command group.
+func buildAdminCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "admin",
+		Short: "Operate a running Nexus server",
+	}
+	cmd.AddCommand(buildAdminPprofCmd())
+	return cmd
+}
+
+func buildAdminPprofCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "pprof",
+		Short: "Pull runtime profiles from the diagnostics port",
+	}
+	cmd.AddCommand(buildAdminPprofCaptureCmd())
+	return cmd
+}
+
+func buildAdminPprofCaptureCmd() *cobra.Command {
+	var (
+		configPath string
+		opts       pprofCaptureOptions
+	)
+	cmd := &cobra.Command{
+		Use:   "capture",
+		Short: "Capture a profile from the running server and write it locally",
+		Long: `Capture a profile from the running server and write it locally.
+
+Profiles are served by the diagnostics port (server.diagnostics), which
+must be enabled. CPU profiles and execution traces are recorded for
+--seconds; heap, goroutine and other profiles are snapshots. The
+diagnostics token from the config is used unless --token or --api-key is
+given; API keys need the admin scope.`,
+		Example: `  # Record a 30 second CPU profile
+  nexus admin pprof capture --seconds 30
+
+  # Snapshot the heap and inspect it
+  nexus admin pprof capture --profile heap -o heap.pprof
+  go tool pprof heap.pprof`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runAdminPprofCapture(cmd, configPath, opts)
+		},
+	}
+	cmd.Flags().StringVarP(&configPath, "config", "c", profile.DefaultConfigPath(),
+		"Path to YAML configuration file")
+	cmd.Flags().StringVar(&opts.profile, "profile", "cpu", "Profile to capture: cpu, heap, goroutine, allocs, block, mutex, threadcreate, or trace")
+	cmd.Flags().IntVar(&opts.seconds, "seconds", 30, "Duration of cpu profiles and traces")
+	cmd.Flags().StringVarP(&opts.output, "output", "o", "", "Output path (default: nexus-<profile>-<time>.pprof)")
+	cmd.Flags().StringVar(&opts.serverAddr, "server", "", "Diagnostics server address (default from config)")
+	cmd.Flags().StringVar(&opts.token, "token", "", "Diagnostics token or JWT (default: server.diagnostics.token)")
+	cmd.Flags().StringVar(&opts.apiKey, "api-key", "", "API key with the admin scope (default $NEXUS_API_KEY)")
+	return cmd
+}
@@ -1,6 +1,8 @@
 package main
 
 import (
+	"time"
+
 	"github.com/haasonsaas/nexus/internal/profile"
 	"github.com/spf13/cobra"
 )
@@ -13,12 +15,20 @@ func buildArtifactsCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "artifacts",
 		Short: "Manage artifacts produced by tool execution",
-		Long:  `List, view, and manage artifacts (screenshots, recordings, files) produced by edge tools.`,
+		Long: `List, view, and manage artifacts (screenshots, recordings, files) produced by edge tools.
+
+Share large outputs with signed, expiring links served by the gateway
+(requires artifacts.links.enabled):
+
+  nexus artifacts show <id> --link --ttl 24h`,
 	}
 	cmd.AddCommand(
 		buildArtifactsListCmd(),
+		buildArtifactsShowCmd(),
+		buildArtifactsDownloadCmd(),
 		buildArtifactsGetCmd(),
 		buildArtifactsDeleteCmd(),
+		buildArtifactsPruneCmd(),
 	)
 	return cmd
 }
@@ -45,6 +55,42 @@ func buildArtifactsListCmd() *cobra.Command {
 	return cmd
 }
 
+func buildArtifactsShowCmd() *cobra.Command {
+	var configPath string
+	var link bool
+	var ttl time.Duration
+	cmd := &cobra.Command{
+		Use:   "show <artifact-id>",
+		Short: "Show artifact metadata",
+		Long:  `Show an artifact's metadata and, with --link, a signed download URL.`,
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runArtifactsShow(cmd, configPath, args[0], link, ttl)
+		},
+	}
+	cmd.Flags().StringVarP(&configPath, "config", "c", profile.DefaultConfigPath(), "Path to YAML configuration file")
+	cmd.Flags().BoolVar(&link, "link", false, "Print a signed download URL")
+	cmd.Flags().DurationVar(&ttl, "ttl", 0, "Link lifetime (default: artifacts.links.ttl)")
+	return cmd
+}
+
+func buildArtifactsDownloadCmd() *cobra.Command {
+	var configPath string
+	var outputPath string
+	cmd := &cobra.Command{
+		Use:   "download <artifact-id>",
+		Short: "Download an artifact",
+		Long:  `Save an artifact's data to a file. Use -o - to write to stdout.`,
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runArtifactsDownload(cmd, configPath, args[0], outputPath)
+		},
+	}
+	cmd.Flags().StringVarP(&configPath, "config", "c", profile.DefaultConfigPath(), "Path to YAML configuration file")
+	cmd.Flags().StringVarP(&outputPath, "output", "o", "", "Output file path (default: ./<filename>)")
+	return cmd
+}
+
 func buildArtifactsGetCmd() *cobra.Command {
 	var configPath string
 	var outputPath string
@@ -78,3 +124,22 @@ func buildArtifactsDeleteCmd() *cobra.Command {
 	cmd.Flags().BoolVarP(&force, "force", "f", false, "Skip confirmation prompt")
 	return cmd
 }
+
+func buildArtifactsPruneCmd() *cobra.Command {
+	var configPath string
+	var olderThan time.Duration
+	var force bool
+	cmd := &cobra.Command{
+		Use:   "prune",
+		Short: "Delete expired artifacts",
+		Long: `Delete artifacts past their TTL. With --older-than, also delete every
+artifact created before that age regardless of TTL.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runArtifactsPrune(cmd, configPath, olderThan, force)
+		},
+	}
+	cmd.Flags().StringVarP(&configPath, "config", "c", profile.DefaultConfigPath(), "Path to YAML configuration file")
+	cmd.Flags().DurationVar(&olderThan, "older-than", 0, "Also delete artifacts older than this age (e.g. 720h)")
+	cmd.Flags().BoolVarP(&force, "force", "f", false, "Skip confirmation prompt")
+	return cmd
+}
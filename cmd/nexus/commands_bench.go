@@ -0,0 +1,67 @@
+package main
+
+import (
+	"time"
+
+	"github.com/haasonsaas/nexus/internal/profile"
+	"github.com/spf13/cobra"
+)
+
+// =============================================================================
+// Bench Command
+// =============================================================================
+
+// benchOptions holds flags for the bench command.
+type benchOptions struct {
+	configPath    string
+	messages      int
+	concurrency   int
+	conversations int
+	maxInFlight   int
+	prompt        string
+	timeout       time.Duration
+	mockLLM       bool
+	mockLatency   time.Duration
+	mockJitter    time.Duration
+	mockResponse  string
+	jsonOutput    bool
+}
+
+// buildBenchCmd creates the "bench" command for gateway load testing.
+func buildBenchCmd() *cobra.Command {
+	opts := benchOptions{}
+	cmd := &cobra.Command{
+		Use:   "bench",
+		Short: "Load test the gateway pipeline with synthetic messages",
+		Long: `Load test the gateway with synthetic concurrent inbound messages.
+
+Messages are injected through an in-process loopback channel and run through the
+full gateway pipeline (sessions, commands, agent runtime, outbound delivery).
+The report includes end-to-end latency percentiles, queue depth, and provider
+throughput.
+
+Use --mock-llm to replace the configured provider with a canned-response mock so
+capacity planning doesn't require real API calls.`,
+		Example: `  # Measure gateway overhead with a 200ms mock LLM
+  nexus bench --mock-llm --mock-latency 200ms --messages 1000 --concurrency 50
+
+  # Benchmark the configured provider with modest load
+  nexus bench --messages 20 --concurrency 2 --json`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runBench(cmd, opts)
+		},
+	}
+	cmd.Flags().StringVarP(&opts.configPath, "config", "c", profile.DefaultConfigPath(), "Path to YAML configuration file")
+	cmd.Flags().IntVarP(&opts.messages, "messages", "n", 100, "Total number of messages to send")
+	cmd.Flags().IntVar(&opts.concurrency, "concurrency", 10, "Number of concurrent senders")
+	cmd.Flags().IntVar(&opts.conversations, "conversations", 0, "Number of distinct conversations (defaults to concurrency)")
+	cmd.Flags().IntVar(&opts.maxInFlight, "max-in-flight", 0, "Override gateway message concurrency limit (0 = default)")
+	cmd.Flags().StringVar(&opts.prompt, "prompt", "Reply with a short greeting.", "Message content to send")
+	cmd.Flags().DurationVar(&opts.timeout, "timeout", 60*time.Second, "Timeout waiting for each reply")
+	cmd.Flags().BoolVar(&opts.mockLLM, "mock-llm", false, "Use a mock LLM provider instead of the configured one")
+	cmd.Flags().DurationVar(&opts.mockLatency, "mock-latency", 100*time.Millisecond, "Mock LLM response latency")
+	cmd.Flags().DurationVar(&opts.mockJitter, "mock-jitter", 0, "Random extra mock LLM latency in [0, jitter)")
+	cmd.Flags().StringVar(&opts.mockResponse, "mock-response", "Hello from the mock provider.", "Mock LLM response text")
+	cmd.Flags().BoolVar(&opts.jsonOutput, "json", false, "Output results as JSON")
+	return cmd
+}
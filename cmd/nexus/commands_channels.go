@@ -96,7 +96,7 @@ func buildChannelsStatusCmd() *cobra.Command {
 	cmd.Flags().StringVarP(&configPath, "config", "c", profile.DefaultConfigPath(), "Path to config file")
 	cmd.Flags().StringVar(&serverAddr, "server", "", "Nexus HTTP server address (default from config)")
 	cmd.Flags().StringVar(&token, "token", "", "JWT bearer token for server auth")
-	cmd.Flags().StringVar(&apiKey, "api-key", "", "API key for server auth")
+	cmd.Flags().StringVar(&apiKey, "api-key", "", "API key for server auth (default $NEXUS_API_KEY)")
 
 	return cmd
 }
@@ -133,7 +133,7 @@ and reports connection and health details.`,
 	cmd.Flags().StringVarP(&configPath, "config", "c", profile.DefaultConfigPath(), "Path to config file")
 	cmd.Flags().StringVar(&serverAddr, "server", "", "Nexus HTTP server address (default from config)")
 	cmd.Flags().StringVar(&token, "token", "", "JWT bearer token for server auth")
-	cmd.Flags().StringVar(&apiKey, "api-key", "", "API key for server auth")
+	cmd.Flags().StringVar(&apiKey, "api-key", "", "API key for server auth (default $NEXUS_API_KEY)")
 	cmd.Flags().StringVar(&channelID, "channel-id", "", "Channel identifier to send test message to")
 	cmd.Flags().StringVar(&message, "message", "Nexus test message", "Test message content")
 
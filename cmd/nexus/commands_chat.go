@@ -0,0 +1,59 @@
+package main
+
+import (
+	"time"
+
+	"github.com/haasonsaas/nexus/internal/profile"
+	"github.com/spf13/cobra"
+)
+
+// =============================================================================
+// Chat Command
+// =============================================================================
+
+// chatOptions holds flags for the chat command.
+type chatOptions struct {
+	configPath string
+	session    string
+	provider   string
+	model      string
+	noStream   bool
+	timeout    time.Duration
+	verbose    bool
+}
+
+// buildChatCmd creates the "chat" command for an interactive terminal session.
+func buildChatCmd() *cobra.Command {
+	opts := chatOptions{}
+	cmd := &cobra.Command{
+		Use:   "chat",
+		Short: "Chat with the agent from the terminal",
+		Long: `Start an interactive terminal chat wired through the full gateway pipeline.
+
+Messages are delivered through an in-process loopback channel, so sessions,
+slash commands, tools, and tool approvals behave exactly as they do on a
+messaging platform. No channel needs to be configured.
+
+Responses stream to the terminal as they are generated. When a tool requires
+approval you are prompted to allow it; allowed tools stay allowed for the rest
+of the chat and the agent is asked to retry.
+
+Type /exit or /quit (or press Ctrl+D) to leave.`,
+		Example: `  # Chat using the default provider
+  nexus chat
+
+  # Resume a named conversation with a specific provider and model
+  nexus chat --session scratch --provider openai --model gpt-4o`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runChat(cmd, opts)
+		},
+	}
+	cmd.Flags().StringVarP(&opts.configPath, "config", "c", profile.DefaultConfigPath(), "Path to YAML configuration file")
+	cmd.Flags().StringVarP(&opts.session, "session", "s", "local", "Conversation name; reuse it to continue a conversation")
+	cmd.Flags().StringVar(&opts.provider, "provider", "", "LLM provider ID (defaults to llm.default_provider)")
+	cmd.Flags().StringVar(&opts.model, "model", "", "Model override")
+	cmd.Flags().BoolVar(&opts.noStream, "no-stream", false, "Print complete responses instead of streaming")
+	cmd.Flags().DurationVar(&opts.timeout, "timeout", 5*time.Minute, "Maximum time to wait for a response")
+	cmd.Flags().BoolVarP(&opts.verbose, "verbose", "v", false, "Show gateway logs")
+	return cmd
+}
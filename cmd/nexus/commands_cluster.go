@@ -0,0 +1,52 @@
+package main
+
+import (
+	"github.com/haasonsaas/nexus/internal/profile"
+	"github.com/spf13/cobra"
+)
+
+// =============================================================================
+// Cluster Commands
+// =============================================================================
+
+// buildClusterCmd creates the "cluster" command group.
+func buildClusterCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "cluster",
+		Short: "Inspect multi-gateway cluster state",
+		Long: `Inspect cluster coordination state for gateways sharing a database.
+
+When cluster.coordination is enabled, gateways heartbeat into the database,
+elect a leader for singleton subsystems (cron, task maintenance, job pruning,
+retention), and partition scheduled tasks across live nodes.`,
+	}
+	cmd.AddCommand(buildClusterStatusCmd())
+	return cmd
+}
+
+// buildClusterStatusCmd creates the "cluster status" command.
+func buildClusterStatusCmd() *cobra.Command {
+	var (
+		configPath string
+		jsonOutput bool
+	)
+	cmd := &cobra.Command{
+		Use:   "status",
+		Short: "Show node membership and lease holders",
+		Long: `Show registered gateway nodes and which node holds each leader lease.
+
+Nodes that have not heartbeated within cluster.coordination.node_ttl are
+reported as stale; expired leases are free for any node to take.`,
+		Example: `  # Show cluster membership and leases
+  nexus cluster status
+
+  # Machine-readable output
+  nexus cluster status --json`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runClusterStatus(cmd, configPath, jsonOutput)
+		},
+	}
+	cmd.Flags().StringVarP(&configPath, "config", "c", profile.DefaultConfigPath(), "Path to config file")
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output in JSON format")
+	return cmd
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+// =============================================================================
+// Completion Commands
+// =============================================================================
+
+// buildCompletionCmd creates the "completion" command that prints shell
+// completion scripts.
+func buildCompletionCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "completion <bash|zsh|fish>",
+		Short: "Generate shell completion scripts",
+		Long: `Print a completion script for bash, zsh, or fish.
+
+Besides commands and flags, the scripts complete profile names, plugin IDs,
+skill names, session IDs, and agent IDs by asking nexus for them at completion
+time. Those values come from local state: the profiles directory, the config
+file, installed plugins, discovered skills, AGENTS.md, and the session database.`,
+		Example: `  # bash (requires bash-completion)
+  nexus completion bash > /etc/bash_completion.d/nexus
+
+  # zsh
+  nexus completion zsh > "${fpath[1]}/_nexus"
+
+  # fish
+  nexus completion fish > ~/.config/fish/completions/nexus.fish`,
+		ValidArgs:             []string{"bash", "zsh", "fish"},
+		Args:                  cobra.MatchAll(cobra.ExactArgs(1), cobra.OnlyValidArgs),
+		DisableFlagsInUseLine: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runCompletion(cmd, args[0])
+		},
+	}
+}
+
+// runCompletion writes the completion script for shell to stdout.
+func runCompletion(cmd *cobra.Command, shell string) error {
+	root := cmd.Root()
+	out := cmd.OutOrStdout()
+	switch shell {
+	case "bash":
+		return root.GenBashCompletionV2(out, true)
+	case "zsh":
+		return root.GenZshCompletion(out)
+	case "fish":
+		return root.GenFishCompletion(out, true)
+	default:
+		return fmt.Errorf("unsupported shell %q", shell)
+	}
+}
@@ -0,0 +1,86 @@
+package main
+
+import (
+	"github.com/haasonsaas/nexus/internal/config"
+	"github.com/haasonsaas/nexus/internal/profile"
+	"github.com/spf13/cobra"
+)
+
+// =============================================================================
+// Config Commands
+// =============================================================================
+
+// buildConfigCmd creates the "config" command group.
+func buildConfigCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "config",
+		Short: "Work with the configuration file",
+	}
+	cmd.AddCommand(buildConfigSchemaCmd())
+	cmd.AddCommand(buildConfigApplyCmd())
+	return cmd
+}
+
+func buildConfigSchemaCmd() *cobra.Command {
+	var output string
+	cmd := &cobra.Command{
+		Use:   "schema",
+		Short: "Print the JSON Schema for nexus.yaml",
+		Long: `Print the JSON Schema for the configuration file, with field descriptions
+and defaults. YAML language servers use it for completion, hover docs, and
+validation while editing nexus.yaml.
+
+The schema for the main branch is published at:
+
+  ` + config.SchemaURL + `
+
+Add this modeline to the top of a config file to use it:
+
+  # yaml-language-server: $schema=` + config.SchemaURL,
+		Example: `  # Print the schema
+  nexus config schema
+
+  # Write it next to the config for editors that work offline
+  nexus config schema -o nexus.schema.json`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runConfigSchema(cmd, output)
+		},
+	}
+	cmd.Flags().StringVarP(&output, "output", "o", "", "Write the schema to this file instead of stdout")
+	return cmd
+}
+
+func buildConfigApplyCmd() *cobra.Command {
+	var (
+		configPath string
+		fromGit    string
+		ref        string
+		dryRun     bool
+	)
+	cmd := &cobra.Command{
+		Use:   "apply",
+		Short: "Apply the config file from a git repository",
+		Long: `Fetch a config file from a git repository, validate it, and write it to
+the local config path. The changed keys are listed first; secret-looking
+values are masked.
+
+Running gateways with gitops.enabled keep following the repository on their
+own, applying hot-reloadable changes and logging the rest as drift.`,
+		Example: `  # Preview what the main branch would change
+  nexus config apply --from-git https://github.com/acme/infra.git#nexus/prod.yaml --dry-run
+
+  # Apply a release tag
+  nexus config apply --from-git git@github.com:acme/infra.git#nexus/prod.yaml --ref v1.4.0`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runConfigApply(cmd, configPath, fromGit, ref, dryRun)
+		},
+	}
+	cmd.Flags().StringVarP(&configPath, "config", "c", profile.DefaultConfigPath(), "Path to config file")
+	cmd.Flags().StringVar(&fromGit, "from-git", "", "Config file in git, as <repo>#<path>")
+	cmd.Flags().StringVar(&ref, "ref", "", "Branch, tag, or commit to read (default: the remote's default branch)")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Show the drift without writing the config")
+	_ = cmd.MarkFlagRequired("from-git")
+	return cmd
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"github.com/haasonsaas/nexus/internal/profile"
+	"github.com/spf13/cobra"
+)
+
+// =============================================================================
+// Debug Commands
+// =============================================================================
+
+// buildDebugCmd creates the "debug" command group.
+func buildDebugCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "debug",
+		Short: "Collect diagnostics for bug reports",
+	}
+	cmd.AddCommand(buildDebugBundleCmd())
+	return cmd
+}
+
+func buildDebugBundleCmd() *cobra.Command {
+	var (
+		configPath string
+		opts       debugBundleOptions
+	)
+	cmd := &cobra.Command{
+		Use:   "bundle",
+		Short: "Write a redacted diagnostics tarball to attach to a bug report",
+		Long: `Write a redacted diagnostics tarball to attach to a bug report.
+
+The bundle contains version and build info, the config with secrets masked,
+doctor output, the database schema version, recent gateway logs, and
+summaries (timings, counts, tokens; no message content) of the most recent
+traces in the trace directory. Every file is passed through secret
+redaction, and the contents are listed for review before anything is
+written: view a file, drop it, or write the bundle.`,
+		Example: `  # Review and write nexus-debug-<time>.tar.gz
+  nexus debug bundle
+
+  # Include a log file and skip the review
+  nexus debug bundle --log-file ~/.nexus/logs/gateway.log --yes -o bug.tar.gz`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runDebugBundle(cmd, configPath, opts)
+		},
+	}
+	cmd.Flags().StringVarP(&configPath, "config", "c", profile.DefaultConfigPath(),
+		"Path to YAML configuration file")
+	cmd.Flags().StringVarP(&opts.output, "output", "o", "", "Output path (default: nexus-debug-<time>.tar.gz)")
+	cmd.Flags().StringArrayVar(&opts.logFiles, "log-file", nil, "Log file to include (repeatable; default: the nexus systemd user journal)")
+	cmd.Flags().IntVar(&opts.logLines, "log-lines", 500, "Number of recent log lines to include per log")
+	cmd.Flags().StringVar(&opts.traceDir, "trace-dir", "", "Trace directory (default: $NEXUS_TRACE_DIR)")
+	cmd.Flags().IntVar(&opts.traces, "traces", 10, "Number of recent trace summaries to include")
+	cmd.Flags().BoolVarP(&opts.yes, "yes", "y", false, "Write the bundle without reviewing its contents")
+	return cmd
+}
@@ -12,23 +12,33 @@ import (
 // buildDoctorCmd creates the "doctor" command for config validation.
 func buildDoctorCmd() *cobra.Command {
 	var configPath string
-	var repair bool
-	var probe bool
-	var audit bool
+	var opts doctorOptions
 
 	cmd := &cobra.Command{
 		Use:   "doctor",
 		Short: "Validate configuration and plugin manifests",
+		Long: `Validate configuration and plugin manifests.
+
+Every run also audits file permissions on the config, workspace, and secret
+files, and checks that binaries required by enabled channels and tools
+(signal-cli, chrome, docker, firecracker, ffmpeg) are installed.`,
+		Example: `  # Tighten permissions on config and secret files
+  nexus doctor --fix-permissions
+
+  # Also check database schema drift and clock skew
+  nexus doctor --deep`,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runDoctor(cmd, configPath, repair, probe, audit)
+			return runDoctor(cmd, configPath, opts)
 		},
 	}
 
 	cmd.Flags().StringVarP(&configPath, "config", "c", profile.DefaultConfigPath(),
 		"Path to YAML configuration file")
-	cmd.Flags().BoolVar(&repair, "repair", false, "Apply migrations and common repairs")
-	cmd.Flags().BoolVar(&probe, "probe", false, "Run channel health probes")
-	cmd.Flags().BoolVar(&audit, "audit", false, "Audit service files and port availability")
+	cmd.Flags().BoolVar(&opts.repair, "repair", false, "Apply migrations and common repairs")
+	cmd.Flags().BoolVar(&opts.probe, "probe", false, "Run channel health probes and validate provider keys and channel tokens")
+	cmd.Flags().BoolVar(&opts.audit, "audit", false, "Audit service files and port availability")
+	cmd.Flags().BoolVar(&opts.fixPermissions, "fix-permissions", false, "Restrict config, workspace, and secret files to the owner")
+	cmd.Flags().BoolVar(&opts.deep, "deep", false, "Check database schema drift and clock skew")
 
 	return cmd
 }
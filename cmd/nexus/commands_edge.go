@@ -40,7 +40,7 @@ Shows the status of all components including:
 	cmd.Flags().StringVar(&serverAddr, "server", "", "Nexus HTTP server address (default from config)")
 	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output in JSON format")
 	cmd.Flags().StringVar(&token, "token", "", "JWT bearer token for server auth")
-	cmd.Flags().StringVar(&apiKey, "api-key", "", "API key for server auth")
+	cmd.Flags().StringVar(&apiKey, "api-key", "", "API key for server auth (default $NEXUS_API_KEY)")
 
 	return cmd
 }
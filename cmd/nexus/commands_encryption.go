@@ -0,0 +1,72 @@
+package main
+
+import (
+	"github.com/haasonsaas/nexus/internal/profile"
+	"github.com/spf13/cobra"
+)
+
+// =============================================================================
+// Encryption Commands
+// =============================================================================
+
+// buildEncryptionCmd creates the "encryption" command group.
+func buildEncryptionCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "encryption",
+		Short: "Manage encryption of stored data",
+		Long: `Manage field-level encryption of message content, vector memory entries,
+and artifact metadata.
+
+Encryption is configured under encryption.keys. To rotate keys, add a new key,
+point encryption.primary_key at it, run "nexus encryption migrate", and then
+remove the retired key.`,
+	}
+	cmd.AddCommand(
+		buildEncryptionKeygenCmd(),
+		buildEncryptionMigrateCmd(),
+	)
+	return cmd
+}
+
+// buildEncryptionKeygenCmd creates the "encryption keygen" command.
+func buildEncryptionKeygenCmd() *cobra.Command {
+	var id string
+	cmd := &cobra.Command{
+		Use:   "keygen",
+		Short: "Generate a new encryption key",
+		Long:  `Print a new random AES-256 key and the config entry that uses it.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runEncryptionKeygen(cmd, id)
+		},
+	}
+	cmd.Flags().StringVar(&id, "id", "", "Key id (default: current date, YYYY-MM)")
+	return cmd
+}
+
+// buildEncryptionMigrateCmd creates the "encryption migrate" command.
+func buildEncryptionMigrateCmd() *cobra.Command {
+	var (
+		configPath string
+		dryRun     bool
+	)
+	cmd := &cobra.Command{
+		Use:   "migrate",
+		Short: "Encrypt existing data with the primary key",
+		Long: `Encrypt stored plaintext and re-encrypt values written with retired keys
+so everything uses encryption.primary_key.
+
+Run it after enabling encryption on an existing database and after every key
+rotation. It is safe to run repeatedly.`,
+		Example: `  # Count what would be rewritten
+  nexus encryption migrate --dry-run
+
+  # Rewrite it
+  nexus encryption migrate`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runEncryptionMigrate(cmd, configPath, dryRun)
+		},
+	}
+	cmd.Flags().StringVarP(&configPath, "config", "c", profile.DefaultConfigPath(), "Path to YAML configuration file")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Count values that need encrypting without changing them")
+	return cmd
+}
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"github.com/haasonsaas/nexus/internal/profile"
+	"github.com/spf13/cobra"
+)
+
+// =============================================================================
+// Eval Commands
+// =============================================================================
+
+// evalOptions holds flags for the eval run command.
+type evalOptions struct {
+	configPath      string
+	provider        string
+	model           string
+	compareProvider string
+	compareModel    string
+	judgeProvider   string
+	judgeModel      string
+	format          string
+	output          string
+	filter          string
+	maxTokens       int
+	maxTurns        int
+	failOnScenarios bool
+	batch           bool
+}
+
+// buildEvalCmd creates the "eval" command group for scenario evaluation.
+func buildEvalCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "eval",
+		Short: "Run scenario evaluation suites against LLM providers",
+		Long: `Run YAML-defined scenario suites against configured LLM providers.
+
+Each scenario supplies an input message, optional stub tools the model may call,
+expected tool calls, and a rubric (substring/regex checks and/or an LLM judge).
+
+Example workflow:
+  nexus eval run suite.yaml                                # Score the default provider
+  nexus eval run suite.yaml --format junit -o results.xml  # CI-friendly output
+  nexus eval run suite.yaml --provider anthropic --compare-provider openai
+  nexus eval run suite.yaml --batch                        # Use the provider batch API`,
+	}
+	cmd.AddCommand(buildEvalRunCmd())
+	return cmd
+}
+
+func buildEvalRunCmd() *cobra.Command {
+	opts := evalOptions{}
+	cmd := &cobra.Command{
+		Use:   "run <suite.yaml>",
+		Short: "Run a scenario suite and score the results",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runEval(cmd, args[0], opts)
+		},
+	}
+	cmd.Flags().StringVarP(&opts.configPath, "config", "c", profile.DefaultConfigPath(), "Path to YAML configuration file")
+	cmd.Flags().StringVar(&opts.provider, "provider", "", "Provider ID to evaluate (defaults to llm.default_provider)")
+	cmd.Flags().StringVar(&opts.model, "model", "", "Model ID to evaluate (defaults to provider default)")
+	cmd.Flags().StringVar(&opts.compareProvider, "compare-provider", "", "Second provider ID to compare against")
+	cmd.Flags().StringVar(&opts.compareModel, "compare-model", "", "Second model ID to compare against")
+	cmd.Flags().StringVar(&opts.judgeProvider, "judge-provider", "", "Provider ID for the LLM judge (defaults to the evaluated provider)")
+	cmd.Flags().StringVar(&opts.judgeModel, "judge-model", "", "Model ID for the LLM judge")
+	cmd.Flags().StringVarP(&opts.format, "format", "f", "text", "Output format (text, json, junit)")
+	cmd.Flags().StringVarP(&opts.output, "output", "o", "", "Write output to file instead of stdout")
+	cmd.Flags().StringVar(&opts.filter, "filter", "", "Only run scenarios whose ID contains this substring")
+	cmd.Flags().IntVar(&opts.maxTokens, "max-tokens", 1024, "Max tokens per completion")
+	cmd.Flags().IntVar(&opts.maxTurns, "max-turns", 4, "Max tool-call turns per scenario")
+	cmd.Flags().BoolVar(&opts.failOnScenarios, "fail", false, "Exit non-zero when any scenario fails or errors")
+	cmd.Flags().BoolVar(&opts.batch, "batch", false, "Send completions through the provider batch API (slower, about half the cost)")
+	return cmd
+}
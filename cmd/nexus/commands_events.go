@@ -1,6 +1,8 @@
 package main
 
 import (
+	"time"
+
 	"github.com/haasonsaas/nexus/internal/profile"
 	"github.com/spf13/cobra"
 )
@@ -22,6 +24,7 @@ allowing you to trace exactly what happened during a run.`,
 	cmd.AddCommand(
 		buildEventsShowCmd(),
 		buildEventsListCmd(),
+		buildEventsQueryCmd(),
 	)
 	return cmd
 }
@@ -79,3 +82,39 @@ func buildEventsListCmd() *cobra.Command {
 	cmd.Flags().StringVarP(&sessionID, "session", "s", "", "Filter by session ID")
 	return cmd
 }
+
+func buildEventsQueryCmd() *cobra.Command {
+	var configPath string
+	var opts eventsQueryOptions
+	cmd := &cobra.Command{
+		Use:   "query",
+		Short: "Query persisted events",
+		Long: `Query events persisted by the database event store (observability.event_store).
+
+Filters combine; --type may be repeated. tool.failed, run.failed and llm.failed
+are accepted as aliases for the matching .error types. Results are printed in
+chronological order, keeping the most recent --limit events.`,
+		Example: `  # Failed tool calls in a session over the last hour
+  nexus events query --session main:slack:C123 --type tool.failed --since 1h
+
+  # Every error in the last day as JSON
+  nexus events query --errors --since 24h --format json
+
+  # The full timeline of a run
+  nexus events query --run run_123456 --since 0`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runEventsQuery(cmd, configPath, opts)
+		},
+	}
+	cmd.Flags().StringVarP(&configPath, "config", "c", profile.DefaultConfigPath(), "Path to YAML configuration file")
+	cmd.Flags().StringVarP(&opts.SessionID, "session", "s", "", "Filter by session ID")
+	cmd.Flags().StringVar(&opts.RunID, "run", "", "Filter by run ID")
+	cmd.Flags().StringVar(&opts.AgentID, "agent", "", "Filter by agent ID")
+	cmd.Flags().StringArrayVarP(&opts.Types, "type", "t", nil, "Filter by event type (repeatable, e.g. tool.error)")
+	cmd.Flags().StringVar(&opts.Name, "name", "", "Filter by event name (e.g. a tool name)")
+	cmd.Flags().BoolVar(&opts.ErrorsOnly, "errors", false, "Only show events that carry an error")
+	cmd.Flags().DurationVar(&opts.Since, "since", 24*time.Hour, "Only include events newer than this (0 for all)")
+	cmd.Flags().IntVarP(&opts.Limit, "limit", "n", 100, "Maximum number of events to show (0 for all)")
+	cmd.Flags().StringVarP(&opts.Format, "format", "f", "text", "Output format (text, json)")
+	return cmd
+}
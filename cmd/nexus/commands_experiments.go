@@ -0,0 +1,64 @@
+package main
+
+import (
+	"time"
+
+	"github.com/haasonsaas/nexus/internal/profile"
+	"github.com/spf13/cobra"
+)
+
+// =============================================================================
+// Experiments Commands
+// =============================================================================
+
+// buildExperimentsCmd creates the "experiments" command group for prompt and
+// model A/B tests.
+func buildExperimentsCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "experiments",
+		Short: "Inspect prompt and model experiments",
+		Long: `Inspect prompt and model experiments.
+
+Active experiments in the experiments config assign each user (or session)
+to a variant deterministically. The gateway logs an exposure for every run
+under a variant and the run's outcome when it finishes, and tags the run's
+traces with experiment.<id>=<variant>.`,
+	}
+	cmd.AddCommand(buildExperimentsReportCmd())
+	return cmd
+}
+
+func buildExperimentsReportCmd() *cobra.Command {
+	var (
+		configPath   string
+		logPath      string
+		feedbackPath string
+		experimentID string
+		since        time.Duration
+		jsonOutput   bool
+	)
+	cmd := &cobra.Command{
+		Use:   "report",
+		Short: "Compare outcome metrics per variant",
+		Long: `Compare outcome metrics per experiment variant.
+
+For each variant the report shows runs exposed, unique subjects, completed
+runs, failure rate, average latency, tokens, and tool calls, and the
+satisfaction ratio from reaction feedback on those runs.`,
+		Example: `  # Report on all experiments
+  nexus experiments report
+
+  # Report on one experiment over the last day
+  nexus experiments report --experiment system-prompt-v2 --since 24h`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runExperimentsReport(cmd, configPath, logPath, feedbackPath, experimentID, since, jsonOutput)
+		},
+	}
+	cmd.Flags().StringVarP(&configPath, "config", "c", profile.DefaultConfigPath(), "Path to YAML configuration file")
+	cmd.Flags().StringVar(&logPath, "log", "", "Experiment log path (defaults to experiments.log_path)")
+	cmd.Flags().StringVar(&feedbackPath, "feedback-store", "", "Feedback store path (defaults to observability.feedback.store_path)")
+	cmd.Flags().StringVar(&experimentID, "experiment", "", "Only report on this experiment ID")
+	cmd.Flags().DurationVar(&since, "since", 0, "Only include runs newer than this (0 for all)")
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output the report as JSON")
+	return cmd
+}
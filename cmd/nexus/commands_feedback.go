@@ -0,0 +1,62 @@
+package main
+
+import (
+	"time"
+
+	"github.com/haasonsaas/nexus/internal/profile"
+	"github.com/spf13/cobra"
+)
+
+// =============================================================================
+// Feedback Commands
+// =============================================================================
+
+// buildFeedbackCmd creates the "feedback" command group for reaction feedback.
+func buildFeedbackCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "feedback",
+		Short: "Inspect feedback captured from reactions on agent replies",
+		Long: `Inspect feedback captured from reactions on agent replies.
+
+When observability.feedback.enabled is set, thumbs up/down reactions on agent
+replies are stored per run and exported as Prometheus metrics
+(nexus_feedback_reactions_total, nexus_feedback_satisfaction_ratio).`,
+	}
+	cmd.AddCommand(buildFeedbackReportCmd())
+	return cmd
+}
+
+func buildFeedbackReportCmd() *cobra.Command {
+	var (
+		configPath string
+		storePath  string
+		traceDir   string
+		since      time.Duration
+		limit      int
+		jsonOutput bool
+	)
+	cmd := &cobra.Command{
+		Use:   "report",
+		Short: "Summarize feedback and list low-rated runs",
+		Long: `Summarize reaction feedback by channel and list low-rated runs.
+
+A run is low-rated when it has at least one negative reaction and no more
+positive than negative reactions. Each run links to its trace file when one
+exists in the trace directory.`,
+		Example: `  # Report on the last week
+  nexus feedback report
+
+  # Report on the last day with trace links
+  nexus feedback report --since 24h --trace-dir ./traces`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runFeedbackReport(cmd, configPath, storePath, traceDir, since, limit, jsonOutput)
+		},
+	}
+	cmd.Flags().StringVarP(&configPath, "config", "c", profile.DefaultConfigPath(), "Path to YAML configuration file")
+	cmd.Flags().StringVar(&storePath, "store", "", "Feedback store path (defaults to observability.feedback.store_path)")
+	cmd.Flags().StringVar(&traceDir, "trace-dir", "", "Directory containing trace files (defaults to NEXUS_TRACE_DIR)")
+	cmd.Flags().DurationVar(&since, "since", 7*24*time.Hour, "Only include feedback newer than this (0 for all)")
+	cmd.Flags().IntVarP(&limit, "limit", "n", 20, "Maximum number of low-rated runs to list")
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output the report as JSON")
+	return cmd
+}
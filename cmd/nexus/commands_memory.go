@@ -1,6 +1,8 @@
 package main
 
 import (
+	"time"
+
 	"github.com/haasonsaas/nexus/internal/profile"
 	"github.com/spf13/cobra"
 )
@@ -19,13 +21,18 @@ func buildMemoryCmd() *cobra.Command {
 Vector memory allows semantic search over conversation history
 and indexed documents using embedding models (OpenAI, Ollama).
 
-Storage backends: sqlite-vec (default), LanceDB, pgvector`,
+Storage backends: sqlite-vec (default), LanceDB, pgvector, Qdrant, Redis`,
 	}
 	cmd.AddCommand(
 		buildMemorySearchCmd(),
 		buildMemoryIndexCmd(),
 		buildMemoryStatsCmd(),
 		buildMemoryCompactCmd(),
+		buildMemoryDeleteCmd(),
+		buildMemoryReviewCmd(),
+		buildMemoryConsolidateCmd(),
+		buildMemoryMigrateCmd(),
+		buildMemoryReembedCmd(),
 	)
 	return cmd
 }
@@ -101,3 +108,184 @@ func buildMemoryCompactCmd() *cobra.Command {
 	cmd.Flags().StringVarP(&configPath, "config", "c", profile.DefaultConfigPath(), "Path to YAML configuration file")
 	return cmd
 }
+
+func buildMemoryDeleteCmd() *cobra.Command {
+	var (
+		configPath string
+		scope      string
+		scopeID    string
+		source     string
+		olderThan  time.Duration
+		reason     string
+		dryRun     bool
+		force      bool
+	)
+	cmd := &cobra.Command{
+		Use:   "delete [id...]",
+		Short: "Delete memories by ID, scope, source, or age",
+		Long: `Delete vector memory entries. Entries are selected by ID and narrowed by
+--scope, --scope-id, --source, and --older-than; at least one selector is
+required. The matching entries are listed before anything is deleted.
+
+Each deletion is appended to vector_memory.deletion_log (default
+~/.nexus/memory-deletions.jsonl) with the IDs, scopes, sources, and creation
+times of the deleted entries, but not their content.`,
+		Example: `  nexus memory delete 3f2c9d1e-5b7a-4c1e-9f0a-2d6b8e4c7a10
+  nexus memory delete --scope session --scope-id 0f1e2d3c --dry-run
+  nexus memory delete --source message --older-than 2160h --reason "retention"`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runMemoryDelete(cmd, configPath, args, scope, scopeID, source, olderThan, reason, dryRun, force)
+		},
+	}
+	cmd.Flags().StringVarP(&configPath, "config", "c", profile.DefaultConfigPath(), "Path to YAML configuration file")
+	cmd.Flags().StringVar(&scope, "scope", "", "Only delete memories in this scope (session, channel, agent, global)")
+	cmd.Flags().StringVar(&scopeID, "scope-id", "", "Only delete memories with this scope ID")
+	cmd.Flags().StringVar(&source, "source", "", "Only delete memories with this source label")
+	cmd.Flags().DurationVar(&olderThan, "older-than", 0, "Only delete memories older than this age (e.g. 720h)")
+	cmd.Flags().StringVar(&reason, "reason", "", "Reason recorded in the deletion log")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "List the matching memories without deleting them")
+	cmd.Flags().BoolVarP(&force, "force", "f", false, "Skip confirmation prompt")
+	return cmd
+}
+
+func buildMemoryReviewCmd() *cobra.Command {
+	var (
+		configPath string
+		all        bool
+		jsonOutput bool
+	)
+	cmd := &cobra.Command{
+		Use:   "review",
+		Short: "List memories waiting for approval",
+		Long: `List the memories the agent proposed while session.memory.review is
+enabled. Approved memories are written to the workspace memory file or
+vector memory; rejected ones are discarded.`,
+		Example: `  nexus memory review
+  nexus memory review approve 1a2b3c4d
+  nexus memory review reject 1a2b3c4d`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runMemoryReviewList(cmd, configPath, all, jsonOutput)
+		},
+	}
+	cmd.PersistentFlags().StringVarP(&configPath, "config", "c", profile.DefaultConfigPath(), "Path to YAML configuration file")
+	cmd.Flags().BoolVar(&all, "all", false, "Include approved and rejected memories")
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output as JSON")
+	cmd.AddCommand(
+		&cobra.Command{
+			Use:   "approve <id>",
+			Short: "Store a proposed memory",
+			Args:  cobra.ExactArgs(1),
+			RunE: func(cmd *cobra.Command, args []string) error {
+				return runMemoryReviewDecide(cmd, configPath, args[0], true)
+			},
+		},
+		&cobra.Command{
+			Use:   "reject <id>",
+			Short: "Discard a proposed memory",
+			Args:  cobra.ExactArgs(1),
+			RunE: func(cmd *cobra.Command, args []string) error {
+				return runMemoryReviewDecide(cmd, configPath, args[0], false)
+			},
+		},
+	)
+	return cmd
+}
+
+func buildMemoryConsolidateCmd() *cobra.Command {
+	var (
+		configPath string
+		dryRun     bool
+		jsonOutput bool
+	)
+	cmd := &cobra.Command{
+		Use:   "consolidate",
+		Short: "Fold old daily memory files into the memory file",
+		Long: `Summarize the daily memory files older than
+session.memory.consolidation.min_age_days into topic sections of the
+workspace memory file, move them to the archive directory, and index the
+topics into vector memory. This runs the scheduled consolidation job once.`,
+		Example: `  nexus memory consolidate --dry-run
+  nexus memory consolidate`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runMemoryConsolidate(cmd, configPath, dryRun, jsonOutput)
+		},
+	}
+	cmd.Flags().StringVarP(&configPath, "config", "c", profile.DefaultConfigPath(), "Path to YAML configuration file")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "List the files that would be consolidated")
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output as JSON")
+	return cmd
+}
+
+func buildMemoryMigrateCmd() *cobra.Command {
+	var (
+		configPath string
+		from       string
+		to         string
+		batchSize  int
+	)
+	cmd := &cobra.Command{
+		Use:   "migrate",
+		Short: "Copy vector memory from one backend to another",
+		Long: `Copy every memory entry, with its embedding, scopes, and timestamps, from
+one storage backend to another. Both backends are opened with their
+settings under vector_memory. Entries already in the target are
+overwritten, so an interrupted migration can be re-run. Switch
+vector_memory.backend to the target once the migration completes.
+
+Backends: sqlite-vec, lancedb, pgvector, qdrant, redis`,
+		Example: `  nexus memory migrate --from sqlite-vec --to qdrant
+  nexus memory migrate --from pgvector --to redis --batch 1000`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runMemoryMigrate(cmd, configPath, from, to, batchSize)
+		},
+	}
+	cmd.Flags().StringVarP(&configPath, "config", "c", profile.DefaultConfigPath(), "Path to YAML configuration file")
+	cmd.Flags().StringVar(&from, "from", "", "Source backend (default: vector_memory.backend)")
+	cmd.Flags().StringVar(&to, "to", "", "Target backend")
+	cmd.Flags().IntVar(&batchSize, "batch", 500, "Entries copied per batch")
+	_ = cmd.MarkFlagRequired("to")
+	return cmd
+}
+
+func buildMemoryReembedCmd() *cobra.Command {
+	var (
+		configPath string
+		provider   string
+		model      string
+		batchSize  int
+		rate       int
+		skipConfig bool
+	)
+	cmd := &cobra.Command{
+		Use:   "reembed",
+		Short: "Re-embed every memory with a new embedding model",
+		Long: `Recompute the embedding of every memory entry with another embedding
+model, in batches, and switch vector memory over to it. On sqlite-vec,
+lancedb and pgvector the new embeddings are written to a parallel index that
+replaces the live one in a single step once every entry is done, so the
+dimension may change and a failed run leaves memory as it was. Qdrant and
+Redis are updated in place and keep their dimension.
+
+On success vector_memory.embeddings.model and vector_memory.dimension are
+updated in the config file. Stop the gateway while this runs: memories it
+stores meanwhile may be lost, and it embeds queries with the old model until
+it is restarted.`,
+		Example: `  nexus memory reembed --model text-embedding-3-large
+  nexus memory reembed --provider ollama --model nomic-embed-text --rate 120`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runMemoryReembed(cmd, configPath, provider, model, batchSize, rate, !skipConfig)
+		},
+	}
+	cmd.Flags().StringVarP(&configPath, "config", "c", profile.DefaultConfigPath(), "Path to YAML configuration file")
+	cmd.Flags().StringVar(&provider, "provider", "", "Embedding provider (default: vector_memory.embeddings.provider)")
+	cmd.Flags().StringVar(&model, "model", "", "Embedding model to switch to")
+	cmd.Flags().IntVar(&batchSize, "batch", 100, "Entries re-embedded per batch")
+	cmd.Flags().IntVar(&rate, "rate", 0, "Maximum embedding requests per minute (0 for no limit)")
+	cmd.Flags().BoolVar(&skipConfig, "no-config-update", false, "Leave the config file unchanged")
+	_ = cmd.MarkFlagRequired("model")
+	return cmd
+}
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"github.com/haasonsaas/nexus/internal/profile"
+	"github.com/spf13/cobra"
+)
+
+// =============================================================================
+// Models Commands
+// =============================================================================
+
+// modelsListOptions holds flags for the models list command.
+type modelsListOptions struct {
+	configPath   string
+	provider     string
+	capabilities []string
+	all          bool
+	discover     bool
+	jsonOutput   bool
+}
+
+// buildModelsCmd creates the "models" command group for the model catalog.
+func buildModelsCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "models",
+		Short: "Inspect the model catalog",
+		Long: `Inspect the model catalog: context window, max output, tool and vision
+support, and pricing per model.
+
+The catalog is bundled with nexus and used for routing, context budgeting,
+and cost estimates. The gateway adds models its providers report that the
+bundled data does not cover.`,
+	}
+	cmd.AddCommand(buildModelsListCmd())
+	return cmd
+}
+
+func buildModelsListCmd() *cobra.Command {
+	opts := modelsListOptions{}
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List catalog models and their capabilities",
+		Example: `  # Every current model
+  nexus models list
+
+  # Anthropic models that take images, as JSON
+  nexus models list --provider anthropic --capability vision --json
+
+  # Include models reported by the providers in the config
+  nexus models list --discover`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runModelsList(cmd, opts)
+		},
+	}
+	cmd.Flags().StringVarP(&opts.configPath, "config", "c", profile.DefaultConfigPath(), "Path to YAML configuration file (used with --discover)")
+	cmd.Flags().StringVar(&opts.provider, "provider", "", "Only list models from this provider")
+	cmd.Flags().StringSliceVar(&opts.capabilities, "capability", nil, "Only list models with this capability (tools, vision, reasoning, ...); repeatable")
+	cmd.Flags().BoolVar(&opts.all, "all", false, "Include deprecated models")
+	cmd.Flags().BoolVar(&opts.discover, "discover", false, "Add models reported by the providers configured in the config file")
+	cmd.Flags().BoolVar(&opts.jsonOutput, "json", false, "Output in JSON format")
+	return cmd
+}
@@ -0,0 +1,108 @@
+package main
+
+import (
+	"github.com/haasonsaas/nexus/internal/profile"
+	"github.com/spf13/cobra"
+)
+
+// =============================================================================
+// Privacy Commands
+// =============================================================================
+
+// buildPrivacyCmd creates the "privacy" command group.
+func buildPrivacyCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "privacy",
+		Short: "Enforce data retention and erase personal data",
+		Long: `Apply data retention policies and erase the data held for a person.
+
+Retention policies are configured under privacy.retention with a default
+policy plus per-channel and per-user overrides. A running gateway enforces
+them every privacy.retention.interval when privacy.retention.enabled is set.`,
+	}
+	cmd.AddCommand(
+		buildPrivacyEraseCmd(),
+		buildPrivacyVerifyCmd(),
+		buildPrivacyEnforceCmd(),
+	)
+	return cmd
+}
+
+// buildPrivacyEraseCmd creates the "privacy erase" command.
+func buildPrivacyEraseCmd() *cobra.Command {
+	var (
+		configPath  string
+		serverAddr  string
+		apiKey      string
+		peer        string
+		requestedBy string
+		force       bool
+	)
+	cmd := &cobra.Command{
+		Use:   "erase",
+		Short: "Erase all data held for a peer",
+		Long: `Erase the sessions, messages, artifacts, and session memories of a peer.
+
+Identities linked to the peer through session.scoping.identity_links are
+erased as well. A signed record of the erasure is written to
+privacy.erasure.audit_dir.
+
+With --server the running gateway performs the erasure through the management
+API. This needs an admin API key (--api-key or NEXUS_API_KEY), and the attempt
+is recorded in the gateway's admin audit log.`,
+		Example: `  # Erase a Telegram user
+  nexus privacy erase --peer telegram:123456789
+
+  # Record who requested the erasure, without prompting
+  nexus privacy erase --peer slack:U024BE7LH --requested-by dpo@example.com --force
+
+  # Erase through a remote gateway
+  NEXUS_API_KEY=... nexus privacy erase --server https://nexus.example.com --peer telegram:123456789`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if serverAddr != "" {
+				return runPrivacyEraseRemote(cmd, serverAddr, apiKey, peer, requestedBy, force)
+			}
+			return runPrivacyErase(cmd, configPath, peer, requestedBy, force)
+		},
+	}
+	cmd.Flags().StringVarP(&configPath, "config", "c", profile.DefaultConfigPath(), "Path to YAML configuration file")
+	cmd.Flags().StringVar(&peer, "peer", "", "Peer identity to erase (provider:peer_id)")
+	cmd.Flags().StringVar(&requestedBy, "requested-by", "", "Who requested the erasure, recorded in the audit record")
+	cmd.Flags().BoolVarP(&force, "force", "f", false, "Skip confirmation prompt")
+	cmd.Flags().StringVar(&serverAddr, "server", "", "Erase through the Nexus HTTP server at this address instead of the local stores")
+	cmd.Flags().StringVar(&apiKey, "api-key", "", "Admin API key for server auth (default $NEXUS_API_KEY)")
+	_ = cmd.MarkFlagRequired("peer")
+	return cmd
+}
+
+// buildPrivacyVerifyCmd creates the "privacy verify" command.
+func buildPrivacyVerifyCmd() *cobra.Command {
+	var configPath string
+	cmd := &cobra.Command{
+		Use:   "verify <record.json>",
+		Short: "Verify a signed erasure record",
+		Long: `Check that an erasure record is unmodified and was signed with the
+configured privacy.erasure.signing_key_path.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runPrivacyVerify(cmd, configPath, args[0])
+		},
+	}
+	cmd.Flags().StringVarP(&configPath, "config", "c", profile.DefaultConfigPath(), "Path to YAML configuration file")
+	return cmd
+}
+
+// buildPrivacyEnforceCmd creates the "privacy enforce" command.
+func buildPrivacyEnforceCmd() *cobra.Command {
+	var configPath string
+	cmd := &cobra.Command{
+		Use:   "enforce",
+		Short: "Run a retention sweep now",
+		Long:  `Delete data older than the configured retention policies without waiting for the gateway's schedule.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runPrivacyEnforce(cmd, configPath)
+		},
+	}
+	cmd.Flags().StringVarP(&configPath, "config", "c", profile.DefaultConfigPath(), "Path to YAML configuration file")
+	return cmd
+}
@@ -1,6 +1,11 @@
 package main
 
-import "github.com/spf13/cobra"
+import (
+	"os"
+
+	"github.com/haasonsaas/nexus/internal/config"
+	"github.com/spf13/cobra"
+)
 
 // =============================================================================
 // Profile Commands
@@ -12,7 +17,7 @@ func buildProfileCmd() *cobra.Command {
 		Use:   "profile",
 		Short: "Manage configuration profiles",
 	}
-	cmd.AddCommand(buildProfileListCmd(), buildProfileUseCmd(), buildProfilePathCmd(), buildProfileInitCmd())
+	cmd.AddCommand(buildProfileListCmd(), buildProfileUseCmd(), buildProfilePathCmd(), buildProfileInitCmd(), buildProfileDiffCmd())
 	return cmd
 }
 
@@ -67,3 +72,26 @@ func buildProfileInitCmd() *cobra.Command {
 	cmd.Flags().BoolVar(&setActive, "use", false, "Set as active profile after creation")
 	return cmd
 }
+
+func buildProfileDiffCmd() *cobra.Command {
+	var env string
+	cmd := &cobra.Command{
+		Use:   "diff [a] [b]",
+		Short: "Show config differences between two profiles",
+		Long: `Show the keys that differ between two profiles after resolving extends,
+includes, and the environment overlay.
+
+Arguments are profile names or paths to config files.`,
+		Example: `  # Compare staging against prod
+  nexus profile diff staging prod
+
+  # Compare with the prod environment overlay applied to both
+  nexus profile diff base team --env prod`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runProfileDiff(cmd, args[0], args[1], env)
+		},
+	}
+	cmd.Flags().StringVar(&env, "env", os.Getenv(config.EnvironmentVar), "Environment overlay to apply (defaults to $NEXUS_ENV)")
+	return cmd
+}
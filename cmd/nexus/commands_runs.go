@@ -0,0 +1,131 @@
+package main
+
+import (
+	"github.com/haasonsaas/nexus/internal/profile"
+	"github.com/spf13/cobra"
+)
+
+// =============================================================================
+// Runs Commands
+// =============================================================================
+
+// buildRunsCmd creates the "runs" command group for checkpointed runs.
+func buildRunsCmd() *cobra.Command {
+	var opts runsOptions
+	cmd := &cobra.Command{
+		Use:   "runs",
+		Short: "Inspect, resume, and kill agent runs",
+		Long: `Inspect and resume long agent runs saved by run checkpointing, and stop
+runaway runs.
+
+With session.checkpoints enabled, the gateway saves the full conversation of a
+run (messages, pending tool calls, and tool outputs) every interval. A run
+that fails or is cut short by a restart keeps its checkpoint until it is
+resumed or pruned by max_age and max_count. These commands act on the running
+gateway through the management API and need an admin API key (--api-key or
+NEXUS_API_KEY).`,
+	}
+	cmd.PersistentFlags().StringVarP(&opts.configPath, "config", "c", profile.DefaultConfigPath(), "Path to YAML configuration file")
+	cmd.PersistentFlags().StringVar(&opts.serverAddr, "server", "", "Nexus HTTP server address (default from config)")
+	cmd.PersistentFlags().StringVar(&opts.token, "token", "", "JWT for server auth")
+	cmd.PersistentFlags().StringVar(&opts.apiKey, "api-key", "", "Admin API key for server auth (default $NEXUS_API_KEY)")
+	cmd.AddCommand(
+		buildRunsListCmd(&opts),
+		buildRunsShowCmd(&opts),
+		buildRunsResumeCmd(&opts),
+		buildRunsKillCmd(&opts),
+	)
+	return cmd
+}
+
+// buildRunsListCmd creates the "runs list" command.
+func buildRunsListCmd(opts *runsOptions) *cobra.Command {
+	var (
+		status     string
+		jsonOutput bool
+	)
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List checkpointed runs",
+		Example: `  # List every checkpointed run
+  nexus runs list
+
+  # Only runs interrupted by a restart, as JSON
+  nexus runs list --status interrupted --json`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runRunsList(cmd, *opts, status, jsonOutput)
+		},
+	}
+	cmd.Flags().StringVar(&status, "status", "", "Only list runs with this status (running, interrupted, failed)")
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output in JSON format")
+	return cmd
+}
+
+// buildRunsShowCmd creates the "runs show" command.
+func buildRunsShowCmd(opts *runsOptions) *cobra.Command {
+	var jsonOutput bool
+	cmd := &cobra.Command{
+		Use:     "show <id>",
+		Short:   "Show a checkpointed run",
+		Example: `  nexus runs show 6f1c0e9a-2b1d-4c47-9a51-1f0b8f6f2e4d-1718000000`,
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runRunsShow(cmd, *opts, args[0], jsonOutput)
+		},
+	}
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output in JSON format")
+	return cmd
+}
+
+// buildRunsResumeCmd creates the "runs resume" command.
+func buildRunsResumeCmd(opts *runsOptions) *cobra.Command {
+	return &cobra.Command{
+		Use:   "resume <id>",
+		Short: "Resume a run from its last checkpoint",
+		Long: `Continue a run from its last checkpoint in the conversation it started in.
+
+The run picks up with the saved messages and tool outputs. Tool calls that
+were still pending are reported to the model as interrupted so it can check
+their effects before calling them again. The reply is delivered to the
+original channel; the command returns once the run has started.`,
+		Example: `  nexus runs resume 6f1c0e9a-2b1d-4c47-9a51-1f0b8f6f2e4d-1718000000`,
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runRunsResume(cmd, *opts, args[0])
+		},
+	}
+}
+
+// buildRunsKillCmd creates the "runs kill" command.
+func buildRunsKillCmd(opts *runsOptions) *cobra.Command {
+	var all bool
+	cmd := &cobra.Command{
+		Use:   "kill [id]",
+		Short: "Stop an active run",
+		Long: `Stop an active run at once, like /stop in its conversation.
+
+The id is a run ID or the ID of the session the run belongs to. With --all,
+every active run on the gateway is stopped. Tool calls already in progress
+are cancelled; their effects are not undone.`,
+		Example: `  # Stop one run
+  nexus runs kill 6f1c0e9a-2b1d-4c47-9a51-1f0b8f6f2e4d-1718000000
+
+  # Stop everything
+  nexus runs kill --all`,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if all {
+				return cobra.NoArgs(cmd, args)
+			}
+			return cobra.ExactArgs(1)(cmd, args)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id := ""
+			if len(args) > 0 {
+				id = args[0]
+			}
+			return runRunsKill(cmd, *opts, id, all)
+		},
+	}
+	cmd.Flags().BoolVar(&all, "all", false, "Stop every active run")
+	return cmd
+}
@@ -0,0 +1,175 @@
+package main
+
+import (
+	"github.com/haasonsaas/nexus/internal/profile"
+	"github.com/spf13/cobra"
+)
+
+// =============================================================================
+// Sandbox Commands
+// =============================================================================
+
+// buildSandboxCmd creates the "sandbox" command group.
+func buildSandboxCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "sandbox",
+		Short: "Manage the code execution sandbox",
+	}
+	cmd.AddCommand(
+		buildSandboxSnapshotsCmd(),
+		buildSandboxBuildRootfsCmd(),
+	)
+	return cmd
+}
+
+// buildSandboxSnapshotsCmd creates the "sandbox snapshots" command group.
+func buildSandboxSnapshotsCmd() *cobra.Command {
+	var opts sandboxSnapshotOptions
+	cmd := &cobra.Command{
+		Use:   "snapshots",
+		Short: "Manage Firecracker VM snapshots",
+		Long: `Manage the Firecracker snapshots the running gateway boots sandbox VMs from.
+
+Snapshots are enabled with tools.sandbox.snapshots. The gateway refreshes a
+language's snapshot once it is older than max_age, checking every
+refresh_interval randomized by jitter. These commands act on the running
+gateway through the management API and need an admin API key (--api-key or
+NEXUS_API_KEY).`,
+	}
+	cmd.PersistentFlags().StringVarP(&opts.configPath, "config", "c", profile.DefaultConfigPath(), "Path to YAML configuration file")
+	cmd.PersistentFlags().StringVar(&opts.serverAddr, "server", "", "Nexus HTTP server address (default from config)")
+	cmd.PersistentFlags().StringVar(&opts.token, "token", "", "JWT for server auth")
+	cmd.PersistentFlags().StringVar(&opts.apiKey, "api-key", "", "Admin API key for server auth (default $NEXUS_API_KEY)")
+	cmd.AddCommand(
+		buildSandboxSnapshotsListCmd(&opts),
+		buildSandboxSnapshotsCreateCmd(&opts),
+		buildSandboxSnapshotsRefreshCmd(&opts),
+		buildSandboxSnapshotsDeleteCmd(&opts),
+	)
+	return cmd
+}
+
+// buildSandboxSnapshotsListCmd creates the "sandbox snapshots list" command.
+func buildSandboxSnapshotsListCmd(opts *sandboxSnapshotOptions) *cobra.Command {
+	var (
+		language   string
+		jsonOutput bool
+	)
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List snapshots with their age and size",
+		Example: `  # List every snapshot
+  nexus sandbox snapshots list
+
+  # Only Python snapshots, as JSON
+  nexus sandbox snapshots list --language python --json`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runSandboxSnapshotsList(cmd, *opts, language, jsonOutput)
+		},
+	}
+	cmd.Flags().StringVar(&language, "language", "", "Only list snapshots for this language")
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output in JSON format")
+	return cmd
+}
+
+// buildSandboxSnapshotsCreateCmd creates the "sandbox snapshots create" command.
+func buildSandboxSnapshotsCreateCmd(opts *sandboxSnapshotOptions) *cobra.Command {
+	var language string
+	cmd := &cobra.Command{
+		Use:   "create",
+		Short: "Snapshot a pooled VM",
+		Long: `Snapshot an idle pooled VM for a language, booting one if none is idle.
+
+Existing snapshots are kept; new VMs boot from the newest one.`,
+		Example: `  nexus sandbox snapshots create --language python`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runSandboxSnapshotsCreate(cmd, *opts, language)
+		},
+	}
+	cmd.Flags().StringVar(&language, "language", "", "Language of the VM to snapshot (python, nodejs, go, bash)")
+	_ = cmd.MarkFlagRequired("language")
+	return cmd
+}
+
+// buildSandboxSnapshotsRefreshCmd creates the "sandbox snapshots refresh" command.
+func buildSandboxSnapshotsRefreshCmd(opts *sandboxSnapshotOptions) *cobra.Command {
+	var language string
+	cmd := &cobra.Command{
+		Use:   "refresh",
+		Short: "Replace snapshots with fresh ones",
+		Long: `Take a new snapshot and delete the ones it replaces, for one language or
+for every language.
+
+Refreshing resets the language's refresh schedule.`,
+		Example: `  # Refresh every language
+  nexus sandbox snapshots refresh
+
+  # Refresh Node.js only
+  nexus sandbox snapshots refresh --language nodejs`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runSandboxSnapshotsRefresh(cmd, *opts, language)
+		},
+	}
+	cmd.Flags().StringVar(&language, "language", "", "Only refresh this language")
+	return cmd
+}
+
+// buildSandboxSnapshotsDeleteCmd creates the "sandbox snapshots delete" command.
+func buildSandboxSnapshotsDeleteCmd(opts *sandboxSnapshotOptions) *cobra.Command {
+	return &cobra.Command{
+		Use:     "delete <id>...",
+		Short:   "Delete snapshots",
+		Example: `  nexus sandbox snapshots delete vm-python-3-1760000000000000000`,
+		Args:    cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runSandboxSnapshotsDelete(cmd, *opts, args)
+		},
+	}
+}
+
+// buildSandboxBuildRootfsCmd creates the "sandbox build-rootfs" command.
+func buildSandboxBuildRootfsCmd() *cobra.Command {
+	var (
+		opts       sandboxBuildRootfsOptions
+		jsonOutput bool
+	)
+	cmd := &cobra.Command{
+		Use:   "build-rootfs",
+		Short: "Build Firecracker guest root filesystems",
+		Long: `Build the ext4 root filesystems the Firecracker backend boots, one per
+language, with the Nexus guest agent installed as init.
+
+Each image starts from a container image exported with Docker and is packed
+with mkfs.ext4, so both must be on PATH. The guest agent is cross-compiled
+from source unless --guest-agent points at a prebuilt binary. With
+--kernel-url the guest kernel is downloaded too.
+
+Outputs are versioned (rootfs-python-<version>.ext4); the names the backend
+reads (rootfs-python.ext4, vmlinux) are symlinks switched to the new build
+once it succeeds. Every build writes manifest-<version>.json and
+SHA256SUMS-<version>.`,
+		Example: `  # Build every language into /var/lib/firecracker
+  sudo nexus sandbox build-rootfs
+
+  # Python, Node.js and Go only, with a kernel
+  nexus sandbox build-rootfs --languages python,node,go --output ./images \
+    --kernel-url https://example.com/vmlinux-6.1 --kernel-sha256 <sha256>
+
+  # Use a custom Python image
+  nexus sandbox build-rootfs --languages python --image python=python:3.13-alpine`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runSandboxBuildRootfs(cmd, opts, jsonOutput)
+		},
+	}
+	cmd.Flags().StringSliceVar(&opts.languages, "languages", []string{"python", "nodejs", "go", "bash"}, "Languages to build (python, nodejs, go, bash)")
+	cmd.Flags().StringVarP(&opts.output, "output", "o", "/var/lib/firecracker", "Directory for the images, manifest, and checksums")
+	cmd.Flags().StringVar(&opts.version, "version", "", "Version for the outputs (default UTC build time)")
+	cmd.Flags().StringVar(&opts.arch, "arch", "", "Guest architecture, amd64 or arm64 (default host architecture)")
+	cmd.Flags().StringArrayVar(&opts.images, "image", nil, "Base image for a language as language=image (repeatable)")
+	cmd.Flags().IntVar(&opts.sizeMB, "size-mb", 0, "Image size in MiB (default fits the contents)")
+	cmd.Flags().StringVar(&opts.guestAgent, "guest-agent", "", "Prebuilt guest agent binary (default build from source)")
+	cmd.Flags().StringVar(&opts.kernelURL, "kernel-url", "", "URL of a guest kernel to download")
+	cmd.Flags().StringVar(&opts.kernelSHA256, "kernel-sha256", "", "Expected SHA-256 of the downloaded kernel")
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output the manifest as JSON")
+	return cmd
+}
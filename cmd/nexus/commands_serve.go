@@ -1,7 +1,10 @@
 package main
 
 import (
+	"context"
+
 	"github.com/haasonsaas/nexus/internal/profile"
+	"github.com/haasonsaas/nexus/internal/service"
 	"github.com/spf13/cobra"
 )
 
@@ -30,7 +33,8 @@ The server will:
 5. Start the gRPC server for API access
 6. Start the HTTP server for health checks and metrics
 
-Graceful shutdown is handled on SIGINT/SIGTERM signals.`,
+Graceful shutdown is handled on SIGINT/SIGTERM signals, or on stop requests
+from the service control manager when running as a Windows service.`,
 		Example: `  # Start with default config
   nexus serve
 
@@ -41,6 +45,11 @@ Graceful shutdown is handled on SIGINT/SIGTERM signals.`,
   nexus serve --debug`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			configPath = resolveConfigPath(configPath)
+			if handled, err := service.RunWindowsService(func(ctx context.Context) error {
+				return runServe(ctx, configPath, debug)
+			}); handled || err != nil {
+				return err
+			}
 			return runServe(cmd.Context(), configPath, debug)
 		},
 	}
@@ -15,7 +15,81 @@ func buildSessionsCmd() *cobra.Command {
 		Use:   "sessions",
 		Short: "Manage sessions and branches",
 	}
-	cmd.AddCommand(buildSessionsBranchesCmd())
+	cmd.AddCommand(buildSessionsBranchesCmd(), buildSessionsShowCmd(), buildSessionsRenderCmd(), buildSessionsContextCmd())
+	return cmd
+}
+
+func buildSessionsShowCmd() *cobra.Command {
+	var (
+		configPath string
+		jsonOutput bool
+	)
+	cmd := &cobra.Command{
+		Use:   "show <session-id>",
+		Short: "Show a session with its parent/child lineage and branches",
+		Long: `Show a stored session: its key, agent, channel, and timestamps, the parent
+and child sessions it is linked to by handoffs, and its conversation branch
+tree, marking the active branch that new messages go to. Branches are created
+by /branch and /undo.`,
+		Example: `  nexus sessions show 3f2a9c1e
+  nexus sessions show 3f2a9c1e --json`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runSessionsShow(cmd, configPath, args[0], jsonOutput)
+		},
+	}
+	cmd.Flags().StringVarP(&configPath, "config", "c", profile.DefaultConfigPath(), "Path to YAML configuration file")
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output as JSON")
+	return cmd
+}
+
+func buildSessionsRenderCmd() *cobra.Command {
+	var (
+		configPath string
+		format     string
+		outputPath string
+	)
+	cmd := &cobra.Command{
+		Use:   "render <session-id>",
+		Short: "Render a session transcript as Markdown or HTML",
+		Long: `Render a stored session as a shareable transcript, including tool calls,
+attachment links, and a token and estimated cost summary.`,
+		Example: `  nexus sessions render 3f2a9c1e
+  nexus sessions render 3f2a9c1e --format html -o transcript.html`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runSessionsRender(cmd, configPath, args[0], format, outputPath)
+		},
+	}
+	cmd.Flags().StringVarP(&configPath, "config", "c", profile.DefaultConfigPath(), "Path to YAML configuration file")
+	cmd.Flags().StringVarP(&format, "format", "f", "markdown", "Output format: markdown or html")
+	cmd.Flags().StringVarP(&outputPath, "output", "o", "", "Write to a file instead of stdout")
+	return cmd
+}
+
+func buildSessionsContextCmd() *cobra.Command {
+	var (
+		configPath string
+		model      string
+		jsonOutput bool
+	)
+	cmd := &cobra.Command{
+		Use:   "context <session-id>",
+		Short: "Show what the next run of a session would pack into context",
+		Long: `Pack a stored session's history the way the agent runtime does before a
+model call and list every message with its size, age, and why it was included
+or dropped, with totals against the model's context window. The system prompt
+and tool definitions are not counted.`,
+		Example: `  nexus sessions context 3f2a9c1e
+  nexus sessions context 3f2a9c1e --model gpt-4o --json`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runSessionsContext(cmd, configPath, args[0], model, jsonOutput)
+		},
+	}
+	cmd.Flags().StringVarP(&configPath, "config", "c", profile.DefaultConfigPath(), "Path to YAML configuration file")
+	cmd.Flags().StringVar(&model, "model", "", "Model whose context window to compare against (default: the session's model)")
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output the packing diagnostics as JSON")
 	return cmd
 }
 
@@ -45,6 +45,13 @@ func buildOnboardCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "onboard",
 		Short: "Create a Nexus config file with guided prompts",
+		Long: `Create a Nexus config file with an interactive terminal wizard.
+
+The wizard checks each credential with a live API call, offers the
+provider's model list, configures one channel, and finishes by sending a
+test message through the model and back to the channel.
+
+Use --non-interactive to write the config from flags alone.`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return runOnboard(cmd, &opts, nonInteractive, setupWorkspace)
 		},
@@ -55,6 +62,7 @@ func buildOnboardCmd() *cobra.Command {
 	cmd.Flags().StringVar(&opts.JWTSecret, "jwt-secret", "", "JWT secret (generated if empty)")
 	cmd.Flags().StringVar(&opts.Provider, "provider", "anthropic", "Default LLM provider")
 	cmd.Flags().StringVar(&opts.ProviderKey, "provider-key", "", "Provider API key")
+	cmd.Flags().StringVar(&opts.Model, "model", "", "Default model for the provider")
 	cmd.Flags().BoolVar(&opts.EnableTelegram, "enable-telegram", false, "Enable Telegram channel")
 	cmd.Flags().StringVar(&opts.TelegramToken, "telegram-token", "", "Telegram bot token")
 	cmd.Flags().BoolVar(&opts.EnableDiscord, "enable-discord", false, "Enable Discord channel")
@@ -78,6 +86,7 @@ func buildAuthCmd() *cobra.Command {
 		Short: "Manage provider credentials",
 	}
 	cmd.AddCommand(buildAuthSetCmd())
+	cmd.AddCommand(buildAuthRotateCmd())
 	return cmd
 }
 
@@ -104,3 +113,37 @@ func buildAuthSetCmd() *cobra.Command {
 
 	return cmd
 }
+
+func buildAuthRotateCmd() *cobra.Command {
+	var (
+		configPath string
+		provider   string
+		keyID      string
+		newKey     string
+		skipVerify bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "rotate",
+		Short: "Replace a provider API key without restarting the gateway",
+		Long: `Replace one API key of a provider in the config file, or in the key_file
+it points to. Each file is replaced atomically, and a running gateway swaps the
+key in on its next config reload, so in-flight requests are not interrupted.
+
+The new key is checked against the provider first. Pass it with --key or enter
+it when prompted.`,
+		Example: `  nexus auth rotate --provider anthropic
+  nexus auth rotate --provider openai --key-id backup`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runAuthRotate(cmd, configPath, provider, keyID, newKey, skipVerify)
+		},
+	}
+
+	cmd.Flags().StringVarP(&configPath, "config", "c", profile.DefaultConfigPath(), "Path to YAML configuration file")
+	cmd.Flags().StringVar(&provider, "provider", "anthropic", "Provider whose key to rotate")
+	cmd.Flags().StringVar(&keyID, "key-id", "", `Key to replace: an id from llm.providers.<provider>.keys, or "default" for api_key`)
+	cmd.Flags().StringVar(&newKey, "key", "", "New API key (prompted when omitted)")
+	cmd.Flags().BoolVar(&skipVerify, "skip-verify", false, "Skip checking the new key against the provider")
+
+	return cmd
+}
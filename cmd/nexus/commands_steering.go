@@ -0,0 +1,58 @@
+package main
+
+import (
+	"github.com/haasonsaas/nexus/internal/profile"
+	"github.com/spf13/cobra"
+)
+
+// =============================================================================
+// Steering Commands
+// =============================================================================
+
+// buildSteeringCmd creates the "steering" command group for steering rules.
+func buildSteeringCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "steering",
+		Short: "Inspect and test steering rules",
+		Long: `Inspect and test steering rules.
+
+Steering rules inject extra directives into the system prompt when a message
+matches. Per-rule hit counts are shown by "nexus status" and exported as
+nexus_steering_rule_hits_total.`,
+	}
+	cmd.AddCommand(buildSteeringTestCmd())
+	return cmd
+}
+
+func buildSteeringTestCmd() *cobra.Command {
+	var (
+		configPath string
+		opts       steeringTestOptions
+		jsonOutput bool
+	)
+	cmd := &cobra.Command{
+		Use:   "test",
+		Short: "Show which steering rules would fire for a message",
+		Long: `Evaluate the configured steering rules against a sample message without
+sending anything. Matched rules are listed in injection order with the reason
+they matched; the remaining rules are listed with the reason they were skipped.`,
+		Example: `  # Which rules fire for a Slack message?
+  nexus steering test --message "can you confirm the renewal?" --channel slack
+
+  # Include tags and metadata the rules match on
+  nexus steering test -m "hi" --channel discord --tag vip --meta account=acme`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runSteeringTest(cmd, resolveConfigPath(configPath), opts, jsonOutput)
+		},
+	}
+	cmd.Flags().StringVarP(&configPath, "config", "c", profile.DefaultConfigPath(), "Path to YAML configuration file")
+	cmd.Flags().StringVarP(&opts.Message, "message", "m", "", "Message content to test (required)")
+	cmd.Flags().StringVar(&opts.Channel, "channel", "", "Channel the message arrives on (e.g. slack, telegram)")
+	cmd.Flags().StringVar(&opts.Agent, "agent", "", "Agent ID handling the message (defaults to session.default_agent_id)")
+	cmd.Flags().StringVar(&opts.Role, "role", "user", "Message role")
+	cmd.Flags().StringSliceVar(&opts.Tags, "tag", nil, "Message tag (repeatable)")
+	cmd.Flags().StringArrayVar(&opts.Metadata, "meta", nil, "Message metadata as key=value (repeatable)")
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output the result as JSON")
+	_ = cmd.MarkFlagRequired("message")
+	return cmd
+}
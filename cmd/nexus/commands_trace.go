@@ -22,12 +22,14 @@ Trace files record agent events in JSONL format for:
 Example workflow:
   nexus trace validate run.jsonl     # Check trace structure
   nexus trace stats run.jsonl        # View computed statistics
-  nexus trace replay run.jsonl       # Replay events to stdout`,
+  nexus trace replay run.jsonl       # Replay events to stdout
+  nexus trace profile run.jsonl      # Export a timeline of model and tool time`,
 	}
 	cmd.AddCommand(
 		buildTraceValidateCmd(),
 		buildTraceStatsCmd(),
 		buildTraceReplayCmd(),
+		buildTraceProfileCmd(),
 	)
 	return cmd
 }
@@ -118,3 +120,36 @@ Views:
 
 	return cmd
 }
+
+func buildTraceProfileCmd() *cobra.Command {
+	var (
+		format string
+		output string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "profile <file>",
+		Short: "Export a flame graph or timeline of a trace",
+		Long: `Export the timeline of a traced run: each iteration, split into model
+time and tool time, with parallel tool calls on separate lanes.
+
+Formats:
+  --format=chrome   Chrome trace-event JSON (default); open in
+                    chrome://tracing, https://ui.perfetto.dev or speedscope
+  --format=folded   Folded stacks for flamegraph.pl or speedscope
+                    (self time in microseconds)
+
+Example:
+  nexus trace profile run.jsonl --format chrome -o run.json
+  nexus trace profile run.jsonl --format folded | flamegraph.pl > run.svg`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runTraceProfile(cmd, args[0], format, output)
+		},
+	}
+
+	cmd.Flags().StringVar(&format, "format", "chrome", "Output format (chrome, folded)")
+	cmd.Flags().StringVarP(&output, "output", "o", "", "Write to a file instead of stdout")
+
+	return cmd
+}
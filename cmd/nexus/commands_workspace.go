@@ -0,0 +1,71 @@
+package main
+
+import (
+	"github.com/haasonsaas/nexus/internal/profile"
+	"github.com/spf13/cobra"
+)
+
+// =============================================================================
+// Workspace Commands
+// =============================================================================
+
+// buildWorkspaceCmd creates the "workspace" command group for the versioned
+// workspace bootstrap files.
+func buildWorkspaceCmd() *cobra.Command {
+	var configPath string
+	cmd := &cobra.Command{
+		Use:   "workspace",
+		Short: "Inspect and restore versions of workspace files",
+		Long: `Inspect and restore versions of the workspace bootstrap files.
+
+With workspace.history enabled (the default), each change the agent makes to
+AGENTS.md, SOUL.md, USER.md, IDENTITY.md, TOOLS.md, HEARTBEAT.md, or
+MEMORY.md through the file tools is saved as a numbered version under
+workspace.history.dir. Edits made outside Nexus are saved as "external"
+versions the next time the agent changes the file.`,
+	}
+	cmd.PersistentFlags().StringVarP(&configPath, "config", "c", profile.DefaultConfigPath(), "Path to YAML configuration file")
+	cmd.AddCommand(
+		buildWorkspaceHistoryCmd(&configPath),
+		buildWorkspaceRollbackCmd(&configPath),
+	)
+	return cmd
+}
+
+// buildWorkspaceHistoryCmd creates the "workspace history" command.
+func buildWorkspaceHistoryCmd(configPath *string) *cobra.Command {
+	var jsonOutput bool
+	cmd := &cobra.Command{
+		Use:   "history <file>",
+		Short: "List the saved versions of a workspace file",
+		Example: `  nexus workspace history SOUL.md
+  nexus workspace history MEMORY.md --json`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runWorkspaceHistory(cmd, *configPath, args[0], jsonOutput)
+		},
+	}
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output in JSON format")
+	return cmd
+}
+
+// buildWorkspaceRollbackCmd creates the "workspace rollback" command.
+func buildWorkspaceRollbackCmd(configPath *string) *cobra.Command {
+	var version int
+	cmd := &cobra.Command{
+		Use:   "rollback <file>",
+		Short: "Restore a workspace file to a saved version",
+		Long: `Restore a workspace file to a saved version.
+
+The current content is saved as a version first, so a rollback can be undone
+by rolling back to that version.`,
+		Example: `  nexus workspace rollback SOUL.md --to 3`,
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runWorkspaceRollback(cmd, *configPath, args[0], version)
+		},
+	}
+	cmd.Flags().IntVar(&version, "to", 0, "Version to restore (see nexus workspace history)")
+	_ = cmd.MarkFlagRequired("to")
+	return cmd
+}
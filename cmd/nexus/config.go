@@ -17,10 +17,13 @@ import (
 	"time"
 
 	"github.com/haasonsaas/nexus/internal/config"
+	"github.com/haasonsaas/nexus/internal/gateway"
 	"github.com/haasonsaas/nexus/internal/marketplace"
 	"github.com/haasonsaas/nexus/internal/mcp"
+	"github.com/haasonsaas/nexus/internal/memory"
 	"github.com/haasonsaas/nexus/internal/profile"
 	"github.com/haasonsaas/nexus/internal/sessions"
+	"github.com/haasonsaas/nexus/internal/storage/sqlite"
 	"github.com/haasonsaas/nexus/pkg/models"
 )
 
@@ -48,6 +51,9 @@ func openMigrationDB(cfg *config.Config) (*sql.DB, error) {
 	if cfg == nil || strings.TrimSpace(cfg.Database.URL) == "" {
 		return nil, fmt.Errorf("database url is required")
 	}
+	if sqlite.IsURL(cfg.Database.URL) {
+		return sqlite.Open(cfg.Database.URL)
+	}
 	db, err := sql.Open("postgres", cfg.Database.URL)
 	if err != nil {
 		return nil, fmt.Errorf("open database: %w", err)
@@ -73,6 +79,51 @@ func openMigrationDB(cfg *config.Config) (*sql.DB, error) {
 	return db, nil
 }
 
+// openSessionStore opens the session store selected by database.url and
+// returns a function that closes it. Message content is encrypted at rest
+// when field encryption is enabled.
+func openSessionStore(cfg *config.Config) (sessions.Store, func(), error) {
+	if cfg == nil || strings.TrimSpace(cfg.Database.URL) == "" {
+		return nil, nil, fmt.Errorf("database url is required")
+	}
+	keyring, err := gateway.BuildKeyring(cfg)
+	if err != nil {
+		return nil, nil, fmt.Errorf("field encryption: %w", err)
+	}
+	if sqlite.IsURL(cfg.Database.URL) {
+		store, err := sessions.NewSQLiteStore(cfg.Database.URL)
+		if err != nil {
+			return nil, nil, err
+		}
+		store.SetKeyring(keyring)
+		return store, func() { _ = store.Close() }, nil
+	}
+	store, err := sessions.NewCockroachStoreFromDSN(cfg.Database.URL, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	store.SetKeyring(keyring)
+	return store, func() { _ = store.Close() }, nil
+}
+
+// openMemoryManager creates the vector memory manager selected by the config.
+// It returns nil when vector memory is disabled.
+func openMemoryManager(cfg *config.Config) (*memory.Manager, error) {
+	keyring, err := gateway.BuildKeyring(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("field encryption: %w", err)
+	}
+	if err := gateway.LoadPluginStorage(cfg); err != nil {
+		return nil, err
+	}
+	mgr, err := memory.NewManager(&cfg.VectorMemory)
+	if err != nil || mgr == nil {
+		return nil, err
+	}
+	mgr.SetKeyring(keyring)
+	return mgr, nil
+}
+
 // loadMCPManager creates an MCP manager from the configuration.
 func loadMCPManager(configPath string) (*config.Config, *mcp.Manager, error) {
 	configPath = resolveConfigPath(configPath)
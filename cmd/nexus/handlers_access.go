@@ -0,0 +1,243 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/haasonsaas/nexus/internal/access"
+	"github.com/haasonsaas/nexus/internal/config"
+	"github.com/haasonsaas/nexus/internal/pairing"
+	"github.com/spf13/cobra"
+)
+
+// =============================================================================
+// Access Command Handlers
+// =============================================================================
+
+// cliGrantor is the GrantedBy of grants made with nexus access grant.
+const cliGrantor = "cli"
+
+// openAccessStore loads the config and opens the grant store in its
+// database. The returned func closes the database.
+func openAccessStore(configPath string) (*config.Config, *access.DBStore, func(), error) {
+	cfg, err := config.Load(resolveConfigPath(configPath))
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to load config: %w", err)
+	}
+	if strings.TrimSpace(cfg.Database.URL) == "" {
+		return nil, nil, nil, access.ErrUnavailable
+	}
+	db, err := openMigrationDB(cfg)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	store, err := access.NewDBStore(db)
+	if err != nil {
+		_ = db.Close()
+		return nil, nil, nil, err
+	}
+	return cfg, store, func() { _ = db.Close() }, nil
+}
+
+// runAccessList handles the access list command.
+func runAccessList(cmd *cobra.Command, configPath, listName string, jsonOutput bool) error {
+	var list access.List
+	if listName != "" {
+		parsed, err := access.ParseList(listName)
+		if err != nil {
+			return err
+		}
+		list = parsed
+	}
+	_, store, closeDB, err := openAccessStore(configPath)
+	if err != nil {
+		return err
+	}
+	defer closeDB()
+
+	grants, err := store.List(cmd.Context(), list)
+	if err != nil {
+		return accessQueryError(err)
+	}
+	out := cmd.OutOrStdout()
+	if jsonOutput {
+		if grants == nil {
+			grants = []access.Grant{}
+		}
+		data, err := json.MarshalIndent(grants, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(out, string(data))
+		return nil
+	}
+	if len(grants) == 0 {
+		fmt.Fprintln(out, "No access grants.")
+		return nil
+	}
+	w := tabwriter.NewWriter(out, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "LIST\tSENDER\tEXPIRES\tGRANTED BY")
+	for _, grant := range grants {
+		expires := "never"
+		if !grant.ExpiresAt.IsZero() {
+			expires = grant.ExpiresAt.Local().Format(time.RFC3339)
+		}
+		grantedBy := grant.GrantedBy
+		if grantedBy == "" {
+			grantedBy = "-"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", grant.List, grant.Subject(), expires, grantedBy)
+	}
+	return w.Flush()
+}
+
+// runAccessGrant handles the access grant command.
+func runAccessGrant(cmd *cobra.Command, configPath, listName, subject, duration string) error {
+	list, err := access.ParseList(listName)
+	if err != nil {
+		return err
+	}
+	channel, sender, err := access.ParseSubject(subject, "")
+	if err != nil {
+		return err
+	}
+	grant := access.Grant{List: list, Channel: channel, Sender: sender, GrantedBy: cliGrantor}
+	if duration != "" {
+		ttl, err := access.ParseTTL(duration)
+		if err != nil {
+			return err
+		}
+		grant.ExpiresAt = time.Now().Add(ttl)
+	}
+	_, store, closeDB, err := openAccessStore(configPath)
+	if err != nil {
+		return err
+	}
+	defer closeDB()
+
+	if err := store.Put(cmd.Context(), grant); err != nil {
+		return accessQueryError(err)
+	}
+	out := cmd.OutOrStdout()
+	if grant.ExpiresAt.IsZero() {
+		fmt.Fprintf(out, "Granted %s access to %s\n", list, grant.Subject())
+	} else {
+		fmt.Fprintf(out, "Granted %s access to %s until %s\n", list, grant.Subject(), grant.ExpiresAt.Local().Format(time.RFC3339))
+	}
+	fmt.Fprintln(out, "Running gateways pick up the change within 30s.")
+	return nil
+}
+
+// runAccessRevoke handles the access revoke command.
+func runAccessRevoke(cmd *cobra.Command, configPath, listName, subject string) error {
+	list, err := access.ParseList(listName)
+	if err != nil {
+		return err
+	}
+	channel, sender, err := access.ParseSubject(subject, "")
+	if err != nil {
+		return err
+	}
+	_, store, closeDB, err := openAccessStore(configPath)
+	if err != nil {
+		return err
+	}
+	defer closeDB()
+
+	removed, err := store.Delete(cmd.Context(), list, channel, sender)
+	if err != nil {
+		return accessQueryError(err)
+	}
+	if !removed {
+		return fmt.Errorf("no %s grant for %s:%s", list, channel, sender)
+	}
+	fmt.Fprintf(cmd.OutOrStdout(), "Revoked %s access for %s:%s\n", list, channel, sender)
+	return nil
+}
+
+// runAccessImport handles the access import command.
+func runAccessImport(cmd *cobra.Command, configPath string) error {
+	cfg, store, closeDB, err := openAccessStore(configPath)
+	if err != nil {
+		return err
+	}
+	defer closeDB()
+
+	grants := access.FromConfig(cfg)
+	for _, provider := range pairingProviders {
+		allowlist, err := pairing.NewStore(provider).GetAllowlist(provider)
+		if err != nil {
+			return fmt.Errorf("read %s pairing allowlist: %w", provider, err)
+		}
+		for _, sender := range allowlist {
+			if strings.TrimSpace(sender) == "" {
+				continue
+			}
+			grants = append(grants, access.Grant{List: access.ListPairing, Channel: provider, Sender: sender, GrantedBy: "pairing"})
+		}
+	}
+	for _, grant := range grants {
+		if err := store.Put(cmd.Context(), grant); err != nil {
+			return accessQueryError(err)
+		}
+	}
+
+	counts := map[access.List]int{}
+	for _, grant := range grants {
+		counts[grant.List]++
+	}
+	out := cmd.OutOrStdout()
+	if len(grants) == 0 {
+		fmt.Fprintln(out, "No allowlist entries to import.")
+		return nil
+	}
+	for _, list := range access.Lists {
+		fmt.Fprintf(out, "%-9s %d\n", list, counts[list])
+	}
+	fmt.Fprintf(out, "Imported %d grants.\n", len(grants))
+	return nil
+}
+
+// runAccessExport handles the access export command.
+func runAccessExport(cmd *cobra.Command, configPath, output string) error {
+	_, store, closeDB, err := openAccessStore(configPath)
+	if err != nil {
+		return err
+	}
+	defer closeDB()
+
+	grants, err := store.List(cmd.Context(), "")
+	if err != nil {
+		return accessQueryError(err)
+	}
+	data, skipped, err := access.ExportConfig(grants)
+	if err != nil {
+		return err
+	}
+	for _, grant := range skipped {
+		fmt.Fprintf(cmd.ErrOrStderr(), "Skipped %s grant for %s (cannot be expressed in config)\n", grant.List, grant.Subject())
+	}
+	if len(data) == 0 {
+		fmt.Fprintln(cmd.ErrOrStderr(), "No permanent grants to export.")
+		return nil
+	}
+	if output == "" {
+		_, err := cmd.OutOrStdout().Write(data)
+		return err
+	}
+	if err := os.WriteFile(output, data, 0o600); err != nil {
+		return err
+	}
+	fmt.Fprintf(cmd.OutOrStdout(), "Wrote %s\n", output)
+	return nil
+}
+
+// accessQueryError hints at missing migrations when the grant table cannot
+// be queried.
+func accessQueryError(err error) error {
+	return fmt.Errorf("access grants query failed (run `nexus migrate up`?): %w", err)
+}
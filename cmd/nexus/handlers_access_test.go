@@ -0,0 +1,85 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/haasonsaas/nexus/internal/sessions"
+)
+
+func TestAccessCommands(t *testing.T) {
+	t.Setenv("NEXUS_PROFILE", "")
+	t.Setenv("HOME", t.TempDir())
+	dir := t.TempDir()
+	dbURL := "sqlite://" + filepath.Join(dir, "nexus.db")
+	db, err := sessions.OpenSQLite(context.Background(), dbURL)
+	if err != nil {
+		t.Fatalf("OpenSQLite() error = %v", err)
+	}
+	_ = db.Close()
+
+	configPath := filepath.Join(dir, "nexus.yaml")
+	config := `version: 1
+database:
+  url: ` + dbURL + `
+llm:
+  default_provider: anthropic
+  providers:
+    anthropic: {}
+commands:
+  allow_from:
+    telegram: ["12345"]
+`
+	if err := os.WriteFile(configPath, []byte(config), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	run := func(args ...string) (string, error) {
+		t.Helper()
+		root := buildRootCmd()
+		var out bytes.Buffer
+		root.SetOut(&out)
+		root.SetErr(&out)
+		root.SetArgs(append(append([]string{"access"}, args...), "-c", configPath))
+		err := root.Execute()
+		return out.String(), err
+	}
+
+	if out, err := run("grant", "elevated", "slack:U123", "--for", "1h"); err != nil || !strings.Contains(out, "Granted elevated access to slack:u123 until") {
+		t.Fatalf("grant: %v\n%s", err, out)
+	}
+	if out, err := run("import"); err != nil || !strings.Contains(out, "Imported 1 grants.") {
+		t.Fatalf("import: %v\n%s", err, out)
+	}
+	out, err := run("list")
+	if err != nil {
+		t.Fatalf("list: %v", err)
+	}
+	for _, want := range []string{"commands  telegram:12345  never", "elevated  slack:u123"} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("expected %q in:\n%s", want, out)
+		}
+	}
+
+	out, err = run("export")
+	if err != nil {
+		t.Fatalf("export: %v", err)
+	}
+	if !strings.Contains(out, "Skipped elevated grant for slack:u123") || !strings.Contains(out, "telegram:\n      - \"12345\"") {
+		t.Fatalf("unexpected export:\n%s", out)
+	}
+
+	if out, err := run("revoke", "elevated", "slack:U123"); err != nil || !strings.Contains(out, "Revoked elevated access for slack:u123") {
+		t.Fatalf("revoke: %v\n%s", err, out)
+	}
+	if _, err := run("revoke", "elevated", "slack:U123"); err == nil {
+		t.Fatal("expected revoking a missing grant to fail")
+	}
+	if _, err := run("grant", "sudo", "slack:U123"); err == nil {
+		t.Fatal("expected an unknown list to fail")
+	}
+}
@@ -0,0 +1,134 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/haasonsaas/nexus/internal/config"
+	"github.com/spf13/cobra"
+)
+
+// =============================================================================
+// Admin Command Handlers
+// =============================================================================
+
+// pprofCaptureOptions holds the admin pprof capture command flags.
+type pprofCaptureOptions struct {
+	profile    string
+	seconds    int
+	output     string
+	serverAddr string
+	token      string
+	apiKey     string
+}
+
+// snapshotProfiles are served by pprof's index handler and need no duration.
+var snapshotProfiles = map[string]bool{
+	"heap":         true,
+	"goroutine":    true,
+	"allocs":       true,
+	"block":        true,
+	"mutex":        true,
+	"threadcreate": true,
+}
+
+// runAdminPprofCapture handles the admin pprof capture command.
+func runAdminPprofCapture(cmd *cobra.Command, configPath string, opts pprofCaptureOptions) error {
+	out := cmd.OutOrStdout()
+	ctx := cmd.Context()
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	name := strings.ToLower(strings.TrimSpace(opts.profile))
+	path, err := pprofCapturePath(name, opts.seconds)
+	if err != nil {
+		return err
+	}
+
+	baseURL := strings.TrimSpace(opts.serverAddr)
+	token := opts.token
+	if baseURL == "" || (token == "" && opts.apiKey == "") {
+		cfg, err := config.Load(resolveConfigPath(configPath))
+		if err != nil {
+			return fmt.Errorf("load config: %w", err)
+		}
+		diag := cfg.Server.Diagnostics
+		if baseURL == "" {
+			if !diag.Enabled {
+				return fmt.Errorf("the diagnostics server is disabled; set server.diagnostics.enabled")
+			}
+			host := diag.Host
+			if host == "" || host == "0.0.0.0" || host == "::" {
+				host = "127.0.0.1"
+			}
+			baseURL = net.JoinHostPort(host, strconv.Itoa(diag.Port))
+		}
+		if token == "" && opts.apiKey == "" {
+			token = diag.Token
+		}
+	}
+	if !strings.HasPrefix(baseURL, "http://") && !strings.HasPrefix(baseURL, "https://") {
+		baseURL = "http://" + baseURL
+	}
+
+	client := newAPIClient(baseURL, token, opts.apiKey)
+	// The server holds the request open while it records the profile.
+	client.httpClient.Timeout = time.Duration(opts.seconds)*time.Second + 30*time.Second
+	if snapshotProfiles[name] {
+		client.httpClient.Timeout = 30 * time.Second
+	}
+
+	output := opts.output
+	if output == "" {
+		ext := ".pprof"
+		if name == "trace" {
+			ext = ".trace"
+		}
+		output = fmt.Sprintf("nexus-%s-%s%s", name, time.Now().Format("20060102-150405"), ext)
+	}
+	f, err := os.OpenFile(output, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o600)
+	if err != nil {
+		return fmt.Errorf("create output: %w", err)
+	}
+	if !snapshotProfiles[name] {
+		fmt.Fprintf(out, "Recording %s profile for %ds...\n", name, opts.seconds)
+	}
+	n, err := client.download(ctx, path, f)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(output)
+		return fmt.Errorf("capture %s profile: %w", name, err)
+	}
+
+	tool := "pprof"
+	if name == "trace" {
+		tool = "trace"
+	}
+	fmt.Fprintf(out, "Wrote %s (%d bytes). Inspect it with: go tool %s %s\n", output, n, tool, output)
+	return nil
+}
+
+// pprofCapturePath returns the diagnostics path serving a profile.
+func pprofCapturePath(name string, seconds int) (string, error) {
+	if snapshotProfiles[name] {
+		return "/debug/pprof/" + name, nil
+	}
+	if seconds <= 0 {
+		return "", fmt.Errorf("--seconds must be positive")
+	}
+	switch name {
+	case "cpu", "profile":
+		return fmt.Sprintf("/debug/pprof/profile?seconds=%d", seconds), nil
+	case "trace":
+		return fmt.Sprintf("/debug/pprof/trace?seconds=%d", seconds), nil
+	default:
+		return "", fmt.Errorf("unknown profile %q (want cpu, heap, goroutine, allocs, block, mutex, threadcreate, or trace)", name)
+	}
+}
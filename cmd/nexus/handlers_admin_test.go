@@ -0,0 +1,62 @@
+package main
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+func TestAdminPprofCapture(t *testing.T) {
+	var gotURL, gotAuth string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotURL, gotAuth = r.URL.String(), r.Header.Get("Authorization")
+		w.Write([]byte("profile-bytes"))
+	}))
+	defer srv.Close()
+
+	output := filepath.Join(t.TempDir(), "cpu.pprof")
+	var out bytes.Buffer
+	cmd := &cobra.Command{}
+	cmd.SetOut(&out)
+	err := runAdminPprofCapture(cmd, "", pprofCaptureOptions{
+		profile:    "cpu",
+		seconds:    5,
+		output:     output,
+		serverAddr: srv.URL,
+		token:      "diag-token",
+	})
+	if err != nil {
+		t.Fatalf("capture: %v", err)
+	}
+	if gotURL != "/debug/pprof/profile?seconds=5" || gotAuth != "Bearer diag-token" {
+		t.Fatalf("unexpected request %q %q", gotURL, gotAuth)
+	}
+	data, err := os.ReadFile(output)
+	if err != nil || string(data) != "profile-bytes" {
+		t.Fatalf("unexpected output %q: %v", data, err)
+	}
+	if !strings.Contains(out.String(), "go tool pprof "+output) {
+		t.Fatalf("unexpected message:\n%s", out.String())
+	}
+}
+
+func TestPprofCapturePath(t *testing.T) {
+	if path, _ := pprofCapturePath("heap", 0); path != "/debug/pprof/heap" {
+		t.Fatalf("unexpected heap path %q", path)
+	}
+	if path, _ := pprofCapturePath("trace", 3); path != "/debug/pprof/trace?seconds=3" {
+		t.Fatalf("unexpected trace path %q", path)
+	}
+	if _, err := pprofCapturePath("cpu", 0); err == nil {
+		t.Fatal("expected cpu profiles to need a duration")
+	}
+	if _, err := pprofCapturePath("bogus", 30); err == nil {
+		t.Fatal("expected unknown profiles to fail")
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"io"
 	"log/slog"
 	"os"
+	"path/filepath"
 	"strings"
 	"time"
 
@@ -76,6 +77,129 @@ func runArtifactsList(cmd *cobra.Command, configPath string, limit int, artifact
 	return nil
 }
 
+func runArtifactsShow(cmd *cobra.Command, configPath, artifactID string, link bool, ttl time.Duration) error {
+	configPath = resolveConfigPath(configPath)
+
+	cfg, err := config.Load(configPath)
+	if err != nil {
+		return fmt.Errorf("load config: %w", err)
+	}
+
+	var signer *artifacts.LinkSigner
+	if link {
+		signer, err = gateway.BuildArtifactLinkSigner(cfg)
+		if err != nil {
+			return fmt.Errorf("artifact links: %w", err)
+		}
+		if signer == nil {
+			return fmt.Errorf("artifact links not configured (set artifacts.links.enabled in config)")
+		}
+	}
+
+	repo, cleanup, err := createArtifactRepository(cfg)
+	if err != nil {
+		return fmt.Errorf("create artifact repository: %w", err)
+	}
+	if cleanup != nil {
+		defer cleanup()
+	}
+	if repo == nil {
+		return fmt.Errorf("artifacts not configured (set artifacts.backend in config)")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	art, data, err := repo.GetArtifact(ctx, artifactID)
+	if err != nil {
+		return fmt.Errorf("get artifact: %w", err)
+	}
+	data.Close()
+
+	out := cmd.OutOrStdout()
+	fmt.Fprintf(out, "ID:       %s\n", art.Id)
+	fmt.Fprintf(out, "Type:     %s\n", art.Type)
+	fmt.Fprintf(out, "MIME:     %s\n", art.MimeType)
+	if art.Filename != "" {
+		fmt.Fprintf(out, "Filename: %s\n", art.Filename)
+	}
+	fmt.Fprintf(out, "Size:     %d bytes\n", art.Size)
+	fmt.Fprintf(out, "Ref:      %s\n", art.Reference)
+	if art.TtlSeconds > 0 {
+		fmt.Fprintf(out, "TTL:      %s\n", time.Duration(art.TtlSeconds)*time.Second)
+	}
+
+	if signer != nil {
+		if strings.HasPrefix(art.Reference, "redacted://") {
+			return fmt.Errorf("artifact %s is redacted and cannot be linked", art.Id)
+		}
+		url, expires := signer.URL(art.Id, ttl)
+		fmt.Fprintf(out, "\nLink:     %s\n", url)
+		fmt.Fprintf(out, "Expires:  %s\n", expires.Format(time.RFC3339))
+	}
+	return nil
+}
+
+func runArtifactsDownload(cmd *cobra.Command, configPath, artifactID, outputPath string) error {
+	configPath = resolveConfigPath(configPath)
+
+	cfg, err := config.Load(configPath)
+	if err != nil {
+		return fmt.Errorf("load config: %w", err)
+	}
+
+	repo, cleanup, err := createArtifactRepository(cfg)
+	if err != nil {
+		return fmt.Errorf("create artifact repository: %w", err)
+	}
+	if cleanup != nil {
+		defer cleanup()
+	}
+	if repo == nil {
+		return fmt.Errorf("artifacts not configured (set artifacts.backend in config)")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	art, data, err := repo.GetArtifact(ctx, artifactID)
+	if err != nil {
+		return fmt.Errorf("get artifact: %w", err)
+	}
+	defer data.Close()
+	if strings.HasPrefix(art.Reference, "redacted://") {
+		return fmt.Errorf("artifact %s is redacted", art.Id)
+	}
+
+	if outputPath == "-" {
+		if _, err := io.Copy(cmd.OutOrStdout(), data); err != nil {
+			return fmt.Errorf("write artifact data: %w", err)
+		}
+		return nil
+	}
+	if outputPath == "" {
+		// Filenames come from tools; never let one escape the working directory.
+		outputPath = filepath.Base(art.Filename)
+		if outputPath == "." || outputPath == string(filepath.Separator) {
+			outputPath = art.Id + extensionForMimeCLI(art.MimeType)
+		}
+	}
+
+	f, err := os.OpenFile(outputPath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
+	if err != nil {
+		return fmt.Errorf("open output file: %w", err)
+	}
+	written, err := io.Copy(f, data)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("write artifact data: %w", err)
+	}
+	fmt.Fprintf(cmd.OutOrStdout(), "Saved %d bytes to %s\n", written, outputPath)
+	return nil
+}
+
 func runArtifactsGet(cmd *cobra.Command, configPath, artifactID, outputPath string) error {
 	configPath = resolveConfigPath(configPath)
 
@@ -185,6 +309,75 @@ func runArtifactsDelete(cmd *cobra.Command, configPath, artifactID string, force
 	return nil
 }
 
+func runArtifactsPrune(cmd *cobra.Command, configPath string, olderThan time.Duration, force bool) error {
+	if olderThan < 0 {
+		return fmt.Errorf("--older-than must be positive")
+	}
+	if olderThan > 0 && !force {
+		reader := bufio.NewReader(os.Stdin)
+		fmt.Printf("Delete all artifacts older than %s? [y/N]: ", olderThan)
+		response, err := reader.ReadString('\n')
+		if err != nil {
+			fmt.Println("Cancelled")
+			return nil
+		}
+		response = strings.TrimSpace(strings.ToLower(response))
+		if response != "y" && response != "yes" {
+			fmt.Println("Cancelled")
+			return nil
+		}
+	}
+
+	configPath = resolveConfigPath(configPath)
+
+	cfg, err := config.Load(configPath)
+	if err != nil {
+		return fmt.Errorf("load config: %w", err)
+	}
+
+	repo, cleanup, err := createArtifactRepository(cfg)
+	if err != nil {
+		return fmt.Errorf("create artifact repository: %w", err)
+	}
+	if cleanup != nil {
+		defer cleanup()
+	}
+	if repo == nil {
+		return fmt.Errorf("artifacts not configured (set artifacts.backend in config)")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	out := cmd.OutOrStdout()
+	expired, err := repo.PruneExpired(ctx)
+	if err != nil {
+		return fmt.Errorf("prune expired artifacts: %w", err)
+	}
+	fmt.Fprintf(out, "Pruned %d expired artifacts\n", expired)
+
+	if olderThan == 0 {
+		return nil
+	}
+	old, err := repo.ListArtifacts(ctx, artifacts.Filter{CreatedBefore: time.Now().Add(-olderThan)})
+	if err != nil {
+		return fmt.Errorf("list artifacts: %w", err)
+	}
+	deleted := 0
+	for _, art := range old {
+		if err := repo.DeleteArtifact(ctx, art.Id); err != nil {
+			fmt.Fprintf(cmd.ErrOrStderr(), "delete %s: %v\n", art.Id, err)
+			continue
+		}
+		deleted++
+	}
+	fmt.Fprintf(out, "Deleted %d artifacts older than %s\n", deleted, olderThan)
+	if deleted < len(old) {
+		return fmt.Errorf("%d artifacts could not be deleted", len(old)-deleted)
+	}
+	return nil
+}
+
 // createArtifactRepository creates an artifact repository from config.
 // Returns nil if artifacts are not configured.
 func createArtifactRepository(cfg *config.Config) (artifacts.Repository, func(), error) {
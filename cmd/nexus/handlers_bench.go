@@ -0,0 +1,150 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/haasonsaas/nexus/internal/agent"
+	"github.com/haasonsaas/nexus/internal/bench"
+	"github.com/haasonsaas/nexus/internal/channels"
+	"github.com/haasonsaas/nexus/internal/channels/loopback"
+	"github.com/haasonsaas/nexus/internal/config"
+	"github.com/haasonsaas/nexus/internal/gateway"
+	"github.com/haasonsaas/nexus/internal/sessions"
+	"github.com/spf13/cobra"
+)
+
+// =============================================================================
+// Bench Command Handler
+// =============================================================================
+
+// runBench handles the bench command.
+func runBench(cmd *cobra.Command, opts benchOptions) error {
+	cfg, err := loadBenchConfig(resolveConfigPath(opts.configPath), opts.mockLLM)
+	if err != nil {
+		return err
+	}
+
+	// Give every synthetic conversation its own session so they run in
+	// parallel instead of serializing on a shared DM session.
+	cfg.Session.Scoping.DMScope = sessions.DMScopePerChannelPeer
+
+	var (
+		provider     agent.LLMProvider
+		defaultModel string
+	)
+	if opts.mockLLM {
+		provider = bench.NewMockProvider(bench.MockProviderConfig{
+			Latency:  opts.mockLatency,
+			Jitter:   opts.mockJitter,
+			Response: opts.mockResponse,
+		})
+		defaultModel = "mock"
+	} else {
+		provider, defaultModel, err = buildLLMProvider(cfg, "")
+		if err != nil {
+			return err
+		}
+	}
+	instrumented := bench.NewInstrumentedProvider(provider)
+
+	logger := slog.New(slog.NewTextHandler(cmd.ErrOrStderr(), &slog.HandlerOptions{Level: slog.LevelError}))
+	server, err := gateway.NewServer(cfg, logger)
+	if err != nil {
+		return fmt.Errorf("failed to initialize gateway: %w", err)
+	}
+	adapter := loopback.New(loopback.Config{BufferSize: opts.messages, Logger: logger})
+
+	ctx, cancel := context.WithCancel(cmd.Context())
+	defer cancel()
+	if err := server.StartEmbedded(ctx, gateway.EmbeddedOptions{
+		Adapters:         []channels.Adapter{adapter},
+		Provider:         instrumented,
+		DefaultModel:     defaultModel,
+		DisableRateLimit: true,
+		MaxConcurrency:   opts.maxInFlight,
+	}); err != nil {
+		return fmt.Errorf("failed to start embedded gateway: %w", err)
+	}
+	defer func() {
+		stopCtx, stopCancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer stopCancel()
+		if err := server.Stop(stopCtx); err != nil {
+			logger.Warn("gateway stop failed", "error", err)
+		}
+	}()
+
+	result, err := bench.Run(ctx, adapter, bench.Config{
+		Messages:       opts.messages,
+		Concurrency:    opts.concurrency,
+		Conversations:  opts.conversations,
+		Prompt:         opts.prompt,
+		Timeout:        opts.timeout,
+		ConversationID: loopback.ConversationID,
+		QueueDepth: func() int {
+			return adapter.QueueDepth() + server.InFlightMessages()
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("bench failed: %w", err)
+	}
+	providerStats := instrumented.Stats(result.Duration)
+	result.Provider = &providerStats
+
+	out := cmd.OutOrStdout()
+	if opts.jsonOutput {
+		enc := json.NewEncoder(out)
+		enc.SetIndent("", "  ")
+		return enc.Encode(result)
+	}
+
+	fmt.Fprintf(out, "Gateway Benchmark\n")
+	fmt.Fprintln(out, strings.Repeat("-", 40))
+	fmt.Fprintf(out, "Messages:     %d (concurrency %d)\n", result.Messages, result.Concurrency)
+	fmt.Fprintf(out, "Completed:    %d  Timed out: %d  Errors: %d\n", result.Completed, result.TimedOut, result.Errors)
+	fmt.Fprintf(out, "Duration:     %v\n", result.Duration.Round(time.Millisecond))
+	fmt.Fprintf(out, "Throughput:   %.1f msg/s\n", result.Throughput)
+	fmt.Fprintln(out)
+	fmt.Fprintln(out, "Latency:")
+	lat := result.Latency
+	fmt.Fprintf(out, "  min %v  mean %v  max %v\n", roundMs(lat.Min), roundMs(lat.Mean), roundMs(lat.Max))
+	fmt.Fprintf(out, "  p50 %v  p90 %v  p95 %v  p99 %v\n", roundMs(lat.P50), roundMs(lat.P90), roundMs(lat.P95), roundMs(lat.P99))
+	fmt.Fprintln(out)
+	fmt.Fprintf(out, "Queue depth:  max %d  mean %.1f (%d samples)\n", result.Queue.Max, result.Queue.Mean, result.Queue.Samples)
+	fmt.Fprintln(out)
+	fmt.Fprintf(out, "Provider (%s):\n", providerStats.Name)
+	fmt.Fprintf(out, "  calls %d (%.1f/s)  errors %d  avg latency %v\n",
+		providerStats.Calls, providerStats.CallsPerSec, providerStats.Errors, roundMs(providerStats.AvgLatency))
+	fmt.Fprintf(out, "  tokens %d in / %d out (%.1f out tok/s)\n",
+		providerStats.InputTokens, providerStats.OutputTokens, providerStats.OutputTokensPerSec)
+	return nil
+}
+
+// loadBenchConfig loads configuration, falling back to defaults in mock mode
+// when no config file exists.
+func loadBenchConfig(path string, mock bool) (*config.Config, error) {
+	cfg, err := config.Load(path)
+	if err == nil {
+		return cfg, nil
+	}
+	if mock && errors.Is(err, fs.ErrNotExist) {
+		if _, statErr := os.Stat(path); errors.Is(statErr, fs.ErrNotExist) {
+			return &config.Config{}, nil
+		}
+	}
+	return nil, fmt.Errorf("failed to load config: %w", err)
+}
+
+func roundMs(d time.Duration) time.Duration {
+	if d < time.Millisecond {
+		return d.Round(time.Microsecond)
+	}
+	return d.Round(time.Millisecond)
+}
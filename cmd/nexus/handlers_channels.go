@@ -7,6 +7,7 @@ import (
 	"io"
 	"log/slog"
 	"os"
+	"strconv"
 	"strings"
 	"time"
 
@@ -572,6 +573,10 @@ func setYAMLValue(node *yaml.Node, path []string, value any) error {
 			node.Kind = yaml.ScalarNode
 			node.Tag = "!!str"
 			node.Value = v
+		case int:
+			node.Kind = yaml.ScalarNode
+			node.Tag = "!!int"
+			node.Value = strconv.Itoa(v)
 		default:
 			return fmt.Errorf("unsupported value type: %T", value)
 		}
@@ -0,0 +1,338 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os/signal"
+	"sort"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/haasonsaas/nexus/internal/agent"
+	"github.com/haasonsaas/nexus/internal/channels"
+	"github.com/haasonsaas/nexus/internal/channels/loopback"
+	"github.com/haasonsaas/nexus/internal/config"
+	"github.com/haasonsaas/nexus/internal/gateway"
+	"github.com/haasonsaas/nexus/pkg/models"
+	"github.com/spf13/cobra"
+)
+
+const (
+	// chatSettleDelay is the minimum time to wait before treating an idle
+	// gateway as finished with a turn.
+	chatSettleDelay = 300 * time.Millisecond
+	// chatPollInterval is how often gateway idleness is checked.
+	chatPollInterval = 100 * time.Millisecond
+	// chatMaxApprovalInput caps how much tool input is shown in approval prompts.
+	chatMaxApprovalInput = 500
+)
+
+// =============================================================================
+// Chat Command Handler
+// =============================================================================
+
+// runChat handles the chat command.
+func runChat(cmd *cobra.Command, opts chatOptions) error {
+	cfg, err := config.Load(resolveConfigPath(opts.configPath))
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	var (
+		provider     agent.LLMProvider
+		defaultModel string
+	)
+	if opts.provider != "" || opts.model != "" {
+		provider, defaultModel, err = buildLLMProvider(cfg, opts.provider)
+		if err != nil {
+			return err
+		}
+		if opts.model != "" {
+			defaultModel = opts.model
+		}
+	}
+
+	level := slog.LevelError
+	if opts.verbose {
+		level = slog.LevelDebug
+	}
+	logger := slog.New(slog.NewTextHandler(cmd.ErrOrStderr(), &slog.HandlerOptions{Level: level}))
+	server, err := gateway.NewServer(cfg, logger)
+	if err != nil {
+		return fmt.Errorf("failed to initialize gateway: %w", err)
+	}
+	adapter := loopback.New(loopback.Config{Logger: logger, Streaming: !opts.noStream})
+
+	ctx, cancel := signal.NotifyContext(cmd.Context(), syscall.SIGINT, syscall.SIGTERM)
+	defer cancel()
+	if err := server.StartEmbedded(ctx, gateway.EmbeddedOptions{
+		Adapters:     []channels.Adapter{adapter},
+		Provider:     provider,
+		DefaultModel: defaultModel,
+	}); err != nil {
+		return fmt.Errorf("failed to start embedded gateway: %w", err)
+	}
+	defer func() {
+		stopCtx, stopCancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer stopCancel()
+		if err := server.Stop(stopCtx); err != nil {
+			logger.Warn("gateway stop failed", "error", err)
+		}
+	}()
+
+	// The terminal can answer approval prompts, so pending approvals are
+	// surfaced instead of being denied outright.
+	if checker := server.ApprovalChecker(); checker != nil {
+		checker.SetUIAvailableCheck(func() bool { return true })
+	}
+
+	chat := &chatSession{
+		adapter:      adapter,
+		server:       server,
+		in:           bufio.NewReader(cmd.InOrStdin()),
+		out:          cmd.OutOrStdout(),
+		conversation: opts.session,
+		timeout:      opts.timeout,
+	}
+	return chat.run(ctx)
+}
+
+// chatSession drives a terminal conversation through the loopback adapter.
+type chatSession struct {
+	adapter      *loopback.Adapter
+	server       *gateway.Server
+	in           *bufio.Reader
+	out          io.Writer
+	conversation string
+	timeout      time.Duration
+
+	// sessionID is learned from the first reply and scopes approval prompts.
+	sessionID string
+}
+
+// run reads user input until EOF or /exit.
+func (c *chatSession) run(ctx context.Context) error {
+	fmt.Fprintf(c.out, "Nexus chat (conversation %q). Type /exit to quit.\n", c.conversation)
+	for {
+		fmt.Fprint(c.out, "\nyou> ")
+		line, err := c.in.ReadString('\n')
+		if err != nil && line == "" {
+			if errors.Is(err, io.EOF) {
+				fmt.Fprintln(c.out)
+				return nil
+			}
+			return err
+		}
+		line = strings.TrimSpace(line)
+		switch line {
+		case "":
+			continue
+		case "/exit", "/quit":
+			return nil
+		}
+
+		if err := c.turn(ctx, line); err != nil {
+			if ctx.Err() != nil {
+				fmt.Fprintln(c.out)
+				return nil
+			}
+			return err
+		}
+		for {
+			approved, err := c.resolveApprovals(ctx)
+			if err != nil {
+				return err
+			}
+			if len(approved) == 0 {
+				break
+			}
+			retry := fmt.Sprintf("Approved: %s. Please retry.", strings.Join(approved, ", "))
+			if err := c.turn(ctx, retry); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// turn sends one message and renders output until the gateway goes idle.
+func (c *chatSession) turn(ctx context.Context, content string) error {
+	if _, err := c.adapter.Inject(ctx, c.conversation, content); err != nil {
+		return err
+	}
+	r := &chatRenderer{out: c.out, printed: make(map[string]string)}
+	defer r.finish()
+
+	sent := time.Now()
+	deadline := time.NewTimer(c.timeout)
+	defer deadline.Stop()
+	ticker := time.NewTicker(chatPollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-deadline.C:
+			r.notice("(timed out waiting for a response)")
+			return nil
+		case msg := <-c.adapter.Replies():
+			c.observe(msg)
+			r.reply(msg)
+		case update := <-c.adapter.Updates():
+			c.observe(update.Message)
+			r.update(update)
+		case <-ticker.C:
+			if time.Since(sent) < chatSettleDelay || c.adapter.QueueDepth() > 0 || c.server.InFlightMessages() > 0 {
+				continue
+			}
+			c.drain(r)
+			return nil
+		}
+	}
+}
+
+// drain renders output that was queued before the gateway went idle.
+func (c *chatSession) drain(r *chatRenderer) {
+	for {
+		select {
+		case msg := <-c.adapter.Replies():
+			c.observe(msg)
+			r.reply(msg)
+		case update := <-c.adapter.Updates():
+			c.observe(update.Message)
+			r.update(update)
+		default:
+			return
+		}
+	}
+}
+
+func (c *chatSession) observe(msg *models.Message) {
+	if msg != nil && msg.SessionID != "" {
+		c.sessionID = msg.SessionID
+	}
+}
+
+// resolveApprovals prompts for pending tool approvals raised in this chat and
+// returns the names of tools the user allowed.
+func (c *chatSession) resolveApprovals(ctx context.Context) ([]string, error) {
+	checker := c.server.ApprovalChecker()
+	if checker == nil {
+		return nil, nil
+	}
+	pending, err := checker.GetPendingRequests(ctx, "")
+	if err != nil {
+		return nil, fmt.Errorf("list approvals: %w", err)
+	}
+	sort.Slice(pending, func(i, j int) bool { return pending[i].CreatedAt.Before(pending[j].CreatedAt) })
+
+	var approved []string
+	for _, req := range pending {
+		if c.sessionID != "" && req.SessionID != c.sessionID {
+			continue
+		}
+		fmt.Fprintf(c.out, "\nApproval required: %s", req.ToolName)
+		if req.Reason != "" {
+			fmt.Fprintf(c.out, " (%s)", req.Reason)
+		}
+		fmt.Fprintln(c.out)
+		if input := strings.TrimSpace(string(req.Input)); input != "" {
+			if len(input) > chatMaxApprovalInput {
+				input = input[:chatMaxApprovalInput] + "..."
+			}
+			fmt.Fprintf(c.out, "  input: %s\n", input)
+		}
+		if req.Diff != "" {
+			fmt.Fprint(c.out, req.Diff)
+		}
+		fmt.Fprint(c.out, "Allow this tool for the rest of the chat? [y/N] ")
+		answer, _ := c.in.ReadString('\n')
+		answer = strings.ToLower(strings.TrimSpace(answer))
+		if answer != "y" && answer != "yes" {
+			if err := checker.Deny(ctx, req.ID, "cli"); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		if err := checker.Approve(ctx, req.ID, "cli"); err != nil {
+			return nil, err
+		}
+		allowChatTool(checker, req.AgentID, req.ToolName)
+		approved = append(approved, req.ToolName)
+	}
+	return approved, nil
+}
+
+// allowChatTool adds toolName to the agent's approval allowlist.
+func allowChatTool(checker *agent.ApprovalChecker, agentID, toolName string) {
+	base := checker.PolicyFor(agentID)
+	policy := agent.DefaultApprovalPolicy()
+	if base != nil {
+		copied := *base
+		policy = &copied
+	}
+	policy.Allowlist = append(append([]string(nil), policy.Allowlist...), toolName)
+	checker.SetAgentPolicy(agentID, policy)
+}
+
+// chatRenderer writes assistant output for a single turn.
+type chatRenderer struct {
+	out     io.Writer
+	started bool
+	// printed tracks the streamed text already written per stream ID.
+	printed map[string]string
+}
+
+func (r *chatRenderer) begin() {
+	if !r.started {
+		fmt.Fprint(r.out, "nexus> ")
+		r.started = true
+	}
+}
+
+func (r *chatRenderer) reply(msg *models.Message) {
+	if msg == nil {
+		return
+	}
+	if strings.TrimSpace(msg.Content) != "" {
+		r.begin()
+		fmt.Fprint(r.out, msg.Content)
+	}
+	for _, att := range msg.Attachments {
+		r.begin()
+		name := att.Filename
+		if name == "" {
+			name = att.URL
+		}
+		fmt.Fprintf(r.out, "\n[%s attachment: %s]", att.Type, name)
+	}
+}
+
+func (r *chatRenderer) update(u loopback.Update) {
+	prev := r.printed[u.StreamID]
+	if u.Content == "" || u.Content == prev {
+		return
+	}
+	r.begin()
+	if strings.HasPrefix(u.Content, prev) {
+		fmt.Fprint(r.out, u.Content[len(prev):])
+	} else {
+		fmt.Fprint(r.out, "\n"+u.Content)
+	}
+	r.printed[u.StreamID] = u.Content
+}
+
+func (r *chatRenderer) notice(text string) {
+	r.begin()
+	fmt.Fprint(r.out, text)
+}
+
+func (r *chatRenderer) finish() {
+	if r.started {
+		fmt.Fprintln(r.out)
+	}
+}
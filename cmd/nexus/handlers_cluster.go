@@ -0,0 +1,125 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"text/tabwriter"
+	"time"
+
+	"github.com/haasonsaas/nexus/internal/cluster"
+	"github.com/haasonsaas/nexus/internal/config"
+	"github.com/spf13/cobra"
+)
+
+// =============================================================================
+// Cluster Command Handlers
+// =============================================================================
+
+// clusterNodeStatus is a node with its liveness as seen by the CLI.
+type clusterNodeStatus struct {
+	cluster.Node
+	Live bool `json:"live"`
+}
+
+// clusterLeaseStatus is a lease with its validity as seen by the CLI.
+type clusterLeaseStatus struct {
+	cluster.Lease
+	Active bool `json:"active"`
+}
+
+// runClusterStatus handles the cluster status command.
+func runClusterStatus(cmd *cobra.Command, configPath string, jsonOutput bool) error {
+	cfg, err := config.Load(resolveConfigPath(configPath))
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	db, err := openMigrationDB(cfg)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	store, err := cluster.NewDBStore(db)
+	if err != nil {
+		return err
+	}
+	ctx := cmd.Context()
+	nodes, err := store.ListNodes(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list cluster nodes: %w", err)
+	}
+	leases, err := store.ListLeases(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list cluster leases: %w", err)
+	}
+
+	now := time.Now()
+	nodeTTL := cfg.Cluster.Coordination.NodeTTL
+	nodeStatuses := make([]clusterNodeStatus, 0, len(nodes))
+	for _, node := range nodes {
+		nodeStatuses = append(nodeStatuses, clusterNodeStatus{Node: node, Live: now.Sub(node.HeartbeatAt) <= nodeTTL})
+	}
+	leaseStatuses := make([]clusterLeaseStatus, 0, len(leases))
+	for _, lease := range leases {
+		leaseStatuses = append(leaseStatuses, clusterLeaseStatus{Lease: lease, Active: now.Before(lease.ExpiresAt)})
+	}
+
+	out := cmd.OutOrStdout()
+	if jsonOutput {
+		data, err := json.MarshalIndent(map[string]any{
+			"coordination_enabled": cfg.Cluster.Enabled && cfg.Cluster.Coordination.Enabled,
+			"nodes":                nodeStatuses,
+			"leases":               leaseStatuses,
+		}, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(out, string(data))
+		return nil
+	}
+
+	fmt.Fprintln(out, "Cluster Status")
+	fmt.Fprintln(out, "==============")
+	if !cfg.Cluster.Enabled || !cfg.Cluster.Coordination.Enabled {
+		fmt.Fprintln(out, "Coordination is disabled in this config (cluster.coordination.enabled).")
+	}
+	fmt.Fprintln(out)
+
+	fmt.Fprintln(out, "Nodes:")
+	if len(nodeStatuses) == 0 {
+		fmt.Fprintln(out, "  (none)")
+	} else {
+		w := tabwriter.NewWriter(out, 0, 4, 2, ' ', 0)
+		fmt.Fprintln(w, "  NODE\tSTATE\tADDRESS\tLAST HEARTBEAT\tSTARTED")
+		for _, node := range nodeStatuses {
+			state := "live"
+			if !node.Live {
+				state = "stale"
+			}
+			fmt.Fprintf(w, "  %s\t%s\t%s\t%s ago\t%s\n",
+				node.ID, state, node.Address,
+				now.Sub(node.HeartbeatAt).Round(time.Second),
+				node.StartedAt.Format(time.RFC3339))
+		}
+		if err := w.Flush(); err != nil {
+			return err
+		}
+	}
+	fmt.Fprintln(out)
+
+	fmt.Fprintln(out, "Leases:")
+	if len(leaseStatuses) == 0 {
+		fmt.Fprintln(out, "  (none)")
+		return nil
+	}
+	w := tabwriter.NewWriter(out, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "  LEASE\tHOLDER\tSTATE\tEXPIRES")
+	for _, lease := range leaseStatuses {
+		state := "held"
+		if !lease.Active {
+			state = "expired"
+		}
+		fmt.Fprintf(w, "  %s\t%s\t%s\t%s\n", lease.Name, lease.HolderID, state, lease.ExpiresAt.Format(time.RFC3339))
+	}
+	return w.Flush()
+}
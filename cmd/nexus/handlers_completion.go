@@ -0,0 +1,289 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/haasonsaas/nexus/internal/access"
+	"github.com/haasonsaas/nexus/internal/config"
+	"github.com/haasonsaas/nexus/internal/multiagent"
+	"github.com/haasonsaas/nexus/internal/profile"
+	"github.com/haasonsaas/nexus/internal/sessions"
+	"github.com/haasonsaas/nexus/internal/skills"
+	"github.com/spf13/cobra"
+)
+
+// =============================================================================
+// Dynamic Completion
+// =============================================================================
+
+// completionTimeout bounds how long a completion may spend reading local
+// state, so a slow database never hangs the shell.
+const completionTimeout = 2 * time.Second
+
+// completionSessionLimit caps the sessions offered per agent.
+const completionSessionLimit = 50
+
+// completionSource lists candidate values as "value" or "value\tdescription".
+type completionSource func(ctx context.Context, cmd *cobra.Command) []string
+
+// argCompletion completes the first maxArgs positional arguments of a command.
+type argCompletion struct {
+	source  completionSource
+	maxArgs int
+}
+
+// argCompletions maps command paths (without "nexus") to the values their
+// positional arguments take.
+var argCompletions = map[string]argCompletion{
+	"profile use":       {completeProfiles, 1},
+	"profile path":      {completeProfiles, 1},
+	"profile diff":      {completeProfiles, 2},
+	"plugins update":    {completePlugins, 1},
+	"plugins uninstall": {completePlugins, 1},
+	"plugins verify":    {completePlugins, 1},
+	"plugins info":      {completePlugins, 1},
+	"plugins enable":    {completePlugins, 1},
+	"plugins disable":   {completePlugins, 1},
+	"skills show":       {completeSkills, 1},
+	"skills check":      {completeSkills, 1},
+	"skills enable":     {completeSkills, 1},
+	"skills disable":    {completeSkills, 1},
+	"sessions show":     {completeSessions, 1},
+	"sessions render":   {completeSessions, 1},
+	"sessions context":  {completeSessions, 1},
+	"agents show":       {completeAgents, 1},
+	"access grant":      {completeAccessLists, 1},
+	"access revoke":     {completeAccessLists, 1},
+}
+
+// flagCompletions maps command paths (without "nexus") to the values their
+// flags take. The empty path is the root command's persistent flags.
+var flagCompletions = map[string]map[string]completionSource{
+	"":                        {"profile": completeProfiles},
+	"sessions branches list":  {"session-id": completeSessions},
+	"sessions branches tree":  {"session-id": completeSessions},
+	"artifacts list":          {"session": completeSessions},
+	"events list":             {"session": completeSessions},
+	"events query":            {"session": completeSessions, "agent": completeAgents},
+	"migrate sessions-export": {"agent": completeAgents},
+	"steering test":           {"agent": completeAgents},
+	"prompt":                  {"session-id": completeSessions},
+	"access list":             {"list": completeAccessLists},
+}
+
+// registerDynamicCompletions attaches the dynamic completions above to the
+// command tree under root.
+func registerDynamicCompletions(root *cobra.Command) {
+	walkCommands(root, func(cmd *cobra.Command) {
+		path := strings.TrimSpace(strings.TrimPrefix(cmd.CommandPath(), root.Name()))
+		if arg, ok := argCompletions[path]; ok {
+			cmd.ValidArgsFunction = func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+				if len(args) >= arg.maxArgs {
+					return nil, cobra.ShellCompDirectiveNoFileComp
+				}
+				return runCompletionSource(cmd, arg.source, toComplete)
+			}
+		}
+		for name, source := range flagCompletions[path] {
+			flags := cmd.Flags()
+			if path == "" {
+				flags = cmd.PersistentFlags()
+			}
+			if flags.Lookup(name) == nil {
+				continue
+			}
+			_ = cmd.RegisterFlagCompletionFunc(name, func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+				return runCompletionSource(cmd, source, toComplete)
+			})
+		}
+	})
+}
+
+func walkCommands(cmd *cobra.Command, fn func(*cobra.Command)) {
+	fn(cmd)
+	for _, sub := range cmd.Commands() {
+		walkCommands(sub, fn)
+	}
+}
+
+// runCompletionSource returns the values of source that start with
+// toComplete. Files are never offered in their place.
+func runCompletionSource(cmd *cobra.Command, source completionSource, toComplete string) ([]string, cobra.ShellCompDirective) {
+	ctx := cmd.Context()
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	ctx, cancel := context.WithTimeout(ctx, completionTimeout)
+	defer cancel()
+
+	var matches []string
+	for _, candidate := range source(ctx, cmd) {
+		value, _, _ := strings.Cut(candidate, "\t")
+		if strings.HasPrefix(value, toComplete) {
+			matches = append(matches, candidate)
+		}
+	}
+	return matches, cobra.ShellCompDirectiveNoFileComp
+}
+
+// completionConfig loads the config selected by the command's --config flag
+// and the active profile. It returns nil when the config cannot be loaded.
+func completionConfig(cmd *cobra.Command) *config.Config {
+	configPath := ""
+	if flag := cmd.Flags().Lookup("config"); flag != nil {
+		configPath = flag.Value.String()
+	}
+	cfg, err := config.Load(resolveConfigPath(configPath))
+	if err != nil {
+		return nil
+	}
+	return cfg
+}
+
+// completeProfiles lists profile names, marking the active one.
+func completeProfiles(_ context.Context, _ *cobra.Command) []string {
+	names, err := profile.ListProfiles()
+	if err != nil {
+		return nil
+	}
+	active, _ := profile.ReadActiveProfile()
+	values := make([]string, 0, len(names))
+	for _, name := range names {
+		if name == active {
+			name += "\tactive"
+		}
+		values = append(values, name)
+	}
+	return values
+}
+
+// completeAccessLists lists the access-control lists grants can be made to.
+func completeAccessLists(_ context.Context, _ *cobra.Command) []string {
+	values := make([]string, 0, len(access.Lists))
+	for _, list := range access.Lists {
+		values = append(values, string(list))
+	}
+	return values
+}
+
+// completePlugins lists installed plugin IDs and plugins configured under
+// plugins.entries.
+func completePlugins(_ context.Context, cmd *cobra.Command) []string {
+	cfg := completionConfig(cmd)
+	if cfg == nil {
+		return nil
+	}
+	descriptions := map[string]string{}
+	for id := range cfg.Plugins.Entries {
+		descriptions[id] = "configured"
+	}
+	if mgr, err := createMarketplaceManager(cfg); err == nil {
+		for _, plugin := range mgr.List() {
+			status := "enabled"
+			if !plugin.Enabled {
+				status = "disabled"
+			}
+			descriptions[plugin.ID] = fmt.Sprintf("%s, %s", plugin.Version, status)
+		}
+	}
+	return describedValues(descriptions)
+}
+
+// completeSkills lists discovered skill names.
+func completeSkills(ctx context.Context, cmd *cobra.Command) []string {
+	cfg := completionConfig(cmd)
+	if cfg == nil {
+		return nil
+	}
+	mgr, err := skills.NewManager(&cfg.Skills, cfg.Workspace.Path, nil)
+	if err != nil {
+		return nil
+	}
+	if err := mgr.Discover(ctx); err != nil {
+		return nil
+	}
+	descriptions := map[string]string{}
+	for _, skill := range mgr.ListAll() {
+		descriptions[skill.Name] = skill.Description
+	}
+	return describedValues(descriptions)
+}
+
+// completeAgents lists agent IDs from AGENTS.md and the default agent.
+func completeAgents(_ context.Context, cmd *cobra.Command) []string {
+	cfg := completionConfig(cmd)
+	if cfg == nil {
+		return nil
+	}
+	return describedValues(completionAgents(cfg))
+}
+
+// completionAgents maps the known agent IDs to their names.
+func completionAgents(cfg *config.Config) map[string]string {
+	agents := map[string]string{}
+	if id := strings.TrimSpace(cfg.Session.DefaultAgentID); id != "" {
+		agents[id] = "default agent"
+	}
+	if manifest, err := multiagent.LoadAgentsManifest(resolveAgentsPath(cfg)); err == nil {
+		for _, agent := range manifest.Agents {
+			if strings.TrimSpace(agent.ID) != "" {
+				agents[agent.ID] = agent.Name
+			}
+		}
+	}
+	return agents
+}
+
+// completeSessions lists the most recently updated sessions of each known
+// agent.
+func completeSessions(ctx context.Context, cmd *cobra.Command) []string {
+	cfg := completionConfig(cmd)
+	if cfg == nil {
+		return nil
+	}
+	store, closeStore, err := openSessionStore(cfg)
+	if err != nil {
+		return nil
+	}
+	defer closeStore()
+
+	agentIDs := make([]string, 0)
+	for id := range completionAgents(cfg) {
+		agentIDs = append(agentIDs, id)
+	}
+	sort.Strings(agentIDs)
+
+	var values []string
+	for _, agentID := range agentIDs {
+		list, err := store.List(ctx, agentID, sessions.ListOptions{Limit: completionSessionLimit})
+		if err != nil {
+			continue
+		}
+		for _, session := range list {
+			label := strings.TrimSpace(session.Title)
+			if label == "" {
+				label = session.Key
+			}
+			values = append(values, session.ID+"\t"+label)
+		}
+	}
+	return values
+}
+
+// describedValues formats a value→description map as sorted completions.
+func describedValues(descriptions map[string]string) []string {
+	values := make([]string, 0, len(descriptions))
+	for value, description := range descriptions {
+		description = strings.Join(strings.Fields(description), " ")
+		if description != "" {
+			value += "\t" + description
+		}
+		values = append(values, value)
+	}
+	sort.Strings(values)
+	return values
+}
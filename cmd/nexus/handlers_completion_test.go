@@ -0,0 +1,118 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+func TestDynamicCompletionsMatchCommandTree(t *testing.T) {
+	root := buildRootCmd()
+	commands := map[string]*cobra.Command{}
+	walkCommands(root, func(cmd *cobra.Command) {
+		commands[strings.TrimSpace(strings.TrimPrefix(cmd.CommandPath(), root.Name()))] = cmd
+	})
+
+	for path := range argCompletions {
+		cmd, ok := commands[path]
+		if !ok {
+			t.Errorf("argument completion for unknown command %q", path)
+			continue
+		}
+		if cmd.ValidArgsFunction == nil {
+			t.Errorf("command %q has no argument completion", path)
+		}
+	}
+	for path, flags := range flagCompletions {
+		cmd, ok := commands[path]
+		if !ok {
+			t.Errorf("flag completion for unknown command %q", path)
+			continue
+		}
+		for name := range flags {
+			if _, ok := cmd.GetFlagCompletionFunc(name); !ok {
+				t.Errorf("flag --%s of %q has no completion", name, path)
+			}
+		}
+	}
+}
+
+func runComplete(t *testing.T, args ...string) []string {
+	t.Helper()
+	root := buildRootCmd()
+	var out bytes.Buffer
+	root.SetOut(&out)
+	root.SetErr(&bytes.Buffer{})
+	root.SetArgs(append([]string{cobra.ShellCompRequestCmd}, args...))
+	if err := root.Execute(); err != nil {
+		t.Fatalf("complete %v: %v", args, err)
+	}
+	var lines []string
+	for _, line := range strings.Split(strings.TrimSpace(out.String()), "\n") {
+		if !strings.HasPrefix(line, ":") {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
+
+func TestCompletionOffersProfiles(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("NEXUS_PROFILE", "")
+	dir := filepath.Join(home, ".nexus", "profiles")
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"prod", "staging", "dev"} {
+		if err := os.WriteFile(filepath.Join(dir, name+".yaml"), nil, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(home, ".nexus", "active_profile"), []byte("prod\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	got := runComplete(t, "profile", "use", "")
+	want := []string{"dev", "prod\tactive", "staging"}
+	if strings.Join(got, "|") != strings.Join(want, "|") {
+		t.Fatalf("profile use completions = %q, want %q", got, want)
+	}
+	if got := runComplete(t, "status", "--profile", "st"); len(got) != 1 || got[0] != "staging" {
+		t.Fatalf("--profile completions = %q", got)
+	}
+	if got := runComplete(t, "profile", "use", "prod", ""); len(got) != 0 {
+		t.Fatalf("expected no completions after the argument, got %q", got)
+	}
+}
+
+func TestCompletionCommandGeneratesScripts(t *testing.T) {
+	for shell, marker := range map[string]string{
+		"bash": "__start_nexus",
+		"zsh":  "#compdef nexus",
+		"fish": "complete -c nexus",
+	} {
+		root := buildRootCmd()
+		var out bytes.Buffer
+		root.SetOut(&out)
+		root.SetArgs([]string{"completion", shell})
+		if err := root.Execute(); err != nil {
+			t.Fatalf("completion %s: %v", shell, err)
+		}
+		if !strings.Contains(out.String(), marker) {
+			t.Fatalf("completion %s output missing %q", shell, marker)
+		}
+	}
+
+	root := buildRootCmd()
+	root.SetOut(&bytes.Buffer{})
+	root.SetErr(&bytes.Buffer{})
+	root.SetArgs([]string{"completion", "tcsh"})
+	if err := root.Execute(); err == nil {
+		t.Fatal("expected an error for an unsupported shell")
+	}
+}
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/haasonsaas/nexus/internal/config"
+	"github.com/haasonsaas/nexus/internal/gitops"
+	"github.com/haasonsaas/nexus/internal/profile"
+	"github.com/spf13/cobra"
+)
+
+// =============================================================================
+// Config Command Handlers
+// =============================================================================
+
+// runConfigSchema handles the config schema command.
+func runConfigSchema(cmd *cobra.Command, output string) error {
+	schema, err := config.JSONSchema()
+	if err != nil {
+		return fmt.Errorf("build config schema: %w", err)
+	}
+	schema = append(schema, '\n')
+	if output == "" {
+		_, err := cmd.OutOrStdout().Write(schema)
+		return err
+	}
+	if err := os.WriteFile(output, schema, 0o644); err != nil {
+		return fmt.Errorf("write schema: %w", err)
+	}
+	fmt.Fprintf(cmd.OutOrStdout(), "Wrote %s\n", output)
+	return nil
+}
+
+// runConfigApply handles the config apply command.
+func runConfigApply(cmd *cobra.Command, configPath, fromGit, ref string, dryRun bool) error {
+	configPath = resolveConfigPath(configPath)
+	src, err := gitops.ParseSource(fromGit)
+	if err != nil {
+		return err
+	}
+	src.Ref = ref
+
+	cacheDir, err := os.MkdirTemp("", "nexus-gitops-")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(cacheDir)
+	snapshot, err := gitops.NewFetcher(cacheDir).Fetch(cmd.Context(), src)
+	if err != nil {
+		return fmt.Errorf("fetch %s: %w", src, err)
+	}
+	if _, err := snapshot.Load(filepath.Dir(configPath)); err != nil {
+		return err
+	}
+
+	local, err := os.ReadFile(configPath)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("read %s: %w", configPath, err)
+	}
+	out := cmd.OutOrStdout()
+	if bytes.Equal(local, snapshot.Raw) {
+		fmt.Fprintf(out, "%s already matches %s at %s.\n", configPath, src, snapshot.ShortCommit())
+		return nil
+	}
+	changes, err := gitops.Drift(local, snapshot.Raw)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(out, "--- %s\n+++ %s at %s\n", configPath, src, snapshot.ShortCommit())
+	for _, change := range changes {
+		switch change.Kind {
+		case profile.ChangeAdded:
+			fmt.Fprintf(out, "+ %s: %s\n", change.Path, profile.FormatValue(change.Path, change.To))
+		case profile.ChangeRemoved:
+			fmt.Fprintf(out, "- %s: %s\n", change.Path, profile.FormatValue(change.Path, change.From))
+		default:
+			fmt.Fprintf(out, "~ %s: %s -> %s\n", change.Path,
+				profile.FormatValue(change.Path, change.From), profile.FormatValue(change.Path, change.To))
+		}
+	}
+	if len(changes) == 0 {
+		fmt.Fprintln(out, "  (formatting or comments only)")
+	}
+	if dryRun {
+		return nil
+	}
+
+	mode := os.FileMode(0o644)
+	if info, err := os.Stat(configPath); err == nil {
+		mode = info.Mode().Perm()
+	}
+	if err := os.MkdirAll(filepath.Dir(configPath), 0o755); err != nil {
+		return err
+	}
+	if err := os.WriteFile(configPath, snapshot.Raw, mode); err != nil {
+		return fmt.Errorf("write %s: %w", configPath, err)
+	}
+	fmt.Fprintf(out, "Wrote %s\n", configPath)
+	return nil
+}
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/haasonsaas/nexus/internal/config"
+)
+
+func TestConfigSchemaCommand(t *testing.T) {
+	output := filepath.Join(t.TempDir(), "nexus.schema.json")
+	root := buildRootCmd()
+	root.SetOut(&bytes.Buffer{})
+	root.SetArgs([]string{"config", "schema", "-o", output})
+	if err := root.Execute(); err != nil {
+		t.Fatalf("config schema: %v", err)
+	}
+
+	data, err := os.ReadFile(output)
+	if err != nil {
+		t.Fatalf("read schema: %v", err)
+	}
+	var schema map[string]any
+	if err := json.Unmarshal(data, &schema); err != nil {
+		t.Fatalf("schema is not JSON: %v", err)
+	}
+	if schema["$id"] != config.SchemaURL {
+		t.Fatalf("$id = %v", schema["$id"])
+	}
+}
+
+func TestConfigApplyFromGit(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	t.Setenv("NEXUS_PROFILE", "")
+	const base = "version: 1\nllm:\n  default_provider: anthropic\n  providers:\n    anthropic:\n      api_key: "
+	repo := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(repo, "deploy"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(repo, "deploy", "nexus.yaml"), []byte(base+"new-key\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	for _, args := range [][]string{
+		{"init", "--quiet"},
+		{"add", "-A"},
+		{"-c", "user.name=test", "-c", "user.email=test@example.com", "commit", "--quiet", "-m", "config"},
+	} {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = repo
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v: %s", args, err, out)
+		}
+	}
+	configPath := filepath.Join(t.TempDir(), "nexus.yaml")
+	if err := os.WriteFile(configPath, []byte(base+"old-key\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	apply := func(extra ...string) string {
+		t.Helper()
+		root := buildRootCmd()
+		var out bytes.Buffer
+		root.SetOut(&out)
+		root.SetArgs(append([]string{"config", "apply", "-c", configPath, "--from-git", "file://" + repo + "#deploy/nexus.yaml"}, extra...))
+		if err := root.Execute(); err != nil {
+			t.Fatalf("config apply: %v", err)
+		}
+		return out.String()
+	}
+
+	out := apply("--dry-run")
+	if !strings.Contains(out, "~ llm.providers.anthropic.api_key: *** -> ***") {
+		t.Fatalf("dry run should list the masked change:\n%s", out)
+	}
+	if data, _ := os.ReadFile(configPath); string(data) != base+"old-key\n" {
+		t.Fatalf("dry run wrote the config: %q", data)
+	}
+
+	apply()
+	data, err := os.ReadFile(configPath)
+	if err != nil || string(data) != base+"new-key\n" {
+		t.Fatalf("config not applied: %q, %v", data, err)
+	}
+	if info, err := os.Stat(configPath); err != nil {
+		t.Fatal(err)
+	} else if info.Mode().Perm() != 0o600 {
+		t.Fatalf("config mode not preserved: %v", info.Mode())
+	}
+	if out := apply(); !strings.Contains(out, "already matches") {
+		t.Fatalf("expected no changes on a second apply:\n%s", out)
+	}
+}
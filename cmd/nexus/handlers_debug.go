@@ -0,0 +1,295 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/haasonsaas/nexus/internal/agent"
+	"github.com/haasonsaas/nexus/internal/config"
+	"github.com/haasonsaas/nexus/internal/doctor"
+	"github.com/haasonsaas/nexus/internal/gateway"
+	"github.com/haasonsaas/nexus/internal/service"
+	"github.com/haasonsaas/nexus/pkg/models"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+// =============================================================================
+// Debug Command Handlers
+// =============================================================================
+
+// debugBundleOptions holds the debug bundle command flags.
+type debugBundleOptions struct {
+	output   string
+	logFiles []string
+	logLines int
+	traceDir string
+	traces   int
+	yes      bool
+}
+
+// runDebugBundle handles the debug bundle command.
+func runDebugBundle(cmd *cobra.Command, configPath string, opts debugBundleOptions) error {
+	configPath = resolveConfigPath(configPath)
+	out := cmd.OutOrStdout()
+	ctx := cmd.Context()
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	now := time.Now()
+
+	files := []doctor.BundleFile{
+		{Name: "version.txt", Data: debugVersionInfo(configPath, now)},
+		{Name: "config.yaml", Data: debugConfig(configPath)},
+		{Name: "doctor.txt", Data: debugDoctorOutput(ctx, configPath)},
+	}
+	if cfg, err := config.Load(configPath); err == nil {
+		var schema bytes.Buffer
+		if err := printSchemaDrift(ctx, &schema, cfg); err != nil {
+			fmt.Fprintf(&schema, "Schema drift: %v\n", err)
+		}
+		files = append(files, doctor.BundleFile{Name: "schema.txt", Data: schema.Bytes()})
+	}
+	files = append(files, debugLogs(ctx, opts)...)
+	if traces := debugTraceSummaries(opts); traces != nil {
+		files = append(files, *traces)
+	}
+	for i := range files {
+		files[i].Data = []byte(gateway.RedactSecrets(string(files[i].Data), ""))
+	}
+
+	if !opts.yes {
+		var ok bool
+		files, ok = reviewDebugBundle(bufio.NewReader(cmd.InOrStdin()), out, files)
+		if !ok {
+			fmt.Fprintln(out, "Cancelled")
+			return nil
+		}
+	}
+
+	name := "nexus-debug-" + now.Format("20060102-150405")
+	output := opts.output
+	if output == "" {
+		output = name + ".tar.gz"
+	}
+	f, err := os.OpenFile(output, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o600)
+	if err != nil {
+		return fmt.Errorf("create bundle: %w", err)
+	}
+	if err := doctor.WriteBundle(f, name, files); err != nil {
+		f.Close()
+		return fmt.Errorf("write bundle: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("write bundle: %w", err)
+	}
+	fmt.Fprintf(out, "Wrote %s (%d files). Review it before sharing; redaction is best effort.\n", output, len(files))
+	return nil
+}
+
+// debugVersionInfo describes the binary and host.
+func debugVersionInfo(configPath string, now time.Time) []byte {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "Version:    %s\n", version)
+	fmt.Fprintf(&buf, "Commit:     %s\n", commit)
+	fmt.Fprintf(&buf, "Built:      %s\n", date)
+	fmt.Fprintf(&buf, "Go:         %s\n", runtime.Version())
+	fmt.Fprintf(&buf, "Platform:   %s/%s (%d CPUs)\n", runtime.GOOS, runtime.GOARCH, runtime.NumCPU())
+	fmt.Fprintf(&buf, "Config:     %s\n", configPath)
+	if profileName != "" {
+		fmt.Fprintf(&buf, "Profile:    %s\n", profileName)
+	}
+	fmt.Fprintf(&buf, "Generated:  %s\n", now.UTC().Format(time.RFC3339))
+	return buf.Bytes()
+}
+
+// debugConfig returns the raw config with secrets masked. Environment
+// references such as ${ANTHROPIC_API_KEY} are kept unexpanded.
+func debugConfig(configPath string) []byte {
+	raw, err := doctor.LoadRawConfig(configPath)
+	if err != nil {
+		return []byte(fmt.Sprintf("# failed to read config: %v\n", err))
+	}
+	data, err := yaml.Marshal(doctor.RedactConfig(raw))
+	if err != nil {
+		return []byte(fmt.Sprintf("# failed to encode config: %v\n", err))
+	}
+	return data
+}
+
+// debugDoctorOutput captures `nexus doctor` without network probes.
+func debugDoctorOutput(ctx context.Context, configPath string) []byte {
+	var buf bytes.Buffer
+	doctorCmd := &cobra.Command{}
+	doctorCmd.SetOut(&buf)
+	doctorCmd.SetContext(ctx)
+	if err := runDoctor(doctorCmd, configPath, doctorOptions{}); err != nil {
+		fmt.Fprintf(&buf, "Error: %v\n", err)
+	}
+	return buf.Bytes()
+}
+
+// debugLogs returns the last lines of each log file, or of the systemd user
+// journal when no files are given.
+func debugLogs(ctx context.Context, opts debugBundleOptions) []doctor.BundleFile {
+	lines := opts.logLines
+	if lines <= 0 {
+		return nil
+	}
+	var files []doctor.BundleFile
+	seen := map[string]int{}
+	for _, path := range opts.logFiles {
+		name := filepath.Base(path)
+		if n := seen[name]; n > 0 {
+			name = fmt.Sprintf("%d-%s", n, name)
+		}
+		seen[filepath.Base(path)]++
+		data, err := doctor.TailLines(path, lines)
+		if err != nil {
+			data = []byte(fmt.Sprintf("failed to read %s: %v\n", path, err))
+		}
+		files = append(files, doctor.BundleFile{Name: "logs/" + name, Data: data})
+	}
+	if len(files) > 0 || runtime.GOOS != "linux" {
+		return files
+	}
+	if _, err := exec.LookPath("journalctl"); err != nil {
+		return nil
+	}
+	journal, err := exec.CommandContext(ctx, "journalctl", "--user", "-u", service.SystemdUnitName,
+		"-n", strconv.Itoa(lines), "--no-pager", "-o", "short-iso").CombinedOutput()
+	if err != nil && len(journal) == 0 {
+		return nil
+	}
+	return []doctor.BundleFile{{Name: "logs/journal.txt", Data: journal}}
+}
+
+// debugTraceSummary is the per-run entry in traces.json.
+type debugTraceSummary struct {
+	File  string           `json:"file"`
+	Stats *models.RunStats `json:"stats,omitempty"`
+	Error string           `json:"error,omitempty"`
+}
+
+// debugTraceSummaries summarizes the most recent trace files. Only run
+// statistics are included, never event payloads.
+func debugTraceSummaries(opts debugBundleOptions) *doctor.BundleFile {
+	dir := strings.TrimSpace(opts.traceDir)
+	if dir == "" {
+		dir = strings.TrimSpace(os.Getenv("NEXUS_TRACE_DIR"))
+	}
+	if dir == "" || opts.traces <= 0 {
+		return nil
+	}
+	paths, err := filepath.Glob(filepath.Join(dir, "*.jsonl"))
+	if err != nil || len(paths) == 0 {
+		return nil
+	}
+	modTimes := make(map[string]time.Time, len(paths))
+	for _, path := range paths {
+		if info, err := os.Stat(path); err == nil {
+			modTimes[path] = info.ModTime()
+		}
+	}
+	sort.Slice(paths, func(i, j int) bool { return modTimes[paths[i]].After(modTimes[paths[j]]) })
+	if len(paths) > opts.traces {
+		paths = paths[:opts.traces]
+	}
+
+	summaries := make([]debugTraceSummary, 0, len(paths))
+	for _, path := range paths {
+		summary := debugTraceSummary{File: filepath.Base(path)}
+		stats, err := traceFileStats(path)
+		if err != nil {
+			summary.Error = err.Error()
+		}
+		summary.Stats = stats
+		summaries = append(summaries, summary)
+	}
+	data, err := json.MarshalIndent(summaries, "", "  ")
+	if err != nil {
+		return nil
+	}
+	return &doctor.BundleFile{Name: "traces.json", Data: append(data, '\n')}
+}
+
+func traceFileStats(path string) (*models.RunStats, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	reader, err := agent.NewTraceReader(f)
+	if err != nil {
+		return nil, err
+	}
+	return agent.ReplayToStats(reader)
+}
+
+// reviewDebugBundle lists the bundle contents and lets the user view or
+// drop files before writing. It reports false if the user cancels.
+func reviewDebugBundle(in *bufio.Reader, out io.Writer, files []doctor.BundleFile) ([]doctor.BundleFile, bool) {
+	for {
+		fmt.Fprintln(out, "Debug bundle contents:")
+		for i, file := range files {
+			fmt.Fprintf(out, "  %d. %-24s %d bytes\n", i+1, file.Name, len(file.Data))
+		}
+		fmt.Fprint(out, "[w]rite, [v]iew <n>, [d]rop <n>, [q]uit: ")
+		line, err := in.ReadString('\n')
+		if err != nil && line == "" {
+			fmt.Fprintln(out)
+			return nil, false
+		}
+		fields := strings.Fields(strings.ToLower(line))
+		if len(fields) == 0 {
+			continue
+		}
+		index := -1
+		if len(fields) > 1 {
+			if n, err := strconv.Atoi(fields[1]); err == nil && n >= 1 && n <= len(files) {
+				index = n - 1
+			}
+		}
+		switch fields[0] {
+		case "w", "write", "y", "yes":
+			if len(files) == 0 {
+				fmt.Fprintln(out, "Nothing left to write.")
+				continue
+			}
+			return files, true
+		case "q", "quit", "n", "no":
+			return nil, false
+		case "v", "view":
+			if index < 0 {
+				fmt.Fprintln(out, "Usage: view <n>")
+				continue
+			}
+			fmt.Fprintf(out, "----- %s -----\n%s", files[index].Name, files[index].Data)
+			if len(files[index].Data) > 0 && !bytes.HasSuffix(files[index].Data, []byte("\n")) {
+				fmt.Fprintln(out)
+			}
+			fmt.Fprintf(out, "----- end of %s -----\n", files[index].Name)
+		case "d", "drop":
+			if index < 0 {
+				fmt.Fprintln(out, "Usage: drop <n>")
+				continue
+			}
+			fmt.Fprintf(out, "Dropped %s\n", files[index].Name)
+			files = append(files[:index], files[index+1:]...)
+		default:
+			fmt.Fprintf(out, "Unknown command %q\n", fields[0])
+		}
+	}
+}
@@ -0,0 +1,31 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/haasonsaas/nexus/internal/doctor"
+)
+
+func TestReviewDebugBundle(t *testing.T) {
+	files := []doctor.BundleFile{
+		{Name: "version.txt", Data: []byte("dev\n")},
+		{Name: "config.yaml", Data: []byte("llm: {}\n")},
+		{Name: "logs/journal.txt", Data: []byte("started\n")},
+	}
+	var out bytes.Buffer
+	in := bufio.NewReader(strings.NewReader("view 2\ndrop 3\nbogus\nw\n"))
+	kept, ok := reviewDebugBundle(in, &out, files)
+	if !ok || len(kept) != 2 || kept[1].Name != "config.yaml" {
+		t.Fatalf("unexpected review result %v %+v", ok, kept)
+	}
+	if !strings.Contains(out.String(), "----- config.yaml -----\nllm: {}\n") || !strings.Contains(out.String(), "Dropped logs/journal.txt") {
+		t.Fatalf("unexpected output:\n%s", out.String())
+	}
+
+	if _, ok := reviewDebugBundle(bufio.NewReader(strings.NewReader("")), &out, kept); ok {
+		t.Fatalf("expected end of input to cancel")
+	}
+}
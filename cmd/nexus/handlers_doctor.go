@@ -1,14 +1,21 @@
 package main
 
 import (
+	"context"
 	"fmt"
+	"io"
 	"log/slog"
+	"os"
+	"runtime"
 	"strings"
 
 	"github.com/haasonsaas/nexus/internal/config"
+	"github.com/haasonsaas/nexus/internal/credentials"
 	"github.com/haasonsaas/nexus/internal/doctor"
 	"github.com/haasonsaas/nexus/internal/gateway"
 	"github.com/haasonsaas/nexus/internal/plugins"
+	"github.com/haasonsaas/nexus/internal/sessions"
+	"github.com/haasonsaas/nexus/internal/storage/sqlite"
 	"github.com/haasonsaas/nexus/pkg/models"
 	"github.com/spf13/cobra"
 )
@@ -17,10 +24,20 @@ import (
 // Doctor Command Handler
 // =============================================================================
 
+// doctorOptions holds the doctor command flags.
+type doctorOptions struct {
+	repair         bool
+	probe          bool
+	audit          bool
+	fixPermissions bool
+	deep           bool
+}
+
 // runDoctor handles the doctor command.
-func runDoctor(cmd *cobra.Command, configPath string, repair, probe, audit bool) error {
+func runDoctor(cmd *cobra.Command, configPath string, opts doctorOptions) error {
 	configPath = resolveConfigPath(configPath)
 	out := cmd.OutOrStdout()
+	repair := opts.repair
 
 	raw, err := doctor.LoadRawConfig(configPath)
 	if err != nil {
@@ -86,7 +103,13 @@ func runDoctor(cmd *cobra.Command, configPath string, repair, probe, audit bool)
 		}
 	}
 
-	if probe {
+	printPermissionIssues(out, doctor.AuditPermissions(cfg, configPath), opts.fixPermissions)
+	printDependencies(out, doctor.CheckDependencies(cfg))
+	if opts.deep {
+		runDeepChecks(cmd, cfg)
+	}
+
+	if opts.probe {
 		server, err := gateway.NewServer(cfg, slog.Default())
 		if err != nil {
 			return fmt.Errorf("failed to initialize gateway for probes: %w", err)
@@ -108,6 +131,8 @@ func runDoctor(cmd *cobra.Command, configPath string, repair, probe, audit bool)
 			}
 		}
 
+		printCredentialChecks(cmd.Context(), out, cfg)
+
 		// Check reminder status
 		if server.TaskStore() != nil {
 			reminderStatus := doctor.ProbeReminderStatus(cmd.Context(), server.TaskStore())
@@ -120,13 +145,16 @@ func runDoctor(cmd *cobra.Command, configPath string, repair, probe, audit bool)
 		}
 	}
 
-	if audit {
+	if opts.audit {
 		report := doctor.AuditServices(cfg)
 		fmt.Fprintln(out, "Service audit:")
 		printAuditList(out, "systemd user", report.SystemdUser)
 		printAuditList(out, "systemd system", report.SystemdSystem)
 		printAuditList(out, "launchd user", report.LaunchdUser)
 		printAuditList(out, "launchd system", report.LaunchdSystem)
+		if runtime.GOOS == "windows" {
+			printAuditList(out, "windows service", report.WindowsServices)
+		}
 		if len(report.Ports) > 0 {
 			fmt.Fprintln(out, "Port checks:")
 			for _, port := range report.Ports {
@@ -134,6 +162,9 @@ func runDoctor(cmd *cobra.Command, configPath string, repair, probe, audit bool)
 				if port.InUse {
 					status = "in use"
 				}
+				if port.PID > 0 {
+					status = fmt.Sprintf("%s by PID %d", status, port.PID)
+				}
 				if port.Error != "" {
 					fmt.Fprintf(out, "  - %d: %s (%s)\n", port.Port, status, port.Error)
 				} else {
@@ -156,6 +187,143 @@ func runDoctor(cmd *cobra.Command, configPath string, repair, probe, audit bool)
 	return nil
 }
 
+// printPermissionIssues reports, and optionally repairs, sensitive paths that
+// other users can access.
+func printPermissionIssues(out io.Writer, issues []doctor.PermissionIssue, fix bool) {
+	if len(issues) == 0 {
+		return
+	}
+	if fix {
+		issues = doctor.FixPermissions(issues)
+		fmt.Fprintln(out, "Permission repairs:")
+	} else {
+		fmt.Fprintln(out, "Permission issues (run `nexus doctor --fix-permissions` to repair):")
+	}
+	for _, issue := range issues {
+		switch {
+		case issue.Error != "":
+			fmt.Fprintf(out, "  - %s %s: %#o -> %#o failed: %s\n", issue.Label, issue.Path, issue.Mode, issue.Want, issue.Error)
+		case issue.Fixed:
+			fmt.Fprintf(out, "  - %s %s: %#o -> %#o\n", issue.Label, issue.Path, issue.Mode, issue.Want)
+		default:
+			fmt.Fprintf(out, "  - %s %s is %#o (want %#o)\n", issue.Label, issue.Path, issue.Mode, issue.Want)
+		}
+	}
+}
+
+// printDependencies reports binaries required by the config.
+func printDependencies(out io.Writer, deps []doctor.DependencyStatus) {
+	if len(deps) == 0 {
+		return
+	}
+	fmt.Fprintln(out, "Dependencies:")
+	for _, dep := range deps {
+		if dep.Found {
+			fmt.Fprintf(out, "  - %s: %s\n", dep.Name, dep.Path)
+		} else {
+			fmt.Fprintf(out, "  - %s: not found (required by %s)\n", dep.Name, dep.Reason)
+		}
+	}
+}
+
+// printCredentialChecks validates provider API keys and channel tokens
+// against the live APIs.
+func printCredentialChecks(ctx context.Context, out io.Writer, cfg *config.Config) {
+	targets := credentials.Targets(cfg)
+	if len(targets) == 0 {
+		fmt.Fprintln(out, "Credential checks: no provider keys or channel tokens configured")
+		return
+	}
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	monitor := credentials.NewMonitor(cfg.Security.Credentials, targets, nil, logger)
+	fmt.Fprintln(out, "Credential checks:")
+	for _, status := range monitor.Check(ctx) {
+		line := fmt.Sprintf("  - %s %s: %s", status.Kind, status.Name, strings.ReplaceAll(string(status.State), "_", " "))
+		switch {
+		case status.Message != "":
+			line += " (" + status.Message + ")"
+		case status.Quota.Known():
+			line += fmt.Sprintf(" (%.0f of %.0f %s remaining)", status.Quota.Remaining, status.Quota.Limit, status.Quota.Unit)
+		}
+		fmt.Fprintln(out, line)
+	}
+}
+
+// runDeepChecks compares the database schema with this binary and measures
+// clock skew against the config's token issuers.
+func runDeepChecks(cmd *cobra.Command, cfg *config.Config) {
+	out := cmd.OutOrStdout()
+	ctx := cmd.Context()
+
+	if err := printSchemaDrift(ctx, out, cfg); err != nil {
+		fmt.Fprintf(out, "Schema drift: %v\n", err)
+	}
+
+	sources := doctor.ClockSkewSources(cfg)
+	if len(sources) == 0 {
+		fmt.Fprintln(out, "Clock skew: skipped (no token-based auth configured)")
+		return
+	}
+	fmt.Fprintln(out, "Clock skew:")
+	for _, source := range sources {
+		status := doctor.CheckClockSkew(ctx, nil, source)
+		switch {
+		case status.Error != "":
+			fmt.Fprintf(out, "  - %s: %s\n", source, status.Error)
+		case status.Exceeded():
+			fmt.Fprintf(out, "  - %s: %s (exceeds %s; token validation will fail, sync the system clock)\n", source, status.Skew, doctor.MaxClockSkew)
+		default:
+			fmt.Fprintf(out, "  - %s: %s\n", source, status.Skew)
+		}
+	}
+}
+
+// printSchemaDrift reports migrations that are pending or unknown to this
+// binary.
+func printSchemaDrift(ctx context.Context, out io.Writer, cfg *config.Config) error {
+	if strings.TrimSpace(cfg.Database.URL) == "" {
+		fmt.Fprintln(out, "Schema drift: skipped (no database.url)")
+		return nil
+	}
+	if sqlite.IsURL(cfg.Database.URL) {
+		// Opening a missing SQLite file would create it.
+		if path, err := sqlite.Path(cfg.Database.URL); err == nil && path != ":memory:" {
+			if _, err := os.Stat(path); os.IsNotExist(err) {
+				fmt.Fprintf(out, "Schema drift: skipped (%s does not exist yet)\n", path)
+				return nil
+			}
+		}
+	}
+	db, err := openMigrationDB(cfg)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+	migrator, err := sessions.NewMigratorForDialect(db, sessions.DialectForURL(cfg.Database.URL))
+	if err != nil {
+		return err
+	}
+	drift, err := doctor.CheckSchemaDrift(ctx, migrator)
+	if err != nil {
+		return err
+	}
+	if !drift.Drifted() {
+		fmt.Fprintf(out, "Schema drift: none (%d migrations applied, latest %s)\n", drift.Applied, drift.Latest)
+		return nil
+	}
+	fmt.Fprintln(out, "Schema drift:")
+	if drift.Latest != "" {
+		fmt.Fprintf(out, "  - latest applied: %s\n", drift.Latest)
+	}
+	for _, id := range drift.Pending {
+		fmt.Fprintf(out, "  - pending: %s (run `nexus migrate up`)\n", id)
+	}
+	for _, id := range drift.Unknown {
+		fmt.Fprintf(out, "  - unknown to this binary: %s (database was migrated by a newer release)\n", id)
+	}
+	return nil
+}
+
 // =============================================================================
 // Prompt Command Handler
 // =============================================================================
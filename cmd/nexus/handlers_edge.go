@@ -98,6 +98,35 @@ func printSystemStatus(ctx context.Context, out io.Writer, jsonOutput bool, conf
 		fmt.Fprintln(out)
 	}
 
+	if len(status.Steering) > 0 {
+		fmt.Fprintln(out, "Steering Rules")
+		for _, rule := range status.Steering {
+			state := ""
+			if !rule.Enabled {
+				state = " (disabled)"
+			}
+			last := "never"
+			if !rule.LastHit.IsZero() {
+				last = rule.LastHit.Local().Format(time.RFC3339)
+			}
+			fmt.Fprintf(out, "   %s%s: %d hits, last %s\n", rule.ID, state, rule.Hits, last)
+		}
+		fmt.Fprintln(out)
+	}
+
+	if len(status.Anomalies) > 0 {
+		fmt.Fprintln(out, "Anomalies")
+		for _, a := range status.Anomalies {
+			name := a.Signal
+			if a.Key != "" {
+				name += " " + a.Key
+			}
+			fmt.Fprintf(out, "   %s: %.0f vs baseline %.1f (z %.1f), since %s\n",
+				name, a.Value, a.Baseline, a.ZScore, a.Since.Local().Format(time.RFC3339))
+		}
+		fmt.Fprintln(out)
+	}
+
 	fmt.Fprintln(out, "LLM Providers")
 	fmt.Fprintln(out, "   Not reported by server status API")
 	fmt.Fprintln(out)
@@ -0,0 +1,108 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/haasonsaas/nexus/internal/artifacts"
+	"github.com/haasonsaas/nexus/internal/config"
+	"github.com/haasonsaas/nexus/internal/sessions"
+	"github.com/haasonsaas/nexus/internal/storage/encryption"
+	"github.com/spf13/cobra"
+)
+
+// =============================================================================
+// Encryption Command Handlers
+// =============================================================================
+
+// runEncryptionKeygen handles the encryption keygen command.
+func runEncryptionKeygen(cmd *cobra.Command, id string) error {
+	id = strings.TrimSpace(id)
+	if id == "" {
+		id = time.Now().UTC().Format("2006-01")
+	}
+	key, err := encryption.GenerateKey()
+	if err != nil {
+		return err
+	}
+	out := cmd.OutOrStdout()
+	fmt.Fprintf(out, "Key: %s\n\n", key)
+	fmt.Fprintln(out, "Store the key in a secret manager or environment variable, then add:")
+	fmt.Fprintln(out)
+	fmt.Fprintln(out, "encryption:")
+	fmt.Fprintln(out, "  enabled: true")
+	fmt.Fprintf(out, "  primary_key: %q\n", id)
+	fmt.Fprintln(out, "  keys:")
+	fmt.Fprintf(out, "    - id: %q\n", id)
+	fmt.Fprintln(out, "      key: ${NEXUS_ENCRYPTION_KEY}")
+	return nil
+}
+
+// runEncryptionMigrate handles the encryption migrate command.
+func runEncryptionMigrate(cmd *cobra.Command, configPath string, dryRun bool) error {
+	cfg, err := config.Load(resolveConfigPath(configPath))
+	if err != nil {
+		return fmt.Errorf("load config: %w", err)
+	}
+	if !cfg.Encryption.Enabled {
+		return fmt.Errorf("encryption is not enabled (set encryption.enabled)")
+	}
+
+	ctx := cmd.Context()
+	out := cmd.OutOrStdout()
+	verb := "Encrypted"
+	if dryRun {
+		verb = "Would encrypt"
+	}
+	fmt.Fprintf(out, "%s with key %q:\n", verb, cfg.Encryption.PrimaryKey)
+
+	var errs []error
+	store, closeStore, err := openSessionStore(cfg)
+	if err != nil {
+		errs = append(errs, fmt.Errorf("open session store: %w", err))
+	} else {
+		defer closeStore()
+		if encrypted, ok := store.(sessions.EncryptedStore); ok {
+			count, err := encrypted.ReencryptMessages(ctx, dryRun)
+			fmt.Fprintf(out, "  Messages:  %d\n", count)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("messages: %w", err))
+			}
+		}
+	}
+
+	mgr, err := openMemoryManager(cfg)
+	if err != nil {
+		errs = append(errs, fmt.Errorf("create memory manager: %w", err))
+	} else if mgr != nil {
+		defer mgr.Close()
+		count, err := mgr.Reencrypt(ctx, dryRun)
+		fmt.Fprintf(out, "  Memories:  %d\n", count)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("memories: %w", err))
+		}
+	}
+
+	repo, closeRepo, err := createArtifactRepository(cfg)
+	if err != nil {
+		errs = append(errs, fmt.Errorf("create artifact repository: %w", err))
+	} else if repo != nil {
+		if closeRepo != nil {
+			defer closeRepo()
+		}
+		if encrypted, ok := repo.(artifacts.EncryptedRepository); ok {
+			count, err := encrypted.ReencryptMetadata(ctx, dryRun)
+			fmt.Fprintf(out, "  Artifacts: %d\n", count)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("artifacts: %w", err))
+			}
+		}
+	}
+
+	if err := errors.Join(errs...); err != nil {
+		return fmt.Errorf("migration incomplete: %w", err)
+	}
+	return nil
+}
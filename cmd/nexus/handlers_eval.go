@@ -0,0 +1,225 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/haasonsaas/nexus/internal/agent"
+	"github.com/haasonsaas/nexus/internal/config"
+	"github.com/haasonsaas/nexus/internal/eval"
+	"github.com/spf13/cobra"
+)
+
+// =============================================================================
+// Eval Command Handlers
+// =============================================================================
+
+// evalOutput is the JSON payload for eval runs.
+type evalOutput struct {
+	Reports    []*eval.Report   `json:"reports"`
+	Comparison *eval.Comparison `json:"comparison,omitempty"`
+}
+
+// runEval handles the eval run command.
+func runEval(cmd *cobra.Command, suitePath string, opts evalOptions) error {
+	format := strings.ToLower(strings.TrimSpace(opts.format))
+	switch format {
+	case "text", "json", "junit":
+	default:
+		return fmt.Errorf("unsupported format %q (use text, json, or junit)", opts.format)
+	}
+
+	suite, err := eval.LoadSuite(suitePath)
+	if err != nil {
+		return err
+	}
+	cfg, err := config.Load(resolveConfigPath(opts.configPath))
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	judge, err := buildEvalJudge(cfg, suite, opts)
+	if err != nil {
+		return err
+	}
+
+	report, err := runEvalSuite(cmd, cfg, suite, opts.provider, opts.model, judge, opts)
+	if err != nil {
+		return err
+	}
+	result := evalOutput{Reports: []*eval.Report{report}}
+	if opts.compareProvider != "" || opts.compareModel != "" {
+		providerID := opts.compareProvider
+		if providerID == "" {
+			providerID = opts.provider
+		}
+		candidate, err := runEvalSuite(cmd, cfg, suite, providerID, opts.compareModel, judge, opts)
+		if err != nil {
+			return err
+		}
+		result.Reports = append(result.Reports, candidate)
+		result.Comparison = eval.Compare(report, candidate)
+	}
+
+	out := cmd.OutOrStdout()
+	if opts.output != "" {
+		f, err := os.Create(opts.output)
+		if err != nil {
+			return fmt.Errorf("create output file: %w", err)
+		}
+		defer f.Close()
+		out = f
+	}
+
+	switch format {
+	case "json":
+		enc := json.NewEncoder(out)
+		enc.SetIndent("", "  ")
+		err = enc.Encode(result)
+	case "junit":
+		err = eval.WriteJUnit(out, result.Reports...)
+	default:
+		printEvalText(out, result)
+	}
+	if err != nil {
+		return fmt.Errorf("write output: %w", err)
+	}
+	if opts.output != "" {
+		fmt.Fprintf(cmd.OutOrStdout(), "Report written to %s\n", opts.output)
+	}
+
+	if opts.failOnScenarios {
+		for _, r := range result.Reports {
+			if r.Summary.Failed > 0 || r.Summary.Errors > 0 {
+				return fmt.Errorf("%d scenario(s) failed and %d errored for %s", r.Summary.Failed, r.Summary.Errors, r.Provider)
+			}
+		}
+	}
+	return nil
+}
+
+func runEvalSuite(cmd *cobra.Command, cfg *config.Config, suite *eval.Suite, providerID, model string, judge eval.Judge, opts evalOptions) (*eval.Report, error) {
+	provider, defaultModel, err := buildLLMProvider(cfg, providerID)
+	if err != nil {
+		return nil, err
+	}
+	if strings.TrimSpace(model) == "" {
+		model = defaultModel
+	}
+	evalOpts := &eval.Options{
+		Model:     model,
+		MaxTokens: opts.maxTokens,
+		MaxTurns:  opts.maxTurns,
+		Filter:    opts.filter,
+	}
+	ctx := cmd.Context()
+	if opts.batch {
+		queue, err := agent.NewBatchQueue(provider, agent.BatchQueueConfig{
+			MaxBatchSize: cfg.LLM.Batch.MaxBatchSize,
+			// Every scenario queues its turn at once, so a short flush
+			// interval still yields one batch per turn.
+			FlushInterval: 5 * time.Second,
+			PollInterval:  cfg.LLM.Batch.PollInterval,
+			OnSubmit: func(id string, requests int) {
+				fmt.Fprintf(cmd.ErrOrStderr(), "Submitted batch %s (%d requests)\n", id, requests)
+			},
+		})
+		if err != nil {
+			return nil, err
+		}
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithCancel(ctx)
+		defer cancel()
+		go queue.Run(ctx)
+		provider = queue
+		evalOpts.Concurrency = len(suite.Scenarios)
+	}
+	runner := eval.NewRunner(provider, evalOpts)
+	if judge != nil {
+		runner.WithJudge(judge)
+	}
+	return runner.Run(ctx, suite)
+}
+
+// buildEvalJudge creates an LLM judge when requested by flags or required by the suite.
+func buildEvalJudge(cfg *config.Config, suite *eval.Suite, opts evalOptions) (eval.Judge, error) {
+	needed := opts.judgeProvider != "" || opts.judgeModel != ""
+	for _, sc := range suite.Scenarios {
+		if sc.Rubric.Judge != "" {
+			needed = true
+			break
+		}
+	}
+	if !needed {
+		return nil, nil
+	}
+	providerID := opts.judgeProvider
+	if providerID == "" {
+		providerID = opts.provider
+	}
+	provider, defaultModel, err := buildLLMProvider(cfg, providerID)
+	if err != nil {
+		return nil, fmt.Errorf("judge provider: %w", err)
+	}
+	model := opts.judgeModel
+	if strings.TrimSpace(model) == "" {
+		model = defaultModel
+	}
+	return eval.NewLLMJudge(provider, model), nil
+}
+
+func printEvalText(out io.Writer, result evalOutput) {
+	for _, r := range result.Reports {
+		label := r.Provider
+		if r.Model != "" {
+			label += "/" + r.Model
+		}
+		fmt.Fprintf(out, "Eval Suite: %s (%s)\n", r.SuiteName, label)
+		fmt.Fprintln(out, strings.Repeat("-", 40))
+		for _, sc := range r.Scenarios {
+			status := "PASS"
+			switch {
+			case sc.Error != "":
+				status = "ERROR"
+			case !sc.Passed:
+				status = "FAIL"
+			}
+			fmt.Fprintf(out, "  %-5s %-30s score=%.2f  %v\n", status, sc.ScenarioID, sc.Score, sc.Duration.Round(1e6))
+			if sc.Error != "" {
+				fmt.Fprintf(out, "        error: %s\n", sc.Error)
+			}
+			for _, missing := range sc.ToolMissing {
+				fmt.Fprintf(out, "        missing tool call: %s\n", missing)
+			}
+			for _, failure := range sc.RubricFailures {
+				fmt.Fprintf(out, "        rubric: %s\n", failure)
+			}
+		}
+		s := r.Summary
+		fmt.Fprintf(out, "Passed: %d/%d  Failed: %d  Errors: %d  Avg score: %.3f\n",
+			s.Passed, s.Scenarios, s.Failed, s.Errors, s.AvgScore)
+		fmt.Fprintf(out, "Tokens: %d in / %d out\n\n", s.InputTokens, s.OutputTokens)
+	}
+
+	if cmp := result.Comparison; cmp != nil {
+		fmt.Fprintf(out, "Comparison: %s vs %s\n", cmp.Baseline, cmp.Candidate)
+		fmt.Fprintln(out, strings.Repeat("-", 40))
+		for _, row := range cmp.Scenarios {
+			marker := "="
+			switch {
+			case row.CandidateScore > row.BaselineScore:
+				marker = "+"
+			case row.CandidateScore < row.BaselineScore:
+				marker = "-"
+			}
+			fmt.Fprintf(out, "  %s %-30s %.2f -> %.2f\n", marker, row.ScenarioID, row.BaselineScore, row.CandidateScore)
+		}
+		fmt.Fprintf(out, "Score delta: %+.3f  Pass rate delta: %+.3f  Improved: %d  Regressed: %d\n",
+			cmp.ScoreDelta, cmp.PassRateDelta, cmp.Improved, cmp.Regressed)
+	}
+}
@@ -8,9 +8,12 @@ import (
 	"io"
 	"os"
 	"sort"
+	"strings"
 	"time"
 
 	"github.com/haasonsaas/nexus/internal/agent"
+	"github.com/haasonsaas/nexus/internal/config"
+	"github.com/haasonsaas/nexus/internal/gateway"
 	"github.com/haasonsaas/nexus/internal/observability"
 	"github.com/haasonsaas/nexus/pkg/models"
 	"github.com/spf13/cobra"
@@ -38,8 +41,10 @@ func runEventsShow(cmd *cobra.Command, configPath string, runID string, format s
 		return nil
 	}
 
-	// Fallback to in-memory event store (previous default)
-	store := observability.NewMemoryEventStore(10000)
+	// Fall back to the database event store, or an empty in-memory store
+	// when events are not persisted.
+	store, closeStore := openCLIEventStore(configPath)
+	defer closeStore()
 
 	events, err := store.GetByRunID(runID)
 	if err != nil {
@@ -48,8 +53,8 @@ func runEventsShow(cmd *cobra.Command, configPath string, runID string, format s
 
 	if len(events) == 0 {
 		fmt.Fprintf(cmd.OutOrStdout(), "No events found for run: %s\n", runID)
-		fmt.Fprintln(cmd.OutOrStdout(), "\nNote: Events are currently stored in memory and are lost when the server restarts.")
-		fmt.Fprintln(cmd.OutOrStdout(), "To capture events, set NEXUS_TRACE_DIR and re-run.")
+		fmt.Fprintln(cmd.OutOrStdout(), "\nNote: Events are only persisted with observability.event_store enabled.")
+		fmt.Fprintln(cmd.OutOrStdout(), "To capture events, enable it or set NEXUS_TRACE_DIR and re-run.")
 		return nil
 	}
 
@@ -69,8 +74,8 @@ func runEventsShow(cmd *cobra.Command, configPath string, runID string, format s
 
 // runEventsList lists recent events.
 func runEventsList(cmd *cobra.Command, configPath string, limit int, eventType string, sessionID string) error {
-	// For now, we use a memory store - in production this would connect to persistent storage
-	store := observability.NewMemoryEventStore(10000)
+	store, closeStore := openCLIEventStore(configPath)
+	defer closeStore()
 
 	var events []*observability.Event
 	var err error
@@ -92,7 +97,7 @@ func runEventsList(cmd *cobra.Command, configPath string, limit int, eventType s
 
 	if len(events) == 0 {
 		fmt.Fprintln(cmd.OutOrStdout(), "No events found.")
-		fmt.Fprintln(cmd.OutOrStdout(), "\nNote: Events are currently stored in memory and are lost when the server restarts.")
+		fmt.Fprintln(cmd.OutOrStdout(), "\nNote: Events are only persisted with observability.event_store enabled.")
 		return nil
 	}
 
@@ -120,6 +125,135 @@ func runEventsList(cmd *cobra.Command, configPath string, limit int, eventType s
 	return nil
 }
 
+// eventsQueryOptions holds the flags of the events query command.
+type eventsQueryOptions struct {
+	SessionID  string
+	RunID      string
+	AgentID    string
+	Types      []string
+	Name       string
+	ErrorsOnly bool
+	Since      time.Duration
+	Limit      int
+	Format     string
+}
+
+// eventTypeAliases maps friendlier failure names to recorded event types.
+var eventTypeAliases = map[string]observability.EventType{
+	"tool.failed": observability.EventTypeToolError,
+	"run.failed":  observability.EventTypeRunError,
+	"llm.failed":  observability.EventTypeLLMError,
+}
+
+// runEventsQuery queries the database event store.
+func runEventsQuery(cmd *cobra.Command, configPath string, opts eventsQueryOptions) error {
+	if opts.Format != "text" && opts.Format != "json" {
+		return fmt.Errorf("unsupported format %q (use text or json)", opts.Format)
+	}
+	cfg, err := config.Load(resolveConfigPath(configPath))
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	db, err := openMigrationDB(cfg)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+	keyring, err := gateway.BuildKeyring(cfg)
+	if err != nil {
+		return fmt.Errorf("field encryption: %w", err)
+	}
+	store, err := observability.NewDBEventStore(db)
+	if err != nil {
+		return err
+	}
+	store.SetKeyring(keyring)
+
+	query := observability.EventQuery{
+		RunID:      opts.RunID,
+		SessionID:  opts.SessionID,
+		AgentID:    opts.AgentID,
+		Name:       opts.Name,
+		ErrorsOnly: opts.ErrorsOnly,
+		Limit:      opts.Limit,
+	}
+	for _, t := range opts.Types {
+		eventType := observability.EventType(strings.TrimSpace(t))
+		if alias, ok := eventTypeAliases[string(eventType)]; ok {
+			eventType = alias
+		}
+		query.Types = append(query.Types, eventType)
+	}
+	if opts.Since > 0 {
+		query.Since = time.Now().Add(-opts.Since)
+	}
+
+	events, err := store.Query(cmd.Context(), query)
+	if err != nil {
+		return fmt.Errorf("failed to query events (run `nexus migrate up`?): %w", err)
+	}
+
+	out := cmd.OutOrStdout()
+	if opts.Format == "json" {
+		if events == nil {
+			events = []*observability.Event{}
+		}
+		enc := json.NewEncoder(out)
+		enc.SetIndent("", "  ")
+		return enc.Encode(events)
+	}
+	if len(events) == 0 {
+		fmt.Fprintln(out, "No events found.")
+		if !cfg.Observability.EventStore.Enabled {
+			fmt.Fprintln(out, "\nNote: observability.event_store is not enabled, so no events are persisted.")
+		}
+		return nil
+	}
+	for _, e := range events {
+		fmt.Fprintf(out, "%s  %-16s %s", e.Timestamp.Local().Format("2006-01-02 15:04:05.000"), e.Type, e.Name)
+		if e.Duration > 0 {
+			fmt.Fprintf(out, " (%s)", e.Duration.Round(time.Millisecond))
+		}
+		fmt.Fprintln(out)
+		if e.SessionID != "" || e.RunID != "" {
+			fmt.Fprintf(out, "    session=%s run=%s\n", e.SessionID, e.RunID)
+		}
+		if e.Error != "" {
+			fmt.Fprintf(out, "    error: %s\n", e.Error)
+		}
+	}
+	return nil
+}
+
+// openCLIEventStore returns the database event store when the config
+// enables it, and otherwise an empty in-memory store. The returned func
+// releases the database.
+func openCLIEventStore(configPath string) (observability.EventStore, func()) {
+	memory := func() (observability.EventStore, func()) {
+		return observability.NewMemoryEventStore(10000), func() {}
+	}
+	cfg, err := config.Load(resolveConfigPath(configPath))
+	if err != nil || !cfg.Observability.EventStore.Enabled {
+		return memory()
+	}
+	db, err := openMigrationDB(cfg)
+	if err != nil {
+		return memory()
+	}
+	keyring, err := gateway.BuildKeyring(cfg)
+	if err != nil {
+		_ = db.Close()
+		return memory()
+	}
+	store, err := observability.NewDBEventStore(db)
+	if err != nil {
+		_ = db.Close()
+		return memory()
+	}
+	store.SetKeyring(keyring)
+	return store, func() { _ = db.Close() }
+}
+
 type traceTimeline struct {
 	Header *agent.TraceHeader  `json:"header"`
 	Stats  *models.RunStats    `json:"stats,omitempty"`
@@ -233,6 +367,10 @@ func formatAgentEventDetail(event models.AgentEvent) string {
 		if event.Stream != nil {
 			return fmt.Sprintf("tokens in=%d out=%d", event.Stream.InputTokens, event.Stream.OutputTokens)
 		}
+	case models.AgentEventModelReasoning:
+		if event.Stream != nil {
+			return fmt.Sprintf("reasoning_tokens=%d", event.Stream.ReasoningTokens)
+		}
 	case models.AgentEventContextPacked:
 		if event.Context != nil {
 			return fmt.Sprintf("used %d/%d chars, dropped %d", event.Context.UsedChars, event.Context.BudgetChars, event.Context.Dropped)
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/haasonsaas/nexus/internal/config"
+	"github.com/haasonsaas/nexus/internal/experiments"
+	"github.com/haasonsaas/nexus/internal/feedback"
+	"github.com/spf13/cobra"
+)
+
+// =============================================================================
+// Experiments Command Handlers
+// =============================================================================
+
+// runExperimentsReport handles the experiments report command.
+func runExperimentsReport(cmd *cobra.Command, configPath, logPath, feedbackPath, experimentID string, since time.Duration, jsonOutput bool) error {
+	if strings.TrimSpace(logPath) == "" || strings.TrimSpace(feedbackPath) == "" {
+		cfg, err := config.Load(resolveConfigPath(configPath))
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+		if strings.TrimSpace(logPath) == "" {
+			if cfg != nil {
+				logPath = cfg.Experiments.LogPath
+			}
+			logPath = experiments.NewLog(logPath).Path()
+		}
+		if strings.TrimSpace(feedbackPath) == "" {
+			if cfg != nil {
+				feedbackPath = strings.TrimSpace(cfg.Observability.Feedback.StorePath)
+			}
+			if feedbackPath == "" {
+				feedbackPath = feedback.DefaultStorePath()
+			}
+		}
+	}
+
+	records, err := experiments.NewLog(logPath).Load()
+	if err != nil {
+		return err
+	}
+	entries, err := feedback.NewStore(feedbackPath).Load()
+	if err != nil {
+		return err
+	}
+	ratings := make(map[string]experiments.Rating)
+	for _, run := range feedback.Aggregate(entries) {
+		ratings[run.RunID] = experiments.Rating{Positive: run.Positive, Negative: run.Negative}
+	}
+
+	opts := experiments.ReportOptions{ExperimentID: strings.TrimSpace(experimentID)}
+	if since > 0 {
+		opts.Since = time.Now().Add(-since)
+	}
+	report := experiments.BuildReport(records, ratings, opts)
+
+	if jsonOutput {
+		enc := json.NewEncoder(cmd.OutOrStdout())
+		enc.SetIndent("", "  ")
+		return enc.Encode(report)
+	}
+	printExperimentsReport(cmd.OutOrStdout(), report, logPath)
+	return nil
+}
+
+func printExperimentsReport(out io.Writer, report experiments.Report, logPath string) {
+	fmt.Fprintf(out, "Experiment log: %s\n", logPath)
+	if len(report.Experiments) == 0 {
+		fmt.Fprintln(out, "\nNo exposures recorded.")
+		return
+	}
+	for _, exp := range report.Experiments {
+		fmt.Fprintf(out, "\n%s\n", exp.ID)
+		fmt.Fprintf(out, "  %-14s %6s %8s %6s %6s %9s %9s %9s %6s %11s\n",
+			"VARIANT", "RUNS", "SUBJECTS", "DONE", "FAIL", "AVG MS", "AVG IN", "AVG OUT", "TOOLS", "SATISFIED")
+		for _, v := range exp.Variants {
+			satisfaction := "-"
+			if v.Positive+v.Negative > 0 {
+				satisfaction = fmt.Sprintf("%.0f%% (%d)", v.Satisfaction*100, v.Positive+v.Negative)
+			}
+			fmt.Fprintf(out, "  %-14s %6d %8d %6d %5.0f%% %9.0f %9.0f %9.0f %6.1f %11s\n",
+				v.ID, v.Exposures, v.Subjects, v.Completed, v.FailureRate*100,
+				v.AvgDurationMs, v.AvgInputTokens, v.AvgOutputTokens, v.AvgToolCalls, satisfaction)
+		}
+	}
+}
@@ -0,0 +1,109 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/haasonsaas/nexus/internal/agent"
+	"github.com/haasonsaas/nexus/internal/config"
+	"github.com/haasonsaas/nexus/internal/feedback"
+	"github.com/spf13/cobra"
+)
+
+// =============================================================================
+// Feedback Command Handlers
+// =============================================================================
+
+// runFeedbackReport handles the feedback report command.
+func runFeedbackReport(cmd *cobra.Command, configPath, storePath, traceDir string, since time.Duration, limit int, jsonOutput bool) error {
+	if strings.TrimSpace(storePath) == "" {
+		path, err := feedbackStorePath(configPath)
+		if err != nil {
+			return err
+		}
+		storePath = path
+	}
+	if traceDir == "" {
+		traceDir = os.Getenv("NEXUS_TRACE_DIR")
+	}
+
+	entries, err := feedback.NewStore(storePath).Load()
+	if err != nil {
+		return err
+	}
+	opts := feedback.ReportOptions{Limit: limit}
+	if since > 0 {
+		opts.Since = time.Now().Add(-since)
+	}
+	report := feedback.BuildReport(entries, opts)
+
+	if jsonOutput {
+		enc := json.NewEncoder(cmd.OutOrStdout())
+		enc.SetIndent("", "  ")
+		return enc.Encode(report)
+	}
+	printFeedbackReport(cmd.OutOrStdout(), report, storePath, traceDir)
+	return nil
+}
+
+// feedbackStorePath resolves the store path from config, falling back to the
+// default when no config file exists.
+func feedbackStorePath(configPath string) (string, error) {
+	cfg, err := config.Load(resolveConfigPath(configPath))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return feedback.DefaultStorePath(), nil
+		}
+		return "", err
+	}
+	if path := strings.TrimSpace(cfg.Observability.Feedback.StorePath); path != "" {
+		return path, nil
+	}
+	return feedback.DefaultStorePath(), nil
+}
+
+func printFeedbackReport(out io.Writer, report *feedback.Report, storePath, traceDir string) {
+	fmt.Fprintf(out, "Feedback: %s\n", storePath)
+	if !report.Since.IsZero() {
+		fmt.Fprintf(out, "Since:    %s\n", report.Since.Format(time.RFC3339))
+	}
+	if report.Runs == 0 {
+		fmt.Fprintln(out, "\nNo feedback recorded.")
+		return
+	}
+	fmt.Fprintf(out, "\nRuns rated: %d  (%d positive, %d negative)  satisfaction %.0f%%\n",
+		report.Runs, report.Positive, report.Negative, report.Satisfaction*100)
+	for _, ch := range report.Channels {
+		fmt.Fprintf(out, "  %-10s %4d runs  %4d +  %4d -  %.0f%%\n",
+			ch.Channel, ch.Runs, ch.Positive, ch.Negative, ch.Satisfaction*100)
+	}
+
+	if len(report.LowRated) == 0 {
+		fmt.Fprintln(out, "\nNo low-rated runs.")
+		return
+	}
+	fmt.Fprintf(out, "\nLow-rated runs (%d):\n", len(report.LowRated))
+	for _, run := range report.LowRated {
+		fmt.Fprintf(out, "  %s  [%s] %+d (%d+/%d-) %s  %s\n",
+			run.LastAt.Local().Format("2006-01-02 15:04"), run.Channel, run.Score(),
+			run.Positive, run.Negative, strings.Join(run.Reactions, " "), run.RunID)
+		fmt.Fprintf(out, "    trace: %s\n", feedbackTraceLink(traceDir, run.RunID))
+	}
+}
+
+// feedbackTraceLink points at the run's trace file when it exists, otherwise
+// at the events command for the run.
+func feedbackTraceLink(traceDir, runID string) string {
+	if traceDir != "" {
+		path := agent.TraceFilePath(traceDir, runID)
+		if _, err := os.Stat(path); err == nil {
+			return path
+		}
+	}
+	return "nexus events show " + runID
+}
@@ -1,16 +1,28 @@
 package main
 
 import (
+	"bufio"
+	"context"
+	"encoding/json"
 	"fmt"
+	"io"
 	"log/slog"
 	"os"
 	"path/filepath"
+	"strings"
+	"text/tabwriter"
 	"time"
 
+	"github.com/haasonsaas/nexus/internal/agent"
 	"github.com/haasonsaas/nexus/internal/config"
+	"github.com/haasonsaas/nexus/internal/gateway"
 	"github.com/haasonsaas/nexus/internal/memory"
+	"github.com/haasonsaas/nexus/internal/memory/consolidate"
+	"github.com/haasonsaas/nexus/internal/memory/review"
+	"github.com/haasonsaas/nexus/internal/workspace"
 	"github.com/haasonsaas/nexus/pkg/models"
 	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
 )
 
 // =============================================================================
@@ -25,7 +37,7 @@ func runMemorySearch(cmd *cobra.Command, configPath, query, scope, scopeID strin
 		return fmt.Errorf("failed to load config: %w", err)
 	}
 
-	mgr, err := memory.NewManager(&cfg.VectorMemory)
+	mgr, err := openMemoryManager(cfg)
 	if err != nil {
 		return fmt.Errorf("failed to create memory manager: %w", err)
 	}
@@ -70,7 +82,7 @@ func runMemoryIndex(cmd *cobra.Command, configPath, path, scope, scopeID, source
 		return fmt.Errorf("failed to load config: %w", err)
 	}
 
-	mgr, err := memory.NewManager(&cfg.VectorMemory)
+	mgr, err := openMemoryManager(cfg)
 	if err != nil {
 		return fmt.Errorf("failed to create memory manager: %w", err)
 	}
@@ -127,7 +139,7 @@ func runMemoryStats(cmd *cobra.Command, configPath string) error {
 		return fmt.Errorf("failed to load config: %w", err)
 	}
 
-	mgr, err := memory.NewManager(&cfg.VectorMemory)
+	mgr, err := openMemoryManager(cfg)
 	if err != nil {
 		return fmt.Errorf("failed to create memory manager: %w", err)
 	}
@@ -157,7 +169,7 @@ func runMemoryCompact(cmd *cobra.Command, configPath string) error {
 		return fmt.Errorf("failed to load config: %w", err)
 	}
 
-	mgr, err := memory.NewManager(&cfg.VectorMemory)
+	mgr, err := openMemoryManager(cfg)
 	if err != nil {
 		return fmt.Errorf("failed to create memory manager: %w", err)
 	}
@@ -170,3 +182,477 @@ func runMemoryCompact(cmd *cobra.Command, configPath string) error {
 	fmt.Fprintln(cmd.OutOrStdout(), "Memory compacted successfully.")
 	return nil
 }
+
+// runMemoryDelete handles the memory delete command.
+func runMemoryDelete(cmd *cobra.Command, configPath string, ids []string, scope, scopeID, source string, olderThan time.Duration, reason string, dryRun, force bool) error {
+	filter := memory.Filter{
+		IDs:     ids,
+		Scope:   models.MemoryScope(strings.ToLower(strings.TrimSpace(scope))),
+		ScopeID: strings.TrimSpace(scopeID),
+		Source:  strings.TrimSpace(source),
+	}
+	switch filter.Scope {
+	case "", models.ScopeSession, models.ScopeChannel, models.ScopeAgent, models.ScopeGlobal:
+	default:
+		return fmt.Errorf("unsupported scope %q (want session, channel, agent, or global)", scope)
+	}
+	if filter.ScopeID != "" && (filter.Scope == "" || filter.Scope == models.ScopeGlobal) {
+		return fmt.Errorf("--scope-id needs --scope session, channel, or agent")
+	}
+	if olderThan < 0 {
+		return fmt.Errorf("--older-than must be positive")
+	}
+	if olderThan > 0 {
+		filter.Before = time.Now().Add(-olderThan)
+	}
+	if filter.IsEmpty() {
+		return fmt.Errorf("select memories to delete by ID, --scope, --source, or --older-than")
+	}
+
+	configPath = resolveConfigPath(configPath)
+	cfg, err := config.Load(configPath)
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	if cfg.VectorMemory.Pgvector.UseCockroachDB && cfg.VectorMemory.Pgvector.DSN == "" {
+		cfg.VectorMemory.Pgvector.DSN = cfg.Database.URL
+	}
+	mgr, err := openMemoryManager(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to create memory manager: %w", err)
+	}
+	if mgr == nil {
+		return fmt.Errorf("vector memory is disabled (vector_memory.enabled)")
+	}
+	defer mgr.Close()
+
+	entries, err := mgr.Find(cmd.Context(), filter, 0)
+	if err != nil {
+		return fmt.Errorf("failed to find memories: %w", err)
+	}
+	out := cmd.OutOrStdout()
+	if len(entries) == 0 {
+		fmt.Fprintln(out, "No matching memories.")
+		return nil
+	}
+
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tSCOPE\tSOURCE\tCREATED\tCONTENT")
+	for _, entry := range entries {
+		entryScope, entryScopeID := memory.EntryScope(entry)
+		if entryScopeID != "" {
+			entryScope += models.MemoryScope(":" + entryScopeID)
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", entry.ID, entryScope, entry.Metadata.Source,
+			entry.CreatedAt.Format("2006-01-02 15:04"), truncate(strings.Join(strings.Fields(entry.Content), " "), 60))
+	}
+	w.Flush()
+
+	if dryRun {
+		fmt.Fprintf(out, "%d memories would be deleted.\n", len(entries))
+		return nil
+	}
+	if !force {
+		reader := bufio.NewReader(os.Stdin)
+		fmt.Printf("Delete %d memories? This cannot be undone. [y/N]: ", len(entries))
+		response, err := reader.ReadString('\n')
+		response = strings.TrimSpace(strings.ToLower(response))
+		if err != nil || (response != "y" && response != "yes") {
+			fmt.Println("Cancelled")
+			return nil
+		}
+	}
+
+	record, err := mgr.Forget(cmd.Context(), entries, memory.DeletionRecord{By: "cli", Reason: reason})
+	if record == nil {
+		return fmt.Errorf("failed to delete memories: %w", err)
+	}
+	fmt.Fprintf(out, "Deleted %d memories.\n", len(record.Entries))
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(out, "Deletion record %s written to %s\n", record.ID, memory.DeletionLogPath(&cfg.VectorMemory))
+	return nil
+}
+
+// runMemoryMigrate handles the memory migrate command.
+func runMemoryMigrate(cmd *cobra.Command, configPath, from, to string, batchSize int) error {
+	configPath = resolveConfigPath(configPath)
+	cfg, err := config.Load(configPath)
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	if from == "" {
+		from = cfg.VectorMemory.Backend
+	}
+	if from == "" {
+		from = "sqlite-vec"
+	}
+	if from == to {
+		return fmt.Errorf("source and target backend are both %q", from)
+	}
+	if cfg.VectorMemory.Pgvector.UseCockroachDB && cfg.VectorMemory.Pgvector.DSN == "" {
+		cfg.VectorMemory.Pgvector.DSN = cfg.Database.URL
+	}
+
+	if err := gateway.LoadPluginStorage(cfg); err != nil {
+		return err
+	}
+	source, err := memory.NewBackend(&cfg.VectorMemory, from)
+	if err != nil {
+		return fmt.Errorf("open %s: %w", from, err)
+	}
+	defer source.Close()
+	target, err := memory.NewBackend(&cfg.VectorMemory, to)
+	if err != nil {
+		return fmt.Errorf("open %s: %w", to, err)
+	}
+	defer target.Close()
+
+	out := cmd.OutOrStdout()
+	start := time.Now()
+	copied, err := memory.Migrate(cmd.Context(), source, target, batchSize, func(n int) {
+		fmt.Fprintf(out, "Copied %d entries...\n", n)
+	})
+	if err != nil {
+		return fmt.Errorf("migration stopped after %d entries: %w", copied, err)
+	}
+	fmt.Fprintf(out, "Migrated %d entries from %s to %s in %s.\n", copied, from, to, time.Since(start).Round(time.Millisecond))
+	if copied > 0 && cfg.VectorMemory.Backend != to {
+		fmt.Fprintf(out, "Set vector_memory.backend to %q to use the new backend.\n", to)
+	}
+	return nil
+}
+
+// runMemoryReembed handles the memory reembed command.
+func runMemoryReembed(cmd *cobra.Command, configPath, provider, model string, batchSize, rate int, updateConfig bool) error {
+	configPath = resolveConfigPath(configPath)
+	cfg, err := config.Load(configPath)
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	if cfg.VectorMemory.Pgvector.UseCockroachDB && cfg.VectorMemory.Pgvector.DSN == "" {
+		cfg.VectorMemory.Pgvector.DSN = cfg.Database.URL
+	}
+
+	embCfg := cfg.VectorMemory.Embeddings
+	if provider != "" {
+		embCfg.Provider = provider
+	}
+	embCfg.Model = model
+	emb, err := memory.NewEmbedder(embCfg)
+	if err != nil {
+		return err
+	}
+
+	mgr, err := openMemoryManager(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to create memory manager: %w", err)
+	}
+	if mgr == nil {
+		return fmt.Errorf("vector memory is disabled (vector_memory.enabled)")
+	}
+	defer mgr.Close()
+
+	out := cmd.OutOrStdout()
+	fmt.Fprintf(out, "Re-embedding with %s/%s (%d dimensions)...\n", emb.Name(), model, emb.Dimension())
+	report, err := mgr.Reembed(cmd.Context(), emb, memory.ReembedOptions{
+		BatchSize:         batchSize,
+		RequestsPerMinute: rate,
+		Progress: func(done, total int64) {
+			fmt.Fprintf(out, "  %d/%d entries\n", done, total)
+		},
+	})
+	if err != nil {
+		if report != nil && report.Staged {
+			return fmt.Errorf("re-embedding stopped after %d entries; the live index is unchanged: %w", report.Entries, err)
+		}
+		return fmt.Errorf("re-embedding stopped: %w", err)
+	}
+
+	mode := "in place"
+	if report.Staged {
+		mode = "in a new index, now live"
+	}
+	fmt.Fprintf(out, "Re-embedded %d entries %s in %s (%d requests).\n", report.Entries, mode, report.Duration.Round(time.Millisecond), report.Requests)
+	if report.Skipped > 0 {
+		fmt.Fprintf(out, "Skipped %d entries without content.\n", report.Skipped)
+	}
+
+	type setting struct {
+		path  []string
+		value any
+	}
+	settings := []setting{
+		{[]string{"vector_memory", "embeddings", "model"}, model},
+		{[]string{"vector_memory", "dimension"}, report.Dimension},
+	}
+	if provider != "" {
+		settings = append(settings, setting{[]string{"vector_memory", "embeddings", "provider"}, provider})
+	}
+	manual := func() {
+		for _, s := range settings {
+			fmt.Fprintf(out, "  %s: %v\n", strings.Join(s.path, "."), s.value)
+		}
+	}
+	if !updateConfig {
+		fmt.Fprintln(out, "Update the config before restarting the gateway:")
+		manual()
+		return nil
+	}
+	if err := updateConfigValues(configPath, func(node *yaml.Node) error {
+		for _, s := range settings {
+			if err := setYAMLValue(node, s.path, s.value); err != nil {
+				return fmt.Errorf("set %s: %w", strings.Join(s.path, "."), err)
+			}
+		}
+		return nil
+	}); err != nil {
+		fmt.Fprintf(out, "Could not update %s (%v); set these before restarting the gateway:\n", configPath, err)
+		manual()
+		return nil
+	}
+	fmt.Fprintf(out, "Updated %s; restart the gateway to use the new model.\n", configPath)
+	return nil
+}
+
+// updateConfigValues edits the YAML config file in place, keeping its
+// layout and comments.
+func updateConfigValues(configPath string, edit func(node *yaml.Node) error) error {
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		return fmt.Errorf("read config: %w", err)
+	}
+	var node yaml.Node
+	if err := yaml.Unmarshal(data, &node); err != nil {
+		return fmt.Errorf("parse config: %w", err)
+	}
+	if err := edit(&node); err != nil {
+		return err
+	}
+	output, err := yaml.Marshal(&node)
+	if err != nil {
+		return fmt.Errorf("marshal config: %w", err)
+	}
+	return writeFilePreserveMode(configPath, output)
+}
+
+// loadMemoryReview returns the config and memory review queue it selects.
+func loadMemoryReview(configPath string) (*config.Config, *review.Queue, error) {
+	cfg, err := config.Load(resolveConfigPath(configPath))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load config: %w", err)
+	}
+	queue, err := review.NewQueue(cfg)
+	if err != nil {
+		return nil, nil, err
+	}
+	if queue == nil {
+		return nil, nil, fmt.Errorf("memory review is disabled (set session.memory.review.enabled)")
+	}
+	return cfg, queue, nil
+}
+
+// runMemoryReviewList handles the memory review command.
+func runMemoryReviewList(cmd *cobra.Command, configPath string, all, jsonOutput bool) error {
+	_, queue, err := loadMemoryReview(configPath)
+	if err != nil {
+		return err
+	}
+	status := review.StatusPending
+	if all {
+		status = ""
+	}
+	proposals, err := queue.List(status)
+	if err != nil {
+		return err
+	}
+
+	out := cmd.OutOrStdout()
+	if jsonOutput {
+		data, err := json.MarshalIndent(proposals, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(out, string(data))
+		return nil
+	}
+	if len(proposals) == 0 {
+		fmt.Fprintln(out, "No memories waiting for review.")
+		return nil
+	}
+	return printMemoryProposals(out, proposals, time.Now())
+}
+
+// runMemoryReviewDecide handles the memory review approve and reject
+// commands.
+func runMemoryReviewDecide(cmd *cobra.Command, configPath, id string, approve bool) error {
+	cfg, queue, err := loadMemoryReview(configPath)
+	if err != nil {
+		return err
+	}
+	const decidedBy = "cli"
+	out := cmd.OutOrStdout()
+	if !approve {
+		proposal, err := queue.Reject(cmd.Context(), id, decidedBy)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(out, "Rejected %s\n", proposal.ID)
+		return nil
+	}
+
+	proposal, err := queue.Get(id)
+	if err != nil {
+		return err
+	}
+	var indexer review.Indexer
+	if proposal.Kind == review.KindVector {
+		mgr, err := openMemoryManager(cfg)
+		if err != nil {
+			return fmt.Errorf("failed to create memory manager: %w", err)
+		}
+		if mgr != nil {
+			defer mgr.Close()
+			indexer = mgr
+		}
+	}
+	if _, err := queue.Approve(cmd.Context(), proposal.ID, decidedBy, indexer); err != nil {
+		return err
+	}
+	fmt.Fprintf(out, "Approved %s: %s\n", proposal.ID, proposal.Summary(200))
+	return nil
+}
+
+// printMemoryProposals writes proposals as a table.
+func printMemoryProposals(out io.Writer, proposals []*review.Proposal, now time.Time) error {
+	w := tabwriter.NewWriter(out, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tSTATUS\tTARGET\tAGE\tFROM\tMEMORY")
+	for _, p := range proposals {
+		target := p.Scope + " memory"
+		if p.Kind == review.KindFile {
+			target = filepath.Base(p.Path)
+		}
+		from := "-"
+		if p.Channel != "" {
+			from = fmt.Sprintf("%s:%s", p.Channel, p.ChannelID)
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", p.ID, p.Status, target, now.Sub(p.CreatedAt).Round(time.Second), from, p.Summary(80))
+	}
+	return w.Flush()
+}
+
+// runMemoryConsolidate handles the memory consolidate command.
+func runMemoryConsolidate(cmd *cobra.Command, configPath string, dryRun, jsonOutput bool) error {
+	cfg, err := config.Load(resolveConfigPath(configPath))
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	consolidator := consolidate.New(cfg).WithHistory(workspace.NewHistory(cfg))
+	out := cmd.OutOrStdout()
+	now := time.Now()
+
+	if dryRun {
+		due, err := consolidator.Due(now)
+		if err != nil {
+			return err
+		}
+		if jsonOutput {
+			data, err := json.MarshalIndent(due, "", "  ")
+			if err != nil {
+				return err
+			}
+			fmt.Fprintln(out, string(data))
+			return nil
+		}
+		if len(due) == 0 {
+			fmt.Fprintln(out, "No daily memory files are due for consolidation.")
+			return nil
+		}
+		w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "DATE\tSIZE\tPATH")
+		for _, file := range due {
+			fmt.Fprintf(w, "%s\t%d\t%s\n", file.Date.Format("2006-01-02"), file.Size, file.Path)
+		}
+		return w.Flush()
+	}
+
+	provider, defaultModel, err := buildLLMProvider(cfg, "")
+	if err != nil {
+		return err
+	}
+	model := strings.TrimSpace(cfg.Session.Memory.Consolidation.Model)
+	if model == "" {
+		model = defaultModel
+	}
+	summarize := func(ctx context.Context, system, prompt string) (string, error) {
+		return completeText(ctx, provider, &agent.CompletionRequest{
+			Model:     model,
+			System:    system,
+			Messages:  []agent.CompletionMessage{{Role: "user", Content: prompt}},
+			MaxTokens: cfg.Session.Memory.Consolidation.MaxOutputTokens,
+		})
+	}
+	var indexer consolidate.Indexer
+	mgr, err := openMemoryManager(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to create memory manager: %w", err)
+	}
+	if mgr != nil {
+		defer mgr.Close()
+		indexer = mgr
+	}
+
+	report, runErr := consolidator.Run(cmd.Context(), now, summarize, indexer)
+	if report == nil {
+		if runErr != nil {
+			return runErr
+		}
+		fmt.Fprintln(out, "No daily memory files are due for consolidation.")
+		return nil
+	}
+	if jsonOutput {
+		data, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(out, string(data))
+		return runErr
+	}
+	fmt.Fprintf(out, "Consolidated %d daily file(s): %s\n", len(report.Dates), strings.Join(report.Dates, ", "))
+	if len(report.Topics) > 0 {
+		fmt.Fprintf(out, "Added %d line(s) to %s under: %s\n", report.Lines, report.MemoryFile, strings.Join(report.Topics, ", "))
+	} else {
+		fmt.Fprintln(out, "Nothing worth keeping; the memory file is unchanged.")
+	}
+	if report.Indexed > 0 {
+		fmt.Fprintf(out, "Indexed %d topic(s) into vector memory.\n", report.Indexed)
+	}
+	fmt.Fprintf(out, "Archived to %s\n", report.ArchiveDir)
+	if report.Remaining > 0 {
+		fmt.Fprintf(out, "%d file(s) did not fit in max_input_chars; run again to continue.\n", report.Remaining)
+	}
+	return runErr
+}
+
+// completeText returns the full text of a completion.
+func completeText(ctx context.Context, provider agent.LLMProvider, req *agent.CompletionRequest) (string, error) {
+	ch, err := provider.Complete(ctx, req)
+	if err != nil {
+		return "", err
+	}
+	var sb strings.Builder
+	for chunk := range ch {
+		if chunk == nil {
+			continue
+		}
+		if chunk.Error != nil {
+			return "", chunk.Error
+		}
+		sb.WriteString(chunk.Text)
+		if chunk.Done {
+			break
+		}
+	}
+	return sb.String(), nil
+}
@@ -36,7 +36,7 @@ func runMigrateUp(cmd *cobra.Command, configPath string, steps int) error {
 	}
 	defer db.Close()
 
-	migrator, err := sessions.NewMigrator(db)
+	migrator, err := sessions.NewMigratorForDialect(db, sessions.DialectForURL(cfg.Database.URL))
 	if err != nil {
 		return fmt.Errorf("failed to initialize migrator: %w", err)
 	}
@@ -74,7 +74,7 @@ func runMigrateDown(cmd *cobra.Command, configPath string, steps int) error {
 	}
 	defer db.Close()
 
-	migrator, err := sessions.NewMigrator(db)
+	migrator, err := sessions.NewMigratorForDialect(db, sessions.DialectForURL(cfg.Database.URL))
 	if err != nil {
 		return fmt.Errorf("failed to initialize migrator: %w", err)
 	}
@@ -105,7 +105,7 @@ func runMigrateStatus(cmd *cobra.Command, configPath string) error {
 	}
 	defer db.Close()
 
-	migrator, err := sessions.NewMigrator(db)
+	migrator, err := sessions.NewMigratorForDialect(db, sessions.DialectForURL(cfg.Database.URL))
 	if err != nil {
 		return fmt.Errorf("failed to initialize migrator: %w", err)
 	}
@@ -237,11 +237,11 @@ func runMigrateSessionsImport(cmd *cobra.Command, configPath, inputFile string,
 	}
 
 	// Create session store
-	store, err := sessions.NewCockroachStoreFromDSN(cfg.Database.URL, nil)
+	store, closeStore, err := openSessionStore(cfg)
 	if err != nil {
 		return fmt.Errorf("create store: %w", err)
 	}
-	defer store.Close()
+	defer closeStore()
 
 	importer := sessions.NewImporter(store)
 
@@ -283,11 +283,11 @@ func runMigrateSessionsExport(cmd *cobra.Command, configPath, agentID, outputFil
 	}
 
 	// Create session store
-	store, err := sessions.NewCockroachStoreFromDSN(cfg.Database.URL, nil)
+	store, closeStore, err := openSessionStore(cfg)
 	if err != nil {
 		return fmt.Errorf("create store: %w", err)
 	}
-	defer store.Close()
+	defer closeStore()
 
 	var w io.Writer = cmd.OutOrStdout()
 	if outputFile != "" {
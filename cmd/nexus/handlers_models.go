@@ -0,0 +1,124 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/haasonsaas/nexus/internal/config"
+	"github.com/haasonsaas/nexus/internal/models"
+	"github.com/spf13/cobra"
+)
+
+// =============================================================================
+// Models Command Handlers
+// =============================================================================
+
+// runModelsList handles the models list command.
+func runModelsList(cmd *cobra.Command, opts modelsListOptions) error {
+	catalog := models.NewCatalog()
+	if opts.discover {
+		cfg, err := config.Load(resolveConfigPath(opts.configPath))
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w", err)
+		}
+		discoverConfiguredModels(cmd.ErrOrStderr(), cfg, catalog)
+	}
+
+	filter := &models.Filter{IncludeDeprecated: opts.all}
+	if provider := strings.ToLower(strings.TrimSpace(opts.provider)); provider != "" {
+		filter.Providers = []models.Provider{models.Provider(provider)}
+	}
+	for _, capability := range opts.capabilities {
+		if capability = strings.ToLower(strings.TrimSpace(capability)); capability != "" {
+			filter.RequiredCapabilities = append(filter.RequiredCapabilities, models.Capability(capability))
+		}
+	}
+	list := catalog.List(filter)
+
+	out := cmd.OutOrStdout()
+	if opts.jsonOutput {
+		enc := json.NewEncoder(out)
+		enc.SetIndent("", "  ")
+		return enc.Encode(list)
+	}
+	if len(list) == 0 {
+		fmt.Fprintln(out, "No models match.")
+		return nil
+	}
+	printModelsTable(out, list)
+	return nil
+}
+
+// discoverConfiguredModels adds the models each configured provider reports
+// to catalog. Providers that cannot be built are reported and skipped.
+func discoverConfiguredModels(errOut io.Writer, cfg *config.Config, catalog *models.Catalog) {
+	ids := make([]string, 0, len(cfg.LLM.Providers))
+	for id := range cfg.LLM.Providers {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		provider, _, err := buildLLMProvider(cfg, id)
+		if err != nil {
+			fmt.Fprintf(errOut, "skipping %s: %v\n", id, err)
+			continue
+		}
+		var discovered []*models.Model
+		for _, m := range provider.Models() {
+			discovered = append(discovered, models.NewDiscoveredModel(models.Provider(strings.ToLower(id)), m.ID, m.Name, m.ContextSize, m.SupportsVision, provider.SupportsTools()))
+		}
+		catalog.AddDiscovered(discovered...)
+	}
+}
+
+func printModelsTable(out io.Writer, list []*models.Model) {
+	w := tabwriter.NewWriter(out, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "PROVIDER\tMODEL\tCONTEXT\tMAX OUTPUT\tTOOLS\tVISION\tINPUT $/M\tOUTPUT $/M")
+	for _, m := range list {
+		id := m.ID
+		if m.Deprecated {
+			id += " (deprecated)"
+		} else if m.Discovered {
+			id += " (discovered)"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			m.Provider, id,
+			formatModelTokens(m.ContextWindow), formatModelTokens(m.MaxOutputTokens),
+			modelFlag(m.SupportsTools()), modelFlag(m.SupportsVision()),
+			formatModelPrice(m, m.InputPrice), formatModelPrice(m, m.OutputPrice))
+	}
+	_ = w.Flush()
+}
+
+func formatModelTokens(tokens int) string {
+	switch {
+	case tokens <= 0:
+		return "-"
+	case tokens >= 1000000:
+		return fmt.Sprintf("%.1fM", float64(tokens)/1000000)
+	case tokens >= 1000:
+		return fmt.Sprintf("%dK", tokens/1000)
+	default:
+		return fmt.Sprintf("%d", tokens)
+	}
+}
+
+// formatModelPrice formats a per-million-token price, or "-" for models
+// without pricing.
+func formatModelPrice(m *models.Model, price float64) string {
+	if m.InputPrice == 0 && m.OutputPrice == 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%.2f", price)
+}
+
+func modelFlag(ok bool) string {
+	if ok {
+		return "yes"
+	}
+	return "-"
+}
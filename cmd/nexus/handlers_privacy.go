@@ -0,0 +1,276 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"crypto/ed25519"
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+
+	"github.com/haasonsaas/nexus/internal/agent"
+	"github.com/haasonsaas/nexus/internal/config"
+	"github.com/haasonsaas/nexus/internal/gateway"
+	"github.com/haasonsaas/nexus/internal/observability"
+	"github.com/haasonsaas/nexus/internal/privacy"
+	"github.com/haasonsaas/nexus/pkg/management"
+	"github.com/spf13/cobra"
+)
+
+// =============================================================================
+// Privacy Command Handlers
+// =============================================================================
+
+// runPrivacyErase handles the privacy erase command.
+func runPrivacyErase(cmd *cobra.Command, configPath, peer, requestedBy string, force bool) error {
+	if _, _, err := privacy.ParsePeer(peer); err != nil {
+		return err
+	}
+	cfg, err := config.Load(resolveConfigPath(configPath))
+	if err != nil {
+		return fmt.Errorf("load config: %w", err)
+	}
+
+	identities := privacy.Identities(strings.TrimSpace(peer), cfg.Session.Scoping.IdentityLinks)
+	if !force && !confirmErase(strings.Join(identities, ", ")) {
+		fmt.Println("Cancelled")
+		return nil
+	}
+
+	stores, cleanup, err := openPrivacyStores(cfg)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	key, err := privacy.LoadSigningKey(cfg.Privacy.Erasure.SigningKeyPath)
+	if err != nil {
+		return err
+	}
+	eraser := privacy.NewEraser(cfg.Session.Scoping.IdentityLinks, stores, key, cfg.Privacy.Erasure.AuditDir)
+	record, path, eraseErr := eraser.Erase(cmd.Context(), peer, requestedBy)
+
+	out := cmd.OutOrStdout()
+	if record != nil {
+		fmt.Fprintf(out, "Erased %s\n", strings.Join(record.Identities, ", "))
+		fmt.Fprintf(out, "  Sessions:    %d\n", record.Removed.Sessions)
+		fmt.Fprintf(out, "  Messages:    %d\n", record.Removed.Messages)
+		fmt.Fprintf(out, "  Artifacts:   %d\n", record.Removed.Artifacts)
+		fmt.Fprintf(out, "  Memories:    %d\n", record.Removed.Memories)
+		fmt.Fprintf(out, "  Events:      %d\n", record.Removed.Events)
+		fmt.Fprintf(out, "  Checkpoints: %d\n", record.Removed.Checkpoints)
+	}
+	if path != "" {
+		fmt.Fprintf(out, "Signed erasure record: %s\n", path)
+	}
+	if eraseErr != nil {
+		return fmt.Errorf("erasure incomplete: %w", eraseErr)
+	}
+	return nil
+}
+
+// runPrivacyEraseRemote asks the gateway at serverAddr to erase peer through
+// the management API.
+func runPrivacyEraseRemote(cmd *cobra.Command, serverAddr, apiKey, peer, requestedBy string, force bool) error {
+	if _, _, err := privacy.ParsePeer(peer); err != nil {
+		return err
+	}
+	baseURL, err := resolveHTTPBaseURL("", serverAddr)
+	if err != nil {
+		return err
+	}
+	if apiKey == "" {
+		apiKey = strings.TrimSpace(os.Getenv(apiKeyEnv))
+	}
+	if !force && !confirmErase(strings.TrimSpace(peer)+" and its linked identities on "+baseURL) {
+		fmt.Println("Cancelled")
+		return nil
+	}
+
+	client := management.NewClient(baseURL, management.WithAPIKey(apiKey))
+	resp, err := client.EraseIdentity(cmd.Context(), &management.EraseIdentityRequest{Peer: peer, RequestedBy: requestedBy})
+	if management.CodeOf(err) == management.CodePermissionDenied {
+		return fmt.Errorf("erase identity: %w (identity erasure needs an admin API key)", err)
+	}
+	if err != nil {
+		return fmt.Errorf("erase identity: %w", err)
+	}
+
+	out := cmd.OutOrStdout()
+	fmt.Fprintf(out, "Erased %s\n", strings.Join(resp.Identities, ", "))
+	fmt.Fprintf(out, "  Sessions:    %d\n", resp.Removed.Sessions)
+	fmt.Fprintf(out, "  Messages:    %d\n", resp.Removed.Messages)
+	fmt.Fprintf(out, "  Artifacts:   %d\n", resp.Removed.Artifacts)
+	fmt.Fprintf(out, "  Memories:    %d\n", resp.Removed.Memories)
+	fmt.Fprintf(out, "  Events:      %d\n", resp.Removed.Events)
+	fmt.Fprintf(out, "  Checkpoints: %d\n", resp.Removed.Checkpoints)
+	if resp.RecordPath != "" {
+		fmt.Fprintf(out, "Signed erasure record (on the server): %s\n", resp.RecordPath)
+	}
+	if len(resp.Errors) > 0 {
+		return fmt.Errorf("erasure incomplete: %s", strings.Join(resp.Errors, "; "))
+	}
+	return nil
+}
+
+// confirmErase asks before erasing the data of who.
+func confirmErase(who string) bool {
+	reader := bufio.NewReader(os.Stdin)
+	fmt.Printf("Erase all data for %s? This cannot be undone. [y/N]: ", who)
+	response, err := reader.ReadString('\n')
+	if err != nil {
+		return false
+	}
+	response = strings.TrimSpace(strings.ToLower(response))
+	return response == "y" || response == "yes"
+}
+
+// runPrivacyVerify handles the privacy verify command.
+func runPrivacyVerify(cmd *cobra.Command, configPath, recordPath string) error {
+	cfg, err := config.Load(resolveConfigPath(configPath))
+	if err != nil {
+		return fmt.Errorf("load config: %w", err)
+	}
+	record, err := privacy.ReadRecord(recordPath)
+	if err != nil {
+		return err
+	}
+	if _, err := os.Stat(cfg.Privacy.Erasure.SigningKeyPath); err != nil {
+		return fmt.Errorf("signing key %s: %w", cfg.Privacy.Erasure.SigningKeyPath, err)
+	}
+	key, err := privacy.LoadSigningKey(cfg.Privacy.Erasure.SigningKeyPath)
+	if err != nil {
+		return err
+	}
+	if err := record.Verify(key.Public().(ed25519.PublicKey)); err != nil {
+		return err
+	}
+	fmt.Fprintf(cmd.OutOrStdout(), "Valid erasure record %s for %s (completed %s)\n",
+		record.ID, record.Peer, record.CompletedAt.Format("2006-01-02 15:04:05 MST"))
+	return nil
+}
+
+// runPrivacyEnforce handles the privacy enforce command.
+func runPrivacyEnforce(cmd *cobra.Command, configPath string) error {
+	cfg, err := config.Load(resolveConfigPath(configPath))
+	if err != nil {
+		return fmt.Errorf("load config: %w", err)
+	}
+	stores, cleanup, err := openPrivacyStores(cfg)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	enforcer := privacy.NewEnforcer(cfg.Privacy.Retention, cfg.Session.Scoping.IdentityLinks, stores, slog.Default())
+	report, runErr := enforcer.Run(cmd.Context())
+
+	out := cmd.OutOrStdout()
+	fmt.Fprintln(out, "Retention sweep removed:")
+	fmt.Fprintf(out, "  Sessions:    %d\n", report.Sessions)
+	fmt.Fprintf(out, "  Messages:    %d\n", report.Messages)
+	fmt.Fprintf(out, "  Artifacts:   %d\n", report.Artifacts)
+	fmt.Fprintf(out, "  Memories:    %d\n", report.Memories)
+	fmt.Fprintf(out, "  Events:      %d\n", report.Events)
+	fmt.Fprintf(out, "  Checkpoints: %d\n", report.Checkpoints)
+	if runErr != nil {
+		return fmt.Errorf("retention sweep incomplete: %w", runErr)
+	}
+	return nil
+}
+
+// openPrivacyStores opens the session store, artifact repository, persisted
+// event store, run checkpoints, and vector memory selected by the config, and
+// returns a function that closes them.
+func openPrivacyStores(cfg *config.Config) (privacy.Stores, func(), error) {
+	store, closeStore, err := openSessionStore(cfg)
+	if err != nil {
+		return privacy.Stores{}, nil, fmt.Errorf("open session store: %w", err)
+	}
+	sessionStore, ok := store.(privacy.SessionStore)
+	if !ok {
+		closeStore()
+		return privacy.Stores{}, nil, fmt.Errorf("session store does not support retention")
+	}
+	stores := privacy.Stores{Sessions: sessionStore}
+	closers := []func(){closeStore}
+	cleanup := func() {
+		for i := len(closers) - 1; i >= 0; i-- {
+			closers[i]()
+		}
+	}
+
+	repo, closeRepo, err := createArtifactRepository(cfg)
+	if err != nil {
+		cleanup()
+		return privacy.Stores{}, nil, fmt.Errorf("create artifact repository: %w", err)
+	}
+	if closeRepo != nil {
+		closers = append(closers, closeRepo)
+	}
+	stores.Artifacts = repo
+
+	events, closeEvents, err := openPrivacyEventStore(cfg)
+	if err != nil {
+		cleanup()
+		return privacy.Stores{}, nil, err
+	}
+	if events != nil {
+		closers = append(closers, closeEvents)
+		stores.Events = events
+	}
+
+	// Checkpoints of earlier runs remain after checkpointing is turned off.
+	if dir := gateway.CheckpointDir(cfg.Session.Checkpoints); isDir(dir) {
+		checkpoints, err := agent.NewFileCheckpointStore(dir, agent.CheckpointRetention{})
+		if err != nil {
+			cleanup()
+			return privacy.Stores{}, nil, fmt.Errorf("open checkpoint store: %w", err)
+		}
+		stores.Checkpoints = checkpoints
+	}
+
+	if cfg.VectorMemory.Enabled && cfg.VectorMemory.Pgvector.UseCockroachDB && cfg.VectorMemory.Pgvector.DSN == "" {
+		cfg.VectorMemory.Pgvector.DSN = cfg.Database.URL
+	}
+	mgr, err := openMemoryManager(cfg)
+	if err != nil {
+		cleanup()
+		return privacy.Stores{}, nil, fmt.Errorf("create memory manager: %w", err)
+	}
+	if mgr != nil {
+		closers = append(closers, func() { _ = mgr.Close() })
+		stores.Memory = mgr
+	}
+	return stores, cleanup, nil
+}
+
+func isDir(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && info.IsDir()
+}
+
+// openPrivacyEventStore opens the persisted agent event store. It returns nil
+// when the agent_events table has not been migrated, since there is then
+// nothing to delete.
+func openPrivacyEventStore(cfg *config.Config) (*observability.DBEventStore, func(), error) {
+	keyring, err := gateway.BuildKeyring(cfg)
+	if err != nil {
+		return nil, nil, fmt.Errorf("field encryption: %w", err)
+	}
+	db, err := openMigrationDB(cfg)
+	if err != nil {
+		return nil, nil, fmt.Errorf("open event store: %w", err)
+	}
+	store, err := observability.NewDBEventStore(db)
+	if err == nil {
+		_, err = store.Query(context.Background(), observability.EventQuery{Limit: 1})
+	}
+	if err != nil {
+		_ = db.Close()
+		return nil, nil, nil
+	}
+	store.SetKeyring(keyring)
+	return store, func() { _ = db.Close() }, nil
+}
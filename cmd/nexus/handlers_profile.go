@@ -2,8 +2,10 @@ package main
 
 import (
 	"fmt"
+	"os"
 	"strings"
 
+	"github.com/haasonsaas/nexus/internal/config"
 	"github.com/haasonsaas/nexus/internal/onboard"
 	"github.com/haasonsaas/nexus/internal/profile"
 	"github.com/spf13/cobra"
@@ -79,3 +81,46 @@ func runProfileInit(cmd *cobra.Command, name, provider string, setActive bool) e
 	fmt.Fprintf(cmd.OutOrStdout(), "Profile config written: %s\n", path)
 	return nil
 }
+
+// runProfileDiff handles the profile diff command.
+func runProfileDiff(cmd *cobra.Command, a, b, env string) error {
+	pathA := resolveProfileArg(a)
+	pathB := resolveProfileArg(b)
+	rawA, err := config.LoadRawForEnv(pathA, env)
+	if err != nil {
+		return fmt.Errorf("load %s: %w", a, err)
+	}
+	rawB, err := config.LoadRawForEnv(pathB, env)
+	if err != nil {
+		return fmt.Errorf("load %s: %w", b, err)
+	}
+
+	out := cmd.OutOrStdout()
+	changes := profile.Diff(rawA, rawB)
+	if len(changes) == 0 {
+		fmt.Fprintf(out, "No differences between %s and %s.\n", a, b)
+		return nil
+	}
+	fmt.Fprintf(out, "--- %s (%s)\n+++ %s (%s)\n", a, pathA, b, pathB)
+	for _, change := range changes {
+		switch change.Kind {
+		case profile.ChangeAdded:
+			fmt.Fprintf(out, "+ %s: %s\n", change.Path, profile.FormatValue(change.Path, change.To))
+		case profile.ChangeRemoved:
+			fmt.Fprintf(out, "- %s: %s\n", change.Path, profile.FormatValue(change.Path, change.From))
+		default:
+			fmt.Fprintf(out, "~ %s: %s -> %s\n", change.Path,
+				profile.FormatValue(change.Path, change.From), profile.FormatValue(change.Path, change.To))
+		}
+	}
+	return nil
+}
+
+// resolveProfileArg treats an argument as a config path when it names an
+// existing file, otherwise as a profile name.
+func resolveProfileArg(arg string) string {
+	if info, err := os.Stat(arg); err == nil && !info.IsDir() {
+		return arg
+	}
+	return profile.ProfileConfigPath(arg)
+}
@@ -0,0 +1,185 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/haasonsaas/nexus/pkg/management"
+	"github.com/spf13/cobra"
+)
+
+// =============================================================================
+// Runs Command Handlers
+// =============================================================================
+
+// runsOptions holds the flags shared by the runs commands.
+type runsOptions struct {
+	configPath string
+	serverAddr string
+	token      string
+	apiKey     string
+}
+
+// client returns a management API client for the gateway.
+func (o runsOptions) client() (*management.Client, error) {
+	baseURL, err := resolveHTTPBaseURL(resolveConfigPath(o.configPath), o.serverAddr)
+	if err != nil {
+		return nil, err
+	}
+	apiKey := o.apiKey
+	if apiKey == "" && o.token == "" {
+		apiKey = strings.TrimSpace(os.Getenv(apiKeyEnv))
+	}
+	var opts []management.ClientOption
+	if apiKey != "" {
+		opts = append(opts, management.WithAPIKey(apiKey))
+	}
+	if o.token != "" {
+		opts = append(opts, management.WithBearerToken(o.token))
+	}
+	return management.NewClient(baseURL, opts...), nil
+}
+
+// runsError adds a hint to management API errors the operator can fix.
+func runsError(action string, err error) error {
+	switch management.CodeOf(err) {
+	case management.CodePermissionDenied:
+		return fmt.Errorf("%s: %w (runs need an admin API key)", action, err)
+	case management.CodeFailedPrecondition:
+		if strings.Contains(err.Error(), "disabled") {
+			return fmt.Errorf("%s: %w (enable session.checkpoints)", action, err)
+		}
+	}
+	return fmt.Errorf("%s: %w", action, err)
+}
+
+// runRunsList handles the runs list command.
+func runRunsList(cmd *cobra.Command, opts runsOptions, status string, jsonOutput bool) error {
+	client, err := opts.client()
+	if err != nil {
+		return err
+	}
+	resp, err := client.ListRuns(cmd.Context(), &management.ListRunsRequest{Status: status})
+	if err != nil {
+		return runsError("list runs", err)
+	}
+
+	out := cmd.OutOrStdout()
+	if jsonOutput {
+		return printRunsJSON(out, resp.Runs)
+	}
+	if len(resp.Runs) == 0 {
+		fmt.Fprintln(out, "No checkpointed runs")
+		return nil
+	}
+	return printRuns(out, resp.Runs, time.Now())
+}
+
+// runRunsShow handles the runs show command.
+func runRunsShow(cmd *cobra.Command, opts runsOptions, id string, jsonOutput bool) error {
+	client, err := opts.client()
+	if err != nil {
+		return err
+	}
+	resp, err := client.GetRun(cmd.Context(), &management.GetRunRequest{ID: id})
+	if err != nil {
+		return runsError("get run "+id, err)
+	}
+
+	out := cmd.OutOrStdout()
+	if jsonOutput {
+		return printRunsJSON(out, resp.Run)
+	}
+	run := resp.Run
+	fmt.Fprintf(out, "Run:         %s\n", run.ID)
+	fmt.Fprintf(out, "Session:     %s\n", run.SessionID)
+	if run.AgentID != "" {
+		fmt.Fprintf(out, "Agent:       %s\n", run.AgentID)
+	}
+	if run.Channel != "" {
+		fmt.Fprintf(out, "Channel:     %s\n", run.Channel)
+	}
+	fmt.Fprintf(out, "Status:      %s\n", run.Status)
+	if run.Error != "" {
+		fmt.Fprintf(out, "Error:       %s\n", run.Error)
+	}
+	if run.Model != "" {
+		fmt.Fprintf(out, "Model:       %s\n", run.Model)
+	}
+	fmt.Fprintf(out, "Iterations:  %d\n", run.Iteration)
+	fmt.Fprintf(out, "Tool calls:  %d\n", run.ToolCalls)
+	fmt.Fprintf(out, "Messages:    %d\n", run.Messages)
+	if len(run.PendingTools) > 0 {
+		fmt.Fprintf(out, "Pending:     %s\n", strings.Join(run.PendingTools, ", "))
+	}
+	fmt.Fprintf(out, "Started:     %s\n", run.CreatedAt.Format(time.RFC3339))
+	fmt.Fprintf(out, "Checkpoint:  %s\n", run.UpdatedAt.Format(time.RFC3339))
+	if run.Request != "" {
+		fmt.Fprintf(out, "Request:     %s\n", run.Request)
+	}
+	return nil
+}
+
+// runRunsResume handles the runs resume command.
+func runRunsResume(cmd *cobra.Command, opts runsOptions, id string) error {
+	client, err := opts.client()
+	if err != nil {
+		return err
+	}
+	resp, err := client.ResumeRun(cmd.Context(), &management.ResumeRunRequest{ID: id})
+	if err != nil {
+		return runsError("resume run "+id, err)
+	}
+	fmt.Fprintf(cmd.OutOrStdout(), "Resuming run %s from iteration %d (%d messages)\n", resp.Run.ID, resp.Run.Iteration, resp.Run.Messages)
+	return nil
+}
+
+// runRunsKill handles the runs kill command.
+func runRunsKill(cmd *cobra.Command, opts runsOptions, id string, all bool) error {
+	client, err := opts.client()
+	if err != nil {
+		return err
+	}
+	resp, err := client.KillRun(cmd.Context(), &management.KillRunRequest{ID: id, All: all})
+	if err != nil {
+		if all {
+			return runsError("kill runs", err)
+		}
+		return runsError("kill run "+id, err)
+	}
+	out := cmd.OutOrStdout()
+	if len(resp.SessionIDs) == 0 {
+		fmt.Fprintln(out, "No active runs")
+		return nil
+	}
+	for _, sessionID := range resp.SessionIDs {
+		fmt.Fprintf(out, "Stopped run in session %s\n", sessionID)
+	}
+	return nil
+}
+
+func printRunsJSON(out io.Writer, value any) error {
+	data, err := json.MarshalIndent(value, "", "  ")
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(out, string(data))
+	return nil
+}
+
+// printRuns writes runs as a table.
+func printRuns(out io.Writer, runs []*management.Run, now time.Time) error {
+	w := tabwriter.NewWriter(out, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tSTATUS\tSESSION\tITERATIONS\tTOOL CALLS\tCHECKPOINTED")
+	for _, run := range runs {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%d\t%d\t%s ago\n",
+			run.ID, run.Status, run.SessionID, run.Iteration, run.ToolCalls,
+			now.Sub(run.UpdatedAt).Round(time.Second))
+	}
+	return w.Flush()
+}
@@ -0,0 +1,86 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/haasonsaas/nexus/pkg/management"
+	"github.com/spf13/cobra"
+)
+
+func TestRunsResume(t *testing.T) {
+	var gotPath, gotKey string
+	var gotReq management.ResumeRunRequest
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath, gotKey = r.URL.Path, r.Header.Get("X-API-Key")
+		json.NewDecoder(r.Body).Decode(&gotReq) //nolint:errcheck
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(management.ResumeRunResponse{Run: &management.Run{ //nolint:errcheck
+			ID: "session-1-msg-1", Status: "interrupted", Iteration: 12, Messages: 25, UpdatedAt: time.Now(),
+		}})
+	}))
+	defer srv.Close()
+
+	var out bytes.Buffer
+	cmd := &cobra.Command{}
+	cmd.SetOut(&out)
+	cmd.SetContext(context.Background())
+	opts := runsOptions{serverAddr: srv.URL, apiKey: "admin-key"}
+	if err := runRunsResume(cmd, opts, "session-1-msg-1"); err != nil {
+		t.Fatalf("resume: %v", err)
+	}
+	if gotPath != management.ResumeRunProcedure || gotKey != "admin-key" || gotReq.ID != "session-1-msg-1" {
+		t.Fatalf("unexpected request %q %q %+v", gotPath, gotKey, gotReq)
+	}
+	if want := "Resuming run session-1-msg-1 from iteration 12 (25 messages)"; !strings.Contains(out.String(), want) {
+		t.Fatalf("expected %q in output:\n%s", want, out.String())
+	}
+}
+
+func TestRunsKill(t *testing.T) {
+	var gotPath string
+	var gotReq management.KillRunRequest
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		json.NewDecoder(r.Body).Decode(&gotReq) //nolint:errcheck
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(management.KillRunResponse{SessionIDs: []string{"session-1"}}) //nolint:errcheck
+	}))
+	defer srv.Close()
+
+	var out bytes.Buffer
+	cmd := &cobra.Command{}
+	cmd.SetOut(&out)
+	cmd.SetContext(context.Background())
+	opts := runsOptions{serverAddr: srv.URL, apiKey: "admin-key"}
+	if err := runRunsKill(cmd, opts, "session-1-msg-1", false); err != nil {
+		t.Fatalf("kill: %v", err)
+	}
+	if gotPath != management.KillRunProcedure || gotReq.ID != "session-1-msg-1" || gotReq.All {
+		t.Fatalf("unexpected request %q %+v", gotPath, gotReq)
+	}
+	if want := "Stopped run in session session-1"; !strings.Contains(out.String(), want) {
+		t.Fatalf("expected %q in output:\n%s", want, out.String())
+	}
+}
+
+func TestPrintRuns(t *testing.T) {
+	now := time.Now()
+	var out bytes.Buffer
+	if err := printRuns(&out, []*management.Run{
+		{ID: "session-1-msg-1", Status: "failed", SessionID: "session-1", Iteration: 3, ToolCalls: 7, UpdatedAt: now.Add(-5 * time.Minute)},
+	}, now); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"session-1-msg-1", "failed", "5m0s ago"} {
+		if !strings.Contains(out.String(), want) {
+			t.Fatalf("expected %q in output:\n%s", want, out.String())
+		}
+	}
+}
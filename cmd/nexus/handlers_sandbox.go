@@ -0,0 +1,257 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/haasonsaas/nexus/internal/tools/sandbox/rootfs"
+	"github.com/haasonsaas/nexus/pkg/management"
+	"github.com/spf13/cobra"
+)
+
+// =============================================================================
+// Sandbox Command Handlers
+// =============================================================================
+
+// sandboxSnapshotOptions holds the flags shared by the sandbox snapshots
+// commands.
+type sandboxSnapshotOptions struct {
+	configPath string
+	serverAddr string
+	token      string
+	apiKey     string
+}
+
+// client returns a management API client for the gateway.
+func (o sandboxSnapshotOptions) client() (*management.Client, error) {
+	baseURL, err := resolveHTTPBaseURL(resolveConfigPath(o.configPath), o.serverAddr)
+	if err != nil {
+		return nil, err
+	}
+	apiKey := o.apiKey
+	if apiKey == "" && o.token == "" {
+		apiKey = strings.TrimSpace(os.Getenv(apiKeyEnv))
+	}
+	var opts []management.ClientOption
+	if apiKey != "" {
+		opts = append(opts, management.WithAPIKey(apiKey))
+	}
+	if o.token != "" {
+		opts = append(opts, management.WithBearerToken(o.token))
+	}
+	return management.NewClient(baseURL, opts...), nil
+}
+
+// sandboxSnapshotError adds a hint to management API errors the operator
+// can fix.
+func sandboxSnapshotError(action string, err error) error {
+	switch management.CodeOf(err) {
+	case management.CodePermissionDenied:
+		return fmt.Errorf("%s: %w (sandbox snapshots need an admin API key)", action, err)
+	case management.CodeFailedPrecondition:
+		return fmt.Errorf("%s: %w (set tools.sandbox.backend to firecracker and enable tools.sandbox.snapshots)", action, err)
+	}
+	return fmt.Errorf("%s: %w", action, err)
+}
+
+// runSandboxSnapshotsList handles the sandbox snapshots list command.
+func runSandboxSnapshotsList(cmd *cobra.Command, opts sandboxSnapshotOptions, language string, jsonOutput bool) error {
+	client, err := opts.client()
+	if err != nil {
+		return err
+	}
+	resp, err := client.ListSandboxSnapshots(cmd.Context(), &management.ListSandboxSnapshotsRequest{Language: language})
+	if err != nil {
+		return sandboxSnapshotError("list snapshots", err)
+	}
+
+	out := cmd.OutOrStdout()
+	if jsonOutput {
+		data, err := json.MarshalIndent(resp.Snapshots, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(out, string(data))
+		return nil
+	}
+	if len(resp.Snapshots) == 0 {
+		fmt.Fprintln(out, "No snapshots")
+		return nil
+	}
+	return printSandboxSnapshots(out, resp.Snapshots, time.Now())
+}
+
+// runSandboxSnapshotsCreate handles the sandbox snapshots create command.
+func runSandboxSnapshotsCreate(cmd *cobra.Command, opts sandboxSnapshotOptions, language string) error {
+	client, err := opts.client()
+	if err != nil {
+		return err
+	}
+	resp, err := client.CreateSandboxSnapshot(cmd.Context(), &management.CreateSandboxSnapshotRequest{Language: language})
+	if err != nil {
+		return sandboxSnapshotError("create snapshot", err)
+	}
+	fmt.Fprintf(cmd.OutOrStdout(), "Created snapshot %s (%s)\n", resp.Snapshot.ID, formatSnapshotSize(resp.Snapshot.SizeBytes))
+	return nil
+}
+
+// runSandboxSnapshotsRefresh handles the sandbox snapshots refresh command.
+func runSandboxSnapshotsRefresh(cmd *cobra.Command, opts sandboxSnapshotOptions, language string) error {
+	client, err := opts.client()
+	if err != nil {
+		return err
+	}
+	resp, err := client.RefreshSandboxSnapshots(cmd.Context(), &management.RefreshSandboxSnapshotsRequest{Language: language})
+	if err != nil {
+		return sandboxSnapshotError("refresh snapshots", err)
+	}
+	out := cmd.OutOrStdout()
+	for _, snapshot := range resp.Snapshots {
+		fmt.Fprintf(out, "Refreshed %s: %s (%s)\n", snapshot.Language, snapshot.ID, formatSnapshotSize(snapshot.SizeBytes))
+	}
+	return nil
+}
+
+// runSandboxSnapshotsDelete handles the sandbox snapshots delete command.
+func runSandboxSnapshotsDelete(cmd *cobra.Command, opts sandboxSnapshotOptions, ids []string) error {
+	client, err := opts.client()
+	if err != nil {
+		return err
+	}
+	out := cmd.OutOrStdout()
+	for _, id := range ids {
+		if _, err := client.DeleteSandboxSnapshot(cmd.Context(), &management.DeleteSandboxSnapshotRequest{ID: id}); err != nil {
+			return sandboxSnapshotError("delete snapshot "+id, err)
+		}
+		fmt.Fprintf(out, "Deleted snapshot %s\n", id)
+	}
+	return nil
+}
+
+// sandboxBuildRootfsOptions holds the flags of the sandbox build-rootfs
+// command.
+type sandboxBuildRootfsOptions struct {
+	languages    []string
+	output       string
+	version      string
+	arch         string
+	images       []string
+	sizeMB       int
+	guestAgent   string
+	kernelURL    string
+	kernelSHA256 string
+}
+
+// builderOptions converts the flags to rootfs builder options.
+func (o sandboxBuildRootfsOptions) builderOptions() (rootfs.Options, error) {
+	opts := rootfs.Options{
+		Languages:    o.languages,
+		OutputDir:    o.output,
+		Version:      o.version,
+		Arch:         o.arch,
+		SizeMB:       o.sizeMB,
+		GuestAgent:   o.guestAgent,
+		KernelURL:    o.kernelURL,
+		KernelSHA256: o.kernelSHA256,
+	}
+	if o.sizeMB < 0 {
+		return opts, fmt.Errorf("--size-mb must be >= 0")
+	}
+	if o.kernelSHA256 != "" && o.kernelURL == "" {
+		return opts, fmt.Errorf("--kernel-sha256 requires --kernel-url")
+	}
+	for _, image := range o.images {
+		lang, ref, ok := strings.Cut(image, "=")
+		if !ok || strings.TrimSpace(lang) == "" || strings.TrimSpace(ref) == "" {
+			return opts, fmt.Errorf("invalid --image %q (expected language=image)", image)
+		}
+		langs, err := rootfs.NormalizeLanguages([]string{lang})
+		if err != nil {
+			return opts, err
+		}
+		if opts.Images == nil {
+			opts.Images = make(map[string]string)
+		}
+		opts.Images[langs[0]] = strings.TrimSpace(ref)
+	}
+	return opts, nil
+}
+
+// runSandboxBuildRootfs handles the sandbox build-rootfs command.
+func runSandboxBuildRootfs(cmd *cobra.Command, opts sandboxBuildRootfsOptions, jsonOutput bool) error {
+	builderOpts, err := opts.builderOptions()
+	if err != nil {
+		return err
+	}
+	out := cmd.OutOrStdout()
+	builderOpts.Progress = out
+	if jsonOutput {
+		builderOpts.Progress = cmd.ErrOrStderr()
+	}
+
+	manifest, err := rootfs.NewBuilder(builderOpts).Build(cmd.Context())
+	if err != nil {
+		return fmt.Errorf("build rootfs: %w", err)
+	}
+
+	if jsonOutput {
+		data, err := json.MarshalIndent(manifest, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(out, string(data))
+		return nil
+	}
+	fmt.Fprintf(out, "\nBuilt version %s (%s)\n", manifest.Version, manifest.Arch)
+	if err := printRootfsArtifacts(out, manifest); err != nil {
+		return err
+	}
+	fmt.Fprintf(out, "\nManifest:  %s\nChecksums: %s\n", manifest.ManifestPath, manifest.ChecksumsPath)
+	return nil
+}
+
+// printRootfsArtifacts writes the artifacts of a rootfs build as a table.
+func printRootfsArtifacts(out io.Writer, manifest *rootfs.Manifest) error {
+	w := tabwriter.NewWriter(out, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "ARTIFACT\tPATH\tSIZE\tSHA256")
+	if manifest.Kernel != nil {
+		fmt.Fprintf(w, "kernel\t%s\t%s\t%s\n", manifest.Kernel.Path, formatSnapshotSize(manifest.Kernel.Size), manifest.Kernel.SHA256)
+	}
+	for _, artifact := range manifest.RootFS {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", artifact.Language, artifact.Path, formatSnapshotSize(artifact.Size), artifact.SHA256)
+	}
+	return w.Flush()
+}
+
+// printSandboxSnapshots writes snapshots as a table.
+func printSandboxSnapshots(out io.Writer, snapshots []*management.SandboxSnapshot, now time.Time) error {
+	w := tabwriter.NewWriter(out, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tLANGUAGE\tTYPE\tAGE\tSIZE\tCREATED")
+	for _, snapshot := range snapshots {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n",
+			snapshot.ID, snapshot.Language, snapshot.Type,
+			now.Sub(snapshot.CreatedAt).Round(time.Second),
+			formatSnapshotSize(snapshot.SizeBytes),
+			snapshot.CreatedAt.Format(time.RFC3339))
+	}
+	return w.Flush()
+}
+
+// formatSnapshotSize formats a byte count with a binary unit.
+func formatSnapshotSize(size int64) string {
+	const unit = 1024
+	if size < unit {
+		return fmt.Sprintf("%d B", size)
+	}
+	div, exp := int64(unit), 0
+	for n := size / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(size)/float64(div), "KMGTPE"[exp])
+}
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/haasonsaas/nexus/pkg/management"
+	"github.com/spf13/cobra"
+)
+
+func TestSandboxSnapshotsList(t *testing.T) {
+	var gotPath, gotKey string
+	var gotReq management.ListSandboxSnapshotsRequest
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath, gotKey = r.URL.Path, r.Header.Get("X-API-Key")
+		json.NewDecoder(r.Body).Decode(&gotReq) //nolint:errcheck
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(management.ListSandboxSnapshotsResponse{Snapshots: []*management.SandboxSnapshot{ //nolint:errcheck
+			{ID: "vm-python-1", Language: "python", Type: "full", CreatedAt: time.Now().Add(-90 * time.Minute), SizeBytes: 512 << 20},
+		}})
+	}))
+	defer srv.Close()
+
+	var out bytes.Buffer
+	cmd := &cobra.Command{}
+	cmd.SetOut(&out)
+	cmd.SetContext(context.Background())
+	opts := sandboxSnapshotOptions{serverAddr: srv.URL, apiKey: "admin-key"}
+	if err := runSandboxSnapshotsList(cmd, opts, "python", false); err != nil {
+		t.Fatalf("list: %v", err)
+	}
+	if gotPath != management.ListSandboxSnapshotsProcedure || gotKey != "admin-key" || gotReq.Language != "python" {
+		t.Fatalf("unexpected request %q %q %+v", gotPath, gotKey, gotReq)
+	}
+	for _, want := range []string{"vm-python-1", "1h30m0s", "512.0 MiB"} {
+		if !strings.Contains(out.String(), want) {
+			t.Fatalf("expected %q in output:\n%s", want, out.String())
+		}
+	}
+}
+
+func TestFormatSnapshotSize(t *testing.T) {
+	for size, want := range map[int64]string{0: "0 B", 1023: "1023 B", 1536: "1.5 KiB", 3 << 30: "3.0 GiB"} {
+		if got := formatSnapshotSize(size); got != want {
+			t.Errorf("formatSnapshotSize(%d) = %q, want %q", size, got, want)
+		}
+	}
+}
+
+func TestSandboxBuildRootfsOptions(t *testing.T) {
+	opts := sandboxBuildRootfsOptions{
+		languages: []string{"python", "node"},
+		output:    "/tmp/images",
+		images:    []string{"node=node:22-alpine", "py=python:3.13-alpine"},
+	}
+	got, err := opts.builderOptions()
+	if err != nil {
+		t.Fatalf("builderOptions: %v", err)
+	}
+	if got.Images["nodejs"] != "node:22-alpine" || got.Images["python"] != "python:3.13-alpine" || got.OutputDir != "/tmp/images" {
+		t.Fatalf("unexpected options %+v", got)
+	}
+
+	for _, bad := range []sandboxBuildRootfsOptions{
+		{images: []string{"python"}},
+		{images: []string{"ruby=ruby:3"}},
+		{kernelSHA256: "abc"},
+		{sizeMB: -1},
+	} {
+		if _, err := bad.builderOptions(); err == nil {
+			t.Fatalf("expected an error for %+v", bad)
+		}
+	}
+}
@@ -134,7 +134,11 @@ func runServiceInstall(cmd *cobra.Command, configPath string, restart bool) erro
 		return err
 	}
 	out := cmd.OutOrStdout()
-	fmt.Fprintf(out, "Service file written: %s\n", result.Path)
+	if result.Registered {
+		fmt.Fprintf(out, "Service registered: %s\n", result.Path)
+	} else {
+		fmt.Fprintf(out, "Service file written: %s\n", result.Path)
+	}
 	if restart {
 		steps, err := service.RestartUserService(cmd.Context())
 		if err != nil {
@@ -170,7 +174,11 @@ func runServiceRepair(cmd *cobra.Command, configPath string, restart bool) error
 		return err
 	}
 	out := cmd.OutOrStdout()
-	fmt.Fprintf(out, "Service file updated: %s\n", result.Path)
+	if result.Registered {
+		fmt.Fprintf(out, "Service registered: %s\n", result.Path)
+	} else {
+		fmt.Fprintf(out, "Service file updated: %s\n", result.Path)
+	}
 	if restart {
 		steps, err := service.RestartUserService(cmd.Context())
 		if err != nil {
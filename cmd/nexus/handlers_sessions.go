@@ -1,15 +1,23 @@
 package main
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
+	"os"
+	"sort"
 	"strings"
 	"time"
 	"text/tabwriter"
 
+	agentctx "github.com/haasonsaas/nexus/internal/agent/context"
 	"github.com/haasonsaas/nexus/internal/config"
+	ctxwindow "github.com/haasonsaas/nexus/internal/context"
+	"github.com/haasonsaas/nexus/internal/gateway"
 	"github.com/haasonsaas/nexus/internal/sessions"
+	"github.com/haasonsaas/nexus/internal/storage/sqlite"
+	"github.com/haasonsaas/nexus/internal/transcript"
 	"github.com/haasonsaas/nexus/pkg/models"
 	"github.com/spf13/cobra"
 )
@@ -255,6 +263,282 @@ func runSessionsBranchesHistory(cmd *cobra.Command, configPath, branchID string,
 	return w.Flush()
 }
 
+// sessionShowReport is the JSON form of `nexus sessions show`.
+type sessionShowReport struct {
+	Session        *models.Session    `json:"session"`
+	ParentKey      string             `json:"parent_session_key,omitempty"`
+	ChildKeys      []string           `json:"child_session_keys,omitempty"`
+	ActiveBranchID string             `json:"active_branch_id,omitempty"`
+	Branches       *models.BranchTree `json:"branches,omitempty"`
+}
+
+func runSessionsShow(cmd *cobra.Command, configPath, sessionID string, jsonOutput bool) error {
+	configPath = resolveConfigPath(configPath)
+	cfg, err := config.Load(configPath)
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	store, closeStore, err := openSessionStore(cfg)
+	if err != nil {
+		return err
+	}
+	defer closeStore()
+
+	session, err := store.Get(cmd.Context(), sessionID)
+	if err != nil {
+		return fmt.Errorf("get session: %w", err)
+	}
+	report := sessionShowReport{
+		Session:   session,
+		ChildKeys: metadataStrings(session.Metadata[sessions.MetaKeyChildSessions]),
+	}
+	report.ParentKey, _ = session.Metadata[sessions.MetaKeyParentSession].(string)
+
+	// Branches are only persisted with CockroachDB/Postgres; SQLite
+	// gateways keep them in memory.
+	branchNote := "not persisted with a SQLite database"
+	if !sqlite.IsURL(cfg.Database.URL) {
+		branchStore, closeBranches, err := openBranchStore(cfg)
+		if err != nil {
+			return err
+		}
+		defer closeBranches()
+		report.Branches, err = branchStore.GetBranchTree(cmd.Context(), session.ID)
+		if err != nil && !errors.Is(err, sessions.ErrBranchNotFound) {
+			return fmt.Errorf("get branch tree: %w", err)
+		}
+		branchNote = "none"
+		if report.Branches != nil {
+			active, err := sessions.ActiveBranch(cmd.Context(), branchStore, session)
+			if err != nil {
+				return fmt.Errorf("resolve active branch: %w", err)
+			}
+			report.ActiveBranchID = active.ID
+		}
+	}
+
+	out := cmd.OutOrStdout()
+	if jsonOutput {
+		data, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(out, string(data))
+		return nil
+	}
+
+	fmt.Fprintf(out, "Session:  %s\n", session.ID)
+	fmt.Fprintf(out, "Key:      %s\n", session.Key)
+	if session.Title != "" {
+		fmt.Fprintf(out, "Title:    %s\n", session.Title)
+	}
+	fmt.Fprintf(out, "Agent:    %s\n", session.AgentID)
+	fmt.Fprintf(out, "Channel:  %s %s\n", session.Channel, session.ChannelID)
+	fmt.Fprintf(out, "Created:  %s\n", session.CreatedAt.Format(time.RFC3339))
+	fmt.Fprintf(out, "Updated:  %s\n", session.UpdatedAt.Format(time.RFC3339))
+	if report.ParentKey != "" {
+		fmt.Fprintf(out, "Parent:   %s\n", report.ParentKey)
+	}
+	for _, child := range report.ChildKeys {
+		fmt.Fprintf(out, "Child:    %s\n", child)
+	}
+	if report.Branches == nil {
+		fmt.Fprintf(out, "Branches: %s\n", branchNote)
+		return nil
+	}
+	fmt.Fprintf(out, "Active branch: %s\n", report.ActiveBranchID)
+	fmt.Fprintln(out, "Branches:")
+	printBranchTree(out, report.Branches, 1)
+	return nil
+}
+
+// metadataStrings reads a string list from session metadata, which holds
+// []any after a JSON round trip.
+func metadataStrings(value any) []string {
+	switch typed := value.(type) {
+	case []string:
+		return typed
+	case []any:
+		out := make([]string, 0, len(typed))
+		for _, item := range typed {
+			if str, ok := item.(string); ok && str != "" {
+				out = append(out, str)
+			}
+		}
+		return out
+	}
+	return nil
+}
+
+func runSessionsRender(cmd *cobra.Command, configPath, sessionID, formatName, outputPath string) error {
+	configPath = resolveConfigPath(configPath)
+	format, err := transcript.ParseFormat(formatName)
+	if err != nil {
+		return err
+	}
+	cfg, err := config.Load(configPath)
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	store, closeStore, err := openSessionStore(cfg)
+	if err != nil {
+		return err
+	}
+	defer closeStore()
+
+	session, err := store.Get(cmd.Context(), sessionID)
+	if err != nil {
+		return fmt.Errorf("get session: %w", err)
+	}
+	history, err := store.GetHistory(cmd.Context(), session.ID, 0)
+	if err != nil {
+		return fmt.Errorf("get history: %w", err)
+	}
+
+	doc := transcript.New(session, history, cfg)
+	if outputPath == "" {
+		return doc.Render(cmd.OutOrStdout(), format)
+	}
+	data, err := doc.Bytes(format)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(outputPath, data, 0o600); err != nil {
+		return fmt.Errorf("write transcript: %w", err)
+	}
+	fmt.Fprintf(cmd.OutOrStdout(), "Wrote %s (%d messages)\n", outputPath, doc.Summary.Messages)
+	return nil
+}
+
+// sessionContextReport is the JSON output of the sessions context command.
+type sessionContextReport struct {
+	SessionID string                      `json:"session_id"`
+	Model     string                      `json:"model,omitempty"`
+	Window    *ctxwindow.WindowInfo       `json:"context_window"`
+	Context   *models.ContextEventPayload `json:"context"`
+}
+
+func runSessionsContext(cmd *cobra.Command, configPath, sessionID, model string, jsonOutput bool) error {
+	cfg, err := config.Load(resolveConfigPath(configPath))
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	store, closeStore, err := openSessionStore(cfg)
+	if err != nil {
+		return err
+	}
+	defer closeStore()
+
+	session, err := store.Get(cmd.Context(), sessionID)
+	if err != nil {
+		return fmt.Errorf("get session: %w", err)
+	}
+	history, err := store.GetHistory(cmd.Context(), session.ID, 0)
+	if err != nil {
+		return fmt.Errorf("get history: %w", err)
+	}
+
+	// Pack as the runtime does before a model call, without an incoming
+	// message, so the diagnostics match the context.packed event.
+	packer := agentctx.NewPacker(agentctx.DefaultPackOptions())
+	diag := packer.PackWithDiagnostics(history, nil, agentctx.FindLatestSummary(history)).Diagnostics
+
+	if strings.TrimSpace(model) == "" {
+		model = sessionContextModel(cfg, session)
+	}
+	window := ctxwindow.NewWindowForModel(model)
+	window.SetUsed(contextTokens(diag.UsedChars))
+	report := sessionContextReport{SessionID: session.ID, Model: model, Window: window.Info(), Context: diag}
+
+	out := cmd.OutOrStdout()
+	if jsonOutput {
+		data, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(out, string(data))
+		return nil
+	}
+	return printSessionContext(out, report, time.Now())
+}
+
+// sessionContextModel returns the session's model override, or the default
+// model of the default provider.
+func sessionContextModel(cfg *config.Config, session *models.Session) string {
+	for _, key := range []string{"model", "model_override"} {
+		if value, ok := session.Metadata[key].(string); ok && strings.TrimSpace(value) != "" {
+			return strings.TrimSpace(value)
+		}
+	}
+	return strings.TrimSpace(cfg.LLM.Providers[cfg.LLM.DefaultProvider].DefaultModel)
+}
+
+// contextTokens estimates the tokens of chars characters.
+func contextTokens(chars int) int {
+	return int(float64(chars) * ctxwindow.TokensPerChar)
+}
+
+// printSessionContext writes the packed items, summary first and then
+// oldest to newest, followed by the totals.
+func printSessionContext(out io.Writer, report sessionContextReport, now time.Time) error {
+	diag := report.Context
+	items := append([]models.ContextPackItem(nil), diag.Items...)
+	sort.SliceStable(items, func(i, j int) bool {
+		if (items[i].Kind == models.ContextItemSummary) != (items[j].Kind == models.ContextItemSummary) {
+			return items[i].Kind == models.ContextItemSummary
+		}
+		return items[i].CreatedAt.Before(items[j].CreatedAt)
+	})
+
+	if len(items) == 0 {
+		fmt.Fprintln(out, "The session has no messages.")
+	} else {
+		w := tabwriter.NewWriter(out, 0, 4, 2, ' ', 0)
+		fmt.Fprintln(w, "KIND\tCHARS\tTOKENS\tAGE\tSTATUS\tREASON")
+		for _, item := range items {
+			status := "included"
+			if !item.Included {
+				status = "dropped"
+			}
+			age := "-"
+			if !item.CreatedAt.IsZero() {
+				age = now.Sub(item.CreatedAt).Round(time.Second).String()
+			}
+			fmt.Fprintf(w, "%s\t%d\t%d\t%s\t%s\t%s\n", item.Kind, item.Chars, contextTokens(item.Chars), age, status, item.Reason)
+		}
+		if err := w.Flush(); err != nil {
+			return err
+		}
+		fmt.Fprintln(out)
+	}
+
+	var overBudget, tooOld int
+	for _, item := range diag.Items {
+		switch item.Reason {
+		case models.ContextReasonOverBudget:
+			overBudget++
+		case models.ContextReasonTooOld:
+			tooOld++
+		}
+	}
+	model := report.Model
+	if model == "" {
+		model = "unknown model"
+	}
+	fmt.Fprintf(out, "Messages: %d of %d included (budget %d), %d dropped (%d over budget, %d too old)\n",
+		diag.Included, diag.Candidates, diag.BudgetMessages, diag.Dropped, overBudget, tooOld)
+	if diag.SummaryUsed {
+		fmt.Fprintf(out, "Summary:  included (%d chars)\n", diag.SummaryChars)
+	}
+	fmt.Fprintf(out, "Packed:   %d/%d chars (~%d tokens)\n", diag.UsedChars, diag.BudgetChars, contextTokens(diag.UsedChars))
+	fmt.Fprintf(out, "Window:   %s: %s, %s window size; system prompt and tools not counted\n",
+		model, report.Window.String(), report.Window.Source)
+	return nil
+}
+
 func openBranchStore(cfg *config.Config) (*sessions.CockroachBranchStore, func(), error) {
 	if cfg == nil {
 		return nil, nil, fmt.Errorf("config is required")
@@ -263,11 +547,27 @@ func openBranchStore(cfg *config.Config) (*sessions.CockroachBranchStore, func()
 		return nil, nil, fmt.Errorf("database.url is required")
 	}
 
+	keyring, err := gateway.BuildKeyring(cfg)
+	if err != nil {
+		return nil, nil, fmt.Errorf("field encryption: %w", err)
+	}
+	if sqlite.IsURL(cfg.Database.URL) {
+		store, err := sessions.NewSQLiteStore(cfg.Database.URL)
+		if err != nil {
+			return nil, nil, fmt.Errorf("open session store: %w", err)
+		}
+		branchStore := sessions.NewSQLiteBranchStore(store.DB())
+		branchStore.SetKeyring(keyring)
+		return branchStore, func() {
+			_ = store.Close()
+		}, nil
+	}
 	store, err := sessions.NewCockroachStoreFromDSN(cfg.Database.URL, nil)
 	if err != nil {
 		return nil, nil, fmt.Errorf("open session store: %w", err)
 	}
 	branchStore := sessions.NewCockroachBranchStore(store.DB())
+	branchStore.SetKeyring(keyring)
 	return branchStore, func() {
 		_ = store.Close()
 	}, nil
@@ -2,10 +2,13 @@ package main
 
 import (
 	"bufio"
+	"bytes"
+	"context"
 	"fmt"
 	"log/slog"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/haasonsaas/nexus/internal/config"
 	"github.com/haasonsaas/nexus/internal/doctor"
@@ -13,6 +16,8 @@ import (
 	"github.com/haasonsaas/nexus/internal/profile"
 	"github.com/haasonsaas/nexus/internal/workspace"
 	"github.com/spf13/cobra"
+	"golang.org/x/term"
+	"gopkg.in/yaml.v3"
 )
 
 // =============================================================================
@@ -72,43 +77,11 @@ func runOnboard(cmd *cobra.Command, opts *onboard.Options, nonInteractive, setup
 		}
 	}
 	if !nonInteractive {
-		reader := bufio.NewReader(os.Stdin)
-		if strings.TrimSpace(opts.DatabaseURL) == "" {
-			opts.DatabaseURL = promptString(reader, "Database URL", "postgres://root@localhost:26257/nexus?sslmode=disable")
+		if !term.IsTerminal(int(os.Stdin.Fd())) {
+			return fmt.Errorf("onboarding wizard needs a terminal; pass --non-interactive with flags instead")
 		}
-		if strings.TrimSpace(opts.Provider) == "" {
-			opts.Provider = promptString(reader, "LLM provider (anthropic/openai/google/openrouter)", "anthropic")
-		}
-		if strings.TrimSpace(opts.ProviderKey) == "" {
-			opts.ProviderKey = promptString(reader, "Provider API key", "")
-		}
-		if strings.TrimSpace(opts.WorkspacePath) == "" {
-			opts.WorkspacePath = promptString(reader, "Workspace path (optional)", "")
-		}
-		opts.EnableTelegram = promptBool(reader, "Enable Telegram?", opts.EnableTelegram)
-		if opts.EnableTelegram && strings.TrimSpace(opts.TelegramToken) == "" {
-			opts.TelegramToken = promptString(reader, "Telegram bot token", "")
-		}
-		opts.EnableDiscord = promptBool(reader, "Enable Discord?", opts.EnableDiscord)
-		if opts.EnableDiscord {
-			if strings.TrimSpace(opts.DiscordToken) == "" {
-				opts.DiscordToken = promptString(reader, "Discord bot token", "")
-			}
-			if strings.TrimSpace(opts.DiscordAppID) == "" {
-				opts.DiscordAppID = promptString(reader, "Discord app ID", "")
-			}
-		}
-		opts.EnableSlack = promptBool(reader, "Enable Slack?", opts.EnableSlack)
-		if opts.EnableSlack {
-			if strings.TrimSpace(opts.SlackBotToken) == "" {
-				opts.SlackBotToken = promptString(reader, "Slack bot token", "")
-			}
-			if strings.TrimSpace(opts.SlackAppToken) == "" {
-				opts.SlackAppToken = promptString(reader, "Slack app token", "")
-			}
-			if strings.TrimSpace(opts.SlackSecret) == "" {
-				opts.SlackSecret = promptString(reader, "Slack signing secret", "")
-			}
+		if err := onboard.NewWizard().Run(cmd.Context(), opts, os.Stdin, cmd.OutOrStdout()); err != nil {
+			return err
 		}
 	}
 
@@ -149,3 +122,167 @@ func runAuthSet(cmd *cobra.Command, configPath, provider, apiKey string, setDefa
 	fmt.Fprintf(cmd.OutOrStdout(), "Updated auth for %s in %s\n", provider, configPath)
 	return nil
 }
+
+// rotationTarget is the config entry holding the key being rotated.
+type rotationTarget struct {
+	id string
+	// value is the api_key or key scalar; keyFile is set instead when the
+	// key lives in a file.
+	value   *yaml.Node
+	keyFile string
+	// entry is the keys item, or nil for api_key.
+	entry *yaml.Node
+}
+
+// runAuthRotate handles the auth rotate command.
+func runAuthRotate(cmd *cobra.Command, configPath, provider, keyID, newKey string, skipVerify bool) error {
+	out := cmd.OutOrStdout()
+	configPath = resolveConfigPath(configPath)
+	provider = strings.ToLower(strings.TrimSpace(provider))
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		return fmt.Errorf("read config: %w", err)
+	}
+	var root yaml.Node
+	if err := yaml.Unmarshal(data, &root); err != nil {
+		return fmt.Errorf("parse config: %w", err)
+	}
+	entry := yamlMappingValue(yamlMappingValue(yamlMappingValue(yamlDocument(&root), "llm"), "providers"), provider)
+	if entry == nil {
+		return fmt.Errorf("llm.providers.%s is not configured in %s", provider, configPath)
+	}
+	target, err := findRotationTarget(entry, provider, strings.TrimSpace(keyID))
+	if err != nil {
+		return err
+	}
+	if target.keyFile == "" && strings.Contains(target.value.Value, "${") {
+		return fmt.Errorf("%s key %q is read from the environment (%s); update that secret, or move the key to llm.providers.%s.keys with key_file",
+			provider, target.id, target.value.Value, provider)
+	}
+
+	newKey = strings.TrimSpace(newKey)
+	if newKey == "" {
+		newKey = promptPassword(bufio.NewReader(os.Stdin), fmt.Sprintf("New %s key for %q", provider, target.id))
+	}
+	if newKey == "" {
+		return fmt.Errorf("no key given")
+	}
+	if !skipVerify {
+		switch provider {
+		case "anthropic", "openai", "google", "openrouter":
+			ctx, cancel := context.WithTimeout(cmd.Context(), 15*time.Second)
+			_, err := onboard.NewValidator().CheckProvider(ctx, provider, newKey)
+			cancel()
+			if err != nil {
+				return fmt.Errorf("new key failed the %s check (use --skip-verify to rotate anyway): %w", provider, err)
+			}
+		default:
+			fmt.Fprintf(out, "Cannot check %s keys; rotating without a check.\n", provider)
+		}
+	}
+
+	// Write the secret before the config so a gateway reloading on the
+	// config change reads the new key.
+	if target.keyFile != "" {
+		if err := writeFilePreserveMode(os.ExpandEnv(target.keyFile), []byte(newKey+"\n")); err != nil {
+			return fmt.Errorf("write key file: %w", err)
+		}
+	} else {
+		target.value.Kind = yaml.ScalarNode
+		target.value.Tag = "!!str"
+		target.value.Value = newKey
+	}
+	if target.entry != nil {
+		stamp := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!timestamp", Value: time.Now().UTC().Format(time.RFC3339)}
+		if existing := yamlMappingValue(target.entry, "rotated_at"); existing != nil {
+			*existing = *stamp
+		} else {
+			target.entry.Content = append(target.entry.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: "rotated_at"}, stamp)
+		}
+	}
+	if target.keyFile == "" || target.entry != nil {
+		var buf bytes.Buffer
+		encoder := yaml.NewEncoder(&buf)
+		encoder.SetIndent(2)
+		if err := encoder.Encode(&root); err != nil {
+			return fmt.Errorf("marshal config: %w", err)
+		}
+		if err := encoder.Close(); err != nil {
+			return fmt.Errorf("marshal config: %w", err)
+		}
+		if err := writeFilePreserveMode(configPath, buf.Bytes()); err != nil {
+			return fmt.Errorf("write config: %w", err)
+		}
+	}
+
+	where := configPath
+	if target.keyFile != "" {
+		where = target.keyFile
+	}
+	fmt.Fprintf(out, "Rotated %s key %q in %s.\n", provider, target.id, where)
+	fmt.Fprintln(out, "A running gateway switches to it on its next config reload. Revoke the old key once traffic has moved.")
+	return nil
+}
+
+// findRotationTarget picks the key of a provider entry named by keyID. With
+// no keyID the provider must have exactly one key.
+func findRotationTarget(entry *yaml.Node, provider, keyID string) (rotationTarget, error) {
+	var targets []rotationTarget
+	if apiKey := yamlMappingValue(entry, "api_key"); apiKey != nil {
+		targets = append(targets, rotationTarget{id: "default", value: apiKey})
+	}
+	if keys := yamlMappingValue(entry, "keys"); keys != nil && keys.Kind == yaml.SequenceNode {
+		for i, item := range keys.Content {
+			target := rotationTarget{id: fmt.Sprintf("key-%d", i+1), entry: item}
+			if id := yamlMappingValue(item, "id"); id != nil && strings.TrimSpace(id.Value) != "" {
+				target.id = strings.TrimSpace(id.Value)
+			}
+			if keyFile := yamlMappingValue(item, "key_file"); keyFile != nil && strings.TrimSpace(keyFile.Value) != "" {
+				target.keyFile = strings.TrimSpace(keyFile.Value)
+			} else if value := yamlMappingValue(item, "key"); value != nil {
+				target.value = value
+			} else {
+				target.value = &yaml.Node{Kind: yaml.ScalarNode}
+				item.Content = append(item.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: "key"}, target.value)
+			}
+			targets = append(targets, target)
+		}
+	}
+
+	ids := make([]string, 0, len(targets))
+	for _, target := range targets {
+		if target.id == keyID || (keyID == "" && len(targets) == 1) {
+			return target, nil
+		}
+		ids = append(ids, target.id)
+	}
+	switch {
+	case len(targets) == 0:
+		return rotationTarget{}, fmt.Errorf("llm.providers.%s has no api_key or keys to rotate", provider)
+	case keyID == "":
+		return rotationTarget{}, fmt.Errorf("llm.providers.%s has several keys; pass --key-id (one of %s)", provider, strings.Join(ids, ", "))
+	default:
+		return rotationTarget{}, fmt.Errorf("llm.providers.%s has no key %q (have %s)", provider, keyID, strings.Join(ids, ", "))
+	}
+}
+
+// yamlDocument returns the top-level node of a parsed document.
+func yamlDocument(node *yaml.Node) *yaml.Node {
+	if node != nil && node.Kind == yaml.DocumentNode && len(node.Content) > 0 {
+		return node.Content[0]
+	}
+	return node
+}
+
+// yamlMappingValue returns the value of key in a mapping node, or nil.
+func yamlMappingValue(node *yaml.Node, key string) *yaml.Node {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
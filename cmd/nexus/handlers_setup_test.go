@@ -0,0 +1,74 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/haasonsaas/nexus/internal/config"
+)
+
+func TestRunAuthRotate(t *testing.T) {
+	dir := t.TempDir()
+	keyFile := filepath.Join(dir, "openai-key")
+	if err := os.WriteFile(keyFile, []byte("sk-old-file\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	configPath := filepath.Join(dir, "nexus.yaml")
+	original := `version: 1
+llm:
+  default_provider: openai
+  providers:
+    openai:
+      # primary key
+      api_key: ${OPENAI_API_KEY}
+      keys:
+        - id: backup
+          key: sk-old-backup
+          weight: 2
+        - id: mounted
+          key_file: ` + keyFile + `
+`
+	if err := os.WriteFile(configPath, []byte(original), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	cmd := buildAuthRotateCmd()
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+
+	if err := runAuthRotate(cmd, configPath, "openai", "", "sk-new", true); err == nil || !strings.Contains(err.Error(), "pass --key-id") {
+		t.Fatalf("expected an ambiguous key error, got %v", err)
+	}
+	if err := runAuthRotate(cmd, configPath, "openai", "default", "sk-new", true); err == nil || !strings.Contains(err.Error(), "OPENAI_API_KEY") {
+		t.Fatalf("expected an environment key error, got %v", err)
+	}
+
+	if err := runAuthRotate(cmd, configPath, "openai", "backup", "sk-new-backup", true); err != nil {
+		t.Fatalf("rotate backup: %v", err)
+	}
+	if err := runAuthRotate(cmd, configPath, "openai", "mounted", "sk-new-file", true); err != nil {
+		t.Fatalf("rotate mounted: %v", err)
+	}
+	if data, _ := os.ReadFile(keyFile); string(data) != "sk-new-file\n" {
+		t.Fatalf("key file = %q", data)
+	}
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), "# primary key") || !strings.Contains(string(data), "${OPENAI_API_KEY}") {
+		t.Fatalf("expected comments and env references to survive:\n%s", data)
+	}
+
+	t.Setenv("OPENAI_API_KEY", "sk-env")
+	cfg, err := config.Load(configPath)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	keys := cfg.LLM.Providers["openai"].Keys
+	if keys[0].Key != "sk-new-backup" || keys[0].Weight != 2 || keys[0].RotatedAt.IsZero() || keys[1].RotatedAt.IsZero() {
+		t.Fatalf("keys after rotation = %+v", keys)
+	}
+}
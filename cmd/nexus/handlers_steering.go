@@ -0,0 +1,134 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/haasonsaas/nexus/internal/config"
+	"github.com/haasonsaas/nexus/internal/gateway"
+	"github.com/haasonsaas/nexus/pkg/models"
+	"github.com/spf13/cobra"
+)
+
+// =============================================================================
+// Steering Command Handlers
+// =============================================================================
+
+// steeringTestOptions describes the sample message for a steering dry run.
+type steeringTestOptions struct {
+	Message  string
+	Channel  string
+	Agent    string
+	Role     string
+	Tags     []string
+	Metadata []string
+}
+
+// steeringTestResult is the JSON output of "nexus steering test".
+type steeringTestResult struct {
+	Enabled bool                        `json:"enabled"`
+	Prompt  string                      `json:"prompt,omitempty"`
+	Rules   []gateway.SteeringRuleTrace `json:"rules"`
+}
+
+// runSteeringTest handles the steering test command.
+func runSteeringTest(cmd *cobra.Command, configPath string, opts steeringTestOptions, jsonOutput bool) error {
+	cfg, err := config.Load(configPath)
+	if err != nil {
+		return fmt.Errorf("load config: %w", err)
+	}
+
+	metadata := make(map[string]any, len(opts.Metadata)+1)
+	for _, pair := range opts.Metadata {
+		key, value, ok := strings.Cut(pair, "=")
+		if !ok || strings.TrimSpace(key) == "" {
+			return fmt.Errorf("invalid --meta %q (want key=value)", pair)
+		}
+		metadata[strings.TrimSpace(key)] = value
+	}
+	if len(opts.Tags) > 0 {
+		metadata["tags"] = opts.Tags
+	}
+	agentID := strings.TrimSpace(opts.Agent)
+	if agentID == "" {
+		agentID = cfg.Session.DefaultAgentID
+	}
+
+	session := &models.Session{AgentID: agentID, Channel: models.ChannelType(opts.Channel)}
+	msg := &models.Message{
+		Channel:  models.ChannelType(opts.Channel),
+		Role:     models.Role(opts.Role),
+		Content:  opts.Message,
+		Metadata: metadata,
+	}
+	prompt, traces := gateway.DryRunSteering(cfg.Steering, session, msg, time.Now())
+	result := steeringTestResult{Enabled: cfg.Steering.Enabled, Prompt: prompt, Rules: traces}
+
+	if jsonOutput {
+		enc := json.NewEncoder(cmd.OutOrStdout())
+		enc.SetIndent("", "  ")
+		return enc.Encode(result)
+	}
+	printSteeringTest(cmd.OutOrStdout(), result)
+	return nil
+}
+
+func printSteeringTest(out io.Writer, result steeringTestResult) {
+	if !result.Enabled {
+		fmt.Fprintln(out, "Note: steering.enabled is false; no rules fire until it is enabled.")
+		fmt.Fprintln(out)
+	}
+	if len(result.Rules) == 0 {
+		fmt.Fprintln(out, "No steering rules configured.")
+		return
+	}
+
+	var matched, skipped []gateway.SteeringRuleTrace
+	for _, trace := range result.Rules {
+		if trace.Matched {
+			matched = append(matched, trace)
+		} else {
+			skipped = append(skipped, trace)
+		}
+	}
+
+	fmt.Fprintf(out, "Matched (%d)\n", len(matched))
+	for _, trace := range matched {
+		fmt.Fprintf(out, "   %s\n", steeringTraceLine(trace))
+	}
+	if len(matched) == 0 {
+		fmt.Fprintln(out, "   none")
+	}
+	fmt.Fprintln(out)
+
+	fmt.Fprintf(out, "Skipped (%d)\n", len(skipped))
+	for _, trace := range skipped {
+		fmt.Fprintf(out, "   %s\n", steeringTraceLine(trace))
+	}
+	if len(skipped) == 0 {
+		fmt.Fprintln(out, "   none")
+	}
+
+	if result.Prompt != "" {
+		fmt.Fprintln(out)
+		fmt.Fprintln(out, "Injected directives")
+		for _, line := range strings.Split(result.Prompt, "\n") {
+			fmt.Fprintf(out, "   %s\n", line)
+		}
+	}
+}
+
+func steeringTraceLine(trace gateway.SteeringRuleTrace) string {
+	label := trace.ID
+	if trace.Name != "" && trace.Name != trace.ID {
+		label = fmt.Sprintf("%s (%s)", trace.ID, trace.Name)
+	}
+	line := fmt.Sprintf("%s [priority %d]", label, trace.Priority)
+	if len(trace.Reasons) > 0 {
+		line += ": " + strings.Join(trace.Reasons, ", ")
+	}
+	return line
+}
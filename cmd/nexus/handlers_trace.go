@@ -105,6 +105,9 @@ func runTraceStats(cmd *cobra.Command, filePath string, jsonOutput bool) error {
 	fmt.Fprintf(out, "  Wall time:    %v\n", stats.WallTime)
 	fmt.Fprintf(out, "  Model time:   %v\n", stats.ModelWallTime)
 	fmt.Fprintf(out, "  Tool time:    %v\n", stats.ToolWallTime)
+	if stats.EarlyToolStarts > 0 {
+		fmt.Fprintf(out, "  Tool overlap: %v (%d early starts)\n", stats.ToolOverlapTime, stats.EarlyToolStarts)
+	}
 	fmt.Fprintln(out)
 
 	// Counts
@@ -118,8 +121,19 @@ func runTraceStats(cmd *cobra.Command, filePath string, jsonOutput bool) error {
 	fmt.Fprintln(out, "Tokens:")
 	fmt.Fprintf(out, "  Input:        %d\n", stats.InputTokens)
 	fmt.Fprintf(out, "  Output:       %d\n", stats.OutputTokens)
+	if stats.ReasoningTokens > 0 {
+		fmt.Fprintf(out, "  Reasoning:    %d\n", stats.ReasoningTokens)
+	}
 	fmt.Fprintln(out)
 
+	// Plan
+	if stats.PlanUpdates > 0 {
+		fmt.Fprintln(out, "Plan:")
+		fmt.Fprintf(out, "  Steps done:   %d/%d\n", stats.PlanStepsDone, stats.PlanSteps)
+		fmt.Fprintf(out, "  Updates:      %d\n", stats.PlanUpdates)
+		fmt.Fprintln(out)
+	}
+
 	// Errors
 	if stats.Errors > 0 {
 		fmt.Fprintf(out, "Errors: %d\n", stats.Errors)
@@ -246,12 +260,25 @@ func runTraceReplay(cmd *cobra.Command, filePath string, speed float64, fromSeq,
 						prefix, e.Stream.InputTokens, e.Stream.OutputTokens)
 				}
 
+			case models.AgentEventModelReasoning:
+				if e.Stream != nil {
+					fmt.Fprintf(out, "%s  [reasoning: %d tokens] %s\n", prefix, e.Stream.ReasoningTokens, e.Stream.Final)
+				}
+
 			case models.AgentEventContextPacked:
 				if e.Context != nil {
 					fmt.Fprintf(out, "%sContext: %d/%d msgs, %d dropped\n",
 						prefix, e.Context.UsedMessages, e.Context.BudgetMessages, e.Context.Dropped)
 				}
 
+			case models.AgentEventPlanUpdated:
+				if e.Plan != nil {
+					fmt.Fprintf(out, "%s# Plan: %d/%d steps done\n", prefix, e.Plan.Done(), len(e.Plan.Steps))
+					for i, step := range e.Plan.Steps {
+						fmt.Fprintf(out, "  %d. [%s] %s\n", i+1, step.Status, step.Title)
+					}
+				}
+
 			default:
 				// Other events - print type for debugging
 				fmt.Fprintf(out, "%s  [%s] seq=%d\n", prefix, e.Type, e.Sequence)
@@ -294,3 +321,50 @@ func runTraceReplay(cmd *cobra.Command, filePath string, speed float64, fromSeq,
 
 	return nil
 }
+
+// runTraceProfile handles the trace profile command.
+func runTraceProfile(cmd *cobra.Command, filePath, format, output string) error {
+	format = strings.ToLower(strings.TrimSpace(format))
+	if format != "chrome" && format != "folded" {
+		return fmt.Errorf("unknown format %q (want chrome or folded)", format)
+	}
+
+	f, err := os.Open(filePath)
+	if err != nil {
+		return fmt.Errorf("failed to open trace file: %w", err)
+	}
+	defer f.Close()
+
+	reader, err := agent.NewTraceReader(f)
+	if err != nil {
+		return fmt.Errorf("failed to read trace: %w", err)
+	}
+	events, err := reader.ReadAll()
+	if err != nil {
+		return fmt.Errorf("failed to read events: %w", err)
+	}
+	profile := agent.BuildTraceProfile(reader.Header().RunID, events)
+
+	out := cmd.OutOrStdout()
+	if output != "" {
+		file, err := os.Create(output)
+		if err != nil {
+			return fmt.Errorf("failed to create output file: %w", err)
+		}
+		defer file.Close()
+		out = file
+	}
+
+	if format == "folded" {
+		err = profile.WriteFolded(out)
+	} else {
+		err = profile.WriteChromeTrace(out)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to write profile: %w", err)
+	}
+	if output != "" {
+		fmt.Fprintf(cmd.OutOrStdout(), "Wrote %s profile to %s\n", format, output)
+	}
+	return nil
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"text/tabwriter"
+	"time"
+
+	"github.com/haasonsaas/nexus/internal/config"
+	"github.com/haasonsaas/nexus/internal/workspace"
+	"github.com/spf13/cobra"
+)
+
+// =============================================================================
+// Workspace Command Handlers
+// =============================================================================
+
+// loadWorkspaceHistory returns the workspace file history for the config.
+func loadWorkspaceHistory(configPath string) (*workspace.History, error) {
+	cfg, err := config.Load(resolveConfigPath(configPath))
+	if err != nil {
+		return nil, fmt.Errorf("load config: %w", err)
+	}
+	history := workspace.NewHistory(cfg)
+	if history == nil {
+		return nil, fmt.Errorf("workspace history is disabled (set workspace.history.enabled)")
+	}
+	return history, nil
+}
+
+// runWorkspaceHistory handles the workspace history command.
+func runWorkspaceHistory(cmd *cobra.Command, configPath, file string, jsonOutput bool) error {
+	history, err := loadWorkspaceHistory(configPath)
+	if err != nil {
+		return err
+	}
+	versions, err := history.Versions(file)
+	if err != nil {
+		return err
+	}
+
+	out := cmd.OutOrStdout()
+	if jsonOutput {
+		data, err := json.MarshalIndent(versions, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(out, string(data))
+		return nil
+	}
+	if len(versions) == 0 {
+		fmt.Fprintf(out, "No saved versions of %s\n", file)
+		return nil
+	}
+	return printWorkspaceVersions(out, versions, time.Now())
+}
+
+// runWorkspaceRollback handles the workspace rollback command.
+func runWorkspaceRollback(cmd *cobra.Command, configPath, file string, version int) error {
+	history, err := loadWorkspaceHistory(configPath)
+	if err != nil {
+		return err
+	}
+	restored, err := history.Rollback(cmd.Context(), file, version)
+	if err != nil {
+		return fmt.Errorf("rollback %s: %w", file, err)
+	}
+
+	out := cmd.OutOrStdout()
+	if restored == nil {
+		fmt.Fprintf(out, "%s already matches version %d\n", file, version)
+		return nil
+	}
+	fmt.Fprintf(out, "Restored %s to version %d (saved as version %d)\n", file, version, restored.Version)
+	return nil
+}
+
+// printWorkspaceVersions writes file versions as a table, newest first.
+func printWorkspaceVersions(out io.Writer, versions []workspace.FileVersion, now time.Time) error {
+	w := tabwriter.NewWriter(out, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "VERSION\tSAVED\tSOURCE\tSIZE")
+	for i := len(versions) - 1; i >= 0; i-- {
+		v := versions[i]
+		source := v.Source
+		if v.RestoredFrom > 0 {
+			source = fmt.Sprintf("%s of v%d", source, v.RestoredFrom)
+		}
+		fmt.Fprintf(w, "%d\t%s ago\t%s\t%d bytes\n", v.Version, now.Sub(v.SavedAt).Round(time.Second), source, v.Size)
+	}
+	return w.Flush()
+}
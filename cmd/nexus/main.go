@@ -98,12 +98,18 @@ Documentation: https://github.com/haasonsaas/nexus`,
 		buildAgentsCmd(),
 		buildStatusCmd(),
 		buildDoctorCmd(),
+		buildDebugCmd(),
+		buildAdminCmd(),
+		buildSandboxCmd(),
+		buildRunsCmd(),
+		buildWorkspaceCmd(),
 		buildPromptCmd(),
 		buildSetupCmd(),
 		buildOnboardCmd(),
 		buildAuthCmd(),
 		buildProfileCmd(),
 		buildPairingCmd(),
+		buildAccessCmd(),
 		buildArtifactsCmd(),
 		buildSkillsCmd(),
 		buildExtensionsCmd(),
@@ -116,7 +122,24 @@ Documentation: https://github.com/haasonsaas/nexus`,
 		buildTraceCmd(),
 		buildEdgeCmd(),
 		buildEventsCmd(),
+		buildFeedbackCmd(),
+		buildSteeringCmd(),
+		buildExperimentsCmd(),
+		buildEvalCmd(),
+		buildModelsCmd(),
+		buildBenchCmd(),
+		buildChatCmd(),
+		buildClusterCmd(),
+		buildPrivacyCmd(),
+		buildEncryptionCmd(),
+		buildConfigCmd(),
+		buildCompletionCmd(),
 	)
 
+	// Replace cobra's default completion command with ours, which documents
+	// the dynamic values, and attach those values to the command tree.
+	rootCmd.CompletionOptions.DisableDefaultCmd = true
+	registerDynamicCompletions(rootCmd)
+
 	return rootCmd
 }
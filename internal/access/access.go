@@ -0,0 +1,270 @@
+// Package access stores runtime grants to the gateway's access-control
+// lists, so admins can let senders run commands, use elevated tools, or DM
+// the gateway without editing the config file on the server.
+package access
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/haasonsaas/nexus/pkg/models"
+)
+
+// List names an access-control list.
+type List string
+
+const (
+	// ListCommands extends commands.allow_from.
+	ListCommands List = "commands"
+	// ListElevated extends tools.elevated.allow_from.
+	ListElevated List = "elevated"
+	// ListPairing holds senders approved to DM the gateway, like the
+	// channels.<channel>.dm.allow_from lists and approved pairing codes.
+	ListPairing List = "pairing"
+)
+
+// Lists are the access-control lists grants can be made to.
+var Lists = []List{ListCommands, ListElevated, ListPairing}
+
+// DefaultChannel is the channel of grants that apply on every channel, as
+// the "default" key does in allow_from maps.
+const DefaultChannel = "default"
+
+// ErrUnavailable is returned when no grant store is configured.
+var ErrUnavailable = errors.New("access grants require database.url")
+
+// ParseList parses a list name case-insensitively.
+func ParseList(value string) (List, error) {
+	list := List(strings.ToLower(strings.TrimSpace(value)))
+	for _, known := range Lists {
+		if list == known {
+			return list, nil
+		}
+	}
+	return "", fmt.Errorf("unknown access list %q (expected commands, elevated, or pairing)", value)
+}
+
+// Grant lets one sender on one channel pass a list.
+type Grant struct {
+	List    List   `json:"list"`
+	Channel string `json:"channel"`
+	Sender  string `json:"sender"`
+	// ExpiresAt is when the grant lapses. Zero means never.
+	ExpiresAt time.Time `json:"expires_at,omitempty"`
+	// GrantedBy is the "channel:sender" of the admin who made the grant,
+	// or "config" and "cli" for grants made outside chat.
+	GrantedBy string    `json:"granted_by,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Subject returns the grant's "channel:sender".
+func (g Grant) Subject() string {
+	return g.Channel + ":" + g.Sender
+}
+
+// Expired reports whether the grant has lapsed at now.
+func (g Grant) Expired(now time.Time) bool {
+	return !g.ExpiresAt.IsZero() && !now.Before(g.ExpiresAt)
+}
+
+// Normalize lowercases the channel and trims mention syntax from the sender
+// so grants compare like allow_from entries.
+func (g Grant) Normalize() Grant {
+	g.Channel = normalizeChannel(g.Channel)
+	g.Sender = NormalizeSender(g.Sender)
+	return g
+}
+
+func (g Grant) validate() error {
+	if _, err := ParseList(string(g.List)); err != nil {
+		return err
+	}
+	if g.Channel == "" || g.Sender == "" {
+		return errors.New("grant channel and sender are required")
+	}
+	return nil
+}
+
+func normalizeChannel(channel string) string {
+	return strings.ToLower(strings.TrimSpace(channel))
+}
+
+// NormalizeSender strips mention syntax such as "@alice", "<@U123>", and
+// "<@U123|alice>" and lowercases the ID, matching how allow_from entries
+// are compared.
+func NormalizeSender(sender string) string {
+	sender = strings.TrimSpace(sender)
+	if strings.HasPrefix(sender, "<@") && strings.HasSuffix(sender, ">") {
+		sender = strings.TrimSuffix(strings.TrimPrefix(sender, "<@"), ">")
+		sender = strings.TrimPrefix(sender, "!")
+		if idx := strings.Index(sender, "|"); idx >= 0 {
+			sender = sender[:idx]
+		}
+	}
+	sender = strings.TrimPrefix(sender, "@")
+	return strings.ToLower(strings.TrimSpace(sender))
+}
+
+// knownChannels are the channel names a subject may be prefixed with.
+var knownChannels = map[string]bool{
+	DefaultChannel:                      true,
+	string(models.ChannelTelegram):      true,
+	string(models.ChannelDiscord):       true,
+	string(models.ChannelSlack):         true,
+	string(models.ChannelAPI):           true,
+	string(models.ChannelWhatsApp):      true,
+	string(models.ChannelSignal):        true,
+	string(models.ChannelIMessage):      true,
+	string(models.ChannelMatrix):        true,
+	string(models.ChannelTeams):         true,
+	string(models.ChannelEmail):         true,
+	string(models.ChannelMattermost):    true,
+	string(models.ChannelNextcloudTalk): true,
+	string(models.ChannelNostr):         true,
+	string(models.ChannelZalo):          true,
+	string(models.ChannelBlueBubbles):   true,
+	string(models.ChannelLoopback):      true,
+}
+
+// ParseSubject splits "channel:sender" into its parts. The prefix is only
+// taken as a channel when it names a known channel, so sender IDs that
+// contain a colon, such as Matrix's "@alice:matrix.org", are kept whole. A
+// subject without a channel is scoped to defaultChannel.
+func ParseSubject(subject, defaultChannel string) (channel, sender string, err error) {
+	subject = strings.TrimSpace(subject)
+	channel, sender = defaultChannel, subject
+	if prefix, rest, found := strings.Cut(subject, ":"); found && knownChannels[normalizeChannel(prefix)] {
+		channel, sender = prefix, rest
+	}
+	channel = normalizeChannel(channel)
+	sender = NormalizeSender(sender)
+	if channel == "" || sender == "" {
+		return "", "", fmt.Errorf("expected channel:sender, got %q", subject)
+	}
+	return channel, sender, nil
+}
+
+// ParseTTL parses how long a grant lasts: a Go duration such as "90m" or
+// "1h", or a number of days such as "7d".
+func ParseTTL(value string) (time.Duration, error) {
+	value = strings.TrimSpace(value)
+	if days, ok := strings.CutSuffix(value, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err == nil && n > 0 {
+			return time.Duration(n) * 24 * time.Hour, nil
+		}
+	}
+	ttl, err := time.ParseDuration(value)
+	if err != nil || ttl <= 0 {
+		return 0, fmt.Errorf("invalid duration %q (use e.g. 30m, 1h, or 7d)", value)
+	}
+	return ttl, nil
+}
+
+// Matches reports whether an unexpired grant lets sender pass on channel.
+// Grants on the default channel apply everywhere, and a "*" sender matches
+// anyone.
+func Matches(grants []Grant, channel, sender string, now time.Time) bool {
+	channel = normalizeChannel(channel)
+	sender = NormalizeSender(sender)
+	if sender == "" {
+		return false
+	}
+	for _, grant := range grants {
+		if grant.Expired(now) {
+			continue
+		}
+		if grant.Channel != channel && grant.Channel != DefaultChannel {
+			continue
+		}
+		if grant.Sender == "*" || grant.Sender == sender {
+			return true
+		}
+	}
+	return false
+}
+
+// Store persists grants.
+type Store interface {
+	// Put inserts or replaces the grant for its list, channel, and sender.
+	Put(ctx context.Context, grant Grant) error
+	// Delete revokes a grant and reports whether one existed.
+	Delete(ctx context.Context, list List, channel, sender string) (bool, error)
+	// List returns the unexpired grants of list, or of every list when list
+	// is empty, ordered by list, channel, and sender.
+	List(ctx context.Context, list List) ([]Grant, error)
+}
+
+// MemoryStore keeps grants in memory. It is used in tests and when grants
+// only need to last for the process.
+type MemoryStore struct {
+	mu     sync.RWMutex
+	grants map[string]Grant
+}
+
+// NewMemoryStore creates an empty in-memory store.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{grants: make(map[string]Grant)}
+}
+
+func grantKey(list List, channel, sender string) string {
+	return string(list) + "\x00" + channel + "\x00" + sender
+}
+
+// Put stores the grant.
+func (s *MemoryStore) Put(ctx context.Context, grant Grant) error {
+	grant = grant.Normalize()
+	if err := grant.validate(); err != nil {
+		return err
+	}
+	if grant.CreatedAt.IsZero() {
+		grant.CreatedAt = time.Now()
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.grants[grantKey(grant.List, grant.Channel, grant.Sender)] = grant
+	return nil
+}
+
+// Delete removes the grant.
+func (s *MemoryStore) Delete(ctx context.Context, list List, channel, sender string) (bool, error) {
+	key := grantKey(list, normalizeChannel(channel), NormalizeSender(sender))
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, ok := s.grants[key]
+	delete(s.grants, key)
+	return ok, nil
+}
+
+// List returns the unexpired grants of list.
+func (s *MemoryStore) List(ctx context.Context, list List) ([]Grant, error) {
+	now := time.Now()
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	var out []Grant
+	for _, grant := range s.grants {
+		if (list == "" || grant.List == list) && !grant.Expired(now) {
+			out = append(out, grant)
+		}
+	}
+	sortGrants(out)
+	return out, nil
+}
+
+func sortGrants(grants []Grant) {
+	sort.Slice(grants, func(i, j int) bool {
+		if grants[i].List != grants[j].List {
+			return grants[i].List < grants[j].List
+		}
+		if grants[i].Channel != grants[j].Channel {
+			return grants[i].Channel < grants[j].Channel
+		}
+		return grants[i].Sender < grants[j].Sender
+	})
+}
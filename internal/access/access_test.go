@@ -0,0 +1,137 @@
+package access
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/haasonsaas/nexus/internal/config"
+)
+
+func TestParseSubject(t *testing.T) {
+	cases := []struct {
+		subject, channel, sender string
+	}{
+		{"@Alice", "slack", "alice"},
+		{"<@U123|alice>", "slack", "u123"},
+		{"telegram:12345", "telegram", "12345"},
+		{"Discord:<@!42>", "discord", "42"},
+		{"@alice:matrix.org", "slack", "alice:matrix.org"},
+		{"matrix:@alice:matrix.org", "matrix", "alice:matrix.org"},
+	}
+	for _, tc := range cases {
+		channel, sender, err := ParseSubject(tc.subject, "slack")
+		if err != nil || channel != tc.channel || sender != tc.sender {
+			t.Errorf("ParseSubject(%q) = %q, %q, %v; want %q, %q", tc.subject, channel, sender, err, tc.channel, tc.sender)
+		}
+	}
+	if _, _, err := ParseSubject("@bob", ""); err == nil {
+		t.Error("expected error without a channel")
+	}
+}
+
+func TestParseTTL(t *testing.T) {
+	for value, want := range map[string]time.Duration{"90m": 90 * time.Minute, "1h": time.Hour, "7d": 7 * 24 * time.Hour} {
+		if got, err := ParseTTL(value); err != nil || got != want {
+			t.Errorf("ParseTTL(%q) = %v, %v; want %v", value, got, err, want)
+		}
+	}
+	for _, value := range []string{"", "soon", "-1h", "0d"} {
+		if _, err := ParseTTL(value); err == nil {
+			t.Errorf("ParseTTL(%q) expected error", value)
+		}
+	}
+}
+
+func TestMatches(t *testing.T) {
+	now := time.Now()
+	grants := []Grant{
+		{List: ListCommands, Channel: "slack", Sender: "u1"},
+		{List: ListCommands, Channel: "default", Sender: "ops"},
+		{List: ListCommands, Channel: "discord", Sender: "*"},
+		{List: ListCommands, Channel: "slack", Sender: "u2", ExpiresAt: now.Add(-time.Minute)},
+	}
+	cases := []struct {
+		channel, sender string
+		want            bool
+	}{
+		{"slack", "U1", true},
+		{"telegram", "u1", false},
+		{"telegram", "ops", true},
+		{"discord", "anyone", true},
+		{"slack", "u2", false},
+		{"slack", "", false},
+	}
+	for _, tc := range cases {
+		if got := Matches(grants, tc.channel, tc.sender, now); got != tc.want {
+			t.Errorf("Matches(%s, %s) = %v, want %v", tc.channel, tc.sender, got, tc.want)
+		}
+	}
+}
+
+func TestCacheInvalidatesOnWrite(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryStore()
+	cache := NewCache(store, time.Hour)
+
+	if grants, err := cache.Grants(ctx, ListElevated); err != nil || len(grants) != 0 {
+		t.Fatalf("Grants() = %v, %v", grants, err)
+	}
+	if err := cache.Put(ctx, Grant{List: ListElevated, Channel: "slack", Sender: "@alice"}); err != nil {
+		t.Fatal(err)
+	}
+	grants, err := cache.Grants(ctx, ListElevated)
+	if err != nil || len(grants) != 1 || grants[0].Sender != "alice" {
+		t.Fatalf("Grants() after Put = %v, %v", grants, err)
+	}
+
+	// Writes made elsewhere are only seen after an invalidation.
+	if err := store.Put(ctx, Grant{List: ListElevated, Channel: "slack", Sender: "bob"}); err != nil {
+		t.Fatal(err)
+	}
+	if grants, _ := cache.Grants(ctx, ListElevated); len(grants) != 1 {
+		t.Fatalf("expected cached grants, got %v", grants)
+	}
+	cache.Invalidate()
+	if grants, _ := cache.Grants(ctx, ListElevated); len(grants) != 2 {
+		t.Fatalf("expected reloaded grants, got %v", grants)
+	}
+}
+
+func TestConfigRoundTrip(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.Commands.AllowFrom = map[string][]string{"telegram": {"12345", "@ops"}}
+	cfg.Tools.Elevated.AllowFrom = map[string][]string{"default": {"admin"}}
+	cfg.Channels.NextcloudTalk.DM.AllowFrom = []string{"carol"}
+
+	grants := FromConfig(cfg)
+	var got []string
+	for _, grant := range grants {
+		got = append(got, string(grant.List)+"/"+grant.Subject())
+		if grant.GrantedBy != ConfigGrantor {
+			t.Errorf("grant %s granted by %q", grant.Subject(), grant.GrantedBy)
+		}
+	}
+	want := "commands/telegram:12345 commands/telegram:ops elevated/default:admin pairing/nextcloud-talk:carol"
+	if strings.Join(got, " ") != want {
+		t.Fatalf("FromConfig() = %s, want %s", strings.Join(got, " "), want)
+	}
+
+	grants = append(grants,
+		Grant{List: ListElevated, Channel: "slack", Sender: "temp", ExpiresAt: time.Now().Add(time.Hour)},
+		Grant{List: ListPairing, Channel: "nostr", Sender: "npub1"},
+	)
+	data, skipped, err := ExportConfig(grants)
+	if err != nil {
+		t.Fatalf("ExportConfig() error = %v", err)
+	}
+	if len(skipped) != 2 {
+		t.Fatalf("expected the expiring and nostr grants to be skipped, got %v", skipped)
+	}
+	for _, want := range []string{"commands:", "elevated:", "nextcloud_talk:", "- ops"} {
+		if !strings.Contains(string(data), want) {
+			t.Fatalf("expected %q in exported config:\n%s", want, data)
+		}
+	}
+}
@@ -0,0 +1,148 @@
+package access
+
+import (
+	"bytes"
+	"strings"
+	"time"
+
+	"github.com/haasonsaas/nexus/internal/config"
+	"gopkg.in/yaml.v3"
+)
+
+// ConfigGrantor is the GrantedBy of grants imported from the config file.
+const ConfigGrantor = "config"
+
+// dmPolicies returns each channel's DM policy keyed by channel type, the
+// channel name messages and grants carry.
+func dmPolicies(cfg *config.Config) map[string]*config.ChannelPolicyConfig {
+	ch := &cfg.Channels
+	return map[string]*config.ChannelPolicyConfig{
+		"telegram":       &ch.Telegram.DM,
+		"discord":        &ch.Discord.DM,
+		"slack":          &ch.Slack.DM,
+		"whatsapp":       &ch.WhatsApp.DM,
+		"signal":         &ch.Signal.DM,
+		"imessage":       &ch.IMessage.DM,
+		"matrix":         &ch.Matrix.DM,
+		"teams":          &ch.Teams.DM,
+		"mattermost":     &ch.Mattermost.DM,
+		"nextcloud-talk": &ch.NextcloudTalk.DM,
+		"zalo":           &ch.Zalo.DM,
+		"bluebubbles":    &ch.BlueBubbles.DM,
+	}
+}
+
+// configKey maps a channel type to its key under channels in the config,
+// e.g. "nextcloud-talk" to "nextcloud_talk".
+func configKey(channel string) string {
+	return strings.ReplaceAll(channel, "-", "_")
+}
+
+// FromConfig converts the allowlists in cfg into grants, for seeding the
+// store from a config file: commands.allow_from, tools.elevated.allow_from,
+// and channels.<channel>.dm.allow_from.
+func FromConfig(cfg *config.Config) []Grant {
+	if cfg == nil {
+		return nil
+	}
+	now := time.Now()
+	var grants []Grant
+	add := func(list List, channel string, senders []string) {
+		for _, sender := range senders {
+			grant := Grant{List: list, Channel: channel, Sender: sender, GrantedBy: ConfigGrantor, CreatedAt: now}.Normalize()
+			if grant.Channel != "" && grant.Sender != "" {
+				grants = append(grants, grant)
+			}
+		}
+	}
+	for channel, senders := range cfg.Commands.AllowFrom {
+		add(ListCommands, channel, senders)
+	}
+	for channel, senders := range cfg.Tools.Elevated.AllowFrom {
+		add(ListElevated, channel, senders)
+	}
+	for channel, policy := range dmPolicies(cfg) {
+		add(ListPairing, channel, policy.AllowFrom)
+	}
+	sortGrants(grants)
+	return grants
+}
+
+type exportAllowFrom struct {
+	AllowFrom map[string][]string `yaml:"allow_from"`
+}
+
+type exportPolicy struct {
+	DM struct {
+		AllowFrom []string `yaml:"allow_from"`
+	} `yaml:"dm"`
+}
+
+type exportTools struct {
+	Elevated exportAllowFrom `yaml:"elevated"`
+}
+
+type exportConfig struct {
+	Commands *exportAllowFrom         `yaml:"commands,omitempty"`
+	Tools    *exportTools             `yaml:"tools,omitempty"`
+	Channels map[string]*exportPolicy `yaml:"channels,omitempty"`
+}
+
+// ExportConfig renders grants as a config fragment with the same shape
+// FromConfig reads, so a store can be snapshotted back into YAML. Grants
+// that expire, and pairing grants on channels without a DM policy, cannot
+// be expressed in config; they are returned as skipped.
+func ExportConfig(grants []Grant) ([]byte, []Grant, error) {
+	known := dmPolicies(&config.Config{})
+	var skipped []Grant
+	sorted := append([]Grant(nil), grants...)
+	sortGrants(sorted)
+	var out exportConfig
+	for _, grant := range sorted {
+		if !grant.ExpiresAt.IsZero() {
+			skipped = append(skipped, grant)
+			continue
+		}
+		switch grant.List {
+		case ListCommands:
+			if out.Commands == nil {
+				out.Commands = &exportAllowFrom{AllowFrom: map[string][]string{}}
+			}
+			out.Commands.AllowFrom[grant.Channel] = append(out.Commands.AllowFrom[grant.Channel], grant.Sender)
+		case ListElevated:
+			if out.Tools == nil {
+				out.Tools = &exportTools{Elevated: exportAllowFrom{AllowFrom: map[string][]string{}}}
+			}
+			allow := out.Tools.Elevated.AllowFrom
+			allow[grant.Channel] = append(allow[grant.Channel], grant.Sender)
+		case ListPairing:
+			if _, ok := known[grant.Channel]; !ok {
+				skipped = append(skipped, grant)
+				continue
+			}
+			if out.Channels == nil {
+				out.Channels = map[string]*exportPolicy{}
+			}
+			key := configKey(grant.Channel)
+			if out.Channels[key] == nil {
+				out.Channels[key] = &exportPolicy{}
+			}
+			out.Channels[key].DM.AllowFrom = append(out.Channels[key].DM.AllowFrom, grant.Sender)
+		default:
+			skipped = append(skipped, grant)
+		}
+	}
+	if out.Commands == nil && out.Tools == nil && out.Channels == nil {
+		return nil, skipped, nil
+	}
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(&out); err != nil {
+		return nil, skipped, err
+	}
+	if err := enc.Close(); err != nil {
+		return nil, skipped, err
+	}
+	return buf.Bytes(), skipped, nil
+}
@@ -0,0 +1,80 @@
+package access
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// DefaultCacheTTL bounds how long a node serves grants without rereading
+// the store, which also bounds how stale it is if an invalidation is lost.
+const DefaultCacheTTL = 30 * time.Second
+
+// Cache wraps a Store and keeps every grant in memory so access checks on
+// the message path do not query the database. Writes through the cache
+// invalidate it.
+type Cache struct {
+	store Store
+	ttl   time.Duration
+
+	mu       sync.Mutex
+	grants   []Grant
+	loadedAt time.Time
+}
+
+// NewCache creates a cache over store. A non-positive ttl uses
+// DefaultCacheTTL.
+func NewCache(store Store, ttl time.Duration) *Cache {
+	if ttl <= 0 {
+		ttl = DefaultCacheTTL
+	}
+	return &Cache{store: store, ttl: ttl}
+}
+
+// Grants returns the unexpired grants of list, reloading the store when the
+// cache is stale.
+func (c *Cache) Grants(ctx context.Context, list List) ([]Grant, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := time.Now()
+	if c.loadedAt.IsZero() || now.Sub(c.loadedAt) >= c.ttl {
+		grants, err := c.store.List(ctx, "")
+		if err != nil {
+			return nil, err
+		}
+		c.grants = grants
+		c.loadedAt = now
+	}
+	var out []Grant
+	for _, grant := range c.grants {
+		if grant.List == list && !grant.Expired(now) {
+			out = append(out, grant)
+		}
+	}
+	return out, nil
+}
+
+// Invalidate drops the cached grants so the next check rereads the store.
+func (c *Cache) Invalidate() {
+	c.mu.Lock()
+	c.loadedAt = time.Time{}
+	c.grants = nil
+	c.mu.Unlock()
+}
+
+// Put stores the grant and invalidates the cache.
+func (c *Cache) Put(ctx context.Context, grant Grant) error {
+	defer c.Invalidate()
+	return c.store.Put(ctx, grant)
+}
+
+// Delete revokes the grant and invalidates the cache.
+func (c *Cache) Delete(ctx context.Context, list List, channel, sender string) (bool, error) {
+	defer c.Invalidate()
+	return c.store.Delete(ctx, list, channel, sender)
+}
+
+// List reads grants straight from the store.
+func (c *Cache) List(ctx context.Context, list List) ([]Grant, error) {
+	return c.store.List(ctx, list)
+}
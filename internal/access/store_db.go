@@ -0,0 +1,99 @@
+package access
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+)
+
+// DBStore implements Store using the access_grants table, so grants are
+// shared by every gateway node and survive restarts.
+type DBStore struct {
+	db *sql.DB
+}
+
+// NewDBStore creates a DB-backed grant store.
+func NewDBStore(db *sql.DB) (*DBStore, error) {
+	if db == nil {
+		return nil, errors.New("db is required")
+	}
+	return &DBStore{db: db}, nil
+}
+
+// Put upserts the grant row.
+func (s *DBStore) Put(ctx context.Context, grant Grant) error {
+	grant = grant.Normalize()
+	if err := grant.validate(); err != nil {
+		return err
+	}
+	if grant.CreatedAt.IsZero() {
+		grant.CreatedAt = time.Now()
+	}
+	var expiresAt sql.NullTime
+	if !grant.ExpiresAt.IsZero() {
+		expiresAt = sql.NullTime{Time: grant.ExpiresAt.UTC(), Valid: true}
+	}
+	_, err := s.db.ExecContext(ctx, `
+		INSERT INTO access_grants (list, channel, sender, expires_at, granted_by, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (list, channel, sender) DO UPDATE
+		SET expires_at = EXCLUDED.expires_at,
+			granted_by = EXCLUDED.granted_by,
+			created_at = EXCLUDED.created_at
+	`, string(grant.List), grant.Channel, grant.Sender, expiresAt, grant.GrantedBy, grant.CreatedAt.UTC())
+	return err
+}
+
+// Delete removes the grant row.
+func (s *DBStore) Delete(ctx context.Context, list List, channel, sender string) (bool, error) {
+	result, err := s.db.ExecContext(ctx, `
+		DELETE FROM access_grants WHERE list = $1 AND channel = $2 AND sender = $3
+	`, string(list), normalizeChannel(channel), NormalizeSender(sender))
+	if err != nil {
+		return false, err
+	}
+	n, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return n > 0, nil
+}
+
+// List returns the unexpired grant rows of list.
+func (s *DBStore) List(ctx context.Context, list List) ([]Grant, error) {
+	query := `
+		SELECT list, channel, sender, expires_at, granted_by, created_at
+		FROM access_grants
+		WHERE (expires_at IS NULL OR expires_at > $1)`
+	args := []any{time.Now().UTC()}
+	if list != "" {
+		query += ` AND list = $2`
+		args = append(args, string(list))
+	}
+	query += ` ORDER BY list, channel, sender`
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var grants []Grant
+	for rows.Next() {
+		var (
+			grant     Grant
+			name      string
+			expiresAt sql.NullTime
+		)
+		if err := rows.Scan(&name, &grant.Channel, &grant.Sender, &expiresAt, &grant.GrantedBy, &grant.CreatedAt); err != nil {
+			return nil, err
+		}
+		grant.List = List(name)
+		if expiresAt.Valid {
+			grant.ExpiresAt = expiresAt.Time
+		}
+		grants = append(grants, grant)
+	}
+	return grants, rows.Err()
+}
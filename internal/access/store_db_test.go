@@ -0,0 +1,75 @@
+package access
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/haasonsaas/nexus/internal/sessions"
+)
+
+func newTestDBStore(t *testing.T) *DBStore {
+	t.Helper()
+	db, err := sessions.OpenSQLite(context.Background(), "sqlite://"+filepath.Join(t.TempDir(), "nexus.db"))
+	if err != nil {
+		t.Fatalf("OpenSQLite() error = %v", err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+	store, err := NewDBStore(db)
+	if err != nil {
+		t.Fatalf("NewDBStore() error = %v", err)
+	}
+	return store
+}
+
+func TestDBStore(t *testing.T) {
+	ctx := context.Background()
+	store := newTestDBStore(t)
+	now := time.Now()
+
+	grants := []Grant{
+		{List: ListCommands, Channel: "Slack", Sender: "<@U1>", GrantedBy: "slack:admin"},
+		{List: ListElevated, Channel: "slack", Sender: "u1", ExpiresAt: now.Add(time.Hour)},
+		{List: ListElevated, Channel: "slack", Sender: "u2", ExpiresAt: now.Add(-time.Minute)},
+	}
+	for _, grant := range grants {
+		if err := store.Put(ctx, grant); err != nil {
+			t.Fatalf("Put() error = %v", err)
+		}
+	}
+	if err := store.Put(ctx, Grant{List: "sudo", Channel: "slack", Sender: "u1"}); err == nil {
+		t.Fatal("expected error for an unknown list")
+	}
+
+	all, err := store.List(ctx, "")
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if len(all) != 2 {
+		t.Fatalf("expected expired grants to be hidden, got %+v", all)
+	}
+	if all[0].List != ListCommands || all[0].Subject() != "slack:u1" || all[0].GrantedBy != "slack:admin" || !all[0].ExpiresAt.IsZero() {
+		t.Fatalf("unexpected commands grant: %+v", all[0])
+	}
+	if all[1].ExpiresAt.Sub(now.Add(time.Hour)).Abs() > time.Second {
+		t.Fatalf("unexpected expiry: %v", all[1].ExpiresAt)
+	}
+
+	// Granting again replaces the expiry.
+	if err := store.Put(ctx, Grant{List: ListElevated, Channel: "slack", Sender: "u1"}); err != nil {
+		t.Fatal(err)
+	}
+	elevated, err := store.List(ctx, ListElevated)
+	if err != nil || len(elevated) != 1 || !elevated[0].ExpiresAt.IsZero() {
+		t.Fatalf("List(elevated) = %+v, %v", elevated, err)
+	}
+
+	removed, err := store.Delete(ctx, ListCommands, "slack", "@u1")
+	if err != nil || !removed {
+		t.Fatalf("Delete() = %v, %v", removed, err)
+	}
+	if removed, _ := store.Delete(ctx, ListCommands, "slack", "u1"); removed {
+		t.Fatal("expected second delete to report nothing removed")
+	}
+}
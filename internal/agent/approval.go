@@ -2,7 +2,9 @@ package agent
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"log/slog"
 	"strings"
 	"sync"
 	"time"
@@ -25,18 +27,60 @@ const (
 
 // ApprovalRequest represents a pending approval request for a tool call that requires user authorization.
 type ApprovalRequest struct {
-	ID         string           `json:"id"`
-	ToolCallID string           `json:"tool_call_id"`
-	ToolName   string           `json:"tool_name"`
-	Input      []byte           `json:"input,omitempty"`
-	AgentID    string           `json:"agent_id,omitempty"`
-	SessionID  string           `json:"session_id,omitempty"`
-	Reason     string           `json:"reason,omitempty"`
-	CreatedAt  time.Time        `json:"created_at"`
-	ExpiresAt  time.Time        `json:"expires_at,omitempty"`
-	Decision   ApprovalDecision `json:"decision"`
-	DecidedAt  time.Time        `json:"decided_at,omitempty"`
-	DecidedBy  string           `json:"decided_by,omitempty"`
+	ID         string `json:"id"`
+	ToolCallID string `json:"tool_call_id"`
+	ToolName   string `json:"tool_name"`
+	Input      []byte `json:"input,omitempty"`
+	AgentID    string `json:"agent_id,omitempty"`
+	SessionID  string `json:"session_id,omitempty"`
+	Reason     string `json:"reason,omitempty"`
+	// Diff previews the change the call would make, as a unified diff, for
+	// tools that implement ChangePreviewer.
+	Diff      string           `json:"diff,omitempty"`
+	CreatedAt time.Time        `json:"created_at"`
+	ExpiresAt time.Time        `json:"expires_at,omitempty"`
+	Decision  ApprovalDecision `json:"decision"`
+	DecidedAt time.Time        `json:"decided_at,omitempty"`
+	DecidedBy string           `json:"decided_by,omitempty"`
+}
+
+// ChangePreviewer is implemented by tools that modify files or documents.
+// PreviewChange returns a unified diff of what a call with params would
+// change, without changing anything, so an approver can see it before
+// allowing the call. It returns "" when the call would change nothing.
+type ChangePreviewer interface {
+	PreviewChange(ctx context.Context, params json.RawMessage) (string, error)
+}
+
+// MaxChangePreviewSize bounds the diff attached to an approval request.
+const MaxChangePreviewSize = 64 << 10
+
+// previewToolChange returns the diff a pending tool call would apply, or ""
+// when the tool cannot preview it.
+func previewToolChange(ctx context.Context, registry *ToolRegistry, toolCall models.ToolCall, logger *slog.Logger) string {
+	if registry == nil {
+		return ""
+	}
+	tool, ok := registry.Get(toolCall.Name)
+	if !ok {
+		return ""
+	}
+	previewer, ok := tool.(ChangePreviewer)
+	if !ok {
+		return ""
+	}
+	diff, err := previewer.PreviewChange(ctx, toolCall.Input)
+	if err != nil {
+		if logger != nil {
+			logger.Debug("failed to preview tool change", "tool", toolCall.Name, "tool_call_id", toolCall.ID, "error", err)
+		}
+		return ""
+	}
+	if len(diff) > MaxChangePreviewSize {
+		cut := strings.LastIndexByte(diff[:MaxChangePreviewSize], '\n') + 1
+		diff = diff[:cut] + fmt.Sprintf("... diff truncated (%d bytes)\n", len(diff))
+	}
+	return diff
 }
 
 // ApprovalPolicy configures approval behavior for tool execution including
@@ -91,6 +135,8 @@ type ApprovalChecker struct {
 	skillTools    map[string]struct{} // tools provided by skills
 	pendingStore  ApprovalStore
 	uiAvailable   func() bool // callback to check if UI can handle approvals
+	onDecision    func(ctx context.Context, req *ApprovalRequest)
+	onRequest     func(ctx context.Context, req *ApprovalRequest)
 }
 
 // ApprovalStore persists pending approval requests for tools requiring user authorization.
@@ -127,6 +173,22 @@ func (c *ApprovalChecker) SetUIAvailableCheck(fn func() bool) {
 	c.uiAvailable = fn
 }
 
+// SetDecisionListener sets a callback invoked after Approve or Deny records a
+// decision, e.g. to propagate it to other gateway nodes.
+func (c *ApprovalChecker) SetDecisionListener(fn func(ctx context.Context, req *ApprovalRequest)) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.onDecision = fn
+}
+
+// SetRequestListener sets a callback invoked after a pending approval request
+// is created.
+func (c *ApprovalChecker) SetRequestListener(fn func(ctx context.Context, req *ApprovalRequest)) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.onRequest = fn
+}
+
 // SetAgentPolicy sets a custom approval policy for a specific agent, overriding the default.
 func (c *ApprovalChecker) SetAgentPolicy(agentID string, policy *ApprovalPolicy) {
 	c.mu.Lock()
@@ -210,12 +272,19 @@ func (c *ApprovalChecker) Check(ctx context.Context, agentID string, toolCall mo
 
 // CreateApprovalRequest creates and persists a pending approval request for a tool call.
 func (c *ApprovalChecker) CreateApprovalRequest(ctx context.Context, agentID, sessionID string, toolCall models.ToolCall, reason string) (*ApprovalRequest, error) {
+	return c.CreateApprovalRequestWithDiff(ctx, agentID, sessionID, toolCall, reason, "")
+}
+
+// CreateApprovalRequestWithDiff creates and persists a pending approval
+// request that shows the approver diff, the change the call would make.
+func (c *ApprovalChecker) CreateApprovalRequestWithDiff(ctx context.Context, agentID, sessionID string, toolCall models.ToolCall, reason, diff string) (*ApprovalRequest, error) {
 	c.mu.RLock()
 	policy := c.agentPolicies[agentID]
 	if policy == nil {
 		policy = c.defaultPolicy
 	}
 	store := c.pendingStore
+	onRequest := c.onRequest
 	c.mu.RUnlock()
 
 	ttl := policy.RequestTTL
@@ -231,6 +300,7 @@ func (c *ApprovalChecker) CreateApprovalRequest(ctx context.Context, agentID, se
 		AgentID:    agentID,
 		SessionID:  sessionID,
 		Reason:     reason,
+		Diff:       diff,
 		CreatedAt:  time.Now(),
 		ExpiresAt:  time.Now().Add(ttl),
 		Decision:   ApprovalPending,
@@ -241,41 +311,36 @@ func (c *ApprovalChecker) CreateApprovalRequest(ctx context.Context, agentID, se
 			return nil, err
 		}
 	}
+	if onRequest != nil {
+		onRequest(ctx, req)
+	}
 
 	return req, nil
 }
 
 // Approve approves a pending approval request, allowing the tool call to proceed.
 func (c *ApprovalChecker) Approve(ctx context.Context, requestID, decidedBy string) error {
-	c.mu.RLock()
-	store := c.pendingStore
-	c.mu.RUnlock()
-
-	if store == nil {
-		return nil
-	}
+	return c.decide(ctx, requestID, ApprovalAllowed, decidedBy, time.Now(), true)
+}
 
-	req, err := store.Get(ctx, requestID)
-	if err != nil {
-		return fmt.Errorf("get approval request %q: %w", requestID, err)
-	}
-	if req == nil {
-		return nil
-	}
+// Deny denies a pending approval request, preventing the tool call from executing.
+func (c *ApprovalChecker) Deny(ctx context.Context, requestID, decidedBy string) error {
+	return c.decide(ctx, requestID, ApprovalDenied, decidedBy, time.Now(), true)
+}
 
-	req.Decision = ApprovalAllowed
-	req.DecidedAt = time.Now()
-	req.DecidedBy = decidedBy
-	if err := store.Update(ctx, req); err != nil {
-		return fmt.Errorf("update approval request %q: %w", requestID, err)
+// ApplyDecision records a decision made elsewhere (such as another gateway
+// node) without notifying the decision listener. Unknown requests are ignored.
+func (c *ApprovalChecker) ApplyDecision(ctx context.Context, requestID string, decision ApprovalDecision, decidedBy string, decidedAt time.Time) error {
+	if decidedAt.IsZero() {
+		decidedAt = time.Now()
 	}
-	return nil
+	return c.decide(ctx, requestID, decision, decidedBy, decidedAt, false)
 }
 
-// Deny denies a pending approval request, preventing the tool call from executing.
-func (c *ApprovalChecker) Deny(ctx context.Context, requestID, decidedBy string) error {
+func (c *ApprovalChecker) decide(ctx context.Context, requestID string, decision ApprovalDecision, decidedBy string, decidedAt time.Time, notify bool) error {
 	c.mu.RLock()
 	store := c.pendingStore
+	onDecision := c.onDecision
 	c.mu.RUnlock()
 
 	if store == nil {
@@ -290,12 +355,15 @@ func (c *ApprovalChecker) Deny(ctx context.Context, requestID, decidedBy string)
 		return nil
 	}
 
-	req.Decision = ApprovalDenied
-	req.DecidedAt = time.Now()
+	req.Decision = decision
+	req.DecidedAt = decidedAt
 	req.DecidedBy = decidedBy
 	if err := store.Update(ctx, req); err != nil {
 		return fmt.Errorf("update approval request %q: %w", requestID, err)
 	}
+	if notify && onDecision != nil {
+		onDecision(ctx, req)
+	}
 	return nil
 }
 
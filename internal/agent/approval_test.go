@@ -2,6 +2,8 @@ package agent
 
 import (
 	"context"
+	"encoding/json"
+	"strings"
 	"testing"
 	"time"
 
@@ -364,6 +366,66 @@ func TestApprovalChecker_Deny(t *testing.T) {
 	}
 }
 
+func TestApprovalChecker_DecisionListener(t *testing.T) {
+	store := NewMemoryApprovalStore()
+	checker := NewApprovalChecker(&ApprovalPolicy{
+		RequireApproval: []string{"risky_tool"},
+	})
+	checker.SetStore(store)
+
+	var notified []*ApprovalRequest
+	checker.SetDecisionListener(func(ctx context.Context, req *ApprovalRequest) {
+		notified = append(notified, req)
+	})
+
+	ctx := context.Background()
+	req, err := checker.CreateApprovalRequest(ctx, "agent-1", "session-1", models.ToolCall{ID: "call-1", Name: "risky_tool"}, "needs approval")
+	if err != nil {
+		t.Fatalf("create request: %v", err)
+	}
+
+	if err := checker.Approve(ctx, req.ID, "admin"); err != nil {
+		t.Fatalf("approve: %v", err)
+	}
+	if len(notified) != 1 || notified[0].Decision != ApprovalAllowed {
+		t.Fatalf("notified = %+v, want one allowed decision", notified)
+	}
+
+	// Decisions applied from elsewhere are recorded but not re-broadcast.
+	if err := checker.ApplyDecision(ctx, req.ID, ApprovalDenied, "remote", time.Time{}); err != nil {
+		t.Fatalf("apply decision: %v", err)
+	}
+	if len(notified) != 1 {
+		t.Fatalf("ApplyDecision notified listener")
+	}
+	applied, _ := store.Get(ctx, req.ID)
+	if applied.Decision != ApprovalDenied || applied.DecidedBy != "remote" {
+		t.Fatalf("applied = %+v", applied)
+	}
+
+	if err := checker.ApplyDecision(ctx, "unknown", ApprovalAllowed, "remote", time.Time{}); err != nil {
+		t.Fatalf("apply decision for unknown request: %v", err)
+	}
+}
+
+func TestApprovalChecker_RequestListener(t *testing.T) {
+	checker := NewApprovalChecker(nil)
+	checker.SetStore(NewMemoryApprovalStore())
+
+	var requested []*ApprovalRequest
+	checker.SetRequestListener(func(ctx context.Context, req *ApprovalRequest) {
+		requested = append(requested, req)
+	})
+
+	req, err := checker.CreateApprovalRequest(context.Background(), "agent-1", "session-1", models.ToolCall{ID: "call-1", Name: "risky_tool"}, "needs approval")
+	if err != nil {
+		t.Fatalf("create request: %v", err)
+	}
+	if len(requested) != 1 || requested[0] != req || requested[0].Decision != ApprovalPending {
+		t.Fatalf("requested = %+v, want the pending request", requested)
+	}
+}
+
 func TestApprovalChecker_IsUIAvailable(t *testing.T) {
 	checker := NewApprovalChecker(nil)
 
@@ -643,3 +705,41 @@ func TestApprovalChecker_ZeroTTL(t *testing.T) {
 		t.Errorf("ExpiresAt not within expected range for zero TTL: %v", req.ExpiresAt)
 	}
 }
+
+type previewTool struct {
+	mockTool
+	diff string
+}
+
+func (p *previewTool) PreviewChange(ctx context.Context, params json.RawMessage) (string, error) {
+	return p.diff, nil
+}
+
+func TestPreviewToolChange(t *testing.T) {
+	registry := NewToolRegistry()
+	registry.Register(&previewTool{mockTool: mockTool{name: "write"}, diff: "--- a/f\n+++ b/f\n"})
+	registry.Register(&previewTool{mockTool: mockTool{name: "huge"}, diff: strings.Repeat("+line\n", MaxChangePreviewSize)})
+	registry.Register(&mockTool{name: "read"})
+	ctx := context.Background()
+
+	if got := previewToolChange(ctx, registry, models.ToolCall{Name: "write"}, nil); got != "--- a/f\n+++ b/f\n" {
+		t.Fatalf("unexpected diff %q", got)
+	}
+	if got := previewToolChange(ctx, registry, models.ToolCall{Name: "read"}, nil); got != "" {
+		t.Fatalf("expected no diff for tool without preview, got %q", got)
+	}
+	got := previewToolChange(ctx, registry, models.ToolCall{Name: "huge"}, nil)
+	if len(got) > MaxChangePreviewSize+64 || !strings.Contains(got, "diff truncated") {
+		t.Fatalf("expected truncated diff, got %d bytes", len(got))
+	}
+
+	checker := NewApprovalChecker(DefaultApprovalPolicy())
+	checker.SetStore(NewMemoryApprovalStore())
+	req, err := checker.CreateApprovalRequestWithDiff(ctx, "agent-1", "session-1", models.ToolCall{ID: "call-1", Name: "write"}, "requires approval", "--- a/f\n")
+	if err != nil {
+		t.Fatalf("create request: %v", err)
+	}
+	if req.Diff != "--- a/f\n" {
+		t.Fatalf("expected diff on request, got %q", req.Diff)
+	}
+}
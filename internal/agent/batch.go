@@ -0,0 +1,348 @@
+package agent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/haasonsaas/nexus/pkg/models"
+)
+
+// BatchRequest is one request of a provider batch.
+type BatchRequest struct {
+	// CustomID identifies the request's result within the batch.
+	CustomID string
+	Request  *CompletionRequest
+}
+
+// BatchResult is the outcome of one batched request.
+type BatchResult struct {
+	CustomID     string
+	Text         string
+	ToolCalls    []models.ToolCall
+	InputTokens  int
+	OutputTokens int
+
+	// Err is set when the request failed, expired, or was canceled.
+	Err error
+}
+
+// BatchStatus reports the progress of a submitted batch.
+type BatchStatus struct {
+	ID string
+
+	// Done is set once the batch has ended; Results then holds one result
+	// per request.
+	Done    bool
+	Results []BatchResult
+}
+
+// BatchProvider is implemented by providers with an asynchronous batch API,
+// such as OpenAI Batch or Anthropic Message Batches. Batched requests finish
+// within hours rather than seconds, at about half the price.
+type BatchProvider interface {
+	// SubmitBatch submits requests as one batch and returns its ID.
+	SubmitBatch(ctx context.Context, requests []BatchRequest) (string, error)
+
+	// PollBatch returns the status of a submitted batch.
+	PollBatch(ctx context.Context, id string) (*BatchStatus, error)
+}
+
+// BatchQueueConfig configures a BatchQueue.
+type BatchQueueConfig struct {
+	// MaxBatchSize submits a batch as soon as this many requests are
+	// queued. Default: 1000.
+	MaxBatchSize int
+
+	// FlushInterval is how often queued requests are submitted as a batch.
+	// Default: 1m.
+	FlushInterval time.Duration
+
+	// PollInterval is how often submitted batches are checked for results.
+	// Default: 30s.
+	PollInterval time.Duration
+
+	// OnSubmit, when set, is called after each batch is submitted.
+	OnSubmit func(id string, requests int)
+
+	// OnComplete, when set, is called once a batch has ended and its
+	// results were handed back.
+	OnComplete func(id string, results int, elapsed time.Duration)
+
+	// OnError, when set, is called when submitting or polling fails.
+	OnError func(err error)
+}
+
+// BatchQueue collects background completion requests and sends them
+// through a provider's batch API. It implements LLMProvider: Complete queues
+// the request and its stream yields the result once the batch has ended, so
+// digests, consolidation, and eval runs can use it in place of the provider
+// when they can wait. Run must be running for requests to be submitted.
+type BatchQueue struct {
+	LLMProvider
+	batches BatchProvider
+	config  BatchQueueConfig
+
+	mu       sync.Mutex
+	pending  []*batchItem
+	inflight map[string]*submittedBatch
+	seq      uint64
+	wake     chan struct{}
+}
+
+type batchItem struct {
+	id   string
+	req  *CompletionRequest
+	done chan BatchResult
+}
+
+type submittedBatch struct {
+	items     map[string]*batchItem
+	submitted time.Time
+}
+
+// NewBatchQueue creates a queue for provider, which must implement
+// BatchProvider.
+func NewBatchQueue(provider LLMProvider, cfg BatchQueueConfig) (*BatchQueue, error) {
+	batches, ok := provider.(BatchProvider)
+	if !ok {
+		return nil, fmt.Errorf("provider %q does not support batches", provider.Name())
+	}
+	if cfg.MaxBatchSize <= 0 {
+		cfg.MaxBatchSize = 1000
+	}
+	if cfg.FlushInterval <= 0 {
+		cfg.FlushInterval = time.Minute
+	}
+	if cfg.PollInterval <= 0 {
+		cfg.PollInterval = 30 * time.Second
+	}
+	return &BatchQueue{
+		LLMProvider: provider,
+		batches:     batches,
+		config:      cfg,
+		inflight:    make(map[string]*submittedBatch),
+		wake:        make(chan struct{}, 1),
+	}, nil
+}
+
+// Complete queues req for the next batch. The returned stream yields the
+// result once the batch has ended, or an error if ctx ends first.
+func (q *BatchQueue) Complete(ctx context.Context, req *CompletionRequest) (<-chan *CompletionChunk, error) {
+	if req == nil {
+		return nil, errors.New("request is nil")
+	}
+	q.mu.Lock()
+	q.seq++
+	item := &batchItem{id: fmt.Sprintf("req-%d", q.seq), req: req, done: make(chan BatchResult, 1)}
+	q.pending = append(q.pending, item)
+	full := len(q.pending) >= q.config.MaxBatchSize
+	q.mu.Unlock()
+	if full {
+		select {
+		case q.wake <- struct{}{}:
+		default:
+		}
+	}
+
+	out := make(chan *CompletionChunk)
+	go func() {
+		defer close(out)
+		select {
+		case result := <-item.done:
+			for _, chunk := range batchResultChunks(result) {
+				select {
+				case out <- chunk:
+				case <-ctx.Done():
+					return
+				}
+			}
+		case <-ctx.Done():
+			q.cancel(item)
+			out <- &CompletionChunk{Error: ctx.Err()}
+		}
+	}()
+	return out, nil
+}
+
+// Run submits queued requests and polls submitted batches until ctx ends.
+// Requests still queued or in flight then fail with the context's error.
+func (q *BatchQueue) Run(ctx context.Context) {
+	flush := time.NewTicker(q.config.FlushInterval)
+	defer flush.Stop()
+	poll := time.NewTicker(q.config.PollInterval)
+	defer poll.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			q.stop(ctx.Err())
+			return
+		case <-q.wake:
+			q.flush(ctx)
+		case <-flush.C:
+			q.flush(ctx)
+		case <-poll.C:
+			q.poll(ctx)
+		}
+	}
+}
+
+// Pending returns how many requests are queued and how many are in
+// submitted batches.
+func (q *BatchQueue) Pending() (queued, submitted int) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	for _, batch := range q.inflight {
+		submitted += len(batch.items)
+	}
+	return len(q.pending), submitted
+}
+
+// flush submits every queued request, MaxBatchSize at a time.
+func (q *BatchQueue) flush(ctx context.Context) {
+	for {
+		q.mu.Lock()
+		n := min(len(q.pending), q.config.MaxBatchSize)
+		items := q.pending[:n:n]
+		q.pending = q.pending[n:]
+		q.mu.Unlock()
+		if len(items) == 0 {
+			return
+		}
+
+		requests := make([]BatchRequest, 0, len(items))
+		for _, item := range items {
+			requests = append(requests, BatchRequest{CustomID: item.id, Request: item.req})
+		}
+		id, err := q.batches.SubmitBatch(ctx, requests)
+		if err != nil {
+			err = fmt.Errorf("submit batch: %w", err)
+			for _, item := range items {
+				item.done <- BatchResult{CustomID: item.id, Err: err}
+			}
+			q.reportError(err)
+			continue
+		}
+
+		batch := &submittedBatch{items: make(map[string]*batchItem, len(items)), submitted: time.Now()}
+		for _, item := range items {
+			batch.items[item.id] = item
+		}
+		q.mu.Lock()
+		q.inflight[id] = batch
+		q.mu.Unlock()
+		if q.config.OnSubmit != nil {
+			q.config.OnSubmit(id, len(items))
+		}
+	}
+}
+
+// poll hands back the results of every batch that has ended.
+func (q *BatchQueue) poll(ctx context.Context) {
+	q.mu.Lock()
+	ids := make([]string, 0, len(q.inflight))
+	for id := range q.inflight {
+		ids = append(ids, id)
+	}
+	q.mu.Unlock()
+
+	for _, id := range ids {
+		status, err := q.batches.PollBatch(ctx, id)
+		if err != nil {
+			q.reportError(fmt.Errorf("poll batch %s: %w", id, err))
+			continue
+		}
+		if status == nil || !status.Done {
+			continue
+		}
+
+		q.mu.Lock()
+		batch := q.inflight[id]
+		delete(q.inflight, id)
+		q.mu.Unlock()
+		if batch == nil {
+			continue
+		}
+		for _, result := range status.Results {
+			q.mu.Lock()
+			item := batch.items[result.CustomID]
+			delete(batch.items, result.CustomID)
+			q.mu.Unlock()
+			if item != nil {
+				item.done <- result
+			}
+		}
+		q.mu.Lock()
+		missing := batch.items
+		batch.items = nil
+		q.mu.Unlock()
+		for _, item := range missing {
+			item.done <- BatchResult{CustomID: item.id, Err: fmt.Errorf("batch %s returned no result for %s", id, item.id)}
+		}
+		if q.config.OnComplete != nil {
+			q.config.OnComplete(id, len(status.Results), time.Since(batch.submitted))
+		}
+	}
+}
+
+// cancel drops item, whose caller stopped waiting. A submitted request
+// still runs; its result is discarded.
+func (q *BatchQueue) cancel(item *batchItem) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	for i, queued := range q.pending {
+		if queued == item {
+			q.pending = append(q.pending[:i], q.pending[i+1:]...)
+			return
+		}
+	}
+	for _, batch := range q.inflight {
+		delete(batch.items, item.id)
+	}
+}
+
+// stop fails every queued and in-flight request with err.
+func (q *BatchQueue) stop(err error) {
+	q.mu.Lock()
+	items := q.pending
+	q.pending = nil
+	for _, batch := range q.inflight {
+		for _, item := range batch.items {
+			items = append(items, item)
+		}
+	}
+	q.inflight = make(map[string]*submittedBatch)
+	q.mu.Unlock()
+	for _, item := range items {
+		item.done <- BatchResult{CustomID: item.id, Err: err}
+	}
+}
+
+func (q *BatchQueue) reportError(err error) {
+	if q.config.OnError != nil {
+		q.config.OnError(err)
+	}
+}
+
+// batchResultChunks converts a batch result into the chunks a streaming
+// completion would have produced.
+func batchResultChunks(result BatchResult) []*CompletionChunk {
+	if result.Err != nil {
+		return []*CompletionChunk{{Error: result.Err}}
+	}
+	var chunks []*CompletionChunk
+	if result.Text != "" {
+		chunks = append(chunks, &CompletionChunk{Text: result.Text})
+	}
+	for i := range result.ToolCalls {
+		chunks = append(chunks, &CompletionChunk{ToolCall: &result.ToolCalls[i]})
+	}
+	return append(chunks, &CompletionChunk{
+		Done:         true,
+		InputTokens:  result.InputTokens,
+		OutputTokens: result.OutputTokens,
+	})
+}
@@ -0,0 +1,144 @@
+package agent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeBatchProvider answers each request with "echo: <content>" once
+// PollBatch has been called twice for its batch.
+type fakeBatchProvider struct {
+	successProvider
+
+	mu      sync.Mutex
+	batches map[string][]BatchRequest
+	polls   map[string]int
+	fail    error
+}
+
+func (p *fakeBatchProvider) SubmitBatch(_ context.Context, requests []BatchRequest) (string, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.fail != nil {
+		return "", p.fail
+	}
+	id := fmt.Sprintf("batch-%d", len(p.batches)+1)
+	p.batches[id] = requests
+	return id, nil
+}
+
+func (p *fakeBatchProvider) PollBatch(_ context.Context, id string) (*BatchStatus, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.polls[id]++
+	status := &BatchStatus{ID: id}
+	if p.polls[id] < 2 {
+		return status, nil
+	}
+	status.Done = true
+	for _, req := range p.batches[id] {
+		status.Results = append(status.Results, BatchResult{
+			CustomID:     req.CustomID,
+			Text:         "echo: " + req.Request.Messages[0].Content,
+			InputTokens:  10,
+			OutputTokens: 2,
+		})
+	}
+	return status, nil
+}
+
+func TestBatchQueueRoundTrip(t *testing.T) {
+	provider := &fakeBatchProvider{successProvider: successProvider{name: "anthropic"}, batches: map[string][]BatchRequest{}, polls: map[string]int{}}
+	var submitted []int
+	queue, err := NewBatchQueue(provider, BatchQueueConfig{
+		MaxBatchSize:  2,
+		FlushInterval: time.Hour,
+		PollInterval:  5 * time.Millisecond,
+		OnSubmit:      func(id string, n int) { submitted = append(submitted, n) },
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go queue.Run(ctx)
+
+	var wg sync.WaitGroup
+	texts := make([]string, 2)
+	for i := range texts {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			stream, err := queue.Complete(ctx, &CompletionRequest{Messages: []CompletionMessage{{Role: "user", Content: fmt.Sprint(i)}}})
+			if err != nil {
+				t.Errorf("Complete() error = %v", err)
+				return
+			}
+			texts[i], err = collectChunks(t, stream)
+			if err != nil {
+				t.Errorf("stream error = %v", err)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	if texts[0] != "echo: 0" || texts[1] != "echo: 1" {
+		t.Errorf("texts = %q", texts)
+	}
+	// A full queue is submitted at once instead of waiting for the flush.
+	if len(submitted) != 1 || submitted[0] != 2 {
+		t.Errorf("submitted = %v", submitted)
+	}
+	if queued, inflight := queue.Pending(); queued != 0 || inflight != 0 {
+		t.Errorf("Pending() = %d, %d", queued, inflight)
+	}
+}
+
+func TestBatchQueueFailsRequestsOnSubmitError(t *testing.T) {
+	provider := &fakeBatchProvider{successProvider: successProvider{name: "openai"}, fail: errors.New("quota exceeded")}
+	queue, err := NewBatchQueue(provider, BatchQueueConfig{FlushInterval: 5 * time.Millisecond})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go queue.Run(ctx)
+
+	stream, err := queue.Complete(ctx, &CompletionRequest{Messages: []CompletionMessage{{Role: "user", Content: "hi"}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := collectChunks(t, stream); err == nil {
+		t.Fatal("expected the submit error")
+	}
+}
+
+func TestBatchQueueRequiresBatchProvider(t *testing.T) {
+	if _, err := NewBatchQueue(&successProvider{name: "google"}, BatchQueueConfig{}); err == nil {
+		t.Fatal("expected an error for a provider without a batch API")
+	}
+}
+
+func TestBatchQueueDropsCanceledRequests(t *testing.T) {
+	provider := &fakeBatchProvider{successProvider: successProvider{name: "openai"}, batches: map[string][]BatchRequest{}, polls: map[string]int{}}
+	queue, err := NewBatchQueue(provider, BatchQueueConfig{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	stream, err := queue.Complete(ctx, &CompletionRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	cancel()
+	if _, err := collectChunks(t, stream); !errors.Is(err, context.Canceled) {
+		t.Fatalf("stream error = %v, want context.Canceled", err)
+	}
+	if queued, _ := queue.Pending(); queued != 0 {
+		t.Errorf("queued = %d after cancel", queued)
+	}
+}
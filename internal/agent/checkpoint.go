@@ -0,0 +1,441 @@
+package agent
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/haasonsaas/nexus/pkg/models"
+)
+
+// ErrCheckpointNotFound is returned for a run without a checkpoint.
+var ErrCheckpointNotFound = errors.New("checkpoint not found")
+
+// CheckpointStatus is the state a checkpointed run was last seen in.
+type CheckpointStatus string
+
+const (
+	// CheckpointRunning marks a run that was in progress at its last
+	// checkpoint.
+	CheckpointRunning CheckpointStatus = "running"
+
+	// CheckpointInterrupted marks a run cut short by a cancellation, a
+	// timeout, or a restart.
+	CheckpointInterrupted CheckpointStatus = "interrupted"
+
+	// CheckpointFailed marks a run that stopped with an error.
+	CheckpointFailed CheckpointStatus = "failed"
+)
+
+// RunCheckpoint is the saved state of a long run: everything needed to send
+// the next request to the model as if the run had never stopped. Finished
+// runs have no checkpoint.
+type RunCheckpoint struct {
+	RunID     string `json:"run_id"`
+	SessionID string `json:"session_id"`
+	AgentID   string `json:"agent_id,omitempty"`
+	BranchID  string `json:"branch_id,omitempty"`
+
+	// Message is the inbound message that started the run, without
+	// attachments. It routes the reply of a resumed run.
+	Message *models.Message `json:"message,omitempty"`
+
+	Model  string `json:"model,omitempty"`
+	System string `json:"system,omitempty"`
+
+	// Messages is the conversation sent to the model, including tool
+	// results. When the last message is an assistant turn with tool calls,
+	// those calls were pending when the checkpoint was taken.
+	Messages []CompletionMessage `json:"messages"`
+
+	// Iteration and ToolCalls count the model turns and tool calls made so
+	// far, across resumes.
+	Iteration int `json:"iteration"`
+	ToolCalls int `json:"tool_calls"`
+
+	// Plan is the run's plan, if the agent created one.
+	Plan *models.Plan `json:"plan,omitempty"`
+
+	Status    CheckpointStatus `json:"status"`
+	Error     string           `json:"error,omitempty"`
+	CreatedAt time.Time        `json:"created_at"`
+	UpdatedAt time.Time        `json:"updated_at"`
+}
+
+// PendingToolCalls returns the tool calls of the last assistant turn that
+// have no results.
+func (c *RunCheckpoint) PendingToolCalls() []models.ToolCall {
+	if c == nil || len(c.Messages) == 0 {
+		return nil
+	}
+	last := c.Messages[len(c.Messages)-1]
+	if last.Role != "assistant" {
+		return nil
+	}
+	return last.ToolCalls
+}
+
+// resumeMessages returns the conversation to continue from. Pending tool
+// calls are answered with an error so the model checks their effects before
+// calling them again.
+func (c *RunCheckpoint) resumeMessages() []CompletionMessage {
+	messages := append([]CompletionMessage(nil), c.Messages...)
+	pending := c.PendingToolCalls()
+	if len(pending) == 0 {
+		return messages
+	}
+	results := make([]models.ToolResult, 0, len(pending))
+	for _, call := range pending {
+		results = append(results, models.ToolResult{
+			ToolCallID: call.ID,
+			Content:    "tool call interrupted before it finished; check its effects before calling it again",
+			IsError:    true,
+		})
+	}
+	return append(messages, CompletionMessage{Role: "tool", ToolResults: results})
+}
+
+// CheckpointStore persists run checkpoints.
+type CheckpointStore interface {
+	Save(ctx context.Context, checkpoint *RunCheckpoint) error
+	Get(ctx context.Context, runID string) (*RunCheckpoint, error)
+	// List returns all checkpoints, most recently updated first.
+	List(ctx context.Context) ([]*RunCheckpoint, error)
+	Delete(ctx context.Context, runID string) error
+}
+
+// CheckpointOptions enables run checkpointing.
+type CheckpointOptions struct {
+	// Store receives checkpoints. Nil disables checkpointing.
+	Store CheckpointStore
+
+	// Interval is the least time between two checkpoints of a run. Runs that
+	// finish within it are never checkpointed.
+	Interval time.Duration
+}
+
+func (o CheckpointOptions) active() bool {
+	return o.Store != nil
+}
+
+// CheckpointRetention limits how many checkpoints are kept.
+type CheckpointRetention struct {
+	// MaxAge removes checkpoints not updated for this long.
+	MaxAge time.Duration
+
+	// MaxCount keeps at most this many checkpoints, newest first.
+	MaxCount int
+}
+
+// FileCheckpointStore keeps one JSON file per run in a directory and applies
+// its retention limits on every save. Checkpoints of running runs are never
+// pruned.
+type FileCheckpointStore struct {
+	mu        sync.Mutex
+	dir       string
+	retention CheckpointRetention
+}
+
+// NewFileCheckpointStore creates dir if needed and returns a store in it.
+func NewFileCheckpointStore(dir string, retention CheckpointRetention) (*FileCheckpointStore, error) {
+	if strings.TrimSpace(dir) == "" {
+		return nil, errors.New("checkpoint directory is required")
+	}
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("create checkpoint dir: %w", err)
+	}
+	return &FileCheckpointStore{dir: dir, retention: retention}, nil
+}
+
+func (s *FileCheckpointStore) path(runID string) string {
+	return filepath.Join(s.dir, url.PathEscape(runID)+".json")
+}
+
+// Save writes a checkpoint, replacing any earlier one for the run.
+func (s *FileCheckpointStore) Save(ctx context.Context, checkpoint *RunCheckpoint) error {
+	if checkpoint == nil || strings.TrimSpace(checkpoint.RunID) == "" {
+		return errors.New("checkpoint run id is required")
+	}
+	data, err := json.Marshal(checkpoint)
+	if err != nil {
+		return fmt.Errorf("encode checkpoint: %w", err)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	path := s.path(checkpoint.RunID)
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return fmt.Errorf("write checkpoint: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return fmt.Errorf("write checkpoint: %w", err)
+	}
+	return s.pruneLocked(checkpoint.RunID)
+}
+
+// Get returns the checkpoint of a run.
+func (s *FileCheckpointStore) Get(ctx context.Context, runID string) (*RunCheckpoint, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.read(s.path(runID), runID)
+}
+
+// List returns all checkpoints, most recently updated first. Unreadable
+// files are skipped.
+func (s *FileCheckpointStore) List(ctx context.Context) ([]*RunCheckpoint, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.listLocked()
+}
+
+// Delete removes the checkpoint of a run.
+func (s *FileCheckpointStore) Delete(ctx context.Context, runID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := os.Remove(s.path(runID)); err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("%w: %s", ErrCheckpointNotFound, runID)
+		}
+		return err
+	}
+	return nil
+}
+
+// PruneSession removes the checkpoints of a session last updated before
+// before, or all of them when before is zero, and returns how many it
+// removed. Checkpoints hold a run's full conversation, so retention and
+// erasure of the session must reach them.
+func (s *FileCheckpointStore) PruneSession(ctx context.Context, sessionID string, before time.Time) (int64, error) {
+	if strings.TrimSpace(sessionID) == "" {
+		return 0, errors.New("session id is required")
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	checkpoints, err := s.listLocked()
+	if err != nil {
+		return 0, err
+	}
+	var removed int64
+	for _, checkpoint := range checkpoints {
+		if checkpoint.SessionID != sessionID {
+			continue
+		}
+		if !before.IsZero() && !checkpoint.UpdatedAt.Before(before) {
+			continue
+		}
+		if err := os.Remove(s.path(checkpoint.RunID)); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return removed, fmt.Errorf("delete checkpoint %s: %w", checkpoint.RunID, err)
+		}
+		removed++
+	}
+	return removed, nil
+}
+
+// Prune applies the retention limits and returns how many checkpoints it
+// removed.
+func (s *FileCheckpointStore) Prune(ctx context.Context) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	before, err := s.listLocked()
+	if err != nil {
+		return 0, err
+	}
+	if err := s.pruneLocked(""); err != nil {
+		return 0, err
+	}
+	after, err := s.listLocked()
+	if err != nil {
+		return 0, err
+	}
+	return len(before) - len(after), nil
+}
+
+func (s *FileCheckpointStore) read(path, runID string) (*RunCheckpoint, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("%w: %s", ErrCheckpointNotFound, runID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read checkpoint: %w", err)
+	}
+	// Decode numbers as json.Number so integer IDs in reply metadata (such
+	// as Telegram chat IDs) come back as int64 rather than float64.
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var checkpoint RunCheckpoint
+	if err := decoder.Decode(&checkpoint); err != nil {
+		return nil, fmt.Errorf("parse checkpoint: %w", err)
+	}
+	if checkpoint.Message != nil {
+		restoreCheckpointNumbers(checkpoint.Message.Metadata)
+	}
+	return &checkpoint, nil
+}
+
+func (s *FileCheckpointStore) listLocked() ([]*RunCheckpoint, error) {
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		return nil, fmt.Errorf("list checkpoints: %w", err)
+	}
+	var checkpoints []*RunCheckpoint
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, ".json") {
+			continue
+		}
+		checkpoint, err := s.read(filepath.Join(s.dir, name), name)
+		if err != nil {
+			continue
+		}
+		checkpoints = append(checkpoints, checkpoint)
+	}
+	sort.Slice(checkpoints, func(i, j int) bool {
+		return checkpoints[i].UpdatedAt.After(checkpoints[j].UpdatedAt)
+	})
+	return checkpoints, nil
+}
+
+// pruneLocked removes checkpoints beyond the retention limits, sparing keep
+// and running runs.
+func (s *FileCheckpointStore) pruneLocked(keep string) error {
+	if s.retention.MaxAge <= 0 && s.retention.MaxCount <= 0 {
+		return nil
+	}
+	checkpoints, err := s.listLocked()
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	for i, checkpoint := range checkpoints {
+		if checkpoint.RunID == keep || checkpoint.Status == CheckpointRunning {
+			continue
+		}
+		expired := s.retention.MaxAge > 0 && now.Sub(checkpoint.UpdatedAt) > s.retention.MaxAge
+		excess := s.retention.MaxCount > 0 && i >= s.retention.MaxCount
+		if !expired && !excess {
+			continue
+		}
+		if err := os.Remove(s.path(checkpoint.RunID)); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("prune checkpoint %s: %w", checkpoint.RunID, err)
+		}
+	}
+	return nil
+}
+
+// restoreCheckpointNumbers converts json.Number metadata values back to
+// int64 or float64.
+func restoreCheckpointNumbers(metadata map[string]any) {
+	for key, value := range metadata {
+		num, ok := value.(json.Number)
+		if !ok {
+			continue
+		}
+		if i, err := num.Int64(); err == nil {
+			metadata[key] = i
+		} else if f, err := num.Float64(); err == nil {
+			metadata[key] = f
+		}
+	}
+}
+
+type resumeCheckpointKey struct{}
+
+// WithResumeCheckpoint makes the run started with ctx continue from a
+// checkpoint instead of the session history. The inbound message is not
+// persisted again, and the run keeps the checkpoint's run ID for later
+// checkpoints.
+func WithResumeCheckpoint(ctx context.Context, checkpoint *RunCheckpoint) context.Context {
+	if checkpoint == nil {
+		return ctx
+	}
+	return context.WithValue(ctx, resumeCheckpointKey{}, checkpoint)
+}
+
+func resumeCheckpointFromContext(ctx context.Context) *RunCheckpoint {
+	checkpoint, _ := ctx.Value(resumeCheckpointKey{}).(*RunCheckpoint)
+	return checkpoint
+}
+
+// runCheckpointer saves the state of one run at most once per interval, and
+// settles its checkpoint when the run ends.
+type runCheckpointer struct {
+	opts       CheckpointOptions
+	logger     *slog.Logger
+	checkpoint RunCheckpoint
+	plan       *RunPlan
+	// base offsets the counters of a resumed run.
+	baseIter, baseToolCalls int
+	lastSave                time.Time
+	saved                   bool
+}
+
+// newRunCheckpointer returns nil when checkpointing is off. A resumed run
+// continues the checkpoint it started from.
+func newRunCheckpointer(opts CheckpointOptions, logger *slog.Logger, checkpoint RunCheckpoint, plan *RunPlan, resumed bool) *runCheckpointer {
+	if !opts.active() {
+		return nil
+	}
+	now := time.Now()
+	c := &runCheckpointer{opts: opts, logger: logger, checkpoint: checkpoint, plan: plan, lastSave: now, saved: resumed}
+	if resumed {
+		c.baseIter = checkpoint.Iteration
+		c.baseToolCalls = checkpoint.ToolCalls
+	} else {
+		c.checkpoint.CreatedAt = now
+	}
+	return c
+}
+
+// maybeSave checkpoints the run once the interval has passed since the last
+// checkpoint, or since the run started.
+func (c *runCheckpointer) maybeSave(ctx context.Context, messages []CompletionMessage, iter, toolCalls int) {
+	if c == nil || time.Since(c.lastSave) < c.opts.Interval {
+		return
+	}
+	c.save(ctx, messages, iter, toolCalls, CheckpointRunning, "")
+}
+
+// finish deletes the checkpoint of a run that succeeded, and records why any
+// other run stopped.
+func (c *runCheckpointer) finish(ctx context.Context, messages []CompletionMessage, iter, toolCalls int, runErr error) {
+	if c == nil || !c.saved {
+		return
+	}
+	if runErr == nil {
+		if err := c.opts.Store.Delete(context.WithoutCancel(ctx), c.checkpoint.RunID); err != nil && !errors.Is(err, ErrCheckpointNotFound) {
+			c.logger.Warn("failed to delete run checkpoint", "run_id", c.checkpoint.RunID, "error", err)
+		}
+		return
+	}
+	status := CheckpointFailed
+	if ctx.Err() != nil {
+		status = CheckpointInterrupted
+	}
+	c.save(ctx, messages, iter, toolCalls, status, runErr.Error())
+}
+
+func (c *runCheckpointer) save(ctx context.Context, messages []CompletionMessage, iter, toolCalls int, status CheckpointStatus, errMsg string) {
+	c.checkpoint.Messages = messages
+	c.checkpoint.Iteration = c.baseIter + iter
+	c.checkpoint.ToolCalls = c.baseToolCalls + toolCalls
+	c.checkpoint.Plan = c.plan.Current()
+	c.checkpoint.Status = status
+	c.checkpoint.Error = errMsg
+	c.checkpoint.UpdatedAt = time.Now()
+	if err := c.opts.Store.Save(context.WithoutCancel(ctx), &c.checkpoint); err != nil {
+		c.logger.Warn("failed to save run checkpoint", "run_id", c.checkpoint.RunID, "error", err)
+		return
+	}
+	c.lastSave = c.checkpoint.UpdatedAt
+	c.saved = true
+}
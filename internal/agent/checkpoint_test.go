@@ -0,0 +1,202 @@
+package agent
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/haasonsaas/nexus/pkg/models"
+)
+
+func TestFileCheckpointStore(t *testing.T) {
+	ctx := context.Background()
+	store, err := NewFileCheckpointStore(t.TempDir(), CheckpointRetention{MaxAge: time.Hour, MaxCount: 2})
+	if err != nil {
+		t.Fatalf("NewFileCheckpointStore() error = %v", err)
+	}
+	now := time.Now()
+	save := func(runID string, status CheckpointStatus, updated time.Time) {
+		t.Helper()
+		if err := store.Save(ctx, &RunCheckpoint{RunID: runID, Status: status, UpdatedAt: updated}); err != nil {
+			t.Fatalf("Save(%s) error = %v", runID, err)
+		}
+	}
+
+	if err := store.Save(ctx, &RunCheckpoint{
+		RunID:   "session/1-msg",
+		Status:  CheckpointFailed,
+		Message: &models.Message{Metadata: map[string]any{"chat_id": int64(-1001234567890)}},
+		Messages: []CompletionMessage{
+			{Role: "user", Content: "hi"},
+		},
+		UpdatedAt: now,
+	}); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	got, err := store.Get(ctx, "session/1-msg")
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if got.Message.Metadata["chat_id"] != int64(-1001234567890) || len(got.Messages) != 1 {
+		t.Fatalf("Get() = %+v", got)
+	}
+	if _, err := store.Get(ctx, "missing"); !errors.Is(err, ErrCheckpointNotFound) {
+		t.Fatalf("Get(missing) error = %v, want ErrCheckpointNotFound", err)
+	}
+
+	save("running-old", CheckpointRunning, now.Add(-2*time.Hour))
+	save("expired", CheckpointInterrupted, now.Add(-2*time.Hour))
+	save("newest", CheckpointFailed, now.Add(time.Minute))
+
+	list, err := store.List(ctx)
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	var ids []string
+	for _, checkpoint := range list {
+		ids = append(ids, checkpoint.RunID)
+	}
+	// "expired" is past max_age; running checkpoints are never pruned.
+	want := []string{"newest", "session/1-msg", "running-old"}
+	if len(ids) != len(want) {
+		t.Fatalf("List() = %v, want %v", ids, want)
+	}
+	for i := range want {
+		if ids[i] != want[i] {
+			t.Fatalf("List() = %v, want %v", ids, want)
+		}
+	}
+
+	if err := store.Delete(ctx, "newest"); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if err := store.Delete(ctx, "newest"); !errors.Is(err, ErrCheckpointNotFound) {
+		t.Fatalf("second Delete() error = %v, want ErrCheckpointNotFound", err)
+	}
+}
+
+func TestFileCheckpointStorePruneSession(t *testing.T) {
+	ctx := context.Background()
+	store, err := NewFileCheckpointStore(t.TempDir(), CheckpointRetention{})
+	if err != nil {
+		t.Fatalf("NewFileCheckpointStore() error = %v", err)
+	}
+	now := time.Now()
+	for _, checkpoint := range []*RunCheckpoint{
+		{RunID: "old", SessionID: "s1", Status: CheckpointFailed, UpdatedAt: now.Add(-2 * time.Hour)},
+		{RunID: "recent", SessionID: "s1", Status: CheckpointRunning, UpdatedAt: now},
+		{RunID: "other", SessionID: "s2", Status: CheckpointFailed, UpdatedAt: now.Add(-2 * time.Hour)},
+	} {
+		if err := store.Save(ctx, checkpoint); err != nil {
+			t.Fatalf("Save(%s) error = %v", checkpoint.RunID, err)
+		}
+	}
+
+	if removed, err := store.PruneSession(ctx, "s1", now.Add(-time.Hour)); err != nil || removed != 1 {
+		t.Fatalf("PruneSession(before) = %d, %v", removed, err)
+	}
+	if _, err := store.Get(ctx, "recent"); err != nil {
+		t.Fatalf("Get(recent) error = %v", err)
+	}
+	if removed, err := store.PruneSession(ctx, "s1", time.Time{}); err != nil || removed != 1 {
+		t.Fatalf("PruneSession() = %d, %v", removed, err)
+	}
+	if _, err := store.Get(ctx, "other"); err != nil {
+		t.Fatalf("Get(other) error = %v", err)
+	}
+}
+
+func TestRunCheckpointResumeMessages(t *testing.T) {
+	checkpoint := &RunCheckpoint{Messages: []CompletionMessage{
+		{Role: "user", Content: "build it"},
+		{Role: "assistant", ToolCalls: []models.ToolCall{{ID: "call-1", Name: "exec"}}},
+	}}
+	if pending := checkpoint.PendingToolCalls(); len(pending) != 1 || pending[0].ID != "call-1" {
+		t.Fatalf("PendingToolCalls() = %+v", pending)
+	}
+	messages := checkpoint.resumeMessages()
+	if len(messages) != 3 || len(checkpoint.Messages) != 2 {
+		t.Fatalf("resumeMessages() returned %d messages, checkpoint has %d", len(messages), len(checkpoint.Messages))
+	}
+	last := messages[2]
+	if last.Role != "tool" || len(last.ToolResults) != 1 || last.ToolResults[0].ToolCallID != "call-1" || !last.ToolResults[0].IsError {
+		t.Fatalf("unexpected interrupted result %+v", last)
+	}
+}
+
+// checkpointProvider records the messages of each request.
+type checkpointProvider struct {
+	sequenceProvider
+	mu       sync.Mutex
+	requests [][]CompletionMessage
+}
+
+func (p *checkpointProvider) Complete(ctx context.Context, req *CompletionRequest) (<-chan *CompletionChunk, error) {
+	p.mu.Lock()
+	p.requests = append(p.requests, append([]CompletionMessage(nil), req.Messages...))
+	p.mu.Unlock()
+	return p.sequenceProvider.Complete(ctx, req)
+}
+
+func TestProcessCheckpointAndResume(t *testing.T) {
+	ctx := context.Background()
+	store, err := NewFileCheckpointStore(t.TempDir(), CheckpointRetention{})
+	if err != nil {
+		t.Fatalf("NewFileCheckpointStore() error = %v", err)
+	}
+	opts := RuntimeOptions{
+		MaxIterations:   5,
+		ToolParallelism: 1,
+		Checkpoints:     CheckpointOptions{Store: store},
+	}
+	session := &models.Session{ID: "session-1", AgentID: "main", Channel: models.ChannelTelegram}
+	drain := func(ch <-chan *ResponseChunk) {
+		for range ch {
+		}
+	}
+
+	// The first run checkpoints after its tool call, then fails.
+	failing := &checkpointProvider{sequenceProvider: sequenceProvider{supportsTools: true, responses: [][]CompletionChunk{
+		{{ToolCall: &models.ToolCall{ID: "call-1", Name: "step", Input: json.RawMessage(`{}`)}}},
+		{{Error: errors.New("provider unavailable")}},
+	}}}
+	runtime := NewRuntimeWithOptions(failing, stubStore{}, opts)
+	runtime.RegisterTool(&countingTool{name: "step"})
+	ch, err := runtime.Process(ctx, session, &models.Message{ID: "msg-1", Role: models.RoleUser, Content: "long task"})
+	if err != nil {
+		t.Fatalf("Process() error = %v", err)
+	}
+	drain(ch)
+
+	checkpoint, err := store.Get(ctx, "session-1-msg-1")
+	if err != nil {
+		t.Fatalf("expected a checkpoint: %v", err)
+	}
+	if checkpoint.Status != CheckpointFailed || checkpoint.Error != "provider unavailable" {
+		t.Fatalf("checkpoint status = %q (%q)", checkpoint.Status, checkpoint.Error)
+	}
+	if len(checkpoint.Messages) != 3 || checkpoint.Iteration != 1 || checkpoint.ToolCalls != 1 || checkpoint.Message.Content != "long task" {
+		t.Fatalf("unexpected checkpoint %+v", checkpoint)
+	}
+
+	// Resuming sends the checkpointed conversation and clears the checkpoint.
+	resumed := &checkpointProvider{sequenceProvider: sequenceProvider{supportsTools: true, responses: [][]CompletionChunk{
+		{{Text: "done"}},
+	}}}
+	runtime = NewRuntimeWithOptions(resumed, stubStore{}, opts)
+	ch, err = runtime.Process(WithResumeCheckpoint(ctx, checkpoint), session, &models.Message{ID: "msg-1-checkpoint", Role: models.RoleUser})
+	if err != nil {
+		t.Fatalf("Process() error = %v", err)
+	}
+	drain(ch)
+
+	if len(resumed.requests) != 1 || len(resumed.requests[0]) != 3 || resumed.requests[0][0].Content != "long task" {
+		t.Fatalf("resumed request = %+v", resumed.requests)
+	}
+	if _, err := store.Get(ctx, "session-1-msg-1"); !errors.Is(err, ErrCheckpointNotFound) {
+		t.Fatalf("expected the checkpoint to be removed, got %v", err)
+	}
+}
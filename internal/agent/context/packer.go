@@ -81,7 +81,8 @@ func NewPacker(opts PackOptions) *Packer {
 // The packed result includes (in order):
 //  1. Summary message (if IncludeSummary and summary exists)
 //  2. Recent messages from history (newest first, up to budget)
-//  3. The incoming user message
+//  3. Quotes (PackWithQuotes only)
+//  4. The incoming user message
 //
 // Tool result content is truncated to MaxToolResultChars.
 // Messages are selected from the end (most recent) backwards until
@@ -93,6 +94,13 @@ func (p *Packer) Pack(history []*models.Message, incoming *models.Message, summa
 
 // PackWithDiagnostics is like Pack but returns detailed diagnostics.
 func (p *Packer) PackWithDiagnostics(history []*models.Message, incoming *models.Message, summary *models.Message) *PackResult {
+	return p.PackWithQuotes(history, nil, incoming, summary)
+}
+
+// PackWithQuotes is like PackWithDiagnostics but also reserves room for
+// quotes: the messages the incoming message replies to or quotes. They are
+// placed right before the incoming message.
+func (p *Packer) PackWithQuotes(history []*models.Message, quotes []*models.Message, incoming *models.Message, summary *models.Message) *PackResult {
 	var messages []*models.Message
 	var items []models.ContextPackItem
 
@@ -111,11 +119,32 @@ func (p *Packer) PackWithDiagnostics(history []*models.Message, incoming *models
 		totalChars += incomingChars
 		totalMsgs++
 		items = append(items, models.ContextPackItem{
-			ID:       hashMessage(incoming),
-			Kind:     models.ContextItemIncoming,
-			Chars:    incomingChars,
-			Included: true,
-			Reason:   models.ContextReasonReserved,
+			ID:        hashMessage(incoming),
+			CreatedAt: incoming.CreatedAt,
+			Kind:      models.ContextItemIncoming,
+			Chars:     incomingChars,
+			Included:  true,
+			Reason:    models.ContextReasonReserved,
+		})
+	}
+
+	// Reserve space for quotes
+	packedQuotes := make([]*models.Message, 0, len(quotes))
+	for _, q := range quotes {
+		if q == nil {
+			continue
+		}
+		chars := p.messageChars(q)
+		totalChars += chars
+		totalMsgs++
+		packedQuotes = append(packedQuotes, q)
+		items = append(items, models.ContextPackItem{
+			ID:        hashMessage(q),
+			CreatedAt: q.CreatedAt,
+			Kind:      models.ContextItemQuote,
+			Chars:     chars,
+			Included:  true,
+			Reason:    models.ContextReasonReserved,
 		})
 	}
 
@@ -126,11 +155,12 @@ func (p *Packer) PackWithDiagnostics(history []*models.Message, incoming *models
 		totalMsgs++
 		summaryUsed = true
 		items = append(items, models.ContextPackItem{
-			ID:       hashMessage(summary),
-			Kind:     models.ContextItemSummary,
-			Chars:    summaryChars,
-			Included: true,
-			Reason:   models.ContextReasonReserved,
+			ID:        hashMessage(summary),
+			CreatedAt: summary.CreatedAt,
+			Kind:      models.ContextItemSummary,
+			Chars:     summaryChars,
+			Included:  true,
+			Reason:    models.ContextReasonReserved,
 		})
 	}
 
@@ -162,11 +192,12 @@ func (p *Packer) PackWithDiagnostics(history []*models.Message, incoming *models
 		if totalMsgs+1 > p.opts.MaxMessages || totalChars+msgChars > p.opts.MaxChars {
 			// Track as dropped due to budget
 			items = append(items, models.ContextPackItem{
-				ID:       hashMessage(m),
-				Kind:     classifyMessage(m),
-				Chars:    msgChars,
-				Included: false,
-				Reason:   models.ContextReasonOverBudget,
+				ID:        hashMessage(m),
+				CreatedAt: m.CreatedAt,
+				Kind:      classifyMessage(m),
+				Chars:     msgChars,
+				Included:  false,
+				Reason:    models.ContextReasonOverBudget,
 			})
 			continue
 		}
@@ -177,11 +208,12 @@ func (p *Packer) PackWithDiagnostics(history []*models.Message, incoming *models
 		totalChars += msgChars
 
 		items = append(items, models.ContextPackItem{
-			ID:       hashMessage(m),
-			Kind:     classifyMessage(m),
-			Chars:    msgChars,
-			Included: true,
-			Reason:   models.ContextReasonIncluded,
+			ID:        hashMessage(m),
+			CreatedAt: m.CreatedAt,
+			Kind:      classifyMessage(m),
+			Chars:     msgChars,
+			Included:  true,
+			Reason:    models.ContextReasonIncluded,
 		})
 	}
 
@@ -202,11 +234,12 @@ func (p *Packer) PackWithDiagnostics(history []*models.Message, incoming *models
 		if !found {
 			m := filtered[i]
 			items = append(items, models.ContextPackItem{
-				ID:       hashMessage(m),
-				Kind:     classifyMessage(m),
-				Chars:    p.messageChars(m),
-				Included: false,
-				Reason:   models.ContextReasonTooOld,
+				ID:        hashMessage(m),
+				CreatedAt: m.CreatedAt,
+				Kind:      classifyMessage(m),
+				Chars:     p.messageChars(m),
+				Included:  false,
+				Reason:    models.ContextReasonTooOld,
 			})
 		}
 	}
@@ -230,7 +263,8 @@ func (p *Packer) PackWithDiagnostics(history []*models.Message, incoming *models
 		messages = append(messages, packed)
 	}
 
-	// 3. Incoming message
+	// 3. Quotes, then the incoming message
+	messages = append(messages, packedQuotes...)
 	if incoming != nil {
 		messages = append(messages, incoming)
 	}
@@ -382,6 +382,37 @@ func TestPackWithDiagnostics_BasicCounts(t *testing.T) {
 	}
 }
 
+func TestPackWithQuotes_PlacesQuotesBeforeIncoming(t *testing.T) {
+	packer := NewPacker(DefaultPackOptions())
+	history := []*models.Message{
+		{ID: "1", Role: models.RoleUser, Content: "Hello"},
+		{ID: "2", Role: models.RoleAssistant, Content: "Hi there"},
+	}
+	quote := &models.Message{ID: "quote:9", Role: models.RoleSystem, Content: "Replying to: the deploy is paused"}
+	incoming := &models.Message{ID: "3", Role: models.RoleUser, Content: "Still?"}
+
+	result := packer.PackWithQuotes(history, []*models.Message{quote}, incoming, nil)
+
+	if len(result.Messages) != 4 {
+		t.Fatalf("expected 4 messages, got %d", len(result.Messages))
+	}
+	if result.Messages[2].ID != "quote:9" || result.Messages[3].ID != "3" {
+		t.Errorf("expected quote right before incoming, got %s, %s", result.Messages[2].ID, result.Messages[3].ID)
+	}
+	var quoteItems int
+	for _, item := range result.Diagnostics.Items {
+		if item.Kind == models.ContextItemQuote {
+			quoteItems++
+			if !item.Included || item.Reason != models.ContextReasonReserved {
+				t.Errorf("expected reserved quote item, got %+v", item)
+			}
+		}
+	}
+	if quoteItems != 1 {
+		t.Errorf("expected 1 quote item, got %d", quoteItems)
+	}
+}
+
 func TestPackWithDiagnostics_BudgetTracking(t *testing.T) {
 	opts := DefaultPackOptions()
 	opts.MaxChars = 500
@@ -543,3 +574,29 @@ func TestPackWithDiagnostics_ItemIDs(t *testing.T) {
 		}
 	}
 }
+
+func TestPackWithDiagnostics_ItemCreatedAt(t *testing.T) {
+	packer := NewPacker(PackOptions{MaxMessages: 2})
+	old := time.Date(2026, 1, 1, 9, 0, 0, 0, time.UTC)
+	recent := old.Add(time.Hour)
+
+	history := []*models.Message{
+		{ID: "msg-1", Role: models.RoleUser, Content: "Hello", CreatedAt: old},
+		{ID: "msg-2", Role: models.RoleAssistant, Content: "Hi", CreatedAt: recent},
+	}
+	incoming := &models.Message{ID: "msg-3", Role: models.RoleUser, Content: "How are you?", CreatedAt: recent}
+
+	diag := packer.PackWithDiagnostics(history, incoming, nil).Diagnostics
+	if len(diag.Items) != 3 {
+		t.Fatalf("expected 3 items, got %d", len(diag.Items))
+	}
+	for _, item := range diag.Items {
+		want := recent
+		if item.Reason == models.ContextReasonOverBudget {
+			want = old
+		}
+		if !item.CreatedAt.Equal(want) {
+			t.Errorf("%s item CreatedAt = %v, want %v", item.Reason, item.CreatedAt, want)
+		}
+	}
+}
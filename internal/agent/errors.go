@@ -28,6 +28,10 @@ var (
 
 	// ErrBackpressure indicates the system is overloaded
 	ErrBackpressure = errors.New("backpressure: system overloaded")
+
+	// ErrLoopDetected indicates a run was stopped for repeating the same
+	// tool calls
+	ErrLoopDetected = errors.New("agent loop detected")
 )
 
 // ToolErrorType categorizes tool execution errors for retry logic and error handling.
@@ -153,13 +153,30 @@ func (e *EventEmitter) ModelDelta(ctx context.Context, delta string) models.Agen
 }
 
 // ModelCompleted emits a model.completed event with provider and token usage information.
-func (e *EventEmitter) ModelCompleted(ctx context.Context, provider, model string, inputTokens, outputTokens int) models.AgentEvent {
+func (e *EventEmitter) ModelCompleted(ctx context.Context, provider, model string, inputTokens, outputTokens, reasoningTokens int) models.AgentEvent {
 	event := e.base(models.AgentEventModelCompleted)
 	event.Stream = &models.StreamEventPayload{
-		Provider:     provider,
-		Model:        model,
-		InputTokens:  inputTokens,
-		OutputTokens: outputTokens,
+		Provider:        provider,
+		Model:           model,
+		InputTokens:     inputTokens,
+		OutputTokens:    outputTokens,
+		ReasoningTokens: reasoningTokens,
+	}
+	e.emit(ctx, event)
+	return event
+}
+
+// ModelReasoning emits a model.reasoning event with the reasoning text of a
+// model turn. Unless trace is set the text is replaced with its length.
+func (e *EventEmitter) ModelReasoning(ctx context.Context, model, text string, reasoningTokens int, trace bool) models.AgentEvent {
+	event := e.base(models.AgentEventModelReasoning)
+	if !trace {
+		text = fmt.Sprintf("[reasoning redacted: %d chars]", len(text))
+	}
+	event.Stream = &models.StreamEventPayload{
+		Model:           model,
+		Final:           text,
+		ReasoningTokens: reasoningTokens,
 	}
 	e.emit(ctx, event)
 	return event
@@ -279,6 +296,14 @@ func (e *EventEmitter) FollowUpQueued(ctx context.Context, content string, count
 	return event
 }
 
+// PlanUpdated emits a plan.updated event with a copy of the run's plan.
+func (e *EventEmitter) PlanUpdated(ctx context.Context, plan *models.Plan) models.AgentEvent {
+	event := e.base(models.AgentEventPlanUpdated)
+	event.Plan = plan.Clone()
+	e.emit(ctx, event)
+	return event
+}
+
 // TurnStarted emits a turn.started event at the beginning of a turn.
 func (e *EventEmitter) TurnStarted(ctx context.Context) models.AgentEvent {
 	event := e.base(models.AgentEventTurnStarted)
@@ -299,6 +324,8 @@ type StatsCollector struct {
 	stats      models.RunStats
 	modelStart time.Time
 	toolStarts map[string]time.Time
+	// earlyStarts holds tools started while the model is still streaming.
+	earlyStarts map[string]time.Time
 }
 
 // NewStatsCollector creates a new stats collector for the given run ID.
@@ -308,7 +335,8 @@ func NewStatsCollector(runID string) *StatsCollector {
 			RunID:     runID,
 			StartedAt: time.Now(),
 		},
-		toolStarts: make(map[string]time.Time),
+		toolStarts:  make(map[string]time.Time),
+		earlyStarts: make(map[string]time.Time),
 	}
 }
 
@@ -327,15 +355,24 @@ func (c *StatsCollector) OnEvent(ctx context.Context, e models.AgentEvent) {
 			c.stats.ModelWallTime += e.Time.Sub(c.modelStart)
 			c.modelStart = time.Time{}
 		}
+		for callID, start := range c.earlyStarts {
+			c.stats.ToolOverlapTime += e.Time.Sub(start)
+			delete(c.earlyStarts, callID)
+		}
 		if e.Stream != nil {
 			c.stats.InputTokens += e.Stream.InputTokens
 			c.stats.OutputTokens += e.Stream.OutputTokens
+			c.stats.ReasoningTokens += e.Stream.ReasoningTokens
 		}
 
 	case models.AgentEventToolStarted:
 		c.stats.ToolCalls++
 		if e.Tool != nil {
 			c.toolStarts[e.Tool.CallID] = e.Time
+			if !c.modelStart.IsZero() {
+				c.stats.EarlyToolStarts++
+				c.earlyStarts[e.Tool.CallID] = e.Time
+			}
 		}
 
 	case models.AgentEventToolFinished:
@@ -344,6 +381,7 @@ func (c *StatsCollector) OnEvent(ctx context.Context, e models.AgentEvent) {
 				c.stats.ToolWallTime += e.Time.Sub(start)
 				delete(c.toolStarts, e.Tool.CallID)
 			}
+			c.endEarlyStart(e.Tool.CallID, e.Time)
 			if !e.Tool.Success {
 				c.stats.Errors++
 			}
@@ -357,6 +395,7 @@ func (c *StatsCollector) OnEvent(ctx context.Context, e models.AgentEvent) {
 				c.stats.ToolWallTime += e.Time.Sub(start)
 				delete(c.toolStarts, e.Tool.CallID)
 			}
+			c.endEarlyStart(e.Tool.CallID, e.Time)
 		}
 
 	case models.AgentEventContextPacked:
@@ -365,6 +404,13 @@ func (c *StatsCollector) OnEvent(ctx context.Context, e models.AgentEvent) {
 			c.stats.DroppedItems += e.Stats.Run.DroppedItems
 		}
 
+	case models.AgentEventPlanUpdated:
+		c.stats.PlanUpdates++
+		if e.Plan != nil {
+			c.stats.PlanSteps = len(e.Plan.Steps)
+			c.stats.PlanStepsDone = e.Plan.Done()
+		}
+
 	case models.AgentEventRunError:
 		c.stats.Errors++
 
@@ -382,6 +428,15 @@ func (c *StatsCollector) OnEvent(ctx context.Context, e models.AgentEvent) {
 	}
 }
 
+// endEarlyStart adds the overlap of an early-started tool that finished
+// before the model turn did.
+func (c *StatsCollector) endEarlyStart(callID string, at time.Time) {
+	if start, ok := c.earlyStarts[callID]; ok {
+		c.stats.ToolOverlapTime += at.Sub(start)
+		delete(c.earlyStarts, callID)
+	}
+}
+
 // Stats returns a copy of the accumulated statistics.
 func (c *StatsCollector) Stats() *models.RunStats {
 	// Copy to avoid mutation
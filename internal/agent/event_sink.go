@@ -342,6 +342,11 @@ func eventToChunk(e models.AgentEvent) *ResponseChunk {
 			}
 		}
 
+	case models.AgentEventPlanUpdated:
+		if e.Plan != nil {
+			return &ResponseChunk{Plan: e.Plan}
+		}
+
 	case models.AgentEventRunError, models.AgentEventRunCancelled, models.AgentEventRunTimedOut:
 		if e.Error != nil {
 			// Prefer original error if available (preserves error type for errors.Is)
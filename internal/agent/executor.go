@@ -9,6 +9,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/haasonsaas/nexus/internal/supervisor"
 	"github.com/haasonsaas/nexus/pkg/models"
 )
 
@@ -375,6 +376,7 @@ func (e *Executor) executeWithTimeout(ctx context.Context, call models.ToolCall,
 		defer func() {
 			if r := recover(); r != nil {
 				stack := debug.Stack()
+				supervisor.Report(supervisor.Crash{Component: "tool:" + call.Name, Panic: fmt.Sprint(r), Stack: string(stack)})
 				err := NewToolError(call.Name, fmt.Errorf("panic: %v\n%s", r, stack)).
 					WithType(ToolErrorPanic).
 					WithToolCallID(call.ID)
@@ -0,0 +1,139 @@
+package agent
+
+import (
+	"context"
+	"fmt"
+
+	modelcatalog "github.com/haasonsaas/nexus/internal/models"
+)
+
+// Reasoning effort levels accepted by GenerationProfile.ReasoningEffort.
+const (
+	ReasoningLow    = "low"
+	ReasoningMedium = "medium"
+	ReasoningHigh   = "high"
+)
+
+// maxStopSequences is the most stop sequences any supported provider accepts.
+const maxStopSequences = 4
+
+// GenerationProfile holds the generation parameters for an agent or a single
+// run. Zero fields leave the runtime and provider defaults in place.
+type GenerationProfile struct {
+	Model           string   `json:"model,omitempty"`
+	Temperature     *float64 `json:"temperature,omitempty"`
+	TopP            *float64 `json:"top_p,omitempty"`
+	MaxTokens       int      `json:"max_tokens,omitempty"`
+	Stop            []string `json:"stop,omitempty"`
+	ReasoningEffort string   `json:"reasoning_effort,omitempty"`
+}
+
+// IsZero reports whether the profile sets nothing.
+func (p GenerationProfile) IsZero() bool {
+	return p.Model == "" && p.Temperature == nil && p.TopP == nil &&
+		p.MaxTokens == 0 && len(p.Stop) == 0 && p.ReasoningEffort == ""
+}
+
+// Merge returns p with every field set in override replacing its own.
+func (p GenerationProfile) Merge(override GenerationProfile) GenerationProfile {
+	if override.Model != "" {
+		p.Model = override.Model
+	}
+	if override.Temperature != nil {
+		p.Temperature = override.Temperature
+	}
+	if override.TopP != nil {
+		p.TopP = override.TopP
+	}
+	if override.MaxTokens > 0 {
+		p.MaxTokens = override.MaxTokens
+	}
+	if len(override.Stop) > 0 {
+		p.Stop = override.Stop
+	}
+	if override.ReasoningEffort != "" {
+		p.ReasoningEffort = override.ReasoningEffort
+	}
+	return p
+}
+
+// Validate checks the profile's ranges and, when its model is in catalog,
+// that the model can honor it. A nil catalog uses the default catalog.
+// Models missing from the catalog are only range-checked.
+func (p GenerationProfile) Validate(catalog *modelcatalog.Catalog) error {
+	if p.Temperature != nil && (*p.Temperature < 0 || *p.Temperature > 2) {
+		return fmt.Errorf("temperature must be between 0 and 2")
+	}
+	if p.TopP != nil && (*p.TopP <= 0 || *p.TopP > 1) {
+		return fmt.Errorf("top_p must be greater than 0 and at most 1")
+	}
+	if p.MaxTokens < 0 {
+		return fmt.Errorf("max_tokens must be >= 0")
+	}
+	if len(p.Stop) > maxStopSequences {
+		return fmt.Errorf("at most %d stop sequences are allowed", maxStopSequences)
+	}
+	for _, stop := range p.Stop {
+		if stop == "" {
+			return fmt.Errorf("stop sequences must not be empty")
+		}
+	}
+	switch p.ReasoningEffort {
+	case "", ReasoningLow, ReasoningMedium, ReasoningHigh:
+	default:
+		return fmt.Errorf("reasoning_effort must be %q, %q, or %q", ReasoningLow, ReasoningMedium, ReasoningHigh)
+	}
+
+	if p.Model == "" {
+		return nil
+	}
+	if catalog == nil {
+		catalog = modelcatalog.DefaultCatalog
+	}
+	model, ok := catalog.Lookup(p.Model)
+	if !ok {
+		return nil
+	}
+	if model.MaxOutputTokens > 0 && p.MaxTokens > model.MaxOutputTokens {
+		return fmt.Errorf("max_tokens %d exceeds %s's output limit of %d", p.MaxTokens, model.ID, model.MaxOutputTokens)
+	}
+	if p.ReasoningEffort != "" && !model.HasCapability(modelcatalog.CapReasoning) {
+		return fmt.Errorf("reasoning_effort is not supported by %s", model.ID)
+	}
+	return nil
+}
+
+// apply copies the profile's sampling settings onto req. The model is
+// resolved separately so session overrides can take precedence.
+func (p GenerationProfile) apply(req *CompletionRequest) {
+	if p.MaxTokens > 0 {
+		req.MaxTokens = p.MaxTokens
+	}
+	if p.Temperature != nil {
+		req.Temperature = p.Temperature
+	}
+	if p.TopP != nil {
+		req.TopP = p.TopP
+	}
+	if len(p.Stop) > 0 {
+		req.Stop = append([]string(nil), p.Stop...)
+	}
+	if p.ReasoningEffort != "" {
+		req.ReasoningEffort = p.ReasoningEffort
+	}
+}
+
+type generationProfileKey struct{}
+
+// WithGenerationProfile stores generation parameters for a run in the context.
+func WithGenerationProfile(ctx context.Context, profile GenerationProfile) context.Context {
+	if profile.IsZero() {
+		return ctx
+	}
+	return context.WithValue(ctx, generationProfileKey{}, profile)
+}
+
+func generationProfileFromContext(ctx context.Context) (GenerationProfile, bool) {
+	profile, ok := ctx.Value(generationProfileKey{}).(GenerationProfile)
+	return profile, ok
+}
@@ -0,0 +1,107 @@
+package agent
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/haasonsaas/nexus/pkg/models"
+)
+
+func floatPtr(v float64) *float64 { return &v }
+
+func TestGenerationProfileValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		profile GenerationProfile
+		wantErr string
+	}{
+		{name: "empty"},
+		{name: "in range", profile: GenerationProfile{Model: "gpt-4o", Temperature: floatPtr(0.2), TopP: floatPtr(0.9), MaxTokens: 2048, Stop: []string{"END"}}},
+		{name: "unknown model skips catalog checks", profile: GenerationProfile{Model: "local-model", MaxTokens: 1000000}},
+		{name: "temperature", profile: GenerationProfile{Temperature: floatPtr(2.5)}, wantErr: "temperature"},
+		{name: "top_p", profile: GenerationProfile{TopP: floatPtr(0)}, wantErr: "top_p"},
+		{name: "negative max_tokens", profile: GenerationProfile{MaxTokens: -1}, wantErr: "max_tokens"},
+		{name: "too many stops", profile: GenerationProfile{Stop: []string{"a", "b", "c", "d", "e"}}, wantErr: "stop"},
+		{name: "empty stop", profile: GenerationProfile{Stop: []string{""}}, wantErr: "stop"},
+		{name: "unknown effort", profile: GenerationProfile{ReasoningEffort: "extreme"}, wantErr: "reasoning_effort"},
+		{name: "max_tokens over model limit", profile: GenerationProfile{Model: "claude-3-5-haiku", MaxTokens: 9000}, wantErr: "output limit of 8192"},
+		{name: "effort on non-reasoning model", profile: GenerationProfile{Model: "gpt-4o", ReasoningEffort: ReasoningHigh}, wantErr: "not supported by gpt-4o"},
+		{name: "effort on reasoning model", profile: GenerationProfile{Model: "o3-mini", ReasoningEffort: ReasoningHigh}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.profile.Validate(nil)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("Validate() error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("Validate() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestGenerationProfileMerge(t *testing.T) {
+	base := GenerationProfile{Model: "gpt-4o", Temperature: floatPtr(0.2), MaxTokens: 1000, Stop: []string{"END"}}
+	merged := base.Merge(GenerationProfile{Temperature: floatPtr(0.9), ReasoningEffort: ReasoningLow})
+	if merged.Model != "gpt-4o" || merged.MaxTokens != 1000 || len(merged.Stop) != 1 {
+		t.Fatalf("unset override fields replaced base: %+v", merged)
+	}
+	if *merged.Temperature != 0.9 || merged.ReasoningEffort != ReasoningLow {
+		t.Fatalf("override fields not applied: %+v", merged)
+	}
+	if *base.Temperature != 0.2 {
+		t.Fatalf("Merge modified the base profile")
+	}
+}
+
+func TestProcessAppliesGenerationProfile(t *testing.T) {
+	provider := &recordingProvider{}
+	runtime := NewRuntime(provider, stubStore{})
+	runtime.SetDefaultModel("default-model")
+	session := &models.Session{ID: "session-1", Channel: models.ChannelTelegram}
+	msg := &models.Message{Role: models.RoleUser, Content: "hi"}
+
+	ctx := WithGenerationProfile(context.Background(), GenerationProfile{
+		Model:       "agent-model",
+		Temperature: floatPtr(0.3),
+		TopP:        floatPtr(0.8),
+		MaxTokens:   1234,
+		Stop:        []string{"END"},
+	})
+	ch, err := runtime.Process(ctx, session, msg)
+	if err != nil {
+		t.Fatalf("Process() error = %v", err)
+	}
+	for range ch {
+	}
+
+	req := provider.lastRequest
+	if req == nil {
+		t.Fatal("expected a completion request")
+	}
+	if req.Model != "agent-model" {
+		t.Fatalf("Model = %q, want agent-model", req.Model)
+	}
+	if req.MaxTokens != 1234 || req.Temperature == nil || *req.Temperature != 0.3 || req.TopP == nil || *req.TopP != 0.8 {
+		t.Fatalf("generation settings not applied: %+v", req)
+	}
+	if len(req.Stop) != 1 || req.Stop[0] != "END" {
+		t.Fatalf("Stop = %v, want [END]", req.Stop)
+	}
+
+	// A session or run model override beats the profile's model.
+	ch, err = runtime.Process(WithModel(ctx, "session-model"), session, msg)
+	if err != nil {
+		t.Fatalf("Process() error = %v", err)
+	}
+	for range ch {
+	}
+	if provider.lastRequest.Model != "session-model" {
+		t.Fatalf("Model = %q, want session-model", provider.lastRequest.Model)
+	}
+}
@@ -0,0 +1,267 @@
+package agent
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"sort"
+	"sync"
+	"time"
+)
+
+// PooledKey is one API key of a KeyPool together with the provider that
+// sends requests with it.
+type PooledKey struct {
+	ID       string
+	Weight   int
+	Provider LLMProvider
+}
+
+// KeyPoolConfig configures how long a KeyPool skips failing keys.
+type KeyPoolConfig struct {
+	// RateLimitCooldown is how long a key answering 429 is skipped.
+	RateLimitCooldown time.Duration
+
+	// AuthCooldown is how long a key answering 401 or 403 is skipped.
+	AuthCooldown time.Duration
+
+	// OnDemote, when set, is called each time a key is demoted.
+	OnDemote func(keyID, reason string, until time.Time, err error)
+}
+
+// KeyStatus is a snapshot of one key's rotation state.
+type KeyStatus struct {
+	ID           string
+	Weight       int
+	Requests     int64
+	Demotions    int64
+	DemotedUntil time.Time
+	Reason       string
+}
+
+// KeyPool spreads requests for one provider across several API keys by
+// weight. A key whose request is rejected with 401/403 or 429 is demoted for
+// a cooldown and the request is retried with the next key, so a revoked or
+// exhausted key does not fail user requests. It implements LLMProvider.
+type KeyPool struct {
+	config KeyPoolConfig
+	now    func() time.Time
+	intn   func(n int) int
+
+	mu   sync.Mutex
+	keys []*keySlot
+}
+
+type keySlot struct {
+	PooledKey
+	requests     int64
+	demotions    int64
+	demotedUntil time.Time
+	reason       string
+}
+
+// NewKeyPool creates a pool over keys. Keys with a non-positive weight get
+// weight 1.
+func NewKeyPool(keys []PooledKey, config KeyPoolConfig) *KeyPool {
+	p := &KeyPool{config: config, now: time.Now, intn: rand.IntN}
+	p.Replace(keys)
+	return p
+}
+
+// Replace swaps the pool's keys in one step. Requests already in flight
+// finish on the key they started with; demotions are cleared.
+func (p *KeyPool) Replace(keys []PooledKey) {
+	slots := make([]*keySlot, 0, len(keys))
+	for _, key := range keys {
+		if key.Provider == nil {
+			continue
+		}
+		if key.Weight <= 0 {
+			key.Weight = 1
+		}
+		slots = append(slots, &keySlot{PooledKey: key})
+	}
+	p.mu.Lock()
+	p.keys = slots
+	p.mu.Unlock()
+}
+
+// Complete implements LLMProvider. Keys are tried in weighted random order,
+// healthy keys before demoted ones. Only errors reported before the first
+// streamed chunk move the request to another key.
+func (p *KeyPool) Complete(ctx context.Context, req *CompletionRequest) (<-chan *CompletionChunk, error) {
+	order := p.order()
+	if len(order) == 0 {
+		return nil, errors.New("no api keys configured")
+	}
+
+	out := make(chan *CompletionChunk)
+	go func() {
+		defer close(out)
+		var lastErr error
+		for _, slot := range order {
+			p.recordRequest(slot)
+			stream, err := slot.Provider.Complete(ctx, req)
+			if err == nil {
+				first, ok := <-stream
+				if ok && first != nil && first.Error != nil {
+					err = first.Error
+					go drainChunks(stream)
+				} else {
+					if ok {
+						out <- first
+					}
+					for chunk := range stream {
+						out <- chunk
+					}
+					return
+				}
+			}
+			lastErr = err
+			if ctx.Err() != nil || !p.demote(slot, err) {
+				break
+			}
+		}
+		out <- &CompletionChunk{Error: lastErr}
+	}()
+	return out, nil
+}
+
+// order returns the keys to try for one request.
+func (p *KeyPool) order() []*keySlot {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := p.now()
+	var healthy, demoted []*keySlot
+	total := 0
+	for _, slot := range p.keys {
+		if now.Before(slot.demotedUntil) {
+			demoted = append(demoted, slot)
+			continue
+		}
+		healthy = append(healthy, slot)
+		total += slot.Weight
+	}
+
+	order := make([]*keySlot, 0, len(p.keys))
+	for len(healthy) > 0 {
+		pick := p.intn(total)
+		for i, slot := range healthy {
+			if pick < slot.Weight {
+				order = append(order, slot)
+				total -= slot.Weight
+				healthy = append(healthy[:i], healthy[i+1:]...)
+				break
+			}
+			pick -= slot.Weight
+		}
+	}
+	// Demoted keys are a last resort, soonest to recover first.
+	sort.SliceStable(demoted, func(i, j int) bool {
+		return demoted[i].demotedUntil.Before(demoted[j].demotedUntil)
+	})
+	return append(order, demoted...)
+}
+
+func (p *KeyPool) recordRequest(slot *keySlot) {
+	p.mu.Lock()
+	slot.requests++
+	p.mu.Unlock()
+}
+
+// demote takes slot out of rotation when err shows the key itself is the
+// problem. It reports whether the request should move to another key.
+func (p *KeyPool) demote(slot *keySlot, err error) bool {
+	// The key's own limiter refusing the request says nothing about the
+	// key; move on without demoting it.
+	if errors.As(err, new(*ThrottledError)) {
+		return true
+	}
+	reason := classifyProviderError(err)
+	var cooldown time.Duration
+	switch reason {
+	case "rate_limit":
+		cooldown = p.config.RateLimitCooldown
+	case "auth":
+		cooldown = p.config.AuthCooldown
+	default:
+		return false
+	}
+
+	p.mu.Lock()
+	until := p.now().Add(cooldown)
+	slot.demotions++
+	slot.demotedUntil = until
+	slot.reason = reason
+	onDemote := p.config.OnDemote
+	p.mu.Unlock()
+
+	if onDemote != nil {
+		onDemote(slot.ID, reason, until, err)
+	}
+	return true
+}
+
+// Status returns the rotation state of every key.
+func (p *KeyPool) Status() []KeyStatus {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := p.now()
+	statuses := make([]KeyStatus, 0, len(p.keys))
+	for _, slot := range p.keys {
+		status := KeyStatus{
+			ID:        slot.ID,
+			Weight:    slot.Weight,
+			Requests:  slot.requests,
+			Demotions: slot.demotions,
+		}
+		if now.Before(slot.demotedUntil) {
+			status.DemotedUntil = slot.demotedUntil
+			status.Reason = slot.reason
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses
+}
+
+// first returns the provider of the first key, which stands in for the pool
+// in Name, Models, and SupportsTools.
+func (p *KeyPool) first() LLMProvider {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if len(p.keys) == 0 {
+		return nil
+	}
+	return p.keys[0].Provider
+}
+
+// Name implements LLMProvider.
+func (p *KeyPool) Name() string {
+	if provider := p.first(); provider != nil {
+		return provider.Name()
+	}
+	return "keypool"
+}
+
+// Models implements LLMProvider.
+func (p *KeyPool) Models() []Model {
+	if provider := p.first(); provider != nil {
+		return provider.Models()
+	}
+	return nil
+}
+
+// SupportsTools implements LLMProvider.
+func (p *KeyPool) SupportsTools() bool {
+	if provider := p.first(); provider != nil {
+		return provider.SupportsTools()
+	}
+	return false
+}
+
+func drainChunks(stream <-chan *CompletionChunk) {
+	for range stream {
+	}
+}
@@ -0,0 +1,134 @@
+package agent
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// streamErrorProvider reports err as the first chunk, the way streaming
+// providers surface HTTP errors.
+type streamErrorProvider struct {
+	err       error
+	callCount atomic.Int32
+}
+
+func (p *streamErrorProvider) Complete(ctx context.Context, req *CompletionRequest) (<-chan *CompletionChunk, error) {
+	p.callCount.Add(1)
+	ch := make(chan *CompletionChunk, 1)
+	ch <- &CompletionChunk{Error: p.err}
+	close(ch)
+	return ch, nil
+}
+
+func (p *streamErrorProvider) Name() string        { return "anthropic" }
+func (p *streamErrorProvider) Models() []Model     { return nil }
+func (p *streamErrorProvider) SupportsTools() bool { return true }
+
+func collectChunks(t *testing.T, ch <-chan *CompletionChunk) (string, error) {
+	t.Helper()
+	var text strings.Builder
+	var err error
+	for chunk := range ch {
+		if chunk.Error != nil {
+			err = chunk.Error
+		}
+		text.WriteString(chunk.Text)
+	}
+	return text.String(), err
+}
+
+func TestKeyPoolDemotesRejectedKeys(t *testing.T) {
+	revoked := &streamErrorProvider{err: errors.New("anthropic: status=401 invalid api key")}
+	limited := &failingProvider{name: "anthropic", err: errors.New("429 too many requests")}
+	healthy := &successProvider{name: "anthropic"}
+
+	var demoted []string
+	pool := NewKeyPool([]PooledKey{
+		{ID: "revoked", Provider: revoked},
+		{ID: "limited", Provider: limited},
+		{ID: "healthy", Provider: healthy},
+	}, KeyPoolConfig{
+		RateLimitCooldown: time.Minute,
+		AuthCooldown:      time.Hour,
+		OnDemote: func(keyID, reason string, until time.Time, err error) {
+			demoted = append(demoted, keyID+":"+reason)
+		},
+	})
+	// Always pick the first healthy key so the order is deterministic.
+	pool.intn = func(int) int { return 0 }
+
+	ch, err := pool.Complete(context.Background(), &CompletionRequest{})
+	if err != nil {
+		t.Fatalf("Complete() error = %v", err)
+	}
+	if text, err := collectChunks(t, ch); err != nil || text != "success" {
+		t.Fatalf("Complete() = %q, %v", text, err)
+	}
+	if len(demoted) != 2 {
+		t.Fatalf("demoted = %v, want both failing keys", demoted)
+	}
+
+	// Demoted keys are skipped until their cooldown ends.
+	for i := 0; i < 5; i++ {
+		ch, _ := pool.Complete(context.Background(), &CompletionRequest{})
+		if _, err := collectChunks(t, ch); err != nil {
+			t.Fatalf("request %d: %v", i, err)
+		}
+	}
+	if revoked.callCount.Load() != 1 || limited.callCount.Load() != 1 || healthy.callCount.Load() != 6 {
+		t.Fatalf("calls revoked=%d limited=%d healthy=%d", revoked.callCount.Load(), limited.callCount.Load(), healthy.callCount.Load())
+	}
+
+	statuses := pool.Status()
+	if statuses[0].Reason != "auth" || statuses[1].Reason != "rate_limit" || !statuses[2].DemotedUntil.IsZero() {
+		t.Fatalf("statuses = %+v", statuses)
+	}
+
+	pool.now = func() time.Time { return time.Now().Add(2 * time.Minute) }
+	if got := pool.order(); got[len(got)-1].ID != "revoked" {
+		t.Fatalf("expected only the revoked key to stay demoted, got last %q", got[len(got)-1].ID)
+	}
+}
+
+func TestKeyPoolDoesNotRetryOtherErrors(t *testing.T) {
+	bad := &failingProvider{name: "openai", err: errors.New("400 bad request: invalid model")}
+	other := &successProvider{name: "openai"}
+	pool := NewKeyPool([]PooledKey{
+		{ID: "a", Weight: 1, Provider: bad},
+		{ID: "b", Weight: 1, Provider: other},
+	}, KeyPoolConfig{RateLimitCooldown: time.Minute, AuthCooldown: time.Hour})
+
+	for i := 0; i < 20; i++ {
+		ch, _ := pool.Complete(context.Background(), &CompletionRequest{})
+		_, err := collectChunks(t, ch)
+		if err != nil && !strings.Contains(err.Error(), "bad request") {
+			t.Fatalf("unexpected error %v", err)
+		}
+	}
+	if bad.callCount.Load()+other.callCount.Load() != 20 {
+		t.Fatalf("expected one attempt per request, got %d", bad.callCount.Load()+other.callCount.Load())
+	}
+}
+
+func TestKeyPoolReplace(t *testing.T) {
+	old := &streamErrorProvider{err: errors.New("401 unauthorized")}
+	pool := NewKeyPool([]PooledKey{{ID: "k1", Provider: old}}, KeyPoolConfig{AuthCooldown: time.Hour})
+	ch, _ := pool.Complete(context.Background(), &CompletionRequest{})
+	if _, err := collectChunks(t, ch); err == nil {
+		t.Fatal("expected the only key to fail")
+	}
+
+	rotated := &successProvider{name: "anthropic"}
+	pool.Replace([]PooledKey{{ID: "k1", Provider: rotated}})
+	ch, _ = pool.Complete(context.Background(), &CompletionRequest{})
+	if text, err := collectChunks(t, ch); err != nil || text != "success" {
+		t.Fatalf("after Replace: %q, %v", text, err)
+	}
+	if status := pool.Status(); len(status) != 1 || status[0].Weight != 1 || !status[0].DemotedUntil.IsZero() {
+		t.Fatalf("status after Replace = %+v", status)
+	}
+}
@@ -443,9 +443,9 @@ func (l *AgenticLoop) initializeState(ctx context.Context, session *models.Sessi
 		if msg.BranchID != "" {
 			state.BranchID = msg.BranchID
 		} else {
-			branch, branchErr := l.config.BranchStore.EnsurePrimaryBranch(ctx, session.ID)
+			branch, branchErr := sessions.ActiveBranch(ctx, l.config.BranchStore, session)
 			if branchErr != nil {
-				return fmt.Errorf("failed to ensure primary branch: %w", branchErr)
+				return fmt.Errorf("failed to resolve active branch: %w", branchErr)
 			}
 			state.BranchID = branch.ID
 			msg.BranchID = branch.ID
@@ -511,7 +511,7 @@ func (l *AgenticLoop) buildInitialMessages(ctx context.Context, state *LoopState
 		packOpts = *l.config.PackOptions
 	}
 	packer := agentctx.NewPacker(packOpts)
-	packResult := packer.PackWithDiagnostics(state.History, msg, summary)
+	packResult := packer.PackWithQuotes(state.History, quotesFromContext(ctx), msg, summary)
 	packed := packResult.Messages
 
 	systemParts := make([]string, 0)
@@ -721,7 +721,8 @@ func (l *AgenticLoop) executeToolsPhase(ctx context.Context, session *models.Ses
 				continue
 			case ApprovalPending:
 				var approvalID string
-				if req, err := approvalChecker.CreateApprovalRequest(ctx, session.AgentID, session.ID, tc, reason); err == nil && req != nil {
+				diff := previewToolChange(ctx, l.executor.registry, tc, nil)
+				if req, err := approvalChecker.CreateApprovalRequestWithDiff(ctx, session.AgentID, session.ID, tc, reason, diff); err == nil && req != nil {
 					approvalID = req.ID
 				}
 				content := "approval required for tool: " + tc.Name
@@ -740,6 +741,7 @@ func (l *AgenticLoop) executeToolsPhase(ctx context.Context, session *models.Ses
 					Stage:        models.ToolEventApprovalRequired,
 					Error:        res.Content,
 					PolicyReason: reason,
+					Diff:         diff,
 					FinishedAt:   time.Now(),
 				})
 				l.persistToolResult(ctx, session, state.AssistantMsgID, tc, res, resolver)
@@ -760,6 +762,7 @@ func (l *AgenticLoop) executeToolsPhase(ctx context.Context, session *models.Ses
 					ToolName:   tc.Name,
 					Stage:      models.ToolEventApprovalRequired,
 					Error:      res.Content,
+					Diff:       previewToolChange(ctx, l.executor.registry, tc, nil),
 					FinishedAt: time.Now(),
 				})
 				l.persistToolResult(ctx, session, state.AssistantMsgID, tc, res, resolver)
@@ -950,7 +953,7 @@ func (l *AgenticLoop) persistToolMessage(ctx context.Context, session *models.Se
 		return nil
 	}
 	resolver, _, _ := toolPolicyFromContext(ctx)
-	persistResults := guardToolResults(l.config.ToolResultGuard, toolCalls, toolResults, resolver)
+	persistResults := guardToolResults(ctx, l.config.ToolResultGuard, toolCalls, toolResults, resolver)
 	resultsForStorage := make([]models.ToolResult, len(persistResults))
 	for i := range persistResults {
 		resultsForStorage[i] = persistResults[i]
@@ -982,11 +985,11 @@ func (l *AgenticLoop) appendMessage(ctx context.Context, session *models.Session
 	}
 	if l.config != nil && l.config.BranchStore != nil {
 		if branch == "" {
-			primary, err := l.config.BranchStore.EnsurePrimaryBranch(ctx, session.ID)
+			active, err := sessions.ActiveBranch(ctx, l.config.BranchStore, session)
 			if err != nil {
 				return err
 			}
-			branch = primary.ID
+			branch = active.ID
 		}
 		msg.BranchID = branch
 		return l.config.BranchStore.AppendMessageToBranch(ctx, session.ID, branch, msg)
@@ -1048,7 +1051,7 @@ func (l *AgenticLoop) persistToolResult(ctx context.Context, session *models.Ses
 	if l.config.ToolEvents == nil || session == nil {
 		return
 	}
-	guarded := guardToolResult(l.config.ToolResultGuard, tc.Name, res, resolver)
+	guarded := guardToolResult(ctx, l.config.ToolResultGuard, tc.Name, res, resolver)
 	if err := l.config.ToolEvents.AddToolResult(ctx, session.ID, assistantMsgID, &tc, &guarded); err != nil {
 		slog.Default().Debug("failed to persist tool result event", "error", err, "tool", tc.Name, "tool_call_id", tc.ID)
 	}
@@ -0,0 +1,134 @@
+package agent
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"github.com/haasonsaas/nexus/pkg/models"
+)
+
+// LoopDetection stops runs that repeat the same tool calls without making
+// progress.
+type LoopDetection struct {
+	// Enabled turns loop detection on.
+	Enabled bool
+
+	// MaxRepeats is how many times a run may call a tool with identical
+	// arguments. The next identical call stops the run. Defaults to 5.
+	MaxRepeats int
+
+	// MaxStalledIterations is how many iterations in a row may make no
+	// progress, meaning every call repeats an earlier one and gets the same
+	// result. Defaults to 3.
+	MaxStalledIterations int
+}
+
+func (d LoopDetection) active() bool {
+	return d.Enabled
+}
+
+// RepeatedCallError describes why a run was stopped. It matches ErrLoopDetected.
+type RepeatedCallError struct {
+	// Tool is the repeated tool.
+	Tool string
+	// Reason explains the repetition, e.g. "called 6 times with the same
+	// arguments".
+	Reason string
+}
+
+func (e *RepeatedCallError) Error() string {
+	return fmt.Sprintf("%v: %s %s", ErrLoopDetected, e.Tool, e.Reason)
+}
+
+func (e *RepeatedCallError) Unwrap() error {
+	return ErrLoopDetected
+}
+
+// loopGuard tracks the tool calls of one run. A nil guard allows everything.
+type loopGuard struct {
+	maxRepeats int
+	maxStalled int
+	calls      map[string]int
+	results    map[string]string
+	stalled    int
+}
+
+func newLoopGuard(cfg LoopDetection) *loopGuard {
+	if !cfg.active() {
+		return nil
+	}
+	g := &loopGuard{
+		maxRepeats: cfg.MaxRepeats,
+		maxStalled: cfg.MaxStalledIterations,
+		calls:      make(map[string]int),
+		results:    make(map[string]string),
+	}
+	if g.maxRepeats <= 0 {
+		g.maxRepeats = 5
+	}
+	if g.maxStalled <= 0 {
+		g.maxStalled = 3
+	}
+	return g
+}
+
+// checkCall counts a tool call as the model emits it and fails if the call
+// has been made too many times. It runs before the call can start early, so
+// a call that trips the guard never runs.
+func (g *loopGuard) checkCall(call models.ToolCall) error {
+	if g == nil {
+		return nil
+	}
+	key := loopCallKey(call)
+	g.calls[key]++
+	if n := g.calls[key]; n > g.maxRepeats {
+		return &RepeatedCallError{Tool: call.Name, Reason: fmt.Sprintf("called %d times with the same arguments", n)}
+	}
+	return nil
+}
+
+// recordResults notes a turn's results and fails once too many turns in a
+// row have only repeated earlier calls with unchanged results.
+func (g *loopGuard) recordResults(calls []models.ToolCall, results []models.ToolResult) error {
+	if g == nil || len(calls) == 0 {
+		return nil
+	}
+	progress := false
+	for i, call := range calls {
+		key := loopCallKey(call)
+		var result string
+		if i < len(results) {
+			result = results[i].Content
+		}
+		if previous, seen := g.results[key]; !seen || previous != result {
+			progress = true
+		}
+		g.results[key] = result
+	}
+	if progress {
+		g.stalled = 0
+		return nil
+	}
+	g.stalled++
+	if g.stalled >= g.maxStalled {
+		return &RepeatedCallError{
+			Tool:   calls[len(calls)-1].Name,
+			Reason: fmt.Sprintf("returned the same result for %d turns in a row", g.stalled+1),
+		}
+	}
+	return nil
+}
+
+// loopCallKey identifies a call by tool name and arguments, ignoring JSON
+// key order and whitespace.
+func loopCallKey(call models.ToolCall) string {
+	input := bytes.TrimSpace(call.Input)
+	var decoded any
+	if err := json.Unmarshal(input, &decoded); err == nil {
+		if canonical, err := json.Marshal(decoded); err == nil {
+			input = canonical
+		}
+	}
+	return call.Name + "\x00" + string(input)
+}
@@ -0,0 +1,157 @@
+package agent
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"testing"
+
+	"github.com/haasonsaas/nexus/pkg/models"
+)
+
+func TestLoopGuardRepeatedCalls(t *testing.T) {
+	guard := newLoopGuard(LoopDetection{Enabled: true, MaxRepeats: 2, MaxStalledIterations: 10})
+	first := models.ToolCall{Name: "search", Input: json.RawMessage(`{"q":"a","n":1}`)}
+	reordered := models.ToolCall{Name: "search", Input: json.RawMessage(`{ "n": 1, "q": "a" }`)}
+	other := models.ToolCall{Name: "search", Input: json.RawMessage(`{"q":"b","n":1}`)}
+
+	for _, call := range []models.ToolCall{first, other} {
+		if err := guard.checkCall(call); err != nil {
+			t.Fatalf("first turn: %v", err)
+		}
+	}
+	if err := guard.checkCall(reordered); err != nil {
+		t.Fatalf("second identical call: %v", err)
+	}
+	err := guard.checkCall(first)
+	var repeatErr *RepeatedCallError
+	if !errors.Is(err, ErrLoopDetected) || !errors.As(err, &repeatErr) || repeatErr.Tool != "search" {
+		t.Fatalf("third identical call error = %v, want a loop error", err)
+	}
+}
+
+func TestLoopGuardStalledIterations(t *testing.T) {
+	guard := newLoopGuard(LoopDetection{Enabled: true, MaxRepeats: 100, MaxStalledIterations: 2})
+	poll := []models.ToolCall{{Name: "job_status", Input: json.RawMessage(`{"id":"1"}`)}}
+	result := func(content string) []models.ToolResult {
+		return []models.ToolResult{{Content: content}}
+	}
+
+	steps := []struct {
+		content string
+		stalled bool
+	}{
+		{"running", false},
+		{"running 40%", false},
+		{"running 40%", false},
+		{"running 80%", false},
+		{"running 80%", false},
+		{"running 80%", true},
+	}
+	for i, step := range steps {
+		err := guard.recordResults(poll, result(step.content))
+		if got := errors.Is(err, ErrLoopDetected); got != step.stalled {
+			t.Fatalf("step %d: error = %v, want stalled %v", i, err, step.stalled)
+		}
+	}
+}
+
+func TestLoopGuardDisabled(t *testing.T) {
+	guard := newLoopGuard(LoopDetection{})
+	call := []models.ToolCall{{Name: "t", Input: json.RawMessage(`{}`)}}
+	for i := 0; i < 20; i++ {
+		if err := guard.checkCall(call[0]); err != nil {
+			t.Fatalf("disabled guard error = %v", err)
+		}
+		if err := guard.recordResults(call, []models.ToolResult{{Content: "same"}}); err != nil {
+			t.Fatalf("disabled guard error = %v", err)
+		}
+	}
+}
+
+// repeatProvider calls the same tool with the same arguments every turn.
+type repeatProvider struct {
+	calls int32
+}
+
+func (p *repeatProvider) Complete(ctx context.Context, req *CompletionRequest) (<-chan *CompletionChunk, error) {
+	call := atomic.AddInt32(&p.calls, 1)
+	ch := make(chan *CompletionChunk, 2)
+	ch <- &CompletionChunk{ToolCall: &models.ToolCall{
+		ID:    fmt.Sprintf("call-%d", call),
+		Name:  "counter",
+		Input: json.RawMessage(`{"path":"/tmp/x"}`),
+	}}
+	ch <- &CompletionChunk{Done: true}
+	close(ch)
+	return ch, nil
+}
+
+func (p *repeatProvider) Name() string { return "repeat" }
+
+func (p *repeatProvider) Models() []Model { return nil }
+
+func (p *repeatProvider) SupportsTools() bool { return true }
+
+func TestProcessStopsLoopingRun(t *testing.T) {
+	provider := &repeatProvider{}
+	runtime := NewRuntimeWithOptions(provider, stubStore{}, RuntimeOptions{
+		MaxIterations:   50,
+		ToolParallelism: 1,
+		LoopDetection:   LoopDetection{Enabled: true, MaxRepeats: 10, MaxStalledIterations: 3},
+	})
+	tool := &countingTool{name: "counter"}
+	runtime.RegisterTool(tool)
+
+	session := &models.Session{ID: "session-1", Channel: models.ChannelTelegram}
+	ch, err := runtime.Process(context.Background(), session, &models.Message{Role: models.RoleUser, Content: "go"})
+	if err != nil {
+		t.Fatalf("Process() error = %v", err)
+	}
+	var gotErr error
+	for chunk := range ch {
+		if chunk.Error != nil {
+			gotErr = chunk.Error
+		}
+	}
+	if !errors.Is(gotErr, ErrLoopDetected) {
+		t.Fatalf("run error = %v, want ErrLoopDetected", gotErr)
+	}
+	// The first call sets the result; three more unchanged turns stop the run.
+	if calls := atomic.LoadInt32(&tool.calls); calls != 4 {
+		t.Fatalf("tool ran %d times, want 4", calls)
+	}
+}
+
+func TestProcessStopsRepeatedCallBeforeEarlyStart(t *testing.T) {
+	provider := &repeatProvider{}
+	runtime := NewRuntimeWithOptions(provider, stubStore{}, RuntimeOptions{
+		MaxIterations:   50,
+		ToolParallelism: 1,
+		EarlyToolStart:  EarlyToolStart{Enabled: true},
+		LoopDetection:   LoopDetection{Enabled: true, MaxRepeats: 2, MaxStalledIterations: 100},
+	})
+	tool := &countingTool{name: "counter"}
+	runtime.RegisterTool(tool)
+
+	session := &models.Session{ID: "session-1", Channel: models.ChannelTelegram}
+	ch, err := runtime.Process(context.Background(), session, &models.Message{Role: models.RoleUser, Content: "go"})
+	if err != nil {
+		t.Fatalf("Process() error = %v", err)
+	}
+	var gotErr error
+	for chunk := range ch {
+		if chunk.Error != nil {
+			gotErr = chunk.Error
+		}
+	}
+	if !errors.Is(gotErr, ErrLoopDetected) {
+		t.Fatalf("run error = %v, want ErrLoopDetected", gotErr)
+	}
+	// The third identical call trips the guard before it can start early.
+	if calls := atomic.LoadInt32(&tool.calls); calls != 2 {
+		t.Fatalf("tool ran %d times, want 2", calls)
+	}
+}
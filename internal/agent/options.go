@@ -24,6 +24,20 @@ type RuntimeOptions struct {
 	// ToolRetryBackoff waits between retry attempts.
 	ToolRetryBackoff time.Duration
 
+	// ToolMaxRetryBackoff caps exponential retry backoff (0 = constant backoff).
+	ToolMaxRetryBackoff time.Duration
+
+	// ToolRetryJitter spreads each retry backoff by up to this fraction.
+	ToolRetryJitter float64
+
+	// ToolRetryOn limits retries to these error classes (empty = any error).
+	ToolRetryOn []ToolErrorType
+
+	// IdempotentTools lists tool patterns that are safe to retry in addition
+	// to tools that declare themselves idempotent. Other tools are never
+	// retried.
+	IdempotentTools []string
+
 	// DisableToolEvents disables ToolEvent emission while processing.
 	DisableToolEvents bool
 
@@ -48,6 +62,23 @@ type RuntimeOptions struct {
 	// ToolResultGuard redacts tool results before persistence.
 	ToolResultGuard ToolResultGuard
 
+	// ToolSchemaValidation checks tool call arguments before execution.
+	ToolSchemaValidation ToolSchemaValidation
+
+	// EarlyToolStart runs complete tool calls before the model turn ends.
+	EarlyToolStart EarlyToolStart
+
+	// LoopDetection stops runs that keep repeating the same tool calls.
+	LoopDetection LoopDetection
+
+	// Checkpoints periodically saves the state of long runs so they can be
+	// resumed.
+	Checkpoints CheckpointOptions
+
+	// TraceReasoning keeps raw reasoning text in model.reasoning events.
+	// When false the text is redacted and only its size is recorded.
+	TraceReasoning bool
+
 	// Logger receives runtime diagnostics.
 	Logger *slog.Logger
 }
@@ -83,6 +114,18 @@ func mergeRuntimeOptions(base RuntimeOptions, override RuntimeOptions) RuntimeOp
 	if override.ToolRetryBackoff > 0 {
 		merged.ToolRetryBackoff = override.ToolRetryBackoff
 	}
+	if override.ToolMaxRetryBackoff > 0 {
+		merged.ToolMaxRetryBackoff = override.ToolMaxRetryBackoff
+	}
+	if override.ToolRetryJitter > 0 {
+		merged.ToolRetryJitter = override.ToolRetryJitter
+	}
+	if len(override.ToolRetryOn) > 0 {
+		merged.ToolRetryOn = override.ToolRetryOn
+	}
+	if len(override.IdempotentTools) > 0 {
+		merged.IdempotentTools = override.IdempotentTools
+	}
 	if override.DisableToolEvents {
 		merged.DisableToolEvents = true
 	}
@@ -105,7 +148,23 @@ func mergeRuntimeOptions(base RuntimeOptions, override RuntimeOptions) RuntimeOp
 		merged.JobStore = override.JobStore
 	}
 	if override.ToolResultGuard.active() {
+		artifacts := merged.ToolResultGuard.Artifacts
 		merged.ToolResultGuard = override.ToolResultGuard
+		if merged.ToolResultGuard.Artifacts == nil {
+			merged.ToolResultGuard.Artifacts = artifacts
+		}
+	}
+	if override.ToolSchemaValidation.active() {
+		merged.ToolSchemaValidation = override.ToolSchemaValidation
+	}
+	if override.EarlyToolStart.active() {
+		merged.EarlyToolStart = override.EarlyToolStart
+	}
+	if override.LoopDetection.active() {
+		merged.LoopDetection = override.LoopDetection
+	}
+	if override.Checkpoints.active() {
+		merged.Checkpoints = override.Checkpoints
 	}
 	if override.Logger != nil {
 		merged.Logger = override.Logger
@@ -0,0 +1,114 @@
+package agent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/haasonsaas/nexus/pkg/models"
+)
+
+// MaxPlanSteps bounds the number of steps in a run's plan.
+const MaxPlanSteps = 50
+
+// ErrNoPlan is returned when updating a step before a plan exists.
+var ErrNoPlan = errors.New("no plan has been created for this run")
+
+// RunPlan is the structured plan of one run. The plan tool changes it through
+// the run's context; every change is emitted as a plan.updated event, saved
+// with the run's checkpoint, and attached to the run's assistant messages.
+type RunPlan struct {
+	mu     sync.Mutex
+	plan   *models.Plan
+	onSave func(ctx context.Context, plan *models.Plan)
+}
+
+// NewRunPlan returns a run plan starting from plan, which may be nil.
+// onSave is called with a copy of the plan after every change.
+func NewRunPlan(plan *models.Plan, onSave func(ctx context.Context, plan *models.Plan)) *RunPlan {
+	return &RunPlan{plan: plan.Clone(), onSave: onSave}
+}
+
+// Current returns a copy of the plan, or nil if none was created.
+func (p *RunPlan) Current() *models.Plan {
+	if p == nil {
+		return nil
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.plan.Clone()
+}
+
+// Create replaces the plan with the given steps, all pending.
+func (p *RunPlan) Create(ctx context.Context, title string, steps []string) (*models.Plan, error) {
+	if len(steps) == 0 {
+		return nil, errors.New("a plan needs at least one step")
+	}
+	if len(steps) > MaxPlanSteps {
+		return nil, fmt.Errorf("a plan has at most %d steps", MaxPlanSteps)
+	}
+	plan := &models.Plan{Title: strings.TrimSpace(title), Steps: make([]models.PlanStep, 0, len(steps))}
+	for i, step := range steps {
+		step = strings.TrimSpace(step)
+		if step == "" {
+			return nil, fmt.Errorf("step %d is empty", i+1)
+		}
+		plan.Steps = append(plan.Steps, models.PlanStep{Title: step, Status: models.PlanStepPending})
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.plan = plan
+	return p.saveLocked(ctx), nil
+}
+
+// UpdateStep sets the status of step (1-based) and, if note is not empty,
+// its note.
+func (p *RunPlan) UpdateStep(ctx context.Context, step int, status models.PlanStepStatus, note string) (*models.Plan, error) {
+	if !status.Valid() {
+		return nil, fmt.Errorf("unknown step status %q", status)
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.plan == nil {
+		return nil, ErrNoPlan
+	}
+	if step < 1 || step > len(p.plan.Steps) {
+		return nil, fmt.Errorf("step %d is out of range (the plan has %d steps)", step, len(p.plan.Steps))
+	}
+	p.plan.Steps[step-1].Status = status
+	if note = strings.TrimSpace(note); note != "" {
+		p.plan.Steps[step-1].Note = note
+	}
+	return p.saveLocked(ctx), nil
+}
+
+func (p *RunPlan) saveLocked(ctx context.Context) *models.Plan {
+	p.plan.UpdatedAt = time.Now()
+	plan := p.plan.Clone()
+	if p.onSave != nil {
+		p.onSave(ctx, plan)
+	}
+	return plan
+}
+
+type runPlanKey struct{}
+
+// WithRunPlan attaches a run's plan to ctx for the plan tool.
+func WithRunPlan(ctx context.Context, plan *RunPlan) context.Context {
+	return context.WithValue(ctx, runPlanKey{}, plan)
+}
+
+// RunPlanFromContext returns the plan of the run executing a tool, or nil
+// outside a run.
+func RunPlanFromContext(ctx context.Context) *RunPlan {
+	if ctx == nil {
+		return nil
+	}
+	plan, _ := ctx.Value(runPlanKey{}).(*RunPlan)
+	return plan
+}
@@ -0,0 +1,125 @@
+package agent
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/haasonsaas/nexus/pkg/models"
+)
+
+func TestRunPlan(t *testing.T) {
+	ctx := context.Background()
+	var saved []*models.Plan
+	plan := NewRunPlan(nil, func(_ context.Context, p *models.Plan) { saved = append(saved, p) })
+
+	if _, err := plan.UpdateStep(ctx, 1, models.PlanStepCompleted, ""); !errors.Is(err, ErrNoPlan) {
+		t.Fatalf("expected ErrNoPlan, got %v", err)
+	}
+	if _, err := plan.Create(ctx, "Release", nil); err == nil {
+		t.Fatal("expected an error for a plan without steps")
+	}
+	if _, err := plan.Create(ctx, "Release", []string{"build", " "}); err == nil {
+		t.Fatal("expected an error for an empty step")
+	}
+	if _, err := plan.Create(ctx, "Release", []string{"build", "test", "tag"}); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if _, err := plan.UpdateStep(ctx, 4, models.PlanStepCompleted, ""); err == nil {
+		t.Fatal("expected an error for a step out of range")
+	}
+	if _, err := plan.UpdateStep(ctx, 1, "done", ""); err == nil {
+		t.Fatal("expected an error for an unknown status")
+	}
+	current, err := plan.UpdateStep(ctx, 2, models.PlanStepSkipped, "no tests")
+	if err != nil {
+		t.Fatalf("UpdateStep() error = %v", err)
+	}
+	if current.Steps[1].Status != models.PlanStepSkipped || current.Steps[1].Note != "no tests" || current.Done() != 1 {
+		t.Fatalf("unexpected plan %+v", current)
+	}
+	if len(saved) != 2 {
+		t.Fatalf("expected 2 saved plans, got %d", len(saved))
+	}
+
+	// Copies handed out do not change with the plan.
+	current.Steps[0].Status = models.PlanStepCompleted
+	if plan.Current().Steps[0].Status != models.PlanStepPending {
+		t.Fatal("plan was changed through a copy")
+	}
+}
+
+type planStepTool struct{}
+
+func (planStepTool) Name() string { return "plan" }
+
+func (planStepTool) Description() string { return "plans" }
+
+func (planStepTool) Schema() json.RawMessage { return json.RawMessage(`{"type":"object"}`) }
+
+func (planStepTool) Execute(ctx context.Context, params json.RawMessage) (*ToolResult, error) {
+	plan := RunPlanFromContext(ctx)
+	if plan == nil {
+		return &ToolResult{Content: "no run plan", IsError: true}, nil
+	}
+	if _, err := plan.Create(ctx, "Deploy", []string{"build", "ship"}); err != nil {
+		return nil, err
+	}
+	if _, err := plan.UpdateStep(ctx, 1, models.PlanStepCompleted, ""); err != nil {
+		return nil, err
+	}
+	return &ToolResult{Content: "ok"}, nil
+}
+
+func TestProcessPlanUpdates(t *testing.T) {
+	provider := &sequenceProvider{supportsTools: true, responses: [][]CompletionChunk{
+		{{ToolCall: &models.ToolCall{ID: "call-1", Name: "plan", Input: json.RawMessage(`{}`)}}, {Done: true}},
+		{{Text: "done"}, {Done: true}},
+	}}
+	store := newMemoryStore()
+	runtime := NewRuntime(provider, store)
+	runtime.RegisterTool(planStepTool{})
+
+	session := &models.Session{ID: "session-1", Channel: models.ChannelSlack}
+	ch, err := runtime.Process(context.Background(), session, &models.Message{ID: "msg-1", Role: models.RoleUser, Content: "deploy"})
+	if err != nil {
+		t.Fatalf("Process() error = %v", err)
+	}
+	var plans []*models.Plan
+	for chunk := range ch {
+		if chunk.Error != nil {
+			t.Fatalf("unexpected error: %v", chunk.Error)
+		}
+		if chunk.Plan != nil {
+			plans = append(plans, chunk.Plan)
+		}
+	}
+	if len(plans) != 2 || plans[0].Done() != 0 || plans[1].Done() != 1 {
+		t.Fatalf("unexpected plan chunks %+v", plans)
+	}
+
+	// The final assistant message carries the plan.
+	messages := store.getMessages("session-1")
+	last := messages[len(messages)-1]
+	plan, ok := last.Metadata["plan"].(*models.Plan)
+	if last.Role != models.RoleAssistant || !ok || plan.Title != "Deploy" || plan.Done() != 1 {
+		t.Fatalf("expected the plan on the final message, got %+v", last.Metadata)
+	}
+}
+
+func TestStatsCollectorPlan(t *testing.T) {
+	collector := NewStatsCollector("run-1")
+	emitter := NewEventEmitter("run-1", NewCallbackSink(collector.OnEvent))
+	plan := &models.Plan{Steps: []models.PlanStep{
+		{Title: "a", Status: models.PlanStepCompleted},
+		{Title: "b", Status: models.PlanStepInProgress},
+	}}
+	emitter.PlanUpdated(context.Background(), plan)
+	emitter.PlanUpdated(context.Background(), plan)
+
+	stats := collector.Stats()
+	if stats.PlanUpdates != 2 || stats.PlanSteps != 2 || stats.PlanStepsDone != 1 {
+		t.Fatalf("unexpected plan stats %+v", stats)
+	}
+}
@@ -0,0 +1,347 @@
+package agent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	ctxwindow "github.com/haasonsaas/nexus/internal/context"
+)
+
+// ProviderLimits bounds the request rate, token rate, and concurrency of
+// one provider or API key. Zero fields are unlimited.
+type ProviderLimits struct {
+	// RequestsPerMinute caps requests started per minute.
+	RequestsPerMinute int
+
+	// TokensPerMinute caps input plus output tokens per minute. A request
+	// reserves its estimated input tokens and MaxTokens up front; the
+	// reservation is corrected with the reported usage once it completes.
+	TokensPerMinute int
+
+	// MaxConcurrent caps requests in flight at once.
+	MaxConcurrent int
+
+	// MaxWait is the longest a request queues for capacity before it
+	// fails with a rate limit error. A request whose context deadline
+	// comes sooner fails as soon as the wait is known to outlast it.
+	// Default: 30s.
+	MaxWait time.Duration
+}
+
+// Enabled reports whether any limit is set.
+func (l ProviderLimits) Enabled() bool {
+	return l.RequestsPerMinute > 0 || l.TokensPerMinute > 0 || l.MaxConcurrent > 0
+}
+
+// ProviderLimiterHooks observe a ProviderLimiter, such as for metrics.
+type ProviderLimiterHooks struct {
+	// OnWait is called when a request waited for capacity. reason is
+	// "concurrency", "requests", "tokens", or "retry_after".
+	OnWait func(name, reason string, wait time.Duration)
+
+	// OnReject is called when a request is refused because the wait would
+	// outlast MaxWait or its deadline.
+	OnReject func(name, reason string)
+
+	// OnPause is called when a 429 response pauses the limiter.
+	OnPause func(name string, pause time.Duration)
+}
+
+// ThrottledError is returned when a request cannot get capacity within
+// ProviderLimits.MaxWait or its deadline. Its message names it a rate
+// limit so failover and key pools treat it like a 429.
+type ThrottledError struct {
+	Name   string
+	Reason string
+	Wait   time.Duration
+}
+
+func (e *ThrottledError) Error() string {
+	return fmt.Sprintf("rate limit: %s needs %s for %s capacity, longer than the request may wait", e.Name, e.Wait.Round(time.Millisecond), e.Reason)
+}
+
+// ProviderLimiter holds the token buckets and concurrency slots of one
+// provider or API key. Every provider wrapped with it shares the limits, so
+// one limiter serves all profiles and routes that use the same account.
+type ProviderLimiter struct {
+	name   string
+	limits ProviderLimits
+	hooks  ProviderLimiterHooks
+	now    func() time.Time
+	slots  chan struct{}
+
+	mu          sync.Mutex
+	requests    *tokenBucket
+	tokens      *tokenBucket
+	pausedUntil time.Time
+}
+
+// NewProviderLimiter creates a limiter named name, as reported to hooks.
+func NewProviderLimiter(name string, limits ProviderLimits, hooks ProviderLimiterHooks) *ProviderLimiter {
+	if limits.MaxWait <= 0 {
+		limits.MaxWait = 30 * time.Second
+	}
+	l := &ProviderLimiter{name: name, limits: limits, hooks: hooks, now: time.Now}
+	now := l.now()
+	if limits.RequestsPerMinute > 0 {
+		l.requests = newTokenBucket(limits.RequestsPerMinute, now)
+	}
+	if limits.TokensPerMinute > 0 {
+		l.tokens = newTokenBucket(limits.TokensPerMinute, now)
+	}
+	if limits.MaxConcurrent > 0 {
+		l.slots = make(chan struct{}, limits.MaxConcurrent)
+	}
+	return l
+}
+
+// Wrap returns provider limited by l.
+func (l *ProviderLimiter) Wrap(provider LLMProvider) LLMProvider {
+	return &limitedProvider{LLMProvider: provider, limiter: l}
+}
+
+// acquire waits for capacity for a request of estimated tokens and returns
+// the function that releases its concurrency slot.
+func (l *ProviderLimiter) acquire(ctx context.Context, tokens int) (func(), error) {
+	start := l.now()
+	deadline := start.Add(l.limits.MaxWait)
+	if ctxDeadline, ok := ctx.Deadline(); ok && ctxDeadline.Before(deadline) {
+		deadline = ctxDeadline
+	}
+
+	release := func() {}
+	waitReason := ""
+	if l.slots != nil {
+		select {
+		case l.slots <- struct{}{}:
+		default:
+			timer := time.NewTimer(deadline.Sub(l.now()))
+			select {
+			case l.slots <- struct{}{}:
+				timer.Stop()
+				waitReason = "concurrency"
+			case <-ctx.Done():
+				timer.Stop()
+				return nil, ctx.Err()
+			case <-timer.C:
+				return nil, l.reject("concurrency", l.limits.MaxWait)
+			}
+		}
+		var once sync.Once
+		release = func() { once.Do(func() { <-l.slots }) }
+	}
+
+	for {
+		l.mu.Lock()
+		now := l.now()
+		wait, reason := l.delay(now, tokens)
+		if wait <= 0 {
+			if l.requests != nil {
+				l.requests.take(1)
+			}
+			if l.tokens != nil {
+				l.tokens.take(float64(tokens))
+			}
+			l.mu.Unlock()
+			if waitReason != "" && l.hooks.OnWait != nil {
+				l.hooks.OnWait(l.name, waitReason, l.now().Sub(start))
+			}
+			return release, nil
+		}
+		l.mu.Unlock()
+
+		if now.Add(wait).After(deadline) {
+			release()
+			return nil, l.reject(reason, wait)
+		}
+		if err := sleepContext(ctx, wait); err != nil {
+			release()
+			return nil, err
+		}
+		waitReason = reason
+	}
+}
+
+// delay returns how long a request must wait and why. It must be called
+// with l.mu held.
+func (l *ProviderLimiter) delay(now time.Time, tokens int) (time.Duration, string) {
+	wait, reason := l.pausedUntil.Sub(now), "retry_after"
+	if l.requests != nil {
+		if d := l.requests.wait(now, 1); d > wait {
+			wait, reason = d, "requests"
+		}
+	}
+	if l.tokens != nil {
+		if d := l.tokens.wait(now, float64(tokens)); d > wait {
+			wait, reason = d, "tokens"
+		}
+	}
+	return wait, reason
+}
+
+func (l *ProviderLimiter) reject(reason string, wait time.Duration) error {
+	if l.hooks.OnReject != nil {
+		l.hooks.OnReject(l.name, reason)
+	}
+	return &ThrottledError{Name: l.name, Reason: reason, Wait: wait}
+}
+
+// settle corrects a request's token reservation with its reported usage.
+func (l *ProviderLimiter) settle(reserved, used int) {
+	if l.tokens == nil || used <= 0 {
+		return
+	}
+	l.mu.Lock()
+	l.tokens.take(float64(used - reserved))
+	l.mu.Unlock()
+}
+
+// observe pauses the limiter for the Retry-After delay of a 429 error and
+// empties the request bucket, since the provider counts differently than
+// the configured limits suggest.
+func (l *ProviderLimiter) observe(err error) {
+	if err == nil || errors.As(err, new(*ThrottledError)) || classifyProviderError(err) != "rate_limit" {
+		return
+	}
+	pause := RetryAfter(err)
+	l.mu.Lock()
+	now := l.now()
+	if l.requests != nil {
+		l.requests.drain(now)
+	}
+	if pause > 0 && now.Add(pause).After(l.pausedUntil) {
+		l.pausedUntil = now.Add(pause)
+	}
+	l.mu.Unlock()
+	if pause > 0 && l.hooks.OnPause != nil {
+		l.hooks.OnPause(l.name, pause)
+	}
+}
+
+// RetryAfter returns the delay a provider asked for before the next
+// request, from an error carrying the Retry-After of a 429 response, or
+// zero.
+func RetryAfter(err error) time.Duration {
+	var hinted interface{ RetryAfterHint() time.Duration }
+	if errors.As(err, &hinted) {
+		return hinted.RetryAfterHint()
+	}
+	return 0
+}
+
+// limitedProvider is an LLMProvider whose requests pass through a
+// ProviderLimiter.
+type limitedProvider struct {
+	LLMProvider
+	limiter *ProviderLimiter
+}
+
+// Complete implements LLMProvider.
+func (p *limitedProvider) Complete(ctx context.Context, req *CompletionRequest) (<-chan *CompletionChunk, error) {
+	reserved := estimateRequestTokens(req)
+	release, err := p.limiter.acquire(ctx, reserved)
+	if err != nil {
+		return nil, err
+	}
+	stream, err := p.LLMProvider.Complete(ctx, req)
+	if err != nil {
+		release()
+		p.limiter.observe(err)
+		return nil, err
+	}
+
+	out := make(chan *CompletionChunk)
+	go func() {
+		defer close(out)
+		defer release()
+		var input, output int
+		for chunk := range stream {
+			if chunk != nil {
+				p.limiter.observe(chunk.Error)
+				input = max(input, chunk.InputTokens)
+				output = max(output, chunk.OutputTokens)
+			}
+			out <- chunk
+		}
+		p.limiter.settle(reserved, input+output)
+	}()
+	return out, nil
+}
+
+// estimateRequestTokens estimates the input tokens of req plus the output
+// tokens it may produce.
+func estimateRequestTokens(req *CompletionRequest) int {
+	if req == nil {
+		return 0
+	}
+	total := ctxwindow.EstimateTokens(req.System) + req.MaxTokens
+	for _, msg := range req.Messages {
+		total += ctxwindow.EstimateTokens(msg.Content) + 4
+		for _, result := range msg.ToolResults {
+			total += ctxwindow.EstimateTokens(result.Content)
+		}
+		for _, call := range msg.ToolCalls {
+			total += ctxwindow.EstimateTokens(string(call.Input))
+		}
+	}
+	return total
+}
+
+// tokenBucket refills at capacity per minute. Its level may go negative
+// when usage turns out larger than reserved.
+type tokenBucket struct {
+	capacity  float64
+	level     float64
+	perSecond float64
+	last      time.Time
+}
+
+func newTokenBucket(perMinute int, now time.Time) *tokenBucket {
+	return &tokenBucket{
+		capacity:  float64(perMinute),
+		level:     float64(perMinute),
+		perSecond: float64(perMinute) / 60,
+		last:      now,
+	}
+}
+
+func (b *tokenBucket) refill(now time.Time) {
+	if elapsed := now.Sub(b.last).Seconds(); elapsed > 0 {
+		b.level = min(b.capacity, b.level+elapsed*b.perSecond)
+		b.last = now
+	}
+}
+
+// wait returns how long until n tokens are available. Requests larger than
+// the bucket wait for a full bucket.
+func (b *tokenBucket) wait(now time.Time, n float64) time.Duration {
+	b.refill(now)
+	n = min(n, b.capacity)
+	if b.level >= n {
+		return 0
+	}
+	return time.Duration((n - b.level) / b.perSecond * float64(time.Second))
+}
+
+func (b *tokenBucket) take(n float64) {
+	b.level = min(b.capacity, b.level-n)
+}
+
+func (b *tokenBucket) drain(now time.Time) {
+	b.refill(now)
+	b.level = min(b.level, 0)
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
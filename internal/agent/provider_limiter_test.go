@@ -0,0 +1,201 @@
+package agent
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+// retryAfterErr is a 429 carrying a Retry-After hint.
+type retryAfterErr struct{ after time.Duration }
+
+func (e *retryAfterErr) Error() string                 { return "429 too many requests" }
+func (e *retryAfterErr) RetryAfterHint() time.Duration { return e.after }
+
+// usageProvider streams one chunk reporting token usage, or fails with err.
+type usageProvider struct {
+	input, output int
+	err           error
+	block         chan struct{}
+}
+
+func (p *usageProvider) Complete(ctx context.Context, req *CompletionRequest) (<-chan *CompletionChunk, error) {
+	ch := make(chan *CompletionChunk, 1)
+	go func() {
+		defer close(ch)
+		if p.block != nil {
+			<-p.block
+		}
+		if p.err != nil {
+			ch <- &CompletionChunk{Error: p.err}
+			return
+		}
+		ch <- &CompletionChunk{Text: "ok", Done: true, InputTokens: p.input, OutputTokens: p.output}
+	}()
+	return ch, nil
+}
+
+func (p *usageProvider) Name() string        { return "openai" }
+func (p *usageProvider) Models() []Model     { return nil }
+func (p *usageProvider) SupportsTools() bool { return true }
+
+func TestProviderLimiter_RejectsWhenWaitExceedsMaxWait(t *testing.T) {
+	var rejected []string
+	limiter := NewProviderLimiter("openai", ProviderLimits{RequestsPerMinute: 1, MaxWait: 50 * time.Millisecond}, ProviderLimiterHooks{
+		OnReject: func(name, reason string) { rejected = append(rejected, name+":"+reason) },
+	})
+	provider := limiter.Wrap(&usageProvider{})
+
+	stream, err := provider.Complete(context.Background(), &CompletionRequest{})
+	if err != nil {
+		t.Fatalf("first Complete() error = %v", err)
+	}
+	if _, err := collectChunks(t, stream); err != nil {
+		t.Fatalf("first stream error = %v", err)
+	}
+
+	_, err = provider.Complete(context.Background(), &CompletionRequest{})
+	var throttled *ThrottledError
+	if !errors.As(err, &throttled) || throttled.Reason != "requests" {
+		t.Fatalf("second Complete() error = %v, want requests throttle", err)
+	}
+	if classifyProviderError(err) != "rate_limit" {
+		t.Errorf("throttle classified as %q, want rate_limit", classifyProviderError(err))
+	}
+	if len(rejected) != 1 || rejected[0] != "openai:requests" {
+		t.Errorf("rejected = %v", rejected)
+	}
+}
+
+func TestProviderLimiter_HonorsRetryAfter(t *testing.T) {
+	var waits []string
+	var paused time.Duration
+	limiter := NewProviderLimiter("anthropic", ProviderLimits{RequestsPerMinute: 600}, ProviderLimiterHooks{
+		OnWait:  func(name, reason string, wait time.Duration) { waits = append(waits, reason) },
+		OnPause: func(name string, pause time.Duration) { paused = pause },
+	})
+
+	limited := limiter.Wrap(&usageProvider{err: &retryAfterErr{after: 80 * time.Millisecond}})
+	stream, err := limited.Complete(context.Background(), &CompletionRequest{})
+	if err != nil {
+		t.Fatalf("Complete() error = %v", err)
+	}
+	if _, err := collectChunks(t, stream); err == nil {
+		t.Fatal("expected the 429 to reach the caller")
+	}
+	if paused != 80*time.Millisecond {
+		t.Errorf("paused = %v, want 80ms", paused)
+	}
+
+	start := time.Now()
+	stream, err = limiter.Wrap(&usageProvider{}).Complete(context.Background(), &CompletionRequest{})
+	if err != nil {
+		t.Fatalf("Complete() after 429 error = %v", err)
+	}
+	if _, err := collectChunks(t, stream); err != nil {
+		t.Fatalf("stream error = %v", err)
+	}
+	if elapsed := time.Since(start); elapsed < 70*time.Millisecond {
+		t.Errorf("request after 429 started after %v, want at least the Retry-After delay", elapsed)
+	}
+	if len(waits) != 1 || (waits[0] != "retry_after" && waits[0] != "requests") {
+		t.Errorf("waits = %v", waits)
+	}
+
+	// A deadline shorter than the pause fails fast instead of waiting.
+	limiter.observe(&retryAfterErr{after: time.Minute})
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	start = time.Now()
+	if _, err := limited.Complete(ctx, &CompletionRequest{}); !errors.As(err, new(*ThrottledError)) {
+		t.Fatalf("Complete() with short deadline error = %v", err)
+	}
+	if time.Since(start) > 50*time.Millisecond {
+		t.Error("request waited although the pause outlasts its deadline")
+	}
+}
+
+func TestProviderLimiter_CapsConcurrency(t *testing.T) {
+	limiter := NewProviderLimiter("openai", ProviderLimits{MaxConcurrent: 1, MaxWait: 30 * time.Millisecond}, ProviderLimiterHooks{})
+	block := make(chan struct{})
+	slow := limiter.Wrap(&usageProvider{block: block})
+
+	first, err := slow.Complete(context.Background(), &CompletionRequest{})
+	if err != nil {
+		t.Fatalf("first Complete() error = %v", err)
+	}
+	var throttled *ThrottledError
+	if _, err := slow.Complete(context.Background(), &CompletionRequest{}); !errors.As(err, &throttled) || throttled.Reason != "concurrency" {
+		t.Fatalf("second Complete() error = %v, want concurrency throttle", err)
+	}
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		time.Sleep(10 * time.Millisecond)
+		close(block)
+	}()
+	limiter.limits.MaxWait = time.Second
+	if _, err := collectChunks(t, first); err != nil {
+		t.Fatalf("first stream error = %v", err)
+	}
+	second, err := slow.Complete(context.Background(), &CompletionRequest{})
+	if err != nil {
+		t.Fatalf("Complete() after release error = %v", err)
+	}
+	if _, err := collectChunks(t, second); err != nil {
+		t.Fatalf("second stream error = %v", err)
+	}
+	wg.Wait()
+}
+
+func TestProviderLimiter_SettlesTokenUsage(t *testing.T) {
+	limiter := NewProviderLimiter("openai", ProviderLimits{TokensPerMinute: 1000}, ProviderLimiterHooks{})
+	stream, err := limiter.Wrap(&usageProvider{input: 300, output: 200}).Complete(context.Background(), &CompletionRequest{MaxTokens: 100})
+	if err != nil {
+		t.Fatalf("Complete() error = %v", err)
+	}
+	if _, err := collectChunks(t, stream); err != nil {
+		t.Fatalf("stream error = %v", err)
+	}
+	limiter.mu.Lock()
+	level := limiter.tokens.level
+	limiter.mu.Unlock()
+	if level < 490 || level > 520 {
+		t.Errorf("token level = %.0f, want about 500 after 500 reported tokens", level)
+	}
+}
+
+func TestKeyPoolSkipsThrottledKeyWithoutDemoting(t *testing.T) {
+	busy := NewProviderLimiter("anthropic/busy", ProviderLimits{RequestsPerMinute: 1, MaxWait: time.Millisecond}, ProviderLimiterHooks{})
+	busy.mu.Lock()
+	busy.requests.take(1)
+	busy.mu.Unlock()
+	healthy := &successProvider{name: "anthropic"}
+
+	var demoted []string
+	pool := NewKeyPool([]PooledKey{
+		{ID: "busy", Provider: busy.Wrap(&successProvider{name: "anthropic"})},
+		{ID: "healthy", Provider: healthy},
+	}, KeyPoolConfig{
+		RateLimitCooldown: time.Minute,
+		OnDemote: func(keyID, reason string, until time.Time, err error) {
+			demoted = append(demoted, keyID)
+		},
+	})
+	pool.intn = func(int) int { return 0 }
+
+	ch, err := pool.Complete(context.Background(), &CompletionRequest{})
+	if err != nil {
+		t.Fatalf("Complete() error = %v", err)
+	}
+	if text, err := collectChunks(t, ch); err != nil || text != "success" {
+		t.Fatalf("Complete() = %q, %v", text, err)
+	}
+	if len(demoted) != 0 || healthy.callCount.Load() != 1 {
+		t.Fatalf("demoted = %v, healthy calls = %d", demoted, healthy.callCount.Load())
+	}
+}
@@ -79,6 +79,19 @@ type CompletionRequest struct {
 	// Only used when EnableThinking is true. If 0, a default budget is used.
 	// Typical range: 1024-100000 tokens depending on task complexity.
 	ThinkingBudgetTokens int `json:"thinking_budget_tokens,omitempty"`
+
+	// Temperature controls sampling randomness. Nil uses the provider default.
+	Temperature *float64 `json:"temperature,omitempty"`
+
+	// TopP sets nucleus sampling. Nil uses the provider default.
+	TopP *float64 `json:"top_p,omitempty"`
+
+	// Stop lists sequences that end generation when produced.
+	Stop []string `json:"stop,omitempty"`
+
+	// ReasoningEffort asks reasoning models to think "low", "medium", or
+	// "high". Providers without an effort setting map it to a thinking budget.
+	ReasoningEffort string `json:"reasoning_effort,omitempty"`
 }
 
 // CompletionMessage represents a single message in a conversation.
@@ -161,6 +174,10 @@ type CompletionChunk struct {
 	// OutputTokens contains the number of output tokens generated by this response.
 	// Only populated in the final chunk (when Done is true).
 	OutputTokens int `json:"output_tokens,omitempty"`
+
+	// ReasoningTokens is the part of OutputTokens spent on reasoning, when the
+	// provider reports it. Only populated in the final chunk.
+	ReasoningTokens int `json:"reasoning_tokens,omitempty"`
 }
 
 // Model describes an available LLM model and its capabilities.
@@ -287,7 +304,8 @@ type ToolEventStore interface {
 
 // ResponseChunk represents a streaming response chunk from the runtime.
 // Each chunk may contain text, tool results, tool events, runtime events, or errors.
-// Consumers should check each field and handle accordingly.
+// Consumers should check each field and handle accordingly. Thinking carries
+// raw model reasoning and must never be forwarded to channels.
 type ResponseChunk struct {
 	Text          string               `json:"text,omitempty"`
 	Thinking      string               `json:"thinking,omitempty"`
@@ -296,6 +314,7 @@ type ResponseChunk struct {
 	ToolResult    *models.ToolResult   `json:"tool_result,omitempty"`
 	ToolEvent     *models.ToolEvent    `json:"tool_event,omitempty"`
 	Event         *models.RuntimeEvent `json:"event,omitempty"`
+	Plan          *models.Plan         `json:"plan,omitempty"` // The run's plan after a plan tool call
 	Error         error                `json:"-"`
 	// Artifacts contains any files/media produced by tool executions.
 	// These should be converted to message attachments when sending to channels.
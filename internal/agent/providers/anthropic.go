@@ -57,6 +57,7 @@ import (
 	"fmt"
 	"io"
 	"math"
+	"net/http"
 	"strings"
 	"time"
 
@@ -534,10 +535,23 @@ func (p *AnthropicProvider) Complete(ctx context.Context, req *agent.CompletionR
 //   - "anthropic: failed to convert messages": Message format is invalid
 //   - "anthropic: failed to convert tools": Tool schema is invalid
 func (p *AnthropicProvider) createStream(ctx context.Context, req *agent.CompletionRequest) (*ssestream.Stream[anthropic.MessageStreamEventUnion], error) {
+	params, err := p.messageParams(req)
+	if err != nil {
+		return nil, err
+	}
+
+	// Create streaming request using Anthropic SDK
+	stream := p.client.Messages.NewStreaming(ctx, params)
+
+	return stream, nil
+}
+
+// messageParams converts req into Messages API parameters.
+func (p *AnthropicProvider) messageParams(req *agent.CompletionRequest) (anthropic.MessageNewParams, error) {
 	// Convert messages
 	messages, err := p.convertMessages(req.Messages)
 	if err != nil {
-		return nil, fmt.Errorf("anthropic: failed to convert messages: %w", err)
+		return anthropic.MessageNewParams{}, fmt.Errorf("anthropic: failed to convert messages: %w", err)
 	}
 
 	// Build Anthropic API parameters
@@ -561,24 +575,59 @@ func (p *AnthropicProvider) createStream(ctx context.Context, req *agent.Complet
 	if len(req.Tools) > 0 {
 		tools, err := p.convertTools(req.Tools)
 		if err != nil {
-			return nil, fmt.Errorf("anthropic: failed to convert tools: %w", err)
+			return anthropic.MessageNewParams{}, fmt.Errorf("anthropic: failed to convert tools: %w", err)
 		}
 		params.Tools = tools
 	}
 
-	// Enable extended thinking if requested
-	if req.EnableThinking {
-		budgetTokens := int64(req.ThinkingBudgetTokens)
-		if budgetTokens < 1024 {
-			budgetTokens = 10000 // Default budget if not specified or too low
+	// Enable extended thinking if requested. The budget counts toward
+	// max_tokens, so room is made for the reply on top of it.
+	if budget := p.thinkingBudget(req); budget > 0 {
+		params.Thinking = anthropic.ThinkingConfigParamOfEnabled(budget)
+		params.MaxTokens = max(params.MaxTokens, budget+int64(p.getMaxTokens(0)))
+	}
+
+	// Anthropic rejects sampling changes while thinking is enabled.
+	if params.Thinking.OfEnabled == nil {
+		if req.Temperature != nil {
+			params.Temperature = anthropic.Float(min(*req.Temperature, 1))
+		}
+		if req.TopP != nil {
+			params.TopP = anthropic.Float(*req.TopP)
 		}
-		params.Thinking = anthropic.ThinkingConfigParamOfEnabled(budgetTokens)
+	}
+	if len(req.Stop) > 0 {
+		params.StopSequences = req.Stop
 	}
 
-	// Create streaming request using Anthropic SDK
-	stream := p.client.Messages.NewStreaming(ctx, params)
+	return params, nil
+}
 
-	return stream, nil
+// thinkingBudget returns the extended thinking budget for req in tokens, or
+// 0 when thinking is off. An explicit budget wins over a reasoning effort.
+func (p *AnthropicProvider) thinkingBudget(req *agent.CompletionRequest) int64 {
+	if req.EnableThinking {
+		if req.ThinkingBudgetTokens < 1024 {
+			return 10000 // Default budget if not specified or too low
+		}
+		return int64(req.ThinkingBudgetTokens)
+	}
+	return anthropicReasoningBudget(req.ReasoningEffort)
+}
+
+// anthropicReasoningBudget maps a reasoning effort to a thinking budget in
+// tokens, or 0 when no effort is requested.
+func anthropicReasoningBudget(effort string) int64 {
+	switch effort {
+	case agent.ReasoningLow:
+		return 2048
+	case agent.ReasoningMedium:
+		return 8192
+	case agent.ReasoningHigh:
+		return 24576
+	default:
+		return 0
+	}
 }
 
 // createBetaStream creates a beta Anthropic streaming request for computer use tools.
@@ -609,12 +658,21 @@ func (p *AnthropicProvider) createBetaStream(ctx context.Context, req *agent.Com
 		params.Tools = tools
 	}
 
-	if req.EnableThinking {
-		budgetTokens := int64(req.ThinkingBudgetTokens)
-		if budgetTokens < 1024 {
-			budgetTokens = 10000
+	if budget := p.thinkingBudget(req); budget > 0 {
+		params.Thinking = anthropic.BetaThinkingConfigParamOfEnabled(budget)
+		params.MaxTokens = max(params.MaxTokens, budget+int64(p.getMaxTokens(0)))
+	}
+
+	if params.Thinking.OfEnabled == nil {
+		if req.Temperature != nil {
+			params.Temperature = anthropic.Float(min(*req.Temperature, 1))
+		}
+		if req.TopP != nil {
+			params.TopP = anthropic.Float(*req.TopP)
 		}
-		params.Thinking = anthropic.BetaThinkingConfigParamOfEnabled(budgetTokens)
+	}
+	if len(req.Stop) > 0 {
+		params.StopSequences = req.Stop
 	}
 
 	stream := p.client.Beta.Messages.NewStreaming(ctx, params)
@@ -1370,6 +1428,9 @@ func (p *AnthropicProvider) wrapError(err error, model string) error {
 			Reason:   FailoverUnknown,
 		}
 		providerErr = providerErr.WithStatus(apiErr.StatusCode)
+		if apiErr.Response != nil && apiErr.StatusCode == http.StatusTooManyRequests {
+			providerErr = providerErr.WithRetryAfter(ParseRetryAfter(apiErr.Response.Header.Get("Retry-After"), time.Now()))
+		}
 
 		message := ""
 		code := ""
@@ -858,6 +858,50 @@ func TestModelDefaults(t *testing.T) {
 	}
 }
 
+// TestMessageParamsGenerationSettings tests sampling and reasoning effort mapping.
+func TestMessageParamsGenerationSettings(t *testing.T) {
+	provider, err := NewAnthropicProvider(AnthropicConfig{APIKey: "test-key"})
+	if err != nil {
+		t.Fatalf("failed to create provider: %v", err)
+	}
+	temperature, topP := 1.5, 0.9
+	req := &agent.CompletionRequest{
+		Messages:    []agent.CompletionMessage{{Role: "user", Content: "hi"}},
+		Temperature: &temperature,
+		TopP:        &topP,
+		Stop:        []string{"END"},
+	}
+
+	params, err := provider.messageParams(req)
+	if err != nil {
+		t.Fatalf("messageParams() error = %v", err)
+	}
+	if params.Temperature.Value != 1 {
+		t.Errorf("expected temperature clamped to 1, got %v", params.Temperature.Value)
+	}
+	if params.TopP.Value != 0.9 {
+		t.Errorf("expected top_p 0.9, got %v", params.TopP.Value)
+	}
+	if len(params.StopSequences) != 1 || params.StopSequences[0] != "END" {
+		t.Errorf("expected stop sequences [END], got %v", params.StopSequences)
+	}
+
+	req.ReasoningEffort = agent.ReasoningMedium
+	params, err = provider.messageParams(req)
+	if err != nil {
+		t.Fatalf("messageParams() error = %v", err)
+	}
+	if params.Thinking.OfEnabled == nil || params.Thinking.OfEnabled.BudgetTokens != 8192 {
+		t.Fatalf("expected an 8192 token thinking budget, got %+v", params.Thinking)
+	}
+	if params.MaxTokens <= params.Thinking.OfEnabled.BudgetTokens {
+		t.Errorf("expected max_tokens above the thinking budget, got %d", params.MaxTokens)
+	}
+	if params.Temperature.Valid() || params.TopP.Valid() {
+		t.Errorf("expected sampling settings dropped while thinking")
+	}
+}
+
 // TestMaxEmptyStreamEventsConstant verifies the malformed stream protection constant.
 func TestMaxEmptyStreamEventsConstant(t *testing.T) {
 	// Verify the constant is set to a reasonable value that protects against
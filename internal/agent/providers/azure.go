@@ -164,6 +164,7 @@ func (p *AzureOpenAIProvider) Complete(ctx context.Context, req *agent.Completio
 	if req.MaxTokens > 0 {
 		chatReq.MaxTokens = req.MaxTokens
 	}
+	applyGenerationParams(&chatReq, req)
 
 	if len(req.Tools) > 0 {
 		chatReq.Tools = p.convertTools(req.Tools)
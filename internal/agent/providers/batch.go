@@ -0,0 +1,236 @@
+package providers
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/anthropics/anthropic-sdk-go"
+	"github.com/haasonsaas/nexus/internal/agent"
+	"github.com/haasonsaas/nexus/pkg/models"
+	openai "github.com/sashabaranov/go-openai"
+)
+
+var (
+	_ agent.BatchProvider = (*AnthropicProvider)(nil)
+	_ agent.BatchProvider = (*OpenAIProvider)(nil)
+)
+
+// SubmitBatch submits requests to the Message Batches API.
+func (p *AnthropicProvider) SubmitBatch(ctx context.Context, requests []agent.BatchRequest) (string, error) {
+	if len(requests) == 0 {
+		return "", errors.New("anthropic: batch is empty")
+	}
+	params := anthropic.MessageBatchNewParams{
+		Requests: make([]anthropic.MessageBatchNewParamsRequest, 0, len(requests)),
+	}
+	for _, request := range requests {
+		msg, err := p.messageParams(request.Request)
+		if err != nil {
+			return "", fmt.Errorf("request %s: %w", request.CustomID, err)
+		}
+		params.Requests = append(params.Requests, anthropic.MessageBatchNewParamsRequest{
+			CustomID: request.CustomID,
+			Params: anthropic.MessageBatchNewParamsRequestParams{
+				Model:         msg.Model,
+				Messages:      msg.Messages,
+				MaxTokens:     msg.MaxTokens,
+				System:        msg.System,
+				Tools:         msg.Tools,
+				Thinking:      msg.Thinking,
+				Temperature:   msg.Temperature,
+				TopP:          msg.TopP,
+				StopSequences: msg.StopSequences,
+			},
+		})
+	}
+	batch, err := p.client.Messages.Batches.New(ctx, params)
+	if err != nil {
+		return "", p.wrapError(err, "")
+	}
+	return batch.ID, nil
+}
+
+// PollBatch returns the status of a message batch, with its results once
+// it has ended.
+func (p *AnthropicProvider) PollBatch(ctx context.Context, id string) (*agent.BatchStatus, error) {
+	batch, err := p.client.Messages.Batches.Get(ctx, id)
+	if err != nil {
+		return nil, p.wrapError(err, "")
+	}
+	status := &agent.BatchStatus{ID: id}
+	if batch.ProcessingStatus != anthropic.MessageBatchProcessingStatusEnded {
+		return status, nil
+	}
+
+	stream := p.client.Messages.Batches.ResultsStreaming(ctx, id)
+	defer stream.Close()
+	for stream.Next() {
+		response := stream.Current()
+		result := agent.BatchResult{CustomID: response.CustomID}
+		switch response.Result.Type {
+		case "succeeded":
+			msg := response.Result.Message
+			var text strings.Builder
+			for _, block := range msg.Content {
+				switch block.Type {
+				case "text":
+					text.WriteString(block.Text)
+				case "tool_use":
+					result.ToolCalls = append(result.ToolCalls, models.ToolCall{ID: block.ID, Name: block.Name, Input: block.Input})
+				}
+			}
+			result.Text = text.String()
+			result.InputTokens = int(msg.Usage.InputTokens)
+			result.OutputTokens = int(msg.Usage.OutputTokens)
+		case "errored":
+			result.Err = fmt.Errorf("anthropic: batch request failed: %s", response.Result.Error.Error.Message)
+		default:
+			result.Err = fmt.Errorf("anthropic: batch request %s", response.Result.Type)
+		}
+		status.Results = append(status.Results, result)
+	}
+	if err := stream.Err(); err != nil {
+		return nil, p.wrapError(err, "")
+	}
+	status.Done = true
+	return status, nil
+}
+
+// openAIBatchLine is one line of an OpenAI batch output or error file.
+type openAIBatchLine struct {
+	CustomID string `json:"custom_id"`
+	Response *struct {
+		StatusCode int                           `json:"status_code"`
+		Body       openai.ChatCompletionResponse `json:"body"`
+	} `json:"response"`
+	Error *struct {
+		Code    string `json:"code"`
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+// SubmitBatch uploads requests as a JSONL file and creates a chat
+// completions batch with a 24h completion window.
+func (p *OpenAIProvider) SubmitBatch(ctx context.Context, requests []agent.BatchRequest) (string, error) {
+	if p.client == nil {
+		return "", NewProviderError("openai", "", errors.New("OpenAI API key not configured (set llm.providers.openai.api_key)")).WithStatus(http.StatusUnauthorized)
+	}
+	if len(requests) == 0 {
+		return "", errors.New("openai: batch is empty")
+	}
+	upload := openai.CreateBatchWithUploadFileRequest{
+		Endpoint:         openai.BatchEndpointChatCompletions,
+		CompletionWindow: "24h",
+	}
+	for _, request := range requests {
+		req := request.Request
+		messages, err := p.convertToOpenAIMessages(req.Messages, req.System)
+		if err != nil {
+			return "", fmt.Errorf("request %s: failed to convert messages: %w", request.CustomID, err)
+		}
+		body := openai.ChatCompletionRequest{Model: req.Model, Messages: messages}
+		if req.MaxTokens > 0 {
+			body.MaxTokens = req.MaxTokens
+		}
+		applyGenerationParams(&body, req)
+		if len(req.Tools) > 0 {
+			body.Tools = p.convertToOpenAITools(req.Tools)
+		}
+		upload.AddChatCompletion(request.CustomID, body)
+	}
+	batch, err := p.client.CreateBatchWithUploadFile(ctx, upload)
+	if err != nil {
+		return "", p.wrapError(err, "")
+	}
+	return batch.ID, nil
+}
+
+// PollBatch returns the status of a batch, reading its output and error
+// files once it has ended.
+func (p *OpenAIProvider) PollBatch(ctx context.Context, id string) (*agent.BatchStatus, error) {
+	if p.client == nil {
+		return nil, NewProviderError("openai", "", errors.New("OpenAI API key not configured (set llm.providers.openai.api_key)")).WithStatus(http.StatusUnauthorized)
+	}
+	batch, err := p.client.RetrieveBatch(ctx, id)
+	if err != nil {
+		return nil, p.wrapError(err, "")
+	}
+	status := &agent.BatchStatus{ID: id}
+	switch batch.Status {
+	case "completed", "failed", "expired", "cancelled":
+	default:
+		return status, nil
+	}
+
+	for _, fileID := range []*string{batch.OutputFileID, batch.ErrorFileID} {
+		if fileID == nil || *fileID == "" {
+			continue
+		}
+		results, err := p.batchFileResults(ctx, *fileID)
+		if err != nil {
+			return nil, err
+		}
+		status.Results = append(status.Results, results...)
+	}
+	status.Done = true
+	return status, nil
+}
+
+func (p *OpenAIProvider) batchFileResults(ctx context.Context, fileID string) ([]agent.BatchResult, error) {
+	content, err := p.client.GetFileContent(ctx, fileID)
+	if err != nil {
+		return nil, p.wrapError(err, "")
+	}
+	defer content.Close()
+
+	var results []agent.BatchResult
+	scanner := bufio.NewScanner(content)
+	scanner.Buffer(make([]byte, 0, 64*1024), 32*1024*1024)
+	for scanner.Scan() {
+		if len(strings.TrimSpace(scanner.Text())) == 0 {
+			continue
+		}
+		var line openAIBatchLine
+		if err := json.Unmarshal(scanner.Bytes(), &line); err != nil {
+			return nil, fmt.Errorf("openai: decode batch result: %w", err)
+		}
+		results = append(results, openAIBatchResult(line))
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("openai: read batch file %s: %w", fileID, err)
+	}
+	return results, nil
+}
+
+func openAIBatchResult(line openAIBatchLine) agent.BatchResult {
+	result := agent.BatchResult{CustomID: line.CustomID}
+	switch {
+	case line.Error != nil:
+		result.Err = fmt.Errorf("openai: batch request failed: %s: %s", line.Error.Code, line.Error.Message)
+	case line.Response == nil:
+		result.Err = errors.New("openai: batch request has no response")
+	case line.Response.StatusCode != http.StatusOK:
+		result.Err = fmt.Errorf("openai: batch request failed with status %d", line.Response.StatusCode)
+	case len(line.Response.Body.Choices) == 0:
+		result.Err = errors.New("openai: batch response has no choices")
+	default:
+		body := line.Response.Body
+		msg := body.Choices[0].Message
+		result.Text = msg.Content
+		for _, call := range msg.ToolCalls {
+			result.ToolCalls = append(result.ToolCalls, models.ToolCall{
+				ID:    call.ID,
+				Name:  call.Function.Name,
+				Input: json.RawMessage(call.Function.Arguments),
+			})
+		}
+		result.InputTokens = body.Usage.PromptTokens
+		result.OutputTokens = body.Usage.CompletionTokens
+	}
+	return result
+}
@@ -0,0 +1,143 @@
+package providers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/haasonsaas/nexus/internal/agent"
+)
+
+func TestAnthropicBatch(t *testing.T) {
+	ended := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/v1/messages/batches":
+			var body struct {
+				Requests []struct {
+					CustomID string `json:"custom_id"`
+					Params   struct {
+						Model  string `json:"model"`
+						System []struct {
+							Text string `json:"text"`
+						} `json:"system"`
+					} `json:"params"`
+				} `json:"requests"`
+			}
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				t.Errorf("decode batch: %v", err)
+			}
+			if len(body.Requests) != 2 || body.Requests[0].CustomID != "req-1" || body.Requests[0].Params.System[0].Text != "be brief" {
+				t.Errorf("batch requests = %+v", body.Requests)
+			}
+			fmt.Fprint(w, `{"id":"msgbatch_1","type":"message_batch","processing_status":"in_progress"}`)
+		case r.Method == http.MethodGet && r.URL.Path == "/v1/messages/batches/msgbatch_1":
+			status := "in_progress"
+			if ended {
+				status = "ended"
+			}
+			fmt.Fprintf(w, `{"id":"msgbatch_1","type":"message_batch","processing_status":%q}`, status)
+		case r.Method == http.MethodGet && r.URL.Path == "/v1/messages/batches/msgbatch_1/results":
+			fmt.Fprintln(w, `{"custom_id":"req-1","result":{"type":"succeeded","message":{"id":"msg_1","type":"message","role":"assistant","content":[{"type":"text","text":"Hi"},{"type":"tool_use","id":"tu_1","name":"search","input":{"q":"x"}}],"usage":{"input_tokens":12,"output_tokens":5}}}}`)
+			fmt.Fprintln(w, `{"custom_id":"req-2","result":{"type":"errored","error":{"type":"error","error":{"type":"invalid_request_error","message":"bad prompt"}}}}`)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	provider, err := NewAnthropicProvider(AnthropicConfig{APIKey: "test", BaseURL: server.URL, MaxRetries: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	id, err := provider.SubmitBatch(context.Background(), []agent.BatchRequest{
+		{CustomID: "req-1", Request: &agent.CompletionRequest{System: "be brief", Messages: []agent.CompletionMessage{{Role: "user", Content: "hello"}}}},
+		{CustomID: "req-2", Request: &agent.CompletionRequest{Messages: []agent.CompletionMessage{{Role: "user", Content: "oops"}}}},
+	})
+	if err != nil || id != "msgbatch_1" {
+		t.Fatalf("SubmitBatch() = %q, %v", id, err)
+	}
+
+	status, err := provider.PollBatch(context.Background(), id)
+	if err != nil || status.Done {
+		t.Fatalf("PollBatch() in progress = %+v, %v", status, err)
+	}
+	ended = true
+	status, err = provider.PollBatch(context.Background(), id)
+	if err != nil || !status.Done || len(status.Results) != 2 {
+		t.Fatalf("PollBatch() = %+v, %v", status, err)
+	}
+	first := status.Results[0]
+	if first.Text != "Hi" || len(first.ToolCalls) != 1 || first.ToolCalls[0].Name != "search" || first.InputTokens != 12 || first.OutputTokens != 5 {
+		t.Errorf("first result = %+v", first)
+	}
+	if status.Results[1].Err == nil || !strings.Contains(status.Results[1].Err.Error(), "bad prompt") {
+		t.Errorf("second result error = %v", status.Results[1].Err)
+	}
+}
+
+func TestOpenAIBatch(t *testing.T) {
+	var uploaded string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/v1/files":
+			file, _, err := r.FormFile("file")
+			if err != nil {
+				t.Errorf("upload: %v", err)
+				return
+			}
+			data, _ := io.ReadAll(file)
+			uploaded = string(data)
+			fmt.Fprint(w, `{"id":"file-in","object":"file","purpose":"batch"}`)
+		case r.Method == http.MethodPost && r.URL.Path == "/v1/batches":
+			var body map[string]any
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			if body["input_file_id"] != "file-in" || body["endpoint"] != "/v1/chat/completions" {
+				t.Errorf("create batch body = %v", body)
+			}
+			fmt.Fprint(w, `{"id":"batch_1","object":"batch","status":"validating"}`)
+		case r.Method == http.MethodGet && r.URL.Path == "/v1/batches/batch_1":
+			fmt.Fprint(w, `{"id":"batch_1","object":"batch","status":"completed","output_file_id":"file-out","error_file_id":"file-err"}`)
+		case r.Method == http.MethodGet && r.URL.Path == "/v1/files/file-out/content":
+			fmt.Fprintln(w, `{"id":"r1","custom_id":"req-1","response":{"status_code":200,"body":{"choices":[{"message":{"role":"assistant","content":"Done","tool_calls":[{"id":"call_1","type":"function","function":{"name":"lookup","arguments":"{\"id\":1}"}}]}}],"usage":{"prompt_tokens":20,"completion_tokens":3}}}}`)
+		case r.Method == http.MethodGet && r.URL.Path == "/v1/files/file-err/content":
+			fmt.Fprintln(w, `{"id":"r2","custom_id":"req-2","response":null,"error":{"code":"invalid_request","message":"too long"}}`)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	provider := NewOpenAIProviderWithConfig(OpenAIConfig{APIKey: "test", BaseURL: server.URL + "/v1"})
+	id, err := provider.SubmitBatch(context.Background(), []agent.BatchRequest{
+		{CustomID: "req-1", Request: &agent.CompletionRequest{Model: "gpt-4o-mini", Messages: []agent.CompletionMessage{{Role: "user", Content: "hello"}}}},
+		{CustomID: "req-2", Request: &agent.CompletionRequest{Model: "gpt-4o-mini", Messages: []agent.CompletionMessage{{Role: "user", Content: "again"}}}},
+	})
+	if err != nil || id != "batch_1" {
+		t.Fatalf("SubmitBatch() = %q, %v", id, err)
+	}
+	if lines := strings.Split(strings.TrimSpace(uploaded), "\n"); len(lines) != 2 || !strings.Contains(lines[0], `"custom_id":"req-1"`) {
+		t.Errorf("uploaded = %s", uploaded)
+	}
+
+	status, err := provider.PollBatch(context.Background(), id)
+	if err != nil || !status.Done || len(status.Results) != 2 {
+		t.Fatalf("PollBatch() = %+v, %v", status, err)
+	}
+	first := status.Results[0]
+	if first.Text != "Done" || len(first.ToolCalls) != 1 || string(first.ToolCalls[0].Input) != `{"id":1}` || first.InputTokens != 20 || first.OutputTokens != 3 {
+		t.Errorf("first result = %+v", first)
+	}
+	if status.Results[1].CustomID != "req-2" || status.Results[1].Err == nil {
+		t.Errorf("second result = %+v", status.Results[1])
+	}
+}
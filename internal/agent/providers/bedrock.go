@@ -225,12 +225,21 @@ func (p *BedrockProvider) Complete(ctx context.Context, req *agent.CompletionReq
 	}
 
 	// Add inference config
+	inference := &types.InferenceConfiguration{}
 	if req.MaxTokens > 0 {
 		maxTokens := min(req.MaxTokens, math.MaxInt32)
-		converseReq.InferenceConfig = &types.InferenceConfiguration{
-			// #nosec G115 -- bounded by min above
-			MaxTokens: aws.Int32(int32(maxTokens)),
-		}
+		// #nosec G115 -- bounded by min above
+		inference.MaxTokens = aws.Int32(int32(maxTokens))
+	}
+	if req.Temperature != nil {
+		inference.Temperature = aws.Float32(float32(*req.Temperature))
+	}
+	if req.TopP != nil {
+		inference.TopP = aws.Float32(float32(*req.TopP))
+	}
+	inference.StopSequences = req.Stop
+	if inference.MaxTokens != nil || inference.Temperature != nil || inference.TopP != nil || len(inference.StopSequences) > 0 {
+		converseReq.InferenceConfig = inference
 	}
 
 	// Add tool configuration
@@ -150,6 +150,7 @@ func (p *CopilotProxyProvider) Complete(ctx context.Context, req *agent.Completi
 	if req.MaxTokens > 0 {
 		chatReq.MaxTokens = req.MaxTokens
 	}
+	applyGenerationParams(&chatReq, req)
 
 	if len(req.Tools) > 0 {
 		chatReq.Tools = p.convertTools(req.Tools)
@@ -4,7 +4,10 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"regexp"
+	"strconv"
 	"strings"
+	"time"
 )
 
 // FailoverReason categorizes why a provider request failed.
@@ -84,6 +87,10 @@ type ProviderError struct {
 	// RequestID is the provider's request ID for debugging
 	RequestID string
 
+	// RetryAfter is the delay the provider asked for before the next
+	// request, from the Retry-After header or message of a 429 response
+	RetryAfter time.Duration
+
 	// Cause is the underlying error
 	Cause error
 }
@@ -164,6 +171,57 @@ func (e *ProviderError) WithRequestID(id string) *ProviderError {
 	return e
 }
 
+// WithRetryAfter records the delay the provider asked for before the next
+// request.
+func (e *ProviderError) WithRetryAfter(d time.Duration) *ProviderError {
+	if d > 0 {
+		e.RetryAfter = d
+	}
+	return e
+}
+
+// RetryAfterHint returns RetryAfter, so rate limiters outside this package
+// can honor it.
+func (e *ProviderError) RetryAfterHint() time.Duration {
+	return e.RetryAfter
+}
+
+// ParseRetryAfter parses a Retry-After header value given in seconds or as
+// an HTTP date, returning zero when it is missing or invalid.
+func ParseRetryAfter(value string, now time.Time) time.Duration {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.ParseFloat(value, 64); err == nil {
+		if seconds <= 0 {
+			return 0
+		}
+		return time.Duration(seconds * float64(time.Second))
+	}
+	if at, err := http.ParseTime(value); err == nil && at.After(now) {
+		return at.Sub(now)
+	}
+	return 0
+}
+
+// retryAfterMessagePattern matches the delay OpenAI-style rate limit
+// messages suggest, such as "Please try again in 1.5s".
+var retryAfterMessagePattern = regexp.MustCompile(`(?i)try again in ([0-9.]+(?:ms|s|m))`)
+
+// retryAfterFromMessage extracts the delay suggested by a rate limit message.
+func retryAfterFromMessage(message string) time.Duration {
+	match := retryAfterMessagePattern.FindStringSubmatch(message)
+	if match == nil {
+		return 0
+	}
+	d, err := time.ParseDuration(match[1])
+	if err != nil || d <= 0 {
+		return 0
+	}
+	return d
+}
+
 // WithMessage sets the error message.
 func (e *ProviderError) WithMessage(msg string) *ProviderError {
 	e.Message = msg
@@ -3,6 +3,7 @@ package providers
 import (
 	"errors"
 	"testing"
+	"time"
 )
 
 func TestFailoverReasonIsRetryable(t *testing.T) {
@@ -363,3 +364,42 @@ func TestClassifyErrorEdgeCases(t *testing.T) {
 		})
 	}
 }
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2026, 1, 2, 15, 4, 5, 0, time.UTC)
+	tests := []struct {
+		value string
+		want  time.Duration
+	}{
+		{"", 0},
+		{"2", 2 * time.Second},
+		{"0.5", 500 * time.Millisecond},
+		{"-1", 0},
+		{"Fri, 02 Jan 2026 15:04:35 GMT", 30 * time.Second},
+		{"Fri, 02 Jan 2026 15:00:00 GMT", 0},
+		{"soon", 0},
+	}
+	for _, tt := range tests {
+		if got := ParseRetryAfter(tt.value, now); got != tt.want {
+			t.Errorf("ParseRetryAfter(%q) = %v, want %v", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestRetryAfterHint(t *testing.T) {
+	if got := retryAfterFromMessage("Rate limit reached for gpt-4o. Please try again in 1.5s."); got != 1500*time.Millisecond {
+		t.Errorf("retryAfterFromMessage() = %v, want 1.5s", got)
+	}
+	if got := retryAfterFromMessage("Please try again in 250ms"); got != 250*time.Millisecond {
+		t.Errorf("retryAfterFromMessage() = %v, want 250ms", got)
+	}
+	if got := retryAfterFromMessage("rate limit exceeded"); got != 0 {
+		t.Errorf("retryAfterFromMessage() = %v, want 0", got)
+	}
+
+	err := NewProviderError("openai", "gpt-4o", errors.New("429")).WithStatus(429).WithRetryAfter(3 * time.Second)
+	var hinted interface{ RetryAfterHint() time.Duration }
+	if !errors.As(error(err), &hinted) || hinted.RetryAfterHint() != 3*time.Second {
+		t.Errorf("RetryAfterHint() not exposed through errors.As")
+	}
+}
@@ -677,6 +677,15 @@ func (p *GoogleProvider) buildConfig(req *agent.CompletionRequest) *genai.Genera
 		// #nosec G115 -- bounded by min above
 		config.MaxOutputTokens = int32(maxTokens)
 	}
+	if req.Temperature != nil {
+		config.Temperature = genai.Ptr(float32(*req.Temperature))
+	}
+	if req.TopP != nil {
+		config.TopP = genai.Ptr(float32(*req.TopP))
+	}
+	if len(req.Stop) > 0 {
+		config.StopSequences = req.Stop
+	}
 
 	// Convert and set tools
 	if len(req.Tools) > 0 {
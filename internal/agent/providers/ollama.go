@@ -93,8 +93,21 @@ func (p *OllamaProvider) Complete(ctx context.Context, req *agent.CompletionRequ
 	if len(req.Tools) > 0 {
 		payload.Tools = toolconv.ToOpenAITools(req.Tools)
 	}
+	options := map[string]any{}
 	if req.MaxTokens > 0 {
-		payload.Options = map[string]any{"num_predict": req.MaxTokens}
+		options["num_predict"] = req.MaxTokens
+	}
+	if req.Temperature != nil {
+		options["temperature"] = *req.Temperature
+	}
+	if req.TopP != nil {
+		options["top_p"] = *req.TopP
+	}
+	if len(req.Stop) > 0 {
+		options["stop"] = req.Stop
+	}
+	if len(options) > 0 {
+		payload.Options = options
 	}
 
 	body, err := json.Marshal(payload)
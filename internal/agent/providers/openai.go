@@ -6,12 +6,15 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"math"
 	"net/http"
+	"sort"
 	"strings"
 	"time"
 
 	"github.com/haasonsaas/nexus/internal/agent"
 	"github.com/haasonsaas/nexus/internal/agent/toolconv"
+	modelcatalog "github.com/haasonsaas/nexus/internal/models"
 	"github.com/haasonsaas/nexus/pkg/models"
 	openai "github.com/sashabaranov/go-openai"
 )
@@ -358,14 +361,16 @@ func (p *OpenAIProvider) Complete(ctx context.Context, req *agent.CompletionRequ
 
 	// Build OpenAI API request
 	chatReq := openai.ChatCompletionRequest{
-		Model:    req.Model,
-		Messages: messages,
-		Stream:   true, // Always use streaming for real-time responses
+		Model:         req.Model,
+		Messages:      messages,
+		Stream:        true, // Always use streaming for real-time responses
+		StreamOptions: &openai.StreamOptions{IncludeUsage: true},
 	}
 
 	if req.MaxTokens > 0 {
 		chatReq.MaxTokens = req.MaxTokens
 	}
+	applyGenerationParams(&chatReq, req)
 
 	// Add tool/function definitions if provided
 	if len(req.Tools) > 0 {
@@ -417,6 +422,10 @@ func (p *OpenAIProvider) Complete(ctx context.Context, req *agent.CompletionRequ
 //  3. FinishReason "tool_calls" signals all tool calls are complete
 //  4. Multiple tool calls can be in progress (tracked by index in map)
 //
+// Calls are streamed one after another, so when a call with a higher index
+// begins, earlier calls whose arguments are complete JSON are emitted right
+// away. The runtime can then start them while the rest of the turn streams.
+//
 // Parameters:
 //   - ctx: Context for cancellation
 //   - stream: OpenAI streaming response
@@ -424,7 +433,8 @@ func (p *OpenAIProvider) Complete(ctx context.Context, req *agent.CompletionRequ
 //
 // Chunk Emissions:
 //   - Text chunks: Emitted immediately for each delta.Content
-//   - Tool call chunks: Emitted when FinishReason is "tool_calls"
+//   - Tool call chunks: Emitted in index order when the next call begins or
+//     when FinishReason is "tool_calls"
 //   - Done chunk: Emitted on io.EOF
 //   - Error chunk: Emitted on streaming errors
 func (p *OpenAIProvider) processStream(ctx context.Context, stream *openai.ChatCompletionStream, chunks chan<- *agent.CompletionChunk, model string) {
@@ -434,6 +444,32 @@ func (p *OpenAIProvider) processStream(ctx context.Context, stream *openai.ChatC
 	// Track tool calls being accumulated across multiple chunks
 	// Map key is the tool call index (OpenAI can return multiple tool calls)
 	toolCalls := make(map[int]*models.ToolCall)
+	var usage *openai.Usage
+
+	// emitToolCalls sends accumulated calls with an index below limit, in
+	// order. With complete set, calls whose arguments are not yet valid JSON
+	// are kept in case more fragments arrive.
+	emitToolCalls := func(limit int, complete bool) {
+		indexes := make([]int, 0, len(toolCalls))
+		for index := range toolCalls {
+			if index < limit {
+				indexes = append(indexes, index)
+			}
+		}
+		sort.Ints(indexes)
+		for _, index := range indexes {
+			tc := toolCalls[index]
+			if complete && !json.Valid(tc.Input) {
+				continue
+			}
+			if tc.ID != "" && tc.Name != "" {
+				chunks <- &agent.CompletionChunk{
+					ToolCall: tc,
+				}
+			}
+			delete(toolCalls, index)
+		}
+	}
 
 	for {
 		// Check for context cancellation
@@ -449,14 +485,16 @@ func (p *OpenAIProvider) processStream(ctx context.Context, stream *openai.ChatC
 		if err != nil {
 			if err == io.EOF {
 				// Stream completed normally - emit any pending tool calls
-				for _, tc := range toolCalls {
-					if tc.ID != "" && tc.Name != "" {
-						chunks <- &agent.CompletionChunk{
-							ToolCall: tc,
-						}
+				emitToolCalls(math.MaxInt, false)
+				done := &agent.CompletionChunk{Done: true}
+				if usage != nil {
+					done.InputTokens = usage.PromptTokens
+					done.OutputTokens = usage.CompletionTokens
+					if usage.CompletionTokensDetails != nil {
+						done.ReasoningTokens = usage.CompletionTokensDetails.ReasoningTokens
 					}
 				}
-				chunks <- &agent.CompletionChunk{Done: true}
+				chunks <- done
 				return
 			}
 			// Stream error
@@ -464,6 +502,11 @@ func (p *OpenAIProvider) processStream(ctx context.Context, stream *openai.ChatC
 			return
 		}
 
+		// Usage arrives in a final response with no choices
+		if response.Usage != nil {
+			usage = response.Usage
+		}
+
 		// Skip empty responses
 		if len(response.Choices) == 0 {
 			continue
@@ -471,6 +514,13 @@ func (p *OpenAIProvider) processStream(ctx context.Context, stream *openai.ChatC
 
 		delta := response.Choices[0].Delta
 
+		// Reasoning text from OpenAI-compatible reasoning models
+		if delta.ReasoningContent != "" {
+			chunks <- &agent.CompletionChunk{
+				Thinking: delta.ReasoningContent,
+			}
+		}
+
 		// Handle text content - emit immediately for real-time streaming
 		if delta.Content != "" {
 			chunks <- &agent.CompletionChunk{
@@ -487,8 +537,10 @@ func (p *OpenAIProvider) processStream(ctx context.Context, stream *openai.ChatC
 					index = *tc.Index
 				}
 
-				// Initialize tool call if this is the first chunk for this index
+				// Initialize tool call if this is the first chunk for this
+				// index; the calls before it are done streaming
 				if toolCalls[index] == nil {
+					emitToolCalls(index, true)
 					toolCalls[index] = &models.ToolCall{}
 				}
 
@@ -513,15 +565,7 @@ func (p *OpenAIProvider) processStream(ctx context.Context, stream *openai.ChatC
 
 		// Check finish reason - "tool_calls" means all tool calls are complete
 		if response.Choices[0].FinishReason == "tool_calls" {
-			for _, tc := range toolCalls {
-				if tc.ID != "" && tc.Name != "" {
-					chunks <- &agent.CompletionChunk{
-						ToolCall: tc,
-					}
-				}
-			}
-			// Reset for potential next iteration (though typically stream ends here)
-			toolCalls = make(map[int]*models.ToolCall)
+			emitToolCalls(math.MaxInt, false)
 		}
 	}
 }
@@ -759,6 +803,9 @@ func (p *OpenAIProvider) wrapError(err error, model string) error {
 		if apiErr.Code != nil {
 			providerErr = providerErr.WithCode(fmt.Sprint(apiErr.Code))
 		}
+		if providerErr.Reason == FailoverRateLimit {
+			providerErr = providerErr.WithRetryAfter(retryAfterFromMessage(apiErr.Message))
+		}
 		return providerErr
 	}
 
@@ -778,6 +825,9 @@ func (p *OpenAIProvider) wrapError(err error, model string) error {
 				providerErr = providerErr.WithMessage(reqErr.Err.Error())
 			}
 		}
+		if providerErr.Reason == FailoverRateLimit {
+			providerErr = providerErr.WithRetryAfter(retryAfterFromMessage(providerErr.Message))
+		}
 		return providerErr
 	}
 
@@ -819,3 +869,44 @@ func findSubstring(s, substr string) int {
 	}
 	return -1
 }
+
+// applyGenerationParams copies sampling and reasoning settings onto an
+// OpenAI-compatible chat request.
+func applyGenerationParams(chatReq *openai.ChatCompletionRequest, req *agent.CompletionRequest) {
+	if req.Temperature != nil {
+		chatReq.Temperature = float32(*req.Temperature)
+	}
+	if req.TopP != nil {
+		chatReq.TopP = float32(*req.TopP)
+	}
+	if len(req.Stop) > 0 {
+		chatReq.Stop = req.Stop
+	}
+	if req.ReasoningEffort != "" {
+		chatReq.ReasoningEffort = req.ReasoningEffort
+	} else if req.EnableThinking && supportsReasoning(req.Model) {
+		chatReq.ReasoningEffort = reasoningEffortForBudget(req.ThinkingBudgetTokens)
+	}
+}
+
+// supportsReasoning reports whether the model catalog lists model as a
+// reasoning model, which accepts a reasoning effort.
+func supportsReasoning(model string) bool {
+	entry, ok := modelcatalog.Lookup(model)
+	return ok && entry.HasCapability(modelcatalog.CapReasoning)
+}
+
+// reasoningEffortForBudget maps an extended thinking budget, as requested
+// with /think, to the nearest reasoning effort.
+func reasoningEffortForBudget(budget int) string {
+	switch {
+	case budget <= 0:
+		return agent.ReasoningMedium
+	case budget <= 4096:
+		return agent.ReasoningLow
+	case budget <= 16384:
+		return agent.ReasoningMedium
+	default:
+		return agent.ReasoningHigh
+	}
+}
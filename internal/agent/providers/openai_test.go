@@ -5,6 +5,8 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 	"time"
 
@@ -922,3 +924,70 @@ func TestIsRetryableEdgeCases(t *testing.T) {
 		})
 	}
 }
+
+// TestOpenAIStreamEmitsToolCallsEarly tests that a tool call is emitted as
+// soon as the next one begins, before the stream finishes.
+func TestOpenAIStreamEmitsToolCallsEarly(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		flusher := w.(http.Flusher)
+		send := func(delta string) {
+			fmt.Fprintf(w, "data: {\"id\":\"c1\",\"object\":\"chat.completion.chunk\",\"model\":\"gpt-4o\",\"choices\":[{\"index\":0,\"delta\":%s}]}\n\n", delta)
+			flusher.Flush()
+		}
+		send(`{"tool_calls":[{"index":0,"id":"call_1","type":"function","function":{"name":"read","arguments":"{\"path\":"}}]}`)
+		send(`{"tool_calls":[{"index":0,"function":{"arguments":"\"a.txt\"}"}}]}`)
+		send(`{"tool_calls":[{"index":1,"id":"call_2","type":"function","function":{"name":"read","arguments":""}}]}`)
+		select {
+		case <-release:
+		case <-r.Context().Done():
+			return
+		}
+		send(`{"tool_calls":[{"index":1,"function":{"arguments":"{\"path\":\"b.txt\"}"}}]}`)
+		fmt.Fprint(w, "data: {\"id\":\"c1\",\"object\":\"chat.completion.chunk\",\"model\":\"gpt-4o\",\"choices\":[{\"index\":0,\"delta\":{},\"finish_reason\":\"tool_calls\"}]}\n\n")
+		fmt.Fprint(w, "data: [DONE]\n\n")
+		flusher.Flush()
+	}))
+	defer server.Close()
+
+	provider := NewOpenAIProviderWithConfig(OpenAIConfig{APIKey: "sk-test", BaseURL: server.URL})
+	chunks, err := provider.Complete(context.Background(), &agent.CompletionRequest{
+		Model:    "gpt-4o",
+		Messages: []agent.CompletionMessage{{Role: "user", Content: "read both"}},
+	})
+	if err != nil {
+		t.Fatalf("Complete() error = %v", err)
+	}
+
+	var calls []models.ToolCall
+	timeout := time.After(5 * time.Second)
+	for done := false; !done; {
+		select {
+		case chunk, ok := <-chunks:
+			if !ok {
+				done = true
+				break
+			}
+			if chunk.Error != nil {
+				t.Fatalf("stream error: %v", chunk.Error)
+			}
+			if chunk.ToolCall != nil {
+				calls = append(calls, *chunk.ToolCall)
+				if len(calls) == 1 {
+					// The first call arrived while the stream is held open.
+					close(release)
+				}
+			}
+		case <-timeout:
+			t.Fatal("first tool call was not emitted before the stream finished")
+		}
+	}
+
+	if len(calls) != 2 || calls[0].ID != "call_1" || calls[1].ID != "call_2" {
+		t.Fatalf("calls = %+v", calls)
+	}
+	if string(calls[0].Input) != `{"path":"a.txt"}` || string(calls[1].Input) != `{"path":"b.txt"}` {
+		t.Fatalf("inputs = %s, %s", calls[0].Input, calls[1].Input)
+	}
+}
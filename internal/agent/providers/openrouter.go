@@ -172,6 +172,7 @@ func (p *OpenRouterProvider) Complete(ctx context.Context, req *agent.Completion
 	if req.MaxTokens > 0 {
 		chatReq.MaxTokens = req.MaxTokens
 	}
+	applyGenerationParams(&chatReq, req)
 
 	if len(req.Tools) > 0 {
 		chatReq.Tools = p.convertTools(req.Tools)
@@ -0,0 +1,74 @@
+package agent
+
+import "strings"
+
+const (
+	thinkOpenTag  = "<think>"
+	thinkCloseTag = "</think>"
+)
+
+// thinkTagSplitter separates the <think>...</think> blocks some reasoning
+// models (DeepSeek R1, QwQ) write inline from the visible reply. A tag split
+// across stream chunks is held back until the next chunk decides it.
+type thinkTagSplitter struct {
+	inThink bool
+	pending string
+}
+
+// split returns the reply text and reasoning text in chunk.
+func (s *thinkTagSplitter) split(chunk string) (text, thinking string) {
+	var out, reasoning strings.Builder
+	buf := s.pending + chunk
+	s.pending = ""
+	for buf != "" {
+		tag := thinkOpenTag
+		dest := &out
+		if s.inThink {
+			tag = thinkCloseTag
+			dest = &reasoning
+		}
+		if idx := strings.Index(buf, tag); idx >= 0 {
+			dest.WriteString(buf[:idx])
+			buf = buf[idx+len(tag):]
+			s.inThink = !s.inThink
+			continue
+		}
+		keep := partialTagSuffix(buf, tag)
+		dest.WriteString(buf[:len(buf)-keep])
+		s.pending = buf[len(buf)-keep:]
+		break
+	}
+	return out.String(), reasoning.String()
+}
+
+// flush returns text held back at the end of the stream.
+func (s *thinkTagSplitter) flush() (text, thinking string) {
+	pending := s.pending
+	s.pending = ""
+	if s.inThink {
+		return "", pending
+	}
+	return pending, ""
+}
+
+// partialTagSuffix returns the length of the longest suffix of buf that is
+// a proper prefix of tag.
+func partialTagSuffix(buf, tag string) int {
+	for n := min(len(buf), len(tag)-1); n > 0; n-- {
+		if strings.HasSuffix(buf, tag[:n]) {
+			return n
+		}
+	}
+	return 0
+}
+
+// estimateReasoningTokens approximates the tokens spent on reasoning from
+// its text, for providers that bill reasoning as output without reporting
+// it separately. The estimate never exceeds outputTokens when that is known.
+func estimateReasoningTokens(text string, outputTokens int) int {
+	tokens := (len(text) + 3) / 4
+	if outputTokens > 0 {
+		tokens = min(tokens, outputTokens)
+	}
+	return tokens
+}
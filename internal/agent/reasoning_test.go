@@ -0,0 +1,112 @@
+package agent
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/haasonsaas/nexus/pkg/models"
+)
+
+func TestThinkTagSplitterAcrossChunks(t *testing.T) {
+	var s thinkTagSplitter
+	var text, thinking strings.Builder
+	for _, chunk := range []string{"<thi", "nk>step one", " step two</th", "ink>The ", "answer <", "is 4"} {
+		out, reasoning := s.split(chunk)
+		text.WriteString(out)
+		thinking.WriteString(reasoning)
+	}
+	out, reasoning := s.flush()
+	text.WriteString(out)
+	thinking.WriteString(reasoning)
+
+	if text.String() != "The answer <is 4" {
+		t.Errorf("text = %q, want %q", text.String(), "The answer <is 4")
+	}
+	if thinking.String() != "step one step two" {
+		t.Errorf("thinking = %q, want %q", thinking.String(), "step one step two")
+	}
+}
+
+func TestThinkTagSplitterFlushUnclosed(t *testing.T) {
+	var s thinkTagSplitter
+	text, thinking := s.split("<think>still going </thi")
+	if text != "" || thinking != "still going " {
+		t.Fatalf("split = (%q, %q)", text, thinking)
+	}
+	text, thinking = s.flush()
+	if text != "" || thinking != "</thi" {
+		t.Errorf("flush = (%q, %q), want (\"\", \"</thi\")", text, thinking)
+	}
+}
+
+func TestEstimateReasoningTokens(t *testing.T) {
+	if got := estimateReasoningTokens(strings.Repeat("a", 40), 0); got != 10 {
+		t.Errorf("estimate = %d, want 10", got)
+	}
+	if got := estimateReasoningTokens(strings.Repeat("a", 40), 6); got != 6 {
+		t.Errorf("capped estimate = %d, want 6", got)
+	}
+	if got := estimateReasoningTokens("", 100); got != 0 {
+		t.Errorf("empty estimate = %d, want 0", got)
+	}
+}
+
+func TestEventEmitter_ModelReasoningRedacted(t *testing.T) {
+	emitter := NewEventEmitter("test", nil)
+
+	event := emitter.ModelReasoning(context.Background(), "m", "secret plan", 3, false)
+	if event.Type != models.AgentEventModelReasoning {
+		t.Fatalf("Type = %s, want model.reasoning", event.Type)
+	}
+	if strings.Contains(event.Stream.Final, "secret") {
+		t.Errorf("redacted reasoning leaked: %q", event.Stream.Final)
+	}
+	if event.Stream.ReasoningTokens != 3 {
+		t.Errorf("ReasoningTokens = %d, want 3", event.Stream.ReasoningTokens)
+	}
+
+	event = emitter.ModelReasoning(context.Background(), "m", "secret plan", 3, true)
+	if event.Stream.Final != "secret plan" {
+		t.Errorf("traced reasoning = %q, want %q", event.Stream.Final, "secret plan")
+	}
+}
+
+func TestProcessStripsInlineReasoning(t *testing.T) {
+	provider := &streamingProvider{chunks: []string{"<think>let me", " see</think>", "Hello"}}
+	runtime := NewRuntime(provider, stubStore{})
+
+	session := &models.Session{ID: "session-1", Channel: models.ChannelTelegram}
+	msg := &models.Message{Role: models.RoleUser, Content: "greet me"}
+
+	events, err := runtime.ProcessStream(context.Background(), session, msg)
+	if err != nil {
+		t.Fatalf("ProcessStream() error = %v", err)
+	}
+
+	var reply strings.Builder
+	var reasoning, completed *models.AgentEvent
+	for event := range events {
+		switch event.Type {
+		case models.AgentEventModelDelta:
+			reply.WriteString(event.Stream.Delta)
+		case models.AgentEventModelReasoning:
+			reasoning = &event
+		case models.AgentEventModelCompleted:
+			completed = &event
+		}
+	}
+
+	if reply.String() != "Hello" {
+		t.Errorf("reply = %q, want %q", reply.String(), "Hello")
+	}
+	if reasoning == nil {
+		t.Fatal("expected a model.reasoning event")
+	}
+	if strings.Contains(reasoning.Stream.Final, "let me") {
+		t.Errorf("reasoning should be redacted by default, got %q", reasoning.Stream.Final)
+	}
+	if completed == nil || completed.Stream.ReasoningTokens == 0 {
+		t.Errorf("expected model.completed to carry reasoning tokens, got %+v", completed)
+	}
+}
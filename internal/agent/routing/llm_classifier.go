@@ -0,0 +1,180 @@
+package routing
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/haasonsaas/nexus/internal/agent"
+)
+
+// DefaultClassifierTags are the tags the LLM classifier chooses from when
+// none are configured. They match the heuristic classifier's tags.
+var DefaultClassifierTags = []string{"code", "reasoning", "quick"}
+
+// LLMClassifierConfig configures an LLMClassifier.
+type LLMClassifierConfig struct {
+	// Model is the model asked to classify; a cheap one is enough.
+	Model string
+
+	// Tags are the tags the model may assign. Default: DefaultClassifierTags.
+	Tags []string
+
+	// Timeout bounds each classification. Default: 5s.
+	Timeout time.Duration
+
+	// CacheSize is how many classifications are kept. Default: 1000.
+	CacheSize int
+
+	// CacheTTL is how long a classification is reused. Default: 1h.
+	CacheTTL time.Duration
+
+	// Fallback classifies requests when the model fails or times out.
+	// Default: HeuristicClassifier.
+	Fallback Classifier
+}
+
+// LLMClassifier tags requests by asking a model which of a fixed set of
+// tags apply to the latest user message. Answers are cached by message
+// content, so repeated or retried messages cost one call.
+type LLMClassifier struct {
+	provider agent.LLMProvider
+	config   LLMClassifierConfig
+
+	mu    sync.Mutex
+	cache map[string]classification
+}
+
+type classification struct {
+	tags    []string
+	expires time.Time
+}
+
+// NewLLMClassifier creates a classifier backed by provider.
+func NewLLMClassifier(provider agent.LLMProvider, cfg LLMClassifierConfig) *LLMClassifier {
+	if len(cfg.Tags) == 0 {
+		cfg.Tags = DefaultClassifierTags
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = 5 * time.Second
+	}
+	if cfg.CacheSize <= 0 {
+		cfg.CacheSize = 1000
+	}
+	if cfg.CacheTTL <= 0 {
+		cfg.CacheTTL = time.Hour
+	}
+	if cfg.Fallback == nil {
+		cfg.Fallback = &HeuristicClassifier{}
+	}
+	return &LLMClassifier{
+		provider: provider,
+		config:   cfg,
+		cache:    make(map[string]classification),
+	}
+}
+
+// Classify returns the tags the model assigns to the request. Errors fall
+// back to the fallback classifier and are not cached.
+func (c *LLMClassifier) Classify(req *agent.CompletionRequest) []string {
+	content := strings.TrimSpace(lastUserContent(req))
+	if content == "" {
+		return nil
+	}
+	sum := sha256.Sum256([]byte(content))
+	key := hex.EncodeToString(sum[:])
+	if tags, ok := c.cached(key); ok {
+		return tags
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), c.config.Timeout)
+	defer cancel()
+	tags, err := c.ask(ctx, content)
+	if err != nil {
+		return c.config.Fallback.Classify(req)
+	}
+	c.store(key, tags)
+	return tags
+}
+
+func (c *LLMClassifier) ask(ctx context.Context, content string) ([]string, error) {
+	stream, err := c.provider.Complete(ctx, &agent.CompletionRequest{
+		Model: c.config.Model,
+		System: "You route chat messages to models. Reply with the comma-separated tags from this list that apply to the user's message, or \"none\": " +
+			strings.Join(c.config.Tags, ", ") + ". Reply with tags only.",
+		Messages:  []agent.CompletionMessage{{Role: "user", Content: content}},
+		MaxTokens: 32,
+	})
+	if err != nil {
+		return nil, err
+	}
+	var answer strings.Builder
+	for chunk := range stream {
+		if chunk.Error != nil {
+			return nil, chunk.Error
+		}
+		answer.WriteString(chunk.Text)
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return c.parse(answer.String())
+}
+
+// parse keeps the known tags in answer, in the order the model gave them.
+func (c *LLMClassifier) parse(answer string) ([]string, error) {
+	answer = strings.ToLower(strings.TrimSpace(answer))
+	if answer == "" {
+		return nil, fmt.Errorf("routing: classifier returned no answer")
+	}
+	var tags []string
+	for _, field := range strings.FieldsFunc(answer, func(r rune) bool {
+		return r == ',' || r == '\n' || r == ' ' || r == '"' || r == '.'
+	}) {
+		if containsTag(c.config.Tags, field) && !containsTag(tags, field) {
+			tags = append(tags, field)
+		}
+	}
+	return tags, nil
+}
+
+func (c *LLMClassifier) cached(key string) ([]string, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry, ok := c.cache[key]
+	if !ok {
+		return nil, false
+	}
+	if time.Now().After(entry.expires) {
+		delete(c.cache, key)
+		return nil, false
+	}
+	return entry.tags, true
+}
+
+func (c *LLMClassifier) store(key string, tags []string) {
+	now := time.Now()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if len(c.cache) >= c.config.CacheSize {
+		// Drop expired entries, then the entry closest to expiring.
+		oldest := ""
+		for k, entry := range c.cache {
+			if now.After(entry.expires) {
+				delete(c.cache, k)
+				continue
+			}
+			if oldest == "" || entry.expires.Before(c.cache[oldest].expires) {
+				oldest = k
+			}
+		}
+		if len(c.cache) >= c.config.CacheSize && oldest != "" {
+			delete(c.cache, oldest)
+		}
+	}
+	c.cache[key] = classification{tags: tags, expires: now.Add(c.config.CacheTTL)}
+}
@@ -0,0 +1,72 @@
+package routing
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/haasonsaas/nexus/internal/agent"
+)
+
+// answerProvider replies with a fixed answer, or fails with err.
+type answerProvider struct {
+	stubProvider
+	answer string
+	err    error
+}
+
+func (p *answerProvider) Complete(ctx context.Context, req *agent.CompletionRequest) (<-chan *agent.CompletionChunk, error) {
+	p.calls++
+	p.lastModel = req.Model
+	if p.err != nil {
+		return nil, p.err
+	}
+	ch := make(chan *agent.CompletionChunk, 2)
+	ch <- &agent.CompletionChunk{Text: p.answer}
+	ch <- &agent.CompletionChunk{Done: true}
+	close(ch)
+	return ch, nil
+}
+
+func TestLLMClassifierTagsAndCaches(t *testing.T) {
+	provider := &answerProvider{answer: "Code, reasoning, poetry"}
+	classifier := NewLLMClassifier(provider, LLMClassifierConfig{Model: "gpt-4o-mini"})
+	req := &agent.CompletionRequest{Messages: []agent.CompletionMessage{{Role: "user", Content: "Why does this loop never end?"}}}
+
+	tags := classifier.Classify(req)
+	if len(tags) != 2 || tags[0] != "code" || tags[1] != "reasoning" {
+		t.Fatalf("tags = %v", tags)
+	}
+	if provider.lastModel != "gpt-4o-mini" {
+		t.Errorf("model = %q", provider.lastModel)
+	}
+	classifier.Classify(req)
+	if provider.calls != 1 {
+		t.Fatalf("provider calls = %d, want 1 (cached)", provider.calls)
+	}
+}
+
+func TestLLMClassifierFallsBackOnError(t *testing.T) {
+	provider := &answerProvider{err: errors.New("unavailable")}
+	classifier := NewLLMClassifier(provider, LLMClassifierConfig{})
+	req := &agent.CompletionRequest{Messages: []agent.CompletionMessage{{Role: "user", Content: "what is go"}}}
+
+	if tags := classifier.Classify(req); !containsTag(tags, "quick") {
+		t.Fatalf("tags = %v, want heuristic fallback", tags)
+	}
+	classifier.Classify(req)
+	if provider.calls != 2 {
+		t.Fatalf("provider calls = %d, errors should not be cached", provider.calls)
+	}
+}
+
+func TestLLMClassifierCacheIsBounded(t *testing.T) {
+	provider := &answerProvider{answer: "none"}
+	classifier := NewLLMClassifier(provider, LLMClassifierConfig{CacheSize: 2})
+	for _, content := range []string{"a", "b", "c"} {
+		classifier.Classify(&agent.CompletionRequest{Messages: []agent.CompletionMessage{{Role: "user", Content: content}}})
+	}
+	if n := len(classifier.cache); n != 2 {
+		t.Fatalf("cache size = %d, want 2", n)
+	}
+}
@@ -0,0 +1,344 @@
+package routing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/haasonsaas/nexus/internal/agent"
+	modelcatalog "github.com/haasonsaas/nexus/internal/models"
+)
+
+// Reasons a request is not raced, as passed to RaceHooks.OnSkip.
+const (
+	RaceSkipBackground = "background"
+	RaceSkipChannel    = "channel"
+	RaceSkipTools      = "tools"
+	RaceSkipCost       = "cost"
+	RaceSkipBudget     = "budget"
+)
+
+// RaceContender is one of the providers a raced request is sent to.
+type RaceContender struct {
+	// Name identifies the contender in hooks, e.g. "ollama".
+	Name     string
+	Provider agent.LLMProvider
+	// Model is sent instead of the request's model, since a model chosen
+	// for one provider rarely exists on the other. Empty uses the
+	// provider's default.
+	Model string
+}
+
+// RaceHooks observe races. Hooks run on the racing goroutines and must not
+// block.
+type RaceHooks struct {
+	// OnResult is called once per race, when a contender wins.
+	OnResult func(RaceResult)
+	// OnSkip is called when a request goes to the fallback provider
+	// instead, with one of the RaceSkip reasons.
+	OnSkip func(reason string)
+}
+
+// RaceResult describes a finished race.
+type RaceResult struct {
+	Winner string
+	Loser  string
+	// FirstChunk is how long the winner took to produce usable output.
+	FirstChunk time.Duration
+	// LoserErr is why the loser dropped out, or nil when it was simply
+	// slower and got cancelled.
+	LoserErr error
+	// EstimatedCostUSD is the request's estimated cost on both
+	// contenders together.
+	EstimatedCostUSD float64
+}
+
+// RaceConfig configures a Racer.
+type RaceConfig struct {
+	// Contenders are the two providers each raced request is sent to.
+	Contenders []RaceContender
+
+	// Fallback serves every request that is not raced.
+	Fallback agent.LLMProvider
+
+	// Channels limits racing to sessions on these channels. Empty races
+	// every request made for a session; requests without a session, such
+	// as background jobs, never race.
+	Channels []string
+
+	// MaxCostUSD, when positive, races only requests whose estimated cost
+	// on both contenders together stays at or below it.
+	MaxCostUSD float64
+
+	// DailyBudgetUSD, when positive, caps the estimated cost of all races
+	// in a UTC day; once spent, requests go to the fallback until the
+	// next day.
+	DailyBudgetUSD float64
+
+	// Catalog prices the contenders' models. Models without catalog
+	// pricing count as free.
+	Catalog *modelcatalog.Catalog
+
+	Hooks RaceHooks
+}
+
+// Racer sends interactive requests to two providers at once, streams the
+// response of whichever produces usable output first, and cancels the
+// other. It trades extra spend for lower latency, e.g. racing a fast
+// local model against a cloud model, so racing is gated on cost.
+type Racer struct {
+	cfg RaceConfig
+
+	budgetMu  sync.Mutex
+	budgetDay string
+	spentUSD  float64
+}
+
+// NewRacer creates a Racer. It needs exactly two contenders and a fallback.
+func NewRacer(cfg RaceConfig) (*Racer, error) {
+	if len(cfg.Contenders) != 2 {
+		return nil, fmt.Errorf("race: need exactly 2 contenders, got %d", len(cfg.Contenders))
+	}
+	for i, contender := range cfg.Contenders {
+		if contender.Provider == nil {
+			return nil, fmt.Errorf("race: contender %d has no provider", i)
+		}
+		if contender.Name == "" {
+			cfg.Contenders[i].Name = contender.Provider.Name()
+		}
+	}
+	if cfg.Fallback == nil {
+		return nil, errors.New("race: fallback provider is required")
+	}
+	return &Racer{cfg: cfg}, nil
+}
+
+// raceEntry is a contender's outcome up to its first usable chunk.
+type raceEntry struct {
+	index    int
+	stream   <-chan *agent.CompletionChunk
+	buffered []*agent.CompletionChunk
+	cancel   context.CancelFunc
+	elapsed  time.Duration
+	err      error
+}
+
+// Complete races req when it qualifies and otherwise sends it to the
+// fallback. It returns once a contender has produced text or a tool call,
+// or with an error when both fail first.
+func (r *Racer) Complete(ctx context.Context, req *agent.CompletionRequest) (<-chan *agent.CompletionChunk, error) {
+	if req == nil {
+		return nil, errInvalidRequest("request is nil")
+	}
+	cost, reason := r.gate(ctx, req)
+	if reason != "" {
+		if r.cfg.Hooks.OnSkip != nil {
+			r.cfg.Hooks.OnSkip(reason)
+		}
+		return r.cfg.Fallback.Complete(ctx, req)
+	}
+
+	results := make(chan raceEntry, len(r.cfg.Contenders))
+	cancels := make([]context.CancelFunc, len(r.cfg.Contenders))
+	start := time.Now()
+	for i, contender := range r.cfg.Contenders {
+		contenderCtx, cancel := context.WithCancel(ctx)
+		cancels[i] = cancel
+		copyReq := *req
+		copyReq.Model = contender.Model
+		go func() {
+			results <- runContender(contenderCtx, cancel, i, contender.Provider, &copyReq, start)
+		}()
+	}
+
+	var errs []error
+	for pending := len(r.cfg.Contenders); pending > 0; pending-- {
+		var entry raceEntry
+		select {
+		case entry = <-results:
+		case <-ctx.Done():
+			go abandon(results, pending)
+			return nil, ctx.Err()
+		}
+		if entry.err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", r.cfg.Contenders[entry.index].Name, entry.err))
+			continue
+		}
+
+		loser := r.cfg.Contenders[1-entry.index].Name
+		result := RaceResult{
+			Winner:           r.cfg.Contenders[entry.index].Name,
+			Loser:            loser,
+			FirstChunk:       entry.elapsed,
+			EstimatedCostUSD: cost,
+		}
+		if len(errs) > 0 {
+			result.LoserErr = errs[0]
+		} else {
+			cancels[1-entry.index]()
+			go abandon(results, pending-1)
+		}
+		if r.cfg.Hooks.OnResult != nil {
+			r.cfg.Hooks.OnResult(result)
+		}
+		return forward(ctx, entry), nil
+	}
+	return nil, fmt.Errorf("race: every contender failed: %w", errors.Join(errs...))
+}
+
+// runContender starts one contender and reads its stream up to the first
+// chunk carrying text or a tool call. Reasoning arrives before the answer
+// and does not count; it is buffered and replayed if the contender wins.
+func runContender(ctx context.Context, cancel context.CancelFunc, index int, provider agent.LLMProvider, req *agent.CompletionRequest, start time.Time) raceEntry {
+	entry := raceEntry{index: index, cancel: cancel}
+	stream, err := provider.Complete(ctx, req)
+	if err != nil {
+		cancel()
+		entry.err = err
+		return entry
+	}
+	for chunk := range stream {
+		if chunk == nil {
+			continue
+		}
+		if chunk.Error != nil {
+			cancel()
+			drain(stream)
+			entry.err = chunk.Error
+			return entry
+		}
+		entry.buffered = append(entry.buffered, chunk)
+		if chunk.Text != "" || chunk.ToolCall != nil {
+			entry.stream = stream
+			entry.elapsed = time.Since(start)
+			return entry
+		}
+		if chunk.Done {
+			break
+		}
+	}
+	cancel()
+	drain(stream)
+	if err := ctx.Err(); err != nil {
+		entry.err = err
+	} else {
+		entry.err = errors.New("empty response")
+	}
+	return entry
+}
+
+// forward streams the winner's buffered chunks and then the rest of its
+// stream.
+func forward(ctx context.Context, entry raceEntry) <-chan *agent.CompletionChunk {
+	out := make(chan *agent.CompletionChunk)
+	go func() {
+		defer close(out)
+		defer entry.cancel()
+		for _, chunk := range entry.buffered {
+			select {
+			case out <- chunk:
+			case <-ctx.Done():
+				drain(entry.stream)
+				return
+			}
+		}
+		for chunk := range entry.stream {
+			select {
+			case out <- chunk:
+			case <-ctx.Done():
+				drain(entry.stream)
+				return
+			}
+		}
+	}()
+	return out
+}
+
+// abandon cancels the contenders still running and releases their streams.
+func abandon(results <-chan raceEntry, pending int) {
+	for ; pending > 0; pending-- {
+		entry := <-results
+		if entry.stream != nil {
+			entry.cancel()
+			drain(entry.stream)
+		}
+	}
+}
+
+func drain(stream <-chan *agent.CompletionChunk) {
+	for range stream {
+	}
+}
+
+// gate returns the estimated cost of racing req, or the reason it should
+// not race. Passing the gate reserves the cost against the daily budget.
+func (r *Racer) gate(ctx context.Context, req *agent.CompletionRequest) (float64, string) {
+	session := agent.SessionFromContext(ctx)
+	if session == nil {
+		return 0, RaceSkipBackground
+	}
+	if len(r.cfg.Channels) > 0 && !slices.Contains(r.cfg.Channels, string(session.Channel)) {
+		return 0, RaceSkipChannel
+	}
+	if len(req.Tools) > 0 {
+		for _, contender := range r.cfg.Contenders {
+			if !contender.Provider.SupportsTools() {
+				return 0, RaceSkipTools
+			}
+		}
+	}
+	cost := r.estimateCost(req)
+	if r.cfg.MaxCostUSD > 0 && cost > r.cfg.MaxCostUSD {
+		return cost, RaceSkipCost
+	}
+	if r.cfg.DailyBudgetUSD > 0 {
+		day := time.Now().UTC().Format(time.DateOnly)
+		r.budgetMu.Lock()
+		defer r.budgetMu.Unlock()
+		if r.budgetDay != day {
+			r.budgetDay, r.spentUSD = day, 0
+		}
+		if r.spentUSD+cost > r.cfg.DailyBudgetUSD {
+			return cost, RaceSkipBudget
+		}
+		r.spentUSD += cost
+	}
+	return cost, ""
+}
+
+// estimateCost prices req on both contenders from the catalog, assuming
+// the full max_tokens is generated.
+func (r *Racer) estimateCost(req *agent.CompletionRequest) float64 {
+	if r.cfg.Catalog == nil {
+		return 0
+	}
+	shape := shapeOf(req)
+	var cost float64
+	for _, contender := range r.cfg.Contenders {
+		entry, ok := r.cfg.Catalog.Lookup(contender.Model)
+		if !ok {
+			continue
+		}
+		cost += (float64(shape.promptTokens)*entry.InputPrice + float64(req.MaxTokens)*entry.OutputPrice) / 1e6
+	}
+	return cost
+}
+
+// Name returns the racer name.
+func (r *Racer) Name() string {
+	return "race:" + r.cfg.Contenders[0].Name + "+" + r.cfg.Contenders[1].Name
+}
+
+// Models returns the fallback's models.
+func (r *Racer) Models() []agent.Model {
+	return r.cfg.Fallback.Models()
+}
+
+// SupportsTools reports whether the fallback supports tools; tool requests
+// race only when both contenders do.
+func (r *Racer) SupportsTools() bool {
+	return r.cfg.Fallback.SupportsTools()
+}
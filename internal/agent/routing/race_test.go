@@ -0,0 +1,263 @@
+package routing
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/haasonsaas/nexus/internal/agent"
+	modelcatalog "github.com/haasonsaas/nexus/internal/models"
+	"github.com/haasonsaas/nexus/pkg/models"
+)
+
+// raceProvider streams its text after delay, or fails with err.
+type raceProvider struct {
+	name  string
+	delay time.Duration
+	text  string
+	err   error
+
+	mu        sync.Mutex
+	calls     int
+	lastModel string
+	cancelled chan struct{}
+}
+
+func newRaceProvider(name string, delay time.Duration, text string) *raceProvider {
+	return &raceProvider{name: name, delay: delay, text: text, cancelled: make(chan struct{}, 1)}
+}
+
+func (p *raceProvider) Complete(ctx context.Context, req *agent.CompletionRequest) (<-chan *agent.CompletionChunk, error) {
+	p.mu.Lock()
+	p.calls++
+	p.lastModel = req.Model
+	p.mu.Unlock()
+	ch := make(chan *agent.CompletionChunk)
+	go func() {
+		defer close(ch)
+		select {
+		case <-time.After(p.delay):
+		case <-ctx.Done():
+			p.cancelled <- struct{}{}
+			return
+		}
+		if p.err != nil {
+			ch <- &agent.CompletionChunk{Error: p.err}
+			return
+		}
+		ch <- &agent.CompletionChunk{Thinking: "hmm"}
+		ch <- &agent.CompletionChunk{Text: p.text}
+		ch <- &agent.CompletionChunk{Done: true}
+	}()
+	return ch, nil
+}
+
+func (p *raceProvider) Name() string          { return p.name }
+func (p *raceProvider) Models() []agent.Model { return nil }
+func (p *raceProvider) SupportsTools() bool   { return true }
+
+func (p *raceProvider) callCount() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.calls
+}
+
+func sessionCtx(channel models.ChannelType) context.Context {
+	return agent.WithSession(context.Background(), &models.Session{ID: "s1", Channel: channel})
+}
+
+func collectText(t *testing.T, stream <-chan *agent.CompletionChunk) string {
+	t.Helper()
+	var b strings.Builder
+	for chunk := range stream {
+		if chunk.Error != nil {
+			t.Fatalf("unexpected stream error: %v", chunk.Error)
+		}
+		b.WriteString(chunk.Text)
+	}
+	return b.String()
+}
+
+func TestRacerStreamsFasterContenderAndCancelsOther(t *testing.T) {
+	fast := newRaceProvider("ollama", 0, "fast")
+	slow := newRaceProvider("anthropic", time.Hour, "slow")
+	var results []RaceResult
+	racer, err := NewRacer(RaceConfig{
+		Contenders: []RaceContender{
+			{Name: "anthropic", Provider: slow, Model: "claude"},
+			{Name: "ollama", Provider: fast, Model: "llama3.2"},
+		},
+		Fallback: &stubProvider{name: "fallback"},
+		Hooks:    RaceHooks{OnResult: func(r RaceResult) { results = append(results, r) }},
+	})
+	if err != nil {
+		t.Fatalf("NewRacer() error = %v", err)
+	}
+
+	stream, err := racer.Complete(sessionCtx(models.ChannelSlack), &agent.CompletionRequest{Model: "claude"})
+	if err != nil {
+		t.Fatalf("Complete() error = %v", err)
+	}
+	if got := collectText(t, stream); got != "fast" {
+		t.Fatalf("text = %q, want fast", got)
+	}
+	select {
+	case <-slow.cancelled:
+	case <-time.After(5 * time.Second):
+		t.Fatal("slower contender was not cancelled")
+	}
+	if len(results) != 1 || results[0].Winner != "ollama" || results[0].Loser != "anthropic" || results[0].LoserErr != nil {
+		t.Fatalf("unexpected results: %+v", results)
+	}
+	if fast.lastModel != "llama3.2" {
+		t.Fatalf("contender model = %q, want llama3.2", fast.lastModel)
+	}
+}
+
+func TestRacerFallsThroughToSlowerContenderOnError(t *testing.T) {
+	failing := newRaceProvider("ollama", 0, "")
+	failing.err = errors.New("model not loaded")
+	slow := newRaceProvider("anthropic", 20*time.Millisecond, "slow")
+	var result RaceResult
+	racer, err := NewRacer(RaceConfig{
+		Contenders: []RaceContender{{Provider: failing}, {Provider: slow}},
+		Fallback:   &stubProvider{name: "fallback"},
+		Hooks:      RaceHooks{OnResult: func(r RaceResult) { result = r }},
+	})
+	if err != nil {
+		t.Fatalf("NewRacer() error = %v", err)
+	}
+
+	stream, err := racer.Complete(sessionCtx(models.ChannelTelegram), &agent.CompletionRequest{})
+	if err != nil {
+		t.Fatalf("Complete() error = %v", err)
+	}
+	if got := collectText(t, stream); got != "slow" {
+		t.Fatalf("text = %q, want slow", got)
+	}
+	if result.Winner != "anthropic" || result.LoserErr == nil || !strings.Contains(result.LoserErr.Error(), "model not loaded") {
+		t.Fatalf("unexpected result: %+v", result)
+	}
+
+	slow.err = errors.New("overloaded")
+	_, err = racer.Complete(sessionCtx(models.ChannelTelegram), &agent.CompletionRequest{})
+	if err == nil || !strings.Contains(err.Error(), "model not loaded") || !strings.Contains(err.Error(), "overloaded") {
+		t.Fatalf("expected both contender errors, got %v", err)
+	}
+}
+
+func TestRacerGating(t *testing.T) {
+	catalog := modelcatalog.NewCatalog()
+	catalog.Register(&modelcatalog.Model{ID: "cloud-model", InputPrice: 10, OutputPrice: 10})
+	prompt := strings.Repeat("word ", 2000)
+
+	tests := []struct {
+		name   string
+		ctx    context.Context
+		req    *agent.CompletionRequest
+		cfg    func(*RaceConfig)
+		reason string
+	}{
+		{name: "races session request", ctx: sessionCtx(models.ChannelSlack), req: &agent.CompletionRequest{}},
+		{name: "background request", ctx: context.Background(), req: &agent.CompletionRequest{}, reason: RaceSkipBackground},
+		{
+			name:   "channel not listed",
+			ctx:    sessionCtx(models.ChannelEmail),
+			req:    &agent.CompletionRequest{},
+			cfg:    func(c *RaceConfig) { c.Channels = []string{"slack"} },
+			reason: RaceSkipChannel,
+		},
+		{
+			name:   "over cost cap",
+			ctx:    sessionCtx(models.ChannelSlack),
+			req:    &agent.CompletionRequest{MaxTokens: 1000, Messages: []agent.CompletionMessage{{Role: "user", Content: prompt}}},
+			cfg:    func(c *RaceConfig) { c.MaxCostUSD = 0.01 },
+			reason: RaceSkipCost,
+		},
+		{
+			name: "under cost cap",
+			ctx:  sessionCtx(models.ChannelSlack),
+			req:  &agent.CompletionRequest{MaxTokens: 100, Messages: []agent.CompletionMessage{{Role: "user", Content: "hi"}}},
+			cfg:  func(c *RaceConfig) { c.MaxCostUSD = 0.01 },
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fallback := &stubProvider{name: "fallback"}
+			var skipped []string
+			cfg := RaceConfig{
+				Contenders: []RaceContender{
+					{Provider: newRaceProvider("ollama", 0, "local"), Model: "llama3.2"},
+					{Provider: newRaceProvider("openai", 0, "cloud"), Model: "cloud-model"},
+				},
+				Fallback: fallback,
+				Catalog:  catalog,
+				Hooks:    RaceHooks{OnSkip: func(reason string) { skipped = append(skipped, reason) }},
+			}
+			if tt.cfg != nil {
+				tt.cfg(&cfg)
+			}
+			racer, err := NewRacer(cfg)
+			if err != nil {
+				t.Fatalf("NewRacer() error = %v", err)
+			}
+			stream, err := racer.Complete(tt.ctx, tt.req)
+			if err != nil {
+				t.Fatalf("Complete() error = %v", err)
+			}
+			collectText(t, stream)
+			if tt.reason == "" {
+				if fallback.calls != 0 || len(skipped) != 0 {
+					t.Fatalf("expected a race, got fallback calls %d, skipped %v", fallback.calls, skipped)
+				}
+				return
+			}
+			if fallback.calls != 1 || len(skipped) != 1 || skipped[0] != tt.reason {
+				t.Fatalf("expected skip %q, got fallback calls %d, skipped %v", tt.reason, fallback.calls, skipped)
+			}
+		})
+	}
+}
+
+func TestRacerDailyBudget(t *testing.T) {
+	catalog := modelcatalog.NewCatalog()
+	catalog.Register(&modelcatalog.Model{ID: "cloud-model", InputPrice: 0, OutputPrice: 10})
+	fallback := &stubProvider{name: "fallback"}
+	local := newRaceProvider("ollama", 0, "local")
+	racer, err := NewRacer(RaceConfig{
+		Contenders: []RaceContender{
+			{Provider: local, Model: "llama3.2"},
+			{Provider: newRaceProvider("openai", 0, "cloud"), Model: "cloud-model"},
+		},
+		Fallback:       fallback,
+		Catalog:        catalog,
+		DailyBudgetUSD: 0.025,
+	})
+	if err != nil {
+		t.Fatalf("NewRacer() error = %v", err)
+	}
+
+	// Each request is estimated at 1000 output tokens * $10/M = $0.01.
+	for range 3 {
+		stream, err := racer.Complete(sessionCtx(models.ChannelSlack), &agent.CompletionRequest{MaxTokens: 1000})
+		if err != nil {
+			t.Fatalf("Complete() error = %v", err)
+		}
+		collectText(t, stream)
+	}
+	if local.callCount() != 2 || fallback.calls != 1 {
+		t.Fatalf("raced %d requests and fell back %d times, want 2 and 1", local.callCount(), fallback.calls)
+	}
+}
+
+func TestNewRacerRequiresTwoContenders(t *testing.T) {
+	if _, err := NewRacer(RaceConfig{
+		Contenders: []RaceContender{{Provider: &stubProvider{name: "one"}}},
+		Fallback:   &stubProvider{name: "fallback"},
+	}); err == nil {
+		t.Fatal("expected error for a single contender")
+	}
+}
@@ -8,6 +8,8 @@ import (
 	"time"
 
 	"github.com/haasonsaas/nexus/internal/agent"
+	ctxwindow "github.com/haasonsaas/nexus/internal/context"
+	modelcatalog "github.com/haasonsaas/nexus/internal/models"
 )
 
 // Router selects an LLM provider for each request based on rules and heuristics.
@@ -20,6 +22,7 @@ type Router struct {
 	classifier      Classifier
 	fallback        Target
 	failureCooldown time.Duration
+	catalog         *modelcatalog.Catalog
 	healthMu        sync.Mutex
 	unhealthy       map[string]time.Time
 }
@@ -31,10 +34,33 @@ type Rule struct {
 	Target Target
 }
 
-// Match defines rule matching conditions.
+// Match defines rule matching conditions. Every condition that is set must
+// hold; a Match with no conditions never matches.
 type Match struct {
 	Patterns []string
 	Tags     []string
+
+	// MinTokens and MaxTokens bound the estimated prompt tokens.
+	MinTokens int
+	MaxTokens int
+
+	// Vision, when set, requires image attachments to be present (true)
+	// or absent (false).
+	Vision *bool
+
+	// Tools, when set, requires tools to be offered (true) or not (false).
+	Tools *bool
+
+	// MaxCostUSD, when positive, requires the request's estimated cost on
+	// the rule's target model to stay at or below it. Models without
+	// catalog pricing count as free.
+	MaxCostUSD float64
+}
+
+// isEmpty reports whether the match has no conditions.
+func (m Match) isEmpty() bool {
+	return len(m.Patterns) == 0 && len(m.Tags) == 0 && m.MinTokens == 0 && m.MaxTokens == 0 &&
+		m.Vision == nil && m.Tools == nil && m.MaxCostUSD == 0
 }
 
 // Target defines the destination provider and model.
@@ -57,6 +83,11 @@ type Config struct {
 	Classifier      Classifier
 	Fallback        Target
 	FailureCooldown time.Duration
+
+	// Catalog, when set, skips candidates whose model is known to lack
+	// tool or vision support the request needs, or whose context window
+	// is too small for it.
+	Catalog *modelcatalog.Catalog
 }
 
 // NewRouter creates a new Router.
@@ -82,6 +113,7 @@ func NewRouter(cfg Config, providers map[string]agent.LLMProvider) *Router {
 		classifier:      classifier,
 		fallback:        cfg.Fallback,
 		failureCooldown: cfg.FailureCooldown,
+		catalog:         cfg.Catalog,
 		unhealthy:       make(map[string]time.Time),
 	}
 }
@@ -181,6 +213,8 @@ func (r *Router) candidates(req *agent.CompletionRequest) ([]candidate, error) {
 		candidates = filtered
 	}
 
+	candidates = r.filterByCatalog(req, candidates)
+
 	if len(candidates) == 0 {
 		if len(req.Tools) > 0 {
 			return nil, errInvalidRequest("no tool-capable providers available")
@@ -190,6 +224,42 @@ func (r *Router) candidates(req *agent.CompletionRequest) ([]candidate, error) {
 	return candidates, nil
 }
 
+// filterByCatalog drops candidates whose model the catalog knows cannot
+// serve req. Unknown models are kept, and if every candidate would be
+// dropped the list is returned unchanged so the provider reports the error.
+func (r *Router) filterByCatalog(req *agent.CompletionRequest, candidates []candidate) []candidate {
+	if r.catalog == nil || len(candidates) == 0 {
+		return candidates
+	}
+	shape := shapeOf(req)
+	tokens := shape.promptTokens + req.MaxTokens
+
+	filtered := make([]candidate, 0, len(candidates))
+	for _, candidate := range candidates {
+		model := req.Model
+		if model == "" {
+			model = candidate.model
+		}
+		entry, ok := r.catalog.Lookup(model)
+		if ok {
+			if len(req.Tools) > 0 && !entry.SupportsTools() {
+				continue
+			}
+			if shape.vision && !entry.SupportsVision() {
+				continue
+			}
+			if entry.ContextWindow > 0 && tokens > entry.ContextWindow {
+				continue
+			}
+		}
+		filtered = append(filtered, candidate)
+	}
+	if len(filtered) == 0 {
+		return candidates
+	}
+	return filtered
+}
+
 func (r *Router) appendCandidate(list *[]candidate, seen map[string]struct{}, name string, model string) {
 	if r == nil {
 		return
@@ -248,11 +318,15 @@ func (r *Router) markUnhealthy(name string) {
 }
 
 func (r *Router) selectProvider(req *agent.CompletionRequest) (string, string) {
-	tags := r.classifier.Classify(req)
+	var tags []string
+	if r.needsTags() {
+		tags = r.classifier.Classify(req)
+	}
+	shape := shapeOf(req)
 
 	// Rule matching (first match wins).
 	for _, rule := range r.rules {
-		if ruleMatches(rule.Match, tags, req) {
+		if ruleMatches(rule.Match, tags, req, shape) && r.withinCost(rule, req, shape) {
 			return normalizeID(rule.Target.Provider), rule.Target.Model
 		}
 	}
@@ -291,8 +365,68 @@ func (r *Router) findToolProvider() agent.LLMProvider {
 	return nil
 }
 
-func ruleMatches(match Match, tags []string, req *agent.CompletionRequest) bool {
-	if len(match.Patterns) == 0 && len(match.Tags) == 0 {
+// needsTags reports whether any rule matches on tags, so a classifier
+// (which may call an LLM) only runs when its answer is used.
+func (r *Router) needsTags() bool {
+	for _, rule := range r.rules {
+		if len(rule.Match.Tags) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// withinCost checks a rule's cost ceiling against the estimated cost of the
+// request on the rule's target model.
+func (r *Router) withinCost(rule Rule, req *agent.CompletionRequest, shape requestShape) bool {
+	if rule.Match.MaxCostUSD <= 0 || r.catalog == nil {
+		return true
+	}
+	model := rule.Target.Model
+	if model == "" {
+		model = req.Model
+	}
+	entry, ok := r.catalog.Lookup(model)
+	if !ok {
+		return true
+	}
+	cost := (float64(shape.promptTokens)*entry.InputPrice + float64(req.MaxTokens)*entry.OutputPrice) / 1e6
+	return cost <= rule.Match.MaxCostUSD
+}
+
+// requestShape holds the request features routing predicates look at.
+type requestShape struct {
+	promptTokens int
+	vision       bool
+}
+
+func shapeOf(req *agent.CompletionRequest) requestShape {
+	shape := requestShape{promptTokens: ctxwindow.EstimateTokens(req.System)}
+	for _, msg := range req.Messages {
+		shape.promptTokens += ctxwindow.EstimateTokens(msg.Content)
+		for _, att := range msg.Attachments {
+			if att.Type == "image" {
+				shape.vision = true
+			}
+		}
+	}
+	return shape
+}
+
+func ruleMatches(match Match, tags []string, req *agent.CompletionRequest, shape requestShape) bool {
+	if match.isEmpty() {
+		return false
+	}
+	if match.MinTokens > 0 && shape.promptTokens < match.MinTokens {
+		return false
+	}
+	if match.MaxTokens > 0 && shape.promptTokens > match.MaxTokens {
+		return false
+	}
+	if match.Vision != nil && *match.Vision != shape.vision {
+		return false
+	}
+	if match.Tools != nil && *match.Tools != (len(req.Tools) > 0) {
 		return false
 	}
 	content := lastUserContent(req)
@@ -3,9 +3,12 @@ package routing
 import (
 	"context"
 	"encoding/json"
+	"strings"
 	"testing"
 
 	"github.com/haasonsaas/nexus/internal/agent"
+	modelcatalog "github.com/haasonsaas/nexus/internal/models"
+	"github.com/haasonsaas/nexus/pkg/models"
 )
 
 type stubProvider struct {
@@ -161,3 +164,136 @@ func TestRouterFallbackOnError(t *testing.T) {
 		t.Fatalf("expected fallback provider to be called")
 	}
 }
+
+func TestRouterSkipsModelsTheCatalogRulesOut(t *testing.T) {
+	small := &stubProvider{name: "small", supportsTools: true}
+	large := &stubProvider{name: "large", supportsTools: true}
+	providers := map[string]agent.LLMProvider{
+		"small": small,
+		"large": large,
+	}
+	router := NewRouter(Config{
+		DefaultProvider: "large",
+		Rules: []Rule{{
+			Name:   "quick",
+			Match:  Match{Tags: []string{"quick"}},
+			Target: Target{Provider: "small", Model: "gpt-4"},
+		}},
+		Fallback: Target{Provider: "large", Model: "claude-3-5-sonnet-latest"},
+		Catalog:  modelcatalog.NewCatalog(),
+	}, providers)
+
+	// gpt-4 has an 8k window, too small for this prompt.
+	req := &agent.CompletionRequest{
+		System:   strings.Repeat("context ", 8000),
+		Messages: []agent.CompletionMessage{{Role: "user", Content: "quick"}},
+	}
+	if _, err := router.Complete(context.Background(), req); err != nil {
+		t.Fatalf("Complete() error: %v", err)
+	}
+	if small.calls != 0 || large.calls != 1 || large.lastModel != "claude-3-5-sonnet-latest" {
+		t.Fatalf("small calls = %d, large calls = %d (model %q)", small.calls, large.calls, large.lastModel)
+	}
+
+	// gpt-4 has no vision support.
+	req = &agent.CompletionRequest{
+		Messages: []agent.CompletionMessage{{Role: "user", Content: "quick", Attachments: []models.Attachment{{Type: "image", URL: "https://example.com/cat.png"}}}},
+	}
+	if _, err := router.Complete(context.Background(), req); err != nil {
+		t.Fatalf("Complete() error: %v", err)
+	}
+	if small.calls != 0 || large.calls != 2 {
+		t.Fatalf("small calls = %d, large calls = %d", small.calls, large.calls)
+	}
+
+	// A short text prompt still goes to the rule's target.
+	req = &agent.CompletionRequest{Messages: []agent.CompletionMessage{{Role: "user", Content: "quick"}}}
+	if _, err := router.Complete(context.Background(), req); err != nil {
+		t.Fatalf("Complete() error: %v", err)
+	}
+	if small.calls != 1 {
+		t.Fatalf("small calls = %d, want 1", small.calls)
+	}
+}
+
+func TestRouterTokenToolAndCostPredicates(t *testing.T) {
+	local := &stubProvider{name: "ollama"}
+	cheap := &stubProvider{name: "openai", supportsTools: true}
+	premium := &stubProvider{name: "anthropic", supportsTools: true}
+	providers := map[string]agent.LLMProvider{
+		"ollama":    local,
+		"openai":    cheap,
+		"anthropic": premium,
+	}
+	no := false
+	router := NewRouter(Config{
+		DefaultProvider: "openai",
+		Rules: []Rule{
+			{Name: "short", Match: Match{MaxTokens: 50, Tools: &no}, Target: Target{Provider: "ollama"}},
+			{Name: "premium", Match: Match{MinTokens: 51, MaxCostUSD: 0.05}, Target: Target{Provider: "anthropic", Model: "claude-opus-4"}},
+		},
+		Catalog: modelcatalog.NewCatalog(),
+	}, providers)
+
+	complete := func(req *agent.CompletionRequest) {
+		t.Helper()
+		if _, err := router.Complete(context.Background(), req); err != nil {
+			t.Fatalf("Complete() error: %v", err)
+		}
+	}
+
+	// Short, tool-free messages go to the local model.
+	complete(&agent.CompletionRequest{Messages: []agent.CompletionMessage{{Role: "user", Content: "hi there"}}})
+	if local.calls != 1 {
+		t.Fatalf("local calls = %d, want 1", local.calls)
+	}
+
+	// The same message with tools skips the short rule.
+	complete(&agent.CompletionRequest{
+		Messages: []agent.CompletionMessage{{Role: "user", Content: "hi there"}},
+		Tools:    []agent.Tool{dummyTool{}},
+	})
+	if local.calls != 1 || cheap.calls != 1 {
+		t.Fatalf("local calls = %d, cheap calls = %d", local.calls, cheap.calls)
+	}
+
+	// ~1k tokens on Opus ($15/M in, $75/M out) is well under $0.05.
+	complete(&agent.CompletionRequest{
+		Messages:  []agent.CompletionMessage{{Role: "user", Content: strings.Repeat("word ", 800)}},
+		MaxTokens: 100,
+	})
+	if premium.calls != 1 || premium.lastModel != "claude-opus-4" {
+		t.Fatalf("premium calls = %d (model %q), want 1", premium.calls, premium.lastModel)
+	}
+
+	// ~10k tokens would cost over $0.15, so the ceiling sends it to the default.
+	complete(&agent.CompletionRequest{
+		Messages:  []agent.CompletionMessage{{Role: "user", Content: strings.Repeat("word ", 8000)}},
+		MaxTokens: 100,
+	})
+	if premium.calls != 1 || cheap.calls != 2 {
+		t.Fatalf("premium calls = %d, cheap calls = %d", premium.calls, cheap.calls)
+	}
+}
+
+func TestRouterSkipsClassifierWithoutTagRules(t *testing.T) {
+	classifier := &countingClassifier{}
+	router := NewRouter(Config{
+		DefaultProvider: "openai",
+		Rules:           []Rule{{Name: "short", Match: Match{MaxTokens: 10}, Target: Target{Provider: "openai"}}},
+		Classifier:      classifier,
+	}, map[string]agent.LLMProvider{"openai": &stubProvider{name: "openai"}})
+	if _, err := router.Complete(context.Background(), &agent.CompletionRequest{Messages: []agent.CompletionMessage{{Role: "user", Content: "hello"}}}); err != nil {
+		t.Fatalf("Complete() error: %v", err)
+	}
+	if classifier.calls != 0 {
+		t.Fatalf("classifier calls = %d, want 0", classifier.calls)
+	}
+}
+
+type countingClassifier struct{ calls int }
+
+func (c *countingClassifier) Classify(*agent.CompletionRequest) []string {
+	c.calls++
+	return nil
+}
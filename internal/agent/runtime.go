@@ -490,6 +490,11 @@ func (r *Runtime) toolExecOverrides(name string) ToolExecConfig {
 	return overrides
 }
 
+// Tools returns the registered tools.
+func (r *Runtime) Tools() []Tool {
+	return r.tools.AsLLMTools()
+}
+
 // UnregisterTool removes a tool from the runtime by name.
 func (r *Runtime) UnregisterTool(name string) {
 	r.tools.Unregister(name)
@@ -575,7 +580,7 @@ func (r *Runtime) Process(ctx context.Context, session *models.Session, msg *mod
 //
 // The emitter dispatches events to whatever sink(s) are configured.
 // Returns nil on success, error on fatal failures.
-func (r *Runtime) run(ctx context.Context, session *models.Session, msg *models.Message, emitter *EventEmitter) error {
+func (r *Runtime) run(ctx context.Context, session *models.Session, msg *models.Message, emitter *EventEmitter) (runErr error) {
 	// Apply wall time limit if configured
 	var cancel context.CancelFunc
 	wallTimeLimit := r.maxWallTime
@@ -595,177 +600,38 @@ func (r *Runtime) run(ctx context.Context, session *models.Session, msg *models.
 	}
 	elevatedMode := ElevatedFromContext(ctx)
 
-	// 1) Load history (pre-incoming message)
-	branchID := strings.TrimSpace(msg.BranchID)
-	if r.branchStore != nil {
-		if branchID == "" {
-			branch, branchErr := r.branchStore.EnsurePrimaryBranch(ctx, session.ID)
-			if branchErr != nil {
-				emitter.RunError(ctx, branchErr, false)
-				return branchErr
-			}
-			branchID = branch.ID
-		}
-		msg.BranchID = branchID
-	}
-
-	var (
-		history []*models.Message
-		err     error
-	)
-	if r.branchStore != nil && branchID != "" {
-		history, err = r.branchStore.GetBranchHistory(ctx, branchID, 50)
-	} else {
-		history, err = r.sessions.GetHistory(ctx, session.ID, 50)
-	}
-	if err != nil {
-		emitter.RunError(ctx, err, false)
-		return err
-	}
-	history = repairTranscript(history)
-
-	appendMessage := func(message *models.Message) error {
-		if message == nil {
-			return nil
-		}
-		if r.branchStore != nil && branchID != "" {
-			message.BranchID = branchID
-			return r.branchStore.AppendMessageToBranch(ctx, session.ID, branchID, message)
-		}
-		return r.sessions.AppendMessage(ctx, session.ID, message)
-	}
-
-	// 2) Persist inbound user message (source of truth)
-	if msg.ID == "" {
-		msg.ID = uuid.NewString()
-	}
-	if msg.SessionID == "" {
-		msg.SessionID = session.ID
-	}
-	if msg.CreatedAt.IsZero() {
-		msg.CreatedAt = time.Now()
-	}
-	if msg.Direction == "" {
-		msg.Direction = models.DirectionInbound
-	}
-	if err := appendMessage(msg); err != nil {
-		wrappedErr := fmt.Errorf("failed to persist user message: %w", err)
-		emitter.RunError(ctx, wrappedErr, false)
-		return wrappedErr
-	}
-
-	// 3) Optional summarization
-	var summaryMsg *models.Message
-	if r.summarizeConfig != nil {
-		summaryMsg = agentctx.FindLatestSummary(history)
-
-		cfg := *r.summarizeConfig
-		summaryProvider := &llmSummaryProvider{runtime: r}
-		summarizer := agentctx.NewSummarizer(summaryProvider, cfg)
-
-		if summarizer.ShouldSummarize(history, summaryMsg) {
-			newSummary, sumErr := summarizer.Summarize(ctx, session.ID, history, summaryMsg)
-			if sumErr != nil {
-				emitter.RunError(ctx, sumErr, false)
-				return sumErr
-			}
-			if newSummary != nil {
-				if newSummary.ID == "" {
-					newSummary.ID = uuid.NewString()
-				}
-				if newSummary.SessionID == "" {
-					newSummary.SessionID = session.ID
-				}
-				if newSummary.CreatedAt.IsZero() {
-					newSummary.CreatedAt = time.Now()
-				}
-				if err := appendMessage(newSummary); err != nil {
-					wrappedErr := fmt.Errorf("failed to persist summary message: %w", err)
-					emitter.RunError(ctx, wrappedErr, false)
-					return wrappedErr
-				}
-				summaryMsg = newSummary
-			}
-		}
-	} else {
-		summaryMsg = agentctx.FindLatestSummary(history)
-	}
-
 	model := r.defaultModel
+	generation, _ := generationProfileFromContext(ctx)
+	if generation.Model != "" {
+		model = generation.Model
+	}
 	if override, ok := modelFromContext(ctx); ok {
 		model = override
 	}
 
-	// 4) Context packing
-	packOpts := agentctx.DefaultPackOptions()
-	if r.packOpts != nil {
-		packOpts = *r.packOpts
-	}
-	if settings := r.contextPruningSettings(); settings != nil && settings.Mode == agentctx.ContextPruningCacheTTL {
-		if isCacheTTLEligibleProvider(r.provider.Name(), model) {
-			now := time.Now()
-			lastTouch, ok := r.cacheTouchAt(session.ID)
-			if !ok {
-				if stored, storedOK := cacheTouchFromSession(session); storedOK {
-					lastTouch = stored
-					ok = true
-					r.setCacheTouchAt(session.ID, stored)
-				}
-			}
-			if ok && settings.TTL > 0 && now.Sub(lastTouch) >= settings.TTL {
-				charWindow := contextPruningCharWindow(model, packOpts)
-				if charWindow > 0 {
-					history = agentctx.PruneContextMessages(history, *settings, charWindow)
-				}
-			}
-			r.setCacheTouchAt(session.ID, now)
-			r.persistCacheTouch(ctx, session, now)
+	// 1-5a) Build the conversation from history, or continue a checkpointed run
+	resume := resumeCheckpointFromContext(ctx)
+	var prepared *preparedRun
+	if resume != nil {
+		if resume.Model != "" {
+			model = resume.Model
 		}
-	}
-	packer := agentctx.NewPacker(packOpts)
-
-	packResult := packer.PackWithDiagnostics(history, msg, summaryMsg)
-	packedModels := packResult.Messages
-
-	// Emit context packed event with diagnostics
-	emitter.ContextPacked(ctx, packResult.Diagnostics)
-
-	// 5) System prompt composition
-	var systemParts []string
-	if system, ok := systemPromptFromContext(ctx); ok {
-		systemParts = append(systemParts, system)
-	} else if r.defaultSystem != "" {
-		systemParts = append(systemParts, r.defaultSystem)
-	}
-
-	nonSystemPacked := make([]*models.Message, 0, len(packedModels))
-	for _, m := range packedModels {
-		if m == nil {
-			continue
+		prepared = &preparedRun{
+			branchID: resume.BranchID,
+			system:   resume.System,
+			messages: resume.resumeMessages(),
 		}
-		if m.Role == models.RoleSystem {
-			if strings.TrimSpace(m.Content) != "" {
-				systemParts = append(systemParts, m.Content)
-			}
-			continue
-		}
-		nonSystemPacked = append(nonSystemPacked, m)
-	}
-
-	messages, err := r.buildCompletionMessages(nonSystemPacked)
-	if err != nil {
-		emitter.RunError(ctx, err, false)
-		return err
-	}
-
-	// 5a) Apply context transform if configured
-	if transform := ContextTransformFromContext(ctx); transform != nil {
-		messages, err = transform(ctx, messages)
+	} else {
+		var err error
+		prepared, err = r.prepareRun(ctx, session, msg, model, emitter)
 		if err != nil {
-			emitter.RunError(ctx, fmt.Errorf("context transform failed: %w", err), false)
 			return err
 		}
 	}
+	branchID := prepared.branchID
+	appendMessage := func(message *models.Message) error {
+		return r.appendRunMessage(ctx, session.ID, branchID, message)
+	}
 
 	// 5b) Get steering queue from context for mid-run interruptions
 	steeringQueue := SteeringQueueFromContext(ctx)
@@ -782,7 +648,7 @@ func (r *Runtime) run(ctx context.Context, session *models.Session, msg *models.
 		if r.toolEvents == nil {
 			return
 		}
-		guarded := guardToolResult(runOpts.ToolResultGuard, tc.Name, res, resolver)
+		guarded := guardToolResult(ctx, runOpts.ToolResultGuard, tc.Name, res, resolver)
 		if err := r.toolEvents.AddToolResult(ctx, session.ID, assistantMsgID, &tc, &guarded); err != nil {
 			r.opts.Logger.Debug(
 				"failed to persist tool result event",
@@ -797,24 +663,24 @@ func (r *Runtime) run(ctx context.Context, session *models.Session, msg *models.
 
 	// 7) Build base request
 	req := &CompletionRequest{
-		Messages:  messages,
+		Messages:  prepared.messages,
 		Tools:     tools,
+		System:    prepared.system,
 		MaxTokens: 4096,
 	}
 	if model != "" {
 		req.Model = model
 	}
-	if len(systemParts) > 0 {
-		req.System = strings.Join(systemParts, "\n\n")
-	}
+	generation.apply(req)
 
-	// 7a) Apply thinking level from context
-	if thinkingLevel := ThinkingLevelFromContext(ctx); thinkingLevel != ThinkingOff {
-		budget := GetThinkingBudget(thinkingLevel)
-		if budget > 0 {
-			req.EnableThinking = true
-			req.ThinkingBudgetTokens = budget
-		}
+	// 7a) Apply thinking budget or level from context
+	budget := ThinkingBudgetFromContext(ctx)
+	if budget == 0 {
+		budget = GetThinkingBudget(ThinkingLevelFromContext(ctx))
+	}
+	if budget > 0 {
+		req.EnableThinking = true
+		req.ThinkingBudgetTokens = budget
 	}
 
 	// Tool executor config
@@ -827,8 +693,20 @@ func (r *Runtime) run(ctx context.Context, session *models.Session, msg *models.
 	if toolExecCfg.Concurrency <= 0 || toolExecCfg.PerToolTimeout <= 0 {
 		toolExecCfg = DefaultToolExecConfig()
 	}
+	// Retrying a tool with side effects can repeat them, so only tools
+	// declared idempotent are retried.
+	toolExecCfg.MaxRetryBackoff = runOpts.ToolMaxRetryBackoff
+	toolExecCfg.RetryJitter = runOpts.ToolRetryJitter
+	toolExecCfg.RetryOn = runOpts.ToolRetryOn
+	toolExecCfg.IdempotentOnly = true
+	toolExecCfg.IdempotentTools = runOpts.IdempotentTools
 	toolExec := NewToolExecutor(r.tools, toolExecCfg)
 
+	// Calls started before a turn ends are cancelled if the run returns
+	// without collecting them.
+	earlyCtx, cancelEarly := context.WithCancel(ctx)
+	defer cancelEarly()
+
 	// 8) Agentic loop
 	maxIters := runOpts.MaxIterations
 	if maxIters <= 0 {
@@ -839,6 +717,32 @@ func (r *Runtime) run(ctx context.Context, session *models.Session, msg *models.
 		maxToolCalls = 0
 	}
 	totalToolCalls := 0
+	schemaRepairs := make(map[string]int)
+	loops := newLoopGuard(runOpts.LoopDetection)
+
+	// 8a) Checkpoint long runs so they can be resumed after a restart
+	checkpoint := RunCheckpoint{
+		RunID:     runID,
+		SessionID: session.ID,
+		AgentID:   session.AgentID,
+		BranchID:  branchID,
+		Message:   checkpointMessage(msg),
+		Model:     model,
+		System:    req.System,
+	}
+	if resume != nil {
+		checkpoint = *resume
+	}
+	// 8b) The plan tool keeps the run's plan in its context
+	plan := NewRunPlan(checkpoint.Plan, func(ctx context.Context, plan *models.Plan) {
+		emitter.PlanUpdated(ctx, plan)
+	})
+	ctx = WithRunPlan(ctx, plan)
+	checkpoints := newRunCheckpointer(runOpts.Checkpoints, r.opts.Logger, checkpoint, plan, resume != nil)
+	turns := 0
+	defer func() {
+		checkpoints.finish(ctx, req.Messages, turns, totalToolCalls, runErr)
+	}()
 
 	for iter := 0; iter < maxIters; iter++ {
 		select {
@@ -875,8 +779,53 @@ func (r *Runtime) run(ctx context.Context, session *models.Session, msg *models.
 		var toolCalls []models.ToolCall
 		var inputTokens int
 		var outputTokens int
+		var reasoningTokens int
+		var reasoning strings.Builder
+		var thinkTags thinkTagSplitter
+		chunksOut, _ := ctx.Value(chunksChanKey{}).(chan<- *ResponseChunk)
+		var early *earlyToolRunner
+		if runOpts.EarlyToolStart.active() {
+			early = newEarlyToolRunner(r, toolExec, emitter, toolExecCfg.Concurrency)
+		}
+		addReasoning := func(text string) {
+			if text == "" {
+				return
+			}
+			if reasoning.Len()+len(text) <= MaxResponseTextSize {
+				reasoning.WriteString(text)
+			}
+			if chunksOut != nil {
+				chunksOut <- &ResponseChunk{Thinking: text}
+			}
+		}
+		addText := func(text string) error {
+			if text == "" {
+				return nil
+			}
+			// Check size limit to prevent memory exhaustion
+			if textBuilder.Len()+len(text) > MaxResponseTextSize {
+				err := fmt.Errorf("response text exceeds maximum size of %d bytes", MaxResponseTextSize)
+				emitter.RunError(ctx, err, true)
+				return err
+			}
+			textBuilder.WriteString(text)
+			emitter.ModelDelta(ctx, text)
+			return nil
+		}
 
-		for chunk := range completion {
+	stream:
+		for {
+			var chunk *CompletionChunk
+			select {
+			case res := <-early.finished():
+				early.finish(ctx, res)
+				continue
+			case next, ok := <-completion:
+				if !ok {
+					break stream
+				}
+				chunk = next
+			}
 			if chunk == nil {
 				continue
 			}
@@ -884,31 +833,26 @@ func (r *Runtime) run(ctx context.Context, session *models.Session, msg *models.
 				emitter.RunError(ctx, chunk.Error, true)
 				return chunk.Error
 			}
-			if chunk.ThinkingStart || chunk.Thinking != "" || chunk.ThinkingEnd {
-				if chunks, ok := ctx.Value(chunksChanKey{}).(chan<- *ResponseChunk); ok {
-					if chunk.ThinkingStart {
-						chunks <- &ResponseChunk{ThinkingStart: true}
-					}
-					if chunk.Thinking != "" {
-						chunks <- &ResponseChunk{Thinking: chunk.Thinking}
-					}
-					if chunk.ThinkingEnd {
-						chunks <- &ResponseChunk{ThinkingEnd: true}
-					}
-				}
+			if chunk.ThinkingStart && chunksOut != nil {
+				chunksOut <- &ResponseChunk{ThinkingStart: true}
+			}
+			addReasoning(chunk.Thinking)
+			if chunk.ThinkingEnd && chunksOut != nil {
+				chunksOut <- &ResponseChunk{ThinkingEnd: true}
 			}
 			if chunk.Done {
 				inputTokens = chunk.InputTokens
 				outputTokens = chunk.OutputTokens
+				reasoningTokens = chunk.ReasoningTokens
 			}
 			if chunk.Text != "" {
-				// Check size limit to prevent memory exhaustion
-				if textBuilder.Len()+len(chunk.Text) > MaxResponseTextSize {
-					emitter.RunError(ctx, fmt.Errorf("response text exceeds maximum size of %d bytes", MaxResponseTextSize), true)
-					return fmt.Errorf("response text exceeds maximum size of %d bytes", MaxResponseTextSize)
+				// Models that reason inline in <think> tags must not have
+				// their reasoning reach the reply.
+				text, thinking := thinkTags.split(chunk.Text)
+				addReasoning(thinking)
+				if err := addText(text); err != nil {
+					return err
 				}
-				textBuilder.WriteString(chunk.Text)
-				emitter.ModelDelta(ctx, chunk.Text)
 			}
 			if chunk.ToolCall != nil {
 				// Check total tool call limit to prevent runaway loops
@@ -927,6 +871,13 @@ func (r *Runtime) run(ctx context.Context, session *models.Session, msg *models.
 				toolCalls = append(toolCalls, tc)
 				totalToolCalls++
 
+				// Stop before repeating a call the run has already made too
+				// often, and before it can start early
+				if err := loops.checkCall(tc); err != nil {
+					emitter.RunError(ctx, err, false)
+					return err
+				}
+
 				// Persist tool call event immediately (best-effort)
 				if r.toolEvents != nil {
 					if err := r.toolEvents.AddToolCall(ctx, session.ID, assistantMsgID, &tc); err != nil {
@@ -940,9 +891,12 @@ func (r *Runtime) run(ctx context.Context, session *models.Session, msg *models.
 						)
 					}
 				}
+				if early != nil && r.canStartEarly(ctx, runOpts, tc, session.AgentID, elevatedMode, resolver, toolPolicy) {
+					early.start(earlyCtx, len(toolCalls)-1, tc)
+				}
 			}
 			if chunk.Done {
-				break
+				break stream
 			}
 		}
 
@@ -951,7 +905,20 @@ func (r *Runtime) run(ctx context.Context, session *models.Session, msg *models.
 			return r.handleContextDone(ctx, emitter, wallTimeLimit)
 		}
 
-		emitter.ModelCompleted(ctx, r.provider.Name(), model, inputTokens, outputTokens)
+		text, thinking := thinkTags.flush()
+		addReasoning(thinking)
+		if err := addText(text); err != nil {
+			return err
+		}
+		if reasoning.Len() > 0 && reasoningTokens == 0 {
+			reasoningTokens = estimateReasoningTokens(reasoning.String(), outputTokens)
+		}
+		emitter.ModelCompleted(ctx, r.provider.Name(), model, inputTokens, outputTokens, reasoningTokens)
+		if reasoning.Len() > 0 {
+			emitter.ModelReasoning(ctx, model, reasoning.String(), reasoningTokens, runOpts.TraceReasoning)
+		}
+		early.wait(ctx)
+		turns++
 
 		// Persist assistant message
 		assistantMsg := &models.Message{
@@ -963,8 +930,15 @@ func (r *Runtime) run(ctx context.Context, session *models.Session, msg *models.
 			Direction: models.DirectionOutbound,
 			Content:   textBuilder.String(),
 			ToolCalls: toolCalls,
+			Metadata:  usageMetadata(r.provider.Name(), model, inputTokens, outputTokens, reasoningTokens),
 			CreatedAt: time.Now(),
 		}
+		if current := plan.Current(); current != nil {
+			if assistantMsg.Metadata == nil {
+				assistantMsg.Metadata = map[string]any{}
+			}
+			assistantMsg.Metadata["plan"] = current
+		}
 		if err := appendMessage(assistantMsg); err != nil {
 			wrappedErr := fmt.Errorf("failed to persist assistant message: %w", err)
 			emitter.RunError(ctx, wrappedErr, false)
@@ -1008,6 +982,8 @@ func (r *Runtime) run(ctx context.Context, session *models.Session, msg *models.
 			return nil
 		}
 
+		checkpoints.maybeSave(ctx, req.Messages, turns, totalToolCalls)
+
 		// Policy-filter tools BEFORE executor runs
 		results := make([]models.ToolResult, len(toolCalls))
 		denied := make([]bool, len(toolCalls))
@@ -1021,6 +997,13 @@ func (r *Runtime) run(ctx context.Context, session *models.Session, msg *models.
 		for i := range toolCalls {
 			tc := toolCalls[i]
 
+			// Calls that started early passed these checks when they started
+			if res, ok := early.result(i); ok {
+				results[i] = res.Result
+				persistToolResult(tc, res.Result, assistantMsgID)
+				continue
+			}
+
 			// Check policy denial first
 			if resolver != nil && toolPolicy != nil && !resolver.IsAllowed(toolPolicy, tc.Name) {
 				denied[i] = true
@@ -1039,6 +1022,19 @@ func (r *Runtime) run(ctx context.Context, session *models.Session, msg *models.
 				continue
 			}
 
+			// Validate arguments against the tool schema before asking for approval
+			if content, blocked := r.checkToolSchema(runOpts, tc, model, schemaRepairs, resolver); blocked {
+				res := models.ToolResult{
+					ToolCallID: tc.ID,
+					Content:    content,
+					IsError:    true,
+				}
+				results[i] = res
+				emitter.ToolFinished(ctx, tc.ID, tc.Name, false, []byte(res.Content), 0)
+				persistToolResult(tc, res, assistantMsgID)
+				continue
+			}
+
 			// Check approvals (policy-based or compatibility require_approval)
 			if approvalChecker != nil {
 				decision, reason := approvalChecker.Check(ctx, session.AgentID, tc)
@@ -1061,7 +1057,8 @@ func (r *Runtime) run(ctx context.Context, session *models.Session, msg *models.
 					continue
 				case ApprovalPending:
 					var approvalID string
-					if req, err := approvalChecker.CreateApprovalRequest(ctx, session.AgentID, session.ID, tc, reason); err == nil && req != nil {
+					diff := previewToolChange(ctx, r.tools, tc, r.opts.Logger)
+					if req, err := approvalChecker.CreateApprovalRequestWithDiff(ctx, session.AgentID, session.ID, tc, reason, diff); err == nil && req != nil {
 						approvalID = req.ID
 					}
 					content := "approval required for tool: " + tc.Name
@@ -1084,6 +1081,7 @@ func (r *Runtime) run(ctx context.Context, session *models.Session, msg *models.
 							Stage:        models.ToolEventApprovalRequired,
 							Input:        tc.Input,
 							PolicyReason: reason,
+							Diff:         diff,
 							FinishedAt:   time.Now(),
 						}, runOpts.DisableToolEvents)
 						// Also send the tool result
@@ -1112,6 +1110,7 @@ func (r *Runtime) run(ctx context.Context, session *models.Session, msg *models.
 							ToolName:   tc.Name,
 							Stage:      models.ToolEventApprovalRequired,
 							Input:      tc.Input,
+							Diff:       previewToolChange(ctx, r.tools, tc, r.opts.Logger),
 							FinishedAt: time.Now(),
 						}, runOpts.DisableToolEvents)
 						// Also send the tool result
@@ -1215,7 +1214,7 @@ func (r *Runtime) run(ctx context.Context, session *models.Session, msg *models.
 			}
 		}
 
-		persistResults := guardToolResults(runOpts.ToolResultGuard, toolCalls, results, resolver)
+		persistResults := guardToolResults(ctx, runOpts.ToolResultGuard, toolCalls, results, resolver)
 		// Persist tool message without inline attachments to avoid bloating storage.
 		resultsForStorage := make([]models.ToolResult, len(persistResults))
 		for i := range persistResults {
@@ -1243,6 +1242,11 @@ func (r *Runtime) run(ctx context.Context, session *models.Session, msg *models.
 			Role:        "tool",
 			ToolResults: results,
 		})
+		checkpoints.maybeSave(ctx, req.Messages, turns, totalToolCalls)
+		if err := loops.recordResults(toolCalls, results); err != nil {
+			emitter.RunError(ctx, err, false)
+			return err
+		}
 
 		// 8a) Check for steering messages after tool execution
 		if steeringQueue != nil {
@@ -1281,6 +1285,236 @@ func (r *Runtime) run(ctx context.Context, session *models.Session, msg *models.
 	return maxIterErr
 }
 
+// preparedRun is the conversation a run starts from.
+type preparedRun struct {
+	branchID string
+	system   string
+	messages []CompletionMessage
+}
+
+// prepareRun persists the inbound message and builds the conversation for a
+// new run from the session history: summarization, context packing, and
+// system prompt composition.
+func (r *Runtime) prepareRun(ctx context.Context, session *models.Session, msg *models.Message, model string, emitter *EventEmitter) (*preparedRun, error) {
+	// 1) Load history (pre-incoming message)
+	branchID := strings.TrimSpace(msg.BranchID)
+	if r.branchStore != nil {
+		if branchID == "" {
+			branch, branchErr := sessions.ActiveBranch(ctx, r.branchStore, session)
+			if branchErr != nil {
+				emitter.RunError(ctx, branchErr, false)
+				return nil, branchErr
+			}
+			branchID = branch.ID
+		}
+		msg.BranchID = branchID
+	}
+
+	var (
+		history []*models.Message
+		err     error
+	)
+	if r.branchStore != nil && branchID != "" {
+		history, err = r.branchStore.GetBranchHistory(ctx, branchID, 50)
+	} else {
+		history, err = r.sessions.GetHistory(ctx, session.ID, 50)
+	}
+	if err != nil {
+		emitter.RunError(ctx, err, false)
+		return nil, err
+	}
+	history = repairTranscript(history)
+
+	appendMessage := func(message *models.Message) error {
+		return r.appendRunMessage(ctx, session.ID, branchID, message)
+	}
+
+	// 2) Persist inbound user message (source of truth)
+	if msg.ID == "" {
+		msg.ID = uuid.NewString()
+	}
+	if msg.SessionID == "" {
+		msg.SessionID = session.ID
+	}
+	if msg.CreatedAt.IsZero() {
+		msg.CreatedAt = time.Now()
+	}
+	if msg.Direction == "" {
+		msg.Direction = models.DirectionInbound
+	}
+	if err := appendMessage(msg); err != nil {
+		wrappedErr := fmt.Errorf("failed to persist user message: %w", err)
+		emitter.RunError(ctx, wrappedErr, false)
+		return nil, wrappedErr
+	}
+
+	// 3) Optional summarization
+	var summaryMsg *models.Message
+	if r.summarizeConfig != nil {
+		summaryMsg = agentctx.FindLatestSummary(history)
+
+		cfg := *r.summarizeConfig
+		summaryProvider := &llmSummaryProvider{runtime: r}
+		summarizer := agentctx.NewSummarizer(summaryProvider, cfg)
+
+		if summarizer.ShouldSummarize(history, summaryMsg) {
+			newSummary, sumErr := summarizer.Summarize(ctx, session.ID, history, summaryMsg)
+			if sumErr != nil {
+				emitter.RunError(ctx, sumErr, false)
+				return nil, sumErr
+			}
+			if newSummary != nil {
+				if newSummary.ID == "" {
+					newSummary.ID = uuid.NewString()
+				}
+				if newSummary.SessionID == "" {
+					newSummary.SessionID = session.ID
+				}
+				if newSummary.CreatedAt.IsZero() {
+					newSummary.CreatedAt = time.Now()
+				}
+				if err := appendMessage(newSummary); err != nil {
+					wrappedErr := fmt.Errorf("failed to persist summary message: %w", err)
+					emitter.RunError(ctx, wrappedErr, false)
+					return nil, wrappedErr
+				}
+				summaryMsg = newSummary
+			}
+		}
+	} else {
+		summaryMsg = agentctx.FindLatestSummary(history)
+	}
+
+	// 4) Context packing
+	packOpts := agentctx.DefaultPackOptions()
+	if r.packOpts != nil {
+		packOpts = *r.packOpts
+	}
+	if settings := r.contextPruningSettings(); settings != nil && settings.Mode == agentctx.ContextPruningCacheTTL {
+		if isCacheTTLEligibleProvider(r.provider.Name(), model) {
+			now := time.Now()
+			lastTouch, ok := r.cacheTouchAt(session.ID)
+			if !ok {
+				if stored, storedOK := cacheTouchFromSession(session); storedOK {
+					lastTouch = stored
+					ok = true
+					r.setCacheTouchAt(session.ID, stored)
+				}
+			}
+			if ok && settings.TTL > 0 && now.Sub(lastTouch) >= settings.TTL {
+				charWindow := contextPruningCharWindow(model, packOpts)
+				if charWindow > 0 {
+					history = agentctx.PruneContextMessages(history, *settings, charWindow)
+				}
+			}
+			r.setCacheTouchAt(session.ID, now)
+			r.persistCacheTouch(ctx, session, now)
+		}
+	}
+	packer := agentctx.NewPacker(packOpts)
+
+	packResult := packer.PackWithQuotes(history, quotesFromContext(ctx), msg, summaryMsg)
+	packedModels := packResult.Messages
+
+	// Emit context packed event with diagnostics
+	emitter.ContextPacked(ctx, packResult.Diagnostics)
+
+	// 5) System prompt composition
+	var systemParts []string
+	if system, ok := systemPromptFromContext(ctx); ok {
+		systemParts = append(systemParts, system)
+	} else if r.defaultSystem != "" {
+		systemParts = append(systemParts, r.defaultSystem)
+	}
+
+	nonSystemPacked := make([]*models.Message, 0, len(packedModels))
+	for _, m := range packedModels {
+		if m == nil {
+			continue
+		}
+		if m.Role == models.RoleSystem {
+			if strings.TrimSpace(m.Content) != "" {
+				systemParts = append(systemParts, m.Content)
+			}
+			continue
+		}
+		nonSystemPacked = append(nonSystemPacked, m)
+	}
+
+	messages, err := r.buildCompletionMessages(nonSystemPacked)
+	if err != nil {
+		emitter.RunError(ctx, err, false)
+		return nil, err
+	}
+
+	// 5a) Apply context transform if configured
+	if transform := ContextTransformFromContext(ctx); transform != nil {
+		messages, err = transform(ctx, messages)
+		if err != nil {
+			emitter.RunError(ctx, fmt.Errorf("context transform failed: %w", err), false)
+			return nil, err
+		}
+	}
+
+	return &preparedRun{
+		branchID: branchID,
+		system:   strings.Join(systemParts, "\n\n"),
+		messages: messages,
+	}, nil
+}
+
+// appendRunMessage persists a message of a run to its branch, or to the
+// session when branches are not in use.
+func (r *Runtime) appendRunMessage(ctx context.Context, sessionID, branchID string, message *models.Message) error {
+	if message == nil {
+		return nil
+	}
+	if r.branchStore != nil && branchID != "" {
+		message.BranchID = branchID
+		return r.branchStore.AppendMessageToBranch(ctx, sessionID, branchID, message)
+	}
+	return r.sessions.AppendMessage(ctx, sessionID, message)
+}
+
+// checkpointMessage copies the inbound message of a run for its checkpoint,
+// without attachments.
+func checkpointMessage(msg *models.Message) *models.Message {
+	if msg == nil {
+		return nil
+	}
+	snapshot := *msg
+	snapshot.Attachments = nil
+	if msg.Metadata != nil {
+		snapshot.Metadata = make(map[string]any, len(msg.Metadata))
+		for key, value := range msg.Metadata {
+			snapshot.Metadata[key] = value
+		}
+	}
+	return &snapshot
+}
+
+// usageMetadata records the model and token usage of an assistant message so
+// transcripts and exports can report per-session usage.
+func usageMetadata(provider, model string, inputTokens, outputTokens, reasoningTokens int) map[string]any {
+	if model == "" && inputTokens == 0 && outputTokens == 0 {
+		return nil
+	}
+	meta := map[string]any{
+		"input_tokens":  inputTokens,
+		"output_tokens": outputTokens,
+	}
+	if reasoningTokens > 0 {
+		meta["reasoning_tokens"] = reasoningTokens
+	}
+	if provider != "" {
+		meta["provider"] = provider
+	}
+	if model != "" {
+		meta["model"] = model
+	}
+	return meta
+}
+
 // handleContextDone emits the appropriate event for context cancellation.
 // It distinguishes between explicit cancellation and wall time timeout.
 func (r *Runtime) handleContextDone(ctx context.Context, emitter *EventEmitter, wallTimeLimit time.Duration) error {
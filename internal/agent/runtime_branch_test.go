@@ -47,3 +47,41 @@ func TestRuntimePersistsToBranchStore(t *testing.T) {
 		t.Fatalf("expected BranchID %q, got %q", branch.ID, history[0].BranchID)
 	}
 }
+
+func TestRuntimeFollowsActiveBranch(t *testing.T) {
+	sessionStore := sessions.NewMemoryStore()
+	branchStore := sessions.NewMemoryBranchStore()
+
+	runtime := NewRuntime(stubProvider{}, sessionStore)
+	runtime.SetBranchStore(branchStore)
+
+	ctx := context.Background()
+	session := &models.Session{ID: "session-1", AgentID: "agent-1", Channel: models.ChannelAPI, ChannelID: "channel-1"}
+	primary, err := branchStore.EnsurePrimaryBranch(ctx, session.ID)
+	if err != nil {
+		t.Fatalf("EnsurePrimaryBranch error: %v", err)
+	}
+	alt, err := sessions.ForkBranchHead(ctx, branchStore, primary, "alt")
+	if err != nil {
+		t.Fatalf("ForkBranchHead error: %v", err)
+	}
+	sessions.SetActiveBranch(session, alt)
+
+	chunks, err := runtime.Process(ctx, session, &models.Message{Role: models.RoleUser, Content: "hello"})
+	if err != nil {
+		t.Fatalf("Process error: %v", err)
+	}
+	for range chunks {
+	}
+
+	own, err := branchStore.GetBranchOwnMessages(ctx, alt.ID, 10)
+	if err != nil {
+		t.Fatalf("GetBranchOwnMessages error: %v", err)
+	}
+	if len(own) == 0 || own[0].Content != "hello" {
+		t.Fatalf("expected the message on the active branch, got %d messages", len(own))
+	}
+	if onPrimary, _ := branchStore.GetBranchOwnMessages(ctx, primary.ID, 10); len(onPrimary) != 0 {
+		t.Fatalf("expected no messages on the primary branch, got %d", len(onPrimary))
+	}
+}
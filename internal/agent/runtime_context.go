@@ -14,6 +14,8 @@ type sessionKey struct{}
 type runtimeOptsKey struct{}
 type elevatedKey struct{}
 type modelKey struct{}
+type traceTagsKey struct{}
+type quotesKey struct{}
 
 const contextPruningCacheTouchKey = "context_pruning_cache_ttl_at"
 
@@ -131,6 +133,49 @@ func modelFromContext(ctx context.Context) (string, bool) {
 	return value, true
 }
 
+// WithQuotes stores quotes for the incoming message in the context: system
+// messages carrying the replied-to or quoted messages, packed right before
+// the incoming message.
+func WithQuotes(ctx context.Context, quotes []*models.Message) context.Context {
+	if len(quotes) == 0 {
+		return ctx
+	}
+	return context.WithValue(ctx, quotesKey{}, quotes)
+}
+
+func quotesFromContext(ctx context.Context) []*models.Message {
+	quotes, _ := ctx.Value(quotesKey{}).([]*models.Message)
+	return quotes
+}
+
+// WithTraceTags adds request-scoped tags, such as experiment variant IDs, that
+// trace plugins attach to the run. Tags already on ctx are kept unless
+// overridden.
+func WithTraceTags(ctx context.Context, tags map[string]string) context.Context {
+	if len(tags) == 0 {
+		return ctx
+	}
+	merged := make(map[string]string, len(tags))
+	for k, v := range TraceTagsFromContext(ctx) {
+		merged[k] = v
+	}
+	for k, v := range tags {
+		if k = strings.TrimSpace(k); k != "" {
+			merged[k] = v
+		}
+	}
+	return context.WithValue(ctx, traceTagsKey{}, merged)
+}
+
+// TraceTagsFromContext returns the trace tags stored in ctx.
+func TraceTagsFromContext(ctx context.Context) map[string]string {
+	if ctx == nil {
+		return nil
+	}
+	tags, _ := ctx.Value(traceTagsKey{}).(map[string]string)
+	return tags
+}
+
 type toolPolicyKey struct{}
 type toolResolverKey struct{}
 
@@ -32,11 +32,13 @@ func (stubProvider) Models() []Model { return nil }
 func (stubProvider) SupportsTools() bool { return false }
 
 type recordingProvider struct {
-	lastModel  string
-	lastSystem string
+	lastModel   string
+	lastSystem  string
+	lastRequest *CompletionRequest
 }
 
 func (p *recordingProvider) Complete(ctx context.Context, req *CompletionRequest) (<-chan *CompletionChunk, error) {
+	p.lastRequest = req
 	p.lastModel = req.Model
 	p.lastSystem = req.System
 	ch := make(chan *CompletionChunk, 1)
@@ -399,6 +401,8 @@ func (f *flakyTool) Description() string { return "fails once" }
 
 func (f *flakyTool) Schema() json.RawMessage { return json.RawMessage(`{"type":"object"}`) }
 
+func (f *flakyTool) Idempotent() bool { return true }
+
 func (f *flakyTool) Execute(ctx context.Context, params json.RawMessage) (*ToolResult, error) {
 	call := atomic.AddInt32(&f.calls, 1)
 	if call == 1 {
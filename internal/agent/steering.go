@@ -4,6 +4,7 @@ package agent
 
 import (
 	"context"
+	"strings"
 	"sync"
 
 	"github.com/haasonsaas/nexus/pkg/models"
@@ -349,6 +350,33 @@ func ThinkingLevelFromContext(ctx context.Context) ThinkingLevel {
 	return level
 }
 
+// ParseThinkingLevel normalizes a thinking level name such as "medium".
+func ParseThinkingLevel(value string) (ThinkingLevel, bool) {
+	level := ThinkingLevel(strings.ToLower(strings.TrimSpace(value)))
+	if _, ok := ThinkingBudgets[level]; !ok {
+		return ThinkingOff, false
+	}
+	return level, true
+}
+
+// thinkingBudgetKey is used to store an explicit thinking budget in context.
+type thinkingBudgetKey struct{}
+
+// WithThinkingBudget stores an explicit thinking budget in tokens, such as
+// one set with /think. It takes precedence over the thinking level.
+func WithThinkingBudget(ctx context.Context, tokens int) context.Context {
+	if tokens <= 0 {
+		return ctx
+	}
+	return context.WithValue(ctx, thinkingBudgetKey{}, tokens)
+}
+
+// ThinkingBudgetFromContext retrieves an explicit thinking budget from context.
+func ThinkingBudgetFromContext(ctx context.Context) int {
+	tokens, _ := ctx.Value(thinkingBudgetKey{}).(int)
+	return tokens
+}
+
 // SkippedToolResult returns a tool result for a skipped tool call.
 // Used when steering interrupts remaining tool calls.
 func SkippedToolResult(toolCallID string, reason string) *models.ToolResult {
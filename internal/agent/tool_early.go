@@ -0,0 +1,166 @@
+package agent
+
+import (
+	"context"
+	"time"
+
+	"github.com/haasonsaas/nexus/internal/tools/policy"
+	"github.com/haasonsaas/nexus/pkg/models"
+)
+
+// EarlyToolStart controls running tool calls while the model is still
+// streaming the rest of its turn. Providers emit a tool call once its
+// arguments are complete (Anthropic at the end of the tool_use block, OpenAI
+// when the next call begins), so in a multi-tool turn the first calls can
+// run while later ones are still being generated.
+type EarlyToolStart struct {
+	// Enabled turns early starts on.
+	Enabled bool
+
+	// Tools limits early starts to these tool patterns. Empty allows every
+	// tool that would run without approval. Calls that need approval,
+	// schema repair, or async execution always wait for the turn to end.
+	Tools []string
+}
+
+func (s EarlyToolStart) active() bool {
+	return s.Enabled
+}
+
+// canStartEarly reports whether tc would run as-is once the turn ends: it is
+// allowed by policy, passes schema validation, needs no approval, and is not
+// an async job. Only such calls are started before the turn ends.
+func (r *Runtime) canStartEarly(ctx context.Context, opts RuntimeOptions, tc models.ToolCall, agentID string, elevatedMode ElevatedMode, resolver *policy.Resolver, toolPolicy *policy.Policy) bool {
+	if len(opts.EarlyToolStart.Tools) > 0 && !matchesToolPatterns(opts.EarlyToolStart.Tools, tc.Name, resolver) {
+		return false
+	}
+	if resolver != nil && toolPolicy != nil && !resolver.IsAllowed(toolPolicy, tc.Name) {
+		return false
+	}
+	if opts.ToolSchemaValidation.active() && r.tools != nil {
+		strict := matchesToolPatterns(opts.ToolSchemaValidation.Strict, tc.Name, resolver)
+		if len(r.tools.ValidateInput(tc.Name, tc.Input, strict)) > 0 {
+			return false
+		}
+	}
+	elevated := elevatedMode == ElevatedFull && matchesToolPatterns(opts.ElevatedTools, tc.Name, resolver)
+	if opts.ApprovalChecker != nil {
+		decision, _ := opts.ApprovalChecker.Check(ctx, agentID, tc)
+		if decision == ApprovalPending && elevated {
+			decision = ApprovalAllowed
+		}
+		if decision != ApprovalAllowed {
+			return false
+		}
+	} else if r.requiresApproval(opts, tc.Name, resolver) && !elevated {
+		return false
+	}
+	return !(r.isAsyncTool(opts, tc.Name, resolver) && opts.JobStore != nil)
+}
+
+// earlyToolRunner executes tool calls of the current model turn while the
+// turn is still streaming. Calls run in the background; their tool.started
+// and tool.finished events are emitted from the run's goroutine, between
+// stream chunks, so events stay ordered.
+type earlyToolRunner struct {
+	r       *Runtime
+	exec    *ToolExecutor
+	emitter *EventEmitter
+	limit   int
+
+	done    chan ToolExecResult
+	started map[int]time.Time
+	results map[int]ToolExecResult
+	running int
+}
+
+// newEarlyToolRunner creates a runner for one model turn that runs at most
+// limit calls at once; further calls wait for the turn to end.
+func newEarlyToolRunner(r *Runtime, exec *ToolExecutor, emitter *EventEmitter, limit int) *earlyToolRunner {
+	if limit <= 0 {
+		limit = 1
+	}
+	return &earlyToolRunner{
+		r:       r,
+		exec:    exec,
+		emitter: emitter,
+		limit:   limit,
+		done:    make(chan ToolExecResult, MaxToolCallsPerIteration),
+		started: make(map[int]time.Time),
+		results: make(map[int]ToolExecResult),
+	}
+}
+
+// start runs the turn's index-th tool call in the background. It returns
+// false when the runner is at its limit.
+func (e *earlyToolRunner) start(ctx context.Context, index int, tc models.ToolCall) bool {
+	if e == nil || e.running >= e.limit {
+		return false
+	}
+	if _, ok := e.started[index]; ok {
+		return false
+	}
+	e.emitter.ToolStarted(ctx, tc.ID, tc.Name, tc.Input)
+	e.started[index] = time.Now()
+	e.running++
+	go func() {
+		results := e.exec.ExecuteConcurrentlyWithOverrides(ctx, []models.ToolCall{tc}, nil, func(call models.ToolCall) ToolExecConfig {
+			return e.r.toolExecOverrides(call.Name)
+		})
+		res := ToolExecResult{
+			ToolCall: tc,
+			Result: models.ToolResult{
+				ToolCallID: tc.ID,
+				Content:    "tool returned no result",
+				IsError:    true,
+			},
+		}
+		if len(results) > 0 {
+			res = results[0]
+		}
+		res.Index = index
+		e.done <- res
+	}()
+	return true
+}
+
+// finished delivers results of background calls. It is nil, and so never
+// ready, when early starts are disabled.
+func (e *earlyToolRunner) finished() <-chan ToolExecResult {
+	if e == nil {
+		return nil
+	}
+	return e.done
+}
+
+// finish records a background call's result and emits its final event.
+func (e *earlyToolRunner) finish(ctx context.Context, res ToolExecResult) {
+	e.running--
+	e.results[res.Index] = res
+	tc := res.ToolCall
+	elapsed := time.Since(e.started[res.Index])
+	if res.TimedOut {
+		e.emitter.ToolTimedOut(ctx, tc.ID, tc.Name, elapsed)
+	} else {
+		e.emitter.ToolFinished(ctx, tc.ID, tc.Name, !res.Result.IsError, []byte(res.Result.Content), elapsed)
+	}
+}
+
+// wait collects every call still running.
+func (e *earlyToolRunner) wait(ctx context.Context) {
+	if e == nil {
+		return
+	}
+	for e.running > 0 {
+		e.finish(ctx, <-e.done)
+	}
+}
+
+// result returns the result of the turn's index-th call if it ran early.
+func (e *earlyToolRunner) result(index int) (ToolExecResult, bool) {
+	if e == nil {
+		return ToolExecResult{}, false
+	}
+	res, ok := e.results[index]
+	return res, ok
+}
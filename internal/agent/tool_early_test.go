@@ -0,0 +1,139 @@
+package agent
+
+import (
+	"context"
+	"encoding/json"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/haasonsaas/nexus/internal/jobs"
+	"github.com/haasonsaas/nexus/pkg/models"
+)
+
+// earlyStartProvider streams a tool call, then holds the turn open until the
+// tool has started (or a timeout), recording whether it started first.
+type earlyStartProvider struct {
+	toolStarted chan struct{}
+	calls       int32
+	startedMid  atomic.Bool
+}
+
+func (p *earlyStartProvider) Complete(ctx context.Context, req *CompletionRequest) (<-chan *CompletionChunk, error) {
+	call := atomic.AddInt32(&p.calls, 1)
+	ch := make(chan *CompletionChunk, 2)
+	go func() {
+		defer close(ch)
+		if call > 1 {
+			ch <- &CompletionChunk{Text: "done"}
+			ch <- &CompletionChunk{Done: true}
+			return
+		}
+		ch <- &CompletionChunk{ToolCall: &models.ToolCall{ID: "call-1", Name: "signal", Input: json.RawMessage(`{}`)}}
+		select {
+		case <-p.toolStarted:
+			p.startedMid.Store(true)
+		case <-time.After(time.Second):
+		}
+		ch <- &CompletionChunk{Text: "still streaming"}
+		ch <- &CompletionChunk{Done: true}
+	}()
+	return ch, nil
+}
+
+func (p *earlyStartProvider) Name() string { return "early-start" }
+
+func (p *earlyStartProvider) Models() []Model { return nil }
+
+func (p *earlyStartProvider) SupportsTools() bool { return true }
+
+type signalTool struct {
+	started chan struct{}
+}
+
+func (t *signalTool) Name() string { return "signal" }
+
+func (t *signalTool) Description() string { return "signals when it runs" }
+
+func (t *signalTool) Schema() json.RawMessage { return json.RawMessage(`{"type":"object"}`) }
+
+func (t *signalTool) Execute(ctx context.Context, params json.RawMessage) (*ToolResult, error) {
+	close(t.started)
+	return &ToolResult{Content: "ok"}, nil
+}
+
+func TestProcessStartsToolCallsEarly(t *testing.T) {
+	started := make(chan struct{})
+	provider := &earlyStartProvider{toolStarted: started}
+	runtime := NewRuntimeWithOptions(provider, stubStore{}, RuntimeOptions{
+		MaxIterations:   2,
+		ToolParallelism: 2,
+		EarlyToolStart:  EarlyToolStart{Enabled: true},
+	})
+	runtime.RegisterTool(&signalTool{started: started})
+
+	session := &models.Session{ID: "session-1", Channel: models.ChannelTelegram}
+	msg := &models.Message{ID: "msg-1", Role: models.RoleUser, Content: "hi"}
+	events, err := runtime.ProcessStream(context.Background(), session, msg)
+	if err != nil {
+		t.Fatalf("ProcessStream() error = %v", err)
+	}
+
+	var stats *models.RunStats
+	var finished *models.ToolEventPayload
+	for event := range events {
+		switch event.Type {
+		case models.AgentEventToolFinished:
+			finished = event.Tool
+		case models.AgentEventRunFinished:
+			if event.Stats != nil {
+				stats = event.Stats.Run
+			}
+		}
+	}
+
+	if !provider.startedMid.Load() {
+		t.Fatal("tool did not start before the model turn ended")
+	}
+	if finished == nil || !finished.Success || string(finished.ResultJSON) != "ok" {
+		t.Fatalf("tool.finished = %+v", finished)
+	}
+	if stats == nil || stats.EarlyToolStarts != 1 || stats.ToolOverlapTime <= 0 {
+		t.Fatalf("stats = %+v, want one early start with overlap", stats)
+	}
+	if stats.ToolCalls != 1 {
+		t.Fatalf("tool calls = %d, want 1 (not re-run after the turn)", stats.ToolCalls)
+	}
+}
+
+func TestCanStartEarly(t *testing.T) {
+	runtime := NewRuntimeWithOptions(stubProvider{}, stubStore{}, RuntimeOptions{})
+	runtime.RegisterTool(&testTool{name: "read_file"})
+	runtime.RegisterTool(&testTool{name: "exec"})
+	call := func(name string) models.ToolCall {
+		return models.ToolCall{ID: "call-1", Name: name, Input: json.RawMessage(`{}`)}
+	}
+	ctx := context.Background()
+
+	tests := []struct {
+		name string
+		opts RuntimeOptions
+		tool string
+		want bool
+	}{
+		{"allowed", RuntimeOptions{}, "read_file", true},
+		{"limited to other tools", RuntimeOptions{EarlyToolStart: EarlyToolStart{Tools: []string{"read_file"}}}, "exec", false},
+		{"limited to this tool", RuntimeOptions{EarlyToolStart: EarlyToolStart{Tools: []string{"read_file"}}}, "read_file", true},
+		{"requires approval", RuntimeOptions{RequireApproval: []string{"exec"}}, "exec", false},
+		{"approval pending", RuntimeOptions{ApprovalChecker: NewApprovalChecker(&ApprovalPolicy{RequireApproval: []string{"exec"}, AskFallback: true})}, "exec", false},
+		{"approval allowed", RuntimeOptions{ApprovalChecker: NewApprovalChecker(&ApprovalPolicy{Allowlist: []string{"exec"}})}, "exec", true},
+		{"async", RuntimeOptions{AsyncTools: []string{"exec"}, JobStore: jobs.NewMemoryStore()}, "exec", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := runtime.canStartEarly(ctx, tt.opts, call(tt.tool), "main", "", nil, nil); got != tt.want {
+				t.Fatalf("canStartEarly() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"github.com/haasonsaas/nexus/internal/observability"
+	"github.com/haasonsaas/nexus/internal/supervisor"
 	"github.com/haasonsaas/nexus/pkg/models"
 )
 
@@ -29,6 +30,23 @@ type ToolExecConfig struct {
 
 	// RetryBackoff waits between retries.
 	RetryBackoff time.Duration
+
+	// MaxRetryBackoff caps exponential backoff. When zero, every retry waits
+	// RetryBackoff.
+	MaxRetryBackoff time.Duration
+
+	// RetryJitter spreads each backoff by up to this fraction (0-1).
+	RetryJitter float64
+
+	// RetryOn limits retries to these error classes. Empty retries any error.
+	RetryOn []ToolErrorType
+
+	// IdempotentOnly retries only tools that declare themselves idempotent
+	// or match IdempotentTools.
+	IdempotentOnly bool
+
+	// IdempotentTools lists tool patterns treated as idempotent.
+	IdempotentTools []string
 }
 
 // DefaultToolExecConfig returns sensible defaults for tool execution with
@@ -53,6 +71,21 @@ func mergeToolExecConfig(base ToolExecConfig, override ToolExecConfig) ToolExecC
 	if override.RetryBackoff > 0 {
 		merged.RetryBackoff = override.RetryBackoff
 	}
+	if override.MaxRetryBackoff > 0 {
+		merged.MaxRetryBackoff = override.MaxRetryBackoff
+	}
+	if override.RetryJitter > 0 {
+		merged.RetryJitter = override.RetryJitter
+	}
+	if len(override.RetryOn) > 0 {
+		merged.RetryOn = override.RetryOn
+	}
+	if override.IdempotentOnly {
+		merged.IdempotentOnly = true
+	}
+	if len(override.IdempotentTools) > 0 {
+		merged.IdempotentTools = override.IdempotentTools
+	}
 	return merged
 }
 
@@ -88,6 +121,7 @@ type ToolExecResult struct {
 	StartTime time.Time
 	EndTime   time.Time
 	TimedOut  bool
+	Attempts  int
 }
 
 // EventCallback is a non-blocking callback invoked for tool lifecycle events during execution.
@@ -146,7 +180,9 @@ func (e *ToolExecutor) ExecuteConcurrentlyWithOverrides(
 					maxAttempts = 1
 				}
 
+				attempts := 0
 				for attempt := 1; attempt <= maxAttempts; attempt++ {
+					attempts = attempt
 					// Emit tool_started event
 					if emit != nil {
 						emit(models.NewToolEvent(models.EventToolStarted, call.Name, call.ID).
@@ -159,36 +195,31 @@ func (e *ToolExecutor) ExecuteConcurrentlyWithOverrides(
 					result, timedOut = e.executeWithTimeout(toolCtx, call, cfg.PerToolTimeout)
 					cancel()
 
-					if !result.IsError {
+					if !result.IsError || attempt == maxAttempts || ctx.Err() != nil {
 						break
 					}
-
-					if attempt < maxAttempts {
-						if emit != nil {
-							eventType := models.EventToolFailed
-							if timedOut {
-								eventType = models.EventToolTimeout
-							}
-							emit(models.NewToolEvent(eventType, call.Name, call.ID).
-								WithMeta("attempt", attempt).
-								WithMeta("retrying", true))
+					class := toolResultErrorType(result, timedOut)
+					delay, ok := e.prepareRetry(ctx, cfg, call, attempt, class)
+					if !ok {
+						break
+					}
+					if emit != nil {
+						eventType := models.EventToolFailed
+						if timedOut {
+							eventType = models.EventToolTimeout
 						}
-						if cfg.RetryBackoff > 0 {
-							canceled := false
-							select {
-							case <-time.After(cfg.RetryBackoff):
-							case <-ctx.Done():
-								result = models.ToolResult{
-									ToolCallID: call.ID,
-									Content:    "tool execution canceled",
-									IsError:    true,
-								}
-								canceled = true
-							}
-							if canceled {
-								break
-							}
+						emit(models.NewToolEvent(eventType, call.Name, call.ID).
+							WithMeta("attempt", attempt).
+							WithMeta("retrying", true).
+							WithMeta("error_class", string(class)))
+					}
+					if !sleepRetry(ctx, delay) {
+						result = models.ToolResult{
+							ToolCallID: call.ID,
+							Content:    "tool execution canceled",
+							IsError:    true,
 						}
+						break
 					}
 				}
 
@@ -201,6 +232,7 @@ func (e *ToolExecutor) ExecuteConcurrentlyWithOverrides(
 					StartTime: startTime,
 					EndTime:   endTime,
 					TimedOut:  timedOut,
+					Attempts:  attempts,
 				}
 
 				// Emit completion event
@@ -245,10 +277,15 @@ func (e *ToolExecutor) executeWithTimeout(ctx context.Context, call models.ToolC
 	resultChan := make(chan execResult, 1)
 
 	go func() {
-		result, err := e.registry.Execute(ctx, call.Name, call.Input)
+		var res execResult
+		res.err = supervisor.Catch("tool:"+call.Name, func() error {
+			var err error
+			res.result, err = e.registry.Execute(ctx, call.Name, call.Input)
+			return err
+		})
 		// Use non-blocking send to prevent goroutine leak if context is already done
 		select {
-		case resultChan <- execResult{result: result, err: err}:
+		case resultChan <- res:
 		default:
 			// Context cancelled/timed out before execution completed - log for observability
 			runID := observability.GetRunID(ctx)
@@ -308,25 +345,27 @@ func (e *ToolExecutor) ExecuteSequentially(ctx context.Context, toolCalls []mode
 		}
 		var result models.ToolResult
 		var timedOut bool
+		attempts := 0
 		for attempt := 1; attempt <= maxAttempts; attempt++ {
+			attempts = attempt
 			toolCtx, cancel := context.WithTimeout(ctx, e.config.PerToolTimeout)
 			toolCtx = observability.AddToolCallID(toolCtx, tc.ID)
 			result, timedOut = e.executeWithTimeout(toolCtx, tc, e.config.PerToolTimeout)
 			cancel()
-			if !result.IsError {
+			if !result.IsError || attempt == maxAttempts || ctx.Err() != nil {
 				break
 			}
-			if attempt < maxAttempts && e.config.RetryBackoff > 0 {
-				select {
-				case <-time.After(e.config.RetryBackoff):
-				case <-ctx.Done():
-					result = models.ToolResult{
-						ToolCallID: tc.ID,
-						Content:    "tool execution canceled",
-						IsError:    true,
-					}
-					break
+			delay, ok := e.prepareRetry(ctx, e.config, tc, attempt, toolResultErrorType(result, timedOut))
+			if !ok {
+				break
+			}
+			if !sleepRetry(ctx, delay) {
+				result = models.ToolResult{
+					ToolCallID: tc.ID,
+					Content:    "tool execution canceled",
+					IsError:    true,
 				}
+				break
 			}
 		}
 		endTime := time.Now()
@@ -338,6 +377,7 @@ func (e *ToolExecutor) ExecuteSequentially(ctx context.Context, toolCalls []mode
 			StartTime: startTime,
 			EndTime:   endTime,
 			TimedOut:  timedOut,
+			Attempts:  attempts,
 		}
 	}
 
@@ -361,13 +401,45 @@ func (e *ToolExecutor) ExecuteSingle(ctx context.Context, name string, input jso
 			return result, nil
 		}
 		lastErr = err
-		if attempt < maxAttempts && e.config.RetryBackoff > 0 {
-			select {
-			case <-time.After(e.config.RetryBackoff):
-			case <-ctx.Done():
-				return nil, ctx.Err()
-			}
+		if attempt == maxAttempts {
+			break
+		}
+		delay, ok := e.prepareRetry(ctx, e.config, models.ToolCall{Name: name}, attempt, classifyToolError(err))
+		if !ok {
+			break
+		}
+		if !sleepRetry(ctx, delay) {
+			return nil, ctx.Err()
 		}
 	}
 	return nil, lastErr
 }
+
+// prepareRetry applies the retry policy to a failed attempt. It returns the
+// backoff before the next attempt, or ok=false when the call must not be
+// retried. Decisions are recorded as metrics and span events.
+func (e *ToolExecutor) prepareRetry(ctx context.Context, cfg ToolExecConfig, call models.ToolCall, attempt int, class ToolErrorType) (time.Duration, bool) {
+	metrics := observability.NewToolRetryMetrics()
+	if reason := e.retrySkipReason(cfg, call.Name, class); reason != "" {
+		metrics.RecordSkipped(call.Name, reason)
+		return 0, false
+	}
+	delay := retryDelay(cfg, attempt)
+	metrics.RecordRetry(ctx, call.Name, string(class), attempt, delay)
+	return delay, true
+}
+
+// sleepRetry waits out a retry backoff. It returns false if ctx ends first.
+func sleepRetry(ctx context.Context, delay time.Duration) bool {
+	if delay <= 0 {
+		return ctx.Err() == nil
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
@@ -4,11 +4,13 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"strings"
 	"sync"
 	"sync/atomic"
 	"testing"
 	"time"
 
+	"github.com/haasonsaas/nexus/internal/supervisor"
 	"github.com/haasonsaas/nexus/pkg/models"
 )
 
@@ -383,6 +385,34 @@ func TestExecuteSequentially_Basic(t *testing.T) {
 	}
 }
 
+func TestExecuteSequentially_RecoversToolPanic(t *testing.T) {
+	var crashes []supervisor.Crash
+	restore := supervisor.SetHandler(func(c supervisor.Crash) { crashes = append(crashes, c) })
+	defer restore()
+
+	registry := NewToolRegistry()
+	registry.Register(&testExecTool{
+		name: "broken",
+		execFunc: func(ctx context.Context, params json.RawMessage) (*ToolResult, error) {
+			panic("boom")
+		},
+	})
+	executor := NewToolExecutor(registry, DefaultToolExecConfig())
+
+	results := executor.ExecuteSequentially(context.Background(), []models.ToolCall{
+		{ID: "1", Name: "broken", Input: json.RawMessage(`{}`)},
+	})
+	if len(results) != 1 || !results[0].Result.IsError {
+		t.Fatalf("expected an error result, got %+v", results)
+	}
+	if !strings.Contains(results[0].Result.Content, "panicked: boom") {
+		t.Errorf("result content = %q, want panic message", results[0].Result.Content)
+	}
+	if len(crashes) != 1 || crashes[0].Component != "tool:broken" || crashes[0].Stack == "" {
+		t.Fatalf("expected one crash report with a stack, got %+v", crashes)
+	}
+}
+
 func TestExecuteSingle_Success(t *testing.T) {
 	registry := NewToolRegistry()
 	registry.Register(&testExecTool{
@@ -16,14 +16,16 @@ import (
 // ToolRegistry manages available tools with thread-safe registration and lookup.
 // Tools are registered by name and can be retrieved for execution during agent conversations.
 type ToolRegistry struct {
-	mu    sync.RWMutex
-	tools map[string]Tool
+	mu      sync.RWMutex
+	tools   map[string]Tool
+	schemas map[string]*compiledToolSchema
 }
 
 // NewToolRegistry creates a new empty tool registry ready for tool registration.
 func NewToolRegistry() *ToolRegistry {
 	return &ToolRegistry{
-		tools: make(map[string]Tool),
+		tools:   make(map[string]Tool),
+		schemas: make(map[string]*compiledToolSchema),
 	}
 }
 
@@ -33,6 +35,7 @@ func (r *ToolRegistry) Register(tool Tool) {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 	r.tools[tool.Name()] = tool
+	r.dropSchemas(tool.Name())
 }
 
 // Unregister removes a tool from the registry by name.
@@ -40,6 +43,13 @@ func (r *ToolRegistry) Unregister(name string) {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 	delete(r.tools, name)
+	r.dropSchemas(name)
+}
+
+// dropSchemas forgets compiled schemas for a tool. Callers hold r.mu.
+func (r *ToolRegistry) dropSchemas(name string) {
+	delete(r.schemas, name)
+	delete(r.schemas, name+"\x00strict")
 }
 
 // Get returns a tool by name and a boolean indicating if it was found.
@@ -226,11 +236,11 @@ func matchToolPattern(pattern, toolName string) bool {
 	return pattern == toolName
 }
 
-func guardToolResult(guard ToolResultGuard, toolName string, result models.ToolResult, resolver *policy.Resolver) models.ToolResult {
-	return guard.Apply(toolName, result, resolver)
+func guardToolResult(ctx context.Context, guard ToolResultGuard, toolName string, result models.ToolResult, resolver *policy.Resolver) models.ToolResult {
+	return guard.ApplyContext(ctx, toolName, result, resolver)
 }
 
-func guardToolResults(guard ToolResultGuard, toolCalls []models.ToolCall, results []models.ToolResult, resolver *policy.Resolver) []models.ToolResult {
+func guardToolResults(ctx context.Context, guard ToolResultGuard, toolCalls []models.ToolCall, results []models.ToolResult, resolver *policy.Resolver) []models.ToolResult {
 	if !guard.active() {
 		return results
 	}
@@ -251,7 +261,7 @@ func guardToolResults(guard ToolResultGuard, toolCalls []models.ToolCall, result
 		if toolName == "" && i < len(toolCalls) {
 			toolName = toolCalls[i].Name
 		}
-		guarded[i] = guardToolResult(guard, toolName, res, resolver)
+		guarded[i] = guardToolResult(ctx, guard, toolName, res, resolver)
 	}
 	return guarded
 }
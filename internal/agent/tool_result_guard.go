@@ -1,6 +1,7 @@
 package agent
 
 import (
+	"context"
 	"regexp"
 	"strings"
 
@@ -36,13 +37,24 @@ type ToolResultGuard struct {
 	RedactionText   string
 	TruncateSuffix  string
 	SanitizeSecrets bool // When true, applies builtin secret detection patterns
+
+	// Artifacts receives binary and base64 payloads moved out of oversized
+	// results. When nil those payloads are dropped with a placeholder.
+	Artifacts ToolArtifactStore
 }
 
 func (g ToolResultGuard) active() bool {
 	return g.Enabled || g.MaxChars > 0 || len(g.Denylist) > 0 || len(g.RedactPatterns) > 0 || g.RedactionText != "" || g.TruncateSuffix != "" || g.SanitizeSecrets
 }
 
+// Apply guards result without a context. See ApplyContext.
 func (g ToolResultGuard) Apply(toolName string, result models.ToolResult, resolver *policy.Resolver) models.ToolResult {
+	return g.ApplyContext(context.Background(), toolName, result, resolver)
+}
+
+// ApplyContext redacts result and, when it exceeds MaxChars, shrinks it
+// according to its content. See truncate.
+func (g ToolResultGuard) ApplyContext(ctx context.Context, toolName string, result models.ToolResult, resolver *policy.Resolver) models.ToolResult {
 	if !g.active() {
 		return result
 	}
@@ -86,19 +98,7 @@ func (g ToolResultGuard) Apply(toolName string, result models.ToolResult, resolv
 		}
 	}
 
-	result.Content = content
-
-	// Truncate if over size limit
-	if g.MaxChars > 0 && len(result.Content) > g.MaxChars {
-		cutoff := g.MaxChars
-		if cutoff < 0 {
-			cutoff = 0
-		}
-		if cutoff > len(result.Content) {
-			cutoff = len(result.Content)
-		}
-		result.Content = result.Content[:cutoff] + truncateSuffix
-	}
+	result.Content = g.truncate(ctx, toolName, content, truncateSuffix)
 
 	return result
 }
@@ -0,0 +1,297 @@
+package agent
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/haasonsaas/nexus/internal/observability"
+	pb "github.com/haasonsaas/nexus/pkg/proto"
+)
+
+// ToolArtifactStore stores payloads the result guard moves out of tool
+// results. artifacts.Repository satisfies it.
+type ToolArtifactStore interface {
+	StoreArtifact(ctx context.Context, artifact *pb.Artifact, data io.Reader) error
+}
+
+// toolOutputArtifactType is the artifact type of payloads moved out of
+// tool results.
+const toolOutputArtifactType = "tool_output"
+
+// minJSONStringChars is the shortest a JSON string is cut to before the
+// guard gives up on structural truncation.
+const minJSONStringChars = 64
+
+// base64Payload matches data URIs and base64 runs long enough that they
+// can only be encoded binary, such as screenshots embedded in JSON.
+var base64Payload = regexp.MustCompile(`(?:data:([\w.+-]+/[\w.+-]+);base64,)?[A-Za-z0-9+/]{512,}={0,2}`)
+
+// truncate shrinks content to MaxChars according to what it holds. Binary
+// output and embedded base64 payloads are moved to artifacts and replaced
+// with references, JSON is shortened by eliding array items and long
+// strings so it still parses, and text is cut on a character boundary
+// with any open code fence closed.
+func (g ToolResultGuard) truncate(ctx context.Context, toolName, content, suffix string) string {
+	if g.MaxChars <= 0 || len(content) <= g.MaxChars {
+		return content
+	}
+	content = g.offloadBinary(ctx, toolName, content)
+	if len(content) <= g.MaxChars {
+		return content
+	}
+	if truncated, ok := truncateJSON(content, g.MaxChars); ok {
+		return truncated
+	}
+	return truncateText(content, g.MaxChars, suffix)
+}
+
+// offloadBinary replaces binary content, data URIs, and long base64 runs
+// with artifact references.
+func (g ToolResultGuard) offloadBinary(ctx context.Context, toolName, content string) string {
+	if !utf8.ValidString(content) || strings.ContainsRune(content, 0) {
+		data := []byte(content)
+		return g.storePayload(ctx, toolName, http.DetectContentType(data), data)
+	}
+
+	matches := base64Payload.FindAllStringSubmatchIndex(content, -1)
+	if len(matches) == 0 {
+		return content
+	}
+	var out strings.Builder
+	last := 0
+	for _, m := range matches {
+		encoded := content[m[0]:m[1]]
+		mimeType := ""
+		if m[2] >= 0 {
+			mimeType = content[m[2]:m[3]]
+			encoded = encoded[strings.IndexByte(encoded, ',')+1:]
+		}
+		data, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			continue
+		}
+		if mimeType == "" {
+			mimeType = http.DetectContentType(data)
+		}
+		out.WriteString(content[last:m[0]])
+		out.WriteString(g.storePayload(ctx, toolName, mimeType, data))
+		last = m[1]
+	}
+	out.WriteString(content[last:])
+	return out.String()
+}
+
+// storePayload stores data as an artifact and returns a reference to put
+// in its place. Artifact IDs derive from the session and content, so the
+// same payload guarded for a tool event and a tool message is stored once.
+func (g ToolResultGuard) storePayload(ctx context.Context, toolName, mimeType string, data []byte) string {
+	if mimeType == "" {
+		mimeType = "application/octet-stream"
+	}
+	if g.Artifacts == nil {
+		return fmt.Sprintf("[%s output omitted: %d bytes]", mimeType, len(data))
+	}
+	sum := sha256.New()
+	sum.Write([]byte(observability.GetSessionID(ctx)))
+	sum.Write(data)
+	artifact := &pb.Artifact{
+		Id:       "tool-" + hex.EncodeToString(sum.Sum(nil))[:32],
+		Type:     toolOutputArtifactType,
+		MimeType: mimeType,
+		Filename: toolName,
+		Size:     int64(len(data)),
+	}
+	if err := g.Artifacts.StoreArtifact(ctx, artifact, bytes.NewReader(data)); err != nil {
+		return fmt.Sprintf("[%s output omitted: %d bytes]", mimeType, len(data))
+	}
+	return fmt.Sprintf("[%s output stored as artifact %s: %d bytes]", mimeType, artifact.Id, len(data))
+}
+
+// truncateText cuts content to at most limit bytes without splitting a
+// UTF-8 character, closing a code fence left open by the cut.
+func truncateText(content string, limit int, suffix string) string {
+	cut := limit
+	for cut > 0 && !utf8.RuneStart(content[cut]) {
+		cut--
+	}
+	kept := content[:cut]
+	if openCodeFence(kept) {
+		kept += "\n```\n"
+	}
+	return kept + suffix
+}
+
+// openCodeFence reports whether text ends inside a ``` fenced code block.
+func openCodeFence(text string) bool {
+	open := false
+	for _, line := range strings.Split(text, "\n") {
+		if strings.HasPrefix(strings.TrimSpace(line), "```") {
+			open = !open
+		}
+	}
+	return open
+}
+
+// jsonNode is a parsed JSON value that keeps object key order.
+type jsonNode struct {
+	delim  json.Delim // '{' or '[' for containers, 0 for scalars
+	keys   []string
+	items  []*jsonNode
+	scalar any
+}
+
+// truncateJSON shortens a JSON object or array to at most limit bytes by
+// keeping only the first items of arrays and the start of long strings,
+// recording what was elided in place. The result is compact, valid JSON.
+// It reports false when content is not JSON or cannot be shrunk enough.
+func truncateJSON(content string, limit int) (string, bool) {
+	trimmed := strings.TrimSpace(content)
+	if trimmed == "" || (trimmed[0] != '{' && trimmed[0] != '[') || !json.Valid([]byte(trimmed)) {
+		return "", false
+	}
+	dec := json.NewDecoder(strings.NewReader(trimmed))
+	dec.UseNumber()
+	root, err := parseJSONNode(dec)
+	if err != nil {
+		return "", false
+	}
+
+	maxItems, maxString := root.widest(), root.longest()
+	for {
+		if maxItems > 1 {
+			maxItems /= 2
+		}
+		if maxString > minJSONStringChars {
+			maxString = max(maxString/2, minJSONStringChars)
+		}
+		var out strings.Builder
+		root.encode(&out, maxItems, maxString)
+		if out.Len() <= limit {
+			return out.String(), true
+		}
+		if maxItems <= 1 && maxString <= minJSONStringChars {
+			return "", false
+		}
+	}
+}
+
+func parseJSONNode(dec *json.Decoder) (*jsonNode, error) {
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+	delim, ok := tok.(json.Delim)
+	if !ok {
+		return &jsonNode{scalar: tok}, nil
+	}
+	node := &jsonNode{delim: delim}
+	for dec.More() {
+		if delim == '{' {
+			keyTok, err := dec.Token()
+			if err != nil {
+				return nil, err
+			}
+			key, _ := keyTok.(string)
+			node.keys = append(node.keys, key)
+		}
+		child, err := parseJSONNode(dec)
+		if err != nil {
+			return nil, err
+		}
+		node.items = append(node.items, child)
+	}
+	if _, err := dec.Token(); err != nil {
+		return nil, err
+	}
+	return node, nil
+}
+
+// widest returns the length of the longest array in the tree.
+func (n *jsonNode) widest() int {
+	widest := 0
+	if n.delim == '[' {
+		widest = len(n.items)
+	}
+	for _, child := range n.items {
+		widest = max(widest, child.widest())
+	}
+	return widest
+}
+
+// longest returns the length of the longest string in the tree.
+func (n *jsonNode) longest() int {
+	if s, ok := n.scalar.(string); ok {
+		return len(s)
+	}
+	longest := 0
+	for _, child := range n.items {
+		longest = max(longest, child.longest())
+	}
+	return longest
+}
+
+func (n *jsonNode) encode(out *strings.Builder, maxItems, maxString int) {
+	switch n.delim {
+	case '{':
+		out.WriteByte('{')
+		for i, child := range n.items {
+			if i > 0 {
+				out.WriteByte(',')
+			}
+			writeJSONString(out, n.keys[i])
+			out.WriteByte(':')
+			child.encode(out, maxItems, maxString)
+		}
+		out.WriteByte('}')
+	case '[':
+		out.WriteByte('[')
+		for i, child := range n.items {
+			if i == maxItems {
+				out.WriteByte(',')
+				writeJSONString(out, fmt.Sprintf("...[%d more items]", len(n.items)-i))
+				break
+			}
+			if i > 0 {
+				out.WriteByte(',')
+			}
+			child.encode(out, maxItems, maxString)
+		}
+		out.WriteByte(']')
+	default:
+		switch value := n.scalar.(type) {
+		case string:
+			if len(value) > maxString {
+				cut := maxString
+				for cut > 0 && !utf8.RuneStart(value[cut]) {
+					cut--
+				}
+				value = value[:cut] + fmt.Sprintf("...[%d more chars]", len(value)-cut)
+			}
+			writeJSONString(out, value)
+		case json.Number:
+			out.WriteString(value.String())
+		case bool:
+			fmt.Fprintf(out, "%t", value)
+		default:
+			out.WriteString("null")
+		}
+	}
+}
+
+func writeJSONString(out *strings.Builder, s string) {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	_ = enc.Encode(s)
+	out.Write(bytes.TrimSuffix(buf.Bytes(), []byte("\n")))
+}
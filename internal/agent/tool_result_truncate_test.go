@@ -0,0 +1,150 @@
+package agent
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/haasonsaas/nexus/pkg/models"
+	pb "github.com/haasonsaas/nexus/pkg/proto"
+)
+
+type memoryArtifactStore struct {
+	stored map[string][]byte
+}
+
+func (s *memoryArtifactStore) StoreArtifact(_ context.Context, artifact *pb.Artifact, data io.Reader) error {
+	body, err := io.ReadAll(data)
+	if err != nil {
+		return err
+	}
+	if s.stored == nil {
+		s.stored = map[string][]byte{}
+	}
+	s.stored[artifact.Id] = body
+	return nil
+}
+
+// pngBytes returns n bytes starting with the PNG signature.
+func pngBytes(n int) []byte {
+	data := make([]byte, n)
+	copy(data, "\x89PNG\r\n\x1a\n")
+	for i := 8; i < n; i++ {
+		data[i] = byte(i)
+	}
+	return data
+}
+
+func TestToolResultGuard_Base64ImageMovedToArtifact(t *testing.T) {
+	store := &memoryArtifactStore{}
+	guard := ToolResultGuard{MaxChars: 500, Artifacts: store}
+	image := base64.StdEncoding.EncodeToString(pngBytes(3000))
+	content := `{"status":"ok","screenshot":"data:image/png;base64,` + image + `"}`
+
+	guarded := guard.Apply("browser", models.ToolResult{Content: content}, nil)
+
+	if !json.Valid([]byte(guarded.Content)) {
+		t.Fatalf("result is not valid JSON: %s", guarded.Content)
+	}
+	if !strings.Contains(guarded.Content, "image/png output stored as artifact tool-") {
+		t.Fatalf("expected artifact reference, got %s", guarded.Content)
+	}
+	if len(store.stored) != 1 {
+		t.Fatalf("stored %d artifacts, want 1", len(store.stored))
+	}
+	for _, data := range store.stored {
+		if len(data) != 3000 {
+			t.Errorf("stored %d bytes, want 3000", len(data))
+		}
+	}
+
+	// Guarding the same result again reuses the artifact.
+	guard.Apply("browser", models.ToolResult{Content: content}, nil)
+	if len(store.stored) != 1 {
+		t.Errorf("stored %d artifacts after second guard, want 1", len(store.stored))
+	}
+}
+
+func TestToolResultGuard_BinaryWithoutArtifactStore(t *testing.T) {
+	guard := ToolResultGuard{MaxChars: 100}
+	guarded := guard.Apply("download", models.ToolResult{Content: string(pngBytes(1000))}, nil)
+	if guarded.Content != "[image/png output omitted: 1000 bytes]" {
+		t.Errorf("Content = %q", guarded.Content)
+	}
+}
+
+func TestToolResultGuard_JSONElidesArrays(t *testing.T) {
+	items := make([]map[string]any, 200)
+	for i := range items {
+		items[i] = map[string]any{"id": i, "name": "item"}
+	}
+	raw, _ := json.MarshalIndent(struct {
+		Total int              `json:"total"`
+		Items []map[string]any `json:"items"`
+	}{200, items}, "", "  ")
+	guard := ToolResultGuard{MaxChars: 400}
+
+	guarded := guard.Apply("search", models.ToolResult{Content: string(raw)}, nil)
+
+	if len(guarded.Content) > 400 {
+		t.Fatalf("len = %d, want <= 400", len(guarded.Content))
+	}
+	var decoded struct {
+		Total int   `json:"total"`
+		Items []any `json:"items"`
+	}
+	if err := json.Unmarshal([]byte(guarded.Content), &decoded); err != nil {
+		t.Fatalf("result is not valid JSON: %v\n%s", err, guarded.Content)
+	}
+	if decoded.Total != 200 {
+		t.Errorf("total = %d, want 200", decoded.Total)
+	}
+	marker, _ := decoded.Items[len(decoded.Items)-1].(string)
+	if !strings.Contains(marker, "more items") {
+		t.Errorf("expected elision marker, got %v", decoded.Items[len(decoded.Items)-1])
+	}
+	if !strings.HasPrefix(guarded.Content, `{"total":200,"items":`) {
+		t.Errorf("key order not preserved: %s", guarded.Content[:40])
+	}
+}
+
+func TestToolResultGuard_JSONLongStrings(t *testing.T) {
+	content := `{"body":"` + strings.Repeat("lorem ipsum ", 200) + `"}`
+	guard := ToolResultGuard{MaxChars: 300}
+
+	guarded := guard.Apply("fetch", models.ToolResult{Content: content}, nil)
+
+	var decoded map[string]string
+	if err := json.Unmarshal([]byte(guarded.Content), &decoded); err != nil {
+		t.Fatalf("result is not valid JSON: %v\n%s", err, guarded.Content)
+	}
+	if !strings.Contains(decoded["body"], "more chars]") {
+		t.Errorf("expected string elision marker, got %q", decoded["body"])
+	}
+}
+
+func TestToolResultGuard_ClosesCodeFence(t *testing.T) {
+	content := "Output:\n```go\n" + strings.Repeat("fmt.Println(\"hi\")\n", 50) + "```\n"
+	guard := ToolResultGuard{MaxChars: 100, TruncateSuffix: "...[truncated]"}
+
+	guarded := guard.Apply("read_file", models.ToolResult{Content: content}, nil)
+
+	if strings.Count(guarded.Content, "```")%2 != 0 {
+		t.Errorf("code fence left open: %q", guarded.Content)
+	}
+	if !strings.HasSuffix(guarded.Content, "```\n...[truncated]") {
+		t.Errorf("Content = %q", guarded.Content)
+	}
+}
+
+func TestToolResultGuard_TruncatesOnRuneBoundary(t *testing.T) {
+	guard := ToolResultGuard{MaxChars: 7}
+	guarded := guard.Apply("echo", models.ToolResult{Content: "héllo wörld"}, nil)
+	if !utf8.ValidString(guarded.Content) {
+		t.Errorf("truncated content is not valid UTF-8: %q", guarded.Content)
+	}
+}
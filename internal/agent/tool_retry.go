@@ -0,0 +1,87 @@
+package agent
+
+import (
+	"errors"
+	"math/rand/v2"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/haasonsaas/nexus/pkg/models"
+)
+
+// IdempotentTool is implemented by tools that declare whether repeating a
+// call with the same arguments is safe. Tools that do not implement it are
+// treated as non-idempotent and are never retried by the runtime.
+type IdempotentTool interface {
+	Idempotent() bool
+}
+
+// IsIdempotent reports whether the named tool declares itself idempotent.
+func (r *ToolRegistry) IsIdempotent(name string) bool {
+	tool, ok := r.Get(name)
+	if !ok {
+		return false
+	}
+	declared, ok := tool.(IdempotentTool)
+	return ok && declared.Idempotent()
+}
+
+// ParseToolErrorType maps a retry_on class name to a ToolErrorType.
+func ParseToolErrorType(name string) (ToolErrorType, bool) {
+	t := ToolErrorType(strings.ToLower(strings.TrimSpace(name)))
+	switch t {
+	case ToolErrorNotFound, ToolErrorInvalidInput, ToolErrorTimeout, ToolErrorNetwork,
+		ToolErrorPermission, ToolErrorRateLimit, ToolErrorExecution, ToolErrorPanic, ToolErrorUnknown:
+		return t, true
+	}
+	return "", false
+}
+
+// toolResultErrorType classifies a failed tool result for retry decisions.
+func toolResultErrorType(result models.ToolResult, timedOut bool) ToolErrorType {
+	if timedOut {
+		return ToolErrorTimeout
+	}
+	return classifyToolError(errors.New(result.Content))
+}
+
+// Reasons a failed call is not retried although attempts remain.
+const (
+	retrySkipNonIdempotent = "non_idempotent"
+	retrySkipErrorClass    = "error_class"
+)
+
+// retrySkipReason reports why a failed call of class must not be retried
+// under cfg, or "" when it may be. Only idempotent tools are retried when
+// cfg.IdempotentOnly is set, and only for classes in cfg.RetryOn when set.
+func (e *ToolExecutor) retrySkipReason(cfg ToolExecConfig, name string, class ToolErrorType) string {
+	if cfg.IdempotentOnly && !e.registry.IsIdempotent(name) && !matchesToolPatterns(cfg.IdempotentTools, name, nil) {
+		return retrySkipNonIdempotent
+	}
+	if len(cfg.RetryOn) > 0 && !slices.Contains(cfg.RetryOn, class) {
+		return retrySkipErrorClass
+	}
+	return ""
+}
+
+// retryDelay returns the wait before the next attempt. The base backoff
+// doubles per attempt when MaxRetryBackoff caps it, and is spread by
+// RetryJitter in either direction.
+func retryDelay(cfg ToolExecConfig, attempt int) time.Duration {
+	delay := cfg.RetryBackoff
+	if delay <= 0 {
+		return 0
+	}
+	if cfg.MaxRetryBackoff > 0 {
+		for i := 1; i < attempt && delay < cfg.MaxRetryBackoff; i++ {
+			delay *= 2
+		}
+		delay = min(delay, cfg.MaxRetryBackoff)
+	}
+	if cfg.RetryJitter > 0 {
+		spread := float64(delay) * min(cfg.RetryJitter, 1)
+		delay += time.Duration((rand.Float64()*2 - 1) * spread)
+	}
+	return max(delay, 0)
+}
@@ -0,0 +1,104 @@
+package agent
+
+import (
+	"context"
+	"encoding/json"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/haasonsaas/nexus/pkg/models"
+)
+
+type idempotentExecTool struct {
+	testExecTool
+}
+
+func (t *idempotentExecTool) Idempotent() bool { return true }
+
+func failingExecTool(name string, content string, idempotent bool, calls *int32) Tool {
+	base := testExecTool{
+		name: name,
+		execFunc: func(ctx context.Context, params json.RawMessage) (*ToolResult, error) {
+			atomic.AddInt32(calls, 1)
+			return &ToolResult{Content: content, IsError: true}, nil
+		},
+	}
+	if idempotent {
+		return &idempotentExecTool{testExecTool: base}
+	}
+	return &base
+}
+
+func TestExecuteConcurrently_RetryPolicy(t *testing.T) {
+	tests := []struct {
+		name       string
+		idempotent bool
+		content    string
+		cfg        ToolExecConfig
+		want       int32
+	}{
+		{name: "non-idempotent tool is not retried", content: "connection reset", cfg: ToolExecConfig{IdempotentOnly: true}, want: 1},
+		{name: "idempotent tool is retried", idempotent: true, content: "connection reset", cfg: ToolExecConfig{IdempotentOnly: true}, want: 3},
+		{name: "configured idempotent pattern is retried", content: "connection reset", cfg: ToolExecConfig{IdempotentOnly: true, IdempotentTools: []string{"lookup"}}, want: 3},
+		{name: "error class outside retry_on", idempotent: true, content: "permission denied", cfg: ToolExecConfig{IdempotentOnly: true, RetryOn: []ToolErrorType{ToolErrorNetwork}}, want: 1},
+		{name: "error class in retry_on", idempotent: true, content: "connection reset", cfg: ToolExecConfig{IdempotentOnly: true, RetryOn: []ToolErrorType{ToolErrorNetwork}}, want: 3},
+		{name: "idempotency not required", content: "boom", cfg: ToolExecConfig{}, want: 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls int32
+			registry := NewToolRegistry()
+			registry.Register(failingExecTool("lookup", tt.content, tt.idempotent, &calls))
+
+			cfg := tt.cfg
+			cfg.Concurrency = 1
+			cfg.PerToolTimeout = time.Second
+			cfg.MaxAttempts = 3
+			executor := NewToolExecutor(registry, cfg)
+
+			var retryEvents int
+			results := executor.ExecuteConcurrently(context.Background(), []models.ToolCall{{ID: "1", Name: "lookup"}}, func(event *models.RuntimeEvent) {
+				if event.Meta["retrying"] == true {
+					retryEvents++
+				}
+			})
+			if got := atomic.LoadInt32(&calls); got != tt.want {
+				t.Fatalf("tool called %d times, want %d", got, tt.want)
+			}
+			if results[0].Attempts != int(tt.want) || retryEvents != int(tt.want)-1 {
+				t.Fatalf("attempts = %d, retry events = %d, want %d attempts", results[0].Attempts, retryEvents, tt.want)
+			}
+		})
+	}
+}
+
+func TestRetryDelay(t *testing.T) {
+	cfg := ToolExecConfig{RetryBackoff: 100 * time.Millisecond}
+	if got := retryDelay(cfg, 3); got != 100*time.Millisecond {
+		t.Fatalf("constant backoff = %v, want 100ms", got)
+	}
+
+	cfg.MaxRetryBackoff = 300 * time.Millisecond
+	for attempt, want := range map[int]time.Duration{1: 100 * time.Millisecond, 2: 200 * time.Millisecond, 3: 300 * time.Millisecond, 8: 300 * time.Millisecond} {
+		if got := retryDelay(cfg, attempt); got != want {
+			t.Fatalf("retryDelay(attempt %d) = %v, want %v", attempt, got, want)
+		}
+	}
+
+	cfg.RetryJitter = 0.5
+	for i := 0; i < 100; i++ {
+		if got := retryDelay(cfg, 1); got < 50*time.Millisecond || got > 150*time.Millisecond {
+			t.Fatalf("jittered delay %v outside [50ms, 150ms]", got)
+		}
+	}
+}
+
+func TestParseToolErrorType(t *testing.T) {
+	if got, ok := ParseToolErrorType(" Rate_Limit "); !ok || got != ToolErrorRateLimit {
+		t.Fatalf("ParseToolErrorType() = %q, %v", got, ok)
+	}
+	if _, ok := ParseToolErrorType("flaky"); ok {
+		t.Fatal("expected unknown class to be rejected")
+	}
+}
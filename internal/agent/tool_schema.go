@@ -0,0 +1,233 @@
+package agent
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/haasonsaas/nexus/internal/observability"
+	"github.com/haasonsaas/nexus/internal/tools/policy"
+	"github.com/haasonsaas/nexus/pkg/models"
+	"github.com/santhosh-tekuri/jsonschema/v5"
+)
+
+// ToolSchemaValidation controls validation of tool call arguments against
+// each tool's JSON Schema before execution.
+type ToolSchemaValidation struct {
+	// Enabled turns validation on.
+	Enabled bool
+
+	// RepairAttempts is how many times per run an invalid call to a tool is
+	// returned to the model with the validation errors so it can retry.
+	// Once exhausted, invalid calls to non-strict tools run anyway.
+	RepairAttempts int
+
+	// Strict lists tool patterns whose invalid calls are never executed.
+	// Strict tools also reject arguments the schema does not declare.
+	Strict []string
+}
+
+func (v ToolSchemaValidation) active() bool {
+	return v.Enabled
+}
+
+// Outcomes recorded for validated tool calls.
+const (
+	toolSchemaValid        = "valid"
+	toolSchemaRepair       = "repair_requested"
+	toolSchemaRejected     = "rejected"
+	toolSchemaPassThrough  = "passed_through"
+	maxToolSchemaViolation = 10
+)
+
+// checkToolSchema validates a tool call before it runs. It returns the tool
+// result to send back instead of running the call, or ok=false when the call
+// should proceed. repairs counts repair prompts per tool for the current run.
+func (r *Runtime) checkToolSchema(opts RuntimeOptions, tc models.ToolCall, model string, repairs map[string]int, resolver *policy.Resolver) (content string, blocked bool) {
+	validation := opts.ToolSchemaValidation
+	if !validation.active() || r.tools == nil {
+		return "", false
+	}
+	strict := matchesToolPatterns(validation.Strict, tc.Name, resolver)
+	violations := r.tools.ValidateInput(tc.Name, tc.Input, strict)
+	metrics := observability.NewToolValidationMetrics()
+	if len(violations) == 0 {
+		metrics.RecordCall(tc.Name, model, toolSchemaValid)
+		return "", false
+	}
+
+	key := normalizeToolName(tc.Name, resolver)
+	if repairs[key] < validation.RepairAttempts {
+		repairs[key]++
+		metrics.RecordCall(tc.Name, model, toolSchemaRepair)
+		return toolSchemaErrorContent(tc.Name, violations, true), true
+	}
+	if strict {
+		metrics.RecordCall(tc.Name, model, toolSchemaRejected)
+		return toolSchemaErrorContent(tc.Name, violations, false), true
+	}
+	metrics.RecordCall(tc.Name, model, toolSchemaPassThrough)
+	if opts.Logger != nil {
+		opts.Logger.Warn("running tool call with invalid arguments",
+			"tool", tc.Name,
+			"tool_call_id", tc.ID,
+			"violations", violations,
+		)
+	}
+	return "", false
+}
+
+// compiledToolSchema caches a tool's compiled schema. A nil schema means the
+// tool declares no usable schema and is not validated.
+type compiledToolSchema struct {
+	source []byte
+	schema *jsonschema.Schema
+}
+
+// ValidateInput checks tool call arguments against the registered tool's
+// schema and returns one message per violation. Unknown tools, tools without
+// a schema, and schemas that fail to compile report no violations. In strict
+// mode, properties the schema does not declare are violations too.
+func (r *ToolRegistry) ValidateInput(name string, input json.RawMessage, strict bool) []string {
+	r.mu.RLock()
+	tool, ok := r.tools[name]
+	r.mu.RUnlock()
+	if !ok {
+		return nil
+	}
+	schema := r.compiledSchema(name, tool, strict)
+	if schema == nil {
+		return nil
+	}
+
+	if len(bytes.TrimSpace(input)) == 0 {
+		input = json.RawMessage("{}")
+	}
+	decoder := json.NewDecoder(bytes.NewReader(input))
+	decoder.UseNumber()
+	var value any
+	if err := decoder.Decode(&value); err != nil {
+		return []string{fmt.Sprintf("arguments are not valid JSON: %v", err)}
+	}
+
+	err := schema.Validate(value)
+	if err == nil {
+		return nil
+	}
+	var validationErr *jsonschema.ValidationError
+	if !errors.As(err, &validationErr) {
+		return []string{err.Error()}
+	}
+	return schemaViolations(validationErr)
+}
+
+// compiledSchema returns the cached schema for a tool, recompiling when the
+// tool's schema changed since it was cached.
+func (r *ToolRegistry) compiledSchema(name string, tool Tool, strict bool) *jsonschema.Schema {
+	source := tool.Schema()
+	key := name
+	if strict {
+		key += "\x00strict"
+	}
+
+	r.mu.RLock()
+	cached, ok := r.schemas[key]
+	r.mu.RUnlock()
+	if ok && bytes.Equal(cached.source, source) {
+		return cached.schema
+	}
+
+	compiled := &compiledToolSchema{source: source}
+	if len(bytes.TrimSpace(source)) > 0 {
+		document := source
+		if strict {
+			document = strictToolSchema(source)
+		}
+		if schema, err := jsonschema.CompileString(name+".schema.json", string(document)); err == nil {
+			compiled.schema = schema
+		}
+	}
+
+	r.mu.Lock()
+	if r.schemas == nil {
+		r.schemas = make(map[string]*compiledToolSchema)
+	}
+	r.schemas[key] = compiled
+	r.mu.Unlock()
+	return compiled.schema
+}
+
+// strictToolSchema disallows undeclared top-level properties unless the
+// schema already says what to do with them.
+func strictToolSchema(source []byte) []byte {
+	var document map[string]any
+	if err := json.Unmarshal(source, &document); err != nil {
+		return source
+	}
+	if _, ok := document["properties"]; !ok {
+		return source
+	}
+	if _, ok := document["additionalProperties"]; ok {
+		return source
+	}
+	document["additionalProperties"] = false
+	strict, err := json.Marshal(document)
+	if err != nil {
+		return source
+	}
+	return strict
+}
+
+// schemaViolations flattens a validation error into leaf messages such as
+// "/path: missing properties: 'query'", capped to keep repair prompts short.
+func schemaViolations(err *jsonschema.ValidationError) []string {
+	seen := make(map[string]bool)
+	var violations []string
+	var walk func(*jsonschema.ValidationError)
+	walk = func(e *jsonschema.ValidationError) {
+		if len(e.Causes) > 0 {
+			for _, cause := range e.Causes {
+				walk(cause)
+			}
+			return
+		}
+		location := e.InstanceLocation
+		if location == "" {
+			location = "/"
+		}
+		message := location + ": " + e.Message
+		if !seen[message] {
+			seen[message] = true
+			violations = append(violations, message)
+		}
+	}
+	walk(err)
+	sort.Strings(violations)
+	if len(violations) > maxToolSchemaViolation {
+		violations = append(violations[:maxToolSchemaViolation], fmt.Sprintf("... and %d more", len(violations)-maxToolSchemaViolation))
+	}
+	return violations
+}
+
+// toolSchemaErrorContent renders validation errors as a tool result. When
+// retry is set, it asks the model to correct the arguments and call again.
+func toolSchemaErrorContent(toolName string, violations []string, retry bool) string {
+	var b strings.Builder
+	b.WriteString("invalid arguments for tool ")
+	b.WriteString(toolName)
+	b.WriteString(":\n")
+	for _, violation := range violations {
+		b.WriteString("- ")
+		b.WriteString(violation)
+		b.WriteString("\n")
+	}
+	if retry {
+		b.WriteString("Fix the arguments to match the tool's input schema and call the tool again.")
+	} else {
+		b.WriteString("The tool was not run.")
+	}
+	return b.String()
+}
@@ -0,0 +1,143 @@
+package agent
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/haasonsaas/nexus/pkg/models"
+)
+
+type schemaTool struct {
+	countingTool
+	schema string
+}
+
+func (s *schemaTool) Schema() json.RawMessage { return json.RawMessage(s.schema) }
+
+const searchSchema = `{
+	"type": "object",
+	"properties": {
+		"query": {"type": "string"},
+		"limit": {"type": "integer", "minimum": 1}
+	},
+	"required": ["query"]
+}`
+
+func TestToolRegistryValidateInput(t *testing.T) {
+	registry := NewToolRegistry()
+	registry.Register(&schemaTool{countingTool: countingTool{name: "search"}, schema: searchSchema})
+	registry.Register(&schemaTool{countingTool: countingTool{name: "loose"}, schema: ""})
+
+	tests := []struct {
+		name   string
+		tool   string
+		input  string
+		strict bool
+		want   []string
+	}{
+		{name: "valid", tool: "search", input: `{"query":"go","limit":5}`},
+		{name: "empty input", tool: "search", input: ``, want: []string{"/: missing properties: 'query'"}},
+		{name: "wrong types", tool: "search", input: `{"query":1,"limit":0}`, want: []string{
+			"/limit: must be >= 1 but found 0",
+			"/query: expected string, but got number",
+		}},
+		{name: "not json", tool: "search", input: `{"query":`, want: []string{"arguments are not valid JSON"}},
+		{name: "extra property", tool: "search", input: `{"query":"go","verbose":true}`},
+		{name: "strict extra property", tool: "search", input: `{"query":"go","verbose":true}`, strict: true, want: []string{"additionalProperties 'verbose' not allowed"}},
+		{name: "no schema", tool: "loose", input: `{"anything":1}`, strict: true},
+		{name: "unknown tool", tool: "missing", input: `{}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := registry.ValidateInput(tt.tool, json.RawMessage(tt.input), tt.strict)
+			if len(got) != len(tt.want) {
+				t.Fatalf("ValidateInput() = %q, want %q", got, tt.want)
+			}
+			for i := range tt.want {
+				if !strings.Contains(got[i], tt.want[i]) {
+					t.Fatalf("ValidateInput()[%d] = %q, want %q", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestToolRegistryValidateInputRecompilesReplacedTool(t *testing.T) {
+	registry := NewToolRegistry()
+	registry.Register(&schemaTool{countingTool: countingTool{name: "search"}, schema: searchSchema})
+	if got := registry.ValidateInput("search", json.RawMessage(`{}`), false); len(got) == 0 {
+		t.Fatal("expected a missing property violation")
+	}
+	registry.Register(&schemaTool{countingTool: countingTool{name: "search"}, schema: `{"type":"object"}`})
+	if got := registry.ValidateInput("search", json.RawMessage(`{}`), false); len(got) != 0 {
+		t.Fatalf("expected the replaced schema to be used, got %q", got)
+	}
+}
+
+func TestProcessToolSchemaValidation(t *testing.T) {
+	badCall := []CompletionChunk{{ToolCall: &models.ToolCall{ID: "call-1", Name: "search", Input: json.RawMessage(`{"q":"go"}`)}}}
+	tests := []struct {
+		name         string
+		strict       []string
+		wantExecuted int32
+		wantResults  []string
+	}{
+		{
+			name:         "repair then pass through",
+			wantExecuted: 1,
+			wantResults:  []string{"call the tool again", "ok"},
+		},
+		{
+			name:         "strict rejects",
+			strict:       []string{"search"},
+			wantExecuted: 0,
+			wantResults:  []string{"call the tool again", "The tool was not run."},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider := &sequenceProvider{responses: [][]CompletionChunk{badCall, badCall, {{Text: "done"}}}, supportsTools: true}
+			tool := &schemaTool{countingTool: countingTool{name: "search"}, schema: searchSchema}
+			runtime := NewRuntimeWithOptions(provider, stubStore{}, RuntimeOptions{
+				MaxIterations:   3,
+				ToolParallelism: 1,
+				ToolSchemaValidation: ToolSchemaValidation{
+					Enabled:        true,
+					RepairAttempts: 1,
+					Strict:         tt.strict,
+				},
+			})
+			runtime.RegisterTool(tool)
+
+			session := &models.Session{ID: "session-1", Channel: models.ChannelTelegram}
+			ch, err := runtime.Process(context.Background(), session, &models.Message{Role: models.RoleUser, Content: "hi"})
+			if err != nil {
+				t.Fatalf("Process() error = %v", err)
+			}
+			var results []models.ToolResult
+			for chunk := range ch {
+				if chunk.ToolResult != nil {
+					results = append(results, *chunk.ToolResult)
+				}
+			}
+
+			if got := atomic.LoadInt32(&tool.calls); got != tt.wantExecuted {
+				t.Fatalf("tool executed %d times, want %d", got, tt.wantExecuted)
+			}
+			if len(results) != len(tt.wantResults) {
+				t.Fatalf("got %d tool results, want %d: %+v", len(results), len(tt.wantResults), results)
+			}
+			for i, want := range tt.wantResults {
+				if !strings.Contains(results[i].Content, want) {
+					t.Fatalf("result %d = %q, want it to contain %q", i, results[i].Content, want)
+				}
+			}
+			if !strings.Contains(results[0].Content, "missing properties: 'query'") {
+				t.Fatalf("expected the repair prompt to list violations, got %q", results[0].Content)
+			}
+		})
+	}
+}
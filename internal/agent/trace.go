@@ -33,6 +33,8 @@ type TraceHeader struct {
 	StartedAt   time.Time `json:"started_at"`  // When the trace started
 	AppVersion  string    `json:"app_version"` // Application version (optional)
 	Environment string    `json:"environment"` // Environment name (optional)
+
+	Tags map[string]string `json:"tags,omitempty"` // Run tags such as experiment variants (optional)
 }
 
 var traceFileSanitizer = regexp.MustCompile(`[^a-zA-Z0-9._-]+`)
@@ -124,6 +126,9 @@ func (p *TracePlugin) OnEvent(ctx context.Context, e models.AgentEvent) {
 	// Write header on first event
 	if !p.started {
 		p.started = true
+		if tags := TraceTagsFromContext(ctx); len(tags) > 0 && p.header != nil {
+			p.header.Tags = tags
+		}
 		p.writeHeader()
 	}
 
@@ -0,0 +1,322 @@
+package agent
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/haasonsaas/nexus/pkg/models"
+)
+
+// Span kinds in a TraceProfile.
+const (
+	SpanRun       = "run"
+	SpanIteration = "iteration"
+	SpanModel     = "model"
+	SpanTool      = "tool"
+)
+
+// ProfileSpan is a timed section of a run.
+type ProfileSpan struct {
+	Name  string
+	Kind  string
+	Start time.Time
+	End   time.Time
+	// Iter is the iteration the span belongs to, or -1 for the run.
+	Iter int
+	// Lane separates overlapping spans: lane 0 holds the run, iterations
+	// and model calls; tool calls that run in parallel get their own lanes.
+	Lane int
+	Args map[string]any
+}
+
+// Duration returns the span's length.
+func (s ProfileSpan) Duration() time.Duration {
+	return s.End.Sub(s.Start)
+}
+
+// TraceProfile is the timeline of a recorded run: the run itself, each
+// iteration, and the model and tool calls inside them.
+type TraceProfile struct {
+	RunID string
+	Start time.Time
+	Spans []ProfileSpan
+}
+
+// BuildTraceProfile derives a timeline from trace events. Model time runs
+// from iter.started to model.completed, as in StatsCollector; spans left
+// open by a truncated trace end at the last event.
+func BuildTraceProfile(runID string, events []models.AgentEvent) *TraceProfile {
+	profile := &TraceProfile{RunID: runID}
+	if len(events) == 0 {
+		return profile
+	}
+	events = append([]models.AgentEvent(nil), events...)
+	sort.SliceStable(events, func(i, j int) bool { return events[i].Sequence < events[j].Sequence })
+
+	last := events[len(events)-1].Time
+	run := ProfileSpan{Name: "run", Kind: SpanRun, Start: events[0].Time, End: last, Iter: -1}
+	if runID != "" {
+		run.Args = map[string]any{"run_id": runID}
+	}
+	var (
+		spans      []ProfileSpan
+		iter       *ProfileSpan
+		modelStart time.Time
+		tools      = make(map[string]*ProfileSpan)
+		toolOrder  []string
+	)
+	closeTools := func(end time.Time) {
+		for _, id := range toolOrder {
+			if span, ok := tools[id]; ok {
+				span.End = end
+				span.Args["unfinished"] = true
+				spans = append(spans, *span)
+				delete(tools, id)
+			}
+		}
+		toolOrder = toolOrder[:0]
+	}
+	closeIter := func(end time.Time) {
+		if iter == nil {
+			return
+		}
+		if !modelStart.IsZero() {
+			spans = append(spans, ProfileSpan{Name: "model", Kind: SpanModel, Start: modelStart, End: end, Iter: iter.Iter, Args: map[string]any{"unfinished": true}})
+			modelStart = time.Time{}
+		}
+		closeTools(end)
+		iter.End = end
+		spans = append(spans, *iter)
+		iter = nil
+	}
+
+	for _, e := range events {
+		switch e.Type {
+		case models.AgentEventRunStarted:
+			run.Start = e.Time
+		case models.AgentEventIterStarted:
+			closeIter(e.Time)
+			iter = &ProfileSpan{Name: fmt.Sprintf("iteration %d", e.IterIndex), Kind: SpanIteration, Start: e.Time, Iter: e.IterIndex}
+			modelStart = e.Time
+		case models.AgentEventModelCompleted:
+			if modelStart.IsZero() {
+				continue
+			}
+			span := ProfileSpan{Name: "model", Kind: SpanModel, Start: modelStart, End: e.Time, Iter: currentIter(iter, e), Args: map[string]any{}}
+			if e.Stream != nil {
+				if e.Stream.Model != "" {
+					span.Name = "model " + e.Stream.Model
+					span.Args["model"] = e.Stream.Model
+				}
+				span.Args["input_tokens"] = e.Stream.InputTokens
+				span.Args["output_tokens"] = e.Stream.OutputTokens
+			}
+			spans = append(spans, span)
+			modelStart = time.Time{}
+		case models.AgentEventToolStarted:
+			if e.Tool == nil {
+				continue
+			}
+			tools[e.Tool.CallID] = &ProfileSpan{
+				Name:  "tool " + e.Tool.Name,
+				Kind:  SpanTool,
+				Start: e.Time,
+				Iter:  currentIter(iter, e),
+				Args:  map[string]any{"call_id": e.Tool.CallID},
+			}
+			toolOrder = append(toolOrder, e.Tool.CallID)
+		case models.AgentEventToolFinished, models.AgentEventToolTimedOut:
+			if e.Tool == nil {
+				continue
+			}
+			span, ok := tools[e.Tool.CallID]
+			if !ok {
+				continue
+			}
+			span.End = e.Time
+			span.Args["success"] = e.Type == models.AgentEventToolFinished && e.Tool.Success
+			if e.Type == models.AgentEventToolTimedOut {
+				span.Args["timed_out"] = true
+			}
+			spans = append(spans, *span)
+			delete(tools, e.Tool.CallID)
+		case models.AgentEventIterFinished:
+			closeIter(e.Time)
+		case models.AgentEventRunFinished, models.AgentEventRunError, models.AgentEventRunCancelled, models.AgentEventRunTimedOut:
+			closeIter(e.Time)
+			run.End = e.Time
+			if e.Type != models.AgentEventRunFinished {
+				if run.Args == nil {
+					run.Args = map[string]any{}
+				}
+				run.Args["outcome"] = string(e.Type)
+			}
+		}
+	}
+	closeIter(last)
+
+	profile.Start = run.Start
+	profile.Spans = append([]ProfileSpan{run}, spans...)
+	sort.SliceStable(profile.Spans, func(i, j int) bool {
+		a, b := profile.Spans[i], profile.Spans[j]
+		if !a.Start.Equal(b.Start) {
+			return a.Start.Before(b.Start)
+		}
+		// Parents before the children that start with them.
+		return a.End.After(b.End)
+	})
+	assignLanes(profile.Spans)
+	return profile
+}
+
+// currentIter returns the index of the open iteration, falling back to the
+// event's own index.
+func currentIter(iter *ProfileSpan, e models.AgentEvent) int {
+	if iter != nil {
+		return iter.Iter
+	}
+	return e.IterIndex
+}
+
+// assignLanes puts tool spans on the first lane that is free when they
+// start, so overlapping calls never share a lane.
+func assignLanes(spans []ProfileSpan) {
+	var laneEnds []time.Time
+	for i := range spans {
+		if spans[i].Kind != SpanTool {
+			continue
+		}
+		lane := -1
+		for l, end := range laneEnds {
+			if !end.After(spans[i].Start) {
+				lane = l
+				break
+			}
+		}
+		if lane < 0 {
+			laneEnds = append(laneEnds, time.Time{})
+			lane = len(laneEnds) - 1
+		}
+		laneEnds[lane] = spans[i].End
+		spans[i].Lane = lane + 1
+	}
+}
+
+// chromeEvent is an event in the Chrome trace-event format, as loaded by
+// chrome://tracing, Perfetto and speedscope.
+type chromeEvent struct {
+	Name  string         `json:"name"`
+	Cat   string         `json:"cat,omitempty"`
+	Phase string         `json:"ph"`
+	TS    int64          `json:"ts"`
+	Dur   int64          `json:"dur"`
+	PID   int            `json:"pid"`
+	TID   int            `json:"tid"`
+	Args  map[string]any `json:"args,omitempty"`
+}
+
+// WriteChromeTrace writes the profile as Chrome trace-event JSON with
+// timestamps in microseconds from the start of the run.
+func (p *TraceProfile) WriteChromeTrace(w io.Writer) error {
+	events := []chromeEvent{{Name: "process_name", Phase: "M", PID: 1, Args: map[string]any{"name": "run " + p.RunID}}}
+	lanes := 0
+	for _, span := range p.Spans {
+		lanes = max(lanes, span.Lane)
+	}
+	for lane := 0; lane <= lanes; lane++ {
+		name := "agent"
+		if lane > 0 {
+			name = fmt.Sprintf("tools %d", lane)
+		}
+		events = append(events, chromeEvent{Name: "thread_name", Phase: "M", PID: 1, TID: lane, Args: map[string]any{"name": name}})
+	}
+	for _, span := range p.Spans {
+		events = append(events, chromeEvent{
+			Name:  span.Name,
+			Cat:   span.Kind,
+			Phase: "X",
+			TS:    span.Start.Sub(p.Start).Microseconds(),
+			Dur:   span.Duration().Microseconds(),
+			PID:   1,
+			TID:   span.Lane,
+			Args:  span.Args,
+		})
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(struct {
+		TraceEvents     []chromeEvent `json:"traceEvents"`
+		DisplayTimeUnit string        `json:"displayTimeUnit"`
+	}{events, "ms"})
+}
+
+// WriteFolded writes the profile as folded stacks ("run;iteration 0;model
+// 1200"), the input format of flamegraph.pl and speedscope. Values are self
+// time in microseconds; parallel tool calls each count in full, so an
+// iteration's tools can add up to more than its wall time.
+func (p *TraceProfile) WriteFolded(w io.Writer) error {
+	type frame struct {
+		stack string
+		self  time.Duration
+	}
+	var (
+		frames    []frame
+		runFrame  = -1
+		iterFrame = map[int]int{}
+	)
+	for _, span := range p.Spans {
+		switch span.Kind {
+		case SpanRun:
+			runFrame = len(frames)
+			frames = append(frames, frame{stack: foldedName(span.Name), self: span.Duration()})
+		case SpanIteration:
+			iterFrame[span.Iter] = len(frames)
+			frames = append(frames, frame{stack: "run;" + foldedName(span.Name), self: span.Duration()})
+			if runFrame >= 0 {
+				frames[runFrame].self -= span.Duration()
+			}
+		}
+	}
+	for _, span := range p.Spans {
+		if span.Kind != SpanModel && span.Kind != SpanTool {
+			continue
+		}
+		parent, ok := iterFrame[span.Iter]
+		stack := "run;" + foldedName(span.Name)
+		if ok {
+			stack = frames[parent].stack + ";" + foldedName(span.Name)
+			frames[parent].self -= span.Duration()
+		} else if runFrame >= 0 {
+			frames[runFrame].self -= span.Duration()
+		}
+		frames = append(frames, frame{stack: stack, self: span.Duration()})
+	}
+
+	totals := make(map[string]int64, len(frames))
+	var order []string
+	for _, f := range frames {
+		us := f.self.Microseconds()
+		if us <= 0 {
+			continue
+		}
+		if _, seen := totals[f.stack]; !seen {
+			order = append(order, f.stack)
+		}
+		totals[f.stack] += us
+	}
+	for _, stack := range order {
+		if _, err := fmt.Fprintf(w, "%s %d\n", stack, totals[stack]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// foldedName makes a frame name safe for the folded stack format.
+func foldedName(name string) string {
+	return strings.NewReplacer(";", "_", "\n", " ").Replace(name)
+}
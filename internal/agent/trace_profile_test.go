@@ -0,0 +1,137 @@
+package agent
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/haasonsaas/nexus/pkg/models"
+)
+
+func profileEvents() []models.AgentEvent {
+	start := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	at := func(ms int) time.Time { return start.Add(time.Duration(ms) * time.Millisecond) }
+	tool := func(id, name string) *models.ToolEventPayload {
+		return &models.ToolEventPayload{CallID: id, Name: name, Success: true}
+	}
+	events := []models.AgentEvent{
+		{Type: models.AgentEventRunStarted, Time: at(0)},
+		{Type: models.AgentEventIterStarted, Time: at(10), IterIndex: 0},
+		{Type: models.AgentEventModelCompleted, Time: at(410), Stream: &models.StreamEventPayload{Model: "sonnet", InputTokens: 100, OutputTokens: 20}},
+		{Type: models.AgentEventToolStarted, Time: at(420), Tool: tool("a", "web_fetch")},
+		{Type: models.AgentEventToolStarted, Time: at(425), Tool: tool("b", "sql_query")},
+		{Type: models.AgentEventToolFinished, Time: at(900), Tool: tool("a", "web_fetch")},
+		{Type: models.AgentEventToolTimedOut, Time: at(1025), Tool: tool("b", "sql_query")},
+		{Type: models.AgentEventIterFinished, Time: at(1030), IterIndex: 0},
+		{Type: models.AgentEventIterStarted, Time: at(1030), IterIndex: 1},
+		{Type: models.AgentEventModelCompleted, Time: at(1230), IterIndex: 1},
+		{Type: models.AgentEventIterFinished, Time: at(1240), IterIndex: 1},
+		{Type: models.AgentEventRunFinished, Time: at(1250)},
+	}
+	for i := range events {
+		events[i].Sequence = uint64(i + 1)
+	}
+	return events
+}
+
+func TestBuildTraceProfile(t *testing.T) {
+	profile := BuildTraceProfile("run-1", profileEvents())
+
+	got := map[string]ProfileSpan{}
+	for _, span := range profile.Spans {
+		got[span.Name] = span
+	}
+	checks := []struct {
+		name     string
+		duration time.Duration
+		lane     int
+		iter     int
+	}{
+		{"run", 1250 * time.Millisecond, 0, -1},
+		{"iteration 0", 1020 * time.Millisecond, 0, 0},
+		{"model sonnet", 400 * time.Millisecond, 0, 0},
+		{"tool web_fetch", 480 * time.Millisecond, 1, 0},
+		{"tool sql_query", 600 * time.Millisecond, 2, 0},
+		{"iteration 1", 210 * time.Millisecond, 0, 1},
+		{"model", 200 * time.Millisecond, 0, 1},
+	}
+	if len(profile.Spans) != len(checks) {
+		t.Fatalf("expected %d spans, got %+v", len(checks), profile.Spans)
+	}
+	for _, c := range checks {
+		span, ok := got[c.name]
+		if !ok {
+			t.Fatalf("missing span %q in %+v", c.name, profile.Spans)
+		}
+		if span.Duration() != c.duration || span.Lane != c.lane || span.Iter != c.iter {
+			t.Errorf("%s: duration=%v lane=%d iter=%d, want %v lane %d iter %d", c.name, span.Duration(), span.Lane, span.Iter, c.duration, c.lane, c.iter)
+		}
+	}
+	if got["tool sql_query"].Args["timed_out"] != true {
+		t.Errorf("expected the timed out tool to be marked, got %v", got["tool sql_query"].Args)
+	}
+}
+
+func TestBuildTraceProfileTruncatedTrace(t *testing.T) {
+	events := profileEvents()[:5]
+	profile := BuildTraceProfile("run-1", events)
+	for _, span := range profile.Spans {
+		if !span.End.Equal(events[4].Time) && span.Kind != SpanModel {
+			t.Errorf("expected %s to end at the last event, got %v", span.Name, span.End)
+		}
+		if span.Kind == SpanTool && span.Args["unfinished"] != true {
+			t.Errorf("expected %s to be marked unfinished", span.Name)
+		}
+	}
+}
+
+func TestTraceProfileChromeTrace(t *testing.T) {
+	var buf bytes.Buffer
+	if err := BuildTraceProfile("run-1", profileEvents()).WriteChromeTrace(&buf); err != nil {
+		t.Fatalf("WriteChromeTrace: %v", err)
+	}
+	var out struct {
+		TraceEvents []struct {
+			Name  string `json:"name"`
+			Phase string `json:"ph"`
+			TS    int64  `json:"ts"`
+			Dur   int64  `json:"dur"`
+			TID   int    `json:"tid"`
+		} `json:"traceEvents"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &out); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	threads := 0
+	for _, e := range out.TraceEvents {
+		if e.Name == "thread_name" {
+			threads++
+		}
+		if e.Name == "tool sql_query" && (e.Phase != "X" || e.TS != 425000 || e.Dur != 600000 || e.TID != 2) {
+			t.Errorf("unexpected tool event %+v", e)
+		}
+	}
+	if threads != 3 {
+		t.Errorf("expected an agent lane and two tool lanes, got %d", threads)
+	}
+}
+
+func TestTraceProfileFolded(t *testing.T) {
+	var buf bytes.Buffer
+	if err := BuildTraceProfile("run-1", profileEvents()).WriteFolded(&buf); err != nil {
+		t.Fatalf("WriteFolded: %v", err)
+	}
+	want := strings.Join([]string{
+		"run 20000",
+		"run;iteration 1 10000",
+		"run;iteration 0;model sonnet 400000",
+		"run;iteration 0;tool web_fetch 480000",
+		"run;iteration 0;tool sql_query 600000",
+		"run;iteration 1;model 200000",
+	}, "\n") + "\n"
+	if buf.String() != want {
+		t.Fatalf("folded stacks:\n%s\nwant:\n%s", buf.String(), want)
+	}
+}
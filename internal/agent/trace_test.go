@@ -36,6 +36,24 @@ func TestTracePlugin_WritesHeader(t *testing.T) {
 	}
 }
 
+func TestTracePlugin_HeaderTags(t *testing.T) {
+	var buf bytes.Buffer
+	plugin := NewTracePlugin(&buf, "test-run-123")
+
+	ctx := WithTraceTags(context.Background(), map[string]string{"experiment.prompt": "control"})
+	ctx = WithTraceTags(ctx, map[string]string{"experiment.model": "treatment"})
+	plugin.OnEvent(ctx, models.AgentEvent{Type: models.AgentEventRunStarted})
+
+	reader, err := NewTraceReader(&buf)
+	if err != nil {
+		t.Fatalf("failed to create reader: %v", err)
+	}
+	tags := reader.Header().Tags
+	if tags["experiment.prompt"] != "control" || tags["experiment.model"] != "treatment" {
+		t.Errorf("Tags = %v, want both experiment variants", tags)
+	}
+}
+
 func TestTracePlugin_WritesEvents(t *testing.T) {
 	var buf bytes.Buffer
 	plugin := NewTracePlugin(&buf, "test-run")
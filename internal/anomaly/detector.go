@@ -0,0 +1,328 @@
+// Package anomaly flags sudden spikes in core gateway counters, such as run
+// errors, token usage, and messages from a single peer, by comparing each
+// interval's count to an exponentially weighted baseline kept in memory.
+// It catches runaway loops and abuse before they show up on the bill.
+package anomaly
+
+import (
+	"context"
+	"log/slog"
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/haasonsaas/nexus/internal/config"
+)
+
+// Signal names a counter the detector watches.
+type Signal string
+
+const (
+	// SignalErrors counts failed runs and failed tool calls.
+	SignalErrors Signal = "errors"
+	// SignalTokens counts LLM tokens, input and output.
+	SignalTokens Signal = "tokens"
+	// SignalPeerMessages counts inbound messages per peer.
+	SignalPeerMessages Signal = "peer_messages"
+)
+
+// peerIdleIntervals is how many quiet intervals a peer's baseline is kept.
+const peerIdleIntervals = 24 * 60
+
+// Anomaly is a signal whose latest interval is far above its baseline.
+type Anomaly struct {
+	Signal Signal `json:"signal"`
+	// Key identifies the peer for SignalPeerMessages, e.g. "telegram:123".
+	Key string `json:"key,omitempty"`
+	// Value is the count in the latest interval.
+	Value float64 `json:"value"`
+	// Baseline and StdDev describe the usual count per interval.
+	Baseline float64   `json:"baseline"`
+	StdDev   float64   `json:"stddev"`
+	ZScore   float64   `json:"z_score"`
+	Since    time.Time `json:"since"`
+	At       time.Time `json:"at"`
+}
+
+// Alert reports an anomaly starting or, with Resolved set, ending.
+type Alert struct {
+	Anomaly
+	Resolved bool `json:"resolved"`
+}
+
+// AlertFunc delivers an alert.
+type AlertFunc func(ctx context.Context, alert Alert) error
+
+// baseline is an exponentially weighted mean and variance of per-interval
+// counts.
+type baseline struct {
+	mean     float64
+	variance float64
+	samples  int
+}
+
+func (b *baseline) update(x, alpha float64) {
+	if b.samples == 0 {
+		b.mean = x
+	} else {
+		diff := x - b.mean
+		incr := alpha * diff
+		b.mean += incr
+		b.variance = (1 - alpha) * (b.variance + diff*incr)
+	}
+	b.samples++
+}
+
+// stddev is floored at the Poisson deviation of the mean, and at 1, so a
+// perfectly steady baseline does not turn every small change into an
+// anomaly.
+func (b *baseline) stddev() float64 {
+	return max(math.Sqrt(b.variance), math.Sqrt(b.mean), 1)
+}
+
+type seriesKey struct {
+	signal Signal
+	key    string
+}
+
+type series struct {
+	baseline baseline
+	count    float64
+	idle     int
+	active   *Anomaly
+}
+
+// Detector sums counters per interval and raises alerts when an interval
+// is anomalous. Anomalous intervals are left out of the baseline, so a
+// sustained spike stays flagged until it ends.
+type Detector struct {
+	cfg     config.AnomalyConfig
+	logger  *slog.Logger
+	metrics *Metrics
+	now     func() time.Time
+
+	mu     sync.Mutex
+	series map[seriesKey]*series
+	// peers is the baseline of an active peer's messages per interval,
+	// used for peers without enough history of their own.
+	peers baseline
+	alert AlertFunc
+}
+
+// NewDetector creates a detector with the given settings. Unset alpha and
+// threshold fall back to 0.1 and 4.
+func NewDetector(cfg config.AnomalyConfig, logger *slog.Logger) *Detector {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	if cfg.Alpha <= 0 || cfg.Alpha > 1 {
+		cfg.Alpha = 0.1
+	}
+	if cfg.Threshold <= 0 {
+		cfg.Threshold = 4
+	}
+	d := &Detector{
+		cfg:     cfg,
+		logger:  logger.With("component", "anomaly"),
+		metrics: NewMetrics(),
+		now:     time.Now,
+		series:  make(map[seriesKey]*series),
+	}
+	// Gateway-wide signals are evaluated every interval, including quiet ones.
+	for _, signal := range []Signal{SignalErrors, SignalTokens} {
+		d.series[seriesKey{signal: signal}] = &series{}
+	}
+	return d
+}
+
+// SetAlert sets the function called when an anomaly starts or resolves.
+func (d *Detector) SetAlert(fn AlertFunc) {
+	if d == nil {
+		return
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.alert = fn
+}
+
+// Add counts n occurrences of signal. key is the peer for
+// SignalPeerMessages and ignored otherwise.
+func (d *Detector) Add(signal Signal, key string, n float64) {
+	if d == nil || n <= 0 {
+		return
+	}
+	if signal != SignalPeerMessages {
+		key = ""
+	} else if key == "" {
+		return
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	s := d.series[seriesKey{signal: signal, key: key}]
+	if s == nil {
+		s = &series{}
+		d.series[seriesKey{signal: signal, key: key}] = s
+	}
+	s.count += n
+}
+
+// Run evaluates every interval until ctx is done.
+func (d *Detector) Run(ctx context.Context) {
+	interval := d.cfg.Interval
+	if interval <= 0 {
+		interval = time.Minute
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			d.Evaluate(ctx)
+		}
+	}
+}
+
+// Evaluate closes the current interval: it scores each counter against its
+// baseline, updates the baselines, sends alerts for anomalies that started
+// or ended, and returns the active anomalies.
+func (d *Detector) Evaluate(ctx context.Context) []Anomaly {
+	now := d.now()
+	var alerts []Alert
+
+	d.mu.Lock()
+	var peerCounts []float64
+	for key, s := range d.series {
+		x := s.count
+		s.count = 0
+		reference := &s.baseline
+		if key.signal == SignalPeerMessages {
+			if x > 0 {
+				s.idle = 0
+				peerCounts = append(peerCounts, x)
+			} else {
+				s.idle++
+			}
+			if s.baseline.samples < d.warmup() {
+				reference = &d.peers
+			}
+		}
+
+		anomalous := false
+		score := 0.0
+		if reference.samples >= d.warmup() {
+			score = (x - reference.mean) / reference.stddev()
+			anomalous = x >= d.minimum(key.signal) && score >= d.cfg.Threshold
+		}
+		if key.key == "" {
+			d.metrics.ZScore.WithLabelValues(string(key.signal)).Set(score)
+		}
+
+		switch {
+		case anomalous:
+			current := Anomaly{
+				Signal:   key.signal,
+				Key:      key.key,
+				Value:    x,
+				Baseline: reference.mean,
+				StdDev:   reference.stddev(),
+				ZScore:   score,
+				Since:    now,
+				At:       now,
+			}
+			if s.active != nil {
+				current.Since = s.active.Since
+			} else {
+				alerts = append(alerts, Alert{Anomaly: current})
+			}
+			s.active = &current
+		case s.active != nil:
+			resolved := *s.active
+			resolved.Value, resolved.ZScore, resolved.At = x, score, now
+			alerts = append(alerts, Alert{Anomaly: resolved, Resolved: true})
+			s.active = nil
+		}
+		if !anomalous {
+			s.baseline.update(x, d.cfg.Alpha)
+		}
+		if key.signal == SignalPeerMessages && s.idle > peerIdleIntervals && s.active == nil {
+			delete(d.series, key)
+		}
+	}
+	// Update the shared peer baseline after scoring, in a stable order.
+	sort.Float64s(peerCounts)
+	for _, x := range peerCounts {
+		if d.peers.samples < d.warmup() || (x-d.peers.mean)/d.peers.stddev() < d.cfg.Threshold {
+			d.peers.update(x, d.cfg.Alpha)
+		}
+	}
+	active := d.activeLocked()
+	alert := d.alert
+	d.mu.Unlock()
+
+	d.metrics.record(active, alerts)
+	for _, a := range alerts {
+		if a.Resolved {
+			d.logger.Info("anomaly resolved", "signal", a.Signal, "key", a.Key, "since", a.Since)
+		} else {
+			d.logger.Warn("anomaly detected",
+				"signal", a.Signal,
+				"key", a.Key,
+				"value", a.Value,
+				"baseline", a.Baseline,
+				"z_score", a.ZScore,
+			)
+		}
+		if alert == nil {
+			continue
+		}
+		if err := alert(ctx, a); err != nil {
+			d.logger.Warn("failed to send anomaly alert", "signal", a.Signal, "key", a.Key, "error", err)
+		}
+	}
+	return active
+}
+
+// Active returns the anomalies flagged by the latest evaluation, gateway
+// signals first.
+func (d *Detector) Active() []Anomaly {
+	if d == nil {
+		return nil
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.activeLocked()
+}
+
+func (d *Detector) activeLocked() []Anomaly {
+	var active []Anomaly
+	for _, s := range d.series {
+		if s.active != nil {
+			active = append(active, *s.active)
+		}
+	}
+	sort.Slice(active, func(i, j int) bool {
+		if active[i].Signal != active[j].Signal {
+			return active[i].Signal < active[j].Signal
+		}
+		return active[i].Key < active[j].Key
+	})
+	return active
+}
+
+func (d *Detector) warmup() int {
+	return max(d.cfg.Warmup, 1)
+}
+
+func (d *Detector) minimum(signal Signal) float64 {
+	switch signal {
+	case SignalErrors:
+		return float64(d.cfg.MinErrors)
+	case SignalTokens:
+		return float64(d.cfg.MinTokens)
+	default:
+		return float64(d.cfg.MinPeerMessages)
+	}
+}
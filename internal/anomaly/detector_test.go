@@ -0,0 +1,162 @@
+package anomaly
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/haasonsaas/nexus/internal/config"
+)
+
+func newTestDetector() (*Detector, *[]Alert) {
+	d := NewDetector(config.AnomalyConfig{
+		Interval:        time.Minute,
+		Alpha:           0.1,
+		Threshold:       4,
+		Warmup:          5,
+		MinErrors:       5,
+		MinTokens:       1000,
+		MinPeerMessages: 10,
+	}, nil)
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	d.now = func() time.Time {
+		now = now.Add(time.Minute)
+		return now
+	}
+	var alerts []Alert
+	d.SetAlert(func(_ context.Context, alert Alert) error {
+		alerts = append(alerts, alert)
+		return nil
+	})
+	return d, &alerts
+}
+
+func TestErrorSpikeAlertsAndResolves(t *testing.T) {
+	ctx := context.Background()
+	d, alerts := newTestDetector()
+	for i := 0; i < 10; i++ {
+		d.Add(SignalErrors, "", 2)
+		if active := d.Evaluate(ctx); len(active) != 0 {
+			t.Fatalf("interval %d: unexpected anomalies %+v", i, active)
+		}
+	}
+
+	d.Add(SignalErrors, "", 40)
+	active := d.Evaluate(ctx)
+	if len(active) != 1 || active[0].Signal != SignalErrors || active[0].Value != 40 {
+		t.Fatalf("active = %+v, want an errors anomaly", active)
+	}
+	if active[0].Baseline != 2 || active[0].ZScore < 4 {
+		t.Fatalf("unexpected score: %+v", active[0])
+	}
+
+	// A sustained spike stays active without alerting again, and does not
+	// drag the baseline up.
+	d.Add(SignalErrors, "", 40)
+	d.Evaluate(ctx)
+	if len(*alerts) != 1 || (*alerts)[0].Resolved {
+		t.Fatalf("alerts = %+v, want one start alert", *alerts)
+	}
+	if got := d.Active(); len(got) != 1 || got[0].Since != active[0].Since {
+		t.Fatalf("Active() = %+v", got)
+	}
+
+	d.Add(SignalErrors, "", 2)
+	if active := d.Evaluate(ctx); len(active) != 0 {
+		t.Fatalf("active after recovery = %+v", active)
+	}
+	if len(*alerts) != 2 || !(*alerts)[1].Resolved || (*alerts)[1].Value != 2 {
+		t.Fatalf("alerts = %+v, want a resolved alert", *alerts)
+	}
+}
+
+func TestMinimumsSuppressSmallSpikes(t *testing.T) {
+	ctx := context.Background()
+	d, alerts := newTestDetector()
+	for i := 0; i < 10; i++ {
+		d.Evaluate(ctx)
+	}
+	// Far above a zero baseline, but below the minimums.
+	d.Add(SignalErrors, "", 4)
+	d.Add(SignalTokens, "", 900)
+	if active := d.Evaluate(ctx); len(active) != 0 {
+		t.Fatalf("active = %+v, want none below minimums", active)
+	}
+	d.Add(SignalTokens, "", 5000)
+	if active := d.Evaluate(ctx); len(active) != 1 || active[0].Signal != SignalTokens {
+		t.Fatalf("active = %+v, want a tokens anomaly", active)
+	}
+	if len(*alerts) != 1 {
+		t.Fatalf("alerts = %+v", *alerts)
+	}
+}
+
+func TestNoAlertsDuringWarmup(t *testing.T) {
+	ctx := context.Background()
+	d, _ := newTestDetector()
+	d.Add(SignalErrors, "", 1)
+	d.Evaluate(ctx)
+	d.Add(SignalErrors, "", 500)
+	if active := d.Evaluate(ctx); len(active) != 0 {
+		t.Fatalf("active = %+v, want none before warmup", active)
+	}
+}
+
+func TestNewPeerComparedToOtherPeers(t *testing.T) {
+	ctx := context.Background()
+	d, alerts := newTestDetector()
+	for i := 0; i < 10; i++ {
+		d.Add(SignalPeerMessages, "slack:U1", 3)
+		d.Add(SignalPeerMessages, "slack:U2", 2)
+		d.Evaluate(ctx)
+	}
+
+	d.Add(SignalPeerMessages, "slack:U1", 3)
+	d.Add(SignalPeerMessages, "telegram:999", 200)
+	active := d.Evaluate(ctx)
+	if len(active) != 1 || active[0].Key != "telegram:999" || active[0].Signal != SignalPeerMessages {
+		t.Fatalf("active = %+v, want the new peer flagged", active)
+	}
+	if len(*alerts) != 1 || (*alerts)[0].Key != "telegram:999" {
+		t.Fatalf("alerts = %+v", *alerts)
+	}
+}
+
+func TestNilDetector(t *testing.T) {
+	var d *Detector
+	d.Add(SignalErrors, "", 1)
+	d.SetAlert(nil)
+	if d.Active() != nil {
+		t.Fatal("nil detector should have no anomalies")
+	}
+}
+
+func TestWebhookAlert(t *testing.T) {
+	var got Alert
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Errorf("decode: %v", err)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	alert := Alert{Anomaly: Anomaly{Signal: SignalPeerMessages, Key: "slack:U1", Value: 50}}
+	if err := WebhookAlert(server.URL, nil)(context.Background(), alert); err != nil {
+		t.Fatalf("WebhookAlert() error = %v", err)
+	}
+	if got.Key != "slack:U1" || got.Value != 50 {
+		t.Fatalf("webhook payload = %+v", got)
+	}
+
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer failing.Close()
+	if err := WebhookAlert(failing.URL, nil)(context.Background(), alert); err == nil {
+		t.Fatal("expected error for non-2xx response")
+	}
+}
@@ -0,0 +1,68 @@
+package anomaly
+
+import (
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// Metrics exports anomaly detection state. Peers are not used as labels, to
+// keep cardinality bounded; see the alert events for them.
+type Metrics struct {
+	// Active is the number of active anomalies.
+	// Labels: signal
+	Active *prometheus.GaugeVec
+
+	// Alerts counts anomalies that started.
+	// Labels: signal
+	Alerts *prometheus.CounterVec
+
+	// ZScore is the latest interval's score of a gateway-wide signal.
+	// Labels: signal (errors|tokens)
+	ZScore *prometheus.GaugeVec
+}
+
+var (
+	metricsOnce     sync.Once
+	metricsInstance *Metrics
+)
+
+// NewMetrics returns the process-wide anomaly metrics.
+func NewMetrics() *Metrics {
+	metricsOnce.Do(func() {
+		metricsInstance = &Metrics{
+			Active: promauto.NewGaugeVec(prometheus.GaugeOpts{
+				Name: "nexus_anomaly_active",
+				Help: "Number of active anomalies by signal",
+			}, []string{"signal"}),
+			Alerts: promauto.NewCounterVec(prometheus.CounterOpts{
+				Name: "nexus_anomaly_alerts_total",
+				Help: "Total number of anomalies detected by signal",
+			}, []string{"signal"}),
+			ZScore: promauto.NewGaugeVec(prometheus.GaugeOpts{
+				Name: "nexus_anomaly_z_score",
+				Help: "Z-score of the latest interval against its baseline",
+			}, []string{"signal"}),
+		}
+	})
+	return metricsInstance
+}
+
+func (m *Metrics) record(active []Anomaly, alerts []Alert) {
+	if m == nil {
+		return
+	}
+	counts := map[Signal]float64{SignalErrors: 0, SignalTokens: 0, SignalPeerMessages: 0}
+	for _, a := range active {
+		counts[a.Signal]++
+	}
+	for signal, n := range counts {
+		m.Active.WithLabelValues(string(signal)).Set(n)
+	}
+	for _, a := range alerts {
+		if !a.Resolved {
+			m.Alerts.WithLabelValues(string(a.Signal)).Inc()
+		}
+	}
+}
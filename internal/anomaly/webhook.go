@@ -0,0 +1,42 @@
+package anomaly
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// webhookTimeout bounds a single webhook delivery.
+const webhookTimeout = 10 * time.Second
+
+// WebhookAlert returns an AlertFunc that POSTs alerts as JSON to url.
+func WebhookAlert(url string, client *http.Client) AlertFunc {
+	if client == nil {
+		client = &http.Client{Timeout: webhookTimeout}
+	}
+	return func(ctx context.Context, alert Alert) error {
+		body, err := json.Marshal(alert)
+		if err != nil {
+			return err
+		}
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+		if err != nil {
+			return err
+		}
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := client.Do(req)
+		if err != nil {
+			return err
+		}
+		defer resp.Body.Close()
+		_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64*1024))
+		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+			return fmt.Errorf("anomaly webhook returned %s", resp.Status)
+		}
+		return nil
+	}
+}
@@ -4,13 +4,17 @@ import (
 	"context"
 	"log/slog"
 	"time"
+
+	"github.com/haasonsaas/nexus/internal/observability"
 )
 
-// CleanupService periodically removes expired artifacts.
+// CleanupService periodically removes expired artifacts and, when the
+// repository tracks references, artifacts no live session refers to.
 type CleanupService struct {
 	repo     Repository
 	interval time.Duration
 	logger   *slog.Logger
+	metrics  *observability.ArtifactGCMetrics
 	stopCh   chan struct{}
 }
 
@@ -26,6 +30,7 @@ func NewCleanupService(repo Repository, interval time.Duration, logger *slog.Log
 		repo:     repo,
 		interval: interval,
 		logger:   logger,
+		metrics:  observability.NewArtifactGCMetrics(),
 		stopCh:   make(chan struct{}),
 	}
 }
@@ -46,16 +51,36 @@ func (s *CleanupService) Start(ctx context.Context) {
 			s.logger.Info("artifact cleanup service stopping (signal)")
 			return
 		case <-ticker.C:
-			count, err := s.repo.PruneExpired(ctx)
-			if err != nil {
-				s.logger.Error("artifact cleanup failed", "error", err)
-			} else if count > 0 {
-				s.logger.Info("artifact cleanup completed", "pruned", count)
-			}
+			s.prune(ctx)
 		}
 	}
 }
 
+// prune runs one cleanup pass.
+func (s *CleanupService) prune(ctx context.Context) {
+	count, err := s.repo.PruneExpired(ctx)
+	if err != nil {
+		s.logger.Error("artifact cleanup failed", "error", err)
+	} else if count > 0 {
+		s.metrics.RecordPruned("expired", count, 0)
+		s.logger.Info("artifact cleanup completed", "pruned", count)
+	}
+
+	tracker, ok := s.repo.(ReferenceTracker)
+	if !ok {
+		return
+	}
+	result, err := tracker.PruneUnreferenced(ctx)
+	if err != nil {
+		s.logger.Error("unreferenced artifact cleanup failed", "error", err)
+		return
+	}
+	if result.Count > 0 {
+		s.metrics.RecordPruned("unreferenced", result.Count, result.Bytes)
+		s.logger.Info("unreferenced artifact cleanup completed", "pruned", result.Count, "bytes", result.Bytes)
+	}
+}
+
 // Stop signals the cleanup service to stop.
 func (s *CleanupService) Stop() {
 	close(s.stopCh)
@@ -0,0 +1,153 @@
+package artifacts
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/haasonsaas/nexus/internal/storage/encryption"
+)
+
+// reencryptBatchSize bounds how many metadata rows are read per query when
+// migrating filenames to the primary key.
+const reencryptBatchSize = 500
+
+// EncryptedRepository is implemented by repositories that can encrypt
+// artifact metadata at rest.
+type EncryptedRepository interface {
+	// SetKeyring makes the repository encrypt filenames on write and decrypt
+	// them on read.
+	SetKeyring(keyring *encryption.Keyring) error
+
+	// ReencryptMetadata rewrites every filename that is plaintext or
+	// encrypted with a retired key so it is encrypted with the primary key.
+	// With dryRun set it only counts them.
+	ReencryptMetadata(ctx context.Context, dryRun bool) (int64, error)
+}
+
+// SetKeyring implements EncryptedRepository.
+func (r *SQLRepository) SetKeyring(keyring *encryption.Keyring) error {
+	r.keyring = keyring
+	return nil
+}
+
+// ReencryptMetadata implements EncryptedRepository.
+func (r *SQLRepository) ReencryptMetadata(ctx context.Context, dryRun bool) (int64, error) {
+	if r.keyring == nil {
+		return 0, fmt.Errorf("artifact encryption is not configured")
+	}
+	var (
+		total  int64
+		cursor string
+	)
+	for {
+		rows, err := r.db.QueryContext(ctx,
+			"SELECT id, filename FROM artifacts WHERE id > $1 ORDER BY id LIMIT $2", cursor, reencryptBatchSize)
+		if err != nil {
+			return total, fmt.Errorf("list artifacts: %w", err)
+		}
+		type pending struct{ id, filename string }
+		var (
+			stale []pending
+			count int
+		)
+		for rows.Next() {
+			var (
+				id       string
+				filename sql.NullString
+			)
+			if err := rows.Scan(&id, &filename); err != nil {
+				rows.Close()
+				return total, fmt.Errorf("scan artifact metadata: %w", err)
+			}
+			count++
+			cursor = id
+			if r.keyring.NeedsRewrap(filename.String) {
+				stale = append(stale, pending{id: id, filename: filename.String})
+			}
+		}
+		if err := rows.Err(); err != nil {
+			rows.Close()
+			return total, fmt.Errorf("list artifacts: %w", err)
+		}
+		rows.Close()
+
+		for _, meta := range stale {
+			if dryRun {
+				total++
+				continue
+			}
+			filename, _, err := r.keyring.Rewrap(meta.filename)
+			if err != nil {
+				return total, fmt.Errorf("artifact %s: %w", meta.id, err)
+			}
+			result, err := r.db.ExecContext(ctx,
+				"UPDATE artifacts SET filename = $1 WHERE id = $2 AND filename = $3", filename, meta.id, meta.filename)
+			if err != nil {
+				return total, fmt.Errorf("update artifact %s: %w", meta.id, err)
+			}
+			if n, err := result.RowsAffected(); err == nil {
+				total += n
+			}
+		}
+
+		if count < reencryptBatchSize {
+			return total, nil
+		}
+	}
+}
+
+// SetKeyring implements EncryptedRepository. Filenames already loaded from
+// an encrypted metadata file are decrypted with the new keyring.
+func (r *PersistentRepository) SetKeyring(keyring *encryption.Keyring) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for id, meta := range r.metadata {
+		filename, err := keyring.Decrypt(meta.Filename)
+		if err != nil {
+			return fmt.Errorf("decrypt artifact %s filename: %w", id, err)
+		}
+		meta.Filename = filename
+	}
+	r.keyring = keyring
+	return nil
+}
+
+// ReencryptMetadata implements EncryptedRepository. The metadata file is
+// rewritten as a whole, so every filename ends up under the primary key.
+func (r *PersistentRepository) ReencryptMetadata(ctx context.Context, dryRun bool) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.keyring == nil {
+		return 0, fmt.Errorf("artifact encryption is not configured")
+	}
+
+	data, err := os.ReadFile(r.metadataPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return 0, nil
+		}
+		return 0, fmt.Errorf("read artifact metadata: %w", err)
+	}
+	var stored persistedMetadata
+	if len(data) > 0 {
+		if err := json.Unmarshal(data, &stored); err != nil {
+			return 0, fmt.Errorf("parse artifact metadata: %w", err)
+		}
+	}
+	var stale int64
+	for _, meta := range stored.Artifacts {
+		if r.keyring.NeedsRewrap(meta.Filename) {
+			stale++
+		}
+	}
+	if dryRun || stale == 0 {
+		return stale, nil
+	}
+	if err := r.persistLocked(); err != nil {
+		return 0, fmt.Errorf("persist artifact metadata: %w", err)
+	}
+	return stale, nil
+}
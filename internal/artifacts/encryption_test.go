@@ -0,0 +1,155 @@
+package artifacts
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/haasonsaas/nexus/internal/storage/encryption"
+	"github.com/haasonsaas/nexus/internal/storage/sqlite"
+	pb "github.com/haasonsaas/nexus/pkg/proto"
+)
+
+func newTestKeyring(t *testing.T, primary string, ids ...string) *encryption.Keyring {
+	t.Helper()
+	keys := make(map[string][]byte, len(ids))
+	for _, id := range ids {
+		keys[id] = bytes.Repeat([]byte{id[len(id)-1]}, encryption.KeySize)
+	}
+	keyring, err := encryption.NewKeyring(primary, keys)
+	if err != nil {
+		t.Fatalf("NewKeyring: %v", err)
+	}
+	return keyring
+}
+
+func storeTestArtifact(t *testing.T, repo Repository, id, filename string) {
+	t.Helper()
+	payload := []byte("data")
+	if err := repo.StoreArtifact(context.Background(), &pb.Artifact{
+		Id: id, Type: "file", MimeType: "text/plain", Filename: filename, Size: int64(len(payload)),
+	}, bytes.NewReader(payload)); err != nil {
+		t.Fatalf("StoreArtifact: %v", err)
+	}
+}
+
+func TestSQLRepository_EncryptsFilenames(t *testing.T) {
+	dir := t.TempDir()
+	store, err := NewLocalStore(filepath.Join(dir, "data"))
+	if err != nil {
+		t.Fatalf("NewLocalStore: %v", err)
+	}
+	db, err := sqlite.Open("sqlite://" + filepath.Join(dir, "nexus.db"))
+	if err != nil {
+		t.Fatalf("sqlite.Open: %v", err)
+	}
+	repo, err := NewSQLiteRepository(db, store, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	if err != nil {
+		t.Fatalf("NewSQLiteRepository: %v", err)
+	}
+	defer repo.Close()
+	ctx := context.Background()
+
+	storeTestArtifact(t, repo, "legacy", "diagnosis.txt")
+	if err := repo.SetKeyring(newTestKeyring(t, "k1", "k1")); err != nil {
+		t.Fatalf("SetKeyring: %v", err)
+	}
+	storeTestArtifact(t, repo, "sealed", "medical-record.pdf")
+
+	var raw string
+	if err := db.QueryRow("SELECT filename FROM artifacts WHERE id = $1", "sealed").Scan(&raw); err != nil {
+		t.Fatalf("query filename: %v", err)
+	}
+	if !encryption.IsEncrypted(raw) || strings.Contains(raw, "medical") {
+		t.Fatalf("expected encrypted filename at rest, got %q", raw)
+	}
+	got, reader, err := repo.GetArtifact(ctx, "sealed")
+	if err != nil {
+		t.Fatalf("GetArtifact: %v", err)
+	}
+	reader.Close()
+	if got.Filename != "medical-record.pdf" {
+		t.Fatalf("expected decrypted filename, got %q", got.Filename)
+	}
+
+	pending, err := repo.ReencryptMetadata(ctx, true)
+	if err != nil || pending != 1 {
+		t.Fatalf("ReencryptMetadata(dryRun) = %d, %v", pending, err)
+	}
+	migrated, err := repo.ReencryptMetadata(ctx, false)
+	if err != nil || migrated != 1 {
+		t.Fatalf("ReencryptMetadata = %d, %v", migrated, err)
+	}
+	listed, err := repo.ListArtifacts(ctx, Filter{})
+	if err != nil {
+		t.Fatalf("ListArtifacts: %v", err)
+	}
+	for _, artifact := range listed {
+		if encryption.IsEncrypted(artifact.Filename) {
+			t.Fatalf("expected decrypted filename in listing, got %q", artifact.Filename)
+		}
+	}
+}
+
+func TestPersistentRepository_EncryptsFilenames(t *testing.T) {
+	dir := t.TempDir()
+	metadataPath := filepath.Join(dir, "metadata.json")
+	open := func() *PersistentRepository {
+		store, err := NewLocalStore(filepath.Join(dir, "data"))
+		if err != nil {
+			t.Fatalf("NewLocalStore: %v", err)
+		}
+		repo, err := NewPersistentRepository(store, metadataPath, slog.New(slog.NewTextHandler(io.Discard, nil)))
+		if err != nil {
+			t.Fatalf("NewPersistentRepository: %v", err)
+		}
+		return repo
+	}
+
+	repo := open()
+	storeTestArtifact(t, repo, "legacy", "diagnosis.txt")
+	if err := repo.SetKeyring(newTestKeyring(t, "k1", "k1")); err != nil {
+		t.Fatalf("SetKeyring: %v", err)
+	}
+	pending, err := repo.ReencryptMetadata(context.Background(), true)
+	if err != nil || pending != 1 {
+		t.Fatalf("ReencryptMetadata(dryRun) = %d, %v", pending, err)
+	}
+	if _, err := repo.ReencryptMetadata(context.Background(), false); err != nil {
+		t.Fatalf("ReencryptMetadata: %v", err)
+	}
+	repo.Close()
+
+	data, err := os.ReadFile(metadataPath)
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+	if strings.Contains(string(data), "diagnosis") {
+		t.Fatalf("expected filename to be encrypted on disk: %s", data)
+	}
+
+	reopened := open()
+	defer reopened.Close()
+	if err := reopened.SetKeyring(newTestKeyring(t, "k2", "k1", "k2")); err != nil {
+		t.Fatalf("SetKeyring: %v", err)
+	}
+	got, reader, err := reopened.GetArtifact(context.Background(), "legacy")
+	if err != nil {
+		t.Fatalf("GetArtifact: %v", err)
+	}
+	reader.Close()
+	if got.Filename != "diagnosis.txt" {
+		t.Fatalf("expected decrypted filename, got %q", got.Filename)
+	}
+
+	wrongKey := open()
+	defer wrongKey.Close()
+	if err := wrongKey.SetKeyring(newTestKeyring(t, "other", "other")); err == nil {
+		t.Fatalf("expected SetKeyring without the encrypting key to fail")
+	}
+}
@@ -0,0 +1,94 @@
+package artifacts
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// LinkPathPrefix is the gateway path that serves artifacts to link holders.
+const LinkPathPrefix = "/artifacts/"
+
+var (
+	// ErrLinkInvalid is returned for links with a missing or wrong signature.
+	ErrLinkInvalid = errors.New("artifact link signature is invalid")
+
+	// ErrLinkExpired is returned for correctly signed links past their expiry.
+	ErrLinkExpired = errors.New("artifact link has expired")
+)
+
+// LinkSigner creates and verifies expiring, HMAC-SHA256 signed download
+// links, so channel messages can point at large artifacts instead of
+// attaching them.
+type LinkSigner struct {
+	key     []byte
+	baseURL string
+	ttl     time.Duration
+	maxTTL  time.Duration
+	now     func() time.Time
+}
+
+// NewLinkSigner creates a signer. Links are built on baseURL (the gateway's
+// externally reachable address), last ttl by default, and never longer than
+// maxTTL when maxTTL is positive.
+func NewLinkSigner(key []byte, baseURL string, ttl, maxTTL time.Duration) (*LinkSigner, error) {
+	if len(key) == 0 {
+		return nil, errors.New("artifact link signing key is required")
+	}
+	if ttl <= 0 {
+		ttl = time.Hour
+	}
+	return &LinkSigner{
+		key:     key,
+		baseURL: strings.TrimRight(baseURL, "/"),
+		ttl:     ttl,
+		maxTTL:  maxTTL,
+		now:     time.Now,
+	}, nil
+}
+
+// URL returns a signed link to the artifact and when it expires. A
+// non-positive ttl uses the default; ttl is capped at the maximum.
+func (s *LinkSigner) URL(artifactID string, ttl time.Duration) (string, time.Time) {
+	if ttl <= 0 {
+		ttl = s.ttl
+	}
+	if s.maxTTL > 0 && ttl > s.maxTTL {
+		ttl = s.maxTTL
+	}
+	expires := s.now().Add(ttl).Truncate(time.Second)
+	query := url.Values{}
+	query.Set("expires", strconv.FormatInt(expires.Unix(), 10))
+	query.Set("sig", s.sign(artifactID, expires.Unix()))
+	return s.baseURL + LinkPathPrefix + url.PathEscape(artifactID) + "?" + query.Encode(), expires
+}
+
+// Verify checks the expires and sig query parameters of a link to the
+// artifact and returns the link's expiry.
+func (s *LinkSigner) Verify(artifactID string, query url.Values) (time.Time, error) {
+	expiresUnix, err := strconv.ParseInt(query.Get("expires"), 10, 64)
+	if err != nil {
+		return time.Time{}, ErrLinkInvalid
+	}
+	expected := s.sign(artifactID, expiresUnix)
+	if !hmac.Equal([]byte(expected), []byte(query.Get("sig"))) {
+		return time.Time{}, ErrLinkInvalid
+	}
+	expires := time.Unix(expiresUnix, 0)
+	if !s.now().Before(expires) {
+		return expires, ErrLinkExpired
+	}
+	return expires, nil
+}
+
+func (s *LinkSigner) sign(artifactID string, expires int64) string {
+	mac := hmac.New(sha256.New, s.key)
+	fmt.Fprintf(mac, "%s\n%d", artifactID, expires)
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,68 @@
+package artifacts
+
+import (
+	"errors"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLinkSigner_RoundTrip(t *testing.T) {
+	signer, err := NewLinkSigner([]byte("secret"), "https://nexus.example.com/", time.Hour, 24*time.Hour)
+	if err != nil {
+		t.Fatalf("NewLinkSigner: %v", err)
+	}
+	now := time.Unix(1_700_000_000, 0)
+	signer.now = func() time.Time { return now }
+
+	link, expires := signer.URL("art/1", 0)
+	if !expires.Equal(now.Add(time.Hour)) {
+		t.Fatalf("expected default ttl, got expiry %v", expires)
+	}
+	if !strings.HasPrefix(link, "https://nexus.example.com/artifacts/art%2F1?") {
+		t.Fatalf("unexpected link %q", link)
+	}
+	parsed, err := url.Parse(link)
+	if err != nil {
+		t.Fatalf("parse link: %v", err)
+	}
+	if _, err := signer.Verify("art/1", parsed.Query()); err != nil {
+		t.Fatalf("Verify: %v", err)
+	}
+	if _, err := signer.Verify("art/2", parsed.Query()); !errors.Is(err, ErrLinkInvalid) {
+		t.Fatalf("expected ErrLinkInvalid for another artifact, got %v", err)
+	}
+
+	tampered := parsed.Query()
+	tampered.Set("expires", "9999999999")
+	if _, err := signer.Verify("art/1", tampered); !errors.Is(err, ErrLinkInvalid) {
+		t.Fatalf("expected ErrLinkInvalid for extended expiry, got %v", err)
+	}
+
+	other, _ := NewLinkSigner([]byte("other"), "", time.Hour, 0)
+	if _, err := other.Verify("art/1", parsed.Query()); !errors.Is(err, ErrLinkInvalid) {
+		t.Fatalf("expected ErrLinkInvalid for another key, got %v", err)
+	}
+
+	now = now.Add(2 * time.Hour)
+	if _, err := signer.Verify("art/1", parsed.Query()); !errors.Is(err, ErrLinkExpired) {
+		t.Fatalf("expected ErrLinkExpired, got %v", err)
+	}
+}
+
+func TestLinkSigner_CapsTTL(t *testing.T) {
+	signer, err := NewLinkSigner([]byte("secret"), "", time.Hour, 2*time.Hour)
+	if err != nil {
+		t.Fatalf("NewLinkSigner: %v", err)
+	}
+	now := time.Unix(1_700_000_000, 0)
+	signer.now = func() time.Time { return now }
+
+	if _, expires := signer.URL("a", 48*time.Hour); !expires.Equal(now.Add(2 * time.Hour)) {
+		t.Fatalf("expected ttl capped at 2h, got %v", expires.Sub(now))
+	}
+	if _, err := NewLinkSigner(nil, "", 0, 0); err == nil {
+		t.Fatalf("expected error for empty key")
+	}
+}
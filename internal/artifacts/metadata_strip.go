@@ -0,0 +1,102 @@
+package artifacts
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+)
+
+var (
+	pngSignature = []byte{0x89, 'P', 'N', 'G', '\r', '\n', 0x1a, '\n'}
+
+	errMalformedImage = errors.New("malformed image")
+)
+
+// stripImageMetadata removes EXIF, XMP, IPTC, comments, and text chunks from
+// JPEG and PNG data without re-encoding pixels. Other formats are returned
+// unchanged.
+func stripImageMetadata(data []byte) ([]byte, bool, error) {
+	switch {
+	case len(data) > 2 && data[0] == 0xFF && data[1] == 0xD8:
+		return stripJPEGMetadata(data)
+	case bytes.HasPrefix(data, pngSignature):
+		return stripPNGMetadata(data)
+	default:
+		return data, false, nil
+	}
+}
+
+// stripJPEGMetadata drops APP1 (EXIF/XMP), APP13 (IPTC), and COM segments.
+// JFIF, ICC profiles, and Adobe segments are kept since they affect rendering.
+func stripJPEGMetadata(data []byte) ([]byte, bool, error) {
+	out := make([]byte, 0, len(data))
+	out = append(out, data[:2]...)
+	stripped := false
+	pos := 2
+	for pos < len(data) {
+		if data[pos] != 0xFF || pos+1 >= len(data) {
+			return nil, false, errMalformedImage
+		}
+		marker := data[pos+1]
+		switch {
+		case marker == 0xFF:
+			// Fill byte.
+			pos++
+			continue
+		case marker == 0xD9 || marker == 0x01 || (marker >= 0xD0 && marker <= 0xD7):
+			out = append(out, data[pos:pos+2]...)
+			pos += 2
+			continue
+		case marker == 0xDA:
+			// Start of scan: entropy-coded data follows; copy the remainder.
+			return append(out, data[pos:]...), stripped, nil
+		}
+		if pos+4 > len(data) {
+			return nil, false, errMalformedImage
+		}
+		end := pos + 2 + int(binary.BigEndian.Uint16(data[pos+2:pos+4]))
+		if end > len(data) {
+			return nil, false, errMalformedImage
+		}
+		if marker == 0xE1 || marker == 0xED || marker == 0xFE {
+			stripped = true
+		} else {
+			out = append(out, data[pos:end]...)
+		}
+		pos = end
+	}
+	return out, stripped, nil
+}
+
+// pngMetadataChunks are ancillary chunks that can carry personal data.
+var pngMetadataChunks = map[string]bool{
+	"eXIf": true,
+	"tEXt": true,
+	"zTXt": true,
+	"iTXt": true,
+	"tIME": true,
+}
+
+func stripPNGMetadata(data []byte) ([]byte, bool, error) {
+	out := make([]byte, 0, len(data))
+	out = append(out, pngSignature...)
+	stripped := false
+	pos := len(pngSignature)
+	for pos < len(data) {
+		if pos+8 > len(data) {
+			return nil, false, errMalformedImage
+		}
+		length := int(binary.BigEndian.Uint32(data[pos : pos+4]))
+		end := pos + 12 + length
+		if length < 0 || end > len(data) {
+			return nil, false, errMalformedImage
+		}
+		if pngMetadataChunks[string(data[pos+4:pos+8])] {
+			stripped = true
+		} else {
+			out = append(out, data[pos:end]...)
+		}
+		pos = end
+	}
+	return out, stripped, nil
+}
@@ -15,6 +15,7 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/haasonsaas/nexus/internal/observability"
+	"github.com/haasonsaas/nexus/internal/storage/encryption"
 	pb "github.com/haasonsaas/nexus/pkg/proto"
 )
 
@@ -25,6 +26,10 @@ type PersistentRepository struct {
 	metadata     map[string]*Metadata
 	metadataPath string
 	logger       *slog.Logger
+
+	// keyring encrypts filenames in the metadata file when set. Metadata is
+	// held decrypted in memory.
+	keyring *encryption.Keyring
 }
 
 type persistedMetadata struct {
@@ -94,6 +99,7 @@ func (r *PersistentRepository) StoreArtifact(ctx context.Context, artifact *pb.A
 	if edgeID := observability.GetEdgeID(ctx); edgeID != "" {
 		meta.EdgeID = edgeID
 	}
+	meta.References = initialReferences(meta.SessionID)
 
 	ttl := time.Duration(artifact.TtlSeconds) * time.Second
 	if ttl == 0 {
@@ -336,6 +342,18 @@ func (r *PersistentRepository) persistLocked() error {
 		Version:   1,
 		Artifacts: r.metadata,
 	}
+	if r.keyring != nil {
+		state.Artifacts = make(map[string]*Metadata, len(r.metadata))
+		for id, meta := range r.metadata {
+			sealed := *meta
+			filename, err := r.keyring.Encrypt(meta.Filename)
+			if err != nil {
+				return fmt.Errorf("encrypt artifact %s filename: %w", id, err)
+			}
+			sealed.Filename = filename
+			state.Artifacts[id] = &sealed
+		}
+	}
 	data, err := json.MarshalIndent(state, "", "  ")
 	if err != nil {
 		return err
@@ -350,3 +368,77 @@ func (r *PersistentRepository) persistLocked() error {
 	}
 	return os.Rename(tmpPath, r.metadataPath)
 }
+
+// AddReference records that a session or message refers to an artifact.
+func (r *PersistentRepository) AddReference(ctx context.Context, artifactID, sessionID, messageID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	meta, ok := r.metadata[artifactID]
+	if !ok || sessionID == "" {
+		return nil
+	}
+	previous := append([]Reference(nil), meta.References...)
+	meta.addReference(sessionID, messageID)
+	if err := r.persistLocked(); err != nil {
+		meta.References = previous
+		return fmt.Errorf("persist artifact reference: %w", err)
+	}
+	return nil
+}
+
+// ReleaseSession releases every reference held by a session.
+func (r *PersistentRepository) ReleaseSession(ctx context.Context, sessionID string) error {
+	now := time.Now()
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	changed := false
+	for _, meta := range r.metadata {
+		if meta.releaseSession(sessionID, now) {
+			changed = true
+		}
+	}
+	if !changed {
+		return nil
+	}
+	if err := r.persistLocked(); err != nil {
+		return fmt.Errorf("persist released artifact references: %w", err)
+	}
+	return nil
+}
+
+// PruneUnreferenced removes artifacts whose references were all released.
+func (r *PersistentRepository) PruneUnreferenced(ctx context.Context) (PruneResult, error) {
+	r.mu.RLock()
+	candidates := make(map[string]int64)
+	for id, meta := range r.metadata {
+		if meta.unreferenced() {
+			candidates[id] = meta.Size
+		}
+	}
+	r.mu.RUnlock()
+
+	var result PruneResult
+	for id, size := range candidates {
+		if err := r.DeleteArtifact(ctx, id); err == nil {
+			result.Count++
+			result.Bytes += size
+		}
+	}
+	return result, nil
+}
+
+// PresignArtifact returns a direct download URL when the artifact's data is
+// held by a presigning store.
+func (r *PersistentRepository) PresignArtifact(ctx context.Context, artifactID string, opts PresignOptions) (string, time.Time, error) {
+	r.mu.RLock()
+	meta, ok := r.metadata[artifactID]
+	var snapshot Metadata
+	if ok {
+		snapshot = *meta
+	}
+	r.mu.RUnlock()
+	if !ok {
+		return "", time.Time{}, fmt.Errorf("artifact not found: %s", artifactID)
+	}
+	return presignMetadata(ctx, r.store, &snapshot, opts)
+}
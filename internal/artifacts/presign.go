@@ -0,0 +1,69 @@
+package artifacts
+
+import (
+	"context"
+	"errors"
+	"mime"
+	"strings"
+	"time"
+)
+
+// ErrPresignUnsupported is returned when an artifact cannot be served
+// straight from its storage backend, for example because it is held inline
+// or redacted, or the store does not presign.
+var ErrPresignUnsupported = errors.New("artifact presigning not supported")
+
+// PresignOptions configures a presigned download URL.
+type PresignOptions struct {
+	// TTL is how long the URL stays valid; non-positive uses the store default.
+	TTL time.Duration
+
+	// ContentType overrides the Content-Type of the download.
+	ContentType string
+
+	// ContentDisposition overrides the Content-Disposition of the download.
+	ContentDisposition string
+
+	// Download asks repositories to serve the artifact as an attachment
+	// named after its filename.
+	Download bool
+}
+
+// StorePresigner is implemented by stores that can issue time-limited URLs
+// for downloading data directly from the backend.
+type StorePresigner interface {
+	PresignGet(ctx context.Context, artifactID string, opts PresignOptions) (url string, expires time.Time, err error)
+}
+
+// Presigner is implemented by repositories that can hand out direct
+// download URLs, so large artifacts bypass the gateway.
+type Presigner interface {
+	// PresignArtifact returns a direct download URL for the artifact and
+	// when it expires, or ErrPresignUnsupported.
+	PresignArtifact(ctx context.Context, artifactID string, opts PresignOptions) (url string, expires time.Time, err error)
+}
+
+// presignMetadata presigns the artifact described by meta when its data is
+// held by store. URLs never outlive the artifact.
+func presignMetadata(ctx context.Context, store Store, meta *Metadata, opts PresignOptions) (string, time.Time, error) {
+	presigner, ok := store.(StorePresigner)
+	if !ok || meta.Reference == "" || strings.HasPrefix(meta.Reference, "inline://") || strings.HasPrefix(meta.Reference, "redacted://") {
+		return "", time.Time{}, ErrPresignUnsupported
+	}
+	if !meta.ExpiresAt.IsZero() {
+		remaining := time.Until(meta.ExpiresAt)
+		if remaining <= 0 {
+			return "", time.Time{}, ErrPresignUnsupported
+		}
+		if opts.TTL > remaining {
+			opts.TTL = remaining
+		}
+	}
+	if opts.ContentType == "" {
+		opts.ContentType = meta.MimeType
+	}
+	if opts.ContentDisposition == "" && opts.Download && meta.Filename != "" {
+		opts.ContentDisposition = mime.FormatMediaType("attachment", map[string]string{"filename": meta.Filename})
+	}
+	return presigner.PresignGet(ctx, meta.ID, opts)
+}
@@ -0,0 +1,488 @@
+package artifacts
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"image"
+	_ "image/gif" // Register GIF decoder
+	"image/jpeg"
+	_ "image/png" // Register PNG decoder
+	"io"
+	"log/slog"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"golang.org/x/image/draw"
+	_ "golang.org/x/image/webp" // Register WebP decoder
+
+	pb "github.com/haasonsaas/nexus/pkg/proto"
+)
+
+// MIME validation modes.
+const (
+	MIMEValidationOff     = "off"
+	MIMEValidationCorrect = "correct"
+	MIMEValidationReject  = "reject"
+)
+
+// ThumbnailType is the artifact type of generated thumbnails.
+const ThumbnailType = "thumbnail"
+
+// OCRTextType is the artifact type of text extracted from images.
+const OCRTextType = "ocr_text"
+
+// OCRTextID returns the ID of the OCR text derived from an image artifact.
+func OCRTextID(artifactID string) string {
+	return artifactID + "-ocr"
+}
+
+// ErrMIMEMismatch is returned when an artifact's content does not match its
+// declared MIME type and validation is set to reject.
+var ErrMIMEMismatch = errors.New("artifact content does not match declared MIME type")
+
+// ProcessingConfig defines the post-processing applied before storage.
+type ProcessingConfig struct {
+	Enabled          bool
+	MIMEValidation   string
+	FFmpegPath       string
+	TranscodeTimeout time.Duration
+	// TextExtractor runs OCR for rules with OCR set; nil disables it.
+	TextExtractor TextExtractor
+	// Rules are keyed by artifact type; "default" applies to other types.
+	Rules map[string]ProcessingRule
+}
+
+// ProcessingRule configures processing for one artifact type.
+type ProcessingRule struct {
+	StripEXIF        bool
+	Thumbnail        bool
+	ThumbnailMaxSide int
+	Transcode        bool
+	MaxVideoBytes    int64
+	MaxVideoHeight   int
+	// OCR stores the text found in the image as a separate "ocr_text"
+	// artifact.
+	OCR bool
+}
+
+// TextExtractor reads text from images.
+type TextExtractor interface {
+	ExtractText(ctx context.Context, image io.Reader, mimeType string) (string, error)
+}
+
+// DerivedArtifact is an extra artifact produced while processing, such as a
+// thumbnail.
+type DerivedArtifact struct {
+	Artifact *pb.Artifact
+	Data     []byte
+}
+
+// videoTranscoder re-encodes video to mp4 at the given height and CRF.
+type videoTranscoder func(ctx context.Context, input []byte, maxHeight, crf int) ([]byte, error)
+
+// Processor validates and transforms artifact data before it is stored.
+type Processor struct {
+	mimeValidation string
+	timeout        time.Duration
+	rules          map[string]ProcessingRule
+	transcode      videoTranscoder
+	ocr            TextExtractor
+	logger         *slog.Logger
+}
+
+// NewProcessor builds a processor from config. It returns nil when
+// processing is disabled.
+func NewProcessor(cfg ProcessingConfig, logger *slog.Logger) *Processor {
+	if !cfg.Enabled {
+		return nil
+	}
+	if logger == nil {
+		logger = slog.Default()
+	}
+	p := &Processor{
+		mimeValidation: strings.ToLower(strings.TrimSpace(cfg.MIMEValidation)),
+		timeout:        cfg.TranscodeTimeout,
+		rules:          make(map[string]ProcessingRule, len(cfg.Rules)),
+		ocr:            cfg.TextExtractor,
+		logger:         logger.With("component", "artifact-processing"),
+	}
+	if p.mimeValidation == "" {
+		p.mimeValidation = MIMEValidationCorrect
+	}
+	if p.timeout <= 0 {
+		p.timeout = 2 * time.Minute
+	}
+	needsFFmpeg := false
+	for artifactType, rule := range cfg.Rules {
+		if rule.ThumbnailMaxSide <= 0 {
+			rule.ThumbnailMaxSide = 320
+		}
+		if rule.MaxVideoBytes <= 0 {
+			rule.MaxVideoBytes = 25 * 1024 * 1024
+		}
+		if rule.MaxVideoHeight <= 0 {
+			rule.MaxVideoHeight = 720
+		}
+		needsFFmpeg = needsFFmpeg || rule.Transcode
+		p.rules[strings.ToLower(strings.TrimSpace(artifactType))] = rule
+	}
+	if needsFFmpeg {
+		ffmpegPath := strings.TrimSpace(cfg.FFmpegPath)
+		if ffmpegPath == "" {
+			ffmpegPath = "ffmpeg"
+		}
+		if resolved, err := exec.LookPath(ffmpegPath); err != nil {
+			p.logger.Warn("ffmpeg not found; video transcoding disabled", "path", ffmpegPath, "error", err)
+		} else {
+			p.transcode = ffmpegTranscoder(resolved)
+		}
+	}
+	return p
+}
+
+func (p *Processor) ruleFor(artifactType string) ProcessingRule {
+	if rule, ok := p.rules[strings.ToLower(artifactType)]; ok {
+		return rule
+	}
+	return p.rules["default"]
+}
+
+// Process validates the artifact's MIME type and applies the rule for its
+// type. It updates the artifact's MimeType, Filename, and Size to match the
+// returned data, and returns any derived artifacts to store alongside it.
+func (p *Processor) Process(ctx context.Context, artifact *pb.Artifact, data []byte) ([]byte, []DerivedArtifact, error) {
+	if p == nil || artifact == nil || len(data) == 0 {
+		return data, nil, nil
+	}
+	if err := p.validateMIME(artifact, data); err != nil {
+		return nil, nil, err
+	}
+
+	rule := p.ruleFor(artifact.Type)
+	mimeType := strings.ToLower(artifact.MimeType)
+	var derived []DerivedArtifact
+
+	if strings.HasPrefix(mimeType, "image/") {
+		if rule.StripEXIF {
+			clean, stripped, err := stripImageMetadata(data)
+			if err != nil {
+				p.logger.Warn("metadata strip failed", "id", artifact.Id, "error", err)
+			} else if stripped {
+				data = clean
+			}
+		}
+		if rule.Thumbnail && artifact.Type != ThumbnailType {
+			thumb, err := makeThumbnail(data, rule.ThumbnailMaxSide)
+			if err != nil {
+				p.logger.Debug("thumbnail skipped", "id", artifact.Id, "error", err)
+			} else if thumb != nil {
+				derived = append(derived, DerivedArtifact{
+					Artifact: &pb.Artifact{
+						Id:         artifact.Id + "-thumb",
+						Type:       ThumbnailType,
+						MimeType:   "image/jpeg",
+						Filename:   withExtension(artifact.Filename, ".thumb.jpg"),
+						Size:       int64(len(thumb)),
+						TtlSeconds: artifact.TtlSeconds,
+					},
+					Data: thumb,
+				})
+			}
+		}
+		if rule.OCR && p.ocr != nil {
+			if text := p.extractText(ctx, artifact, data); text != "" {
+				derived = append(derived, DerivedArtifact{
+					Artifact: &pb.Artifact{
+						Id:         OCRTextID(artifact.Id),
+						Type:       OCRTextType,
+						MimeType:   "text/plain",
+						Filename:   withExtension(artifact.Filename, ".ocr.txt"),
+						Size:       int64(len(text)),
+						TtlSeconds: artifact.TtlSeconds,
+					},
+					Data: []byte(text),
+				})
+			}
+		}
+	}
+
+	if strings.HasPrefix(mimeType, "video/") && rule.Transcode && int64(len(data)) > rule.MaxVideoBytes {
+		if out := p.transcodeVideo(ctx, artifact.Id, data, rule); out != nil {
+			data = out
+			artifact.MimeType = "video/mp4"
+			artifact.Filename = withExtension(artifact.Filename, ".mp4")
+		}
+	}
+
+	artifact.Size = int64(len(data))
+	return data, derived, nil
+}
+
+// extractText runs OCR on an image. Failures are logged and yield "".
+func (p *Processor) extractText(ctx context.Context, artifact *pb.Artifact, data []byte) string {
+	text, err := p.ocr.ExtractText(ctx, bytes.NewReader(data), artifact.MimeType)
+	if err != nil {
+		p.logger.Warn("ocr failed", "id", artifact.Id, "error", err)
+		return ""
+	}
+	return strings.TrimSpace(text)
+}
+
+// validateMIME compares the declared MIME type with the sniffed content type.
+func (p *Processor) validateMIME(artifact *pb.Artifact, data []byte) error {
+	if p.mimeValidation == MIMEValidationOff {
+		return nil
+	}
+	sniffed := normalizeMIME(http.DetectContentType(data))
+	declared := normalizeMIME(artifact.MimeType)
+	if mimeCompatible(declared, sniffed) {
+		if declared == "" || declared == "application/octet-stream" {
+			artifact.MimeType = sniffed
+		}
+		return nil
+	}
+	if p.mimeValidation == MIMEValidationReject {
+		return fmt.Errorf("%w: declared %s, detected %s", ErrMIMEMismatch, declared, sniffed)
+	}
+	p.logger.Warn("correcting artifact MIME type", "id", artifact.Id, "declared", declared, "detected", sniffed)
+	artifact.MimeType = sniffed
+	return nil
+}
+
+func normalizeMIME(value string) string {
+	if idx := strings.Index(value, ";"); idx != -1 {
+		value = value[:idx]
+	}
+	return strings.ToLower(strings.TrimSpace(value))
+}
+
+// mimeCompatible reports whether sniffed content plausibly matches the
+// declared type. Sniffing only recognizes a limited set of signatures, so
+// generic results never count as a mismatch.
+func mimeCompatible(declared, sniffed string) bool {
+	switch {
+	case declared == "" || declared == "application/octet-stream":
+		return true
+	case sniffed == declared || sniffed == "application/octet-stream":
+		return true
+	case sniffed == "text/plain":
+		// Any text format: JSON, YAML, CSV, source code, logs.
+		if declared == "image/svg+xml" {
+			return true
+		}
+		return !strings.HasPrefix(declared, "image/") && !strings.HasPrefix(declared, "video/") && !strings.HasPrefix(declared, "audio/")
+	case sniffed == "application/zip":
+		// Office documents, archives, and other zip containers.
+		return strings.HasPrefix(declared, "application/")
+	case sniffed == "text/xml":
+		return declared == "image/svg+xml" || strings.HasSuffix(declared, "+xml") || declared == "application/xml"
+	case sniffed == "audio/wave" || sniffed == "audio/wav":
+		return strings.HasPrefix(declared, "audio/")
+	case strings.HasPrefix(sniffed, "video/") && strings.HasPrefix(declared, "audio/"):
+		// Container formats (mp4, webm) also carry audio-only streams.
+		return true
+	}
+	return false
+}
+
+// makeThumbnail scales an image to fit within maxSide and encodes it as JPEG.
+// It returns nil when the image already fits.
+func makeThumbnail(data []byte, maxSide int) ([]byte, error) {
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	bounds := img.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	if width <= maxSide && height <= maxSide {
+		return nil, nil
+	}
+	if width >= height {
+		height = max(1, height*maxSide/width)
+		width = maxSide
+	} else {
+		width = max(1, width*maxSide/height)
+		height = maxSide
+	}
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.CatmullRom.Scale(dst, dst.Bounds(), img, bounds, draw.Src, nil)
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, dst, &jpeg.Options{Quality: 80}); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// transcodeVideo tries progressively smaller encodes until one fits under
+// the size cap. It returns the smallest encode that beats the original, or
+// nil to keep the original.
+func (p *Processor) transcodeVideo(ctx context.Context, id string, data []byte, rule ProcessingRule) []byte {
+	if p.transcode == nil {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(ctx, p.timeout)
+	defer cancel()
+
+	attempts := []struct{ height, crf int }{
+		{rule.MaxVideoHeight, 28},
+		{rule.MaxVideoHeight, 34},
+		{max(rule.MaxVideoHeight/2, 144), 38},
+	}
+	var best []byte
+	for _, attempt := range attempts {
+		out, err := p.transcode(ctx, data, attempt.height, attempt.crf)
+		if err != nil {
+			p.logger.Warn("video transcode failed", "id", id, "error", err)
+			break
+		}
+		if best == nil || len(out) < len(best) {
+			best = out
+		}
+		if int64(len(out)) <= rule.MaxVideoBytes {
+			break
+		}
+	}
+	if best == nil || len(best) >= len(data) {
+		return nil
+	}
+	if int64(len(best)) > rule.MaxVideoBytes {
+		p.logger.Warn("transcoded video still exceeds cap", "id", id, "size", len(best), "max", rule.MaxVideoBytes)
+	}
+	return best
+}
+
+func ffmpegTranscoder(ffmpegPath string) videoTranscoder {
+	return func(ctx context.Context, input []byte, maxHeight, crf int) ([]byte, error) {
+		dir, err := os.MkdirTemp("", "nexus-transcode-*")
+		if err != nil {
+			return nil, err
+		}
+		defer os.RemoveAll(dir)
+		in := filepath.Join(dir, "input")
+		out := filepath.Join(dir, "output.mp4")
+		if err := os.WriteFile(in, input, 0o600); err != nil {
+			return nil, err
+		}
+		cmd := exec.CommandContext(ctx, ffmpegPath,
+			"-hide_banner", "-loglevel", "error", "-y",
+			"-i", in,
+			"-vf", fmt.Sprintf("scale=-2:'min(%d,ih)'", maxHeight),
+			"-c:v", "libx264", "-preset", "veryfast", "-crf", strconv.Itoa(crf), "-pix_fmt", "yuv420p",
+			"-c:a", "aac", "-b:a", "96k",
+			"-movflags", "+faststart",
+			out,
+		)
+		var stderr bytes.Buffer
+		cmd.Stderr = &stderr
+		if err := cmd.Run(); err != nil {
+			return nil, fmt.Errorf("ffmpeg: %w: %s", err, strings.TrimSpace(stderr.String()))
+		}
+		return os.ReadFile(out)
+	}
+}
+
+func withExtension(filename, ext string) string {
+	if filename == "" {
+		return ""
+	}
+	return strings.TrimSuffix(filename, filepath.Ext(filename)) + ext
+}
+
+// ProcessingRepository runs a Processor on artifacts before handing them to
+// the wrapped repository, and stores derived artifacts next to them.
+type ProcessingRepository struct {
+	Repository
+	processor *Processor
+	logger    *slog.Logger
+}
+
+// NewProcessingRepository wraps repo. It returns repo unchanged when
+// processor is nil.
+func NewProcessingRepository(repo Repository, processor *Processor, logger *slog.Logger) Repository {
+	if repo == nil || processor == nil {
+		return repo
+	}
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &ProcessingRepository{Repository: repo, processor: processor, logger: logger}
+}
+
+// StoreArtifact processes the artifact and stores it with any derivatives.
+func (r *ProcessingRepository) StoreArtifact(ctx context.Context, artifact *pb.Artifact, data io.Reader) error {
+	if artifact == nil || strings.HasPrefix(artifact.Reference, "redacted://") {
+		return r.Repository.StoreArtifact(ctx, artifact, data)
+	}
+	if artifact.Id == "" {
+		artifact.Id = uuid.NewString()
+	}
+	raw, err := io.ReadAll(data)
+	if err != nil {
+		return fmt.Errorf("read artifact data: %w", err)
+	}
+	processed, derived, err := r.processor.Process(ctx, artifact, raw)
+	if err != nil {
+		return err
+	}
+	if len(artifact.Data) > 0 {
+		// Callers also hand inline data back to tools and channels.
+		artifact.Data = processed
+	}
+	if err := r.Repository.StoreArtifact(ctx, artifact, bytes.NewReader(processed)); err != nil {
+		return err
+	}
+	for _, extra := range derived {
+		if err := r.Repository.StoreArtifact(ctx, extra.Artifact, bytes.NewReader(extra.Data)); err != nil {
+			r.logger.Warn("failed to store derived artifact", "id", extra.Artifact.Id, "parent", artifact.Id, "error", err)
+		}
+	}
+	return nil
+}
+
+// Close closes the wrapped repository when it supports closing.
+func (r *ProcessingRepository) Close() error {
+	if closer, ok := r.Repository.(interface{ Close() error }); ok {
+		return closer.Close()
+	}
+	return nil
+}
+
+// AddReference forwards to the wrapped repository when it tracks references.
+func (r *ProcessingRepository) AddReference(ctx context.Context, artifactID, sessionID, messageID string) error {
+	if tracker, ok := r.Repository.(ReferenceTracker); ok {
+		return tracker.AddReference(ctx, artifactID, sessionID, messageID)
+	}
+	return nil
+}
+
+// ReleaseSession forwards to the wrapped repository when it tracks references.
+func (r *ProcessingRepository) ReleaseSession(ctx context.Context, sessionID string) error {
+	if tracker, ok := r.Repository.(ReferenceTracker); ok {
+		return tracker.ReleaseSession(ctx, sessionID)
+	}
+	return nil
+}
+
+// PruneUnreferenced forwards to the wrapped repository when it tracks references.
+func (r *ProcessingRepository) PruneUnreferenced(ctx context.Context) (PruneResult, error) {
+	if tracker, ok := r.Repository.(ReferenceTracker); ok {
+		return tracker.PruneUnreferenced(ctx)
+	}
+	return PruneResult{}, nil
+}
+
+// PresignArtifact forwards to the wrapped repository when it presigns.
+func (r *ProcessingRepository) PresignArtifact(ctx context.Context, artifactID string, opts PresignOptions) (string, time.Time, error) {
+	if presigner, ok := r.Repository.(Presigner); ok {
+		return presigner.PresignArtifact(ctx, artifactID, opts)
+	}
+	return "", time.Time{}, ErrPresignUnsupported
+}
@@ -0,0 +1,224 @@
+package artifacts
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"hash/crc32"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"log/slog"
+	"testing"
+
+	pb "github.com/haasonsaas/nexus/pkg/proto"
+)
+
+func testImage(width, height int) image.Image {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			img.Set(x, y, color.RGBA{R: uint8(x), G: uint8(y), B: 128, A: 255})
+		}
+	}
+	return img
+}
+
+func jpegWithEXIF(t *testing.T) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, testImage(16, 16), nil); err != nil {
+		t.Fatalf("jpeg.Encode: %v", err)
+	}
+	payload := append([]byte("Exif\x00\x00"), []byte("GPS 37.7749N 122.4194W")...)
+	segment := []byte{0xFF, 0xE1, 0, 0}
+	binary.BigEndian.PutUint16(segment[2:], uint16(len(payload)+2))
+	segment = append(segment, payload...)
+	data := buf.Bytes()
+	return append(append(append([]byte{}, data[:2]...), segment...), data[2:]...)
+}
+
+func pngWithText(t *testing.T) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, testImage(16, 16)); err != nil {
+		t.Fatalf("png.Encode: %v", err)
+	}
+	data := buf.Bytes()
+	body := []byte("tEXtAuthor\x00Jane Doe")
+	chunk := make([]byte, 4, 12+len(body))
+	binary.BigEndian.PutUint32(chunk, uint32(len(body)-4))
+	chunk = append(chunk, body...)
+	chunk = binary.BigEndian.AppendUint32(chunk, crc32.ChecksumIEEE(body))
+	// Insert after the signature and the 25-byte IHDR chunk.
+	insertAt := len(pngSignature) + 25
+	return append(append(append([]byte{}, data[:insertAt]...), chunk...), data[insertAt:]...)
+}
+
+func TestStripImageMetadata(t *testing.T) {
+	for name, input := range map[string][]byte{
+		"jpeg": jpegWithEXIF(t),
+		"png":  pngWithText(t),
+	} {
+		t.Run(name, func(t *testing.T) {
+			if _, _, err := image.Decode(bytes.NewReader(input)); err != nil {
+				t.Fatalf("fixture does not decode: %v", err)
+			}
+			out, stripped, err := stripImageMetadata(input)
+			if err != nil || !stripped {
+				t.Fatalf("stripImageMetadata = %v, %v", stripped, err)
+			}
+			if bytes.Contains(out, []byte("GPS")) || bytes.Contains(out, []byte("Jane")) {
+				t.Fatalf("metadata left in output")
+			}
+			if _, _, err := image.Decode(bytes.NewReader(out)); err != nil {
+				t.Fatalf("stripped image does not decode: %v", err)
+			}
+		})
+	}
+
+	plain := []byte("not an image")
+	if out, stripped, err := stripImageMetadata(plain); err != nil || stripped || !bytes.Equal(out, plain) {
+		t.Fatalf("expected non-image data unchanged")
+	}
+}
+
+func TestProcessor_MIMEValidation(t *testing.T) {
+	html := []byte("<html><script>alert(1)</script></html>")
+
+	corrector := NewProcessor(ProcessingConfig{Enabled: true}, nil)
+	artifact := &pb.Artifact{Id: "a", Type: "file", MimeType: "image/png"}
+	if _, _, err := corrector.Process(context.Background(), artifact, html); err != nil {
+		t.Fatalf("Process: %v", err)
+	}
+	if artifact.MimeType != "text/html" {
+		t.Fatalf("expected corrected MIME type, got %q", artifact.MimeType)
+	}
+
+	rejecter := NewProcessor(ProcessingConfig{Enabled: true, MIMEValidation: MIMEValidationReject}, nil)
+	if _, _, err := rejecter.Process(context.Background(), &pb.Artifact{Id: "b", MimeType: "image/png"}, html); !errors.Is(err, ErrMIMEMismatch) {
+		t.Fatalf("expected ErrMIMEMismatch, got %v", err)
+	}
+	if _, _, err := rejecter.Process(context.Background(), &pb.Artifact{Id: "c", MimeType: "application/json"}, []byte(`{"ok":true}`)); err != nil {
+		t.Fatalf("expected JSON declared as JSON to pass: %v", err)
+	}
+}
+
+func TestProcessingRepository_StoresThumbnail(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	store, err := NewLocalStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewLocalStore: %v", err)
+	}
+	processor := NewProcessor(ProcessingConfig{
+		Enabled: true,
+		Rules:   map[string]ProcessingRule{"screenshot": {StripEXIF: true, Thumbnail: true, ThumbnailMaxSide: 64}},
+	}, logger)
+	repo := NewProcessingRepository(NewMemoryRepository(store, logger), processor, logger)
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, testImage(200, 100)); err != nil {
+		t.Fatalf("png.Encode: %v", err)
+	}
+	if err := repo.StoreArtifact(context.Background(), &pb.Artifact{
+		Id: "shot", Type: "screenshot", MimeType: "image/png", Filename: "screen.png", Size: int64(buf.Len()),
+	}, bytes.NewReader(buf.Bytes())); err != nil {
+		t.Fatalf("StoreArtifact: %v", err)
+	}
+
+	thumb, reader, err := repo.GetArtifact(context.Background(), "shot-thumb")
+	if err != nil {
+		t.Fatalf("GetArtifact(thumbnail): %v", err)
+	}
+	defer reader.Close()
+	if thumb.Type != ThumbnailType || thumb.Filename != "screen.thumb.jpg" {
+		t.Fatalf("unexpected thumbnail metadata %+v", thumb)
+	}
+	img, format, err := image.Decode(reader)
+	if err != nil {
+		t.Fatalf("decode thumbnail: %v", err)
+	}
+	if format != "jpeg" || img.Bounds().Dx() != 64 || img.Bounds().Dy() != 32 {
+		t.Fatalf("unexpected thumbnail %s %v", format, img.Bounds())
+	}
+}
+
+type fakeTextExtractor struct{ text string }
+
+func (f fakeTextExtractor) ExtractText(context.Context, io.Reader, string) (string, error) {
+	return f.text, nil
+}
+
+func TestProcessingRepository_StoresOCRText(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	store, err := NewLocalStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewLocalStore: %v", err)
+	}
+	processor := NewProcessor(ProcessingConfig{
+		Enabled:       true,
+		TextExtractor: fakeTextExtractor{text: "  Build failed\n"},
+		Rules:         map[string]ProcessingRule{"screenshot": {OCR: true}},
+	}, logger)
+	repo := NewProcessingRepository(NewMemoryRepository(store, logger), processor, logger)
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, testImage(20, 20)); err != nil {
+		t.Fatalf("png.Encode: %v", err)
+	}
+	for _, artifact := range []*pb.Artifact{
+		{Id: "shot", Type: "screenshot", MimeType: "image/png", Filename: "screen.png"},
+		{Id: "photo", Type: "file", MimeType: "image/png", Filename: "photo.png"},
+	} {
+		if err := repo.StoreArtifact(context.Background(), artifact, bytes.NewReader(buf.Bytes())); err != nil {
+			t.Fatalf("StoreArtifact(%s): %v", artifact.Id, err)
+		}
+	}
+
+	text, reader, err := repo.GetArtifact(context.Background(), OCRTextID("shot"))
+	if err != nil {
+		t.Fatalf("GetArtifact(ocr): %v", err)
+	}
+	defer reader.Close()
+	data, _ := io.ReadAll(reader)
+	if text.Type != OCRTextType || text.MimeType != "text/plain" || string(data) != "Build failed" {
+		t.Fatalf("unexpected OCR artifact %+v %q", text, data)
+	}
+	if _, _, err := repo.GetArtifact(context.Background(), OCRTextID("photo")); err == nil {
+		t.Fatal("expected no OCR for types without the rule")
+	}
+}
+
+func TestProcessor_TranscodeCapsSize(t *testing.T) {
+	processor := NewProcessor(ProcessingConfig{
+		Enabled:        true,
+		MIMEValidation: MIMEValidationOff,
+		Rules:          map[string]ProcessingRule{"recording": {Transcode: true, MaxVideoBytes: 40}},
+	}, nil)
+	var crfs []int
+	processor.transcode = func(ctx context.Context, input []byte, maxHeight, crf int) ([]byte, error) {
+		crfs = append(crfs, crf)
+		return bytes.Repeat([]byte{0}, 100-2*crf), nil
+	}
+
+	artifact := &pb.Artifact{Id: "rec", Type: "recording", MimeType: "video/webm", Filename: "rec.webm"}
+	out, _, err := processor.Process(context.Background(), artifact, bytes.Repeat([]byte{1}, 200))
+	if err != nil {
+		t.Fatalf("Process: %v", err)
+	}
+	if len(out) != 32 || len(crfs) != 2 {
+		t.Fatalf("expected second attempt (crf 34) to fit, got %d bytes after %v", len(out), crfs)
+	}
+	if artifact.MimeType != "video/mp4" || artifact.Filename != "rec.mp4" || artifact.Size != 32 {
+		t.Fatalf("unexpected artifact after transcode %+v", artifact)
+	}
+
+	small := &pb.Artifact{Id: "small", Type: "recording", MimeType: "video/webm"}
+	crfs = nil
+	if _, _, err := processor.Process(context.Background(), small, bytes.Repeat([]byte{1}, 30)); err != nil || len(crfs) != 0 {
+		t.Fatalf("expected videos under the cap to skip transcoding, got %v, %v", crfs, err)
+	}
+}
@@ -0,0 +1,85 @@
+package artifacts
+
+import (
+	"context"
+	"time"
+)
+
+// Reference records that a session, or a message in it, refers to an
+// artifact. The session an artifact was stored in always holds one.
+type Reference struct {
+	SessionID string
+	MessageID string `json:",omitempty"`
+
+	// ReleasedAt is set once the session is reset or deleted.
+	ReleasedAt time.Time `json:",omitempty"`
+}
+
+// ReferenceTracker is implemented by repositories that track which sessions
+// refer to each artifact, so artifacts can be reclaimed as soon as every
+// session referring to them is reset or deleted instead of waiting out
+// their TTL.
+type ReferenceTracker interface {
+	// AddReference records that sessionID, and messageID when set, refers
+	// to artifactID. Unknown artifacts are ignored.
+	AddReference(ctx context.Context, artifactID, sessionID, messageID string) error
+
+	// ReleaseSession releases every reference held by sessionID.
+	ReleaseSession(ctx context.Context, sessionID string) error
+
+	// PruneUnreferenced deletes artifacts whose references have all been
+	// released. Artifacts that were never referenced are left to their TTL.
+	PruneUnreferenced(ctx context.Context) (PruneResult, error)
+}
+
+// PruneResult reports what a prune reclaimed.
+type PruneResult struct {
+	Count int
+	Bytes int64
+}
+
+// addReference records a reference, reviving it if it was released.
+func (m *Metadata) addReference(sessionID, messageID string) {
+	for i, ref := range m.References {
+		if ref.SessionID == sessionID && ref.MessageID == messageID {
+			m.References[i].ReleasedAt = time.Time{}
+			return
+		}
+	}
+	m.References = append(m.References, Reference{SessionID: sessionID, MessageID: messageID})
+}
+
+// releaseSession releases the references held by sessionID and reports
+// whether any were still live.
+func (m *Metadata) releaseSession(sessionID string, now time.Time) bool {
+	changed := false
+	for i, ref := range m.References {
+		if ref.SessionID == sessionID && ref.ReleasedAt.IsZero() {
+			m.References[i].ReleasedAt = now
+			changed = true
+		}
+	}
+	return changed
+}
+
+// unreferenced reports whether the artifact had references and all of
+// them have been released.
+func (m *Metadata) unreferenced() bool {
+	if len(m.References) == 0 {
+		return false
+	}
+	for _, ref := range m.References {
+		if ref.ReleasedAt.IsZero() {
+			return false
+		}
+	}
+	return true
+}
+
+// initialReferences returns the references of a newly stored artifact.
+func initialReferences(sessionID string) []Reference {
+	if sessionID == "" {
+		return nil
+	}
+	return []Reference{{SessionID: sessionID}}
+}
@@ -0,0 +1,196 @@
+package artifacts
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"log/slog"
+	"path/filepath"
+	"testing"
+
+	"github.com/haasonsaas/nexus/internal/observability"
+	"github.com/haasonsaas/nexus/internal/sessions"
+	pb "github.com/haasonsaas/nexus/pkg/proto"
+)
+
+type trackingRepository interface {
+	Repository
+	ReferenceTracker
+}
+
+func referenceRepositories(t *testing.T) map[string]trackingRepository {
+	t.Helper()
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	newStore := func(dir string) Store {
+		store, err := NewLocalStore(dir)
+		if err != nil {
+			t.Fatalf("NewLocalStore: %v", err)
+		}
+		return store
+	}
+
+	dir := t.TempDir()
+	persistent, err := NewPersistentRepository(newStore(filepath.Join(dir, "persistent")), filepath.Join(dir, "metadata.json"), logger)
+	if err != nil {
+		t.Fatalf("NewPersistentRepository: %v", err)
+	}
+	db, err := sessions.OpenSQLite(context.Background(), "sqlite://"+filepath.Join(dir, "nexus.db"))
+	if err != nil {
+		t.Fatalf("OpenSQLite: %v", err)
+	}
+	sqlRepo, err := NewSQLiteRepository(db, newStore(filepath.Join(dir, "sql")), logger)
+	if err != nil {
+		t.Fatalf("NewSQLiteRepository: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = persistent.Close()
+		_ = sqlRepo.Close()
+	})
+	return map[string]trackingRepository{
+		"memory":     NewMemoryRepository(newStore(filepath.Join(dir, "memory")), logger),
+		"persistent": persistent,
+		"sqlite":     sqlRepo,
+	}
+}
+
+func TestReferenceTracker_PrunesAfterAllSessionsRelease(t *testing.T) {
+	for name, repo := range referenceRepositories(t) {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			payload := []byte("photo-bytes")
+			store := func(id, sessionID string) {
+				storeCtx := ctx
+				if sessionID != "" {
+					storeCtx = observability.AddSessionID(ctx, sessionID)
+				}
+				if err := repo.StoreArtifact(storeCtx, &pb.Artifact{
+					Id: id, Type: "file", Size: int64(len(payload)),
+				}, bytes.NewReader(payload)); err != nil {
+					t.Fatalf("StoreArtifact(%s): %v", id, err)
+				}
+			}
+			store("shared", "session-a")
+			store("owned", "session-a")
+			store("untracked", "")
+
+			if err := repo.AddReference(ctx, "shared", "session-b", "msg-1"); err != nil {
+				t.Fatalf("AddReference: %v", err)
+			}
+			if err := repo.AddReference(ctx, "missing", "session-b", "msg-1"); err != nil {
+				t.Fatalf("AddReference for unknown artifact: %v", err)
+			}
+
+			if err := repo.ReleaseSession(ctx, "session-a"); err != nil {
+				t.Fatalf("ReleaseSession: %v", err)
+			}
+			result, err := repo.PruneUnreferenced(ctx)
+			if err != nil {
+				t.Fatalf("PruneUnreferenced: %v", err)
+			}
+			if result.Count != 1 || result.Bytes != int64(len(payload)) {
+				t.Fatalf("first prune = %+v, want 1 artifact of %d bytes", result, len(payload))
+			}
+			if _, _, err := repo.GetArtifact(ctx, "owned"); err == nil {
+				t.Fatal("owned artifact should have been pruned")
+			}
+
+			if err := repo.ReleaseSession(ctx, "session-b"); err != nil {
+				t.Fatalf("ReleaseSession: %v", err)
+			}
+			result, err = repo.PruneUnreferenced(ctx)
+			if err != nil {
+				t.Fatalf("PruneUnreferenced: %v", err)
+			}
+			if result.Count != 1 {
+				t.Fatalf("second prune = %+v, want the shared artifact", result)
+			}
+
+			listed, err := repo.ListArtifacts(ctx, Filter{})
+			if err != nil {
+				t.Fatalf("ListArtifacts: %v", err)
+			}
+			if len(listed) != 1 || listed[0].Id != "untracked" {
+				t.Fatalf("remaining artifacts = %+v, want only untracked", listed)
+			}
+		})
+	}
+}
+
+func TestReferenceTracker_ReferenceRevivesReleased(t *testing.T) {
+	store, err := NewLocalStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewLocalStore: %v", err)
+	}
+	repo := NewMemoryRepository(store, nil)
+	ctx := observability.AddSessionID(context.Background(), "session-a")
+	if err := repo.StoreArtifact(ctx, &pb.Artifact{Id: "a", Type: "file", Size: 1}, bytes.NewReader([]byte("x"))); err != nil {
+		t.Fatalf("StoreArtifact: %v", err)
+	}
+	_ = repo.ReleaseSession(ctx, "session-a")
+	_ = repo.AddReference(ctx, "a", "session-a", "")
+
+	result, err := repo.PruneUnreferenced(ctx)
+	if err != nil {
+		t.Fatalf("PruneUnreferenced: %v", err)
+	}
+	if result.Count != 0 {
+		t.Fatalf("pruned %d artifacts, want 0", result.Count)
+	}
+}
+
+func TestPersistentRepository_PersistsReferences(t *testing.T) {
+	dir := t.TempDir()
+	store, err := NewLocalStore(dir)
+	if err != nil {
+		t.Fatalf("NewLocalStore: %v", err)
+	}
+	path := filepath.Join(dir, "metadata.json")
+	repo, err := NewPersistentRepository(store, path, nil)
+	if err != nil {
+		t.Fatalf("NewPersistentRepository: %v", err)
+	}
+	ctx := observability.AddSessionID(context.Background(), "session-a")
+	if err := repo.StoreArtifact(ctx, &pb.Artifact{Id: "a", Type: "file", Size: 1}, bytes.NewReader([]byte("x"))); err != nil {
+		t.Fatalf("StoreArtifact: %v", err)
+	}
+	if err := repo.ReleaseSession(ctx, "session-a"); err != nil {
+		t.Fatalf("ReleaseSession: %v", err)
+	}
+	_ = repo.Close()
+
+	storeReloaded, err := NewLocalStore(dir)
+	if err != nil {
+		t.Fatalf("NewLocalStore: %v", err)
+	}
+	reloaded, err := NewPersistentRepository(storeReloaded, path, nil)
+	if err != nil {
+		t.Fatalf("NewPersistentRepository (reload): %v", err)
+	}
+	defer reloaded.Close()
+	result, err := reloaded.PruneUnreferenced(context.Background())
+	if err != nil {
+		t.Fatalf("PruneUnreferenced: %v", err)
+	}
+	if result.Count != 1 {
+		t.Fatalf("pruned %d artifacts after reload, want 1", result.Count)
+	}
+}
+
+func TestCleanupService_PrunesUnreferenced(t *testing.T) {
+	store, err := NewLocalStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewLocalStore: %v", err)
+	}
+	repo := NewMemoryRepository(store, nil)
+	ctx := observability.AddSessionID(context.Background(), "session-a")
+	if err := repo.StoreArtifact(ctx, &pb.Artifact{Id: "a", Type: "file", Size: 1}, bytes.NewReader([]byte("x"))); err != nil {
+		t.Fatalf("StoreArtifact: %v", err)
+	}
+	_ = repo.ReleaseSession(ctx, "session-a")
+
+	NewCleanupService(repo, 0, nil).prune(context.Background())
+
+	if _, _, err := repo.GetArtifact(context.Background(), "a"); err == nil {
+		t.Fatal("expected the released artifact to be pruned")
+	}
+}
@@ -0,0 +1,66 @@
+package artifacts
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// StoreFactory opens an artifact store provided outside the core, such as by
+// a plugin.
+type StoreFactory func(ctx context.Context) (Store, error)
+
+// builtinStores are the artifacts.backend values handled by the gateway
+// itself; registered stores cannot shadow them.
+var builtinStores = map[string]bool{
+	"local": true, "s3": true, "minio": true, "none": true, "disabled": true,
+}
+
+var (
+	storeFactoriesMu sync.RWMutex
+	storeFactories   = map[string]StoreFactory{}
+)
+
+// RegisterStore makes a store selectable as artifacts.backend by name.
+// Names are case-insensitive.
+func RegisterStore(name string, factory StoreFactory) error {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if name == "" {
+		return fmt.Errorf("artifact store name is required")
+	}
+	if factory == nil {
+		return fmt.Errorf("artifact store %q factory is nil", name)
+	}
+	if builtinStores[name] {
+		return fmt.Errorf("artifact store %q is built in", name)
+	}
+	storeFactoriesMu.Lock()
+	defer storeFactoriesMu.Unlock()
+	if _, exists := storeFactories[name]; exists {
+		return fmt.Errorf("artifact store %q is already registered", name)
+	}
+	storeFactories[name] = factory
+	return nil
+}
+
+// LookupStore returns the factory registered under name.
+func LookupStore(name string) (StoreFactory, bool) {
+	storeFactoriesMu.RLock()
+	defer storeFactoriesMu.RUnlock()
+	factory, ok := storeFactories[strings.ToLower(strings.TrimSpace(name))]
+	return factory, ok
+}
+
+// RegisteredStores lists the names of registered stores, sorted.
+func RegisteredStores() []string {
+	storeFactoriesMu.RLock()
+	defer storeFactoriesMu.RUnlock()
+	names := make([]string, 0, len(storeFactories))
+	for name := range storeFactories {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,32 @@
+package artifacts
+
+import (
+	"context"
+	"testing"
+)
+
+func TestRegisterStore(t *testing.T) {
+	factory := func(context.Context) (Store, error) { return nil, nil }
+	if err := RegisterStore("GCS-Test", factory); err != nil {
+		t.Fatalf("RegisterStore() error = %v", err)
+	}
+	if _, ok := LookupStore("gcs-test"); !ok {
+		t.Fatal("LookupStore() did not find the store case-insensitively")
+	}
+	if err := RegisterStore("gcs-test", factory); err == nil {
+		t.Fatal("duplicate RegisterStore() should fail")
+	}
+	if err := RegisterStore("s3", factory); err == nil {
+		t.Fatal("RegisterStore() should not shadow a built-in backend")
+	}
+	if err := RegisterStore("azure-test", nil); err == nil {
+		t.Fatal("RegisterStore() with a nil factory should fail")
+	}
+	found := false
+	for _, name := range RegisteredStores() {
+		found = found || name == "gcs-test"
+	}
+	if !found {
+		t.Fatalf("RegisteredStores() = %q", RegisteredStores())
+	}
+}
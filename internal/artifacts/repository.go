@@ -60,6 +60,7 @@ func (r *MemoryRepository) StoreArtifact(ctx context.Context, artifact *pb.Artif
 	if edgeID := observability.GetEdgeID(ctx); edgeID != "" {
 		meta.EdgeID = edgeID
 	}
+	meta.References = initialReferences(meta.SessionID)
 
 	// Calculate expiration
 	ttl := time.Duration(artifact.TtlSeconds) * time.Second
@@ -268,3 +269,61 @@ func (r *MemoryRepository) PruneExpired(ctx context.Context) (int, error) {
 	r.logger.Info("pruned expired artifacts", "count", count)
 	return count, nil
 }
+
+// AddReference records that a session or message refers to an artifact.
+func (r *MemoryRepository) AddReference(ctx context.Context, artifactID, sessionID, messageID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if meta, ok := r.metadata[artifactID]; ok && sessionID != "" {
+		meta.addReference(sessionID, messageID)
+	}
+	return nil
+}
+
+// ReleaseSession releases every reference held by a session.
+func (r *MemoryRepository) ReleaseSession(ctx context.Context, sessionID string) error {
+	now := time.Now()
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, meta := range r.metadata {
+		meta.releaseSession(sessionID, now)
+	}
+	return nil
+}
+
+// PruneUnreferenced removes artifacts whose references were all released.
+func (r *MemoryRepository) PruneUnreferenced(ctx context.Context) (PruneResult, error) {
+	r.mu.RLock()
+	candidates := make(map[string]int64)
+	for id, meta := range r.metadata {
+		if meta.unreferenced() {
+			candidates[id] = meta.Size
+		}
+	}
+	r.mu.RUnlock()
+
+	var result PruneResult
+	for id, size := range candidates {
+		if err := r.DeleteArtifact(ctx, id); err == nil {
+			result.Count++
+			result.Bytes += size
+		}
+	}
+	return result, nil
+}
+
+// PresignArtifact returns a direct download URL when the artifact's data is
+// held by a presigning store.
+func (r *MemoryRepository) PresignArtifact(ctx context.Context, artifactID string, opts PresignOptions) (string, time.Time, error) {
+	r.mu.RLock()
+	meta, ok := r.metadata[artifactID]
+	var snapshot Metadata
+	if ok {
+		snapshot = *meta
+	}
+	r.mu.RUnlock()
+	if !ok {
+		return "", time.Time{}, fmt.Errorf("artifact not found: %s", artifactID)
+	}
+	return presignMetadata(ctx, r.store, &snapshot, opts)
+}
@@ -1,12 +1,18 @@
 package artifacts
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
 	"io"
+	"math"
+	"net/url"
 	"path"
+	"sort"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/config"
@@ -25,8 +31,52 @@ type S3StoreConfig struct {
 	AccessKeyID     string
 	SecretAccessKey string
 	UsePathStyle    bool
+
+	// SSE is the server-side encryption applied to objects: "" (bucket
+	// default), "AES256" (SSE-S3), or "aws:kms" (SSE-KMS).
+	SSE string
+
+	// KMSKeyID is the KMS key for SSE-KMS; empty uses the AWS managed key.
+	KMSKeyID string
+
+	// Lifecycle installs bucket lifecycle rules expiring objects after their
+	// artifact TTL, as a backstop for the metadata cleanup.
+	Lifecycle bool
+
+	// MultipartThreshold is the size above which uploads use multipart
+	// (default: 16MB).
+	MultipartThreshold int64
+
+	// PartSize is the multipart part size (default: 8MB, minimum 5MB).
+	PartSize int64
+
+	// Presign enables presigned download URLs.
+	Presign bool
+
+	// PresignTTL is how long presigned URLs stay valid when no TTL is
+	// requested (default: 15m).
+	PresignTTL time.Duration
 }
 
+const (
+	defaultS3MultipartThreshold = 16 << 20
+	defaultS3PartSize           = 8 << 20
+	minS3PartSize               = 5 << 20
+	maxS3Parts                  = 10000
+	defaultS3PresignTTL         = 15 * time.Minute
+
+	// MaxS3PresignTTL is the longest lifetime S3 accepts for SigV4
+	// presigned URLs.
+	MaxS3PresignTTL = 7 * 24 * time.Hour
+
+	// s3ExpiryTag tags objects with their TTL in days so lifecycle rules
+	// can expire them.
+	s3ExpiryTag = "nexus-expires"
+
+	// s3LifecycleRulePrefix marks lifecycle rules managed by the store.
+	s3LifecycleRulePrefix = "nexus-"
+)
+
 // DefaultS3StoreConfig returns the default configuration.
 func DefaultS3StoreConfig() *S3StoreConfig {
 	return &S3StoreConfig{
@@ -34,11 +84,33 @@ func DefaultS3StoreConfig() *S3StoreConfig {
 	}
 }
 
+// s3API is the subset of the S3 client used by S3Store.
+type s3API interface {
+	PutObject(ctx context.Context, params *s3.PutObjectInput, optFns ...func(*s3.Options)) (*s3.PutObjectOutput, error)
+	GetObject(ctx context.Context, params *s3.GetObjectInput, optFns ...func(*s3.Options)) (*s3.GetObjectOutput, error)
+	DeleteObject(ctx context.Context, params *s3.DeleteObjectInput, optFns ...func(*s3.Options)) (*s3.DeleteObjectOutput, error)
+	HeadObject(ctx context.Context, params *s3.HeadObjectInput, optFns ...func(*s3.Options)) (*s3.HeadObjectOutput, error)
+	CreateMultipartUpload(ctx context.Context, params *s3.CreateMultipartUploadInput, optFns ...func(*s3.Options)) (*s3.CreateMultipartUploadOutput, error)
+	UploadPart(ctx context.Context, params *s3.UploadPartInput, optFns ...func(*s3.Options)) (*s3.UploadPartOutput, error)
+	CompleteMultipartUpload(ctx context.Context, params *s3.CompleteMultipartUploadInput, optFns ...func(*s3.Options)) (*s3.CompleteMultipartUploadOutput, error)
+	AbortMultipartUpload(ctx context.Context, params *s3.AbortMultipartUploadInput, optFns ...func(*s3.Options)) (*s3.AbortMultipartUploadOutput, error)
+	GetBucketLifecycleConfiguration(ctx context.Context, params *s3.GetBucketLifecycleConfigurationInput, optFns ...func(*s3.Options)) (*s3.GetBucketLifecycleConfigurationOutput, error)
+	PutBucketLifecycleConfiguration(ctx context.Context, params *s3.PutBucketLifecycleConfigurationInput, optFns ...func(*s3.Options)) (*s3.PutBucketLifecycleConfigurationOutput, error)
+}
+
 // S3Store stores artifacts in an S3-compatible bucket.
 type S3Store struct {
-	client *s3.Client
-	bucket string
-	prefix string
+	client    s3API
+	presigner *s3.PresignClient
+	bucket    string
+	prefix    string
+
+	sse                types.ServerSideEncryption
+	kmsKeyID           string
+	tagExpiry          bool
+	multipartThreshold int64
+	partSize           int64
+	presignTTL         time.Duration
 }
 
 // NewS3Store creates a new S3-backed artifact store.
@@ -82,21 +154,91 @@ func NewS3Store(ctx context.Context, cfg *S3StoreConfig) (*S3Store, error) {
 		}
 	})
 
-	prefix := strings.Trim(cfg.Prefix, "/")
-	return &S3Store{
-		client: client,
-		bucket: bucket,
-		prefix: prefix,
-	}, nil
+	store, err := newS3Store(client, bucket, cfg)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.Presign {
+		store.presigner = s3.NewPresignClient(client)
+	}
+	if cfg.Lifecycle {
+		if err := store.EnsureLifecycle(ctx, defaultTTLSnapshot()); err != nil {
+			return nil, err
+		}
+	}
+	return store, nil
+}
+
+// newS3Store applies cfg to a store around client.
+func newS3Store(client s3API, bucket string, cfg *S3StoreConfig) (*S3Store, error) {
+	store := &S3Store{
+		client:             client,
+		bucket:             bucket,
+		prefix:             strings.Trim(cfg.Prefix, "/"),
+		kmsKeyID:           strings.TrimSpace(cfg.KMSKeyID),
+		tagExpiry:          cfg.Lifecycle,
+		multipartThreshold: cfg.MultipartThreshold,
+		partSize:           cfg.PartSize,
+		presignTTL:         cfg.PresignTTL,
+	}
+	switch sse := strings.TrimSpace(cfg.SSE); {
+	case sse == "":
+	case strings.EqualFold(sse, string(types.ServerSideEncryptionAes256)):
+		store.sse = types.ServerSideEncryptionAes256
+	case strings.EqualFold(sse, string(types.ServerSideEncryptionAwsKms)):
+		store.sse = types.ServerSideEncryptionAwsKms
+	default:
+		return nil, fmt.Errorf("unsupported s3 server-side encryption %q", cfg.SSE)
+	}
+	if store.kmsKeyID != "" && store.sse != types.ServerSideEncryptionAwsKms {
+		return nil, fmt.Errorf("s3 kms key id requires aws:kms server-side encryption")
+	}
+	if store.multipartThreshold <= 0 {
+		store.multipartThreshold = defaultS3MultipartThreshold
+	}
+	if store.partSize <= 0 {
+		store.partSize = defaultS3PartSize
+	}
+	if store.partSize < minS3PartSize {
+		store.partSize = minS3PartSize
+	}
+	if store.presignTTL <= 0 {
+		store.presignTTL = defaultS3PresignTTL
+	}
+	return store, nil
 }
 
-// Put stores artifact data in S3.
+// Put stores artifact data in S3. Data larger than the multipart threshold
+// is uploaded in parts so it is never buffered whole.
 func (s *S3Store) Put(ctx context.Context, artifactID string, data io.Reader, opts PutOptions) (string, error) {
 	key := s.objectKey(artifactID)
+	var head bytes.Buffer
+	_, err := io.CopyN(&head, data, s.multipartThreshold+1)
+	switch {
+	case err == io.EOF:
+		err = s.putObject(ctx, key, head.Bytes(), opts)
+	case err != nil:
+		return "", fmt.Errorf("read artifact data: %w", err)
+	default:
+		err = s.putMultipart(ctx, key, io.MultiReader(&head, data), opts)
+	}
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("s3://%s/%s", s.bucket, key), nil
+}
+
+func (s *S3Store) putObject(ctx context.Context, key string, data []byte, opts PutOptions) error {
 	input := &s3.PutObjectInput{
-		Bucket: &s.bucket,
-		Key:    &key,
-		Body:   data,
+		Bucket:               &s.bucket,
+		Key:                  &key,
+		Body:                 bytes.NewReader(data),
+		ContentLength:        aws.Int64(int64(len(data))),
+		ServerSideEncryption: s.sse,
+		Tagging:              s.tagging(opts.TTL),
+	}
+	if s.kmsKeyID != "" {
+		input.SSEKMSKeyId = aws.String(s.kmsKeyID)
 	}
 	if opts.MimeType != "" {
 		input.ContentType = aws.String(opts.MimeType)
@@ -105,10 +247,237 @@ func (s *S3Store) Put(ctx context.Context, artifactID string, data io.Reader, op
 		input.Metadata = opts.Metadata
 	}
 	if _, err := s.client.PutObject(ctx, input); err != nil {
-		return "", fmt.Errorf("s3 put object: %w", err)
+		return fmt.Errorf("s3 put object: %w", err)
 	}
+	return nil
+}
 
-	return fmt.Sprintf("s3://%s/%s", s.bucket, key), nil
+// putMultipart uploads data in parts, aborting the upload on failure so no
+// orphaned parts are billed.
+func (s *S3Store) putMultipart(ctx context.Context, key string, data io.Reader, opts PutOptions) error {
+	input := &s3.CreateMultipartUploadInput{
+		Bucket:               &s.bucket,
+		Key:                  &key,
+		ServerSideEncryption: s.sse,
+		Tagging:              s.tagging(opts.TTL),
+	}
+	if s.kmsKeyID != "" {
+		input.SSEKMSKeyId = aws.String(s.kmsKeyID)
+	}
+	if opts.MimeType != "" {
+		input.ContentType = aws.String(opts.MimeType)
+	}
+	if len(opts.Metadata) > 0 {
+		input.Metadata = opts.Metadata
+	}
+	upload, err := s.client.CreateMultipartUpload(ctx, input)
+	if err != nil {
+		return fmt.Errorf("s3 create multipart upload: %w", err)
+	}
+
+	parts, err := s.uploadParts(ctx, key, upload.UploadId, data)
+	if err == nil {
+		_, err = s.client.CompleteMultipartUpload(ctx, &s3.CompleteMultipartUploadInput{
+			Bucket:          &s.bucket,
+			Key:             &key,
+			UploadId:        upload.UploadId,
+			MultipartUpload: &types.CompletedMultipartUpload{Parts: parts},
+		})
+		if err != nil {
+			err = fmt.Errorf("s3 complete multipart upload: %w", err)
+		}
+	}
+	if err != nil {
+		// Abort with a fresh context: ctx may be what failed the upload.
+		abortCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 30*time.Second)
+		defer cancel()
+		if _, abortErr := s.client.AbortMultipartUpload(abortCtx, &s3.AbortMultipartUploadInput{
+			Bucket:   &s.bucket,
+			Key:      &key,
+			UploadId: upload.UploadId,
+		}); abortErr != nil {
+			return errors.Join(err, fmt.Errorf("s3 abort multipart upload: %w", abortErr))
+		}
+		return err
+	}
+	return nil
+}
+
+func (s *S3Store) uploadParts(ctx context.Context, key string, uploadID *string, data io.Reader) ([]types.CompletedPart, error) {
+	var parts []types.CompletedPart
+	buf := make([]byte, s.partSize)
+	for number := int32(1); ; number++ {
+		n, readErr := io.ReadFull(data, buf)
+		if readErr == io.EOF {
+			return parts, nil
+		}
+		if readErr != nil && readErr != io.ErrUnexpectedEOF {
+			return nil, fmt.Errorf("read artifact data: %w", readErr)
+		}
+		if number > maxS3Parts {
+			return nil, fmt.Errorf("artifact exceeds %d parts of %d bytes", maxS3Parts, s.partSize)
+		}
+		out, err := s.client.UploadPart(ctx, &s3.UploadPartInput{
+			Bucket:        &s.bucket,
+			Key:           &key,
+			UploadId:      uploadID,
+			PartNumber:    aws.Int32(number),
+			Body:          bytes.NewReader(buf[:n]),
+			ContentLength: aws.Int64(int64(n)),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("s3 upload part %d: %w", number, err)
+		}
+		parts = append(parts, types.CompletedPart{ETag: out.ETag, PartNumber: aws.Int32(number)})
+		if readErr == io.ErrUnexpectedEOF {
+			return parts, nil
+		}
+	}
+}
+
+// PresignGet returns a presigned GET URL for the artifact and when it
+// expires. A non-positive TTL uses the configured default; S3 caps the
+// lifetime at seven days. It returns ErrPresignUnsupported when presigning
+// is disabled.
+func (s *S3Store) PresignGet(ctx context.Context, artifactID string, opts PresignOptions) (string, time.Time, error) {
+	if s.presigner == nil {
+		return "", time.Time{}, ErrPresignUnsupported
+	}
+	ttl := opts.TTL
+	if ttl <= 0 {
+		ttl = s.presignTTL
+	}
+	ttl = min(ttl, MaxS3PresignTTL)
+	key := s.objectKey(artifactID)
+	input := &s3.GetObjectInput{
+		Bucket: &s.bucket,
+		Key:    &key,
+	}
+	if opts.ContentType != "" {
+		input.ResponseContentType = aws.String(opts.ContentType)
+	}
+	if opts.ContentDisposition != "" {
+		input.ResponseContentDisposition = aws.String(opts.ContentDisposition)
+	}
+	expires := time.Now().Add(ttl)
+	req, err := s.presigner.PresignGetObject(ctx, input, s3.WithPresignExpires(ttl))
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("s3 presign get object: %w", err)
+	}
+	return req.URL, expires, nil
+}
+
+// EnsureLifecycle installs one lifecycle rule per distinct TTL, in whole
+// days, expiring objects tagged with that TTL, plus a rule aborting
+// incomplete multipart uploads. Rules not managed by the store are kept.
+func (s *S3Store) EnsureLifecycle(ctx context.Context, ttls map[string]time.Duration) error {
+	var existing []types.LifecycleRule
+	out, err := s.client.GetBucketLifecycleConfiguration(ctx, &s3.GetBucketLifecycleConfigurationInput{
+		Bucket: &s.bucket,
+	})
+	if err != nil {
+		var apiErr smithy.APIError
+		if !errors.As(err, &apiErr) || apiErr.ErrorCode() != "NoSuchLifecycleConfiguration" {
+			return fmt.Errorf("s3 get bucket lifecycle: %w", err)
+		}
+	} else {
+		existing = out.Rules
+	}
+
+	var rules []types.LifecycleRule
+	for _, rule := range existing {
+		if rule.ID == nil || !strings.HasPrefix(*rule.ID, s3LifecycleRulePrefix+s.ruleScope()) {
+			rules = append(rules, rule)
+		}
+	}
+	rules = append(rules, s.lifecycleRules(ttls)...)
+
+	if _, err := s.client.PutBucketLifecycleConfiguration(ctx, &s3.PutBucketLifecycleConfigurationInput{
+		Bucket:                 &s.bucket,
+		LifecycleConfiguration: &types.BucketLifecycleConfiguration{Rules: rules},
+	}); err != nil {
+		return fmt.Errorf("s3 put bucket lifecycle: %w", err)
+	}
+	return nil
+}
+
+// lifecycleRules builds the store's managed lifecycle rules for ttls.
+func (s *S3Store) lifecycleRules(ttls map[string]time.Duration) []types.LifecycleRule {
+	days := make(map[int32]bool)
+	for _, ttl := range ttls {
+		if d := expiryDays(ttl); d > 0 {
+			days[d] = true
+		}
+	}
+	sorted := make([]int32, 0, len(days))
+	for d := range days {
+		sorted = append(sorted, d)
+	}
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	var prefix *string
+	if s.prefix != "" {
+		prefix = aws.String(s.prefix + "/")
+	}
+	rules := []types.LifecycleRule{{
+		ID:                             aws.String(s3LifecycleRulePrefix + s.ruleScope() + "abort-multipart"),
+		Status:                         types.ExpirationStatusEnabled,
+		Filter:                         &types.LifecycleRuleFilter{Prefix: aws.String(aws.ToString(prefix))},
+		AbortIncompleteMultipartUpload: &types.AbortIncompleteMultipartUpload{DaysAfterInitiation: aws.Int32(1)},
+	}}
+	for _, d := range sorted {
+		tag := types.Tag{Key: aws.String(s3ExpiryTag), Value: aws.String(expiryTagValue(d))}
+		filter := &types.LifecycleRuleFilter{Tag: &tag}
+		if prefix != nil {
+			filter = &types.LifecycleRuleFilter{And: &types.LifecycleRuleAndOperator{
+				Prefix: prefix,
+				Tags:   []types.Tag{tag},
+			}}
+		}
+		rules = append(rules, types.LifecycleRule{
+			ID:         aws.String(s3LifecycleRulePrefix + s.ruleScope() + "expire-" + expiryTagValue(d)),
+			Status:     types.ExpirationStatusEnabled,
+			Filter:     filter,
+			Expiration: &types.LifecycleExpiration{Days: aws.Int32(d)},
+		})
+	}
+	return rules
+}
+
+// ruleScope distinguishes the rules of stores sharing a bucket under
+// different prefixes.
+func (s *S3Store) ruleScope() string {
+	if s.prefix == "" {
+		return ""
+	}
+	return strings.ReplaceAll(s.prefix, "/", "-") + "-"
+}
+
+// tagging returns the object tags for an artifact with the given TTL, or
+// nil when lifecycle rules are not managed.
+func (s *S3Store) tagging(ttl time.Duration) *string {
+	d := expiryDays(ttl)
+	if !s.tagExpiry || d <= 0 {
+		return nil
+	}
+	return aws.String(url.Values{s3ExpiryTag: {expiryTagValue(d)}}.Encode())
+}
+
+// expiryDays rounds ttl up to whole days, the granularity of lifecycle
+// expiration, so objects never expire before their metadata.
+func expiryDays(ttl time.Duration) int32 {
+	if ttl <= 0 {
+		return 0
+	}
+	days := math.Ceil(ttl.Hours() / 24)
+	if days > math.MaxInt32 {
+		return math.MaxInt32
+	}
+	return int32(days)
+}
+
+func expiryTagValue(days int32) string {
+	return strconv.Itoa(int(days)) + "d"
 }
 
 // Get retrieves artifact data from S3.
@@ -0,0 +1,243 @@
+package artifacts
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+	pb "github.com/haasonsaas/nexus/pkg/proto"
+)
+
+// fakeS3 records the requests S3Store makes.
+type fakeS3 struct {
+	s3API
+
+	puts      []*s3.PutObjectInput
+	created   *s3.CreateMultipartUploadInput
+	parts     []int
+	completed *s3.CompleteMultipartUploadInput
+	aborted   bool
+	failPart  int32
+
+	lifecycle []types.LifecycleRule
+	putRules  []types.LifecycleRule
+}
+
+func (f *fakeS3) PutObject(_ context.Context, in *s3.PutObjectInput, _ ...func(*s3.Options)) (*s3.PutObjectOutput, error) {
+	f.puts = append(f.puts, in)
+	return &s3.PutObjectOutput{}, nil
+}
+
+func (f *fakeS3) CreateMultipartUpload(_ context.Context, in *s3.CreateMultipartUploadInput, _ ...func(*s3.Options)) (*s3.CreateMultipartUploadOutput, error) {
+	f.created = in
+	return &s3.CreateMultipartUploadOutput{UploadId: aws.String("upload-1")}, nil
+}
+
+func (f *fakeS3) UploadPart(_ context.Context, in *s3.UploadPartInput, _ ...func(*s3.Options)) (*s3.UploadPartOutput, error) {
+	if aws.ToInt32(in.PartNumber) == f.failPart {
+		return nil, errors.New("connection reset")
+	}
+	data, _ := io.ReadAll(in.Body)
+	f.parts = append(f.parts, len(data))
+	return &s3.UploadPartOutput{ETag: aws.String("etag")}, nil
+}
+
+func (f *fakeS3) CompleteMultipartUpload(_ context.Context, in *s3.CompleteMultipartUploadInput, _ ...func(*s3.Options)) (*s3.CompleteMultipartUploadOutput, error) {
+	f.completed = in
+	return &s3.CompleteMultipartUploadOutput{}, nil
+}
+
+func (f *fakeS3) AbortMultipartUpload(context.Context, *s3.AbortMultipartUploadInput, ...func(*s3.Options)) (*s3.AbortMultipartUploadOutput, error) {
+	f.aborted = true
+	return &s3.AbortMultipartUploadOutput{}, nil
+}
+
+func (f *fakeS3) GetBucketLifecycleConfiguration(context.Context, *s3.GetBucketLifecycleConfigurationInput, ...func(*s3.Options)) (*s3.GetBucketLifecycleConfigurationOutput, error) {
+	return &s3.GetBucketLifecycleConfigurationOutput{Rules: f.lifecycle}, nil
+}
+
+func (f *fakeS3) PutBucketLifecycleConfiguration(_ context.Context, in *s3.PutBucketLifecycleConfigurationInput, _ ...func(*s3.Options)) (*s3.PutBucketLifecycleConfigurationOutput, error) {
+	f.putRules = in.LifecycleConfiguration.Rules
+	return &s3.PutBucketLifecycleConfigurationOutput{}, nil
+}
+
+func newTestS3Store(t *testing.T, client *fakeS3, cfg S3StoreConfig) *S3Store {
+	t.Helper()
+	store, err := newS3Store(client, "nexus-artifacts", &cfg)
+	if err != nil {
+		t.Fatalf("newS3Store: %v", err)
+	}
+	return store
+}
+
+func TestS3Store_PutAppliesEncryptionAndExpiryTag(t *testing.T) {
+	client := &fakeS3{}
+	store := newTestS3Store(t, client, S3StoreConfig{
+		Prefix:    "nexus",
+		SSE:       "aws:kms",
+		KMSKeyID:  "alias/nexus",
+		Lifecycle: true,
+	})
+
+	ref, err := store.Put(context.Background(), "a1", strings.NewReader("hello"), PutOptions{MimeType: "text/plain", TTL: 36 * time.Hour})
+	if err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+	if ref != "s3://nexus-artifacts/nexus/a1" {
+		t.Errorf("reference = %q", ref)
+	}
+	if len(client.puts) != 1 || client.created != nil {
+		t.Fatalf("expected a single PutObject, got %d puts and multipart %v", len(client.puts), client.created != nil)
+	}
+	put := client.puts[0]
+	if put.ServerSideEncryption != types.ServerSideEncryptionAwsKms || aws.ToString(put.SSEKMSKeyId) != "alias/nexus" {
+		t.Errorf("encryption = %q key %q", put.ServerSideEncryption, aws.ToString(put.SSEKMSKeyId))
+	}
+	if aws.ToString(put.Tagging) != "nexus-expires=2d" {
+		t.Errorf("tagging = %q, want nexus-expires=2d", aws.ToString(put.Tagging))
+	}
+	if aws.ToInt64(put.ContentLength) != 5 {
+		t.Errorf("content length = %d, want 5", aws.ToInt64(put.ContentLength))
+	}
+}
+
+func TestS3Store_RejectsKMSKeyWithoutKMS(t *testing.T) {
+	if _, err := newS3Store(&fakeS3{}, "b", &S3StoreConfig{SSE: "AES256", KMSKeyID: "alias/nexus"}); err == nil {
+		t.Fatal("expected an error for a KMS key without aws:kms")
+	}
+	if _, err := newS3Store(&fakeS3{}, "b", &S3StoreConfig{SSE: "rot13"}); err == nil {
+		t.Fatal("expected an error for unknown encryption")
+	}
+}
+
+func TestS3Store_PutLargeUsesMultipart(t *testing.T) {
+	client := &fakeS3{}
+	store := newTestS3Store(t, client, S3StoreConfig{SSE: "AES256", MultipartThreshold: 1 << 20, PartSize: minS3PartSize})
+	data := bytes.Repeat([]byte("x"), 2*minS3PartSize+1024)
+
+	if _, err := store.Put(context.Background(), "big", bytes.NewReader(data), PutOptions{}); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+	if len(client.puts) != 0 || client.created == nil || client.completed == nil {
+		t.Fatalf("expected a completed multipart upload")
+	}
+	if client.created.ServerSideEncryption != types.ServerSideEncryptionAes256 {
+		t.Errorf("multipart encryption = %q", client.created.ServerSideEncryption)
+	}
+	want := []int{minS3PartSize, minS3PartSize, 1024}
+	if len(client.parts) != len(want) {
+		t.Fatalf("parts = %v, want %v", client.parts, want)
+	}
+	for i := range want {
+		if client.parts[i] != want[i] {
+			t.Fatalf("parts = %v, want %v", client.parts, want)
+		}
+	}
+	if n := len(client.completed.MultipartUpload.Parts); n != 3 {
+		t.Errorf("completed with %d parts, want 3", n)
+	}
+}
+
+func TestS3Store_MultipartFailureAborts(t *testing.T) {
+	client := &fakeS3{failPart: 2}
+	store := newTestS3Store(t, client, S3StoreConfig{MultipartThreshold: 1 << 20, PartSize: minS3PartSize})
+	data := bytes.Repeat([]byte("x"), 2*minS3PartSize)
+
+	if _, err := store.Put(context.Background(), "big", bytes.NewReader(data), PutOptions{}); err == nil {
+		t.Fatal("expected the failed part to fail the upload")
+	}
+	if !client.aborted || client.completed != nil {
+		t.Errorf("aborted = %v, completed = %v; want the upload aborted", client.aborted, client.completed != nil)
+	}
+}
+
+func TestS3Store_EnsureLifecycle(t *testing.T) {
+	client := &fakeS3{lifecycle: []types.LifecycleRule{
+		{ID: aws.String("archive-logs"), Status: types.ExpirationStatusEnabled},
+		{ID: aws.String("nexus-nexus-expire-3d"), Status: types.ExpirationStatusEnabled},
+	}}
+	store := newTestS3Store(t, client, S3StoreConfig{Prefix: "nexus", Lifecycle: true})
+
+	err := store.EnsureLifecycle(context.Background(), map[string]time.Duration{
+		"screenshot": 7 * 24 * time.Hour,
+		"file":       7 * 24 * time.Hour,
+		"default":    12 * time.Hour,
+	})
+	if err != nil {
+		t.Fatalf("EnsureLifecycle: %v", err)
+	}
+
+	ids := make(map[string]types.LifecycleRule)
+	for _, rule := range client.putRules {
+		ids[aws.ToString(rule.ID)] = rule
+	}
+	for _, want := range []string{"archive-logs", "nexus-nexus-abort-multipart", "nexus-nexus-expire-1d", "nexus-nexus-expire-7d"} {
+		if _, ok := ids[want]; !ok {
+			t.Errorf("missing rule %q in %v", want, ids)
+		}
+	}
+	if _, ok := ids["nexus-nexus-expire-3d"]; ok || len(ids) != 4 {
+		t.Errorf("stale managed rule kept or extra rules: %v", ids)
+	}
+	week := ids["nexus-nexus-expire-7d"]
+	if aws.ToInt32(week.Expiration.Days) != 7 {
+		t.Errorf("expiration days = %d, want 7", aws.ToInt32(week.Expiration.Days))
+	}
+	and := week.Filter.And
+	if and == nil || aws.ToString(and.Prefix) != "nexus/" || len(and.Tags) != 1 || aws.ToString(and.Tags[0].Value) != "7d" {
+		t.Errorf("unexpected filter %+v", week.Filter)
+	}
+}
+
+func TestMemoryRepository_PresignArtifact(t *testing.T) {
+	store := newTestS3Store(t, &fakeS3{}, S3StoreConfig{Prefix: "nexus"})
+	store.presigner = s3.NewPresignClient(s3.New(s3.Options{
+		Region:      "us-east-1",
+		Credentials: credentials.NewStaticCredentialsProvider("AKID", "SECRET", ""),
+	}))
+	repo := NewMemoryRepository(store, nil)
+	ctx := context.Background()
+
+	large := bytes.Repeat([]byte("x"), int(MaxInlineDataBytes)+1)
+	if err := repo.StoreArtifact(ctx, &pb.Artifact{Id: "rec", Type: "recording", MimeType: "video/mp4", Filename: "demo.mp4", Size: int64(len(large))}, bytes.NewReader(large)); err != nil {
+		t.Fatalf("StoreArtifact: %v", err)
+	}
+	if err := repo.StoreArtifact(ctx, &pb.Artifact{Id: "small", Type: "file", Size: 2}, bytes.NewReader([]byte("hi"))); err != nil {
+		t.Fatalf("StoreArtifact: %v", err)
+	}
+
+	link, expires, err := repo.PresignArtifact(ctx, "rec", PresignOptions{Download: true})
+	if err != nil {
+		t.Fatalf("PresignArtifact: %v", err)
+	}
+	parsed, err := url.Parse(link)
+	if err != nil {
+		t.Fatalf("parse presigned URL: %v", err)
+	}
+	if !strings.HasSuffix(parsed.Path, "/nexus/rec") {
+		t.Errorf("presigned path = %q", parsed.Path)
+	}
+	query := parsed.Query()
+	if query.Get("X-Amz-Expires") != "900" {
+		t.Errorf("X-Amz-Expires = %q, want 900", query.Get("X-Amz-Expires"))
+	}
+	if !strings.Contains(query.Get("response-content-disposition"), "demo.mp4") {
+		t.Errorf("content disposition = %q", query.Get("response-content-disposition"))
+	}
+	if time.Until(expires) > defaultS3PresignTTL {
+		t.Errorf("expires = %v, want within the default TTL", expires)
+	}
+
+	if _, _, err := repo.PresignArtifact(ctx, "small", PresignOptions{}); !errors.Is(err, ErrPresignUnsupported) {
+		t.Errorf("inline artifact presign error = %v, want ErrPresignUnsupported", err)
+	}
+}
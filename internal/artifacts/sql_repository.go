@@ -12,18 +12,78 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/haasonsaas/nexus/internal/observability"
+	"github.com/haasonsaas/nexus/internal/storage/encryption"
 	pb "github.com/haasonsaas/nexus/pkg/proto"
 )
 
+// postgresSchema creates the artifacts table on CockroachDB/Postgres.
+var postgresSchema = []string{
+	`CREATE TABLE IF NOT EXISTS artifacts (
+		id STRING PRIMARY KEY,
+		session_id STRING,
+		edge_id STRING,
+		type STRING,
+		mime_type STRING,
+		filename STRING,
+		size INT8,
+		reference STRING,
+		ttl_seconds INT4,
+		created_at TIMESTAMPTZ NOT NULL,
+		expires_at TIMESTAMPTZ
+	)`,
+	`CREATE INDEX IF NOT EXISTS idx_artifacts_session_id ON artifacts (session_id)`,
+	`CREATE INDEX IF NOT EXISTS idx_artifacts_edge_id ON artifacts (edge_id)`,
+	`CREATE INDEX IF NOT EXISTS idx_artifacts_type ON artifacts (type)`,
+	`CREATE INDEX IF NOT EXISTS idx_artifacts_created_at ON artifacts (created_at DESC)`,
+	`CREATE INDEX IF NOT EXISTS idx_artifacts_expires_at ON artifacts (expires_at)`,
+}
+
+// sqliteSchema creates the artifacts table on SQLite.
+var sqliteSchema = []string{
+	`CREATE TABLE IF NOT EXISTS artifacts (
+		id TEXT PRIMARY KEY,
+		session_id TEXT,
+		edge_id TEXT,
+		type TEXT,
+		mime_type TEXT,
+		filename TEXT,
+		size INTEGER,
+		reference TEXT,
+		ttl_seconds INTEGER,
+		created_at TIMESTAMP NOT NULL,
+		expires_at TIMESTAMP
+	)`,
+	`CREATE INDEX IF NOT EXISTS idx_artifacts_session_id ON artifacts (session_id)`,
+	`CREATE INDEX IF NOT EXISTS idx_artifacts_edge_id ON artifacts (edge_id)`,
+	`CREATE INDEX IF NOT EXISTS idx_artifacts_type ON artifacts (type)`,
+	`CREATE INDEX IF NOT EXISTS idx_artifacts_created_at ON artifacts (created_at DESC)`,
+	`CREATE INDEX IF NOT EXISTS idx_artifacts_expires_at ON artifacts (expires_at)`,
+}
+
 // SQLRepository stores artifact metadata in SQL and artifact data in a Store backend.
 type SQLRepository struct {
 	db     *sql.DB
 	store  Store
 	logger *slog.Logger
+	schema []string
+
+	// keyring encrypts filenames at rest when set.
+	keyring *encryption.Keyring
 }
 
-// NewSQLRepository creates a SQL-backed repository and ensures the schema exists.
+// NewSQLRepository creates a CockroachDB/Postgres-backed repository and
+// ensures the schema exists.
 func NewSQLRepository(db *sql.DB, store Store, logger *slog.Logger) (*SQLRepository, error) {
+	return newSQLRepository(db, store, logger, postgresSchema)
+}
+
+// NewSQLiteRepository creates a SQLite-backed repository and ensures the
+// schema exists.
+func NewSQLiteRepository(db *sql.DB, store Store, logger *slog.Logger) (*SQLRepository, error) {
+	return newSQLRepository(db, store, logger, sqliteSchema)
+}
+
+func newSQLRepository(db *sql.DB, store Store, logger *slog.Logger, schema []string) (*SQLRepository, error) {
 	if db == nil {
 		return nil, fmt.Errorf("db is required")
 	}
@@ -37,6 +97,7 @@ func NewSQLRepository(db *sql.DB, store Store, logger *slog.Logger) (*SQLReposit
 		db:     db,
 		store:  store,
 		logger: logger,
+		schema: schema,
 	}
 	if err := repo.ensureSchema(context.Background()); err != nil {
 		return nil, err
@@ -98,6 +159,9 @@ func (r *SQLRepository) StoreArtifact(ctx context.Context, artifact *pb.Artifact
 		if err := r.upsertMetadata(ctx, meta); err != nil {
 			return err
 		}
+		if err := r.AddReference(ctx, meta.ID, meta.SessionID, ""); err != nil {
+			r.logger.Warn("failed to record artifact reference", "id", meta.ID, "error", err)
+		}
 		r.logger.Info("artifact redacted", "id", artifact.Id, "type", artifact.Type)
 		return nil
 	}
@@ -129,6 +193,9 @@ func (r *SQLRepository) StoreArtifact(ctx context.Context, artifact *pb.Artifact
 		}
 		return err
 	}
+	if err := r.AddReference(ctx, meta.ID, meta.SessionID, ""); err != nil {
+		r.logger.Warn("failed to record artifact reference", "id", meta.ID, "error", err)
+	}
 
 	r.logger.Info("artifact stored",
 		"id", artifact.Id,
@@ -176,9 +243,9 @@ func (r *SQLRepository) GetArtifact(ctx context.Context, artifactID string) (*pb
 
 // ListArtifacts finds artifacts matching criteria.
 func (r *SQLRepository) ListArtifacts(ctx context.Context, filter Filter) ([]*pb.Artifact, error) {
-	where := []string{"(expires_at IS NULL OR expires_at > now())"}
-	args := []any{}
-	argIdx := 1
+	where := []string{"(expires_at IS NULL OR expires_at > $1)"}
+	args := []any{time.Now()}
+	argIdx := 2
 
 	if filter.SessionID != "" {
 		where = append(where, fmt.Sprintf("session_id = $%d", argIdx))
@@ -225,7 +292,7 @@ func (r *SQLRepository) ListArtifacts(ctx context.Context, filter Filter) ([]*pb
 
 	var results []*pb.Artifact
 	for rows.Next() {
-		meta, err := scanMetadata(rows)
+		meta, err := r.scanMetadata(rows)
 		if err != nil {
 			return nil, err
 		}
@@ -257,6 +324,9 @@ func (r *SQLRepository) DeleteArtifact(ctx context.Context, artifactID string) e
 	if _, err := r.db.ExecContext(ctx, `DELETE FROM artifacts WHERE id = $1`, artifactID); err != nil {
 		return fmt.Errorf("delete artifact metadata: %w", err)
 	}
+	if _, err := r.db.ExecContext(ctx, `DELETE FROM artifact_refs WHERE artifact_id = $1`, artifactID); err != nil {
+		r.logger.Warn("failed to delete artifact references", "id", artifactID, "error", err)
+	}
 	if meta.Reference != "" && !strings.HasPrefix(meta.Reference, "redacted://") {
 		if err := r.store.Delete(ctx, artifactID); err != nil {
 			r.logger.Warn("failed to delete artifact from store",
@@ -270,7 +340,7 @@ func (r *SQLRepository) DeleteArtifact(ctx context.Context, artifactID string) e
 
 // PruneExpired removes expired artifacts.
 func (r *SQLRepository) PruneExpired(ctx context.Context) (int, error) {
-	rows, err := r.db.QueryContext(ctx, `SELECT id FROM artifacts WHERE expires_at IS NOT NULL AND expires_at <= now()`)
+	rows, err := r.db.QueryContext(ctx, `SELECT id FROM artifacts WHERE expires_at IS NOT NULL AND expires_at <= $1`, time.Now())
 	if err != nil {
 		return 0, fmt.Errorf("list expired artifacts: %w", err)
 	}
@@ -298,28 +368,79 @@ func (r *SQLRepository) PruneExpired(ctx context.Context) (int, error) {
 	return count, nil
 }
 
+// AddReference records that a session or message refers to an artifact.
+func (r *SQLRepository) AddReference(ctx context.Context, artifactID, sessionID, messageID string) error {
+	if sessionID == "" {
+		return nil
+	}
+	_, err := r.db.ExecContext(ctx, `
+		INSERT INTO artifact_refs (artifact_id, session_id, message_id)
+		SELECT id, $2, $3 FROM artifacts WHERE id = $1
+		ON CONFLICT (artifact_id, session_id, message_id) DO UPDATE SET released_at = NULL`,
+		artifactID, sessionID, messageID)
+	if err != nil {
+		return fmt.Errorf("add artifact reference: %w", err)
+	}
+	return nil
+}
+
+// ReleaseSession releases every reference held by a session.
+func (r *SQLRepository) ReleaseSession(ctx context.Context, sessionID string) error {
+	_, err := r.db.ExecContext(ctx,
+		`UPDATE artifact_refs SET released_at = $2 WHERE session_id = $1 AND released_at IS NULL`,
+		sessionID, time.Now())
+	if err != nil {
+		return fmt.Errorf("release artifact references: %w", err)
+	}
+	return nil
+}
+
+// PruneUnreferenced removes artifacts whose references were all released.
+func (r *SQLRepository) PruneUnreferenced(ctx context.Context) (PruneResult, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT a.id, COALESCE(a.size, 0) FROM artifacts a
+		WHERE EXISTS (SELECT 1 FROM artifact_refs r WHERE r.artifact_id = a.id)
+		AND NOT EXISTS (SELECT 1 FROM artifact_refs r WHERE r.artifact_id = a.id AND r.released_at IS NULL)`)
+	if err != nil {
+		return PruneResult{}, fmt.Errorf("list unreferenced artifacts: %w", err)
+	}
+	defer rows.Close()
+
+	candidates := make(map[string]int64)
+	for rows.Next() {
+		var id string
+		var size int64
+		if err := rows.Scan(&id, &size); err != nil {
+			return PruneResult{}, fmt.Errorf("scan unreferenced artifact: %w", err)
+		}
+		candidates[id] = size
+	}
+	if err := rows.Err(); err != nil {
+		return PruneResult{}, fmt.Errorf("list unreferenced artifacts: %w", err)
+	}
+
+	var result PruneResult
+	for id, size := range candidates {
+		if err := r.DeleteArtifact(ctx, id); err == nil {
+			result.Count++
+			result.Bytes += size
+		}
+	}
+	return result, nil
+}
+
+// PresignArtifact returns a direct download URL when the artifact's data is
+// held by a presigning store.
+func (r *SQLRepository) PresignArtifact(ctx context.Context, artifactID string, opts PresignOptions) (string, time.Time, error) {
+	meta, err := r.fetchMetadata(ctx, artifactID)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	return presignMetadata(ctx, r.store, meta, opts)
+}
+
 func (r *SQLRepository) ensureSchema(ctx context.Context) error {
-	statements := []string{
-		`CREATE TABLE IF NOT EXISTS artifacts (
-			id STRING PRIMARY KEY,
-			session_id STRING,
-			edge_id STRING,
-			type STRING,
-			mime_type STRING,
-			filename STRING,
-			size INT8,
-			reference STRING,
-			ttl_seconds INT4,
-			created_at TIMESTAMPTZ NOT NULL,
-			expires_at TIMESTAMPTZ
-		)`,
-		`CREATE INDEX IF NOT EXISTS idx_artifacts_session_id ON artifacts (session_id)`,
-		`CREATE INDEX IF NOT EXISTS idx_artifacts_edge_id ON artifacts (edge_id)`,
-		`CREATE INDEX IF NOT EXISTS idx_artifacts_type ON artifacts (type)`,
-		`CREATE INDEX IF NOT EXISTS idx_artifacts_created_at ON artifacts (created_at DESC)`,
-		`CREATE INDEX IF NOT EXISTS idx_artifacts_expires_at ON artifacts (expires_at)`,
-	}
-	for _, stmt := range statements {
+	for _, stmt := range r.schema {
 		if _, err := r.db.ExecContext(ctx, stmt); err != nil {
 			return fmt.Errorf("ensure artifacts schema: %w", err)
 		}
@@ -331,7 +452,11 @@ func (r *SQLRepository) upsertMetadata(ctx context.Context, meta *Metadata) erro
 	if meta == nil {
 		return fmt.Errorf("metadata is required")
 	}
-	_, err := r.db.ExecContext(ctx, `
+	filename, err := r.keyring.Encrypt(meta.Filename)
+	if err != nil {
+		return fmt.Errorf("encrypt artifact filename: %w", err)
+	}
+	_, err = r.db.ExecContext(ctx, `
 		INSERT INTO artifacts (
 			id, session_id, edge_id, type, mime_type, filename, size, reference,
 			ttl_seconds, created_at, expires_at
@@ -353,7 +478,7 @@ func (r *SQLRepository) upsertMetadata(ctx context.Context, meta *Metadata) erro
 		nullString(meta.EdgeID),
 		meta.Type,
 		meta.MimeType,
-		meta.Filename,
+		filename,
 		meta.Size,
 		meta.Reference,
 		meta.TTLSeconds,
@@ -371,7 +496,7 @@ func (r *SQLRepository) fetchMetadata(ctx context.Context, artifactID string) (*
 		SELECT id, session_id, edge_id, type, mime_type, filename, size, reference, ttl_seconds, created_at, expires_at
 		FROM artifacts WHERE id = $1
 	`, artifactID)
-	meta, err := scanMetadata(row)
+	meta, err := r.scanMetadata(row)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, fmt.Errorf("artifact not found: %s: %w", artifactID, err)
@@ -385,7 +510,7 @@ type metadataScanner interface {
 	Scan(dest ...any) error
 }
 
-func scanMetadata(row metadataScanner) (*Metadata, error) {
+func (r *SQLRepository) scanMetadata(row metadataScanner) (*Metadata, error) {
 	var meta Metadata
 	var sessionID sql.NullString
 	var edgeID sql.NullString
@@ -422,7 +547,11 @@ func scanMetadata(row metadataScanner) (*Metadata, error) {
 		meta.MimeType = mimeType.String
 	}
 	if filename.Valid {
-		meta.Filename = filename.String
+		plaintext, err := r.keyring.Decrypt(filename.String)
+		if err != nil {
+			return nil, fmt.Errorf("decrypt artifact %s filename: %w", meta.ID, err)
+		}
+		meta.Filename = plaintext
 	}
 	if reference.Valid {
 		meta.Reference = reference.String
@@ -0,0 +1,80 @@
+package artifacts
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"log/slog"
+	"path/filepath"
+	"testing"
+
+	"github.com/haasonsaas/nexus/internal/storage/sqlite"
+	pb "github.com/haasonsaas/nexus/pkg/proto"
+)
+
+func TestSQLiteRepository_StoreListPrune(t *testing.T) {
+	dir := t.TempDir()
+	store, err := NewLocalStore(filepath.Join(dir, "data"))
+	if err != nil {
+		t.Fatalf("NewLocalStore: %v", err)
+	}
+	db, err := sqlite.Open("sqlite://" + filepath.Join(dir, "nexus.db"))
+	if err != nil {
+		t.Fatalf("sqlite.Open: %v", err)
+	}
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	repo, err := NewSQLiteRepository(db, store, logger)
+	if err != nil {
+		t.Fatalf("NewSQLiteRepository: %v", err)
+	}
+	defer repo.Close()
+
+	ctx := context.Background()
+	payload := []byte("artifact-data")
+	if err := repo.StoreArtifact(ctx, &pb.Artifact{
+		Id:       "live",
+		Type:     "file",
+		MimeType: "text/plain",
+		Filename: "note.txt",
+		Size:     int64(len(payload)),
+	}, bytes.NewReader(payload)); err != nil {
+		t.Fatalf("StoreArtifact: %v", err)
+	}
+	if err := repo.StoreArtifact(ctx, &pb.Artifact{
+		Id:         "expired",
+		Type:       "file",
+		Size:       int64(len(payload)),
+		TtlSeconds: -1,
+	}, bytes.NewReader(payload)); err != nil {
+		t.Fatalf("StoreArtifact: %v", err)
+	}
+
+	listed, err := repo.ListArtifacts(ctx, Filter{Type: "file"})
+	if err != nil {
+		t.Fatalf("ListArtifacts: %v", err)
+	}
+	if len(listed) != 1 || listed[0].Id != "live" {
+		t.Fatalf("expected only the live artifact, got %+v", listed)
+	}
+
+	got, reader, err := repo.GetArtifact(ctx, "live")
+	if err != nil {
+		t.Fatalf("GetArtifact: %v", err)
+	}
+	defer reader.Close()
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		t.Fatalf("read artifact: %v", err)
+	}
+	if got.Filename != "note.txt" || !bytes.Equal(data, payload) {
+		t.Fatalf("unexpected artifact %+v with data %q", got, data)
+	}
+
+	pruned, err := repo.PruneExpired(ctx)
+	if err != nil {
+		t.Fatalf("PruneExpired: %v", err)
+	}
+	if pruned != 1 {
+		t.Fatalf("expected 1 pruned artifact, got %d", pruned)
+	}
+}
@@ -84,6 +84,10 @@ type Metadata struct {
 	TTLSeconds int32
 	CreatedAt  time.Time
 	ExpiresAt  time.Time
+
+	// References lists the sessions and messages referring to the artifact.
+	// SQLRepository keeps them in the artifact_refs table instead.
+	References []Reference `json:",omitempty"`
 }
 
 // defaultTTLs provides default retention periods by artifact type.
@@ -123,6 +127,17 @@ func SetDefaultTTLs(ttls map[string]time.Duration) {
 	defaultTTLs = merged
 }
 
+// defaultTTLSnapshot returns a copy of the default TTLs.
+func defaultTTLSnapshot() map[string]time.Duration {
+	defaultTTLsMu.RLock()
+	defer defaultTTLsMu.RUnlock()
+	snapshot := make(map[string]time.Duration, len(defaultTTLs))
+	for key, value := range defaultTTLs {
+		snapshot[key] = value
+	}
+	return snapshot
+}
+
 // GetDefaultTTL returns the default TTL for an artifact type.
 func GetDefaultTTL(artifactType string) time.Duration {
 	key := strings.ToLower(strings.TrimSpace(artifactType))
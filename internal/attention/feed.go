@@ -2,6 +2,7 @@ package attention
 
 import (
 	"context"
+	"fmt"
 	"sort"
 	"sync"
 	"time"
@@ -71,11 +72,18 @@ type FeedStats struct {
 	NewestItem   *time.Time     `json:"newest_item,omitempty"`
 }
 
+// storeTimeout bounds each write-through to the feed's store.
+const storeTimeout = 5 * time.Second
+
 // Feed aggregates attention items from multiple channels.
 type Feed struct {
 	items    map[string]*Item
 	mu       sync.RWMutex
 	handlers []ItemHandler
+
+	// store, when set by Load, receives every change to the feed.
+	store        Store
+	onStoreError func(error)
 }
 
 // ItemHandler is called when items are added or updated.
@@ -88,12 +96,39 @@ func NewFeed() *Feed {
 	}
 }
 
+// Load attaches a persistent store and replaces the feed contents with the
+// items it holds. Every later change is written through to the store.
+func (f *Feed) Load(ctx context.Context, store Store) error {
+	items, err := store.List(ctx)
+	if err != nil {
+		return err
+	}
+	f.mu.Lock()
+	f.store = store
+	f.items = make(map[string]*Item, len(items))
+	for _, item := range items {
+		f.items[item.ID] = item
+	}
+	f.mu.Unlock()
+	return nil
+}
+
+// OnStoreError registers a callback for failed writes to the store. Writes
+// are best-effort so the in-memory feed stays usable when the store is down.
+func (f *Feed) OnStoreError(handler func(error)) {
+	f.mu.Lock()
+	f.onStoreError = handler
+	f.mu.Unlock()
+}
+
 // Add adds a new item to the feed.
 func (f *Feed) Add(item *Item) {
 	f.mu.Lock()
 	f.items[item.ID] = item
+	snapshot := *item
 	f.mu.Unlock()
 
+	f.save(&snapshot)
 	f.notifyHandlers(item, "added")
 }
 
@@ -120,8 +155,10 @@ func (f *Feed) Update(item *Item) bool {
 		return false
 	}
 	f.items[item.ID] = item
+	snapshot := *item
 	f.mu.Unlock()
 
+	f.save(&snapshot)
 	f.notifyHandlers(item, "updated")
 	return true
 }
@@ -136,6 +173,7 @@ func (f *Feed) Remove(id string) bool {
 	f.mu.Unlock()
 
 	if exists {
+		f.delete(id)
 		f.notifyHandlers(item, "removed")
 	}
 	return exists
@@ -145,12 +183,15 @@ func (f *Feed) Remove(id string) bool {
 func (f *Feed) MarkViewed(id string) bool {
 	f.mu.Lock()
 	item, exists := f.items[id]
+	var snapshot Item
 	if exists {
 		item.SetViewed()
+		snapshot = *item
 	}
 	f.mu.Unlock()
 
 	if exists {
+		f.save(&snapshot)
 		f.notifyHandlers(item, "viewed")
 	}
 	return exists
@@ -160,12 +201,15 @@ func (f *Feed) MarkViewed(id string) bool {
 func (f *Feed) MarkHandled(id string) bool {
 	f.mu.Lock()
 	item, exists := f.items[id]
+	var snapshot Item
 	if exists {
 		item.SetHandled()
+		snapshot = *item
 	}
 	f.mu.Unlock()
 
 	if exists {
+		f.save(&snapshot)
 		f.notifyHandlers(item, "handled")
 	}
 	return exists
@@ -175,12 +219,15 @@ func (f *Feed) MarkHandled(id string) bool {
 func (f *Feed) Snooze(id string, until time.Time) bool {
 	f.mu.Lock()
 	item, exists := f.items[id]
+	var snapshot Item
 	if exists {
 		item.Snooze(until)
+		snapshot = *item
 	}
 	f.mu.Unlock()
 
 	if exists {
+		f.save(&snapshot)
 		f.notifyHandlers(item, "snoozed")
 	}
 	return exists
@@ -190,12 +237,15 @@ func (f *Feed) Snooze(id string, until time.Time) bool {
 func (f *Feed) Unsnooze(id string) bool {
 	f.mu.Lock()
 	item, exists := f.items[id]
+	var snapshot Item
 	if exists {
 		item.Unsnooze()
+		snapshot = *item
 	}
 	f.mu.Unlock()
 
 	if exists {
+		f.save(&snapshot)
 		f.notifyHandlers(item, "unsnoozed")
 	}
 	return exists
@@ -301,9 +351,9 @@ func (f *Feed) OnItemChange(handler ItemHandler) {
 // WakeSnoozed checks for snoozed items that should be unsnoozed.
 func (f *Feed) WakeSnoozed() []*Item {
 	f.mu.Lock()
-	defer f.mu.Unlock()
 
 	var woken []*Item
+	var snapshots []Item
 	now := time.Now()
 
 	for _, item := range f.items {
@@ -311,31 +361,71 @@ func (f *Feed) WakeSnoozed() []*Item {
 			if now.After(*item.SnoozedUntil) {
 				item.Unsnooze()
 				woken = append(woken, item)
+				snapshots = append(snapshots, *item)
 			}
 		}
 	}
+	f.mu.Unlock()
 
+	for i := range snapshots {
+		f.save(&snapshots[i])
+	}
 	return woken
 }
 
 // Prune removes old handled/archived items.
 func (f *Feed) Prune(olderThan time.Duration) int {
 	f.mu.Lock()
-	defer f.mu.Unlock()
 
 	threshold := time.Now().Add(-olderThan)
-	var removed int
+	var removed []string
 
 	for id, item := range f.items {
 		if item.Status == StatusHandled || item.Status == StatusArchived {
 			if item.HandledAt != nil && item.HandledAt.Before(threshold) {
 				delete(f.items, id)
-				removed++
+				removed = append(removed, id)
 			}
 		}
 	}
+	f.mu.Unlock()
+
+	for _, id := range removed {
+		f.delete(id)
+	}
+	return len(removed)
+}
+
+// save writes an item snapshot through to the store, if one is attached.
+func (f *Feed) save(item *Item) {
+	store, onError := f.storeHooks()
+	if store == nil {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), storeTimeout)
+	defer cancel()
+	if err := store.Save(ctx, item); err != nil && onError != nil {
+		onError(fmt.Errorf("save attention item %s: %w", item.ID, err))
+	}
+}
 
-	return removed
+// delete removes an item from the store, if one is attached.
+func (f *Feed) delete(id string) {
+	store, onError := f.storeHooks()
+	if store == nil {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), storeTimeout)
+	defer cancel()
+	if err := store.Delete(ctx, id); err != nil && onError != nil {
+		onError(fmt.Errorf("delete attention item %s: %w", id, err))
+	}
+}
+
+func (f *Feed) storeHooks() (Store, func(error)) {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	return f.store, f.onStoreError
 }
 
 // matchesOptions checks if an item matches filter options.
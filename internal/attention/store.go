@@ -0,0 +1,91 @@
+package attention
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Store persists attention items so the feed survives restarts.
+type Store interface {
+	// Save inserts or replaces an item.
+	Save(ctx context.Context, item *Item) error
+	// Delete removes an item by ID.
+	Delete(ctx context.Context, id string) error
+	// List returns every stored item.
+	List(ctx context.Context) ([]*Item, error)
+}
+
+// DBStore implements Store using the attention_items table.
+type DBStore struct {
+	db *sql.DB
+}
+
+// NewDBStore creates a DB-backed attention store.
+func NewDBStore(db *sql.DB) (*DBStore, error) {
+	if db == nil {
+		return nil, errors.New("db is required")
+	}
+	return &DBStore{db: db}, nil
+}
+
+// Save upserts the item row. The original message is not persisted; the
+// item already carries its content and sender.
+func (s *DBStore) Save(ctx context.Context, item *Item) error {
+	if item == nil || item.ID == "" {
+		return errors.New("item id is required")
+	}
+	stored := *item
+	stored.OriginalMessage = nil
+	data, err := json.Marshal(&stored)
+	if err != nil {
+		return fmt.Errorf("marshal attention item: %w", err)
+	}
+	_, err = s.db.ExecContext(ctx, `
+		INSERT INTO attention_items (id, status, priority, received_at, data, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (id) DO UPDATE
+		SET status = EXCLUDED.status,
+			priority = EXCLUDED.priority,
+			received_at = EXCLUDED.received_at,
+			data = EXCLUDED.data,
+			updated_at = EXCLUDED.updated_at
+	`, item.ID, string(item.Status), int(item.Priority), item.ReceivedAt, string(data), time.Now())
+	return err
+}
+
+// Delete removes the item row.
+func (s *DBStore) Delete(ctx context.Context, id string) error {
+	_, err := s.db.ExecContext(ctx, `DELETE FROM attention_items WHERE id = $1`, id)
+	return err
+}
+
+// List returns all item rows, newest first.
+func (s *DBStore) List(ctx context.Context) ([]*Item, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT data
+		FROM attention_items
+		ORDER BY received_at DESC
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var items []*Item
+	for rows.Next() {
+		var data []byte
+		if err := rows.Scan(&data); err != nil {
+			return nil, err
+		}
+		var item Item
+		if err := json.Unmarshal(data, &item); err != nil {
+			return nil, fmt.Errorf("decode attention item: %w", err)
+		}
+		items = append(items, &item)
+	}
+	return items, rows.Err()
+}
@@ -0,0 +1,144 @@
+package attention
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/haasonsaas/nexus/pkg/models"
+)
+
+func TestDBStoreSaveAndList(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock: %v", err)
+	}
+	defer db.Close()
+
+	store, err := NewDBStore(db)
+	if err != nil {
+		t.Fatalf("NewDBStore: %v", err)
+	}
+	ctx := context.Background()
+	item := &Item{
+		ID:              "item-1",
+		Type:            ItemTypeTask,
+		Title:           "Renew certificate",
+		Priority:        PriorityHigh,
+		Status:          StatusNew,
+		ReceivedAt:      time.Now(),
+		OriginalMessage: &models.Message{ID: "msg-1"},
+	}
+
+	mock.ExpectExec("INSERT INTO attention_items").
+		WithArgs("item-1", "new", 3, sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	if err := store.Save(ctx, item); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	stored := *item
+	stored.OriginalMessage = nil
+	data, _ := json.Marshal(&stored)
+	mock.ExpectQuery("SELECT data").
+		WillReturnRows(sqlmock.NewRows([]string{"data"}).AddRow(data))
+	items, err := store.List(ctx)
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if len(items) != 1 || items[0].Title != "Renew certificate" || items[0].Priority != PriorityHigh {
+		t.Fatalf("List = %+v", items)
+	}
+
+	mock.ExpectExec("DELETE FROM attention_items").
+		WithArgs("item-1").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	if err := store.Delete(ctx, "item-1"); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("expectations: %v", err)
+	}
+}
+
+type memoryStore struct {
+	items map[string]Item
+	err   error
+}
+
+func (s *memoryStore) Save(_ context.Context, item *Item) error {
+	if s.err != nil {
+		return s.err
+	}
+	s.items[item.ID] = *item
+	return nil
+}
+
+func (s *memoryStore) Delete(_ context.Context, id string) error {
+	delete(s.items, id)
+	return nil
+}
+
+func (s *memoryStore) List(_ context.Context) ([]*Item, error) {
+	var items []*Item
+	for _, item := range s.items {
+		item := item
+		items = append(items, &item)
+	}
+	return items, nil
+}
+
+func TestFeedWritesThroughToStore(t *testing.T) {
+	store := &memoryStore{items: map[string]Item{
+		"old": {ID: "old", Status: StatusViewed, ReceivedAt: time.Now().Add(-time.Hour)},
+	}}
+	feed := NewFeed()
+	if err := feed.Load(context.Background(), store); err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if _, ok := feed.Get("old"); !ok {
+		t.Fatal("expected stored item to be loaded")
+	}
+
+	feed.Add(&Item{ID: "new", Status: StatusNew, ReceivedAt: time.Now()})
+	feed.Snooze("new", time.Now().Add(-time.Second))
+	if store.items["new"].Status != StatusSnoozed {
+		t.Fatalf("stored status = %q, want snoozed", store.items["new"].Status)
+	}
+	feed.WakeSnoozed()
+	if store.items["new"].Status != StatusNew {
+		t.Fatalf("stored status after wake = %q, want new", store.items["new"].Status)
+	}
+
+	feed.MarkHandled("old")
+	past := time.Now().Add(-2 * time.Hour)
+	feed.items["old"].HandledAt = &past
+	if removed := feed.Prune(time.Hour); removed != 1 {
+		t.Fatalf("Prune removed %d, want 1", removed)
+	}
+	if _, ok := store.items["old"]; ok {
+		t.Fatal("expected pruned item to be deleted from store")
+	}
+}
+
+func TestFeedReportsStoreErrors(t *testing.T) {
+	store := &memoryStore{items: map[string]Item{}}
+	feed := NewFeed()
+	if err := feed.Load(context.Background(), store); err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	var reported error
+	feed.OnStoreError(func(err error) { reported = err })
+	store.err = errors.New("db down")
+
+	feed.Add(&Item{ID: "item-1", Status: StatusNew})
+	if reported == nil {
+		t.Fatal("expected store error to be reported")
+	}
+	if _, ok := feed.Get("item-1"); !ok {
+		t.Fatal("expected item to stay in memory when the store fails")
+	}
+}
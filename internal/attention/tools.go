@@ -8,6 +8,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/haasonsaas/nexus/internal/agent"
 	"github.com/haasonsaas/nexus/pkg/models"
 )
@@ -98,17 +99,8 @@ func (t *ListAttentionTool) Execute(ctx context.Context, params json.RawMessage)
 	}
 
 	// Map priority
-	switch strings.ToLower(input.Priority) {
-	case "low":
-		opts.MinPriority = PriorityLow
-	case "normal":
-		opts.MinPriority = PriorityNormal
-	case "high":
-		opts.MinPriority = PriorityHigh
-	case "urgent":
-		opts.MinPriority = PriorityUrgent
-	case "critical":
-		opts.MinPriority = PriorityCritical
+	if priority, ok := parsePriority(input.Priority); ok {
+		opts.MinPriority = priority
 	}
 
 	// Map sort
@@ -238,6 +230,127 @@ func (t *GetAttentionTool) Execute(ctx context.Context, params json.RawMessage)
 	}, nil
 }
 
+// AddAttentionTool adds an agent-created item to the attention feed.
+type AddAttentionTool struct {
+	feed *Feed
+}
+
+// NewAddAttentionTool creates a new add attention tool.
+func NewAddAttentionTool(feed *Feed) *AddAttentionTool {
+	return &AddAttentionTool{feed: feed}
+}
+
+func (t *AddAttentionTool) Name() string {
+	return "attention_add"
+}
+
+func (t *AddAttentionTool) Description() string {
+	return "Add a task, reminder, or follow-up to the attention feed so it is tracked until completed"
+}
+
+func (t *AddAttentionTool) Schema() json.RawMessage {
+	return json.RawMessage(`{
+		"type": "object",
+		"properties": {
+			"title": {
+				"type": "string",
+				"description": "Short summary of what needs attention"
+			},
+			"content": {
+				"type": "string",
+				"description": "Optional details"
+			},
+			"type": {
+				"type": "string",
+				"description": "Item type (default task)",
+				"enum": ["task", "reminder", "notification"]
+			},
+			"priority": {
+				"type": "string",
+				"description": "Priority: low, normal, high, urgent, critical (default normal)",
+				"enum": ["low", "normal", "high", "urgent", "critical"]
+			},
+			"tags": {
+				"type": "array",
+				"items": {"type": "string"},
+				"description": "Optional tags for filtering"
+			}
+		},
+		"required": ["title"]
+	}`)
+}
+
+func (t *AddAttentionTool) Execute(ctx context.Context, params json.RawMessage) (*agent.ToolResult, error) {
+	var input struct {
+		Title    string   `json:"title"`
+		Content  string   `json:"content"`
+		Type     string   `json:"type"`
+		Priority string   `json:"priority"`
+		Tags     []string `json:"tags"`
+	}
+
+	if err := json.Unmarshal(params, &input); err != nil {
+		return nil, fmt.Errorf("parse params: %w", err)
+	}
+
+	title := strings.TrimSpace(input.Title)
+	if title == "" {
+		return &agent.ToolResult{
+			Content: "title is required",
+			IsError: true,
+		}, nil
+	}
+
+	priority := PriorityNormal
+	if input.Priority != "" {
+		parsed, ok := parsePriority(input.Priority)
+		if !ok {
+			return &agent.ToolResult{
+				Content: fmt.Sprintf("Invalid priority: %s", input.Priority),
+				IsError: true,
+			}, nil
+		}
+		priority = parsed
+	}
+
+	itemType := ItemTypeTask
+	switch ItemType(strings.ToLower(input.Type)) {
+	case "", ItemTypeTask:
+	case ItemTypeReminder:
+		itemType = ItemTypeReminder
+	case ItemTypeNotification:
+		itemType = ItemTypeNotification
+	default:
+		return &agent.ToolResult{
+			Content: fmt.Sprintf("Invalid type: %s", input.Type),
+			IsError: true,
+		}, nil
+	}
+
+	content := strings.TrimSpace(input.Content)
+	preview := content
+	if preview == "" {
+		preview = title
+	}
+	item := &Item{
+		ID:         uuid.NewString(),
+		Type:       itemType,
+		Title:      truncate(title, 80),
+		Preview:    truncate(preview, 200),
+		Content:    content,
+		Sender:     Sender{ID: "agent", Name: "agent"},
+		Priority:   priority,
+		Status:     StatusNew,
+		ReceivedAt: time.Now(),
+		Tags:       input.Tags,
+	}
+	t.feed.Add(item)
+
+	return &agent.ToolResult{
+		Content: fmt.Sprintf("Added %s %s [%s]: %s", item.Type, item.ID, priorityName(item.Priority), item.Title),
+	}, nil
+}
+
 // HandleAttentionTool marks an attention item as handled.
 type HandleAttentionTool struct {
 	feed *Feed
@@ -253,7 +366,7 @@ func (t *HandleAttentionTool) Name() string {
 }
 
 func (t *HandleAttentionTool) Description() string {
-	return "Mark an attention item as handled/resolved"
+	return "Mark an attention item as handled/resolved (use this to complete a task)"
 }
 
 func (t *HandleAttentionTool) Schema() json.RawMessage {
@@ -449,6 +562,24 @@ func (t *StatsAttentionTool) Execute(ctx context.Context, params json.RawMessage
 	}, nil
 }
 
+// parsePriority maps a priority name to its level.
+func parsePriority(name string) (Priority, bool) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "low":
+		return PriorityLow, true
+	case "normal":
+		return PriorityNormal, true
+	case "high":
+		return PriorityHigh, true
+	case "urgent":
+		return PriorityUrgent, true
+	case "critical":
+		return PriorityCritical, true
+	default:
+		return 0, false
+	}
+}
+
 // priorityName returns a human-readable priority name.
 func priorityName(p Priority) string {
 	switch p {
@@ -0,0 +1,43 @@
+package attention
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+)
+
+func TestAddAttentionTool(t *testing.T) {
+	feed := NewFeed()
+	tool := NewAddAttentionTool(feed)
+
+	result, err := tool.Execute(context.Background(), json.RawMessage(`{"title":"Follow up with Dana","priority":"urgent","tags":["sales"]}`))
+	if err != nil {
+		t.Fatalf("Execute: %v", err)
+	}
+	if result.IsError {
+		t.Fatalf("unexpected error result: %s", result.Content)
+	}
+	items := feed.Active()
+	if len(items) != 1 {
+		t.Fatalf("active items = %d, want 1", len(items))
+	}
+	item := items[0]
+	if item.Type != ItemTypeTask || item.Priority != PriorityUrgent || item.Title != "Follow up with Dana" {
+		t.Fatalf("item = %+v", item)
+	}
+
+	result, err = tool.Execute(context.Background(), json.RawMessage(`{"title":"x","priority":"someday"}`))
+	if err != nil {
+		t.Fatalf("Execute: %v", err)
+	}
+	if !result.IsError {
+		t.Fatal("expected invalid priority to be rejected")
+	}
+
+	if handled, _ := NewHandleAttentionTool(feed).Execute(context.Background(), json.RawMessage(`{"id":"`+item.ID+`"}`)); handled.IsError {
+		t.Fatalf("handle failed: %s", handled.Content)
+	}
+	if len(feed.Active()) != 0 {
+		t.Fatal("expected completed item to leave the active list")
+	}
+}
@@ -294,6 +294,28 @@ func (l *Logger) LogPermissionDecision(ctx context.Context, granted bool, permis
 	})
 }
 
+// LogAdminAction logs an attempt at an admin-only operation such as a
+// config write, approval override, or identity erasure. It is a no-op on a
+// nil logger.
+func (l *Logger) LogAdminAction(ctx context.Context, allowed bool, userID, operation string, details map[string]any) {
+	if l == nil {
+		return
+	}
+	eventType := EventAdminAction
+	level := LevelInfo
+	if !allowed {
+		eventType = EventAdminDenied
+		level = LevelWarn
+	}
+	l.Log(ctx, &Event{
+		Type:    eventType,
+		Level:   level,
+		UserID:  userID,
+		Action:  operation,
+		Details: details,
+	})
+}
+
 // LogAgentHandoff logs an agent handoff event.
 func (l *Logger) LogAgentHandoff(ctx context.Context, fromAgent, toAgent, reason, contextMode string, depth int, sessionKey string) {
 	l.Log(ctx, &Event{
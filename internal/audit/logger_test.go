@@ -918,6 +918,36 @@ func TestLogger_LogPermissionDecision(t *testing.T) {
 	}
 }
 
+func TestLogger_LogAdminAction(t *testing.T) {
+	logger := &Logger{
+		config: Config{
+			Enabled:    true,
+			Level:      LevelInfo,
+			SampleRate: 1.0,
+		},
+		eventTypes: make(map[EventType]bool),
+		buffer:     make(chan *Event, 10),
+		done:       make(chan struct{}),
+	}
+
+	logger.LogAdminAction(context.Background(), false, "user-1", "config.write", map[string]any{"path": "/api/config"})
+
+	select {
+	case event := <-logger.buffer:
+		if event.Type != EventAdminDenied || event.Level != LevelWarn {
+			t.Errorf("expected a denied warning, got %s/%s", event.Type, event.Level)
+		}
+		if event.UserID != "user-1" || event.Action != "config.write" {
+			t.Errorf("unexpected event %+v", event)
+		}
+	case <-time.After(100 * time.Millisecond):
+		t.Error("expected event in buffer")
+	}
+
+	var nilLogger *Logger
+	nilLogger.LogAdminAction(context.Background(), true, "user-1", "config.write", nil)
+}
+
 func TestLogger_LogAgentHandoff(t *testing.T) {
 	logger := &Logger{
 		config: Config{
@@ -49,6 +49,10 @@ const (
 	EventCanvasAction EventType = "canvas.action"
 	EventCanvasUpdate EventType = "canvas.update"
 	EventCanvasReset  EventType = "canvas.reset"
+
+	// Admin events
+	EventAdminAction EventType = "admin.action"
+	EventAdminDenied EventType = "admin.denied"
 )
 
 // Level represents audit log severity.
@@ -25,21 +25,32 @@ type Config struct {
 	APIKeys     []APIKeyConfig
 }
 
-// APIKeyConfig declares a static API key and associated identity.
+// APIKeyConfig declares a static API key and associated identity. Keys
+// without a Role or Scopes have full access.
 type APIKeyConfig struct {
 	Key    string
 	UserID string
 	Email  string
 	Name   string
+	// Role is RoleReadOnly, RoleOperator, or RoleAdmin; its scopes are
+	// combined with Scopes.
+	Role   string
+	Scopes []string
+}
+
+type apiKeyEntry struct {
+	user   *models.User
+	scopes []string
 }
 
 // Service validates JWTs and API keys.
 type Service struct {
 	mu        sync.RWMutex
 	jwt       *JWTService
-	apiKeys   map[string]*models.User
+	apiKeys   map[string]apiKeyEntry
 	users     UserStore
 	providers map[string]OAuthProvider
+	oidc      *OIDCProvider
 }
 
 // NewService constructs an auth service from static configuration.
@@ -77,50 +88,99 @@ func (s *Service) GenerateJWT(user *models.User) (string, error) {
 	return jwt.Generate(user)
 }
 
-// ValidateJWT validates a JWT and returns the associated user.
+// ValidateJWT validates a JWT and returns the associated user. Tokens limited
+// to a role other than RoleAdmin are rejected; scope-aware callers use
+// AuthenticateJWT instead.
 func (s *Service) ValidateJWT(token string) (*models.User, error) {
+	user, scopes, err := s.AuthenticateJWT(token)
+	if err != nil {
+		return nil, err
+	}
+	if len(scopes) > 0 && !scopesAllow(scopes, ScopeAdmin) {
+		return nil, ErrInsufficientScope
+	}
+	return user, nil
+}
+
+// AuthenticateJWT validates a JWT and returns the associated user and the
+// scopes of its role. Nil scopes mean full access.
+func (s *Service) AuthenticateJWT(token string) (*models.User, []string, error) {
+	if s == nil {
+		return nil, nil, ErrAuthDisabled
+	}
+	s.mu.RLock()
+	jwt := s.jwt
+	s.mu.RUnlock()
+	if jwt == nil {
+		return nil, nil, ErrAuthDisabled
+	}
+	user, role, err := jwt.authenticate(token)
+	if err != nil {
+		return nil, nil, err
+	}
+	return user, keyScopes(role, nil), nil
+}
+
+// GenerateSessionJWT issues a login session token limited to role.
+func (s *Service) GenerateSessionJWT(user *models.User, role string, ttl time.Duration) (string, error) {
 	if s == nil {
-		return nil, ErrAuthDisabled
+		return "", ErrAuthDisabled
 	}
 	s.mu.RLock()
 	jwt := s.jwt
 	s.mu.RUnlock()
 	if jwt == nil {
-		return nil, ErrAuthDisabled
+		return "", ErrAuthDisabled
 	}
-	return jwt.Validate(token)
+	return jwt.GenerateWithRole(user, role, ttl)
 }
 
 // ValidateAPIKey validates an API key and returns the associated user.
-// Uses constant-time comparison to prevent timing attacks.
+// Scoped keys are rejected unless they include ScopeAdmin; scope-aware
+// callers use AuthenticateAPIKey instead.
 func (s *Service) ValidateAPIKey(key string) (*models.User, error) {
+	user, scopes, err := s.AuthenticateAPIKey(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(scopes) > 0 && !scopesAllow(scopes, ScopeAdmin) {
+		return nil, ErrInsufficientScope
+	}
+	return user, nil
+}
+
+// AuthenticateAPIKey validates an API key and returns the associated user
+// and scopes. Nil scopes mean full access.
+// Uses constant-time comparison to prevent timing attacks.
+func (s *Service) AuthenticateAPIKey(key string) (*models.User, []string, error) {
 	if s == nil {
-		return nil, ErrAuthDisabled
+		return nil, nil, ErrAuthDisabled
 	}
 	s.mu.RLock()
 	apiKeys := s.apiKeys
 	s.mu.RUnlock()
 
 	if len(apiKeys) == 0 {
-		return nil, ErrAuthDisabled
+		return nil, nil, ErrAuthDisabled
 	}
 	inputKey := strings.TrimSpace(key)
 	// Iterate through all keys using constant-time comparison
 	// to prevent timing attacks that could reveal valid keys.
-	var matchedUser *models.User
-	for storedKey, user := range apiKeys {
+	var matched *apiKeyEntry
+	for storedKey, entry := range apiKeys {
 		if subtle.ConstantTimeCompare([]byte(inputKey), []byte(storedKey)) == 1 {
-			matchedUser = user
+			entry := entry
+			matched = &entry
 		}
 	}
-	if matchedUser == nil {
-		return nil, ErrInvalidKey
+	if matched == nil {
+		return nil, nil, ErrInvalidKey
 	}
-	return matchedUser, nil
+	return matched.user, matched.scopes, nil
 }
 
-func buildAPIKeyMap(keys []APIKeyConfig) map[string]*models.User {
-	out := map[string]*models.User{}
+func buildAPIKeyMap(keys []APIKeyConfig) map[string]apiKeyEntry {
+	out := map[string]apiKeyEntry{}
 	for _, entry := range keys {
 		key := strings.TrimSpace(entry.Key)
 		if key == "" {
@@ -131,10 +191,13 @@ func buildAPIKeyMap(keys []APIKeyConfig) map[string]*models.User {
 			sum := sha256.Sum256([]byte(key))
 			userID = "api_" + hex.EncodeToString(sum[:8])
 		}
-		out[key] = &models.User{
-			ID:    userID,
-			Email: strings.TrimSpace(entry.Email),
-			Name:  strings.TrimSpace(entry.Name),
+		out[key] = apiKeyEntry{
+			user: &models.User{
+				ID:    userID,
+				Email: strings.TrimSpace(entry.Email),
+				Name:  strings.TrimSpace(entry.Name),
+			},
+			scopes: keyScopes(entry.Role, entry.Scopes),
 		}
 	}
 	return out
@@ -1,6 +1,9 @@
 package auth
 
-import "testing"
+import (
+	"context"
+	"testing"
+)
 
 func TestServiceValidateAPIKey(t *testing.T) {
 	service := NewService(Config{APIKeys: []APIKeyConfig{{Key: "abc123", UserID: "user-1", Email: "user@example.com"}}})
@@ -15,3 +18,84 @@ func TestServiceValidateAPIKey(t *testing.T) {
 		t.Fatalf("expected email, got %q", user.Email)
 	}
 }
+
+func TestServiceAPIKeyScopes(t *testing.T) {
+	service := NewService(Config{APIKeys: []APIKeyConfig{
+		{Key: "full", UserID: "user-1"},
+		{Key: "scoped", UserID: "bot", Scopes: []string{" Usage:Read ", "approvals:*"}},
+		{Key: "admin", UserID: "ops", Scopes: []string{"admin"}},
+	}})
+
+	if _, err := service.ValidateAPIKey("full"); err != nil {
+		t.Fatalf("unscoped key: %v", err)
+	}
+	if _, err := service.ValidateAPIKey("admin"); err != nil {
+		t.Fatalf("admin key: %v", err)
+	}
+	if _, err := service.ValidateAPIKey("scoped"); err != ErrInsufficientScope {
+		t.Fatalf("expected scoped key to be rejected by ValidateAPIKey, got %v", err)
+	}
+
+	user, scopes, err := service.AuthenticateAPIKey("scoped")
+	if err != nil || user.ID != "bot" {
+		t.Fatalf("AuthenticateAPIKey() = %v, %v", user, err)
+	}
+	ctx := WithScopes(context.Background(), scopes)
+	for scope, want := range map[string]bool{
+		"usage:read":      true,
+		"approvals:write": true,
+		"sessions:read":   false,
+		ScopeAdmin:        false,
+	} {
+		if got := HasScope(ctx, scope); got != want {
+			t.Errorf("HasScope(%q) = %v, want %v", scope, got, want)
+		}
+	}
+	if !HasScope(context.Background(), "sessions:write") {
+		t.Error("expected unscoped context to allow everything")
+	}
+}
+
+func TestServiceAPIKeyRoles(t *testing.T) {
+	service := NewService(Config{APIKeys: []APIKeyConfig{
+		{Key: "viewer", UserID: "viewer", Role: RoleReadOnly},
+		{Key: "ops", UserID: "ops", Role: " Operator ", Scopes: []string{"approvals:write"}},
+		{Key: "root", UserID: "root", Role: RoleAdmin},
+	}})
+
+	tests := []struct {
+		key    string
+		scope  string
+		allows bool
+	}{
+		{"viewer", "sessions:read", true},
+		{"viewer", "config:read", true},
+		{"viewer", "messages:write", false},
+		{"ops", "messages:write", true},
+		{"ops", "system:write", true},
+		{"ops", "approvals:write", true},
+		{"ops", "config:write", false},
+		{"ops", ScopeAdmin, false},
+		{"root", "config:write", true},
+		{"root", ScopeAdmin, true},
+	}
+	for _, tt := range tests {
+		_, scopes, err := service.AuthenticateAPIKey(tt.key)
+		if err != nil {
+			t.Fatalf("AuthenticateAPIKey(%q) error = %v", tt.key, err)
+		}
+		if got := HasScope(WithScopes(context.Background(), scopes), tt.scope); got != tt.allows {
+			t.Errorf("%s: HasScope(%q) = %v, want %v", tt.key, tt.scope, got, tt.allows)
+		}
+	}
+
+	if _, err := service.ValidateAPIKey("root"); err != nil {
+		t.Fatalf("expected admin role to pass ValidateAPIKey, got %v", err)
+	}
+	if _, ok := RoleScopes("superuser"); ok {
+		t.Fatal("expected unknown role to be rejected")
+	}
+	if !IsAdmin(context.Background()) {
+		t.Fatal("expected unscoped callers to be admin")
+	}
+}
@@ -24,11 +24,23 @@ func NewJWTService(secret string, expiry time.Duration) *JWTService {
 type Claims struct {
 	Email string `json:"email,omitempty"`
 	Name  string `json:"name,omitempty"`
+	// Role limits the token to the scopes of an API key role. Tokens without
+	// a role have full access.
+	Role string `json:"role,omitempty"`
 	jwt.RegisteredClaims
 }
 
 // Generate issues a signed token for the given user.
 func (s *JWTService) Generate(user *models.User) (string, error) {
+	if s == nil {
+		return "", ErrAuthDisabled
+	}
+	return s.GenerateWithRole(user, "", s.expiry)
+}
+
+// GenerateWithRole issues a token limited to role that expires after ttl
+// (never when ttl <= 0).
+func (s *JWTService) GenerateWithRole(user *models.User, role string, ttl time.Duration) (string, error) {
 	if s == nil || len(s.secret) == 0 {
 		return "", ErrAuthDisabled
 	}
@@ -39,13 +51,14 @@ func (s *JWTService) Generate(user *models.User) (string, error) {
 	claims := Claims{
 		Email: strings.TrimSpace(user.Email),
 		Name:  strings.TrimSpace(user.Name),
+		Role:  strings.ToLower(strings.TrimSpace(role)),
 		RegisteredClaims: jwt.RegisteredClaims{
 			Subject:   user.ID,
 			IssuedAt:  jwt.NewNumericDate(time.Now()),
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(s.expiry)),
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(ttl)),
 		},
 	}
-	if s.expiry <= 0 {
+	if ttl <= 0 {
 		claims.ExpiresAt = nil
 	}
 
@@ -55,8 +68,14 @@ func (s *JWTService) Generate(user *models.User) (string, error) {
 
 // Validate parses and validates a JWT and returns the user embedded in it.
 func (s *JWTService) Validate(token string) (*models.User, error) {
+	user, _, err := s.authenticate(token)
+	return user, err
+}
+
+// authenticate validates a JWT and returns its user and role.
+func (s *JWTService) authenticate(token string) (*models.User, string, error) {
 	if s == nil || len(s.secret) == 0 {
-		return nil, ErrAuthDisabled
+		return nil, "", ErrAuthDisabled
 	}
 
 	parsed, err := jwt.ParseWithClaims(token, &Claims{}, func(t *jwt.Token) (any, error) {
@@ -66,19 +85,22 @@ func (s *JWTService) Validate(token string) (*models.User, error) {
 		return s.secret, nil
 	})
 	if err != nil {
-		return nil, ErrInvalidToken
+		return nil, "", ErrInvalidToken
 	}
 
 	claims, ok := parsed.Claims.(*Claims)
 	if !ok || !parsed.Valid {
-		return nil, ErrInvalidToken
+		return nil, "", ErrInvalidToken
 	}
 	if strings.TrimSpace(claims.Subject) == "" {
-		return nil, ErrInvalidToken
+		return nil, "", ErrInvalidToken
+	}
+	if _, known := RoleScopes(claims.Role); claims.Role != "" && !known {
+		return nil, "", ErrInvalidToken
 	}
 	return &models.User{
 		ID:    claims.Subject,
 		Email: strings.TrimSpace(claims.Email),
 		Name:  strings.TrimSpace(claims.Name),
-	}, nil
+	}, claims.Role, nil
 }
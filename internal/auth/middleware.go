@@ -23,26 +23,32 @@ func UnaryInterceptor(service *Service, logger *slog.Logger) grpc.UnaryServerInt
 		}
 
 		if token := extractBearer(md); token != "" {
-			user, err := service.ValidateJWT(token)
+			user, scopes, err := service.AuthenticateJWT(token)
 			if err != nil {
 				if logger != nil {
 					logger.Warn("jwt validation failed", "error", err)
 				}
 				return nil, status.Error(codes.Unauthenticated, "invalid token")
 			}
-			ctx = WithUser(ctx, user)
+			ctx = WithScopes(WithUser(ctx, user), scopes)
+			if scope := MethodScope(info.FullMethod); !HasScope(ctx, scope) {
+				return nil, status.Errorf(codes.PermissionDenied, "token lacks scope %q", scope)
+			}
 			return handler(ctx, req)
 		}
 
 		if apiKey := extractAPIKey(md); apiKey != "" {
-			user, err := service.ValidateAPIKey(apiKey)
+			user, scopes, err := service.AuthenticateAPIKey(apiKey)
 			if err != nil {
 				if logger != nil {
 					logger.Warn("api key validation failed", "error", err)
 				}
 				return nil, status.Error(codes.Unauthenticated, "invalid api key")
 			}
-			ctx = WithUser(ctx, user)
+			ctx = WithScopes(WithUser(ctx, user), scopes)
+			if scope := MethodScope(info.FullMethod); !HasScope(ctx, scope) {
+				return nil, status.Errorf(codes.PermissionDenied, "api key lacks scope %q", scope)
+			}
 			return handler(ctx, req)
 		}
 
@@ -62,25 +68,33 @@ func StreamInterceptor(service *Service, logger *slog.Logger) grpc.StreamServerI
 		}
 
 		if token := extractBearer(md); token != "" {
-			user, err := service.ValidateJWT(token)
+			user, scopes, err := service.AuthenticateJWT(token)
 			if err != nil {
 				if logger != nil {
 					logger.Warn("jwt validation failed", "error", err)
 				}
 				return status.Error(codes.Unauthenticated, "invalid token")
 			}
-			return handler(srv, &wrappedStream{ServerStream: stream, ctx: WithUser(stream.Context(), user)})
+			ctx := WithScopes(WithUser(stream.Context(), user), scopes)
+			if scope := MethodScope(info.FullMethod); !HasScope(ctx, scope) {
+				return status.Errorf(codes.PermissionDenied, "token lacks scope %q", scope)
+			}
+			return handler(srv, &wrappedStream{ServerStream: stream, ctx: ctx})
 		}
 
 		if apiKey := extractAPIKey(md); apiKey != "" {
-			user, err := service.ValidateAPIKey(apiKey)
+			user, scopes, err := service.AuthenticateAPIKey(apiKey)
 			if err != nil {
 				if logger != nil {
 					logger.Warn("api key validation failed", "error", err)
 				}
 				return status.Error(codes.Unauthenticated, "invalid api key")
 			}
-			return handler(srv, &wrappedStream{ServerStream: stream, ctx: WithUser(stream.Context(), user)})
+			ctx := WithScopes(WithUser(stream.Context(), user), scopes)
+			if scope := MethodScope(info.FullMethod); !HasScope(ctx, scope) {
+				return status.Errorf(codes.PermissionDenied, "api key lacks scope %q", scope)
+			}
+			return handler(srv, &wrappedStream{ServerStream: stream, ctx: ctx})
 		}
 
 		return status.Error(codes.Unauthenticated, "missing credentials")
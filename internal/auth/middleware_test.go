@@ -129,3 +129,29 @@ type stubServerStream struct {
 func (s *stubServerStream) Context() context.Context {
 	return s.ctx
 }
+
+func TestUnaryInterceptorEnforcesAPIKeyScopes(t *testing.T) {
+	service := NewService(Config{APIKeys: []APIKeyConfig{{Key: "k1", UserID: "user-1", Scopes: []string{"sessions:read"}}}})
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.New(map[string]string{
+		"x-api-key": "k1",
+	}))
+	interceptor := UnaryInterceptor(service, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	handler := func(ctx context.Context, req any) (any, error) {
+		if !HasScope(ctx, "sessions:read") || HasScope(ctx, "sessions:write") {
+			t.Fatal("expected scopes on handler context")
+		}
+		return "ok", nil
+	}
+
+	if _, err := interceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: "/nexus.v1.SessionService/ListSessions"}, handler); err != nil {
+		t.Fatalf("expected scoped call to succeed, got %v", err)
+	}
+	_, err := interceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: "/nexus.v1.SessionService/DeleteSession"}, handler)
+	if status.Code(err) != codes.PermissionDenied {
+		t.Fatalf("expected permission denied, got %v", err)
+	}
+	_, err = interceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: "/nexus.v1.EventService/GetEvents"}, handler)
+	if status.Code(err) != codes.PermissionDenied {
+		t.Fatalf("expected unmapped method to require admin, got %v", err)
+	}
+}
@@ -0,0 +1,501 @@
+package auth
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"golang.org/x/oauth2"
+
+	"github.com/haasonsaas/nexus/pkg/models"
+)
+
+var (
+	// ErrOIDCState is returned for a callback without a matching login, for
+	// example one that expired or was already used.
+	ErrOIDCState = errors.New("oidc login expired or unknown")
+	// ErrOIDCNoRole is returned when a user's groups map to no role and no
+	// default role is configured.
+	ErrOIDCNoRole = errors.New("oidc user has no nexus role")
+)
+
+// oidcLoginTTL bounds how long a user may take to sign in at the provider.
+const oidcLoginTTL = 10 * time.Minute
+
+// maxPendingOIDCLogins caps unfinished logins held in memory.
+const maxPendingOIDCLogins = 10000
+
+// OIDCConfig configures an OpenID Connect login provider.
+type OIDCConfig struct {
+	Issuer       string
+	ClientID     string
+	ClientSecret string
+	RedirectURL  string
+	Scopes       []string
+	// GroupsClaim names the claim listing the user's groups (default
+	// "groups").
+	GroupsClaim string
+	// GroupRoles maps groups to RoleReadOnly, RoleOperator, or RoleAdmin.
+	GroupRoles map[string]string
+	// DefaultRole applies to users in no mapped group; empty refuses them.
+	DefaultRole string
+	HTTPClient  *http.Client
+}
+
+// OIDCIdentity is a user signed in through OIDC.
+type OIDCIdentity struct {
+	Subject string
+	Email   string
+	Name    string
+	Groups  []string
+	// Role is the highest role granted by Groups, or the default role.
+	Role string
+}
+
+// OIDCProvider runs the authorization code flow with PKCE against an OpenID
+// Connect provider and verifies the returned ID tokens.
+type OIDCProvider struct {
+	cfg    OIDCConfig
+	client *http.Client
+	now    func() time.Time
+
+	mu        sync.Mutex
+	discovery *oidcDiscovery
+	keys      map[string]any
+	pending   map[string]oidcLogin
+}
+
+type oidcDiscovery struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	UserInfoEndpoint      string `json:"userinfo_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+}
+
+type oidcLogin struct {
+	verifier string
+	nonce    string
+	redirect string
+	expires  time.Time
+}
+
+// NewOIDCProvider creates a provider. Endpoints are discovered from the
+// issuer on first use.
+func NewOIDCProvider(cfg OIDCConfig) *OIDCProvider {
+	cfg.Issuer = strings.TrimRight(strings.TrimSpace(cfg.Issuer), "/")
+	cfg.ClientID = strings.TrimSpace(cfg.ClientID)
+	cfg.ClientSecret = strings.TrimSpace(cfg.ClientSecret)
+	cfg.RedirectURL = strings.TrimSpace(cfg.RedirectURL)
+	if len(cfg.Scopes) == 0 {
+		cfg.Scopes = []string{"openid", "email", "profile"}
+	}
+	if strings.TrimSpace(cfg.GroupsClaim) == "" {
+		cfg.GroupsClaim = "groups"
+	}
+	client := cfg.HTTPClient
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+	return &OIDCProvider{
+		cfg:     cfg,
+		client:  client,
+		now:     time.Now,
+		pending: map[string]oidcLogin{},
+	}
+}
+
+// SecureCookies reports whether session cookies should be marked Secure,
+// which is the case when the callback is served over HTTPS.
+func (p *OIDCProvider) SecureCookies() bool {
+	return p != nil && strings.HasPrefix(strings.ToLower(p.cfg.RedirectURL), "https://")
+}
+
+// Begin starts a login and returns the provider URL to send the browser to.
+// redirect is returned by Complete once the login finishes.
+func (p *OIDCProvider) Begin(ctx context.Context, redirect string) (string, error) {
+	discovery, err := p.discover(ctx)
+	if err != nil {
+		return "", err
+	}
+	state, err := randomToken()
+	if err != nil {
+		return "", err
+	}
+	nonce, err := randomToken()
+	if err != nil {
+		return "", err
+	}
+	verifier := oauth2.GenerateVerifier()
+
+	p.mu.Lock()
+	now := p.now()
+	for key, login := range p.pending {
+		if now.After(login.expires) {
+			delete(p.pending, key)
+		}
+	}
+	if len(p.pending) >= maxPendingOIDCLogins {
+		p.mu.Unlock()
+		return "", errors.New("too many pending oidc logins")
+	}
+	p.pending[state] = oidcLogin{verifier: verifier, nonce: nonce, redirect: redirect, expires: now.Add(oidcLoginTTL)}
+	p.mu.Unlock()
+
+	return p.oauthConfig(discovery).AuthCodeURL(state,
+		oauth2.S256ChallengeOption(verifier),
+		oauth2.SetAuthURLParam("nonce", nonce),
+	), nil
+}
+
+// Complete finishes the login identified by state, exchanging code for
+// tokens and verifying the ID token. It returns the signed-in identity and
+// the redirect passed to Begin.
+func (p *OIDCProvider) Complete(ctx context.Context, state, code string) (*OIDCIdentity, string, error) {
+	if len(code) > maxCodeLength {
+		return nil, "", errors.New("authorization code too long")
+	}
+	p.mu.Lock()
+	login, ok := p.pending[state]
+	delete(p.pending, state)
+	p.mu.Unlock()
+	if !ok || p.now().After(login.expires) {
+		return nil, "", ErrOIDCState
+	}
+	if strings.TrimSpace(code) == "" {
+		return nil, "", errors.New("authorization code required")
+	}
+
+	discovery, err := p.discover(ctx)
+	if err != nil {
+		return nil, "", err
+	}
+	ctx = context.WithValue(ctx, oauth2.HTTPClient, p.client)
+	token, err := p.oauthConfig(discovery).Exchange(ctx, code, oauth2.VerifierOption(login.verifier))
+	if err != nil {
+		return nil, "", fmt.Errorf("oidc token exchange: %w", err)
+	}
+	rawIDToken, _ := token.Extra("id_token").(string)
+	if rawIDToken == "" {
+		return nil, "", errors.New("oidc token response has no id_token")
+	}
+	claims, err := p.verifyIDToken(ctx, discovery, rawIDToken)
+	if err != nil {
+		return nil, "", err
+	}
+	if nonce, _ := claims["nonce"].(string); nonce != login.nonce {
+		return nil, "", errors.New("oidc id token nonce mismatch")
+	}
+	if _, ok := claims[p.cfg.GroupsClaim]; !ok && discovery.UserInfoEndpoint != "" {
+		if err := p.mergeUserInfo(ctx, discovery, token, claims); err != nil {
+			return nil, "", err
+		}
+	}
+
+	identity := &OIDCIdentity{
+		Subject: stringClaim(claims, "sub"),
+		Email:   stringClaim(claims, "email"),
+		Name:    stringClaim(claims, "name"),
+		Groups:  stringsClaim(claims, p.cfg.GroupsClaim),
+	}
+	if identity.Subject == "" {
+		return nil, "", errors.New("oidc id token has no subject")
+	}
+	identity.Role = p.RoleFor(identity.Groups)
+	if identity.Role == "" {
+		return identity, "", ErrOIDCNoRole
+	}
+	return identity, login.redirect, nil
+}
+
+// RoleFor returns the highest role granted by groups, falling back to the
+// default role.
+func (p *OIDCProvider) RoleFor(groups []string) string {
+	best := ""
+	for _, group := range groups {
+		role := strings.ToLower(strings.TrimSpace(p.cfg.GroupRoles[group]))
+		if roleRank(role) > roleRank(best) {
+			best = role
+		}
+	}
+	if best == "" {
+		best = strings.ToLower(strings.TrimSpace(p.cfg.DefaultRole))
+	}
+	if _, ok := RoleScopes(best); !ok {
+		return ""
+	}
+	return best
+}
+
+func roleRank(role string) int {
+	switch role {
+	case RoleReadOnly:
+		return 1
+	case RoleOperator:
+		return 2
+	case RoleAdmin:
+		return 3
+	default:
+		return 0
+	}
+}
+
+func (p *OIDCProvider) oauthConfig(discovery *oidcDiscovery) *oauth2.Config {
+	return &oauth2.Config{
+		ClientID:     p.cfg.ClientID,
+		ClientSecret: p.cfg.ClientSecret,
+		RedirectURL:  p.cfg.RedirectURL,
+		Scopes:       p.cfg.Scopes,
+		Endpoint: oauth2.Endpoint{
+			AuthURL:  discovery.AuthorizationEndpoint,
+			TokenURL: discovery.TokenEndpoint,
+		},
+	}
+}
+
+// discover loads the provider metadata, caching it after the first success.
+func (p *OIDCProvider) discover(ctx context.Context) (*oidcDiscovery, error) {
+	p.mu.Lock()
+	cached := p.discovery
+	p.mu.Unlock()
+	if cached != nil {
+		return cached, nil
+	}
+	var discovery oidcDiscovery
+	if err := p.getJSON(ctx, p.cfg.Issuer+"/.well-known/openid-configuration", "", &discovery); err != nil {
+		return nil, fmt.Errorf("oidc discovery: %w", err)
+	}
+	if strings.TrimRight(discovery.Issuer, "/") != p.cfg.Issuer {
+		return nil, fmt.Errorf("oidc discovery: issuer %q does not match %q", discovery.Issuer, p.cfg.Issuer)
+	}
+	if discovery.AuthorizationEndpoint == "" || discovery.TokenEndpoint == "" || discovery.JWKSURI == "" {
+		return nil, errors.New("oidc discovery: provider metadata is missing endpoints")
+	}
+	p.mu.Lock()
+	p.discovery = &discovery
+	p.mu.Unlock()
+	return &discovery, nil
+}
+
+func (p *OIDCProvider) verifyIDToken(ctx context.Context, discovery *oidcDiscovery, raw string) (jwt.MapClaims, error) {
+	claims := jwt.MapClaims{}
+	_, err := jwt.ParseWithClaims(raw, claims, func(t *jwt.Token) (any, error) {
+		kid, _ := t.Header["kid"].(string)
+		return p.signingKey(ctx, discovery, kid)
+	},
+		jwt.WithValidMethods([]string{"RS256", "RS384", "RS512", "ES256", "ES384", "ES512"}),
+		jwt.WithIssuer(discovery.Issuer),
+		jwt.WithAudience(p.cfg.ClientID),
+		jwt.WithExpirationRequired(),
+		jwt.WithTimeFunc(p.now),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("oidc id token: %w", err)
+	}
+	return claims, nil
+}
+
+// signingKey returns the provider key with id kid, refetching the key set
+// once when the key is unknown (after a rotation).
+func (p *OIDCProvider) signingKey(ctx context.Context, discovery *oidcDiscovery, kid string) (any, error) {
+	p.mu.Lock()
+	key, ok := p.keys[kid]
+	p.mu.Unlock()
+	if ok {
+		return key, nil
+	}
+	var set struct {
+		Keys []jsonWebKey `json:"keys"`
+	}
+	if err := p.getJSON(ctx, discovery.JWKSURI, "", &set); err != nil {
+		return nil, fmt.Errorf("oidc keys: %w", err)
+	}
+	keys := make(map[string]any, len(set.Keys))
+	for _, jwk := range set.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+		if parsed, err := jwk.publicKey(); err == nil {
+			keys[jwk.Kid] = parsed
+		}
+	}
+	p.mu.Lock()
+	p.keys = keys
+	p.mu.Unlock()
+	if key, ok := keys[kid]; ok {
+		return key, nil
+	}
+	return nil, fmt.Errorf("oidc signing key %q not found", kid)
+}
+
+func (p *OIDCProvider) mergeUserInfo(ctx context.Context, discovery *oidcDiscovery, token *oauth2.Token, claims jwt.MapClaims) error {
+	info := map[string]any{}
+	if err := p.getJSON(ctx, discovery.UserInfoEndpoint, token.AccessToken, &info); err != nil {
+		return fmt.Errorf("oidc user info: %w", err)
+	}
+	if sub, _ := info["sub"].(string); sub != stringClaim(claims, "sub") {
+		return errors.New("oidc user info subject mismatch")
+	}
+	for key, value := range info {
+		if _, ok := claims[key]; !ok {
+			claims[key] = value
+		}
+	}
+	return nil
+}
+
+func (p *OIDCProvider) getJSON(ctx context.Context, url, accessToken string, out any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	if accessToken != "" {
+		req.Header.Set("Authorization", "Bearer "+accessToken)
+	}
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("GET %s: %s", url, resp.Status)
+	}
+	return json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(out)
+}
+
+// jsonWebKey is an RSA or EC public key from a JWKS document.
+type jsonWebKey struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+func (k jsonWebKey) publicKey() (any, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := base64.RawURLEncoding.DecodeString(k.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := base64.RawURLEncoding.DecodeString(k.E)
+		if err != nil {
+			return nil, err
+		}
+		return &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}, nil
+	case "EC":
+		var curve elliptic.Curve
+		switch k.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("unsupported curve %q", k.Crv)
+		}
+		x, err := base64.RawURLEncoding.DecodeString(k.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := base64.RawURLEncoding.DecodeString(k.Y)
+		if err != nil {
+			return nil, err
+		}
+		return &ecdsa.PublicKey{Curve: curve, X: new(big.Int).SetBytes(x), Y: new(big.Int).SetBytes(y)}, nil
+	default:
+		return nil, fmt.Errorf("unsupported key type %q", k.Kty)
+	}
+}
+
+func stringClaim(claims jwt.MapClaims, name string) string {
+	value, _ := claims[name].(string)
+	return strings.TrimSpace(value)
+}
+
+// stringsClaim reads a claim holding a list of strings or a single string.
+func stringsClaim(claims jwt.MapClaims, name string) []string {
+	switch value := claims[name].(type) {
+	case string:
+		return []string{value}
+	case []any:
+		out := make([]string, 0, len(value))
+		for _, item := range value {
+			if s, ok := item.(string); ok {
+				out = append(out, s)
+			}
+		}
+		return out
+	default:
+		return nil
+	}
+}
+
+func randomToken() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
+
+// SetOIDC enables OIDC login.
+func (s *Service) SetOIDC(provider *OIDCProvider) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.oidc = provider
+}
+
+// OIDC returns the OIDC login provider, or nil when SSO is not configured.
+func (s *Service) OIDC() *OIDCProvider {
+	if s == nil {
+		return nil
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.oidc
+}
+
+// OIDCUser returns the user for an OIDC identity, creating it in the user
+// store when one is configured.
+func (s *Service) OIDCUser(ctx context.Context, identity *OIDCIdentity) (*models.User, error) {
+	if s == nil || identity == nil {
+		return nil, ErrAuthDisabled
+	}
+	s.mu.RLock()
+	users := s.users
+	s.mu.RUnlock()
+	info := &UserInfo{ID: identity.Subject, Provider: "oidc", Email: identity.Email, Name: identity.Name}
+	if users != nil {
+		return users.FindOrCreate(ctx, info)
+	}
+	return &models.User{
+		ID:         "oidc:" + identity.Subject,
+		Email:      identity.Email,
+		Name:       identity.Name,
+		Provider:   "oidc",
+		ProviderID: identity.Subject,
+	}, nil
+}
@@ -0,0 +1,182 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// fakeOIDCServer is a minimal OpenID provider that checks PKCE and issues
+// RS256 ID tokens for the configured groups.
+type fakeOIDCServer struct {
+	*httptest.Server
+	key       *rsa.PrivateKey
+	groups    []string
+	challenge string
+	nonce     string
+}
+
+func newFakeOIDCServer(t *testing.T) *fakeOIDCServer {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f := &fakeOIDCServer{key: key}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]string{
+			"issuer":                 f.URL,
+			"authorization_endpoint": f.URL + "/authorize",
+			"token_endpoint":         f.URL + "/token",
+			"jwks_uri":               f.URL + "/jwks",
+		})
+	})
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]any{"keys": []map[string]string{{
+			"kty": "RSA",
+			"kid": "k1",
+			"use": "sig",
+			"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+		}}})
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		sum := sha256.Sum256([]byte(r.Form.Get("code_verifier")))
+		if r.Form.Get("code") != "good-code" || base64.RawURLEncoding.EncodeToString(sum[:]) != f.challenge {
+			http.Error(w, `{"error":"invalid_grant"}`, http.StatusBadRequest)
+			return
+		}
+		token := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.MapClaims{
+			"iss":    f.URL,
+			"aud":    "nexus",
+			"sub":    "u-42",
+			"email":  "ada@example.com",
+			"name":   "Ada",
+			"nonce":  f.nonce,
+			"groups": f.groups,
+			"exp":    time.Now().Add(time.Hour).Unix(),
+		})
+		token.Header["kid"] = "k1"
+		signed, err := token.SignedString(key)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"access_token": "access",
+			"token_type":   "Bearer",
+			"id_token":     signed,
+		})
+	})
+	f.Server = httptest.NewServer(mux)
+	t.Cleanup(f.Close)
+	return f
+}
+
+// authorize records the PKCE challenge and nonce of a login URL and returns
+// its state, as the provider's login page would.
+func (f *fakeOIDCServer) authorize(t *testing.T, loginURL string) string {
+	t.Helper()
+	parsed, err := url.Parse(loginURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	query := parsed.Query()
+	if query.Get("code_challenge_method") != "S256" {
+		t.Fatalf("expected a PKCE challenge, got %s", loginURL)
+	}
+	f.challenge = query.Get("code_challenge")
+	f.nonce = query.Get("nonce")
+	return query.Get("state")
+}
+
+func TestOIDCProviderLogin(t *testing.T) {
+	ctx := context.Background()
+	server := newFakeOIDCServer(t)
+	provider := NewOIDCProvider(OIDCConfig{
+		Issuer:      server.URL,
+		ClientID:    "nexus",
+		RedirectURL: "https://nexus.example.com/auth/oidc/callback",
+		GroupRoles:  map[string]string{"eng": RoleOperator, "sre": RoleAdmin},
+	})
+
+	server.groups = []string{"eng", "sre", "sales"}
+	loginURL, err := provider.Begin(ctx, "/ui/sessions")
+	if err != nil {
+		t.Fatalf("Begin() error = %v", err)
+	}
+	state := server.authorize(t, loginURL)
+	identity, redirect, err := provider.Complete(ctx, state, "good-code")
+	if err != nil {
+		t.Fatalf("Complete() error = %v", err)
+	}
+	if identity.Subject != "u-42" || identity.Email != "ada@example.com" || identity.Role != RoleAdmin || redirect != "/ui/sessions" {
+		t.Fatalf("unexpected login %+v -> %q", identity, redirect)
+	}
+	if _, _, err := provider.Complete(ctx, state, "good-code"); !errors.Is(err, ErrOIDCState) {
+		t.Fatalf("expected a replayed state to fail, got %v", err)
+	}
+
+	server.groups = []string{"sales"}
+	loginURL, err = provider.Begin(ctx, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	state = server.authorize(t, loginURL)
+	if _, _, err := provider.Complete(ctx, state, "good-code"); !errors.Is(err, ErrOIDCNoRole) {
+		t.Fatalf("expected unmapped groups to be refused, got %v", err)
+	}
+
+	loginURL, err = provider.Begin(ctx, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	state = server.authorize(t, loginURL)
+	server.challenge = "tampered"
+	if _, _, err := provider.Complete(ctx, state, "good-code"); err == nil {
+		t.Fatal("expected a PKCE mismatch to fail the exchange")
+	}
+	if !provider.SecureCookies() {
+		t.Fatal("expected secure cookies for an https callback")
+	}
+}
+
+func TestOIDCSessionTokenCarriesRole(t *testing.T) {
+	service := NewService(Config{JWTSecret: "secret", TokenExpiry: time.Hour})
+	user, err := service.OIDCUser(context.Background(), &OIDCIdentity{Subject: "u-42", Email: "ada@example.com"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	token, err := service.GenerateSessionJWT(user, RoleReadOnly, time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, scopes, err := service.AuthenticateJWT(token)
+	if err != nil || got.ID != "oidc:u-42" {
+		t.Fatalf("AuthenticateJWT() = %v, %v", got, err)
+	}
+	if !ScopesAllow(scopes, "sessions:read") || ScopesAllow(scopes, "sessions:write") {
+		t.Fatalf("unexpected scopes %v", scopes)
+	}
+	if _, err := service.ValidateJWT(token); !errors.Is(err, ErrInsufficientScope) {
+		t.Fatalf("expected read-only sessions to be refused by ValidateJWT, got %v", err)
+	}
+}
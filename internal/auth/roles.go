@@ -0,0 +1,67 @@
+package auth
+
+import (
+	"context"
+	"strings"
+)
+
+// API key roles. A role expands to a fixed set of scopes; keys may add
+// further scopes on top of their role.
+const (
+	// RoleReadOnly can read sessions, messages, agents, approvals, usage,
+	// memory, config, and system status.
+	RoleReadOnly = "read-only"
+	// RoleOperator can also send messages, create scheduled tasks, and
+	// change sessions, agents, and system state (channel tests, skill
+	// refreshes, cron jobs).
+	RoleOperator = "operator"
+	// RoleAdmin has full access, including the sensitive operations:
+	// config writes, approval overrides, and identity erasure.
+	RoleAdmin = "admin"
+)
+
+var readOnlyScopes = []string{
+	"sessions:read",
+	"messages:read",
+	"agents:read",
+	"approvals:read",
+	"usage:read",
+	"memory:read",
+	"config:read",
+	"system:read",
+}
+
+var operatorScopes = append(append([]string{}, readOnlyScopes...),
+	"sessions:write",
+	"messages:write",
+	"agents:write",
+	"system:write",
+	"tasks:write",
+)
+
+// RoleScopes returns the scopes granted by role and whether the role is
+// known.
+func RoleScopes(role string) ([]string, bool) {
+	switch strings.ToLower(strings.TrimSpace(role)) {
+	case RoleReadOnly:
+		return append([]string{}, readOnlyScopes...), true
+	case RoleOperator:
+		return append([]string{}, operatorScopes...), true
+	case RoleAdmin:
+		return []string{ScopeAdmin}, true
+	default:
+		return nil, false
+	}
+}
+
+// IsAdmin reports whether the caller may perform sensitive operations:
+// JWT users, unscoped API keys, and keys with the admin role or scope.
+func IsAdmin(ctx context.Context) bool {
+	return HasScope(ctx, ScopeAdmin)
+}
+
+// keyScopes combines the scopes of role with the explicitly listed ones.
+func keyScopes(role string, scopes []string) []string {
+	granted, _ := RoleScopes(role)
+	return normalizeScopes(append(granted, scopes...))
+}
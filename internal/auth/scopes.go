@@ -0,0 +1,101 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"strings"
+)
+
+// ScopeAdmin grants full access, matching keys configured without scopes.
+const ScopeAdmin = "admin"
+
+// ErrInsufficientScope is returned when a scoped API key is used outside the
+// scope-aware APIs.
+var ErrInsufficientScope = errors.New("api key scopes do not allow this call")
+
+// grpcMethodScopes maps gRPC methods to the scope a scoped API key needs.
+// Methods not listed require ScopeAdmin.
+var grpcMethodScopes = map[string]string{
+	"/nexus.v1.NexusGateway/Stream":             "messages:write",
+	"/nexus.v1.SessionService/GetSession":       "sessions:read",
+	"/nexus.v1.SessionService/ListSessions":     "sessions:read",
+	"/nexus.v1.SessionService/CreateSession":    "sessions:write",
+	"/nexus.v1.SessionService/UpdateSession":    "sessions:write",
+	"/nexus.v1.SessionService/DeleteSession":    "sessions:write",
+	"/nexus.v1.AgentService/GetAgent":           "agents:read",
+	"/nexus.v1.AgentService/ListAgents":         "agents:read",
+	"/nexus.v1.AgentService/CreateAgent":        "agents:write",
+	"/nexus.v1.AgentService/UpdateAgent":        "agents:write",
+	"/nexus.v1.AgentService/DeleteAgent":        "agents:write",
+	"/nexus.v1.MessageService/SendMessage":      "messages:write",
+	"/nexus.v1.MessageService/BroadcastMessage": "messages:write",
+}
+
+type scopesContextKey struct{}
+
+// WithScopes attaches API key scopes to the context. Empty scopes leave the
+// context unrestricted.
+func WithScopes(ctx context.Context, scopes []string) context.Context {
+	if len(scopes) == 0 {
+		return ctx
+	}
+	return context.WithValue(ctx, scopesContextKey{}, scopes)
+}
+
+// ScopesFromContext returns the scopes attached by WithScopes.
+func ScopesFromContext(ctx context.Context) ([]string, bool) {
+	scopes, ok := ctx.Value(scopesContextKey{}).([]string)
+	return scopes, ok
+}
+
+// HasScope reports whether the caller may use scope. Callers without scopes
+// (JWT users and unscoped API keys) are unrestricted. A scope of the form
+// "resource:*" grants every action on that resource.
+func HasScope(ctx context.Context, scope string) bool {
+	granted, ok := ScopesFromContext(ctx)
+	if !ok {
+		return true
+	}
+	return scopesAllow(granted, scope)
+}
+
+// ScopesAllow reports whether granted covers scope. Nil granted, as returned
+// for unrestricted callers, allows everything.
+func ScopesAllow(granted []string, scope string) bool {
+	return len(granted) == 0 || scopesAllow(granted, scope)
+}
+
+// MethodScope returns the scope required to call a gRPC method.
+func MethodScope(fullMethod string) string {
+	if scope, ok := grpcMethodScopes[fullMethod]; ok {
+		return scope
+	}
+	return ScopeAdmin
+}
+
+func scopesAllow(granted []string, scope string) bool {
+	resource, _, _ := strings.Cut(scope, ":")
+	for _, g := range granted {
+		switch {
+		case g == ScopeAdmin, g == scope:
+			return true
+		case strings.HasSuffix(g, ":*") && strings.TrimSuffix(g, ":*") == resource:
+			return true
+		}
+	}
+	return false
+}
+
+func normalizeScopes(scopes []string) []string {
+	out := make([]string, 0, len(scopes))
+	for _, scope := range scopes {
+		scope = strings.ToLower(strings.TrimSpace(scope))
+		if scope != "" {
+			out = append(out, scope)
+		}
+	}
+	if len(out) == 0 {
+		return nil
+	}
+	return out
+}
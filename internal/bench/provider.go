@@ -0,0 +1,194 @@
+package bench
+
+import (
+	"context"
+	"math/rand"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/haasonsaas/nexus/internal/agent"
+)
+
+// MockProviderConfig configures the mock LLM used for capacity planning.
+type MockProviderConfig struct {
+	// Latency is the simulated time to first token.
+	Latency time.Duration
+	// Jitter adds a uniformly random delay in [0, Jitter) to each completion.
+	Jitter time.Duration
+	// Response is the text returned by every completion.
+	Response string
+	// Chunks splits Response into this many streamed chunks (default 1).
+	Chunks int
+	// ChunkDelay is the delay between streamed chunks.
+	ChunkDelay time.Duration
+}
+
+// MockProvider is an LLM provider that returns canned responses after a
+// configurable delay, so gateway overhead can be measured without API calls.
+type MockProvider struct {
+	cfg MockProviderConfig
+
+	mu  sync.Mutex
+	rng *rand.Rand
+}
+
+// NewMockProvider creates a mock provider.
+func NewMockProvider(cfg MockProviderConfig) *MockProvider {
+	if cfg.Response == "" {
+		cfg.Response = "ok"
+	}
+	if cfg.Chunks <= 0 {
+		cfg.Chunks = 1
+	}
+	return &MockProvider{cfg: cfg, rng: rand.New(rand.NewSource(time.Now().UnixNano()))}
+}
+
+// Complete streams the canned response.
+func (p *MockProvider) Complete(ctx context.Context, req *agent.CompletionRequest) (<-chan *agent.CompletionChunk, error) {
+	delay := p.cfg.Latency
+	if p.cfg.Jitter > 0 {
+		p.mu.Lock()
+		delay += time.Duration(p.rng.Int63n(int64(p.cfg.Jitter)))
+		p.mu.Unlock()
+	}
+	inputTokens := 0
+	for _, msg := range req.Messages {
+		inputTokens += len(strings.Fields(msg.Content))
+	}
+	parts := splitChunks(p.cfg.Response, p.cfg.Chunks)
+
+	ch := make(chan *agent.CompletionChunk, len(parts)+1)
+	go func() {
+		defer close(ch)
+		if !sleepCtx(ctx, delay) {
+			ch <- &agent.CompletionChunk{Error: ctx.Err()}
+			return
+		}
+		for i, part := range parts {
+			if i > 0 && !sleepCtx(ctx, p.cfg.ChunkDelay) {
+				ch <- &agent.CompletionChunk{Error: ctx.Err()}
+				return
+			}
+			ch <- &agent.CompletionChunk{Text: part}
+		}
+		ch <- &agent.CompletionChunk{
+			Done:         true,
+			InputTokens:  inputTokens,
+			OutputTokens: len(strings.Fields(p.cfg.Response)),
+		}
+	}()
+	return ch, nil
+}
+
+// Name returns the provider name.
+func (p *MockProvider) Name() string { return "mock" }
+
+// Models returns the single mock model.
+func (p *MockProvider) Models() []agent.Model {
+	return []agent.Model{{ID: "mock", Name: "Mock"}}
+}
+
+// SupportsTools reports false; the mock never requests tools.
+func (p *MockProvider) SupportsTools() bool { return false }
+
+func splitChunks(text string, n int) []string {
+	if n <= 1 || len(text) <= n {
+		return []string{text}
+	}
+	size := (len(text) + n - 1) / n
+	parts := make([]string, 0, n)
+	for start := 0; start < len(text); start += size {
+		end := start + size
+		if end > len(text) {
+			end = len(text)
+		}
+		parts = append(parts, text[start:end])
+	}
+	return parts
+}
+
+func sleepCtx(ctx context.Context, d time.Duration) bool {
+	if d <= 0 {
+		return ctx.Err() == nil
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// InstrumentedProvider wraps a provider and records completion counts,
+// latency, and token usage.
+type InstrumentedProvider struct {
+	agent.LLMProvider
+
+	calls        atomic.Int64
+	errors       atomic.Int64
+	inputTokens  atomic.Int64
+	outputTokens atomic.Int64
+	totalNanos   atomic.Int64
+}
+
+// NewInstrumentedProvider wraps provider with instrumentation.
+func NewInstrumentedProvider(provider agent.LLMProvider) *InstrumentedProvider {
+	return &InstrumentedProvider{LLMProvider: provider}
+}
+
+// Complete forwards to the wrapped provider and records stream statistics.
+func (p *InstrumentedProvider) Complete(ctx context.Context, req *agent.CompletionRequest) (<-chan *agent.CompletionChunk, error) {
+	start := time.Now()
+	p.calls.Add(1)
+	upstream, err := p.LLMProvider.Complete(ctx, req)
+	if err != nil {
+		p.errors.Add(1)
+		return nil, err
+	}
+	out := make(chan *agent.CompletionChunk)
+	go func() {
+		defer close(out)
+		defer func() { p.totalNanos.Add(int64(time.Since(start))) }()
+		for chunk := range upstream {
+			if chunk != nil {
+				if chunk.Error != nil {
+					p.errors.Add(1)
+				}
+				p.inputTokens.Add(int64(chunk.InputTokens))
+				p.outputTokens.Add(int64(chunk.OutputTokens))
+			}
+			select {
+			case out <- chunk:
+			case <-ctx.Done():
+				// Drain so the upstream producer can exit.
+				for range upstream {
+				}
+				return
+			}
+		}
+	}()
+	return out, nil
+}
+
+// Stats returns a snapshot of provider statistics over the given elapsed time.
+func (p *InstrumentedProvider) Stats(elapsed time.Duration) ProviderStats {
+	stats := ProviderStats{
+		Name:         p.LLMProvider.Name(),
+		Calls:        p.calls.Load(),
+		Errors:       p.errors.Load(),
+		InputTokens:  p.inputTokens.Load(),
+		OutputTokens: p.outputTokens.Load(),
+	}
+	if stats.Calls > 0 {
+		stats.AvgLatency = time.Duration(p.totalNanos.Load() / stats.Calls)
+	}
+	if secs := elapsed.Seconds(); secs > 0 {
+		stats.CallsPerSec = float64(stats.Calls) / secs
+		stats.OutputTokensPerSec = float64(stats.OutputTokens) / secs
+	}
+	return stats
+}
@@ -0,0 +1,322 @@
+// Package bench drives synthetic load through the gateway pipeline and reports
+// end-to-end latency, queue depth, and provider throughput.
+package bench
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/haasonsaas/nexus/pkg/models"
+)
+
+// Target is the inbound side of the system under test, typically a loopback
+// channel adapter registered with an embedded gateway.
+type Target interface {
+	Inject(ctx context.Context, conversationID, content string) (*models.Message, error)
+	Replies() <-chan *models.Message
+}
+
+// Config controls a benchmark run.
+type Config struct {
+	// Messages is the total number of messages to send.
+	Messages int
+	// Concurrency is the number of concurrent senders.
+	Concurrency int
+	// Conversations is the number of distinct conversations. Each sender owns
+	// its own conversations so replies can be correlated; values below
+	// Concurrency are raised to Concurrency.
+	Conversations int
+	// Prompt is the message content sent on every request.
+	Prompt string
+	// Timeout bounds how long to wait for each reply.
+	Timeout time.Duration
+	// SampleInterval controls how often queue depth is sampled.
+	SampleInterval time.Duration
+	// QueueDepth reports the number of queued or in-flight messages.
+	QueueDepth func() int
+	// ConversationID extracts the conversation ID from a reply.
+	ConversationID func(*models.Message) string
+}
+
+// Result summarises a benchmark run.
+type Result struct {
+	Messages    int            `json:"messages"`
+	Concurrency int            `json:"concurrency"`
+	Completed   int64          `json:"completed"`
+	TimedOut    int64          `json:"timed_out"`
+	Errors      int64          `json:"errors"`
+	Duration    time.Duration  `json:"duration"`
+	Throughput  float64        `json:"throughput_per_sec"`
+	Latency     LatencyStats   `json:"latency"`
+	Queue       QueueStats     `json:"queue"`
+	Provider    *ProviderStats `json:"provider,omitempty"`
+}
+
+// LatencyStats holds end-to-end latency percentiles.
+type LatencyStats struct {
+	Min  time.Duration `json:"min"`
+	Mean time.Duration `json:"mean"`
+	P50  time.Duration `json:"p50"`
+	P90  time.Duration `json:"p90"`
+	P95  time.Duration `json:"p95"`
+	P99  time.Duration `json:"p99"`
+	Max  time.Duration `json:"max"`
+}
+
+// QueueStats holds sampled queue depth.
+type QueueStats struct {
+	Samples int     `json:"samples"`
+	Max     int     `json:"max"`
+	Mean    float64 `json:"mean"`
+}
+
+// ProviderStats holds LLM provider throughput.
+type ProviderStats struct {
+	Name               string        `json:"name"`
+	Calls              int64         `json:"calls"`
+	Errors             int64         `json:"errors"`
+	CallsPerSec        float64       `json:"calls_per_sec"`
+	AvgLatency         time.Duration `json:"avg_latency"`
+	InputTokens        int64         `json:"input_tokens"`
+	OutputTokens       int64         `json:"output_tokens"`
+	OutputTokensPerSec float64       `json:"output_tokens_per_sec"`
+}
+
+// Run sends cfg.Messages messages through target and measures the results.
+func Run(ctx context.Context, target Target, cfg Config) (*Result, error) {
+	if target == nil {
+		return nil, errors.New("bench target is nil")
+	}
+	if cfg.Messages <= 0 {
+		return nil, errors.New("messages must be positive")
+	}
+	if cfg.ConversationID == nil {
+		return nil, errors.New("conversation id extractor is required")
+	}
+	if cfg.Concurrency <= 0 {
+		cfg.Concurrency = 1
+	}
+	if cfg.Concurrency > cfg.Messages {
+		cfg.Concurrency = cfg.Messages
+	}
+	if cfg.Conversations < cfg.Concurrency {
+		cfg.Conversations = cfg.Concurrency
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = 30 * time.Second
+	}
+	if cfg.SampleInterval <= 0 {
+		cfg.SampleInterval = 50 * time.Millisecond
+	}
+	if cfg.Prompt == "" {
+		cfg.Prompt = "ping"
+	}
+
+	runCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	d := newDispatcher(cfg.ConversationID)
+	go d.run(runCtx, target.Replies())
+
+	var sampler queueSampler
+	if cfg.QueueDepth != nil {
+		go sampler.run(runCtx, cfg.QueueDepth, cfg.SampleInterval)
+	}
+
+	var (
+		next      atomic.Int64
+		completed atomic.Int64
+		timedOut  atomic.Int64
+		failed    atomic.Int64
+		latMu     sync.Mutex
+		latencies = make([]time.Duration, 0, cfg.Messages)
+		wg        sync.WaitGroup
+	)
+	start := time.Now()
+	for w := 0; w < cfg.Concurrency; w++ {
+		wg.Add(1)
+		go func(worker int) {
+			defer wg.Done()
+			convs := conversationsFor(worker, cfg.Concurrency, cfg.Conversations)
+			for i := 0; ; i++ {
+				if next.Add(1) > int64(cfg.Messages) || runCtx.Err() != nil {
+					return
+				}
+				conv := convs[i%len(convs)]
+				replies := d.register(conv)
+				sent := time.Now()
+				if _, err := target.Inject(runCtx, conv, cfg.Prompt); err != nil {
+					d.unregister(conv)
+					failed.Add(1)
+					continue
+				}
+				timer := time.NewTimer(cfg.Timeout)
+				select {
+				case <-replies:
+					latMu.Lock()
+					latencies = append(latencies, time.Since(sent))
+					latMu.Unlock()
+					completed.Add(1)
+				case <-timer.C:
+					timedOut.Add(1)
+				case <-runCtx.Done():
+				}
+				timer.Stop()
+				d.unregister(conv)
+			}
+		}(w)
+	}
+	wg.Wait()
+	elapsed := time.Since(start)
+	cancel()
+
+	result := &Result{
+		Messages:    cfg.Messages,
+		Concurrency: cfg.Concurrency,
+		Completed:   completed.Load(),
+		TimedOut:    timedOut.Load(),
+		Errors:      failed.Load(),
+		Duration:    elapsed,
+		Latency:     computeLatency(latencies),
+		Queue:       sampler.stats(),
+	}
+	if secs := elapsed.Seconds(); secs > 0 {
+		result.Throughput = float64(result.Completed) / secs
+	}
+	if err := ctx.Err(); err != nil {
+		return result, err
+	}
+	return result, nil
+}
+
+// conversationsFor assigns conversations to a worker so no two workers share one.
+func conversationsFor(worker, workers, total int) []string {
+	var convs []string
+	for c := worker; c < total; c += workers {
+		convs = append(convs, fmt.Sprintf("bench-%d", c))
+	}
+	return convs
+}
+
+// dispatcher routes replies to the sender waiting on each conversation.
+type dispatcher struct {
+	convID  func(*models.Message) string
+	mu      sync.Mutex
+	waiters map[string]chan *models.Message
+}
+
+func newDispatcher(convID func(*models.Message) string) *dispatcher {
+	return &dispatcher{convID: convID, waiters: make(map[string]chan *models.Message)}
+}
+
+func (d *dispatcher) register(conv string) <-chan *models.Message {
+	ch := make(chan *models.Message, 1)
+	d.mu.Lock()
+	d.waiters[conv] = ch
+	d.mu.Unlock()
+	return ch
+}
+
+func (d *dispatcher) unregister(conv string) {
+	d.mu.Lock()
+	delete(d.waiters, conv)
+	d.mu.Unlock()
+}
+
+func (d *dispatcher) run(ctx context.Context, replies <-chan *models.Message) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case msg, ok := <-replies:
+			if !ok {
+				return
+			}
+			d.mu.Lock()
+			ch := d.waiters[d.convID(msg)]
+			d.mu.Unlock()
+			if ch == nil {
+				continue
+			}
+			select {
+			case ch <- msg:
+			default:
+			}
+		}
+	}
+}
+
+type queueSampler struct {
+	mu      sync.Mutex
+	samples int
+	max     int
+	total   int
+}
+
+func (s *queueSampler) run(ctx context.Context, depth func() int, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			v := depth()
+			s.mu.Lock()
+			s.samples++
+			s.total += v
+			if v > s.max {
+				s.max = v
+			}
+			s.mu.Unlock()
+		}
+	}
+}
+
+func (s *queueSampler) stats() QueueStats {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	stats := QueueStats{Samples: s.samples, Max: s.max}
+	if s.samples > 0 {
+		stats.Mean = float64(s.total) / float64(s.samples)
+	}
+	return stats
+}
+
+func computeLatency(samples []time.Duration) LatencyStats {
+	if len(samples) == 0 {
+		return LatencyStats{}
+	}
+	sorted := append([]time.Duration(nil), samples...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	var total time.Duration
+	for _, s := range sorted {
+		total += s
+	}
+	return LatencyStats{
+		Min:  sorted[0],
+		Mean: total / time.Duration(len(sorted)),
+		P50:  percentile(sorted, 0.50),
+		P90:  percentile(sorted, 0.90),
+		P95:  percentile(sorted, 0.95),
+		P99:  percentile(sorted, 0.99),
+		Max:  sorted[len(sorted)-1],
+	}
+}
+
+// percentile returns the nearest-rank percentile of a sorted slice.
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	rank := int(p*float64(len(sorted))+0.999999) - 1
+	if rank < 0 {
+		rank = 0
+	}
+	if rank >= len(sorted) {
+		rank = len(sorted) - 1
+	}
+	return sorted[rank]
+}
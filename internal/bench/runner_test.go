@@ -0,0 +1,85 @@
+package bench
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/haasonsaas/nexus/internal/agent"
+	"github.com/haasonsaas/nexus/pkg/models"
+)
+
+// echoTarget replies to every injected message after a fixed delay.
+type echoTarget struct {
+	delay   time.Duration
+	replies chan *models.Message
+}
+
+func (t *echoTarget) Inject(ctx context.Context, conversationID, content string) (*models.Message, error) {
+	msg := &models.Message{ChannelID: conversationID, Content: content}
+	go func() {
+		time.Sleep(t.delay)
+		t.replies <- &models.Message{ChannelID: conversationID, Content: "pong"}
+	}()
+	return msg, nil
+}
+
+func (t *echoTarget) Replies() <-chan *models.Message { return t.replies }
+
+func TestRunMeasuresLatency(t *testing.T) {
+	target := &echoTarget{delay: 5 * time.Millisecond, replies: make(chan *models.Message, 16)}
+	result, err := Run(context.Background(), target, Config{
+		Messages:       20,
+		Concurrency:    4,
+		Timeout:        time.Second,
+		QueueDepth:     func() int { return 1 },
+		SampleInterval: time.Millisecond,
+		ConversationID: func(m *models.Message) string { return m.ChannelID },
+	})
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if result.Completed != 20 || result.TimedOut != 0 {
+		t.Fatalf("completed=%d timed_out=%d, want 20/0", result.Completed, result.TimedOut)
+	}
+	if result.Latency.P50 < 5*time.Millisecond || result.Latency.Max < result.Latency.P99 {
+		t.Fatalf("unexpected latency stats: %+v", result.Latency)
+	}
+	if result.Queue.Max != 1 {
+		t.Fatalf("queue max = %d, want 1", result.Queue.Max)
+	}
+}
+
+func TestPercentile(t *testing.T) {
+	sorted := make([]time.Duration, 100)
+	for i := range sorted {
+		sorted[i] = time.Duration(i+1) * time.Millisecond
+	}
+	if got := percentile(sorted, 0.5); got != 50*time.Millisecond {
+		t.Fatalf("p50 = %v, want 50ms", got)
+	}
+	if got := percentile(sorted, 0.99); got != 99*time.Millisecond {
+		t.Fatalf("p99 = %v, want 99ms", got)
+	}
+}
+
+func TestInstrumentedMockProvider(t *testing.T) {
+	provider := NewInstrumentedProvider(NewMockProvider(MockProviderConfig{Response: "hello there world", Chunks: 3}))
+	ch, err := provider.Complete(context.Background(), &agent.CompletionRequest{
+		Messages: []agent.CompletionMessage{{Role: "user", Content: "hi you"}},
+	})
+	if err != nil {
+		t.Fatalf("Complete: %v", err)
+	}
+	var text string
+	for chunk := range ch {
+		text += chunk.Text
+	}
+	if text != "hello there world" {
+		t.Fatalf("text = %q", text)
+	}
+	stats := provider.Stats(time.Second)
+	if stats.Calls != 1 || stats.InputTokens != 2 || stats.OutputTokens != 3 {
+		t.Fatalf("stats = %+v", stats)
+	}
+}
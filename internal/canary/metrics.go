@@ -0,0 +1,74 @@
+package canary
+
+import (
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// Metrics exports canary outcomes.
+type Metrics struct {
+	// Runs counts canary runs.
+	// Labels: canary, result (success|failure)
+	Runs *prometheus.CounterVec
+
+	// Latency is the time from sending the canary message to its reply,
+	// for successful runs.
+	// Labels: canary
+	Latency *prometheus.HistogramVec
+
+	// Up is 1 when the last run succeeded and 0 otherwise.
+	// Labels: canary
+	Up *prometheus.GaugeVec
+
+	// ConsecutiveFailures counts failed runs since the last success.
+	// Labels: canary
+	ConsecutiveFailures *prometheus.GaugeVec
+}
+
+var (
+	metricsOnce     sync.Once
+	metricsInstance *Metrics
+)
+
+// NewMetrics returns the process-wide canary metrics.
+func NewMetrics() *Metrics {
+	metricsOnce.Do(func() {
+		metricsInstance = &Metrics{
+			Runs: promauto.NewCounterVec(prometheus.CounterOpts{
+				Name: "nexus_canary_runs_total",
+				Help: "Canary conversation runs by result",
+			}, []string{"canary", "result"}),
+			Latency: promauto.NewHistogramVec(prometheus.HistogramOpts{
+				Name:    "nexus_canary_latency_seconds",
+				Help:    "Time from sending a canary message to its reply",
+				Buckets: []float64{0.5, 1, 2, 5, 10, 15, 30, 60, 120},
+			}, []string{"canary"}),
+			Up: promauto.NewGaugeVec(prometheus.GaugeOpts{
+				Name: "nexus_canary_up",
+				Help: "Whether the last canary run succeeded (1) or failed (0)",
+			}, []string{"canary"}),
+			ConsecutiveFailures: promauto.NewGaugeVec(prometheus.GaugeOpts{
+				Name: "nexus_canary_consecutive_failures",
+				Help: "Failed canary runs since the last success",
+			}, []string{"canary"}),
+		}
+	})
+	return metricsInstance
+}
+
+func (m *Metrics) record(r Result, latency time.Duration) {
+	if m == nil {
+		return
+	}
+	if r.Success {
+		m.Runs.WithLabelValues(r.Name, "success").Inc()
+		m.Latency.WithLabelValues(r.Name).Observe(latency.Seconds())
+		m.Up.WithLabelValues(r.Name).Set(1)
+		return
+	}
+	m.Runs.WithLabelValues(r.Name, "failure").Inc()
+	m.Up.WithLabelValues(r.Name).Set(0)
+}
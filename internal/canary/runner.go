@@ -0,0 +1,270 @@
+// Package canary runs scripted conversations through the gateway on an
+// interval, records their success and latency, and alerts when a check keeps
+// failing. It catches provider or channel breakage that produces no errors,
+// such as replies that silently stop arriving.
+package canary
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/haasonsaas/nexus/internal/config"
+	"github.com/haasonsaas/nexus/internal/infra"
+)
+
+// State is whether a check is passing or failing.
+type State string
+
+const (
+	StatePassing State = "passing"
+	StateFailing State = "failing"
+)
+
+// Result is the outcome of one canary run.
+type Result struct {
+	Name      string    `json:"name"`
+	Success   bool      `json:"success"`
+	LatencyMs int64     `json:"latency_ms"`
+	Error     string    `json:"error,omitempty"`
+	At        time.Time `json:"at"`
+	// ConsecutiveFailures counts failed runs since the last success.
+	ConsecutiveFailures int `json:"consecutive_failures"`
+}
+
+// Alert reports a check reaching the failure threshold (StateFailing) or
+// passing again after an alert (StatePassing).
+type Alert struct {
+	Result
+	State State `json:"state"`
+}
+
+// Text describes the alert for chat delivery.
+func (a Alert) Text() string {
+	if a.State == StatePassing {
+		return fmt.Sprintf("Canary %q recovered (reply in %dms).", a.Name, a.LatencyMs)
+	}
+	return fmt.Sprintf("Canary %q failed %d times in a row: %s", a.Name, a.ConsecutiveFailures, a.Error)
+}
+
+// AlertFunc delivers an alert.
+type AlertFunc func(ctx context.Context, alert Alert) error
+
+// DispatchFunc sends check.Message into the gateway, tagged with id so the
+// reply can be reported with Runner.Reply.
+type DispatchFunc func(ctx context.Context, check config.CanaryCheckConfig, id string) error
+
+type checkState struct {
+	last     *Result
+	failures int
+	alerted  bool
+}
+
+// Runner runs the configured checks.
+type Runner struct {
+	cfg      config.CanaryConfig
+	dispatch DispatchFunc
+	logger   *slog.Logger
+	metrics  *Metrics
+
+	mu      sync.Mutex
+	pending map[string]chan string
+	states  map[string]*checkState
+	alert   AlertFunc
+}
+
+// NewRunner creates a runner that sends canary messages with dispatch.
+func NewRunner(cfg config.CanaryConfig, dispatch DispatchFunc, logger *slog.Logger) *Runner {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &Runner{
+		cfg:      cfg,
+		dispatch: dispatch,
+		logger:   logger.With("component", "canary"),
+		metrics:  NewMetrics(),
+		pending:  make(map[string]chan string),
+		states:   make(map[string]*checkState),
+	}
+}
+
+// SetAlert sets the function called when a check starts failing or recovers.
+func (r *Runner) SetAlert(fn AlertFunc) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.alert = fn
+}
+
+// RunOnce runs all checks concurrently and returns their results.
+func (r *Runner) RunOnce(ctx context.Context) []Result {
+	results := make([]Result, len(r.cfg.Checks))
+	var wg sync.WaitGroup
+	for i, check := range r.cfg.Checks {
+		wg.Add(1)
+		go func(i int, check config.CanaryCheckConfig) {
+			defer wg.Done()
+			results[i] = r.runCheck(ctx, check)
+		}(i, check)
+	}
+	wg.Wait()
+	return results
+}
+
+// Reply reports the reply to the canary message id. It returns false when
+// id is not a pending canary message.
+func (r *Runner) Reply(id, content string) bool {
+	r.mu.Lock()
+	ch, ok := r.pending[id]
+	r.mu.Unlock()
+	if !ok {
+		return false
+	}
+	select {
+	case ch <- content:
+	default:
+	}
+	return true
+}
+
+func (r *Runner) runCheck(ctx context.Context, check config.CanaryCheckConfig) Result {
+	timeout := check.Timeout
+	if timeout <= 0 {
+		timeout = r.cfg.Timeout
+	}
+	if timeout <= 0 {
+		timeout = time.Minute
+	}
+	id := "canary-" + uuid.NewString()
+	replies := make(chan string, 1)
+	r.mu.Lock()
+	r.pending[id] = replies
+	r.mu.Unlock()
+	defer func() {
+		r.mu.Lock()
+		delete(r.pending, id)
+		r.mu.Unlock()
+	}()
+
+	start := time.Now()
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	// The run itself uses ctx, so post-reply work is not cut short by the
+	// check's timeout.
+	err := r.dispatch(ctx, check, id)
+	if err == nil {
+		select {
+		case reply := <-replies:
+			if !strings.Contains(strings.ToLower(reply), strings.ToLower(check.Expect)) {
+				err = fmt.Errorf("reply does not contain %q", check.Expect)
+			}
+		case <-timer.C:
+			err = fmt.Errorf("no reply within %s", timeout)
+		case <-ctx.Done():
+			err = ctx.Err()
+		}
+	}
+	latency := time.Since(start)
+
+	result := Result{Name: check.Name, Success: err == nil, LatencyMs: latency.Milliseconds(), At: start}
+	if err != nil {
+		result.Error = err.Error()
+	}
+	r.metrics.record(result, latency)
+	r.update(ctx, &result)
+	return result
+}
+
+// update tracks consecutive failures and sends an alert when a check
+// reaches the threshold or recovers after one.
+func (r *Runner) update(ctx context.Context, result *Result) {
+	threshold := max(r.cfg.Alerts.AfterFailures, 1)
+
+	r.mu.Lock()
+	state, ok := r.states[result.Name]
+	if !ok {
+		state = &checkState{}
+		r.states[result.Name] = state
+	}
+	var alert *Alert
+	if result.Success {
+		if state.alerted {
+			alert = &Alert{State: StatePassing}
+		}
+		state.failures, state.alerted = 0, false
+	} else {
+		state.failures++
+		if state.failures >= threshold && !state.alerted {
+			state.alerted = true
+			alert = &Alert{State: StateFailing}
+		}
+	}
+	result.ConsecutiveFailures = state.failures
+	if alert != nil {
+		alert.Result = *result
+	}
+	last := *result
+	state.last = &last
+	send := r.alert
+	r.mu.Unlock()
+
+	r.metrics.ConsecutiveFailures.WithLabelValues(result.Name).Set(float64(result.ConsecutiveFailures))
+	if result.Success {
+		r.logger.Debug("canary passed", "canary", result.Name, "latency_ms", result.LatencyMs)
+	} else {
+		r.logger.Warn("canary failed", "canary", result.Name, "error", result.Error, "consecutive_failures", result.ConsecutiveFailures)
+	}
+	if alert == nil || send == nil {
+		return
+	}
+	if err := send(ctx, *alert); err != nil {
+		r.logger.Warn("failed to send canary alert", "canary", result.Name, "error", err)
+	}
+}
+
+// Results returns the latest result of each check that has run.
+func (r *Runner) Results() []Result {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	out := make([]Result, 0, len(r.states))
+	for _, state := range r.states {
+		if state.last != nil {
+			out = append(out, *state.last)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+	return out
+}
+
+// HealthCheck reports failing checks: degraded below the alert threshold
+// and unhealthy at or above it.
+func (r *Runner) HealthCheck(ctx context.Context) infra.HealthCheckResult {
+	result := infra.HealthCheckResult{
+		Name:      "canary",
+		Status:    infra.ServiceHealthHealthy,
+		Timestamp: time.Now(),
+		Metadata:  map[string]string{},
+	}
+	threshold := max(r.cfg.Alerts.AfterFailures, 1)
+	var problems []string
+	for _, last := range r.Results() {
+		if last.Success {
+			result.Metadata[last.Name] = string(StatePassing)
+			continue
+		}
+		result.Metadata[last.Name] = string(StateFailing)
+		if last.ConsecutiveFailures >= threshold {
+			result.Status = infra.ServiceHealthUnhealthy
+		} else if result.Status == infra.ServiceHealthHealthy {
+			result.Status = infra.ServiceHealthDegraded
+		}
+		problems = append(problems, fmt.Sprintf("%s: %s", last.Name, last.Error))
+	}
+	result.Message = strings.Join(problems, ", ")
+	return result
+}
@@ -0,0 +1,96 @@
+package canary
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/haasonsaas/nexus/internal/config"
+	"github.com/haasonsaas/nexus/internal/infra"
+)
+
+// fakeGateway answers canary messages with reply, or not at all when reply
+// is empty.
+type fakeGateway struct {
+	runner *Runner
+	reply  string
+	err    error
+}
+
+func (g *fakeGateway) dispatch(ctx context.Context, check config.CanaryCheckConfig, id string) error {
+	if g.err != nil {
+		return g.err
+	}
+	if g.reply != "" {
+		go g.runner.Reply(id, g.reply)
+	}
+	return nil
+}
+
+func newTestRunner(gw *fakeGateway) *Runner {
+	runner := NewRunner(config.CanaryConfig{
+		Timeout: 50 * time.Millisecond,
+		Checks:  []config.CanaryCheckConfig{{Name: "loopback", Message: "ping", Expect: "pong"}},
+		Alerts:  config.CanaryAlertConfig{AfterFailures: 2},
+	}, gw.dispatch, nil)
+	gw.runner = runner
+	return runner
+}
+
+func TestRunOnceChecksReply(t *testing.T) {
+	gw := &fakeGateway{reply: "PONG!"}
+	runner := newTestRunner(gw)
+	if result := runner.RunOnce(context.Background())[0]; !result.Success {
+		t.Fatalf("expected success, got %+v", result)
+	}
+
+	gw.reply = "hello"
+	result := runner.RunOnce(context.Background())[0]
+	if result.Success || !strings.Contains(result.Error, `reply does not contain "pong"`) {
+		t.Fatalf("expected a content failure, got %+v", result)
+	}
+
+	gw.reply = ""
+	result = runner.RunOnce(context.Background())[0]
+	if result.Success || !strings.Contains(result.Error, "no reply within 50ms") || result.ConsecutiveFailures != 2 {
+		t.Fatalf("expected a timeout, got %+v", result)
+	}
+	if runner.Reply("unknown", "pong") {
+		t.Fatal("expected replies to unknown messages to be ignored")
+	}
+}
+
+func TestAlertsOnConsecutiveFailures(t *testing.T) {
+	gw := &fakeGateway{err: errors.New("adapter missing")}
+	runner := newTestRunner(gw)
+	var alerts []Alert
+	runner.SetAlert(func(_ context.Context, alert Alert) error {
+		alerts = append(alerts, alert)
+		return nil
+	})
+
+	for i := 0; i < 3; i++ {
+		runner.RunOnce(context.Background())
+	}
+	if len(alerts) != 1 || alerts[0].State != StateFailing || alerts[0].ConsecutiveFailures != 2 {
+		t.Fatalf("expected one failing alert at the threshold, got %+v", alerts)
+	}
+	if got := alerts[0].Text(); got != `Canary "loopback" failed 2 times in a row: adapter missing` {
+		t.Fatalf("unexpected alert text %q", got)
+	}
+	if health := runner.HealthCheck(context.Background()); health.Status != infra.ServiceHealthUnhealthy {
+		t.Fatalf("expected unhealthy, got %+v", health)
+	}
+
+	gw.err, gw.reply = nil, "pong"
+	runner.RunOnce(context.Background())
+	runner.RunOnce(context.Background())
+	if len(alerts) != 2 || alerts[1].State != StatePassing || alerts[1].ConsecutiveFailures != 0 {
+		t.Fatalf("expected a single recovery alert, got %+v", alerts)
+	}
+	if health := runner.HealthCheck(context.Background()); health.Status != infra.ServiceHealthHealthy {
+		t.Fatalf("expected healthy, got %+v", health)
+	}
+}
@@ -49,6 +49,15 @@ func NewCockroachStoreFromDSN(dsn string, config *storage.CockroachConfig) (*Coc
 	return &CockroachStore{db: db}, nil
 }
 
+// NewSQLiteStore creates a canvas store on an open, migrated SQLite
+// database. The queries are shared with the CockroachDB/Postgres store.
+func NewSQLiteStore(db *sql.DB) (*CockroachStore, error) {
+	if db == nil {
+		return nil, fmt.Errorf("db is required")
+	}
+	return &CockroachStore{db: db}, nil
+}
+
 // Close closes the underlying database connection.
 func (s *CockroachStore) Close() error {
 	if s.db == nil {
@@ -213,14 +222,23 @@ func (s *CockroachStore) UpsertState(ctx context.Context, state *State) error {
 	if state.UpdatedAt.IsZero() {
 		state.UpdatedAt = time.Now()
 	}
+	var versions []byte
+	if len(state.Versions) > 0 {
+		encoded, err := json.Marshal(state.Versions)
+		if err != nil {
+			return fmt.Errorf("encode canvas versions: %w", err)
+		}
+		versions = encoded
+	}
 	_, err := s.db.ExecContext(ctx, `
-		INSERT INTO canvas_state (session_id, state_json, updated_at)
-		VALUES ($1,$2,$3)
+		INSERT INTO canvas_state (session_id, state_json, versions_json, updated_at)
+		VALUES ($1,$2,$3,$4)
 		ON CONFLICT (session_id) DO UPDATE
-		SET state_json = excluded.state_json, updated_at = excluded.updated_at
+		SET state_json = excluded.state_json, versions_json = excluded.versions_json, updated_at = excluded.updated_at
 	`,
 		state.SessionID,
 		json.RawMessage(state.StateJSON),
+		versions,
 		state.UpdatedAt,
 	)
 	if err != nil {
@@ -234,13 +252,13 @@ func (s *CockroachStore) UpsertState(ctx context.Context, state *State) error {
 
 func (s *CockroachStore) GetState(ctx context.Context, sessionID string) (*State, error) {
 	row := s.db.QueryRowContext(ctx, `
-		SELECT session_id, state_json, updated_at
+		SELECT session_id, state_json, versions_json, updated_at
 		FROM canvas_state WHERE session_id = $1
 	`, sessionID)
 
 	var state State
-	var raw []byte
-	if err := row.Scan(&state.SessionID, &raw, &state.UpdatedAt); err != nil {
+	var raw, versions []byte
+	if err := row.Scan(&state.SessionID, &raw, &versions, &state.UpdatedAt); err != nil {
 		if err == sql.ErrNoRows {
 			return nil, ErrNotFound
 		}
@@ -249,6 +267,11 @@ func (s *CockroachStore) GetState(ctx context.Context, sessionID string) (*State
 	if len(raw) > 0 {
 		state.StateJSON = append([]byte(nil), raw...)
 	}
+	if len(versions) > 0 {
+		if err := json.Unmarshal(versions, &state.Versions); err != nil {
+			return nil, fmt.Errorf("decode canvas versions: %w", err)
+		}
+	}
 	return &state, nil
 }
 
@@ -348,6 +371,19 @@ func (s *CockroachStore) DeleteEvents(ctx context.Context, sessionID string) err
 	return nil
 }
 
+// DeleteEventsBefore removes events created before the cutoff.
+func (s *CockroachStore) DeleteEventsBefore(ctx context.Context, sessionID string, before time.Time) (int64, error) {
+	res, err := s.db.ExecContext(ctx, `DELETE FROM canvas_events WHERE session_id = $1 AND created_at < $2`, sessionID, before)
+	if err != nil {
+		return 0, fmt.Errorf("prune canvas events: %w", err)
+	}
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("prune canvas events: %w", err)
+	}
+	return rows, nil
+}
+
 func nullString(value string) sql.NullString {
 	if strings.TrimSpace(value) == "" {
 		return sql.NullString{}
@@ -363,7 +399,8 @@ func isUniqueViolation(err error) bool {
 	if errors.As(err, &pqErr) && pqErr.Code == "23505" {
 		return true
 	}
-	return strings.Contains(strings.ToLower(err.Error()), "duplicate")
+	message := strings.ToLower(err.Error())
+	return strings.Contains(message, "duplicate") || strings.Contains(message, "unique constraint")
 }
 
 func isForeignKeyViolation(err error) bool {
@@ -20,6 +20,7 @@ import (
 	"time"
 
 	"github.com/fsnotify/fsnotify"
+	"github.com/google/uuid"
 	"github.com/gorilla/websocket"
 
 	"github.com/haasonsaas/nexus/internal/auth"
@@ -959,6 +960,7 @@ func (h *Host) Start(ctx context.Context) error {
 	})
 	mux.Handle(path.Join(canvasPrefix, "api/stream"), h.streamHandler())
 	mux.Handle(path.Join(canvasPrefix, "api/action"), h.actionsHandler())
+	mux.Handle(path.Join(canvasPrefix, "api/sync"), h.syncHandler())
 
 	if h.liveReload {
 		mux.Handle(h.liveReloadScriptPath(), h.liveReloadScriptHandler())
@@ -1263,6 +1265,7 @@ func (h *Host) streamHandler() http.Handler {
 					Type:      "state",
 					SessionID: sessionID,
 					Payload:   state.StateJSON,
+					Version:   state.Versions,
 					Timestamp: time.Now(),
 				}); err != nil {
 					h.logger.Warn("canvas stream write failed", "error", err)
@@ -1348,12 +1351,7 @@ func (h *Host) actionsHandler() http.Handler {
 			h.writeTokenError(w, err)
 			return
 		}
-		role := RoleEditor
-		if access != nil {
-			role = NormalizeRole(access.Role)
-		} else if user != nil {
-			role = NormalizeRole(h.actionDefaultRole)
-		}
+		role := h.sessionRole(access, user)
 		if !RoleAllowsAction(role) {
 			http.Error(w, "forbidden", http.StatusForbidden)
 			return
@@ -1405,6 +1403,237 @@ func (h *Host) actionsHandler() http.Handler {
 	})
 }
 
+// syncRequest is a frame sent by clients over the sync socket.
+type syncRequest struct {
+	Type  string          `json:"type"`
+	ID    string          `json:"id,omitempty"`
+	Base  VersionVector   `json:"base,omitempty"`
+	Ops   []PatchOp       `json:"ops,omitempty"`
+	State json.RawMessage `json:"state,omitempty"`
+}
+
+// SyncMessage is a reply frame sent to a single client over the sync
+// socket. Broadcast "patch" and "reset" frames use StreamMessage.
+type SyncMessage struct {
+	Type      string          `json:"type"`
+	ID        string          `json:"id,omitempty"`
+	SessionID string          `json:"session_id"`
+	ClientID  string          `json:"client_id,omitempty"`
+	Role      string          `json:"role,omitempty"`
+	Payload   json.RawMessage `json:"payload,omitempty"`
+	Version   VersionVector   `json:"version,omitempty"`
+	Error     string          `json:"error,omitempty"`
+	Timestamp time.Time       `json:"ts"`
+}
+
+const (
+	syncReadLimit    = 1 << 20
+	syncPingInterval = 30 * time.Second
+	syncPongWait     = 75 * time.Second
+	syncWriteWait    = 10 * time.Second
+)
+
+// syncHandler serves the collaborative state socket. Clients receive the
+// current state and version vector, then send JSON patches based on the
+// version they last saw. Stale patches are answered with a "conflict" frame
+// carrying the current state. Hub broadcasts are best-effort, so a client
+// that misses a patch recovers on its next write.
+func (h *Host) syncHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if h == nil || h.manager == nil || h.manager.Hub() == nil {
+			http.Error(w, "canvas sync unavailable", http.StatusServiceUnavailable)
+			return
+		}
+		sessionID := strings.TrimSpace(r.URL.Query().Get("session"))
+		if sessionID == "" {
+			http.Error(w, "missing session", http.StatusBadRequest)
+			return
+		}
+		if !validSessionID(sessionID) {
+			http.NotFound(w, r)
+			return
+		}
+		access, user, err := h.authorizeSessionRequest(r, sessionID)
+		if err != nil {
+			h.writeTokenError(w, err)
+			return
+		}
+		role := h.sessionRole(access, user)
+		clientID := syncClientID(access, user)
+
+		conn, err := h.upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		if h.metrics != nil {
+			h.metrics.ViewerConnected()
+			defer h.metrics.ViewerDisconnected()
+		}
+		conn.SetReadLimit(syncReadLimit)
+		_ = conn.SetReadDeadline(time.Now().Add(syncPongWait))
+		conn.SetPongHandler(func(string) error {
+			return conn.SetReadDeadline(time.Now().Add(syncPongWait))
+		})
+
+		ctx := r.Context()
+		if user != nil {
+			ctx = auth.WithUser(ctx, user)
+		}
+
+		// Subscribe before loading the state so no write can slip between
+		// the snapshot and the first broadcast.
+		stream, cancel := h.manager.Hub().Subscribe(sessionID)
+		defer cancel()
+
+		out := make(chan SyncMessage, 16)
+		done := make(chan struct{})
+		writerDone := make(chan struct{})
+		go func() {
+			defer close(writerDone)
+			ticker := time.NewTicker(syncPingInterval)
+			defer ticker.Stop()
+			for {
+				var frame any
+				select {
+				case <-done:
+					return
+				case msg, ok := <-stream:
+					if !ok {
+						return
+					}
+					frame = msg
+				case msg := <-out:
+					frame = msg
+				case <-ticker.C:
+					if err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(syncWriteWait)); err != nil {
+						_ = conn.Close()
+						return
+					}
+					continue
+				}
+				_ = conn.SetWriteDeadline(time.Now().Add(syncWriteWait))
+				if err := conn.WriteJSON(frame); err != nil {
+					_ = conn.Close()
+					return
+				}
+			}
+		}()
+		defer func() {
+			close(done)
+			<-writerDone
+		}()
+
+		send := func(msg SyncMessage) {
+			msg.SessionID = sessionID
+			msg.Timestamp = time.Now()
+			select {
+			case out <- msg:
+			case <-writerDone:
+			}
+		}
+		sendState := func(msgType, id string, state *State) {
+			msg := SyncMessage{Type: msgType, ID: id}
+			if state != nil {
+				msg.Payload = state.StateJSON
+				msg.Version = state.Versions
+			}
+			send(msg)
+		}
+
+		state, err := h.manager.State(ctx, sessionID)
+		if err != nil {
+			h.logger.Warn("canvas sync state load failed", "session_id", sessionID, "error", err)
+			send(SyncMessage{Type: "error", Error: "state unavailable"})
+			return
+		}
+		initial := SyncMessage{Type: "state", ClientID: clientID, Role: role}
+		if state != nil {
+			initial.Payload = state.StateJSON
+			initial.Version = state.Versions
+		}
+		send(initial)
+
+		for {
+			_, data, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+			var req syncRequest
+			if err := json.Unmarshal(data, &req); err != nil {
+				send(SyncMessage{Type: "error", Error: "invalid message"})
+				continue
+			}
+			switch req.Type {
+			case "patch":
+				if !RoleAllowsPatch(role) {
+					send(SyncMessage{Type: "error", ID: req.ID, Error: "forbidden"})
+					continue
+				}
+				if h.actionLimiter != nil && !h.actionLimiter.Allow(sessionID+":"+clientID) {
+					send(SyncMessage{Type: "error", ID: req.ID, Error: "rate limit exceeded"})
+					continue
+				}
+				state, err := h.manager.ApplyPatch(ctx, sessionID, clientID, req.Base, req.Ops)
+				switch {
+				case errors.Is(err, ErrVersionConflict):
+					sendState("conflict", req.ID, state)
+				case err != nil:
+					send(SyncMessage{Type: "error", ID: req.ID, Error: h.syncError(sessionID, err)})
+				default:
+					send(SyncMessage{Type: "ack", ID: req.ID, Version: state.Versions})
+				}
+			case "reset":
+				if !RoleAllowsReset(role) {
+					send(SyncMessage{Type: "error", ID: req.ID, Error: "forbidden"})
+					continue
+				}
+				if len(req.State) == 0 || !json.Valid(req.State) {
+					send(SyncMessage{Type: "error", ID: req.ID, Error: "state must be valid JSON"})
+					continue
+				}
+				msg, err := h.manager.ResetAs(ctx, sessionID, clientID, req.State)
+				if err != nil {
+					send(SyncMessage{Type: "error", ID: req.ID, Error: h.syncError(sessionID, err)})
+					continue
+				}
+				send(SyncMessage{Type: "ack", ID: req.ID, Version: msg.Version})
+			case "sync":
+				state, err := h.manager.State(ctx, sessionID)
+				if err != nil {
+					send(SyncMessage{Type: "error", ID: req.ID, Error: h.syncError(sessionID, err)})
+					continue
+				}
+				sendState("state", req.ID, state)
+			default:
+				send(SyncMessage{Type: "error", ID: req.ID, Error: fmt.Sprintf("unknown message type %q", req.Type)})
+			}
+		}
+	})
+}
+
+// syncError maps a write failure to a client-facing message. Patch and
+// size errors are the client's to fix; anything else is logged.
+func (h *Host) syncError(sessionID string, err error) string {
+	if errors.Is(err, ErrPatchFailed) || errors.Is(err, ErrStateTooLarge) {
+		return err.Error()
+	}
+	h.logger.Warn("canvas sync write failed", "session_id", sessionID, "error", err)
+	return "write failed"
+}
+
+// syncClientID identifies a sync connection in version vectors. Writes are
+// attributed to the user when known so reconnects keep their history.
+func syncClientID(access *AccessToken, user *models.User) string {
+	if user != nil && strings.TrimSpace(user.ID) != "" {
+		return "user:" + strings.TrimSpace(user.ID)
+	}
+	if access != nil && access.UserID != "" {
+		return "user:" + access.UserID
+	}
+	return "conn:" + uuid.NewString()
+}
+
 func (h *Host) a2uiHandler() http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if r.Method != http.MethodGet && r.Method != http.MethodHead {
@@ -1859,24 +2088,44 @@ func (h *Host) authorizeSessionRequest(r *http.Request, sessionID string) (*Acce
 
 	var user *models.User
 	if h.authService != nil && h.authService.Enabled() {
-		user = h.authenticateRequest(r)
+		var role string
+		user, role = h.authenticateRequest(r)
 		if user == nil && access == nil {
 			return access, nil, ErrUnauthorized
 		}
+		if user != nil && access == nil && role != "" {
+			access = &AccessToken{SessionID: sessionID, UserID: user.ID, Role: role}
+		}
 	}
 
 	return access, user, nil
 }
 
-func (h *Host) authenticateRequest(r *http.Request) *models.User {
+// sessionRole resolves the canvas role for an authorized request. Signed
+// tokens carry their own role; other authenticated users get the configured
+// default role.
+func (h *Host) sessionRole(access *AccessToken, user *models.User) string {
+	if access != nil {
+		return NormalizeRole(access.Role)
+	}
+	if user != nil {
+		return NormalizeRole(h.actionDefaultRole)
+	}
+	return RoleEditor
+}
+
+// authenticateRequest returns the authenticated user and the canvas role
+// their scopes allow: RoleViewer for callers that cannot change sessions
+// (such as read-only SSO users), "" for the configured default.
+func (h *Host) authenticateRequest(r *http.Request) (*models.User, string) {
 	if h == nil || h.authService == nil || !h.authService.Enabled() || r == nil {
-		return nil
+		return nil, ""
 	}
 	authHeader := strings.TrimSpace(r.Header.Get("Authorization"))
 	if strings.HasPrefix(strings.ToLower(authHeader), "bearer ") {
 		token := strings.TrimSpace(authHeader[len("bearer "):])
-		if user, err := h.authService.ValidateJWT(token); err == nil {
-			return user
+		if user, scopes, err := h.authService.AuthenticateJWT(token); err == nil {
+			return user, scopedRole(scopes)
 		}
 	}
 
@@ -1885,18 +2134,26 @@ func (h *Host) authenticateRequest(r *http.Request) *models.User {
 		apiKey = strings.TrimSpace(r.Header.Get("Api-Key"))
 	}
 	if apiKey != "" {
-		if user, err := h.authService.ValidateAPIKey(apiKey); err == nil {
-			return user
+		if user, scopes, err := h.authService.AuthenticateAPIKey(apiKey); err == nil {
+			return user, scopedRole(scopes)
 		}
 	}
 
 	if cookie, err := r.Cookie("nexus_session"); err == nil && strings.TrimSpace(cookie.Value) != "" {
-		if user, err := h.authService.ValidateJWT(strings.TrimSpace(cookie.Value)); err == nil {
-			return user
+		if user, scopes, err := h.authService.AuthenticateJWT(strings.TrimSpace(cookie.Value)); err == nil {
+			return user, scopedRole(scopes)
 		}
 	}
 
-	return nil
+	return nil, ""
+}
+
+// scopedRole caps callers without sessions:write at RoleViewer.
+func scopedRole(scopes []string) string {
+	if auth.ScopesAllow(scopes, "sessions:write") {
+		return ""
+	}
+	return RoleViewer
 }
 
 func (h *Host) signSessionToken(sessionID string, role string, userID string) (string, error) {
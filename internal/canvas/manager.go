@@ -5,10 +5,12 @@ import (
 	"encoding/json"
 	"errors"
 	"log/slog"
+	"sync"
 	"time"
 
 	"github.com/haasonsaas/nexus/internal/audit"
 	"github.com/haasonsaas/nexus/internal/auth"
+	"github.com/haasonsaas/nexus/internal/config"
 )
 
 // Manager coordinates persistence and realtime broadcasts.
@@ -18,6 +20,13 @@ type Manager struct {
 	logger      *slog.Logger
 	auditLogger *audit.Logger
 	metrics     *Metrics
+	retention   config.CanvasRetentionConfig
+
+	// sessionLocks serializes state writes per session.
+	sessionLocks sync.Map
+	// lastPrune throttles event retention per session.
+	pruneMu   sync.Mutex
+	lastPrune map[string]time.Time
 }
 
 // NewManager creates a canvas manager.
@@ -29,9 +38,10 @@ func NewManager(store Store, logger *slog.Logger) *Manager {
 		logger = slog.Default()
 	}
 	return &Manager{
-		store:  store,
-		hub:    NewHub(),
-		logger: logger.With("component", "canvas"),
+		store:     store,
+		hub:       NewHub(),
+		logger:    logger.With("component", "canvas"),
+		lastPrune: make(map[string]time.Time),
 	}
 }
 
@@ -65,6 +75,14 @@ func (m *Manager) SetMetrics(metrics *Metrics) {
 	m.metrics = metrics
 }
 
+// SetRetention configures state size limits and event history retention.
+func (m *Manager) SetRetention(retention config.CanvasRetentionConfig) {
+	if m == nil {
+		return
+	}
+	m.retention = retention
+}
+
 // Push appends an event and broadcasts it to subscribers.
 func (m *Manager) Push(ctx context.Context, sessionID string, payload json.RawMessage) (*StreamMessage, error) {
 	if m == nil || m.store == nil {
@@ -82,6 +100,7 @@ func (m *Manager) Push(ctx context.Context, sessionID string, payload json.RawMe
 	if m.metrics != nil {
 		m.metrics.RecordUpdate()
 	}
+	m.maybePruneEvents(ctx, sessionID)
 
 	msg := StreamMessage{
 		Type:      "event",
@@ -95,29 +114,7 @@ func (m *Manager) Push(ctx context.Context, sessionID string, payload json.RawMe
 
 // Reset replaces the stored state and broadcasts a reset message.
 func (m *Manager) Reset(ctx context.Context, sessionID string, state json.RawMessage) (*StreamMessage, error) {
-	if m == nil || m.store == nil {
-		return nil, errors.New("canvas manager unavailable")
-	}
-	if err := m.store.UpsertState(ctx, &State{
-		SessionID: sessionID,
-		StateJSON: state,
-		UpdatedAt: time.Now(),
-	}); err != nil {
-		return nil, err
-	}
-	m.recordStateChange(ctx, sessionID, audit.EventCanvasReset, "canvas.reset", state)
-	if m.metrics != nil {
-		m.metrics.RecordUpdate()
-	}
-
-	msg := StreamMessage{
-		Type:      "reset",
-		SessionID: sessionID,
-		Payload:   state,
-		Timestamp: time.Now(),
-	}
-	m.hub.Broadcast(msg)
-	return &msg, nil
+	return m.ResetAs(ctx, sessionID, AgentClientID, state)
 }
 
 // Snapshot returns the current snapshot state and event log.
@@ -125,14 +122,9 @@ func (m *Manager) Snapshot(ctx context.Context, sessionID string) (*State, []*Ev
 	if m == nil || m.store == nil {
 		return nil, nil, errors.New("canvas manager unavailable")
 	}
-	var state *State
-	stored, err := m.store.GetState(ctx, sessionID)
+	state, err := m.loadState(ctx, sessionID)
 	if err != nil {
-		if !errors.Is(err, ErrNotFound) {
-			return nil, nil, err
-		}
-	} else {
-		state = stored
+		return nil, nil, err
 	}
 	events, err := m.store.ListEvents(ctx, sessionID, EventListOptions{})
 	if err != nil {
@@ -218,6 +218,27 @@ func (s *MemoryStore) DeleteEvents(_ context.Context, sessionID string) error {
 	return nil
 }
 
+// DeleteEventsBefore removes events created before the cutoff.
+func (s *MemoryStore) DeleteEventsBefore(_ context.Context, sessionID string, before time.Time) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	events := s.eventsBySession[sessionID]
+	kept := events[:0]
+	for _, event := range events {
+		if event.CreatedAt.Before(before) {
+			continue
+		}
+		kept = append(kept, event)
+	}
+	removed := int64(len(events) - len(kept))
+	if len(kept) == 0 {
+		delete(s.eventsBySession, sessionID)
+	} else {
+		s.eventsBySession[sessionID] = kept
+	}
+	return removed, nil
+}
+
 func cloneSession(session *Session) *Session {
 	if session == nil {
 		return nil
@@ -234,6 +255,9 @@ func cloneState(state *State) *State {
 	if state.StateJSON != nil {
 		clone.StateJSON = append([]byte(nil), state.StateJSON...)
 	}
+	if state.Versions != nil {
+		clone.Versions = state.Versions.Clone()
+	}
 	return &clone
 }
 
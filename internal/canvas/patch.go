@@ -0,0 +1,268 @@
+package canvas
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// ErrPatchFailed is returned when a JSON patch cannot be applied.
+var ErrPatchFailed = errors.New("canvas: patch failed")
+
+// PatchOp is a single RFC 6902 JSON Patch operation.
+type PatchOp struct {
+	Op    string          `json:"op"`
+	Path  string          `json:"path"`
+	From  string          `json:"from,omitempty"`
+	Value json.RawMessage `json:"value,omitempty"`
+}
+
+// ApplyPatch applies ops to a JSON document atomically: either every
+// operation succeeds or the original document is left untouched. An empty
+// document is treated as an empty object.
+func ApplyPatch(doc json.RawMessage, ops []PatchOp) (json.RawMessage, error) {
+	var root any = map[string]any{}
+	if len(strings.TrimSpace(string(doc))) > 0 {
+		if err := json.Unmarshal(doc, &root); err != nil {
+			return nil, fmt.Errorf("%w: decode state: %v", ErrPatchFailed, err)
+		}
+	}
+	for i, op := range ops {
+		next, err := applyOp(root, op)
+		if err != nil {
+			return nil, fmt.Errorf("%w: op %d (%s %s): %v", ErrPatchFailed, i, op.Op, op.Path, err)
+		}
+		root = next
+	}
+	out, err := json.Marshal(root)
+	if err != nil {
+		return nil, fmt.Errorf("%w: encode state: %v", ErrPatchFailed, err)
+	}
+	return out, nil
+}
+
+func applyOp(root any, op PatchOp) (any, error) {
+	path, err := parsePointer(op.Path)
+	if err != nil {
+		return nil, err
+	}
+	switch op.Op {
+	case "add", "replace", "test":
+		if len(op.Value) == 0 {
+			return nil, errors.New("value is required")
+		}
+		var value any
+		if err := json.Unmarshal(op.Value, &value); err != nil {
+			return nil, fmt.Errorf("decode value: %w", err)
+		}
+		switch op.Op {
+		case "add":
+			return addValue(root, path, value)
+		case "replace":
+			if len(path) == 0 {
+				return value, nil
+			}
+			next, _, err := removeValue(root, path)
+			if err != nil {
+				return nil, err
+			}
+			return addValue(next, path, value)
+		default:
+			current, err := getValue(root, path)
+			if err != nil {
+				return nil, err
+			}
+			if !reflect.DeepEqual(current, value) {
+				return nil, errors.New("test failed")
+			}
+			return root, nil
+		}
+	case "remove":
+		next, _, err := removeValue(root, path)
+		return next, err
+	case "move", "copy":
+		from, err := parsePointer(op.From)
+		if err != nil {
+			return nil, fmt.Errorf("from: %w", err)
+		}
+		value, err := getValue(root, from)
+		if err != nil {
+			return nil, fmt.Errorf("from: %w", err)
+		}
+		if op.Op == "copy" {
+			return addValue(root, path, deepCopy(value))
+		}
+		if len(path) > len(from) && strings.HasPrefix(op.Path, op.From+"/") {
+			return nil, errors.New("cannot move a value into itself")
+		}
+		next, _, err := removeValue(root, from)
+		if err != nil {
+			return nil, err
+		}
+		return addValue(next, path, value)
+	default:
+		return nil, fmt.Errorf("unsupported op %q", op.Op)
+	}
+}
+
+// parsePointer splits an RFC 6901 JSON pointer into unescaped tokens.
+func parsePointer(pointer string) ([]string, error) {
+	if pointer == "" {
+		return nil, nil
+	}
+	if !strings.HasPrefix(pointer, "/") {
+		return nil, fmt.Errorf("invalid pointer %q", pointer)
+	}
+	tokens := strings.Split(pointer[1:], "/")
+	for i, token := range tokens {
+		tokens[i] = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+	}
+	return tokens, nil
+}
+
+func getValue(node any, path []string) (any, error) {
+	for _, token := range path {
+		switch container := node.(type) {
+		case map[string]any:
+			child, ok := container[token]
+			if !ok {
+				return nil, fmt.Errorf("path %q not found", token)
+			}
+			node = child
+		case []any:
+			idx, err := arrayIndex(token, len(container), false)
+			if err != nil {
+				return nil, err
+			}
+			node = container[idx]
+		default:
+			return nil, fmt.Errorf("cannot traverse into %T", node)
+		}
+	}
+	return node, nil
+}
+
+// updateParent walks to the parent of path, applies fn to it, and writes the
+// result back up the tree (slices may be reallocated).
+func updateParent(node any, path []string, fn func(parent any, key string) (any, error)) (any, error) {
+	if len(path) == 1 {
+		return fn(node, path[0])
+	}
+	switch container := node.(type) {
+	case map[string]any:
+		child, ok := container[path[0]]
+		if !ok {
+			return nil, fmt.Errorf("path %q not found", path[0])
+		}
+		updated, err := updateParent(child, path[1:], fn)
+		if err != nil {
+			return nil, err
+		}
+		container[path[0]] = updated
+		return container, nil
+	case []any:
+		idx, err := arrayIndex(path[0], len(container), false)
+		if err != nil {
+			return nil, err
+		}
+		updated, err := updateParent(container[idx], path[1:], fn)
+		if err != nil {
+			return nil, err
+		}
+		container[idx] = updated
+		return container, nil
+	default:
+		return nil, fmt.Errorf("cannot traverse into %T", node)
+	}
+}
+
+func addValue(root any, path []string, value any) (any, error) {
+	if len(path) == 0 {
+		return value, nil
+	}
+	return updateParent(root, path, func(parent any, key string) (any, error) {
+		switch container := parent.(type) {
+		case map[string]any:
+			container[key] = value
+			return container, nil
+		case []any:
+			idx, err := arrayIndex(key, len(container), true)
+			if err != nil {
+				return nil, err
+			}
+			container = append(container, nil)
+			copy(container[idx+1:], container[idx:])
+			container[idx] = value
+			return container, nil
+		default:
+			return nil, fmt.Errorf("cannot add to %T", parent)
+		}
+	})
+}
+
+func removeValue(root any, path []string) (any, any, error) {
+	if len(path) == 0 {
+		return nil, nil, errors.New("cannot remove the document root")
+	}
+	var removed any
+	next, err := updateParent(root, path, func(parent any, key string) (any, error) {
+		switch container := parent.(type) {
+		case map[string]any:
+			value, ok := container[key]
+			if !ok {
+				return nil, fmt.Errorf("path %q not found", key)
+			}
+			removed = value
+			delete(container, key)
+			return container, nil
+		case []any:
+			idx, err := arrayIndex(key, len(container), false)
+			if err != nil {
+				return nil, err
+			}
+			removed = container[idx]
+			return append(container[:idx], container[idx+1:]...), nil
+		default:
+			return nil, fmt.Errorf("cannot remove from %T", parent)
+		}
+	})
+	return next, removed, err
+}
+
+// arrayIndex parses an array token. "-" (append) and len are only valid
+// when inserting.
+func arrayIndex(token string, length int, insert bool) (int, error) {
+	if token == "-" && insert {
+		return length, nil
+	}
+	idx, err := strconv.Atoi(token)
+	if err != nil || idx < 0 || (token != "0" && strings.HasPrefix(token, "0")) {
+		return 0, fmt.Errorf("invalid array index %q", token)
+	}
+	if idx > length || (!insert && idx == length) {
+		return 0, fmt.Errorf("array index %d out of range", idx)
+	}
+	return idx, nil
+}
+
+func deepCopy(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		out := make(map[string]any, len(v))
+		for key, child := range v {
+			out[key] = deepCopy(child)
+		}
+		return out
+	case []any:
+		out := make([]any, len(v))
+		for i, child := range v {
+			out[i] = deepCopy(child)
+		}
+		return out
+	default:
+		return v
+	}
+}
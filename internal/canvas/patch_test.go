@@ -0,0 +1,104 @@
+package canvas
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestApplyPatch(t *testing.T) {
+	doc := json.RawMessage(`{"title":"Board","items":["a","b"],"meta":{"count":2}}`)
+	tests := []struct {
+		name string
+		ops  string
+		want string
+	}{
+		{"add member", `[{"op":"add","path":"/owner","value":"ops"}]`, `{"items":["a","b"],"meta":{"count":2},"owner":"ops","title":"Board"}`},
+		{"insert into array", `[{"op":"add","path":"/items/1","value":"x"}]`, `{"items":["a","x","b"],"meta":{"count":2},"title":"Board"}`},
+		{"append to array", `[{"op":"add","path":"/items/-","value":"c"}]`, `{"items":["a","b","c"],"meta":{"count":2},"title":"Board"}`},
+		{"remove", `[{"op":"remove","path":"/items/0"}]`, `{"items":["b"],"meta":{"count":2},"title":"Board"}`},
+		{"replace", `[{"op":"replace","path":"/meta/count","value":3}]`, `{"items":["a","b"],"meta":{"count":3},"title":"Board"}`},
+		{"move", `[{"op":"move","from":"/title","path":"/meta/title"}]`, `{"items":["a","b"],"meta":{"count":2,"title":"Board"}}`},
+		{"copy", `[{"op":"copy","from":"/items","path":"/backup"}]`, `{"backup":["a","b"],"items":["a","b"],"meta":{"count":2},"title":"Board"}`},
+		{"test then replace", `[{"op":"test","path":"/title","value":"Board"},{"op":"replace","path":"/title","value":"Roadmap"}]`, `{"items":["a","b"],"meta":{"count":2},"title":"Roadmap"}`},
+		{"escaped pointer", `[{"op":"add","path":"/a~1b~0c","value":1}]`, `{"a/b~c":1,"items":["a","b"],"meta":{"count":2},"title":"Board"}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var ops []PatchOp
+			if err := json.Unmarshal([]byte(tt.ops), &ops); err != nil {
+				t.Fatalf("decode ops: %v", err)
+			}
+			got, err := ApplyPatch(doc, ops)
+			if err != nil {
+				t.Fatalf("ApplyPatch: %v", err)
+			}
+			if string(got) != tt.want {
+				t.Fatalf("got %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestApplyPatch_EmptyDocument(t *testing.T) {
+	got, err := ApplyPatch(nil, []PatchOp{{Op: "add", Path: "/x", Value: json.RawMessage(`true`)}})
+	if err != nil {
+		t.Fatalf("ApplyPatch: %v", err)
+	}
+	if string(got) != `{"x":true}` {
+		t.Fatalf("got %s", got)
+	}
+}
+
+func TestApplyPatch_Errors(t *testing.T) {
+	doc := json.RawMessage(`{"items":[1,2],"title":"Board"}`)
+	tests := map[string]PatchOp{
+		"failed test":        {Op: "test", Path: "/title", Value: json.RawMessage(`"Other"`)},
+		"missing path":       {Op: "remove", Path: "/missing"},
+		"index out of range": {Op: "replace", Path: "/items/5", Value: json.RawMessage(`0`)},
+		"leading zero index": {Op: "remove", Path: "/items/01"},
+		"bad pointer":        {Op: "add", Path: "title", Value: json.RawMessage(`1`)},
+		"missing value":      {Op: "add", Path: "/x"},
+		"unknown op":         {Op: "merge", Path: "/x"},
+		"move into child":    {Op: "move", From: "/items", Path: "/items/0"},
+		"remove root":        {Op: "remove", Path: ""},
+	}
+	for name, op := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := ApplyPatch(doc, []PatchOp{op}); !errors.Is(err, ErrPatchFailed) {
+				t.Fatalf("expected ErrPatchFailed, got %v", err)
+			}
+		})
+	}
+}
+
+func TestApplyPatch_Atomic(t *testing.T) {
+	doc := json.RawMessage(`{"title":"Board"}`)
+	ops := []PatchOp{
+		{Op: "replace", Path: "/title", Value: json.RawMessage(`"Changed"`)},
+		{Op: "remove", Path: "/missing"},
+	}
+	if _, err := ApplyPatch(doc, ops); err == nil {
+		t.Fatalf("expected error")
+	}
+	if string(doc) != `{"title":"Board"}` {
+		t.Fatalf("original document modified: %s", doc)
+	}
+}
+
+func TestVersionVector(t *testing.T) {
+	base := VersionVector{"a": 1}
+	next := base.Increment("b")
+	if base["b"] != 0 || next["b"] != 1 || next["a"] != 1 {
+		t.Fatalf("Increment must copy: base=%v next=%v", base, next)
+	}
+	if !next.Covers(base) {
+		t.Fatalf("expected %v to cover %v", next, base)
+	}
+	if base.Covers(next) {
+		t.Fatalf("expected %v not to cover %v", base, next)
+	}
+	if !VersionVector(nil).Covers(nil) {
+		t.Fatalf("empty vectors should cover each other")
+	}
+}
@@ -25,3 +25,13 @@ func RoleAllowsAction(role string) bool {
 	normalized := NormalizeRole(role)
 	return normalized == RoleEditor || normalized == RoleAdmin
 }
+
+// RoleAllowsPatch reports whether the role may apply state patches.
+func RoleAllowsPatch(role string) bool {
+	return RoleAllowsAction(role)
+}
+
+// RoleAllowsReset reports whether the role may replace the whole state.
+func RoleAllowsReset(role string) bool {
+	return NormalizeRole(role) == RoleAdmin
+}
@@ -28,6 +28,7 @@ type Session struct {
 type State struct {
 	SessionID string
 	StateJSON json.RawMessage
+	Versions  VersionVector
 	UpdatedAt time.Time
 }
 
@@ -62,3 +63,11 @@ type Store interface {
 	ListEvents(ctx context.Context, sessionID string, opts EventListOptions) ([]*Event, error)
 	DeleteEvents(ctx context.Context, sessionID string) error
 }
+
+// EventPruner is implemented by stores that can trim a session's event
+// history for retention.
+type EventPruner interface {
+	// DeleteEventsBefore removes events created before the cutoff and
+	// returns how many were removed.
+	DeleteEventsBefore(ctx context.Context, sessionID string, before time.Time) (int64, error)
+}
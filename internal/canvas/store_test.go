@@ -4,8 +4,11 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"path/filepath"
 	"testing"
 	"time"
+
+	"github.com/haasonsaas/nexus/internal/sessions"
 )
 
 func TestMemoryStoreSessionLifecycle(t *testing.T) {
@@ -148,3 +151,62 @@ func TestMemoryStoreStateAndEvents(t *testing.T) {
 		t.Fatalf("expected ErrNotFound after state delete, got %v", err)
 	}
 }
+
+func TestSQLiteStore(t *testing.T) {
+	ctx := context.Background()
+	db, err := sessions.OpenSQLite(ctx, "sqlite://"+filepath.Join(t.TempDir(), "nexus.db"))
+	if err != nil {
+		t.Fatalf("OpenSQLite() error = %v", err)
+	}
+	store, err := NewSQLiteStore(db)
+	if err != nil {
+		t.Fatalf("NewSQLiteStore() error = %v", err)
+	}
+	t.Cleanup(func() { _ = store.Close() })
+
+	session := &Session{Key: "slack:workspace:channel", WorkspaceID: "workspace", ChannelID: "channel"}
+	if err := store.CreateSession(ctx, session); err != nil {
+		t.Fatalf("CreateSession() error = %v", err)
+	}
+	duplicate := &Session{Key: session.Key, WorkspaceID: "workspace", ChannelID: "channel"}
+	if err := store.CreateSession(ctx, duplicate); !errors.Is(err, ErrAlreadyExists) {
+		t.Fatalf("expected ErrAlreadyExists for a duplicate key, got %v", err)
+	}
+
+	state := &State{SessionID: session.ID, StateJSON: json.RawMessage(`{"view":"ok"}`), Versions: VersionVector{"node": 2}}
+	if err := store.UpsertState(ctx, state); err != nil {
+		t.Fatalf("UpsertState() error = %v", err)
+	}
+	stored, err := store.GetState(ctx, session.ID)
+	if err != nil {
+		t.Fatalf("GetState() error = %v", err)
+	}
+	if string(stored.StateJSON) != `{"view":"ok"}` || stored.Versions["node"] != 2 {
+		t.Fatalf("unexpected state %+v", stored)
+	}
+	if err := store.UpsertState(ctx, &State{SessionID: "missing", StateJSON: json.RawMessage(`{}`)}); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected ErrNotFound for an unknown session, got %v", err)
+	}
+
+	now := time.Now()
+	for i := range 2 {
+		event := &Event{SessionID: session.ID, Type: "state", Payload: json.RawMessage(`{}`), CreatedAt: now.Add(time.Duration(i-1) * time.Minute)}
+		if err := store.AppendEvent(ctx, event); err != nil {
+			t.Fatalf("AppendEvent() error = %v", err)
+		}
+	}
+	events, err := store.ListEvents(ctx, session.ID, EventListOptions{Since: now.Add(-30 * time.Second)})
+	if err != nil {
+		t.Fatalf("ListEvents() error = %v", err)
+	}
+	if len(events) != 1 || !events[0].CreatedAt.Equal(now) {
+		t.Fatalf("expected the latest event, got %+v", events)
+	}
+
+	if err := store.DeleteSession(ctx, session.ID); err != nil {
+		t.Fatalf("DeleteSession() error = %v", err)
+	}
+	if _, err := store.GetState(ctx, session.ID); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected state to be deleted with the session, got %v", err)
+	}
+}
@@ -11,6 +11,7 @@ type StreamMessage struct {
 	Type      string          `json:"type"`
 	SessionID string          `json:"session_id"`
 	Payload   json.RawMessage `json:"payload,omitempty"`
+	Version   VersionVector   `json:"version,omitempty"`
 	Timestamp time.Time       `json:"ts"`
 }
 
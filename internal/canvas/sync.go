@@ -0,0 +1,241 @@
+package canvas
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/haasonsaas/nexus/internal/audit"
+)
+
+// AgentClientID is the version vector entry for writes made by the agent
+// through the canvas tool.
+const AgentClientID = "agent"
+
+// pruneInterval limits how often event retention runs per session.
+const pruneInterval = time.Minute
+
+var (
+	// ErrVersionConflict is returned when a patch was based on a state that
+	// has since been modified by another client.
+	ErrVersionConflict = errors.New("canvas: version conflict")
+
+	// ErrStateTooLarge is returned when a write would exceed
+	// retention.state_max_bytes.
+	ErrStateTooLarge = errors.New("canvas: state exceeds size limit")
+)
+
+// PatchMessage is the payload of "patch" stream messages and events.
+type PatchMessage struct {
+	ClientID string    `json:"client_id"`
+	Ops      []PatchOp `json:"ops"`
+}
+
+// ApplyPatch applies a JSON patch on behalf of clientID. base is the
+// version vector the client last saw; when another client has written
+// since, the patch is rejected with ErrVersionConflict and the current
+// state is returned so the client can rebase.
+func (m *Manager) ApplyPatch(ctx context.Context, sessionID, clientID string, base VersionVector, ops []PatchOp) (*State, error) {
+	if m == nil || m.store == nil {
+		return nil, errors.New("canvas manager unavailable")
+	}
+	if len(ops) == 0 {
+		return nil, fmt.Errorf("%w: no operations", ErrPatchFailed)
+	}
+	unlock := m.lockSession(sessionID)
+	defer unlock()
+
+	current, err := m.loadState(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	if current == nil {
+		current = &State{SessionID: sessionID}
+	}
+	if !base.Covers(current.Versions) {
+		return current, ErrVersionConflict
+	}
+	next, err := ApplyPatch(current.StateJSON, ops)
+	if err != nil {
+		return nil, err
+	}
+	state, err := m.writeStateVersions(ctx, sessionID, current.Versions.Increment(clientID), next)
+	if err != nil {
+		return nil, err
+	}
+
+	payload, err := json.Marshal(PatchMessage{ClientID: clientID, Ops: ops})
+	if err != nil {
+		return nil, err
+	}
+	if err := m.store.AppendEvent(ctx, &Event{
+		SessionID: sessionID,
+		Type:      "patch",
+		Payload:   payload,
+		CreatedAt: state.UpdatedAt,
+	}); err != nil {
+		m.logger.Warn("canvas patch event append failed", "session_id", sessionID, "error", err)
+	}
+	m.recordStateChange(ctx, sessionID, audit.EventCanvasUpdate, "canvas.patch", payload)
+	m.maybePruneEvents(ctx, sessionID)
+
+	m.hub.Broadcast(StreamMessage{
+		Type:      "patch",
+		SessionID: sessionID,
+		Payload:   payload,
+		Version:   state.Versions,
+		Timestamp: state.UpdatedAt,
+	})
+	return state, nil
+}
+
+// ResetAs replaces the stored state on behalf of clientID and broadcasts a
+// reset message.
+func (m *Manager) ResetAs(ctx context.Context, sessionID, clientID string, state json.RawMessage) (*StreamMessage, error) {
+	if m == nil || m.store == nil {
+		return nil, errors.New("canvas manager unavailable")
+	}
+	unlock := m.lockSession(sessionID)
+	defer unlock()
+
+	current, err := m.loadState(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	var versions VersionVector
+	if current != nil {
+		versions = current.Versions
+	}
+	stored, err := m.writeStateVersions(ctx, sessionID, versions.Increment(clientID), state)
+	if err != nil {
+		return nil, err
+	}
+	m.recordStateChange(ctx, sessionID, audit.EventCanvasReset, "canvas.reset", state)
+
+	msg := StreamMessage{
+		Type:      "reset",
+		SessionID: sessionID,
+		Payload:   state,
+		Version:   stored.Versions,
+		Timestamp: stored.UpdatedAt,
+	}
+	m.hub.Broadcast(msg)
+	return &msg, nil
+}
+
+// State returns the current state for a session, or nil when none is
+// stored.
+func (m *Manager) State(ctx context.Context, sessionID string) (*State, error) {
+	if m == nil || m.store == nil {
+		return nil, errors.New("canvas manager unavailable")
+	}
+	return m.loadState(ctx, sessionID)
+}
+
+// writeStateVersions stores a new state. The caller must hold the session
+// lock.
+func (m *Manager) writeStateVersions(ctx context.Context, sessionID string, versions VersionVector, stateJSON json.RawMessage) (*State, error) {
+	if limit := m.retention.StateMaxBytes; limit > 0 && int64(len(stateJSON)) > limit {
+		return nil, fmt.Errorf("%w: %d bytes (max %d)", ErrStateTooLarge, len(stateJSON), limit)
+	}
+	state := &State{
+		SessionID: sessionID,
+		StateJSON: stateJSON,
+		Versions:  versions,
+		UpdatedAt: time.Now(),
+	}
+	if err := m.store.UpsertState(ctx, state); err != nil {
+		return nil, err
+	}
+	if m.metrics != nil {
+		m.metrics.RecordUpdate()
+	}
+	return state, nil
+}
+
+// loadState returns the stored state, or nil when there is none or it is
+// older than retention.state_max_age.
+func (m *Manager) loadState(ctx context.Context, sessionID string) (*State, error) {
+	state, err := m.store.GetState(ctx, sessionID)
+	if errors.Is(err, ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if maxAge := m.retention.StateMaxAge; maxAge > 0 && time.Since(state.UpdatedAt) > maxAge {
+		if err := m.store.DeleteState(ctx, sessionID); err != nil && !errors.Is(err, ErrNotFound) {
+			m.logger.Warn("canvas state expiry failed", "session_id", sessionID, "error", err)
+		}
+		return nil, nil
+	}
+	return state, nil
+}
+
+func (m *Manager) lockSession(sessionID string) func() {
+	value, _ := m.sessionLocks.LoadOrStore(sessionID, &sync.Mutex{})
+	mu := value.(*sync.Mutex)
+	mu.Lock()
+	return mu.Unlock
+}
+
+// PruneEvents applies event retention to a session: events older than
+// retention.event_max_age are removed, then the oldest events are dropped
+// until the remaining payloads fit in retention.event_max_bytes.
+func (m *Manager) PruneEvents(ctx context.Context, sessionID string) (int64, error) {
+	if m == nil || m.store == nil {
+		return 0, errors.New("canvas manager unavailable")
+	}
+	pruner, ok := m.store.(EventPruner)
+	if !ok {
+		return 0, nil
+	}
+	var cutoff time.Time
+	if m.retention.EventMaxAge > 0 {
+		cutoff = time.Now().Add(-m.retention.EventMaxAge)
+	}
+	if limit := m.retention.EventMaxBytes; limit > 0 {
+		events, err := m.store.ListEvents(ctx, sessionID, EventListOptions{Since: cutoff})
+		if err != nil {
+			return 0, err
+		}
+		var total int64
+		for _, event := range events {
+			total += int64(len(event.Payload))
+		}
+		for i := 0; total > limit && i < len(events); i++ {
+			total -= int64(len(events[i].Payload))
+			if i+1 < len(events) {
+				cutoff = events[i+1].CreatedAt
+			} else {
+				cutoff = events[i].CreatedAt.Add(time.Nanosecond)
+			}
+		}
+	}
+	if cutoff.IsZero() {
+		return 0, nil
+	}
+	return pruner.DeleteEventsBefore(ctx, sessionID, cutoff)
+}
+
+func (m *Manager) maybePruneEvents(ctx context.Context, sessionID string) {
+	if m.retention.EventMaxAge <= 0 && m.retention.EventMaxBytes <= 0 {
+		return
+	}
+	m.pruneMu.Lock()
+	if last, ok := m.lastPrune[sessionID]; ok && time.Since(last) < pruneInterval {
+		m.pruneMu.Unlock()
+		return
+	}
+	m.lastPrune[sessionID] = time.Now()
+	m.pruneMu.Unlock()
+
+	if removed, err := m.PruneEvents(ctx, sessionID); err != nil {
+		m.logger.Warn("canvas event retention failed", "session_id", sessionID, "error", err)
+	} else if removed > 0 {
+		m.logger.Debug("pruned canvas events", "session_id", sessionID, "count", removed)
+	}
+}
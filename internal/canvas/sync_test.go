@@ -0,0 +1,268 @@
+package canvas
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"github.com/haasonsaas/nexus/internal/config"
+)
+
+func newSyncTestManager(t *testing.T) (*Manager, *MemoryStore, string) {
+	t.Helper()
+	store := NewMemoryStore()
+	session := &Session{Key: "slack:workspace:channel", WorkspaceID: "workspace", ChannelID: "channel"}
+	if err := store.CreateSession(context.Background(), session); err != nil {
+		t.Fatalf("CreateSession: %v", err)
+	}
+	return NewManager(store, nil), store, session.ID
+}
+
+func TestManagerApplyPatch_Versions(t *testing.T) {
+	ctx := context.Background()
+	manager, _, sessionID := newSyncTestManager(t)
+	if _, err := manager.Reset(ctx, sessionID, json.RawMessage(`{"count":0}`)); err != nil {
+		t.Fatalf("Reset: %v", err)
+	}
+	base, err := manager.State(ctx, sessionID)
+	if err != nil {
+		t.Fatalf("State: %v", err)
+	}
+	if base.Versions[AgentClientID] != 1 {
+		t.Fatalf("expected agent version 1, got %v", base.Versions)
+	}
+
+	stream, cancel := manager.Hub().Subscribe(sessionID)
+	defer cancel()
+
+	replace := []PatchOp{{Op: "replace", Path: "/count", Value: json.RawMessage(`1`)}}
+	state, err := manager.ApplyPatch(ctx, sessionID, "alice", base.Versions, replace)
+	if err != nil {
+		t.Fatalf("ApplyPatch: %v", err)
+	}
+	if string(state.StateJSON) != `{"count":1}` || state.Versions["alice"] != 1 {
+		t.Fatalf("unexpected state %s %v", state.StateJSON, state.Versions)
+	}
+	select {
+	case msg := <-stream:
+		if msg.Type != "patch" || msg.Version["alice"] != 1 {
+			t.Fatalf("unexpected broadcast %+v", msg)
+		}
+	case <-time.After(500 * time.Millisecond):
+		t.Fatalf("expected patch broadcast")
+	}
+
+	// bob still holds the pre-patch version and must rebase.
+	current, err := manager.ApplyPatch(ctx, sessionID, "bob", base.Versions, replace)
+	if !errors.Is(err, ErrVersionConflict) {
+		t.Fatalf("expected ErrVersionConflict, got %v", err)
+	}
+	if string(current.StateJSON) != `{"count":1}` || current.Versions["alice"] != 1 {
+		t.Fatalf("conflict should return current state, got %s %v", current.StateJSON, current.Versions)
+	}
+	if _, err := manager.ApplyPatch(ctx, sessionID, "bob", current.Versions, replace); err != nil {
+		t.Fatalf("ApplyPatch after rebase: %v", err)
+	}
+
+	_, events, err := manager.Snapshot(ctx, sessionID)
+	if err != nil {
+		t.Fatalf("Snapshot: %v", err)
+	}
+	if len(events) != 2 || events[0].Type != "patch" {
+		t.Fatalf("expected two patch events, got %d", len(events))
+	}
+}
+
+func TestManagerApplyPatch_StateMaxBytes(t *testing.T) {
+	ctx := context.Background()
+	manager, _, sessionID := newSyncTestManager(t)
+	manager.SetRetention(config.CanvasRetentionConfig{StateMaxBytes: 16})
+
+	ops := []PatchOp{{Op: "add", Path: "/text", Value: json.RawMessage(`"this is far too long"`)}}
+	if _, err := manager.ApplyPatch(ctx, sessionID, "alice", nil, ops); !errors.Is(err, ErrStateTooLarge) {
+		t.Fatalf("expected ErrStateTooLarge, got %v", err)
+	}
+	if state, err := manager.State(ctx, sessionID); err != nil || state != nil {
+		t.Fatalf("expected no stored state, got %v, %v", state, err)
+	}
+}
+
+func TestManagerPruneEvents(t *testing.T) {
+	ctx := context.Background()
+	manager, store, sessionID := newSyncTestManager(t)
+	manager.SetRetention(config.CanvasRetentionConfig{EventMaxAge: time.Hour, EventMaxBytes: 21})
+
+	now := time.Now()
+	for i, age := range []time.Duration{2 * time.Hour, 30 * time.Minute, 20 * time.Minute, 10 * time.Minute} {
+		if err := store.AppendEvent(ctx, &Event{
+			SessionID: sessionID,
+			Type:      "event",
+			Payload:   json.RawMessage(`{"n":"` + strings.Repeat("x", i) + `"}`),
+			CreatedAt: now.Add(-age),
+		}); err != nil {
+			t.Fatalf("AppendEvent: %v", err)
+		}
+	}
+
+	removed, err := manager.PruneEvents(ctx, sessionID)
+	if err != nil {
+		t.Fatalf("PruneEvents: %v", err)
+	}
+	if removed != 2 {
+		t.Fatalf("expected 2 events removed (one by age, one by size), got %d", removed)
+	}
+	events, err := store.ListEvents(ctx, sessionID, EventListOptions{})
+	if err != nil {
+		t.Fatalf("ListEvents: %v", err)
+	}
+	if len(events) != 2 || string(events[0].Payload) != `{"n":"xx"}` {
+		t.Fatalf("expected the two newest events to remain, got %d", len(events))
+	}
+}
+
+func TestManagerState_Expired(t *testing.T) {
+	ctx := context.Background()
+	manager, store, sessionID := newSyncTestManager(t)
+	manager.SetRetention(config.CanvasRetentionConfig{StateMaxAge: time.Hour})
+	if err := store.UpsertState(ctx, &State{
+		SessionID: sessionID,
+		StateJSON: json.RawMessage(`{}`),
+		UpdatedAt: time.Now().Add(-2 * time.Hour),
+	}); err != nil {
+		t.Fatalf("UpsertState: %v", err)
+	}
+	if state, err := manager.State(ctx, sessionID); err != nil || state != nil {
+		t.Fatalf("expected expired state to be dropped, got %v, %v", state, err)
+	}
+	if _, err := store.GetState(ctx, sessionID); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected expired state to be deleted, got %v", err)
+	}
+}
+
+func TestSyncHandler(t *testing.T) {
+	secret := "canvas-secret-should-be-long-enough-1234"
+	host, err := NewHost(config.CanvasHostConfig{
+		Port:      18793,
+		Root:      t.TempDir(),
+		Namespace: "/__nexus__",
+	}, config.CanvasConfig{
+		Tokens: config.CanvasTokenConfig{Secret: secret, TTL: time.Hour},
+	}, nil)
+	if err != nil {
+		t.Fatalf("NewHost error: %v", err)
+	}
+	manager, _, sessionID := newSyncTestManager(t)
+	host.SetManager(manager)
+	if _, err := manager.Reset(context.Background(), sessionID, json.RawMessage(`{"count":0}`)); err != nil {
+		t.Fatalf("Reset: %v", err)
+	}
+
+	server := httptest.NewServer(host.syncHandler())
+	defer server.Close()
+
+	dial := func(role, userID string) *websocket.Conn {
+		t.Helper()
+		token, err := SignAccessToken([]byte(secret), AccessToken{
+			SessionID: sessionID,
+			UserID:    userID,
+			Role:      role,
+			ExpiresAt: time.Now().Add(time.Hour).Unix(),
+		})
+		if err != nil {
+			t.Fatalf("SignAccessToken error: %v", err)
+		}
+		header := http.Header{}
+		header.Set("X-Canvas-Token", token)
+		url := "ws" + strings.TrimPrefix(server.URL, "http") + "?session=" + sessionID
+		conn, _, err := websocket.DefaultDialer.Dial(url, header)
+		if err != nil {
+			t.Fatalf("dial: %v", err)
+		}
+		t.Cleanup(func() { conn.Close() })
+		return conn
+	}
+	read := func(conn *websocket.Conn) map[string]any {
+		t.Helper()
+		_ = conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+		var msg map[string]any
+		if err := conn.ReadJSON(&msg); err != nil {
+			t.Fatalf("read: %v", err)
+		}
+		return msg
+	}
+	readType := func(conn *websocket.Conn, msgType string) map[string]any {
+		t.Helper()
+		for i := 0; i < 4; i++ {
+			if msg := read(conn); msg["type"] == msgType {
+				return msg
+			}
+		}
+		t.Fatalf("no %q message received", msgType)
+		return nil
+	}
+
+	viewer := dial(RoleViewer, "viewer")
+	hello := readType(viewer, "state")
+	if hello["role"] != RoleViewer || hello["client_id"] != "user:viewer" {
+		t.Fatalf("unexpected hello %v", hello)
+	}
+	version := hello["version"]
+
+	patch := map[string]any{
+		"type": "patch",
+		"id":   "1",
+		"base": version,
+		"ops":  []map[string]any{{"op": "replace", "path": "/count", "value": 1}},
+	}
+	if err := viewer.WriteJSON(patch); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	if msg := readType(viewer, "error"); msg["error"] != "forbidden" {
+		t.Fatalf("expected viewer patch to be forbidden, got %v", msg)
+	}
+
+	editor := dial(RoleEditor, "editor")
+	readType(editor, "state")
+	if err := editor.WriteJSON(patch); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	ack := readType(editor, "ack")
+	if ack["id"] != "1" {
+		t.Fatalf("unexpected ack %v", ack)
+	}
+	if msg := readType(viewer, "patch"); msg["version"].(map[string]any)["user:editor"] != float64(1) {
+		t.Fatalf("expected viewer to receive the patch, got %v", msg)
+	}
+
+	// Replaying the same base is now stale.
+	if err := editor.WriteJSON(patch); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	conflict := readType(editor, "conflict")
+	if string(mustJSON(t, conflict["payload"])) != `{"count":1}` {
+		t.Fatalf("expected conflict to carry current state, got %v", conflict)
+	}
+
+	if err := editor.WriteJSON(map[string]any{"type": "reset", "id": "2", "state": map[string]any{}}); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	if msg := readType(editor, "error"); msg["error"] != "forbidden" {
+		t.Fatalf("expected editor reset to be forbidden, got %v", msg)
+	}
+}
+
+func mustJSON(t *testing.T, value any) []byte {
+	t.Helper()
+	data, err := json.Marshal(value)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	return data
+}
@@ -0,0 +1,32 @@
+package canvas
+
+// VersionVector tracks how many writes each client has applied to a canvas
+// state. Clients send the vector they last saw with each patch; a patch is
+// only accepted when that vector already includes every applied write.
+type VersionVector map[string]uint64
+
+// Clone returns an independent copy of the vector.
+func (v VersionVector) Clone() VersionVector {
+	out := make(VersionVector, len(v))
+	for client, counter := range v {
+		out[client] = counter
+	}
+	return out
+}
+
+// Covers reports whether v has seen every write recorded in other.
+func (v VersionVector) Covers(other VersionVector) bool {
+	for client, counter := range other {
+		if v[client] < counter {
+			return false
+		}
+	}
+	return true
+}
+
+// Increment returns a copy of v with the client's counter advanced.
+func (v VersionVector) Increment(client string) VersionVector {
+	out := v.Clone()
+	out[client]++
+	return out
+}
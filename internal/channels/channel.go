@@ -30,6 +30,22 @@ type InboundAdapter interface {
 	Messages() <-chan *models.Message
 }
 
+// ReactionEvent is an emoji reaction added to or removed from a message.
+type ReactionEvent struct {
+	Channel   models.ChannelType `json:"channel"`
+	ChatID    string             `json:"chat_id,omitempty"`
+	MessageID string             `json:"message_id"`
+	UserID    string             `json:"user_id,omitempty"`
+	Emoji     string             `json:"emoji"`
+	Removed   bool               `json:"removed,omitempty"`
+	Timestamp time.Time          `json:"timestamp"`
+}
+
+// ReactionAdapter represents adapters that emit inbound reaction events.
+type ReactionAdapter interface {
+	Reactions() <-chan *ReactionEvent
+}
+
 // AttachmentDownloader allows adapters to fetch attachment bytes when URLs are not public.
 // Implementations should avoid leaking credentials in returned metadata.
 type AttachmentDownloader interface {
@@ -109,6 +125,7 @@ type Registry struct {
 	health    map[models.ChannelType]HealthAdapter
 	streaming map[models.ChannelType]StreamingAdapter
 	actions   map[models.ChannelType]MessageActionsAdapter
+	reactions map[models.ChannelType]ReactionAdapter
 }
 
 // NewRegistry creates a new channel registry.
@@ -121,6 +138,7 @@ func NewRegistry() *Registry {
 		health:    make(map[models.ChannelType]HealthAdapter),
 		streaming: make(map[models.ChannelType]StreamingAdapter),
 		actions:   make(map[models.ChannelType]MessageActionsAdapter),
+		reactions: make(map[models.ChannelType]ReactionAdapter),
 	}
 }
 
@@ -167,6 +185,12 @@ func (r *Registry) Register(adapter Adapter) {
 	} else {
 		delete(r.actions, channelType)
 	}
+
+	if reactions, ok := adapter.(ReactionAdapter); ok {
+		r.reactions[channelType] = reactions
+	} else {
+		delete(r.reactions, channelType)
+	}
 }
 
 // Get returns an adapter by channel type.
@@ -316,3 +340,47 @@ func (r *Registry) AggregateMessages(ctx context.Context) <-chan *models.Message
 
 	return out
 }
+
+// AggregateReactions returns a channel that receives reaction events from all
+// adapters that emit them. The returned channel is closed when the context is
+// cancelled or all adapters close.
+func (r *Registry) AggregateReactions(ctx context.Context) <-chan *ReactionEvent {
+	out := make(chan *ReactionEvent, 100)
+	var wg sync.WaitGroup
+
+	r.mu.RLock()
+	adapters := make([]ReactionAdapter, 0, len(r.reactions))
+	for _, adapter := range r.reactions {
+		adapters = append(adapters, adapter)
+	}
+	r.mu.RUnlock()
+
+	for _, adapter := range adapters {
+		wg.Add(1)
+		go func(a ReactionAdapter) {
+			defer wg.Done()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case event, ok := <-a.Reactions():
+					if !ok {
+						return
+					}
+					select {
+					case out <- event:
+					case <-ctx.Done():
+						return
+					}
+				}
+			}
+		}(adapter)
+	}
+
+	go func() {
+		wg.Wait()
+		close(out)
+	}()
+
+	return out
+}
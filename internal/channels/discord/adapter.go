@@ -10,6 +10,7 @@ import (
 
 	"github.com/bwmarrin/discordgo"
 	"github.com/haasonsaas/nexus/internal/channels"
+	"github.com/haasonsaas/nexus/internal/supervisor"
 	"github.com/haasonsaas/nexus/pkg/models"
 )
 
@@ -21,6 +22,7 @@ type discordSession interface {
 	ChannelMessageSendComplex(channelID string, data *discordgo.MessageSend, options ...discordgo.RequestOption) (*discordgo.Message, error)
 	ChannelMessageEdit(channelID, messageID, content string, options ...discordgo.RequestOption) (*discordgo.Message, error)
 	ChannelMessageDelete(channelID, messageID string, options ...discordgo.RequestOption) error
+	ChannelMessage(channelID, messageID string, options ...discordgo.RequestOption) (*discordgo.Message, error)
 	ChannelTyping(channelID string, options ...discordgo.RequestOption) error
 	MessageReactionAdd(channelID, messageID, emoji string, options ...discordgo.RequestOption) error
 	MessageReactionRemove(channelID, messageID, emoji, userID string, options ...discordgo.RequestOption) error
@@ -90,6 +92,7 @@ type Adapter struct {
 	token          string
 	session        discordSession
 	messages       chan *models.Message
+	reactions      chan *channels.ReactionEvent
 	mu             sync.RWMutex
 	ctx            context.Context
 	cancel         context.CancelFunc
@@ -111,6 +114,7 @@ func NewAdapter(config Config) (*Adapter, error) {
 		config:      config,
 		token:       config.Token,
 		messages:    make(chan *models.Message, 100),
+		reactions:   make(chan *channels.ReactionEvent, 100),
 		rateLimiter: channels.NewRateLimiter(config.RateLimit, config.RateBurst),
 		logger:      config.Logger.With("adapter", "discord"),
 	}
@@ -165,6 +169,8 @@ func (a *Adapter) Start(ctx context.Context) error {
 	// Set up event handlers
 	a.session.AddHandler(a.handleMessageCreate)
 	a.session.AddHandler(a.handleInteractionCreate)
+	a.session.AddHandler(a.handleReactionAdd)
+	a.session.AddHandler(a.handleReactionRemove)
 	a.session.AddHandler(a.handleReady)
 	a.session.AddHandler(a.handleDisconnect)
 
@@ -223,6 +229,7 @@ func (a *Adapter) Stop(ctx context.Context) error {
 
 	a.updateStatus(false, "")
 	close(a.messages)
+	close(a.reactions)
 	a.health.RecordConnectionClosed()
 
 	a.logger.Info("discord adapter stopped gracefully")
@@ -300,6 +307,7 @@ func (a *Adapter) Send(ctx context.Context, msg *models.Message) error {
 	embedDescription, hasEmbedDescription := msg.Metadata["discord_embed_description"].(string)
 
 	var err error
+	var sent *discordgo.Message
 
 	if hasEmbedTitle || hasEmbedColor || hasEmbedDescription {
 		// Send as embed
@@ -316,10 +324,10 @@ func (a *Adapter) Send(ctx context.Context, msg *models.Message) error {
 			Embeds: []*discordgo.MessageEmbed{embed},
 		}
 
-		_, err = a.session.ChannelMessageSendComplex(channelID, messageSend)
+		sent, err = a.session.ChannelMessageSendComplex(channelID, messageSend)
 	} else if msg.Content != "" {
 		// Send as regular message
-		_, err = a.session.ChannelMessageSend(channelID, msg.Content)
+		sent, err = a.session.ChannelMessageSend(channelID, msg.Content)
 	}
 
 	if err != nil {
@@ -338,6 +346,10 @@ func (a *Adapter) Send(ctx context.Context, msg *models.Message) error {
 		return channels.ErrInternal("failed to send message", err)
 	}
 
+	if sent != nil && sent.ID != "" {
+		msg.ChannelID = sent.ID
+	}
+
 	// Record success metrics
 	a.health.RecordMessageSent()
 	a.health.RecordSendLatency(time.Since(startTime))
@@ -354,6 +366,11 @@ func (a *Adapter) Messages() <-chan *models.Message {
 	return a.messages
 }
 
+// Reactions returns a channel of reactions added to or removed from messages.
+func (a *Adapter) Reactions() <-chan *channels.ReactionEvent {
+	return a.reactions
+}
+
 // Type returns the channel type.
 func (a *Adapter) Type() models.ChannelType {
 	return models.ChannelDiscord
@@ -452,6 +469,8 @@ func (a *Adapter) RegisterSlashCommands(commands []*discordgo.ApplicationCommand
 // Event handlers
 
 func (a *Adapter) handleMessageCreate(s *discordgo.Session, m *discordgo.MessageCreate) {
+	defer supervisor.Recover("channel:discord")
+
 	startTime := time.Now()
 
 	// Ignore messages from bots
@@ -468,6 +487,9 @@ func (a *Adapter) handleMessageCreate(s *discordgo.Session, m *discordgo.Message
 	if msg == nil {
 		return
 	}
+	if s != nil && s.State != nil && s.State.User != nil && discordMentionsBot(m.Message, s.State.User.ID) {
+		channels.SetMentioned(msg)
+	}
 
 	// Record metrics
 	a.health.RecordMessageReceived()
@@ -484,7 +506,47 @@ func (a *Adapter) handleMessageCreate(s *discordgo.Session, m *discordgo.Message
 	}
 }
 
+func (a *Adapter) handleReactionAdd(s *discordgo.Session, r *discordgo.MessageReactionAdd) {
+	defer supervisor.Recover("channel:discord")
+	if r == nil || r.MessageReaction == nil {
+		return
+	}
+	a.emitReaction(s, r.MessageReaction, false)
+}
+
+func (a *Adapter) handleReactionRemove(s *discordgo.Session, r *discordgo.MessageReactionRemove) {
+	defer supervisor.Recover("channel:discord")
+	if r == nil || r.MessageReaction == nil {
+		return
+	}
+	a.emitReaction(s, r.MessageReaction, true)
+}
+
+func (a *Adapter) emitReaction(s *discordgo.Session, r *discordgo.MessageReaction, removed bool) {
+	// Ignore the bot's own reactions
+	if s != nil && s.State != nil && s.State.User != nil && r.UserID == s.State.User.ID {
+		return
+	}
+	event := &channels.ReactionEvent{
+		Channel:   models.ChannelDiscord,
+		ChatID:    r.ChannelID,
+		MessageID: r.MessageID,
+		UserID:    r.UserID,
+		Emoji:     r.Emoji.Name,
+		Removed:   removed,
+		Timestamp: time.Now(),
+	}
+	select {
+	case a.reactions <- event:
+	case <-a.ctx.Done():
+	default:
+		a.logger.Warn("reactions channel full, dropping reaction",
+			"channel_id", r.ChannelID)
+	}
+}
+
 func (a *Adapter) handleInteractionCreate(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	defer supervisor.Recover("channel:discord")
 	if i.Type != discordgo.InteractionApplicationCommand {
 		return
 	}
@@ -585,8 +647,9 @@ func (a *Adapter) connectWithRetry(ctx context.Context) error {
 			Factor:       2,
 			Jitter:       true,
 		},
-		Logger: a.logger,
-		Health: a.health,
+		Logger:    a.logger,
+		Health:    a.health,
+		Component: "channel:discord",
 	}
 
 	attempt := 0
@@ -617,8 +680,9 @@ func (a *Adapter) reconnect() {
 			Factor:       2,
 			Jitter:       true,
 		},
-		Logger: a.logger,
-		Health: a.health,
+		Logger:    a.logger,
+		Health:    a.health,
+		Component: "channel:discord",
 	}
 
 	attempt := 0
@@ -757,9 +821,78 @@ func convertDiscordMessage(m *discordgo.Message) *models.Message {
 		msg.Metadata["discord_mentions"] = mentions
 	}
 
+	channels.SetReplyReference(msg, discordReplyReference(m))
+
 	return msg
 }
 
+// discordReplyReference describes the message m replies to. Discord usually
+// includes the referenced message; ResolveReplyContext fetches it otherwise.
+func discordReplyReference(m *discordgo.Message) *channels.ReplyReference {
+	if m.MessageReference == nil || m.MessageReference.MessageID == "" || m.MessageReference.Type == discordgo.MessageReferenceTypeForward {
+		return nil
+	}
+	ref := &channels.ReplyReference{
+		MessageID: m.MessageReference.MessageID,
+		ChatID:    m.MessageReference.ChannelID,
+	}
+	if ref.ChatID == "" {
+		ref.ChatID = m.ChannelID
+	}
+	fillDiscordReplyReference(ref, m.ReferencedMessage)
+	return ref
+}
+
+// discordMentionsBot reports whether m mentions the bot user or replies to
+// one of its messages.
+func discordMentionsBot(m *discordgo.Message, botID string) bool {
+	if m == nil || botID == "" {
+		return false
+	}
+	for _, user := range m.Mentions {
+		if user != nil && user.ID == botID {
+			return true
+		}
+	}
+	return m.ReferencedMessage != nil && m.ReferencedMessage.Author != nil && m.ReferencedMessage.Author.ID == botID
+}
+
+func fillDiscordReplyReference(ref *channels.ReplyReference, referenced *discordgo.Message) {
+	if referenced == nil {
+		return
+	}
+	ref.Content = referenced.Content
+	ref.SentAt = referenced.Timestamp
+	if referenced.Author != nil {
+		ref.SenderID = referenced.Author.ID
+		ref.SenderName = referenced.Author.Username
+		ref.FromBot = referenced.Author.Bot
+	}
+}
+
+// ResolveReplyContext fetches the referenced message of a reply.
+// This is part of the ReplyContextAdapter interface.
+func (a *Adapter) ResolveReplyContext(ctx context.Context, msg *models.Message, ref channels.ReplyReference) (*channels.ReplyReference, error) {
+	if a.session == nil {
+		return nil, channels.ErrInternal("session not initialized (start adapter)", nil)
+	}
+	if ref.ChatID == "" {
+		ref.ChatID, _ = msg.Metadata["discord_channel_id"].(string)
+	}
+	if ref.ChatID == "" || ref.MessageID == "" {
+		return nil, channels.ErrInvalidInput("reply reference has no channel or message ID", nil)
+	}
+	if err := a.rateLimiter.Wait(ctx); err != nil {
+		return nil, channels.ErrTimeout("rate limit wait cancelled", err)
+	}
+	referenced, err := a.session.ChannelMessage(ref.ChatID, ref.MessageID, discordgo.WithContext(ctx))
+	if err != nil {
+		return nil, channels.ErrConnection("failed to fetch referenced message", err)
+	}
+	fillDiscordReplyReference(&ref, referenced)
+	return &ref, nil
+}
+
 func detectAttachmentType(contentType string) string {
 	if strings.HasPrefix(contentType, "image/") {
 		return "image"
@@ -1078,6 +1078,58 @@ func TestAdapter_SendRateLimitError(t *testing.T) {
 	}
 }
 
+func TestDiscordMentionsBot(t *testing.T) {
+	bot := &discordgo.User{ID: "bot-1", Bot: true}
+	if discordMentionsBot(&discordgo.Message{Content: "hi"}, "bot-1") {
+		t.Error("expected no mention")
+	}
+	if !discordMentionsBot(&discordgo.Message{Mentions: []*discordgo.User{{ID: "user-2"}, bot}}, "bot-1") {
+		t.Error("expected @-mention to count")
+	}
+	if !discordMentionsBot(&discordgo.Message{ReferencedMessage: &discordgo.Message{Author: bot}}, "bot-1") {
+		t.Error("expected a reply to the bot to count")
+	}
+}
+
+func TestAdapter_ResolveReplyContext(t *testing.T) {
+	msg := convertDiscordMessage(&discordgo.Message{
+		ID:               "msg-2",
+		ChannelID:        "channel-123",
+		Content:          "what about this?",
+		Author:           &discordgo.User{ID: "user-1", Username: "ana"},
+		MessageReference: &discordgo.MessageReference{MessageID: "msg-1"},
+	})
+	ref, ok := channels.ReplyReferenceFrom(msg)
+	if !ok || ref.Resolved() || ref.ChatID != "channel-123" {
+		t.Fatalf("expected an unresolved reference to msg-1, got %+v", ref)
+	}
+
+	adapter := NewAdapterSimple("test-token")
+	adapter.session = &mockDiscordSession{
+		channelMessageFn: func(channelID, messageID string) (*discordgo.Message, error) {
+			if channelID != "channel-123" || messageID != "msg-1" {
+				return nil, errors.New("not found")
+			}
+			return &discordgo.Message{
+				ID:      "msg-1",
+				Content: "the deploy is paused",
+				Author:  &discordgo.User{ID: "bot-1", Username: "nexus", Bot: true},
+			}, nil
+		},
+	}
+	resolved, err := adapter.ResolveReplyContext(context.Background(), msg, *ref)
+	if err != nil {
+		t.Fatalf("ResolveReplyContext() error = %v", err)
+	}
+	if resolved.Content != "the deploy is paused" || resolved.SenderName != "nexus" || !resolved.FromBot {
+		t.Fatalf("unexpected resolved reference %+v", resolved)
+	}
+
+	if _, err := adapter.ResolveReplyContext(context.Background(), msg, channels.ReplyReference{MessageID: "missing"}); err == nil {
+		t.Fatal("expected an error for a missing message")
+	}
+}
+
 func TestAdapter_SendReactionError(t *testing.T) {
 	adapter := NewAdapterSimple("test-token")
 	mock := &mockDiscordSession{
@@ -1151,6 +1203,7 @@ type mockDiscordSession struct {
 	channelMessageSendFn func(channelID string, content string, options ...discordgo.RequestOption) (*discordgo.Message, error)
 	messageReactionAddFn func(channelID, messageID, emoji string) error
 	threadStartFn        func(channelID, name string, archiveDuration int) (*discordgo.Channel, error)
+	channelMessageFn     func(channelID, messageID string) (*discordgo.Message, error)
 }
 
 func (m *mockDiscordSession) Open() error {
@@ -1235,6 +1288,13 @@ func (m *mockDiscordSession) ThreadStart(channelID, name string, typ discordgo.C
 	}, nil
 }
 
+func (m *mockDiscordSession) ChannelMessage(channelID, messageID string, options ...discordgo.RequestOption) (*discordgo.Message, error) {
+	if m.channelMessageFn != nil {
+		return m.channelMessageFn(channelID, messageID)
+	}
+	return nil, errors.New("not found")
+}
+
 func (m *mockDiscordSession) AddHandler(handler interface{}) func() {
 	return func() {}
 }
@@ -1499,6 +1559,57 @@ func TestAdapter_HandleMessageCreate_ChannelFull(t *testing.T) {
 	}
 }
 
+func TestAdapter_HandleReactionEvents(t *testing.T) {
+	adapter := NewAdapterSimple("test-token")
+	adapter.session = &mockDiscordSession{}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	adapter.ctx = ctx
+	adapter.cancel = cancel
+
+	reaction := &discordgo.MessageReaction{
+		UserID:    "user-789",
+		MessageID: "bot-msg-1",
+		ChannelID: "channel-456",
+		Emoji:     discordgo.Emoji{Name: "👍"},
+	}
+	adapter.handleReactionAdd(nil, &discordgo.MessageReactionAdd{MessageReaction: reaction})
+	adapter.handleReactionRemove(nil, &discordgo.MessageReactionRemove{MessageReaction: reaction})
+
+	for _, wantRemoved := range []bool{false, true} {
+		select {
+		case event := <-adapter.Reactions():
+			if event.Channel != models.ChannelDiscord || event.ChatID != "channel-456" ||
+				event.MessageID != "bot-msg-1" || event.UserID != "user-789" || event.Emoji != "👍" {
+				t.Fatalf("unexpected reaction event: %+v", event)
+			}
+			if event.Removed != wantRemoved {
+				t.Fatalf("expected Removed=%v, got %v", wantRemoved, event.Removed)
+			}
+		case <-time.After(100 * time.Millisecond):
+			t.Fatal("expected reaction event, timed out")
+		}
+	}
+}
+
+func TestAdapter_SendRecordsSentMessageID(t *testing.T) {
+	adapter := NewAdapterSimple("test-token")
+	adapter.session = &mockDiscordSession{}
+	adapter.updateStatus(true, "")
+
+	msg := &models.Message{
+		Content:  "hello",
+		Metadata: map[string]any{"discord_channel_id": "channel-456"},
+	}
+	if err := adapter.Send(context.Background(), msg); err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+	if msg.ChannelID != "test-msg-id" {
+		t.Fatalf("expected sent message ID to be recorded, got %q", msg.ChannelID)
+	}
+}
+
 func TestAdapter_HandleMessageCreate_ContextCancelled(t *testing.T) {
 	adapter := NewAdapterSimple("test-token")
 	mock := &mockDiscordSession{}
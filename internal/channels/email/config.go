@@ -1,14 +1,54 @@
 package email
 
 import (
+	"context"
 	"log/slog"
+	"strings"
 	"time"
 
 	"github.com/haasonsaas/nexus/internal/channels"
+	"golang.org/x/oauth2"
 )
 
-// Config holds configuration for the Microsoft Graph Email adapter.
+// Mail backends.
+const (
+	// BackendGraph reads and sends mail through Microsoft Graph.
+	BackendGraph = "graph"
+
+	// BackendIMAP reads mail over IMAP and sends it over SMTP.
+	BackendIMAP = "imap"
+)
+
+// Connection security modes for IMAP and SMTP.
+const (
+	SecurityTLS      = "tls"
+	SecurityStartTLS = "starttls"
+	SecurityNone     = "none"
+)
+
+// Config holds configuration for the Email adapter.
 type Config struct {
+	// Backend selects the mail transport: "graph" (default) or "imap".
+	Backend string
+
+	// Address is the mailbox address for the imap backend. It is the From
+	// address of outgoing mail and the default IMAP and SMTP username.
+	Address string
+
+	// DisplayName is the From display name for the imap backend.
+	DisplayName string
+
+	// IMAP configures the watched mailbox for the imap backend.
+	IMAP IMAPConfig
+
+	// SMTP configures outgoing mail for the imap backend.
+	SMTP SMTPConfig
+
+	// TokenURL is the OAuth2 token endpoint used to refresh XOAUTH2 access
+	// tokens for the imap backend. Defaults to the tenant endpoint when
+	// TenantID is set.
+	TokenURL string
+
 	// TenantID is the Azure AD tenant ID (required)
 	TenantID string
 
@@ -57,18 +97,57 @@ type Config struct {
 	Logger *slog.Logger
 }
 
-// Validate checks if the configuration is valid and applies defaults.
-func (c *Config) Validate() error {
-	if c.TenantID == "" {
-		return channels.ErrConfig("tenant_id is required", nil)
-	}
+// IMAPConfig configures the IMAP connection of the imap backend.
+type IMAPConfig struct {
+	Host string
+	Port int
 
-	if c.ClientID == "" {
-		return channels.ErrConfig("client_id is required", nil)
-	}
+	// Security is "tls" (default), "starttls", or "none".
+	Security string
 
-	if c.ClientSecret == "" && c.AccessToken == "" {
-		return channels.ErrConfig("client_secret or access_token is required", nil)
+	// Username defaults to Config.Address.
+	Username string
+
+	// Password is the account or app password. Leave empty to authenticate
+	// with OAuth2 (XOAUTH2) using AccessToken or RefreshToken.
+	Password string
+
+	// DisableIdle polls every PollInterval instead of waiting with IDLE.
+	DisableIdle bool
+
+	// IdleTimeout is how long one IDLE command runs before it is renewed
+	// (default 25m, below the 29 minutes servers allow).
+	IdleTimeout time.Duration
+}
+
+// SMTPConfig configures the SMTP connection of the imap backend.
+type SMTPConfig struct {
+	Host string
+	Port int
+
+	// Security is "starttls" (default), "tls", or "none".
+	Security string
+
+	// Username and Password default to the IMAP credentials.
+	Username string
+	Password string
+}
+
+// Validate checks if the configuration is valid and applies defaults.
+func (c *Config) Validate() error {
+	switch strings.ToLower(strings.TrimSpace(c.Backend)) {
+	case "", BackendGraph:
+		c.Backend = BackendGraph
+		if err := c.validateGraph(); err != nil {
+			return err
+		}
+	case BackendIMAP:
+		c.Backend = BackendIMAP
+		if err := c.validateIMAP(); err != nil {
+			return err
+		}
+	default:
+		return channels.ErrConfig("backend must be graph or imap", nil)
 	}
 
 	if c.PollInterval == 0 {
@@ -103,6 +182,118 @@ func (c *Config) Validate() error {
 	return nil
 }
 
+func (c *Config) validateGraph() error {
+	if c.TenantID == "" {
+		return channels.ErrConfig("tenant_id is required", nil)
+	}
+
+	if c.ClientID == "" {
+		return channels.ErrConfig("client_id is required", nil)
+	}
+
+	if c.ClientSecret == "" && c.AccessToken == "" {
+		return channels.ErrConfig("client_secret or access_token is required", nil)
+	}
+	return nil
+}
+
+func (c *Config) validateIMAP() error {
+	c.Address = strings.TrimSpace(c.Address)
+	if c.Address == "" {
+		return channels.ErrConfig("address is required for the imap backend", nil)
+	}
+	if c.IMAP.Host == "" {
+		return channels.ErrConfig("imap.host is required", nil)
+	}
+	if c.SMTP.Host == "" {
+		return channels.ErrConfig("smtp.host is required", nil)
+	}
+
+	security, err := normalizeSecurity("imap.security", c.IMAP.Security, SecurityTLS)
+	if err != nil {
+		return err
+	}
+	c.IMAP.Security = security
+	if c.IMAP.Port == 0 {
+		c.IMAP.Port = 993
+		if security != SecurityTLS {
+			c.IMAP.Port = 143
+		}
+	}
+	security, err = normalizeSecurity("smtp.security", c.SMTP.Security, SecurityStartTLS)
+	if err != nil {
+		return err
+	}
+	c.SMTP.Security = security
+	if c.SMTP.Port == 0 {
+		switch security {
+		case SecurityTLS:
+			c.SMTP.Port = 465
+		case SecurityStartTLS:
+			c.SMTP.Port = 587
+		default:
+			c.SMTP.Port = 25
+		}
+	}
+
+	if c.IMAP.Username == "" {
+		c.IMAP.Username = c.Address
+	}
+	if c.SMTP.Username == "" {
+		c.SMTP.Username = c.IMAP.Username
+	}
+	if c.SMTP.Password == "" {
+		c.SMTP.Password = c.IMAP.Password
+	}
+	if c.IMAP.Password == "" {
+		if c.AccessToken == "" && c.RefreshToken == "" {
+			return channels.ErrConfig("imap.password, access_token, or refresh_token is required", nil)
+		}
+		if c.RefreshToken != "" {
+			if c.TokenURL == "" && c.TenantID != "" {
+				c.TokenURL = c.TokenEndpoint()
+			}
+			if c.TokenURL == "" || c.ClientID == "" {
+				return channels.ErrConfig("token_url and client_id are required to refresh access tokens", nil)
+			}
+		}
+	}
+	if c.IMAP.IdleTimeout == 0 {
+		c.IMAP.IdleTimeout = 25 * time.Minute
+	}
+	return nil
+}
+
+func normalizeSecurity(field, value, fallback string) (string, error) {
+	switch security := strings.ToLower(strings.TrimSpace(value)); security {
+	case "":
+		return fallback, nil
+	case SecurityTLS, SecurityStartTLS, SecurityNone:
+		return security, nil
+	default:
+		return "", channels.ErrConfig(field+" must be tls, starttls, or none", nil)
+	}
+}
+
+// oauthTokenSource returns the XOAUTH2 token source of the imap backend, or
+// nil when it authenticates with a password.
+func (c *Config) oauthTokenSource(ctx context.Context) oauth2.TokenSource {
+	if c.IMAP.Password != "" {
+		return nil
+	}
+	if c.RefreshToken == "" {
+		return oauth2.StaticTokenSource(&oauth2.Token{AccessToken: c.AccessToken})
+	}
+	oauthCfg := &oauth2.Config{
+		ClientID:     c.ClientID,
+		ClientSecret: c.ClientSecret,
+		Endpoint:     oauth2.Endpoint{TokenURL: c.TokenURL},
+	}
+	// Without an expiry a configured access token would never be refreshed,
+	// so start from the refresh token alone.
+	return oauthCfg.TokenSource(ctx, &oauth2.Token{RefreshToken: c.RefreshToken})
+}
+
 // TokenEndpoint returns the OAuth2 token endpoint for this tenant.
 func (c *Config) TokenEndpoint() string {
 	return "https://login.microsoftonline.com/" + c.TenantID + "/oauth2/v2.0/token"
@@ -0,0 +1,592 @@
+package email
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/mail"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/haasonsaas/nexus/internal/artifacts"
+	"github.com/haasonsaas/nexus/internal/channels"
+	"github.com/haasonsaas/nexus/pkg/models"
+	pb "github.com/haasonsaas/nexus/pkg/proto"
+	"golang.org/x/oauth2"
+)
+
+// AttachmentArtifactType is the artifact type of inbound email attachments.
+const AttachmentArtifactType = "email_attachment"
+
+// Draft is an email composed by the agent rather than sent as a reply.
+type Draft struct {
+	To      []string
+	Cc      []string
+	Subject string
+	Body    string
+
+	// InReplyTo optionally threads the draft under an existing Message-ID.
+	InReplyTo string
+}
+
+// IMAPAdapter implements channels.Adapter over IMAP and SMTP. It watches one
+// folder with IDLE (or polling), threads replies with In-Reply-To and
+// References, and stores inbound attachments as artifacts.
+type IMAPAdapter struct {
+	config      Config
+	messages    chan *models.Message
+	cancel      context.CancelFunc
+	wg          sync.WaitGroup
+	rateLimiter *channels.RateLimiter
+	logger      *slog.Logger
+	health      *channels.BaseHealthAdapter
+	tokens      oauth2.TokenSource
+
+	artifactMu   sync.RWMutex
+	artifactRepo artifacts.Repository
+
+	// Mailbox position, owned by the watch goroutine after Start.
+	uidValidity uint32
+	uidNext     uint32
+}
+
+// NewIMAPAdapter creates an IMAP/SMTP email adapter.
+func NewIMAPAdapter(config Config) (*IMAPAdapter, error) {
+	config.Backend = BackendIMAP
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	a := &IMAPAdapter{
+		config:      config,
+		messages:    make(chan *models.Message, 100),
+		rateLimiter: channels.NewRateLimiter(config.RateLimit, config.RateBurst),
+		logger:      config.Logger.With("adapter", "email", "backend", BackendIMAP),
+		tokens:      config.oauthTokenSource(context.Background()),
+	}
+	a.health = channels.NewBaseHealthAdapter(models.ChannelEmail, a.logger)
+	return a, nil
+}
+
+// SetArtifactRepository stores inbound attachments in repo so they reach
+// the artifact pipeline and can be downloaded for media processing.
+func (a *IMAPAdapter) SetArtifactRepository(repo artifacts.Repository) {
+	a.artifactMu.Lock()
+	a.artifactRepo = repo
+	a.artifactMu.Unlock()
+}
+
+func (a *IMAPAdapter) artifactRepository() artifacts.Repository {
+	a.artifactMu.RLock()
+	defer a.artifactMu.RUnlock()
+	return a.artifactRepo
+}
+
+// Type returns the channel type.
+func (a *IMAPAdapter) Type() models.ChannelType {
+	return models.ChannelEmail
+}
+
+// Start connects to the mailbox and begins watching for new mail. Mail
+// already in the folder is not delivered.
+func (a *IMAPAdapter) Start(ctx context.Context) error {
+	ctx, cancel := context.WithCancel(ctx)
+	a.cancel = cancel
+
+	client, err := a.connect(ctx)
+	if err != nil {
+		cancel()
+		return err
+	}
+	a.setStatus(true, "")
+	a.logger.Info("email adapter started",
+		"address", a.config.Address,
+		"folder", a.config.FolderID,
+		"idle", client.caps["IDLE"] && !a.config.IMAP.DisableIdle,
+	)
+
+	a.wg.Add(1)
+	go a.watchMailbox(ctx, client)
+	return nil
+}
+
+// Stop gracefully shuts down the adapter.
+func (a *IMAPAdapter) Stop(ctx context.Context) error {
+	a.logger.Info("stopping email adapter")
+	if a.cancel != nil {
+		a.cancel()
+	}
+
+	done := make(chan struct{})
+	go func() {
+		a.wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		a.logger.Info("email adapter stopped gracefully")
+	case <-ctx.Done():
+		a.logger.Warn("email adapter stop timed out")
+	}
+
+	a.setStatus(false, "stopped")
+	close(a.messages)
+	return nil
+}
+
+// login opens an authenticated IMAP connection.
+func (a *IMAPAdapter) login(ctx context.Context) (*imapClient, error) {
+	client, err := dialIMAP(ctx, a.config.IMAP)
+	if err != nil {
+		return nil, err
+	}
+	if a.tokens != nil {
+		var token *oauth2.Token
+		token, err = a.tokens.Token()
+		if err != nil {
+			client.conn.Close()
+			return nil, fmt.Errorf("oauth2 token: %w", err)
+		}
+		err = client.authenticateXOAUTH2(a.config.IMAP.Username, token.AccessToken)
+	} else {
+		err = client.login(a.config.IMAP.Username, a.config.IMAP.Password)
+	}
+	if err != nil {
+		client.conn.Close()
+		return nil, err
+	}
+	return client, nil
+}
+
+// connect logs in and selects the watched folder. The first connection sets
+// the starting UID; a changed UIDVALIDITY starts over from the current end.
+func (a *IMAPAdapter) connect(ctx context.Context) (*imapClient, error) {
+	client, err := a.login(ctx)
+	if err != nil {
+		return nil, err
+	}
+	validity, next, err := client.selectFolder(a.config.FolderID)
+	if err != nil {
+		client.logout()
+		return nil, err
+	}
+	if a.uidNext == 0 || validity != a.uidValidity {
+		if a.uidNext != 0 {
+			a.logger.Warn("mailbox UIDVALIDITY changed; skipping to new mail", "folder", a.config.FolderID)
+		}
+		a.uidNext = next
+	}
+	a.uidValidity = validity
+	return client, nil
+}
+
+// watchMailbox delivers new mail until ctx ends, reconnecting after errors.
+func (a *IMAPAdapter) watchMailbox(ctx context.Context, client *imapClient) {
+	defer a.wg.Done()
+	for {
+		if client != nil {
+			err := a.runSession(ctx, client)
+			if ctx.Err() != nil {
+				// The session may be mid-IDLE; just drop the connection.
+				_ = client.conn.Close()
+				return
+			}
+			client.logout()
+			a.logger.Warn("imap session ended", "error", err)
+			a.setStatus(false, err.Error())
+			a.health.RecordConnectionClosed()
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(a.config.ReconnectDelay):
+		}
+		a.health.RecordReconnectAttempt()
+		var err error
+		client, err = a.connect(ctx)
+		if err != nil {
+			a.logger.Warn("imap reconnect failed", "error", err)
+			a.setStatus(false, err.Error())
+			continue
+		}
+		a.setStatus(true, "")
+	}
+}
+
+// runSession fetches new mail, then waits with IDLE or PollInterval, until
+// the connection fails or ctx ends.
+func (a *IMAPAdapter) runSession(ctx context.Context, client *imapClient) error {
+	useIdle := client.caps["IDLE"] && !a.config.IMAP.DisableIdle
+	for {
+		if err := a.fetchNew(ctx, client); err != nil {
+			return err
+		}
+		if useIdle {
+			if err := client.idle(ctx, a.config.IMAP.IdleTimeout); err != nil {
+				return err
+			}
+			continue
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(a.config.PollInterval):
+		}
+		if err := client.noop(); err != nil {
+			return err
+		}
+	}
+}
+
+// fetchNew delivers every message at or above the next expected UID.
+func (a *IMAPAdapter) fetchNew(ctx context.Context, client *imapClient) error {
+	criteria := fmt.Sprintf("UID %d:*", a.uidNext)
+	if !a.config.IncludeRead {
+		criteria += " UNSEEN"
+	}
+	uids, err := client.searchUIDs(criteria)
+	if err != nil {
+		return err
+	}
+	for _, uid := range uids {
+		// "n:*" always matches the last message, even below n.
+		if uid < a.uidNext {
+			continue
+		}
+		raw, err := client.fetchMessage(uid)
+		if err != nil {
+			return err
+		}
+		a.uidNext = uid + 1
+		a.processMessage(ctx, uid, raw)
+		if a.config.AutoMarkRead {
+			if err := client.markSeen(uid); err != nil {
+				a.logger.Warn("failed to mark email as read", "uid", uid, "error", err)
+			}
+		}
+	}
+	return nil
+}
+
+// processMessage converts a raw email to a Nexus message.
+func (a *IMAPAdapter) processMessage(ctx context.Context, uid uint32, raw []byte) {
+	email, err := parseEmail(raw)
+	if err != nil {
+		a.logger.Warn("failed to parse email", "uid", uid, "error", err)
+		a.health.RecordMessageFailed()
+		return
+	}
+	// Skip our own mail and automatic replies so two mailboxes cannot loop.
+	if email.FromAddress == "" || strings.EqualFold(email.FromAddress, a.config.Address) {
+		return
+	}
+	if email.AutoSubmitted != "" && email.AutoSubmitted != "no" {
+		a.logger.Debug("skipping auto-submitted email", "from", email.FromAddress)
+		return
+	}
+
+	nexusMsg := &models.Message{
+		ID:        uuid.NewString(),
+		Channel:   models.ChannelEmail,
+		ChannelID: "email:" + email.FromAddress,
+		Direction: models.DirectionInbound,
+		Role:      models.RoleUser,
+		Content:   email.Body(),
+		CreatedAt: email.Date,
+		Metadata: map[string]any{
+			"email_message_id":    email.MessageID,
+			"conversation_id":     email.ThreadID(),
+			"subject":             email.Subject,
+			"sender_email":        email.FromAddress,
+			"sender_name":         email.FromName,
+			"has_attachments":     len(email.Attachments) > 0,
+			"reply_to_message_id": email.MessageID,
+			"email_references":    strings.Join(email.References, " "),
+			"imap_uid":            uid,
+		},
+	}
+	nexusMsg.Attachments = a.ingestAttachments(ctx, email)
+
+	a.health.RecordMessageReceived()
+	select {
+	case a.messages <- nexusMsg:
+		a.logger.Debug("email received", "from", email.FromAddress, "subject", email.Subject)
+	default:
+		a.logger.Warn("message channel full, dropping email", "from", email.FromAddress)
+		a.health.RecordMessageFailed()
+	}
+}
+
+// ingestAttachments stores attachments as artifacts and references them by
+// artifact ID. Without a repository only their names and sizes are kept.
+func (a *IMAPAdapter) ingestAttachments(ctx context.Context, email *inboundEmail) []models.Attachment {
+	if len(email.Attachments) == 0 {
+		return nil
+	}
+	repo := a.artifactRepository()
+	attachments := make([]models.Attachment, 0, len(email.Attachments))
+	for _, att := range email.Attachments {
+		artifact := &pb.Artifact{
+			Id:       uuid.NewString(),
+			Type:     AttachmentArtifactType,
+			MimeType: att.ContentType,
+			Filename: att.Filename,
+			Size:     int64(len(att.Data)),
+		}
+		if repo != nil {
+			if err := repo.StoreArtifact(ctx, artifact, bytes.NewReader(att.Data)); err != nil {
+				a.logger.Warn("failed to store email attachment", "filename", att.Filename, "error", err)
+				continue
+			}
+		}
+		attachments = append(attachments, models.Attachment{
+			ID:       artifact.Id,
+			Type:     attachmentType(artifact.MimeType),
+			Filename: artifact.Filename,
+			MimeType: artifact.MimeType,
+			Size:     artifact.Size,
+		})
+	}
+	return attachments
+}
+
+func attachmentType(mimeType string) string {
+	switch {
+	case strings.HasPrefix(mimeType, "image/"):
+		return "image"
+	case strings.HasPrefix(mimeType, "audio/"):
+		return "audio"
+	case strings.HasPrefix(mimeType, "video/"):
+		return "video"
+	default:
+		return "document"
+	}
+}
+
+// DownloadAttachment reads an inbound attachment back from the artifact
+// repository.
+func (a *IMAPAdapter) DownloadAttachment(ctx context.Context, msg *models.Message, attachment *models.Attachment) ([]byte, string, string, error) {
+	repo := a.artifactRepository()
+	if repo == nil {
+		return nil, "", "", errors.New("email attachments are not stored: artifacts are disabled")
+	}
+	artifact, reader, err := repo.GetArtifact(ctx, attachment.ID)
+	if err != nil {
+		return nil, "", "", err
+	}
+	defer reader.Close()
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, "", "", err
+	}
+	return data, artifact.MimeType, artifact.Filename, nil
+}
+
+// Send delivers a reply, or a new email when msg is not a reply. Replies
+// keep the thread with In-Reply-To and References.
+func (a *IMAPAdapter) Send(ctx context.Context, msg *models.Message) error {
+	if err := a.rateLimiter.Wait(ctx); err != nil {
+		return fmt.Errorf("rate limit: %w", err)
+	}
+
+	metaString := func(key string) string {
+		value, _ := msg.Metadata[key].(string)
+		return strings.TrimSpace(value)
+	}
+	recipient := strings.TrimPrefix(msg.ChannelID, "email:")
+	if recipient == "" {
+		recipient = metaString("sender_email")
+	}
+	if recipient == "" {
+		return errors.New("email recipient is required")
+	}
+
+	out := &outboundEmail{
+		From: a.from(),
+		To:   []mail.Address{{Address: recipient}},
+		Body: msg.Content,
+	}
+	if replyTo := metaString("reply_to_message_id"); replyTo != "" {
+		out.Subject = replySubject(metaString("subject"))
+		out.InReplyTo = replyTo
+		out.References = append(messageIDs(metaString("email_references")), replyTo)
+	} else {
+		out.Subject = metaString("subject")
+		if out.Subject == "" {
+			out.Subject = "Message from Nexus"
+		}
+	}
+	out.Attachments, out.Body = outboundAttachments(msg.Attachments, out.Body)
+
+	if _, err := a.deliver(ctx, out); err != nil {
+		return err
+	}
+	a.logger.Debug("email sent", "to", recipient, "subject", out.Subject)
+	return nil
+}
+
+// Compose sends a new email to arbitrary recipients and returns its
+// Message-ID. The gateway only exposes it through the approval-gated
+// email_compose tool.
+func (a *IMAPAdapter) Compose(ctx context.Context, draft Draft) (string, error) {
+	if err := a.rateLimiter.Wait(ctx); err != nil {
+		return "", fmt.Errorf("rate limit: %w", err)
+	}
+	to, err := parseAddresses(draft.To)
+	if err != nil {
+		return "", fmt.Errorf("to: %w", err)
+	}
+	if len(to) == 0 {
+		return "", errors.New("at least one recipient is required")
+	}
+	cc, err := parseAddresses(draft.Cc)
+	if err != nil {
+		return "", fmt.Errorf("cc: %w", err)
+	}
+	out := &outboundEmail{
+		From:    a.from(),
+		To:      to,
+		Cc:      cc,
+		Subject: strings.TrimSpace(draft.Subject),
+		Body:    draft.Body,
+	}
+	if replyTo := firstMessageID(draft.InReplyTo); replyTo != "" {
+		out.InReplyTo = replyTo
+		out.References = []string{replyTo}
+	}
+	return a.deliver(ctx, out)
+}
+
+func parseAddresses(values []string) ([]mail.Address, error) {
+	var addrs []mail.Address
+	for _, value := range values {
+		if strings.TrimSpace(value) == "" {
+			continue
+		}
+		parsed, err := mail.ParseAddressList(value)
+		if err != nil {
+			return nil, err
+		}
+		for _, addr := range parsed {
+			addrs = append(addrs, *addr)
+		}
+	}
+	return addrs, nil
+}
+
+// deliver sends out over SMTP and returns its Message-ID.
+func (a *IMAPAdapter) deliver(ctx context.Context, out *outboundEmail) (string, error) {
+	token := ""
+	if a.tokens != nil {
+		tok, err := a.tokens.Token()
+		if err != nil {
+			a.health.RecordMessageFailed()
+			return "", fmt.Errorf("oauth2 token: %w", err)
+		}
+		token = tok.AccessToken
+	}
+	raw, messageID := out.Bytes(time.Now())
+	if err := sendSMTP(ctx, a.config.SMTP, token, a.config.Address, out.Recipients(), raw); err != nil {
+		a.health.RecordMessageFailed()
+		return "", err
+	}
+	a.health.RecordMessageSent()
+	return messageID, nil
+}
+
+func (a *IMAPAdapter) from() mail.Address {
+	return mail.Address{Name: a.config.DisplayName, Address: a.config.Address}
+}
+
+// outboundAttachments attaches inline (data URL) attachments and lists the
+// others as links at the end of body.
+func outboundAttachments(attachments []models.Attachment, body string) ([]emailAttachment, string) {
+	var files []emailAttachment
+	var links []string
+	for _, att := range attachments {
+		if data, mimeType, ok := decodeDataURL(att.URL); ok {
+			if att.MimeType != "" {
+				mimeType = att.MimeType
+			}
+			filename := att.Filename
+			if filename == "" {
+				filename = "attachment"
+			}
+			files = append(files, emailAttachment{Filename: filename, ContentType: mimeType, Data: data})
+			continue
+		}
+		if att.URL != "" {
+			links = append(links, att.URL)
+		}
+	}
+	if len(links) > 0 {
+		body = strings.TrimRight(body, "\n") + "\n\n" + strings.Join(links, "\n")
+	}
+	return files, body
+}
+
+// decodeDataURL decodes a base64 data: URL.
+func decodeDataURL(value string) ([]byte, string, bool) {
+	rest, ok := strings.CutPrefix(value, "data:")
+	if !ok {
+		return nil, "", false
+	}
+	meta, payload, ok := strings.Cut(rest, ",")
+	if !ok || !strings.HasSuffix(meta, ";base64") {
+		return nil, "", false
+	}
+	data, err := base64.StdEncoding.DecodeString(payload)
+	if err != nil {
+		return nil, "", false
+	}
+	return data, strings.TrimSuffix(meta, ";base64"), true
+}
+
+// Messages returns the channel for receiving inbound messages.
+func (a *IMAPAdapter) Messages() <-chan *models.Message {
+	return a.messages
+}
+
+// Status returns the current adapter status.
+func (a *IMAPAdapter) Status() channels.Status {
+	return a.health.Status()
+}
+
+// HealthCheck logs in to the IMAP server.
+func (a *IMAPAdapter) HealthCheck(ctx context.Context) channels.HealthStatus {
+	start := time.Now()
+	client, err := a.login(ctx)
+	if err != nil {
+		return channels.HealthStatus{
+			Healthy: false,
+			Message: fmt.Sprintf("health check failed: %v", err),
+			Latency: time.Since(start),
+		}
+	}
+	client.logout()
+	return channels.HealthStatus{
+		Healthy: true,
+		Message: "connected",
+		Latency: time.Since(start),
+	}
+}
+
+// Metrics returns the current metrics snapshot.
+func (a *IMAPAdapter) Metrics() channels.MetricsSnapshot {
+	return a.health.Metrics()
+}
+
+// SendTypingIndicator reports typing indicators as unsupported for email.
+func (a *IMAPAdapter) SendTypingIndicator(ctx context.Context, msg *models.Message) error {
+	return channels.ErrNotSupported
+}
+
+func (a *IMAPAdapter) setStatus(connected bool, errorMsg string) {
+	a.health.SetStatus(connected, errorMsg)
+}
@@ -0,0 +1,378 @@
+package email
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxIMAPLiteral bounds a single literal (one message body) read from the server.
+const maxIMAPLiteral = 64 << 20
+
+var (
+	imapUIDPattern  = regexp.MustCompile(`\bUID (\d+)`)
+	imapCodePattern = regexp.MustCompile(`\[(UIDNEXT|UIDVALIDITY) (\d+)\]`)
+)
+
+// imapResponse is one server response line with the literals it carried.
+type imapResponse struct {
+	line     string
+	literals [][]byte
+}
+
+// imapClient is a minimal IMAP4rev1 client covering what the email channel
+// needs: authentication, SELECT, UID SEARCH/FETCH/STORE, and IDLE.
+type imapClient struct {
+	conn net.Conn
+	r    *bufio.Reader
+	tag  int
+	caps map[string]bool
+}
+
+// dialIMAP connects to the server and reads its greeting and capabilities.
+func dialIMAP(ctx context.Context, cfg IMAPConfig) (*imapClient, error) {
+	addr := net.JoinHostPort(cfg.Host, strconv.Itoa(cfg.Port))
+	dialer := &net.Dialer{Timeout: 30 * time.Second}
+	var (
+		conn net.Conn
+		err  error
+	)
+	if cfg.Security == SecurityTLS {
+		tlsDialer := &tls.Dialer{NetDialer: dialer, Config: &tls.Config{ServerName: cfg.Host, MinVersion: tls.VersionTLS12}}
+		conn, err = tlsDialer.DialContext(ctx, "tcp", addr)
+	} else {
+		conn, err = dialer.DialContext(ctx, "tcp", addr)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("dial imap: %w", err)
+	}
+
+	c := &imapClient{conn: conn, r: bufio.NewReader(conn)}
+	greeting, err := c.readResponse()
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("imap greeting: %w", err)
+	}
+	if !strings.HasPrefix(greeting.line, "* OK") && !strings.HasPrefix(greeting.line, "* PREAUTH") {
+		conn.Close()
+		return nil, fmt.Errorf("imap greeting: %s", greeting.line)
+	}
+
+	if cfg.Security == SecurityStartTLS {
+		if _, err := c.command("STARTTLS"); err != nil {
+			conn.Close()
+			return nil, err
+		}
+		tlsConn := tls.Client(conn, &tls.Config{ServerName: cfg.Host, MinVersion: tls.VersionTLS12})
+		if err := tlsConn.HandshakeContext(ctx); err != nil {
+			conn.Close()
+			return nil, fmt.Errorf("imap starttls: %w", err)
+		}
+		c.conn = tlsConn
+		c.r = bufio.NewReader(tlsConn)
+	}
+	if err := c.capability(); err != nil {
+		c.conn.Close()
+		return nil, err
+	}
+	return c, nil
+}
+
+// readResponse reads one response line, following any literals it announces.
+func (c *imapClient) readResponse() (imapResponse, error) {
+	var resp imapResponse
+	var line strings.Builder
+	for {
+		part, err := c.r.ReadString('\n')
+		if err != nil {
+			return resp, err
+		}
+		part = strings.TrimRight(part, "\r\n")
+		line.WriteString(part)
+
+		size, ok := literalSize(part)
+		if !ok {
+			break
+		}
+		if size > maxIMAPLiteral {
+			return resp, fmt.Errorf("imap literal of %d bytes exceeds limit", size)
+		}
+		literal := make([]byte, size)
+		if _, err := io.ReadFull(c.r, literal); err != nil {
+			return resp, err
+		}
+		resp.literals = append(resp.literals, literal)
+	}
+	resp.line = line.String()
+	return resp, nil
+}
+
+// literalSize parses a trailing "{n}" or "{n+}" literal marker.
+func literalSize(line string) (int, bool) {
+	if !strings.HasSuffix(line, "}") {
+		return 0, false
+	}
+	open := strings.LastIndexByte(line, '{')
+	if open < 0 {
+		return 0, false
+	}
+	size, err := strconv.Atoi(strings.TrimSuffix(line[open+1:len(line)-1], "+"))
+	if err != nil || size < 0 {
+		return 0, false
+	}
+	return size, true
+}
+
+func (c *imapClient) nextTag() string {
+	c.tag++
+	return fmt.Sprintf("N%04d", c.tag)
+}
+
+// command sends one tagged command and collects the untagged responses
+// until its completion. NO and BAD completions are returned as errors.
+func (c *imapClient) command(format string, args ...any) ([]imapResponse, error) {
+	tag := c.nextTag()
+	cmd := fmt.Sprintf(format, args...)
+	if _, err := fmt.Fprintf(c.conn, "%s %s\r\n", tag, cmd); err != nil {
+		return nil, err
+	}
+	verb := strings.Fields(cmd)[0]
+	var untagged []imapResponse
+	for {
+		resp, err := c.readResponse()
+		if err != nil {
+			return nil, err
+		}
+		switch {
+		case strings.HasPrefix(resp.line, "+"):
+			// Only a failed SASL exchange asks for more here; an empty
+			// response makes the server send its tagged failure.
+			if _, err := io.WriteString(c.conn, "\r\n"); err != nil {
+				return nil, err
+			}
+		case strings.HasPrefix(resp.line, tag+" "):
+			status := strings.TrimPrefix(resp.line, tag+" ")
+			if !strings.HasPrefix(status, "OK") {
+				return untagged, fmt.Errorf("imap %s: %s", verb, status)
+			}
+			return untagged, nil
+		default:
+			untagged = append(untagged, resp)
+		}
+	}
+}
+
+func (c *imapClient) capability() error {
+	responses, err := c.command("CAPABILITY")
+	if err != nil {
+		return err
+	}
+	c.caps = make(map[string]bool)
+	for _, resp := range responses {
+		if !strings.HasPrefix(resp.line, "* CAPABILITY ") {
+			continue
+		}
+		for _, capability := range strings.Fields(strings.TrimPrefix(resp.line, "* CAPABILITY ")) {
+			c.caps[strings.ToUpper(capability)] = true
+		}
+	}
+	return nil
+}
+
+// login authenticates with a password.
+func (c *imapClient) login(username, password string) error {
+	if _, err := c.command("LOGIN %s %s", quoteIMAP(username), quoteIMAP(password)); err != nil {
+		return errors.New("imap login failed")
+	}
+	return nil
+}
+
+// authenticateXOAUTH2 authenticates with an OAuth2 access token.
+func (c *imapClient) authenticateXOAUTH2(username, token string) error {
+	if _, err := c.command("AUTHENTICATE XOAUTH2 %s", base64.StdEncoding.EncodeToString(xoauth2Response(username, token))); err != nil {
+		return errors.New("imap xoauth2 authentication failed")
+	}
+	return c.capability()
+}
+
+// selectFolder opens folder and returns its UIDVALIDITY and UIDNEXT.
+func (c *imapClient) selectFolder(folder string) (uidValidity, uidNext uint32, err error) {
+	responses, err := c.command("SELECT %s", quoteIMAP(folder))
+	if err != nil {
+		return 0, 0, err
+	}
+	for _, resp := range responses {
+		match := imapCodePattern.FindStringSubmatch(resp.line)
+		if match == nil {
+			continue
+		}
+		value, _ := strconv.ParseUint(match[2], 10, 32)
+		if match[1] == "UIDNEXT" {
+			uidNext = uint32(value)
+		} else {
+			uidValidity = uint32(value)
+		}
+	}
+	if uidNext == 0 {
+		// Servers may omit UIDNEXT; derive it from the highest UID.
+		uids, err := c.searchUIDs("ALL")
+		if err != nil {
+			return 0, 0, err
+		}
+		uidNext = 1
+		if len(uids) > 0 {
+			uidNext = uids[len(uids)-1] + 1
+		}
+	}
+	return uidValidity, uidNext, nil
+}
+
+// searchUIDs runs UID SEARCH and returns the matching UIDs in order.
+func (c *imapClient) searchUIDs(criteria string) ([]uint32, error) {
+	responses, err := c.command("UID SEARCH %s", criteria)
+	if err != nil {
+		return nil, err
+	}
+	var uids []uint32
+	for _, resp := range responses {
+		if !strings.HasPrefix(resp.line, "* SEARCH") {
+			continue
+		}
+		for _, field := range strings.Fields(strings.TrimPrefix(resp.line, "* SEARCH")) {
+			if uid, err := strconv.ParseUint(field, 10, 32); err == nil {
+				uids = append(uids, uint32(uid))
+			}
+		}
+	}
+	sort.Slice(uids, func(i, j int) bool { return uids[i] < uids[j] })
+	return uids, nil
+}
+
+// fetchMessage returns the raw RFC 5322 message with uid without marking
+// it read.
+func (c *imapClient) fetchMessage(uid uint32) ([]byte, error) {
+	responses, err := c.command("UID FETCH %d (UID BODY.PEEK[])", uid)
+	if err != nil {
+		return nil, err
+	}
+	for _, resp := range responses {
+		if !strings.Contains(resp.line, " FETCH ") || len(resp.literals) == 0 {
+			continue
+		}
+		if match := imapUIDPattern.FindStringSubmatch(resp.line); match != nil && match[1] != strconv.FormatUint(uint64(uid), 10) {
+			continue
+		}
+		return resp.literals[0], nil
+	}
+	return nil, fmt.Errorf("imap message %d not found", uid)
+}
+
+// markSeen sets \Seen on uid.
+func (c *imapClient) markSeen(uid uint32) error {
+	_, err := c.command(`UID STORE %d +FLAGS.SILENT (\Seen)`, uid)
+	return err
+}
+
+// noop lets the server report mailbox changes.
+func (c *imapClient) noop() error {
+	_, err := c.command("NOOP")
+	return err
+}
+
+// idle waits until the server reports new mail, timeout passes, or ctx is
+// done. It returns ctx.Err() when ctx ends the wait.
+func (c *imapClient) idle(ctx context.Context, timeout time.Duration) error {
+	tag := c.nextTag()
+	if _, err := fmt.Fprintf(c.conn, "%s IDLE\r\n", tag); err != nil {
+		return err
+	}
+	for {
+		resp, err := c.readResponse()
+		if err != nil {
+			return err
+		}
+		if strings.HasPrefix(resp.line, "+") {
+			break
+		}
+		if strings.HasPrefix(resp.line, tag+" ") {
+			return fmt.Errorf("imap IDLE: %s", strings.TrimPrefix(resp.line, tag+" "))
+		}
+	}
+
+	stop := make(chan struct{})
+	go func() {
+		select {
+		case <-ctx.Done():
+			_ = c.conn.SetReadDeadline(time.Now())
+		case <-stop:
+		}
+	}()
+	_ = c.conn.SetReadDeadline(time.Now().Add(timeout))
+	var waitErr error
+	for {
+		resp, err := c.readResponse()
+		if err != nil {
+			var netErr net.Error
+			if !errors.As(err, &netErr) || !netErr.Timeout() {
+				waitErr = err
+			}
+			break
+		}
+		fields := strings.Fields(resp.line)
+		if len(fields) >= 3 && fields[0] == "*" && (fields[2] == "EXISTS" || fields[2] == "RECENT") {
+			break
+		}
+	}
+	close(stop)
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	_ = c.conn.SetReadDeadline(time.Time{})
+	if waitErr != nil {
+		return waitErr
+	}
+
+	if _, err := io.WriteString(c.conn, "DONE\r\n"); err != nil {
+		return err
+	}
+	for {
+		resp, err := c.readResponse()
+		if err != nil {
+			return err
+		}
+		if strings.HasPrefix(resp.line, tag+" ") {
+			if status := strings.TrimPrefix(resp.line, tag+" "); !strings.HasPrefix(status, "OK") {
+				return fmt.Errorf("imap IDLE: %s", status)
+			}
+			return nil
+		}
+	}
+}
+
+// logout ends the session and closes the connection.
+func (c *imapClient) logout() {
+	_ = c.conn.SetDeadline(time.Now().Add(5 * time.Second))
+	_, _ = c.command("LOGOUT")
+	_ = c.conn.Close()
+}
+
+// quoteIMAP renders s as an IMAP quoted string.
+func quoteIMAP(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	return `"` + strings.ReplaceAll(s, `"`, `\"`) + `"`
+}
+
+// xoauth2Response is the SASL XOAUTH2 initial response used by IMAP and SMTP.
+func xoauth2Response(username, token string) []byte {
+	return []byte("user=" + username + "\x01auth=Bearer " + token + "\x01\x01")
+}
@@ -0,0 +1,493 @@
+package email
+
+import (
+	"bufio"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"net"
+	"net/textproto"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/haasonsaas/nexus/internal/artifacts"
+	"github.com/haasonsaas/nexus/pkg/models"
+)
+
+// fakeIMAPServer serves one mailbox with just enough IMAP for the adapter.
+type fakeIMAPServer struct {
+	ln       net.Listener
+	password string
+	token    string
+
+	mu       sync.Mutex
+	messages map[uint32][]byte
+	seen     map[uint32]bool
+	nextUID  uint32
+	changed  chan struct{}
+}
+
+func newFakeIMAPServer(t *testing.T) *fakeIMAPServer {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := &fakeIMAPServer{
+		ln:       ln,
+		password: "app-pass",
+		token:    "tok-1",
+		messages: make(map[uint32][]byte),
+		seen:     make(map[uint32]bool),
+		nextUID:  1,
+		changed:  make(chan struct{}),
+	}
+	t.Cleanup(func() { ln.Close() })
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go s.serve(conn)
+		}
+	}()
+	return s
+}
+
+func (s *fakeIMAPServer) port() int { return s.ln.Addr().(*net.TCPAddr).Port }
+
+// deliver appends a message and wakes idling sessions.
+func (s *fakeIMAPServer) deliver(raw string) uint32 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	uid := s.nextUID
+	s.nextUID++
+	s.messages[uid] = []byte(raw)
+	close(s.changed)
+	s.changed = make(chan struct{})
+	return uid
+}
+
+func (s *fakeIMAPServer) isSeen(uid uint32) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.seen[uid]
+}
+
+var fakeSearchPattern = regexp.MustCompile(`^UID SEARCH UID (\d+):\*( UNSEEN)?$`)
+
+func (s *fakeIMAPServer) serve(conn net.Conn) {
+	defer conn.Close()
+	r := bufio.NewReader(conn)
+	write := func(format string, args ...any) { fmt.Fprintf(conn, format+"\r\n", args...) }
+	write("* OK fake imap ready")
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return
+		}
+		tag, cmd, _ := strings.Cut(strings.TrimRight(line, "\r\n"), " ")
+		switch {
+		case cmd == "CAPABILITY":
+			write("* CAPABILITY IMAP4rev1 IDLE AUTH=XOAUTH2")
+			write("%s OK done", tag)
+		case strings.HasPrefix(cmd, "LOGIN "):
+			if cmd == fmt.Sprintf(`LOGIN "bot@example.com" %q`, s.password) {
+				write("%s OK logged in", tag)
+			} else {
+				write("%s NO invalid credentials", tag)
+			}
+		case strings.HasPrefix(cmd, "AUTHENTICATE XOAUTH2 "):
+			decoded, _ := base64.StdEncoding.DecodeString(strings.TrimPrefix(cmd, "AUTHENTICATE XOAUTH2 "))
+			if string(decoded) == "user=bot@example.com\x01auth=Bearer "+s.token+"\x01\x01" {
+				write("%s OK authenticated", tag)
+				continue
+			}
+			write("+ eyJzdGF0dXMiOiI0MDEifQ==")
+			if _, err := r.ReadString('\n'); err != nil {
+				return
+			}
+			write("%s NO authentication failed", tag)
+		case strings.HasPrefix(cmd, "SELECT "):
+			s.mu.Lock()
+			write("* %d EXISTS", len(s.messages))
+			write("* OK [UIDVALIDITY 7] ok")
+			write("* OK [UIDNEXT %d] ok", s.nextUID)
+			s.mu.Unlock()
+			write("%s OK [READ-WRITE] selected", tag)
+		case fakeSearchPattern.MatchString(cmd):
+			match := fakeSearchPattern.FindStringSubmatch(cmd)
+			from, _ := strconv.Atoi(match[1])
+			s.mu.Lock()
+			var uids []int
+			highest := 0
+			for uid := range s.messages {
+				highest = max(highest, int(uid))
+				if int(uid) >= from && (match[2] == "" || !s.seen[uid]) {
+					uids = append(uids, int(uid))
+				}
+			}
+			s.mu.Unlock()
+			if len(uids) == 0 && highest > 0 {
+				// "n:*" matches the highest UID even when it is below n.
+				uids = append(uids, highest)
+			}
+			sort.Ints(uids)
+			fields := make([]string, 0, len(uids))
+			for _, uid := range uids {
+				fields = append(fields, strconv.Itoa(uid))
+			}
+			write("* SEARCH %s", strings.Join(fields, " "))
+			write("%s OK search done", tag)
+		case strings.HasPrefix(cmd, "UID FETCH "):
+			uid, _ := strconv.Atoi(strings.Fields(cmd)[2])
+			s.mu.Lock()
+			raw := s.messages[uint32(uid)]
+			s.mu.Unlock()
+			fmt.Fprintf(conn, "* %d FETCH (UID %d BODY[] {%d}\r\n%s)\r\n", uid, uid, len(raw), raw)
+			write("%s OK fetch done", tag)
+		case strings.HasPrefix(cmd, "UID STORE "):
+			uid, _ := strconv.Atoi(strings.Fields(cmd)[2])
+			s.mu.Lock()
+			s.seen[uint32(uid)] = true
+			s.mu.Unlock()
+			write("%s OK store done", tag)
+		case cmd == "IDLE":
+			s.mu.Lock()
+			changed := s.changed
+			s.mu.Unlock()
+			write("+ idling")
+			done := make(chan error, 1)
+			go func() {
+				_, err := r.ReadString('\n')
+				done <- err
+			}()
+			select {
+			case <-changed:
+				s.mu.Lock()
+				write("* %d EXISTS", len(s.messages))
+				s.mu.Unlock()
+				if err := <-done; err != nil {
+					return
+				}
+			case err := <-done:
+				if err != nil {
+					return
+				}
+			}
+			write("%s OK idle done", tag)
+		case cmd == "NOOP":
+			write("%s OK noop", tag)
+		case cmd == "LOGOUT":
+			write("* BYE")
+			write("%s OK logout", tag)
+			return
+		default:
+			write("%s BAD unknown command", tag)
+		}
+	}
+}
+
+type fakeMail struct {
+	from  string
+	rcpts []string
+	data  string
+}
+
+// fakeSMTPServer accepts mail with AUTH PLAIN and records it.
+type fakeSMTPServer struct {
+	ln    net.Listener
+	mu    sync.Mutex
+	mails []fakeMail
+}
+
+func newFakeSMTPServer(t *testing.T) *fakeSMTPServer {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := &fakeSMTPServer{ln: ln}
+	t.Cleanup(func() { ln.Close() })
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go s.serve(textproto.NewConn(conn))
+		}
+	}()
+	return s
+}
+
+func (s *fakeSMTPServer) port() int { return s.ln.Addr().(*net.TCPAddr).Port }
+
+func (s *fakeSMTPServer) sent() []fakeMail {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]fakeMail(nil), s.mails...)
+}
+
+func (s *fakeSMTPServer) serve(conn *textproto.Conn) {
+	defer conn.Close()
+	var mail fakeMail
+	_ = conn.PrintfLine("220 fake smtp")
+	for {
+		line, err := conn.ReadLine()
+		if err != nil {
+			return
+		}
+		verb := strings.ToUpper(strings.Fields(line + " x")[0])
+		switch verb {
+		case "EHLO", "HELO":
+			_ = conn.PrintfLine("250-fake")
+			_ = conn.PrintfLine("250 AUTH PLAIN")
+		case "AUTH":
+			decoded, _ := base64.StdEncoding.DecodeString(strings.TrimPrefix(line, "AUTH PLAIN "))
+			if string(decoded) != "\x00bot@example.com\x00app-pass" {
+				_ = conn.PrintfLine("535 bad credentials")
+				continue
+			}
+			_ = conn.PrintfLine("235 ok")
+		case "MAIL":
+			mail = fakeMail{from: strings.Trim(strings.TrimPrefix(line, "MAIL FROM:"), "<>")}
+			_ = conn.PrintfLine("250 ok")
+		case "RCPT":
+			mail.rcpts = append(mail.rcpts, strings.Trim(strings.TrimPrefix(line, "RCPT TO:"), "<>"))
+			_ = conn.PrintfLine("250 ok")
+		case "DATA":
+			_ = conn.PrintfLine("354 go ahead")
+			data, err := io.ReadAll(conn.DotReader())
+			if err != nil {
+				return
+			}
+			mail.data = string(data)
+			s.mu.Lock()
+			s.mails = append(s.mails, mail)
+			s.mu.Unlock()
+			_ = conn.PrintfLine("250 queued")
+		case "QUIT":
+			_ = conn.PrintfLine("221 bye")
+			return
+		default:
+			_ = conn.PrintfLine("250 ok")
+		}
+	}
+}
+
+func testIMAPConfig(imapPort, smtpPort int) Config {
+	return Config{
+		Backend:        BackendIMAP,
+		Address:        "bot@example.com",
+		DisplayName:    "Nexus",
+		IMAP:           IMAPConfig{Host: "127.0.0.1", Port: imapPort, Security: SecurityNone, Password: "app-pass"},
+		SMTP:           SMTPConfig{Host: "127.0.0.1", Port: smtpPort, Security: SecurityNone},
+		AutoMarkRead:   true,
+		ReconnectDelay: 10 * time.Millisecond,
+	}
+}
+
+func receiveEmail(t *testing.T, adapter *IMAPAdapter) *models.Message {
+	t.Helper()
+	select {
+	case msg := <-adapter.Messages():
+		return msg
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for an email")
+		return nil
+	}
+}
+
+const reportEmail = "From: Alice <alice@example.com>\r\n" +
+	"To: bot@example.com\r\n" +
+	"Subject: =?utf-8?q?Quarterly_report?=\r\n" +
+	"Message-ID: <m2@example.com>\r\n" +
+	"In-Reply-To: <root@example.com>\r\n" +
+	"References: <root@example.com>\r\n" +
+	"MIME-Version: 1.0\r\n" +
+	"Content-Type: multipart/mixed; boundary=b1\r\n" +
+	"\r\n" +
+	"--b1\r\n" +
+	"Content-Type: text/plain; charset=utf-8\r\n" +
+	"Content-Transfer-Encoding: quoted-printable\r\n" +
+	"\r\n" +
+	"Numbers attached =E2=80=94 thoughts?\r\n" +
+	"--b1\r\n" +
+	"Content-Type: text/csv; name=q3.csv\r\n" +
+	"Content-Disposition: attachment; filename=q3.csv\r\n" +
+	"Content-Transfer-Encoding: base64\r\n" +
+	"\r\n" +
+	"cmV2ZW51ZSwxMDAK\r\n" +
+	"--b1--\r\n"
+
+func TestIMAPAdapterReceivesAndReplies(t *testing.T) {
+	imapServer := newFakeIMAPServer(t)
+	smtpServer := newFakeSMTPServer(t)
+	imapServer.deliver("From: old@example.com\r\nSubject: before start\r\nMessage-ID: <old@example.com>\r\n\r\nold\r\n")
+
+	adapter, err := NewIMAPAdapter(testIMAPConfig(imapServer.port(), smtpServer.port()))
+	if err != nil {
+		t.Fatalf("NewIMAPAdapter() error = %v", err)
+	}
+	store, err := artifacts.NewLocalStore(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	adapter.SetArtifactRepository(artifacts.NewMemoryRepository(store, nil))
+	ctx := context.Background()
+	if err := adapter.Start(ctx); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+	defer adapter.Stop(ctx)
+
+	uid := imapServer.deliver(reportEmail)
+	msg := receiveEmail(t, adapter)
+	if msg.Content != "Numbers attached — thoughts?" || msg.ChannelID != "email:alice@example.com" {
+		t.Fatalf("unexpected message %q from %q", msg.Content, msg.ChannelID)
+	}
+	if msg.Metadata["reply_to_message_id"] != "<m2@example.com>" || msg.Metadata["conversation_id"] != "<root@example.com>" ||
+		msg.Metadata["subject"] != "Quarterly report" {
+		t.Fatalf("unexpected metadata %v", msg.Metadata)
+	}
+	if len(msg.Attachments) != 1 || msg.Attachments[0].Filename != "q3.csv" {
+		t.Fatalf("attachments = %+v", msg.Attachments)
+	}
+	data, mimeType, _, err := adapter.DownloadAttachment(ctx, msg, &msg.Attachments[0])
+	if err != nil || string(data) != "revenue,100\n" || mimeType != "text/csv" {
+		t.Fatalf("DownloadAttachment() = %q, %q, %v", data, mimeType, err)
+	}
+	deadline := time.Now().Add(5 * time.Second)
+	for !imapServer.isSeen(uid) && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if !imapServer.isSeen(uid) {
+		t.Fatal("expected the email to be marked read")
+	}
+
+	// Automatic replies are ignored.
+	imapServer.deliver("From: alice@example.com\r\nAuto-Submitted: auto-replied\r\nSubject: Out of office\r\n\r\naway\r\n")
+	imapServer.deliver("From: Bob <bob@example.com>\r\nSubject: hello\r\nMessage-ID: <m4@example.com>\r\n\r\nhi\r\n")
+	if next := receiveEmail(t, adapter); next.Metadata["sender_email"] != "bob@example.com" {
+		t.Fatalf("expected the auto-reply to be skipped, got %v", next.Metadata)
+	}
+
+	reply := &models.Message{
+		ChannelID: msg.ChannelID,
+		Content:   "Looks good.",
+		Metadata: map[string]any{
+			"reply_to_message_id": msg.Metadata["reply_to_message_id"],
+			"subject":             msg.Metadata["subject"],
+			"email_references":    msg.Metadata["email_references"],
+		},
+	}
+	if err := adapter.Send(ctx, reply); err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+	sent := smtpServer.sent()
+	if len(sent) != 1 || sent[0].from != "bot@example.com" || strings.Join(sent[0].rcpts, ",") != "alice@example.com" {
+		t.Fatalf("sent = %+v", sent)
+	}
+	for _, want := range []string{
+		"Subject: Re: Quarterly report",
+		"In-Reply-To: <m2@example.com>",
+		"References: <root@example.com> <m2@example.com>",
+		`From: "Nexus" <bot@example.com>`,
+	} {
+		if !strings.Contains(sent[0].data, want) {
+			t.Fatalf("reply is missing %q:\n%s", want, sent[0].data)
+		}
+	}
+
+	messageID, err := adapter.Compose(ctx, Draft{
+		To:      []string{"Carol <carol@example.com>"},
+		Cc:      []string{"dave@example.com"},
+		Subject: "Follow-up",
+		Body:    "See you Monday.",
+	})
+	if err != nil || !strings.HasSuffix(messageID, "@example.com>") {
+		t.Fatalf("Compose() = %q, %v", messageID, err)
+	}
+	sent = smtpServer.sent()
+	if len(sent) != 2 || strings.Join(sent[1].rcpts, ",") != "carol@example.com,dave@example.com" ||
+		!strings.Contains(sent[1].data, "Message-ID: "+messageID) {
+		t.Fatalf("composed mail = %+v", sent[1:])
+	}
+}
+
+func TestIMAPAdapterXOAUTH2(t *testing.T) {
+	imapServer := newFakeIMAPServer(t)
+	cfg := testIMAPConfig(imapServer.port(), 25)
+	cfg.IMAP.Password = ""
+	cfg.AccessToken = "tok-1"
+	adapter, err := NewIMAPAdapter(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if status := adapter.HealthCheck(context.Background()); !status.Healthy {
+		t.Fatalf("HealthCheck() = %+v", status)
+	}
+
+	cfg.AccessToken = "expired"
+	adapter, err = NewIMAPAdapter(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := adapter.Start(context.Background()); err == nil || !strings.Contains(err.Error(), "xoauth2") {
+		t.Fatalf("expected a rejected token to fail Start, got %v", err)
+	}
+}
+
+func TestIMAPConfigValidate(t *testing.T) {
+	cfg := Config{Backend: "IMAP", Address: "bot@example.com", IMAP: IMAPConfig{Host: "imap.example.com", Password: "pw"}, SMTP: SMTPConfig{Host: "smtp.example.com"}}
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+	if cfg.IMAP.Port != 993 || cfg.SMTP.Port != 587 || cfg.SMTP.Username != "bot@example.com" || cfg.SMTP.Password != "pw" || cfg.IMAP.IdleTimeout != 25*time.Minute {
+		t.Fatalf("defaults not applied: %+v", cfg)
+	}
+
+	for name, cfg := range map[string]Config{
+		"missing address":    {Backend: BackendIMAP, IMAP: IMAPConfig{Host: "h", Password: "pw"}, SMTP: SMTPConfig{Host: "h"}},
+		"missing smtp":       {Backend: BackendIMAP, Address: "a@b.c", IMAP: IMAPConfig{Host: "h", Password: "pw"}},
+		"missing auth":       {Backend: BackendIMAP, Address: "a@b.c", IMAP: IMAPConfig{Host: "h"}, SMTP: SMTPConfig{Host: "h"}},
+		"refresh without id": {Backend: BackendIMAP, Address: "a@b.c", RefreshToken: "r", TokenURL: "https://t", IMAP: IMAPConfig{Host: "h"}, SMTP: SMTPConfig{Host: "h"}},
+		"bad security":       {Backend: BackendIMAP, Address: "a@b.c", IMAP: IMAPConfig{Host: "h", Password: "pw", Security: "ssl"}, SMTP: SMTPConfig{Host: "h"}},
+		"unknown backend":    {Backend: "pop3"},
+	} {
+		if err := cfg.Validate(); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestParseEmailFallbacks(t *testing.T) {
+	raw := "From: =?iso-8859-1?q?Ren=E9?= <Rene@Example.com>\r\n" +
+		"Subject: =?iso-8859-1?q?Caf=E9?=\r\n" +
+		"Message-ID: <x@example.com>\r\n" +
+		"Content-Type: multipart/alternative; boundary=alt\r\n" +
+		"\r\n" +
+		"--alt\r\n" +
+		"Content-Type: text/html; charset=iso-8859-1\r\n" +
+		"Content-Transfer-Encoding: quoted-printable\r\n" +
+		"\r\n" +
+		"<p>Tr=E8s bien</p>\r\n" +
+		"--alt--\r\n"
+	email, err := parseEmail([]byte(raw))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if email.FromName != "René" || email.FromAddress != "rene@example.com" || email.Subject != "Café" {
+		t.Fatalf("headers = %q %q %q", email.FromName, email.FromAddress, email.Subject)
+	}
+	if email.Body() != "Très bien" || email.ThreadID() != "<x@example.com>" || len(email.Attachments) != 0 {
+		t.Fatalf("body = %q, thread = %q, attachments = %d", email.Body(), email.ThreadID(), len(email.Attachments))
+	}
+}
@@ -0,0 +1,326 @@
+package email
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"net/textproto"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"golang.org/x/text/encoding/htmlindex"
+)
+
+// maxMIMEDepth bounds nested multiparts in inbound mail.
+const maxMIMEDepth = 10
+
+// inboundEmail is the part of an RFC 5322 message the channel uses.
+type inboundEmail struct {
+	MessageID   string
+	InReplyTo   string
+	References  []string
+	FromName    string
+	FromAddress string
+	Subject     string
+	Date        time.Time
+	// AutoSubmitted is the Auto-Submitted header of automatic replies.
+	AutoSubmitted string
+	Text          string
+	HTML          string
+	Attachments   []emailAttachment
+}
+
+// emailAttachment is a file carried by a message.
+type emailAttachment struct {
+	Filename    string
+	ContentType string
+	Data        []byte
+}
+
+// Body returns the plain text body, falling back to the HTML body without tags.
+func (e *inboundEmail) Body() string {
+	if strings.TrimSpace(e.Text) != "" {
+		return strings.TrimSpace(e.Text)
+	}
+	return strings.TrimSpace(stripHTMLTags(e.HTML))
+}
+
+// ThreadID identifies the conversation: the first message of References,
+// else the message replied to, else the message itself.
+func (e *inboundEmail) ThreadID() string {
+	if len(e.References) > 0 {
+		return e.References[0]
+	}
+	if e.InReplyTo != "" {
+		return e.InReplyTo
+	}
+	return e.MessageID
+}
+
+var headerDecoder = &mime.WordDecoder{CharsetReader: charsetReader}
+
+// charsetReader converts text in charset to UTF-8.
+func charsetReader(charset string, input io.Reader) (io.Reader, error) {
+	switch strings.ToLower(charset) {
+	case "", "utf-8", "us-ascii":
+		return input, nil
+	}
+	encoding, err := htmlindex.Get(charset)
+	if err != nil {
+		return nil, err
+	}
+	return encoding.NewDecoder().Reader(input), nil
+}
+
+// parseEmail parses a raw message into its headers, body, and attachments.
+func parseEmail(raw []byte) (*inboundEmail, error) {
+	msg, err := mail.ReadMessage(bytes.NewReader(raw))
+	if err != nil {
+		return nil, fmt.Errorf("parse email: %w", err)
+	}
+	header := msg.Header
+	email := &inboundEmail{
+		MessageID:     firstMessageID(header.Get("Message-Id")),
+		InReplyTo:     firstMessageID(header.Get("In-Reply-To")),
+		References:    messageIDs(header.Get("References")),
+		AutoSubmitted: strings.ToLower(strings.TrimSpace(header.Get("Auto-Submitted"))),
+	}
+	if subject, err := headerDecoder.DecodeHeader(header.Get("Subject")); err == nil {
+		email.Subject = subject
+	} else {
+		email.Subject = header.Get("Subject")
+	}
+	parser := mail.AddressParser{WordDecoder: headerDecoder}
+	if from, err := parser.Parse(header.Get("From")); err == nil {
+		email.FromName = from.Name
+		email.FromAddress = strings.ToLower(from.Address)
+	}
+	if date, err := header.Date(); err == nil {
+		email.Date = date
+	} else {
+		email.Date = time.Now()
+	}
+
+	if err := email.walkPart(textproto.MIMEHeader(header), msg.Body, 0); err != nil {
+		return nil, err
+	}
+	return email, nil
+}
+
+// walkPart collects the text bodies and attachments of one MIME part.
+func (e *inboundEmail) walkPart(header textproto.MIMEHeader, body io.Reader, depth int) error {
+	mediaType, params, err := mime.ParseMediaType(header.Get("Content-Type"))
+	if err != nil {
+		mediaType, params = "text/plain", map[string]string{}
+	}
+	body = transferDecoder(header.Get("Content-Transfer-Encoding"), body)
+
+	if strings.HasPrefix(mediaType, "multipart/") {
+		if depth >= maxMIMEDepth {
+			return nil
+		}
+		reader := multipart.NewReader(body, params["boundary"])
+		for {
+			part, err := reader.NextRawPart()
+			if err == io.EOF {
+				return nil
+			}
+			if err != nil {
+				return fmt.Errorf("parse email part: %w", err)
+			}
+			if err := e.walkPart(part.Header, part, depth+1); err != nil {
+				return err
+			}
+		}
+	}
+
+	disposition, dispositionParams, _ := mime.ParseMediaType(header.Get("Content-Disposition"))
+	filename := dispositionParams["filename"]
+	if filename == "" {
+		filename = params["name"]
+	}
+	if decoded, err := headerDecoder.DecodeHeader(filename); err == nil {
+		filename = decoded
+	}
+	data, err := io.ReadAll(io.LimitReader(body, maxIMAPLiteral))
+	if err != nil {
+		return fmt.Errorf("read email part: %w", err)
+	}
+
+	isText := mediaType == "text/plain" || mediaType == "text/html"
+	if disposition == "attachment" || filename != "" || !isText {
+		e.Attachments = append(e.Attachments, emailAttachment{
+			Filename:    filename,
+			ContentType: mediaType,
+			Data:        data,
+		})
+		return nil
+	}
+	if reader, err := charsetReader(params["charset"], bytes.NewReader(data)); err == nil {
+		if converted, err := io.ReadAll(reader); err == nil {
+			data = converted
+		}
+	}
+	if mediaType == "text/html" {
+		if e.HTML == "" {
+			e.HTML = string(data)
+		}
+	} else if e.Text == "" {
+		e.Text = string(data)
+	}
+	return nil
+}
+
+func transferDecoder(encoding string, body io.Reader) io.Reader {
+	switch strings.ToLower(strings.TrimSpace(encoding)) {
+	case "base64":
+		return base64.NewDecoder(base64.StdEncoding, body)
+	case "quoted-printable":
+		return quotedprintable.NewReader(body)
+	default:
+		return body
+	}
+}
+
+// messageIDs extracts the <id> tokens of a Message-ID list header.
+func messageIDs(value string) []string {
+	var ids []string
+	for {
+		start := strings.IndexByte(value, '<')
+		if start < 0 {
+			return ids
+		}
+		end := strings.IndexByte(value[start:], '>')
+		if end < 0 {
+			return ids
+		}
+		ids = append(ids, value[start:start+end+1])
+		value = value[start+end+1:]
+	}
+}
+
+func firstMessageID(value string) string {
+	if ids := messageIDs(value); len(ids) > 0 {
+		return ids[0]
+	}
+	return strings.TrimSpace(value)
+}
+
+// outboundEmail is a message composed for delivery over SMTP.
+type outboundEmail struct {
+	From        mail.Address
+	To          []mail.Address
+	Cc          []mail.Address
+	Subject     string
+	Body        string
+	InReplyTo   string
+	References  []string
+	Attachments []emailAttachment
+}
+
+// Recipients lists every envelope recipient.
+func (e *outboundEmail) Recipients() []string {
+	recipients := make([]string, 0, len(e.To)+len(e.Cc))
+	for _, addr := range append(append([]mail.Address{}, e.To...), e.Cc...) {
+		recipients = append(recipients, addr.Address)
+	}
+	return recipients
+}
+
+// Bytes renders the message and returns it with its Message-ID.
+func (e *outboundEmail) Bytes(now time.Time) ([]byte, string) {
+	domain := "localhost"
+	if at := strings.LastIndexByte(e.From.Address, '@'); at >= 0 {
+		domain = e.From.Address[at+1:]
+	}
+	messageID := "<" + uuid.NewString() + "@" + domain + ">"
+
+	var buf bytes.Buffer
+	writeHeader := func(name, value string) {
+		if value != "" {
+			fmt.Fprintf(&buf, "%s: %s\r\n", name, value)
+		}
+	}
+	writeHeader("From", e.From.String())
+	writeHeader("To", formatAddresses(e.To))
+	writeHeader("Cc", formatAddresses(e.Cc))
+	writeHeader("Subject", mime.QEncoding.Encode("utf-8", e.Subject))
+	writeHeader("Date", now.Format(time.RFC1123Z))
+	writeHeader("Message-ID", messageID)
+	writeHeader("In-Reply-To", e.InReplyTo)
+	writeHeader("References", strings.Join(e.References, " "))
+	writeHeader("MIME-Version", "1.0")
+
+	if len(e.Attachments) == 0 {
+		writeHeader("Content-Type", "text/plain; charset=utf-8")
+		writeHeader("Content-Transfer-Encoding", "quoted-printable")
+		buf.WriteString("\r\n")
+		writeQuotedPrintable(&buf, e.Body)
+		return buf.Bytes(), messageID
+	}
+
+	writer := multipart.NewWriter(&buf)
+	writeHeader("Content-Type", "multipart/mixed; boundary="+writer.Boundary())
+	buf.WriteString("\r\n")
+	textPart, _ := writer.CreatePart(textproto.MIMEHeader{
+		"Content-Type":              {"text/plain; charset=utf-8"},
+		"Content-Transfer-Encoding": {"quoted-printable"},
+	})
+	writeQuotedPrintable(textPart, e.Body)
+	for _, att := range e.Attachments {
+		contentType := att.ContentType
+		if contentType == "" {
+			contentType = "application/octet-stream"
+		}
+		part, _ := writer.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {mime.FormatMediaType(contentType, map[string]string{"name": att.Filename})},
+			"Content-Disposition":       {mime.FormatMediaType("attachment", map[string]string{"filename": att.Filename})},
+			"Content-Transfer-Encoding": {"base64"},
+		})
+		writeBase64Lines(part, att.Data)
+	}
+	_ = writer.Close()
+	return buf.Bytes(), messageID
+}
+
+func formatAddresses(addrs []mail.Address) string {
+	formatted := make([]string, 0, len(addrs))
+	for _, addr := range addrs {
+		formatted = append(formatted, addr.String())
+	}
+	return strings.Join(formatted, ", ")
+}
+
+func writeQuotedPrintable(w io.Writer, text string) {
+	qp := quotedprintable.NewWriter(w)
+	_, _ = io.WriteString(qp, strings.ReplaceAll(strings.ReplaceAll(text, "\r\n", "\n"), "\n", "\r\n"))
+	_ = qp.Close()
+}
+
+// writeBase64Lines writes data as base64 wrapped at 76 columns.
+func writeBase64Lines(w io.Writer, data []byte) {
+	encoded := base64.StdEncoding.EncodeToString(data)
+	for len(encoded) > 76 {
+		_, _ = io.WriteString(w, encoded[:76]+"\r\n")
+		encoded = encoded[76:]
+	}
+	_, _ = io.WriteString(w, encoded+"\r\n")
+}
+
+// replySubject prefixes subject with "Re: " unless it already has it.
+func replySubject(subject string) string {
+	subject = strings.TrimSpace(subject)
+	if subject == "" {
+		return "Re: your message"
+	}
+	if strings.HasPrefix(strings.ToLower(subject), "re:") {
+		return subject
+	}
+	return "Re: " + subject
+}
@@ -0,0 +1,114 @@
+package email
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"net/smtp"
+	"strconv"
+	"time"
+)
+
+// smtpTimeout bounds one delivery when ctx has no earlier deadline.
+const smtpTimeout = time.Minute
+
+// sendSMTP delivers msg from from to recipients. token, when set, is used
+// for XOAUTH2 instead of the configured password.
+func sendSMTP(ctx context.Context, cfg SMTPConfig, token, from string, recipients []string, msg []byte) error {
+	if len(recipients) == 0 {
+		return errors.New("no recipients")
+	}
+	addr := net.JoinHostPort(cfg.Host, strconv.Itoa(cfg.Port))
+	dialer := &net.Dialer{Timeout: 30 * time.Second}
+	tlsConfig := &tls.Config{ServerName: cfg.Host, MinVersion: tls.VersionTLS12}
+	var (
+		conn net.Conn
+		err  error
+	)
+	if cfg.Security == SecurityTLS {
+		conn, err = (&tls.Dialer{NetDialer: dialer, Config: tlsConfig}).DialContext(ctx, "tcp", addr)
+	} else {
+		conn, err = dialer.DialContext(ctx, "tcp", addr)
+	}
+	if err != nil {
+		return fmt.Errorf("dial smtp: %w", err)
+	}
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		deadline = time.Now().Add(smtpTimeout)
+	}
+	_ = conn.SetDeadline(deadline)
+
+	client, err := smtp.NewClient(conn, cfg.Host)
+	if err != nil {
+		conn.Close()
+		return fmt.Errorf("smtp greeting: %w", err)
+	}
+	defer client.Close()
+
+	if cfg.Security == SecurityStartTLS {
+		if ok, _ := client.Extension("STARTTLS"); !ok {
+			return errors.New("smtp server does not offer STARTTLS")
+		}
+		if err := client.StartTLS(tlsConfig); err != nil {
+			return fmt.Errorf("smtp starttls: %w", err)
+		}
+	}
+	if ok, _ := client.Extension("AUTH"); ok {
+		var auth smtp.Auth
+		switch {
+		case token != "":
+			auth = xoauth2Auth{username: cfg.Username, token: token}
+		case cfg.Password != "":
+			auth = smtp.PlainAuth("", cfg.Username, cfg.Password, cfg.Host)
+		}
+		if auth != nil {
+			if err := client.Auth(auth); err != nil {
+				return fmt.Errorf("smtp auth: %w", err)
+			}
+		}
+	}
+
+	if err := client.Mail(from); err != nil {
+		return fmt.Errorf("smtp MAIL FROM: %w", err)
+	}
+	for _, rcpt := range recipients {
+		if err := client.Rcpt(rcpt); err != nil {
+			return fmt.Errorf("smtp RCPT TO %s: %w", rcpt, err)
+		}
+	}
+	w, err := client.Data()
+	if err != nil {
+		return fmt.Errorf("smtp DATA: %w", err)
+	}
+	if _, err := w.Write(msg); err != nil {
+		return fmt.Errorf("smtp DATA: %w", err)
+	}
+	if err := w.Close(); err != nil {
+		return fmt.Errorf("smtp DATA: %w", err)
+	}
+	return client.Quit()
+}
+
+// xoauth2Auth implements smtp.Auth for the XOAUTH2 mechanism.
+type xoauth2Auth struct {
+	username string
+	token    string
+}
+
+func (a xoauth2Auth) Start(server *smtp.ServerInfo) (string, []byte, error) {
+	if !server.TLS {
+		return "", nil, errors.New("xoauth2 requires an encrypted connection")
+	}
+	return "XOAUTH2", xoauth2Response(a.username, a.token), nil
+}
+
+func (a xoauth2Auth) Next(fromServer []byte, more bool) ([]byte, error) {
+	if more {
+		// The server sent an error challenge; an empty reply ends the exchange.
+		return []byte{}, nil
+	}
+	return nil, nil
+}
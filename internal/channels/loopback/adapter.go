@@ -0,0 +1,273 @@
+// Package loopback provides an in-process channel adapter that lets local
+// harnesses (benchmarks, terminal chat) drive the full gateway pipeline without
+// a messaging platform.
+package loopback
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/haasonsaas/nexus/internal/channels"
+	"github.com/haasonsaas/nexus/pkg/models"
+)
+
+const (
+	// MetaConversationID is the metadata key carrying the loopback conversation ID.
+	// The gateway copies it onto replies so callers can correlate responses.
+	MetaConversationID = "peer_id"
+
+	defaultBufferSize = 256
+)
+
+// ErrStopped is returned when injecting into a stopped adapter.
+var ErrStopped = errors.New("loopback: adapter stopped")
+
+// Config configures the loopback adapter.
+type Config struct {
+	// BufferSize is the capacity of the inbound and reply queues (default 256).
+	BufferSize int
+
+	// Logger is an optional logger for adapter diagnostics.
+	Logger *slog.Logger
+
+	// Streaming enables streaming responses. Partial and final streamed
+	// content is delivered on Updates instead of Replies.
+	Streaming bool
+}
+
+// Update is a streamed response update.
+type Update struct {
+	// ConversationID identifies the loopback conversation.
+	ConversationID string
+	// StreamID identifies the streamed response.
+	StreamID string
+	// Content is the full response text accumulated so far.
+	Content string
+	// Final is true for the last update of a response.
+	Final bool
+	// Message is the outbound message template supplied by the gateway.
+	Message *models.Message
+}
+
+// Adapter is an in-memory channel adapter. Messages passed to Inject are
+// emitted to the gateway, and replies sent by the gateway are delivered on
+// Replies.
+//
+// Thread Safety:
+// Adapter is safe for concurrent use.
+type Adapter struct {
+	inbound chan *models.Message
+	replies chan *models.Message
+	updates chan Update
+	health  *channels.BaseHealthAdapter
+	logger  *slog.Logger
+
+	streaming bool
+
+	mu      sync.RWMutex
+	stopped bool
+}
+
+// New creates a loopback adapter.
+func New(cfg Config) *Adapter {
+	size := cfg.BufferSize
+	if size <= 0 {
+		size = defaultBufferSize
+	}
+	logger := cfg.Logger
+	if logger == nil {
+		logger = slog.Default()
+	}
+	logger = logger.With("adapter", "loopback")
+	return &Adapter{
+		inbound:   make(chan *models.Message, size),
+		replies:   make(chan *models.Message, size),
+		updates:   make(chan Update, size),
+		health:    channels.NewBaseHealthAdapter(models.ChannelLoopback, logger),
+		logger:    logger,
+		streaming: cfg.Streaming,
+	}
+}
+
+// Type returns the channel type.
+func (a *Adapter) Type() models.ChannelType {
+	return models.ChannelLoopback
+}
+
+// Start marks the adapter as connected.
+func (a *Adapter) Start(ctx context.Context) error {
+	a.health.SetStatus(true, "")
+	return nil
+}
+
+// Stop marks the adapter as disconnected and rejects further injections.
+func (a *Adapter) Stop(ctx context.Context) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if !a.stopped {
+		a.stopped = true
+		close(a.inbound)
+	}
+	a.health.SetStatus(false, "")
+	return nil
+}
+
+// Messages returns the inbound message stream consumed by the gateway.
+func (a *Adapter) Messages() <-chan *models.Message {
+	return a.inbound
+}
+
+// Replies returns the stream of outbound messages sent by the gateway.
+func (a *Adapter) Replies() <-chan *models.Message {
+	return a.replies
+}
+
+// Updates returns the stream of streamed response updates. It only carries
+// data when the adapter was created with Streaming enabled.
+func (a *Adapter) Updates() <-chan Update {
+	return a.updates
+}
+
+// QueueDepth returns the number of injected messages not yet picked up by the gateway.
+func (a *Adapter) QueueDepth() int {
+	return len(a.inbound)
+}
+
+// Inject enqueues a user message for the given conversation and returns it.
+// It blocks when the inbound queue is full until ctx is done.
+func (a *Adapter) Inject(ctx context.Context, conversationID, content string) (*models.Message, error) {
+	msg := &models.Message{
+		ID:        uuid.NewString(),
+		Channel:   models.ChannelLoopback,
+		ChannelID: conversationID,
+		Direction: models.DirectionInbound,
+		Role:      models.RoleUser,
+		Content:   content,
+		Metadata: map[string]any{
+			MetaConversationID:  conversationID,
+			"conversation_type": "dm",
+		},
+		CreatedAt: time.Now(),
+	}
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	if a.stopped {
+		return nil, ErrStopped
+	}
+	select {
+	case a.inbound <- msg:
+		a.health.RecordMessageReceived()
+		return msg, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// Send delivers an outbound message to Replies.
+func (a *Adapter) Send(ctx context.Context, msg *models.Message) error {
+	if msg == nil {
+		return errors.New("loopback: message is nil")
+	}
+	start := time.Now()
+	select {
+	case a.replies <- msg:
+		a.health.RecordMessageSent()
+		a.health.RecordSendLatency(time.Since(start))
+		return nil
+	case <-ctx.Done():
+		a.health.RecordMessageFailed()
+		return ctx.Err()
+	}
+}
+
+// SendTypingIndicator is a no-op; terminal clients render their own progress.
+func (a *Adapter) SendTypingIndicator(ctx context.Context, msg *models.Message) error {
+	if !a.streaming {
+		return channels.ErrNotSupported
+	}
+	return nil
+}
+
+// StartStreamingResponse begins a streamed response and returns its ID.
+func (a *Adapter) StartStreamingResponse(ctx context.Context, msg *models.Message) (string, error) {
+	if !a.streaming {
+		return "", channels.ErrStreamingNotSupported
+	}
+	id := uuid.NewString()
+	if err := a.publish(ctx, Update{ConversationID: ConversationID(msg), StreamID: id, Message: msg}); err != nil {
+		return "", err
+	}
+	return id, nil
+}
+
+// UpdateStreamingResponse publishes the accumulated content of a streamed
+// response. The gateway sets msg.Content only on the final update, which is
+// reported with Final set.
+func (a *Adapter) UpdateStreamingResponse(ctx context.Context, msg *models.Message, messageID string, content string) error {
+	if !a.streaming {
+		return channels.ErrStreamingNotSupported
+	}
+	final := msg != nil && msg.Content != ""
+	if err := a.publish(ctx, Update{
+		ConversationID: ConversationID(msg),
+		StreamID:       messageID,
+		Content:        content,
+		Final:          final,
+		Message:        msg,
+	}); err != nil {
+		return err
+	}
+	if final {
+		a.health.RecordMessageSent()
+	}
+	return nil
+}
+
+func (a *Adapter) publish(ctx context.Context, update Update) error {
+	select {
+	case a.updates <- update:
+		return nil
+	case <-ctx.Done():
+		a.health.RecordMessageFailed()
+		return ctx.Err()
+	}
+}
+
+// Status returns the current connection status.
+func (a *Adapter) Status() channels.Status {
+	return a.health.Status()
+}
+
+// HealthCheck always reports healthy while the adapter is running.
+func (a *Adapter) HealthCheck(ctx context.Context) channels.HealthStatus {
+	a.mu.RLock()
+	stopped := a.stopped
+	a.mu.RUnlock()
+	status := channels.HealthStatus{Healthy: !stopped, LastCheck: time.Now()}
+	if stopped {
+		status.Message = "stopped"
+	}
+	return status
+}
+
+// Metrics returns adapter metrics.
+func (a *Adapter) Metrics() channels.MetricsSnapshot {
+	return a.health.Metrics()
+}
+
+// ConversationID returns the loopback conversation ID carried by a message.
+func ConversationID(msg *models.Message) string {
+	if msg == nil {
+		return ""
+	}
+	if msg.Metadata != nil {
+		if id, ok := msg.Metadata[MetaConversationID].(string); ok && id != "" {
+			return id
+		}
+	}
+	return msg.ChannelID
+}
@@ -0,0 +1,90 @@
+package loopback
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/haasonsaas/nexus/internal/channels"
+	"github.com/haasonsaas/nexus/pkg/models"
+)
+
+func TestInjectAndReply(t *testing.T) {
+	adapter := New(Config{BufferSize: 2})
+	ctx := context.Background()
+	if err := adapter.Start(ctx); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+
+	msg, err := adapter.Inject(ctx, "conv-1", "hello")
+	if err != nil {
+		t.Fatalf("Inject: %v", err)
+	}
+	if adapter.QueueDepth() != 1 {
+		t.Fatalf("queue depth = %d, want 1", adapter.QueueDepth())
+	}
+	got := <-adapter.Messages()
+	if got != msg || got.Channel != models.ChannelLoopback || ConversationID(got) != "conv-1" {
+		t.Fatalf("unexpected inbound message: %+v", got)
+	}
+
+	reply := &models.Message{Content: "hi", Metadata: map[string]any{MetaConversationID: "conv-1"}}
+	if err := adapter.Send(ctx, reply); err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+	if out := <-adapter.Replies(); ConversationID(out) != "conv-1" {
+		t.Fatalf("reply conversation = %q", ConversationID(out))
+	}
+	if snap := adapter.Metrics(); snap.MessagesSent != 1 || snap.MessagesReceived != 1 {
+		t.Fatalf("metrics = %+v", snap)
+	}
+}
+
+func TestInjectAfterStop(t *testing.T) {
+	adapter := New(Config{})
+	if err := adapter.Stop(context.Background()); err != nil {
+		t.Fatalf("Stop: %v", err)
+	}
+	if _, err := adapter.Inject(context.Background(), "c", "x"); !errors.Is(err, ErrStopped) {
+		t.Fatalf("Inject after stop err = %v, want ErrStopped", err)
+	}
+	if adapter.HealthCheck(context.Background()).Healthy {
+		t.Fatal("expected unhealthy after stop")
+	}
+}
+
+func TestStreamingUpdates(t *testing.T) {
+	ctx := context.Background()
+	plain := New(Config{})
+	if _, err := plain.StartStreamingResponse(ctx, &models.Message{}); !errors.Is(err, channels.ErrStreamingNotSupported) {
+		t.Fatalf("StartStreamingResponse without streaming err = %v", err)
+	}
+
+	adapter := New(Config{Streaming: true})
+	tmpl := &models.Message{Metadata: map[string]any{MetaConversationID: "conv-1"}}
+	id, err := adapter.StartStreamingResponse(ctx, tmpl)
+	if err != nil {
+		t.Fatalf("StartStreamingResponse: %v", err)
+	}
+	if err := adapter.UpdateStreamingResponse(ctx, tmpl, id, "hel"); err != nil {
+		t.Fatalf("UpdateStreamingResponse: %v", err)
+	}
+	final := &models.Message{Content: "hello", Metadata: tmpl.Metadata}
+	if err := adapter.UpdateStreamingResponse(ctx, final, id, "hello"); err != nil {
+		t.Fatalf("UpdateStreamingResponse final: %v", err)
+	}
+
+	var updates []Update
+	for i := 0; i < 3; i++ {
+		updates = append(updates, <-adapter.Updates())
+	}
+	if updates[0].StreamID != id || updates[0].ConversationID != "conv-1" || updates[0].Content != "" {
+		t.Fatalf("unexpected start update: %+v", updates[0])
+	}
+	if updates[1].Content != "hel" || updates[1].Final {
+		t.Fatalf("unexpected partial update: %+v", updates[1])
+	}
+	if updates[2].Content != "hello" || !updates[2].Final {
+		t.Fatalf("unexpected final update: %+v", updates[2])
+	}
+}
@@ -300,8 +300,9 @@ func (a *Adapter) syncLoop(ctx context.Context) {
 			Factor:       2,
 			Jitter:       true,
 		},
-		Logger: a.logger,
-		Health: a.health,
+		Logger:    a.logger,
+		Health:    a.health,
+		Component: "channel:matrix",
 	}
 
 	attempt := 0
@@ -0,0 +1,27 @@
+package channels
+
+import "github.com/haasonsaas/nexus/pkg/models"
+
+// MentionedMetadataKey is the inbound message metadata key adapters set to
+// true when the message mentions the bot or replies to one of its messages.
+const MentionedMetadataKey = "mentioned"
+
+// SetMentioned marks msg as addressed to the bot.
+func SetMentioned(msg *models.Message) {
+	if msg == nil {
+		return
+	}
+	if msg.Metadata == nil {
+		msg.Metadata = map[string]any{}
+	}
+	msg.Metadata[MentionedMetadataKey] = true
+}
+
+// WasMentioned reports whether an adapter marked msg as addressed to the bot.
+func WasMentioned(msg *models.Message) bool {
+	if msg == nil || msg.Metadata == nil {
+		return false
+	}
+	mentioned, _ := msg.Metadata[MentionedMetadataKey].(bool)
+	return mentioned
+}
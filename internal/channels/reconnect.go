@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/haasonsaas/nexus/internal/retry"
+	"github.com/haasonsaas/nexus/internal/supervisor"
 )
 
 // ReconnectConfig controls reconnection behavior.
@@ -34,10 +35,15 @@ type Reconnector struct {
 	Config ReconnectConfig
 	Logger *slog.Logger
 	Health *BaseHealthAdapter
+
+	// Component names the adapter in crash reports, e.g. "channel:telegram".
+	Component string
 }
 
 // Run executes the provided function until it succeeds, the context is canceled,
-// or max attempts are reached. It returns the last error.
+// or max attempts are reached. It returns the last error. A panic in run is
+// reported and treated as a failed attempt, so the adapter reconnects with
+// backoff instead of crashing the process.
 func (r *Reconnector) Run(ctx context.Context, run func(context.Context) error) error {
 	if run == nil {
 		return errors.New("reconnector: run func is nil")
@@ -56,12 +62,17 @@ func (r *Reconnector) Run(ctx context.Context, run func(context.Context) error)
 		cfg.Factor = DefaultReconnectConfig().Factor
 	}
 
+	component := r.Component
+	if component == "" {
+		component = "channel"
+	}
+
 	attempt := 0
 	for {
 		if ctx.Err() != nil {
 			return ctx.Err()
 		}
-		if err := run(ctx); err == nil {
+		if err := supervisor.Catch(component, func() error { return run(ctx) }); err == nil {
 			return nil
 		} else {
 			if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
@@ -52,6 +52,38 @@ func TestAggregateMessagesUsesInboundAdapters(t *testing.T) {
 	}
 }
 
+type reactionOnlyAdapter struct {
+	reactions chan *ReactionEvent
+}
+
+func (a *reactionOnlyAdapter) Type() models.ChannelType { return models.ChannelSlack }
+
+func (a *reactionOnlyAdapter) Reactions() <-chan *ReactionEvent { return a.reactions }
+
+func TestAggregateReactionsUsesReactionAdapters(t *testing.T) {
+	registry := NewRegistry()
+	adapter := &reactionOnlyAdapter{reactions: make(chan *ReactionEvent, 1)}
+	registry.Register(adapter)
+	registry.Register(&inboundOnlyAdapter{messages: make(chan *models.Message)})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	out := registry.AggregateReactions(ctx)
+	event := &ReactionEvent{Channel: models.ChannelSlack, MessageID: "1", Emoji: "+1"}
+	adapter.reactions <- event
+
+	got := <-out
+	if got != event {
+		t.Fatalf("expected reaction to pass through, got %#v", got)
+	}
+
+	close(adapter.reactions)
+	if _, ok := <-out; ok {
+		t.Fatalf("expected output to close once all reaction adapters close")
+	}
+}
+
 // ============================================================================
 // Channel Metadata Registry Tests (new)
 // ============================================================================
@@ -0,0 +1,105 @@
+package channels
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/haasonsaas/nexus/pkg/models"
+)
+
+// ReplyMetadataKey is the inbound message metadata key holding the
+// ReplyReference of the message being replied to or quoted.
+const ReplyMetadataKey = "reply_context"
+
+// ReplyReference identifies the message an inbound message replies to or
+// quotes, with its content when the platform delivered it inline.
+type ReplyReference struct {
+	// MessageID is the platform ID of the referenced message.
+	MessageID string `json:"message_id"`
+
+	// ChatID is the chat/channel holding the referenced message, when
+	// known (Slack channel, Discord channel, Telegram chat).
+	ChatID string `json:"chat_id,omitempty"`
+
+	// SenderID and SenderName identify the author of the referenced message.
+	SenderID   string `json:"sender_id,omitempty"`
+	SenderName string `json:"sender_name,omitempty"`
+
+	// FromBot reports whether the referenced message was sent by a bot.
+	FromBot bool `json:"from_bot,omitempty"`
+
+	// Content is the text of the referenced message; empty until resolved.
+	Content string `json:"content,omitempty"`
+
+	// Quote is the excerpt of the referenced message the user highlighted.
+	Quote string `json:"quote,omitempty"`
+
+	// ThreadParent marks a thread root rather than an explicit reply.
+	ThreadParent bool `json:"thread_parent,omitempty"`
+
+	// SentAt is when the referenced message was sent.
+	SentAt time.Time `json:"sent_at,omitempty"`
+}
+
+// Resolved reports whether the referenced message's content is known.
+func (r *ReplyReference) Resolved() bool {
+	return r != nil && strings.TrimSpace(r.Content) != ""
+}
+
+// ReplyContextAdapter fetches a message referenced by an inbound message
+// when the platform does not deliver its content inline.
+type ReplyContextAdapter interface {
+	ResolveReplyContext(ctx context.Context, msg *models.Message, ref ReplyReference) (*ReplyReference, error)
+}
+
+// SetReplyReference records ref on msg.
+func SetReplyReference(msg *models.Message, ref *ReplyReference) {
+	if msg == nil || ref == nil {
+		return
+	}
+	if msg.Metadata == nil {
+		msg.Metadata = map[string]any{}
+	}
+	msg.Metadata[ReplyMetadataKey] = ref
+}
+
+// ReplyReferenceFrom returns the reply reference recorded on msg. It also
+// accepts the decoded JSON form of a stored message and the plain
+// "reply_to" message ID set by the Matrix and personal adapters.
+func ReplyReferenceFrom(msg *models.Message) (*ReplyReference, bool) {
+	if msg == nil || msg.Metadata == nil {
+		return nil, false
+	}
+	switch value := msg.Metadata[ReplyMetadataKey].(type) {
+	case *ReplyReference:
+		if value != nil {
+			return value, true
+		}
+	case ReplyReference:
+		return &value, true
+	case map[string]any:
+		raw, err := json.Marshal(value)
+		if err != nil {
+			return nil, false
+		}
+		var ref ReplyReference
+		if err := json.Unmarshal(raw, &ref); err != nil || ref.MessageID == "" {
+			return nil, false
+		}
+		return &ref, true
+	}
+	if id, ok := msg.Metadata["reply_to"].(string); ok && strings.TrimSpace(id) != "" {
+		return &ReplyReference{MessageID: strings.TrimSpace(id)}, true
+	}
+	return nil, false
+}
+
+// GetReplyContext returns the reply context adapter for the channel if available.
+func (r *Registry) GetReplyContext(channelType models.ChannelType) (ReplyContextAdapter, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	adapter, ok := r.adapters[channelType].(ReplyContextAdapter)
+	return adapter, ok
+}
@@ -0,0 +1,42 @@
+package channels
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/haasonsaas/nexus/pkg/models"
+)
+
+func TestReplyReferenceFrom(t *testing.T) {
+	msg := &models.Message{}
+	if _, ok := ReplyReferenceFrom(msg); ok {
+		t.Fatal("expected no reference on a plain message")
+	}
+
+	sent := time.Date(2026, 10, 14, 9, 0, 0, 0, time.UTC)
+	SetReplyReference(msg, &ReplyReference{MessageID: "42", Content: "hello", SentAt: sent})
+	ref, ok := ReplyReferenceFrom(msg)
+	if !ok || ref.MessageID != "42" || !ref.Resolved() {
+		t.Fatalf("unexpected reference %+v", ref)
+	}
+
+	// Stored messages come back with decoded JSON metadata.
+	raw, err := json.Marshal(msg.Metadata)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded map[string]any
+	if err := json.Unmarshal(raw, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	ref, ok = ReplyReferenceFrom(&models.Message{Metadata: decoded})
+	if !ok || ref.Content != "hello" || !ref.SentAt.Equal(sent) {
+		t.Fatalf("unexpected decoded reference %+v", ref)
+	}
+
+	ref, ok = ReplyReferenceFrom(&models.Message{Metadata: map[string]any{"reply_to": "$event"}})
+	if !ok || ref.MessageID != "$event" || ref.Resolved() {
+		t.Fatalf("unexpected legacy reference %+v", ref)
+	}
+}
@@ -1,12 +1,12 @@
 package signal
 
 import (
-	"bufio"
 	"context"
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
-	"io"
 	"log/slog"
+	"net/url"
 	"os"
 	"os/exec"
 	"path/filepath"
@@ -20,21 +20,26 @@ import (
 	"github.com/haasonsaas/nexus/pkg/models"
 )
 
-// Adapter implements the Signal channel adapter using signal-cli.
+// Adapter implements the Signal channel adapter using signal-cli's JSON-RPC
+// interface, either over a spawned "signal-cli jsonRpc" process or a
+// connection to a long-running signal-cli daemon.
 type Adapter struct {
 	*personal.BaseAdapter
 
 	config *Config
 
-	process *exec.Cmd
-	stdin   io.WriteCloser
-	stdout  io.ReadCloser
-	stderr  io.ReadCloser
+	session   *rpcSession
+	sessionMu sync.RWMutex
 
 	requestID atomic.Int64
 	pending   map[int64]chan json.RawMessage
+	rpcErrors map[int64]*jsonRPCError
 	pendingMu sync.Mutex
 
+	deliveries deliveryTracker
+	typing     typingHub
+	relink     atomic.Bool
+
 	attachments   map[string]attachmentRecord
 	attachmentsMu sync.RWMutex
 
@@ -52,8 +57,12 @@ func New(cfg *Config, logger *slog.Logger) (*Adapter, error) {
 		return nil, channels.ErrConfig("signal account (phone number) is required", nil)
 	}
 
-	// Verify signal-cli is available
-	if _, err := exec.LookPath(cfg.SignalCLIPath); err != nil {
+	if cfg.daemonMode() {
+		if _, _, err := daemonNetwork(cfg.DaemonAddress); err != nil {
+			return nil, channels.ErrConfig("invalid signal daemon_address", err)
+		}
+	} else if _, err := exec.LookPath(cfg.SignalCLIPath); err != nil {
+		// Verify signal-cli is available
 		return nil, channels.ErrNotFound(fmt.Sprintf("signal-cli not found at %q", cfg.SignalCLIPath), err)
 	}
 
@@ -72,51 +81,20 @@ func (a *Adapter) Start(ctx context.Context) error {
 	ctx, cancel := context.WithCancel(ctx)
 	a.cancelFunc = cancel
 
-	// Build signal-cli command
-	args := []string{
-		"--output=json",
-		"-a", a.config.Account,
-	}
-
-	if a.config.ConfigDir != "" {
-		configDir := expandPath(a.config.ConfigDir)
-		args = append(args, "--config", configDir)
-	}
-
-	args = append(args, "jsonRpc")
-
-	a.process = exec.CommandContext(ctx, a.config.SignalCLIPath, args...)
-
-	// Set up pipes
-	var err error
-	a.stdin, err = a.process.StdinPipe()
+	session, err := a.openSession(ctx)
 	if err != nil {
-		return channels.ErrConnection("failed to create stdin pipe", err)
-	}
-
-	a.stdout, err = a.process.StdoutPipe()
-	if err != nil {
-		return channels.ErrConnection("failed to create stdout pipe", err)
-	}
-
-	a.stderr, err = a.process.StderrPipe()
-	if err != nil {
-		return channels.ErrConnection("failed to create stderr pipe", err)
-	}
-
-	// Start the process
-	if err := a.process.Start(); err != nil {
-		return channels.ErrConnection("failed to start signal-cli", err)
+		cancel()
+		return err
 	}
+	a.setSession(session)
 
 	a.SetStatus(true, "")
-	a.Logger().Info("started signal-cli",
-		"account", a.config.Account)
+	a.Logger().Info("connected to signal-cli",
+		"account", a.config.Account,
+		"mode", defaultMode(a.config.Mode))
 
-	// Start message receiver
-	a.wg.Add(2)
-	go a.receiveLoop(ctx)
-	go a.stderrLoop(ctx)
+	a.wg.Add(1)
+	go a.run(ctx, session)
 
 	return nil
 }
@@ -127,14 +105,8 @@ func (a *Adapter) Stop(ctx context.Context) error {
 		a.cancelFunc()
 	}
 
-	if a.stdin != nil {
-		a.stdin.Close()
-	}
-
-	if a.process != nil {
-		if err := a.process.Wait(); err != nil {
-			a.Logger().Debug("signal process wait returned error", "error", err)
-		}
+	if session := a.currentSession(); session != nil {
+		a.dropSession(session)
 	}
 
 	a.wg.Wait()
@@ -170,10 +142,21 @@ func (a *Adapter) Send(ctx context.Context, msg *models.Message) error {
 		delete(params, "recipient")
 	}
 
-	// Send attachments
+	// Send attachments. A daemon may run on another host, so it gets the
+	// bytes inline as data URIs instead of local paths.
 	var attachmentPaths []string
+	var inlineAttachments []string
 	for _, att := range msg.Attachments {
-		if att.URL != "" {
+		if att.URL != "" && a.config.daemonMode() {
+			uri, err := attachmentDataURI(ctx, att)
+			if err != nil {
+				a.Logger().Error("failed to download attachment",
+					"error", err,
+					"url", att.URL)
+				continue
+			}
+			inlineAttachments = append(inlineAttachments, uri)
+		} else if att.URL != "" {
 			// Download to scratch file
 			path, err := downloadToTempFile(ctx, att.URL)
 			if err != nil {
@@ -197,47 +180,87 @@ func (a *Adapter) Send(ctx context.Context, msg *models.Message) error {
 
 	if len(attachmentPaths) > 0 {
 		params["attachments"] = attachmentPaths
+	} else if len(inlineAttachments) > 0 {
+		params["attachments"] = inlineAttachments
 	}
 
-	_, err := a.call(ctx, req)
+	result, err := a.call(ctx, req)
 	if err != nil {
 		a.IncrementErrors()
 		return channels.ErrConnection("failed to send message", err)
 	}
 
+	var sent struct {
+		Timestamp int64 `json:"timestamp"`
+	}
+	if json.Unmarshal(result, &sent) == nil && sent.Timestamp > 0 {
+		a.deliveries.sent(sent.Timestamp)
+	}
+
 	a.IncrementSent()
 	return nil
 }
 
-// HealthCheck returns the adapter's health status.
+// HealthCheck returns the adapter's health status. Before Start, daemon mode
+// probes the daemon so doctor can report connectivity and relink problems.
 func (a *Adapter) HealthCheck(ctx context.Context) channels.HealthStatus {
 	start := time.Now()
-
-	if a.process == nil {
+	status := func(healthy bool, message string) channels.HealthStatus {
 		return channels.HealthStatus{
-			Healthy:   false,
-			Message:   "process not started",
+			Healthy:   healthy,
+			Message:   message,
 			Latency:   time.Since(start),
 			LastCheck: time.Now(),
 		}
 	}
 
-	// Check if process is still running
-	if a.process.ProcessState != nil && a.process.ProcessState.Exited() {
-		return channels.HealthStatus{
-			Healthy:   false,
-			Message:   "process exited",
-			Latency:   time.Since(start),
-			LastCheck: time.Now(),
+	if a.relinkRequired() {
+		return status(false, a.relinkGuidance())
+	}
+
+	session := a.currentSession()
+	if session == nil {
+		if a.config.daemonMode() {
+			if a.cancelFunc != nil {
+				return status(false, "reconnecting to daemon")
+			}
+			return a.probeDaemon(ctx)
+		}
+		if a.cancelFunc != nil {
+			return status(false, "restarting signal-cli")
 		}
+		return status(false, "process not started")
 	}
 
-	return channels.HealthStatus{
-		Healthy:   true,
-		Message:   "running",
-		Latency:   time.Since(start),
-		LastCheck: time.Now(),
+	// Check if process is still running
+	if session.exited() {
+		return status(false, "process exited")
+	}
+	if a.config.daemonMode() {
+		return status(true, "connected to daemon")
+	}
+	return status(true, "running")
+}
+
+// DownloadAttachment returns the bytes of an inbound attachment, fetching it
+// from signal-cli when it is not available locally.
+func (a *Adapter) DownloadAttachment(ctx context.Context, msg *models.Message, attachment *models.Attachment) ([]byte, string, string, error) {
+	if attachment == nil {
+		return nil, "", "", channels.ErrInvalidInput("attachment is required", nil)
 	}
+	mediaID := attachment.ID
+	if mediaID == "" {
+		mediaID = attachment.URL
+	}
+	data, mimeType, err := a.Media().Download(ctx, mediaID)
+	if err != nil {
+		return nil, "", "", err
+	}
+	filename := attachment.Filename
+	if record, ok := a.getAttachmentRecord(mediaID); ok && record.filename != "" {
+		filename = record.filename
+	}
+	return data, mimeType, filename, nil
 }
 
 // Contacts returns the contact manager.
@@ -265,7 +288,7 @@ func (a *Adapter) GetConversation(ctx context.Context, peerID string) (*personal
 
 // ListConversations lists conversations.
 func (a *Adapter) ListConversations(ctx context.Context, opts personal.ListOptions) ([]*personal.Conversation, error) {
-	if a == nil || a.stdin == nil || a.pending == nil {
+	if a == nil || a.currentSession() == nil || a.pending == nil {
 		return nil, channels.ErrUnavailable("list conversations unavailable", nil)
 	}
 	if ctx == nil {
@@ -324,52 +347,6 @@ func (a *Adapter) ListConversations(ctx context.Context, opts personal.ListOptio
 	return conversations, nil
 }
 
-// receiveLoop reads and processes messages from signal-cli stdout.
-func (a *Adapter) receiveLoop(ctx context.Context) {
-	defer a.wg.Done()
-
-	scanner := bufio.NewScanner(a.stdout)
-	scanner.Buffer(make([]byte, 1024*1024), 1024*1024) // 1MB buffer
-
-	for scanner.Scan() {
-		select {
-		case <-ctx.Done():
-			return
-		default:
-		}
-
-		line := scanner.Text()
-		if line == "" {
-			continue
-		}
-
-		a.processLine(line)
-	}
-
-	if err := scanner.Err(); err != nil {
-		a.Logger().Error("scanner error", "error", err)
-	}
-}
-
-// stderrLoop reads and logs stderr from signal-cli.
-func (a *Adapter) stderrLoop(ctx context.Context) {
-	defer a.wg.Done()
-
-	scanner := bufio.NewScanner(a.stderr)
-	for scanner.Scan() {
-		select {
-		case <-ctx.Done():
-			return
-		default:
-		}
-
-		line := scanner.Text()
-		if line != "" {
-			a.Logger().Warn("signal-cli stderr", "message", line)
-		}
-	}
-}
-
 // processLine handles a single JSON-RPC line from signal-cli.
 func (a *Adapter) processLine(line string) {
 	var msg jsonRPCMessage
@@ -385,6 +362,9 @@ func (a *Adapter) processLine(line string) {
 		a.pendingMu.Lock()
 		if ch, ok := a.pending[*msg.ID]; ok {
 			delete(a.pending, *msg.ID)
+			if msg.Error != nil {
+				a.recordRPCError(*msg.ID, msg.Error)
+			}
 			a.pendingMu.Unlock()
 
 			select {
@@ -402,13 +382,48 @@ func (a *Adapter) processLine(line string) {
 	}
 }
 
-// handleReceive processes incoming Signal messages.
+// handleReceive processes incoming Signal messages, receipts and typing
+// notifications.
 func (a *Adapter) handleReceive(params json.RawMessage) {
-	var envelope signalEnvelope
-	if err := json.Unmarshal(params, &envelope); err != nil {
+	var notification struct {
+		Envelope  *signalEnvelope  `json:"envelope"`
+		Account   string           `json:"account"`
+		Exception *signalException `json:"exception"`
+	}
+	if err := json.Unmarshal(params, &notification); err != nil {
 		a.Logger().Error("failed to parse envelope", "error", err)
 		return
 	}
+	if notification.Exception != nil {
+		message := notification.Exception.Type + ": " + notification.Exception.Message
+		if !a.detectRelink(message) {
+			a.Logger().Warn("signal-cli receive error", "error", message)
+		}
+		return
+	}
+	// A multi-account daemon streams envelopes for every account.
+	if notification.Account != "" && notification.Account != a.config.Account {
+		return
+	}
+
+	envelope := notification.Envelope
+	if envelope == nil {
+		// Older signal-cli releases send the envelope as the params object.
+		envelope = &signalEnvelope{}
+		if err := json.Unmarshal(params, envelope); err != nil {
+			a.Logger().Error("failed to parse envelope", "error", err)
+			return
+		}
+	}
+
+	switch {
+	case envelope.ReceiptMessage != nil:
+		a.handleReceipt(envelope)
+		return
+	case envelope.TypingMessage != nil:
+		a.handleTyping(envelope)
+		return
+	}
 
 	// Skip non-data messages
 	if envelope.DataMessage == nil {
@@ -420,7 +435,7 @@ func (a *Adapter) handleReceive(params json.RawMessage) {
 	raw := personal.RawMessage{
 		ID:        fmt.Sprintf("%d", envelope.Timestamp),
 		Content:   dm.Message,
-		PeerID:    envelope.Source,
+		PeerID:    envelope.sender(),
 		PeerName:  envelope.SourceName,
 		Timestamp: time.UnixMilli(envelope.Timestamp),
 	}
@@ -435,10 +450,7 @@ func (a *Adapter) handleReceive(params json.RawMessage) {
 	for _, att := range dm.Attachments {
 		attachmentID := strings.TrimSpace(att.ID)
 		if attachmentID != "" {
-			storedPath := strings.TrimSpace(att.StoredFilename)
-			if storedPath != "" {
-				storedPath = expandPath(storedPath)
-			}
+			storedPath := a.localAttachmentPath(att)
 			a.trackAttachment(attachmentID, attachmentRecord{
 				peerID:     raw.PeerID,
 				groupID:    raw.GroupID,
@@ -449,8 +461,8 @@ func (a *Adapter) handleReceive(params json.RawMessage) {
 			})
 		}
 		attachmentURL := ""
-		if att.StoredFilename != "" {
-			attachmentURL = "file://" + expandPath(att.StoredFilename)
+		if path := a.localAttachmentPath(att); path != "" {
+			attachmentURL = "file://" + path
 		}
 		raw.Attachments = append(raw.Attachments, personal.RawAttachment{
 			ID:       att.ID,
@@ -473,12 +485,22 @@ func (a *Adapter) handleReceive(params json.RawMessage) {
 
 // call sends a JSON-RPC request and waits for a response.
 func (a *Adapter) call(ctx context.Context, req map[string]any) (json.RawMessage, error) {
-	if a == nil || a.stdin == nil || a.pending == nil {
+	session := a.currentSession()
+	if session == nil || a.pending == nil {
 		return nil, channels.ErrUnavailable("signal client not started", nil)
 	}
 	id := a.requestID.Add(1)
 	req["jsonrpc"] = "2.0"
 	req["id"] = id
+	if a.config.daemonMode() {
+		// Multi-account daemons route requests by account.
+		params, _ := req["params"].(map[string]any)
+		if params == nil {
+			params = map[string]any{}
+			req["params"] = params
+		}
+		params["account"] = a.config.Account
+	}
 
 	// Register response channel
 	ch := make(chan json.RawMessage, 1)
@@ -489,6 +511,7 @@ func (a *Adapter) call(ctx context.Context, req map[string]any) (json.RawMessage
 	defer func() {
 		a.pendingMu.Lock()
 		delete(a.pending, id)
+		delete(a.rpcErrors, id)
 		a.pendingMu.Unlock()
 	}()
 
@@ -498,7 +521,7 @@ func (a *Adapter) call(ctx context.Context, req map[string]any) (json.RawMessage
 		return nil, channels.ErrInternal("failed to marshal request", err)
 	}
 
-	if _, err := fmt.Fprintf(a.stdin, "%s\n", data); err != nil {
+	if err := session.write(data); err != nil {
 		return nil, channels.ErrConnection("failed to write request", err)
 	}
 
@@ -507,6 +530,10 @@ func (a *Adapter) call(ctx context.Context, req map[string]any) (json.RawMessage
 	case <-ctx.Done():
 		return nil, ctx.Err()
 	case result := <-ch:
+		if rpcErr := a.takeRPCError(id); rpcErr != nil {
+			a.detectRelink(rpcErr.Message)
+			return nil, rpcErr
+		}
 		return result, nil
 	case <-time.After(30 * time.Second):
 		return nil, channels.ErrTimeout("request timeout", nil)
@@ -529,11 +556,50 @@ type jsonRPCError struct {
 	Message string `json:"message"`
 }
 
+func (e *jsonRPCError) Error() string {
+	return fmt.Sprintf("signal-cli error %d: %s", e.Code, e.Message)
+}
+
+type signalException struct {
+	Message string `json:"message"`
+	Type    string `json:"type"`
+}
+
 type signalEnvelope struct {
-	Source      string             `json:"source"`
-	SourceName  string             `json:"sourceName"`
-	Timestamp   int64              `json:"timestamp"`
-	DataMessage *signalDataMessage `json:"dataMessage"`
+	Source         string                `json:"source"`
+	SourceNumber   string                `json:"sourceNumber"`
+	SourceUUID     string                `json:"sourceUuid"`
+	SourceName     string                `json:"sourceName"`
+	Timestamp      int64                 `json:"timestamp"`
+	DataMessage    *signalDataMessage    `json:"dataMessage"`
+	ReceiptMessage *signalReceiptMessage `json:"receiptMessage"`
+	TypingMessage  *signalTypingMessage  `json:"typingMessage"`
+}
+
+// sender returns the best available identifier for the envelope's author.
+func (e *signalEnvelope) sender() string {
+	switch {
+	case e.Source != "":
+		return e.Source
+	case e.SourceNumber != "":
+		return e.SourceNumber
+	default:
+		return e.SourceUUID
+	}
+}
+
+type signalReceiptMessage struct {
+	When       int64   `json:"when"`
+	IsDelivery bool    `json:"isDelivery"`
+	IsRead     bool    `json:"isRead"`
+	IsViewed   bool    `json:"isViewed"`
+	Timestamps []int64 `json:"timestamps"`
+}
+
+type signalTypingMessage struct {
+	Action    string `json:"action"`
+	Timestamp int64  `json:"timestamp"`
+	GroupID   string `json:"groupId"`
 }
 
 type signalDataMessage struct {
@@ -574,6 +640,40 @@ func expandPath(path string) string {
 	return path
 }
 
+// localAttachmentPath returns where signal-cli stored an inbound attachment
+// on this host, or "" when it is only reachable through the daemon.
+func (a *Adapter) localAttachmentPath(att signalAttachment) string {
+	if stored := strings.TrimSpace(att.StoredFilename); stored != "" {
+		return expandPath(stored)
+	}
+	if a.config.daemonMode() || a.config.ConfigDir == "" || strings.TrimSpace(att.ID) == "" {
+		return ""
+	}
+	path := filepath.Join(expandPath(a.config.ConfigDir), "attachments", filepath.Base(att.ID))
+	if info, err := os.Stat(path); err == nil && !info.IsDir() {
+		return path
+	}
+	return ""
+}
+
+// attachmentDataURI downloads an outbound attachment into the data URI form
+// signal-cli accepts in place of a file path.
+func attachmentDataURI(ctx context.Context, att models.Attachment) (string, error) {
+	data, err := downloadURL(ctx, att.URL)
+	if err != nil {
+		return "", err
+	}
+	mimeType := att.MimeType
+	if mimeType == "" {
+		mimeType = detectMimeType(data, att.Filename, "")
+	}
+	uri := "data:" + mimeType
+	if att.Filename != "" {
+		uri += ";filename=" + url.PathEscape(att.Filename)
+	}
+	return uri + ";base64," + base64.StdEncoding.EncodeToString(data), nil
+}
+
 // downloadToTempFile downloads a URL to a scratch file.
 func downloadToTempFile(ctx context.Context, url string) (string, error) {
 	if ctx == nil {
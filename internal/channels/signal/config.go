@@ -2,12 +2,23 @@
 package signal
 
 import (
+	"fmt"
 	"time"
 
 	"github.com/haasonsaas/nexus/internal/channels"
 	"github.com/haasonsaas/nexus/internal/channels/personal"
 )
 
+// Connection modes for reaching signal-cli.
+const (
+	// ModeProcess spawns "signal-cli jsonRpc" and talks JSON-RPC over its
+	// stdin and stdout.
+	ModeProcess = "process"
+	// ModeDaemon connects to a running "signal-cli daemon --tcp" or
+	// "--socket" endpoint.
+	ModeDaemon = "daemon"
+)
+
 // Config holds Signal adapter configuration.
 type Config struct {
 	// Enabled controls whether the Signal adapter is active.
@@ -22,6 +33,17 @@ type Config struct {
 	// ConfigDir is the directory for signal-cli configuration.
 	ConfigDir string `yaml:"config_dir"`
 
+	// Mode selects how signal-cli is reached: "process" (default) or "daemon".
+	Mode string `yaml:"mode"`
+
+	// DaemonAddress is the daemon's JSON-RPC endpoint in daemon mode, either
+	// host:port or unix:///path/to/socket.
+	DaemonAddress string `yaml:"daemon_address"`
+
+	// ReconnectDelay is how long to wait before reconnecting to the daemon or
+	// restarting signal-cli after the connection drops (default "5s").
+	ReconnectDelay string `yaml:"reconnect_delay"`
+
 	// Personal contains shared personal channel settings.
 	Personal personal.Config `yaml:"personal"`
 
@@ -36,6 +58,8 @@ func DefaultConfig() *Config {
 		Enabled:          false,
 		SignalCLIPath:    "signal-cli",
 		ConfigDir:        "~/.config/signal-cli",
+		Mode:             ModeProcess,
+		ReconnectDelay:   "5s",
 		AttachmentMaxAge: "168h",
 		Personal: personal.Config{
 			SyncOnStart: true,
@@ -57,8 +81,23 @@ func (c *Config) Validate() error {
 		return channels.ErrConfig("signal: account (phone number) is required", nil)
 	}
 
-	if c.SignalCLIPath == "" {
-		return channels.ErrConfig("signal: signal_cli_path is required", nil)
+	switch c.Mode {
+	case "", ModeProcess:
+		if c.SignalCLIPath == "" {
+			return channels.ErrConfig("signal: signal_cli_path is required", nil)
+		}
+	case ModeDaemon:
+		if _, _, err := daemonNetwork(c.DaemonAddress); err != nil {
+			return channels.ErrConfig("signal: invalid daemon_address", err)
+		}
+	default:
+		return channels.ErrConfig(fmt.Sprintf("signal: unknown mode %q (use process or daemon)", c.Mode), nil)
+	}
+
+	if c.ReconnectDelay != "" {
+		if _, err := time.ParseDuration(c.ReconnectDelay); err != nil {
+			return channels.ErrConfig("signal: invalid reconnect_delay", err)
+		}
 	}
 
 	if c.AttachmentMaxAge != "" {
@@ -69,3 +108,25 @@ func (c *Config) Validate() error {
 
 	return nil
 }
+
+// daemonMode reports whether the adapter connects to a running daemon.
+func (c *Config) daemonMode() bool {
+	return c != nil && c.Mode == ModeDaemon
+}
+
+// reconnectDelay returns the parsed reconnect delay.
+func (c *Config) reconnectDelay() time.Duration {
+	if c != nil && c.ReconnectDelay != "" {
+		if d, err := time.ParseDuration(c.ReconnectDelay); err == nil && d > 0 {
+			return d
+		}
+	}
+	return 5 * time.Second
+}
+
+func defaultMode(mode string) string {
+	if mode == "" {
+		return ModeProcess
+	}
+	return mode
+}
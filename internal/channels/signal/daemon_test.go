@@ -0,0 +1,315 @@
+package signal
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/haasonsaas/nexus/internal/channels/personal"
+	"github.com/haasonsaas/nexus/pkg/models"
+)
+
+// fakeDaemon is a signal-cli daemon speaking newline-delimited JSON-RPC over
+// TCP. It answers send with a fixed timestamp and records every request.
+type fakeDaemon struct {
+	t        *testing.T
+	listener net.Listener
+
+	mu       sync.Mutex
+	conns    []net.Conn
+	requests []map[string]any
+	errors   map[string]string
+	accepted chan net.Conn
+}
+
+func newFakeDaemon(t *testing.T) *fakeDaemon {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	d := &fakeDaemon{t: t, listener: listener, errors: map[string]string{}, accepted: make(chan net.Conn, 8)}
+	go d.serve()
+	t.Cleanup(func() {
+		listener.Close()
+		d.mu.Lock()
+		for _, conn := range d.conns {
+			conn.Close()
+		}
+		d.mu.Unlock()
+	})
+	return d
+}
+
+func (d *fakeDaemon) serve() {
+	for {
+		conn, err := d.listener.Accept()
+		if err != nil {
+			return
+		}
+		d.mu.Lock()
+		d.conns = append(d.conns, conn)
+		d.mu.Unlock()
+		d.accepted <- conn
+		go d.handle(conn)
+	}
+}
+
+func (d *fakeDaemon) handle(conn net.Conn) {
+	scanner := bufio.NewScanner(conn)
+	for scanner.Scan() {
+		var req map[string]any
+		if err := json.Unmarshal(scanner.Bytes(), &req); err != nil {
+			continue
+		}
+		d.mu.Lock()
+		d.requests = append(d.requests, req)
+		failure := d.errors[req["method"].(string)]
+		d.mu.Unlock()
+
+		resp := map[string]any{"jsonrpc": "2.0", "id": req["id"]}
+		switch {
+		case failure != "":
+			resp["error"] = map[string]any{"code": -1, "message": failure}
+		case req["method"] == "send":
+			resp["result"] = map[string]any{"timestamp": 1700000000123}
+		case req["method"] == "version":
+			resp["result"] = map[string]any{"version": "0.13.4"}
+		default:
+			resp["result"] = map[string]any{}
+		}
+		data, _ := json.Marshal(resp)
+		conn.Write(append(data, '\n'))
+	}
+}
+
+func (d *fakeDaemon) failMethod(method, message string) {
+	d.mu.Lock()
+	d.errors[method] = message
+	d.mu.Unlock()
+}
+
+func (d *fakeDaemon) lastRequest(method string) map[string]any {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	for i := len(d.requests) - 1; i >= 0; i-- {
+		if d.requests[i]["method"] == method {
+			return d.requests[i]
+		}
+	}
+	return nil
+}
+
+func (d *fakeDaemon) waitConn(t *testing.T) net.Conn {
+	t.Helper()
+	select {
+	case conn := <-d.accepted:
+		return conn
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for a daemon connection")
+		return nil
+	}
+}
+
+func notify(t *testing.T, conn net.Conn, params string) {
+	t.Helper()
+	if _, err := conn.Write([]byte(`{"jsonrpc":"2.0","method":"receive","params":` + params + "}\n")); err != nil {
+		t.Fatalf("write notification: %v", err)
+	}
+}
+
+func newDaemonAdapter(t *testing.T, address string) *Adapter {
+	t.Helper()
+	cfg := DefaultConfig()
+	cfg.Account = "+1234567890"
+	cfg.Mode = ModeDaemon
+	cfg.DaemonAddress = address
+	cfg.ReconnectDelay = "20ms"
+	adapter, err := New(cfg, nil)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	return adapter
+}
+
+func TestDaemonModeStreamsAndSends(t *testing.T) {
+	daemon := newFakeDaemon(t)
+	adapter := newDaemonAdapter(t, daemon.listener.Addr().String())
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	if err := adapter.Start(ctx); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+	defer adapter.Stop(context.Background())
+	conn := daemon.waitConn(t)
+
+	// Envelopes for other accounts on a multi-account daemon are ignored.
+	notify(t, conn, `{"account":"+1999","envelope":{"sourceNumber":"+1555","timestamp":1,"dataMessage":{"message":"not ours"}}}`)
+	notify(t, conn, `{"account":"+1234567890","envelope":{"sourceNumber":"+1555","sourceName":"Ada","timestamp":1700000000001,`+
+		`"dataMessage":{"message":"hello","attachments":[{"id":"abc.jpg","contentType":"image/jpeg","filename":"cat.jpg","size":3}]}}}`)
+
+	var msg *models.Message
+	select {
+	case msg = <-adapter.Messages():
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for inbound message")
+	}
+	if msg.Content != "hello" || msg.Metadata["peer_id"] != "+1555" {
+		t.Fatalf("inbound message = %+v", msg)
+	}
+	if len(msg.Attachments) != 1 || msg.Attachments[0].URL != "" || msg.Attachments[0].ID != "abc.jpg" {
+		t.Fatalf("daemon attachments should be fetched through the daemon, got %+v", msg.Attachments)
+	}
+
+	typing, err := adapter.Presence().Subscribe(ctx, "+1555")
+	if err != nil {
+		t.Fatalf("Subscribe() error = %v", err)
+	}
+	notify(t, conn, `{"account":"+1234567890","envelope":{"sourceNumber":"+1555","timestamp":5,"typingMessage":{"action":"STARTED","timestamp":5}}}`)
+	select {
+	case event := <-typing:
+		if event.Type != personal.PresenceTyping || event.PeerID != "+1555" {
+			t.Fatalf("typing event = %+v", event)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for typing event")
+	}
+
+	err = adapter.Send(ctx, &models.Message{
+		Content:     "reply",
+		Metadata:    map[string]any{"peer_id": "+1555"},
+		Attachments: []models.Attachment{{URL: "data:text/plain;base64,aGk=", Filename: "note.txt", MimeType: "text/plain"}},
+	})
+	if err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+	req := daemon.lastRequest("send")
+	params, _ := req["params"].(map[string]any)
+	if params["account"] != "+1234567890" {
+		t.Fatalf("send params missing account: %v", params)
+	}
+	attachments, _ := params["attachments"].([]any)
+	if len(attachments) != 1 || attachments[0] != "data:text/plain;filename=note.txt;base64,aGk=" {
+		t.Fatalf("send attachments = %v", params["attachments"])
+	}
+	if state, ok := adapter.DeliveryStatus(1700000000123); !ok || state != DeliverySent {
+		t.Fatalf("DeliveryStatus() = %q, %v", state, ok)
+	}
+
+	notify(t, conn, `{"account":"+1234567890","envelope":{"sourceNumber":"+1555","timestamp":7,"receiptMessage":{"isRead":true,"timestamps":[1700000000123]}}}`)
+	notify(t, conn, `{"account":"+1234567890","envelope":{"sourceNumber":"+1555","timestamp":8,"receiptMessage":{"isDelivery":true,"timestamps":[1700000000123]}}}`)
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		state, _ := adapter.DeliveryStatus(1700000000123)
+		if state == DeliveryRead {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("expected a late delivery receipt not to downgrade read, got %q", state)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	if health := adapter.HealthCheck(ctx); !health.Healthy || health.Message != "connected to daemon" {
+		t.Fatalf("HealthCheck() = %+v", health)
+	}
+}
+
+func TestDaemonModeReconnectsAndDetectsRelink(t *testing.T) {
+	daemon := newFakeDaemon(t)
+	adapter := newDaemonAdapter(t, daemon.listener.Addr().String())
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	if err := adapter.Start(ctx); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+	defer adapter.Stop(context.Background())
+
+	daemon.waitConn(t).Close()
+	conn := daemon.waitConn(t)
+	notify(t, conn, `{"account":"+1234567890","envelope":{"sourceNumber":"+1555","timestamp":2,"dataMessage":{"message":"after reconnect"}}}`)
+	select {
+	case msg := <-adapter.Messages():
+		if msg.Content != "after reconnect" {
+			t.Fatalf("inbound message = %+v", msg)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for message after reconnect")
+	}
+
+	daemon.failMethod("send", "User +1234567890 is not registered.")
+	err := adapter.Send(ctx, &models.Message{Content: "hi", Metadata: map[string]any{"peer_id": "+1555"}})
+	if err == nil || !strings.Contains(err.Error(), "not registered") {
+		t.Fatalf("Send() error = %v", err)
+	}
+	health := adapter.HealthCheck(ctx)
+	if health.Healthy || !strings.Contains(health.Message, "signal-cli link") || !strings.Contains(health.Message, "nexus doctor") {
+		t.Fatalf("HealthCheck() = %+v", health)
+	}
+	if status := adapter.Status(); status.Connected || !strings.Contains(status.Error, "no longer registered") {
+		t.Fatalf("Status() = %+v", status)
+	}
+}
+
+func TestDaemonHealthProbeBeforeStart(t *testing.T) {
+	daemon := newFakeDaemon(t)
+	adapter := newDaemonAdapter(t, daemon.listener.Addr().String())
+
+	health := adapter.HealthCheck(context.Background())
+	if !health.Healthy || !strings.Contains(health.Message, "0.13.4") {
+		t.Fatalf("HealthCheck() = %+v", health)
+	}
+	if req := daemon.lastRequest("listDevices"); req == nil {
+		t.Fatal("expected the probe to check the account")
+	}
+
+	daemon.failMethod("listDevices", "org.whispersystems.signalservice.api.push.exceptions.AuthorizationFailedException: Authorization failed!")
+	health = adapter.HealthCheck(context.Background())
+	if health.Healthy || !strings.Contains(health.Message, "signal-cli link") {
+		t.Fatalf("HealthCheck() = %+v", health)
+	}
+
+	daemon.listener.Close()
+	unreachable := newDaemonAdapter(t, daemon.listener.Addr().String())
+	if health := unreachable.HealthCheck(context.Background()); health.Healthy {
+		t.Fatalf("expected an unreachable daemon to be unhealthy, got %+v", health)
+	}
+}
+
+func TestDaemonConfigValidate(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Enabled = true
+	cfg.Account = "+1234567890"
+	cfg.Mode = ModeDaemon
+	if err := cfg.Validate(); err == nil {
+		t.Fatal("expected daemon mode without an address to fail")
+	}
+	for _, address := range []string{"127.0.0.1:7583", "tcp://localhost:7583", "unix:///run/signal-cli/socket"} {
+		cfg.DaemonAddress = address
+		if err := cfg.Validate(); err != nil {
+			t.Fatalf("Validate(%q) error = %v", address, err)
+		}
+	}
+	cfg.Mode = "http"
+	if err := cfg.Validate(); err == nil {
+		t.Fatal("expected an unknown mode to fail")
+	}
+}
+
+func TestNeedsRelink(t *testing.T) {
+	for message, want := range map[string]bool{
+		"User +1234567890 is not registered.":                    true,
+		"AuthorizationFailedException: Authorization failed!":    true,
+		"Failed to send message: Unregistered user \"+1555\"":    false,
+		"Connection closed unexpectedly, reconnecting in 100 ms": false,
+	} {
+		if got := needsRelink(message); got != want {
+			t.Errorf("needsRelink(%q) = %v, want %v", message, got, want)
+		}
+	}
+}
@@ -0,0 +1,165 @@
+package signal
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/haasonsaas/nexus/internal/channels/personal"
+)
+
+// DeliveryState is the delivery progress of a sent message.
+type DeliveryState string
+
+const (
+	DeliverySent      DeliveryState = "sent"
+	DeliveryDelivered DeliveryState = "delivered"
+	DeliveryRead      DeliveryState = "read"
+	DeliveryViewed    DeliveryState = "viewed"
+)
+
+// deliveryRank orders states so late delivery receipts never downgrade a read
+// message.
+var deliveryRank = map[DeliveryState]int{
+	DeliverySent:      1,
+	DeliveryDelivered: 2,
+	DeliveryRead:      3,
+	DeliveryViewed:    4,
+}
+
+// maxTrackedDeliveries bounds the number of sent messages whose receipts are
+// remembered.
+const maxTrackedDeliveries = 1000
+
+// deliveryTracker records receipts for messages sent by the adapter, keyed
+// by the Signal timestamp returned from send.
+type deliveryTracker struct {
+	mu     sync.Mutex
+	states map[int64]DeliveryState
+	order  []int64
+}
+
+func (t *deliveryTracker) sent(timestamp int64) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.states == nil {
+		t.states = make(map[int64]DeliveryState)
+	}
+	if _, ok := t.states[timestamp]; ok {
+		return
+	}
+	t.states[timestamp] = DeliverySent
+	t.order = append(t.order, timestamp)
+	if len(t.order) > maxTrackedDeliveries {
+		delete(t.states, t.order[0])
+		t.order = t.order[1:]
+	}
+}
+
+// update advances a tracked message and reports whether it was tracked.
+func (t *deliveryTracker) update(timestamp int64, state DeliveryState) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	current, ok := t.states[timestamp]
+	if !ok {
+		return false
+	}
+	if deliveryRank[state] > deliveryRank[current] {
+		t.states[timestamp] = state
+	}
+	return true
+}
+
+func (t *deliveryTracker) get(timestamp int64) (DeliveryState, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	state, ok := t.states[timestamp]
+	return state, ok
+}
+
+// DeliveryStatus returns the receipt state of a message sent by this adapter,
+// identified by its Signal timestamp.
+func (a *Adapter) DeliveryStatus(timestamp int64) (DeliveryState, bool) {
+	return a.deliveries.get(timestamp)
+}
+
+// handleReceipt applies a delivery, read or viewed receipt.
+func (a *Adapter) handleReceipt(envelope *signalEnvelope) {
+	receipt := envelope.ReceiptMessage
+	state := DeliveryDelivered
+	switch {
+	case receipt.IsViewed:
+		state = DeliveryViewed
+	case receipt.IsRead:
+		state = DeliveryRead
+	case !receipt.IsDelivery:
+		return
+	}
+	for _, timestamp := range receipt.Timestamps {
+		if a.deliveries.update(timestamp, state) {
+			a.Logger().Debug("signal receipt", "peer", envelope.sender(), "timestamp", timestamp, "state", state)
+		}
+	}
+}
+
+// typingHub fans inbound typing notifications out to presence subscribers.
+type typingHub struct {
+	mu   sync.Mutex
+	subs map[string][]chan personal.PresenceEvent
+}
+
+func (h *typingHub) subscribe(ctx context.Context, peerID string) <-chan personal.PresenceEvent {
+	ch := make(chan personal.PresenceEvent, 10)
+	h.mu.Lock()
+	if h.subs == nil {
+		h.subs = make(map[string][]chan personal.PresenceEvent)
+	}
+	h.subs[peerID] = append(h.subs[peerID], ch)
+	h.mu.Unlock()
+
+	go func() {
+		<-ctx.Done()
+		h.mu.Lock()
+		defer h.mu.Unlock()
+		subs := h.subs[peerID]
+		for i, sub := range subs {
+			if sub == ch {
+				h.subs[peerID] = append(subs[:i], subs[i+1:]...)
+				close(ch)
+				break
+			}
+		}
+		if len(h.subs[peerID]) == 0 {
+			delete(h.subs, peerID)
+		}
+	}()
+	return ch
+}
+
+func (h *typingHub) publish(event personal.PresenceEvent) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for _, ch := range h.subs[event.PeerID] {
+		select {
+		case ch <- event:
+		default:
+		}
+	}
+}
+
+// handleTyping forwards a typing notification to presence subscribers.
+func (a *Adapter) handleTyping(envelope *signalEnvelope) {
+	eventType := personal.PresenceTyping
+	if envelope.TypingMessage.Action == "STOPPED" {
+		eventType = personal.PresenceStoppedTyping
+	}
+	timestamp := envelope.TypingMessage.Timestamp
+	if timestamp == 0 {
+		timestamp = envelope.Timestamp
+	}
+	a.typing.publish(personal.PresenceEvent{
+		PeerID:    envelope.sender(),
+		Type:      eventType,
+		Timestamp: time.UnixMilli(timestamp),
+	})
+}
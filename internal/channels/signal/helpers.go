@@ -8,6 +8,7 @@ import (
 	"io"
 	"net/http"
 	"os"
+	"strconv"
 	"strings"
 	"time"
 
@@ -58,7 +59,7 @@ func (c *contactManager) Resolve(ctx context.Context, identifier string) (*perso
 }
 
 func (c *contactManager) Search(ctx context.Context, query string) ([]*personal.Contact, error) {
-	if c == nil || c.adapter == nil || c.adapter.stdin == nil || c.adapter.pending == nil {
+	if c == nil || c.adapter == nil || c.adapter.currentSession() == nil || c.adapter.pending == nil {
 		return nil, channels.ErrUnavailable("contact search unavailable", nil)
 	}
 	q := strings.TrimSpace(query)
@@ -162,16 +163,11 @@ func (p *presenceManager) SetTyping(ctx context.Context, peerID string, typing b
 		return nil
 	}
 
-	action := "STARTED"
-	if !typing {
-		action = "STOPPED"
-	}
-
 	req := map[string]any{
 		"method": "sendTyping",
 		"params": map[string]any{
-			"recipient": peerID,
-			"action":    action,
+			"recipient": []string{peerID},
+			"stop":      !typing,
 		},
 	}
 
@@ -186,8 +182,10 @@ func (p *presenceManager) SetOnline(ctx context.Context, online bool) error {
 
 func (p *presenceManager) Subscribe(ctx context.Context, peerID string) (<-chan personal.PresenceEvent, error) {
 	// Signal typing notifications come through the main event stream
-	ch := make(chan personal.PresenceEvent, 10)
-	return ch, nil
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return p.adapter.typing.subscribe(ctx, peerID), nil
 }
 
 func (p *presenceManager) MarkRead(ctx context.Context, peerID string, messageID string) error {
@@ -195,16 +193,20 @@ func (p *presenceManager) MarkRead(ctx context.Context, peerID string, messageID
 		return nil
 	}
 
+	timestamp, err := strconv.ParseInt(strings.TrimSpace(messageID), 10, 64)
+	if err != nil {
+		return channels.ErrInvalidInput("signal message id must be a timestamp", err)
+	}
 	req := map[string]any{
 		"method": "sendReceipt",
 		"params": map[string]any{
-			"recipient":       peerID,
-			"targetTimestamp": messageID,
+			"recipient":       []string{peerID},
+			"targetTimestamp": []int64{timestamp},
 			"type":            "read",
 		},
 	}
 
-	_, err := p.adapter.call(ctx, req)
+	_, err = p.adapter.call(ctx, req)
 	return err
 }
 
@@ -0,0 +1,402 @@
+package signal
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/haasonsaas/nexus/internal/channels"
+)
+
+// rpcSession is one JSON-RPC connection to signal-cli: the stdio of a
+// "signal-cli jsonRpc" process or a socket to a running daemon.
+type rpcSession struct {
+	w   io.WriteCloser
+	r   io.Reader
+	cmd *exec.Cmd
+
+	writeMu   sync.Mutex
+	closeOnce sync.Once
+	closeFn   func() error
+}
+
+// write sends one newline-delimited JSON-RPC frame.
+func (s *rpcSession) write(data []byte) error {
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+	_, err := s.w.Write(append(data, '\n'))
+	return err
+}
+
+// close shuts the connection down; it is safe to call more than once.
+func (s *rpcSession) close() {
+	s.closeOnce.Do(func() {
+		if s.closeFn != nil {
+			_ = s.closeFn()
+		}
+	})
+}
+
+// exited reports whether a spawned signal-cli process has exited.
+func (s *rpcSession) exited() bool {
+	return s.cmd != nil && s.cmd.ProcessState != nil && s.cmd.ProcessState.Exited()
+}
+
+// daemonNetwork splits a daemon address into a dial network and address.
+// Addresses are host:port (optionally tcp://) or unix:///path.
+func daemonNetwork(address string) (string, string, error) {
+	address = strings.TrimSpace(address)
+	if address == "" {
+		return "", "", errors.New("daemon_address is required in daemon mode")
+	}
+	if path, ok := strings.CutPrefix(address, "unix://"); ok {
+		if path == "" {
+			return "", "", errors.New("unix socket path is empty")
+		}
+		return "unix", path, nil
+	}
+	address = strings.TrimPrefix(address, "tcp://")
+	if _, _, err := net.SplitHostPort(address); err != nil {
+		return "", "", err
+	}
+	return "tcp", address, nil
+}
+
+// dialDaemon connects to a signal-cli daemon.
+func dialDaemon(ctx context.Context, address string) (net.Conn, error) {
+	network, addr, err := daemonNetwork(address)
+	if err != nil {
+		return nil, channels.ErrConfig("invalid signal daemon address", err)
+	}
+	dialer := net.Dialer{Timeout: 10 * time.Second}
+	conn, err := dialer.DialContext(ctx, network, addr)
+	if err != nil {
+		return nil, channels.ErrConnection(fmt.Sprintf("failed to connect to signal-cli daemon at %s", address), err)
+	}
+	return conn, nil
+}
+
+// openSession starts signal-cli or connects to the daemon, depending on the
+// configured mode.
+func (a *Adapter) openSession(ctx context.Context) (*rpcSession, error) {
+	if a.config.daemonMode() {
+		conn, err := dialDaemon(ctx, a.config.DaemonAddress)
+		if err != nil {
+			return nil, err
+		}
+		return &rpcSession{w: conn, r: conn, closeFn: conn.Close}, nil
+	}
+
+	args := []string{
+		"--output=json",
+		"-a", a.config.Account,
+	}
+	if a.config.ConfigDir != "" {
+		args = append(args, "--config", expandPath(a.config.ConfigDir))
+	}
+	args = append(args, "jsonRpc")
+
+	cmd := exec.CommandContext(ctx, a.config.SignalCLIPath, args...)
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, channels.ErrConnection("failed to create stdin pipe", err)
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, channels.ErrConnection("failed to create stdout pipe", err)
+	}
+	stderr, err := cmd.StderrPipe()
+	if err != nil {
+		return nil, channels.ErrConnection("failed to create stderr pipe", err)
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, channels.ErrConnection("failed to start signal-cli", err)
+	}
+
+	a.wg.Add(1)
+	go a.stderrLoop(ctx, stderr)
+
+	return &rpcSession{
+		w:   stdin,
+		r:   stdout,
+		cmd: cmd,
+		closeFn: func() error {
+			stdin.Close()
+			if err := cmd.Wait(); err != nil {
+				a.Logger().Debug("signal process wait returned error", "error", err)
+			}
+			return nil
+		},
+	}, nil
+}
+
+// run serves the session until it drops, then reconnects (or restarts
+// signal-cli) after the reconnect delay until ctx is cancelled.
+func (a *Adapter) run(ctx context.Context, session *rpcSession) {
+	defer a.wg.Done()
+
+	for {
+		a.receiveLoop(ctx, session.r)
+		a.dropSession(session)
+		if ctx.Err() != nil {
+			return
+		}
+
+		a.failPending("signal-cli connection closed")
+		if !a.relinkRequired() {
+			a.SetStatus(false, "signal-cli connection lost")
+		}
+		a.Logger().Warn("signal-cli connection lost; reconnecting", "delay", a.config.reconnectDelay())
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(a.config.reconnectDelay()):
+			}
+			next, err := a.openSession(ctx)
+			if err != nil {
+				a.Logger().Warn("signal-cli reconnect failed", "error", err)
+				continue
+			}
+			a.setSession(next)
+			if !a.relinkRequired() {
+				a.SetStatus(true, "")
+			}
+			session = next
+			break
+		}
+	}
+}
+
+// receiveLoop reads and processes JSON-RPC frames until the stream ends.
+func (a *Adapter) receiveLoop(ctx context.Context, r io.Reader) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 1024*1024), 1024*1024) // 1MB buffer
+
+	for scanner.Scan() {
+		select {
+		case <-ctx.Done():
+			return
+		default:
+		}
+
+		line := scanner.Text()
+		if line == "" {
+			continue
+		}
+
+		a.processLine(line)
+	}
+
+	if err := scanner.Err(); err != nil && ctx.Err() == nil {
+		a.Logger().Error("scanner error", "error", err)
+	}
+}
+
+// stderrLoop logs signal-cli stderr and watches it for relink errors.
+func (a *Adapter) stderrLoop(ctx context.Context, r io.Reader) {
+	defer a.wg.Done()
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		select {
+		case <-ctx.Done():
+			return
+		default:
+		}
+
+		line := scanner.Text()
+		if line == "" {
+			continue
+		}
+		if !a.detectRelink(line) {
+			a.Logger().Warn("signal-cli stderr", "message", line)
+		}
+	}
+}
+
+func (a *Adapter) currentSession() *rpcSession {
+	if a == nil {
+		return nil
+	}
+	a.sessionMu.RLock()
+	defer a.sessionMu.RUnlock()
+	return a.session
+}
+
+func (a *Adapter) setSession(session *rpcSession) {
+	a.sessionMu.Lock()
+	a.session = session
+	a.sessionMu.Unlock()
+}
+
+// dropSession closes session and clears it if it is still current.
+func (a *Adapter) dropSession(session *rpcSession) {
+	a.sessionMu.Lock()
+	if a.session == session {
+		a.session = nil
+	}
+	a.sessionMu.Unlock()
+	session.close()
+}
+
+// failPending releases callers waiting on a connection that has gone away.
+func (a *Adapter) failPending(reason string) {
+	a.pendingMu.Lock()
+	defer a.pendingMu.Unlock()
+	for id, ch := range a.pending {
+		delete(a.pending, id)
+		a.recordRPCError(id, &jsonRPCError{Code: -1, Message: reason})
+		select {
+		case ch <- nil:
+		default:
+		}
+	}
+}
+
+// recordRPCError remembers the error response for a request. Callers must
+// hold pendingMu.
+func (a *Adapter) recordRPCError(id int64, rpcErr *jsonRPCError) {
+	if a.rpcErrors == nil {
+		a.rpcErrors = make(map[int64]*jsonRPCError)
+	}
+	a.rpcErrors[id] = rpcErr
+}
+
+// takeRPCError returns and forgets the error response for a request.
+func (a *Adapter) takeRPCError(id int64) *jsonRPCError {
+	a.pendingMu.Lock()
+	defer a.pendingMu.Unlock()
+	rpcErr := a.rpcErrors[id]
+	delete(a.rpcErrors, id)
+	return rpcErr
+}
+
+// relinkMarkers are substrings of signal-cli errors raised when the account
+// has been unregistered or this linked device was removed.
+var relinkMarkers = []string{
+	"is not registered",
+	"authorizationfailed",
+	"authorization failed",
+	"device was unlinked",
+	"not linked",
+	"deviceremoved",
+}
+
+// needsRelink reports whether a signal-cli error means the account must be
+// linked again.
+func needsRelink(message string) bool {
+	message = strings.ToLower(message)
+	for _, marker := range relinkMarkers {
+		if strings.Contains(message, marker) {
+			return true
+		}
+	}
+	return false
+}
+
+// detectRelink flags the adapter for relinking when message is a relink
+// error, returning true if it was.
+func (a *Adapter) detectRelink(message string) bool {
+	if !needsRelink(message) {
+		return false
+	}
+	if !a.relink.Swap(true) {
+		a.Logger().Error("signal account needs relinking", "error", message, "hint", a.relinkGuidance())
+	}
+	a.SetStatus(false, a.relinkGuidance())
+	return true
+}
+
+// relinkRequired reports whether signal-cli has rejected the account.
+func (a *Adapter) relinkRequired() bool {
+	return a.relink.Load()
+}
+
+// relinkGuidance explains how to restore a rejected account.
+func (a *Adapter) relinkGuidance() string {
+	return fmt.Sprintf("signal account %s is no longer registered or linked: run `signal-cli link -n nexus` and scan the QR code "+
+		"under Signal > Settings > Linked devices (or re-register the number), restart the daemon, then check with `nexus doctor --probe`",
+		a.config.Account)
+}
+
+// probeDaemon checks that the daemon answers and still accepts the account.
+// It is used by health checks before the adapter has started.
+func (a *Adapter) probeDaemon(ctx context.Context) channels.HealthStatus {
+	start := time.Now()
+	status := func(healthy bool, message string) channels.HealthStatus {
+		return channels.HealthStatus{Healthy: healthy, Message: message, Latency: time.Since(start), LastCheck: time.Now()}
+	}
+
+	conn, err := dialDaemon(ctx, a.config.DaemonAddress)
+	if err != nil {
+		return status(false, err.Error())
+	}
+	defer conn.Close()
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	} else {
+		_ = conn.SetDeadline(time.Now().Add(10 * time.Second))
+	}
+
+	session := &rpcSession{w: conn, r: conn}
+	reader := bufio.NewScanner(conn)
+	reader.Buffer(make([]byte, 1024*1024), 1024*1024)
+	request := func(id int64, method string) (json.RawMessage, *jsonRPCError, error) {
+		req := map[string]any{"jsonrpc": "2.0", "id": id, "method": method}
+		if a.config.Account != "" {
+			req["params"] = map[string]any{"account": a.config.Account}
+		}
+		data, err := json.Marshal(req)
+		if err != nil {
+			return nil, nil, err
+		}
+		if err := session.write(data); err != nil {
+			return nil, nil, err
+		}
+		for reader.Scan() {
+			var msg jsonRPCMessage
+			if json.Unmarshal(reader.Bytes(), &msg) != nil || msg.ID == nil || *msg.ID != id {
+				continue
+			}
+			return msg.Result, msg.Error, nil
+		}
+		if err := reader.Err(); err != nil {
+			return nil, nil, err
+		}
+		return nil, nil, io.EOF
+	}
+
+	version, rpcErr, err := request(1, "version")
+	if err != nil {
+		return status(false, "signal-cli daemon did not answer: "+err.Error())
+	}
+	if rpcErr != nil {
+		return status(false, "signal-cli daemon error: "+rpcErr.Message)
+	}
+	_, rpcErr, err = request(2, "listDevices")
+	if err != nil {
+		return status(false, "signal-cli daemon did not answer: "+err.Error())
+	}
+	if rpcErr != nil && needsRelink(rpcErr.Message) {
+		return status(false, a.relinkGuidance())
+	}
+
+	var info struct {
+		Version string `json:"version"`
+	}
+	_ = json.Unmarshal(version, &info)
+	if info.Version != "" {
+		return status(true, "daemon reachable (signal-cli "+info.Version+")")
+	}
+	return status(true, "daemon reachable")
+}
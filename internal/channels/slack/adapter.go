@@ -12,6 +12,7 @@ import (
 	"time"
 
 	"github.com/haasonsaas/nexus/internal/channels"
+	"github.com/haasonsaas/nexus/internal/supervisor"
 	"github.com/haasonsaas/nexus/pkg/models"
 	"github.com/slack-go/slack"
 	"github.com/slack-go/slack/slackevents"
@@ -124,6 +125,7 @@ type Adapter struct {
 	client       *slack.Client
 	socketClient *socketmode.Client
 	messages     chan *models.Message
+	reactions    chan *channels.ReactionEvent
 	ctx          context.Context
 	cancel       context.CancelFunc
 	wg           sync.WaitGroup
@@ -134,6 +136,15 @@ type Adapter struct {
 	health       *channels.BaseHealthAdapter
 	canvasMu     sync.RWMutex
 	canvasLinker CanvasLinkProvider
+
+	// threadParents caches fetched thread roots by "channel:ts".
+	threadParentsMu sync.Mutex
+	threadParents   map[string]*channels.ReplyReference
+
+	// botThreads records the threads the bot has replied in by "channel:ts",
+	// so follow-ups there count as addressed to the bot.
+	botThreadsMu sync.Mutex
+	botThreads   map[string]struct{}
 }
 
 // NewAdapter creates a new Slack adapter with the given configuration.
@@ -158,6 +169,7 @@ func NewAdapter(cfg Config) (*Adapter, error) {
 		client:       client,
 		socketClient: socketClient,
 		messages:     make(chan *models.Message, 100),
+		reactions:    make(chan *channels.ReactionEvent, 100),
 		rateLimiter:  channels.NewRateLimiter(cfg.RateLimit, cfg.RateBurst),
 		logger:       cfg.Logger.With("adapter", "slack"),
 	}
@@ -241,6 +253,7 @@ func (a *Adapter) Stop(ctx context.Context) error {
 	case <-done:
 		// Goroutines finished - safe to close messages channel now
 		close(a.messages)
+		close(a.reactions)
 		a.updateStatus(false, "")
 		a.health.RecordConnectionClosed()
 		a.logger.Info("slack adapter stopped gracefully")
@@ -248,6 +261,7 @@ func (a *Adapter) Stop(ctx context.Context) error {
 	case <-ctx.Done():
 		// Timeout - still close the channel but some messages may be lost
 		close(a.messages)
+		close(a.reactions)
 		a.updateStatus(false, "shutdown timeout")
 		a.logger.Warn("slack adapter stop timeout")
 		a.health.RecordError(channels.ErrCodeTimeout)
@@ -285,7 +299,8 @@ func (a *Adapter) Send(ctx context.Context, msg *models.Message) error {
 	options := buildBlockKitMessage(msg)
 
 	// Add thread timestamp if this is a reply
-	if threadTS, ok := msg.Metadata["slack_thread_ts"].(string); ok && threadTS != "" {
+	threadTS, _ := msg.Metadata["slack_thread_ts"].(string)
+	if threadTS != "" {
 		options = append(options, slack.MsgOptionTS(threadTS))
 	}
 
@@ -306,6 +321,11 @@ func (a *Adapter) Send(ctx context.Context, msg *models.Message) error {
 		a.health.RecordError(channels.ErrCodeInternal)
 		return channels.ErrInternal("failed to send Slack message", err)
 	}
+	a.rememberBotThread(channelID, threadTS)
+
+	if timestamp != "" {
+		msg.ChannelID = timestamp
+	}
 
 	// Record success metrics
 	a.health.RecordMessageSent()
@@ -348,6 +368,11 @@ func (a *Adapter) Messages() <-chan *models.Message {
 	return a.messages
 }
 
+// Reactions returns a channel of reactions added to or removed from messages.
+func (a *Adapter) Reactions() <-chan *channels.ReactionEvent {
+	return a.reactions
+}
+
 // Type returns the channel type.
 func (a *Adapter) Type() models.ChannelType {
 	return models.ChannelSlack
@@ -407,13 +432,21 @@ func (a *Adapter) Metrics() channels.MetricsSnapshot {
 	return a.health.Metrics()
 }
 
-// handleEvents processes incoming Socket Mode events.
+// handleEvents processes incoming Socket Mode events. If handling an event
+// panics, the loop is restarted with backoff instead of crashing the gateway.
 func (a *Adapter) handleEvents() {
 	defer a.wg.Done()
 
+	_ = supervisor.Run(a.ctx, "channel:slack", supervisor.DefaultConfig(), func(ctx context.Context) error {
+		a.eventLoop(ctx)
+		return nil
+	})
+}
+
+func (a *Adapter) eventLoop(ctx context.Context) {
 	for {
 		select {
-		case <-a.ctx.Done():
+		case <-ctx.Done():
 			a.logger.Info("event handler stopped")
 			return
 		case event, ok := <-a.socketClient.Events:
@@ -451,7 +484,10 @@ func (a *Adapter) handleEvents() {
 					a.logger.Warn("could not type cast slash command payload")
 					continue
 				}
-				go a.handleSlashCommand(command)
+				go func() {
+					defer supervisor.Recover("channel:slack")
+					a.handleSlashCommand(command)
+				}()
 
 			case socketmode.EventTypeInteractive:
 				if event.Request != nil {
@@ -462,7 +498,10 @@ func (a *Adapter) handleEvents() {
 					a.logger.Warn("could not type cast interactive payload")
 					continue
 				}
-				go a.handleInteractive(callback)
+				go func() {
+					defer supervisor.Recover("channel:slack")
+					a.handleInteractive(callback)
+				}()
 			}
 		}
 	}
@@ -497,10 +536,36 @@ func (a *Adapter) handleEventsAPI(event socketmode.Event) {
 			}
 
 			a.handleMessage(ev)
+
+		case *slackevents.ReactionAddedEvent:
+			a.handleReaction(convertSlackReaction(ev.User, ev.Reaction, ev.Item, ev.EventTimestamp, false))
+
+		case *slackevents.ReactionRemovedEvent:
+			a.handleReaction(convertSlackReaction(ev.User, ev.Reaction, ev.Item, ev.EventTimestamp, true))
 		}
 	}
 }
 
+// handleReaction forwards reactions from users other than the bot.
+func (a *Adapter) handleReaction(event *channels.ReactionEvent) {
+	if event == nil {
+		return
+	}
+	a.botUserIDMu.RLock()
+	botUserID := a.botUserID
+	a.botUserIDMu.RUnlock()
+	if botUserID != "" && event.UserID == botUserID {
+		return
+	}
+	select {
+	case a.reactions <- event:
+	case <-a.ctx.Done():
+	default:
+		a.logger.Warn("reactions channel full, dropping reaction",
+			"channel", event.ChatID)
+	}
+}
+
 func (a *Adapter) handleSlashCommand(command slack.SlashCommand) {
 	if !a.cfg.Canvas.Enabled {
 		return
@@ -631,6 +696,9 @@ func (a *Adapter) handleMessage(event *slackevents.MessageEvent) {
 
 	// Convert Slack message to unified format
 	msg := convertSlackMessage(event, a.cfg.BotToken)
+	if isMention || a.inBotThread(event.Channel, event.ThreadTimeStamp) {
+		channels.SetMentioned(msg)
+	}
 
 	// Record metrics
 	a.health.RecordMessageReceived()
@@ -747,6 +815,13 @@ func convertSlackMessage(event *slackevents.MessageEvent, botToken string) *mode
 	if strings.HasPrefix(event.Channel, "D") {
 		msg.Metadata["conversation_type"] = "dm"
 	}
+	if event.ThreadTimeStamp != "" && event.ThreadTimeStamp != event.TimeStamp {
+		channels.SetReplyReference(msg, &channels.ReplyReference{
+			MessageID:    event.ThreadTimeStamp,
+			ChatID:       event.Channel,
+			ThreadParent: true,
+		})
+	}
 
 	// Process file attachments from the Message field if present
 	if event.Message != nil && len(event.Message.Files) > 0 {
@@ -768,6 +843,90 @@ func convertSlackMessage(event *slackevents.MessageEvent, botToken string) *mode
 	return msg
 }
 
+// maxCachedThreadParents bounds the thread root cache.
+const maxCachedThreadParents = 512
+
+// maxBotThreads bounds the set of threads the bot has replied in.
+const maxBotThreads = 1024
+
+func (a *Adapter) rememberBotThread(channelID, threadTS string) {
+	if channelID == "" || threadTS == "" {
+		return
+	}
+	a.botThreadsMu.Lock()
+	defer a.botThreadsMu.Unlock()
+	if a.botThreads == nil || len(a.botThreads) >= maxBotThreads {
+		a.botThreads = make(map[string]struct{})
+	}
+	a.botThreads[channelID+":"+threadTS] = struct{}{}
+}
+
+func (a *Adapter) inBotThread(channelID, threadTS string) bool {
+	if threadTS == "" {
+		return false
+	}
+	a.botThreadsMu.Lock()
+	defer a.botThreadsMu.Unlock()
+	_, ok := a.botThreads[channelID+":"+threadTS]
+	return ok
+}
+
+// ResolveReplyContext fetches the root message of the thread a message was
+// posted in. This is part of the ReplyContextAdapter interface.
+func (a *Adapter) ResolveReplyContext(ctx context.Context, msg *models.Message, ref channels.ReplyReference) (*channels.ReplyReference, error) {
+	if a.client == nil {
+		return nil, channels.ErrInternal("client not initialized (check adapter setup)", nil)
+	}
+	if ref.ChatID == "" {
+		ref.ChatID, _ = msg.Metadata["slack_channel"].(string)
+	}
+	if ref.ChatID == "" || ref.MessageID == "" {
+		return nil, channels.ErrInvalidInput("reply reference has no channel or timestamp", nil)
+	}
+	key := ref.ChatID + ":" + ref.MessageID
+	a.threadParentsMu.Lock()
+	cached := a.threadParents[key]
+	a.threadParentsMu.Unlock()
+	if cached != nil {
+		resolved := *cached
+		resolved.Quote = ref.Quote
+		return &resolved, nil
+	}
+
+	if err := a.rateLimiter.Wait(ctx); err != nil {
+		return nil, channels.ErrTimeout("rate limit wait cancelled", err)
+	}
+	replies, _, _, err := a.client.GetConversationRepliesContext(ctx, &slack.GetConversationRepliesParameters{
+		ChannelID: ref.ChatID,
+		Timestamp: ref.MessageID,
+		Inclusive: true,
+		Limit:     1,
+	})
+	if err != nil {
+		return nil, channels.ErrConnection("failed to fetch thread parent", err)
+	}
+	if len(replies) == 0 {
+		return nil, channels.ErrNotFound("thread parent not found", nil)
+	}
+	parent := replies[0]
+	ref.Content = parent.Text
+	ref.SenderID = parent.User
+	ref.SenderName = parent.Username
+	ref.FromBot = parent.BotID != ""
+	if ts, err := parseSlackTimestamp(parent.Timestamp); err == nil {
+		ref.SentAt = ts
+	}
+
+	a.threadParentsMu.Lock()
+	if a.threadParents == nil || len(a.threadParents) >= maxCachedThreadParents {
+		a.threadParents = make(map[string]*channels.ReplyReference)
+	}
+	cachedRef := ref
+	a.threadParents[key] = &cachedRef
+	a.threadParentsMu.Unlock()
+	return &ref, nil
+}
+
 // buildBlockKitMessage creates Block Kit formatted message options.
 func buildBlockKitMessage(msg *models.Message) []slack.MsgOption {
 	options := []slack.MsgOption{}
@@ -819,6 +978,27 @@ func getAttachmentType(mimeType string) string {
 	}
 }
 
+// convertSlackReaction converts a reaction_added or reaction_removed event.
+// Reactions on files and file comments are ignored.
+func convertSlackReaction(user, reaction string, item slackevents.Item, eventTS string, removed bool) *channels.ReactionEvent {
+	if item.Type != "message" || item.Timestamp == "" {
+		return nil
+	}
+	timestamp, err := parseSlackTimestamp(eventTS)
+	if err != nil {
+		timestamp = time.Now()
+	}
+	return &channels.ReactionEvent{
+		Channel:   models.ChannelSlack,
+		ChatID:    item.Channel,
+		MessageID: item.Timestamp,
+		UserID:    user,
+		Emoji:     reaction,
+		Removed:   removed,
+		Timestamp: timestamp,
+	}
+}
+
 // generateSessionID creates a deterministic session ID for a Slack thread.
 func generateSessionID(channel, threadTS string) string {
 	data := fmt.Sprintf("slack:%s:%s", channel, threadTS)
@@ -964,7 +1144,8 @@ func (a *Adapter) StartStreamingResponse(ctx context.Context, msg *models.Messag
 	}
 
 	// Thread the response if there's a thread timestamp
-	if threadTS, ok := msg.Metadata["slack_thread_ts"].(string); ok && threadTS != "" {
+	threadTS, _ := msg.Metadata["slack_thread_ts"].(string)
+	if threadTS != "" {
 		options = append(options, slack.MsgOptionTS(threadTS))
 	}
 
@@ -974,6 +1155,7 @@ func (a *Adapter) StartStreamingResponse(ctx context.Context, msg *models.Messag
 		a.health.RecordMessageFailed()
 		return "", channels.ErrInternal("failed to send initial message", err)
 	}
+	a.rememberBotThread(channelID, threadTS)
 
 	a.health.RecordMessageSent()
 	return timestamp, nil
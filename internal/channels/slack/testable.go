@@ -18,6 +18,7 @@ type TestableAdapter struct {
 	cfg         Config
 	apiClient   SlackAPIClient
 	messages    chan *models.Message
+	reactions   chan *channels.ReactionEvent
 	ctx         context.Context
 	cancel      context.CancelFunc
 	wg          sync.WaitGroup
@@ -38,6 +39,7 @@ func NewTestableAdapter(cfg Config, apiClient SlackAPIClient) (*TestableAdapter,
 		cfg:         cfg,
 		apiClient:   apiClient,
 		messages:    make(chan *models.Message, 100),
+		reactions:   make(chan *channels.ReactionEvent, 100),
 		rateLimiter: channels.NewRateLimiter(cfg.RateLimit, cfg.RateBurst),
 		logger:      cfg.Logger.With("adapter", "slack-testable"),
 	}
@@ -55,6 +57,11 @@ func (a *TestableAdapter) Messages() <-chan *models.Message {
 	return a.messages
 }
 
+// Reactions returns the inbound reaction channel.
+func (a *TestableAdapter) Reactions() <-chan *channels.ReactionEvent {
+	return a.reactions
+}
+
 // Status returns the current connection status.
 func (a *TestableAdapter) Status() channels.Status {
 	if a.health == nil {
@@ -98,6 +105,7 @@ func (a *TestableAdapter) Stop(ctx context.Context) error {
 	}
 
 	close(a.messages)
+	close(a.reactions)
 
 	done := make(chan struct{})
 	go func() {
@@ -156,6 +164,10 @@ func (a *TestableAdapter) Send(ctx context.Context, msg *models.Message) error {
 		return channels.ErrInternal("failed to send Slack message", err)
 	}
 
+	if timestamp != "" {
+		msg.ChannelID = timestamp
+	}
+
 	a.health.RecordMessageSent()
 	a.health.RecordSendLatency(time.Since(startTime))
 
@@ -241,6 +253,27 @@ func (a *TestableAdapter) ProcessMessage(event *slackevents.MessageEvent) {
 	}
 }
 
+// ProcessReaction simulates receiving a reaction_added event for testing.
+func (a *TestableAdapter) ProcessReaction(event *slackevents.ReactionAddedEvent) {
+	reaction := convertSlackReaction(event.User, event.Reaction, event.Item, event.EventTimestamp, false)
+	if reaction == nil {
+		return
+	}
+
+	a.botUserIDMu.RLock()
+	botUserID := a.botUserID
+	a.botUserIDMu.RUnlock()
+	if botUserID != "" && reaction.UserID == botUserID {
+		return
+	}
+
+	select {
+	case a.reactions <- reaction:
+	case <-a.ctx.Done():
+	default:
+	}
+}
+
 // ProcessAppMention simulates receiving an app mention for testing.
 func (a *TestableAdapter) ProcessAppMention(event *slackevents.AppMentionEvent) {
 	msgEvent := &slackevents.MessageEvent{
@@ -21,6 +21,7 @@ import (
 	"github.com/go-telegram/bot/models"
 	"github.com/haasonsaas/nexus/internal/channels"
 	channelcontext "github.com/haasonsaas/nexus/internal/channels/context"
+	"github.com/haasonsaas/nexus/internal/supervisor"
 	nexusmodels "github.com/haasonsaas/nexus/pkg/models"
 )
 
@@ -63,6 +64,12 @@ type Config struct {
 	// RateBurst configures the burst capacity for rate limiting
 	RateBurst int
 
+	// ChannelPosts delivers posts in channels the bot administers
+	ChannelPosts bool
+
+	// EditedMessages delivers edits of earlier messages as new inbound messages
+	EditedMessages bool
+
 	// Logger is an optional slog.Logger instance
 	Logger *slog.Logger
 }
@@ -112,12 +119,16 @@ type Adapter struct {
 	bot         *bot.Bot
 	botClient   BotClient // Interface for testability
 	messages    chan *nexusmodels.Message
+	reactions   chan *channels.ReactionEvent
 	cancel      context.CancelFunc
 	wg          sync.WaitGroup
 	rateLimiter *channels.RateLimiter
 	logger      *slog.Logger
 	httpClient  *http.Client
 	health      *channels.BaseHealthAdapter
+
+	// me is the bot's own user, used to detect mentions and replies.
+	me *models.User
 }
 
 // NewAdapter creates a new Telegram adapter with the given configuration.
@@ -130,6 +141,7 @@ func NewAdapter(config Config) (*Adapter, error) {
 	a := &Adapter{
 		config:      config,
 		messages:    make(chan *nexusmodels.Message, 100),
+		reactions:   make(chan *channels.ReactionEvent, 100),
 		rateLimiter: channels.NewRateLimiter(config.RateLimit, config.RateBurst),
 		logger:      config.Logger.With("adapter", "telegram"),
 		httpClient:  &http.Client{Timeout: 30 * time.Second},
@@ -156,7 +168,7 @@ func (a *Adapter) Start(ctx context.Context) error {
 		"rate_limit", a.config.RateLimit)
 
 	// Initialize bot
-	opts := []bot.Option{}
+	opts := []bot.Option{bot.WithAllowedUpdates(allowedUpdates)}
 	b, err := bot.New(a.config.Token, opts...)
 	if err != nil {
 		a.updateStatus(false, fmt.Sprintf("failed to create bot: %v", err))
@@ -168,6 +180,12 @@ func (a *Adapter) Start(ctx context.Context) error {
 	a.botClient = newRealBotClient(b)
 	a.health.RecordConnectionOpened()
 
+	if me, err := a.botClient.GetMe(ctx); err != nil {
+		a.logger.Warn("failed to look up bot user; mentions will not be detected", "error", err)
+	} else {
+		a.me = me
+	}
+
 	// Start message handler
 	a.wg.Add(1)
 	go a.runWithReconnection(ctx)
@@ -180,6 +198,7 @@ func (a *Adapter) Start(ctx context.Context) error {
 func (a *Adapter) runWithReconnection(ctx context.Context) {
 	defer a.wg.Done()
 	defer close(a.messages)
+	defer close(a.reactions)
 
 	attempts := 0
 	reconnector := &channels.Reconnector{
@@ -190,8 +209,9 @@ func (a *Adapter) runWithReconnection(ctx context.Context) {
 			Factor:       2,
 			Jitter:       true,
 		},
-		Logger: a.logger,
-		Health: a.health,
+		Logger:    a.logger,
+		Health:    a.health,
+		Component: "channel:telegram",
 	}
 
 	err := reconnector.Run(ctx, func(runCtx context.Context) error {
@@ -234,11 +254,7 @@ func (a *Adapter) run(ctx context.Context) error {
 func (a *Adapter) runLongPolling(ctx context.Context) error {
 	a.logger.Info("starting long polling mode")
 
-	// Register text message handler
-	a.botClient.RegisterHandler(bot.HandlerTypeMessageText, "", bot.MatchTypePrefix, a.handleMessage)
-
-	// Register handler for voice and media messages (these don't have text)
-	a.botClient.RegisterHandlerMatchFunc(a.matchMediaMessage, a.handleMessage)
+	a.registerHandlers()
 
 	// Start bot (this blocks until context is cancelled)
 	a.botClient.Start(ctx)
@@ -252,18 +268,15 @@ func (a *Adapter) runWebhook(ctx context.Context) error {
 
 	// Set webhook
 	_, err := a.botClient.SetWebhook(ctx, &bot.SetWebhookParams{
-		URL: a.config.WebhookURL,
+		URL:            a.config.WebhookURL,
+		AllowedUpdates: allowedUpdates,
 	})
 	if err != nil {
 		a.health.RecordError(channels.ErrCodeConnection)
 		return channels.ErrConnection("failed to set webhook", err)
 	}
 
-	// Register text message handler
-	a.botClient.RegisterHandler(bot.HandlerTypeMessageText, "", bot.MatchTypePrefix, a.handleMessage)
-
-	// Register handler for voice and media messages (these don't have text)
-	a.botClient.RegisterHandlerMatchFunc(a.matchMediaMessage, a.handleMessage)
+	a.registerHandlers()
 
 	// Start webhook server
 	go a.botClient.StartWebhook(ctx)
@@ -274,6 +287,29 @@ func (a *Adapter) runWebhook(ctx context.Context) error {
 	return nil
 }
 
+// registerHandlers registers the update handlers shared by long polling and
+// webhook mode.
+func (a *Adapter) registerHandlers() {
+	// Register text message handler
+	a.botClient.RegisterHandler(bot.HandlerTypeMessageText, "", bot.MatchTypePrefix, a.handleMessage)
+
+	// Register handler for voice and media messages (these don't have text)
+	a.botClient.RegisterHandlerMatchFunc(a.matchMediaMessage, a.handleMessage)
+
+	// Register handler for reactions on messages
+	a.botClient.RegisterHandlerMatchFunc(matchReaction, a.handleReaction)
+
+	// Register handler for inline keyboard buttons that send commands
+	a.botClient.RegisterHandlerMatchFunc(matchCommandCallback, a.handleCommandCallback)
+
+	if a.config.ChannelPosts {
+		a.botClient.RegisterHandlerMatchFunc(matchChannelPost, a.handleMessage)
+	}
+	if a.config.EditedMessages {
+		a.botClient.RegisterHandlerMatchFunc(a.matchEditedMessage, a.handleMessage)
+	}
+}
+
 // matchMediaMessage is a custom match function that matches messages with media
 // content (voice, photo, document, audio) but no text. This ensures we handle
 // voice messages and other media that the text handler won't catch.
@@ -292,20 +328,199 @@ func (a *Adapter) matchMediaMessage(update *models.Update) bool {
 		update.Message.Document != nil
 }
 
+// allowedUpdates lists the update types requested from Telegram. Reactions
+// and channel posts are not delivered unless explicitly requested.
+var allowedUpdates = bot.AllowedUpdates{"message", "edited_message", "channel_post", "edited_channel_post", "callback_query", "message_reaction"}
+
+// matchChannelPost matches new posts in channels.
+func matchChannelPost(update *models.Update) bool {
+	return update.ChannelPost != nil
+}
+
+// matchEditedMessage matches edits of chat messages, and of channel posts
+// when those are enabled.
+func (a *Adapter) matchEditedMessage(update *models.Update) bool {
+	return update.EditedMessage != nil || (a.config.ChannelPosts && update.EditedChannelPost != nil)
+}
+
+// updateMessage returns the message carried by a message, channel post or
+// edit update, and whether it is an edit.
+func updateMessage(update *models.Update) (*models.Message, bool) {
+	switch {
+	case update.Message != nil:
+		return update.Message, false
+	case update.ChannelPost != nil:
+		return update.ChannelPost, false
+	case update.EditedMessage != nil:
+		return update.EditedMessage, true
+	case update.EditedChannelPost != nil:
+		return update.EditedChannelPost, true
+	}
+	return nil, false
+}
+
+// matchReaction matches message reaction updates.
+func matchReaction(update *models.Update) bool {
+	return update.MessageReaction != nil
+}
+
+// handleReaction converts a reaction update into added and removed reaction
+// events.
+func (a *Adapter) handleReaction(ctx context.Context, b *bot.Bot, update *models.Update) {
+	defer supervisor.Recover("channel:telegram")
+
+	for _, event := range convertReactionUpdate(update.MessageReaction) {
+		select {
+		case a.reactions <- event:
+		case <-ctx.Done():
+			return
+		default:
+			a.logger.Warn("reactions channel full, dropping reaction",
+				"chat_id", event.ChatID)
+		}
+	}
+}
+
+// convertReactionUpdate diffs the old and new reactions of an update. Custom
+// and paid reactions are ignored.
+func convertReactionUpdate(update *models.MessageReactionUpdated) []*channels.ReactionEvent {
+	if update == nil {
+		return nil
+	}
+	userID := ""
+	if update.User != nil {
+		userID = strconv.FormatInt(update.User.ID, 10)
+	} else if update.ActorChat != nil {
+		userID = strconv.FormatInt(update.ActorChat.ID, 10)
+	}
+	emojis := func(reactions []models.ReactionType) map[string]bool {
+		out := make(map[string]bool, len(reactions))
+		for _, reaction := range reactions {
+			if emoji := reactionEmoji(reaction); emoji != "" {
+				out[emoji] = true
+			}
+		}
+		return out
+	}
+	oldEmojis := emojis(update.OldReaction)
+	newEmojis := emojis(update.NewReaction)
+
+	base := channels.ReactionEvent{
+		Channel:   nexusmodels.ChannelTelegram,
+		ChatID:    strconv.FormatInt(update.Chat.ID, 10),
+		MessageID: strconv.Itoa(update.MessageID),
+		UserID:    userID,
+		Timestamp: time.Unix(int64(update.Date), 0),
+	}
+	var events []*channels.ReactionEvent
+	for _, reaction := range update.OldReaction {
+		if emoji := reactionEmoji(reaction); emoji != "" && !newEmojis[emoji] {
+			event := base
+			event.Emoji = emoji
+			event.Removed = true
+			events = append(events, &event)
+		}
+	}
+	for _, reaction := range update.NewReaction {
+		if emoji := reactionEmoji(reaction); emoji != "" && !oldEmojis[emoji] {
+			event := base
+			event.Emoji = emoji
+			events = append(events, &event)
+		}
+	}
+	return events
+}
+
+func reactionEmoji(reaction models.ReactionType) string {
+	if reaction.ReactionTypeEmoji == nil {
+		return ""
+	}
+	return reaction.ReactionTypeEmoji.Emoji
+}
+
+// Reactions returns a channel of reactions added to or removed from messages.
+func (a *Adapter) Reactions() <-chan *channels.ReactionEvent {
+	return a.reactions
+}
+
+// matchCommandCallback matches presses of inline keyboard buttons whose
+// callback data is a slash command, such as the approve and reject buttons
+// of memory review.
+func matchCommandCallback(update *models.Update) bool {
+	query := update.CallbackQuery
+	return query != nil && query.Message.Message != nil && strings.HasPrefix(query.Data, "/")
+}
+
+// handleCommandCallback acknowledges a command button and delivers its
+// command as a message from the user who pressed it, in the chat of the
+// message the button is on.
+func (a *Adapter) handleCommandCallback(ctx context.Context, b *bot.Bot, update *models.Update) {
+	defer supervisor.Recover("channel:telegram")
+
+	query := update.CallbackQuery
+	if _, err := a.botClient.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{CallbackQueryID: query.ID}); err != nil {
+		a.logger.Debug("failed to answer callback query", "error", err)
+	}
+	msg := convertCommandCallback(a.convertMessage, query)
+	if msg == nil {
+		return
+	}
+	a.health.RecordMessageReceived()
+	select {
+	case a.messages <- msg:
+		a.updateLastPing()
+	case <-ctx.Done():
+	default:
+		a.logger.Warn("messages channel full, dropping button press",
+			"chat_id", msg.Metadata["chat_id"])
+		a.health.RecordMessageFailed()
+	}
+}
+
+// convertCommandCallback turns a command button press into a message whose
+// text is the button's command.
+func convertCommandCallback(convert func(*models.Message) *nexusmodels.Message, query *models.CallbackQuery) *nexusmodels.Message {
+	if query == nil || query.Message.Message == nil {
+		return nil
+	}
+	source := *query.Message.Message
+	from := query.From
+	source.From = &from
+	source.Text = query.Data
+	source.Entities = nil
+	source.Caption = ""
+	source.ReplyToMessage = nil
+	source.Quote = nil
+	source.ExternalReply = nil
+	source.Date = int(time.Now().Unix())
+	msg := convert(&source)
+	msg.ID = "tg_callback_" + query.ID
+	msg.Metadata["telegram_callback"] = true
+	channels.SetMentioned(msg)
+	return msg
+}
+
 // handleMessage processes incoming Telegram messages.
 func (a *Adapter) handleMessage(ctx context.Context, b *bot.Bot, update *models.Update) {
+	defer supervisor.Recover("channel:telegram")
+
 	startTime := time.Now()
 
-	if update.Message == nil {
+	message, edited := updateMessage(update)
+	if message == nil {
 		return
 	}
 
 	a.logger.Debug("received message",
-		"chat_id", update.Message.Chat.ID,
-		"user_id", update.Message.From.ID,
-		"text", update.Message.Text)
+		"chat_id", message.Chat.ID,
+		"chat_type", message.Chat.Type,
+		"edited", edited,
+		"text", message.Text)
 
-	msg := a.convertMessage(update.Message)
+	msg := a.convertMessage(message)
+	if edited {
+		markEdited(msg, message)
+	}
 
 	// Record metrics
 	a.health.RecordMessageReceived()
@@ -318,14 +533,146 @@ func (a *Adapter) handleMessage(ctx context.Context, b *bot.Bot, update *models.
 		return
 	default:
 		a.logger.Warn("messages channel full, dropping message",
-			"chat_id", update.Message.Chat.ID)
+			"chat_id", message.Chat.ID)
 		a.health.RecordMessageFailed()
 	}
 }
 
 // convertMessage converts a Telegram message to the unified format.
 func (a *Adapter) convertMessage(msg *models.Message) *nexusmodels.Message {
-	return convertTelegramMessage(&telegramMessageAdapter{msg})
+	converted := convertTelegramMessage(&telegramMessageAdapter{msg})
+	applyTopicMetadata(converted, msg)
+	if msg.Chat.Type == models.ChatTypeChannel {
+		applyChannelPostMetadata(converted, msg)
+	}
+	if msg.From != nil && msg.From.LanguageCode != "" {
+		// The sender's app language, used to localize system messages.
+		converted.Metadata["locale"] = msg.From.LanguageCode
+	}
+	channels.SetReplyReference(converted, telegramReplyReference(msg))
+	if telegramMentionsBot(msg, a.me) {
+		channels.SetMentioned(converted)
+	}
+	return converted
+}
+
+// applyTopicMetadata keeps message_thread_id only for forum topics, where it
+// scopes the conversation; in other groups it marks a reply thread. Forum
+// messages outside a topic belong to the General topic.
+func applyTopicMetadata(converted *nexusmodels.Message, msg *models.Message) {
+	if !msg.Chat.IsForum {
+		delete(converted.Metadata, "message_thread_id")
+		return
+	}
+	converted.Metadata["telegram_forum"] = true
+	threadID := msg.MessageThreadID
+	if !msg.IsTopicMessage || threadID == 0 {
+		threadID = generalTopicID
+		delete(converted.Metadata, "message_thread_id")
+	}
+	converted.Metadata["telegram_topic_id"] = threadID
+	if reply := msg.ReplyToMessage; reply != nil && reply.ForumTopicCreated != nil {
+		converted.Metadata["telegram_topic_name"] = reply.ForumTopicCreated.Name
+	}
+}
+
+// generalTopicID is the thread ID Telegram uses for the General topic of a
+// forum supergroup.
+const generalTopicID = 1
+
+// applyChannelPostMetadata attributes a channel post, which has no sending
+// user, to the channel (or the chat it was signed by).
+func applyChannelPostMetadata(converted *nexusmodels.Message, msg *models.Message) {
+	sender := &msg.Chat
+	if msg.SenderChat != nil {
+		sender = msg.SenderChat
+	}
+	converted.Metadata["conversation_type"] = "channel"
+	converted.Metadata["telegram_channel_post"] = true
+	converted.Metadata["sender_id"] = strconv.FormatInt(sender.ID, 10)
+	converted.Metadata["sender_name"] = sender.Title
+	if msg.AuthorSignature != "" {
+		converted.Metadata["author_signature"] = msg.AuthorSignature
+	}
+}
+
+// markEdited marks an edited message. Edits get their own message ID so
+// deduplication does not drop them as a repeat of the original.
+func markEdited(converted *nexusmodels.Message, msg *models.Message) {
+	converted.ID = fmt.Sprintf("tg_%d_edit_%d", msg.ID, msg.EditDate)
+	converted.Metadata["edited"] = true
+	converted.Metadata["original_message_id"] = strconv.Itoa(msg.ID)
+	if msg.EditDate > 0 {
+		converted.Metadata["edited_at"] = time.Unix(int64(msg.EditDate), 0).UTC().Format(time.RFC3339)
+	}
+}
+
+// telegramMentionsBot reports whether msg @-mentions me or replies to one of
+// its messages.
+func telegramMentionsBot(msg *models.Message, me *models.User) bool {
+	if msg == nil || me == nil {
+		return false
+	}
+	if reply := msg.ReplyToMessage; reply != nil && reply.From != nil && reply.From.ID == me.ID {
+		return true
+	}
+	text, entities := msg.Text, msg.Entities
+	if text == "" {
+		text, entities = msg.Caption, msg.CaptionEntities
+	}
+	for _, entity := range entities {
+		if entity.Type == models.MessageEntityTypeTextMention && entity.User != nil && entity.User.ID == me.ID {
+			return true
+		}
+	}
+	return me.Username != "" && strings.Contains(strings.ToLower(text), "@"+strings.ToLower(me.Username))
+}
+
+// telegramReplyReference describes the message msg replies to. Telegram
+// delivers the replied-to message inline; the Bot API cannot fetch it later.
+func telegramReplyReference(msg *models.Message) *channels.ReplyReference {
+	if msg == nil {
+		return nil
+	}
+	var quote string
+	if msg.Quote != nil {
+		quote = strings.TrimSpace(msg.Quote.Text)
+	}
+	reply := msg.ReplyToMessage
+	// Messages in a forum topic reply to the topic's creation message.
+	if reply != nil && reply.ForumTopicCreated != nil {
+		reply = nil
+	}
+	if reply == nil {
+		if msg.ExternalReply == nil || quote == "" {
+			return nil
+		}
+		ref := &channels.ReplyReference{MessageID: strconv.Itoa(msg.ExternalReply.MessageID), Quote: quote}
+		if msg.ExternalReply.Chat != nil {
+			ref.ChatID = strconv.FormatInt(msg.ExternalReply.Chat.ID, 10)
+		}
+		return ref
+	}
+
+	ref := &channels.ReplyReference{
+		MessageID: strconv.Itoa(reply.ID),
+		ChatID:    strconv.FormatInt(reply.Chat.ID, 10),
+		Content:   reply.Text,
+		Quote:     quote,
+		SentAt:    time.Unix(int64(reply.Date), 0),
+	}
+	if ref.Content == "" {
+		ref.Content = reply.Caption
+	}
+	if reply.From != nil {
+		ref.SenderID = strconv.FormatInt(reply.From.ID, 10)
+		ref.SenderName = strings.TrimSpace(reply.From.FirstName + " " + reply.From.LastName)
+		if ref.SenderName == "" {
+			ref.SenderName = reply.From.Username
+		}
+		ref.FromBot = reply.From.IsBot
+	}
+	return ref
 }
 
 // Stop gracefully shuts down the adapter.
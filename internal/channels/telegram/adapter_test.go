@@ -178,6 +178,10 @@ func (m *mockBotClient) EditMessageText(ctx context.Context, params *bot.EditMes
 	return &models.Message{ID: int(params.MessageID)}, nil
 }
 
+func (m *mockBotClient) AnswerCallbackQuery(ctx context.Context, params *bot.AnswerCallbackQueryParams) (bool, error) {
+	return true, nil
+}
+
 func (m *mockBotClient) getSendMessageCalls() int {
 	m.mu.Lock()
 	defer m.mu.Unlock()
@@ -1855,6 +1859,47 @@ func TestConvertTelegramMessage_NilUser(t *testing.T) {
 	}
 }
 
+func TestConvertReactionUpdate(t *testing.T) {
+	emoji := func(value string) models.ReactionType {
+		return models.ReactionType{
+			Type:              models.ReactionTypeTypeEmoji,
+			ReactionTypeEmoji: &models.ReactionTypeEmoji{Type: models.ReactionTypeTypeEmoji, Emoji: value},
+		}
+	}
+	update := &models.MessageReactionUpdated{
+		Chat:        models.Chat{ID: -100123},
+		MessageID:   42,
+		User:        &models.User{ID: 777},
+		Date:        1700000000,
+		OldReaction: []models.ReactionType{emoji("👍"), emoji("🔥")},
+		NewReaction: []models.ReactionType{emoji("🔥"), emoji("👎"), {Type: models.ReactionTypeTypeCustomEmoji, ReactionTypeCustomEmoji: &models.ReactionTypeCustomEmoji{CustomEmojiID: "1"}}},
+	}
+
+	events := convertReactionUpdate(update)
+	if len(events) != 2 {
+		t.Fatalf("expected 2 events, got %d: %+v", len(events), events)
+	}
+	removed, added := events[0], events[1]
+	if !removed.Removed || removed.Emoji != "👍" {
+		t.Errorf("expected removed thumbs up, got %+v", removed)
+	}
+	if added.Removed || added.Emoji != "👎" {
+		t.Errorf("expected added thumbs down, got %+v", added)
+	}
+	for _, event := range events {
+		if event.Channel != nexusmodels.ChannelTelegram || event.ChatID != "-100123" || event.MessageID != "42" || event.UserID != "777" {
+			t.Errorf("unexpected event identifiers: %+v", event)
+		}
+		if event.Timestamp.Unix() != 1700000000 {
+			t.Errorf("expected update date as timestamp, got %v", event.Timestamp)
+		}
+	}
+
+	if events := convertReactionUpdate(nil); events != nil {
+		t.Errorf("expected nil events for nil update, got %+v", events)
+	}
+}
+
 func TestConvertTelegramMessage_SpecialCharacters(t *testing.T) {
 	specialContent := `Special chars: <>&"'` + "`" + `\n\t\r`
 	teleMsg := &mockTelegramMessage{
@@ -1985,6 +2030,44 @@ func TestWebhookUpdateParsing_CallbackQuery(t *testing.T) {
 	}
 }
 
+func TestConvertCommandCallback(t *testing.T) {
+	adapter := &Adapter{}
+	query := &models.CallbackQuery{
+		ID:   "cb1",
+		From: models.User{ID: 111, FirstName: "John"},
+		Message: models.MaybeInaccessibleMessage{Message: &models.Message{
+			ID:   456,
+			Chat: models.Chat{ID: 456789, Type: "private"},
+			From: &models.User{ID: 999, IsBot: true, FirstName: "Nexus"},
+			Text: "The assistant wants to remember...",
+		}},
+		Data: "/memory approve 1a2b3c4d",
+	}
+	if !matchCommandCallback(&models.Update{CallbackQuery: query}) {
+		t.Fatal("expected command callback to match")
+	}
+	if matchCommandCallback(&models.Update{CallbackQuery: &models.CallbackQuery{Data: "button_clicked", Message: query.Message}}) {
+		t.Error("expected non-command callback not to match")
+	}
+
+	msg := convertCommandCallback(adapter.convertMessage, query)
+	if msg.Content != "/memory approve 1a2b3c4d" {
+		t.Errorf("Content = %q", msg.Content)
+	}
+	if msg.ID != "tg_callback_cb1" {
+		t.Errorf("ID = %q, want tg_callback_cb1", msg.ID)
+	}
+	if msg.Metadata["sender_id"] != "111" {
+		t.Errorf("sender_id = %v, want the user who pressed the button", msg.Metadata["sender_id"])
+	}
+	if msg.Metadata["chat_id"] != int64(456789) {
+		t.Errorf("chat_id = %v, want 456789", msg.Metadata["chat_id"])
+	}
+	if !channels.WasMentioned(msg) {
+		t.Error("expected button press to count as addressed to the bot")
+	}
+}
+
 // =============================================================================
 // Rate Limit Recovery Tests
 // =============================================================================
@@ -2652,6 +2735,172 @@ func TestConvertTelegramMessage_WithRealAdapter(t *testing.T) {
 	}
 }
 
+func TestConvertMessage_ReplyContext(t *testing.T) {
+	adapter := &Adapter{}
+	msg := adapter.convertMessage(&models.Message{
+		ID:   20,
+		Chat: models.Chat{ID: 67890, Type: "group"},
+		From: &models.User{ID: 111, FirstName: "John"},
+		Date: 1234567890,
+		Text: "why?",
+		ReplyToMessage: &models.Message{
+			ID:      19,
+			Chat:    models.Chat{ID: 67890},
+			From:    &models.User{ID: 999, FirstName: "Nexus", IsBot: true},
+			Date:    1234567800,
+			Caption: "Build failed on main",
+		},
+		Quote: &models.TextQuote{Text: "failed"},
+	})
+	ref, ok := channels.ReplyReferenceFrom(msg)
+	if !ok {
+		t.Fatal("expected a reply reference")
+	}
+	if ref.MessageID != "19" || ref.ChatID != "67890" || ref.Content != "Build failed on main" || ref.Quote != "failed" {
+		t.Fatalf("unexpected reference %+v", ref)
+	}
+	if ref.SenderName != "Nexus" || !ref.FromBot || ref.SentAt.Unix() != 1234567800 {
+		t.Fatalf("unexpected sender %+v", ref)
+	}
+
+	// Forum topic messages reply to the topic's creation message.
+	msg = adapter.convertMessage(&models.Message{
+		ID:             21,
+		Chat:           models.Chat{ID: 67890, Type: "supergroup"},
+		From:           &models.User{ID: 111},
+		Text:           "hi",
+		IsTopicMessage: true,
+		ReplyToMessage: &models.Message{ID: 3, ForumTopicCreated: &models.ForumTopicCreated{Name: "ops"}},
+	})
+	if _, ok := channels.ReplyReferenceFrom(msg); ok {
+		t.Fatal("expected no reply reference for the topic root")
+	}
+}
+
+func TestConvertMessage_Mentioned(t *testing.T) {
+	adapter := &Adapter{me: &models.User{ID: 42, Username: "NexusBot", IsBot: true}}
+	tests := []struct {
+		name string
+		msg  *models.Message
+		want bool
+	}{
+		{name: "plain", msg: &models.Message{Text: "lunch?"}, want: false},
+		{name: "username", msg: &models.Message{Text: "hey @nexusbot what's up"}, want: true},
+		{name: "caption", msg: &models.Message{Caption: "@NexusBot look"}, want: true},
+		{name: "text mention", msg: &models.Message{Text: "Nexus?", Entities: []models.MessageEntity{{Type: models.MessageEntityTypeTextMention, User: &models.User{ID: 42}}}}, want: true},
+		{name: "reply to bot", msg: &models.Message{Text: "thanks", ReplyToMessage: &models.Message{ID: 1, From: &models.User{ID: 42}}}, want: true},
+		{name: "reply to user", msg: &models.Message{Text: "thanks", ReplyToMessage: &models.Message{ID: 1, From: &models.User{ID: 9}}}, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.msg.ID = 5
+			tt.msg.Chat = models.Chat{ID: -100, Type: "group"}
+			tt.msg.From = &models.User{ID: 7}
+			if got := channels.WasMentioned(adapter.convertMessage(tt.msg)); got != tt.want {
+				t.Fatalf("WasMentioned() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConvertMessage_ForumTopics(t *testing.T) {
+	adapter := &Adapter{}
+	msg := adapter.convertMessage(&models.Message{
+		ID:              30,
+		Chat:            models.Chat{ID: -1001, Type: "supergroup", IsForum: true},
+		From:            &models.User{ID: 111, LanguageCode: "es"},
+		Text:            "deploy?",
+		MessageThreadID: 7,
+		IsTopicMessage:  true,
+		ReplyToMessage:  &models.Message{ID: 7, ForumTopicCreated: &models.ForumTopicCreated{Name: "ops"}},
+	})
+	if msg.Metadata["message_thread_id"] != 7 || msg.Metadata["telegram_topic_id"] != 7 || msg.Metadata["telegram_topic_name"] != "ops" {
+		t.Fatalf("unexpected topic metadata %+v", msg.Metadata)
+	}
+	if msg.Metadata["locale"] != "es" {
+		t.Fatalf("expected the sender's language as locale, got %+v", msg.Metadata)
+	}
+
+	// Forum messages outside a topic belong to General.
+	msg = adapter.convertMessage(&models.Message{
+		ID:   31,
+		Chat: models.Chat{ID: -1001, Type: "supergroup", IsForum: true},
+		From: &models.User{ID: 111},
+		Text: "hello",
+	})
+	if _, ok := msg.Metadata["message_thread_id"]; ok || msg.Metadata["telegram_topic_id"] != generalTopicID {
+		t.Fatalf("unexpected General topic metadata %+v", msg.Metadata)
+	}
+
+	// Reply threads in ordinary groups are not separate conversations.
+	msg = adapter.convertMessage(&models.Message{
+		ID:              32,
+		Chat:            models.Chat{ID: -1002, Type: "supergroup"},
+		From:            &models.User{ID: 111},
+		Text:            "+1",
+		MessageThreadID: 12,
+	})
+	if _, ok := msg.Metadata["message_thread_id"]; ok {
+		t.Fatalf("expected no thread id outside a forum, got %+v", msg.Metadata)
+	}
+	if _, ok := msg.Metadata["telegram_topic_id"]; ok {
+		t.Fatalf("expected no topic id outside a forum, got %+v", msg.Metadata)
+	}
+}
+
+func TestAdapter_HandleChannelPostAndEdits(t *testing.T) {
+	adapter, err := NewAdapter(Config{Token: "test-token", ChannelPosts: true, EditedMessages: true})
+	if err != nil {
+		t.Fatalf("NewAdapter() error = %v", err)
+	}
+	mock := newMockBotClient()
+	adapter.SetBotClient(mock)
+	adapter.registerHandlers()
+	if len(mock.registerHandlers) != 6 {
+		t.Fatalf("registered %d handlers, want 6", len(mock.registerHandlers))
+	}
+	if !matchChannelPost(&models.Update{ChannelPost: &models.Message{}}) || !adapter.matchEditedMessage(&models.Update{EditedChannelPost: &models.Message{}}) {
+		t.Fatal("expected channel posts and edits to match")
+	}
+
+	adapter.handleMessage(context.Background(), nil, &models.Update{ChannelPost: &models.Message{
+		ID:              40,
+		Chat:            models.Chat{ID: -1003, Type: models.ChatTypeChannel, Title: "Announcements"},
+		Text:            "v2 is out",
+		AuthorSignature: "Ada",
+	}})
+	msg := <-adapter.Messages()
+	if msg.Content != "v2 is out" || msg.Metadata["conversation_type"] != "channel" || msg.Metadata["telegram_channel_post"] != true {
+		t.Fatalf("unexpected channel post %+v", msg)
+	}
+	if msg.Metadata["sender_id"] != "-1003" || msg.Metadata["sender_name"] != "Announcements" || msg.Metadata["author_signature"] != "Ada" {
+		t.Fatalf("unexpected channel post sender %+v", msg.Metadata)
+	}
+
+	adapter.handleMessage(context.Background(), nil, &models.Update{EditedMessage: &models.Message{
+		ID:       41,
+		Chat:     models.Chat{ID: 67890, Type: "private"},
+		From:     &models.User{ID: 111},
+		Text:     "fixed typo",
+		EditDate: 1234567999,
+	}})
+	msg = <-adapter.Messages()
+	if msg.ID != "tg_41_edit_1234567999" || msg.Metadata["edited"] != true || msg.Metadata["original_message_id"] != "41" {
+		t.Fatalf("unexpected edit %+v", msg)
+	}
+
+	plain, err := NewAdapter(Config{Token: "test-token"})
+	if err != nil {
+		t.Fatalf("NewAdapter() error = %v", err)
+	}
+	plainMock := newMockBotClient()
+	plain.SetBotClient(plainMock)
+	plain.registerHandlers()
+	if len(plainMock.registerHandlers) != 4 {
+		t.Fatalf("registered %d handlers without channel posts or edits, want 4", len(plainMock.registerHandlers))
+	}
+}
+
 // =============================================================================
 // User Adapter Tests
 // =============================================================================
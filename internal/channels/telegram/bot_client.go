@@ -50,6 +50,9 @@ type BotClient interface {
 
 	// EditMessageText edits a text message in a chat.
 	EditMessageText(ctx context.Context, params *bot.EditMessageTextParams) (*models.Message, error)
+
+	// AnswerCallbackQuery acknowledges an inline keyboard button press.
+	AnswerCallbackQuery(ctx context.Context, params *bot.AnswerCallbackQueryParams) (bool, error)
 }
 
 // realBotClient wraps a *bot.Bot to implement BotClient.
@@ -113,3 +116,7 @@ func (r *realBotClient) SendChatAction(ctx context.Context, params *bot.SendChat
 func (r *realBotClient) EditMessageText(ctx context.Context, params *bot.EditMessageTextParams) (*models.Message, error) {
 	return r.bot.EditMessageText(ctx, params)
 }
+
+func (r *realBotClient) AnswerCallbackQuery(ctx context.Context, params *bot.AnswerCallbackQueryParams) (bool, error) {
+	return r.bot.AnswerCallbackQuery(ctx, params)
+}
@@ -0,0 +1,328 @@
+package cluster
+
+import (
+	"context"
+	"errors"
+	"hash/fnv"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// Lease names for singleton subsystems.
+const (
+	// LeaseCron gates execution of configured cron jobs.
+	LeaseCron = "cron"
+	// LeaseTaskMaintenance gates cleanup of stale and timed-out task executions.
+	LeaseTaskMaintenance = "tasks.maintenance"
+	// LeaseJobPruning gates pruning of old job records.
+	LeaseJobPruning = "jobs.pruning"
+	// LeaseRetention gates data retention sweeps.
+	LeaseRetention = "privacy.retention"
+	// LeaseCredentialAlerts gates admin alerts from the credential monitor.
+	LeaseCredentialAlerts = "credentials.alerts"
+	// LeaseHeartbeats gates scheduled session heartbeat runs.
+	LeaseHeartbeats = "session.heartbeats"
+	// LeaseAttentionDigest gates the scheduled attention digest.
+	LeaseAttentionDigest = "attention.digest"
+	// LeaseWebWatch gates scheduled web page checks.
+	LeaseWebWatch = "webwatch.checks"
+	// LeaseCatchupDigest gates scheduled catch-up digests.
+	LeaseCatchupDigest = "session.catchup_digest"
+	// LeaseCanary gates canary conversation runs and their alerts.
+	LeaseCanary = "canary"
+	// LeaseCostReport gates the scheduled cost report.
+	LeaseCostReport = "usage.cost_report"
+	// LeaseMemoryConsolidation gates consolidation of daily memory files.
+	LeaseMemoryConsolidation = "session.memory_consolidation"
+	// LeaseEventRetention gates pruning of persisted agent events.
+	LeaseEventRetention = "events.retention"
+)
+
+// DefaultLeases are the leases a gateway node campaigns for.
+var DefaultLeases = []string{LeaseCron, LeaseTaskMaintenance, LeaseJobPruning, LeaseRetention, LeaseCredentialAlerts, LeaseHeartbeats, LeaseAttentionDigest, LeaseWebWatch, LeaseCatchupDigest, LeaseCanary, LeaseCostReport, LeaseMemoryConsolidation, LeaseEventRetention}
+
+// Config configures a Coordinator.
+type Config struct {
+	// NodeID uniquely identifies this node in the cluster.
+	NodeID string
+
+	// Address is an optional advertised address shown in cluster status.
+	Address string
+
+	// HeartbeatInterval is how often membership and leases are refreshed.
+	HeartbeatInterval time.Duration
+
+	// NodeTTL is how long a node stays a member without heartbeating.
+	NodeTTL time.Duration
+
+	// LeaseTTL is how long a lease is held without renewal.
+	LeaseTTL time.Duration
+
+	// Leases are the lease names this node campaigns for.
+	Leases []string
+
+	// Logger for coordinator events.
+	Logger *slog.Logger
+}
+
+// DefaultConfig returns the default coordinator configuration.
+func DefaultConfig() Config {
+	return Config{
+		HeartbeatInterval: 10 * time.Second,
+		NodeTTL:           30 * time.Second,
+		LeaseTTL:          30 * time.Second,
+		Leases:            DefaultLeases,
+	}
+}
+
+// Coordinator maintains this node's membership and campaigns for leader leases.
+type Coordinator struct {
+	store  Store
+	config Config
+	logger *slog.Logger
+
+	startedAt time.Time
+
+	mu      sync.RWMutex
+	members []string
+	held    map[string]time.Time // lease name -> local expiry
+
+	started bool
+	cancel  context.CancelFunc
+	wg      sync.WaitGroup
+	now     func() time.Time
+}
+
+// New creates a coordinator. Zero-valued config fields use DefaultConfig values.
+func New(store Store, config Config) (*Coordinator, error) {
+	if store == nil {
+		return nil, errors.New("store is required")
+	}
+	if config.NodeID == "" {
+		return nil, errors.New("node ID is required")
+	}
+	defaults := DefaultConfig()
+	if config.HeartbeatInterval <= 0 {
+		config.HeartbeatInterval = defaults.HeartbeatInterval
+	}
+	if config.NodeTTL <= 0 {
+		config.NodeTTL = defaults.NodeTTL
+	}
+	if config.LeaseTTL <= 0 {
+		config.LeaseTTL = defaults.LeaseTTL
+	}
+	if config.Leases == nil {
+		config.Leases = defaults.Leases
+	}
+	logger := config.Logger
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &Coordinator{
+		store:  store,
+		config: config,
+		logger: logger.With("component", "cluster", "node_id", config.NodeID),
+		held:   make(map[string]time.Time),
+		now:    time.Now,
+	}, nil
+}
+
+// NodeID returns this node's ID.
+func (c *Coordinator) NodeID() string {
+	return c.config.NodeID
+}
+
+// Start registers the node, runs an initial election round, and begins the
+// heartbeat loop.
+func (c *Coordinator) Start(ctx context.Context) error {
+	c.mu.Lock()
+	if c.started {
+		c.mu.Unlock()
+		return nil
+	}
+	c.started = true
+	c.startedAt = c.now()
+	c.mu.Unlock()
+
+	if err := c.tick(ctx); err != nil {
+		return err
+	}
+
+	loopCtx, cancel := context.WithCancel(context.Background())
+	c.cancel = cancel
+	c.wg.Add(1)
+	go c.loop(loopCtx)
+
+	c.logger.Info("cluster coordinator started",
+		"heartbeat_interval", c.config.HeartbeatInterval,
+		"lease_ttl", c.config.LeaseTTL)
+	return nil
+}
+
+// Stop halts the heartbeat loop, releases held leases, and removes the node
+// from membership so other nodes can take over immediately.
+func (c *Coordinator) Stop(ctx context.Context) error {
+	c.mu.Lock()
+	if !c.started {
+		c.mu.Unlock()
+		return nil
+	}
+	c.started = false
+	held := make([]string, 0, len(c.held))
+	for name := range c.held {
+		held = append(held, name)
+	}
+	c.held = make(map[string]time.Time)
+	c.members = nil
+	c.mu.Unlock()
+
+	if c.cancel != nil {
+		c.cancel()
+	}
+	c.wg.Wait()
+
+	var errs []error
+	for _, name := range held {
+		if err := c.store.ReleaseLease(ctx, name, c.config.NodeID); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if err := c.store.RemoveNode(ctx, c.config.NodeID); err != nil {
+		errs = append(errs, err)
+	}
+	return errors.Join(errs...)
+}
+
+// IsLeader reports whether this node currently holds the named lease.
+func (c *Coordinator) IsLeader(name string) bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	expires, ok := c.held[name]
+	return ok && c.now().Before(expires)
+}
+
+// Members returns the IDs of live cluster members, as of the last heartbeat.
+func (c *Coordinator) Members() []string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return append([]string(nil), c.members...)
+}
+
+// Owns reports whether this node is responsible for the given work key.
+// Keys are assigned to live members by rendezvous hashing, so ownership only
+// moves for keys of nodes that join or leave. With no known membership the
+// node owns everything.
+func (c *Coordinator) Owns(key string) bool {
+	c.mu.RLock()
+	members := c.members
+	c.mu.RUnlock()
+	if len(members) == 0 {
+		return true
+	}
+	return Owner(members, key) == c.config.NodeID
+}
+
+// Owner returns the member responsible for key using rendezvous hashing.
+func Owner(members []string, key string) string {
+	var (
+		owner string
+		best  uint64
+	)
+	for _, member := range members {
+		h := fnv.New64a()
+		_, _ = h.Write([]byte(member))
+		_, _ = h.Write([]byte{0})
+		_, _ = h.Write([]byte(key))
+		score := mix64(h.Sum64())
+		if owner == "" || score > best || (score == best && member < owner) {
+			owner = member
+			best = score
+		}
+	}
+	return owner
+}
+
+// mix64 spreads FNV output so that similar member/key pairs score independently.
+func mix64(x uint64) uint64 {
+	x ^= x >> 33
+	x *= 0xff51afd7ed558ccd
+	x ^= x >> 33
+	x *= 0xc4ceb9fe1a85ec53
+	x ^= x >> 33
+	return x
+}
+
+func (c *Coordinator) loop(ctx context.Context) {
+	defer c.wg.Done()
+	ticker := time.NewTicker(c.config.HeartbeatInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := c.tick(ctx); err != nil && ctx.Err() == nil {
+				c.logger.Warn("cluster heartbeat failed", "error", err)
+			}
+		}
+	}
+}
+
+// tick heartbeats, refreshes membership, and campaigns for each lease.
+func (c *Coordinator) tick(ctx context.Context) error {
+	now := c.now()
+	if err := c.store.Heartbeat(ctx, Node{
+		ID:          c.config.NodeID,
+		Address:     c.config.Address,
+		StartedAt:   c.startedAt,
+		HeartbeatAt: now,
+	}); err != nil {
+		return err
+	}
+
+	nodes, err := c.store.ListNodes(ctx)
+	if err != nil {
+		return err
+	}
+	members := LiveMembers(nodes, now, c.config.NodeTTL)
+
+	held := make(map[string]time.Time, len(c.config.Leases))
+	for _, name := range c.config.Leases {
+		acquired, err := c.store.TryAcquireLease(ctx, name, c.config.NodeID, c.config.LeaseTTL)
+		if err != nil {
+			c.logger.Warn("lease campaign failed", "lease", name, "error", err)
+			continue
+		}
+		if acquired {
+			held[name] = now.Add(c.config.LeaseTTL)
+		}
+	}
+
+	c.mu.Lock()
+	for name := range held {
+		if _, ok := c.held[name]; !ok {
+			c.logger.Info("acquired cluster lease", "lease", name)
+		}
+	}
+	for name := range c.held {
+		if _, ok := held[name]; !ok {
+			c.logger.Info("lost cluster lease", "lease", name)
+		}
+	}
+	c.held = held
+	c.members = members
+	c.mu.Unlock()
+	return nil
+}
+
+// LiveMembers returns the IDs of nodes that heartbeated within ttl of now.
+func LiveMembers(nodes []Node, now time.Time, ttl time.Duration) []string {
+	members := make([]string, 0, len(nodes))
+	for _, node := range nodes {
+		if now.Sub(node.HeartbeatAt) <= ttl {
+			members = append(members, node.ID)
+		}
+	}
+	return members
+}
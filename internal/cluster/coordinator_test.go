@@ -0,0 +1,107 @@
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+)
+
+func newTestCoordinator(t *testing.T, store Store, nodeID string) *Coordinator {
+	t.Helper()
+	c, err := New(store, Config{
+		NodeID:            nodeID,
+		HeartbeatInterval: time.Hour,
+		Logger:            slog.New(slog.NewTextHandler(io.Discard, nil)),
+	})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	return c
+}
+
+func TestCoordinatorLeaderFailover(t *testing.T) {
+	store := NewMemoryStore()
+	ctx := context.Background()
+
+	a := newTestCoordinator(t, store, "node-a")
+	b := newTestCoordinator(t, store, "node-b")
+	if err := a.Start(ctx); err != nil {
+		t.Fatalf("Start a: %v", err)
+	}
+	if err := b.Start(ctx); err != nil {
+		t.Fatalf("Start b: %v", err)
+	}
+	defer b.Stop(ctx)
+
+	if !a.IsLeader(LeaseCron) || b.IsLeader(LeaseCron) {
+		t.Fatalf("expected node-a to lead cron: a=%v b=%v", a.IsLeader(LeaseCron), b.IsLeader(LeaseCron))
+	}
+	if got := b.Members(); len(got) != 2 {
+		t.Fatalf("members = %v, want 2", got)
+	}
+
+	if err := a.Stop(ctx); err != nil {
+		t.Fatalf("Stop a: %v", err)
+	}
+	if err := b.tick(ctx); err != nil {
+		t.Fatalf("tick b: %v", err)
+	}
+	if !b.IsLeader(LeaseCron) {
+		t.Fatal("expected node-b to take over cron after node-a stopped")
+	}
+	if got := b.Members(); len(got) != 1 || got[0] != "node-b" {
+		t.Fatalf("members = %v, want [node-b]", got)
+	}
+}
+
+func TestCoordinatorOwnsPartitionsKeys(t *testing.T) {
+	store := NewMemoryStore()
+	ctx := context.Background()
+	nodes := []*Coordinator{
+		newTestCoordinator(t, store, "node-a"),
+		newTestCoordinator(t, store, "node-b"),
+		newTestCoordinator(t, store, "node-c"),
+	}
+	for _, node := range nodes {
+		if err := node.Start(ctx); err != nil {
+			t.Fatalf("Start: %v", err)
+		}
+		defer node.Stop(ctx)
+	}
+	// Refresh membership so every node sees all three.
+	for _, node := range nodes {
+		if err := node.tick(ctx); err != nil {
+			t.Fatalf("tick: %v", err)
+		}
+	}
+
+	counts := make(map[string]int)
+	for i := 0; i < 300; i++ {
+		key := fmt.Sprintf("task-%d", i)
+		owners := 0
+		for _, node := range nodes {
+			if node.Owns(key) {
+				owners++
+				counts[node.NodeID()]++
+			}
+		}
+		if owners != 1 {
+			t.Fatalf("key %s has %d owners, want 1", key, owners)
+		}
+	}
+	for _, node := range nodes {
+		if counts[node.NodeID()] == 0 {
+			t.Fatalf("node %s owns no keys: %v", node.NodeID(), counts)
+		}
+	}
+}
+
+func TestCoordinatorOwnsAllWithoutMembership(t *testing.T) {
+	c := newTestCoordinator(t, NewMemoryStore(), "node-a")
+	if !c.Owns("anything") {
+		t.Fatal("expected coordinator without membership to own all keys")
+	}
+}
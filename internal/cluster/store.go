@@ -0,0 +1,244 @@
+// Package cluster coordinates gateway replicas: node membership, leader leases
+// for singleton subsystems, and partitioning of shared work across nodes.
+package cluster
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Node is a gateway replica registered in the cluster.
+type Node struct {
+	ID          string    `json:"id"`
+	Address     string    `json:"address,omitempty"`
+	StartedAt   time.Time `json:"started_at"`
+	HeartbeatAt time.Time `json:"heartbeat_at"`
+}
+
+// Lease is a named, time-bounded leadership claim.
+type Lease struct {
+	Name       string    `json:"name"`
+	HolderID   string    `json:"holder_id"`
+	AcquiredAt time.Time `json:"acquired_at"`
+	ExpiresAt  time.Time `json:"expires_at"`
+}
+
+// Store persists cluster membership and leases.
+type Store interface {
+	// Heartbeat registers the node or refreshes its heartbeat.
+	Heartbeat(ctx context.Context, node Node) error
+	// RemoveNode deletes a node's membership record.
+	RemoveNode(ctx context.Context, nodeID string) error
+	// ListNodes returns all registered nodes, including stale ones.
+	ListNodes(ctx context.Context) ([]Node, error)
+
+	// TryAcquireLease acquires or renews the named lease for holderID.
+	// It returns false when another holder owns an unexpired lease.
+	TryAcquireLease(ctx context.Context, name, holderID string, ttl time.Duration) (bool, error)
+	// ReleaseLease releases the named lease if held by holderID.
+	ReleaseLease(ctx context.Context, name, holderID string) error
+	// ListLeases returns all leases, including expired ones.
+	ListLeases(ctx context.Context) ([]Lease, error)
+}
+
+// DBStore implements Store using the cluster_nodes and cluster_leases tables.
+type DBStore struct {
+	db *sql.DB
+}
+
+// NewDBStore creates a DB-backed cluster store.
+func NewDBStore(db *sql.DB) (*DBStore, error) {
+	if db == nil {
+		return nil, errors.New("db is required")
+	}
+	return &DBStore{db: db}, nil
+}
+
+// Heartbeat upserts the node row and refreshes its heartbeat.
+func (s *DBStore) Heartbeat(ctx context.Context, node Node) error {
+	_, err := s.db.ExecContext(ctx, `
+		INSERT INTO cluster_nodes (node_id, address, started_at, heartbeat_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (node_id) DO UPDATE
+		SET address = EXCLUDED.address,
+			started_at = EXCLUDED.started_at,
+			heartbeat_at = EXCLUDED.heartbeat_at
+	`, node.ID, node.Address, node.StartedAt, node.HeartbeatAt)
+	return err
+}
+
+// RemoveNode deletes the node row.
+func (s *DBStore) RemoveNode(ctx context.Context, nodeID string) error {
+	_, err := s.db.ExecContext(ctx, `DELETE FROM cluster_nodes WHERE node_id = $1`, nodeID)
+	return err
+}
+
+// ListNodes returns all node rows ordered by ID.
+func (s *DBStore) ListNodes(ctx context.Context) ([]Node, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT node_id, address, started_at, heartbeat_at
+		FROM cluster_nodes
+		ORDER BY node_id
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var nodes []Node
+	for rows.Next() {
+		var node Node
+		if err := rows.Scan(&node.ID, &node.Address, &node.StartedAt, &node.HeartbeatAt); err != nil {
+			return nil, err
+		}
+		nodes = append(nodes, node)
+	}
+	return nodes, rows.Err()
+}
+
+// TryAcquireLease takes the lease when it is free or expired, or renews it
+// when already held by holderID.
+func (s *DBStore) TryAcquireLease(ctx context.Context, name, holderID string, ttl time.Duration) (bool, error) {
+	now := time.Now()
+	var holder string
+	err := s.db.QueryRowContext(ctx, `
+		INSERT INTO cluster_leases (name, holder_id, acquired_at, expires_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (name) DO UPDATE
+		SET holder_id = EXCLUDED.holder_id,
+			acquired_at = CASE WHEN cluster_leases.holder_id = EXCLUDED.holder_id
+				THEN cluster_leases.acquired_at ELSE EXCLUDED.acquired_at END,
+			expires_at = EXCLUDED.expires_at
+		WHERE cluster_leases.expires_at < $3 OR cluster_leases.holder_id = EXCLUDED.holder_id
+		RETURNING holder_id
+	`, name, holderID, now, now.Add(ttl)).Scan(&holder)
+	if err == sql.ErrNoRows {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return holder == holderID, nil
+}
+
+// ReleaseLease deletes the lease if held by holderID.
+func (s *DBStore) ReleaseLease(ctx context.Context, name, holderID string) error {
+	_, err := s.db.ExecContext(ctx, `
+		DELETE FROM cluster_leases
+		WHERE name = $1 AND holder_id = $2
+	`, name, holderID)
+	return err
+}
+
+// ListLeases returns all lease rows ordered by name.
+func (s *DBStore) ListLeases(ctx context.Context) ([]Lease, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT name, holder_id, acquired_at, expires_at
+		FROM cluster_leases
+		ORDER BY name
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var leases []Lease
+	for rows.Next() {
+		var lease Lease
+		if err := rows.Scan(&lease.Name, &lease.HolderID, &lease.AcquiredAt, &lease.ExpiresAt); err != nil {
+			return nil, err
+		}
+		leases = append(leases, lease)
+	}
+	return leases, rows.Err()
+}
+
+// MemoryStore is an in-process Store for single-node deployments and tests.
+type MemoryStore struct {
+	mu     sync.Mutex
+	nodes  map[string]Node
+	leases map[string]Lease
+	now    func() time.Time
+}
+
+// NewMemoryStore creates an in-memory cluster store.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		nodes:  make(map[string]Node),
+		leases: make(map[string]Lease),
+		now:    time.Now,
+	}
+}
+
+// Heartbeat records the node.
+func (s *MemoryStore) Heartbeat(ctx context.Context, node Node) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.nodes[node.ID] = node
+	return nil
+}
+
+// RemoveNode deletes the node.
+func (s *MemoryStore) RemoveNode(ctx context.Context, nodeID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.nodes, nodeID)
+	return nil
+}
+
+// ListNodes returns all nodes ordered by ID.
+func (s *MemoryStore) ListNodes(ctx context.Context) ([]Node, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	nodes := make([]Node, 0, len(s.nodes))
+	for _, node := range s.nodes {
+		nodes = append(nodes, node)
+	}
+	sort.Slice(nodes, func(i, j int) bool { return nodes[i].ID < nodes[j].ID })
+	return nodes, nil
+}
+
+// TryAcquireLease acquires or renews the lease.
+func (s *MemoryStore) TryAcquireLease(ctx context.Context, name, holderID string, ttl time.Duration) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := s.now()
+	lease, ok := s.leases[name]
+	switch {
+	case !ok || lease.ExpiresAt.Before(now):
+		s.leases[name] = Lease{Name: name, HolderID: holderID, AcquiredAt: now, ExpiresAt: now.Add(ttl)}
+		return true, nil
+	case lease.HolderID == holderID:
+		lease.ExpiresAt = now.Add(ttl)
+		s.leases[name] = lease
+		return true, nil
+	default:
+		return false, nil
+	}
+}
+
+// ReleaseLease deletes the lease if held by holderID.
+func (s *MemoryStore) ReleaseLease(ctx context.Context, name, holderID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if lease, ok := s.leases[name]; ok && lease.HolderID == holderID {
+		delete(s.leases, name)
+	}
+	return nil
+}
+
+// ListLeases returns all leases ordered by name.
+func (s *MemoryStore) ListLeases(ctx context.Context) ([]Lease, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	leases := make([]Lease, 0, len(s.leases))
+	for _, lease := range s.leases {
+		leases = append(leases, lease)
+	}
+	sort.Slice(leases, func(i, j int) bool { return leases[i].Name < leases[j].Name })
+	return leases, nil
+}
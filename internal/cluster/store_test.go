@@ -0,0 +1,77 @@
+package cluster
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+func TestDBStoreTryAcquireLease(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock: %v", err)
+	}
+	defer db.Close()
+
+	store, err := NewDBStore(db)
+	if err != nil {
+		t.Fatalf("NewDBStore: %v", err)
+	}
+	ctx := context.Background()
+
+	mock.ExpectQuery("INSERT INTO cluster_leases").
+		WithArgs("cron", "node-1", sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows([]string{"holder_id"}).AddRow("node-1"))
+	acquired, err := store.TryAcquireLease(ctx, "cron", "node-1", time.Minute)
+	if err != nil || !acquired {
+		t.Fatalf("TryAcquireLease = %v, %v; want true", acquired, err)
+	}
+
+	mock.ExpectQuery("INSERT INTO cluster_leases").
+		WithArgs("cron", "node-2", sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnError(sql.ErrNoRows)
+	acquired, err = store.TryAcquireLease(ctx, "cron", "node-2", time.Minute)
+	if err != nil || acquired {
+		t.Fatalf("TryAcquireLease = %v, %v; want false", acquired, err)
+	}
+
+	mock.ExpectExec("DELETE FROM cluster_leases").
+		WithArgs("cron", "node-1").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	if err := store.ReleaseLease(ctx, "cron", "node-1"); err != nil {
+		t.Fatalf("ReleaseLease: %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("expectations: %v", err)
+	}
+}
+
+func TestMemoryStoreLeaseExpiry(t *testing.T) {
+	store := NewMemoryStore()
+	now := time.Now()
+	store.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	if ok, _ := store.TryAcquireLease(ctx, "cron", "a", time.Minute); !ok {
+		t.Fatal("expected a to acquire free lease")
+	}
+	if ok, _ := store.TryAcquireLease(ctx, "cron", "b", time.Minute); ok {
+		t.Fatal("expected b to be blocked while a holds the lease")
+	}
+	if ok, _ := store.TryAcquireLease(ctx, "cron", "a", time.Minute); !ok {
+		t.Fatal("expected a to renew its lease")
+	}
+
+	now = now.Add(2 * time.Minute)
+	if ok, _ := store.TryAcquireLease(ctx, "cron", "b", time.Minute); !ok {
+		t.Fatal("expected b to take over expired lease")
+	}
+	leases, _ := store.ListLeases(ctx)
+	if len(leases) != 1 || leases[0].HolderID != "b" {
+		t.Fatalf("leases = %+v", leases)
+	}
+}
@@ -0,0 +1,166 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/haasonsaas/nexus/internal/access"
+)
+
+// PairingApprover approves a pending pairing code on channel and returns
+// the sender it was issued to.
+type PairingApprover func(ctx context.Context, channel, code string) (string, error)
+
+// RegisterAccessCommands registers /allow, /elevate, and /pair, which let
+// admins manage the command, elevated, and pairing allowlists from chat.
+// approve may be nil, in which case /pair approve is unavailable.
+func RegisterAccessCommands(r *Registry, store access.Store, approve PairingApprover) error {
+	if store == nil {
+		return fmt.Errorf("access store is required")
+	}
+	commands := []*Command{
+		{
+			Name:        "allow",
+			Description: "Let a sender run commands",
+			Usage:       "/allow [@user [duration] | revoke @user | list]",
+			Handler:     accessHandler(access.ListCommands, store, nil),
+		},
+		{
+			Name:        "elevate",
+			Description: "Let a sender use elevated tools",
+			Usage:       "/elevate [@user [duration] | revoke @user | list]",
+			Handler:     accessHandler(access.ListElevated, store, nil),
+		},
+		{
+			Name:        "pair",
+			Description: "Let a sender DM the assistant",
+			Usage:       "/pair [approve <code> | @user [duration] | revoke @user | list]",
+			Handler:     accessHandler(access.ListPairing, store, approve),
+		},
+	}
+	for _, cmd := range commands {
+		cmd.AcceptsArgs = true
+		cmd.MinRole = RoleAdmin
+		cmd.Category = "system"
+		cmd.Source = "builtin"
+		if err := r.Register(cmd); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func accessHandler(list access.List, store access.Store, approve PairingApprover) CommandHandler {
+	return func(ctx context.Context, inv *Invocation) (*Result, error) {
+		channel := ""
+		sender := inv.UserID
+		if inv.Context != nil {
+			channel, _ = inv.Context["channel"].(string)
+			if id, ok := inv.Context["user_id"].(string); ok && id != "" {
+				sender = id
+			}
+		}
+		grantedBy := channel + ":" + access.NormalizeSender(sender)
+		usage := &Result{Error: "Usage: " + inv.Command.Usage}
+
+		fields := strings.Fields(inv.Args)
+		op := "list"
+		if len(fields) > 0 {
+			op = strings.ToLower(fields[0])
+		}
+		switch op {
+		case "list":
+			if len(fields) > 1 {
+				return usage, nil
+			}
+			grants, err := store.List(ctx, list)
+			if err != nil {
+				return accessError(err)
+			}
+			if len(grants) == 0 {
+				return &Result{Text: fmt.Sprintf("No %s grants.", list)}, nil
+			}
+			lines := make([]string, 0, len(grants))
+			for _, grant := range grants {
+				lines = append(lines, formatGrant(grant))
+			}
+			return &Result{Text: strings.Join(lines, "\n")}, nil
+
+		case "revoke", "remove":
+			if len(fields) != 2 {
+				return usage, nil
+			}
+			grantChannel, grantSender, err := access.ParseSubject(fields[1], channel)
+			if err != nil {
+				return &Result{Error: err.Error()}, nil
+			}
+			removed, err := store.Delete(ctx, list, grantChannel, grantSender)
+			if err != nil {
+				return accessError(err)
+			}
+			if !removed {
+				return &Result{Text: fmt.Sprintf("No %s grant for %s:%s", list, grantChannel, grantSender)}, nil
+			}
+			return &Result{Text: fmt.Sprintf("Revoked %s access for %s:%s", list, grantChannel, grantSender)}, nil
+
+		case "approve":
+			if list != access.ListPairing || len(fields) != 2 {
+				return usage, nil
+			}
+			if approve == nil {
+				return &Result{Error: "Pairing approval is not available"}, nil
+			}
+			approved, err := approve(ctx, channel, fields[1])
+			if err != nil {
+				return &Result{Error: fmt.Sprintf("Approve pairing code: %v", err)}, nil
+			}
+			// The approver records the sender in the channel's pairing
+			// allowlist; a grant also shares it with every gateway node.
+			grant := access.Grant{List: list, Channel: channel, Sender: approved, GrantedBy: grantedBy}
+			if err := store.Put(ctx, grant); err != nil && !errors.Is(err, access.ErrUnavailable) {
+				return nil, err
+			}
+			return &Result{Text: fmt.Sprintf("Approved pairing for %s:%s", channel, access.NormalizeSender(approved))}, nil
+		}
+
+		if len(fields) > 2 {
+			return usage, nil
+		}
+		grantChannel, grantSender, err := access.ParseSubject(fields[0], channel)
+		if err != nil {
+			return &Result{Error: err.Error()}, nil
+		}
+		grant := access.Grant{List: list, Channel: grantChannel, Sender: grantSender, GrantedBy: grantedBy}
+		if len(fields) == 2 {
+			ttl, err := access.ParseTTL(fields[1])
+			if err != nil {
+				return &Result{Error: err.Error()}, nil
+			}
+			grant.ExpiresAt = time.Now().Add(ttl)
+		}
+		if err := store.Put(ctx, grant); err != nil {
+			return accessError(err)
+		}
+		return &Result{Text: "Granted " + formatGrant(grant)}, nil
+	}
+}
+
+// accessError reports a missing grant store to the admin instead of failing
+// the command.
+func accessError(err error) (*Result, error) {
+	if errors.Is(err, access.ErrUnavailable) {
+		return &Result{Error: "Access grants require database.url to be configured"}, nil
+	}
+	return nil, err
+}
+
+func formatGrant(grant access.Grant) string {
+	text := fmt.Sprintf("%s access for %s", grant.List, grant.Subject())
+	if !grant.ExpiresAt.IsZero() {
+		text += fmt.Sprintf(" until %s", grant.ExpiresAt.UTC().Format(time.RFC3339))
+	}
+	return text
+}
@@ -0,0 +1,100 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/haasonsaas/nexus/internal/access"
+)
+
+func TestAccessCommands(t *testing.T) {
+	r := NewRegistry(nil)
+	store := access.NewMemoryStore()
+	approve := func(ctx context.Context, channel, code string) (string, error) {
+		if code != "ABCD1234" {
+			return "", errors.New("code not found")
+		}
+		return "U777", nil
+	}
+	if err := RegisterAccessCommands(r, store, approve); err != nil {
+		t.Fatalf("RegisterAccessCommands: %v", err)
+	}
+	ctx := context.Background()
+	invoke := func(role Role, name, args string) *Result {
+		result, err := r.Execute(ctx, &Invocation{
+			Name:    name,
+			Args:    args,
+			Role:    role,
+			Context: map[string]any{"channel": "slack", "user_id": "UADMIN"},
+		})
+		if err != nil {
+			t.Fatalf("Execute(/%s %s): %v", name, args, err)
+		}
+		return result
+	}
+
+	if got := invoke(RoleMember, "allow", "@bob"); !strings.Contains(got.Error, "admin") {
+		t.Errorf("member should not grant access, got %+v", got)
+	}
+	if got := invoke(RoleAdmin, "allow", "<@U123|bob>"); got.Text != "Granted commands access for slack:u123" {
+		t.Errorf("allow = %+v", got)
+	}
+	if got := invoke(RoleAdmin, "elevate", "telegram:42 1h"); !strings.HasPrefix(got.Text, "Granted elevated access for telegram:42 until ") {
+		t.Errorf("elevate = %+v", got)
+	}
+	if got := invoke(RoleAdmin, "elevate", "@bob soon"); !strings.Contains(got.Error, "invalid duration") {
+		t.Errorf("invalid duration = %+v", got)
+	}
+	if got := invoke(RoleAdmin, "pair", "approve abcd"); !strings.Contains(got.Error, "code not found") {
+		t.Errorf("unknown code = %+v", got)
+	}
+	if got := invoke(RoleAdmin, "pair", "approve ABCD1234"); got.Text != "Approved pairing for slack:u777" {
+		t.Errorf("approve = %+v", got)
+	}
+
+	grants, _ := store.List(ctx, "")
+	var got []string
+	for _, grant := range grants {
+		got = append(got, string(grant.List)+"/"+grant.Subject()+"/"+grant.GrantedBy)
+	}
+	if want := "commands/slack:u123/slack:uadmin elevated/telegram:42/slack:uadmin pairing/slack:u777/slack:uadmin"; strings.Join(got, " ") != want {
+		t.Fatalf("grants = %s, want %s", strings.Join(got, " "), want)
+	}
+
+	if got := invoke(RoleAdmin, "allow", ""); got.Text != "commands access for slack:u123" {
+		t.Errorf("list = %q", got.Text)
+	}
+	if got := invoke(RoleAdmin, "allow", "revoke @U123"); got.Text != "Revoked commands access for slack:u123" {
+		t.Errorf("revoke = %+v", got)
+	}
+	if got := invoke(RoleAdmin, "allow", "list"); got.Text != "No commands grants." {
+		t.Errorf("list after revoke = %q", got.Text)
+	}
+}
+
+func TestAccessCommandsWithoutDatabase(t *testing.T) {
+	r := NewRegistry(nil)
+	if err := RegisterAccessCommands(r, unavailableAccessStore{}, nil); err != nil {
+		t.Fatalf("RegisterAccessCommands: %v", err)
+	}
+	result, err := r.Execute(context.Background(), &Invocation{Name: "allow", Args: "@bob", Role: RoleAdmin, Context: map[string]any{"channel": "slack"}})
+	if err != nil || !strings.Contains(result.Error, "database.url") {
+		t.Fatalf("expected a database.url hint, got %+v, %v", result, err)
+	}
+}
+
+type unavailableAccessStore struct{}
+
+func (unavailableAccessStore) Put(context.Context, access.Grant) error {
+	return access.ErrUnavailable
+}
+
+func (unavailableAccessStore) Delete(context.Context, access.List, string, string) (bool, error) {
+	return false, access.ErrUnavailable
+}
+
+func (unavailableAccessStore) List(context.Context, access.List) ([]access.Grant, error) {
+	return nil, access.ErrUnavailable
+}
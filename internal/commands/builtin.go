@@ -6,6 +6,9 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"time"
+
+	"github.com/haasonsaas/nexus/internal/messages"
 )
 
 // RegisterBuiltins registers the built-in commands.
@@ -27,6 +30,7 @@ func RegisterBuiltins(r *Registry) error {
 		Description: "Show available commands",
 		Usage:       "/help [command]",
 		AcceptsArgs: true,
+		MinRole:     RoleGuest,
 		Category:    "system",
 		Source:      "builtin",
 		Handler:     helpHandler(r),
@@ -36,6 +40,7 @@ func RegisterBuiltins(r *Registry) error {
 	mustRegister(&Command{
 		Name:        "status",
 		Description: "Show current session status",
+		MinRole:     RoleGuest,
 		Category:    "system",
 		Source:      "builtin",
 		Handler: func(ctx context.Context, inv *Invocation) (*Result, error) {
@@ -139,6 +144,7 @@ func RegisterBuiltins(r *Registry) error {
 		Name:        "whoami",
 		Aliases:     []string{"id"},
 		Description: "Show sender identity",
+		MinRole:     RoleGuest,
 		Category:    "system",
 		Source:      "builtin",
 		Handler: func(ctx context.Context, inv *Invocation) (*Result, error) {
@@ -166,12 +172,13 @@ func RegisterBuiltins(r *Registry) error {
 	// Undo command
 	mustRegister(&Command{
 		Name:        "undo",
-		Description: "Undo the last message",
+		Description: "Undo your last message and the reply to it",
 		Category:    "session",
 		Source:      "builtin",
 		Handler: func(ctx context.Context, inv *Invocation) (*Result, error) {
+			// The gateway rolls the conversation back and replies with
+			// what was undone.
 			return &Result{
-				Text: "Undoing last message...",
 				Data: map[string]any{
 					"action": "undo",
 				},
@@ -179,21 +186,65 @@ func RegisterBuiltins(r *Registry) error {
 		},
 	})
 
+	// Branch command
+	mustRegister(&Command{
+		Name:        "branch",
+		Description: "Fork the conversation to explore another direction, or switch branches",
+		Usage:       "/branch [name] | list | switch <name|id>",
+		AcceptsArgs: true,
+		Category:    "session",
+		Source:      "builtin",
+		Handler: func(ctx context.Context, inv *Invocation) (*Result, error) {
+			op, value, _ := strings.Cut(strings.TrimSpace(inv.Args), " ")
+			value = strings.TrimSpace(value)
+			switch strings.ToLower(op) {
+			case "list", "ls":
+				op, value = "list", ""
+			case "switch", "checkout":
+				if value == "" {
+					return &Result{Error: "Usage: /branch switch <name|id>"}, nil
+				}
+				op = "switch"
+			default:
+				value = strings.TrimSpace(inv.Args)
+				op = "fork"
+			}
+			// The gateway updates the session and replies with the branch.
+			return &Result{
+				Data: map[string]any{
+					"action": "branch",
+					"op":     op,
+					"value":  value,
+				},
+			}, nil
+		},
+	})
+
 	// Memory command
 	mustRegister(&Command{
 		Name:        "memory",
 		Aliases:     []string{"mem"},
 		Description: "Search or manage memory",
-		Usage:       "/memory [query]",
+		Usage:       "/memory [query] | review | approve <id> | reject <id>",
 		AcceptsArgs: true,
 		Category:    "memory",
 		Source:      "builtin",
 		Handler: func(ctx context.Context, inv *Invocation) (*Result, error) {
 			if inv.Args == "" {
 				return &Result{
-					Text: "Memory search. Usage: /memory <query>",
+					Text: "Memory search. Usage: /memory <query>, or /memory review to see memories waiting for approval",
 				}, nil
 			}
+			fields := strings.Fields(inv.Args)
+			switch op := strings.ToLower(fields[0]); {
+			case op == "review" && len(fields) == 1:
+				// The gateway lists the proposals waiting for review.
+				return &Result{Data: map[string]any{"action": "memory_review", "op": "list"}}, nil
+			case (op == "approve" || op == "reject") && len(fields) == 2:
+				return &Result{Data: map[string]any{"action": "memory_review", "op": op, "id": fields[1]}}, nil
+			case op == "approve" || op == "reject":
+				return &Result{Error: "Usage: /memory " + op + " <id> (see /memory review for IDs)"}, nil
+			}
 			return &Result{
 				Text: fmt.Sprintf("Searching memory for: %s", inv.Args),
 				Data: map[string]any{
@@ -204,6 +255,39 @@ func RegisterBuiltins(r *Registry) error {
 		},
 	})
 
+	// Draft command
+	mustRegister(&Command{
+		Name:        "draft",
+		Aliases:     []string{"drafts"},
+		Description: "Approve, edit, or cancel drafted messages",
+		Usage:       "/draft [list] | approve <id> | edit <id> <text> | cancel <id>",
+		AcceptsArgs: true,
+		Category:    "tools",
+		Source:      "builtin",
+		Handler: func(ctx context.Context, inv *Invocation) (*Result, error) {
+			fields := strings.Fields(inv.Args)
+			if len(fields) == 0 || (strings.EqualFold(fields[0], "list") && len(fields) == 1) {
+				// The gateway lists the drafts waiting for approval.
+				return &Result{Data: map[string]any{"action": "draft", "op": "list"}}, nil
+			}
+			switch op := strings.ToLower(fields[0]); {
+			case (op == "approve" || op == "cancel") && len(fields) == 2:
+				return &Result{Data: map[string]any{"action": "draft", "op": op, "id": fields[1]}}, nil
+			case op == "edit" && len(fields) >= 3:
+				// Keep the new text as typed, including line breaks.
+				rest := strings.TrimSpace(inv.Args)
+				rest = strings.TrimSpace(rest[len(fields[0]):])
+				text := strings.TrimSpace(rest[len(fields[1]):])
+				return &Result{Data: map[string]any{"action": "draft", "op": op, "id": fields[1], "text": text}}, nil
+			case op == "approve" || op == "cancel":
+				return &Result{Error: "Usage: /draft " + op + " <id> (see /draft list for IDs)"}, nil
+			case op == "edit":
+				return &Result{Error: "Usage: /draft edit <id> <new text>"}, nil
+			}
+			return &Result{Error: "Usage: /draft [list] | approve <id> | edit <id> <text> | cancel <id>"}, nil
+		},
+	})
+
 	// Compact/summarize command
 	mustRegister(&Command{
 		Name:        "compact",
@@ -304,6 +388,276 @@ func RegisterBuiltins(r *Registry) error {
 		},
 	})
 
+	// Export command
+	mustRegister(&Command{
+		Name:        "export",
+		Aliases:     []string{"transcript"},
+		Description: "Export this conversation as a Markdown or HTML file",
+		Usage:       "/export [markdown|html]",
+		AcceptsArgs: true,
+		Category:    "session",
+		Source:      "builtin",
+		Handler: func(ctx context.Context, inv *Invocation) (*Result, error) {
+			format := "markdown"
+			switch strings.ToLower(strings.TrimSpace(inv.Args)) {
+			case "", "md", "markdown":
+			case "html", "htm":
+				format = "html"
+			default:
+				return &Result{Error: "Usage: /export [markdown|html]"}, nil
+			}
+			// The gateway renders the transcript and sends it as a file.
+			return &Result{
+				Data: map[string]any{
+					"action": "export",
+					"format": format,
+				},
+			}, nil
+		},
+	})
+
+	// Heartbeat command - per-session heartbeat schedule
+	mustRegister(&Command{
+		Name:        "heartbeat",
+		Description: "Show or change proactive heartbeat check-ins for this conversation",
+		Usage:       "/heartbeat [status|on|off|every <duration>|cron <expr>|quiet <HH:MM-HH:MM>]",
+		AcceptsArgs: true,
+		Category:    "config",
+		Source:      "builtin",
+		Handler: func(ctx context.Context, inv *Invocation) (*Result, error) {
+			usage := "Usage: /heartbeat [status|on|off|every <duration>|cron <expr>|quiet <HH:MM-HH:MM>]"
+			op, value, _ := strings.Cut(strings.TrimSpace(inv.Args), " ")
+			op = strings.ToLower(op)
+			value = strings.TrimSpace(value)
+			switch op {
+			case "", "status":
+				op = "status"
+			case "on", "reset", "default":
+				op = "on"
+			case "off", "disable":
+				op = "off"
+			case "every":
+				interval, err := time.ParseDuration(value)
+				if err != nil || interval < time.Minute {
+					return &Result{Error: "Heartbeat interval must be a duration of at least 1m, e.g. /heartbeat every 4h"}, nil
+				}
+				value = interval.String()
+			case "cron":
+				if value == "" {
+					return &Result{Error: usage}, nil
+				}
+			case "quiet":
+				start, end, ok := strings.Cut(value, "-")
+				if !ok || !validClock(strings.TrimSpace(start)) || !validClock(strings.TrimSpace(end)) {
+					return &Result{Error: "Quiet hours must look like 22:00-07:00"}, nil
+				}
+			default:
+				return &Result{Error: usage}, nil
+			}
+			// The gateway updates the session and replies with the schedule.
+			return &Result{
+				Data: map[string]any{
+					"action": "heartbeat",
+					"op":     op,
+					"value":  value,
+				},
+			}, nil
+		},
+	})
+
+	// Quiet command - silence the bot in a group for a while
+	mustRegister(&Command{
+		Name:        "quiet",
+		Description: "Keep the bot silent in this conversation for a while",
+		Usage:       "/quiet [<duration>|off]",
+		AcceptsArgs: true,
+		Category:    "config",
+		Source:      "builtin",
+		Handler: func(ctx context.Context, inv *Invocation) (*Result, error) {
+			arg := strings.ToLower(strings.TrimSpace(inv.Args))
+			op, value := "set", ""
+			switch arg {
+			case "", "status":
+				op = "status"
+			case "off", "stop", "resume":
+				op = "off"
+			default:
+				duration, err := time.ParseDuration(arg)
+				if err != nil || duration < time.Minute || duration > 7*24*time.Hour {
+					return &Result{Error: "Quiet time must be a duration between 1m and 168h, e.g. /quiet 1h"}, nil
+				}
+				value = duration.String()
+			}
+			// The gateway records the quiet period on the session.
+			return &Result{
+				Data: map[string]any{
+					"action": "quiet",
+					"op":     op,
+					"value":  value,
+				},
+			}, nil
+		},
+	})
+
+	// Catchup command - summarize what the user missed
+	mustRegister(&Command{
+		Name:        "catchup",
+		Description: "Summarize the messages you missed in this conversation",
+		Usage:       "/catchup [<duration>]",
+		AcceptsArgs: true,
+		Category:    "session",
+		Source:      "builtin",
+		Handler: func(ctx context.Context, inv *Invocation) (*Result, error) {
+			arg := strings.ToLower(strings.TrimSpace(inv.Args))
+			window := ""
+			if arg != "" {
+				duration, err := time.ParseDuration(arg)
+				if err != nil || duration < time.Minute || duration > 7*24*time.Hour {
+					return &Result{Error: "Catch-up window must be a duration between 1m and 168h, e.g. /catchup 4h"}, nil
+				}
+				window = duration.String()
+			}
+			// The gateway finds the missed messages and summarizes them.
+			return &Result{
+				Data: map[string]any{
+					"action": "catchup",
+					"window": window,
+				},
+			}, nil
+		},
+	})
+
+	// Language command - choose the language the agent replies in
+	mustRegister(&Command{
+		Name:        "language",
+		Description: "Show or set the language the agent replies in",
+		Usage:       "/language [<language>|auto]",
+		AcceptsArgs: true,
+		Category:    "config",
+		Source:      "builtin",
+		Handler: func(ctx context.Context, inv *Invocation) (*Result, error) {
+			arg := strings.TrimSpace(inv.Args)
+			op, value := "set", ""
+			switch strings.ToLower(arg) {
+			case "", "status":
+				op = "status"
+			case "auto", "off", "reset":
+				op = "auto"
+			default:
+				value = messages.ParseLanguage(arg)
+				if value == "" {
+					return &Result{Error: "Language must be a language code or name, e.g. /language es or /language Spanish"}, nil
+				}
+			}
+			// The gateway records the choice on the session and the sender's identity.
+			return &Result{
+				Data: map[string]any{
+					"action": "language",
+					"op":     op,
+					"value":  value,
+				},
+			}, nil
+		},
+	})
+
+	// Schedule and remind commands - send-later and recurring messages
+	scheduleHandler := func(kind, usage string) CommandHandler {
+		return func(ctx context.Context, inv *Invocation) (*Result, error) {
+			text, when, ok := splitScheduleArgs(inv.Args, kind == "reminder")
+			if !ok {
+				return &Result{Error: usage}, nil
+			}
+			// The gateway resolves the time in the user's timezone and stores the task.
+			return &Result{
+				Data: map[string]any{
+					"action": "schedule_message",
+					"kind":   kind,
+					"text":   text,
+					"when":   when,
+				},
+			}, nil
+		}
+	}
+	mustRegister(&Command{
+		Name:        "schedule",
+		Description: "Send a message to this conversation later or on a schedule",
+		Usage:       `/schedule "text" <when> (e.g. at 5pm, tomorrow 9am, daily at 8:30)`,
+		AcceptsArgs: true,
+		Category:    "scheduling",
+		Source:      "builtin",
+		Handler:     scheduleHandler("message", `Usage: /schedule "text" at 5pm | tomorrow 9am | in 2 hours | daily at 8:30 | weekly on monday at 10am`),
+	})
+	mustRegister(&Command{
+		Name:        "remind",
+		Description: "Set a one-off or recurring reminder",
+		Usage:       `/remind <when> "text" or /remind <when> to <text>`,
+		AcceptsArgs: true,
+		Category:    "scheduling",
+		Source:      "builtin",
+		Handler:     scheduleHandler("reminder", `Usage: /remind weekly on friday at 4pm "submit timesheet" or /remind in 20 minutes to stretch`),
+	})
+	mustRegister(&Command{
+		Name:        "scheduled",
+		Aliases:     []string{"schedules", "reminders"},
+		Description: "List scheduled messages and reminders for this conversation",
+		Usage:       "/scheduled",
+		Category:    "scheduling",
+		Source:      "builtin",
+		Handler: func(ctx context.Context, inv *Invocation) (*Result, error) {
+			return &Result{Data: map[string]any{"action": "list_scheduled"}}, nil
+		},
+	})
+	mustRegister(&Command{
+		Name:        "unschedule",
+		Aliases:     []string{"unremind"},
+		Description: "Cancel a scheduled message or reminder",
+		Usage:       "/unschedule <id>",
+		AcceptsArgs: true,
+		Category:    "scheduling",
+		Source:      "builtin",
+		Handler: func(ctx context.Context, inv *Invocation) (*Result, error) {
+			id := strings.TrimSpace(inv.Args)
+			if id == "" || strings.ContainsAny(id, " \t") {
+				return &Result{Error: "Usage: /unschedule <id> (see /scheduled for IDs)"}, nil
+			}
+			return &Result{Data: map[string]any{"action": "cancel_scheduled", "id": id}}, nil
+		},
+	})
+
+	// Broadcast command - deliver one composed message to a configured list
+	mustRegister(&Command{
+		Name:        "broadcast",
+		Description: "Compose a message and deliver it to a broadcast list",
+		Usage:       "/broadcast [<list> <instructions or \"exact text\"> | retry]",
+		AcceptsArgs: true,
+		Category:    "messaging",
+		Source:      "builtin",
+		MinRole:     RoleAdmin,
+		Handler: func(ctx context.Context, inv *Invocation) (*Result, error) {
+			args := strings.TrimSpace(inv.Args)
+			if args == "" {
+				return &Result{Data: map[string]any{"action": "broadcast_lists"}}, nil
+			}
+			if strings.EqualFold(args, "retry") {
+				return &Result{Data: map[string]any{"action": "broadcast_retry"}}, nil
+			}
+			list, rest, _ := strings.Cut(args, " ")
+			rest = strings.TrimSpace(rest)
+			if rest == "" {
+				return &Result{Error: "Usage: /broadcast <list> <what to say>, or /broadcast <list> \"exact text\""}, nil
+			}
+			data := map[string]any{"action": "broadcast", "list": list, "instructions": rest}
+			for _, quote := range scheduleQuotes {
+				if len(rest) > len(quote[0])+len(quote[1]) && strings.HasPrefix(rest, quote[0]) && strings.HasSuffix(rest, quote[1]) {
+					data["text"] = strings.TrimSpace(rest[len(quote[0]) : len(rest)-len(quote[1])])
+					delete(data, "instructions")
+					break
+				}
+			}
+			return &Result{Data: data}, nil
+		},
+	})
+
 	// Send command - toggle message sending policy
 	mustRegister(&Command{
 		Name:        "send",
@@ -434,6 +788,44 @@ func RegisterBuiltins(r *Registry) error {
 	return firstErr
 }
 
+// scheduleQuotes pairs opening and closing quotes, including the curly
+// quotes phone keyboards insert.
+var scheduleQuotes = [][2]string{{`"`, `"`}, {"\u201c", "\u201d"}}
+
+// splitScheduleArgs separates the quoted message text from the time
+// specification around it. With allowTo, unquoted "<when> to <text>" is
+// accepted as well.
+func splitScheduleArgs(args string, allowTo bool) (text, when string, ok bool) {
+	args = strings.TrimSpace(args)
+	for _, quote := range scheduleQuotes {
+		start := strings.Index(args, quote[0])
+		if start < 0 {
+			continue
+		}
+		end := strings.Index(args[start+len(quote[0]):], quote[1])
+		if end < 0 {
+			continue
+		}
+		end += start + len(quote[0])
+		text = strings.TrimSpace(args[start+len(quote[0]) : end])
+		when = strings.TrimSpace(args[:start] + " " + args[end+len(quote[1]):])
+		return text, when, text != "" && when != ""
+	}
+	if allowTo {
+		if before, after, found := strings.Cut(args, " to "); found {
+			text, when = strings.TrimSpace(after), strings.TrimSpace(before)
+			return text, when, text != "" && when != ""
+		}
+	}
+	return "", "", false
+}
+
+// validClock reports whether value is a 24-hour HH:MM time.
+func validClock(value string) bool {
+	parsed, err := time.Parse("15:04", value)
+	return err == nil && parsed.Format("15:04") == value
+}
+
 // titleCase converts the first letter to uppercase.
 func titleCase(s string) string {
 	if s == "" {
@@ -471,8 +863,10 @@ func helpHandler(r *Registry) CommandHandler {
 				}
 				sb.WriteString(fmt.Sprintf("\nAliases: %s\n", strings.Join(aliases, ", ")))
 			}
-			if cmd.AdminOnly {
+			if required := r.RequiredRole(cmd); required == RoleAdmin {
 				sb.WriteString("\n⚠️ Admin only\n")
+			} else if required == RoleGuest {
+				sb.WriteString("\nAvailable to guests\n")
 			}
 
 			return &Result{
@@ -489,6 +883,7 @@ func helpHandler(r *Registry) CommandHandler {
 		}
 		sort.Strings(categories)
 
+		role := inv.EffectiveRole()
 		var sb strings.Builder
 		sb.WriteString("**Available Commands**\n\n")
 
@@ -498,8 +893,19 @@ func helpHandler(r *Registry) CommandHandler {
 				continue
 			}
 
-			sb.WriteString(fmt.Sprintf("**%s**\n", titleCase(category)))
+			var visible []*Command
 			for _, cmd := range commands {
+				// Only list commands the invoker can actually run.
+				if role.Allows(r.RequiredRole(cmd)) {
+					visible = append(visible, cmd)
+				}
+			}
+			if len(visible) == 0 {
+				continue
+			}
+
+			sb.WriteString(fmt.Sprintf("**%s**\n", titleCase(category)))
+			for _, cmd := range visible {
 				desc := cmd.Description
 				if desc == "" {
 					desc = "No description"
@@ -42,7 +42,9 @@ func TestRegisterBuiltins(t *testing.T) {
 	// Verify expected commands are registered
 	expectedCommands := []string{
 		"help", "status", "new", "model", "stop", "whoami",
-		"undo", "memory", "compact", "context", "send", "think",
+		"undo", "branch", "memory", "compact", "context", "send", "think", "heartbeat",
+		"schedule", "remind", "scheduled", "unschedule", "broadcast", "quiet",
+		"catchup", "language",
 	}
 
 	for _, name := range expectedCommands {
@@ -255,6 +257,81 @@ func TestBuiltinHandlers_Memory(t *testing.T) {
 			t.Errorf("query = %v, want 'test query'", result.Data["query"])
 		}
 	})
+
+	t.Run("review", func(t *testing.T) {
+		result, err := r.Execute(context.Background(), &Invocation{Name: "memory", Args: "review"})
+		if err != nil {
+			t.Fatalf("memory command failed: %v", err)
+		}
+		if result.Data["action"] != "memory_review" || result.Data["op"] != "list" {
+			t.Errorf("data = %v, want memory_review list", result.Data)
+		}
+	})
+
+	t.Run("approve", func(t *testing.T) {
+		result, err := r.Execute(context.Background(), &Invocation{Name: "memory", Args: "approve 1a2b3c4d"})
+		if err != nil {
+			t.Fatalf("memory command failed: %v", err)
+		}
+		if result.Data["op"] != "approve" || result.Data["id"] != "1a2b3c4d" {
+			t.Errorf("data = %v, want approve 1a2b3c4d", result.Data)
+		}
+	})
+
+	t.Run("reject without id", func(t *testing.T) {
+		result, err := r.Execute(context.Background(), &Invocation{Name: "memory", Args: "reject"})
+		if err != nil {
+			t.Fatalf("memory command failed: %v", err)
+		}
+		if !strings.Contains(result.Error, "Usage") {
+			t.Errorf("expected usage error, got: %+v", result)
+		}
+	})
+}
+
+func TestBuiltinHandlers_Draft(t *testing.T) {
+	r := NewRegistry(nil)
+	requireBuiltins(t, r)
+
+	tests := []struct {
+		args string
+		want map[string]any
+	}{
+		{args: "", want: map[string]any{"action": "draft", "op": "list"}},
+		{args: "list", want: map[string]any{"action": "draft", "op": "list"}},
+		{args: "approve 1a2b3c4d", want: map[string]any{"action": "draft", "op": "approve", "id": "1a2b3c4d"}},
+		{args: "CANCEL 1a2b3c4d", want: map[string]any{"action": "draft", "op": "cancel", "id": "1a2b3c4d"}},
+		{
+			args: "edit 1a2b3c4d Hi Ada,\n\nSee you  Monday.",
+			want: map[string]any{"action": "draft", "op": "edit", "id": "1a2b3c4d", "text": "Hi Ada,\n\nSee you  Monday."},
+		},
+	}
+	for _, tt := range tests {
+		result, err := r.Execute(context.Background(), &Invocation{Name: "draft", Args: tt.args})
+		if err != nil {
+			t.Fatalf("draft %q failed: %v", tt.args, err)
+		}
+		if len(result.Data) != len(tt.want) {
+			t.Errorf("draft %q data = %v, want %v", tt.args, result.Data, tt.want)
+			continue
+		}
+		for key, want := range tt.want {
+			if result.Data[key] != want {
+				t.Errorf("draft %q data = %v, want %v", tt.args, result.Data, tt.want)
+				break
+			}
+		}
+	}
+
+	for _, args := range []string{"approve", "edit 1a2b3c4d", "send 1a2b3c4d"} {
+		result, err := r.Execute(context.Background(), &Invocation{Name: "draft", Args: args})
+		if err != nil {
+			t.Fatalf("draft %q failed: %v", args, err)
+		}
+		if !strings.Contains(result.Error, "Usage") {
+			t.Errorf("draft %q: expected usage error, got %+v", args, result)
+		}
+	}
 }
 
 func TestBuiltinHandlers_Send(t *testing.T) {
@@ -310,6 +387,193 @@ func TestBuiltinHandlers_Send(t *testing.T) {
 	})
 }
 
+func TestBuiltinHandlers_Heartbeat(t *testing.T) {
+	r := NewRegistry(nil)
+	requireBuiltins(t, r)
+
+	tests := []struct {
+		args      string
+		wantOp    string
+		wantValue string
+		wantError bool
+	}{
+		{args: "", wantOp: "status"},
+		{args: "off", wantOp: "off"},
+		{args: "reset", wantOp: "on"},
+		{args: "every 90m", wantOp: "every", wantValue: "1h30m0s"},
+		{args: "every 10s", wantError: true},
+		{args: "cron 0 9 * * 1-5", wantOp: "cron", wantValue: "0 9 * * 1-5"},
+		{args: "quiet 22:00-07:00", wantOp: "quiet", wantValue: "22:00-07:00"},
+		{args: "quiet 10pm-7am", wantError: true},
+		{args: "sometimes", wantError: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.args, func(t *testing.T) {
+			result, err := r.Execute(context.Background(), &Invocation{Name: "heartbeat", Args: tt.args})
+			if err != nil {
+				t.Fatalf("heartbeat command failed: %v", err)
+			}
+			if tt.wantError {
+				if result.Error == "" {
+					t.Fatalf("expected error, got %+v", result)
+				}
+				return
+			}
+			if result.Data["action"] != "heartbeat" || result.Data["op"] != tt.wantOp || result.Data["value"] != tt.wantValue {
+				t.Fatalf("unexpected data: %+v", result.Data)
+			}
+		})
+	}
+}
+
+func TestBuiltinHandlers_Quiet(t *testing.T) {
+	r := NewRegistry(nil)
+	requireBuiltins(t, r)
+
+	tests := []struct {
+		args      string
+		wantOp    string
+		wantValue string
+		wantError bool
+	}{
+		{args: "", wantOp: "status"},
+		{args: "off", wantOp: "off"},
+		{args: "1h", wantOp: "set", wantValue: "1h0m0s"},
+		{args: "90m", wantOp: "set", wantValue: "1h30m0s"},
+		{args: "10s", wantError: true},
+		{args: "200h", wantError: true},
+		{args: "forever", wantError: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.args, func(t *testing.T) {
+			result, err := r.Execute(context.Background(), &Invocation{Name: "quiet", Args: tt.args})
+			if err != nil {
+				t.Fatalf("quiet command failed: %v", err)
+			}
+			if tt.wantError {
+				if result.Error == "" {
+					t.Fatalf("expected error, got %+v", result)
+				}
+				return
+			}
+			if result.Data["action"] != "quiet" || result.Data["op"] != tt.wantOp || result.Data["value"] != tt.wantValue {
+				t.Fatalf("unexpected data: %+v", result.Data)
+			}
+		})
+	}
+}
+
+func TestBuiltinHandlers_Catchup(t *testing.T) {
+	r := NewRegistry(nil)
+	requireBuiltins(t, r)
+
+	tests := []struct {
+		args       string
+		wantWindow string
+		wantError  bool
+	}{
+		{args: "", wantWindow: ""},
+		{args: "4h", wantWindow: "4h0m0s"},
+		{args: "30s", wantError: true},
+		{args: "yesterday", wantError: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.args, func(t *testing.T) {
+			result, err := r.Execute(context.Background(), &Invocation{Name: "catchup", Args: tt.args})
+			if err != nil {
+				t.Fatalf("catchup command failed: %v", err)
+			}
+			if tt.wantError {
+				if result.Error == "" {
+					t.Fatalf("expected error, got %+v", result)
+				}
+				return
+			}
+			if result.Data["action"] != "catchup" || result.Data["window"] != tt.wantWindow {
+				t.Fatalf("unexpected data: %+v", result.Data)
+			}
+		})
+	}
+}
+
+func TestBuiltinHandlers_Language(t *testing.T) {
+	r := NewRegistry(nil)
+	requireBuiltins(t, r)
+
+	tests := []struct {
+		args      string
+		wantOp    string
+		wantValue string
+		wantError bool
+	}{
+		{args: "", wantOp: "status"},
+		{args: "auto", wantOp: "auto"},
+		{args: "es", wantOp: "set", wantValue: "es"},
+		{args: "pt_BR", wantOp: "set", wantValue: "pt-br"},
+		{args: "German", wantOp: "set", wantValue: "de"},
+		{args: "the moon", wantError: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.args, func(t *testing.T) {
+			result, err := r.Execute(context.Background(), &Invocation{Name: "language", Args: tt.args})
+			if err != nil {
+				t.Fatalf("language command failed: %v", err)
+			}
+			if tt.wantError {
+				if result.Error == "" {
+					t.Fatalf("expected error, got %+v", result)
+				}
+				return
+			}
+			if result.Data["action"] != "language" || result.Data["op"] != tt.wantOp || result.Data["value"] != tt.wantValue {
+				t.Fatalf("unexpected data: %+v", result.Data)
+			}
+		})
+	}
+}
+
+func TestBuiltinHandlers_Schedule(t *testing.T) {
+	r := NewRegistry(nil)
+	requireBuiltins(t, r)
+
+	tests := []struct {
+		name     string
+		args     string
+		wantText string
+		wantWhen string
+	}{
+		{name: "schedule", args: `"ship it" at 5pm`, wantText: "ship it", wantWhen: "at 5pm"},
+		{name: "schedule", args: `tomorrow 9am “standup notes”`, wantText: "standup notes", wantWhen: "tomorrow 9am"},
+		{name: "schedule", args: "at 5pm ship it"},
+		{name: "schedule", args: `"" at 5pm`},
+		{name: "remind", args: `weekly on monday "timesheet"`, wantText: "timesheet", wantWhen: "weekly on monday"},
+		{name: "remind", args: "in 20 minutes to stretch", wantText: "stretch", wantWhen: "in 20 minutes"},
+		{name: "remind", args: `"no time"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name+" "+tt.args, func(t *testing.T) {
+			result, err := r.Execute(context.Background(), &Invocation{Name: tt.name, Args: tt.args})
+			if err != nil {
+				t.Fatalf("%s command failed: %v", tt.name, err)
+			}
+			if tt.wantText == "" {
+				if result.Error == "" {
+					t.Fatalf("expected usage error, got %+v", result)
+				}
+				return
+			}
+			if result.Data["action"] != "schedule_message" || result.Data["text"] != tt.wantText || result.Data["when"] != tt.wantWhen {
+				t.Fatalf("unexpected data: %+v", result.Data)
+			}
+		})
+	}
+
+	result, err := r.Execute(context.Background(), &Invocation{Name: "unschedule", Args: "abc123"})
+	if err != nil || result.Data["action"] != "cancel_scheduled" || result.Data["id"] != "abc123" {
+		t.Fatalf("unexpected unschedule result %+v, %v", result, err)
+	}
+}
+
 func TestBuiltinHandlers_Think(t *testing.T) {
 	r := NewRegistry(nil)
 	requireBuiltins(t, r)
@@ -511,6 +775,39 @@ func TestBuiltinHandlers_Undo(t *testing.T) {
 	}
 }
 
+func TestBuiltinHandlers_Branch(t *testing.T) {
+	r := NewRegistry(nil)
+	requireBuiltins(t, r)
+
+	tests := []struct {
+		args  string
+		op    string
+		value string
+	}{
+		{args: "", op: "fork", value: ""},
+		{args: "shorter answers", op: "fork", value: "shorter answers"},
+		{args: "list", op: "list", value: ""},
+		{args: "switch main", op: "switch", value: "main"},
+	}
+	for _, tt := range tests {
+		result, err := r.Execute(context.Background(), &Invocation{Name: "branch", Args: tt.args})
+		if err != nil {
+			t.Fatalf("branch %q failed: %v", tt.args, err)
+		}
+		if result.Data["action"] != "branch" || result.Data["op"] != tt.op || result.Data["value"] != tt.value {
+			t.Errorf("branch %q data = %v, want op %q value %q", tt.args, result.Data, tt.op, tt.value)
+		}
+	}
+
+	result, err := r.Execute(context.Background(), &Invocation{Name: "branch", Args: "switch"})
+	if err != nil {
+		t.Fatalf("branch switch failed: %v", err)
+	}
+	if result.Error == "" {
+		t.Error("expected usage error for /branch switch without a branch")
+	}
+}
+
 func TestBuiltinHandlers_Compact(t *testing.T) {
 	r := NewRegistry(nil)
 	requireBuiltins(t, r)
@@ -523,3 +820,42 @@ func TestBuiltinHandlers_Compact(t *testing.T) {
 		t.Errorf("action = %v, want compact", result.Data["action"])
 	}
 }
+
+func TestBuiltinHandlers_Broadcast(t *testing.T) {
+	r := NewRegistry(nil)
+	requireBuiltins(t, r)
+
+	result, err := r.Execute(context.Background(), &Invocation{Name: "broadcast", Args: "team share the release notes", Role: RoleMember})
+	if err != nil || !strings.Contains(result.Error, "admin") {
+		t.Fatalf("expected admin requirement, got %+v, %v", result, err)
+	}
+
+	tests := []struct {
+		args string
+		want map[string]any
+	}{
+		{"", map[string]any{"action": "broadcast_lists"}},
+		{"Retry", map[string]any{"action": "broadcast_retry"}},
+		{"team share the release notes", map[string]any{"action": "broadcast", "list": "team", "instructions": "share the release notes"}},
+		{`team "Deploy at 5pm"`, map[string]any{"action": "broadcast", "list": "team", "text": "Deploy at 5pm"}},
+	}
+	for _, tt := range tests {
+		result, err := r.Execute(context.Background(), &Invocation{Name: "broadcast", Args: tt.args, Role: RoleAdmin})
+		if err != nil {
+			t.Fatalf("broadcast %q failed: %v", tt.args, err)
+		}
+		if len(result.Data) != len(tt.want) {
+			t.Fatalf("broadcast %q data = %+v, want %+v", tt.args, result.Data, tt.want)
+		}
+		for key, value := range tt.want {
+			if result.Data[key] != value {
+				t.Fatalf("broadcast %q data = %+v, want %+v", tt.args, result.Data, tt.want)
+			}
+		}
+	}
+
+	result, err = r.Execute(context.Background(), &Invocation{Name: "broadcast", Args: "team", Role: RoleAdmin})
+	if err != nil || result.Error == "" {
+		t.Fatalf("expected usage error, got %+v, %v", result, err)
+	}
+}
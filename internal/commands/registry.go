@@ -14,6 +14,7 @@ type Registry struct {
 	commands   map[string]*Command // name -> command
 	aliases    map[string]string   // alias -> name
 	categories map[string][]*Command
+	minRoles   map[string]Role // name -> configured minimum role
 	logger     *slog.Logger
 	mu         sync.RWMutex
 }
@@ -209,10 +210,15 @@ func (r *Registry) Execute(ctx context.Context, inv *Invocation) (*Result, error
 		return nil, fmt.Errorf("command %q not found", inv.Name)
 	}
 
-	// Check admin restriction
-	if cmd.AdminOnly && !inv.IsAdmin {
+	// Check role restriction
+	if required := r.RequiredRole(cmd); !inv.EffectiveRole().Allows(required) {
+		if required == RoleAdmin {
+			return &Result{
+				Error: "This command requires admin privileges",
+			}, nil
+		}
 		return &Result{
-			Error: "This command requires admin privileges",
+			Error: fmt.Sprintf("This command requires the %s role", required),
 		}, nil
 	}
 
@@ -227,6 +233,30 @@ func (r *Registry) Execute(ctx context.Context, inv *Invocation) (*Result, error
 	return cmd.Handler(ctx, inv)
 }
 
+// SetMinRoles overrides the minimum role of commands by name. Overrides
+// replace both MinRole and AdminOnly.
+func (r *Registry) SetMinRoles(roles map[string]Role) {
+	normalized := make(map[string]Role, len(roles))
+	for name, role := range roles {
+		normalized[strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "/")))] = role
+	}
+	r.mu.Lock()
+	r.minRoles = normalized
+	r.mu.Unlock()
+}
+
+// RequiredRole returns the minimum role needed to run cmd, taking configured
+// overrides into account.
+func (r *Registry) RequiredRole(cmd *Command) Role {
+	r.mu.RLock()
+	role, ok := r.minRoles[strings.ToLower(cmd.Name)]
+	r.mu.RUnlock()
+	if ok && role.rank() > 0 {
+		return role
+	}
+	return cmd.RequiredRole()
+}
+
 // Names returns all registered command names (not aliases).
 func (r *Registry) Names() []string {
 	r.mu.RLock()
@@ -0,0 +1,329 @@
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/haasonsaas/nexus/internal/access"
+)
+
+// Role is a command permission tier. Roles are ordered: admin can run
+// everything a member can, and a member everything a guest can.
+type Role string
+
+const (
+	RoleGuest  Role = "guest"
+	RoleMember Role = "member"
+	RoleAdmin  Role = "admin"
+)
+
+// ParseRole parses a role name case-insensitively.
+func ParseRole(value string) (Role, error) {
+	role := Role(strings.ToLower(strings.TrimSpace(value)))
+	if role.rank() == 0 {
+		return "", fmt.Errorf("unknown role %q (expected guest, member, or admin)", value)
+	}
+	return role, nil
+}
+
+func (r Role) rank() int {
+	switch r {
+	case RoleGuest:
+		return 1
+	case RoleMember:
+		return 2
+	case RoleAdmin:
+		return 3
+	}
+	return 0
+}
+
+// Allows reports whether r meets the minimum role min.
+func (r Role) Allows(min Role) bool {
+	return r.rank() >= min.rank()
+}
+
+// RequiredRole returns the minimum role needed to run the command. Commands
+// without an explicit MinRole require member, or admin when AdminOnly is set.
+func (c *Command) RequiredRole() Role {
+	if c.AdminOnly {
+		return RoleAdmin
+	}
+	if c.MinRole.rank() > 0 {
+		return c.MinRole
+	}
+	return RoleMember
+}
+
+// EffectiveRole returns the invoker's role, falling back to IsAdmin for
+// callers that do not resolve roles.
+func (inv *Invocation) EffectiveRole() Role {
+	if inv.Role.rank() > 0 {
+		return inv.Role
+	}
+	if inv.IsAdmin {
+		return RoleAdmin
+	}
+	return RoleMember
+}
+
+// roleStoreData is the persisted format.
+type roleStoreData struct {
+	Version int             `json:"version"`
+	Roles   map[string]Role `json:"roles"`
+}
+
+// RoleStore persists roles granted at runtime with /role. Subjects are either
+// "channel:sender_id" or a canonical identity ID.
+type RoleStore struct {
+	mu    sync.RWMutex
+	path  string
+	roles map[string]Role
+}
+
+// DefaultRoleStorePath returns ~/.nexus/roles.json.
+func DefaultRoleStorePath() string {
+	home, err := os.UserHomeDir()
+	if err != nil || strings.TrimSpace(home) == "" {
+		home = "."
+	}
+	return filepath.Join(home, ".nexus", "roles.json")
+}
+
+// NewRoleStore loads the role store at path. An empty path keeps grants in
+// memory only. A missing file is treated as an empty store.
+func NewRoleStore(path string) (*RoleStore, error) {
+	s := &RoleStore{path: path, roles: make(map[string]Role)}
+	if path == "" {
+		return s, nil
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read role store: %w", err)
+	}
+	var stored roleStoreData
+	if err := json.Unmarshal(data, &stored); err != nil {
+		return nil, fmt.Errorf("parse role store: %w", err)
+	}
+	for subject, role := range stored.Roles {
+		if role.rank() > 0 {
+			s.roles[normalizeSubject(subject)] = role
+		}
+	}
+	return s, nil
+}
+
+// Get returns the role granted to subject.
+func (s *RoleStore) Get(subject string) (Role, bool) {
+	if s == nil {
+		return "", false
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	role, ok := s.roles[normalizeSubject(subject)]
+	return role, ok
+}
+
+// Set grants role to subject and persists the change.
+func (s *RoleStore) Set(subject string, role Role) error {
+	subject = normalizeSubject(subject)
+	if subject == "" {
+		return fmt.Errorf("subject is required")
+	}
+	if role.rank() == 0 {
+		return fmt.Errorf("invalid role %q", role)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	prev, had := s.roles[subject]
+	s.roles[subject] = role
+	if err := s.saveLocked(); err != nil {
+		if had {
+			s.roles[subject] = prev
+		} else {
+			delete(s.roles, subject)
+		}
+		return err
+	}
+	return nil
+}
+
+// Remove revokes the role granted to subject. It reports whether a grant
+// existed.
+func (s *RoleStore) Remove(subject string) (bool, error) {
+	subject = normalizeSubject(subject)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	prev, ok := s.roles[subject]
+	if !ok {
+		return false, nil
+	}
+	delete(s.roles, subject)
+	if err := s.saveLocked(); err != nil {
+		s.roles[subject] = prev
+		return false, err
+	}
+	return true, nil
+}
+
+// List returns a copy of all grants.
+func (s *RoleStore) List() map[string]Role {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	out := make(map[string]Role, len(s.roles))
+	for subject, role := range s.roles {
+		out[subject] = role
+	}
+	return out
+}
+
+func (s *RoleStore) saveLocked() error {
+	if s.path == "" {
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(s.path), 0700); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(roleStoreData{Version: 1, Roles: s.roles}, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp := s.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, s.path)
+}
+
+func normalizeSubject(subject string) string {
+	return strings.ToLower(strings.TrimSpace(subject))
+}
+
+// SubjectResolver maps a /role target (a mention or ID) to the subject that
+// roles are stored under.
+type SubjectResolver func(ctx context.Context, channel, target string) string
+
+// RegisterRoleCommand registers the /role command backed by store. Anyone can
+// view their own role; granting and revoking requires admin. resolve may be nil, in which case targets without an explicit channel
+// prefix are scoped to the invoking channel.
+func RegisterRoleCommand(r *Registry, store *RoleStore, resolve SubjectResolver) error {
+	if store == nil {
+		return fmt.Errorf("role store is required")
+	}
+	return r.Register(&Command{
+		Name:        "role",
+		Aliases:     []string{"roles"},
+		Description: "Show or manage command roles",
+		Usage:       "/role [grant @user <guest|member|admin> | revoke @user | list]",
+		AcceptsArgs: true,
+		MinRole:     RoleGuest,
+		Category:    "system",
+		Source:      "builtin",
+		Handler:     roleHandler(store, resolve),
+	})
+}
+
+func roleHandler(store *RoleStore, resolve SubjectResolver) CommandHandler {
+	return func(ctx context.Context, inv *Invocation) (*Result, error) {
+		fields := strings.Fields(inv.Args)
+		if len(fields) == 0 {
+			return &Result{Text: fmt.Sprintf("Your role: %s", inv.EffectiveRole())}, nil
+		}
+		if !inv.EffectiveRole().Allows(RoleAdmin) {
+			return &Result{Error: "Managing roles requires the admin role"}, nil
+		}
+
+		channel := ""
+		if inv.Context != nil {
+			channel, _ = inv.Context["channel"].(string)
+		}
+		subjectFor := func(target string) string {
+			target = normalizeMention(target)
+			if target == "" {
+				return ""
+			}
+			if resolve != nil {
+				return normalizeSubject(resolve(ctx, channel, target))
+			}
+			// Only a known channel prefix scopes the target, so IDs that
+			// contain a colon (Matrix's "alice:matrix.org") stay whole.
+			ch, sender, err := access.ParseSubject(target, channel)
+			if err != nil {
+				return normalizeSubject(target)
+			}
+			return normalizeSubject(ch + ":" + sender)
+		}
+
+		switch strings.ToLower(fields[0]) {
+		case "grant", "set":
+			if len(fields) != 3 {
+				return &Result{Error: "Usage: /role grant @user <guest|member|admin>"}, nil
+			}
+			role, err := ParseRole(fields[2])
+			if err != nil {
+				return &Result{Error: err.Error()}, nil
+			}
+			subject := subjectFor(fields[1])
+			if subject == "" {
+				return &Result{Error: "Usage: /role grant @user <guest|member|admin>"}, nil
+			}
+			if err := store.Set(subject, role); err != nil {
+				return nil, err
+			}
+			return &Result{Text: fmt.Sprintf("Granted %s to %s", role, subject)}, nil
+		case "revoke", "remove":
+			if len(fields) != 2 {
+				return &Result{Error: "Usage: /role revoke @user"}, nil
+			}
+			subject := subjectFor(fields[1])
+			removed, err := store.Remove(subject)
+			if err != nil {
+				return nil, err
+			}
+			if !removed {
+				return &Result{Text: fmt.Sprintf("No role granted to %s", subject)}, nil
+			}
+			return &Result{Text: fmt.Sprintf("Revoked role for %s", subject)}, nil
+		case "list":
+			grants := store.List()
+			if len(grants) == 0 {
+				return &Result{Text: "No roles granted."}, nil
+			}
+			subjects := make([]string, 0, len(grants))
+			for subject := range grants {
+				subjects = append(subjects, subject)
+			}
+			sort.Strings(subjects)
+			lines := make([]string, 0, len(subjects))
+			for _, subject := range subjects {
+				lines = append(lines, fmt.Sprintf("%s: %s", subject, grants[subject]))
+			}
+			return &Result{Text: strings.Join(lines, "\n")}, nil
+		}
+		return &Result{Error: "Usage: " + inv.Command.Usage}, nil
+	}
+}
+
+// normalizeMention strips channel mention syntax such as "@alice",
+// "<@U123>", "<@!123>", and "<@U123|alice>" down to the user ID.
+func normalizeMention(target string) string {
+	target = strings.TrimSpace(target)
+	if strings.HasPrefix(target, "<@") && strings.HasSuffix(target, ">") {
+		target = strings.TrimSuffix(strings.TrimPrefix(target, "<@"), ">")
+		target = strings.TrimPrefix(target, "!")
+		if idx := strings.Index(target, "|"); idx >= 0 {
+			target = target[:idx]
+		}
+	}
+	return strings.TrimPrefix(target, "@")
+}
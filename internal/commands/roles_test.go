@@ -0,0 +1,142 @@
+package commands
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRoleAllows(t *testing.T) {
+	tests := []struct {
+		role, min Role
+		want      bool
+	}{
+		{RoleAdmin, RoleMember, true},
+		{RoleMember, RoleMember, true},
+		{RoleGuest, RoleMember, false},
+		{RoleMember, RoleAdmin, false},
+		{RoleGuest, RoleGuest, true},
+	}
+	for _, tt := range tests {
+		if got := tt.role.Allows(tt.min); got != tt.want {
+			t.Errorf("%s.Allows(%s) = %v, want %v", tt.role, tt.min, got, tt.want)
+		}
+	}
+	if _, err := ParseRole("owner"); err == nil {
+		t.Error("expected error for unknown role")
+	}
+	if role, err := ParseRole(" Admin "); err != nil || role != RoleAdmin {
+		t.Errorf("ParseRole(Admin) = %q, %v", role, err)
+	}
+}
+
+func TestRegistry_MinRole(t *testing.T) {
+	r := NewRegistry(nil)
+	if err := RegisterBuiltins(r); err != nil {
+		t.Fatalf("RegisterBuiltins: %v", err)
+	}
+	ctx := context.Background()
+
+	result, _ := r.Execute(ctx, &Invocation{Name: "status", Role: RoleGuest})
+	if result.Error != "" {
+		t.Errorf("guest should run /status, got %q", result.Error)
+	}
+	result, _ = r.Execute(ctx, &Invocation{Name: "undo", Role: RoleGuest})
+	if !strings.Contains(result.Error, "member role") {
+		t.Errorf("guest should be denied /undo, got %+v", result)
+	}
+
+	r.SetMinRoles(map[string]Role{"/undo": RoleAdmin})
+	result, _ = r.Execute(ctx, &Invocation{Name: "undo", Role: RoleMember})
+	if !strings.Contains(result.Error, "admin privileges") {
+		t.Errorf("member should be denied overridden /undo, got %+v", result)
+	}
+
+	help, _ := r.Execute(ctx, &Invocation{Name: "help", Role: RoleGuest})
+	if strings.Contains(help.Text, "/undo") || !strings.Contains(help.Text, "/status") {
+		t.Errorf("guest help should only list runnable commands:\n%s", help.Text)
+	}
+}
+
+func TestRoleStorePersists(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "roles.json")
+	store, err := NewRoleStore(path)
+	if err != nil {
+		t.Fatalf("NewRoleStore: %v", err)
+	}
+	if err := store.Set("Telegram:123", RoleAdmin); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+
+	reloaded, err := NewRoleStore(path)
+	if err != nil {
+		t.Fatalf("reload: %v", err)
+	}
+	if role, ok := reloaded.Get("telegram:123"); !ok || role != RoleAdmin {
+		t.Fatalf("Get = %q, %v", role, ok)
+	}
+	if removed, err := reloaded.Remove("telegram:123"); err != nil || !removed {
+		t.Fatalf("Remove = %v, %v", removed, err)
+	}
+	if _, ok := reloaded.Get("telegram:123"); ok {
+		t.Fatal("expected grant to be removed")
+	}
+}
+
+func TestRoleCommand(t *testing.T) {
+	r := NewRegistry(nil)
+	store, _ := NewRoleStore("")
+	if err := RegisterRoleCommand(r, store, nil); err != nil {
+		t.Fatalf("RegisterRoleCommand: %v", err)
+	}
+	ctx := context.Background()
+	invoke := func(role Role, args string) *Result {
+		result, err := r.Execute(ctx, &Invocation{
+			Name:    "role",
+			Args:    args,
+			Role:    role,
+			Context: map[string]any{"channel": "slack"},
+		})
+		if err != nil {
+			t.Fatalf("Execute(%q): %v", args, err)
+		}
+		return result
+	}
+
+	if got := invoke(RoleGuest, ""); got.Text != "Your role: guest" {
+		t.Errorf("bare /role = %+v", got)
+	}
+	if got := invoke(RoleMember, "grant @bob admin"); got.Error == "" {
+		t.Error("member should not grant roles")
+	}
+	if got := invoke(RoleAdmin, "grant <@U123|bob> admin"); got.Text != "Granted admin to slack:u123" {
+		t.Errorf("grant = %+v", got)
+	}
+	if got := invoke(RoleAdmin, "grant telegram:42 guest"); got.Error != "" {
+		t.Errorf("grant with channel = %+v", got)
+	}
+	if got := invoke(RoleAdmin, "list"); got.Text != "slack:u123: admin\ntelegram:42: guest" {
+		t.Errorf("list = %q", got.Text)
+	}
+	if got := invoke(RoleAdmin, "revoke @U123"); got.Text != "Revoked role for slack:u123" {
+		t.Errorf("revoke = %+v", got)
+	}
+	if got := invoke(RoleAdmin, "grant @bob owner"); !strings.Contains(got.Error, "unknown role") {
+		t.Errorf("invalid role = %+v", got)
+	}
+
+	// Matrix user IDs contain a colon but name no channel.
+	result, err := r.Execute(ctx, &Invocation{
+		Name:    "role",
+		Args:    "grant @alice:matrix.org member",
+		Role:    RoleAdmin,
+		Context: map[string]any{"channel": "matrix"},
+	})
+	if err != nil || result.Text != "Granted member to matrix:alice:matrix.org" {
+		t.Errorf("matrix grant = %+v, %v", result, err)
+	}
+	if got := invoke(RoleAdmin, "grant matrix:@carol:matrix.org guest"); got.Text != "Granted guest to matrix:carol:matrix.org" {
+		t.Errorf("prefixed matrix grant = %+v", got)
+	}
+}
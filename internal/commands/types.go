@@ -28,6 +28,10 @@ type Command struct {
 	// AdminOnly restricts the command to admin users
 	AdminOnly bool `json:"admin_only,omitempty"`
 
+	// MinRole is the minimum role required to run the command.
+	// Defaults to member, or admin when AdminOnly is set.
+	MinRole Role `json:"min_role,omitempty"`
+
 	// Handler is the function that executes the command
 	Handler CommandHandler `json:"-"`
 
@@ -67,6 +71,9 @@ type Invocation struct {
 	// IsAdmin indicates if the user has admin privileges
 	IsAdmin bool
 
+	// Role is the invoker's resolved command role
+	Role Role
+
 	// Context holds additional invocation data
 	Context map[string]any
 }
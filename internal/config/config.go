@@ -2,9 +2,13 @@ package config
 
 import (
 	"fmt"
+	"net"
 	"net/url"
 	"os"
+	"path"
 	"path/filepath"
+	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -12,9 +16,13 @@ import (
 	"github.com/haasonsaas/nexus/internal/audit"
 	"github.com/haasonsaas/nexus/internal/experiments"
 	"github.com/haasonsaas/nexus/internal/mcp"
+	"github.com/haasonsaas/nexus/internal/media/ocr"
+	"github.com/haasonsaas/nexus/internal/media/transcribe"
 	"github.com/haasonsaas/nexus/internal/memory"
+	"github.com/haasonsaas/nexus/internal/messages"
 	"github.com/haasonsaas/nexus/internal/ratelimit"
 	"github.com/haasonsaas/nexus/internal/skills"
+	"github.com/haasonsaas/nexus/internal/storage/encryption"
 	"github.com/haasonsaas/nexus/internal/templates"
 	"github.com/haasonsaas/nexus/internal/tts"
 )
@@ -26,6 +34,7 @@ type Config struct {
 	CanvasHost    CanvasHostConfig          `yaml:"canvas_host"`
 	Canvas        CanvasConfig              `yaml:"canvas"`
 	Gateway       GatewayConfig             `yaml:"gateway"`
+	GitOps        GitOpsConfig              `yaml:"gitops"`
 	Cluster       ClusterConfig             `yaml:"cluster"`
 	Commands      CommandsConfig            `yaml:"commands"`
 	Database      DatabaseConfig            `yaml:"database"`
@@ -34,6 +43,7 @@ type Config struct {
 	Workspace     WorkspaceConfig           `yaml:"workspace"`
 	Identity      IdentityConfig            `yaml:"identity"`
 	User          UserConfig                `yaml:"user"`
+	Messages      MessagesConfig            `yaml:"messages"`
 	Plugins       PluginsConfig             `yaml:"plugins"`
 	Marketplace   MarketplaceConfig         `yaml:"marketplace"`
 	Skills        skills.SkillsConfig       `yaml:"skills"`
@@ -41,6 +51,7 @@ type Config struct {
 	Experiments   experiments.Config        `yaml:"experiments"`
 	VectorMemory  memory.Config             `yaml:"vector_memory"`
 	Attention     AttentionConfig           `yaml:"attention"`
+	Delivery      DeliveryConfig            `yaml:"delivery"`
 	Steering      SteeringConfig            `yaml:"steering"`
 	RAG           RAGConfig                 `yaml:"rag"`
 	MCP           mcp.Config                `yaml:"mcp"`
@@ -54,7 +65,10 @@ type Config struct {
 	Logging       LoggingConfig             `yaml:"logging"`
 	Observability ObservabilityConfig       `yaml:"observability"`
 	Security      SecurityConfig            `yaml:"security"`
+	Privacy       PrivacyConfig             `yaml:"privacy"`
+	Encryption    EncryptionConfig          `yaml:"encryption"`
 	Transcription TranscriptionConfig       `yaml:"transcription"`
+	OCR           OCRConfig                 `yaml:"ocr"`
 	TTS           tts.Config                `yaml:"tts"`
 }
 
@@ -69,7 +83,7 @@ func Load(path string) (*Config, error) {
 
 	cfg, err := decodeRawConfig(raw)
 	if err != nil {
-		return nil, err
+		return nil, explainDecodeError(path, raw, err)
 	}
 	if err := ValidateVersion(cfg.Version); err != nil {
 		return nil, err
@@ -82,7 +96,7 @@ func Load(path string) (*Config, error) {
 
 	// Validate config
 	if err := validateConfig(cfg); err != nil {
-		return nil, err
+		return nil, locateIssues(path, err)
 	}
 
 	return cfg, nil
@@ -97,16 +111,22 @@ func applyDefaults(cfg *Config) {
 	applyClusterDefaults(&cfg.Cluster)
 	applyChannelDefaults(&cfg.Channels)
 	applyCommandsDefaults(&cfg.Commands)
+	applyBroadcastDefaults(&cfg.Gateway.Broadcast.Outbound)
+	applyGitOpsDefaults(&cfg.GitOps)
 	applySessionDefaults(&cfg.Session)
 	applyWorkspaceDefaults(&cfg.Workspace)
 	applyToolsDefaults(cfg)
 	applyAttentionDefaults(&cfg.Attention)
+	applyDeliveryDefaults(&cfg.Delivery)
 	applySteeringDefaults(&cfg.Steering)
 	applyLLMDefaults(&cfg.LLM)
 	applyLoggingDefaults(&cfg.Logging)
 	applyObservabilityDefaults(&cfg.Observability)
 	applySecurityDefaults(&cfg.Security)
+	applyPrivacyDefaults(&cfg.Privacy)
+	applyEncryptionDefaults(&cfg.Encryption)
 	applyTranscriptionDefaults(&cfg.Transcription)
+	applyOCRDefaults(&cfg.OCR)
 	applyTTSDefaults(&cfg.TTS)
 	applyMarketplaceDefaults(&cfg.Marketplace)
 	applyRAGDefaults(&cfg.RAG)
@@ -114,6 +134,34 @@ func applyDefaults(cfg *Config) {
 	applyArtifactDefaults(&cfg.Artifacts)
 }
 
+func applyBroadcastDefaults(cfg *OutboundBroadcastConfig) {
+	if cfg.MaxAttempts == 0 {
+		cfg.MaxAttempts = 3
+	}
+	if cfg.RetryDelay == 0 {
+		cfg.RetryDelay = 2 * time.Second
+	}
+}
+
+func applyGitOpsDefaults(cfg *GitOpsConfig) {
+	if cfg.Path == "" {
+		cfg.Path = "nexus.yaml"
+	}
+	if cfg.Interval == 0 {
+		cfg.Interval = time.Minute
+	}
+	if cfg.Mode == "" {
+		cfg.Mode = GitOpsModeApply
+	}
+	if cfg.CacheDir == "" {
+		home, err := os.UserHomeDir()
+		if err != nil || strings.TrimSpace(home) == "" {
+			home = "."
+		}
+		cfg.CacheDir = filepath.Join(home, ".nexus", "cache", "gitops")
+	}
+}
+
 func applyServerDefaults(cfg *ServerConfig) {
 	if cfg.Host == "" {
 		cfg.Host = "0.0.0.0"
@@ -127,6 +175,12 @@ func applyServerDefaults(cfg *ServerConfig) {
 	if cfg.MetricsPort == 0 {
 		cfg.MetricsPort = 9090
 	}
+	if cfg.Diagnostics.Host == "" {
+		cfg.Diagnostics.Host = "127.0.0.1"
+	}
+	if cfg.Diagnostics.Port == 0 {
+		cfg.Diagnostics.Port = 6060
+	}
 }
 
 func applyClusterDefaults(cfg *ClusterConfig) {
@@ -149,6 +203,21 @@ func applyClusterDefaults(cfg *ClusterConfig) {
 	if cfg.SessionLocks.PollInterval == 0 {
 		cfg.SessionLocks.PollInterval = 200 * time.Millisecond
 	}
+	if cfg.Coordination.HeartbeatInterval == 0 {
+		cfg.Coordination.HeartbeatInterval = 10 * time.Second
+	}
+	if cfg.Coordination.NodeTTL == 0 {
+		cfg.Coordination.NodeTTL = 30 * time.Second
+	}
+	if cfg.Coordination.LeaseTTL == 0 {
+		cfg.Coordination.LeaseTTL = 30 * time.Second
+	}
+	if cfg.EventBus.Backend == "" {
+		cfg.EventBus.Backend = "auto"
+	}
+	if cfg.EventBus.Channel == "" {
+		cfg.EventBus.Channel = "nexus_events"
+	}
 }
 
 func applyCanvasHostDefaults(cfg *CanvasHostConfig, rootCfg *Config) {
@@ -298,6 +367,16 @@ func applyAuthDefaults(cfg *AuthConfig) {
 	if cfg.TokenExpiry == 0 {
 		cfg.TokenExpiry = 24 * time.Hour
 	}
+	applyAuditDefaults(&cfg.Audit)
+	if len(cfg.OIDC.Scopes) == 0 {
+		cfg.OIDC.Scopes = []string{"openid", "email", "profile"}
+	}
+	if cfg.OIDC.GroupsClaim == "" {
+		cfg.OIDC.GroupsClaim = "groups"
+	}
+	if cfg.OIDC.SessionTTL == 0 {
+		cfg.OIDC.SessionTTL = 12 * time.Hour
+	}
 }
 
 func applyChannelDefaults(cfg *ChannelsConfig) {
@@ -318,6 +397,26 @@ func applyChannelDefaults(cfg *ChannelsConfig) {
 	applyChannelPolicyDefaults(&cfg.Matrix.Group)
 	applyChannelPolicyDefaults(&cfg.Teams.DM)
 	applyChannelPolicyDefaults(&cfg.Teams.Group)
+	applyChannelPolicyDefaults(&cfg.Mattermost.DM)
+	applyChannelPolicyDefaults(&cfg.Mattermost.Group)
+	applyChannelPolicyDefaults(&cfg.NextcloudTalk.DM)
+	applyChannelPolicyDefaults(&cfg.NextcloudTalk.Group)
+	applyChannelPolicyDefaults(&cfg.Zalo.DM)
+	applyChannelPolicyDefaults(&cfg.Zalo.Group)
+	applyChannelPolicyDefaults(&cfg.BlueBubbles.DM)
+	applyChannelPolicyDefaults(&cfg.BlueBubbles.Group)
+	applyEmailDefaults(&cfg.Email)
+}
+
+func applyEmailDefaults(cfg *EmailConfig) {
+	if cfg.IMAP.IDLE == nil {
+		idle := true
+		cfg.IMAP.IDLE = &idle
+	}
+	if cfg.Compose.RequireApproval == nil {
+		requireApproval := true
+		cfg.Compose.RequireApproval = &requireApproval
+	}
 }
 
 func applyAttentionDefaults(cfg *AttentionConfig) {
@@ -327,6 +426,31 @@ func applyAttentionDefaults(cfg *AttentionConfig) {
 	if cfg.MaxItems == 0 {
 		cfg.MaxItems = 5
 	}
+	if cfg.Retention == 0 {
+		cfg.Retention = 7 * 24 * time.Hour
+	}
+	if strings.TrimSpace(cfg.Digest.Schedule) == "" {
+		cfg.Digest.Schedule = "0 9 * * *"
+	}
+	if cfg.Digest.MaxItems == 0 {
+		cfg.Digest.MaxItems = 20
+	}
+}
+
+func applyDeliveryDefaults(cfg *DeliveryConfig) {
+	if cfg == nil {
+		return
+	}
+	if strings.TrimSpace(cfg.Strategy) == "" {
+		cfg.Strategy = "preferred"
+	}
+	if cfg.Fallback == nil {
+		fallback := true
+		cfg.Fallback = &fallback
+	}
+	if strings.TrimSpace(cfg.DuringQuietHours) == "" {
+		cfg.DuringQuietHours = "defer"
+	}
 }
 
 func applySteeringDefaults(cfg *SteeringConfig) {
@@ -346,6 +470,15 @@ func applyChannelPolicyDefaults(cfg *ChannelPolicyConfig) {
 	if strings.TrimSpace(cfg.Policy) == "" {
 		cfg.Policy = "open"
 	}
+	if strings.TrimSpace(cfg.Activation) == "" {
+		cfg.Activation = "always"
+	}
+	if cfg.Interject.Probability == 0 {
+		cfg.Interject.Probability = 0.25
+	}
+	if cfg.Interject.MinRelevance == 0 {
+		cfg.Interject.MinRelevance = 0.7
+	}
 }
 
 func applySlackCanvasDefaults(cfg *SlackCanvasConfig) {
@@ -381,6 +514,9 @@ func applyCommandsDefaults(cfg *CommandsConfig) {
 	if len(cfg.InlineCommands) == 0 {
 		cfg.InlineCommands = []string{"help", "commands", "status", "whoami", "id"}
 	}
+	if strings.TrimSpace(cfg.Roles.Default) == "" {
+		cfg.Roles.Default = "member"
+	}
 }
 
 func applySessionDefaults(cfg *SessionConfig) {
@@ -405,12 +541,30 @@ func applySessionDefaults(cfg *SessionConfig) {
 	if cfg.Memory.Scope == "" {
 		cfg.Memory.Scope = "session"
 	}
+	if cfg.Memory.Review.AutoApproveScopes == nil {
+		cfg.Memory.Review.AutoApproveScopes = []string{"session"}
+	}
+	if strings.TrimSpace(cfg.Memory.Consolidation.Schedule) == "" {
+		cfg.Memory.Consolidation.Schedule = "0 3 * * *"
+	}
+	if cfg.Memory.Consolidation.MinAgeDays == 0 {
+		cfg.Memory.Consolidation.MinAgeDays = 7
+	}
+	if cfg.Memory.Consolidation.MaxInputChars == 0 {
+		cfg.Memory.Consolidation.MaxInputChars = 40000
+	}
+	if cfg.Memory.Consolidation.MaxOutputTokens == 0 {
+		cfg.Memory.Consolidation.MaxOutputTokens = 1000
+	}
 	if cfg.Heartbeat.File == "" {
 		cfg.Heartbeat.File = "HEARTBEAT.md"
 	}
 	if cfg.Heartbeat.Mode == "" {
 		cfg.Heartbeat.Mode = "always"
 	}
+	if cfg.Heartbeat.CheckInterval == 0 {
+		cfg.Heartbeat.CheckInterval = time.Minute
+	}
 	if cfg.MemoryFlush.Threshold == 0 {
 		cfg.MemoryFlush.Threshold = 80
 	}
@@ -418,6 +572,43 @@ func applySessionDefaults(cfg *SessionConfig) {
 		cfg.MemoryFlush.Prompt = "Session nearing compaction. If there are durable facts, store them in memory/YYYY-MM-DD.md or MEMORY.md. Reply NO_REPLY if nothing needs attention."
 	}
 	applySessionScopeDefaults(&cfg.Scoping)
+	if cfg.Catchup.MaxInputTokens == 0 {
+		cfg.Catchup.MaxInputTokens = 4000
+	}
+	if cfg.Catchup.MaxOutputTokens == 0 {
+		cfg.Catchup.MaxOutputTokens = 400
+	}
+	if cfg.Catchup.Lookback == 0 {
+		cfg.Catchup.Lookback = 24 * time.Hour
+	}
+	if strings.TrimSpace(cfg.Catchup.Digest.Schedule) == "" {
+		cfg.Catchup.Digest.Schedule = "0 9 * * *"
+	}
+	if cfg.Catchup.Digest.MinMessages == 0 {
+		cfg.Catchup.Digest.MinMessages = 10
+	}
+	if cfg.Language.Detect == nil {
+		detect := true
+		cfg.Language.Detect = &detect
+	}
+	if cfg.RunRecovery.Mode == "" {
+		cfg.RunRecovery.Mode = "resume"
+	}
+	if cfg.RunRecovery.MaxAge == 0 {
+		cfg.RunRecovery.MaxAge = 30 * time.Minute
+	}
+	if cfg.RunRecovery.MaxAttempts == 0 {
+		cfg.RunRecovery.MaxAttempts = 1
+	}
+	if cfg.Checkpoints.Interval == 0 {
+		cfg.Checkpoints.Interval = 2 * time.Minute
+	}
+	if cfg.Checkpoints.MaxAge == 0 {
+		cfg.Checkpoints.MaxAge = 7 * 24 * time.Hour
+	}
+	if cfg.Checkpoints.MaxCount == 0 {
+		cfg.Checkpoints.MaxCount = 50
+	}
 }
 
 func applySessionScopeDefaults(cfg *SessionScopeConfig) {
@@ -454,6 +645,12 @@ func applyWorkspaceDefaults(cfg *WorkspaceConfig) {
 	if cfg.MemoryFile == "" {
 		cfg.MemoryFile = "MEMORY.md"
 	}
+	if cfg.History.Dir == "" {
+		cfg.History.Dir = ".nexus/history"
+	}
+	if cfg.History.MaxVersions == 0 {
+		cfg.History.MaxVersions = 50
+	}
 }
 
 func applyToolsDefaults(cfg *Config) {
@@ -469,6 +666,18 @@ func applyToolsDefaults(cfg *Config) {
 	if cfg.Tools.WebFetch.MaxChars == 0 {
 		cfg.Tools.WebFetch.MaxChars = 10000
 	}
+	if cfg.Tools.WebWatch.CheckInterval == 0 {
+		cfg.Tools.WebWatch.CheckInterval = time.Minute
+	}
+	if cfg.Tools.WebWatch.DefaultInterval == 0 {
+		cfg.Tools.WebWatch.DefaultInterval = time.Hour
+	}
+	if cfg.Tools.WebWatch.MinInterval == 0 {
+		cfg.Tools.WebWatch.MinInterval = 5 * time.Minute
+	}
+	if cfg.Tools.WebWatch.MaxPerSession == 0 {
+		cfg.Tools.WebWatch.MaxPerSession = 20
+	}
 	if cfg.Tools.MemorySearch.Mode == "" {
 		cfg.Tools.MemorySearch.Mode = "hybrid"
 	}
@@ -493,12 +702,33 @@ func applyToolsDefaults(cfg *Config) {
 	if cfg.Tools.Jobs.PruneInterval == 0 {
 		cfg.Tools.Jobs.PruneInterval = 1 * time.Hour
 	}
+	if len(cfg.Tools.Drafts.Tools) == 0 {
+		cfg.Tools.Drafts.Tools = []string{"email_compose"}
+	}
+	if cfg.Tools.Drafts.TTL == 0 {
+		cfg.Tools.Drafts.TTL = time.Hour
+	}
 	if strings.TrimSpace(cfg.Tools.Execution.ResultGuard.RedactionText) == "" {
 		cfg.Tools.Execution.ResultGuard.RedactionText = "[redacted]"
 	}
 	if strings.TrimSpace(cfg.Tools.Execution.ResultGuard.TruncateSuffix) == "" {
 		cfg.Tools.Execution.ResultGuard.TruncateSuffix = "...[truncated]"
 	}
+	if cfg.Tools.Execution.SchemaValidation.RepairAttempts == 0 {
+		cfg.Tools.Execution.SchemaValidation.RepairAttempts = 1
+	}
+	if cfg.Tools.Execution.LoopDetection.MaxRepeats == 0 {
+		cfg.Tools.Execution.LoopDetection.MaxRepeats = 5
+	}
+	if cfg.Tools.Execution.LoopDetection.MaxStalledIterations == 0 {
+		cfg.Tools.Execution.LoopDetection.MaxStalledIterations = 3
+	}
+	if strings.TrimSpace(cfg.Tools.Sandbox.Snapshots.Dir) == "" {
+		cfg.Tools.Sandbox.Snapshots.Dir = "/var/lib/firecracker/snapshots"
+	}
+	if cfg.Tools.Sandbox.Snapshots.Jitter == 0 {
+		cfg.Tools.Sandbox.Snapshots.Jitter = 0.1
+	}
 }
 
 func applyMemorySearchEmbeddingsDefaults(cfg *MemorySearchEmbeddingsConfig) {
@@ -551,6 +781,12 @@ func applyLinksDefaults(cfg *LinksConfig) {
 	if cfg.TimeoutSeconds == 0 {
 		cfg.TimeoutSeconds = 30
 	}
+	if cfg.SummaryCacheTTL == 0 {
+		cfg.SummaryCacheTTL = 24 * time.Hour
+	}
+	for i := range cfg.Models {
+		cfg.Models[i].Type = strings.ToLower(strings.TrimSpace(cfg.Models[i].Type))
+	}
 }
 
 // DefaultWorkspaceConfig returns a workspace config with defaults applied.
@@ -567,6 +803,55 @@ func applyLLMDefaults(cfg *LLMConfig) {
 	if cfg.Routing.Classifier == "" {
 		cfg.Routing.Classifier = "heuristic"
 	}
+	if cfg.Routing.ClassifierLLM.Timeout == 0 {
+		cfg.Routing.ClassifierLLM.Timeout = 5 * time.Second
+	}
+	if cfg.Routing.ClassifierLLM.CacheSize == 0 {
+		cfg.Routing.ClassifierLLM.CacheSize = 1000
+	}
+	if cfg.Routing.ClassifierLLM.CacheTTL == 0 {
+		cfg.Routing.ClassifierLLM.CacheTTL = time.Hour
+	}
+	if cfg.KeyRotation.RateLimitCooldown == 0 {
+		cfg.KeyRotation.RateLimitCooldown = time.Minute
+	}
+	if cfg.KeyRotation.AuthCooldown == 0 {
+		cfg.KeyRotation.AuthCooldown = time.Hour
+	}
+	if cfg.KeyRotation.RemindAfter == 0 {
+		cfg.KeyRotation.RemindAfter = 90 * 24 * time.Hour
+	}
+	if cfg.Batch.MaxBatchSize == 0 {
+		cfg.Batch.MaxBatchSize = 1000
+	}
+	if cfg.Batch.FlushInterval == 0 {
+		cfg.Batch.FlushInterval = time.Minute
+	}
+	if cfg.Batch.PollInterval == 0 {
+		cfg.Batch.PollInterval = time.Minute
+	}
+	if cfg.Batch.Timeout == 0 {
+		cfg.Batch.Timeout = 24 * time.Hour
+	}
+	if cfg.Reasoning.Redact == nil {
+		redact := true
+		cfg.Reasoning.Redact = &redact
+	}
+	for name, provider := range cfg.Providers {
+		if len(provider.Keys) == 0 {
+			continue
+		}
+		for i := range provider.Keys {
+			key := &provider.Keys[i]
+			if strings.TrimSpace(key.ID) == "" {
+				key.ID = fmt.Sprintf("key-%d", i+1)
+			}
+			if key.Weight == 0 {
+				key.Weight = 1
+			}
+		}
+		cfg.Providers[name] = provider
+	}
 	if cfg.AutoDiscover.Ollama.Enabled && len(cfg.AutoDiscover.Ollama.ProbeLocations) == 0 {
 		cfg.AutoDiscover.Ollama.ProbeLocations = []string{
 			"http://localhost:11434",
@@ -598,6 +883,144 @@ func applyObservabilityDefaults(cfg *ObservabilityConfig) {
 	if cfg.Tracing.Attributes == nil {
 		cfg.Tracing.Attributes = map[string]string{}
 	}
+	if cfg.CrashReporting.RestartBackoff == 0 {
+		cfg.CrashReporting.RestartBackoff = time.Second
+	}
+	if cfg.CrashReporting.MaxRestartBackoff == 0 {
+		cfg.CrashReporting.MaxRestartBackoff = time.Minute
+	}
+	if cfg.SLO.Interval == 0 {
+		cfg.SLO.Interval = 30 * time.Second
+	}
+	if cfg.SLO.Window == 0 {
+		cfg.SLO.Window = 30 * 24 * time.Hour
+	}
+	if cfg.SLO.Alerts.CriticalBurnRate == 0 {
+		cfg.SLO.Alerts.CriticalBurnRate = 14.4
+	}
+	if cfg.SLO.Alerts.WarningBurnRate == 0 {
+		cfg.SLO.Alerts.WarningBurnRate = 6
+	}
+	if cfg.Anomaly.Interval == 0 {
+		cfg.Anomaly.Interval = time.Minute
+	}
+	if cfg.Anomaly.Alpha == 0 {
+		cfg.Anomaly.Alpha = 0.1
+	}
+	if cfg.Anomaly.Threshold == 0 {
+		cfg.Anomaly.Threshold = 4
+	}
+	if cfg.Anomaly.Warmup == 0 {
+		cfg.Anomaly.Warmup = 10
+	}
+	if cfg.Anomaly.MinErrors == 0 {
+		cfg.Anomaly.MinErrors = 5
+	}
+	if cfg.Anomaly.MinTokens == 0 {
+		cfg.Anomaly.MinTokens = 50000
+	}
+	if cfg.Anomaly.MinPeerMessages == 0 {
+		cfg.Anomaly.MinPeerMessages = 20
+	}
+	if cfg.Canary.Interval == 0 {
+		cfg.Canary.Interval = 5 * time.Minute
+	}
+	if cfg.Canary.Timeout == 0 {
+		cfg.Canary.Timeout = time.Minute
+	}
+	if cfg.Canary.Alerts.AfterFailures == 0 {
+		cfg.Canary.Alerts.AfterFailures = 3
+	}
+	if cfg.CostReport.Period == "" {
+		cfg.CostReport.Period = "weekly"
+	}
+	if cfg.CostReport.Schedule == "" {
+		cfg.CostReport.Schedule = "0 9 * * 1"
+		if cfg.CostReport.Period == "monthly" {
+			cfg.CostReport.Schedule = "0 9 1 * *"
+		}
+	}
+	if cfg.CostReport.WarnAt == 0 {
+		cfg.CostReport.WarnAt = 1
+	}
+	if cfg.CostReport.TopN == 0 {
+		cfg.CostReport.TopN = 5
+	}
+	if cfg.Metrics.Exporter == "" {
+		cfg.Metrics.Exporter = MetricsExporterPrometheus
+	}
+	if cfg.Metrics.OTLP.Protocol == "" {
+		cfg.Metrics.OTLP.Protocol = "grpc"
+	}
+	if cfg.Metrics.OTLP.Interval == 0 {
+		cfg.Metrics.OTLP.Interval = time.Minute
+	}
+	if cfg.Metrics.OTLP.Timeout == 0 {
+		cfg.Metrics.OTLP.Timeout = 10 * time.Second
+	}
+	if cfg.EventStore.Retention == 0 {
+		cfg.EventStore.Retention = 30 * 24 * time.Hour
+	}
+	if cfg.EventStore.SampleRate == 0 {
+		cfg.EventStore.SampleRate = 1.0
+	}
+	if cfg.EventStore.BufferSize == 0 {
+		cfg.EventStore.BufferSize = 10000
+	}
+	if cfg.EventStore.FlushInterval == 0 {
+		cfg.EventStore.FlushInterval = 5 * time.Second
+	}
+	if cfg.EventBridge.Format == "" {
+		cfg.EventBridge.Format = "json"
+	}
+	if cfg.EventBridge.Subject == "" {
+		if cfg.EventBridge.Backend == EventBridgeBackendKafka {
+			cfg.EventBridge.Subject = "nexus.events"
+		} else {
+			cfg.EventBridge.Subject = "nexus.events.{type}"
+		}
+	}
+	if cfg.EventBridge.BufferSize == 0 {
+		cfg.EventBridge.BufferSize = 10000
+	}
+	if cfg.EventBridge.NATS.Timeout == 0 {
+		cfg.EventBridge.NATS.Timeout = 5 * time.Second
+	}
+	if cfg.EventBridge.Kafka.Timeout == 0 {
+		cfg.EventBridge.Kafka.Timeout = 10 * time.Second
+	}
+	if cfg.Canary.Enabled && len(cfg.Canary.Checks) == 0 {
+		cfg.Canary.Checks = []CanaryCheckConfig{{Name: "loopback"}}
+	}
+	for i := range cfg.Canary.Checks {
+		check := &cfg.Canary.Checks[i]
+		if check.Channel == "" {
+			check.Channel = "loopback"
+		}
+		if check.Message == "" {
+			check.Message = "ping"
+		}
+		if check.Expect == "" {
+			check.Expect = "pong"
+		}
+	}
+	for i := range cfg.SLO.Objectives {
+		objective := &cfg.SLO.Objectives[i]
+		if objective.Latency != nil && objective.Latency.Metric == "" {
+			objective.Latency.Metric = "nexus_reply_duration_seconds"
+		}
+		if objective.Errors != nil {
+			if objective.Errors.Metric == "" {
+				objective.Errors.Metric = "nexus_runs_total"
+			}
+			if objective.Errors.Label == "" {
+				objective.Errors.Label = "outcome"
+			}
+			if len(objective.Errors.Values) == 0 {
+				objective.Errors.Values = []string{"error"}
+			}
+		}
+	}
 }
 
 func applySecurityDefaults(cfg *SecurityConfig) {
@@ -607,6 +1030,12 @@ func applySecurityDefaults(cfg *SecurityConfig) {
 	if cfg.Posture.Interval == 0 {
 		cfg.Posture.Interval = 10 * time.Minute
 	}
+	if cfg.Credentials.Interval == 0 {
+		cfg.Credentials.Interval = 6 * time.Hour
+	}
+	if cfg.Credentials.QuotaWarnRatio == 0 {
+		cfg.Credentials.QuotaWarnRatio = 0.1
+	}
 	if cfg.Posture.IncludeFilesystem == nil {
 		value := true
 		cfg.Posture.IncludeFilesystem = &value
@@ -632,12 +1061,59 @@ func applySecurityDefaults(cfg *SecurityConfig) {
 	}
 }
 
+func applyPrivacyDefaults(cfg *PrivacyConfig) {
+	if cfg.Retention.Interval == 0 {
+		cfg.Retention.Interval = time.Hour
+	}
+	if cfg.Erasure.AuditDir == "" {
+		home, err := os.UserHomeDir()
+		if err != nil || home == "" {
+			home = "."
+		}
+		cfg.Erasure.AuditDir = filepath.Join(home, ".nexus", "privacy", "erasures")
+	}
+	if cfg.Erasure.SigningKeyPath == "" {
+		cfg.Erasure.SigningKeyPath = filepath.Join(filepath.Dir(cfg.Erasure.AuditDir), "erasure_signing.key")
+	}
+}
+
+func applyEncryptionDefaults(cfg *EncryptionConfig) {
+	if strings.TrimSpace(cfg.PrimaryKey) == "" && len(cfg.Keys) > 0 {
+		cfg.PrimaryKey = cfg.Keys[0].ID
+	}
+}
+
 func applyTranscriptionDefaults(cfg *TranscriptionConfig) {
 	if cfg.Provider == "" {
-		cfg.Provider = "openai"
+		cfg.Provider = transcribe.ProviderOpenAI
 	}
 	if cfg.Model == "" {
-		cfg.Model = "whisper-1"
+		switch cfg.Provider {
+		case transcribe.ProviderWhisperCPP:
+			cfg.Model = transcribe.DefaultWhisperModel
+		default:
+			cfg.Model = "whisper-1"
+		}
+	}
+	if len(cfg.Languages) > 0 {
+		languages := make(map[string]string, len(cfg.Languages))
+		for channel, lang := range cfg.Languages {
+			languages[strings.ToLower(strings.TrimSpace(channel))] = lang
+		}
+		cfg.Languages = languages
+	}
+}
+
+func applyOCRDefaults(cfg *OCRConfig) {
+	cfg.Provider = strings.ToLower(strings.TrimSpace(cfg.Provider))
+	if cfg.Provider == "" {
+		cfg.Provider = ocr.ProviderTesseract
+	}
+	if len(cfg.Languages) == 0 {
+		cfg.Languages = []string{"eng"}
+	}
+	if cfg.Timeout == 0 {
+		cfg.Timeout = 30 * time.Second
 	}
 }
 
@@ -762,6 +1238,40 @@ func applyArtifactDefaults(cfg *ArtifactConfig) {
 			"default":    24 * time.Hour,
 		}
 	}
+	if cfg.Links.TTL == 0 {
+		cfg.Links.TTL = time.Hour
+	}
+	if cfg.Links.MaxTTL == 0 {
+		cfg.Links.MaxTTL = 7 * 24 * time.Hour
+	}
+	if cfg.Processing.MIMEValidation == "" {
+		cfg.Processing.MIMEValidation = "correct"
+	}
+	if cfg.Processing.FFmpegPath == "" {
+		cfg.Processing.FFmpegPath = "ffmpeg"
+	}
+	if cfg.Processing.TranscodeTimeout == 0 {
+		cfg.Processing.TranscodeTimeout = 2 * time.Minute
+	}
+	if cfg.Processing.Types == nil {
+		cfg.Processing.Types = map[string]ArtifactProcessingRule{
+			"screenshot": {StripEXIF: true, Thumbnail: true, OCR: true},
+			"recording":  {Transcode: true},
+			"default":    {StripEXIF: true},
+		}
+	}
+	for artifactType, rule := range cfg.Processing.Types {
+		if rule.ThumbnailMaxSide == 0 {
+			rule.ThumbnailMaxSide = 320
+		}
+		if rule.MaxVideoBytes == 0 {
+			rule.MaxVideoBytes = 25 * 1024 * 1024
+		}
+		if rule.MaxVideoHeight == 0 {
+			rule.MaxVideoHeight = 720
+		}
+		cfg.Processing.Types[artifactType] = rule
+	}
 }
 
 func isTruthyEnv(value string) bool {
@@ -928,16 +1438,77 @@ func validateConfig(cfg *Config) error {
 	if cfg.Session.Memory.Scope != "" && !validMemoryScope(cfg.Session.Memory.Scope) {
 		issues = append(issues, "session.memory.scope must be \"session\", \"channel\", or \"global\"")
 	}
+	for _, scope := range cfg.Session.Memory.Review.AutoApproveScopes {
+		if !validMemoryReviewScope(scope) {
+			issues = append(issues, fmt.Sprintf("session.memory.review.auto_approve_scopes: unknown scope %q (want session, channel, agent, global, or file)", scope))
+		}
+	}
+	validateMemoryConsolidationConfig(&issues, cfg.Session.Memory.Consolidation)
 	if cfg.Session.Heartbeat.Enabled && strings.TrimSpace(cfg.Session.Heartbeat.File) == "" {
 		issues = append(issues, "session.heartbeat.file is required when heartbeat is enabled")
 	}
 	if cfg.Session.Heartbeat.Mode != "" && !validHeartbeatMode(cfg.Session.Heartbeat.Mode) {
 		issues = append(issues, "session.heartbeat.mode must be \"always\" or \"on_demand\"")
 	}
+	if cfg.Session.Heartbeat.CheckInterval < 0 {
+		issues = append(issues, "session.heartbeat.check_interval must be >= 0")
+	}
+	validateHeartbeatSchedule(&issues, "session.heartbeat.schedule", cfg.Session.Heartbeat.Schedule)
+	for agentID, sched := range cfg.Session.Heartbeat.Agents {
+		validateHeartbeatSchedule(&issues, fmt.Sprintf("session.heartbeat.agents.%s", agentID), sched)
+	}
+	for key, sched := range cfg.Session.Heartbeat.Sessions {
+		validateHeartbeatSchedule(&issues, fmt.Sprintf("session.heartbeat.sessions.%s", key), sched)
+	}
 	if cfg.Session.MemoryFlush.Threshold < 0 {
 		issues = append(issues, "session.memory_flush.threshold must be >= 0")
 	}
+	if mode := strings.ToLower(strings.TrimSpace(cfg.Session.RunRecovery.Mode)); mode != "" && mode != "resume" && mode != "finalize" {
+		issues = append(issues, "session.run_recovery.mode must be \"resume\" or \"finalize\"")
+	}
+	if cfg.Session.RunRecovery.MaxAge < 0 {
+		issues = append(issues, "session.run_recovery.max_age must be >= 0")
+	}
+	if cfg.Session.RunRecovery.MaxAttempts < 0 {
+		issues = append(issues, "session.run_recovery.max_attempts must be >= 0")
+	}
+	if cfg.Session.Checkpoints.Interval < 0 {
+		issues = append(issues, "session.checkpoints.interval must be >= 0")
+	}
+	if cfg.Session.Checkpoints.MaxAge < 0 {
+		issues = append(issues, "session.checkpoints.max_age must be >= 0")
+	}
+	if cfg.Session.Checkpoints.MaxCount < 0 {
+		issues = append(issues, "session.checkpoints.max_count must be >= 0")
+	}
+	validateCatchupConfig(&issues, cfg.Session.Catchup)
+	if lang := cfg.Session.Language.Default; lang != "" && messages.NormalizeLocale(lang) == "" {
+		issues = append(issues, fmt.Sprintf("session.language.default %q is not a language tag", lang))
+	}
 	validateSteeringConfig(&issues, cfg.Steering)
+	validateExperimentsConfig(&issues, cfg.Experiments)
+	validateGitOpsConfig(&issues, cfg.GitOps)
+	if cfg.Transcription.Enabled {
+		switch cfg.Transcription.Provider {
+		case transcribe.ProviderOpenAI, transcribe.ProviderWhisperCPP:
+		default:
+			issues = append(issues, "transcription.provider must be \"openai\" or \"whispercpp\"")
+		}
+	}
+	if cfg.OCR.Enabled {
+		switch cfg.OCR.Provider {
+		case ocr.ProviderTesseract:
+		case ocr.ProviderGoogle:
+			if strings.TrimSpace(cfg.OCR.APIKey) == "" {
+				issues = append(issues, "ocr.api_key is required for provider \"google\"")
+			}
+		default:
+			issues = append(issues, "ocr.provider must be \"tesseract\" or \"google\"")
+		}
+		if cfg.OCR.Timeout < 0 {
+			issues = append(issues, "ocr.timeout must be >= 0")
+		}
+	}
 	if !validDMScope(cfg.Session.Scoping.DMScope) {
 		issues = append(issues, "session.scoping.dm_scope must be \"main\", \"per-peer\", or \"per-channel-peer\"")
 	}
@@ -967,6 +1538,9 @@ func validateConfig(cfg *Config) error {
 	if cfg.Workspace.MaxChars < 0 {
 		issues = append(issues, "workspace.max_chars must be >= 0")
 	}
+	if cfg.Workspace.History.MaxVersions < 0 {
+		issues = append(issues, "workspace.history.max_versions must be >= 0")
+	}
 	if cfg.CanvasHost.Enabled != nil && *cfg.CanvasHost.Enabled {
 		if cfg.CanvasHost.Port <= 0 || cfg.CanvasHost.Port > 65535 {
 			issues = append(issues, "canvas_host.port must be between 1 and 65535")
@@ -1030,6 +1604,9 @@ func validateConfig(cfg *Config) error {
 	if cfg.Canvas.Audit.FlushInterval < 0 {
 		issues = append(issues, "canvas.audit.flush_interval must be >= 0")
 	}
+	if cfg.Auth.Audit.SampleRate < 0 || cfg.Auth.Audit.SampleRate > 1 {
+		issues = append(issues, "auth.audit.sample_rate must be between 0 and 1")
+	}
 
 	if strings.TrimSpace(cfg.Artifacts.MetadataBackend) != "" {
 		switch strings.ToLower(strings.TrimSpace(cfg.Artifacts.MetadataBackend)) {
@@ -1047,6 +1624,44 @@ func validateConfig(cfg *Config) error {
 		if strings.TrimSpace(cfg.Artifacts.S3Bucket) == "" {
 			issues = append(issues, "artifacts.s3_bucket is required for s3/minio backends")
 		}
+		sse := strings.TrimSpace(cfg.Artifacts.S3SSE)
+		if sse != "" && !strings.EqualFold(sse, "AES256") && !strings.EqualFold(sse, "aws:kms") {
+			issues = append(issues, "artifacts.s3_sse must be \"AES256\" or \"aws:kms\"")
+		}
+		if strings.TrimSpace(cfg.Artifacts.S3KMSKeyID) != "" && !strings.EqualFold(sse, "aws:kms") {
+			issues = append(issues, "artifacts.s3_kms_key_id requires artifacts.s3_sse \"aws:kms\"")
+		}
+		if cfg.Artifacts.S3MultipartThreshold < 0 {
+			issues = append(issues, "artifacts.s3_multipart_threshold must be >= 0")
+		}
+		if cfg.Artifacts.S3PartSize != 0 && (cfg.Artifacts.S3PartSize < 5<<20 || cfg.Artifacts.S3PartSize > 5<<30) {
+			issues = append(issues, "artifacts.s3_part_size must be between 5MB and 5GB")
+		}
+		if cfg.Artifacts.S3PresignTTL < 0 || cfg.Artifacts.S3PresignTTL > 7*24*time.Hour {
+			issues = append(issues, "artifacts.s3_presign_ttl must be between 0 and 168h")
+		}
+	}
+	if cfg.Artifacts.Links.Enabled {
+		if strings.TrimSpace(cfg.Artifacts.Links.SigningKey) == "" && strings.TrimSpace(cfg.Auth.JWTSecret) == "" {
+			issues = append(issues, "artifacts.links requires artifacts.links.signing_key or auth.jwt_secret")
+		}
+		if cfg.Artifacts.Links.TTL < 0 || cfg.Artifacts.Links.MaxTTL < 0 {
+			issues = append(issues, "artifacts.links.ttl and max_ttl must be >= 0")
+		} else if cfg.Artifacts.Links.TTL > cfg.Artifacts.Links.MaxTTL {
+			issues = append(issues, "artifacts.links.ttl must not exceed artifacts.links.max_ttl")
+		}
+	}
+	if cfg.Artifacts.Processing.Enabled {
+		switch strings.ToLower(strings.TrimSpace(cfg.Artifacts.Processing.MIMEValidation)) {
+		case "off", "correct", "reject":
+		default:
+			issues = append(issues, "artifacts.processing.mime_validation must be \"off\", \"correct\", or \"reject\"")
+		}
+		for artifactType, rule := range cfg.Artifacts.Processing.Types {
+			if rule.ThumbnailMaxSide < 0 || rule.MaxVideoBytes < 0 || rule.MaxVideoHeight < 0 {
+				issues = append(issues, fmt.Sprintf("artifacts.processing.types.%s limits must be >= 0", artifactType))
+			}
+		}
 	}
 
 	defaultProvider := strings.TrimSpace(cfg.LLM.DefaultProvider)
@@ -1103,6 +1718,14 @@ func validateConfig(cfg *Config) error {
 		} else {
 			seenKeys[key] = struct{}{}
 		}
+		if entry.Role != "" && !validAuthRole(entry.Role) {
+			issues = append(issues, fmt.Sprintf("auth.api_keys[%d].role must be \"read-only\", \"operator\", or \"admin\"", i))
+		}
+		for _, scope := range entry.Scopes {
+			if !validAPIKeyScope(scope) {
+				issues = append(issues, fmt.Sprintf("auth.api_keys[%d].scopes has unknown scope %q", i, scope))
+			}
+		}
 	}
 
 	// JWT secret validation: require minimum 32 bytes when set
@@ -1144,6 +1767,19 @@ func validateConfig(cfg *Config) error {
 	if cfg.Tools.WebFetch.MaxChars < 0 {
 		issues = append(issues, "tools.web_fetch.max_chars must be >= 0")
 	}
+	if cfg.Tools.WebWatch.CheckInterval < 0 || cfg.Tools.WebWatch.DefaultInterval < 0 || cfg.Tools.WebWatch.MinInterval < 0 {
+		issues = append(issues, "tools.web_watch intervals must be >= 0")
+	}
+	if cfg.Tools.WebWatch.MaxPerSession < 0 {
+		issues = append(issues, "tools.web_watch.max_per_session must be >= 0")
+	}
+	for i, model := range cfg.Tools.Links.Models {
+		switch strings.ToLower(strings.TrimSpace(model.Type)) {
+		case "", "cli", "llm":
+		default:
+			issues = append(issues, fmt.Sprintf("tools.links.models[%d].type must be \"cli\" or \"llm\"", i))
+		}
+	}
 	if cfg.Tools.MemorySearch.MaxResults < 0 {
 		issues = append(issues, "tools.memory_search.max_results must be >= 0")
 	}
@@ -1184,6 +1820,33 @@ func validateConfig(cfg *Config) error {
 	if cfg.Tools.Execution.ResultGuard.MaxChars < 0 {
 		issues = append(issues, "tools.execution.result_guard.max_chars must be >= 0")
 	}
+	if cfg.Tools.Execution.Retry.MaxBackoff < 0 {
+		issues = append(issues, "tools.execution.retry.max_backoff must be >= 0")
+	}
+	if cfg.Tools.Execution.Retry.Jitter < 0 || cfg.Tools.Execution.Retry.Jitter > 1 {
+		issues = append(issues, "tools.execution.retry.jitter must be between 0 and 1")
+	}
+	for _, class := range cfg.Tools.Execution.Retry.RetryOn {
+		switch strings.ToLower(strings.TrimSpace(class)) {
+		case "timeout", "network", "rate_limit", "execution", "permission", "invalid_input", "not_found", "panic", "unknown":
+		default:
+			issues = append(issues, fmt.Sprintf("tools.execution.retry.retry_on has unknown error class %q", class))
+		}
+	}
+	if cfg.Tools.Execution.SchemaValidation.RepairAttempts < 0 {
+		issues = append(issues, "tools.execution.schema_validation.repair_attempts must be >= 0")
+	}
+	for i, pattern := range cfg.Tools.Execution.SchemaValidation.Strict {
+		if strings.TrimSpace(pattern) == "" {
+			issues = append(issues, fmt.Sprintf("tools.execution.schema_validation.strict[%d] must not be empty", i))
+		}
+	}
+	if cfg.Tools.Execution.LoopDetection.MaxRepeats < 0 {
+		issues = append(issues, "tools.execution.loop_detection.max_repeats must be >= 0")
+	}
+	if cfg.Tools.Execution.LoopDetection.MaxStalledIterations < 0 {
+		issues = append(issues, "tools.execution.loop_detection.max_stalled_iterations must be >= 0")
+	}
 	if profile := strings.ToLower(strings.TrimSpace(cfg.Tools.Execution.Approval.Profile)); profile != "" {
 		switch profile {
 		case "coding", "messaging", "readonly", "full", "minimal":
@@ -1194,7 +1857,15 @@ func validateConfig(cfg *Config) error {
 
 	if cfg.Gateway.WebhookHooks.Enabled {
 		if strings.TrimSpace(cfg.Gateway.WebhookHooks.Token) == "" {
-			issues = append(issues, "gateway.webhook_hooks.token is required when webhook hooks are enabled")
+			for _, mapping := range cfg.Gateway.WebhookHooks.Mappings {
+				if strings.TrimSpace(mapping.Token) == "" && strings.TrimSpace(mapping.Secret) == "" {
+					issues = append(issues, "gateway.webhook_hooks.token is required unless every mapping sets a token or secret")
+					break
+				}
+			}
+			if len(cfg.Gateway.WebhookHooks.Mappings) == 0 {
+				issues = append(issues, "gateway.webhook_hooks.token is required when webhook hooks are enabled")
+			}
 		}
 		if cfg.Gateway.WebhookHooks.MaxBodyBytes < 0 {
 			issues = append(issues, "gateway.webhook_hooks.max_body_bytes must be >= 0")
@@ -1209,6 +1880,12 @@ func validateConfig(cfg *Config) error {
 			default:
 				issues = append(issues, fmt.Sprintf("gateway.webhook_hooks.mappings[%d].handler must be agent, wake, or custom", i))
 			}
+			if mapping.RateLimit < 0 {
+				issues = append(issues, fmt.Sprintf("gateway.webhook_hooks.mappings[%d].rate_limit must be >= 0", i))
+			}
+			if channel := strings.TrimSpace(mapping.Channel); channel != "" && strings.TrimSpace(mapping.ChannelID) == "" && !strings.EqualFold(channel, "api") {
+				issues = append(issues, fmt.Sprintf("gateway.webhook_hooks.mappings[%d].channel_id is required with channel %q", i, channel))
+			}
 		}
 	}
 
@@ -1300,6 +1977,8 @@ func validateConfig(cfg *Config) error {
 	if cfg.LLM.Routing.UnhealthyCooldown < 0 {
 		issues = append(issues, "llm.routing.unhealthy_cooldown must be >= 0")
 	}
+	validateLLMRouting(&issues, cfg.LLM.Routing)
+	validateLLMRace(&issues, cfg.LLM.Race)
 	if cfg.Plugins.Isolation.Enabled {
 		backend := strings.ToLower(strings.TrimSpace(cfg.Plugins.Isolation.Backend))
 		if backend == "" {
@@ -1316,6 +1995,15 @@ func validateConfig(cfg *Config) error {
 		}
 	}
 
+	switch strings.ToLower(strings.TrimSpace(cfg.Cluster.EventBus.Backend)) {
+	case "", "auto", "memory", "postgres":
+	default:
+		issues = append(issues, fmt.Sprintf("cluster.event_bus.backend %q is not supported; choose auto, memory, or postgres", cfg.Cluster.EventBus.Backend))
+	}
+	if cfg.Cluster.Enabled && strings.HasPrefix(strings.ToLower(strings.TrimSpace(cfg.Database.URL)), "sqlite:") {
+		issues = append(issues, "cluster.enabled requires a CockroachDB/Postgres database.url; sqlite supports a single gateway")
+	}
+
 	if pluginIssues := pluginValidationIssues(cfg); len(pluginIssues) > 0 {
 		issues = append(issues, pluginIssues...)
 	}
@@ -1328,28 +2016,238 @@ func validateConfig(cfg *Config) error {
 			issues = append(issues, "observability.tracing.endpoint is required when tracing is enabled")
 		}
 	}
-	if cfg.Security.Posture.Enabled && cfg.Security.Posture.AutoRemediation.Enabled {
-		mode := strings.ToLower(strings.TrimSpace(cfg.Security.Posture.AutoRemediation.Mode))
-		if mode != "" && mode != "lockdown" && mode != "warn_only" {
-			issues = append(issues, "security.posture.auto_remediation.mode must be \"lockdown\" or \"warn_only\"")
+	if cfg.Observability.Feedback.Enabled {
+		for _, reaction := range cfg.Observability.Feedback.PositiveReactions {
+			if strings.TrimSpace(reaction) == "" {
+				issues = append(issues, "observability.feedback.positive_reactions must not contain empty entries")
+				break
+			}
+		}
+		for _, reaction := range cfg.Observability.Feedback.NegativeReactions {
+			if strings.TrimSpace(reaction) == "" {
+				issues = append(issues, "observability.feedback.negative_reactions must not contain empty entries")
+				break
+			}
 		}
 	}
-
-	if len(issues) > 0 {
-		return &ConfigValidationError{Issues: issues}
+	if cfg.Observability.CrashReporting.RestartBackoff < 0 || cfg.Observability.CrashReporting.MaxRestartBackoff < 0 {
+		issues = append(issues, "observability.crash_reporting restart backoffs must be >= 0")
 	}
-
-	return nil
-}
-
-func validateChannelPolicy(issues *[]string, field string, cfg ChannelPolicyConfig) {
-	policy := strings.ToLower(strings.TrimSpace(cfg.Policy))
-	if policy == "" {
+	if cfg.Observability.CrashReporting.MaxRestarts < 0 {
+		issues = append(issues, "observability.crash_reporting.max_restarts must be >= 0")
+	}
+	if dsn := strings.TrimSpace(cfg.Observability.CrashReporting.Sentry.DSN); dsn != "" {
+		if u, err := url.Parse(dsn); err != nil || u.Host == "" || u.User == nil {
+			issues = append(issues, "observability.crash_reporting.sentry.dsn must be a Sentry DSN (https://<key>@<host>/<project>)")
+		}
+	}
+	if cfg.Security.Credentials.QuotaWarnRatio < 0 || cfg.Security.Credentials.QuotaWarnRatio > 1 {
+		issues = append(issues, "security.credentials.quota_warn_ratio must be between 0 and 1")
+	}
+	if cfg.Attention.Retention < 0 {
+		issues = append(issues, "attention.retention must be >= 0")
+	}
+	if digest := cfg.Attention.Digest; digest.Enabled {
+		if !cfg.Attention.Enabled {
+			issues = append(issues, "attention.digest requires attention.enabled")
+		}
+		if strings.TrimSpace(digest.Channel) == "" || strings.TrimSpace(digest.PeerID) == "" {
+			issues = append(issues, "attention.digest requires both channel and peer_id")
+		}
+		if digest.MaxItems < 0 {
+			issues = append(issues, "attention.digest.max_items must be >= 0")
+		}
+		if tz := strings.TrimSpace(digest.Timezone); tz != "" {
+			if _, err := time.LoadLocation(tz); err != nil {
+				issues = append(issues, fmt.Sprintf("attention.digest.timezone is invalid: %v", err))
+			}
+		}
+	}
+	validateDeliveryConfig(&issues, cfg.Delivery)
+	validateOIDCConfig(&issues, cfg.Auth)
+	validateLLMKeys(&issues, cfg.LLM)
+	validateLLMBatch(&issues, cfg.LLM)
+	validateEmailConfig(&issues, cfg.Channels.Email)
+	validateSignalConfig(&issues, cfg.Channels.Signal)
+	validateTelegramTopics(&issues, cfg.Channels.Telegram.Topics)
+	validateMessagesConfig(&issues, cfg.Messages)
+	validateSQLQueryConfig(&issues, cfg.Tools.SQLQuery)
+	validateSpreadsheetsConfig(&issues, cfg.Tools.Spreadsheets)
+	validateToolDraftsConfig(&issues, cfg.Tools.Drafts)
+	validateSLOConfig(&issues, cfg.Observability.SLO)
+	validateAnomalyConfig(&issues, cfg.Observability.Anomaly)
+	validateDiagnosticsServerConfig(&issues, cfg.Server.Diagnostics, cfg.Auth)
+	validateSandboxSnapshotConfig(&issues, cfg.Tools.Sandbox.Snapshots)
+	validateSandboxLanguagesConfig(&issues, cfg.Tools.Sandbox.Languages)
+	validateSandboxWasmConfig(&issues, cfg.Tools.Sandbox.Wasm)
+	validateCanaryConfig(&issues, cfg.Observability.Canary)
+	validateCostReportConfig(&issues, cfg.Observability.CostReport)
+	validateMetricsConfig(&issues, cfg.Observability.Metrics)
+	validateEventStoreConfig(&issues, cfg.Observability.EventStore, cfg.Database.URL)
+	validateEventBridgeConfig(&issues, cfg.Observability.EventBridge)
+	if alert := cfg.Security.Credentials.Alert; (alert.Channel == "") != (alert.PeerID == "") {
+		issues = append(issues, "security.credentials.alert requires both channel and peer_id")
+	}
+	if cfg.Security.Posture.Enabled && cfg.Security.Posture.AutoRemediation.Enabled {
+		mode := strings.ToLower(strings.TrimSpace(cfg.Security.Posture.AutoRemediation.Mode))
+		if mode != "" && mode != "lockdown" && mode != "warn_only" {
+			issues = append(issues, "security.posture.auto_remediation.mode must be \"lockdown\" or \"warn_only\"")
+		}
+	}
+
+	validateCommandRolesConfig(&issues, cfg.Commands.Roles)
+	validateOutboundBroadcastConfig(&issues, cfg.Gateway.Broadcast.Outbound)
+	validateRetentionConfig(&issues, cfg.Privacy.Retention)
+	validateEncryptionConfig(&issues, cfg.Encryption)
+
+	if len(issues) > 0 {
+		return &ConfigValidationError{Issues: issues}
+	}
+
+	return nil
+}
+
+func validateCommandRolesConfig(issues *[]string, cfg CommandRolesConfig) {
+	check := func(path, role string) {
+		switch strings.ToLower(strings.TrimSpace(role)) {
+		case "", "guest", "member", "admin":
+		default:
+			*issues = append(*issues, fmt.Sprintf("%s must be guest, member, or admin", path))
+		}
+	}
+	check("commands.roles.default", cfg.Default)
+	for channel, role := range cfg.Channels {
+		check("commands.roles.channels."+channel, role)
+	}
+	for subject, role := range cfg.Assignments {
+		check("commands.roles.assignments."+subject, role)
+	}
+	for command, role := range cfg.Commands {
+		check("commands.roles.commands."+command, role)
+	}
+}
+
+func validateOutboundBroadcastConfig(issues *[]string, cfg OutboundBroadcastConfig) {
+	if cfg.MaxAttempts < 0 {
+		*issues = append(*issues, "gateway.broadcast.outbound.max_attempts must be >= 0")
+	}
+	if cfg.RetryDelay < 0 {
+		*issues = append(*issues, "gateway.broadcast.outbound.retry_delay must be >= 0")
+	}
+	for name, targets := range cfg.Lists {
+		path := "gateway.broadcast.outbound.lists." + name
+		if strings.TrimSpace(name) == "" || strings.ContainsAny(name, " \t") {
+			*issues = append(*issues, fmt.Sprintf("%s: list names must be non-empty and contain no spaces", path))
+		}
+		if len(targets) == 0 {
+			*issues = append(*issues, path+" must have at least one target")
+		}
+		for i, target := range targets {
+			if strings.TrimSpace(target.Channel) == "" || strings.TrimSpace(target.PeerID) == "" {
+				*issues = append(*issues, fmt.Sprintf("%s[%d] requires channel and peer_id", path, i))
+			}
+			switch strings.ToLower(strings.TrimSpace(target.Format)) {
+			case "", "auto", "markdown", "plain":
+			default:
+				*issues = append(*issues, fmt.Sprintf("%s[%d].format must be auto, markdown, or plain", path, i))
+			}
+		}
+	}
+}
+
+func validateRetentionConfig(issues *[]string, cfg RetentionConfig) {
+	if cfg.Interval < 0 {
+		*issues = append(*issues, "privacy.retention.interval must be >= 0")
+	}
+	validateRetentionPolicy(issues, "privacy.retention.default", cfg.Default)
+	for channel, policy := range cfg.Channels {
+		validateRetentionPolicy(issues, "privacy.retention.channels."+channel, policy)
+	}
+	for user, policy := range cfg.Users {
+		validateRetentionPolicy(issues, "privacy.retention.users."+user, policy)
+	}
+}
+
+func validateRetentionPolicy(issues *[]string, field string, policy RetentionPolicy) {
+	durations := []struct {
+		name  string
+		value time.Duration
+	}{
+		{"messages", policy.Messages},
+		{"sessions", policy.Sessions},
+		{"artifacts", policy.Artifacts},
+		{"memory", policy.Memory},
+	}
+	for _, d := range durations {
+		if d.value < 0 {
+			*issues = append(*issues, fmt.Sprintf("%s.%s must be >= 0", field, d.name))
+		}
+	}
+}
+
+func validateEncryptionConfig(issues *[]string, cfg EncryptionConfig) {
+	if !cfg.Enabled {
+		return
+	}
+	if len(cfg.Keys) == 0 {
+		*issues = append(*issues, "encryption.keys must define at least one key when encryption is enabled")
 		return
 	}
-	if !validChannelPolicy(policy) {
+	seen := make(map[string]bool, len(cfg.Keys))
+	for i, key := range cfg.Keys {
+		field := fmt.Sprintf("encryption.keys[%d]", i)
+		id := strings.TrimSpace(key.ID)
+		switch {
+		case id == "":
+			*issues = append(*issues, field+".id is required")
+		case strings.Contains(id, ":"):
+			*issues = append(*issues, field+".id must not contain \":\"")
+		case seen[id]:
+			*issues = append(*issues, fmt.Sprintf("%s.id %q is duplicated", field, id))
+		}
+		seen[id] = true
+
+		hasKey := strings.TrimSpace(key.Key) != ""
+		hasAge := strings.TrimSpace(key.AgeIdentityFile) != ""
+		if hasKey == hasAge {
+			*issues = append(*issues, field+" must set exactly one of key or age_identity_file")
+			continue
+		}
+		if hasKey {
+			if _, err := encryption.ParseKey(key.Key); err != nil {
+				*issues = append(*issues, fmt.Sprintf("%s.key is invalid: %v", field, err))
+			}
+		}
+	}
+	if !seen[strings.TrimSpace(cfg.PrimaryKey)] {
+		*issues = append(*issues, fmt.Sprintf("encryption.primary_key %q does not match a configured key", cfg.PrimaryKey))
+	}
+}
+
+func validateChannelPolicy(issues *[]string, field string, cfg ChannelPolicyConfig) {
+	policy := strings.ToLower(strings.TrimSpace(cfg.Policy))
+	if policy != "" && !validChannelPolicy(policy) {
 		*issues = append(*issues, fmt.Sprintf("%s.policy must be \"open\", \"allowlist\", \"pairing\", or \"disabled\"", field))
 	}
+	switch strings.ToLower(strings.TrimSpace(cfg.Activation)) {
+	case "", "always", "mention", "interject":
+	default:
+		*issues = append(*issues, fmt.Sprintf("%s.activation must be \"always\", \"mention\", or \"interject\"", field))
+	}
+	for _, pattern := range cfg.MentionPatterns {
+		if _, err := regexp.Compile("(?i)" + pattern); err != nil {
+			*issues = append(*issues, fmt.Sprintf("%s.mention_patterns: invalid pattern %q: %v", field, pattern, err))
+		}
+	}
+	if cfg.Cooldown < 0 {
+		*issues = append(*issues, fmt.Sprintf("%s.cooldown must be >= 0", field))
+	}
+	if cfg.Interject.Probability < 0 || cfg.Interject.Probability > 1 {
+		*issues = append(*issues, fmt.Sprintf("%s.interject.probability must be between 0 and 1", field))
+	}
+	if cfg.Interject.MinRelevance < 0 || cfg.Interject.MinRelevance > 1 {
+		*issues = append(*issues, fmt.Sprintf("%s.interject.min_relevance must be between 0 and 1", field))
+	}
 }
 
 func validChannelPolicy(policy string) bool {
@@ -1392,6 +2290,433 @@ func validScope(scope string) bool {
 	}
 }
 
+func validateOIDCConfig(issues *[]string, cfg AuthConfig) {
+	oidc := cfg.OIDC
+	if !oidc.Enabled {
+		return
+	}
+	if strings.TrimSpace(oidc.Issuer) == "" {
+		*issues = append(*issues, "auth.oidc.issuer is required when auth.oidc.enabled is true")
+	}
+	if strings.TrimSpace(oidc.ClientID) == "" {
+		*issues = append(*issues, "auth.oidc.client_id is required when auth.oidc.enabled is true")
+	}
+	if strings.TrimSpace(oidc.RedirectURL) == "" {
+		*issues = append(*issues, "auth.oidc.redirect_url is required when auth.oidc.enabled is true")
+	}
+	if strings.TrimSpace(cfg.JWTSecret) == "" {
+		*issues = append(*issues, "auth.jwt_secret is required to sign auth.oidc sessions")
+	}
+	if oidc.DefaultRole != "" && !validAuthRole(oidc.DefaultRole) {
+		*issues = append(*issues, "auth.oidc.default_role must be \"read-only\", \"operator\", or \"admin\"")
+	}
+	groups := make([]string, 0, len(oidc.GroupRoles))
+	for group := range oidc.GroupRoles {
+		groups = append(groups, group)
+	}
+	sort.Strings(groups)
+	for _, group := range groups {
+		if !validAuthRole(oidc.GroupRoles[group]) {
+			*issues = append(*issues, fmt.Sprintf("auth.oidc.group_roles[%q] must be \"read-only\", \"operator\", or \"admin\"", group))
+		}
+	}
+	if oidc.SessionTTL < 0 {
+		*issues = append(*issues, "auth.oidc.session_ttl must be >= 0")
+	}
+}
+
+func validateEmailConfig(issues *[]string, cfg EmailConfig) {
+	if !cfg.Enabled {
+		return
+	}
+	switch strings.ToLower(strings.TrimSpace(cfg.Backend)) {
+	case "", "graph":
+		if cfg.Compose.Enabled {
+			*issues = append(*issues, "channels.email.compose requires backend \"imap\"")
+		}
+		return
+	case "imap":
+	default:
+		*issues = append(*issues, "channels.email.backend must be \"graph\" or \"imap\"")
+		return
+	}
+	if strings.TrimSpace(cfg.Address) == "" {
+		*issues = append(*issues, "channels.email.address is required for the imap backend")
+	}
+	if strings.TrimSpace(cfg.IMAP.Host) == "" {
+		*issues = append(*issues, "channels.email.imap.host is required for the imap backend")
+	}
+	if strings.TrimSpace(cfg.SMTP.Host) == "" {
+		*issues = append(*issues, "channels.email.smtp.host is required for the imap backend")
+	}
+	for _, conn := range []struct{ field, security string }{{"imap", cfg.IMAP.Security}, {"smtp", cfg.SMTP.Security}} {
+		switch strings.ToLower(strings.TrimSpace(conn.security)) {
+		case "", "tls", "starttls", "none":
+		default:
+			*issues = append(*issues, fmt.Sprintf("channels.email.%s.security must be \"tls\", \"starttls\", or \"none\"", conn.field))
+		}
+	}
+	if cfg.IMAP.Port < 0 || cfg.IMAP.Port > 65535 || cfg.SMTP.Port < 0 || cfg.SMTP.Port > 65535 {
+		*issues = append(*issues, "channels.email imap.port and smtp.port must be between 0 and 65535")
+	}
+	oauth := cfg.OAuth2
+	if cfg.IMAP.Password == "" && oauth.AccessToken == "" && oauth.RefreshToken == "" {
+		*issues = append(*issues, "channels.email.imap.password or channels.email.oauth2 tokens are required for the imap backend")
+	}
+	if cfg.IMAP.Password == "" && oauth.RefreshToken != "" {
+		if strings.TrimSpace(oauth.TokenURL) == "" && strings.TrimSpace(cfg.TenantID) == "" {
+			*issues = append(*issues, "channels.email.oauth2.token_url (or tenant_id) is required with a refresh_token")
+		}
+		if strings.TrimSpace(cfg.ClientID) == "" {
+			*issues = append(*issues, "channels.email.client_id is required with oauth2.refresh_token")
+		}
+	}
+	if cfg.IMAP.IdleTimeout < 0 {
+		*issues = append(*issues, "channels.email.imap.idle_timeout must be >= 0")
+	}
+}
+
+func validateSignalConfig(issues *[]string, cfg SignalConfig) {
+	if !cfg.Enabled {
+		return
+	}
+	if strings.TrimSpace(cfg.Account) == "" {
+		*issues = append(*issues, "channels.signal.account is required")
+	}
+	switch strings.ToLower(strings.TrimSpace(cfg.Mode)) {
+	case "", "process":
+	case "daemon":
+		address := strings.TrimSpace(cfg.DaemonAddress)
+		if address == "" {
+			*issues = append(*issues, "channels.signal.daemon_address is required in daemon mode")
+		} else if !strings.HasPrefix(address, "unix://") {
+			if _, _, err := net.SplitHostPort(strings.TrimPrefix(address, "tcp://")); err != nil {
+				*issues = append(*issues, "channels.signal.daemon_address must be host:port or unix:///path")
+			}
+		}
+	default:
+		*issues = append(*issues, "channels.signal.mode must be \"process\" or \"daemon\"")
+	}
+	if cfg.ReconnectDelay < 0 {
+		*issues = append(*issues, "channels.signal.reconnect_delay must be >= 0")
+	}
+}
+
+func validateCatchupConfig(issues *[]string, cfg CatchupConfig) {
+	if cfg.MaxInputTokens < 0 {
+		*issues = append(*issues, "session.catchup.max_input_tokens must be >= 0")
+	}
+	if cfg.MaxOutputTokens < 0 {
+		*issues = append(*issues, "session.catchup.max_output_tokens must be >= 0")
+	}
+	if cfg.Lookback < 0 {
+		*issues = append(*issues, "session.catchup.lookback must be >= 0")
+	}
+	if !cfg.Digest.Enabled {
+		return
+	}
+	if !cfg.Enabled {
+		*issues = append(*issues, "session.catchup.digest requires session.catchup.enabled")
+	}
+	if tz := strings.TrimSpace(cfg.Digest.Timezone); tz != "" {
+		if _, err := time.LoadLocation(tz); err != nil {
+			*issues = append(*issues, fmt.Sprintf("session.catchup.digest.timezone is invalid: %v", err))
+		}
+	}
+	if cfg.Digest.MinMessages < 0 {
+		*issues = append(*issues, "session.catchup.digest.min_messages must be >= 0")
+	}
+}
+
+func validateMemoryConsolidationConfig(issues *[]string, cfg MemoryConsolidationConfig) {
+	if cfg.MinAgeDays < 0 {
+		*issues = append(*issues, "session.memory.consolidation.min_age_days must be >= 0")
+	}
+	if cfg.MaxInputChars < 0 {
+		*issues = append(*issues, "session.memory.consolidation.max_input_chars must be >= 0")
+	}
+	if cfg.MaxOutputTokens < 0 {
+		*issues = append(*issues, "session.memory.consolidation.max_output_tokens must be >= 0")
+	}
+	if tz := strings.TrimSpace(cfg.Timezone); tz != "" {
+		if _, err := time.LoadLocation(tz); err != nil {
+			*issues = append(*issues, fmt.Sprintf("session.memory.consolidation.timezone is invalid: %v", err))
+		}
+	}
+}
+
+func validateTelegramTopics(issues *[]string, topics []TelegramTopicConfig) {
+	seen := make(map[[2]int64]bool, len(topics))
+	for i, topic := range topics {
+		prefix := fmt.Sprintf("channels.telegram.topics[%d]", i)
+		if topic.ChatID == 0 {
+			*issues = append(*issues, prefix+".chat_id is required")
+		}
+		if topic.TopicID < 0 {
+			*issues = append(*issues, prefix+".topic_id must be >= 0")
+		}
+		if !topic.Disabled && strings.TrimSpace(topic.AgentID) == "" {
+			*issues = append(*issues, prefix+" must set agent_id or disabled")
+		}
+		key := [2]int64{topic.ChatID, int64(topic.TopicID)}
+		if seen[key] {
+			*issues = append(*issues, prefix+" duplicates an earlier rule for the same chat and topic")
+		}
+		seen[key] = true
+	}
+}
+
+func validateMessagesConfig(issues *[]string, cfg MessagesConfig) {
+	if cfg.Locale != "" && messages.NormalizeLocale(cfg.Locale) == "" {
+		*issues = append(*issues, fmt.Sprintf("messages.locale %q is not a language tag", cfg.Locale))
+	}
+	channels := make([]string, 0, len(cfg.Channels))
+	for channel := range cfg.Channels {
+		channels = append(channels, channel)
+	}
+	sort.Strings(channels)
+	for _, channel := range channels {
+		if messages.NormalizeLocale(cfg.Channels[channel]) == "" {
+			*issues = append(*issues, fmt.Sprintf("messages.channels.%s %q is not a language tag", channel, cfg.Channels[channel]))
+		}
+	}
+	if _, err := messages.New(cfg.Locale, cfg.Templates); err != nil {
+		*issues = append(*issues, "messages.templates: "+err.Error())
+	}
+}
+
+func validateSQLQueryConfig(issues *[]string, cfg SQLQueryConfig) {
+	if !cfg.Enabled {
+		return
+	}
+	if len(cfg.Databases) == 0 {
+		*issues = append(*issues, "tools.sql_query requires at least one database")
+	}
+	seen := make(map[string]bool, len(cfg.Databases))
+	for i, db := range cfg.Databases {
+		prefix := fmt.Sprintf("tools.sql_query.databases[%d]", i)
+		name := strings.TrimSpace(db.Name)
+		if name == "" {
+			*issues = append(*issues, prefix+".name is required")
+		} else if seen[name] {
+			*issues = append(*issues, fmt.Sprintf("%s.name %q is duplicated", prefix, name))
+		}
+		seen[name] = true
+		switch strings.ToLower(db.Driver) {
+		case "postgres", "mysql", "sqlite":
+		default:
+			*issues = append(*issues, prefix+".driver must be \"postgres\", \"mysql\" or \"sqlite\"")
+		}
+		if strings.TrimSpace(db.DSN) == "" {
+			*issues = append(*issues, prefix+".dsn is required")
+		}
+	}
+	for _, statement := range cfg.Statements {
+		switch strings.ToLower(statement) {
+		case "select", "with", "values", "explain", "show", "describe":
+		default:
+			*issues = append(*issues, fmt.Sprintf("tools.sql_query.statements: %q is not a read-only statement", statement))
+		}
+	}
+	if cfg.MaxRows < 0 || cfg.MaxBytes < 0 || cfg.Timeout < 0 {
+		*issues = append(*issues, "tools.sql_query max_rows, max_bytes and timeout must be >= 0")
+	}
+}
+
+func validateSpreadsheetsConfig(issues *[]string, cfg SpreadsheetsConfig) {
+	if !cfg.Enabled {
+		return
+	}
+	google := cfg.Google
+	hasKey := strings.TrimSpace(google.CredentialsFile) != ""
+	hasToken := strings.TrimSpace(google.RefreshToken) != ""
+	if hasKey && hasToken {
+		*issues = append(*issues, "tools.spreadsheets.google must set only one of credentials_file or refresh_token")
+	}
+	if hasToken && (strings.TrimSpace(google.ClientID) == "" || strings.TrimSpace(google.ClientSecret) == "") {
+		*issues = append(*issues, "tools.spreadsheets.google.refresh_token requires client_id and client_secret")
+	}
+	if len(google.Spreadsheets) > 0 && !hasKey && !hasToken {
+		*issues = append(*issues, "tools.spreadsheets.google.spreadsheets requires credentials_file or refresh_token")
+	}
+	names := make([]string, 0, len(google.Spreadsheets))
+	for name := range google.Spreadsheets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if strings.TrimSpace(google.Spreadsheets[name]) == "" {
+			*issues = append(*issues, fmt.Sprintf("tools.spreadsheets.google.spreadsheets.%s must be a spreadsheet ID", name))
+		}
+	}
+	if cfg.MaxCells < 0 || google.Timeout < 0 {
+		*issues = append(*issues, "tools.spreadsheets max_cells and google.timeout must be >= 0")
+	}
+}
+
+func validateToolDraftsConfig(issues *[]string, cfg ToolDraftsConfig) {
+	if cfg.TTL < 0 {
+		*issues = append(*issues, "tools.drafts.ttl must be >= 0")
+	}
+	for i, pattern := range cfg.Tools {
+		if _, err := path.Match(strings.TrimSpace(pattern), ""); strings.TrimSpace(pattern) == "" || err != nil {
+			*issues = append(*issues, fmt.Sprintf("tools.drafts.tools[%d] must be a tool name or glob", i))
+		}
+	}
+}
+
+func validateLLMKeys(issues *[]string, cfg LLMConfig) {
+	names := make([]string, 0, len(cfg.Providers))
+	for name := range cfg.Providers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		validateLLMRateLimit(issues, "llm.providers."+name+".rate_limit", cfg.Providers[name].RateLimit)
+		seen := map[string]struct{}{}
+		for i, key := range cfg.Providers[name].Keys {
+			prefix := fmt.Sprintf("llm.providers.%s.keys[%d]", name, i)
+			hasKey := strings.TrimSpace(key.Key) != ""
+			hasFile := strings.TrimSpace(key.KeyFile) != ""
+			if hasKey == hasFile {
+				*issues = append(*issues, prefix+" must set exactly one of key or key_file")
+			}
+			if key.Weight < 0 {
+				*issues = append(*issues, prefix+".weight must be >= 0")
+			}
+			id := strings.TrimSpace(key.ID)
+			if id == "default" && strings.TrimSpace(cfg.Providers[name].APIKey) != "" {
+				*issues = append(*issues, prefix+`.id "default" is reserved for api_key`)
+			}
+			if _, ok := seen[id]; ok {
+				*issues = append(*issues, fmt.Sprintf("%s.id %q is duplicated", prefix, id))
+			}
+			seen[id] = struct{}{}
+			validateLLMRateLimit(issues, prefix+".rate_limit", key.RateLimit)
+		}
+	}
+	if cfg.KeyRotation.RateLimitCooldown < 0 {
+		*issues = append(*issues, "llm.key_rotation.rate_limit_cooldown must be >= 0")
+	}
+	if cfg.KeyRotation.AuthCooldown < 0 {
+		*issues = append(*issues, "llm.key_rotation.auth_cooldown must be >= 0")
+	}
+}
+
+func validateLLMBatch(issues *[]string, cfg LLMConfig) {
+	batch := cfg.Batch
+	if !batch.Enabled {
+		return
+	}
+	provider := strings.ToLower(strings.TrimSpace(batch.Provider))
+	if provider == "" {
+		provider = strings.ToLower(strings.TrimSpace(cfg.DefaultProvider))
+	}
+	switch provider {
+	case "anthropic", "openai":
+	default:
+		*issues = append(*issues, fmt.Sprintf("llm.batch.provider %q has no batch API (use anthropic or openai)", provider))
+	}
+	if batch.MaxBatchSize < 0 {
+		*issues = append(*issues, "llm.batch.max_batch_size must be >= 0")
+	}
+	if batch.FlushInterval < 0 || batch.PollInterval < 0 || batch.Timeout < 0 {
+		*issues = append(*issues, "llm.batch intervals and timeout must be >= 0")
+	}
+}
+
+func validateLLMRouting(issues *[]string, cfg LLMRoutingConfig) {
+	switch strings.ToLower(strings.TrimSpace(cfg.Classifier)) {
+	case "", "heuristic", "llm":
+	default:
+		*issues = append(*issues, fmt.Sprintf("llm.routing.classifier %q must be heuristic or llm", cfg.Classifier))
+	}
+	classifier := cfg.ClassifierLLM
+	if classifier.Timeout < 0 || classifier.CacheSize < 0 || classifier.CacheTTL < 0 {
+		*issues = append(*issues, "llm.routing.classifier_llm timeout, cache_size, and cache_ttl must be >= 0")
+	}
+	for i, rule := range cfg.Rules {
+		match := rule.Match
+		prefix := fmt.Sprintf("llm.routing.rules[%d].match", i)
+		if match.MinTokens < 0 || match.MaxTokens < 0 {
+			*issues = append(*issues, prefix+" min_tokens and max_tokens must be >= 0")
+		}
+		if match.MaxTokens > 0 && match.MinTokens > match.MaxTokens {
+			*issues = append(*issues, prefix+".min_tokens must not exceed max_tokens")
+		}
+		if match.MaxCostUSD < 0 {
+			*issues = append(*issues, prefix+".max_cost_usd must be >= 0")
+		}
+	}
+}
+
+func validateLLMRace(issues *[]string, cfg LLMRaceConfig) {
+	if cfg.MaxCostUSD < 0 || cfg.DailyBudgetUSD < 0 {
+		*issues = append(*issues, "llm.race.max_cost_usd and daily_budget_usd must be >= 0")
+	}
+	if !cfg.Enabled {
+		return
+	}
+	if len(cfg.Contenders) != 2 {
+		*issues = append(*issues, fmt.Sprintf("llm.race.contenders must list exactly 2 providers, got %d", len(cfg.Contenders)))
+		return
+	}
+	for i, contender := range cfg.Contenders {
+		if strings.TrimSpace(contender.Provider) == "" {
+			*issues = append(*issues, fmt.Sprintf("llm.race.contenders[%d].provider is required", i))
+		}
+	}
+	first, second := cfg.Contenders[0], cfg.Contenders[1]
+	if strings.EqualFold(strings.TrimSpace(first.Provider), strings.TrimSpace(second.Provider)) && first.Model == second.Model {
+		*issues = append(*issues, "llm.race.contenders must differ in provider or model")
+	}
+}
+
+func validateLLMRateLimit(issues *[]string, prefix string, cfg LLMRateLimitConfig) {
+	if cfg.RequestsPerMinute < 0 {
+		*issues = append(*issues, prefix+".requests_per_minute must be >= 0")
+	}
+	if cfg.TokensPerMinute < 0 {
+		*issues = append(*issues, prefix+".tokens_per_minute must be >= 0")
+	}
+	if cfg.MaxConcurrent < 0 {
+		*issues = append(*issues, prefix+".max_concurrent must be >= 0")
+	}
+	if cfg.MaxWait < 0 {
+		*issues = append(*issues, prefix+".max_wait must be >= 0")
+	}
+}
+
+func validAuthRole(role string) bool {
+	switch strings.ToLower(strings.TrimSpace(role)) {
+	case "read-only", "operator", "admin":
+		return true
+	}
+	return false
+}
+
+func validAPIKeyScope(scope string) bool {
+	scope = strings.ToLower(strings.TrimSpace(scope))
+	if scope == "admin" {
+		return true
+	}
+	resource, action, ok := strings.Cut(scope, ":")
+	if !ok {
+		return false
+	}
+	switch action {
+	case "read", "write", "*":
+	default:
+		return false
+	}
+	switch resource {
+	case "sessions", "messages", "agents", "approvals", "usage", "config", "system":
+		return true
+	default:
+		return false
+	}
+}
+
 func validMemoryScope(scope string) bool {
 	switch strings.ToLower(strings.TrimSpace(scope)) {
 	case "session", "channel", "global":
@@ -1401,6 +2726,15 @@ func validMemoryScope(scope string) bool {
 	}
 }
 
+func validMemoryReviewScope(scope string) bool {
+	switch strings.ToLower(strings.TrimSpace(scope)) {
+	case "session", "channel", "agent", "global", "file":
+		return true
+	default:
+		return false
+	}
+}
+
 func validHeartbeatMode(mode string) bool {
 	switch strings.ToLower(strings.TrimSpace(mode)) {
 	case "always", "on_demand":
@@ -1410,6 +2744,89 @@ func validHeartbeatMode(mode string) bool {
 	}
 }
 
+func validateHeartbeatSchedule(issues *[]string, path string, sched HeartbeatScheduleConfig) {
+	if sched.Every < 0 {
+		*issues = append(*issues, path+".every must be >= 0")
+	}
+	if sched.Every > 0 && strings.TrimSpace(sched.Cron) != "" {
+		*issues = append(*issues, path+" must set only one of every or cron")
+	}
+	if sched.SuppressIfActive < 0 {
+		*issues = append(*issues, path+".suppress_if_active must be >= 0")
+	}
+	if tz := strings.TrimSpace(sched.Timezone); tz != "" {
+		if _, err := time.LoadLocation(tz); err != nil {
+			*issues = append(*issues, fmt.Sprintf("%s.timezone is invalid: %v", path, err))
+		}
+	}
+	start := strings.TrimSpace(sched.QuietHours.Start)
+	end := strings.TrimSpace(sched.QuietHours.End)
+	if start == "" && end == "" {
+		return
+	}
+	if !validClockTime(start) || !validClockTime(end) {
+		*issues = append(*issues, path+".quiet_hours start and end must both be HH:MM")
+	}
+}
+
+func validateDeliveryConfig(issues *[]string, cfg DeliveryConfig) {
+	if strategy := strings.TrimSpace(cfg.Strategy); strategy != "" && !validDeliveryStrategy(strategy) {
+		*issues = append(*issues, "delivery.strategy must be \"preferred\", \"recent\", or \"origin\"")
+	}
+	if mode := strings.TrimSpace(cfg.DuringQuietHours); mode != "" && !validQuietHoursMode(mode) {
+		*issues = append(*issues, "delivery.during_quiet_hours must be \"defer\", \"send\", or \"drop\"")
+	}
+	start := strings.TrimSpace(cfg.QuietHours.Start)
+	end := strings.TrimSpace(cfg.QuietHours.End)
+	if (start != "" || end != "") && (!validClockTime(start) || !validClockTime(end)) {
+		*issues = append(*issues, "delivery.quiet_hours start and end must both be HH:MM")
+	}
+	for kind, override := range cfg.Types {
+		path := "delivery.types." + kind
+		switch kind {
+		case "reminder", "scheduled_message", "digest", "alert", "watch":
+		default:
+			*issues = append(*issues, path+" is not a known message type (reminder, scheduled_message, digest, alert, watch)")
+			continue
+		}
+		if strategy := strings.TrimSpace(override.Strategy); strategy != "" && !validDeliveryStrategy(strategy) {
+			*issues = append(*issues, path+".strategy must be \"preferred\", \"recent\", or \"origin\"")
+		}
+		if mode := strings.TrimSpace(override.DuringQuietHours); mode != "" && !validQuietHoursMode(mode) {
+			*issues = append(*issues, path+".during_quiet_hours must be \"defer\", \"send\", or \"drop\"")
+		}
+		for _, channel := range override.Channels {
+			if strings.TrimSpace(channel) == "" {
+				*issues = append(*issues, path+".channels must not contain empty entries")
+				break
+			}
+		}
+	}
+}
+
+func validDeliveryStrategy(strategy string) bool {
+	switch strings.ToLower(strings.TrimSpace(strategy)) {
+	case "preferred", "recent", "origin":
+		return true
+	default:
+		return false
+	}
+}
+
+func validQuietHoursMode(mode string) bool {
+	switch strings.ToLower(strings.TrimSpace(mode)) {
+	case "defer", "send", "drop":
+		return true
+	default:
+		return false
+	}
+}
+
+func validClockTime(value string) bool {
+	parsed, err := time.Parse("15:04", value)
+	return err == nil && parsed.Format("15:04") == value
+}
+
 func validDMScope(scope string) bool {
 	switch strings.ToLower(strings.TrimSpace(scope)) {
 	case "main", "per-peer", "per-channel-peer":
@@ -1453,6 +2870,20 @@ func validateSteeringConfig(issues *[]string, cfg SteeringConfig) {
 	}
 }
 
+func validateExperimentsConfig(issues *[]string, cfg experiments.Config) {
+	for i, exp := range cfg.Experiments {
+		label := exp.ID
+		if label == "" {
+			label = fmt.Sprintf("index %d", i)
+		}
+		switch strings.ToLower(strings.TrimSpace(exp.AssignBy)) {
+		case "", experiments.AssignByUser, experiments.AssignBySession:
+		default:
+			*issues = append(*issues, fmt.Sprintf("experiments.experiments[%s].assign_by must be \"user\" or \"session\"", label))
+		}
+	}
+}
+
 func validateContextPruning(issues *[]string, cfg ContextPruningConfig) {
 	mode := strings.ToLower(strings.TrimSpace(cfg.Mode))
 	if mode != "" && mode != "off" && mode != "cache-ttl" {
@@ -1526,3 +2957,294 @@ func (c *Config) GetMarkdownTableMode(channel string) string {
 		return "off"
 	}
 }
+
+func validateSLOConfig(issues *[]string, cfg SLOConfig) {
+	if cfg.Interval < 0 || cfg.Window < 0 {
+		*issues = append(*issues, "observability.slo interval and window must be >= 0")
+	}
+	if cfg.Alerts.CriticalBurnRate < 0 || cfg.Alerts.WarningBurnRate < 0 {
+		*issues = append(*issues, "observability.slo.alerts burn rates must be >= 0")
+	}
+	if webhook := strings.TrimSpace(cfg.Alerts.WebhookURL); webhook != "" {
+		if u, err := url.Parse(webhook); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			*issues = append(*issues, "observability.slo.alerts.webhook_url must be an http(s) URL")
+		}
+	}
+	if !cfg.Enabled {
+		return
+	}
+	if len(cfg.Objectives) == 0 {
+		*issues = append(*issues, "observability.slo.objectives must not be empty when slo is enabled")
+	}
+	seen := make(map[string]bool, len(cfg.Objectives))
+	for i, objective := range cfg.Objectives {
+		name := strings.TrimSpace(objective.Name)
+		if name == "" {
+			*issues = append(*issues, fmt.Sprintf("observability.slo.objectives[%d].name is required", i))
+		} else if seen[name] {
+			*issues = append(*issues, fmt.Sprintf("observability.slo.objectives[%d].name %q is duplicated", i, name))
+		}
+		seen[name] = true
+		if objective.Target <= 0 || objective.Target >= 1 {
+			*issues = append(*issues, fmt.Sprintf("observability.slo.objectives[%d].target must be between 0 and 1 (exclusive)", i))
+		}
+		if (objective.Latency == nil) == (objective.Errors == nil) {
+			*issues = append(*issues, fmt.Sprintf("observability.slo.objectives[%d] must set exactly one of latency or errors", i))
+			continue
+		}
+		if objective.Latency != nil && objective.Latency.Threshold <= 0 {
+			*issues = append(*issues, fmt.Sprintf("observability.slo.objectives[%d].latency.threshold must be > 0", i))
+		}
+	}
+}
+
+func validateAnomalyConfig(issues *[]string, cfg AnomalyConfig) {
+	if cfg.Interval < 0 || cfg.Threshold < 0 || cfg.Warmup < 0 {
+		*issues = append(*issues, "observability.anomaly interval, threshold and warmup must be >= 0")
+	}
+	if cfg.Alpha < 0 || cfg.Alpha > 1 {
+		*issues = append(*issues, "observability.anomaly.alpha must be between 0 and 1")
+	}
+	if cfg.MinErrors < 0 || cfg.MinTokens < 0 || cfg.MinPeerMessages < 0 {
+		*issues = append(*issues, "observability.anomaly min_errors, min_tokens and min_peer_messages must be >= 0")
+	}
+	if webhook := strings.TrimSpace(cfg.Alerts.WebhookURL); webhook != "" {
+		if u, err := url.Parse(webhook); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			*issues = append(*issues, "observability.anomaly.alerts.webhook_url must be an http(s) URL")
+		}
+	}
+}
+
+func validateGitOpsConfig(issues *[]string, cfg GitOpsConfig) {
+	if cfg.Mode != GitOpsModeApply && cfg.Mode != GitOpsModeReport {
+		*issues = append(*issues, "gitops.mode must be \"apply\" or \"report\"")
+	}
+	if cfg.Interval < 0 {
+		*issues = append(*issues, "gitops.interval must be >= 0")
+	}
+	if p := path.Clean(filepath.ToSlash(strings.TrimSpace(cfg.Path))); path.IsAbs(p) || p == ".." || strings.HasPrefix(p, "../") {
+		*issues = append(*issues, "gitops.path must be relative to the repository root")
+	}
+	if cfg.Enabled && strings.TrimSpace(cfg.Repo) == "" {
+		*issues = append(*issues, "gitops.repo is required when gitops is enabled")
+	}
+}
+
+func validateCostReportConfig(issues *[]string, cfg CostReportConfig) {
+	if cfg.Period != "weekly" && cfg.Period != "monthly" {
+		*issues = append(*issues, "observability.cost_report.period must be weekly or monthly")
+	}
+	if cfg.PlanUSD < 0 || cfg.WarnAt < 0 || cfg.TopN < 0 {
+		*issues = append(*issues, "observability.cost_report plan_usd, warn_at and top_n must be >= 0")
+	}
+	for provider, plan := range cfg.ProviderPlansUSD {
+		if plan < 0 {
+			*issues = append(*issues, fmt.Sprintf("observability.cost_report.provider_plans_usd.%s must be >= 0", provider))
+		}
+	}
+	if tz := strings.TrimSpace(cfg.Timezone); tz != "" {
+		if _, err := time.LoadLocation(tz); err != nil {
+			*issues = append(*issues, fmt.Sprintf("observability.cost_report.timezone is invalid: %v", err))
+		}
+	}
+	if !cfg.Enabled {
+		return
+	}
+	if strings.TrimSpace(cfg.Channel) == "" || strings.TrimSpace(cfg.PeerID) == "" {
+		*issues = append(*issues, "observability.cost_report requires both channel and peer_id")
+	}
+}
+
+func validateCanaryConfig(issues *[]string, cfg CanaryConfig) {
+	if cfg.Interval < 0 || cfg.Timeout < 0 {
+		*issues = append(*issues, "observability.canary interval and timeout must be >= 0")
+	}
+	if cfg.Alerts.AfterFailures < 0 {
+		*issues = append(*issues, "observability.canary.alerts.after_failures must be >= 0")
+	}
+	if webhook := strings.TrimSpace(cfg.Alerts.WebhookURL); webhook != "" {
+		if u, err := url.Parse(webhook); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			*issues = append(*issues, "observability.canary.alerts.webhook_url must be an http(s) URL")
+		}
+	}
+	if (cfg.Alerts.Channel == "") != (cfg.Alerts.PeerID == "") {
+		*issues = append(*issues, "observability.canary.alerts requires both channel and peer_id")
+	}
+	if !cfg.Enabled {
+		return
+	}
+	seen := make(map[string]bool, len(cfg.Checks))
+	for i, check := range cfg.Checks {
+		name := strings.TrimSpace(check.Name)
+		if name == "" {
+			*issues = append(*issues, fmt.Sprintf("observability.canary.checks[%d].name is required", i))
+		} else if seen[name] {
+			*issues = append(*issues, fmt.Sprintf("observability.canary.checks[%d].name %q is duplicated", i, name))
+		}
+		seen[name] = true
+		if check.Timeout < 0 {
+			*issues = append(*issues, fmt.Sprintf("observability.canary.checks[%d].timeout must be >= 0", i))
+		}
+		if check.Channel != "loopback" && strings.TrimSpace(check.SessionKey) == "" {
+			*issues = append(*issues, fmt.Sprintf("observability.canary.checks[%d].session_key is required for channel %q", i, check.Channel))
+		}
+	}
+}
+
+func validateMetricsConfig(issues *[]string, cfg MetricsConfig) {
+	switch cfg.Exporter {
+	case MetricsExporterPrometheus, MetricsExporterOTLP, MetricsExporterBoth:
+	default:
+		*issues = append(*issues, fmt.Sprintf("observability.metrics.exporter %q is not supported; choose prometheus, otlp, or both", cfg.Exporter))
+		return
+	}
+	if !cfg.OTLPEnabled() {
+		return
+	}
+	if strings.TrimSpace(cfg.OTLP.Endpoint) == "" {
+		*issues = append(*issues, fmt.Sprintf("observability.metrics.otlp.endpoint is required when exporter is %s", cfg.Exporter))
+	}
+	if cfg.OTLP.Protocol != "grpc" && cfg.OTLP.Protocol != "http" {
+		*issues = append(*issues, fmt.Sprintf("observability.metrics.otlp.protocol %q is not supported; choose grpc or http", cfg.OTLP.Protocol))
+	}
+	if cfg.OTLP.Interval < time.Second {
+		*issues = append(*issues, "observability.metrics.otlp.interval must be >= 1s")
+	}
+	if cfg.OTLP.Timeout < 0 {
+		*issues = append(*issues, "observability.metrics.otlp.timeout must be >= 0")
+	}
+}
+
+func validateEventStoreConfig(issues *[]string, cfg EventStoreConfig, databaseURL string) {
+	if !cfg.Enabled {
+		return
+	}
+	if strings.TrimSpace(databaseURL) == "" {
+		*issues = append(*issues, "observability.event_store requires database.url to be set")
+	}
+	if cfg.Retention < 0 {
+		*issues = append(*issues, "observability.event_store.retention must be >= 0")
+	}
+	if cfg.SampleRate < 0 || cfg.SampleRate > 1 {
+		*issues = append(*issues, "observability.event_store.sample_rate must be between 0 and 1")
+	}
+	if cfg.BufferSize < 0 {
+		*issues = append(*issues, "observability.event_store.buffer_size must be >= 0")
+	}
+	if cfg.FlushInterval < 0 {
+		*issues = append(*issues, "observability.event_store.flush_interval must be >= 0")
+	}
+}
+
+func validateEventBridgeConfig(issues *[]string, cfg EventBridgeConfig) {
+	if !cfg.Enabled {
+		return
+	}
+	switch cfg.Backend {
+	case EventBridgeBackendNATS:
+		if strings.TrimSpace(cfg.NATS.URL) == "" {
+			*issues = append(*issues, "observability.event_bridge.nats.url is required when backend is nats")
+		}
+		if strings.ContainsAny(cfg.Subject, " \t") {
+			*issues = append(*issues, "observability.event_bridge.subject must not contain whitespace")
+		}
+	case EventBridgeBackendKafka:
+		if strings.TrimSpace(cfg.Kafka.RESTURL) == "" {
+			*issues = append(*issues, "observability.event_bridge.kafka.rest_url is required when backend is kafka")
+		}
+	default:
+		*issues = append(*issues, fmt.Sprintf("observability.event_bridge.backend %q is not supported; choose nats or kafka", cfg.Backend))
+	}
+	if cfg.Format != "json" && cfg.Format != "protobuf" {
+		*issues = append(*issues, fmt.Sprintf("observability.event_bridge.format %q is not supported; choose json or protobuf", cfg.Format))
+	}
+	if cfg.BufferSize < 0 {
+		*issues = append(*issues, "observability.event_bridge.buffer_size must be >= 0")
+	}
+	if cfg.NATS.Timeout < 0 || cfg.Kafka.Timeout < 0 {
+		*issues = append(*issues, "observability.event_bridge timeouts must be >= 0")
+	}
+}
+
+func validateSandboxSnapshotConfig(issues *[]string, cfg SandboxSnapshotConfig) {
+	if cfg.RefreshInterval < 0 {
+		*issues = append(*issues, "tools.sandbox.snapshots.refresh_interval must be >= 0")
+	}
+	if cfg.MaxAge < 0 {
+		*issues = append(*issues, "tools.sandbox.snapshots.max_age must be >= 0")
+	}
+	if cfg.Jitter < 0 || cfg.Jitter >= 1 {
+		*issues = append(*issues, "tools.sandbox.snapshots.jitter must be >= 0 and < 1")
+	}
+}
+
+func validateSandboxLanguagesConfig(issues *[]string, languages map[string]SandboxLanguageConfig) {
+	names := make([]string, 0, len(languages))
+	for name := range languages {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		lang := languages[name]
+		switch name {
+		case "python", "nodejs", "go", "bash":
+		default:
+			*issues = append(*issues, fmt.Sprintf("tools.sandbox.languages.%s is not a sandbox language (python, nodejs, go, bash)", name))
+			continue
+		}
+		image := strings.TrimSpace(lang.Image)
+		if strings.TrimSpace(lang.Tag) == "" {
+			continue
+		}
+		if image == "" {
+			*issues = append(*issues, fmt.Sprintf("tools.sandbox.languages.%s.tag requires image", name))
+			continue
+		}
+		repo := image[strings.LastIndex(image, "/")+1:]
+		if strings.ContainsAny(repo, ":@") {
+			*issues = append(*issues, fmt.Sprintf("tools.sandbox.languages.%s.image %q already has a tag or digest; drop tag", name, image))
+		}
+	}
+}
+
+func validateSandboxWasmConfig(issues *[]string, cfg SandboxWasmConfig) {
+	if !cfg.Enabled {
+		return
+	}
+	if strings.TrimSpace(cfg.PythonModule) == "" && strings.TrimSpace(cfg.JavaScriptModule) == "" {
+		*issues = append(*issues, "tools.sandbox.wasm requires python_module or javascript_module")
+	}
+	if strings.TrimSpace(cfg.PythonHome) != "" && strings.TrimSpace(cfg.PythonModule) == "" {
+		*issues = append(*issues, "tools.sandbox.wasm.python_home requires python_module")
+	}
+	if cfg.MaxCodeBytes < 0 {
+		*issues = append(*issues, "tools.sandbox.wasm.max_code_bytes must be >= 0")
+	}
+	if cfg.Timeout < 0 {
+		*issues = append(*issues, "tools.sandbox.wasm.timeout must be >= 0")
+	}
+}
+
+func validateDiagnosticsServerConfig(issues *[]string, cfg DiagnosticsServerConfig, authCfg AuthConfig) {
+	if !cfg.Enabled {
+		return
+	}
+	if cfg.Port < 0 || cfg.Port > 65535 {
+		*issues = append(*issues, "server.diagnostics.port must be between 0 and 65535")
+	}
+	if !cfg.AllowRemote && !isLoopbackHost(cfg.Host) {
+		*issues = append(*issues, fmt.Sprintf("server.diagnostics.host %q is not a loopback address; set allow_remote to expose profiles", cfg.Host))
+	}
+	if strings.TrimSpace(cfg.Token) == "" && strings.TrimSpace(authCfg.JWTSecret) == "" && len(authCfg.APIKeys) == 0 {
+		*issues = append(*issues, "server.diagnostics requires a token, auth.jwt_secret, or auth.api_keys")
+	}
+}
+
+func isLoopbackHost(host string) bool {
+	host = strings.Trim(strings.TrimSpace(host), "[]")
+	if strings.EqualFold(host, "localhost") {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
@@ -1,12 +1,20 @@
 package config
 
-import "time"
+import (
+	"time"
+
+	"github.com/haasonsaas/nexus/internal/audit"
+)
 
 type AuthConfig struct {
 	JWTSecret   string         `yaml:"jwt_secret"`
 	TokenExpiry time.Duration  `yaml:"token_expiry"`
 	APIKeys     []APIKeyConfig `yaml:"api_keys"`
 	OAuth       OAuthConfig    `yaml:"oauth"`
+	OIDC        OIDCConfig     `yaml:"oidc"`
+	// Audit records admin operations (config writes, approval overrides,
+	// identity erasure) and denied attempts at them.
+	Audit audit.Config `yaml:"audit"`
 }
 
 type APIKeyConfig struct {
@@ -14,6 +22,13 @@ type APIKeyConfig struct {
 	UserID string `yaml:"user_id"`
 	Email  string `yaml:"email"`
 	Name   string `yaml:"name"`
+	// Role grants a fixed set of scopes: "read-only", "operator", or
+	// "admin".
+	Role string `yaml:"role"`
+	// Scopes limits the key to the listed scopes (e.g. "sessions:read",
+	// "approvals:*", "admin"), on top of those of Role. A key with neither
+	// has full access.
+	Scopes []string `yaml:"scopes"`
 }
 
 type OAuthConfig struct {
@@ -26,3 +41,26 @@ type OAuthProviderConfig struct {
 	ClientSecret string `yaml:"client_secret"`
 	RedirectURL  string `yaml:"redirect_url"`
 }
+
+// OIDCConfig enables single sign-on through an OpenID Connect provider for the
+// HTTP surfaces: the dashboard, the canvas host, and artifact links.
+type OIDCConfig struct {
+	Enabled bool `yaml:"enabled"`
+	// Issuer is the provider URL; endpoints are discovered from
+	// <issuer>/.well-known/openid-configuration.
+	Issuer       string `yaml:"issuer"`
+	ClientID     string `yaml:"client_id"`
+	ClientSecret string `yaml:"client_secret"`
+	// RedirectURL is the gateway's callback, ending in /auth/oidc/callback.
+	RedirectURL string   `yaml:"redirect_url"`
+	Scopes      []string `yaml:"scopes"`
+	// GroupsClaim names the ID token claim that lists the user's groups.
+	GroupsClaim string `yaml:"groups_claim"`
+	// GroupRoles maps groups to "read-only", "operator", or "admin". Users in
+	// several mapped groups get the highest role.
+	GroupRoles map[string]string `yaml:"group_roles"`
+	// DefaultRole applies to users in no mapped group; empty refuses them.
+	DefaultRole string `yaml:"default_role"`
+	// SessionTTL is how long a login lasts.
+	SessionTTL time.Duration `yaml:"session_ttl"`
+}
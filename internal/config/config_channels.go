@@ -26,6 +26,33 @@ type ChannelPolicyConfig struct {
 	Policy string `yaml:"policy"`
 	// AllowFrom is a list of sender identifiers allowed for this policy.
 	AllowFrom []string `yaml:"allow_from"`
+
+	// The remaining fields set group chat etiquette and are ignored for DMs.
+
+	// Activation decides which group messages get a reply: "always" (default),
+	// "mention" (only when the bot is mentioned or replied to), or "interject"
+	// (mentions, plus unprompted replies to messages the relevance classifier
+	// rates as worth joining in on).
+	Activation string `yaml:"activation"`
+	// MentionPatterns are case-insensitive regular expressions that count as
+	// mentioning the bot, e.g. its name.
+	MentionPatterns []string `yaml:"mention_patterns"`
+	// Cooldown is the minimum time after a reply before the bot answers
+	// another message in the group that does not mention it.
+	Cooldown time.Duration `yaml:"cooldown"`
+	// Interject tunes the "interject" activation.
+	Interject GroupInterjectConfig `yaml:"interject"`
+}
+
+// GroupInterjectConfig configures unprompted replies in group chats.
+type GroupInterjectConfig struct {
+	// Probability is the chance an unaddressed message is considered at all
+	// (default 0.25), bounding classifier calls in busy groups.
+	Probability float64 `yaml:"probability"`
+	// MinRelevance is the classifier score (0-1) needed to reply (default 0.7).
+	MinRelevance float64 `yaml:"min_relevance"`
+	// Model overrides the model used for the relevance classifier.
+	Model string `yaml:"model"`
 }
 
 // ChannelMarkdownConfig configures markdown processing for a channel.
@@ -43,12 +70,31 @@ type TelegramConfig struct {
 	BotToken string `yaml:"bot_token"`
 	Webhook  string `yaml:"webhook"`
 
+	// ChannelPosts handles posts in channels the bot administers.
+	ChannelPosts bool `yaml:"channel_posts"`
+
+	// EditedMessages handles edits of earlier messages as new messages.
+	EditedMessages bool `yaml:"edited_messages"`
+
+	// Topics overrides the agent per forum topic, or disables it there.
+	Topics []TelegramTopicConfig `yaml:"topics"`
+
 	DM    ChannelPolicyConfig `yaml:"dm"`
 	Group ChannelPolicyConfig `yaml:"group"`
 
 	Markdown ChannelMarkdownConfig `yaml:"markdown"`
 }
 
+// TelegramTopicConfig configures the agent for one forum topic. A rule with
+// topic_id 0 applies to every topic of the chat without its own rule; the
+// General topic is topic 1.
+type TelegramTopicConfig struct {
+	ChatID   int64  `yaml:"chat_id"`
+	TopicID  int    `yaml:"topic_id"`
+	AgentID  string `yaml:"agent_id"`
+	Disabled bool   `yaml:"disabled"`
+}
+
 type DiscordConfig struct {
 	Enabled  bool   `yaml:"enabled"`
 	BotToken string `yaml:"bot_token"`
@@ -111,6 +157,16 @@ type SignalConfig struct {
 	SignalCLIPath string `yaml:"signal_cli_path"`
 	ConfigDir     string `yaml:"config_dir"`
 
+	// Mode is "process" (spawn signal-cli jsonRpc, the default) or "daemon"
+	// (connect to a running signal-cli daemon at DaemonAddress).
+	Mode           string        `yaml:"mode"`
+	DaemonAddress  string        `yaml:"daemon_address"`
+	ReconnectDelay time.Duration `yaml:"reconnect_delay"`
+
+	// AttachmentMaxAge prunes cached attachments older than this duration
+	// (for example "168h"); empty disables pruning.
+	AttachmentMaxAge string `yaml:"attachment_max_age"`
+
 	DM    ChannelPolicyConfig `yaml:"dm"`
 	Group ChannelPolicyConfig `yaml:"group"`
 
@@ -123,6 +179,8 @@ type SignalPresenceConfig struct {
 	SendTyping       bool `yaml:"send_typing"`
 }
 
+// IMessageConfig configures iMessage. The core relays it through a macOS edge
+// daemon that hosts the channel (nexus-edge --channels imessage).
 type IMessageConfig struct {
 	Enabled      bool   `yaml:"enabled"`
 	DatabasePath string `yaml:"database_path"`
@@ -161,7 +219,9 @@ type TeamsConfig struct {
 }
 
 type EmailConfig struct {
-	Enabled      bool   `yaml:"enabled"`
+	Enabled bool `yaml:"enabled"`
+	// Backend is "graph" (Microsoft Graph, default) or "imap" (IMAP and SMTP).
+	Backend      string `yaml:"backend"`
 	TenantID     string `yaml:"tenant_id"`
 	ClientID     string `yaml:"client_id"`
 	ClientSecret string `yaml:"client_secret"`
@@ -175,6 +235,64 @@ type EmailConfig struct {
 	AutoMarkRead bool `yaml:"auto_mark_read"`
 	// PollInterval for checking new emails (default: 30s)
 	PollInterval string `yaml:"poll_interval"`
+
+	// Address is the mailbox of the imap backend: the From address of
+	// outgoing mail and the default IMAP and SMTP username.
+	Address string `yaml:"address"`
+	// DisplayName is the From display name of the imap backend.
+	DisplayName string          `yaml:"display_name"`
+	IMAP        EmailIMAPConfig `yaml:"imap"`
+	SMTP        EmailSMTPConfig `yaml:"smtp"`
+	// OAuth2 authenticates IMAP and SMTP with XOAUTH2 instead of passwords.
+	OAuth2 EmailOAuth2Config `yaml:"oauth2"`
+	// Compose configures the email_compose tool of the imap backend.
+	Compose EmailComposeConfig `yaml:"compose"`
+}
+
+// EmailIMAPConfig configures the watched mailbox of the imap backend.
+type EmailIMAPConfig struct {
+	Host string `yaml:"host"`
+	// Port defaults to 993 for tls and 143 otherwise.
+	Port int `yaml:"port"`
+	// Security is tls (default), starttls, or none.
+	Security string `yaml:"security"`
+	// Username defaults to address.
+	Username string `yaml:"username"`
+	// Password is the account or app password.
+	Password string `yaml:"password"`
+	// IDLE waits for new mail with IMAP IDLE instead of polling (default: true).
+	IDLE *bool `yaml:"idle"`
+	// IdleTimeout renews IDLE before servers drop it (default: 25m).
+	IdleTimeout time.Duration `yaml:"idle_timeout"`
+}
+
+// EmailSMTPConfig configures outgoing mail of the imap backend.
+type EmailSMTPConfig struct {
+	Host string `yaml:"host"`
+	// Port defaults to 587 for starttls, 465 for tls, and 25 for none.
+	Port int `yaml:"port"`
+	// Security is starttls (default), tls, or none.
+	Security string `yaml:"security"`
+	// Username and Password default to the IMAP credentials.
+	Username string `yaml:"username"`
+	Password string `yaml:"password"`
+}
+
+// EmailOAuth2Config holds XOAUTH2 tokens for the imap backend. A refresh
+// token is exchanged at token_url (or the tenant_id endpoint) with
+// client_id and client_secret.
+type EmailOAuth2Config struct {
+	AccessToken  string `yaml:"access_token"`
+	RefreshToken string `yaml:"refresh_token"`
+	TokenURL     string `yaml:"token_url"`
+}
+
+// EmailComposeConfig controls the email_compose tool, which sends new email
+// to any address rather than replying.
+type EmailComposeConfig struct {
+	Enabled bool `yaml:"enabled"`
+	// RequireApproval holds every email_compose call for approval (default: true).
+	RequireApproval *bool `yaml:"require_approval"`
 }
 
 type MattermostConfig struct {
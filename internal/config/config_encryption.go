@@ -0,0 +1,30 @@
+package config
+
+// EncryptionConfig controls field-level encryption of stored message content,
+// vector memory entries, and artifact metadata.
+type EncryptionConfig struct {
+	Enabled bool `yaml:"enabled"`
+
+	// PrimaryKey is the id of the key used to encrypt new values. Defaults to
+	// the first configured key.
+	PrimaryKey string `yaml:"primary_key"`
+
+	// Keys lists the primary key and any retired keys that existing values
+	// may still be encrypted with. Keep a retired key here until
+	// "nexus encryption migrate" has re-encrypted everything.
+	Keys []EncryptionKeyConfig `yaml:"keys"`
+}
+
+// EncryptionKeyConfig defines one AES-256 key. Exactly one of Key and
+// AgeIdentityFile must be set.
+type EncryptionKeyConfig struct {
+	ID string `yaml:"id"`
+
+	// Key is a 32-byte key encoded as base64 or hex, usually supplied from the
+	// environment, for example ${NEXUS_ENCRYPTION_KEY}.
+	Key string `yaml:"key"`
+
+	// AgeIdentityFile is an age identity file (AGE-SECRET-KEY-1...) the key
+	// is derived from.
+	AgeIdentityFile string `yaml:"age_identity_file"`
+}
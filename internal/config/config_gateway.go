@@ -1,5 +1,7 @@
 package config
 
+import "time"
+
 // GatewayConfig configures gateway-level message routing and processing.
 type GatewayConfig struct {
 	Broadcast BroadcastConfig `yaml:"broadcast"`
@@ -15,6 +17,61 @@ type AttentionConfig struct {
 	InjectInPrompt bool `yaml:"inject_in_prompt"`
 	// MaxItems limits how many items are injected into the prompt.
 	MaxItems int `yaml:"max_items"`
+	// Retention is how long handled items are kept before pruning
+	// (default: 7d).
+	Retention time.Duration `yaml:"retention"`
+	// Digest posts a periodic summary of open items to a channel.
+	Digest AttentionDigestConfig `yaml:"digest"`
+}
+
+// AttentionDigestConfig schedules the attention digest.
+type AttentionDigestConfig struct {
+	Enabled bool `yaml:"enabled"`
+	// Schedule is a cron expression (default: "0 9 * * *", daily at 09:00).
+	Schedule string `yaml:"schedule"`
+	// Timezone is the IANA zone the schedule is evaluated in (default: UTC).
+	Timezone string `yaml:"timezone"`
+	// Channel and PeerID identify the conversation the digest is posted to.
+	Channel string `yaml:"channel"`
+	PeerID  string `yaml:"peer_id"`
+	// MaxItems caps how many open items the digest lists (default: 20).
+	MaxItems int `yaml:"max_items"`
+}
+
+// DeliveryConfig routes proactive messages (reminders, scheduled messages,
+// attention digests, credential alerts and web watch notifications) to the
+// best channel of a user whose identity is linked across channels.
+type DeliveryConfig struct {
+	// Enabled turns on presence-aware delivery. When off, proactive messages
+	// go to the conversation they were addressed to.
+	Enabled bool `yaml:"enabled"`
+	// Strategy picks the first channel to try: "preferred" (the identity's
+	// preferred_channel metadata, then the most recently active channel),
+	// "recent" (the most recently active channel), or "origin" (the
+	// conversation the message was addressed to). Default: preferred.
+	Strategy string `yaml:"strategy"`
+	// Fallback tries the user's other linked channels when the first one
+	// fails (default: true).
+	Fallback *bool `yaml:"fallback"`
+	// QuietHours is a daily window, in the user's timezone, during which
+	// proactive messages are held back.
+	QuietHours QuietHoursConfig `yaml:"quiet_hours"`
+	// DuringQuietHours is "defer" (send when quiet hours end), "send", or
+	// "drop". Default: defer.
+	DuringQuietHours string `yaml:"during_quiet_hours"`
+	// Types overrides the settings per message type: reminder,
+	// scheduled_message, digest, alert, watch.
+	Types map[string]DeliveryTypeConfig `yaml:"types"`
+}
+
+// DeliveryTypeConfig overrides delivery settings for one message type.
+type DeliveryTypeConfig struct {
+	// Channels are tried first, in order, when the user is linked on them.
+	Channels []string `yaml:"channels"`
+	// Strategy overrides DeliveryConfig.Strategy.
+	Strategy string `yaml:"strategy"`
+	// DuringQuietHours overrides DeliveryConfig.DuringQuietHours.
+	DuringQuietHours string `yaml:"during_quiet_hours"`
 }
 
 // SteeringConfig controls conditional prompt injection rules.
@@ -23,6 +80,9 @@ type SteeringConfig struct {
 	Enabled bool `yaml:"enabled"`
 	// Rules define conditional prompt injections.
 	Rules []SteeringRule `yaml:"rules"`
+	// Watch reloads the rules whenever the config file changes, without
+	// applying the rest of the file.
+	Watch bool `yaml:"watch"`
 }
 
 // SteeringRule defines a conditional prompt injection.
@@ -76,6 +136,31 @@ type CommandsConfig struct {
 
 	// InlineCommands lists command names that can run inline (without leading slash).
 	InlineCommands []string `yaml:"inline_commands"`
+
+	// Roles assigns permission tiers (guest, member, admin) to senders.
+	Roles CommandRolesConfig `yaml:"roles"`
+}
+
+// CommandRolesConfig configures command permission roles.
+type CommandRolesConfig struct {
+	// Default is the role of senders with no other assignment. Defaults to member.
+	Default string `yaml:"default"`
+
+	// Channels overrides the default role per channel.
+	// Example: {"discord": "guest"}
+	Channels map[string]string `yaml:"channels"`
+
+	// Assignments maps subjects to roles. A subject is either
+	// "channel:sender_id" or a canonical identity ID from session.scoping.identity_links.
+	Assignments map[string]string `yaml:"assignments"`
+
+	// Commands overrides the minimum role of individual commands.
+	// Example: {"model": "admin"}
+	Commands map[string]string `yaml:"commands"`
+
+	// StorePath is where roles granted with /role are persisted.
+	// Defaults to ~/.nexus/roles.json.
+	StorePath string `yaml:"store_path"`
 }
 
 // BroadcastConfig configures broadcast groups for message routing.
@@ -87,6 +172,39 @@ type BroadcastConfig struct {
 	// When a message arrives from a peer in this map, it will be routed to all
 	// specified agents instead of the default single agent.
 	Groups map[string][]string `yaml:"groups"`
+
+	// Outbound configures named target lists for the broadcast command and
+	// tool, which deliver one agent-composed message to many channels.
+	Outbound OutboundBroadcastConfig `yaml:"outbound"`
+}
+
+// OutboundBroadcastConfig configures outbound broadcast lists.
+type OutboundBroadcastConfig struct {
+	// Lists maps a list name to the channels/peers a broadcast delivers to.
+	Lists map[string][]BroadcastTargetConfig `yaml:"lists"`
+
+	// MaxAttempts is the number of delivery attempts per target (default 3).
+	MaxAttempts int `yaml:"max_attempts"`
+
+	// RetryDelay is the delay before the first retry; it doubles on each
+	// subsequent attempt (default 2s).
+	RetryDelay time.Duration `yaml:"retry_delay"`
+}
+
+// BroadcastTargetConfig is a single broadcast destination.
+type BroadcastTargetConfig struct {
+	// Channel is the channel type (slack, telegram, discord, ...).
+	Channel string `yaml:"channel"`
+
+	// PeerID is the channel-specific chat, channel, or user ID.
+	PeerID string `yaml:"peer_id"`
+
+	// Format controls how the message is rendered for this target:
+	// "auto" (per channel, default), "markdown", or "plain".
+	Format string `yaml:"format"`
+
+	// Label is an optional display name used in delivery reports.
+	Label string `yaml:"label"`
 }
 
 // WebhookHooksConfig configures inbound webhook hook handling.
@@ -97,7 +215,8 @@ type WebhookHooksConfig struct {
 	// BasePath is the URL path prefix for webhook hooks (default: /hooks).
 	BasePath string `yaml:"base_path"`
 
-	// Token is the required authentication token.
+	// Token is the authentication token. Required unless every mapping
+	// sets its own token or secret.
 	Token string `yaml:"token"`
 
 	// MaxBodyBytes limits the request body size (default: 256KB).
@@ -123,4 +242,69 @@ type WebhookHookMapping struct {
 
 	// ChannelID targets a specific channel (optional).
 	ChannelID string `yaml:"channel_id"`
+
+	// Channel is the channel type runs are delivered to, such as slack.
+	// With ChannelID it fixes the conversation replies are posted in.
+	Channel string `yaml:"channel"`
+
+	// Template renders the prompt from the request with text/template.
+	// Templates see .payload (the decoded JSON body), .headers, .query,
+	// and .webhook (the mapping name). When set, the body is not read as
+	// a WebhookPayload, so any JSON the sender emits is accepted.
+	Template string `yaml:"template"`
+
+	// Match restricts the endpoint to requests whose fields equal the given
+	// values. Keys are dotted payload paths ("action", "issue.state") or
+	// "header:<Name>". Requests that do not match are acknowledged and
+	// ignored.
+	Match map[string]string `yaml:"match"`
+
+	// Token overrides the gateway-wide token for this endpoint.
+	Token string `yaml:"token"`
+
+	// Secret verifies an HMAC-SHA256 signature of the body instead of a
+	// token, as sent by GitHub.
+	Secret string `yaml:"secret"`
+
+	// SignatureHeader carries the "sha256=<hex>" signature
+	// (default: X-Hub-Signature-256).
+	SignatureHeader string `yaml:"signature_header"`
+
+	// RateLimit caps accepted requests per minute (0 = unlimited).
+	RateLimit int `yaml:"rate_limit"`
+}
+
+// GitOps modes.
+const (
+	// GitOpsModeApply writes the config from git and applies its
+	// hot-reloadable changes.
+	GitOpsModeApply = "apply"
+	// GitOpsModeReport only logs how the local config drifted from git.
+	GitOpsModeReport = "report"
+)
+
+// GitOpsConfig syncs the config file from a git repository so it can be
+// managed through pull requests. Every gateway node syncs its own config
+// file. Keep this section in the config stored in git, or the next restart
+// turns syncing off.
+type GitOpsConfig struct {
+	// Enabled turns on the sync loop.
+	Enabled bool `yaml:"enabled"`
+	// Repo is the repository URL, in any form git fetch accepts.
+	Repo string `yaml:"repo"`
+	// Ref is the branch, tag, or commit to follow (default: the remote's
+	// default branch).
+	Ref string `yaml:"ref"`
+	// Path is the config file within the repository (default: nexus.yaml).
+	// It must be self-contained: includes and extends are resolved next to
+	// the local config file, not in the repository.
+	Path string `yaml:"path"`
+	// Interval is how often the ref is polled (default: 1m).
+	Interval time.Duration `yaml:"interval"`
+	// Mode is "apply" (write the config and apply hot-reloadable changes)
+	// or "report" (only log drift). Default: apply.
+	Mode string `yaml:"mode"`
+	// CacheDir holds the fetched repository (default:
+	// ~/.nexus/cache/gitops).
+	CacheDir string `yaml:"cache_dir"`
 }
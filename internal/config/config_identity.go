@@ -12,6 +12,25 @@ type WorkspaceConfig struct {
 	IdentityFile string `yaml:"identity_file"`
 	ToolsFile    string `yaml:"tools_file"`
 	MemoryFile   string `yaml:"memory_file"`
+
+	History WorkspaceHistoryConfig `yaml:"history"`
+}
+
+// WorkspaceHistoryConfig controls versioning of the workspace bootstrap
+// files (AGENTS.md, SOUL.md, MEMORY.md, ...). Versions are listed and
+// restored with "nexus workspace history" and "nexus workspace rollback".
+type WorkspaceHistoryConfig struct {
+	// Enabled keeps a copy of each version of a bootstrap file the agent
+	// changes with the file tools. Defaults to true.
+	Enabled *bool `yaml:"enabled"`
+
+	// Dir holds the versions. Relative paths are resolved against the
+	// workspace path. Defaults to .nexus/history.
+	Dir string `yaml:"dir"`
+
+	// MaxVersions keeps at most this many versions per file, removing the
+	// oldest first. Defaults to 50.
+	MaxVersions int `yaml:"max_versions"`
 }
 
 type IdentityConfig struct {
@@ -29,6 +48,22 @@ type UserConfig struct {
 	Notes            string `yaml:"notes"`
 }
 
+// MessagesConfig localizes and brands the text Nexus sends on its own
+// behalf (pairing prompts, approval notices, errors and quota warnings).
+type MessagesConfig struct {
+	// Locale is the fallback language when the user's profile and channel
+	// do not name one. Defaults to English.
+	Locale string `yaml:"locale"`
+
+	// Channels sets the language per channel, e.g. {telegram: es}. A
+	// locale in the user's identity profile takes precedence.
+	Channels map[string]string `yaml:"channels"`
+
+	// Templates overrides messages by key and locale using Go template
+	// syntax, e.g. {"error.run_failed": {en: "Oops, try again."}}.
+	Templates map[string]map[string]string `yaml:"templates"`
+}
+
 type PluginsConfig struct {
 	Load      PluginLoadConfig             `yaml:"load"`
 	Entries   map[string]PluginEntryConfig `yaml:"entries"`
@@ -54,6 +89,12 @@ type PluginIsolationConfig struct {
 	Limits         ResourceLimits       `yaml:"limits"`
 	RunnerPath     string               `yaml:"runner_path"`
 	Daytona        SandboxDaytonaConfig `yaml:"daytona"`
+
+	// TraceEndpoint is an OTLP gRPC collector reachable from the sandbox.
+	// When set, isolated tool executions export spans that join the
+	// gateway's trace; the trace context is passed either way.
+	TraceEndpoint string `yaml:"trace_endpoint"`
+	TraceInsecure bool   `yaml:"trace_insecure"`
 }
 
 // MarketplaceConfig configures the plugin marketplace.
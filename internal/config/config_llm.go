@@ -17,8 +17,74 @@ type LLMConfig struct {
 	// Routing configures intelligent provider routing.
 	Routing LLMRoutingConfig `yaml:"routing"`
 
+	// Race sends interactive requests to two providers at once and keeps
+	// the faster response.
+	Race LLMRaceConfig `yaml:"race"`
+
 	// AutoDiscover configures local provider discovery.
 	AutoDiscover LLMAutoDiscoverConfig `yaml:"auto_discover"`
+
+	// KeyRotation configures how provider API keys are rotated.
+	KeyRotation LLMKeyRotationConfig `yaml:"key_rotation"`
+
+	// Batch configures the queue that sends background requests through a
+	// provider batch API.
+	Batch LLMBatchConfig `yaml:"batch"`
+
+	// Reasoning controls how model reasoning is recorded.
+	Reasoning LLMReasoningConfig `yaml:"reasoning"`
+}
+
+// LLMRaceConfig configures speculative racing: each interactive request
+// goes to both contenders, the first to produce text or a tool call is
+// streamed, and the other is cancelled. Racing can double the cost of a
+// request, so it is gated on the estimated cost from the model catalog;
+// requests that do not race go through the usual provider, fallback chain,
+// and routing.
+type LLMRaceConfig struct {
+	Enabled bool `yaml:"enabled"`
+	// Contenders are the two providers to race, e.g. a local ollama model
+	// and a cloud model. Model defaults to the provider's default model.
+	Contenders []RoutingTarget `yaml:"contenders"`
+	// Channels limits racing to sessions on these channels (default: all).
+	// Requests without a session, such as background jobs, never race.
+	Channels []string `yaml:"channels"`
+	// MaxCostUSD races only requests whose estimated cost on both
+	// contenders together is at or below it (default: no cap).
+	MaxCostUSD float64 `yaml:"max_cost_usd"`
+	// DailyBudgetUSD caps the estimated cost of all races per UTC day;
+	// later requests are not raced (default: no cap).
+	DailyBudgetUSD float64 `yaml:"daily_budget_usd"`
+}
+
+// LLMReasoningConfig controls how extended thinking (Anthropic) and
+// reasoning (OpenAI o-series, inline <think> models) is recorded. Reasoning
+// is never sent to channels.
+type LLMReasoningConfig struct {
+	// Redact replaces reasoning text in model.reasoning trace events with
+	// its length (default: true). Reasoning token counts are always kept.
+	Redact *bool `yaml:"redact"`
+}
+
+// LLMBatchConfig configures the batch queue. Background jobs that opt in
+// (such as memory consolidation) queue their requests, which are submitted
+// together through the provider's batch API (OpenAI Batch or Anthropic
+// Message Batches) at about half the price, and wait for the results.
+type LLMBatchConfig struct {
+	Enabled bool `yaml:"enabled"`
+	// Provider is the provider batches are sent to: anthropic or openai
+	// (default: llm.default_provider).
+	Provider string `yaml:"provider"`
+	// MaxBatchSize submits a batch as soon as this many requests are
+	// queued (default: 1000).
+	MaxBatchSize int `yaml:"max_batch_size"`
+	// FlushInterval is how often queued requests are submitted (default: 1m).
+	FlushInterval time.Duration `yaml:"flush_interval"`
+	// PollInterval is how often submitted batches are checked (default: 1m).
+	PollInterval time.Duration `yaml:"poll_interval"`
+	// Timeout is how long a job waits for a batched result before giving
+	// up (default: 24h, the providers' completion window).
+	Timeout time.Duration `yaml:"timeout"`
 }
 
 type LLMProviderConfig struct {
@@ -27,6 +93,62 @@ type LLMProviderConfig struct {
 	BaseURL      string                              `yaml:"base_url"`
 	APIVersion   string                              `yaml:"api_version"`
 	Profiles     map[string]LLMProviderProfileConfig `yaml:"profiles"`
+
+	// Keys lists API keys shared by weight across requests. APIKey, when
+	// also set, joins the rotation as key "default" with weight 1.
+	Keys []LLMProviderKeyConfig `yaml:"keys"`
+
+	// RateLimit throttles requests to the provider across all its keys and
+	// profiles.
+	RateLimit LLMRateLimitConfig `yaml:"rate_limit"`
+}
+
+// LLMProviderKeyConfig is one API key in a provider's rotation.
+type LLMProviderKeyConfig struct {
+	// ID names the key in logs and for `nexus auth rotate --key-id`
+	// (default: key-<n>).
+	ID string `yaml:"id"`
+	// Key is the API key. KeyFile reads it from a file, such as a mounted
+	// secret, instead.
+	Key     string `yaml:"key"`
+	KeyFile string `yaml:"key_file"`
+	// Weight is the key's share of requests relative to the other keys
+	// (default: 1).
+	Weight int `yaml:"weight"`
+	// RotatedAt is when the key was last replaced; rotation reminders are
+	// counted from it.
+	RotatedAt time.Time `yaml:"rotated_at"`
+	// RateLimit throttles requests made with this key. A request the key
+	// cannot take in time moves on to the next key.
+	RateLimit LLMRateLimitConfig `yaml:"rate_limit"`
+}
+
+// LLMRateLimitConfig caps the request rate, token rate, and concurrency
+// sent to a provider or key. Zero fields are unlimited.
+type LLMRateLimitConfig struct {
+	// RequestsPerMinute caps requests started per minute (RPM).
+	RequestsPerMinute int `yaml:"requests_per_minute"`
+	// TokensPerMinute caps input plus output tokens per minute (TPM).
+	TokensPerMinute int `yaml:"tokens_per_minute"`
+	// MaxConcurrent caps requests in flight at once.
+	MaxConcurrent int `yaml:"max_concurrent"`
+	// MaxWait is the longest a request queues for capacity before failing
+	// over (default: 30s). Requests with an earlier deadline fail sooner.
+	MaxWait time.Duration `yaml:"max_wait"`
+}
+
+// LLMKeyRotationConfig configures demotion of failing provider keys and
+// rotation reminders.
+type LLMKeyRotationConfig struct {
+	// RateLimitCooldown is how long a key answering 429 is skipped
+	// (default: 1m).
+	RateLimitCooldown time.Duration `yaml:"rate_limit_cooldown"`
+	// AuthCooldown is how long a key answering 401 or 403 is skipped
+	// (default: 1h).
+	AuthCooldown time.Duration `yaml:"auth_cooldown"`
+	// RemindAfter is the key age at which rotation reminders start
+	// (default: 90 days). A negative value disables reminders.
+	RemindAfter time.Duration `yaml:"remind_after"`
 }
 
 type LLMProviderProfileConfig struct {
@@ -38,12 +160,33 @@ type LLMProviderProfileConfig struct {
 
 // LLMRoutingConfig configures provider routing rules.
 type LLMRoutingConfig struct {
-	Enabled           bool          `yaml:"enabled"`
+	Enabled bool `yaml:"enabled"`
+	// Classifier tags requests for rules that match on tags: "heuristic"
+	// (default) or "llm".
 	Classifier        string        `yaml:"classifier"`
 	PreferLocal       bool          `yaml:"prefer_local"`
 	UnhealthyCooldown time.Duration `yaml:"unhealthy_cooldown"`
 	Rules             []RoutingRule `yaml:"rules"`
 	Fallback          RoutingTarget `yaml:"fallback"`
+
+	// ClassifierLLM configures the "llm" classifier.
+	ClassifierLLM RoutingClassifierLLMConfig `yaml:"classifier_llm"`
+}
+
+// RoutingClassifierLLMConfig configures the LLM routing classifier, which
+// asks a small model to tag each request with the tags the rules use.
+type RoutingClassifierLLMConfig struct {
+	// Provider defaults to llm.default_provider.
+	Provider string `yaml:"provider"`
+	// Model should be a cheap, fast model.
+	Model string `yaml:"model"`
+	// Timeout bounds each classification; on timeout or error the
+	// heuristic classifier is used instead (default: 5s).
+	Timeout time.Duration `yaml:"timeout"`
+	// CacheSize is how many classifications are kept (default: 1000).
+	CacheSize int `yaml:"cache_size"`
+	// CacheTTL is how long a classification is reused (default: 1h).
+	CacheTTL time.Duration `yaml:"cache_ttl"`
 }
 
 // RoutingRule defines a routing rule.
@@ -53,10 +196,27 @@ type RoutingRule struct {
 	Target RoutingTarget `yaml:"target"`
 }
 
-// RoutingMatch defines rule matching criteria.
+// RoutingMatch defines rule matching criteria. Every condition that is set
+// must hold for the rule to match.
 type RoutingMatch struct {
 	Patterns []string `yaml:"patterns"`
 	Tags     []string `yaml:"tags"`
+
+	// MinTokens and MaxTokens bound the estimated prompt size in tokens.
+	MinTokens int `yaml:"min_tokens"`
+	MaxTokens int `yaml:"max_tokens"`
+
+	// Vision matches requests with (true) or without (false) image
+	// attachments.
+	Vision *bool `yaml:"vision"`
+
+	// Tools matches requests that offer (true) or do not offer (false)
+	// tools.
+	Tools *bool `yaml:"tools"`
+
+	// MaxCostUSD skips the rule when the request's estimated cost on the
+	// target model, from the model catalog, exceeds it.
+	MaxCostUSD float64 `yaml:"max_cost_usd"`
 }
 
 // RoutingTarget defines a routing destination.
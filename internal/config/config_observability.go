@@ -1,6 +1,11 @@
 package config
 
-import "time"
+import (
+	"strings"
+	"time"
+
+	"github.com/haasonsaas/nexus/internal/media/transcribe"
+)
 
 type LoggingConfig struct {
 	Level  string `yaml:"level"`
@@ -9,7 +14,406 @@ type LoggingConfig struct {
 
 // ObservabilityConfig configures tracing and other observability features.
 type ObservabilityConfig struct {
-	Tracing TracingConfig `yaml:"tracing"`
+	Tracing        TracingConfig        `yaml:"tracing"`
+	Feedback       FeedbackConfig       `yaml:"feedback"`
+	CrashReporting CrashReportingConfig `yaml:"crash_reporting"`
+	SLO            SLOConfig            `yaml:"slo"`
+	Anomaly        AnomalyConfig        `yaml:"anomaly"`
+	Canary         CanaryConfig         `yaml:"canary"`
+	CostReport     CostReportConfig     `yaml:"cost_report"`
+	Metrics        MetricsConfig        `yaml:"metrics"`
+	EventStore     EventStoreConfig     `yaml:"event_store"`
+	EventBridge    EventBridgeConfig    `yaml:"event_bridge"`
+}
+
+// EventStoreConfig persists agent timeline events (runs, tool calls, LLM
+// calls) to the database so timelines survive restarts and can be queried
+// with `nexus events query` for postmortems. Requires database.url.
+type EventStoreConfig struct {
+	Enabled bool `yaml:"enabled"`
+
+	// Retention is how long persisted events are kept (default: 720h).
+	Retention time.Duration `yaml:"retention"`
+
+	// SampleRate is the fraction of runs whose events are persisted
+	// (default: 1.0). Sampling is per run, so a persisted timeline is
+	// complete. Error events are always persisted.
+	SampleRate float64 `yaml:"sample_rate"`
+
+	// Types limits persistence to these event types, e.g. "run.*" or
+	// "tool.error". Empty persists every type.
+	Types []string `yaml:"types"`
+
+	// BufferSize bounds events waiting to be written (default: 10000).
+	BufferSize int `yaml:"buffer_size"`
+
+	// FlushInterval is how often buffered events are written (default: 5s).
+	FlushInterval time.Duration `yaml:"flush_interval"`
+}
+
+// Event bridge backends.
+const (
+	EventBridgeBackendNATS  = "nats"
+	EventBridgeBackendKafka = "kafka"
+)
+
+// EventBridgeConfig publishes gateway events (messages, runs, tool calls,
+// approvals) to NATS or Kafka for downstream analytics pipelines.
+type EventBridgeConfig struct {
+	Enabled bool `yaml:"enabled"`
+
+	// Backend is nats or kafka.
+	Backend string `yaml:"backend"`
+
+	// Format is json (default) or protobuf. Both carry the versioned
+	// nexus.events.v1.Event envelope.
+	Format string `yaml:"format"`
+
+	// Subject is the NATS subject or Kafka topic. "{type}" is replaced with
+	// the event type (default: "nexus.events.{type}" for nats,
+	// "nexus.events" for kafka).
+	Subject string `yaml:"subject"`
+
+	// Events limits publishing to these event types, e.g. "run.*" or
+	// "approval.decided". Empty publishes every type.
+	Events []string `yaml:"events"`
+
+	// Channels and Agents limit publishing to events from these channels
+	// or agents. Empty publishes from all.
+	Channels []string `yaml:"channels"`
+	Agents   []string `yaml:"agents"`
+
+	// IncludeContent adds message text and tool arguments/results to the
+	// published events. Off by default so payloads carry metadata only.
+	IncludeContent bool `yaml:"include_content"`
+
+	// BufferSize bounds events waiting to be published (default: 10000).
+	BufferSize int `yaml:"buffer_size"`
+
+	NATS  EventBridgeNATSConfig  `yaml:"nats"`
+	Kafka EventBridgeKafkaConfig `yaml:"kafka"`
+}
+
+// EventBridgeNATSConfig configures the NATS backend.
+type EventBridgeNATSConfig struct {
+	// URL is the server address, e.g. nats://nats:4222. Comma-separate
+	// several servers; use tls:// for TLS.
+	URL      string `yaml:"url"`
+	User     string `yaml:"user"`
+	Password string `yaml:"password"`
+	Token    string `yaml:"token"`
+
+	// Timeout bounds connecting and each publish (default: 5s).
+	Timeout time.Duration `yaml:"timeout"`
+}
+
+// EventBridgeKafkaConfig configures the Kafka backend, which produces
+// through a Kafka REST proxy (Confluent REST Proxy or Redpanda HTTP proxy).
+type EventBridgeKafkaConfig struct {
+	// RESTURL is the REST proxy base URL, e.g. http://kafka-rest:8082.
+	RESTURL  string `yaml:"rest_url"`
+	Username string `yaml:"username"`
+	Password string `yaml:"password"`
+
+	// Headers are sent with every request, e.g. API keys.
+	Headers map[string]string `yaml:"headers"`
+
+	// Timeout bounds each produce request (default: 10s).
+	Timeout time.Duration `yaml:"timeout"`
+}
+
+// Metrics exporters.
+const (
+	MetricsExporterPrometheus = "prometheus"
+	MetricsExporterOTLP       = "otlp"
+	MetricsExporterBoth       = "both"
+)
+
+// MetricsConfig selects how gateway metrics leave the process: scraped from
+// /metrics, pushed to an OTLP collector, or both.
+type MetricsConfig struct {
+	// Exporter is prometheus (default), otlp, or both. With otlp the
+	// /metrics endpoint is not served.
+	Exporter string `yaml:"exporter"`
+
+	OTLP OTLPMetricsConfig `yaml:"otlp"`
+}
+
+// PrometheusEnabled reports whether /metrics should be served.
+func (c MetricsConfig) PrometheusEnabled() bool {
+	return c.Exporter != MetricsExporterOTLP
+}
+
+// OTLPEnabled reports whether metrics are pushed to an OTLP collector.
+func (c MetricsConfig) OTLPEnabled() bool {
+	return c.Exporter == MetricsExporterOTLP || c.Exporter == MetricsExporterBoth
+}
+
+// OTLPMetricsConfig configures the OTLP metrics push. The exported instruments
+// are the same ones registered for the Prometheus scrape.
+type OTLPMetricsConfig struct {
+	// Endpoint is the collector address, e.g. otel-collector:4317 for grpc
+	// or https://otlp.example.com/v1/metrics for http.
+	Endpoint string `yaml:"endpoint"`
+
+	// Protocol is grpc (default) or http (protobuf over HTTP).
+	Protocol string `yaml:"protocol"`
+
+	Insecure bool `yaml:"insecure"`
+
+	// Interval is how often metrics are pushed (default: 60s).
+	Interval time.Duration `yaml:"interval"`
+
+	// Timeout bounds each push (default: 10s).
+	Timeout time.Duration `yaml:"timeout"`
+
+	// Headers are sent with every push, e.g. collector API keys.
+	Headers map[string]string `yaml:"headers"`
+
+	// ResourceAttributes are added to the exported resource. service.name,
+	// service.version, and deployment.environment default to the tracing
+	// settings.
+	ResourceAttributes map[string]string `yaml:"resource_attributes"`
+}
+
+// SLOConfig defines service level objectives evaluated against the
+// gateway's Prometheus metrics.
+type SLOConfig struct {
+	Enabled bool `yaml:"enabled"`
+
+	// Interval is how often SLOs are evaluated (default: 30s).
+	Interval time.Duration `yaml:"interval"`
+
+	// Window is the compliance period error budgets are measured over
+	// (default: 720h). Samples are kept in memory, so compliance restarts
+	// with the process.
+	Window time.Duration `yaml:"window"`
+
+	Objectives []SLOObjectiveConfig `yaml:"objectives"`
+	Alerts     SLOAlertConfig       `yaml:"alerts"`
+}
+
+// SLOObjectiveConfig is a single objective. Exactly one of Latency or
+// Errors must be set.
+type SLOObjectiveConfig struct {
+	Name string `yaml:"name"`
+
+	// Target is the share of good events, e.g. 0.95.
+	Target float64 `yaml:"target"`
+
+	Latency *SLOLatencyConfig `yaml:"latency"`
+	Errors  *SLOErrorsConfig  `yaml:"errors"`
+}
+
+// SLOLatencyConfig counts histogram observations at or below Threshold as
+// good events.
+type SLOLatencyConfig struct {
+	// Metric is a histogram name (default: nexus_reply_duration_seconds).
+	Metric string `yaml:"metric"`
+
+	// Threshold should match a bucket boundary; otherwise the nearest lower
+	// bucket is used.
+	Threshold time.Duration `yaml:"threshold"`
+
+	// Labels restricts the series, e.g. {channel: slack}.
+	Labels map[string]string `yaml:"labels"`
+}
+
+// SLOErrorsConfig counts counter increments whose Label is one of Values
+// as bad events.
+type SLOErrorsConfig struct {
+	// Metric is a counter name (default: nexus_runs_total).
+	Metric string `yaml:"metric"`
+
+	// Label and Values identify failures (default: outcome in [error]).
+	Label  string   `yaml:"label"`
+	Values []string `yaml:"values"`
+
+	// Labels restricts the series, e.g. {channel: slack}.
+	Labels map[string]string `yaml:"labels"`
+}
+
+// SLOAlertConfig controls burn-rate alerts. Critical fires when both the 1h
+// and 5m burn rates exceed CriticalBurnRate, warning when both the 6h and
+// 30m burn rates exceed WarningBurnRate.
+type SLOAlertConfig struct {
+	// CriticalBurnRate defaults to 14.4 (2% of a 30 day budget in an hour).
+	CriticalBurnRate float64 `yaml:"critical_burn_rate"`
+
+	// WarningBurnRate defaults to 6 (5% of a 30 day budget in six hours).
+	WarningBurnRate float64 `yaml:"warning_burn_rate"`
+
+	// WebhookURL receives a JSON POST when an objective's alert level changes.
+	WebhookURL string `yaml:"webhook_url"`
+}
+
+// AnomalyConfig detects sudden spikes in run errors, token usage, and
+// message volume from a single peer against in-process EWMA baselines.
+type AnomalyConfig struct {
+	Enabled bool `yaml:"enabled"`
+
+	// Interval is the bucket counters are summed over and compared to
+	// their baseline (default: 1m).
+	Interval time.Duration `yaml:"interval"`
+
+	// Alpha is the EWMA smoothing factor; higher adapts faster (default: 0.1).
+	Alpha float64 `yaml:"alpha"`
+
+	// Threshold is the z-score an interval must reach to be anomalous
+	// (default: 4).
+	Threshold float64 `yaml:"threshold"`
+
+	// Warmup is how many intervals a baseline needs before it can alert
+	// (default: 10). Peers without enough history are compared to the
+	// baseline of all peers.
+	Warmup int `yaml:"warmup"`
+
+	// MinErrors, MinTokens, and MinPeerMessages are the smallest interval
+	// counts that can alert, so quiet gateways do not page on noise
+	// (defaults: 5, 50000, 20).
+	MinErrors       int `yaml:"min_errors"`
+	MinTokens       int `yaml:"min_tokens"`
+	MinPeerMessages int `yaml:"min_peer_messages"`
+
+	Alerts AnomalyAlertConfig `yaml:"alerts"`
+}
+
+// AnomalyAlertConfig controls where anomaly alerts go besides the
+// "anomaly.alert" event and the gateway log.
+type AnomalyAlertConfig struct {
+	// WebhookURL receives a JSON POST when an anomaly starts or resolves.
+	WebhookURL string `yaml:"webhook_url"`
+}
+
+// CanaryConfig runs scripted conversations through the gateway on an
+// interval to catch provider or channel breakage before users do.
+type CanaryConfig struct {
+	Enabled bool `yaml:"enabled"`
+
+	// Interval is how often every check runs (default: 5m).
+	Interval time.Duration `yaml:"interval"`
+
+	// Timeout is how long a check waits for the reply (default: 60s).
+	Timeout time.Duration `yaml:"timeout"`
+
+	// Checks are the canary conversations (default: one loopback check
+	// sending "ping" and expecting "pong").
+	Checks []CanaryCheckConfig `yaml:"checks"`
+
+	Alerts CanaryAlertConfig `yaml:"alerts"`
+}
+
+// CanaryCheckConfig is a single canary conversation.
+type CanaryCheckConfig struct {
+	Name string `yaml:"name"`
+
+	// Channel is "loopback" (default), which runs in-process, or the channel
+	// of SessionKey, whose reply is delivered to that conversation.
+	Channel string `yaml:"channel"`
+
+	// SessionKey selects an existing session on Channel, e.g.
+	// "main:telegram:123456". Its last user message supplies the delivery
+	// metadata, as for heartbeats.
+	SessionKey string `yaml:"session_key"`
+
+	// Message is sent as the user (default: "ping").
+	Message string `yaml:"message"`
+
+	// Expect must appear in the reply, ignoring case (default: "pong").
+	Expect string `yaml:"expect"`
+
+	// Timeout overrides CanaryConfig.Timeout.
+	Timeout time.Duration `yaml:"timeout"`
+}
+
+// CanaryAlertConfig controls alerts on failing checks.
+type CanaryAlertConfig struct {
+	// AfterFailures is the number of consecutive failures that raise an
+	// alert (default: 3). A recovery is reported once the check passes.
+	AfterFailures int `yaml:"after_failures"`
+
+	// WebhookURL receives a JSON POST when a check starts failing or recovers.
+	WebhookURL string `yaml:"webhook_url"`
+
+	// Channel and PeerID target an admin conversation for alert messages.
+	Channel string `yaml:"channel"`
+	PeerID  string `yaml:"peer_id"`
+}
+
+// CostReportConfig posts a weekly or monthly report of estimated LLM spend,
+// broken down by provider, model, agent, and user, to an admin conversation.
+type CostReportConfig struct {
+	Enabled bool `yaml:"enabled"`
+
+	// Period is "weekly" (the previous Monday to Sunday) or "monthly" (the
+	// previous calendar month). Default: weekly.
+	Period string `yaml:"period"`
+
+	// Schedule is a cron expression for when the report is posted (default:
+	// "0 9 * * 1" for weekly, "0 9 1 * *" for monthly). The report covers the
+	// last full period before it runs.
+	Schedule string `yaml:"schedule"`
+
+	// Timezone is the IANA zone periods and the schedule are evaluated in
+	// (default: user.timezone, then UTC).
+	Timezone string `yaml:"timezone"`
+
+	// Channel and PeerID identify the admin conversation the report is
+	// posted to.
+	Channel string `yaml:"channel"`
+	PeerID  string `yaml:"peer_id"`
+
+	// PlanUSD is the planned spend for one period. Zero means no plan.
+	PlanUSD float64 `yaml:"plan_usd"`
+
+	// ProviderPlansUSD are planned spends per provider for one period.
+	ProviderPlansUSD map[string]float64 `yaml:"provider_plans_usd"`
+
+	// WarnAt is the fraction of a plan at which the report switches to its
+	// warning format (default: 1, when spend reaches the plan).
+	WarnAt float64 `yaml:"warn_at"`
+
+	// TopN caps the rows listed per breakdown in the message (default: 5).
+	// The attached report lists every row.
+	TopN int `yaml:"top_n"`
+}
+
+// CrashReportingConfig controls how panics in channel adapters, tools, and
+// background workers are recovered and reported.
+type CrashReportingConfig struct {
+	// RestartBackoff is the delay before restarting a crashed component.
+	// It doubles on each consecutive crash up to MaxRestartBackoff.
+	RestartBackoff    time.Duration `yaml:"restart_backoff"`
+	MaxRestartBackoff time.Duration `yaml:"max_restart_backoff"`
+
+	// MaxRestarts stops restarting a component after this many consecutive
+	// crashes. Zero restarts indefinitely.
+	MaxRestarts int `yaml:"max_restarts"`
+
+	// Sentry exports crashes with stack traces when a DSN is set.
+	Sentry SentryConfig `yaml:"sentry"`
+}
+
+// SentryConfig configures the Sentry crash exporter.
+type SentryConfig struct {
+	DSN         string `yaml:"dsn"`
+	Environment string `yaml:"environment"`
+	Release     string `yaml:"release"`
+}
+
+// FeedbackConfig controls capturing reactions on agent replies as run
+// feedback.
+type FeedbackConfig struct {
+	Enabled bool `yaml:"enabled"`
+
+	// StorePath is the JSONL file feedback is appended to
+	// (default: ~/.nexus/feedback.jsonl).
+	StorePath string `yaml:"store_path"`
+
+	// PositiveReactions and NegativeReactions add emoji or platform reaction
+	// names to the built-in thumbs up/down sets.
+	PositiveReactions []string `yaml:"positive_reactions"`
+	NegativeReactions []string `yaml:"negative_reactions"`
 }
 
 // TracingConfig controls OpenTelemetry tracing.
@@ -26,7 +430,29 @@ type TracingConfig struct {
 
 // SecurityConfig configures security features.
 type SecurityConfig struct {
-	Posture SecurityPostureConfig `yaml:"posture"`
+	Posture     SecurityPostureConfig   `yaml:"posture"`
+	Credentials CredentialMonitorConfig `yaml:"credentials"`
+}
+
+// CredentialMonitorConfig controls periodic validation of provider API keys
+// and channel tokens.
+type CredentialMonitorConfig struct {
+	Enabled  bool          `yaml:"enabled"`
+	Interval time.Duration `yaml:"interval"`
+
+	// QuotaWarnRatio warns when a key's remaining quota falls below this
+	// share of its limit (default: 0.1).
+	QuotaWarnRatio float64 `yaml:"quota_warn_ratio"`
+
+	// Alert sends a message to an admin conversation when a credential
+	// starts failing or runs low on quota.
+	Alert CredentialAlertConfig `yaml:"alert"`
+}
+
+// CredentialAlertConfig targets the admin channel for credential alerts.
+type CredentialAlertConfig struct {
+	Channel string `yaml:"channel"`
+	PeerID  string `yaml:"peer_id"`
 }
 
 // SecurityPostureConfig controls continuous security posture auditing.
@@ -80,6 +506,31 @@ type ArtifactConfig struct {
 	// S3SecretAccessKey is the AWS secret access key for S3 authentication.
 	S3SecretAccessKey string `yaml:"s3_secret_access_key"`
 
+	// S3SSE is the server-side encryption for stored objects: "AES256"
+	// (SSE-S3) or "aws:kms" (SSE-KMS). Empty uses the bucket default.
+	S3SSE string `yaml:"s3_sse"`
+
+	// S3KMSKeyID is the KMS key for SSE-KMS (default: the AWS managed key).
+	S3KMSKeyID string `yaml:"s3_kms_key_id"`
+
+	// S3Lifecycle installs bucket lifecycle rules expiring objects after
+	// their TTL and aborting stale multipart uploads.
+	S3Lifecycle bool `yaml:"s3_lifecycle"`
+
+	// S3MultipartThreshold is the size in bytes above which uploads use
+	// multipart (default: 16MB).
+	S3MultipartThreshold int64 `yaml:"s3_multipart_threshold"`
+
+	// S3PartSize is the multipart part size in bytes (default: 8MB, min 5MB).
+	S3PartSize int64 `yaml:"s3_part_size"`
+
+	// S3Presign serves artifact downloads through presigned bucket URLs
+	// instead of proxying them through the gateway.
+	S3Presign bool `yaml:"s3_presign"`
+
+	// S3PresignTTL is how long presigned URLs stay valid (default: 15m).
+	S3PresignTTL time.Duration `yaml:"s3_presign_ttl"`
+
 	// TTLs configures retention period by artifact type.
 	TTLs map[string]time.Duration `yaml:"ttls"`
 
@@ -91,6 +542,77 @@ type ArtifactConfig struct {
 
 	// Redaction configures rules for sensitive artifacts.
 	Redaction ArtifactRedactionConfig `yaml:"redaction"`
+
+	// Links configures signed, expiring download links served by the gateway.
+	Links ArtifactLinksConfig `yaml:"links"`
+
+	// Processing configures validation and transforms applied before storage.
+	Processing ArtifactProcessingConfig `yaml:"processing"`
+}
+
+// ArtifactProcessingConfig controls artifact post-processing.
+type ArtifactProcessingConfig struct {
+	// Enabled toggles processing.
+	Enabled bool `yaml:"enabled"`
+
+	// MIMEValidation compares declared MIME types with sniffed content:
+	// "correct" (default) fixes mismatches, "reject" refuses them, "off" skips.
+	MIMEValidation string `yaml:"mime_validation"`
+
+	// FFmpegPath is the ffmpeg binary used for video transcodes (default: ffmpeg).
+	FFmpegPath string `yaml:"ffmpeg_path"`
+
+	// TranscodeTimeout bounds a single video transcode (default: 2m).
+	TranscodeTimeout time.Duration `yaml:"transcode_timeout"`
+
+	// Types maps artifact types to processing rules; "default" applies to
+	// types without their own entry.
+	Types map[string]ArtifactProcessingRule `yaml:"types"`
+}
+
+// ArtifactProcessingRule configures processing for one artifact type.
+type ArtifactProcessingRule struct {
+	// StripEXIF removes EXIF, XMP, and text metadata from JPEG and PNG images.
+	StripEXIF bool `yaml:"strip_exif"`
+
+	// Thumbnail stores a JPEG preview as a separate "thumbnail" artifact.
+	Thumbnail bool `yaml:"thumbnail"`
+
+	// ThumbnailMaxSide is the longest thumbnail edge in pixels (default: 320).
+	ThumbnailMaxSide int `yaml:"thumbnail_max_side"`
+
+	// Transcode re-encodes videos larger than MaxVideoBytes to H.264 mp4.
+	Transcode bool `yaml:"transcode"`
+
+	// MaxVideoBytes is the size cap for stored videos (default: 25MB).
+	MaxVideoBytes int64 `yaml:"max_video_bytes"`
+
+	// MaxVideoHeight is the transcode resolution cap (default: 720).
+	MaxVideoHeight int `yaml:"max_video_height"`
+
+	// OCR stores text read from images as a separate "ocr_text" artifact.
+	// Requires ocr.enabled.
+	OCR bool `yaml:"ocr"`
+}
+
+// ArtifactLinksConfig controls signed artifact download links.
+type ArtifactLinksConfig struct {
+	// Enabled serves artifacts at /artifacts/{id} for signed links and
+	// authenticated clients.
+	Enabled bool `yaml:"enabled"`
+
+	// BaseURL is the externally reachable gateway URL used in links.
+	// Defaults to http://localhost:<server.http_port>.
+	BaseURL string `yaml:"base_url"`
+
+	// SigningKey signs links. Defaults to auth.jwt_secret.
+	SigningKey string `yaml:"signing_key"`
+
+	// TTL is how long links stay valid when no TTL is requested.
+	TTL time.Duration `yaml:"ttl"`
+
+	// MaxTTL caps requested link lifetimes.
+	MaxTTL time.Duration `yaml:"max_ttl"`
 }
 
 // ArtifactRedactionConfig controls artifact redaction behavior.
@@ -113,7 +635,7 @@ type TranscriptionConfig struct {
 	// Enabled enables/disables transcription globally
 	Enabled bool `yaml:"enabled"`
 
-	// Provider is the transcription provider (e.g., "openai")
+	// Provider is the transcription provider ("openai" or "whispercpp")
 	Provider string `yaml:"provider"`
 
 	// APIKey is the API key for the transcription provider
@@ -128,6 +650,47 @@ type TranscriptionConfig struct {
 	// Language is the default language for transcription (ISO 639-1)
 	// If empty, the provider will auto-detect the language
 	Language string `yaml:"language"`
+
+	// Languages overrides Language per channel (e.g., telegram: de)
+	Languages map[string]string `yaml:"languages"`
+
+	// Diarize labels speakers in voice notes from group conversations
+	Diarize bool `yaml:"diarize"`
+
+	// Local configures the whisper.cpp backend (provider: whispercpp)
+	Local transcribe.WhisperConfig `yaml:"local"`
+}
+
+// LanguageFor returns the language hint for a channel.
+func (c TranscriptionConfig) LanguageFor(channel string) string {
+	if lang := strings.TrimSpace(c.Languages[strings.ToLower(channel)]); lang != "" {
+		return lang
+	}
+	return c.Language
+}
+
+// OCRConfig configures text extraction from images.
+type OCRConfig struct {
+	// Enabled extracts text from inbound images and enables the ocr_image tool
+	Enabled bool `yaml:"enabled"`
+
+	// Provider is the OCR engine ("tesseract" or "google")
+	Provider string `yaml:"provider"`
+
+	// Languages are tesseract language codes (default: [eng])
+	Languages []string `yaml:"languages"`
+
+	// TesseractPath is the tesseract binary (default: tesseract on PATH)
+	TesseractPath string `yaml:"tesseract_path"`
+
+	// APIKey is the Google Cloud Vision API key (provider: google)
+	APIKey string `yaml:"api_key"`
+
+	// BaseURL overrides the Google Cloud Vision endpoint
+	BaseURL string `yaml:"base_url"`
+
+	// Timeout bounds a single extraction (default: 30s)
+	Timeout time.Duration `yaml:"timeout"`
 }
 
 // CronConfig configures scheduled jobs.
@@ -0,0 +1,45 @@
+package config
+
+import "time"
+
+// PrivacyConfig controls data retention and identity erasure.
+type PrivacyConfig struct {
+	Retention RetentionConfig `yaml:"retention"`
+	Erasure   ErasureConfig   `yaml:"erasure"`
+}
+
+// RetentionConfig controls scheduled deletion of stored conversation data.
+type RetentionConfig struct {
+	Enabled  bool          `yaml:"enabled"`
+	Interval time.Duration `yaml:"interval"`
+
+	// Default applies to data not covered by a channel or user override.
+	Default RetentionPolicy `yaml:"default"`
+
+	// Channels overrides the default per channel type (slack, telegram, ...).
+	Channels map[string]RetentionPolicy `yaml:"channels"`
+
+	// Users overrides channel and default policies per peer, keyed by
+	// "provider:peer_id" or by a canonical identity from session.scoping.identity_links.
+	Users map[string]RetentionPolicy `yaml:"users"`
+}
+
+// RetentionPolicy sets the maximum age of each class of data. A zero value
+// keeps data indefinitely in the default policy and inherits the broader
+// policy in an override.
+type RetentionPolicy struct {
+	Messages  time.Duration `yaml:"messages"`
+	Sessions  time.Duration `yaml:"sessions"`
+	Artifacts time.Duration `yaml:"artifacts"`
+	Memory    time.Duration `yaml:"memory"`
+}
+
+// ErasureConfig configures where signed erasure records are written.
+type ErasureConfig struct {
+	// AuditDir holds one signed JSON record per erasure.
+	AuditDir string `yaml:"audit_dir"`
+
+	// SigningKeyPath is the ed25519 key used to sign erasure records. It is
+	// generated on first use when missing.
+	SigningKeyPath string `yaml:"signing_key_path"`
+}
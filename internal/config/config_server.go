@@ -13,6 +13,24 @@ type ServerConfig struct {
 	GRPCPort    int    `yaml:"grpc_port"`
 	HTTPPort    int    `yaml:"http_port"`
 	MetricsPort int    `yaml:"metrics_port"`
+
+	// Diagnostics serves pprof profiles and runtime stats on a separate port.
+	Diagnostics DiagnosticsServerConfig `yaml:"diagnostics"`
+}
+
+// DiagnosticsServerConfig configures the pprof and runtime stats endpoint.
+// Requests need the Token as a bearer token, or a gateway JWT or API key
+// with the admin scope.
+type DiagnosticsServerConfig struct {
+	Enabled bool `yaml:"enabled"`
+
+	// Host defaults to 127.0.0.1. Binding any other address requires
+	// AllowRemote.
+	Host        string `yaml:"host"`
+	Port        int    `yaml:"port"`
+	AllowRemote bool   `yaml:"allow_remote"`
+
+	Token string `yaml:"token"`
 }
 
 // DatabaseConfig configures the primary database connection pool.
@@ -35,6 +53,42 @@ type ClusterConfig struct {
 
 	// SessionLocks controls distributed session locking.
 	SessionLocks SessionLockConfig `yaml:"session_locks"`
+
+	// Coordination controls leader election and work partitioning.
+	Coordination CoordinationConfig `yaml:"coordination"`
+
+	// EventBus controls event propagation between gateway nodes.
+	EventBus EventBusConfig `yaml:"event_bus"`
+}
+
+// EventBusConfig configures the cross-node event bus used to propagate
+// approval decisions, session resets, config changes, and cache invalidation.
+type EventBusConfig struct {
+	// Backend selects the transport: "auto" uses Postgres LISTEN/NOTIFY when
+	// cluster mode is enabled with a database and falls back to in-memory
+	// delivery otherwise; "postgres" requires LISTEN/NOTIFY; "memory" never
+	// leaves the process.
+	Backend string `yaml:"backend"`
+
+	// Channel is the Postgres notification channel name.
+	Channel string `yaml:"channel"`
+}
+
+// CoordinationConfig configures DB-backed cluster coordination. When enabled,
+// singleton subsystems (cron, task maintenance, job pruning) run only on the
+// lease holder and scheduled tasks are partitioned across live nodes.
+type CoordinationConfig struct {
+	// Enabled turns on leader election and work partitioning.
+	Enabled bool `yaml:"enabled"`
+
+	// HeartbeatInterval is how often nodes heartbeat and renew leases.
+	HeartbeatInterval time.Duration `yaml:"heartbeat_interval"`
+
+	// NodeTTL is how long a node is considered live after its last heartbeat.
+	NodeTTL time.Duration `yaml:"node_ttl"`
+
+	// LeaseTTL is how long a leader lease lasts without renewal.
+	LeaseTTL time.Duration `yaml:"lease_ttl"`
 }
 
 // SessionLockConfig configures distributed session locks.
@@ -11,6 +11,108 @@ type SessionConfig struct {
 	MemoryFlush    MemoryFlushConfig    `yaml:"memory_flush"`
 	ContextPruning ContextPruningConfig `yaml:"context_pruning"`
 	Scoping        SessionScopeConfig   `yaml:"scoping"`
+	RunRecovery    RunRecoveryConfig    `yaml:"run_recovery"`
+	Checkpoints    CheckpointConfig     `yaml:"checkpoints"`
+	Catchup        CatchupConfig        `yaml:"catchup"`
+	Language       LanguageConfig       `yaml:"language"`
+}
+
+// LanguageConfig steers the agent to reply in each user's language.
+type LanguageConfig struct {
+	// Enabled instructs the model to reply in the user's language: the one
+	// chosen with /language, set in their identity profile, or detected.
+	Enabled bool `yaml:"enabled"`
+
+	// Detect guesses the language of inbound messages. Defaults to true.
+	Detect *bool `yaml:"detect"`
+
+	// Default is the reply language when none of the above applies. Empty
+	// leaves the choice to the model.
+	Default string `yaml:"default"`
+}
+
+// CatchupConfig controls read-state tracking and /catchup summaries of
+// messages a user missed in a group conversation.
+type CatchupConfig struct {
+	// Enabled turns on read-state tracking and the /catchup command.
+	Enabled bool `yaml:"enabled"`
+
+	// Model overrides the model used for summaries. Defaults to the
+	// session's model.
+	Model string `yaml:"model"`
+
+	// MaxInputTokens caps the missed messages sent to the model; the oldest
+	// are dropped first. Defaults to 4000.
+	MaxInputTokens int `yaml:"max_input_tokens"`
+
+	// MaxOutputTokens caps the length of a summary. Defaults to 400.
+	MaxOutputTokens int `yaml:"max_output_tokens"`
+
+	// Lookback is how far back /catchup reaches for a user with no recorded
+	// activity in the conversation. Defaults to 24h.
+	Lookback time.Duration `yaml:"lookback"`
+
+	// Digest posts a scheduled summary of the messages the agent has not
+	// read to each group conversation.
+	Digest CatchupDigestConfig `yaml:"digest"`
+}
+
+// CatchupDigestConfig schedules catch-up digests.
+type CatchupDigestConfig struct {
+	Enabled bool `yaml:"enabled"`
+	// Schedule is a cron expression (default: "0 9 * * *", daily at 09:00).
+	Schedule string `yaml:"schedule"`
+	// Timezone is the IANA zone the schedule is evaluated in. Defaults to
+	// user.timezone.
+	Timezone string `yaml:"timezone"`
+	// MinMessages is the fewest unread messages worth a digest (default: 10).
+	MinMessages int `yaml:"min_messages"`
+}
+
+// RunRecoveryConfig controls how runs interrupted by a gateway restart are
+// handled on the next startup.
+type RunRecoveryConfig struct {
+	// Enabled journals active runs so they can be recovered after a restart.
+	Enabled bool `yaml:"enabled"`
+
+	// StorePath is the run journal file. Defaults to ~/.nexus/active_runs.json.
+	StorePath string `yaml:"store_path"`
+
+	// Mode is "resume" (default) to continue interrupted runs, or "finalize"
+	// to tell the user the run was interrupted without continuing it.
+	Mode string `yaml:"mode"`
+
+	// MaxAge is how old an interrupted run may be and still be resumed.
+	// Older runs are finalized. Defaults to 30m.
+	MaxAge time.Duration `yaml:"max_age"`
+
+	// MaxAttempts caps how many times a run is resumed before it is
+	// finalized, so a run that crashes the gateway is not retried forever.
+	// Defaults to 1.
+	MaxAttempts int `yaml:"max_attempts"`
+}
+
+// CheckpointConfig controls checkpointing of long runs. A checkpoint holds
+// the full conversation of a run so it can be resumed with
+// "nexus runs resume" after a failure or restart.
+type CheckpointConfig struct {
+	// Enabled saves checkpoints of runs that outlast Interval.
+	Enabled bool `yaml:"enabled"`
+
+	// Dir holds one file per checkpointed run. Defaults to
+	// ~/.nexus/checkpoints.
+	Dir string `yaml:"dir"`
+
+	// Interval is the least time between two checkpoints of a run. Runs that
+	// finish sooner are never checkpointed. Defaults to 2m.
+	Interval time.Duration `yaml:"interval"`
+
+	// MaxAge removes checkpoints not updated for this long. Defaults to 168h.
+	MaxAge time.Duration `yaml:"max_age"`
+
+	// MaxCount keeps at most this many checkpoints, removing the oldest
+	// first. Checkpoints of runs in progress are always kept. Defaults to 50.
+	MaxCount int `yaml:"max_count"`
 }
 
 // SessionScopeConfig controls advanced session scoping behavior.
@@ -54,12 +156,117 @@ type MemoryConfig struct {
 	MaxLines  int    `yaml:"max_lines"`
 	Days      int    `yaml:"days"`
 	Scope     string `yaml:"scope"`
+
+	// Review holds what the agent asks to remember for approval.
+	Review MemoryReviewConfig `yaml:"review"`
+
+	// Consolidation folds old daily memory files into MEMORY.md.
+	Consolidation MemoryConsolidationConfig `yaml:"consolidation"`
+}
+
+// MemoryConsolidationConfig schedules the job that summarizes old daily
+// memory files (directory/YYYY-MM-DD.md) into topic sections of the
+// workspace memory file, archives the originals, and indexes the summaries
+// into vector memory.
+type MemoryConsolidationConfig struct {
+	Enabled bool `yaml:"enabled"`
+	// Schedule is a cron expression (default: "0 3 * * *", daily at 03:00).
+	Schedule string `yaml:"schedule"`
+	// Timezone is the IANA zone the schedule is evaluated in. Defaults to
+	// user.timezone.
+	Timezone string `yaml:"timezone"`
+	// MinAgeDays is how many days old a daily file must be before it is
+	// consolidated (default: 7).
+	MinAgeDays int `yaml:"min_age_days"`
+	// Model is the model that writes the summaries; a cheap one is enough.
+	// Defaults to the gateway's default model.
+	Model string `yaml:"model"`
+	// MaxInputChars caps the daily file content sent in one run; files past
+	// the cap wait for the next run (default: 40000).
+	MaxInputChars int `yaml:"max_input_chars"`
+	// MaxOutputTokens caps the length of the summary (default: 1000).
+	MaxOutputTokens int `yaml:"max_output_tokens"`
+	// ArchiveDir receives the consolidated files. Defaults to the archive
+	// subdirectory of the memory directory.
+	ArchiveDir string `yaml:"archive_dir"`
+	// Batch sends the summary request through the llm.batch queue, at
+	// about half the price; the files are consolidated once it completes.
+	Batch bool `yaml:"batch"`
+}
+
+// MemoryReviewConfig gates memory writes behind user review. Writes the
+// agent makes to the workspace memory file (MEMORY.md) and to vector memory
+// are queued as proposals and stored only once approved in chat or with
+// "nexus memory review".
+type MemoryReviewConfig struct {
+	// Enabled queues memory writes for review.
+	Enabled bool `yaml:"enabled"`
+
+	// Dir holds the review queue. Defaults to ~/.nexus/memory-review.
+	Dir string `yaml:"dir"`
+
+	// AutoApproveScopes lists the scopes stored without review: session,
+	// channel, agent, and global for vector memory, and file for the
+	// workspace memory file. Defaults to [session], since session memories
+	// do not outlive the conversation; set [] to review every write.
+	AutoApproveScopes []string `yaml:"auto_approve_scopes"`
 }
 
 type HeartbeatConfig struct {
 	Enabled bool   `yaml:"enabled"`
 	File    string `yaml:"file"`
 	Mode    string `yaml:"mode"`
+
+	// CheckInterval is how often the scheduler looks for sessions with a
+	// heartbeat due. Defaults to 1m.
+	CheckInterval time.Duration `yaml:"check_interval"`
+
+	// Schedule is the default cadence for proactive heartbeat runs. No
+	// heartbeats are sent on a schedule unless a cadence is set here or in an
+	// agent, session, or /heartbeat override.
+	Schedule HeartbeatScheduleConfig `yaml:"schedule"`
+
+	// Agents overrides Schedule per agent ID.
+	Agents map[string]HeartbeatScheduleConfig `yaml:"agents"`
+
+	// Sessions overrides the agent schedule per session key.
+	Sessions map[string]HeartbeatScheduleConfig `yaml:"sessions"`
+}
+
+// HeartbeatScheduleConfig controls when proactive heartbeat runs fire.
+// Fields left empty in an agent or session override inherit from the
+// broader schedule.
+type HeartbeatScheduleConfig struct {
+	// Disabled turns off scheduled heartbeats for this scope.
+	Disabled bool `yaml:"disabled"`
+
+	// Every runs a heartbeat at a fixed interval, e.g. 4h.
+	Every time.Duration `yaml:"every"`
+
+	// Cron runs a heartbeat on a cron expression, e.g. "0 9,17 * * 1-5".
+	// Setting Every or Cron in an override replaces both.
+	Cron string `yaml:"cron"`
+
+	// Timezone is used for cron expressions and quiet hours. Defaults to
+	// user.timezone, then the gateway's local timezone.
+	Timezone string `yaml:"timezone"`
+
+	// QuietHours suppresses heartbeats during a daily window.
+	QuietHours QuietHoursConfig `yaml:"quiet_hours"`
+
+	// SuppressIfActive skips a heartbeat when the user sent a message within
+	// this window.
+	SuppressIfActive time.Duration `yaml:"suppress_if_active"`
+
+	// Prompt replaces the default heartbeat prompt.
+	Prompt string `yaml:"prompt"`
+}
+
+// QuietHoursConfig is a daily window in HH:MM. The window may cross
+// midnight, e.g. 22:00 to 07:00.
+type QuietHoursConfig struct {
+	Start string `yaml:"start"`
+	End   string `yaml:"end"`
 }
 
 type MemoryFlushConfig struct {
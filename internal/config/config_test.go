@@ -6,6 +6,7 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 )
 
 func TestLoadRejectsUnknownFields(t *testing.T) {
@@ -140,6 +141,229 @@ llm:
 	}
 }
 
+func TestLoadValidatesRunRecoveryMode(t *testing.T) {
+	path := writeConfig(t, `
+session:
+  run_recovery:
+    enabled: true
+    mode: retry
+llm:
+  default_provider: anthropic
+  providers:
+    anthropic: {}
+`)
+
+	_, err := Load(path)
+	if err == nil {
+		t.Fatalf("expected validation error")
+	}
+	if !strings.Contains(err.Error(), "run_recovery.mode") {
+		t.Fatalf("expected run_recovery.mode error, got %v", err)
+	}
+}
+
+func TestLoadCheckpointDefaults(t *testing.T) {
+	path := writeConfig(t, `
+session:
+  checkpoints:
+    enabled: true
+    max_count: 5
+llm:
+  default_provider: anthropic
+  providers:
+    anthropic: {}
+`)
+
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	checkpoints := cfg.Session.Checkpoints
+	if checkpoints.Interval != 2*time.Minute || checkpoints.MaxAge != 7*24*time.Hour || checkpoints.MaxCount != 5 {
+		t.Fatalf("unexpected checkpoint config %+v", checkpoints)
+	}
+}
+
+func TestLoadValidatesCheckpoints(t *testing.T) {
+	path := writeConfig(t, `
+session:
+  checkpoints:
+    enabled: true
+    max_count: -1
+llm:
+  default_provider: anthropic
+  providers:
+    anthropic: {}
+`)
+
+	_, err := Load(path)
+	if err == nil || !strings.Contains(err.Error(), "session.checkpoints.max_count") {
+		t.Fatalf("expected checkpoints.max_count error, got %v", err)
+	}
+}
+
+func TestLoadValidatesHeartbeatSchedule(t *testing.T) {
+	path := writeConfig(t, `
+session:
+  heartbeat:
+    enabled: true
+    schedule:
+      every: 1h
+      cron: "0 9 * * *"
+    agents:
+      main:
+        quiet_hours:
+          start: "10pm"
+          end: "07:00"
+llm:
+  default_provider: anthropic
+  providers:
+    anthropic: {}
+`)
+
+	_, err := Load(path)
+	if err == nil {
+		t.Fatalf("expected validation error")
+	}
+	for _, want := range []string{"session.heartbeat.schedule must set only one of every or cron", "session.heartbeat.agents.main.quiet_hours"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Fatalf("expected %q in error, got %v", want, err)
+		}
+	}
+}
+
+func TestLoadValidatesAttentionDigest(t *testing.T) {
+	path := writeConfig(t, `
+attention:
+  enabled: true
+  digest:
+    enabled: true
+    channel: slack
+    timezone: Mars/Olympus
+llm:
+  default_provider: anthropic
+  providers:
+    anthropic: {}
+`)
+
+	_, err := Load(path)
+	if err == nil {
+		t.Fatalf("expected validation error")
+	}
+	for _, want := range []string{"attention.digest requires both channel and peer_id", "attention.digest.timezone is invalid"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Fatalf("expected %q in error, got %v", want, err)
+		}
+	}
+}
+
+func TestLoadTranscriptionLocalBackend(t *testing.T) {
+	path := writeConfig(t, `
+transcription:
+  enabled: true
+  provider: whispercpp
+  language: en
+  languages:
+    Telegram: de
+  diarize: true
+  local:
+    model_dir: /opt/whisper
+    auto_download: true
+llm:
+  default_provider: anthropic
+  providers:
+    anthropic: {}
+`)
+
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	tc := cfg.Transcription
+	if tc.Model != "base" {
+		t.Fatalf("expected whispercpp default model, got %q", tc.Model)
+	}
+	if got := tc.LanguageFor("telegram"); got != "de" {
+		t.Fatalf("telegram language = %q, want de", got)
+	}
+	if got := tc.LanguageFor("slack"); got != "en" {
+		t.Fatalf("slack language = %q, want en", got)
+	}
+	if !tc.Diarize || !tc.Local.AutoDownload || tc.Local.ModelDir != "/opt/whisper" {
+		t.Fatalf("unexpected transcription config: %+v", tc)
+	}
+}
+
+func TestLoadOCRConfig(t *testing.T) {
+	path := writeConfig(t, `
+ocr:
+  enabled: true
+llm:
+  default_provider: anthropic
+  providers:
+    anthropic: {}
+`)
+
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if cfg.OCR.Provider != "tesseract" || len(cfg.OCR.Languages) != 1 || cfg.OCR.Languages[0] != "eng" {
+		t.Fatalf("unexpected OCR defaults: %+v", cfg.OCR)
+	}
+	if !cfg.Artifacts.Processing.Types["screenshot"].OCR {
+		t.Fatal("expected screenshots to be OCR'd by default")
+	}
+
+	path = writeConfig(t, `
+ocr:
+  enabled: true
+  provider: google
+llm:
+  default_provider: anthropic
+  providers:
+    anthropic: {}
+`)
+	if _, err := Load(path); err == nil || !strings.Contains(err.Error(), "ocr.api_key") {
+		t.Fatalf("expected ocr.api_key error, got %v", err)
+	}
+}
+
+func TestLoadWebWatchConfig(t *testing.T) {
+	path := writeConfig(t, `
+tools:
+  web_watch:
+    enabled: true
+    min_interval: 10m
+llm:
+  default_provider: anthropic
+  providers:
+    anthropic: {}
+`)
+
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	watch := cfg.Tools.WebWatch
+	if watch.CheckInterval != time.Minute || watch.DefaultInterval != time.Hour || watch.MinInterval != 10*time.Minute || watch.MaxPerSession != 20 {
+		t.Fatalf("unexpected web watch config: %+v", watch)
+	}
+
+	path = writeConfig(t, `
+tools:
+  web_watch:
+    max_per_session: -1
+llm:
+  default_provider: anthropic
+  providers:
+    anthropic: {}
+`)
+	if _, err := Load(path); err == nil || !strings.Contains(err.Error(), "tools.web_watch.max_per_session") {
+		t.Fatalf("expected web_watch validation error, got %v", err)
+	}
+}
+
 func TestLoadValidatesMemorySearchMaxResults(t *testing.T) {
 	path := writeConfig(t, `
 tools:
@@ -246,43 +470,44 @@ llm:
 	}
 }
 
-func TestLoadAppliesEnvOverrides(t *testing.T) {
-	t.Setenv("NEXUS_HOST", "127.0.0.1")
-	t.Setenv("NEXUS_GRPC_PORT", "55051")
-	t.Setenv("DATABASE_URL", "postgres://override@localhost:26257/nexus?sslmode=disable")
-
+func TestLoadValidatesAuthAPIKeyScopes(t *testing.T) {
 	path := writeConfig(t, `
-server:
-  host: 0.0.0.0
-  grpc_port: 50051
-database:
-  url: postgres://default@localhost:26257/nexus?sslmode=disable
+auth:
+  api_keys:
+    - key: k1
+      scopes: [sessions:read, approvals:*]
+    - key: k2
+      scopes: [sessions:delete]
 llm:
   default_provider: anthropic
   providers:
     anthropic: {}
 `)
 
-	cfg, err := Load(path)
-	if err != nil {
-		t.Fatalf("Load() error = %v", err)
-	}
-	if cfg.Server.Host != "127.0.0.1" {
-		t.Fatalf("expected host override, got %q", cfg.Server.Host)
+	_, err := Load(path)
+	if err == nil {
+		t.Fatalf("expected validation error")
 	}
-	if cfg.Server.GRPCPort != 55051 {
-		t.Fatalf("expected grpc port override, got %d", cfg.Server.GRPCPort)
+	if !strings.Contains(err.Error(), `auth.api_keys[1].scopes has unknown scope "sessions:delete"`) {
+		t.Fatalf("expected auth.api_keys[1].scopes error, got %v", err)
 	}
-	if cfg.Database.URL != "postgres://override@localhost:26257/nexus?sslmode=disable" {
-		t.Fatalf("expected database url override, got %q", cfg.Database.URL)
+	if strings.Contains(err.Error(), "auth.api_keys[0]") {
+		t.Fatalf("unexpected error for valid scopes: %v", err)
 	}
 }
 
-func TestLoadValidatesWorkspaceMaxChars(t *testing.T) {
+func TestLoadValidatesAuthAPIKeyRoles(t *testing.T) {
 	path := writeConfig(t, `
-workspace:
-  enabled: true
-  max_chars: -5
+auth:
+  api_keys:
+    - key: k1
+      role: operator
+      scopes: [config:read]
+    - key: k2
+      role: superuser
+  audit:
+    enabled: true
+    output: stderr
 llm:
   default_provider: anthropic
   providers:
@@ -293,17 +518,25 @@ llm:
 	if err == nil {
 		t.Fatalf("expected validation error")
 	}
-	if !strings.Contains(err.Error(), "workspace.max_chars") {
-		t.Fatalf("expected workspace.max_chars error, got %v", err)
+	if !strings.Contains(err.Error(), "auth.api_keys[1].role") {
+		t.Fatalf("expected auth.api_keys[1].role error, got %v", err)
+	}
+	if strings.Contains(err.Error(), "auth.api_keys[0]") || strings.Contains(err.Error(), "auth.audit") {
+		t.Fatalf("unexpected error for valid entries: %v", err)
 	}
 }
 
-func TestLoadValidatesApprovalProfile(t *testing.T) {
+func TestLoadValidatesAuthOIDC(t *testing.T) {
 	path := writeConfig(t, `
-tools:
-  execution:
-    approval:
-      profile: invalid
+auth:
+  jwt_secret: secret
+  oidc:
+    enabled: true
+    issuer: https://login.example.com
+    redirect_url: https://nexus.example.com/auth/oidc/callback
+    group_roles:
+      sre: admin
+      eng: owner
 llm:
   default_provider: anthropic
   providers:
@@ -314,43 +547,1778 @@ llm:
 	if err == nil {
 		t.Fatalf("expected validation error")
 	}
-	if !strings.Contains(err.Error(), "approval.profile") {
-		t.Fatalf("expected approval.profile error, got %v", err)
+	for _, want := range []string{"auth.oidc.client_id", `auth.oidc.group_roles["eng"]`} {
+		if !strings.Contains(err.Error(), want) {
+			t.Fatalf("expected %s error, got %v", want, err)
+		}
+	}
+	if strings.Contains(err.Error(), `"sre"`) || strings.Contains(err.Error(), "auth.oidc.issuer") {
+		t.Fatalf("unexpected error for valid entries: %v", err)
 	}
 }
 
-func TestLoadValidApprovalProfile(t *testing.T) {
-	profiles := []string{"coding", "messaging", "readonly", "full", "minimal"}
-	for _, profile := range profiles {
-		t.Run(profile, func(t *testing.T) {
-			path := writeConfig(t, `
-tools:
-  execution:
-    approval:
-      profile: `+profile+`
+func TestLoadValidatesLLMKeys(t *testing.T) {
+	path := writeConfig(t, `
 llm:
   default_provider: anthropic
   providers:
-    anthropic: {}
+    anthropic:
+      api_key: sk-primary
+      rate_limit:
+        requests_per_minute: -1
+      keys:
+        - key: sk-second
+          rate_limit:
+            tokens_per_minute: 40000
+        - id: default
+          key: sk-third
+          rate_limit:
+            max_concurrent: -2
+        - id: both
+          key: sk-fourth
+          key_file: /run/secrets/anthropic
 `)
 
-			if _, err := Load(path); err != nil {
-				t.Fatalf("expected config to load with profile %q, got %v", profile, err)
-			}
-		})
+	_, err := Load(path)
+	if err == nil {
+		t.Fatalf("expected validation error")
+	}
+	for _, want := range []string{`llm.providers.anthropic.keys[1].id "default" is reserved`, "llm.providers.anthropic.keys[2] must set exactly one of key or key_file",
+		"llm.providers.anthropic.rate_limit.requests_per_minute must be >= 0", "llm.providers.anthropic.keys[1].rate_limit.max_concurrent must be >= 0"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Fatalf("expected %q, got %v", want, err)
+		}
+	}
+	if strings.Contains(err.Error(), "keys[0]") {
+		t.Fatalf("unexpected error for valid entry: %v", err)
 	}
 }
 
-func writeConfig(t *testing.T, contents string) string {
-	t.Helper()
-	dir := t.TempDir()
-	path := filepath.Join(dir, "nexus.yaml")
-	trimmed := strings.TrimSpace(contents)
-	if !strings.HasPrefix(trimmed, "version:") {
-		trimmed = fmt.Sprintf("version: %d\n%s", CurrentVersion, trimmed)
+func TestLoadValidatesLLMBatch(t *testing.T) {
+	path := writeConfig(t, `
+llm:
+  default_provider: google
+  batch:
+    enabled: true
+    max_batch_size: -1
+`)
+
+	_, err := Load(path)
+	if err == nil {
+		t.Fatalf("expected validation error")
 	}
-	if err := os.WriteFile(path, []byte(trimmed), 0o644); err != nil {
-		t.Fatalf("WriteFile() error = %v", err)
+	for _, want := range []string{`llm.batch.provider "google" has no batch API`, "llm.batch.max_batch_size must be >= 0"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Fatalf("expected %q, got %v", want, err)
+		}
+	}
+}
+
+func TestLoadValidatesLLMRouting(t *testing.T) {
+	path := writeConfig(t, `
+llm:
+  routing:
+    enabled: true
+    classifier: embeddings
+    rules:
+      - name: short
+        match:
+          max_tokens: 200
+          tools: false
+        target:
+          provider: ollama
+      - name: inverted
+        match:
+          min_tokens: 500
+          max_tokens: 100
+          max_cost_usd: -1
+        target:
+          provider: openai
+`)
+
+	_, err := Load(path)
+	if err == nil {
+		t.Fatalf("expected validation error")
+	}
+	for _, want := range []string{`llm.routing.classifier "embeddings" must be heuristic or llm`,
+		"llm.routing.rules[1].match.min_tokens must not exceed max_tokens", "llm.routing.rules[1].match.max_cost_usd must be >= 0"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Fatalf("expected %q, got %v", want, err)
+		}
+	}
+	if strings.Contains(err.Error(), "rules[0]") {
+		t.Fatalf("unexpected error for valid rule: %v", err)
+	}
+}
+
+func TestLoadLLMRace(t *testing.T) {
+	path := writeConfig(t, `
+llm:
+  default_provider: anthropic
+  providers:
+    anthropic: {}
+  race:
+    enabled: true
+    contenders:
+      - provider: ollama
+        model: llama3.2
+      - provider: anthropic
+    channels: [slack, telegram]
+    max_cost_usd: 0.05
+    daily_budget_usd: 5
+`)
+
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	race := cfg.LLM.Race
+	if !race.Enabled || len(race.Contenders) != 2 || race.Contenders[0].Model != "llama3.2" || race.Contenders[1].Provider != "anthropic" {
+		t.Fatalf("unexpected race config: %+v", race)
+	}
+	if race.MaxCostUSD != 0.05 || race.DailyBudgetUSD != 5 || len(race.Channels) != 2 {
+		t.Fatalf("unexpected race gating: %+v", race)
+	}
+
+	path = writeConfig(t, `
+llm:
+  race:
+    enabled: true
+    contenders:
+      - provider: openai
+        model: gpt-4o-mini
+      - provider: OpenAI
+        model: gpt-4o-mini
+    max_cost_usd: -1
+`)
+	_, err = Load(path)
+	if err == nil {
+		t.Fatalf("expected validation error")
+	}
+	for _, want := range []string{"llm.race.contenders must differ in provider or model", "llm.race.max_cost_usd and daily_budget_usd must be >= 0"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Fatalf("expected %q, got %v", want, err)
+		}
+	}
+
+	path = writeConfig(t, `
+llm:
+  race:
+    enabled: true
+    contenders:
+      - provider: openai
+`)
+	if _, err := Load(path); err == nil || !strings.Contains(err.Error(), "llm.race.contenders must list exactly 2 providers, got 1") {
+		t.Fatalf("expected contender count error, got %v", err)
+	}
+}
+
+func TestLoadValidatesEmailIMAP(t *testing.T) {
+	path := writeConfig(t, `
+channels:
+  email:
+    enabled: true
+    backend: imap
+    address: bot@example.com
+    imap:
+      host: imap.example.com
+      security: ssl
+    oauth2:
+      refresh_token: r-1
+    compose:
+      enabled: true
+llm:
+  default_provider: anthropic
+  providers:
+    anthropic: {}
+`)
+
+	_, err := Load(path)
+	if err == nil {
+		t.Fatalf("expected validation error")
+	}
+	for _, want := range []string{"channels.email.smtp.host", "channels.email.imap.security", "channels.email.oauth2.token_url", "channels.email.client_id"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Fatalf("expected %s error, got %v", want, err)
+		}
+	}
+	if strings.Contains(err.Error(), "channels.email.address") || strings.Contains(err.Error(), "channels.email.compose") {
+		t.Fatalf("unexpected error for valid entries: %v", err)
+	}
+
+	path = writeConfig(t, `
+channels:
+  email:
+    enabled: true
+    backend: imap
+    address: bot@example.com
+    imap:
+      host: imap.example.com
+      password: app-pass
+    smtp:
+      host: smtp.example.com
+llm:
+  default_provider: anthropic
+  providers:
+    anthropic: {}
+`)
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if !*cfg.Channels.Email.IMAP.IDLE || !*cfg.Channels.Email.Compose.RequireApproval {
+		t.Fatalf("expected idle and compose approval to default on")
+	}
+}
+
+func TestLoadValidatesCatchup(t *testing.T) {
+	path := writeConfig(t, `
+session:
+  catchup:
+    max_output_tokens: -1
+    digest:
+      enabled: true
+      timezone: Mars/Olympus
+llm:
+  default_provider: anthropic
+  providers:
+    anthropic: {}
+`)
+
+	_, err := Load(path)
+	if err == nil {
+		t.Fatalf("expected validation error")
+	}
+	for _, want := range []string{"session.catchup.max_output_tokens", "requires session.catchup.enabled", "session.catchup.digest.timezone"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Fatalf("expected %s error, got %v", want, err)
+		}
+	}
+
+	path = writeConfig(t, `
+session:
+  catchup:
+    enabled: true
+llm:
+  default_provider: anthropic
+  providers:
+    anthropic: {}
+`)
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	catchup := cfg.Session.Catchup
+	if catchup.MaxInputTokens != 4000 || catchup.MaxOutputTokens != 400 || catchup.Lookback != 24*time.Hour || catchup.Digest.Schedule != "0 9 * * *" {
+		t.Fatalf("unexpected catchup defaults %+v", catchup)
+	}
+}
+
+func TestLoadValidatesTelegramTopics(t *testing.T) {
+	path := writeConfig(t, `
+channels:
+  telegram:
+    enabled: true
+    bot_token: token
+    channel_posts: true
+    edited_messages: true
+    topics:
+      - chat_id: -1001234567890
+        topic_id: 7
+        agent_id: ops
+      - chat_id: -1001234567890
+        topic_id: 7
+        disabled: true
+      - topic_id: 3
+        agent_id: ops
+      - chat_id: -1001234567890
+        topic_id: 9
+llm:
+  default_provider: anthropic
+  providers:
+    anthropic: {}
+`)
+
+	_, err := Load(path)
+	if err == nil {
+		t.Fatalf("expected validation error")
+	}
+	for _, want := range []string{"topics[1] duplicates", "topics[2].chat_id", "topics[3] must set agent_id or disabled"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Fatalf("expected %s error, got %v", want, err)
+		}
+	}
+}
+
+func TestLoadValidatesMessages(t *testing.T) {
+	path := writeConfig(t, `
+messages:
+  locale: "en us"
+  channels:
+    telegram: es
+    slack: ""
+  templates:
+    error.run_failed:
+      en: "{{.Error"
+llm:
+  default_provider: anthropic
+  providers:
+    anthropic: {}
+`)
+
+	_, err := Load(path)
+	if err == nil {
+		t.Fatalf("expected validation error")
+	}
+	for _, want := range []string{"messages.locale", "messages.channels.slack", "messages.templates"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Fatalf("expected %s error, got %v", want, err)
+		}
+	}
+	if strings.Contains(err.Error(), "messages.channels.telegram") {
+		t.Fatalf("expected telegram locale to be accepted, got %v", err)
+	}
+}
+
+func TestLoadValidatesSQLQuery(t *testing.T) {
+	path := writeConfig(t, `
+tools:
+  sql_query:
+    enabled: true
+    statements: [select, delete]
+    databases:
+      - name: analytics
+        driver: postgres
+        dsn: postgres://reader@db/analytics
+      - name: analytics
+        driver: oracle
+llm:
+  default_provider: anthropic
+  providers:
+    anthropic: {}
+`)
+
+	_, err := Load(path)
+	if err == nil {
+		t.Fatalf("expected validation error")
+	}
+	for _, want := range []string{`"delete" is not a read-only statement`, `databases[1].name "analytics" is duplicated`, "databases[1].driver", "databases[1].dsn is required"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Fatalf("expected %s error, got %v", want, err)
+		}
+	}
+}
+
+func TestLoadValidatesSpreadsheets(t *testing.T) {
+	path := writeConfig(t, `
+tools:
+  spreadsheets:
+    enabled: true
+    google:
+      refresh_token: token
+      spreadsheets:
+        expenses: ""
+llm:
+  default_provider: anthropic
+  providers:
+    anthropic: {}
+`)
+
+	_, err := Load(path)
+	if err == nil {
+		t.Fatalf("expected validation error")
+	}
+	for _, want := range []string{"refresh_token requires client_id and client_secret", "spreadsheets.expenses must be a spreadsheet ID"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Fatalf("expected %s error, got %v", want, err)
+		}
+	}
+}
+
+func TestLoadValidatesSLO(t *testing.T) {
+	path := writeConfig(t, `
+observability:
+  slo:
+    enabled: true
+    objectives:
+      - name: reply_latency
+        target: 95
+        latency:
+          threshold: 15s
+      - name: reply_latency
+        target: 0.99
+        errors: {}
+        latency:
+          threshold: 15s
+llm:
+  default_provider: anthropic
+  providers:
+    anthropic: {}
+`)
+
+	_, err := Load(path)
+	if err == nil {
+		t.Fatalf("expected validation error")
+	}
+	for _, want := range []string{"objectives[0].target must be between 0 and 1", `objectives[1].name "reply_latency" is duplicated`, "objectives[1] must set exactly one of latency or errors"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Fatalf("expected %s error, got %v", want, err)
+		}
+	}
+}
+
+func TestLoadValidatesAnomaly(t *testing.T) {
+	path := writeConfig(t, `
+observability:
+  anomaly:
+    enabled: true
+    alpha: 1.5
+    min_tokens: -1
+    alerts:
+      webhook_url: ftp://example.com
+llm:
+  default_provider: anthropic
+  providers:
+    anthropic: {}
+`)
+
+	_, err := Load(path)
+	if err == nil {
+		t.Fatalf("expected validation error")
+	}
+	for _, want := range []string{"anomaly.alpha must be between 0 and 1", "min_tokens and min_peer_messages must be >= 0", "anomaly.alerts.webhook_url must be an http(s) URL"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Fatalf("expected %s error, got %v", want, err)
+		}
+	}
+}
+
+func TestLoadValidatesDiagnosticsServer(t *testing.T) {
+	path := writeConfig(t, `
+server:
+  diagnostics:
+    enabled: true
+    host: 0.0.0.0
+llm:
+  default_provider: anthropic
+  providers:
+    anthropic: {}
+`)
+
+	_, err := Load(path)
+	if err == nil {
+		t.Fatalf("expected validation error")
+	}
+	for _, want := range []string{`server.diagnostics.host "0.0.0.0" is not a loopback address`, "server.diagnostics requires a token"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Fatalf("expected %s error, got %v", want, err)
+		}
+	}
+}
+
+func TestLoadValidatesCanary(t *testing.T) {
+	path := writeConfig(t, `
+observability:
+  canary:
+    enabled: true
+    checks:
+      - name: telegram
+        channel: telegram
+      - name: telegram
+    alerts:
+      channel: slack
+llm:
+  default_provider: anthropic
+  providers:
+    anthropic: {}
+`)
+
+	_, err := Load(path)
+	if err == nil {
+		t.Fatalf("expected validation error")
+	}
+	for _, want := range []string{
+		`observability.canary.checks[0].session_key is required for channel "telegram"`,
+		`observability.canary.checks[1].name "telegram" is duplicated`,
+		"observability.canary.alerts requires both channel and peer_id",
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Fatalf("expected %s error, got %v", want, err)
+		}
+	}
+}
+
+func TestLoadCostReportDefaults(t *testing.T) {
+	path := writeConfig(t, `
+observability:
+  cost_report:
+    enabled: true
+    period: monthly
+    channel: slack
+    peer_id: C-ADMIN
+    plan_usd: 200
+llm:
+  default_provider: anthropic
+  providers:
+    anthropic: {}
+`)
+
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	report := cfg.Observability.CostReport
+	if report.Schedule != "0 9 1 * *" || report.WarnAt != 1 || report.TopN != 5 {
+		t.Fatalf("unexpected cost report defaults: %+v", report)
+	}
+}
+
+func TestLoadValidatesCostReport(t *testing.T) {
+	path := writeConfig(t, `
+observability:
+  cost_report:
+    enabled: true
+    period: daily
+    channel: slack
+    timezone: Mars/Olympus
+    provider_plans_usd:
+      openai: -5
+llm:
+  default_provider: anthropic
+  providers:
+    anthropic: {}
+`)
+
+	_, err := Load(path)
+	if err == nil {
+		t.Fatalf("expected validation error")
+	}
+	for _, want := range []string{
+		"observability.cost_report.period must be weekly or monthly",
+		"observability.cost_report.provider_plans_usd.openai must be >= 0",
+		"observability.cost_report.timezone is invalid",
+		"observability.cost_report requires both channel and peer_id",
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Fatalf("expected %s error, got %v", want, err)
+		}
+	}
+}
+
+func TestLoadValidatesGitOps(t *testing.T) {
+	path := writeConfig(t, `
+gitops:
+  enabled: true
+  path: ../outside.yaml
+  mode: sometimes
+llm:
+  default_provider: anthropic
+  providers:
+    anthropic: {}
+`)
+
+	_, err := Load(path)
+	if err == nil {
+		t.Fatalf("expected validation error")
+	}
+	for _, want := range []string{
+		"gitops.mode must be \"apply\" or \"report\"",
+		"gitops.path must be relative to the repository root",
+		"gitops.repo is required when gitops is enabled",
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Fatalf("expected %s error, got %v", want, err)
+		}
+	}
+
+	path = writeConfig(t, `
+gitops:
+  repo: https://github.com/acme/infra.git
+llm:
+  default_provider: anthropic
+  providers:
+    anthropic: {}
+`)
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if cfg.GitOps.Path != "nexus.yaml" || cfg.GitOps.Mode != GitOpsModeApply || cfg.GitOps.Interval != time.Minute {
+		t.Fatalf("unexpected gitops defaults: %+v", cfg.GitOps)
+	}
+}
+
+func TestLoadMetricsExporter(t *testing.T) {
+	path := writeConfig(t, `
+observability:
+  metrics:
+    exporter: both
+    otlp:
+      endpoint: otel-collector:4317
+      insecure: true
+      resource_attributes:
+        k8s.cluster.name: prod
+llm:
+  default_provider: anthropic
+  providers:
+    anthropic: {}
+`)
+
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	metrics := cfg.Observability.Metrics
+	if !metrics.PrometheusEnabled() || !metrics.OTLPEnabled() {
+		t.Fatalf("expected both exporters, got %q", metrics.Exporter)
+	}
+	if metrics.OTLP.Protocol != "grpc" || metrics.OTLP.Interval != time.Minute || metrics.OTLP.Timeout != 10*time.Second {
+		t.Fatalf("unexpected otlp defaults %+v", metrics.OTLP)
+	}
+	if metrics.OTLP.ResourceAttributes["k8s.cluster.name"] != "prod" {
+		t.Fatalf("unexpected resource attributes %v", metrics.OTLP.ResourceAttributes)
+	}
+
+	path = writeConfig(t, `
+observability:
+  metrics:
+    exporter: otlp
+    otlp:
+      protocol: thrift
+      interval: 100ms
+llm:
+  default_provider: anthropic
+  providers:
+    anthropic: {}
+`)
+
+	_, err = Load(path)
+	if err == nil {
+		t.Fatalf("expected validation error")
+	}
+	for _, want := range []string{
+		"observability.metrics.otlp.endpoint is required when exporter is otlp",
+		`observability.metrics.otlp.protocol "thrift" is not supported`,
+		"observability.metrics.otlp.interval must be >= 1s",
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Fatalf("expected %s error, got %v", want, err)
+		}
+	}
+}
+
+func TestLoadEventStore(t *testing.T) {
+	path := writeConfig(t, `
+database:
+  url: sqlite:///tmp/nexus.db
+observability:
+  event_store:
+    enabled: true
+    sample_rate: 0.25
+    types: ["run.*", tool.error]
+llm:
+  default_provider: anthropic
+  providers:
+    anthropic: {}
+`)
+
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	store := cfg.Observability.EventStore
+	if store.SampleRate != 0.25 || len(store.Types) != 2 {
+		t.Fatalf("unexpected event store config %+v", store)
+	}
+	if store.Retention != 720*time.Hour || store.BufferSize != 10000 || store.FlushInterval != 5*time.Second {
+		t.Fatalf("unexpected event store defaults %+v", store)
+	}
+
+	path = writeConfig(t, `
+observability:
+  event_store:
+    enabled: true
+    sample_rate: 2
+llm:
+  default_provider: anthropic
+  providers:
+    anthropic: {}
+`)
+
+	_, err = Load(path)
+	if err == nil {
+		t.Fatalf("expected validation error")
+	}
+	for _, want := range []string{
+		"observability.event_store requires database.url to be set",
+		"observability.event_store.sample_rate must be between 0 and 1",
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Fatalf("expected %s error, got %v", want, err)
+		}
+	}
+}
+
+func TestLoadEventBridge(t *testing.T) {
+	path := writeConfig(t, `
+observability:
+  event_bridge:
+    enabled: true
+    backend: kafka
+    format: protobuf
+    events: ["run.*", approval.decided]
+    kafka:
+      rest_url: http://kafka-rest:8082
+llm:
+  default_provider: anthropic
+  providers:
+    anthropic: {}
+`)
+
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	bridge := cfg.Observability.EventBridge
+	if bridge.Subject != "nexus.events" || bridge.BufferSize != 10000 || bridge.Kafka.Timeout != 10*time.Second {
+		t.Fatalf("unexpected event bridge defaults %+v", bridge)
+	}
+	if bridge.Format != "protobuf" || len(bridge.Events) != 2 {
+		t.Fatalf("unexpected event bridge config %+v", bridge)
+	}
+
+	path = writeConfig(t, `
+observability:
+  event_bridge:
+    enabled: true
+    backend: nats
+    format: avro
+llm:
+  default_provider: anthropic
+  providers:
+    anthropic: {}
+`)
+
+	_, err = Load(path)
+	if err == nil {
+		t.Fatalf("expected validation error")
+	}
+	for _, want := range []string{
+		"observability.event_bridge.nats.url is required when backend is nats",
+		`observability.event_bridge.format "avro" is not supported`,
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Fatalf("expected %s error, got %v", want, err)
+		}
+	}
+}
+
+func TestLoadValidatesSandboxSnapshots(t *testing.T) {
+	path := writeConfig(t, `
+tools:
+  sandbox:
+    snapshots:
+      enabled: true
+      max_age: -1h
+      jitter: 1.5
+llm:
+  default_provider: anthropic
+  providers:
+    anthropic: {}
+`)
+
+	_, err := Load(path)
+	if err == nil {
+		t.Fatalf("expected validation error")
+	}
+	for _, want := range []string{
+		"tools.sandbox.snapshots.max_age must be >= 0",
+		"tools.sandbox.snapshots.jitter must be >= 0 and < 1",
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Fatalf("expected %s error, got %v", want, err)
+		}
+	}
+}
+
+func TestLoadValidatesSandboxLanguages(t *testing.T) {
+	path := writeConfig(t, `
+tools:
+  sandbox:
+    languages:
+      python:
+        image: ghcr.io/acme/sandbox-python:3.12
+        tag: "1.4"
+      nodejs:
+        tag: "20"
+      ruby:
+        image: ruby:3
+llm:
+  default_provider: anthropic
+  providers:
+    anthropic: {}
+`)
+
+	_, err := Load(path)
+	if err == nil {
+		t.Fatalf("expected validation error")
+	}
+	for _, want := range []string{
+		`tools.sandbox.languages.python.image "ghcr.io/acme/sandbox-python:3.12" already has a tag or digest`,
+		"tools.sandbox.languages.nodejs.tag requires image",
+		"tools.sandbox.languages.ruby is not a sandbox language",
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Fatalf("expected %s error, got %v", want, err)
+		}
+	}
+}
+
+func TestLoadValidatesSandboxWasm(t *testing.T) {
+	path := writeConfig(t, `
+tools:
+  sandbox:
+    wasm:
+      enabled: true
+      python_home: /opt/python-wasm/lib
+      timeout: -1s
+llm:
+  default_provider: anthropic
+  providers:
+    anthropic: {}
+`)
+
+	_, err := Load(path)
+	if err == nil {
+		t.Fatalf("expected validation error")
+	}
+	for _, want := range []string{
+		"tools.sandbox.wasm requires python_module or javascript_module",
+		"tools.sandbox.wasm.python_home requires python_module",
+		"tools.sandbox.wasm.timeout must be >= 0",
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Fatalf("expected %s error, got %v", want, err)
+		}
+	}
+}
+
+func TestSandboxLanguageImageRef(t *testing.T) {
+	tests := []struct {
+		cfg  SandboxLanguageConfig
+		want string
+	}{
+		{SandboxLanguageConfig{}, ""},
+		{SandboxLanguageConfig{Image: "python:3.12-slim"}, "python:3.12-slim"},
+		{SandboxLanguageConfig{Image: "localhost:5000/sandbox-python", Tag: "1.4"}, "localhost:5000/sandbox-python:1.4"},
+		{SandboxLanguageConfig{Image: "ghcr.io/acme/node", Tag: "sha256:abc"}, "ghcr.io/acme/node@sha256:abc"},
+	}
+	for _, tt := range tests {
+		if got := tt.cfg.ImageRef(); got != tt.want {
+			t.Fatalf("ImageRef(%+v) = %q, want %q", tt.cfg, got, tt.want)
+		}
+	}
+}
+
+func TestLoadValidatesToolSchemaValidation(t *testing.T) {
+	path := writeConfig(t, `
+tools:
+  execution:
+    schema_validation:
+      enabled: true
+      repair_attempts: -1
+      strict: ["exec", " "]
+llm:
+  default_provider: anthropic
+  providers:
+    anthropic: {}
+`)
+
+	_, err := Load(path)
+	if err == nil {
+		t.Fatalf("expected validation error")
+	}
+	for _, want := range []string{
+		"tools.execution.schema_validation.repair_attempts must be >= 0",
+		"tools.execution.schema_validation.strict[1] must not be empty",
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Fatalf("expected %s error, got %v", want, err)
+		}
+	}
+}
+
+func TestLoadDefaultsToolSchemaRepairAttempts(t *testing.T) {
+	path := writeConfig(t, `
+tools:
+  execution:
+    schema_validation:
+      enabled: true
+llm:
+  default_provider: anthropic
+  providers:
+    anthropic: {}
+`)
+
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if cfg.Tools.Execution.SchemaValidation.RepairAttempts != 1 {
+		t.Fatalf("expected repair_attempts to default to 1, got %d", cfg.Tools.Execution.SchemaValidation.RepairAttempts)
+	}
+}
+
+func TestLoadToolLoopDetection(t *testing.T) {
+	path := writeConfig(t, `
+llm:
+  default_provider: anthropic
+  providers:
+    anthropic: {}
+`)
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	loops := cfg.Tools.Execution.LoopDetection
+	if loops.Enabled != nil || loops.MaxRepeats != 5 || loops.MaxStalledIterations != 3 {
+		t.Fatalf("unexpected loop detection defaults: %+v", loops)
+	}
+
+	path = writeConfig(t, `
+tools:
+  execution:
+    loop_detection:
+      max_repeats: -1
+      max_stalled_iterations: -2
+llm:
+  default_provider: anthropic
+  providers:
+    anthropic: {}
+`)
+	_, err = Load(path)
+	if err == nil {
+		t.Fatalf("expected validation error")
+	}
+	for _, want := range []string{
+		"tools.execution.loop_detection.max_repeats must be >= 0",
+		"tools.execution.loop_detection.max_stalled_iterations must be >= 0",
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Fatalf("expected %s error, got %v", want, err)
+		}
+	}
+}
+
+func TestLoadDefaultsLLMReasoningRedact(t *testing.T) {
+	path := writeConfig(t, `
+llm:
+  default_provider: anthropic
+  providers:
+    anthropic: {}
+`)
+
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if cfg.LLM.Reasoning.Redact == nil || !*cfg.LLM.Reasoning.Redact {
+		t.Fatalf("expected llm.reasoning.redact to default to true, got %v", cfg.LLM.Reasoning.Redact)
+	}
+}
+
+func TestLoadValidatesToolRetry(t *testing.T) {
+	path := writeConfig(t, `
+tools:
+  execution:
+    retry:
+      max_backoff: -1s
+      jitter: 1.5
+      retry_on: [timeout, flaky]
+llm:
+  default_provider: anthropic
+  providers:
+    anthropic: {}
+`)
+
+	_, err := Load(path)
+	if err == nil {
+		t.Fatalf("expected validation error")
+	}
+	for _, want := range []string{
+		"tools.execution.retry.max_backoff must be >= 0",
+		"tools.execution.retry.jitter must be between 0 and 1",
+		`tools.execution.retry.retry_on has unknown error class "flaky"`,
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Fatalf("expected %s error, got %v", want, err)
+		}
+	}
+}
+
+func TestLoadValidatesSignalDaemon(t *testing.T) {
+	path := writeConfig(t, `
+channels:
+  signal:
+    enabled: true
+    mode: daemon
+    daemon_address: localhost
+llm:
+  default_provider: anthropic
+  providers:
+    anthropic: {}
+`)
+
+	_, err := Load(path)
+	if err == nil {
+		t.Fatalf("expected validation error")
+	}
+	for _, want := range []string{"channels.signal.account", "channels.signal.daemon_address"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Fatalf("expected %s error, got %v", want, err)
+		}
+	}
+
+	path = writeConfig(t, `
+channels:
+  signal:
+    enabled: true
+    account: "+15555550123"
+    mode: daemon
+    daemon_address: unix:///run/signal-cli/socket
+    reconnect_delay: 10s
+    attachment_max_age: 168h
+llm:
+  default_provider: anthropic
+  providers:
+    anthropic: {}
+`)
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if cfg.Channels.Signal.ReconnectDelay != 10*time.Second {
+		t.Fatalf("reconnect_delay = %v", cfg.Channels.Signal.ReconnectDelay)
+	}
+}
+
+func TestLoadAppliesEnvOverrides(t *testing.T) {
+	t.Setenv("NEXUS_HOST", "127.0.0.1")
+	t.Setenv("NEXUS_GRPC_PORT", "55051")
+	t.Setenv("DATABASE_URL", "postgres://override@localhost:26257/nexus?sslmode=disable")
+
+	path := writeConfig(t, `
+server:
+  host: 0.0.0.0
+  grpc_port: 50051
+database:
+  url: postgres://default@localhost:26257/nexus?sslmode=disable
+llm:
+  default_provider: anthropic
+  providers:
+    anthropic: {}
+`)
+
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if cfg.Server.Host != "127.0.0.1" {
+		t.Fatalf("expected host override, got %q", cfg.Server.Host)
+	}
+	if cfg.Server.GRPCPort != 55051 {
+		t.Fatalf("expected grpc port override, got %d", cfg.Server.GRPCPort)
+	}
+	if cfg.Database.URL != "postgres://override@localhost:26257/nexus?sslmode=disable" {
+		t.Fatalf("expected database url override, got %q", cfg.Database.URL)
+	}
+}
+
+func TestLoadValidatesWorkspaceMaxChars(t *testing.T) {
+	path := writeConfig(t, `
+workspace:
+  enabled: true
+  max_chars: -5
+llm:
+  default_provider: anthropic
+  providers:
+    anthropic: {}
+`)
+
+	_, err := Load(path)
+	if err == nil {
+		t.Fatalf("expected validation error")
+	}
+	if !strings.Contains(err.Error(), "workspace.max_chars") {
+		t.Fatalf("expected workspace.max_chars error, got %v", err)
+	}
+}
+
+func TestLoadValidatesApprovalProfile(t *testing.T) {
+	path := writeConfig(t, `
+tools:
+  execution:
+    approval:
+      profile: invalid
+llm:
+  default_provider: anthropic
+  providers:
+    anthropic: {}
+`)
+
+	_, err := Load(path)
+	if err == nil {
+		t.Fatalf("expected validation error")
+	}
+	if !strings.Contains(err.Error(), "approval.profile") {
+		t.Fatalf("expected approval.profile error, got %v", err)
+	}
+}
+
+func TestLoadValidApprovalProfile(t *testing.T) {
+	profiles := []string{"coding", "messaging", "readonly", "full", "minimal"}
+	for _, profile := range profiles {
+		t.Run(profile, func(t *testing.T) {
+			path := writeConfig(t, `
+tools:
+  execution:
+    approval:
+      profile: `+profile+`
+llm:
+  default_provider: anthropic
+  providers:
+    anthropic: {}
+`)
+
+			if _, err := Load(path); err != nil {
+				t.Fatalf("expected config to load with profile %q, got %v", profile, err)
+			}
+		})
+	}
+}
+
+func TestLoadRejectsClusterWithSQLite(t *testing.T) {
+	path := writeConfig(t, `
+database:
+  url: sqlite:///~/.nexus/nexus.db
+cluster:
+  enabled: true
+llm:
+  default_provider: anthropic
+  providers:
+    anthropic: {}
+`)
+
+	_, err := Load(path)
+	if err == nil {
+		t.Fatalf("expected validation error")
+	}
+	if !strings.Contains(err.Error(), "cluster.enabled") {
+		t.Fatalf("expected cluster.enabled error, got %v", err)
+	}
+}
+
+func TestLoadPrivacyRetention(t *testing.T) {
+	path := writeConfig(t, `
+privacy:
+  retention:
+    enabled: true
+    default:
+      messages: 720h
+    channels:
+      slack:
+        messages: 168h
+    users:
+      telegram:42:
+        memory: 24h
+llm:
+  default_provider: anthropic
+  providers:
+    anthropic: {}
+`)
+
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	retention := cfg.Privacy.Retention
+	if retention.Interval != time.Hour {
+		t.Fatalf("expected default interval 1h, got %v", retention.Interval)
+	}
+	if retention.Channels["slack"].Messages != 168*time.Hour {
+		t.Fatalf("unexpected slack policy %+v", retention.Channels["slack"])
+	}
+	if retention.Users["telegram:42"].Memory != 24*time.Hour {
+		t.Fatalf("unexpected user policy %+v", retention.Users["telegram:42"])
+	}
+	if cfg.Privacy.Erasure.AuditDir == "" || cfg.Privacy.Erasure.SigningKeyPath == "" {
+		t.Fatalf("expected erasure defaults, got %+v", cfg.Privacy.Erasure)
+	}
+}
+
+func TestLoadRejectsNegativeRetention(t *testing.T) {
+	path := writeConfig(t, `
+privacy:
+  retention:
+    channels:
+      slack:
+        sessions: -1h
+llm:
+  default_provider: anthropic
+  providers:
+    anthropic: {}
+`)
+
+	_, err := Load(path)
+	if err == nil {
+		t.Fatalf("expected validation error")
+	}
+	if !strings.Contains(err.Error(), "privacy.retention.channels.slack.sessions") {
+		t.Fatalf("expected retention error, got %v", err)
+	}
+}
+
+func TestLoadEncryptionDefaultsPrimaryKey(t *testing.T) {
+	path := writeConfig(t, `
+encryption:
+  enabled: true
+  keys:
+    - id: "2026-01"
+      key: "AAECAwQFBgcICQoLDA0ODxAREhMUFRYXGBkaGxwdHh8="
+    - id: "2025-06"
+      age_identity_file: /etc/nexus/age.key
+llm:
+  default_provider: anthropic
+  providers:
+    anthropic: {}
+`)
+
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if cfg.Encryption.PrimaryKey != "2026-01" {
+		t.Fatalf("expected primary key to default to the first key, got %q", cfg.Encryption.PrimaryKey)
+	}
+}
+
+func TestLoadRejectsInvalidEncryptionKeys(t *testing.T) {
+	path := writeConfig(t, `
+encryption:
+  enabled: true
+  primary_key: missing
+  keys:
+    - id: a
+      key: "c2hvcnQ="
+    - id: a
+llm:
+  default_provider: anthropic
+  providers:
+    anthropic: {}
+`)
+
+	_, err := Load(path)
+	if err == nil {
+		t.Fatalf("expected validation error")
+	}
+	for _, want := range []string{
+		"encryption.keys[0].key is invalid",
+		"encryption.keys[1].id \"a\" is duplicated",
+		"encryption.keys[1] must set exactly one of key or age_identity_file",
+		"encryption.primary_key \"missing\"",
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Fatalf("expected %q in error, got %v", want, err)
+		}
+	}
+}
+
+func TestLoadArtifactLinks(t *testing.T) {
+	path := writeConfig(t, `
+artifacts:
+  links:
+    enabled: true
+llm:
+  default_provider: anthropic
+  providers:
+    anthropic: {}
+`)
+	if _, err := Load(path); err == nil || !strings.Contains(err.Error(), "artifacts.links requires") {
+		t.Fatalf("expected signing key validation error, got %v", err)
+	}
+
+	path = writeConfig(t, `
+artifacts:
+  links:
+    enabled: true
+    signing_key: secret
+llm:
+  default_provider: anthropic
+  providers:
+    anthropic: {}
+`)
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if cfg.Artifacts.Links.TTL != time.Hour || cfg.Artifacts.Links.MaxTTL != 7*24*time.Hour {
+		t.Fatalf("unexpected link defaults %+v", cfg.Artifacts.Links)
+	}
+}
+
+func TestLoadArtifactS3Options(t *testing.T) {
+	path := writeConfig(t, `
+artifacts:
+  backend: s3
+  s3_bucket: nexus-artifacts
+  s3_sse: AES256
+  s3_kms_key_id: alias/nexus
+  s3_part_size: 1048576
+llm:
+  default_provider: anthropic
+  providers:
+    anthropic: {}
+`)
+	_, err := Load(path)
+	if err == nil {
+		t.Fatal("expected validation errors")
+	}
+	for _, want := range []string{"artifacts.s3_kms_key_id requires", "artifacts.s3_part_size must be"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("expected %q in %v", want, err)
+		}
+	}
+
+	path = writeConfig(t, `
+artifacts:
+  backend: s3
+  s3_bucket: nexus-artifacts
+  s3_sse: aws:kms
+  s3_kms_key_id: alias/nexus
+  s3_lifecycle: true
+  s3_presign: true
+  s3_presign_ttl: 30m
+llm:
+  default_provider: anthropic
+  providers:
+    anthropic: {}
+`)
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if !cfg.Artifacts.S3Lifecycle || !cfg.Artifacts.S3Presign || cfg.Artifacts.S3PresignTTL != 30*time.Minute {
+		t.Fatalf("unexpected s3 options %+v", cfg.Artifacts)
+	}
+}
+
+func TestLoadArtifactProcessing(t *testing.T) {
+	path := writeConfig(t, `
+artifacts:
+  processing:
+    enabled: true
+    mime_validation: strict
+llm:
+  default_provider: anthropic
+  providers:
+    anthropic: {}
+`)
+	if _, err := Load(path); err == nil || !strings.Contains(err.Error(), "artifacts.processing.mime_validation") {
+		t.Fatalf("expected mime_validation error, got %v", err)
+	}
+
+	path = writeConfig(t, `
+artifacts:
+  processing:
+    enabled: true
+    types:
+      recording:
+        transcode: true
+llm:
+  default_provider: anthropic
+  providers:
+    anthropic: {}
+`)
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	rule := cfg.Artifacts.Processing.Types["recording"]
+	if cfg.Artifacts.Processing.MIMEValidation != "correct" || rule.MaxVideoBytes != 25*1024*1024 || rule.MaxVideoHeight != 720 {
+		t.Fatalf("unexpected processing defaults %+v", cfg.Artifacts.Processing)
+	}
+}
+
+func writeConfig(t *testing.T, contents string) string {
+	t.Helper()
+	dir := t.TempDir()
+	path := filepath.Join(dir, "nexus.yaml")
+	trimmed := strings.TrimSpace(contents)
+	if !strings.HasPrefix(trimmed, "version:") {
+		trimmed = fmt.Sprintf("version: %d\n%s", CurrentVersion, trimmed)
+	}
+	if err := os.WriteFile(path, []byte(trimmed), 0o644); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+	return path
+}
+
+func TestLoadRawExtendsAndEnvironments(t *testing.T) {
+	dir := t.TempDir()
+	base := `
+server:
+  host: 0.0.0.0
+  grpc_port: 50051
+session:
+  default_agent_id: main
+environments:
+  prod:
+    server:
+      grpc_port: 443
+`
+	child := `
+extends: base
+server:
+  host: 127.0.0.1
+environments:
+  staging:
+    session:
+      default_agent_id: staging
+`
+	if err := os.WriteFile(filepath.Join(dir, "base.yaml"), []byte(base), 0o644); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+	childPath := filepath.Join(dir, "team.yaml")
+	if err := os.WriteFile(childPath, []byte(child), 0o644); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+
+	raw, err := LoadRawForEnv(childPath, "")
+	if err != nil {
+		t.Fatalf("LoadRawForEnv() error = %v", err)
+	}
+	server := raw["server"].(map[string]any)
+	if server["host"] != "127.0.0.1" || server["grpc_port"] != 50051 {
+		t.Fatalf("expected child host over base port, got %v", server)
+	}
+	if _, ok := raw["environments"]; ok {
+		t.Fatalf("expected environments block to be removed")
+	}
+	if _, ok := raw["extends"]; ok {
+		t.Fatalf("expected extends key to be removed")
+	}
+
+	raw, err = LoadRawForEnv(childPath, "prod")
+	if err != nil {
+		t.Fatalf("LoadRawForEnv(prod) error = %v", err)
+	}
+	server = raw["server"].(map[string]any)
+	if server["grpc_port"] != 443 || server["host"] != "127.0.0.1" {
+		t.Fatalf("expected inherited prod overlay, got %v", server)
+	}
+
+	raw, err = LoadRawForEnv(childPath, "staging")
+	if err != nil {
+		t.Fatalf("LoadRawForEnv(staging) error = %v", err)
+	}
+	if got := raw["session"].(map[string]any)["default_agent_id"]; got != "staging" {
+		t.Fatalf("default_agent_id = %v, want staging", got)
+	}
+}
+
+func TestLoadRawExtendsCycle(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "a.yaml"), []byte("extends: b\n"), 0o644); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "b.yaml"), []byte("extends: a\n"), 0o644); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+	if _, err := LoadRaw(filepath.Join(dir, "a.yaml")); err == nil || !strings.Contains(err.Error(), "cycle") {
+		t.Fatalf("expected cycle error, got %v", err)
+	}
+}
+
+func TestLoadAppliesNexusEnv(t *testing.T) {
+	path := writeConfig(t, `
+server:
+  http_port: 8080
+llm:
+  default_provider: anthropic
+  providers:
+    anthropic: {}
+environments:
+  dev:
+    server:
+      http_port: 9090
+`)
+	t.Setenv(EnvironmentVar, "dev")
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if cfg.Server.HTTPPort != 9090 {
+		t.Fatalf("HTTPPort = %d, want 9090", cfg.Server.HTTPPort)
+	}
+}
+
+func TestLoadOutboundBroadcast(t *testing.T) {
+	path := writeConfig(t, `
+gateway:
+  broadcast:
+    outbound:
+      lists:
+        team:
+          - channel: slack
+            peer_id: C123
+            label: "#general"
+          - channel: telegram
+            peer_id: "42"
+            format: plain
+llm:
+  default_provider: anthropic
+  providers:
+    anthropic: {}
+`)
+
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	outbound := cfg.Gateway.Broadcast.Outbound
+	if outbound.MaxAttempts != 3 || outbound.RetryDelay != 2*time.Second {
+		t.Fatalf("unexpected defaults %+v", outbound)
+	}
+	if team := outbound.Lists["team"]; len(team) != 2 || team[1].Format != "plain" || team[0].Label != "#general" {
+		t.Fatalf("unexpected list %+v", team)
+	}
+}
+
+func TestLoadRejectsInvalidBroadcastTargets(t *testing.T) {
+	path := writeConfig(t, `
+gateway:
+  broadcast:
+    outbound:
+      lists:
+        team:
+          - channel: slack
+          - channel: telegram
+            peer_id: "42"
+            format: html
+llm:
+  default_provider: anthropic
+  providers:
+    anthropic: {}
+`)
+
+	_, err := Load(path)
+	if err == nil {
+		t.Fatalf("expected validation error")
+	}
+	for _, want := range []string{"lists.team[0] requires channel and peer_id", "lists.team[1].format"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Fatalf("expected %q in %v", want, err)
+		}
+	}
+}
+
+func TestLoadGroupEtiquette(t *testing.T) {
+	path := writeConfig(t, `
+channels:
+  telegram:
+    group:
+      activation: interject
+      mention_patterns: ["\\bnexus\\b"]
+      cooldown: 10m
+      interject:
+        min_relevance: 0.8
+llm:
+  default_provider: anthropic
+  providers:
+    anthropic: {}
+`)
+
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	group := cfg.Channels.Telegram.Group
+	if group.Activation != "interject" || group.Cooldown != 10*time.Minute || len(group.MentionPatterns) != 1 {
+		t.Fatalf("unexpected group config %+v", group)
+	}
+	if group.Interject.Probability != 0.25 || group.Interject.MinRelevance != 0.8 {
+		t.Fatalf("unexpected interject config %+v", group.Interject)
+	}
+	if cfg.Channels.Discord.Group.Activation != "always" {
+		t.Fatalf("expected default activation, got %q", cfg.Channels.Discord.Group.Activation)
+	}
+}
+
+func TestLoadRejectsInvalidGroupEtiquette(t *testing.T) {
+	path := writeConfig(t, `
+channels:
+  slack:
+    group:
+      activation: sometimes
+      mention_patterns: ["(nexus"]
+      interject:
+        probability: 2
+llm:
+  default_provider: anthropic
+  providers:
+    anthropic: {}
+`)
+
+	_, err := Load(path)
+	if err == nil {
+		t.Fatalf("expected validation error")
+	}
+	for _, want := range []string{"channels.slack.group.activation", "channels.slack.group.mention_patterns", "channels.slack.group.interject.probability"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Fatalf("expected %q in %v", want, err)
+		}
+	}
+}
+
+func TestLoadDelivery(t *testing.T) {
+	path := writeConfig(t, `
+delivery:
+  enabled: true
+  quiet_hours:
+    start: "22:00"
+    end: "07:00"
+  types:
+    alert:
+      during_quiet_hours: send
+    reminder:
+      channels: [telegram, slack]
+llm:
+  default_provider: anthropic
+  providers:
+    anthropic: {}
+`)
+
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	delivery := cfg.Delivery
+	if delivery.Strategy != "preferred" || delivery.DuringQuietHours != "defer" || delivery.Fallback == nil || !*delivery.Fallback {
+		t.Fatalf("unexpected delivery defaults %+v", delivery)
+	}
+	if delivery.Types["alert"].DuringQuietHours != "send" || len(delivery.Types["reminder"].Channels) != 2 {
+		t.Fatalf("unexpected type overrides %+v", delivery.Types)
+	}
+}
+
+func TestLoadRejectsInvalidDelivery(t *testing.T) {
+	path := writeConfig(t, `
+delivery:
+  strategy: loudest
+  quiet_hours:
+    start: "22:00"
+  types:
+    newsletter: {}
+    digest:
+      during_quiet_hours: later
+llm:
+  default_provider: anthropic
+  providers:
+    anthropic: {}
+`)
+
+	_, err := Load(path)
+	if err == nil {
+		t.Fatalf("expected validation error")
+	}
+	for _, want := range []string{"delivery.strategy", "delivery.quiet_hours", "delivery.types.newsletter", "delivery.types.digest.during_quiet_hours"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Fatalf("expected %q in %v", want, err)
+		}
+	}
+}
+
+func TestLoadWebhookHooksPerMappingAuth(t *testing.T) {
+	path := writeConfig(t, `
+gateway:
+  webhook_hooks:
+    enabled: true
+    mappings:
+      - path: github
+        handler: agent
+        secret: s3cret
+        channel: slack
+      - path: open
+        handler: agent
+llm:
+  default_provider: anthropic
+  providers:
+    anthropic: {}
+`)
+	_, err := Load(path)
+	if err == nil {
+		t.Fatal("expected validation errors")
+	}
+	for _, want := range []string{"gateway.webhook_hooks.token is required unless", "mappings[0].channel_id is required"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("expected %q in %v", want, err)
+		}
+	}
+
+	path = writeConfig(t, `
+gateway:
+  webhook_hooks:
+    enabled: true
+    mappings:
+      - path: github
+        handler: agent
+        secret: s3cret
+        channel: slack
+        channel_id: C123
+        rate_limit: 10
+llm:
+  default_provider: anthropic
+  providers:
+    anthropic: {}
+`)
+	if _, err := Load(path); err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+}
+
+func TestLoadToolDrafts(t *testing.T) {
+	path := writeConfig(t, `
+llm:
+  default_provider: anthropic
+  providers:
+    anthropic: {}
+tools:
+  drafts:
+    enabled: true
+`)
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	drafts := cfg.Tools.Drafts
+	if !drafts.Enabled || len(drafts.Tools) != 1 || drafts.Tools[0] != "email_compose" || drafts.TTL != time.Hour {
+		t.Fatalf("unexpected draft defaults: %+v", drafts)
+	}
+
+	path = writeConfig(t, `
+tools:
+  drafts:
+    enabled: true
+    tools: ["servicenow_[", ""]
+    ttl: -1m
+`)
+	_, err = Load(path)
+	if err == nil {
+		t.Fatal("expected validation error")
+	}
+	for _, want := range []string{"tools.drafts.ttl must be >= 0", "tools.drafts.tools[0] must be a tool name or glob", "tools.drafts.tools[1]"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Fatalf("expected %q, got %v", want, err)
+		}
 	}
-	return path
 }
@@ -1,6 +1,9 @@
 package config
 
-import "time"
+import (
+	"strings"
+	"time"
+)
 
 type ToolsConfig struct {
 	Sandbox      SandboxConfig       `yaml:"sandbox"`
@@ -8,6 +11,7 @@ type ToolsConfig struct {
 	ComputerUse  ComputerUseConfig   `yaml:"computer_use"`
 	WebSearch    WebSearchConfig     `yaml:"websearch"`
 	WebFetch     WebFetchConfig      `yaml:"web_fetch"`
+	WebWatch     WebWatchConfig      `yaml:"web_watch"`
 	MemorySearch MemorySearchConfig  `yaml:"memory_search"`
 	FactExtract  FactExtractConfig   `yaml:"fact_extraction"`
 	Links        LinksConfig         `yaml:"links"`
@@ -18,6 +22,10 @@ type ToolsConfig struct {
 	Elevated     ElevatedConfig      `yaml:"elevated"`
 	Jobs         ToolJobsConfig      `yaml:"jobs"`
 	ServiceNow   ServiceNowConfig    `yaml:"servicenow"`
+	SQLQuery     SQLQueryConfig      `yaml:"sql_query"`
+	Spreadsheets SpreadsheetsConfig  `yaml:"spreadsheets"`
+	Plan         PlanToolConfig      `yaml:"plan"`
+	Drafts       ToolDraftsConfig    `yaml:"drafts"`
 }
 
 // ToolPoliciesConfig defines default allow/deny policies for tools.
@@ -48,6 +56,45 @@ type ToolExecutionConfig struct {
 	Async           []string              `yaml:"async"`
 	Approval        ApprovalConfig        `yaml:"approval"`
 	ResultGuard     ToolResultGuardConfig `yaml:"result_guard"`
+
+	// SchemaValidation checks tool call arguments against each tool's
+	// JSON Schema before execution.
+	SchemaValidation ToolSchemaValidationConfig `yaml:"schema_validation"`
+
+	// Retry shapes retries of failed calls to idempotent tools. MaxAttempts
+	// and RetryBackoff set the attempt count and base backoff.
+	Retry ToolRetryConfig `yaml:"retry"`
+
+	// EarlyStart runs tool calls as soon as the model has finished
+	// streaming them, before the rest of its turn arrives.
+	EarlyStart ToolEarlyStartConfig `yaml:"early_start"`
+
+	// LoopDetection stops runs that keep repeating the same tool calls.
+	LoopDetection ToolLoopDetectionConfig `yaml:"loop_detection"`
+}
+
+// ToolLoopDetectionConfig controls stopping runs that loop on tool calls.
+type ToolLoopDetectionConfig struct {
+	// Enabled gates loop detection. When nil, it is enabled.
+	Enabled *bool `yaml:"enabled"`
+
+	// MaxRepeats is how many times a run may call a tool with identical
+	// arguments before it is stopped. Defaults to 5.
+	MaxRepeats int `yaml:"max_repeats"`
+
+	// MaxStalledIterations is how many iterations in a row may only repeat
+	// earlier calls and get the same results. Defaults to 3.
+	MaxStalledIterations int `yaml:"max_stalled_iterations"`
+}
+
+// ToolEarlyStartConfig controls starting tool calls before the model turn
+// ends. Only calls that would run without approval are started early.
+type ToolEarlyStartConfig struct {
+	Enabled bool `yaml:"enabled"`
+
+	// Tools limits early starts to these tools, e.g. read-only ones.
+	// Supports patterns like "mcp:*". Empty allows every tool.
+	Tools []string `yaml:"tools"`
 }
 
 // ApprovalConfig controls tool approval behavior.
@@ -93,6 +140,39 @@ type ToolResultGuardConfig struct {
 	SanitizeSecrets bool     `yaml:"sanitize_secrets"` // Applies builtin secret detection patterns
 }
 
+// ToolSchemaValidationConfig controls validation of tool call arguments.
+type ToolSchemaValidationConfig struct {
+	Enabled bool `yaml:"enabled"`
+
+	// RepairAttempts is how many times per run an invalid call to a tool is
+	// sent back to the model with the validation errors. Defaults to 1.
+	RepairAttempts int `yaml:"repair_attempts"`
+
+	// Strict lists tools whose invalid calls are never executed and whose
+	// undeclared arguments are rejected. Supports patterns like "mcp:*".
+	Strict []string `yaml:"strict"`
+}
+
+// ToolRetryConfig controls automatic retries of failed tool calls. Only
+// tools that declare themselves idempotent, or match Idempotent, are retried.
+type ToolRetryConfig struct {
+	// MaxBackoff caps exponential backoff. When zero, every retry waits
+	// retry_backoff.
+	MaxBackoff time.Duration `yaml:"max_backoff"`
+
+	// Jitter spreads each backoff by up to this fraction (0-1).
+	Jitter float64 `yaml:"jitter"`
+
+	// RetryOn limits retries to these error classes: timeout, network,
+	// rate_limit, execution, permission, invalid_input, not_found, panic,
+	// unknown. Empty retries any error.
+	RetryOn []string `yaml:"retry_on"`
+
+	// Idempotent lists additional tools that are safe to retry, such as MCP
+	// tools whose servers declare no hints. Supports patterns like "mcp:*".
+	Idempotent []string `yaml:"idempotent"`
+}
+
 // ElevatedConfig controls elevated tool execution behavior and allowlists.
 type ElevatedConfig struct {
 	// Enabled gates elevated execution. When nil, elevated is disabled by default.
@@ -120,6 +200,14 @@ type SandboxConfig struct {
 	Snapshots      SandboxSnapshotConfig `yaml:"snapshots"`
 	Daytona        SandboxDaytonaConfig  `yaml:"daytona"`
 
+	// Languages pins the image each language runs in, keyed by python,
+	// nodejs, go, or bash. Languages not listed use the built-in images.
+	Languages map[string]SandboxLanguageConfig `yaml:"languages"`
+
+	// Wasm runs small Python and JavaScript snippets in-process with wazero
+	// instead of the configured backend.
+	Wasm SandboxWasmConfig `yaml:"wasm"`
+
 	// Mode controls which agents use sandboxing:
 	// - "off": sandboxing disabled (default when enabled=false)
 	// - "all": all agents use sandboxing
@@ -157,11 +245,65 @@ type SandboxDaytonaConfig struct {
 	AutoDelete     *time.Duration `yaml:"auto_delete_interval"`
 }
 
+// SandboxLanguageConfig selects the image for one sandbox language.
+type SandboxLanguageConfig struct {
+	// Image is the container image for the docker and daytona backends,
+	// e.g. "ghcr.io/acme/sandbox-python" or "python:3.12-slim".
+	Image string `yaml:"image"`
+
+	// Tag pins Image to a tag or, with a "sha256:" prefix, a digest. Leave
+	// it empty when Image already carries one.
+	Tag string `yaml:"tag"`
+
+	// RootFS is the ext4 root filesystem the firecracker backend boots for
+	// the language.
+	RootFS string `yaml:"rootfs"`
+}
+
+// ImageRef returns Image pinned to Tag, or "" when no image is set.
+func (c SandboxLanguageConfig) ImageRef() string {
+	image := strings.TrimSpace(c.Image)
+	tag := strings.TrimSpace(c.Tag)
+	if image == "" || tag == "" {
+		return image
+	}
+	if strings.HasPrefix(tag, "sha256:") {
+		return image + "@" + tag
+	}
+	return image + ":" + tag
+}
+
+// SandboxWasmConfig configures the WASI fast path for small snippets.
+type SandboxWasmConfig struct {
+	Enabled bool `yaml:"enabled"`
+
+	// PythonModule is a CPython WASI build; PythonHome is its standard
+	// library directory, mounted read-only, when the build needs one.
+	PythonModule string `yaml:"python_module"`
+	PythonHome   string `yaml:"python_home"`
+
+	// JavaScriptModule is a QuickJS WASI build.
+	JavaScriptModule string `yaml:"javascript_module"`
+
+	// MaxCodeBytes is the largest snippet routed to WASM (default 4096).
+	MaxCodeBytes int `yaml:"max_code_bytes"`
+
+	// MaxMemory caps each snippet's memory, e.g. "128MB" (the default).
+	MaxMemory string `yaml:"max_memory"`
+
+	// Timeout caps each snippet's run time (default 5s).
+	Timeout time.Duration `yaml:"timeout"`
+}
+
 // SandboxSnapshotConfig controls Firecracker snapshot behavior.
 type SandboxSnapshotConfig struct {
 	Enabled         bool          `yaml:"enabled"`
+	Dir             string        `yaml:"dir"`
 	RefreshInterval time.Duration `yaml:"refresh_interval"`
 	MaxAge          time.Duration `yaml:"max_age"`
+	// Jitter randomizes each refresh interval by up to this fraction so
+	// gateways sharing a schedule do not snapshot at the same moment.
+	Jitter float64 `yaml:"jitter"`
 }
 
 type ResourceLimits struct {
@@ -189,6 +331,34 @@ type FactExtractConfig struct {
 	MaxFacts int  `yaml:"max_facts"`
 }
 
+// PlanToolConfig configures the plan tool, a structured to-do list the
+// agent keeps for long tasks.
+type PlanToolConfig struct {
+	// Enabled registers the plan tool.
+	Enabled bool `yaml:"enabled"`
+	// Progress shows the plan as a checklist message that is edited as steps
+	// change, on channels that can edit messages (default: true).
+	Progress *bool `yaml:"progress"`
+}
+
+// ProgressEnabled reports whether plan progress is shown in channels.
+func (c PlanToolConfig) ProgressEnabled() bool {
+	return c.Progress == nil || *c.Progress
+}
+
+// ToolDraftsConfig holds calls to tools that send external communications
+// as drafts the user approves, edits, or cancels before anything is sent.
+type ToolDraftsConfig struct {
+	// Enabled turns draft mode on.
+	Enabled bool `yaml:"enabled"`
+	// Tools lists the drafted tools by name or glob, e.g. "servicenow_*"
+	// (default: [email_compose]).
+	Tools []string `yaml:"tools"`
+	// TTL is how long a draft waits for approval before it expires
+	// unsent (default: 1h).
+	TTL time.Duration `yaml:"ttl"`
+}
+
 type BrowserConfig struct {
 	Enabled  bool   `yaml:"enabled"`
 	Headless bool   `yaml:"headless"`
@@ -207,6 +377,24 @@ type WebFetchConfig struct {
 	MaxChars int  `yaml:"max_chars"`
 }
 
+// WebWatchConfig controls the watch_url tool, which checks pages on a
+// schedule and notifies the session that registered them.
+type WebWatchConfig struct {
+	Enabled bool `yaml:"enabled"`
+
+	// CheckInterval is how often due watches are looked for. Default: 1m.
+	CheckInterval time.Duration `yaml:"check_interval"`
+
+	// DefaultInterval applies when a watch does not set one. Default: 1h.
+	DefaultInterval time.Duration `yaml:"default_interval"`
+
+	// MinInterval is the shortest interval a watch may use. Default: 5m.
+	MinInterval time.Duration `yaml:"min_interval"`
+
+	// MaxPerSession limits watches per session. Default: 20.
+	MaxPerSession int `yaml:"max_per_session"`
+}
+
 // ToolJobsConfig controls async tool job persistence.
 type ToolJobsConfig struct {
 	// Retention is how long to keep completed jobs. Default: 24h.
@@ -235,13 +423,18 @@ type LinksConfig struct {
 	// Models are the link processing model configurations.
 	Models []LinkModelConfig `yaml:"models"`
 
+	// SummaryCacheTTL is how long "llm" summaries are cached per URL.
+	// Default: 24h.
+	SummaryCacheTTL time.Duration `yaml:"summary_cache_ttl"`
+
 	// Scope controls which channels can use link understanding.
 	Scope *LinkScopeConfig `yaml:"scope"`
 }
 
 // LinkModelConfig defines a link processing model.
 type LinkModelConfig struct {
-	// Type is the model type: "cli".
+	// Type is the model type: "cli" runs Command; "llm" fetches the page
+	// (rendering it in the browser pool when needed) and summarizes it.
 	Type string `yaml:"type"`
 
 	// Command is the CLI command to execute.
@@ -254,6 +447,21 @@ type LinkModelConfig struct {
 
 	// TimeoutSeconds overrides the default timeout for this model.
 	TimeoutSeconds int `yaml:"timeout_seconds"`
+
+	// Provider is the LLM provider for "llm" models (default: llm.default_provider).
+	Provider string `yaml:"provider"`
+
+	// Model is the summarization model; pick a cheap one (default: the provider's).
+	Model string `yaml:"model"`
+
+	// Prompt overrides the summarization instructions.
+	Prompt string `yaml:"prompt"`
+
+	// MaxInputChars caps the page text sent to the model. Default: 12000.
+	MaxInputChars int `yaml:"max_input_chars"`
+
+	// MaxTokens caps the summary length. Default: 300.
+	MaxTokens int `yaml:"max_tokens"`
 }
 
 // LinkScopeConfig controls which channels can use link understanding.
@@ -290,6 +498,57 @@ type MemorySearchEmbeddingsConfig struct {
 	Timeout  time.Duration `yaml:"timeout"`
 }
 
+// SQLQueryConfig configures the sql_query tool, which runs read-only
+// queries against external databases.
+type SQLQueryConfig struct {
+	Enabled   bool                `yaml:"enabled"`
+	Databases []SQLDatabaseConfig `yaml:"databases"`
+	// Statements are the allowed leading keywords, from select, with,
+	// values, explain, show and describe. Defaults to select and with.
+	Statements []string `yaml:"statements"`
+	// MaxRows caps the rows returned per query (default: 200).
+	MaxRows int `yaml:"max_rows"`
+	// MaxBytes caps the size of a markdown result (default: 64KB).
+	MaxBytes int `yaml:"max_bytes"`
+	// Timeout bounds each query (default: 10s).
+	Timeout time.Duration `yaml:"timeout"`
+}
+
+// SQLDatabaseConfig is a database the sql_query tool can query.
+type SQLDatabaseConfig struct {
+	Name string `yaml:"name"`
+	// Driver is postgres, mysql or sqlite.
+	Driver string `yaml:"driver"`
+	DSN    string `yaml:"dsn"`
+	// Description tells the model what the database holds.
+	Description string `yaml:"description"`
+}
+
+// SpreadsheetsConfig configures the spreadsheet tools: Google Sheets access
+// and reading and generating XLSX files.
+type SpreadsheetsConfig struct {
+	Enabled bool               `yaml:"enabled"`
+	Google  GoogleSheetsConfig `yaml:"google"`
+	// MaxCells caps the cells returned by a read (default: 2000).
+	MaxCells int `yaml:"max_cells"`
+}
+
+// GoogleSheetsConfig configures Google Sheets access. The Sheets tools are
+// registered when a service account key or a refresh token is set.
+type GoogleSheetsConfig struct {
+	// CredentialsFile is a service account key file. The service account
+	// can only open spreadsheets shared with its email address.
+	CredentialsFile string `yaml:"credentials_file"`
+	// ClientID, ClientSecret and RefreshToken authorize as a Google user
+	// instead of a service account.
+	ClientID     string `yaml:"client_id"`
+	ClientSecret string `yaml:"client_secret"`
+	RefreshToken string `yaml:"refresh_token"`
+	// Spreadsheets maps names the model can use to spreadsheet IDs.
+	Spreadsheets map[string]string `yaml:"spreadsheets"`
+	Timeout      time.Duration     `yaml:"timeout"`
+}
+
 type ServiceNowConfig struct {
 	Enabled     bool   `yaml:"enabled"`
 	InstanceURL string `yaml:"instance_url"`
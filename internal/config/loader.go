@@ -12,18 +12,39 @@ import (
 	"gopkg.in/yaml.v3"
 )
 
-const includeKey = "$include"
+const (
+	includeKey      = "$include"
+	extendsKey      = "extends"
+	environmentsKey = "environments"
+)
+
+// EnvironmentVar selects the environment overlay applied by LoadRaw.
+const EnvironmentVar = "NEXUS_ENV"
 
-// LoadRaw reads a configuration file into a merged raw map, resolving $include directives.
+// LoadRaw reads a configuration file into a merged raw map, resolving extends
+// and $include directives and applying the overlay for the environment named
+// by NEXUS_ENV.
 func LoadRaw(path string) (map[string]any, error) {
+	return LoadRawForEnv(path, os.Getenv(EnvironmentVar))
+}
+
+// LoadRawForEnv is like LoadRaw but applies the overlay for env. An empty env
+// applies no overlay.
+func LoadRawForEnv(path, env string) (map[string]any, error) {
 	if strings.TrimSpace(path) == "" {
 		return nil, fmt.Errorf("config path is required")
 	}
 	seen := map[string]bool{}
-	return loadRawRecursive(path, seen)
+	raw, err := loadRawRecursive(path, seen)
+	if err != nil {
+		return nil, err
+	}
+	return applyEnvironment(raw, env)
 }
 
-// loadRawRecursive loads a config file, resolving $include directives with cycle detection.
+// loadRawRecursive loads a config file, resolving extends and $include
+// directives with cycle detection. The parent named by extends is merged
+// first, then includes, then the file itself.
 func loadRawRecursive(path string, seen map[string]bool) (map[string]any, error) {
 	absPath, err := filepath.Abs(path)
 	if err != nil {
@@ -45,14 +66,25 @@ func loadRawRecursive(path string, seen map[string]bool) (map[string]any, error)
 		return nil, err
 	}
 
+	parent, err := extractExtends(raw)
+	if err != nil {
+		return nil, err
+	}
 	includes, err := extractIncludes(raw)
 	if err != nil {
 		return nil, err
 	}
 
 	merged := map[string]any{}
+	baseDir := filepath.Dir(absPath)
+	if parent != "" {
+		parentRaw, err := loadRawRecursive(resolveExtendsPath(baseDir, parent), seen)
+		if err != nil {
+			return nil, fmt.Errorf("extends %q: %w", parent, err)
+		}
+		merged = mergeMaps(merged, parentRaw)
+	}
 	if len(includes) > 0 {
-		baseDir := filepath.Dir(absPath)
 		for _, inc := range includes {
 			if strings.TrimSpace(inc) == "" {
 				continue
@@ -136,6 +168,66 @@ func extractIncludes(raw map[string]any) ([]string, error) {
 	}
 }
 
+func extractExtends(raw map[string]any) (string, error) {
+	val, ok := raw[extendsKey]
+	if !ok {
+		return "", nil
+	}
+	delete(raw, extendsKey)
+	if val == nil {
+		return "", nil
+	}
+	name, ok := val.(string)
+	if !ok {
+		return "", fmt.Errorf("extends must be a string")
+	}
+	return strings.TrimSpace(name), nil
+}
+
+// resolveExtendsPath maps an extends value to a file. A bare name such as
+// "base" refers to a sibling profile (base.yaml); anything with an extension
+// or path separator is treated as a path relative to the extending file.
+func resolveExtendsPath(baseDir, parent string) string {
+	if filepath.Ext(parent) == "" && !strings.ContainsAny(parent, `/\`) {
+		parent += ".yaml"
+	}
+	if filepath.IsAbs(parent) {
+		return parent
+	}
+	return filepath.Join(baseDir, parent)
+}
+
+// applyEnvironment deep-merges the environments.<env> section over the rest
+// of the config. The environments block is always removed so it never reaches
+// the typed decoder.
+func applyEnvironment(raw map[string]any, env string) (map[string]any, error) {
+	val, ok := raw[environmentsKey]
+	if !ok {
+		return raw, nil
+	}
+	delete(raw, environmentsKey)
+	if val == nil {
+		return raw, nil
+	}
+	envs, ok := val.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("environments must be a map of environment name to overrides")
+	}
+	env = strings.TrimSpace(env)
+	if env == "" {
+		return raw, nil
+	}
+	overlay, ok := envs[env]
+	if !ok || overlay == nil {
+		return raw, nil
+	}
+	overlayMap, ok := overlay.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("environments.%s must be a map", env)
+	}
+	return mergeMaps(raw, overlayMap), nil
+}
+
 func mergeMaps(dst, src map[string]any) map[string]any {
 	if dst == nil {
 		dst = map[string]any{}
@@ -2,25 +2,199 @@ package config
 
 import (
 	"encoding/json"
+	"os"
+	"path"
+	"reflect"
+	"strings"
 	"sync"
+	"time"
 
 	"github.com/invopop/jsonschema"
 )
 
+//go:generate go run ./schemagen -comments schema_comments.go
+//go:generate go run ./schemagen -schema ../../docs/nexus.schema.json
+
+// SchemaURL is where the published config schema is served. Point YAML
+// language servers at it with a modeline:
+//
+//	# yaml-language-server: $schema=https://raw.githubusercontent.com/haasonsaas/nexus/main/docs/nexus.schema.json
+const SchemaURL = "https://raw.githubusercontent.com/haasonsaas/nexus/main/docs/nexus.schema.json"
+
+// durationPattern matches the strings time.ParseDuration accepts.
+const durationPattern = `^-?(0|([0-9]+(\.[0-9]+)?(ns|us|µs|ms|s|m|h))+)$`
+
 var (
 	schemaOnce sync.Once
 	schemaJSON []byte
 	schemaErr  error
 )
 
-// JSONSchema returns the JSON Schema for the Config struct.
+var (
+	configPkgPath = reflect.TypeOf(Config{}).PkgPath()
+	durationType  = reflect.TypeOf(time.Duration(0))
+)
+
+// JSONSchema returns the JSON Schema for the Config struct. Properties carry
+// the field doc comments as descriptions and the values applyDefaults fills
+// in as defaults.
 func JSONSchema() ([]byte, error) {
 	schemaOnce.Do(func() {
 		r := &jsonschema.Reflector{
-			FieldNameTag: "yaml",
+			FieldNameTag:               "yaml",
+			RequiredFromJSONSchemaTags: true,
+			Namer:                      schemaTypeName,
+			Mapper:                     schemaTypeMapper,
+			CommentMap:                 schemaComments,
 		}
 		schema := r.Reflect(&Config{})
+		schema.ID = jsonschema.ID(SchemaURL)
+		schema.Title = "Nexus configuration"
+
+		var defaults Config
+		applyDefaults(&defaults)
+		applySchemaDefaults(schema, reflect.ValueOf(defaults))
+
 		schemaJSON, schemaErr = json.MarshalIndent(schema, "", "  ")
 	})
 	return schemaJSON, schemaErr
 }
+
+// schemaTypeName names schema definitions. Types from other packages are
+// qualified so tts.Config does not collide with Config.
+func schemaTypeName(t reflect.Type) string {
+	if t.PkgPath() == "" || t.PkgPath() == configPkgPath {
+		return t.Name()
+	}
+	return path.Base(t.PkgPath()) + "." + t.Name()
+}
+
+// schemaTypeMapper describes types whose YAML form differs from their Go
+// kind.
+func schemaTypeMapper(t reflect.Type) *jsonschema.Schema {
+	if t == durationType {
+		return &jsonschema.Schema{
+			AnyOf: []*jsonschema.Schema{
+				{Type: "string", Pattern: durationPattern},
+				{Type: "integer"},
+			},
+		}
+	}
+	return nil
+}
+
+// schemaDefault is the default of one struct field across every place its
+// type appears in Config.
+type schemaDefault struct {
+	value    any
+	conflict bool
+}
+
+// applySchemaDefaults sets the default of each definition property from the
+// defaults config. A type used in several places (or inside maps and lists,
+// where entries start empty) only gets defaults that agree everywhere.
+// Defaults derived from the host, such as paths under the home directory,
+// are left out so the schema is the same on every machine.
+func applySchemaDefaults(schema *jsonschema.Schema, defaults reflect.Value) {
+	collected := map[reflect.Type]map[string]*schemaDefault{}
+	collectSchemaDefaults(defaults, collected, map[reflect.Type]bool{})
+
+	for t, fields := range collected {
+		def := schema.Definitions[schemaTypeName(t)]
+		if def == nil || def.Properties == nil {
+			continue
+		}
+		for name, d := range fields {
+			if d.conflict || d.value == nil {
+				continue
+			}
+			if prop, ok := def.Properties.Get(name); ok {
+				prop.Default = d.value
+			}
+		}
+	}
+}
+
+func collectSchemaDefaults(v reflect.Value, collected map[reflect.Type]map[string]*schemaDefault, visiting map[reflect.Type]bool) {
+	t := v.Type()
+	if t.Kind() != reflect.Struct || visiting[t] {
+		return
+	}
+	visiting[t] = true
+	defer delete(visiting, t)
+
+	fields := collected[t]
+	if fields == nil {
+		fields = map[string]*schemaDefault{}
+		collected[t] = fields
+	}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, _, _ := strings.Cut(field.Tag.Get("yaml"), ",")
+		if !field.IsExported() || name == "" || name == "-" {
+			continue
+		}
+		fv := v.Field(i)
+		for fv.Kind() == reflect.Pointer {
+			if fv.IsNil() {
+				fv = reflect.Zero(fv.Type().Elem())
+				break
+			}
+			fv = fv.Elem()
+		}
+
+		switch fv.Kind() {
+		case reflect.Struct:
+			if fv.Type() != reflect.TypeOf(time.Time{}) {
+				collectSchemaDefaults(fv, collected, visiting)
+				continue
+			}
+		case reflect.Map, reflect.Slice, reflect.Array:
+			// Entries start from zero values, whatever the default list holds.
+			elem := fv.Type().Elem()
+			for elem.Kind() == reflect.Pointer {
+				elem = elem.Elem()
+			}
+			collectSchemaDefaults(reflect.Zero(elem), collected, visiting)
+		}
+
+		value := schemaDefaultValue(fv)
+		if field.Name == "Enabled" && t == reflect.TypeOf(CanvasHostConfig{}) {
+			// Enabled when the canvas directory exists on this host.
+			value = nil
+		}
+		if d, ok := fields[name]; !ok {
+			fields[name] = &schemaDefault{value: value}
+		} else if !reflect.DeepEqual(d.value, value) {
+			d.conflict = true
+		}
+	}
+}
+
+// schemaDefaultValue returns the JSON default for a field value, or nil when
+// the value is zero or depends on the host.
+func schemaDefaultValue(v reflect.Value) any {
+	if !v.IsValid() || v.IsZero() {
+		return nil
+	}
+	if v.Type() == durationType {
+		return time.Duration(v.Int()).String()
+	}
+	if v.Kind() == reflect.String && hostDependent(v.String()) {
+		return nil
+	}
+	return v.Interface()
+}
+
+// hostDependent reports whether a default string was derived from the host
+// the schema was generated on.
+func hostDependent(value string) bool {
+	if home, err := os.UserHomeDir(); err == nil && home != "" && strings.HasPrefix(value, home) {
+		return true
+	}
+	if tmp := os.TempDir(); tmp != "" && strings.HasPrefix(value, tmp) {
+		return true
+	}
+	host, err := os.Hostname()
+	return err == nil && host != "" && value == host
+}
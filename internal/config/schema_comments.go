@@ -0,0 +1,893 @@
+// Code generated by schemagen; DO NOT EDIT.
+
+package config
+
+// schemaComments maps types and fields reachable from Config to their doc
+// comments, which JSONSchema uses as descriptions.
+var schemaComments = map[string]string{
+	"github.com/haasonsaas/nexus/internal/audit.Config":                                        "Config configures the audit logger.",
+	"github.com/haasonsaas/nexus/internal/audit.Config.BufferSize":                             "BufferSize is the size of the async write buffer.",
+	"github.com/haasonsaas/nexus/internal/audit.Config.Enabled":                                "Enabled determines if audit logging is active.",
+	"github.com/haasonsaas/nexus/internal/audit.Config.EventTypes":                             "EventTypes filters which event types to log (empty = all).",
+	"github.com/haasonsaas/nexus/internal/audit.Config.FlushInterval":                          "FlushInterval is how often to flush the buffer.",
+	"github.com/haasonsaas/nexus/internal/audit.Config.Format":                                 "Format specifies the output format.",
+	"github.com/haasonsaas/nexus/internal/audit.Config.IncludeMessageContent":                  "IncludeMessageContent determines if message content is logged.",
+	"github.com/haasonsaas/nexus/internal/audit.Config.IncludeToolInput":                       "IncludeToolInput determines if tool inputs are logged.\nSet to false for privacy-sensitive environments.",
+	"github.com/haasonsaas/nexus/internal/audit.Config.IncludeToolOutput":                      "IncludeToolOutput determines if tool outputs are logged.",
+	"github.com/haasonsaas/nexus/internal/audit.Config.Level":                                  "Level is the minimum level to log.",
+	"github.com/haasonsaas/nexus/internal/audit.Config.MaxFieldSize":                           "MaxFieldSize limits the size of logged fields.",
+	"github.com/haasonsaas/nexus/internal/audit.Config.Output":                                 "Output specifies where to write logs.\nSupported: \"stdout\", \"stderr\", \"file:/path/to/file.log\"",
+	"github.com/haasonsaas/nexus/internal/audit.Config.SampleRate":                             "SampleRate controls what fraction of events are logged (0.0 to 1.0).\n1.0 = all events, 0.1 = 10% of events.",
+	"github.com/haasonsaas/nexus/internal/config.APIKeyConfig.Role":                            "Role grants a fixed set of scopes: \"read-only\", \"operator\", or\n\"admin\".",
+	"github.com/haasonsaas/nexus/internal/config.APIKeyConfig.Scopes":                          "Scopes limits the key to the listed scopes (e.g. \"sessions:read\",\n\"approvals:*\", \"admin\"), on top of those of Role. A key with neither\nhas full access.",
+	"github.com/haasonsaas/nexus/internal/config.AnomalyAlertConfig":                           "AnomalyAlertConfig controls where anomaly alerts go besides the \"anomaly.alert\" event and the gateway log.",
+	"github.com/haasonsaas/nexus/internal/config.AnomalyAlertConfig.WebhookURL":                "WebhookURL receives a JSON POST when an anomaly starts or resolves.",
+	"github.com/haasonsaas/nexus/internal/config.AnomalyConfig":                                "AnomalyConfig detects sudden spikes in run errors, token usage, and message volume from a single peer against in-process EWMA baselines.",
+	"github.com/haasonsaas/nexus/internal/config.AnomalyConfig.Alpha":                          "Alpha is the EWMA smoothing factor; higher adapts faster (default: 0.1).",
+	"github.com/haasonsaas/nexus/internal/config.AnomalyConfig.Interval":                       "Interval is the bucket counters are summed over and compared to\ntheir baseline (default: 1m).",
+	"github.com/haasonsaas/nexus/internal/config.AnomalyConfig.MinErrors":                      "MinErrors, MinTokens, and MinPeerMessages are the smallest interval\ncounts that can alert, so quiet gateways do not page on noise\n(defaults: 5, 50000, 20).",
+	"github.com/haasonsaas/nexus/internal/config.AnomalyConfig.Threshold":                      "Threshold is the z-score an interval must reach to be anomalous\n(default: 4).",
+	"github.com/haasonsaas/nexus/internal/config.AnomalyConfig.Warmup":                         "Warmup is how many intervals a baseline needs before it can alert\n(default: 10). Peers without enough history are compared to the\nbaseline of all peers.",
+	"github.com/haasonsaas/nexus/internal/config.ApprovalConfig":                               "ApprovalConfig controls tool approval behavior.",
+	"github.com/haasonsaas/nexus/internal/config.ApprovalConfig.Allowlist":                     "Allowlist contains tools that are always allowed (no approval needed).\nSupports patterns like \"mcp:*\", \"read_*\", \"*\" (all).\nAlso supports group references like \"group:fs\", \"group:runtime\".",
+	"github.com/haasonsaas/nexus/internal/config.ApprovalConfig.AskFallback":                   "AskFallback queues approval when UI is unavailable instead of denying.",
+	"github.com/haasonsaas/nexus/internal/config.ApprovalConfig.DefaultDecision":               "DefaultDecision when no rule matches: \"allowed\", \"denied\", or \"pending\".",
+	"github.com/haasonsaas/nexus/internal/config.ApprovalConfig.Denylist":                      "Denylist contains tools that are always denied.\nSupports patterns and group references like Allowlist.",
+	"github.com/haasonsaas/nexus/internal/config.ApprovalConfig.Profile":                       "Profile is a pre-configured tool access level.\nValid profiles: \"coding\", \"messaging\", \"readonly\", \"full\", \"minimal\".\nWhen set, the profile's default tools are included in the allowlist.",
+	"github.com/haasonsaas/nexus/internal/config.ApprovalConfig.RequestTTL":                    "RequestTTL is how long approval requests remain valid.",
+	"github.com/haasonsaas/nexus/internal/config.ApprovalConfig.SafeBins":                      "SafeBins are stdin-only tools that are safe to auto-allow.",
+	"github.com/haasonsaas/nexus/internal/config.ApprovalConfig.SkillAllowlist":                "SkillAllowlist auto-allows tools defined by enabled skills.",
+	"github.com/haasonsaas/nexus/internal/config.ArtifactConfig":                               "ArtifactConfig configures artifact storage and retention.",
+	"github.com/haasonsaas/nexus/internal/config.ArtifactConfig.Backend":                       "Backend specifies storage backend: \"local\", \"s3\", or \"minio\".",
+	"github.com/haasonsaas/nexus/internal/config.ArtifactConfig.Links":                         "Links configures signed, expiring download links served by the gateway.",
+	"github.com/haasonsaas/nexus/internal/config.ArtifactConfig.LocalPath":                     "LocalPath is the directory for local storage.",
+	"github.com/haasonsaas/nexus/internal/config.ArtifactConfig.MaxStorageSize":                "MaxStorageSize is the total quota in bytes (0 = unlimited).",
+	"github.com/haasonsaas/nexus/internal/config.ArtifactConfig.MetadataBackend":               "MetadataBackend selects where artifact metadata is stored: \"file\" or \"database\".",
+	"github.com/haasonsaas/nexus/internal/config.ArtifactConfig.MetadataPath":                  "MetadataPath is the file path for artifact metadata persistence.",
+	"github.com/haasonsaas/nexus/internal/config.ArtifactConfig.Processing":                    "Processing configures validation and transforms applied before storage.",
+	"github.com/haasonsaas/nexus/internal/config.ArtifactConfig.PruneInterval":                 "PruneInterval is how often to cleanup expired artifacts.",
+	"github.com/haasonsaas/nexus/internal/config.ArtifactConfig.Redaction":                     "Redaction configures rules for sensitive artifacts.",
+	"github.com/haasonsaas/nexus/internal/config.ArtifactConfig.S3AccessKeyID":                 "S3AccessKeyID is the AWS access key ID for S3 authentication.",
+	"github.com/haasonsaas/nexus/internal/config.ArtifactConfig.S3Bucket":                      "S3Bucket is the bucket name for S3/MinIO storage.",
+	"github.com/haasonsaas/nexus/internal/config.ArtifactConfig.S3Endpoint":                    "S3Endpoint is the endpoint URL for MinIO or S3-compatible storage.",
+	"github.com/haasonsaas/nexus/internal/config.ArtifactConfig.S3KMSKeyID":                    "S3KMSKeyID is the KMS key for SSE-KMS (default: the AWS managed key).",
+	"github.com/haasonsaas/nexus/internal/config.ArtifactConfig.S3Lifecycle":                   "S3Lifecycle installs bucket lifecycle rules expiring objects after\ntheir TTL and aborting stale multipart uploads.",
+	"github.com/haasonsaas/nexus/internal/config.ArtifactConfig.S3MultipartThreshold":          "S3MultipartThreshold is the size in bytes above which uploads use\nmultipart (default: 16MB).",
+	"github.com/haasonsaas/nexus/internal/config.ArtifactConfig.S3PartSize":                    "S3PartSize is the multipart part size in bytes (default: 8MB, min 5MB).",
+	"github.com/haasonsaas/nexus/internal/config.ArtifactConfig.S3Prefix":                      "S3Prefix is an optional path prefix for all S3 objects.",
+	"github.com/haasonsaas/nexus/internal/config.ArtifactConfig.S3Presign":                     "S3Presign serves artifact downloads through presigned bucket URLs\ninstead of proxying them through the gateway.",
+	"github.com/haasonsaas/nexus/internal/config.ArtifactConfig.S3PresignTTL":                  "S3PresignTTL is how long presigned URLs stay valid (default: 15m).",
+	"github.com/haasonsaas/nexus/internal/config.ArtifactConfig.S3Region":                      "S3Region is the AWS region for S3.",
+	"github.com/haasonsaas/nexus/internal/config.ArtifactConfig.S3SSE":                         "S3SSE is the server-side encryption for stored objects: \"AES256\"\n(SSE-S3) or \"aws:kms\" (SSE-KMS). Empty uses the bucket default.",
+	"github.com/haasonsaas/nexus/internal/config.ArtifactConfig.S3SecretAccessKey":             "S3SecretAccessKey is the AWS secret access key for S3 authentication.",
+	"github.com/haasonsaas/nexus/internal/config.ArtifactConfig.TTLs":                          "TTLs configures retention period by artifact type.",
+	"github.com/haasonsaas/nexus/internal/config.ArtifactLinksConfig":                          "ArtifactLinksConfig controls signed artifact download links.",
+	"github.com/haasonsaas/nexus/internal/config.ArtifactLinksConfig.BaseURL":                  "BaseURL is the externally reachable gateway URL used in links.\nDefaults to http://localhost:<server.http_port>.",
+	"github.com/haasonsaas/nexus/internal/config.ArtifactLinksConfig.Enabled":                  "Enabled serves artifacts at /artifacts/{id} for signed links and\nauthenticated clients.",
+	"github.com/haasonsaas/nexus/internal/config.ArtifactLinksConfig.MaxTTL":                   "MaxTTL caps requested link lifetimes.",
+	"github.com/haasonsaas/nexus/internal/config.ArtifactLinksConfig.SigningKey":               "SigningKey signs links. Defaults to auth.jwt_secret.",
+	"github.com/haasonsaas/nexus/internal/config.ArtifactLinksConfig.TTL":                      "TTL is how long links stay valid when no TTL is requested.",
+	"github.com/haasonsaas/nexus/internal/config.ArtifactProcessingConfig":                     "ArtifactProcessingConfig controls artifact post-processing.",
+	"github.com/haasonsaas/nexus/internal/config.ArtifactProcessingConfig.Enabled":             "Enabled toggles processing.",
+	"github.com/haasonsaas/nexus/internal/config.ArtifactProcessingConfig.FFmpegPath":          "FFmpegPath is the ffmpeg binary used for video transcodes (default: ffmpeg).",
+	"github.com/haasonsaas/nexus/internal/config.ArtifactProcessingConfig.MIMEValidation":      "MIMEValidation compares declared MIME types with sniffed content:\n\"correct\" (default) fixes mismatches, \"reject\" refuses them, \"off\" skips.",
+	"github.com/haasonsaas/nexus/internal/config.ArtifactProcessingConfig.TranscodeTimeout":    "TranscodeTimeout bounds a single video transcode (default: 2m).",
+	"github.com/haasonsaas/nexus/internal/config.ArtifactProcessingConfig.Types":               "Types maps artifact types to processing rules; \"default\" applies to\ntypes without their own entry.",
+	"github.com/haasonsaas/nexus/internal/config.ArtifactProcessingRule":                       "ArtifactProcessingRule configures processing for one artifact type.",
+	"github.com/haasonsaas/nexus/internal/config.ArtifactProcessingRule.MaxVideoBytes":         "MaxVideoBytes is the size cap for stored videos (default: 25MB).",
+	"github.com/haasonsaas/nexus/internal/config.ArtifactProcessingRule.MaxVideoHeight":        "MaxVideoHeight is the transcode resolution cap (default: 720).",
+	"github.com/haasonsaas/nexus/internal/config.ArtifactProcessingRule.OCR":                   "OCR stores text read from images as a separate \"ocr_text\" artifact.\nRequires ocr.enabled.",
+	"github.com/haasonsaas/nexus/internal/config.ArtifactProcessingRule.StripEXIF":             "StripEXIF removes EXIF, XMP, and text metadata from JPEG and PNG images.",
+	"github.com/haasonsaas/nexus/internal/config.ArtifactProcessingRule.Thumbnail":             "Thumbnail stores a JPEG preview as a separate \"thumbnail\" artifact.",
+	"github.com/haasonsaas/nexus/internal/config.ArtifactProcessingRule.ThumbnailMaxSide":      "ThumbnailMaxSide is the longest thumbnail edge in pixels (default: 320).",
+	"github.com/haasonsaas/nexus/internal/config.ArtifactProcessingRule.Transcode":             "Transcode re-encodes videos larger than MaxVideoBytes to H.264 mp4.",
+	"github.com/haasonsaas/nexus/internal/config.ArtifactRedactionConfig":                      "ArtifactRedactionConfig controls artifact redaction behavior.",
+	"github.com/haasonsaas/nexus/internal/config.ArtifactRedactionConfig.Enabled":              "Enabled toggles redaction.",
+	"github.com/haasonsaas/nexus/internal/config.ArtifactRedactionConfig.FilenamePatterns":     "FilenamePatterns are regex patterns to match against filenames.",
+	"github.com/haasonsaas/nexus/internal/config.ArtifactRedactionConfig.MimeTypes":            "MimeTypes lists MIME types to redact (supports wildcards like \"image/*\").",
+	"github.com/haasonsaas/nexus/internal/config.ArtifactRedactionConfig.Types":                "Types lists artifact types to redact (case-insensitive).",
+	"github.com/haasonsaas/nexus/internal/config.AttentionConfig":                              "AttentionConfig controls the attention feed integration.",
+	"github.com/haasonsaas/nexus/internal/config.AttentionConfig.Digest":                       "Digest posts a periodic summary of open items to a channel.",
+	"github.com/haasonsaas/nexus/internal/config.AttentionConfig.Enabled":                      "Enabled turns on the attention feed and tools.",
+	"github.com/haasonsaas/nexus/internal/config.AttentionConfig.InjectInPrompt":               "InjectInPrompt adds a summary of active items to the system prompt.",
+	"github.com/haasonsaas/nexus/internal/config.AttentionConfig.MaxItems":                     "MaxItems limits how many items are injected into the prompt.",
+	"github.com/haasonsaas/nexus/internal/config.AttentionConfig.Retention":                    "Retention is how long handled items are kept before pruning\n(default: 7d).",
+	"github.com/haasonsaas/nexus/internal/config.AttentionDigestConfig":                        "AttentionDigestConfig schedules the attention digest.",
+	"github.com/haasonsaas/nexus/internal/config.AttentionDigestConfig.Channel":                "Channel and PeerID identify the conversation the digest is posted to.",
+	"github.com/haasonsaas/nexus/internal/config.AttentionDigestConfig.MaxItems":               "MaxItems caps how many open items the digest lists (default: 20).",
+	"github.com/haasonsaas/nexus/internal/config.AttentionDigestConfig.Schedule":               "Schedule is a cron expression (default: \"0 9 * * *\", daily at 09:00).",
+	"github.com/haasonsaas/nexus/internal/config.AttentionDigestConfig.Timezone":               "Timezone is the IANA zone the schedule is evaluated in (default: UTC).",
+	"github.com/haasonsaas/nexus/internal/config.AuthConfig.Audit":                             "Audit records admin operations (config writes, approval overrides,\nidentity erasure) and denied attempts at them.",
+	"github.com/haasonsaas/nexus/internal/config.BedrockConfig":                                "BedrockConfig configures AWS Bedrock model discovery.",
+	"github.com/haasonsaas/nexus/internal/config.BedrockConfig.DefaultContextWindow":           "DefaultContextWindow is used when the model doesn't report context size.\nDefault: 32000.",
+	"github.com/haasonsaas/nexus/internal/config.BedrockConfig.DefaultMaxTokens":               "DefaultMaxTokens is used when the model doesn't report max output.\nDefault: 4096.",
+	"github.com/haasonsaas/nexus/internal/config.BedrockConfig.Enabled":                        "Enabled enables automatic discovery of Bedrock foundation models.",
+	"github.com/haasonsaas/nexus/internal/config.BedrockConfig.ProviderFilter":                 "ProviderFilter limits discovery to specific model providers.\nExample: [\"anthropic\", \"amazon\", \"meta\"]\nEmpty means all providers.",
+	"github.com/haasonsaas/nexus/internal/config.BedrockConfig.RefreshInterval":                "RefreshInterval is how often to refresh the model list (e.g., \"1h\", \"30m\").\nDefault: 1h. Set to \"0\" to disable caching.",
+	"github.com/haasonsaas/nexus/internal/config.BedrockConfig.Region":                         "Region is the AWS region to query for models. Default: us-east-1.",
+	"github.com/haasonsaas/nexus/internal/config.BlueBubblesConfig.Password":                   "Password is the API password (required).",
+	"github.com/haasonsaas/nexus/internal/config.BlueBubblesConfig.ServerURL":                  "ServerURL is the BlueBubbles server URL (required).",
+	"github.com/haasonsaas/nexus/internal/config.BlueBubblesConfig.Timeout":                    "Timeout is the HTTP timeout (default: 10s).",
+	"github.com/haasonsaas/nexus/internal/config.BlueBubblesConfig.WebhookPath":                "WebhookPath is the path for webhook callbacks (default: /webhook/bluebubbles).",
+	"github.com/haasonsaas/nexus/internal/config.BroadcastConfig":                              "BroadcastConfig configures broadcast groups for message routing.",
+	"github.com/haasonsaas/nexus/internal/config.BroadcastConfig.Groups":                       "Groups maps peer_id to a list of agent_ids that should process messages.\nWhen a message arrives from a peer in this map, it will be routed to all\nspecified agents instead of the default single agent.",
+	"github.com/haasonsaas/nexus/internal/config.BroadcastConfig.Outbound":                     "Outbound configures named target lists for the broadcast command and\ntool, which deliver one agent-composed message to many channels.",
+	"github.com/haasonsaas/nexus/internal/config.BroadcastConfig.Strategy":                     "Strategy defines how messages are processed: \"parallel\" or \"sequential\".",
+	"github.com/haasonsaas/nexus/internal/config.BroadcastTargetConfig":                        "BroadcastTargetConfig is a single broadcast destination.",
+	"github.com/haasonsaas/nexus/internal/config.BroadcastTargetConfig.Channel":                "Channel is the channel type (slack, telegram, discord, ...).",
+	"github.com/haasonsaas/nexus/internal/config.BroadcastTargetConfig.Format":                 "Format controls how the message is rendered for this target:\n\"auto\" (per channel, default), \"markdown\", or \"plain\".",
+	"github.com/haasonsaas/nexus/internal/config.BroadcastTargetConfig.Label":                  "Label is an optional display name used in delivery reports.",
+	"github.com/haasonsaas/nexus/internal/config.BroadcastTargetConfig.PeerID":                 "PeerID is the channel-specific chat, channel, or user ID.",
+	"github.com/haasonsaas/nexus/internal/config.CanaryAlertConfig":                            "CanaryAlertConfig controls alerts on failing checks.",
+	"github.com/haasonsaas/nexus/internal/config.CanaryAlertConfig.AfterFailures":              "AfterFailures is the number of consecutive failures that raise an\nalert (default: 3). A recovery is reported once the check passes.",
+	"github.com/haasonsaas/nexus/internal/config.CanaryAlertConfig.Channel":                    "Channel and PeerID target an admin conversation for alert messages.",
+	"github.com/haasonsaas/nexus/internal/config.CanaryAlertConfig.WebhookURL":                 "WebhookURL receives a JSON POST when a check starts failing or recovers.",
+	"github.com/haasonsaas/nexus/internal/config.CanaryCheckConfig":                            "CanaryCheckConfig is a single canary conversation.",
+	"github.com/haasonsaas/nexus/internal/config.CanaryCheckConfig.Channel":                    "Channel is \"loopback\" (default), which runs in-process, or the channel\nof SessionKey, whose reply is delivered to that conversation.",
+	"github.com/haasonsaas/nexus/internal/config.CanaryCheckConfig.Expect":                     "Expect must appear in the reply, ignoring case (default: \"pong\").",
+	"github.com/haasonsaas/nexus/internal/config.CanaryCheckConfig.Message":                    "Message is sent as the user (default: \"ping\").",
+	"github.com/haasonsaas/nexus/internal/config.CanaryCheckConfig.SessionKey":                 "SessionKey selects an existing session on Channel, e.g.\n\"main:telegram:123456\". Its last user message supplies the delivery\nmetadata, as for heartbeats.",
+	"github.com/haasonsaas/nexus/internal/config.CanaryCheckConfig.Timeout":                    "Timeout overrides CanaryConfig.Timeout.",
+	"github.com/haasonsaas/nexus/internal/config.CanaryConfig":                                 "CanaryConfig runs scripted conversations through the gateway on an interval to catch provider or channel breakage before users do.",
+	"github.com/haasonsaas/nexus/internal/config.CanaryConfig.Checks":                          "Checks are the canary conversations (default: one loopback check\nsending \"ping\" and expecting \"pong\").",
+	"github.com/haasonsaas/nexus/internal/config.CanaryConfig.Interval":                        "Interval is how often every check runs (default: 5m).",
+	"github.com/haasonsaas/nexus/internal/config.CanaryConfig.Timeout":                         "Timeout is how long a check waits for the reply (default: 60s).",
+	"github.com/haasonsaas/nexus/internal/config.CanvasActionConfig":                           "CanvasActionConfig configures canvas UI action handling.",
+	"github.com/haasonsaas/nexus/internal/config.CanvasConfig":                                 "CanvasConfig configures canvas persistence and retention.",
+	"github.com/haasonsaas/nexus/internal/config.CanvasHostConfig":                             "CanvasHostConfig configures the dedicated canvas host.",
+	"github.com/haasonsaas/nexus/internal/config.CanvasRetentionConfig":                        "CanvasRetentionConfig controls how long canvas state and events are retained.",
+	"github.com/haasonsaas/nexus/internal/config.CanvasTokenConfig":                            "CanvasTokenConfig controls signed canvas access tokens.",
+	"github.com/haasonsaas/nexus/internal/config.CatchupConfig":                                "CatchupConfig controls read-state tracking and /catchup summaries of messages a user missed in a group conversation.",
+	"github.com/haasonsaas/nexus/internal/config.CatchupConfig.Digest":                         "Digest posts a scheduled summary of the messages the agent has not\nread to each group conversation.",
+	"github.com/haasonsaas/nexus/internal/config.CatchupConfig.Enabled":                        "Enabled turns on read-state tracking and the /catchup command.",
+	"github.com/haasonsaas/nexus/internal/config.CatchupConfig.Lookback":                       "Lookback is how far back /catchup reaches for a user with no recorded\nactivity in the conversation. Defaults to 24h.",
+	"github.com/haasonsaas/nexus/internal/config.CatchupConfig.MaxInputTokens":                 "MaxInputTokens caps the missed messages sent to the model; the oldest\nare dropped first. Defaults to 4000.",
+	"github.com/haasonsaas/nexus/internal/config.CatchupConfig.MaxOutputTokens":                "MaxOutputTokens caps the length of a summary. Defaults to 400.",
+	"github.com/haasonsaas/nexus/internal/config.CatchupConfig.Model":                          "Model overrides the model used for summaries. Defaults to the\nsession's model.",
+	"github.com/haasonsaas/nexus/internal/config.CatchupDigestConfig":                          "CatchupDigestConfig schedules catch-up digests.",
+	"github.com/haasonsaas/nexus/internal/config.CatchupDigestConfig.MinMessages":              "MinMessages is the fewest unread messages worth a digest (default: 10).",
+	"github.com/haasonsaas/nexus/internal/config.CatchupDigestConfig.Schedule":                 "Schedule is a cron expression (default: \"0 9 * * *\", daily at 09:00).",
+	"github.com/haasonsaas/nexus/internal/config.CatchupDigestConfig.Timezone":                 "Timezone is the IANA zone the schedule is evaluated in. Defaults to\nuser.timezone.",
+	"github.com/haasonsaas/nexus/internal/config.ChannelMarkdownConfig":                        "ChannelMarkdownConfig configures markdown processing for a channel.",
+	"github.com/haasonsaas/nexus/internal/config.ChannelMarkdownConfig.Tables":                 "Tables specifies how to handle markdown tables: \"off\", \"bullets\", or \"code\".\n- \"off\": Leave tables unchanged (for channels that support markdown tables)\n- \"bullets\": Convert tables to bullet lists (for channels like Signal, WhatsApp)\n- \"code\": Wrap tables in code blocks (for channels like Slack, Discord)\nDefault depends on channel type.",
+	"github.com/haasonsaas/nexus/internal/config.ChannelPolicyConfig.Activation":               "Activation decides which group messages get a reply: \"always\" (default),\n\"mention\" (only when the bot is mentioned or replied to), or \"interject\"\n(mentions, plus unprompted replies to messages the relevance classifier\nrates as worth joining in on).",
+	"github.com/haasonsaas/nexus/internal/config.ChannelPolicyConfig.AllowFrom":                "AllowFrom is a list of sender identifiers allowed for this policy.",
+	"github.com/haasonsaas/nexus/internal/config.ChannelPolicyConfig.Cooldown":                 "Cooldown is the minimum time after a reply before the bot answers\nanother message in the group that does not mention it.",
+	"github.com/haasonsaas/nexus/internal/config.ChannelPolicyConfig.Interject":                "Interject tunes the \"interject\" activation.",
+	"github.com/haasonsaas/nexus/internal/config.ChannelPolicyConfig.MentionPatterns":          "MentionPatterns are case-insensitive regular expressions that count as\nmentioning the bot, e.g. its name.",
+	"github.com/haasonsaas/nexus/internal/config.ChannelPolicyConfig.Policy":                   "Policy controls access: \"open\", \"allowlist\", \"pairing\", or \"disabled\".",
+	"github.com/haasonsaas/nexus/internal/config.CheckpointConfig":                             "CheckpointConfig controls checkpointing of long runs.",
+	"github.com/haasonsaas/nexus/internal/config.CheckpointConfig.Dir":                         "Dir holds one file per checkpointed run. Defaults to\n~/.nexus/checkpoints.",
+	"github.com/haasonsaas/nexus/internal/config.CheckpointConfig.Enabled":                     "Enabled saves checkpoints of runs that outlast Interval.",
+	"github.com/haasonsaas/nexus/internal/config.CheckpointConfig.Interval":                    "Interval is the least time between two checkpoints of a run. Runs that\nfinish sooner are never checkpointed. Defaults to 2m.",
+	"github.com/haasonsaas/nexus/internal/config.CheckpointConfig.MaxAge":                      "MaxAge removes checkpoints not updated for this long. Defaults to 168h.",
+	"github.com/haasonsaas/nexus/internal/config.CheckpointConfig.MaxCount":                    "MaxCount keeps at most this many checkpoints, removing the oldest\nfirst. Checkpoints of runs in progress are always kept. Defaults to 50.",
+	"github.com/haasonsaas/nexus/internal/config.ClusterConfig":                                "ClusterConfig controls multi-gateway behavior.",
+	"github.com/haasonsaas/nexus/internal/config.ClusterConfig.AllowMultipleGateways":          "AllowMultipleGateways bypasses the singleton gateway lock.",
+	"github.com/haasonsaas/nexus/internal/config.ClusterConfig.Coordination":                   "Coordination controls leader election and work partitioning.",
+	"github.com/haasonsaas/nexus/internal/config.ClusterConfig.Enabled":                        "Enabled turns on cluster-aware behavior.",
+	"github.com/haasonsaas/nexus/internal/config.ClusterConfig.EventBus":                       "EventBus controls event propagation between gateway nodes.",
+	"github.com/haasonsaas/nexus/internal/config.ClusterConfig.NodeID":                         "NodeID uniquely identifies this gateway instance.",
+	"github.com/haasonsaas/nexus/internal/config.ClusterConfig.SessionLocks":                   "SessionLocks controls distributed session locking.",
+	"github.com/haasonsaas/nexus/internal/config.CommandRolesConfig":                           "CommandRolesConfig configures command permission roles.",
+	"github.com/haasonsaas/nexus/internal/config.CommandRolesConfig.Assignments":               "Assignments maps subjects to roles. A subject is either\n\"channel:sender_id\" or a canonical identity ID from session.scoping.identity_links.",
+	"github.com/haasonsaas/nexus/internal/config.CommandRolesConfig.Channels":                  "Channels overrides the default role per channel.\nExample: {\"discord\": \"guest\"}",
+	"github.com/haasonsaas/nexus/internal/config.CommandRolesConfig.Commands":                  "Commands overrides the minimum role of individual commands.\nExample: {\"model\": \"admin\"}",
+	"github.com/haasonsaas/nexus/internal/config.CommandRolesConfig.Default":                   "Default is the role of senders with no other assignment. Defaults to member.",
+	"github.com/haasonsaas/nexus/internal/config.CommandRolesConfig.StorePath":                 "StorePath is where roles granted with /role are persisted.\nDefaults to ~/.nexus/roles.json.",
+	"github.com/haasonsaas/nexus/internal/config.CommandsConfig":                               "CommandsConfig configures gateway command handling.",
+	"github.com/haasonsaas/nexus/internal/config.CommandsConfig.AllowFrom":                     "AllowFrom restricts command-only messages by channel/provider.\nExample: {\"telegram\": [\"12345\", \"67890\"], \"discord\": [\"*\"]}",
+	"github.com/haasonsaas/nexus/internal/config.CommandsConfig.Enabled":                       "Enabled toggles text command handling. Defaults to true.",
+	"github.com/haasonsaas/nexus/internal/config.CommandsConfig.InlineAllowFrom":               "InlineAllowFrom restricts inline command shortcuts by channel/provider.\nWhen empty, inline commands are disabled by default.",
+	"github.com/haasonsaas/nexus/internal/config.CommandsConfig.InlineCommands":                "InlineCommands lists command names that can run inline (without leading slash).",
+	"github.com/haasonsaas/nexus/internal/config.CommandsConfig.Roles":                         "Roles assigns permission tiers (guest, member, admin) to senders.",
+	"github.com/haasonsaas/nexus/internal/config.ComputerUseConfig":                            "ComputerUseConfig controls the Claude computer use tool routing.",
+	"github.com/haasonsaas/nexus/internal/config.ComputerUseConfig.DisplayHeightPx":            "DisplayHeightPx overrides the display height in pixels when metadata is unavailable.",
+	"github.com/haasonsaas/nexus/internal/config.ComputerUseConfig.DisplayNumber":              "DisplayNumber overrides the display number (0-based) when metadata is unavailable.",
+	"github.com/haasonsaas/nexus/internal/config.ComputerUseConfig.DisplayWidthPx":             "DisplayWidthPx overrides the display width in pixels when metadata is unavailable.",
+	"github.com/haasonsaas/nexus/internal/config.ComputerUseConfig.EdgeID":                     "EdgeID selects the default edge to target for computer use.",
+	"github.com/haasonsaas/nexus/internal/config.ComputerUseConfig.Enabled":                    "Enabled registers the computer use tool in the runtime.",
+	"github.com/haasonsaas/nexus/internal/config.Config":                                       "Config is the main configuration structure for Nexus.",
+	"github.com/haasonsaas/nexus/internal/config.ContextPruningConfig":                         "ContextPruningConfig controls in-memory tool result pruning for sessions.",
+	"github.com/haasonsaas/nexus/internal/config.ContextPruningHardClear":                      "ContextPruningHardClear configures hard clearing of tool result content.",
+	"github.com/haasonsaas/nexus/internal/config.ContextPruningSoftTrim":                       "ContextPruningSoftTrim configures soft trimming of tool result content.",
+	"github.com/haasonsaas/nexus/internal/config.ContextPruningToolMatch":                      "ContextPruningToolMatch selects which tool results can be trimmed.",
+	"github.com/haasonsaas/nexus/internal/config.CoordinationConfig":                           "CoordinationConfig configures DB-backed cluster coordination.",
+	"github.com/haasonsaas/nexus/internal/config.CoordinationConfig.Enabled":                   "Enabled turns on leader election and work partitioning.",
+	"github.com/haasonsaas/nexus/internal/config.CoordinationConfig.HeartbeatInterval":         "HeartbeatInterval is how often nodes heartbeat and renew leases.",
+	"github.com/haasonsaas/nexus/internal/config.CoordinationConfig.LeaseTTL":                  "LeaseTTL is how long a leader lease lasts without renewal.",
+	"github.com/haasonsaas/nexus/internal/config.CoordinationConfig.NodeTTL":                   "NodeTTL is how long a node is considered live after its last heartbeat.",
+	"github.com/haasonsaas/nexus/internal/config.CostReportConfig":                             "CostReportConfig posts a weekly or monthly report of estimated LLM spend, broken down by provider, model, agent, and user, to an admin conversation.",
+	"github.com/haasonsaas/nexus/internal/config.CostReportConfig.Channel":                     "Channel and PeerID identify the admin conversation the report is\nposted to.",
+	"github.com/haasonsaas/nexus/internal/config.CostReportConfig.Period":                      "Period is \"weekly\" (the previous Monday to Sunday) or \"monthly\" (the\nprevious calendar month). Default: weekly.",
+	"github.com/haasonsaas/nexus/internal/config.CostReportConfig.PlanUSD":                     "PlanUSD is the planned spend for one period. Zero means no plan.",
+	"github.com/haasonsaas/nexus/internal/config.CostReportConfig.ProviderPlansUSD":            "ProviderPlansUSD are planned spends per provider for one period.",
+	"github.com/haasonsaas/nexus/internal/config.CostReportConfig.Schedule":                    "Schedule is a cron expression for when the report is posted (default:\n\"0 9 * * 1\" for weekly, \"0 9 1 * *\" for monthly). The report covers the\nlast full period before it runs.",
+	"github.com/haasonsaas/nexus/internal/config.CostReportConfig.Timezone":                    "Timezone is the IANA zone periods and the schedule are evaluated in\n(default: user.timezone, then UTC).",
+	"github.com/haasonsaas/nexus/internal/config.CostReportConfig.TopN":                        "TopN caps the rows listed per breakdown in the message (default: 5).\nThe attached report lists every row.",
+	"github.com/haasonsaas/nexus/internal/config.CostReportConfig.WarnAt":                      "WarnAt is the fraction of a plan at which the report switches to its\nwarning format (default: 1, when spend reaches the plan).",
+	"github.com/haasonsaas/nexus/internal/config.CrashReportingConfig":                         "CrashReportingConfig controls how panics in channel adapters, tools, and background workers are recovered and reported.",
+	"github.com/haasonsaas/nexus/internal/config.CrashReportingConfig.MaxRestarts":             "MaxRestarts stops restarting a component after this many consecutive\ncrashes. Zero restarts indefinitely.",
+	"github.com/haasonsaas/nexus/internal/config.CrashReportingConfig.RestartBackoff":          "RestartBackoff is the delay before restarting a crashed component.\nIt doubles on each consecutive crash up to MaxRestartBackoff.",
+	"github.com/haasonsaas/nexus/internal/config.CrashReportingConfig.Sentry":                  "Sentry exports crashes with stack traces when a DSN is set.",
+	"github.com/haasonsaas/nexus/internal/config.CredentialAlertConfig":                        "CredentialAlertConfig targets the admin channel for credential alerts.",
+	"github.com/haasonsaas/nexus/internal/config.CredentialMonitorConfig":                      "CredentialMonitorConfig controls periodic validation of provider API keys and channel tokens.",
+	"github.com/haasonsaas/nexus/internal/config.CredentialMonitorConfig.Alert":                "Alert sends a message to an admin conversation when a credential\nstarts failing or runs low on quota.",
+	"github.com/haasonsaas/nexus/internal/config.CredentialMonitorConfig.QuotaWarnRatio":       "QuotaWarnRatio warns when a key's remaining quota falls below this\nshare of its limit (default: 0.1).",
+	"github.com/haasonsaas/nexus/internal/config.CronConfig":                                   "CronConfig configures scheduled jobs.",
+	"github.com/haasonsaas/nexus/internal/config.CronCustomConfig":                             "CronCustomConfig defines a custom cron job payload.",
+	"github.com/haasonsaas/nexus/internal/config.CronJobConfig":                                "CronJobConfig defines a scheduled job.",
+	"github.com/haasonsaas/nexus/internal/config.CronMessageConfig":                            "CronMessageConfig defines a message job payload.",
+	"github.com/haasonsaas/nexus/internal/config.CronRetryConfig":                              "CronRetryConfig controls retry behavior for cron jobs.",
+	"github.com/haasonsaas/nexus/internal/config.CronScheduleConfig":                           "CronScheduleConfig defines when a job runs.",
+	"github.com/haasonsaas/nexus/internal/config.CronWebhookAuth":                              "CronWebhookAuth defines authentication for webhook jobs.",
+	"github.com/haasonsaas/nexus/internal/config.CronWebhookConfig":                            "CronWebhookConfig defines a webhook job payload.",
+	"github.com/haasonsaas/nexus/internal/config.DatabaseConfig":                               "DatabaseConfig configures the primary database connection pool.",
+	"github.com/haasonsaas/nexus/internal/config.DeliveryConfig":                               "DeliveryConfig routes proactive messages (reminders, scheduled messages, attention digests, credential alerts and web watch notifications) to the best channel of a user whose identity is linked across channels.",
+	"github.com/haasonsaas/nexus/internal/config.DeliveryConfig.DuringQuietHours":              "DuringQuietHours is \"defer\" (send when quiet hours end), \"send\", or\n\"drop\". Default: defer.",
+	"github.com/haasonsaas/nexus/internal/config.DeliveryConfig.Enabled":                       "Enabled turns on presence-aware delivery. When off, proactive messages\ngo to the conversation they were addressed to.",
+	"github.com/haasonsaas/nexus/internal/config.DeliveryConfig.Fallback":                      "Fallback tries the user's other linked channels when the first one\nfails (default: true).",
+	"github.com/haasonsaas/nexus/internal/config.DeliveryConfig.QuietHours":                    "QuietHours is a daily window, in the user's timezone, during which\nproactive messages are held back.",
+	"github.com/haasonsaas/nexus/internal/config.DeliveryConfig.Strategy":                      "Strategy picks the first channel to try: \"preferred\" (the identity's\npreferred_channel metadata, then the most recently active channel),\n\"recent\" (the most recently active channel), or \"origin\" (the\nconversation the message was addressed to). Default: preferred.",
+	"github.com/haasonsaas/nexus/internal/config.DeliveryConfig.Types":                         "Types overrides the settings per message type: reminder,\nscheduled_message, digest, alert, watch.",
+	"github.com/haasonsaas/nexus/internal/config.DeliveryTypeConfig":                           "DeliveryTypeConfig overrides delivery settings for one message type.",
+	"github.com/haasonsaas/nexus/internal/config.DeliveryTypeConfig.Channels":                  "Channels are tried first, in order, when the user is linked on them.",
+	"github.com/haasonsaas/nexus/internal/config.DeliveryTypeConfig.DuringQuietHours":          "DuringQuietHours overrides DeliveryConfig.DuringQuietHours.",
+	"github.com/haasonsaas/nexus/internal/config.DeliveryTypeConfig.Strategy":                  "Strategy overrides DeliveryConfig.Strategy.",
+	"github.com/haasonsaas/nexus/internal/config.DiagnosticsServerConfig":                      "DiagnosticsServerConfig configures the pprof and runtime stats endpoint.",
+	"github.com/haasonsaas/nexus/internal/config.DiagnosticsServerConfig.Host":                 "Host defaults to 127.0.0.1. Binding any other address requires\nAllowRemote.",
+	"github.com/haasonsaas/nexus/internal/config.EdgeConfig":                                   "EdgeConfig configures the edge protocol for remote tool execution.",
+	"github.com/haasonsaas/nexus/internal/config.EdgeConfig.AuthMode":                          "AuthMode controls how edges authenticate: \"token\", \"tofu\", or \"dev\".\ntoken: Pre-shared tokens (production)\ntofu: Trust-On-First-Use with manual approval\ndev: Accept all connections (development only)",
+	"github.com/haasonsaas/nexus/internal/config.EdgeConfig.DefaultToolTimeout":                "DefaultToolTimeout is the default timeout for tool execution.",
+	"github.com/haasonsaas/nexus/internal/config.EdgeConfig.Enabled":                           "Enabled enables the edge service for remote edge daemons.",
+	"github.com/haasonsaas/nexus/internal/config.EdgeConfig.EventBufferSize":                   "EventBufferSize is the buffer size for edge events.",
+	"github.com/haasonsaas/nexus/internal/config.EdgeConfig.HeartbeatInterval":                 "HeartbeatInterval is how often edges should send heartbeats.",
+	"github.com/haasonsaas/nexus/internal/config.EdgeConfig.HeartbeatTimeout":                  "HeartbeatTimeout is how long before an edge is considered disconnected.",
+	"github.com/haasonsaas/nexus/internal/config.EdgeConfig.MaxConcurrentTools":                "MaxConcurrentTools limits concurrent tool executions per edge.",
+	"github.com/haasonsaas/nexus/internal/config.EdgeConfig.Tokens":                            "Tokens maps edge IDs to pre-shared authentication tokens.\nOnly used when AuthMode is \"token\".",
+	"github.com/haasonsaas/nexus/internal/config.ElevatedConfig":                               "ElevatedConfig controls elevated tool execution behavior and allowlists.",
+	"github.com/haasonsaas/nexus/internal/config.ElevatedConfig.AllowFrom":                     "AllowFrom maps channel/provider to allowed sender identifiers.\nExample: {\"telegram\": [\"12345\", \"67890\"], \"discord\": [\"*\"]}",
+	"github.com/haasonsaas/nexus/internal/config.ElevatedConfig.Enabled":                       "Enabled gates elevated execution. When nil, elevated is disabled by default.",
+	"github.com/haasonsaas/nexus/internal/config.ElevatedConfig.Tools":                         "Tools lists tool patterns that elevated-full can bypass approvals for.\nIf empty, defaults to [\"execute_code\"] in gateway logic.",
+	"github.com/haasonsaas/nexus/internal/config.EmailComposeConfig":                           "EmailComposeConfig controls the email_compose tool, which sends new email to any address rather than replying.",
+	"github.com/haasonsaas/nexus/internal/config.EmailComposeConfig.RequireApproval":           "RequireApproval holds every email_compose call for approval (default: true).",
+	"github.com/haasonsaas/nexus/internal/config.EmailConfig.Address":                          "Address is the mailbox of the imap backend: the From address of\noutgoing mail and the default IMAP and SMTP username.",
+	"github.com/haasonsaas/nexus/internal/config.EmailConfig.AutoMarkRead":                     "AutoMarkRead marks messages as read after processing",
+	"github.com/haasonsaas/nexus/internal/config.EmailConfig.Backend":                          "Backend is \"graph\" (Microsoft Graph, default) or \"imap\" (IMAP and SMTP).",
+	"github.com/haasonsaas/nexus/internal/config.EmailConfig.Compose":                          "Compose configures the email_compose tool of the imap backend.",
+	"github.com/haasonsaas/nexus/internal/config.EmailConfig.DisplayName":                      "DisplayName is the From display name of the imap backend.",
+	"github.com/haasonsaas/nexus/internal/config.EmailConfig.FolderID":                         "FolderID specifies which folder to monitor (default: inbox)",
+	"github.com/haasonsaas/nexus/internal/config.EmailConfig.IncludeRead":                      "IncludeRead determines whether to process already-read messages",
+	"github.com/haasonsaas/nexus/internal/config.EmailConfig.OAuth2":                           "OAuth2 authenticates IMAP and SMTP with XOAUTH2 instead of passwords.",
+	"github.com/haasonsaas/nexus/internal/config.EmailConfig.PollInterval":                     "PollInterval for checking new emails (default: 30s)",
+	"github.com/haasonsaas/nexus/internal/config.EmailConfig.UserEmail":                        "UserEmail is the email address to monitor (for app-only auth)",
+	"github.com/haasonsaas/nexus/internal/config.EmailIMAPConfig":                              "EmailIMAPConfig configures the watched mailbox of the imap backend.",
+	"github.com/haasonsaas/nexus/internal/config.EmailIMAPConfig.IDLE":                         "IDLE waits for new mail with IMAP IDLE instead of polling (default: true).",
+	"github.com/haasonsaas/nexus/internal/config.EmailIMAPConfig.IdleTimeout":                  "IdleTimeout renews IDLE before servers drop it (default: 25m).",
+	"github.com/haasonsaas/nexus/internal/config.EmailIMAPConfig.Password":                     "Password is the account or app password.",
+	"github.com/haasonsaas/nexus/internal/config.EmailIMAPConfig.Port":                         "Port defaults to 993 for tls and 143 otherwise.",
+	"github.com/haasonsaas/nexus/internal/config.EmailIMAPConfig.Security":                     "Security is tls (default), starttls, or none.",
+	"github.com/haasonsaas/nexus/internal/config.EmailIMAPConfig.Username":                     "Username defaults to address.",
+	"github.com/haasonsaas/nexus/internal/config.EmailOAuth2Config":                            "EmailOAuth2Config holds XOAUTH2 tokens for the imap backend.",
+	"github.com/haasonsaas/nexus/internal/config.EmailSMTPConfig":                              "EmailSMTPConfig configures outgoing mail of the imap backend.",
+	"github.com/haasonsaas/nexus/internal/config.EmailSMTPConfig.Port":                         "Port defaults to 587 for starttls, 465 for tls, and 25 for none.",
+	"github.com/haasonsaas/nexus/internal/config.EmailSMTPConfig.Security":                     "Security is starttls (default), tls, or none.",
+	"github.com/haasonsaas/nexus/internal/config.EmailSMTPConfig.Username":                     "Username and Password default to the IMAP credentials.",
+	"github.com/haasonsaas/nexus/internal/config.EncryptionConfig":                             "EncryptionConfig controls field-level encryption of stored message content, vector memory entries, and artifact metadata.",
+	"github.com/haasonsaas/nexus/internal/config.EncryptionConfig.Keys":                        "Keys lists the primary key and any retired keys that existing values\nmay still be encrypted with. Keep a retired key here until\n\"nexus encryption migrate\" has re-encrypted everything.",
+	"github.com/haasonsaas/nexus/internal/config.EncryptionConfig.PrimaryKey":                  "PrimaryKey is the id of the key used to encrypt new values. Defaults to\nthe first configured key.",
+	"github.com/haasonsaas/nexus/internal/config.EncryptionKeyConfig":                          "EncryptionKeyConfig defines one AES-256 key.",
+	"github.com/haasonsaas/nexus/internal/config.EncryptionKeyConfig.AgeIdentityFile":          "AgeIdentityFile is an age identity file (AGE-SECRET-KEY-1...) the key\nis derived from.",
+	"github.com/haasonsaas/nexus/internal/config.EncryptionKeyConfig.Key":                      "Key is a 32-byte key encoded as base64 or hex, usually supplied from the\nenvironment, for example ${NEXUS_ENCRYPTION_KEY}.",
+	"github.com/haasonsaas/nexus/internal/config.ErasureConfig":                                "ErasureConfig configures where signed erasure records are written.",
+	"github.com/haasonsaas/nexus/internal/config.ErasureConfig.AuditDir":                       "AuditDir holds one signed JSON record per erasure.",
+	"github.com/haasonsaas/nexus/internal/config.ErasureConfig.SigningKeyPath":                 "SigningKeyPath is the ed25519 key used to sign erasure records. It is\ngenerated on first use when missing.",
+	"github.com/haasonsaas/nexus/internal/config.EventBridgeConfig":                            "EventBridgeConfig publishes gateway events (messages, runs, tool calls, approvals) to NATS or Kafka for downstream analytics pipelines.",
+	"github.com/haasonsaas/nexus/internal/config.EventBridgeConfig.Backend":                    "Backend is nats or kafka.",
+	"github.com/haasonsaas/nexus/internal/config.EventBridgeConfig.BufferSize":                 "BufferSize bounds events waiting to be published (default: 10000).",
+	"github.com/haasonsaas/nexus/internal/config.EventBridgeConfig.Channels":                   "Channels and Agents limit publishing to events from these channels\nor agents. Empty publishes from all.",
+	"github.com/haasonsaas/nexus/internal/config.EventBridgeConfig.Events":                     "Events limits publishing to these event types, e.g. \"run.*\" or\n\"approval.decided\". Empty publishes every type.",
+	"github.com/haasonsaas/nexus/internal/config.EventBridgeConfig.Format":                     "Format is json (default) or protobuf. Both carry the versioned\nnexus.events.v1.Event envelope.",
+	"github.com/haasonsaas/nexus/internal/config.EventBridgeConfig.IncludeContent":             "IncludeContent adds message text and tool arguments/results to the\npublished events. Off by default so payloads carry metadata only.",
+	"github.com/haasonsaas/nexus/internal/config.EventBridgeConfig.Subject":                    "Subject is the NATS subject or Kafka topic. \"{type}\" is replaced with\nthe event type (default: \"nexus.events.{type}\" for nats,\n\"nexus.events\" for kafka).",
+	"github.com/haasonsaas/nexus/internal/config.EventBridgeKafkaConfig":                       "EventBridgeKafkaConfig configures the Kafka backend, which produces through a Kafka REST proxy (Confluent REST Proxy or Redpanda HTTP proxy).",
+	"github.com/haasonsaas/nexus/internal/config.EventBridgeKafkaConfig.Headers":               "Headers are sent with every request, e.g. API keys.",
+	"github.com/haasonsaas/nexus/internal/config.EventBridgeKafkaConfig.RESTURL":               "RESTURL is the REST proxy base URL, e.g. http://kafka-rest:8082.",
+	"github.com/haasonsaas/nexus/internal/config.EventBridgeKafkaConfig.Timeout":               "Timeout bounds each produce request (default: 10s).",
+	"github.com/haasonsaas/nexus/internal/config.EventBridgeNATSConfig":                        "EventBridgeNATSConfig configures the NATS backend.",
+	"github.com/haasonsaas/nexus/internal/config.EventBridgeNATSConfig.Timeout":                "Timeout bounds connecting and each publish (default: 5s).",
+	"github.com/haasonsaas/nexus/internal/config.EventBridgeNATSConfig.URL":                    "URL is the server address, e.g. nats://nats:4222. Comma-separate\nseveral servers; use tls:// for TLS.",
+	"github.com/haasonsaas/nexus/internal/config.EventBusConfig":                               "EventBusConfig configures the cross-node event bus used to propagate approval decisions, session resets, config changes, and cache invalidation.",
+	"github.com/haasonsaas/nexus/internal/config.EventBusConfig.Backend":                       "Backend selects the transport: \"auto\" uses Postgres LISTEN/NOTIFY when\ncluster mode is enabled with a database and falls back to in-memory\ndelivery otherwise; \"postgres\" requires LISTEN/NOTIFY; \"memory\" never\nleaves the process.",
+	"github.com/haasonsaas/nexus/internal/config.EventBusConfig.Channel":                       "Channel is the Postgres notification channel name.",
+	"github.com/haasonsaas/nexus/internal/config.EventStoreConfig":                             "EventStoreConfig persists agent timeline events (runs, tool calls, LLM calls) to the database so timelines survive restarts and can be queried with `nexus events query` for postmortems.",
+	"github.com/haasonsaas/nexus/internal/config.EventStoreConfig.BufferSize":                  "BufferSize bounds events waiting to be written (default: 10000).",
+	"github.com/haasonsaas/nexus/internal/config.EventStoreConfig.FlushInterval":               "FlushInterval is how often buffered events are written (default: 5s).",
+	"github.com/haasonsaas/nexus/internal/config.EventStoreConfig.Retention":                   "Retention is how long persisted events are kept (default: 720h).",
+	"github.com/haasonsaas/nexus/internal/config.EventStoreConfig.SampleRate":                  "SampleRate is the fraction of runs whose events are persisted\n(default: 1.0). Sampling is per run, so a persisted timeline is\ncomplete. Error events are always persisted.",
+	"github.com/haasonsaas/nexus/internal/config.EventStoreConfig.Types":                       "Types limits persistence to these event types, e.g. \"run.*\" or\n\"tool.error\". Empty persists every type.",
+	"github.com/haasonsaas/nexus/internal/config.FactExtractConfig":                            "FactExtractConfig controls the structured fact extraction tool.",
+	"github.com/haasonsaas/nexus/internal/config.FeedbackConfig":                               "FeedbackConfig controls capturing reactions on agent replies as run feedback.",
+	"github.com/haasonsaas/nexus/internal/config.FeedbackConfig.PositiveReactions":             "PositiveReactions and NegativeReactions add emoji or platform reaction\nnames to the built-in thumbs up/down sets.",
+	"github.com/haasonsaas/nexus/internal/config.FeedbackConfig.StorePath":                     "StorePath is the JSONL file feedback is appended to\n(default: ~/.nexus/feedback.jsonl).",
+	"github.com/haasonsaas/nexus/internal/config.GatewayConfig":                                "GatewayConfig configures gateway-level message routing and processing.",
+	"github.com/haasonsaas/nexus/internal/config.GatewayConfig.WebhookHooks":                   "WebhookHooks configures inbound webhook handlers.",
+	"github.com/haasonsaas/nexus/internal/config.GitOpsConfig":                                 "GitOpsConfig syncs the config file from a git repository so it can be managed through pull requests.",
+	"github.com/haasonsaas/nexus/internal/config.GitOpsConfig.CacheDir":                        "CacheDir holds the fetched repository (default:\n~/.nexus/cache/gitops).",
+	"github.com/haasonsaas/nexus/internal/config.GitOpsConfig.Enabled":                         "Enabled turns on the sync loop.",
+	"github.com/haasonsaas/nexus/internal/config.GitOpsConfig.Interval":                        "Interval is how often the ref is polled (default: 1m).",
+	"github.com/haasonsaas/nexus/internal/config.GitOpsConfig.Mode":                            "Mode is \"apply\" (write the config and apply hot-reloadable changes)\nor \"report\" (only log drift). Default: apply.",
+	"github.com/haasonsaas/nexus/internal/config.GitOpsConfig.Path":                            "Path is the config file within the repository (default: nexus.yaml).\nIt must be self-contained: includes and extends are resolved next to\nthe local config file, not in the repository.",
+	"github.com/haasonsaas/nexus/internal/config.GitOpsConfig.Ref":                             "Ref is the branch, tag, or commit to follow (default: the remote's\ndefault branch).",
+	"github.com/haasonsaas/nexus/internal/config.GitOpsConfig.Repo":                            "Repo is the repository URL, in any form git fetch accepts.",
+	"github.com/haasonsaas/nexus/internal/config.GoogleSheetsConfig":                           "GoogleSheetsConfig configures Google Sheets access.",
+	"github.com/haasonsaas/nexus/internal/config.GoogleSheetsConfig.ClientID":                  "ClientID, ClientSecret and RefreshToken authorize as a Google user\ninstead of a service account.",
+	"github.com/haasonsaas/nexus/internal/config.GoogleSheetsConfig.CredentialsFile":           "CredentialsFile is a service account key file. The service account\ncan only open spreadsheets shared with its email address.",
+	"github.com/haasonsaas/nexus/internal/config.GoogleSheetsConfig.Spreadsheets":              "Spreadsheets maps names the model can use to spreadsheet IDs.",
+	"github.com/haasonsaas/nexus/internal/config.GroupInterjectConfig":                         "GroupInterjectConfig configures unprompted replies in group chats.",
+	"github.com/haasonsaas/nexus/internal/config.GroupInterjectConfig.MinRelevance":            "MinRelevance is the classifier score (0-1) needed to reply (default 0.7).",
+	"github.com/haasonsaas/nexus/internal/config.GroupInterjectConfig.Model":                   "Model overrides the model used for the relevance classifier.",
+	"github.com/haasonsaas/nexus/internal/config.GroupInterjectConfig.Probability":             "Probability is the chance an unaddressed message is considered at all\n(default 0.25), bounding classifier calls in busy groups.",
+	"github.com/haasonsaas/nexus/internal/config.HeartbeatConfig.Agents":                       "Agents overrides Schedule per agent ID.",
+	"github.com/haasonsaas/nexus/internal/config.HeartbeatConfig.CheckInterval":                "CheckInterval is how often the scheduler looks for sessions with a\nheartbeat due. Defaults to 1m.",
+	"github.com/haasonsaas/nexus/internal/config.HeartbeatConfig.Schedule":                     "Schedule is the default cadence for proactive heartbeat runs. No\nheartbeats are sent on a schedule unless a cadence is set here or in an\nagent, session, or /heartbeat override.",
+	"github.com/haasonsaas/nexus/internal/config.HeartbeatConfig.Sessions":                     "Sessions overrides the agent schedule per session key.",
+	"github.com/haasonsaas/nexus/internal/config.HeartbeatScheduleConfig":                      "HeartbeatScheduleConfig controls when proactive heartbeat runs fire.",
+	"github.com/haasonsaas/nexus/internal/config.HeartbeatScheduleConfig.Cron":                 "Cron runs a heartbeat on a cron expression, e.g. \"0 9,17 * * 1-5\".\nSetting Every or Cron in an override replaces both.",
+	"github.com/haasonsaas/nexus/internal/config.HeartbeatScheduleConfig.Disabled":             "Disabled turns off scheduled heartbeats for this scope.",
+	"github.com/haasonsaas/nexus/internal/config.HeartbeatScheduleConfig.Every":                "Every runs a heartbeat at a fixed interval, e.g. 4h.",
+	"github.com/haasonsaas/nexus/internal/config.HeartbeatScheduleConfig.Prompt":               "Prompt replaces the default heartbeat prompt.",
+	"github.com/haasonsaas/nexus/internal/config.HeartbeatScheduleConfig.QuietHours":           "QuietHours suppresses heartbeats during a daily window.",
+	"github.com/haasonsaas/nexus/internal/config.HeartbeatScheduleConfig.SuppressIfActive":     "SuppressIfActive skips a heartbeat when the user sent a message within\nthis window.",
+	"github.com/haasonsaas/nexus/internal/config.HeartbeatScheduleConfig.Timezone":             "Timezone is used for cron expressions and quiet hours. Defaults to\nuser.timezone, then the gateway's local timezone.",
+	"github.com/haasonsaas/nexus/internal/config.HomeAssistantConfig.BaseURL":                  "BaseURL is the Home Assistant instance URL (e.g., http://homeassistant:8123).",
+	"github.com/haasonsaas/nexus/internal/config.HomeAssistantConfig.Timeout":                  "Timeout is the request timeout when calling Home Assistant APIs.",
+	"github.com/haasonsaas/nexus/internal/config.HomeAssistantConfig.Token":                    "Token is a long-lived access token.",
+	"github.com/haasonsaas/nexus/internal/config.IMessageConfig":                               "IMessageConfig configures iMessage.",
+	"github.com/haasonsaas/nexus/internal/config.LLMAutoDiscoverConfig":                        "LLMAutoDiscoverConfig configures local provider discovery.",
+	"github.com/haasonsaas/nexus/internal/config.LLMBatchConfig":                               "LLMBatchConfig configures the batch queue.",
+	"github.com/haasonsaas/nexus/internal/config.LLMBatchConfig.FlushInterval":                 "FlushInterval is how often queued requests are submitted (default: 1m).",
+	"github.com/haasonsaas/nexus/internal/config.LLMBatchConfig.MaxBatchSize":                  "MaxBatchSize submits a batch as soon as this many requests are\nqueued (default: 1000).",
+	"github.com/haasonsaas/nexus/internal/config.LLMBatchConfig.PollInterval":                  "PollInterval is how often submitted batches are checked (default: 1m).",
+	"github.com/haasonsaas/nexus/internal/config.LLMBatchConfig.Provider":                      "Provider is the provider batches are sent to: anthropic or openai\n(default: llm.default_provider).",
+	"github.com/haasonsaas/nexus/internal/config.LLMBatchConfig.Timeout":                       "Timeout is how long a job waits for a batched result before giving\nup (default: 24h, the providers' completion window).",
+	"github.com/haasonsaas/nexus/internal/config.LLMConfig.AutoDiscover":                       "AutoDiscover configures local provider discovery.",
+	"github.com/haasonsaas/nexus/internal/config.LLMConfig.Batch":                              "Batch configures the queue that sends background requests through a\nprovider batch API.",
+	"github.com/haasonsaas/nexus/internal/config.LLMConfig.Bedrock":                            "Bedrock configures AWS Bedrock model discovery.",
+	"github.com/haasonsaas/nexus/internal/config.LLMConfig.FallbackChain":                      "FallbackChain specifies provider IDs to try if the default provider fails.\nProviders are tried in order until one succeeds.\nExample: [\"openai\", \"google\"] - try OpenAI first, then Google.",
+	"github.com/haasonsaas/nexus/internal/config.LLMConfig.KeyRotation":                        "KeyRotation configures how provider API keys are rotated.",
+	"github.com/haasonsaas/nexus/internal/config.LLMConfig.Race":                               "Race sends interactive requests to two providers at once and keeps\nthe faster response.",
+	"github.com/haasonsaas/nexus/internal/config.LLMConfig.Reasoning":                          "Reasoning controls how model reasoning is recorded.",
+	"github.com/haasonsaas/nexus/internal/config.LLMConfig.Routing":                            "Routing configures intelligent provider routing.",
+	"github.com/haasonsaas/nexus/internal/config.LLMKeyRotationConfig":                         "LLMKeyRotationConfig configures demotion of failing provider keys and rotation reminders.",
+	"github.com/haasonsaas/nexus/internal/config.LLMKeyRotationConfig.AuthCooldown":            "AuthCooldown is how long a key answering 401 or 403 is skipped\n(default: 1h).",
+	"github.com/haasonsaas/nexus/internal/config.LLMKeyRotationConfig.RateLimitCooldown":       "RateLimitCooldown is how long a key answering 429 is skipped\n(default: 1m).",
+	"github.com/haasonsaas/nexus/internal/config.LLMKeyRotationConfig.RemindAfter":             "RemindAfter is the key age at which rotation reminders start\n(default: 90 days). A negative value disables reminders.",
+	"github.com/haasonsaas/nexus/internal/config.LLMProviderConfig.Keys":                       "Keys lists API keys shared by weight across requests. APIKey, when\nalso set, joins the rotation as key \"default\" with weight 1.",
+	"github.com/haasonsaas/nexus/internal/config.LLMProviderConfig.RateLimit":                  "RateLimit throttles requests to the provider across all its keys and\nprofiles.",
+	"github.com/haasonsaas/nexus/internal/config.LLMProviderKeyConfig":                         "LLMProviderKeyConfig is one API key in a provider's rotation.",
+	"github.com/haasonsaas/nexus/internal/config.LLMProviderKeyConfig.ID":                      "ID names the key in logs and for `nexus auth rotate --key-id`\n(default: key-<n>).",
+	"github.com/haasonsaas/nexus/internal/config.LLMProviderKeyConfig.Key":                     "Key is the API key. KeyFile reads it from a file, such as a mounted\nsecret, instead.",
+	"github.com/haasonsaas/nexus/internal/config.LLMProviderKeyConfig.RateLimit":               "RateLimit throttles requests made with this key. A request the key\ncannot take in time moves on to the next key.",
+	"github.com/haasonsaas/nexus/internal/config.LLMProviderKeyConfig.RotatedAt":               "RotatedAt is when the key was last replaced; rotation reminders are\ncounted from it.",
+	"github.com/haasonsaas/nexus/internal/config.LLMProviderKeyConfig.Weight":                  "Weight is the key's share of requests relative to the other keys\n(default: 1).",
+	"github.com/haasonsaas/nexus/internal/config.LLMRaceConfig":                                "LLMRaceConfig configures speculative racing: each interactive request goes to both contenders, the first to produce text or a tool call is streamed, and the other is cancelled.",
+	"github.com/haasonsaas/nexus/internal/config.LLMRaceConfig.Channels":                       "Channels limits racing to sessions on these channels (default: all).\nRequests without a session, such as background jobs, never race.",
+	"github.com/haasonsaas/nexus/internal/config.LLMRaceConfig.Contenders":                     "Contenders are the two providers to race, e.g. a local ollama model\nand a cloud model. Model defaults to the provider's default model.",
+	"github.com/haasonsaas/nexus/internal/config.LLMRaceConfig.DailyBudgetUSD":                 "DailyBudgetUSD caps the estimated cost of all races per UTC day;\nlater requests are not raced (default: no cap).",
+	"github.com/haasonsaas/nexus/internal/config.LLMRaceConfig.MaxCostUSD":                     "MaxCostUSD races only requests whose estimated cost on both\ncontenders together is at or below it (default: no cap).",
+	"github.com/haasonsaas/nexus/internal/config.LLMRateLimitConfig":                           "LLMRateLimitConfig caps the request rate, token rate, and concurrency sent to a provider or key.",
+	"github.com/haasonsaas/nexus/internal/config.LLMRateLimitConfig.MaxConcurrent":             "MaxConcurrent caps requests in flight at once.",
+	"github.com/haasonsaas/nexus/internal/config.LLMRateLimitConfig.MaxWait":                   "MaxWait is the longest a request queues for capacity before failing\nover (default: 30s). Requests with an earlier deadline fail sooner.",
+	"github.com/haasonsaas/nexus/internal/config.LLMRateLimitConfig.RequestsPerMinute":         "RequestsPerMinute caps requests started per minute (RPM).",
+	"github.com/haasonsaas/nexus/internal/config.LLMRateLimitConfig.TokensPerMinute":           "TokensPerMinute caps input plus output tokens per minute (TPM).",
+	"github.com/haasonsaas/nexus/internal/config.LLMReasoningConfig":                           "LLMReasoningConfig controls how extended thinking (Anthropic) and reasoning (OpenAI o-series, inline <think> models) is recorded.",
+	"github.com/haasonsaas/nexus/internal/config.LLMReasoningConfig.Redact":                    "Redact replaces reasoning text in model.reasoning trace events with\nits length (default: true). Reasoning token counts are always kept.",
+	"github.com/haasonsaas/nexus/internal/config.LLMRoutingConfig":                             "LLMRoutingConfig configures provider routing rules.",
+	"github.com/haasonsaas/nexus/internal/config.LLMRoutingConfig.Classifier":                  "Classifier tags requests for rules that match on tags: \"heuristic\"\n(default) or \"llm\".",
+	"github.com/haasonsaas/nexus/internal/config.LLMRoutingConfig.ClassifierLLM":               "ClassifierLLM configures the \"llm\" classifier.",
+	"github.com/haasonsaas/nexus/internal/config.LanguageConfig":                               "LanguageConfig steers the agent to reply in each user's language.",
+	"github.com/haasonsaas/nexus/internal/config.LanguageConfig.Default":                       "Default is the reply language when none of the above applies. Empty\nleaves the choice to the model.",
+	"github.com/haasonsaas/nexus/internal/config.LanguageConfig.Detect":                        "Detect guesses the language of inbound messages. Defaults to true.",
+	"github.com/haasonsaas/nexus/internal/config.LanguageConfig.Enabled":                       "Enabled instructs the model to reply in the user's language: the one\nchosen with /language, set in their identity profile, or detected.",
+	"github.com/haasonsaas/nexus/internal/config.LinkModelConfig":                              "LinkModelConfig defines a link processing model.",
+	"github.com/haasonsaas/nexus/internal/config.LinkModelConfig.Args":                         "Args are the command arguments. Supports template variables:\n{{LinkUrl}}, {{URL}}, {{url}} - the URL to process\n{{Channel}}, {{SessionID}}, {{PeerID}}, {{AgentID}} - context info",
+	"github.com/haasonsaas/nexus/internal/config.LinkModelConfig.Command":                      "Command is the CLI command to execute.",
+	"github.com/haasonsaas/nexus/internal/config.LinkModelConfig.MaxInputChars":                "MaxInputChars caps the page text sent to the model. Default: 12000.",
+	"github.com/haasonsaas/nexus/internal/config.LinkModelConfig.MaxTokens":                    "MaxTokens caps the summary length. Default: 300.",
+	"github.com/haasonsaas/nexus/internal/config.LinkModelConfig.Model":                        "Model is the summarization model; pick a cheap one (default: the provider's).",
+	"github.com/haasonsaas/nexus/internal/config.LinkModelConfig.Prompt":                       "Prompt overrides the summarization instructions.",
+	"github.com/haasonsaas/nexus/internal/config.LinkModelConfig.Provider":                     "Provider is the LLM provider for \"llm\" models (default: llm.default_provider).",
+	"github.com/haasonsaas/nexus/internal/config.LinkModelConfig.TimeoutSeconds":               "TimeoutSeconds overrides the default timeout for this model.",
+	"github.com/haasonsaas/nexus/internal/config.LinkModelConfig.Type":                         "Type is the model type: \"cli\" runs Command; \"llm\" fetches the page\n(rendering it in the browser pool when needed) and summarizes it.",
+	"github.com/haasonsaas/nexus/internal/config.LinkScopeConfig":                              "LinkScopeConfig controls which channels can use link understanding.",
+	"github.com/haasonsaas/nexus/internal/config.LinkScopeConfig.Allowlist":                    "Allowlist is the list of channels to allow when mode is \"allowlist\".\nSupports channel names (\"telegram\"), channel:peer_id (\"telegram:123\"), or \"*\".",
+	"github.com/haasonsaas/nexus/internal/config.LinkScopeConfig.Denylist":                     "Denylist is the list of channels to deny when mode is \"denylist\".",
+	"github.com/haasonsaas/nexus/internal/config.LinkScopeConfig.Mode":                         "Mode is the scope mode: \"all\", \"allowlist\", \"denylist\".\nDefault: \"all\".",
+	"github.com/haasonsaas/nexus/internal/config.LinksConfig":                                  "LinksConfig configures link understanding for extracting and processing URLs.",
+	"github.com/haasonsaas/nexus/internal/config.LinksConfig.Enabled":                          "Enabled enables link understanding.",
+	"github.com/haasonsaas/nexus/internal/config.LinksConfig.MaxLinks":                         "MaxLinks is the maximum number of links to extract from a message.\nDefault: 5.",
+	"github.com/haasonsaas/nexus/internal/config.LinksConfig.MaxOutputChars":                   "MaxOutputChars caps the number of characters injected into the prompt.\nDefault: 2000.",
+	"github.com/haasonsaas/nexus/internal/config.LinksConfig.Models":                           "Models are the link processing model configurations.",
+	"github.com/haasonsaas/nexus/internal/config.LinksConfig.Scope":                            "Scope controls which channels can use link understanding.",
+	"github.com/haasonsaas/nexus/internal/config.LinksConfig.SummaryCacheTTL":                  "SummaryCacheTTL is how long \"llm\" summaries are cached per URL.\nDefault: 24h.",
+	"github.com/haasonsaas/nexus/internal/config.LinksConfig.TimeoutSeconds":                   "TimeoutSeconds is the default timeout for link processing.\nDefault: 30.",
+	"github.com/haasonsaas/nexus/internal/config.MarketplaceConfig":                            "MarketplaceConfig configures the plugin marketplace.",
+	"github.com/haasonsaas/nexus/internal/config.MarketplaceConfig.AutoUpdate":                 "AutoUpdate enables automatic updates for plugins.",
+	"github.com/haasonsaas/nexus/internal/config.MarketplaceConfig.CheckInterval":              "CheckInterval is how often to check for updates (e.g., \"24h\").",
+	"github.com/haasonsaas/nexus/internal/config.MarketplaceConfig.Enabled":                    "Enabled enables marketplace functionality.",
+	"github.com/haasonsaas/nexus/internal/config.MarketplaceConfig.Registries":                 "Registries are the registry URLs to search for plugins.",
+	"github.com/haasonsaas/nexus/internal/config.MarketplaceConfig.SkipVerify":                 "SkipVerify skips signature verification (not recommended).",
+	"github.com/haasonsaas/nexus/internal/config.MarketplaceConfig.TrustedKeys":                "TrustedKeys are the trusted signing keys (name -> base64 public key).",
+	"github.com/haasonsaas/nexus/internal/config.MattermostConfig.Password":                    "Password for login-based authentication (optional).",
+	"github.com/haasonsaas/nexus/internal/config.MattermostConfig.RateBurst":                   "RateBurst configures burst capacity for rate limiting.",
+	"github.com/haasonsaas/nexus/internal/config.MattermostConfig.RateLimit":                   "RateLimit configures rate limiting for API calls (ops/sec).",
+	"github.com/haasonsaas/nexus/internal/config.MattermostConfig.ServerURL":                   "ServerURL is the Mattermost server URL (required).",
+	"github.com/haasonsaas/nexus/internal/config.MattermostConfig.TeamName":                    "TeamName is the default team to operate in (optional).",
+	"github.com/haasonsaas/nexus/internal/config.MattermostConfig.Token":                       "Token is the bot token for API calls (optional).\nEither Token or (Username + Password) must be provided.",
+	"github.com/haasonsaas/nexus/internal/config.MattermostConfig.Username":                    "Username for login-based authentication (optional).",
+	"github.com/haasonsaas/nexus/internal/config.MemoryConfig.Consolidation":                   "Consolidation folds old daily memory files into MEMORY.md.",
+	"github.com/haasonsaas/nexus/internal/config.MemoryConfig.Review":                          "Review holds what the agent asks to remember for approval.",
+	"github.com/haasonsaas/nexus/internal/config.MemoryConsolidationConfig":                    "MemoryConsolidationConfig schedules the job that summarizes old daily memory files (directory/YYYY-MM-DD.md) into topic sections of the workspace memory file, archives the originals, and indexes the summaries into vector memory.",
+	"github.com/haasonsaas/nexus/internal/config.MemoryConsolidationConfig.ArchiveDir":         "ArchiveDir receives the consolidated files. Defaults to the archive\nsubdirectory of the memory directory.",
+	"github.com/haasonsaas/nexus/internal/config.MemoryConsolidationConfig.Batch":              "Batch sends the summary request through the llm.batch queue, at\nabout half the price; the files are consolidated once it completes.",
+	"github.com/haasonsaas/nexus/internal/config.MemoryConsolidationConfig.MaxInputChars":      "MaxInputChars caps the daily file content sent in one run; files past\nthe cap wait for the next run (default: 40000).",
+	"github.com/haasonsaas/nexus/internal/config.MemoryConsolidationConfig.MaxOutputTokens":    "MaxOutputTokens caps the length of the summary (default: 1000).",
+	"github.com/haasonsaas/nexus/internal/config.MemoryConsolidationConfig.MinAgeDays":         "MinAgeDays is how many days old a daily file must be before it is\nconsolidated (default: 7).",
+	"github.com/haasonsaas/nexus/internal/config.MemoryConsolidationConfig.Model":              "Model is the model that writes the summaries; a cheap one is enough.\nDefaults to the gateway's default model.",
+	"github.com/haasonsaas/nexus/internal/config.MemoryConsolidationConfig.Schedule":           "Schedule is a cron expression (default: \"0 3 * * *\", daily at 03:00).",
+	"github.com/haasonsaas/nexus/internal/config.MemoryConsolidationConfig.Timezone":           "Timezone is the IANA zone the schedule is evaluated in. Defaults to\nuser.timezone.",
+	"github.com/haasonsaas/nexus/internal/config.MemoryReviewConfig":                           "MemoryReviewConfig gates memory writes behind user review.",
+	"github.com/haasonsaas/nexus/internal/config.MemoryReviewConfig.AutoApproveScopes":         "AutoApproveScopes lists the scopes stored without review: session,\nchannel, agent, and global for vector memory, and file for the\nworkspace memory file. Defaults to [session], since session memories\ndo not outlive the conversation; set [] to review every write.",
+	"github.com/haasonsaas/nexus/internal/config.MemoryReviewConfig.Dir":                       "Dir holds the review queue. Defaults to ~/.nexus/memory-review.",
+	"github.com/haasonsaas/nexus/internal/config.MemoryReviewConfig.Enabled":                   "Enabled queues memory writes for review.",
+	"github.com/haasonsaas/nexus/internal/config.MessagesConfig":                               "MessagesConfig localizes and brands the text Nexus sends on its own behalf (pairing prompts, approval notices, errors and quota warnings).",
+	"github.com/haasonsaas/nexus/internal/config.MessagesConfig.Channels":                      "Channels sets the language per channel, e.g. {telegram: es}. A\nlocale in the user's identity profile takes precedence.",
+	"github.com/haasonsaas/nexus/internal/config.MessagesConfig.Locale":                        "Locale is the fallback language when the user's profile and channel\ndo not name one. Defaults to English.",
+	"github.com/haasonsaas/nexus/internal/config.MessagesConfig.Templates":                     "Templates overrides messages by key and locale using Go template\nsyntax, e.g. {\"error.run_failed\": {en: \"Oops, try again.\"}}.",
+	"github.com/haasonsaas/nexus/internal/config.MetricsConfig":                                "MetricsConfig selects how gateway metrics leave the process: scraped from /metrics, pushed to an OTLP collector, or both.",
+	"github.com/haasonsaas/nexus/internal/config.MetricsConfig.Exporter":                       "Exporter is prometheus (default), otlp, or both. With otlp the\n/metrics endpoint is not served.",
+	"github.com/haasonsaas/nexus/internal/config.NextcloudTalkConfig.BaseURL":                  "BaseURL is the Nextcloud server base URL (required).",
+	"github.com/haasonsaas/nexus/internal/config.NextcloudTalkConfig.BotSecret":                "BotSecret is the bot secret for webhook verification (required).",
+	"github.com/haasonsaas/nexus/internal/config.NextcloudTalkConfig.RateBurst":                "RateBurst configures burst capacity for rate limiting.",
+	"github.com/haasonsaas/nexus/internal/config.NextcloudTalkConfig.RateLimit":                "RateLimit configures rate limiting for API calls (ops/sec).",
+	"github.com/haasonsaas/nexus/internal/config.NextcloudTalkConfig.WebhookHost":              "WebhookHost is the host for the webhook server (default: 0.0.0.0).",
+	"github.com/haasonsaas/nexus/internal/config.NextcloudTalkConfig.WebhookPath":              "WebhookPath is the path for the webhook endpoint (default: /nextcloud-talk-webhook).",
+	"github.com/haasonsaas/nexus/internal/config.NextcloudTalkConfig.WebhookPort":              "WebhookPort is the port for the webhook server (default: 8788).",
+	"github.com/haasonsaas/nexus/internal/config.OCRConfig":                                    "OCRConfig configures text extraction from images.",
+	"github.com/haasonsaas/nexus/internal/config.OCRConfig.APIKey":                             "APIKey is the Google Cloud Vision API key (provider: google)",
+	"github.com/haasonsaas/nexus/internal/config.OCRConfig.BaseURL":                            "BaseURL overrides the Google Cloud Vision endpoint",
+	"github.com/haasonsaas/nexus/internal/config.OCRConfig.Enabled":                            "Enabled extracts text from inbound images and enables the ocr_image tool",
+	"github.com/haasonsaas/nexus/internal/config.OCRConfig.Languages":                          "Languages are tesseract language codes (default: [eng])",
+	"github.com/haasonsaas/nexus/internal/config.OCRConfig.Provider":                           "Provider is the OCR engine (\"tesseract\" or \"google\")",
+	"github.com/haasonsaas/nexus/internal/config.OCRConfig.TesseractPath":                      "TesseractPath is the tesseract binary (default: tesseract on PATH)",
+	"github.com/haasonsaas/nexus/internal/config.OCRConfig.Timeout":                            "Timeout bounds a single extraction (default: 30s)",
+	"github.com/haasonsaas/nexus/internal/config.OIDCConfig":                                   "OIDCConfig enables single sign-on through an OpenID Connect provider for the HTTP surfaces: the dashboard, the canvas host, and artifact links.",
+	"github.com/haasonsaas/nexus/internal/config.OIDCConfig.DefaultRole":                       "DefaultRole applies to users in no mapped group; empty refuses them.",
+	"github.com/haasonsaas/nexus/internal/config.OIDCConfig.GroupRoles":                        "GroupRoles maps groups to \"read-only\", \"operator\", or \"admin\". Users in\nseveral mapped groups get the highest role.",
+	"github.com/haasonsaas/nexus/internal/config.OIDCConfig.GroupsClaim":                       "GroupsClaim names the ID token claim that lists the user's groups.",
+	"github.com/haasonsaas/nexus/internal/config.OIDCConfig.Issuer":                            "Issuer is the provider URL; endpoints are discovered from\n<issuer>/.well-known/openid-configuration.",
+	"github.com/haasonsaas/nexus/internal/config.OIDCConfig.RedirectURL":                       "RedirectURL is the gateway's callback, ending in /auth/oidc/callback.",
+	"github.com/haasonsaas/nexus/internal/config.OIDCConfig.SessionTTL":                        "SessionTTL is how long a login lasts.",
+	"github.com/haasonsaas/nexus/internal/config.OTLPMetricsConfig":                            "OTLPMetricsConfig configures the OTLP metrics push.",
+	"github.com/haasonsaas/nexus/internal/config.OTLPMetricsConfig.Endpoint":                   "Endpoint is the collector address, e.g. otel-collector:4317 for grpc\nor https://otlp.example.com/v1/metrics for http.",
+	"github.com/haasonsaas/nexus/internal/config.OTLPMetricsConfig.Headers":                    "Headers are sent with every push, e.g. collector API keys.",
+	"github.com/haasonsaas/nexus/internal/config.OTLPMetricsConfig.Interval":                   "Interval is how often metrics are pushed (default: 60s).",
+	"github.com/haasonsaas/nexus/internal/config.OTLPMetricsConfig.Protocol":                   "Protocol is grpc (default) or http (protobuf over HTTP).",
+	"github.com/haasonsaas/nexus/internal/config.OTLPMetricsConfig.ResourceAttributes":         "ResourceAttributes are added to the exported resource. service.name,\nservice.version, and deployment.environment default to the tracing\nsettings.",
+	"github.com/haasonsaas/nexus/internal/config.OTLPMetricsConfig.Timeout":                    "Timeout bounds each push (default: 10s).",
+	"github.com/haasonsaas/nexus/internal/config.ObservabilityConfig":                          "ObservabilityConfig configures tracing and other observability features.",
+	"github.com/haasonsaas/nexus/internal/config.OllamaDiscoverConfig":                         "OllamaDiscoverConfig configures Ollama discovery.",
+	"github.com/haasonsaas/nexus/internal/config.OutboundBroadcastConfig":                      "OutboundBroadcastConfig configures outbound broadcast lists.",
+	"github.com/haasonsaas/nexus/internal/config.OutboundBroadcastConfig.Lists":                "Lists maps a list name to the channels/peers a broadcast delivers to.",
+	"github.com/haasonsaas/nexus/internal/config.OutboundBroadcastConfig.MaxAttempts":          "MaxAttempts is the number of delivery attempts per target (default 3).",
+	"github.com/haasonsaas/nexus/internal/config.OutboundBroadcastConfig.RetryDelay":           "RetryDelay is the delay before the first retry; it doubles on each\nsubsequent attempt (default 2s).",
+	"github.com/haasonsaas/nexus/internal/config.PlanToolConfig":                               "PlanToolConfig configures the plan tool, a structured to-do list the agent keeps for long tasks.",
+	"github.com/haasonsaas/nexus/internal/config.PlanToolConfig.Enabled":                       "Enabled registers the plan tool.",
+	"github.com/haasonsaas/nexus/internal/config.PlanToolConfig.Progress":                      "Progress shows the plan as a checklist message that is edited as steps\nchange, on channels that can edit messages (default: true).",
+	"github.com/haasonsaas/nexus/internal/config.PluginIsolationConfig":                        "PluginIsolationConfig configures (future) out-of-process plugin execution.",
+	"github.com/haasonsaas/nexus/internal/config.PluginIsolationConfig.Backend":                "docker | firecracker | daytona",
+	"github.com/haasonsaas/nexus/internal/config.PluginIsolationConfig.TraceEndpoint":          "TraceEndpoint is an OTLP gRPC collector reachable from the sandbox.\nWhen set, isolated tool executions export spans that join the\ngateway's trace; the trace context is passed either way.",
+	"github.com/haasonsaas/nexus/internal/config.PrivacyConfig":                                "PrivacyConfig controls data retention and identity erasure.",
+	"github.com/haasonsaas/nexus/internal/config.QuietHoursConfig":                             "QuietHoursConfig is a daily window in HH:MM.",
+	"github.com/haasonsaas/nexus/internal/config.RAGChunkingConfig":                            "RAGChunkingConfig configures document chunking.",
+	"github.com/haasonsaas/nexus/internal/config.RAGChunkingConfig.ChunkOverlap":               "ChunkOverlap is the overlap between chunks in characters.\nDefault: 200",
+	"github.com/haasonsaas/nexus/internal/config.RAGChunkingConfig.ChunkSize":                  "ChunkSize is the target chunk size in characters.\nDefault: 1000",
+	"github.com/haasonsaas/nexus/internal/config.RAGChunkingConfig.MinChunkSize":               "MinChunkSize is the minimum chunk size to keep.\nDefault: 100",
+	"github.com/haasonsaas/nexus/internal/config.RAGConfig":                                    "RAGConfig configures the Retrieval-Augmented Generation pipeline.",
+	"github.com/haasonsaas/nexus/internal/config.RAGConfig.Chunking":                           "Chunking configures document chunking.",
+	"github.com/haasonsaas/nexus/internal/config.RAGConfig.ContextInjection":                   "ContextInjection configures automatic context injection.",
+	"github.com/haasonsaas/nexus/internal/config.RAGConfig.Embeddings":                         "Embeddings configures the embedding provider.",
+	"github.com/haasonsaas/nexus/internal/config.RAGConfig.Enabled":                            "Enabled enables the RAG system.",
+	"github.com/haasonsaas/nexus/internal/config.RAGConfig.Search":                             "Search configures default search behavior.",
+	"github.com/haasonsaas/nexus/internal/config.RAGConfig.Store":                              "Store configures the document store backend.",
+	"github.com/haasonsaas/nexus/internal/config.RAGContextInjectionConfig":                    "RAGContextInjectionConfig configures automatic context injection.",
+	"github.com/haasonsaas/nexus/internal/config.RAGContextInjectionConfig.Enabled":            "Enabled enables automatic RAG context injection.",
+	"github.com/haasonsaas/nexus/internal/config.RAGContextInjectionConfig.MaxChunks":          "MaxChunks is the maximum chunks to inject.\nDefault: 5",
+	"github.com/haasonsaas/nexus/internal/config.RAGContextInjectionConfig.MaxTokens":          "MaxTokens is the maximum tokens to inject.\nDefault: 2000",
+	"github.com/haasonsaas/nexus/internal/config.RAGContextInjectionConfig.MinScore":           "MinScore is the minimum similarity score for inclusion.\nDefault: 0.7",
+	"github.com/haasonsaas/nexus/internal/config.RAGContextInjectionConfig.Scope":              "Scope limits retrieval: \"global\", \"agent\", \"session\", \"channel\"\nDefault: \"global\"",
+	"github.com/haasonsaas/nexus/internal/config.RAGEmbeddingsConfig":                          "RAGEmbeddingsConfig configures the embedding provider for RAG.",
+	"github.com/haasonsaas/nexus/internal/config.RAGEmbeddingsConfig.APIKey":                   "APIKey is the API key for the provider.",
+	"github.com/haasonsaas/nexus/internal/config.RAGEmbeddingsConfig.BaseURL":                  "BaseURL is the API base URL (optional).",
+	"github.com/haasonsaas/nexus/internal/config.RAGEmbeddingsConfig.BatchSize":                "BatchSize is the maximum texts per embedding batch.\nDefault: 100",
+	"github.com/haasonsaas/nexus/internal/config.RAGEmbeddingsConfig.Model":                    "Model is the embedding model to use.\nDefault: \"text-embedding-3-small\" for OpenAI",
+	"github.com/haasonsaas/nexus/internal/config.RAGEmbeddingsConfig.Provider":                 "Provider is the embedding provider: \"openai\", \"ollama\"",
+	"github.com/haasonsaas/nexus/internal/config.RAGSearchConfig":                              "RAGSearchConfig configures default search behavior.",
+	"github.com/haasonsaas/nexus/internal/config.RAGSearchConfig.DefaultLimit":                 "DefaultLimit is the default number of results.\nDefault: 5",
+	"github.com/haasonsaas/nexus/internal/config.RAGSearchConfig.DefaultThreshold":             "DefaultThreshold is the default similarity threshold (0-1).\nDefault: 0.7",
+	"github.com/haasonsaas/nexus/internal/config.RAGSearchConfig.MaxResults":                   "MaxResults is the maximum results allowed.\nDefault: 20",
+	"github.com/haasonsaas/nexus/internal/config.RAGStoreConfig":                               "RAGStoreConfig configures the RAG document store.",
+	"github.com/haasonsaas/nexus/internal/config.RAGStoreConfig.Backend":                       "Backend is the storage backend: \"pgvector\"",
+	"github.com/haasonsaas/nexus/internal/config.RAGStoreConfig.DSN":                           "DSN is the PostgreSQL connection string (for pgvector).\nIf empty and UseDatabaseURL is true, uses the main database.url.",
+	"github.com/haasonsaas/nexus/internal/config.RAGStoreConfig.Dimension":                     "Dimension is the embedding vector dimension.\nDefault: 1536 (OpenAI text-embedding-3-small)",
+	"github.com/haasonsaas/nexus/internal/config.RAGStoreConfig.RunMigrations":                 "RunMigrations controls whether to run migrations on startup.",
+	"github.com/haasonsaas/nexus/internal/config.RAGStoreConfig.UseDatabaseURL":                "UseDatabaseURL uses the main database.url for pgvector storage.",
+	"github.com/haasonsaas/nexus/internal/config.ResetConfig":                                  "ResetConfig controls when sessions are automatically reset.",
+	"github.com/haasonsaas/nexus/internal/config.ResetConfig.AtHour":                           "AtHour is the hour (0-23) to reset sessions when mode includes \"daily\".",
+	"github.com/haasonsaas/nexus/internal/config.ResetConfig.IdleMinutes":                      "IdleMinutes is the number of minutes of inactivity before reset when mode includes \"idle\".",
+	"github.com/haasonsaas/nexus/internal/config.ResetConfig.Mode":                             "Mode is the reset mode: \"daily\", \"idle\", \"daily+idle\", or \"never\" (default).",
+	"github.com/haasonsaas/nexus/internal/config.RetentionConfig":                              "RetentionConfig controls scheduled deletion of stored conversation data.",
+	"github.com/haasonsaas/nexus/internal/config.RetentionConfig.Channels":                     "Channels overrides the default per channel type (slack, telegram, ...).",
+	"github.com/haasonsaas/nexus/internal/config.RetentionConfig.Default":                      "Default applies to data not covered by a channel or user override.",
+	"github.com/haasonsaas/nexus/internal/config.RetentionConfig.Users":                        "Users overrides channel and default policies per peer, keyed by\n\"provider:peer_id\" or by a canonical identity from session.scoping.identity_links.",
+	"github.com/haasonsaas/nexus/internal/config.RetentionPolicy":                              "RetentionPolicy sets the maximum age of each class of data.",
+	"github.com/haasonsaas/nexus/internal/config.RoutingClassifierLLMConfig":                   "RoutingClassifierLLMConfig configures the LLM routing classifier, which asks a small model to tag each request with the tags the rules use.",
+	"github.com/haasonsaas/nexus/internal/config.RoutingClassifierLLMConfig.CacheSize":         "CacheSize is how many classifications are kept (default: 1000).",
+	"github.com/haasonsaas/nexus/internal/config.RoutingClassifierLLMConfig.CacheTTL":          "CacheTTL is how long a classification is reused (default: 1h).",
+	"github.com/haasonsaas/nexus/internal/config.RoutingClassifierLLMConfig.Model":             "Model should be a cheap, fast model.",
+	"github.com/haasonsaas/nexus/internal/config.RoutingClassifierLLMConfig.Provider":          "Provider defaults to llm.default_provider.",
+	"github.com/haasonsaas/nexus/internal/config.RoutingClassifierLLMConfig.Timeout":           "Timeout bounds each classification; on timeout or error the\nheuristic classifier is used instead (default: 5s).",
+	"github.com/haasonsaas/nexus/internal/config.RoutingMatch":                                 "RoutingMatch defines rule matching criteria.",
+	"github.com/haasonsaas/nexus/internal/config.RoutingMatch.MaxCostUSD":                      "MaxCostUSD skips the rule when the request's estimated cost on the\ntarget model, from the model catalog, exceeds it.",
+	"github.com/haasonsaas/nexus/internal/config.RoutingMatch.MinTokens":                       "MinTokens and MaxTokens bound the estimated prompt size in tokens.",
+	"github.com/haasonsaas/nexus/internal/config.RoutingMatch.Tools":                           "Tools matches requests that offer (true) or do not offer (false)\ntools.",
+	"github.com/haasonsaas/nexus/internal/config.RoutingMatch.Vision":                          "Vision matches requests with (true) or without (false) image\nattachments.",
+	"github.com/haasonsaas/nexus/internal/config.RoutingRule":                                  "RoutingRule defines a routing rule.",
+	"github.com/haasonsaas/nexus/internal/config.RoutingTarget":                                "RoutingTarget defines a routing destination.",
+	"github.com/haasonsaas/nexus/internal/config.RunRecoveryConfig":                            "RunRecoveryConfig controls how runs interrupted by a gateway restart are handled on the next startup.",
+	"github.com/haasonsaas/nexus/internal/config.RunRecoveryConfig.Enabled":                    "Enabled journals active runs so they can be recovered after a restart.",
+	"github.com/haasonsaas/nexus/internal/config.RunRecoveryConfig.MaxAge":                     "MaxAge is how old an interrupted run may be and still be resumed.\nOlder runs are finalized. Defaults to 30m.",
+	"github.com/haasonsaas/nexus/internal/config.RunRecoveryConfig.MaxAttempts":                "MaxAttempts caps how many times a run is resumed before it is\nfinalized, so a run that crashes the gateway is not retried forever.\nDefaults to 1.",
+	"github.com/haasonsaas/nexus/internal/config.RunRecoveryConfig.Mode":                       "Mode is \"resume\" (default) to continue interrupted runs, or \"finalize\"\nto tell the user the run was interrupted without continuing it.",
+	"github.com/haasonsaas/nexus/internal/config.RunRecoveryConfig.StorePath":                  "StorePath is the run journal file. Defaults to ~/.nexus/active_runs.json.",
+	"github.com/haasonsaas/nexus/internal/config.SLOAlertConfig":                               "SLOAlertConfig controls burn-rate alerts.",
+	"github.com/haasonsaas/nexus/internal/config.SLOAlertConfig.CriticalBurnRate":              "CriticalBurnRate defaults to 14.4 (2% of a 30 day budget in an hour).",
+	"github.com/haasonsaas/nexus/internal/config.SLOAlertConfig.WarningBurnRate":               "WarningBurnRate defaults to 6 (5% of a 30 day budget in six hours).",
+	"github.com/haasonsaas/nexus/internal/config.SLOAlertConfig.WebhookURL":                    "WebhookURL receives a JSON POST when an objective's alert level changes.",
+	"github.com/haasonsaas/nexus/internal/config.SLOConfig":                                    "SLOConfig defines service level objectives evaluated against the gateway's Prometheus metrics.",
+	"github.com/haasonsaas/nexus/internal/config.SLOConfig.Interval":                           "Interval is how often SLOs are evaluated (default: 30s).",
+	"github.com/haasonsaas/nexus/internal/config.SLOConfig.Window":                             "Window is the compliance period error budgets are measured over\n(default: 720h). Samples are kept in memory, so compliance restarts\nwith the process.",
+	"github.com/haasonsaas/nexus/internal/config.SLOErrorsConfig":                              "SLOErrorsConfig counts counter increments whose Label is one of Values as bad events.",
+	"github.com/haasonsaas/nexus/internal/config.SLOErrorsConfig.Label":                        "Label and Values identify failures (default: outcome in [error]).",
+	"github.com/haasonsaas/nexus/internal/config.SLOErrorsConfig.Labels":                       "Labels restricts the series, e.g. {channel: slack}.",
+	"github.com/haasonsaas/nexus/internal/config.SLOErrorsConfig.Metric":                       "Metric is a counter name (default: nexus_runs_total).",
+	"github.com/haasonsaas/nexus/internal/config.SLOLatencyConfig":                             "SLOLatencyConfig counts histogram observations at or below Threshold as good events.",
+	"github.com/haasonsaas/nexus/internal/config.SLOLatencyConfig.Labels":                      "Labels restricts the series, e.g. {channel: slack}.",
+	"github.com/haasonsaas/nexus/internal/config.SLOLatencyConfig.Metric":                      "Metric is a histogram name (default: nexus_reply_duration_seconds).",
+	"github.com/haasonsaas/nexus/internal/config.SLOLatencyConfig.Threshold":                   "Threshold should match a bucket boundary; otherwise the nearest lower\nbucket is used.",
+	"github.com/haasonsaas/nexus/internal/config.SLOObjectiveConfig":                           "SLOObjectiveConfig is a single objective.",
+	"github.com/haasonsaas/nexus/internal/config.SLOObjectiveConfig.Target":                    "Target is the share of good events, e.g. 0.95.",
+	"github.com/haasonsaas/nexus/internal/config.SQLDatabaseConfig":                            "SQLDatabaseConfig is a database the sql_query tool can query.",
+	"github.com/haasonsaas/nexus/internal/config.SQLDatabaseConfig.Description":                "Description tells the model what the database holds.",
+	"github.com/haasonsaas/nexus/internal/config.SQLDatabaseConfig.Driver":                     "Driver is postgres, mysql or sqlite.",
+	"github.com/haasonsaas/nexus/internal/config.SQLQueryConfig":                               "SQLQueryConfig configures the sql_query tool, which runs read-only queries against external databases.",
+	"github.com/haasonsaas/nexus/internal/config.SQLQueryConfig.MaxBytes":                      "MaxBytes caps the size of a markdown result (default: 64KB).",
+	"github.com/haasonsaas/nexus/internal/config.SQLQueryConfig.MaxRows":                       "MaxRows caps the rows returned per query (default: 200).",
+	"github.com/haasonsaas/nexus/internal/config.SQLQueryConfig.Statements":                    "Statements are the allowed leading keywords, from select, with,\nvalues, explain, show and describe. Defaults to select and with.",
+	"github.com/haasonsaas/nexus/internal/config.SQLQueryConfig.Timeout":                       "Timeout bounds each query (default: 10s).",
+	"github.com/haasonsaas/nexus/internal/config.SandboxConfig.Languages":                      "Languages pins the image each language runs in, keyed by python,\nnodejs, go, or bash. Languages not listed use the built-in images.",
+	"github.com/haasonsaas/nexus/internal/config.SandboxConfig.Mode":                           "Mode controls which agents use sandboxing:\n- \"off\": sandboxing disabled (default when enabled=false)\n- \"all\": all agents use sandboxing\n- \"non-main\": only non-main agents use sandboxing (main agent unsandboxed)",
+	"github.com/haasonsaas/nexus/internal/config.SandboxConfig.Scope":                          "Scope controls sandbox isolation level:\n- \"agent\": one sandbox container per agent (default)\n- \"session\": one sandbox per session\n- \"shared\": all agents share one sandbox",
+	"github.com/haasonsaas/nexus/internal/config.SandboxConfig.Wasm":                           "Wasm runs small Python and JavaScript snippets in-process with wazero\ninstead of the configured backend.",
+	"github.com/haasonsaas/nexus/internal/config.SandboxConfig.WorkspaceAccess":                "WorkspaceAccess controls workspace access mode: \"readonly\", \"readwrite\", \"ro\", \"rw\", or \"none\".",
+	"github.com/haasonsaas/nexus/internal/config.SandboxConfig.WorkspaceRoot":                  "WorkspaceRoot is the root directory for sandboxed workspaces.",
+	"github.com/haasonsaas/nexus/internal/config.SandboxDaytonaConfig":                         "SandboxDaytonaConfig configures the Daytona sandbox backend.",
+	"github.com/haasonsaas/nexus/internal/config.SandboxLanguageConfig":                        "SandboxLanguageConfig selects the image for one sandbox language.",
+	"github.com/haasonsaas/nexus/internal/config.SandboxLanguageConfig.Image":                  "Image is the container image for the docker and daytona backends,\ne.g. \"ghcr.io/acme/sandbox-python\" or \"python:3.12-slim\".",
+	"github.com/haasonsaas/nexus/internal/config.SandboxLanguageConfig.RootFS":                 "RootFS is the ext4 root filesystem the firecracker backend boots for\nthe language.",
+	"github.com/haasonsaas/nexus/internal/config.SandboxLanguageConfig.Tag":                    "Tag pins Image to a tag or, with a \"sha256:\" prefix, a digest. Leave\nit empty when Image already carries one.",
+	"github.com/haasonsaas/nexus/internal/config.SandboxSnapshotConfig":                        "SandboxSnapshotConfig controls Firecracker snapshot behavior.",
+	"github.com/haasonsaas/nexus/internal/config.SandboxSnapshotConfig.Jitter":                 "Jitter randomizes each refresh interval by up to this fraction so\ngateways sharing a schedule do not snapshot at the same moment.",
+	"github.com/haasonsaas/nexus/internal/config.SandboxWasmConfig":                            "SandboxWasmConfig configures the WASI fast path for small snippets.",
+	"github.com/haasonsaas/nexus/internal/config.SandboxWasmConfig.JavaScriptModule":           "JavaScriptModule is a QuickJS WASI build.",
+	"github.com/haasonsaas/nexus/internal/config.SandboxWasmConfig.MaxCodeBytes":               "MaxCodeBytes is the largest snippet routed to WASM (default 4096).",
+	"github.com/haasonsaas/nexus/internal/config.SandboxWasmConfig.MaxMemory":                  "MaxMemory caps each snippet's memory, e.g. \"128MB\" (the default).",
+	"github.com/haasonsaas/nexus/internal/config.SandboxWasmConfig.PythonModule":               "PythonModule is a CPython WASI build; PythonHome is its standard\nlibrary directory, mounted read-only, when the build needs one.",
+	"github.com/haasonsaas/nexus/internal/config.SandboxWasmConfig.Timeout":                    "Timeout caps each snippet's run time (default 5s).",
+	"github.com/haasonsaas/nexus/internal/config.SecurityConfig":                               "SecurityConfig configures security features.",
+	"github.com/haasonsaas/nexus/internal/config.SecurityPostureConfig":                        "SecurityPostureConfig controls continuous security posture auditing.",
+	"github.com/haasonsaas/nexus/internal/config.SecurityRemediationCfg":                       "SecurityRemediationCfg configures posture remediation behavior.",
+	"github.com/haasonsaas/nexus/internal/config.SecurityRemediationCfg.Mode":                  "lockdown | warn_only",
+	"github.com/haasonsaas/nexus/internal/config.SentryConfig":                                 "SentryConfig configures the Sentry crash exporter.",
+	"github.com/haasonsaas/nexus/internal/config.ServerConfig":                                 "ServerConfig defines the network addresses and ports for the gateway server.",
+	"github.com/haasonsaas/nexus/internal/config.ServerConfig.Diagnostics":                     "Diagnostics serves pprof profiles and runtime stats on a separate port.",
+	"github.com/haasonsaas/nexus/internal/config.SessionLockConfig":                            "SessionLockConfig configures distributed session locks.",
+	"github.com/haasonsaas/nexus/internal/config.SessionLockConfig.AcquireTimeout":             "AcquireTimeout is how long to wait for a lock.",
+	"github.com/haasonsaas/nexus/internal/config.SessionLockConfig.Enabled":                    "Enabled uses DB-backed session locks.",
+	"github.com/haasonsaas/nexus/internal/config.SessionLockConfig.PollInterval":               "PollInterval controls backoff when lock is held by another owner.",
+	"github.com/haasonsaas/nexus/internal/config.SessionLockConfig.RefreshInterval":            "RefreshInterval is how often leases are renewed.",
+	"github.com/haasonsaas/nexus/internal/config.SessionLockConfig.TTL":                        "TTL is the lock lease duration.",
+	"github.com/haasonsaas/nexus/internal/config.SessionScopeConfig":                           "SessionScopeConfig controls advanced session scoping behavior.",
+	"github.com/haasonsaas/nexus/internal/config.SessionScopeConfig.DMScope":                   "DMScope controls how DM sessions are scoped:\n- \"main\": all DMs share one session (default)\n- \"per-peer\": separate session per peer\n- \"per-channel-peer\": separate session per channel+peer combination",
+	"github.com/haasonsaas/nexus/internal/config.SessionScopeConfig.IdentityLinks":             "IdentityLinks maps canonical IDs to platform-specific peer IDs.\nFormat: canonical_id -> [\"provider:peer_id\", \"provider:peer_id\", ...]\nThis allows cross-channel identity resolution for unified sessions.",
+	"github.com/haasonsaas/nexus/internal/config.SessionScopeConfig.Reset":                     "Reset configures default session reset behavior.",
+	"github.com/haasonsaas/nexus/internal/config.SessionScopeConfig.ResetByChannel":            "ResetByChannel configures reset behavior per channel (slack, discord, etc).",
+	"github.com/haasonsaas/nexus/internal/config.SessionScopeConfig.ResetByType":               "ResetByType configures reset behavior per conversation type (dm, group, thread).",
+	"github.com/haasonsaas/nexus/internal/config.SignalConfig.AttachmentMaxAge":                "AttachmentMaxAge prunes cached attachments older than this duration\n(for example \"168h\"); empty disables pruning.",
+	"github.com/haasonsaas/nexus/internal/config.SignalConfig.Mode":                            "Mode is \"process\" (spawn signal-cli jsonRpc, the default) or \"daemon\"\n(connect to a running signal-cli daemon at DaemonAddress).",
+	"github.com/haasonsaas/nexus/internal/config.SlackConfig.UploadAttachments":                "UploadAttachments enables Slack file uploads for outbound attachments.",
+	"github.com/haasonsaas/nexus/internal/config.SpreadsheetsConfig":                           "SpreadsheetsConfig configures the spreadsheet tools: Google Sheets access and reading and generating XLSX files.",
+	"github.com/haasonsaas/nexus/internal/config.SpreadsheetsConfig.MaxCells":                  "MaxCells caps the cells returned by a read (default: 2000).",
+	"github.com/haasonsaas/nexus/internal/config.SteeringConfig":                               "SteeringConfig controls conditional prompt injection rules.",
+	"github.com/haasonsaas/nexus/internal/config.SteeringConfig.Enabled":                       "Enabled toggles steering rule evaluation.",
+	"github.com/haasonsaas/nexus/internal/config.SteeringConfig.Rules":                         "Rules define conditional prompt injections.",
+	"github.com/haasonsaas/nexus/internal/config.SteeringConfig.Watch":                         "Watch reloads the rules whenever the config file changes, without\napplying the rest of the file.",
+	"github.com/haasonsaas/nexus/internal/config.SteeringRule":                                 "SteeringRule defines a conditional prompt injection.",
+	"github.com/haasonsaas/nexus/internal/config.SteeringRule.Agents":                          "Agents restrict matches to specific agent IDs.",
+	"github.com/haasonsaas/nexus/internal/config.SteeringRule.Channels":                        "Channels restrict matches to specific channel types.",
+	"github.com/haasonsaas/nexus/internal/config.SteeringRule.Contains":                        "Contains restricts matches to messages containing any of the substrings.",
+	"github.com/haasonsaas/nexus/internal/config.SteeringRule.Enabled":                         "Enabled toggles this rule. Defaults to true when omitted.",
+	"github.com/haasonsaas/nexus/internal/config.SteeringRule.ID":                              "ID is an optional stable identifier for the rule.",
+	"github.com/haasonsaas/nexus/internal/config.SteeringRule.Metadata":                        "Metadata requires specific metadata key/value pairs.",
+	"github.com/haasonsaas/nexus/internal/config.SteeringRule.Name":                            "Name is a human-readable label for observability.",
+	"github.com/haasonsaas/nexus/internal/config.SteeringRule.Priority":                        "Priority controls ordering when multiple rules match (higher first).",
+	"github.com/haasonsaas/nexus/internal/config.SteeringRule.Prompt":                          "Prompt is the injected text when the rule matches.",
+	"github.com/haasonsaas/nexus/internal/config.SteeringRule.Roles":                           "Roles restrict matches to specific message roles.",
+	"github.com/haasonsaas/nexus/internal/config.SteeringRule.Tags":                            "Tags restrict matches to metadata tags (any match).",
+	"github.com/haasonsaas/nexus/internal/config.SteeringRule.TimeWindow":                      "TimeWindow restricts matches to a time range.",
+	"github.com/haasonsaas/nexus/internal/config.SteeringTimeWindow":                           "SteeringTimeWindow restricts rule matching by absolute time.",
+	"github.com/haasonsaas/nexus/internal/config.SteeringTimeWindow.After":                     "After is an RFC3339 timestamp; now must be after this to match.",
+	"github.com/haasonsaas/nexus/internal/config.SteeringTimeWindow.Before":                    "Before is an RFC3339 timestamp; now must be before this to match.",
+	"github.com/haasonsaas/nexus/internal/config.TasksConfig":                                  "TasksConfig configures the scheduled tasks system.",
+	"github.com/haasonsaas/nexus/internal/config.TasksConfig.AcquireInterval":                  "AcquireInterval is how often the scheduler tries to acquire pending executions.\nDefaults to 1 second.",
+	"github.com/haasonsaas/nexus/internal/config.TasksConfig.CleanupInterval":                  "CleanupInterval is how often stale executions are cleaned up.\nDefaults to 1 minute.",
+	"github.com/haasonsaas/nexus/internal/config.TasksConfig.DefaultTimeout":                   "DefaultTimeout is the default timeout for task execution if not specified on the task.\nDefaults to 5 minutes.",
+	"github.com/haasonsaas/nexus/internal/config.TasksConfig.Enabled":                          "Enabled enables the scheduled tasks scheduler.",
+	"github.com/haasonsaas/nexus/internal/config.TasksConfig.LockDuration":                     "LockDuration is how long an execution lock is held.\nShould be longer than the maximum expected execution time.\nDefaults to 10 minutes.",
+	"github.com/haasonsaas/nexus/internal/config.TasksConfig.MaxConcurrency":                   "MaxConcurrency is the maximum number of concurrent task executions.\nDefaults to 5.",
+	"github.com/haasonsaas/nexus/internal/config.TasksConfig.PollInterval":                     "PollInterval is how often the scheduler checks for due tasks.\nDefaults to 10 seconds.",
+	"github.com/haasonsaas/nexus/internal/config.TasksConfig.StaleTimeout":                     "StaleTimeout is how long an execution can run before being marked stale.\nDefaults to 30 minutes.",
+	"github.com/haasonsaas/nexus/internal/config.TasksConfig.WorkerID":                         "WorkerID uniquely identifies this scheduler instance for distributed locking.\nDefaults to a generated UUID if empty.",
+	"github.com/haasonsaas/nexus/internal/config.TeamsConfig.PollInterval":                     "PollInterval for checking messages when webhooks unavailable (default: 5s)",
+	"github.com/haasonsaas/nexus/internal/config.TeamsConfig.WebhookURL":                       "WebhookURL is the public URL for receiving Teams notifications",
+	"github.com/haasonsaas/nexus/internal/config.TelegramConfig.ChannelPosts":                  "ChannelPosts handles posts in channels the bot administers.",
+	"github.com/haasonsaas/nexus/internal/config.TelegramConfig.EditedMessages":                "EditedMessages handles edits of earlier messages as new messages.",
+	"github.com/haasonsaas/nexus/internal/config.TelegramConfig.Topics":                        "Topics overrides the agent per forum topic, or disables it there.",
+	"github.com/haasonsaas/nexus/internal/config.TelegramTopicConfig":                          "TelegramTopicConfig configures the agent for one forum topic.",
+	"github.com/haasonsaas/nexus/internal/config.ToolDraftsConfig":                             "ToolDraftsConfig holds calls to tools that send external communications as drafts the user approves, edits, or cancels before anything is sent.",
+	"github.com/haasonsaas/nexus/internal/config.ToolDraftsConfig.Enabled":                     "Enabled turns draft mode on.",
+	"github.com/haasonsaas/nexus/internal/config.ToolDraftsConfig.TTL":                         "TTL is how long a draft waits for approval before it expires\nunsent (default: 1h).",
+	"github.com/haasonsaas/nexus/internal/config.ToolDraftsConfig.Tools":                       "Tools lists the drafted tools by name or glob, e.g. \"servicenow_*\"\n(default: [email_compose]).",
+	"github.com/haasonsaas/nexus/internal/config.ToolEarlyStartConfig":                         "ToolEarlyStartConfig controls starting tool calls before the model turn ends.",
+	"github.com/haasonsaas/nexus/internal/config.ToolEarlyStartConfig.Tools":                   "Tools limits early starts to these tools, e.g. read-only ones.\nSupports patterns like \"mcp:*\". Empty allows every tool.",
+	"github.com/haasonsaas/nexus/internal/config.ToolExecutionConfig":                          "ToolExecutionConfig controls runtime tool execution behavior.",
+	"github.com/haasonsaas/nexus/internal/config.ToolExecutionConfig.EarlyStart":               "EarlyStart runs tool calls as soon as the model has finished\nstreaming them, before the rest of its turn arrives.",
+	"github.com/haasonsaas/nexus/internal/config.ToolExecutionConfig.LoopDetection":            "LoopDetection stops runs that keep repeating the same tool calls.",
+	"github.com/haasonsaas/nexus/internal/config.ToolExecutionConfig.Retry":                    "Retry shapes retries of failed calls to idempotent tools. MaxAttempts\nand RetryBackoff set the attempt count and base backoff.",
+	"github.com/haasonsaas/nexus/internal/config.ToolExecutionConfig.SchemaValidation":         "SchemaValidation checks tool call arguments against each tool's\nJSON Schema before execution.",
+	"github.com/haasonsaas/nexus/internal/config.ToolJobsConfig":                               "ToolJobsConfig controls async tool job persistence.",
+	"github.com/haasonsaas/nexus/internal/config.ToolJobsConfig.PruneInterval":                 "PruneInterval is how often to prune old jobs. Default: 1h.",
+	"github.com/haasonsaas/nexus/internal/config.ToolJobsConfig.Retention":                     "Retention is how long to keep completed jobs. Default: 24h.",
+	"github.com/haasonsaas/nexus/internal/config.ToolLoopDetectionConfig":                      "ToolLoopDetectionConfig controls stopping runs that loop on tool calls.",
+	"github.com/haasonsaas/nexus/internal/config.ToolLoopDetectionConfig.Enabled":              "Enabled gates loop detection. When nil, it is enabled.",
+	"github.com/haasonsaas/nexus/internal/config.ToolLoopDetectionConfig.MaxRepeats":           "MaxRepeats is how many times a run may call a tool with identical\narguments before it is stopped. Defaults to 5.",
+	"github.com/haasonsaas/nexus/internal/config.ToolLoopDetectionConfig.MaxStalledIterations": "MaxStalledIterations is how many iterations in a row may only repeat\nearlier calls and get the same results. Defaults to 3.",
+	"github.com/haasonsaas/nexus/internal/config.ToolPoliciesConfig":                           "ToolPoliciesConfig defines default allow/deny policies for tools.",
+	"github.com/haasonsaas/nexus/internal/config.ToolPoliciesConfig.Default":                   "Default policy behavior: \"allow\" or \"deny\".",
+	"github.com/haasonsaas/nexus/internal/config.ToolPoliciesConfig.Rules":                     "Rules define per-tool allow/deny behavior.",
+	"github.com/haasonsaas/nexus/internal/config.ToolPolicyRule":                               "ToolPolicyRule defines a policy action for a tool, optionally scoped by channel.",
+	"github.com/haasonsaas/nexus/internal/config.ToolPolicyRule.Action":                        "\"allow\" | \"deny\"",
+	"github.com/haasonsaas/nexus/internal/config.ToolPolicyRule.Channels":                      "optional channel filters",
+	"github.com/haasonsaas/nexus/internal/config.ToolResultGuardConfig":                        "ToolResultGuardConfig controls redaction of tool results before persistence.",
+	"github.com/haasonsaas/nexus/internal/config.ToolResultGuardConfig.SanitizeSecrets":        "Applies builtin secret detection patterns",
+	"github.com/haasonsaas/nexus/internal/config.ToolRetryConfig":                              "ToolRetryConfig controls automatic retries of failed tool calls.",
+	"github.com/haasonsaas/nexus/internal/config.ToolRetryConfig.Idempotent":                   "Idempotent lists additional tools that are safe to retry, such as MCP\ntools whose servers declare no hints. Supports patterns like \"mcp:*\".",
+	"github.com/haasonsaas/nexus/internal/config.ToolRetryConfig.Jitter":                       "Jitter spreads each backoff by up to this fraction (0-1).",
+	"github.com/haasonsaas/nexus/internal/config.ToolRetryConfig.MaxBackoff":                   "MaxBackoff caps exponential backoff. When zero, every retry waits\nretry_backoff.",
+	"github.com/haasonsaas/nexus/internal/config.ToolRetryConfig.RetryOn":                      "RetryOn limits retries to these error classes: timeout, network,\nrate_limit, execution, permission, invalid_input, not_found, panic,\nunknown. Empty retries any error.",
+	"github.com/haasonsaas/nexus/internal/config.ToolSchemaValidationConfig":                   "ToolSchemaValidationConfig controls validation of tool call arguments.",
+	"github.com/haasonsaas/nexus/internal/config.ToolSchemaValidationConfig.RepairAttempts":    "RepairAttempts is how many times per run an invalid call to a tool is\nsent back to the model with the validation errors. Defaults to 1.",
+	"github.com/haasonsaas/nexus/internal/config.ToolSchemaValidationConfig.Strict":            "Strict lists tools whose invalid calls are never executed and whose\nundeclared arguments are rejected. Supports patterns like \"mcp:*\".",
+	"github.com/haasonsaas/nexus/internal/config.TracingConfig":                                "TracingConfig controls OpenTelemetry tracing.",
+	"github.com/haasonsaas/nexus/internal/config.TranscriptionConfig":                          "TranscriptionConfig configures audio transcription.",
+	"github.com/haasonsaas/nexus/internal/config.TranscriptionConfig.APIKey":                   "APIKey is the API key for the transcription provider",
+	"github.com/haasonsaas/nexus/internal/config.TranscriptionConfig.BaseURL":                  "BaseURL is an optional custom base URL for the API",
+	"github.com/haasonsaas/nexus/internal/config.TranscriptionConfig.Diarize":                  "Diarize labels speakers in voice notes from group conversations",
+	"github.com/haasonsaas/nexus/internal/config.TranscriptionConfig.Enabled":                  "Enabled enables/disables transcription globally",
+	"github.com/haasonsaas/nexus/internal/config.TranscriptionConfig.Language":                 "Language is the default language for transcription (ISO 639-1)\nIf empty, the provider will auto-detect the language",
+	"github.com/haasonsaas/nexus/internal/config.TranscriptionConfig.Languages":                "Languages overrides Language per channel (e.g., telegram: de)",
+	"github.com/haasonsaas/nexus/internal/config.TranscriptionConfig.Local":                    "Local configures the whisper.cpp backend (provider: whispercpp)",
+	"github.com/haasonsaas/nexus/internal/config.TranscriptionConfig.Model":                    "Model is the transcription model to use (e.g., \"whisper-1\")",
+	"github.com/haasonsaas/nexus/internal/config.TranscriptionConfig.Provider":                 "Provider is the transcription provider (\"openai\" or \"whispercpp\")",
+	"github.com/haasonsaas/nexus/internal/config.WebWatchConfig":                               "WebWatchConfig controls the watch_url tool, which checks pages on a schedule and notifies the session that registered them.",
+	"github.com/haasonsaas/nexus/internal/config.WebWatchConfig.CheckInterval":                 "CheckInterval is how often due watches are looked for. Default: 1m.",
+	"github.com/haasonsaas/nexus/internal/config.WebWatchConfig.DefaultInterval":               "DefaultInterval applies when a watch does not set one. Default: 1h.",
+	"github.com/haasonsaas/nexus/internal/config.WebWatchConfig.MaxPerSession":                 "MaxPerSession limits watches per session. Default: 20.",
+	"github.com/haasonsaas/nexus/internal/config.WebWatchConfig.MinInterval":                   "MinInterval is the shortest interval a watch may use. Default: 5m.",
+	"github.com/haasonsaas/nexus/internal/config.WebhookHookMapping":                           "WebhookHookMapping defines a webhook hook endpoint.",
+	"github.com/haasonsaas/nexus/internal/config.WebhookHookMapping.AgentID":                   "AgentID targets a specific agent (optional).",
+	"github.com/haasonsaas/nexus/internal/config.WebhookHookMapping.Channel":                   "Channel is the channel type runs are delivered to, such as slack.\nWith ChannelID it fixes the conversation replies are posted in.",
+	"github.com/haasonsaas/nexus/internal/config.WebhookHookMapping.ChannelID":                 "ChannelID targets a specific channel (optional).",
+	"github.com/haasonsaas/nexus/internal/config.WebhookHookMapping.Handler":                   "Handler is the handler type (agent, wake, custom).",
+	"github.com/haasonsaas/nexus/internal/config.WebhookHookMapping.Match":                     "Match restricts the endpoint to requests whose fields equal the given\nvalues. Keys are dotted payload paths (\"action\", \"issue.state\") or\n\"header:<Name>\". Requests that do not match are acknowledged and\nignored.",
+	"github.com/haasonsaas/nexus/internal/config.WebhookHookMapping.Name":                      "Name is a human-readable name for this webhook.",
+	"github.com/haasonsaas/nexus/internal/config.WebhookHookMapping.Path":                      "Path is the endpoint path (appended to BasePath).",
+	"github.com/haasonsaas/nexus/internal/config.WebhookHookMapping.RateLimit":                 "RateLimit caps accepted requests per minute (0 = unlimited).",
+	"github.com/haasonsaas/nexus/internal/config.WebhookHookMapping.Secret":                    "Secret verifies an HMAC-SHA256 signature of the body instead of a\ntoken, as sent by GitHub.",
+	"github.com/haasonsaas/nexus/internal/config.WebhookHookMapping.SignatureHeader":           "SignatureHeader carries the \"sha256=<hex>\" signature\n(default: X-Hub-Signature-256).",
+	"github.com/haasonsaas/nexus/internal/config.WebhookHookMapping.Template":                  "Template renders the prompt from the request with text/template.\nTemplates see .payload (the decoded JSON body), .headers, .query,\nand .webhook (the mapping name). When set, the body is not read as\na WebhookPayload, so any JSON the sender emits is accepted.",
+	"github.com/haasonsaas/nexus/internal/config.WebhookHookMapping.Token":                     "Token overrides the gateway-wide token for this endpoint.",
+	"github.com/haasonsaas/nexus/internal/config.WebhookHooksConfig":                           "WebhookHooksConfig configures inbound webhook hook handling.",
+	"github.com/haasonsaas/nexus/internal/config.WebhookHooksConfig.BasePath":                  "BasePath is the URL path prefix for webhook hooks (default: /hooks).",
+	"github.com/haasonsaas/nexus/internal/config.WebhookHooksConfig.Enabled":                   "Enabled turns on webhook hooks.",
+	"github.com/haasonsaas/nexus/internal/config.WebhookHooksConfig.Mappings":                  "Mappings define webhook endpoints and their handlers.",
+	"github.com/haasonsaas/nexus/internal/config.WebhookHooksConfig.MaxBodyBytes":              "MaxBodyBytes limits the request body size (default: 256KB).",
+	"github.com/haasonsaas/nexus/internal/config.WebhookHooksConfig.Token":                     "Token is the authentication token. Required unless every mapping\nsets its own token or secret.",
+	"github.com/haasonsaas/nexus/internal/config.WorkspaceHistoryConfig":                       "WorkspaceHistoryConfig controls versioning of the workspace bootstrap files (AGENTS.md, SOUL.md, MEMORY.md, ...).",
+	"github.com/haasonsaas/nexus/internal/config.WorkspaceHistoryConfig.Dir":                   "Dir holds the versions. Relative paths are resolved against the\nworkspace path. Defaults to .nexus/history.",
+	"github.com/haasonsaas/nexus/internal/config.WorkspaceHistoryConfig.Enabled":               "Enabled keeps a copy of each version of a bootstrap file the agent\nchanges with the file tools. Defaults to true.",
+	"github.com/haasonsaas/nexus/internal/config.WorkspaceHistoryConfig.MaxVersions":           "MaxVersions keeps at most this many versions per file, removing the\noldest first. Defaults to 50.",
+	"github.com/haasonsaas/nexus/internal/config.ZaloConfig.PollTimeout":                       "PollTimeout is the long-polling timeout in seconds (default: 30).",
+	"github.com/haasonsaas/nexus/internal/config.ZaloConfig.Token":                             "Token is the Zalo bot token (required).",
+	"github.com/haasonsaas/nexus/internal/config.ZaloConfig.WebhookPath":                       "WebhookPath is the path for the webhook endpoint (default: /webhook/zalo).",
+	"github.com/haasonsaas/nexus/internal/config.ZaloConfig.WebhookSecret":                     "WebhookSecret is the secret for validating webhook signatures (optional).",
+	"github.com/haasonsaas/nexus/internal/config.ZaloConfig.WebhookURL":                        "WebhookURL is the public URL for webhook callbacks (optional).",
+	"github.com/haasonsaas/nexus/internal/experiments.Config":                                  "Config defines experiment configuration.",
+	"github.com/haasonsaas/nexus/internal/experiments.Config.LogPath":                          "LogPath is where exposures and run outcomes are appended\n(default: ~/.nexus/experiments.jsonl).",
+	"github.com/haasonsaas/nexus/internal/experiments.Experiment":                              "Experiment defines a single experiment.",
+	"github.com/haasonsaas/nexus/internal/experiments.Experiment.Allocation":                   "percentage 0-100",
+	"github.com/haasonsaas/nexus/internal/experiments.Experiment.AssignBy":                     "user (default) | session",
+	"github.com/haasonsaas/nexus/internal/experiments.Experiment.Status":                       "active | inactive",
+	"github.com/haasonsaas/nexus/internal/experiments.Variant":                                 "Variant defines a variant within an experiment.",
+	"github.com/haasonsaas/nexus/internal/experiments.VariantConfig":                           "VariantConfig defines per-variant overrides.",
+	"github.com/haasonsaas/nexus/internal/mcp.Config":                                          "Config holds the MCP manager configuration.",
+	"github.com/haasonsaas/nexus/internal/mcp.ServerConfig":                                    "ServerConfig holds configuration for an MCP server.",
+	"github.com/haasonsaas/nexus/internal/mcp.ServerConfig.Command":                            "Stdio transport options",
+	"github.com/haasonsaas/nexus/internal/mcp.ServerConfig.Timeout":                            "Common options",
+	"github.com/haasonsaas/nexus/internal/mcp.ServerConfig.URL":                                "HTTP transport options",
+	"github.com/haasonsaas/nexus/internal/media/transcribe.WhisperConfig":                      "WhisperConfig holds configuration for the local whisper.cpp transcriber.",
+	"github.com/haasonsaas/nexus/internal/media/transcribe.WhisperConfig.AutoDownload":         "AutoDownload fetches the model on first use when it is missing",
+	"github.com/haasonsaas/nexus/internal/media/transcribe.WhisperConfig.BinaryPath":           "BinaryPath is the whisper.cpp CLI (default: whisper-cli)",
+	"github.com/haasonsaas/nexus/internal/media/transcribe.WhisperConfig.DownloadURL":          "DownloadURL is the base URL models are fetched from",
+	"github.com/haasonsaas/nexus/internal/media/transcribe.WhisperConfig.FFmpegPath":           "FFmpegPath converts input audio to 16 kHz mono WAV (default: ffmpeg)",
+	"github.com/haasonsaas/nexus/internal/media/transcribe.WhisperConfig.Language":             "Language is the default language for transcription (ISO 639-1)\nIf empty, whisper.cpp will auto-detect the language",
+	"github.com/haasonsaas/nexus/internal/media/transcribe.WhisperConfig.Logger":               "Logger is an optional structured logger",
+	"github.com/haasonsaas/nexus/internal/media/transcribe.WhisperConfig.Model":                "Model is the ggml model name, e.g. \"base.en\" or \"small\" (default: base)",
+	"github.com/haasonsaas/nexus/internal/media/transcribe.WhisperConfig.ModelDir":             "ModelDir holds downloaded models (default: ~/.nexus/models/whisper)",
+	"github.com/haasonsaas/nexus/internal/media/transcribe.WhisperConfig.ModelPath":            "ModelPath is an explicit model file; it overrides Model and ModelDir",
+	"github.com/haasonsaas/nexus/internal/media/transcribe.WhisperConfig.Threads":              "Threads is the number of CPU threads (0 lets whisper.cpp decide)",
+	"github.com/haasonsaas/nexus/internal/media/transcribe.WhisperConfig.Timeout":              "Timeout bounds a single transcription, including conversion (default: 5m)",
+	"github.com/haasonsaas/nexus/internal/memory.AutoCaptureConfig":                            "AutoCaptureConfig configures automatic memory capture.",
+	"github.com/haasonsaas/nexus/internal/memory.AutoCaptureConfig.DefaultImportance":          "DefaultImportance is the importance score for auto-captured memories (default: 0.7).",
+	"github.com/haasonsaas/nexus/internal/memory.AutoCaptureConfig.DuplicateThreshold":         "DuplicateThreshold is the similarity score above which content is considered duplicate (default: 0.95).",
+	"github.com/haasonsaas/nexus/internal/memory.AutoCaptureConfig.Enabled":                    "Enabled enables auto-capture of conversation content.",
+	"github.com/haasonsaas/nexus/internal/memory.AutoCaptureConfig.MaxCapturesPerConversation": "MaxCapturesPerConversation limits captures per agent run (default: 3).",
+	"github.com/haasonsaas/nexus/internal/memory.AutoCaptureConfig.MaxContentLength":           "MaxContentLength is the maximum text length to consider (default: 500).",
+	"github.com/haasonsaas/nexus/internal/memory.AutoCaptureConfig.MinContentLength":           "MinContentLength is the minimum text length to consider (default: 10).",
+	"github.com/haasonsaas/nexus/internal/memory.AutoRecallConfig":                             "AutoRecallConfig configures automatic memory recall.",
+	"github.com/haasonsaas/nexus/internal/memory.AutoRecallConfig.Enabled":                     "Enabled enables auto-recall of relevant memories.",
+	"github.com/haasonsaas/nexus/internal/memory.AutoRecallConfig.MaxResults":                  "MaxResults is the maximum number of memories to inject (default: 3).",
+	"github.com/haasonsaas/nexus/internal/memory.AutoRecallConfig.MinQueryLength":              "MinQueryLength is the minimum prompt length to trigger recall (default: 5).",
+	"github.com/haasonsaas/nexus/internal/memory.AutoRecallConfig.MinScore":                    "MinScore is the minimum similarity score for recall (default: 0.3).",
+	"github.com/haasonsaas/nexus/internal/memory.Config":                                       "Config contains configuration for the memory manager.",
+	"github.com/haasonsaas/nexus/internal/memory.Config.AutoCapture":                           "Auto-capture configuration",
+	"github.com/haasonsaas/nexus/internal/memory.Config.AutoRecall":                            "Auto-recall configuration",
+	"github.com/haasonsaas/nexus/internal/memory.Config.Backend":                               "sqlite-vec, lancedb, pgvector, qdrant, redis, or a plugin backend",
+	"github.com/haasonsaas/nexus/internal/memory.Config.Consolidation":                         "Consolidation configuration",
+	"github.com/haasonsaas/nexus/internal/memory.Config.DeletionLog":                           "DeletionLog is the JSON Lines file that records every deletion made\nwith \"nexus memory delete\" or the forget tool.\nDefault: ~/.nexus/memory-deletions.jsonl.",
+	"github.com/haasonsaas/nexus/internal/memory.Config.Dimension":                             "Must match embedding model",
+	"github.com/haasonsaas/nexus/internal/memory.Config.Embeddings":                            "Embedding provider config",
+	"github.com/haasonsaas/nexus/internal/memory.Config.Indexing":                              "Indexing behavior",
+	"github.com/haasonsaas/nexus/internal/memory.Config.SQLiteVec":                             "Backend-specific config",
+	"github.com/haasonsaas/nexus/internal/memory.Config.Search":                                "Search defaults",
+	"github.com/haasonsaas/nexus/internal/memory.ConsolidationConfig":                          "ConsolidationConfig controls background memory consolidation.",
+	"github.com/haasonsaas/nexus/internal/memory.ConsolidationConfig.Batch":                    "Batch sends the session summaries of a run through the llm.batch\nqueue together, at about half the price.",
+	"github.com/haasonsaas/nexus/internal/memory.EmbeddingsConfig":                             "EmbeddingsConfig contains embedding provider configuration.",
+	"github.com/haasonsaas/nexus/internal/memory.EmbeddingsConfig.OllamaURL":                   "Ollama-specific",
+	"github.com/haasonsaas/nexus/internal/memory.EmbeddingsConfig.ProjectID":                   "Gemini-specific",
+	"github.com/haasonsaas/nexus/internal/memory.EmbeddingsConfig.Provider":                    "openai, gemini, ollama",
+	"github.com/haasonsaas/nexus/internal/memory.HierarchyConfig":                              "HierarchyConfig configures hierarchical memory search across scopes.",
+	"github.com/haasonsaas/nexus/internal/memory.IndexingConfig":                               "IndexingConfig contains configuration for automatic indexing.",
+	"github.com/haasonsaas/nexus/internal/memory.LanceDBConfig":                                "LanceDBConfig contains LanceDB specific configuration.",
+	"github.com/haasonsaas/nexus/internal/memory.LanceDBConfig.EF":                             "EF is the HNSW ef parameter for search.",
+	"github.com/haasonsaas/nexus/internal/memory.LanceDBConfig.IndexType":                      "IndexType specifies the vector index type to use.\nOptions: auto, ivf_pq, ivf_flat, hnsw_pq, hnsw_sq",
+	"github.com/haasonsaas/nexus/internal/memory.LanceDBConfig.MetricType":                     "MetricType specifies the distance metric.\nOptions: cosine, l2, dot",
+	"github.com/haasonsaas/nexus/internal/memory.LanceDBConfig.NProbes":                        "NProbes is the number of probes for IVF indexes.",
+	"github.com/haasonsaas/nexus/internal/memory.LanceDBConfig.Path":                           "Path is the directory path for LanceDB storage.",
+	"github.com/haasonsaas/nexus/internal/memory.LanceDBConfig.RefineFactor":                   "RefineFactor improves accuracy by re-ranking results.",
+	"github.com/haasonsaas/nexus/internal/memory.PgvectorConfig":                               "PgvectorConfig contains pgvector specific configuration.",
+	"github.com/haasonsaas/nexus/internal/memory.PgvectorConfig.DB":                            "DB is an existing database connection to reuse (set programmatically, not via config).",
+	"github.com/haasonsaas/nexus/internal/memory.PgvectorConfig.DSN":                           "DSN is the PostgreSQL connection string.\nIf empty and UseCockroachDB is true, gateway will fall back to database.url.",
+	"github.com/haasonsaas/nexus/internal/memory.PgvectorConfig.RunMigrations":                 "RunMigrations controls whether to run migrations on startup.\nDefault is true.",
+	"github.com/haasonsaas/nexus/internal/memory.PgvectorConfig.UseCockroachDB":                "UseCockroachDB indicates whether to reuse the configured database URL.\nWhen true and DSN is empty, the gateway copies database.url into DSN.",
+	"github.com/haasonsaas/nexus/internal/memory.QdrantConfig":                                 "QdrantConfig contains Qdrant specific configuration.",
+	"github.com/haasonsaas/nexus/internal/memory.QdrantConfig.APIKey":                          "APIKey authenticates with Qdrant Cloud or a secured instance.",
+	"github.com/haasonsaas/nexus/internal/memory.QdrantConfig.Collection":                      "Collection holds the memories and is created when missing.\nDefault: nexus_memories.",
+	"github.com/haasonsaas/nexus/internal/memory.QdrantConfig.Distance":                        "Distance is the vector distance used for a new collection.\nOptions: Cosine (default), Dot, Euclid",
+	"github.com/haasonsaas/nexus/internal/memory.QdrantConfig.URL":                             "URL is the Qdrant REST endpoint. Default: http://localhost:6333.",
+	"github.com/haasonsaas/nexus/internal/memory.RedisConfig":                                  "RedisConfig contains Redis (RediSearch) specific configuration.",
+	"github.com/haasonsaas/nexus/internal/memory.RedisConfig.Addr":                             "Addr is the Redis host:port. Default: localhost:6379.",
+	"github.com/haasonsaas/nexus/internal/memory.RedisConfig.DB":                               "DB is the logical database number.",
+	"github.com/haasonsaas/nexus/internal/memory.RedisConfig.Index":                            "Index is the search index, created when missing. Default: nexus_memories.",
+	"github.com/haasonsaas/nexus/internal/memory.RedisConfig.Prefix":                           "Prefix is the key prefix of memory hashes. Default: nexus:memory:.",
+	"github.com/haasonsaas/nexus/internal/memory.RedisConfig.Username":                         "Username and Password authenticate with AUTH when Password is set.",
+	"github.com/haasonsaas/nexus/internal/memory.SQLiteVecConfig":                              "SQLiteVecConfig contains sqlite-vec specific configuration.",
+	"github.com/haasonsaas/nexus/internal/memory.SQLiteVecConfig.Path":                         "Path to database file",
+	"github.com/haasonsaas/nexus/internal/memory.SearchConfig":                                 "SearchConfig contains default search parameters.",
+	"github.com/haasonsaas/nexus/internal/ratelimit.Config":                                    "Config configures rate limiting behavior.",
+	"github.com/haasonsaas/nexus/internal/ratelimit.Config.BurstSize":                          "BurstSize is the maximum number of requests allowed in a burst.",
+	"github.com/haasonsaas/nexus/internal/ratelimit.Config.Enabled":                            "Enabled controls whether rate limiting is active.",
+	"github.com/haasonsaas/nexus/internal/ratelimit.Config.RequestsPerSecond":                  "RequestsPerSecond is the number of requests allowed per second.",
+	"github.com/haasonsaas/nexus/internal/skills.LoadConfig":                                   "LoadConfig configures skill loading behavior.",
+	"github.com/haasonsaas/nexus/internal/skills.LoadConfig.ExtraDirs":                         "ExtraDirs are additional directories to scan for skills.",
+	"github.com/haasonsaas/nexus/internal/skills.LoadConfig.Watch":                             "Watch enables file watching for skill changes.",
+	"github.com/haasonsaas/nexus/internal/skills.LoadConfig.WatchDebounceMs":                   "WatchDebounceMs is the debounce delay for the watcher.",
+	"github.com/haasonsaas/nexus/internal/skills.SkillConfig":                                  "SkillConfig provides per-skill configuration overrides.",
+	"github.com/haasonsaas/nexus/internal/skills.SkillConfig.APIKey":                           "APIKey is a convenience for skills with PrimaryEnv.",
+	"github.com/haasonsaas/nexus/internal/skills.SkillConfig.Config":                           "Config provides custom skill configuration.",
+	"github.com/haasonsaas/nexus/internal/skills.SkillConfig.Enabled":                          "Enabled controls whether the skill is active.",
+	"github.com/haasonsaas/nexus/internal/skills.SkillConfig.Env":                              "Env provides environment variable overrides.",
+	"github.com/haasonsaas/nexus/internal/skills.SkillsConfig":                                 "SkillsConfig is the top-level skills configuration.",
+	"github.com/haasonsaas/nexus/internal/skills.SkillsConfig.Entries":                         "Entries provides per-skill configuration.",
+	"github.com/haasonsaas/nexus/internal/skills.SkillsConfig.Load":                            "Load configures loading behavior.",
+	"github.com/haasonsaas/nexus/internal/skills.SkillsConfig.Sources":                         "Sources are additional discovery sources beyond defaults.",
+	"github.com/haasonsaas/nexus/internal/skills.SourceConfig":                                 "SourceConfig configures a skill discovery source.",
+	"github.com/haasonsaas/nexus/internal/skills.SourceConfig.Auth":                            "Auth is the authentication token for registry sources.",
+	"github.com/haasonsaas/nexus/internal/skills.SourceConfig.Branch":                          "Branch is the git branch to use.",
+	"github.com/haasonsaas/nexus/internal/skills.SourceConfig.Path":                            "Path is the directory path for local sources.",
+	"github.com/haasonsaas/nexus/internal/skills.SourceConfig.Refresh":                         "Refresh is the auto-pull interval for git sources.",
+	"github.com/haasonsaas/nexus/internal/skills.SourceConfig.SubPath":                         "SubPath is a subdirectory within a git repository.",
+	"github.com/haasonsaas/nexus/internal/skills.SourceConfig.Type":                            "Type is the source type: local, git, registry.",
+	"github.com/haasonsaas/nexus/internal/skills.SourceConfig.URL":                             "URL is the repository/registry URL for git/registry sources.",
+	"github.com/haasonsaas/nexus/internal/templates.LoadConfig":                                "LoadConfig configures template loading behavior.",
+	"github.com/haasonsaas/nexus/internal/templates.LoadConfig.ExtraDirs":                      "ExtraDirs are additional directories to scan for templates.",
+	"github.com/haasonsaas/nexus/internal/templates.LoadConfig.Watch":                          "Watch enables file watching for template changes.",
+	"github.com/haasonsaas/nexus/internal/templates.LoadConfig.WatchDebounceMs":                "WatchDebounceMs is the debounce delay for the watcher.",
+	"github.com/haasonsaas/nexus/internal/templates.SourceConfig":                              "SourceConfig configures a template discovery source.",
+	"github.com/haasonsaas/nexus/internal/templates.SourceConfig.Auth":                         "Auth is the authentication token for registry sources.",
+	"github.com/haasonsaas/nexus/internal/templates.SourceConfig.Branch":                       "Branch is the git branch to use.",
+	"github.com/haasonsaas/nexus/internal/templates.SourceConfig.Path":                         "Path is the directory path for local sources.",
+	"github.com/haasonsaas/nexus/internal/templates.SourceConfig.Refresh":                      "Refresh is the auto-pull interval for git sources.",
+	"github.com/haasonsaas/nexus/internal/templates.SourceConfig.SubPath":                      "SubPath is a subdirectory within a git repository.",
+	"github.com/haasonsaas/nexus/internal/templates.SourceConfig.Type":                         "Type is the source type: local, git, registry.",
+	"github.com/haasonsaas/nexus/internal/templates.SourceConfig.URL":                          "URL is the repository/registry URL for git/registry sources.",
+	"github.com/haasonsaas/nexus/internal/templates.TemplateConfig":                            "TemplateConfig provides per-template configuration overrides.",
+	"github.com/haasonsaas/nexus/internal/templates.TemplateConfig.Enabled":                    "Enabled controls whether the template is available.",
+	"github.com/haasonsaas/nexus/internal/templates.TemplateConfig.Variables":                  "Variables provides default variable overrides.",
+	"github.com/haasonsaas/nexus/internal/templates.TemplatesConfig":                           "TemplatesConfig is the top-level templates configuration.",
+	"github.com/haasonsaas/nexus/internal/templates.TemplatesConfig.Entries":                   "Entries provides per-template configuration.",
+	"github.com/haasonsaas/nexus/internal/templates.TemplatesConfig.Load":                      "Load configures loading behavior.",
+	"github.com/haasonsaas/nexus/internal/templates.TemplatesConfig.Sources":                   "Sources are additional discovery sources beyond defaults.",
+	"github.com/haasonsaas/nexus/internal/tts.Config":                                          "Config holds TTS configuration.",
+	"github.com/haasonsaas/nexus/internal/tts.Config.Edge":                                     "Edge configures the Edge TTS provider.",
+	"github.com/haasonsaas/nexus/internal/tts.Config.ElevenLabs":                               "ElevenLabs configures the ElevenLabs TTS provider.",
+	"github.com/haasonsaas/nexus/internal/tts.Config.Enabled":                                  "Enabled toggles TTS functionality.",
+	"github.com/haasonsaas/nexus/internal/tts.Config.FallbackChain":                            "FallbackChain specifies providers to try if the primary fails.\nProviders are tried in order.",
+	"github.com/haasonsaas/nexus/internal/tts.Config.MacOS":                                    "MacOS configures the macOS say provider.",
+	"github.com/haasonsaas/nexus/internal/tts.Config.MaxTextLength":                            "MaxTextLength is the maximum text length to process.\nText longer than this is truncated.\nDefault: 4096",
+	"github.com/haasonsaas/nexus/internal/tts.Config.OpenAI":                                   "OpenAI configures the OpenAI TTS provider.",
+	"github.com/haasonsaas/nexus/internal/tts.Config.OutputDir":                                "OutputDir is the directory for generated audio files.\nDefault: system scratch directory",
+	"github.com/haasonsaas/nexus/internal/tts.Config.Provider":                                 "Provider is the primary TTS provider to use.",
+	"github.com/haasonsaas/nexus/internal/tts.Config.TimeoutSeconds":                           "TimeoutSeconds is the timeout for TTS generation.\nDefault: 30",
+	"github.com/haasonsaas/nexus/internal/tts.EdgeConfig":                                      "EdgeConfig configures Edge TTS.",
+	"github.com/haasonsaas/nexus/internal/tts.EdgeConfig.OutputFormat":                         "OutputFormat is the audio output format.\nExample: \"audio-24khz-48kbitrate-mono-mp3\", \"audio-24khz-96kbitrate-mono-mp3\"\nDefault: \"audio-24khz-48kbitrate-mono-mp3\"",
+	"github.com/haasonsaas/nexus/internal/tts.EdgeConfig.Voice":                                "Voice is the Edge TTS voice to use.\nExample: \"en-US-MichelleNeural\", \"en-US-GuyNeural\"\nDefault: \"en-US-AriaNeural\"",
+	"github.com/haasonsaas/nexus/internal/tts.ElevenLabsConfig":                                "ElevenLabsConfig configures ElevenLabs TTS.",
+	"github.com/haasonsaas/nexus/internal/tts.ElevenLabsConfig.APIKey":                         "APIKey is the ElevenLabs API key.",
+	"github.com/haasonsaas/nexus/internal/tts.ElevenLabsConfig.ModelID":                        "ModelID is the model to use.\nOptions: \"eleven_monolingual_v1\", \"eleven_multilingual_v2\", \"eleven_turbo_v2\"\nDefault: \"eleven_monolingual_v1\"",
+	"github.com/haasonsaas/nexus/internal/tts.ElevenLabsConfig.OutputFormat":                   "OutputFormat is the audio format.\nOptions: \"mp3_44100_128\", \"mp3_44100_192\", \"pcm_16000\", \"pcm_22050\", \"pcm_24000\"\nDefault: \"mp3_44100_128\"",
+	"github.com/haasonsaas/nexus/internal/tts.ElevenLabsConfig.SimilarityBoost":                "SimilarityBoost controls voice similarity (0.0 to 1.0).\nDefault: 0.75",
+	"github.com/haasonsaas/nexus/internal/tts.ElevenLabsConfig.Stability":                      "Stability controls voice stability (0.0 to 1.0).\nDefault: 0.5",
+	"github.com/haasonsaas/nexus/internal/tts.ElevenLabsConfig.VoiceID":                        "VoiceID is the voice ID to use.\nDefault: \"21m00Tcm4TlvDq8ikWAM\" (Rachel)",
+	"github.com/haasonsaas/nexus/internal/tts.MacOSConfig":                                     "MacOSConfig configures the macOS say provider.",
+	"github.com/haasonsaas/nexus/internal/tts.MacOSConfig.Rate":                                "Rate is the speech rate in words per minute (optional).",
+	"github.com/haasonsaas/nexus/internal/tts.MacOSConfig.Voice":                               "Voice is the macOS voice to use (optional).",
+	"github.com/haasonsaas/nexus/internal/tts.OpenAIConfig":                                    "OpenAIConfig configures OpenAI TTS.",
+	"github.com/haasonsaas/nexus/internal/tts.OpenAIConfig.APIKey":                             "APIKey is the OpenAI API key.",
+	"github.com/haasonsaas/nexus/internal/tts.OpenAIConfig.BaseURL":                            "BaseURL is the API base URL (optional).",
+	"github.com/haasonsaas/nexus/internal/tts.OpenAIConfig.Model":                              "Model is the TTS model to use.\nOptions: \"tts-1\", \"tts-1-hd\", \"gpt-4o-mini-tts\"\nDefault: \"tts-1\"",
+	"github.com/haasonsaas/nexus/internal/tts.OpenAIConfig.ResponseFormat":                     "ResponseFormat is the audio format.\nOptions: \"mp3\", \"opus\", \"aac\", \"flac\", \"wav\", \"pcm\"\nDefault: \"mp3\"",
+	"github.com/haasonsaas/nexus/internal/tts.OpenAIConfig.Speed":                              "Speed is the speech speed (0.25 to 4.0).\nDefault: 1.0",
+	"github.com/haasonsaas/nexus/internal/tts.OpenAIConfig.Voice":                              "Voice is the voice to use.\nOptions: \"alloy\", \"echo\", \"fable\", \"onyx\", \"nova\", \"shimmer\"\nDefault: \"alloy\"",
+}
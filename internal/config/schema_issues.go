@@ -0,0 +1,374 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/santhosh-tekuri/jsonschema/v5"
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	compiledSchemaOnce sync.Once
+	compiledSchema     *jsonschema.Schema
+	compiledSchemaErr  error
+)
+
+// schemaIssue is a config value that does not match JSONSchema.
+type schemaIssue struct {
+	keys    []string
+	message string
+}
+
+func (i schemaIssue) String() string {
+	return formatKeyPath(i.keys) + ": " + i.message
+}
+
+// schemaIssues validates a raw config map against JSONSchema.
+func schemaIssues(raw map[string]any) ([]schemaIssue, error) {
+	compiledSchemaOnce.Do(func() {
+		data, err := JSONSchema()
+		if err != nil {
+			compiledSchemaErr = err
+			return
+		}
+		compiler := jsonschema.NewCompiler()
+		if err := compiler.AddResource(SchemaURL, bytes.NewReader(data)); err != nil {
+			compiledSchemaErr = err
+			return
+		}
+		compiledSchema, compiledSchemaErr = compiler.Compile(SchemaURL)
+	})
+	if compiledSchemaErr != nil {
+		return nil, compiledSchemaErr
+	}
+
+	// Null values leave fields unset when decoding, so they never violate the
+	// schema. They show up wherever ${VAR} expands to nothing.
+	payload, err := json.Marshal(withoutNulls(raw))
+	if err != nil {
+		return nil, err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(payload))
+	decoder.UseNumber()
+	var value any
+	if err := decoder.Decode(&value); err != nil {
+		return nil, err
+	}
+
+	err = compiledSchema.Validate(value)
+	if err == nil {
+		return nil, nil
+	}
+	var verr *jsonschema.ValidationError
+	if !errors.As(err, &verr) {
+		return nil, err
+	}
+	var issues []schemaIssue
+	collectSchemaIssues(verr, &issues)
+	sort.SliceStable(issues, func(a, b int) bool {
+		return formatKeyPath(issues[a].keys) < formatKeyPath(issues[b].keys)
+	})
+	return issues, nil
+}
+
+// withoutNulls returns a copy of value with null map entries removed.
+func withoutNulls(value any) any {
+	switch typed := value.(type) {
+	case map[string]any:
+		out := make(map[string]any, len(typed))
+		for key, item := range typed {
+			if item != nil {
+				out[key] = withoutNulls(item)
+			}
+		}
+		return out
+	case []any:
+		out := make([]any, len(typed))
+		for i, item := range typed {
+			out[i] = withoutNulls(item)
+		}
+		return out
+	default:
+		return value
+	}
+}
+
+var quotedNamePattern = regexp.MustCompile(`'([^']*)'`)
+
+// collectSchemaIssues flattens a validation error into one issue per
+// offending value.
+func collectSchemaIssues(verr *jsonschema.ValidationError, issues *[]schemaIssue) {
+	keys := splitPointer(verr.InstanceLocation)
+	switch path.Base(verr.KeywordLocation) {
+	case "additionalProperties":
+		if len(verr.Causes) == 0 {
+			for _, match := range quotedNamePattern.FindAllStringSubmatch(verr.Message, -1) {
+				*issues = append(*issues, schemaIssue{keys: appendKey(keys, match[1]), message: "unknown field"})
+			}
+			return
+		}
+	case "anyOf", "oneOf":
+		*issues = append(*issues, schemaIssue{keys: keys, message: alternativesMessage(verr)})
+		return
+	}
+	if len(verr.Causes) == 0 {
+		*issues = append(*issues, schemaIssue{keys: keys, message: verr.Message})
+		return
+	}
+	for _, cause := range verr.Causes {
+		collectSchemaIssues(cause, issues)
+	}
+}
+
+// alternativesMessage describes a value that matched none of the schemas of
+// an anyOf or oneOf.
+func alternativesMessage(verr *jsonschema.ValidationError) string {
+	var messages []string
+	for _, cause := range verr.Causes {
+		if strings.Contains(cause.Message, "(ns|us|µs|ms|s|m|h)") {
+			return "expected a duration such as 30s or 5m"
+		}
+		messages = append(messages, cause.Message)
+	}
+	if len(messages) == 0 {
+		return verr.Message
+	}
+	return strings.Join(messages, ", or ")
+}
+
+func appendKey(keys []string, key string) []string {
+	out := make([]string, 0, len(keys)+1)
+	return append(append(out, keys...), key)
+}
+
+// splitPointer splits a JSON pointer into keys.
+func splitPointer(pointer string) []string {
+	pointer = strings.TrimPrefix(pointer, "/")
+	if pointer == "" {
+		return nil
+	}
+	keys := strings.Split(pointer, "/")
+	for i, key := range keys {
+		keys[i] = strings.NewReplacer("~1", "/", "~0", "~").Replace(key)
+	}
+	return keys
+}
+
+// formatKeyPath renders keys the way validation issues name fields, such as
+// cron.jobs[2].type.
+func formatKeyPath(keys []string) string {
+	var b strings.Builder
+	for i, key := range keys {
+		if _, err := strconv.Atoi(key); err == nil && i > 0 {
+			b.WriteString("[" + key + "]")
+			continue
+		}
+		if i > 0 {
+			b.WriteByte('.')
+		}
+		b.WriteString(key)
+	}
+	return b.String()
+}
+
+// explainDecodeError replaces an error from decoding the merged config with
+// the schema issues behind it, located in the source files. yaml.v3 reports
+// lines of the re-serialized merged config, which mean nothing to the user.
+func explainDecodeError(configPath string, raw map[string]any, err error) error {
+	issues, schemaErr := schemaIssues(raw)
+	if schemaErr != nil || len(issues) == 0 {
+		return err
+	}
+	locator := newConfigLocator(configPath)
+	located := make([]string, 0, len(issues))
+	for _, issue := range issues {
+		located = append(located, locator.prefix(issue.keys)+issue.String())
+	}
+	return &ConfigValidationError{Issues: located}
+}
+
+// locateIssues prefixes validation issues that start with a field path with
+// the file and line that set the field.
+func locateIssues(configPath string, err error) error {
+	var verr *ConfigValidationError
+	if !errors.As(err, &verr) {
+		return err
+	}
+	locator := newConfigLocator(configPath)
+	for i, issue := range verr.Issues {
+		if keys := issueKeyPath(issue); len(keys) > 0 {
+			verr.Issues[i] = locator.prefix(keys) + issue
+		}
+	}
+	return err
+}
+
+var issuePathPattern = regexp.MustCompile(`^[A-Za-z0-9_\-]+(\[\d+\])*(\.[A-Za-z0-9_\-]+(\[\d+\])*)*$`)
+
+// issueKeyPath returns the field path an issue starts with, such as
+// ["cron", "jobs", "2", "type"] for "cron.jobs[2].type is required".
+func issueKeyPath(issue string) []string {
+	token, _, _ := strings.Cut(issue, " ")
+	token = strings.TrimRight(token, ":,")
+	if !issuePathPattern.MatchString(token) {
+		return nil
+	}
+	token = strings.NewReplacer("[", ".", "]", "").Replace(token)
+	keys := strings.Split(token, ".")
+	if !topLevelKeys()[keys[0]] {
+		return nil
+	}
+	return keys
+}
+
+var topLevelKeys = sync.OnceValue(func() map[string]bool {
+	keys := map[string]bool{}
+	t := reflect.TypeOf(Config{})
+	for i := 0; i < t.NumField(); i++ {
+		if name, _, _ := strings.Cut(t.Field(i).Tag.Get("yaml"), ","); name != "" && name != "-" {
+			keys[name] = true
+		}
+	}
+	return keys
+})
+
+// configLocator finds the lines that set config fields across a config file
+// and the files it includes or extends.
+type configLocator struct {
+	sources []configSource
+	env     string
+}
+
+type configSource struct {
+	path string
+	root *yaml.Node
+}
+
+func newConfigLocator(configPath string) *configLocator {
+	locator := &configLocator{env: strings.TrimSpace(os.Getenv(EnvironmentVar))}
+	for _, file := range configSourceFiles(configPath, map[string]bool{}) {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			continue
+		}
+		var root yaml.Node
+		if err := yaml.Unmarshal(data, &root); err != nil {
+			continue
+		}
+		locator.sources = append(locator.sources, configSource{path: file, root: &root})
+	}
+	return locator
+}
+
+// prefix returns "file:line: " for the line that sets keys, or for its
+// nearest parent that is set, or "" when none is found.
+func (l *configLocator) prefix(keys []string) string {
+	if file, line := l.locate(keys); line > 0 {
+		return fmt.Sprintf("%s:%d: ", file, line)
+	}
+	return ""
+}
+
+// locate searches the files in override order: the environment overlay
+// first, then the file itself, its includes, and the file it extends.
+func (l *configLocator) locate(keys []string) (string, int) {
+	bestFile, bestLine, bestDepth := "", 0, 0
+	for _, source := range l.sources {
+		if l.env != "" {
+			line, depth := findYAMLKey(source.root, append([]string{environmentsKey, l.env}, keys...))
+			if depth-2 > bestDepth {
+				bestFile, bestLine, bestDepth = source.path, line, depth-2
+			}
+		}
+		line, depth := findYAMLKey(source.root, keys)
+		if depth > bestDepth {
+			bestFile, bestLine, bestDepth = source.path, line, depth
+		}
+		if bestDepth == len(keys) {
+			break
+		}
+	}
+	return bestFile, bestLine
+}
+
+// findYAMLKey walks keys into node and returns the line of the deepest key
+// found and how many keys matched.
+func findYAMLKey(node *yaml.Node, keys []string) (int, int) {
+	if node.Kind == yaml.DocumentNode && len(node.Content) > 0 {
+		node = node.Content[0]
+	}
+	line, depth := 0, 0
+	for _, key := range keys {
+		var next *yaml.Node
+		switch node.Kind {
+		case yaml.MappingNode:
+			for i := 0; i+1 < len(node.Content); i += 2 {
+				if node.Content[i].Value == key {
+					line = node.Content[i].Line
+					next = node.Content[i+1]
+					break
+				}
+			}
+		case yaml.SequenceNode:
+			if index, err := strconv.Atoi(key); err == nil && index >= 0 && index < len(node.Content) {
+				next = node.Content[index]
+				line = next.Line
+			}
+		}
+		if next == nil {
+			break
+		}
+		node = next
+		depth++
+	}
+	return line, depth
+}
+
+// configSourceFiles lists configPath followed by the files it includes, the
+// last include first, and then the file it extends.
+func configSourceFiles(configPath string, seen map[string]bool) []string {
+	absPath, err := filepath.Abs(configPath)
+	if err != nil || seen[absPath] {
+		return nil
+	}
+	seen[absPath] = true
+	files := []string{configPath}
+
+	data, err := os.ReadFile(absPath)
+	if err != nil {
+		return files
+	}
+	raw, err := parseRawBytes([]byte(os.ExpandEnv(string(data))), absPath)
+	if err != nil {
+		return files
+	}
+	baseDir := filepath.Dir(absPath)
+	parent, _ := extractExtends(raw)
+	includes, _ := extractIncludes(raw)
+	for i := len(includes) - 1; i >= 0; i-- {
+		inc := strings.TrimSpace(includes[i])
+		if inc == "" {
+			continue
+		}
+		if !filepath.IsAbs(inc) {
+			inc = filepath.Join(baseDir, inc)
+		}
+		files = append(files, configSourceFiles(inc, seen)...)
+	}
+	if parent != "" {
+		files = append(files, configSourceFiles(resolveExtendsPath(baseDir, parent), seen)...)
+	}
+	return files
+}
@@ -0,0 +1,151 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func loadSchema(t *testing.T) map[string]any {
+	t.Helper()
+	data, err := JSONSchema()
+	if err != nil {
+		t.Fatalf("JSONSchema() error = %v", err)
+	}
+	var schema map[string]any
+	if err := json.Unmarshal(data, &schema); err != nil {
+		t.Fatalf("unmarshal schema: %v", err)
+	}
+	return schema
+}
+
+func schemaProperty(t *testing.T, schema map[string]any, def, prop string) map[string]any {
+	t.Helper()
+	defs := schema["$defs"].(map[string]any)
+	d, ok := defs[def].(map[string]any)
+	if !ok {
+		t.Fatalf("definition %q missing", def)
+	}
+	p, ok := d["properties"].(map[string]any)[prop].(map[string]any)
+	if !ok {
+		t.Fatalf("property %s.%s missing", def, prop)
+	}
+	return p
+}
+
+func TestJSONSchemaDescriptionsAndDefaults(t *testing.T) {
+	schema := loadSchema(t)
+	if schema["$id"] != SchemaURL {
+		t.Fatalf("$id = %v", schema["$id"])
+	}
+
+	port := schemaProperty(t, schema, "ServerConfig", "http_port")
+	if port["default"] != float64(8080) {
+		t.Fatalf("http_port default = %v", port["default"])
+	}
+	period := schemaProperty(t, schema, "CostReportConfig", "period")
+	if period["default"] != "weekly" || !strings.Contains(period["description"].(string), "monthly") {
+		t.Fatalf("period = %v", period)
+	}
+	expiry := schemaProperty(t, schema, "AuthConfig", "token_expiry")
+	if _, ok := expiry["anyOf"]; !ok {
+		t.Fatalf("durations should accept strings: %v", expiry)
+	}
+
+	tts := schemaProperty(t, schema, "Config", "tts")
+	if tts["$ref"] != "#/$defs/tts.Config" {
+		t.Fatalf("tts ref = %v", tts["$ref"])
+	}
+	for name, def := range schema["$defs"].(map[string]any) {
+		if _, ok := def.(map[string]any)["required"]; ok {
+			t.Fatalf("definition %q marks fields required", name)
+		}
+	}
+}
+
+func TestPublishedSchemaUpToDate(t *testing.T) {
+	published, err := os.ReadFile(filepath.Join("..", "..", "docs", "nexus.schema.json"))
+	if err != nil {
+		t.Fatalf("read published schema: %v", err)
+	}
+	current, err := JSONSchema()
+	if err != nil {
+		t.Fatalf("JSONSchema() error = %v", err)
+	}
+	if !bytes.Equal(bytes.TrimSpace(published), bytes.TrimSpace(current)) {
+		t.Fatal("docs/nexus.schema.json is stale; run go generate ./internal/config")
+	}
+}
+
+func TestLoadLocatesUnknownFields(t *testing.T) {
+	path := writeConfig(t, `
+server:
+  host: 0.0.0.0
+  extra: true
+auth:
+  token_expiry: soon
+llm:
+  default_provider: anthropic
+  providers:
+    anthropic: {}
+`)
+
+	_, err := Load(path)
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	for _, want := range []string{
+		path + ":4: server.extra: unknown field",
+		path + ":6: auth.token_expiry: expected a duration",
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Fatalf("expected %q in:\n%v", want, err)
+		}
+	}
+}
+
+func TestLoadLocatesValidationIssues(t *testing.T) {
+	dir := t.TempDir()
+	base := filepath.Join(dir, "base.yaml")
+	if err := os.WriteFile(base, []byte("session:\n  slack_scope: nope\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	path := writeConfig(t, `
+extends: `+base+`
+llm:
+  default_provider: openai
+  providers:
+    anthropic: {}
+`)
+
+	_, err := Load(path)
+	if err == nil {
+		t.Fatal("expected validation error")
+	}
+	for _, want := range []string{
+		path + ":5: llm.providers missing entry",
+		base + ":2: session.slack_scope",
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Fatalf("expected %q in:\n%v", want, err)
+		}
+	}
+}
+
+func TestIssueKeyPath(t *testing.T) {
+	tests := map[string]string{
+		"cron.jobs[2].type is required":           "cron.jobs.2.type",
+		"observability.cost_report.period: bad":   "observability.cost_report.period",
+		"nothing here":                            "",
+		"unknown.field must be set":               "",
+		"llm.providers.my-provider.profiles[0] x": "llm.providers.my-provider.profiles.0",
+	}
+	for issue, want := range tests {
+		if got := strings.Join(issueKeyPath(issue), "."); got != want {
+			t.Errorf("issueKeyPath(%q) = %q, want %q", issue, got, want)
+		}
+	}
+}
@@ -0,0 +1,153 @@
+// Command schemagen generates the config schema artifacts. Run it through
+// go generate in internal/config:
+//
+//	schemagen -comments schema_comments.go      # field doc comments for descriptions
+//	schemagen -schema ../../docs/nexus.schema.json  # the published JSON Schema
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/format"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/haasonsaas/nexus/internal/config"
+	"github.com/invopop/jsonschema"
+)
+
+const modulePath = "github.com/haasonsaas/nexus"
+
+func main() {
+	commentsOut := flag.String("comments", "", "write the comment map Go file to this path")
+	schemaOut := flag.String("schema", "", "write the JSON Schema to this path")
+	flag.Parse()
+
+	if *commentsOut == "" && *schemaOut == "" {
+		fmt.Fprintln(os.Stderr, "schemagen: -comments or -schema is required")
+		os.Exit(2)
+	}
+	if *commentsOut != "" {
+		if err := writeComments(*commentsOut); err != nil {
+			fmt.Fprintf(os.Stderr, "schemagen: %v\n", err)
+			os.Exit(1)
+		}
+	}
+	if *schemaOut != "" {
+		schema, err := config.JSONSchema()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "schemagen: %v\n", err)
+			os.Exit(1)
+		}
+		if err := os.WriteFile(*schemaOut, append(schema, '\n'), 0o644); err != nil {
+			fmt.Fprintf(os.Stderr, "schemagen: %v\n", err)
+			os.Exit(1)
+		}
+	}
+}
+
+// writeComments extracts the doc comments of every type and field reachable
+// from config.Config and writes them as the schemaComments map.
+func writeComments(out string) error {
+	out, err := filepath.Abs(out)
+	if err != nil {
+		return err
+	}
+	root, err := moduleRoot()
+	if err != nil {
+		return err
+	}
+
+	keys := map[string]bool{}
+	packages := map[string]bool{}
+	collectKeys(reflect.TypeOf(config.Config{}), keys, packages)
+
+	// AddGoComments joins the module path with the walked directory, so it
+	// has to run from the module root.
+	if err := os.Chdir(root); err != nil {
+		return err
+	}
+	r := &jsonschema.Reflector{}
+	pkgs := make([]string, 0, len(packages))
+	for pkg := range packages {
+		pkgs = append(pkgs, pkg)
+	}
+	sort.Strings(pkgs)
+	for _, pkg := range pkgs {
+		dir := strings.TrimPrefix(strings.TrimPrefix(pkg, modulePath), "/")
+		if err := r.AddGoComments(modulePath, dir); err != nil {
+			return fmt.Errorf("comments for %s: %w", pkg, err)
+		}
+	}
+
+	names := make([]string, 0, len(keys))
+	for key := range keys {
+		if strings.TrimSpace(r.CommentMap[key]) != "" {
+			names = append(names, key)
+		}
+	}
+	sort.Strings(names)
+
+	var buf bytes.Buffer
+	buf.WriteString("// Code generated by schemagen; DO NOT EDIT.\n\npackage config\n\n")
+	buf.WriteString("// schemaComments maps types and fields reachable from Config to their doc\n// comments, which JSONSchema uses as descriptions.\n")
+	buf.WriteString("var schemaComments = map[string]string{\n")
+	for _, key := range names {
+		fmt.Fprintf(&buf, "\t%s: %s,\n", strconv.Quote(key), strconv.Quote(r.CommentMap[key]))
+	}
+	buf.WriteString("}\n")
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(out, src, 0o644)
+}
+
+// collectKeys records the comment map keys of t and the types it reaches,
+// along with the module packages that declare them.
+func collectKeys(t reflect.Type, keys, packages map[string]bool) {
+	for t.Kind() == reflect.Pointer || t.Kind() == reflect.Slice || t.Kind() == reflect.Array || t.Kind() == reflect.Map {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct || !strings.HasPrefix(t.PkgPath(), modulePath) {
+		return
+	}
+	typeKey := t.PkgPath() + "." + t.Name()
+	if keys[typeKey] {
+		return
+	}
+	keys[typeKey] = true
+	packages[t.PkgPath()] = true
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		keys[typeKey+"."+field.Name] = true
+		collectKeys(field.Type, keys, packages)
+	}
+}
+
+// moduleRoot finds the directory holding go.mod above the working directory.
+func moduleRoot() (string, error) {
+	dir, err := os.Getwd()
+	if err != nil {
+		return "", err
+	}
+	for {
+		if _, err := os.Stat(filepath.Join(dir, "go.mod")); err == nil {
+			return dir, nil
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", fmt.Errorf("go.mod not found")
+		}
+		dir = parent
+	}
+}
@@ -3,9 +3,10 @@ package context
 
 import (
 	"fmt"
-	"strings"
 	"sync"
 	"unicode/utf8"
+
+	"github.com/haasonsaas/nexus/internal/models"
 )
 
 // modelContextMu protects access to modelContextWindows
@@ -26,36 +27,10 @@ const (
 	TokensPerChar = 0.25
 )
 
-// modelContextWindows maps model IDs to their context window sizes.
+// modelContextWindows holds context window sizes registered at runtime,
+// which take precedence over the model catalog.
 // Access must be protected by modelContextMu.
-var modelContextWindows = map[string]int{
-	// Anthropic models
-	"claude-3-opus":     200000,
-	"claude-3-sonnet":   200000,
-	"claude-3-haiku":    200000,
-	"claude-3-5-sonnet": 200000,
-	"claude-3-5-haiku":  200000,
-	"claude-opus-4":     200000,
-
-	// OpenAI models
-	"gpt-4":             8192,
-	"gpt-4-32k":         32768,
-	"gpt-4-turbo":       128000,
-	"gpt-4o":            128000,
-	"gpt-4o-mini":       128000,
-	"gpt-3.5-turbo":     16385,
-	"gpt-3.5-turbo-16k": 16385,
-	"o1":                200000,
-	"o1-mini":           128000,
-	"o1-preview":        128000,
-	"o3-mini":           200000,
-
-	// Google models
-	"gemini-pro":       32768,
-	"gemini-1.5-pro":   2097152,
-	"gemini-1.5-flash": 1048576,
-	"gemini-2.0-flash": 1048576,
-}
+var modelContextWindows = map[string]int{}
 
 // WindowInfo holds information about a context window.
 type WindowInfo struct {
@@ -123,29 +98,9 @@ func NewWindow(totalTokens int, source string) *Window {
 
 // NewWindowForModel creates a context window for a specific model.
 func NewWindowForModel(modelID string) *Window {
-	modelContextMu.RLock()
-	defer modelContextMu.RUnlock()
-
-	// Try exact match first
-	if tokens, ok := modelContextWindows[modelID]; ok {
+	if tokens, ok := GetModelContextWindow(modelID); ok {
 		return NewWindow(tokens, "model")
 	}
-
-	// Try prefix match - find longest matching prefix
-	// (e.g., "gpt-4-turbo-preview" should match "gpt-4-turbo" not "gpt-4")
-	bestMatch := ""
-	bestTokens := 0
-	for prefix, tokens := range modelContextWindows {
-		if strings.HasPrefix(modelID, prefix) && len(prefix) > len(bestMatch) {
-			bestMatch = prefix
-			bestTokens = tokens
-		}
-	}
-
-	if bestMatch != "" {
-		return NewWindow(bestTokens, "model")
-	}
-
 	return NewWindow(DefaultContextWindow, "default")
 }
 
@@ -239,29 +194,19 @@ func EstimateTokensForMessages(contents []string) int {
 	return total
 }
 
-// GetModelContextWindow returns the context window for a model ID.
+// GetModelContextWindow returns the context window for a model ID, from
+// registered sizes first and then the model catalog.
 func GetModelContextWindow(modelID string) (int, bool) {
 	modelContextMu.RLock()
-	defer modelContextMu.RUnlock()
-
-	if tokens, ok := modelContextWindows[modelID]; ok {
+	tokens, ok := modelContextWindows[modelID]
+	modelContextMu.RUnlock()
+	if ok {
 		return tokens, true
 	}
 
-	// Try prefix match - find longest matching prefix
-	bestMatch := ""
-	bestTokens := 0
-	for prefix, tokens := range modelContextWindows {
-		if strings.HasPrefix(modelID, prefix) && len(prefix) > len(bestMatch) {
-			bestMatch = prefix
-			bestTokens = tokens
-		}
+	if model, ok := models.Lookup(modelID); ok && model.ContextWindow > 0 {
+		return model.ContextWindow, true
 	}
-
-	if bestMatch != "" {
-		return bestTokens, true
-	}
-
 	return 0, false
 }
 
@@ -0,0 +1,471 @@
+// Package costreport totals estimated LLM spend over a week or month by
+// provider, model, agent, and user, and renders it as a chat message and an
+// HTML attachment with a bar chart. Reports over plan switch to a warning
+// format that leads with the overspend.
+package costreport
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Period is the span a report covers.
+type Period string
+
+const (
+	// Weekly covers Monday 00:00 to the following Monday.
+	Weekly Period = "weekly"
+	// Monthly covers the first of a month to the first of the next.
+	Monthly Period = "monthly"
+)
+
+// unknownLabel stands in for a missing provider, model, agent, or user.
+const unknownLabel = "unknown"
+
+// ParsePeriod parses a period name. An empty value selects Weekly.
+func ParsePeriod(value string) (Period, error) {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "", "week", "weekly":
+		return Weekly, nil
+	case "month", "monthly":
+		return Monthly, nil
+	}
+	return "", fmt.Errorf("unknown period %q (expected weekly or monthly)", value)
+}
+
+// Window returns the last full period that ended at or before now, in now's
+// location.
+func (p Period) Window(now time.Time) (start, end time.Time) {
+	if p == Monthly {
+		end = time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
+		return end.AddDate(0, -1, 0), end
+	}
+	end = time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	// time.Weekday counts from Sunday; weeks here start on Monday.
+	end = end.AddDate(0, 0, -((int(end.Weekday()) + 6) % 7))
+	return end.AddDate(0, 0, -7), end
+}
+
+// Record is the usage of one LLM call.
+type Record struct {
+	Time         time.Time
+	Provider     string
+	Model        string
+	Agent        string
+	User         string
+	InputTokens  int64
+	OutputTokens int64
+	CostUSD      float64
+	// Priced is false when the model has no known price, so CostUSD is zero.
+	Priced bool
+}
+
+// Row totals the records sharing a provider, model, agent, or user.
+type Row struct {
+	Name         string  `json:"name"`
+	Requests     int     `json:"requests"`
+	InputTokens  int64   `json:"input_tokens"`
+	OutputTokens int64   `json:"output_tokens"`
+	CostUSD      float64 `json:"cost_usd"`
+	// Unpriced counts calls to models without a known price.
+	Unpriced int `json:"unpriced,omitempty"`
+	// PlanUSD is the provider's plan for the period, if one is set.
+	PlanUSD float64 `json:"plan_usd,omitempty"`
+}
+
+// Tokens returns the row's input and output tokens.
+func (r Row) Tokens() int64 {
+	return r.InputTokens + r.OutputTokens
+}
+
+func (r *Row) add(rec Record) {
+	r.Requests++
+	r.InputTokens += rec.InputTokens
+	r.OutputTokens += rec.OutputTokens
+	r.CostUSD += rec.CostUSD
+	if !rec.Priced {
+		r.Unpriced++
+	}
+}
+
+// Options sets the plans a report is checked against.
+type Options struct {
+	// PlanUSD is the planned total spend for the period. Zero means no plan.
+	PlanUSD float64
+	// ProviderPlansUSD are planned spends per provider.
+	ProviderPlansUSD map[string]float64
+	// WarnAt is the fraction of a plan at which the report warns
+	// (default: 1).
+	WarnAt float64
+}
+
+// Report is the spend of one period.
+type Report struct {
+	Period Period    `json:"period"`
+	Start  time.Time `json:"start"`
+	End    time.Time `json:"end"`
+
+	Total   Row     `json:"total"`
+	PlanUSD float64 `json:"plan_usd,omitempty"`
+
+	Providers []Row `json:"providers"`
+	Models    []Row `json:"models"`
+	Agents    []Row `json:"agents"`
+	Users     []Row `json:"users"`
+
+	// Warnings describe each plan the spend reached, total first.
+	Warnings []string `json:"warnings,omitempty"`
+}
+
+// Build totals the records in [start, end). Breakdowns are sorted by cost,
+// then tokens, highest first.
+func Build(period Period, start, end time.Time, records []Record, opts Options) *Report {
+	if opts.WarnAt <= 0 {
+		opts.WarnAt = 1
+	}
+	report := &Report{
+		Period:  period,
+		Start:   start,
+		End:     end,
+		Total:   Row{Name: "total"},
+		PlanUSD: opts.PlanUSD,
+	}
+	providers := map[string]*Row{}
+	models := map[string]*Row{}
+	agents := map[string]*Row{}
+	users := map[string]*Row{}
+	for _, rec := range records {
+		if rec.Time.Before(start) || !rec.Time.Before(end) {
+			continue
+		}
+		provider := orUnknown(rec.Provider)
+		report.Total.add(rec)
+		rowFor(providers, provider).add(rec)
+		rowFor(models, provider+"/"+orUnknown(rec.Model)).add(rec)
+		rowFor(agents, orUnknown(rec.Agent)).add(rec)
+		rowFor(users, orUnknown(rec.User)).add(rec)
+	}
+	for name, plan := range opts.ProviderPlansUSD {
+		if plan > 0 {
+			rowFor(providers, name).PlanUSD = plan
+		}
+	}
+	report.Providers = sortedRows(providers)
+	report.Models = sortedRows(models)
+	report.Agents = sortedRows(agents)
+	report.Users = sortedRows(users)
+
+	if report.PlanUSD > 0 && report.Total.CostUSD >= report.PlanUSD*opts.WarnAt {
+		report.Warnings = append(report.Warnings, planWarning("Total spend", report.Total.CostUSD, report.PlanUSD))
+	}
+	for _, row := range report.Providers {
+		if row.PlanUSD > 0 && row.CostUSD >= row.PlanUSD*opts.WarnAt {
+			report.Warnings = append(report.Warnings, planWarning(row.Name, row.CostUSD, row.PlanUSD))
+		}
+	}
+	return report
+}
+
+func orUnknown(value string) string {
+	if value = strings.TrimSpace(value); value == "" {
+		return unknownLabel
+	}
+	return value
+}
+
+func rowFor(rows map[string]*Row, name string) *Row {
+	row := rows[name]
+	if row == nil {
+		row = &Row{Name: name}
+		rows[name] = row
+	}
+	return row
+}
+
+func sortedRows(rows map[string]*Row) []Row {
+	out := make([]Row, 0, len(rows))
+	for _, row := range rows {
+		out = append(out, *row)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].CostUSD != out[j].CostUSD {
+			return out[i].CostUSD > out[j].CostUSD
+		}
+		if out[i].Tokens() != out[j].Tokens() {
+			return out[i].Tokens() > out[j].Tokens()
+		}
+		return out[i].Name < out[j].Name
+	})
+	return out
+}
+
+func planWarning(name string, spend, plan float64) string {
+	return fmt.Sprintf("%s %s is %.0f%% of the %s plan", name, formatUSD(spend), spend/plan*100, formatUSD(plan))
+}
+
+// OverPlan reports whether any plan reached its warning threshold.
+func (r *Report) OverPlan() bool {
+	return len(r.Warnings) > 0
+}
+
+// Title names the report and its period, e.g. "Weekly cost report,
+// Oct 5 – Oct 11, 2026".
+func (r *Report) Title() string {
+	label := "Weekly"
+	if r.Period == Monthly {
+		label = "Monthly"
+	}
+	return fmt.Sprintf("%s cost report, %s", label, r.span())
+}
+
+func (r *Report) span() string {
+	if r.Period == Monthly {
+		return r.Start.Format("January 2006")
+	}
+	last := r.End.AddDate(0, 0, -1)
+	return r.Start.Format("Jan 2") + " – " + last.Format("Jan 2, 2006")
+}
+
+// Filename returns the attachment name, e.g. "cost-report-2026-10-05.html".
+func (r *Report) Filename() string {
+	return "cost-report-" + r.Start.Format("2006-01-02") + ".html"
+}
+
+// Text renders the report as a compact message listing the top rows of each
+// breakdown. Over plan, the message opens with a warning and the overspend.
+func (r *Report) Text(top int) string {
+	var b strings.Builder
+	if r.OverPlan() {
+		fmt.Fprintf(&b, "⚠️ Spend warning: %s\n", r.Title())
+		for _, warning := range r.Warnings {
+			fmt.Fprintf(&b, "- %s\n", warning)
+		}
+	} else {
+		b.WriteString(r.Title() + "\n")
+	}
+	fmt.Fprintf(&b, "Spend %s", formatUSD(r.Total.CostUSD))
+	if r.PlanUSD > 0 {
+		fmt.Fprintf(&b, " of %s plan", formatUSD(r.PlanUSD))
+	}
+	fmt.Fprintf(&b, " · %d calls · %s tokens\n", r.Total.Requests, formatTokens(r.Total.Tokens()))
+	if r.Total.Requests == 0 {
+		b.WriteString("No LLM calls were recorded in this period.")
+		return b.String()
+	}
+	if r.Total.Unpriced > 0 {
+		fmt.Fprintf(&b, "%d calls used models without a known price and are counted at $0.\n", r.Total.Unpriced)
+	}
+
+	b.WriteString("```\n")
+	for i, section := range r.sections() {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		writeTable(&b, section.title, section.rows, top)
+	}
+	b.WriteString("```")
+	return b.String()
+}
+
+type section struct {
+	title string
+	rows  []Row
+}
+
+func (r *Report) sections() []section {
+	return []section{
+		{"Provider", r.Providers},
+		{"Model", r.Models},
+		{"Agent", r.Agents},
+		{"User", r.Users},
+	}
+}
+
+// writeTable writes up to top rows, folding the rest into one "others" row.
+func writeTable(b *strings.Builder, title string, rows []Row, top int) {
+	shown := rows
+	var others *Row
+	if top > 0 && len(rows) > top {
+		shown = rows[:top]
+		others = &Row{Name: fmt.Sprintf("%d others", len(rows)-top)}
+		for _, row := range rows[top:] {
+			others.Requests += row.Requests
+			others.InputTokens += row.InputTokens
+			others.OutputTokens += row.OutputTokens
+			others.CostUSD += row.CostUSD
+		}
+		shown = append(append([]Row(nil), shown...), *others)
+	}
+	width := len(title)
+	for _, row := range shown {
+		width = max(width, len([]rune(row.Name)))
+	}
+	width = min(width, 32)
+	fmt.Fprintf(b, "%-*s %9s %8s %6s\n", width, title, "Cost", "Tokens", "Calls")
+	for _, row := range shown {
+		name := row.Name
+		if runes := []rune(name); len(runes) > width {
+			name = string(runes[:width-1]) + "…"
+		}
+		fmt.Fprintf(b, "%-*s %9s %8s %6d\n", width, name, formatUSD(row.CostUSD), formatTokens(row.Tokens()), row.Requests)
+	}
+}
+
+// HTML renders the full report with every row and a bar chart of spend by
+// provider and model.
+func (r *Report) HTML() ([]byte, error) {
+	var buf bytes.Buffer
+	if err := htmlTemplate.Execute(&buf, r.htmlView()); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+type htmlBar struct {
+	Label string
+	Value string
+	Width float64
+	Y     int
+	Over  bool
+}
+
+type htmlChart struct {
+	Title  string
+	Height int
+	Bars   []htmlBar
+}
+
+type htmlTable struct {
+	Title string
+	Rows  [][]string
+}
+
+type htmlView struct {
+	Title    string
+	Summary  string
+	Warnings []string
+	Charts   []htmlChart
+	Tables   []htmlTable
+}
+
+const (
+	// chartBars caps the bars drawn per chart.
+	chartBars = 10
+	// chartWidth is the longest bar, in SVG units.
+	chartWidth = 460
+)
+
+func (r *Report) htmlView() htmlView {
+	view := htmlView{
+		Title:    r.Title(),
+		Warnings: r.Warnings,
+		Summary: fmt.Sprintf("Spend %s · %d calls · %s input and %s output tokens",
+			formatUSD(r.Total.CostUSD), r.Total.Requests, formatTokens(r.Total.InputTokens), formatTokens(r.Total.OutputTokens)),
+	}
+	if r.PlanUSD > 0 {
+		view.Summary += " · plan " + formatUSD(r.PlanUSD)
+	}
+	view.Charts = []htmlChart{
+		chart("Spend by provider", r.Providers),
+		chart("Spend by model", r.Models),
+	}
+	for _, section := range r.sections() {
+		table := htmlTable{Title: section.title}
+		for _, row := range section.rows {
+			plan := ""
+			if row.PlanUSD > 0 {
+				plan = formatUSD(row.PlanUSD)
+			}
+			table.Rows = append(table.Rows, []string{
+				row.Name,
+				formatUSD(row.CostUSD),
+				plan,
+				fmt.Sprint(row.InputTokens),
+				fmt.Sprint(row.OutputTokens),
+				fmt.Sprint(row.Requests),
+			})
+		}
+		view.Tables = append(view.Tables, table)
+	}
+	return view
+}
+
+// chart draws the top rows, scaled to the highest spend. Providers at or
+// over plan are drawn in red.
+func chart(title string, rows []Row) htmlChart {
+	rows = rows[:min(len(rows), chartBars)]
+	peak := 0.0
+	for _, row := range rows {
+		peak = max(peak, row.CostUSD)
+	}
+	c := htmlChart{Title: title, Height: len(rows)*24 + 8}
+	for i, row := range rows {
+		bar := htmlBar{
+			Label: row.Name,
+			Value: formatUSD(row.CostUSD),
+			Y:     i*24 + 4,
+			Over:  row.PlanUSD > 0 && row.CostUSD >= row.PlanUSD,
+		}
+		if peak > 0 {
+			bar.Width = row.CostUSD / peak * chartWidth
+		}
+		c.Bars = append(c.Bars, bar)
+	}
+	return c
+}
+
+func formatUSD(value float64) string {
+	if value > 0 && value < 0.01 {
+		return "<$0.01"
+	}
+	return fmt.Sprintf("$%.2f", value)
+}
+
+func formatTokens(n int64) string {
+	switch {
+	case n >= 1_000_000:
+		return fmt.Sprintf("%.1fM", float64(n)/1_000_000)
+	case n >= 1_000:
+		return fmt.Sprintf("%.1fk", float64(n)/1_000)
+	}
+	return fmt.Sprint(n)
+}
+
+var htmlTemplate = template.Must(template.New("costreport").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>{{.Title}}</title>
+<style>
+body { font-family: -apple-system, BlinkMacSystemFont, "Segoe UI", sans-serif; max-width: 860px; margin: 2rem auto; padding: 0 1rem; color: #1f2328; line-height: 1.5; }
+h1 { margin-bottom: 0.25rem; }
+.summary { color: #57606a; }
+.warnings { background: #fff8c5; border: 1px solid #d4a72c; border-radius: 8px; padding: 0.5rem 1rem 0.5rem 2rem; }
+svg { width: 100%; font-size: 12px; }
+svg .label { fill: #1f2328; }
+svg .bar { fill: #0969da; }
+svg .bar.over { fill: #cf222e; }
+table { border-collapse: collapse; width: 100%; margin-bottom: 1.5rem; }
+th, td { border: 1px solid #d0d7de; padding: 0.25rem 0.75rem; text-align: right; }
+th:first-child, td:first-child { text-align: left; }
+th { background: #f6f8fa; }
+</style>
+</head>
+<body>
+<h1>{{.Title}}</h1>
+<p class="summary">{{.Summary}}</p>
+{{if .Warnings}}<ul class="warnings">{{range .Warnings}}<li>{{.}}</li>{{end}}</ul>
+{{end}}{{range .Charts}}{{if .Bars}}<h2>{{.Title}}</h2>
+<svg viewBox="0 0 800 {{.Height}}" role="img" aria-label="{{.Title}}">
+{{range .Bars}}<text class="label" x="0" y="{{.Y}}" dy="14">{{.Label}}</text><rect class="bar{{if .Over}} over{{end}}" x="240" y="{{.Y}}" width="{{printf "%.1f" .Width}}" height="18"></rect><text x="790" y="{{.Y}}" dy="14" text-anchor="end">{{.Value}}</text>
+{{end}}</svg>
+{{end}}{{end}}{{range .Tables}}<h2>By {{.Title}}</h2>
+<table><tr><th>{{.Title}}</th><th>Cost</th><th>Plan</th><th>Input tokens</th><th>Output tokens</th><th>Calls</th></tr>
+{{range .Rows}}<tr>{{range .}}<td>{{.}}</td>{{end}}</tr>
+{{end}}</table>
+{{end}}</body>
+</html>`))
@@ -0,0 +1,133 @@
+package costreport
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPeriodWindow(t *testing.T) {
+	loc := time.FixedZone("UTC-7", -7*3600)
+	// Monday, Oct 12 2026 09:00.
+	now := time.Date(2026, 10, 12, 9, 0, 0, 0, loc)
+
+	start, end := Weekly.Window(now)
+	if !start.Equal(time.Date(2026, 10, 5, 0, 0, 0, 0, loc)) || !end.Equal(time.Date(2026, 10, 12, 0, 0, 0, 0, loc)) {
+		t.Fatalf("Weekly.Window() = %v, %v", start, end)
+	}
+	start, _ = Weekly.Window(now.AddDate(0, 0, 6))
+	if !start.Equal(time.Date(2026, 10, 5, 0, 0, 0, 0, loc)) {
+		t.Fatalf("Weekly.Window() on Sunday starts %v", start)
+	}
+	start, end = Monthly.Window(now)
+	if !start.Equal(time.Date(2026, 9, 1, 0, 0, 0, 0, loc)) || !end.Equal(time.Date(2026, 10, 1, 0, 0, 0, 0, loc)) {
+		t.Fatalf("Monthly.Window() = %v, %v", start, end)
+	}
+
+	if _, err := ParsePeriod("daily"); err == nil {
+		t.Fatal("ParsePeriod(daily) should fail")
+	}
+	if p, err := ParsePeriod("Monthly"); err != nil || p != Monthly {
+		t.Fatalf("ParsePeriod(Monthly) = %q, %v", p, err)
+	}
+}
+
+func testRecords(start time.Time) []Record {
+	return []Record{
+		{Time: start.Add(time.Hour), Provider: "anthropic", Model: "claude-sonnet", Agent: "main", User: "slack:U1", InputTokens: 1000, OutputTokens: 500, CostUSD: 6, Priced: true},
+		{Time: start.Add(2 * time.Hour), Provider: "anthropic", Model: "claude-haiku", Agent: "main", User: "slack:U2", InputTokens: 2000, OutputTokens: 100, CostUSD: 1, Priced: true},
+		{Time: start.Add(3 * time.Hour), Provider: "openai", Model: "gpt-x", Agent: "support", User: "telegram:7", InputTokens: 300, OutputTokens: 300, CostUSD: 2, Priced: true},
+		{Time: start.Add(4 * time.Hour), Provider: "ollama", Model: "llama", InputTokens: 50, OutputTokens: 50},
+		// Outside the window.
+		{Time: start.Add(-time.Hour), Provider: "openai", Model: "gpt-x", CostUSD: 100, Priced: true},
+	}
+}
+
+func TestBuildAggregates(t *testing.T) {
+	start := time.Date(2026, 10, 5, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(0, 0, 7)
+	report := Build(Weekly, start, end, testRecords(start), Options{PlanUSD: 20})
+
+	if report.Total.Requests != 4 || report.Total.CostUSD != 9 || report.Total.Unpriced != 1 {
+		t.Fatalf("unexpected total: %+v", report.Total)
+	}
+	if report.OverPlan() {
+		t.Fatalf("report under plan has warnings: %q", report.Warnings)
+	}
+	if got := report.Providers[0]; got.Name != "anthropic" || got.Requests != 2 || got.CostUSD != 7 {
+		t.Fatalf("top provider = %+v", got)
+	}
+	if got := report.Models[0].Name; got != "anthropic/claude-sonnet" {
+		t.Fatalf("top model = %q", got)
+	}
+	if got := report.Agents[len(report.Agents)-1].Name; got != unknownLabel {
+		t.Fatalf("last agent = %q, want %q", got, unknownLabel)
+	}
+	if len(report.Users) != 4 {
+		t.Fatalf("users = %+v", report.Users)
+	}
+
+	text := report.Text(2)
+	for _, want := range []string{"Weekly cost report, Oct 5 – Oct 11, 2026", "Spend $9.00 of $20.00 plan", "2 others", "1 calls used models without a known price"} {
+		if !strings.Contains(text, want) {
+			t.Fatalf("Text() missing %q:\n%s", want, text)
+		}
+	}
+	if strings.Contains(text, "Spend warning") {
+		t.Fatalf("Text() under plan should not warn:\n%s", text)
+	}
+}
+
+func TestBuildWarnsOverPlan(t *testing.T) {
+	start := time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(0, 1, 0)
+	report := Build(Monthly, start, end, testRecords(start), Options{
+		PlanUSD:          10,
+		ProviderPlansUSD: map[string]float64{"anthropic": 5, "openai": 10, "bedrock": 3},
+		WarnAt:           0.8,
+	})
+
+	if len(report.Warnings) != 2 ||
+		report.Warnings[0] != "Total spend $9.00 is 90% of the $10.00 plan" ||
+		report.Warnings[1] != "anthropic $7.00 is 140% of the $5.00 plan" {
+		t.Fatalf("warnings = %q", report.Warnings)
+	}
+	text := report.Text(5)
+	if !strings.HasPrefix(text, "⚠️ Spend warning: Monthly cost report, September 2026\n- Total spend") {
+		t.Fatalf("Text() over plan should lead with the warning:\n%s", text)
+	}
+	var bedrock *Row
+	for i := range report.Providers {
+		if report.Providers[i].Name == "bedrock" {
+			bedrock = &report.Providers[i]
+		}
+	}
+	if bedrock == nil || bedrock.PlanUSD != 3 || bedrock.Requests != 0 {
+		t.Fatalf("planned provider without usage = %+v", bedrock)
+	}
+
+	html, err := report.HTML()
+	if err != nil {
+		t.Fatalf("HTML() error = %v", err)
+	}
+	page := string(html)
+	for _, want := range []string{"<svg", `class="bar over"`, "Spend by model", "anthropic/claude-sonnet", "140% of the $5.00 plan"} {
+		if !strings.Contains(page, want) {
+			t.Fatalf("HTML() missing %q", want)
+		}
+	}
+	if report.Filename() != "cost-report-2026-09-01.html" {
+		t.Fatalf("Filename() = %q", report.Filename())
+	}
+}
+
+func TestEmptyReport(t *testing.T) {
+	start := time.Date(2026, 10, 5, 0, 0, 0, 0, time.UTC)
+	report := Build(Weekly, start, start.AddDate(0, 0, 7), nil, Options{})
+	if text := report.Text(5); !strings.Contains(text, "No LLM calls were recorded") {
+		t.Fatalf("Text() = %q", text)
+	}
+	if _, err := report.HTML(); err != nil {
+		t.Fatalf("HTML() error = %v", err)
+	}
+}
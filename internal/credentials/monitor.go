@@ -0,0 +1,335 @@
+// Package credentials periodically validates provider API keys and channel
+// tokens so broken or exhausted credentials are noticed before users see
+// failed replies.
+package credentials
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/haasonsaas/nexus/internal/config"
+	"github.com/haasonsaas/nexus/internal/infra"
+	"github.com/haasonsaas/nexus/internal/messages"
+	"github.com/haasonsaas/nexus/internal/onboard"
+)
+
+// Kind distinguishes provider keys from channel tokens.
+type Kind string
+
+const (
+	KindProvider Kind = "provider"
+	KindChannel  Kind = "channel"
+)
+
+// State is the outcome of the most recent check for a credential.
+type State string
+
+const (
+	StateUnknown  State = "unknown"
+	StateOK       State = "ok"
+	StateLowQuota State = "low_quota"
+	StateInvalid  State = "invalid"
+	// StateError means the check itself failed, e.g. a network error, so
+	// the credential may still be valid.
+	StateError State = "error"
+)
+
+// checkTimeout bounds each individual credential check.
+const checkTimeout = 15 * time.Second
+
+// Target is a credential to validate.
+type Target struct {
+	Kind   Kind
+	Name   string
+	Secret string
+}
+
+// Status is the last recorded check result for a target.
+type Status struct {
+	Kind      Kind          `json:"kind"`
+	Name      string        `json:"name"`
+	State     State         `json:"state"`
+	Message   string        `json:"message,omitempty"`
+	Quota     onboard.Quota `json:"quota"`
+	CheckedAt time.Time     `json:"checked_at"`
+	// FailingSince is when the credential last left StateOK.
+	FailingSince time.Time `json:"failing_since,omitempty"`
+
+	// notified is the last state administrators were alerted about.
+	notified State
+}
+
+// Healthy reports whether the credential is usable.
+func (s Status) Healthy() bool {
+	return s.State == StateOK || s.State == StateLowQuota
+}
+
+// Checker performs the live validation calls. *onboard.Validator satisfies it.
+type Checker interface {
+	CheckProvider(ctx context.Context, provider, apiKey string) (onboard.Quota, error)
+	ValidateChannel(ctx context.Context, channel, token string) (onboard.ChannelIdentity, error)
+}
+
+// AlertFunc delivers an alert message to administrators.
+type AlertFunc func(ctx context.Context, text string) error
+
+// Targets lists the credentials in cfg that can be validated: API keys of
+// supported LLM providers and tokens of enabled channels.
+func Targets(cfg *config.Config) []Target {
+	if cfg == nil {
+		return nil
+	}
+	var targets []Target
+	for name, provider := range cfg.LLM.Providers {
+		if strings.TrimSpace(provider.APIKey) == "" || strings.TrimSpace(provider.BaseURL) != "" {
+			// Custom base URLs point at proxies or self-hosted endpoints the
+			// validator does not know how to check.
+			continue
+		}
+		if !supportedProvider(name) {
+			continue
+		}
+		targets = append(targets, Target{Kind: KindProvider, Name: name, Secret: provider.APIKey})
+	}
+	channels := cfg.Channels
+	if channels.Telegram.Enabled && channels.Telegram.BotToken != "" {
+		targets = append(targets, Target{Kind: KindChannel, Name: "telegram", Secret: channels.Telegram.BotToken})
+	}
+	if channels.Discord.Enabled && channels.Discord.BotToken != "" {
+		targets = append(targets, Target{Kind: KindChannel, Name: "discord", Secret: channels.Discord.BotToken})
+	}
+	if channels.Slack.Enabled && channels.Slack.BotToken != "" {
+		targets = append(targets, Target{Kind: KindChannel, Name: "slack", Secret: channels.Slack.BotToken})
+	}
+	sort.Slice(targets, func(i, j int) bool {
+		if targets[i].Kind != targets[j].Kind {
+			return targets[i].Kind > targets[j].Kind
+		}
+		return targets[i].Name < targets[j].Name
+	})
+	return targets
+}
+
+func supportedProvider(name string) bool {
+	switch strings.ToLower(name) {
+	case "anthropic", "openai", "google", "openrouter":
+		return true
+	}
+	return false
+}
+
+// Monitor validates a fixed set of credentials and tracks their state.
+type Monitor struct {
+	checker   Checker
+	targets   []Target
+	warnRatio float64
+	logger    *slog.Logger
+	now       func() time.Time
+
+	mu       sync.RWMutex
+	alert    AlertFunc
+	catalog  *messages.Catalog
+	locale   string
+	statuses map[string]Status
+}
+
+// NewMonitor creates a monitor for targets. A nil checker uses the live
+// provider and channel APIs.
+func NewMonitor(cfg config.CredentialMonitorConfig, targets []Target, checker Checker, logger *slog.Logger) *Monitor {
+	if checker == nil {
+		checker = onboard.NewValidator()
+	}
+	if logger == nil {
+		logger = slog.Default()
+	}
+	m := &Monitor{
+		checker:   checker,
+		targets:   targets,
+		warnRatio: cfg.QuotaWarnRatio,
+		logger:    logger.With("component", "credentials"),
+		now:       time.Now,
+		statuses:  make(map[string]Status, len(targets)),
+	}
+	for _, target := range targets {
+		m.statuses[targetKey(target)] = Status{Kind: target.Kind, Name: target.Name, State: StateUnknown}
+	}
+	return m
+}
+
+// SetAlert sets the function used to notify administrators of credentials
+// that start failing, run low, or recover.
+func (m *Monitor) SetAlert(fn AlertFunc) {
+	m.mu.Lock()
+	m.alert = fn
+	m.mu.Unlock()
+}
+
+// SetMessages sets the catalog and locale used to word alerts.
+func (m *Monitor) SetMessages(catalog *messages.Catalog, locale string) {
+	m.mu.Lock()
+	m.catalog = catalog
+	m.locale = locale
+	m.mu.Unlock()
+}
+
+// Check validates every target, records the results, and logs and alerts on
+// state changes. It returns the new statuses.
+func (m *Monitor) Check(ctx context.Context) []Status {
+	results := make([]Status, len(m.targets))
+	var wg sync.WaitGroup
+	for i, target := range m.targets {
+		wg.Add(1)
+		go func(i int, target Target) {
+			defer wg.Done()
+			results[i] = m.checkTarget(ctx, target)
+		}(i, target)
+	}
+	wg.Wait()
+
+	var alerts []string
+	m.mu.Lock()
+	for i, target := range m.targets {
+		key := targetKey(target)
+		prev := m.statuses[key]
+		status := results[i]
+		if status.State != StateOK {
+			status.FailingSince = prev.FailingSince
+			if prev.State == StateOK || prev.State == StateUnknown || prev.FailingSince.IsZero() {
+				status.FailingSince = status.CheckedAt
+			}
+		}
+		status.notified = prev.notified
+		if msg := transitionMessage(m.catalog, m.locale, prev.notified, status); msg != "" {
+			alerts = append(alerts, msg)
+		}
+		if status.State != StateError {
+			status.notified = status.State
+		}
+		m.statuses[key] = status
+		results[i] = status
+	}
+	alert, catalog, locale := m.alert, m.catalog, m.locale
+	m.mu.Unlock()
+
+	for _, status := range results {
+		switch status.State {
+		case StateInvalid:
+			m.logger.Error("credential rejected", "kind", status.Kind, "name", status.Name, "error", status.Message)
+		case StateLowQuota:
+			m.logger.Warn("credential quota low", "kind", status.Kind, "name", status.Name,
+				"remaining", status.Quota.Remaining, "limit", status.Quota.Limit, "unit", status.Quota.Unit)
+		case StateError:
+			m.logger.Warn("credential check failed", "kind", status.Kind, "name", status.Name, "error", status.Message)
+		}
+	}
+
+	if alert != nil && len(alerts) > 0 {
+		text := catalog.Render(messages.CredentialAlert, locale, messages.Data{"Changes": strings.Join(alerts, "\n")})
+		if err := alert(ctx, text); err != nil {
+			m.logger.Warn("failed to send credential alert", "error", err)
+		}
+	}
+	return results
+}
+
+func (m *Monitor) checkTarget(ctx context.Context, target Target) Status {
+	ctx, cancel := context.WithTimeout(ctx, checkTimeout)
+	defer cancel()
+
+	status := Status{Kind: target.Kind, Name: target.Name, State: StateOK}
+	var err error
+	switch target.Kind {
+	case KindProvider:
+		status.Quota, err = m.checker.CheckProvider(ctx, target.Name, target.Secret)
+	case KindChannel:
+		_, err = m.checker.ValidateChannel(ctx, target.Name, target.Secret)
+	default:
+		err = fmt.Errorf("unknown credential kind %q", target.Kind)
+	}
+	status.CheckedAt = m.now()
+
+	switch {
+	case errors.Is(err, onboard.ErrInvalidCredentials):
+		status.State = StateInvalid
+		status.Message = err.Error()
+	case errors.Is(err, onboard.ErrQuotaExceeded):
+		status.State = StateLowQuota
+		status.Message = err.Error()
+	case err != nil:
+		status.State = StateError
+		status.Message = err.Error()
+	case status.Quota.Known() && status.Quota.Fraction() < m.warnRatio:
+		status.State = StateLowQuota
+		status.Message = fmt.Sprintf("%.0f of %.0f %s remaining", status.Quota.Remaining, status.Quota.Limit, status.Quota.Unit)
+	}
+	return status
+}
+
+// transitionMessage describes a change from the last notified state worth
+// alerting on. Transient check errors are only logged.
+func transitionMessage(catalog *messages.Catalog, locale string, notified State, next Status) string {
+	if notified == "" {
+		notified = StateOK
+	}
+	if notified == next.State || next.State == StateError {
+		return ""
+	}
+	data := messages.Data{"Name": next.Name, "Kind": string(next.Kind), "Message": next.Message}
+	switch next.State {
+	case StateInvalid:
+		return catalog.Render(messages.CredentialsRejected, locale, data)
+	case StateLowQuota:
+		return catalog.Render(messages.QuotaLow, locale, data)
+	case StateOK:
+		return catalog.Render(messages.CredentialsHealthy, locale, data)
+	}
+	return ""
+}
+
+// Statuses returns the most recent status of every target.
+func (m *Monitor) Statuses() []Status {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	out := make([]Status, 0, len(m.targets))
+	for _, target := range m.targets {
+		out = append(out, m.statuses[targetKey(target)])
+	}
+	return out
+}
+
+// HealthCheck summarizes the recorded statuses without making new calls, for
+// registration with infra.HealthCheckRegistry.
+func (m *Monitor) HealthCheck(ctx context.Context) infra.HealthCheckResult {
+	result := infra.HealthCheckResult{
+		Name:      "credentials",
+		Status:    infra.ServiceHealthHealthy,
+		Timestamp: m.now(),
+		Metadata:  map[string]string{},
+	}
+	var problems []string
+	for _, status := range m.Statuses() {
+		result.Metadata[string(status.Kind)+"."+status.Name] = string(status.State)
+		switch status.State {
+		case StateInvalid:
+			result.Status = infra.ServiceHealthUnhealthy
+			problems = append(problems, status.Name+" rejected")
+		case StateLowQuota, StateError:
+			if result.Status == infra.ServiceHealthHealthy {
+				result.Status = infra.ServiceHealthDegraded
+			}
+			problems = append(problems, fmt.Sprintf("%s %s", status.Name, strings.ReplaceAll(string(status.State), "_", " ")))
+		}
+	}
+	result.Message = strings.Join(problems, ", ")
+	return result
+}
+
+func targetKey(target Target) string {
+	return string(target.Kind) + ":" + target.Name
+}
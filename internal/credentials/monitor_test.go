@@ -0,0 +1,159 @@
+package credentials
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/haasonsaas/nexus/internal/config"
+	"github.com/haasonsaas/nexus/internal/infra"
+	"github.com/haasonsaas/nexus/internal/onboard"
+)
+
+type fakeChecker struct {
+	mu       sync.Mutex
+	provider map[string]error
+	quota    map[string]onboard.Quota
+	channel  map[string]error
+}
+
+func (f *fakeChecker) CheckProvider(ctx context.Context, provider, apiKey string) (onboard.Quota, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.quota[provider], f.provider[provider]
+}
+
+func (f *fakeChecker) ValidateChannel(ctx context.Context, channel, token string) (onboard.ChannelIdentity, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return onboard.ChannelIdentity{}, f.channel[channel]
+}
+
+func (f *fakeChecker) set(fn func()) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	fn()
+}
+
+func TestTargets(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.LLM.Providers = map[string]config.LLMProviderConfig{
+		"openai":    {APIKey: "sk-1"},
+		"anthropic": {APIKey: "sk-2"},
+		"ollama":    {APIKey: "unused"},
+		"google":    {APIKey: "key", BaseURL: "https://proxy.example.com"},
+		"bedrock":   {},
+	}
+	cfg.Channels.Slack = config.SlackConfig{Enabled: true, BotToken: "xoxb"}
+	cfg.Channels.Telegram = config.TelegramConfig{Enabled: false, BotToken: "123:abc"}
+
+	var got []string
+	for _, target := range Targets(cfg) {
+		got = append(got, fmt.Sprintf("%s:%s", target.Kind, target.Name))
+	}
+	want := "provider:anthropic provider:openai channel:slack"
+	if strings.Join(got, " ") != want {
+		t.Fatalf("Targets() = %v, want %s", got, want)
+	}
+}
+
+func TestMonitorCheckStates(t *testing.T) {
+	checker := &fakeChecker{
+		provider: map[string]error{
+			"anthropic": fmt.Errorf("%w (401 Unauthorized)", onboard.ErrInvalidCredentials),
+			"google":    errors.New("dial tcp: timeout"),
+		},
+		quota: map[string]onboard.Quota{
+			"openai": {Remaining: 5, Limit: 100, Unit: "requests"},
+		},
+	}
+	targets := []Target{
+		{Kind: KindProvider, Name: "anthropic", Secret: "bad"},
+		{Kind: KindProvider, Name: "openai", Secret: "low"},
+		{Kind: KindProvider, Name: "google", Secret: "key"},
+		{Kind: KindChannel, Name: "slack", Secret: "xoxb"},
+	}
+	monitor := NewMonitor(config.CredentialMonitorConfig{QuotaWarnRatio: 0.1}, targets, checker, nil)
+
+	statuses := monitor.Check(context.Background())
+	want := []State{StateInvalid, StateLowQuota, StateError, StateOK}
+	for i, status := range statuses {
+		if status.State != want[i] {
+			t.Errorf("%s state = %s, want %s", status.Name, status.State, want[i])
+		}
+	}
+	if statuses[0].FailingSince.IsZero() || !statuses[3].FailingSince.IsZero() {
+		t.Errorf("unexpected FailingSince: %+v", statuses)
+	}
+
+	health := monitor.HealthCheck(context.Background())
+	if health.Status != infra.ServiceHealthUnhealthy || !strings.Contains(health.Message, "anthropic rejected") {
+		t.Fatalf("unexpected health %+v", health)
+	}
+}
+
+func TestMonitorAlertsOnTransitions(t *testing.T) {
+	checker := &fakeChecker{}
+	targets := []Target{{Kind: KindChannel, Name: "telegram", Secret: "token"}}
+	monitor := NewMonitor(config.CredentialMonitorConfig{QuotaWarnRatio: 0.1}, targets, checker, nil)
+
+	var alerts []string
+	monitor.SetAlert(func(ctx context.Context, text string) error {
+		alerts = append(alerts, text)
+		return nil
+	})
+
+	monitor.Check(context.Background())
+	if len(alerts) != 0 {
+		t.Fatalf("expected no alert for a healthy first check, got %v", alerts)
+	}
+
+	checker.set(func() {
+		checker.channel = map[string]error{"telegram": fmt.Errorf("%w (unknown bot token)", onboard.ErrInvalidCredentials)}
+	})
+	monitor.Check(context.Background())
+	monitor.Check(context.Background())
+	if len(alerts) != 1 || !strings.Contains(alerts[0], "telegram channel credentials were rejected") {
+		t.Fatalf("expected a single rejection alert, got %v", alerts)
+	}
+	failingSince := monitor.Statuses()[0].FailingSince
+
+	checker.set(func() {
+		checker.channel = map[string]error{"telegram": errors.New("connection reset")}
+	})
+	monitor.Check(context.Background())
+	if len(alerts) != 1 {
+		t.Fatalf("expected transient errors not to alert, got %v", alerts)
+	}
+	if got := monitor.Statuses()[0].FailingSince; !got.Equal(failingSince) {
+		t.Fatalf("FailingSince changed from %v to %v", failingSince, got)
+	}
+
+	checker.set(func() { checker.channel = nil })
+	monitor.Check(context.Background())
+	if len(alerts) != 2 || !strings.Contains(alerts[1], "healthy again") {
+		t.Fatalf("expected a recovery alert, got %v", alerts)
+	}
+}
+
+func TestMonitorAlertsOnRecovery(t *testing.T) {
+	checker := &fakeChecker{quota: map[string]onboard.Quota{"openrouter": {Remaining: 1, Limit: 20, Unit: "credits"}}}
+	targets := []Target{{Kind: KindProvider, Name: "openrouter", Secret: "key"}}
+	monitor := NewMonitor(config.CredentialMonitorConfig{QuotaWarnRatio: 0.1}, targets, checker, nil)
+
+	var alerts []string
+	monitor.SetAlert(func(ctx context.Context, text string) error {
+		alerts = append(alerts, text)
+		return nil
+	})
+
+	monitor.Check(context.Background())
+	checker.set(func() { checker.quota = nil })
+	monitor.Check(context.Background())
+	if len(alerts) != 2 || !strings.Contains(alerts[0], "running low on quota: 1 of 20 credits") || !strings.Contains(alerts[1], "healthy again") {
+		t.Fatalf("unexpected alerts %v", alerts)
+	}
+}
@@ -30,6 +30,7 @@ type Scheduler struct {
 	executionStore ExecutionStore
 	now            func() time.Time
 	tickInterval   time.Duration
+	leaderCheck    func() bool
 
 	mu      sync.Mutex
 	started bool
@@ -109,6 +110,13 @@ func WithTickInterval(interval time.Duration) Option {
 	}
 }
 
+// WithLeaderCheck restricts job execution to nodes where check returns true.
+func WithLeaderCheck(check func() bool) Option {
+	return func(s *Scheduler) {
+		s.leaderCheck = check
+	}
+}
+
 // SetMessageSender updates the sender for message jobs after initialization.
 func (s *Scheduler) SetMessageSender(sender MessageSender) {
 	if s == nil || sender == nil {
@@ -139,6 +147,18 @@ func (s *Scheduler) SetExecutionStore(store ExecutionStore) {
 	s.mu.Unlock()
 }
 
+// SetLeaderCheck updates the leader check after initialization. While the check
+// returns false, due jobs are skipped and rescheduled so that only one node in a
+// cluster executes them.
+func (s *Scheduler) SetLeaderCheck(check func() bool) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	s.leaderCheck = check
+	s.mu.Unlock()
+}
+
 // RegisterCustomHandler registers a handler for custom cron jobs.
 func (s *Scheduler) RegisterCustomHandler(name string, handler CustomHandler) {
 	if s == nil || handler == nil {
@@ -376,7 +396,9 @@ func (s *Scheduler) runDue(ctx context.Context) int {
 	s.mu.Lock()
 	jobs := make([]*Job, len(s.jobs))
 	copy(jobs, s.jobs)
+	leaderCheck := s.leaderCheck
 	s.mu.Unlock()
+	follower := leaderCheck != nil && !leaderCheck()
 
 	for _, job := range jobs {
 		if job == nil {
@@ -387,6 +409,13 @@ func (s *Scheduler) runDue(ctx context.Context) int {
 			s.mu.Unlock()
 			continue
 		}
+		if follower {
+			// Another node runs this occurrence; keep the schedule in step so
+			// a failover does not replay missed runs.
+			s.skipRun(job, now)
+			s.mu.Unlock()
+			continue
+		}
 		s.mu.Unlock()
 
 		err := s.runJob(ctx, job, now)
@@ -434,6 +463,18 @@ func (s *Scheduler) runJob(ctx context.Context, job *Job, now time.Time) error {
 	return err
 }
 
+// skipRun advances job to its next occurrence without executing it.
+// The caller must hold s.mu.
+func (s *Scheduler) skipRun(job *Job, now time.Time) {
+	next, disable, err := s.nextRunForJob(job, job.Schedule, now, nil)
+	if err != nil || disable {
+		job.NextRun = time.Time{}
+		job.Enabled = false
+		return
+	}
+	job.NextRun = next
+}
+
 func (s *Scheduler) startExecution(ctx context.Context, job *Job, retryCount int, startedAt time.Time) *JobExecution {
 	if s == nil || s.executionStore == nil || job == nil {
 		return nil
@@ -183,6 +183,57 @@ func TestSchedulerRunsWebhookJob(t *testing.T) {
 	}
 }
 
+func TestSchedulerSkipsJobsWhenNotLeader(t *testing.T) {
+	var hits int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&hits, 1)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	now := time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC)
+	cfg := config.CronConfig{
+		Enabled: true,
+		Jobs: []config.CronJobConfig{
+			{
+				ID:       "job-1",
+				Name:     "webhook",
+				Type:     "webhook",
+				Enabled:  true,
+				Schedule: config.CronScheduleConfig{Every: time.Minute},
+				Webhook:  &config.CronWebhookConfig{URL: server.URL},
+			},
+		},
+	}
+
+	var leader atomic.Bool
+	scheduler, err := NewScheduler(cfg,
+		WithNow(func() time.Time { return now }),
+		WithHTTPClient(server.Client()),
+		WithLeaderCheck(leader.Load),
+	)
+	if err != nil {
+		t.Fatalf("NewScheduler() error = %v", err)
+	}
+
+	now = now.Add(2 * time.Minute)
+	if count := scheduler.RunOnce(context.Background()); count != 0 {
+		t.Fatalf("expected follower to run 0 jobs, got %d", count)
+	}
+	if atomic.LoadInt32(&hits) != 0 {
+		t.Fatal("expected follower not to call webhook")
+	}
+	if next := scheduler.Jobs()[0].NextRun; !next.After(now) {
+		t.Fatalf("expected follower to advance next run past %v, got %v", now, next)
+	}
+
+	leader.Store(true)
+	now = now.Add(2 * time.Minute)
+	if count := scheduler.RunOnce(context.Background()); count != 1 {
+		t.Fatalf("expected leader to run 1 job, got %d", count)
+	}
+}
+
 func TestSchedulerRunsJobWithHeaders(t *testing.T) {
 	var receivedHeader string
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -0,0 +1,112 @@
+// Package delivery routes proactive messages (reminders, digests, alerts)
+// to the channel where a user whose identity is linked across channels is
+// most likely to see them.
+package delivery
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/haasonsaas/nexus/pkg/models"
+)
+
+// Address is a conversation the agent can post to.
+type Address struct {
+	Channel models.ChannelType
+	// PeerID is the conversation ID stored on outbound messages (the
+	// Telegram chat, Slack DM channel, ...).
+	PeerID string
+	// Metadata holds the channel routing keys the adapter needs to send
+	// (chat_id, slack_channel, discord_channel_id, peer_id).
+	Metadata map[string]any
+	// SeenAt is when the user last wrote in the conversation; zero for
+	// addresses derived from identity links alone.
+	SeenAt time.Time
+}
+
+// Presence remembers where each sender last wrote to the agent directly.
+// It is in memory only: after a restart routing falls back to the
+// preferred channel and the identity links until users write again.
+type Presence struct {
+	mu      sync.RWMutex
+	seen    map[string]Address
+	senders map[string]string
+}
+
+// NewPresence creates an empty presence tracker.
+func NewPresence() *Presence {
+	return &Presence{
+		seen:    make(map[string]Address),
+		senders: make(map[string]string),
+	}
+}
+
+// Record notes that senderID wrote in the conversation at addr.
+func (p *Presence) Record(senderID string, addr Address) {
+	senderID = strings.TrimSpace(senderID)
+	if p == nil || senderID == "" || addr.Channel == "" || addr.PeerID == "" {
+		return
+	}
+	if addr.SeenAt.IsZero() {
+		addr.SeenAt = time.Now()
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.seen[peerKey(addr.Channel, senderID)] = addr
+	p.senders[peerKey(addr.Channel, addr.PeerID)] = senderID
+}
+
+// Lookup returns the conversation senderID last wrote in on channel.
+func (p *Presence) Lookup(channel models.ChannelType, senderID string) (Address, bool) {
+	if p == nil {
+		return Address{}, false
+	}
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	addr, ok := p.seen[peerKey(channel, senderID)]
+	return addr, ok
+}
+
+// SenderOf returns the sender last seen in the conversation peerID, so a
+// conversation ID can be resolved to the linked peer it belongs to.
+func (p *Presence) SenderOf(channel models.ChannelType, peerID string) (string, bool) {
+	if p == nil {
+		return "", false
+	}
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	sender, ok := p.senders[peerKey(channel, peerID)]
+	return sender, ok
+}
+
+// MostRecent returns the most recently active address among the linked
+// peers ("channel:peer_id").
+func (p *Presence) MostRecent(linkedPeers []string) (Address, bool) {
+	var best Address
+	found := false
+	for _, linked := range linkedPeers {
+		channel, peerID, ok := splitLinkedPeer(linked)
+		if !ok {
+			continue
+		}
+		addr, ok := p.Lookup(channel, peerID)
+		if ok && (!found || addr.SeenAt.After(best.SeenAt)) {
+			best, found = addr, true
+		}
+	}
+	return best, found
+}
+
+func peerKey(channel models.ChannelType, peerID string) string {
+	return string(channel) + ":" + peerID
+}
+
+// splitLinkedPeer parses an identity link ("telegram:123456").
+func splitLinkedPeer(linked string) (models.ChannelType, string, bool) {
+	channel, peerID, ok := strings.Cut(strings.TrimSpace(linked), ":")
+	if !ok || channel == "" || peerID == "" {
+		return "", "", false
+	}
+	return models.ChannelType(channel), peerID, true
+}
@@ -0,0 +1,399 @@
+package delivery
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/haasonsaas/nexus/internal/channels"
+	"github.com/haasonsaas/nexus/internal/config"
+	"github.com/haasonsaas/nexus/internal/identity"
+	"github.com/haasonsaas/nexus/pkg/models"
+)
+
+// Message types proactive senders pass to Deliver. They are the keys of
+// delivery.types in the config.
+const (
+	KindReminder         = "reminder"
+	KindScheduledMessage = "scheduled_message"
+	KindDigest           = "digest"
+	KindAlert            = "alert"
+	KindWatch            = "watch"
+)
+
+// PreferredChannelKey is the identity metadata key naming the channel the
+// user wants proactive messages on.
+const PreferredChannelKey = "preferred_channel"
+
+// deferredSendTimeout bounds sending a message held back by quiet hours.
+const deferredSendTimeout = time.Minute
+
+// Options are the dependencies of a Router.
+type Options struct {
+	// Identities resolves the user behind the addressed conversation.
+	Identities identity.Store
+	// Channels provides the outbound adapters.
+	Channels *channels.Registry
+	// Presence tracks where users last wrote; nil disables the recent
+	// strategy.
+	Presence *Presence
+	// Timezone returns the IANA zone of the user at channel/peerID, used
+	// to evaluate quiet hours. Nil evaluates them in local time.
+	Timezone func(ctx context.Context, channel models.ChannelType, peerID string) string
+	Logger   *slog.Logger
+	// Now overrides the clock for tests.
+	Now func() time.Time
+}
+
+// Result describes what Deliver did with a message.
+type Result struct {
+	// Message is the message as sent, addressed to the channel that took
+	// it. Nil when the message was deferred or dropped.
+	Message *models.Message
+	// Deferred is set when quiet hours held the message back until
+	// DeferredUntil.
+	Deferred      bool
+	DeferredUntil time.Time
+	// Dropped is set when quiet hours discarded the message.
+	Dropped bool
+}
+
+// Router sends proactive messages to the best channel of the addressed
+// user.
+type Router struct {
+	cfg    config.DeliveryConfig
+	opts   Options
+	logger *slog.Logger
+
+	mu     sync.Mutex
+	timers map[string]*time.Timer
+	closed bool
+}
+
+// NewRouter creates a router. A router with delivery disabled sends every
+// message to the conversation it is addressed to.
+func NewRouter(cfg config.DeliveryConfig, opts Options) *Router {
+	logger := opts.Logger
+	if logger == nil {
+		logger = slog.Default()
+	}
+	if opts.Now == nil {
+		opts.Now = time.Now
+	}
+	return &Router{
+		cfg:    cfg,
+		opts:   opts,
+		logger: logger.With("component", "delivery"),
+		timers: make(map[string]*time.Timer),
+	}
+}
+
+// Deliver sends msg, addressed by its Channel and ChannelID, to the best
+// channel of the user behind that conversation. When delivery is disabled or
+// the conversation does not belong to a linked identity, the message goes
+// to the conversation it is addressed to.
+func (r *Router) Deliver(ctx context.Context, msg *models.Message, kind string) (*Result, error) {
+	if msg == nil {
+		return nil, errors.New("message is required")
+	}
+	if !r.cfg.Enabled {
+		return r.sendTo(ctx, msg, []Address{r.originAddress(msg)})
+	}
+
+	mode := r.quietHoursMode(kind)
+	if mode != "send" {
+		if until, quiet := r.quietUntil(ctx, msg); quiet {
+			if mode == "drop" {
+				r.logger.Info("dropped message during quiet hours", "kind", kind, "channel", msg.Channel)
+				return &Result{Dropped: true}, nil
+			}
+			r.deferUntil(msg, kind, until)
+			return &Result{Deferred: true, DeferredUntil: until}, nil
+		}
+	}
+	return r.deliverNow(ctx, msg, kind)
+}
+
+// Close cancels messages still held back by quiet hours.
+func (r *Router) Close() {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.closed = true
+	for id, timer := range r.timers {
+		timer.Stop()
+		delete(r.timers, id)
+	}
+}
+
+// Pending returns how many messages are waiting for quiet hours to end.
+func (r *Router) Pending() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.timers)
+}
+
+func (r *Router) deliverNow(ctx context.Context, msg *models.Message, kind string) (*Result, error) {
+	return r.sendTo(ctx, msg, r.candidates(ctx, msg, kind))
+}
+
+func (r *Router) deferUntil(msg *models.Message, kind string, until time.Time) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.closed {
+		return
+	}
+	key := msg.ID
+	if key == "" {
+		key = fmt.Sprintf("%s:%s:%d", msg.Channel, msg.ChannelID, len(r.timers))
+	}
+	if existing, ok := r.timers[key]; ok {
+		existing.Stop()
+	}
+	r.logger.Info("deferring message until quiet hours end", "kind", kind, "channel", msg.Channel, "until", until)
+	r.timers[key] = time.AfterFunc(until.Sub(r.opts.Now()), func() {
+		r.mu.Lock()
+		delete(r.timers, key)
+		r.mu.Unlock()
+		ctx, cancel := context.WithTimeout(context.Background(), deferredSendTimeout)
+		defer cancel()
+		if _, err := r.deliverNow(ctx, msg, kind); err != nil {
+			r.logger.Warn("deferred delivery failed", "kind", kind, "channel", msg.Channel, "error", err)
+		}
+	})
+}
+
+// candidates lists the addresses to try, best first.
+func (r *Router) candidates(ctx context.Context, msg *models.Message, kind string) []Address {
+	origin := r.originAddress(msg)
+	ident := r.resolveIdentity(ctx, msg)
+	if ident == nil {
+		return []Address{origin}
+	}
+
+	var list []Address
+	seen := make(map[string]bool)
+	add := func(addr Address, ok bool) {
+		if !ok {
+			return
+		}
+		key := peerKey(addr.Channel, addr.PeerID)
+		if seen[key] {
+			return
+		}
+		seen[key] = true
+		list = append(list, addr)
+	}
+
+	override := r.cfg.Types[kind]
+	for _, channel := range override.Channels {
+		add(r.addressOn(ident, models.ChannelType(strings.TrimSpace(channel))))
+	}
+	switch r.strategy(kind) {
+	case "preferred":
+		add(r.addressOn(ident, models.ChannelType(strings.TrimSpace(ident.Metadata[PreferredChannelKey]))))
+		add(r.opts.Presence.MostRecent(ident.LinkedPeers))
+	case "recent":
+		add(r.opts.Presence.MostRecent(ident.LinkedPeers))
+	}
+	add(origin, true)
+	if r.cfg.Fallback != nil && !*r.cfg.Fallback {
+		return list[:1]
+	}
+	for _, linked := range ident.LinkedPeers {
+		if channel, _, ok := splitLinkedPeer(linked); ok {
+			add(r.addressOn(ident, channel))
+		}
+	}
+	return list
+}
+
+// sendTo tries each address in turn until one channel accepts the message.
+func (r *Router) sendTo(ctx context.Context, msg *models.Message, addresses []Address) (*Result, error) {
+	var errs []error
+	for _, addr := range addresses {
+		if r.opts.Channels == nil {
+			break
+		}
+		adapter, ok := r.opts.Channels.GetOutbound(addr.Channel)
+		if !ok {
+			errs = append(errs, fmt.Errorf("channel %s not found or doesn't support outbound messages", addr.Channel))
+			continue
+		}
+		out := addressed(msg, addr)
+		if err := adapter.Send(ctx, out); err != nil {
+			r.logger.Debug("delivery attempt failed", "channel", addr.Channel, "error", err)
+			errs = append(errs, fmt.Errorf("%s: %w", addr.Channel, err))
+			continue
+		}
+		return &Result{Message: out}, nil
+	}
+	if len(errs) == 0 {
+		return nil, errors.New("no channel to deliver to")
+	}
+	return nil, errors.Join(errs...)
+}
+
+// resolveIdentity finds the identity linked to the addressed conversation,
+// either directly or through the sender last seen in it.
+func (r *Router) resolveIdentity(ctx context.Context, msg *models.Message) *identity.Identity {
+	if r.opts.Identities == nil || msg.ChannelID == "" {
+		return nil
+	}
+	if ident, err := r.opts.Identities.ResolveByPeer(ctx, string(msg.Channel), msg.ChannelID); err == nil && ident != nil {
+		return ident
+	}
+	sender, ok := r.opts.Presence.SenderOf(msg.Channel, msg.ChannelID)
+	if !ok || sender == msg.ChannelID {
+		return nil
+	}
+	if ident, err := r.opts.Identities.ResolveByPeer(ctx, string(msg.Channel), sender); err == nil && ident != nil {
+		return ident
+	}
+	return nil
+}
+
+// addressOn returns the conversation with ident on channel: where the user
+// last wrote, or the linked peer ID itself.
+func (r *Router) addressOn(ident *identity.Identity, channel models.ChannelType) (Address, bool) {
+	if channel == "" {
+		return Address{}, false
+	}
+	for _, linked := range ident.LinkedPeers {
+		linkedChannel, peerID, ok := splitLinkedPeer(linked)
+		if !ok || linkedChannel != channel {
+			continue
+		}
+		if addr, ok := r.opts.Presence.Lookup(channel, peerID); ok {
+			return addr, true
+		}
+		return Address{Channel: channel, PeerID: peerID, Metadata: RoutingMetadata(channel, peerID)}, true
+	}
+	return Address{}, false
+}
+
+// originAddress is the conversation msg is addressed to, keeping any
+// routing metadata the caller set.
+func (r *Router) originAddress(msg *models.Message) Address {
+	metadata := RoutingMetadata(msg.Channel, msg.ChannelID)
+	for key := range metadata {
+		if value, ok := msg.Metadata[key]; ok {
+			metadata[key] = value
+		}
+	}
+	if addr, ok := r.opts.Presence.Lookup(msg.Channel, msg.ChannelID); ok && addr.PeerID == msg.ChannelID {
+		for key, value := range addr.Metadata {
+			if _, set := msg.Metadata[key]; !set {
+				metadata[key] = value
+			}
+		}
+	}
+	return Address{Channel: msg.Channel, PeerID: msg.ChannelID, Metadata: metadata}
+}
+
+func (r *Router) strategy(kind string) string {
+	if strategy := strings.ToLower(strings.TrimSpace(r.cfg.Types[kind].Strategy)); strategy != "" {
+		return strategy
+	}
+	if strategy := strings.ToLower(strings.TrimSpace(r.cfg.Strategy)); strategy != "" {
+		return strategy
+	}
+	return "preferred"
+}
+
+func (r *Router) quietHoursMode(kind string) string {
+	if mode := strings.ToLower(strings.TrimSpace(r.cfg.Types[kind].DuringQuietHours)); mode != "" {
+		return mode
+	}
+	if mode := strings.ToLower(strings.TrimSpace(r.cfg.DuringQuietHours)); mode != "" {
+		return mode
+	}
+	return "defer"
+}
+
+// quietUntil reports whether the addressed user is in quiet hours and when
+// they end.
+func (r *Router) quietUntil(ctx context.Context, msg *models.Message) (time.Time, bool) {
+	loc := time.Local
+	if r.opts.Timezone != nil {
+		if tz := r.opts.Timezone(ctx, msg.Channel, msg.ChannelID); tz != "" {
+			if loaded, err := time.LoadLocation(tz); err == nil {
+				loc = loaded
+			}
+		}
+	}
+	return QuietUntil(r.cfg.QuietHours, r.opts.Now(), loc)
+}
+
+// QuietUntil reports whether now falls within the daily quiet window in loc
+// and, if so, when the window ends. Windows may cross midnight.
+func QuietUntil(hours config.QuietHoursConfig, now time.Time, loc *time.Location) (time.Time, bool) {
+	start, okStart := clockMinutes(hours.Start)
+	end, okEnd := clockMinutes(hours.End)
+	if !okStart || !okEnd || start == end {
+		return time.Time{}, false
+	}
+	local := now.In(loc)
+	minutes := local.Hour()*60 + local.Minute()
+	var quiet bool
+	if start < end {
+		quiet = minutes >= start && minutes < end
+	} else {
+		quiet = minutes >= start || minutes < end
+	}
+	if !quiet {
+		return time.Time{}, false
+	}
+	until := time.Date(local.Year(), local.Month(), local.Day(), end/60, end%60, 0, 0, loc)
+	if !until.After(local) {
+		until = until.AddDate(0, 0, 1)
+	}
+	return until, true
+}
+
+func clockMinutes(value string) (int, bool) {
+	parsed, err := time.Parse("15:04", strings.TrimSpace(value))
+	if err != nil {
+		return 0, false
+	}
+	return parsed.Hour()*60 + parsed.Minute(), true
+}
+
+// RoutingMetadata returns the metadata an adapter needs to post to peerID
+// when no inbound message from the conversation is known.
+func RoutingMetadata(channel models.ChannelType, peerID string) map[string]any {
+	switch channel {
+	case models.ChannelTelegram:
+		return map[string]any{"chat_id": peerID}
+	case models.ChannelSlack:
+		return map[string]any{"slack_channel": peerID}
+	case models.ChannelDiscord:
+		return map[string]any{"discord_channel_id": peerID}
+	default:
+		return map[string]any{"peer_id": peerID}
+	}
+}
+
+// addressed copies msg and points the copy at addr.
+func addressed(msg *models.Message, addr Address) *models.Message {
+	out := *msg
+	out.Channel = addr.Channel
+	out.ChannelID = addr.PeerID
+	out.Metadata = make(map[string]any, len(msg.Metadata)+len(addr.Metadata))
+	for key, value := range msg.Metadata {
+		out.Metadata[key] = value
+	}
+	for key, value := range addr.Metadata {
+		out.Metadata[key] = value
+	}
+	if addr.Channel != msg.Channel || addr.PeerID != msg.ChannelID {
+		out.Metadata["delivered_from"] = peerKey(msg.Channel, msg.ChannelID)
+	}
+	return &out
+}
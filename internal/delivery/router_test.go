@@ -0,0 +1,279 @@
+package delivery
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/haasonsaas/nexus/internal/channels"
+	"github.com/haasonsaas/nexus/internal/config"
+	"github.com/haasonsaas/nexus/internal/identity"
+	"github.com/haasonsaas/nexus/pkg/models"
+)
+
+type fakeAdapter struct {
+	channel models.ChannelType
+	err     error
+
+	mu   sync.Mutex
+	sent []*models.Message
+}
+
+func (a *fakeAdapter) Type() models.ChannelType { return a.channel }
+
+func (a *fakeAdapter) Send(ctx context.Context, msg *models.Message) error {
+	if a.err != nil {
+		return a.err
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.sent = append(a.sent, msg)
+	return nil
+}
+
+func (a *fakeAdapter) messages() []*models.Message {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return append([]*models.Message(nil), a.sent...)
+}
+
+type routerFixture struct {
+	router   *Router
+	presence *Presence
+	telegram *fakeAdapter
+	slack    *fakeAdapter
+	discord  *fakeAdapter
+}
+
+func newRouterFixture(t *testing.T, cfg config.DeliveryConfig, now time.Time) *routerFixture {
+	t.Helper()
+	ctx := context.Background()
+	identities := identity.NewMemoryStore()
+	if err := identities.Create(ctx, &identity.Identity{
+		CanonicalID: "alice",
+		LinkedPeers: []string{"telegram:100", "slack:U1", "discord:D1"},
+		Metadata:    map[string]string{},
+	}); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	fixture := &routerFixture{
+		presence: NewPresence(),
+		telegram: &fakeAdapter{channel: models.ChannelTelegram},
+		slack:    &fakeAdapter{channel: models.ChannelSlack},
+		discord:  &fakeAdapter{channel: models.ChannelDiscord},
+	}
+	registry := channels.NewRegistry()
+	registry.Register(fixture.telegram)
+	registry.Register(fixture.slack)
+	registry.Register(fixture.discord)
+	fixture.router = NewRouter(cfg, Options{
+		Identities: identities,
+		Channels:   registry,
+		Presence:   fixture.presence,
+		Timezone: func(context.Context, models.ChannelType, string) string {
+			return "UTC"
+		},
+		Logger: slog.New(slog.NewTextHandler(io.Discard, nil)),
+		Now:    func() time.Time { return now },
+	})
+	return fixture
+}
+
+func reminder() *models.Message {
+	return &models.Message{
+		ID:        "msg-1",
+		Channel:   models.ChannelTelegram,
+		ChannelID: "100",
+		Direction: models.DirectionOutbound,
+		Role:      models.RoleAssistant,
+		Content:   "Reminder: call mom",
+		Metadata:  map[string]any{"type": KindReminder},
+	}
+}
+
+func enabled() config.DeliveryConfig {
+	fallback := true
+	return config.DeliveryConfig{Enabled: true, Strategy: "preferred", Fallback: &fallback}
+}
+
+var noon = time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+
+func TestRouterDisabledSendsToOrigin(t *testing.T) {
+	f := newRouterFixture(t, config.DeliveryConfig{}, noon)
+	f.presence.Record("U1", Address{Channel: models.ChannelSlack, PeerID: "DM1", SeenAt: noon})
+
+	result, err := f.router.Deliver(context.Background(), reminder(), KindReminder)
+	if err != nil {
+		t.Fatalf("Deliver() error = %v", err)
+	}
+	if result.Message.Channel != models.ChannelTelegram || len(f.slack.messages()) != 0 {
+		t.Fatalf("expected delivery to the origin, got %s", result.Message.Channel)
+	}
+	if got := result.Message.Metadata["chat_id"]; got != "100" {
+		t.Fatalf("expected chat_id routing metadata, got %v", got)
+	}
+}
+
+func TestRouterPrefersRecentChannel(t *testing.T) {
+	f := newRouterFixture(t, enabled(), noon)
+	f.presence.Record("100", Address{Channel: models.ChannelTelegram, PeerID: "100", SeenAt: noon.Add(-2 * time.Hour)})
+	f.presence.Record("U1", Address{
+		Channel:  models.ChannelSlack,
+		PeerID:   "DM1",
+		Metadata: map[string]any{"slack_channel": "DM1"},
+		SeenAt:   noon.Add(-time.Minute),
+	})
+
+	result, err := f.router.Deliver(context.Background(), reminder(), KindReminder)
+	if err != nil {
+		t.Fatalf("Deliver() error = %v", err)
+	}
+	sent := f.slack.messages()
+	if len(sent) != 1 || result.Message.ChannelID != "DM1" {
+		t.Fatalf("expected delivery to the Slack DM, got %+v", result.Message)
+	}
+	if sent[0].Metadata["slack_channel"] != "DM1" || sent[0].Metadata["delivered_from"] != "telegram:100" {
+		t.Fatalf("unexpected metadata %v", sent[0].Metadata)
+	}
+}
+
+func TestRouterPreferredChannelAndTypeOverride(t *testing.T) {
+	cfg := enabled()
+	cfg.Types = map[string]config.DeliveryTypeConfig{KindAlert: {Channels: []string{"telegram"}}}
+	f := newRouterFixture(t, cfg, noon)
+	ident, err := f.router.opts.Identities.Get(context.Background(), "alice")
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	ident.Metadata[PreferredChannelKey] = "discord"
+	if err := f.router.opts.Identities.Update(context.Background(), ident); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	f.presence.Record("U1", Address{Channel: models.ChannelSlack, PeerID: "DM1", SeenAt: noon})
+
+	result, err := f.router.Deliver(context.Background(), reminder(), KindReminder)
+	if err != nil {
+		t.Fatalf("Deliver() error = %v", err)
+	}
+	if result.Message.Channel != models.ChannelDiscord || result.Message.Metadata["discord_channel_id"] != "D1" {
+		t.Fatalf("expected the preferred channel, got %s %v", result.Message.Channel, result.Message.Metadata)
+	}
+
+	result, err = f.router.Deliver(context.Background(), reminder(), KindAlert)
+	if err != nil {
+		t.Fatalf("Deliver() error = %v", err)
+	}
+	if result.Message.Channel != models.ChannelTelegram {
+		t.Fatalf("expected the alert override to pick telegram, got %s", result.Message.Channel)
+	}
+}
+
+func TestRouterFallsBackWhenSendFails(t *testing.T) {
+	f := newRouterFixture(t, enabled(), noon)
+	f.presence.Record("U1", Address{Channel: models.ChannelSlack, PeerID: "DM1", SeenAt: noon})
+	f.slack.err = errors.New("token revoked")
+
+	result, err := f.router.Deliver(context.Background(), reminder(), KindReminder)
+	if err != nil {
+		t.Fatalf("Deliver() error = %v", err)
+	}
+	if result.Message.Channel != models.ChannelTelegram {
+		t.Fatalf("expected fallback to the origin, got %s", result.Message.Channel)
+	}
+
+	f.telegram.err = errors.New("blocked")
+	result, err = f.router.Deliver(context.Background(), reminder(), KindReminder)
+	if err != nil {
+		t.Fatalf("Deliver() error = %v", err)
+	}
+	if result.Message.Channel != models.ChannelDiscord {
+		t.Fatalf("expected fallback to another linked channel, got %s", result.Message.Channel)
+	}
+
+	noFallback := false
+	f.router.cfg.Fallback = &noFallback
+	if _, err := f.router.Deliver(context.Background(), reminder(), KindReminder); err == nil {
+		t.Fatal("expected an error without fallback")
+	}
+}
+
+func TestRouterQuietHours(t *testing.T) {
+	cfg := enabled()
+	cfg.QuietHours = config.QuietHoursConfig{Start: "22:00", End: "07:00"}
+	cfg.DuringQuietHours = "defer"
+	cfg.Types = map[string]config.DeliveryTypeConfig{
+		KindAlert:  {DuringQuietHours: "send"},
+		KindDigest: {DuringQuietHours: "drop"},
+	}
+	night := time.Date(2026, 3, 10, 23, 30, 0, 0, time.UTC)
+	f := newRouterFixture(t, cfg, night)
+	defer f.router.Close()
+
+	result, err := f.router.Deliver(context.Background(), reminder(), KindReminder)
+	if err != nil {
+		t.Fatalf("Deliver() error = %v", err)
+	}
+	if !result.Deferred || !result.DeferredUntil.Equal(time.Date(2026, 3, 11, 7, 0, 0, 0, time.UTC)) {
+		t.Fatalf("expected deferral until 07:00, got %+v", result)
+	}
+	if f.router.Pending() != 1 || len(f.telegram.messages()) != 0 {
+		t.Fatal("expected the reminder to be held back")
+	}
+
+	if result, err := f.router.Deliver(context.Background(), reminder(), KindDigest); err != nil || !result.Dropped {
+		t.Fatalf("expected the digest to be dropped, got %+v, %v", result, err)
+	}
+	if result, err := f.router.Deliver(context.Background(), reminder(), KindAlert); err != nil || result.Message == nil {
+		t.Fatalf("expected the alert to be sent, got %+v, %v", result, err)
+	}
+
+	f.router.Close()
+	if f.router.Pending() != 0 {
+		t.Fatal("expected Close to cancel deferred messages")
+	}
+}
+
+func TestQuietUntil(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skipf("tzdata unavailable: %v", err)
+	}
+	window := config.QuietHoursConfig{Start: "22:00", End: "07:00"}
+	tests := []struct {
+		name  string
+		now   time.Time
+		quiet bool
+		until time.Time
+	}{
+		{"before midnight", time.Date(2026, 3, 10, 22, 30, 0, 0, berlin), true, time.Date(2026, 3, 11, 7, 0, 0, 0, berlin)},
+		{"after midnight", time.Date(2026, 3, 11, 3, 0, 0, 0, berlin), true, time.Date(2026, 3, 11, 7, 0, 0, 0, berlin)},
+		{"daytime", time.Date(2026, 3, 11, 12, 0, 0, 0, berlin), false, time.Time{}},
+		{"utc instant in local night", time.Date(2026, 3, 10, 21, 30, 0, 0, time.UTC), true, time.Date(2026, 3, 11, 7, 0, 0, 0, berlin)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			until, quiet := QuietUntil(window, tt.now, berlin)
+			if quiet != tt.quiet || !until.Equal(tt.until) {
+				t.Fatalf("QuietUntil() = %v, %v; want %v, %v", until, quiet, tt.until, tt.quiet)
+			}
+		})
+	}
+	if _, quiet := QuietUntil(config.QuietHoursConfig{}, time.Now(), berlin); quiet {
+		t.Fatal("expected no quiet hours without a window")
+	}
+}
+
+func TestPresenceSenderOf(t *testing.T) {
+	p := NewPresence()
+	p.Record("U1", Address{Channel: models.ChannelSlack, PeerID: "DM1"})
+	if sender, ok := p.SenderOf(models.ChannelSlack, "DM1"); !ok || sender != "U1" {
+		t.Fatalf("SenderOf() = %q, %v", sender, ok)
+	}
+	if _, ok := p.MostRecent([]string{"telegram:100", "malformed"}); ok {
+		t.Fatal("expected no presence for unseen peers")
+	}
+}
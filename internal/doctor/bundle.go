@@ -0,0 +1,142 @@
+package doctor
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path"
+	"strings"
+	"time"
+)
+
+// BundleFile is a file included in a debug bundle.
+type BundleFile struct {
+	Name string
+	Data []byte
+}
+
+// redactedValue replaces secrets in bundles.
+const redactedValue = "***"
+
+// RedactConfig returns a copy of a raw config with values under
+// secret-looking keys masked and credentials removed from URLs, so the
+// result can be attached to a bug report.
+func RedactConfig(raw map[string]any) map[string]any {
+	out := make(map[string]any, len(raw))
+	for key, value := range raw {
+		if isSecretKey(key) {
+			if value == nil || value == "" {
+				out[key] = value
+			} else {
+				out[key] = redactedValue
+			}
+			continue
+		}
+		out[key] = redactConfigValue(value)
+	}
+	return out
+}
+
+func redactConfigValue(value any) any {
+	switch typed := value.(type) {
+	case map[string]any:
+		return RedactConfig(typed)
+	case []any:
+		out := make([]any, len(typed))
+		for i, item := range typed {
+			out[i] = redactConfigValue(item)
+		}
+		return out
+	case string:
+		return redactURLCredentials(typed)
+	default:
+		return value
+	}
+}
+
+func isSecretKey(key string) bool {
+	lower := strings.ToLower(key)
+	for _, needle := range []string{"token", "secret", "api_key", "apikey", "password", "passphrase", "jwt", "signing", "private", "dsn", "cookie"} {
+		if strings.Contains(lower, needle) {
+			return true
+		}
+	}
+	return false
+}
+
+// redactURLCredentials masks the password in URLs such as database DSNs.
+func redactURLCredentials(value string) string {
+	if !strings.Contains(value, "://") || !strings.Contains(value, "@") {
+		return value
+	}
+	u, err := url.Parse(value)
+	if err != nil || u.User == nil {
+		return value
+	}
+	if _, ok := u.User.Password(); !ok {
+		return value
+	}
+	u.User = url.UserPassword(u.User.Username(), redactedValue)
+	return strings.Replace(u.String(), url.QueryEscape(redactedValue), redactedValue, 1)
+}
+
+// TailLines returns the last n lines of the file at path.
+func TailLines(path string, n int) ([]byte, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	lines := make([]string, 0, n)
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		if len(lines) == n {
+			lines = lines[1:]
+		}
+		lines = append(lines, scanner.Text())
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if len(lines) == 0 {
+		return nil, nil
+	}
+	return []byte(strings.Join(lines, "\n") + "\n"), nil
+}
+
+// WriteBundle writes files as a gzipped tarball with every entry under the
+// directory dir.
+func WriteBundle(w io.Writer, dir string, files []BundleFile) error {
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+	now := time.Now().Truncate(time.Second)
+	for _, file := range files {
+		name := path.Clean("/" + file.Name)[1:]
+		if name == "" {
+			return fmt.Errorf("bundle file has no name")
+		}
+		header := &tar.Header{
+			Name:    path.Join(dir, name),
+			Mode:    0o600,
+			Size:    int64(len(file.Data)),
+			ModTime: now,
+		}
+		if err := tw.WriteHeader(header); err != nil {
+			return err
+		}
+		if _, err := io.Copy(tw, bytes.NewReader(file.Data)); err != nil {
+			return err
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	return gz.Close()
+}
@@ -0,0 +1,82 @@
+package doctor
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRedactConfig(t *testing.T) {
+	raw := map[string]any{
+		"database": map[string]any{"url": "postgres://nexus:hunter22@db:5432/nexus?sslmode=disable"},
+		"llm": map[string]any{
+			"providers": map[string]any{
+				"anthropic": map[string]any{"api_key": "sk-ant-123", "default_model": "sonnet"},
+			},
+		},
+		"channels": []any{map[string]any{"bot_token": "123:abc", "app_token": ""}},
+	}
+	out := RedactConfig(raw)
+
+	if got := out["database"].(map[string]any)["url"]; got != "postgres://nexus:***@db:5432/nexus?sslmode=disable" {
+		t.Errorf("expected the database password to be masked, got %v", got)
+	}
+	anthropic := out["llm"].(map[string]any)["providers"].(map[string]any)["anthropic"].(map[string]any)
+	if anthropic["api_key"] != "***" || anthropic["default_model"] != "sonnet" {
+		t.Errorf("unexpected provider config %v", anthropic)
+	}
+	channel := out["channels"].([]any)[0].(map[string]any)
+	if channel["bot_token"] != "***" || channel["app_token"] != "" {
+		t.Errorf("unexpected channel config %v", channel)
+	}
+	if raw["llm"].(map[string]any)["providers"].(map[string]any)["anthropic"].(map[string]any)["api_key"] != "sk-ant-123" {
+		t.Errorf("expected the input to be left unchanged")
+	}
+}
+
+func TestTailLines(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "gateway.log")
+	if err := os.WriteFile(path, []byte("one\ntwo\nthree\nfour\n"), 0o600); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	data, err := TailLines(path, 2)
+	if err != nil || string(data) != "three\nfour\n" {
+		t.Fatalf("TailLines = %q, %v", data, err)
+	}
+}
+
+func TestWriteBundle(t *testing.T) {
+	var buf bytes.Buffer
+	err := WriteBundle(&buf, "nexus-debug", []BundleFile{
+		{Name: "version.txt", Data: []byte("dev\n")},
+		{Name: "../logs/gateway.log", Data: []byte("started\n")},
+	})
+	if err != nil {
+		t.Fatalf("WriteBundle: %v", err)
+	}
+
+	gz, err := gzip.NewReader(&buf)
+	if err != nil {
+		t.Fatalf("gzip: %v", err)
+	}
+	tr := tar.NewReader(gz)
+	var names []string
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("tar: %v", err)
+		}
+		names = append(names, header.Name)
+	}
+	if got := strings.Join(names, ","); got != "nexus-debug/version.txt,nexus-debug/logs/gateway.log" {
+		t.Fatalf("unexpected entries %s", got)
+	}
+}
@@ -0,0 +1,223 @@
+package doctor
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/haasonsaas/nexus/internal/config"
+	"github.com/haasonsaas/nexus/internal/sessions"
+)
+
+// DependencyStatus reports whether an external binary needed by the config
+// is installed.
+type DependencyStatus struct {
+	Name   string
+	Reason string
+	Path   string
+	Found  bool
+}
+
+// lookPath is swapped in tests.
+var lookPath = exec.LookPath
+
+// chromeBinaries are the executable names checked for the browser tool.
+var chromeBinaries = []string{"google-chrome", "google-chrome-stable", "chromium", "chromium-browser", "chrome"}
+
+// CheckDependencies looks up the binaries required by enabled channels and
+// tools.
+func CheckDependencies(cfg *config.Config) []DependencyStatus {
+	if cfg == nil {
+		return nil
+	}
+	var deps []DependencyStatus
+	check := func(name, reason string, candidates ...string) {
+		status := DependencyStatus{Name: name, Reason: reason}
+		for _, candidate := range candidates {
+			if path, err := lookPath(candidate); err == nil {
+				status.Path = path
+				status.Found = true
+				break
+			}
+		}
+		deps = append(deps, status)
+	}
+
+	// In daemon mode signal-cli runs elsewhere; --probe checks the daemon.
+	if cfg.Channels.Signal.Enabled && !strings.EqualFold(strings.TrimSpace(cfg.Channels.Signal.Mode), "daemon") {
+		check("signal-cli", "channels.signal.enabled", defaultString(cfg.Channels.Signal.SignalCLIPath, "signal-cli"))
+	}
+	if cfg.Tools.Browser.Enabled && strings.TrimSpace(cfg.Tools.Browser.URL) == "" {
+		check("chrome", "tools.browser.enabled without a remote url", chromeCandidates()...)
+	}
+	if cfg.Tools.Sandbox.Enabled {
+		switch backend := strings.ToLower(strings.TrimSpace(cfg.Tools.Sandbox.Backend)); backend {
+		case "", "docker":
+			check("docker", "tools.sandbox.backend docker", "docker")
+		case "firecracker":
+			check("firecracker", "tools.sandbox.backend firecracker", "firecracker")
+		}
+	}
+	if cfg.Plugins.Isolation.Enabled {
+		switch backend := strings.ToLower(strings.TrimSpace(cfg.Plugins.Isolation.Backend)); backend {
+		case "docker", "firecracker":
+			check(backend, "plugins.isolation.backend "+backend, backend)
+		}
+	}
+	if cfg.Artifacts.Processing.Enabled {
+		for _, rule := range cfg.Artifacts.Processing.Types {
+			if rule.Transcode {
+				check("ffmpeg", "artifacts.processing transcodes", defaultString(cfg.Artifacts.Processing.FFmpegPath, "ffmpeg"))
+				break
+			}
+		}
+	}
+	return deps
+}
+
+func chromeCandidates() []string {
+	candidates := append([]string{}, chromeBinaries...)
+	switch runtime.GOOS {
+	case "darwin":
+		candidates = append(candidates,
+			"/Applications/Google Chrome.app/Contents/MacOS/Google Chrome",
+			"/Applications/Chromium.app/Contents/MacOS/Chromium")
+	case "windows":
+		for _, env := range []string{"ProgramFiles", "ProgramFiles(x86)", "LocalAppData"} {
+			if dir := os.Getenv(env); dir != "" {
+				candidates = append(candidates, filepath.Join(dir, "Google", "Chrome", "Application", "chrome.exe"))
+			}
+		}
+	}
+	return candidates
+}
+
+func defaultString(value, fallback string) string {
+	if trimmed := strings.TrimSpace(value); trimmed != "" {
+		return trimmed
+	}
+	return fallback
+}
+
+// SchemaDrift compares the migrations applied to a database with the ones
+// embedded in this binary.
+type SchemaDrift struct {
+	Applied int
+	// Latest is the ID of the most recent applied migration, the schema
+	// version of the database.
+	Latest string
+	// Pending migrations ship with this binary but are not applied.
+	Pending []string
+	// Unknown migrations are applied but not known to this binary, usually
+	// because a newer release migrated the database.
+	Unknown []string
+}
+
+// Drifted reports whether the database and binary disagree.
+func (d SchemaDrift) Drifted() bool {
+	return len(d.Pending) > 0 || len(d.Unknown) > 0
+}
+
+// CheckSchemaDrift inspects schema_migrations for pending and unknown
+// migrations.
+func CheckSchemaDrift(ctx context.Context, migrator *sessions.Migrator) (SchemaDrift, error) {
+	applied, pending, err := migrator.Status(ctx)
+	if err != nil {
+		return SchemaDrift{}, err
+	}
+	drift := SchemaDrift{Applied: len(applied)}
+	if len(applied) > 0 {
+		drift.Latest = applied[len(applied)-1].ID
+	}
+	for _, migration := range pending {
+		drift.Pending = append(drift.Pending, migration.ID)
+	}
+	known := map[string]bool{}
+	for _, migration := range migrator.Migrations() {
+		known[migration.ID] = true
+	}
+	for _, entry := range applied {
+		if !known[entry.ID] {
+			drift.Unknown = append(drift.Unknown, entry.ID)
+		}
+	}
+	return drift, nil
+}
+
+// MaxClockSkew is the largest clock offset tolerated before token checks
+// (JWT expiry, signed canvas and artifact links, OAuth state) start failing.
+const MaxClockSkew = 30 * time.Second
+
+// ClockSkewStatus reports the local clock offset against a reference server.
+type ClockSkewStatus struct {
+	Source string
+	Skew   time.Duration
+	Error  string
+}
+
+// Exceeded reports whether the measured skew exceeds MaxClockSkew.
+func (s ClockSkewStatus) Exceeded() bool {
+	return s.Error == "" && (s.Skew > MaxClockSkew || s.Skew < -MaxClockSkew)
+}
+
+// ClockSkewSources returns reference URLs for the clock check when the
+// config relies on time-limited tokens, or nil when it does not.
+func ClockSkewSources(cfg *config.Config) []string {
+	if cfg == nil {
+		return nil
+	}
+	var sources []string
+	if strings.TrimSpace(cfg.Auth.OAuth.Google.ClientID) != "" {
+		sources = append(sources, "https://oauth2.googleapis.com")
+	}
+	if strings.TrimSpace(cfg.Auth.OAuth.GitHub.ClientID) != "" {
+		sources = append(sources, "https://api.github.com")
+	}
+	if len(sources) == 0 && (strings.TrimSpace(cfg.Auth.JWTSecret) != "" ||
+		strings.TrimSpace(cfg.Canvas.Tokens.Secret) != "" || cfg.Artifacts.Links.Enabled) {
+		sources = append(sources, "https://www.google.com")
+	}
+	return sources
+}
+
+// CheckClockSkew compares the local clock with the Date header returned by
+// source. The local reference is the midpoint of the request round trip;
+// Date has one-second resolution, so small offsets are noise.
+func CheckClockSkew(ctx context.Context, client *http.Client, source string) ClockSkewStatus {
+	status := ClockSkewStatus{Source: source}
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, source, nil)
+	if err != nil {
+		status.Error = err.Error()
+		return status
+	}
+	start := time.Now()
+	resp, err := client.Do(req)
+	if err != nil {
+		status.Error = err.Error()
+		return status
+	}
+	resp.Body.Close()
+	rtt := time.Since(start)
+
+	header := resp.Header.Get("Date")
+	if header == "" {
+		status.Error = "response has no Date header"
+		return status
+	}
+	remote, err := http.ParseTime(header)
+	if err != nil {
+		status.Error = fmt.Sprintf("parse Date header: %v", err)
+		return status
+	}
+	status.Skew = start.Add(rtt / 2).Sub(remote).Truncate(time.Second)
+	return status
+}
@@ -0,0 +1,117 @@
+package doctor
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/haasonsaas/nexus/internal/config"
+	"github.com/haasonsaas/nexus/internal/sessions"
+	"github.com/haasonsaas/nexus/internal/storage/sqlite"
+)
+
+func TestCheckDependencies(t *testing.T) {
+	original := lookPath
+	defer func() { lookPath = original }()
+	lookPath = func(name string) (string, error) {
+		if name == "/opt/signal-cli/bin/signal-cli" || name == "chromium" {
+			return name, nil
+		}
+		return "", errors.New("not found")
+	}
+
+	cfg := &config.Config{}
+	cfg.Channels.Signal.Enabled = true
+	cfg.Channels.Signal.SignalCLIPath = "/opt/signal-cli/bin/signal-cli"
+	cfg.Tools.Browser.Enabled = true
+	cfg.Tools.Sandbox.Enabled = true
+	cfg.Tools.Sandbox.Backend = "firecracker"
+
+	deps := CheckDependencies(cfg)
+	if len(deps) != 3 {
+		t.Fatalf("expected 3 dependencies, got %+v", deps)
+	}
+	found := map[string]bool{}
+	for _, dep := range deps {
+		found[dep.Name] = dep.Found
+	}
+	if !found["signal-cli"] || !found["chrome"] || found["firecracker"] {
+		t.Fatalf("unexpected dependency results %+v", deps)
+	}
+
+	cfg.Tools.Browser.URL = "ws://browser:3000"
+	cfg.Channels.Signal.Mode = "daemon"
+	for _, dep := range CheckDependencies(cfg) {
+		if dep.Name == "chrome" {
+			t.Fatalf("remote browser should not require a local chrome")
+		}
+		if dep.Name == "signal-cli" {
+			t.Fatalf("signal daemon mode should not require a local signal-cli")
+		}
+	}
+}
+
+func TestCheckSchemaDrift(t *testing.T) {
+	ctx := context.Background()
+	db, err := sqlite.Open("sqlite://" + filepath.Join(t.TempDir(), "nexus.db"))
+	if err != nil {
+		t.Fatalf("open sqlite: %v", err)
+	}
+	defer db.Close()
+	migrator, err := sessions.NewMigratorForDialect(db, sessions.DialectSQLite)
+	if err != nil {
+		t.Fatalf("NewMigratorForDialect: %v", err)
+	}
+
+	drift, err := CheckSchemaDrift(ctx, migrator)
+	if err != nil {
+		t.Fatalf("CheckSchemaDrift: %v", err)
+	}
+	if len(drift.Pending) != len(migrator.Migrations()) || !drift.Drifted() {
+		t.Fatalf("expected every migration pending on an empty database, got %+v", drift)
+	}
+
+	if _, err := migrator.Up(ctx, 0); err != nil {
+		t.Fatalf("Up: %v", err)
+	}
+	if _, err := db.ExecContext(ctx, `INSERT INTO schema_migrations (id) VALUES ('999_from_the_future')`); err != nil {
+		t.Fatalf("insert migration: %v", err)
+	}
+	drift, err = CheckSchemaDrift(ctx, migrator)
+	if err != nil {
+		t.Fatalf("CheckSchemaDrift: %v", err)
+	}
+	if len(drift.Pending) != 0 || len(drift.Unknown) != 1 || drift.Unknown[0] != "999_from_the_future" || drift.Latest != "999_from_the_future" {
+		t.Fatalf("expected one unknown migration, got %+v", drift)
+	}
+}
+
+func TestCheckClockSkew(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Date", time.Now().Add(-2*time.Minute).UTC().Format(http.TimeFormat))
+	}))
+	defer server.Close()
+
+	status := CheckClockSkew(context.Background(), server.Client(), server.URL)
+	if status.Error != "" {
+		t.Fatalf("CheckClockSkew: %s", status.Error)
+	}
+	if status.Skew < 119*time.Second || status.Skew > 121*time.Second || !status.Exceeded() {
+		t.Fatalf("expected ~2m of skew, got %s", status.Skew)
+	}
+}
+
+func TestClockSkewSources(t *testing.T) {
+	if sources := ClockSkewSources(&config.Config{}); len(sources) != 0 {
+		t.Fatalf("expected no sources without token auth, got %v", sources)
+	}
+	cfg := &config.Config{}
+	cfg.Auth.OAuth.GitHub.ClientID = "client"
+	if sources := ClockSkewSources(cfg); len(sources) != 1 || sources[0] != "https://api.github.com" {
+		t.Fatalf("unexpected sources %v", sources)
+	}
+}
@@ -0,0 +1,111 @@
+package doctor
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/haasonsaas/nexus/internal/config"
+	"github.com/haasonsaas/nexus/internal/storage/sqlite"
+)
+
+// PermissionIssue reports a sensitive path that other users can access.
+type PermissionIssue struct {
+	Label string
+	Path  string
+	Mode  os.FileMode
+	Want  os.FileMode
+	Fixed bool
+	Error string
+}
+
+// AuditPermissions checks the config file, workspace, and secret material
+// referenced by the config for group or world access. Files should be 0600
+// and directories 0700.
+func AuditPermissions(cfg *config.Config, configPath string) []PermissionIssue {
+	if runtime.GOOS == "windows" {
+		return nil
+	}
+	var issues []PermissionIssue
+	seen := map[string]bool{}
+	check := func(label, path string) {
+		path = strings.TrimSpace(path)
+		if path == "" {
+			return
+		}
+		path = filepath.Clean(path)
+		if seen[path] {
+			return
+		}
+		seen[path] = true
+		info, err := os.Stat(path)
+		if err != nil {
+			return
+		}
+		want := os.FileMode(0o600)
+		if info.IsDir() {
+			want = 0o700
+		}
+		if perm := info.Mode().Perm(); perm&0o077 != 0 {
+			issues = append(issues, PermissionIssue{Label: label, Path: path, Mode: perm, Want: want})
+		}
+	}
+
+	check("config file", configPath)
+	if cfg == nil {
+		return issues
+	}
+	if cfg.Workspace.Enabled && !sharedDir(cfg.Workspace.Path) {
+		check("workspace directory", cfg.Workspace.Path)
+	}
+	if sqlite.IsURL(cfg.Database.URL) {
+		if path, err := sqlite.Path(cfg.Database.URL); err == nil && path != ":memory:" {
+			check("sqlite database", path)
+		}
+	}
+	for _, key := range cfg.Encryption.Keys {
+		check(fmt.Sprintf("encryption key %q identity file", key.ID), key.AgeIdentityFile)
+	}
+	check("erasure signing key", cfg.Privacy.Erasure.SigningKeyPath)
+	if cfg.Channels.Signal.Enabled {
+		check("signal-cli config directory", cfg.Channels.Signal.ConfigDir)
+	}
+	return issues
+}
+
+// FixPermissions chmods each path to its recommended mode and records the
+// outcome on the returned issues.
+func FixPermissions(issues []PermissionIssue) []PermissionIssue {
+	fixed := make([]PermissionIssue, len(issues))
+	for i, issue := range issues {
+		if err := os.Chmod(issue.Path, issue.Want); err != nil {
+			issue.Error = err.Error()
+		} else {
+			issue.Fixed = true
+		}
+		fixed[i] = issue
+	}
+	return fixed
+}
+
+// sharedDir reports whether path is the working directory, the home
+// directory, or the filesystem root. The default workspace "." usually
+// points at one of these, and tightening them is not doctor's call.
+func sharedDir(path string) bool {
+	abs, err := filepath.Abs(strings.TrimSpace(path))
+	if err != nil {
+		return true
+	}
+	if abs == filepath.Dir(abs) {
+		return true
+	}
+	if cwd, err := os.Getwd(); err == nil && abs == cwd {
+		return true
+	}
+	if home, err := os.UserHomeDir(); err == nil && abs == filepath.Clean(home) {
+		return true
+	}
+	return false
+}
@@ -0,0 +1,60 @@
+package doctor
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/haasonsaas/nexus/internal/config"
+)
+
+func TestAuditAndFixPermissions(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("permission bits are not reliable on windows")
+	}
+
+	dir := t.TempDir()
+	cfgPath := filepath.Join(dir, "nexus.yaml")
+	keyPath := filepath.Join(dir, "age.key")
+	workspace := filepath.Join(dir, "workspace")
+	for _, path := range []string{cfgPath, keyPath} {
+		if err := os.WriteFile(path, []byte("x"), 0o600); err != nil {
+			t.Fatalf("write %s: %v", path, err)
+		}
+	}
+	if err := os.Mkdir(workspace, 0o700); err != nil {
+		t.Fatalf("create workspace: %v", err)
+	}
+	if err := os.Chmod(cfgPath, 0o644); err != nil {
+		t.Fatalf("chmod config: %v", err)
+	}
+	if err := os.Chmod(workspace, 0o755); err != nil {
+		t.Fatalf("chmod workspace: %v", err)
+	}
+
+	cfg := &config.Config{
+		Workspace:  config.WorkspaceConfig{Enabled: true, Path: workspace},
+		Encryption: config.EncryptionConfig{Keys: []config.EncryptionKeyConfig{{ID: "k1", AgeIdentityFile: keyPath}}},
+	}
+	issues := AuditPermissions(cfg, cfgPath)
+	if len(issues) != 2 {
+		t.Fatalf("expected config and workspace issues, got %+v", issues)
+	}
+	if issues[0].Path != cfgPath || issues[0].Want != 0o600 || issues[1].Path != workspace || issues[1].Want != 0o700 {
+		t.Fatalf("unexpected issues %+v", issues)
+	}
+
+	if shared := AuditPermissions(&config.Config{Workspace: config.WorkspaceConfig{Enabled: true, Path: "."}}, ""); len(shared) != 0 {
+		t.Fatalf("expected the working directory to be skipped, got %+v", shared)
+	}
+
+	for _, issue := range FixPermissions(issues) {
+		if !issue.Fixed || issue.Error != "" {
+			t.Fatalf("expected %s to be fixed: %+v", issue.Path, issue)
+		}
+	}
+	if remaining := AuditPermissions(cfg, cfgPath); len(remaining) != 0 {
+		t.Fatalf("expected no issues after repair, got %+v", remaining)
+	}
+}
@@ -4,9 +4,11 @@ import (
 	"fmt"
 	"net"
 	"os"
+	"os/exec"
 	"path/filepath"
 	"runtime"
 	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/haasonsaas/nexus/internal/config"
@@ -18,7 +20,10 @@ type ServiceAudit struct {
 	SystemdSystem []string
 	LaunchdUser   []string
 	LaunchdSystem []string
-	Ports         []PortStatus
+	// WindowsServices lists registered Windows services whose name
+	// contains "nexus".
+	WindowsServices []string
+	Ports           []PortStatus
 }
 
 // PortStatus reports port availability.
@@ -26,6 +31,14 @@ type PortStatus struct {
 	Port  int
 	InUse bool
 	Error string
+	// PID is the listening process when it could be determined.
+	PID int
+}
+
+// windowsCommandOutput runs a Windows system tool. Tests replace it.
+var windowsCommandOutput = func(name string, args ...string) (string, error) {
+	output, err := exec.Command(name, args...).Output()
+	return string(output), err
 }
 
 // AuditServices inspects common service file locations and port availability.
@@ -37,7 +50,9 @@ func AuditServices(cfg *config.Config) ServiceAudit {
 		home = "."
 	}
 
-	if runtime.GOOS != "windows" {
+	if runtime.GOOS == "windows" {
+		audit.WindowsServices = findWindowsServices("nexus")
+	} else {
 		xdg := os.Getenv("XDG_CONFIG_HOME")
 		if xdg == "" {
 			xdg = filepath.Join(home, ".config")
@@ -59,6 +74,9 @@ func AuditServices(cfg *config.Config) ServiceAudit {
 		if cfg.Server.HTTPPort > 0 {
 			audit.Ports = append(audit.Ports, CheckPort(host, cfg.Server.HTTPPort))
 		}
+		if runtime.GOOS == "windows" {
+			annotateWindowsPortOwners(audit.Ports)
+		}
 	}
 
 	return audit
@@ -111,3 +129,67 @@ func findServiceFiles(dir string, suffix string, contains string) []string {
 	}
 	return matches
 }
+
+// findWindowsServices lists registered services whose name contains the
+// given text, using `sc.exe query`.
+func findWindowsServices(contains string) []string {
+	output, err := windowsCommandOutput("sc.exe", "query", "type=", "service", "state=", "all")
+	if err != nil {
+		return nil
+	}
+	return parseSCServiceNames(output, contains)
+}
+
+func parseSCServiceNames(output, contains string) []string {
+	var names []string
+	for _, line := range strings.Split(output, "\n") {
+		key, value, ok := strings.Cut(line, ":")
+		if !ok || strings.TrimSpace(key) != "SERVICE_NAME" {
+			continue
+		}
+		name := strings.TrimSpace(value)
+		if strings.Contains(strings.ToLower(name), strings.ToLower(contains)) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// annotateWindowsPortOwners fills in the listening PID for ports in use,
+// using `netstat -ano`.
+func annotateWindowsPortOwners(ports []PortStatus) {
+	var output string
+	for i := range ports {
+		if !ports[i].InUse {
+			continue
+		}
+		if output == "" {
+			out, err := windowsCommandOutput("netstat", "-ano", "-p", "tcp")
+			if err != nil {
+				return
+			}
+			output = out
+		}
+		ports[i].PID = parseNetstatListener(output, ports[i].Port)
+	}
+}
+
+// parseNetstatListener returns the PID listening on port in `netstat -ano`
+// output, or 0 if none is found.
+func parseNetstatListener(output string, port int) int {
+	suffix := ":" + strconv.Itoa(port)
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) != 5 || !strings.EqualFold(fields[0], "TCP") || fields[3] != "LISTENING" {
+			continue
+		}
+		if !strings.HasSuffix(fields[1], suffix) {
+			continue
+		}
+		if pid, err := strconv.Atoi(fields[4]); err == nil {
+			return pid
+		}
+	}
+	return 0
+}
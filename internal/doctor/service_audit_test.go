@@ -35,3 +35,59 @@ func TestCheckPortDetectsInUse(t *testing.T) {
 		t.Fatalf("expected port to be in use")
 	}
 }
+
+func TestParseSCServiceNames(t *testing.T) {
+	output := `
+SERVICE_NAME: Dnscache
+DISPLAY_NAME: DNS Client
+        STATE              : 4  RUNNING
+
+SERVICE_NAME: nexus
+DISPLAY_NAME: Nexus Gateway
+        STATE              : 1  STOPPED
+
+SERVICE_NAME: nexus-staging
+DISPLAY_NAME: Nexus Staging
+`
+	names := parseSCServiceNames(output, "nexus")
+	if len(names) != 2 || names[0] != "nexus" || names[1] != "nexus-staging" {
+		t.Fatalf("unexpected services %v", names)
+	}
+}
+
+func TestParseNetstatListener(t *testing.T) {
+	output := `
+Active Connections
+
+  Proto  Local Address          Foreign Address        State           PID
+  TCP    0.0.0.0:135            0.0.0.0:0              LISTENING       1024
+  TCP    127.0.0.1:8080         127.0.0.1:51000        ESTABLISHED     4321
+  TCP    0.0.0.0:8080           0.0.0.0:0              LISTENING       5150
+  TCP    0.0.0.0:18080          0.0.0.0:0              LISTENING       9999
+`
+	if pid := parseNetstatListener(output, 8080); pid != 5150 {
+		t.Fatalf("parseNetstatListener() = %d, want 5150", pid)
+	}
+	if pid := parseNetstatListener(output, 50051); pid != 0 {
+		t.Fatalf("parseNetstatListener() = %d, want 0", pid)
+	}
+}
+
+func TestAnnotateWindowsPortOwners(t *testing.T) {
+	orig := windowsCommandOutput
+	t.Cleanup(func() { windowsCommandOutput = orig })
+	calls := 0
+	windowsCommandOutput = func(name string, args ...string) (string, error) {
+		calls++
+		return "  TCP    0.0.0.0:8080           0.0.0.0:0              LISTENING       5150\n", nil
+	}
+
+	ports := []PortStatus{{Port: 50051}, {Port: 8080, InUse: true}}
+	annotateWindowsPortOwners(ports)
+	if ports[0].PID != 0 || ports[1].PID != 5150 {
+		t.Fatalf("unexpected ports %+v", ports)
+	}
+	if calls != 1 {
+		t.Fatalf("expected netstat to run once, got %d", calls)
+	}
+}
@@ -0,0 +1,405 @@
+// Package drafts holds calls to tools that send external communications,
+// such as email or ticket comments, until the user approves them. The agent
+// composes the message as usual; the call is kept as a draft, previewed to
+// the user, and only executed after approval.
+package drafts
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/haasonsaas/nexus/internal/agent"
+	"github.com/haasonsaas/nexus/pkg/models"
+)
+
+// Draft statuses.
+const (
+	StatusPending   = "pending"
+	StatusSent      = "sent"
+	StatusCancelled = "cancelled"
+	StatusExpired   = "expired"
+)
+
+// DefaultTTL is how long a draft waits for approval when none is configured.
+const DefaultTTL = time.Hour
+
+// decidedRetention is how long drafts are kept after they leave pending.
+const decidedRetention = 24 * time.Hour
+
+var (
+	// ErrNotFound is returned for an unknown draft ID.
+	ErrNotFound = errors.New("draft not found")
+
+	// ErrDecided is returned when acting on a draft that is not pending.
+	ErrDecided = errors.New("draft is no longer pending")
+
+	// ErrNotEditable is returned when a draft's tool cannot apply a text
+	// edit and the edit is not a full JSON input.
+	ErrNotEditable = errors.New("draft can only be replaced with a JSON object of the tool input")
+)
+
+// Previewer is implemented by draft tools that can show what a call would
+// send in a readable form. Other tools are previewed as indented JSON.
+type Previewer interface {
+	PreviewDraft(params json.RawMessage) (string, error)
+}
+
+// Reviser is implemented by draft tools whose main text, such as an email
+// body, can be replaced by the user before sending. Other tools only accept
+// a replacement input as JSON.
+type Reviser interface {
+	ReviseDraft(params json.RawMessage, text string) (json.RawMessage, error)
+}
+
+// Draft is a held tool call.
+type Draft struct {
+	ID        string             `json:"id"`
+	Tool      string             `json:"tool"`
+	Input     json.RawMessage    `json:"input"`
+	Preview   string             `json:"preview"`
+	AgentID   string             `json:"agent_id,omitempty"`
+	SessionID string             `json:"session_id,omitempty"`
+	Channel   models.ChannelType `json:"channel,omitempty"`
+	ChannelID string             `json:"channel_id,omitempty"`
+	Status    string             `json:"status"`
+	CreatedAt time.Time          `json:"created_at"`
+	ExpiresAt time.Time          `json:"expires_at"`
+	DecidedAt *time.Time         `json:"decided_at,omitempty"`
+	DecidedBy string             `json:"decided_by,omitempty"`
+}
+
+// Queue holds drafts in memory until they are approved, cancelled, or
+// expire. Drafts do not survive a restart; the agent is told the call was
+// not sent, so nothing goes out unapproved.
+type Queue struct {
+	ttl time.Duration
+
+	mu       sync.Mutex
+	tools    map[string]agent.Tool
+	drafts   map[string]*Draft
+	timers   map[string]*time.Timer
+	onHold   func(ctx context.Context, draft *Draft)
+	onExpire func(draft *Draft)
+	now      func() time.Time
+}
+
+// NewQueue creates a queue whose drafts expire after ttl (DefaultTTL when
+// ttl is not positive).
+func NewQueue(ttl time.Duration) *Queue {
+	if ttl <= 0 {
+		ttl = DefaultTTL
+	}
+	return &Queue{
+		ttl:    ttl,
+		tools:  make(map[string]agent.Tool),
+		drafts: make(map[string]*Draft),
+		timers: make(map[string]*time.Timer),
+		now:    time.Now,
+	}
+}
+
+// TTL returns how long drafts wait for approval.
+func (q *Queue) TTL() time.Duration {
+	return q.ttl
+}
+
+// SetOnHold registers fn to be called after a draft is held, to preview it
+// to the user.
+func (q *Queue) SetOnHold(fn func(ctx context.Context, draft *Draft)) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.onHold = fn
+}
+
+// SetOnExpire registers fn to be called when a draft expires unapproved.
+func (q *Queue) SetOnExpire(fn func(draft *Draft)) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.onExpire = fn
+}
+
+// Matches reports whether tool is listed by patterns, which are tool names
+// or globs such as "servicenow_*".
+func Matches(patterns []string, tool string) bool {
+	for _, pattern := range patterns {
+		pattern = strings.TrimSpace(pattern)
+		if pattern == tool {
+			return true
+		}
+		if ok, err := path.Match(pattern, tool); err == nil && ok {
+			return true
+		}
+	}
+	return false
+}
+
+// Wrap returns a tool with the same name and schema as tool whose calls are
+// held in q instead of executed.
+func (q *Queue) Wrap(tool agent.Tool) agent.Tool {
+	q.mu.Lock()
+	q.tools[tool.Name()] = tool
+	q.mu.Unlock()
+	return &heldTool{Tool: tool, queue: q}
+}
+
+// Hold stores a call to tool as a pending draft. The conversation in ctx
+// is recorded so approval can come from it.
+func (q *Queue) Hold(ctx context.Context, tool string, input json.RawMessage) (*Draft, error) {
+	q.mu.Lock()
+	target, ok := q.tools[tool]
+	q.mu.Unlock()
+	if !ok {
+		return nil, fmt.Errorf("tool %q is not drafted", tool)
+	}
+	preview, err := previewDraft(target, input)
+	if err != nil {
+		return nil, err
+	}
+	now := q.now()
+	draft := &Draft{
+		Tool:      tool,
+		Input:     append(json.RawMessage(nil), input...),
+		Preview:   preview,
+		Status:    StatusPending,
+		CreatedAt: now,
+		ExpiresAt: now.Add(q.ttl),
+	}
+	if session := agent.SessionFromContext(ctx); session != nil {
+		draft.AgentID = session.AgentID
+		draft.SessionID = session.ID
+		draft.Channel = session.Channel
+		draft.ChannelID = session.ChannelID
+	}
+
+	q.mu.Lock()
+	q.prune(now)
+	for {
+		draft.ID = strings.ReplaceAll(uuid.NewString(), "-", "")[:8]
+		if _, exists := q.drafts[draft.ID]; !exists {
+			break
+		}
+	}
+	q.drafts[draft.ID] = draft
+	id := draft.ID
+	q.timers[id] = time.AfterFunc(q.ttl, func() { q.expire(id) })
+	onHold := q.onHold
+	held := *draft
+	q.mu.Unlock()
+
+	if onHold != nil {
+		onHold(ctx, &held)
+	}
+	return &held, nil
+}
+
+// Get returns a copy of the draft with id.
+func (q *Queue) Get(id string) (*Draft, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	draft, ok := q.drafts[strings.TrimSpace(id)]
+	if !ok {
+		return nil, fmt.Errorf("%s: %w", id, ErrNotFound)
+	}
+	copied := *draft
+	return &copied, nil
+}
+
+// List returns the pending drafts of the conversation on channel/channelID,
+// or of every conversation when channel is empty, oldest first.
+func (q *Queue) List(channel models.ChannelType, channelID string) []*Draft {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	var pending []*Draft
+	for _, draft := range q.drafts {
+		if draft.Status != StatusPending {
+			continue
+		}
+		if channel != "" && (draft.Channel != channel || draft.ChannelID != channelID) {
+			continue
+		}
+		copied := *draft
+		pending = append(pending, &copied)
+	}
+	sort.Slice(pending, func(i, j int) bool { return pending[i].CreatedAt.Before(pending[j].CreatedAt) })
+	return pending
+}
+
+// Approve executes a pending draft with its tool and marks it sent. When the
+// tool fails the draft stays pending, so it can be edited and approved
+// again.
+func (q *Queue) Approve(ctx context.Context, id, decidedBy string) (*Draft, *agent.ToolResult, error) {
+	q.mu.Lock()
+	draft, err := q.pending(id)
+	if err != nil {
+		q.mu.Unlock()
+		return nil, nil, err
+	}
+	tool := q.tools[draft.Tool]
+	input := draft.Input
+	// Mark the draft sent before executing, so a second approval cannot
+	// send it twice.
+	q.decide(draft, StatusSent, decidedBy)
+	q.mu.Unlock()
+
+	result, err := tool.Execute(ctx, input)
+	if err == nil && result != nil && result.IsError {
+		err = errors.New(result.Content)
+	}
+	if err != nil {
+		q.mu.Lock()
+		draft.Status = StatusPending
+		draft.DecidedAt = nil
+		draft.DecidedBy = ""
+		id := draft.ID
+		q.timers[id] = time.AfterFunc(max(draft.ExpiresAt.Sub(q.now()), 0), func() { q.expire(id) })
+		q.mu.Unlock()
+		return nil, nil, fmt.Errorf("send draft %s: %w", draft.ID, err)
+	}
+	q.mu.Lock()
+	copied := *draft
+	q.mu.Unlock()
+	return &copied, result, nil
+}
+
+// Cancel discards a pending draft.
+func (q *Queue) Cancel(id, decidedBy string) (*Draft, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	draft, err := q.pending(id)
+	if err != nil {
+		return nil, err
+	}
+	q.decide(draft, StatusCancelled, decidedBy)
+	copied := *draft
+	return &copied, nil
+}
+
+// Edit replaces the content of a pending draft. Tools implementing Reviser
+// take text as their new main text; otherwise text must be a JSON object
+// replacing the whole input. The expiry is unchanged.
+func (q *Queue) Edit(id, text string) (*Draft, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	draft, err := q.pending(id)
+	if err != nil {
+		return nil, err
+	}
+	tool := q.tools[draft.Tool]
+	var input json.RawMessage
+	if reviser, ok := tool.(Reviser); ok {
+		input, err = reviser.ReviseDraft(draft.Input, text)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		trimmed := bytes.TrimSpace([]byte(text))
+		if len(trimmed) == 0 || trimmed[0] != '{' || !json.Valid(trimmed) {
+			return nil, ErrNotEditable
+		}
+		input = json.RawMessage(trimmed)
+	}
+	preview, err := previewDraft(tool, input)
+	if err != nil {
+		return nil, err
+	}
+	draft.Input = input
+	draft.Preview = preview
+	copied := *draft
+	return &copied, nil
+}
+
+// expire marks a draft expired if it is still pending and reports it.
+func (q *Queue) expire(id string) {
+	q.mu.Lock()
+	draft, ok := q.drafts[id]
+	if !ok || draft.Status != StatusPending {
+		q.mu.Unlock()
+		return
+	}
+	q.decide(draft, StatusExpired, "")
+	onExpire := q.onExpire
+	copied := *draft
+	q.mu.Unlock()
+	if onExpire != nil {
+		onExpire(&copied)
+	}
+}
+
+// pending returns the draft with id when it is pending. Callers hold q.mu.
+func (q *Queue) pending(id string) (*Draft, error) {
+	draft, ok := q.drafts[strings.TrimSpace(id)]
+	if !ok {
+		return nil, fmt.Errorf("%s: %w", id, ErrNotFound)
+	}
+	if draft.Status != StatusPending {
+		return nil, fmt.Errorf("%s: %w (%s)", id, ErrDecided, draft.Status)
+	}
+	return draft, nil
+}
+
+// decide records a final status. Callers hold q.mu.
+func (q *Queue) decide(draft *Draft, status, decidedBy string) {
+	now := q.now()
+	draft.Status = status
+	draft.DecidedAt = &now
+	draft.DecidedBy = decidedBy
+	if timer, ok := q.timers[draft.ID]; ok {
+		timer.Stop()
+		delete(q.timers, draft.ID)
+	}
+}
+
+// prune forgets drafts decided more than decidedRetention ago. Callers hold
+// q.mu.
+func (q *Queue) prune(now time.Time) {
+	for id, draft := range q.drafts {
+		if draft.DecidedAt != nil && now.Sub(*draft.DecidedAt) > decidedRetention {
+			delete(q.drafts, id)
+		}
+	}
+}
+
+func previewDraft(tool agent.Tool, input json.RawMessage) (string, error) {
+	if previewer, ok := tool.(Previewer); ok {
+		return previewer.PreviewDraft(input)
+	}
+	var out bytes.Buffer
+	if err := json.Indent(&out, input, "", "  "); err != nil {
+		return "", fmt.Errorf("parse input: %w", err)
+	}
+	return out.String(), nil
+}
+
+// heldTool holds calls to the wrapped tool as drafts.
+type heldTool struct {
+	agent.Tool
+	queue *Queue
+}
+
+// Description tells the model calls wait for approval.
+func (t *heldTool) Description() string {
+	return t.Tool.Description() + " Calls are shown to the user as a draft and only sent once they approve it."
+}
+
+// Execute holds the call instead of running it.
+func (t *heldTool) Execute(ctx context.Context, params json.RawMessage) (*agent.ToolResult, error) {
+	if agent.SessionFromContext(ctx) == nil {
+		return &agent.ToolResult{Content: "not sent: drafts need a conversation where the user can approve them", IsError: true}, nil
+	}
+	draft, err := t.queue.Hold(ctx, t.Name(), params)
+	if err != nil {
+		return &agent.ToolResult{Content: "draft failed: " + err.Error(), IsError: true}, nil
+	}
+	return &agent.ToolResult{Content: fmt.Sprintf(
+		"Draft %s was shown to the user and has NOT been sent. It is sent only if they approve it within %s; do not call %s again for it.",
+		draft.ID, t.queue.ttl, t.Name())}, nil
+}
@@ -0,0 +1,194 @@
+package drafts
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/haasonsaas/nexus/internal/agent"
+	"github.com/haasonsaas/nexus/pkg/models"
+)
+
+// sendTool records the inputs it was executed with.
+type sendTool struct {
+	mu    sync.Mutex
+	sent  []string
+	fail  bool
+	reply string
+}
+
+func (t *sendTool) Name() string            { return "send_note" }
+func (t *sendTool) Description() string     { return "Sends a note." }
+func (t *sendTool) Schema() json.RawMessage { return json.RawMessage(`{"type":"object"}`) }
+
+func (t *sendTool) Execute(_ context.Context, params json.RawMessage) (*agent.ToolResult, error) {
+	if t.fail {
+		return &agent.ToolResult{Content: "smtp unavailable", IsError: true}, nil
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.sent = append(t.sent, string(params))
+	return &agent.ToolResult{Content: "sent"}, nil
+}
+
+func (t *sendTool) sentInputs() []string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return append([]string(nil), t.sent...)
+}
+
+// noteTool previews and revises its "text" field.
+type noteTool struct{ sendTool }
+
+func (t *noteTool) PreviewDraft(params json.RawMessage) (string, error) {
+	var input struct {
+		Text string `json:"text"`
+	}
+	err := json.Unmarshal(params, &input)
+	return "Note: " + input.Text, err
+}
+
+func (t *noteTool) ReviseDraft(_ json.RawMessage, text string) (json.RawMessage, error) {
+	return json.Marshal(map[string]string{"text": text})
+}
+
+func conversation() context.Context {
+	return agent.WithSession(context.Background(), &models.Session{
+		ID: "s1", AgentID: "main", Channel: models.ChannelSlack, ChannelID: "C1",
+	})
+}
+
+func TestHeldToolOnlySendsAfterApproval(t *testing.T) {
+	target := &noteTool{}
+	queue := NewQueue(time.Hour)
+	var held []*Draft
+	queue.SetOnHold(func(_ context.Context, draft *Draft) { held = append(held, draft) })
+	tool := queue.Wrap(target)
+
+	if tool.Name() != "send_note" || !strings.Contains(tool.Description(), "approve") {
+		t.Fatalf("unexpected wrapper: %s %q", tool.Name(), tool.Description())
+	}
+	result, err := tool.Execute(conversation(), json.RawMessage(`{"text":"hello"}`))
+	if err != nil || result.IsError {
+		t.Fatalf("Execute() = %+v, %v", result, err)
+	}
+	if len(target.sentInputs()) != 0 {
+		t.Fatal("draft was sent before approval")
+	}
+	if len(held) != 1 || held[0].Preview != "Note: hello" || held[0].ChannelID != "C1" {
+		t.Fatalf("unexpected held drafts: %+v", held)
+	}
+	id := held[0].ID
+	if !strings.Contains(result.Content, id) || !strings.Contains(result.Content, "NOT been sent") {
+		t.Fatalf("tool result should say the draft was not sent: %q", result.Content)
+	}
+	if pending := queue.List(models.ChannelSlack, "C1"); len(pending) != 1 {
+		t.Fatalf("List() = %d drafts, want 1", len(pending))
+	}
+	if pending := queue.List(models.ChannelSlack, "other"); len(pending) != 0 {
+		t.Fatalf("List() for another conversation = %d drafts", len(pending))
+	}
+
+	edited, err := queue.Edit(id, "hello there")
+	if err != nil || edited.Preview != "Note: hello there" {
+		t.Fatalf("Edit() = %+v, %v", edited, err)
+	}
+	sent, result, err := queue.Approve(context.Background(), id, "slack:U1")
+	if err != nil {
+		t.Fatalf("Approve() error = %v", err)
+	}
+	if sent.Status != StatusSent || result.Content != "sent" {
+		t.Fatalf("Approve() = %+v, %+v", sent, result)
+	}
+	if got := target.sentInputs(); len(got) != 1 || got[0] != `{"text":"hello there"}` {
+		t.Fatalf("sent inputs = %q", got)
+	}
+	if _, _, err := queue.Approve(context.Background(), id, "slack:U1"); !errors.Is(err, ErrDecided) {
+		t.Fatalf("second Approve() error = %v, want ErrDecided", err)
+	}
+}
+
+func TestHeldToolRequiresConversation(t *testing.T) {
+	queue := NewQueue(time.Hour)
+	result, err := queue.Wrap(&sendTool{}).Execute(context.Background(), json.RawMessage(`{}`))
+	if err != nil || !result.IsError {
+		t.Fatalf("Execute() without a session = %+v, %v", result, err)
+	}
+}
+
+func TestFailedSendStaysPending(t *testing.T) {
+	target := &sendTool{fail: true}
+	queue := NewQueue(time.Hour)
+	queue.Wrap(target)
+	draft, err := queue.Hold(conversation(), "send_note", json.RawMessage(`{"to":"a"}`))
+	if err != nil {
+		t.Fatalf("Hold() error = %v", err)
+	}
+	if draft.Preview != "{\n  \"to\": \"a\"\n}" {
+		t.Fatalf("JSON preview = %q", draft.Preview)
+	}
+	if _, _, err := queue.Approve(context.Background(), draft.ID, "u"); err == nil || !strings.Contains(err.Error(), "smtp unavailable") {
+		t.Fatalf("Approve() error = %v", err)
+	}
+	if _, err := queue.Edit(draft.ID, "plain text"); !errors.Is(err, ErrNotEditable) {
+		t.Fatalf("Edit() with text error = %v, want ErrNotEditable", err)
+	}
+	if _, err := queue.Edit(draft.ID, `{"to":"b"}`); err != nil {
+		t.Fatalf("Edit() with JSON error = %v", err)
+	}
+
+	target.fail = false
+	if _, _, err := queue.Approve(context.Background(), draft.ID, "u"); err != nil {
+		t.Fatalf("retry Approve() error = %v", err)
+	}
+	if got := target.sentInputs(); len(got) != 1 || got[0] != `{"to":"b"}` {
+		t.Fatalf("sent inputs = %q", got)
+	}
+}
+
+func TestDraftExpiry(t *testing.T) {
+	target := &sendTool{}
+	queue := NewQueue(10 * time.Millisecond)
+	queue.Wrap(target)
+	expired := make(chan *Draft, 1)
+	queue.SetOnExpire(func(draft *Draft) { expired <- draft })
+
+	draft, err := queue.Hold(conversation(), "send_note", json.RawMessage(`{}`))
+	if err != nil {
+		t.Fatalf("Hold() error = %v", err)
+	}
+	select {
+	case got := <-expired:
+		if got.ID != draft.ID || got.Status != StatusExpired {
+			t.Fatalf("expired draft = %+v", got)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("draft did not expire")
+	}
+	if _, _, err := queue.Approve(context.Background(), draft.ID, "u"); !errors.Is(err, ErrDecided) {
+		t.Fatalf("Approve() after expiry error = %v, want ErrDecided", err)
+	}
+	if _, err := queue.Cancel(draft.ID, "u"); !errors.Is(err, ErrDecided) {
+		t.Fatalf("Cancel() after expiry error = %v, want ErrDecided", err)
+	}
+	if len(target.sentInputs()) != 0 {
+		t.Fatal("expired draft was sent")
+	}
+}
+
+func TestMatches(t *testing.T) {
+	patterns := []string{"email_compose", "servicenow_*"}
+	for tool, want := range map[string]bool{
+		"email_compose":          true,
+		"servicenow_add_comment": true,
+		"exec":                   false,
+	} {
+		if got := Matches(patterns, tool); got != want {
+			t.Errorf("Matches(%q) = %v, want %v", tool, got, want)
+		}
+	}
+}
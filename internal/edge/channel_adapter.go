@@ -0,0 +1,321 @@
+package edge
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/haasonsaas/nexus/internal/channels"
+	"github.com/haasonsaas/nexus/pkg/models"
+	pb "github.com/haasonsaas/nexus/pkg/proto"
+)
+
+// defaultChannelSendTimeout bounds how long Send waits for the edge to
+// acknowledge delivery.
+const defaultChannelSendTimeout = 30 * time.Second
+
+// ChannelAdapter exposes a channel hosted on edge daemons (such as iMessage
+// on a Mac) as a regular channel adapter. Inbound messages arrive over the
+// edge stream; replies are sent back to the edge that hosts the conversation.
+type ChannelAdapter struct {
+	*channels.BaseHealthAdapter
+
+	manager     *Manager
+	channelType models.ChannelType
+	protoType   pb.ChannelType
+	logger      *slog.Logger
+	sendTimeout time.Duration
+
+	mu       sync.RWMutex
+	messages chan *models.Message
+	closed   bool
+	// routes remembers which edge delivered each conversation.
+	routes map[string]string
+}
+
+// NewChannelAdapter creates an adapter for an edge-hosted channel type.
+func NewChannelAdapter(manager *Manager, channelType models.ChannelType, logger *slog.Logger) (*ChannelAdapter, error) {
+	if manager == nil {
+		return nil, errors.New("edge manager is required")
+	}
+	protoType := ChannelTypeToProto(channelType)
+	if protoType == pb.ChannelType_CHANNEL_TYPE_UNSPECIFIED {
+		return nil, fmt.Errorf("channel %q cannot be hosted on an edge", channelType)
+	}
+	if logger == nil {
+		logger = slog.Default()
+	}
+	logger = logger.With("channel", channelType, "component", "edge-channel")
+	return &ChannelAdapter{
+		BaseHealthAdapter: channels.NewBaseHealthAdapter(channelType, logger),
+		manager:           manager,
+		channelType:       channelType,
+		protoType:         protoType,
+		logger:            logger,
+		sendTimeout:       defaultChannelSendTimeout,
+		messages:          make(chan *models.Message, 100),
+		routes:            make(map[string]string),
+	}, nil
+}
+
+// Type returns the channel type.
+func (a *ChannelAdapter) Type() models.ChannelType {
+	return a.channelType
+}
+
+// Start marks the adapter as ready. Delivery depends on an edge hosting the
+// channel being connected.
+func (a *ChannelAdapter) Start(ctx context.Context) error {
+	a.SetStatus(true, "")
+	return nil
+}
+
+// Stop closes the inbound message channel.
+func (a *ChannelAdapter) Stop(ctx context.Context) error {
+	a.mu.Lock()
+	if !a.closed {
+		a.closed = true
+		close(a.messages)
+	}
+	a.mu.Unlock()
+	a.SetStatus(false, "")
+	return nil
+}
+
+// Messages returns inbound messages relayed from edges.
+func (a *ChannelAdapter) Messages() <-chan *models.Message {
+	return a.messages
+}
+
+// HealthCheck reports whether any connected edge hosts the channel.
+func (a *ChannelAdapter) HealthCheck(ctx context.Context) channels.HealthStatus {
+	start := time.Now()
+	edges := a.manager.GetEdgesWithChannel(string(a.channelType))
+	status := channels.HealthStatus{
+		Healthy:   len(edges) > 0,
+		Latency:   time.Since(start),
+		LastCheck: time.Now(),
+	}
+	if len(edges) == 0 {
+		status.Message = "no connected edge hosts this channel"
+	} else {
+		status.Message = fmt.Sprintf("hosted by %d edge(s)", len(edges))
+	}
+	return status
+}
+
+// HandleInbound converts a relayed message and emits it to the gateway.
+func (a *ChannelAdapter) HandleInbound(ctx context.Context, in *pb.EdgeChannelInbound) error {
+	if in == nil {
+		return nil
+	}
+	conversationID := strings.TrimSpace(in.ChannelId)
+	if conversationID == "" {
+		return errors.New("edge channel message missing channel_id")
+	}
+
+	msg := &models.Message{
+		ID:        in.Metadata["message_id"],
+		Channel:   a.channelType,
+		ChannelID: conversationID,
+		Direction: models.DirectionInbound,
+		Role:      models.RoleUser,
+		Content:   in.Content,
+		Metadata:  make(map[string]any, len(in.Metadata)+2),
+		CreatedAt: time.Now(),
+	}
+	if in.ReceivedAt != nil {
+		msg.CreatedAt = in.ReceivedAt.AsTime()
+	}
+	if msg.ID == "" {
+		msg.ID = uuid.NewString()
+	}
+	for key, value := range in.Metadata {
+		msg.Metadata[key] = value
+	}
+	if in.SenderId != "" {
+		msg.Metadata["sender_id"] = in.SenderId
+		if _, ok := msg.Metadata["peer_id"]; !ok {
+			msg.Metadata["peer_id"] = in.SenderId
+		}
+	}
+	if in.SenderName != "" {
+		msg.Metadata["sender_name"] = in.SenderName
+	}
+	msg.Metadata["edge_id"] = in.EdgeId
+	for _, att := range in.Attachments {
+		if att == nil {
+			continue
+		}
+		msg.Attachments = append(msg.Attachments, models.Attachment{
+			ID:       att.Id,
+			Type:     att.Type,
+			URL:      att.Url,
+			Filename: att.Filename,
+			MimeType: att.MimeType,
+			Size:     att.Size,
+		})
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.closed {
+		return errors.New("edge channel adapter stopped")
+	}
+	a.routes[conversationID] = in.EdgeId
+	select {
+	case a.messages <- msg:
+		a.RecordMessageReceived()
+		return nil
+	default:
+		a.RecordMessageFailed()
+		return errors.New("edge channel message buffer full")
+	}
+}
+
+// Send relays a message to the edge hosting the conversation and waits for
+// the edge to acknowledge it.
+func (a *ChannelAdapter) Send(ctx context.Context, msg *models.Message) error {
+	if msg == nil {
+		return channels.ErrInvalidInput("message is required", nil)
+	}
+	destination := metadataString(msg.Metadata, "peer_id")
+	if destination == "" {
+		destination = msg.ChannelID
+	}
+	if destination == "" {
+		return channels.ErrInvalidInput(channels.MissingMetadata("peer_id", msg.ID), nil)
+	}
+	conversationID := metadataString(msg.Metadata, "group_id")
+	if conversationID == "" {
+		conversationID = destination
+	}
+
+	edgeID, err := a.routeFor(msg, conversationID)
+	if err != nil {
+		a.RecordMessageFailed()
+		return err
+	}
+
+	out := &pb.CoreChannelOutbound{
+		MessageId:   uuid.NewString(),
+		SessionId:   msg.SessionID,
+		ChannelType: a.protoType,
+		ChannelId:   destination,
+		Content:     msg.Content,
+		Options:     make(map[string]string),
+	}
+	if groupID := metadataString(msg.Metadata, "group_id"); groupID != "" {
+		out.Options["group_id"] = groupID
+	}
+	if replyTo := metadataString(msg.Metadata, "reply_to"); replyTo != "" {
+		out.ReplyToId = replyTo
+	}
+	for _, att := range msg.Attachments {
+		out.Attachments = append(out.Attachments, &pb.Attachment{
+			Id:       att.ID,
+			Type:     att.Type,
+			Url:      att.URL,
+			Filename: att.Filename,
+			MimeType: att.MimeType,
+			Size:     att.Size,
+		})
+	}
+
+	sendCtx, cancel := context.WithTimeout(ctx, a.sendTimeout)
+	defer cancel()
+	start := time.Now()
+	ack, err := a.manager.SendChannelMessage(sendCtx, edgeID, out)
+	if err != nil {
+		a.RecordMessageFailed()
+		return channels.ErrConnection(fmt.Sprintf("send via edge %s", edgeID), err)
+	}
+	if ack.Status == pb.ChannelDeliveryStatus_CHANNEL_DELIVERY_STATUS_FAILED {
+		a.RecordMessageFailed()
+		return channels.ErrConnection(fmt.Sprintf("edge %s failed to deliver: %s", edgeID, ack.Error), nil)
+	}
+	a.RecordSendLatency(time.Since(start))
+	a.RecordMessageSent()
+	return nil
+}
+
+// routeFor picks the edge for an outbound message: the edge named in the
+// message metadata, then the edge that last delivered the conversation, then
+// any connected edge hosting the channel.
+func (a *ChannelAdapter) routeFor(msg *models.Message, conversationID string) (string, error) {
+	hosts := a.manager.GetEdgesWithChannel(string(a.channelType))
+	if len(hosts) == 0 {
+		return "", channels.ErrUnavailable(fmt.Sprintf("no connected edge hosts %s", a.channelType), nil)
+	}
+	connected := make(map[string]bool, len(hosts))
+	for _, conn := range hosts {
+		connected[conn.ID] = true
+	}
+
+	if edgeID := metadataString(msg.Metadata, "edge_id"); connected[edgeID] {
+		return edgeID, nil
+	}
+	a.mu.RLock()
+	edgeID := a.routes[conversationID]
+	a.mu.RUnlock()
+	if connected[edgeID] {
+		return edgeID, nil
+	}
+
+	best := hosts[0].ID
+	for _, conn := range hosts[1:] {
+		if conn.ID < best {
+			best = conn.ID
+		}
+	}
+	return best, nil
+}
+
+func metadataString(metadata map[string]any, key string) string {
+	if metadata == nil {
+		return ""
+	}
+	value, ok := metadata[key].(string)
+	if !ok {
+		return ""
+	}
+	return strings.TrimSpace(value)
+}
+
+// ChannelInboundRouter dispatches relayed channel messages to the adapter
+// registered for their channel type.
+func ChannelInboundRouter(adapters ...*ChannelAdapter) ChannelInboundHandler {
+	byType := make(map[pb.ChannelType]*ChannelAdapter, len(adapters))
+	for _, adapter := range adapters {
+		if adapter != nil {
+			byType[adapter.protoType] = adapter
+		}
+	}
+	return func(ctx context.Context, msg *pb.EdgeChannelInbound) error {
+		adapter, ok := byType[msg.ChannelType]
+		if !ok {
+			return fmt.Errorf("no adapter for edge channel %s", msg.ChannelType)
+		}
+		return adapter.HandleInbound(ctx, msg)
+	}
+}
+
+// ChannelTypeToProto maps an edge-hostable channel type to its protocol enum.
+// Channels that cannot be hosted on an edge map to CHANNEL_TYPE_UNSPECIFIED.
+func ChannelTypeToProto(channelType models.ChannelType) pb.ChannelType {
+	switch channelType {
+	case models.ChannelIMessage:
+		return pb.ChannelType_CHANNEL_TYPE_IMESSAGE
+	case models.ChannelSignal:
+		return pb.ChannelType_CHANNEL_TYPE_SIGNAL
+	case models.ChannelWhatsApp:
+		return pb.ChannelType_CHANNEL_TYPE_WHATSAPP
+	default:
+		return pb.ChannelType_CHANNEL_TYPE_UNSPECIFIED
+	}
+}
@@ -0,0 +1,118 @@
+package edge
+
+import (
+	"context"
+	"testing"
+
+	"google.golang.org/grpc"
+
+	"github.com/haasonsaas/nexus/pkg/models"
+	pb "github.com/haasonsaas/nexus/pkg/proto"
+)
+
+// ackingStream records outbound channel messages and acknowledges them.
+type ackingStream struct {
+	grpc.ServerStream
+	manager *Manager
+	conn    *EdgeConnection
+	sent    chan *pb.CoreChannelOutbound
+	status  pb.ChannelDeliveryStatus
+}
+
+func (s *ackingStream) Send(msg *pb.CoreMessage) error {
+	out := msg.GetChannelOutbound()
+	if out == nil {
+		return nil
+	}
+	s.sent <- out
+	go s.manager.handleChannelAck(s.conn, &pb.EdgeChannelAck{MessageId: out.MessageId, Status: s.status})
+	return nil
+}
+
+func (s *ackingStream) Recv() (*pb.EdgeMessage, error) {
+	select {}
+}
+
+func addChannelEdge(manager *Manager, id string, status pb.ChannelDeliveryStatus) *ackingStream {
+	conn := &EdgeConnection{ID: id, ChannelTypes: []string{"imessage"}}
+	stream := &ackingStream{manager: manager, conn: conn, sent: make(chan *pb.CoreChannelOutbound, 4), status: status}
+	conn.stream = stream
+	manager.mu.Lock()
+	manager.edges[id] = conn
+	manager.mu.Unlock()
+	return stream
+}
+
+func TestChannelAdapterRelaysInboundAndReplies(t *testing.T) {
+	manager := NewManager(DefaultManagerConfig(), &mockAuthenticator{}, nil)
+	adapter, err := NewChannelAdapter(manager, models.ChannelIMessage, nil)
+	if err != nil {
+		t.Fatalf("NewChannelAdapter: %v", err)
+	}
+	addChannelEdge(manager, "a-edge", pb.ChannelDeliveryStatus_CHANNEL_DELIVERY_STATUS_SENT)
+	macbook := addChannelEdge(manager, "macbook", pb.ChannelDeliveryStatus_CHANNEL_DELIVERY_STATUS_SENT)
+
+	route := ChannelInboundRouter(adapter)
+	err = route(context.Background(), &pb.EdgeChannelInbound{
+		EdgeId:      "macbook",
+		ChannelType: pb.ChannelType_CHANNEL_TYPE_IMESSAGE,
+		ChannelId:   "+15550100",
+		Content:     "hello",
+		SenderId:    "+15550100",
+		Metadata:    map[string]string{"message_id": "guid-1", "conversation_type": "dm"},
+	})
+	if err != nil {
+		t.Fatalf("route inbound: %v", err)
+	}
+	msg := <-adapter.Messages()
+	if msg.ID != "guid-1" || msg.ChannelID != "+15550100" || msg.Content != "hello" {
+		t.Fatalf("unexpected inbound message: %+v", msg)
+	}
+	if msg.Metadata["peer_id"] != "+15550100" || msg.Metadata["edge_id"] != "macbook" {
+		t.Fatalf("unexpected inbound metadata: %+v", msg.Metadata)
+	}
+
+	// The reply carries only the peer; it must go back to the edge that
+	// delivered the conversation rather than the first hosting edge.
+	reply := &models.Message{
+		SessionID: "session-1",
+		Channel:   models.ChannelIMessage,
+		Content:   "hi there",
+		Metadata:  map[string]any{"peer_id": "+15550100"},
+	}
+	if err := adapter.Send(context.Background(), reply); err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+	out := <-macbook.sent
+	if out.ChannelId != "+15550100" || out.Content != "hi there" || out.ChannelType != pb.ChannelType_CHANNEL_TYPE_IMESSAGE {
+		t.Fatalf("unexpected outbound: %+v", out)
+	}
+	if adapter.Metrics().MessagesSent != 1 {
+		t.Fatalf("expected sent metric, got %+v", adapter.Metrics())
+	}
+}
+
+func TestChannelAdapterSendErrors(t *testing.T) {
+	manager := NewManager(DefaultManagerConfig(), &mockAuthenticator{}, nil)
+	adapter, err := NewChannelAdapter(manager, models.ChannelIMessage, nil)
+	if err != nil {
+		t.Fatalf("NewChannelAdapter: %v", err)
+	}
+	msg := &models.Message{Content: "hi", Metadata: map[string]any{"peer_id": "+15550100"}}
+
+	if err := adapter.Send(context.Background(), msg); err == nil {
+		t.Fatal("expected error with no hosting edge")
+	}
+	if health := adapter.HealthCheck(context.Background()); health.Healthy {
+		t.Fatal("expected unhealthy with no hosting edge")
+	}
+
+	addChannelEdge(manager, "macbook", pb.ChannelDeliveryStatus_CHANNEL_DELIVERY_STATUS_FAILED)
+	if err := adapter.Send(context.Background(), msg); err == nil {
+		t.Fatal("expected error when the edge reports a failed delivery")
+	}
+
+	if _, err := NewChannelAdapter(manager, models.ChannelSlack, nil); err == nil {
+		t.Fatal("expected slack to be rejected as an edge channel")
+	}
+}
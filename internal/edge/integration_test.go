@@ -4,9 +4,11 @@ import (
 	"context"
 	"fmt"
 	"net"
+	"strings"
 	"testing"
 	"time"
 
+	"go.opentelemetry.io/otel/trace"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/protobuf/types/known/timestamppb"
@@ -135,9 +137,18 @@ func TestEdgeIntegration(t *testing.T) {
 	toolResultCh := make(chan *ToolExecutionResult, 1)
 	toolErrCh := make(chan error, 1)
 
+	traceID, _ := trace.TraceIDFromHex("4bf92f3577b34da6a3ce929d0e0e4736")
+	spanID, _ := trace.SpanIDFromHex("00f067aa0ba902b7")
+	runCtx := trace.ContextWithSpanContext(ctx, trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    traceID,
+		SpanID:     spanID,
+		TraceFlags: trace.FlagsSampled,
+	}))
+
 	go func() {
-		result, err := manager.ExecuteTool(ctx, "test-edge", "echo", `{"message":"hello"}`, ExecuteOptions{
-			Timeout: 5 * time.Second,
+		result, err := manager.ExecuteTool(runCtx, "test-edge", "echo", `{"message":"hello"}`, ExecuteOptions{
+			Timeout:  5 * time.Second,
+			Metadata: map[string]string{"source": "test"},
 		})
 		if err != nil {
 			toolErrCh <- err
@@ -159,6 +170,12 @@ func TestEdgeIntegration(t *testing.T) {
 	if toolReq.ToolName != "echo" {
 		t.Errorf("expected tool name echo, got %s", toolReq.ToolName)
 	}
+	if toolReq.Metadata["source"] != "test" {
+		t.Errorf("expected caller metadata, got %v", toolReq.Metadata)
+	}
+	if traceparent := toolReq.Metadata["traceparent"]; !strings.Contains(traceparent, traceID.String()) {
+		t.Errorf("expected traceparent in trace %s, got %q", traceID, traceparent)
+	}
 
 	// Send tool result
 	err = stream.Send(&pb.EdgeMessage{
@@ -41,6 +41,7 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"maps"
 	"math/rand"
 	"sync"
 	"time"
@@ -49,6 +50,10 @@ import (
 	"github.com/haasonsaas/nexus/internal/artifacts"
 	"github.com/haasonsaas/nexus/internal/observability"
 	pb "github.com/haasonsaas/nexus/pkg/proto"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
@@ -81,6 +86,9 @@ type Manager struct {
 	// channelHandler receives inbound channel messages
 	channelHandler ChannelInboundHandler
 
+	// nodes tracks the devices exposed by edges
+	nodes *nodeRegistry
+
 	// config holds manager configuration
 	config ManagerConfig
 
@@ -299,6 +307,7 @@ func NewManager(config ManagerConfig, auth Authenticator, logger *slog.Logger) *
 		edges:              make(map[string]*EdgeConnection),
 		pendingTools:       make(map[string]*PendingTool),
 		pendingChannelMsgs: make(map[string]*PendingChannelMessage),
+		nodes:              newNodeRegistry(logger.With("component", "edge.nodes")),
 		config:             config,
 		auth:               auth,
 		events:             make(chan EdgeEvent, config.EventBufferSize),
@@ -403,6 +412,7 @@ func (m *Manager) HandleConnect(stream pb.EdgeService_ConnectServer) error {
 	m.metrics.ConnectedEdges++
 	m.metrics.TotalConnections++
 	m.mu.Unlock()
+	m.nodes.edgeConnected(conn)
 
 	// Send success response
 	if err := stream.Send(&pb.CoreMessage{
@@ -471,10 +481,12 @@ func (m *Manager) handleMessages(ctx context.Context, conn *EdgeConnection) erro
 
 // handleHeartbeat processes a heartbeat from an edge.
 func (m *Manager) handleHeartbeat(conn *EdgeConnection, hb *pb.EdgeHeartbeat) {
+	now := time.Now()
 	conn.mu.Lock()
-	conn.LastHeartbeat = time.Now()
+	conn.LastHeartbeat = now
 	conn.Metrics = hb.Metrics
 	conn.mu.Unlock()
+	m.nodes.edgeSeen(conn.ID, now)
 }
 
 // handleToolResult processes a tool execution result.
@@ -621,6 +633,9 @@ func (m *Manager) handleChannelInbound(conn *EdgeConnection, msg *pb.EdgeChannel
 		return
 	}
 
+	// Trust the authenticated connection, not the edge_id in the payload.
+	msg.EdgeId = conn.ID
+
 	m.logger.Debug("received channel inbound message",
 		"edge_id", conn.ID,
 		"channel_type", msg.ChannelType,
@@ -767,12 +782,41 @@ func (m *Manager) removeEdge(edgeID string) {
 
 	delete(m.edges, edgeID)
 	m.metrics.ConnectedEdges--
+	m.nodes.edgeDisconnected(edgeID, time.Now())
 
 	m.logger.Info("edge disconnected", "edge_id", edgeID)
 }
 
-// ExecuteTool sends a tool execution request to an edge.
+// edgeTracer records the core side of edge tool calls. It resolves to the
+// global provider, so spans are exported only when tracing is enabled.
+var edgeTracer = otel.Tracer("github.com/haasonsaas/nexus/internal/edge")
+
+// ExecuteTool sends a tool execution request to an edge. The call is traced
+// as a client span whose W3C trace context travels in the request metadata,
+// so the edge's execution span joins the same trace.
 func (m *Manager) ExecuteTool(ctx context.Context, edgeID, toolName, input string, opts ExecuteOptions) (*ToolExecutionResult, error) {
+	ctx, span := edgeTracer.Start(ctx, "edge.tool "+toolName,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("edge.id", edgeID),
+			attribute.String("tool.name", toolName),
+			attribute.String("run_id", opts.RunID),
+		),
+	)
+	defer span.End()
+
+	result, err := m.executeTool(ctx, edgeID, toolName, input, opts)
+	switch {
+	case err != nil:
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	case result.IsError:
+		span.SetStatus(codes.Error, "tool returned an error")
+	}
+	return result, err
+}
+
+func (m *Manager) executeTool(ctx context.Context, edgeID, toolName, input string, opts ExecuteOptions) (*ToolExecutionResult, error) {
 	m.mu.RLock()
 	conn, ok := m.edges[edgeID]
 	m.mu.RUnlock()
@@ -801,6 +845,11 @@ func (m *Manager) ExecuteTool(ctx context.Context, edgeID, toolName, input strin
 
 	// Create execution ID
 	execID := uuid.New().String()
+	trace.SpanFromContext(ctx).SetAttributes(attribute.String("tool.execution_id", execID))
+
+	metadata := make(map[string]string, len(opts.Metadata)+2)
+	maps.Copy(metadata, opts.Metadata)
+	observability.InjectTraceContext(ctx, metadata)
 
 	// Create pending tool tracker
 	pending := &PendingTool{
@@ -836,7 +885,7 @@ func (m *Manager) ExecuteTool(ctx context.Context, edgeID, toolName, input strin
 				Input:          input,
 				TimeoutSeconds: int32(timeout.Seconds()),
 				Approved:       opts.Approved,
-				Metadata:       opts.Metadata,
+				Metadata:       metadata,
 			},
 		},
 	}); err != nil {
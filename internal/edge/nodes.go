@@ -0,0 +1,458 @@
+package edge
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/haasonsaas/nexus/internal/nodes"
+)
+
+// NodesMetadataKey is the registration metadata key an edge uses to declare
+// the nodes it exposes, as a JSON array of NodeDescriptor. Edges that do not
+// set it expose a single node named after the edge.
+const NodesMetadataKey = "nodes"
+
+// NodeIDMetadataKey is set on tool execution requests routed to a node so an
+// edge exposing several nodes can dispatch them.
+const NodeIDMetadataKey = "node_id"
+
+// NodeDescriptor is how an edge describes one device it exposes, such as the
+// Mac it runs on or a phone reached through a companion app.
+type NodeDescriptor struct {
+	ID           string            `json:"id" yaml:"id"`
+	Name         string            `json:"name,omitempty" yaml:"name,omitempty"`
+	DeviceType   string            `json:"device_type,omitempty" yaml:"device_type,omitempty"`
+	Capabilities []string          `json:"capabilities,omitempty" yaml:"capabilities,omitempty"`
+	Tools        []string          `json:"tools,omitempty" yaml:"tools,omitempty"`
+	Metadata     map[string]string `json:"metadata,omitempty" yaml:"metadata,omitempty"`
+}
+
+// Node is a device in the core's node registry. Nodes stay registered after
+// their edge disconnects so they can be reported as offline.
+type Node struct {
+	ID            string             `json:"id"`
+	Name          string             `json:"name"`
+	DeviceType    string             `json:"device_type,omitempty"`
+	EdgeID        string             `json:"edge_id"`
+	Capabilities  []nodes.Capability `json:"capabilities"`
+	Tools         []string           `json:"tools"`
+	Metadata      map[string]string  `json:"metadata,omitempty"`
+	Online        bool               `json:"online"`
+	ConnectedAt   time.Time          `json:"connected_at"`
+	LastSeen      time.Time          `json:"last_seen"`
+	capabilitySet map[nodes.Capability]bool
+}
+
+// HasCapability reports whether the node declares a capability.
+func (n *Node) HasCapability(capability nodes.Capability) bool {
+	return n.capabilitySet[capability]
+}
+
+// clone returns a copy safe to hand out of the registry lock.
+func (n *Node) clone() *Node {
+	out := *n
+	out.Capabilities = append([]nodes.Capability(nil), n.Capabilities...)
+	out.Tools = append([]string(nil), n.Tools...)
+	if n.Metadata != nil {
+		out.Metadata = make(map[string]string, len(n.Metadata))
+		for k, v := range n.Metadata {
+			out.Metadata[k] = v
+		}
+	}
+	return &out
+}
+
+// toolCapabilities infers capabilities from the tools a node serves when it
+// does not declare them. Keys are tool names without their namespace.
+var toolCapabilities = map[string]nodes.Capability{
+	"camera_snap":    nodes.CapCamera,
+	"screen_capture": nodes.CapScreen,
+	"screen_record":  nodes.CapScreen,
+	"location_get":   nodes.CapLocation,
+	"file_read":      nodes.CapFilesystem,
+	"file_write":     nodes.CapFilesystem,
+	"file_glob":      nodes.CapFilesystem,
+	"shell_run":      nodes.CapShell,
+	"run_command":    nodes.CapShell,
+	"computer_use":   nodes.CapComputerUse,
+}
+
+// nodeRegistry tracks the nodes exposed by edges and whether they are online.
+type nodeRegistry struct {
+	mu     sync.RWMutex
+	nodes  map[string]*Node
+	logger *slog.Logger
+}
+
+func newNodeRegistry(logger *slog.Logger) *nodeRegistry {
+	return &nodeRegistry{
+		nodes:  make(map[string]*Node),
+		logger: logger,
+	}
+}
+
+// edgeConnected registers the nodes an edge exposes and marks them online.
+// Nodes the edge exposed before but no longer declares are dropped.
+func (r *nodeRegistry) edgeConnected(conn *EdgeConnection) {
+	conn.mu.RLock()
+	descriptors := nodeDescriptors(conn, r.logger)
+	toolNames := make([]string, 0, len(conn.Tools))
+	for name := range conn.Tools {
+		toolNames = append(toolNames, name)
+	}
+	hostsChannels := len(conn.ChannelTypes) > 0
+	edgeID := conn.ID
+	connectedAt := conn.ConnectedAt
+	conn.mu.RUnlock()
+	sort.Strings(toolNames)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	declared := make(map[string]bool, len(descriptors))
+	for _, desc := range descriptors {
+		node := buildNode(desc, toolNames, hostsChannels)
+		node.EdgeID = edgeID
+		node.Online = true
+		node.ConnectedAt = connectedAt
+		node.LastSeen = connectedAt
+		if existing, ok := r.nodes[node.ID]; ok && existing.Online && existing.EdgeID != edgeID {
+			r.logger.Warn("node id claimed by another edge; replacing",
+				"node_id", node.ID,
+				"previous_edge_id", existing.EdgeID,
+				"edge_id", edgeID,
+			)
+		}
+		r.nodes[node.ID] = node
+		declared[node.ID] = true
+	}
+	for id, node := range r.nodes {
+		if node.EdgeID == edgeID && !declared[id] {
+			delete(r.nodes, id)
+		}
+	}
+}
+
+// edgeSeen refreshes the last-seen time of an edge's nodes.
+func (r *nodeRegistry) edgeSeen(edgeID string, at time.Time) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, node := range r.nodes {
+		if node.EdgeID == edgeID && node.Online {
+			node.LastSeen = at
+		}
+	}
+}
+
+// edgeDisconnected marks an edge's nodes offline.
+func (r *nodeRegistry) edgeDisconnected(edgeID string, at time.Time) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, node := range r.nodes {
+		if node.EdgeID == edgeID && node.Online {
+			node.Online = false
+			node.LastSeen = at
+		}
+	}
+}
+
+func (r *nodeRegistry) list() []*Node {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	out := make([]*Node, 0, len(r.nodes))
+	for _, node := range r.nodes {
+		out = append(out, node.clone())
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].ID < out[j].ID })
+	return out
+}
+
+func (r *nodeRegistry) get(id string) (*Node, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	node, ok := r.nodes[id]
+	if !ok {
+		return nil, false
+	}
+	return node.clone(), true
+}
+
+// nodeDescriptors reads the nodes an edge declares, falling back to a single
+// node for the edge itself. Callers hold conn.mu.
+func nodeDescriptors(conn *EdgeConnection, logger *slog.Logger) []NodeDescriptor {
+	implicit := []NodeDescriptor{{ID: conn.ID, Name: conn.Name}}
+	raw := strings.TrimSpace(conn.Metadata[NodesMetadataKey])
+	if raw == "" {
+		return implicit
+	}
+	var declared []NodeDescriptor
+	if err := json.Unmarshal([]byte(raw), &declared); err != nil {
+		logger.Warn("ignoring invalid node declaration", "edge_id", conn.ID, "error", err)
+		return implicit
+	}
+	out := make([]NodeDescriptor, 0, len(declared))
+	for _, desc := range declared {
+		desc.ID = strings.TrimSpace(desc.ID)
+		if desc.ID == "" {
+			continue
+		}
+		out = append(out, desc)
+	}
+	if len(out) == 0 {
+		return implicit
+	}
+	for i := range out {
+		if out[i].DeviceType == "" {
+			out[i].DeviceType = deviceTypeFromMetadata(conn.Metadata)
+		}
+	}
+	return out
+}
+
+func buildNode(desc NodeDescriptor, edgeTools []string, hostsChannels bool) *Node {
+	node := &Node{
+		ID:            desc.ID,
+		Name:          desc.Name,
+		DeviceType:    desc.DeviceType,
+		Metadata:      desc.Metadata,
+		capabilitySet: make(map[nodes.Capability]bool),
+	}
+	if node.Name == "" {
+		node.Name = node.ID
+	}
+
+	if len(desc.Tools) == 0 {
+		node.Tools = edgeTools
+	} else {
+		available := make(map[string]bool, len(edgeTools))
+		for _, name := range edgeTools {
+			available[name] = true
+		}
+		for _, name := range desc.Tools {
+			if available[name] {
+				node.Tools = append(node.Tools, name)
+			}
+		}
+	}
+	if node.Tools == nil {
+		node.Tools = []string{}
+	}
+
+	addCapability := func(capability nodes.Capability) {
+		if capability != "" && !node.capabilitySet[capability] {
+			node.capabilitySet[capability] = true
+			node.Capabilities = append(node.Capabilities, capability)
+		}
+	}
+	if len(desc.Capabilities) > 0 {
+		for _, capability := range desc.Capabilities {
+			addCapability(nodes.Capability(strings.ToLower(strings.TrimSpace(capability))))
+		}
+	} else {
+		for _, name := range node.Tools {
+			if capability, ok := toolCapabilities[shortToolName(name)]; ok {
+				addCapability(capability)
+			} else if strings.Contains(name, "browser") {
+				addCapability(nodes.CapBrowser)
+			}
+		}
+		if hostsChannels && len(desc.Tools) == 0 {
+			addCapability(nodes.CapChannels)
+		}
+	}
+	sort.Slice(node.Capabilities, func(i, j int) bool { return node.Capabilities[i] < node.Capabilities[j] })
+	if node.Capabilities == nil {
+		node.Capabilities = []nodes.Capability{}
+	}
+	return node
+}
+
+// deviceTypeFromMetadata derives a device type from the edge's reported OS
+// when the edge does not set one.
+func deviceTypeFromMetadata(metadata map[string]string) string {
+	if deviceType := strings.TrimSpace(metadata["device_type"]); deviceType != "" {
+		return deviceType
+	}
+	switch metadata["os"] {
+	case "darwin":
+		return "mac"
+	case "ios":
+		return "iphone"
+	default:
+		return metadata["os"]
+	}
+}
+
+// shortToolName strips the namespace from a tool name ("nodes.camera_snap"
+// becomes "camera_snap").
+func shortToolName(name string) string {
+	if idx := strings.LastIndex(name, "."); idx >= 0 {
+		return name[idx+1:]
+	}
+	return name
+}
+
+// NodeTarget selects a node explicitly or by capability.
+type NodeTarget struct {
+	NodeID     string
+	Capability nodes.Capability
+}
+
+// ParseNodeTarget parses "node:<id>" or "capability:<name>". A bare value is
+// treated as a node ID.
+func ParseNodeTarget(raw string) (NodeTarget, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return NodeTarget{}, errors.New("node target is required")
+	}
+	kind, value, ok := strings.Cut(raw, ":")
+	if !ok {
+		return NodeTarget{NodeID: raw}, nil
+	}
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return NodeTarget{}, fmt.Errorf("invalid node target %q", raw)
+	}
+	switch strings.ToLower(strings.TrimSpace(kind)) {
+	case "node":
+		return NodeTarget{NodeID: value}, nil
+	case "capability", "cap":
+		return NodeTarget{Capability: nodes.Capability(strings.ToLower(value))}, nil
+	default:
+		return NodeTarget{}, fmt.Errorf("invalid node target %q: expected node:<id> or capability:<name>", raw)
+	}
+}
+
+// String formats the target in its parseable form.
+func (t NodeTarget) String() string {
+	if t.NodeID != "" {
+		return "node:" + t.NodeID
+	}
+	return "capability:" + string(t.Capability)
+}
+
+// ListNodes returns all known nodes, online or not, ordered by ID.
+func (m *Manager) ListNodes() []*Node {
+	return m.nodes.list()
+}
+
+// GetNode returns a node by ID.
+func (m *Manager) GetNode(id string) (*Node, bool) {
+	return m.nodes.get(id)
+}
+
+// ResolveNode picks the online node for a target and, when toolName is set,
+// the node's tool it refers to. Tool names may omit their namespace
+// ("camera_snap" for "nodes.camera_snap"). Capability targets pick the
+// least busy matching node.
+func (m *Manager) ResolveNode(target NodeTarget, toolName string) (*Node, string, error) {
+	toolName = strings.TrimSpace(toolName)
+	if target.NodeID != "" {
+		node, ok := m.nodes.get(target.NodeID)
+		if !ok {
+			return nil, "", fmt.Errorf("unknown node %q", target.NodeID)
+		}
+		if !node.Online {
+			return nil, "", fmt.Errorf("node %q is offline (last seen %s)", node.ID, node.LastSeen.Format(time.RFC3339))
+		}
+		if target.Capability != "" && !node.HasCapability(target.Capability) {
+			return nil, "", fmt.Errorf("node %q does not have capability %q", node.ID, target.Capability)
+		}
+		if toolName == "" {
+			return node, "", nil
+		}
+		resolved, err := resolveNodeTool(node, toolName)
+		if err != nil {
+			return nil, "", err
+		}
+		return node, resolved, nil
+	}
+	if target.Capability == "" {
+		return nil, "", errors.New("node target needs a node id or capability")
+	}
+
+	var (
+		best     *Node
+		bestTool string
+		bestLoad float64
+	)
+	for _, node := range m.nodes.list() {
+		if !node.Online || !node.HasCapability(target.Capability) {
+			continue
+		}
+		resolved := ""
+		if toolName != "" {
+			var err error
+			if resolved, err = resolveNodeTool(node, toolName); err != nil {
+				continue
+			}
+		}
+		m.mu.RLock()
+		conn, ok := m.edges[node.EdgeID]
+		m.mu.RUnlock()
+		if !ok {
+			continue
+		}
+		load := m.edgeLoad(conn)
+		if best == nil || load < bestLoad {
+			best, bestTool, bestLoad = node, resolved, load
+		}
+	}
+	if best == nil {
+		if toolName != "" {
+			return nil, "", fmt.Errorf("no online node with capability %q provides %s", target.Capability, toolName)
+		}
+		return nil, "", fmt.Errorf("no online node with capability %q", target.Capability)
+	}
+	return best, bestTool, nil
+}
+
+func resolveNodeTool(node *Node, toolName string) (string, error) {
+	var matches []string
+	for _, name := range node.Tools {
+		if name == toolName {
+			return name, nil
+		}
+		if shortToolName(name) == toolName {
+			matches = append(matches, name)
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return "", fmt.Errorf("node %q does not provide tool %s", node.ID, toolName)
+	case 1:
+		return matches[0], nil
+	default:
+		return "", fmt.Errorf("tool %s is ambiguous on node %q: %s", toolName, node.ID, strings.Join(matches, ", "))
+	}
+}
+
+// ExecuteNodeTool runs a tool on the node selected by target. The node ID is
+// passed to the edge in the request metadata.
+func (m *Manager) ExecuteNodeTool(ctx context.Context, target NodeTarget, toolName, input string, opts ExecuteOptions) (*Node, *ToolExecutionResult, error) {
+	if strings.TrimSpace(toolName) == "" {
+		return nil, nil, errors.New("tool is required")
+	}
+	node, resolved, err := m.ResolveNode(target, toolName)
+	if err != nil {
+		return nil, nil, err
+	}
+	metadata := make(map[string]string, len(opts.Metadata)+1)
+	for k, v := range opts.Metadata {
+		metadata[k] = v
+	}
+	metadata[NodeIDMetadataKey] = node.ID
+	opts.Metadata = metadata
+
+	result, err := m.ExecuteTool(ctx, node.EdgeID, resolved, input, opts)
+	if err != nil {
+		return node, nil, err
+	}
+	return node, result, nil
+}
@@ -0,0 +1,160 @@
+package edge
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+
+	"github.com/haasonsaas/nexus/internal/nodes"
+	pb "github.com/haasonsaas/nexus/pkg/proto"
+)
+
+// echoToolStream answers tool requests with "<tool>@<node_id>".
+type echoToolStream struct {
+	grpc.ServerStream
+	manager *Manager
+	conn    *EdgeConnection
+}
+
+func (s *echoToolStream) Send(msg *pb.CoreMessage) error {
+	req := msg.GetToolRequest()
+	if req == nil {
+		return nil
+	}
+	go s.manager.handleToolResult(s.conn, &pb.ToolExecutionResult{
+		ExecutionId: req.ExecutionId,
+		Content:     req.ToolName + "@" + req.Metadata[NodeIDMetadataKey],
+	})
+	return nil
+}
+
+func (s *echoToolStream) Recv() (*pb.EdgeMessage, error) {
+	select {}
+}
+
+func connectNodeEdge(t *testing.T, manager *Manager, id string, tools []string, metadata map[string]string, active int32) {
+	t.Helper()
+	conn := &EdgeConnection{
+		ID:          id,
+		Name:        id,
+		ConnectedAt: time.Now(),
+		Tools:       make(map[string]*EdgeTool),
+		Metadata:    metadata,
+		Metrics:     &pb.EdgeMetrics{ActiveToolCount: active},
+		activeTools: make(map[string]*PendingTool),
+	}
+	for _, name := range tools {
+		conn.Tools[name] = &EdgeTool{Name: name, EdgeID: id}
+	}
+	conn.stream = &echoToolStream{manager: manager, conn: conn}
+	manager.mu.Lock()
+	manager.edges[id] = conn
+	manager.mu.Unlock()
+	manager.nodes.edgeConnected(conn)
+}
+
+func TestNodeRegistryImplicitAndDeclaredNodes(t *testing.T) {
+	manager := NewManager(DefaultManagerConfig(), &mockAuthenticator{}, nil)
+	connectNodeEdge(t, manager, "kitchen-pi", []string{"nodes.camera_snap", "edge.run_command"}, map[string]string{"os": "linux"}, 0)
+
+	declared, err := json.Marshal([]NodeDescriptor{
+		{ID: "macbook", Name: "MacBook", Tools: []string{"nodes.screen_capture", "nodes.computer_use"}},
+		{ID: "iphone", DeviceType: "iphone", Tools: []string{"phone.camera_snap", "phone.location_get"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	connectNodeEdge(t, manager, "mac-edge", []string{"nodes.screen_capture", "nodes.computer_use", "phone.camera_snap", "phone.location_get"}, map[string]string{"os": "darwin", NodesMetadataKey: string(declared)}, 0)
+
+	list := manager.ListNodes()
+	if len(list) != 3 || list[0].ID != "iphone" || list[1].ID != "kitchen-pi" || list[2].ID != "macbook" {
+		t.Fatalf("unexpected nodes %+v", list)
+	}
+	pi := list[1]
+	if pi.EdgeID != "kitchen-pi" || pi.DeviceType != "" || !pi.Online {
+		t.Fatalf("unexpected implicit node %+v", pi)
+	}
+	if !pi.HasCapability(nodes.CapCamera) || !pi.HasCapability(nodes.CapShell) || pi.HasCapability(nodes.CapScreen) {
+		t.Fatalf("unexpected inferred capabilities %v", pi.Capabilities)
+	}
+	mac := list[2]
+	if mac.Name != "MacBook" || mac.DeviceType != "mac" || mac.EdgeID != "mac-edge" {
+		t.Fatalf("unexpected declared node %+v", mac)
+	}
+	if !mac.HasCapability(nodes.CapComputerUse) || mac.HasCapability(nodes.CapCamera) {
+		t.Fatalf("unexpected mac capabilities %v", mac.Capabilities)
+	}
+
+	manager.removeEdge("mac-edge")
+	node, ok := manager.GetNode("iphone")
+	if !ok || node.Online {
+		t.Fatalf("expected iphone to be registered but offline, got %+v", node)
+	}
+	if _, _, err := manager.ResolveNode(NodeTarget{NodeID: "iphone"}, "camera_snap"); err == nil || !strings.Contains(err.Error(), "offline") {
+		t.Fatalf("expected offline error, got %v", err)
+	}
+}
+
+func TestExecuteNodeToolTargets(t *testing.T) {
+	manager := NewManager(DefaultManagerConfig(), &mockAuthenticator{}, nil)
+	connectNodeEdge(t, manager, "kitchen-pi", []string{"nodes.camera_snap"}, nil, 5)
+	connectNodeEdge(t, manager, "garage-pi", []string{"nodes.camera_snap", "nodes.shell_run"}, nil, 1)
+	ctx := context.Background()
+
+	node, result, err := manager.ExecuteNodeTool(ctx, NodeTarget{NodeID: "kitchen-pi"}, "camera_snap", "{}", ExecuteOptions{})
+	if err != nil {
+		t.Fatalf("ExecuteNodeTool: %v", err)
+	}
+	if node.ID != "kitchen-pi" || result.Content != "nodes.camera_snap@kitchen-pi" {
+		t.Fatalf("unexpected explicit routing: %s via %s", result.Content, node.ID)
+	}
+
+	// Capability targets pick the least busy node that has the tool.
+	target, err := ParseNodeTarget("capability:camera")
+	if err != nil {
+		t.Fatal(err)
+	}
+	node, result, err = manager.ExecuteNodeTool(ctx, target, "nodes.camera_snap", "{}", ExecuteOptions{})
+	if err != nil {
+		t.Fatalf("ExecuteNodeTool: %v", err)
+	}
+	if node.ID != "garage-pi" || result.Content != "nodes.camera_snap@garage-pi" {
+		t.Fatalf("unexpected capability routing: %s via %s", result.Content, node.ID)
+	}
+
+	if _, _, err := manager.ExecuteNodeTool(ctx, NodeTarget{NodeID: "kitchen-pi"}, "shell_run", "{}", ExecuteOptions{}); err == nil {
+		t.Fatal("expected error for a tool the node does not provide")
+	}
+	if _, _, err := manager.ExecuteNodeTool(ctx, NodeTarget{Capability: nodes.CapLocation}, "location_get", "{}", ExecuteOptions{}); err == nil {
+		t.Fatal("expected error when no node has the capability")
+	}
+	if _, _, err := manager.ResolveNode(NodeTarget{NodeID: "attic"}, ""); err == nil {
+		t.Fatal("expected error for unknown node")
+	}
+}
+
+func TestParseNodeTarget(t *testing.T) {
+	tests := []struct {
+		raw     string
+		want    NodeTarget
+		wantErr bool
+	}{
+		{raw: "node:kitchen-pi", want: NodeTarget{NodeID: "kitchen-pi"}},
+		{raw: "kitchen-pi", want: NodeTarget{NodeID: "kitchen-pi"}},
+		{raw: "capability:Camera", want: NodeTarget{Capability: nodes.CapCamera}},
+		{raw: "cap:shell", want: NodeTarget{Capability: nodes.CapShell}},
+		{raw: "device:x", wantErr: true},
+		{raw: "node:", wantErr: true},
+		{raw: "", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := ParseNodeTarget(tt.raw)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("ParseNodeTarget(%q) = %+v, %v", tt.raw, got, err)
+		}
+	}
+}
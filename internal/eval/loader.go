@@ -0,0 +1,77 @@
+package eval
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+
+	"gopkg.in/yaml.v3"
+)
+
+// LoadSuite reads a YAML scenario suite from disk.
+func LoadSuite(path string) (*Suite, error) {
+	if path == "" {
+		return nil, fmt.Errorf("suite path is required")
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read suite: %w", err)
+	}
+	return ParseSuite(data)
+}
+
+// ParseSuite parses and validates a YAML scenario suite.
+func ParseSuite(data []byte) (*Suite, error) {
+	var suite Suite
+	if err := yaml.Unmarshal(data, &suite); err != nil {
+		return nil, fmt.Errorf("parse suite: %w", err)
+	}
+	if len(suite.Scenarios) == 0 {
+		return nil, fmt.Errorf("suite has no scenarios")
+	}
+	if err := normalizeTools(suite.Tools); err != nil {
+		return nil, err
+	}
+	seen := make(map[string]struct{}, len(suite.Scenarios))
+	for i := range suite.Scenarios {
+		sc := &suite.Scenarios[i]
+		if sc.ID == "" {
+			return nil, fmt.Errorf("scenario %d missing id", i)
+		}
+		if _, dup := seen[sc.ID]; dup {
+			return nil, fmt.Errorf("duplicate scenario id %q", sc.ID)
+		}
+		seen[sc.ID] = struct{}{}
+		if sc.Input == "" {
+			return nil, fmt.Errorf("scenario %q missing input", sc.ID)
+		}
+		if err := normalizeTools(sc.Tools); err != nil {
+			return nil, fmt.Errorf("scenario %q: %w", sc.ID, err)
+		}
+		for _, pattern := range sc.Rubric.Regex {
+			if _, err := regexp.Compile(pattern); err != nil {
+				return nil, fmt.Errorf("scenario %q: invalid regex %q: %w", sc.ID, pattern, err)
+			}
+		}
+	}
+	return &suite, nil
+}
+
+func normalizeTools(tools []ToolSpec) error {
+	for i := range tools {
+		if tools[i].Name == "" {
+			return fmt.Errorf("tool %d missing name", i)
+		}
+		schema := tools[i].SchemaYAML
+		if schema == nil {
+			schema = map[string]any{"type": "object", "properties": map[string]any{}}
+		}
+		raw, err := json.Marshal(schema)
+		if err != nil {
+			return fmt.Errorf("tool %q: invalid schema: %w", tools[i].Name, err)
+		}
+		tools[i].Schema = raw
+	}
+	return nil
+}
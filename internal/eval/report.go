@@ -0,0 +1,234 @@
+package eval
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/haasonsaas/nexus/pkg/models"
+)
+
+// Report captures the results of running a suite against one provider.
+type Report struct {
+	GeneratedAt time.Time        `json:"generated_at"`
+	SuiteName   string           `json:"suite_name"`
+	Provider    string           `json:"provider"`
+	Model       string           `json:"model,omitempty"`
+	Summary     Summary          `json:"summary"`
+	Scenarios   []ScenarioResult `json:"scenarios"`
+}
+
+// ScenarioResult contains the outcome of a single scenario.
+type ScenarioResult struct {
+	ScenarioID     string            `json:"scenario_id"`
+	Input          string            `json:"input"`
+	Answer         string            `json:"answer"`
+	ToolCalls      []models.ToolCall `json:"tool_calls,omitempty"`
+	ToolScore      float64           `json:"tool_score"`
+	ToolMissing    []string          `json:"tool_missing,omitempty"`
+	RubricScore    float64           `json:"rubric_score"`
+	RubricFailures []string          `json:"rubric_failures,omitempty"`
+	Judged         bool              `json:"judged"`
+	JudgeScore     float64           `json:"judge_score"`
+	JudgeReason    string            `json:"judge_reason,omitempty"`
+	Score          float64           `json:"score"`
+	Passed         bool              `json:"passed"`
+	Error          string            `json:"error,omitempty"`
+	Duration       time.Duration     `json:"duration"`
+	InputTokens    int               `json:"input_tokens"`
+	OutputTokens   int               `json:"output_tokens"`
+}
+
+// Summary aggregates scenario results.
+type Summary struct {
+	Scenarios    int           `json:"scenarios"`
+	Passed       int           `json:"passed"`
+	Failed       int           `json:"failed"`
+	Errors       int           `json:"errors"`
+	PassRate     float64       `json:"pass_rate"`
+	AvgScore     float64       `json:"avg_score"`
+	TotalTime    time.Duration `json:"total_time"`
+	InputTokens  int           `json:"input_tokens"`
+	OutputTokens int           `json:"output_tokens"`
+}
+
+func summarize(results []ScenarioResult) Summary {
+	s := Summary{Scenarios: len(results)}
+	if len(results) == 0 {
+		return s
+	}
+	for _, r := range results {
+		switch {
+		case r.Error != "":
+			s.Errors++
+		case r.Passed:
+			s.Passed++
+		default:
+			s.Failed++
+		}
+		s.AvgScore += r.Score
+		s.TotalTime += r.Duration
+		s.InputTokens += r.InputTokens
+		s.OutputTokens += r.OutputTokens
+	}
+	count := float64(len(results))
+	s.AvgScore /= count
+	s.PassRate = float64(s.Passed) / count
+	return s
+}
+
+// WriteJSON writes the report as indented JSON.
+func (r *Report) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(r)
+}
+
+type junitTestSuites struct {
+	XMLName xml.Name         `xml:"testsuites"`
+	Suites  []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name     string          `xml:"name,attr"`
+	Tests    int             `xml:"tests,attr"`
+	Failures int             `xml:"failures,attr"`
+	Errors   int             `xml:"errors,attr"`
+	Time     string          `xml:"time,attr"`
+	Cases    []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Time      string        `xml:"time,attr"`
+	Failure   *junitMessage `xml:"failure,omitempty"`
+	Error     *junitMessage `xml:"error,omitempty"`
+}
+
+type junitMessage struct {
+	Message string `xml:"message,attr"`
+	Body    string `xml:",chardata"`
+}
+
+// WriteJUnit writes the given reports as a JUnit XML document, one test suite per report.
+func WriteJUnit(w io.Writer, reports ...*Report) error {
+	doc := junitTestSuites{}
+	for _, r := range reports {
+		if r == nil {
+			continue
+		}
+		name := r.SuiteName
+		if r.Provider != "" {
+			name = fmt.Sprintf("%s [%s]", name, r.label())
+		}
+		suite := junitTestSuite{
+			Name:     name,
+			Tests:    r.Summary.Scenarios,
+			Failures: r.Summary.Failed,
+			Errors:   r.Summary.Errors,
+			Time:     seconds(r.Summary.TotalTime),
+		}
+		for _, sc := range r.Scenarios {
+			tc := junitTestCase{Name: sc.ScenarioID, ClassName: name, Time: seconds(sc.Duration)}
+			switch {
+			case sc.Error != "":
+				tc.Error = &junitMessage{Message: sc.Error}
+			case !sc.Passed:
+				reasons := append(append([]string{}, sc.ToolMissing...), sc.RubricFailures...)
+				if sc.JudgeReason != "" {
+					reasons = append(reasons, "judge: "+sc.JudgeReason)
+				}
+				tc.Failure = &junitMessage{
+					Message: fmt.Sprintf("score %.2f", sc.Score),
+					Body:    strings.Join(reasons, "\n"),
+				}
+			}
+			suite.Cases = append(suite.Cases, tc)
+		}
+		doc.Suites = append(doc.Suites, suite)
+	}
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(doc); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+func (r *Report) label() string {
+	if r.Model != "" {
+		return r.Provider + "/" + r.Model
+	}
+	return r.Provider
+}
+
+func seconds(d time.Duration) string {
+	return fmt.Sprintf("%.3f", d.Seconds())
+}
+
+// Comparison contrasts two reports for the same suite.
+type Comparison struct {
+	Baseline  string               `json:"baseline"`
+	Candidate string               `json:"candidate"`
+	Scenarios []ScenarioComparison `json:"scenarios"`
+	// ScoreDelta is candidate average score minus baseline average score.
+	ScoreDelta    float64 `json:"score_delta"`
+	PassRateDelta float64 `json:"pass_rate_delta"`
+	Improved      int     `json:"improved"`
+	Regressed     int     `json:"regressed"`
+}
+
+// ScenarioComparison contrasts one scenario across two reports.
+type ScenarioComparison struct {
+	ScenarioID      string        `json:"scenario_id"`
+	BaselineScore   float64       `json:"baseline_score"`
+	CandidateScore  float64       `json:"candidate_score"`
+	BaselinePassed  bool          `json:"baseline_passed"`
+	CandidatePassed bool          `json:"candidate_passed"`
+	LatencyDelta    time.Duration `json:"latency_delta"`
+}
+
+// Compare builds a comparison between a baseline and a candidate report.
+// Only scenarios present in both reports are compared.
+func Compare(baseline, candidate *Report) *Comparison {
+	cmp := &Comparison{
+		Baseline:      baseline.label(),
+		Candidate:     candidate.label(),
+		ScoreDelta:    candidate.Summary.AvgScore - baseline.Summary.AvgScore,
+		PassRateDelta: candidate.Summary.PassRate - baseline.Summary.PassRate,
+	}
+	byID := make(map[string]ScenarioResult, len(candidate.Scenarios))
+	for _, sc := range candidate.Scenarios {
+		byID[sc.ScenarioID] = sc
+	}
+	for _, base := range baseline.Scenarios {
+		cand, ok := byID[base.ScenarioID]
+		if !ok {
+			continue
+		}
+		row := ScenarioComparison{
+			ScenarioID:      base.ScenarioID,
+			BaselineScore:   base.Score,
+			CandidateScore:  cand.Score,
+			BaselinePassed:  base.Passed,
+			CandidatePassed: cand.Passed,
+			LatencyDelta:    cand.Duration - base.Duration,
+		}
+		switch {
+		case cand.Score > base.Score:
+			cmp.Improved++
+		case cand.Score < base.Score:
+			cmp.Regressed++
+		}
+		cmp.Scenarios = append(cmp.Scenarios, row)
+	}
+	return cmp
+}
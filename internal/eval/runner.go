@@ -0,0 +1,280 @@
+package eval
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/haasonsaas/nexus/internal/agent"
+	"github.com/haasonsaas/nexus/pkg/models"
+)
+
+const (
+	defaultMaxTurns  = 4
+	defaultMaxTokens = 1024
+)
+
+// Options controls scenario execution.
+type Options struct {
+	// Model overrides the provider's default model.
+	Model string
+	// MaxTokens limits each completion (default 1024).
+	MaxTokens int
+	// MaxTurns bounds the tool-call loop per scenario (default 4).
+	MaxTurns int
+	// Filter restricts execution to scenarios whose ID contains this substring.
+	Filter string
+	// Concurrency is how many scenarios run at once (default 1). Running
+	// them together lets a batch provider submit each turn as one batch.
+	Concurrency int
+}
+
+// Runner executes a suite against a single provider.
+type Runner struct {
+	provider agent.LLMProvider
+	judge    Judge
+	options  Options
+}
+
+// NewRunner creates a runner for the given provider.
+func NewRunner(provider agent.LLMProvider, opts *Options) *Runner {
+	resolved := Options{MaxTokens: defaultMaxTokens, MaxTurns: defaultMaxTurns}
+	if opts != nil {
+		if opts.MaxTokens > 0 {
+			resolved.MaxTokens = opts.MaxTokens
+		}
+		if opts.MaxTurns > 0 {
+			resolved.MaxTurns = opts.MaxTurns
+		}
+		resolved.Model = opts.Model
+		resolved.Filter = opts.Filter
+		resolved.Concurrency = opts.Concurrency
+	}
+	return &Runner{provider: provider, options: resolved}
+}
+
+// WithJudge attaches a judge used for scenarios with a judge rubric.
+func (r *Runner) WithJudge(judge Judge) *Runner {
+	r.judge = judge
+	return r
+}
+
+// Run executes every scenario in the suite and returns a report.
+func (r *Runner) Run(ctx context.Context, suite *Suite) (*Report, error) {
+	if suite == nil {
+		return nil, fmt.Errorf("suite is nil")
+	}
+	if r.provider == nil {
+		return nil, fmt.Errorf("provider is nil")
+	}
+	report := &Report{
+		GeneratedAt: time.Now(),
+		SuiteName:   suite.Name,
+		Provider:    r.provider.Name(),
+		Model:       r.options.Model,
+	}
+	var selected []Scenario
+	for _, sc := range suite.Scenarios {
+		if r.options.Filter != "" && !strings.Contains(sc.ID, r.options.Filter) {
+			continue
+		}
+		selected = append(selected, sc)
+	}
+	report.Scenarios = make([]ScenarioResult, len(selected))
+	slots := make(chan struct{}, max(r.options.Concurrency, 1))
+	var wg sync.WaitGroup
+	for i, sc := range selected {
+		slots <- struct{}{}
+		if err := ctx.Err(); err != nil {
+			wg.Wait()
+			return nil, err
+		}
+		wg.Add(1)
+		go func() {
+			defer func() { <-slots; wg.Done() }()
+			report.Scenarios[i] = r.runScenario(ctx, suite, sc)
+		}()
+	}
+	wg.Wait()
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	report.Summary = summarize(report.Scenarios)
+	return report, nil
+}
+
+func (r *Runner) runScenario(ctx context.Context, suite *Suite, sc Scenario) ScenarioResult {
+	result := ScenarioResult{ScenarioID: sc.ID, Input: sc.Input}
+	start := time.Now()
+	answer, calls, usage, err := r.execute(ctx, suite, sc)
+	result.Duration = time.Since(start)
+	result.Answer = answer
+	result.ToolCalls = calls
+	result.InputTokens = usage.input
+	result.OutputTokens = usage.output
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+
+	result.ToolScore, result.ToolMissing = scoreToolCalls(sc.ExpectedTools, calls)
+	scores := make([]float64, 0, 3)
+	if len(sc.ExpectedTools) > 0 {
+		scores = append(scores, result.ToolScore)
+	}
+	if sc.Rubric.HasDeterministicChecks() {
+		result.RubricScore, result.RubricFailures = scoreRubric(sc.Rubric, answer)
+		scores = append(scores, result.RubricScore)
+	}
+	if sc.Rubric.Judge != "" {
+		if r.judge == nil {
+			result.RubricFailures = append(result.RubricFailures, "judge rubric skipped: no judge configured")
+		} else {
+			score, reason, err := r.judge.Score(ctx, sc, answer)
+			if err != nil {
+				result.Error = fmt.Sprintf("judge: %v", err)
+				return result
+			}
+			result.Judged = true
+			result.JudgeScore = score
+			result.JudgeReason = reason
+			scores = append(scores, score)
+		}
+	}
+
+	result.Score = 1
+	if len(scores) > 0 {
+		total := 0.0
+		for _, s := range scores {
+			total += s
+		}
+		result.Score = total / float64(len(scores))
+	}
+	result.Passed = result.Score >= sc.Rubric.threshold()
+	return result
+}
+
+type tokenUsage struct {
+	input  int
+	output int
+}
+
+func (r *Runner) execute(ctx context.Context, suite *Suite, sc Scenario) (string, []models.ToolCall, tokenUsage, error) {
+	specs := mergeTools(suite.Tools, sc.Tools)
+	tools := make([]agent.Tool, 0, len(specs))
+	for _, spec := range specs {
+		tools = append(tools, stubTool{spec: spec})
+	}
+	system := sc.System
+	if system == "" {
+		system = suite.System
+	}
+
+	messages := []agent.CompletionMessage{{Role: "user", Content: sc.Input}}
+	var (
+		calls []models.ToolCall
+		usage tokenUsage
+	)
+	for turn := 0; turn < r.options.MaxTurns; turn++ {
+		req := &agent.CompletionRequest{
+			Model:     r.options.Model,
+			System:    system,
+			Messages:  messages,
+			Tools:     tools,
+			MaxTokens: r.options.MaxTokens,
+		}
+		text, turnCalls, turnUsage, err := collect(ctx, r.provider, req)
+		usage.input += turnUsage.input
+		usage.output += turnUsage.output
+		if err != nil {
+			return text, calls, usage, err
+		}
+		if len(turnCalls) == 0 {
+			return text, calls, usage, nil
+		}
+		calls = append(calls, turnCalls...)
+		results := make([]models.ToolResult, 0, len(turnCalls))
+		for _, call := range turnCalls {
+			results = append(results, stubResult(specs, call))
+		}
+		messages = append(messages,
+			agent.CompletionMessage{Role: "assistant", Content: text, ToolCalls: turnCalls},
+			agent.CompletionMessage{Role: "tool", ToolResults: results},
+		)
+	}
+	return "", calls, usage, fmt.Errorf("exceeded %d turns without a final answer", r.options.MaxTurns)
+}
+
+func collect(ctx context.Context, provider agent.LLMProvider, req *agent.CompletionRequest) (string, []models.ToolCall, tokenUsage, error) {
+	var usage tokenUsage
+	ch, err := provider.Complete(ctx, req)
+	if err != nil {
+		return "", nil, usage, err
+	}
+	var (
+		sb    strings.Builder
+		calls []models.ToolCall
+	)
+	for chunk := range ch {
+		if chunk == nil {
+			continue
+		}
+		if chunk.Error != nil {
+			return sb.String(), calls, usage, chunk.Error
+		}
+		if chunk.ToolCall != nil {
+			calls = append(calls, *chunk.ToolCall)
+		}
+		sb.WriteString(chunk.Text)
+		if chunk.Done {
+			usage.input += chunk.InputTokens
+			usage.output += chunk.OutputTokens
+		}
+	}
+	return strings.TrimSpace(sb.String()), calls, usage, nil
+}
+
+func mergeTools(base, overrides []ToolSpec) []ToolSpec {
+	merged := make([]ToolSpec, 0, len(base)+len(overrides))
+	index := make(map[string]int, len(base)+len(overrides))
+	for _, list := range [][]ToolSpec{base, overrides} {
+		for _, spec := range list {
+			if i, ok := index[spec.Name]; ok {
+				merged[i] = spec
+				continue
+			}
+			index[spec.Name] = len(merged)
+			merged = append(merged, spec)
+		}
+	}
+	return merged
+}
+
+func stubResult(specs []ToolSpec, call models.ToolCall) models.ToolResult {
+	for _, spec := range specs {
+		if spec.Name == call.Name {
+			content := spec.Response
+			if content == "" {
+				content = "ok"
+			}
+			return models.ToolResult{ToolCallID: call.ID, Content: content}
+		}
+	}
+	return models.ToolResult{ToolCallID: call.ID, Content: fmt.Sprintf("unknown tool %q", call.Name), IsError: true}
+}
+
+// stubTool exposes a ToolSpec to providers without executing anything.
+type stubTool struct {
+	spec ToolSpec
+}
+
+func (t stubTool) Name() string            { return t.spec.Name }
+func (t stubTool) Description() string     { return t.spec.Description }
+func (t stubTool) Schema() json.RawMessage { return t.spec.Schema }
+
+func (t stubTool) Execute(context.Context, json.RawMessage) (*agent.ToolResult, error) {
+	return &agent.ToolResult{Content: t.spec.Response}, nil
+}
@@ -0,0 +1,206 @@
+package eval
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/haasonsaas/nexus/internal/agent"
+	"github.com/haasonsaas/nexus/pkg/models"
+)
+
+// scriptedProvider replies with a tool call on the first turn when tools are
+// offered, then with a fixed answer.
+type scriptedProvider struct {
+	name     string
+	toolCall *models.ToolCall
+	answer   string
+	requests []*agent.CompletionRequest
+}
+
+func (p *scriptedProvider) Complete(_ context.Context, req *agent.CompletionRequest) (<-chan *agent.CompletionChunk, error) {
+	p.requests = append(p.requests, req)
+	ch := make(chan *agent.CompletionChunk, 3)
+	last := req.Messages[len(req.Messages)-1]
+	if p.toolCall != nil && len(req.Tools) > 0 && last.Role == "user" {
+		ch <- &agent.CompletionChunk{ToolCall: p.toolCall}
+	} else {
+		ch <- &agent.CompletionChunk{Text: p.answer}
+	}
+	ch <- &agent.CompletionChunk{Done: true, InputTokens: 10, OutputTokens: 5}
+	close(ch)
+	return ch, nil
+}
+
+func (p *scriptedProvider) Name() string          { return p.name }
+func (p *scriptedProvider) Models() []agent.Model { return nil }
+func (p *scriptedProvider) SupportsTools() bool   { return true }
+
+const testSuite = `
+name: weather
+tools:
+  - name: get_weather
+    description: Look up the weather
+    schema:
+      type: object
+      properties:
+        city: {type: string}
+    response: "Sunny, 21C"
+scenarios:
+  - id: weather-paris
+    input: What's the weather in Paris?
+    expected_tools:
+      - name: get_weather
+        args_contain: ["Paris"]
+    rubric:
+      contains: ["sunny"]
+      regex: ["\\d+C"]
+  - id: greeting
+    input: Say hello
+    rubric:
+      contains: ["hello"]
+      not_contains: ["goodbye"]
+`
+
+func TestRunnerScoresToolsAndRubric(t *testing.T) {
+	suite, err := ParseSuite([]byte(testSuite))
+	if err != nil {
+		t.Fatalf("ParseSuite: %v", err)
+	}
+	provider := &scriptedProvider{
+		name:     "fake",
+		toolCall: &models.ToolCall{ID: "call-1", Name: "get_weather", Input: json.RawMessage(`{"city":"Paris"}`)},
+		answer:   "It is sunny and 21C.",
+	}
+	report, err := NewRunner(provider, nil).Run(context.Background(), suite)
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if report.Summary.Scenarios != 2 {
+		t.Fatalf("scenarios = %d, want 2", report.Summary.Scenarios)
+	}
+	weather := report.Scenarios[0]
+	if !weather.Passed || weather.ToolScore != 1 || weather.RubricScore != 1 {
+		t.Fatalf("weather scenario = %+v, want pass", weather)
+	}
+	if weather.InputTokens != 20 {
+		t.Fatalf("input tokens = %d, want 20", weather.InputTokens)
+	}
+	toolMsg := provider.requests[1].Messages[2]
+	if toolMsg.Role != "tool" || toolMsg.ToolResults[0].Content != "Sunny, 21C" {
+		t.Fatalf("tool result message = %+v", toolMsg)
+	}
+	greeting := report.Scenarios[1]
+	if greeting.Passed || greeting.RubricScore != 0.5 {
+		t.Fatalf("greeting scenario = %+v, want failure with score 0.5", greeting)
+	}
+}
+
+// barrierProvider answers only once want requests are waiting, so a run
+// finishes only if scenarios run concurrently.
+type barrierProvider struct {
+	want    int
+	mu      sync.Mutex
+	waiting int
+	release chan struct{}
+}
+
+func (p *barrierProvider) Complete(ctx context.Context, req *agent.CompletionRequest) (<-chan *agent.CompletionChunk, error) {
+	p.mu.Lock()
+	p.waiting++
+	if p.waiting == p.want {
+		close(p.release)
+	}
+	p.mu.Unlock()
+	ch := make(chan *agent.CompletionChunk, 2)
+	go func() {
+		defer close(ch)
+		select {
+		case <-p.release:
+			ch <- &agent.CompletionChunk{Text: "hello from " + req.Messages[0].Content}
+			ch <- &agent.CompletionChunk{Done: true}
+		case <-ctx.Done():
+			ch <- &agent.CompletionChunk{Error: ctx.Err()}
+		}
+	}()
+	return ch, nil
+}
+
+func (p *barrierProvider) Name() string          { return "barrier" }
+func (p *barrierProvider) Models() []agent.Model { return nil }
+func (p *barrierProvider) SupportsTools() bool   { return true }
+
+func TestRunnerConcurrencyKeepsScenarioOrder(t *testing.T) {
+	suite, err := ParseSuite([]byte(testSuite))
+	if err != nil {
+		t.Fatalf("ParseSuite: %v", err)
+	}
+	suite.Tools = nil
+	for i := range suite.Scenarios {
+		suite.Scenarios[i].ExpectedTools = nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	provider := &barrierProvider{want: 2, release: make(chan struct{})}
+	report, err := NewRunner(provider, &Options{Concurrency: 2}).Run(ctx, suite)
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if len(report.Scenarios) != 2 || report.Scenarios[0].ScenarioID != "weather-paris" || report.Scenarios[1].ScenarioID != "greeting" {
+		t.Fatalf("scenarios = %+v", report.Scenarios)
+	}
+	if report.Scenarios[1].Answer != "hello from Say hello" || report.Scenarios[1].Error != "" {
+		t.Fatalf("greeting = %+v", report.Scenarios[1])
+	}
+}
+
+func TestScoreToolCallsArgs(t *testing.T) {
+	expected := []ExpectedToolCall{{Name: "search", ArgsContain: []string{"golang"}}}
+	calls := []models.ToolCall{{Name: "search", Input: json.RawMessage(`{"q":"rust"}`)}}
+	score, missing := scoreToolCalls(expected, calls)
+	if score != 0 || len(missing) != 1 {
+		t.Fatalf("score=%v missing=%v, want 0 and one missing", score, missing)
+	}
+}
+
+func TestCompareAndJUnit(t *testing.T) {
+	base := &Report{SuiteName: "s", Provider: "a", Scenarios: []ScenarioResult{
+		{ScenarioID: "one", Score: 0.5},
+		{ScenarioID: "two", Score: 1, Passed: true},
+	}}
+	base.Summary = summarize(base.Scenarios)
+	cand := &Report{SuiteName: "s", Provider: "b", Scenarios: []ScenarioResult{
+		{ScenarioID: "one", Score: 1, Passed: true},
+		{ScenarioID: "two", Error: "boom"},
+	}}
+	cand.Summary = summarize(cand.Scenarios)
+
+	cmp := Compare(base, cand)
+	if cmp.Improved != 1 || cmp.Regressed != 1 {
+		t.Fatalf("improved=%d regressed=%d, want 1/1", cmp.Improved, cmp.Regressed)
+	}
+
+	var buf bytes.Buffer
+	if err := WriteJUnit(&buf, base, cand); err != nil {
+		t.Fatalf("WriteJUnit: %v", err)
+	}
+	out := buf.String()
+	for _, want := range []string{`<testsuite name="s [a]"`, `<failure message="score 0.50">`, `<error message="boom">`} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("junit output missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestParseSuiteValidation(t *testing.T) {
+	if _, err := ParseSuite([]byte("scenarios:\n  - id: a\n")); err == nil {
+		t.Fatal("expected error for missing input")
+	}
+	if _, err := ParseSuite([]byte("scenarios:\n  - id: a\n    input: x\n    rubric:\n      regex: ['(']\n")); err == nil {
+		t.Fatal("expected error for invalid regex")
+	}
+}
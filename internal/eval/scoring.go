@@ -0,0 +1,148 @@
+package eval
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/haasonsaas/nexus/internal/agent"
+	"github.com/haasonsaas/nexus/pkg/models"
+)
+
+// Judge scores an answer against a scenario's natural-language rubric.
+type Judge interface {
+	Score(ctx context.Context, sc Scenario, answer string) (float64, string, error)
+}
+
+// scoreToolCalls returns the fraction of expected tool calls that were made
+// along with a description of each missing call.
+func scoreToolCalls(expected []ExpectedToolCall, calls []models.ToolCall) (float64, []string) {
+	if len(expected) == 0 {
+		return 1, nil
+	}
+	used := make([]bool, len(calls))
+	var missing []string
+	for _, exp := range expected {
+		found := false
+		for i, call := range calls {
+			if used[i] || call.Name != exp.Name {
+				continue
+			}
+			if !containsAll(string(call.Input), exp.ArgsContain) {
+				continue
+			}
+			used[i] = true
+			found = true
+			break
+		}
+		if !found {
+			desc := exp.Name
+			if len(exp.ArgsContain) > 0 {
+				desc += fmt.Sprintf(" (args containing %s)", strings.Join(exp.ArgsContain, ", "))
+			}
+			missing = append(missing, desc)
+		}
+	}
+	return float64(len(expected)-len(missing)) / float64(len(expected)), missing
+}
+
+// scoreRubric applies substring and regex checks, returning the fraction passed.
+func scoreRubric(rubric Rubric, answer string) (float64, []string) {
+	total := len(rubric.Contains) + len(rubric.NotContains) + len(rubric.Regex)
+	if total == 0 {
+		return 1, nil
+	}
+	lower := strings.ToLower(answer)
+	var failures []string
+	for _, want := range rubric.Contains {
+		if !strings.Contains(lower, strings.ToLower(want)) {
+			failures = append(failures, fmt.Sprintf("missing %q", want))
+		}
+	}
+	for _, unwanted := range rubric.NotContains {
+		if strings.Contains(lower, strings.ToLower(unwanted)) {
+			failures = append(failures, fmt.Sprintf("unexpected %q", unwanted))
+		}
+	}
+	for _, pattern := range rubric.Regex {
+		re, err := regexp.Compile(pattern)
+		if err != nil || !re.MatchString(answer) {
+			failures = append(failures, fmt.Sprintf("no match for /%s/", pattern))
+		}
+	}
+	return float64(total-len(failures)) / float64(total), failures
+}
+
+func containsAll(s string, subs []string) bool {
+	for _, sub := range subs {
+		if !strings.Contains(s, sub) {
+			return false
+		}
+	}
+	return true
+}
+
+var judgeScorePattern = regexp.MustCompile(`[-+]?[0-9]*\.?[0-9]+`)
+
+// LLMJudge scores answers using an LLM provider.
+type LLMJudge struct {
+	provider  agent.LLMProvider
+	model     string
+	maxTokens int
+}
+
+// NewLLMJudge creates a judge backed by the given provider and model.
+func NewLLMJudge(provider agent.LLMProvider, model string) *LLMJudge {
+	return &LLMJudge{provider: provider, model: model, maxTokens: 256}
+}
+
+// Score asks the judge model to rate the answer between 0 and 1.
+func (j *LLMJudge) Score(ctx context.Context, sc Scenario, answer string) (float64, string, error) {
+	if j == nil || j.provider == nil {
+		return 0, "", fmt.Errorf("llm judge provider is nil")
+	}
+	if strings.TrimSpace(answer) == "" {
+		return 0, "empty answer", nil
+	}
+	req := &agent.CompletionRequest{
+		Model: j.model,
+		System: "You are a strict evaluator. Reply with a score between 0 and 1 on the first line, " +
+			"followed by a one-sentence justification.",
+		Messages: []agent.CompletionMessage{{
+			Role: "user",
+			Content: fmt.Sprintf("User message:\n%s\n\nRubric:\n%s\n\nAnswer:\n%s\n\nScore (0-1):",
+				sc.Input, sc.Rubric.Judge, answer),
+		}},
+		MaxTokens: j.maxTokens,
+	}
+	text, _, _, err := collect(ctx, j.provider, req)
+	if err != nil {
+		return 0, "", err
+	}
+	score, err := parseJudgeScore(text)
+	if err != nil {
+		return 0, "", err
+	}
+	reason := ""
+	if idx := strings.Index(text, "\n"); idx >= 0 {
+		reason = strings.TrimSpace(text[idx+1:])
+	}
+	return score, reason, nil
+}
+
+func parseJudgeScore(text string) (float64, error) {
+	match := judgeScorePattern.FindString(strings.TrimSpace(text))
+	if match == "" {
+		return 0, fmt.Errorf("no numeric score in judge response: %q", text)
+	}
+	val, err := strconv.ParseFloat(match, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid score %q: %w", match, err)
+	}
+	if val < 0 || val > 1 {
+		return 0, fmt.Errorf("score out of range: %v", val)
+	}
+	return val, nil
+}
@@ -0,0 +1,72 @@
+// Package eval runs scenario suites against LLM providers and scores the results.
+//
+// A suite is a YAML file of scenarios. Each scenario supplies an input message,
+// optional stub tools the model may call, the tool calls it is expected to make,
+// and a rubric used to score the final answer.
+package eval
+
+import "encoding/json"
+
+// Suite defines a set of evaluation scenarios.
+type Suite struct {
+	Version   int        `yaml:"version" json:"version"`
+	Name      string     `yaml:"name" json:"name"`
+	System    string     `yaml:"system" json:"system,omitempty"`
+	Tools     []ToolSpec `yaml:"tools" json:"tools,omitempty"`
+	Scenarios []Scenario `yaml:"scenarios" json:"scenarios"`
+}
+
+// Scenario is a single evaluation case.
+type Scenario struct {
+	ID            string             `yaml:"id" json:"id"`
+	Description   string             `yaml:"description" json:"description,omitempty"`
+	System        string             `yaml:"system" json:"system,omitempty"`
+	Input         string             `yaml:"input" json:"input"`
+	Tools         []ToolSpec         `yaml:"tools" json:"tools,omitempty"`
+	ExpectedTools []ExpectedToolCall `yaml:"expected_tools" json:"expected_tools,omitempty"`
+	Rubric        Rubric             `yaml:"rubric" json:"rubric"`
+}
+
+// ToolSpec declares a stub tool offered to the model during a scenario.
+// The tool is never executed; Response is returned verbatim as its result.
+type ToolSpec struct {
+	Name        string          `yaml:"name" json:"name"`
+	Description string          `yaml:"description" json:"description"`
+	Schema      json.RawMessage `yaml:"-" json:"schema,omitempty"`
+	SchemaYAML  map[string]any  `yaml:"schema" json:"-"`
+	Response    string          `yaml:"response" json:"response,omitempty"`
+}
+
+// ExpectedToolCall describes a tool call the model should make.
+type ExpectedToolCall struct {
+	Name string `yaml:"name" json:"name"`
+	// ArgsContain lists substrings that must appear in the raw JSON arguments.
+	ArgsContain []string `yaml:"args_contain" json:"args_contain,omitempty"`
+}
+
+// Rubric describes how a final answer is scored.
+type Rubric struct {
+	Contains    []string `yaml:"contains" json:"contains,omitempty"`
+	NotContains []string `yaml:"not_contains" json:"not_contains,omitempty"`
+	Regex       []string `yaml:"regex" json:"regex,omitempty"`
+	// Judge is a natural-language rubric scored by an LLM judge.
+	Judge string `yaml:"judge" json:"judge,omitempty"`
+	// PassThreshold is the minimum score for the scenario to pass (default 1.0
+	// for deterministic checks, 0.7 when a judge rubric is present).
+	PassThreshold float64 `yaml:"pass_threshold" json:"pass_threshold,omitempty"`
+}
+
+// HasDeterministicChecks reports whether the rubric has regex or substring checks.
+func (r Rubric) HasDeterministicChecks() bool {
+	return len(r.Contains) > 0 || len(r.NotContains) > 0 || len(r.Regex) > 0
+}
+
+func (r Rubric) threshold() float64 {
+	if r.PassThreshold > 0 {
+		return r.PassThreshold
+	}
+	if r.Judge != "" {
+		return 0.7
+	}
+	return 1.0
+}
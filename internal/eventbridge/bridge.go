@@ -0,0 +1,309 @@
+// Package eventbridge publishes selected gateway events (messages, runs,
+// tool calls, approvals) to an external message queue such as NATS or
+// Kafka, so analytics pipelines can consume them without polling the
+// gateway.
+//
+// Events are wrapped in a schema-versioned envelope (see event.proto) and
+// encoded as JSON or protobuf. Publishing is asynchronous: events are
+// queued in a bounded buffer and dropped, with a metric, when the queue is
+// full, so a slow or unavailable broker never blocks message handling.
+package eventbridge
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Event types published by the gateway.
+const (
+	TypeMessageReceived   = "message.received"
+	TypeMessageSent       = "message.sent"
+	TypeRunStarted        = "run.started"
+	TypeRunFinished       = "run.finished"
+	TypeRunFailed         = "run.failed"
+	TypeToolStarted       = "tool.started"
+	TypeToolFinished      = "tool.finished"
+	TypeApprovalRequested = "approval.requested"
+	TypeApprovalDecided   = "approval.decided"
+)
+
+// TypePlaceholder is replaced with the event type in Config.Subject.
+const TypePlaceholder = "{type}"
+
+// Event is a gateway event to publish.
+type Event struct {
+	ID        string
+	Type      string
+	Time      time.Time
+	SessionID string
+	RunID     string
+	AgentID   string
+	Channel   string
+	// Data holds type-specific fields. Values must be JSON-encodable.
+	Data map[string]any
+}
+
+// Message is an encoded event handed to a Publisher.
+type Message struct {
+	// Subject is the NATS subject or Kafka topic.
+	Subject string
+	// Key partitions Kafka records; events of a session share a key so
+	// they stay ordered.
+	Key string
+	// Headers carry the content type and schema version where the
+	// transport supports headers.
+	Headers map[string]string
+	Data    []byte
+}
+
+// Publisher delivers encoded events to a broker.
+type Publisher interface {
+	Publish(ctx context.Context, msg Message) error
+	Close() error
+}
+
+// Config controls what the bridge publishes and how.
+type Config struct {
+	// Format is FormatJSON (default) or FormatProtobuf.
+	Format string
+	// Subject is the NATS subject or Kafka topic. TypePlaceholder is
+	// replaced with the event type, e.g. "nexus.events.{type}".
+	Subject string
+	// Events lists the event types to publish. Entries ending in "*"
+	// match by prefix, e.g. "tool.*". Empty publishes every type.
+	Events []string
+	// Channels and Agents restrict publishing to events from these
+	// channels or agents. Events without a channel or agent pass.
+	Channels []string
+	Agents   []string
+	// Source identifies this gateway in the envelope.
+	Source string
+	// BufferSize bounds queued events (default 10000).
+	BufferSize int
+	// Retries is how many times a failed publish is retried before the
+	// event is dropped (default 2).
+	Retries int
+	// Timeout bounds each publish attempt (default 10s).
+	Timeout time.Duration
+}
+
+// Bridge filters, encodes, and publishes events in the background.
+type Bridge struct {
+	cfg       Config
+	publisher Publisher
+	logger    *slog.Logger
+	metrics   *Metrics
+	queue     chan Event
+
+	mu      sync.Mutex
+	started bool
+	closed  bool
+	cancel  context.CancelFunc
+	done    chan struct{}
+}
+
+// New creates a bridge that publishes through publisher.
+func New(cfg Config, publisher Publisher, logger *slog.Logger) (*Bridge, error) {
+	if publisher == nil {
+		return nil, errors.New("eventbridge: publisher is required")
+	}
+	if strings.TrimSpace(cfg.Subject) == "" {
+		return nil, errors.New("eventbridge: subject is required")
+	}
+	switch cfg.Format {
+	case "":
+		cfg.Format = FormatJSON
+	case FormatJSON, FormatProtobuf:
+	default:
+		return nil, fmt.Errorf("eventbridge: unknown format %q (use json or protobuf)", cfg.Format)
+	}
+	if logger == nil {
+		logger = slog.Default()
+	}
+	if cfg.BufferSize <= 0 {
+		cfg.BufferSize = 10000
+	}
+	if cfg.Retries < 0 {
+		cfg.Retries = 0
+	} else if cfg.Retries == 0 {
+		cfg.Retries = 2
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = 10 * time.Second
+	}
+	return &Bridge{
+		cfg:       cfg,
+		publisher: publisher,
+		logger:    logger.With("component", "event_bridge"),
+		metrics:   NewMetrics(),
+		queue:     make(chan Event, cfg.BufferSize),
+	}, nil
+}
+
+// Publish queues event if it passes the filters. It never blocks; events
+// are dropped when the queue is full or the bridge has shut down.
+func (b *Bridge) Publish(event Event) {
+	if b == nil || !b.Matches(event) {
+		return
+	}
+	if event.Time.IsZero() {
+		event.Time = time.Now()
+	}
+	if event.ID == "" {
+		event.ID = newEventID()
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.closed {
+		return
+	}
+	select {
+	case b.queue <- event:
+		b.metrics.QueueDepth.Set(float64(len(b.queue)))
+	default:
+		b.metrics.Dropped.WithLabelValues(event.Type, "queue_full").Inc()
+	}
+}
+
+// Matches reports whether event passes the type, channel, and agent
+// filters.
+func (b *Bridge) Matches(event Event) bool {
+	if !matchesType(event.Type, b.cfg.Events) {
+		return false
+	}
+	if event.Channel != "" && len(b.cfg.Channels) > 0 && !slices.Contains(b.cfg.Channels, event.Channel) {
+		return false
+	}
+	if event.AgentID != "" && len(b.cfg.Agents) > 0 && !slices.Contains(b.cfg.Agents, event.AgentID) {
+		return false
+	}
+	return true
+}
+
+// Start publishes queued events until Shutdown is called or ctx is done.
+func (b *Bridge) Start(ctx context.Context) {
+	b.mu.Lock()
+	if b.started {
+		b.mu.Unlock()
+		return
+	}
+	b.started = true
+	ctx, b.cancel = context.WithCancel(ctx)
+	b.done = make(chan struct{})
+	b.mu.Unlock()
+
+	go func() {
+		defer close(b.done)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case event := <-b.queue:
+				b.metrics.QueueDepth.Set(float64(len(b.queue)))
+				b.deliver(ctx, event)
+			}
+		}
+	}()
+}
+
+// Shutdown stops accepting events, publishes what is queued until ctx is
+// done, and closes the publisher.
+func (b *Bridge) Shutdown(ctx context.Context) error {
+	b.mu.Lock()
+	if b.closed {
+		b.mu.Unlock()
+		return nil
+	}
+	b.closed = true
+	cancel, done := b.cancel, b.done
+	b.mu.Unlock()
+	if cancel != nil {
+		cancel()
+		<-done
+	}
+
+drain:
+	for ctx.Err() == nil {
+		select {
+		case event := <-b.queue:
+			b.deliver(ctx, event)
+		default:
+			break drain
+		}
+	}
+	for remaining := len(b.queue); remaining > 0; remaining-- {
+		b.metrics.Dropped.WithLabelValues((<-b.queue).Type, "shutdown").Inc()
+	}
+	b.metrics.QueueDepth.Set(0)
+	return b.publisher.Close()
+}
+
+// deliver encodes and publishes one event, retrying transient failures.
+func (b *Bridge) deliver(ctx context.Context, event Event) {
+	data, contentType, err := Encode(event, b.cfg.Format, b.cfg.Source)
+	if err != nil {
+		b.metrics.Dropped.WithLabelValues(event.Type, "encode").Inc()
+		b.logger.Warn("event encode failed", "type", event.Type, "error", err)
+		return
+	}
+	msg := Message{
+		Subject: strings.ReplaceAll(b.cfg.Subject, TypePlaceholder, event.Type),
+		Key:     event.SessionID,
+		Headers: map[string]string{
+			"Content-Type":         contentType,
+			"Nexus-Schema":         SchemaName,
+			"Nexus-Schema-Version": fmt.Sprint(SchemaVersion),
+			"Nexus-Event-Type":     event.Type,
+			"Nexus-Event-Id":       event.ID,
+		},
+		Data: data,
+	}
+
+	backoff := 200 * time.Millisecond
+	for attempt := 0; ; attempt++ {
+		start := time.Now()
+		attemptCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), b.cfg.Timeout)
+		err = b.publisher.Publish(attemptCtx, msg)
+		cancel()
+		b.metrics.Latency.Observe(time.Since(start).Seconds())
+		if err == nil {
+			b.metrics.Published.WithLabelValues(event.Type).Inc()
+			return
+		}
+		b.metrics.Errors.WithLabelValues(event.Type).Inc()
+		if attempt >= b.cfg.Retries || ctx.Err() != nil {
+			break
+		}
+		select {
+		case <-ctx.Done():
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+	b.metrics.Dropped.WithLabelValues(event.Type, "publish_failed").Inc()
+	b.logger.Warn("event publish failed", "type", event.Type, "subject", msg.Subject, "error", err)
+}
+
+// matchesType reports whether eventType is selected by patterns. An empty
+// list selects everything.
+func matchesType(eventType string, patterns []string) bool {
+	if len(patterns) == 0 {
+		return true
+	}
+	for _, pattern := range patterns {
+		if prefix, ok := strings.CutSuffix(pattern, "*"); ok {
+			if strings.HasPrefix(eventType, prefix) {
+				return true
+			}
+		} else if eventType == pattern {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,209 @@
+package eventbridge
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type fakePublisher struct {
+	mu       sync.Mutex
+	messages []Message
+	failures int
+	closed   bool
+}
+
+func (p *fakePublisher) Publish(_ context.Context, msg Message) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.failures > 0 {
+		p.failures--
+		return errors.New("broker unavailable")
+	}
+	p.messages = append(p.messages, msg)
+	return nil
+}
+
+func (p *fakePublisher) Close() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.closed = true
+	return nil
+}
+
+func (p *fakePublisher) published() []Message {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([]Message(nil), p.messages...)
+}
+
+func TestBridgeFiltersAndPublishes(t *testing.T) {
+	pub := &fakePublisher{}
+	bridge, err := New(Config{
+		Subject:  "nexus.events.{type}",
+		Events:   []string{"tool.*", TypeMessageReceived},
+		Channels: []string{"slack"},
+		Source:   "node-1",
+	}, pub, nil)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	bridge.Publish(Event{Type: TypeToolStarted, SessionID: "s1", Channel: "slack", Data: map[string]any{"tool_name": "web_search"}})
+	bridge.Publish(Event{Type: TypeMessageReceived, SessionID: "s1", Channel: "discord"})
+	bridge.Publish(Event{Type: TypeRunStarted, SessionID: "s1", Channel: "slack"})
+	bridge.Publish(Event{Type: TypeMessageReceived, SessionID: "s2"})
+
+	if err := bridge.Shutdown(context.Background()); err != nil {
+		t.Fatalf("Shutdown() error = %v", err)
+	}
+	msgs := pub.published()
+	if len(msgs) != 2 {
+		t.Fatalf("published %d messages, want 2", len(msgs))
+	}
+	if msgs[0].Subject != "nexus.events.tool.started" || msgs[0].Key != "s1" {
+		t.Fatalf("message = %q key %q", msgs[0].Subject, msgs[0].Key)
+	}
+	if msgs[0].Headers["Nexus-Schema-Version"] != "1" || msgs[0].Headers["Content-Type"] != ContentTypeJSON {
+		t.Fatalf("headers = %v", msgs[0].Headers)
+	}
+	var envelope Envelope
+	if err := json.Unmarshal(msgs[0].Data, &envelope); err != nil {
+		t.Fatalf("decode envelope: %v", err)
+	}
+	if envelope.Schema != SchemaName || envelope.Type != TypeToolStarted || envelope.Source != "node-1" || envelope.ID == "" {
+		t.Fatalf("envelope = %+v", envelope)
+	}
+	if envelope.Data["tool_name"] != "web_search" {
+		t.Fatalf("data = %v", envelope.Data)
+	}
+	if !pub.closed {
+		t.Fatal("publisher not closed on shutdown")
+	}
+}
+
+func TestBridgeRetriesFailedPublishes(t *testing.T) {
+	pub := &fakePublisher{failures: 1}
+	bridge, err := New(Config{Subject: "events", Retries: 1}, pub, nil)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	bridge.Start(context.Background())
+	bridge.Publish(Event{Type: TypeRunFinished})
+
+	deadline := time.Now().Add(2 * time.Second)
+	for len(pub.published()) == 0 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if err := bridge.Shutdown(context.Background()); err != nil {
+		t.Fatalf("Shutdown() error = %v", err)
+	}
+	if got := len(pub.published()); got != 1 {
+		t.Fatalf("published %d messages after retry, want 1", got)
+	}
+
+	// Events published after shutdown are ignored.
+	bridge.Publish(Event{Type: TypeRunFinished})
+	if got := len(pub.published()); got != 1 {
+		t.Fatalf("published %d messages after shutdown, want 1", got)
+	}
+}
+
+func TestNewRejectsUnknownFormat(t *testing.T) {
+	if _, err := New(Config{Subject: "events", Format: "avro"}, &fakePublisher{}, nil); err == nil {
+		t.Fatal("expected error for unknown format")
+	}
+}
+
+func TestEncodeProtobuf(t *testing.T) {
+	at := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	data, contentType, err := Encode(Event{
+		ID:        "evt-1",
+		Type:      TypeApprovalDecided,
+		Time:      at,
+		SessionID: "s1",
+		AgentID:   "main",
+		Data:      map[string]any{"decision": "allowed", "attempts": 2},
+	}, FormatProtobuf, "node-1")
+	if err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+	if contentType != ContentTypeProtobuf {
+		t.Fatalf("content type = %q", contentType)
+	}
+
+	fields := map[protowire.Number][]byte{}
+	var version uint64
+	for len(data) > 0 {
+		num, typ, n := protowire.ConsumeTag(data)
+		if n < 0 {
+			t.Fatalf("bad tag: %v", protowire.ParseError(n))
+		}
+		data = data[n:]
+		switch typ {
+		case protowire.VarintType:
+			v, m := protowire.ConsumeVarint(data)
+			if m < 0 {
+				t.Fatalf("bad varint: %v", protowire.ParseError(m))
+			}
+			version, data = v, data[m:]
+		case protowire.BytesType:
+			v, m := protowire.ConsumeBytes(data)
+			if m < 0 {
+				t.Fatalf("bad bytes: %v", protowire.ParseError(m))
+			}
+			fields[num], data = v, data[m:]
+		default:
+			t.Fatalf("unexpected wire type %v for field %d", typ, num)
+		}
+	}
+
+	if string(fields[fieldSchema]) != SchemaName || version != SchemaVersion {
+		t.Fatalf("schema = %q v%d", fields[fieldSchema], version)
+	}
+	if string(fields[fieldType]) != TypeApprovalDecided || string(fields[fieldSource]) != "node-1" || string(fields[fieldAgentID]) != "main" {
+		t.Fatalf("fields = %q", fields)
+	}
+	if _, ok := fields[fieldRunID]; ok {
+		t.Fatal("empty run_id should be omitted")
+	}
+	var ts timestamppb.Timestamp
+	if err := proto.Unmarshal(fields[fieldTime], &ts); err != nil || !ts.AsTime().Equal(at) {
+		t.Fatalf("time = %v (%v)", ts.AsTime(), err)
+	}
+	var payload structpb.Struct
+	if err := proto.Unmarshal(fields[fieldData], &payload); err != nil {
+		t.Fatalf("decode data: %v", err)
+	}
+	if payload.Fields["decision"].GetStringValue() != "allowed" || payload.Fields["attempts"].GetNumberValue() != 2 {
+		t.Fatalf("data = %v", payload.AsMap())
+	}
+}
+
+func TestMatchesType(t *testing.T) {
+	tests := []struct {
+		eventType string
+		patterns  []string
+		want      bool
+	}{
+		{TypeRunStarted, nil, true},
+		{TypeRunStarted, []string{"run.*"}, true},
+		{TypeToolStarted, []string{"run.*"}, false},
+		{TypeApprovalDecided, []string{TypeApprovalDecided}, true},
+		{TypeApprovalRequested, []string{TypeApprovalDecided}, false},
+		{TypeMessageSent, []string{"*"}, true},
+	}
+	for _, tt := range tests {
+		if got := matchesType(tt.eventType, tt.patterns); got != tt.want {
+			t.Errorf("matchesType(%q, %v) = %v, want %v", tt.eventType, tt.patterns, got, tt.want)
+		}
+	}
+}
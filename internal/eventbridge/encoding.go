@@ -0,0 +1,155 @@
+package eventbridge
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Payload formats.
+const (
+	FormatJSON     = "json"
+	FormatProtobuf = "protobuf"
+)
+
+// Content types of encoded envelopes.
+const (
+	ContentTypeJSON     = "application/json"
+	ContentTypeProtobuf = "application/x-protobuf; messageType=nexus.events.v1.Event"
+)
+
+// SchemaName identifies the envelope schema. SchemaVersion is bumped on
+// incompatible changes; new fields are added without a bump.
+const (
+	SchemaName    = "nexus.events.v1.Event"
+	SchemaVersion = 1
+)
+
+// Envelope is the JSON form of a published event. Its fields mirror the
+// nexus.events.v1.Event protobuf message in event.proto.
+type Envelope struct {
+	Schema        string         `json:"schema"`
+	SchemaVersion int            `json:"schema_version"`
+	ID            string         `json:"id"`
+	Type          string         `json:"type"`
+	Time          time.Time      `json:"time"`
+	Source        string         `json:"source,omitempty"`
+	SessionID     string         `json:"session_id,omitempty"`
+	RunID         string         `json:"run_id,omitempty"`
+	AgentID       string         `json:"agent_id,omitempty"`
+	Channel       string         `json:"channel,omitempty"`
+	Data          map[string]any `json:"data,omitempty"`
+}
+
+// Field numbers of nexus.events.v1.Event.
+const (
+	fieldSchema        protowire.Number = 1
+	fieldSchemaVersion protowire.Number = 2
+	fieldID            protowire.Number = 3
+	fieldType          protowire.Number = 4
+	fieldTime          protowire.Number = 5
+	fieldSource        protowire.Number = 6
+	fieldSessionID     protowire.Number = 7
+	fieldRunID         protowire.Number = 8
+	fieldAgentID       protowire.Number = 9
+	fieldChannel       protowire.Number = 10
+	fieldData          protowire.Number = 11
+)
+
+// Encode wraps event in the envelope and encodes it in format. It returns
+// the payload and its content type.
+func Encode(event Event, format, source string) ([]byte, string, error) {
+	envelope := Envelope{
+		Schema:        SchemaName,
+		SchemaVersion: SchemaVersion,
+		ID:            event.ID,
+		Type:          event.Type,
+		Time:          event.Time.UTC(),
+		Source:        source,
+		SessionID:     event.SessionID,
+		RunID:         event.RunID,
+		AgentID:       event.AgentID,
+		Channel:       event.Channel,
+		Data:          event.Data,
+	}
+	switch format {
+	case "", FormatJSON:
+		data, err := json.Marshal(envelope)
+		return data, ContentTypeJSON, err
+	case FormatProtobuf:
+		data, err := encodeProtobuf(envelope)
+		return data, ContentTypeProtobuf, err
+	default:
+		return nil, "", fmt.Errorf("unknown format %q", format)
+	}
+}
+
+// encodeProtobuf writes the envelope in the nexus.events.v1.Event wire
+// format. Data is encoded as a google.protobuf.Struct.
+func encodeProtobuf(e Envelope) ([]byte, error) {
+	var b []byte
+	appendString := func(num protowire.Number, v string) {
+		if v == "" {
+			return
+		}
+		b = protowire.AppendTag(b, num, protowire.BytesType)
+		b = protowire.AppendString(b, v)
+	}
+	appendMessage := func(num protowire.Number, m proto.Message) error {
+		data, err := proto.Marshal(m)
+		if err != nil {
+			return err
+		}
+		b = protowire.AppendTag(b, num, protowire.BytesType)
+		b = protowire.AppendBytes(b, data)
+		return nil
+	}
+
+	appendString(fieldSchema, e.Schema)
+	b = protowire.AppendTag(b, fieldSchemaVersion, protowire.VarintType)
+	b = protowire.AppendVarint(b, uint64(e.SchemaVersion))
+	appendString(fieldID, e.ID)
+	appendString(fieldType, e.Type)
+	if err := appendMessage(fieldTime, timestamppb.New(e.Time)); err != nil {
+		return nil, err
+	}
+	appendString(fieldSource, e.Source)
+	appendString(fieldSessionID, e.SessionID)
+	appendString(fieldRunID, e.RunID)
+	appendString(fieldAgentID, e.AgentID)
+	appendString(fieldChannel, e.Channel)
+	if len(e.Data) > 0 {
+		data, err := toStruct(e.Data)
+		if err != nil {
+			return nil, fmt.Errorf("encode data: %w", err)
+		}
+		if err := appendMessage(fieldData, data); err != nil {
+			return nil, err
+		}
+	}
+	return b, nil
+}
+
+// toStruct converts data to a protobuf Struct through its JSON form, so
+// any JSON-encodable value (times, typed slices, structs) is accepted.
+func toStruct(data map[string]any) (*structpb.Struct, error) {
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+	s := &structpb.Struct{}
+	if err := s.UnmarshalJSON(raw); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func newEventID() string {
+	return uuid.NewString()
+}
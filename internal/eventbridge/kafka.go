@@ -0,0 +1,120 @@
+package eventbridge
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// KafkaConfig configures a KafkaPublisher.
+type KafkaConfig struct {
+	// RESTURL is the base URL of a Kafka REST proxy speaking the v2
+	// produce API, such as Confluent REST Proxy or Redpanda's HTTP proxy,
+	// e.g. http://kafka-rest:8082.
+	RESTURL string
+	// Username and Password enable HTTP basic auth.
+	Username string
+	Password string
+	// Headers are sent with every request, e.g. an API key.
+	Headers map[string]string
+	// Client overrides the HTTP client.
+	Client *http.Client
+}
+
+// KafkaPublisher produces records to Kafka through a REST proxy. Records
+// are keyed by session, so a session's events land on one partition in
+// order. The REST v2 API has no record headers; consumers read the schema
+// from the envelope itself.
+type KafkaPublisher struct {
+	cfg    KafkaConfig
+	base   string
+	client *http.Client
+}
+
+// NewKafkaPublisher validates cfg.
+func NewKafkaPublisher(cfg KafkaConfig) (*KafkaPublisher, error) {
+	u, err := url.Parse(strings.TrimSpace(cfg.RESTURL))
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, fmt.Errorf("kafka: rest_url must be an http(s) URL, got %q", cfg.RESTURL)
+	}
+	client := cfg.Client
+	if client == nil {
+		client = &http.Client{}
+	}
+	return &KafkaPublisher{cfg: cfg, base: strings.TrimRight(u.String(), "/"), client: client}, nil
+}
+
+type kafkaRecord struct {
+	Key   *string `json:"key"`
+	Value string  `json:"value"`
+}
+
+type kafkaProduceResponse struct {
+	Offsets []struct {
+		Partition int     `json:"partition"`
+		Offset    int64   `json:"offset"`
+		ErrorCode *int    `json:"error_code"`
+		Error     *string `json:"error"`
+	} `json:"offsets"`
+}
+
+// Publish produces msg.Data to the msg.Subject topic.
+func (p *KafkaPublisher) Publish(ctx context.Context, msg Message) error {
+	if msg.Subject == "" {
+		return errors.New("kafka: topic is required")
+	}
+	record := kafkaRecord{Value: base64.StdEncoding.EncodeToString(msg.Data)}
+	if msg.Key != "" {
+		key := base64.StdEncoding.EncodeToString([]byte(msg.Key))
+		record.Key = &key
+	}
+	body, err := json.Marshal(map[string]any{"records": []kafkaRecord{record}})
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.base+"/topics/"+url.PathEscape(msg.Subject), bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/vnd.kafka.binary.v2+json")
+	req.Header.Set("Accept", "application/vnd.kafka.v2+json")
+	for key, value := range p.cfg.Headers {
+		req.Header.Set(key, value)
+	}
+	if p.cfg.Username != "" {
+		req.SetBasicAuth(p.cfg.Username, p.cfg.Password)
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("kafka: produce: %w", err)
+	}
+	defer resp.Body.Close()
+	respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 64<<10)) //nolint:errcheck // best-effort error detail
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("kafka: produce to %s: %s: %s", msg.Subject, resp.Status, strings.TrimSpace(string(respBody)))
+	}
+	var produced kafkaProduceResponse
+	if err := json.Unmarshal(respBody, &produced); err != nil {
+		return fmt.Errorf("kafka: decode produce response: %w", err)
+	}
+	for _, offset := range produced.Offsets {
+		if offset.Error != nil && *offset.Error != "" {
+			return fmt.Errorf("kafka: produce to %s: %s", msg.Subject, *offset.Error)
+		}
+	}
+	return nil
+}
+
+// Close releases idle connections.
+func (p *KafkaPublisher) Close() error {
+	p.client.CloseIdleConnections()
+	return nil
+}
@@ -0,0 +1,84 @@
+package eventbridge
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestKafkaPublisherProduces(t *testing.T) {
+	var gotPath, gotContentType, gotAPIKey string
+	var body struct {
+		Records []struct {
+			Key   string `json:"key"`
+			Value string `json:"value"`
+		} `json:"records"`
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		gotContentType = r.Header.Get("Content-Type")
+		gotAPIKey = r.Header.Get("X-Api-Key")
+		if user, pass, ok := r.BasicAuth(); !ok || user != "nexus" || pass != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+		w.Header().Set("Content-Type", "application/vnd.kafka.v2+json")
+		w.Write([]byte(`{"offsets":[{"partition":0,"offset":7,"error_code":null,"error":null}]}`))
+	}))
+	defer server.Close()
+
+	pub, err := NewKafkaPublisher(KafkaConfig{
+		RESTURL:  server.URL + "/",
+		Username: "nexus",
+		Password: "secret",
+		Headers:  map[string]string{"X-Api-Key": "k1"},
+	})
+	if err != nil {
+		t.Fatalf("NewKafkaPublisher() error = %v", err)
+	}
+	if err := pub.Publish(context.Background(), Message{Subject: "nexus.events", Key: "s1", Data: []byte(`{"type":"run.started"}`)}); err != nil {
+		t.Fatalf("Publish() error = %v", err)
+	}
+
+	if gotPath != "/topics/nexus.events" || gotContentType != "application/vnd.kafka.binary.v2+json" || gotAPIKey != "k1" {
+		t.Fatalf("request path=%q content-type=%q api-key=%q", gotPath, gotContentType, gotAPIKey)
+	}
+	if len(body.Records) != 1 {
+		t.Fatalf("records = %+v", body.Records)
+	}
+	key, _ := base64.StdEncoding.DecodeString(body.Records[0].Key)
+	value, _ := base64.StdEncoding.DecodeString(body.Records[0].Value)
+	if string(key) != "s1" || string(value) != `{"type":"run.started"}` {
+		t.Fatalf("record key=%q value=%q", key, value)
+	}
+}
+
+func TestKafkaPublisherReportsErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/topics/missing" {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"error_code":40401,"message":"Topic not found"}`))
+			return
+		}
+		w.Write([]byte(`{"offsets":[{"partition":null,"offset":null,"error_code":2,"error":"record too large"}]}`))
+	}))
+	defer server.Close()
+
+	pub, err := NewKafkaPublisher(KafkaConfig{RESTURL: server.URL})
+	if err != nil {
+		t.Fatalf("NewKafkaPublisher() error = %v", err)
+	}
+	if err := pub.Publish(context.Background(), Message{Subject: "missing", Data: []byte("x")}); err == nil {
+		t.Fatal("expected error for 404")
+	}
+	if err := pub.Publish(context.Background(), Message{Subject: "events", Data: []byte("x")}); err == nil {
+		t.Fatal("expected error for per-record failure")
+	}
+	if _, err := NewKafkaPublisher(KafkaConfig{RESTURL: "kafka:9092"}); err == nil {
+		t.Fatal("expected error for non-http rest_url")
+	}
+}
@@ -0,0 +1,64 @@
+package eventbridge
+
+import (
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// Metrics exports event bridge delivery.
+type Metrics struct {
+	// Published counts events accepted by the broker.
+	// Labels: type
+	Published *prometheus.CounterVec
+
+	// Errors counts failed publish attempts, including retried ones.
+	// Labels: type
+	Errors *prometheus.CounterVec
+
+	// Dropped counts events that were never published.
+	// Labels: type, reason (queue_full|encode|publish_failed|shutdown)
+	Dropped *prometheus.CounterVec
+
+	// Latency is the duration of each publish attempt.
+	Latency prometheus.Histogram
+
+	// QueueDepth is the number of events waiting to be published.
+	QueueDepth prometheus.Gauge
+}
+
+var (
+	metricsOnce     sync.Once
+	metricsInstance *Metrics
+)
+
+// NewMetrics returns the process-wide event bridge metrics.
+func NewMetrics() *Metrics {
+	metricsOnce.Do(func() {
+		metricsInstance = &Metrics{
+			Published: promauto.NewCounterVec(prometheus.CounterOpts{
+				Name: "nexus_event_bridge_published_total",
+				Help: "Events published to the message queue by type",
+			}, []string{"type"}),
+			Errors: promauto.NewCounterVec(prometheus.CounterOpts{
+				Name: "nexus_event_bridge_errors_total",
+				Help: "Failed event publish attempts by type",
+			}, []string{"type"}),
+			Dropped: promauto.NewCounterVec(prometheus.CounterOpts{
+				Name: "nexus_event_bridge_dropped_total",
+				Help: "Events dropped without being published by type and reason",
+			}, []string{"type", "reason"}),
+			Latency: promauto.NewHistogram(prometheus.HistogramOpts{
+				Name:    "nexus_event_bridge_publish_seconds",
+				Help:    "Duration of event publish attempts",
+				Buckets: []float64{0.001, 0.005, 0.01, 0.05, 0.1, 0.5, 1, 5, 10},
+			}),
+			QueueDepth: promauto.NewGauge(prometheus.GaugeOpts{
+				Name: "nexus_event_bridge_queue_depth",
+				Help: "Events waiting to be published",
+			}),
+		}
+	})
+	return metricsInstance
+}
@@ -0,0 +1,128 @@
+package eventbridge
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// fakeNATSServer accepts one connection, completes the handshake, and
+// sends each published message's protocol line and payload on the
+// returned channel.
+func fakeNATSServer(t *testing.T, headers bool) (string, <-chan string, <-chan map[string]any) {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	t.Cleanup(func() { ln.Close() })
+	published := make(chan string, 4)
+	connects := make(chan map[string]any, 1)
+
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		info, _ := json.Marshal(map[string]any{"server_id": "test", "headers": headers, "max_payload": 1024})
+		conn.Write([]byte("INFO " + string(info) + "\r\n"))
+		r := bufio.NewReader(conn)
+		for {
+			line, err := r.ReadString('\n')
+			if err != nil {
+				return
+			}
+			line = strings.TrimRight(line, "\r\n")
+			fields := strings.Fields(line)
+			switch {
+			case strings.HasPrefix(line, "CONNECT "):
+				var opts map[string]any
+				json.Unmarshal([]byte(strings.TrimPrefix(line, "CONNECT ")), &opts)
+				connects <- opts
+			case line == "PING":
+				conn.Write([]byte("PONG\r\n"))
+			case len(fields) > 0 && (fields[0] == "PUB" || fields[0] == "HPUB"):
+				size, _ := strconv.Atoi(fields[len(fields)-1])
+				payload := make([]byte, size+2)
+				if _, err := io.ReadFull(r, payload); err != nil {
+					return
+				}
+				published <- line + "\n" + string(payload[:size])
+			}
+		}
+	}()
+	return ln.Addr().String(), published, connects
+}
+
+func TestNATSPublisherHPUB(t *testing.T) {
+	addr, published, connects := fakeNATSServer(t, true)
+	pub, err := NewNATSPublisher(NATSConfig{URL: "nats://bot:secret@" + addr, Name: "nexus"})
+	if err != nil {
+		t.Fatalf("NewNATSPublisher() error = %v", err)
+	}
+	defer pub.Close()
+
+	err = pub.Publish(context.Background(), Message{
+		Subject: "nexus.events.run.started",
+		Headers: map[string]string{"Nexus-Schema-Version": "1"},
+		Data:    []byte(`{"type":"run.started"}`),
+	})
+	if err != nil {
+		t.Fatalf("Publish() error = %v", err)
+	}
+
+	opts := <-connects
+	if opts["user"] != "bot" || opts["pass"] != "secret" || opts["name"] != "nexus" || opts["headers"] != true {
+		t.Fatalf("CONNECT options = %v", opts)
+	}
+	hdr := "NATS/1.0\r\nNexus-Schema-Version: 1\r\n\r\n"
+	body := `{"type":"run.started"}`
+	want := "HPUB nexus.events.run.started " + strconv.Itoa(len(hdr)) + " " + strconv.Itoa(len(hdr)+len(body)) + "\n" + hdr + body
+	if got := <-published; got != want {
+		t.Fatalf("published %q, want %q", got, want)
+	}
+}
+
+func TestNATSPublisherPUBWithoutHeaderSupport(t *testing.T) {
+	addr, published, _ := fakeNATSServer(t, false)
+	pub, err := NewNATSPublisher(NATSConfig{URL: addr})
+	if err != nil {
+		t.Fatalf("NewNATSPublisher() error = %v", err)
+	}
+	defer pub.Close()
+
+	msg := Message{Subject: "events", Headers: map[string]string{"A": "b"}, Data: []byte("hello")}
+	if err := pub.Publish(context.Background(), msg); err != nil {
+		t.Fatalf("Publish() error = %v", err)
+	}
+	if got := <-published; got != "PUB events 5\nhello" {
+		t.Fatalf("published %q", got)
+	}
+
+	msg.Data = make([]byte, 2048)
+	if err := pub.Publish(context.Background(), msg); err == nil {
+		t.Fatal("expected error for payload over max_payload")
+	}
+}
+
+func TestNewNATSPublisherValidatesURL(t *testing.T) {
+	if _, err := NewNATSPublisher(NATSConfig{}); err == nil {
+		t.Fatal("expected error for empty url")
+	}
+	if _, err := NewNATSPublisher(NATSConfig{URL: "http://localhost:4222"}); err == nil {
+		t.Fatal("expected error for http scheme")
+	}
+	pub, err := NewNATSPublisher(NATSConfig{URL: "localhost, tls://nats.internal:4443"})
+	if err != nil {
+		t.Fatalf("NewNATSPublisher() error = %v", err)
+	}
+	if len(pub.servers) != 2 || pub.servers[0].Host != "localhost:4222" || pub.servers[1].Scheme != "tls" {
+		t.Fatalf("servers = %v", pub.servers)
+	}
+}
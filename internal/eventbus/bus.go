@@ -0,0 +1,154 @@
+// Package eventbus propagates gateway events between cluster nodes.
+//
+// A MemoryBus delivers events to subscribers in the same process. A
+// PostgresBus additionally fans events out to other gateways over Postgres
+// LISTEN/NOTIFY, delivering remote events into its local MemoryBus.
+package eventbus
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// Well-known topics.
+const (
+	// TopicApprovalResolved is published when a tool approval is approved or denied.
+	TopicApprovalResolved = "approval.resolved"
+	// TopicSessionReset is published when a session is reset or deleted.
+	TopicSessionReset = "session.reset"
+	// TopicConfigChanged is published when the gateway config is applied.
+	TopicConfigChanged = "config.changed"
+	// TopicCacheInvalidate is published to drop cached entries on every node.
+	TopicCacheInvalidate = "cache.invalidate"
+)
+
+// Event is a message published on the bus.
+type Event struct {
+	Topic   string          `json:"topic"`
+	Source  string          `json:"source,omitempty"`
+	Payload json.RawMessage `json:"payload,omitempty"`
+	Time    time.Time       `json:"time"`
+
+	// Remote is true when the event was published by another node.
+	Remote bool `json:"-"`
+}
+
+// Decode unmarshals the event payload into v.
+func (e Event) Decode(v any) error {
+	if len(e.Payload) == 0 {
+		return nil
+	}
+	return json.Unmarshal(e.Payload, v)
+}
+
+// Handler processes a delivered event.
+type Handler func(ctx context.Context, event Event)
+
+// Bus publishes events and dispatches them to subscribers.
+type Bus interface {
+	// Publish delivers payload to subscribers of topic. Payload is JSON encoded.
+	Publish(ctx context.Context, topic string, payload any) error
+	// Subscribe registers handler for topic and returns a function that removes it.
+	Subscribe(topic string, handler Handler) func()
+	// Close releases resources held by the bus.
+	Close() error
+}
+
+// MemoryBus dispatches events to subscribers in the current process.
+type MemoryBus struct {
+	source string
+	logger *slog.Logger
+
+	mu       sync.RWMutex
+	nextID   int
+	handlers map[string]map[int]Handler
+}
+
+// NewMemoryBus creates an in-process bus. Source identifies this node on
+// published events.
+func NewMemoryBus(source string, logger *slog.Logger) *MemoryBus {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &MemoryBus{
+		source:   source,
+		logger:   logger,
+		handlers: make(map[string]map[int]Handler),
+	}
+}
+
+// Publish delivers the event synchronously to local subscribers.
+func (b *MemoryBus) Publish(ctx context.Context, topic string, payload any) error {
+	event, err := b.newEvent(topic, payload)
+	if err != nil {
+		return err
+	}
+	b.dispatch(ctx, event)
+	return nil
+}
+
+// Subscribe registers handler for topic.
+func (b *MemoryBus) Subscribe(topic string, handler Handler) func() {
+	if handler == nil {
+		return func() {}
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	id := b.nextID
+	b.nextID++
+	if b.handlers[topic] == nil {
+		b.handlers[topic] = make(map[int]Handler)
+	}
+	b.handlers[topic][id] = handler
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			b.mu.Lock()
+			defer b.mu.Unlock()
+			delete(b.handlers[topic], id)
+		})
+	}
+}
+
+// Close is a no-op for the in-memory bus.
+func (b *MemoryBus) Close() error {
+	return nil
+}
+
+func (b *MemoryBus) newEvent(topic string, payload any) (Event, error) {
+	event := Event{Topic: topic, Source: b.source, Time: time.Now().UTC()}
+	if payload != nil {
+		data, err := json.Marshal(payload)
+		if err != nil {
+			return Event{}, err
+		}
+		event.Payload = data
+	}
+	return event, nil
+}
+
+// dispatch runs every handler for the event's topic. A panicking handler is
+// logged and does not prevent delivery to the others.
+func (b *MemoryBus) dispatch(ctx context.Context, event Event) {
+	b.mu.RLock()
+	handlers := make([]Handler, 0, len(b.handlers[event.Topic]))
+	for _, handler := range b.handlers[event.Topic] {
+		handlers = append(handlers, handler)
+	}
+	b.mu.RUnlock()
+
+	for _, handler := range handlers {
+		func() {
+			defer func() {
+				if r := recover(); r != nil {
+					b.logger.Error("event handler panicked", "topic", event.Topic, "panic", r)
+				}
+			}()
+			handler(ctx, event)
+		}()
+	}
+}
@@ -0,0 +1,57 @@
+package eventbus
+
+import (
+	"context"
+	"testing"
+)
+
+func TestMemoryBusPublishSubscribe(t *testing.T) {
+	bus := NewMemoryBus("node-1", nil)
+	ctx := context.Background()
+
+	var got []Event
+	unsubscribe := bus.Subscribe(TopicSessionReset, func(ctx context.Context, event Event) {
+		got = append(got, event)
+	})
+	bus.Subscribe(TopicConfigChanged, func(ctx context.Context, event Event) {
+		t.Fatal("handler for other topic should not run")
+	})
+
+	if err := bus.Publish(ctx, TopicSessionReset, map[string]string{"session_id": "s1"}); err != nil {
+		t.Fatalf("Publish: %v", err)
+	}
+	if len(got) != 1 {
+		t.Fatalf("got %d events, want 1", len(got))
+	}
+	if got[0].Source != "node-1" || got[0].Remote {
+		t.Fatalf("event = %+v", got[0])
+	}
+	var payload struct {
+		SessionID string `json:"session_id"`
+	}
+	if err := got[0].Decode(&payload); err != nil || payload.SessionID != "s1" {
+		t.Fatalf("Decode = %+v, %v", payload, err)
+	}
+
+	unsubscribe()
+	if err := bus.Publish(ctx, TopicSessionReset, nil); err != nil {
+		t.Fatalf("Publish: %v", err)
+	}
+	if len(got) != 1 {
+		t.Fatalf("got %d events after unsubscribe, want 1", len(got))
+	}
+}
+
+func TestMemoryBusRecoversHandlerPanic(t *testing.T) {
+	bus := NewMemoryBus("node-1", nil)
+	called := false
+	bus.Subscribe(TopicCacheInvalidate, func(ctx context.Context, event Event) { panic("boom") })
+	bus.Subscribe(TopicCacheInvalidate, func(ctx context.Context, event Event) { called = true })
+
+	if err := bus.Publish(context.Background(), TopicCacheInvalidate, nil); err != nil {
+		t.Fatalf("Publish: %v", err)
+	}
+	if !called {
+		t.Fatal("expected second handler to run after first panicked")
+	}
+}
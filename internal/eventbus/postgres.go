@@ -0,0 +1,197 @@
+package eventbus
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// DefaultChannel is the Postgres notification channel used for gateway events.
+const DefaultChannel = "nexus_events"
+
+// maxNotifyPayload is the largest NOTIFY payload Postgres accepts (8000 bytes)
+// minus headroom for quoting.
+const maxNotifyPayload = 7900
+
+// ErrPayloadTooLarge is returned when an encoded event exceeds the NOTIFY limit.
+// The event is still delivered to local subscribers.
+var ErrPayloadTooLarge = errors.New("event payload exceeds notify size limit")
+
+// PostgresConfig configures a PostgresBus.
+type PostgresConfig struct {
+	// DSN is the connection string used for the dedicated LISTEN connection.
+	DSN string
+
+	// Channel is the notification channel name. Defaults to DefaultChannel.
+	Channel string
+
+	// MinReconnectInterval and MaxReconnectInterval bound listener reconnect backoff.
+	MinReconnectInterval time.Duration
+	MaxReconnectInterval time.Duration
+
+	// Logger for bus events.
+	Logger *slog.Logger
+}
+
+// listener is the subset of *pq.Listener used by PostgresBus.
+type listener interface {
+	Listen(channel string) error
+	NotificationChannel() <-chan *pq.Notification
+	Close() error
+}
+
+// PostgresBus publishes events over Postgres NOTIFY and delivers notifications
+// from other nodes to a local MemoryBus.
+type PostgresBus struct {
+	db       *sql.DB
+	local    *MemoryBus
+	listener listener
+	channel  string
+	logger   *slog.Logger
+
+	cancel    context.CancelFunc
+	wg        sync.WaitGroup
+	closeOnce sync.Once
+}
+
+// NewPostgresBus starts listening for events on the configured channel.
+// Subscriptions registered on local remain active and also receive events
+// published by other nodes.
+func NewPostgresBus(db *sql.DB, local *MemoryBus, cfg PostgresConfig) (*PostgresBus, error) {
+	if db == nil {
+		return nil, errors.New("db is required")
+	}
+	if cfg.DSN == "" {
+		return nil, errors.New("dsn is required")
+	}
+	if cfg.MinReconnectInterval <= 0 {
+		cfg.MinReconnectInterval = time.Second
+	}
+	if cfg.MaxReconnectInterval <= 0 {
+		cfg.MaxReconnectInterval = time.Minute
+	}
+	logger := cfg.Logger
+	if logger == nil {
+		logger = slog.Default()
+	}
+	logger = logger.With("component", "eventbus")
+
+	l := pq.NewListener(cfg.DSN, cfg.MinReconnectInterval, cfg.MaxReconnectInterval,
+		func(ev pq.ListenerEventType, err error) {
+			switch ev {
+			case pq.ListenerEventDisconnected:
+				logger.Warn("event bus listener disconnected", "error", err)
+			case pq.ListenerEventReconnected:
+				logger.Info("event bus listener reconnected")
+			case pq.ListenerEventConnectionAttemptFailed:
+				logger.Debug("event bus listener connection failed", "error", err)
+			}
+		})
+	return newPostgresBus(db, local, l, cfg.Channel, logger)
+}
+
+func newPostgresBus(db *sql.DB, local *MemoryBus, l listener, channel string, logger *slog.Logger) (*PostgresBus, error) {
+	if local == nil {
+		return nil, errors.New("local bus is required")
+	}
+	if channel == "" {
+		channel = DefaultChannel
+	}
+	if err := l.Listen(channel); err != nil {
+		_ = l.Close()
+		return nil, fmt.Errorf("listen %s: %w", channel, err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	b := &PostgresBus{
+		db:       db,
+		local:    local,
+		listener: l,
+		channel:  channel,
+		logger:   logger,
+		cancel:   cancel,
+	}
+	b.wg.Add(1)
+	go b.receive(ctx)
+	return b, nil
+}
+
+// Publish delivers the event to local subscribers and notifies other nodes.
+func (b *PostgresBus) Publish(ctx context.Context, topic string, payload any) error {
+	event, err := b.local.newEvent(topic, payload)
+	if err != nil {
+		return err
+	}
+	b.local.dispatch(ctx, event)
+
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	if len(data) > maxNotifyPayload {
+		return fmt.Errorf("%w: %s is %d bytes", ErrPayloadTooLarge, topic, len(data))
+	}
+	if _, err := b.db.ExecContext(ctx, `SELECT pg_notify($1, $2)`, b.channel, string(data)); err != nil {
+		return fmt.Errorf("notify %s: %w", topic, err)
+	}
+	return nil
+}
+
+// Subscribe registers handler on the local bus.
+func (b *PostgresBus) Subscribe(topic string, handler Handler) func() {
+	return b.local.Subscribe(topic, handler)
+}
+
+// Close stops listening for notifications.
+func (b *PostgresBus) Close() error {
+	var err error
+	b.closeOnce.Do(func() {
+		b.cancel()
+		err = b.listener.Close()
+		b.wg.Wait()
+	})
+	return err
+}
+
+func (b *PostgresBus) receive(ctx context.Context) {
+	defer b.wg.Done()
+	notifications := b.listener.NotificationChannel()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case n, ok := <-notifications:
+			if !ok {
+				return
+			}
+			b.handleNotification(ctx, n)
+		}
+	}
+}
+
+// handleNotification decodes a notification and delivers it locally. Events
+// published by this node were already delivered by Publish and are dropped.
+func (b *PostgresBus) handleNotification(ctx context.Context, n *pq.Notification) {
+	if n == nil {
+		// A nil notification follows a reconnect; events may have been missed.
+		b.logger.Warn("event bus reconnected; notifications may have been missed")
+		return
+	}
+	var event Event
+	if err := json.Unmarshal([]byte(n.Extra), &event); err != nil {
+		b.logger.Warn("dropping malformed event", "error", err)
+		return
+	}
+	if event.Source == b.local.source {
+		return
+	}
+	event.Remote = true
+	b.local.dispatch(ctx, event)
+}
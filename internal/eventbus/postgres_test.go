@@ -0,0 +1,115 @@
+package eventbus
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/lib/pq"
+)
+
+type fakeListener struct {
+	channel       string
+	notifications chan *pq.Notification
+	closed        bool
+}
+
+func (l *fakeListener) Listen(channel string) error {
+	l.channel = channel
+	return nil
+}
+
+func (l *fakeListener) NotificationChannel() <-chan *pq.Notification {
+	return l.notifications
+}
+
+func (l *fakeListener) Close() error {
+	l.closed = true
+	return nil
+}
+
+func newTestPostgresBus(t *testing.T) (*PostgresBus, *fakeListener, sqlmock.Sqlmock) {
+	t.Helper()
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	l := &fakeListener{notifications: make(chan *pq.Notification, 4)}
+	bus, err := newPostgresBus(db, NewMemoryBus("node-1", logger), l, "", logger)
+	if err != nil {
+		t.Fatalf("newPostgresBus: %v", err)
+	}
+	t.Cleanup(func() { bus.Close() })
+	return bus, l, mock
+}
+
+func TestPostgresBusPublishNotifies(t *testing.T) {
+	bus, l, mock := newTestPostgresBus(t)
+	if l.channel != DefaultChannel {
+		t.Fatalf("listening on %q, want %q", l.channel, DefaultChannel)
+	}
+
+	var local int
+	bus.Subscribe(TopicApprovalResolved, func(ctx context.Context, event Event) { local++ })
+
+	mock.ExpectExec("SELECT pg_notify").
+		WithArgs(DefaultChannel, sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	if err := bus.Publish(context.Background(), TopicApprovalResolved, map[string]string{"id": "a1"}); err != nil {
+		t.Fatalf("Publish: %v", err)
+	}
+	if local != 1 {
+		t.Fatalf("local deliveries = %d, want 1", local)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("expectations: %v", err)
+	}
+
+	err := bus.Publish(context.Background(), TopicApprovalResolved, strings.Repeat("x", maxNotifyPayload))
+	if !errors.Is(err, ErrPayloadTooLarge) {
+		t.Fatalf("Publish oversized = %v, want ErrPayloadTooLarge", err)
+	}
+	if local != 2 {
+		t.Fatalf("oversized event should still be delivered locally, got %d", local)
+	}
+}
+
+func TestPostgresBusDeliversRemoteEvents(t *testing.T) {
+	bus, l, _ := newTestPostgresBus(t)
+
+	received := make(chan Event, 2)
+	bus.Subscribe(TopicSessionReset, func(ctx context.Context, event Event) { received <- event })
+
+	l.notifications <- &pq.Notification{Extra: `{"topic":"session.reset","source":"node-1","payload":{}}`}
+	l.notifications <- nil
+	l.notifications <- &pq.Notification{Extra: `not json`}
+	l.notifications <- &pq.Notification{Extra: `{"topic":"session.reset","source":"node-2","payload":{"session_id":"s1"}}`}
+
+	select {
+	case event := <-received:
+		if !event.Remote || event.Source != "node-2" {
+			t.Fatalf("event = %+v, want remote event from node-2", event)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for remote event")
+	}
+	select {
+	case event := <-received:
+		t.Fatalf("unexpected extra event %+v", event)
+	default:
+	}
+
+	if err := bus.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	if !l.closed {
+		t.Fatal("expected listener to be closed")
+	}
+}
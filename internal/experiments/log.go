@@ -0,0 +1,136 @@
+package experiments
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Record types in the experiment log.
+const (
+	RecordExposure = "exposure"
+	RecordOutcome  = "outcome"
+)
+
+// Record is a line in the experiment log. An exposure is written when a run
+// starts under an assigned variant; an outcome is written for that run when
+// it finishes and is joined to its exposures by RunID.
+type Record struct {
+	Type string    `json:"type"`
+	Time time.Time `json:"time"`
+
+	RunID     string `json:"run_id"`
+	SessionID string `json:"session_id,omitempty"`
+	Channel   string `json:"channel,omitempty"`
+
+	// Exposure fields.
+	ExperimentID string `json:"experiment_id,omitempty"`
+	VariantID    string `json:"variant_id,omitempty"`
+	Subject      string `json:"subject,omitempty"`
+
+	// Outcome fields.
+	DurationMs   int64 `json:"duration_ms,omitempty"`
+	InputTokens  int   `json:"input_tokens,omitempty"`
+	OutputTokens int   `json:"output_tokens,omitempty"`
+	ToolCalls    int   `json:"tool_calls,omitempty"`
+	Errors       int   `json:"errors,omitempty"`
+	Failed       bool  `json:"failed,omitempty"`
+}
+
+// Log appends experiment records to a JSONL file.
+type Log struct {
+	mu   sync.Mutex
+	path string
+}
+
+// DefaultLogPath returns ~/.nexus/experiments.jsonl.
+func DefaultLogPath() string {
+	home, err := os.UserHomeDir()
+	if err != nil || strings.TrimSpace(home) == "" {
+		home = "."
+	}
+	return filepath.Join(home, ".nexus", "experiments.jsonl")
+}
+
+// NewLog returns a log backed by path, or the default path when empty.
+func NewLog(path string) *Log {
+	if strings.TrimSpace(path) == "" {
+		path = DefaultLogPath()
+	}
+	return &Log{path: path}
+}
+
+// Path returns the backing file path.
+func (l *Log) Path() string {
+	return l.path
+}
+
+// Append writes records to the end of the log.
+func (l *Log) Append(records ...Record) error {
+	if len(records) == 0 {
+		return nil
+	}
+	var buf []byte
+	for _, record := range records {
+		data, err := json.Marshal(record)
+		if err != nil {
+			return err
+		}
+		buf = append(append(buf, data...), '\n')
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if err := os.MkdirAll(filepath.Dir(l.path), 0700); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(l.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(buf); err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// Load reads all records. A missing file is treated as an empty log.
+func (l *Log) Load() ([]Record, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	f, err := os.Open(l.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("open experiment log: %w", err)
+	}
+	defer f.Close()
+
+	var records []Record
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+		var record Record
+		if err := json.Unmarshal([]byte(text), &record); err != nil {
+			return nil, fmt.Errorf("parse experiment log line %d: %w", line, err)
+		}
+		records = append(records, record)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read experiment log: %w", err)
+	}
+	return records, nil
+}
@@ -23,18 +23,29 @@ func NewManager(cfg Config) *Manager {
 
 // Resolve returns merged overrides for the subject.
 func (m *Manager) Resolve(subject string) Overrides {
+	return m.ResolveSubject(Subject{UserID: subject})
+}
+
+// ResolveSubject returns merged overrides, assigning each experiment by the
+// unit it is configured for.
+func (m *Manager) ResolveSubject(subject Subject) Overrides {
 	var out Overrides
-	if subject == "" || len(m.experiments) == 0 {
+	if m == nil || len(m.experiments) == 0 {
 		return out
 	}
 	for _, exp := range m.experiments {
-		variant := selectVariant(subject, exp)
+		key := subjectKey(exp, subject)
+		if key == "" {
+			continue
+		}
+		variant := selectVariant(key, exp)
 		if variant == nil {
 			continue
 		}
 		out.Assignments = append(out.Assignments, Assignment{
 			ExperimentID: exp.ID,
 			VariantID:    variant.ID,
+			Subject:      key,
 		})
 		if variant.Config.SystemPrompt != "" {
 			out.SystemPrompt = variant.Config.SystemPrompt
@@ -49,6 +60,24 @@ func (m *Manager) Resolve(subject string) Overrides {
 	return out
 }
 
+// Active reports whether any experiment is running.
+func (m *Manager) Active() bool {
+	return m != nil && len(m.experiments) > 0
+}
+
+// subjectKey returns the identifier exp is bucketed by.
+func subjectKey(exp Experiment, subject Subject) string {
+	user := strings.TrimSpace(subject.UserID)
+	session := strings.TrimSpace(subject.SessionID)
+	if strings.EqualFold(strings.TrimSpace(exp.AssignBy), AssignBySession) {
+		return session
+	}
+	if user != "" {
+		return user
+	}
+	return session
+}
+
 func selectVariant(subject string, exp Experiment) *Variant {
 	if exp.ID == "" || exp.Allocation <= 0 || len(exp.Variants) == 0 {
 		return nil
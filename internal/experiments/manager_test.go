@@ -25,3 +25,38 @@ func TestResolve(t *testing.T) {
 		t.Fatalf("expected system prompt override")
 	}
 }
+
+func TestResolveSubjectAssignBy(t *testing.T) {
+	variants := []Variant{{ID: "a", Weight: 50}, {ID: "b", Weight: 50}}
+	mgr := NewManager(Config{
+		Experiments: []Experiment{
+			{ID: "by-user", Status: "active", Allocation: 100, Variants: variants},
+			{ID: "by-session", Status: "active", Allocation: 100, AssignBy: AssignBySession, Variants: variants},
+		},
+	})
+
+	out := mgr.ResolveSubject(Subject{UserID: "user-1", SessionID: "session-1"})
+	if len(out.Assignments) != 2 {
+		t.Fatalf("expected 2 assignments, got %d", len(out.Assignments))
+	}
+	if got := out.Assignments[0].Subject; got != "user-1" {
+		t.Fatalf("by-user subject = %q, want user-1", got)
+	}
+	if got := out.Assignments[1].Subject; got != "session-1" {
+		t.Fatalf("by-session subject = %q, want session-1", got)
+	}
+
+	// Assignment is deterministic and follows the user across sessions.
+	again := mgr.ResolveSubject(Subject{UserID: "user-1", SessionID: "session-2"})
+	if again.Assignments[0].VariantID != out.Assignments[0].VariantID {
+		t.Fatalf("user assignment changed across sessions")
+	}
+	if again.Assignments[1].Subject != "session-2" {
+		t.Fatalf("session assignment should key on the new session")
+	}
+
+	anonymous := mgr.ResolveSubject(Subject{SessionID: "session-3"})
+	if got := anonymous.Assignments[0].Subject; got != "session-3" {
+		t.Fatalf("by-user without a user should fall back to session, got %q", got)
+	}
+}
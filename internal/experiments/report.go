@@ -0,0 +1,140 @@
+package experiments
+
+import (
+	"sort"
+	"time"
+)
+
+// Rating is the net reaction feedback for a run.
+type Rating struct {
+	Positive int
+	Negative int
+}
+
+// ReportOptions filters a report.
+type ReportOptions struct {
+	// ExperimentID limits the report to one experiment.
+	ExperimentID string
+	// Since drops exposures older than this time.
+	Since time.Time
+}
+
+// Report compares outcome metrics across variants.
+type Report struct {
+	Experiments []ExperimentReport `json:"experiments"`
+}
+
+// ExperimentReport holds per-variant results for one experiment.
+type ExperimentReport struct {
+	ID       string          `json:"id"`
+	Variants []VariantReport `json:"variants"`
+}
+
+// VariantReport summarizes the runs exposed to one variant. Averages are
+// over completed runs, those with an outcome record.
+type VariantReport struct {
+	ID              string  `json:"id"`
+	Exposures       int     `json:"exposures"`
+	Subjects        int     `json:"subjects"`
+	Completed       int     `json:"completed"`
+	FailureRate     float64 `json:"failure_rate"`
+	AvgDurationMs   float64 `json:"avg_duration_ms"`
+	AvgInputTokens  float64 `json:"avg_input_tokens"`
+	AvgOutputTokens float64 `json:"avg_output_tokens"`
+	AvgToolCalls    float64 `json:"avg_tool_calls"`
+	Positive        int     `json:"positive"`
+	Negative        int     `json:"negative"`
+	Satisfaction    float64 `json:"satisfaction"`
+}
+
+// BuildReport joins exposures to run outcomes and feedback ratings (keyed by
+// run ID) and aggregates them per experiment and variant.
+func BuildReport(records []Record, ratings map[string]Rating, opts ReportOptions) Report {
+	outcomes := make(map[string]Record)
+	for _, record := range records {
+		if record.Type == RecordOutcome && record.RunID != "" {
+			outcomes[record.RunID] = record
+		}
+	}
+
+	type variantKey struct{ experiment, variant string }
+	type accumulator struct {
+		report   VariantReport
+		subjects map[string]struct{}
+		runs     map[string]struct{}
+		failed   int
+		duration int64
+		input    int
+		output   int
+		tools    int
+	}
+	variants := make(map[variantKey]*accumulator)
+	for _, record := range records {
+		if record.Type != RecordExposure || record.ExperimentID == "" {
+			continue
+		}
+		if opts.ExperimentID != "" && record.ExperimentID != opts.ExperimentID {
+			continue
+		}
+		if !opts.Since.IsZero() && record.Time.Before(opts.Since) {
+			continue
+		}
+		key := variantKey{record.ExperimentID, record.VariantID}
+		acc := variants[key]
+		if acc == nil {
+			acc = &accumulator{
+				report:   VariantReport{ID: record.VariantID},
+				subjects: make(map[string]struct{}),
+				runs:     make(map[string]struct{}),
+			}
+			variants[key] = acc
+		}
+		if _, seen := acc.runs[record.RunID]; seen && record.RunID != "" {
+			continue
+		}
+		acc.runs[record.RunID] = struct{}{}
+		acc.report.Exposures++
+		if record.Subject != "" {
+			acc.subjects[record.Subject] = struct{}{}
+		}
+		if outcome, ok := outcomes[record.RunID]; ok {
+			acc.report.Completed++
+			if outcome.Failed {
+				acc.failed++
+			}
+			acc.duration += outcome.DurationMs
+			acc.input += outcome.InputTokens
+			acc.output += outcome.OutputTokens
+			acc.tools += outcome.ToolCalls
+		}
+		if rating, ok := ratings[record.RunID]; ok {
+			acc.report.Positive += rating.Positive
+			acc.report.Negative += rating.Negative
+		}
+	}
+
+	byExperiment := make(map[string][]VariantReport)
+	for key, acc := range variants {
+		report := acc.report
+		report.Subjects = len(acc.subjects)
+		if n := float64(report.Completed); n > 0 {
+			report.FailureRate = float64(acc.failed) / n
+			report.AvgDurationMs = float64(acc.duration) / n
+			report.AvgInputTokens = float64(acc.input) / n
+			report.AvgOutputTokens = float64(acc.output) / n
+			report.AvgToolCalls = float64(acc.tools) / n
+		}
+		if total := report.Positive + report.Negative; total > 0 {
+			report.Satisfaction = float64(report.Positive) / float64(total)
+		}
+		byExperiment[key.experiment] = append(byExperiment[key.experiment], report)
+	}
+
+	out := Report{Experiments: make([]ExperimentReport, 0, len(byExperiment))}
+	for id, reports := range byExperiment {
+		sort.Slice(reports, func(i, j int) bool { return reports[i].ID < reports[j].ID })
+		out.Experiments = append(out.Experiments, ExperimentReport{ID: id, Variants: reports})
+	}
+	sort.Slice(out.Experiments, func(i, j int) bool { return out.Experiments[i].ID < out.Experiments[j].ID })
+	return out
+}
@@ -0,0 +1,81 @@
+package experiments
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestLogRoundTrip(t *testing.T) {
+	log := NewLog(filepath.Join(t.TempDir(), "nested", "experiments.jsonl"))
+	if records, err := log.Load(); err != nil || len(records) != 0 {
+		t.Fatalf("missing log should load empty, got %v, %v", records, err)
+	}
+	now := time.Now().UTC().Truncate(time.Second)
+	if err := log.Append(
+		Record{Type: RecordExposure, Time: now, RunID: "run-1", ExperimentID: "exp", VariantID: "a", Subject: "u1"},
+		Record{Type: RecordOutcome, Time: now, RunID: "run-1", DurationMs: 1200, ToolCalls: 2},
+	); err != nil {
+		t.Fatalf("Append() error = %v", err)
+	}
+	records, err := log.Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if len(records) != 2 || records[0].VariantID != "a" || records[1].DurationMs != 1200 {
+		t.Fatalf("unexpected records: %+v", records)
+	}
+	if !records[0].Time.Equal(now) {
+		t.Fatalf("time = %v, want %v", records[0].Time, now)
+	}
+}
+
+func TestBuildReport(t *testing.T) {
+	now := time.Now()
+	exposure := func(run, variant, subject string, at time.Time) Record {
+		return Record{Type: RecordExposure, Time: at, RunID: run, ExperimentID: "exp", VariantID: variant, Subject: subject}
+	}
+	records := []Record{
+		exposure("r1", "a", "u1", now),
+		exposure("r2", "a", "u1", now),
+		exposure("r3", "b", "u2", now),
+		exposure("old", "b", "u3", now.Add(-48*time.Hour)),
+		{Type: RecordExposure, Time: now, RunID: "r4", ExperimentID: "other", VariantID: "x"},
+		{Type: RecordOutcome, RunID: "r1", DurationMs: 1000, InputTokens: 100, OutputTokens: 50, ToolCalls: 1},
+		{Type: RecordOutcome, RunID: "r2", DurationMs: 3000, InputTokens: 300, OutputTokens: 150, ToolCalls: 3, Failed: true},
+		{Type: RecordOutcome, RunID: "old", DurationMs: 9000},
+	}
+	ratings := map[string]Rating{
+		"r1": {Positive: 1},
+		"r2": {Negative: 1},
+		"r3": {Positive: 2},
+	}
+
+	report := BuildReport(records, ratings, ReportOptions{ExperimentID: "exp", Since: now.Add(-time.Hour)})
+	if len(report.Experiments) != 1 || report.Experiments[0].ID != "exp" {
+		t.Fatalf("expected only exp, got %+v", report.Experiments)
+	}
+	variants := report.Experiments[0].Variants
+	if len(variants) != 2 {
+		t.Fatalf("expected 2 variants, got %+v", variants)
+	}
+
+	a := variants[0]
+	if a.ID != "a" || a.Exposures != 2 || a.Subjects != 1 || a.Completed != 2 {
+		t.Fatalf("variant a counts: %+v", a)
+	}
+	if a.FailureRate != 0.5 || a.AvgDurationMs != 2000 || a.AvgInputTokens != 200 || a.AvgToolCalls != 2 {
+		t.Fatalf("variant a averages: %+v", a)
+	}
+	if a.Positive != 1 || a.Negative != 1 || a.Satisfaction != 0.5 {
+		t.Fatalf("variant a feedback: %+v", a)
+	}
+
+	b := variants[1]
+	if b.ID != "b" || b.Exposures != 1 || b.Completed != 0 || b.AvgDurationMs != 0 {
+		t.Fatalf("variant b should exclude old runs and have no outcomes: %+v", b)
+	}
+	if b.Satisfaction != 1 {
+		t.Fatalf("variant b satisfaction = %v, want 1", b.Satisfaction)
+	}
+}
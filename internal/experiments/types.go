@@ -3,14 +3,24 @@ package experiments
 // Config defines experiment configuration.
 type Config struct {
 	Experiments []Experiment `yaml:"experiments"`
+	// LogPath is where exposures and run outcomes are appended
+	// (default: ~/.nexus/experiments.jsonl).
+	LogPath string `yaml:"log_path"`
 }
 
+// Assignment units.
+const (
+	AssignByUser    = "user"
+	AssignBySession = "session"
+)
+
 // Experiment defines a single experiment.
 type Experiment struct {
 	ID          string    `yaml:"id"`
 	Description string    `yaml:"description"`
 	Status      string    `yaml:"status"`     // active | inactive
 	Allocation  int       `yaml:"allocation"` // percentage 0-100
+	AssignBy    string    `yaml:"assign_by"`  // user (default) | session
 	Variants    []Variant `yaml:"variants"`
 }
 
@@ -28,10 +38,18 @@ type VariantConfig struct {
 	Model        string `yaml:"model"`
 }
 
+// Subject identifies who is being assigned. Experiments assigned by user
+// fall back to the session when the user is unknown.
+type Subject struct {
+	UserID    string
+	SessionID string
+}
+
 // Assignment records a subject's experiment variant.
 type Assignment struct {
 	ExperimentID string `json:"experiment_id"`
 	VariantID    string `json:"variant_id"`
+	Subject      string `json:"subject,omitempty"`
 }
 
 // Overrides represents merged experiment overrides.
@@ -0,0 +1,106 @@
+// Package feedback turns reactions on agent replies into run-level feedback.
+//
+// The gateway tracks which run produced each outbound message. When a user
+// reacts to one of those messages, the reaction is classified as positive or
+// negative, appended to a JSONL store, and reflected in Prometheus metrics.
+// The store backs `nexus feedback report`.
+package feedback
+
+import (
+	"strings"
+	"time"
+	"unicode"
+)
+
+// Rating is the sentiment of a reaction.
+type Rating int
+
+const (
+	RatingNegative Rating = -1
+	RatingNeutral  Rating = 0
+	RatingPositive Rating = 1
+)
+
+// String returns the metric label for the rating.
+func (r Rating) String() string {
+	switch {
+	case r > 0:
+		return "positive"
+	case r < 0:
+		return "negative"
+	}
+	return "neutral"
+}
+
+// Entry is a single stored reaction tied to the run that produced the
+// reacted-to message.
+type Entry struct {
+	RunID     string    `json:"run_id"`
+	SessionID string    `json:"session_id,omitempty"`
+	Channel   string    `json:"channel"`
+	ChatID    string    `json:"chat_id,omitempty"`
+	MessageID string    `json:"message_id"`
+	UserID    string    `json:"user_id,omitempty"`
+	Reaction  string    `json:"reaction"`
+	Rating    Rating    `json:"rating"`
+	Removed   bool      `json:"removed,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Default reaction sets. Entries are matched after normalization, so skin
+// tone and emoji presentation variants of these also count.
+var (
+	defaultPositive = []string{"👍", "+1", "thumbsup", "thumbs_up", "❤", "heart"}
+	defaultNegative = []string{"👎", "-1", "thumbsdown", "thumbs_down"}
+)
+
+// Classifier maps emoji and platform reaction names to ratings.
+type Classifier struct {
+	ratings map[string]Rating
+}
+
+// NewClassifier returns a classifier that recognizes the default thumbs
+// up/down reactions plus any extra positive and negative reactions.
+func NewClassifier(positive, negative []string) *Classifier {
+	c := &Classifier{ratings: make(map[string]Rating)}
+	for _, list := range [][]string{defaultPositive, positive} {
+		for _, reaction := range list {
+			if key := normalizeReaction(reaction); key != "" {
+				c.ratings[key] = RatingPositive
+			}
+		}
+	}
+	for _, list := range [][]string{defaultNegative, negative} {
+		for _, reaction := range list {
+			if key := normalizeReaction(reaction); key != "" {
+				c.ratings[key] = RatingNegative
+			}
+		}
+	}
+	return c
+}
+
+// Rate returns the rating for a reaction, or RatingNeutral if the reaction is
+// not a feedback signal.
+func (c *Classifier) Rate(reaction string) Rating {
+	if c == nil {
+		return RatingNeutral
+	}
+	return c.ratings[normalizeReaction(reaction)]
+}
+
+// normalizeReaction strips Slack shortcode colons and skin tone suffixes,
+// emoji skin tone modifiers, and variation selectors.
+func normalizeReaction(reaction string) string {
+	reaction = strings.ToLower(strings.TrimSpace(reaction))
+	reaction = strings.Trim(reaction, ":")
+	if idx := strings.Index(reaction, "::skin-tone-"); idx >= 0 {
+		reaction = reaction[:idx]
+	}
+	return strings.Map(func(r rune) rune {
+		if r == '\ufe0f' || (r >= 0x1f3fb && r <= 0x1f3ff) || unicode.IsSpace(r) {
+			return -1
+		}
+		return r
+	}, reaction)
+}
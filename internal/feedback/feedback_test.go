@@ -0,0 +1,177 @@
+package feedback
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/haasonsaas/nexus/internal/channels"
+	"github.com/haasonsaas/nexus/pkg/models"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestClassifierRate(t *testing.T) {
+	c := NewClassifier([]string{":rocket:"}, []string{"😡"})
+	tests := []struct {
+		reaction string
+		want     Rating
+	}{
+		{"👍", RatingPositive},
+		{"👍🏽", RatingPositive},
+		{"+1", RatingPositive},
+		{"+1::skin-tone-3", RatingPositive},
+		{":thumbsup:", RatingPositive},
+		{"❤️", RatingPositive},
+		{"rocket", RatingPositive},
+		{"👎", RatingNegative},
+		{"-1", RatingNegative},
+		{"thumbsdown", RatingNegative},
+		{"😡", RatingNegative},
+		{"eyes", RatingNeutral},
+		{"", RatingNeutral},
+	}
+	for _, tc := range tests {
+		if got := c.Rate(tc.reaction); got != tc.want {
+			t.Errorf("Rate(%q) = %v, want %v", tc.reaction, got, tc.want)
+		}
+	}
+}
+
+func TestStoreAppendLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nested", "feedback.jsonl")
+	store := NewStore(path)
+
+	entries, err := store.Load()
+	if err != nil || len(entries) != 0 {
+		t.Fatalf("expected empty store, got %v, %v", entries, err)
+	}
+
+	want := Entry{RunID: "s1-m1", Channel: "slack", MessageID: "1.2", Reaction: "-1", Rating: RatingNegative}
+	if err := store.Append(want); err != nil {
+		t.Fatalf("Append() error = %v", err)
+	}
+	if err := store.Append(Entry{RunID: "s1-m2", Channel: "slack", Rating: RatingPositive}); err != nil {
+		t.Fatalf("Append() error = %v", err)
+	}
+
+	entries, err = store.Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if len(entries) != 2 || entries[0].RunID != want.RunID || entries[0].Rating != RatingNegative {
+		t.Fatalf("unexpected entries: %+v", entries)
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("stat: %v", err)
+	}
+	if info.Mode().Perm() != 0600 {
+		t.Fatalf("expected 0600 permissions, got %v", info.Mode().Perm())
+	}
+}
+
+func TestAggregateCountsLatestVotePerUser(t *testing.T) {
+	now := time.Now()
+	entries := []Entry{
+		{RunID: "r1", Channel: "discord", UserID: "u1", Reaction: "👎", Rating: RatingNegative, CreatedAt: now},
+		{RunID: "r1", Channel: "discord", UserID: "u2", Reaction: "👍", Rating: RatingPositive, CreatedAt: now},
+		{RunID: "r1", Channel: "discord", UserID: "u2", Reaction: "👍", Rating: RatingPositive, Removed: true, CreatedAt: now.Add(time.Second)},
+		{RunID: "r2", Channel: "discord", UserID: "u1", Reaction: "👍", Rating: RatingPositive, CreatedAt: now},
+		{RunID: "r3", Channel: "slack", UserID: "u1", Reaction: "-1", Rating: RatingNegative, CreatedAt: now},
+		{RunID: "r3", Channel: "slack", UserID: "u1", Reaction: "-1", Rating: RatingNegative, Removed: true, CreatedAt: now},
+	}
+
+	runs := Aggregate(entries)
+	if len(runs) != 2 {
+		t.Fatalf("expected 2 runs with active votes, got %+v", runs)
+	}
+	if runs[0].RunID != "r1" || runs[0].Positive != 0 || runs[0].Negative != 1 {
+		t.Fatalf("expected r1 first with one negative vote, got %+v", runs[0])
+	}
+	if runs[1].RunID != "r2" || runs[1].Score() != 1 {
+		t.Fatalf("expected r2 with score 1, got %+v", runs[1])
+	}
+}
+
+func TestBuildReport(t *testing.T) {
+	now := time.Now()
+	entries := []Entry{
+		{RunID: "old", Channel: "slack", UserID: "u1", Reaction: "-1", Rating: RatingNegative, CreatedAt: now.Add(-48 * time.Hour)},
+		{RunID: "r1", Channel: "slack", UserID: "u1", Reaction: "-1", Rating: RatingNegative, CreatedAt: now},
+		{RunID: "r2", Channel: "slack", UserID: "u1", Reaction: "+1", Rating: RatingPositive, CreatedAt: now},
+		{RunID: "r3", Channel: "telegram", UserID: "u1", Reaction: "👍", Rating: RatingPositive, CreatedAt: now},
+		{RunID: "r4", Channel: "telegram", UserID: "u1", Reaction: "👎", Rating: RatingNegative, CreatedAt: now},
+	}
+
+	report := BuildReport(entries, ReportOptions{Since: now.Add(-time.Hour), Limit: 1})
+	if report.Runs != 4 || report.Positive != 2 || report.Negative != 2 {
+		t.Fatalf("unexpected totals: %+v", report)
+	}
+	if report.Satisfaction != 0.5 {
+		t.Fatalf("expected satisfaction 0.5, got %v", report.Satisfaction)
+	}
+	if len(report.Channels) != 2 || report.Channels[0].Channel != "slack" {
+		t.Fatalf("unexpected channels: %+v", report.Channels)
+	}
+	if len(report.LowRated) != 1 || report.LowRated[0].Negative != 1 {
+		t.Fatalf("expected one low-rated run, got %+v", report.LowRated)
+	}
+}
+
+func TestRecorderRecordsTrackedReactions(t *testing.T) {
+	store := NewStore(filepath.Join(t.TempDir(), "feedback.jsonl"))
+	metrics := NewMetrics()
+	recorder := NewRecorder(store, NewClassifier(nil, nil), metrics)
+	recorder.Track("telegram", "42", "7", Target{RunID: "sess-msg", SessionID: "sess"})
+
+	untracked, err := recorder.Record(&channels.ReactionEvent{Channel: models.ChannelTelegram, ChatID: "43", MessageID: "7", Emoji: "👍"})
+	if err != nil || untracked != nil {
+		t.Fatalf("expected reaction in another chat to be ignored, got %+v, %v", untracked, err)
+	}
+	neutral, err := recorder.Record(&channels.ReactionEvent{Channel: models.ChannelTelegram, ChatID: "42", MessageID: "7", Emoji: "👀"})
+	if err != nil || neutral != nil {
+		t.Fatalf("expected neutral reaction to be ignored, got %+v, %v", neutral, err)
+	}
+
+	entry, err := recorder.Record(&channels.ReactionEvent{Channel: models.ChannelTelegram, ChatID: "42", MessageID: "7", UserID: "u1", Emoji: "👎"})
+	if err != nil {
+		t.Fatalf("Record() error = %v", err)
+	}
+	if entry == nil || entry.RunID != "sess-msg" || entry.SessionID != "sess" || entry.Rating != RatingNegative {
+		t.Fatalf("unexpected entry: %+v", entry)
+	}
+	if got := testutil.ToFloat64(metrics.Votes.WithLabelValues("telegram", "negative")); got != 1 {
+		t.Fatalf("expected 1 negative vote, got %v", got)
+	}
+	if got := testutil.ToFloat64(metrics.Satisfaction.WithLabelValues("telegram")); got != 0 {
+		t.Fatalf("expected satisfaction 0, got %v", got)
+	}
+
+	if _, err := recorder.Record(&channels.ReactionEvent{Channel: models.ChannelTelegram, ChatID: "42", MessageID: "7", UserID: "u1", Emoji: "👎", Removed: true}); err != nil {
+		t.Fatalf("Record() error = %v", err)
+	}
+	if got := testutil.ToFloat64(metrics.Votes.WithLabelValues("telegram", "negative")); got != 0 {
+		t.Fatalf("expected removal to clear the vote, got %v", got)
+	}
+
+	entries, err := store.Load()
+	if err != nil || len(entries) != 2 {
+		t.Fatalf("expected 2 stored entries, got %d, %v", len(entries), err)
+	}
+}
+
+func TestRecorderTrackEvictsOldest(t *testing.T) {
+	recorder := NewRecorder(nil, nil, nil)
+	recorder.limit = 2
+	recorder.Track("slack", "C1", "1", Target{RunID: "r1"})
+	recorder.Track("slack", "C1", "2", Target{RunID: "r2"})
+	recorder.Track("slack", "C1", "3", Target{RunID: "r3"})
+
+	if entry, _ := recorder.Record(&channels.ReactionEvent{Channel: models.ChannelSlack, ChatID: "C1", MessageID: "1", Emoji: "+1"}); entry != nil {
+		t.Fatalf("expected oldest message to be evicted, got %+v", entry)
+	}
+	if entry, _ := recorder.Record(&channels.ReactionEvent{Channel: models.ChannelSlack, ChatID: "C1", MessageID: "3", Emoji: "+1"}); entry == nil || entry.RunID != "r3" {
+		t.Fatalf("expected newest message to be tracked, got %+v", entry)
+	}
+}
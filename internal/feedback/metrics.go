@@ -0,0 +1,68 @@
+package feedback
+
+import (
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// Metrics exposes feedback counts and satisfaction in Prometheus.
+type Metrics struct {
+	// ReactionsTotal counts feedback reactions as they are added.
+	// Labels: channel, rating (positive|negative)
+	ReactionsTotal *prometheus.CounterVec
+
+	// Votes tracks the current number of active feedback reactions.
+	// Labels: channel, rating (positive|negative)
+	Votes *prometheus.GaugeVec
+
+	// Satisfaction is the share of active feedback reactions that are
+	// positive, between 0 and 1.
+	// Labels: channel
+	Satisfaction *prometheus.GaugeVec
+}
+
+var (
+	metricsOnce     sync.Once
+	metricsInstance *Metrics
+)
+
+// NewMetrics returns the process-wide feedback metrics.
+func NewMetrics() *Metrics {
+	metricsOnce.Do(func() {
+		metricsInstance = &Metrics{
+			ReactionsTotal: promauto.NewCounterVec(prometheus.CounterOpts{
+				Name: "nexus_feedback_reactions_total",
+				Help: "Total number of feedback reactions on agent replies",
+			}, []string{"channel", "rating"}),
+			Votes: promauto.NewGaugeVec(prometheus.GaugeOpts{
+				Name: "nexus_feedback_votes",
+				Help: "Current number of active feedback reactions on agent replies",
+			}, []string{"channel", "rating"}),
+			Satisfaction: promauto.NewGaugeVec(prometheus.GaugeOpts{
+				Name: "nexus_feedback_satisfaction_ratio",
+				Help: "Share of active feedback reactions that are positive",
+			}, []string{"channel"}),
+		}
+	})
+	return metricsInstance
+}
+
+func (m *Metrics) recordReaction(channel string, rating Rating) {
+	if m == nil || m.ReactionsTotal == nil {
+		return
+	}
+	m.ReactionsTotal.WithLabelValues(channel, rating.String()).Inc()
+}
+
+func (m *Metrics) setVotes(channel string, positive, negative int) {
+	if m == nil || m.Votes == nil || m.Satisfaction == nil {
+		return
+	}
+	m.Votes.WithLabelValues(channel, RatingPositive.String()).Set(float64(positive))
+	m.Votes.WithLabelValues(channel, RatingNegative.String()).Set(float64(negative))
+	if positive+negative > 0 {
+		m.Satisfaction.WithLabelValues(channel).Set(Satisfaction(positive, negative))
+	}
+}
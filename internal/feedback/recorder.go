@@ -0,0 +1,161 @@
+package feedback
+
+import (
+	"sync"
+	"time"
+
+	"github.com/haasonsaas/nexus/internal/channels"
+)
+
+// defaultTrackLimit bounds how many outbound messages are remembered for
+// reaction lookup. Reactions on older messages are ignored.
+const defaultTrackLimit = 10000
+
+// Target identifies the run that produced an outbound message.
+type Target struct {
+	RunID     string
+	SessionID string
+}
+
+// Recorder maps reactions on tracked outbound messages to runs, stores them,
+// and keeps the feedback metrics current.
+type Recorder struct {
+	store      *Store
+	classifier *Classifier
+	metrics    *Metrics
+
+	mu      sync.Mutex
+	targets map[string]Target
+	order   []string
+	limit   int
+	votes   map[string]*[2]int // channel -> [positive, negative]
+}
+
+// NewRecorder creates a recorder. metrics may be nil.
+func NewRecorder(store *Store, classifier *Classifier, metrics *Metrics) *Recorder {
+	if classifier == nil {
+		classifier = NewClassifier(nil, nil)
+	}
+	return &Recorder{
+		store:      store,
+		classifier: classifier,
+		metrics:    metrics,
+		targets:    make(map[string]Target),
+		limit:      defaultTrackLimit,
+		votes:      make(map[string]*[2]int),
+	}
+}
+
+// Load seeds the vote gauges from previously stored feedback.
+func (r *Recorder) Load() error {
+	if r.store == nil {
+		return nil
+	}
+	entries, err := r.store.Load()
+	if err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, run := range Aggregate(entries) {
+		counts := r.votesLocked(run.Channel)
+		counts[0] += run.Positive
+		counts[1] += run.Negative
+	}
+	for channel, counts := range r.votes {
+		r.metrics.setVotes(channel, counts[0], counts[1])
+	}
+	return nil
+}
+
+// Track remembers that messageID in chatID was produced by target.
+func (r *Recorder) Track(channel, chatID, messageID string, target Target) {
+	if messageID == "" || target.RunID == "" {
+		return
+	}
+	key := targetKey(channel, chatID, messageID)
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, exists := r.targets[key]; !exists {
+		r.order = append(r.order, key)
+	}
+	r.targets[key] = target
+	for len(r.order) > r.limit {
+		delete(r.targets, r.order[0])
+		r.order = r.order[1:]
+	}
+}
+
+// Record stores a reaction event. It returns nil without error when the
+// reaction is not on a tracked message or is not a feedback reaction.
+func (r *Recorder) Record(event *channels.ReactionEvent) (*Entry, error) {
+	if event == nil {
+		return nil, nil
+	}
+	rating := r.classifier.Rate(event.Emoji)
+	if rating == RatingNeutral {
+		return nil, nil
+	}
+	channel := string(event.Channel)
+
+	r.mu.Lock()
+	target, ok := r.targets[targetKey(channel, event.ChatID, event.MessageID)]
+	r.mu.Unlock()
+	if !ok {
+		return nil, nil
+	}
+
+	createdAt := event.Timestamp
+	if createdAt.IsZero() {
+		createdAt = time.Now()
+	}
+	entry := Entry{
+		RunID:     target.RunID,
+		SessionID: target.SessionID,
+		Channel:   channel,
+		ChatID:    event.ChatID,
+		MessageID: event.MessageID,
+		UserID:    event.UserID,
+		Reaction:  event.Emoji,
+		Rating:    rating,
+		Removed:   event.Removed,
+		CreatedAt: createdAt.UTC(),
+	}
+	if r.store != nil {
+		if err := r.store.Append(entry); err != nil {
+			return nil, err
+		}
+	}
+
+	r.mu.Lock()
+	counts := r.votesLocked(channel)
+	idx := 0
+	if rating == RatingNegative {
+		idx = 1
+	}
+	if event.Removed {
+		if counts[idx] > 0 {
+			counts[idx]--
+		}
+	} else {
+		counts[idx]++
+		r.metrics.recordReaction(channel, rating)
+	}
+	r.metrics.setVotes(channel, counts[0], counts[1])
+	r.mu.Unlock()
+
+	return &entry, nil
+}
+
+func (r *Recorder) votesLocked(channel string) *[2]int {
+	counts, ok := r.votes[channel]
+	if !ok {
+		counts = &[2]int{}
+		r.votes[channel] = counts
+	}
+	return counts
+}
+
+func targetKey(channel, chatID, messageID string) string {
+	return channel + "\x00" + chatID + "\x00" + messageID
+}
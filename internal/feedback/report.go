@@ -0,0 +1,160 @@
+package feedback
+
+import (
+	"sort"
+	"time"
+)
+
+// RunFeedback is the net feedback for a single run.
+type RunFeedback struct {
+	RunID     string    `json:"run_id"`
+	SessionID string    `json:"session_id,omitempty"`
+	Channel   string    `json:"channel"`
+	Positive  int       `json:"positive"`
+	Negative  int       `json:"negative"`
+	Reactions []string  `json:"reactions,omitempty"`
+	LastAt    time.Time `json:"last_at"`
+}
+
+// Score returns positive minus negative reactions.
+func (r RunFeedback) Score() int {
+	return r.Positive - r.Negative
+}
+
+// Satisfaction returns the share of positive ratings, or 0 when there are none.
+func Satisfaction(positive, negative int) float64 {
+	if positive+negative == 0 {
+		return 0
+	}
+	return float64(positive) / float64(positive+negative)
+}
+
+// Aggregate folds entries into per-run feedback. Each user's reaction counts
+// once per run, and a later removal cancels it. Neutral reactions are
+// ignored. Runs are ordered by score, lowest first, then most recent first.
+func Aggregate(entries []Entry) []RunFeedback {
+	type voteKey struct{ run, user, reaction string }
+	votes := make(map[voteKey]Entry)
+	var order []voteKey
+	for _, entry := range entries {
+		if entry.RunID == "" || entry.Rating == RatingNeutral {
+			continue
+		}
+		key := voteKey{entry.RunID, entry.Channel + ":" + entry.UserID, normalizeReaction(entry.Reaction)}
+		if _, seen := votes[key]; !seen {
+			order = append(order, key)
+		}
+		votes[key] = entry
+	}
+
+	runs := make(map[string]*RunFeedback)
+	var runOrder []string
+	for _, key := range order {
+		entry := votes[key]
+		run, ok := runs[entry.RunID]
+		if !ok {
+			run = &RunFeedback{RunID: entry.RunID, SessionID: entry.SessionID, Channel: entry.Channel}
+			runs[entry.RunID] = run
+			runOrder = append(runOrder, entry.RunID)
+		}
+		if entry.CreatedAt.After(run.LastAt) {
+			run.LastAt = entry.CreatedAt
+		}
+		if entry.Removed {
+			continue
+		}
+		if entry.Rating > 0 {
+			run.Positive++
+		} else {
+			run.Negative++
+		}
+		run.Reactions = append(run.Reactions, entry.Reaction)
+	}
+
+	out := make([]RunFeedback, 0, len(runOrder))
+	for _, id := range runOrder {
+		if run := runs[id]; run.Positive+run.Negative > 0 {
+			out = append(out, *run)
+		}
+	}
+	sort.SliceStable(out, func(i, j int) bool {
+		if out[i].Score() != out[j].Score() {
+			return out[i].Score() < out[j].Score()
+		}
+		return out[i].LastAt.After(out[j].LastAt)
+	})
+	return out
+}
+
+// ChannelFeedback summarizes feedback for one channel.
+type ChannelFeedback struct {
+	Channel      string  `json:"channel"`
+	Runs         int     `json:"runs"`
+	Positive     int     `json:"positive"`
+	Negative     int     `json:"negative"`
+	Satisfaction float64 `json:"satisfaction"`
+}
+
+// Report summarizes feedback over a time window.
+type Report struct {
+	Since        time.Time         `json:"since"`
+	Runs         int               `json:"runs"`
+	Positive     int               `json:"positive"`
+	Negative     int               `json:"negative"`
+	Satisfaction float64           `json:"satisfaction"`
+	Channels     []ChannelFeedback `json:"channels,omitempty"`
+	LowRated     []RunFeedback     `json:"low_rated,omitempty"`
+}
+
+// ReportOptions filters a report.
+type ReportOptions struct {
+	// Since drops entries created before this time. Zero keeps everything.
+	Since time.Time
+
+	// Limit caps the number of low-rated runs. Zero means no limit.
+	Limit int
+}
+
+// BuildReport aggregates entries into a report. A run is low-rated when it
+// has at least one negative reaction and no more positive than negative.
+func BuildReport(entries []Entry, opts ReportOptions) *Report {
+	if !opts.Since.IsZero() {
+		filtered := make([]Entry, 0, len(entries))
+		for _, entry := range entries {
+			if !entry.CreatedAt.Before(opts.Since) {
+				filtered = append(filtered, entry)
+			}
+		}
+		entries = filtered
+	}
+
+	report := &Report{Since: opts.Since}
+	channels := make(map[string]*ChannelFeedback)
+	for _, run := range Aggregate(entries) {
+		report.Runs++
+		report.Positive += run.Positive
+		report.Negative += run.Negative
+		ch, ok := channels[run.Channel]
+		if !ok {
+			ch = &ChannelFeedback{Channel: run.Channel}
+			channels[run.Channel] = ch
+		}
+		ch.Runs++
+		ch.Positive += run.Positive
+		ch.Negative += run.Negative
+		if run.Negative > 0 && run.Score() <= 0 {
+			if opts.Limit <= 0 || len(report.LowRated) < opts.Limit {
+				report.LowRated = append(report.LowRated, run)
+			}
+		}
+	}
+	report.Satisfaction = Satisfaction(report.Positive, report.Negative)
+	for _, ch := range channels {
+		ch.Satisfaction = Satisfaction(ch.Positive, ch.Negative)
+		report.Channels = append(report.Channels, *ch)
+	}
+	sort.Slice(report.Channels, func(i, j int) bool {
+		return report.Channels[i].Channel < report.Channels[j].Channel
+	})
+	return report
+}
@@ -0,0 +1,105 @@
+package gateway
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/haasonsaas/nexus/internal/access"
+	"github.com/haasonsaas/nexus/internal/pairing"
+	"github.com/haasonsaas/nexus/pkg/models"
+)
+
+// accessGrantCache returns the cache over the access_grants table, opening
+// it on first use. Grants need database.url so every node sees them.
+func (s *Server) accessGrantCache() (*access.Cache, error) {
+	s.accessGrantsMu.Lock()
+	defer s.accessGrantsMu.Unlock()
+	if s.accessGrants != nil {
+		return s.accessGrants, nil
+	}
+	if s.config == nil || strings.TrimSpace(s.config.Database.URL) == "" {
+		return nil, access.ErrUnavailable
+	}
+	db, err := s.clusterDB()
+	if err != nil {
+		return nil, err
+	}
+	if db == nil {
+		return nil, access.ErrUnavailable
+	}
+	store, err := access.NewDBStore(db)
+	if err != nil {
+		return nil, err
+	}
+	s.accessGrants = access.NewCache(store, access.DefaultCacheTTL)
+	return s.accessGrants, nil
+}
+
+// accessGranted reports whether a runtime grant on list lets senderID
+// through on channel, and whether list has any grants at all. Lookup
+// failures are logged and count as no grants, leaving the config allowlists
+// in charge.
+func (s *Server) accessGranted(ctx context.Context, list access.List, channel models.ChannelType, senderID string) (granted, listed bool) {
+	cache, err := s.accessGrantCache()
+	if err != nil {
+		if !errors.Is(err, access.ErrUnavailable) {
+			s.logger.Warn("access grants unavailable", "error", err)
+		}
+		return false, false
+	}
+	grants, err := cache.Grants(ctx, list)
+	if err != nil {
+		s.logger.Warn("failed to load access grants", "list", list, "error", err)
+		return false, false
+	}
+	return access.Matches(grants, string(channel), senderID, time.Now()), len(grants) > 0
+}
+
+// accessGrantStore backs the /allow, /elevate, and /pair commands. Writes go
+// through the local cache and then invalidate every other node's copy.
+type accessGrantStore struct {
+	s *Server
+}
+
+func (a accessGrantStore) Put(ctx context.Context, grant access.Grant) error {
+	cache, err := a.s.accessGrantCache()
+	if err != nil {
+		return err
+	}
+	if err := cache.Put(ctx, grant); err != nil {
+		return err
+	}
+	a.s.InvalidateCache(ctx, cacheAccessGrants, "")
+	return nil
+}
+
+func (a accessGrantStore) Delete(ctx context.Context, list access.List, channel, sender string) (bool, error) {
+	cache, err := a.s.accessGrantCache()
+	if err != nil {
+		return false, err
+	}
+	removed, err := cache.Delete(ctx, list, channel, sender)
+	if err != nil {
+		return false, err
+	}
+	a.s.InvalidateCache(ctx, cacheAccessGrants, "")
+	return removed, nil
+}
+
+func (a accessGrantStore) List(ctx context.Context, list access.List) ([]access.Grant, error) {
+	cache, err := a.s.accessGrantCache()
+	if err != nil {
+		return nil, err
+	}
+	return cache.List(ctx, list)
+}
+
+// approvePairingCode approves a pending pairing request from this node's
+// pairing store, adding the sender to the channel's pairing allowlist.
+func (s *Server) approvePairingCode(ctx context.Context, channel, code string) (string, error) {
+	provider := strings.ToLower(strings.TrimSpace(channel))
+	id, _, err := pairing.NewStore(provider).ApproveCode(provider, code)
+	return id, err
+}
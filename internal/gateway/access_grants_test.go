@@ -0,0 +1,59 @@
+package gateway
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/haasonsaas/nexus/internal/access"
+	"github.com/haasonsaas/nexus/internal/config"
+	"github.com/haasonsaas/nexus/pkg/models"
+)
+
+func TestAccessGrantsExtendAllowlists(t *testing.T) {
+	ctx := context.Background()
+	server := &Server{
+		config: &config.Config{},
+		logger: slog.New(slog.NewTextHandler(io.Discard, nil)),
+	}
+	server.accessGrants = access.NewCache(access.NewMemoryStore(), time.Hour)
+	msg := func(sender string) *models.Message {
+		return &models.Message{Channel: models.ChannelSlack, Metadata: map[string]any{"slack_user_id": sender}}
+	}
+
+	if !server.commandAllowlistAllows(ctx, msg("U1")) {
+		t.Fatal("commands should be open without allow_from or grants")
+	}
+
+	store := accessGrantStore{server}
+	if err := store.Put(ctx, access.Grant{List: access.ListCommands, Channel: "slack", Sender: "U1"}); err != nil {
+		t.Fatalf("Put() error = %v", err)
+	}
+	if !server.commandAllowlistAllows(ctx, msg("U1")) {
+		t.Fatal("granted sender should run commands")
+	}
+	if server.commandAllowlistAllows(ctx, msg("U2")) {
+		t.Fatal("a grant should restrict commands to granted senders")
+	}
+
+	if err := store.Put(ctx, access.Grant{List: access.ListPairing, Channel: "slack", Sender: "U3"}); err != nil {
+		t.Fatalf("Put() error = %v", err)
+	}
+	if !server.isAllowedTarget(ctx, models.ChannelSlack, "U3", config.ChannelPolicyConfig{}, true) {
+		t.Fatal("pairing grant should allow DMs")
+	}
+	if server.isAllowedTarget(ctx, models.ChannelSlack, "U3", config.ChannelPolicyConfig{}, false) {
+		t.Fatal("pairing grants should not apply to group allowlists")
+	}
+
+	enabled := true
+	elevated := config.ElevatedConfig{Enabled: &enabled}
+	if allowed, _ := resolveElevatedPermission(elevated, nil, msg("U1"), false); allowed {
+		t.Fatal("elevated should require allow_from or a grant")
+	}
+	if allowed, _ := resolveElevatedPermission(elevated, nil, msg("U1"), true); !allowed {
+		t.Fatal("elevate grant should allow elevated mode")
+	}
+}
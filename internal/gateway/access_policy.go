@@ -7,7 +7,9 @@ import (
 	"strings"
 	"time"
 
+	"github.com/haasonsaas/nexus/internal/access"
 	"github.com/haasonsaas/nexus/internal/config"
+	"github.com/haasonsaas/nexus/internal/messages"
 	"github.com/haasonsaas/nexus/internal/pairing"
 	"github.com/haasonsaas/nexus/pkg/models"
 )
@@ -37,7 +39,7 @@ func (s *Server) enforceAccessPolicy(ctx context.Context, msg *models.Message) b
 	case "allowlist":
 		targetID := s.policyTargetID(msg, convType)
 		useStore := strings.EqualFold(convType, "dm")
-		if targetID != "" && s.isAllowedTarget(msg.Channel, targetID, policyCfg, useStore) {
+		if targetID != "" && s.isAllowedTarget(ctx, msg.Channel, targetID, policyCfg, useStore) {
 			return false
 		}
 		s.logger.Info("message blocked by allowlist",
@@ -55,7 +57,7 @@ func (s *Server) enforceAccessPolicy(ctx context.Context, msg *models.Message) b
 			return true
 		}
 		targetID := s.policyTargetID(msg, convType)
-		if targetID != "" && s.isAllowedTarget(msg.Channel, targetID, policyCfg, true) {
+		if targetID != "" && s.isAllowedTarget(ctx, msg.Channel, targetID, policyCfg, true) {
 			return false
 		}
 		if err := s.handlePairingRequest(ctx, msg, targetID); err != nil {
@@ -149,7 +151,7 @@ func (s *Server) policyTargetID(msg *models.Message, convType string) string {
 	return extractSenderID(msg)
 }
 
-func (s *Server) isAllowedTarget(channel models.ChannelType, targetID string, policyCfg config.ChannelPolicyConfig, includeStore bool) bool {
+func (s *Server) isAllowedTarget(ctx context.Context, channel models.ChannelType, targetID string, policyCfg config.ChannelPolicyConfig, includeStore bool) bool {
 	if targetID == "" {
 		return false
 	}
@@ -159,6 +161,9 @@ func (s *Server) isAllowedTarget(channel models.ChannelType, targetID string, po
 	if !includeStore {
 		return false
 	}
+	if granted, _ := s.accessGranted(ctx, access.ListPairing, channel, targetID); granted {
+		return true
+	}
 	provider := strings.ToLower(string(channel))
 	store := pairing.NewStore(provider)
 	allowlist, err := store.GetAllowlist(provider)
@@ -204,7 +209,7 @@ func (s *Server) handlePairingRequest(ctx context.Context, msg *models.Message,
 		}
 	}
 
-	content := buildPairingPrompt(provider, extractSenderName(msg), req, created)
+	content := s.systemMessage(ctx, msg, messages.PairingRequest, pairingPromptData(provider, extractSenderName(msg), req, created))
 	if strings.TrimSpace(content) == "" {
 		return nil
 	}
@@ -235,14 +240,10 @@ func (s *Server) handlePairingRequest(ctx context.Context, msg *models.Message,
 	return nil
 }
 
-func buildPairingPrompt(provider string, senderName string, req pairing.Request, created bool) string {
-	label := "Pairing request"
-	if senderName != "" {
-		label = fmt.Sprintf("Pairing request from %s", senderName)
-	}
-	status := "received"
+func pairingPromptData(provider string, senderName string, req pairing.Request, created bool) messages.Data {
+	pending := ""
 	if !created {
-		status = "already pending"
+		pending = "true"
 	}
 	expiresAt := req.CreatedAt.Add(pairing.PendingTTL)
 	expiresIn := time.Until(expiresAt).Round(time.Minute)
@@ -250,13 +251,13 @@ func buildPairingPrompt(provider string, senderName string, req pairing.Request,
 		expiresIn = 0
 	}
 
-	lines := []string{
-		fmt.Sprintf("%s for %s (%s).", label, provider, status),
-		fmt.Sprintf("Code: %s (expires in %s).", req.Code, expiresIn),
-		fmt.Sprintf("Approve: nexus pairing approve %s --provider %s", req.Code, provider),
-		fmt.Sprintf("Deny: nexus pairing deny %s --provider %s", req.Code, provider),
+	return messages.Data{
+		"Provider":  provider,
+		"Sender":    senderName,
+		"Pending":   pending,
+		"Code":      req.Code,
+		"ExpiresIn": expiresIn.String(),
 	}
-	return strings.Join(lines, "\n")
 }
 
 func conversationTypeForMessage(msg *models.Message) string {
@@ -0,0 +1,364 @@
+package gateway
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/haasonsaas/nexus/internal/auth"
+	"github.com/haasonsaas/nexus/pkg/management"
+	"github.com/haasonsaas/nexus/pkg/models"
+	proto "github.com/haasonsaas/nexus/pkg/proto"
+)
+
+const (
+	// actionsSource marks messages and tasks created through the actions API.
+	actionsSource = "actions_api"
+
+	// actionsChannelPrefix prefixes the channel ID of the automation session
+	// run_agent uses when no session is given.
+	actionsChannelPrefix = "automation:"
+
+	defaultMemorySearchLimit = 10
+	maxMemorySearchLimit     = 50
+)
+
+// actionsAPI serves the REST actions API described by management.Actions.
+// It shares authentication, scopes, and error bodies with the management
+// API so the same API keys work for both.
+type actionsAPI struct {
+	management *managementAPI
+	server     *Server
+	handlers   map[string]managementMethod
+}
+
+func (s *Server) newActionsAPI() *actionsAPI {
+	api := &actionsAPI{
+		management: s.newManagementAPI(),
+		server:     s,
+	}
+	api.handlers = map[string]managementMethod{
+		management.SendMessageActionPath:  managementUnary(api.sendMessage),
+		management.RunAgentActionPath:     managementUnary(api.runAgent),
+		management.SearchMemoryActionPath: managementUnary(api.searchMemory),
+		management.CreateTaskActionPath:   managementUnary(api.createTask),
+	}
+	return api
+}
+
+func (a *actionsAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	action, ok := management.LookupAction(r.URL.Path)
+	handler := a.handlers[r.URL.Path]
+	if !ok || handler == nil {
+		a.management.writeError(w, management.Errorf(management.CodeNotFound, "unknown action %s", r.URL.Path))
+		return
+	}
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	// Automation tools do not always label their bodies; only reject
+	// bodies that claim to be something other than JSON.
+	if contentType := r.Header.Get("Content-Type"); contentType != "" {
+		if mediaType, _, err := mime.ParseMediaType(contentType); err != nil || mediaType != "application/json" {
+			w.WriteHeader(http.StatusUnsupportedMediaType)
+			return
+		}
+	}
+
+	ctx, err := a.management.authenticate(r)
+	if err != nil {
+		a.management.writeError(w, err)
+		return
+	}
+	if !auth.HasScope(ctx, action.Scope) {
+		a.management.writeError(w, management.Errorf(management.CodePermissionDenied, "api key lacks scope %q", action.Scope))
+		return
+	}
+
+	body, err := io.ReadAll(io.LimitReader(r.Body, managementMaxBodyBytes+1))
+	if err != nil {
+		a.management.writeError(w, management.Errorf(management.CodeInvalidArgument, "read request body: %v", err))
+		return
+	}
+	if len(body) > managementMaxBodyBytes {
+		a.management.writeError(w, management.Errorf(management.CodeResourceExhausted, "request body exceeds %d bytes", managementMaxBodyBytes))
+		return
+	}
+
+	resp, err := handler(ctx, body)
+	if err != nil {
+		a.management.writeError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		a.management.logger.Warn("failed to write action response", "action", r.URL.Path, "error", err)
+	}
+}
+
+// handleOpenAPISpec serves the OpenAPI document for the actions API. It is
+// public so automation tools can import it before a key is configured.
+func (s *Server) handleOpenAPISpec(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.Header().Set("Allow", http.MethodGet)
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	scheme := "http"
+	if r.TLS != nil {
+		scheme = "https"
+	}
+	if forwarded := r.Header.Get("X-Forwarded-Proto"); forwarded == "http" || forwarded == "https" {
+		scheme = forwarded
+	}
+	spec, err := management.OpenAPISpec(scheme+"://"+r.Host, "")
+	if err != nil {
+		writeJSON(w, http.StatusInternalServerError, map[string]any{"error": "failed to generate spec"})
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if _, err := w.Write(spec); err != nil && s.logger != nil {
+		s.logger.Debug("failed to write openapi spec", "error", err)
+	}
+}
+
+func (a *actionsAPI) defaultAgentID(agentID string) string {
+	if agentID = strings.TrimSpace(agentID); agentID != "" {
+		return agentID
+	}
+	if a.server.config != nil {
+		if agentID = strings.TrimSpace(a.server.config.Session.DefaultAgentID); agentID != "" {
+			return agentID
+		}
+	}
+	return defaultAgentID
+}
+
+// sendMessage queues a message for the session's channel. The agent run
+// happens in the background, like a message arriving on that channel.
+func (a *actionsAPI) sendMessage(ctx context.Context, req *management.SendMessageActionRequest) (*management.SendMessageActionResponse, error) {
+	content := strings.TrimSpace(req.Content)
+	if content == "" {
+		return nil, management.Errorf(management.CodeInvalidArgument, "content is required")
+	}
+
+	agentID := a.defaultAgentID(req.AgentID)
+	channel := models.ChannelType(strings.ToLower(strings.TrimSpace(req.Channel)))
+	channelID := strings.TrimSpace(req.ChannelID)
+	if sessionID := strings.TrimSpace(req.SessionID); sessionID != "" {
+		store, err := a.management.sessionStore()
+		if err != nil {
+			return nil, err
+		}
+		session, err := store.Get(ctx, sessionID)
+		if err != nil || session == nil {
+			return nil, management.Errorf(management.CodeNotFound, "session %q not found", sessionID)
+		}
+		agentID, channel, channelID = session.AgentID, session.Channel, session.ChannelID
+	}
+	if channel == "" || channelID == "" {
+		return nil, management.Errorf(management.CodeInvalidArgument, "session_id, or channel and channel_id, are required")
+	}
+
+	metadata := make(map[string]any, len(req.Metadata)+3)
+	for k, v := range req.Metadata {
+		metadata[k] = v
+	}
+	metadata["agent_id"] = agentID
+	metadata["source"] = actionsSource
+	if caller := managementCaller(ctx); caller != "" {
+		metadata["user_id"] = caller
+	}
+	applyWebhookChannelMetadata(metadata, channel, channelID)
+
+	msg := &models.Message{
+		ID:        uuid.NewString(),
+		Channel:   channel,
+		ChannelID: channelID,
+		Direction: models.DirectionInbound,
+		Role:      models.RoleUser,
+		Content:   content,
+		Metadata:  metadata,
+		CreatedAt: time.Now(),
+	}
+	s := a.server
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		runCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), maxProcessingTime)
+		defer cancel()
+		s.handleMessage(runCtx, msg)
+	}()
+
+	return &management.SendMessageActionResponse{MessageID: msg.ID, Status: "queued"}, nil
+}
+
+// runAgent runs the agent and waits for its reply. Without a session ID
+// each caller gets one automation session per agent, so successive runs
+// share context the way a chat would.
+func (a *actionsAPI) runAgent(ctx context.Context, req *management.RunAgentActionRequest) (*management.RunAgentActionResponse, error) {
+	if strings.TrimSpace(req.Prompt) == "" {
+		return nil, management.Errorf(management.CodeInvalidArgument, "prompt is required")
+	}
+	sessionID := strings.TrimSpace(req.SessionID)
+	if sessionID == "" {
+		store, err := a.management.sessionStore()
+		if err != nil {
+			return nil, err
+		}
+		agentID := a.defaultAgentID(req.AgentID)
+		caller := managementCaller(ctx)
+		if caller == "" {
+			caller = "anonymous"
+		}
+		channelID := actionsChannelPrefix + caller
+		key := a.server.buildSessionKeyForPeer(agentID, models.ChannelAPI, channelID)
+		session, err := store.GetOrCreate(ctx, key, agentID, models.ChannelAPI, channelID)
+		if err != nil {
+			return nil, management.Errorf(management.CodeInternal, "create session: %v", err)
+		}
+		sessionID = session.ID
+	}
+
+	metadata := make(map[string]string, len(req.Metadata)+1)
+	for k, v := range req.Metadata {
+		metadata[k] = v
+	}
+	metadata["source"] = actionsSource
+	resp, err := a.management.sendMessage(ctx, &management.SendMessageRequest{
+		SessionID: sessionID,
+		Content:   req.Prompt,
+		Metadata:  metadata,
+	})
+	if err != nil {
+		return nil, err
+	}
+	out := &management.RunAgentActionResponse{SessionID: resp.SessionID}
+	if resp.Reply != nil {
+		out.MessageID = resp.Reply.ID
+		out.Reply = resp.Reply.Content
+	}
+	return out, nil
+}
+
+func (a *actionsAPI) searchMemory(ctx context.Context, req *management.SearchMemoryActionRequest) (*management.SearchMemoryActionResponse, error) {
+	if a.server.vectorMemory == nil {
+		return nil, management.Errorf(management.CodeFailedPrecondition, "vector memory is disabled (set vector_memory.enabled)")
+	}
+	if strings.TrimSpace(req.Query) == "" {
+		return nil, management.Errorf(management.CodeInvalidArgument, "query is required")
+	}
+	scope := models.MemoryScope(strings.ToLower(strings.TrimSpace(req.Scope)))
+	scopeID := strings.TrimSpace(req.ScopeID)
+	switch scope {
+	case "":
+		scope = models.ScopeAll
+		if agentID := strings.TrimSpace(req.AgentID); agentID != "" {
+			scope, scopeID = models.ScopeAgent, agentID
+		}
+	case models.ScopeSession, models.ScopeChannel, models.ScopeAgent:
+		if scopeID == "" && scope == models.ScopeAgent {
+			scopeID = a.defaultAgentID(req.AgentID)
+		}
+		if scopeID == "" {
+			return nil, management.Errorf(management.CodeInvalidArgument, "scope_id is required for scope %q", scope)
+		}
+	case models.ScopeGlobal, models.ScopeAll:
+	default:
+		return nil, management.Errorf(management.CodeInvalidArgument, "unknown scope %q", req.Scope)
+	}
+	limit := req.Limit
+	if limit <= 0 {
+		limit = defaultMemorySearchLimit
+	}
+	limit = min(limit, maxMemorySearchLimit)
+	threshold := req.Threshold
+	if threshold <= 0 && a.server.config != nil {
+		threshold = a.server.config.VectorMemory.Search.DefaultThreshold
+	}
+
+	resp, err := a.server.vectorMemory.Search(ctx, &models.SearchRequest{
+		Query:     req.Query,
+		Scope:     scope,
+		ScopeID:   scopeID,
+		Limit:     limit,
+		Threshold: threshold,
+	})
+	if err != nil {
+		return nil, management.Errorf(management.CodeInternal, "search memory: %v", err)
+	}
+	results := make([]management.MemoryResult, 0, len(resp.Results))
+	for _, r := range resp.Results {
+		if r == nil || r.Entry == nil {
+			continue
+		}
+		results = append(results, management.MemoryResult{
+			ID:        r.Entry.ID,
+			Content:   r.Entry.Content,
+			Score:     r.Score,
+			Source:    r.Entry.Metadata.Source,
+			SessionID: r.Entry.SessionID,
+			AgentID:   r.Entry.AgentID,
+			CreatedAt: r.Entry.CreatedAt,
+		})
+	}
+	return &management.SearchMemoryActionResponse{Results: results}, nil
+}
+
+func (a *actionsAPI) createTask(ctx context.Context, req *management.CreateTaskActionRequest) (*management.CreateTaskActionResponse, error) {
+	if a.server.taskStore == nil {
+		return nil, management.Errorf(management.CodeFailedPrecondition, "task scheduler not enabled (set tasks.enabled)")
+	}
+	switch {
+	case strings.TrimSpace(req.Name) == "":
+		return nil, management.Errorf(management.CodeInvalidArgument, "name is required")
+	case strings.TrimSpace(req.Prompt) == "":
+		return nil, management.Errorf(management.CodeInvalidArgument, "prompt is required")
+	case strings.TrimSpace(req.Schedule) == "":
+		return nil, management.Errorf(management.CodeInvalidArgument, "schedule is required")
+	}
+	if _, err := cronParser.Parse(req.Schedule); err != nil {
+		return nil, management.Errorf(management.CodeInvalidArgument, "invalid schedule: %v", err)
+	}
+	if req.Timezone != "" {
+		if _, err := time.LoadLocation(req.Timezone); err != nil {
+			return nil, management.Errorf(management.CodeInvalidArgument, "invalid timezone %q", req.Timezone)
+		}
+	}
+
+	metadata := map[string]string{"source": actionsSource}
+	if caller := managementCaller(ctx); caller != "" {
+		metadata["created_by"] = caller
+	}
+	created, err := newTaskService(a.server).CreateTask(ctx, &proto.CreateTaskRequest{
+		Name:        req.Name,
+		Description: req.Description,
+		AgentId:     a.defaultAgentID(req.AgentID),
+		Schedule:    req.Schedule,
+		Timezone:    req.Timezone,
+		Prompt:      req.Prompt,
+		Config: &proto.TaskConfig{
+			Channel:   req.Channel,
+			ChannelId: req.ChannelID,
+		},
+		Metadata: metadata,
+	})
+	if err != nil {
+		return nil, management.Errorf(management.CodeInternal, "%v", err)
+	}
+	task := created.GetTask()
+	return &management.CreateTaskActionResponse{
+		ID:        task.GetId(),
+		Name:      task.GetName(),
+		Status:    strings.ToLower(strings.TrimPrefix(task.GetStatus().String(), "TASK_STATUS_")),
+		NextRunAt: task.GetNextRunAt().AsTime(),
+	}, nil
+}
@@ -0,0 +1,190 @@
+package gateway
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/haasonsaas/nexus/internal/auth"
+	"github.com/haasonsaas/nexus/internal/config"
+	"github.com/haasonsaas/nexus/internal/sessions"
+	"github.com/haasonsaas/nexus/internal/tasks"
+	"github.com/haasonsaas/nexus/pkg/management"
+	"github.com/haasonsaas/nexus/pkg/models"
+)
+
+func newActionsTestServer(t *testing.T) (*Server, *httptest.Server) {
+	t.Helper()
+	server := &Server{config: &config.Config{Session: config.SessionConfig{DefaultAgentID: "main"}}}
+	server.sessions = sessions.NewMemoryStore()
+	server.authService = auth.NewService(auth.Config{APIKeys: []auth.APIKeyConfig{
+		{Key: "zapier", UserID: "zapier", Role: auth.RoleOperator},
+		{Key: "reader", UserID: "dashboard", Role: auth.RoleReadOnly},
+	}})
+
+	mux := http.NewServeMux()
+	mux.Handle(management.ActionsPathPrefix, server.newActionsAPI())
+	mux.HandleFunc(management.OpenAPIPath, server.handleOpenAPISpec)
+	httpServer := httptest.NewServer(mux)
+	t.Cleanup(httpServer.Close)
+	return server, httpServer
+}
+
+func postAction(t *testing.T, url, key string, body any) (int, map[string]any) {
+	t.Helper()
+	data, err := json.Marshal(body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req, err := http.NewRequest(http.MethodPost, url, strings.NewReader(string(data)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if key != "" {
+		req.Header.Set("X-API-Key", key)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	var decoded map[string]any
+	_ = json.NewDecoder(resp.Body).Decode(&decoded) //nolint:errcheck // some statuses have no body
+	return resp.StatusCode, decoded
+}
+
+func TestActionsAPISendMessageQueuesForSession(t *testing.T) {
+	ctx := context.Background()
+	server, httpServer := newActionsTestServer(t)
+	session, err := server.sessions.GetOrCreate(ctx, "main:slack:C1", "main", models.ChannelSlack, "C1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	received := make(chan *models.Message, 1)
+	server.handleMessageHook = func(_ context.Context, msg *models.Message) {
+		received <- msg
+	}
+
+	status, body := postAction(t, httpServer.URL+management.SendMessageActionPath, "zapier", map[string]any{
+		"session_id": session.ID,
+		"content":    "New lead: Ada",
+		"metadata":   map[string]string{"zap": "leads"},
+	})
+	if status != http.StatusOK || body["status"] != "queued" {
+		t.Fatalf("status %d body %v", status, body)
+	}
+
+	select {
+	case msg := <-received:
+		if msg.Channel != models.ChannelSlack || msg.ChannelID != "C1" || msg.Content != "New lead: Ada" {
+			t.Fatalf("unexpected message %+v", msg)
+		}
+		if msg.Metadata["source"] != actionsSource || msg.Metadata["zap"] != "leads" || msg.Metadata["slack_channel"] != "C1" {
+			t.Fatalf("unexpected metadata %v", msg.Metadata)
+		}
+		if msg.ID != body["message_id"] {
+			t.Fatalf("message ID %q, response %v", msg.ID, body["message_id"])
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("message was not handled")
+	}
+	server.wg.Wait()
+
+	status, body = postAction(t, httpServer.URL+management.SendMessageActionPath, "zapier", map[string]any{"content": "hi"})
+	if status != http.StatusBadRequest || body["code"] != string(management.CodeInvalidArgument) {
+		t.Fatalf("expected invalid_argument without a target, got %d %v", status, body)
+	}
+	status, _ = postAction(t, httpServer.URL+management.SendMessageActionPath, "zapier", map[string]any{"session_id": "missing", "content": "hi"})
+	if status != http.StatusNotFound {
+		t.Fatalf("expected 404 for an unknown session, got %d", status)
+	}
+}
+
+func TestActionsAPIScopesAndAuth(t *testing.T) {
+	_, httpServer := newActionsTestServer(t)
+
+	status, body := postAction(t, httpServer.URL+management.RunAgentActionPath, "", map[string]any{"prompt": "hi"})
+	if status != http.StatusUnauthorized || body["code"] != string(management.CodeUnauthenticated) {
+		t.Fatalf("expected unauthenticated, got %d %v", status, body)
+	}
+	status, body = postAction(t, httpServer.URL+management.CreateTaskActionPath, "reader", map[string]any{"name": "x"})
+	if status != http.StatusForbidden || !strings.Contains(body["message"].(string), management.ScopeTasksWrite) {
+		t.Fatalf("expected permission_denied, got %d %v", status, body)
+	}
+	status, body = postAction(t, httpServer.URL+management.SearchMemoryActionPath, "reader", map[string]any{"query": "pricing"})
+	if status != http.StatusBadRequest || body["code"] != string(management.CodeFailedPrecondition) {
+		t.Fatalf("expected failed_precondition without vector memory, got %d %v", status, body)
+	}
+	status, _ = postAction(t, httpServer.URL+management.ActionsPathPrefix+"delete_everything", "zapier", map[string]any{})
+	if status != http.StatusNotFound {
+		t.Fatalf("expected 404 for an unknown action, got %d", status)
+	}
+}
+
+func TestActionsAPICreateTask(t *testing.T) {
+	ctx := context.Background()
+	server, httpServer := newActionsTestServer(t)
+	db, err := sessions.OpenSQLite(ctx, "sqlite://"+filepath.Join(t.TempDir(), "nexus.db"))
+	if err != nil {
+		t.Fatalf("OpenSQLite() error = %v", err)
+	}
+	taskStore, err := tasks.NewSQLiteStore(db)
+	if err != nil {
+		t.Fatalf("NewSQLiteStore() error = %v", err)
+	}
+	t.Cleanup(func() { _ = taskStore.Close() })
+	server.taskStore = taskStore
+
+	status, body := postAction(t, httpServer.URL+management.CreateTaskActionPath, "zapier", map[string]any{
+		"name":     "standup",
+		"prompt":   "Summarize yesterday's tickets",
+		"schedule": "0 9 * * 1-5",
+		"timezone": "Europe/Berlin",
+	})
+	if status != http.StatusOK || body["status"] != "active" || body["id"] == "" {
+		t.Fatalf("status %d body %v", status, body)
+	}
+	task, err := taskStore.GetTask(ctx, body["id"].(string))
+	if err != nil || task == nil {
+		t.Fatalf("GetTask: %v", err)
+	}
+	if task.AgentID != "main" || task.Metadata["source"] != actionsSource || task.Metadata["created_by"] != "zapier" {
+		t.Fatalf("unexpected task %+v", task)
+	}
+
+	status, body = postAction(t, httpServer.URL+management.CreateTaskActionPath, "zapier", map[string]any{
+		"name": "bad", "prompt": "x", "schedule": "every tuesday",
+	})
+	if status != http.StatusBadRequest || body["code"] != string(management.CodeInvalidArgument) {
+		t.Fatalf("expected invalid_argument for a bad schedule, got %d %v", status, body)
+	}
+}
+
+func TestHandleOpenAPISpec(t *testing.T) {
+	_, httpServer := newActionsTestServer(t)
+	resp, err := http.Get(httpServer.URL + management.OpenAPIPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	var doc struct {
+		Servers []struct {
+			URL string `json:"url"`
+		} `json:"servers"`
+		Paths map[string]any `json:"paths"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&doc); err != nil {
+		t.Fatal(err)
+	}
+	if resp.StatusCode != http.StatusOK || len(doc.Servers) != 1 || doc.Servers[0].URL != httpServer.URL {
+		t.Fatalf("status %d servers %+v", resp.StatusCode, doc.Servers)
+	}
+	if _, ok := doc.Paths[management.RunAgentActionPath]; !ok {
+		t.Fatalf("spec is missing run_agent: %v", doc.Paths)
+	}
+}
@@ -0,0 +1,102 @@
+package gateway
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/haasonsaas/nexus/internal/agent"
+	"github.com/haasonsaas/nexus/pkg/models"
+)
+
+// generationKey is the agent config and message metadata key holding a
+// generation profile.
+const generationKey = "generation"
+
+// decodeGenerationProfile reads a generation profile from an agent config
+// or message metadata value, which may be a map or a JSON object string.
+func decodeGenerationProfile(raw any) (agent.GenerationProfile, error) {
+	var profile agent.GenerationProfile
+	var payload []byte
+	switch value := raw.(type) {
+	case nil:
+		return profile, nil
+	case string:
+		if strings.TrimSpace(value) == "" {
+			return profile, nil
+		}
+		payload = []byte(value)
+	default:
+		var err error
+		if payload, err = json.Marshal(value); err != nil {
+			return profile, fmt.Errorf("invalid generation profile: %w", err)
+		}
+	}
+	decoder := json.NewDecoder(strings.NewReader(string(payload)))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&profile); err != nil {
+		return agent.GenerationProfile{}, fmt.Errorf("invalid generation profile: %w", err)
+	}
+	profile.Model = strings.TrimSpace(profile.Model)
+	profile.ReasoningEffort = strings.ToLower(strings.TrimSpace(profile.ReasoningEffort))
+	return profile, nil
+}
+
+// agentGenerationProfile returns the generation profile stored with an
+// agent definition. The agent's model fills in when the profile sets none.
+func agentGenerationProfile(agentModel *models.Agent) (agent.GenerationProfile, error) {
+	if agentModel == nil {
+		return agent.GenerationProfile{}, nil
+	}
+	profile, err := decodeGenerationProfile(agentModel.Config[generationKey])
+	if err != nil {
+		return agent.GenerationProfile{}, err
+	}
+	if profile.Model == "" {
+		profile.Model = strings.TrimSpace(agentModel.Model)
+	}
+	return profile, nil
+}
+
+// validateAgentGeneration checks an agent definition's generation profile
+// against the model catalog before it is stored.
+func (s *Server) validateAgentGeneration(agentModel *models.Agent) error {
+	profile, err := agentGenerationProfile(agentModel)
+	if err != nil {
+		return err
+	}
+	return profile.Validate(s.modelCatalog)
+}
+
+// withGenerationProfile applies the agent's generation profile, overridden
+// by the message's "generation" metadata, to a run. A run override's model
+// beats session and experiment model overrides, so call this after them.
+// Invalid profiles are logged and skipped rather than failing the run.
+func (s *Server) withGenerationProfile(ctx context.Context, agentModel *models.Agent, msg *models.Message) context.Context {
+	profile, err := agentGenerationProfile(agentModel)
+	if err == nil {
+		err = profile.Validate(s.modelCatalog)
+	}
+	if err != nil {
+		s.logger.Warn("ignoring agent generation profile", "agent_id", agentModel.ID, "error", err)
+		profile = agent.GenerationProfile{}
+	}
+
+	if msg != nil && msg.Metadata != nil {
+		run, err := decodeGenerationProfile(msg.Metadata[generationKey])
+		if err == nil {
+			merged := profile.Merge(run)
+			if err = merged.Validate(s.modelCatalog); err == nil {
+				profile = merged
+				if run.Model != "" {
+					ctx = agent.WithModel(ctx, run.Model)
+				}
+			}
+		}
+		if err != nil {
+			s.logger.Warn("ignoring run generation override", "message_id", msg.ID, "error", err)
+		}
+	}
+	return agent.WithGenerationProfile(ctx, profile)
+}
@@ -0,0 +1,58 @@
+package gateway
+
+import (
+	"testing"
+
+	"github.com/haasonsaas/nexus/pkg/models"
+)
+
+func TestDecodeGenerationProfile(t *testing.T) {
+	fromMap, err := decodeGenerationProfile(map[string]any{
+		"temperature":      0.4,
+		"max_tokens":       512,
+		"reasoning_effort": " High ",
+	})
+	if err != nil {
+		t.Fatalf("decode map: %v", err)
+	}
+	if fromMap.Temperature == nil || *fromMap.Temperature != 0.4 || fromMap.MaxTokens != 512 || fromMap.ReasoningEffort != "high" {
+		t.Fatalf("unexpected profile from map: %+v", fromMap)
+	}
+
+	fromJSON, err := decodeGenerationProfile(`{"model":"gpt-4o","stop":["END"]}`)
+	if err != nil {
+		t.Fatalf("decode string: %v", err)
+	}
+	if fromJSON.Model != "gpt-4o" || len(fromJSON.Stop) != 1 {
+		t.Fatalf("unexpected profile from JSON: %+v", fromJSON)
+	}
+
+	if _, err := decodeGenerationProfile(map[string]any{"temprature": 0.4}); err == nil {
+		t.Fatal("expected unknown field to be rejected")
+	}
+	if profile, err := decodeGenerationProfile(nil); err != nil || !profile.IsZero() {
+		t.Fatalf("expected empty profile for nil, got %+v, %v", profile, err)
+	}
+}
+
+func TestAgentGenerationProfileUsesAgentModel(t *testing.T) {
+	profile, err := agentGenerationProfile(&models.Agent{
+		Model:  "claude-sonnet-4",
+		Config: map[string]any{"generation": map[string]any{"max_tokens": 2048}},
+	})
+	if err != nil {
+		t.Fatalf("agentGenerationProfile: %v", err)
+	}
+	if profile.Model != "claude-sonnet-4" || profile.MaxTokens != 2048 {
+		t.Fatalf("unexpected profile: %+v", profile)
+	}
+
+	server := &Server{}
+	err = server.validateAgentGeneration(&models.Agent{
+		Model:  "claude-3-5-haiku",
+		Config: map[string]any{"generation": `{"max_tokens": 20000}`},
+	})
+	if err == nil {
+		t.Fatal("expected max_tokens above the model limit to be rejected")
+	}
+}
@@ -56,15 +56,19 @@ func parseAgentToolOverrides(agentModel *models.Agent) agentToolOverrides {
 
 func runtimeOptionsOverrideFromExecution(execCfg config.ToolExecutionConfig) agent.RuntimeOptions {
 	return agent.RuntimeOptions{
-		MaxIterations:     execCfg.MaxIterations,
-		ToolParallelism:   execCfg.Parallelism,
-		ToolTimeout:       execCfg.Timeout,
-		ToolMaxAttempts:   execCfg.MaxAttempts,
-		ToolRetryBackoff:  execCfg.RetryBackoff,
-		DisableToolEvents: execCfg.DisableEvents,
-		MaxToolCalls:      execCfg.MaxToolCalls,
-		RequireApproval:   execCfg.RequireApproval,
-		AsyncTools:        execCfg.Async,
+		MaxIterations:       execCfg.MaxIterations,
+		ToolParallelism:     execCfg.Parallelism,
+		ToolTimeout:         execCfg.Timeout,
+		ToolMaxAttempts:     execCfg.MaxAttempts,
+		ToolRetryBackoff:    execCfg.RetryBackoff,
+		ToolMaxRetryBackoff: execCfg.Retry.MaxBackoff,
+		ToolRetryJitter:     execCfg.Retry.Jitter,
+		ToolRetryOn:         toolRetryClasses(execCfg.Retry.RetryOn),
+		IdempotentTools:     execCfg.Retry.Idempotent,
+		DisableToolEvents:   execCfg.DisableEvents,
+		MaxToolCalls:        execCfg.MaxToolCalls,
+		RequireApproval:     execCfg.RequireApproval,
+		AsyncTools:          execCfg.Async,
 		ToolResultGuard: agent.ToolResultGuard{
 			Enabled:         execCfg.ResultGuard.Enabled,
 			MaxChars:        execCfg.ResultGuard.MaxChars,
@@ -74,5 +78,22 @@ func runtimeOptionsOverrideFromExecution(execCfg config.ToolExecutionConfig) age
 			TruncateSuffix:  execCfg.ResultGuard.TruncateSuffix,
 			SanitizeSecrets: execCfg.ResultGuard.SanitizeSecrets,
 		},
+		ToolSchemaValidation: agent.ToolSchemaValidation{
+			Enabled:        execCfg.SchemaValidation.Enabled,
+			RepairAttempts: execCfg.SchemaValidation.RepairAttempts,
+			Strict:         execCfg.SchemaValidation.Strict,
+		},
+	}
+}
+
+// toolRetryClasses converts configured retry_on names to error classes,
+// skipping names config validation already rejected.
+func toolRetryClasses(names []string) []agent.ToolErrorType {
+	var classes []agent.ToolErrorType
+	for _, name := range names {
+		if class, ok := agent.ParseToolErrorType(name); ok {
+			classes = append(classes, class)
+		}
 	}
+	return classes
 }
@@ -0,0 +1,66 @@
+package gateway
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/haasonsaas/nexus/internal/anomaly"
+	"github.com/haasonsaas/nexus/internal/observability"
+	"github.com/haasonsaas/nexus/internal/web"
+)
+
+// startAnomalyDetector starts scoring usage counters against their
+// baselines. Anomalies starting and ending are recorded as "anomaly.alert"
+// events and posted to the alert webhook when one is configured.
+func (s *Server) startAnomalyDetector(ctx context.Context) {
+	if s == nil || s.anomalies == nil {
+		return
+	}
+	var webhook anomaly.AlertFunc
+	if url := strings.TrimSpace(s.config.Observability.Anomaly.Alerts.WebhookURL); url != "" {
+		webhook = anomaly.WebhookAlert(url, nil)
+	}
+	s.anomalies.SetAlert(func(ctx context.Context, alert anomaly.Alert) error {
+		var errs []error
+		if s.eventRecorder != nil {
+			data := map[string]interface{}{
+				"signal":   string(alert.Signal),
+				"key":      alert.Key,
+				"value":    alert.Value,
+				"baseline": alert.Baseline,
+				"stddev":   alert.StdDev,
+				"z_score":  alert.ZScore,
+				"since":    alert.Since,
+				"resolved": alert.Resolved,
+			}
+			errs = append(errs, s.eventRecorder.Record(ctx, observability.EventTypeCustom, "anomaly.alert", data))
+		}
+		if webhook != nil {
+			errs = append(errs, webhook(ctx, alert))
+		}
+		return errors.Join(errs...)
+	})
+
+	s.goSupervised(ctx, "worker:anomaly", s.anomalies.Run)
+}
+
+// anomalyStatus reports active anomalies for the status API.
+func (s *Server) anomalyStatus() []web.AnomalyStatus {
+	active := s.anomalies.Active()
+	if len(active) == 0 {
+		return nil
+	}
+	statuses := make([]web.AnomalyStatus, 0, len(active))
+	for _, a := range active {
+		statuses = append(statuses, web.AnomalyStatus{
+			Signal:   string(a.Signal),
+			Key:      a.Key,
+			Value:    a.Value,
+			Baseline: a.Baseline,
+			ZScore:   a.ZScore,
+			Since:    a.Since,
+		})
+	}
+	return statuses
+}
@@ -0,0 +1,208 @@
+package gateway
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"mime"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/haasonsaas/nexus/internal/artifacts"
+	"github.com/haasonsaas/nexus/internal/auth"
+	"github.com/haasonsaas/nexus/internal/config"
+	"github.com/haasonsaas/nexus/internal/web"
+)
+
+// BuildArtifactLinkSigner creates the signer for artifact download links. It
+// returns nil when links are disabled.
+func BuildArtifactLinkSigner(cfg *config.Config) (*artifacts.LinkSigner, error) {
+	if cfg == nil || !cfg.Artifacts.Links.Enabled {
+		return nil, nil
+	}
+	links := cfg.Artifacts.Links
+	key := strings.TrimSpace(links.SigningKey)
+	if key == "" {
+		key = strings.TrimSpace(cfg.Auth.JWTSecret)
+	}
+	baseURL := strings.TrimSpace(links.BaseURL)
+	if baseURL == "" {
+		baseURL = fmt.Sprintf("http://localhost:%d", cfg.Server.HTTPPort)
+	}
+	return artifacts.NewLinkSigner([]byte(key), baseURL, links.TTL, links.MaxTTL)
+}
+
+// artifactLinkHandler serves GET /artifacts/{id}. Requests carrying a valid
+// signed link are served without credentials; all others must authenticate
+// like the rest of the HTTP API.
+type artifactLinkHandler struct {
+	repo          artifacts.Repository
+	signer        *artifacts.LinkSigner
+	authenticated http.Handler
+	logger        *slog.Logger
+}
+
+func newArtifactLinkHandler(repo artifacts.Repository, signer *artifacts.LinkSigner, authService *auth.Service, logger *slog.Logger) http.Handler {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	h := &artifactLinkHandler{repo: repo, signer: signer, logger: logger}
+	if authService == nil || !authService.Enabled() {
+		// Without auth configured a bare URL would expose every artifact.
+		h.authenticated = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			http.Error(w, "Signed link required", http.StatusUnauthorized)
+		})
+		return h
+	}
+	h.authenticated = web.AuthMiddleware(authService, logger)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		h.serve(w, r, time.Time{})
+	}))
+	return h
+}
+
+func (h *artifactLinkHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if !r.URL.Query().Has("sig") {
+		h.authenticated.ServeHTTP(w, r)
+		return
+	}
+	expires, err := h.signer.Verify(artifactLinkID(r), r.URL.Query())
+	switch {
+	case errors.Is(err, artifacts.ErrLinkExpired):
+		http.Error(w, "Link expired", http.StatusGone)
+		return
+	case err != nil:
+		http.Error(w, "Invalid link", http.StatusForbidden)
+		return
+	}
+	h.serve(w, r, expires)
+}
+
+func (h *artifactLinkHandler) serve(w http.ResponseWriter, r *http.Request, expires time.Time) {
+	artifactID := artifactLinkID(r)
+	if artifactID == "" {
+		http.Error(w, "Artifact ID required", http.StatusBadRequest)
+		return
+	}
+	if presigner, ok := h.repo.(artifacts.Presigner); ok {
+		download := r.URL.Query().Get("download")
+		presigned, _, err := presigner.PresignArtifact(r.Context(), artifactID, artifacts.PresignOptions{
+			Download: download == "1" || strings.EqualFold(download, "true"),
+		})
+		if err == nil {
+			w.Header().Set("Cache-Control", "private, no-store")
+			http.Redirect(w, r, presigned, http.StatusFound)
+			return
+		}
+		if !errors.Is(err, artifacts.ErrPresignUnsupported) {
+			h.logger.Debug("artifact presign failed", "id", artifactID, "error", err)
+		}
+	}
+	artifact, reader, err := h.repo.GetArtifact(r.Context(), artifactID)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") || strings.Contains(err.Error(), "expired") {
+			http.Error(w, "Artifact not found", http.StatusNotFound)
+		} else {
+			h.logger.Error("failed to get artifact", "id", artifactID, "error", err)
+			http.Error(w, "Failed to retrieve artifact", http.StatusInternalServerError)
+		}
+		return
+	}
+	defer reader.Close()
+	if strings.HasPrefix(artifact.Reference, "redacted://") {
+		http.Error(w, "Artifact redacted", http.StatusGone)
+		return
+	}
+
+	contentType := artifact.MimeType
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	// Artifacts are served from the gateway's origin, which holds the
+	// session cookie and the admin API; the sandbox keeps any script in
+	// them from running there.
+	w.Header().Set("Content-Security-Policy", "sandbox")
+	if artifact.Size > 0 {
+		w.Header().Set("Content-Length", strconv.FormatInt(artifact.Size, 10))
+	}
+	if !expires.IsZero() {
+		maxAge := int(time.Until(expires).Seconds())
+		w.Header().Set("Cache-Control", fmt.Sprintf("private, max-age=%d", max(maxAge, 0)))
+	} else {
+		w.Header().Set("Cache-Control", "private, no-store")
+	}
+	disposition := "attachment"
+	if download := r.URL.Query().Get("download"); download != "1" && !strings.EqualFold(download, "true") && inlineArtifactType(contentType) {
+		disposition = "inline"
+	}
+	var params map[string]string
+	if name := strings.Map(func(r rune) rune {
+		if r == '\r' || r == '\n' || r == '"' {
+			return -1
+		}
+		return r
+	}, artifact.Filename); name != "" {
+		params = map[string]string{"filename": name}
+	}
+	w.Header().Set("Content-Disposition", mime.FormatMediaType(disposition, params))
+	if r.Method == http.MethodHead {
+		return
+	}
+	if _, err := io.Copy(w, reader); err != nil {
+		h.logger.Warn("artifact link download failed", "id", artifactID, "error", err)
+	}
+}
+
+// inlineArtifactTypes are the content types a browser may render in place.
+// Anything else, notably HTML and SVG, is sent as an attachment so it cannot
+// run script on the gateway's origin.
+var inlineArtifactTypes = map[string]bool{
+	"image/png":  true,
+	"image/jpeg": true,
+	"image/gif":  true,
+	"image/webp": true,
+	"text/plain": true,
+}
+
+func inlineArtifactType(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+	return inlineArtifactTypes[mediaType]
+}
+
+func artifactLinkID(r *http.Request) string {
+	id, err := url.PathUnescape(strings.TrimPrefix(r.URL.EscapedPath(), artifacts.LinkPathPrefix))
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(id)
+}
+
+// presignArtifact returns a presigned download URL for the artifact when
+// the artifact repository can issue one.
+func (s *Server) presignArtifact(ctx context.Context, artifactID string, opts artifacts.PresignOptions) (string, bool) {
+	presigner, ok := s.artifactRepo.(artifacts.Presigner)
+	if !ok || artifactID == "" {
+		return "", false
+	}
+	presigned, _, err := presigner.PresignArtifact(ctx, artifactID, opts)
+	if err != nil {
+		if !errors.Is(err, artifacts.ErrPresignUnsupported) {
+			s.logger.Debug("artifact presign failed", "id", artifactID, "error", err)
+		}
+		return "", false
+	}
+	return presigned, true
+}
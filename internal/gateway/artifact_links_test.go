@@ -0,0 +1,101 @@
+package gateway
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/haasonsaas/nexus/internal/artifacts"
+	"github.com/haasonsaas/nexus/internal/auth"
+	pb "github.com/haasonsaas/nexus/pkg/proto"
+)
+
+func TestArtifactLinkHandler(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	store, err := artifacts.NewLocalStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewLocalStore() error = %v", err)
+	}
+	repo := artifacts.NewMemoryRepository(store, logger)
+	payload := []byte("build log")
+	if err := repo.StoreArtifact(context.Background(), &pb.Artifact{
+		Id: "art-1", Type: "file", MimeType: "text/plain", Filename: "build.log", Size: int64(len(payload)),
+	}, bytes.NewReader(payload)); err != nil {
+		t.Fatalf("StoreArtifact() error = %v", err)
+	}
+	signer, err := artifacts.NewLinkSigner([]byte("secret"), "http://gateway", time.Hour, 24*time.Hour)
+	if err != nil {
+		t.Fatalf("NewLinkSigner() error = %v", err)
+	}
+	authService := auth.NewService(auth.Config{APIKeys: []auth.APIKeyConfig{{Key: "key-1", UserID: "u1"}}})
+	handler := newArtifactLinkHandler(repo, signer, authService, logger)
+
+	get := func(target string, header http.Header) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, target, nil)
+		for key, values := range header {
+			req.Header[key] = values
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+
+	link, _ := signer.URL("art-1", 0)
+	parsed, _ := url.Parse(link)
+	rec := get(parsed.RequestURI(), nil)
+	if rec.Code != http.StatusOK || rec.Body.String() != "build log" {
+		t.Fatalf("signed link: status %d body %q", rec.Code, rec.Body.String())
+	}
+	if got := rec.Header().Get("Content-Disposition"); got != `inline; filename=build.log` {
+		t.Fatalf("unexpected Content-Disposition %q", got)
+	}
+	if got := rec.Header().Get("Content-Security-Policy"); got != "sandbox" {
+		t.Fatalf("unexpected Content-Security-Policy %q", got)
+	}
+
+	// Types a browser could run script from are never rendered inline.
+	for id, mimeType := range map[string]string{"page": "text/html; charset=utf-8", "drawing": "image/svg+xml"} {
+		if err := repo.StoreArtifact(context.Background(), &pb.Artifact{
+			Id: id, Type: "file", MimeType: mimeType, Filename: id, Size: int64(len(payload)),
+		}, bytes.NewReader(payload)); err != nil {
+			t.Fatalf("StoreArtifact() error = %v", err)
+		}
+		link, _ := signer.URL(id, 0)
+		parsed, _ := url.Parse(link)
+		rec := get(parsed.RequestURI(), nil)
+		if got := rec.Header().Get("Content-Disposition"); got != "attachment; filename="+id {
+			t.Fatalf("%s: unexpected Content-Disposition %q", mimeType, got)
+		}
+		if got := rec.Header().Get("Content-Security-Policy"); got != "sandbox" {
+			t.Fatalf("%s: unexpected Content-Security-Policy %q", mimeType, got)
+		}
+	}
+
+	query := parsed.Query()
+	query.Set("sig", "forged")
+	if rec := get("/artifacts/art-1?"+query.Encode(), nil); rec.Code != http.StatusForbidden {
+		t.Fatalf("forged link: status %d", rec.Code)
+	}
+	if rec := get("/artifacts/art-1", nil); rec.Code != http.StatusUnauthorized {
+		t.Fatalf("unauthenticated: status %d", rec.Code)
+	}
+	if rec := get("/artifacts/art-1", http.Header{"X-Api-Key": {"key-1"}}); rec.Code != http.StatusOK {
+		t.Fatalf("api key: status %d", rec.Code)
+	}
+	if rec := get("/artifacts/missing", http.Header{"X-Api-Key": {"key-1"}}); rec.Code != http.StatusNotFound {
+		t.Fatalf("missing artifact: status %d", rec.Code)
+	}
+
+	open := newArtifactLinkHandler(repo, signer, nil, logger)
+	rec = httptest.NewRecorder()
+	open.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/artifacts/art-1", nil))
+	if rec.Code != http.StatusUnauthorized {
+		t.Fatalf("expected signed link to be required without auth, got %d", rec.Code)
+	}
+}
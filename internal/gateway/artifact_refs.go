@@ -0,0 +1,51 @@
+package gateway
+
+import (
+	"context"
+
+	"github.com/haasonsaas/nexus/internal/artifacts"
+	"github.com/haasonsaas/nexus/pkg/models"
+)
+
+// artifactReferences returns the artifact repository's reference tracker,
+// or nil when artifacts are disabled or references are not tracked.
+func (s *Server) artifactReferences() artifacts.ReferenceTracker {
+	if s == nil || s.artifactRepo == nil {
+		return nil
+	}
+	tracker, ok := s.artifactRepo.(artifacts.ReferenceTracker)
+	if !ok {
+		return nil
+	}
+	return tracker
+}
+
+// trackAttachmentReferences records that msg refers to the artifacts its
+// attachments were stored as, keeping them alive while session is.
+func (s *Server) trackAttachmentReferences(ctx context.Context, session *models.Session, msg *models.Message) {
+	tracker := s.artifactReferences()
+	if tracker == nil || session == nil || msg == nil {
+		return
+	}
+	for _, attachment := range msg.Attachments {
+		if attachment.ID == "" {
+			continue
+		}
+		if err := tracker.AddReference(ctx, attachment.ID, session.ID, msg.ID); err != nil {
+			s.logger.Warn("failed to record attachment reference", "artifact_id", attachment.ID, "session_id", session.ID, "error", err)
+		}
+	}
+}
+
+// releaseSessionArtifacts releases a reset or deleted session's artifact
+// references. The artifact pruner then deletes artifacts no other session
+// refers to, without waiting for their TTL.
+func (s *Server) releaseSessionArtifacts(ctx context.Context, sessionID string) {
+	tracker := s.artifactReferences()
+	if tracker == nil || sessionID == "" {
+		return
+	}
+	if err := tracker.ReleaseSession(ctx, sessionID); err != nil {
+		s.logger.Warn("failed to release session artifacts", "session_id", sessionID, "error", err)
+	}
+}
@@ -10,16 +10,21 @@ import (
 
 	"github.com/haasonsaas/nexus/internal/artifacts"
 	"github.com/haasonsaas/nexus/internal/config"
+	"github.com/haasonsaas/nexus/internal/media"
 	"github.com/haasonsaas/nexus/internal/sessions"
+	"github.com/haasonsaas/nexus/internal/storage/sqlite"
 )
 
 type artifactSetup struct {
 	repo     artifacts.Repository
 	redactor *artifacts.RedactionPolicy
 	cleanup  *artifacts.CleanupService
+	links    *artifacts.LinkSigner
 }
 
-func buildArtifactSetup(cfg *config.Config, logger *slog.Logger) (*artifactSetup, error) {
+// buildArtifactSetup builds the artifact repository and its services.
+// textExtractor, when set, runs OCR for processing rules that request it.
+func buildArtifactSetup(cfg *config.Config, textExtractor media.TextExtractor, logger *slog.Logger) (*artifactSetup, error) {
 	repo, err := BuildArtifactRepository(context.Background(), cfg, logger)
 	if err != nil || repo == nil {
 		return nil, err
@@ -37,17 +42,72 @@ func buildArtifactSetup(cfg *config.Config, logger *slog.Logger) (*artifactSetup
 		return nil, err
 	}
 
+	links, err := BuildArtifactLinkSigner(cfg)
+	if err != nil {
+		if closer, ok := repo.(interface{ Close() error }); ok {
+			_ = closer.Close()
+		}
+		return nil, fmt.Errorf("artifact links: %w", err)
+	}
+
+	processing := cfg.Artifacts.Processing
+	rules := make(map[string]artifacts.ProcessingRule, len(processing.Types))
+	for artifactType, rule := range processing.Types {
+		rules[artifactType] = artifacts.ProcessingRule{
+			StripEXIF:        rule.StripEXIF,
+			Thumbnail:        rule.Thumbnail,
+			ThumbnailMaxSide: rule.ThumbnailMaxSide,
+			Transcode:        rule.Transcode,
+			MaxVideoBytes:    rule.MaxVideoBytes,
+			MaxVideoHeight:   rule.MaxVideoHeight,
+			OCR:              rule.OCR,
+		}
+	}
+	repo = artifacts.NewProcessingRepository(repo, artifacts.NewProcessor(artifacts.ProcessingConfig{
+		Enabled:          processing.Enabled,
+		MIMEValidation:   processing.MIMEValidation,
+		FFmpegPath:       processing.FFmpegPath,
+		TranscodeTimeout: processing.TranscodeTimeout,
+		TextExtractor:    textExtractor,
+		Rules:            rules,
+	}, logger), logger)
+
 	cleanup := artifacts.NewCleanupService(repo, cfg.Artifacts.PruneInterval, logger)
 
 	return &artifactSetup{
 		repo:     repo,
 		redactor: policy,
 		cleanup:  cleanup,
+		links:    links,
 	}, nil
 }
 
 // BuildArtifactRepository constructs the artifact repository based on config.
+// Artifact metadata is encrypted at rest when field encryption is enabled.
 func BuildArtifactRepository(ctx context.Context, cfg *config.Config, logger *slog.Logger) (artifacts.Repository, error) {
+	if err := LoadPluginStorage(cfg); err != nil {
+		return nil, err
+	}
+	keyring, err := BuildKeyring(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("field encryption: %w", err)
+	}
+	repo, err := buildArtifactRepository(ctx, cfg, logger)
+	if err != nil || repo == nil || keyring == nil {
+		return repo, err
+	}
+	if encrypted, ok := repo.(artifacts.EncryptedRepository); ok {
+		if err := encrypted.SetKeyring(keyring); err != nil {
+			if closer, ok := repo.(interface{ Close() error }); ok {
+				_ = closer.Close()
+			}
+			return nil, err
+		}
+	}
+	return repo, nil
+}
+
+func buildArtifactRepository(ctx context.Context, cfg *config.Config, logger *slog.Logger) (artifacts.Repository, error) {
 	if cfg == nil {
 		return nil, nil
 	}
@@ -80,13 +140,29 @@ func BuildArtifactRepository(ctx context.Context, cfg *config.Config, logger *sl
 			AccessKeyID:     cfg.Artifacts.S3AccessKeyID,
 			SecretAccessKey: cfg.Artifacts.S3SecretAccessKey,
 			UsePathStyle:    usePathStyle,
+
+			SSE:                cfg.Artifacts.S3SSE,
+			KMSKeyID:           cfg.Artifacts.S3KMSKeyID,
+			Lifecycle:          cfg.Artifacts.S3Lifecycle,
+			MultipartThreshold: cfg.Artifacts.S3MultipartThreshold,
+			PartSize:           cfg.Artifacts.S3PartSize,
+			Presign:            cfg.Artifacts.S3Presign,
+			PresignTTL:         cfg.Artifacts.S3PresignTTL,
 		})
 		if err != nil {
 			return nil, err
 		}
 		store = s3Store
 	default:
-		return nil, fmt.Errorf("unsupported artifact backend %q", backend)
+		factory, ok := artifacts.LookupStore(backend)
+		if !ok {
+			return nil, fmt.Errorf("unsupported artifact backend %q", backend)
+		}
+		pluginStore, err := factory(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("artifact backend %q: %w", backend, err)
+		}
+		store = pluginStore
 	}
 
 	metadataBackend := strings.ToLower(strings.TrimSpace(cfg.Artifacts.MetadataBackend))
@@ -108,7 +184,11 @@ func BuildArtifactRepository(ctx context.Context, cfg *config.Config, logger *sl
 			_ = store.Close()
 			return nil, err
 		}
-		repo, err := artifacts.NewSQLRepository(db, store, logger)
+		newRepo := artifacts.NewSQLRepository
+		if sqlite.IsURL(cfg.Database.URL) {
+			newRepo = artifacts.NewSQLiteRepository
+		}
+		repo, err := newRepo(db, store, logger)
 		if err != nil {
 			_ = db.Close()
 			_ = store.Close()
@@ -125,6 +205,9 @@ func openArtifactDB(cfg *config.Config) (*sql.DB, error) {
 	if cfg == nil || strings.TrimSpace(cfg.Database.URL) == "" {
 		return nil, fmt.Errorf("database url is required for artifacts metadata")
 	}
+	if sqlite.IsURL(cfg.Database.URL) {
+		return sessions.OpenSQLite(context.Background(), cfg.Database.URL)
+	}
 	db, err := sql.Open("postgres", cfg.Database.URL)
 	if err != nil {
 		return nil, fmt.Errorf("open database: %w", err)
@@ -0,0 +1,193 @@
+package gateway
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/haasonsaas/nexus/internal/attention"
+	"github.com/haasonsaas/nexus/internal/cluster"
+	"github.com/haasonsaas/nexus/internal/config"
+	"github.com/haasonsaas/nexus/internal/cron"
+	"github.com/haasonsaas/nexus/internal/delivery"
+	"github.com/haasonsaas/nexus/pkg/models"
+)
+
+// attentionTick is how often snoozed items are woken, handled items are
+// pruned, and the digest schedule is checked.
+const attentionTick = time.Minute
+
+// activeAttentionStatuses are the statuses of items that still need action.
+var activeAttentionStatuses = []attention.Status{
+	attention.StatusNew,
+	attention.StatusViewed,
+	attention.StatusInProgress,
+}
+
+// startAttention loads the attention feed from the session database and runs
+// the worker that wakes snoozed items, prunes handled ones, and posts the
+// digest.
+func (s *Server) startAttention(ctx context.Context) {
+	if s == nil || s.config == nil || s.attentionFeed == nil {
+		return
+	}
+	cfg := s.config.Attention
+
+	var store attention.Store
+	db, err := s.clusterDB()
+	if err != nil {
+		s.logger.Warn("attention: persistence disabled (session store init failed)", "error", err)
+	} else if db != nil {
+		dbStore, err := attention.NewDBStore(db)
+		if err == nil {
+			err = s.attentionFeed.Load(ctx, dbStore)
+		}
+		if err != nil {
+			s.logger.Warn("attention: persistence disabled (run `nexus migrate up`?)", "error", err)
+		} else {
+			store = dbStore
+			s.attentionFeed.OnStoreError(func(err error) {
+				s.logger.Warn("attention: store write failed", "error", err)
+			})
+		}
+	}
+
+	var digest cron.Schedule
+	digestEnabled := false
+	if cfg.Digest.Enabled {
+		timezone := strings.TrimSpace(cfg.Digest.Timezone)
+		if timezone == "" {
+			timezone = strings.TrimSpace(s.config.User.Timezone)
+		}
+		digest, err = cron.NewSchedule(config.CronScheduleConfig{Cron: cfg.Digest.Schedule, Timezone: timezone})
+		if err != nil {
+			s.logger.Warn("attention: digest disabled (invalid schedule)", "schedule", cfg.Digest.Schedule, "error", err)
+		} else {
+			digestEnabled = true
+		}
+	}
+
+	s.goSupervised(ctx, "worker:attention", func(ctx context.Context) {
+		ticker := time.NewTicker(attentionTick)
+		defer ticker.Stop()
+
+		var nextDigest time.Time
+		if digestEnabled {
+			nextDigest = nextAttentionDigest(digest, time.Now())
+		}
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case now := <-ticker.C:
+				s.attentionFeed.WakeSnoozed()
+				if cfg.Retention > 0 {
+					s.attentionFeed.Prune(cfg.Retention)
+				}
+				if nextDigest.IsZero() || now.Before(nextDigest) {
+					continue
+				}
+				nextDigest = nextAttentionDigest(digest, now)
+				if !s.isClusterLeader(cluster.LeaseAttentionDigest) {
+					continue
+				}
+				if store != nil {
+					// Other replicas write to the same table; pick up their items.
+					if err := s.attentionFeed.Load(ctx, store); err != nil {
+						s.logger.Warn("attention: reload before digest failed", "error", err)
+					}
+				}
+				if err := s.sendAttentionDigest(ctx); err != nil {
+					s.logger.Warn("attention: digest failed", "channel", cfg.Digest.Channel, "error", err)
+				}
+			}
+		}
+	})
+}
+
+// nextAttentionDigest returns the next digest time after now, or the zero
+// time when the schedule has no further runs.
+func nextAttentionDigest(schedule cron.Schedule, now time.Time) time.Time {
+	next, ok, err := schedule.Next(now)
+	if err != nil || !ok {
+		return time.Time{}
+	}
+	return next
+}
+
+// sendAttentionDigest posts the open items to the configured conversation.
+// Nothing is sent when the feed is empty.
+func (s *Server) sendAttentionDigest(ctx context.Context) error {
+	cfg := s.config.Attention.Digest
+	items := s.attentionFeed.List(attention.FeedOptions{
+		Statuses: activeAttentionStatuses,
+		SortBy:   attention.SortByPriorityDesc,
+	})
+	text := formatAttentionDigest(items, cfg.MaxItems, time.Now())
+	if text == "" {
+		return nil
+	}
+	_, err := s.sendProactive(ctx, delivery.KindDigest, models.ChannelType(cfg.Channel), cfg.PeerID, text)
+	return err
+}
+
+// formatAttentionDigest renders up to limit items, highest priority first.
+func formatAttentionDigest(items []*attention.Item, limit int, now time.Time) string {
+	if len(items) == 0 {
+		return ""
+	}
+	var b strings.Builder
+	noun := "items"
+	if len(items) == 1 {
+		noun = "item"
+	}
+	fmt.Fprintf(&b, "Attention digest: %d open %s", len(items), noun)
+	shown := items
+	if limit > 0 && len(shown) > limit {
+		shown = shown[:limit]
+	}
+	for _, item := range shown {
+		fmt.Fprintf(&b, "\n- %s, %s old", attentionItemLine(item), formatAttentionAge(now.Sub(item.ReceivedAt)))
+	}
+	if rest := len(items) - len(shown); rest > 0 {
+		fmt.Fprintf(&b, "\n…and %d more", rest)
+	}
+	return b.String()
+}
+
+// attentionItemLine renders an item as "[SOURCE/PRIORITY] title (id: ...)".
+// Items the agent added have no channel and are labelled by type instead.
+func attentionItemLine(item *attention.Item) string {
+	title := strings.TrimSpace(item.Title)
+	if title == "" {
+		title = strings.TrimSpace(item.Preview)
+	}
+	if title == "" {
+		title = "Untitled"
+	}
+	source := string(item.Channel)
+	if source == "" {
+		source = string(item.Type)
+	}
+	return fmt.Sprintf("[%s/%s] %s (id: %s)",
+		strings.ToUpper(source),
+		attentionPriorityLabel(item.Priority),
+		title,
+		item.ID,
+	)
+}
+
+// formatAttentionAge renders a coarse age such as "3d", "5h", or "12m".
+func formatAttentionAge(age time.Duration) string {
+	switch {
+	case age >= 24*time.Hour:
+		return fmt.Sprintf("%dd", int(age/(24*time.Hour)))
+	case age >= time.Hour:
+		return fmt.Sprintf("%dh", int(age/time.Hour))
+	case age >= time.Minute:
+		return fmt.Sprintf("%dm", int(age/time.Minute))
+	default:
+		return "<1m"
+	}
+}
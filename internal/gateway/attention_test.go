@@ -0,0 +1,60 @@
+package gateway
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/haasonsaas/nexus/internal/attention"
+	"github.com/haasonsaas/nexus/internal/config"
+	"github.com/haasonsaas/nexus/pkg/models"
+)
+
+func TestAttentionSummaryOrdersByPriority(t *testing.T) {
+	now := time.Now()
+	feed := attention.NewFeed()
+	feed.Add(&attention.Item{ID: "low", Channel: models.ChannelSlack, Title: "FYI", Priority: attention.PriorityLow, Status: attention.StatusNew, ReceivedAt: now})
+	feed.Add(&attention.Item{ID: "urgent", Type: attention.ItemTypeTask, Title: "Renew cert", Priority: attention.PriorityUrgent, Status: attention.StatusNew, ReceivedAt: now.Add(-time.Hour)})
+	feed.Add(&attention.Item{ID: "high", Channel: models.ChannelEmail, Title: "Contract", Priority: attention.PriorityHigh, Status: attention.StatusViewed, ReceivedAt: now.Add(-2 * time.Hour)})
+	feed.Add(&attention.Item{ID: "done", Title: "Done", Priority: attention.PriorityCritical, Status: attention.StatusHandled, ReceivedAt: now})
+
+	s := &Server{
+		config: &config.Config{Attention: config.AttentionConfig{
+			Enabled:        true,
+			InjectInPrompt: true,
+			MaxItems:       2,
+		}},
+		attentionFeed: feed,
+	}
+	want := "- [TASK/URGENT] Renew cert (id: urgent)\n- [EMAIL/HIGH] Contract (id: high)"
+	if got := s.attentionSummary(); got != want {
+		t.Fatalf("attentionSummary() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestFormatAttentionDigest(t *testing.T) {
+	now := time.Now()
+	items := []*attention.Item{
+		{ID: "a", Channel: models.ChannelSlack, Title: "Reply to Sam", Priority: attention.PriorityHigh, ReceivedAt: now.Add(-50 * time.Hour)},
+		{ID: "b", Type: attention.ItemTypeReminder, Title: "Water plants", Priority: attention.PriorityNormal, ReceivedAt: now.Add(-3 * time.Hour)},
+		{ID: "c", Type: attention.ItemTypeTask, Title: "Expense report", Priority: attention.PriorityLow, ReceivedAt: now},
+	}
+
+	got := formatAttentionDigest(items, 2, now)
+	for _, want := range []string{
+		"Attention digest: 3 open items",
+		"- [SLACK/HIGH] Reply to Sam (id: a), 2d old",
+		"- [REMINDER/NORMAL] Water plants (id: b), 3h old",
+		"…and 1 more",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("digest missing %q:\n%s", want, got)
+		}
+	}
+	if strings.Contains(got, "Expense report") {
+		t.Errorf("digest should be capped at 2 items:\n%s", got)
+	}
+	if formatAttentionDigest(nil, 10, now) != "" {
+		t.Error("expected empty digest for no items")
+	}
+}
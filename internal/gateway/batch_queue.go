@@ -0,0 +1,92 @@
+package gateway
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/haasonsaas/nexus/internal/agent"
+)
+
+// startBatchQueue starts the queue that sends background requests through
+// the batch API of llm.batch.provider.
+func (s *Server) startBatchQueue(ctx context.Context) {
+	cfg := s.config.LLM.Batch
+	if !cfg.Enabled {
+		return
+	}
+	providerID := strings.TrimSpace(cfg.Provider)
+	if providerID == "" {
+		providerID = s.config.LLM.DefaultProvider
+	}
+	provider, model, err := s.buildBatchProvider(providerID)
+	if err != nil {
+		s.logger.Warn("llm batch queue disabled", "provider", providerID, "error", err)
+		return
+	}
+	queue, err := agent.NewBatchQueue(provider, agent.BatchQueueConfig{
+		MaxBatchSize:  cfg.MaxBatchSize,
+		FlushInterval: cfg.FlushInterval,
+		PollInterval:  cfg.PollInterval,
+		OnSubmit: func(id string, requests int) {
+			s.logger.Info("llm batch submitted", "provider", providerID, "batch_id", id, "requests", requests)
+		},
+		OnComplete: func(id string, results int, elapsed time.Duration) {
+			s.logger.Info("llm batch completed", "provider", providerID, "batch_id", id, "results", results, "elapsed", elapsed.Round(time.Second))
+		},
+		OnError: func(err error) {
+			s.logger.Warn("llm batch failed", "provider", providerID, "error", err)
+		},
+	})
+	if err != nil {
+		s.logger.Warn("llm batch queue disabled", "provider", providerID, "error", err)
+		return
+	}
+	s.batchQueue = queue
+	s.batchModel = model
+	s.goSupervised(ctx, "worker:llm_batch", queue.Run)
+}
+
+// buildBatchProvider creates the provider batches are sent with. Batches
+// are not spread across a key pool; the first configured key is used.
+func (s *Server) buildBatchProvider(providerID string) (agent.LLMProvider, string, error) {
+	baseID, profileID := splitProviderProfileID(providerID)
+	providerKey := strings.ToLower(strings.TrimSpace(baseID))
+	providerCfg, ok := s.config.LLM.Providers[providerKey]
+	if !ok {
+		return nil, "", fmt.Errorf("provider config missing for %q", providerID)
+	}
+	effectiveCfg, err := resolveProviderProfile(providerCfg, profileID)
+	if err != nil {
+		return nil, "", err
+	}
+	keys, err := resolveProviderKeys(effectiveCfg)
+	if err != nil {
+		return nil, "", err
+	}
+	if len(keys) > 0 {
+		effectiveCfg.APIKey = keys[0].Secret
+	}
+	effectiveCfg.Keys = nil
+	return s.buildProviderWithConfig(providerKey, effectiveCfg)
+}
+
+// backgroundProvider returns the provider, model, and timeout for a
+// background request. With batch set and the batch queue running, the
+// request goes through the queue and may wait up to llm.batch.timeout;
+// otherwise it is sent to the live provider with timeout. An empty model
+// means the default model of whichever provider is used.
+func (s *Server) backgroundProvider(batch bool, model string, timeout time.Duration) (agent.LLMProvider, string, time.Duration) {
+	model = strings.TrimSpace(model)
+	if batch && s.batchQueue != nil {
+		if model == "" {
+			model = s.batchModel
+		}
+		return s.batchQueue, model, s.config.LLM.Batch.Timeout
+	}
+	if model == "" {
+		model = s.defaultModel
+	}
+	return s.llmProvider, model, timeout
+}
@@ -0,0 +1,160 @@
+package gateway
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/haasonsaas/nexus/internal/agent"
+	broadcasttools "github.com/haasonsaas/nexus/internal/tools/broadcast"
+	"github.com/haasonsaas/nexus/pkg/models"
+)
+
+const (
+	// broadcastToolName is the agent tool (and command) that delivers to
+	// outbound broadcast lists.
+	broadcastToolName = "broadcast"
+
+	// broadcastHistoryWindow is how much of the conversation the composer
+	// sees when writing a broadcast.
+	broadcastHistoryWindow = 20
+)
+
+const broadcastComposeSystemPrompt = "You write announcements that will be posted to several chat channels. " +
+	"Follow the instructions, use the conversation only for context, and output just the message in markdown " +
+	"with no preamble, sign-off, or commentary."
+
+// outboundBroadcaster returns the broadcaster for gateway.broadcast.outbound,
+// or nil when no lists are configured.
+func (s *Server) outboundBroadcaster() *broadcasttools.Broadcaster {
+	if s.config == nil || len(s.config.Gateway.Broadcast.Outbound.Lists) == 0 || s.channels == nil {
+		return nil
+	}
+	s.outboundBroadcastOnce.Do(func() {
+		s.outboundBroadcast = broadcasttools.New(s.channels, s.config.Gateway.Broadcast.Outbound)
+	})
+	return s.outboundBroadcast
+}
+
+// senderMayBroadcast reports whether the sender of msg holds the role the
+// broadcast command requires, which also gates the broadcast tool.
+func (s *Server) senderMayBroadcast(ctx context.Context, msg *models.Message) bool {
+	if s.config == nil || len(s.config.Gateway.Broadcast.Outbound.Lists) == 0 || s.commandRegistry == nil {
+		return true
+	}
+	cmd, ok := s.commandRegistry.Get(broadcastToolName)
+	if !ok {
+		return true
+	}
+	return s.resolveCommandRole(ctx, msg).Allows(s.commandRegistry.RequiredRole(cmd))
+}
+
+// applyBroadcastCommand handles /broadcast <list> ...: text is sent as-is
+// when given, otherwise the message is composed from instructions.
+func (s *Server) applyBroadcastCommand(ctx context.Context, session *models.Session, msg *models.Message, list, text, instructions string) {
+	broadcaster := s.outboundBroadcaster()
+	if broadcaster == nil {
+		s.sendImmediateReply(ctx, session, msg, "No broadcast lists are configured.")
+		return
+	}
+	if _, ok := broadcaster.Targets(list); !ok {
+		s.sendImmediateReply(ctx, session, msg, fmt.Sprintf("Unknown broadcast list %q.\n\n%s", list, broadcasttools.FormatLists(broadcaster)))
+		return
+	}
+	if text == "" {
+		composed, err := s.composeBroadcast(ctx, session, list, instructions)
+		if err != nil {
+			s.logger.Error("failed to compose broadcast", "list", list, "error", err)
+			s.sendImmediateReply(ctx, session, msg, "Couldn't compose the broadcast. Send exact text with /broadcast "+list+" \"...\".")
+			return
+		}
+		text = composed
+	}
+	report, err := broadcaster.Send(ctx, session.ID, list, text)
+	if err != nil {
+		s.sendImmediateReply(ctx, session, msg, titleFirst(err.Error())+".")
+		return
+	}
+	s.sendImmediateReply(ctx, session, msg, broadcastReply(report))
+}
+
+// applyBroadcastRetryCommand handles /broadcast retry.
+func (s *Server) applyBroadcastRetryCommand(ctx context.Context, session *models.Session, msg *models.Message) {
+	broadcaster := s.outboundBroadcaster()
+	if broadcaster == nil {
+		s.sendImmediateReply(ctx, session, msg, "No broadcast lists are configured.")
+		return
+	}
+	report, err := broadcaster.RetryFailed(ctx, session.ID)
+	if err != nil {
+		s.sendImmediateReply(ctx, session, msg, titleFirst(err.Error())+".")
+		return
+	}
+	s.sendImmediateReply(ctx, session, msg, broadcastReply(report))
+}
+
+// applyBroadcastListsCommand handles /broadcast with no arguments.
+func (s *Server) applyBroadcastListsCommand(ctx context.Context, session *models.Session, msg *models.Message) {
+	broadcaster := s.outboundBroadcaster()
+	if broadcaster == nil {
+		s.sendImmediateReply(ctx, session, msg, "No broadcast lists are configured.")
+		return
+	}
+	s.sendImmediateReply(ctx, session, msg, broadcasttools.FormatLists(broadcaster)+"\n\nUsage: /broadcast <list> <what to say>")
+}
+
+// composeBroadcast asks the LLM to write a broadcast from instructions and
+// the recent conversation.
+func (s *Server) composeBroadcast(ctx context.Context, session *models.Session, list, instructions string) (string, error) {
+	var sb strings.Builder
+	if s.sessions != nil {
+		history, err := s.sessions.GetHistory(ctx, session.ID, broadcastHistoryWindow)
+		if err != nil {
+			s.logger.Warn("failed to load history for broadcast", "session", session.ID, "error", err)
+		}
+		for _, m := range history {
+			if m == nil || (m.Role != models.RoleUser && m.Role != models.RoleAssistant) {
+				continue
+			}
+			if content := strings.TrimSpace(m.Content); content != "" && !strings.HasPrefix(content, "/") {
+				fmt.Fprintf(&sb, "%s: %s\n", m.Role, truncateContent(content, 1000))
+			}
+		}
+	}
+	prompt := fmt.Sprintf("Write a message for the %q broadcast list.\n\nInstructions: %s", list, instructions)
+	if sb.Len() > 0 {
+		prompt = "Conversation:\n" + sb.String() + "\n" + prompt
+	}
+
+	model := sessionModelOverride(session)
+	if model == "" {
+		model = s.defaultModel
+	}
+	ctx, cancel := context.WithTimeout(ctx, 60*time.Second)
+	defer cancel()
+	text, err := collectCompletion(ctx, s.llmProvider, &agent.CompletionRequest{
+		Model:     model,
+		System:    broadcastComposeSystemPrompt,
+		Messages:  []agent.CompletionMessage{{Role: "user", Content: prompt}},
+		MaxTokens: 1024,
+	})
+	if err != nil {
+		return "", err
+	}
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return "", fmt.Errorf("empty completion")
+	}
+	return text, nil
+}
+
+// broadcastReply renders a delivery report, pointing at /broadcast retry
+// when some targets failed.
+func broadcastReply(report *broadcasttools.Report) string {
+	reply := report.Summary()
+	if len(report.Failed()) > 0 {
+		reply += "\n\nResend to the failed targets with /broadcast retry."
+	}
+	return reply
+}
@@ -0,0 +1,121 @@
+package gateway
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/haasonsaas/nexus/internal/agent"
+	"github.com/haasonsaas/nexus/internal/channels"
+	"github.com/haasonsaas/nexus/internal/config"
+	"github.com/haasonsaas/nexus/internal/sessions"
+	"github.com/haasonsaas/nexus/internal/tools/policy"
+	"github.com/haasonsaas/nexus/pkg/models"
+)
+
+func TestHandleMessageBroadcastCommand(t *testing.T) {
+	ctx := context.Background()
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	cfg := &config.Config{
+		Session:  config.SessionConfig{DefaultAgentID: "main"},
+		Commands: config.CommandsConfig{Roles: config.CommandRolesConfig{Assignments: map[string]string{"telegram:7": "admin"}}},
+		Gateway: config.GatewayConfig{Broadcast: config.BroadcastConfig{Outbound: config.OutboundBroadcastConfig{
+			Lists: map[string][]config.BroadcastTargetConfig{
+				"team": {
+					{Channel: "telegram", PeerID: "100", Label: "ops chat"},
+					{Channel: "telegram", PeerID: "200"},
+					{Channel: "discord", PeerID: "D1"},
+				},
+			},
+			MaxAttempts: 1,
+		}}},
+	}
+	server, err := NewServer(cfg, logger)
+	if err != nil {
+		t.Fatalf("NewServer() error = %v", err)
+	}
+	store := sessions.NewMemoryStore()
+	server.sessions = store
+	provider := &countingProvider{}
+	server.runtime = agent.NewRuntime(provider, store)
+	server.llmProvider = provider
+	adapter := &recordingAdapter{}
+	registry := channels.NewRegistry()
+	registry.Register(adapter)
+	server.channels = registry
+
+	send := func(id, content string, userID int64) string {
+		t.Helper()
+		server.handleMessage(ctx, &models.Message{
+			ID:        id,
+			Channel:   models.ChannelTelegram,
+			ChannelID: "chat-1",
+			Direction: models.DirectionInbound,
+			Role:      models.RoleUser,
+			Content:   content,
+			Metadata:  map[string]any{"chat_id": int64(1), "user_id": userID},
+		})
+		adapter.mu.Lock()
+		defer adapter.mu.Unlock()
+		return adapter.messages[len(adapter.messages)-1].Content
+	}
+	delivered := func() map[string]string {
+		adapter.mu.Lock()
+		defer adapter.mu.Unlock()
+		out := map[string]string{}
+		for _, m := range adapter.messages {
+			if m.Metadata["broadcast_list"] == "team" {
+				out[m.ChannelID] = m.Content
+			}
+		}
+		return out
+	}
+
+	if reply := send("m1", "/broadcast team announce the release", 8); !strings.Contains(reply, "admin") {
+		t.Fatalf("expected admin requirement, got %q", reply)
+	}
+	if reply := send("m2", "/broadcast", 7); !strings.Contains(reply, "team: ops chat, telegram:200, discord:D1") {
+		t.Fatalf("unexpected lists reply %q", reply)
+	}
+
+	reply := send("m3", `/broadcast team "**Deploy** at 5pm"`, 7)
+	if !strings.Contains(reply, "Broadcast to team: 2/3 delivered") || !strings.Contains(reply, "/broadcast retry") {
+		t.Fatalf("unexpected report %q", reply)
+	}
+	if got := delivered(); got["100"] != "Deploy at 5pm" || got["200"] != "Deploy at 5pm" {
+		t.Fatalf("unexpected deliveries %+v", got)
+	}
+	if calls, _ := provider.stats(); calls != 0 {
+		t.Fatalf("quoted broadcast should not call the LLM, got %d calls", calls)
+	}
+
+	reply = send("m4", "/broadcast team announce the release", 7)
+	if calls, _ := provider.stats(); calls != 1 || !strings.Contains(reply, "2/3 delivered") {
+		t.Fatalf("expected composed broadcast, got %q after %d calls", reply, calls)
+	}
+	if got := delivered(); got["100"] != "ok" {
+		t.Fatalf("expected composed text to be delivered, got %+v", got)
+	}
+
+	if reply = send("m5", "/broadcast retry", 7); !strings.Contains(reply, "0/1 delivered") || !strings.Contains(reply, "discord not available") {
+		t.Fatalf("unexpected retry report %q", reply)
+	}
+	if reply = send("m6", "/broadcast nope hi", 7); !strings.Contains(reply, `Unknown broadcast list "nope"`) {
+		t.Fatalf("unexpected reply %q", reply)
+	}
+
+	resolver := policy.NewResolver()
+	member := &models.Message{Channel: models.ChannelTelegram, Metadata: map[string]any{"user_id": int64(8)}}
+	if resolver.IsAllowed(server.resolveToolPolicy(ctx, nil, member), "broadcast") {
+		t.Fatal("expected broadcast tool to be denied for members")
+	}
+	if !resolver.IsAllowed(server.resolveToolPolicy(ctx, nil, member), "message") {
+		t.Fatal("expected other tools to stay allowed")
+	}
+	admin := &models.Message{Channel: models.ChannelTelegram, Metadata: map[string]any{"user_id": int64(7)}}
+	if p := server.resolveToolPolicy(ctx, nil, admin); p != nil {
+		t.Fatalf("expected no policy for admins, got %+v", p)
+	}
+}
@@ -0,0 +1,216 @@
+package gateway
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/haasonsaas/nexus/internal/canary"
+	"github.com/haasonsaas/nexus/internal/channels"
+	"github.com/haasonsaas/nexus/internal/channels/loopback"
+	"github.com/haasonsaas/nexus/internal/cluster"
+	"github.com/haasonsaas/nexus/internal/config"
+	"github.com/haasonsaas/nexus/internal/delivery"
+	"github.com/haasonsaas/nexus/internal/infra"
+	"github.com/haasonsaas/nexus/internal/observability"
+	"github.com/haasonsaas/nexus/internal/supervisor"
+	"github.com/haasonsaas/nexus/pkg/models"
+)
+
+// metaCanary marks canary messages with the name of their check.
+const metaCanary = "canary"
+
+// initCanary creates the canary runner and registers the loopback adapter
+// that receives the replies of loopback canaries. It runs before channels
+// and message processing start.
+func (s *Server) initCanary() {
+	if s == nil || s.config == nil || !s.config.Observability.Canary.Enabled {
+		return
+	}
+	cfg := s.config.Observability.Canary
+	if len(cfg.Checks) == 0 {
+		return
+	}
+
+	runner := canary.NewRunner(cfg, s.dispatchCanary, s.logger)
+	var webhook canary.AlertFunc
+	if url := strings.TrimSpace(cfg.Alerts.WebhookURL); url != "" {
+		webhook = canary.WebhookAlert(url, nil)
+	}
+	runner.SetAlert(func(ctx context.Context, alert canary.Alert) error {
+		var errs []error
+		if s.eventRecorder != nil {
+			data := map[string]interface{}{
+				"canary":               alert.Name,
+				"state":                string(alert.State),
+				"consecutive_failures": alert.ConsecutiveFailures,
+				"error":                alert.Error,
+				"latency_ms":           alert.LatencyMs,
+			}
+			errs = append(errs, s.eventRecorder.Record(ctx, observability.EventTypeCustom, "canary.alert", data))
+		}
+		if webhook != nil {
+			errs = append(errs, webhook(ctx, alert))
+		}
+		if cfg.Alerts.Channel != "" && cfg.Alerts.PeerID != "" {
+			_, err := s.sendProactive(ctx, delivery.KindAlert, models.ChannelType(cfg.Alerts.Channel), cfg.Alerts.PeerID, alert.Text())
+			errs = append(errs, err)
+		}
+		return errors.Join(errs...)
+	})
+	s.canary = runner
+
+	if _, exists := s.channels.Get(models.ChannelLoopback); exists {
+		return
+	}
+	for _, check := range cfg.Checks {
+		if check.Channel == string(models.ChannelLoopback) {
+			s.canaryLoopback = loopback.New(loopback.Config{Logger: s.logger})
+			s.channels.Register(s.canaryLoopback)
+			return
+		}
+	}
+}
+
+// startCanary runs the canary conversations on the cluster leader. Alerts
+// are recorded as "canary.alert" events and sent to the alert webhook and
+// admin conversation when configured.
+func (s *Server) startCanary(ctx context.Context) {
+	if s == nil || s.canary == nil {
+		return
+	}
+	runner := s.canary
+	infra.RegisterHealthCheck(infra.HealthCheckConfig{
+		Name:    "canary",
+		Checker: runner.HealthCheck,
+	})
+
+	if adapter := s.canaryLoopback; adapter != nil {
+		s.goSupervised(ctx, "worker:canary_replies", func(ctx context.Context) {
+			// Replies are reported from the processing pipeline; drain the
+			// adapter so its queue never fills.
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case <-adapter.Replies():
+				}
+			}
+		})
+	}
+	interval := s.config.Observability.Canary.Interval
+	if interval <= 0 {
+		interval = 5 * time.Minute
+	}
+	s.goSupervised(ctx, "worker:canary", func(ctx context.Context) {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			if s.isClusterLeader(cluster.LeaseCanary) {
+				runner.RunOnce(ctx)
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	})
+}
+
+// dispatchCanary processes a canary message as if the user had sent it.
+func (s *Server) dispatchCanary(ctx context.Context, check config.CanaryCheckConfig, id string) error {
+	var msg *models.Message
+	if check.Channel == string(models.ChannelLoopback) {
+		conversationID := "canary:" + check.Name
+		msg = &models.Message{
+			Channel:   models.ChannelLoopback,
+			ChannelID: conversationID,
+			Metadata: map[string]any{
+				loopback.MetaConversationID: conversationID,
+				"conversation_type":         "dm",
+			},
+		}
+	} else {
+		template, err := s.canaryTemplate(ctx, check)
+		if err != nil {
+			return err
+		}
+		metadata := make(map[string]any, len(template.Metadata)+1)
+		for key, value := range template.Metadata {
+			metadata[key] = value
+		}
+		delete(metadata, channels.ReplyMetadataKey)
+		delete(metadata, channels.MentionedMetadataKey)
+		msg = &models.Message{Channel: template.Channel, ChannelID: template.ChannelID, Metadata: metadata}
+	}
+	if _, ok := s.channels.GetOutbound(msg.Channel); !ok {
+		return fmt.Errorf("no outbound adapter for channel %s", msg.Channel)
+	}
+	msg.ID = id
+	msg.Direction = models.DirectionInbound
+	msg.Role = models.RoleUser
+	msg.Content = check.Message
+	msg.Metadata[metaCanary] = check.Name
+	msg.CreatedAt = time.Now()
+
+	select {
+	case s.messageSem <- struct{}{}:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	s.wg.Add(1)
+	go func() {
+		defer func() {
+			<-s.messageSem
+			s.wg.Done()
+		}()
+		defer supervisor.Recover("gateway:canary")
+		s.handleMessage(ctx, msg)
+	}()
+	return nil
+}
+
+// canaryTemplate returns the last user message in the check's session,
+// which supplies the channel metadata needed to deliver the reply.
+func (s *Server) canaryTemplate(ctx context.Context, check config.CanaryCheckConfig) (*models.Message, error) {
+	if s.sessions == nil {
+		return nil, fmt.Errorf("session store unavailable")
+	}
+	session, err := s.sessions.GetByKey(ctx, check.SessionKey)
+	if err != nil || session == nil {
+		return nil, fmt.Errorf("session %q not found", check.SessionKey)
+	}
+	if string(session.Channel) != check.Channel {
+		return nil, fmt.Errorf("session %q is on channel %s, not %s", check.SessionKey, session.Channel, check.Channel)
+	}
+	history, err := s.sessions.GetHistory(ctx, session.ID, heartbeatHistoryWindow)
+	if err != nil {
+		return nil, fmt.Errorf("load session history: %w", err)
+	}
+	for i := len(history) - 1; i >= 0; i-- {
+		msg := history[i]
+		if msg == nil || msg.Direction != models.DirectionInbound || msg.Role != models.RoleUser || isHeartbeatMessage(msg) {
+			continue
+		}
+		if _, ok := msg.Metadata[metaCanary]; ok {
+			continue
+		}
+		return msg, nil
+	}
+	return nil, fmt.Errorf("session %q has no user message to reply to", check.SessionKey)
+}
+
+// reportCanaryReply hands the delivered reply to a canary message to the
+// canary runner.
+func (s *Server) reportCanaryReply(inbound *models.Message, content string) {
+	if s.canary == nil || inbound == nil {
+		return
+	}
+	if _, ok := inbound.Metadata[metaCanary]; ok {
+		s.canary.Reply(inbound.ID, content)
+	}
+}
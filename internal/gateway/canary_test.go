@@ -0,0 +1,67 @@
+package gateway
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/haasonsaas/nexus/internal/channels"
+	"github.com/haasonsaas/nexus/internal/channels/loopback"
+	"github.com/haasonsaas/nexus/internal/config"
+)
+
+func TestCanaryLoopbackRoundTrip(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	cfg := &config.Config{}
+	cfg.Workspace.Path = t.TempDir()
+	cfg.Observability.Canary = config.CanaryConfig{
+		Enabled: true,
+		Timeout: 2 * time.Second,
+		Checks: []config.CanaryCheckConfig{
+			{Name: "ok", Channel: "loopback", Message: "ping", Expect: "WORLD"},
+			{Name: "wrong", Channel: "loopback", Message: "ping", Expect: "pong"},
+			{Name: "missing", Channel: "telegram", SessionKey: "main:telegram:1", Message: "ping", Expect: "pong"},
+		},
+		Alerts: config.CanaryAlertConfig{AfterFailures: 3},
+	}
+	server, err := NewServer(cfg, logger)
+	if err != nil {
+		t.Fatalf("NewServer() error = %v", err)
+	}
+
+	adapter := loopback.New(loopback.Config{Logger: logger})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	if err := server.StartEmbedded(ctx, EmbeddedOptions{
+		Adapters:         []channels.Adapter{adapter},
+		Provider:         streamingProvider{},
+		DisableRateLimit: true,
+	}); err != nil {
+		t.Fatalf("StartEmbedded() error = %v", err)
+	}
+	server.initCanary()
+
+	results := server.canary.RunOnce(ctx)
+	if !results[0].Success {
+		t.Fatalf("expected the loopback canary to pass, got %+v", results[0])
+	}
+	if results[1].Success || !strings.Contains(results[1].Error, `reply does not contain "pong"`) {
+		t.Fatalf("expected a content failure, got %+v", results[1])
+	}
+	if results[2].Success || !strings.Contains(results[2].Error, `session "main:telegram:1" not found`) {
+		t.Fatalf("expected a missing session failure, got %+v", results[2])
+	}
+	reply := <-adapter.Replies()
+	if got := loopback.ConversationID(reply); got != "canary:ok" && got != "canary:wrong" {
+		t.Fatalf("unexpected canary conversation %q", got)
+	}
+
+	stopCtx, stopCancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer stopCancel()
+	if err := server.Stop(stopCtx); err != nil {
+		t.Fatalf("Stop() error = %v", err)
+	}
+}
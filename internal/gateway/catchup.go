@@ -0,0 +1,492 @@
+package gateway
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/haasonsaas/nexus/internal/agent"
+	"github.com/haasonsaas/nexus/internal/cluster"
+	"github.com/haasonsaas/nexus/internal/config"
+	agentcontext "github.com/haasonsaas/nexus/internal/context"
+	"github.com/haasonsaas/nexus/internal/cron"
+	"github.com/haasonsaas/nexus/internal/sessions"
+	"github.com/haasonsaas/nexus/pkg/models"
+)
+
+const (
+	// metaCatchupSeen maps user IDs to when they last ran /catchup (RFC3339),
+	// so a restart does not summarize the same messages again.
+	metaCatchupSeen = "catchup_seen"
+	// metaCatchupDigestAt records when the last catch-up digest was posted.
+	metaCatchupDigestAt = "catchup_digest_at"
+
+	// catchupHistoryWindow is how many recent messages are searched for
+	// missed messages.
+	catchupHistoryWindow = 500
+
+	// catchupMessageMaxChars truncates each missed message in the prompt.
+	catchupMessageMaxChars = 1000
+
+	catchupSummaryTimeout = 60 * time.Second
+	catchupTick           = time.Minute
+)
+
+const catchupSystemPrompt = `You summarize a group chat for someone who was away.
+Group the summary by topic and lead with decisions, questions addressed to the reader, and action items. Name who said what when it matters.
+Be brief: short bullet points, no preamble.`
+
+// readMark is the last message the agent has read in a conversation.
+type readMark struct {
+	MessageID string
+	At        time.Time
+}
+
+// readState tracks, per session, how far the agent has read and when each
+// user was last active.
+type readState struct {
+	mu    sync.Mutex
+	agent map[string]readMark
+	users map[string]map[string]time.Time
+}
+
+// markAgentRead advances the agent's read position to msg.
+func (r *readState) markAgentRead(sessionID string, msg *models.Message) {
+	if msg == nil {
+		return
+	}
+	at := msg.CreatedAt
+	if at.IsZero() {
+		at = time.Now()
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.agent == nil {
+		r.agent = make(map[string]readMark)
+	}
+	if current, ok := r.agent[sessionID]; ok && current.At.After(at) {
+		return
+	}
+	r.agent[sessionID] = readMark{MessageID: msg.ID, At: at}
+}
+
+func (r *readState) agentPosition(sessionID string) (readMark, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	mark, ok := r.agent[sessionID]
+	return mark, ok
+}
+
+// markUserActive records that userID was active in the session at at.
+func (r *readState) markUserActive(sessionID, userID string, at time.Time) {
+	if userID == "" {
+		return
+	}
+	if at.IsZero() {
+		at = time.Now()
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.users == nil {
+		r.users = make(map[string]map[string]time.Time)
+	}
+	if r.users[sessionID] == nil {
+		r.users[sessionID] = make(map[string]time.Time)
+	}
+	if at.After(r.users[sessionID][userID]) {
+		r.users[sessionID][userID] = at
+	}
+}
+
+func (r *readState) userActivity(sessionID, userID string) (time.Time, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	at, ok := r.users[sessionID][userID]
+	return at, ok
+}
+
+func (s *Server) catchupEnabled() bool {
+	return s != nil && s.config != nil && s.config.Session.Catchup.Enabled
+}
+
+// trackReadState records the sender's activity for msg and, when the agent
+// is about to answer it, the agent's read position.
+func (s *Server) trackReadState(session *models.Session, msg *models.Message, answered bool) {
+	if !s.catchupEnabled() || session == nil || msg == nil || isHeartbeatMessage(msg) {
+		return
+	}
+	s.readState.markUserActive(session.ID, extractSenderID(msg), msg.CreatedAt)
+	if answered {
+		s.readState.markAgentRead(session.ID, msg)
+	}
+}
+
+// applyCatchupCommand handles /catchup: it summarizes the messages posted
+// since the user was last active, or within window when one is given.
+func (s *Server) applyCatchupCommand(ctx context.Context, session *models.Session, msg *models.Message, window string) {
+	if !s.catchupEnabled() {
+		s.sendImmediateReply(ctx, session, msg, "Catch-up summaries are not enabled on this gateway.")
+		return
+	}
+	now := time.Now()
+	userID := extractSenderID(msg)
+	history, err := s.sessions.GetHistory(ctx, session.ID, catchupHistoryWindow)
+	if err != nil {
+		s.logger.Error("catchup: history unavailable", "session_id", session.ID, "error", err)
+		s.sendImmediateReply(ctx, session, msg, "Failed to load the conversation history.")
+		return
+	}
+
+	since := s.catchupSince(session, userID, history, window, now)
+	missed := missedMessages(history, since, userID)
+	if len(missed) == 0 {
+		s.sendImmediateReply(ctx, session, msg, fmt.Sprintf("Nothing new since %s.", formatCatchupTime(since, now)))
+	} else {
+		s.sendImmediateReply(ctx, session, msg, s.catchupSummary(ctx, session, missed, since, now))
+	}
+
+	s.readState.markUserActive(session.ID, userID, now)
+	if userID == "" {
+		return
+	}
+	if session.Metadata == nil {
+		session.Metadata = map[string]any{}
+	}
+	seen, _ := session.Metadata[metaCatchupSeen].(map[string]any)
+	if seen == nil {
+		seen = map[string]any{}
+	}
+	seen[userID] = now.UTC().Format(time.RFC3339)
+	session.Metadata[metaCatchupSeen] = seen
+	if err := s.sessions.Update(ctx, session); err != nil {
+		s.logger.Debug("catchup: failed to record read position", "session_id", session.ID, "error", err)
+	}
+}
+
+// catchupSince returns when userID was last active in the session: the
+// latest of their tracked activity, their last /catchup, and their last
+// message in history. Users with no activity get the configured lookback.
+func (s *Server) catchupSince(session *models.Session, userID string, history []*models.Message, window string, now time.Time) time.Time {
+	if window != "" {
+		if duration, err := time.ParseDuration(window); err == nil {
+			return now.Add(-duration)
+		}
+	}
+	var since time.Time
+	if at, ok := s.readState.userActivity(session.ID, userID); ok {
+		since = at
+	}
+	if seen, ok := session.Metadata[metaCatchupSeen].(map[string]any); ok && userID != "" {
+		if raw, ok := seen[userID].(string); ok {
+			if at, err := time.Parse(time.RFC3339, raw); err == nil && at.After(since) {
+				since = at
+			}
+		}
+	}
+	if userID != "" {
+		for i := len(history) - 1; i >= 0; i-- {
+			m := history[i]
+			if m != nil && m.Role == models.RoleUser && extractSenderID(m) == userID {
+				if m.CreatedAt.After(since) {
+					since = m.CreatedAt
+				}
+				break
+			}
+		}
+	}
+	if since.IsZero() {
+		since = now.Add(-s.config.Session.Catchup.Lookback)
+	}
+	return since
+}
+
+// missedMessages returns the messages in history posted after since by
+// anyone other than userID, oldest first.
+func missedMessages(history []*models.Message, since time.Time, userID string) []*models.Message {
+	var missed []*models.Message
+	for _, m := range history {
+		if m == nil || !m.CreatedAt.After(since) || strings.TrimSpace(m.Content) == "" || isHeartbeatMessage(m) {
+			continue
+		}
+		if m.Role != models.RoleUser && m.Role != models.RoleAssistant {
+			continue
+		}
+		if userID != "" && m.Role == models.RoleUser && extractSenderID(m) == userID {
+			continue
+		}
+		missed = append(missed, m)
+	}
+	return missed
+}
+
+// catchupSummary summarizes missed under the configured token budgets,
+// headed by how many messages came from whom.
+func (s *Server) catchupSummary(ctx context.Context, session *models.Session, missed []*models.Message, since, now time.Time) string {
+	header := fmt.Sprintf("Since %s: %d %s", formatCatchupTime(since, now), len(missed), pluralize(len(missed), "message", "messages"))
+	if names := catchupParticipants(missed); len(names) > 0 {
+		header += " from " + strings.Join(names, ", ")
+	}
+
+	summary, err := s.summarizeMissed(ctx, session, missed)
+	if err != nil {
+		s.logger.Warn("catchup: summary failed", "session_id", session.ID, "error", err)
+		return header + ".\nA summary is not available right now."
+	}
+	return header + ".\n\n" + summary
+}
+
+// summarizeMissed asks the model for a summary of missed. The newest
+// messages that fit in max_input_tokens are sent; older ones are counted
+// but dropped.
+func (s *Server) summarizeMissed(ctx context.Context, session *models.Session, missed []*models.Message) (string, error) {
+	if s.llmProvider == nil {
+		return "", fmt.Errorf("llm provider unavailable")
+	}
+	cfg := s.config.Session.Catchup
+	prompt, omitted := buildCatchupPrompt(missed, cfg.MaxInputTokens)
+	if omitted > 0 {
+		s.logger.Debug("catchup: trimmed missed messages to the token budget", "session_id", session.ID, "omitted", omitted)
+	}
+
+	model := strings.TrimSpace(cfg.Model)
+	if model == "" {
+		model = sessionModelOverride(session)
+	}
+	if model == "" {
+		model = s.defaultModel
+	}
+	ctx, cancel := context.WithTimeout(ctx, catchupSummaryTimeout)
+	defer cancel()
+	text, err := collectCompletion(ctx, s.llmProvider, &agent.CompletionRequest{
+		Model:     model,
+		System:    catchupSystemPrompt,
+		Messages:  []agent.CompletionMessage{{Role: "user", Content: prompt}},
+		MaxTokens: cfg.MaxOutputTokens,
+	})
+	if err != nil {
+		return "", err
+	}
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return "", fmt.Errorf("empty summary")
+	}
+	return text, nil
+}
+
+// buildCatchupPrompt renders the newest messages that fit within maxTokens
+// as a transcript, returning it and how many older messages were left out.
+func buildCatchupPrompt(missed []*models.Message, maxTokens int) (string, int) {
+	lines := make([]string, 0, len(missed))
+	used := 0
+	for i := len(missed) - 1; i >= 0; i-- {
+		m := missed[i]
+		speaker := "assistant"
+		if m.Role == models.RoleUser {
+			speaker = extractSenderName(m)
+			if speaker == "" {
+				speaker = "user"
+			}
+		}
+		line := fmt.Sprintf("[%s] %s: %s", m.CreatedAt.Format("Jan 2 15:04"), speaker, truncateContent(strings.TrimSpace(m.Content), catchupMessageMaxChars))
+		tokens := agentcontext.EstimateTokens(line) + 4
+		if maxTokens > 0 && used+tokens > maxTokens && len(lines) > 0 {
+			break
+		}
+		used += tokens
+		lines = append(lines, line)
+	}
+	omitted := len(missed) - len(lines)
+	for i, j := 0, len(lines)-1; i < j; i, j = i+1, j-1 {
+		lines[i], lines[j] = lines[j], lines[i]
+	}
+
+	var sb strings.Builder
+	if omitted > 0 {
+		fmt.Fprintf(&sb, "(%d earlier messages omitted)\n", omitted)
+	}
+	sb.WriteString("Messages:\n")
+	sb.WriteString(strings.Join(lines, "\n"))
+	return sb.String(), omitted
+}
+
+// catchupParticipants lists who posted the missed messages, most active
+// first.
+func catchupParticipants(missed []*models.Message) []string {
+	counts := make(map[string]int)
+	for _, m := range missed {
+		if m.Role != models.RoleUser {
+			continue
+		}
+		if name := extractSenderName(m); name != "" {
+			counts[name]++
+		}
+	}
+	names := make([]string, 0, len(counts))
+	for name := range counts {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		if counts[names[i]] != counts[names[j]] {
+			return counts[names[i]] > counts[names[j]]
+		}
+		return names[i] < names[j]
+	})
+	return names
+}
+
+func formatCatchupTime(at, now time.Time) string {
+	if at.Year() == now.Year() && at.YearDay() == now.YearDay() {
+		return at.Format("15:04")
+	}
+	return at.Format("Jan 2 15:04")
+}
+
+func pluralize(n int, singular, plural string) string {
+	if n == 1 {
+		return singular
+	}
+	return plural
+}
+
+// startCatchupDigest launches the worker that posts scheduled catch-up
+// digests to group conversations.
+func (s *Server) startCatchupDigest(ctx context.Context) {
+	if !s.catchupEnabled() || !s.config.Session.Catchup.Digest.Enabled || s.sessions == nil {
+		return
+	}
+	cfg := s.config.Session.Catchup.Digest
+	timezone := strings.TrimSpace(cfg.Timezone)
+	if timezone == "" {
+		timezone = strings.TrimSpace(s.config.User.Timezone)
+	}
+	schedule, err := cron.NewSchedule(config.CronScheduleConfig{Cron: cfg.Schedule, Timezone: timezone})
+	if err != nil {
+		s.logger.Warn("catchup: digest disabled (invalid schedule)", "schedule", cfg.Schedule, "error", err)
+		return
+	}
+
+	s.goSupervised(ctx, "worker:catchup", func(ctx context.Context) {
+		ticker := time.NewTicker(catchupTick)
+		defer ticker.Stop()
+
+		next := nextAttentionDigest(schedule, time.Now())
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case now := <-ticker.C:
+				if next.IsZero() || now.Before(next) {
+					continue
+				}
+				next = nextAttentionDigest(schedule, now)
+				if !s.isClusterLeader(cluster.LeaseCatchupDigest) {
+					continue
+				}
+				s.runCatchupDigests(ctx, now)
+			}
+		}
+	})
+}
+
+// runCatchupDigests posts a digest to every group conversation with at
+// least min_messages the agent has not read.
+func (s *Server) runCatchupDigests(ctx context.Context, now time.Time) {
+	sessionList, err := s.sessions.List(ctx, "", sessions.ListOptions{})
+	if err != nil {
+		s.logger.Warn("catchup: list sessions failed", "error", err)
+		return
+	}
+	for _, session := range sessionList {
+		if session == nil {
+			continue
+		}
+		if reason := s.postCatchupDigest(ctx, session, now); reason != "" {
+			s.logger.Debug("skipping catch-up digest", "session_id", session.ID, "reason", reason)
+		}
+	}
+}
+
+// postCatchupDigest summarizes the messages after the agent's read position
+// into the session's conversation, returning why it was skipped, if it was.
+func (s *Server) postCatchupDigest(ctx context.Context, session *models.Session, now time.Time) string {
+	if until, ok := sessionQuietUntil(session); ok && now.Before(until) {
+		return "quiet_command"
+	}
+	if s.hasActiveRun(session.ID) {
+		return "active_run"
+	}
+	if _, ok := s.channels.GetOutbound(session.Channel); !ok {
+		return "no_outbound_adapter"
+	}
+	history, err := s.sessions.GetHistory(ctx, session.ID, catchupHistoryWindow)
+	if err != nil {
+		return "history_unavailable"
+	}
+	var template *models.Message
+	for i := len(history) - 1; i >= 0; i-- {
+		if m := history[i]; m != nil && m.Direction == models.DirectionInbound && m.Role == models.RoleUser && !isHeartbeatMessage(m) {
+			template = m
+			break
+		}
+	}
+	if template == nil {
+		return "no_user_message"
+	}
+	if conversationTypeForMessage(template) != "group" {
+		return "not_group"
+	}
+
+	since := s.agentReadPosition(session, history)
+	missed := missedMessages(history, since, "")
+	if len(missed) == 0 || len(missed) < s.config.Session.Catchup.Digest.MinMessages {
+		return "too_few_unread"
+	}
+	summary, err := s.summarizeMissed(ctx, session, missed)
+	if err != nil {
+		s.logger.Warn("catchup: digest summary failed", "session_id", session.ID, "error", err)
+		return "summary_failed"
+	}
+
+	header := fmt.Sprintf("Catch-up: %d %s since %s", len(missed), pluralize(len(missed), "message", "messages"), formatCatchupTime(since, now))
+	if names := catchupParticipants(missed); len(names) > 0 {
+		header += " from " + strings.Join(names, ", ")
+	}
+	s.sendImmediateReply(ctx, session, template, header+".\n\n"+summary)
+
+	s.readState.markAgentRead(session.ID, missed[len(missed)-1])
+	if session.Metadata == nil {
+		session.Metadata = map[string]any{}
+	}
+	session.Metadata[metaCatchupDigestAt] = now.UTC().Format(time.RFC3339)
+	if err := s.sessions.Update(ctx, session); err != nil {
+		s.logger.Debug("catchup: failed to record digest", "session_id", session.ID, "error", err)
+	}
+	return ""
+}
+
+// agentReadPosition returns how far the agent has read in the session: its
+// tracked position, the last digest, or its last reply in history, whichever
+// is latest.
+func (s *Server) agentReadPosition(session *models.Session, history []*models.Message) time.Time {
+	var since time.Time
+	if mark, ok := s.readState.agentPosition(session.ID); ok {
+		since = mark.At
+	}
+	if raw, ok := session.Metadata[metaCatchupDigestAt].(string); ok {
+		if at, err := time.Parse(time.RFC3339, raw); err == nil && at.After(since) {
+			since = at
+		}
+	}
+	for i := len(history) - 1; i >= 0; i-- {
+		if m := history[i]; m != nil && m.Role == models.RoleAssistant {
+			if m.CreatedAt.After(since) {
+				since = m.CreatedAt
+			}
+			break
+		}
+	}
+	return since
+}
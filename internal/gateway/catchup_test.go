@@ -0,0 +1,123 @@
+package gateway
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/haasonsaas/nexus/internal/config"
+	"github.com/haasonsaas/nexus/internal/sessions"
+	"github.com/haasonsaas/nexus/pkg/models"
+)
+
+func newCatchupServer(t *testing.T) (*Server, *etiquetteProvider, *recordingAdapter) {
+	t.Helper()
+	server, provider, _ := newEtiquetteServer(t, config.ChannelPolicyConfig{Activation: "mention"})
+	server.config.Session.Catchup = config.CatchupConfig{
+		Enabled:         true,
+		MaxInputTokens:  4000,
+		MaxOutputTokens: 400,
+		Lookback:        24 * time.Hour,
+		Digest:          config.CatchupDigestConfig{Enabled: true, MinMessages: 2},
+	}
+	adapter, _ := server.channels.Get(models.ChannelTelegram)
+	return server, provider, adapter.(*recordingAdapter)
+}
+
+func memberMessage(id, senderID, senderName, content string) *models.Message {
+	msg := groupMessage(id, content, false)
+	msg.Metadata["sender_id"] = senderID
+	msg.Metadata["sender_name"] = senderName
+	return msg
+}
+
+func (a *recordingAdapter) last() string {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if len(a.messages) == 0 {
+		return ""
+	}
+	return a.messages[len(a.messages)-1].Content
+}
+
+func TestCatchupCommand(t *testing.T) {
+	ctx := context.Background()
+	server, provider, adapter := newCatchupServer(t)
+
+	server.handleMessage(ctx, memberMessage("m1", "1", "Ada", "heading out, back later"))
+	server.handleMessage(ctx, memberMessage("m2", "2", "Bob", "we moved the launch to Friday"))
+	server.handleMessage(ctx, memberMessage("m3", "3", "Cy", "Ada, can you review the PR?"))
+	server.handleMessage(ctx, memberMessage("m4", "2", "Bob", "also the demo is cancelled"))
+
+	server.handleMessage(ctx, memberMessage("m5", "1", "Ada", "/catchup"))
+	reply := adapter.last()
+	if !strings.Contains(reply, "3 messages from Bob, Cy") || !strings.HasSuffix(reply, "sure") {
+		t.Fatalf("unexpected catch-up reply %q", reply)
+	}
+	provider.mu.Lock()
+	req := provider.lastRequest
+	provider.mu.Unlock()
+	if req == nil || req.System != catchupSystemPrompt || req.MaxTokens != 400 {
+		t.Fatalf("unexpected summary request %+v", req)
+	}
+	prompt := req.Messages[0].Content
+	if !strings.Contains(prompt, "Bob: we moved the launch") || strings.Contains(prompt, "heading out") {
+		t.Fatalf("expected only the missed messages in the prompt, got %q", prompt)
+	}
+
+	server.handleMessage(ctx, memberMessage("m6", "1", "Ada", "/catchup"))
+	if reply := adapter.last(); !strings.HasPrefix(reply, "Nothing new since") {
+		t.Fatalf("expected nothing new after catching up, got %q", reply)
+	}
+
+	server.config.Session.Catchup.Enabled = false
+	server.handleMessage(ctx, memberMessage("m7", "1", "Ada", "/catchup"))
+	if reply := adapter.last(); !strings.Contains(reply, "not enabled") {
+		t.Fatalf("expected catch-up to be refused when disabled, got %q", reply)
+	}
+}
+
+func TestCatchupDigest(t *testing.T) {
+	ctx := context.Background()
+	server, _, adapter := newCatchupServer(t)
+
+	server.handleMessage(ctx, memberMessage("m1", "2", "Bob", "deploy is green"))
+	sessionList, err := server.sessions.List(ctx, "", sessions.ListOptions{})
+	if err != nil || len(sessionList) != 1 {
+		t.Fatalf("expected one group session, got %d (%v)", len(sessionList), err)
+	}
+	session := sessionList[0]
+	if reason := server.postCatchupDigest(ctx, session, time.Now()); reason != "too_few_unread" {
+		t.Fatalf("expected a single message to be too few for a digest, got %q", reason)
+	}
+
+	server.handleMessage(ctx, memberMessage("m2", "3", "Cy", "rolling back staging"))
+	server.runCatchupDigests(ctx, time.Now())
+	if reply := adapter.last(); !strings.HasPrefix(reply, "Catch-up: 2 messages") {
+		t.Fatalf("unexpected digest %q", reply)
+	}
+
+	if reason := server.postCatchupDigest(ctx, session, time.Now()); reason != "too_few_unread" {
+		t.Fatalf("expected the digest to advance the read position, got %q", reason)
+	}
+}
+
+func TestBuildCatchupPromptBudget(t *testing.T) {
+	base := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	var missed []*models.Message
+	for i := 0; i < 20; i++ {
+		msg := memberMessage("m", "2", "Bob", strings.Repeat("x", 200))
+		msg.CreatedAt = base.Add(time.Duration(i) * time.Minute)
+		missed = append(missed, msg)
+	}
+	missed[19].Content = "latest"
+
+	prompt, omitted := buildCatchupPrompt(missed, 200)
+	if omitted == 0 || !strings.HasPrefix(prompt, "(") || !strings.HasSuffix(prompt, "Bob: latest") {
+		t.Fatalf("expected the oldest messages to be dropped, got %d omitted:\n%s", omitted, prompt)
+	}
+	if _, omitted := buildCatchupPrompt(missed, 0); omitted != 0 {
+		t.Fatalf("expected no budget to keep every message, got %d omitted", omitted)
+	}
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/haasonsaas/nexus/internal/channels/email"
 	"github.com/haasonsaas/nexus/internal/channels/mattermost"
 	"github.com/haasonsaas/nexus/internal/channels/nextcloudtalk"
+	"github.com/haasonsaas/nexus/internal/channels/signal"
 	"github.com/haasonsaas/nexus/internal/channels/slack"
 	"github.com/haasonsaas/nexus/internal/channels/teams"
 	"github.com/haasonsaas/nexus/internal/channels/telegram"
@@ -87,6 +88,7 @@ func registerBuiltinChannelPlugins(registry *channelPluginRegistry) {
 	registry.Register(nextcloudTalkPlugin{})
 	registry.Register(zaloPlugin{})
 	registry.Register(blueBubblesPlugin{})
+	registry.Register(signalPlugin{})
 }
 
 type telegramPlugin struct{}
@@ -112,10 +114,12 @@ func (telegramPlugin) Build(cfg *config.Config, logger *slog.Logger) (channels.A
 		mode = telegram.ModeWebhook
 	}
 	return telegram.NewAdapter(telegram.Config{
-		Token:      cfg.Channels.Telegram.BotToken,
-		Mode:       mode,
-		WebhookURL: webhookURL,
-		Logger:     logger,
+		Token:          cfg.Channels.Telegram.BotToken,
+		Mode:           mode,
+		WebhookURL:     webhookURL,
+		ChannelPosts:   cfg.Channels.Telegram.ChannelPosts,
+		EditedMessages: cfg.Channels.Telegram.EditedMessages,
+		Logger:         logger,
 	})
 }
 
@@ -223,7 +227,7 @@ type emailPlugin struct{}
 func (emailPlugin) Manifest() ChannelPluginManifest {
 	return ChannelPluginManifest{
 		ID:   models.ChannelEmail,
-		Name: "Microsoft Graph Email",
+		Name: "Email",
 	}
 }
 
@@ -232,6 +236,9 @@ func (emailPlugin) Enabled(cfg *config.Config) bool {
 }
 
 func (emailPlugin) Build(cfg *config.Config, logger *slog.Logger) (channels.Adapter, error) {
+	if strings.EqualFold(strings.TrimSpace(cfg.Channels.Email.Backend), email.BackendIMAP) {
+		return buildIMAPEmailAdapter(cfg.Channels.Email, logger)
+	}
 	if cfg.Channels.Email.TenantID == "" {
 		return nil, errors.New("email tenant_id is required")
 	}
@@ -267,6 +274,48 @@ func (emailPlugin) Build(cfg *config.Config, logger *slog.Logger) (channels.Adap
 	})
 }
 
+// buildIMAPEmailAdapter builds the IMAP/SMTP email adapter.
+func buildIMAPEmailAdapter(cfg config.EmailConfig, logger *slog.Logger) (channels.Adapter, error) {
+	pollInterval := 30 * time.Second
+	if cfg.PollInterval != "" {
+		if d, err := time.ParseDuration(cfg.PollInterval); err == nil {
+			pollInterval = d
+		}
+	}
+	return email.NewIMAPAdapter(email.Config{
+		Backend:      email.BackendIMAP,
+		Address:      cfg.Address,
+		DisplayName:  cfg.DisplayName,
+		TenantID:     cfg.TenantID,
+		ClientID:     cfg.ClientID,
+		ClientSecret: cfg.ClientSecret,
+		AccessToken:  cfg.OAuth2.AccessToken,
+		RefreshToken: cfg.OAuth2.RefreshToken,
+		TokenURL:     cfg.OAuth2.TokenURL,
+		IMAP: email.IMAPConfig{
+			Host:        cfg.IMAP.Host,
+			Port:        cfg.IMAP.Port,
+			Security:    cfg.IMAP.Security,
+			Username:    cfg.IMAP.Username,
+			Password:    cfg.IMAP.Password,
+			DisableIdle: cfg.IMAP.IDLE != nil && !*cfg.IMAP.IDLE,
+			IdleTimeout: cfg.IMAP.IdleTimeout,
+		},
+		SMTP: email.SMTPConfig{
+			Host:     cfg.SMTP.Host,
+			Port:     cfg.SMTP.Port,
+			Security: cfg.SMTP.Security,
+			Username: cfg.SMTP.Username,
+			Password: cfg.SMTP.Password,
+		},
+		FolderID:     cfg.FolderID,
+		IncludeRead:  cfg.IncludeRead,
+		AutoMarkRead: cfg.AutoMarkRead,
+		PollInterval: pollInterval,
+		Logger:       logger,
+	})
+}
+
 type mattermostPlugin struct{}
 
 func (mattermostPlugin) Manifest() ChannelPluginManifest {
@@ -343,6 +392,47 @@ func (zaloPlugin) Build(cfg *config.Config, logger *slog.Logger) (channels.Adapt
 	})
 }
 
+type signalPlugin struct{}
+
+func (signalPlugin) Manifest() ChannelPluginManifest {
+	return ChannelPluginManifest{
+		ID:   models.ChannelSignal,
+		Name: "Signal",
+	}
+}
+
+func (signalPlugin) Enabled(cfg *config.Config) bool {
+	return cfg != nil && cfg.Channels.Signal.Enabled
+}
+
+func (signalPlugin) Build(cfg *config.Config, logger *slog.Logger) (channels.Adapter, error) {
+	signalCfg := signal.DefaultConfig()
+	signalCfg.Enabled = true
+	signalCfg.Account = strings.TrimSpace(cfg.Channels.Signal.Account)
+	if path := strings.TrimSpace(cfg.Channels.Signal.SignalCLIPath); path != "" {
+		signalCfg.SignalCLIPath = path
+	}
+	if dir := strings.TrimSpace(cfg.Channels.Signal.ConfigDir); dir != "" {
+		signalCfg.ConfigDir = dir
+	}
+	if mode := strings.ToLower(strings.TrimSpace(cfg.Channels.Signal.Mode)); mode != "" {
+		signalCfg.Mode = mode
+	}
+	signalCfg.DaemonAddress = strings.TrimSpace(cfg.Channels.Signal.DaemonAddress)
+	if cfg.Channels.Signal.ReconnectDelay > 0 {
+		signalCfg.ReconnectDelay = cfg.Channels.Signal.ReconnectDelay.String()
+	}
+	if cfg.Channels.Signal.AttachmentMaxAge != "" {
+		signalCfg.AttachmentMaxAge = cfg.Channels.Signal.AttachmentMaxAge
+	}
+	signalCfg.Personal.Presence.SendReadReceipts = cfg.Channels.Signal.Presence.SendReadReceipts
+	signalCfg.Personal.Presence.SendTyping = cfg.Channels.Signal.Presence.SendTyping
+	if err := signalCfg.Validate(); err != nil {
+		return nil, err
+	}
+	return signal.New(signalCfg, logger)
+}
+
 type blueBubblesPlugin struct{}
 
 func (blueBubblesPlugin) Manifest() ChannelPluginManifest {
@@ -0,0 +1,94 @@
+package gateway
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/haasonsaas/nexus/internal/cluster"
+)
+
+// startCluster starts leader election and work partitioning when cluster
+// coordination is enabled. Coordination state lives in the session database,
+// so it is unavailable without one.
+func (s *Server) startCluster(ctx context.Context) error {
+	if s == nil || s.config == nil {
+		return nil
+	}
+	if !s.config.Cluster.Enabled || !s.config.Cluster.Coordination.Enabled {
+		return nil
+	}
+	db, err := s.clusterDB()
+	if err != nil {
+		return err
+	}
+	if db == nil {
+		s.logger.Warn("cluster coordination requires a database; running without leader election")
+		return nil
+	}
+
+	store, err := cluster.NewDBStore(db)
+	if err != nil {
+		return err
+	}
+	coordCfg := s.config.Cluster.Coordination
+	coordinator, err := cluster.New(store, cluster.Config{
+		NodeID:            s.nodeID,
+		Address:           s.config.Server.Host,
+		HeartbeatInterval: coordCfg.HeartbeatInterval,
+		NodeTTL:           coordCfg.NodeTTL,
+		LeaseTTL:          coordCfg.LeaseTTL,
+		Logger:            s.logger,
+	})
+	if err != nil {
+		return err
+	}
+	if err := coordinator.Start(ctx); err != nil {
+		return fmt.Errorf("start cluster coordinator: %w", err)
+	}
+	s.cluster = coordinator
+	return nil
+}
+
+// clusterDB returns the session database, creating the session store if the
+// runtime has not done so yet.
+func (s *Server) clusterDB() (*sql.DB, error) {
+	s.runtimeMu.Lock()
+	defer s.runtimeMu.Unlock()
+	if s.sessions == nil && s.config.Database.URL != "" {
+		store, err := s.newSessionStore()
+		if err != nil {
+			return nil, fmt.Errorf("create session store: %w", err)
+		}
+		s.sessions = store
+	}
+	return s.readinessDB(), nil
+}
+
+// stopCluster releases leases and leaves the cluster.
+func (s *Server) stopCluster(ctx context.Context) {
+	if s.cluster == nil {
+		return
+	}
+	if err := s.cluster.Stop(ctx); err != nil {
+		s.logger.Error("error stopping cluster coordinator", "error", err)
+	}
+}
+
+// isClusterLeader reports whether this node should run the named singleton
+// subsystem. Without coordination every node is its own leader.
+func (s *Server) isClusterLeader(lease string) bool {
+	if s.cluster == nil {
+		return true
+	}
+	return s.cluster.IsLeader(lease)
+}
+
+// leaderCheck returns a leader check for the named lease, or nil when
+// coordination is disabled.
+func (s *Server) leaderCheck(lease string) func() bool {
+	if s.cluster == nil {
+		return nil
+	}
+	return func() bool { return s.cluster.IsLeader(lease) }
+}
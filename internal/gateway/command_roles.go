@@ -0,0 +1,123 @@
+package gateway
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/haasonsaas/nexus/internal/access"
+	"github.com/haasonsaas/nexus/internal/commands"
+	"github.com/haasonsaas/nexus/internal/config"
+	"github.com/haasonsaas/nexus/pkg/models"
+)
+
+// setupCommandRoles loads runtime role grants, applies configured command
+// overrides, and registers the /role command.
+func setupCommandRoles(registry *commands.Registry, cfg config.CommandRolesConfig) (*commands.RoleStore, error) {
+	path := strings.TrimSpace(cfg.StorePath)
+	if path == "" {
+		path = commands.DefaultRoleStorePath()
+	}
+	store, err := commands.NewRoleStore(path)
+	if err != nil {
+		return nil, err
+	}
+	if len(cfg.Commands) > 0 {
+		overrides := make(map[string]commands.Role, len(cfg.Commands))
+		for name, value := range cfg.Commands {
+			role, err := commands.ParseRole(value)
+			if err != nil {
+				return nil, fmt.Errorf("commands.roles.commands.%s: %w", name, err)
+			}
+			overrides[name] = role
+		}
+		registry.SetMinRoles(overrides)
+	}
+	return store, nil
+}
+
+// resolveCommandRole determines the sender's command role. Runtime grants
+// win over configured assignments, which win over admin message metadata,
+// the per-channel default, and finally the global default.
+func (s *Server) resolveCommandRole(ctx context.Context, msg *models.Message) commands.Role {
+	if msg == nil {
+		return commands.RoleGuest
+	}
+	var rolesCfg config.CommandRolesConfig
+	if s.config != nil {
+		rolesCfg = s.config.Commands.Roles
+	}
+
+	channel := strings.ToLower(string(msg.Channel))
+	sender := extractSenderID(msg)
+	var subjects []string
+	if sender != "" {
+		subjects = append(subjects, channel+":"+sender)
+		// /role stores grants with mention syntax stripped, so a Matrix
+		// grant is "matrix:alice:matrix.org" for sender "@alice:matrix.org".
+		if normalized := access.NormalizeSender(sender); normalized != strings.ToLower(sender) {
+			subjects = append(subjects, channel+":"+normalized)
+		}
+		if s.identityStore != nil {
+			if ident, err := s.identityStore.ResolveByPeer(ctx, channel, sender); err == nil && ident != nil {
+				subjects = append(subjects, ident.CanonicalID)
+			}
+		}
+	}
+
+	for _, subject := range subjects {
+		if role, ok := s.roleStore.Get(subject); ok {
+			return role
+		}
+	}
+	for _, subject := range subjects {
+		if role, ok := lookupRole(rolesCfg.Assignments, subject); ok {
+			return role
+		}
+	}
+	if isAdminMessage(msg) {
+		return commands.RoleAdmin
+	}
+	if role, ok := lookupRole(rolesCfg.Channels, channel); ok {
+		return role
+	}
+	if role, err := commands.ParseRole(rolesCfg.Default); err == nil {
+		return role
+	}
+	return commands.RoleMember
+}
+
+// resolveRoleSubject maps a /role target to a stored subject. Targets with a
+// known channel prefix or matching a known identity are kept as-is; other
+// user IDs, including ones that contain a colon such as Matrix's
+// "alice:matrix.org", are scoped to the invoking channel.
+func (s *Server) resolveRoleSubject(ctx context.Context, channel, target string) string {
+	target = strings.TrimSpace(target)
+	if target == "" {
+		return target
+	}
+	if prefix, sender, err := access.ParseSubject(target, ""); err == nil {
+		return prefix + ":" + sender
+	}
+	if s.identityStore != nil {
+		if ident, err := s.identityStore.Get(ctx, target); err == nil && ident != nil {
+			return ident.CanonicalID
+		}
+	}
+	prefix, sender, err := access.ParseSubject(target, channel)
+	if err != nil {
+		return target
+	}
+	return prefix + ":" + sender
+}
+
+func lookupRole(roles map[string]string, key string) (commands.Role, bool) {
+	for candidate, value := range roles {
+		if !strings.EqualFold(strings.TrimSpace(candidate), key) {
+			continue
+		}
+		role, err := commands.ParseRole(value)
+		return role, err == nil
+	}
+	return "", false
+}
@@ -7,7 +7,9 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/haasonsaas/nexus/internal/access"
 	"github.com/haasonsaas/nexus/internal/commands"
+	"github.com/haasonsaas/nexus/internal/messages"
 	"github.com/haasonsaas/nexus/pkg/models"
 )
 
@@ -29,14 +31,14 @@ func (s *Server) maybeHandleCommand(ctx context.Context, session *models.Session
 		return false
 	}
 
-	if !s.commandAllowlistAllows(msg) {
+	if !s.commandAllowlistAllows(ctx, msg) {
 		return true
 	}
 
-	inv := s.buildCommandInvocation(session, msg, detection.Primary)
+	inv := s.buildCommandInvocation(ctx, session, msg, detection.Primary)
 	result, err := s.commandRegistry.Execute(ctx, inv)
 	if err != nil {
-		s.sendImmediateReply(ctx, session, msg, "Command failed: "+err.Error())
+		s.sendImmediateReply(ctx, session, msg, s.systemMessage(ctx, msg, messages.CommandFailed, messages.Data{"Error": err.Error()}))
 		return true
 	}
 	if result == nil {
@@ -49,16 +51,17 @@ func (s *Server) maybeHandleCommand(ctx context.Context, session *models.Session
 	if !result.Suppress && strings.TrimSpace(result.Text) != "" {
 		s.sendImmediateReply(ctx, session, msg, result.Text)
 	}
-	s.applyCommandActions(ctx, session, result)
+	s.applyCommandActions(ctx, session, msg, result)
 	return true
 }
 
-func (s *Server) buildCommandInvocation(session *models.Session, msg *models.Message, parsed *commands.ParsedCommand) *commands.Invocation {
+func (s *Server) buildCommandInvocation(ctx context.Context, session *models.Session, msg *models.Message, parsed *commands.ParsedCommand) *commands.Invocation {
 	rawText := strings.TrimSpace(msg.Content)
 	if parsed != nil && parsed.StartPos >= 0 && parsed.EndPos > parsed.StartPos && parsed.EndPos <= len(msg.Content) {
 		rawText = strings.TrimSpace(msg.Content[parsed.StartPos:parsed.EndPos])
 	}
 
+	role := s.resolveCommandRole(ctx, msg)
 	inv := &commands.Invocation{
 		Name:       parsed.Name,
 		Args:       parsed.Args,
@@ -66,13 +69,15 @@ func (s *Server) buildCommandInvocation(session *models.Session, msg *models.Mes
 		SessionKey: session.Key,
 		ChannelID:  session.ChannelID,
 		UserID:     extractSenderID(msg),
-		IsAdmin:    isAdminMessage(msg),
+		IsAdmin:    role == commands.RoleAdmin,
+		Role:       role,
 		Context: map[string]any{
 			"session_id":     session.ID,
 			"agent_id":       session.AgentID,
 			"channel":        string(session.Channel),
 			"channel_id":     session.ChannelID,
 			"user_id":        extractSenderID(msg),
+			"role":           string(role),
 			"has_active_run": s.hasActiveRun(session.ID),
 		},
 	}
@@ -83,10 +88,14 @@ func (s *Server) buildCommandInvocation(session *models.Session, msg *models.Mes
 	if s.defaultModel != "" {
 		inv.Context["default_model"] = s.defaultModel
 	}
+	if budget := sessionThinkingBudget(session); budget > 0 {
+		inv.Context["thinking_enabled"] = true
+		inv.Context["thinking_budget"] = budget
+	}
 	return inv
 }
 
-func (s *Server) applyCommandActions(ctx context.Context, session *models.Session, result *commands.Result) {
+func (s *Server) applyCommandActions(ctx context.Context, session *models.Session, msg *models.Message, result *commands.Result) {
 	if result == nil || result.Data == nil || session == nil {
 		return
 	}
@@ -102,6 +111,8 @@ func (s *Server) applyCommandActions(ctx context.Context, session *models.Sessio
 		if err := s.sessions.Delete(ctx, session.ID); err != nil {
 			s.logger.Error("failed to reset session", "error", err)
 		}
+		s.releaseSessionArtifacts(ctx, session.ID)
+		s.publishSessionReset(ctx, session.ID, session.Key)
 		newSession, err := s.sessions.GetOrCreate(ctx, session.Key, session.AgentID, session.Channel, session.ChannelID)
 		if err != nil {
 			s.logger.Error("failed to create new session", "error", err)
@@ -119,6 +130,67 @@ func (s *Server) applyCommandActions(ctx context.Context, session *models.Sessio
 				}
 			}
 		}
+	case "undo":
+		s.applyUndoCommand(ctx, session, msg)
+	case "branch":
+		op, _ := result.Data["op"].(string)
+		value, _ := result.Data["value"].(string)
+		s.applyBranchCommand(ctx, session, msg, op, value)
+	case "export":
+		format, _ := result.Data["format"].(string)
+		s.exportSession(ctx, session, msg, format)
+	case "heartbeat":
+		op, _ := result.Data["op"].(string)
+		value, _ := result.Data["value"].(string)
+		s.applyHeartbeatCommand(ctx, session, msg, op, value)
+	case "quiet":
+		op, _ := result.Data["op"].(string)
+		value, _ := result.Data["value"].(string)
+		s.applyQuietCommand(ctx, session, msg, op, value)
+	case "catchup":
+		window, _ := result.Data["window"].(string)
+		s.applyCatchupCommand(ctx, session, msg, window)
+	case "language":
+		op, _ := result.Data["op"].(string)
+		value, _ := result.Data["value"].(string)
+		s.applyLanguageCommand(ctx, session, msg, op, value)
+	case "schedule_message":
+		kind, _ := result.Data["kind"].(string)
+		text, _ := result.Data["text"].(string)
+		when, _ := result.Data["when"].(string)
+		s.applyScheduleCommand(ctx, session, msg, kind, text, when)
+	case "list_scheduled":
+		s.applyListScheduledCommand(ctx, session, msg)
+	case "cancel_scheduled":
+		id, _ := result.Data["id"].(string)
+		s.applyCancelScheduledCommand(ctx, session, msg, id)
+	case "broadcast":
+		list, _ := result.Data["list"].(string)
+		text, _ := result.Data["text"].(string)
+		instructions, _ := result.Data["instructions"].(string)
+		s.applyBroadcastCommand(ctx, session, msg, list, text, instructions)
+	case "broadcast_retry":
+		s.applyBroadcastRetryCommand(ctx, session, msg)
+	case "broadcast_lists":
+		s.applyBroadcastListsCommand(ctx, session, msg)
+	case "memory_review":
+		op, _ := result.Data["op"].(string)
+		id, _ := result.Data["id"].(string)
+		s.applyMemoryReviewCommand(ctx, session, msg, op, id)
+	case "draft":
+		op, _ := result.Data["op"].(string)
+		id, _ := result.Data["id"].(string)
+		text, _ := result.Data["text"].(string)
+		s.applyDraftCommand(ctx, session, msg, op, id, text)
+	case "set_thinking":
+		budget := 0
+		if enabled, _ := result.Data["enabled"].(bool); enabled {
+			budget, _ = result.Data["budget"].(int)
+		}
+		setSessionThinkingBudget(session, budget)
+		if err := s.sessions.Update(ctx, session); err != nil {
+			s.logger.Error("failed to update session thinking budget", "error", err)
+		}
 	case "set_model":
 		model, ok := result.Data["model"].(string)
 		if !ok {
@@ -176,6 +248,7 @@ func isAdminMessage(msg *models.Message) bool {
 
 type activeRun struct {
 	token     string
+	runID     string
 	cancel    context.CancelFunc
 	startedAt time.Time
 }
@@ -211,6 +284,48 @@ func (s *Server) finishActiveRun(sessionID, token string) {
 	}
 }
 
+// setActiveRunID records the runtime's run ID for a registered run, so it can
+// be killed by run ID.
+func (s *Server) setActiveRunID(sessionID, token, runID string) {
+	if s == nil || sessionID == "" || token == "" {
+		return
+	}
+	s.activeRunsMu.Lock()
+	defer s.activeRunsMu.Unlock()
+	if current, ok := s.activeRuns[sessionID]; ok && current.token == token {
+		current.runID = runID
+		s.activeRuns[sessionID] = current
+	}
+}
+
+// killActiveRuns cancels the active run whose run ID or session ID is id, or
+// every active run when all is set, and returns the sessions that were
+// stopped.
+func (s *Server) killActiveRuns(id string, all bool) []string {
+	if s == nil {
+		return nil
+	}
+	s.activeRunsMu.Lock()
+	var killed []activeRun
+	var sessionIDs []string
+	for sessionID, run := range s.activeRuns {
+		if !all && sessionID != id && (run.runID == "" || run.runID != id) {
+			continue
+		}
+		delete(s.activeRuns, sessionID)
+		killed = append(killed, run)
+		sessionIDs = append(sessionIDs, sessionID)
+	}
+	s.activeRunsMu.Unlock()
+	for _, run := range killed {
+		if run.cancel != nil {
+			run.cancel()
+		}
+	}
+	sort.Strings(sessionIDs)
+	return sessionIDs
+}
+
 func (s *Server) cancelActiveRun(sessionID string) bool {
 	if s == nil || sessionID == "" {
 		return false
@@ -309,10 +424,10 @@ func (s *Server) maybeHandleInlineCommands(ctx context.Context, session *models.
 	for _, cmd := range inline {
 		inlineCmd := cmd
 		inlineCmd.Args = ""
-		inv := s.buildCommandInvocation(session, msg, &inlineCmd)
+		inv := s.buildCommandInvocation(ctx, session, msg, &inlineCmd)
 		result, err := s.commandRegistry.Execute(ctx, inv)
 		if err != nil {
-			s.sendImmediateReply(ctx, session, msg, "Command failed: "+err.Error())
+			s.sendImmediateReply(ctx, session, msg, s.systemMessage(ctx, msg, messages.CommandFailed, messages.Data{"Error": err.Error()}))
 			continue
 		}
 		if result == nil {
@@ -325,7 +440,7 @@ func (s *Server) maybeHandleInlineCommands(ctx context.Context, session *models.
 		if !result.Suppress && strings.TrimSpace(result.Text) != "" {
 			s.sendImmediateReply(ctx, session, msg, result.Text)
 		}
-		s.applyCommandActions(ctx, session, result)
+		s.applyCommandActions(ctx, session, msg, result)
 	}
 
 	msg.Content = stripInlineCommands(msg.Content, inline)
@@ -342,14 +457,22 @@ func (s *Server) commandsEnabled() bool {
 	return *s.config.Commands.Enabled
 }
 
-func (s *Server) commandAllowlistAllows(msg *models.Message) bool {
+// commandAllowlistAllows checks commands.allow_from together with runtime
+// grants made with /allow. Commands are open while both are empty, and
+// admins always pass so granting access cannot lock them out.
+func (s *Server) commandAllowlistAllows(ctx context.Context, msg *models.Message) bool {
 	if s == nil || s.config == nil {
 		return true
 	}
-	if len(s.config.Commands.AllowFrom) == 0 {
+	senderID := extractSenderID(msg)
+	granted, listed := s.accessGranted(ctx, access.ListCommands, msg.Channel, senderID)
+	if len(s.config.Commands.AllowFrom) == 0 && !listed {
+		return true
+	}
+	if granted || allowlistMatches(s.config.Commands.AllowFrom, msg.Channel, senderID) {
 		return true
 	}
-	return allowlistMatches(s.config.Commands.AllowFrom, msg.Channel, extractSenderID(msg))
+	return s.resolveCommandRole(ctx, msg) == commands.RoleAdmin
 }
 
 func (s *Server) inlineAllowlistAllows(msg *models.Message) bool {
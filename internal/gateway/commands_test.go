@@ -4,6 +4,7 @@ import (
 	"context"
 	"io"
 	"log/slog"
+	"path/filepath"
 	"strings"
 	"sync"
 	"testing"
@@ -528,3 +529,113 @@ func TestHandleMessageInlineCommandIgnoredWithoutAllowlist(t *testing.T) {
 func boolPtr(value bool) *bool {
 	return &value
 }
+
+func TestHandleMessageCommandRoles(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	cfg := &config.Config{
+		Commands: config.CommandsConfig{
+			Roles: config.CommandRolesConfig{
+				Default:     "member",
+				Channels:    map[string]string{"telegram": "guest"},
+				Assignments: map[string]string{"telegram:1": "admin"},
+				StorePath:   filepath.Join(t.TempDir(), "roles.json"),
+			},
+		},
+	}
+	server, err := NewServer(cfg, logger)
+	if err != nil {
+		t.Fatalf("NewServer() error = %v", err)
+	}
+
+	store := sessions.NewMemoryStore()
+	server.sessions = store
+	server.runtime = agent.NewRuntime(&countingProvider{}, store)
+
+	adapter := &recordingAdapter{}
+	registry := channels.NewRegistry()
+	registry.Register(adapter)
+	server.channels = registry
+
+	send := func(userID int64, content string) string {
+		adapter.mu.Lock()
+		adapter.messages = nil
+		adapter.mu.Unlock()
+		server.handleMessage(context.Background(), &models.Message{
+			ID:        "cmd_" + content,
+			Channel:   models.ChannelTelegram,
+			ChannelID: "1",
+			Direction: models.DirectionInbound,
+			Role:      models.RoleUser,
+			Content:   content,
+			Metadata: map[string]any{
+				"chat_id": userID,
+				"user_id": userID,
+			},
+		})
+		adapter.mu.Lock()
+		defer adapter.mu.Unlock()
+		if len(adapter.messages) != 1 {
+			t.Fatalf("%q: expected 1 reply, got %d", content, len(adapter.messages))
+		}
+		return adapter.messages[0].Content
+	}
+
+	if reply := send(2, "/model gpt-4"); !strings.Contains(reply, "requires the member role") {
+		t.Fatalf("expected guest to be denied /model, got %q", reply)
+	}
+	if reply := send(2, "/role grant @3 admin"); !strings.Contains(reply, "requires the admin role") {
+		t.Fatalf("expected guest to be denied /role grant, got %q", reply)
+	}
+	if reply := send(1, "/role grant @2 member"); reply != "Granted member to telegram:2" {
+		t.Fatalf("unexpected grant reply %q", reply)
+	}
+	if reply := send(2, "/role"); reply != "Your role: member" {
+		t.Fatalf("expected granted role, got %q", reply)
+	}
+}
+
+func TestHandleMessageCommandExportSendsTranscript(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	cfg := &config.Config{Session: config.SessionConfig{DefaultAgentID: "agent-test"}}
+	server, err := NewServer(cfg, logger)
+	if err != nil {
+		t.Fatalf("NewServer() error = %v", err)
+	}
+
+	store := sessions.NewMemoryStore()
+	server.sessions = store
+	server.runtime = agent.NewRuntime(&countingProvider{}, store)
+
+	adapter := &recordingAdapter{}
+	registry := channels.NewRegistry()
+	registry.Register(adapter)
+	server.channels = registry
+
+	newMsg := func(id, content string) *models.Message {
+		return &models.Message{
+			ID:        id,
+			Channel:   models.ChannelTelegram,
+			ChannelID: id,
+			Direction: models.DirectionInbound,
+			Role:      models.RoleUser,
+			Content:   content,
+			Metadata:  map[string]any{"chat_id": int64(7)},
+		}
+	}
+	server.handleMessage(context.Background(), newMsg("m1", "hello there"))
+	server.handleMessage(context.Background(), newMsg("m2", "/export html"))
+
+	adapter.mu.Lock()
+	defer adapter.mu.Unlock()
+	last := adapter.messages[len(adapter.messages)-1]
+	if len(last.Attachments) != 1 {
+		t.Fatalf("expected transcript attachment, got %+v", last)
+	}
+	att := last.Attachments[0]
+	if att.Type != "document" || att.MimeType != "text/html" || !strings.HasSuffix(att.Filename, ".html") {
+		t.Fatalf("unexpected attachment %+v", att)
+	}
+	if !strings.Contains(last.Content, "Conversation export") {
+		t.Fatalf("unexpected caption %q", last.Content)
+	}
+}
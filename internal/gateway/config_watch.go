@@ -0,0 +1,63 @@
+package gateway
+
+import (
+	"context"
+	"path/filepath"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// configReloadDebounce coalesces the burst of events editors emit when
+// saving a file.
+const configReloadDebounce = 500 * time.Millisecond
+
+// watchConfigFile calls reload with path, debounced, whenever the file is
+// written or replaced, until ctx is done.
+func (s *Server) watchConfigFile(ctx context.Context, worker, path string, reload func(path string)) error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return err
+	}
+	// Watch the directory so editors that replace the file by rename are seen.
+	if err := watcher.Add(filepath.Dir(path)); err != nil {
+		_ = watcher.Close()
+		return err
+	}
+
+	s.goSupervised(ctx, worker, func(ctx context.Context) {
+		var timer *time.Timer
+		defer func() {
+			if timer != nil {
+				timer.Stop()
+			}
+		}()
+		for {
+			select {
+			case <-ctx.Done():
+				_ = watcher.Close()
+				return
+			case event, ok := <-watcher.Events:
+				if !ok {
+					return
+				}
+				if filepath.Clean(event.Name) != filepath.Clean(path) {
+					continue
+				}
+				if event.Op&(fsnotify.Create|fsnotify.Write|fsnotify.Rename) == 0 {
+					continue
+				}
+				if timer != nil {
+					timer.Stop()
+				}
+				timer = time.AfterFunc(configReloadDebounce, func() { reload(path) })
+			case err, ok := <-watcher.Errors:
+				if !ok {
+					return
+				}
+				s.logger.Warn("config watch error", "worker", worker, "error", err)
+			}
+		}
+	})
+	return nil
+}
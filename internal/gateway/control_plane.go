@@ -14,6 +14,7 @@ import (
 	"github.com/haasonsaas/nexus/internal/agent"
 	"github.com/haasonsaas/nexus/internal/config"
 	"github.com/haasonsaas/nexus/internal/controlplane"
+	"github.com/haasonsaas/nexus/internal/eventbus"
 	"github.com/haasonsaas/nexus/internal/plugins"
 	"gopkg.in/yaml.v3"
 )
@@ -60,10 +61,24 @@ func (s *Server) ConfigSchema(ctx context.Context) ([]byte, error) {
 }
 
 // ApplyConfig applies a new raw config, validating and updating runtime options.
+// Other cluster nodes are notified so they can reload a shared config file.
 func (s *Server) ApplyConfig(ctx context.Context, raw string, baseHash string) (*controlplane.ConfigApplyResult, error) {
 	if s == nil {
 		return nil, fmt.Errorf("server unavailable")
 	}
+	result, err := s.applyConfig(ctx, raw, baseHash)
+	if err != nil {
+		return nil, err
+	}
+	if snapshot, err := s.ConfigSnapshot(ctx); err == nil {
+		s.publishEvent(ctx, eventbus.TopicConfigChanged, configChangedEvent{Hash: snapshot.Hash})
+	}
+	return result, nil
+}
+
+// applyConfig writes raw (when non-empty) and reloads the config file without
+// notifying other nodes.
+func (s *Server) applyConfig(ctx context.Context, raw string, baseHash string) (*controlplane.ConfigApplyResult, error) {
 	path := strings.TrimSpace(s.configPath)
 	if path == "" {
 		return nil, fmt.Errorf("config path not configured (start with --config)")
@@ -165,26 +180,34 @@ func applyRuntimeConfigUpdates(ctx context.Context, s *Server, cfg *config.Confi
 
 	restartRequired := len(warnings) > 0
 
+	if cfg != nil {
+		s.setSteering(cfg.Steering)
+	}
+
 	// Update runtime options when possible.
 	if s.runtime != nil && cfg != nil {
 		elevatedTools := effectiveElevatedTools(cfg.Tools.Elevated, nil)
 		basePolicy := buildApprovalPolicy(cfg.Tools.Execution, s.toolPolicyResolver)
-		checker := agent.NewApprovalChecker(basePolicy)
-		checker.SetStore(agent.NewMemoryApprovalStore())
+		requireEmailComposeApproval(basePolicy, cfg)
+		checker := s.newApprovalChecker(basePolicy)
 		s.approvalChecker = checker
 
 		s.runtime.SetOptions(agent.RuntimeOptions{
-			MaxIterations:     cfg.Tools.Execution.MaxIterations,
-			ToolParallelism:   cfg.Tools.Execution.Parallelism,
-			ToolTimeout:       cfg.Tools.Execution.Timeout,
-			ToolMaxAttempts:   cfg.Tools.Execution.MaxAttempts,
-			ToolRetryBackoff:  cfg.Tools.Execution.RetryBackoff,
-			DisableToolEvents: cfg.Tools.Execution.DisableEvents,
-			MaxToolCalls:      cfg.Tools.Execution.MaxToolCalls,
-			RequireApproval:   cfg.Tools.Execution.RequireApproval,
-			ApprovalChecker:   checker,
-			ElevatedTools:     elevatedTools,
-			AsyncTools:        cfg.Tools.Execution.Async,
+			MaxIterations:       cfg.Tools.Execution.MaxIterations,
+			ToolParallelism:     cfg.Tools.Execution.Parallelism,
+			ToolTimeout:         cfg.Tools.Execution.Timeout,
+			ToolMaxAttempts:     cfg.Tools.Execution.MaxAttempts,
+			ToolRetryBackoff:    cfg.Tools.Execution.RetryBackoff,
+			ToolMaxRetryBackoff: cfg.Tools.Execution.Retry.MaxBackoff,
+			ToolRetryJitter:     cfg.Tools.Execution.Retry.Jitter,
+			ToolRetryOn:         toolRetryClasses(cfg.Tools.Execution.Retry.RetryOn),
+			IdempotentTools:     cfg.Tools.Execution.Retry.Idempotent,
+			DisableToolEvents:   cfg.Tools.Execution.DisableEvents,
+			MaxToolCalls:        cfg.Tools.Execution.MaxToolCalls,
+			RequireApproval:     cfg.Tools.Execution.RequireApproval,
+			ApprovalChecker:     checker,
+			ElevatedTools:       elevatedTools,
+			AsyncTools:          cfg.Tools.Execution.Async,
 			ToolResultGuard: agent.ToolResultGuard{
 				Enabled:         cfg.Tools.Execution.ResultGuard.Enabled,
 				MaxChars:        cfg.Tools.Execution.ResultGuard.MaxChars,
@@ -193,9 +216,16 @@ func applyRuntimeConfigUpdates(ctx context.Context, s *Server, cfg *config.Confi
 				RedactionText:   cfg.Tools.Execution.ResultGuard.RedactionText,
 				TruncateSuffix:  cfg.Tools.Execution.ResultGuard.TruncateSuffix,
 				SanitizeSecrets: cfg.Tools.Execution.ResultGuard.SanitizeSecrets,
+				Artifacts:       s.artifactRepo,
+			},
+			ToolSchemaValidation: agent.ToolSchemaValidation{
+				Enabled:        cfg.Tools.Execution.SchemaValidation.Enabled,
+				RepairAttempts: cfg.Tools.Execution.SchemaValidation.RepairAttempts,
+				Strict:         cfg.Tools.Execution.SchemaValidation.Strict,
 			},
-			JobStore: s.jobStore,
-			Logger:   s.logger,
+			JobStore:    s.jobStore,
+			Checkpoints: s.checkpointOptions(),
+			Logger:      s.logger,
 		})
 
 		if pruning := config.EffectiveContextPruningSettings(cfg.Session.ContextPruning); pruning != nil {
@@ -234,6 +264,7 @@ func configRestartWarnings(oldCfg *config.Config, newCfg *config.Config) []strin
 	addWarning("auth", oldCfg.Auth, newCfg.Auth)
 	addWarning("channels", oldCfg.Channels, newCfg.Channels)
 	addWarning("gateway", oldCfg.Gateway, newCfg.Gateway)
+	addWarning("gitops", oldCfg.GitOps, newCfg.GitOps)
 	addWarning("commands", oldCfg.Commands, newCfg.Commands)
 	addWarning("llm", oldCfg.LLM, newCfg.LLM)
 	addWarning("workspace", oldCfg.Workspace, newCfg.Workspace)
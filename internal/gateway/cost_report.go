@@ -0,0 +1,224 @@
+package gateway
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/haasonsaas/nexus/internal/cluster"
+	"github.com/haasonsaas/nexus/internal/config"
+	"github.com/haasonsaas/nexus/internal/costreport"
+	"github.com/haasonsaas/nexus/internal/cron"
+	"github.com/haasonsaas/nexus/internal/delivery"
+	"github.com/haasonsaas/nexus/internal/observability"
+	statuspkg "github.com/haasonsaas/nexus/internal/status"
+	"github.com/haasonsaas/nexus/pkg/models"
+)
+
+// costReportTick is how often the cost report worker checks its schedule.
+const costReportTick = time.Minute
+
+// startCostReport posts the cost report on its schedule from the cluster
+// leader.
+func (s *Server) startCostReport(ctx context.Context) {
+	if s == nil || s.config == nil || !s.config.Observability.CostReport.Enabled {
+		return
+	}
+	cfg := s.config.Observability.CostReport
+	loc, err := s.costReportLocation()
+	if err != nil {
+		s.logger.Warn("cost report: disabled (invalid timezone)", "timezone", cfg.Timezone, "error", err)
+		return
+	}
+	period, err := costreport.ParsePeriod(cfg.Period)
+	if err != nil {
+		s.logger.Warn("cost report: disabled", "error", err)
+		return
+	}
+	schedule, err := cron.NewSchedule(config.CronScheduleConfig{Cron: cfg.Schedule, Timezone: loc.String()})
+	if err != nil {
+		s.logger.Warn("cost report: disabled (invalid schedule)", "schedule", cfg.Schedule, "error", err)
+		return
+	}
+
+	s.goSupervised(ctx, "worker:cost_report", func(ctx context.Context) {
+		ticker := time.NewTicker(costReportTick)
+		defer ticker.Stop()
+
+		next := nextAttentionDigest(schedule, time.Now())
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case now := <-ticker.C:
+				if next.IsZero() || now.Before(next) {
+					continue
+				}
+				next = nextAttentionDigest(schedule, now)
+				if !s.isClusterLeader(cluster.LeaseCostReport) {
+					continue
+				}
+				if err := s.sendCostReport(ctx, period, now.In(loc)); err != nil {
+					s.logger.Warn("cost report: send failed", "channel", cfg.Channel, "error", err)
+				}
+			}
+		}
+	})
+}
+
+func (s *Server) costReportLocation() (*time.Location, error) {
+	timezone := strings.TrimSpace(s.config.Observability.CostReport.Timezone)
+	if timezone == "" {
+		timezone = strings.TrimSpace(s.config.User.Timezone)
+	}
+	if timezone == "" {
+		return time.UTC, nil
+	}
+	return time.LoadLocation(timezone)
+}
+
+// sendCostReport posts the report for the last full period before now to
+// the admin conversation, with the full report attached as HTML.
+func (s *Server) sendCostReport(ctx context.Context, period costreport.Period, now time.Time) error {
+	cfg := s.config.Observability.CostReport
+	report, err := s.buildCostReport(ctx, period, now)
+	if err != nil {
+		return err
+	}
+	page, err := report.HTML()
+	if err != nil {
+		return fmt.Errorf("render cost report: %w", err)
+	}
+	attachment := models.Attachment{
+		ID:       uuid.NewString(),
+		Type:     "document",
+		Filename: report.Filename(),
+		MimeType: "text/html",
+		Size:     int64(len(page)),
+		URL:      "data:text/html;base64," + base64.StdEncoding.EncodeToString(page),
+	}
+	kind := delivery.KindDigest
+	if report.OverPlan() {
+		kind = delivery.KindAlert
+	}
+	_, err = s.sendProactiveWithAttachments(ctx, kind, models.ChannelType(cfg.Channel), cfg.PeerID,
+		report.Text(cfg.TopN), []models.Attachment{attachment})
+	if err != nil {
+		return err
+	}
+	s.logger.Info("cost report sent",
+		"period", report.Period,
+		"start", report.Start,
+		"cost_usd", report.Total.CostUSD,
+		"over_plan", report.OverPlan(),
+	)
+	return nil
+}
+
+// buildCostReport totals the LLM calls recorded in the period. Persisted
+// events are used when the event store is enabled, since the in-memory
+// store only keeps the most recent events.
+func (s *Server) buildCostReport(ctx context.Context, period costreport.Period, now time.Time) (*costreport.Report, error) {
+	cfg := s.config.Observability.CostReport
+	start, end := period.Window(now)
+	events, err := s.costReportEvents(ctx, start, end)
+	if err != nil {
+		return nil, fmt.Errorf("load usage events: %w", err)
+	}
+
+	users := make(map[string]string)
+	agents := make(map[string]string)
+	records := make([]costreport.Record, 0, len(events))
+	for _, event := range events {
+		provider, _ := event.Data["provider"].(string) //nolint:errcheck // missing is empty
+		model, _ := event.Data["model"].(string)       //nolint:errcheck // missing is empty
+		input := usageEventInt(event.Data["input_tokens"])
+		output := usageEventInt(event.Data["output_tokens"])
+		pricing := statuspkg.ResolveModelCostConfig(provider, model, s.config)
+		record := costreport.Record{
+			Time:         event.Timestamp,
+			Provider:     provider,
+			Model:        model,
+			Agent:        event.AgentID,
+			InputTokens:  int64(input),
+			OutputTokens: int64(output),
+			CostUSD:      statuspkg.EstimateUsageCost(input, output, pricing),
+			Priced:       pricing != nil,
+		}
+		if event.SessionID != "" {
+			if _, ok := users[event.SessionID]; !ok {
+				users[event.SessionID], agents[event.SessionID] = s.costReportUser(ctx, event.SessionID)
+			}
+			record.User = users[event.SessionID]
+			if record.Agent == "" {
+				record.Agent = agents[event.SessionID]
+			}
+		}
+		records = append(records, record)
+	}
+	return costreport.Build(period, start, end, records, costreport.Options{
+		PlanUSD:          cfg.PlanUSD,
+		ProviderPlansUSD: cfg.ProviderPlansUSD,
+		WarnAt:           cfg.WarnAt,
+	}), nil
+}
+
+// costReportEvents returns the LLM response events in [start, end), from
+// memory and, when enabled, the persisted event store.
+func (s *Server) costReportEvents(ctx context.Context, start, end time.Time) ([]*observability.Event, error) {
+	var events []*observability.Event
+	seen := make(map[string]bool)
+	if s.eventStore != nil {
+		memory, err := s.eventStore.GetByType(observability.EventTypeLLMResponse, 0)
+		if err != nil {
+			return nil, err
+		}
+		for _, event := range memory {
+			if event.Timestamp.Before(start) || !event.Timestamp.Before(end) {
+				continue
+			}
+			seen[event.ID] = true
+			events = append(events, event)
+		}
+	}
+	if s.persistedEvents == nil || s.persistedEvents.DB() == nil {
+		return events, nil
+	}
+	persisted, err := s.persistedEvents.DB().Query(ctx, observability.EventQuery{
+		Types: []observability.EventType{observability.EventTypeLLMResponse},
+		Since: start,
+		Until: end,
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, event := range persisted {
+		if !seen[event.ID] {
+			events = append(events, event)
+		}
+	}
+	return events, nil
+}
+
+// costReportUser labels the user behind a session by their linked identity,
+// or by the conversation when none is linked. It also returns the session's
+// agent for events that do not carry one.
+func (s *Server) costReportUser(ctx context.Context, sessionID string) (user, agentID string) {
+	if s.sessions == nil {
+		return "", ""
+	}
+	session, err := s.sessions.Get(ctx, sessionID)
+	if err != nil || session == nil || session.Channel == "" {
+		return "", ""
+	}
+	if s.identityStore != nil && session.ChannelID != "" {
+		if ident, err := s.identityStore.ResolveByPeer(ctx, string(session.Channel), session.ChannelID); err == nil && ident != nil {
+			return ident.CanonicalID, session.AgentID
+		}
+	}
+	return fmt.Sprintf("%s:%s", session.Channel, session.ChannelID), session.AgentID
+}
@@ -0,0 +1,65 @@
+package gateway
+
+import (
+	"context"
+	"time"
+
+	"github.com/haasonsaas/nexus/internal/cluster"
+	"github.com/haasonsaas/nexus/internal/credentials"
+	"github.com/haasonsaas/nexus/internal/delivery"
+	"github.com/haasonsaas/nexus/internal/infra"
+	"github.com/haasonsaas/nexus/pkg/models"
+)
+
+// startCredentialMonitor starts the background worker that validates
+// provider API keys and channel tokens. Results are reported through the
+// "credentials" health check shown in status, and state changes are sent to
+// the configured admin conversation.
+func (s *Server) startCredentialMonitor(ctx context.Context) {
+	if s == nil || s.config == nil || !s.config.Security.Credentials.Enabled {
+		return
+	}
+	cfg := s.config.Security.Credentials
+	interval := cfg.Interval
+	if interval <= 0 {
+		interval = 6 * time.Hour
+	}
+
+	targets := credentials.Targets(s.config)
+	if len(targets) == 0 {
+		s.logger.Debug("credential monitor has no credentials to check")
+		return
+	}
+	monitor := credentials.NewMonitor(cfg, targets, nil, s.logger)
+	if cfg.Alert.Channel != "" && cfg.Alert.PeerID != "" {
+		channel := models.ChannelType(cfg.Alert.Channel)
+		monitor.SetMessages(s.messageCatalog, s.channelLocale(channel))
+		monitor.SetAlert(func(ctx context.Context, text string) error {
+			// Every node checks its own credentials, but only one alerts.
+			if !s.isClusterLeader(cluster.LeaseCredentialAlerts) {
+				return nil
+			}
+			_, err := s.sendProactive(ctx, delivery.KindAlert, channel, cfg.Alert.PeerID, text)
+			return err
+		})
+	}
+	infra.RegisterHealthCheck(infra.HealthCheckConfig{
+		Name:    "credentials",
+		Checker: monitor.HealthCheck,
+	})
+
+	s.goSupervised(ctx, "worker:credential_monitor", func(ctx context.Context) {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		monitor.Check(ctx)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				monitor.Check(ctx)
+			}
+		}
+	})
+}
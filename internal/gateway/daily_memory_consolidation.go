@@ -0,0 +1,114 @@
+package gateway
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/haasonsaas/nexus/internal/agent"
+	"github.com/haasonsaas/nexus/internal/cluster"
+	"github.com/haasonsaas/nexus/internal/config"
+	"github.com/haasonsaas/nexus/internal/cron"
+	"github.com/haasonsaas/nexus/internal/hooks"
+	"github.com/haasonsaas/nexus/internal/memory/consolidate"
+)
+
+// dailyConsolidationTimeout bounds one summary request.
+const dailyConsolidationTimeout = 2 * time.Minute
+
+// startDailyMemoryConsolidation launches the worker that folds old daily
+// memory files into the workspace memory file on the configured schedule.
+func (s *Server) startDailyMemoryConsolidation(ctx context.Context) {
+	cfg := s.config.Session.Memory.Consolidation
+	if !cfg.Enabled {
+		return
+	}
+	timezone := strings.TrimSpace(cfg.Timezone)
+	if timezone == "" {
+		timezone = strings.TrimSpace(s.config.User.Timezone)
+	}
+	schedule, err := cron.NewSchedule(config.CronScheduleConfig{Cron: cfg.Schedule, Timezone: timezone})
+	if err != nil {
+		s.logger.Warn("memory consolidation: daily files disabled (invalid schedule)", "schedule", cfg.Schedule, "error", err)
+		return
+	}
+	consolidator := consolidate.New(s.config).WithHistory(newWorkspaceHistory(s.config))
+
+	s.goSupervised(ctx, "worker:daily_memory_consolidation", func(ctx context.Context) {
+		ticker := time.NewTicker(catchupTick)
+		defer ticker.Stop()
+
+		next := nextAttentionDigest(schedule, time.Now())
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case now := <-ticker.C:
+				if next.IsZero() || now.Before(next) {
+					continue
+				}
+				next = nextAttentionDigest(schedule, now)
+				if !s.isClusterLeader(cluster.LeaseMemoryConsolidation) {
+					continue
+				}
+				s.runDailyMemoryConsolidation(ctx, consolidator, now)
+			}
+		}
+	})
+}
+
+// runDailyMemoryConsolidation consolidates the due daily files, logging the
+// result and firing the memory.consolidated hook event.
+func (s *Server) runDailyMemoryConsolidation(ctx context.Context, consolidator *consolidate.Consolidator, now time.Time) {
+	if s.llmProvider == nil {
+		s.logger.Warn("memory consolidation: daily files skipped (llm provider unavailable)")
+		return
+	}
+	var indexer consolidate.Indexer
+	if s.vectorMemory != nil {
+		indexer = s.vectorMemory
+	}
+	report, err := consolidator.Run(ctx, now, s.dailyMemorySummarizer(), indexer)
+	if report == nil {
+		if err != nil {
+			s.logger.Warn("memory consolidation: daily files failed", "error", err)
+		}
+		return
+	}
+	if err != nil {
+		s.logger.Warn("memory consolidation: daily files consolidated with errors", "dates", report.Dates, "error", err)
+	} else {
+		s.logger.Info("memory consolidation: daily files consolidated",
+			"dates", report.Dates,
+			"topics", report.Topics,
+			"lines", report.Lines,
+			"indexed", report.Indexed,
+			"remaining", report.Remaining)
+	}
+	event := hooks.NewEvent(hooks.EventMemoryConsolidated, report.MemoryFile).
+		WithContext("dates", report.Dates).
+		WithContext("topics", report.Topics).
+		WithContext("lines", report.Lines).
+		WithContext("indexed", report.Indexed).
+		WithContext("remaining", report.Remaining).
+		WithContext("archive_dir", report.ArchiveDir)
+	hooks.TriggerAsync(context.WithoutCancel(ctx), event)
+}
+
+// dailyMemorySummarizer asks session.memory.consolidation.model, or the
+// default model, for the consolidated topics, through the batch queue when
+// session.memory.consolidation.batch is set.
+func (s *Server) dailyMemorySummarizer() consolidate.Summarizer {
+	cfg := s.config.Session.Memory.Consolidation
+	return func(ctx context.Context, system, prompt string) (string, error) {
+		provider, model, timeout := s.backgroundProvider(cfg.Batch, cfg.Model, dailyConsolidationTimeout)
+		ctx, cancel := context.WithTimeout(ctx, timeout)
+		defer cancel()
+		return collectCompletion(ctx, provider, &agent.CompletionRequest{
+			Model:     model,
+			System:    system,
+			Messages:  []agent.CompletionMessage{{Role: "user", Content: prompt}},
+			MaxTokens: cfg.MaxOutputTokens,
+		})
+	}
+}
@@ -0,0 +1,81 @@
+package gateway
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/haasonsaas/nexus/internal/config"
+	"github.com/haasonsaas/nexus/internal/delivery"
+	"github.com/haasonsaas/nexus/pkg/models"
+)
+
+// deliveryRouter returns the router for proactive messages, creating it on
+// first use so it sees the registered channel adapters.
+func (s *Server) deliveryRouter() *delivery.Router {
+	s.deliveryOnce.Do(func() {
+		var cfg config.DeliveryConfig
+		if s.config != nil {
+			cfg = s.config.Delivery
+		}
+		s.delivery = delivery.NewRouter(cfg, delivery.Options{
+			Identities: s.identityStore,
+			Channels:   s.channels,
+			Presence:   s.presence,
+			Timezone:   s.userTimezone,
+			Logger:     s.logger,
+		})
+	})
+	return s.delivery
+}
+
+// sendProactive sends content to the user behind channel/peerID, on the
+// channel chosen by the delivery settings for kind.
+func (s *Server) sendProactive(ctx context.Context, kind string, channel models.ChannelType, peerID, content string) (*delivery.Result, error) {
+	return s.sendProactiveWithAttachments(ctx, kind, channel, peerID, content, nil)
+}
+
+// sendProactiveWithAttachments is sendProactive with files attached.
+func (s *Server) sendProactiveWithAttachments(ctx context.Context, kind string, channel models.ChannelType, peerID, content string, attachments []models.Attachment) (*delivery.Result, error) {
+	msg := &models.Message{
+		ID:          uuid.NewString(),
+		Channel:     channel,
+		ChannelID:   peerID,
+		Direction:   models.DirectionOutbound,
+		Role:        models.RoleAssistant,
+		Content:     content,
+		Attachments: attachments,
+		CreatedAt:   time.Now(),
+		Metadata:    map[string]any{},
+	}
+	if kind != "" {
+		msg.Metadata["type"] = kind
+	}
+	return s.deliveryRouter().Deliver(ctx, msg, kind)
+}
+
+// recordPresence notes where the sender of a direct message can be reached.
+func (s *Server) recordPresence(msg *models.Message, conversationID string) {
+	if s.presence == nil || isHeartbeatMessage(msg) || conversationTypeForMessage(msg) != "dm" {
+		return
+	}
+	sender := extractSenderID(msg)
+	if sender == "" {
+		sender = conversationID
+	}
+	metadata := s.buildReplyMetadata(msg)
+	// Proactive messages start a new message rather than replying in place.
+	delete(metadata, "reply_to_message_id")
+	delete(metadata, "slack_thread_ts")
+	delete(metadata, "message_thread_id")
+	if len(metadata) == 0 {
+		metadata = delivery.RoutingMetadata(msg.Channel, conversationID)
+	}
+	s.presence.Record(sender, delivery.Address{
+		Channel:  msg.Channel,
+		PeerID:   conversationID,
+		Metadata: metadata,
+		SeenAt:   time.Now(),
+	})
+}
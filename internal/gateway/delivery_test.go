@@ -0,0 +1,119 @@
+package gateway
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/haasonsaas/nexus/internal/channels"
+	"github.com/haasonsaas/nexus/internal/config"
+	"github.com/haasonsaas/nexus/internal/delivery"
+	"github.com/haasonsaas/nexus/internal/identity"
+	"github.com/haasonsaas/nexus/pkg/models"
+)
+
+// slackOutbound records messages sent to Slack.
+type slackOutbound struct {
+	mu   sync.Mutex
+	sent []*models.Message
+}
+
+func (a *slackOutbound) Type() models.ChannelType { return models.ChannelSlack }
+
+func (a *slackOutbound) Send(ctx context.Context, msg *models.Message) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.sent = append(a.sent, msg)
+	return nil
+}
+
+func newDeliveryServer(t *testing.T, cfg config.DeliveryConfig) (*Server, *recordingAdapter, *slackOutbound) {
+	t.Helper()
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	server, err := NewServer(&config.Config{Delivery: cfg}, logger)
+	if err != nil {
+		t.Fatalf("NewServer() error = %v", err)
+	}
+	identities := identity.NewMemoryStore()
+	if err := identities.Create(context.Background(), &identity.Identity{
+		CanonicalID: "alice",
+		LinkedPeers: []string{"telegram:100", "slack:U1"},
+	}); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	server.identityStore = identities
+	telegram := &recordingAdapter{}
+	slack := &slackOutbound{}
+	registry := channels.NewRegistry()
+	registry.Register(telegram)
+	registry.Register(slack)
+	server.channels = registry
+	return server, telegram, slack
+}
+
+func TestSendProactiveFollowsRecentChannel(t *testing.T) {
+	fallback := true
+	server, telegram, slack := newDeliveryServer(t, config.DeliveryConfig{
+		Enabled:  true,
+		Strategy: "recent",
+		Fallback: &fallback,
+	})
+
+	server.recordPresence(&models.Message{
+		Channel:  models.ChannelSlack,
+		Content:  "hi",
+		Metadata: map[string]any{"slack_channel": "D42", "slack_ts": "1.2", "sender_id": "U1"},
+	}, "D42")
+
+	result, err := server.sendProactive(context.Background(), delivery.KindDigest, models.ChannelTelegram, "100", "2 open items")
+	if err != nil {
+		t.Fatalf("sendProactive() error = %v", err)
+	}
+	if len(telegram.messages) != 0 || len(slack.sent) != 1 {
+		t.Fatalf("expected the digest on Slack, got telegram=%d slack=%d", len(telegram.messages), len(slack.sent))
+	}
+	sent := slack.sent[0]
+	if result.Message != sent || sent.Metadata["slack_channel"] != "D42" || sent.Metadata["type"] != delivery.KindDigest {
+		t.Fatalf("unexpected delivery %+v", sent.Metadata)
+	}
+	if _, threaded := sent.Metadata["slack_thread_ts"]; threaded {
+		t.Fatal("expected a new message, not a thread reply")
+	}
+}
+
+func TestSendProactiveDisabledKeepsAddress(t *testing.T) {
+	server, telegram, slack := newDeliveryServer(t, config.DeliveryConfig{})
+	server.recordPresence(&models.Message{
+		Channel:  models.ChannelSlack,
+		Metadata: map[string]any{"slack_channel": "D42", "sender_id": "U1"},
+	}, "D42")
+
+	if err := server.SendProactiveMessage(context.Background(), models.ChannelTelegram, "100", "ping"); err != nil {
+		t.Fatalf("SendProactiveMessage() error = %v", err)
+	}
+	if len(telegram.messages) != 1 || len(slack.sent) != 0 {
+		t.Fatalf("expected delivery to the addressed chat, got telegram=%d slack=%d", len(telegram.messages), len(slack.sent))
+	}
+	if got := telegram.messages[0].Metadata["chat_id"]; got != "100" {
+		t.Fatalf("expected chat_id routing metadata, got %v", got)
+	}
+
+	err := server.SendProactiveMessage(context.Background(), models.ChannelDiscord, "D1", "ping")
+	if err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Fatalf("expected a missing channel error, got %v", err)
+	}
+}
+
+func TestRecordPresenceSkipsGroups(t *testing.T) {
+	server, _, _ := newDeliveryServer(t, config.DeliveryConfig{})
+	server.recordPresence(&models.Message{
+		Channel:  models.ChannelSlack,
+		Metadata: map[string]any{"slack_channel": "C1", "sender_id": "U1"},
+	}, "C1")
+	if _, ok := server.presence.Lookup(models.ChannelSlack, "U1"); ok {
+		t.Fatal("expected group messages not to count as presence")
+	}
+}
@@ -0,0 +1,177 @@
+package gateway
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/pprof"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/haasonsaas/nexus/internal/auth"
+)
+
+// DiagnosticsRuntimePath serves runtime stats on the diagnostics port.
+const DiagnosticsRuntimePath = "/debug/runtime"
+
+// startDiagnosticsServer serves pprof profiles and runtime stats on
+// server.diagnostics, separate from the public HTTP port.
+func (s *Server) startDiagnosticsServer() error {
+	if s == nil || s.config == nil || !s.config.Server.Diagnostics.Enabled {
+		return nil
+	}
+	cfg := s.config.Server.Diagnostics
+	addr := net.JoinHostPort(cfg.Host, fmt.Sprint(cfg.Port))
+
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return fmt.Errorf("diagnostics listen: %w", err)
+	}
+	server := &http.Server{
+		Handler:           s.diagnosticsHandler(),
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+	s.diagnosticsServer = server
+
+	go func() {
+		if err := server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			s.logger.Error("diagnostics server error", "error", err)
+		}
+	}()
+	s.logger.Info("starting diagnostics server", "addr", listener.Addr().String())
+	return nil
+}
+
+func (s *Server) stopDiagnosticsServer(ctx context.Context) {
+	if s == nil || s.diagnosticsServer == nil {
+		return
+	}
+	if err := s.diagnosticsServer.Shutdown(ctx); err != nil {
+		s.logger.Warn("diagnostics server shutdown error", "error", err)
+	}
+	s.diagnosticsServer = nil
+}
+
+// diagnosticsHandler routes the pprof and runtime endpoints behind
+// authentication.
+func (s *Server) diagnosticsHandler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/debug/pprof/", pprof.Index)
+	mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+	mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
+	mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+	mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+	mux.HandleFunc(DiagnosticsRuntimePath, s.handleRuntimeStats)
+	return s.requireDiagnosticsAuth(mux)
+}
+
+// requireDiagnosticsAuth accepts the diagnostics token, or a gateway JWT or
+// API key with the admin scope. Unlike the dashboard, it never falls open
+// when gateway auth is disabled.
+func (s *Server) requireDiagnosticsAuth(next http.Handler) http.Handler {
+	token := strings.TrimSpace(s.config.Server.Diagnostics.Token)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx, ok := s.authenticateDiagnostics(r, token)
+		if !ok {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="nexus-diagnostics"`)
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		allowed := auth.HasScope(ctx, auth.ScopeAdmin)
+		userID := ""
+		if user, ok := auth.UserFromContext(ctx); ok && user != nil {
+			userID = user.ID
+		}
+		s.adminAudit.LogAdminAction(ctx, allowed, userID, "diagnostics "+r.URL.Path, map[string]any{
+			"remote_addr": r.RemoteAddr,
+		})
+		if !allowed {
+			http.Error(w, "forbidden: the admin scope is required", http.StatusForbidden)
+			return
+		}
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+func (s *Server) authenticateDiagnostics(r *http.Request, token string) (context.Context, bool) {
+	ctx := r.Context()
+	bearer := ""
+	if header := r.Header.Get("Authorization"); strings.HasPrefix(strings.ToLower(header), "bearer ") {
+		bearer = strings.TrimSpace(header[len("bearer "):])
+	}
+	if token != "" && bearer != "" && subtle.ConstantTimeCompare([]byte(bearer), []byte(token)) == 1 {
+		return ctx, true
+	}
+	if s.authService == nil || !s.authService.Enabled() {
+		return nil, false
+	}
+	if bearer != "" {
+		if user, scopes, err := s.authService.AuthenticateJWT(bearer); err == nil {
+			return auth.WithScopes(auth.WithUser(ctx, user), scopes), true
+		}
+	}
+	apiKey := r.Header.Get("X-API-Key")
+	if apiKey == "" {
+		apiKey = r.Header.Get("Api-Key")
+	}
+	if apiKey != "" {
+		if user, scopes, err := s.authService.AuthenticateAPIKey(apiKey); err == nil {
+			return auth.WithScopes(auth.WithUser(ctx, user), scopes), true
+		}
+	}
+	return nil, false
+}
+
+// RuntimeStats is the response of DiagnosticsRuntimePath.
+type RuntimeStats struct {
+	GoVersion     string    `json:"go_version"`
+	UptimeSeconds float64   `json:"uptime_seconds"`
+	Goroutines    int       `json:"goroutines"`
+	GOMAXPROCS    int       `json:"gomaxprocs"`
+	NumCPU        int       `json:"num_cpu"`
+	CgoCalls      int64     `json:"cgo_calls"`
+	HeapAlloc     uint64    `json:"heap_alloc_bytes"`
+	HeapInuse     uint64    `json:"heap_inuse_bytes"`
+	HeapObjects   uint64    `json:"heap_objects"`
+	StackInuse    uint64    `json:"stack_inuse_bytes"`
+	Sys           uint64    `json:"sys_bytes"`
+	NextGC        uint64    `json:"next_gc_bytes"`
+	NumGC         uint32    `json:"num_gc"`
+	GCPauseTotal  uint64    `json:"gc_pause_total_ns"`
+	LastGC        time.Time `json:"last_gc,omitzero"`
+}
+
+func (s *Server) handleRuntimeStats(w http.ResponseWriter, r *http.Request) {
+	var mem runtime.MemStats
+	runtime.ReadMemStats(&mem)
+	stats := RuntimeStats{
+		GoVersion:    runtime.Version(),
+		Goroutines:   runtime.NumGoroutine(),
+		GOMAXPROCS:   runtime.GOMAXPROCS(0),
+		NumCPU:       runtime.NumCPU(),
+		CgoCalls:     runtime.NumCgoCall(),
+		HeapAlloc:    mem.HeapAlloc,
+		HeapInuse:    mem.HeapInuse,
+		HeapObjects:  mem.HeapObjects,
+		StackInuse:   mem.StackInuse,
+		Sys:          mem.Sys,
+		NextGC:       mem.NextGC,
+		NumGC:        mem.NumGC,
+		GCPauseTotal: mem.PauseTotalNs,
+	}
+	if !s.startTime.IsZero() {
+		stats.UptimeSeconds = time.Since(s.startTime).Seconds()
+	}
+	if mem.LastGC > 0 {
+		stats.LastGC = time.Unix(0, int64(mem.LastGC)).UTC()
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(stats); err != nil {
+		s.logger.Debug("failed to write runtime stats", "error", err)
+	}
+}
@@ -0,0 +1,95 @@
+package gateway
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/haasonsaas/nexus/internal/auth"
+	"github.com/haasonsaas/nexus/internal/config"
+)
+
+func newDiagnosticsTestServer(t *testing.T, token string, authService *auth.Service) *httptest.Server {
+	t.Helper()
+	server := &Server{config: &config.Config{Server: config.ServerConfig{
+		Diagnostics: config.DiagnosticsServerConfig{Enabled: true, Token: token},
+	}}}
+	server.authService = authService
+	httpServer := httptest.NewServer(server.diagnosticsHandler())
+	t.Cleanup(httpServer.Close)
+	return httpServer
+}
+
+func diagnosticsStatus(t *testing.T, url string, header, value string) int {
+	t.Helper()
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if header != "" {
+		req.Header.Set(header, value)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	return resp.StatusCode
+}
+
+func TestDiagnosticsAuth(t *testing.T) {
+	authService := auth.NewService(auth.Config{APIKeys: []auth.APIKeyConfig{
+		{Key: "admin", UserID: "ops", Role: auth.RoleAdmin},
+		{Key: "viewer", UserID: "viewer", Role: auth.RoleReadOnly},
+	}})
+	srv := newDiagnosticsTestServer(t, "diag-token", authService)
+	url := srv.URL + "/debug/pprof/goroutine?debug=1"
+
+	cases := []struct {
+		name   string
+		header string
+		value  string
+		want   int
+	}{
+		{"anonymous", "", "", http.StatusUnauthorized},
+		{"wrong token", "Authorization", "Bearer nope", http.StatusUnauthorized},
+		{"token", "Authorization", "Bearer diag-token", http.StatusOK},
+		{"admin key", "X-API-Key", "admin", http.StatusOK},
+		{"read-only key", "X-API-Key", "viewer", http.StatusForbidden},
+	}
+	for _, tc := range cases {
+		if got := diagnosticsStatus(t, url, tc.header, tc.value); got != tc.want {
+			t.Errorf("%s: got status %d, want %d", tc.name, got, tc.want)
+		}
+	}
+}
+
+func TestDiagnosticsRequiresCredentialsWhenAuthDisabled(t *testing.T) {
+	srv := newDiagnosticsTestServer(t, "", auth.NewService(auth.Config{}))
+	if got := diagnosticsStatus(t, srv.URL+DiagnosticsRuntimePath, "", ""); got != http.StatusUnauthorized {
+		t.Fatalf("expected diagnostics to stay closed without auth, got %d", got)
+	}
+}
+
+func TestDiagnosticsRuntimeStats(t *testing.T) {
+	srv := newDiagnosticsTestServer(t, "diag-token", nil)
+	req, err := http.NewRequest(http.MethodGet, srv.URL+DiagnosticsRuntimePath, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Authorization", "Bearer diag-token")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	var stats RuntimeStats
+	if err := json.NewDecoder(resp.Body).Decode(&stats); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if stats.GoVersion == "" || stats.Goroutines == 0 || stats.HeapAlloc == 0 {
+		t.Fatalf("unexpected runtime stats %+v", stats)
+	}
+}
@@ -0,0 +1,184 @@
+package gateway
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/haasonsaas/nexus/internal/agent"
+	"github.com/haasonsaas/nexus/internal/commands"
+	"github.com/haasonsaas/nexus/internal/delivery"
+	"github.com/haasonsaas/nexus/internal/drafts"
+	"github.com/haasonsaas/nexus/internal/messages"
+	"github.com/haasonsaas/nexus/pkg/models"
+)
+
+// draftListPreviewLen caps the preview shown per draft in /draft list.
+const draftListPreviewLen = 120
+
+// holdDraftTools replaces the tools listed in tools.drafts with wrappers
+// that hold their calls for approval.
+func (s *Server) holdDraftTools(runtime *agent.Runtime) {
+	if s.drafts == nil || runtime == nil {
+		return
+	}
+	patterns := s.config.Tools.Drafts.Tools
+	for _, tool := range runtime.Tools() {
+		if drafts.Matches(patterns, tool.Name()) {
+			runtime.RegisterTool(s.drafts.Wrap(tool))
+		}
+	}
+}
+
+// announceDraft previews a held call to the conversation it came from.
+// Telegram gets inline buttons that send the matching /draft command.
+func (s *Server) announceDraft(ctx context.Context, draft *drafts.Draft) {
+	content := s.messageCatalog.Render(messages.DraftPreview, s.channelLocale(draft.Channel), messages.Data{
+		"ID":        draft.ID,
+		"Tool":      draft.Tool,
+		"Preview":   draft.Preview,
+		"ExpiresIn": time.Until(draft.ExpiresAt).Round(time.Minute).String(),
+	})
+	var keyboard map[string]any
+	if draft.Channel == models.ChannelTelegram {
+		keyboard = map[string]any{
+			"inline_keyboard": [][]map[string]string{{
+				{"text": "Send", "callback_data": "/draft approve " + draft.ID},
+				{"text": "Cancel", "callback_data": "/draft cancel " + draft.ID},
+			}},
+		}
+	}
+	s.sendDraftNotice(ctx, draft, content, keyboard)
+}
+
+// announceDraftExpired tells the conversation a draft was not sent.
+func (s *Server) announceDraftExpired(draft *drafts.Draft) {
+	content := s.messageCatalog.Render(messages.DraftExpired, s.channelLocale(draft.Channel), messages.Data{
+		"ID":   draft.ID,
+		"Tool": draft.Tool,
+		"TTL":  s.drafts.TTL().String(),
+	})
+	s.sendDraftNotice(context.Background(), draft, content, nil)
+}
+
+func (s *Server) sendDraftNotice(ctx context.Context, draft *drafts.Draft, content string, keyboard map[string]any) {
+	if draft.Channel == "" || draft.ChannelID == "" || s.channels == nil {
+		return
+	}
+	adapter, ok := s.channels.GetOutbound(draft.Channel)
+	if !ok {
+		return
+	}
+	metadata := delivery.RoutingMetadata(draft.Channel, draft.ChannelID)
+	if keyboard != nil {
+		metadata["inline_keyboard"] = keyboard
+	}
+	msg := &models.Message{
+		ID:        uuid.NewString(),
+		SessionID: draft.SessionID,
+		Channel:   draft.Channel,
+		ChannelID: draft.ChannelID,
+		Direction: models.DirectionOutbound,
+		Role:      models.RoleAssistant,
+		Content:   content,
+		Metadata:  metadata,
+		CreatedAt: time.Now(),
+	}
+	sendCtx := context.WithoutCancel(ctx)
+	if err := s.sendWithCircuitBreaker(sendCtx, draft.Channel, func() error {
+		return adapter.Send(sendCtx, msg)
+	}); err != nil {
+		s.logger.Warn("failed to send draft notice", "draft", draft.ID, "error", err)
+	}
+}
+
+// applyDraftCommand handles /draft list, approve, edit, and cancel. Drafts
+// can be decided from the conversation they came from, or by an admin from
+// anywhere.
+func (s *Server) applyDraftCommand(ctx context.Context, session *models.Session, msg *models.Message, op, id, text string) {
+	if s.drafts == nil {
+		s.sendImmediateReply(ctx, session, msg, "Draft mode is not enabled on this gateway.")
+		return
+	}
+	isAdmin := s.resolveCommandRole(ctx, msg) == commands.RoleAdmin
+	if op == "list" {
+		var pending []*drafts.Draft
+		if isAdmin {
+			pending = s.drafts.List("", "")
+		} else if session != nil {
+			pending = s.drafts.List(session.Channel, session.ChannelID)
+		}
+		if len(pending) == 0 {
+			s.sendImmediateReply(ctx, session, msg, "No drafts are waiting for approval.")
+			return
+		}
+		lines := make([]string, 0, len(pending))
+		for _, draft := range pending {
+			lines = append(lines, fmt.Sprintf("%s (%s, expires in %s): %s", draft.ID, draft.Tool,
+				time.Until(draft.ExpiresAt).Round(time.Minute), draftSummary(draft.Preview, draftListPreviewLen)))
+		}
+		s.sendImmediateReply(ctx, session, msg, "Drafts waiting for approval:\n"+strings.Join(lines, "\n")+
+			"\n\nReply /draft approve <id>, /draft edit <id> <new text>, or /draft cancel <id>.")
+		return
+	}
+
+	draft, err := s.drafts.Get(id)
+	if err == nil && !isAdmin && !sameDraftConversation(draft, session) {
+		err = fmt.Errorf("%s: %w", id, drafts.ErrNotFound)
+	}
+	if err != nil {
+		s.sendImmediateReply(ctx, session, msg, titleFirst(err.Error())+". See /draft list.")
+		return
+	}
+	decidedBy := fmt.Sprintf("%s:%s", msg.Channel, extractSenderID(msg))
+	switch op {
+	case "cancel":
+		if _, err := s.drafts.Cancel(draft.ID, decidedBy); err != nil {
+			s.sendImmediateReply(ctx, session, msg, titleFirst(err.Error())+".")
+			return
+		}
+		s.sendImmediateReply(ctx, session, msg, fmt.Sprintf("Discarded draft %s; nothing was sent.", draft.ID))
+	case "edit":
+		edited, err := s.drafts.Edit(draft.ID, text)
+		if err != nil {
+			s.sendImmediateReply(ctx, session, msg, titleFirst(err.Error())+".")
+			return
+		}
+		s.announceDraft(ctx, edited)
+	case "approve":
+		toolCtx := ctx
+		if session != nil {
+			toolCtx = agent.WithSession(ctx, session)
+		}
+		_, result, err := s.drafts.Approve(toolCtx, draft.ID, decidedBy)
+		if err != nil {
+			if !errors.Is(err, drafts.ErrDecided) {
+				s.logger.Error("failed to send draft", "draft", draft.ID, "tool", draft.Tool, "error", err)
+			}
+			s.sendImmediateReply(ctx, session, msg, titleFirst(err.Error())+".")
+			return
+		}
+		reply := fmt.Sprintf("Sent draft %s.", draft.ID)
+		if result != nil && strings.TrimSpace(result.Content) != "" {
+			reply += " " + result.Content
+		}
+		s.sendImmediateReply(ctx, session, msg, reply)
+	}
+}
+
+func sameDraftConversation(draft *drafts.Draft, session *models.Session) bool {
+	return session != nil && draft.Channel == session.Channel && draft.ChannelID == session.ChannelID
+}
+
+// draftSummary returns a preview on one line, cut to max runes.
+func draftSummary(preview string, max int) string {
+	text := strings.Join(strings.Fields(preview), " ")
+	if runes := []rune(text); max > 3 && len(runes) > max {
+		text = string(runes[:max-3]) + "..."
+	}
+	return text
+}
@@ -0,0 +1,42 @@
+package gateway
+
+import (
+	"github.com/haasonsaas/nexus/internal/config"
+	"github.com/haasonsaas/nexus/internal/edge"
+	"github.com/haasonsaas/nexus/pkg/models"
+)
+
+// registerEdgeChannels registers adapters for channels hosted on edge daemons,
+// such as iMessage on a Mac, and routes messages relayed over the edge stream
+// into the gateway. The core needs no local access to these channels.
+func (s *Server) registerEdgeChannels() error {
+	if s.edgeManager == nil || s.config == nil || !s.config.Edge.Enabled {
+		return nil
+	}
+	var adapters []*edge.ChannelAdapter
+	for _, channelType := range edgeHostedChannels(s.config) {
+		if _, exists := s.channels.Get(channelType); exists {
+			// A locally configured adapter takes precedence.
+			continue
+		}
+		adapter, err := edge.NewChannelAdapter(s.edgeManager, channelType, s.logger)
+		if err != nil {
+			return err
+		}
+		s.channels.Register(adapter)
+		adapters = append(adapters, adapter)
+	}
+	if len(adapters) > 0 {
+		s.edgeManager.SetChannelHandler(edge.ChannelInboundRouter(adapters...))
+	}
+	return nil
+}
+
+// edgeHostedChannels lists enabled channels that are served by edges.
+func edgeHostedChannels(cfg *config.Config) []models.ChannelType {
+	var out []models.ChannelType
+	if cfg.Channels.IMessage.Enabled {
+		out = append(out, models.ChannelIMessage)
+	}
+	return out
+}
@@ -119,14 +119,17 @@ func allowFromMatches(cfg config.ElevatedConfig, channel models.ChannelType, sen
 	return allowlistMatches(cfg.AllowFrom, channel, senderID)
 }
 
-func resolveElevatedPermission(global config.ElevatedConfig, agentCfg *config.ElevatedConfig, msg *models.Message) (bool, string) {
+// resolveElevatedPermission decides whether the sender may use elevated
+// mode. granted reports a runtime /elevate grant, which extends the global
+// allow_from list.
+func resolveElevatedPermission(global config.ElevatedConfig, agentCfg *config.ElevatedConfig, msg *models.Message, granted bool) (bool, string) {
 	if global.Enabled == nil || !*global.Enabled {
 		return false, "tools.elevated.enabled"
 	}
 	if agentCfg != nil && agentCfg.Enabled != nil && !*agentCfg.Enabled {
 		return false, "agents.list[].tools.elevated.enabled"
 	}
-	if !allowFromMatches(global, msg.Channel, extractSenderID(msg)) {
+	if !granted && !allowFromMatches(global, msg.Channel, extractSenderID(msg)) {
 		return false, "tools.elevated.allow_from." + strings.ToLower(string(msg.Channel))
 	}
 	if agentCfg != nil && len(agentCfg.AllowFrom) > 0 && !allowFromMatches(*agentCfg, msg.Channel, extractSenderID(msg)) {
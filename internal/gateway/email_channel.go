@@ -0,0 +1,58 @@
+package gateway
+
+import (
+	"github.com/haasonsaas/nexus/internal/agent"
+	"github.com/haasonsaas/nexus/internal/channels"
+	"github.com/haasonsaas/nexus/internal/channels/email"
+	"github.com/haasonsaas/nexus/internal/config"
+	emailtools "github.com/haasonsaas/nexus/internal/tools/email"
+	"github.com/haasonsaas/nexus/pkg/models"
+)
+
+// imapEmailAdapter returns the registered IMAP/SMTP email adapter, if any.
+func imapEmailAdapter(registry *channels.Registry) *email.IMAPAdapter {
+	if registry == nil {
+		return nil
+	}
+	adapter, ok := registry.Get(models.ChannelEmail)
+	if !ok {
+		return nil
+	}
+	imapAdapter, _ := adapter.(*email.IMAPAdapter)
+	return imapAdapter
+}
+
+// configureEmailChannel routes inbound email attachments into the artifact
+// repository.
+func (s *Server) configureEmailChannel() {
+	if s == nil || s.artifactRepo == nil {
+		return
+	}
+	if adapter := imapEmailAdapter(s.channels); adapter != nil {
+		adapter.SetArtifactRepository(s.artifactRepo)
+	}
+}
+
+// emailComposer returns the adapter behind the email_compose tool, or nil
+// when composing is disabled.
+func emailComposer(cfg *config.Config, registry *channels.Registry) emailtools.Composer {
+	if cfg == nil || !cfg.Channels.Email.Enabled || !cfg.Channels.Email.Compose.Enabled {
+		return nil
+	}
+	if adapter := imapEmailAdapter(registry); adapter != nil {
+		return adapter
+	}
+	return nil
+}
+
+// requireEmailComposeApproval holds email_compose calls for approval unless
+// channels.email.compose.require_approval is false.
+func requireEmailComposeApproval(policy *agent.ApprovalPolicy, cfg *config.Config) {
+	if policy == nil || cfg == nil || !cfg.Channels.Email.Compose.Enabled {
+		return
+	}
+	if required := cfg.Channels.Email.Compose.RequireApproval; required != nil && !*required {
+		return
+	}
+	policy.RequireApproval = append(policy.RequireApproval, emailtools.ToolName)
+}
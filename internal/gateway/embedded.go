@@ -0,0 +1,86 @@
+package gateway
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/haasonsaas/nexus/internal/agent"
+	"github.com/haasonsaas/nexus/internal/channels"
+	"github.com/haasonsaas/nexus/internal/sessions"
+)
+
+// EmbeddedOptions configures an in-process gateway used by local harnesses
+// such as `nexus bench` and `nexus chat`.
+type EmbeddedOptions struct {
+	// Adapters are the only channel adapters served in embedded mode.
+	// Channels enabled in config are not started.
+	Adapters []channels.Adapter
+
+	// Provider overrides the configured LLM provider (e.g. a mock for benchmarks).
+	Provider agent.LLMProvider
+
+	// DefaultModel is the model used with Provider.
+	DefaultModel string
+
+	// Sessions overrides the session store. When nil and no database is
+	// configured, an in-memory store is used.
+	Sessions sessions.Store
+
+	// DisableRateLimit turns off per-channel inbound rate limiting so
+	// synthetic load is not dropped.
+	DisableRateLimit bool
+
+	// MaxConcurrency overrides the number of messages processed concurrently.
+	MaxConcurrency int
+}
+
+// StartEmbedded starts message processing for the supplied adapters without
+// opening gRPC or HTTP listeners. Stop the server with Stop.
+func (s *Server) StartEmbedded(ctx context.Context, opts EmbeddedOptions) error {
+	if len(opts.Adapters) == 0 {
+		return fmt.Errorf("embedded mode requires at least one adapter")
+	}
+	registry := channels.NewRegistry()
+	for _, adapter := range opts.Adapters {
+		registry.Register(adapter)
+	}
+	s.channels = registry
+
+	if opts.Provider != nil {
+		s.providerOverride = opts.Provider
+		s.providerOverrideModel = opts.DefaultModel
+	}
+	switch {
+	case opts.Sessions != nil:
+		s.sessions = opts.Sessions
+	case s.sessions == nil && strings.TrimSpace(s.config.Database.URL) == "":
+		s.sessions = sessions.NewMemoryStore()
+	}
+	if opts.DisableRateLimit {
+		s.perChannelLimiter = nil
+	}
+	if opts.MaxConcurrency > 0 {
+		s.messageSem = make(chan struct{}, opts.MaxConcurrency)
+	}
+
+	if _, err := s.ensureRuntime(ctx); err != nil {
+		return err
+	}
+	if err := s.channels.StartAll(ctx); err != nil {
+		return fmt.Errorf("failed to start channels: %w", err)
+	}
+	s.startProcessing(ctx)
+	return nil
+}
+
+// InFlightMessages returns the number of messages currently being processed.
+func (s *Server) InFlightMessages() int {
+	return len(s.messageSem)
+}
+
+// ApprovalChecker returns the tool approval checker used by the runtime.
+// It is nil until the runtime has been initialized.
+func (s *Server) ApprovalChecker() *agent.ApprovalChecker {
+	return s.approvalChecker
+}
@@ -0,0 +1,55 @@
+package gateway
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/haasonsaas/nexus/internal/channels"
+	"github.com/haasonsaas/nexus/internal/channels/loopback"
+	"github.com/haasonsaas/nexus/internal/config"
+)
+
+func TestStartEmbeddedRoundTrip(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	cfg := &config.Config{}
+	cfg.Workspace.Path = t.TempDir()
+	server, err := NewServer(cfg, logger)
+	if err != nil {
+		t.Fatalf("NewServer() error = %v", err)
+	}
+
+	adapter := loopback.New(loopback.Config{Logger: logger})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	if err := server.StartEmbedded(ctx, EmbeddedOptions{
+		Adapters:         []channels.Adapter{adapter},
+		Provider:         streamingProvider{},
+		DisableRateLimit: true,
+	}); err != nil {
+		t.Fatalf("StartEmbedded() error = %v", err)
+	}
+
+	if _, err := adapter.Inject(ctx, "conv-a", "ping"); err != nil {
+		t.Fatalf("Inject() error = %v", err)
+	}
+	select {
+	case reply := <-adapter.Replies():
+		if reply.Content != "hello world" {
+			t.Fatalf("reply content = %q, want %q", reply.Content, "hello world")
+		}
+		if got := loopback.ConversationID(reply); got != "conv-a" {
+			t.Fatalf("reply conversation = %q, want conv-a", got)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for loopback reply")
+	}
+
+	stopCtx, stopCancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer stopCancel()
+	if err := server.Stop(stopCtx); err != nil {
+		t.Fatalf("Stop() error = %v", err)
+	}
+}
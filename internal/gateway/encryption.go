@@ -0,0 +1,35 @@
+package gateway
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/haasonsaas/nexus/internal/config"
+	"github.com/haasonsaas/nexus/internal/storage/encryption"
+)
+
+// BuildKeyring loads the field encryption keys named in the config. It
+// returns nil when encryption is disabled.
+func BuildKeyring(cfg *config.Config) (*encryption.Keyring, error) {
+	if cfg == nil || !cfg.Encryption.Enabled {
+		return nil, nil
+	}
+	keys := make(map[string][]byte, len(cfg.Encryption.Keys))
+	for _, entry := range cfg.Encryption.Keys {
+		id := strings.TrimSpace(entry.ID)
+		var (
+			key []byte
+			err error
+		)
+		if path := strings.TrimSpace(entry.AgeIdentityFile); path != "" {
+			key, err = encryption.LoadAgeIdentity(path)
+		} else {
+			key, err = encryption.ParseKey(entry.Key)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("encryption key %q: %w", id, err)
+		}
+		keys[id] = key
+	}
+	return encryption.NewKeyring(strings.TrimSpace(cfg.Encryption.PrimaryKey), keys)
+}
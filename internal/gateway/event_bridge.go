@@ -0,0 +1,198 @@
+package gateway
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+
+	"github.com/haasonsaas/nexus/internal/agent"
+	"github.com/haasonsaas/nexus/internal/config"
+	"github.com/haasonsaas/nexus/internal/eventbridge"
+	"github.com/haasonsaas/nexus/pkg/models"
+)
+
+// newEventBridge builds the bridge publishing gateway events to NATS or
+// Kafka; nil when observability.event_bridge is disabled.
+func newEventBridge(cfg *config.Config, logger *slog.Logger) (*eventbridge.Bridge, error) {
+	bridgeCfg := cfg.Observability.EventBridge
+	if !bridgeCfg.Enabled {
+		return nil, nil
+	}
+	source := cfg.Cluster.NodeID
+	if source == "" {
+		source, _ = os.Hostname() //nolint:errcheck // source is informational
+	}
+
+	var (
+		publisher eventbridge.Publisher
+		timeout   = bridgeCfg.NATS.Timeout
+		err       error
+	)
+	switch bridgeCfg.Backend {
+	case config.EventBridgeBackendNATS:
+		publisher, err = eventbridge.NewNATSPublisher(eventbridge.NATSConfig{
+			URL:      bridgeCfg.NATS.URL,
+			User:     bridgeCfg.NATS.User,
+			Password: bridgeCfg.NATS.Password,
+			Token:    bridgeCfg.NATS.Token,
+			Name:     "nexus-" + source,
+			Timeout:  bridgeCfg.NATS.Timeout,
+		})
+	case config.EventBridgeBackendKafka:
+		timeout = bridgeCfg.Kafka.Timeout
+		publisher, err = eventbridge.NewKafkaPublisher(eventbridge.KafkaConfig{
+			RESTURL:  bridgeCfg.Kafka.RESTURL,
+			Username: bridgeCfg.Kafka.Username,
+			Password: bridgeCfg.Kafka.Password,
+			Headers:  bridgeCfg.Kafka.Headers,
+		})
+	default:
+		err = fmt.Errorf("unsupported backend %q", bridgeCfg.Backend)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return eventbridge.New(eventbridge.Config{
+		Format:     bridgeCfg.Format,
+		Subject:    bridgeCfg.Subject,
+		Events:     bridgeCfg.Events,
+		Channels:   bridgeCfg.Channels,
+		Agents:     bridgeCfg.Agents,
+		Source:     source,
+		BufferSize: bridgeCfg.BufferSize,
+		Timeout:    timeout,
+	}, publisher, logger)
+}
+
+// startEventBridge starts publishing queued events.
+func (s *Server) startEventBridge(ctx context.Context) {
+	if s == nil || s.eventBridge == nil {
+		return
+	}
+	s.eventBridge.Start(ctx)
+	cfg := s.config.Observability.EventBridge
+	s.logger.Info("event bridge started",
+		"backend", cfg.Backend,
+		"subject", cfg.Subject,
+		"format", cfg.Format)
+}
+
+// eventBridgePlugin publishes run and tool lifecycle events.
+func (s *Server) eventBridgePlugin() agent.Plugin {
+	includeContent := s.config.Observability.EventBridge.IncludeContent
+	return agent.PluginFunc(func(ctx context.Context, e models.AgentEvent) {
+		event := eventbridge.Event{RunID: e.RunID, Time: e.Time}
+		switch e.Type {
+		case models.AgentEventRunStarted:
+			event.Type = eventbridge.TypeRunStarted
+		case models.AgentEventRunFinished:
+			event.Type = eventbridge.TypeRunFinished
+			if e.Stats != nil && e.Stats.Run != nil {
+				stats := e.Stats.Run
+				event.Data = map[string]any{
+					"duration_ms":   stats.WallTime.Milliseconds(),
+					"turns":         stats.Turns,
+					"iterations":    stats.Iters,
+					"tool_calls":    stats.ToolCalls,
+					"tool_timeouts": stats.ToolTimeouts,
+				}
+			}
+		case models.AgentEventRunError, models.AgentEventRunCancelled, models.AgentEventRunTimedOut:
+			event.Type = eventbridge.TypeRunFailed
+			event.Data = map[string]any{"reason": string(e.Type)}
+			if e.Error != nil {
+				event.Data["error"] = e.Error.Message
+			}
+		case models.AgentEventToolStarted:
+			if e.Tool == nil {
+				return
+			}
+			event.Type = eventbridge.TypeToolStarted
+			event.Data = map[string]any{"tool_name": e.Tool.Name, "tool_call_id": e.Tool.CallID}
+			if includeContent && len(e.Tool.ArgsJSON) > 0 {
+				event.Data["args"] = bridgeJSON(e.Tool.ArgsJSON)
+			}
+		case models.AgentEventToolFinished, models.AgentEventToolTimedOut:
+			if e.Tool == nil {
+				return
+			}
+			event.Type = eventbridge.TypeToolFinished
+			event.Data = map[string]any{
+				"tool_name":    e.Tool.Name,
+				"tool_call_id": e.Tool.CallID,
+				"success":      e.Tool.Success,
+				"timed_out":    e.Type == models.AgentEventToolTimedOut,
+				"duration_ms":  e.Tool.Elapsed.Milliseconds(),
+			}
+			if includeContent && len(e.Tool.ResultJSON) > 0 {
+				event.Data["result"] = bridgeJSON(e.Tool.ResultJSON)
+			}
+		default:
+			return
+		}
+		if session := agent.SessionFromContext(ctx); session != nil {
+			event.SessionID = session.ID
+			event.AgentID = session.AgentID
+			event.Channel = string(session.Channel)
+		}
+		s.eventBridge.Publish(event)
+	})
+}
+
+// bridgeMessage publishes an inbound or outbound channel message.
+func (s *Server) bridgeMessage(session *models.Session, msg *models.Message, eventType, runID string) {
+	if s.eventBridge == nil || session == nil || msg == nil {
+		return
+	}
+	data := map[string]any{
+		"message_id":  msg.ID,
+		"role":        string(msg.Role),
+		"attachments": len(msg.Attachments),
+	}
+	if s.config.Observability.EventBridge.IncludeContent {
+		data["content"] = msg.Content
+	}
+	s.eventBridge.Publish(eventbridge.Event{
+		Type:      eventType,
+		SessionID: session.ID,
+		RunID:     runID,
+		AgentID:   session.AgentID,
+		Channel:   string(msg.Channel),
+		Data:      data,
+	})
+}
+
+// bridgeApproval publishes an approval request or decision.
+func (s *Server) bridgeApproval(req *agent.ApprovalRequest, eventType string) {
+	if s.eventBridge == nil || req == nil {
+		return
+	}
+	data := map[string]any{
+		"request_id":   req.ID,
+		"tool_name":    req.ToolName,
+		"tool_call_id": req.ToolCallID,
+		"decision":     string(req.Decision),
+	}
+	if eventType == eventbridge.TypeApprovalRequested {
+		data["reason"] = req.Reason
+		data["expires_at"] = req.ExpiresAt
+	} else {
+		data["decided_by"] = req.DecidedBy
+	}
+	s.eventBridge.Publish(eventbridge.Event{
+		Type:      eventType,
+		SessionID: req.SessionID,
+		AgentID:   req.AgentID,
+		Data:      data,
+	})
+}
+
+// bridgeJSON keeps valid JSON structured and passes anything else as text.
+func bridgeJSON(raw []byte) any {
+	if json.Valid(raw) {
+		return json.RawMessage(raw)
+	}
+	return string(raw)
+}
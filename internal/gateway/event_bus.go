@@ -0,0 +1,254 @@
+package gateway
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/haasonsaas/nexus/internal/agent"
+	"github.com/haasonsaas/nexus/internal/eventbridge"
+	"github.com/haasonsaas/nexus/internal/eventbus"
+)
+
+// Cache names accepted by InvalidateCache.
+const (
+	cacheUsage         = "usage"
+	cacheProviderProbe = "provider_probe"
+	cacheAccessGrants  = "access_grants"
+)
+
+type approvalResolvedEvent struct {
+	RequestID string                 `json:"request_id"`
+	Decision  agent.ApprovalDecision `json:"decision"`
+	DecidedBy string                 `json:"decided_by,omitempty"`
+	DecidedAt time.Time              `json:"decided_at"`
+}
+
+type sessionResetEvent struct {
+	SessionID  string `json:"session_id"`
+	SessionKey string `json:"session_key,omitempty"`
+}
+
+type configChangedEvent struct {
+	Hash string `json:"hash"`
+}
+
+type cacheInvalidateEvent struct {
+	Cache string `json:"cache"`
+	Key   string `json:"key,omitempty"`
+}
+
+// startEventBus upgrades the in-process event bus to Postgres LISTEN/NOTIFY
+// when configured. Subscriptions made on the local bus carry over.
+func (s *Server) startEventBus(ctx context.Context) error {
+	if s == nil || s.config == nil || s.localEvents == nil {
+		return nil
+	}
+	busCfg := s.config.Cluster.EventBus
+	backend := strings.ToLower(strings.TrimSpace(busCfg.Backend))
+	switch backend {
+	case "memory":
+		return nil
+	case "", "auto":
+		if !s.config.Cluster.Enabled || s.config.Database.URL == "" {
+			return nil
+		}
+	case "postgres":
+		if s.config.Database.URL == "" {
+			return fmt.Errorf("cluster.event_bus.backend postgres requires database.url")
+		}
+	default:
+		return fmt.Errorf("unsupported event bus backend %q", busCfg.Backend)
+	}
+
+	db, err := s.clusterDB()
+	if err == nil && db == nil {
+		err = fmt.Errorf("no database available")
+	}
+	var bus *eventbus.PostgresBus
+	if err == nil {
+		bus, err = eventbus.NewPostgresBus(db, s.localEvents, eventbus.PostgresConfig{
+			DSN:     s.config.Database.URL,
+			Channel: busCfg.Channel,
+			Logger:  s.logger,
+		})
+	}
+	if err != nil {
+		if backend == "postgres" {
+			return fmt.Errorf("start postgres event bus: %w", err)
+		}
+		s.logger.Warn("postgres event bus unavailable; events stay on this node", "error", err)
+		return nil
+	}
+	s.events = bus
+	s.logger.Info("postgres event bus started", "channel", busCfg.Channel)
+	return nil
+}
+
+// stopEventBus closes the cross-node transport, reverting to local delivery.
+func (s *Server) stopEventBus() {
+	if s.events == nil || s.events == eventbus.Bus(s.localEvents) {
+		return
+	}
+	if err := s.events.Close(); err != nil {
+		s.logger.Error("error closing event bus", "error", err)
+	}
+	s.events = s.localEvents
+}
+
+// publishEvent publishes an event, logging failures. Event delivery is best
+// effort and never fails the operation that produced it.
+func (s *Server) publishEvent(ctx context.Context, topic string, payload any) {
+	if s == nil || s.events == nil {
+		return
+	}
+	if err := s.events.Publish(ctx, topic, payload); err != nil {
+		s.logger.Warn("event publish failed", "topic", topic, "error", err)
+	}
+}
+
+// subscribeClusterEvents applies events published by other nodes. Local
+// events are ignored because the publishing code path already applied them.
+func (s *Server) subscribeClusterEvents() {
+	if s.localEvents == nil {
+		return
+	}
+	s.localEvents.Subscribe(eventbus.TopicApprovalResolved, s.remoteOnly(s.handleApprovalResolved))
+	s.localEvents.Subscribe(eventbus.TopicSessionReset, s.remoteOnly(s.handleSessionReset))
+	s.localEvents.Subscribe(eventbus.TopicConfigChanged, s.remoteOnly(s.handleConfigChanged))
+	s.localEvents.Subscribe(eventbus.TopicCacheInvalidate, s.remoteOnly(s.handleCacheInvalidate))
+}
+
+func (s *Server) remoteOnly(handler eventbus.Handler) eventbus.Handler {
+	return func(ctx context.Context, event eventbus.Event) {
+		if event.Remote {
+			handler(ctx, event)
+		}
+	}
+}
+
+// newApprovalChecker creates an approval checker whose decisions are
+// propagated to other nodes and, with the event bridge, to the message queue.
+func (s *Server) newApprovalChecker(policy *agent.ApprovalPolicy) *agent.ApprovalChecker {
+	checker := agent.NewApprovalChecker(policy)
+	checker.SetStore(agent.NewMemoryApprovalStore())
+	checker.SetRequestListener(func(_ context.Context, req *agent.ApprovalRequest) {
+		s.bridgeApproval(req, eventbridge.TypeApprovalRequested)
+	})
+	checker.SetDecisionListener(func(ctx context.Context, req *agent.ApprovalRequest) {
+		s.bridgeApproval(req, eventbridge.TypeApprovalDecided)
+		s.publishEvent(ctx, eventbus.TopicApprovalResolved, approvalResolvedEvent{
+			RequestID: req.ID,
+			Decision:  req.Decision,
+			DecidedBy: req.DecidedBy,
+			DecidedAt: req.DecidedAt,
+		})
+	})
+	return checker
+}
+
+// publishSessionReset tells other nodes a session was reset or deleted.
+func (s *Server) publishSessionReset(ctx context.Context, sessionID, sessionKey string) {
+	s.publishEvent(ctx, eventbus.TopicSessionReset, sessionResetEvent{SessionID: sessionID, SessionKey: sessionKey})
+}
+
+// InvalidateCache drops cached entries on this node and every other node.
+// An empty key clears the whole cache.
+func (s *Server) InvalidateCache(ctx context.Context, cache, key string) {
+	s.invalidateLocalCache(cache, key)
+	s.publishEvent(ctx, eventbus.TopicCacheInvalidate, cacheInvalidateEvent{Cache: cache, Key: key})
+}
+
+func (s *Server) invalidateLocalCache(cache, key string) {
+	switch cache {
+	case cacheUsage:
+		if s.integration == nil || s.integration.UsageCache() == nil {
+			return
+		}
+		if key == "" {
+			s.integration.UsageCache().InvalidateAll()
+		} else {
+			s.integration.UsageCache().Invalidate(key)
+		}
+	case cacheProviderProbe:
+		s.providerProbe.mu.Lock()
+		s.providerProbe.checkedAt = time.Time{}
+		s.providerProbe.mu.Unlock()
+	case cacheAccessGrants:
+		s.accessGrantsMu.Lock()
+		grants := s.accessGrants
+		s.accessGrantsMu.Unlock()
+		if grants != nil {
+			grants.Invalidate()
+		}
+	default:
+		s.logger.Debug("ignoring invalidation for unknown cache", "cache", cache)
+	}
+}
+
+func (s *Server) handleApprovalResolved(ctx context.Context, event eventbus.Event) {
+	var payload approvalResolvedEvent
+	if err := event.Decode(&payload); err != nil || payload.RequestID == "" {
+		s.logger.Warn("invalid approval event", "source", event.Source, "error", err)
+		return
+	}
+	checker := s.approvalChecker
+	if checker == nil {
+		return
+	}
+	if err := checker.ApplyDecision(ctx, payload.RequestID, payload.Decision, payload.DecidedBy, payload.DecidedAt); err != nil {
+		s.logger.Warn("failed to apply remote approval decision", "request_id", payload.RequestID, "error", err)
+	}
+}
+
+func (s *Server) handleSessionReset(ctx context.Context, event eventbus.Event) {
+	var payload sessionResetEvent
+	if err := event.Decode(&payload); err != nil || payload.SessionID == "" {
+		s.logger.Warn("invalid session reset event", "source", event.Source, "error", err)
+		return
+	}
+	if s.cancelActiveRun(payload.SessionID) {
+		s.logger.Info("cancelled run for session reset on another node",
+			"session_id", payload.SessionID, "source", event.Source)
+	}
+}
+
+// handleConfigChanged reloads the config file when another node applied a
+// config this node can see, such as on a shared volume or ConfigMap.
+func (s *Server) handleConfigChanged(ctx context.Context, event eventbus.Event) {
+	var payload configChangedEvent
+	if err := event.Decode(&payload); err != nil {
+		s.logger.Warn("invalid config change event", "source", event.Source, "error", err)
+		return
+	}
+	s.invalidateLocalCache(cacheUsage, "")
+	s.invalidateLocalCache(cacheProviderProbe, "")
+	if strings.TrimSpace(s.configPath) == "" {
+		return
+	}
+	snapshot, err := s.ConfigSnapshot(ctx)
+	if err != nil {
+		s.logger.Warn("failed to read config after remote change", "error", err)
+		return
+	}
+	if snapshot.Hash != payload.Hash {
+		s.logger.Info("config changed on another node; local config file differs, not reloading",
+			"source", event.Source)
+		return
+	}
+	if _, err := s.applyConfig(ctx, "", ""); err != nil {
+		s.logger.Warn("failed to reload config after remote change", "source", event.Source, "error", err)
+		return
+	}
+	s.logger.Info("reloaded config changed on another node", "source", event.Source)
+}
+
+func (s *Server) handleCacheInvalidate(ctx context.Context, event eventbus.Event) {
+	var payload cacheInvalidateEvent
+	if err := event.Decode(&payload); err != nil || payload.Cache == "" {
+		s.logger.Warn("invalid cache invalidation event", "source", event.Source, "error", err)
+		return
+	}
+	s.invalidateLocalCache(payload.Cache, payload.Key)
+}
@@ -0,0 +1,108 @@
+package gateway
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/haasonsaas/nexus/internal/agent"
+	"github.com/haasonsaas/nexus/internal/config"
+	"github.com/haasonsaas/nexus/internal/eventbus"
+	"github.com/haasonsaas/nexus/pkg/models"
+)
+
+func newEventBusTestServer(t *testing.T) *Server {
+	t.Helper()
+	cfg := &config.Config{}
+	cfg.Workspace.Path = t.TempDir()
+	server, err := NewServer(cfg, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	if err != nil {
+		t.Fatalf("NewServer() error = %v", err)
+	}
+	return server
+}
+
+func remoteEvent(t *testing.T, topic string, payload any) eventbus.Event {
+	t.Helper()
+	data, err := json.Marshal(payload)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	return eventbus.Event{Topic: topic, Source: "node-2", Payload: data, Remote: true}
+}
+
+func TestApprovalDecisionPropagation(t *testing.T) {
+	ctx := context.Background()
+	server := newEventBusTestServer(t)
+	server.approvalChecker = server.newApprovalChecker(nil)
+
+	var published []eventbus.Event
+	server.events.Subscribe(eventbus.TopicApprovalResolved, func(ctx context.Context, event eventbus.Event) {
+		published = append(published, event)
+	})
+
+	req, err := server.approvalChecker.CreateApprovalRequest(ctx, "agent-1", "session-1", models.ToolCall{ID: "call-1", Name: "exec"}, "needs approval")
+	if err != nil {
+		t.Fatalf("CreateApprovalRequest: %v", err)
+	}
+	if err := server.approvalChecker.Approve(ctx, req.ID, "admin"); err != nil {
+		t.Fatalf("Approve: %v", err)
+	}
+	if len(published) != 1 {
+		t.Fatalf("published %d approval events, want 1", len(published))
+	}
+
+	// A decision made on another node is applied to the local request.
+	req2, err := server.approvalChecker.CreateApprovalRequest(ctx, "agent-1", "session-1", models.ToolCall{ID: "call-2", Name: "exec"}, "needs approval")
+	if err != nil {
+		t.Fatalf("CreateApprovalRequest: %v", err)
+	}
+	server.handleApprovalResolved(ctx, remoteEvent(t, eventbus.TopicApprovalResolved, approvalResolvedEvent{
+		RequestID: req2.ID,
+		Decision:  agent.ApprovalDenied,
+		DecidedBy: "remote-admin",
+		DecidedAt: time.Now(),
+	}))
+	pending, err := server.approvalChecker.GetPendingRequests(ctx, "")
+	if err != nil {
+		t.Fatalf("GetPendingRequests: %v", err)
+	}
+	if len(pending) != 0 {
+		t.Fatalf("expected remote decision to resolve request, pending = %d", len(pending))
+	}
+	if len(published) != 1 {
+		t.Fatalf("remote decision was re-published")
+	}
+}
+
+func TestRemoteSessionResetCancelsRun(t *testing.T) {
+	server := newEventBusTestServer(t)
+	cancelled := false
+	server.registerActiveRun("session-1", func() { cancelled = true })
+
+	// Local events were already applied by the publisher and are ignored.
+	if err := server.events.Publish(context.Background(), eventbus.TopicSessionReset, sessionResetEvent{SessionID: "session-1"}); err != nil {
+		t.Fatalf("Publish: %v", err)
+	}
+	if cancelled {
+		t.Fatal("local session reset event should not be re-applied")
+	}
+
+	server.handleSessionReset(context.Background(), remoteEvent(t, eventbus.TopicSessionReset, sessionResetEvent{SessionID: "session-1"}))
+	if !cancelled {
+		t.Fatal("expected remote session reset to cancel the active run")
+	}
+}
+
+func TestRemoteCacheInvalidation(t *testing.T) {
+	server := newEventBusTestServer(t)
+	server.providerProbe.checkedAt = time.Now()
+
+	server.handleCacheInvalidate(context.Background(), remoteEvent(t, eventbus.TopicCacheInvalidate, cacheInvalidateEvent{Cache: cacheProviderProbe}))
+	if !server.providerProbe.checkedAt.IsZero() {
+		t.Fatal("expected provider probe cache to be cleared")
+	}
+}
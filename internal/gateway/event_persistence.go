@@ -0,0 +1,143 @@
+package gateway
+
+import (
+	"context"
+	"log/slog"
+	"sort"
+	"time"
+
+	"github.com/haasonsaas/nexus/internal/cluster"
+	"github.com/haasonsaas/nexus/internal/config"
+	"github.com/haasonsaas/nexus/internal/observability"
+)
+
+// eventRetentionInterval is how often persisted events past retention are
+// pruned.
+const eventRetentionInterval = time.Hour
+
+// newPersistingEventStore wraps the in-memory timeline store so a sampled
+// copy of recorded events is written to the database once it is attached
+// at start.
+func newPersistingEventStore(memory *observability.MemoryEventStore, cfg config.EventStoreConfig, logger *slog.Logger) *observability.PersistingEventStore {
+	if !cfg.Enabled {
+		return nil
+	}
+	return observability.NewPersistingEventStore(memory, observability.EventPersistConfig{
+		SampleRate:    cfg.SampleRate,
+		Types:         cfg.Types,
+		BufferSize:    cfg.BufferSize,
+		FlushInterval: cfg.FlushInterval,
+	}, logger)
+}
+
+// startEventPersistence attaches the session database to the event store,
+// starts flushing buffered events, and prunes events past retention.
+func (s *Server) startEventPersistence(ctx context.Context) {
+	if s == nil || s.config == nil || s.persistedEvents == nil {
+		return
+	}
+	db, err := s.clusterDB()
+	if err != nil || db == nil {
+		s.logger.Warn("event store: persistence disabled (session store init failed)", "error", err)
+		return
+	}
+	store, err := observability.NewDBEventStore(db)
+	if err == nil {
+		// Probe the table so a missing migration is reported once here
+		// rather than on every flush.
+		_, err = store.Query(ctx, observability.EventQuery{Limit: 1})
+	}
+	if err != nil {
+		s.logger.Warn("event store: persistence disabled (run `nexus migrate up`?)", "error", err)
+		return
+	}
+	store.SetKeyring(s.keyring)
+	s.persistedEvents.Attach(store)
+	s.persistedEvents.Start(ctx)
+
+	cfg := s.config.Observability.EventStore
+	s.logger.Info("event store persistence started",
+		"sample_rate", cfg.SampleRate,
+		"retention", cfg.Retention,
+		"types", cfg.Types)
+	if cfg.Retention <= 0 {
+		return
+	}
+
+	s.goSupervised(ctx, "worker:event-retention", func(ctx context.Context) {
+		ticker := time.NewTicker(eventRetentionInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case now := <-ticker.C:
+				if !s.isClusterLeader(cluster.LeaseEventRetention) {
+					continue
+				}
+				deleted, err := store.Prune(ctx, now.Add(-cfg.Retention))
+				if err != nil {
+					s.logger.Warn("event store: retention sweep failed", "error", err)
+				} else if deleted > 0 {
+					s.logger.Info("event store: pruned expired events", "events", deleted)
+				}
+			}
+		}
+	})
+}
+
+// stopEventPersistence writes buffered events before the database closes.
+func (s *Server) stopEventPersistence(ctx context.Context) {
+	if s.persistedEvents == nil {
+		return
+	}
+	if err := s.persistedEvents.Shutdown(ctx); err != nil {
+		s.logger.Error("error flushing event store", "error", err)
+	}
+	if dropped := s.persistedEvents.Dropped(); dropped > 0 {
+		s.logger.Warn("event store dropped events", "events", dropped)
+	}
+}
+
+// timelineEvents returns the events of a run (or, without a run ID, a
+// session) from the in-memory store merged with persisted events, so a
+// timeline covers both events from before a restart and events not yet
+// flushed.
+func (s *Server) timelineEvents(ctx context.Context, runID, sessionID string) ([]*observability.Event, error) {
+	var events []*observability.Event
+	if s.eventStore != nil {
+		var err error
+		if runID != "" {
+			events, err = s.eventStore.GetByRunID(runID)
+		} else {
+			events, err = s.eventStore.GetBySessionID(sessionID)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	if s.persistedEvents == nil || s.persistedEvents.DB() == nil {
+		return events, nil
+	}
+	query := observability.EventQuery{RunID: runID}
+	if runID == "" {
+		query.SessionID = sessionID
+	}
+	persisted, err := s.persistedEvents.DB().Query(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	seen := make(map[string]bool, len(events))
+	for _, ev := range events {
+		seen[ev.ID] = true
+	}
+	for _, ev := range persisted {
+		if !seen[ev.ID] {
+			events = append(events, ev)
+		}
+	}
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].Timestamp.Before(events[j].Timestamp)
+	})
+	return events, nil
+}
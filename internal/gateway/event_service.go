@@ -26,24 +26,13 @@ func newEventService(s *Server) *eventService {
 
 // GetEvents retrieves events by run ID or session ID.
 func (e *eventService) GetEvents(ctx context.Context, req *proto.GetEventsRequest) (*proto.GetEventsResponse, error) {
-	store := e.server.EventStore()
-	if store == nil {
-		return &proto.GetEventsResponse{Events: []*proto.TimelineEvent{}, TotalCount: 0}, nil
-	}
-
-	var events []*observability.Event
-	var err error
-
-	// Query by run ID or session ID
-	if req.RunId != "" {
-		events, err = store.GetByRunID(req.RunId)
-	} else if req.SessionId != "" {
-		events, err = store.GetBySessionID(req.SessionId)
-	} else {
+	if req.RunId == "" && req.SessionId == "" {
 		// Return empty if no query specified
 		return &proto.GetEventsResponse{Events: []*proto.TimelineEvent{}, TotalCount: 0}, nil
 	}
 
+	// Query by run ID or session ID
+	events, err := e.server.timelineEvents(ctx, req.RunId, req.SessionId)
 	if err != nil {
 		return nil, err
 	}
@@ -82,22 +71,11 @@ func (e *eventService) GetEvents(ctx context.Context, req *proto.GetEventsReques
 
 // GetTimeline retrieves a formatted timeline for a run.
 func (e *eventService) GetTimeline(ctx context.Context, req *proto.GetTimelineRequest) (*proto.GetTimelineResponse, error) {
-	store := e.server.EventStore()
-	if store == nil {
-		return &proto.GetTimelineResponse{Formatted: "No events found"}, nil
-	}
-
-	var events []*observability.Event
-	var err error
-
-	if req.RunId != "" {
-		events, err = store.GetByRunID(req.RunId)
-	} else if req.SessionId != "" {
-		events, err = store.GetBySessionID(req.SessionId)
-	} else {
+	if req.RunId == "" && req.SessionId == "" {
 		return &proto.GetTimelineResponse{Formatted: "No run_id or session_id specified"}, nil
 	}
 
+	events, err := e.server.timelineEvents(ctx, req.RunId, req.SessionId)
 	if err != nil {
 		return nil, err
 	}
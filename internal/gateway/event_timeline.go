@@ -109,6 +109,9 @@ func (p *EventTimelinePlugin) OnEvent(ctx context.Context, e models.AgentEvent)
 			}
 			data["input_tokens"] = e.Stream.InputTokens
 			data["output_tokens"] = e.Stream.OutputTokens
+			if e.Stream.ReasoningTokens > 0 {
+				data["reasoning_tokens"] = e.Stream.ReasoningTokens
+			}
 		}
 		if e.Stats != nil && e.Stats.Run != nil {
 			data["model_wall_time_ms"] = e.Stats.Run.ModelWallTime.Milliseconds()
@@ -1,27 +1,165 @@
 package gateway
 
 import (
+	"context"
+	"log/slog"
 	"strings"
+	"sync"
+	"time"
 
+	"github.com/haasonsaas/nexus/internal/agent"
 	"github.com/haasonsaas/nexus/internal/experiments"
 	"github.com/haasonsaas/nexus/pkg/models"
 )
 
+// experimentTagPrefix prefixes the trace tag that carries an experiment's
+// variant ID.
+const experimentTagPrefix = "experiment."
+
 func (s *Server) experimentOverrides(session *models.Session, msg *models.Message) experiments.Overrides {
 	if s == nil || s.experimentsMgr == nil {
 		return experiments.Overrides{}
 	}
-	subject := ""
-	if session != nil && session.Metadata != nil {
+	return s.experimentsMgr.ResolveSubject(experimentSubject(session, msg))
+}
+
+// experimentSubject identifies the user and session a message belongs to.
+func experimentSubject(session *models.Session, msg *models.Message) experiments.Subject {
+	var subject experiments.Subject
+	if session != nil {
 		if value, ok := session.Metadata["user_id"].(string); ok {
-			subject = strings.TrimSpace(value)
+			subject.UserID = strings.TrimSpace(value)
 		}
+		subject.SessionID = strings.TrimSpace(session.ID)
+	}
+	if subject.SessionID == "" && msg != nil {
+		subject.SessionID = strings.TrimSpace(msg.ChannelID)
+	}
+	return subject
+}
+
+// withExperiments applies the experiment model override for the run, tags its
+// traces with the assigned variants, and logs the exposures.
+func (s *Server) withExperiments(ctx context.Context, session *models.Session, msg *models.Message) context.Context {
+	overrides := s.experimentOverrides(session, msg)
+	if overrides.Model != "" {
+		ctx = agent.WithModel(ctx, overrides.Model)
+	}
+	if len(overrides.Assignments) == 0 {
+		return ctx
+	}
+	ctx = agent.WithTraceTags(ctx, experimentTraceTags(overrides.Assignments))
+	if s.experimentRecorder != nil && session != nil && msg != nil {
+		s.experimentRecorder.Expose(session.ID+"-"+msg.ID, session, msg, overrides.Assignments)
+	}
+	return ctx
+}
+
+// experimentTraceTags maps each assignment to an "experiment.<id>" tag.
+func experimentTraceTags(assignments []experiments.Assignment) map[string]string {
+	tags := make(map[string]string, len(assignments))
+	for _, assignment := range assignments {
+		tags[experimentTagPrefix+assignment.ExperimentID] = assignment.VariantID
+	}
+	return tags
+}
+
+// experimentRecorder logs exposures and, as a runtime plugin, the outcome of
+// each exposed run.
+type experimentRecorder struct {
+	log    *experiments.Log
+	logger *slog.Logger
+
+	mu   sync.Mutex
+	runs map[string]*experimentRun
+}
+
+// experimentRun is an exposed run awaiting its outcome.
+type experimentRun struct {
+	sessionID string
+	channel   string
+	failed    bool
+}
+
+func newExperimentRecorder(log *experiments.Log, logger *slog.Logger) *experimentRecorder {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &experimentRecorder{
+		log:    log,
+		logger: logger,
+		runs:   make(map[string]*experimentRun),
+	}
+}
+
+// Expose logs that runID is running under the given variants.
+func (r *experimentRecorder) Expose(runID string, session *models.Session, msg *models.Message, assignments []experiments.Assignment) {
+	if r == nil || len(assignments) == 0 {
+		return
+	}
+	run := &experimentRun{sessionID: session.ID, channel: string(msg.Channel)}
+	r.mu.Lock()
+	r.runs[runID] = run
+	r.mu.Unlock()
+
+	now := time.Now()
+	records := make([]experiments.Record, 0, len(assignments))
+	for _, assignment := range assignments {
+		records = append(records, experiments.Record{
+			Type:         experiments.RecordExposure,
+			Time:         now,
+			RunID:        runID,
+			SessionID:    run.sessionID,
+			Channel:      run.channel,
+			ExperimentID: assignment.ExperimentID,
+			VariantID:    assignment.VariantID,
+			Subject:      assignment.Subject,
+		})
 	}
-	if subject == "" && session != nil {
-		subject = strings.TrimSpace(session.ID)
+	if err := r.log.Append(records...); err != nil {
+		r.logger.Warn("failed to log experiment exposure", "run_id", runID, "error", err)
 	}
-	if subject == "" && msg != nil {
-		subject = strings.TrimSpace(msg.ChannelID)
+}
+
+// OnEvent records the outcome of exposed runs.
+func (r *experimentRecorder) OnEvent(_ context.Context, e models.AgentEvent) {
+	if r == nil || e.RunID == "" {
+		return
+	}
+	switch e.Type {
+	case models.AgentEventRunError, models.AgentEventRunTimedOut, models.AgentEventRunCancelled:
+		r.mu.Lock()
+		if run := r.runs[e.RunID]; run != nil {
+			run.failed = true
+		}
+		r.mu.Unlock()
+	case models.AgentEventRunFinished:
+		r.mu.Lock()
+		run := r.runs[e.RunID]
+		delete(r.runs, e.RunID)
+		r.mu.Unlock()
+		if run == nil {
+			return
+		}
+		record := experiments.Record{
+			Type:      experiments.RecordOutcome,
+			Time:      time.Now(),
+			RunID:     e.RunID,
+			SessionID: run.sessionID,
+			Channel:   run.channel,
+			Failed:    run.failed,
+		}
+		if e.Stats != nil && e.Stats.Run != nil {
+			stats := e.Stats.Run
+			record.DurationMs = stats.WallTime.Milliseconds()
+			record.InputTokens = stats.InputTokens
+			record.OutputTokens = stats.OutputTokens
+			record.ToolCalls = stats.ToolCalls
+			record.Errors = stats.Errors
+			record.Failed = record.Failed || stats.Cancelled || stats.TimedOut
+		}
+		if err := r.log.Append(record); err != nil {
+			r.logger.Warn("failed to log experiment outcome", "run_id", e.RunID, "error", err)
+		}
 	}
-	return s.experimentsMgr.Resolve(subject)
 }
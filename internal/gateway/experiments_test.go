@@ -0,0 +1,64 @@
+package gateway
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/haasonsaas/nexus/internal/agent"
+	"github.com/haasonsaas/nexus/internal/experiments"
+	"github.com/haasonsaas/nexus/pkg/models"
+)
+
+func TestWithExperimentsLogsExposureAndOutcome(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	log := experiments.NewLog(filepath.Join(t.TempDir(), "experiments.jsonl"))
+	server := &Server{
+		logger: logger,
+		experimentsMgr: experiments.NewManager(experiments.Config{
+			Experiments: []experiments.Experiment{{
+				ID:         "model-ab",
+				Status:     "active",
+				Allocation: 100,
+				Variants: []experiments.Variant{
+					{ID: "only", Weight: 1, Config: experiments.VariantConfig{Model: "model-b"}},
+				},
+			}},
+		}),
+		experimentRecorder: newExperimentRecorder(log, logger),
+	}
+	session := &models.Session{ID: "s1", Metadata: map[string]any{"user_id": "u1"}}
+	msg := &models.Message{ID: "m1", Channel: models.ChannelSlack}
+
+	ctx := server.withExperiments(context.Background(), session, msg)
+	if got := agent.TraceTagsFromContext(ctx)["experiment.model-ab"]; got != "only" {
+		t.Fatalf("trace tag = %q, want only", got)
+	}
+
+	server.experimentRecorder.OnEvent(ctx, models.AgentEvent{Type: models.AgentEventRunError, RunID: "s1-m1"})
+	server.experimentRecorder.OnEvent(ctx, models.AgentEvent{
+		Type:  models.AgentEventRunFinished,
+		RunID: "s1-m1",
+		Stats: &models.StatsEventPayload{Run: &models.RunStats{WallTime: 2 * time.Second, InputTokens: 10, ToolCalls: 1}},
+	})
+	// Runs without an exposure are not logged.
+	server.experimentRecorder.OnEvent(ctx, models.AgentEvent{Type: models.AgentEventRunFinished, RunID: "other"})
+
+	records, err := log.Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if len(records) != 2 {
+		t.Fatalf("expected exposure and outcome, got %+v", records)
+	}
+	exposure, outcome := records[0], records[1]
+	if exposure.Type != experiments.RecordExposure || exposure.RunID != "s1-m1" || exposure.VariantID != "only" || exposure.Subject != "u1" {
+		t.Fatalf("unexpected exposure: %+v", exposure)
+	}
+	if outcome.Type != experiments.RecordOutcome || outcome.DurationMs != 2000 || outcome.InputTokens != 10 || !outcome.Failed {
+		t.Fatalf("unexpected outcome: %+v", outcome)
+	}
+}
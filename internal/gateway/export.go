@@ -0,0 +1,53 @@
+package gateway
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/haasonsaas/nexus/internal/transcript"
+	"github.com/haasonsaas/nexus/pkg/models"
+)
+
+// exportSession renders the session transcript and sends it back to the
+// conversation as a document attachment.
+func (s *Server) exportSession(ctx context.Context, session *models.Session, inbound *models.Message, formatName string) {
+	if s.sessions == nil || session == nil || inbound == nil {
+		return
+	}
+	format, err := transcript.ParseFormat(formatName)
+	if err != nil {
+		s.sendImmediateReply(ctx, session, inbound, err.Error())
+		return
+	}
+	history, err := s.sessions.GetHistory(ctx, session.ID, 0)
+	if err != nil {
+		s.logger.Error("failed to load session history for export", "session_id", session.ID, "error", err)
+		s.sendImmediateReply(ctx, session, inbound, "Export failed: could not load conversation history.")
+		return
+	}
+	if len(history) == 0 {
+		s.sendImmediateReply(ctx, session, inbound, "Nothing to export yet.")
+		return
+	}
+
+	doc := transcript.New(session, history, s.config)
+	data, err := doc.Bytes(format)
+	if err != nil {
+		s.logger.Error("failed to render transcript", "session_id", session.ID, "error", err)
+		s.sendImmediateReply(ctx, session, inbound, "Export failed: could not render transcript.")
+		return
+	}
+	attachment := models.Attachment{
+		ID:       uuid.NewString(),
+		Type:     "document",
+		Filename: doc.Filename(format),
+		MimeType: format.MimeType(),
+		Size:     int64(len(data)),
+		URL:      "data:" + format.MimeType() + ";base64," + base64.StdEncoding.EncodeToString(data),
+	}
+	caption := fmt.Sprintf("Conversation export: %d messages, %d tool calls, %d tokens",
+		doc.Summary.Messages, doc.Summary.ToolCalls, doc.Summary.InputTokens+doc.Summary.OutputTokens)
+	s.sendImmediateReplyWithAttachments(ctx, session, inbound, caption, []models.Attachment{attachment})
+}
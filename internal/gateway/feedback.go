@@ -0,0 +1,99 @@
+package gateway
+
+import (
+	"context"
+	"strings"
+
+	"github.com/haasonsaas/nexus/internal/config"
+	"github.com/haasonsaas/nexus/internal/feedback"
+	"github.com/haasonsaas/nexus/pkg/models"
+)
+
+// setupFeedback creates the reaction feedback recorder, or returns nil when
+// feedback capture is disabled.
+func setupFeedback(cfg config.FeedbackConfig) (*feedback.Recorder, error) {
+	if !cfg.Enabled {
+		return nil, nil
+	}
+	path := strings.TrimSpace(cfg.StorePath)
+	if path == "" {
+		path = feedback.DefaultStorePath()
+	}
+	recorder := feedback.NewRecorder(
+		feedback.NewStore(path),
+		feedback.NewClassifier(cfg.PositiveReactions, cfg.NegativeReactions),
+		feedback.NewMetrics(),
+	)
+	if err := recorder.Load(); err != nil {
+		return nil, err
+	}
+	return recorder, nil
+}
+
+// startFeedbackCapture consumes reactions from channel adapters and records
+// those on tracked agent replies as run feedback.
+func (s *Server) startFeedbackCapture(ctx context.Context) {
+	if s == nil || s.feedbackRecorder == nil || s.channels == nil {
+		return
+	}
+	reactions := s.channels.AggregateReactions(ctx)
+
+	s.goSupervised(ctx, "worker:feedback_capture", func(ctx context.Context) {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case event, ok := <-reactions:
+				if !ok {
+					return
+				}
+				entry, err := s.feedbackRecorder.Record(event)
+				if err != nil {
+					s.logger.Warn("failed to record feedback", "channel", event.Channel, "error", err)
+					continue
+				}
+				if entry != nil {
+					s.logger.Debug("recorded feedback",
+						"run_id", entry.RunID,
+						"channel", entry.Channel,
+						"rating", entry.Rating.String(),
+						"removed", entry.Removed)
+				}
+			}
+		}
+	})
+}
+
+// trackFeedbackTarget remembers which run produced a delivered reply so that
+// reactions on it can be attributed.
+func (s *Server) trackFeedbackTarget(session *models.Session, inbound *models.Message, runID, deliveredID string) {
+	if s.feedbackRecorder == nil || session == nil || inbound == nil || deliveredID == "" {
+		return
+	}
+	s.feedbackRecorder.Track(string(inbound.Channel), feedbackChatID(inbound), deliveredID, feedback.Target{
+		RunID:     runID,
+		SessionID: session.ID,
+	})
+}
+
+// feedbackChatID returns the platform chat the reply was sent to, matching
+// the ChatID adapters report on reaction events.
+func feedbackChatID(msg *models.Message) string {
+	if msg.Metadata == nil {
+		return ""
+	}
+	switch msg.Channel {
+	case models.ChannelTelegram:
+		if id, ok := msg.Metadata[MetaChatID].(string); ok && id != "" {
+			return id
+		}
+		return stringifyID(msg.Metadata["chat_id"])
+	case models.ChannelSlack:
+		id, _ := msg.Metadata["slack_channel"].(string)
+		return id
+	case models.ChannelDiscord:
+		id, _ := msg.Metadata["discord_channel_id"].(string)
+		return id
+	}
+	return ""
+}
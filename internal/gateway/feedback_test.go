@@ -0,0 +1,88 @@
+package gateway
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/haasonsaas/nexus/internal/agent"
+	"github.com/haasonsaas/nexus/internal/channels"
+	"github.com/haasonsaas/nexus/internal/config"
+	"github.com/haasonsaas/nexus/internal/feedback"
+	"github.com/haasonsaas/nexus/pkg/models"
+)
+
+// sentIDAdapter records outbound messages and assigns platform message IDs
+// the way real adapters do after sending.
+type sentIDAdapter struct {
+	recordingAdapter
+}
+
+func (a *sentIDAdapter) Send(ctx context.Context, msg *models.Message) error {
+	msg.ChannelID = "99"
+	return a.recordingAdapter.Send(ctx, msg)
+}
+
+func TestHandleMessageTracksRepliesForFeedback(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	storePath := filepath.Join(t.TempDir(), "feedback.jsonl")
+	cfg := &config.Config{
+		Session: config.SessionConfig{DefaultAgentID: "agent-test"},
+		Observability: config.ObservabilityConfig{
+			Feedback: config.FeedbackConfig{Enabled: true, StorePath: storePath},
+		},
+	}
+	server, err := NewServer(cfg, logger)
+	if err != nil {
+		t.Fatalf("NewServer() error = %v", err)
+	}
+	if server.feedbackRecorder == nil {
+		t.Fatal("expected feedback recorder when feedback is enabled")
+	}
+
+	store := &recordingStore{}
+	server.sessions = store
+	server.runtime = agent.NewRuntime(fixedProvider{}, store)
+	adapter := &sentIDAdapter{}
+	registry := channels.NewRegistry()
+	registry.Register(adapter)
+	server.channels = registry
+
+	server.handleMessage(context.Background(), &models.Message{
+		ID:        "tg_1",
+		Channel:   models.ChannelTelegram,
+		Direction: models.DirectionInbound,
+		Role:      models.RoleUser,
+		Content:   "ping",
+		Metadata:  map[string]any{"chat_id": int64(123)},
+		CreatedAt: time.Now(),
+	})
+	if len(adapter.messages) != 1 {
+		t.Fatalf("expected 1 outbound message, got %d", len(adapter.messages))
+	}
+
+	entry, err := server.feedbackRecorder.Record(&channels.ReactionEvent{
+		Channel:   models.ChannelTelegram,
+		ChatID:    "123",
+		MessageID: "99",
+		UserID:    "42",
+		Emoji:     "👎",
+	})
+	if err != nil {
+		t.Fatalf("Record() error = %v", err)
+	}
+	if entry == nil {
+		t.Fatal("expected reaction on the reply to be recorded")
+	}
+	if entry.RunID != "session-1-tg_1" || entry.SessionID != "session-1" || entry.Rating != feedback.RatingNegative {
+		t.Fatalf("unexpected feedback entry: %+v", entry)
+	}
+
+	entries, err := feedback.NewStore(storePath).Load()
+	if err != nil || len(entries) != 1 {
+		t.Fatalf("expected 1 stored entry, got %d, %v", len(entries), err)
+	}
+}
@@ -0,0 +1,135 @@
+package gateway
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/haasonsaas/nexus/internal/config"
+	"github.com/haasonsaas/nexus/internal/gitops"
+	"github.com/haasonsaas/nexus/internal/plugins"
+)
+
+// gitOpsSync follows the config file in git. It remembers what it last
+// reported so each commit and drift is logged once rather than every poll.
+type gitOpsSync struct {
+	fetcher *gitops.Fetcher
+	source  gitops.Source
+	mode    string
+
+	reported string
+}
+
+// startGitOpsSync polls the configured git ref and keeps the local config
+// file in line with it. Every node syncs its own file, so no lease is
+// needed.
+func (s *Server) startGitOpsSync(ctx context.Context) {
+	if s == nil || s.config == nil || !s.config.GitOps.Enabled {
+		return
+	}
+	cfg := s.config.GitOps
+	if strings.TrimSpace(s.configPath) == "" {
+		s.logger.Warn("gitops: disabled (config path not configured)")
+		return
+	}
+	sync := &gitOpsSync{
+		fetcher: gitops.NewFetcher(cfg.CacheDir),
+		source:  gitops.Source{Repo: cfg.Repo, Ref: cfg.Ref, Path: cfg.Path},
+		mode:    cfg.Mode,
+	}
+	interval := cfg.Interval
+	if interval <= 0 {
+		interval = time.Minute
+	}
+
+	s.goSupervised(ctx, "worker:gitops", func(ctx context.Context) {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			s.syncGitOps(ctx, sync)
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	})
+}
+
+// syncGitOps fetches the config from git, validates it, and either applies
+// it or reports how the local file drifted from it.
+func (s *Server) syncGitOps(ctx context.Context, sync *gitOpsSync) {
+	snapshot, err := sync.fetcher.Fetch(ctx, sync.source)
+	if err != nil {
+		if ctx.Err() == nil {
+			s.logger.Warn("gitops: fetch failed", "source", sync.source.String(), "error", err)
+		}
+		return
+	}
+	local, err := os.ReadFile(s.configPath)
+	if err != nil && !os.IsNotExist(err) {
+		s.logger.Warn("gitops: read local config failed", "path", s.configPath, "error", err)
+		return
+	}
+	if bytes.Equal(local, snapshot.Raw) {
+		if sync.reported != "" {
+			s.logger.Info("gitops: config in sync", "commit", snapshot.ShortCommit())
+			sync.reported = ""
+		}
+		return
+	}
+
+	// Report each commit and local state once until either changes.
+	hash := sha256.Sum256(local)
+	key := snapshot.Commit + ":" + hex.EncodeToString(hash[:])
+	if key == sync.reported {
+		return
+	}
+	sync.reported = key
+
+	changes, err := gitops.Drift(local, snapshot.Raw)
+	if err != nil {
+		s.logger.Warn("gitops: compare configs failed", "commit", snapshot.ShortCommit(), "error", err)
+		return
+	}
+	cfg, err := snapshot.Load(filepath.Dir(s.configPath))
+	if err == nil {
+		err = plugins.ValidateConfig(cfg)
+	}
+	if err != nil {
+		s.logger.Warn("gitops: config in git is invalid; keeping the local config",
+			"commit", snapshot.ShortCommit(), "error", err)
+		return
+	}
+
+	if sync.mode == config.GitOpsModeReport {
+		s.logger.Warn("gitops: local config drifted from git",
+			"commit", snapshot.ShortCommit(),
+			"path", s.configPath,
+			"changed", gitops.DriftPaths(changes),
+		)
+		return
+	}
+
+	result, err := s.ApplyConfig(ctx, string(snapshot.Raw), "")
+	if err != nil {
+		s.logger.Warn("gitops: apply failed", "commit", snapshot.ShortCommit(), "error", err)
+		return
+	}
+	// Applied changes are no longer drift; only restart-required sections
+	// still differ from what is running.
+	sync.reported = ""
+	s.logger.Info("gitops: applied config",
+		"commit", snapshot.ShortCommit(),
+		"changed", gitops.DriftPaths(changes),
+		"restart_required", result.RestartRequired,
+	)
+	for _, warning := range result.Warnings {
+		s.logger.Warn("gitops: running config drifted from git", "commit", snapshot.ShortCommit(), "reason", warning)
+	}
+}
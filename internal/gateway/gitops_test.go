@@ -0,0 +1,107 @@
+package gateway
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/haasonsaas/nexus/internal/config"
+	"github.com/haasonsaas/nexus/internal/gitops"
+)
+
+const gitOpsTestConfig = `version: 1
+llm:
+  default_provider: anthropic
+  providers:
+    anthropic: {}
+`
+
+func gitOpsTestRepo(t *testing.T, content string) string {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "nexus.yaml"), []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	for _, args := range [][]string{
+		{"init", "--quiet"},
+		{"add", "-A"},
+		{"-c", "user.name=test", "-c", "user.email=test@example.com", "commit", "--quiet", "-m", "config"},
+	} {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v: %s", args, err, out)
+		}
+	}
+	return "file://" + dir
+}
+
+func TestSyncGitOpsReportsDriftOnce(t *testing.T) {
+	repo := gitOpsTestRepo(t, gitOpsTestConfig+"server:\n  http_port: 9000\n")
+	configPath := filepath.Join(t.TempDir(), "nexus.yaml")
+	if err := os.WriteFile(configPath, []byte(gitOpsTestConfig), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	var logs bytes.Buffer
+	server := &Server{
+		config:     &config.Config{},
+		configPath: configPath,
+		logger:     slog.New(slog.NewTextHandler(&logs, nil)),
+	}
+	sync := &gitOpsSync{
+		fetcher: gitops.NewFetcher(t.TempDir()),
+		source:  gitops.Source{Repo: repo, Path: "nexus.yaml"},
+		mode:    config.GitOpsModeReport,
+	}
+
+	server.syncGitOps(context.Background(), sync)
+	server.syncGitOps(context.Background(), sync)
+
+	if got := strings.Count(logs.String(), "local config drifted from git"); got != 1 {
+		t.Fatalf("expected one drift report, got %d:\n%s", got, logs.String())
+	}
+	if !strings.Contains(logs.String(), "server") {
+		t.Fatalf("drift report should name the changed section:\n%s", logs.String())
+	}
+	local, err := os.ReadFile(configPath)
+	if err != nil || string(local) != gitOpsTestConfig {
+		t.Fatalf("report mode must not touch the local config: %q, %v", local, err)
+	}
+}
+
+func TestSyncGitOpsKeepsLocalConfigWhenGitIsInvalid(t *testing.T) {
+	repo := gitOpsTestRepo(t, gitOpsTestConfig+"gitops:\n  mode: sometimes\n")
+	configPath := filepath.Join(t.TempDir(), "nexus.yaml")
+	if err := os.WriteFile(configPath, []byte(gitOpsTestConfig), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	var logs bytes.Buffer
+	server := &Server{
+		config:     &config.Config{},
+		configPath: configPath,
+		logger:     slog.New(slog.NewTextHandler(&logs, nil)),
+	}
+	sync := &gitOpsSync{
+		fetcher: gitops.NewFetcher(t.TempDir()),
+		source:  gitops.Source{Repo: repo, Path: "nexus.yaml"},
+		mode:    config.GitOpsModeApply,
+	}
+
+	server.syncGitOps(context.Background(), sync)
+
+	if !strings.Contains(logs.String(), "config in git is invalid") {
+		t.Fatalf("expected an invalid config warning:\n%s", logs.String())
+	}
+	local, err := os.ReadFile(configPath)
+	if err != nil || string(local) != gitOpsTestConfig {
+		t.Fatalf("invalid config must not be applied: %q, %v", local, err)
+	}
+}
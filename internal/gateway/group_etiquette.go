@@ -0,0 +1,295 @@
+package gateway
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/haasonsaas/nexus/internal/agent"
+	"github.com/haasonsaas/nexus/internal/channels"
+	"github.com/haasonsaas/nexus/internal/config"
+	"github.com/haasonsaas/nexus/pkg/models"
+)
+
+const (
+	// metaQuietUntil is the session metadata key holding the end of a /quiet
+	// period (RFC3339).
+	metaQuietUntil = "quiet_until"
+
+	// interjectHistoryWindow is how many recent messages the relevance
+	// classifier sees.
+	interjectHistoryWindow = 10
+
+	interjectClassifierTimeout = 15 * time.Second
+)
+
+const interjectSystemPrompt = `You decide whether an assistant in a group chat should join the conversation without being asked.
+Rate how useful a reply from the assistant to the latest message would be, from 0 (stay silent: small talk, people talking to each other, nothing to add) to 1 (clearly helpful: an open question or a problem the assistant can answer).
+Reply with the number only.`
+
+// groupEtiquette holds the per-group state behind the group chat settings
+// of the channel policy blocks.
+type groupEtiquette struct {
+	mu        sync.Mutex
+	lastReply map[string]time.Time
+	patterns  map[string]*regexp.Regexp
+
+	// roll returns a number in [0,1) for interject sampling; nil uses math/rand.
+	roll func() float64
+}
+
+// cooling reports whether the group replied within cooldown.
+func (g *groupEtiquette) cooling(key string, cooldown time.Duration, now time.Time) bool {
+	if cooldown <= 0 {
+		return false
+	}
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	last, ok := g.lastReply[key]
+	return ok && now.Sub(last) < cooldown
+}
+
+func (g *groupEtiquette) recordReply(key string, now time.Time) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.lastReply == nil {
+		g.lastReply = make(map[string]time.Time)
+	}
+	g.lastReply[key] = now
+}
+
+func (g *groupEtiquette) sample() float64 {
+	if g.roll != nil {
+		return g.roll()
+	}
+	return rand.Float64() //nolint:gosec // sampling, not security sensitive
+}
+
+// matches reports whether content matches one of the mention patterns.
+func (g *groupEtiquette) matches(patterns []string, content string) bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	for _, pattern := range patterns {
+		re, ok := g.patterns[pattern]
+		if !ok {
+			// Patterns are validated on load; a nil entry skips a bad one.
+			re, _ = regexp.Compile("(?i)" + pattern) //nolint:errcheck
+			if g.patterns == nil {
+				g.patterns = make(map[string]*regexp.Regexp)
+			}
+			g.patterns[pattern] = re
+		}
+		if re != nil && re.MatchString(content) {
+			return true
+		}
+	}
+	return false
+}
+
+// shouldRespondInGroup applies the group etiquette of the channel's group
+// policy block and reports whether the agent should reply to msg. Messages
+// it declines are still added to the session history so the agent has the
+// conversation when it is addressed later.
+func (s *Server) shouldRespondInGroup(ctx context.Context, session *models.Session, msg *models.Message) bool {
+	if conversationTypeForMessage(msg) != "group" {
+		return true
+	}
+	now := time.Now()
+	if until, ok := sessionQuietUntil(session); ok && now.Before(until) {
+		s.skipGroupMessage(ctx, session, msg, "quiet")
+		return false
+	}
+	if isHeartbeatMessage(msg) {
+		return true
+	}
+	policyCfg, ok := s.channelPolicyConfig(msg.Channel, "group")
+	if !ok {
+		return true
+	}
+
+	key := string(msg.Channel) + ":" + extractGroupID(msg)
+	addressed := channels.WasMentioned(msg) || s.groupEtiquette.matches(policyCfg.MentionPatterns, msg.Content)
+	if !addressed {
+		activation := strings.ToLower(strings.TrimSpace(policyCfg.Activation))
+		if activation == "mention" {
+			s.skipGroupMessage(ctx, session, msg, "not_mentioned")
+			return false
+		}
+		if s.groupEtiquette.cooling(key, policyCfg.Cooldown, now) {
+			s.skipGroupMessage(ctx, session, msg, "cooldown")
+			return false
+		}
+		if activation == "interject" && !s.shouldInterject(ctx, session, msg, policyCfg.Interject) {
+			s.skipGroupMessage(ctx, session, msg, "not_relevant")
+			return false
+		}
+	}
+	s.groupEtiquette.recordReply(key, now)
+	return true
+}
+
+// shouldInterject samples unaddressed messages and asks the relevance
+// classifier whether a reply would help.
+func (s *Server) shouldInterject(ctx context.Context, session *models.Session, msg *models.Message, cfg config.GroupInterjectConfig) bool {
+	if s.groupEtiquette.sample() >= cfg.Probability {
+		return false
+	}
+	score, err := s.classifyRelevance(ctx, session, msg, cfg.Model)
+	if err != nil {
+		s.logger.Debug("interject classifier failed", "session_id", session.ID, "error", err)
+		return false
+	}
+	return score >= cfg.MinRelevance
+}
+
+// classifyRelevance rates from 0 to 1 how useful an unprompted reply to msg
+// would be, given the recent conversation.
+func (s *Server) classifyRelevance(ctx context.Context, session *models.Session, msg *models.Message, model string) (float64, error) {
+	var sb strings.Builder
+	if history, err := s.sessions.GetHistory(ctx, session.ID, interjectHistoryWindow); err == nil && len(history) > 0 {
+		sb.WriteString("Recent conversation:\n")
+		for _, m := range history {
+			if m == nil || strings.TrimSpace(m.Content) == "" {
+				continue
+			}
+			speaker := "assistant"
+			if m.Role == models.RoleUser {
+				speaker = extractSenderName(m)
+				if speaker == "" {
+					speaker = "user"
+				}
+			}
+			fmt.Fprintf(&sb, "%s: %s\n", speaker, truncateContent(strings.TrimSpace(m.Content), 500))
+		}
+		sb.WriteString("\n")
+	}
+	sender := extractSenderName(msg)
+	if sender == "" {
+		sender = "user"
+	}
+	fmt.Fprintf(&sb, "Latest message:\n%s: %s", sender, truncateContent(strings.TrimSpace(msg.Content), 2000))
+
+	if strings.TrimSpace(model) == "" {
+		model = sessionModelOverride(session)
+	}
+	if model == "" {
+		model = s.defaultModel
+	}
+	ctx, cancel := context.WithTimeout(ctx, interjectClassifierTimeout)
+	defer cancel()
+	text, err := collectCompletion(ctx, s.llmProvider, &agent.CompletionRequest{
+		Model:     model,
+		System:    interjectSystemPrompt,
+		Messages:  []agent.CompletionMessage{{Role: "user", Content: sb.String()}},
+		MaxTokens: 8,
+	})
+	if err != nil {
+		return 0, err
+	}
+	return parseRelevanceScore(text)
+}
+
+var relevanceScorePattern = regexp.MustCompile(`\d+(?:\.\d+)?`)
+
+func parseRelevanceScore(text string) (float64, error) {
+	match := relevanceScorePattern.FindString(text)
+	if match == "" {
+		return 0, fmt.Errorf("no score in classifier reply %q", truncateContent(text, 100))
+	}
+	score, err := strconv.ParseFloat(match, 64)
+	if err != nil {
+		return 0, err
+	}
+	if score > 1 {
+		score = 1
+	}
+	return score, nil
+}
+
+// skipGroupMessage stores a message the agent will not reply to.
+func (s *Server) skipGroupMessage(ctx context.Context, session *models.Session, msg *models.Message, reason string) {
+	s.logger.Debug("group message not answered",
+		"channel", msg.Channel,
+		"session_id", session.ID,
+		"reason", reason,
+	)
+	if isHeartbeatMessage(msg) {
+		return
+	}
+	if err := s.sessions.AppendMessage(ctx, session.ID, msg); err != nil {
+		s.logger.Debug("failed to store unanswered group message", "session_id", session.ID, "error", err)
+	}
+}
+
+func sessionQuietUntil(session *models.Session) (time.Time, bool) {
+	if session == nil || session.Metadata == nil {
+		return time.Time{}, false
+	}
+	raw, ok := session.Metadata[metaQuietUntil].(string)
+	if !ok || raw == "" {
+		return time.Time{}, false
+	}
+	until, err := time.Parse(time.RFC3339, raw)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return until, true
+}
+
+// applyQuietCommand handles /quiet for the session.
+func (s *Server) applyQuietCommand(ctx context.Context, session *models.Session, msg *models.Message, op, value string) {
+	now := time.Now()
+	switch op {
+	case "status":
+		if until, ok := sessionQuietUntil(session); ok && now.Before(until) {
+			s.sendImmediateReply(ctx, session, msg, fmt.Sprintf("Quiet for another %s. Use /quiet off to resume.", until.Sub(now).Round(time.Minute)))
+		} else {
+			s.sendImmediateReply(ctx, session, msg, "Not quiet. Use /quiet <duration> (e.g. /quiet 1h) to pause replies here.")
+		}
+		return
+	case "off":
+		if session.Metadata != nil {
+			delete(session.Metadata, metaQuietUntil)
+		}
+	case "set":
+		duration, err := time.ParseDuration(value)
+		if err != nil {
+			return
+		}
+		if session.Metadata == nil {
+			session.Metadata = map[string]any{}
+		}
+		session.Metadata[metaQuietUntil] = now.Add(duration).UTC().Format(time.RFC3339)
+	default:
+		return
+	}
+	if err := s.sessions.Update(ctx, session); err != nil {
+		s.logger.Error("failed to update quiet period", "session_id", session.ID, "error", err)
+		s.sendImmediateReply(ctx, session, msg, "Failed to update the quiet period.")
+		return
+	}
+	if op == "off" {
+		s.sendImmediateReply(ctx, session, msg, "Back to normal; replying here again.")
+		return
+	}
+	duration, _ := time.ParseDuration(value) //nolint:errcheck // parsed above
+	s.sendImmediateReply(ctx, session, msg, fmt.Sprintf("Going quiet here for %s. Commands still work; /quiet off resumes.", formatQuietDuration(duration)))
+}
+
+// formatQuietDuration drops the zero units of whole durations ("1h0m0s"
+// becomes "1h").
+func formatQuietDuration(d time.Duration) string {
+	text := d.String()
+	if strings.HasSuffix(text, "m0s") {
+		text = strings.TrimSuffix(text, "0s")
+	}
+	if strings.HasSuffix(text, "h0m") {
+		text = strings.TrimSuffix(text, "0m")
+	}
+	return text
+}
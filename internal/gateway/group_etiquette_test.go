@@ -0,0 +1,189 @@
+package gateway
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/haasonsaas/nexus/internal/agent"
+	"github.com/haasonsaas/nexus/internal/channels"
+	"github.com/haasonsaas/nexus/internal/config"
+	"github.com/haasonsaas/nexus/internal/sessions"
+	"github.com/haasonsaas/nexus/pkg/models"
+)
+
+// etiquetteProvider scores relevance for the interject classifier and
+// answers everything else.
+type etiquetteProvider struct {
+	mu          sync.Mutex
+	score       string
+	classified  int
+	replies     int
+	lastRequest *agent.CompletionRequest
+}
+
+func (p *etiquetteProvider) Complete(ctx context.Context, req *agent.CompletionRequest) (<-chan *agent.CompletionChunk, error) {
+	p.mu.Lock()
+	text := "sure"
+	if req.System == interjectSystemPrompt {
+		p.classified++
+		text = p.score
+	} else {
+		p.replies++
+		p.lastRequest = req
+	}
+	p.mu.Unlock()
+	ch := make(chan *agent.CompletionChunk, 2)
+	ch <- &agent.CompletionChunk{Text: text}
+	ch <- &agent.CompletionChunk{Done: true}
+	close(ch)
+	return ch, nil
+}
+
+func (p *etiquetteProvider) Name() string { return "etiquette" }
+
+func (p *etiquetteProvider) Models() []agent.Model { return nil }
+
+func (p *etiquetteProvider) SupportsTools() bool { return false }
+
+func (p *etiquetteProvider) counts() (int, int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.classified, p.replies
+}
+
+func newEtiquetteServer(t *testing.T, group config.ChannelPolicyConfig) (*Server, *etiquetteProvider, sessions.Store) {
+	t.Helper()
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	cfg := &config.Config{
+		Session:  config.SessionConfig{DefaultAgentID: "main"},
+		Channels: config.ChannelsConfig{Telegram: config.TelegramConfig{Group: group}},
+	}
+	server, err := NewServer(cfg, logger)
+	if err != nil {
+		t.Fatalf("NewServer() error = %v", err)
+	}
+	store := sessions.NewMemoryStore()
+	provider := &etiquetteProvider{score: "0.9"}
+	server.sessions = store
+	server.runtime = agent.NewRuntime(provider, store)
+	server.llmProvider = provider
+	registry := channels.NewRegistry()
+	registry.Register(&recordingAdapter{})
+	server.channels = registry
+	return server, provider, store
+}
+
+func groupMessage(id, content string, mentioned bool) *models.Message {
+	msg := &models.Message{
+		ID:        id,
+		Channel:   models.ChannelTelegram,
+		ChannelID: id,
+		Direction: models.DirectionInbound,
+		Role:      models.RoleUser,
+		Content:   content,
+		Metadata:  map[string]any{"chat_id": int64(-100), "chat_type": "group", "user_id": int64(7)},
+	}
+	if mentioned {
+		channels.SetMentioned(msg)
+	}
+	return msg
+}
+
+func TestGroupEtiquetteMentionActivation(t *testing.T) {
+	ctx := context.Background()
+	server, provider, store := newEtiquetteServer(t, config.ChannelPolicyConfig{
+		Activation:      "mention",
+		MentionPatterns: []string{`\bnexus\b`},
+	})
+
+	server.handleMessage(ctx, groupMessage("m1", "lunch at noon?", false))
+	if _, replies := provider.counts(); replies != 0 {
+		t.Fatalf("expected no reply to an unaddressed message, got %d", replies)
+	}
+	server.handleMessage(ctx, groupMessage("m2", "what do you think?", true))
+	server.handleMessage(ctx, groupMessage("m3", "Nexus, book a table", false))
+	if _, replies := provider.counts(); replies != 2 {
+		t.Fatalf("expected replies to the mention and the name pattern, got %d", replies)
+	}
+
+	sessionList, err := store.List(ctx, "", sessions.ListOptions{})
+	if err != nil || len(sessionList) != 1 {
+		t.Fatalf("expected one group session, got %d (%v)", len(sessionList), err)
+	}
+	history, err := store.GetHistory(ctx, sessionList[0].ID, 20)
+	if err != nil {
+		t.Fatalf("GetHistory() error = %v", err)
+	}
+	if len(history) == 0 || history[0].Content != "lunch at noon?" {
+		t.Fatalf("expected the unanswered message in the history, got %+v", history)
+	}
+}
+
+func TestGroupEtiquetteCooldownAndQuiet(t *testing.T) {
+	ctx := context.Background()
+	server, provider, _ := newEtiquetteServer(t, config.ChannelPolicyConfig{Cooldown: time.Hour})
+
+	server.handleMessage(ctx, groupMessage("m1", "morning all", false))
+	server.handleMessage(ctx, groupMessage("m2", "anyone around?", false))
+	if _, replies := provider.counts(); replies != 1 {
+		t.Fatalf("expected the cooldown to hold back the second reply, got %d replies", replies)
+	}
+	server.handleMessage(ctx, groupMessage("m3", "summarize this", true))
+	if _, replies := provider.counts(); replies != 2 {
+		t.Fatalf("expected mentions to bypass the cooldown, got %d replies", replies)
+	}
+
+	server.handleMessage(ctx, groupMessage("m4", "/quiet 1h", false))
+	server.handleMessage(ctx, groupMessage("m5", "still there?", true))
+	if _, replies := provider.counts(); replies != 2 {
+		t.Fatalf("expected no replies while quiet, got %d", replies)
+	}
+	server.handleMessage(ctx, groupMessage("m6", "/quiet off", false))
+	server.handleMessage(ctx, groupMessage("m7", "now?", true))
+	if _, replies := provider.counts(); replies != 3 {
+		t.Fatalf("expected replies after /quiet off, got %d", replies)
+	}
+}
+
+func TestGroupEtiquetteInterject(t *testing.T) {
+	ctx := context.Background()
+	server, provider, _ := newEtiquetteServer(t, config.ChannelPolicyConfig{
+		Activation: "interject",
+		Interject:  config.GroupInterjectConfig{Probability: 0.5, MinRelevance: 0.7},
+	})
+	roll := 0.9
+	server.groupEtiquette.roll = func() float64 { return roll }
+
+	server.handleMessage(ctx, groupMessage("m1", "how do I rotate the API key?", false))
+	if classified, replies := provider.counts(); classified != 0 || replies != 0 {
+		t.Fatalf("expected unsampled messages to be skipped, got %d classified, %d replies", classified, replies)
+	}
+
+	roll = 0.1
+	server.handleMessage(ctx, groupMessage("m2", "how do I rotate the API key?", false))
+	if classified, replies := provider.counts(); classified != 1 || replies != 1 {
+		t.Fatalf("expected a relevant interjection, got %d classified, %d replies", classified, replies)
+	}
+
+	provider.score = "0.2"
+	server.handleMessage(ctx, groupMessage("m3", "haha nice", false))
+	if classified, replies := provider.counts(); classified != 2 || replies != 1 {
+		t.Fatalf("expected no reply to an irrelevant message, got %d classified, %d replies", classified, replies)
+	}
+}
+
+func TestParseRelevanceScore(t *testing.T) {
+	for text, want := range map[string]float64{"0.8": 0.8, "Score: 0.35\n": 0.35, "1": 1, "7": 1} {
+		got, err := parseRelevanceScore(text)
+		if err != nil || got != want {
+			t.Errorf("parseRelevanceScore(%q) = %v, %v; want %v", text, got, err, want)
+		}
+	}
+	if _, err := parseRelevanceScore("no idea"); err == nil {
+		t.Error("expected an error without a number")
+	}
+}
@@ -132,7 +132,7 @@ func (g *grpcService) handleSendMessage(ctx context.Context, stream proto.NexusG
 	}
 
 	promptCtx := ctx
-	toolPolicy := g.server.resolveToolPolicy(agentModel, msg)
+	toolPolicy := g.server.resolveToolPolicy(ctx, agentModel, msg)
 	systemPrompt, steeringTrace := g.server.systemPromptForMessage(ctx, session, msg, toolPolicy)
 	if systemPrompt != "" {
 		promptCtx = agent.WithSystemPrompt(promptCtx, systemPrompt)
@@ -140,12 +140,12 @@ func (g *grpcService) handleSendMessage(ctx context.Context, stream proto.NexusG
 	if g.server.toolPolicyResolver != nil && toolPolicy != nil {
 		promptCtx = agent.WithToolPolicy(promptCtx, g.server.toolPolicyResolver, toolPolicy)
 	}
-	if overrides := g.server.experimentOverrides(session, msg); overrides.Model != "" {
-		promptCtx = agent.WithModel(promptCtx, overrides.Model)
-	}
+	promptCtx = g.server.withExperiments(promptCtx, session, msg)
 	if model := sessionModelOverride(session); model != "" {
 		promptCtx = agent.WithModel(promptCtx, model)
 	}
+	promptCtx = g.server.withGenerationProfile(promptCtx, agentModel, msg)
+	promptCtx = withThinking(promptCtx, session, msg)
 
 	runCtx, cancel := context.WithCancel(promptCtx)
 	runToken := g.server.registerActiveRun(session.ID, cancel)
@@ -379,6 +379,9 @@ func (g *grpcService) DeleteSession(ctx context.Context, req *proto.DeleteSessio
 	if err := g.server.sessions.Delete(ctx, req.Id); err != nil {
 		return nil, status.Error(codes.NotFound, "session not found")
 	}
+	g.server.cancelActiveRun(req.Id)
+	g.server.releaseSessionArtifacts(ctx, req.Id)
+	g.server.publishSessionReset(ctx, req.Id, "")
 	return &proto.DeleteSessionResponse{Success: true}, nil
 }
 
@@ -398,6 +401,9 @@ func (g *grpcService) CreateAgent(ctx context.Context, req *proto.CreateAgentReq
 		CreatedAt:    time.Now(),
 		UpdatedAt:    time.Now(),
 	}
+	if err := g.server.validateAgentGeneration(agent); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
 	if err := g.agentStore.Create(ctx, agent); err != nil {
 		if errors.Is(err, storage.ErrAlreadyExists) {
 			return nil, status.Error(codes.AlreadyExists, "agent already exists")
@@ -476,6 +482,9 @@ func (g *grpcService) UpdateAgent(ctx context.Context, req *proto.UpdateAgentReq
 	if req.Config != nil {
 		agent.Config = mapStringToAny(req.Config)
 	}
+	if err := g.server.validateAgentGeneration(agent); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
 	agent.UpdatedAt = time.Now()
 	if err := g.agentStore.Update(ctx, agent); err != nil {
 		if errors.Is(err, storage.ErrNotFound) {
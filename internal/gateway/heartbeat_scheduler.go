@@ -0,0 +1,414 @@
+package gateway
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	agentheartbeat "github.com/haasonsaas/nexus/internal/agents/heartbeat"
+	"github.com/haasonsaas/nexus/internal/channels"
+	"github.com/haasonsaas/nexus/internal/cluster"
+	"github.com/haasonsaas/nexus/internal/config"
+	"github.com/haasonsaas/nexus/internal/cron"
+	"github.com/haasonsaas/nexus/internal/sessions"
+	"github.com/haasonsaas/nexus/internal/supervisor"
+	"github.com/haasonsaas/nexus/pkg/models"
+)
+
+const (
+	// metaHeartbeatSchedule holds a session's /heartbeat override.
+	metaHeartbeatSchedule = "heartbeat_schedule"
+	// metaHeartbeatLastRun records when the last scheduled heartbeat ran.
+	metaHeartbeatLastRun = "heartbeat_last_run"
+
+	// heartbeatHistoryWindow is how many recent messages are searched for the
+	// user's last message.
+	heartbeatHistoryWindow = 50
+
+	defaultHeartbeatPrompt = "heartbeat: Check the heartbeat checklist. Only report new or changed items; reply HEARTBEAT_OK if nothing needs attention."
+)
+
+// heartbeatSchedule is the effective heartbeat schedule for one session.
+type heartbeatSchedule struct {
+	config.HeartbeatScheduleConfig
+	cadence cron.Schedule
+}
+
+// next returns when the heartbeat after last is due.
+func (h heartbeatSchedule) next(last time.Time) (time.Time, bool) {
+	next, ok, err := h.cadence.Next(last)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return next, ok
+}
+
+// quiet reports whether now falls within the schedule's quiet hours.
+func (h heartbeatSchedule) quiet(now time.Time) bool {
+	start := strings.TrimSpace(h.QuietHours.Start)
+	end := strings.TrimSpace(h.QuietHours.End)
+	if start == "" || end == "" {
+		return false
+	}
+	// Quiet hours are the complement of the active window.
+	active := agentheartbeat.ActiveHoursConfig{
+		Enabled:  true,
+		Start:    end,
+		End:      start,
+		Timezone: h.Timezone,
+	}
+	ok, err := active.IsActiveAt(now, "")
+	return err == nil && !ok
+}
+
+// describe summarizes the schedule for /heartbeat status.
+func (h heartbeatSchedule) describe() string {
+	var parts []string
+	if h.Every > 0 {
+		parts = append(parts, "every "+h.Every.String())
+	} else {
+		parts = append(parts, "cron "+h.Cron)
+	}
+	if h.QuietHours.Start != "" && h.QuietHours.End != "" {
+		parts = append(parts, fmt.Sprintf("quiet %s-%s", h.QuietHours.Start, h.QuietHours.End))
+	}
+	if h.Timezone != "" {
+		parts = append(parts, h.Timezone)
+	}
+	if h.SuppressIfActive > 0 {
+		parts = append(parts, "skipped if active within "+h.SuppressIfActive.String())
+	}
+	return strings.Join(parts, ", ")
+}
+
+// startHeartbeatScheduler launches the worker that sends proactive heartbeat
+// runs to sessions on their schedule.
+func (s *Server) startHeartbeatScheduler(ctx context.Context) {
+	if s == nil || s.config == nil || !s.config.Session.Heartbeat.Enabled || s.sessions == nil {
+		return
+	}
+	interval := s.config.Session.Heartbeat.CheckInterval
+	if interval <= 0 {
+		interval = time.Minute
+	}
+
+	s.goSupervised(ctx, "worker:heartbeat", func(ctx context.Context) {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case now := <-ticker.C:
+				if !s.isClusterLeader(cluster.LeaseHeartbeats) {
+					continue
+				}
+				s.runScheduledHeartbeats(ctx, now)
+			}
+		}
+	})
+}
+
+// runScheduledHeartbeats sends a heartbeat to every session that is due.
+// A slot that falls in quiet hours, during an active run, or shortly after
+// the user's last message is skipped rather than deferred, so check-ins never
+// pile up.
+func (s *Server) runScheduledHeartbeats(ctx context.Context, now time.Time) {
+	sessionList, err := s.sessions.List(ctx, "", sessions.ListOptions{})
+	if err != nil {
+		s.logger.Warn("heartbeat: list sessions failed", "error", err)
+		return
+	}
+
+	for _, session := range sessionList {
+		if session == nil {
+			continue
+		}
+		sched, ok := s.resolveHeartbeatSchedule(session)
+		if !ok {
+			continue
+		}
+		due, ok := sched.next(s.lastHeartbeatSlot(session, now))
+		if !ok || now.Before(due) {
+			continue
+		}
+		s.setHeartbeatSlot(session.ID, now)
+
+		template, reason := s.heartbeatTemplate(ctx, session, sched, now)
+		if template == nil {
+			s.logger.Debug("skipping scheduled heartbeat", "session_id", session.ID, "reason", reason)
+			continue
+		}
+		s.dispatchHeartbeat(ctx, session, template, sched, now)
+	}
+}
+
+// heartbeatTemplate returns the user's last message in the session, which
+// supplies the channel metadata needed to deliver the heartbeat reply, or
+// the reason the heartbeat should be skipped.
+func (s *Server) heartbeatTemplate(ctx context.Context, session *models.Session, sched heartbeatSchedule, now time.Time) (*models.Message, string) {
+	if sched.quiet(now) {
+		return nil, "quiet_hours"
+	}
+	if until, ok := sessionQuietUntil(session); ok && now.Before(until) {
+		return nil, "quiet_command"
+	}
+	if s.hasActiveRun(session.ID) {
+		return nil, "active_run"
+	}
+	if _, ok := s.channels.GetOutbound(session.Channel); !ok {
+		return nil, "no_outbound_adapter"
+	}
+	history, err := s.sessions.GetHistory(ctx, session.ID, heartbeatHistoryWindow)
+	if err != nil {
+		return nil, "history_unavailable"
+	}
+	for i := len(history) - 1; i >= 0; i-- {
+		msg := history[i]
+		if msg == nil || msg.Direction != models.DirectionInbound || msg.Role != models.RoleUser || isHeartbeatMessage(msg) {
+			continue
+		}
+		if sched.SuppressIfActive > 0 && now.Sub(msg.CreatedAt) < sched.SuppressIfActive {
+			return nil, "recently_active"
+		}
+		return msg, ""
+	}
+	return nil, "no_user_message"
+}
+
+// dispatchHeartbeat records the run and processes a heartbeat message as if
+// the user had sent it.
+func (s *Server) dispatchHeartbeat(ctx context.Context, session *models.Session, template *models.Message, sched heartbeatSchedule, now time.Time) {
+	if session.Metadata == nil {
+		session.Metadata = map[string]any{}
+	}
+	session.Metadata[metaHeartbeatLastRun] = now.UTC().Format(time.RFC3339)
+	if err := s.sessions.Update(ctx, session); err != nil {
+		s.logger.Debug("failed to record heartbeat run", "session_id", session.ID, "error", err)
+	}
+
+	metadata := make(map[string]any, len(template.Metadata)+1)
+	for key, value := range template.Metadata {
+		metadata[key] = value
+	}
+	metadata["heartbeat"] = true
+	// The heartbeat neither replies to nor mentions anything.
+	delete(metadata, channels.ReplyMetadataKey)
+	delete(metadata, channels.MentionedMetadataKey)
+
+	prompt := strings.TrimSpace(sched.Prompt)
+	if prompt == "" {
+		prompt = defaultHeartbeatPrompt
+	}
+	msg := &models.Message{
+		ID:        fmt.Sprintf("heartbeat-%s-%d", session.ID, now.Unix()),
+		Channel:   template.Channel,
+		ChannelID: template.ChannelID,
+		Direction: models.DirectionInbound,
+		Role:      models.RoleUser,
+		Content:   prompt,
+		Metadata:  metadata,
+		CreatedAt: now,
+	}
+
+	s.logger.Info("running scheduled heartbeat", "session_id", session.ID, "agent_id", session.AgentID)
+	select {
+	case s.messageSem <- struct{}{}:
+	case <-ctx.Done():
+		return
+	}
+	s.wg.Add(1)
+	go func() {
+		defer func() {
+			<-s.messageSem
+			s.wg.Done()
+		}()
+		defer supervisor.Recover("gateway:heartbeat")
+		s.handleMessage(ctx, msg)
+	}()
+}
+
+// resolveHeartbeatSchedule layers the default schedule, the agent and
+// session-key overrides from config, and the session's /heartbeat override.
+func (s *Server) resolveHeartbeatSchedule(session *models.Session) (heartbeatSchedule, bool) {
+	if s.config == nil || session == nil {
+		return heartbeatSchedule{}, false
+	}
+	cfg := s.config.Session.Heartbeat
+	merged := cfg.Schedule
+	if override, ok := cfg.Agents[session.AgentID]; ok {
+		merged = mergeHeartbeatSchedule(merged, override)
+	}
+	if override, ok := cfg.Sessions[session.Key]; ok {
+		merged = mergeHeartbeatSchedule(merged, override)
+	}
+	if override, ok := sessionHeartbeatOverride(session); ok {
+		merged = mergeHeartbeatSchedule(merged, override)
+	}
+	if merged.Disabled || (merged.Every <= 0 && strings.TrimSpace(merged.Cron) == "") {
+		return heartbeatSchedule{}, false
+	}
+	if strings.TrimSpace(merged.Timezone) == "" {
+		merged.Timezone = strings.TrimSpace(s.config.User.Timezone)
+	}
+
+	cadence, err := cron.NewSchedule(config.CronScheduleConfig{
+		Cron:     merged.Cron,
+		Every:    merged.Every,
+		Timezone: merged.Timezone,
+	})
+	if err != nil {
+		s.logger.Warn("invalid heartbeat schedule", "session_id", session.ID, "error", err)
+		return heartbeatSchedule{}, false
+	}
+	return heartbeatSchedule{HeartbeatScheduleConfig: merged, cadence: cadence}, true
+}
+
+// mergeHeartbeatSchedule applies the fields set in override on top of base.
+func mergeHeartbeatSchedule(base, override config.HeartbeatScheduleConfig) config.HeartbeatScheduleConfig {
+	if override.Every > 0 || strings.TrimSpace(override.Cron) != "" {
+		base.Every = override.Every
+		base.Cron = override.Cron
+		base.Disabled = false
+	}
+	if override.Disabled {
+		base.Disabled = true
+	}
+	if override.Timezone != "" {
+		base.Timezone = override.Timezone
+	}
+	if override.QuietHours.Start != "" || override.QuietHours.End != "" {
+		base.QuietHours = override.QuietHours
+	}
+	if override.SuppressIfActive > 0 {
+		base.SuppressIfActive = override.SuppressIfActive
+	}
+	if override.Prompt != "" {
+		base.Prompt = override.Prompt
+	}
+	return base
+}
+
+// sessionHeartbeatOverride reads the schedule set with /heartbeat.
+func sessionHeartbeatOverride(session *models.Session) (config.HeartbeatScheduleConfig, bool) {
+	var override config.HeartbeatScheduleConfig
+	if session == nil || session.Metadata == nil {
+		return override, false
+	}
+	raw, ok := session.Metadata[metaHeartbeatSchedule].(map[string]any)
+	if !ok || len(raw) == 0 {
+		return override, false
+	}
+	override.Disabled, _ = raw["disabled"].(bool)
+	if every, ok := raw["every"].(string); ok {
+		override.Every, _ = time.ParseDuration(every)
+	}
+	override.Cron, _ = raw["cron"].(string)
+	override.QuietHours.Start, _ = raw["quiet_start"].(string)
+	override.QuietHours.End, _ = raw["quiet_end"].(string)
+	return override, true
+}
+
+// lastHeartbeatSlot returns the last heartbeat slot considered for the
+// session. Sessions seen for the first time start their schedule now so a
+// restart does not fire every overdue heartbeat at once.
+func (s *Server) lastHeartbeatSlot(session *models.Session, now time.Time) time.Time {
+	s.heartbeatMu.Lock()
+	defer s.heartbeatMu.Unlock()
+	if last, ok := s.heartbeatSlots[session.ID]; ok {
+		return last
+	}
+	last := now
+	if raw, ok := session.Metadata[metaHeartbeatLastRun].(string); ok {
+		if parsed, err := time.Parse(time.RFC3339, raw); err == nil {
+			last = parsed
+		}
+	}
+	if s.heartbeatSlots == nil {
+		s.heartbeatSlots = make(map[string]time.Time)
+	}
+	s.heartbeatSlots[session.ID] = last
+	return last
+}
+
+func (s *Server) setHeartbeatSlot(sessionID string, at time.Time) {
+	s.heartbeatMu.Lock()
+	defer s.heartbeatMu.Unlock()
+	if s.heartbeatSlots == nil {
+		s.heartbeatSlots = make(map[string]time.Time)
+	}
+	s.heartbeatSlots[sessionID] = at
+}
+
+// applyHeartbeatCommand handles /heartbeat for a session.
+func (s *Server) applyHeartbeatCommand(ctx context.Context, session *models.Session, msg *models.Message, op, value string) {
+	if s.config == nil || !s.config.Session.Heartbeat.Enabled {
+		s.sendImmediateReply(ctx, session, msg, "Heartbeats are not enabled on this gateway.")
+		return
+	}
+
+	override := map[string]any{}
+	if existing, ok := session.Metadata[metaHeartbeatSchedule].(map[string]any); ok {
+		for key, val := range existing {
+			override[key] = val
+		}
+	}
+	switch op {
+	case "status":
+		s.sendImmediateReply(ctx, session, msg, s.heartbeatStatus(session))
+		return
+	case "off":
+		override = map[string]any{"disabled": true}
+	case "on":
+		override = nil
+	case "every":
+		delete(override, "disabled")
+		delete(override, "cron")
+		override["every"] = value
+	case "cron":
+		if _, err := cron.NewSchedule(config.CronScheduleConfig{Cron: value}); err != nil {
+			s.sendImmediateReply(ctx, session, msg, "Invalid cron expression: "+err.Error())
+			return
+		}
+		delete(override, "disabled")
+		delete(override, "every")
+		override["cron"] = value
+	case "quiet":
+		start, end, _ := strings.Cut(value, "-")
+		override["quiet_start"] = strings.TrimSpace(start)
+		override["quiet_end"] = strings.TrimSpace(end)
+	default:
+		return
+	}
+
+	if session.Metadata == nil {
+		session.Metadata = map[string]any{}
+	}
+	if len(override) == 0 {
+		delete(session.Metadata, metaHeartbeatSchedule)
+	} else {
+		session.Metadata[metaHeartbeatSchedule] = override
+	}
+	if err := s.sessions.Update(ctx, session); err != nil {
+		s.logger.Error("failed to update heartbeat schedule", "error", err)
+		s.sendImmediateReply(ctx, session, msg, "Failed to update the heartbeat schedule.")
+		return
+	}
+	s.setHeartbeatSlot(session.ID, time.Now())
+	s.sendImmediateReply(ctx, session, msg, s.heartbeatStatus(session))
+}
+
+// heartbeatStatus describes the session's effective heartbeat schedule.
+func (s *Server) heartbeatStatus(session *models.Session) string {
+	sched, ok := s.resolveHeartbeatSchedule(session)
+	if !ok {
+		return "Heartbeats are off for this conversation."
+	}
+	status := "Heartbeat: " + sched.describe()
+	if next, ok := sched.next(s.lastHeartbeatSlot(session, time.Now())); ok {
+		status += "\nNext: " + next.Format(time.RFC1123)
+	}
+	return status
+}
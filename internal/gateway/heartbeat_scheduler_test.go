@@ -0,0 +1,209 @@
+package gateway
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/haasonsaas/nexus/internal/agent"
+	"github.com/haasonsaas/nexus/internal/channels"
+	"github.com/haasonsaas/nexus/internal/config"
+	"github.com/haasonsaas/nexus/internal/sessions"
+	"github.com/haasonsaas/nexus/pkg/models"
+)
+
+// heartbeatStore is a recordingStore that lists its session and returns
+// recorded messages as history.
+type heartbeatStore struct {
+	*recordingStore
+}
+
+func (s heartbeatStore) List(ctx context.Context, agentID string, opts sessions.ListOptions) ([]*models.Session, error) {
+	return []*models.Session{s.session}, nil
+}
+
+func (s heartbeatStore) GetHistory(ctx context.Context, sessionID string, limit int) ([]*models.Message, error) {
+	return s.messages, nil
+}
+
+func newHeartbeatServer(t *testing.T, heartbeat config.HeartbeatConfig, lastUserMessage time.Time) (*Server, *recordingAdapter, *recordingStore) {
+	t.Helper()
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	heartbeat.Enabled = true
+	cfg := &config.Config{
+		Session: config.SessionConfig{DefaultAgentID: "agent-test", Heartbeat: heartbeat},
+	}
+	server, err := NewServer(cfg, logger)
+	if err != nil {
+		t.Fatalf("NewServer() error = %v", err)
+	}
+
+	store := &recordingStore{
+		session: &models.Session{
+			ID:       "session-1",
+			AgentID:  "agent-test",
+			Channel:  models.ChannelTelegram,
+			Key:      "agent-test:telegram:123",
+			Metadata: map[string]any{metaHeartbeatLastRun: time.Now().Add(-2 * time.Hour).UTC().Format(time.RFC3339)},
+		},
+		messages: []*models.Message{{
+			ID:        "tg_1",
+			Channel:   models.ChannelTelegram,
+			Direction: models.DirectionInbound,
+			Role:      models.RoleUser,
+			Content:   "hello",
+			Metadata:  map[string]any{"chat_id": int64(123)},
+			CreatedAt: lastUserMessage,
+		}},
+	}
+	server.sessions = heartbeatStore{store}
+	server.runtime = agent.NewRuntime(fixedProvider{}, store)
+	adapter := &recordingAdapter{}
+	registry := channels.NewRegistry()
+	registry.Register(adapter)
+	server.channels = registry
+	return server, adapter, store
+}
+
+func TestRunScheduledHeartbeatsDispatchesDueSession(t *testing.T) {
+	server, adapter, store := newHeartbeatServer(t, config.HeartbeatConfig{
+		Schedule: config.HeartbeatScheduleConfig{Every: time.Hour, SuppressIfActive: 30 * time.Minute},
+	}, time.Now().Add(-3*time.Hour))
+
+	server.runScheduledHeartbeats(context.Background(), time.Now())
+	server.wg.Wait()
+
+	if len(adapter.messages) != 1 || adapter.messages[0].Content != "pong" {
+		t.Fatalf("expected heartbeat reply, got %+v", adapter.messages)
+	}
+	if fmt.Sprint(adapter.messages[0].Metadata["chat_id"]) != "123" {
+		t.Fatalf("expected reply routed to the user's chat, got %+v", adapter.messages[0].Metadata)
+	}
+	prompt := store.messages[1]
+	if !isHeartbeatMessage(prompt) || prompt.Content != defaultHeartbeatPrompt {
+		t.Fatalf("expected persisted heartbeat prompt, got %+v", prompt)
+	}
+
+	// The slot is consumed, so an immediate second pass does nothing.
+	server.runScheduledHeartbeats(context.Background(), time.Now())
+	server.wg.Wait()
+	if len(adapter.messages) != 1 {
+		t.Fatalf("expected no second heartbeat, got %d messages", len(adapter.messages))
+	}
+}
+
+func TestRunScheduledHeartbeatsSkips(t *testing.T) {
+	now := time.Date(2026, 3, 2, 3, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name      string
+		heartbeat config.HeartbeatConfig
+		lastUser  time.Time
+	}{
+		{
+			name: "quiet hours",
+			heartbeat: config.HeartbeatConfig{Schedule: config.HeartbeatScheduleConfig{
+				Every:      time.Hour,
+				Timezone:   "UTC",
+				QuietHours: config.QuietHoursConfig{Start: "22:00", End: "07:00"},
+			}},
+			lastUser: now.Add(-3 * time.Hour),
+		},
+		{
+			name: "recently active",
+			heartbeat: config.HeartbeatConfig{Schedule: config.HeartbeatScheduleConfig{
+				Every:            time.Hour,
+				SuppressIfActive: 30 * time.Minute,
+			}},
+			lastUser: now.Add(-5 * time.Minute),
+		},
+		{
+			name: "disabled for agent",
+			heartbeat: config.HeartbeatConfig{
+				Schedule: config.HeartbeatScheduleConfig{Every: time.Hour},
+				Agents:   map[string]config.HeartbeatScheduleConfig{"agent-test": {Disabled: true}},
+			},
+			lastUser: now.Add(-3 * time.Hour),
+		},
+		{
+			name:      "no cadence",
+			heartbeat: config.HeartbeatConfig{},
+			lastUser:  now.Add(-3 * time.Hour),
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			server, adapter, store := newHeartbeatServer(t, tc.heartbeat, tc.lastUser)
+			store.session.Metadata[metaHeartbeatLastRun] = now.Add(-2 * time.Hour).Format(time.RFC3339)
+
+			server.runScheduledHeartbeats(context.Background(), now)
+			server.wg.Wait()
+
+			if len(adapter.messages) != 0 {
+				t.Fatalf("expected heartbeat to be skipped, got %+v", adapter.messages)
+			}
+		})
+	}
+}
+
+func TestResolveHeartbeatScheduleLayersOverrides(t *testing.T) {
+	server := &Server{
+		logger: slog.New(slog.NewTextHandler(io.Discard, nil)),
+		config: &config.Config{
+			User: config.UserConfig{Timezone: "America/New_York"},
+			Session: config.SessionConfig{Heartbeat: config.HeartbeatConfig{
+				Enabled: true,
+				Schedule: config.HeartbeatScheduleConfig{
+					Every:      4 * time.Hour,
+					QuietHours: config.QuietHoursConfig{Start: "22:00", End: "07:00"},
+				},
+				Agents: map[string]config.HeartbeatScheduleConfig{
+					"ops": {Cron: "0 9 * * 1-5"},
+				},
+				Sessions: map[string]config.HeartbeatScheduleConfig{
+					"ops:slack:C1": {SuppressIfActive: time.Hour},
+				},
+			}},
+		},
+	}
+	session := &models.Session{ID: "s1", AgentID: "ops", Key: "ops:slack:C1"}
+
+	sched, ok := server.resolveHeartbeatSchedule(session)
+	if !ok {
+		t.Fatal("expected a schedule")
+	}
+	if sched.Every != 0 || sched.Cron != "0 9 * * 1-5" || sched.SuppressIfActive != time.Hour {
+		t.Fatalf("unexpected merged schedule: %+v", sched.HeartbeatScheduleConfig)
+	}
+	if sched.Timezone != "America/New_York" || sched.QuietHours.Start != "22:00" {
+		t.Fatalf("expected inherited timezone and quiet hours, got %+v", sched.HeartbeatScheduleConfig)
+	}
+
+	session.Metadata = map[string]any{metaHeartbeatSchedule: map[string]any{"every": "2h"}}
+	sched, _ = server.resolveHeartbeatSchedule(session)
+	if sched.Every != 2*time.Hour || sched.Cron != "" {
+		t.Fatalf("expected session override to replace the cadence, got %+v", sched.HeartbeatScheduleConfig)
+	}
+
+	session.Metadata[metaHeartbeatSchedule] = map[string]any{"disabled": true}
+	if _, ok := server.resolveHeartbeatSchedule(session); ok {
+		t.Fatal("expected /heartbeat off to disable the schedule")
+	}
+}
+
+func TestHeartbeatQuietHours(t *testing.T) {
+	sched := heartbeatSchedule{HeartbeatScheduleConfig: config.HeartbeatScheduleConfig{
+		Timezone:   "America/New_York",
+		QuietHours: config.QuietHoursConfig{Start: "22:00", End: "07:00"},
+	}}
+	loc, _ := time.LoadLocation("America/New_York")
+	cases := map[int]bool{3: true, 23: true, 7: false, 12: false, 21: false}
+	for hour, want := range cases {
+		at := time.Date(2026, 3, 2, hour, 30, 0, 0, loc)
+		if got := sched.quiet(at); got != want {
+			t.Errorf("quiet(%02d:30) = %v, want %v", hour, got, want)
+		}
+	}
+}
@@ -12,11 +12,15 @@ import (
 	"strings"
 
 	"github.com/haasonsaas/nexus/internal/auth"
+	"github.com/haasonsaas/nexus/internal/canvas"
 	"github.com/haasonsaas/nexus/internal/channels"
 	"github.com/haasonsaas/nexus/internal/config"
+	"github.com/haasonsaas/nexus/internal/jobs"
 	"github.com/haasonsaas/nexus/internal/media"
 	"github.com/haasonsaas/nexus/internal/sessions"
 	"github.com/haasonsaas/nexus/internal/storage"
+	"github.com/haasonsaas/nexus/internal/storage/sqlite"
+	"github.com/haasonsaas/nexus/internal/tasks"
 	"github.com/haasonsaas/nexus/pkg/models"
 )
 
@@ -159,9 +163,13 @@ func (s *Server) buildSessionKeyForPeer(agentID string, channel models.ChannelTy
 	)
 }
 
-// enrichMessageWithMedia processes media attachments and adds transcriptions.
+// enrichMessageWithMedia processes media attachments and adds transcriptions
+// and text read from images.
 func (s *Server) enrichMessageWithMedia(ctx context.Context, msg *models.Message) {
-	if msg == nil || s.mediaAggregator == nil || s.config == nil || !s.config.Transcription.Enabled {
+	if msg == nil || s.mediaAggregator == nil || s.config == nil {
+		return
+	}
+	if !s.config.Transcription.Enabled && !s.config.OCR.Enabled {
 		return
 	}
 	if len(msg.Attachments) == 0 {
@@ -177,8 +185,10 @@ func (s *Server) enrichMessageWithMedia(ctx context.Context, msg *models.Message
 
 	opts := media.DefaultOptions()
 	opts.EnableVision = false
-	opts.EnableTranscription = true
-	opts.TranscriptionLanguage = s.config.Transcription.Language
+	opts.EnableTranscription = s.config.Transcription.Enabled
+	opts.EnableOCR = s.config.OCR.Enabled
+	opts.TranscriptionLanguage = s.config.Transcription.LanguageFor(string(msg.Channel))
+	opts.Diarize = s.config.Transcription.Diarize && IsGroupMessage(msg)
 
 	mediaAttachments := make([]*media.Attachment, 0, len(msg.Attachments))
 	for i := range msg.Attachments {
@@ -193,7 +203,10 @@ func (s *Server) enrichMessageWithMedia(ctx context.Context, msg *models.Message
 		case "audio":
 			mediaType = media.MediaTypeAudio
 		}
-		if mediaType != media.MediaTypeAudio {
+		switch {
+		case mediaType == media.MediaTypeAudio && opts.EnableTranscription:
+		case mediaType == media.MediaTypeImage && opts.EnableOCR:
+		default:
 			continue
 		}
 
@@ -252,6 +265,12 @@ func (s *Server) enrichMessageWithMedia(ctx context.Context, msg *models.Message
 		msg.Metadata = map[string]any{}
 	}
 	msg.Metadata["media_text"] = content.Text
+	if len(content.Transcripts) > 0 {
+		msg.Metadata["transcripts"] = content.Transcripts
+	}
+	if len(content.ImageText) > 0 {
+		msg.Metadata["image_text"] = content.ImageText
+	}
 	if len(content.Errors) > 0 {
 		msg.Metadata["media_errors"] = content.Errors
 	}
@@ -312,7 +331,14 @@ func (s *Server) buildReplyMetadata(msg *models.Message) map[string]any {
 		} else if channelID, ok := msg.Metadata["discord_channel_id"].(string); ok {
 			metadata["discord_channel_id"] = channelID
 		}
-	case models.ChannelWhatsApp, models.ChannelSignal, models.ChannelIMessage, models.ChannelMatrix:
+	case models.ChannelEmail:
+		// Thread replies under the email being answered.
+		for _, key := range []string{"reply_to_message_id", "subject", "sender_email", "email_references"} {
+			if value, ok := msg.Metadata[key].(string); ok && value != "" {
+				metadata[key] = value
+			}
+		}
+	case models.ChannelWhatsApp, models.ChannelSignal, models.ChannelIMessage, models.ChannelMatrix, models.ChannelLoopback:
 		if peerID, ok := msg.Metadata["peer_id"].(string); ok && peerID != "" {
 			metadata["peer_id"] = peerID
 		}
@@ -339,6 +365,13 @@ func initStorageStores(cfg *config.Config) (storage.StoreSet, error) {
 	if cfg == nil || strings.TrimSpace(cfg.Database.URL) == "" {
 		return storage.NewMemoryStores(), nil
 	}
+	if sqlite.IsURL(cfg.Database.URL) {
+		db, err := sessions.OpenSQLite(context.Background(), cfg.Database.URL)
+		if err != nil {
+			return storage.StoreSet{}, fmt.Errorf("storage database: %w", err)
+		}
+		return storage.NewSQLiteStores(db)
+	}
 	dbCfg := storage.DefaultCockroachConfig()
 	if cfg.Database.MaxConnections > 0 {
 		dbCfg.MaxOpenConns = cfg.Database.MaxConnections
@@ -353,6 +386,46 @@ func initStorageStores(cfg *config.Config) (storage.StoreSet, error) {
 	return stores, nil
 }
 
+// newTaskStore opens the scheduled task store for the configured database.
+func newTaskStore(cfg *config.Config) (tasks.Store, error) {
+	if sqlite.IsURL(cfg.Database.URL) {
+		db, err := sessions.OpenSQLite(context.Background(), cfg.Database.URL)
+		if err != nil {
+			return nil, err
+		}
+		return tasks.NewSQLiteStore(db)
+	}
+	taskStoreCfg := tasks.DefaultCockroachConfig()
+	if cfg.Database.MaxConnections > 0 {
+		taskStoreCfg.MaxOpenConns = cfg.Database.MaxConnections
+	}
+	if cfg.Database.ConnMaxLifetime > 0 {
+		taskStoreCfg.ConnMaxLifetime = cfg.Database.ConnMaxLifetime
+	}
+	return tasks.NewCockroachStoreFromDSN(cfg.Database.URL, taskStoreCfg)
+}
+
+// newJobStore opens the async tool job store for the configured database.
+func newJobStore(cfg *config.Config) (*jobs.CockroachStore, error) {
+	if sqlite.IsURL(cfg.Database.URL) {
+		db, err := sessions.OpenSQLite(context.Background(), cfg.Database.URL)
+		if err != nil {
+			return nil, err
+		}
+		return jobs.NewSQLiteStore(db)
+	}
+	return jobs.NewCockroachStoreFromDSN(cfg.Database.URL, nil)
+}
+
+// newSQLiteCanvasStore opens the canvas store on a SQLite database.
+func newSQLiteCanvasStore(dsn string) (*canvas.CockroachStore, error) {
+	db, err := sessions.OpenSQLite(context.Background(), dsn)
+	if err != nil {
+		return nil, err
+	}
+	return canvas.NewSQLiteStore(db)
+}
+
 // registerOAuthProviders registers OAuth providers with the auth service.
 func registerOAuthProviders(service *auth.Service, cfg config.OAuthConfig) {
 	if service == nil {
@@ -373,3 +446,20 @@ func registerOAuthProviders(service *auth.Service, cfg config.OAuthConfig) {
 		}))
 	}
 }
+
+// registerOIDC enables single sign-on for the HTTP surfaces.
+func registerOIDC(service *auth.Service, cfg config.OIDCConfig) {
+	if service == nil || !cfg.Enabled {
+		return
+	}
+	service.SetOIDC(auth.NewOIDCProvider(auth.OIDCConfig{
+		Issuer:       cfg.Issuer,
+		ClientID:     cfg.ClientID,
+		ClientSecret: cfg.ClientSecret,
+		RedirectURL:  cfg.RedirectURL,
+		Scopes:       cfg.Scopes,
+		GroupsClaim:  cfg.GroupsClaim,
+		GroupRoles:   cfg.GroupRoles,
+		DefaultRole:  cfg.DefaultRole,
+	}))
+}
@@ -1,7 +1,10 @@
 package gateway
 
 import (
+	"context"
+	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/haasonsaas/nexus/internal/config"
 	"github.com/haasonsaas/nexus/pkg/models"
@@ -106,6 +109,26 @@ func TestInitStorageStores_WhitespaceOnlyURL(t *testing.T) {
 	}
 }
 
+func TestInitStorageStores_SQLite(t *testing.T) {
+	cfg := &config.Config{
+		Database: config.DatabaseConfig{
+			URL: "sqlite://" + filepath.Join(t.TempDir(), "nexus.db"),
+		},
+	}
+	stores, err := initStorageStores(cfg)
+	if err != nil {
+		t.Fatalf("initStorageStores with sqlite URL returned error: %v", err)
+	}
+	defer stores.Close()
+	agent := &models.Agent{ID: "agent-1", Name: "Helper", CreatedAt: time.Now(), UpdatedAt: time.Now()}
+	if err := stores.Agents.Create(context.Background(), agent); err != nil {
+		t.Fatalf("Agents.Create() error = %v", err)
+	}
+	if _, err := stores.Agents.Get(context.Background(), agent.ID); err != nil {
+		t.Fatalf("Agents.Get() error = %v", err)
+	}
+}
+
 func TestBuildReplyMetadata_TelegramChannel(t *testing.T) {
 	s := &Server{
 		config: &config.Config{},
@@ -169,6 +192,34 @@ func TestBuildReplyMetadata_SlackFallbackToTs(t *testing.T) {
 	}
 }
 
+func TestBuildReplyMetadata_EmailChannel(t *testing.T) {
+	s := &Server{
+		config: &config.Config{},
+	}
+	msg := &models.Message{
+		Channel: models.ChannelEmail,
+		Metadata: map[string]any{
+			"reply_to_message_id": "<b@example.com>",
+			"email_references":    "<a@example.com>",
+			"subject":             "Quarterly report",
+			"sender_email":        "ada@example.com",
+			"imap_uid":            "42",
+		},
+	}
+
+	metadata := s.buildReplyMetadata(msg)
+
+	if metadata["reply_to_message_id"] != "<b@example.com>" || metadata["email_references"] != "<a@example.com>" {
+		t.Errorf("threading metadata = %v", metadata)
+	}
+	if metadata["sender_email"] != "ada@example.com" || metadata["subject"] != "Quarterly report" {
+		t.Errorf("reply metadata = %v", metadata)
+	}
+	if _, ok := metadata["imap_uid"]; ok {
+		t.Errorf("imap_uid should not be copied: %v", metadata)
+	}
+}
+
 func TestBuildReplyMetadata_DiscordChannel(t *testing.T) {
 	s := &Server{
 		config: &config.Config{},
@@ -337,3 +388,19 @@ func TestRegisterOAuthProviders_WhitespaceCredentials(t *testing.T) {
 	// Should not register providers with whitespace-only credentials
 	registerOAuthProviders(nil, cfg)
 }
+
+func TestLocalRedirect(t *testing.T) {
+	cases := map[string]string{
+		"/ui/sessions?tab=1":        "/ui/sessions?tab=1",
+		"":                          "",
+		"https://evil.example.com/": "",
+		"//evil.example.com/":       "",
+		`/\evil.example.com`:        "",
+		" /canvas/s1 ":              "/canvas/s1",
+	}
+	for in, want := range cases {
+		if got := localRedirect(in); got != want {
+			t.Errorf("localRedirect(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
@@ -188,7 +188,7 @@ func (s *Server) handleHomeAssistantConversation(w http.ResponseWriter, r *http.
 			agentModel = model
 		}
 	}
-	toolPolicy := s.resolveToolPolicy(agentModel, msg)
+	toolPolicy := s.resolveToolPolicy(ctx, agentModel, msg)
 	systemPrompt, _ := s.systemPromptForMessage(ctx, session, msg, toolPolicy)
 	if systemPrompt != "" {
 		promptCtx = agent.WithSystemPrompt(promptCtx, systemPrompt)
@@ -196,12 +196,12 @@ func (s *Server) handleHomeAssistantConversation(w http.ResponseWriter, r *http.
 	if s.toolPolicyResolver != nil && toolPolicy != nil {
 		promptCtx = agent.WithToolPolicy(promptCtx, s.toolPolicyResolver, toolPolicy)
 	}
-	if overrides := s.experimentOverrides(session, msg); overrides.Model != "" {
-		promptCtx = agent.WithModel(promptCtx, overrides.Model)
-	}
+	promptCtx = s.withExperiments(promptCtx, session, msg)
 	if model := sessionModelOverride(session); model != "" {
 		promptCtx = agent.WithModel(promptCtx, model)
 	}
+	promptCtx = s.withGenerationProfile(promptCtx, agentModel, msg)
+	promptCtx = withThinking(promptCtx, session, msg)
 
 	runCtx, cancel := context.WithTimeout(promptCtx, maxProcessingTime)
 	runToken := s.registerActiveRun(session.ID, cancel)
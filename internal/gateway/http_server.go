@@ -11,10 +11,12 @@ import (
 
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 
+	"github.com/haasonsaas/nexus/internal/artifacts"
 	"github.com/haasonsaas/nexus/internal/commands"
 	"github.com/haasonsaas/nexus/internal/config"
 	"github.com/haasonsaas/nexus/internal/infra"
 	"github.com/haasonsaas/nexus/internal/web"
+	"github.com/haasonsaas/nexus/pkg/management"
 )
 
 func (s *Server) startHTTPServer(ctx context.Context) error {
@@ -25,8 +27,11 @@ func (s *Server) startHTTPServer(ctx context.Context) error {
 	addr := fmt.Sprintf("%s:%d", s.config.Server.Host, s.config.Server.HTTPPort)
 	mux := http.NewServeMux()
 
-	mux.Handle("/metrics", promhttp.Handler())
+	if s.config.Observability.Metrics.PrometheusEnabled() {
+		mux.Handle("/metrics", promhttp.Handler())
+	}
 	mux.HandleFunc("/healthz", s.handleHealthz)
+	mux.HandleFunc("/readyz", s.handleReadyz)
 	if s.webhookHooks != nil {
 		basePath := s.webhookHooks.Config().BasePath
 		if basePath == "" {
@@ -38,27 +43,42 @@ func (s *Server) startHTTPServer(ctx context.Context) error {
 
 	if s.config.Channels.HomeAssistant.Enabled {
 		var haHandler http.Handler = http.HandlerFunc(s.handleHomeAssistantConversation)
+		haHandler = web.ScopeMiddleware(s.adminAudit, s.logger)(haHandler)
 		haHandler = web.AuthMiddleware(s.authService, s.logger)(haHandler)
 		mux.Handle("/api/v1/ha/conversation", haHandler)
 	}
 
+	if s.authService.OIDC() != nil {
+		mux.Handle(oidcPathPrefix, s.newOIDCHandler())
+	}
 	mux.Handle("/ws", s.newWSControlPlane())
+	mux.Handle(managementPathPrefix, s.newManagementAPI())
+	mux.Handle(management.ActionsPathPrefix, s.newActionsAPI())
+	mux.HandleFunc(management.OpenAPIPath, s.handleOpenAPISpec)
+
+	if s.artifactRepo != nil && s.artifactLinks != nil {
+		mux.Handle(artifacts.LinkPathPrefix, newArtifactLinkHandler(s.artifactRepo, s.artifactLinks, s.authService, s.logger))
+	}
 
 	webHandler, err := web.NewHandler(&web.Config{
 		BasePath:            "/ui",
 		AuthService:         s.authService,
 		SessionStore:        s.sessions,
 		ArtifactRepo:        s.artifactRepo,
+		ArtifactLinks:       s.artifactLinks,
 		ChannelRegistry:     s.channels,
 		CronScheduler:       s.cronScheduler,
 		SkillsManager:       s.skillsManager,
 		EdgeManager:         s.edgeManager,
 		ToolSummaryProvider: s.toolManager,
+		SteeringStatus:      s.steeringStatus,
+		AnomalyStatus:       s.anomalyStatus,
 		GatewayConfig:       s.config,
 		EventStore:          s.eventStore,
 		UsageCache:          s.integration.UsageCache(),
 		ConfigManager:       s,
 		ConfigPath:          s.configPath,
+		AuditLogger:         s.adminAudit,
 		DefaultAgentID:      s.config.Session.DefaultAgentID,
 		Logger:              s.logger,
 		ServerStartTime:     s.startTime,
@@ -114,9 +134,20 @@ func (s *Server) stopHTTPServer(ctx context.Context) {
 	s.httpListener = nil
 }
 
+// handleHealthz serves the liveness probe. It reports 200 whenever the process
+// can serve requests; dependency failures are reported by /readyz instead so an
+// orchestrator does not restart the gateway for an upstream outage.
 func (s *Server) handleHealthz(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
 
+	response := map[string]any{"status": "ok"}
+	if s.nodeID != "" {
+		response["node_id"] = s.nodeID
+	}
+	if !s.startTime.IsZero() {
+		response["uptime_ms"] = time.Since(s.startTime).Milliseconds()
+	}
+
 	// Use integration health checker if available
 	if s.integration != nil {
 		// Quick health check without probing
@@ -129,38 +160,16 @@ func (s *Server) handleHealthz(w http.ResponseWriter, r *http.Request) {
 		}
 		summary, err := s.integration.CheckHealth(r.Context(), opts)
 		if err != nil {
-			payload := map[string]any{
-				"status": "error",
-				"error":  err.Error(),
-			}
-			data, marshalErr := json.Marshal(payload)
-			if marshalErr != nil {
-				http.Error(w, "internal error", http.StatusInternalServerError)
-				return
-			}
-
-			w.WriteHeader(http.StatusInternalServerError)
-			if _, writeErr := w.Write(data); writeErr != nil && s.logger != nil {
-				s.logger.Debug("healthz write failed", "error", writeErr)
+			response["health"] = "error"
+			response["error"] = err.Error()
+		} else {
+			health := "ok"
+			if !summary.OK {
+				health = "degraded"
 			}
-			return
-		}
-
-		// Build response
-		status := "ok"
-		statusCode := http.StatusOK
-		if !summary.OK {
-			status = "degraded"
-			statusCode = http.StatusServiceUnavailable
-		}
-
-		response := map[string]any{
-			"status":      status,
-			"ts":          summary.Ts,
-			"duration_ms": summary.DurationMs,
-		}
-		if s.nodeID != "" {
-			response["node_id"] = s.nodeID
+			response["health"] = health
+			response["ts"] = summary.Ts
+			response["duration_ms"] = summary.DurationMs
 		}
 
 		// Include activity stats
@@ -183,29 +192,8 @@ func (s *Server) handleHealthz(w http.ResponseWriter, r *http.Request) {
 		if r.URL.Query().Get("checks") == "true" {
 			response["checks"] = infra.CheckHealth(r.Context())
 		}
-
-		data, err := json.Marshal(response)
-		if err != nil {
-			if s.logger != nil {
-				s.logger.Error("healthz marshal failed", "error", err)
-			}
-			http.Error(w, "internal error", http.StatusInternalServerError)
-			return
-		}
-
-		w.WriteHeader(statusCode)
-		if _, err := w.Write(data); err != nil && s.logger != nil {
-			s.logger.Debug("healthz write failed", "error", err)
-		}
-		return
 	}
 
-	// Fallback to simple health check
-	w.WriteHeader(http.StatusOK)
-	response := map[string]any{"status": "ok"}
-	if s.nodeID != "" {
-		response["node_id"] = s.nodeID
-	}
 	data, err := json.Marshal(response)
 	if err != nil {
 		if s.logger != nil {
@@ -214,6 +202,7 @@ func (s *Server) handleHealthz(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, "internal error", http.StatusInternalServerError)
 		return
 	}
+	w.WriteHeader(http.StatusOK)
 	if _, err := w.Write(data); err != nil && s.logger != nil {
 		s.logger.Debug("healthz write failed", "error", err)
 	}
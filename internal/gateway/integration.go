@@ -229,8 +229,10 @@ func EmitMessageQueued(sessionKey, sessionID, channel, source string, queueDepth
 	})
 }
 
-// EmitMessageProcessed emits a diagnostic event for message processing completion.
+// EmitMessageProcessed emits a diagnostic event for message processing
+// completion and records the reply metrics SLOs are computed from.
 func EmitMessageProcessed(channel, messageID, chatID, sessionKey, sessionID, outcome, reason, errMsg string, durationMs int64) {
+	observability.NewReplyMetrics().Record(channel, outcome, time.Duration(durationMs)*time.Millisecond)
 	observability.EmitMessageProcessed(&observability.MessageProcessedEvent{
 		Channel:    channel,
 		MessageID:  messageID,
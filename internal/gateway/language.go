@@ -0,0 +1,184 @@
+package gateway
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/haasonsaas/nexus/internal/messages"
+	"github.com/haasonsaas/nexus/pkg/models"
+)
+
+const (
+	// metaReplyLanguage is the session metadata key holding the language
+	// chosen with /language.
+	metaReplyLanguage = "reply_language"
+	// metaDetectedLanguage holds the language detected in the latest inbound
+	// message, on the message, and the last confident detection, on the
+	// session, so short replies like "ok" keep the conversation's language.
+	metaDetectedLanguage = "detected_language"
+)
+
+// detectMessageLanguage records the language of an inbound message on the
+// message and, when it changes, on the session.
+func (s *Server) detectMessageLanguage(ctx context.Context, session *models.Session, msg *models.Message) {
+	if s.config == nil || msg == nil {
+		return
+	}
+	cfg := s.config.Session.Language
+	if !cfg.Enabled || (cfg.Detect != nil && !*cfg.Detect) {
+		return
+	}
+	lang := messages.DetectLanguage(msg.Content)
+	if lang == "" {
+		return
+	}
+	if msg.Metadata == nil {
+		msg.Metadata = map[string]any{}
+	}
+	msg.Metadata[metaDetectedLanguage] = lang
+	if session == nil {
+		return
+	}
+	if previous, _ := session.Metadata[metaDetectedLanguage].(string); previous == lang {
+		return
+	}
+	if session.Metadata == nil {
+		session.Metadata = map[string]any{}
+	}
+	session.Metadata[metaDetectedLanguage] = lang
+	if err := s.sessions.Update(ctx, session); err != nil {
+		s.logger.Debug("failed to persist detected language", "session_id", session.ID, "error", err)
+	}
+}
+
+// replyLanguage returns the language the agent should reply to msg in and
+// where it came from: the /language choice for the conversation, the
+// sender's identity profile, the language detected in the message or
+// earlier in the conversation, or the configured default.
+func (s *Server) replyLanguage(ctx context.Context, session *models.Session, msg *models.Message) (string, string) {
+	if s.config == nil || !s.config.Session.Language.Enabled {
+		return "", ""
+	}
+	if session != nil {
+		if lang, _ := session.Metadata[metaReplyLanguage].(string); lang != "" {
+			return lang, "chosen with /language"
+		}
+	}
+	if lang := s.identityLocale(ctx, msg); lang != "" {
+		return lang, "your profile"
+	}
+	if msg != nil {
+		if lang, _ := msg.Metadata[metaDetectedLanguage].(string); lang != "" {
+			return lang, "detected"
+		}
+	}
+	if session != nil {
+		if lang, _ := session.Metadata[metaDetectedLanguage].(string); lang != "" {
+			return lang, "detected"
+		}
+	}
+	if lang := messages.NormalizeLocale(s.config.Session.Language.Default); lang != "" {
+		return lang, "default"
+	}
+	return "", ""
+}
+
+// identityLocale returns the locale in the identity profile of the sender
+// of msg, if any.
+func (s *Server) identityLocale(ctx context.Context, msg *models.Message) string {
+	if s.identityStore == nil || msg == nil {
+		return ""
+	}
+	peerID := extractSenderID(msg)
+	if peerID == "" {
+		return ""
+	}
+	ident, err := s.identityStore.ResolveByPeer(ctx, string(msg.Channel), peerID)
+	if err != nil || ident == nil {
+		return ""
+	}
+	return messages.NormalizeLocale(ident.Metadata[identityLocaleKey])
+}
+
+// replyLanguageDirective is the system prompt line asking the model to reply
+// in lang.
+func replyLanguageDirective(lang string) string {
+	name := messages.LanguageName(lang)
+	if name == "" {
+		name = lang
+	}
+	return fmt.Sprintf("Reply in %s, the user's language, unless they ask for a different one.", name)
+}
+
+func (s *Server) applyLanguageCommand(ctx context.Context, session *models.Session, msg *models.Message, op, value string) {
+	if s.config == nil || !s.config.Session.Language.Enabled {
+		s.sendImmediateReply(ctx, session, msg, "Reply language steering is not enabled (session.language.enabled).")
+		return
+	}
+	switch op {
+	case "status":
+		lang, source := s.replyLanguage(ctx, session, msg)
+		if lang == "" {
+			s.sendImmediateReply(ctx, session, msg, "No reply language set; the model picks one. Use /language <language> to choose.")
+			return
+		}
+		s.sendImmediateReply(ctx, session, msg, fmt.Sprintf("Replying in %s (%s). Use /language auto to follow your messages instead.", languageLabel(lang), source))
+		return
+	case "auto":
+		if session.Metadata != nil {
+			delete(session.Metadata, metaReplyLanguage)
+		}
+		s.setIdentityLocale(ctx, msg, "")
+	case "set":
+		if session.Metadata == nil {
+			session.Metadata = map[string]any{}
+		}
+		session.Metadata[metaReplyLanguage] = value
+		s.setIdentityLocale(ctx, msg, value)
+	default:
+		return
+	}
+	if err := s.sessions.Update(ctx, session); err != nil {
+		s.logger.Error("failed to update reply language", "session_id", session.ID, "error", err)
+		return
+	}
+	if op == "auto" {
+		s.sendImmediateReply(ctx, session, msg, "Reply language cleared; I'll follow the language you write in.")
+		return
+	}
+	s.sendImmediateReply(ctx, session, msg, fmt.Sprintf("I'll reply in %s.", languageLabel(value)))
+}
+
+// setIdentityLocale stores the sender's language on their linked identity,
+// if they have one, so it follows them to other channels.
+func (s *Server) setIdentityLocale(ctx context.Context, msg *models.Message, lang string) {
+	if s.identityStore == nil {
+		return
+	}
+	peerID := extractSenderID(msg)
+	if peerID == "" {
+		return
+	}
+	ident, err := s.identityStore.ResolveByPeer(ctx, string(msg.Channel), peerID)
+	if err != nil || ident == nil {
+		return
+	}
+	if lang == "" {
+		delete(ident.Metadata, identityLocaleKey)
+	} else {
+		if ident.Metadata == nil {
+			ident.Metadata = map[string]string{}
+		}
+		ident.Metadata[identityLocaleKey] = lang
+	}
+	if err := s.identityStore.Update(ctx, ident); err != nil {
+		s.logger.Warn("failed to update identity locale", "identity", ident.CanonicalID, "error", err)
+	}
+}
+
+func languageLabel(lang string) string {
+	if name := messages.LanguageName(lang); name != "" {
+		return name
+	}
+	return lang
+}
@@ -0,0 +1,55 @@
+package gateway
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/haasonsaas/nexus/internal/config"
+	"github.com/haasonsaas/nexus/pkg/models"
+)
+
+func TestReplyLanguage(t *testing.T) {
+	ctx := context.Background()
+	server, provider, _ := newEtiquetteServer(t, config.ChannelPolicyConfig{})
+	server.config.Session.Language = config.LanguageConfig{Enabled: true}
+	got, _ := server.channels.Get(models.ChannelTelegram)
+	adapter := got.(*recordingAdapter)
+
+	lastSystem := func() string {
+		provider.mu.Lock()
+		defer provider.mu.Unlock()
+		if provider.lastRequest == nil {
+			return ""
+		}
+		return provider.lastRequest.System
+	}
+
+	server.handleMessage(ctx, groupMessage("m1", "¿Qué tiempo hace hoy en la ciudad?", false))
+	if system := lastSystem(); !strings.Contains(system, "Reply in Spanish") {
+		t.Fatalf("expected a Spanish reply directive, got %q", system)
+	}
+	server.handleMessage(ctx, groupMessage("m2", "ok", false))
+	if system := lastSystem(); !strings.Contains(system, "Reply in Spanish") {
+		t.Fatalf("expected the detected language to stick for short messages, got %q", system)
+	}
+
+	server.handleMessage(ctx, groupMessage("m3", "/language German", false))
+	if reply := adapter.last(); reply != "I'll reply in German." {
+		t.Fatalf("unexpected /language reply %q", reply)
+	}
+	server.handleMessage(ctx, groupMessage("m4", "What is the weather like today?", false))
+	if system := lastSystem(); !strings.Contains(system, "Reply in German") {
+		t.Fatalf("expected the chosen language to win over detection, got %q", system)
+	}
+	server.handleMessage(ctx, groupMessage("m5", "/language", false))
+	if reply := adapter.last(); !strings.HasPrefix(reply, "Replying in German (chosen with /language)") {
+		t.Fatalf("unexpected /language status %q", reply)
+	}
+
+	server.handleMessage(ctx, groupMessage("m6", "/language auto", false))
+	server.handleMessage(ctx, groupMessage("m7", "What is the weather like today?", false))
+	if system := lastSystem(); !strings.Contains(system, "Reply in English") {
+		t.Fatalf("expected detection after /language auto, got %q", system)
+	}
+}
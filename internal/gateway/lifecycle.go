@@ -1,7 +1,8 @@
 // Package gateway provides the main Nexus gateway server.
 //
 // lifecycle.go contains server lifecycle management including startup, shutdown,
-// and background task management (task scheduler, job pruning, hooks).
+// and background task management (cluster coordination, task scheduler, job
+// pruning, hooks).
 package gateway
 
 import (
@@ -11,6 +12,7 @@ import (
 	"net"
 	"time"
 
+	"github.com/haasonsaas/nexus/internal/cluster"
 	"github.com/haasonsaas/nexus/internal/hooks"
 	"github.com/haasonsaas/nexus/internal/sessions"
 	"github.com/haasonsaas/nexus/internal/tasks"
@@ -36,6 +38,9 @@ func (s *Server) Start(ctx context.Context) error {
 	}
 	s.singletonLock = lock
 
+	// Report panics recovered in adapters, tools, and background workers
+	s.installCrashHandler()
+
 	if s.mcpManager != nil {
 		if err := s.mcpManager.Start(ctx); err != nil {
 			return fmt.Errorf("failed to start MCP manager: %w", err)
@@ -46,6 +51,9 @@ func (s *Server) Start(ctx context.Context) error {
 			s.logger.Warn("failed to start canvas host", "error", err)
 		}
 	}
+	// Register the canary runner and its loopback adapter before channels start
+	s.initCanary()
+
 	// Start channel adapters
 	if err := s.channels.StartAll(ctx); err != nil {
 		return fmt.Errorf("failed to start channels: %w", err)
@@ -59,7 +67,17 @@ func (s *Server) Start(ctx context.Context) error {
 		s.logger.Info("integration subsystems started")
 	}
 
+	// Join the cluster before schedulers start so they only run on the leader
+	if err := s.startCluster(ctx); err != nil {
+		return fmt.Errorf("failed to start cluster coordination: %w", err)
+	}
+
+	if err := s.startEventBus(ctx); err != nil {
+		return fmt.Errorf("failed to start event bus: %w", err)
+	}
+
 	if s.cronScheduler != nil {
+		s.cronScheduler.SetLeaderCheck(s.leaderCheck(cluster.LeaseCron))
 		if err := s.cronScheduler.Start(ctx); err != nil {
 			return fmt.Errorf("failed to start cron scheduler: %w", err)
 		}
@@ -70,11 +88,25 @@ func (s *Server) Start(ctx context.Context) error {
 		return fmt.Errorf("failed to start task scheduler: %w", err)
 	}
 
+	// Start reloading steering rules when the config file changes
+	s.startSteeringWatch(ctx)
+
+	// Swap provider keys when the config file changes and remind about old ones
+	s.startProviderKeyWatch(ctx)
+	s.startKeyRotationReminders(ctx)
+
+	// Load the attention feed before inbound messages start adding to it
+	s.startAttention(ctx)
+
 	// Start message processing
 	s.startProcessing(ctx)
 
+	// Start the batch queue before the background jobs that use it
+	s.startBatchQueue(ctx)
+
 	// Start memory consolidation background worker
 	s.startMemoryConsolidation(ctx)
+	s.startDailyMemoryConsolidation(ctx)
 
 	// Start security posture background worker
 	s.startSecurityPosture(ctx)
@@ -82,6 +114,46 @@ func (s *Server) Start(ctx context.Context) error {
 	// Start job pruning background task
 	s.startJobPruning(ctx)
 
+	// Start data retention enforcement
+	s.startRetentionEnforcement(ctx)
+
+	// Start provider key and channel token validation
+	s.startCredentialMonitor(ctx)
+
+	// Start evaluating service level objectives
+	s.startSLOTracker(ctx)
+	s.startAnomalyDetector(ctx)
+
+	// Start running canary conversations
+	s.startCanary(ctx)
+
+	// Start posting scheduled cost reports
+	s.startCostReport(ctx)
+
+	// Start syncing the config file from git
+	s.startGitOpsSync(ctx)
+
+	// Start pushing metrics to the OTLP collector
+	s.startMetricsExport(ctx)
+
+	// Start persisting timeline events to the database
+	s.startEventPersistence(ctx)
+
+	// Start publishing gateway events to the message queue
+	s.startEventBridge(ctx)
+
+	// Start recording reactions on replies as run feedback
+	s.startFeedbackCapture(ctx)
+
+	// Start scheduled session heartbeats
+	s.startHeartbeatScheduler(ctx)
+
+	// Start scheduled catch-up digests
+	s.startCatchupDigest(ctx)
+
+	// Start checking watched web pages
+	s.startWebWatch(ctx)
+
 	// Start active runs cleanup background task
 	s.startActiveRunsCleanup(ctx)
 
@@ -95,6 +167,9 @@ func (s *Server) Start(ctx context.Context) error {
 	if err := s.startHTTPServer(ctx); err != nil {
 		return fmt.Errorf("failed to start http server: %w", err)
 	}
+	if err := s.startDiagnosticsServer(); err != nil {
+		return fmt.Errorf("failed to start diagnostics server: %w", err)
+	}
 
 	// Start gRPC server
 	return s.startGRPCServer()
@@ -135,6 +210,12 @@ func (s *Server) Stop(ctx context.Context) error {
 	// Stop accepting new connections
 	s.grpc.GracefulStop()
 	s.stopHTTPServer(ctx)
+	s.stopDiagnosticsServer(ctx)
+
+	// Drop proactive messages still waiting for quiet hours to end
+	if s.delivery != nil {
+		s.delivery.Close()
+	}
 
 	// Stop channel adapters
 	if err := s.channels.StopAll(ctx); err != nil {
@@ -152,6 +233,13 @@ func (s *Server) Stop(ctx context.Context) error {
 		return err
 	}
 
+	// Write buffered timeline events while the database is still open
+	s.stopEventPersistence(ctx)
+
+	// Leave the cluster while the database is still open
+	s.stopCluster(ctx)
+	s.stopEventBus()
+
 	if s.browserPool != nil {
 		if err := s.browserPool.Close(); err != nil {
 			s.logger.Error("error closing browser pool", "error", err)
@@ -224,6 +312,16 @@ func (s *Server) Stop(ctx context.Context) error {
 			s.logger.Error("error shutting down tracer", "error", err)
 		}
 	}
+	if s.metricsExporter != nil {
+		if err := s.metricsExporter.Shutdown(ctx); err != nil {
+			s.logger.Error("error shutting down otlp metrics exporter", "error", err)
+		}
+	}
+	if s.eventBridge != nil {
+		if err := s.eventBridge.Shutdown(ctx); err != nil {
+			s.logger.Error("error shutting down event bridge", "error", err)
+		}
+	}
 	if s.canvasHost != nil {
 		if err := s.canvasHost.Close(); err != nil {
 			s.logger.Error("error closing canvas host", "error", err)
@@ -234,6 +332,11 @@ func (s *Server) Stop(ctx context.Context) error {
 			s.logger.Error("error closing audit logger", "error", err)
 		}
 	}
+	if s.adminAudit != nil {
+		if err := s.adminAudit.Close(); err != nil {
+			s.logger.Error("error closing admin audit logger", "error", err)
+		}
+	}
 	if err := s.stores.Close(); err != nil {
 		s.logger.Error("error closing storage stores", "error", err)
 	}
@@ -250,6 +353,11 @@ func (s *Server) Stop(ctx context.Context) error {
 		}
 	}
 
+	if s.restoreCrashHandler != nil {
+		s.restoreCrashHandler()
+		s.restoreCrashHandler = nil
+	}
+
 	return nil
 }
 
@@ -279,6 +387,8 @@ func (s *Server) startTaskScheduler(ctx context.Context) error {
 				DMScope:       s.config.Session.Scoping.DMScope,
 				IdentityLinks: s.config.Session.Scoping.IdentityLinks,
 			},
+			Hooks:  s.hooksRegistry,
+			Router: s.deliveryRouter(),
 			Logger: func(format string, args ...any) {
 				s.logger.Info(fmt.Sprintf(format, args...), "component", "message-executor")
 			},
@@ -311,6 +421,10 @@ func (s *Server) startTaskScheduler(ctx context.Context) error {
 	if s.config.Tasks.StaleTimeout > 0 {
 		schedulerCfg.StaleTimeout = s.config.Tasks.StaleTimeout
 	}
+	if s.cluster != nil {
+		schedulerCfg.Partition = s.cluster.Owns
+		schedulerCfg.Leader = s.leaderCheck(cluster.LeaseTaskMaintenance)
+	}
 	schedulerCfg.Logger = s.logger.With("component", "task-scheduler")
 
 	// Create and start the scheduler
@@ -339,9 +453,7 @@ func (s *Server) startJobPruning(ctx context.Context) {
 		return
 	}
 
-	s.wg.Add(1)
-	go func() {
-		defer s.wg.Done()
+	s.goSupervised(ctx, "worker:job_pruning", func(ctx context.Context) {
 		ticker := time.NewTicker(interval)
 		defer ticker.Stop()
 
@@ -350,6 +462,9 @@ func (s *Server) startJobPruning(ctx context.Context) {
 			case <-ctx.Done():
 				return
 			case <-ticker.C:
+				if !s.isClusterLeader(cluster.LeaseJobPruning) {
+					continue
+				}
 				pruned, err := s.jobStore.Prune(ctx, retention)
 				if err != nil {
 					s.logger.Error("job pruning failed", "error", err)
@@ -358,7 +473,7 @@ func (s *Server) startJobPruning(ctx context.Context) {
 				}
 			}
 		}
-	}()
+	})
 }
 
 // startActiveRunsCleanup starts a background goroutine that cleans up stale active runs.
@@ -367,9 +482,7 @@ func (s *Server) startActiveRunsCleanup(ctx context.Context) {
 	// Clean up stale active runs every 5 minutes
 	const cleanupInterval = 5 * time.Minute
 
-	s.wg.Add(1)
-	go func() {
-		defer s.wg.Done()
+	s.goSupervised(ctx, "worker:active_runs_cleanup", func(ctx context.Context) {
 		ticker := time.NewTicker(cleanupInterval)
 		defer ticker.Stop()
 
@@ -381,7 +494,7 @@ func (s *Server) startActiveRunsCleanup(ctx context.Context) {
 				s.cleanupStaleActiveRuns()
 			}
 		}
-	}()
+	})
 }
 
 // createHookHandler creates a handler function for a discovered hook.
@@ -0,0 +1,103 @@
+package gateway
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/haasonsaas/nexus/internal/agent"
+	"github.com/haasonsaas/nexus/internal/config"
+	"github.com/haasonsaas/nexus/internal/links"
+	"github.com/haasonsaas/nexus/internal/tools/websearch"
+)
+
+// newLinkSummarizer returns the summarizer for "llm" link models, or nil when
+// none are configured.
+func (s *Server) newLinkSummarizer(cfg config.LinksConfig) *links.LLMSummarizer {
+	if !cfg.Enabled {
+		return nil
+	}
+	for _, model := range cfg.Models {
+		if model.Type == links.ModelTypeLLM {
+			return links.NewLLMSummarizer(
+				&linkPageFetcher{server: s, extractor: websearch.NewContentExtractor()},
+				&linkSummaryCompleter{server: s},
+				cfg.SummaryCacheTTL,
+			)
+		}
+	}
+	return nil
+}
+
+// linkPageFetcher reads pages the way web_fetch does, rendering them in the
+// browser pool when it is running and the static page has little text.
+type linkPageFetcher struct {
+	server    *Server
+	extractor *websearch.ContentExtractor
+}
+
+func (f *linkPageFetcher) FetchText(ctx context.Context, url string) (string, error) {
+	var renderer websearch.PageRenderer
+	if pool := f.server.browserPool; pool != nil {
+		renderer = pool
+	}
+	return f.extractor.ExtractRendered(ctx, url, renderer)
+}
+
+// linkSummaryCompleter runs summaries on the default provider or on a
+// provider built from llm.providers on first use.
+type linkSummaryCompleter struct {
+	server *Server
+
+	mu        sync.Mutex
+	providers map[string]linkSummaryProvider
+}
+
+type linkSummaryProvider struct {
+	provider agent.LLMProvider
+	model    string
+}
+
+func (c *linkSummaryCompleter) CompleteSummary(ctx context.Context, req links.SummaryRequest) (string, error) {
+	target, err := c.provider(req.Provider)
+	if err != nil {
+		return "", err
+	}
+	model := strings.TrimSpace(req.Model)
+	if model == "" {
+		model = target.model
+	}
+	return collectCompletion(ctx, target.provider, &agent.CompletionRequest{
+		Model:     model,
+		System:    req.System,
+		Messages:  []agent.CompletionMessage{{Role: "user", Content: req.Prompt}},
+		MaxTokens: req.MaxTokens,
+	})
+}
+
+func (c *linkSummaryCompleter) provider(name string) (linkSummaryProvider, error) {
+	s := c.server
+	id := normalizeProviderID(name)
+	if id == "" || id == normalizeProviderID(s.config.LLM.DefaultProvider) {
+		if s.llmProvider == nil {
+			return linkSummaryProvider{}, fmt.Errorf("llm provider unavailable")
+		}
+		return linkSummaryProvider{provider: s.llmProvider, model: s.defaultModel}, nil
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if cached, ok := c.providers[id]; ok {
+		return cached, nil
+	}
+	provider, model, err := s.buildProvider(id)
+	if err != nil {
+		return linkSummaryProvider{}, fmt.Errorf("link summary provider %s: %w", id, err)
+	}
+	if c.providers == nil {
+		c.providers = make(map[string]linkSummaryProvider)
+	}
+	c.providers[id] = linkSummaryProvider{provider: provider, model: model}
+	return c.providers[id], nil
+}
@@ -0,0 +1,69 @@
+package gateway
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/haasonsaas/nexus/internal/agent"
+	"github.com/haasonsaas/nexus/internal/config"
+	"github.com/haasonsaas/nexus/internal/links"
+	"github.com/haasonsaas/nexus/pkg/models"
+)
+
+type recordingSummaryProvider struct {
+	fixedProvider
+	req *agent.CompletionRequest
+}
+
+func (p *recordingSummaryProvider) Complete(ctx context.Context, req *agent.CompletionRequest) (<-chan *agent.CompletionChunk, error) {
+	p.req = req
+	ch := make(chan *agent.CompletionChunk, 1)
+	ch <- &agent.CompletionChunk{Text: "Release notes for v2.", Done: true}
+	close(ch)
+	return ch, nil
+}
+
+type staticPageFetcher struct{}
+
+func (staticPageFetcher) FetchText(ctx context.Context, url string) (string, error) {
+	return "v2 adds streaming.", nil
+}
+
+func TestLinkUnderstandingContext_LLMSummary(t *testing.T) {
+	provider := &recordingSummaryProvider{}
+	cfg := &config.Config{}
+	cfg.LLM.DefaultProvider = "anthropic"
+	cfg.Tools.Links = config.LinksConfig{
+		Enabled:        true,
+		MaxLinks:       5,
+		MaxOutputChars: 2000,
+		Models:         []config.LinkModelConfig{{Type: "llm", Model: "claude-haiku"}},
+	}
+	server := &Server{
+		config:      cfg,
+		logger:      slog.New(slog.NewTextHandler(io.Discard, nil)),
+		llmProvider: provider,
+	}
+	if server.newLinkSummarizer(cfg.Tools.Links) == nil {
+		t.Fatal("expected a summarizer for llm link models")
+	}
+	server.linkSummarizer = links.NewLLMSummarizer(staticPageFetcher{}, &linkSummaryCompleter{server: server}, 0)
+
+	msg := &models.Message{Channel: models.ChannelSlack, Content: "what changed? https://example.com/release"}
+	linkContext := server.linkUnderstandingContext(context.Background(), &models.Session{ID: "s1"}, msg, nil)
+
+	want := "https://example.com/release: Release notes for v2."
+	if !strings.Contains(linkContext, want) {
+		t.Fatalf("link context = %q", linkContext)
+	}
+	summaries, ok := msg.Metadata["link_summaries"].(map[string]string)
+	if !ok || summaries["https://example.com/release"] != want {
+		t.Fatalf("unexpected link_summaries metadata %#v", msg.Metadata["link_summaries"])
+	}
+	if provider.req == nil || provider.req.Model != "claude-haiku" || !strings.Contains(provider.req.Messages[0].Content, "v2 adds streaming.") {
+		t.Fatalf("unexpected summary request %+v", provider.req)
+	}
+}
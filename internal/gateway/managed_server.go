@@ -69,6 +69,7 @@ func NewManagedServer(cfg ManagedServerConfig) (*ManagedServer, error) {
 		TaskStore:      server.taskStore,
 		RAGManager:     server.ragIndex,
 		VectorMemory:   server.vectorMemory,
+		MemoryReview:   server.memoryReview,
 		Logger:         logger.With("component", "tool-manager"),
 	})
 	server.toolManager = toolManager
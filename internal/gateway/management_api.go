@@ -0,0 +1,816 @@
+package gateway
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"mime"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/haasonsaas/nexus/internal/agent"
+	"github.com/haasonsaas/nexus/internal/auth"
+	"github.com/haasonsaas/nexus/internal/observability"
+	"github.com/haasonsaas/nexus/internal/privacy"
+	"github.com/haasonsaas/nexus/internal/sessions"
+	statuspkg "github.com/haasonsaas/nexus/internal/status"
+	"github.com/haasonsaas/nexus/internal/storage"
+	"github.com/haasonsaas/nexus/internal/tools/sandbox/firecracker"
+	"github.com/haasonsaas/nexus/pkg/management"
+	"github.com/haasonsaas/nexus/pkg/models"
+	proto "github.com/haasonsaas/nexus/pkg/proto"
+)
+
+const (
+	managementMaxBodyBytes = 1 << 20
+	managementPathPrefix   = "/" + management.ServiceName + "/"
+)
+
+// managementAPI serves the management service over the Connect unary
+// protocol (JSON request and response bodies).
+type managementAPI struct {
+	server  *Server
+	grpc    *grpcService
+	auth    *auth.Service
+	logger  *slog.Logger
+	methods map[string]managementMethod
+
+	// sandbox overrides the server's Firecracker backend in tests.
+	sandbox sandboxSnapshotStore
+}
+
+// sandboxSnapshotStore is the snapshot management surface of the
+// Firecracker backend.
+type sandboxSnapshotStore interface {
+	Snapshots(language string) ([]firecracker.Snapshot, error)
+	CreateSnapshot(ctx context.Context, language string) (firecracker.Snapshot, error)
+	RefreshSnapshots(ctx context.Context, language string) ([]firecracker.Snapshot, error)
+	DeleteSnapshot(id string) error
+}
+
+type managementMethod func(ctx context.Context, body []byte) (any, error)
+
+func (s *Server) newManagementAPI() *managementAPI {
+	logger := s.logger
+	if logger == nil {
+		logger = slog.Default()
+	}
+	api := &managementAPI{
+		server: s,
+		grpc:   newGRPCService(s),
+		auth:   s.authService,
+		logger: logger.With("component", "management_api"),
+	}
+	api.methods = map[string]managementMethod{
+		management.ListSessionsProcedure:   managementUnary(api.listSessions),
+		management.GetSessionProcedure:     managementUnary(api.getSession),
+		management.ListMessagesProcedure:   managementUnary(api.listMessages),
+		management.SendMessageProcedure:    managementUnary(api.sendMessage),
+		management.ListAgentsProcedure:     managementUnary(api.listAgents),
+		management.GetAgentProcedure:       managementUnary(api.getAgent),
+		management.ListApprovalsProcedure:  managementUnary(api.listApprovals),
+		management.DecideApprovalProcedure: managementUnary(api.decideApproval),
+		management.GetUsageProcedure:       managementUnary(api.getUsage),
+		management.EraseIdentityProcedure:  managementUnary(api.eraseIdentity),
+
+		management.ListSandboxSnapshotsProcedure:    managementUnary(api.listSandboxSnapshots),
+		management.CreateSandboxSnapshotProcedure:   managementUnary(api.createSandboxSnapshot),
+		management.RefreshSandboxSnapshotsProcedure: managementUnary(api.refreshSandboxSnapshots),
+		management.DeleteSandboxSnapshotProcedure:   managementUnary(api.deleteSandboxSnapshot),
+
+		management.ListRunsProcedure:  managementUnary(api.listRuns),
+		management.GetRunProcedure:    managementUnary(api.getRun),
+		management.ResumeRunProcedure: managementUnary(api.resumeRun),
+		management.KillRunProcedure:   managementUnary(api.killRun),
+	}
+	return api
+}
+
+func managementUnary[Req any, Resp any](fn func(context.Context, *Req) (*Resp, error)) managementMethod {
+	return func(ctx context.Context, body []byte) (any, error) {
+		req := new(Req)
+		if len(strings.TrimSpace(string(body))) > 0 {
+			if err := json.Unmarshal(body, req); err != nil {
+				return nil, management.Errorf(management.CodeInvalidArgument, "invalid request body: %v", err)
+			}
+		}
+		return fn(ctx, req)
+	}
+}
+
+func (m *managementAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	method, ok := m.methods[r.URL.Path]
+	if !ok {
+		m.writeError(w, management.Errorf(management.CodeUnimplemented, "unknown procedure %s", r.URL.Path))
+		return
+	}
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	if mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type")); err != nil || mediaType != "application/json" {
+		w.WriteHeader(http.StatusUnsupportedMediaType)
+		return
+	}
+
+	ctx, err := m.authenticate(r)
+	if err != nil {
+		m.writeError(w, err)
+		return
+	}
+	scope := management.ProcedureScopes[r.URL.Path]
+	if !auth.HasScope(ctx, scope) {
+		if scope == management.ScopeAdmin {
+			m.server.adminAudit.LogAdminAction(ctx, false, managementCaller(ctx), r.URL.Path, nil)
+		}
+		m.writeError(w, management.Errorf(management.CodePermissionDenied, "api key lacks scope %q", scope))
+		return
+	}
+
+	body, err := io.ReadAll(io.LimitReader(r.Body, managementMaxBodyBytes+1))
+	if err != nil {
+		m.writeError(w, management.Errorf(management.CodeInvalidArgument, "read request body: %v", err))
+		return
+	}
+	if len(body) > managementMaxBodyBytes {
+		m.writeError(w, management.Errorf(management.CodeResourceExhausted, "request body exceeds %d bytes", managementMaxBodyBytes))
+		return
+	}
+
+	resp, err := method(ctx, body)
+	if scope == management.ScopeAdmin {
+		details := map[string]any{"code": "ok"}
+		if err != nil {
+			details["code"] = string(management.CodeOf(err))
+		}
+		m.server.adminAudit.LogAdminAction(ctx, true, managementCaller(ctx), r.URL.Path, details)
+	}
+	if err != nil {
+		m.writeError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		m.logger.Warn("failed to write management response", "procedure", r.URL.Path, "error", err)
+	}
+}
+
+func (m *managementAPI) authenticate(r *http.Request) (context.Context, error) {
+	ctx := r.Context()
+	if m.auth == nil || !m.auth.Enabled() {
+		return ctx, nil
+	}
+	if header := r.Header.Get("Authorization"); strings.HasPrefix(strings.ToLower(header), "bearer ") {
+		user, scopes, err := m.auth.AuthenticateJWT(strings.TrimSpace(header[len("bearer "):]))
+		if err != nil {
+			return nil, management.Errorf(management.CodeUnauthenticated, "invalid token")
+		}
+		return auth.WithScopes(auth.WithUser(ctx, user), scopes), nil
+	}
+	apiKey := r.Header.Get("X-API-Key")
+	if apiKey == "" {
+		apiKey = r.Header.Get("Api-Key")
+	}
+	if apiKey != "" {
+		user, scopes, err := m.auth.AuthenticateAPIKey(apiKey)
+		if err != nil {
+			return nil, management.Errorf(management.CodeUnauthenticated, "invalid api key")
+		}
+		return auth.WithScopes(auth.WithUser(ctx, user), scopes), nil
+	}
+	return nil, management.Errorf(management.CodeUnauthenticated, "missing credentials")
+}
+
+func (m *managementAPI) writeError(w http.ResponseWriter, err error) {
+	var apiErr *management.Error
+	if !errors.As(err, &apiErr) {
+		apiErr = managementErrorFromStatus(err)
+	}
+	if apiErr.Code == management.CodeInternal || apiErr.Code == management.CodeUnknown {
+		m.logger.Warn("management call failed", "error", err)
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(apiErr.Code.HTTPStatus())
+	_ = json.NewEncoder(w).Encode(apiErr) //nolint:errcheck // client may be gone
+}
+
+// managementErrorFromStatus maps gRPC status errors from the shared service
+// code to management error codes.
+func managementErrorFromStatus(err error) *management.Error {
+	st, ok := status.FromError(err)
+	if !ok {
+		return &management.Error{Code: management.CodeInternal, Message: err.Error()}
+	}
+	code := management.CodeUnknown
+	switch st.Code() {
+	case codes.Canceled:
+		code = management.CodeCanceled
+	case codes.InvalidArgument:
+		code = management.CodeInvalidArgument
+	case codes.DeadlineExceeded:
+		code = management.CodeDeadlineExceeded
+	case codes.NotFound:
+		code = management.CodeNotFound
+	case codes.AlreadyExists:
+		code = management.CodeAlreadyExists
+	case codes.PermissionDenied:
+		code = management.CodePermissionDenied
+	case codes.ResourceExhausted:
+		code = management.CodeResourceExhausted
+	case codes.FailedPrecondition:
+		code = management.CodeFailedPrecondition
+	case codes.Aborted:
+		code = management.CodeAborted
+	case codes.Unimplemented:
+		code = management.CodeUnimplemented
+	case codes.Internal:
+		code = management.CodeInternal
+	case codes.Unavailable:
+		code = management.CodeUnavailable
+	case codes.Unauthenticated:
+		code = management.CodeUnauthenticated
+	}
+	return &management.Error{Code: code, Message: st.Message()}
+}
+
+func (m *managementAPI) sessionStore() (sessions.Store, error) {
+	if m.server == nil || m.server.sessions == nil {
+		return nil, management.Errorf(management.CodeFailedPrecondition, "session store unavailable (set database.url)")
+	}
+	return m.server.sessions, nil
+}
+
+func clampManagementLimit(limit int) int {
+	if limit <= 0 || limit > 500 {
+		return 50
+	}
+	return limit
+}
+
+func (m *managementAPI) listSessions(ctx context.Context, req *management.ListSessionsRequest) (*management.ListSessionsResponse, error) {
+	store, err := m.sessionStore()
+	if err != nil {
+		return nil, err
+	}
+	agentID := strings.TrimSpace(req.AgentID)
+	if agentID == "" && m.server.config != nil {
+		agentID = m.server.config.Session.DefaultAgentID
+	}
+	if agentID == "" {
+		agentID = "main"
+	}
+	opts := sessions.ListOptions{
+		Channel: models.ChannelType(strings.TrimSpace(req.Channel)),
+		Limit:   clampManagementLimit(req.Limit),
+		Offset:  max(req.Offset, 0),
+	}
+	list, err := store.List(ctx, agentID, opts)
+	if err != nil {
+		return nil, management.Errorf(management.CodeInternal, "list sessions: %v", err)
+	}
+	if list == nil {
+		list = []*models.Session{}
+	}
+	return &management.ListSessionsResponse{Sessions: list}, nil
+}
+
+func (m *managementAPI) getSession(ctx context.Context, req *management.GetSessionRequest) (*management.GetSessionResponse, error) {
+	store, err := m.sessionStore()
+	if err != nil {
+		return nil, err
+	}
+	if strings.TrimSpace(req.ID) == "" {
+		return nil, management.Errorf(management.CodeInvalidArgument, "id is required")
+	}
+	session, err := store.Get(ctx, req.ID)
+	if err != nil || session == nil {
+		return nil, management.Errorf(management.CodeNotFound, "session %q not found", req.ID)
+	}
+	return &management.GetSessionResponse{Session: session}, nil
+}
+
+func (m *managementAPI) listMessages(ctx context.Context, req *management.ListMessagesRequest) (*management.ListMessagesResponse, error) {
+	store, err := m.sessionStore()
+	if err != nil {
+		return nil, err
+	}
+	if strings.TrimSpace(req.SessionID) == "" {
+		return nil, management.Errorf(management.CodeInvalidArgument, "session_id is required")
+	}
+	if session, err := store.Get(ctx, req.SessionID); err != nil || session == nil {
+		return nil, management.Errorf(management.CodeNotFound, "session %q not found", req.SessionID)
+	}
+	msgs, err := store.GetHistory(ctx, req.SessionID, clampManagementLimit(req.Limit))
+	if err != nil {
+		return nil, management.Errorf(management.CodeInternal, "load history: %v", err)
+	}
+	if msgs == nil {
+		msgs = []*models.Message{}
+	}
+	return &management.ListMessagesResponse{Messages: msgs}, nil
+}
+
+func (m *managementAPI) sendMessage(ctx context.Context, req *management.SendMessageRequest) (*management.SendMessageResponse, error) {
+	if strings.TrimSpace(req.Content) == "" {
+		return nil, management.Errorf(management.CodeInvalidArgument, "content is required")
+	}
+	var complete *proto.MessageComplete
+	stream := &wsStream{
+		ctx: ctx,
+		send: func(msg *proto.ServerMessage) error {
+			if done := msg.GetMessageComplete(); done != nil {
+				complete = done
+			}
+			return nil
+		},
+	}
+	err := m.grpc.handleSendMessage(ctx, stream, &proto.SendMessageRequest{
+		SessionId: req.SessionID,
+		Content:   req.Content,
+		Metadata:  req.Metadata,
+	})
+	if err != nil {
+		if _, ok := status.FromError(err); ok {
+			return nil, err
+		}
+		return nil, management.Errorf(management.CodeInternal, "run failed: %v", err)
+	}
+	if complete == nil {
+		return nil, management.Errorf(management.CodeInternal, "run finished without a reply")
+	}
+	return &management.SendMessageResponse{
+		SessionID: complete.SessionId,
+		Reply:     messageFromCompletion(complete.GetMessage()),
+	}, nil
+}
+
+func messageFromCompletion(msg *proto.Message) *models.Message {
+	if msg == nil {
+		return nil
+	}
+	out := &models.Message{
+		ID:        msg.Id,
+		SessionID: msg.SessionId,
+		Channel:   channelFromProto(msg.Channel),
+		ChannelID: msg.ChannelId,
+		Direction: models.DirectionOutbound,
+		Role:      models.RoleAssistant,
+		Content:   msg.Content,
+	}
+	if msg.CreatedAt != nil {
+		out.CreatedAt = msg.CreatedAt.AsTime()
+	}
+	for _, result := range msg.ToolResults {
+		out.ToolResults = append(out.ToolResults, models.ToolResult{
+			ToolCallID: result.ToolCallId,
+			Content:    result.Content,
+			IsError:    result.IsError,
+		})
+	}
+	return out
+}
+
+func (m *managementAPI) listAgents(ctx context.Context, req *management.ListAgentsRequest) (*management.ListAgentsResponse, error) {
+	limit := req.Limit
+	if limit <= 0 || limit > 500 {
+		limit = 25
+	}
+	agents, total, err := m.grpc.agentStore.List(ctx, resolveUserID(ctx, ""), limit, max(req.Offset, 0))
+	if err != nil {
+		return nil, management.Errorf(management.CodeInternal, "list agents: %v", err)
+	}
+	if agents == nil {
+		agents = []*models.Agent{}
+	}
+	return &management.ListAgentsResponse{Agents: agents, Total: total}, nil
+}
+
+func (m *managementAPI) getAgent(ctx context.Context, req *management.GetAgentRequest) (*management.GetAgentResponse, error) {
+	if strings.TrimSpace(req.ID) == "" {
+		return nil, management.Errorf(management.CodeInvalidArgument, "id is required")
+	}
+	found, err := m.grpc.agentStore.Get(ctx, req.ID)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return nil, management.Errorf(management.CodeNotFound, "agent %q not found", req.ID)
+		}
+		return nil, management.Errorf(management.CodeInternal, "get agent: %v", err)
+	}
+	return &management.GetAgentResponse{Agent: found}, nil
+}
+
+func (m *managementAPI) listApprovals(ctx context.Context, req *management.ListApprovalsRequest) (*management.ListApprovalsResponse, error) {
+	resp := &management.ListApprovalsResponse{Approvals: []*management.Approval{}}
+	checker := m.server.ApprovalChecker()
+	if checker == nil {
+		return resp, nil
+	}
+	pending, err := checker.GetPendingRequests(ctx, strings.TrimSpace(req.AgentID))
+	if err != nil {
+		return nil, management.Errorf(management.CodeInternal, "list approvals: %v", err)
+	}
+	sort.Slice(pending, func(i, j int) bool {
+		return pending[i].CreatedAt.Before(pending[j].CreatedAt)
+	})
+	for _, item := range pending {
+		resp.Approvals = append(resp.Approvals, approvalToManagement(item))
+	}
+	return resp, nil
+}
+
+func (m *managementAPI) decideApproval(ctx context.Context, req *management.DecideApprovalRequest) (*management.DecideApprovalResponse, error) {
+	if strings.TrimSpace(req.ID) == "" {
+		return nil, management.Errorf(management.CodeInvalidArgument, "id is required")
+	}
+	decision := strings.ToLower(strings.TrimSpace(req.Decision))
+	if decision != management.DecisionApprove && decision != management.DecisionDeny {
+		return nil, management.Errorf(management.CodeInvalidArgument, "decision must be %q or %q", management.DecisionApprove, management.DecisionDeny)
+	}
+	checker := m.server.ApprovalChecker()
+	if checker == nil {
+		return nil, management.Errorf(management.CodeNotFound, "approval %q not found", req.ID)
+	}
+	pending, err := checker.GetPendingRequests(ctx, "")
+	if err != nil {
+		return nil, management.Errorf(management.CodeInternal, "list approvals: %v", err)
+	}
+	var target *agent.ApprovalRequest
+	for _, item := range pending {
+		if item.ID == req.ID {
+			target = item
+			break
+		}
+	}
+	if target == nil {
+		return nil, management.Errorf(management.CodeNotFound, "pending approval %q not found", req.ID)
+	}
+
+	decidedBy := "api"
+	if caller := managementCaller(ctx); caller != "" {
+		decidedBy = "api:" + caller
+	}
+	approval := approvalToManagement(target)
+	if decision == management.DecisionApprove {
+		err = checker.Approve(ctx, req.ID, decidedBy)
+	} else {
+		err = checker.Deny(ctx, req.ID, decidedBy)
+	}
+	if err != nil {
+		return nil, management.Errorf(management.CodeInternal, "record decision: %v", err)
+	}
+
+	approval.Decision = string(agent.ApprovalAllowed)
+	if decision == management.DecisionDeny {
+		approval.Decision = string(agent.ApprovalDenied)
+	}
+	approval.DecidedBy = decidedBy
+	approval.DecidedAt = time.Now()
+	return &management.DecideApprovalResponse{Approval: approval}, nil
+}
+
+func (m *managementAPI) eraseIdentity(ctx context.Context, req *management.EraseIdentityRequest) (*management.EraseIdentityResponse, error) {
+	if _, _, err := privacy.ParsePeer(req.Peer); err != nil {
+		return nil, management.Errorf(management.CodeInvalidArgument, "%v", err)
+	}
+	requestedBy := strings.TrimSpace(req.RequestedBy)
+	if requestedBy == "" {
+		requestedBy = "api"
+		if caller := managementCaller(ctx); caller != "" {
+			requestedBy = "api:" + caller
+		}
+	}
+	record, path, err := m.server.eraseIdentity(ctx, req.Peer, requestedBy)
+	if record == nil {
+		return nil, management.Errorf(management.CodeFailedPrecondition, "erase identity: %v", err)
+	}
+	if err != nil {
+		m.logger.Warn("identity erasure incomplete", "peer", record.Peer, "error", err)
+	}
+	return &management.EraseIdentityResponse{
+		RecordID:   record.ID,
+		Identities: record.Identities,
+		Removed: management.ErasureCounts{
+			Sessions:    record.Removed.Sessions,
+			Messages:    record.Removed.Messages,
+			Artifacts:   record.Removed.Artifacts,
+			Memories:    record.Removed.Memories,
+			Events:      record.Removed.Events,
+			Checkpoints: record.Removed.Checkpoints,
+		},
+		RecordPath:  path,
+		Errors:      record.Errors,
+		CompletedAt: record.CompletedAt,
+	}, nil
+}
+
+// managementCaller returns the ID of the authenticated caller, if any.
+func managementCaller(ctx context.Context) string {
+	if user, ok := auth.UserFromContext(ctx); ok && user != nil {
+		return user.ID
+	}
+	return ""
+}
+
+func approvalToManagement(req *agent.ApprovalRequest) *management.Approval {
+	return &management.Approval{
+		ID:         req.ID,
+		ToolCallID: req.ToolCallID,
+		ToolName:   req.ToolName,
+		Input:      string(req.Input),
+		AgentID:    req.AgentID,
+		SessionID:  req.SessionID,
+		Reason:     req.Reason,
+		Diff:       req.Diff,
+		Decision:   string(req.Decision),
+		CreatedAt:  req.CreatedAt,
+		ExpiresAt:  req.ExpiresAt,
+		DecidedAt:  req.DecidedAt,
+		DecidedBy:  req.DecidedBy,
+	}
+}
+
+func (m *managementAPI) getUsage(ctx context.Context, req *management.GetUsageRequest) (*management.GetUsageResponse, error) {
+	if m.server.eventStore == nil {
+		return nil, management.Errorf(management.CodeUnavailable, "usage data unavailable")
+	}
+	days := req.Days
+	if days <= 0 {
+		days = 7
+	}
+	days = min(days, 90)
+	now := time.Now()
+	since := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location()).AddDate(0, 0, -days+1)
+
+	events, err := m.server.eventStore.GetByType(observability.EventTypeLLMResponse, 0)
+	if err != nil {
+		return nil, management.Errorf(management.CodeInternal, "load usage events: %v", err)
+	}
+	resp := &management.GetUsageResponse{Since: since, Models: []*management.ModelUsage{}}
+	byModel := make(map[string]*management.ModelUsage)
+	for _, event := range events {
+		if event.Timestamp.Before(since) {
+			continue
+		}
+		provider, _ := event.Data["provider"].(string) //nolint:errcheck // missing is empty
+		model, _ := event.Data["model"].(string)       //nolint:errcheck // missing is empty
+		if provider == "" || model == "" {
+			continue
+		}
+		input := usageEventInt(event.Data["input_tokens"])
+		output := usageEventInt(event.Data["output_tokens"])
+		reasoning := usageEventInt(event.Data["reasoning_tokens"])
+		cost := statuspkg.EstimateUsageCost(input, output, statuspkg.ResolveModelCostConfig(provider, model, m.server.config))
+
+		key := provider + "/" + model
+		entry := byModel[key]
+		if entry == nil {
+			entry = &management.ModelUsage{Provider: provider, Model: model}
+			byModel[key] = entry
+			resp.Models = append(resp.Models, entry)
+		}
+		entry.Requests++
+		entry.InputTokens += int64(input)
+		entry.OutputTokens += int64(output)
+		entry.ReasoningTokens += int64(reasoning)
+		entry.EstimatedCostUSD += cost
+		resp.InputTokens += int64(input)
+		resp.OutputTokens += int64(output)
+		resp.ReasoningTokens += int64(reasoning)
+		resp.EstimatedCostUSD += cost
+	}
+	sort.Slice(resp.Models, func(i, j int) bool {
+		if resp.Models[i].Provider != resp.Models[j].Provider {
+			return resp.Models[i].Provider < resp.Models[j].Provider
+		}
+		return resp.Models[i].Model < resp.Models[j].Model
+	})
+	return resp, nil
+}
+
+func usageEventInt(value any) int {
+	switch typed := value.(type) {
+	case int:
+		return typed
+	case int64:
+		return int(typed)
+	case int32:
+		return int(typed)
+	case float64:
+		return int(typed)
+	case json.Number:
+		parsed, _ := typed.Int64() //nolint:errcheck // invalid counts as zero
+		return int(parsed)
+	default:
+		return 0
+	}
+}
+
+func (m *managementAPI) sandboxSnapshots() (sandboxSnapshotStore, error) {
+	if m.sandbox != nil {
+		return m.sandbox, nil
+	}
+	if m.server.firecrackerBackend == nil {
+		return nil, management.Errorf(management.CodeFailedPrecondition, "the firecracker sandbox backend is not running")
+	}
+	return m.server.firecrackerBackend, nil
+}
+
+func (m *managementAPI) listSandboxSnapshots(ctx context.Context, req *management.ListSandboxSnapshotsRequest) (*management.ListSandboxSnapshotsResponse, error) {
+	store, err := m.sandboxSnapshots()
+	if err != nil {
+		return nil, err
+	}
+	snapshots, err := store.Snapshots(strings.TrimSpace(req.Language))
+	if err != nil {
+		return nil, sandboxSnapshotError(err)
+	}
+	return &management.ListSandboxSnapshotsResponse{Snapshots: sandboxSnapshotsToManagement(snapshots)}, nil
+}
+
+func (m *managementAPI) createSandboxSnapshot(ctx context.Context, req *management.CreateSandboxSnapshotRequest) (*management.CreateSandboxSnapshotResponse, error) {
+	language := strings.TrimSpace(req.Language)
+	if language == "" {
+		return nil, management.Errorf(management.CodeInvalidArgument, "language is required")
+	}
+	store, err := m.sandboxSnapshots()
+	if err != nil {
+		return nil, err
+	}
+	snapshot, err := store.CreateSnapshot(ctx, language)
+	if err != nil {
+		return nil, sandboxSnapshotError(err)
+	}
+	return &management.CreateSandboxSnapshotResponse{Snapshot: sandboxSnapshotToManagement(snapshot)}, nil
+}
+
+func (m *managementAPI) refreshSandboxSnapshots(ctx context.Context, req *management.RefreshSandboxSnapshotsRequest) (*management.RefreshSandboxSnapshotsResponse, error) {
+	store, err := m.sandboxSnapshots()
+	if err != nil {
+		return nil, err
+	}
+	snapshots, err := store.RefreshSnapshots(ctx, strings.TrimSpace(req.Language))
+	if err != nil {
+		return nil, sandboxSnapshotError(err)
+	}
+	return &management.RefreshSandboxSnapshotsResponse{Snapshots: sandboxSnapshotsToManagement(snapshots)}, nil
+}
+
+func (m *managementAPI) deleteSandboxSnapshot(ctx context.Context, req *management.DeleteSandboxSnapshotRequest) (*management.DeleteSandboxSnapshotResponse, error) {
+	id := strings.TrimSpace(req.ID)
+	if id == "" {
+		return nil, management.Errorf(management.CodeInvalidArgument, "id is required")
+	}
+	store, err := m.sandboxSnapshots()
+	if err != nil {
+		return nil, err
+	}
+	if err := store.DeleteSnapshot(id); err != nil {
+		return nil, sandboxSnapshotError(err)
+	}
+	return &management.DeleteSandboxSnapshotResponse{}, nil
+}
+
+func sandboxSnapshotError(err error) error {
+	switch {
+	case errors.Is(err, firecracker.ErrSnapshotNotFound):
+		return management.Errorf(management.CodeNotFound, "%v", err)
+	case errors.Is(err, firecracker.ErrSnapshotsDisabled):
+		return management.Errorf(management.CodeFailedPrecondition, "%v", err)
+	default:
+		return management.Errorf(management.CodeInternal, "%v", err)
+	}
+}
+
+func sandboxSnapshotsToManagement(snapshots []firecracker.Snapshot) []*management.SandboxSnapshot {
+	out := make([]*management.SandboxSnapshot, 0, len(snapshots))
+	for _, snapshot := range snapshots {
+		out = append(out, sandboxSnapshotToManagement(snapshot))
+	}
+	return out
+}
+
+func sandboxSnapshotToManagement(snapshot firecracker.Snapshot) *management.SandboxSnapshot {
+	return &management.SandboxSnapshot{
+		ID:        snapshot.ID,
+		Language:  snapshot.Language,
+		Type:      string(snapshot.Type),
+		CreatedAt: snapshot.CreatedAt,
+		SizeBytes: snapshot.Size,
+	}
+}
+
+func (m *managementAPI) runCheckpoints() (*agent.FileCheckpointStore, error) {
+	if m.server.runCheckpoints == nil {
+		return nil, management.Errorf(management.CodeFailedPrecondition, "run checkpoints are disabled (session.checkpoints.enabled)")
+	}
+	return m.server.runCheckpoints, nil
+}
+
+func (m *managementAPI) listRuns(ctx context.Context, req *management.ListRunsRequest) (*management.ListRunsResponse, error) {
+	store, err := m.runCheckpoints()
+	if err != nil {
+		return nil, err
+	}
+	checkpoints, err := store.List(ctx)
+	if err != nil {
+		return nil, runCheckpointError(err)
+	}
+	status := strings.ToLower(strings.TrimSpace(req.Status))
+	runs := make([]*management.Run, 0, len(checkpoints))
+	for _, checkpoint := range checkpoints {
+		if status != "" && string(checkpoint.Status) != status {
+			continue
+		}
+		runs = append(runs, runToManagement(checkpoint))
+	}
+	return &management.ListRunsResponse{Runs: runs}, nil
+}
+
+func (m *managementAPI) getRun(ctx context.Context, req *management.GetRunRequest) (*management.GetRunResponse, error) {
+	id := strings.TrimSpace(req.ID)
+	if id == "" {
+		return nil, management.Errorf(management.CodeInvalidArgument, "id is required")
+	}
+	store, err := m.runCheckpoints()
+	if err != nil {
+		return nil, err
+	}
+	checkpoint, err := store.Get(ctx, id)
+	if err != nil {
+		return nil, runCheckpointError(err)
+	}
+	return &management.GetRunResponse{Run: runToManagement(checkpoint)}, nil
+}
+
+func (m *managementAPI) resumeRun(ctx context.Context, req *management.ResumeRunRequest) (*management.ResumeRunResponse, error) {
+	id := strings.TrimSpace(req.ID)
+	if id == "" {
+		return nil, management.Errorf(management.CodeInvalidArgument, "id is required")
+	}
+	if _, err := m.runCheckpoints(); err != nil {
+		return nil, err
+	}
+	checkpoint, err := m.server.resumeCheckpointedRun(ctx, id)
+	if err != nil {
+		return nil, runCheckpointError(err)
+	}
+	return &management.ResumeRunResponse{Run: runToManagement(checkpoint)}, nil
+}
+
+func (m *managementAPI) killRun(ctx context.Context, req *management.KillRunRequest) (*management.KillRunResponse, error) {
+	id := strings.TrimSpace(req.ID)
+	if id == "" && !req.All {
+		return nil, management.Errorf(management.CodeInvalidArgument, "id or all is required")
+	}
+	killed := m.server.killActiveRuns(id, req.All)
+	if len(killed) == 0 && !req.All {
+		return nil, management.Errorf(management.CodeNotFound, "no active run %q", id)
+	}
+	for _, sessionID := range killed {
+		m.logger.Warn("run killed", "session_id", sessionID, "caller", managementCaller(ctx))
+	}
+	return &management.KillRunResponse{SessionIDs: killed}, nil
+}
+
+func runCheckpointError(err error) error {
+	switch {
+	case errors.Is(err, agent.ErrCheckpointNotFound):
+		return management.Errorf(management.CodeNotFound, "%v", err)
+	case errors.Is(err, errRunActive), errors.Is(err, errRunNotResumable):
+		return management.Errorf(management.CodeFailedPrecondition, "%v", err)
+	default:
+		return management.Errorf(management.CodeInternal, "%v", err)
+	}
+}
+
+func runToManagement(checkpoint *agent.RunCheckpoint) *management.Run {
+	run := &management.Run{
+		ID:        checkpoint.RunID,
+		SessionID: checkpoint.SessionID,
+		AgentID:   checkpoint.AgentID,
+		Status:    string(checkpoint.Status),
+		Error:     checkpoint.Error,
+		Model:     checkpoint.Model,
+		Iteration: checkpoint.Iteration,
+		ToolCalls: checkpoint.ToolCalls,
+		Messages:  len(checkpoint.Messages),
+		CreatedAt: checkpoint.CreatedAt,
+		UpdatedAt: checkpoint.UpdatedAt,
+	}
+	if checkpoint.Message != nil {
+		run.Channel = string(checkpoint.Message.Channel)
+		run.Request = checkpoint.Message.Content
+	}
+	for _, call := range checkpoint.PendingToolCalls() {
+		run.PendingTools = append(run.PendingTools, call.Name)
+	}
+	return run
+}
@@ -0,0 +1,390 @@
+package gateway
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/haasonsaas/nexus/internal/agent"
+	"github.com/haasonsaas/nexus/internal/auth"
+	"github.com/haasonsaas/nexus/internal/config"
+	"github.com/haasonsaas/nexus/internal/observability"
+	"github.com/haasonsaas/nexus/internal/privacy"
+	"github.com/haasonsaas/nexus/internal/sessions"
+	"github.com/haasonsaas/nexus/internal/tools/sandbox/firecracker"
+	"github.com/haasonsaas/nexus/pkg/management"
+	"github.com/haasonsaas/nexus/pkg/models"
+)
+
+func newManagementTestServer(t *testing.T) (*Server, *httptest.Server) {
+	t.Helper()
+	server := &Server{config: &config.Config{Session: config.SessionConfig{DefaultAgentID: "main"}}}
+	server.sessions = sessions.NewMemoryStore()
+	server.eventStore = observability.NewMemoryEventStore(100)
+	server.authService = auth.NewService(auth.Config{APIKeys: []auth.APIKeyConfig{
+		{Key: "full", UserID: "ops"},
+		{Key: "reader", UserID: "dashboard", Scopes: []string{"sessions:read", "messages:read", "usage:read"}},
+		{Key: "approver", UserID: "pager", Scopes: []string{"approvals:*"}},
+		{Key: "admin", UserID: "pager", Role: auth.RoleAdmin},
+	}})
+	server.approvalChecker = server.newApprovalChecker(nil)
+
+	httpServer := httptest.NewServer(server.newManagementAPI())
+	t.Cleanup(httpServer.Close)
+	return server, httpServer
+}
+
+func TestManagementAPISessionsAndScopes(t *testing.T) {
+	ctx := context.Background()
+	server, httpServer := newManagementTestServer(t)
+	session, err := server.sessions.GetOrCreate(ctx, "main:api:user-1", "main", models.ChannelAPI, "user-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := server.sessions.AppendMessage(ctx, session.ID, &models.Message{Role: models.RoleUser, Content: "hello"}); err != nil {
+		t.Fatal(err)
+	}
+
+	reader := management.NewClient(httpServer.URL, management.WithAPIKey("reader"))
+	list, err := reader.ListSessions(ctx, &management.ListSessionsRequest{})
+	if err != nil {
+		t.Fatalf("ListSessions: %v", err)
+	}
+	if len(list.Sessions) != 1 || list.Sessions[0].ID != session.ID {
+		t.Fatalf("unexpected sessions %+v", list.Sessions)
+	}
+	msgs, err := reader.ListMessages(ctx, &management.ListMessagesRequest{SessionID: session.ID})
+	if err != nil {
+		t.Fatalf("ListMessages: %v", err)
+	}
+	if len(msgs.Messages) != 1 || msgs.Messages[0].Content != "hello" {
+		t.Fatalf("unexpected messages %+v", msgs.Messages)
+	}
+
+	_, err = reader.GetSession(ctx, &management.GetSessionRequest{ID: "missing"})
+	if management.CodeOf(err) != management.CodeNotFound {
+		t.Fatalf("expected not_found, got %v", err)
+	}
+	_, err = reader.ListApprovals(ctx, &management.ListApprovalsRequest{})
+	if management.CodeOf(err) != management.CodePermissionDenied {
+		t.Fatalf("expected permission_denied, got %v", err)
+	}
+	_, err = management.NewClient(httpServer.URL).ListSessions(ctx, &management.ListSessionsRequest{})
+	if management.CodeOf(err) != management.CodeUnauthenticated {
+		t.Fatalf("expected unauthenticated, got %v", err)
+	}
+	_, err = management.NewClient(httpServer.URL, management.WithAPIKey("full")).SendMessage(ctx, &management.SendMessageRequest{})
+	if management.CodeOf(err) != management.CodeInvalidArgument {
+		t.Fatalf("expected invalid_argument, got %v", err)
+	}
+}
+
+func TestManagementAPIApprovals(t *testing.T) {
+	ctx := context.Background()
+	server, httpServer := newManagementTestServer(t)
+	req, err := server.approvalChecker.CreateApprovalRequest(ctx, "main", "session-1", models.ToolCall{ID: "call-1", Name: "exec"}, "needs approval")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	client := management.NewClient(httpServer.URL, management.WithAPIKey("approver"))
+	list, err := client.ListApprovals(ctx, &management.ListApprovalsRequest{})
+	if err != nil {
+		t.Fatalf("ListApprovals: %v", err)
+	}
+	if len(list.Approvals) != 1 || list.Approvals[0].ID != req.ID || list.Approvals[0].ToolName != "exec" {
+		t.Fatalf("unexpected approvals %+v", list.Approvals)
+	}
+
+	_, err = client.DecideApproval(ctx, &management.DecideApprovalRequest{ID: req.ID, Decision: management.DecisionDeny})
+	if management.CodeOf(err) != management.CodePermissionDenied {
+		t.Fatalf("expected approval overrides to need admin, got %v", err)
+	}
+
+	client = management.NewClient(httpServer.URL, management.WithAPIKey("admin"))
+	_, err = client.DecideApproval(ctx, &management.DecideApprovalRequest{ID: req.ID, Decision: "maybe"})
+	if management.CodeOf(err) != management.CodeInvalidArgument {
+		t.Fatalf("expected invalid_argument, got %v", err)
+	}
+	decided, err := client.DecideApproval(ctx, &management.DecideApprovalRequest{ID: req.ID, Decision: management.DecisionDeny})
+	if err != nil {
+		t.Fatalf("DecideApproval: %v", err)
+	}
+	if decided.Approval.Decision != "denied" || decided.Approval.DecidedBy != "api:pager" {
+		t.Fatalf("unexpected decision %+v", decided.Approval)
+	}
+	_, err = client.DecideApproval(ctx, &management.DecideApprovalRequest{ID: req.ID, Decision: management.DecisionApprove})
+	if management.CodeOf(err) != management.CodeNotFound {
+		t.Fatalf("expected decided approval to be gone, got %v", err)
+	}
+}
+
+func TestManagementAPIEraseIdentity(t *testing.T) {
+	ctx := context.Background()
+	server, httpServer := newManagementTestServer(t)
+	dir := t.TempDir()
+	server.config.Privacy.Erasure = config.ErasureConfig{
+		AuditDir:       filepath.Join(dir, "erasures"),
+		SigningKeyPath: filepath.Join(dir, "erasure.key"),
+	}
+	if _, err := server.sessions.GetOrCreate(ctx, "main:telegram:42", "main", models.ChannelTelegram, "42"); err != nil {
+		t.Fatal(err)
+	}
+
+	req := &management.EraseIdentityRequest{Peer: "telegram:42"}
+	_, err := management.NewClient(httpServer.URL, management.WithAPIKey("reader")).EraseIdentity(ctx, req)
+	if management.CodeOf(err) != management.CodePermissionDenied {
+		t.Fatalf("expected permission_denied, got %v", err)
+	}
+
+	client := management.NewClient(httpServer.URL, management.WithAPIKey("admin"))
+	if _, err := client.EraseIdentity(ctx, &management.EraseIdentityRequest{Peer: "nobody"}); management.CodeOf(err) != management.CodeInvalidArgument {
+		t.Fatalf("expected invalid_argument, got %v", err)
+	}
+	resp, err := client.EraseIdentity(ctx, req)
+	if err != nil {
+		t.Fatalf("EraseIdentity: %v", err)
+	}
+	if resp.Removed.Sessions != 1 || resp.RecordPath == "" || len(resp.Errors) != 0 {
+		t.Fatalf("unexpected erasure %+v", resp)
+	}
+	record, err := privacy.ReadRecord(resp.RecordPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if record.RequestedBy != "api:pager" {
+		t.Fatalf("expected the caller in the record, got %q", record.RequestedBy)
+	}
+}
+
+func TestManagementAPIUsage(t *testing.T) {
+	server, httpServer := newManagementTestServer(t)
+	for _, data := range []map[string]interface{}{
+		{"provider": "anthropic", "model": "claude-sonnet", "input_tokens": 100, "output_tokens": 20},
+		{"provider": "anthropic", "model": "claude-sonnet", "input_tokens": 50, "output_tokens": 5},
+		{"provider": "openai", "model": "gpt-4o", "input_tokens": 10, "output_tokens": 1},
+	} {
+		if err := server.eventStore.Record(&observability.Event{Type: observability.EventTypeLLMResponse, Timestamp: time.Now(), Data: data}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	usage, err := management.NewClient(httpServer.URL, management.WithAPIKey("reader")).GetUsage(context.Background(), &management.GetUsageRequest{Days: 1})
+	if err != nil {
+		t.Fatalf("GetUsage: %v", err)
+	}
+	if usage.InputTokens != 160 || usage.OutputTokens != 26 || len(usage.Models) != 2 {
+		t.Fatalf("unexpected usage %+v", usage)
+	}
+	if m := usage.Models[0]; m.Provider != "anthropic" || m.Requests != 2 || m.InputTokens != 150 {
+		t.Fatalf("unexpected model usage %+v", m)
+	}
+}
+
+// fakeSnapshotStore keeps snapshots in memory.
+type fakeSnapshotStore struct {
+	snapshots []firecracker.Snapshot
+	created   int
+}
+
+func (f *fakeSnapshotStore) Snapshots(language string) ([]firecracker.Snapshot, error) {
+	var out []firecracker.Snapshot
+	for _, snapshot := range f.snapshots {
+		if language == "" || snapshot.Language == language {
+			out = append(out, snapshot)
+		}
+	}
+	return out, nil
+}
+
+func (f *fakeSnapshotStore) CreateSnapshot(_ context.Context, language string) (firecracker.Snapshot, error) {
+	snapshot := firecracker.Snapshot{ID: fmt.Sprintf("%s-%d", language, f.created), Language: language, Type: "full", CreatedAt: time.Now(), Size: 1024}
+	f.created++
+	f.snapshots = append(f.snapshots, snapshot)
+	return snapshot, nil
+}
+
+func (f *fakeSnapshotStore) RefreshSnapshots(ctx context.Context, language string) ([]firecracker.Snapshot, error) {
+	f.snapshots = nil
+	snapshot, err := f.CreateSnapshot(ctx, language)
+	return []firecracker.Snapshot{snapshot}, err
+}
+
+func (f *fakeSnapshotStore) DeleteSnapshot(id string) error {
+	for i, snapshot := range f.snapshots {
+		if snapshot.ID == id {
+			f.snapshots = append(f.snapshots[:i], f.snapshots[i+1:]...)
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: %s", firecracker.ErrSnapshotNotFound, id)
+}
+
+func TestManagementAPISandboxSnapshots(t *testing.T) {
+	ctx := context.Background()
+	server, httpServer := newManagementTestServer(t)
+	_, err := management.NewClient(httpServer.URL, management.WithAPIKey("admin")).ListSandboxSnapshots(ctx, &management.ListSandboxSnapshotsRequest{})
+	if management.CodeOf(err) != management.CodeFailedPrecondition {
+		t.Fatalf("expected failed_precondition without a firecracker backend, got %v", err)
+	}
+
+	api := server.newManagementAPI()
+	api.sandbox = &fakeSnapshotStore{}
+	sandboxServer := httptest.NewServer(api)
+	defer sandboxServer.Close()
+
+	_, err = management.NewClient(sandboxServer.URL, management.WithAPIKey("reader")).ListSandboxSnapshots(ctx, &management.ListSandboxSnapshotsRequest{})
+	if management.CodeOf(err) != management.CodePermissionDenied {
+		t.Fatalf("expected permission_denied, got %v", err)
+	}
+	admin := management.NewClient(sandboxServer.URL, management.WithAPIKey("admin"))
+	if _, err := admin.CreateSandboxSnapshot(ctx, &management.CreateSandboxSnapshotRequest{}); management.CodeOf(err) != management.CodeInvalidArgument {
+		t.Fatalf("expected invalid_argument, got %v", err)
+	}
+	created, err := admin.CreateSandboxSnapshot(ctx, &management.CreateSandboxSnapshotRequest{Language: "python"})
+	if err != nil {
+		t.Fatalf("CreateSandboxSnapshot: %v", err)
+	}
+	if created.Snapshot.ID != "python-0" || created.Snapshot.SizeBytes != 1024 {
+		t.Fatalf("unexpected snapshot %+v", created.Snapshot)
+	}
+	refreshed, err := admin.RefreshSandboxSnapshots(ctx, &management.RefreshSandboxSnapshotsRequest{Language: "python"})
+	if err != nil || len(refreshed.Snapshots) != 1 {
+		t.Fatalf("RefreshSandboxSnapshots: %+v, %v", refreshed, err)
+	}
+	list, err := admin.ListSandboxSnapshots(ctx, &management.ListSandboxSnapshotsRequest{Language: "python"})
+	if err != nil || len(list.Snapshots) != 1 || list.Snapshots[0].ID != refreshed.Snapshots[0].ID {
+		t.Fatalf("ListSandboxSnapshots: %+v, %v", list, err)
+	}
+	if _, err := admin.DeleteSandboxSnapshot(ctx, &management.DeleteSandboxSnapshotRequest{ID: "python-0"}); management.CodeOf(err) != management.CodeNotFound {
+		t.Fatalf("expected not_found for the replaced snapshot, got %v", err)
+	}
+	if _, err := admin.DeleteSandboxSnapshot(ctx, &management.DeleteSandboxSnapshotRequest{ID: list.Snapshots[0].ID}); err != nil {
+		t.Fatalf("DeleteSandboxSnapshot: %v", err)
+	}
+}
+
+func TestManagementAPIRuns(t *testing.T) {
+	ctx := context.Background()
+	server, httpServer := newManagementTestServer(t)
+	admin := management.NewClient(httpServer.URL, management.WithAPIKey("admin"))
+	if _, err := admin.ListRuns(ctx, &management.ListRunsRequest{}); management.CodeOf(err) != management.CodeFailedPrecondition {
+		t.Fatalf("expected failed_precondition with checkpoints disabled, got %v", err)
+	}
+
+	store, err := agent.NewFileCheckpointStore(t.TempDir(), agent.CheckpointRetention{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	server.runCheckpoints = store
+	if err := store.Save(ctx, &agent.RunCheckpoint{
+		RunID:     "session-1-msg-1",
+		SessionID: "session-1",
+		Message:   &models.Message{Channel: models.ChannelAPI, Content: "migrate the repo"},
+		Messages: []agent.CompletionMessage{
+			{Role: "user", Content: "migrate the repo"},
+			{Role: "assistant", ToolCalls: []models.ToolCall{{ID: "call-1", Name: "exec"}}},
+		},
+		Iteration: 4,
+		Status:    agent.CheckpointFailed,
+		Error:     "provider unavailable",
+		UpdatedAt: time.Now(),
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := management.NewClient(httpServer.URL, management.WithAPIKey("reader")).ListRuns(ctx, &management.ListRunsRequest{}); management.CodeOf(err) != management.CodePermissionDenied {
+		t.Fatalf("expected permission_denied, got %v", err)
+	}
+	list, err := admin.ListRuns(ctx, &management.ListRunsRequest{Status: "failed"})
+	if err != nil || len(list.Runs) != 1 {
+		t.Fatalf("ListRuns: %+v, %v", list, err)
+	}
+	run := list.Runs[0]
+	if run.ID != "session-1-msg-1" || run.Request != "migrate the repo" || run.Iteration != 4 || run.Messages != 2 || len(run.PendingTools) != 1 || run.PendingTools[0] != "exec" {
+		t.Fatalf("unexpected run %+v", run)
+	}
+	if list, err := admin.ListRuns(ctx, &management.ListRunsRequest{Status: "interrupted"}); err != nil || len(list.Runs) != 0 {
+		t.Fatalf("ListRuns(interrupted): %+v, %v", list, err)
+	}
+	if _, err := admin.GetRun(ctx, &management.GetRunRequest{ID: "missing"}); management.CodeOf(err) != management.CodeNotFound {
+		t.Fatalf("expected not_found, got %v", err)
+	}
+	if _, err := admin.ResumeRun(ctx, &management.ResumeRunRequest{}); management.CodeOf(err) != management.CodeInvalidArgument {
+		t.Fatalf("expected invalid_argument, got %v", err)
+	}
+}
+
+func TestManagementAPIKillRun(t *testing.T) {
+	ctx := context.Background()
+	server, httpServer := newManagementTestServer(t)
+	admin := management.NewClient(httpServer.URL, management.WithAPIKey("admin"))
+
+	runCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	token := server.registerActiveRun("session-1", cancel)
+	server.setActiveRunID("session-1", token, "session-1-msg-1")
+	_, cancelOther := context.WithCancel(ctx)
+	defer cancelOther()
+	server.registerActiveRun("session-2", cancelOther)
+
+	if _, err := management.NewClient(httpServer.URL, management.WithAPIKey("reader")).KillRun(ctx, &management.KillRunRequest{ID: "session-1"}); management.CodeOf(err) != management.CodePermissionDenied {
+		t.Fatalf("expected permission_denied, got %v", err)
+	}
+	if _, err := admin.KillRun(ctx, &management.KillRunRequest{}); management.CodeOf(err) != management.CodeInvalidArgument {
+		t.Fatalf("expected invalid_argument, got %v", err)
+	}
+	if _, err := admin.KillRun(ctx, &management.KillRunRequest{ID: "missing"}); management.CodeOf(err) != management.CodeNotFound {
+		t.Fatalf("expected not_found, got %v", err)
+	}
+	resp, err := admin.KillRun(ctx, &management.KillRunRequest{ID: "session-1-msg-1"})
+	if err != nil || len(resp.SessionIDs) != 1 || resp.SessionIDs[0] != "session-1" {
+		t.Fatalf("KillRun: %+v, %v", resp, err)
+	}
+	if runCtx.Err() == nil {
+		t.Fatal("killed run was not cancelled")
+	}
+	if server.hasActiveRun("session-1") || !server.hasActiveRun("session-2") {
+		t.Fatal("only the killed run should be removed")
+	}
+	resp, err = admin.KillRun(ctx, &management.KillRunRequest{All: true})
+	if err != nil || len(resp.SessionIDs) != 1 || resp.SessionIDs[0] != "session-2" {
+		t.Fatalf("KillRun(all): %+v, %v", resp, err)
+	}
+}
+
+func TestManagementAPIProtocol(t *testing.T) {
+	_, httpServer := newManagementTestServer(t)
+
+	resp, err := http.Post(httpServer.URL+management.ListSessionsProcedure, "text/plain", strings.NewReader("{}"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusUnsupportedMediaType {
+		t.Fatalf("expected 415, got %d", resp.StatusCode)
+	}
+
+	resp, err = http.Get(httpServer.URL + management.ListSessionsProcedure)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusMethodNotAllowed {
+		t.Fatalf("expected 405, got %d", resp.StatusCode)
+	}
+
+	resp, err = http.Post(httpServer.URL+"/"+management.ServiceName+"/DropDatabase", "application/json", strings.NewReader("{}"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusNotImplemented {
+		t.Fatalf("expected 501, got %d", resp.StatusCode)
+	}
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/haasonsaas/nexus/internal/config"
 	"github.com/haasonsaas/nexus/internal/infra"
 	"github.com/haasonsaas/nexus/internal/media"
+	"github.com/haasonsaas/nexus/internal/media/ocr"
 	"github.com/haasonsaas/nexus/internal/media/transcribe"
 )
 
@@ -54,16 +55,16 @@ func (m *MediaManager) Start(ctx context.Context) error {
 		return fmt.Errorf("media manager cannot start from state %s", m.State())
 	}
 
-	if m.config.Transcription.Enabled {
-		if err := m.initTranscription(); err != nil {
-			m.Logger().Warn("transcription not initialized", "error", err)
-			// Not a fatal error - continue without transcription
-		}
+	if m.config.Transcription.Enabled || m.config.OCR.Enabled {
+		// Backend failures are logged and not fatal; media is passed through
+		// unprocessed.
+		m.initProcessor()
 	}
 
 	m.MarkStarted()
 	m.Logger().Info("media manager started",
 		"transcription_enabled", m.config.Transcription.Enabled,
+		"ocr_enabled", m.config.OCR.Enabled,
 		"processor_active", m.processor != nil,
 	)
 	return nil
@@ -129,30 +130,82 @@ func (m *MediaManager) Health(_ context.Context) infra.ComponentHealth {
 	}
 }
 
-// initTranscription initializes the transcription service.
-func (m *MediaManager) initTranscription() error {
-	cfg := m.config.Transcription
+// initProcessor initializes the media processor and aggregator.
+func (m *MediaManager) initProcessor() {
+	var extractor media.TextExtractor
+	if m.config.OCR.Enabled {
+		e, err := newTextExtractor(m.config.OCR, m.Logger())
+		if err != nil {
+			m.Logger().Warn("ocr not initialized", "error", err)
+		} else {
+			extractor = e
+		}
+	}
+	processor := newMediaProcessor(m.config, extractor, m.Logger())
+	if processor == nil {
+		return
+	}
+
+	m.mu.Lock()
+	m.processor = processor
+	m.aggregator = media.NewAggregator(processor, m.Logger())
+	m.mu.Unlock()
+}
 
-	transcriber, err := transcribe.New(transcribe.Config{
+// newMediaProcessor builds the inbound media processor from the enabled
+// transcription backend and the given text extractor. It returns nil when
+// neither is available.
+func newMediaProcessor(cfg *config.Config, extractor media.TextExtractor, logger *slog.Logger) *media.DefaultProcessor {
+	var transcriber media.Transcriber
+	if cfg.Transcription.Enabled {
+		t, err := newTranscriber(cfg.Transcription, logger)
+		if err != nil {
+			logger.Warn("transcription not initialized", "error", err)
+		} else {
+			transcriber = t
+		}
+	}
+	if transcriber == nil && extractor == nil {
+		return nil
+	}
+	processor := media.NewDefaultProcessor(logger)
+	if transcriber != nil {
+		processor.SetTranscriber(transcriber)
+	}
+	if extractor != nil {
+		processor.SetTextExtractor(extractor)
+	}
+	return processor
+}
+
+// newTranscriber creates the configured transcription backend.
+func newTranscriber(cfg config.TranscriptionConfig, logger *slog.Logger) (*transcribe.Transcriber, error) {
+	return transcribe.New(transcribe.Config{
 		Provider: cfg.Provider,
 		APIKey:   cfg.APIKey,
 		BaseURL:  cfg.BaseURL,
 		Model:    cfg.Model,
 		Language: cfg.Language,
-		Logger:   m.Logger(),
+		Whisper:  cfg.Local,
+		Logger:   logger,
+	})
+}
+
+// newTextExtractor creates the configured OCR backend.
+func newTextExtractor(cfg config.OCRConfig, logger *slog.Logger) (media.TextExtractor, error) {
+	extractor, err := ocr.New(ocr.Config{
+		Provider:      cfg.Provider,
+		Languages:     cfg.Languages,
+		TesseractPath: cfg.TesseractPath,
+		APIKey:        cfg.APIKey,
+		BaseURL:       cfg.BaseURL,
+		Timeout:       cfg.Timeout,
+		Logger:        logger,
 	})
 	if err != nil {
-		return err
+		return nil, err
 	}
-
-	m.mu.Lock()
-	processor := media.NewDefaultProcessor(m.Logger())
-	processor.SetTranscriber(transcriber)
-	m.processor = processor
-	m.aggregator = media.NewAggregator(processor, m.Logger())
-	m.mu.Unlock()
-
-	return nil
+	return extractor, nil
 }
 
 // GetProcessor returns the media processor if available.
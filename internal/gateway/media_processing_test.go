@@ -78,3 +78,55 @@ func TestEnrichMessageWithMedia_AppendsTranscription(t *testing.T) {
 		t.Fatalf("expected transcription language 'en', got %q", transcriber.language)
 	}
 }
+
+type stubTextExtractor struct{ text string }
+
+func (s stubTextExtractor) ExtractText(ctx context.Context, image io.Reader, mimeType string) (string, error) {
+	return s.text, nil
+}
+
+func TestEnrichMessageWithMedia_AttachesImageText(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	transcriber := &stubTranscriber{text: "should not run"}
+	processor := media.NewDefaultProcessor(logger)
+	processor.SetTranscriber(transcriber)
+	processor.SetTextExtractor(stubTextExtractor{text: "Out of memory"})
+
+	server := &Server{
+		config: &config.Config{
+			OCR: config.OCRConfig{Enabled: true},
+		},
+		mediaAggregator: media.NewAggregator(processor, logger),
+		logger:          logger,
+		channels:        channels.NewRegistry(),
+	}
+
+	fileServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("fake bytes"))
+	}))
+	defer fileServer.Close()
+
+	msg := &models.Message{
+		ID:      "msg-1",
+		Channel: models.ChannelSlack,
+		Role:    models.RoleUser,
+		Content: "see attached",
+		Attachments: []models.Attachment{
+			{ID: "img-1", Type: "image", URL: fileServer.URL, MimeType: "image/png"},
+			{ID: "att-2", Type: "audio", URL: fileServer.URL, MimeType: "audio/ogg"},
+		},
+	}
+
+	server.enrichMessageWithMedia(context.Background(), msg)
+
+	if !strings.Contains(msg.Content, "[Image text]: Out of memory") {
+		t.Fatalf("expected image text appended to content, got %q", msg.Content)
+	}
+	imageText, ok := msg.Metadata["image_text"].(map[string]string)
+	if !ok || imageText["img-1"] != "Out of memory" {
+		t.Fatalf("unexpected image_text metadata %#v", msg.Metadata["image_text"])
+	}
+	if strings.Contains(msg.Content, "should not run") {
+		t.Fatal("audio was transcribed with transcription disabled")
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/google/uuid"
@@ -40,10 +41,7 @@ func (s *Server) startMemoryConsolidation(ctx context.Context) {
 		interval = 6 * time.Hour
 	}
 
-	s.wg.Add(1)
-	go func() {
-		defer s.wg.Done()
-
+	s.goSupervised(ctx, "worker:memory_consolidation", func(ctx context.Context) {
 		ticker := time.NewTicker(interval)
 		defer ticker.Stop()
 
@@ -57,7 +55,7 @@ func (s *Server) startMemoryConsolidation(ctx context.Context) {
 				s.runMemoryConsolidation(ctx)
 			}
 		}
-	}()
+	})
 }
 
 func (s *Server) runMemoryConsolidation(ctx context.Context) {
@@ -77,6 +75,7 @@ func (s *Server) runMemoryConsolidation(ctx context.Context) {
 		return
 	}
 
+	var due []*sessionConsolidation
 	for _, sess := range sessionList {
 		if sess == nil {
 			continue
@@ -93,14 +92,28 @@ func (s *Server) runMemoryConsolidation(ctx context.Context) {
 		if len(history) < cfg.MinMessages {
 			continue
 		}
+		due = append(due, &sessionConsolidation{session: sess, history: history})
+	}
 
-		model := strings.TrimSpace(cfg.Model)
-		if model == "" {
-			model = s.defaultModel
+	// Batched summaries are requested together so they share one batch.
+	var wg sync.WaitGroup
+	for _, item := range due {
+		if !cfg.Batch || s.batchQueue == nil {
+			item.summary, item.err = s.summarizeSession(ctx, item.history, cfg)
+			continue
 		}
-		summary, err := s.summarizeSession(ctx, history, cfg, model)
-		if err != nil {
-			s.logger.Warn("memory consolidation: summarize failed", "session", sess.ID, "error", err)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			item.summary, item.err = s.summarizeSession(ctx, item.history, cfg)
+		}()
+	}
+	wg.Wait()
+
+	for _, item := range due {
+		sess, summary := item.session, item.summary
+		if item.err != nil {
+			s.logger.Warn("memory consolidation: summarize failed", "session", sess.ID, "error", item.err)
 			continue
 		}
 		if strings.TrimSpace(summary) == "" {
@@ -134,13 +147,22 @@ func (s *Server) runMemoryConsolidation(ctx context.Context) {
 	}
 }
 
-func (s *Server) summarizeSession(ctx context.Context, history []*models.Message, cfg memory.ConsolidationConfig, model string) (string, error) {
+// sessionConsolidation is a session due for consolidation and its summary.
+type sessionConsolidation struct {
+	session *models.Session
+	history []*models.Message
+	summary string
+	err     error
+}
+
+func (s *Server) summarizeSession(ctx context.Context, history []*models.Message, cfg memory.ConsolidationConfig) (string, error) {
 	if len(history) == 0 {
 		return "", nil
 	}
 
 	// Use LLM if available
-	if s.llmProvider != nil {
+	provider, model, timeout := s.backgroundProvider(cfg.Batch, cfg.Model, 60*time.Second)
+	if provider != nil {
 		prompt := buildConsolidationPrompt(history, cfg.SummaryMaxChars)
 		req := &agent.CompletionRequest{
 			Model:     model,
@@ -148,9 +170,9 @@ func (s *Server) summarizeSession(ctx context.Context, history []*models.Message
 			Messages:  []agent.CompletionMessage{{Role: "user", Content: prompt}},
 			MaxTokens: cfg.SummaryMaxTokens,
 		}
-		ctx, cancel := context.WithTimeout(ctx, 60*time.Second)
+		ctx, cancel := context.WithTimeout(ctx, timeout)
 		defer cancel()
-		text, err := collectCompletion(ctx, s.llmProvider, req)
+		text, err := collectCompletion(ctx, provider, req)
 		if err == nil {
 			return strings.TrimSpace(text), nil
 		}
@@ -0,0 +1,134 @@
+package gateway
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/haasonsaas/nexus/internal/commands"
+	"github.com/haasonsaas/nexus/internal/delivery"
+	"github.com/haasonsaas/nexus/internal/memory/review"
+	"github.com/haasonsaas/nexus/pkg/models"
+)
+
+// memoryReviewSummaryLen caps the memory text shown in review messages.
+const memoryReviewSummaryLen = 300
+
+// announceMemoryProposal asks the conversation a memory proposal came from
+// to approve or reject it. Telegram gets inline buttons that send the
+// matching /memory command.
+func (s *Server) announceMemoryProposal(ctx context.Context, proposal *review.Proposal) {
+	if proposal == nil || proposal.Channel == "" || proposal.ChannelID == "" || s.channels == nil {
+		return
+	}
+	adapter, ok := s.channels.GetOutbound(proposal.Channel)
+	if !ok {
+		return
+	}
+	content := fmt.Sprintf("The assistant wants to remember (%s, %s):\n%s\n\nReply /memory approve %s or /memory reject %s.",
+		memoryProposalTarget(proposal), proposal.ID, proposal.Summary(memoryReviewSummaryLen), proposal.ID, proposal.ID)
+	metadata := delivery.RoutingMetadata(proposal.Channel, proposal.ChannelID)
+	if proposal.Channel == models.ChannelTelegram {
+		metadata["inline_keyboard"] = map[string]any{
+			"inline_keyboard": [][]map[string]string{{
+				{"text": "Remember", "callback_data": "/memory approve " + proposal.ID},
+				{"text": "Forget", "callback_data": "/memory reject " + proposal.ID},
+			}},
+		}
+	}
+	msg := &models.Message{
+		ID:        uuid.NewString(),
+		SessionID: proposal.SessionID,
+		Channel:   proposal.Channel,
+		ChannelID: proposal.ChannelID,
+		Direction: models.DirectionOutbound,
+		Role:      models.RoleAssistant,
+		Content:   content,
+		Metadata:  metadata,
+		CreatedAt: time.Now(),
+	}
+	sendCtx := context.WithoutCancel(ctx)
+	if err := s.sendWithCircuitBreaker(sendCtx, proposal.Channel, func() error {
+		return adapter.Send(sendCtx, msg)
+	}); err != nil {
+		s.logger.Warn("failed to announce memory proposal", "proposal", proposal.ID, "error", err)
+	}
+}
+
+// applyMemoryReviewCommand handles /memory review, approve, and reject.
+// Proposals can be decided from the conversation they came from, or by an
+// admin from anywhere.
+func (s *Server) applyMemoryReviewCommand(ctx context.Context, session *models.Session, msg *models.Message, op, id string) {
+	if s.memoryReview == nil {
+		s.sendImmediateReply(ctx, session, msg, "Memory review is not enabled on this gateway.")
+		return
+	}
+	isAdmin := s.resolveCommandRole(ctx, msg) == commands.RoleAdmin
+	if op == "list" {
+		pending, err := s.memoryReview.List(review.StatusPending)
+		if err != nil {
+			s.logger.Error("failed to list memory proposals", "error", err)
+			s.sendImmediateReply(ctx, session, msg, "Failed to list memories waiting for review.")
+			return
+		}
+		var lines []string
+		for _, proposal := range pending {
+			if !isAdmin && !sameConversation(proposal, session) {
+				continue
+			}
+			lines = append(lines, fmt.Sprintf("%s (%s): %s", proposal.ID, memoryProposalTarget(proposal), proposal.Summary(120)))
+		}
+		if len(lines) == 0 {
+			s.sendImmediateReply(ctx, session, msg, "No memories are waiting for review.")
+			return
+		}
+		s.sendImmediateReply(ctx, session, msg, "Memories waiting for review:\n"+strings.Join(lines, "\n")+"\n\nReply /memory approve <id> or /memory reject <id>.")
+		return
+	}
+
+	proposal, err := s.memoryReview.Get(id)
+	if err == nil && !isAdmin && !sameConversation(proposal, session) {
+		err = fmt.Errorf("%s: %w", id, review.ErrNotFound)
+	}
+	if err != nil {
+		s.sendImmediateReply(ctx, session, msg, titleFirst(err.Error())+". See /memory review.")
+		return
+	}
+	decidedBy := fmt.Sprintf("%s:%s", msg.Channel, extractSenderID(msg))
+	if op == "reject" {
+		if _, err := s.memoryReview.Reject(ctx, proposal.ID, decidedBy); err != nil {
+			s.sendImmediateReply(ctx, session, msg, titleFirst(err.Error())+".")
+			return
+		}
+		s.sendImmediateReply(ctx, session, msg, fmt.Sprintf("Discarded %s.", proposal.ID))
+		return
+	}
+	var indexer review.Indexer
+	if s.vectorMemory != nil {
+		indexer = s.vectorMemory
+	}
+	if _, err := s.memoryReview.Approve(ctx, proposal.ID, decidedBy, indexer); err != nil {
+		if !errors.Is(err, review.ErrDecided) && !errors.Is(err, review.ErrStale) {
+			s.logger.Error("failed to apply memory proposal", "proposal", proposal.ID, "error", err)
+		}
+		s.sendImmediateReply(ctx, session, msg, titleFirst(err.Error())+".")
+		return
+	}
+	s.sendImmediateReply(ctx, session, msg, fmt.Sprintf("Remembered %s: %s", proposal.ID, proposal.Summary(memoryReviewSummaryLen)))
+}
+
+// memoryProposalTarget names where a proposal would be stored.
+func memoryProposalTarget(proposal *review.Proposal) string {
+	if proposal.Kind == review.KindFile {
+		return filepath.Base(proposal.Path)
+	}
+	return proposal.Scope + " memory"
+}
+
+func sameConversation(proposal *review.Proposal, session *models.Session) bool {
+	return session != nil && proposal.Channel == session.Channel && proposal.ChannelID == session.ChannelID
+}
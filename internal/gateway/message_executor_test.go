@@ -4,8 +4,10 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/haasonsaas/nexus/internal/channels"
+	"github.com/haasonsaas/nexus/internal/hooks"
 	"github.com/haasonsaas/nexus/internal/tasks"
 	"github.com/haasonsaas/nexus/pkg/models"
 )
@@ -228,3 +230,58 @@ func containsSubstring(s, substr string) bool {
 	}
 	return false
 }
+
+func TestMessageExecutor_ScheduledMessageDelivery(t *testing.T) {
+	mock := &mockAdapter{channelType: "test"}
+	registry := channels.NewRegistry()
+	registry.Register(mock)
+
+	hookRegistry := hooks.NewRegistry(nil)
+	events := make(chan *hooks.Event, 2)
+	record := func(ctx context.Context, event *hooks.Event) error {
+		events <- event
+		return nil
+	}
+	hookRegistry.Register(string(hooks.EventScheduledMessageDelivered), record)
+	hookRegistry.Register(string(hooks.EventScheduledMessageFailed), record)
+	executor := NewMessageExecutor(registry, MessageExecutorConfig{Hooks: hookRegistry})
+
+	task := &tasks.ScheduledTask{
+		ID:       "task-1",
+		Schedule: "0 9 * * 1-5",
+		Prompt:   "Standup in 5",
+		Config:   tasks.TaskConfig{Channel: "test", ChannelID: "user-123"},
+		Metadata: map[string]any{"type": "scheduled_message", "created_by": "u1"},
+	}
+	if _, err := executor.Execute(context.Background(), task, &tasks.TaskExecution{ID: "exec-1", AttemptNumber: 1}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := mock.messages[0]; got.Content != "Standup in 5" || got.Metadata["type"] != "scheduled_message" {
+		t.Fatalf("unexpected message %+v", got)
+	}
+	event := waitHookEvent(t, events)
+	if event.Type != hooks.EventScheduledMessageDelivered || event.Action != "scheduled_message" ||
+		event.Context["task_id"] != "task-1" || event.Context["recurring"] != true || event.Context["created_by"] != "u1" {
+		t.Fatalf("unexpected delivery event %+v", event)
+	}
+
+	mock.sendFunc = func(ctx context.Context, msg *models.Message) error { return errors.New("offline") }
+	if _, err := executor.Execute(context.Background(), task, &tasks.TaskExecution{ID: "exec-2", AttemptNumber: 2}); err == nil {
+		t.Fatal("expected send error")
+	}
+	event = waitHookEvent(t, events)
+	if event.Type != hooks.EventScheduledMessageFailed || event.ErrorMsg != "offline" || event.Context["attempt"] != 2 {
+		t.Fatalf("unexpected failure event %+v", event)
+	}
+}
+
+func waitHookEvent(t *testing.T, events <-chan *hooks.Event) *hooks.Event {
+	t.Helper()
+	select {
+	case event := <-events:
+		return event
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for hook event")
+		return nil
+	}
+}
@@ -13,8 +13,11 @@ import (
 	"github.com/google/uuid"
 
 	"github.com/haasonsaas/nexus/internal/channels"
+	"github.com/haasonsaas/nexus/internal/delivery"
+	"github.com/haasonsaas/nexus/internal/hooks"
 	"github.com/haasonsaas/nexus/internal/sessions"
 	"github.com/haasonsaas/nexus/internal/tasks"
+	"github.com/haasonsaas/nexus/internal/tools/reminders"
 	"github.com/haasonsaas/nexus/pkg/models"
 	proto "github.com/haasonsaas/nexus/pkg/proto"
 )
@@ -254,24 +257,12 @@ func (s *messageService) BroadcastMessage(ctx context.Context, req *proto.Broadc
 }
 
 // SendProactiveMessage is a helper function for internal use to send a proactive message.
+// The message goes through the delivery router, so it may reach the user on
+// another linked channel or be held back by quiet hours.
 // This is useful for task executors and other internal components.
 func (s *Server) SendProactiveMessage(ctx context.Context, channel models.ChannelType, peerID, content string) error {
-	adapter, ok := s.channels.GetOutbound(channel)
-	if !ok {
-		return fmt.Errorf("channel %s not found or doesn't support outbound messages", channel)
-	}
-
-	msg := &models.Message{
-		ID:        uuid.NewString(),
-		Channel:   channel,
-		ChannelID: peerID,
-		Direction: models.DirectionOutbound,
-		Role:      models.RoleAssistant,
-		Content:   content,
-		CreatedAt: time.Now(),
-	}
-
-	return adapter.Send(ctx, msg)
+	_, err := s.sendProactive(ctx, "", channel, peerID, content)
+	return err
 }
 
 // MessageExecutor is a task executor that sends messages directly via channels.
@@ -281,6 +272,8 @@ type MessageExecutor struct {
 	registry *channels.Registry
 	sessions sessions.Store
 	scoping  sessions.ScopeConfig
+	hooks    *hooks.Registry
+	router   *delivery.Router
 	logger   func(format string, args ...any)
 }
 
@@ -288,7 +281,13 @@ type MessageExecutor struct {
 type MessageExecutorConfig struct {
 	Sessions sessions.Store
 	Scoping  sessions.ScopeConfig
-	Logger   func(format string, args ...any)
+	// Hooks receives delivery confirmation events. Defaults to the global
+	// hooks registry.
+	Hooks *hooks.Registry
+	// Router picks the user's channel and applies quiet hours. When nil,
+	// messages go to the task's channel.
+	Router *delivery.Router
+	Logger func(format string, args ...any)
 }
 
 // NewMessageExecutor creates a new executor that sends messages directly.
@@ -297,10 +296,16 @@ func NewMessageExecutor(registry *channels.Registry, config MessageExecutorConfi
 	if logger == nil {
 		logger = func(string, ...any) {}
 	}
+	registryHooks := config.Hooks
+	if registryHooks == nil {
+		registryHooks = hooks.Global()
+	}
 	return &MessageExecutor{
 		registry: registry,
 		sessions: config.Sessions,
 		scoping:  config.Scoping,
+		hooks:    registryHooks,
+		router:   config.Router,
 		logger:   logger,
 	}
 }
@@ -325,12 +330,6 @@ func (e *MessageExecutor) Execute(ctx context.Context, task *tasks.ScheduledTask
 		return "", fmt.Errorf("channel_id (peer) is required for message execution")
 	}
 
-	// Get the outbound adapter
-	adapter, ok := e.registry.GetOutbound(channelType)
-	if !ok {
-		return "", fmt.Errorf("channel %s not found or doesn't support outbound", channelType)
-	}
-
 	// Format the reminder message
 	content := formatReminderMessage(task, exec)
 
@@ -347,16 +346,38 @@ func (e *MessageExecutor) Execute(ctx context.Context, task *tasks.ScheduledTask
 			"task_id":      task.ID,
 			"task_name":    task.Name,
 			"execution_id": exec.ID,
-			"type":         "reminder",
+			"type":         taskMessageType(task),
 		},
 	}
 
 	// Send the message
-	if err := adapter.Send(ctx, msg); err != nil {
-		return "", fmt.Errorf("send message: %w", err)
+	if e.router != nil {
+		result, err := e.router.Deliver(ctx, msg, taskMessageType(task))
+		if err != nil {
+			e.notifyDelivery(ctx, task, exec, msg, err)
+			return "", fmt.Errorf("send message: %w", err)
+		}
+		if result.Dropped {
+			return fmt.Sprintf("Reminder for %s:%s dropped during quiet hours", channelType, peerID), nil
+		}
+		if result.Deferred {
+			return fmt.Sprintf("Reminder for %s:%s deferred until %s (quiet hours)", channelType, peerID, result.DeferredUntil.Format(time.RFC3339)), nil
+		}
+		msg = result.Message
+		channelType, peerID = msg.Channel, msg.ChannelID
+	} else {
+		adapter, ok := e.registry.GetOutbound(channelType)
+		if !ok {
+			return "", fmt.Errorf("channel %s not found or doesn't support outbound", channelType)
+		}
+		if err := adapter.Send(ctx, msg); err != nil {
+			e.notifyDelivery(ctx, task, exec, msg, err)
+			return "", fmt.Errorf("send message: %w", err)
+		}
 	}
 
 	e.logger("reminder sent: task=%s channel=%s peer=%s", task.ID, channelType, peerID)
+	e.notifyDelivery(ctx, task, exec, msg, nil)
 
 	// Store the message in session if we have a session store
 	if e.sessions != nil {
@@ -383,8 +404,43 @@ func (e *MessageExecutor) Execute(ctx context.Context, task *tasks.ScheduledTask
 	return fmt.Sprintf("Reminder sent to %s:%s", channelType, peerID), nil
 }
 
+// notifyDelivery emits a delivery confirmation (or failure) hook event.
+func (e *MessageExecutor) notifyDelivery(ctx context.Context, task *tasks.ScheduledTask, exec *tasks.TaskExecution, msg *models.Message, err error) {
+	if e.hooks == nil {
+		return
+	}
+	eventType := hooks.EventScheduledMessageDelivered
+	if err != nil {
+		eventType = hooks.EventScheduledMessageFailed
+	}
+	event := hooks.NewEvent(eventType, taskMessageType(task)).
+		WithChannel(msg.ChannelID, msg.Channel).
+		WithMessage(msg).
+		WithContext("task_id", task.ID).
+		WithContext("execution_id", exec.ID).
+		WithContext("attempt", exec.AttemptNumber).
+		WithContext("recurring", !strings.HasPrefix(task.Schedule, "@at ")).
+		WithError(err)
+	if createdBy, ok := task.Metadata["created_by"].(string); ok {
+		event.WithContext("created_by", createdBy)
+	}
+	e.hooks.TriggerAsync(context.WithoutCancel(ctx), event)
+}
+
+// taskMessageType returns the task's metadata type, defaulting to reminder.
+func taskMessageType(task *tasks.ScheduledTask) string {
+	if kind, ok := task.Metadata["type"].(string); ok && kind != "" {
+		return kind
+	}
+	return reminders.TaskTypeReminder
+}
+
 // formatReminderMessage formats the reminder for display.
 func formatReminderMessage(task *tasks.ScheduledTask, _ *tasks.TaskExecution) string {
+	// Scheduled messages are sent exactly as the user wrote them
+	if taskMessageType(task) == reminders.TaskTypeScheduledMessage {
+		return task.Prompt
+	}
 	// For reminders, the prompt is the message to send
 	// Add a prefix to make it clear this is a reminder
 	return fmt.Sprintf("Reminder: %s", task.Prompt)
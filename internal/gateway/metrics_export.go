@@ -0,0 +1,51 @@
+package gateway
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/haasonsaas/nexus/internal/config"
+	"github.com/haasonsaas/nexus/internal/observability"
+)
+
+// newMetricsExporter builds the OTLP metrics exporter when the config pushes
+// metrics. It bridges the default Prometheus registry, so the pushed
+// instruments are exactly the ones served at /metrics.
+func newMetricsExporter(cfg *config.Config, logger *slog.Logger) (*observability.OTLPMetricsExporter, error) {
+	metrics := cfg.Observability.Metrics
+	if !metrics.OTLPEnabled() {
+		return nil, nil
+	}
+	tracing := cfg.Observability.Tracing
+	attributes := map[string]string{"service.name": tracing.ServiceName}
+	if tracing.ServiceVersion != "" {
+		attributes["service.version"] = tracing.ServiceVersion
+	}
+	if tracing.Environment != "" {
+		attributes["deployment.environment"] = tracing.Environment
+	}
+	for key, value := range metrics.OTLP.ResourceAttributes {
+		attributes[key] = value
+	}
+	return observability.NewOTLPMetricsExporter(observability.OTLPMetricsConfig{
+		Endpoint:           metrics.OTLP.Endpoint,
+		Protocol:           metrics.OTLP.Protocol,
+		Insecure:           metrics.OTLP.Insecure,
+		Interval:           metrics.OTLP.Interval,
+		Timeout:            metrics.OTLP.Timeout,
+		Headers:            metrics.OTLP.Headers,
+		ResourceAttributes: attributes,
+	}, nil, logger)
+}
+
+// startMetricsExport starts pushing metrics to the OTLP collector.
+func (s *Server) startMetricsExport(ctx context.Context) {
+	if s == nil || s.metricsExporter == nil {
+		return
+	}
+	s.metricsExporter.Start(ctx)
+	s.logger.Info("otlp metrics export started",
+		"endpoint", s.config.Observability.Metrics.OTLP.Endpoint,
+		"protocol", s.config.Observability.Metrics.OTLP.Protocol,
+		"interval", s.config.Observability.Metrics.OTLP.Interval)
+}
@@ -5,6 +5,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/haasonsaas/nexus/internal/agent"
 	"github.com/haasonsaas/nexus/internal/config"
 	"github.com/haasonsaas/nexus/internal/models"
 )
@@ -29,3 +30,22 @@ func buildBedrockDiscoveryConfig(cfg config.BedrockConfig, logger *slog.Logger)
 	}
 	return out
 }
+
+// discoverProviderModels adds the models each provider reports to the model
+// catalog. Bundled entries win; this fills in models the catalog does not
+// know, such as local Ollama models or new provider releases.
+func (s *Server) discoverProviderModels(providers map[string]agent.LLMProvider) {
+	if s.modelCatalog == nil {
+		return
+	}
+	for id, provider := range providers {
+		baseID, _ := splitProviderProfileID(id)
+		var discovered []*models.Model
+		for _, m := range provider.Models() {
+			discovered = append(discovered, models.NewDiscoveredModel(models.Provider(baseID), m.ID, m.Name, m.ContextSize, m.SupportsVision, provider.SupportsTools()))
+		}
+		if added := s.modelCatalog.AddDiscovered(discovered...); added > 0 && s.logger != nil {
+			s.logger.Debug("registered provider models", "provider", id, "count", added)
+		}
+	}
+}
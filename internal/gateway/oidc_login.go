@@ -0,0 +1,155 @@
+package gateway
+
+import (
+	"errors"
+	"html"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/haasonsaas/nexus/internal/auth"
+	"github.com/haasonsaas/nexus/internal/web"
+)
+
+const (
+	oidcPathPrefix       = "/auth/"
+	oidcCallbackPath     = "/auth/oidc/callback"
+	oidcLogoutPath       = "/auth/logout"
+	sessionCookieName    = "nexus_session"
+	defaultLoginRedirect = "/ui/"
+)
+
+// oidcHandler serves the SSO login, callback, and logout endpoints. A
+// successful login sets the nexus_session cookie read by the dashboard, the
+// canvas host, and artifact links.
+type oidcHandler struct {
+	auth       *auth.Service
+	provider   *auth.OIDCProvider
+	sessionTTL time.Duration
+	logger     *slog.Logger
+}
+
+func (s *Server) newOIDCHandler() *oidcHandler {
+	logger := s.logger
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &oidcHandler{
+		auth:       s.authService,
+		provider:   s.authService.OIDC(),
+		sessionTTL: s.config.Auth.OIDC.SessionTTL,
+		logger:     logger.With("component", "oidc"),
+	}
+}
+
+func (h *oidcHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.URL.Path {
+	case web.LoginPath:
+		h.login(w, r)
+	case oidcCallbackPath:
+		h.callback(w, r)
+	case oidcLogoutPath:
+		h.logout(w, r)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func (h *oidcHandler) login(w http.ResponseWriter, r *http.Request) {
+	target, err := h.provider.Begin(r.Context(), localRedirect(r.URL.Query().Get("redirect")))
+	if err != nil {
+		h.logger.Error("oidc login failed", "error", err)
+		http.Error(w, "Single sign-on is unavailable", http.StatusBadGateway)
+		return
+	}
+	http.Redirect(w, r, target, http.StatusFound)
+}
+
+func (h *oidcHandler) callback(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	if providerErr := query.Get("error"); providerErr != "" {
+		h.writeLoginError(w, http.StatusUnauthorized, "Sign-in was cancelled or refused: "+providerErr)
+		return
+	}
+	identity, redirect, err := h.provider.Complete(r.Context(), query.Get("state"), query.Get("code"))
+	switch {
+	case errors.Is(err, auth.ErrOIDCNoRole):
+		h.logger.Warn("oidc user has no role", "subject", identity.Subject, "groups", identity.Groups)
+		h.writeLoginError(w, http.StatusForbidden, "Your account is not in a group with access to Nexus.")
+		return
+	case errors.Is(err, auth.ErrOIDCState):
+		h.writeLoginError(w, http.StatusBadRequest, "This sign-in link expired. Please try again.")
+		return
+	case err != nil:
+		h.logger.Warn("oidc callback failed", "error", err)
+		h.writeLoginError(w, http.StatusUnauthorized, "Sign-in failed.")
+		return
+	}
+
+	user, err := h.auth.OIDCUser(r.Context(), identity)
+	if err != nil {
+		h.logger.Error("oidc user lookup failed", "subject", identity.Subject, "error", err)
+		h.writeLoginError(w, http.StatusInternalServerError, "Sign-in failed.")
+		return
+	}
+	token, err := h.auth.GenerateSessionJWT(user, identity.Role, h.sessionTTL)
+	if err != nil {
+		h.logger.Error("oidc session token failed", "error", err)
+		h.writeLoginError(w, http.StatusInternalServerError, "Sign-in failed.")
+		return
+	}
+	cookie := &http.Cookie{
+		Name:     sessionCookieName,
+		Value:    token,
+		Path:     "/",
+		HttpOnly: true,
+		Secure:   h.provider.SecureCookies(),
+		SameSite: http.SameSiteLaxMode,
+	}
+	if h.sessionTTL > 0 {
+		cookie.MaxAge = int(h.sessionTTL.Seconds())
+	}
+	http.SetCookie(w, cookie)
+	h.logger.Info("oidc login", "user_id", user.ID, "role", identity.Role)
+	if redirect == "" {
+		redirect = defaultLoginRedirect
+	}
+	http.Redirect(w, r, redirect, http.StatusFound)
+}
+
+func (h *oidcHandler) logout(w http.ResponseWriter, r *http.Request) {
+	http.SetCookie(w, &http.Cookie{
+		Name:     sessionCookieName,
+		Value:    "",
+		Path:     "/",
+		MaxAge:   -1,
+		HttpOnly: true,
+		Secure:   h.provider.SecureCookies(),
+		SameSite: http.SameSiteLaxMode,
+	})
+	http.Redirect(w, r, "/", http.StatusFound)
+}
+
+func (h *oidcHandler) writeLoginError(w http.ResponseWriter, status int, message string) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(status)
+	_, _ = w.Write([]byte(`<!DOCTYPE html>
+<html>
+<head><title>Sign-in failed</title></head>
+<body>
+<p>` + html.EscapeString(message) + `</p>
+<p><a href="` + web.LoginPath + `">Try again</a></p>
+</body>
+</html>`)) // response write to disconnected client
+}
+
+// localRedirect returns redirect when it is a path on this server, so the
+// login flow cannot be used as an open redirect.
+func localRedirect(redirect string) string {
+	redirect = strings.TrimSpace(redirect)
+	if !strings.HasPrefix(redirect, "/") || strings.HasPrefix(redirect, "//") || strings.Contains(redirect, `\`) {
+		return ""
+	}
+	return redirect
+}
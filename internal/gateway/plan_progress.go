@@ -0,0 +1,126 @@
+package gateway
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/haasonsaas/nexus/internal/channels"
+	"github.com/haasonsaas/nexus/pkg/models"
+)
+
+// planProgress shows the plan of a run as a checklist message that is
+// edited in place as steps change.
+type planProgress struct {
+	streaming channels.StreamingAdapter
+	msg       models.Message
+	logger    *slog.Logger
+
+	messageID string
+	last      string
+	failed    bool
+}
+
+// newPlanProgress returns nil when plan progress is off or the channel
+// cannot edit messages.
+func (s *Server) newPlanProgress(streaming channels.StreamingAdapter, hasStreaming bool, template *models.Message) *planProgress {
+	if !hasStreaming || s.config == nil || !s.config.Tools.Plan.ProgressEnabled() {
+		return nil
+	}
+	return &planProgress{streaming: streaming, msg: *template, logger: s.logger}
+}
+
+// update renders plan into the progress message, sending it on the first
+// update. A channel that fails to send or edit gets no further updates.
+func (p *planProgress) update(ctx context.Context, plan *models.Plan) {
+	if p == nil || p.failed || plan == nil {
+		return
+	}
+	content := renderPlanProgress(p.msg.Channel, plan)
+	if content == p.last {
+		return
+	}
+	if p.messageID == "" {
+		id, err := p.streaming.StartStreamingResponse(ctx, &p.msg)
+		if err != nil {
+			if !errors.Is(err, channels.ErrStreamingNotSupported) {
+				p.logger.Debug("failed to send plan progress", "channel", p.msg.Channel, "error", err)
+			}
+			p.failed = true
+			return
+		}
+		p.messageID = id
+	}
+	if err := p.streaming.UpdateStreamingResponse(ctx, &p.msg, p.messageID, content); err != nil {
+		p.logger.Debug("failed to update plan progress", "channel", p.msg.Channel, "error", err)
+		p.failed = true
+		return
+	}
+	p.last = content
+}
+
+// renderPlanProgress formats a plan as a checklist: Slack mrkdwn on Slack,
+// plain text with status emoji elsewhere.
+func renderPlanProgress(channel models.ChannelType, plan *models.Plan) string {
+	slack := channel == models.ChannelSlack
+	var b strings.Builder
+	if plan.Title != "" {
+		if slack {
+			fmt.Fprintf(&b, "*%s*\n", plan.Title)
+		} else {
+			fmt.Fprintf(&b, "%s\n", plan.Title)
+		}
+	}
+	for _, step := range plan.Steps {
+		title := step.Title
+		if slack {
+			switch step.Status {
+			case models.PlanStepInProgress:
+				title = "*" + title + "*"
+			case models.PlanStepSkipped:
+				title = "~" + title + "~"
+			}
+		}
+		b.WriteString(planStepMarker(step.Status, slack))
+		b.WriteString(" ")
+		b.WriteString(title)
+		if step.Note != "" {
+			b.WriteString(" — ")
+			b.WriteString(step.Note)
+		}
+		b.WriteString("\n")
+	}
+	done := fmt.Sprintf("%d/%d steps done", plan.Done(), len(plan.Steps))
+	if slack {
+		done = "_" + done + "_"
+	}
+	b.WriteString(done)
+	return b.String()
+}
+
+func planStepMarker(status models.PlanStepStatus, slack bool) string {
+	switch status {
+	case models.PlanStepCompleted:
+		if slack {
+			return ":white_check_mark:"
+		}
+		return "✅"
+	case models.PlanStepInProgress:
+		if slack {
+			return ":arrow_forward:"
+		}
+		return "▶️"
+	case models.PlanStepSkipped:
+		if slack {
+			return ":heavy_minus_sign:"
+		}
+		return "➖"
+	default:
+		if slack {
+			return ":white_large_square:"
+		}
+		return "⬜"
+	}
+}
@@ -0,0 +1,65 @@
+package gateway
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+
+	"github.com/haasonsaas/nexus/internal/config"
+	"github.com/haasonsaas/nexus/pkg/models"
+)
+
+func TestRenderPlanProgress(t *testing.T) {
+	plan := &models.Plan{Title: "Release", Steps: []models.PlanStep{
+		{Title: "build", Status: models.PlanStepCompleted},
+		{Title: "test", Status: models.PlanStepInProgress, Note: "3 suites left"},
+		{Title: "docs", Status: models.PlanStepSkipped},
+		{Title: "tag", Status: models.PlanStepPending},
+	}}
+
+	slack := renderPlanProgress(models.ChannelSlack, plan)
+	want := "*Release*\n:white_check_mark: build\n:arrow_forward: *test* — 3 suites left\n:heavy_minus_sign: ~docs~\n:white_large_square: tag\n_2/4 steps done_"
+	if slack != want {
+		t.Fatalf("slack = %q, want %q", slack, want)
+	}
+	telegram := renderPlanProgress(models.ChannelTelegram, plan)
+	want = "Release\n✅ build\n▶️ test — 3 suites left\n➖ docs\n⬜ tag\n2/4 steps done"
+	if telegram != want {
+		t.Fatalf("telegram = %q, want %q", telegram, want)
+	}
+}
+
+func TestPlanProgressUpdate(t *testing.T) {
+	ctx := context.Background()
+	server := &Server{config: &config.Config{}, logger: slog.Default()}
+	adapter := &mockStreamingAdapter{}
+	template := &models.Message{Channel: models.ChannelTelegram}
+
+	progress := server.newPlanProgress(adapter, true, template)
+	plan := &models.Plan{Steps: []models.PlanStep{{Title: "build", Status: models.PlanStepPending}}}
+	progress.update(ctx, plan)
+	progress.update(ctx, plan)
+	plan.Steps[0].Status = models.PlanStepCompleted
+	progress.update(ctx, plan)
+
+	if adapter.startCalls != 1 || adapter.updateCalls != 2 || adapter.lastContent != "✅ build\n1/1 steps done" {
+		t.Fatalf("start=%d update=%d content=%q", adapter.startCalls, adapter.updateCalls, adapter.lastContent)
+	}
+
+	// A channel that cannot edit gets no further updates.
+	failing := &mockStreamingAdapter{updateErr: errors.New("edit failed")}
+	progress = server.newPlanProgress(failing, true, template)
+	progress.update(ctx, plan)
+	plan.Steps[0].Status = models.PlanStepPending
+	progress.update(ctx, plan)
+	if failing.updateCalls != 1 {
+		t.Fatalf("expected updates to stop after a failure, got %d", failing.updateCalls)
+	}
+
+	off := false
+	server.config.Tools.Plan.Progress = &off
+	if server.newPlanProgress(adapter, true, template) != nil {
+		t.Fatal("expected no plan progress when disabled")
+	}
+}
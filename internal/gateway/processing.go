@@ -14,9 +14,15 @@ import (
 	"sync/atomic"
 	"time"
 
+	"github.com/haasonsaas/nexus/internal/access"
 	"github.com/haasonsaas/nexus/internal/agent"
+	"github.com/haasonsaas/nexus/internal/anomaly"
+	"github.com/haasonsaas/nexus/internal/artifacts"
 	"github.com/haasonsaas/nexus/internal/channels"
 	"github.com/haasonsaas/nexus/internal/config"
+	"github.com/haasonsaas/nexus/internal/eventbridge"
+	"github.com/haasonsaas/nexus/internal/messages"
+	"github.com/haasonsaas/nexus/internal/supervisor"
 	"github.com/haasonsaas/nexus/pkg/models"
 	"go.opentelemetry.io/otel/trace"
 )
@@ -49,15 +55,20 @@ const (
 func (s *Server) startProcessing(ctx context.Context) {
 	processCtx, cancel := context.WithCancel(ctx)
 	s.cancel = cancel
+	s.processCtx = processCtx
+	// Aggregate once so a restart after a panic keeps reading the same stream.
+	messages := s.channels.AggregateMessages(processCtx)
+	s.goSupervised(processCtx, "worker:message_processing", func(ctx context.Context) {
+		s.processMessages(ctx, messages)
+	})
+
+	// Resume or finalize runs interrupted by the previous shutdown
 	s.wg.Add(1)
-	go s.processMessages(processCtx)
+	go s.recoverInterruptedRuns(processCtx)
 }
 
 // processMessages handles incoming messages from all channels.
-func (s *Server) processMessages(ctx context.Context) {
-	defer s.wg.Done()
-	messages := s.channels.AggregateMessages(ctx)
-
+func (s *Server) processMessages(ctx context.Context, messages <-chan *models.Message) {
 	for {
 		select {
 		case <-ctx.Done():
@@ -75,6 +86,7 @@ func (s *Server) processMessages(ctx context.Context) {
 						<-s.messageSem // Release semaphore slot
 						s.wg.Done()
 					}()
+					defer supervisor.Recover("gateway:message:" + string(message.Channel))
 					s.handleMessage(ctx, message)
 				}(msg)
 			case <-ctx.Done():
@@ -122,6 +134,7 @@ func (s *Server) handleMessage(ctx context.Context, msg *models.Message) {
 	if s.integration != nil {
 		s.integration.RecordInbound(string(msg.Channel), msg.ChannelID)
 	}
+	s.anomalies.Add(anomaly.SignalPeerMessages, string(msg.Channel)+":"+extractSenderID(msg), 1)
 
 	if s.handleMessageHook != nil {
 		s.handleMessageHook(ctx, msg)
@@ -178,6 +191,7 @@ func (s *Server) handleMessage(ctx context.Context, msg *models.Message) {
 		s.logger.Error("failed to resolve conversation id", "error", err)
 		return
 	}
+	s.recordPresence(msg, channelID)
 
 	agentID := defaultAgentID
 	if s.config != nil && s.config.Session.DefaultAgentID != "" {
@@ -188,6 +202,13 @@ func (s *Server) handleMessage(ctx context.Context, msg *models.Message) {
 			agentID = strings.TrimSpace(override)
 		}
 	}
+	if rule := s.telegramTopicRule(msg); rule != nil {
+		if rule.Disabled {
+			s.logger.Debug("telegram topic disabled, skipping message", "conversation", channelID)
+			return
+		}
+		agentID = strings.TrimSpace(rule.AgentID)
+	}
 	key := s.buildSessionKey(agentID, msg, channelID)
 	session, err := s.sessions.GetOrCreate(ctx, key, agentID, msg.Channel, channelID)
 	if err != nil {
@@ -199,6 +220,8 @@ func (s *Server) handleMessage(ctx context.Context, msg *models.Message) {
 	if msg.CreatedAt.IsZero() {
 		msg.CreatedAt = time.Now()
 	}
+	s.bridgeMessage(session, msg, eventbridge.TypeMessageReceived, "")
+	s.trackAttachmentReferences(ctx, session, msg)
 	if msgSpan != nil {
 		s.tracer.SetAttributes(msgSpan, "session_id", session.ID, "agent_id", agentID)
 		if msg.ID != "" {
@@ -216,6 +239,11 @@ func (s *Server) handleMessage(ctx context.Context, msg *models.Message) {
 			return
 		}
 	}
+	if !s.shouldRespondInGroup(ctx, session, msg) {
+		s.trackReadState(session, msg, false)
+		return
+	}
+	s.trackReadState(session, msg, true)
 
 	// Acquire session write lock to prevent concurrent writes to the same session
 	// This is done AFTER command handling so /stop can cancel active runs
@@ -237,6 +265,7 @@ func (s *Server) handleMessage(ctx context.Context, msg *models.Message) {
 	}
 
 	s.enrichMessageWithMedia(ctx, msg)
+	s.detectMessageLanguage(ctx, session, msg)
 
 	// Note: inbound message persistence is handled by runtime.Process()
 	// to avoid double-persisting the same message.
@@ -256,13 +285,14 @@ func (s *Server) handleMessage(ctx context.Context, msg *models.Message) {
 		}
 	}
 	overrides := parseAgentToolOverrides(agentModel)
-	toolPolicy := s.resolveToolPolicy(agentModel, msg)
+	toolPolicy := s.resolveToolPolicy(ctx, agentModel, msg)
 
 	var agentElevatedCfg *config.ElevatedConfig
 	if overrides.HasElevated {
 		agentElevatedCfg = &overrides.Elevated
 	}
-	elevatedAllowed, elevatedReason := resolveElevatedPermission(s.config.Tools.Elevated, agentElevatedCfg, msg)
+	elevatedGranted, _ := s.accessGranted(ctx, access.ListElevated, msg.Channel, extractSenderID(msg))
+	elevatedAllowed, elevatedReason := resolveElevatedPermission(s.config.Tools.Elevated, agentElevatedCfg, msg, elevatedGranted)
 	inlineElevatedSet := false
 	inlineElevatedMode := agent.ElevatedOff
 
@@ -306,12 +336,14 @@ func (s *Server) handleMessage(ctx context.Context, msg *models.Message) {
 	if systemPrompt != "" {
 		promptCtx = agent.WithSystemPrompt(promptCtx, systemPrompt)
 	}
-	if overrides := s.experimentOverrides(session, msg); overrides.Model != "" {
-		promptCtx = agent.WithModel(promptCtx, overrides.Model)
-	}
+	s.resolveReplyContext(ctx, msg)
+	promptCtx = agent.WithQuotes(promptCtx, replyQuotes(msg))
+	promptCtx = s.withExperiments(promptCtx, session, msg)
 	if model := sessionModelOverride(session); model != "" {
 		promptCtx = agent.WithModel(promptCtx, model)
 	}
+	promptCtx = s.withGenerationProfile(promptCtx, agentModel, msg)
+	promptCtx = withThinking(promptCtx, session, msg)
 	if effectiveElevated != agent.ElevatedOff {
 		promptCtx = agent.WithElevated(promptCtx, effectiveElevated)
 	}
@@ -333,11 +365,25 @@ func (s *Server) handleMessage(ctx context.Context, msg *models.Message) {
 		}
 	}
 
+	checkpoint, ok := s.resumeCheckpoint(ctx, session, msg)
+	if !ok {
+		return
+	}
+	if checkpoint != nil {
+		promptCtx = agent.WithResumeCheckpoint(promptCtx, checkpoint)
+	}
+
 	runCtx, cancel := context.WithTimeout(promptCtx, maxProcessingTime)
 	runToken := s.registerActiveRun(session.ID, cancel)
+
+	// Matches the run ID the runtime assigns, read before Process can fill in msg.ID.
+	runID := session.ID + "-" + msg.ID
+	s.setActiveRunID(session.ID, runToken, runID)
+	s.journalRunStart(session, msg, runID)
 	defer func() {
 		cancel()
 		s.finishActiveRun(session.ID, runToken)
+		s.journalRunFinish(ctx, session.ID, runID)
 	}()
 
 	chunks, err := runtime.Process(runCtx, session, msg)
@@ -370,6 +416,9 @@ func (s *Server) handleMessage(ctx context.Context, msg *models.Message) {
 		outboundMsg.Metadata["steering_rules"] = steeringTrace
 	}
 
+	// Plan progress is a separate message, edited as the agent's plan changes
+	progress := s.newPlanProgress(streamingAdapter, hasStreaming, outboundMsg)
+
 	// Streaming state - use atomic for hasStreaming to avoid race conditions
 	var streamingEnabled atomic.Bool
 	streamingEnabled.Store(hasStreaming)
@@ -395,8 +444,34 @@ func (s *Server) handleMessage(ctx context.Context, msg *models.Message) {
 	for chunk := range chunks {
 		if chunk.Error != nil {
 			s.logger.Error("runtime stream error", "error", chunk.Error)
+			outcome := "cancelled"
+			if runCtx.Err() == nil && !errors.Is(chunk.Error, context.Canceled) {
+				outcome = "error"
+				var repeated *agent.RepeatedCallError
+				if errors.As(chunk.Error, &repeated) {
+					s.sendImmediateReply(ctx, session, msg, s.systemMessage(ctx, msg, messages.RunLoopStopped, messages.Data{"Tool": repeated.Tool}))
+				} else {
+					// The error stays in the logs; it can carry internal
+					// details that must not reach chat users.
+					s.sendImmediateReply(ctx, session, msg, s.systemMessage(ctx, msg, messages.RunFailed, nil))
+				}
+			}
+			EmitMessageProcessed(string(msg.Channel), outboundMsg.ID, channelID, key, session.ID,
+				outcome, "", chunk.Error.Error(), time.Since(startTime).Milliseconds())
 			return
 		}
+		if chunk.ToolEvent != nil || chunk.ToolResult != nil {
+			s.journalRunChunk(session.ID, chunk.ToolEvent, chunk.ToolResult)
+		}
+		if event := chunk.ToolEvent; event != nil && event.Stage == models.ToolEventApprovalRequired {
+			s.sendImmediateReply(ctx, session, msg, s.systemMessage(ctx, msg, messages.ApprovalRequired, messages.Data{
+				"Tool":   event.ToolName,
+				"Reason": event.PolicyReason,
+			}))
+		}
+		if chunk.Plan != nil {
+			progress.update(runCtx, chunk.Plan)
+		}
 		if chunk.Text != "" {
 			// Check size limit to prevent memory exhaustion
 			if response.Len()+len(chunk.Text) > maxResponseSize {
@@ -470,7 +545,7 @@ func (s *Server) handleMessage(ctx context.Context, msg *models.Message) {
 		// Collect artifacts from tool executions for sending as attachments
 		if len(chunk.Artifacts) > 0 {
 			for _, art := range chunk.Artifacts {
-				attachments = append(attachments, s.artifactToAttachment(art))
+				attachments = append(attachments, s.artifactToAttachment(ctx, art))
 			}
 		}
 	}
@@ -497,7 +572,10 @@ func (s *Server) handleMessage(ctx context.Context, msg *models.Message) {
 	finalStreamingEnabled := streamingEnabled.Load()
 	mu.Unlock()
 
+	// deliveredID is the platform ID of the reply, used to attribute reactions.
+	var deliveredID string
 	if finalStreamingEnabled && finalStreamingMsgID != "" {
+		deliveredID = finalStreamingMsgID
 		// Do final update with complete content
 		if err := streamingAdapter.UpdateStreamingResponse(runCtx, outboundMsg, finalStreamingMsgID, response.String()); err != nil {
 			s.logger.Debug("failed to send final streaming update", "error", err)
@@ -508,6 +586,7 @@ func (s *Server) handleMessage(ctx context.Context, msg *models.Message) {
 				s.logger.Error("failed to send outbound message", "error", err)
 				return
 			}
+			deliveredID = outboundMsg.ChannelID
 		} else if len(outboundMsg.Attachments) > 0 {
 			// Streaming adapters typically only update message text; send any attachments separately.
 			attachmentMsg := &models.Message{
@@ -536,7 +615,11 @@ func (s *Server) handleMessage(ctx context.Context, msg *models.Message) {
 				"error", "", err.Error(), time.Since(startTime).Milliseconds())
 			return
 		}
+		deliveredID = outboundMsg.ChannelID
 	}
+	s.trackFeedbackTarget(session, msg, runID, deliveredID)
+	s.reportCanaryReply(msg, outboundMsg.Content)
+	s.bridgeMessage(session, outboundMsg, eventbridge.TypeMessageSent, runID)
 
 	// Track outbound activity and emit completion event
 	if s.integration != nil {
@@ -558,7 +641,13 @@ func (s *Server) handleMessage(ctx context.Context, msg *models.Message) {
 }
 
 func (s *Server) sendImmediateReply(ctx context.Context, session *models.Session, inbound *models.Message, content string) {
-	if strings.TrimSpace(content) == "" {
+	s.sendImmediateReplyWithAttachments(ctx, session, inbound, content, nil)
+}
+
+// sendImmediateReplyWithAttachments sends a reply with files directly to the
+// inbound message's channel, bypassing the agent runtime.
+func (s *Server) sendImmediateReplyWithAttachments(ctx context.Context, session *models.Session, inbound *models.Message, content string, attachments []models.Attachment) {
+	if strings.TrimSpace(content) == "" && len(attachments) == 0 {
 		return
 	}
 	adapter, ok := s.channels.GetOutbound(inbound.Channel)
@@ -567,13 +656,14 @@ func (s *Server) sendImmediateReply(ctx context.Context, session *models.Session
 		return
 	}
 	outbound := &models.Message{
-		SessionID: session.ID,
-		Channel:   inbound.Channel,
-		Direction: models.DirectionOutbound,
-		Role:      models.RoleAssistant,
-		Content:   content,
-		Metadata:  s.buildReplyMetadata(inbound),
-		CreatedAt: time.Now(),
+		SessionID:   session.ID,
+		Channel:     inbound.Channel,
+		Direction:   models.DirectionOutbound,
+		Role:        models.RoleAssistant,
+		Content:     content,
+		Attachments: attachments,
+		Metadata:    s.buildReplyMetadata(inbound),
+		CreatedAt:   time.Now(),
 	}
 	if err := s.sendWithCircuitBreaker(ctx, inbound.Channel, func() error {
 		return adapter.Send(ctx, outbound)
@@ -581,6 +671,7 @@ func (s *Server) sendImmediateReply(ctx context.Context, session *models.Session
 		s.logger.Error("failed to send outbound message", "error", err)
 		return
 	}
+	s.bridgeMessage(session, outbound, eventbridge.TypeMessageSent, "")
 	// Track outbound activity
 	if s.integration != nil {
 		s.integration.RecordOutbound(string(inbound.Channel), inbound.ChannelID)
@@ -595,8 +686,9 @@ func (s *Server) sendImmediateReply(ctx context.Context, session *models.Session
 
 // artifactToAttachment converts an agent.Artifact to a models.Attachment.
 // Artifacts from edge tools (like screenshots) are converted to attachments
-// so they can be sent via messaging channels like WhatsApp.
-func (s *Server) artifactToAttachment(art agent.Artifact) models.Attachment {
+// so they can be sent via messaging channels like WhatsApp. Artifacts held
+// in a presigning store are sent as presigned URLs rather than inline data.
+func (s *Server) artifactToAttachment(ctx context.Context, art agent.Artifact) models.Attachment {
 	// Determine attachment type from artifact type or mime type
 	attType := "file"
 	switch art.Type {
@@ -627,6 +719,8 @@ func (s *Server) artifactToAttachment(art agent.Artifact) models.Attachment {
 	// If artifact has a URL, use it; otherwise create a data URL
 	if art.URL != "" {
 		att.URL = art.URL
+	} else if presigned, ok := s.presignArtifact(ctx, art.ID, artifacts.PresignOptions{}); ok {
+		att.URL = presigned
 	} else if len(art.Data) > 0 {
 		// Create data URL for inline artifacts
 		att.URL = "data:" + art.MimeType + ";base64," + base64.StdEncoding.EncodeToString(art.Data)
@@ -699,6 +793,9 @@ func (s *Server) handleBroadcastMessage(ctx context.Context, peerID string, msg
 	s.broadcastManager.runtime = runtime
 	s.broadcastManager.sessions = s.sessions
 
+	// Resolve once; the agents share msg.
+	s.resolveReplyContext(ctx, msg)
+
 	results, err := s.broadcastManager.ProcessBroadcastWithContext(
 		ctx,
 		peerID,
@@ -714,13 +811,14 @@ func (s *Server) handleBroadcastMessage(ctx context.Context, peerID string, msg
 			}
 
 			overrides := parseAgentToolOverrides(agentModel)
-			toolPolicy := s.resolveToolPolicy(agentModel, msg)
+			toolPolicy := s.resolveToolPolicy(ctx, agentModel, msg)
 
 			var agentElevatedCfg *config.ElevatedConfig
 			if overrides.HasElevated {
 				agentElevatedCfg = &overrides.Elevated
 			}
-			elevatedAllowed, _ := resolveElevatedPermission(s.config.Tools.Elevated, agentElevatedCfg, msg)
+			elevatedGranted, _ := s.accessGranted(ctx, access.ListElevated, msg.Channel, extractSenderID(msg))
+			elevatedAllowed, _ := resolveElevatedPermission(s.config.Tools.Elevated, agentElevatedCfg, msg, elevatedGranted)
 			effectiveElevated := elevatedModeFromSession(session)
 
 			if directive, ok := parseElevatedDirective(msg.Content); ok && directive.Scope == elevatedScopeInline {
@@ -740,12 +838,13 @@ func (s *Server) handleBroadcastMessage(ctx context.Context, peerID string, msg
 			if systemPrompt != "" {
 				promptCtx = agent.WithSystemPrompt(promptCtx, systemPrompt)
 			}
-			if overrides := s.experimentOverrides(session, msg); overrides.Model != "" {
-				promptCtx = agent.WithModel(promptCtx, overrides.Model)
-			}
+			promptCtx = agent.WithQuotes(promptCtx, replyQuotes(msg))
+			promptCtx = s.withExperiments(promptCtx, session, msg)
 			if model := sessionModelOverride(session); model != "" {
 				promptCtx = agent.WithModel(promptCtx, model)
 			}
+			promptCtx = s.withGenerationProfile(promptCtx, agentModel, msg)
+			promptCtx = withThinking(promptCtx, session, msg)
 			if effectiveElevated != agent.ElevatedOff {
 				promptCtx = agent.WithElevated(promptCtx, effectiveElevated)
 			}
@@ -0,0 +1,298 @@
+package gateway
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/haasonsaas/nexus/internal/agent"
+	"github.com/haasonsaas/nexus/internal/cluster"
+	"github.com/haasonsaas/nexus/internal/config"
+	"github.com/haasonsaas/nexus/internal/delivery"
+	"github.com/haasonsaas/nexus/pkg/models"
+)
+
+// defaultKeyID names llm.providers.<provider>.api_key within a key pool.
+const defaultKeyID = "default"
+
+// keyRotationReminderInterval is how often stale keys are reported.
+const keyRotationReminderInterval = 24 * time.Hour
+
+// providerKeyPool is a live key pool and the keys it was last built from.
+type providerKeyPool struct {
+	pool        *agent.KeyPool
+	providerKey string
+	fingerprint string
+}
+
+// resolvedKey is one API key of a provider, read from config or key_file.
+type resolvedKey struct {
+	ID        string
+	Secret    string
+	Weight    int
+	RateLimit config.LLMRateLimitConfig
+}
+
+// keyedProvider reports whether providerKey authenticates with API keys.
+func keyedProvider(providerKey string) bool {
+	switch providerKey {
+	case "anthropic", "openai", "google", "gemini", "openrouter", "azure":
+		return true
+	}
+	return false
+}
+
+// resolveProviderKeys lists the keys of cfg: api_key as "default", then
+// every entry of keys, reading key_file entries from disk.
+func resolveProviderKeys(cfg config.LLMProviderConfig) ([]resolvedKey, error) {
+	var keys []resolvedKey
+	if secret := strings.TrimSpace(cfg.APIKey); secret != "" {
+		keys = append(keys, resolvedKey{ID: defaultKeyID, Secret: secret, Weight: 1})
+	}
+	for _, entry := range cfg.Keys {
+		secret := strings.TrimSpace(entry.Key)
+		if path := strings.TrimSpace(entry.KeyFile); path != "" {
+			data, err := os.ReadFile(path)
+			if err != nil {
+				return nil, fmt.Errorf("key %q: %w", entry.ID, err)
+			}
+			secret = strings.TrimSpace(string(data))
+		}
+		if secret == "" {
+			return nil, fmt.Errorf("key %q is empty", entry.ID)
+		}
+		keys = append(keys, resolvedKey{ID: entry.ID, Secret: secret, Weight: entry.Weight, RateLimit: entry.RateLimit})
+	}
+	return keys, nil
+}
+
+// keysFingerprint identifies a key set without holding on to the secrets.
+func keysFingerprint(keys []resolvedKey) string {
+	hash := sha256.New()
+	for _, key := range keys {
+		fmt.Fprintf(hash, "%s\x00%d\x00%s\x00%+v\x00", key.ID, key.Weight, key.Secret, key.RateLimit)
+	}
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// buildKeyPool creates, or returns the existing, key pool for providerID.
+func (s *Server) buildKeyPool(providerID, providerKey string, cfg config.LLMProviderConfig) (agent.LLMProvider, string, error) {
+	s.keyPoolsMu.Lock()
+	defer s.keyPoolsMu.Unlock()
+	if existing := s.keyPools[providerID]; existing != nil {
+		return existing.pool, cfg.DefaultModel, nil
+	}
+
+	keys, err := resolveProviderKeys(cfg)
+	if err != nil {
+		return nil, "", fmt.Errorf("provider %q: %w", providerID, err)
+	}
+	pooled, err := s.pooledKeys(providerKey, cfg, keys)
+	if err != nil {
+		return nil, "", err
+	}
+	pool := agent.NewKeyPool(pooled, s.keyPoolConfig(providerID))
+	if s.keyPools == nil {
+		s.keyPools = make(map[string]*providerKeyPool)
+	}
+	s.keyPools[providerID] = &providerKeyPool{
+		pool:        pool,
+		providerKey: providerKey,
+		fingerprint: keysFingerprint(keys),
+	}
+	return pool, cfg.DefaultModel, nil
+}
+
+// pooledKeys builds one provider per key.
+func (s *Server) pooledKeys(providerKey string, cfg config.LLMProviderConfig, keys []resolvedKey) ([]agent.PooledKey, error) {
+	pooled := make([]agent.PooledKey, 0, len(keys))
+	for _, key := range keys {
+		keyCfg := cfg
+		keyCfg.APIKey = key.Secret
+		keyCfg.Keys = nil
+		provider, _, err := s.buildProviderWithConfig(providerKey, keyCfg)
+		if err != nil {
+			return nil, fmt.Errorf("key %q: %w", key.ID, err)
+		}
+		provider = s.limitProvider(providerKey+"/"+key.ID, key.RateLimit, provider)
+		pooled = append(pooled, agent.PooledKey{ID: key.ID, Weight: key.Weight, Provider: provider})
+	}
+	return pooled, nil
+}
+
+func (s *Server) keyPoolConfig(providerID string) agent.KeyPoolConfig {
+	rotation := s.config.LLM.KeyRotation
+	return agent.KeyPoolConfig{
+		RateLimitCooldown: rotation.RateLimitCooldown,
+		AuthCooldown:      rotation.AuthCooldown,
+		OnDemote: func(keyID, reason string, until time.Time, err error) {
+			s.logger.Warn("provider key demoted",
+				"provider", providerID,
+				"key_id", keyID,
+				"reason", reason,
+				"until", until.Format(time.RFC3339),
+				"error", err,
+			)
+		},
+	}
+}
+
+// startProviderKeyWatch swaps provider keys in place whenever the config
+// file changes, so keys rotated with `nexus auth rotate` take effect without
+// a restart.
+func (s *Server) startProviderKeyWatch(ctx context.Context) {
+	if s == nil || s.config == nil {
+		return
+	}
+	path := strings.TrimSpace(s.configPath)
+	if path == "" {
+		return
+	}
+	// Pools are built with the runtime, which may come later, so watch
+	// whenever a provider has keys.
+	keyed := false
+	for name := range s.config.LLM.Providers {
+		if keyedProvider(strings.ToLower(name)) {
+			keyed = true
+			break
+		}
+	}
+	if !keyed {
+		return
+	}
+	if err := s.watchConfigFile(ctx, "worker:provider-key-watch", path, s.reloadProviderKeys); err != nil {
+		s.logger.Warn("provider key watch disabled", "path", path, "error", err)
+	}
+}
+
+// reloadProviderKeys loads path and replaces the keys of every pool whose
+// keys changed. An invalid file or unreadable key keeps the current keys.
+func (s *Server) reloadProviderKeys(path string) {
+	cfg, err := config.Load(path)
+	if err != nil {
+		s.logger.Warn("provider key reload failed; keeping current keys", "path", path, "error", err)
+		return
+	}
+	s.applyProviderKeys(cfg.LLM)
+}
+
+func (s *Server) applyProviderKeys(llm config.LLMConfig) {
+	s.keyPoolsMu.Lock()
+	defer s.keyPoolsMu.Unlock()
+	s.keyRotationLLM = &llm
+
+	for providerID, entry := range s.keyPools {
+		baseID, profileID := splitProviderProfileID(providerID)
+		providerCfg, ok := llm.Providers[entry.providerKey]
+		if !ok {
+			providerCfg, ok = llm.Providers[baseID]
+		}
+		if !ok {
+			s.logger.Warn("provider removed from config; keeping current keys", "provider", providerID)
+			continue
+		}
+		effectiveCfg, err := resolveProviderProfile(providerCfg, profileID)
+		if err != nil {
+			s.logger.Warn("provider key reload failed; keeping current keys", "provider", providerID, "error", err)
+			continue
+		}
+		keys, err := resolveProviderKeys(effectiveCfg)
+		if err == nil && len(keys) == 0 {
+			err = fmt.Errorf("no api keys configured")
+		}
+		if err != nil {
+			s.logger.Warn("provider key reload failed; keeping current keys", "provider", providerID, "error", err)
+			continue
+		}
+		fingerprint := keysFingerprint(keys)
+		if fingerprint == entry.fingerprint {
+			continue
+		}
+		pooled, err := s.pooledKeys(entry.providerKey, effectiveCfg, keys)
+		if err != nil {
+			s.logger.Warn("provider key reload failed; keeping current keys", "provider", providerID, "error", err)
+			continue
+		}
+		entry.pool.Replace(pooled)
+		entry.fingerprint = fingerprint
+		ids := make([]string, 0, len(keys))
+		for _, key := range keys {
+			ids = append(ids, key.ID)
+		}
+		s.logger.Info("provider keys rotated", "provider", providerID, "keys", ids)
+	}
+}
+
+// currentKeyRotationLLM returns the LLM config last applied to the key
+// pools, or the loaded config when nothing has been reloaded.
+func (s *Server) currentKeyRotationLLM() config.LLMConfig {
+	s.keyPoolsMu.Lock()
+	defer s.keyPoolsMu.Unlock()
+	if s.keyRotationLLM != nil {
+		return *s.keyRotationLLM
+	}
+	return s.config.LLM
+}
+
+// startKeyRotationReminders reports provider keys older than
+// llm.key_rotation.remind_after once a day, in the log and to the
+// credential alert conversation when one is configured.
+func (s *Server) startKeyRotationReminders(ctx context.Context) {
+	if s == nil || s.config == nil || s.config.LLM.KeyRotation.RemindAfter < 0 {
+		return
+	}
+	alert := s.config.Security.Credentials.Alert
+	s.goSupervised(ctx, "worker:key_rotation_reminders", func(ctx context.Context) {
+		ticker := time.NewTicker(keyRotationReminderInterval)
+		defer ticker.Stop()
+		for {
+			reminders := keyRotationReminders(s.currentKeyRotationLLM(), time.Now())
+			for _, reminder := range reminders {
+				s.logger.Warn("provider key due for rotation", "reminder", reminder)
+			}
+			if len(reminders) > 0 && alert.Channel != "" && alert.PeerID != "" && s.isClusterLeader(cluster.LeaseCredentialAlerts) {
+				text := "Nexus key rotation reminder:\n" + strings.Join(reminders, "\n")
+				if _, err := s.sendProactive(ctx, delivery.KindAlert, models.ChannelType(alert.Channel), alert.PeerID, text); err != nil {
+					s.logger.Warn("failed to send key rotation reminder", "error", err)
+				}
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	})
+}
+
+// keyRotationReminders lists the keys in llm whose rotated_at is at least
+// remind_after before now.
+func keyRotationReminders(llm config.LLMConfig, now time.Time) []string {
+	remindAfter := llm.KeyRotation.RemindAfter
+	if remindAfter <= 0 {
+		return nil
+	}
+	names := make([]string, 0, len(llm.Providers))
+	for name := range llm.Providers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var reminders []string
+	for _, name := range names {
+		for _, key := range llm.Providers[name].Keys {
+			if key.RotatedAt.IsZero() || now.Sub(key.RotatedAt) < remindAfter {
+				continue
+			}
+			days := int(now.Sub(key.RotatedAt).Hours() / 24)
+			reminders = append(reminders, fmt.Sprintf("- %s key %q was last rotated %d days ago; run `nexus auth rotate --provider %s --key-id %s`",
+				name, key.ID, days, name, key.ID))
+		}
+	}
+	return reminders
+}
@@ -0,0 +1,160 @@
+package gateway
+
+import (
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/haasonsaas/nexus/internal/agent"
+	"github.com/haasonsaas/nexus/internal/config"
+)
+
+func keyPoolIDs(pool *agent.KeyPool) []string {
+	var ids []string
+	for _, status := range pool.Status() {
+		ids = append(ids, status.ID)
+	}
+	return ids
+}
+
+func TestBuildProviderPoolsKeys(t *testing.T) {
+	keyFile := filepath.Join(t.TempDir(), "anthropic-key")
+	if err := os.WriteFile(keyFile, []byte("sk-file-1\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	cfg := &config.Config{
+		LLM: config.LLMConfig{
+			Providers: map[string]config.LLMProviderConfig{
+				"anthropic": {
+					APIKey: "sk-primary",
+					Keys: []config.LLMProviderKeyConfig{
+						{ID: "backup", Key: "sk-backup", Weight: 3},
+						{ID: "mounted", KeyFile: keyFile, Weight: 1},
+					},
+					Profiles: map[string]config.LLMProviderProfileConfig{
+						"team": {APIKey: "sk-team"},
+					},
+				},
+			},
+			KeyRotation: config.LLMKeyRotationConfig{RateLimitCooldown: time.Minute, AuthCooldown: time.Hour},
+		},
+	}
+	server := &Server{config: cfg, logger: slog.New(slog.NewTextHandler(io.Discard, nil))}
+
+	provider, _, err := server.buildProvider("anthropic")
+	if err != nil {
+		t.Fatalf("buildProvider() error = %v", err)
+	}
+	pool, ok := provider.(*agent.KeyPool)
+	if !ok {
+		t.Fatalf("expected a key pool, got %T", provider)
+	}
+	if got := strings.Join(keyPoolIDs(pool), ","); got != "default,backup,mounted" {
+		t.Fatalf("keys = %s", got)
+	}
+	if again, _, _ := server.buildProvider("anthropic"); again != provider {
+		t.Fatal("expected the pool to be reused")
+	}
+	team, _, err := server.buildProvider("anthropic:team")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.Join(keyPoolIDs(team.(*agent.KeyPool)), ","); got != "default" {
+		t.Fatalf("profile keys = %s, want only its own key", got)
+	}
+
+	// Rotating the mounted key swaps it in place.
+	if err := os.WriteFile(keyFile, []byte("sk-file-2\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	before := server.keyPools["anthropic"].fingerprint
+	server.applyProviderKeys(cfg.LLM)
+	if server.keyPools["anthropic"].fingerprint == before {
+		t.Fatal("expected the rotated key to be applied")
+	}
+	if server.keyPools["anthropic:team"].fingerprint == "" {
+		t.Fatal("expected the profile pool to be kept")
+	}
+
+	// An unreadable key keeps the current keys.
+	if err := os.Remove(keyFile); err != nil {
+		t.Fatal(err)
+	}
+	rotated := server.keyPools["anthropic"].fingerprint
+	server.applyProviderKeys(cfg.LLM)
+	if server.keyPools["anthropic"].fingerprint != rotated {
+		t.Fatal("expected a failed reload to keep the current keys")
+	}
+}
+
+func TestBuildProviderSharesRateLimiters(t *testing.T) {
+	cfg := &config.Config{
+		LLM: config.LLMConfig{
+			Providers: map[string]config.LLMProviderConfig{
+				"anthropic": {
+					APIKey:    "sk-primary",
+					RateLimit: config.LLMRateLimitConfig{RequestsPerMinute: 50},
+					Keys: []config.LLMProviderKeyConfig{
+						{ID: "backup", Key: "sk-backup", RateLimit: config.LLMRateLimitConfig{TokensPerMinute: 40000}},
+					},
+				},
+			},
+		},
+	}
+	server := &Server{config: cfg, logger: slog.New(slog.NewTextHandler(io.Discard, nil))}
+
+	if _, _, err := server.buildProvider("anthropic"); err != nil {
+		t.Fatalf("buildProvider() error = %v", err)
+	}
+	provider := server.providerLimiters["anthropic"]
+	key := server.providerLimiters["anthropic/backup"]
+	if provider == nil || key == nil || server.providerLimiters["anthropic/default"] != nil {
+		t.Fatalf("limiters = %v, want the provider and the limited key", server.providerLimiters)
+	}
+	if _, _, err := server.buildProvider("anthropic"); err != nil {
+		t.Fatal(err)
+	}
+	if server.providerLimiters["anthropic"] != provider {
+		t.Fatal("expected rebuilding the provider to reuse its limiter")
+	}
+
+	// Changing a key's limits on reload replaces its limiter.
+	llm := cfg.LLM
+	anthropic := llm.Providers["anthropic"]
+	anthropic.Keys = []config.LLMProviderKeyConfig{
+		{ID: "backup", Key: "sk-backup", RateLimit: config.LLMRateLimitConfig{TokensPerMinute: 80000}},
+	}
+	llm.Providers = map[string]config.LLMProviderConfig{"anthropic": anthropic}
+	server.applyProviderKeys(llm)
+	if updated := server.providerLimiters["anthropic/backup"]; updated == key || updated.config.TokensPerMinute != 80000 {
+		t.Fatal("expected the reloaded key limits to apply")
+	}
+}
+
+func TestKeyRotationReminders(t *testing.T) {
+	now := time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)
+	llm := config.LLMConfig{
+		Providers: map[string]config.LLMProviderConfig{
+			"openai": {Keys: []config.LLMProviderKeyConfig{
+				{ID: "old", Key: "a", RotatedAt: now.AddDate(0, 0, -120)},
+				{ID: "fresh", Key: "b", RotatedAt: now.AddDate(0, 0, -10)},
+				{ID: "undated", Key: "c"},
+			}},
+		},
+		KeyRotation: config.LLMKeyRotationConfig{RemindAfter: 90 * 24 * time.Hour},
+	}
+	reminders := keyRotationReminders(llm, now)
+	if len(reminders) != 1 || !strings.Contains(reminders[0], `openai key "old" was last rotated 120 days ago`) ||
+		!strings.Contains(reminders[0], "nexus auth rotate --provider openai --key-id old") {
+		t.Fatalf("reminders = %v", reminders)
+	}
+
+	llm.KeyRotation.RemindAfter = -1
+	if reminders := keyRotationReminders(llm, now); len(reminders) != 0 {
+		t.Fatalf("expected reminders to be disabled, got %v", reminders)
+	}
+}
@@ -0,0 +1,54 @@
+package gateway
+
+import (
+	"time"
+
+	"github.com/haasonsaas/nexus/internal/agent"
+	"github.com/haasonsaas/nexus/internal/config"
+	"github.com/haasonsaas/nexus/internal/observability"
+)
+
+// providerLimiter is a live rate limiter and the config it was built from.
+type providerLimiter struct {
+	limiter *agent.ProviderLimiter
+	config  config.LLMRateLimitConfig
+}
+
+// limitProvider wraps provider with the rate limiter named name, such as
+// "anthropic" or "anthropic/key-2". Limiters are shared by name, so every
+// provider built for the same account draws from the same buckets. provider
+// is returned unchanged when cfg sets no limit.
+func (s *Server) limitProvider(name string, cfg config.LLMRateLimitConfig, provider agent.LLMProvider) agent.LLMProvider {
+	limits := agent.ProviderLimits{
+		RequestsPerMinute: cfg.RequestsPerMinute,
+		TokensPerMinute:   cfg.TokensPerMinute,
+		MaxConcurrent:     cfg.MaxConcurrent,
+		MaxWait:           cfg.MaxWait,
+	}
+	if provider == nil || !limits.Enabled() {
+		return provider
+	}
+
+	s.providerLimitersMu.Lock()
+	defer s.providerLimitersMu.Unlock()
+	if existing := s.providerLimiters[name]; existing != nil && existing.config == cfg {
+		return existing.limiter.Wrap(provider)
+	}
+	metrics := observability.NewProviderThrottleMetrics()
+	limiter := agent.NewProviderLimiter(name, limits, agent.ProviderLimiterHooks{
+		OnWait: metrics.RecordWait,
+		OnReject: func(name, reason string) {
+			metrics.RecordReject(name, reason)
+			s.logger.Warn("provider request throttled", "provider", name, "reason", reason)
+		},
+		OnPause: func(name string, pause time.Duration) {
+			metrics.RecordPause(name)
+			s.logger.Warn("provider asked to back off", "provider", name, "retry_after", pause)
+		},
+	})
+	if s.providerLimiters == nil {
+		s.providerLimiters = make(map[string]*providerLimiter)
+	}
+	s.providerLimiters[name] = &providerLimiter{limiter: limiter, config: cfg}
+	return limiter.Wrap(provider)
+}
@@ -43,7 +43,9 @@ func resolveProviderProfile(cfg config.LLMProviderConfig, profileID string) (con
 	}
 	effective := cfg
 	if profile.APIKey != "" {
+		// A profile key replaces the provider's key rotation.
 		effective.APIKey = profile.APIKey
+		effective.Keys = nil
 	}
 	if profile.DefaultModel != "" {
 		effective.DefaultModel = profile.DefaultModel
@@ -0,0 +1,54 @@
+package gateway
+
+import (
+	"fmt"
+
+	"github.com/haasonsaas/nexus/internal/agent"
+	"github.com/haasonsaas/nexus/internal/agent/routing"
+	"github.com/haasonsaas/nexus/internal/observability"
+)
+
+// newRacer wraps fallback, the provider built from the default provider,
+// fallback chain, and routing, with a racer sending interactive requests to
+// the llm.race contenders. Contenders already in providerMap, such as a
+// discovered ollama, are reused.
+func (s *Server) newRacer(fallback agent.LLMProvider, providerMap map[string]agent.LLMProvider) (*routing.Racer, error) {
+	cfg := s.config.LLM.Race
+	contenders := make([]routing.RaceContender, 0, len(cfg.Contenders))
+	for _, target := range cfg.Contenders {
+		id := normalizeProviderID(target.Provider)
+		provider, model, err := s.buildProvider(id)
+		if err != nil {
+			existing, ok := providerMap[id]
+			if !ok {
+				return nil, fmt.Errorf("contender %q: %w", id, err)
+			}
+			provider = existing
+		}
+		if target.Model != "" {
+			model = target.Model
+		}
+		contenders = append(contenders, routing.RaceContender{Name: id, Provider: provider, Model: model})
+	}
+
+	metrics := observability.NewProviderRaceMetrics()
+	return routing.NewRacer(routing.RaceConfig{
+		Contenders:     contenders,
+		Fallback:       fallback,
+		Channels:       cfg.Channels,
+		MaxCostUSD:     cfg.MaxCostUSD,
+		DailyBudgetUSD: cfg.DailyBudgetUSD,
+		Catalog:        s.modelCatalog,
+		Hooks: routing.RaceHooks{
+			OnResult: func(result routing.RaceResult) {
+				metrics.RecordRace(result.Winner, result.LoserErr != nil, result.FirstChunk, result.EstimatedCostUSD)
+				s.logger.Debug("provider race finished",
+					"winner", result.Winner,
+					"loser", result.Loser,
+					"first_chunk", result.FirstChunk,
+					"loser_error", result.LoserErr)
+			},
+			OnSkip: metrics.RecordSkip,
+		},
+	})
+}
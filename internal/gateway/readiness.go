@@ -0,0 +1,328 @@
+package gateway
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/haasonsaas/nexus/internal/sessions"
+)
+
+const (
+	// readinessCheckTimeout bounds each individual readiness check.
+	readinessCheckTimeout = 3 * time.Second
+
+	// providerProbeTTL is how long a provider reachability result is reused.
+	// Probes hit external APIs, so they are not repeated on every request.
+	providerProbeTTL = 30 * time.Second
+
+	readinessOK      = "ok"
+	readinessFail    = "fail"
+	readinessSkipped = "skipped"
+)
+
+// defaultProviderEndpoints are probed when a provider has no base_url configured.
+var defaultProviderEndpoints = map[string]string{
+	"anthropic":  "https://api.anthropic.com",
+	"openai":     "https://api.openai.com",
+	"google":     "https://generativelanguage.googleapis.com",
+	"gemini":     "https://generativelanguage.googleapis.com",
+	"openrouter": "https://openrouter.ai",
+	"ollama":     "http://localhost:11434",
+}
+
+// ReadinessCheck is the result of a single readiness dependency check.
+type ReadinessCheck struct {
+	Name       string         `json:"name"`
+	Status     string         `json:"status"`
+	Message    string         `json:"message,omitempty"`
+	DurationMs int64          `json:"duration_ms"`
+	Details    map[string]any `json:"details,omitempty"`
+}
+
+// ReadinessReport aggregates the readiness checks for the gateway.
+type ReadinessReport struct {
+	Ready  bool             `json:"ready"`
+	Status string           `json:"status"`
+	NodeID string           `json:"node_id,omitempty"`
+	Checks []ReadinessCheck `json:"checks"`
+}
+
+// providerProbeCache caches the most recent provider reachability result.
+type providerProbeCache struct {
+	mu        sync.Mutex
+	endpoint  string
+	checkedAt time.Time
+	result    ReadinessCheck
+}
+
+// CheckReadiness runs all readiness checks. The gateway is ready when no
+// check fails; skipped checks do not affect readiness.
+func (s *Server) CheckReadiness(ctx context.Context) *ReadinessReport {
+	checks := []func(context.Context) ReadinessCheck{
+		s.checkDatabaseReadiness,
+		s.checkMigrationReadiness,
+		s.checkChannelReadiness,
+		s.checkProviderReadiness,
+	}
+
+	report := &ReadinessReport{Ready: true, NodeID: s.nodeID, Checks: make([]ReadinessCheck, len(checks))}
+	var wg sync.WaitGroup
+	for i, check := range checks {
+		wg.Add(1)
+		go func(i int, check func(context.Context) ReadinessCheck) {
+			defer wg.Done()
+			checkCtx, cancel := context.WithTimeout(ctx, readinessCheckTimeout)
+			defer cancel()
+			start := time.Now()
+			result := check(checkCtx)
+			if result.DurationMs == 0 {
+				result.DurationMs = time.Since(start).Milliseconds()
+			}
+			report.Checks[i] = result
+		}(i, check)
+	}
+	wg.Wait()
+
+	for _, check := range report.Checks {
+		if check.Status == readinessFail {
+			report.Ready = false
+		}
+	}
+	report.Status = "ready"
+	if !report.Ready {
+		report.Status = "not_ready"
+	}
+	return report
+}
+
+// handleReadyz serves the readiness probe. Failing checks always include their
+// message; pass ?verbose=true to include messages and details for every check.
+func (s *Server) handleReadyz(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	report := s.CheckReadiness(r.Context())
+	if r.URL.Query().Get("verbose") != "true" {
+		for i := range report.Checks {
+			report.Checks[i].Details = nil
+			if report.Checks[i].Status != readinessFail {
+				report.Checks[i].Message = ""
+			}
+		}
+	}
+
+	data, err := json.Marshal(report)
+	if err != nil {
+		if s.logger != nil {
+			s.logger.Error("readyz marshal failed", "error", err)
+		}
+		http.Error(w, "internal error", http.StatusInternalServerError)
+		return
+	}
+	statusCode := http.StatusOK
+	if !report.Ready {
+		statusCode = http.StatusServiceUnavailable
+	}
+	w.WriteHeader(statusCode)
+	if _, err := w.Write(data); err != nil && s.logger != nil {
+		s.logger.Debug("readyz write failed", "error", err)
+	}
+}
+
+// readinessDB returns the database backing the session store, if any.
+func (s *Server) readinessDB() *sql.DB {
+	if dbStore, ok := s.sessions.(interface{ DB() *sql.DB }); ok {
+		return dbStore.DB()
+	}
+	return nil
+}
+
+func (s *Server) checkDatabaseReadiness(ctx context.Context) ReadinessCheck {
+	check := ReadinessCheck{Name: "database"}
+	db := s.readinessDB()
+	if db == nil {
+		check.Status = readinessSkipped
+		check.Message = "no database configured"
+		return check
+	}
+	if err := db.PingContext(ctx); err != nil {
+		check.Status = readinessFail
+		check.Message = fmt.Sprintf("ping failed: %v", err)
+		return check
+	}
+	stats := db.Stats()
+	check.Status = readinessOK
+	check.Details = map[string]any{
+		"open_connections": stats.OpenConnections,
+		"in_use":           stats.InUse,
+		"idle":             stats.Idle,
+	}
+	return check
+}
+
+func (s *Server) checkMigrationReadiness(ctx context.Context) ReadinessCheck {
+	check := ReadinessCheck{Name: "migrations", Status: readinessSkipped, Details: map[string]any{}}
+
+	if db := s.readinessDB(); db != nil {
+		migrator, err := sessions.NewMigratorForDialect(db, sessions.DialectForURL(s.config.Database.URL))
+		if err != nil {
+			check.Status = readinessFail
+			check.Message = fmt.Sprintf("load migrations: %v", err)
+			return check
+		}
+		applied, pending, err := migrator.Status(ctx)
+		if err != nil {
+			check.Status = readinessFail
+			check.Message = fmt.Sprintf("migration status: %v", err)
+			return check
+		}
+		check.Status = readinessOK
+		check.Details["database_applied"] = len(applied)
+		check.Details["database_pending"] = len(pending)
+		if len(pending) > 0 {
+			check.Status = readinessFail
+			check.Message = fmt.Sprintf("%d database migration(s) pending", len(pending))
+		}
+	}
+
+	if s.integration != nil {
+		current, latest, pending, err := s.integration.GetMigrationStatus()
+		if err == nil {
+			check.Details["state_current"] = current
+			check.Details["state_latest"] = latest
+			check.Details["state_pending"] = pending
+			if pending > 0 && check.Status != readinessFail {
+				check.Status = readinessFail
+				check.Message = fmt.Sprintf("%d state migration(s) pending", pending)
+			} else if check.Status == readinessSkipped {
+				check.Status = readinessOK
+			}
+		}
+	}
+	if check.Status == readinessSkipped {
+		check.Message = "no migrations tracked"
+	}
+	return check
+}
+
+func (s *Server) checkChannelReadiness(ctx context.Context) ReadinessCheck {
+	check := ReadinessCheck{Name: "channels", Status: readinessOK}
+	if s.channels == nil {
+		check.Status = readinessSkipped
+		check.Message = "channel registry not initialized"
+		return check
+	}
+	adapters := s.channels.HealthAdapters()
+	if len(adapters) == 0 {
+		check.Message = "no channels registered"
+		return check
+	}
+
+	details := make(map[string]any, len(adapters))
+	var down []string
+	for channelType, adapter := range adapters {
+		status := adapter.Status()
+		entry := map[string]any{"connected": status.Connected}
+		if status.Error != "" {
+			entry["error"] = status.Error
+		}
+		details[string(channelType)] = entry
+		if !status.Connected {
+			down = append(down, string(channelType))
+		}
+	}
+	check.Details = details
+	if len(down) > 0 {
+		sort.Strings(down)
+		check.Status = readinessFail
+		check.Message = "disconnected: " + strings.Join(down, ", ")
+		return check
+	}
+	check.Message = fmt.Sprintf("%d channel(s) connected", len(adapters))
+	return check
+}
+
+func (s *Server) checkProviderReadiness(ctx context.Context) ReadinessCheck {
+	check := ReadinessCheck{Name: "provider"}
+	if s.providerOverride != nil {
+		check.Status = readinessSkipped
+		check.Message = "provider overridden in embedded mode"
+		return check
+	}
+	providerID, endpoint := s.providerEndpoint()
+	if endpoint == "" {
+		check.Status = readinessSkipped
+		check.Message = fmt.Sprintf("no probe endpoint for provider %q", providerID)
+		return check
+	}
+
+	cache := &s.providerProbe
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+	if cache.endpoint == endpoint && time.Since(cache.checkedAt) < providerProbeTTL {
+		cached := cache.result
+		cached.Details = map[string]any{"cached": true}
+		for k, v := range cache.result.Details {
+			cached.Details[k] = v
+		}
+		cached.DurationMs = 0
+		return cached
+	}
+
+	start := time.Now()
+	check.Details = map[string]any{"provider": providerID, "endpoint": endpoint}
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, endpoint, nil)
+	if err != nil {
+		check.Status = readinessFail
+		check.Message = fmt.Sprintf("invalid endpoint: %v", err)
+		return check
+	}
+	resp, err := http.DefaultClient.Do(req)
+	check.DurationMs = time.Since(start).Milliseconds()
+	if err != nil {
+		check.Status = readinessFail
+		check.Message = fmt.Sprintf("unreachable: %v", err)
+	} else {
+		resp.Body.Close()
+		// Any HTTP response proves the endpoint is reachable; auth and
+		// routing errors are expected for an unauthenticated HEAD.
+		check.Status = readinessOK
+		check.Details["status_code"] = resp.StatusCode
+	}
+
+	cache.endpoint = endpoint
+	cache.checkedAt = time.Now()
+	cache.result = check
+	return check
+}
+
+// providerEndpoint returns the default provider ID and the URL used to probe it.
+func (s *Server) providerEndpoint() (string, string) {
+	if s.config == nil {
+		return "", ""
+	}
+	providerID := strings.TrimSpace(s.config.LLM.DefaultProvider)
+	if providerID == "" {
+		providerID = "anthropic"
+	}
+	baseID, profileID := splitProviderProfileID(normalizeProviderID(providerID))
+	if providerCfg, ok := s.config.LLM.Providers[baseID]; ok {
+		if effective, err := resolveProviderProfile(providerCfg, profileID); err == nil {
+			if baseURL := strings.TrimSpace(effective.BaseURL); baseURL != "" {
+				return providerID, baseURL
+			}
+		}
+	}
+	if baseID == "bedrock" {
+		if region := strings.TrimSpace(s.config.LLM.Bedrock.Region); region != "" {
+			return providerID, fmt.Sprintf("https://bedrock-runtime.%s.amazonaws.com", region)
+		}
+	}
+	return providerID, defaultProviderEndpoints[baseID]
+}
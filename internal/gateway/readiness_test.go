@@ -0,0 +1,192 @@
+package gateway
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+
+	"github.com/haasonsaas/nexus/internal/channels"
+	"github.com/haasonsaas/nexus/internal/channels/loopback"
+	"github.com/haasonsaas/nexus/internal/config"
+	"github.com/haasonsaas/nexus/internal/sessions"
+	"github.com/haasonsaas/nexus/internal/tasks"
+)
+
+func newReadinessTestServer(t *testing.T, providerURL string) (*Server, *loopback.Adapter) {
+	t.Helper()
+	cfg := &config.Config{}
+	cfg.Workspace.Path = t.TempDir()
+	cfg.LLM.DefaultProvider = "openai"
+	cfg.LLM.Providers = map[string]config.LLMProviderConfig{
+		"openai": {BaseURL: providerURL},
+	}
+	server, err := NewServer(cfg, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	if err != nil {
+		t.Fatalf("NewServer() error = %v", err)
+	}
+	// Apply state migrations into the temp workspace as server startup would.
+	if err := server.integration.Start(context.Background()); err != nil {
+		t.Fatalf("integration.Start() error = %v", err)
+	}
+	t.Cleanup(func() { _ = server.integration.Stop(context.Background()) })
+	adapter := loopback.New(loopback.Config{})
+	registry := channels.NewRegistry()
+	registry.Register(adapter)
+	server.channels = registry
+	return server, adapter
+}
+
+func findReadinessCheck(t *testing.T, report *ReadinessReport, name string) ReadinessCheck {
+	t.Helper()
+	for _, check := range report.Checks {
+		if check.Name == name {
+			return check
+		}
+	}
+	t.Fatalf("check %q missing from report", name)
+	return ReadinessCheck{}
+}
+
+func TestCheckReadiness(t *testing.T) {
+	var hits atomic.Int32
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer upstream.Close()
+
+	server, adapter := newReadinessTestServer(t, upstream.URL)
+	ctx := context.Background()
+
+	report := server.CheckReadiness(ctx)
+	if report.Ready {
+		t.Fatal("expected not ready while channel is disconnected")
+	}
+	if check := findReadinessCheck(t, report, "channels"); check.Status != readinessFail {
+		t.Fatalf("channels status = %q, want fail", check.Status)
+	}
+	if check := findReadinessCheck(t, report, "database"); check.Status != readinessSkipped {
+		t.Fatalf("database status = %q, want skipped", check.Status)
+	}
+	provider := findReadinessCheck(t, report, "provider")
+	if provider.Status != readinessOK || provider.Details["status_code"] != http.StatusUnauthorized {
+		t.Fatalf("provider check = %+v", provider)
+	}
+
+	if err := adapter.Start(ctx); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+	report = server.CheckReadiness(ctx)
+	if !report.Ready || report.Status != "ready" {
+		t.Fatalf("expected ready, got %+v", report)
+	}
+	if cached := findReadinessCheck(t, report, "provider"); cached.Details["cached"] != true {
+		t.Fatalf("expected cached provider result, got %+v", cached)
+	}
+	if got := hits.Load(); got != 1 {
+		t.Fatalf("provider probed %d times, want 1", got)
+	}
+}
+
+func TestCheckReadinessProviderUnreachable(t *testing.T) {
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	url := upstream.URL
+	upstream.Close()
+
+	server, _ := newReadinessTestServer(t, url)
+	check := server.checkProviderReadiness(context.Background())
+	if check.Status != readinessFail {
+		t.Fatalf("provider status = %q, want fail", check.Status)
+	}
+}
+
+func TestCheckReadinessSQLiteDatabase(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.Workspace.Path = t.TempDir()
+	cfg.Database.URL = "sqlite://" + filepath.Join(t.TempDir(), "nexus.db")
+	cfg.Tasks.Enabled = true
+	server, err := NewServer(cfg, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	if err != nil {
+		t.Fatalf("NewServer() error = %v", err)
+	}
+	taskStore, ok := server.taskStore.(*tasks.SQLiteStore)
+	if !ok {
+		t.Fatalf("expected sqlite task store, got %T", server.taskStore)
+	}
+	t.Cleanup(func() { _ = taskStore.Close() })
+	store, err := server.newSessionStore()
+	if err != nil {
+		t.Fatalf("newSessionStore() error = %v", err)
+	}
+	sqliteStore, ok := store.(*sessions.SQLiteStore)
+	if !ok {
+		t.Fatalf("expected sqlite session store, got %T", store)
+	}
+	t.Cleanup(func() { _ = sqliteStore.Close() })
+	server.sessions = store
+
+	ctx := context.Background()
+	if check := server.checkDatabaseReadiness(ctx); check.Status != readinessOK {
+		t.Fatalf("database check = %+v", check)
+	}
+	check := server.checkMigrationReadiness(ctx)
+	if check.Details["database_pending"] != 0 {
+		t.Fatalf("migrations check = %+v", check)
+	}
+}
+
+func TestHandleReadyzVerbosity(t *testing.T) {
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer upstream.Close()
+	server, _ := newReadinessTestServer(t, upstream.URL)
+
+	rec := httptest.NewRecorder()
+	server.handleReadyz(rec, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Fatalf("status = %d, want 503", rec.Code)
+	}
+	var report ReadinessReport
+	if err := json.Unmarshal(rec.Body.Bytes(), &report); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	for _, check := range report.Checks {
+		if check.Details != nil {
+			t.Fatalf("check %q has details without verbose", check.Name)
+		}
+		if check.Status == readinessFail && check.Message == "" {
+			t.Fatalf("failing check %q missing message", check.Name)
+		}
+	}
+
+	rec = httptest.NewRecorder()
+	server.handleReadyz(rec, httptest.NewRequest(http.MethodGet, "/readyz?verbose=true", nil))
+	report = ReadinessReport{}
+	if err := json.Unmarshal(rec.Body.Bytes(), &report); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if check := findReadinessCheck(t, &report, "channels"); check.Details == nil {
+		t.Fatal("expected channel details with verbose")
+	}
+}
+
+func TestHandleHealthzIsLiveness(t *testing.T) {
+	server, _ := newReadinessTestServer(t, "")
+	rec := httptest.NewRecorder()
+	server.handleHealthz(rec, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200", rec.Code)
+	}
+	var body map[string]any
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if body["status"] != "ok" {
+		t.Fatalf("status = %v, want ok", body["status"])
+	}
+}
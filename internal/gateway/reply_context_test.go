@@ -0,0 +1,99 @@
+package gateway
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/haasonsaas/nexus/internal/agent"
+	"github.com/haasonsaas/nexus/internal/channels"
+	"github.com/haasonsaas/nexus/internal/config"
+	"github.com/haasonsaas/nexus/internal/sessions"
+	"github.com/haasonsaas/nexus/pkg/models"
+)
+
+// replyFetchingAdapter resolves replies like adapters whose platform does
+// not deliver the referenced message inline.
+type replyFetchingAdapter struct {
+	recordingAdapter
+	fetched []string
+}
+
+func (a *replyFetchingAdapter) ResolveReplyContext(ctx context.Context, msg *models.Message, ref channels.ReplyReference) (*channels.ReplyReference, error) {
+	a.fetched = append(a.fetched, ref.MessageID)
+	ref.Content = "Deploy is blocked on the DB migration."
+	ref.SenderName = "Ana"
+	ref.SentAt = time.Date(2026, 10, 14, 9, 12, 0, 0, time.UTC)
+	return &ref, nil
+}
+
+func TestHandleMessageInjectsReplyContext(t *testing.T) {
+	ctx := context.Background()
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	server, err := NewServer(&config.Config{Session: config.SessionConfig{DefaultAgentID: "main"}}, logger)
+	if err != nil {
+		t.Fatalf("NewServer() error = %v", err)
+	}
+	store := sessions.NewMemoryStore()
+	provider := &recordingSummaryProvider{}
+	server.sessions = store
+	server.runtime = agent.NewRuntime(provider, store)
+	adapter := &replyFetchingAdapter{}
+	registry := channels.NewRegistry()
+	registry.Register(adapter)
+	server.channels = registry
+
+	msg := &models.Message{
+		ID:        "m1",
+		Channel:   models.ChannelTelegram,
+		ChannelID: "chat-1",
+		Direction: models.DirectionInbound,
+		Role:      models.RoleUser,
+		Content:   "is this still true?",
+		Metadata:  map[string]any{"chat_id": int64(1), "user_id": int64(7)},
+	}
+	channels.SetReplyReference(msg, &channels.ReplyReference{MessageID: "55", Quote: "blocked"})
+	server.handleMessage(ctx, msg)
+
+	if len(adapter.fetched) != 1 || adapter.fetched[0] != "55" {
+		t.Fatalf("expected the referenced message to be fetched once, got %v", adapter.fetched)
+	}
+	if provider.req == nil {
+		t.Fatal("expected the runtime to call the provider")
+	}
+	for _, want := range []string{
+		"replying to an earlier message from Ana (sent 2026-10-14 09:12 UTC)",
+		"<quoted_message>\nDeploy is blocked on the DB migration.\n</quoted_message>",
+		"<quote>\nblocked\n</quote>",
+	} {
+		if !strings.Contains(provider.req.System, want) {
+			t.Fatalf("system prompt missing %q:\n%s", want, provider.req.System)
+		}
+	}
+	if ref, ok := channels.ReplyReferenceFrom(msg); !ok || !ref.Resolved() {
+		t.Fatalf("expected the resolved reference to be stored on the message, got %+v", ref)
+	}
+
+	// Inline content is used as-is without a fetch.
+	provider.req = nil
+	next := &models.Message{
+		ID:        "m2",
+		Channel:   models.ChannelTelegram,
+		ChannelID: "chat-1",
+		Direction: models.DirectionInbound,
+		Role:      models.RoleUser,
+		Content:   "and this?",
+		Metadata:  map[string]any{"chat_id": int64(1), "user_id": int64(7)},
+	}
+	channels.SetReplyReference(next, &channels.ReplyReference{MessageID: "56", Content: "Rollback done", ThreadParent: true, FromBot: true, SenderName: "nexus"})
+	server.handleMessage(ctx, next)
+	if len(adapter.fetched) != 1 {
+		t.Fatalf("expected no fetch for inline replies, got %v", adapter.fetched)
+	}
+	if provider.req == nil || !strings.Contains(provider.req.System, "in a thread started by nexus (a bot).") {
+		t.Fatalf("unexpected system prompt for thread reply: %+v", provider.req)
+	}
+}
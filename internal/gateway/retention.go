@@ -0,0 +1,123 @@
+package gateway
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/haasonsaas/nexus/internal/cluster"
+	"github.com/haasonsaas/nexus/internal/observability"
+	"github.com/haasonsaas/nexus/internal/privacy"
+)
+
+// startRetentionEnforcement starts the background sweep that deletes data
+// older than the configured retention policies.
+func (s *Server) startRetentionEnforcement(ctx context.Context) {
+	if s == nil || s.config == nil || !s.config.Privacy.Retention.Enabled {
+		return
+	}
+	interval := s.config.Privacy.Retention.Interval
+	if interval <= 0 {
+		return
+	}
+
+	s.runtimeMu.Lock()
+	if s.sessions == nil {
+		store, err := s.newSessionStore()
+		if err != nil {
+			s.logger.Warn("retention enforcement disabled (session store init failed)", "error", err)
+			s.runtimeMu.Unlock()
+			return
+		}
+		s.sessions = store
+	}
+	store, ok := s.sessions.(privacy.SessionStore)
+	s.runtimeMu.Unlock()
+	if !ok {
+		s.logger.Warn("retention enforcement disabled (session store does not support retention)")
+		return
+	}
+
+	enforcer := privacy.NewEnforcer(s.config.Privacy.Retention, s.config.Session.Scoping.IdentityLinks, s.privacyStores(ctx, store), s.logger)
+
+	s.goSupervised(ctx, "worker:retention", func(ctx context.Context) {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if !s.isClusterLeader(cluster.LeaseRetention) {
+					continue
+				}
+				report, err := enforcer.Run(ctx)
+				if err != nil {
+					s.logger.Error("retention enforcement failed", "error", err)
+				}
+				if report.Total() > 0 {
+					s.logger.Info("retention enforcement removed expired data",
+						"sessions", report.Sessions,
+						"messages", report.Messages,
+						"artifacts", report.Artifacts,
+						"memories", report.Memories,
+						"events", report.Events,
+						"checkpoints", report.Checkpoints,
+					)
+				}
+			}
+		}
+	})
+}
+
+// eraseIdentity deletes all data held for peer and its linked identities and
+// writes a signed erasure record, as "nexus privacy erase" does offline.
+func (s *Server) eraseIdentity(ctx context.Context, peer, requestedBy string) (*privacy.ErasureRecord, string, error) {
+	s.runtimeMu.Lock()
+	store, ok := s.sessions.(privacy.SessionStore)
+	s.runtimeMu.Unlock()
+	if !ok {
+		return nil, "", errors.New("session store does not support erasure")
+	}
+	if s.config == nil {
+		return nil, "", errors.New("privacy config unavailable")
+	}
+	cfg := s.config
+	key, err := privacy.LoadSigningKey(cfg.Privacy.Erasure.SigningKeyPath)
+	if err != nil {
+		return nil, "", err
+	}
+	eraser := privacy.NewEraser(cfg.Session.Scoping.IdentityLinks, s.privacyStores(ctx, store), key, cfg.Privacy.Erasure.AuditDir)
+	return eraser.Erase(ctx, peer, requestedBy)
+}
+
+// privacyStores bundles the stores retention and erasure operate on. Persisted
+// agent events are included whenever their table exists, even with event
+// persistence turned off, since rows from earlier runs may remain.
+func (s *Server) privacyStores(ctx context.Context, store privacy.SessionStore) privacy.Stores {
+	stores := privacy.Stores{Sessions: store, Artifacts: s.artifactRepo}
+	if s.vectorMemory != nil {
+		stores.Memory = s.vectorMemory
+	}
+	if s.runCheckpoints != nil {
+		stores.Checkpoints = s.runCheckpoints
+	}
+	s.runtimeMu.Lock()
+	db := s.readinessDB()
+	s.runtimeMu.Unlock()
+	if db == nil {
+		return stores
+	}
+	events, err := observability.NewDBEventStore(db)
+	if err == nil {
+		_, err = events.Query(ctx, observability.EventQuery{Limit: 1})
+	}
+	if err != nil {
+		s.logger.Warn("privacy: persisted events are not covered (run `nexus migrate up`?)", "error", err)
+		return stores
+	}
+	events.SetKeyring(s.keyring)
+	stores.Events = events
+	return stores
+}
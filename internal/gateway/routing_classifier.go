@@ -0,0 +1,52 @@
+package gateway
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/haasonsaas/nexus/internal/agent"
+	"github.com/haasonsaas/nexus/internal/agent/routing"
+)
+
+// routingClassifier builds the classifier selected by llm.routing.classifier.
+// It returns nil for the heuristic classifier, which the router defaults to,
+// and when the LLM classifier's provider cannot be built.
+func (s *Server) routingClassifier(defaultProviderID string, providers map[string]agent.LLMProvider) routing.Classifier {
+	cfg := s.config.LLM.Routing
+	if !strings.EqualFold(strings.TrimSpace(cfg.Classifier), "llm") {
+		return nil
+	}
+	providerID := normalizeProviderID(cfg.ClassifierLLM.Provider)
+	if providerID == "" {
+		providerID = defaultProviderID
+	}
+	provider, ok := providers[providerID]
+	if !ok {
+		built, _, err := s.buildProvider(providerID)
+		if err != nil {
+			if s.logger != nil {
+				s.logger.Warn("routing classifier disabled; using heuristic", "provider", providerID, "error", err)
+			}
+			return nil
+		}
+		provider = built
+	}
+
+	// Offer the model exactly the tags the rules match on.
+	var tags []string
+	for _, rule := range cfg.Rules {
+		for _, tag := range rule.Match.Tags {
+			tag = strings.ToLower(strings.TrimSpace(tag))
+			if tag != "" && !slices.Contains(tags, tag) {
+				tags = append(tags, tag)
+			}
+		}
+	}
+	return routing.NewLLMClassifier(provider, routing.LLMClassifierConfig{
+		Model:     cfg.ClassifierLLM.Model,
+		Tags:      tags,
+		Timeout:   cfg.ClassifierLLM.Timeout,
+		CacheSize: cfg.ClassifierLLM.CacheSize,
+		CacheTTL:  cfg.ClassifierLLM.CacheTTL,
+	})
+}
@@ -0,0 +1,177 @@
+package gateway
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/haasonsaas/nexus/internal/agent"
+	"github.com/haasonsaas/nexus/internal/config"
+	"github.com/haasonsaas/nexus/internal/supervisor"
+	"github.com/haasonsaas/nexus/pkg/models"
+)
+
+// metaResumeCheckpoint marks a synthetic message that resumes a checkpointed
+// run, and holds the run's ID.
+const metaResumeCheckpoint = "run_checkpoint"
+
+var (
+	// errRunActive is returned when resuming a run whose session is busy.
+	errRunActive = errors.New("the run's session has an active run")
+
+	// errRunNotResumable is returned for a checkpoint that cannot be routed
+	// back to its conversation.
+	errRunNotResumable = errors.New("run cannot be resumed")
+)
+
+// defaultCheckpointDir returns ~/.nexus/checkpoints.
+func defaultCheckpointDir() string {
+	home, err := os.UserHomeDir()
+	if err != nil || strings.TrimSpace(home) == "" {
+		home = "."
+	}
+	return filepath.Join(home, ".nexus", "checkpoints")
+}
+
+// CheckpointDir returns the directory run checkpoints are kept in.
+func CheckpointDir(cfg config.CheckpointConfig) string {
+	if dir := strings.TrimSpace(cfg.Dir); dir != "" {
+		return dir
+	}
+	return defaultCheckpointDir()
+}
+
+// setupRunCheckpoints opens the checkpoint store, or returns nil when
+// checkpointing is disabled. Runs still marked running were cut short by
+// the previous process and are marked interrupted.
+func setupRunCheckpoints(ctx context.Context, cfg config.CheckpointConfig) (*agent.FileCheckpointStore, error) {
+	if !cfg.Enabled {
+		return nil, nil
+	}
+	store, err := agent.NewFileCheckpointStore(CheckpointDir(cfg), agent.CheckpointRetention{MaxAge: cfg.MaxAge, MaxCount: cfg.MaxCount})
+	if err != nil {
+		return nil, err
+	}
+	checkpoints, err := store.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, checkpoint := range checkpoints {
+		if checkpoint.Status != agent.CheckpointRunning {
+			continue
+		}
+		checkpoint.Status = agent.CheckpointInterrupted
+		checkpoint.Error = "gateway restarted"
+		if err := store.Save(ctx, checkpoint); err != nil {
+			return nil, err
+		}
+	}
+	if _, err := store.Prune(ctx); err != nil {
+		return nil, err
+	}
+	return store, nil
+}
+
+// checkpointOptions returns the runtime's checkpoint settings.
+func (s *Server) checkpointOptions() agent.CheckpointOptions {
+	if s.runCheckpoints == nil || s.config == nil {
+		return agent.CheckpointOptions{}
+	}
+	return agent.CheckpointOptions{
+		Store:    s.runCheckpoints,
+		Interval: s.config.Session.Checkpoints.Interval,
+	}
+}
+
+// resumeCheckpointedRun starts a checkpointed run again in its conversation
+// and returns its checkpoint. The run continues in the background.
+func (s *Server) resumeCheckpointedRun(ctx context.Context, runID string) (*agent.RunCheckpoint, error) {
+	checkpoint, err := s.runCheckpoints.Get(ctx, runID)
+	if err != nil {
+		return nil, err
+	}
+	if checkpoint.Message == nil {
+		return nil, fmt.Errorf("%w: the checkpoint has no inbound message", errRunNotResumable)
+	}
+	if _, ok := s.channels.GetOutbound(checkpoint.Message.Channel); !ok {
+		return nil, fmt.Errorf("%w: no outbound adapter for channel %s", errRunNotResumable, checkpoint.Message.Channel)
+	}
+	if s.hasActiveRun(checkpoint.SessionID) {
+		return nil, errRunActive
+	}
+	processCtx := s.processCtx
+	if processCtx == nil {
+		return nil, fmt.Errorf("%w: the gateway is not processing messages", errRunNotResumable)
+	}
+
+	s.logger.Info("resuming checkpointed run",
+		"run_id", checkpoint.RunID,
+		"session_id", checkpoint.SessionID,
+		"iteration", checkpoint.Iteration,
+		"status", checkpoint.Status)
+	msg := buildCheckpointResumeMessage(checkpoint)
+	select {
+	case s.messageSem <- struct{}{}:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	s.wg.Add(1)
+	go func() {
+		defer func() {
+			<-s.messageSem
+			s.wg.Done()
+		}()
+		defer supervisor.Recover("gateway:message:" + string(msg.Channel))
+		s.handleMessage(processCtx, msg)
+	}()
+	return checkpoint, nil
+}
+
+// buildCheckpointResumeMessage copies the message that started a run so the
+// resumed run is routed to the same conversation. The runtime continues
+// from the checkpoint and does not persist the copy.
+func buildCheckpointResumeMessage(checkpoint *agent.RunCheckpoint) *models.Message {
+	orig := checkpoint.Message
+	metadata := make(map[string]any, len(orig.Metadata)+1)
+	for key, value := range orig.Metadata {
+		metadata[key] = value
+	}
+	metadata[metaResumeCheckpoint] = checkpoint.RunID
+
+	msg := *orig
+	msg.ID = fmt.Sprintf("%s-checkpoint-%d", orig.ID, time.Now().UnixNano())
+	msg.Metadata = metadata
+	msg.CreatedAt = time.Now()
+	return &msg
+}
+
+// resumeCheckpoint returns the checkpoint a message resumes, if any. ok is
+// false when the message names a checkpoint that cannot be resumed in
+// session.
+func (s *Server) resumeCheckpoint(ctx context.Context, session *models.Session, msg *models.Message) (checkpoint *agent.RunCheckpoint, ok bool) {
+	runID, _ := msg.Metadata[metaResumeCheckpoint].(string)
+	if runID == "" {
+		return nil, true
+	}
+	if s.runCheckpoints == nil {
+		s.logger.Warn("dropping checkpoint resume with checkpoints disabled", "run_id", runID)
+		return nil, false
+	}
+	checkpoint, err := s.runCheckpoints.Get(ctx, runID)
+	if err != nil {
+		s.logger.Warn("failed to load run checkpoint", "run_id", runID, "error", err)
+		return nil, false
+	}
+	if checkpoint.SessionID != session.ID {
+		s.logger.Warn("dropping checkpoint resume for a different session",
+			"run_id", runID,
+			"session_id", session.ID,
+			"checkpoint_session_id", checkpoint.SessionID)
+		return nil, false
+	}
+	return checkpoint, true
+}
@@ -0,0 +1,75 @@
+package gateway
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/haasonsaas/nexus/internal/agent"
+	"github.com/haasonsaas/nexus/internal/config"
+	"github.com/haasonsaas/nexus/pkg/models"
+)
+
+func TestResumeCheckpointedRun(t *testing.T) {
+	ctx := context.Background()
+	server, adapter, store := newRunRecoveryServer(t, config.RunRecoveryConfig{})
+	store.session = &models.Session{ID: "session-1", AgentID: "agent-test", Channel: models.ChannelTelegram}
+
+	dir := t.TempDir()
+	seed, err := agent.NewFileCheckpointStore(dir, agent.CheckpointRetention{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := seed.Save(ctx, &agent.RunCheckpoint{
+		RunID:     "session-1-tg_1",
+		SessionID: "session-1",
+		Message:   &models.Message{ID: "tg_1", Channel: models.ChannelTelegram, Content: "build it", Metadata: map[string]any{"chat_id": int64(123)}},
+		Messages: []agent.CompletionMessage{
+			{Role: "user", Content: "build it"},
+			{Role: "assistant", ToolCalls: []models.ToolCall{{ID: "call-1", Name: "exec"}}},
+		},
+		Status:    agent.CheckpointRunning,
+		UpdatedAt: time.Now(),
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	// Checkpoints left running by a previous process are marked interrupted.
+	server.runCheckpoints, err = setupRunCheckpoints(ctx, config.CheckpointConfig{Enabled: true, Dir: dir})
+	if err != nil {
+		t.Fatalf("setupRunCheckpoints() error = %v", err)
+	}
+	checkpoint, err := server.runCheckpoints.Get(ctx, "session-1-tg_1")
+	if err != nil || checkpoint.Status != agent.CheckpointInterrupted {
+		t.Fatalf("expected an interrupted checkpoint, got %+v, %v", checkpoint, err)
+	}
+	server.runtime = agent.NewRuntimeWithOptions(fixedProvider{}, store, agent.RuntimeOptions{Checkpoints: server.checkpointOptions()})
+
+	if _, err := server.resumeCheckpointedRun(ctx, "session-1-tg_1"); !errors.Is(err, errRunNotResumable) {
+		t.Fatalf("expected errRunNotResumable before processing starts, got %v", err)
+	}
+	server.processCtx = ctx
+	token := server.registerActiveRun("session-1", func() {})
+	if _, err := server.resumeCheckpointedRun(ctx, "session-1-tg_1"); !errors.Is(err, errRunActive) {
+		t.Fatalf("expected errRunActive, got %v", err)
+	}
+	server.finishActiveRun("session-1", token)
+
+	if _, err := server.resumeCheckpointedRun(ctx, "session-1-tg_1"); err != nil {
+		t.Fatalf("resumeCheckpointedRun() error = %v", err)
+	}
+	server.wg.Wait()
+
+	if len(adapter.messages) != 1 || adapter.messages[0].Content != "pong" {
+		t.Fatalf("expected the resumed reply, got %+v", adapter.messages)
+	}
+	for _, msg := range store.messages {
+		if msg.Role == models.RoleUser {
+			t.Fatalf("resumed run persisted the inbound message again: %+v", msg)
+		}
+	}
+	if _, err := server.runCheckpoints.Get(ctx, "session-1-tg_1"); !errors.Is(err, agent.ErrCheckpointNotFound) {
+		t.Fatalf("expected the finished run's checkpoint to be removed, got %v", err)
+	}
+}
@@ -0,0 +1,453 @@
+package gateway
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/haasonsaas/nexus/internal/config"
+	"github.com/haasonsaas/nexus/internal/messages"
+	"github.com/haasonsaas/nexus/internal/supervisor"
+	"github.com/haasonsaas/nexus/pkg/models"
+)
+
+const (
+	// metaResumeAttempt marks a synthetic message that resumes an interrupted
+	// run, and counts how many times the run has been resumed.
+	metaResumeAttempt = "run_resume_attempt"
+
+	runJournalVersion   = 1
+	runRecoveryFinalize = "finalize"
+)
+
+// journaledRun is the persisted state of an active run. The partial
+// transcript lives in the session store; the journal records what is needed
+// to find it again and route a reply.
+type journaledRun struct {
+	RunID     string          `json:"run_id"`
+	SessionID string          `json:"session_id"`
+	Message   *models.Message `json:"message"`
+	// PendingTools maps tool call IDs that had not finished to tool names.
+	PendingTools map[string]string `json:"pending_tools,omitempty"`
+	Attempt      int               `json:"attempt,omitempty"`
+	StartedAt    time.Time         `json:"started_at"`
+	UpdatedAt    time.Time         `json:"updated_at"`
+}
+
+// runJournalData is the persisted format.
+type runJournalData struct {
+	Version int                      `json:"version"`
+	Runs    map[string]*journaledRun `json:"runs"`
+}
+
+// runJournal persists active runs keyed by session ID so runs interrupted by
+// a restart can be found on the next startup. An empty path keeps the
+// journal in memory only.
+type runJournal struct {
+	mu   sync.Mutex
+	path string
+	runs map[string]*journaledRun
+}
+
+// defaultRunJournalPath returns ~/.nexus/active_runs.json.
+func defaultRunJournalPath() string {
+	home, err := os.UserHomeDir()
+	if err != nil || strings.TrimSpace(home) == "" {
+		home = "."
+	}
+	return filepath.Join(home, ".nexus", "active_runs.json")
+}
+
+// newRunJournal loads the journal at path. A missing file is treated as an
+// empty journal.
+func newRunJournal(path string) (*runJournal, error) {
+	j := &runJournal{path: path, runs: make(map[string]*journaledRun)}
+	if path == "" {
+		return j, nil
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return j, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read run journal: %w", err)
+	}
+	// Decode numbers as json.Number so integer IDs in reply metadata (such
+	// as Telegram chat IDs) come back as int64 rather than float64.
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var stored runJournalData
+	if err := decoder.Decode(&stored); err != nil {
+		return nil, fmt.Errorf("parse run journal: %w", err)
+	}
+	for sessionID, run := range stored.Runs {
+		if run == nil || run.Message == nil {
+			continue
+		}
+		restoreMetadataNumbers(run.Message.Metadata)
+		j.runs[sessionID] = run
+	}
+	return j, nil
+}
+
+// begin records a run that has started for run.SessionID, replacing any
+// earlier run for the same session.
+func (j *runJournal) begin(run *journaledRun) error {
+	if j == nil || run == nil || run.SessionID == "" {
+		return nil
+	}
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.runs[run.SessionID] = run
+	return j.saveLocked()
+}
+
+// toolStarted marks a tool call as in flight.
+func (j *runJournal) toolStarted(sessionID, callID, toolName string) error {
+	if j == nil || callID == "" {
+		return nil
+	}
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	run, ok := j.runs[sessionID]
+	if !ok {
+		return nil
+	}
+	if _, exists := run.PendingTools[callID]; exists {
+		return nil
+	}
+	if run.PendingTools == nil {
+		run.PendingTools = make(map[string]string)
+	}
+	run.PendingTools[callID] = toolName
+	run.UpdatedAt = time.Now()
+	return j.saveLocked()
+}
+
+// toolFinished clears an in-flight tool call.
+func (j *runJournal) toolFinished(sessionID, callID string) error {
+	if j == nil || callID == "" {
+		return nil
+	}
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	run, ok := j.runs[sessionID]
+	if !ok {
+		return nil
+	}
+	if _, exists := run.PendingTools[callID]; !exists {
+		return nil
+	}
+	delete(run.PendingTools, callID)
+	run.UpdatedAt = time.Now()
+	return j.saveLocked()
+}
+
+// finish removes the run for sessionID if runID is still the active one.
+func (j *runJournal) finish(sessionID, runID string) error {
+	if j == nil {
+		return nil
+	}
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	run, ok := j.runs[sessionID]
+	if !ok || run.RunID != runID {
+		return nil
+	}
+	delete(j.runs, sessionID)
+	return j.saveLocked()
+}
+
+// drain removes and returns all journaled runs, oldest first.
+func (j *runJournal) drain() ([]*journaledRun, error) {
+	if j == nil {
+		return nil, nil
+	}
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	runs := make([]*journaledRun, 0, len(j.runs))
+	for _, run := range j.runs {
+		runs = append(runs, run)
+	}
+	sort.Slice(runs, func(a, b int) bool {
+		return runs[a].StartedAt.Before(runs[b].StartedAt)
+	})
+	j.runs = make(map[string]*journaledRun)
+	return runs, j.saveLocked()
+}
+
+func (j *runJournal) saveLocked() error {
+	if j.path == "" {
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(j.path), 0700); err != nil {
+		return fmt.Errorf("create run journal dir: %w", err)
+	}
+	data, err := json.MarshalIndent(runJournalData{Version: runJournalVersion, Runs: j.runs}, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp := j.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return fmt.Errorf("write run journal: %w", err)
+	}
+	return os.Rename(tmp, j.path)
+}
+
+// restoreMetadataNumbers converts json.Number metadata values back to int64
+// or float64.
+func restoreMetadataNumbers(metadata map[string]any) {
+	for key, value := range metadata {
+		num, ok := value.(json.Number)
+		if !ok {
+			continue
+		}
+		if i, err := num.Int64(); err == nil {
+			metadata[key] = i
+		} else if f, err := num.Float64(); err == nil {
+			metadata[key] = f
+		}
+	}
+}
+
+// setupRunJournal creates the active run journal, or returns nil when run
+// recovery is disabled.
+func setupRunJournal(cfg config.RunRecoveryConfig) (*runJournal, error) {
+	if !cfg.Enabled {
+		return nil, nil
+	}
+	path := strings.TrimSpace(cfg.StorePath)
+	if path == "" {
+		path = defaultRunJournalPath()
+	}
+	return newRunJournal(path)
+}
+
+// journalRunStart records a run before it is handed to the runtime.
+func (s *Server) journalRunStart(session *models.Session, msg *models.Message, runID string) {
+	if s.runJournal == nil || session == nil || msg == nil {
+		return
+	}
+	now := time.Now()
+	snapshot := *msg
+	snapshot.Attachments = nil
+	if msg.Metadata != nil {
+		snapshot.Metadata = make(map[string]any, len(msg.Metadata))
+		for key, value := range msg.Metadata {
+			snapshot.Metadata[key] = value
+		}
+	}
+	if err := s.runJournal.begin(&journaledRun{
+		RunID:     runID,
+		SessionID: session.ID,
+		Message:   &snapshot,
+		Attempt:   resumeAttempt(msg),
+		StartedAt: now,
+		UpdatedAt: now,
+	}); err != nil {
+		s.logger.Warn("failed to journal run", "run_id", runID, "error", err)
+	}
+}
+
+// journalRunChunk keeps the journaled set of in-flight tool calls current.
+func (s *Server) journalRunChunk(sessionID string, event *models.ToolEvent, result *models.ToolResult) {
+	if s.runJournal == nil {
+		return
+	}
+	var err error
+	switch {
+	case event != nil && (event.Stage == models.ToolEventRequested || event.Stage == models.ToolEventStarted || event.Stage == models.ToolEventRetrying):
+		err = s.runJournal.toolStarted(sessionID, event.ToolCallID, event.ToolName)
+	case event != nil:
+		err = s.runJournal.toolFinished(sessionID, event.ToolCallID)
+	case result != nil:
+		err = s.runJournal.toolFinished(sessionID, result.ToolCallID)
+	}
+	if err != nil {
+		s.logger.Debug("failed to update run journal", "session_id", sessionID, "error", err)
+	}
+}
+
+// journalRunFinish removes a run from the journal. Runs cut short because
+// the gateway is shutting down stay journaled so they are recovered on the
+// next startup.
+func (s *Server) journalRunFinish(ctx context.Context, sessionID, runID string) {
+	if s.runJournal == nil || ctx.Err() != nil {
+		return
+	}
+	if err := s.runJournal.finish(sessionID, runID); err != nil {
+		s.logger.Warn("failed to clear journaled run", "run_id", runID, "error", err)
+	}
+}
+
+// recoverInterruptedRuns handles runs left in the journal by a previous
+// process. Each session is told about the restart, then the run is either
+// resumed or finalized.
+func (s *Server) recoverInterruptedRuns(ctx context.Context) {
+	defer s.wg.Done()
+	if s.runJournal == nil {
+		return
+	}
+	runs, err := s.runJournal.drain()
+	if err != nil {
+		s.logger.Warn("failed to clear run journal", "error", err)
+	}
+	for _, run := range runs {
+		if ctx.Err() != nil {
+			return
+		}
+		s.recoverRun(ctx, run)
+	}
+}
+
+func (s *Server) recoverRun(ctx context.Context, run *journaledRun) {
+	if run == nil || run.Message == nil {
+		return
+	}
+	if _, ok := s.channels.GetOutbound(run.Message.Channel); !ok {
+		s.logger.Warn("dropping interrupted run with no outbound adapter",
+			"run_id", run.RunID,
+			"channel", run.Message.Channel)
+		return
+	}
+	session, err := s.sessions.Get(ctx, run.SessionID)
+	if err != nil || session == nil {
+		s.logger.Warn("dropping interrupted run for unknown session",
+			"run_id", run.RunID,
+			"session_id", run.SessionID,
+			"error", err)
+		return
+	}
+
+	if !s.shouldResumeRun(run) {
+		s.logger.Info("finalizing interrupted run",
+			"run_id", run.RunID,
+			"session_id", run.SessionID,
+			"attempt", run.Attempt)
+		notice := s.systemMessage(ctx, run.Message, messages.RunInterrupted, nil)
+		s.sendImmediateReply(ctx, session, run.Message, notice)
+		if err := s.sessions.AppendMessage(ctx, session.ID, &models.Message{
+			SessionID: session.ID,
+			Channel:   run.Message.Channel,
+			Direction: models.DirectionOutbound,
+			Role:      models.RoleAssistant,
+			Content:   notice,
+			CreatedAt: time.Now(),
+		}); err != nil {
+			s.logger.Debug("failed to persist run finalization", "run_id", run.RunID, "error", err)
+		}
+		return
+	}
+
+	s.logger.Info("resuming interrupted run",
+		"run_id", run.RunID,
+		"session_id", run.SessionID,
+		"attempt", run.Attempt+1,
+		"pending_tools", len(run.PendingTools))
+	s.sendImmediateReply(ctx, session, run.Message, s.systemMessage(ctx, run.Message, messages.RunResuming, nil))
+
+	select {
+	case s.messageSem <- struct{}{}:
+	case <-ctx.Done():
+		return
+	}
+	s.wg.Add(1)
+	go func() {
+		defer func() {
+			<-s.messageSem
+			s.wg.Done()
+		}()
+		defer supervisor.Recover("gateway:message:" + string(run.Message.Channel))
+		s.handleMessage(ctx, buildResumeMessage(run))
+	}()
+}
+
+// shouldResumeRun reports whether an interrupted run should be continued
+// rather than finalized.
+func (s *Server) shouldResumeRun(run *journaledRun) bool {
+	if s.config == nil {
+		return false
+	}
+	cfg := s.config.Session.RunRecovery
+	if strings.EqualFold(strings.TrimSpace(cfg.Mode), runRecoveryFinalize) {
+		return false
+	}
+	if cfg.MaxAttempts > 0 && run.Attempt >= cfg.MaxAttempts {
+		return false
+	}
+	lastActive := run.UpdatedAt
+	if lastActive.IsZero() {
+		lastActive = run.StartedAt
+	}
+	return cfg.MaxAge <= 0 || time.Since(lastActive) <= cfg.MaxAge
+}
+
+// buildResumeMessage creates the synthetic inbound message that continues an
+// interrupted run in the same conversation. The partial transcript is already
+// in the session history, so the message only explains what happened.
+func buildResumeMessage(run *journaledRun) *models.Message {
+	orig := run.Message
+	attempt := run.Attempt + 1
+	metadata := make(map[string]any, len(orig.Metadata)+1)
+	for key, value := range orig.Metadata {
+		metadata[key] = value
+	}
+	metadata[metaResumeAttempt] = attempt
+
+	id := fmt.Sprintf("%s-resume-%d", orig.ID, attempt)
+	if orig.ID == "" {
+		id = fmt.Sprintf("%s-resume-%d", run.RunID, attempt)
+	}
+	return &models.Message{
+		ID:        id,
+		Channel:   orig.Channel,
+		ChannelID: orig.ChannelID,
+		Direction: models.DirectionInbound,
+		Role:      models.RoleUser,
+		Content:   resumePrompt(run),
+		Metadata:  metadata,
+		CreatedAt: time.Now(),
+	}
+}
+
+func resumePrompt(run *journaledRun) string {
+	var b strings.Builder
+	b.WriteString("[The gateway restarted while you were working on the previous message. Continue where you left off and finish the task.")
+	if len(run.PendingTools) > 0 {
+		calls := make([]string, 0, len(run.PendingTools))
+		for id, name := range run.PendingTools {
+			calls = append(calls, fmt.Sprintf("%s (%s)", name, id))
+		}
+		sort.Strings(calls)
+		b.WriteString(" These tool calls were interrupted and may not have completed: ")
+		b.WriteString(strings.Join(calls, ", "))
+		b.WriteString(". Check their effects before retrying them.")
+	}
+	b.WriteString("]")
+	return b.String()
+}
+
+// resumeAttempt returns how many times the run carried by msg has been
+// resumed.
+func resumeAttempt(msg *models.Message) int {
+	if msg == nil || msg.Metadata == nil {
+		return 0
+	}
+	switch v := msg.Metadata[metaResumeAttempt].(type) {
+	case int:
+		return v
+	case int64:
+		return int(v)
+	case float64:
+		return int(v)
+	}
+	return 0
+}
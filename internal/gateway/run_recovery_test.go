@@ -0,0 +1,222 @@
+package gateway
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/haasonsaas/nexus/internal/agent"
+	"github.com/haasonsaas/nexus/internal/channels"
+	"github.com/haasonsaas/nexus/internal/config"
+	"github.com/haasonsaas/nexus/internal/messages"
+	"github.com/haasonsaas/nexus/pkg/models"
+)
+
+// sessionLookupStore is a recordingStore that can also look sessions up by ID.
+type sessionLookupStore struct {
+	*recordingStore
+}
+
+func (s sessionLookupStore) Get(ctx context.Context, id string) (*models.Session, error) {
+	if s.session != nil && s.session.ID == id {
+		return s.session, nil
+	}
+	return nil, nil
+}
+
+func TestRunJournalPersistsPendingTools(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "active_runs.json")
+	journal, err := newRunJournal(path)
+	if err != nil {
+		t.Fatalf("newRunJournal() error = %v", err)
+	}
+	msg := &models.Message{ID: "m1", Channel: models.ChannelTelegram, Metadata: map[string]any{"chat_id": int64(123)}}
+	if err := journal.begin(&journaledRun{RunID: "s1-m1", SessionID: "s1", Message: msg, StartedAt: time.Now()}); err != nil {
+		t.Fatalf("begin() error = %v", err)
+	}
+	if err := journal.toolStarted("s1", "call-1", "exec"); err != nil {
+		t.Fatalf("toolStarted() error = %v", err)
+	}
+	if err := journal.toolStarted("s1", "call-2", "web_fetch"); err != nil {
+		t.Fatalf("toolStarted() error = %v", err)
+	}
+	if err := journal.toolFinished("s1", "call-2"); err != nil {
+		t.Fatalf("toolFinished() error = %v", err)
+	}
+
+	reloaded, err := newRunJournal(path)
+	if err != nil {
+		t.Fatalf("reload error = %v", err)
+	}
+	runs, err := reloaded.drain()
+	if err != nil {
+		t.Fatalf("drain() error = %v", err)
+	}
+	if len(runs) != 1 {
+		t.Fatalf("expected 1 journaled run, got %d", len(runs))
+	}
+	run := runs[0]
+	if run.RunID != "s1-m1" || len(run.PendingTools) != 1 || run.PendingTools["call-1"] != "exec" {
+		t.Fatalf("unexpected run: %+v", run)
+	}
+	if chatID, ok := run.Message.Metadata["chat_id"].(int64); !ok || chatID != 123 {
+		t.Fatalf("expected chat_id to reload as int64, got %#v", run.Message.Metadata["chat_id"])
+	}
+
+	emptied, err := newRunJournal(path)
+	if err != nil {
+		t.Fatalf("reload error = %v", err)
+	}
+	if runs, _ := emptied.drain(); len(runs) != 0 {
+		t.Fatalf("expected drain to clear the journal, got %d runs", len(runs))
+	}
+}
+
+func TestRunJournalFinishIgnoresReplacedRun(t *testing.T) {
+	journal, _ := newRunJournal("")
+	_ = journal.begin(&journaledRun{RunID: "s1-m1", SessionID: "s1", Message: &models.Message{}})
+	_ = journal.begin(&journaledRun{RunID: "s1-m2", SessionID: "s1", Message: &models.Message{}})
+	_ = journal.finish("s1", "s1-m1")
+
+	runs, _ := journal.drain()
+	if len(runs) != 1 || runs[0].RunID != "s1-m2" {
+		t.Fatalf("expected the newer run to remain, got %+v", runs)
+	}
+}
+
+func newRunRecoveryServer(t *testing.T, recovery config.RunRecoveryConfig) (*Server, *recordingAdapter, *recordingStore) {
+	t.Helper()
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	recovery.Enabled = true
+	recovery.StorePath = filepath.Join(t.TempDir(), "active_runs.json")
+	cfg := &config.Config{
+		Session: config.SessionConfig{DefaultAgentID: "agent-test", RunRecovery: recovery},
+	}
+	server, err := NewServer(cfg, logger)
+	if err != nil {
+		t.Fatalf("NewServer() error = %v", err)
+	}
+	if server.runJournal == nil {
+		t.Fatal("expected run journal when run recovery is enabled")
+	}
+
+	store := &recordingStore{}
+	server.sessions = sessionLookupStore{store}
+	server.runtime = agent.NewRuntime(fixedProvider{}, store)
+	adapter := &recordingAdapter{}
+	registry := channels.NewRegistry()
+	registry.Register(adapter)
+	server.channels = registry
+	return server, adapter, store
+}
+
+func TestHandleMessageClearsJournalOnCompletion(t *testing.T) {
+	server, adapter, _ := newRunRecoveryServer(t, config.RunRecoveryConfig{})
+
+	server.handleMessage(context.Background(), &models.Message{
+		ID:        "tg_1",
+		Channel:   models.ChannelTelegram,
+		Direction: models.DirectionInbound,
+		Role:      models.RoleUser,
+		Content:   "ping",
+		Metadata:  map[string]any{"chat_id": int64(123)},
+	})
+	if len(adapter.messages) != 1 {
+		t.Fatalf("expected 1 outbound message, got %d", len(adapter.messages))
+	}
+	if runs, _ := server.runJournal.drain(); len(runs) != 0 {
+		t.Fatalf("expected completed run to leave the journal, got %+v", runs)
+	}
+}
+
+func TestRecoverInterruptedRunsResumes(t *testing.T) {
+	server, adapter, store := newRunRecoveryServer(t, config.RunRecoveryConfig{MaxAge: time.Hour, MaxAttempts: 1})
+	store.session = &models.Session{ID: "session-1", AgentID: "agent-test", Channel: models.ChannelTelegram}
+	_ = server.runJournal.begin(&journaledRun{
+		RunID:        "session-1-tg_1",
+		SessionID:    "session-1",
+		Message:      &models.Message{ID: "tg_1", Channel: models.ChannelTelegram, Content: "build it", Metadata: map[string]any{"chat_id": int64(123)}},
+		PendingTools: map[string]string{"call-1": "exec"},
+		StartedAt:    time.Now(),
+		UpdatedAt:    time.Now(),
+	})
+
+	server.wg.Add(1)
+	server.recoverInterruptedRuns(context.Background())
+	server.wg.Wait()
+
+	if len(adapter.messages) != 2 {
+		t.Fatalf("expected resume notice and reply, got %d messages", len(adapter.messages))
+	}
+	if adapter.messages[0].Content != messages.Default().Render(messages.RunResuming, "", nil) {
+		t.Fatalf("expected resume notice first, got %q", adapter.messages[0].Content)
+	}
+	if adapter.messages[1].Content != "pong" {
+		t.Fatalf("expected resumed reply, got %q", adapter.messages[1].Content)
+	}
+	var resume *models.Message
+	for _, msg := range store.messages {
+		if msg.Role == models.RoleUser {
+			resume = msg
+		}
+	}
+	if resume == nil || !strings.Contains(resume.Content, "exec (call-1)") {
+		t.Fatalf("expected resume prompt naming the interrupted tool call, got %+v", resume)
+	}
+	if resumeAttempt(resume) != 1 {
+		t.Fatalf("expected resume attempt 1, got %d", resumeAttempt(resume))
+	}
+	if runs, _ := server.runJournal.drain(); len(runs) != 0 {
+		t.Fatalf("expected resumed run to leave the journal, got %+v", runs)
+	}
+}
+
+func TestRecoverInterruptedRunsFinalizes(t *testing.T) {
+	tests := []struct {
+		name     string
+		recovery config.RunRecoveryConfig
+		run      journaledRun
+	}{
+		{
+			name:     "finalize mode",
+			recovery: config.RunRecoveryConfig{Mode: "finalize"},
+			run:      journaledRun{UpdatedAt: time.Now()},
+		},
+		{
+			name:     "too old",
+			recovery: config.RunRecoveryConfig{MaxAge: time.Minute},
+			run:      journaledRun{UpdatedAt: time.Now().Add(-time.Hour)},
+		},
+		{
+			name:     "attempts exhausted",
+			recovery: config.RunRecoveryConfig{MaxAge: time.Hour, MaxAttempts: 1},
+			run:      journaledRun{Attempt: 1, UpdatedAt: time.Now()},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			server, adapter, store := newRunRecoveryServer(t, tc.recovery)
+			store.session = &models.Session{ID: "session-1", AgentID: "agent-test", Channel: models.ChannelTelegram}
+			run := tc.run
+			run.RunID = "session-1-tg_1"
+			run.SessionID = "session-1"
+			run.Message = &models.Message{ID: "tg_1", Channel: models.ChannelTelegram, Metadata: map[string]any{"chat_id": int64(123)}}
+			_ = server.runJournal.begin(&run)
+
+			server.wg.Add(1)
+			server.recoverInterruptedRuns(context.Background())
+			server.wg.Wait()
+
+			if len(adapter.messages) != 1 || adapter.messages[0].Content != messages.Default().Render(messages.RunInterrupted, "", nil) {
+				t.Fatalf("expected only the finalization notice, got %d messages", len(adapter.messages))
+			}
+			if len(store.messages) != 1 || store.messages[0].Content != messages.Default().Render(messages.RunInterrupted, "", nil) {
+				t.Fatalf("expected finalization to be persisted to the session, got %+v", store.messages)
+			}
+		})
+	}
+}
@@ -14,12 +14,16 @@ import (
 	"github.com/haasonsaas/nexus/internal/agent"
 	"github.com/haasonsaas/nexus/internal/agent/providers"
 	"github.com/haasonsaas/nexus/internal/agent/routing"
+	"github.com/haasonsaas/nexus/internal/anomaly"
+	"github.com/haasonsaas/nexus/internal/artifacts"
 	"github.com/haasonsaas/nexus/internal/attention"
 	"github.com/haasonsaas/nexus/internal/config"
 	"github.com/haasonsaas/nexus/internal/edge"
 	"github.com/haasonsaas/nexus/internal/mcp"
+	"github.com/haasonsaas/nexus/internal/observability"
 	"github.com/haasonsaas/nexus/internal/sessions"
 	"github.com/haasonsaas/nexus/internal/skills"
+	broadcasttools "github.com/haasonsaas/nexus/internal/tools/broadcast"
 	"github.com/haasonsaas/nexus/internal/tools/browser"
 	canvastools "github.com/haasonsaas/nexus/internal/tools/canvas"
 	"github.com/haasonsaas/nexus/internal/tools/computeruse"
@@ -34,14 +38,20 @@ import (
 	"github.com/haasonsaas/nexus/internal/tools/message"
 	modelstools "github.com/haasonsaas/nexus/internal/tools/models"
 	nodestools "github.com/haasonsaas/nexus/internal/tools/nodes"
+	ocrtools "github.com/haasonsaas/nexus/internal/tools/ocr"
+	plantools "github.com/haasonsaas/nexus/internal/tools/plan"
 	ragtools "github.com/haasonsaas/nexus/internal/tools/rag"
 	"github.com/haasonsaas/nexus/internal/tools/reminders"
 	"github.com/haasonsaas/nexus/internal/tools/sandbox"
 	"github.com/haasonsaas/nexus/internal/tools/sandbox/firecracker"
 	"github.com/haasonsaas/nexus/internal/tools/servicenow"
 	sessiontools "github.com/haasonsaas/nexus/internal/tools/sessions"
+	"github.com/haasonsaas/nexus/internal/tools/spreadsheet"
+	"github.com/haasonsaas/nexus/internal/tools/sqlquery"
 	"github.com/haasonsaas/nexus/internal/tools/vectormemory"
+	watchtools "github.com/haasonsaas/nexus/internal/tools/watch"
 	"github.com/haasonsaas/nexus/internal/tools/websearch"
+	"github.com/haasonsaas/nexus/pkg/models"
 )
 
 // ensureRuntime initializes the agent runtime if not already created.
@@ -62,9 +72,16 @@ func (s *Server) ensureRuntime(ctx context.Context) (*agent.Runtime, error) {
 	}
 	s.ensureSessionLocker()
 	if s.branchStore == nil {
-		if cr, ok := s.sessions.(*sessions.CockroachStore); ok {
-			s.branchStore = sessions.NewCockroachBranchStore(cr.DB())
-		} else {
+		switch store := s.sessions.(type) {
+		case *sessions.CockroachStore:
+			branchStore := sessions.NewCockroachBranchStore(store.DB())
+			branchStore.SetKeyring(s.keyring)
+			s.branchStore = branchStore
+		case *sessions.SQLiteStore:
+			branchStore := sessions.NewSQLiteBranchStore(store.DB())
+			branchStore.SetKeyring(s.keyring)
+			s.branchStore = branchStore
+		default:
 			s.branchStore = sessions.NewMemoryBranchStore()
 		}
 	}
@@ -72,9 +89,13 @@ func (s *Server) ensureRuntime(ctx context.Context) (*agent.Runtime, error) {
 		s.memoryLogger = sessions.NewMemoryLogger(s.config.Session.Memory.Directory)
 	}
 
-	provider, defaultModel, err := s.newProvider()
-	if err != nil {
-		return nil, fmt.Errorf("create LLM provider: %w", err)
+	provider, defaultModel := s.providerOverride, s.providerOverrideModel
+	if provider == nil {
+		var err error
+		provider, defaultModel, err = s.newProvider()
+		if err != nil {
+			return nil, fmt.Errorf("create LLM provider: %w", err)
+		}
 	}
 	if s.llmProvider == nil {
 		s.llmProvider = provider
@@ -109,6 +130,7 @@ func (s *Server) ensureRuntime(ctx context.Context) (*agent.Runtime, error) {
 			return nil, fmt.Errorf("load runtime plugins: %w", err)
 		}
 	}
+	s.holdDraftTools(runtime)
 
 	if traceDir := strings.TrimSpace(os.Getenv("NEXUS_TRACE_DIR")); traceDir != "" {
 		tracePlugin, err := agent.NewTraceDirectoryPlugin(traceDir)
@@ -130,26 +152,52 @@ func (s *Server) ensureRuntime(ctx context.Context) (*agent.Runtime, error) {
 	if plugin := s.GetTracingPlugin(); plugin != nil {
 		runtime.Use(plugin)
 	}
+	// Publish run and tool events to the message queue
+	if s.eventBridge != nil {
+		runtime.Use(s.eventBridgePlugin())
+	}
+	// Record outcomes of runs exposed to experiments
+	if s.experimentRecorder != nil {
+		runtime.Use(s.experimentRecorder)
+	}
+	// Count token usage, with reasoning tokens reported separately, and feed
+	// tokens and errors to the anomaly detector
+	usageMetrics := observability.NewLLMUsageMetrics()
+	runtime.Use(agent.PluginFunc(func(_ context.Context, e models.AgentEvent) {
+		switch {
+		case e.Type == models.AgentEventModelCompleted && e.Stream != nil:
+			usageMetrics.Record(e.Stream.Provider, e.Stream.Model, e.Stream.InputTokens, e.Stream.OutputTokens, e.Stream.ReasoningTokens)
+			s.anomalies.Add(anomaly.SignalTokens, "", float64(e.Stream.InputTokens+e.Stream.OutputTokens))
+		case e.Type == models.AgentEventRunError:
+			s.anomalies.Add(anomaly.SignalErrors, "", 1)
+		case e.Type == models.AgentEventToolFinished && e.Tool != nil && !e.Tool.Success:
+			s.anomalies.Add(anomaly.SignalErrors, "", 1)
+		}
+	}))
 
 	if s.approvalChecker == nil {
 		basePolicy := buildApprovalPolicy(s.config.Tools.Execution, s.toolPolicyResolver)
-		checker := agent.NewApprovalChecker(basePolicy)
-		checker.SetStore(agent.NewMemoryApprovalStore())
+		requireEmailComposeApproval(basePolicy, s.config)
+		checker := s.newApprovalChecker(basePolicy)
 		s.approvalChecker = checker
 	}
 	elevatedTools := effectiveElevatedTools(s.config.Tools.Elevated, nil)
 	runtime.SetOptions(agent.RuntimeOptions{
-		MaxIterations:     s.config.Tools.Execution.MaxIterations,
-		ToolParallelism:   s.config.Tools.Execution.Parallelism,
-		ToolTimeout:       s.config.Tools.Execution.Timeout,
-		ToolMaxAttempts:   s.config.Tools.Execution.MaxAttempts,
-		ToolRetryBackoff:  s.config.Tools.Execution.RetryBackoff,
-		DisableToolEvents: s.config.Tools.Execution.DisableEvents,
-		MaxToolCalls:      s.config.Tools.Execution.MaxToolCalls,
-		RequireApproval:   s.config.Tools.Execution.RequireApproval,
-		ApprovalChecker:   s.approvalChecker,
-		ElevatedTools:     elevatedTools,
-		AsyncTools:        s.config.Tools.Execution.Async,
+		MaxIterations:       s.config.Tools.Execution.MaxIterations,
+		ToolParallelism:     s.config.Tools.Execution.Parallelism,
+		ToolTimeout:         s.config.Tools.Execution.Timeout,
+		ToolMaxAttempts:     s.config.Tools.Execution.MaxAttempts,
+		ToolRetryBackoff:    s.config.Tools.Execution.RetryBackoff,
+		ToolMaxRetryBackoff: s.config.Tools.Execution.Retry.MaxBackoff,
+		ToolRetryJitter:     s.config.Tools.Execution.Retry.Jitter,
+		ToolRetryOn:         toolRetryClasses(s.config.Tools.Execution.Retry.RetryOn),
+		IdempotentTools:     s.config.Tools.Execution.Retry.Idempotent,
+		DisableToolEvents:   s.config.Tools.Execution.DisableEvents,
+		MaxToolCalls:        s.config.Tools.Execution.MaxToolCalls,
+		RequireApproval:     s.config.Tools.Execution.RequireApproval,
+		ApprovalChecker:     s.approvalChecker,
+		ElevatedTools:       elevatedTools,
+		AsyncTools:          s.config.Tools.Execution.Async,
 		ToolResultGuard: agent.ToolResultGuard{
 			Enabled:         s.config.Tools.Execution.ResultGuard.Enabled,
 			MaxChars:        s.config.Tools.Execution.ResultGuard.MaxChars,
@@ -158,9 +206,26 @@ func (s *Server) ensureRuntime(ctx context.Context) (*agent.Runtime, error) {
 			RedactionText:   s.config.Tools.Execution.ResultGuard.RedactionText,
 			TruncateSuffix:  s.config.Tools.Execution.ResultGuard.TruncateSuffix,
 			SanitizeSecrets: s.config.Tools.Execution.ResultGuard.SanitizeSecrets,
+			Artifacts:       s.artifactRepo,
+		},
+		ToolSchemaValidation: agent.ToolSchemaValidation{
+			Enabled:        s.config.Tools.Execution.SchemaValidation.Enabled,
+			RepairAttempts: s.config.Tools.Execution.SchemaValidation.RepairAttempts,
+			Strict:         s.config.Tools.Execution.SchemaValidation.Strict,
+		},
+		EarlyToolStart: agent.EarlyToolStart{
+			Enabled: s.config.Tools.Execution.EarlyStart.Enabled,
+			Tools:   s.config.Tools.Execution.EarlyStart.Tools,
+		},
+		LoopDetection: agent.LoopDetection{
+			Enabled:              boolValue(s.config.Tools.Execution.LoopDetection.Enabled, true),
+			MaxRepeats:           s.config.Tools.Execution.LoopDetection.MaxRepeats,
+			MaxStalledIterations: s.config.Tools.Execution.LoopDetection.MaxStalledIterations,
 		},
-		JobStore: s.jobStore,
-		Logger:   s.logger,
+		JobStore:       s.jobStore,
+		Checkpoints:    s.checkpointOptions(),
+		TraceReasoning: !boolValue(s.config.LLM.Reasoning.Redact, true),
+		Logger:         s.logger,
 	})
 	if pruning := config.EffectiveContextPruningSettings(s.config.Session.ContextPruning); pruning != nil {
 		runtime.SetContextPruning(pruning)
@@ -233,7 +298,14 @@ func (s *Server) newSessionStore() (sessions.Store, error) {
 		poolCfg.ConnMaxLifetime = s.config.Database.ConnMaxLifetime
 	}
 
-	return sessions.NewCockroachStoreFromDSN(s.config.Database.URL, poolCfg)
+	store, err := sessions.NewStoreFromURL(s.config.Database.URL, poolCfg)
+	if err != nil {
+		return nil, err
+	}
+	if encrypted, ok := store.(sessions.EncryptedStore); ok {
+		encrypted.SetKeyring(s.keyring)
+	}
+	return store, nil
 }
 
 // newProvider creates a new LLM provider based on configuration.
@@ -340,8 +412,13 @@ func (s *Server) newProvider() (agent.LLMProvider, string, error) {
 			rules = append(rules, routing.Rule{
 				Name: rule.Name,
 				Match: routing.Match{
-					Patterns: rule.Match.Patterns,
-					Tags:     rule.Match.Tags,
+					Patterns:   rule.Match.Patterns,
+					Tags:       rule.Match.Tags,
+					MinTokens:  rule.Match.MinTokens,
+					MaxTokens:  rule.Match.MaxTokens,
+					Vision:     rule.Match.Vision,
+					Tools:      rule.Match.Tools,
+					MaxCostUSD: rule.Match.MaxCostUSD,
 				},
 				Target: routing.Target{
 					Provider: rule.Target.Provider,
@@ -356,15 +433,29 @@ func (s *Server) newProvider() (agent.LLMProvider, string, error) {
 			PreferLocal:     preferLocal,
 			LocalProviders:  localProviders,
 			Rules:           rules,
+			Classifier:      s.routingClassifier(providerID, providerMap),
 			Fallback: routing.Target{
 				Provider: s.config.LLM.Routing.Fallback.Provider,
 				Model:    s.config.LLM.Routing.Fallback.Model,
 			},
 			FailureCooldown: s.config.LLM.Routing.UnhealthyCooldown,
+			Catalog:         s.modelCatalog,
 		}, providerMap)
 		selected = router
 	}
 
+	if s.config.LLM.Race.Enabled {
+		racer, err := s.newRacer(selected, providerMap)
+		if err != nil {
+			if s.logger != nil {
+				s.logger.Warn("provider racing disabled", "error", err)
+			}
+		} else {
+			selected = racer
+		}
+	}
+
+	s.discoverProviderModels(providerMap)
 	return selected, model, nil
 }
 
@@ -383,7 +474,22 @@ func (s *Server) buildProvider(providerID string) (agent.LLMProvider, string, er
 	if err != nil {
 		return nil, "", fmt.Errorf("provider %q: %w", providerID, err)
 	}
+	var provider agent.LLMProvider
+	var model string
+	if keyedProvider(providerKey) && (effectiveCfg.APIKey != "" || len(effectiveCfg.Keys) > 0) {
+		provider, model, err = s.buildKeyPool(providerID, providerKey, effectiveCfg)
+	} else {
+		provider, model, err = s.buildProviderWithConfig(providerKey, effectiveCfg)
+	}
+	if err != nil {
+		return nil, "", err
+	}
+	return s.limitProvider(providerKey, effectiveCfg.RateLimit, provider), model, nil
+}
 
+// buildProviderWithConfig creates the provider for providerKey from its
+// resolved config, using effectiveCfg.APIKey as the only key.
+func (s *Server) buildProviderWithConfig(providerKey string, effectiveCfg config.LLMProviderConfig) (agent.LLMProvider, string, error) {
 	switch providerKey {
 	case "anthropic":
 		if effectiveCfg.APIKey == "" {
@@ -555,6 +661,15 @@ func (s *Server) registerTools(ctx context.Context, runtime *agent.Runtime) erro
 				if s.config.Tools.Sandbox.Snapshots.MaxAge > 0 {
 					fcConfig.SnapshotMaxAge = s.config.Tools.Sandbox.Snapshots.MaxAge
 				}
+				if dir := strings.TrimSpace(s.config.Tools.Sandbox.Snapshots.Dir); dir != "" {
+					fcConfig.SnapshotDir = dir
+				}
+				fcConfig.SnapshotJitter = s.config.Tools.Sandbox.Snapshots.Jitter
+			}
+			for lang, langCfg := range s.config.Tools.Sandbox.Languages {
+				if rootfs := strings.TrimSpace(langCfg.RootFS); rootfs != "" {
+					fcConfig.RootFSImages[lang] = rootfs
+				}
 			}
 			fcBackend, err := firecracker.NewBackend(fcConfig)
 			if err != nil {
@@ -571,6 +686,25 @@ func (s *Server) registerTools(ctx context.Context, runtime *agent.Runtime) erro
 			return fmt.Errorf("unsupported sandbox backend %q", backend)
 		}
 
+		if wasmCfg := s.config.Tools.Sandbox.Wasm; wasmCfg.Enabled {
+			memMB, err := parseMemoryMB(wasmCfg.MaxMemory)
+			if err != nil {
+				return fmt.Errorf("tools.sandbox.wasm.max_memory: %w", err)
+			}
+			opts = append(opts, sandbox.WithWasm(sandbox.WasmConfig{
+				PythonModule:     strings.TrimSpace(wasmCfg.PythonModule),
+				PythonHome:       strings.TrimSpace(wasmCfg.PythonHome),
+				JavaScriptModule: strings.TrimSpace(wasmCfg.JavaScriptModule),
+				MaxCodeBytes:     wasmCfg.MaxCodeBytes,
+				MemoryMB:         memMB,
+				Timeout:          wasmCfg.Timeout,
+			}))
+		}
+		for lang, langCfg := range s.config.Tools.Sandbox.Languages {
+			if image := langCfg.ImageRef(); image != "" {
+				opts = append(opts, sandbox.WithLanguageImage(lang, image))
+			}
+		}
 		if s.config.Tools.Sandbox.PoolSize > 0 {
 			opts = append(opts, sandbox.WithPoolSize(s.config.Tools.Sandbox.PoolSize))
 		}
@@ -600,7 +734,10 @@ func (s *Server) registerTools(ctx context.Context, runtime *agent.Runtime) erro
 		}
 	}
 
-	fileCfg := files.Config{Workspace: s.config.Workspace.Path}
+	fileCfg := files.Config{Workspace: s.config.Workspace.Path, History: newWorkspaceHistory(s.config)}
+	if s.memoryReview != nil {
+		fileCfg.Review = s.memoryReview
+	}
 	runtime.RegisterTool(files.NewReadTool(fileCfg))
 	runtime.RegisterTool(files.NewWriteTool(fileCfg))
 	runtime.RegisterTool(files.NewEditTool(fileCfg))
@@ -621,6 +758,9 @@ func (s *Server) registerTools(ctx context.Context, runtime *agent.Runtime) erro
 		runtime.RegisterTool(message.NewTool("message", s.channels, s.sessions, s.config.Session.DefaultAgentID))
 		runtime.RegisterTool(message.NewTool("send_message", s.channels, s.sessions, s.config.Session.DefaultAgentID))
 	}
+	if broadcaster := s.outboundBroadcaster(); broadcaster != nil {
+		runtime.RegisterTool(broadcasttools.NewTool(broadcaster))
+	}
 	if s.cronScheduler != nil {
 		runtime.RegisterTool(crontools.NewTool(s.cronScheduler))
 	}
@@ -676,7 +816,11 @@ func (s *Server) registerTools(ctx context.Context, runtime *agent.Runtime) erro
 		fetchConfig := &websearch.FetchConfig{
 			MaxChars: s.config.Tools.WebFetch.MaxChars,
 		}
-		runtime.RegisterTool(websearch.NewWebFetchTool(fetchConfig))
+		var fetchOpts []websearch.WebFetchOption
+		if s.browserPool != nil {
+			fetchOpts = append(fetchOpts, websearch.WithRenderer(s.browserPool))
+		}
+		runtime.RegisterTool(websearch.NewWebFetchTool(fetchConfig, fetchOpts...))
 	}
 
 	if s.config.Tools.MemorySearch.Enabled {
@@ -702,7 +846,12 @@ func (s *Server) registerTools(ctx context.Context, runtime *agent.Runtime) erro
 	}
 	if s.vectorMemory != nil {
 		runtime.RegisterTool(vectormemory.NewSearchTool(s.vectorMemory, &s.config.VectorMemory))
-		runtime.RegisterTool(vectormemory.NewWriteTool(s.vectorMemory, &s.config.VectorMemory))
+		writeTool := vectormemory.NewWriteTool(s.vectorMemory, &s.config.VectorMemory)
+		if s.memoryReview != nil {
+			writeTool.WithReview(s.memoryReview)
+		}
+		runtime.RegisterTool(writeTool)
+		runtime.RegisterTool(vectormemory.NewForgetTool(s.vectorMemory, &s.config.VectorMemory))
 	}
 
 	if s.config.RAG.Enabled && s.ragIndex != nil {
@@ -724,6 +873,14 @@ func (s *Server) registerTools(ctx context.Context, runtime *agent.Runtime) erro
 		runtime.RegisterTool(facts.NewExtractTool(s.config.Tools.FactExtract.MaxFacts))
 	}
 
+	if s.config.Tools.Plan.Enabled {
+		runtime.RegisterTool(plantools.NewTool())
+	}
+
+	if s.textExtractor != nil && s.artifactRepo != nil {
+		runtime.RegisterTool(ocrtools.NewImageTool(s.artifactRepo, s.textExtractor))
+	}
+
 	if s.skillsManager != nil {
 		for _, skill := range s.skillsManager.ListEligible() {
 			for _, tool := range skills.BuildSkillTools(skill, execManager) {
@@ -736,9 +893,14 @@ func (s *Server) registerTools(ctx context.Context, runtime *agent.Runtime) erro
 		runtime.RegisterTool(jobtools.NewStatusTool(s.jobStore))
 	}
 
+	if s.webWatch != nil {
+		runtime.RegisterTool(watchtools.NewURLTool(s.webWatch))
+	}
+
 	if s.attentionFeed != nil {
 		runtime.RegisterTool(attention.NewListAttentionTool(s.attentionFeed))
 		runtime.RegisterTool(attention.NewGetAttentionTool(s.attentionFeed))
+		runtime.RegisterTool(attention.NewAddAttentionTool(s.attentionFeed))
 		runtime.RegisterTool(attention.NewHandleAttentionTool(s.attentionFeed))
 		runtime.RegisterTool(attention.NewSnoozeAttentionTool(s.attentionFeed))
 		runtime.RegisterTool(attention.NewStatsAttentionTool(s.attentionFeed))
@@ -749,6 +911,7 @@ func (s *Server) registerTools(ctx context.Context, runtime *agent.Runtime) erro
 		runtime.RegisterTool(reminders.NewSetTool(s.taskStore))
 		runtime.RegisterTool(reminders.NewCancelTool(s.taskStore))
 		runtime.RegisterTool(reminders.NewListTool(s.taskStore))
+		runtime.RegisterTool(reminders.NewScheduleMessageTool(s.taskStore, s.userTimezone))
 		s.logger.Info("registered reminder tools")
 	}
 
@@ -783,6 +946,26 @@ func (s *Server) registerTools(ctx context.Context, runtime *agent.Runtime) erro
 		s.logger.Info("registered Home Assistant tools")
 	}
 
+	if s.config.Tools.SQLQuery.Enabled {
+		tool, err := newSQLQueryTool(s.config.Tools.SQLQuery)
+		if err != nil {
+			return err
+		}
+		runtime.RegisterTool(tool)
+		s.logger.Info("registered SQL query tool", "databases", len(s.config.Tools.SQLQuery.Databases))
+	}
+
+	if s.config.Tools.Spreadsheets.Enabled {
+		tools, err := newSpreadsheetTools(s.config.Tools.Spreadsheets, s.artifactRepo)
+		if err != nil {
+			return err
+		}
+		for _, tool := range tools {
+			runtime.RegisterTool(tool)
+		}
+		s.logger.Info("registered spreadsheet tools", "count", len(tools))
+	}
+
 	if s.config.MCP.Enabled && s.mcpManager != nil {
 		mcp.RegisterToolsWithRegistrar(runtime, s.mcpManager, s.toolPolicyResolver)
 	}
@@ -795,6 +978,59 @@ func (s *Server) registerTools(ctx context.Context, runtime *agent.Runtime) erro
 	return nil
 }
 
+// newSQLQueryTool builds the sql_query tool from config.
+func newSQLQueryTool(cfg config.SQLQueryConfig) (*sqlquery.Tool, error) {
+	databases := make([]sqlquery.Database, 0, len(cfg.Databases))
+	for _, db := range cfg.Databases {
+		databases = append(databases, sqlquery.Database{
+			Name:        db.Name,
+			Driver:      db.Driver,
+			DSN:         db.DSN,
+			Description: db.Description,
+		})
+	}
+	tool, err := sqlquery.New(sqlquery.Config{
+		Databases:  databases,
+		Statements: cfg.Statements,
+		MaxRows:    cfg.MaxRows,
+		MaxBytes:   cfg.MaxBytes,
+		Timeout:    cfg.Timeout,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("sql query tool: %w", err)
+	}
+	return tool, nil
+}
+
+// newSpreadsheetTools builds the XLSX tools, and the Google Sheets tools
+// when credentials are configured. xlsx_read needs artifact storage.
+func newSpreadsheetTools(cfg config.SpreadsheetsConfig, artifactRepo artifacts.Repository) ([]agent.Tool, error) {
+	tools := []agent.Tool{spreadsheet.NewXLSXCreateTool()}
+	if artifactRepo != nil {
+		tools = append(tools, spreadsheet.NewXLSXReadTool(artifactRepo, cfg.MaxCells))
+	}
+	google := cfg.Google
+	if strings.TrimSpace(google.CredentialsFile) == "" && strings.TrimSpace(google.RefreshToken) == "" {
+		return tools, nil
+	}
+	client, err := spreadsheet.NewSheetsClient(spreadsheet.SheetsConfig{
+		CredentialsFile: google.CredentialsFile,
+		ClientID:        google.ClientID,
+		ClientSecret:    google.ClientSecret,
+		RefreshToken:    google.RefreshToken,
+		Spreadsheets:    google.Spreadsheets,
+		Timeout:         google.Timeout,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("spreadsheet tools: %w", err)
+	}
+	return append(tools,
+		spreadsheet.NewSheetsReadTool(client, cfg.MaxCells),
+		spreadsheet.NewSheetsAppendTool(client),
+		spreadsheet.NewSheetsCreateTool(client),
+	), nil
+}
+
 // registerEdgeTools registers tools from connected edges with the runtime.
 func (s *Server) registerEdgeTools(runtime *agent.Runtime) {
 	provider := edge.NewToolProvider(s.edgeManager)
@@ -0,0 +1,145 @@
+package gateway
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/haasonsaas/nexus/internal/datetime"
+	"github.com/haasonsaas/nexus/internal/tools/reminders"
+	"github.com/haasonsaas/nexus/pkg/models"
+)
+
+// identityTimezoneKey is the identity metadata key holding a user's IANA
+// timezone.
+const identityTimezoneKey = "timezone"
+
+// userTimezone resolves the timezone of a user on a channel: the
+// "timezone" metadata of their linked identity, then user.timezone, then
+// the gateway host's timezone.
+func (s *Server) userTimezone(ctx context.Context, channel models.ChannelType, peerID string) string {
+	if s.identityStore != nil && peerID != "" {
+		ident, err := s.identityStore.ResolveByPeer(ctx, string(channel), peerID)
+		if err == nil && ident != nil {
+			if tz := strings.TrimSpace(ident.Metadata[identityTimezoneKey]); tz != "" {
+				if _, err := time.LoadLocation(tz); err == nil {
+					return tz
+				}
+				s.logger.Warn("ignoring invalid identity timezone", "identity", ident.CanonicalID, "timezone", tz)
+			}
+		}
+	}
+	configured := ""
+	if s.config != nil {
+		configured = s.config.User.Timezone
+	}
+	return datetime.ResolveUserTimezone(configured)
+}
+
+// userLocation loads the timezone of the sender of msg.
+func (s *Server) userLocation(ctx context.Context, session *models.Session, msg *models.Message) *time.Location {
+	peerID := extractSenderID(msg)
+	if peerID == "" {
+		peerID = session.ChannelID
+	}
+	loc, err := time.LoadLocation(s.userTimezone(ctx, session.Channel, peerID))
+	if err != nil {
+		return time.Local
+	}
+	return loc
+}
+
+// applyScheduleCommand handles /schedule and /remind.
+func (s *Server) applyScheduleCommand(ctx context.Context, session *models.Session, msg *models.Message, kind, text, when string) {
+	if s.taskStore == nil || s.config == nil || !s.config.Tasks.Enabled {
+		s.sendImmediateReply(ctx, session, msg, "Scheduling is not enabled on this gateway.")
+		return
+	}
+
+	loc := s.userLocation(ctx, session, msg)
+	now := time.Now()
+	schedule, err := reminders.ParseSchedule(when, loc, now)
+	if err != nil {
+		s.sendImmediateReply(ctx, session, msg, fmt.Sprintf("Couldn't read %q: %v", when, err))
+		return
+	}
+	taskType := reminders.TaskTypeScheduledMessage
+	if kind == "reminder" {
+		taskType = reminders.TaskTypeReminder
+	}
+	task, err := reminders.ScheduledMessage{
+		Type:      taskType,
+		Text:      text,
+		Schedule:  schedule,
+		AgentID:   session.AgentID,
+		Channel:   session.Channel,
+		ChannelID: session.ChannelID,
+		CreatedBy: extractSenderID(msg),
+		Source:    "command",
+	}.NewTask(now)
+	if err != nil {
+		s.sendImmediateReply(ctx, session, msg, "Couldn't schedule that: "+err.Error())
+		return
+	}
+	if err := s.taskStore.CreateTask(ctx, task); err != nil {
+		s.logger.Error("failed to create scheduled message", "error", err)
+		s.sendImmediateReply(ctx, session, msg, "Failed to save the scheduled message.")
+		return
+	}
+
+	label := "Message"
+	if taskType == reminders.TaskTypeReminder {
+		label = "Reminder"
+	}
+	s.sendImmediateReply(ctx, session, msg, fmt.Sprintf("%s scheduled %s (ID %s). Cancel with /unschedule %s.",
+		label, schedule.Summary(task.NextRunAt), reminders.ShortID(task.ID), reminders.ShortID(task.ID)))
+}
+
+// applyListScheduledCommand handles /scheduled.
+func (s *Server) applyListScheduledCommand(ctx context.Context, session *models.Session, msg *models.Message) {
+	if s.taskStore == nil || s.config == nil || !s.config.Tasks.Enabled {
+		s.sendImmediateReply(ctx, session, msg, "Scheduling is not enabled on this gateway.")
+		return
+	}
+	list, err := reminders.ListConversation(ctx, s.taskStore, session.AgentID, session.Channel, session.ChannelID)
+	if err != nil {
+		s.logger.Error("failed to list scheduled messages", "error", err)
+		s.sendImmediateReply(ctx, session, msg, "Failed to list scheduled messages.")
+		return
+	}
+	s.sendImmediateReply(ctx, session, msg, reminders.FormatList(list, s.userLocation(ctx, session, msg)))
+}
+
+// applyCancelScheduledCommand handles /unschedule.
+func (s *Server) applyCancelScheduledCommand(ctx context.Context, session *models.Session, msg *models.Message, id string) {
+	if s.taskStore == nil || s.config == nil || !s.config.Tasks.Enabled {
+		s.sendImmediateReply(ctx, session, msg, "Scheduling is not enabled on this gateway.")
+		return
+	}
+	list, err := reminders.ListConversation(ctx, s.taskStore, session.AgentID, session.Channel, session.ChannelID)
+	if err != nil {
+		s.logger.Error("failed to list scheduled messages", "error", err)
+		s.sendImmediateReply(ctx, session, msg, "Failed to look up scheduled messages.")
+		return
+	}
+	task, err := reminders.FindByID(list, id)
+	if err != nil {
+		s.sendImmediateReply(ctx, session, msg, titleFirst(err.Error())+". See /scheduled.")
+		return
+	}
+	if err := reminders.Cancel(ctx, s.taskStore, task, "user_command"); err != nil {
+		s.logger.Error("failed to cancel scheduled message", "error", err)
+		s.sendImmediateReply(ctx, session, msg, "Failed to cancel the scheduled message.")
+		return
+	}
+	s.sendImmediateReply(ctx, session, msg, fmt.Sprintf("Cancelled %s: %q", reminders.ShortID(task.ID), task.Prompt))
+}
+
+// titleFirst upper-cases the first letter of an error message for a reply.
+func titleFirst(text string) string {
+	if text == "" {
+		return text
+	}
+	return strings.ToUpper(text[:1]) + text[1:]
+}
@@ -0,0 +1,118 @@
+package gateway
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/haasonsaas/nexus/internal/agent"
+	"github.com/haasonsaas/nexus/internal/channels"
+	"github.com/haasonsaas/nexus/internal/config"
+	"github.com/haasonsaas/nexus/internal/identity"
+	"github.com/haasonsaas/nexus/internal/sessions"
+	"github.com/haasonsaas/nexus/internal/tasks"
+	"github.com/haasonsaas/nexus/internal/tools/reminders"
+	"github.com/haasonsaas/nexus/pkg/models"
+)
+
+func TestHandleMessageScheduleCommands(t *testing.T) {
+	ctx := context.Background()
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	cfg := &config.Config{
+		Session: config.SessionConfig{DefaultAgentID: "main"},
+		Tasks:   config.TasksConfig{Enabled: true},
+		User:    config.UserConfig{Timezone: "America/New_York"},
+	}
+	server, err := NewServer(cfg, logger)
+	if err != nil {
+		t.Fatalf("NewServer() error = %v", err)
+	}
+
+	db, err := sessions.OpenSQLite(ctx, "sqlite://"+filepath.Join(t.TempDir(), "nexus.db"))
+	if err != nil {
+		t.Fatalf("OpenSQLite() error = %v", err)
+	}
+	taskStore, err := tasks.NewSQLiteStore(db)
+	if err != nil {
+		t.Fatalf("NewSQLiteStore() error = %v", err)
+	}
+	t.Cleanup(func() { _ = taskStore.Close() })
+	server.taskStore = taskStore
+
+	store := sessions.NewMemoryStore()
+	server.sessions = store
+	server.runtime = agent.NewRuntime(&countingProvider{}, store)
+	adapter := &recordingAdapter{}
+	registry := channels.NewRegistry()
+	registry.Register(adapter)
+	server.channels = registry
+
+	idStore := identity.NewMemoryStore()
+	if err := idStore.Create(ctx, &identity.Identity{CanonicalID: "ana", Metadata: map[string]string{"timezone": "Asia/Tokyo"}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := idStore.LinkPeer(ctx, "ana", string(models.ChannelTelegram), "7"); err != nil {
+		t.Fatal(err)
+	}
+	server.identityStore = idStore
+
+	send := func(id, content string, userID int64) string {
+		t.Helper()
+		server.handleMessage(ctx, &models.Message{
+			ID:        id,
+			Channel:   models.ChannelTelegram,
+			ChannelID: "chat-1",
+			Direction: models.DirectionInbound,
+			Role:      models.RoleUser,
+			Content:   content,
+			Metadata:  map[string]any{"chat_id": int64(1), "user_id": userID},
+		})
+		adapter.mu.Lock()
+		defer adapter.mu.Unlock()
+		return adapter.messages[len(adapter.messages)-1].Content
+	}
+
+	reply := send("m1", `/schedule "ship it" tomorrow at 9am`, 7)
+	if !strings.Contains(reply, "Message scheduled for") || !strings.Contains(reply, "JST") {
+		t.Fatalf("unexpected reply %q", reply)
+	}
+	reply = send("m2", `/remind weekly on monday at 10am "standup notes"`, 8)
+	if !strings.Contains(reply, "Reminder scheduled every Monday at 10:00 AM America/New_York") {
+		t.Fatalf("unexpected reply %q", reply)
+	}
+	if reply = send("m3", `/remind whenever "x"`, 8); !strings.Contains(reply, "Couldn't read") {
+		t.Fatalf("unexpected reply %q", reply)
+	}
+
+	list, err := taskStore.ListTasks(ctx, tasks.ListTasksOptions{})
+	if err != nil || len(list) != 2 {
+		t.Fatalf("ListTasks = %d tasks, %v", len(list), err)
+	}
+	var reminder *tasks.ScheduledTask
+	for _, task := range list {
+		if task.Metadata["type"] == reminders.TaskTypeReminder {
+			reminder = task
+		} else if task.Timezone != "Asia/Tokyo" || task.Prompt != "ship it" || task.Metadata["created_by"] != "7" {
+			t.Fatalf("unexpected scheduled message %+v", task)
+		}
+	}
+	if reminder == nil || reminder.Schedule != "0 10 * * 1" || reminder.Timezone != "America/New_York" {
+		t.Fatalf("unexpected reminder %+v", reminder)
+	}
+
+	if reply = send("m4", "/scheduled", 8); !strings.Contains(reply, "Scheduled (2)") || !strings.Contains(reply, reminders.ShortID(reminder.ID)) {
+		t.Fatalf("unexpected listing %q", reply)
+	}
+	if reply = send("m5", "/unschedule "+reminders.ShortID(reminder.ID), 8); !strings.Contains(reply, "Cancelled") {
+		t.Fatalf("unexpected cancel reply %q", reply)
+	}
+	if reply = send("m6", "/unschedule nope", 8); !strings.Contains(reply, "No scheduled message") {
+		t.Fatalf("unexpected cancel reply %q", reply)
+	}
+	if reply = send("m7", "/scheduled", 8); !strings.Contains(reply, "Scheduled (1)") {
+		t.Fatalf("unexpected listing %q", reply)
+	}
+}
@@ -26,10 +26,7 @@ func (s *Server) startSecurityPosture(ctx context.Context) {
 		interval = 10 * time.Minute
 	}
 
-	s.wg.Add(1)
-	go func() {
-		defer s.wg.Done()
-
+	s.goSupervised(ctx, "worker:security_posture", func(ctx context.Context) {
 		ticker := time.NewTicker(interval)
 		defer ticker.Stop()
 
@@ -43,7 +40,7 @@ func (s *Server) startSecurityPosture(ctx context.Context) {
 				s.runSecurityPosture(ctx)
 			}
 		}
-	}()
+	})
 }
 
 func (s *Server) runSecurityPosture(ctx context.Context) {
@@ -166,23 +163,26 @@ func (s *Server) applyPostureLockdown(ctx context.Context) bool {
 	policy.RequireApproval = []string{"*"}
 	policy.DefaultDecision = agent.ApprovalPending
 
-	checker := agent.NewApprovalChecker(policy)
-	checker.SetStore(agent.NewMemoryApprovalStore())
+	checker := s.newApprovalChecker(policy)
 	s.approvalChecker = checker
 
 	elevatedTools := []string{"__disabled__"}
 	runtime.SetOptions(agent.RuntimeOptions{
-		MaxIterations:     s.config.Tools.Execution.MaxIterations,
-		ToolParallelism:   s.config.Tools.Execution.Parallelism,
-		ToolTimeout:       s.config.Tools.Execution.Timeout,
-		ToolMaxAttempts:   s.config.Tools.Execution.MaxAttempts,
-		ToolRetryBackoff:  s.config.Tools.Execution.RetryBackoff,
-		DisableToolEvents: s.config.Tools.Execution.DisableEvents,
-		MaxToolCalls:      s.config.Tools.Execution.MaxToolCalls,
-		RequireApproval:   []string{"*"},
-		ApprovalChecker:   checker,
-		ElevatedTools:     elevatedTools,
-		AsyncTools:        s.config.Tools.Execution.Async,
+		MaxIterations:       s.config.Tools.Execution.MaxIterations,
+		ToolParallelism:     s.config.Tools.Execution.Parallelism,
+		ToolTimeout:         s.config.Tools.Execution.Timeout,
+		ToolMaxAttempts:     s.config.Tools.Execution.MaxAttempts,
+		ToolRetryBackoff:    s.config.Tools.Execution.RetryBackoff,
+		ToolMaxRetryBackoff: s.config.Tools.Execution.Retry.MaxBackoff,
+		ToolRetryJitter:     s.config.Tools.Execution.Retry.Jitter,
+		ToolRetryOn:         toolRetryClasses(s.config.Tools.Execution.Retry.RetryOn),
+		IdempotentTools:     s.config.Tools.Execution.Retry.Idempotent,
+		DisableToolEvents:   s.config.Tools.Execution.DisableEvents,
+		MaxToolCalls:        s.config.Tools.Execution.MaxToolCalls,
+		RequireApproval:     []string{"*"},
+		ApprovalChecker:     checker,
+		ElevatedTools:       elevatedTools,
+		AsyncTools:          s.config.Tools.Execution.Async,
 		ToolResultGuard: agent.ToolResultGuard{
 			Enabled:         s.config.Tools.Execution.ResultGuard.Enabled,
 			MaxChars:        s.config.Tools.Execution.ResultGuard.MaxChars,
@@ -191,9 +191,16 @@ func (s *Server) applyPostureLockdown(ctx context.Context) bool {
 			RedactionText:   s.config.Tools.Execution.ResultGuard.RedactionText,
 			TruncateSuffix:  s.config.Tools.Execution.ResultGuard.TruncateSuffix,
 			SanitizeSecrets: s.config.Tools.Execution.ResultGuard.SanitizeSecrets,
+			Artifacts:       s.artifactRepo,
+		},
+		ToolSchemaValidation: agent.ToolSchemaValidation{
+			Enabled:        s.config.Tools.Execution.SchemaValidation.Enabled,
+			RepairAttempts: s.config.Tools.Execution.SchemaValidation.RepairAttempts,
+			Strict:         s.config.Tools.Execution.SchemaValidation.Strict,
 		},
-		JobStore: s.jobStore,
-		Logger:   s.logger,
+		JobStore:    s.jobStore,
+		Checkpoints: s.checkpointOptions(),
+		Logger:      s.logger,
 	})
 
 	s.postureMu.Lock()
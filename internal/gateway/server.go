@@ -26,27 +26,39 @@ import (
 	"google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/reflection"
 
+	"github.com/haasonsaas/nexus/internal/access"
 	"github.com/haasonsaas/nexus/internal/agent"
+	"github.com/haasonsaas/nexus/internal/anomaly"
 	"github.com/haasonsaas/nexus/internal/artifacts"
 	"github.com/haasonsaas/nexus/internal/attention"
 	"github.com/haasonsaas/nexus/internal/audit"
 	"github.com/haasonsaas/nexus/internal/auth"
+	"github.com/haasonsaas/nexus/internal/canary"
 	"github.com/haasonsaas/nexus/internal/canvas"
 	"github.com/haasonsaas/nexus/internal/channels"
+	"github.com/haasonsaas/nexus/internal/channels/loopback"
+	"github.com/haasonsaas/nexus/internal/cluster"
 	"github.com/haasonsaas/nexus/internal/commands"
 	"github.com/haasonsaas/nexus/internal/config"
 	"github.com/haasonsaas/nexus/internal/cron"
+	"github.com/haasonsaas/nexus/internal/delivery"
+	"github.com/haasonsaas/nexus/internal/drafts"
 	"github.com/haasonsaas/nexus/internal/edge"
+	"github.com/haasonsaas/nexus/internal/eventbridge"
+	"github.com/haasonsaas/nexus/internal/eventbus"
 	"github.com/haasonsaas/nexus/internal/experiments"
+	"github.com/haasonsaas/nexus/internal/feedback"
 	"github.com/haasonsaas/nexus/internal/hooks"
 	"github.com/haasonsaas/nexus/internal/hooks/bundled"
 	"github.com/haasonsaas/nexus/internal/identity"
 	"github.com/haasonsaas/nexus/internal/infra"
 	"github.com/haasonsaas/nexus/internal/jobs"
+	"github.com/haasonsaas/nexus/internal/links"
 	"github.com/haasonsaas/nexus/internal/mcp"
 	"github.com/haasonsaas/nexus/internal/media"
-	"github.com/haasonsaas/nexus/internal/media/transcribe"
 	"github.com/haasonsaas/nexus/internal/memory"
+	"github.com/haasonsaas/nexus/internal/memory/review"
+	"github.com/haasonsaas/nexus/internal/messages"
 	modelcatalog "github.com/haasonsaas/nexus/internal/models"
 	"github.com/haasonsaas/nexus/internal/observability"
 	"github.com/haasonsaas/nexus/internal/plugins"
@@ -55,10 +67,16 @@ import (
 	"github.com/haasonsaas/nexus/internal/sessions"
 	"github.com/haasonsaas/nexus/internal/skills"
 	"github.com/haasonsaas/nexus/internal/storage"
+	"github.com/haasonsaas/nexus/internal/storage/encryption"
+	"github.com/haasonsaas/nexus/internal/storage/sqlite"
+	"github.com/haasonsaas/nexus/internal/supervisor"
 	"github.com/haasonsaas/nexus/internal/tasks"
+	broadcasttools "github.com/haasonsaas/nexus/internal/tools/broadcast"
 	"github.com/haasonsaas/nexus/internal/tools/browser"
 	"github.com/haasonsaas/nexus/internal/tools/policy"
 	"github.com/haasonsaas/nexus/internal/tools/sandbox/firecracker"
+	"github.com/haasonsaas/nexus/internal/tools/websearch"
+	"github.com/haasonsaas/nexus/internal/webwatch"
 	"github.com/haasonsaas/nexus/pkg/models"
 	proto "github.com/haasonsaas/nexus/pkg/proto"
 )
@@ -76,6 +94,12 @@ type Server struct {
 	cancel      context.CancelFunc
 	startTime   time.Time
 
+	// processCtx is the context of message processing, canceled at shutdown.
+	processCtx context.Context
+
+	// adminAudit records admin-only operations and denied attempts at them.
+	adminAudit *audit.Logger
+
 	// startupCancel cancels background discovery goroutines launched during initialization
 	startupCancel context.CancelFunc
 
@@ -91,13 +115,23 @@ type Server struct {
 	memoryLogger    *sessions.MemoryLogger
 	skillsManager   *skills.Manager
 	vectorMemory    *memory.Manager
+	memoryReview    *review.Queue
+	drafts          *drafts.Queue
+	anomalies       *anomaly.Detector
+	keyring         *encryption.Keyring
 	ragIndex        *ragindex.Manager
 	ragStoreCloser  io.Closer
 	ragInjector     *ragcontext.Injector
 	attentionFeed   *attention.Feed
 	mediaProcessor  media.Processor
 	mediaAggregator *media.Aggregator
+	textExtractor   media.TextExtractor
+	linkSummarizer  *links.LLMSummarizer
+	webWatch        *webwatch.Monitor
 	experimentsMgr  *experiments.Manager
+	// experimentRecorder logs exposures and run outcomes; nil when no
+	// experiment is active.
+	experimentRecorder *experimentRecorder
 
 	channelPlugins     *channelPluginRegistry
 	runtimePlugins     *plugins.RuntimeRegistry
@@ -112,12 +146,49 @@ type Server struct {
 	toolPolicyResolver *policy.Resolver
 	llmProvider        agent.LLMProvider
 	defaultModel       string
+	batchQueue         *agent.BatchQueue
+	batchModel         string
 	jobStore           jobs.Store
 	approvalChecker    *agent.ApprovalChecker
 	commandRegistry    *commands.Registry
+	roleStore          *commands.RoleStore
+	accessGrants       *access.Cache
+	accessGrantsMu     sync.Mutex
+	feedbackRecorder   *feedback.Recorder
+	runJournal         *runJournal
+	runCheckpoints     *agent.FileCheckpointStore
 	commandParser      *commands.Parser
 	activeRuns         map[string]activeRun
 	activeRunsMu       sync.Mutex
+	heartbeatSlots     map[string]time.Time
+	heartbeatMu        sync.Mutex
+
+	// Steering rules reloaded at runtime and per-rule hit counts.
+	steeringRules *config.SteeringConfig
+	steeringHits  map[string]*steeringRuleHits
+	steeringMu    sync.RWMutex
+
+	// Provider key pools by provider ID, and the LLM config last applied
+	// to them by a config reload.
+	keyPools       map[string]*providerKeyPool
+	keyRotationLLM *config.LLMConfig
+	keyPoolsMu     sync.Mutex
+
+	// Provider rate limiters by provider or provider/key name.
+	providerLimiters   map[string]*providerLimiter
+	providerLimitersMu sync.Mutex
+
+	// Panic supervision for adapters, tools, and background workers.
+	supervisorConfig    supervisor.Config
+	sentryExporter      *observability.SentryExporter
+	restoreCrashHandler func()
+
+	// providerOverride replaces the configured LLM provider (embedded mode only).
+	providerOverride      agent.LLMProvider
+	providerOverrideModel string
+
+	// providerProbe caches provider reachability for readiness checks.
+	providerProbe providerProbeCache
 
 	broadcastManager *BroadcastManager
 	hooksRegistry    *hooks.Registry
@@ -125,6 +196,21 @@ type Server struct {
 	webhookMu        sync.RWMutex
 	webhookHandlers  map[string]WebhookHandler
 
+	// outboundBroadcast delivers /broadcast and broadcast tool messages;
+	// built on first use from gateway.broadcast.outbound.
+	outboundBroadcast     *broadcasttools.Broadcaster
+	outboundBroadcastOnce sync.Once
+
+	// groupEtiquette tracks group reply cooldowns and interject sampling.
+	groupEtiquette groupEtiquette
+
+	// readState tracks read positions for /catchup and catch-up digests.
+	readState readState
+
+	// messageCatalog renders localized system messages; nil uses the
+	// built-in text.
+	messageCatalog *messages.Catalog
+
 	edgeManager *edge.Manager
 	edgeService *edge.Service
 	edgeTOFU    *edge.TOFUAuthenticator
@@ -134,21 +220,40 @@ type Server struct {
 
 	// Artifact repository for tool-produced files
 	artifactRepo artifacts.Repository
+	// artifactLinks signs download links; nil when links are disabled
+	artifactLinks *artifacts.LinkSigner
 
 	// Event timeline for observability and debugging
 	eventStore    *observability.MemoryEventStore
 	eventRecorder *observability.EventRecorder
+	// persistedEvents writes a sampled copy of recorded events to the
+	// database; nil unless observability.event_store is enabled.
+	persistedEvents *observability.PersistingEventStore
 
 	// Tracing for distributed observability
 	tracer        *observability.Tracer
 	traceShutdown func(context.Context) error
 
+	// metricsExporter pushes metrics to an OTLP collector; nil when only
+	// the Prometheus scrape endpoint is used
+	metricsExporter *observability.OTLPMetricsExporter
+
+	// eventBridge publishes gateway events to NATS or Kafka; nil unless
+	// observability.event_bridge is enabled
+	eventBridge *eventbridge.Bridge
+
 	// Trace directory plugin for run tracing
 	tracePlugin *agent.TraceDirectoryPlugin
 
 	// Identity linking for cross-channel user mapping
 	identityStore identity.Store
 
+	// presence tracks where users last wrote; delivery routes proactive
+	// messages with it
+	presence     *delivery.Presence
+	delivery     *delivery.Router
+	deliveryOnce sync.Once
+
 	// messageSem limits concurrent message processing to prevent unbounded goroutine growth
 	messageSem chan struct{}
 
@@ -176,6 +281,14 @@ type Server struct {
 	httpServer   *http.Server
 	httpListener net.Listener
 
+	// diagnosticsServer serves pprof and runtime stats on server.diagnostics
+	diagnosticsServer *http.Server
+
+	// canary runs synthetic conversations; canaryLoopback receives the
+	// replies of loopback canaries.
+	canary         *canary.Runner
+	canaryLoopback *loopback.Adapter
+
 	configApplyMu sync.Mutex
 
 	// postureMu guards security posture state
@@ -195,6 +308,15 @@ type Server struct {
 
 	// nodeID identifies this gateway in a cluster.
 	nodeID string
+
+	// cluster coordinates leader election and work partitioning across nodes.
+	cluster *cluster.Coordinator
+
+	// events propagates approvals, session resets, config changes, and cache
+	// invalidation between nodes. localEvents holds the subscriptions and is
+	// wrapped by a Postgres transport in cluster mode.
+	events      eventbus.Bus
+	localEvents *eventbus.MemoryBus
 }
 
 // NewServer creates a new gateway server with the given configuration and logger.
@@ -224,6 +346,8 @@ func NewServer(cfg *config.Config, logger *slog.Logger) (*Server, error) {
 			UserID: entry.UserID,
 			Email:  entry.Email,
 			Name:   entry.Name,
+			Role:   entry.Role,
+			Scopes: entry.Scopes,
 		})
 	}
 	authService := auth.NewService(auth.Config{
@@ -278,7 +402,16 @@ func NewServer(cfg *config.Config, logger *slog.Logger) (*Server, error) {
 
 	// Initialize canvas manager and store
 	var canvasStore canvas.Store
-	if cfg.Database.URL != "" {
+	if sqlite.IsURL(cfg.Database.URL) {
+		dbStore, err := newSQLiteCanvasStore(cfg.Database.URL)
+		if err != nil {
+			logger.Warn("canvas store falling back to memory", "error", err)
+			canvasStore = canvas.NewMemoryStore()
+		} else {
+			canvasStore = dbStore
+			logger.Info("using database-backed canvas store")
+		}
+	} else if cfg.Database.URL != "" {
 		storeCfg := storage.DefaultCockroachConfig()
 		if cfg.Database.MaxConnections > 0 {
 			storeCfg.MaxOpenConns = cfg.Database.MaxConnections
@@ -300,6 +433,7 @@ func NewServer(cfg *config.Config, logger *slog.Logger) (*Server, error) {
 	canvasMetrics := canvas.NewMetrics()
 	canvasManager := canvas.NewManager(canvasStore, logger)
 	canvasManager.SetMetrics(canvasMetrics)
+	canvasManager.SetRetention(cfg.Canvas.Retention)
 	if canvasHost != nil {
 		canvasHost.SetManager(canvasManager)
 		canvasHost.SetMetrics(canvasMetrics)
@@ -315,6 +449,21 @@ func NewServer(cfg *config.Config, logger *slog.Logger) (*Server, error) {
 	}
 	canvasManager.SetAuditLogger(auditLogger)
 
+	adminAudit, err := audit.NewLogger(cfg.Auth.Audit)
+	if err != nil {
+		logger.Warn("admin audit logger init failed", "error", err)
+		adminAudit = nil
+	}
+
+	keyring, err := BuildKeyring(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("field encryption: %w", err)
+	}
+
+	if err := LoadPluginStorage(cfg); err != nil {
+		return nil, err
+	}
+
 	// Initialize vector memory manager (optional, returns nil if not enabled)
 	if cfg.VectorMemory.Enabled && cfg.VectorMemory.Pgvector.UseCockroachDB && cfg.VectorMemory.Pgvector.DSN == "" {
 		cfg.VectorMemory.Pgvector.DSN = cfg.Database.URL
@@ -323,10 +472,33 @@ func NewServer(cfg *config.Config, logger *slog.Logger) (*Server, error) {
 	if err != nil {
 		logger.Warn("vector memory not initialized", "error", err)
 	}
+	if vectorMem != nil {
+		vectorMem.SetKeyring(keyring)
+	}
+	memoryReview, err := review.NewQueue(cfg)
+	if err != nil {
+		logger.Warn("memory review not initialized", "error", err)
+	}
+	var draftQueue *drafts.Queue
+	if cfg.Tools.Drafts.Enabled {
+		draftQueue = drafts.NewQueue(cfg.Tools.Drafts.TTL)
+	}
+	var anomalies *anomaly.Detector
+	if cfg.Observability.Anomaly.Enabled {
+		anomalies = anomaly.NewDetector(cfg.Observability.Anomaly, logger)
+	}
 	var attentionFeed *attention.Feed
 	if cfg.Attention.Enabled {
 		attentionFeed = attention.NewFeed()
 	}
+	var webWatch *webwatch.Monitor
+	if cfg.Tools.WebWatch.Enabled {
+		webWatch = webwatch.NewMonitor(webwatch.Config{
+			DefaultInterval: cfg.Tools.WebWatch.DefaultInterval,
+			MinInterval:     cfg.Tools.WebWatch.MinInterval,
+			MaxPerSession:   cfg.Tools.WebWatch.MaxPerSession,
+		}, websearch.NewContentExtractor())
+	}
 	var ragIndex *ragindex.Manager
 	var ragStoreCloser io.Closer
 	var ragInjector *ragcontext.Injector
@@ -340,22 +512,19 @@ func NewServer(cfg *config.Config, logger *slog.Logger) (*Server, error) {
 			ragInjector = injector
 		}
 	}
-	var mediaProcessor media.Processor
-	var mediaAggregator *media.Aggregator
-	if cfg.Transcription.Enabled {
-		transcriber, err := transcribe.New(transcribe.Config{
-			Provider: cfg.Transcription.Provider,
-			APIKey:   cfg.Transcription.APIKey,
-			BaseURL:  cfg.Transcription.BaseURL,
-			Model:    cfg.Transcription.Model,
-			Language: cfg.Transcription.Language,
-			Logger:   logger,
-		})
+	var textExtractor media.TextExtractor
+	if cfg.OCR.Enabled {
+		extractor, err := newTextExtractor(cfg.OCR, logger)
 		if err != nil {
-			logger.Warn("transcription not initialized", "error", err)
+			logger.Warn("ocr not initialized", "error", err)
 		} else {
-			processor := media.NewDefaultProcessor(logger)
-			processor.SetTranscriber(transcriber)
+			textExtractor = extractor
+		}
+	}
+	var mediaProcessor media.Processor
+	var mediaAggregator *media.Aggregator
+	if cfg.Transcription.Enabled || textExtractor != nil {
+		if processor := newMediaProcessor(cfg, textExtractor, logger); processor != nil {
 			mediaProcessor = processor
 			mediaAggregator = media.NewAggregator(processor, logger)
 		}
@@ -366,9 +535,35 @@ func NewServer(cfg *config.Config, logger *slog.Logger) (*Server, error) {
 	if err := commands.RegisterBuiltins(commandRegistry); err != nil {
 		return nil, fmt.Errorf("register builtins: %w", err)
 	}
+	roleStore, err := setupCommandRoles(commandRegistry, cfg.Commands.Roles)
+	if err != nil {
+		return nil, fmt.Errorf("command roles: %w", err)
+	}
 	commandParser := commands.NewParser(commandRegistry)
+	feedbackRecorder, err := setupFeedback(cfg.Observability.Feedback)
+	if err != nil {
+		return nil, fmt.Errorf("feedback: %w", err)
+	}
+	runJournal, err := setupRunJournal(cfg.Session.RunRecovery)
+	if err != nil {
+		return nil, fmt.Errorf("run recovery: %w", err)
+	}
+	runCheckpoints, err := setupRunCheckpoints(context.Background(), cfg.Session.Checkpoints)
+	if err != nil {
+		return nil, fmt.Errorf("run checkpoints: %w", err)
+	}
+	messageCatalog, err := messages.New(cfg.Messages.Locale, cfg.Messages.Templates)
+	if err != nil {
+		return nil, fmt.Errorf("messages: %w", err)
+	}
+	supervisorConfig, sentryExporter, err := setupCrashReporting(cfg.Observability.CrashReporting)
+	if err != nil {
+		return nil, fmt.Errorf("crash reporting: %w", err)
+	}
 
-	modelCatalog := modelcatalog.NewCatalog()
+	// The process-wide catalog, so models discovered here also inform
+	// context budgeting and cost estimates.
+	modelCatalog := modelcatalog.DefaultCatalog
 	var bedrockDiscovery *modelcatalog.BedrockDiscovery
 	if cfg.LLM.Bedrock.Enabled {
 		bedrockCfg := buildBedrockDiscoveryConfig(cfg.LLM.Bedrock, logger)
@@ -378,10 +573,10 @@ func NewServer(cfg *config.Config, logger *slog.Logger) (*Server, error) {
 		}
 	}
 
-	// Create job store (prefer DB when available)
+	// Create job store (prefer CockroachDB/Postgres when available)
 	var jobStore jobs.Store
 	if cfg.Database.URL != "" {
-		dbJobStore, err := jobs.NewCockroachStoreFromDSN(cfg.Database.URL, nil)
+		dbJobStore, err := newJobStore(cfg)
 		if err != nil {
 			logger.Warn("job store falling back to memory", "error", err)
 			jobStore = jobs.NewMemoryStore()
@@ -401,6 +596,7 @@ func NewServer(cfg *config.Config, logger *slog.Logger) (*Server, error) {
 		authService.SetUserStore(stores.Users)
 	}
 	registerOAuthProviders(authService, cfg.Auth.OAuth)
+	registerOIDC(authService, cfg.Auth.OIDC)
 
 	var cronScheduler *cron.Scheduler
 	if cfg.Cron.Enabled {
@@ -413,14 +609,7 @@ func NewServer(cfg *config.Config, logger *slog.Logger) (*Server, error) {
 	// Initialize task store if tasks are enabled
 	var taskStore tasks.Store
 	if cfg.Tasks.Enabled && cfg.Database.URL != "" {
-		taskStoreCfg := tasks.DefaultCockroachConfig()
-		if cfg.Database.MaxConnections > 0 {
-			taskStoreCfg.MaxOpenConns = cfg.Database.MaxConnections
-		}
-		if cfg.Database.ConnMaxLifetime > 0 {
-			taskStoreCfg.ConnMaxLifetime = cfg.Database.ConnMaxLifetime
-		}
-		dbTaskStore, err := tasks.NewCockroachStoreFromDSN(cfg.Database.URL, taskStoreCfg)
+		dbTaskStore, err := newTaskStore(cfg)
 		if err != nil {
 			logger.Warn("task store initialization failed, scheduled tasks disabled", "error", err)
 		} else {
@@ -466,7 +655,7 @@ func NewServer(cfg *config.Config, logger *slog.Logger) (*Server, error) {
 		}
 	}()
 
-	artifactSetup, err := buildArtifactSetup(cfg, logger)
+	artifactSetup, err := buildArtifactSetup(cfg, textExtractor, logger)
 	if err != nil {
 		return nil, fmt.Errorf("artifact setup: %w", err)
 	}
@@ -525,7 +714,12 @@ func NewServer(cfg *config.Config, logger *slog.Logger) (*Server, error) {
 
 	// Initialize event store for observability timeline
 	eventStore := observability.NewMemoryEventStore(10000) // Store up to 10k events
-	eventRecorder := observability.NewEventRecorder(eventStore, nil)
+	var recordedEvents observability.EventStore = eventStore
+	persistedEvents := newPersistingEventStore(eventStore, cfg.Observability.EventStore, logger)
+	if persistedEvents != nil {
+		recordedEvents = persistedEvents
+	}
+	eventRecorder := observability.NewEventRecorder(recordedEvents, nil)
 
 	// Initialize OpenTelemetry tracer if enabled
 	var tracer *observability.Tracer
@@ -548,6 +742,15 @@ func NewServer(cfg *config.Config, logger *slog.Logger) (*Server, error) {
 		}
 	}
 
+	metricsExporter, err := newMetricsExporter(cfg, logger)
+	if err != nil {
+		return nil, fmt.Errorf("otlp metrics: %w", err)
+	}
+	eventBridge, err := newEventBridge(cfg, logger)
+	if err != nil {
+		return nil, fmt.Errorf("event bridge: %w", err)
+	}
+
 	// Initialize identity store for cross-channel linking
 	identityStore := identity.NewMemoryStore()
 	// Import identity links from config if present
@@ -597,6 +800,10 @@ func NewServer(cfg *config.Config, logger *slog.Logger) (*Server, error) {
 	integration.ConfigureProviderUsage(anthropicKey, openaiKey, geminiKey)
 
 	experimentsMgr := experiments.NewManager(cfg.Experiments)
+	var experimentRec *experimentRecorder
+	if experimentsMgr.Active() {
+		experimentRec = newExperimentRecorder(experiments.NewLog(cfg.Experiments.LogPath), logger)
+	}
 
 	startupCancelUsed = true
 	server := &Server{
@@ -605,18 +812,26 @@ func NewServer(cfg *config.Config, logger *slog.Logger) (*Server, error) {
 		channels:           channels.NewRegistry(),
 		logger:             logger,
 		auditLogger:        auditLogger,
+		adminAudit:         adminAudit,
 		startupCancel:      startupCancel,
 		channelPlugins:     newChannelPluginRegistry(),
 		runtimePlugins:     plugins.DefaultRuntimeRegistry(),
 		skillsManager:      skillsMgr,
 		vectorMemory:       vectorMem,
+		memoryReview:       memoryReview,
+		drafts:             draftQueue,
+		anomalies:          anomalies,
+		keyring:            keyring,
 		ragIndex:           ragIndex,
 		ragStoreCloser:     ragStoreCloser,
 		ragInjector:        ragInjector,
 		attentionFeed:      attentionFeed,
+		webWatch:           webWatch,
 		mediaProcessor:     mediaProcessor,
 		mediaAggregator:    mediaAggregator,
+		textExtractor:      textExtractor,
 		experimentsMgr:     experimentsMgr,
+		experimentRecorder: experimentRec,
 		stores:             stores,
 		authService:        authService,
 		cronScheduler:      cronScheduler,
@@ -636,10 +851,21 @@ func NewServer(cfg *config.Config, logger *slog.Logger) (*Server, error) {
 		artifactRepo:       artifactRepo,
 		eventStore:         eventStore,
 		eventRecorder:      eventRecorder,
+		persistedEvents:    persistedEvents,
 		tracer:             tracer,
 		traceShutdown:      traceShutdown,
+		metricsExporter:    metricsExporter,
+		eventBridge:        eventBridge,
 		identityStore:      identityStore,
+		presence:           delivery.NewPresence(),
 		commandRegistry:    commandRegistry,
+		roleStore:          roleStore,
+		feedbackRecorder:   feedbackRecorder,
+		runJournal:         runJournal,
+		runCheckpoints:     runCheckpoints,
+		messageCatalog:     messageCatalog,
+		supervisorConfig:   supervisorConfig,
+		sentryExporter:     sentryExporter,
 		commandParser:      commandParser,
 		activeRuns:         make(map[string]activeRun),
 		messageSem:         make(chan struct{}, 100), // Limit concurrent message handlers
@@ -652,6 +878,20 @@ func NewServer(cfg *config.Config, logger *slog.Logger) (*Server, error) {
 		sessionLocker:      sessions.NewLocalLocker(sessions.DefaultLockTimeout),
 		nodeID:             cfg.Cluster.NodeID,
 	}
+	server.localEvents = eventbus.NewMemoryBus(server.nodeID, logger)
+	server.events = server.localEvents
+	server.subscribeClusterEvents()
+	server.memoryReview.SetOnQueue(server.announceMemoryProposal)
+	if server.drafts != nil {
+		server.drafts.SetOnHold(server.announceDraft)
+		server.drafts.SetOnExpire(server.announceDraftExpired)
+	}
+	if err := commands.RegisterRoleCommand(commandRegistry, roleStore, server.resolveRoleSubject); err != nil {
+		return nil, fmt.Errorf("register role command: %w", err)
+	}
+	if err := commands.RegisterAccessCommands(commandRegistry, accessGrantStore{server}, server.approvePairingCode); err != nil {
+		return nil, fmt.Errorf("register access commands: %w", err)
+	}
 	if err := server.initWebhookHooks(); err != nil {
 		return nil, err
 	}
@@ -731,10 +971,12 @@ func NewServer(cfg *config.Config, logger *slog.Logger) (*Server, error) {
 	}
 	if artifactSetup != nil {
 		server.artifactRepo = artifactSetup.repo
+		server.artifactLinks = artifactSetup.links
 	}
 	if server.canvasHost != nil {
 		server.canvasHost.SetActionHandler(server.handleCanvasAction)
 	}
+	server.linkSummarizer = server.newLinkSummarizer(cfg.Tools.Links)
 	grpcSvc := newGRPCService(server)
 	proto.RegisterNexusGatewayServer(grpcServer, grpcSvc)
 	proto.RegisterSessionServiceServer(grpcServer, grpcSvc)
@@ -795,7 +1037,21 @@ func (s *Server) registerChannelsFromConfig() error {
 	if err := s.runtimePlugins.LoadChannels(s.config, s.channels); err != nil {
 		return err
 	}
+	if err := s.registerEdgeChannels(); err != nil {
+		return err
+	}
 	s.configureSlackCanvas()
+	s.configureEmailChannel()
+	return nil
+}
+
+// LoadPluginStorage registers the artifact stores and vector memory
+// backends of enabled runtime plugins, so artifacts.backend and
+// vector_memory.backend can name them. It is safe to call more than once.
+func LoadPluginStorage(cfg *config.Config) error {
+	if err := plugins.DefaultRuntimeRegistry().LoadStorage(cfg); err != nil {
+		return fmt.Errorf("plugin storage backends: %w", err)
+	}
 	return nil
 }
 
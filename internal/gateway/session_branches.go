@@ -0,0 +1,165 @@
+package gateway
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/haasonsaas/nexus/internal/sessions"
+	"github.com/haasonsaas/nexus/pkg/models"
+)
+
+// applyUndoCommand rolls the conversation back to before the sender's last
+// message. The undone exchange stays on the old branch, so /branch switch
+// can return to it; tool calls it made are listed because their effects
+// are not reverted.
+func (s *Server) applyUndoCommand(ctx context.Context, session *models.Session, msg *models.Message) {
+	if s.branchStore == nil {
+		s.sendImmediateReply(ctx, session, msg, "Undo is not available on this gateway.")
+		return
+	}
+	s.cancelActiveRun(session.ID)
+	current, err := sessions.ActiveBranch(ctx, s.branchStore, session)
+	if err != nil {
+		s.logger.Error("failed to resolve active branch", "session_id", session.ID, "error", err)
+		s.sendImmediateReply(ctx, session, msg, "Failed to undo the last message.")
+		return
+	}
+	branch, undone, err := sessions.UndoLastExchange(ctx, s.branchStore, current)
+	if errors.Is(err, sessions.ErrNothingToUndo) {
+		s.sendImmediateReply(ctx, session, msg, "There is nothing to undo.")
+		return
+	}
+	if err == nil {
+		sessions.SetActiveBranch(session, branch)
+		err = s.sessions.Update(ctx, session)
+	}
+	if err != nil {
+		s.logger.Error("failed to undo last exchange", "session_id", session.ID, "error", err)
+		s.sendImmediateReply(ctx, session, msg, "Failed to undo the last message.")
+		return
+	}
+
+	reply := "Undid your last message."
+	if later := len(undone) - 1; later > 0 {
+		reply = fmt.Sprintf("Undid your last message and the %d %s after it.", later, pluralize(later, "message", "messages"))
+	}
+	if tools := undoneToolNames(undone); len(tools) > 0 {
+		reply += " Tools it ran were not reverted: " + strings.Join(tools, ", ") + "."
+	}
+	reply += fmt.Sprintf(" /branch switch %s restores it.", shortBranchID(current.ID))
+	s.sendImmediateReply(ctx, session, msg, reply)
+}
+
+// applyBranchCommand forks, lists, or switches conversation branches.
+func (s *Server) applyBranchCommand(ctx context.Context, session *models.Session, msg *models.Message, op, value string) {
+	if s.branchStore == nil {
+		s.sendImmediateReply(ctx, session, msg, "Branches are not available on this gateway.")
+		return
+	}
+	current, err := sessions.ActiveBranch(ctx, s.branchStore, session)
+	if err != nil {
+		s.logger.Error("failed to resolve active branch", "session_id", session.ID, "error", err)
+		s.sendImmediateReply(ctx, session, msg, "Failed to read the conversation branches.")
+		return
+	}
+
+	switch op {
+	case "list":
+		s.sendImmediateReply(ctx, session, msg, s.describeBranches(ctx, session, current))
+		return
+	case "switch":
+		target, err := sessions.FindBranch(ctx, s.branchStore, session.ID, value)
+		if err != nil {
+			s.sendImmediateReply(ctx, session, msg, fmt.Sprintf("No branch named %q. Use /branch list to see branches.", value))
+			return
+		}
+		s.cancelActiveRun(session.ID)
+		sessions.SetActiveBranch(session, target)
+		if err := s.sessions.Update(ctx, session); err != nil {
+			s.logger.Error("failed to switch branch", "session_id", session.ID, "error", err)
+			s.sendImmediateReply(ctx, session, msg, "Failed to switch branches.")
+			return
+		}
+		s.sendImmediateReply(ctx, session, msg, fmt.Sprintf("Switched to branch %q (%s).", target.Name, shortBranchID(target.ID)))
+	case "fork":
+		name := value
+		if name == "" {
+			existing, err := s.branchStore.ListBranches(ctx, session.ID, sessions.BranchListOptions{IncludeArchived: true, Limit: 1000})
+			if err != nil {
+				s.logger.Error("failed to list branches", "session_id", session.ID, "error", err)
+			}
+			name = fmt.Sprintf("branch-%d", len(existing))
+		}
+		s.cancelActiveRun(session.ID)
+		branch, err := sessions.ForkBranchHead(ctx, s.branchStore, current, name)
+		if err == nil {
+			sessions.SetActiveBranch(session, branch)
+			err = s.sessions.Update(ctx, session)
+		}
+		if err != nil {
+			s.logger.Error("failed to fork branch", "session_id", session.ID, "error", err)
+			s.sendImmediateReply(ctx, session, msg, "Failed to create a branch.")
+			return
+		}
+		s.sendImmediateReply(ctx, session, msg, fmt.Sprintf("Started branch %q from %q. Use /branch switch %s to go back.", branch.Name, current.Name, current.Name))
+	}
+}
+
+// describeBranches lists the session's branches, marking the active one.
+func (s *Server) describeBranches(ctx context.Context, session *models.Session, active *models.Branch) string {
+	branches, err := s.branchStore.ListBranches(ctx, session.ID, sessions.BranchListOptions{Limit: 1000})
+	if err != nil {
+		s.logger.Error("failed to list branches", "session_id", session.ID, "error", err)
+		return "Failed to list branches."
+	}
+	slices.SortFunc(branches, func(a, b *models.Branch) int { return a.CreatedAt.Compare(b.CreatedAt) })
+	names := make(map[string]string, len(branches))
+	for _, branch := range branches {
+		names[branch.ID] = branch.Name
+	}
+
+	var b strings.Builder
+	b.WriteString("Branches:")
+	for _, branch := range branches {
+		marker := "  "
+		if branch.ID == active.ID {
+			marker = "* "
+		}
+		fmt.Fprintf(&b, "\n%s%s (%s)", marker, branch.Name, shortBranchID(branch.ID))
+		if branch.ParentBranchID != nil {
+			parent := names[*branch.ParentBranchID]
+			if parent == "" {
+				parent = shortBranchID(*branch.ParentBranchID)
+			}
+			if undoOf, ok := branch.Metadata[sessions.MetaKeyUndoOf].(string); ok {
+				fmt.Fprintf(&b, ", undo of %s (%s)", names[undoOf], shortBranchID(undoOf))
+			} else {
+				fmt.Fprintf(&b, ", from %s", parent)
+			}
+		}
+	}
+	return b.String()
+}
+
+// undoneToolNames lists the distinct tools called in undone messages.
+func undoneToolNames(undone []*models.Message) []string {
+	var names []string
+	for _, msg := range undone {
+		for _, call := range msg.ToolCalls {
+			if call.Name != "" && !slices.Contains(names, call.Name) {
+				names = append(names, call.Name)
+			}
+		}
+	}
+	return names
+}
+
+func shortBranchID(id string) string {
+	if len(id) > 8 {
+		return id[:8]
+	}
+	return id
+}
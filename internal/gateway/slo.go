@@ -0,0 +1,50 @@
+package gateway
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/haasonsaas/nexus/internal/observability"
+	"github.com/haasonsaas/nexus/internal/slo"
+)
+
+// startSLOTracker starts evaluating the configured service level objectives.
+// Level changes are recorded as "slo.alert" events and posted to the alert
+// webhook when one is configured.
+func (s *Server) startSLOTracker(ctx context.Context) {
+	if s == nil || s.config == nil || !s.config.Observability.SLO.Enabled {
+		return
+	}
+	cfg := s.config.Observability.SLO
+	if len(cfg.Objectives) == 0 {
+		return
+	}
+
+	tracker := slo.NewTracker(cfg, nil, s.logger)
+	var webhook slo.AlertFunc
+	if url := strings.TrimSpace(cfg.Alerts.WebhookURL); url != "" {
+		webhook = slo.WebhookAlert(url, nil)
+	}
+	tracker.SetAlert(func(ctx context.Context, alert slo.Alert) error {
+		var errs []error
+		if s.eventRecorder != nil {
+			data := map[string]interface{}{
+				"slo":                    alert.Name,
+				"level":                  string(alert.Level),
+				"previous_level":         string(alert.Previous),
+				"target":                 alert.Target,
+				"compliance":             alert.Compliance,
+				"error_budget_remaining": alert.BudgetRemaining,
+				"burn_rates":             alert.BurnRates,
+			}
+			errs = append(errs, s.eventRecorder.Record(ctx, observability.EventTypeCustom, "slo.alert", data))
+		}
+		if webhook != nil {
+			errs = append(errs, webhook(ctx, alert))
+		}
+		return errors.Join(errs...)
+	})
+
+	s.goSupervised(ctx, "worker:slo", tracker.Run)
+}
@@ -20,14 +20,35 @@ type SteeringRuleTrace struct {
 }
 
 func (s *Server) steeringForMessage(session *models.Session, msg *models.Message) (string, []SteeringRuleTrace) {
-	if s == nil || s.config == nil || !s.config.Steering.Enabled {
+	if s == nil || s.config == nil || msg == nil {
 		return "", nil
 	}
+	steering := s.currentSteering()
+	if !steering.Enabled {
+		return "", nil
+	}
+
+	prompt, traces := evaluateSteeringRules(steering.Rules, session, msg, time.Now(), false)
+	s.recordSteeringHits(traces)
+	return prompt, traces
+}
+
+// DryRunSteering evaluates every rule against msg without recording hits.
+// It returns the directives that would be injected and a trace for every
+// rule: matched rules first in injection order, then the rules that did not
+// fire with the reason they were skipped. The steering.enabled flag is not
+// consulted so rules can be tested before they are switched on.
+func DryRunSteering(cfg config.SteeringConfig, session *models.Session, msg *models.Message, now time.Time) (string, []SteeringRuleTrace) {
 	if msg == nil {
 		return "", nil
 	}
+	return evaluateSteeringRules(cfg.Rules, session, msg, now, true)
+}
 
-	now := time.Now()
+// evaluateSteeringRules matches rules against msg and joins the prompts of
+// the matching rules by priority. With includeSkipped, rules that did not
+// match are appended to the trace with Matched unset.
+func evaluateSteeringRules(rules []config.SteeringRule, session *models.Session, msg *models.Message, now time.Time, includeSkipped bool) (string, []SteeringRuleTrace) {
 	tags := mergeTagsFromMetadata(msg, session)
 
 	type match struct {
@@ -38,17 +59,32 @@ func (s *Server) steeringForMessage(session *models.Session, msg *models.Message
 	}
 
 	var matches []match
-	for i, rule := range s.config.Steering.Rules {
+	var skipped []SteeringRuleTrace
+	skip := func(rule config.SteeringRule, index int, reasons ...string) {
+		if !includeSkipped {
+			return
+		}
+		skipped = append(skipped, SteeringRuleTrace{
+			ID:       steeringRuleID(rule, index),
+			Name:     strings.TrimSpace(rule.Name),
+			Priority: rule.Priority,
+			Reasons:  reasons,
+		})
+	}
+	for i, rule := range rules {
 		if rule.Enabled != nil && !*rule.Enabled {
+			skip(rule, i, "disabled")
 			continue
 		}
 		prompt := strings.TrimSpace(rule.Prompt)
 		if prompt == "" {
+			skip(rule, i, "empty prompt")
 			continue
 		}
 
 		ok, reasons := matchSteeringRule(rule, session, msg, tags, now)
 		if !ok {
+			skip(rule, i, reasons...)
 			continue
 		}
 
@@ -69,7 +105,7 @@ func (s *Server) steeringForMessage(session *models.Session, msg *models.Message
 	}
 
 	if len(matches) == 0 {
-		return "", nil
+		return "", skipped
 	}
 
 	sort.SliceStable(matches, func(i, j int) bool {
@@ -80,13 +116,13 @@ func (s *Server) steeringForMessage(session *models.Session, msg *models.Message
 	})
 
 	prompts := make([]string, 0, len(matches))
-	traces := make([]SteeringRuleTrace, 0, len(matches))
+	traces := make([]SteeringRuleTrace, 0, len(matches)+len(skipped))
 	for _, m := range matches {
 		prompts = append(prompts, m.prompt)
 		traces = append(traces, m.trace)
 	}
 
-	return strings.Join(prompts, "\n"), traces
+	return strings.Join(prompts, "\n"), append(traces, skipped...)
 }
 
 func steeringRuleID(rule config.SteeringRule, index int) string {
@@ -0,0 +1,141 @@
+package gateway
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/haasonsaas/nexus/internal/config"
+	"github.com/haasonsaas/nexus/internal/observability"
+	"github.com/haasonsaas/nexus/internal/web"
+)
+
+// steeringRuleHits counts how often a rule was injected.
+type steeringRuleHits struct {
+	Hits    int64
+	LastHit time.Time
+}
+
+// currentSteering returns the live steering rules: the last reloaded set, or
+// the loaded config when nothing has been reloaded.
+func (s *Server) currentSteering() config.SteeringConfig {
+	s.steeringMu.RLock()
+	defer s.steeringMu.RUnlock()
+	if s.steeringRules != nil {
+		return *s.steeringRules
+	}
+	if s.config == nil {
+		return config.SteeringConfig{}
+	}
+	return s.config.Steering
+}
+
+// setSteering replaces the live steering rules. It reports whether they
+// changed.
+func (s *Server) setSteering(steering config.SteeringConfig) bool {
+	s.steeringMu.Lock()
+	defer s.steeringMu.Unlock()
+	current := s.steeringRules
+	if current == nil && s.config != nil {
+		current = &s.config.Steering
+	}
+	if current != nil && reflect.DeepEqual(*current, steering) {
+		return false
+	}
+	s.steeringRules = &steering
+	return true
+}
+
+// recordSteeringHits counts the matched rules in traces.
+func (s *Server) recordSteeringHits(traces []SteeringRuleTrace) {
+	if len(traces) == 0 {
+		return
+	}
+	now := time.Now()
+	metrics := observability.NewSteeringMetrics()
+	s.steeringMu.Lock()
+	defer s.steeringMu.Unlock()
+	if s.steeringHits == nil {
+		s.steeringHits = make(map[string]*steeringRuleHits)
+	}
+	for _, trace := range traces {
+		if !trace.Matched {
+			continue
+		}
+		hits := s.steeringHits[trace.ID]
+		if hits == nil {
+			hits = &steeringRuleHits{}
+			s.steeringHits[trace.ID] = hits
+		}
+		hits.Hits++
+		hits.LastHit = now
+		metrics.RecordHit(trace.ID)
+	}
+}
+
+// steeringStatus lists every configured rule with its hit count since start,
+// followed by rules that were hit but have since been removed.
+func (s *Server) steeringStatus() []web.SteeringRuleStatus {
+	steering := s.currentSteering()
+	s.steeringMu.RLock()
+	defer s.steeringMu.RUnlock()
+
+	seen := make(map[string]struct{}, len(steering.Rules))
+	statuses := make([]web.SteeringRuleStatus, 0, len(steering.Rules))
+	for i, rule := range steering.Rules {
+		id := steeringRuleID(rule, i)
+		seen[id] = struct{}{}
+		status := web.SteeringRuleStatus{
+			ID:       id,
+			Name:     strings.TrimSpace(rule.Name),
+			Enabled:  steering.Enabled && (rule.Enabled == nil || *rule.Enabled),
+			Priority: rule.Priority,
+		}
+		if hits := s.steeringHits[id]; hits != nil {
+			status.Hits = hits.Hits
+			status.LastHit = hits.LastHit
+		}
+		statuses = append(statuses, status)
+	}
+
+	var removed []web.SteeringRuleStatus
+	for id, hits := range s.steeringHits {
+		if _, ok := seen[id]; ok {
+			continue
+		}
+		removed = append(removed, web.SteeringRuleStatus{ID: id, Hits: hits.Hits, LastHit: hits.LastHit})
+	}
+	sort.Slice(removed, func(i, j int) bool { return removed[i].ID < removed[j].ID })
+	return append(statuses, removed...)
+}
+
+// startSteeringWatch reloads steering rules whenever the config file changes.
+// Only the steering section is applied; an invalid file keeps the previous
+// rules.
+func (s *Server) startSteeringWatch(ctx context.Context) {
+	if s == nil || s.config == nil || !s.config.Steering.Watch {
+		return
+	}
+	path := strings.TrimSpace(s.configPath)
+	if path == "" {
+		s.logger.Warn("steering.watch ignored: config path not configured (start with --config)")
+		return
+	}
+	if err := s.watchConfigFile(ctx, "worker:steering-watch", path, s.reloadSteering); err != nil {
+		s.logger.Warn("steering watch disabled", "path", path, "error", err)
+	}
+}
+
+// reloadSteering loads path and applies its steering section.
+func (s *Server) reloadSteering(path string) {
+	cfg, err := config.Load(path)
+	if err != nil {
+		s.logger.Warn("steering reload failed; keeping current rules", "path", path, "error", err)
+		return
+	}
+	if s.setSteering(cfg.Steering) {
+		s.logger.Info("steering rules reloaded", "rules", len(cfg.Steering.Rules), "enabled", cfg.Steering.Enabled)
+	}
+}
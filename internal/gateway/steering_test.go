@@ -1,6 +1,11 @@
 package gateway
 
 import (
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 	"time"
@@ -121,3 +126,85 @@ func TestSteeringForMessageHonorsTimeWindow(t *testing.T) {
 		t.Fatalf("expected time window rule to match, prompt=%q trace=%v", prompt, trace)
 	}
 }
+
+func TestDryRunSteeringListsSkippedRules(t *testing.T) {
+	disabled := false
+	steering := config.SteeringConfig{
+		Rules: []config.SteeringRule{
+			{ID: "slack", Prompt: "Use threads.", Channels: []string{"slack"}},
+			{ID: "renewals", Prompt: "Confirm next steps.", Contains: []string{"renewal"}, Priority: 5},
+			{ID: "off", Prompt: "Never.", Enabled: &disabled},
+			{ID: "discord", Prompt: "Be casual.", Channels: []string{"discord"}},
+		},
+	}
+	msg := &models.Message{Channel: models.ChannelSlack, Role: models.RoleUser, Content: "About the renewal"}
+
+	prompt, traces := DryRunSteering(steering, &models.Session{}, msg, time.Now())
+	if prompt != "Confirm next steps.\nUse threads." {
+		t.Fatalf("prompt = %q", prompt)
+	}
+	var got []string
+	for _, trace := range traces {
+		got = append(got, fmt.Sprintf("%s:%v:%s", trace.ID, trace.Matched, strings.Join(trace.Reasons, ",")))
+	}
+	want := []string{
+		"renewals:true:contains=renewal",
+		"slack:true:channel=slack",
+		"off:false:disabled",
+		"discord:false:channel mismatch",
+	}
+	if strings.Join(got, " ") != strings.Join(want, " ") {
+		t.Fatalf("traces = %v, want %v", got, want)
+	}
+}
+
+func TestSteeringHitsAndReload(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nexus.yaml")
+	writeSteeringConfig := func(prompt string) {
+		t.Helper()
+		raw := `
+version: 1
+llm:
+  default_provider: anthropic
+  providers:
+    anthropic: {}
+steering:
+  enabled: true
+  rules:
+    - id: greet
+      prompt: "` + prompt + `"
+      contains: ["hello"]
+`
+		if err := os.WriteFile(path, []byte(raw), 0o600); err != nil {
+			t.Fatalf("write config: %v", err)
+		}
+	}
+	writeSteeringConfig("Say hi back.")
+	cfg, err := config.Load(path)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	server := &Server{config: cfg, logger: slog.New(slog.NewTextHandler(io.Discard, nil))}
+	msg := &models.Message{Channel: models.ChannelSlack, Role: models.RoleUser, Content: "hello there"}
+
+	server.steeringForMessage(nil, msg)
+	server.steeringForMessage(nil, msg)
+	status := server.steeringStatus()
+	if len(status) != 1 || status[0].ID != "greet" || status[0].Hits != 2 || status[0].LastHit.IsZero() {
+		t.Fatalf("status = %+v", status)
+	}
+
+	writeSteeringConfig("Wave.")
+	server.reloadSteering(path)
+	if prompt, _ := server.steeringForMessage(nil, msg); prompt != "Wave." {
+		t.Fatalf("prompt after reload = %q", prompt)
+	}
+
+	if err := os.WriteFile(path, []byte("steering: ["), 0o600); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+	server.reloadSteering(path)
+	if prompt, _ := server.steeringForMessage(nil, msg); prompt != "Wave." {
+		t.Fatalf("invalid config should keep rules, got %q", prompt)
+	}
+}
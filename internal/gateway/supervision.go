@@ -0,0 +1,91 @@
+package gateway
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/haasonsaas/nexus/internal/config"
+	"github.com/haasonsaas/nexus/internal/observability"
+	"github.com/haasonsaas/nexus/internal/supervisor"
+)
+
+// sentryTimeout bounds how long a crash export may take.
+const sentryTimeout = 10 * time.Second
+
+// setupCrashReporting builds the restart policy for supervised components and
+// the optional Sentry exporter.
+func setupCrashReporting(cfg config.CrashReportingConfig) (supervisor.Config, *observability.SentryExporter, error) {
+	policy := supervisor.DefaultConfig()
+	if cfg.RestartBackoff > 0 {
+		policy.InitialBackoff = cfg.RestartBackoff
+	}
+	if cfg.MaxRestartBackoff > 0 {
+		policy.MaxBackoff = cfg.MaxRestartBackoff
+	}
+	policy.MaxRestarts = cfg.MaxRestarts
+
+	dsn := strings.TrimSpace(cfg.Sentry.DSN)
+	if dsn == "" {
+		return policy, nil, nil
+	}
+	exporter, err := observability.NewSentryExporter(dsn, cfg.Sentry.Environment, cfg.Sentry.Release)
+	if err != nil {
+		return policy, nil, err
+	}
+	return policy, exporter, nil
+}
+
+// installCrashHandler routes recovered panics from every supervised component
+// to this server's logs, metrics, diagnostics, and Sentry.
+func (s *Server) installCrashHandler() {
+	if s.restoreCrashHandler != nil {
+		return
+	}
+	s.restoreCrashHandler = supervisor.SetHandler(s.handleCrash)
+}
+
+// handleCrash records a recovered panic.
+func (s *Server) handleCrash(crash supervisor.Crash) {
+	s.logger.Error("component panicked",
+		"component", crash.Component,
+		"panic", crash.Panic,
+		"restart", crash.Restart,
+		"stack", crash.Stack)
+	observability.NewCrashMetrics().RecordCrash(crash.Component, crash.Restart > 0)
+
+	event := &observability.ComponentCrashEvent{
+		Component: crash.Component,
+		Panic:     crash.Panic,
+		Stack:     crash.Stack,
+		Restart:   crash.Restart,
+	}
+	observability.EmitComponentCrash(event)
+
+	if s.sentryExporter == nil {
+		return
+	}
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), sentryTimeout)
+		defer cancel()
+		if err := s.sentryExporter.CaptureCrash(ctx, event); err != nil {
+			s.logger.Warn("failed to export crash to sentry", "component", crash.Component, "error", err)
+		}
+	}()
+}
+
+// goSupervised runs fn in a goroutine tracked by s.wg and restarts it with
+// backoff if it panics.
+func (s *Server) goSupervised(ctx context.Context, component string, fn func(context.Context)) {
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		err := supervisor.Run(ctx, component, s.supervisorConfig, func(ctx context.Context) error {
+			fn(ctx)
+			return nil
+		})
+		if err != nil && ctx.Err() == nil {
+			s.logger.Error("component stopped after repeated crashes", "component", component, "error", err)
+		}
+	}()
+}
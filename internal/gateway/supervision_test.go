@@ -0,0 +1,65 @@
+package gateway
+
+import (
+	"context"
+	"log/slog"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/haasonsaas/nexus/internal/config"
+	"github.com/haasonsaas/nexus/internal/supervisor"
+)
+
+func TestSetupCrashReporting(t *testing.T) {
+	policy, exporter, err := setupCrashReporting(config.CrashReportingConfig{
+		RestartBackoff:    2 * time.Second,
+		MaxRestartBackoff: 30 * time.Second,
+		MaxRestarts:       3,
+	})
+	if err != nil {
+		t.Fatalf("setupCrashReporting() error = %v", err)
+	}
+	if exporter != nil {
+		t.Fatal("expected no exporter without a DSN")
+	}
+	if policy.InitialBackoff != 2*time.Second || policy.MaxBackoff != 30*time.Second || policy.MaxRestarts != 3 {
+		t.Fatalf("unexpected policy: %+v", policy)
+	}
+
+	if _, _, err := setupCrashReporting(config.CrashReportingConfig{
+		Sentry: config.SentryConfig{DSN: "https://host/1"},
+	}); err == nil {
+		t.Fatal("expected error for DSN without a key")
+	}
+}
+
+func TestGoSupervisedRestartsPanickingWorker(t *testing.T) {
+	s := &Server{
+		logger:           slog.Default(),
+		supervisorConfig: supervisor.Config{InitialBackoff: time.Millisecond},
+	}
+	s.installCrashHandler()
+	defer s.restoreCrashHandler()
+
+	var calls atomic.Int32
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	done := make(chan struct{})
+	s.goSupervised(ctx, "worker:test", func(ctx context.Context) {
+		if calls.Add(1) < 3 {
+			panic("worker failed")
+		}
+		close(done)
+	})
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("worker was not restarted")
+	}
+	s.wg.Wait()
+	if got := calls.Load(); got != 3 {
+		t.Fatalf("expected 3 runs, got %d", got)
+	}
+}
@@ -0,0 +1,53 @@
+package gateway
+
+import (
+	"context"
+	"strings"
+
+	"github.com/haasonsaas/nexus/internal/messages"
+	"github.com/haasonsaas/nexus/pkg/models"
+)
+
+// identityLocaleKey is the identity metadata key holding a user's preferred
+// language for system messages.
+const identityLocaleKey = "locale"
+
+// systemMessage renders a catalog message in the language of the sender of
+// msg.
+func (s *Server) systemMessage(ctx context.Context, msg *models.Message, key messages.Key, data messages.Data) string {
+	return s.messageCatalog.Render(key, s.messageLocale(ctx, msg), data)
+}
+
+// messageLocale picks the language for system messages replying to msg: the
+// sender's identity profile, then the locale reported by the channel or
+// detected in the message, then the channel's configured locale. An empty
+// result leaves the choice to the catalog's default.
+func (s *Server) messageLocale(ctx context.Context, msg *models.Message) string {
+	if msg == nil {
+		return ""
+	}
+	if locale := s.identityLocale(ctx, msg); locale != "" {
+		return locale
+	}
+	for _, key := range []string{"locale", metaDetectedLanguage} {
+		if raw, ok := msg.Metadata[key].(string); ok {
+			if locale := messages.NormalizeLocale(raw); locale != "" {
+				return locale
+			}
+		}
+	}
+	return s.channelLocale(msg.Channel)
+}
+
+// channelLocale returns the configured locale for channel, if any.
+func (s *Server) channelLocale(channel models.ChannelType) string {
+	if s.config == nil {
+		return ""
+	}
+	for name, locale := range s.config.Messages.Channels {
+		if strings.EqualFold(name, string(channel)) {
+			return messages.NormalizeLocale(locale)
+		}
+	}
+	return ""
+}
@@ -0,0 +1,41 @@
+package gateway
+
+import (
+	"context"
+	"testing"
+
+	"github.com/haasonsaas/nexus/internal/config"
+	"github.com/haasonsaas/nexus/internal/messages"
+	"github.com/haasonsaas/nexus/pkg/models"
+)
+
+func TestSystemMessageLocale(t *testing.T) {
+	cfg := &config.Config{Messages: config.MessagesConfig{
+		Channels: map[string]string{"telegram": "de"},
+		Templates: map[string]map[string]string{
+			string(messages.CommandFailed): {"en": "Acme bot: {{.Error}}"},
+		},
+	}}
+	catalog, err := messages.New(cfg.Messages.Locale, cfg.Messages.Templates)
+	if err != nil {
+		t.Fatalf("messages.New: %v", err)
+	}
+	server := &Server{config: cfg, messageCatalog: catalog}
+	ctx := context.Background()
+	data := messages.Data{"Error": "boom"}
+
+	tests := []struct {
+		name string
+		msg  *models.Message
+		want string
+	}{
+		{"channel locale", &models.Message{Channel: models.ChannelTelegram}, "Befehl fehlgeschlagen: boom"},
+		{"sender locale", &models.Message{Channel: models.ChannelTelegram, Metadata: map[string]any{"locale": "es-MX"}}, "El comando falló: boom"},
+		{"override", &models.Message{Channel: models.ChannelSlack}, "Acme bot: boom"},
+	}
+	for _, tt := range tests {
+		if got := server.systemMessage(ctx, tt.msg, messages.CommandFailed, data); got != tt.want {
+			t.Errorf("%s: got %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
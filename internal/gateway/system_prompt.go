@@ -18,6 +18,7 @@ type SystemPromptOptions struct {
 	Heartbeat           string
 	AttentionSummary    string
 	SteeringDirectives  string
+	ReplyLanguage       string
 	WorkspaceSections   []PromptSection
 	MemoryFlush         string
 	SkillContent        []SkillSection
@@ -120,6 +121,10 @@ func buildSystemPrompt(cfg *config.Config, opts SystemPromptOptions) string {
 		lines = append(lines, fmt.Sprintf("Steering directives:\n%s", steering))
 	}
 
+	if lang := strings.TrimSpace(opts.ReplyLanguage); lang != "" {
+		lines = append(lines, replyLanguageDirective(lang))
+	}
+
 	if flush := strings.TrimSpace(opts.MemoryFlush); flush != "" {
 		lines = append(lines, fmt.Sprintf("Memory flush reminder:\n%s", flush))
 	}
@@ -39,6 +39,10 @@ func (s *Server) systemPromptForMessage(ctx context.Context, session *models.Ses
 		opts.SteeringDirectives = steeringDirectives
 	}
 
+	if lang, _ := s.replyLanguage(ctx, session, msg); lang != "" {
+		opts.ReplyLanguage = lang
+	}
+
 	if overrides := s.experimentOverrides(session, msg); overrides.SystemPrompt != "" {
 		opts.ExperimentPrompt = overrides.SystemPrompt
 	}
@@ -106,8 +110,12 @@ func (s *Server) linkUnderstandingContext(ctx context.Context, session *models.S
 		AgentID:   session.AgentID,
 	}
 
+	linkConfig := toLinkToolsConfig(s.config.Tools.Links)
+	if s.linkSummarizer != nil {
+		linkConfig.Summarizer = s.linkSummarizer
+	}
 	result, err := links.RunLinkUnderstanding(ctx, links.RunnerParams{
-		Config:  toLinkToolsConfig(s.config.Tools.Links),
+		Config:  linkConfig,
 		Context: msgCtx,
 		Message: msg.Content,
 	})
@@ -117,6 +125,13 @@ func (s *Server) linkUnderstandingContext(ctx context.Context, session *models.S
 		}
 		return ""
 	}
+	if len(result.OutputsByURL) > 0 {
+		// Annotate the message so the summaries are stored with it.
+		if msg.Metadata == nil {
+			msg.Metadata = map[string]any{}
+		}
+		msg.Metadata["link_summaries"] = result.OutputsByURL
+	}
 
 	outputs := make([]string, 0, len(result.Outputs))
 	for _, out := range result.Outputs {
@@ -150,6 +165,11 @@ func toLinkToolsConfig(cfg config.LinksConfig) *links.LinkToolsConfig {
 			Command:        entry.Command,
 			Args:           entry.Args,
 			TimeoutSeconds: entry.TimeoutSeconds,
+			Provider:       entry.Provider,
+			Model:          entry.Model,
+			Prompt:         entry.Prompt,
+			MaxInputChars:  entry.MaxInputChars,
+			MaxTokens:      entry.MaxTokens,
 		})
 	}
 	var scope *links.ScopeConfig
@@ -176,32 +196,21 @@ func (s *Server) attentionSummary() string {
 	if !s.config.Attention.Enabled || !s.config.Attention.InjectInPrompt {
 		return ""
 	}
-	items := s.attentionFeed.Active()
-	if len(items) == 0 {
-		return ""
-	}
 	limit := s.config.Attention.MaxItems
 	if limit <= 0 {
 		limit = 5
 	}
-	if len(items) > limit {
-		items = items[:limit]
+	items := s.attentionFeed.List(attention.FeedOptions{
+		Statuses: activeAttentionStatuses,
+		SortBy:   attention.SortByPriorityDesc,
+		Limit:    limit,
+	})
+	if len(items) == 0 {
+		return ""
 	}
 	lines := make([]string, 0, len(items))
 	for _, item := range items {
-		title := strings.TrimSpace(item.Title)
-		if title == "" {
-			title = strings.TrimSpace(item.Preview)
-		}
-		if title == "" {
-			title = "Untitled"
-		}
-		lines = append(lines, fmt.Sprintf("- [%s/%s] %s (id: %s)",
-			strings.ToUpper(string(item.Channel)),
-			attentionPriorityLabel(item.Priority),
-			title,
-			item.ID,
-		))
+		lines = append(lines, "- "+attentionItemLine(item))
 	}
 	return strings.Join(lines, "\n")
 }
@@ -0,0 +1,40 @@
+package gateway
+
+import (
+	"strconv"
+
+	"github.com/haasonsaas/nexus/internal/config"
+	"github.com/haasonsaas/nexus/pkg/models"
+)
+
+// telegramTopicRule returns the channels.telegram.topics rule for msg: the
+// rule for its forum topic, else the chat-wide rule (topic_id 0), else nil.
+func (s *Server) telegramTopicRule(msg *models.Message) *config.TelegramTopicConfig {
+	if s == nil || s.config == nil || msg == nil || msg.Channel != models.ChannelTelegram || msg.Metadata == nil {
+		return nil
+	}
+	topics := s.config.Channels.Telegram.Topics
+	if len(topics) == 0 {
+		return nil
+	}
+	chatID, err := strconv.ParseInt(stringifyID(msg.Metadata["chat_id"]), 10, 64)
+	if err != nil {
+		return nil
+	}
+	topicID, _ := strconv.Atoi(stringifyID(msg.Metadata["telegram_topic_id"]))
+
+	var chatRule *config.TelegramTopicConfig
+	for i := range topics {
+		rule := &topics[i]
+		if rule.ChatID != chatID {
+			continue
+		}
+		if topicID != 0 && rule.TopicID == topicID {
+			return rule
+		}
+		if rule.TopicID == 0 && chatRule == nil {
+			chatRule = rule
+		}
+	}
+	return chatRule
+}
@@ -0,0 +1,53 @@
+package gateway
+
+import (
+	"testing"
+
+	"github.com/haasonsaas/nexus/internal/config"
+	"github.com/haasonsaas/nexus/pkg/models"
+)
+
+func TestTelegramTopicRule(t *testing.T) {
+	server := &Server{config: &config.Config{}}
+	server.config.Channels.Telegram.Topics = []config.TelegramTopicConfig{
+		{ChatID: -1001, TopicID: 0, AgentID: "community"},
+		{ChatID: -1001, TopicID: 7, AgentID: "ops"},
+		{ChatID: -1001, TopicID: 9, Disabled: true},
+	}
+	message := func(chatID int64, topicID any) *models.Message {
+		metadata := map[string]any{"chat_id": chatID}
+		if topicID != nil {
+			metadata["telegram_topic_id"] = topicID
+		}
+		return &models.Message{Channel: models.ChannelTelegram, Metadata: metadata}
+	}
+
+	tests := []struct {
+		name      string
+		msg       *models.Message
+		wantAgent string
+		disabled  bool
+		none      bool
+	}{
+		{name: "topic rule", msg: message(-1001, 7), wantAgent: "ops"},
+		{name: "disabled topic", msg: message(-1001, 9), disabled: true},
+		{name: "chat-wide rule", msg: message(-1001, 1), wantAgent: "community"},
+		{name: "no topic", msg: message(-1001, nil), wantAgent: "community"},
+		{name: "other chat", msg: message(-1002, 7), none: true},
+		{name: "other channel", msg: &models.Message{Channel: models.ChannelSlack, Metadata: map[string]any{"chat_id": int64(-1001)}}, none: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule := server.telegramTopicRule(tt.msg)
+			if tt.none {
+				if rule != nil {
+					t.Fatalf("expected no rule, got %+v", rule)
+				}
+				return
+			}
+			if rule == nil || rule.Disabled != tt.disabled || rule.AgentID != tt.wantAgent {
+				t.Fatalf("telegramTopicRule() = %+v", rule)
+			}
+		})
+	}
+}
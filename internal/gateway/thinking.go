@@ -0,0 +1,56 @@
+package gateway
+
+import (
+	"context"
+
+	"github.com/haasonsaas/nexus/internal/agent"
+	"github.com/haasonsaas/nexus/pkg/models"
+)
+
+// thinkingBudgetKey is the session metadata key holding the extended
+// thinking budget set with /think.
+const thinkingBudgetKey = "thinking_budget"
+
+// sessionThinkingBudget returns the /think budget of session, or 0 when
+// thinking is off.
+func sessionThinkingBudget(session *models.Session) int {
+	if session == nil || session.Metadata == nil {
+		return 0
+	}
+	switch value := session.Metadata[thinkingBudgetKey].(type) {
+	case int:
+		return value
+	case int64:
+		return int(value)
+	case float64:
+		return int(value)
+	}
+	return 0
+}
+
+// setSessionThinkingBudget stores a /think budget on session; 0 turns
+// thinking off.
+func setSessionThinkingBudget(session *models.Session, budget int) {
+	if budget <= 0 {
+		delete(session.Metadata, thinkingBudgetKey)
+		return
+	}
+	if session.Metadata == nil {
+		session.Metadata = map[string]any{}
+	}
+	session.Metadata[thinkingBudgetKey] = budget
+}
+
+// withThinking requests extended thinking for a run. A "thinking" level in
+// the message metadata, as set by webhooks, beats the session's /think
+// budget; "off" disables thinking for the run.
+func withThinking(ctx context.Context, session *models.Session, msg *models.Message) context.Context {
+	if msg != nil && msg.Metadata != nil {
+		if raw, ok := msg.Metadata["thinking"].(string); ok {
+			if level, ok := agent.ParseThinkingLevel(raw); ok {
+				return agent.WithThinkingLevel(ctx, level)
+			}
+		}
+	}
+	return agent.WithThinkingBudget(ctx, sessionThinkingBudget(session))
+}
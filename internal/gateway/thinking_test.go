@@ -0,0 +1,46 @@
+package gateway
+
+import (
+	"context"
+	"testing"
+
+	"github.com/haasonsaas/nexus/internal/agent"
+	"github.com/haasonsaas/nexus/pkg/models"
+)
+
+func TestSessionThinkingBudget(t *testing.T) {
+	session := &models.Session{}
+	setSessionThinkingBudget(session, 4096)
+	if got := sessionThinkingBudget(session); got != 4096 {
+		t.Fatalf("budget = %d, want 4096", got)
+	}
+
+	// Budgets read back from JSON-backed stores arrive as float64.
+	session.Metadata[thinkingBudgetKey] = float64(2048)
+	if got := sessionThinkingBudget(session); got != 2048 {
+		t.Fatalf("budget = %d, want 2048", got)
+	}
+
+	setSessionThinkingBudget(session, 0)
+	if got := sessionThinkingBudget(session); got != 0 {
+		t.Fatalf("budget after off = %d, want 0", got)
+	}
+}
+
+func TestWithThinkingMetadataOverridesSession(t *testing.T) {
+	session := &models.Session{Metadata: map[string]any{thinkingBudgetKey: 4096}}
+
+	ctx := withThinking(context.Background(), session, &models.Message{})
+	if got := agent.ThinkingBudgetFromContext(ctx); got != 4096 {
+		t.Fatalf("session budget = %d, want 4096", got)
+	}
+
+	msg := &models.Message{Metadata: map[string]any{"thinking": "off"}}
+	ctx = withThinking(context.Background(), session, msg)
+	if got := agent.ThinkingBudgetFromContext(ctx); got != 0 {
+		t.Fatalf("budget with thinking off = %d, want 0", got)
+	}
+	if level := agent.ThinkingLevelFromContext(ctx); level != agent.ThinkingOff {
+		t.Fatalf("level = %v, want off", level)
+	}
+}
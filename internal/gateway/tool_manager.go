@@ -11,26 +11,31 @@ import (
 	"sync"
 
 	"github.com/haasonsaas/nexus/internal/agent"
+	"github.com/haasonsaas/nexus/internal/artifacts"
 	"github.com/haasonsaas/nexus/internal/attention"
 	"github.com/haasonsaas/nexus/internal/canvas"
 	"github.com/haasonsaas/nexus/internal/channels"
 	"github.com/haasonsaas/nexus/internal/commands"
 	"github.com/haasonsaas/nexus/internal/config"
 	"github.com/haasonsaas/nexus/internal/cron"
+	"github.com/haasonsaas/nexus/internal/datetime"
 	"github.com/haasonsaas/nexus/internal/edge"
 	"github.com/haasonsaas/nexus/internal/infra"
 	"github.com/haasonsaas/nexus/internal/jobs"
 	"github.com/haasonsaas/nexus/internal/mcp"
 	"github.com/haasonsaas/nexus/internal/memory"
+	"github.com/haasonsaas/nexus/internal/memory/review"
 	modelcatalog "github.com/haasonsaas/nexus/internal/models"
 	ragindex "github.com/haasonsaas/nexus/internal/rag/index"
 	"github.com/haasonsaas/nexus/internal/sessions"
 	"github.com/haasonsaas/nexus/internal/skills"
 	"github.com/haasonsaas/nexus/internal/tasks"
+	broadcasttools "github.com/haasonsaas/nexus/internal/tools/broadcast"
 	"github.com/haasonsaas/nexus/internal/tools/browser"
 	canvastools "github.com/haasonsaas/nexus/internal/tools/canvas"
 	"github.com/haasonsaas/nexus/internal/tools/computeruse"
 	crontools "github.com/haasonsaas/nexus/internal/tools/cron"
+	emailtools "github.com/haasonsaas/nexus/internal/tools/email"
 	exectools "github.com/haasonsaas/nexus/internal/tools/exec"
 	"github.com/haasonsaas/nexus/internal/tools/facts"
 	"github.com/haasonsaas/nexus/internal/tools/files"
@@ -41,6 +46,8 @@ import (
 	"github.com/haasonsaas/nexus/internal/tools/message"
 	modelstools "github.com/haasonsaas/nexus/internal/tools/models"
 	nodestools "github.com/haasonsaas/nexus/internal/tools/nodes"
+	ocrtools "github.com/haasonsaas/nexus/internal/tools/ocr"
+	plantools "github.com/haasonsaas/nexus/internal/tools/plan"
 	"github.com/haasonsaas/nexus/internal/tools/policy"
 	ragtools "github.com/haasonsaas/nexus/internal/tools/rag"
 	"github.com/haasonsaas/nexus/internal/tools/reminders"
@@ -50,6 +57,7 @@ import (
 	sessiontools "github.com/haasonsaas/nexus/internal/tools/sessions"
 	systemtools "github.com/haasonsaas/nexus/internal/tools/system"
 	"github.com/haasonsaas/nexus/internal/tools/vectormemory"
+	watchtools "github.com/haasonsaas/nexus/internal/tools/watch"
 	"github.com/haasonsaas/nexus/internal/tools/websearch"
 	"github.com/haasonsaas/nexus/pkg/models"
 )
@@ -80,6 +88,7 @@ type ToolManager struct {
 	taskStore      tasks.Store
 	ragManager     *ragindex.Manager
 	vectorMemory   *memory.Manager
+	memoryReview   *review.Queue
 
 	// Managed resources
 	browserPool        *browser.Pool
@@ -112,6 +121,7 @@ type ToolManagerConfig struct {
 	TaskStore      tasks.Store
 	RAGManager     *ragindex.Manager
 	VectorMemory   *memory.Manager
+	MemoryReview   *review.Queue
 	Logger         *slog.Logger
 }
 
@@ -143,6 +153,7 @@ func NewToolManager(cfg ToolManagerConfig) *ToolManager {
 		taskStore:       cfg.TaskStore,
 		ragManager:      cfg.RAGManager,
 		vectorMemory:    cfg.VectorMemory,
+		memoryReview:    cfg.MemoryReview,
 		registeredTools: make([]string, 0),
 		mcpTools:        make([]string, 0),
 		toolSummaries:   make([]models.ToolSummary, 0),
@@ -324,7 +335,10 @@ func (m *ToolManager) RegisterTools(ctx context.Context, runtime *agent.Runtime)
 	}
 	cfg := m.config
 
-	fileCfg := files.Config{Workspace: cfg.Workspace.Path}
+	fileCfg := files.Config{Workspace: cfg.Workspace.Path, History: newWorkspaceHistory(cfg)}
+	if m.memoryReview != nil {
+		fileCfg.Review = m.memoryReview
+	}
 	m.registerCoreTool(runtime, files.NewReadTool(fileCfg))
 	m.registerCoreTool(runtime, files.NewWriteTool(fileCfg))
 	m.registerCoreTool(runtime, files.NewEditTool(fileCfg))
@@ -345,6 +359,16 @@ func (m *ToolManager) RegisterTools(ctx context.Context, runtime *agent.Runtime)
 	if m.channels != nil {
 		m.registerCoreTool(runtime, message.NewTool("message", m.channels, m.sessionStore, cfg.Session.DefaultAgentID))
 		m.registerCoreTool(runtime, message.NewTool("send_message", m.channels, m.sessionStore, cfg.Session.DefaultAgentID))
+		if m.gateway != nil {
+			if broadcaster := m.gateway.outboundBroadcaster(); broadcaster != nil {
+				m.registerCoreTool(runtime, broadcasttools.NewTool(broadcaster))
+			}
+		} else if len(cfg.Gateway.Broadcast.Outbound.Lists) > 0 {
+			m.registerCoreTool(runtime, broadcasttools.NewTool(broadcasttools.New(m.channels, cfg.Gateway.Broadcast.Outbound)))
+		}
+		if composer := emailComposer(cfg, m.channels); composer != nil {
+			m.registerCoreTool(runtime, emailtools.NewComposeTool(composer))
+		}
 	}
 
 	// Register sandbox tool
@@ -366,7 +390,11 @@ func (m *ToolManager) RegisterTools(ctx context.Context, runtime *agent.Runtime)
 		m.registerWebSearchTool(runtime)
 	}
 	if cfg.Tools.WebFetch.Enabled {
-		m.registerCoreTool(runtime, websearch.NewWebFetchTool(&websearch.FetchConfig{MaxChars: cfg.Tools.WebFetch.MaxChars}))
+		var fetchOpts []websearch.WebFetchOption
+		if m.browserPool != nil {
+			fetchOpts = append(fetchOpts, websearch.WithRenderer(m.browserPool))
+		}
+		m.registerCoreTool(runtime, websearch.NewWebFetchTool(&websearch.FetchConfig{MaxChars: cfg.Tools.WebFetch.MaxChars}, fetchOpts...))
 	}
 
 	// Register memory search tool
@@ -392,7 +420,12 @@ func (m *ToolManager) RegisterTools(ctx context.Context, runtime *agent.Runtime)
 	}
 	if m.vectorMemory != nil {
 		m.registerCoreTool(runtime, vectormemory.NewSearchTool(m.vectorMemory, &cfg.VectorMemory))
-		m.registerCoreTool(runtime, vectormemory.NewWriteTool(m.vectorMemory, &cfg.VectorMemory))
+		writeTool := vectormemory.NewWriteTool(m.vectorMemory, &cfg.VectorMemory)
+		if m.memoryReview != nil {
+			writeTool.WithReview(m.memoryReview)
+		}
+		m.registerCoreTool(runtime, writeTool)
+		m.registerCoreTool(runtime, vectormemory.NewForgetTool(m.vectorMemory, &cfg.VectorMemory))
 	}
 
 	// Register RAG tools if enabled
@@ -416,6 +449,16 @@ func (m *ToolManager) RegisterTools(ctx context.Context, runtime *agent.Runtime)
 		m.registerCoreTool(runtime, facts.NewExtractTool(cfg.Tools.FactExtract.MaxFacts))
 	}
 
+	// Register the plan tool for long multi-step tasks
+	if cfg.Tools.Plan.Enabled {
+		m.registerCoreTool(runtime, plantools.NewTool())
+	}
+
+	// Register OCR over stored artifacts
+	if m.gateway != nil && m.gateway.textExtractor != nil && m.gateway.artifactRepo != nil {
+		m.registerCoreTool(runtime, ocrtools.NewImageTool(m.gateway.artifactRepo, m.gateway.textExtractor))
+	}
+
 	// Register skill-provided tools
 	if m.skillsManager != nil {
 		for _, skill := range m.skillsManager.ListEligible() {
@@ -430,17 +473,30 @@ func (m *ToolManager) RegisterTools(ctx context.Context, runtime *agent.Runtime)
 		m.registerCoreTool(runtime, jobtools.NewStatusTool(m.jobStore))
 	}
 
+	// Register scheduled web page watches
+	if m.gateway != nil && m.gateway.webWatch != nil {
+		m.registerCoreTool(runtime, watchtools.NewURLTool(m.gateway.webWatch))
+	}
+
 	// Register reminder tools if task store is available
 	if m.taskStore != nil && cfg.Tasks.Enabled {
 		m.registerCoreTool(runtime, reminders.NewSetTool(m.taskStore))
 		m.registerCoreTool(runtime, reminders.NewCancelTool(m.taskStore))
 		m.registerCoreTool(runtime, reminders.NewListTool(m.taskStore))
+		timezone := func(context.Context, models.ChannelType, string) string {
+			return datetime.ResolveUserTimezone(cfg.User.Timezone)
+		}
+		if m.gateway != nil {
+			timezone = m.gateway.userTimezone
+		}
+		m.registerCoreTool(runtime, reminders.NewScheduleMessageTool(m.taskStore, timezone))
 	}
 
 	// Register attention feed tools
 	if m.attentionFeed != nil {
 		m.registerCoreTool(runtime, attention.NewListAttentionTool(m.attentionFeed))
 		m.registerCoreTool(runtime, attention.NewGetAttentionTool(m.attentionFeed))
+		m.registerCoreTool(runtime, attention.NewAddAttentionTool(m.attentionFeed))
 		m.registerCoreTool(runtime, attention.NewHandleAttentionTool(m.attentionFeed))
 		m.registerCoreTool(runtime, attention.NewSnoozeAttentionTool(m.attentionFeed))
 		m.registerCoreTool(runtime, attention.NewStatsAttentionTool(m.attentionFeed))
@@ -486,6 +542,30 @@ func (m *ToolManager) RegisterTools(ctx context.Context, runtime *agent.Runtime)
 		m.registerCoreTool(runtime, servicenow.NewUpdateTicketTool(snowClient))
 	}
 
+	// Register the SQL query tool if enabled
+	if cfg.Tools.SQLQuery.Enabled {
+		tool, err := newSQLQueryTool(cfg.Tools.SQLQuery)
+		if err != nil {
+			return err
+		}
+		m.registerCoreTool(runtime, tool)
+	}
+
+	// Register spreadsheet tools if enabled
+	if cfg.Tools.Spreadsheets.Enabled {
+		var artifactRepo artifacts.Repository
+		if m.gateway != nil {
+			artifactRepo = m.gateway.artifactRepo
+		}
+		tools, err := newSpreadsheetTools(cfg.Tools.Spreadsheets, artifactRepo)
+		if err != nil {
+			return err
+		}
+		for _, tool := range tools {
+			m.registerCoreTool(runtime, tool)
+		}
+	}
+
 	// Register MCP tools
 	if cfg.MCP.Enabled && m.mcpManager != nil {
 		mcpTools := mcp.RegisterToolsWithRegistrar(runtime, m.mcpManager, m.policyResolver)
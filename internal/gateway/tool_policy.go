@@ -1,6 +1,7 @@
 package gateway
 
 import (
+	"context"
 	"encoding/json"
 	"strings"
 
@@ -128,7 +129,7 @@ func toolPolicyRuleMatches(msg *models.Message, peerID string, rule config.ToolP
 	return false
 }
 
-func (s *Server) resolveToolPolicy(agentModel *models.Agent, msg *models.Message) *policy.Policy {
+func (s *Server) resolveToolPolicy(ctx context.Context, agentModel *models.Agent, msg *models.Message) *policy.Policy {
 	var global *policy.Policy
 	if s != nil && s.config != nil {
 		global = toolPolicyFromConfig(s.config.Tools.Policies, msg, s.extractPeerID(msg))
@@ -144,6 +145,12 @@ func (s *Server) resolveToolPolicy(agentModel *models.Agent, msg *models.Message
 	if msgPolicy := toolPolicyFromMessage(msg); msgPolicy != nil {
 		policies = append(policies, msgPolicy)
 	}
+	if s != nil && !s.senderMayBroadcast(ctx, msg) {
+		if len(policies) == 0 {
+			return &policy.Policy{Profile: policy.ProfileFull, Deny: []string{broadcastToolName}}
+		}
+		policies = append(policies, &policy.Policy{Deny: []string{broadcastToolName}})
+	}
 	if len(policies) == 0 {
 		return nil
 	}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"sync"
 
+	"github.com/haasonsaas/nexus/internal/agent"
 	"github.com/haasonsaas/nexus/internal/observability"
 	"github.com/haasonsaas/nexus/pkg/models"
 	"go.opentelemetry.io/otel/trace"
@@ -57,6 +58,9 @@ func (p *TracingPlugin) OnEvent(ctx context.Context, e models.AgentEvent) {
 func (p *TracingPlugin) startIterSpan(ctx context.Context, runID string, iter int) {
 	_, span := p.tracer.Start(ctx, "llm.request", observability.SpanOptions{Kind: trace.SpanKindClient})
 	p.tracer.SetAttributes(span, "run_id", runID, "iteration", iter)
+	for key, value := range agent.TraceTagsFromContext(ctx) {
+		p.tracer.SetAttributes(span, key, value)
+	}
 
 	p.mu.Lock()
 	defer p.mu.Unlock()
@@ -84,6 +88,9 @@ func (p *TracingPlugin) finishIterSpan(ctx context.Context, e models.AgentEvent)
 		if e.Stream.OutputTokens > 0 {
 			p.tracer.SetAttributes(span, "llm.output_tokens", e.Stream.OutputTokens)
 		}
+		if e.Stream.ReasoningTokens > 0 {
+			p.tracer.SetAttributes(span, "llm.reasoning_tokens", e.Stream.ReasoningTokens)
+		}
 	}
 	span.End()
 }
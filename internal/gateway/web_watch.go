@@ -0,0 +1,131 @@
+package gateway
+
+import (
+	"context"
+	"time"
+
+	"github.com/haasonsaas/nexus/internal/cluster"
+	"github.com/haasonsaas/nexus/internal/delivery"
+	"github.com/haasonsaas/nexus/internal/webwatch"
+	"github.com/haasonsaas/nexus/pkg/models"
+)
+
+// metaWebWatchID marks session messages posted by a web watch.
+const metaWebWatchID = "web_watch_id"
+
+// startWebWatch loads registered watches from the session database and runs
+// the worker that checks due pages and notifies their sessions.
+func (s *Server) startWebWatch(ctx context.Context) {
+	if s == nil || s.config == nil || s.webWatch == nil {
+		return
+	}
+	tick := s.config.Tools.WebWatch.CheckInterval
+	if tick <= 0 {
+		tick = time.Minute
+	}
+
+	var store webwatch.Store
+	db, err := s.clusterDB()
+	if err != nil {
+		s.logger.Warn("web watch: persistence disabled (session store init failed)", "error", err)
+	} else if db != nil {
+		dbStore, err := webwatch.NewDBStore(db)
+		if err == nil {
+			err = s.webWatch.Load(ctx, dbStore)
+		}
+		if err != nil {
+			s.logger.Warn("web watch: persistence disabled (run `nexus migrate up`?)", "error", err)
+		} else {
+			store = dbStore
+			s.webWatch.OnStoreError(func(err error) {
+				s.logger.Warn("web watch: store write failed", "error", err)
+			})
+		}
+	}
+
+	s.goSupervised(ctx, "worker:web-watch", func(ctx context.Context) {
+		ticker := time.NewTicker(tick)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case now := <-ticker.C:
+				if !s.isClusterLeader(cluster.LeaseWebWatch) {
+					continue
+				}
+				if store != nil {
+					// Watches may have been added through other replicas.
+					if err := s.webWatch.Load(ctx, store); err != nil {
+						s.logger.Warn("web watch: reload failed", "error", err)
+					}
+				}
+				for _, event := range s.webWatch.CheckDue(ctx, now) {
+					s.notifyWebWatch(ctx, event)
+				}
+			}
+		}
+	})
+}
+
+// notifyWebWatch posts a triggered watch to the session that registered it
+// and records the notice in the session history so the agent sees it on the
+// next turn.
+func (s *Server) notifyWebWatch(ctx context.Context, event webwatch.Event) {
+	watch := event.Watch
+	text := event.Message()
+
+	var session *models.Session
+	if s.sessions != nil {
+		if found, err := s.sessions.Get(ctx, watch.SessionID); err == nil {
+			session = found
+		}
+	}
+	if session == nil {
+		// The session is gone; fall back to the conversation it came from.
+		if _, err := s.sendProactive(ctx, delivery.KindWatch, models.ChannelType(watch.Channel), watch.ChannelID, text); err != nil {
+			s.logger.Warn("web watch: notification failed", "watch_id", watch.ID, "error", err)
+		}
+		return
+	}
+
+	// Group notifications reply in the thread the watch was set up in;
+	// direct ones follow the user when presence-aware delivery is on.
+	template := s.lastUserMessage(ctx, session.ID)
+	if template != nil && (!s.config.Delivery.Enabled || conversationTypeForMessage(template) == "group") {
+		s.sendImmediateReply(ctx, session, template, text)
+	} else if _, err := s.sendProactive(ctx, delivery.KindWatch, session.Channel, session.ChannelID, text); err != nil {
+		s.logger.Warn("web watch: notification failed", "watch_id", watch.ID, "error", err)
+		return
+	}
+
+	notice := &models.Message{
+		SessionID: session.ID,
+		Channel:   session.Channel,
+		ChannelID: session.ChannelID,
+		Direction: models.DirectionOutbound,
+		Role:      models.RoleAssistant,
+		Content:   text,
+		Metadata:  map[string]any{metaWebWatchID: watch.ID},
+		CreatedAt: time.Now(),
+	}
+	if err := s.sessions.AppendMessage(ctx, session.ID, notice); err != nil {
+		s.logger.Debug("web watch: failed to record notification", "session_id", session.ID, "error", err)
+	}
+}
+
+// lastUserMessage returns the user's most recent message in the session,
+// which carries the channel metadata needed to reply in the same thread.
+func (s *Server) lastUserMessage(ctx context.Context, sessionID string) *models.Message {
+	history, err := s.sessions.GetHistory(ctx, sessionID, heartbeatHistoryWindow)
+	if err != nil {
+		return nil
+	}
+	for i := len(history) - 1; i >= 0; i-- {
+		msg := history[i]
+		if msg != nil && msg.Direction == models.DirectionInbound && msg.Role == models.RoleUser {
+			return msg
+		}
+	}
+	return nil
+}
@@ -0,0 +1,57 @@
+package gateway
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/haasonsaas/nexus/internal/config"
+	"github.com/haasonsaas/nexus/internal/webwatch"
+	"github.com/haasonsaas/nexus/pkg/models"
+)
+
+// webWatchStore is a heartbeatStore that also resolves its session by ID.
+type webWatchStore struct {
+	heartbeatStore
+}
+
+func (s webWatchStore) Get(ctx context.Context, id string) (*models.Session, error) {
+	if id == s.session.ID {
+		return s.session, nil
+	}
+	return nil, fmt.Errorf("session %s not found", id)
+}
+
+func TestNotifyWebWatchRepliesInOriginatingChat(t *testing.T) {
+	server, adapter, store := newHeartbeatServer(t, config.HeartbeatConfig{}, time.Now())
+	server.sessions = webWatchStore{heartbeatStore{store}}
+
+	watch := &webwatch.Watch{
+		ID:        "watch-1",
+		URL:       "https://shop.example.com/kettle",
+		Label:     "Kettle",
+		Condition: webwatch.ConditionPriceBelow,
+		Threshold: 50,
+		SessionID: "session-1",
+		Channel:   string(models.ChannelTelegram),
+	}
+	server.notifyWebWatch(context.Background(), webwatch.Event{Watch: watch, Current: "$45", Price: 45})
+
+	if len(adapter.messages) != 1 {
+		t.Fatalf("expected 1 notification, got %d", len(adapter.messages))
+	}
+	sent := adapter.messages[0]
+	if !strings.Contains(sent.Content, "Kettle") || !strings.Contains(sent.Content, "below 50") {
+		t.Fatalf("unexpected notification: %q", sent.Content)
+	}
+	if fmt.Sprint(sent.Metadata["chat_id"]) != "123" {
+		t.Fatalf("expected notification routed to the user's chat, got %+v", sent.Metadata)
+	}
+
+	recorded := store.messages[len(store.messages)-1]
+	if recorded.Metadata[metaWebWatchID] != "watch-1" || recorded.Content != sent.Content {
+		t.Fatalf("expected notification recorded in session history, got %+v", recorded)
+	}
+}
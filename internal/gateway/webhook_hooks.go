@@ -2,27 +2,37 @@
 package gateway
 
 import (
+	"bytes"
 	"context"
+	"crypto/hmac"
+	"crypto/sha256"
 	"crypto/subtle"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"log/slog"
+	"math"
 	"net/http"
+	"strconv"
 	"strings"
 	"sync"
+	"text/template"
 	"time"
 
 	"github.com/haasonsaas/nexus/internal/config"
+	"github.com/haasonsaas/nexus/internal/ratelimit"
+	"github.com/haasonsaas/nexus/internal/templates"
 )
 
 const (
-	defaultWebhookPath   = "/hooks"
-	defaultMaxBodyBytes  = 256 * 1024
-	webhookHandlerAgent  = "agent"
-	webhookHandlerWake   = "wake"
-	webhookHandlerCustom = "custom"
+	defaultWebhookPath     = "/hooks"
+	defaultMaxBodyBytes    = 256 * 1024
+	defaultSignatureHeader = "X-Hub-Signature-256"
+	webhookHandlerAgent    = "agent"
+	webhookHandlerWake     = "wake"
+	webhookHandlerCustom   = "custom"
 )
 
 // WebhookPayload represents the standard webhook request body.
@@ -99,16 +109,22 @@ type WebhookHooks struct {
 	handlers map[string]WebhookHandler
 	stats    *WebhookStats
 	logger   *slog.Logger
+
+	// limiters holds the rate limit bucket of each mapping with a limit,
+	// keyed by mapping index.
+	limiters map[int]*ratelimit.Bucket
 }
 
 // WebhookStats tracks webhook usage statistics.
 type WebhookStats struct {
-	mu             sync.Mutex
-	TotalRequests  int64            `json:"total_requests"`
-	TotalSuccesses int64            `json:"total_successes"`
-	TotalErrors    int64            `json:"total_errors"`
-	ByPath         map[string]int64 `json:"by_path"`
-	LastRequestAt  time.Time        `json:"last_request_at"`
+	mu               sync.Mutex
+	TotalRequests    int64            `json:"total_requests"`
+	TotalSuccesses   int64            `json:"total_successes"`
+	TotalErrors      int64            `json:"total_errors"`
+	TotalIgnored     int64            `json:"total_ignored"`
+	TotalRateLimited int64            `json:"total_rate_limited"`
+	ByPath           map[string]int64 `json:"by_path"`
+	LastRequestAt    time.Time        `json:"last_request_at"`
 }
 
 // NewWebhookHooks creates a new webhook hooks manager.
@@ -118,7 +134,30 @@ func NewWebhookHooks(config *config.WebhookHooksConfig) (*WebhookHooks, error) {
 	}
 
 	if strings.TrimSpace(config.Token) == "" {
-		return nil, fmt.Errorf("webhook hooks require a token")
+		if len(config.Mappings) == 0 {
+			return nil, fmt.Errorf("webhook hooks require a token")
+		}
+		for _, mapping := range config.Mappings {
+			if strings.TrimSpace(mapping.Token) == "" && strings.TrimSpace(mapping.Secret) == "" {
+				return nil, fmt.Errorf("webhook hook %q requires a token or secret", mapping.Path)
+			}
+		}
+	}
+
+	limiters := make(map[int]*ratelimit.Bucket)
+	for i, mapping := range config.Mappings {
+		if mapping.Template != "" {
+			if _, err := template.New("webhook").Funcs(templates.NewVariableEngine().FuncMap).Parse(mapping.Template); err != nil {
+				return nil, fmt.Errorf("webhook hook %q template: %w", mapping.Path, err)
+			}
+		}
+		if mapping.RateLimit > 0 {
+			limiters[i] = ratelimit.NewBucket(ratelimit.Config{
+				RequestsPerSecond: float64(mapping.RateLimit) / 60,
+				BurstSize:         mapping.RateLimit,
+				Enabled:           true,
+			})
+		}
 	}
 
 	// Normalize config
@@ -142,7 +181,8 @@ func NewWebhookHooks(config *config.WebhookHooksConfig) (*WebhookHooks, error) {
 		stats: &WebhookStats{
 			ByPath: make(map[string]int64),
 		},
-		logger: logger,
+		logger:   logger,
+		limiters: limiters,
 	}, nil
 }
 
@@ -167,16 +207,26 @@ func (h *WebhookHooks) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Extract and validate token
-	token := h.extractToken(r)
-	if !h.validateToken(token) {
-		h.respondError(w, http.StatusUnauthorized, "invalid token")
+	// Find matching mapping
+	path := strings.TrimPrefix(r.URL.Path, h.config.BasePath)
+	index, mapping := h.findMapping(path)
+
+	// Read the body first: signatures are computed over it
+	body, err := h.readBody(w, r)
+	if err != nil {
+		var maxErr *http.MaxBytesError
+		if errors.As(err, &maxErr) {
+			h.respondError(w, http.StatusRequestEntityTooLarge, "Request entity too large")
+			return
+		}
+		h.respondError(w, http.StatusBadRequest, err.Error())
 		return
 	}
 
-	// Find matching mapping
-	path := strings.TrimPrefix(r.URL.Path, h.config.BasePath)
-	mapping := h.findMapping(path)
+	if !h.authorize(r, mapping, body) {
+		h.respondError(w, http.StatusUnauthorized, "invalid token")
+		return
+	}
 	if mapping == nil {
 		h.respondError(w, http.StatusNotFound, "webhook not found")
 		return
@@ -187,17 +237,27 @@ func (h *WebhookHooks) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	h.stats.ByPath[path]++
 	h.stats.mu.Unlock()
 
-	// Read and parse body
-	payload, err := h.readPayload(w, r)
+	if limiter := h.limiters[index]; limiter != nil && !limiter.Allow() {
+		h.stats.mu.Lock()
+		h.stats.TotalRateLimited++
+		h.stats.mu.Unlock()
+		w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(limiter.WaitTime().Seconds()))))
+		h.respondError(w, http.StatusTooManyRequests, "rate limit exceeded")
+		return
+	}
+
+	payload, matched, err := h.buildPayload(r, mapping, body)
 	if err != nil {
-		var maxErr *http.MaxBytesError
-		if errors.As(err, &maxErr) {
-			h.respondError(w, http.StatusRequestEntityTooLarge, "Request entity too large")
-			return
-		}
 		h.respondError(w, http.StatusBadRequest, err.Error())
 		return
 	}
+	if !matched {
+		h.stats.mu.Lock()
+		h.stats.TotalIgnored++
+		h.stats.mu.Unlock()
+		h.respondJSON(w, http.StatusAccepted, &WebhookResponse{OK: true, Message: "ignored: request does not match"})
+		return
+	}
 
 	// Get handler
 	h.mu.RLock()
@@ -262,46 +322,109 @@ func (h *WebhookHooks) extractToken(r *http.Request) string {
 	return ""
 }
 
-// validateToken validates the provided token.
-func (h *WebhookHooks) validateToken(token string) bool {
-	return subtle.ConstantTimeCompare([]byte(token), []byte(h.config.Token)) == 1
+// authorize checks the request against the mapping's secret or token,
+// falling back to the gateway-wide token. Requests for unknown paths must
+// carry the gateway-wide token so paths cannot be probed.
+func (h *WebhookHooks) authorize(r *http.Request, mapping *config.WebhookHookMapping, body []byte) bool {
+	expected := h.config.Token
+	if mapping != nil {
+		if secret := strings.TrimSpace(mapping.Secret); secret != "" {
+			header := strings.TrimSpace(mapping.SignatureHeader)
+			if header == "" {
+				header = defaultSignatureHeader
+			}
+			return validSignature(secret, r.Header.Get(header), body)
+		}
+		if token := strings.TrimSpace(mapping.Token); token != "" {
+			expected = token
+		}
+	}
+	if strings.TrimSpace(expected) == "" {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(h.extractToken(r)), []byte(expected)) == 1
 }
 
-// findMapping finds the matching webhook mapping for a path.
-func (h *WebhookHooks) findMapping(path string) *config.WebhookHookMapping {
+// validSignature verifies a "sha256=<hex>" HMAC-SHA256 signature of body.
+func validSignature(secret, signature string, body []byte) bool {
+	signature = strings.TrimSpace(signature)
+	if !strings.HasPrefix(signature, "sha256=") {
+		return false
+	}
+	got, err := hex.DecodeString(strings.TrimPrefix(signature, "sha256="))
+	if err != nil {
+		return false
+	}
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return hmac.Equal(got, mac.Sum(nil))
+}
+
+// findMapping finds the matching webhook mapping for a path and returns
+// its index.
+func (h *WebhookHooks) findMapping(path string) (int, *config.WebhookHookMapping) {
 	path = strings.TrimPrefix(path, "/")
 	for i := range h.config.Mappings {
 		mappingPath := strings.TrimPrefix(h.config.Mappings[i].Path, "/")
 		if mappingPath == path {
-			return &h.config.Mappings[i]
+			return i, &h.config.Mappings[i]
 		}
 	}
-	return nil
+	return -1, nil
 }
 
-// readPayload reads and parses the request body.
-func (h *WebhookHooks) readPayload(w http.ResponseWriter, r *http.Request) (*WebhookPayload, error) {
-	// Limit body size
+// readBody reads the request body up to the configured limit.
+func (h *WebhookHooks) readBody(w http.ResponseWriter, r *http.Request) ([]byte, error) {
 	r.Body = http.MaxBytesReader(w, r.Body, h.config.MaxBodyBytes)
 
 	body, err := io.ReadAll(r.Body)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read body: %w", err)
 	}
+	return body, nil
+}
 
-	if len(body) == 0 {
-		return &WebhookPayload{}, nil
+// buildPayload turns the request into a payload for the mapping's handler.
+// Mappings with a template render the prompt from the raw JSON body; others
+// read the body as a WebhookPayload. It reports false when the request does
+// not satisfy the mapping's match rules.
+func (h *WebhookHooks) buildPayload(r *http.Request, mapping *config.WebhookHookMapping, body []byte) (*WebhookPayload, bool, error) {
+	var raw any
+	if mapping.Template != "" || len(mapping.Match) > 0 {
+		if len(bytes.TrimSpace(body)) > 0 {
+			if err := json.Unmarshal(body, &raw); err != nil {
+				return nil, false, fmt.Errorf("invalid JSON: %w", err)
+			}
+		}
+		if !webhookMatches(mapping.Match, r.Header, raw) {
+			return nil, false, nil
+		}
 	}
 
 	var payload WebhookPayload
-	if err := json.Unmarshal(body, &payload); err != nil {
-		return nil, fmt.Errorf("invalid JSON: %w", err)
+	if mapping.Template != "" {
+		message, err := renderWebhookTemplate(mapping, r, raw)
+		if err != nil {
+			return nil, false, err
+		}
+		if strings.TrimSpace(message) == "" {
+			return nil, false, errors.New("template rendered an empty prompt")
+		}
+		payload.Message = message
+		payload.Name = mapping.Name
+	} else if len(body) > 0 {
+		if err := json.Unmarshal(body, &payload); err != nil {
+			return nil, false, fmt.Errorf("invalid JSON: %w", err)
+		}
 	}
 
 	// Apply defaults
 	if payload.Name == "" {
 		payload.Name = "Webhook"
 	}
+	if payload.Channel == "" {
+		payload.Channel = strings.TrimSpace(mapping.Channel)
+	}
 	if payload.Channel == "" {
 		payload.Channel = "last"
 	}
@@ -309,7 +432,60 @@ func (h *WebhookHooks) readPayload(w http.ResponseWriter, r *http.Request) (*Web
 		payload.WakeMode = "now"
 	}
 
-	return &payload, nil
+	return &payload, true, nil
+}
+
+// renderWebhookTemplate renders the mapping's prompt template.
+func renderWebhookTemplate(mapping *config.WebhookHookMapping, r *http.Request, payload any) (string, error) {
+	headers := make(map[string]any, len(r.Header))
+	for name := range r.Header {
+		headers[name] = r.Header.Get(name)
+	}
+	query := make(map[string]any)
+	for name := range r.URL.Query() {
+		if name != "token" {
+			query[name] = r.URL.Query().Get(name)
+		}
+	}
+	rendered, err := templates.NewVariableEngine().Process(mapping.Template, map[string]any{
+		"payload": payload,
+		"headers": headers,
+		"query":   query,
+		"webhook": mapping.Name,
+	})
+	if err != nil {
+		return "", fmt.Errorf("render template: %w", err)
+	}
+	return rendered, nil
+}
+
+// webhookMatches reports whether every match rule holds. Keys are dotted
+// paths into the payload or "header:<Name>".
+func webhookMatches(rules map[string]string, headers http.Header, payload any) bool {
+	for key, want := range rules {
+		var got string
+		if name, ok := strings.CutPrefix(key, "header:"); ok {
+			got = headers.Get(name)
+		} else {
+			value := payload
+			for _, part := range strings.Split(key, ".") {
+				object, ok := value.(map[string]any)
+				if !ok {
+					value = nil
+					break
+				}
+				value = object[part]
+			}
+			if value == nil {
+				return false
+			}
+			got = fmt.Sprint(value)
+		}
+		if got != want {
+			return false
+		}
+	}
+	return true
 }
 
 // respondError sends an error response.
@@ -341,11 +517,13 @@ func (h *WebhookHooks) Stats() *WebhookStats {
 	}
 
 	return &WebhookStats{
-		TotalRequests:  h.stats.TotalRequests,
-		TotalSuccesses: h.stats.TotalSuccesses,
-		TotalErrors:    h.stats.TotalErrors,
-		ByPath:         byPath,
-		LastRequestAt:  h.stats.LastRequestAt,
+		TotalRequests:    h.stats.TotalRequests,
+		TotalSuccesses:   h.stats.TotalSuccesses,
+		TotalErrors:      h.stats.TotalErrors,
+		TotalIgnored:     h.stats.TotalIgnored,
+		TotalRateLimited: h.stats.TotalRateLimited,
+		ByPath:           byPath,
+		LastRequestAt:    h.stats.LastRequestAt,
 	}
 }
 
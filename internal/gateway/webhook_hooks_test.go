@@ -3,6 +3,9 @@ package gateway
 import (
 	"bytes"
 	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
@@ -85,3 +88,122 @@ func TestWebhookHooksAcceptsValidPayload(t *testing.T) {
 		t.Fatalf("payload.message = %#v, want %q", got, "hi")
 	}
 }
+
+func TestWebhookHooksTemplatedGitHubEndpoint(t *testing.T) {
+	t.Parallel()
+
+	hooks, err := NewWebhookHooks(&config.WebhookHooksConfig{
+		Enabled: true,
+		Mappings: []config.WebhookHookMapping{
+			{
+				Path:      "github",
+				Name:      "github-issues",
+				Handler:   webhookHandlerCustom,
+				Channel:   "slack",
+				ChannelID: "C123",
+				Secret:    "s3cret",
+				Template:  "Summarize issue #{{.payload.issue.number}}: {{.payload.issue.title}}",
+				Match: map[string]string{
+					"header:X-GitHub-Event": "issues",
+					"action":                "opened",
+				},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("NewWebhookHooks: %v", err)
+	}
+
+	var got *WebhookPayload
+	hooks.RegisterHandler(webhookHandlerCustom, WebhookHandlerFunc(func(ctx context.Context, payload *WebhookPayload, mapping *config.WebhookHookMapping) (*WebhookResponse, error) {
+		got = payload
+		return &WebhookResponse{OK: true}, nil
+	}))
+
+	send := func(body, signature string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/hooks/github", bytes.NewReader([]byte(body)))
+		req.Header.Set("X-GitHub-Event", "issues")
+		if signature != "" {
+			req.Header.Set("X-Hub-Signature-256", signature)
+		}
+		rec := httptest.NewRecorder()
+		hooks.ServeHTTP(rec, req)
+		return rec
+	}
+	sign := func(body string) string {
+		mac := hmac.New(sha256.New, []byte("s3cret"))
+		mac.Write([]byte(body))
+		return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+	}
+
+	opened := `{"action":"opened","issue":{"number":42,"title":"Crash on start"}}`
+	if rec := send(opened, "sha256=00"); rec.Code != http.StatusUnauthorized {
+		t.Fatalf("bad signature status = %d, want %d", rec.Code, http.StatusUnauthorized)
+	}
+
+	if rec := send(opened, sign(opened)); rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d: %s", rec.Code, http.StatusOK, rec.Body.String())
+	}
+	if got == nil || got.Message != "Summarize issue #42: Crash on start" {
+		t.Fatalf("payload = %#v", got)
+	}
+	if got.Channel != "slack" {
+		t.Errorf("channel = %q, want slack", got.Channel)
+	}
+
+	got = nil
+	closed := `{"action":"closed","issue":{"number":42}}`
+	rec := send(closed, sign(closed))
+	if rec.Code != http.StatusAccepted || got != nil {
+		t.Fatalf("non-matching request: status = %d, handled = %v", rec.Code, got != nil)
+	}
+}
+
+func TestWebhookHooksRateLimit(t *testing.T) {
+	t.Parallel()
+
+	hooks, err := NewWebhookHooks(&config.WebhookHooksConfig{
+		Enabled: true,
+		Mappings: []config.WebhookHookMapping{
+			{
+				Path:      "alerts",
+				Handler:   webhookHandlerCustom,
+				Token:     "alerts-token",
+				RateLimit: 2,
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("NewWebhookHooks: %v", err)
+	}
+	hooks.RegisterHandler(webhookHandlerCustom, WebhookHandlerFunc(func(ctx context.Context, payload *WebhookPayload, mapping *config.WebhookHookMapping) (*WebhookResponse, error) {
+		return &WebhookResponse{OK: true}, nil
+	}))
+
+	var codes []int
+	for range 3 {
+		req := httptest.NewRequest(http.MethodPost, "/hooks/alerts", bytes.NewReader([]byte(`{"message":"disk full"}`)))
+		req.Header.Set("Authorization", "Bearer alerts-token")
+		rec := httptest.NewRecorder()
+		hooks.ServeHTTP(rec, req)
+		codes = append(codes, rec.Code)
+	}
+	if codes[0] != http.StatusOK || codes[1] != http.StatusOK || codes[2] != http.StatusTooManyRequests {
+		t.Fatalf("status codes = %v, want [200 200 429]", codes)
+	}
+	if hooks.Stats().TotalRateLimited != 1 {
+		t.Errorf("TotalRateLimited = %d, want 1", hooks.Stats().TotalRateLimited)
+	}
+}
+
+func TestWebhookHooksRequireTokenPerMapping(t *testing.T) {
+	t.Parallel()
+
+	_, err := NewWebhookHooks(&config.WebhookHooksConfig{
+		Enabled:  true,
+		Mappings: []config.WebhookHookMapping{{Path: "open", Handler: webhookHandlerAgent}},
+	})
+	if err == nil {
+		t.Fatal("expected an error for a mapping without any authentication")
+	}
+}
@@ -0,0 +1,34 @@
+package gateway
+
+import (
+	"context"
+
+	"github.com/haasonsaas/nexus/internal/agent"
+	"github.com/haasonsaas/nexus/internal/config"
+	"github.com/haasonsaas/nexus/internal/hooks"
+	"github.com/haasonsaas/nexus/internal/workspace"
+)
+
+// newWorkspaceHistory returns the version history of the workspace bootstrap
+// files for the file tools, or nil when workspace.history is disabled. Every
+// version the agent writes fires the workspace.file_changed hook event.
+func newWorkspaceHistory(cfg *config.Config) *workspace.History {
+	history := workspace.NewHistory(cfg)
+	history.SetOnChange(func(ctx context.Context, name string, version workspace.FileVersion) {
+		if version.Source == workspace.SourceExternal {
+			return
+		}
+		event := hooks.NewEvent(hooks.EventWorkspaceFileChanged, name).
+			WithContext("file", name).
+			WithContext("version", version.Version).
+			WithContext("tool", version.Source).
+			WithContext("size", version.Size)
+		if session := agent.SessionFromContext(ctx); session != nil {
+			event.WithSession(session.Key).
+				WithChannel(session.ChannelID, session.Channel).
+				WithContext("agent_id", session.AgentID)
+		}
+		hooks.TriggerAsync(context.WithoutCancel(ctx), event)
+	})
+	return history
+}
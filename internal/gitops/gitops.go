@@ -0,0 +1,225 @@
+// Package gitops reads the Nexus config from a git repository, so teams can
+// manage it through pull requests, and reports how a local config drifted
+// from it.
+package gitops
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/haasonsaas/nexus/internal/config"
+	"github.com/haasonsaas/nexus/internal/profile"
+	"gopkg.in/yaml.v3"
+)
+
+// Source is a config file in a git repository.
+type Source struct {
+	// Repo is the repository URL, in any form git fetch accepts.
+	Repo string
+	// Ref is the branch, tag, or commit to read. Empty means the remote's
+	// default branch.
+	Ref string
+	// Path is the config file relative to the repository root.
+	Path string
+}
+
+// ParseSource parses "<repo>#<path>", such as
+// "https://github.com/acme/infra.git#nexus/prod.yaml".
+func ParseSource(spec string) (Source, error) {
+	spec = strings.TrimSpace(spec)
+	idx := strings.LastIndex(spec, "#")
+	if idx <= 0 || idx == len(spec)-1 {
+		return Source{}, fmt.Errorf("git source %q must look like <repo>#<path>", spec)
+	}
+	src := Source{Repo: spec[:idx], Path: spec[idx+1:]}
+	if err := src.validate(); err != nil {
+		return Source{}, err
+	}
+	return src, nil
+}
+
+// String formats the source as "<repo>#<path>", with "@<ref>" when a ref is
+// set.
+func (s Source) String() string {
+	out := s.Repo + "#" + s.Path
+	if s.Ref != "" {
+		out += "@" + s.Ref
+	}
+	return out
+}
+
+func (s Source) validate() error {
+	if strings.TrimSpace(s.Repo) == "" {
+		return errors.New("git source repo is required")
+	}
+	if strings.HasPrefix(s.Ref, "-") {
+		return fmt.Errorf("invalid git ref %q", s.Ref)
+	}
+	if _, err := cleanPath(s.Path); err != nil {
+		return err
+	}
+	return nil
+}
+
+// cleanPath returns p as a path relative to the repository root.
+func cleanPath(p string) (string, error) {
+	cleaned := path.Clean(filepath.ToSlash(strings.TrimSpace(p)))
+	if cleaned == "." || path.IsAbs(cleaned) || cleaned == ".." || strings.HasPrefix(cleaned, "../") {
+		return "", fmt.Errorf("git source path %q must be a file relative to the repository root", p)
+	}
+	return cleaned, nil
+}
+
+// Snapshot is the content of a config file at one commit.
+type Snapshot struct {
+	Source Source
+	// Commit is the full SHA the file was read at.
+	Commit string
+	Raw    []byte
+}
+
+// ShortCommit returns the abbreviated commit SHA.
+func (s *Snapshot) ShortCommit() string {
+	if len(s.Commit) > 12 {
+		return s.Commit[:12]
+	}
+	return s.Commit
+}
+
+// Fetcher fetches config files from git repositories into a local cache.
+// It shells out to git, so credentials come from the usual git config,
+// credential helpers, and SSH agent.
+type Fetcher struct {
+	cacheDir string
+	mu       sync.Mutex
+}
+
+// NewFetcher creates a fetcher that keeps one bare repository per remote
+// under cacheDir.
+func NewFetcher(cacheDir string) *Fetcher {
+	return &Fetcher{cacheDir: cacheDir}
+}
+
+// Fetch reads the source file at the current commit of its ref. Only that
+// commit is fetched, so following a branch stays cheap.
+func (f *Fetcher) Fetch(ctx context.Context, src Source) (*Snapshot, error) {
+	if err := src.validate(); err != nil {
+		return nil, err
+	}
+	file, err := cleanPath(src.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	sum := sha256.Sum256([]byte(src.Repo))
+	repoDir := filepath.Join(f.cacheDir, hex.EncodeToString(sum[:8]))
+	if _, err := os.Stat(filepath.Join(repoDir, "HEAD")); err != nil {
+		if err := os.MkdirAll(repoDir, 0o755); err != nil {
+			return nil, fmt.Errorf("create cache dir: %w", err)
+		}
+		if _, err := runGit(ctx, repoDir, "init", "--quiet", "--bare"); err != nil {
+			return nil, err
+		}
+	}
+
+	ref := src.Ref
+	if ref == "" {
+		ref = "HEAD"
+	}
+	if _, err := runGit(ctx, repoDir, "fetch", "--quiet", "--depth", "1", "--no-tags", src.Repo, ref); err != nil {
+		return nil, err
+	}
+	commit, err := runGit(ctx, repoDir, "rev-parse", "FETCH_HEAD")
+	if err != nil {
+		return nil, err
+	}
+	commit = bytes.TrimSpace(commit)
+	raw, err := runGit(ctx, repoDir, "show", string(commit)+":"+file)
+	if err != nil {
+		return nil, fmt.Errorf("read %s at %s: %w", file, commit, err)
+	}
+	return &Snapshot{Source: src, Commit: string(commit), Raw: raw}, nil
+}
+
+func runGit(ctx context.Context, dir string, args ...string) ([]byte, error) {
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), "GIT_TERMINAL_PROMPT=0")
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("git %s: %w: %s", args[0], err, strings.TrimSpace(stderr.String()))
+	}
+	return out, nil
+}
+
+// Load validates the snapshot the way the gateway would load it from a file
+// in dir, so includes and extends resolve as they will once it is written
+// there. Validation issues name the git source instead of the temporary
+// file.
+func (s *Snapshot) Load(dir string) (*config.Config, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	tmp, err := os.CreateTemp(dir, ".nexus-gitops-*.yaml")
+	if err != nil {
+		return nil, err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(s.Raw); err != nil {
+		_ = tmp.Close()
+		return nil, err
+	}
+	if err := tmp.Close(); err != nil {
+		return nil, err
+	}
+
+	cfg, err := config.Load(tmp.Name())
+	if err != nil {
+		var verr *config.ConfigValidationError
+		if errors.As(err, &verr) {
+			for i, issue := range verr.Issues {
+				verr.Issues[i] = strings.ReplaceAll(issue, tmp.Name(), s.Source.Path)
+			}
+		}
+		return nil, fmt.Errorf("%s at %s: %w", s.Source, s.ShortCommit(), err)
+	}
+	return cfg, nil
+}
+
+// Drift lists the keys that differ between a local config and the desired
+// config from git. Values under secret-looking keys are masked by
+// profile.FormatValue when printed.
+func Drift(local, desired []byte) ([]profile.Change, error) {
+	var localRaw, desiredRaw map[string]any
+	if err := yaml.Unmarshal(local, &localRaw); err != nil {
+		return nil, fmt.Errorf("parse local config: %w", err)
+	}
+	if err := yaml.Unmarshal(desired, &desiredRaw); err != nil {
+		return nil, fmt.Errorf("parse config from git: %w", err)
+	}
+	return profile.Diff(localRaw, desiredRaw), nil
+}
+
+// DriftPaths returns the paths of changes, for logging.
+func DriftPaths(changes []profile.Change) []string {
+	paths := make([]string, 0, len(changes))
+	for _, change := range changes {
+		paths = append(paths, change.Path)
+	}
+	return paths
+}
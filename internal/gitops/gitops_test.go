@@ -0,0 +1,147 @@
+package gitops
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestParseSource(t *testing.T) {
+	src, err := ParseSource("git@github.com:acme/infra.git#nexus/prod.yaml")
+	if err != nil {
+		t.Fatalf("ParseSource() error = %v", err)
+	}
+	if src.Repo != "git@github.com:acme/infra.git" || src.Path != "nexus/prod.yaml" {
+		t.Fatalf("unexpected source: %+v", src)
+	}
+
+	for _, spec := range []string{"", "repo", "repo#", "#nexus.yaml", "repo#/etc/nexus.yaml", "repo#../nexus.yaml", "repo#a/../../b"} {
+		if _, err := ParseSource(spec); err == nil {
+			t.Errorf("ParseSource(%q) expected error", spec)
+		}
+	}
+}
+
+// initRepo creates a repository with one commit per config and returns its
+// URL.
+func initRepo(t *testing.T, configs ...string) string {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	dir := t.TempDir()
+	git := func(args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		cmd.Env = append(os.Environ(),
+			"GIT_AUTHOR_NAME=test", "GIT_AUTHOR_EMAIL=test@example.com",
+			"GIT_COMMITTER_NAME=test", "GIT_COMMITTER_EMAIL=test@example.com")
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v: %s", args, err, out)
+		}
+	}
+	git("init", "--quiet", "--initial-branch", "main")
+	for _, content := range configs {
+		if err := os.MkdirAll(filepath.Join(dir, "deploy"), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, "deploy", "nexus.yaml"), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		git("add", "-A")
+		git("commit", "--quiet", "-m", "update config")
+	}
+	return "file://" + dir
+}
+
+const validConfig = `version: 1
+llm:
+  default_provider: anthropic
+  providers:
+    anthropic:
+      api_key: secret-one
+`
+
+func TestFetchFollowsRef(t *testing.T) {
+	repo := initRepo(t, validConfig)
+	fetcher := NewFetcher(t.TempDir())
+	src := Source{Repo: repo, Ref: "main", Path: "deploy/nexus.yaml"}
+
+	first, err := fetcher.Fetch(context.Background(), src)
+	if err != nil {
+		t.Fatalf("Fetch() error = %v", err)
+	}
+	if string(first.Raw) != validConfig || len(first.Commit) != 40 {
+		t.Fatalf("unexpected snapshot: %q at %q", first.Raw, first.Commit)
+	}
+
+	// A new commit on the ref is picked up by the next fetch.
+	dir := strings.TrimPrefix(repo, "file://")
+	updated := strings.Replace(validConfig, "secret-one", "secret-two", 1)
+	if err := os.WriteFile(filepath.Join(dir, "deploy", "nexus.yaml"), []byte(updated), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	cmd := exec.Command("git", "-c", "user.name=test", "-c", "user.email=test@example.com", "commit", "--quiet", "-am", "rotate")
+	cmd.Dir = dir
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("commit: %v: %s", err, out)
+	}
+	second, err := fetcher.Fetch(context.Background(), Source{Repo: repo, Path: "deploy/nexus.yaml"})
+	if err != nil {
+		t.Fatalf("Fetch() error = %v", err)
+	}
+	if second.Commit == first.Commit || string(second.Raw) != updated {
+		t.Fatalf("expected the new commit, got %q at %q", second.Raw, second.Commit)
+	}
+
+	if _, err := fetcher.Fetch(context.Background(), Source{Repo: repo, Path: "missing.yaml"}); err == nil {
+		t.Fatal("expected error for a missing file")
+	}
+}
+
+func TestSnapshotLoadNamesSource(t *testing.T) {
+	snapshot := &Snapshot{
+		Source: Source{Repo: "https://example.com/infra.git", Path: "deploy/nexus.yaml"},
+		Commit: "0123456789abcdef0123",
+		Raw:    []byte(validConfig + "gitops:\n  mode: sometimes\n"),
+	}
+	dir := t.TempDir()
+	_, err := snapshot.Load(dir)
+	if err == nil {
+		t.Fatal("expected validation error")
+	}
+	for _, want := range []string{"infra.git#deploy/nexus.yaml at 0123456789ab", "deploy/nexus.yaml:8: gitops.mode"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Fatalf("expected %q in:\n%v", want, err)
+		}
+	}
+	if entries, _ := os.ReadDir(dir); len(entries) != 0 {
+		t.Fatalf("temporary config left behind: %v", entries)
+	}
+
+	snapshot.Raw = []byte(validConfig)
+	if _, err := snapshot.Load(dir); err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+}
+
+func TestDrift(t *testing.T) {
+	desired := strings.Replace(validConfig, "secret-one", "secret-two", 1) + "server:\n  http_port: 9000\n"
+	changes, err := Drift([]byte(validConfig), []byte(desired))
+	if err != nil {
+		t.Fatalf("Drift() error = %v", err)
+	}
+	got := strings.Join(DriftPaths(changes), ",")
+	if got != "llm.providers.anthropic.api_key,server" {
+		t.Fatalf("drift paths = %q", got)
+	}
+
+	changes, err = Drift(nil, []byte(validConfig))
+	if err != nil || len(changes) != 2 {
+		t.Fatalf("drift from an empty config = %v, %v", changes, err)
+	}
+}
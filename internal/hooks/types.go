@@ -49,6 +49,17 @@ const (
 	EventGmailReceived EventType = "gmail.received"
 	EventGmailError    EventType = "gmail.error"
 
+	// Scheduled message events; the action is the task type
+	// ("reminder" or "scheduled_message")
+	EventScheduledMessageDelivered EventType = "scheduled_message.delivered"
+	EventScheduledMessageFailed    EventType = "scheduled_message.failed"
+
+	// Workspace events; the action is the changed file's name
+	EventWorkspaceFileChanged EventType = "workspace.file_changed"
+
+	// Memory events; the action is the memory file's path
+	EventMemoryConsolidated EventType = "memory.consolidated"
+
 	// Lifecycle events (compatibility; prefer gateway.* events)
 	EventStartup  EventType = "lifecycle.startup"
 	EventShutdown EventType = "lifecycle.shutdown"
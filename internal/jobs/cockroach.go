@@ -66,6 +66,15 @@ func NewCockroachStoreFromDSN(dsn string, config *CockroachConfig) (*CockroachSt
 	return &CockroachStore{db: db}, nil
 }
 
+// NewSQLiteStore creates a job store on an open, migrated SQLite database.
+// The queries are shared with the CockroachDB/Postgres store.
+func NewSQLiteStore(db *sql.DB) (*CockroachStore, error) {
+	if db == nil {
+		return nil, fmt.Errorf("db is required")
+	}
+	return &CockroachStore{db: db}, nil
+}
+
 // Close releases database resources.
 func (s *CockroachStore) Close() error {
 	if s == nil || s.db == nil {
@@ -0,0 +1,62 @@
+package jobs
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/haasonsaas/nexus/internal/sessions"
+	"github.com/haasonsaas/nexus/pkg/models"
+)
+
+func TestSQLiteStoreJobLifecycle(t *testing.T) {
+	ctx := context.Background()
+	db, err := sessions.OpenSQLite(ctx, "sqlite://"+filepath.Join(t.TempDir(), "nexus.db"))
+	if err != nil {
+		t.Fatalf("OpenSQLite() error = %v", err)
+	}
+	store, err := NewSQLiteStore(db)
+	if err != nil {
+		t.Fatalf("NewSQLiteStore() error = %v", err)
+	}
+	t.Cleanup(func() { _ = store.Close() })
+
+	now := time.Now()
+	job := &Job{ID: "job-1", ToolName: "exec", ToolCallID: "call-1", Status: StatusQueued, CreatedAt: now}
+	if err := store.Create(ctx, job); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	job.Status = StatusSucceeded
+	job.StartedAt = now
+	job.FinishedAt = now.Add(time.Second)
+	job.Result = &models.ToolResult{ToolCallID: "call-1", Content: "done"}
+	if err := store.Update(ctx, job); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+
+	got, err := store.Get(ctx, job.ID)
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if got == nil || got.Status != StatusSucceeded || got.Result == nil || got.Result.Content != "done" || !got.FinishedAt.Equal(job.FinishedAt) {
+		t.Fatalf("unexpected job %+v", got)
+	}
+	list, err := store.List(ctx, 10, 0)
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if len(list) != 1 {
+		t.Fatalf("List() = %d jobs, want 1", len(list))
+	}
+	if err := store.Cancel(ctx, job.ID); err != nil {
+		t.Fatalf("Cancel() error = %v", err)
+	}
+	pruned, err := store.Prune(ctx, -time.Minute)
+	if err != nil {
+		t.Fatalf("Prune() error = %v", err)
+	}
+	if pruned != 1 {
+		t.Fatalf("Prune() = %d, want 1", pruned)
+	}
+}
@@ -10,18 +10,33 @@ import (
 // Default timeout
 const DefaultLinkTimeoutSeconds = 30
 
+// Link model types.
+const (
+	ModelTypeCLI = "cli"
+	ModelTypeLLM = "llm"
+)
+
 // LinkUnderstandingResult is the output of link processing.
 type LinkUnderstandingResult struct {
 	URLs    []string
 	Outputs []string
+	// OutputsByURL maps each processed URL to its output.
+	OutputsByURL map[string]string
 }
 
 // LinkModelConfig defines a link processing model.
 type LinkModelConfig struct {
-	Type           string   `yaml:"type"` // "cli"
+	Type           string   `yaml:"type"` // "cli" or "llm"
 	Command        string   `yaml:"command"`
 	Args           []string `yaml:"args"`
 	TimeoutSeconds int      `yaml:"timeout_seconds"`
+
+	// LLM model fields.
+	Provider      string `yaml:"provider"`
+	Model         string `yaml:"model"`
+	Prompt        string `yaml:"prompt"`
+	MaxInputChars int    `yaml:"max_input_chars"`
+	MaxTokens     int    `yaml:"max_tokens"`
 }
 
 // ScopeConfig defines scope configuration for link understanding.
@@ -38,6 +53,9 @@ type LinkToolsConfig struct {
 	MaxLinks       int               `yaml:"max_links"`
 	TimeoutSeconds int               `yaml:"timeout_seconds"`
 	Scope          *ScopeConfig      `yaml:"scope"`
+
+	// Summarizer handles "llm" models; they are skipped when it is nil.
+	Summarizer Summarizer `yaml:"-"`
 }
 
 // MsgContext provides context about the message being processed.
@@ -84,16 +102,19 @@ func RunLinkUnderstanding(ctx context.Context, params RunnerParams) (*LinkUnders
 
 	// Process each link
 	var outputs []string
+	byURL := make(map[string]string, len(links))
 	for _, url := range links {
 		output, err := runLinkEntries(ctx, entries, url, params.Context, config)
 		if err == nil && output != "" {
 			outputs = append(outputs, output)
+			byURL[url] = output
 		}
 	}
 
 	return &LinkUnderstandingResult{
-		URLs:    links,
-		Outputs: outputs,
+		URLs:         links,
+		Outputs:      outputs,
+		OutputsByURL: byURL,
 	}, nil
 }
 
@@ -102,7 +123,13 @@ func runLinkEntries(ctx context.Context, entries []LinkModelConfig, url string,
 	var lastErr error
 
 	for _, entry := range entries {
-		output, err := runCliEntry(ctx, entry, url, msgCtx, config)
+		var output string
+		var err error
+		if entry.Type == ModelTypeLLM {
+			output, err = runLLMEntry(ctx, entry, url, config)
+		} else {
+			output, err = runCliEntry(ctx, entry, url, msgCtx, config)
+		}
 		if err != nil {
 			lastErr = err
 			continue
@@ -117,7 +144,7 @@ func runLinkEntries(ctx context.Context, entries []LinkModelConfig, url string,
 
 // runCliEntry executes a CLI command for link processing.
 func runCliEntry(ctx context.Context, entry LinkModelConfig, url string, msgCtx *MsgContext, config *LinkToolsConfig) (string, error) {
-	if entry.Type != "" && entry.Type != "cli" {
+	if entry.Type != "" && entry.Type != ModelTypeCLI {
 		return "", nil
 	}
 
@@ -143,6 +170,22 @@ func runCliEntry(ctx context.Context, entry LinkModelConfig, url string, msgCtx
 	return strings.TrimSpace(string(output)), nil
 }
 
+// runLLMEntry summarizes the page with the configured summarizer.
+func runLLMEntry(ctx context.Context, entry LinkModelConfig, url string, config *LinkToolsConfig) (string, error) {
+	if config.Summarizer == nil {
+		return "", nil
+	}
+	timeout := resolveTimeout(entry.TimeoutSeconds, config.TimeoutSeconds)
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	summary, err := config.Summarizer.Summarize(ctx, url, entry)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(summary), nil
+}
+
 // applyTemplateToArgs replaces template variables in args.
 func applyTemplateToArgs(args []string, msgCtx *MsgContext, url string) []string {
 	result := make([]string, len(args))
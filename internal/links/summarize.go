@@ -0,0 +1,172 @@
+package links
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Defaults for "llm" link models.
+const (
+	DefaultSummaryPrompt        = "Summarize the linked page in 2-4 sentences for an assistant that has not read it. Include the title or subject, the key facts, and anything the reader is asked to do. Do not add commentary."
+	DefaultSummaryMaxInputChars = 12000
+	DefaultSummaryMaxTokens     = 300
+	DefaultSummaryCacheTTL      = 24 * time.Hour
+	defaultSummaryCacheEntries  = 512
+)
+
+// Summarizer produces a summary of a link for an "llm" model entry.
+type Summarizer interface {
+	Summarize(ctx context.Context, url string, entry LinkModelConfig) (string, error)
+}
+
+// PageFetcher returns the readable text of a page.
+type PageFetcher interface {
+	FetchText(ctx context.Context, url string) (string, error)
+}
+
+// SummaryRequest is a single summarization call.
+type SummaryRequest struct {
+	Provider  string
+	Model     string
+	System    string
+	Prompt    string
+	MaxTokens int
+}
+
+// Completer runs a summarization request against a model.
+type Completer interface {
+	CompleteSummary(ctx context.Context, req SummaryRequest) (string, error)
+}
+
+// LLMSummarizer fetches a page, summarizes it with a model, and caches the
+// result by a hash of the URL and model settings.
+type LLMSummarizer struct {
+	fetcher   PageFetcher
+	completer Completer
+	ttl       time.Duration
+	now       func() time.Time
+
+	mu    sync.Mutex
+	cache map[string]cachedSummary
+}
+
+type cachedSummary struct {
+	summary string
+	expires time.Time
+}
+
+// NewLLMSummarizer creates a summarizer. A ttl <= 0 uses
+// DefaultSummaryCacheTTL.
+func NewLLMSummarizer(fetcher PageFetcher, completer Completer, ttl time.Duration) *LLMSummarizer {
+	if ttl <= 0 {
+		ttl = DefaultSummaryCacheTTL
+	}
+	return &LLMSummarizer{
+		fetcher:   fetcher,
+		completer: completer,
+		ttl:       ttl,
+		now:       time.Now,
+		cache:     make(map[string]cachedSummary),
+	}
+}
+
+// Summarize returns the cached summary for url or fetches and summarizes it.
+// Pages without readable text produce an empty summary.
+func (s *LLMSummarizer) Summarize(ctx context.Context, url string, entry LinkModelConfig) (string, error) {
+	if s == nil || s.fetcher == nil || s.completer == nil {
+		return "", fmt.Errorf("link summarizer not configured")
+	}
+	prompt := strings.TrimSpace(entry.Prompt)
+	if prompt == "" {
+		prompt = DefaultSummaryPrompt
+	}
+	key := summaryCacheKey(url, entry.Provider, entry.Model, prompt)
+	if summary, ok := s.cached(key); ok {
+		return summary, nil
+	}
+
+	text, err := s.fetcher.FetchText(ctx, url)
+	if err != nil {
+		return "", fmt.Errorf("fetch %s: %w", url, err)
+	}
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return "", nil
+	}
+	maxInput := entry.MaxInputChars
+	if maxInput <= 0 {
+		maxInput = DefaultSummaryMaxInputChars
+	}
+	if len(text) > maxInput {
+		text = text[:maxInput] + "...[truncated]"
+	}
+	maxTokens := entry.MaxTokens
+	if maxTokens <= 0 {
+		maxTokens = DefaultSummaryMaxTokens
+	}
+
+	summary, err := s.completer.CompleteSummary(ctx, SummaryRequest{
+		Provider:  entry.Provider,
+		Model:     entry.Model,
+		System:    prompt,
+		Prompt:    fmt.Sprintf("URL: %s\n\nPage content:\n%s", url, text),
+		MaxTokens: maxTokens,
+	})
+	if err != nil {
+		return "", fmt.Errorf("summarize %s: %w", url, err)
+	}
+	summary = strings.TrimSpace(summary)
+	if summary == "" {
+		return "", nil
+	}
+	summary = fmt.Sprintf("%s: %s", url, summary)
+	s.store(key, summary)
+	return summary, nil
+}
+
+func (s *LLMSummarizer) cached(key string) (string, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	entry, ok := s.cache[key]
+	if !ok {
+		return "", false
+	}
+	if s.now().After(entry.expires) {
+		delete(s.cache, key)
+		return "", false
+	}
+	return entry.summary, true
+}
+
+func (s *LLMSummarizer) store(key, summary string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := s.now()
+	if len(s.cache) >= defaultSummaryCacheEntries {
+		for k, entry := range s.cache {
+			if now.After(entry.expires) {
+				delete(s.cache, k)
+			}
+		}
+		// Still full: drop an arbitrary entry rather than grow unbounded.
+		for k := range s.cache {
+			if len(s.cache) < defaultSummaryCacheEntries {
+				break
+			}
+			delete(s.cache, k)
+		}
+	}
+	s.cache[key] = cachedSummary{summary: summary, expires: now.Add(s.ttl)}
+}
+
+// summaryCacheKey hashes the URL together with the settings that change the
+// summary, so editing the model or prompt does not serve stale results.
+func summaryCacheKey(url, provider, model, prompt string) string {
+	sum := sha256.Sum256([]byte(url + "\x00" + provider + "\x00" + model + "\x00" + prompt))
+	return hex.EncodeToString(sum[:])
+}
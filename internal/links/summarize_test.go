@@ -0,0 +1,124 @@
+package links
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+type fakeFetcher struct {
+	text  string
+	calls int
+}
+
+func (f *fakeFetcher) FetchText(ctx context.Context, url string) (string, error) {
+	f.calls++
+	return f.text, nil
+}
+
+type fakeCompleter struct {
+	reply string
+	last  SummaryRequest
+	calls int
+}
+
+func (c *fakeCompleter) CompleteSummary(ctx context.Context, req SummaryRequest) (string, error) {
+	c.calls++
+	c.last = req
+	return c.reply, nil
+}
+
+func TestRunLinkUnderstanding_LLMModel(t *testing.T) {
+	fetcher := &fakeFetcher{text: strings.Repeat("word ", 100)}
+	completer := &fakeCompleter{reply: " A page about words. "}
+	summarizer := NewLLMSummarizer(fetcher, completer, time.Hour)
+
+	config := &LinkToolsConfig{
+		Enabled: true,
+		Models: []LinkModelConfig{{
+			Type:          ModelTypeLLM,
+			Provider:      "openai",
+			Model:         "gpt-4o-mini",
+			MaxInputChars: 50,
+		}},
+		Summarizer: summarizer,
+	}
+	params := RunnerParams{Config: config, Message: "read https://example.com/a"}
+
+	result, err := RunLinkUnderstanding(context.Background(), params)
+	if err != nil {
+		t.Fatalf("RunLinkUnderstanding: %v", err)
+	}
+	want := "https://example.com/a: A page about words."
+	if len(result.Outputs) != 1 || result.Outputs[0] != want {
+		t.Fatalf("Outputs = %v", result.Outputs)
+	}
+	if result.OutputsByURL["https://example.com/a"] != want {
+		t.Fatalf("OutputsByURL = %v", result.OutputsByURL)
+	}
+	if completer.last.Model != "gpt-4o-mini" || completer.last.Provider != "openai" || completer.last.System != DefaultSummaryPrompt {
+		t.Fatalf("unexpected request %+v", completer.last)
+	}
+	if !strings.Contains(completer.last.Prompt, "...[truncated]") {
+		t.Fatalf("expected page content to be truncated, got %q", completer.last.Prompt)
+	}
+
+	if _, err := RunLinkUnderstanding(context.Background(), params); err != nil {
+		t.Fatalf("RunLinkUnderstanding: %v", err)
+	}
+	if fetcher.calls != 1 || completer.calls != 1 {
+		t.Fatalf("expected cached summary, got %d fetches and %d completions", fetcher.calls, completer.calls)
+	}
+}
+
+func TestLLMSummarizer_CacheExpiresAndKeysOnModel(t *testing.T) {
+	fetcher := &fakeFetcher{text: "content"}
+	completer := &fakeCompleter{reply: "summary"}
+	summarizer := NewLLMSummarizer(fetcher, completer, time.Minute)
+	now := time.Now()
+	summarizer.now = func() time.Time { return now }
+
+	entry := LinkModelConfig{Type: ModelTypeLLM, Model: "small"}
+	for i := 0; i < 2; i++ {
+		if _, err := summarizer.Summarize(context.Background(), "https://example.com", entry); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if completer.calls != 1 {
+		t.Fatalf("calls = %d, want 1", completer.calls)
+	}
+
+	entry.Model = "other"
+	if _, err := summarizer.Summarize(context.Background(), "https://example.com", entry); err != nil {
+		t.Fatal(err)
+	}
+	if completer.calls != 2 {
+		t.Fatalf("expected a model change to miss the cache, calls = %d", completer.calls)
+	}
+
+	now = now.Add(2 * time.Minute)
+	entry.Model = "small"
+	if _, err := summarizer.Summarize(context.Background(), "https://example.com", entry); err != nil {
+		t.Fatal(err)
+	}
+	if completer.calls != 3 {
+		t.Fatalf("expected expired entry to be refreshed, calls = %d", completer.calls)
+	}
+}
+
+func TestRunLinkUnderstanding_LLMModelWithoutSummarizer(t *testing.T) {
+	result, err := RunLinkUnderstanding(context.Background(), RunnerParams{
+		Config: &LinkToolsConfig{
+			Enabled: true,
+			Models:  []LinkModelConfig{{Type: ModelTypeLLM}},
+		},
+		Message: "https://example.com",
+	})
+	if err != nil {
+		t.Fatalf("RunLinkUnderstanding: %v", err)
+	}
+	if len(result.Outputs) != 0 {
+		t.Fatalf("expected no outputs, got %v", result.Outputs)
+	}
+}
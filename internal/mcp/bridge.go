@@ -76,6 +76,16 @@ func (b *ToolBridge) Schema() json.RawMessage {
 	return b.tool.InputSchema
 }
 
+// Idempotent reports whether the server marked the tool read-only or
+// idempotent, which makes failed calls safe to retry.
+func (b *ToolBridge) Idempotent() bool {
+	hints := b.tool.Annotations
+	if hints == nil {
+		return false
+	}
+	return (hints.ReadOnlyHint != nil && *hints.ReadOnlyHint) || (hints.IdempotentHint != nil && *hints.IdempotentHint)
+}
+
 // Execute invokes the MCP tool via the manager.
 func (b *ToolBridge) Execute(ctx context.Context, params json.RawMessage) (*agent.ToolResult, error) {
 	var arguments map[string]any
@@ -226,6 +226,29 @@ func TestToolBridgeSchema(t *testing.T) {
 	}
 }
 
+func TestToolBridgeIdempotent(t *testing.T) {
+	yes, no := true, false
+	tests := []struct {
+		name        string
+		annotations *MCPToolAnnotations
+		expected    bool
+	}{
+		{name: "no annotations", expected: false},
+		{name: "read only", annotations: &MCPToolAnnotations{ReadOnlyHint: &yes}, expected: true},
+		{name: "idempotent", annotations: &MCPToolAnnotations{ReadOnlyHint: &no, IdempotentHint: &yes}, expected: true},
+		{name: "destructive", annotations: &MCPToolAnnotations{DestructiveHint: &yes, IdempotentHint: &no}, expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bridge := NewToolBridge(nil, "server", &MCPTool{Name: "search", Annotations: tt.annotations}, "mcp_server_search")
+			if got := bridge.Idempotent(); got != tt.expected {
+				t.Errorf("expected Idempotent() = %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
 func TestResourceListBridgeName(t *testing.T) {
 	bridge := NewResourceListBridge(nil, "server", "mcp_server_resources_list")
 	if bridge.Name() != "mcp_server_resources_list" {
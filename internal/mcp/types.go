@@ -141,9 +141,19 @@ func containsShellMetachars(s string) bool {
 
 // MCPTool represents a tool exposed by an MCP server.
 type MCPTool struct {
-	Name        string          `json:"name"`
-	Description string          `json:"description,omitempty"`
-	InputSchema json.RawMessage `json:"inputSchema"`
+	Name        string              `json:"name"`
+	Description string              `json:"description,omitempty"`
+	InputSchema json.RawMessage     `json:"inputSchema"`
+	Annotations *MCPToolAnnotations `json:"annotations,omitempty"`
+}
+
+// MCPToolAnnotations are behavior hints a server declares for a tool.
+type MCPToolAnnotations struct {
+	Title           string `json:"title,omitempty"`
+	ReadOnlyHint    *bool  `json:"readOnlyHint,omitempty"`
+	DestructiveHint *bool  `json:"destructiveHint,omitempty"`
+	IdempotentHint  *bool  `json:"idempotentHint,omitempty"`
+	OpenWorldHint   *bool  `json:"openWorldHint,omitempty"`
 }
 
 // MCPResource represents a resource exposed by an MCP server.
@@ -88,6 +88,12 @@ type AggregatedContent struct {
 	// Text is aggregated text content (transcriptions, descriptions)
 	Text string `json:"text,omitempty"`
 
+	// Transcripts are the structured transcriptions of audio attachments
+	Transcripts []*Transcript `json:"transcripts,omitempty"`
+
+	// ImageText maps attachment IDs to the text OCR found in them
+	ImageText map[string]string `json:"image_text,omitempty"`
+
 	// Errors lists any processing errors
 	Errors []string `json:"errors,omitempty"`
 
@@ -133,9 +139,21 @@ func (a *Aggregator) Aggregate(ctx context.Context, attachments []*Attachment, o
 			}
 		}
 
+		if result.Transcript != nil {
+			content.Transcripts = append(content.Transcripts, result.Transcript)
+		}
 		if result.Transcription != "" {
 			textParts = append(textParts, fmt.Sprintf("[Transcription]: %s", result.Transcription))
 		}
+		if result.ExtractedText != "" {
+			if content.ImageText == nil {
+				content.ImageText = make(map[string]string)
+			}
+			if result.Attachment != nil {
+				content.ImageText[result.Attachment.ID] = result.ExtractedText
+			}
+			textParts = append(textParts, fmt.Sprintf("[Image text]: %s", result.ExtractedText))
+		}
 		if result.Description != "" {
 			textParts = append(textParts, fmt.Sprintf("[Description]: %s", result.Description))
 		}
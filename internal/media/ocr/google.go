@@ -0,0 +1,97 @@
+package ocr
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/haasonsaas/nexus/internal/media"
+)
+
+// DefaultGoogleVisionURL is the Google Cloud Vision API endpoint.
+const DefaultGoogleVisionURL = "https://vision.googleapis.com"
+
+// GoogleVision extracts text with the Google Cloud Vision TEXT_DETECTION API.
+type GoogleVision struct {
+	apiKey     string
+	baseURL    string
+	httpClient *http.Client
+}
+
+var _ media.TextExtractor = (*GoogleVision)(nil)
+
+// NewGoogleVision creates a Cloud Vision extractor.
+func NewGoogleVision(apiKey, baseURL string) (*GoogleVision, error) {
+	apiKey = strings.TrimSpace(apiKey)
+	if apiKey == "" {
+		return nil, fmt.Errorf("Google Cloud Vision API key is required")
+	}
+	baseURL = strings.TrimSuffix(strings.TrimSpace(baseURL), "/")
+	if baseURL == "" {
+		baseURL = DefaultGoogleVisionURL
+	}
+	return &GoogleVision{apiKey: apiKey, baseURL: baseURL, httpClient: &http.Client{}}, nil
+}
+
+// ExtractText sends the image inline and returns the full text annotation.
+func (g *GoogleVision) ExtractText(ctx context.Context, image io.Reader, _ string) (string, error) {
+	data, err := readImage(image)
+	if err != nil {
+		return "", err
+	}
+	payload, err := json.Marshal(map[string]any{
+		"requests": []map[string]any{{
+			"image":    map[string]string{"content": base64.StdEncoding.EncodeToString(data)},
+			"features": []map[string]string{{"type": "TEXT_DETECTION"}},
+		}},
+	})
+	if err != nil {
+		return "", err
+	}
+	endpoint := g.baseURL + "/v1/images:annotate?key=" + url.QueryEscape(g.apiKey)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(payload))
+	if err != nil {
+		return "", fmt.Errorf("create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := g.httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("vision request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 10*1024*1024))
+	if err != nil {
+		return "", fmt.Errorf("read vision response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("vision API error (status %d): %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	var decoded struct {
+		Responses []struct {
+			FullTextAnnotation struct {
+				Text string `json:"text"`
+			} `json:"fullTextAnnotation"`
+			Error *struct {
+				Message string `json:"message"`
+			} `json:"error"`
+		} `json:"responses"`
+	}
+	if err := json.Unmarshal(body, &decoded); err != nil {
+		return "", fmt.Errorf("decode vision response: %w", err)
+	}
+	if len(decoded.Responses) == 0 {
+		return "", nil
+	}
+	if e := decoded.Responses[0].Error; e != nil && e.Message != "" {
+		return "", fmt.Errorf("vision API error: %s", e.Message)
+	}
+	return decoded.Responses[0].FullTextAnnotation.Text, nil
+}
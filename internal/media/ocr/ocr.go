@@ -0,0 +1,156 @@
+// Package ocr extracts text from images using a local or hosted OCR engine.
+package ocr
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/haasonsaas/nexus/internal/media"
+)
+
+// Supported providers.
+const (
+	ProviderTesseract = "tesseract"
+	ProviderGoogle    = "google"
+)
+
+// Config holds configuration for OCR providers.
+type Config struct {
+	// Provider is the OCR engine to use ("tesseract" or "google")
+	Provider string
+
+	// Languages are tesseract language codes, e.g. "eng" or "deu" (default: eng).
+	// The hosted provider detects the language itself.
+	Languages []string
+
+	// TesseractPath is the tesseract binary (default: tesseract)
+	TesseractPath string
+
+	// APIKey is the Google Cloud Vision API key
+	APIKey string
+
+	// BaseURL overrides the Google Cloud Vision endpoint
+	BaseURL string
+
+	// Timeout bounds a single extraction (default: 30s)
+	Timeout time.Duration
+
+	// Logger is an optional structured logger
+	Logger *slog.Logger
+}
+
+// applyDefaults sets default values for unset configuration fields.
+func (c *Config) applyDefaults() {
+	c.Provider = strings.ToLower(strings.TrimSpace(c.Provider))
+	if c.Provider == "" {
+		c.Provider = ProviderTesseract
+	}
+	if len(c.Languages) == 0 {
+		c.Languages = []string{"eng"}
+	}
+	if c.Timeout <= 0 {
+		c.Timeout = 30 * time.Second
+	}
+	if c.Logger == nil {
+		c.Logger = slog.Default()
+	}
+}
+
+// Extractor wraps an OCR provider with logging and a per-call timeout.
+type Extractor struct {
+	provider media.TextExtractor
+	name     string
+	timeout  time.Duration
+	logger   *slog.Logger
+}
+
+// Verify that Extractor implements media.TextExtractor.
+var _ media.TextExtractor = (*Extractor)(nil)
+
+// New creates an Extractor with the given configuration. It returns an error
+// if the provider is not supported or its requirements are missing.
+func New(cfg Config) (*Extractor, error) {
+	cfg.applyDefaults()
+
+	var provider media.TextExtractor
+	var err error
+
+	switch cfg.Provider {
+	case ProviderTesseract:
+		provider, err = NewTesseract(cfg.TesseractPath, cfg.Languages)
+	case ProviderGoogle:
+		provider, err = NewGoogleVision(cfg.APIKey, cfg.BaseURL)
+	default:
+		return nil, fmt.Errorf("unsupported OCR provider: %s", cfg.Provider)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create %s OCR: %w", cfg.Provider, err)
+	}
+
+	return &Extractor{
+		provider: provider,
+		name:     cfg.Provider,
+		timeout:  cfg.Timeout,
+		logger:   cfg.Logger.With("component", "ocr"),
+	}, nil
+}
+
+// NewWithProvider creates an Extractor with a custom provider implementation.
+func NewWithProvider(name string, provider media.TextExtractor, logger *slog.Logger) *Extractor {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &Extractor{
+		provider: provider,
+		name:     name,
+		timeout:  30 * time.Second,
+		logger:   logger.With("component", "ocr"),
+	}
+}
+
+// Name returns the provider name.
+func (e *Extractor) Name() string {
+	return e.name
+}
+
+// ExtractText returns the text found in the image, trimmed of surrounding
+// whitespace.
+func (e *Extractor) ExtractText(ctx context.Context, image io.Reader, mimeType string) (string, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	ctx, cancel := context.WithTimeout(ctx, e.timeout)
+	defer cancel()
+
+	e.logger.Debug("extracting text", "provider", e.name, "mime_type", mimeType)
+	text, err := e.provider.ExtractText(ctx, image, mimeType)
+	if err != nil {
+		e.logger.Warn("text extraction failed", "provider", e.name, "error", err)
+		return "", err
+	}
+	text = strings.TrimSpace(text)
+	e.logger.Debug("text extraction complete", "provider", e.name, "text_length", len(text))
+	return text, nil
+}
+
+// maxImageBytes caps the image size sent to a provider.
+const maxImageBytes = 20 * 1024 * 1024
+
+// readImage reads at most maxImageBytes from image.
+func readImage(image io.Reader) ([]byte, error) {
+	data, err := io.ReadAll(io.LimitReader(image, maxImageBytes+1))
+	if err != nil {
+		return nil, fmt.Errorf("read image: %w", err)
+	}
+	if len(data) == 0 {
+		return nil, fmt.Errorf("image data is empty")
+	}
+	if len(data) > maxImageBytes {
+		return nil, fmt.Errorf("image too large (%d bytes)", len(data))
+	}
+	return data, nil
+}
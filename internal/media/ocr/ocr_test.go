@@ -0,0 +1,112 @@
+package ocr
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestTesseract_ExtractText(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("shell scripts not supported on windows")
+	}
+	dir := t.TempDir()
+	argsFile := filepath.Join(dir, "args.txt")
+	script := "#!/bin/sh\necho \"$@\" > " + argsFile + "\ncat > /dev/null\necho '  Invoice #42  '\n"
+	binary := filepath.Join(dir, "tesseract")
+	if err := os.WriteFile(binary, []byte(script), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	extractor, err := New(Config{TesseractPath: binary, Languages: []string{"eng", "deu"}})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	text, err := extractor.ExtractText(context.Background(), strings.NewReader("png"), "image/png")
+	if err != nil {
+		t.Fatalf("ExtractText: %v", err)
+	}
+	if text != "Invoice #42" {
+		t.Fatalf("text = %q", text)
+	}
+	args, err := os.ReadFile(argsFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.TrimSpace(string(args)); got != "stdin stdout -l eng+deu" {
+		t.Fatalf("args = %q", got)
+	}
+}
+
+func TestTesseract_MissingBinary(t *testing.T) {
+	if _, err := New(Config{TesseractPath: filepath.Join(t.TempDir(), "missing")}); err == nil {
+		t.Fatal("expected error for missing binary")
+	}
+}
+
+func TestGoogleVision_ExtractText(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/images:annotate" || r.URL.Query().Get("key") != "k" {
+			http.Error(w, "bad request", http.StatusBadRequest)
+			return
+		}
+		var body struct {
+			Requests []struct {
+				Image struct {
+					Content string `json:"content"`
+				} `json:"image"`
+				Features []struct {
+					Type string `json:"type"`
+				} `json:"features"`
+			} `json:"requests"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil || len(body.Requests) != 1 || body.Requests[0].Image.Content == "" {
+			http.Error(w, "bad body", http.StatusBadRequest)
+			return
+		}
+		_, _ = w.Write([]byte(`{"responses":[{"fullTextAnnotation":{"text":"Hello\nWorld\n"}}]}`))
+	}))
+	defer server.Close()
+
+	extractor, err := New(Config{Provider: ProviderGoogle, APIKey: "k", BaseURL: server.URL})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	text, err := extractor.ExtractText(context.Background(), strings.NewReader("png"), "image/png")
+	if err != nil {
+		t.Fatalf("ExtractText: %v", err)
+	}
+	if text != "Hello\nWorld" {
+		t.Fatalf("text = %q", text)
+	}
+}
+
+func TestNew_Validation(t *testing.T) {
+	if _, err := New(Config{Provider: ProviderGoogle}); err == nil {
+		t.Fatal("expected error without API key")
+	}
+	if _, err := New(Config{Provider: "nope"}); err == nil {
+		t.Fatal("expected error for unknown provider")
+	}
+}
+
+type failingExtractor struct{}
+
+func (failingExtractor) ExtractText(context.Context, io.Reader, string) (string, error) {
+	return "", errors.New("boom")
+}
+
+func TestExtractor_PropagatesErrors(t *testing.T) {
+	extractor := NewWithProvider("fake", failingExtractor{}, nil)
+	if _, err := extractor.ExtractText(context.Background(), strings.NewReader("x"), "image/png"); err == nil {
+		t.Fatal("expected error")
+	}
+}
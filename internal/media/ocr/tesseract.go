@@ -0,0 +1,62 @@
+package ocr
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os/exec"
+	"strings"
+
+	"github.com/haasonsaas/nexus/internal/media"
+)
+
+// Tesseract runs the tesseract CLI locally.
+type Tesseract struct {
+	binary    string
+	languages string
+}
+
+var _ media.TextExtractor = (*Tesseract)(nil)
+
+// NewTesseract resolves the tesseract binary (default: tesseract on PATH).
+func NewTesseract(binary string, languages []string) (*Tesseract, error) {
+	binary = strings.TrimSpace(binary)
+	if binary == "" {
+		binary = "tesseract"
+	}
+	path, err := exec.LookPath(binary)
+	if err != nil {
+		return nil, fmt.Errorf("tesseract binary %q not found: %w", binary, err)
+	}
+	langs := make([]string, 0, len(languages))
+	for _, lang := range languages {
+		if lang = strings.TrimSpace(lang); lang != "" {
+			langs = append(langs, lang)
+		}
+	}
+	if len(langs) == 0 {
+		langs = []string{"eng"}
+	}
+	return &Tesseract{binary: path, languages: strings.Join(langs, "+")}, nil
+}
+
+// ExtractText pipes the image through `tesseract stdin stdout`.
+func (t *Tesseract) ExtractText(ctx context.Context, image io.Reader, _ string) (string, error) {
+	data, err := readImage(image)
+	if err != nil {
+		return "", err
+	}
+	cmd := exec.CommandContext(ctx, t.binary, "stdin", "stdout", "-l", t.languages)
+	cmd.Stdin = bytes.NewReader(data)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return "", fmt.Errorf("tesseract: %w: %s", err, msg)
+		}
+		return "", fmt.Errorf("tesseract: %w", err)
+	}
+	return stdout.String(), nil
+}
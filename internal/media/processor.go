@@ -24,6 +24,7 @@ import (
 type DefaultProcessor struct {
 	httpClient  *http.Client
 	transcriber Transcriber
+	extractor   TextExtractor
 	logger      *slog.Logger
 }
 
@@ -43,6 +44,11 @@ func (p *DefaultProcessor) SetTranscriber(t Transcriber) {
 	p.transcriber = t
 }
 
+// SetTextExtractor sets the OCR backend for image processing.
+func (p *DefaultProcessor) SetTextExtractor(e TextExtractor) {
+	p.extractor = e
+}
+
 // Process processes an attachment.
 func (p *DefaultProcessor) Process(attachment *Attachment, opts ProcessingOptions) (*ProcessingResult, error) {
 	start := time.Now()
@@ -91,19 +97,25 @@ func (p *DefaultProcessor) Process(attachment *Attachment, opts ProcessingOption
 				result.Contents = append(result.Contents, *content)
 			}
 		}
+		if opts.EnableOCR && p.extractor != nil {
+			text, err := p.extractor.ExtractText(ctx, bytes.NewReader(data), attachment.MimeType)
+			if err != nil {
+				// OCR is best effort; the image itself is still usable.
+				p.logger.Warn("text extraction failed", "error", err)
+			} else {
+				result.ExtractedText = strings.TrimSpace(text)
+			}
+		}
 
 	case MediaTypeAudio:
 		if opts.EnableTranscription && p.transcriber != nil {
-			text, err := p.transcriber.Transcribe(
-				bytes.NewReader(data),
-				attachment.MimeType,
-				opts.TranscriptionLanguage,
-			)
+			text, transcript, err := p.transcribe(ctx, data, attachment.MimeType, opts)
 			if err != nil {
 				p.logger.Warn("transcription failed", "error", err)
 				result.Error = err.Error()
 			} else {
 				result.Transcription = text
+				result.Transcript = transcript
 				result.Contents = append(result.Contents, Content{
 					Type:   ContentTypeText,
 					Text:   text,
@@ -126,6 +138,26 @@ func (p *DefaultProcessor) Process(attachment *Attachment, opts ProcessingOption
 	return result, nil
 }
 
+// transcribe runs the transcriber, using its structured output when it
+// provides one.
+func (p *DefaultProcessor) transcribe(ctx context.Context, data []byte, mimeType string, opts ProcessingOptions) (string, *Transcript, error) {
+	if detailed, ok := p.transcriber.(DetailedTranscriber); ok {
+		transcript, err := detailed.TranscribeDetailed(ctx, bytes.NewReader(data), mimeType, TranscriptionOptions{
+			Language: opts.TranscriptionLanguage,
+			Diarize:  opts.Diarize,
+		})
+		if err != nil {
+			return "", nil, err
+		}
+		return transcript.Format(), transcript, nil
+	}
+	text, err := p.transcriber.Transcribe(bytes.NewReader(data), mimeType, opts.TranscriptionLanguage)
+	if err != nil {
+		return "", nil, err
+	}
+	return text, &Transcript{Text: text}, nil
+}
+
 // SupportedTypes returns supported media types.
 func (p *DefaultProcessor) SupportedTypes() []MediaType {
 	return []MediaType{
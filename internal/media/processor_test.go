@@ -6,6 +6,7 @@ import (
 	"image"
 	"image/color"
 	"image/png"
+	"io"
 	"testing"
 )
 
@@ -251,6 +252,87 @@ func TestAggregator_Aggregate(t *testing.T) {
 	}
 }
 
+// diarizingTranscriber returns a two-speaker transcript when asked to diarize.
+type diarizingTranscriber struct {
+	gotOpts TranscriptionOptions
+}
+
+func (d *diarizingTranscriber) Transcribe(audio io.Reader, mimeType, language string) (string, error) {
+	return "plain", nil
+}
+
+func (d *diarizingTranscriber) TranscribeDetailed(ctx context.Context, audio io.Reader, mimeType string, opts TranscriptionOptions) (*Transcript, error) {
+	d.gotOpts = opts
+	return &Transcript{
+		Text: "Hi. Hello. Bye.",
+		Segments: []TranscriptSegment{
+			{Speaker: "Speaker 1", Text: "Hi."},
+			{Speaker: "Speaker 2", Text: "Hello."},
+			{Speaker: "Speaker 2", Text: "Bye."},
+		},
+	}, nil
+}
+
+func TestDefaultProcessor_Process_DetailedTranscription(t *testing.T) {
+	transcriber := &diarizingTranscriber{}
+	processor := NewDefaultProcessor(nil)
+	processor.SetTranscriber(transcriber)
+
+	opts := DefaultOptions()
+	opts.TranscriptionLanguage = "de"
+	opts.Diarize = true
+	result, err := processor.Process(&Attachment{Type: MediaTypeAudio, MimeType: "audio/ogg", Data: []byte("audio")}, opts)
+	if err != nil {
+		t.Fatalf("Process() error = %v", err)
+	}
+	if transcriber.gotOpts.Language != "de" || !transcriber.gotOpts.Diarize {
+		t.Errorf("options not passed through: %+v", transcriber.gotOpts)
+	}
+	if want := "Speaker 1: Hi.\nSpeaker 2: Hello. Bye."; result.Transcription != want {
+		t.Errorf("Transcription = %q, want %q", result.Transcription, want)
+	}
+	if result.Transcript == nil || len(result.Transcript.Segments) != 3 {
+		t.Errorf("expected structured transcript, got %+v", result.Transcript)
+	}
+}
+
+// staticExtractor returns fixed OCR text.
+type staticExtractor struct{ text string }
+
+func (e staticExtractor) ExtractText(ctx context.Context, image io.Reader, mimeType string) (string, error) {
+	return e.text, nil
+}
+
+func TestAggregator_Aggregate_OCR(t *testing.T) {
+	processor := NewDefaultProcessor(nil)
+	processor.SetTextExtractor(staticExtractor{text: " Error 500 \n"})
+	aggregator := NewAggregator(processor, nil)
+
+	attachments := []*Attachment{{
+		ID:       "shot-1",
+		Type:     MediaTypeImage,
+		MimeType: "image/png",
+		Data:     createTestImage(20, 20),
+	}}
+	opts := DefaultOptions()
+	opts.EnableVision = false
+	opts.EnableOCR = true
+	content := aggregator.Aggregate(context.Background(), attachments, opts)
+
+	if got := content.ImageText["shot-1"]; got != "Error 500" {
+		t.Errorf("ImageText = %q, want %q", got, "Error 500")
+	}
+	if content.Text != "[Image text]: Error 500" {
+		t.Errorf("Text = %q", content.Text)
+	}
+
+	opts.EnableOCR = false
+	content = aggregator.Aggregate(context.Background(), attachments, opts)
+	if len(content.ImageText) != 0 {
+		t.Errorf("expected no OCR when disabled, got %v", content.ImageText)
+	}
+}
+
 func TestAggregator_ProcessAll_Concurrent(t *testing.T) {
 	processor := NewDefaultProcessor(nil)
 	aggregator := NewAggregator(processor, nil)
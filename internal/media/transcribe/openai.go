@@ -3,6 +3,7 @@ package transcribe
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
 	"io"
 	"log/slog"
@@ -47,7 +48,10 @@ type OpenAITranscriber struct {
 }
 
 // Verify that OpenAITranscriber implements media.Transcriber.
-var _ media.Transcriber = (*OpenAITranscriber)(nil)
+var (
+	_ media.Transcriber         = (*OpenAITranscriber)(nil)
+	_ media.DetailedTranscriber = (*OpenAITranscriber)(nil)
+)
 
 // NewOpenAITranscriber creates a new OpenAI Whisper transcriber.
 func NewOpenAITranscriber(cfg OpenAIConfig) (*OpenAITranscriber, error) {
@@ -107,18 +111,78 @@ func (t *OpenAITranscriber) Transcribe(audio io.Reader, mimeType string, languag
 
 // TranscribeWithContext transcribes audio with a custom context for cancellation.
 func (t *OpenAITranscriber) TranscribeWithContext(ctx context.Context, audio io.Reader, mimeType string, language string) (string, error) {
+	respBody, err := t.request(ctx, audio, mimeType, language, "text")
+	if err != nil {
+		return "", err
+	}
+
+	// Since we requested "text" format, response is plain text
+	text := strings.TrimSpace(string(respBody))
+
+	t.logger.Debug("transcription complete",
+		"text_length", len(text))
+
+	return text, nil
+}
+
+// TranscribeDetailed transcribes audio with timed segments using the
+// verbose_json response format. The Whisper API does not label speakers, so
+// opts.Diarize is ignored.
+func (t *OpenAITranscriber) TranscribeDetailed(ctx context.Context, audio io.Reader, mimeType string, opts media.TranscriptionOptions) (*media.Transcript, error) {
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, t.httpClient.Timeout)
+		defer cancel()
+	}
+	respBody, err := t.request(ctx, audio, mimeType, opts.Language, "verbose_json")
+	if err != nil {
+		return nil, err
+	}
+
+	var verbose struct {
+		Text     string  `json:"text"`
+		Language string  `json:"language"`
+		Duration float64 `json:"duration"`
+		Segments []struct {
+			Start float64 `json:"start"`
+			End   float64 `json:"end"`
+			Text  string  `json:"text"`
+		} `json:"segments"`
+	}
+	if err := json.Unmarshal(respBody, &verbose); err != nil {
+		return nil, fmt.Errorf("failed to decode transcription response: %w", err)
+	}
+
+	transcript := &media.Transcript{
+		Text:     strings.TrimSpace(verbose.Text),
+		Language: verbose.Language,
+		Duration: secondsToDuration(verbose.Duration),
+	}
+	for _, seg := range verbose.Segments {
+		transcript.Segments = append(transcript.Segments, media.TranscriptSegment{
+			Start: secondsToDuration(seg.Start),
+			End:   secondsToDuration(seg.End),
+			Text:  strings.TrimSpace(seg.Text),
+		})
+	}
+	return transcript, nil
+}
+
+// request posts the audio to the transcription endpoint and returns the raw
+// response body.
+func (t *OpenAITranscriber) request(ctx context.Context, audio io.Reader, mimeType, language, format string) ([]byte, error) {
 	// Read all audio data
 	const maxAudioBytes = 25 * 1024 * 1024
 	audioData, err := io.ReadAll(io.LimitReader(audio, maxAudioBytes+1))
 	if err != nil {
-		return "", fmt.Errorf("failed to read audio data: %w", err)
+		return nil, fmt.Errorf("failed to read audio data: %w", err)
 	}
 
 	if len(audioData) == 0 {
-		return "", fmt.Errorf("audio data is empty")
+		return nil, fmt.Errorf("audio data is empty")
 	}
 	if len(audioData) > maxAudioBytes {
-		return "", fmt.Errorf("audio data too large (%d bytes)", len(audioData))
+		return nil, fmt.Errorf("audio data too large (%d bytes)", len(audioData))
 	}
 
 	t.logger.Debug("transcribing audio",
@@ -137,20 +201,20 @@ func (t *OpenAITranscriber) TranscribeWithContext(ctx context.Context, audio io.
 	// Add file field
 	part, err := writer.CreateFormFile("file", filename)
 	if err != nil {
-		return "", fmt.Errorf("failed to create form file: %w", err)
+		return nil, fmt.Errorf("failed to create form file: %w", err)
 	}
 	if _, err := part.Write(audioData); err != nil {
-		return "", fmt.Errorf("failed to write audio data: %w", err)
+		return nil, fmt.Errorf("failed to write audio data: %w", err)
 	}
 
 	// Add model field
 	if err := writer.WriteField("model", t.model); err != nil {
-		return "", fmt.Errorf("failed to write model field: %w", err)
+		return nil, fmt.Errorf("failed to write model field: %w", err)
 	}
 
 	// Add response format field
-	if err := writer.WriteField("response_format", "text"); err != nil {
-		return "", fmt.Errorf("failed to write response_format field: %w", err)
+	if err := writer.WriteField("response_format", format); err != nil {
+		return nil, fmt.Errorf("failed to write response_format field: %w", err)
 	}
 
 	// Add language field (use explicit or default, empty for auto-detect)
@@ -160,19 +224,19 @@ func (t *OpenAITranscriber) TranscribeWithContext(ctx context.Context, audio io.
 	}
 	if lang != "" {
 		if err := writer.WriteField("language", lang); err != nil {
-			return "", fmt.Errorf("failed to write language field: %w", err)
+			return nil, fmt.Errorf("failed to write language field: %w", err)
 		}
 	}
 
 	if err := writer.Close(); err != nil {
-		return "", fmt.Errorf("failed to close multipart writer: %w", err)
+		return nil, fmt.Errorf("failed to close multipart writer: %w", err)
 	}
 
 	// Create HTTP request
 	url := t.baseURL + "/audio/transcriptions"
 	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, &body)
 	if err != nil {
-		return "", fmt.Errorf("failed to create request: %w", err)
+		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
 	req.Header.Set("Authorization", "Bearer "+t.apiKey)
@@ -181,7 +245,7 @@ func (t *OpenAITranscriber) TranscribeWithContext(ctx context.Context, audio io.
 	// Execute request
 	resp, err := t.httpClient.Do(req)
 	if err != nil {
-		return "", fmt.Errorf("request failed: %w", err)
+		return nil, fmt.Errorf("request failed: %w", err)
 	}
 	defer resp.Body.Close()
 
@@ -189,30 +253,28 @@ func (t *OpenAITranscriber) TranscribeWithContext(ctx context.Context, audio io.
 	if resp.StatusCode != http.StatusOK {
 		respBody, err := io.ReadAll(io.LimitReader(resp.Body, 8<<10))
 		if err != nil {
-			return "", fmt.Errorf("failed to read response: %w", err)
+			return nil, fmt.Errorf("failed to read response: %w", err)
 		}
 		t.logger.Error("transcription API error",
 			"status", resp.StatusCode,
 			"response", string(respBody))
-		return "", fmt.Errorf("API error (status %d): %s", resp.StatusCode, string(respBody))
+		return nil, fmt.Errorf("API error (status %d): %s", resp.StatusCode, string(respBody))
 	}
 
 	const maxTranscriptionResponseBytes = 10 * 1024 * 1024
 	respBody, err := io.ReadAll(io.LimitReader(resp.Body, maxTranscriptionResponseBytes+1))
 	if err != nil {
-		return "", fmt.Errorf("failed to read response: %w", err)
+		return nil, fmt.Errorf("failed to read response: %w", err)
 	}
 	if len(respBody) > maxTranscriptionResponseBytes {
-		return "", fmt.Errorf("transcription response too large (%d bytes)", len(respBody))
+		return nil, fmt.Errorf("transcription response too large (%d bytes)", len(respBody))
 	}
+	return respBody, nil
+}
 
-	// Since we requested "text" format, response is plain text
-	text := strings.TrimSpace(string(respBody))
-
-	t.logger.Debug("transcription complete",
-		"text_length", len(text))
-
-	return text, nil
+// secondsToDuration converts fractional seconds to a duration.
+func secondsToDuration(seconds float64) time.Duration {
+	return time.Duration(seconds * float64(time.Second))
 }
 
 // getFilenameForMimeType returns an appropriate filename with extension for the given MIME type.
@@ -2,6 +2,7 @@
 package transcribe
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"log/slog"
@@ -11,7 +12,7 @@ import (
 
 // Config holds configuration for transcription providers.
 type Config struct {
-	// Provider is the transcription provider to use (e.g., "openai")
+	// Provider is the transcription provider to use ("openai" or "whispercpp")
 	Provider string `yaml:"provider"`
 
 	// APIKey is the API key for the provider
@@ -20,13 +21,17 @@ type Config struct {
 	// BaseURL is an optional custom base URL for the API
 	BaseURL string `yaml:"base_url"`
 
-	// Model is the transcription model to use (e.g., "whisper-1")
+	// Model is the transcription model to use (e.g., "whisper-1", or a
+	// whisper.cpp model name such as "base.en")
 	Model string `yaml:"model"`
 
 	// Language is the default language for transcription (ISO 639-1)
 	// If empty, the provider will auto-detect the language
 	Language string `yaml:"language"`
 
+	// Whisper configures the local whisper.cpp backend
+	Whisper WhisperConfig `yaml:"whisper"`
+
 	// Logger is an optional structured logger
 	Logger *slog.Logger `yaml:"-"`
 }
@@ -34,16 +39,27 @@ type Config struct {
 // applyDefaults sets default values for unset configuration fields.
 func (c *Config) applyDefaults() {
 	if c.Provider == "" {
-		c.Provider = "openai"
+		c.Provider = ProviderOpenAI
 	}
 	if c.Model == "" {
-		c.Model = "whisper-1"
+		switch c.Provider {
+		case ProviderWhisperCPP:
+			c.Model = DefaultWhisperModel
+		default:
+			c.Model = "whisper-1"
+		}
 	}
 	if c.Logger == nil {
 		c.Logger = slog.Default()
 	}
 }
 
+// Supported providers.
+const (
+	ProviderOpenAI     = "openai"
+	ProviderWhisperCPP = "whispercpp"
+)
+
 // Transcriber wraps the media.Transcriber interface with additional metadata.
 type Transcriber struct {
 	provider media.Transcriber
@@ -74,6 +90,41 @@ func (t *Transcriber) Transcribe(audio io.Reader, mimeType string, language stri
 	return text, nil
 }
 
+// TranscribeDetailed converts audio to a structured transcript. Providers
+// without segment output return the plain text as a single-block transcript.
+func (t *Transcriber) TranscribeDetailed(ctx context.Context, audio io.Reader, mimeType string, opts media.TranscriptionOptions) (*media.Transcript, error) {
+	detailed, ok := t.provider.(media.DetailedTranscriber)
+	if !ok {
+		text, err := t.Transcribe(audio, mimeType, opts.Language)
+		if err != nil {
+			return nil, err
+		}
+		return &media.Transcript{Text: text, Language: opts.Language, Provider: t.name}, nil
+	}
+
+	t.logger.Debug("transcribing audio",
+		"provider", t.name,
+		"mime_type", mimeType,
+		"language", opts.Language,
+		"diarize", opts.Diarize)
+
+	transcript, err := detailed.TranscribeDetailed(ctx, audio, mimeType, opts)
+	if err != nil {
+		t.logger.Error("transcription failed",
+			"provider", t.name,
+			"error", err)
+		return nil, err
+	}
+	transcript.Provider = t.name
+
+	t.logger.Debug("transcription complete",
+		"provider", t.name,
+		"text_length", len(transcript.Text),
+		"segments", len(transcript.Segments))
+
+	return transcript, nil
+}
+
 // Name returns the provider name.
 func (t *Transcriber) Name() string {
 	return t.name
@@ -89,7 +140,7 @@ func New(cfg Config) (*Transcriber, error) {
 	var err error
 
 	switch cfg.Provider {
-	case "openai":
+	case ProviderOpenAI:
 		provider, err = NewOpenAITranscriber(OpenAIConfig{
 			APIKey:   cfg.APIKey,
 			BaseURL:  cfg.BaseURL,
@@ -97,6 +148,16 @@ func New(cfg Config) (*Transcriber, error) {
 			Language: cfg.Language,
 			Logger:   cfg.Logger,
 		})
+	case ProviderWhisperCPP:
+		whisperCfg := cfg.Whisper
+		if whisperCfg.Model == "" {
+			whisperCfg.Model = cfg.Model
+		}
+		if whisperCfg.Language == "" {
+			whisperCfg.Language = cfg.Language
+		}
+		whisperCfg.Logger = cfg.Logger
+		provider, err = NewWhisperTranscriber(whisperCfg)
 	default:
 		return nil, fmt.Errorf("unsupported transcription provider: %s", cfg.Provider)
 	}
@@ -2,11 +2,15 @@ package transcribe
 
 import (
 	"bytes"
+	"context"
 	"io"
 	"net/http"
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
+
+	"github.com/haasonsaas/nexus/internal/media"
 )
 
 // mockTranscriber is a test implementation of media.Transcriber
@@ -335,6 +339,52 @@ func TestOpenAITranscriber_Transcribe_EmptyAudio(t *testing.T) {
 	}
 }
 
+func TestOpenAITranscriber_TranscribeDetailed(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseMultipartForm(10 << 20); err != nil {
+			t.Errorf("failed to parse form: %v", err)
+		}
+		if format := r.FormValue("response_format"); format != "verbose_json" {
+			t.Errorf("response_format = %q, want %q", format, "verbose_json")
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"text":" Hello world ","language":"english","duration":2.5,` +
+			`"segments":[{"start":0,"end":1.2,"text":" Hello"},{"start":1.2,"end":2.5,"text":" world"}]}`))
+	}))
+	defer server.Close()
+
+	transcriber, err := NewOpenAITranscriber(OpenAIConfig{APIKey: "test-key", BaseURL: server.URL})
+	if err != nil {
+		t.Fatalf("NewOpenAITranscriber() error = %v", err)
+	}
+	wrapped := NewWithProvider(ProviderOpenAI, transcriber, nil)
+
+	transcript, err := wrapped.TranscribeDetailed(context.Background(), bytes.NewReader([]byte("audio")), "audio/ogg", media.TranscriptionOptions{})
+	if err != nil {
+		t.Fatalf("TranscribeDetailed() error = %v", err)
+	}
+	if transcript.Text != "Hello world" || transcript.Language != "english" || transcript.Provider != ProviderOpenAI {
+		t.Errorf("unexpected transcript: %+v", transcript)
+	}
+	if transcript.Duration != 2500*time.Millisecond || len(transcript.Segments) != 2 {
+		t.Errorf("unexpected timing: %+v", transcript)
+	}
+	if transcript.Segments[1].Start != 1200*time.Millisecond || transcript.Segments[1].Text != "world" {
+		t.Errorf("unexpected segment: %+v", transcript.Segments[1])
+	}
+}
+
+func TestTranscriber_TranscribeDetailed_PlainProvider(t *testing.T) {
+	wrapped := NewWithProvider("mock", &mockTranscriber{}, nil)
+	transcript, err := wrapped.TranscribeDetailed(context.Background(), strings.NewReader("audio"), "audio/ogg", media.TranscriptionOptions{Language: "fr"})
+	if err != nil {
+		t.Fatalf("TranscribeDetailed() error = %v", err)
+	}
+	if transcript.Text != "mock transcription" || transcript.Language != "fr" || transcript.Provider != "mock" {
+		t.Errorf("unexpected transcript: %+v", transcript)
+	}
+}
+
 func TestGetFilenameForMimeType(t *testing.T) {
 	tests := []struct {
 		mimeType string
@@ -430,4 +480,10 @@ func TestConfig_ApplyDefaults(t *testing.T) {
 	if cfg.Logger == nil {
 		t.Error("default Logger should not be nil")
 	}
+
+	local := Config{Provider: ProviderWhisperCPP}
+	local.applyDefaults()
+	if local.Model != DefaultWhisperModel {
+		t.Errorf("default whispercpp Model = %q, want %q", local.Model, DefaultWhisperModel)
+	}
 }
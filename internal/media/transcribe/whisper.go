@@ -0,0 +1,395 @@
+package transcribe
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/haasonsaas/nexus/internal/media"
+)
+
+const (
+	// DefaultWhisperModel is the whisper.cpp model used when none is set.
+	DefaultWhisperModel = "base"
+
+	// DefaultWhisperDownloadURL hosts the ggml model files.
+	DefaultWhisperDownloadURL = "https://huggingface.co/ggerganov/whisper.cpp/resolve/main"
+)
+
+// WhisperConfig holds configuration for the local whisper.cpp transcriber.
+type WhisperConfig struct {
+	// BinaryPath is the whisper.cpp CLI (default: whisper-cli)
+	BinaryPath string `yaml:"binary_path"`
+
+	// FFmpegPath converts input audio to 16 kHz mono WAV (default: ffmpeg)
+	FFmpegPath string `yaml:"ffmpeg_path"`
+
+	// Model is the ggml model name, e.g. "base.en" or "small" (default: base)
+	Model string `yaml:"model"`
+
+	// ModelPath is an explicit model file; it overrides Model and ModelDir
+	ModelPath string `yaml:"model_path"`
+
+	// ModelDir holds downloaded models (default: ~/.nexus/models/whisper)
+	ModelDir string `yaml:"model_dir"`
+
+	// AutoDownload fetches the model on first use when it is missing
+	AutoDownload bool `yaml:"auto_download"`
+
+	// DownloadURL is the base URL models are fetched from
+	DownloadURL string `yaml:"download_url"`
+
+	// Threads is the number of CPU threads (0 lets whisper.cpp decide)
+	Threads int `yaml:"threads"`
+
+	// Language is the default language for transcription (ISO 639-1)
+	// If empty, whisper.cpp will auto-detect the language
+	Language string `yaml:"language"`
+
+	// Timeout bounds a single transcription, including conversion (default: 5m)
+	Timeout time.Duration `yaml:"timeout"`
+
+	// Logger is an optional structured logger
+	Logger *slog.Logger `yaml:"-"`
+}
+
+// WhisperTranscriber implements media.Transcriber by running whisper.cpp locally.
+type WhisperTranscriber struct {
+	binary      string
+	ffmpeg      string
+	model       string
+	modelPath   string
+	download    bool
+	downloadURL string
+	threads     int
+	language    string
+	timeout     time.Duration
+	httpClient  *http.Client
+	logger      *slog.Logger
+
+	// modelMu serializes the model check and download.
+	modelMu sync.Mutex
+}
+
+// Verify that WhisperTranscriber implements the transcriber interfaces.
+var (
+	_ media.Transcriber         = (*WhisperTranscriber)(nil)
+	_ media.DetailedTranscriber = (*WhisperTranscriber)(nil)
+)
+
+// NewWhisperTranscriber creates a whisper.cpp transcriber. The binaries must
+// be on PATH (or configured explicitly); the model must exist unless
+// AutoDownload is set, in which case it is fetched on first use.
+func NewWhisperTranscriber(cfg WhisperConfig) (*WhisperTranscriber, error) {
+	binary := strings.TrimSpace(cfg.BinaryPath)
+	if binary == "" {
+		binary = "whisper-cli"
+	}
+	binaryPath, err := exec.LookPath(binary)
+	if err != nil {
+		return nil, fmt.Errorf("whisper.cpp binary %q not found: %w", binary, err)
+	}
+	ffmpeg := strings.TrimSpace(cfg.FFmpegPath)
+	if ffmpeg == "" {
+		ffmpeg = "ffmpeg"
+	}
+	ffmpegPath, err := exec.LookPath(ffmpeg)
+	if err != nil {
+		return nil, fmt.Errorf("ffmpeg %q not found: %w", ffmpeg, err)
+	}
+
+	model := strings.TrimSpace(cfg.Model)
+	if model == "" {
+		model = DefaultWhisperModel
+	}
+	modelPath := strings.TrimSpace(cfg.ModelPath)
+	if modelPath == "" {
+		dir := strings.TrimSpace(cfg.ModelDir)
+		if dir == "" {
+			dir = defaultWhisperModelDir()
+		}
+		modelPath = filepath.Join(dir, whisperModelFile(model))
+	}
+	if _, err := os.Stat(modelPath); err != nil && !cfg.AutoDownload {
+		return nil, fmt.Errorf("whisper model not found at %s (set model_path or enable auto_download): %w", modelPath, err)
+	}
+
+	downloadURL := strings.TrimSuffix(strings.TrimSpace(cfg.DownloadURL), "/")
+	if downloadURL == "" {
+		downloadURL = DefaultWhisperDownloadURL
+	}
+
+	timeout := cfg.Timeout
+	if timeout <= 0 {
+		timeout = 5 * time.Minute
+	}
+
+	logger := cfg.Logger
+	if logger == nil {
+		logger = slog.Default()
+	}
+
+	return &WhisperTranscriber{
+		binary:      binaryPath,
+		ffmpeg:      ffmpegPath,
+		model:       model,
+		modelPath:   modelPath,
+		download:    cfg.AutoDownload,
+		downloadURL: downloadURL,
+		threads:     cfg.Threads,
+		language:    cfg.Language,
+		timeout:     timeout,
+		httpClient:  &http.Client{Timeout: 30 * time.Minute},
+		logger:      logger.With("component", "whisper-transcriber"),
+	}, nil
+}
+
+// Transcribe converts audio to text using whisper.cpp.
+func (t *WhisperTranscriber) Transcribe(audio io.Reader, mimeType string, language string) (string, error) {
+	transcript, err := t.TranscribeDetailed(context.Background(), audio, mimeType, media.TranscriptionOptions{Language: language})
+	if err != nil {
+		return "", err
+	}
+	return transcript.Text, nil
+}
+
+// TranscribeDetailed converts audio to a timed transcript. With
+// opts.Diarize, speaker turns are detected with tinydiarize, which requires
+// a "-tdrz" model; other models return a single speaker.
+func (t *WhisperTranscriber) TranscribeDetailed(ctx context.Context, audio io.Reader, mimeType string, opts media.TranscriptionOptions) (*media.Transcript, error) {
+	ctx, cancel := context.WithTimeout(ctx, t.timeout)
+	defer cancel()
+
+	if err := t.ensureModel(ctx); err != nil {
+		return nil, err
+	}
+
+	const maxAudioBytes = 100 * 1024 * 1024
+	audioData, err := io.ReadAll(io.LimitReader(audio, maxAudioBytes+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read audio data: %w", err)
+	}
+	if len(audioData) == 0 {
+		return nil, fmt.Errorf("audio data is empty")
+	}
+	if len(audioData) > maxAudioBytes {
+		return nil, fmt.Errorf("audio data too large (%d bytes)", len(audioData))
+	}
+
+	dir, err := os.MkdirTemp("", "nexus-whisper-*")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create work dir: %w", err)
+	}
+	defer os.RemoveAll(dir)
+
+	input := filepath.Join(dir, getFilenameForMimeType(mimeType))
+	if err := os.WriteFile(input, audioData, 0o600); err != nil {
+		return nil, fmt.Errorf("failed to write audio data: %w", err)
+	}
+
+	// whisper.cpp only reads 16 kHz WAV.
+	wav := filepath.Join(dir, "input.wav")
+	if err := t.run(ctx, t.ffmpeg, "-nostdin", "-y", "-loglevel", "error",
+		"-i", input, "-ar", "16000", "-ac", "1", "-c:a", "pcm_s16le", wav); err != nil {
+		return nil, fmt.Errorf("ffmpeg conversion failed: %w", err)
+	}
+
+	lang := opts.Language
+	if lang == "" {
+		lang = t.language
+	}
+	if lang == "" {
+		lang = "auto"
+	}
+	outBase := filepath.Join(dir, "output")
+	args := []string{"-m", t.modelPath, "-f", wav, "-l", lang, "-oj", "-of", outBase, "-np"}
+	if t.threads > 0 {
+		args = append(args, "-t", strconv.Itoa(t.threads))
+	}
+	if opts.Diarize {
+		args = append(args, "-tdrz")
+	}
+
+	t.logger.Debug("transcribing audio",
+		"size_bytes", len(audioData),
+		"mime_type", mimeType,
+		"language", lang,
+		"model", t.model,
+		"diarize", opts.Diarize)
+
+	if err := t.run(ctx, t.binary, args...); err != nil {
+		return nil, fmt.Errorf("whisper.cpp failed: %w", err)
+	}
+
+	data, err := os.ReadFile(outBase + ".json")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read whisper.cpp output: %w", err)
+	}
+	transcript, err := parseWhisperOutput(data, opts.Diarize)
+	if err != nil {
+		return nil, err
+	}
+	if transcript.Language == "" && lang != "auto" {
+		transcript.Language = lang
+	}
+
+	t.logger.Debug("transcription complete",
+		"text_length", len(transcript.Text),
+		"segments", len(transcript.Segments))
+
+	return transcript, nil
+}
+
+// run executes a command, returning its stderr on failure.
+func (t *WhisperTranscriber) run(ctx context.Context, name string, args ...string) error {
+	cmd := exec.CommandContext(ctx, name, args...)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		return fmt.Errorf("%v: %s", err, strings.TrimSpace(stderr.String()))
+	}
+	return nil
+}
+
+// ensureModel downloads the model when it is missing and auto-download is on.
+func (t *WhisperTranscriber) ensureModel(ctx context.Context) error {
+	t.modelMu.Lock()
+	defer t.modelMu.Unlock()
+
+	if _, err := os.Stat(t.modelPath); err == nil {
+		return nil
+	} else if !errors.Is(err, os.ErrNotExist) || !t.download {
+		return fmt.Errorf("whisper model unavailable at %s: %w", t.modelPath, err)
+	}
+
+	url := t.downloadURL + "/" + filepath.Base(t.modelPath)
+	t.logger.Info("downloading whisper model", "url", url, "path", t.modelPath)
+	if err := downloadWhisperModel(ctx, t.httpClient, url, t.modelPath); err != nil {
+		return fmt.Errorf("failed to download whisper model: %w", err)
+	}
+	return nil
+}
+
+// downloadWhisperModel fetches url into destPath via a scratch file so a
+// partial download is never mistaken for a model.
+func downloadWhisperModel(ctx context.Context, client *http.Client, url, destPath string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return fmt.Errorf("create request: %w", err)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("http request: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("download returned %d", resp.StatusCode)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(destPath), 0o755); err != nil {
+		return fmt.Errorf("create model dir: %w", err)
+	}
+	tmpFile := destPath + ".tmp"
+	f, err := os.Create(tmpFile)
+	if err != nil {
+		return fmt.Errorf("create scratch file: %w", err)
+	}
+	_, err = io.Copy(f, resp.Body)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(tmpFile)
+		return fmt.Errorf("write file: %w", err)
+	}
+	if err := os.Rename(tmpFile, destPath); err != nil {
+		os.Remove(tmpFile)
+		return fmt.Errorf("rename file: %w", err)
+	}
+	return nil
+}
+
+// whisperOutput is the JSON written by whisper.cpp's -oj flag.
+type whisperOutput struct {
+	Result struct {
+		Language string `json:"language"`
+	} `json:"result"`
+	Transcription []struct {
+		Offsets struct {
+			From int64 `json:"from"`
+			To   int64 `json:"to"`
+		} `json:"offsets"`
+		Text            string `json:"text"`
+		SpeakerTurnNext bool   `json:"speaker_turn_next"`
+	} `json:"transcription"`
+}
+
+// parseWhisperOutput converts whisper.cpp JSON into a transcript. When
+// diarizing, segments are labelled "Speaker N", advancing on each speaker
+// turn whisper.cpp reports; tinydiarize detects turns but does not match
+// voices, so a returning speaker gets a new label.
+func parseWhisperOutput(data []byte, diarize bool) (*media.Transcript, error) {
+	var out whisperOutput
+	if err := json.Unmarshal(data, &out); err != nil {
+		return nil, fmt.Errorf("failed to decode whisper.cpp output: %w", err)
+	}
+
+	transcript := &media.Transcript{Language: out.Result.Language}
+	texts := make([]string, 0, len(out.Transcription))
+	speaker := 1
+	for _, seg := range out.Transcription {
+		text := strings.TrimSpace(seg.Text)
+		if text == "" {
+			continue
+		}
+		segment := media.TranscriptSegment{
+			Start: time.Duration(seg.Offsets.From) * time.Millisecond,
+			End:   time.Duration(seg.Offsets.To) * time.Millisecond,
+			Text:  text,
+		}
+		if diarize {
+			segment.Speaker = fmt.Sprintf("Speaker %d", speaker)
+			if seg.SpeakerTurnNext {
+				speaker++
+			}
+		}
+		transcript.Segments = append(transcript.Segments, segment)
+		texts = append(texts, text)
+	}
+	transcript.Text = strings.Join(texts, " ")
+	if n := len(transcript.Segments); n > 0 {
+		transcript.Duration = transcript.Segments[n-1].End
+	}
+	return transcript, nil
+}
+
+// whisperModelFile returns the ggml file name for a model.
+func whisperModelFile(model string) string {
+	if strings.HasSuffix(model, ".bin") {
+		return model
+	}
+	return "ggml-" + model + ".bin"
+}
+
+func defaultWhisperModelDir() string {
+	home, err := os.UserHomeDir()
+	if err != nil || strings.TrimSpace(home) == "" {
+		home = "."
+	}
+	return filepath.Join(home, ".nexus", "models", "whisper")
+}
@@ -0,0 +1,164 @@
+package transcribe
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/haasonsaas/nexus/internal/media"
+)
+
+// fakeWhisperOutput mimics whisper.cpp -oj output with a speaker turn.
+const fakeWhisperOutput = `{
+  "result": {"language": "en"},
+  "transcription": [
+    {"offsets": {"from": 0, "to": 1500}, "text": " Hi there.", "speaker_turn_next": true},
+    {"offsets": {"from": 1500, "to": 3000}, "text": " Hello!"},
+    {"offsets": {"from": 3000, "to": 3200}, "text": " "}
+  ]
+}`
+
+// writeFakeWhisperTools installs shell scripts standing in for ffmpeg and
+// whisper-cli. The whisper script records its arguments and writes
+// fakeWhisperOutput to the -of path.
+func writeFakeWhisperTools(t *testing.T) (dir, argsFile string) {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("shell scripts not supported on windows")
+	}
+	dir = t.TempDir()
+	argsFile = filepath.Join(dir, "args.txt")
+	outputFile := filepath.Join(dir, "output.json")
+	if err := os.WriteFile(outputFile, []byte(fakeWhisperOutput), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	ffmpeg := "#!/bin/sh\nfor last; do :; done\ntouch \"$last\"\n"
+	whisper := "#!/bin/sh\necho \"$@\" > " + argsFile + "\n" +
+		"while [ $# -gt 0 ]; do\n  if [ \"$1\" = \"-of\" ]; then out=\"$2\"; fi\n  shift\ndone\n" +
+		"cp " + outputFile + " \"$out.json\"\n"
+	for name, script := range map[string]string{"ffmpeg": ffmpeg, "whisper-cli": whisper} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(script), 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir, argsFile
+}
+
+func TestWhisperTranscriber_TranscribeDetailed(t *testing.T) {
+	dir, argsFile := writeFakeWhisperTools(t)
+	modelPath := filepath.Join(dir, "ggml-base.bin")
+	if err := os.WriteFile(modelPath, []byte("model"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	transcriber, err := NewWhisperTranscriber(WhisperConfig{
+		BinaryPath: filepath.Join(dir, "whisper-cli"),
+		FFmpegPath: filepath.Join(dir, "ffmpeg"),
+		ModelPath:  modelPath,
+		Threads:    2,
+	})
+	if err != nil {
+		t.Fatalf("NewWhisperTranscriber() error = %v", err)
+	}
+
+	transcript, err := transcriber.TranscribeDetailed(context.Background(), bytes.NewReader([]byte("audio")), "audio/ogg",
+		media.TranscriptionOptions{Language: "de", Diarize: true})
+	if err != nil {
+		t.Fatalf("TranscribeDetailed() error = %v", err)
+	}
+	if transcript.Text != "Hi there. Hello!" {
+		t.Errorf("Text = %q", transcript.Text)
+	}
+	if transcript.Language != "en" || transcript.Duration != 3*time.Second {
+		t.Errorf("Language = %q, Duration = %v", transcript.Language, transcript.Duration)
+	}
+	if got := transcript.Speakers(); len(got) != 2 {
+		t.Errorf("Speakers() = %v, want 2 speakers", got)
+	}
+	if got := transcript.Format(); got != "Speaker 1: Hi there.\nSpeaker 2: Hello!" {
+		t.Errorf("Format() = %q", got)
+	}
+
+	args, err := os.ReadFile(argsFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"-m " + modelPath, "-l de", "-t 2", "-tdrz", "-oj"} {
+		if !strings.Contains(string(args), want) {
+			t.Errorf("whisper args %q missing %q", args, want)
+		}
+	}
+}
+
+func TestWhisperTranscriber_MissingModel(t *testing.T) {
+	dir, _ := writeFakeWhisperTools(t)
+	_, err := NewWhisperTranscriber(WhisperConfig{
+		BinaryPath: filepath.Join(dir, "whisper-cli"),
+		FFmpegPath: filepath.Join(dir, "ffmpeg"),
+		ModelDir:   filepath.Join(dir, "models"),
+	})
+	if err == nil || !strings.Contains(err.Error(), "auto_download") {
+		t.Fatalf("expected missing model error, got %v", err)
+	}
+}
+
+func TestWhisperTranscriber_AutoDownload(t *testing.T) {
+	dir, _ := writeFakeWhisperTools(t)
+	var requested string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requested = r.URL.Path
+		w.Write([]byte("model-bytes"))
+	}))
+	defer server.Close()
+
+	modelDir := filepath.Join(dir, "models")
+	transcriber, err := NewWhisperTranscriber(WhisperConfig{
+		BinaryPath:   filepath.Join(dir, "whisper-cli"),
+		FFmpegPath:   filepath.Join(dir, "ffmpeg"),
+		Model:        "tiny.en",
+		ModelDir:     modelDir,
+		AutoDownload: true,
+		DownloadURL:  server.URL + "/models/",
+	})
+	if err != nil {
+		t.Fatalf("NewWhisperTranscriber() error = %v", err)
+	}
+
+	text, err := transcriber.Transcribe(bytes.NewReader([]byte("audio")), "audio/wav", "")
+	if err != nil {
+		t.Fatalf("Transcribe() error = %v", err)
+	}
+	if text != "Hi there. Hello!" {
+		t.Errorf("Transcribe() = %q", text)
+	}
+	if requested != "/models/ggml-tiny.en.bin" {
+		t.Errorf("requested %q", requested)
+	}
+	data, err := os.ReadFile(filepath.Join(modelDir, "ggml-tiny.en.bin"))
+	if err != nil || string(data) != "model-bytes" {
+		t.Fatalf("model not downloaded: %q, %v", data, err)
+	}
+}
+
+func TestParseWhisperOutput_NoDiarization(t *testing.T) {
+	transcript, err := parseWhisperOutput([]byte(fakeWhisperOutput), false)
+	if err != nil {
+		t.Fatalf("parseWhisperOutput() error = %v", err)
+	}
+	if len(transcript.Segments) != 2 {
+		t.Fatalf("expected blank segment to be dropped, got %d segments", len(transcript.Segments))
+	}
+	if len(transcript.Speakers()) != 0 {
+		t.Errorf("expected no speakers without diarization")
+	}
+	if transcript.Format() != "Hi there. Hello!" {
+		t.Errorf("Format() = %q", transcript.Format())
+	}
+}
@@ -2,7 +2,10 @@
 package media
 
 import (
+	"context"
+	"fmt"
 	"io"
+	"strings"
 	"time"
 )
 
@@ -94,6 +97,12 @@ type ProcessingResult struct {
 	// Transcription is text from audio/video
 	Transcription string `json:"transcription,omitempty"`
 
+	// Transcript is the structured transcription, when one was produced
+	Transcript *Transcript `json:"transcript,omitempty"`
+
+	// ExtractedText is text read from an image by OCR
+	ExtractedText string `json:"extracted_text,omitempty"`
+
 	// Error is set if processing failed
 	Error string `json:"error,omitempty"`
 
@@ -118,9 +127,15 @@ type ProcessingOptions struct {
 	// EnableVision enables vision model processing
 	EnableVision bool
 
+	// EnableOCR extracts text from images when a text extractor is set
+	EnableOCR bool
+
 	// TranscriptionLanguage for audio transcription
 	TranscriptionLanguage string
 
+	// Diarize labels speakers in transcriptions when the backend supports it
+	Diarize bool
+
 	// Quality for image processing (1-100)
 	Quality int
 
@@ -159,6 +174,111 @@ type Transcriber interface {
 	Transcribe(audio io.Reader, mimeType string, language string) (string, error)
 }
 
+// TextExtractor reads text from images (OCR).
+type TextExtractor interface {
+	// ExtractText returns the text found in the image, or "" when there is none.
+	ExtractText(ctx context.Context, image io.Reader, mimeType string) (string, error)
+}
+
+// TranscriptionOptions configures a detailed transcription.
+type TranscriptionOptions struct {
+	// Language is an ISO 639-1 hint; empty auto-detects.
+	Language string
+
+	// Diarize requests speaker labels on segments.
+	Diarize bool
+}
+
+// DetailedTranscriber is implemented by transcribers that return timed
+// segments. Every backend produces the same Transcript so callers do not
+// depend on which one ran.
+type DetailedTranscriber interface {
+	TranscribeDetailed(ctx context.Context, audio io.Reader, mimeType string, opts TranscriptionOptions) (*Transcript, error)
+}
+
+// Transcript is a backend-independent transcription result.
+type Transcript struct {
+	// Text is the full transcription.
+	Text string `json:"text"`
+
+	// Language is the detected or requested language, when known.
+	Language string `json:"language,omitempty"`
+
+	// Duration is the audio length, when known.
+	Duration time.Duration `json:"duration,omitempty"`
+
+	// Segments are timed spans of the transcription, when available.
+	Segments []TranscriptSegment `json:"segments,omitempty"`
+
+	// Provider names the backend that produced the transcript.
+	Provider string `json:"provider,omitempty"`
+}
+
+// TranscriptSegment is a timed span of a transcript.
+type TranscriptSegment struct {
+	Start   time.Duration `json:"start"`
+	End     time.Duration `json:"end"`
+	Speaker string        `json:"speaker,omitempty"`
+	Text    string        `json:"text"`
+}
+
+// Speakers returns the distinct speaker labels in order of first appearance.
+func (t *Transcript) Speakers() []string {
+	if t == nil {
+		return nil
+	}
+	var speakers []string
+	seen := make(map[string]struct{})
+	for _, seg := range t.Segments {
+		if seg.Speaker == "" {
+			continue
+		}
+		if _, ok := seen[seg.Speaker]; ok {
+			continue
+		}
+		seen[seg.Speaker] = struct{}{}
+		speakers = append(speakers, seg.Speaker)
+	}
+	return speakers
+}
+
+// Format renders the transcript as text. When more than one speaker was
+// identified, each speaker turn is put on its own "Speaker: text" line.
+func (t *Transcript) Format() string {
+	if t == nil {
+		return ""
+	}
+	if len(t.Speakers()) < 2 {
+		return strings.TrimSpace(t.Text)
+	}
+	var lines []string
+	current := ""
+	var turn []string
+	flush := func() {
+		if len(turn) > 0 {
+			lines = append(lines, fmt.Sprintf("%s: %s", current, strings.Join(turn, " ")))
+		}
+		turn = nil
+	}
+	for _, seg := range t.Segments {
+		text := strings.TrimSpace(seg.Text)
+		if text == "" {
+			continue
+		}
+		speaker := seg.Speaker
+		if speaker == "" {
+			speaker = current
+		}
+		if speaker != current {
+			flush()
+			current = speaker
+		}
+		turn = append(turn, text)
+	}
+	flush()
+	return strings.Join(lines, "\n")
+}
+
 // ImageProcessor processes images for vision models.
 type ImageProcessor interface {
 	// PrepareForVision prepares an image for vision model consumption.
@@ -4,6 +4,7 @@ package backend
 
 import (
 	"context"
+	"time"
 
 	"github.com/haasonsaas/nexus/pkg/models"
 )
@@ -29,6 +30,47 @@ type Backend interface {
 	Close() error
 }
 
+// Pruner is implemented by backends that can delete entries in bulk.
+type Pruner interface {
+	// Prune removes entries in the scope created before the cutoff, or every
+	// entry in the scope when before is zero, and returns how many were removed.
+	Prune(ctx context.Context, scope models.MemoryScope, scopeID string, before time.Time) (int64, error)
+}
+
+// ContentRewriter is implemented by backends that can rewrite stored entry
+// content in place, such as when re-encrypting it under a new key.
+type ContentRewriter interface {
+	// RewriteContent passes the content of every entry to rewrite and stores
+	// the result when rewrite reports a change. It returns how many entries
+	// were changed.
+	RewriteContent(ctx context.Context, rewrite func(content string) (string, bool, error)) (int64, error)
+}
+
+// Exporter is implemented by backends that can read back every entry with
+// its embedding, such as when migrating to another backend.
+type Exporter interface {
+	// Export returns up to limit entries starting at cursor, which is empty
+	// for the first page, and the cursor of the next page, which is empty
+	// after the last page. A page may be short before the last one.
+	Export(ctx context.Context, cursor string, limit int) ([]*models.MemoryEntry, string, error)
+}
+
+// Reindexer is implemented by backends that can build a replacement index
+// beside the live one and switch to it atomically, such as when every entry
+// is re-embedded with a model of another dimension.
+type Reindexer interface {
+	// StageIndex returns a backend writing to a new, empty index for
+	// embeddings of the given dimension, replacing any staged index left
+	// over from an earlier attempt. The live index is unaffected until
+	// PromoteIndex.
+	StageIndex(ctx context.Context, dimension int) (Backend, error)
+
+	// PromoteIndex replaces the live index with the staged one in a single
+	// step and drops the old index. The staged backend should only be closed
+	// afterwards.
+	PromoteIndex(ctx context.Context, staged Backend) error
+}
+
 // SearchMode specifies the search algorithm to use.
 type SearchMode string
 
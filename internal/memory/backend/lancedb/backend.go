@@ -39,6 +39,10 @@ type Backend struct {
 	config    Config
 	entries   map[string]*models.MemoryEntry
 	mu        sync.RWMutex
+
+	// promoted is set on a staged backend once its data became the live
+	// data file, so closing it does not write anything.
+	promoted bool
 }
 
 // Config contains configuration for the LanceDB backend.
@@ -340,6 +344,79 @@ func (b *Backend) Count(ctx context.Context, scope models.MemoryScope, scopeID s
 	return count, nil
 }
 
+// Prune removes entries in the scope created before the cutoff.
+func (b *Backend) Prune(ctx context.Context, scope models.MemoryScope, scopeID string, before time.Time) (int64, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	opts := &backend.SearchOptions{Scope: scope, ScopeID: scopeID}
+	var pruned int64
+	for id, entry := range b.entries {
+		if !b.matchesScope(entry, opts) {
+			continue
+		}
+		if !before.IsZero() && !entry.CreatedAt.Before(before) {
+			continue
+		}
+		delete(b.entries, id)
+		pruned++
+	}
+	if pruned == 0 {
+		return 0, nil
+	}
+	return pruned, b.save()
+}
+
+// RewriteContent implements backend.ContentRewriter.
+func (b *Backend) RewriteContent(ctx context.Context, rewrite func(content string) (string, bool, error)) (int64, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	var changed int64
+	for id, entry := range b.entries {
+		content, ok, err := rewrite(entry.Content)
+		if err != nil {
+			return changed, fmt.Errorf("entry %s: %w", id, err)
+		}
+		if !ok {
+			continue
+		}
+		entry.Content = content
+		changed++
+	}
+	if changed == 0 {
+		return 0, nil
+	}
+	return changed, b.save()
+}
+
+// Export implements backend.Exporter. Entries are returned in ID order and
+// the cursor is the last ID returned.
+func (b *Backend) Export(ctx context.Context, cursor string, limit int) ([]*models.MemoryEntry, string, error) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	ids := make([]string, 0, len(b.entries))
+	for id := range b.entries {
+		if id > cursor {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+	if limit <= 0 || limit > len(ids) {
+		limit = len(ids)
+	}
+	entries := make([]*models.MemoryEntry, 0, limit)
+	for _, id := range ids[:limit] {
+		entry := *b.entries[id]
+		entries = append(entries, &entry)
+	}
+	if limit == len(ids) {
+		return entries, "", nil
+	}
+	return entries, ids[limit-1], nil
+}
+
 // Compact rewrites the on-disk representation to drop deleted entries.
 func (b *Backend) Compact(ctx context.Context) error {
 	b.mu.Lock()
@@ -347,9 +424,58 @@ func (b *Backend) Compact(ctx context.Context) error {
 	return b.save()
 }
 
+// stagedDir is the directory under the live path a replacement index is
+// built in.
+const stagedDir = "reembed"
+
+// StageIndex implements backend.Reindexer. The staged index is a fresh data
+// directory inside the live one.
+func (b *Backend) StageIndex(ctx context.Context, dimension int) (backend.Backend, error) {
+	dir := filepath.Join(b.path, stagedDir)
+	if err := os.RemoveAll(dir); err != nil {
+		return nil, fmt.Errorf("failed to remove staged index: %w", err)
+	}
+	cfg := b.config
+	cfg.Path = dir
+	cfg.Dimension = dimension
+	return New(cfg)
+}
+
+// PromoteIndex implements backend.Reindexer. The staged data file is
+// renamed over the live one, which is atomic on POSIX file systems.
+func (b *Backend) PromoteIndex(ctx context.Context, staged backend.Backend) error {
+	s, ok := staged.(*Backend)
+	if !ok || s.path != filepath.Join(b.path, stagedDir) {
+		return fmt.Errorf("index was not staged by this backend")
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if err := s.save(); err != nil {
+		return fmt.Errorf("failed to save staged index: %w", err)
+	}
+	if err := os.Rename(s.dataFile(), b.dataFile()); err != nil {
+		return fmt.Errorf("failed to promote index: %w", err)
+	}
+	b.entries = s.entries
+	b.dimension = s.dimension
+	b.config.Dimension = s.dimension
+	s.entries = make(map[string]*models.MemoryEntry)
+	s.promoted = true
+	if err := os.RemoveAll(s.path); err != nil {
+		slog.Warn("lancedb: failed to remove staged index", "path", s.path, "error", err)
+	}
+	return nil
+}
+
 // Close saves data and releases resources.
 func (b *Backend) Close() error {
 	b.mu.Lock()
 	defer b.mu.Unlock()
+	if b.promoted {
+		return nil
+	}
 	return b.save()
 }
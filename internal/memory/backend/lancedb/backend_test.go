@@ -2,8 +2,10 @@ package lancedb
 
 import (
 	"context"
+	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/haasonsaas/nexus/internal/memory/backend"
 	"github.com/haasonsaas/nexus/pkg/models"
@@ -312,6 +314,43 @@ func TestBackend_Count(t *testing.T) {
 	})
 }
 
+func TestBackend_Prune(t *testing.T) {
+	b, err := New(Config{
+		Path:      filepath.Join(t.TempDir(), "test_prune_db"),
+		Dimension: 128,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create backend: %v", err)
+	}
+	defer b.Close()
+
+	ctx := context.Background()
+	now := time.Now()
+	entries := []*models.MemoryEntry{
+		{ID: "prune-1", ChannelID: "c1", Content: "old", Embedding: makeTestEmbedding(128), CreatedAt: now.Add(-48 * time.Hour)},
+		{ID: "prune-2", ChannelID: "c1", Content: "new", Embedding: makeTestEmbedding(128), CreatedAt: now},
+		{ID: "prune-3", ChannelID: "c2", Content: "other", Embedding: makeTestEmbedding(128), CreatedAt: now.Add(-48 * time.Hour)},
+	}
+	if err := b.Index(ctx, entries); err != nil {
+		t.Fatalf("Failed to index entries: %v", err)
+	}
+
+	pruned, err := b.Prune(ctx, models.ScopeChannel, "c1", now.Add(-time.Hour))
+	if err != nil {
+		t.Fatalf("Prune() error = %v", err)
+	}
+	if pruned != 1 {
+		t.Errorf("Expected 1 pruned entry, got %d", pruned)
+	}
+	count, err := b.Count(ctx, models.ScopeAll, "")
+	if err != nil {
+		t.Fatalf("Count() error = %v", err)
+	}
+	if count != 2 {
+		t.Errorf("Expected 2 remaining entries, got %d", count)
+	}
+}
+
 func TestSimilarityFunctions(t *testing.T) {
 	t.Run("cosine similarity", func(t *testing.T) {
 		a := []float32{1.0, 0.0, 0.0}
@@ -358,3 +397,92 @@ func makeTestEmbedding(dim int) []float32 {
 	}
 	return embedding
 }
+
+func TestBackend_Export(t *testing.T) {
+	b, err := New(Config{Path: filepath.Join(t.TempDir(), "test_export_db"), Dimension: 2})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer b.Close()
+
+	ctx := context.Background()
+	var entries []*models.MemoryEntry
+	for _, id := range []string{"c", "a", "b"} {
+		entries = append(entries, &models.MemoryEntry{ID: id, Content: id, Embedding: []float32{1, 0}})
+	}
+	if err := b.Index(ctx, entries); err != nil {
+		t.Fatalf("Index() error = %v", err)
+	}
+
+	var got []string
+	cursor := ""
+	for page := 0; page < 3; page++ {
+		batch, next, err := b.Export(ctx, cursor, 2)
+		if err != nil {
+			t.Fatalf("Export() error = %v", err)
+		}
+		for _, entry := range batch {
+			if len(entry.Embedding) != 2 {
+				t.Errorf("entry %s exported without its embedding", entry.ID)
+			}
+			got = append(got, entry.ID)
+		}
+		if next == "" {
+			break
+		}
+		cursor = next
+	}
+	if len(got) != 3 || got[0] != "a" || got[1] != "b" || got[2] != "c" {
+		t.Errorf("exported IDs = %v, want [a b c]", got)
+	}
+}
+
+func TestBackend_StageAndPromoteIndex(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "test_reindex_db")
+	b, err := New(Config{Path: dbPath, Dimension: 2})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer b.Close()
+
+	ctx := context.Background()
+	if err := b.Index(ctx, []*models.MemoryEntry{{ID: "a", Content: "old", Embedding: []float32{1, 0}}}); err != nil {
+		t.Fatalf("Index() error = %v", err)
+	}
+	staged, err := b.StageIndex(ctx, 3)
+	if err != nil {
+		t.Fatalf("StageIndex() error = %v", err)
+	}
+	if err := staged.Index(ctx, []*models.MemoryEntry{{ID: "a", Content: "old", Embedding: []float32{0, 1, 0}}}); err != nil {
+		t.Fatalf("staged Index() error = %v", err)
+	}
+	if results, _ := b.Search(ctx, []float32{1, 0}, &backend.SearchOptions{Scope: models.ScopeAll, Limit: 1}); len(results) != 1 {
+		t.Errorf("live index changed before promote: %+v", results)
+	}
+
+	if err := b.PromoteIndex(ctx, staged); err != nil {
+		t.Fatalf("PromoteIndex() error = %v", err)
+	}
+	if err := staged.Close(); err != nil {
+		t.Errorf("staged Close() error = %v", err)
+	}
+	if err := b.Index(ctx, []*models.MemoryEntry{{ID: "b", Content: "new", Embedding: []float32{1, 0}}}); err == nil {
+		t.Error("Index() accepted the old dimension after promote")
+	}
+
+	reopened, err := New(Config{Path: dbPath, Dimension: 3})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer reopened.Close()
+	if count, err := reopened.Count(ctx, models.ScopeAll, ""); err != nil || count != 1 {
+		t.Errorf("Count() after reopen = %d, %v", count, err)
+	}
+	results, err := b.Search(ctx, []float32{0, 1, 0}, &backend.SearchOptions{Scope: models.ScopeAll, Limit: 1})
+	if err != nil || len(results) != 1 || results[0].Score < 0.99 {
+		t.Errorf("Search() after promote = %+v, %v", results, err)
+	}
+	if _, err := os.Stat(filepath.Join(dbPath, stagedDir)); !os.IsNotExist(err) {
+		t.Errorf("staged directory left behind: %v", err)
+	}
+}
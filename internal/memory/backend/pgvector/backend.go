@@ -22,10 +22,21 @@ import (
 //go:embed migrations/*.sql
 var migrationsFS embed.FS
 
+// rewriteBatchSize bounds how many entries RewriteContent reads per query.
+const rewriteBatchSize = 500
+
+// defaultTable holds the live entries; stagedSuffix names the table a
+// replacement index is built in.
+const (
+	defaultTable = "memories"
+	stagedSuffix = "_reembed"
+)
+
 // Backend implements the backend.Backend interface using pgvector.
 type Backend struct {
 	db        *sql.DB
 	dimension int
+	table     string
 	ownsDB    bool // whether this backend owns the db connection and should close it
 }
 
@@ -83,6 +94,7 @@ func New(cfg Config) (*Backend, error) {
 	b := &Backend{
 		db:        db,
 		dimension: cfg.Dimension,
+		table:     defaultTable,
 		ownsDB:    ownsDB,
 	}
 
@@ -199,7 +211,7 @@ func (b *Backend) Index(ctx context.Context, entries []*models.MemoryEntry) erro
 	}()
 
 	stmt, err := tx.PrepareContext(ctx, `
-		INSERT INTO memories (id, session_id, channel_id, agent_id, content, metadata, embedding, created_at, updated_at)
+		INSERT INTO `+b.table+` (id, session_id, channel_id, agent_id, content, metadata, embedding, created_at, updated_at)
 		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
 		ON CONFLICT (id) DO UPDATE SET
 			session_id = EXCLUDED.session_id,
@@ -284,7 +296,7 @@ func (b *Backend) searchVector(ctx context.Context, queryEmbedding []float32, op
 			id, session_id, channel_id, agent_id, content, metadata,
 			embedding, created_at, updated_at,
 			1 - (embedding <=> $1::vector) as similarity
-		FROM memories
+		FROM ` + b.table + `
 		WHERE embedding IS NOT NULL
 	`
 	args := []any{queryVec}
@@ -322,7 +334,7 @@ func (b *Backend) searchBM25(ctx context.Context, opts *backend.SearchOptions) (
 			id, session_id, channel_id, agent_id, content, metadata,
 			embedding, created_at, updated_at,
 			ts_rank_cd(content_tsv, plainto_tsquery('english', $1)) as similarity
-		FROM memories
+		FROM ` + b.table + `
 		WHERE content_tsv @@ plainto_tsquery('english', $1)
 	`
 	args := []any{opts.Query}
@@ -376,7 +388,7 @@ func (b *Backend) searchHybrid(ctx context.Context, queryEmbedding []float32, op
 				embedding, created_at, updated_at,
 				1 - (embedding <=> $1::vector) as vec_score,
 				ROW_NUMBER() OVER (ORDER BY embedding <=> $1::vector ASC) as vec_rank
-			FROM memories
+			FROM ` + b.table + `
 			WHERE embedding IS NOT NULL
 		),
 		bm25_results AS (
@@ -384,7 +396,7 @@ func (b *Backend) searchHybrid(ctx context.Context, queryEmbedding []float32, op
 				id,
 				ts_rank_cd(content_tsv, plainto_tsquery('english', $2)) as bm25_score,
 				ROW_NUMBER() OVER (ORDER BY ts_rank_cd(content_tsv, plainto_tsquery('english', $2)) DESC) as bm25_rank
-			FROM memories
+			FROM ` + b.table + `
 			WHERE content_tsv @@ plainto_tsquery('english', $2)
 		),
 		combined AS (
@@ -488,13 +500,13 @@ func (b *Backend) Delete(ctx context.Context, ids []string) error {
 		return nil
 	}
 
-	_, err := b.db.ExecContext(ctx, "DELETE FROM memories WHERE id = ANY($1::uuid[])", pq.Array(ids))
+	_, err := b.db.ExecContext(ctx, "DELETE FROM "+b.table+" WHERE id = ANY($1::uuid[])", pq.Array(ids))
 	return err
 }
 
 // Count returns the number of entries matching the scope.
 func (b *Backend) Count(ctx context.Context, scope models.MemoryScope, scopeID string) (int64, error) {
-	query := "SELECT COUNT(*) FROM memories WHERE 1=1"
+	query := "SELECT COUNT(*) FROM " + b.table + " WHERE 1=1"
 	args := []any{}
 	argNum := 1
 
@@ -517,12 +529,177 @@ func (b *Backend) Count(ctx context.Context, scope models.MemoryScope, scopeID s
 	return count, err
 }
 
+// Prune removes entries in the scope created before the cutoff.
+func (b *Backend) Prune(ctx context.Context, scope models.MemoryScope, scopeID string, before time.Time) (int64, error) {
+	query := "DELETE FROM " + b.table + " WHERE 1=1"
+	args := []any{}
+	argNum := 1
+
+	switch scope {
+	case models.ScopeSession:
+		query += fmt.Sprintf(" AND session_id = $%d", argNum)
+		args = append(args, scopeID)
+		argNum++
+	case models.ScopeChannel:
+		query += fmt.Sprintf(" AND channel_id = $%d", argNum)
+		args = append(args, scopeID)
+		argNum++
+	case models.ScopeAgent:
+		query += fmt.Sprintf(" AND agent_id = $%d", argNum)
+		args = append(args, scopeID)
+		argNum++
+	case models.ScopeGlobal:
+		query += " AND (session_id IS NULL OR session_id = '') AND (channel_id IS NULL OR channel_id = '') AND (agent_id IS NULL OR agent_id = '')"
+	}
+	if !before.IsZero() {
+		query += fmt.Sprintf(" AND created_at < $%d", argNum)
+		args = append(args, before)
+	}
+
+	result, err := b.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+// RewriteContent implements backend.ContentRewriter.
+func (b *Backend) RewriteContent(ctx context.Context, rewrite func(content string) (string, bool, error)) (int64, error) {
+	var changed int64
+	cursor := uuid.Nil.String()
+	for {
+		rows, err := b.db.QueryContext(ctx,
+			"SELECT id, content FROM "+b.table+" WHERE id > $1 ORDER BY id LIMIT $2", cursor, rewriteBatchSize)
+		if err != nil {
+			return changed, fmt.Errorf("failed to query: %w", err)
+		}
+		type update struct{ id, old, new string }
+		var updates []update
+		count := 0
+		for rows.Next() {
+			var id, content string
+			if err := rows.Scan(&id, &content); err != nil {
+				rows.Close()
+				return changed, fmt.Errorf("failed to scan row: %w", err)
+			}
+			count++
+			cursor = id
+			rewritten, ok, err := rewrite(content)
+			if err != nil {
+				rows.Close()
+				return changed, fmt.Errorf("entry %s: %w", id, err)
+			}
+			if ok {
+				updates = append(updates, update{id: id, old: content, new: rewritten})
+			}
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return changed, err
+		}
+
+		for _, u := range updates {
+			result, err := b.db.ExecContext(ctx,
+				"UPDATE "+b.table+" SET content = $1 WHERE id = $2 AND content = $3", u.new, u.id, u.old)
+			if err != nil {
+				return changed, fmt.Errorf("failed to update %s: %w", u.id, err)
+			}
+			if n, err := result.RowsAffected(); err == nil {
+				changed += n
+			}
+		}
+		if count < rewriteBatchSize {
+			return changed, nil
+		}
+	}
+}
+
+// Export implements backend.Exporter. The cursor is the last ID returned.
+func (b *Backend) Export(ctx context.Context, cursor string, limit int) ([]*models.MemoryEntry, string, error) {
+	if limit <= 0 {
+		limit = rewriteBatchSize
+	}
+	if cursor == "" {
+		cursor = uuid.Nil.String()
+	}
+	rows, err := b.db.QueryContext(ctx, `
+		SELECT
+			id, session_id, channel_id, agent_id, content, metadata,
+			embedding, created_at, updated_at, 0
+		FROM `+b.table+`
+		WHERE id > $1
+		ORDER BY id
+		LIMIT $2
+	`, cursor, limit)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to query: %w", err)
+	}
+	defer rows.Close()
+
+	var entries []*models.MemoryEntry
+	for rows.Next() {
+		entry, _, err := scanEntry(rows)
+		if err != nil {
+			return nil, "", err
+		}
+		entries = append(entries, entry)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, "", fmt.Errorf("rows error: %w", err)
+	}
+	if len(entries) < limit {
+		return entries, "", nil
+	}
+	return entries, entries[len(entries)-1].ID, nil
+}
+
 // Compact optimizes the database by running VACUUM ANALYZE.
 func (b *Backend) Compact(ctx context.Context) error {
-	_, err := b.db.ExecContext(ctx, "VACUUM ANALYZE memories")
+	_, err := b.db.ExecContext(ctx, "VACUUM ANALYZE "+b.table)
 	return err
 }
 
+// StageIndex implements backend.Reindexer. The staged index is a copy of
+// the live table's structure, including its indexes, replacing any left over
+// from an earlier run.
+func (b *Backend) StageIndex(ctx context.Context, dimension int) (backend.Backend, error) {
+	staged := &Backend{db: b.db, dimension: dimension, table: b.table + stagedSuffix}
+	if _, err := b.db.ExecContext(ctx, "DROP TABLE IF EXISTS "+staged.table); err != nil {
+		return nil, fmt.Errorf("failed to drop staged table: %w", err)
+	}
+	if _, err := b.db.ExecContext(ctx, fmt.Sprintf("CREATE TABLE %s (LIKE %s INCLUDING ALL)", staged.table, b.table)); err != nil {
+		return nil, fmt.Errorf("failed to create staged table: %w", err)
+	}
+	return staged, nil
+}
+
+// PromoteIndex implements backend.Reindexer. The live table is dropped and
+// the staged table renamed in its place in one transaction, so other
+// gateways sharing the database switch over on their next query.
+func (b *Backend) PromoteIndex(ctx context.Context, staged backend.Backend) error {
+	s, ok := staged.(*Backend)
+	if !ok || s.db != b.db || s.table == b.table {
+		return fmt.Errorf("index was not staged by this backend")
+	}
+	tx, err := b.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if _, err := tx.ExecContext(ctx, "DROP TABLE "+b.table); err != nil {
+		return fmt.Errorf("failed to drop live table: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx, fmt.Sprintf("ALTER TABLE %s RENAME TO %s", s.table, b.table)); err != nil {
+		return fmt.Errorf("failed to rename staged table: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to promote index: %w", err)
+	}
+	b.dimension = s.dimension
+	return nil
+}
+
 // Close releases resources.
 func (b *Backend) Close() error {
 	if b.ownsDB && b.db != nil {
@@ -0,0 +1,513 @@
+// Package qdrant provides a vector storage backend using the Qdrant REST API.
+package qdrant
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/haasonsaas/nexus/internal/memory/backend"
+	"github.com/haasonsaas/nexus/pkg/models"
+)
+
+// pageSize bounds how many points are read per scroll request.
+const pageSize = 256
+
+// pointNamespace derives point IDs for entry IDs that are not UUIDs, since
+// Qdrant only accepts UUIDs and integers.
+var pointNamespace = uuid.MustParse("5b0c7c39-3f55-4a3e-9d1a-6f3a2a1c8e42")
+
+// Payload fields that carry a keyword index.
+var keywordFields = []string{"id", "session_id", "channel_id", "agent_id", "metadata.source", "metadata.tags"}
+
+// Backend implements the backend.Backend interface using Qdrant.
+type Backend struct {
+	baseURL    string
+	apiKey     string
+	collection string
+	dimension  int
+	client     *http.Client
+}
+
+// Config contains configuration for the Qdrant backend.
+type Config struct {
+	// URL is the Qdrant REST endpoint. Default: http://localhost:6333.
+	URL string
+
+	// APIKey is sent as the api-key header when set.
+	APIKey string
+
+	// Collection holds the memories. Default: nexus_memories.
+	Collection string
+
+	// Dimension is the embedding dimension.
+	Dimension int
+
+	// Distance is the vector distance: Cosine (default), Dot, or Euclid.
+	Distance string
+
+	// Timeout bounds each request. Default: 30s.
+	Timeout time.Duration
+
+	// HTTPClient overrides the HTTP client (tests).
+	HTTPClient *http.Client
+}
+
+// New creates a Qdrant backend, creating the collection and its payload
+// indexes when the collection does not exist.
+func New(cfg Config) (*Backend, error) {
+	if cfg.URL == "" {
+		cfg.URL = "http://localhost:6333"
+	}
+	if cfg.Collection == "" {
+		cfg.Collection = "nexus_memories"
+	}
+	if cfg.Dimension == 0 {
+		cfg.Dimension = 1536 // Default to OpenAI text-embedding-3-small
+	}
+	if cfg.Distance == "" {
+		cfg.Distance = "Cosine"
+	}
+	if cfg.Timeout == 0 {
+		cfg.Timeout = 30 * time.Second
+	}
+	client := cfg.HTTPClient
+	if client == nil {
+		client = &http.Client{Timeout: cfg.Timeout}
+	}
+	b := &Backend{
+		baseURL:    strings.TrimRight(cfg.URL, "/"),
+		apiKey:     cfg.APIKey,
+		collection: cfg.Collection,
+		dimension:  cfg.Dimension,
+		client:     client,
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.Timeout)
+	defer cancel()
+	if err := b.ensureCollection(ctx, cfg.Distance); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// ensureCollection creates the collection when it is missing and checks the
+// vector size of an existing one.
+func (b *Backend) ensureCollection(ctx context.Context, distance string) error {
+	var info struct {
+		Config struct {
+			Params struct {
+				Vectors struct {
+					Size int `json:"size"`
+				} `json:"vectors"`
+			} `json:"params"`
+		} `json:"config"`
+	}
+	err := b.do(ctx, http.MethodGet, b.collectionPath(""), nil, &info)
+	if err == nil {
+		if size := info.Config.Params.Vectors.Size; size != 0 && size != b.dimension {
+			return fmt.Errorf("qdrant collection %q has vector size %d, expected %d", b.collection, size, b.dimension)
+		}
+		return nil
+	}
+	if !errors.Is(err, errNotFound) {
+		return fmt.Errorf("failed to read qdrant collection: %w", err)
+	}
+
+	create := map[string]any{
+		"vectors": map[string]any{"size": b.dimension, "distance": distance},
+	}
+	if err := b.do(ctx, http.MethodPut, b.collectionPath(""), create, nil); err != nil {
+		return fmt.Errorf("failed to create qdrant collection: %w", err)
+	}
+	for _, field := range keywordFields {
+		index := map[string]any{"field_name": field, "field_schema": "keyword"}
+		if err := b.do(ctx, http.MethodPut, b.collectionPath("/index?wait=true"), index, nil); err != nil {
+			return fmt.Errorf("failed to index payload field %s: %w", field, err)
+		}
+	}
+	index := map[string]any{"field_name": "created_unix", "field_schema": "integer"}
+	if err := b.do(ctx, http.MethodPut, b.collectionPath("/index?wait=true"), index, nil); err != nil {
+		return fmt.Errorf("failed to index payload field created_unix: %w", err)
+	}
+	return nil
+}
+
+// point is a Qdrant point as sent and received.
+type point struct {
+	ID      string         `json:"id"`
+	Vector  []float32      `json:"vector,omitempty"`
+	Payload map[string]any `json:"payload,omitempty"`
+	Score   float32        `json:"score,omitempty"`
+}
+
+// Index stores memory entries with their embeddings.
+func (b *Backend) Index(ctx context.Context, entries []*models.MemoryEntry) error {
+	if len(entries) == 0 {
+		return nil
+	}
+	points := make([]point, 0, len(entries))
+	for _, entry := range entries {
+		if entry.ID == "" {
+			entry.ID = uuid.New().String()
+		}
+		if entry.CreatedAt.IsZero() {
+			entry.CreatedAt = time.Now()
+		}
+		entry.UpdatedAt = time.Now()
+		if len(entry.Embedding) == 0 {
+			return fmt.Errorf("entry %s has no embedding", entry.ID)
+		}
+		if len(entry.Embedding) != b.dimension {
+			return fmt.Errorf("embedding dimension mismatch: got %d, expected %d", len(entry.Embedding), b.dimension)
+		}
+		payload, err := entryPayload(entry)
+		if err != nil {
+			return err
+		}
+		points = append(points, point{ID: pointID(entry.ID), Vector: entry.Embedding, Payload: payload})
+	}
+	return b.do(ctx, http.MethodPut, b.collectionPath("/points?wait=true"), map[string]any{"points": points}, nil)
+}
+
+// Search finds similar entries using the query embedding. Qdrant keeps no
+// full-text index here, so BM25 and hybrid modes run as vector search.
+func (b *Backend) Search(ctx context.Context, queryEmbedding []float32, opts *backend.SearchOptions) ([]*models.SearchResult, error) {
+	if opts == nil {
+		opts = &backend.SearchOptions{Limit: 10}
+	}
+	if opts.Limit <= 0 {
+		opts.Limit = 10
+	}
+	req := map[string]any{
+		"vector":       queryEmbedding,
+		"limit":        opts.Limit,
+		"with_payload": true,
+	}
+	if opts.Threshold > 0 {
+		req["score_threshold"] = opts.Threshold
+	}
+	if filter := searchFilter(opts.Scope, opts.ScopeID, opts.Filters, time.Time{}); filter != nil {
+		req["filter"] = filter
+	}
+	var hits []point
+	if err := b.do(ctx, http.MethodPost, b.collectionPath("/points/search"), req, &hits); err != nil {
+		return nil, fmt.Errorf("failed to search: %w", err)
+	}
+	results := make([]*models.SearchResult, 0, len(hits))
+	for _, hit := range hits {
+		entry, err := payloadEntry(hit.Payload)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, &models.SearchResult{Entry: entry, Score: hit.Score})
+	}
+	return results, nil
+}
+
+// Delete removes entries by ID.
+func (b *Backend) Delete(ctx context.Context, ids []string) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	points := make([]string, 0, len(ids))
+	for _, id := range ids {
+		points = append(points, pointID(id))
+	}
+	return b.do(ctx, http.MethodPost, b.collectionPath("/points/delete?wait=true"), map[string]any{"points": points}, nil)
+}
+
+// Count returns the number of entries matching the scope.
+func (b *Backend) Count(ctx context.Context, scope models.MemoryScope, scopeID string) (int64, error) {
+	return b.count(ctx, searchFilter(scope, scopeID, nil, time.Time{}))
+}
+
+func (b *Backend) count(ctx context.Context, filter map[string]any) (int64, error) {
+	req := map[string]any{"exact": true}
+	if filter != nil {
+		req["filter"] = filter
+	}
+	var result struct {
+		Count int64 `json:"count"`
+	}
+	if err := b.do(ctx, http.MethodPost, b.collectionPath("/points/count"), req, &result); err != nil {
+		return 0, fmt.Errorf("failed to count: %w", err)
+	}
+	return result.Count, nil
+}
+
+// Prune removes entries in the scope created before the cutoff.
+func (b *Backend) Prune(ctx context.Context, scope models.MemoryScope, scopeID string, before time.Time) (int64, error) {
+	filter := searchFilter(scope, scopeID, nil, before)
+	if filter == nil {
+		filter = map[string]any{"must": []any{}}
+	}
+	n, err := b.count(ctx, filter)
+	if err != nil || n == 0 {
+		return 0, err
+	}
+	if err := b.do(ctx, http.MethodPost, b.collectionPath("/points/delete?wait=true"), map[string]any{"filter": filter}, nil); err != nil {
+		return 0, fmt.Errorf("failed to prune: %w", err)
+	}
+	return n, nil
+}
+
+// Export implements backend.Exporter. The cursor is Qdrant's scroll offset.
+func (b *Backend) Export(ctx context.Context, cursor string, limit int) ([]*models.MemoryEntry, string, error) {
+	if limit <= 0 {
+		limit = pageSize
+	}
+	points, next, err := b.scroll(ctx, cursor, limit, true)
+	if err != nil {
+		return nil, "", err
+	}
+	entries := make([]*models.MemoryEntry, 0, len(points))
+	for _, p := range points {
+		entry, err := payloadEntry(p.Payload)
+		if err != nil {
+			return nil, "", err
+		}
+		entry.Embedding = p.Vector
+		entries = append(entries, entry)
+	}
+	return entries, next, nil
+}
+
+// RewriteContent implements backend.ContentRewriter.
+func (b *Backend) RewriteContent(ctx context.Context, rewrite func(content string) (string, bool, error)) (int64, error) {
+	var changed int64
+	cursor := ""
+	for {
+		points, next, err := b.scroll(ctx, cursor, pageSize, false)
+		if err != nil {
+			return changed, err
+		}
+		for _, p := range points {
+			content, _ := p.Payload["content"].(string)
+			rewritten, ok, err := rewrite(content)
+			if err != nil {
+				return changed, fmt.Errorf("entry %v: %w", p.Payload["id"], err)
+			}
+			if !ok {
+				continue
+			}
+			req := map[string]any{"payload": map[string]any{"content": rewritten}, "points": []string{p.ID}}
+			if err := b.do(ctx, http.MethodPost, b.collectionPath("/points/payload?wait=true"), req, nil); err != nil {
+				return changed, fmt.Errorf("failed to update %v: %w", p.Payload["id"], err)
+			}
+			changed++
+		}
+		if next == "" {
+			return changed, nil
+		}
+		cursor = next
+	}
+}
+
+// scroll reads one page of points in point ID order.
+func (b *Backend) scroll(ctx context.Context, offset string, limit int, withVector bool) ([]point, string, error) {
+	req := map[string]any{
+		"limit":        limit,
+		"with_payload": true,
+		"with_vector":  withVector,
+	}
+	if offset != "" {
+		req["offset"] = offset
+	}
+	var result struct {
+		Points         []point `json:"points"`
+		NextPageOffset any     `json:"next_page_offset"`
+	}
+	if err := b.do(ctx, http.MethodPost, b.collectionPath("/points/scroll"), req, &result); err != nil {
+		return nil, "", fmt.Errorf("failed to scroll: %w", err)
+	}
+	next := ""
+	if result.NextPageOffset != nil {
+		next = fmt.Sprint(result.NextPageOffset)
+	}
+	return result.Points, next, nil
+}
+
+// Compact is a no-op; Qdrant optimizes segments in the background.
+func (b *Backend) Compact(ctx context.Context) error {
+	return nil
+}
+
+// Close releases resources.
+func (b *Backend) Close() error {
+	b.client.CloseIdleConnections()
+	return nil
+}
+
+// errNotFound is returned by do for a 404 response.
+var errNotFound = errors.New("not found")
+
+func (b *Backend) collectionPath(suffix string) string {
+	return "/collections/" + url.PathEscape(b.collection) + suffix
+}
+
+// do sends a JSON request and decodes the "result" field of the response
+// into out.
+func (b *Backend) do(ctx context.Context, method, path string, body, out any) error {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("encode request: %w", err)
+		}
+		reader = bytes.NewReader(data)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, b.baseURL+path, reader)
+	if err != nil {
+		return err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if b.apiKey != "" {
+		req.Header.Set("api-key", b.apiKey)
+	}
+	resp, err := b.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, 64<<20))
+	if err != nil {
+		return fmt.Errorf("read response: %w", err)
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		return errNotFound
+	}
+	var envelope struct {
+		Result json.RawMessage `json:"result"`
+		Status any             `json:"status"`
+	}
+	if err := json.Unmarshal(data, &envelope); err != nil && resp.StatusCode < 300 {
+		return fmt.Errorf("decode response: %w", err)
+	}
+	if resp.StatusCode >= 300 {
+		if status, ok := envelope.Status.(map[string]any); ok && status["error"] != nil {
+			return fmt.Errorf("qdrant: %v (HTTP %d)", status["error"], resp.StatusCode)
+		}
+		return fmt.Errorf("qdrant: HTTP %d", resp.StatusCode)
+	}
+	if out == nil || len(envelope.Result) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(envelope.Result, out); err != nil {
+		return fmt.Errorf("decode result: %w", err)
+	}
+	return nil
+}
+
+// pointID returns the Qdrant point ID of an entry ID.
+func pointID(id string) string {
+	if parsed, err := uuid.Parse(id); err == nil {
+		return parsed.String()
+	}
+	return uuid.NewSHA1(pointNamespace, []byte(id)).String()
+}
+
+// entryPayload returns the payload stored for an entry. Empty scope IDs are
+// left out so that global entries match is_empty conditions.
+func entryPayload(entry *models.MemoryEntry) (map[string]any, error) {
+	metadata, err := json.Marshal(entry.Metadata)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal metadata: %w", err)
+	}
+	var meta map[string]any
+	if err := json.Unmarshal(metadata, &meta); err != nil {
+		return nil, fmt.Errorf("failed to marshal metadata: %w", err)
+	}
+	payload := map[string]any{
+		"id":           entry.ID,
+		"content":      entry.Content,
+		"metadata":     meta,
+		"created_at":   entry.CreatedAt.UTC().Format(time.RFC3339Nano),
+		"updated_at":   entry.UpdatedAt.UTC().Format(time.RFC3339Nano),
+		"created_unix": entry.CreatedAt.Unix(),
+	}
+	if entry.SessionID != "" {
+		payload["session_id"] = entry.SessionID
+	}
+	if entry.ChannelID != "" {
+		payload["channel_id"] = entry.ChannelID
+	}
+	if entry.AgentID != "" {
+		payload["agent_id"] = entry.AgentID
+	}
+	return payload, nil
+}
+
+// payloadEntry rebuilds an entry from its payload.
+func payloadEntry(payload map[string]any) (*models.MemoryEntry, error) {
+	entry := &models.MemoryEntry{}
+	entry.ID, _ = payload["id"].(string)
+	entry.SessionID, _ = payload["session_id"].(string)
+	entry.ChannelID, _ = payload["channel_id"].(string)
+	entry.AgentID, _ = payload["agent_id"].(string)
+	entry.Content, _ = payload["content"].(string)
+	if raw, ok := payload["metadata"]; ok && raw != nil {
+		data, err := json.Marshal(raw)
+		if err != nil {
+			return nil, fmt.Errorf("failed to unmarshal metadata: %w", err)
+		}
+		if err := json.Unmarshal(data, &entry.Metadata); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal metadata: %w", err)
+		}
+	}
+	if raw, ok := payload["created_at"].(string); ok {
+		entry.CreatedAt, _ = time.Parse(time.RFC3339Nano, raw)
+	}
+	if raw, ok := payload["updated_at"].(string); ok {
+		entry.UpdatedAt, _ = time.Parse(time.RFC3339Nano, raw)
+	}
+	return entry, nil
+}
+
+// searchFilter builds a Qdrant filter for a scope, metadata filters (source,
+// role, or tags), and a creation cutoff. It returns nil when nothing is
+// filtered.
+func searchFilter(scope models.MemoryScope, scopeID string, filters map[string]any, before time.Time) map[string]any {
+	var must []any
+	match := func(key string, value any) {
+		must = append(must, map[string]any{"key": key, "match": map[string]any{"value": value}})
+	}
+	switch scope {
+	case models.ScopeSession:
+		match("session_id", scopeID)
+	case models.ScopeChannel:
+		match("channel_id", scopeID)
+	case models.ScopeAgent:
+		match("agent_id", scopeID)
+	case models.ScopeGlobal:
+		for _, key := range []string{"session_id", "channel_id", "agent_id"} {
+			must = append(must, map[string]any{"is_empty": map[string]any{"key": key}})
+		}
+	}
+	for key, value := range filters {
+		switch key {
+		case "source", "role":
+			match("metadata."+key, value)
+		case "tag", "tags":
+			match("metadata.tags", value)
+		}
+	}
+	if !before.IsZero() {
+		must = append(must, map[string]any{"key": "created_unix", "range": map[string]any{"lt": before.Unix()}})
+	}
+	if len(must) == 0 {
+		return nil
+	}
+	return map[string]any{"must": must}
+}
@@ -0,0 +1,233 @@
+package qdrant
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/haasonsaas/nexus/internal/memory/backend"
+	"github.com/haasonsaas/nexus/pkg/models"
+)
+
+// fakeQdrant serves the subset of the Qdrant REST API used by the backend.
+type fakeQdrant struct {
+	mu       sync.Mutex
+	exists   bool
+	size     int
+	points   map[string]point
+	indexes  []string
+	requests []string
+	bodies   map[string]map[string]any
+}
+
+func newFakeQdrant(t *testing.T) (*fakeQdrant, *httptest.Server) {
+	t.Helper()
+	f := &fakeQdrant{points: map[string]point{}, bodies: map[string]map[string]any{}}
+	srv := httptest.NewServer(http.HandlerFunc(f.serve))
+	t.Cleanup(srv.Close)
+	return f, srv
+}
+
+func (f *fakeQdrant) serve(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	route := r.Method + " " + strings.TrimPrefix(r.URL.Path, "/collections/mem")
+	f.requests = append(f.requests, route)
+	var body map[string]any
+	_ = json.NewDecoder(r.Body).Decode(&body)
+	f.bodies[route] = body
+
+	reply := func(result any) {
+		_ = json.NewEncoder(w).Encode(map[string]any{"result": result, "status": "ok"})
+	}
+	switch route {
+	case "GET ":
+		if !f.exists {
+			w.WriteHeader(http.StatusNotFound)
+			_ = json.NewEncoder(w).Encode(map[string]any{"status": map[string]any{"error": "Not found"}})
+			return
+		}
+		reply(map[string]any{"config": map[string]any{"params": map[string]any{"vectors": map[string]any{"size": f.size}}}})
+	case "PUT ":
+		f.exists = true
+		f.size = int(body["vectors"].(map[string]any)["size"].(float64))
+		reply(true)
+	case "PUT /index":
+		f.indexes = append(f.indexes, body["field_name"].(string))
+		reply(map[string]any{})
+	case "PUT /points":
+		data, _ := json.Marshal(body["points"])
+		var points []point
+		_ = json.Unmarshal(data, &points)
+		for _, p := range points {
+			f.points[p.ID] = p
+		}
+		reply(map[string]any{})
+	case "POST /points/search":
+		var hits []point
+		for _, p := range f.points {
+			hits = append(hits, point{ID: p.ID, Payload: p.Payload, Score: 0.9})
+		}
+		reply(hits)
+	case "POST /points/count":
+		reply(map[string]any{"count": len(f.points)})
+	case "POST /points/delete":
+		if ids, ok := body["points"].([]any); ok {
+			for _, id := range ids {
+				delete(f.points, id.(string))
+			}
+		} else {
+			f.points = map[string]point{}
+		}
+		reply(map[string]any{})
+	case "POST /points/scroll":
+		var page []point
+		for _, p := range f.points {
+			page = append(page, p)
+		}
+		reply(map[string]any{"points": page, "next_page_offset": nil})
+	case "POST /points/payload":
+		for _, id := range body["points"].([]any) {
+			p := f.points[id.(string)]
+			p.Payload["content"] = body["payload"].(map[string]any)["content"]
+			f.points[id.(string)] = p
+		}
+		reply(map[string]any{})
+	default:
+		w.WriteHeader(http.StatusBadRequest)
+		_ = json.NewEncoder(w).Encode(map[string]any{"status": map[string]any{"error": "unexpected " + route}})
+	}
+}
+
+func newTestBackend(t *testing.T) (*Backend, *fakeQdrant) {
+	t.Helper()
+	f, srv := newFakeQdrant(t)
+	b, err := New(Config{URL: srv.URL, Collection: "mem", Dimension: 3})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	t.Cleanup(func() { b.Close() })
+	return b, f
+}
+
+func TestNewCreatesCollection(t *testing.T) {
+	b, f := newTestBackend(t)
+	if !f.exists || f.size != 3 {
+		t.Fatalf("collection exists=%v size=%d", f.exists, f.size)
+	}
+	if got := strings.Join(f.indexes, ","); got != "id,session_id,channel_id,agent_id,metadata.source,metadata.tags,created_unix" {
+		t.Errorf("payload indexes = %s", got)
+	}
+	b.Close()
+
+	// An existing collection with another vector size is rejected.
+	srv := httptest.NewServer(http.HandlerFunc(f.serve))
+	defer srv.Close()
+	if _, err := New(Config{URL: srv.URL, Collection: "mem", Dimension: 4}); err == nil {
+		t.Error("New() accepted a dimension mismatch")
+	}
+}
+
+func TestIndexSearchAndExport(t *testing.T) {
+	b, f := newTestBackend(t)
+	ctx := context.Background()
+	created := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	entries := []*models.MemoryEntry{
+		{ID: "note-1", SessionID: "s1", Content: "likes tea", Embedding: []float32{1, 0, 0}, CreatedAt: created,
+			Metadata: models.MemoryMetadata{Source: "note", Tags: []string{"prefs"}}},
+		{ID: "9b2e8a0c-5d1f-4c3b-8a7e-2f6d9c4b1a05", Content: "global fact", Embedding: []float32{0, 1, 0}},
+	}
+	if err := b.Index(ctx, entries); err != nil {
+		t.Fatalf("Index() error = %v", err)
+	}
+	if _, ok := f.points[entries[1].ID]; !ok {
+		t.Error("UUID entry ID was not used as the point ID")
+	}
+	if p, ok := f.points[pointID("note-1")]; !ok || p.Payload["id"] != "note-1" || p.Payload["created_unix"] != float64(created.Unix()) {
+		t.Errorf("point for note-1 = %+v", p)
+	}
+	if _, ok := f.points[pointID(entries[1].ID)].Payload["session_id"]; ok {
+		t.Error("empty session_id stored in payload")
+	}
+	if err := b.Index(ctx, []*models.MemoryEntry{{Content: "bad", Embedding: []float32{1}}}); err == nil {
+		t.Error("Index() accepted a dimension mismatch")
+	}
+
+	results, err := b.Search(ctx, []float32{1, 0, 0}, &backend.SearchOptions{
+		Scope: models.ScopeSession, ScopeID: "s1", Limit: 5, Threshold: 0.5,
+		Filters: map[string]any{"source": "note"},
+	})
+	if err != nil {
+		t.Fatalf("Search() error = %v", err)
+	}
+	if len(results) != 2 || results[0].Score != 0.9 {
+		t.Errorf("Search() = %+v", results)
+	}
+	req := f.bodies["POST /points/search"]
+	filter, _ := json.Marshal(req["filter"])
+	if !strings.Contains(string(filter), `{"key":"session_id","match":{"value":"s1"}}`) ||
+		!strings.Contains(string(filter), `{"key":"metadata.source","match":{"value":"note"}}`) ||
+		req["score_threshold"] != 0.5 {
+		t.Errorf("search request = %+v", req)
+	}
+
+	exported, next, err := b.Export(ctx, "", 10)
+	if err != nil || next != "" || len(exported) != 2 {
+		t.Fatalf("Export() = %d entries, next %q, error %v", len(exported), next, err)
+	}
+	for _, entry := range exported {
+		if entry.ID == "note-1" && (entry.SessionID != "s1" || entry.Metadata.Tags[0] != "prefs" ||
+			!entry.CreatedAt.Equal(created) || len(entry.Embedding) != 3) {
+			t.Errorf("exported note-1 = %+v", entry)
+		}
+	}
+}
+
+func TestCountPruneRewriteDelete(t *testing.T) {
+	b, f := newTestBackend(t)
+	ctx := context.Background()
+	if err := b.Index(ctx, []*models.MemoryEntry{
+		{ID: "a", Content: "one", Embedding: []float32{1, 0, 0}},
+		{ID: "b", Content: "two", Embedding: []float32{0, 1, 0}},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	count, err := b.Count(ctx, models.ScopeGlobal, "")
+	if err != nil || count != 2 {
+		t.Fatalf("Count() = %d, %v", count, err)
+	}
+	filter, _ := json.Marshal(f.bodies["POST /points/count"]["filter"])
+	if !strings.Contains(string(filter), `{"is_empty":{"key":"agent_id"}}`) {
+		t.Errorf("global count filter = %s", filter)
+	}
+
+	changed, err := b.RewriteContent(ctx, func(content string) (string, bool, error) {
+		return strings.ToUpper(content), content == "one", nil
+	})
+	if err != nil || changed != 1 || f.points[pointID("a")].Payload["content"] != "ONE" {
+		t.Errorf("RewriteContent() = %d, %v", changed, err)
+	}
+
+	if err := b.Delete(ctx, []string{"a"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := f.points[pointID("a")]; ok || len(f.points) != 1 {
+		t.Errorf("Delete() left %d points", len(f.points))
+	}
+
+	pruned, err := b.Prune(ctx, models.ScopeAll, "", time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+	if err != nil || pruned != 1 || len(f.points) != 0 {
+		t.Errorf("Prune() = %d, %v", pruned, err)
+	}
+	filter, _ = json.Marshal(f.bodies["POST /points/delete"]["filter"])
+	if !strings.Contains(string(filter), `"range":{"lt":1767225600}`) {
+		t.Errorf("prune filter = %s", filter)
+	}
+}
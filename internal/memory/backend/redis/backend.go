@@ -0,0 +1,545 @@
+// Package redis provides a vector storage backend using Redis with the
+// RediSearch module (Redis Stack or Redis 8).
+package redis
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/google/uuid"
+	"github.com/haasonsaas/nexus/internal/memory/backend"
+	"github.com/haasonsaas/nexus/pkg/models"
+)
+
+// pageSize bounds how many keys are read per SCAN or search page.
+const pageSize = 256
+
+// returnFields are the hash fields read back for an entry, excluding the
+// embedding.
+var returnFields = []string{"id", "session_id", "channel_id", "agent_id", "content", "metadata", "created_at", "updated_at"}
+
+// Backend implements the backend.Backend interface using RediSearch.
+type Backend struct {
+	conn      *conn
+	index     string
+	prefix    string
+	dimension int
+}
+
+// Config contains configuration for the Redis backend.
+type Config struct {
+	// Addr is the Redis host:port. Default: localhost:6379.
+	Addr string
+
+	// Username and Password authenticate with AUTH when Password is set.
+	Username string
+	Password string
+
+	// DB is the logical database selected after connecting.
+	DB int
+
+	// Index is the RediSearch index name. Default: nexus_memories.
+	Index string
+
+	// Prefix is the key prefix of memory hashes. Default: nexus:memory:.
+	Prefix string
+
+	// Dimension is the embedding dimension.
+	Dimension int
+
+	// Timeout bounds dialing and each command. Default: 30s.
+	Timeout time.Duration
+}
+
+// New creates a Redis backend, creating the search index when it does not
+// exist.
+func New(cfg Config) (*Backend, error) {
+	if cfg.Addr == "" {
+		cfg.Addr = "localhost:6379"
+	}
+	if cfg.Index == "" {
+		cfg.Index = "nexus_memories"
+	}
+	if cfg.Prefix == "" {
+		cfg.Prefix = "nexus:memory:"
+	}
+	if cfg.Dimension == 0 {
+		cfg.Dimension = 1536 // Default to OpenAI text-embedding-3-small
+	}
+	if cfg.Timeout == 0 {
+		cfg.Timeout = 30 * time.Second
+	}
+
+	var setup [][]any
+	if cfg.Password != "" {
+		if cfg.Username != "" {
+			setup = append(setup, []any{"AUTH", cfg.Username, cfg.Password})
+		} else {
+			setup = append(setup, []any{"AUTH", cfg.Password})
+		}
+	}
+	if cfg.DB != 0 {
+		setup = append(setup, []any{"SELECT", cfg.DB})
+	}
+	dialer := &net.Dialer{Timeout: cfg.Timeout}
+	b := &Backend{
+		conn: &conn{
+			dial: func(ctx context.Context) (net.Conn, error) {
+				return dialer.DialContext(ctx, "tcp", cfg.Addr)
+			},
+			setup:   setup,
+			timeout: cfg.Timeout,
+		},
+		index:     cfg.Index,
+		prefix:    cfg.Prefix,
+		dimension: cfg.Dimension,
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.Timeout)
+	defer cancel()
+	if err := b.ensureIndex(ctx); err != nil {
+		b.conn.close()
+		return nil, err
+	}
+	return b, nil
+}
+
+// ensureIndex creates the search index when FT.INFO reports it missing and
+// checks the vector dimension of an existing one.
+func (b *Backend) ensureIndex(ctx context.Context) error {
+	info, err := b.conn.do(ctx, "FT.INFO", b.index)
+	if err == nil {
+		if dim := indexDimension(info); dim != 0 && dim != b.dimension {
+			return fmt.Errorf("redis index %q has vector dimension %d, expected %d", b.index, dim, b.dimension)
+		}
+		return nil
+	}
+	var re respError
+	if !errors.As(err, &re) {
+		return fmt.Errorf("failed to read redis index: %w", err)
+	}
+	msg := strings.ToLower(err.Error())
+	if !strings.Contains(msg, "unknown index") && !strings.Contains(msg, "no such index") {
+		return fmt.Errorf("failed to read redis index: %w", err)
+	}
+	if _, err := b.conn.do(ctx, createIndexArgs(b.index, b.prefix, b.dimension)...); err != nil {
+		return fmt.Errorf("failed to create redis index: %w", err)
+	}
+	return nil
+}
+
+// createIndexArgs returns the FT.CREATE command for the memory index. The
+// scope tag is "global" for entries without a session, channel, or agent so
+// global searches do not need empty-tag matching.
+func createIndexArgs(index, prefix string, dimension int) []any {
+	return []any{
+		"FT.CREATE", index, "ON", "HASH", "PREFIX", 1, prefix, "SCHEMA",
+		"id", "TAG",
+		"session_id", "TAG",
+		"channel_id", "TAG",
+		"agent_id", "TAG",
+		"scope", "TAG",
+		"source", "TAG",
+		"role", "TAG",
+		"tags", "TAG", "SEPARATOR", ",",
+		"content", "TEXT",
+		"created_unix", "NUMERIC", "SORTABLE",
+		"embedding", "VECTOR", "HNSW", 6, "TYPE", "FLOAT32", "DIM", dimension, "DISTANCE_METRIC", "COSINE",
+	}
+}
+
+// indexDimension finds the DIM of the vector attribute in an FT.INFO reply.
+func indexDimension(info any) int {
+	items, _ := info.([]any)
+	for i := 0; i+1 < len(items); i += 2 {
+		if replyString(items[i]) != "attributes" {
+			continue
+		}
+		attrs, _ := items[i+1].([]any)
+		for _, attr := range attrs {
+			fields, _ := attr.([]any)
+			for j := 0; j+1 < len(fields); j++ {
+				if strings.EqualFold(replyString(fields[j]), "dim") {
+					n, _ := strconv.Atoi(replyString(fields[j+1]))
+					return n
+				}
+			}
+		}
+	}
+	return 0
+}
+
+// Index stores memory entries with their embeddings.
+func (b *Backend) Index(ctx context.Context, entries []*models.MemoryEntry) error {
+	for _, entry := range entries {
+		if entry.ID == "" {
+			entry.ID = uuid.New().String()
+		}
+		if entry.CreatedAt.IsZero() {
+			entry.CreatedAt = time.Now()
+		}
+		entry.UpdatedAt = time.Now()
+		if len(entry.Embedding) == 0 {
+			return fmt.Errorf("entry %s has no embedding", entry.ID)
+		}
+		if len(entry.Embedding) != b.dimension {
+			return fmt.Errorf("embedding dimension mismatch: got %d, expected %d", len(entry.Embedding), b.dimension)
+		}
+		metadata, err := json.Marshal(entry.Metadata)
+		if err != nil {
+			return fmt.Errorf("failed to marshal metadata: %w", err)
+		}
+		scope := "scoped"
+		if entry.SessionID == "" && entry.ChannelID == "" && entry.AgentID == "" {
+			scope = "global"
+		}
+		args := []any{
+			"HSET", b.prefix + entry.ID,
+			"id", entry.ID,
+			"session_id", entry.SessionID,
+			"channel_id", entry.ChannelID,
+			"agent_id", entry.AgentID,
+			"scope", scope,
+			"source", entry.Metadata.Source,
+			"role", entry.Metadata.Role,
+			"tags", strings.Join(entry.Metadata.Tags, ","),
+			"content", entry.Content,
+			"metadata", string(metadata),
+			"created_at", entry.CreatedAt.UTC().Format(time.RFC3339Nano),
+			"updated_at", entry.UpdatedAt.UTC().Format(time.RFC3339Nano),
+			"created_unix", entry.CreatedAt.Unix(),
+			"embedding", encodeEmbedding(entry.Embedding),
+		}
+		if _, err := b.conn.do(ctx, args...); err != nil {
+			return fmt.Errorf("failed to store entry %s: %w", entry.ID, err)
+		}
+	}
+	return nil
+}
+
+// Search finds similar entries using the query embedding. BM25 and hybrid
+// modes run as vector search.
+func (b *Backend) Search(ctx context.Context, queryEmbedding []float32, opts *backend.SearchOptions) ([]*models.SearchResult, error) {
+	if opts == nil {
+		opts = &backend.SearchOptions{Limit: 10}
+	}
+	if opts.Limit <= 0 {
+		opts.Limit = 10
+	}
+	filter := searchFilter(opts.Scope, opts.ScopeID, opts.Filters, time.Time{})
+	if filter != "*" {
+		filter = "(" + filter + ")"
+	}
+	query := fmt.Sprintf("%s=>[KNN %d @embedding $vec AS distance]", filter, opts.Limit)
+	args := []any{"FT.SEARCH", b.index, query, "PARAMS", 2, "vec", encodeEmbedding(queryEmbedding),
+		"SORTBY", "distance", "RETURN", len(returnFields) + 1}
+	for _, field := range returnFields {
+		args = append(args, field)
+	}
+	args = append(args, "distance", "LIMIT", 0, opts.Limit, "DIALECT", 2)
+
+	reply, err := b.conn.do(ctx, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search: %w", err)
+	}
+	docs, err := parseSearch(reply)
+	if err != nil {
+		return nil, err
+	}
+	results := make([]*models.SearchResult, 0, len(docs))
+	for _, doc := range docs {
+		distance, _ := strconv.ParseFloat(doc["distance"], 32)
+		score := float32(1 - distance)
+		if score < opts.Threshold {
+			continue
+		}
+		entry, err := hashEntry(doc)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, &models.SearchResult{Entry: entry, Score: score})
+	}
+	return results, nil
+}
+
+// Delete removes entries by ID.
+func (b *Backend) Delete(ctx context.Context, ids []string) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	args := []any{"DEL"}
+	for _, id := range ids {
+		args = append(args, b.prefix+id)
+	}
+	if _, err := b.conn.do(ctx, args...); err != nil {
+		return fmt.Errorf("failed to delete: %w", err)
+	}
+	return nil
+}
+
+// Count returns the number of entries matching the scope.
+func (b *Backend) Count(ctx context.Context, scope models.MemoryScope, scopeID string) (int64, error) {
+	reply, err := b.conn.do(ctx, "FT.SEARCH", b.index, searchFilter(scope, scopeID, nil, time.Time{}), "LIMIT", 0, 0, "DIALECT", 2)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count: %w", err)
+	}
+	items, _ := reply.([]any)
+	if len(items) == 0 {
+		return 0, fmt.Errorf("failed to count: unexpected reply")
+	}
+	total, _ := items[0].(int64)
+	return total, nil
+}
+
+// Prune removes entries in the scope created before the cutoff.
+func (b *Backend) Prune(ctx context.Context, scope models.MemoryScope, scopeID string, before time.Time) (int64, error) {
+	query := searchFilter(scope, scopeID, nil, before)
+	var removed int64
+	for {
+		reply, err := b.conn.do(ctx, "FT.SEARCH", b.index, query, "NOCONTENT", "LIMIT", 0, pageSize, "DIALECT", 2)
+		if err != nil {
+			return removed, fmt.Errorf("failed to prune: %w", err)
+		}
+		items, _ := reply.([]any)
+		if len(items) <= 1 {
+			return removed, nil
+		}
+		args := []any{"DEL"}
+		for _, key := range items[1:] {
+			args = append(args, replyString(key))
+		}
+		n, err := b.conn.do(ctx, args...)
+		if err != nil {
+			return removed, fmt.Errorf("failed to prune: %w", err)
+		}
+		deleted, _ := n.(int64)
+		removed += deleted
+		if deleted == 0 {
+			return removed, nil
+		}
+	}
+}
+
+// Export implements backend.Exporter. The cursor is a SCAN cursor; SCAN may
+// return a key more than once, which re-indexes the same entry.
+func (b *Backend) Export(ctx context.Context, cursor string, limit int) ([]*models.MemoryEntry, string, error) {
+	if limit <= 0 {
+		limit = pageSize
+	}
+	keys, next, err := b.scan(ctx, cursor, limit)
+	if err != nil {
+		return nil, "", err
+	}
+	entries := make([]*models.MemoryEntry, 0, len(keys))
+	for _, key := range keys {
+		reply, err := b.conn.do(ctx, "HGETALL", key)
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to read %s: %w", key, err)
+		}
+		doc := pairs(reply)
+		if len(doc) == 0 {
+			continue // deleted since the scan
+		}
+		entry, err := hashEntry(doc)
+		if err != nil {
+			return nil, "", err
+		}
+		entry.Embedding = decodeEmbedding([]byte(doc["embedding"]))
+		entries = append(entries, entry)
+	}
+	return entries, next, nil
+}
+
+// RewriteContent implements backend.ContentRewriter.
+func (b *Backend) RewriteContent(ctx context.Context, rewrite func(content string) (string, bool, error)) (int64, error) {
+	var changed int64
+	cursor := ""
+	for {
+		keys, next, err := b.scan(ctx, cursor, pageSize)
+		if err != nil {
+			return changed, err
+		}
+		for _, key := range keys {
+			reply, err := b.conn.do(ctx, "HGET", key, "content")
+			if err != nil {
+				return changed, fmt.Errorf("failed to read %s: %w", key, err)
+			}
+			if reply == nil {
+				continue
+			}
+			rewritten, ok, err := rewrite(replyString(reply))
+			if err != nil {
+				return changed, fmt.Errorf("entry %s: %w", strings.TrimPrefix(key, b.prefix), err)
+			}
+			if !ok {
+				continue
+			}
+			if _, err := b.conn.do(ctx, "HSET", key, "content", rewritten); err != nil {
+				return changed, fmt.Errorf("failed to update %s: %w", key, err)
+			}
+			changed++
+		}
+		if next == "" {
+			return changed, nil
+		}
+		cursor = next
+	}
+}
+
+// scan returns one page of memory keys and the next cursor, which is empty
+// after the last page.
+func (b *Backend) scan(ctx context.Context, cursor string, limit int) ([]string, string, error) {
+	if cursor == "" {
+		cursor = "0"
+	}
+	reply, err := b.conn.do(ctx, "SCAN", cursor, "MATCH", b.prefix+"*", "COUNT", limit)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to scan: %w", err)
+	}
+	items, _ := reply.([]any)
+	if len(items) != 2 {
+		return nil, "", fmt.Errorf("failed to scan: unexpected reply")
+	}
+	next := replyString(items[0])
+	if next == "0" {
+		next = ""
+	}
+	raw, _ := items[1].([]any)
+	keys := make([]string, 0, len(raw))
+	for _, key := range raw {
+		keys = append(keys, replyString(key))
+	}
+	return keys, next, nil
+}
+
+// Compact is a no-op; RediSearch maintains its index incrementally.
+func (b *Backend) Compact(ctx context.Context) error {
+	return nil
+}
+
+// Close releases resources.
+func (b *Backend) Close() error {
+	return b.conn.close()
+}
+
+// searchFilter builds a RediSearch query for a scope, metadata filters
+// (source, role, or tags), and a creation cutoff.
+func searchFilter(scope models.MemoryScope, scopeID string, filters map[string]any, before time.Time) string {
+	var parts []string
+	tag := func(field, value string) {
+		parts = append(parts, fmt.Sprintf("@%s:{%s}", field, escapeTag(value)))
+	}
+	switch scope {
+	case models.ScopeSession:
+		tag("session_id", scopeID)
+	case models.ScopeChannel:
+		tag("channel_id", scopeID)
+	case models.ScopeAgent:
+		tag("agent_id", scopeID)
+	case models.ScopeGlobal:
+		tag("scope", "global")
+	}
+	for _, key := range []string{"source", "role", "tag", "tags"} {
+		value, ok := filters[key].(string)
+		if !ok || value == "" {
+			continue
+		}
+		if key == "tag" {
+			key = "tags"
+		}
+		tag(key, value)
+	}
+	if !before.IsZero() {
+		parts = append(parts, fmt.Sprintf("@created_unix:[-inf (%d]", before.Unix()))
+	}
+	if len(parts) == 0 {
+		return "*"
+	}
+	return strings.Join(parts, " ")
+}
+
+// escapeTag escapes a TAG value; RediSearch treats punctuation and spaces as
+// separators or syntax.
+func escapeTag(value string) string {
+	var sb strings.Builder
+	for _, r := range value {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '_' {
+			sb.WriteByte('\\')
+		}
+		sb.WriteRune(r)
+	}
+	return sb.String()
+}
+
+// parseSearch converts an FT.SEARCH reply into field maps, one per document.
+func parseSearch(reply any) ([]map[string]string, error) {
+	items, ok := reply.([]any)
+	if !ok || len(items) == 0 {
+		return nil, fmt.Errorf("failed to search: unexpected reply")
+	}
+	var docs []map[string]string
+	for i := 1; i+1 < len(items); i += 2 {
+		docs = append(docs, pairs(items[i+1]))
+	}
+	return docs, nil
+}
+
+// pairs converts a flat field/value array into a map.
+func pairs(reply any) map[string]string {
+	items, _ := reply.([]any)
+	out := make(map[string]string, len(items)/2)
+	for i := 0; i+1 < len(items); i += 2 {
+		out[replyString(items[i])] = replyString(items[i+1])
+	}
+	return out
+}
+
+// hashEntry rebuilds an entry from its hash fields.
+func hashEntry(doc map[string]string) (*models.MemoryEntry, error) {
+	entry := &models.MemoryEntry{
+		ID:        doc["id"],
+		SessionID: doc["session_id"],
+		ChannelID: doc["channel_id"],
+		AgentID:   doc["agent_id"],
+		Content:   doc["content"],
+	}
+	if raw := doc["metadata"]; raw != "" {
+		if err := json.Unmarshal([]byte(raw), &entry.Metadata); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal metadata: %w", err)
+		}
+	}
+	entry.CreatedAt, _ = time.Parse(time.RFC3339Nano, doc["created_at"])
+	entry.UpdatedAt, _ = time.Parse(time.RFC3339Nano, doc["updated_at"])
+	return entry, nil
+}
+
+// encodeEmbedding packs an embedding as little-endian float32 bytes, the
+// layout RediSearch expects for FLOAT32 vectors.
+func encodeEmbedding(embedding []float32) []byte {
+	buf := make([]byte, len(embedding)*4)
+	for i, v := range embedding {
+		binary.LittleEndian.PutUint32(buf[i*4:], math.Float32bits(v))
+	}
+	return buf
+}
+
+// decodeEmbedding reverses encodeEmbedding.
+func decodeEmbedding(buf []byte) []float32 {
+	if len(buf) == 0 {
+		return nil
+	}
+	embedding := make([]float32, len(buf)/4)
+	for i := range embedding {
+		embedding[i] = math.Float32frombits(binary.LittleEndian.Uint32(buf[i*4:]))
+	}
+	return embedding
+}
@@ -0,0 +1,313 @@
+package redis
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/haasonsaas/nexus/internal/memory/backend"
+	"github.com/haasonsaas/nexus/pkg/models"
+)
+
+// fakeRedis serves the commands used by the backend from in-memory hashes.
+// FT.SEARCH returns every hash with a fixed distance.
+type fakeRedis struct {
+	mu       sync.Mutex
+	indexDim int
+	hashes   map[string]map[string]string
+	commands [][]string
+}
+
+func newFakeRedis(t *testing.T) (*fakeRedis, string) {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Skipf("listen: %v", err)
+	}
+	t.Cleanup(func() { ln.Close() })
+	f := &fakeRedis{hashes: map[string]map[string]string{}}
+	go func() {
+		for {
+			c, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go f.serve(c)
+		}
+	}()
+	return f, ln.Addr().String()
+}
+
+func (f *fakeRedis) serve(c net.Conn) {
+	defer c.Close()
+	rd := bufio.NewReader(c)
+	for {
+		reply, err := readReply(rd)
+		if err != nil {
+			return
+		}
+		var args []string
+		for _, arg := range reply.([]any) {
+			args = append(args, replyString(arg))
+		}
+		f.mu.Lock()
+		f.commands = append(f.commands, args)
+		out := f.handle(args)
+		f.mu.Unlock()
+		if _, err := c.Write([]byte(encodeReply(out))); err != nil {
+			return
+		}
+	}
+}
+
+func (f *fakeRedis) handle(args []string) any {
+	switch strings.ToUpper(args[0]) {
+	case "AUTH", "SELECT":
+		return "OK"
+	case "FT.INFO":
+		if f.indexDim == 0 {
+			return respError("Unknown index name")
+		}
+		return []any{"index_name", args[1], "attributes", []any{[]any{"identifier", "embedding", "type", "VECTOR", "dim", int64(f.indexDim)}}}
+	case "FT.CREATE":
+		for i, arg := range args {
+			if arg == "DIM" {
+				fmt.Sscan(args[i+1], &f.indexDim)
+			}
+		}
+		return "OK"
+	case "HSET":
+		h := f.hashes[args[1]]
+		if h == nil {
+			h = map[string]string{}
+			f.hashes[args[1]] = h
+		}
+		for i := 2; i+1 < len(args); i += 2 {
+			h[args[i]] = args[i+1]
+		}
+		return int64(0)
+	case "HGET":
+		if v, ok := f.hashes[args[1]][args[2]]; ok {
+			return []byte(v)
+		}
+		return nil
+	case "HGETALL":
+		var out []any
+		for k, v := range f.hashes[args[1]] {
+			out = append(out, k, []byte(v))
+		}
+		return out
+	case "DEL":
+		var n int64
+		for _, key := range args[1:] {
+			if _, ok := f.hashes[key]; ok {
+				delete(f.hashes, key)
+				n++
+			}
+		}
+		return n
+	case "SCAN":
+		var keys []any
+		for _, key := range f.sortedKeys() {
+			keys = append(keys, key)
+		}
+		return []any{"0", keys}
+	case "FT.SEARCH":
+		out := []any{int64(len(f.hashes))}
+		for _, arg := range args {
+			if arg == "NOCONTENT" {
+				for _, key := range f.sortedKeys() {
+					out = append(out, key)
+				}
+				return out
+			}
+		}
+		for _, key := range f.sortedKeys() {
+			fields := []any{"distance", "0.25"}
+			for k, v := range f.hashes[key] {
+				if k != "embedding" {
+					fields = append(fields, k, v)
+				}
+			}
+			out = append(out, key, fields)
+		}
+		return out
+	}
+	return respError("ERR unknown command " + args[0])
+}
+
+func (f *fakeRedis) sortedKeys() []string {
+	keys := make([]string, 0, len(f.hashes))
+	for key := range f.hashes {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func (f *fakeRedis) lastCommand(name string) []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for i := len(f.commands) - 1; i >= 0; i-- {
+		if f.commands[i][0] == name {
+			return f.commands[i]
+		}
+	}
+	return nil
+}
+
+func encodeReply(v any) string {
+	switch r := v.(type) {
+	case nil:
+		return "$-1\r\n"
+	case string:
+		return "+" + r + "\r\n"
+	case respError:
+		return "-" + string(r) + "\r\n"
+	case int64:
+		return fmt.Sprintf(":%d\r\n", r)
+	case []byte:
+		return fmt.Sprintf("$%d\r\n%s\r\n", len(r), r)
+	case []any:
+		var sb strings.Builder
+		fmt.Fprintf(&sb, "*%d\r\n", len(r))
+		for _, item := range r {
+			if s, ok := item.(string); ok {
+				item = []byte(s)
+			}
+			sb.WriteString(encodeReply(item))
+		}
+		return sb.String()
+	}
+	panic(fmt.Sprintf("unsupported reply %T", v))
+}
+
+func newTestBackend(t *testing.T) (*Backend, *fakeRedis) {
+	t.Helper()
+	f, addr := newFakeRedis(t)
+	b, err := New(Config{Addr: addr, Password: "secret", DB: 2, Dimension: 3, Timeout: 5 * time.Second})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	t.Cleanup(func() { b.Close() })
+	return b, f
+}
+
+func TestNewCreatesIndex(t *testing.T) {
+	f, addr := newFakeRedis(t)
+	b, err := New(Config{Addr: addr, Password: "secret", DB: 2, Dimension: 3, Timeout: 5 * time.Second})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	b.Close()
+	if f.indexDim != 3 {
+		t.Fatalf("index dimension = %d", f.indexDim)
+	}
+	if auth := f.lastCommand("AUTH"); len(auth) != 2 || auth[1] != "secret" {
+		t.Errorf("AUTH = %v", auth)
+	}
+	if sel := f.lastCommand("SELECT"); len(sel) != 2 || sel[1] != "2" {
+		t.Errorf("SELECT = %v", sel)
+	}
+	create := strings.Join(f.lastCommand("FT.CREATE"), " ")
+	if !strings.Contains(create, "PREFIX 1 nexus:memory:") || !strings.Contains(create, "DISTANCE_METRIC COSINE") {
+		t.Errorf("FT.CREATE = %s", create)
+	}
+
+	if _, err := New(Config{Addr: addr, Dimension: 4}); err == nil {
+		t.Error("New() accepted a dimension mismatch")
+	}
+}
+
+func TestIndexSearchExport(t *testing.T) {
+	b, f := newTestBackend(t)
+	ctx := context.Background()
+	created := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	if err := b.Index(ctx, []*models.MemoryEntry{
+		{ID: "a", SessionID: "s1", Content: "likes tea", Embedding: []float32{1, 0.5, -2}, CreatedAt: created,
+			Metadata: models.MemoryMetadata{Source: "note", Tags: []string{"prefs", "drinks"}}},
+		{ID: "b", Content: "global fact", Embedding: []float32{0, 1, 0}},
+	}); err != nil {
+		t.Fatalf("Index() error = %v", err)
+	}
+	if h := f.hashes["nexus:memory:a"]; h["scope"] != "scoped" || h["tags"] != "prefs,drinks" || h["created_unix"] != fmt.Sprint(created.Unix()) {
+		t.Errorf("hash a = %v", h)
+	}
+	if f.hashes["nexus:memory:b"]["scope"] != "global" {
+		t.Error("entry without scope IDs not tagged global")
+	}
+
+	results, err := b.Search(ctx, []float32{1, 0, 0}, &backend.SearchOptions{
+		Scope: models.ScopeSession, ScopeID: "s-1", Limit: 5, Threshold: 0.5,
+		Filters: map[string]any{"source": "note"},
+	})
+	if err != nil {
+		t.Fatalf("Search() error = %v", err)
+	}
+	if len(results) != 2 || results[0].Score != 0.75 || results[0].Entry.ID != "a" || results[0].Entry.Metadata.Source != "note" {
+		t.Errorf("Search() = %+v", results)
+	}
+	search := f.lastCommand("FT.SEARCH")
+	if search[2] != `(@session_id:{s\-1} @source:{note})=>[KNN 5 @embedding $vec AS distance]` {
+		t.Errorf("search query = %s", search[2])
+	}
+	if results, _ := b.Search(ctx, []float32{1, 0, 0}, &backend.SearchOptions{Limit: 5, Threshold: 0.9}); len(results) != 0 {
+		t.Errorf("Search() ignored the threshold: %+v", results)
+	}
+
+	entries, next, err := b.Export(ctx, "", 10)
+	if err != nil || next != "" || len(entries) != 2 {
+		t.Fatalf("Export() = %d entries, next %q, error %v", len(entries), next, err)
+	}
+	a := entries[0]
+	if a.ID != "a" || a.SessionID != "s1" || !a.CreatedAt.Equal(created) || len(a.Embedding) != 3 || a.Embedding[2] != -2 {
+		t.Errorf("exported a = %+v", a)
+	}
+}
+
+func TestCountPruneRewriteDelete(t *testing.T) {
+	b, f := newTestBackend(t)
+	ctx := context.Background()
+	if err := b.Index(ctx, []*models.MemoryEntry{
+		{ID: "a", Content: "one", Embedding: []float32{1, 0, 0}},
+		{ID: "b", Content: "two", Embedding: []float32{0, 1, 0}},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	count, err := b.Count(ctx, models.ScopeGlobal, "")
+	if err != nil || count != 2 {
+		t.Fatalf("Count() = %d, %v", count, err)
+	}
+	if q := f.lastCommand("FT.SEARCH")[2]; q != "@scope:{global}" {
+		t.Errorf("count query = %s", q)
+	}
+
+	changed, err := b.RewriteContent(ctx, func(content string) (string, bool, error) {
+		return strings.ToUpper(content), content == "one", nil
+	})
+	if err != nil || changed != 1 || f.hashes["nexus:memory:a"]["content"] != "ONE" {
+		t.Errorf("RewriteContent() = %d, %v", changed, err)
+	}
+
+	if err := b.Delete(ctx, []string{"a"}); err != nil || len(f.hashes) != 1 {
+		t.Errorf("Delete() left %d hashes, error %v", len(f.hashes), err)
+	}
+
+	pruned, err := b.Prune(ctx, models.ScopeAll, "", time.Unix(1767225600, 0))
+	if err != nil || pruned != 1 || len(f.hashes) != 0 {
+		t.Errorf("Prune() = %d, %v", pruned, err)
+	}
+}
+
+func TestEscapeTag(t *testing.T) {
+	if got := escapeTag("user@example.com ok_1"); got != `user\@example\.com\ ok_1` {
+		t.Errorf("escapeTag() = %s", got)
+	}
+}
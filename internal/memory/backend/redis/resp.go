@@ -0,0 +1,197 @@
+package redis
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// respError is an error reply from the server.
+type respError string
+
+func (e respError) Error() string { return string(e) }
+
+// conn is a minimal RESP2 client over a single connection. Commands are
+// serialized; a connection that fails mid-command is dropped and redialed on
+// the next call.
+type conn struct {
+	mu      sync.Mutex
+	dial    func(ctx context.Context) (net.Conn, error)
+	setup   [][]any
+	timeout time.Duration
+
+	nc net.Conn
+	rd *bufio.Reader
+	wr *bufio.Writer
+}
+
+// do sends one command and returns its reply: string for simple strings,
+// int64 for integers, []byte or nil for bulk strings, and []any for arrays.
+func (c *conn) do(ctx context.Context, args ...any) (any, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.nc == nil {
+		if err := c.connect(ctx); err != nil {
+			return nil, err
+		}
+	}
+	reply, err := c.roundTrip(ctx, args)
+	var re respError
+	if err != nil && !errors.As(err, &re) {
+		c.closeLocked()
+	}
+	return reply, err
+}
+
+// connect dials and runs the setup commands (AUTH, SELECT).
+func (c *conn) connect(ctx context.Context) error {
+	nc, err := c.dial(ctx)
+	if err != nil {
+		return fmt.Errorf("redis dial: %w", err)
+	}
+	c.nc = nc
+	c.rd = bufio.NewReader(nc)
+	c.wr = bufio.NewWriter(nc)
+	for _, args := range c.setup {
+		if _, err := c.roundTrip(ctx, args); err != nil {
+			c.closeLocked()
+			return fmt.Errorf("redis %v: %w", args[0], err)
+		}
+	}
+	return nil
+}
+
+func (c *conn) roundTrip(ctx context.Context, args []any) (any, error) {
+	deadline := time.Now().Add(c.timeout)
+	if d, ok := ctx.Deadline(); ok && d.Before(deadline) {
+		deadline = d
+	}
+	if err := c.nc.SetDeadline(deadline); err != nil {
+		return nil, err
+	}
+	if err := writeCommand(c.wr, args); err != nil {
+		return nil, err
+	}
+	if err := c.wr.Flush(); err != nil {
+		return nil, err
+	}
+	return readReply(c.rd)
+}
+
+func (c *conn) close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.closeLocked()
+}
+
+func (c *conn) closeLocked() error {
+	if c.nc == nil {
+		return nil
+	}
+	err := c.nc.Close()
+	c.nc, c.rd, c.wr = nil, nil, nil
+	return err
+}
+
+// writeCommand encodes a command as an array of bulk strings.
+func writeCommand(w *bufio.Writer, args []any) error {
+	fmt.Fprintf(w, "*%d\r\n", len(args))
+	for _, arg := range args {
+		var b []byte
+		switch v := arg.(type) {
+		case string:
+			b = []byte(v)
+		case []byte:
+			b = v
+		case int:
+			b = strconv.AppendInt(nil, int64(v), 10)
+		case int64:
+			b = strconv.AppendInt(nil, v, 10)
+		case float64:
+			b = strconv.AppendFloat(nil, v, 'g', -1, 64)
+		default:
+			return fmt.Errorf("redis: unsupported argument type %T", arg)
+		}
+		fmt.Fprintf(w, "$%d\r\n", len(b))
+		w.Write(b)
+		w.WriteString("\r\n")
+	}
+	return nil
+}
+
+// readReply decodes one RESP2 reply. Error replies are returned as respError.
+func readReply(r *bufio.Reader) (any, error) {
+	line, err := r.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+	if len(line) < 3 || line[len(line)-2] != '\r' {
+		return nil, fmt.Errorf("redis: malformed reply %q", line)
+	}
+	kind, body := line[0], line[1:len(line)-2]
+	switch kind {
+	case '+':
+		return body, nil
+	case '-':
+		return nil, respError(body)
+	case ':':
+		return strconv.ParseInt(body, 10, 64)
+	case '$':
+		n, err := strconv.Atoi(body)
+		if err != nil {
+			return nil, fmt.Errorf("redis: malformed bulk length %q", body)
+		}
+		if n < 0 {
+			return nil, nil
+		}
+		buf := make([]byte, n+2)
+		if _, err := io.ReadFull(r, buf); err != nil {
+			return nil, err
+		}
+		return buf[:n], nil
+	case '*':
+		n, err := strconv.Atoi(body)
+		if err != nil {
+			return nil, fmt.Errorf("redis: malformed array length %q", body)
+		}
+		if n < 0 {
+			return nil, nil
+		}
+		items := make([]any, n)
+		var firstErr error
+		for i := range items {
+			item, err := readReply(r)
+			var re respError
+			if err != nil && !errors.As(err, &re) {
+				return nil, err
+			}
+			if err != nil && firstErr == nil {
+				firstErr = err
+			}
+			items[i] = item
+		}
+		return items, firstErr
+	default:
+		return nil, fmt.Errorf("redis: unexpected reply type %q", kind)
+	}
+}
+
+// replyString converts a simple or bulk string reply to a string.
+func replyString(v any) string {
+	switch s := v.(type) {
+	case string:
+		return s
+	case []byte:
+		return string(s)
+	case int64:
+		return strconv.FormatInt(s, 10)
+	}
+	return ""
+}
@@ -0,0 +1,68 @@
+package backend
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Factory opens a backend provided outside the core, such as by a plugin.
+type Factory func(cfg Config) (Backend, error)
+
+// builtinNames are the vector_memory.backend values handled by the memory
+// package itself; registered backends cannot shadow them.
+var builtinNames = map[string]bool{
+	"sqlite-vec": true, "sqlite": true,
+	"pgvector": true, "postgres": true, "postgresql": true,
+	"lancedb": true, "lance": true,
+	"qdrant": true,
+	"redis":  true, "redisearch": true,
+}
+
+var (
+	factoriesMu sync.RWMutex
+	factories   = map[string]Factory{}
+)
+
+// Register makes a backend selectable as vector_memory.backend by name.
+// Names are case-insensitive.
+func Register(name string, factory Factory) error {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if name == "" {
+		return fmt.Errorf("memory backend name is required")
+	}
+	if factory == nil {
+		return fmt.Errorf("memory backend %q factory is nil", name)
+	}
+	if builtinNames[name] {
+		return fmt.Errorf("memory backend %q is built in", name)
+	}
+	factoriesMu.Lock()
+	defer factoriesMu.Unlock()
+	if _, exists := factories[name]; exists {
+		return fmt.Errorf("memory backend %q is already registered", name)
+	}
+	factories[name] = factory
+	return nil
+}
+
+// Lookup returns the factory registered under name.
+func Lookup(name string) (Factory, bool) {
+	factoriesMu.RLock()
+	defer factoriesMu.RUnlock()
+	factory, ok := factories[strings.ToLower(strings.TrimSpace(name))]
+	return factory, ok
+}
+
+// Registered lists the names of registered backends, sorted.
+func Registered() []string {
+	factoriesMu.RLock()
+	defer factoriesMu.RUnlock()
+	names := make([]string, 0, len(factories))
+	for name := range factories {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,22 @@
+package backend
+
+import "testing"
+
+func TestRegister(t *testing.T) {
+	factory := func(Config) (Backend, error) { return nil, nil }
+	if err := Register("Milvus-Test", factory); err != nil {
+		t.Fatalf("Register() error = %v", err)
+	}
+	if _, ok := Lookup("milvus-test"); !ok {
+		t.Fatal("Lookup() did not find the backend case-insensitively")
+	}
+	if err := Register("milvus-test", factory); err == nil {
+		t.Fatal("duplicate Register() should fail")
+	}
+	if err := Register("qdrant", factory); err == nil {
+		t.Fatal("Register() should not shadow a built-in backend")
+	}
+	if names := Registered(); len(names) != 1 || names[0] != "milvus-test" {
+		t.Fatalf("Registered() = %q", names)
+	}
+}
@@ -16,10 +16,25 @@ import (
 	_ "modernc.org/sqlite" // Pure-Go SQLite driver
 )
 
+// rewriteBatchSize bounds how many entries RewriteContent reads per query.
+const rewriteBatchSize = 500
+
+// defaultTable holds the live entries; stagedSuffix names the table a
+// replacement index is built in.
+const (
+	defaultTable = "memories"
+	stagedSuffix = "_reembed"
+)
+
 // Backend implements the backend.Backend interface using sqlite-vec.
 type Backend struct {
 	db        *sql.DB
 	dimension int
+	table     string
+
+	// shared is set on staged backends, which borrow the live backend's
+	// connection and must not close it.
+	shared bool
 }
 
 // Config contains configuration for the sqlite-vec backend.
@@ -45,9 +60,10 @@ func New(cfg Config) (*Backend, error) {
 	b := &Backend{
 		db:        db,
 		dimension: cfg.Dimension,
+		table:     defaultTable,
 	}
 
-	if err := b.init(); err != nil {
+	if err := b.init(context.Background()); err != nil {
 		db.Close()
 		return nil, err
 	}
@@ -55,13 +71,13 @@ func New(cfg Config) (*Backend, error) {
 	return b, nil
 }
 
-func (b *Backend) init() error {
+func (b *Backend) init(ctx context.Context) error {
 	// Note: In production with CGO, you would load the vec0 extension:
 	// _, err := b.db.Exec("SELECT load_extension('vec0')")
 
 	// Create memories table
-	_, err := b.db.Exec(`
-		CREATE TABLE IF NOT EXISTS memories (
+	_, err := b.db.ExecContext(ctx, `
+		CREATE TABLE IF NOT EXISTS `+b.table+` (
 			id TEXT PRIMARY KEY,
 			session_id TEXT,
 			channel_id TEXT,
@@ -78,14 +94,8 @@ func (b *Backend) init() error {
 	}
 
 	// Create indexes for scoping
-	indexes := []string{
-		"CREATE INDEX IF NOT EXISTS idx_memories_session ON memories(session_id)",
-		"CREATE INDEX IF NOT EXISTS idx_memories_channel ON memories(channel_id)",
-		"CREATE INDEX IF NOT EXISTS idx_memories_agent ON memories(agent_id)",
-		"CREATE INDEX IF NOT EXISTS idx_memories_created ON memories(created_at)",
-	}
-	for _, idx := range indexes {
-		if _, err := b.db.Exec(idx); err != nil {
+	for _, idx := range createIndexStatements(b.table) {
+		if _, err := b.db.ExecContext(ctx, idx); err != nil {
 			return fmt.Errorf("failed to create index: %w", err)
 		}
 	}
@@ -93,6 +103,70 @@ func (b *Backend) init() error {
 	return nil
 }
 
+// indexColumns maps the suffix of each scoping index name to its column.
+var indexColumns = [][2]string{
+	{"session", "session_id"},
+	{"channel", "channel_id"},
+	{"agent", "agent_id"},
+	{"created", "created_at"},
+}
+
+func createIndexStatements(table string) []string {
+	stmts := make([]string, 0, len(indexColumns))
+	for _, ic := range indexColumns {
+		stmts = append(stmts, fmt.Sprintf("CREATE INDEX IF NOT EXISTS idx_%s_%s ON %s(%s)", table, ic[0], table, ic[1]))
+	}
+	return stmts
+}
+
+// StageIndex implements backend.Reindexer. The staged index is a second
+// table in the same database, replacing any left over from an earlier run.
+func (b *Backend) StageIndex(ctx context.Context, dimension int) (backend.Backend, error) {
+	staged := &Backend{db: b.db, dimension: dimension, table: b.table + stagedSuffix, shared: true}
+	if _, err := b.db.ExecContext(ctx, "DROP TABLE IF EXISTS "+staged.table); err != nil {
+		return nil, fmt.Errorf("failed to drop staged table: %w", err)
+	}
+	if err := staged.init(ctx); err != nil {
+		return nil, err
+	}
+	return staged, nil
+}
+
+// PromoteIndex implements backend.Reindexer. The live table is dropped and
+// the staged table renamed in its place in one transaction, so other
+// processes using the database switch over on their next query.
+func (b *Backend) PromoteIndex(ctx context.Context, staged backend.Backend) error {
+	s, ok := staged.(*Backend)
+	if !ok || s.db != b.db || s.table == b.table {
+		return fmt.Errorf("index was not staged by this backend")
+	}
+	tx, err := b.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	stmts := []string{
+		"DROP TABLE " + b.table,
+		fmt.Sprintf("ALTER TABLE %s RENAME TO %s", s.table, b.table),
+	}
+	// SQLite cannot rename indexes; recreate them under the live names.
+	for _, ic := range indexColumns {
+		stmts = append(stmts, fmt.Sprintf("DROP INDEX IF EXISTS idx_%s_%s", s.table, ic[0]))
+	}
+	stmts = append(stmts, createIndexStatements(b.table)...)
+	for _, stmt := range stmts {
+		if _, err := tx.ExecContext(ctx, stmt); err != nil {
+			return fmt.Errorf("failed to promote index: %w", err)
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to promote index: %w", err)
+	}
+	b.dimension = s.dimension
+	return nil
+}
+
 // Index stores memory entries with their embeddings.
 func (b *Backend) Index(ctx context.Context, entries []*models.MemoryEntry) error {
 	tx, err := b.db.BeginTx(ctx, nil)
@@ -106,7 +180,7 @@ func (b *Backend) Index(ctx context.Context, entries []*models.MemoryEntry) erro
 	}()
 
 	stmt, err := tx.PrepareContext(ctx, `
-		INSERT OR REPLACE INTO memories (id, session_id, channel_id, agent_id, content, metadata, embedding, created_at, updated_at)
+		INSERT OR REPLACE INTO `+b.table+` (id, session_id, channel_id, agent_id, content, metadata, embedding, created_at, updated_at)
 		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
 	`)
 	if err != nil {
@@ -159,7 +233,7 @@ func (b *Backend) Search(ctx context.Context, queryEmbedding []float32, opts *ba
 	}
 
 	// Build query with scope filter
-	query := `SELECT id, session_id, channel_id, agent_id, content, metadata, embedding, created_at, updated_at FROM memories WHERE 1=1`
+	query := `SELECT id, session_id, channel_id, agent_id, content, metadata, embedding, created_at, updated_at FROM ` + b.table + ` WHERE 1=1`
 	args := []any{}
 
 	switch opts.Scope {
@@ -227,7 +301,7 @@ func (b *Backend) Delete(ctx context.Context, ids []string) error {
 		return err
 	}
 
-	stmt, err := tx.PrepareContext(ctx, "DELETE FROM memories WHERE id = ?")
+	stmt, err := tx.PrepareContext(ctx, "DELETE FROM "+b.table+" WHERE id = ?")
 	if err != nil {
 		if rbErr := tx.Rollback(); rbErr != nil {
 			return fmt.Errorf("prepare delete statement: %w (rollback: %v)", err, rbErr)
@@ -250,7 +324,7 @@ func (b *Backend) Delete(ctx context.Context, ids []string) error {
 
 // Count returns the number of entries matching the scope.
 func (b *Backend) Count(ctx context.Context, scope models.MemoryScope, scopeID string) (int64, error) {
-	query := "SELECT COUNT(*) FROM memories WHERE 1=1"
+	query := "SELECT COUNT(*) FROM " + b.table + " WHERE 1=1"
 	args := []any{}
 
 	switch scope {
@@ -272,6 +346,135 @@ func (b *Backend) Count(ctx context.Context, scope models.MemoryScope, scopeID s
 	return count, err
 }
 
+// Prune removes entries in the scope created before the cutoff. Timestamps
+// are compared after scanning so the stored text format does not matter.
+func (b *Backend) Prune(ctx context.Context, scope models.MemoryScope, scopeID string, before time.Time) (int64, error) {
+	query := "SELECT id, created_at FROM " + b.table + " WHERE 1=1"
+	args := []any{}
+
+	switch scope {
+	case models.ScopeSession:
+		query += " AND session_id = ?"
+		args = append(args, scopeID)
+	case models.ScopeChannel:
+		query += " AND channel_id = ?"
+		args = append(args, scopeID)
+	case models.ScopeAgent:
+		query += " AND agent_id = ?"
+		args = append(args, scopeID)
+	case models.ScopeGlobal:
+		query += " AND (session_id IS NULL OR session_id = '') AND (channel_id IS NULL OR channel_id = '') AND (agent_id IS NULL OR agent_id = '')"
+	}
+
+	rows, err := b.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return 0, fmt.Errorf("failed to query: %w", err)
+	}
+	var ids []string
+	for rows.Next() {
+		var id string
+		var createdAt time.Time
+		if err := rows.Scan(&id, &createdAt); err != nil {
+			rows.Close()
+			return 0, fmt.Errorf("failed to scan row: %w", err)
+		}
+		if before.IsZero() || createdAt.Before(before) {
+			ids = append(ids, id)
+		}
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, err
+	}
+
+	if err := b.Delete(ctx, ids); err != nil {
+		return 0, err
+	}
+	return int64(len(ids)), nil
+}
+
+// RewriteContent implements backend.ContentRewriter.
+func (b *Backend) RewriteContent(ctx context.Context, rewrite func(content string) (string, bool, error)) (int64, error) {
+	var changed int64
+	cursor := ""
+	for {
+		rows, err := b.db.QueryContext(ctx,
+			"SELECT id, content FROM "+b.table+" WHERE id > ? ORDER BY id LIMIT ?", cursor, rewriteBatchSize)
+		if err != nil {
+			return changed, fmt.Errorf("failed to query: %w", err)
+		}
+		type update struct{ id, old, new string }
+		var updates []update
+		count := 0
+		for rows.Next() {
+			var id, content string
+			if err := rows.Scan(&id, &content); err != nil {
+				rows.Close()
+				return changed, fmt.Errorf("failed to scan row: %w", err)
+			}
+			count++
+			cursor = id
+			rewritten, ok, err := rewrite(content)
+			if err != nil {
+				rows.Close()
+				return changed, fmt.Errorf("entry %s: %w", id, err)
+			}
+			if ok {
+				updates = append(updates, update{id: id, old: content, new: rewritten})
+			}
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return changed, err
+		}
+
+		for _, u := range updates {
+			result, err := b.db.ExecContext(ctx,
+				"UPDATE "+b.table+" SET content = ? WHERE id = ? AND content = ?", u.new, u.id, u.old)
+			if err != nil {
+				return changed, fmt.Errorf("failed to update %s: %w", u.id, err)
+			}
+			if n, err := result.RowsAffected(); err == nil {
+				changed += n
+			}
+		}
+		if count < rewriteBatchSize {
+			return changed, nil
+		}
+	}
+}
+
+// Export implements backend.Exporter. The cursor is the last ID returned.
+func (b *Backend) Export(ctx context.Context, cursor string, limit int) ([]*models.MemoryEntry, string, error) {
+	if limit <= 0 {
+		limit = rewriteBatchSize
+	}
+	rows, err := b.db.QueryContext(ctx,
+		`SELECT id, session_id, channel_id, agent_id, content, metadata, embedding, created_at, updated_at
+		FROM `+b.table+` WHERE id > ? ORDER BY id LIMIT ?`, cursor, limit)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to query: %w", err)
+	}
+	defer rows.Close()
+
+	var entries []*models.MemoryEntry
+	for rows.Next() {
+		entry, embeddingBlob, err := scanEntry(rows)
+		if err != nil {
+			return nil, "", err
+		}
+		entry.Embedding = decodeEmbedding(embeddingBlob)
+		entries = append(entries, entry)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, "", err
+	}
+	if len(entries) < limit {
+		return entries, "", nil
+	}
+	return entries, entries[len(entries)-1].ID, nil
+}
+
 // Compact optimizes the database.
 func (b *Backend) Compact(ctx context.Context) error {
 	_, err := b.db.ExecContext(ctx, "VACUUM")
@@ -280,6 +483,9 @@ func (b *Backend) Compact(ctx context.Context) error {
 
 // Close releases resources.
 func (b *Backend) Close() error {
+	if b.shared {
+		return nil
+	}
 	return b.db.Close()
 }
 
@@ -2,6 +2,7 @@ package sqlitevec
 
 import (
 	"context"
+	"path/filepath"
 	"strings"
 	"testing"
 	"time"
@@ -285,6 +286,46 @@ func TestBackend_Count(t *testing.T) {
 	})
 }
 
+func TestBackend_Prune(t *testing.T) {
+	b := newTestBackend(t)
+	defer b.Close()
+
+	ctx := context.Background()
+	now := time.Now()
+	entries := []*models.MemoryEntry{
+		{Content: "old", SessionID: "s1", CreatedAt: now.Add(-48 * time.Hour)},
+		{Content: "new", SessionID: "s1", CreatedAt: now},
+		{Content: "other", SessionID: "s2", CreatedAt: now.Add(-48 * time.Hour)},
+	}
+	if err := b.Index(ctx, entries); err != nil {
+		t.Fatalf("Index error: %v", err)
+	}
+
+	pruned, err := b.Prune(ctx, models.ScopeSession, "s1", now.Add(-time.Hour))
+	if err != nil {
+		t.Fatalf("Prune error: %v", err)
+	}
+	if pruned != 1 {
+		t.Errorf("pruned = %d, want 1", pruned)
+	}
+
+	pruned, err = b.Prune(ctx, models.ScopeSession, "s1", time.Time{})
+	if err != nil {
+		t.Fatalf("Prune error: %v", err)
+	}
+	if pruned != 1 {
+		t.Errorf("pruned = %d, want 1", pruned)
+	}
+
+	count, err := b.Count(ctx, models.ScopeSession, "s2")
+	if err != nil {
+		t.Fatalf("Count error: %v", err)
+	}
+	if count != 1 {
+		t.Errorf("count = %d, want 1 untouched entry in s2", count)
+	}
+}
+
 func TestBackend_Compact(t *testing.T) {
 	b := newTestBackend(t)
 	defer b.Close()
@@ -483,3 +524,56 @@ func TestBackend_ContextCancellation(t *testing.T) {
 	_ = b.Index(ctx, []*models.MemoryEntry{{Content: "test"}})
 	// We don't check the error since behavior varies by implementation
 }
+
+func TestBackend_StageAndPromoteIndex(t *testing.T) {
+	b, err := New(Config{Path: filepath.Join(t.TempDir(), "memory.db"), Dimension: 2})
+	if err != nil {
+		if strings.Contains(err.Error(), "unknown driver") {
+			t.Skip("SQLite driver not available (driver name mismatch)")
+		}
+		t.Fatalf("New error: %v", err)
+	}
+	defer b.Close()
+
+	ctx := context.Background()
+	if err := b.Index(ctx, []*models.MemoryEntry{{ID: "a", Content: "old", Embedding: []float32{1, 0}}}); err != nil {
+		t.Fatalf("Index error: %v", err)
+	}
+
+	staged, err := b.StageIndex(ctx, 3)
+	if err != nil {
+		t.Fatalf("StageIndex error: %v", err)
+	}
+	if err := staged.Index(ctx, []*models.MemoryEntry{
+		{ID: "a", Content: "old", Embedding: []float32{0, 1, 0}},
+		{ID: "b", Content: "new", Embedding: []float32{0, 0, 1}},
+	}); err != nil {
+		t.Fatalf("staged Index error: %v", err)
+	}
+	if count, _ := b.Count(ctx, models.ScopeAll, ""); count != 1 {
+		t.Errorf("live count before promote = %d, want 1", count)
+	}
+
+	if err := b.PromoteIndex(ctx, staged); err != nil {
+		t.Fatalf("PromoteIndex error: %v", err)
+	}
+	if err := staged.Close(); err != nil {
+		t.Errorf("staged Close error: %v", err)
+	}
+	results, err := b.Search(ctx, []float32{0, 0, 1}, &backend.SearchOptions{Scope: models.ScopeAll, Limit: 1})
+	if err != nil || len(results) != 1 || results[0].Entry.ID != "b" {
+		t.Fatalf("Search after promote = %+v, %v", results, err)
+	}
+	if count, _ := b.Count(ctx, models.ScopeAll, ""); count != 2 {
+		t.Errorf("live count after promote = %d, want 2", count)
+	}
+
+	// The live indexes keep their names, so a second run can stage again.
+	var indexes int
+	if err := b.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM sqlite_master WHERE type = 'index' AND name LIKE 'idx_memories_%' AND tbl_name = 'memories'").Scan(&indexes); err != nil || indexes != 4 {
+		t.Errorf("live indexes = %d, %v", indexes, err)
+	}
+	if _, err := b.StageIndex(ctx, 3); err != nil {
+		t.Errorf("second StageIndex error: %v", err)
+	}
+}
@@ -0,0 +1,455 @@
+// Package consolidate folds old daily memory files into the workspace memory
+// file.
+package consolidate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/haasonsaas/nexus/internal/config"
+	"github.com/haasonsaas/nexus/pkg/models"
+)
+
+// Source is the memory entry source and file history source of
+// consolidation.
+const Source = "memory_consolidation"
+
+// SystemPrompt instructs the model that summarizes daily files.
+const SystemPrompt = `You maintain an assistant's long-term memory file. Condense the daily memory logs you are given into durable facts, preferences, decisions, and open commitments, grouped by topic.
+Output only markdown: a "## Topic" heading per topic followed by short "- " bullet points. Reuse an existing topic name when one fits. Leave out small talk and anything that no longer matters.
+If nothing is worth keeping, output NONE.`
+
+// dateLayout is the name of a daily file without its extension.
+const dateLayout = "2006-01-02"
+
+// Summarizer asks a model to answer prompt under the system prompt system.
+type Summarizer func(ctx context.Context, system, prompt string) (string, error)
+
+// Indexer stores the consolidated topics in vector memory.
+type Indexer interface {
+	Index(ctx context.Context, entries []*models.MemoryEntry) error
+}
+
+// FileHistory records versions of the memory file.
+type FileHistory interface {
+	Record(ctx context.Context, path, source string) error
+}
+
+// DailyFile is a daily memory file due for consolidation.
+type DailyFile struct {
+	Date time.Time `json:"date"`
+	Path string    `json:"path"`
+	Size int64     `json:"size"`
+}
+
+// Topic is a section of the memory file.
+type Topic struct {
+	Title string
+	Lines []string
+}
+
+// Report describes one consolidation run.
+type Report struct {
+	// Dates are the days consolidated, oldest first.
+	Dates []string `json:"dates"`
+	// Topics are the memory file sections that gained lines.
+	Topics []string `json:"topics"`
+	// Lines is how many lines were added to the memory file.
+	Lines int `json:"lines"`
+	// Indexed is how many topic entries were stored in vector memory.
+	Indexed int `json:"indexed"`
+	// Remaining is how many due files were left for the next run.
+	Remaining int `json:"remaining"`
+	// Truncated is set when the oldest file alone exceeded the input cap
+	// and only its beginning was summarized.
+	Truncated  bool   `json:"truncated,omitempty"`
+	MemoryFile string `json:"memory_file"`
+	ArchiveDir string `json:"archive_dir"`
+}
+
+// Consolidator summarizes daily memory files older than a minimum age into
+// topic sections of the memory file and moves them to an archive directory.
+type Consolidator struct {
+	dir           string
+	archiveDir    string
+	memoryFile    string
+	minAgeDays    int
+	maxInputChars int
+	history       FileHistory
+
+	mu sync.Mutex
+}
+
+// New returns the consolidator for the memory directory and memory file of
+// cfg.
+func New(cfg *config.Config) *Consolidator {
+	memCfg := cfg.Session.Memory
+	dir := strings.TrimSpace(memCfg.Directory)
+	if dir == "" {
+		dir = "memory"
+	}
+	archiveDir := strings.TrimSpace(memCfg.Consolidation.ArchiveDir)
+	if archiveDir == "" {
+		archiveDir = filepath.Join(dir, "archive")
+	}
+	c := &Consolidator{
+		dir:           dir,
+		archiveDir:    archiveDir,
+		minAgeDays:    memCfg.Consolidation.MinAgeDays,
+		maxInputChars: memCfg.Consolidation.MaxInputChars,
+	}
+	if name := strings.TrimSpace(cfg.Workspace.MemoryFile); name != "" {
+		path := name
+		if !filepath.IsAbs(path) {
+			root := strings.TrimSpace(cfg.Workspace.Path)
+			if root == "" {
+				root = "."
+			}
+			path = filepath.Join(root, name)
+		}
+		c.memoryFile = filepath.Clean(path)
+	}
+	return c
+}
+
+// WithHistory versions the memory file in history before and after each
+// change.
+func (c *Consolidator) WithHistory(history FileHistory) *Consolidator {
+	c.history = history
+	return c
+}
+
+// Due returns the daily files at least the minimum age old at now, oldest
+// first.
+func (c *Consolidator) Due(now time.Time) ([]DailyFile, error) {
+	entries, err := os.ReadDir(c.dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read memory dir: %w", err)
+	}
+	year, month, day := now.Date()
+	cutoff := time.Date(year, month, day, 0, 0, 0, 0, now.Location()).AddDate(0, 0, -c.minAgeDays)
+
+	var due []DailyFile
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || filepath.Ext(name) != ".md" {
+			continue
+		}
+		date, err := time.ParseInLocation(dateLayout, strings.TrimSuffix(name, ".md"), now.Location())
+		if err != nil || date.After(cutoff) {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+		due = append(due, DailyFile{Date: date, Path: filepath.Join(c.dir, name), Size: info.Size()})
+	}
+	sort.Slice(due, func(i, j int) bool { return due[i].Date.Before(due[j].Date) })
+	return due, nil
+}
+
+// Run consolidates the due daily files that fit in the input cap: it asks
+// summarize for topic sections, merges them into the memory file, moves the
+// files to the archive directory, and indexes the topics with indexer when
+// it is not nil. It returns a nil report when no file is due. An indexing
+// error is returned with the report, after the files are archived.
+func (c *Consolidator) Run(ctx context.Context, now time.Time, summarize Summarizer, indexer Indexer) (*Report, error) {
+	if c.memoryFile == "" {
+		return nil, errors.New("workspace.memory_file is not set")
+	}
+	if summarize == nil {
+		return nil, errors.New("no summarizer")
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	due, err := c.Due(now)
+	if err != nil || len(due) == 0 {
+		return nil, err
+	}
+	current, err := os.ReadFile(c.memoryFile)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("read memory file: %w", err)
+	}
+
+	batch, transcript, truncated, err := c.collect(due)
+	if err != nil {
+		return nil, err
+	}
+	report := &Report{
+		Remaining:  len(due) - len(batch),
+		Truncated:  truncated,
+		MemoryFile: c.memoryFile,
+		ArchiveDir: c.archiveDir,
+	}
+	for _, file := range batch {
+		report.Dates = append(report.Dates, file.Date.Format(dateLayout))
+	}
+
+	summary, err := summarize(ctx, SystemPrompt, buildPrompt(string(current), transcript))
+	if err != nil {
+		return nil, fmt.Errorf("summarize: %w", err)
+	}
+	topics := ParseTopics(summary)
+	if len(topics) > 0 {
+		if err := c.writeMemory(ctx, topics); err != nil {
+			return nil, err
+		}
+		for _, topic := range topics {
+			report.Topics = append(report.Topics, topic.Title)
+			report.Lines += len(topic.Lines)
+		}
+	}
+	if err := c.archive(batch); err != nil {
+		return report, err
+	}
+	if indexer == nil || len(topics) == 0 {
+		return report, nil
+	}
+	entries := topicEntries(topics, report.Dates, now)
+	if err := indexer.Index(ctx, entries); err != nil {
+		return report, fmt.Errorf("index summaries: %w", err)
+	}
+	report.Indexed = len(entries)
+	return report, nil
+}
+
+// collect reads the oldest due files until the input cap is reached. The
+// first file is always taken, cut to the cap when it exceeds it.
+func (c *Consolidator) collect(due []DailyFile) ([]DailyFile, string, bool, error) {
+	var (
+		sb        strings.Builder
+		batch     []DailyFile
+		truncated bool
+	)
+	for _, file := range due {
+		data, err := os.ReadFile(file.Path)
+		if err != nil {
+			return nil, "", false, fmt.Errorf("read %s: %w", filepath.Base(file.Path), err)
+		}
+		section := "### " + file.Date.Format(dateLayout) + "\n" + strings.TrimSpace(string(data)) + "\n\n"
+		if c.maxInputChars > 0 && sb.Len()+len(section) > c.maxInputChars {
+			if len(batch) > 0 {
+				break
+			}
+			section = strings.ToValidUTF8(section[:c.maxInputChars], "")
+			truncated = true
+		}
+		sb.WriteString(section)
+		batch = append(batch, file)
+	}
+	return batch, sb.String(), truncated, nil
+}
+
+func buildPrompt(current, transcript string) string {
+	var sb strings.Builder
+	if titles := topicTitles(current); len(titles) > 0 {
+		sb.WriteString("Existing topics: ")
+		sb.WriteString(strings.Join(titles, ", "))
+		sb.WriteString("\n\n")
+	}
+	sb.WriteString("Daily memory logs:\n\n")
+	sb.WriteString(transcript)
+	return sb.String()
+}
+
+// writeMemory merges topics into the memory file as it is now, since it may
+// have changed while the summary was written, recording the versions before
+// and after in the file history.
+func (c *Consolidator) writeMemory(ctx context.Context, topics []Topic) error {
+	current, err := os.ReadFile(c.memoryFile)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("read memory file: %w", err)
+	}
+	if c.history != nil {
+		if err := c.history.Record(ctx, c.memoryFile, ""); err != nil {
+			return fmt.Errorf("record memory file history: %w", err)
+		}
+	}
+	if err := os.MkdirAll(filepath.Dir(c.memoryFile), 0o755); err != nil {
+		return fmt.Errorf("create memory file dir: %w", err)
+	}
+	tmp := c.memoryFile + ".tmp"
+	if err := os.WriteFile(tmp, []byte(MergeTopics(string(current), topics)), 0o644); err != nil {
+		return fmt.Errorf("write memory file: %w", err)
+	}
+	if err := os.Rename(tmp, c.memoryFile); err != nil {
+		return fmt.Errorf("write memory file: %w", err)
+	}
+	if c.history != nil {
+		if err := c.history.Record(ctx, c.memoryFile, Source); err != nil {
+			return fmt.Errorf("record memory file history: %w", err)
+		}
+	}
+	return nil
+}
+
+// archive moves files into the archive directory. A file whose name is
+// already archived is appended to the archived copy.
+func (c *Consolidator) archive(files []DailyFile) error {
+	if err := os.MkdirAll(c.archiveDir, 0o755); err != nil {
+		return fmt.Errorf("create archive dir: %w", err)
+	}
+	for _, file := range files {
+		target := filepath.Join(c.archiveDir, filepath.Base(file.Path))
+		if _, err := os.Stat(target); errors.Is(err, os.ErrNotExist) {
+			if err := os.Rename(file.Path, target); err != nil {
+				return fmt.Errorf("archive %s: %w", filepath.Base(file.Path), err)
+			}
+			continue
+		}
+		data, err := os.ReadFile(file.Path)
+		if err != nil {
+			return fmt.Errorf("archive %s: %w", filepath.Base(file.Path), err)
+		}
+		f, err := os.OpenFile(target, os.O_APPEND|os.O_WRONLY, 0o644)
+		if err != nil {
+			return fmt.Errorf("archive %s: %w", filepath.Base(file.Path), err)
+		}
+		_, err = f.Write(data)
+		if closeErr := f.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			return fmt.Errorf("archive %s: %w", filepath.Base(file.Path), err)
+		}
+		if err := os.Remove(file.Path); err != nil {
+			return fmt.Errorf("archive %s: %w", filepath.Base(file.Path), err)
+		}
+	}
+	return nil
+}
+
+func topicEntries(topics []Topic, dates []string, now time.Time) []*models.MemoryEntry {
+	entries := make([]*models.MemoryEntry, 0, len(topics))
+	for _, topic := range topics {
+		entries = append(entries, &models.MemoryEntry{
+			ID:      uuid.New().String(),
+			Content: topic.Title + "\n" + strings.Join(topic.Lines, "\n"),
+			Metadata: models.MemoryMetadata{
+				Source: Source,
+				Role:   string(models.RoleSystem),
+				Tags:   []string{"summary", "topic"},
+				Extra: map[string]any{
+					"topic": topic.Title,
+					"dates": strings.Join(dates, ","),
+				},
+			},
+			CreatedAt: now,
+			UpdatedAt: now,
+		})
+	}
+	return entries
+}
+
+// ParseTopics splits a model summary into topics at its markdown headings.
+// Lines before the first heading go under "Notes". A summary of NONE has no
+// topics.
+func ParseTopics(summary string) []Topic {
+	summary = strings.TrimSpace(summary)
+	if summary == "" || strings.EqualFold(summary, "none") {
+		return nil
+	}
+	var (
+		topics  []Topic
+		current *Topic
+	)
+	for _, line := range strings.Split(summary, "\n") {
+		line = strings.TrimRight(line, " \t\r")
+		if title, ok := headingTitle(line); ok {
+			topics = append(topics, Topic{Title: title})
+			current = &topics[len(topics)-1]
+			continue
+		}
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		if current == nil {
+			topics = append(topics, Topic{Title: "Notes"})
+			current = &topics[len(topics)-1]
+		}
+		current.Lines = append(current.Lines, line)
+	}
+	kept := topics[:0]
+	for _, topic := range topics {
+		if len(topic.Lines) > 0 {
+			kept = append(kept, topic)
+		}
+	}
+	return kept
+}
+
+// MergeTopics adds the lines of each topic to the end of the section of the
+// memory file with the same title, compared without case, or appends a new
+// "## " section for it.
+func MergeTopics(current string, topics []Topic) string {
+	lines := strings.Split(strings.TrimRight(current, "\n"), "\n")
+	if strings.TrimSpace(current) == "" {
+		lines = nil
+	}
+	for _, topic := range topics {
+		start := -1
+		for i, line := range lines {
+			if title, ok := headingTitle(line); ok && strings.EqualFold(title, topic.Title) {
+				start = i
+				break
+			}
+		}
+		if start < 0 {
+			if len(lines) > 0 {
+				lines = append(lines, "")
+			}
+			lines = append(lines, "## "+topic.Title)
+			lines = append(lines, topic.Lines...)
+			continue
+		}
+		end := len(lines)
+		for i := start + 1; i < len(lines); i++ {
+			if _, ok := headingTitle(lines[i]); ok {
+				end = i
+				break
+			}
+		}
+		for end > start+1 && strings.TrimSpace(lines[end-1]) == "" {
+			end--
+		}
+		merged := append([]string(nil), lines[:end]...)
+		merged = append(merged, topic.Lines...)
+		lines = append(merged, lines[end:]...)
+	}
+	return strings.Join(lines, "\n") + "\n"
+}
+
+// topicTitles returns the section titles of a memory file.
+func topicTitles(content string) []string {
+	var titles []string
+	for _, line := range strings.Split(content, "\n") {
+		if title, ok := headingTitle(line); ok {
+			titles = append(titles, title)
+		}
+	}
+	return titles
+}
+
+// headingTitle returns the title of a level one to three markdown heading.
+func headingTitle(line string) (string, bool) {
+	trimmed := strings.TrimSpace(line)
+	level := len(trimmed) - len(strings.TrimLeft(trimmed, "#"))
+	if level == 0 || level > 3 || !strings.HasPrefix(trimmed[level:], " ") {
+		return "", false
+	}
+	title := strings.TrimSpace(trimmed[level:])
+	return title, title != ""
+}
@@ -0,0 +1,176 @@
+package consolidate
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/haasonsaas/nexus/internal/config"
+	"github.com/haasonsaas/nexus/pkg/models"
+)
+
+type fakeIndexer struct {
+	entries []*models.MemoryEntry
+	err     error
+}
+
+func (f *fakeIndexer) Index(_ context.Context, entries []*models.MemoryEntry) error {
+	f.entries = append(f.entries, entries...)
+	return f.err
+}
+
+type fakeHistory struct {
+	sources []string
+}
+
+func (f *fakeHistory) Record(_ context.Context, _ string, source string) error {
+	f.sources = append(f.sources, source)
+	return nil
+}
+
+func newTestConsolidator(t *testing.T, maxInputChars int) (*Consolidator, string) {
+	t.Helper()
+	root := t.TempDir()
+	cfg := &config.Config{Workspace: config.DefaultWorkspaceConfig()}
+	cfg.Workspace.Path = root
+	cfg.Session.Memory.Directory = filepath.Join(root, "memory")
+	cfg.Session.Memory.Consolidation = config.MemoryConsolidationConfig{
+		MinAgeDays:    7,
+		MaxInputChars: maxInputChars,
+	}
+	if err := os.MkdirAll(cfg.Session.Memory.Directory, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	return New(cfg), root
+}
+
+func writeDaily(t *testing.T, root, date, content string) {
+	t.Helper()
+	if err := os.WriteFile(filepath.Join(root, "memory", date+".md"), []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestDueSkipsRecentAndOtherFiles(t *testing.T) {
+	c, root := newTestConsolidator(t, 0)
+	now := time.Date(2026, 3, 20, 15, 0, 0, 0, time.UTC)
+	writeDaily(t, root, "2026-03-13", "- old enough\n")
+	writeDaily(t, root, "2026-03-01", "- older\n")
+	writeDaily(t, root, "2026-03-14", "- too recent\n")
+	writeDaily(t, root, "notes", "- not a daily file\n")
+	if err := os.MkdirAll(filepath.Join(root, "memory", "archive"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	due, err := c.Due(now)
+	if err != nil {
+		t.Fatalf("Due() error = %v", err)
+	}
+	var dates []string
+	for _, file := range due {
+		dates = append(dates, file.Date.Format(dateLayout))
+	}
+	if got := strings.Join(dates, ","); got != "2026-03-01,2026-03-13" {
+		t.Errorf("Due() dates = %s, want 2026-03-01,2026-03-13", got)
+	}
+}
+
+func TestRunMergesArchivesAndIndexes(t *testing.T) {
+	c, root := newTestConsolidator(t, 0)
+	history := &fakeHistory{}
+	c.WithHistory(history)
+	memoryFile := filepath.Join(root, "MEMORY.md")
+	if err := os.WriteFile(memoryFile, []byte("# Memory\n\n## Preferences\n- Likes tea\n\n## Projects\n- Nexus\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	writeDaily(t, root, "2026-03-01", "- [09:00:00] user (telegram/s1): I switched to coffee\n")
+	writeDaily(t, root, "2026-03-02", "- [10:00:00] user (telegram/s1): Trip to Lisbon in May\n")
+
+	var prompt string
+	summarize := func(_ context.Context, system, p string) (string, error) {
+		if system != SystemPrompt {
+			t.Errorf("system prompt = %q", system)
+		}
+		prompt = p
+		return "## preferences\n- Drinks coffee now\n\n## Travel\n- Lisbon trip in May\n", nil
+	}
+	indexer := &fakeIndexer{}
+	report, err := c.Run(context.Background(), time.Date(2026, 3, 20, 3, 0, 0, 0, time.UTC), summarize, indexer)
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	if !strings.Contains(prompt, "Existing topics: Memory, Preferences, Projects") || !strings.Contains(prompt, "### 2026-03-02\n") {
+		t.Errorf("prompt = %q", prompt)
+	}
+	data, _ := os.ReadFile(memoryFile)
+	want := "# Memory\n\n## Preferences\n- Likes tea\n- Drinks coffee now\n\n## Projects\n- Nexus\n\n## Travel\n- Lisbon trip in May\n"
+	if string(data) != want {
+		t.Errorf("memory file = %q, want %q", data, want)
+	}
+	if strings.Join(report.Dates, ",") != "2026-03-01,2026-03-02" || report.Lines != 2 || report.Indexed != 2 {
+		t.Errorf("report = %+v", report)
+	}
+	for _, date := range report.Dates {
+		if _, err := os.Stat(filepath.Join(root, "memory", date+".md")); !errors.Is(err, os.ErrNotExist) {
+			t.Errorf("%s was not moved: %v", date, err)
+		}
+		if _, err := os.Stat(filepath.Join(root, "memory", "archive", date+".md")); err != nil {
+			t.Errorf("%s not archived: %v", date, err)
+		}
+	}
+	if len(indexer.entries) != 2 || indexer.entries[1].Metadata.Source != Source || indexer.entries[1].Metadata.Extra["topic"] != "Travel" {
+		t.Errorf("indexed entries = %+v", indexer.entries)
+	}
+	if strings.Join(history.sources, ",") != ","+Source {
+		t.Errorf("history sources = %q", history.sources)
+	}
+}
+
+func TestRunHonorsInputCap(t *testing.T) {
+	c, root := newTestConsolidator(t, 60)
+	writeDaily(t, root, "2026-03-01", strings.Repeat("a", 40))
+	writeDaily(t, root, "2026-03-02", strings.Repeat("b", 40))
+
+	summarize := func(context.Context, string, string) (string, error) { return "NONE", nil }
+	report, err := c.Run(context.Background(), time.Date(2026, 3, 20, 0, 0, 0, 0, time.UTC), summarize, nil)
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if len(report.Dates) != 1 || report.Remaining != 1 || len(report.Topics) != 0 {
+		t.Errorf("report = %+v", report)
+	}
+	if _, err := os.Stat(filepath.Join(root, "MEMORY.md")); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("memory file written for an empty summary: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(root, "memory", "2026-03-02.md")); err != nil {
+		t.Errorf("file past the cap was consolidated: %v", err)
+	}
+}
+
+func TestRunKeepsFilesWhenSummaryFails(t *testing.T) {
+	c, root := newTestConsolidator(t, 0)
+	writeDaily(t, root, "2026-03-01", "- note\n")
+
+	summarize := func(context.Context, string, string) (string, error) { return "", errors.New("model down") }
+	if _, err := c.Run(context.Background(), time.Date(2026, 3, 20, 0, 0, 0, 0, time.UTC), summarize, nil); err == nil {
+		t.Fatal("Run() succeeded with a failing summarizer")
+	}
+	if _, err := os.Stat(filepath.Join(root, "memory", "2026-03-01.md")); err != nil {
+		t.Errorf("daily file moved after a failed run: %v", err)
+	}
+}
+
+func TestParseTopics(t *testing.T) {
+	topics := ParseTopics("- loose fact\n### Work\n- Ships on Fridays\n## Empty\n")
+	if len(topics) != 2 || topics[0].Title != "Notes" || topics[1].Title != "Work" || topics[1].Lines[0] != "- Ships on Fridays" {
+		t.Errorf("ParseTopics() = %+v", topics)
+	}
+	if topics := ParseTopics(" none "); topics != nil {
+		t.Errorf("ParseTopics(none) = %+v", topics)
+	}
+}
@@ -0,0 +1,216 @@
+package memory
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/haasonsaas/nexus/internal/memory/backend"
+	"github.com/haasonsaas/nexus/internal/storage/encryption"
+	"github.com/haasonsaas/nexus/pkg/models"
+)
+
+// Filter selects stored memories by ID, scope, source, and age. Empty
+// fields match every entry.
+type Filter struct {
+	// IDs limits the selection to these entries.
+	IDs []string
+
+	// Scope limits the selection to one scope. With ScopeID empty, every
+	// entry of a session, channel, or agent scope matches.
+	Scope   models.MemoryScope
+	ScopeID string
+
+	// Source matches the entry's metadata source, such as "message".
+	Source string
+
+	// Before matches entries created before this time.
+	Before time.Time
+}
+
+// IsEmpty reports whether the filter would match every entry.
+func (f Filter) IsEmpty() bool {
+	return len(f.IDs) == 0 && (f.Scope == "" || f.Scope == models.ScopeAll) && f.Source == "" && f.Before.IsZero()
+}
+
+func (f Filter) matches(entry *models.MemoryEntry) bool {
+	if len(f.IDs) > 0 && !slices.Contains(f.IDs, entry.ID) {
+		return false
+	}
+	if f.Source != "" && entry.Metadata.Source != f.Source {
+		return false
+	}
+	if !f.Before.IsZero() && !entry.CreatedAt.Before(f.Before) {
+		return false
+	}
+	scope, scopeID := EntryScope(entry)
+	switch f.Scope {
+	case "", models.ScopeAll:
+		return true
+	case models.ScopeGlobal:
+		return scope == models.ScopeGlobal
+	default:
+		return scope == f.Scope && (f.ScopeID == "" || scopeID == f.ScopeID)
+	}
+}
+
+// EntryScope returns the scope an entry was stored in and its scope ID.
+func EntryScope(entry *models.MemoryEntry) (models.MemoryScope, string) {
+	switch {
+	case entry.SessionID != "":
+		return models.ScopeSession, entry.SessionID
+	case entry.ChannelID != "":
+		return models.ScopeChannel, entry.ChannelID
+	case entry.AgentID != "":
+		return models.ScopeAgent, entry.AgentID
+	default:
+		return models.ScopeGlobal, ""
+	}
+}
+
+// Find returns up to limit stored entries matching filter, or all of them
+// when limit is zero, with their content decrypted. It reads every entry, so
+// it is meant for maintenance rather than the request path.
+func (m *Manager) Find(ctx context.Context, filter Filter, limit int) ([]*models.MemoryEntry, error) {
+	exporter, ok := m.backend.(backend.Exporter)
+	if !ok {
+		return nil, fmt.Errorf("memory backend %q does not support listing entries", m.config.Backend)
+	}
+	var found []*models.MemoryEntry
+	cursor := ""
+	for {
+		entries, next, err := exporter.Export(ctx, cursor, 500)
+		if err != nil {
+			return found, fmt.Errorf("export: %w", err)
+		}
+		for _, entry := range entries {
+			if !filter.matches(entry) {
+				continue
+			}
+			entry.Embedding = nil
+			if encryption.IsEncrypted(entry.Content) && m.keyring != nil {
+				if entry.Content, err = m.keyring.Decrypt(entry.Content); err != nil {
+					return found, fmt.Errorf("failed to decrypt memory %s: %w", entry.ID, err)
+				}
+			}
+			found = append(found, entry)
+			if limit > 0 && len(found) >= limit {
+				return found, nil
+			}
+		}
+		if next == "" {
+			return found, nil
+		}
+		cursor = next
+	}
+}
+
+// DeletionRecord is the audit record written for every deletion. It lists
+// what was removed but not the content, which stays forgotten.
+type DeletionRecord struct {
+	ID        string    `json:"id"`
+	DeletedAt time.Time `json:"deleted_at"`
+
+	// By names who requested the deletion, such as "cli" or
+	// "tool:vector_memory_forget".
+	By string `json:"by"`
+
+	// SessionID is the session the deletion was requested from, if any.
+	SessionID string `json:"session_id,omitempty"`
+
+	// Reason is the free-form reason given for the deletion.
+	Reason string `json:"reason,omitempty"`
+
+	Entries []DeletedEntry `json:"entries"`
+}
+
+// DeletedEntry describes one deleted memory in a DeletionRecord.
+type DeletedEntry struct {
+	ID        string             `json:"id"`
+	Scope     models.MemoryScope `json:"scope"`
+	ScopeID   string             `json:"scope_id,omitempty"`
+	Source    string             `json:"source,omitempty"`
+	CreatedAt time.Time          `json:"created_at"`
+}
+
+// DefaultDeletionLogPath returns ~/.nexus/memory-deletions.jsonl.
+func DefaultDeletionLogPath() string {
+	home, err := os.UserHomeDir()
+	if err != nil || home == "" {
+		return filepath.Join(".nexus", "memory-deletions.jsonl")
+	}
+	return filepath.Join(home, ".nexus", "memory-deletions.jsonl")
+}
+
+// DeletionLogPath returns the configured deletion log with a leading ~
+// expanded, or the default path.
+func DeletionLogPath(cfg *Config) string {
+	path := strings.TrimSpace(cfg.DeletionLog)
+	if path == "" {
+		return DefaultDeletionLogPath()
+	}
+	if strings.HasPrefix(path, "~/") {
+		if home, err := os.UserHomeDir(); err == nil && home != "" {
+			return filepath.Join(home, path[2:])
+		}
+	}
+	return path
+}
+
+// Forget deletes entries and appends a record of the deletion to the
+// deletion log (Config.DeletionLog). record supplies By, SessionID, and
+// Reason; the rest is filled in. The record is returned even when writing
+// it fails after the entries were deleted.
+func (m *Manager) Forget(ctx context.Context, entries []*models.MemoryEntry, record DeletionRecord) (*DeletionRecord, error) {
+	if len(entries) == 0 {
+		return nil, nil
+	}
+	ids := make([]string, 0, len(entries))
+	record.Entries = make([]DeletedEntry, 0, len(entries))
+	for _, entry := range entries {
+		ids = append(ids, entry.ID)
+		scope, scopeID := EntryScope(entry)
+		record.Entries = append(record.Entries, DeletedEntry{
+			ID:        entry.ID,
+			Scope:     scope,
+			ScopeID:   scopeID,
+			Source:    entry.Metadata.Source,
+			CreatedAt: entry.CreatedAt,
+		})
+	}
+	if err := m.backend.Delete(ctx, ids); err != nil {
+		return nil, fmt.Errorf("delete: %w", err)
+	}
+
+	record.ID = uuid.NewString()
+	record.DeletedAt = time.Now().UTC()
+	if err := appendDeletionRecord(DeletionLogPath(m.config), &record); err != nil {
+		return &record, fmt.Errorf("memories deleted but the deletion record was not written: %w", err)
+	}
+	return &record, nil
+}
+
+func appendDeletionRecord(path string, record *DeletionRecord) error {
+	line, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
@@ -0,0 +1,99 @@
+package memory
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/haasonsaas/nexus/internal/memory/backend/lancedb"
+	"github.com/haasonsaas/nexus/pkg/models"
+)
+
+func TestFilter_Matches(t *testing.T) {
+	old := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	entry := &models.MemoryEntry{ID: "a", ChannelID: "c1", Metadata: models.MemoryMetadata{Source: "message"}, CreatedAt: old}
+
+	tests := []struct {
+		name   string
+		filter Filter
+		want   bool
+	}{
+		{"empty", Filter{}, true},
+		{"id", Filter{IDs: []string{"b", "a"}}, true},
+		{"other id", Filter{IDs: []string{"b"}}, false},
+		{"scope", Filter{Scope: models.ScopeChannel}, true},
+		{"scope id", Filter{Scope: models.ScopeChannel, ScopeID: "c1"}, true},
+		{"other scope id", Filter{Scope: models.ScopeChannel, ScopeID: "c2"}, false},
+		{"global", Filter{Scope: models.ScopeGlobal}, false},
+		{"source", Filter{Source: "message"}, true},
+		{"other source", Filter{Source: "document"}, false},
+		{"before", Filter{Before: old.Add(time.Hour)}, true},
+		{"not before", Filter{Before: old}, false},
+	}
+	for _, tt := range tests {
+		if got := tt.filter.matches(entry); got != tt.want {
+			t.Errorf("%s: matches() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+	if !(Filter{Scope: models.ScopeAll}).IsEmpty() || (Filter{Source: "x"}).IsEmpty() {
+		t.Error("IsEmpty() misreports")
+	}
+}
+
+func TestManager_FindAndForget(t *testing.T) {
+	ctx := context.Background()
+	b, err := lancedb.New(lancedb.Config{Path: t.TempDir(), Dimension: 3})
+	if err != nil {
+		t.Fatalf("lancedb.New() error = %v", err)
+	}
+	logPath := filepath.Join(t.TempDir(), "deletions.jsonl")
+	mgr := &Manager{backend: b, embedder: fixedEmbedder{}, config: &Config{Backend: "lancedb", Dimension: 3, DeletionLog: logPath}, cache: newEmbeddingCache(10)}
+	defer mgr.Close()
+
+	entries := []*models.MemoryEntry{
+		{ID: "a", Content: "likes tea", SessionID: "s1", Metadata: models.MemoryMetadata{Source: "message"}},
+		{ID: "b", Content: "lives in Oslo", SessionID: "s1", Metadata: models.MemoryMetadata{Source: "manual"}},
+		{ID: "c", Content: "team handbook", Metadata: models.MemoryMetadata{Source: "document"}},
+	}
+	if err := mgr.Index(ctx, entries); err != nil {
+		t.Fatalf("Index() error = %v", err)
+	}
+
+	found, err := mgr.Find(ctx, Filter{Scope: models.ScopeSession, ScopeID: "s1", Source: "manual"}, 0)
+	if err != nil || len(found) != 1 || found[0].ID != "b" || found[0].Content != "lives in Oslo" {
+		t.Fatalf("Find() = %+v, %v", found, err)
+	}
+
+	record, err := mgr.Forget(ctx, found, DeletionRecord{By: "cli", Reason: "user asked"})
+	if err != nil {
+		t.Fatalf("Forget() error = %v", err)
+	}
+	if record.ID == "" || len(record.Entries) != 1 || record.Entries[0].Scope != models.ScopeSession || record.Entries[0].ScopeID != "s1" {
+		t.Errorf("record = %+v", record)
+	}
+	if n, _ := mgr.Count(ctx, models.ScopeAll, ""); n != 2 {
+		t.Errorf("Count() after Forget = %d, want 2", n)
+	}
+
+	f, err := os.Open(logPath)
+	if err != nil {
+		t.Fatalf("open deletion log: %v", err)
+	}
+	defer f.Close()
+	scanner := bufio.NewScanner(f)
+	if !scanner.Scan() {
+		t.Fatal("deletion log is empty")
+	}
+	if strings.Contains(scanner.Text(), "Oslo") {
+		t.Errorf("deletion log kept the forgotten content: %s", scanner.Text())
+	}
+	var logged DeletionRecord
+	if err := json.Unmarshal(scanner.Bytes(), &logged); err != nil || logged.ID != record.ID || logged.Reason != "user asked" {
+		t.Errorf("logged record = %+v, %v", logged, err)
+	}
+}
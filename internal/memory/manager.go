@@ -11,10 +11,13 @@ import (
 	"github.com/haasonsaas/nexus/internal/memory/backend"
 	"github.com/haasonsaas/nexus/internal/memory/backend/lancedb"
 	"github.com/haasonsaas/nexus/internal/memory/backend/pgvector"
+	"github.com/haasonsaas/nexus/internal/memory/backend/qdrant"
+	"github.com/haasonsaas/nexus/internal/memory/backend/redis"
 	"github.com/haasonsaas/nexus/internal/memory/backend/sqlitevec"
 	"github.com/haasonsaas/nexus/internal/memory/embeddings"
 	"github.com/haasonsaas/nexus/internal/memory/embeddings/ollama"
 	"github.com/haasonsaas/nexus/internal/memory/embeddings/openai"
+	"github.com/haasonsaas/nexus/internal/storage/encryption"
 	"github.com/haasonsaas/nexus/pkg/models"
 )
 
@@ -24,18 +27,24 @@ type Manager struct {
 	embedder embeddings.Provider
 	config   *Config
 	cache    *embeddingCache
+
+	// keyring encrypts entry content at rest when set. Embeddings are
+	// computed from the plaintext before it is encrypted.
+	keyring *encryption.Keyring
 }
 
 // Config contains configuration for the memory manager.
 type Config struct {
 	Enabled   bool   `yaml:"enabled"`
-	Backend   string `yaml:"backend"`   // sqlite-vec, lancedb, pgvector
+	Backend   string `yaml:"backend"`   // sqlite-vec, lancedb, pgvector, qdrant, redis, or a plugin backend
 	Dimension int    `yaml:"dimension"` // Must match embedding model
 
 	// Backend-specific config
 	SQLiteVec SQLiteVecConfig `yaml:"sqlite_vec"`
 	Pgvector  PgvectorConfig  `yaml:"pgvector"`
 	LanceDB   LanceDBConfig   `yaml:"lancedb"`
+	Qdrant    QdrantConfig    `yaml:"qdrant"`
+	Redis     RedisConfig     `yaml:"redis"`
 
 	// Embedding provider config
 	Embeddings EmbeddingsConfig `yaml:"embeddings"`
@@ -54,6 +63,11 @@ type Config struct {
 
 	// Consolidation configuration
 	Consolidation ConsolidationConfig `yaml:"consolidation"`
+
+	// DeletionLog is the JSON Lines file that records every deletion made
+	// with "nexus memory delete" or the forget tool.
+	// Default: ~/.nexus/memory-deletions.jsonl.
+	DeletionLog string `yaml:"deletion_log"`
 }
 
 // SQLiteVecConfig contains sqlite-vec specific configuration.
@@ -102,6 +116,42 @@ type LanceDBConfig struct {
 	RefineFactor int `yaml:"refine_factor"`
 }
 
+// QdrantConfig contains Qdrant specific configuration.
+type QdrantConfig struct {
+	// URL is the Qdrant REST endpoint. Default: http://localhost:6333.
+	URL string `yaml:"url"`
+
+	// APIKey authenticates with Qdrant Cloud or a secured instance.
+	APIKey string `yaml:"api_key"`
+
+	// Collection holds the memories and is created when missing.
+	// Default: nexus_memories.
+	Collection string `yaml:"collection"`
+
+	// Distance is the vector distance used for a new collection.
+	// Options: Cosine (default), Dot, Euclid
+	Distance string `yaml:"distance"`
+}
+
+// RedisConfig contains Redis (RediSearch) specific configuration.
+type RedisConfig struct {
+	// Addr is the Redis host:port. Default: localhost:6379.
+	Addr string `yaml:"addr"`
+
+	// Username and Password authenticate with AUTH when Password is set.
+	Username string `yaml:"username"`
+	Password string `yaml:"password"`
+
+	// DB is the logical database number.
+	DB int `yaml:"db"`
+
+	// Index is the search index, created when missing. Default: nexus_memories.
+	Index string `yaml:"index"`
+
+	// Prefix is the key prefix of memory hashes. Default: nexus:memory:.
+	Prefix string `yaml:"prefix"`
+}
+
 // EmbeddingsConfig contains embedding provider configuration.
 type EmbeddingsConfig struct {
 	Provider string `yaml:"provider"` // openai, gemini, ollama
@@ -152,6 +202,10 @@ type ConsolidationConfig struct {
 	SummaryMaxChars  int           `yaml:"summary_max_chars"`
 	SummaryMaxTokens int           `yaml:"summary_max_tokens"`
 	Model            string        `yaml:"model"`
+
+	// Batch sends the session summaries of a run through the llm.batch
+	// queue together, at about half the price.
+	Batch bool `yaml:"batch"`
 }
 
 // NewManager creates a new memory manager with the given configuration.
@@ -217,13 +271,47 @@ func NewManager(cfg *Config) (*Manager, error) {
 	}
 
 	// Initialize backend
+	b, err := NewBackend(cfg, cfg.Backend)
+	if err != nil {
+		return nil, err
+	}
+
+	// Initialize embedder
+	emb, err := NewEmbedder(cfg.Embeddings)
+	if err != nil {
+		b.Close()
+		return nil, err
+	}
+
+	// Verify dimension matches
+	if emb.Dimension() != cfg.Dimension {
+		b.Close()
+		return nil, fmt.Errorf("dimension mismatch: config=%d, embedder=%d", cfg.Dimension, emb.Dimension())
+	}
+
+	return &Manager{
+		backend:  b,
+		embedder: emb,
+		config:   cfg,
+		cache:    newEmbeddingCache(1000), // Cache up to 1000 query embeddings
+	}, nil
+}
+
+// NewBackend opens the named storage backend with its settings from cfg.
+// An empty name selects sqlite-vec. Names not built in are looked up among
+// the backends registered with backend.Register, such as by plugins.
+func NewBackend(cfg *Config, name string) (backend.Backend, error) {
+	dimension := cfg.Dimension
+	if dimension == 0 {
+		dimension = 1536
+	}
 	var b backend.Backend
 	var err error
-	switch cfg.Backend {
+	switch name {
 	case "sqlite-vec", "sqlite", "":
 		b, err = sqlitevec.New(sqlitevec.Config{
 			Path:      cfg.SQLiteVec.Path,
-			Dimension: cfg.Dimension,
+			Dimension: dimension,
 		})
 	case "pgvector", "postgres", "postgresql":
 		runMigrations := true
@@ -233,57 +321,113 @@ func NewManager(cfg *Config) (*Manager, error) {
 		b, err = pgvector.New(pgvector.Config{
 			DSN:           cfg.Pgvector.DSN,
 			DB:            cfg.Pgvector.DB,
-			Dimension:     cfg.Dimension,
+			Dimension:     dimension,
 			RunMigrations: runMigrations,
 		})
 	case "lancedb", "lance":
 		b, err = lancedb.New(lancedb.Config{
 			Path:       cfg.LanceDB.Path,
-			Dimension:  cfg.Dimension,
+			Dimension:  dimension,
 			IndexType:  lancedb.IndexType(cfg.LanceDB.IndexType),
 			MetricType: cfg.LanceDB.MetricType,
 		})
+	case "qdrant":
+		b, err = qdrant.New(qdrant.Config{
+			URL:        cfg.Qdrant.URL,
+			APIKey:     cfg.Qdrant.APIKey,
+			Collection: cfg.Qdrant.Collection,
+			Dimension:  dimension,
+			Distance:   cfg.Qdrant.Distance,
+		})
+	case "redis", "redisearch":
+		b, err = redis.New(redis.Config{
+			Addr:      cfg.Redis.Addr,
+			Username:  cfg.Redis.Username,
+			Password:  cfg.Redis.Password,
+			DB:        cfg.Redis.DB,
+			Index:     cfg.Redis.Index,
+			Prefix:    cfg.Redis.Prefix,
+			Dimension: dimension,
+		})
 	default:
-		return nil, fmt.Errorf("unknown backend: %s", cfg.Backend)
+		factory, ok := backend.Lookup(name)
+		if !ok {
+			return nil, fmt.Errorf("unknown backend: %s", name)
+		}
+		b, err = factory(backend.Config{Dimension: dimension})
 	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to initialize backend: %w", err)
 	}
+	return b, nil
+}
 
-	// Initialize embedder
+// NewEmbedder creates the embedding provider described by cfg.
+func NewEmbedder(cfg EmbeddingsConfig) (embeddings.Provider, error) {
 	var emb embeddings.Provider
-	switch cfg.Embeddings.Provider {
+	var err error
+	switch cfg.Provider {
 	case "openai", "":
 		emb, err = openai.New(openai.Config{
-			APIKey:  cfg.Embeddings.APIKey,
-			BaseURL: cfg.Embeddings.BaseURL,
-			Model:   cfg.Embeddings.Model,
+			APIKey:  cfg.APIKey,
+			BaseURL: cfg.BaseURL,
+			Model:   cfg.Model,
 		})
 	case "ollama":
 		emb, err = ollama.New(ollama.Config{
-			BaseURL: cfg.Embeddings.OllamaURL,
-			Model:   cfg.Embeddings.Model,
+			BaseURL: cfg.OllamaURL,
+			Model:   cfg.Model,
 		})
 	default:
-		return nil, fmt.Errorf("unknown embedding provider: %s", cfg.Embeddings.Provider)
+		return nil, fmt.Errorf("unknown embedding provider: %s", cfg.Provider)
 	}
 	if err != nil {
-		b.Close()
 		return nil, fmt.Errorf("failed to initialize embedder: %w", err)
 	}
+	return emb, nil
+}
 
-	// Verify dimension matches
-	if emb.Dimension() != cfg.Dimension {
-		b.Close()
-		return nil, fmt.Errorf("dimension mismatch: config=%d, embedder=%d", cfg.Dimension, emb.Dimension())
+// Migrate copies every entry from one backend to another in batches,
+// reporting the running total to progress after each batch. Entries keep
+// their IDs, scopes, timestamps, and embeddings, and encrypted content is
+// copied as stored. Re-running a migration overwrites entries already copied.
+func Migrate(ctx context.Context, from, to backend.Backend, batchSize int, progress func(copied int)) (int, error) {
+	exporter, ok := from.(backend.Exporter)
+	if !ok {
+		return 0, fmt.Errorf("source backend does not support export")
+	}
+	if batchSize <= 0 {
+		batchSize = 500
+	}
+	copied := 0
+	cursor := ""
+	for {
+		entries, next, err := exporter.Export(ctx, cursor, batchSize)
+		if err != nil {
+			return copied, fmt.Errorf("export: %w", err)
+		}
+		if len(entries) > 0 {
+			// Index stamps UpdatedAt; keep the source timestamps.
+			updated := make([]time.Time, len(entries))
+			for i, entry := range entries {
+				updated[i] = entry.UpdatedAt
+			}
+			if err := to.Index(ctx, entries); err != nil {
+				return copied, fmt.Errorf("index: %w", err)
+			}
+			for i, entry := range entries {
+				entry.UpdatedAt = updated[i]
+			}
+			copied += len(entries)
+			if progress != nil {
+				progress(copied)
+			}
+		}
+		if next == "" {
+			return copied, nil
+		}
+		cursor = next
 	}
-
-	return &Manager{
-		backend:  b,
-		embedder: emb,
-		config:   cfg,
-		cache:    newEmbeddingCache(1000), // Cache up to 1000 query embeddings
-	}, nil
 }
 
 // Index stores memory entries, generating embeddings as needed.
@@ -328,8 +472,31 @@ func (m *Manager) Index(ctx context.Context, entries []*models.MemoryEntry) erro
 		}
 	}
 
-	// Store in backend
-	return m.backend.Index(ctx, entries)
+	if m.keyring == nil {
+		return m.backend.Index(ctx, entries)
+	}
+
+	// Encrypt copies so callers keep their plaintext entries.
+	sealed := make([]*models.MemoryEntry, len(entries))
+	for i, entry := range entries {
+		content, err := m.keyring.Encrypt(entry.Content)
+		if err != nil {
+			return fmt.Errorf("failed to encrypt memory %s: %w", entry.ID, err)
+		}
+		copied := *entry
+		copied.Content = content
+		sealed[i] = &copied
+	}
+	if err := m.backend.Index(ctx, sealed); err != nil {
+		return err
+	}
+	// Backends may assign IDs and timestamps; reflect them back.
+	for i, entry := range entries {
+		entry.ID = sealed[i].ID
+		entry.CreatedAt = sealed[i].CreatedAt
+		entry.UpdatedAt = sealed[i].UpdatedAt
+	}
+	return nil
 }
 
 // Search finds relevant memories using semantic similarity.
@@ -370,6 +537,19 @@ func (m *Manager) Search(ctx context.Context, req *models.SearchRequest) (*model
 	if err != nil {
 		return nil, fmt.Errorf("search failed: %w", err)
 	}
+	for _, result := range results {
+		if result.Entry == nil || !encryption.IsEncrypted(result.Entry.Content) {
+			continue
+		}
+		content, err := m.keyring.Decrypt(result.Entry.Content)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decrypt memory %s: %w", result.Entry.ID, err)
+		}
+		// Copy so in-memory backends keep the stored ciphertext.
+		entry := *result.Entry
+		entry.Content = content
+		result.Entry = &entry
+	}
 
 	return &models.SearchResponse{
 		Results:    results,
@@ -388,6 +568,57 @@ func (m *Manager) Count(ctx context.Context, scope models.MemoryScope, scopeID s
 	return m.backend.Count(ctx, scope, scopeID)
 }
 
+// Prune removes memories in the scope created before the cutoff, or every
+// memory in the scope when before is zero. Session, channel, and agent scopes
+// require a scope ID.
+func (m *Manager) Prune(ctx context.Context, scope models.MemoryScope, scopeID string, before time.Time) (int64, error) {
+	pruner, ok := m.backend.(backend.Pruner)
+	if !ok {
+		return 0, fmt.Errorf("memory backend %q does not support pruning", m.config.Backend)
+	}
+	switch scope {
+	case models.ScopeSession, models.ScopeChannel, models.ScopeAgent:
+		if scopeID == "" {
+			return 0, fmt.Errorf("scope %q requires a scope id", scope)
+		}
+	}
+	return pruner.Prune(ctx, scope, scopeID, before)
+}
+
+// SetKeyring makes the manager encrypt entry content before it reaches the
+// backend and decrypt it in search results. Keyword (BM25) search cannot
+// match encrypted content.
+func (m *Manager) SetKeyring(keyring *encryption.Keyring) {
+	m.keyring = keyring
+}
+
+// Reencrypt rewrites every entry whose content is plaintext or encrypted
+// with a retired key so it is encrypted with the primary key. With dryRun
+// set it only counts them.
+func (m *Manager) Reencrypt(ctx context.Context, dryRun bool) (int64, error) {
+	if m.keyring == nil {
+		return 0, fmt.Errorf("memory encryption is not configured")
+	}
+	rewriter, ok := m.backend.(backend.ContentRewriter)
+	if !ok {
+		return 0, fmt.Errorf("memory backend %q does not support re-encryption", m.config.Backend)
+	}
+	var stale int64
+	changed, err := rewriter.RewriteContent(ctx, func(content string) (string, bool, error) {
+		if dryRun {
+			if m.keyring.NeedsRewrap(content) {
+				stale++
+			}
+			return content, false, nil
+		}
+		return m.keyring.Rewrap(content)
+	})
+	if dryRun {
+		return stale, err
+	}
+	return changed, err
+}
+
 // Compact optimizes the storage backend.
 func (m *Manager) Compact(ctx context.Context) error {
 	return m.backend.Compact(ctx)
@@ -1,8 +1,15 @@
 package memory
 
 import (
+	"bytes"
+	"context"
 	"testing"
 	"time"
+
+	"github.com/haasonsaas/nexus/internal/memory/backend"
+	"github.com/haasonsaas/nexus/internal/memory/backend/lancedb"
+	"github.com/haasonsaas/nexus/internal/storage/encryption"
+	"github.com/haasonsaas/nexus/pkg/models"
 )
 
 func TestNewEmbeddingCache(t *testing.T) {
@@ -403,3 +410,124 @@ func TestEmbeddingCache_ConcurrentAccess(t *testing.T) {
 		}
 	}
 }
+
+type fixedEmbedder struct{}
+
+func (fixedEmbedder) Embed(ctx context.Context, text string) ([]float32, error) {
+	return []float32{1, 0, 0}, nil
+}
+
+func (fixedEmbedder) EmbedBatch(ctx context.Context, texts []string) ([][]float32, error) {
+	out := make([][]float32, len(texts))
+	for i := range texts {
+		out[i] = []float32{1, 0, 0}
+	}
+	return out, nil
+}
+
+func (fixedEmbedder) Name() string      { return "fixed" }
+func (fixedEmbedder) Dimension() int    { return 3 }
+func (fixedEmbedder) MaxBatchSize() int { return 16 }
+
+func TestManager_EncryptsContent(t *testing.T) {
+	ctx := context.Background()
+	b, err := lancedb.New(lancedb.Config{Path: t.TempDir(), Dimension: 3})
+	if err != nil {
+		t.Fatalf("lancedb.New() error = %v", err)
+	}
+	cfg := &Config{Backend: "lancedb", Dimension: 3}
+	mgr := &Manager{backend: b, embedder: fixedEmbedder{}, config: cfg, cache: newEmbeddingCache(10)}
+	defer mgr.Close()
+
+	if err := mgr.Index(ctx, []*models.MemoryEntry{{Content: "legacy note"}}); err != nil {
+		t.Fatalf("Index() error = %v", err)
+	}
+	keyring, err := encryption.NewKeyring("k1", map[string][]byte{"k1": bytes.Repeat([]byte{1}, encryption.KeySize)})
+	if err != nil {
+		t.Fatalf("NewKeyring() error = %v", err)
+	}
+	mgr.SetKeyring(keyring)
+
+	entry := &models.MemoryEntry{Content: "prefers dark mode"}
+	if err := mgr.Index(ctx, []*models.MemoryEntry{entry}); err != nil {
+		t.Fatalf("Index() error = %v", err)
+	}
+	if entry.Content != "prefers dark mode" || entry.ID == "" {
+		t.Fatalf("expected caller entry to keep plaintext and receive an ID, got %+v", entry)
+	}
+
+	pending, err := mgr.Reencrypt(ctx, true)
+	if err != nil || pending != 1 {
+		t.Fatalf("Reencrypt(dryRun) = %d, %v", pending, err)
+	}
+	if migrated, err := mgr.Reencrypt(ctx, false); err != nil || migrated != 1 {
+		t.Fatalf("Reencrypt() = %d, %v", migrated, err)
+	}
+
+	stored, err := b.Search(ctx, []float32{1, 0, 0}, &backend.SearchOptions{Scope: models.ScopeAll, Limit: 10})
+	if err != nil {
+		t.Fatalf("backend Search() error = %v", err)
+	}
+	for _, result := range stored {
+		if !encryption.IsEncrypted(result.Entry.Content) {
+			t.Fatalf("expected encrypted content at rest, got %q", result.Entry.Content)
+		}
+	}
+
+	resp, err := mgr.Search(ctx, &models.SearchRequest{Query: "theme", Scope: models.ScopeAll, Limit: 10, Threshold: 0.1})
+	if err != nil {
+		t.Fatalf("Search() error = %v", err)
+	}
+	if len(resp.Results) != 2 {
+		t.Fatalf("expected 2 results, got %d", len(resp.Results))
+	}
+	for _, result := range resp.Results {
+		if encryption.IsEncrypted(result.Entry.Content) {
+			t.Fatalf("expected decrypted search result, got %q", result.Entry.Content)
+		}
+	}
+}
+
+func TestMigrate(t *testing.T) {
+	ctx := context.Background()
+	from, err := lancedb.New(lancedb.Config{Path: t.TempDir(), Dimension: 3})
+	if err != nil {
+		t.Fatalf("lancedb.New() error = %v", err)
+	}
+	defer from.Close()
+	to, err := lancedb.New(lancedb.Config{Path: t.TempDir(), Dimension: 3})
+	if err != nil {
+		t.Fatalf("lancedb.New() error = %v", err)
+	}
+	defer to.Close()
+
+	created := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	for _, id := range []string{"a", "b", "c", "d", "e"} {
+		entry := &models.MemoryEntry{ID: id, SessionID: "s1", Content: "note " + id, Embedding: []float32{1, 0, 0}, CreatedAt: created}
+		if err := from.Index(ctx, []*models.MemoryEntry{entry}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var progress []int
+	copied, err := Migrate(ctx, from, to, 2, func(n int) { progress = append(progress, n) })
+	if err != nil || copied != 5 {
+		t.Fatalf("Migrate() = %d, %v", copied, err)
+	}
+	if len(progress) != 3 || progress[2] != 5 {
+		t.Errorf("progress = %v", progress)
+	}
+	if n, _ := to.Count(ctx, models.ScopeSession, "s1"); n != 5 {
+		t.Errorf("target session count = %d, want 5", n)
+	}
+	entries, _, err := to.Export(ctx, "", 10)
+	if err != nil || len(entries) != 5 || entries[0].ID != "a" || !entries[0].CreatedAt.Equal(created) || len(entries[0].Embedding) != 3 {
+		t.Errorf("target entries = %+v, %v", entries, err)
+	}
+
+	if _, err := Migrate(ctx, &nonExporter{}, to, 0, nil); err == nil {
+		t.Error("Migrate() accepted a backend without export support")
+	}
+}
+
+type nonExporter struct{ backend.Backend }
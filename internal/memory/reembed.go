@@ -0,0 +1,240 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/haasonsaas/nexus/internal/memory/backend"
+	"github.com/haasonsaas/nexus/internal/memory/embeddings"
+	"github.com/haasonsaas/nexus/internal/storage/encryption"
+	"github.com/haasonsaas/nexus/pkg/models"
+)
+
+// reembedAttempts bounds how often one embedding request is tried before
+// re-embedding stops; reembedRetryDelay is the wait before the first retry
+// and doubles after each one.
+var (
+	reembedAttempts   = 3
+	reembedRetryDelay = 5 * time.Second
+)
+
+// ReembedOptions controls Manager.Reembed.
+type ReembedOptions struct {
+	// BatchSize is how many entries are read and written at a time.
+	// Default: 100.
+	BatchSize int
+
+	// RequestsPerMinute spaces embedding requests to stay under a provider
+	// rate limit. Zero means no limit.
+	RequestsPerMinute int
+
+	// Progress, when set, is called after each batch with the number of
+	// entries processed so far and the number of entries when the run began.
+	Progress func(done, total int64)
+}
+
+// ReembedReport describes a completed re-embedding run.
+type ReembedReport struct {
+	// Entries is how many entries were re-embedded.
+	Entries int64 `json:"entries"`
+
+	// Skipped counts entries without content, which are not carried over
+	// to a staged index.
+	Skipped int64 `json:"skipped"`
+
+	// Requests is how many embedding requests were made.
+	Requests int `json:"requests"`
+
+	// Dimension is the dimension of the new embeddings.
+	Dimension int `json:"dimension"`
+
+	// Staged reports whether the entries were written to a parallel index
+	// that then replaced the live one, rather than updated in place.
+	Staged bool `json:"staged"`
+
+	// Duration is how long the run took.
+	Duration time.Duration `json:"duration"`
+}
+
+// Reembed recomputes the embedding of every entry with emb and switches the
+// manager to it. On backends that implement backend.Reindexer the entries
+// are written to a parallel index that replaces the live one only once all
+// of them are done, so searches keep working on the old embeddings until
+// then and a failed run leaves the live index untouched. Other backends are
+// updated in place, which requires the dimension to stay the same. Entries
+// stored while the run is in progress may not be carried over to a staged
+// index.
+func (m *Manager) Reembed(ctx context.Context, emb embeddings.Provider, opts ReembedOptions) (*ReembedReport, error) {
+	start := time.Now()
+	exporter, ok := m.backend.(backend.Exporter)
+	if !ok {
+		return nil, fmt.Errorf("memory backend %q does not support export", m.config.Backend)
+	}
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = 100
+	}
+	total, err := m.backend.Count(ctx, models.ScopeAll, "")
+	if err != nil {
+		return nil, fmt.Errorf("count entries: %w", err)
+	}
+
+	report := &ReembedReport{Dimension: emb.Dimension()}
+	target := m.backend
+	reindexer, staged := m.backend.(backend.Reindexer)
+	if staged {
+		target, err = reindexer.StageIndex(ctx, report.Dimension)
+		if err != nil {
+			return nil, fmt.Errorf("stage index: %w", err)
+		}
+		defer target.Close()
+		report.Staged = true
+	} else if report.Dimension != m.config.Dimension {
+		return nil, fmt.Errorf("memory backend %q cannot change the embedding dimension from %d to %d; migrate to sqlite-vec, lancedb or pgvector first",
+			m.config.Backend, m.config.Dimension, report.Dimension)
+	}
+
+	pace := newPacer(opts.RequestsPerMinute)
+	var done int64
+	cursor := ""
+	for {
+		entries, next, err := exporter.Export(ctx, cursor, opts.BatchSize)
+		if err != nil {
+			return report, fmt.Errorf("export: %w", err)
+		}
+		var batch []*models.MemoryEntry
+		var texts []string
+		for _, entry := range entries {
+			text := entry.Content
+			if encryption.IsEncrypted(text) {
+				if m.keyring == nil {
+					return report, fmt.Errorf("memory %s is encrypted but no encryption key is configured", entry.ID)
+				}
+				if text, err = m.keyring.Decrypt(text); err != nil {
+					return report, fmt.Errorf("failed to decrypt memory %s: %w", entry.ID, err)
+				}
+			}
+			if text == "" {
+				report.Skipped++
+				continue
+			}
+			batch = append(batch, entry)
+			texts = append(texts, text)
+		}
+
+		for i := 0; i < len(batch); i += emb.MaxBatchSize() {
+			end := min(i+emb.MaxBatchSize(), len(batch))
+			vectors, err := m.embedWithRetry(ctx, emb, pace, texts[i:end])
+			report.Requests++
+			if err != nil {
+				return report, fmt.Errorf("failed to generate embeddings: %w", err)
+			}
+			for j, entry := range batch[i:end] {
+				entry.Embedding = vectors[j]
+			}
+		}
+
+		if len(batch) > 0 {
+			// Index stamps UpdatedAt; keep the stored timestamps.
+			updated := make([]time.Time, len(batch))
+			for i, entry := range batch {
+				updated[i] = entry.UpdatedAt
+			}
+			if err := target.Index(ctx, batch); err != nil {
+				return report, fmt.Errorf("index: %w", err)
+			}
+			for i, entry := range batch {
+				entry.UpdatedAt = updated[i]
+			}
+		}
+		report.Entries += int64(len(batch))
+		done += int64(len(entries))
+		if opts.Progress != nil {
+			opts.Progress(done, total)
+		}
+		if next == "" {
+			break
+		}
+		cursor = next
+	}
+
+	if staged {
+		if err := reindexer.PromoteIndex(ctx, target); err != nil {
+			return report, fmt.Errorf("promote index: %w", err)
+		}
+	}
+	m.embedder = emb
+	m.config.Dimension = report.Dimension
+	m.cache = newEmbeddingCache(1000)
+	report.Duration = time.Since(start)
+	return report, nil
+}
+
+// embedWithRetry embeds one batch, retrying failed requests with a doubling
+// delay.
+func (m *Manager) embedWithRetry(ctx context.Context, emb embeddings.Provider, pace *pacer, texts []string) ([][]float32, error) {
+	delay := reembedRetryDelay
+	var lastErr error
+	for attempt := 0; attempt < reembedAttempts; attempt++ {
+		if attempt > 0 {
+			if err := sleepCtx(ctx, delay); err != nil {
+				return nil, err
+			}
+			delay *= 2
+		}
+		if err := pace.wait(ctx); err != nil {
+			return nil, err
+		}
+		vectors, err := emb.EmbedBatch(ctx, texts)
+		if err == nil {
+			if len(vectors) != len(texts) {
+				return nil, fmt.Errorf("embedder returned %d embeddings for %d texts", len(vectors), len(texts))
+			}
+			return vectors, nil
+		}
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		lastErr = err
+	}
+	return nil, lastErr
+}
+
+// pacer spaces calls to at most a fixed number per minute.
+type pacer struct {
+	interval time.Duration
+	next     time.Time
+}
+
+func newPacer(perMinute int) *pacer {
+	if perMinute <= 0 {
+		return &pacer{}
+	}
+	return &pacer{interval: time.Minute / time.Duration(perMinute)}
+}
+
+// wait blocks until the next call is allowed.
+func (p *pacer) wait(ctx context.Context) error {
+	if p.interval <= 0 {
+		return nil
+	}
+	if err := sleepCtx(ctx, time.Until(p.next)); err != nil {
+		return err
+	}
+	p.next = time.Now().Add(p.interval)
+	return nil
+}
+
+func sleepCtx(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,136 @@
+package memory
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/haasonsaas/nexus/internal/memory/backend"
+	"github.com/haasonsaas/nexus/internal/memory/backend/lancedb"
+	"github.com/haasonsaas/nexus/internal/storage/encryption"
+	"github.com/haasonsaas/nexus/pkg/models"
+)
+
+// wideEmbedder returns 4-dimensional embeddings that encode the text length,
+// failing its first request when flaky is set.
+type wideEmbedder struct {
+	flaky bool
+	calls int
+	texts []string
+}
+
+func (e *wideEmbedder) Embed(ctx context.Context, text string) ([]float32, error) {
+	vectors, err := e.EmbedBatch(ctx, []string{text})
+	if err != nil {
+		return nil, err
+	}
+	return vectors[0], nil
+}
+
+func (e *wideEmbedder) EmbedBatch(_ context.Context, texts []string) ([][]float32, error) {
+	e.calls++
+	if e.flaky && e.calls == 1 {
+		return nil, errors.New("429 too many requests")
+	}
+	e.texts = append(e.texts, texts...)
+	out := make([][]float32, len(texts))
+	for i, text := range texts {
+		out[i] = []float32{0, 0, 0, float32(len(text))}
+	}
+	return out, nil
+}
+
+func (e *wideEmbedder) Name() string      { return "wide" }
+func (e *wideEmbedder) Dimension() int    { return 4 }
+func (e *wideEmbedder) MaxBatchSize() int { return 2 }
+
+func TestManager_ReembedStagesAndSwitches(t *testing.T) {
+	reembedRetryDelay = 0
+	ctx := context.Background()
+	b, err := lancedb.New(lancedb.Config{Path: t.TempDir(), Dimension: 3})
+	if err != nil {
+		t.Fatalf("lancedb.New() error = %v", err)
+	}
+	cfg := &Config{Backend: "lancedb", Dimension: 3}
+	mgr := &Manager{backend: b, embedder: fixedEmbedder{}, config: cfg, cache: newEmbeddingCache(10)}
+	defer mgr.Close()
+	keyring, err := encryption.NewKeyring("k1", map[string][]byte{"k1": bytes.Repeat([]byte{1}, encryption.KeySize)})
+	if err != nil {
+		t.Fatalf("NewKeyring() error = %v", err)
+	}
+	mgr.SetKeyring(keyring)
+
+	for _, content := range []string{"one note", "another note", "third", "fourth memory", "fifth"} {
+		if err := mgr.Index(ctx, []*models.MemoryEntry{{Content: content, SessionID: "s1"}}); err != nil {
+			t.Fatalf("Index() error = %v", err)
+		}
+	}
+
+	emb := &wideEmbedder{flaky: true}
+	var progress []int64
+	report, err := mgr.Reembed(ctx, emb, ReembedOptions{
+		BatchSize: 3,
+		Progress:  func(done, total int64) { progress = append(progress, done, total) },
+	})
+	if err != nil {
+		t.Fatalf("Reembed() error = %v", err)
+	}
+	if report.Entries != 5 || !report.Staged || report.Dimension != 4 || report.Requests != 3 {
+		t.Errorf("report = %+v", report)
+	}
+	if len(progress) != 4 || progress[0] != 3 || progress[2] != 5 || progress[3] != 5 {
+		t.Errorf("progress = %v", progress)
+	}
+	for _, text := range emb.texts {
+		if encryption.IsEncrypted(text) {
+			t.Fatalf("embedder received ciphertext %q", text)
+		}
+	}
+	if mgr.config.Dimension != 4 || mgr.embedder != emb {
+		t.Errorf("manager not switched: dimension %d, embedder %v", mgr.config.Dimension, mgr.embedder.Name())
+	}
+
+	stored, err := b.Search(ctx, []float32{0, 0, 0, 1}, &backend.SearchOptions{Scope: models.ScopeAll, Limit: 10})
+	if err != nil || len(stored) != 5 {
+		t.Fatalf("backend Search() = %d results, %v", len(stored), err)
+	}
+	for _, result := range stored {
+		if !encryption.IsEncrypted(result.Entry.Content) || result.Entry.SessionID != "s1" {
+			t.Errorf("stored entry = %+v", result.Entry)
+		}
+	}
+	resp, err := mgr.Search(ctx, &models.SearchRequest{Query: "fifth", Scope: models.ScopeAll, Limit: 1, Threshold: 0.5})
+	if err != nil || len(resp.Results) != 1 {
+		t.Fatalf("Search() after reembed = %+v, %v", resp, err)
+	}
+}
+
+func TestManager_ReembedInPlaceKeepsDimension(t *testing.T) {
+	ctx := context.Background()
+	b, err := lancedb.New(lancedb.Config{Path: t.TempDir(), Dimension: 4})
+	if err != nil {
+		t.Fatalf("lancedb.New() error = %v", err)
+	}
+	// Hide StageIndex so the manager has to update entries in place.
+	plain := struct {
+		backend.Backend
+		backend.Exporter
+	}{b, b}
+	mgr := &Manager{backend: plain, embedder: &wideEmbedder{}, config: &Config{Backend: "test", Dimension: 4}, cache: newEmbeddingCache(10)}
+	defer mgr.Close()
+	if err := mgr.Index(ctx, []*models.MemoryEntry{{ID: "a", Content: "hello"}}); err != nil {
+		t.Fatalf("Index() error = %v", err)
+	}
+
+	report, err := mgr.Reembed(ctx, &wideEmbedder{}, ReembedOptions{})
+	if err != nil || report.Staged || report.Entries != 1 {
+		t.Fatalf("Reembed() = %+v, %v", report, err)
+	}
+
+	mgr.config.Dimension = 3
+	if _, err := mgr.Reembed(ctx, &wideEmbedder{}, ReembedOptions{}); err == nil || !strings.Contains(err.Error(), "cannot change the embedding dimension") {
+		t.Errorf("Reembed() with a dimension change error = %v", err)
+	}
+}
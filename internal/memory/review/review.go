@@ -0,0 +1,465 @@
+// Package review queues memory writes for user approval.
+package review
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/haasonsaas/nexus/internal/agent"
+	"github.com/haasonsaas/nexus/internal/config"
+	"github.com/haasonsaas/nexus/internal/textdiff"
+	"github.com/haasonsaas/nexus/pkg/models"
+)
+
+// Kinds of proposals.
+const (
+	// KindVector is an entry for vector memory.
+	KindVector = "vector"
+	// KindFile is a change to the workspace memory file.
+	KindFile = "file"
+)
+
+// Proposal statuses.
+const (
+	StatusPending  = "pending"
+	StatusApproved = "approved"
+	StatusRejected = "rejected"
+)
+
+// ScopeFile is the review scope of the workspace memory file.
+const ScopeFile = "file"
+
+// decidedRetention is how long decided proposals are kept.
+const decidedRetention = 30 * 24 * time.Hour
+
+var (
+	// ErrNotFound is returned for an unknown proposal ID.
+	ErrNotFound = errors.New("memory proposal not found")
+
+	// ErrDecided is returned when deciding a proposal that is not pending.
+	ErrDecided = errors.New("memory proposal already decided")
+
+	// ErrStale is returned when approving a file change whose file has
+	// changed since it was proposed.
+	ErrStale = errors.New("memory file changed since the proposal was made")
+)
+
+// Indexer stores approved vector memory entries.
+type Indexer interface {
+	Index(ctx context.Context, entries []*models.MemoryEntry) error
+}
+
+// Proposal is a memory write waiting for review.
+type Proposal struct {
+	ID      string `json:"id"`
+	Kind    string `json:"kind"`
+	Scope   string `json:"scope"`
+	ScopeID string `json:"scope_id,omitempty"`
+
+	// Content is what would be remembered: the entry text, or the text
+	// added to the memory file. A file change that only removes text has
+	// no Content; see Diff.
+	Content string `json:"content"`
+
+	// Entry is the vector memory entry to index.
+	Entry *models.MemoryEntry `json:"entry,omitempty"`
+
+	// Path is the absolute path of the memory file.
+	Path string `json:"path,omitempty"`
+	// Append is set when the change only adds Content to the end of the
+	// file; it then applies whatever the file holds when approved.
+	Append bool `json:"append,omitempty"`
+	// NewContent replaces the file when the change is not an append.
+	NewContent string `json:"new_content,omitempty"`
+	// BaseSHA256 is the hash of the file the replacement was made from.
+	BaseSHA256 string `json:"base_sha256,omitempty"`
+	// Diff is a unified diff of the file change.
+	Diff string `json:"diff,omitempty"`
+
+	Tool      string             `json:"tool,omitempty"`
+	AgentID   string             `json:"agent_id,omitempty"`
+	SessionID string             `json:"session_id,omitempty"`
+	Channel   models.ChannelType `json:"channel,omitempty"`
+	ChannelID string             `json:"channel_id,omitempty"`
+	Status    string             `json:"status"`
+	CreatedAt time.Time          `json:"created_at"`
+	DecidedAt *time.Time         `json:"decided_at,omitempty"`
+	DecidedBy string             `json:"decided_by,omitempty"`
+}
+
+// Queue stores proposals as one JSON file each.
+type Queue struct {
+	dir         string
+	memoryFile  string
+	autoApprove map[string]bool
+
+	mu      sync.Mutex
+	onQueue func(ctx context.Context, proposal *Proposal)
+}
+
+// NewQueue returns the review queue of cfg, or nil when
+// session.memory.review is disabled.
+func NewQueue(cfg *config.Config) (*Queue, error) {
+	if cfg == nil || !cfg.Session.Memory.Review.Enabled {
+		return nil, nil
+	}
+	reviewCfg := cfg.Session.Memory.Review
+	dir := strings.TrimSpace(reviewCfg.Dir)
+	if dir == "" {
+		dir = DefaultDir()
+	}
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("create memory review dir: %w", err)
+	}
+	autoApprove := make(map[string]bool, len(reviewCfg.AutoApproveScopes))
+	for _, scope := range reviewCfg.AutoApproveScopes {
+		autoApprove[strings.ToLower(strings.TrimSpace(scope))] = true
+	}
+	q := &Queue{dir: dir, autoApprove: autoApprove}
+	if name := strings.TrimSpace(cfg.Workspace.MemoryFile); name != "" {
+		root := strings.TrimSpace(cfg.Workspace.Path)
+		if root == "" {
+			root = "."
+		}
+		path := name
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(root, name)
+		}
+		if abs, err := filepath.Abs(path); err == nil {
+			path = abs
+		}
+		q.memoryFile = filepath.Clean(path)
+	}
+	return q, nil
+}
+
+// DefaultDir returns ~/.nexus/memory-review.
+func DefaultDir() string {
+	home, err := os.UserHomeDir()
+	if err != nil || strings.TrimSpace(home) == "" {
+		home = "."
+	}
+	return filepath.Join(home, ".nexus", "memory-review")
+}
+
+// SetOnQueue registers fn to be called after a proposal is queued.
+func (q *Queue) SetOnQueue(fn func(ctx context.Context, proposal *Proposal)) {
+	if q == nil {
+		return
+	}
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.onQueue = fn
+}
+
+// AutoApproves reports whether writes in scope skip review.
+func (q *Queue) AutoApproves(scope string) bool {
+	return q == nil || q.autoApprove[strings.ToLower(strings.TrimSpace(scope))]
+}
+
+// HoldEntry queues a vector memory entry stored in scope. It returns the
+// proposal ID, or "" when the scope is auto-approved and the entry should
+// be stored directly.
+func (q *Queue) HoldEntry(ctx context.Context, entry *models.MemoryEntry, scope string) (string, error) {
+	if q == nil || entry == nil || q.AutoApproves(scope) {
+		return "", nil
+	}
+	proposal := &Proposal{
+		Kind:    KindVector,
+		Scope:   strings.ToLower(strings.TrimSpace(scope)),
+		Content: entry.Content,
+		Entry:   entry,
+		Tool:    "vector_memory_write",
+	}
+	switch {
+	case entry.SessionID != "":
+		proposal.ScopeID = entry.SessionID
+	case entry.ChannelID != "":
+		proposal.ScopeID = entry.ChannelID
+	case entry.AgentID != "":
+		proposal.ScopeID = entry.AgentID
+	}
+	return q.add(ctx, proposal)
+}
+
+// Hold queues a change of the file at path from before to after when path
+// is the workspace memory file. It returns the proposal ID, or "" when the
+// file may be written directly.
+func (q *Queue) Hold(ctx context.Context, path, before, after string) (string, error) {
+	if q == nil || q.memoryFile == "" || q.AutoApproves(ScopeFile) || before == after {
+		return "", nil
+	}
+	if abs, err := filepath.Abs(path); err == nil {
+		path = abs
+	}
+	if filepath.Clean(path) != q.memoryFile {
+		return "", nil
+	}
+	name := filepath.Base(q.memoryFile)
+	proposal := &Proposal{
+		Kind:  KindFile,
+		Scope: ScopeFile,
+		Path:  q.memoryFile,
+		Diff:  textdiff.Unified("a/"+name, "b/"+name, before, after, textdiff.DefaultContext),
+	}
+	if strings.HasPrefix(after, before) {
+		proposal.Append = true
+		proposal.Content = after[len(before):]
+	} else {
+		proposal.NewContent = after
+		proposal.BaseSHA256 = hashString(before)
+		proposal.Content = addedLines(proposal.Diff)
+	}
+	return q.add(ctx, proposal)
+}
+
+// List returns proposals with status, or all proposals when status is
+// empty, oldest first.
+func (q *Queue) List(status string) ([]*Proposal, error) {
+	if q == nil {
+		return nil, errors.New("memory review is disabled")
+	}
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	entries, err := os.ReadDir(q.dir)
+	if err != nil {
+		return nil, fmt.Errorf("read memory review dir: %w", err)
+	}
+	var proposals []*Proposal
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".json" {
+			continue
+		}
+		proposal, err := q.load(strings.TrimSuffix(entry.Name(), ".json"))
+		if err != nil {
+			continue
+		}
+		if proposal.DecidedAt != nil && time.Since(*proposal.DecidedAt) > decidedRetention {
+			_ = os.Remove(q.path(proposal.ID))
+			continue
+		}
+		if status == "" || proposal.Status == status {
+			proposals = append(proposals, proposal)
+		}
+	}
+	sort.Slice(proposals, func(i, j int) bool { return proposals[i].CreatedAt.Before(proposals[j].CreatedAt) })
+	return proposals, nil
+}
+
+// Get returns a proposal by ID.
+func (q *Queue) Get(id string) (*Proposal, error) {
+	if q == nil {
+		return nil, errors.New("memory review is disabled")
+	}
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.load(id)
+}
+
+// Approve stores a pending proposal and marks it approved. Vector entries
+// are indexed with indexer.
+func (q *Queue) Approve(ctx context.Context, id, decidedBy string, indexer Indexer) (*Proposal, error) {
+	if q == nil {
+		return nil, errors.New("memory review is disabled")
+	}
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	proposal, err := q.pending(id)
+	if err != nil {
+		return nil, err
+	}
+	switch proposal.Kind {
+	case KindVector:
+		if indexer == nil {
+			return nil, errors.New("vector memory is unavailable")
+		}
+		if proposal.Entry == nil {
+			return nil, fmt.Errorf("memory proposal %s has no entry", id)
+		}
+		proposal.Entry.UpdatedAt = time.Now()
+		if err := indexer.Index(ctx, []*models.MemoryEntry{proposal.Entry}); err != nil {
+			return nil, fmt.Errorf("store memory: %w", err)
+		}
+	case KindFile:
+		if err := applyFileChange(proposal); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("memory proposal %s has unknown kind %q", id, proposal.Kind)
+	}
+	return proposal, q.decide(proposal, StatusApproved, decidedBy)
+}
+
+// Reject discards a pending proposal.
+func (q *Queue) Reject(ctx context.Context, id, decidedBy string) (*Proposal, error) {
+	_ = ctx
+	if q == nil {
+		return nil, errors.New("memory review is disabled")
+	}
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	proposal, err := q.pending(id)
+	if err != nil {
+		return nil, err
+	}
+	return proposal, q.decide(proposal, StatusRejected, decidedBy)
+}
+
+func (q *Queue) add(ctx context.Context, proposal *Proposal) (string, error) {
+	if session := agent.SessionFromContext(ctx); session != nil {
+		proposal.AgentID = session.AgentID
+		proposal.SessionID = session.ID
+		proposal.Channel = session.Channel
+		proposal.ChannelID = session.ChannelID
+	}
+	proposal.Status = StatusPending
+	proposal.CreatedAt = time.Now()
+
+	q.mu.Lock()
+	for {
+		proposal.ID = strings.ReplaceAll(uuid.NewString(), "-", "")[:8]
+		if _, err := os.Stat(q.path(proposal.ID)); errors.Is(err, os.ErrNotExist) {
+			break
+		}
+	}
+	err := q.save(proposal)
+	onQueue := q.onQueue
+	q.mu.Unlock()
+	if err != nil {
+		return "", err
+	}
+	if onQueue != nil {
+		onQueue(ctx, proposal)
+	}
+	return proposal.ID, nil
+}
+
+func (q *Queue) pending(id string) (*Proposal, error) {
+	proposal, err := q.load(id)
+	if err != nil {
+		return nil, err
+	}
+	if proposal.Status != StatusPending {
+		return nil, fmt.Errorf("%s: %w (%s)", id, ErrDecided, proposal.Status)
+	}
+	return proposal, nil
+}
+
+func (q *Queue) decide(proposal *Proposal, status, decidedBy string) error {
+	now := time.Now()
+	proposal.Status = status
+	proposal.DecidedAt = &now
+	proposal.DecidedBy = decidedBy
+	return q.save(proposal)
+}
+
+func (q *Queue) path(id string) string {
+	return filepath.Join(q.dir, id+".json")
+}
+
+func (q *Queue) load(id string) (*Proposal, error) {
+	id = strings.TrimSpace(id)
+	if id == "" || strings.ContainsAny(id, `/\.`) {
+		return nil, fmt.Errorf("%q: %w", id, ErrNotFound)
+	}
+	data, err := os.ReadFile(q.path(id))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("%s: %w", id, ErrNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read memory proposal %s: %w", id, err)
+	}
+	var proposal Proposal
+	if err := json.Unmarshal(data, &proposal); err != nil {
+		return nil, fmt.Errorf("decode memory proposal %s: %w", id, err)
+	}
+	return &proposal, nil
+}
+
+func (q *Queue) save(proposal *Proposal) error {
+	data, err := json.MarshalIndent(proposal, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode memory proposal: %w", err)
+	}
+	path := q.path(proposal.ID)
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return fmt.Errorf("write memory proposal: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return fmt.Errorf("write memory proposal: %w", err)
+	}
+	return nil
+}
+
+// applyFileChange writes an approved change to the memory file.
+func applyFileChange(proposal *Proposal) error {
+	if err := os.MkdirAll(filepath.Dir(proposal.Path), 0o755); err != nil {
+		return fmt.Errorf("create directory: %w", err)
+	}
+	if proposal.Append {
+		file, err := os.OpenFile(proposal.Path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+		if err != nil {
+			return fmt.Errorf("open memory file: %w", err)
+		}
+		_, err = file.WriteString(proposal.Content)
+		if closeErr := file.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			return fmt.Errorf("write memory file: %w", err)
+		}
+		return nil
+	}
+	current, err := os.ReadFile(proposal.Path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("read memory file: %w", err)
+	}
+	if hashString(string(current)) != proposal.BaseSHA256 {
+		return fmt.Errorf("%s: %w", proposal.ID, ErrStale)
+	}
+	if err := os.WriteFile(proposal.Path, []byte(proposal.NewContent), 0o644); err != nil {
+		return fmt.Errorf("write memory file: %w", err)
+	}
+	return nil
+}
+
+func hashString(s string) string {
+	sum := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(sum[:])
+}
+
+// addedLines returns the lines a unified diff adds.
+func addedLines(diff string) string {
+	var added []string
+	for _, line := range strings.Split(diff, "\n") {
+		if strings.HasPrefix(line, "+") && !strings.HasPrefix(line, "+++") {
+			added = append(added, line[1:])
+		}
+	}
+	return strings.Join(added, "\n")
+}
+
+// Summary returns what the proposal would remember on one line, cut to
+// max runes.
+func (p *Proposal) Summary(max int) string {
+	text := strings.Join(strings.Fields(p.Content), " ")
+	if text == "" && p.Kind == KindFile {
+		text = "(removes text from " + filepath.Base(p.Path) + ")"
+	}
+	if runes := []rune(text); max > 3 && len(runes) > max {
+		text = string(runes[:max-3]) + "..."
+	}
+	return text
+}
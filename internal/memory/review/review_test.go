@@ -0,0 +1,153 @@
+package review
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/haasonsaas/nexus/internal/agent"
+	"github.com/haasonsaas/nexus/internal/config"
+	"github.com/haasonsaas/nexus/pkg/models"
+)
+
+type fakeIndexer struct {
+	entries []*models.MemoryEntry
+}
+
+func (f *fakeIndexer) Index(_ context.Context, entries []*models.MemoryEntry) error {
+	f.entries = append(f.entries, entries...)
+	return nil
+}
+
+func newTestQueue(t *testing.T, autoApprove ...string) (*Queue, string) {
+	t.Helper()
+	root := t.TempDir()
+	cfg := &config.Config{Workspace: config.DefaultWorkspaceConfig()}
+	cfg.Workspace.Path = root
+	cfg.Session.Memory.Review = config.MemoryReviewConfig{
+		Enabled:           true,
+		Dir:               filepath.Join(root, "review"),
+		AutoApproveScopes: autoApprove,
+	}
+	queue, err := NewQueue(cfg)
+	if err != nil {
+		t.Fatalf("NewQueue() error = %v", err)
+	}
+	return queue, root
+}
+
+func TestNewQueueDisabled(t *testing.T) {
+	queue, err := NewQueue(&config.Config{})
+	if err != nil || queue != nil {
+		t.Fatalf("NewQueue() = %v, %v; want nil, nil", queue, err)
+	}
+	if !queue.AutoApproves("global") {
+		t.Error("a nil queue should approve everything")
+	}
+}
+
+func TestHoldEntryAndApprove(t *testing.T) {
+	queue, _ := newTestQueue(t, "session")
+	ctx := agent.WithSession(context.Background(), &models.Session{
+		ID: "sess-1", AgentID: "main", Channel: models.ChannelTelegram, ChannelID: "42",
+	})
+
+	var queued []*Proposal
+	queue.SetOnQueue(func(ctx context.Context, proposal *Proposal) {
+		queued = append(queued, proposal)
+	})
+
+	id, err := queue.HoldEntry(ctx, &models.MemoryEntry{ID: "e1", Content: "temp note", SessionID: "sess-1"}, "session")
+	if err != nil || id != "" {
+		t.Fatalf("HoldEntry(session) = %q, %v; want auto-approved", id, err)
+	}
+	id, err = queue.HoldEntry(ctx, &models.MemoryEntry{ID: "e2", Content: "prefers tea", AgentID: "main"}, "agent")
+	if err != nil || id == "" {
+		t.Fatalf("HoldEntry(agent) = %q, %v; want queued", id, err)
+	}
+	if len(queued) != 1 || queued[0].ID != id || queued[0].ChannelID != "42" || queued[0].ScopeID != "main" {
+		t.Fatalf("unexpected queued proposals %+v", queued)
+	}
+
+	pending, err := queue.List(StatusPending)
+	if err != nil || len(pending) != 1 {
+		t.Fatalf("List() = %v, %v", pending, err)
+	}
+
+	indexer := &fakeIndexer{}
+	if _, err := queue.Approve(context.Background(), id, "cli", indexer); err != nil {
+		t.Fatalf("Approve() error = %v", err)
+	}
+	if len(indexer.entries) != 1 || indexer.entries[0].Content != "prefers tea" {
+		t.Fatalf("unexpected indexed entries %+v", indexer.entries)
+	}
+	if _, err := queue.Reject(context.Background(), id, "cli"); !errors.Is(err, ErrDecided) {
+		t.Fatalf("Reject() after approve error = %v, want ErrDecided", err)
+	}
+	proposal, err := queue.Get(id)
+	if err != nil || proposal.Status != StatusApproved || proposal.DecidedBy != "cli" {
+		t.Fatalf("Get() = %+v, %v", proposal, err)
+	}
+	if _, err := queue.Get("../etc"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("Get(../etc) error = %v, want ErrNotFound", err)
+	}
+}
+
+func TestHoldMemoryFile(t *testing.T) {
+	queue, root := newTestQueue(t)
+	ctx := context.Background()
+	memoryFile := filepath.Join(root, "MEMORY.md")
+	if err := os.WriteFile(memoryFile, []byte("# Memory\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	// Other files are written directly.
+	if id, err := queue.Hold(ctx, filepath.Join(root, "notes.md"), "", "x"); err != nil || id != "" {
+		t.Fatalf("Hold(notes.md) = %q, %v; want not held", id, err)
+	}
+
+	appendID, err := queue.Hold(ctx, memoryFile, "# Memory\n", "# Memory\n- likes go\n")
+	if err != nil || appendID == "" {
+		t.Fatalf("Hold(append) = %q, %v", appendID, err)
+	}
+	replaceID, err := queue.Hold(ctx, memoryFile, "# Memory\n", "# Notes\n")
+	if err != nil || replaceID == "" {
+		t.Fatalf("Hold(replace) = %q, %v", replaceID, err)
+	}
+	proposal, _ := queue.Get(appendID)
+	if !proposal.Append || proposal.Content != "- likes go\n" || proposal.Diff == "" {
+		t.Fatalf("unexpected append proposal %+v", proposal)
+	}
+
+	// The append applies to whatever the file holds; the replacement was
+	// made from the old content and is refused.
+	if _, err := queue.Approve(ctx, appendID, "cli", nil); err != nil {
+		t.Fatalf("Approve(append) error = %v", err)
+	}
+	data, _ := os.ReadFile(memoryFile)
+	if string(data) != "# Memory\n- likes go\n" {
+		t.Fatalf("memory file = %q", data)
+	}
+	if _, err := queue.Approve(ctx, replaceID, "cli", nil); !errors.Is(err, ErrStale) {
+		t.Fatalf("Approve(stale replace) error = %v, want ErrStale", err)
+	}
+	if _, err := queue.Reject(ctx, replaceID, "cli"); err != nil {
+		t.Fatalf("Reject() error = %v", err)
+	}
+}
+
+func TestProposalSummary(t *testing.T) {
+	p := &Proposal{Content: "  likes\n  green   tea  "}
+	if got := p.Summary(100); got != "likes green tea" {
+		t.Errorf("Summary() = %q", got)
+	}
+	if got := p.Summary(8); got != "likes..." {
+		t.Errorf("Summary(8) = %q", got)
+	}
+	p = &Proposal{Kind: KindFile, Path: "/ws/MEMORY.md"}
+	if got := p.Summary(100); got != "(removes text from MEMORY.md)" {
+		t.Errorf("Summary() = %q", got)
+	}
+}
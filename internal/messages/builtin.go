@@ -0,0 +1,117 @@
+package messages
+
+// builtin holds the shipped variants of every message. English is required
+// for each key since it is the last fallback.
+var builtin = map[Key]map[string]string{
+	PairingRequest: {
+		"en": "{{if .Sender}}Pairing request from {{.Sender}}{{else}}Pairing request{{end}} for {{.Provider}} ({{if .Pending}}already pending{{else}}received{{end}}).\n" +
+			"Code: {{.Code}} (expires in {{.ExpiresIn}}).\n" +
+			"Approve: nexus pairing approve {{.Code}} --provider {{.Provider}}\n" +
+			"Deny: nexus pairing deny {{.Code}} --provider {{.Provider}}",
+		"es": "{{if .Sender}}Solicitud de vinculación de {{.Sender}}{{else}}Solicitud de vinculación{{end}} para {{.Provider}} ({{if .Pending}}ya pendiente{{else}}recibida{{end}}).\n" +
+			"Código: {{.Code}} (caduca en {{.ExpiresIn}}).\n" +
+			"Aprobar: nexus pairing approve {{.Code}} --provider {{.Provider}}\n" +
+			"Rechazar: nexus pairing deny {{.Code}} --provider {{.Provider}}",
+		"de": "{{if .Sender}}Kopplungsanfrage von {{.Sender}}{{else}}Kopplungsanfrage{{end}} für {{.Provider}} ({{if .Pending}}bereits ausstehend{{else}}erhalten{{end}}).\n" +
+			"Code: {{.Code}} (läuft ab in {{.ExpiresIn}}).\n" +
+			"Genehmigen: nexus pairing approve {{.Code}} --provider {{.Provider}}\n" +
+			"Ablehnen: nexus pairing deny {{.Code}} --provider {{.Provider}}",
+		"fr": "{{if .Sender}}Demande d'appairage de {{.Sender}}{{else}}Demande d'appairage{{end}} pour {{.Provider}} ({{if .Pending}}déjà en attente{{else}}reçue{{end}}).\n" +
+			"Code : {{.Code}} (expire dans {{.ExpiresIn}}).\n" +
+			"Approuver : nexus pairing approve {{.Code}} --provider {{.Provider}}\n" +
+			"Refuser : nexus pairing deny {{.Code}} --provider {{.Provider}}",
+		"pt": "{{if .Sender}}Pedido de pareamento de {{.Sender}}{{else}}Pedido de pareamento{{end}} para {{.Provider}} ({{if .Pending}}já pendente{{else}}recebido{{end}}).\n" +
+			"Código: {{.Code}} (expira em {{.ExpiresIn}}).\n" +
+			"Aprovar: nexus pairing approve {{.Code}} --provider {{.Provider}}\n" +
+			"Recusar: nexus pairing deny {{.Code}} --provider {{.Provider}}",
+	},
+	ApprovalRequired: {
+		"en": "I need approval before I can run {{.Tool}}{{if .Reason}} ({{.Reason}}){{end}}.",
+		"es": "Necesito aprobación antes de ejecutar {{.Tool}}{{if .Reason}} ({{.Reason}}){{end}}.",
+		"de": "Ich brauche eine Genehmigung, bevor ich {{.Tool}} ausführen kann{{if .Reason}} ({{.Reason}}){{end}}.",
+		"fr": "J'ai besoin d'une approbation avant d'exécuter {{.Tool}}{{if .Reason}} ({{.Reason}}){{end}}.",
+		"pt": "Preciso de aprovação antes de executar {{.Tool}}{{if .Reason}} ({{.Reason}}){{end}}.",
+	},
+	DraftPreview: {
+		"en": "Draft {{.ID}} for {{.Tool}}, not sent yet (expires in {{.ExpiresIn}}):\n\n{{.Preview}}\n\n" +
+			"Send: /draft approve {{.ID}}\nEdit: /draft edit {{.ID}} <new text>\nCancel: /draft cancel {{.ID}}",
+		"es": "Borrador {{.ID}} para {{.Tool}}, aún sin enviar (caduca en {{.ExpiresIn}}):\n\n{{.Preview}}\n\n" +
+			"Enviar: /draft approve {{.ID}}\nEditar: /draft edit {{.ID}} <nuevo texto>\nCancelar: /draft cancel {{.ID}}",
+		"de": "Entwurf {{.ID}} für {{.Tool}}, noch nicht gesendet (läuft ab in {{.ExpiresIn}}):\n\n{{.Preview}}\n\n" +
+			"Senden: /draft approve {{.ID}}\nBearbeiten: /draft edit {{.ID}} <neuer Text>\nAbbrechen: /draft cancel {{.ID}}",
+		"fr": "Brouillon {{.ID}} pour {{.Tool}}, pas encore envoyé (expire dans {{.ExpiresIn}}) :\n\n{{.Preview}}\n\n" +
+			"Envoyer : /draft approve {{.ID}}\nModifier : /draft edit {{.ID}} <nouveau texte>\nAnnuler : /draft cancel {{.ID}}",
+		"pt": "Rascunho {{.ID}} para {{.Tool}}, ainda não enviado (expira em {{.ExpiresIn}}):\n\n{{.Preview}}\n\n" +
+			"Enviar: /draft approve {{.ID}}\nEditar: /draft edit {{.ID}} <novo texto>\nCancelar: /draft cancel {{.ID}}",
+	},
+	DraftExpired: {
+		"en": "Draft {{.ID}} for {{.Tool}} expired after {{.TTL}} without approval and was not sent.",
+		"es": "El borrador {{.ID}} para {{.Tool}} caducó tras {{.TTL}} sin aprobación y no se envió.",
+		"de": "Entwurf {{.ID}} für {{.Tool}} ist nach {{.TTL}} ohne Genehmigung abgelaufen und wurde nicht gesendet.",
+		"fr": "Le brouillon {{.ID}} pour {{.Tool}} a expiré après {{.TTL}} sans approbation et n'a pas été envoyé.",
+		"pt": "O rascunho {{.ID}} para {{.Tool}} expirou após {{.TTL}} sem aprovação e não foi enviado.",
+	},
+	CommandFailed: {
+		"en": "Command failed: {{.Error}}",
+		"es": "El comando falló: {{.Error}}",
+		"de": "Befehl fehlgeschlagen: {{.Error}}",
+		"fr": "La commande a échoué : {{.Error}}",
+		"pt": "O comando falhou: {{.Error}}",
+	},
+	RunFailed: {
+		"en": "Sorry, something went wrong while working on your message. Please try again.",
+		"es": "Lo siento, algo salió mal al procesar tu mensaje. Inténtalo de nuevo.",
+		"de": "Entschuldigung, bei der Bearbeitung deiner Nachricht ist etwas schiefgelaufen. Bitte versuche es erneut.",
+		"fr": "Désolé, une erreur s'est produite lors du traitement de votre message. Veuillez réessayer.",
+		"pt": "Desculpe, algo deu errado ao processar sua mensagem. Tente novamente.",
+	},
+	RunResuming: {
+		"en": "I was restarted, resuming…",
+		"es": "Me reiniciaron, continúo…",
+		"de": "Ich wurde neu gestartet und mache weiter…",
+		"fr": "J'ai été redémarré, je reprends…",
+		"pt": "Fui reiniciado, retomando…",
+	},
+	RunInterrupted: {
+		"en": "I was restarted and couldn't finish working on your last message. Please send it again if you still need it.",
+		"es": "Me reiniciaron y no pude terminar con tu último mensaje. Vuelve a enviarlo si aún lo necesitas.",
+		"de": "Ich wurde neu gestartet und konnte deine letzte Nachricht nicht fertig bearbeiten. Bitte sende sie erneut, falls du sie noch brauchst.",
+		"fr": "J'ai été redémarré et n'ai pas pu terminer votre dernier message. Renvoyez-le si vous en avez encore besoin.",
+		"pt": "Fui reiniciado e não consegui terminar sua última mensagem. Envie-a novamente se ainda precisar.",
+	},
+	RunLoopStopped: {
+		"en": "I stopped because I kept repeating the same step ({{.Tool}}) without making progress. Try rephrasing your request or splitting it into smaller steps.",
+		"es": "Me detuve porque repetía el mismo paso ({{.Tool}}) sin avanzar. Prueba a reformular tu petición o dividirla en pasos más pequeños.",
+		"de": "Ich habe aufgehört, weil ich denselben Schritt ({{.Tool}}) wiederholt habe, ohne voranzukommen. Formuliere deine Anfrage um oder teile sie in kleinere Schritte auf.",
+		"fr": "Je me suis arrêté car je répétais la même étape ({{.Tool}}) sans progresser. Reformulez votre demande ou découpez-la en étapes plus petites.",
+		"pt": "Parei porque estava repetindo o mesmo passo ({{.Tool}}) sem progredir. Tente reformular seu pedido ou dividi-lo em passos menores.",
+	},
+	CredentialAlert: {
+		"en": "Nexus credential monitor:\n{{.Changes}}",
+		"es": "Monitor de credenciales de Nexus:\n{{.Changes}}",
+		"de": "Nexus-Zugangsdatenmonitor:\n{{.Changes}}",
+		"fr": "Surveillance des identifiants Nexus :\n{{.Changes}}",
+		"pt": "Monitor de credenciais do Nexus:\n{{.Changes}}",
+	},
+	CredentialsRejected: {
+		"en": "- {{.Name}} {{.Kind}} credentials were rejected: {{.Message}}",
+		"es": "- Las credenciales de {{.Name}} {{.Kind}} fueron rechazadas: {{.Message}}",
+		"de": "- Zugangsdaten für {{.Name}} {{.Kind}} wurden abgelehnt: {{.Message}}",
+		"fr": "- Les identifiants {{.Name}} {{.Kind}} ont été refusés : {{.Message}}",
+		"pt": "- As credenciais de {{.Name}} {{.Kind}} foram rejeitadas: {{.Message}}",
+	},
+	QuotaLow: {
+		"en": "- {{.Name}} {{.Kind}} is running low on quota: {{.Message}}",
+		"es": "- A {{.Name}} {{.Kind}} le queda poca cuota: {{.Message}}",
+		"de": "- Das Kontingent von {{.Name}} {{.Kind}} wird knapp: {{.Message}}",
+		"fr": "- Le quota de {{.Name}} {{.Kind}} est presque épuisé : {{.Message}}",
+		"pt": "- A cota de {{.Name}} {{.Kind}} está acabando: {{.Message}}",
+	},
+	CredentialsHealthy: {
+		"en": "- {{.Name}} {{.Kind}} credentials are healthy again",
+		"es": "- Las credenciales de {{.Name}} {{.Kind}} vuelven a funcionar",
+		"de": "- Zugangsdaten für {{.Name}} {{.Kind}} funktionieren wieder",
+		"fr": "- Les identifiants {{.Name}} {{.Kind}} fonctionnent à nouveau",
+		"pt": "- As credenciais de {{.Name}} {{.Kind}} voltaram a funcionar",
+	},
+}
@@ -0,0 +1,199 @@
+// Package messages holds the text Nexus sends on its own behalf, such as
+// pairing prompts, approval notices, errors and quota warnings, with a
+// variant per language. Deployments can override any variant in config to
+// brand or translate it without code changes. It also detects the language
+// of inbound text so replies can follow the user's language.
+package messages
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"text/template"
+)
+
+// Key identifies a system message.
+type Key string
+
+const (
+	PairingRequest      Key = "pairing.request"
+	ApprovalRequired    Key = "approval.required"
+	DraftPreview        Key = "draft.preview"
+	DraftExpired        Key = "draft.expired"
+	CommandFailed       Key = "error.command_failed"
+	RunFailed           Key = "error.run_failed"
+	RunResuming         Key = "run.resuming"
+	RunInterrupted      Key = "run.interrupted"
+	RunLoopStopped      Key = "run.loop_stopped"
+	CredentialAlert     Key = "credentials.alert"
+	CredentialsRejected Key = "credentials.rejected"
+	QuotaLow            Key = "credentials.quota_low"
+	CredentialsHealthy  Key = "credentials.healthy"
+)
+
+// DefaultLocale is used when neither the user, the channel nor the config
+// names a language that has a variant.
+const DefaultLocale = "en"
+
+// Data holds the values a message template can reference, e.g. {{.Code}}.
+// Missing values render as the empty string.
+type Data map[string]string
+
+// Catalog renders system messages in the best available language.
+type Catalog struct {
+	locale    string
+	templates map[Key]map[string]*template.Template
+}
+
+var defaultCatalog = mustNew()
+
+func mustNew() *Catalog {
+	c, err := New("", nil)
+	if err != nil {
+		panic(err)
+	}
+	return c
+}
+
+// Default returns the catalog of built-in messages.
+func Default() *Catalog {
+	return defaultCatalog
+}
+
+// New builds a catalog from the built-in messages and the given overrides,
+// keyed by message key and then by locale. locale is the fallback language
+// for recipients whose language has no variant.
+func New(locale string, overrides map[string]map[string]string) (*Catalog, error) {
+	c := &Catalog{
+		locale:    NormalizeLocale(locale),
+		templates: make(map[Key]map[string]*template.Template, len(builtin)),
+	}
+	if c.locale == "" {
+		c.locale = DefaultLocale
+	}
+	for key, variants := range builtin {
+		for loc, text := range variants {
+			if err := c.add(key, loc, text); err != nil {
+				return nil, err
+			}
+		}
+	}
+	for rawKey, variants := range overrides {
+		key := Key(rawKey)
+		if _, ok := builtin[key]; !ok {
+			return nil, fmt.Errorf("unknown message %q", rawKey)
+		}
+		for rawLocale, text := range variants {
+			loc := NormalizeLocale(rawLocale)
+			if loc == "" {
+				return nil, fmt.Errorf("message %q: invalid locale %q", rawKey, rawLocale)
+			}
+			if err := c.add(key, loc, text); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return c, nil
+}
+
+func (c *Catalog) add(key Key, locale, text string) error {
+	tmpl, err := template.New(string(key) + "." + locale).Option("missingkey=zero").Parse(text)
+	if err != nil {
+		return fmt.Errorf("message %q (%s): %w", key, locale, err)
+	}
+	if c.templates[key] == nil {
+		c.templates[key] = make(map[string]*template.Template)
+	}
+	c.templates[key][locale] = tmpl
+	return nil
+}
+
+// Render renders key in locale, falling back to the base language (pt-br to
+// pt), then the catalog's locale, then English. A nil catalog renders the
+// built-in messages.
+func (c *Catalog) Render(key Key, locale string, data Data) string {
+	if c == nil {
+		c = defaultCatalog
+	}
+	variants := c.templates[key]
+	for _, candidate := range c.candidates(locale) {
+		tmpl, ok := variants[candidate]
+		if !ok {
+			continue
+		}
+		var out strings.Builder
+		if err := tmpl.Execute(&out, data); err != nil {
+			continue
+		}
+		return out.String()
+	}
+	return ""
+}
+
+// Locales returns the locales key has a variant in, sorted.
+func (c *Catalog) Locales(key Key) []string {
+	if c == nil {
+		c = defaultCatalog
+	}
+	out := make([]string, 0, len(c.templates[key]))
+	for loc := range c.templates[key] {
+		out = append(out, loc)
+	}
+	sort.Strings(out)
+	return out
+}
+
+func (c *Catalog) candidates(locale string) []string {
+	var out []string
+	add := func(loc string) {
+		if loc == "" {
+			return
+		}
+		for _, existing := range out {
+			if existing == loc {
+				return
+			}
+		}
+		out = append(out, loc)
+	}
+	for _, loc := range []string{NormalizeLocale(locale), c.locale} {
+		add(loc)
+		if base, _, found := strings.Cut(loc, "-"); found {
+			add(base)
+		}
+	}
+	add(DefaultLocale)
+	return out
+}
+
+// Keys returns every known message key, sorted.
+func Keys() []Key {
+	out := make([]Key, 0, len(builtin))
+	for key := range builtin {
+		out = append(out, key)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i] < out[j] })
+	return out
+}
+
+// NormalizeLocale lowercases a language tag and uses hyphens as separators,
+// so "pt_BR" and "pt-BR" both become "pt-br". It returns "" for values that
+// are not language tags.
+func NormalizeLocale(locale string) string {
+	locale = strings.ToLower(strings.TrimSpace(locale))
+	locale = strings.ReplaceAll(locale, "_", "-")
+	if locale == "" {
+		return ""
+	}
+	for _, part := range strings.Split(locale, "-") {
+		if part == "" || len(part) > 8 {
+			return ""
+		}
+		for _, r := range part {
+			if (r < 'a' || r > 'z') && (r < '0' || r > '9') {
+				return ""
+			}
+		}
+	}
+	return locale
+}
@@ -0,0 +1,64 @@
+package messages
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestBuiltinHasEnglishForEveryKey(t *testing.T) {
+	for _, key := range Keys() {
+		if _, ok := builtin[key][DefaultLocale]; !ok {
+			t.Errorf("message %q has no %s variant", key, DefaultLocale)
+		}
+	}
+}
+
+func TestRenderLocaleFallback(t *testing.T) {
+	catalog, err := New("de", map[string]map[string]string{
+		string(CommandFailed): {"pt_BR": "Falha no comando: {{.Error}}"},
+	})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	data := Data{"Error": "boom"}
+	tests := []struct {
+		locale string
+		want   string
+	}{
+		{"pt-BR", "Falha no comando: boom"},
+		{"pt-PT", "O comando falhou: boom"},
+		{"es", "El comando falló: boom"},
+		{"ja", "Befehl fehlgeschlagen: boom"},
+		{"", "Befehl fehlgeschlagen: boom"},
+	}
+	for _, tt := range tests {
+		if got := catalog.Render(CommandFailed, tt.locale, data); got != tt.want {
+			t.Errorf("Render(%q) = %q, want %q", tt.locale, got, tt.want)
+		}
+	}
+}
+
+func TestRenderMissingValues(t *testing.T) {
+	var catalog *Catalog
+	got := catalog.Render(ApprovalRequired, "en", Data{"Tool": "exec"})
+	if got != "I need approval before I can run exec." {
+		t.Fatalf("unexpected render %q", got)
+	}
+	got = catalog.Render(PairingRequest, "en", Data{"Provider": "telegram", "Code": "ABC", "ExpiresIn": "1h0m0s"})
+	if !strings.HasPrefix(got, "Pairing request for telegram (received).") {
+		t.Fatalf("unexpected render %q", got)
+	}
+}
+
+func TestNewRejectsInvalidOverrides(t *testing.T) {
+	tests := map[string]map[string]map[string]string{
+		"unknown key":    {"nope": {"en": "hi"}},
+		"invalid locale": {string(RunFailed): {"en us": "hi"}},
+		"bad template":   {string(RunFailed): {"en": "{{.Error"}},
+	}
+	for name, overrides := range tests {
+		if _, err := New("", overrides); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
@@ -0,0 +1,166 @@
+package messages
+
+import (
+	"strings"
+	"unicode"
+)
+
+// languageNames maps the languages DetectLanguage can report, and other
+// common tags, to their English names for use in prompts.
+var languageNames = map[string]string{
+	"ar": "Arabic",
+	"de": "German",
+	"el": "Greek",
+	"en": "English",
+	"es": "Spanish",
+	"fr": "French",
+	"he": "Hebrew",
+	"hi": "Hindi",
+	"it": "Italian",
+	"ja": "Japanese",
+	"ko": "Korean",
+	"nl": "Dutch",
+	"pl": "Polish",
+	"pt": "Portuguese",
+	"ru": "Russian",
+	"sv": "Swedish",
+	"th": "Thai",
+	"tr": "Turkish",
+	"uk": "Ukrainian",
+	"zh": "Chinese",
+}
+
+// LanguageName returns the English name of a language tag, keeping any
+// region ("pt-br" is "Portuguese (BR)"), or "" for unknown languages.
+func LanguageName(locale string) string {
+	locale = NormalizeLocale(locale)
+	base, region, _ := strings.Cut(locale, "-")
+	name, ok := languageNames[base]
+	if !ok {
+		return ""
+	}
+	if region != "" {
+		return name + " (" + strings.ToUpper(region) + ")"
+	}
+	return name
+}
+
+// ParseLanguage accepts a language tag ("es", "pt-BR") or the English name
+// of a known language ("Spanish") and returns the normalized tag, or "" if
+// value is neither.
+func ParseLanguage(value string) string {
+	value = strings.TrimSpace(value)
+	for tag, name := range languageNames {
+		if strings.EqualFold(value, name) {
+			return tag
+		}
+	}
+	return NormalizeLocale(value)
+}
+
+// stopwords are frequent function words that rarely appear in other
+// languages written in Latin script.
+var stopwords = map[string][]string{
+	"en": {"the", "and", "is", "are", "you", "what", "this", "that", "with", "have", "for", "not", "can", "please", "how", "was", "it's", "i'm", "do", "my"},
+	"es": {"el", "la", "los", "las", "que", "de", "y", "es", "en", "por", "para", "con", "una", "un", "qué", "cómo", "pero", "está", "muy", "gracias"},
+	"de": {"der", "die", "das", "und", "ist", "nicht", "ich", "du", "sie", "mit", "auf", "ein", "eine", "wie", "was", "bitte", "danke", "auch", "für", "kannst"},
+	"fr": {"le", "la", "les", "et", "est", "je", "tu", "vous", "pas", "une", "des", "avec", "pour", "que", "qui", "merci", "c'est", "dans", "sur", "mais"},
+	"pt": {"o", "os", "as", "e", "é", "não", "que", "de", "um", "uma", "com", "para", "você", "obrigado", "obrigada", "está", "como", "mas", "isso", "muito"},
+	"it": {"il", "lo", "gli", "e", "è", "che", "di", "non", "un", "una", "con", "per", "sono", "come", "grazie", "questo", "ma", "perché", "anche", "della"},
+	"nl": {"de", "het", "een", "en", "is", "niet", "ik", "je", "jij", "met", "voor", "wat", "dat", "van", "ook", "maar", "dank", "graag", "kun", "zijn"},
+}
+
+var stopwordIndex = func() map[string][]string {
+	index := make(map[string][]string)
+	for lang, words := range stopwords {
+		for _, word := range words {
+			index[word] = append(index[word], lang)
+		}
+	}
+	return index
+}()
+
+// DetectLanguage guesses the language of text and returns its tag, or ""
+// when the text is too short or ambiguous to tell. Non-Latin scripts are
+// identified by script; Latin-script languages by their common words.
+func DetectLanguage(text string) string {
+	if lang := detectScript(text); lang != "" {
+		return lang
+	}
+	scores := make(map[string]int)
+	hits := 0
+	for _, word := range strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && r != '\''
+	}) {
+		langs := stopwordIndex[word]
+		if len(langs) > 0 {
+			hits++
+		}
+		for _, lang := range langs {
+			scores[lang]++
+		}
+	}
+	if hits < 2 {
+		return ""
+	}
+	best, bestScore, runnerUp := "", 0, 0
+	for lang, score := range scores {
+		switch {
+		case score > bestScore:
+			best, bestScore, runnerUp = lang, score, bestScore
+		case score > runnerUp:
+			runnerUp = score
+		}
+	}
+	if bestScore == runnerUp {
+		return ""
+	}
+	return best
+}
+
+// detectScript reports the language of text written mostly in a script used
+// by a single language (or, for Han and Cyrillic, mostly one).
+func detectScript(text string) string {
+	counts := make(map[string]int)
+	letters := 0
+	for _, r := range text {
+		if !unicode.IsLetter(r) {
+			continue
+		}
+		letters++
+		switch {
+		case unicode.In(r, unicode.Hiragana, unicode.Katakana):
+			counts["ja"]++
+		case unicode.Is(unicode.Hangul, r):
+			counts["ko"]++
+		case unicode.Is(unicode.Han, r):
+			counts["zh"]++
+		case unicode.Is(unicode.Cyrillic, r):
+			counts["ru"]++
+		case unicode.Is(unicode.Arabic, r):
+			counts["ar"]++
+		case unicode.Is(unicode.Hebrew, r):
+			counts["he"]++
+		case unicode.Is(unicode.Greek, r):
+			counts["el"]++
+		case unicode.Is(unicode.Devanagari, r):
+			counts["hi"]++
+		case unicode.Is(unicode.Thai, r):
+			counts["th"]++
+		}
+	}
+	if letters == 0 {
+		return ""
+	}
+	// Japanese mixes kana with Han characters.
+	if counts["ja"] > 0 {
+		counts["ja"] += counts["zh"]
+		counts["zh"] = 0
+	}
+	for lang, count := range counts {
+		if count*2 > letters {
+			return lang
+		}
+	}
+	return ""
+}
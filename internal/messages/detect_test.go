@@ -0,0 +1,42 @@
+package messages
+
+import "testing"
+
+func TestDetectLanguage(t *testing.T) {
+	tests := []struct {
+		text string
+		want string
+	}{
+		{"Can you please check what the weather is like this weekend?", "en"},
+		{"¿Puedes revisar el pronóstico para el fin de semana, por favor?", "es"},
+		{"Kannst du bitte das Wetter für das Wochenende prüfen?", "de"},
+		{"Est-ce que tu peux vérifier la météo pour le week-end ? Merci", "fr"},
+		{"Você pode ver a previsão do tempo para o fim de semana? Obrigado", "pt"},
+		{"週末の天気を調べてもらえますか", "ja"},
+		{"你能查一下周末的天气吗", "zh"},
+		{"Можешь проверить погоду на выходные?", "ru"},
+		{"ok", ""},
+		{"👍", ""},
+	}
+	for _, tt := range tests {
+		if got := DetectLanguage(tt.text); got != tt.want {
+			t.Errorf("DetectLanguage(%q) = %q, want %q", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestParseLanguage(t *testing.T) {
+	for value, want := range map[string]string{"es": "es", "pt_BR": "pt-br", "german": "de", "not a language": ""} {
+		if got := ParseLanguage(value); got != want {
+			t.Errorf("ParseLanguage(%q) = %q, want %q", value, got, want)
+		}
+	}
+}
+
+func TestLanguageName(t *testing.T) {
+	for locale, want := range map[string]string{"es": "Spanish", "pt_BR": "Portuguese (BR)", "xx": ""} {
+		if got := LanguageName(locale); got != want {
+			t.Errorf("LanguageName(%q) = %q, want %q", locale, got, want)
+		}
+	}
+}
@@ -2,6 +2,9 @@
 package models
 
 import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
 	"sort"
 	"strings"
 	"sync"
@@ -95,6 +98,13 @@ type Model struct {
 
 	// OutputPrice is the price per million output tokens (USD)
 	OutputPrice float64 `json:"output_price,omitempty"`
+
+	// CachedInputPrice is the price per million cached input tokens (USD)
+	CachedInputPrice float64 `json:"cached_input_price,omitempty"`
+
+	// Discovered is set for models reported by a provider rather than
+	// bundled with the catalog
+	Discovered bool `json:"discovered,omitempty"`
 }
 
 // HasCapability checks if the model has a specific capability.
@@ -173,6 +183,78 @@ func (c *Catalog) Get(id string) (*Model, bool) {
 	return nil, false
 }
 
+// Lookup resolves a model ID as providers report it. Besides IDs and
+// aliases it accepts router-style "vendor/model" IDs and dated or suffixed
+// variants, which resolve to the model with the longest matching ID or
+// alias prefix (e.g. "gpt-4-turbo-preview" to gpt-4-turbo).
+func (c *Catalog) Lookup(id string) (*Model, bool) {
+	id = strings.TrimSpace(id)
+	if id == "" {
+		return nil, false
+	}
+	if model, ok := c.Get(id); ok {
+		return model, true
+	}
+	if _, rest, ok := strings.Cut(id, "/"); ok && rest != "" {
+		if model, ok := c.Get(rest); ok {
+			return model, true
+		}
+		id = rest
+	}
+
+	lower := strings.ToLower(id)
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	best, bestLen := "", 0
+	for modelID := range c.models {
+		if n := len(modelID); n > bestLen && hasVariantPrefix(lower, strings.ToLower(modelID)) {
+			best, bestLen = modelID, n
+		}
+	}
+	for alias, modelID := range c.aliases {
+		if n := len(alias); n > bestLen && hasVariantPrefix(lower, alias) {
+			best, bestLen = modelID, n
+		}
+	}
+	if best == "" {
+		return nil, false
+	}
+	return c.models[best], true
+}
+
+// hasVariantPrefix reports whether id is a variant of prefix: prefix
+// followed by a "-", ":" or "@" suffix, so "gpt-4-0613" matches gpt-4 but
+// "gpt-4o" does not.
+func hasVariantPrefix(id, prefix string) bool {
+	if len(id) <= len(prefix) || !strings.HasPrefix(id, prefix) {
+		return false
+	}
+	switch id[len(prefix)] {
+	case '-', ':', '@':
+		return true
+	}
+	return false
+}
+
+// AddDiscovered registers models reported by a provider that the catalog
+// does not already know, so bundled data always takes precedence. It
+// returns how many models were added.
+func (c *Catalog) AddDiscovered(models ...*Model) int {
+	added := 0
+	for _, model := range models {
+		if model == nil || strings.TrimSpace(model.ID) == "" {
+			continue
+		}
+		if _, ok := c.Get(model.ID); ok {
+			continue
+		}
+		model.Discovered = true
+		c.Register(model)
+		added++
+	}
+	return added
+}
+
 // List returns all models, optionally filtered.
 func (c *Catalog) List(filter *Filter) []*Model {
 	c.mu.RLock()
@@ -296,161 +378,47 @@ func tierRank(t Tier) int {
 	}
 }
 
-func (c *Catalog) registerBuiltinModels() {
-	// Anthropic models
-	c.Register(&Model{
-		ID:              "claude-opus-4",
-		Name:            "Claude Opus 4",
-		Provider:        ProviderAnthropic,
-		Tier:            TierFlagship,
-		ContextWindow:   200000,
-		MaxOutputTokens: 32000,
-		Capabilities: []Capability{
-			CapVision, CapTools, CapStreaming, CapJSON, CapCode,
-			CapLongContext, CapCaching, CapPDFInput,
-		},
-		Aliases:     []string{"claude-opus-4-5-20251101", "opus"},
-		ReleaseDate: "2025-11-01",
-		InputPrice:  15.0,
-		OutputPrice: 75.0,
-	})
-
-	c.Register(&Model{
-		ID:              "claude-3-5-sonnet-latest",
-		Name:            "Claude 3.5 Sonnet",
-		Provider:        ProviderAnthropic,
-		Tier:            TierStandard,
-		ContextWindow:   200000,
-		MaxOutputTokens: 8192,
-		Capabilities: []Capability{
-			CapVision, CapTools, CapStreaming, CapJSON, CapCode,
-			CapLongContext, CapCaching, CapPDFInput,
-		},
-		Aliases:     []string{"claude-3-5-sonnet", "sonnet"},
-		ReleaseDate: "2024-10-22",
-		InputPrice:  3.0,
-		OutputPrice: 15.0,
-	})
-
-	c.Register(&Model{
-		ID:              "claude-3-5-haiku-latest",
-		Name:            "Claude 3.5 Haiku",
-		Provider:        ProviderAnthropic,
-		Tier:            TierFast,
-		ContextWindow:   200000,
-		MaxOutputTokens: 8192,
-		Capabilities: []Capability{
-			CapVision, CapTools, CapStreaming, CapJSON, CapCode,
-			CapLongContext, CapCaching,
-		},
-		Aliases:     []string{"claude-3-5-haiku", "haiku"},
-		ReleaseDate: "2024-11-04",
-		InputPrice:  0.8,
-		OutputPrice: 4.0,
-	})
-
-	// OpenAI models
-	c.Register(&Model{
-		ID:              "gpt-4o",
-		Name:            "GPT-4o",
-		Provider:        ProviderOpenAI,
-		Tier:            TierStandard,
-		ContextWindow:   128000,
-		MaxOutputTokens: 16384,
-		Capabilities: []Capability{
-			CapVision, CapTools, CapStreaming, CapJSON, CapCode,
-			CapLongContext, CapAudio,
-		},
-		Aliases:     []string{"gpt-4o-2024-11-20"},
-		ReleaseDate: "2024-05-13",
-		InputPrice:  2.5,
-		OutputPrice: 10.0,
-	})
-
-	c.Register(&Model{
-		ID:              "gpt-4o-mini",
-		Name:            "GPT-4o Mini",
-		Provider:        ProviderOpenAI,
-		Tier:            TierFast,
-		ContextWindow:   128000,
-		MaxOutputTokens: 16384,
-		Capabilities: []Capability{
-			CapVision, CapTools, CapStreaming, CapJSON, CapCode,
-			CapLongContext,
-		},
-		Aliases:     []string{"gpt-4o-mini-2024-07-18"},
-		ReleaseDate: "2024-07-18",
-		InputPrice:  0.15,
-		OutputPrice: 0.6,
-	})
-
-	c.Register(&Model{
-		ID:              "o1",
-		Name:            "o1",
-		Provider:        ProviderOpenAI,
-		Tier:            TierFlagship,
-		ContextWindow:   200000,
-		MaxOutputTokens: 100000,
-		Capabilities: []Capability{
-			CapVision, CapTools, CapReasoning, CapJSON, CapCode,
-			CapLongContext,
-		},
-		Aliases:     []string{"o1-2024-12-17"},
-		ReleaseDate: "2024-12-17",
-		InputPrice:  15.0,
-		OutputPrice: 60.0,
-	})
-
-	c.Register(&Model{
-		ID:              "o3-mini",
-		Name:            "o3-mini",
-		Provider:        ProviderOpenAI,
-		Tier:            TierStandard,
-		ContextWindow:   200000,
-		MaxOutputTokens: 100000,
-		Capabilities: []Capability{
-			CapTools, CapReasoning, CapJSON, CapCode, CapLongContext,
-		},
-		Aliases:     []string{"o3-mini-2025-01-31"},
-		ReleaseDate: "2025-01-31",
-		InputPrice:  1.1,
-		OutputPrice: 4.4,
-	})
+// builtinModelsJSON is the bundled model data, kept in a data file so prices
+// and limits can be updated without touching code.
+//
+//go:embed builtin_models.json
+var builtinModelsJSON []byte
 
-	// Google models
-	c.Register(&Model{
-		ID:              "gemini-2.0-flash-exp",
-		Name:            "Gemini 2.0 Flash",
-		Provider:        ProviderGoogle,
-		Tier:            TierFast,
-		ContextWindow:   1048576,
-		MaxOutputTokens: 8192,
-		Capabilities: []Capability{
-			CapVision, CapTools, CapStreaming, CapJSON, CapCode,
-			CapLongContext, CapAudio, CapVideo,
-		},
-		Aliases:     []string{"gemini-2.0-flash"},
-		ReleaseDate: "2024-12-11",
-		InputPrice:  0.0,
-		OutputPrice: 0.0,
-	})
+func (c *Catalog) registerBuiltinModels() {
+	var builtin []*Model
+	if err := json.Unmarshal(builtinModelsJSON, &builtin); err != nil {
+		panic(fmt.Sprintf("models: invalid builtin_models.json: %v", err))
+	}
+	for _, model := range builtin {
+		c.Register(model)
+	}
+}
 
-	c.Register(&Model{
-		ID:              "gemini-1.5-pro-latest",
-		Name:            "Gemini 1.5 Pro",
-		Provider:        ProviderGoogle,
-		Tier:            TierStandard,
-		ContextWindow:   2097152,
-		MaxOutputTokens: 8192,
-		Capabilities: []Capability{
-			CapVision, CapTools, CapStreaming, CapJSON, CapCode,
-			CapLongContext, CapAudio, CapVideo,
-		},
-		Aliases:     []string{"gemini-1.5-pro"},
-		ReleaseDate: "2024-05-14",
-		InputPrice:  1.25,
-		OutputPrice: 5.0,
-	})
+// NewDiscoveredModel builds a catalog entry for a model a provider reports,
+// for use with AddDiscovered. Providers report less than the bundled data
+// holds, so pricing and output limits stay unset.
+func NewDiscoveredModel(provider Provider, id, name string, contextWindow int, vision, tools bool) *Model {
+	if name == "" {
+		name = id
+	}
+	caps := []Capability{CapStreaming}
+	if tools {
+		caps = append(caps, CapTools)
+	}
+	if vision {
+		caps = append(caps, CapVision)
+	}
+	if contextWindow >= 100000 {
+		caps = append(caps, CapLongContext)
+	}
+	return &Model{
+		ID:            id,
+		Name:          name,
+		Provider:      provider,
+		Tier:          TierStandard,
+		ContextWindow: contextWindow,
+		Capabilities:  caps,
+	}
 }
 
 // DefaultCatalog is the global model catalog.
@@ -461,6 +429,11 @@ func Get(id string) (*Model, bool) {
 	return DefaultCatalog.Get(id)
 }
 
+// Lookup resolves a model ID against the default catalog. See Catalog.Lookup.
+func Lookup(id string) (*Model, bool) {
+	return DefaultCatalog.Lookup(id)
+}
+
 // List returns models from the default catalog.
 func List(filter *Filter) []*Model {
 	return DefaultCatalog.List(filter)
@@ -214,6 +214,65 @@ func TestDefaultCatalog(t *testing.T) {
 	}
 }
 
+func TestCatalog_Lookup(t *testing.T) {
+	c := NewCatalog()
+	tests := []struct {
+		id   string
+		want string
+	}{
+		{"gpt-4o", "gpt-4o"},
+		{"claude-3-5-haiku-20241022", "claude-3-5-haiku-latest"},
+		{"gpt-4-turbo-preview", "gpt-4-turbo"},
+		{"gpt-4o-2024-08-06", "gpt-4o"},
+		{"gpt-4o-mini-2024-07-18", "gpt-4o-mini"},
+		{"claude-sonnet-4-5-20250929", "claude-sonnet-4-20250514"},
+		{"anthropic/claude-3-opus", "claude-3-opus-latest"},
+		{"gemini-2.0-flash-latest", "gemini-2.0-flash"},
+	}
+	for _, tt := range tests {
+		model, ok := c.Lookup(tt.id)
+		if !ok || model.ID != tt.want {
+			t.Errorf("Lookup(%q) = %v, %v; want %s", tt.id, model, ok, tt.want)
+		}
+	}
+	for _, id := range []string{"", "gpt-4.1", "gpt-4oz", "llama3"} {
+		if model, ok := c.Lookup(id); ok {
+			t.Errorf("Lookup(%q) = %s, want no match", id, model.ID)
+		}
+	}
+}
+
+func TestCatalog_AddDiscovered(t *testing.T) {
+	c := NewCatalog()
+	added := c.AddDiscovered(
+		NewDiscoveredModel(ProviderOpenAI, "gpt-4o", "GPT-4o", 64000, true, true),
+		NewDiscoveredModel(ProviderOllama, "llama3", "", 8192, false, false),
+	)
+	if added != 1 {
+		t.Fatalf("added = %d, want 1", added)
+	}
+	if model, _ := c.Get("gpt-4o"); model.ContextWindow != 128000 || model.Discovered {
+		t.Errorf("bundled gpt-4o was overridden: %+v", model)
+	}
+	model, ok := c.Get("llama3")
+	if !ok || !model.Discovered || model.Name != "llama3" || model.ContextWindow != 8192 || model.SupportsTools() {
+		t.Errorf("llama3 = %+v", model)
+	}
+}
+
+func TestBuiltinModelsAreComplete(t *testing.T) {
+	for _, model := range NewCatalog().List(&Filter{IncludeDeprecated: true}) {
+		if model.Name == "" || model.Provider == "" || model.Tier == "" || model.ContextWindow <= 0 || model.MaxOutputTokens <= 0 {
+			t.Errorf("incomplete builtin model %+v", model)
+		}
+		if model.Deprecated && model.ReplacedBy != "" {
+			if _, ok := Get(model.ReplacedBy); !ok {
+				t.Errorf("%s is replaced by unknown model %s", model.ID, model.ReplacedBy)
+			}
+		}
+	}
+}
+
 // ==============================================================================
 // Dynamic ModelCatalog Tests (clawdbot-style)
 // ==============================================================================
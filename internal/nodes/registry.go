@@ -478,7 +478,7 @@ func (r *Registry) logAudit(ctx context.Context, nodeID NodeID, action, userID s
 // isSensitiveCapability returns true for capabilities that should require approval.
 func isSensitiveCapability(cap Capability) bool {
 	switch cap {
-	case CapCamera, CapScreen, CapLocation, CapFilesystem, CapShell:
+	case CapCamera, CapScreen, CapLocation, CapFilesystem, CapShell, CapComputerUse:
 		return true
 	default:
 		return false
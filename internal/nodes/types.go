@@ -33,6 +33,7 @@
 //   - filesystem: File access
 //   - shell: Command execution
 //   - browser: Browser relay
+//   - computer_use: UI automation (mouse/keyboard/screen)
 //   - channels: Message channel hosting
 package nodes
 
@@ -64,13 +65,14 @@ const (
 type Capability string
 
 const (
-	CapCamera     Capability = "camera"
-	CapScreen     Capability = "screen"
-	CapLocation   Capability = "location"
-	CapFilesystem Capability = "filesystem"
-	CapShell      Capability = "shell"
-	CapBrowser    Capability = "browser"
-	CapChannels   Capability = "channels"
+	CapCamera      Capability = "camera"
+	CapScreen      Capability = "screen"
+	CapLocation    Capability = "location"
+	CapFilesystem  Capability = "filesystem"
+	CapShell       Capability = "shell"
+	CapBrowser     Capability = "browser"
+	CapComputerUse Capability = "computer_use"
+	CapChannels    Capability = "channels"
 )
 
 // Node represents a registered device/agent.
@@ -0,0 +1,55 @@
+package observability
+
+import (
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// ArtifactGCMetrics counts artifacts reclaimed by the artifact pruner.
+type ArtifactGCMetrics struct {
+	// Pruned counts deleted artifacts.
+	// Labels: reason (expired, unreferenced)
+	Pruned *prometheus.CounterVec
+
+	// ReclaimedBytes counts the bytes of deleted artifacts. Repositories
+	// only report sizes for unreferenced artifacts.
+	// Labels: reason (unreferenced)
+	ReclaimedBytes *prometheus.CounterVec
+}
+
+var (
+	artifactGCMetricsOnce     sync.Once
+	artifactGCMetricsInstance *ArtifactGCMetrics
+)
+
+// NewArtifactGCMetrics returns the process-wide artifact pruner metrics.
+func NewArtifactGCMetrics() *ArtifactGCMetrics {
+	artifactGCMetricsOnce.Do(func() {
+		artifactGCMetricsInstance = &ArtifactGCMetrics{
+			Pruned: promauto.NewCounterVec(prometheus.CounterOpts{
+				Name: "nexus_artifacts_pruned_total",
+				Help: "Total number of artifacts deleted by the pruner by reason",
+			}, []string{"reason"}),
+			ReclaimedBytes: promauto.NewCounterVec(prometheus.CounterOpts{
+				Name: "nexus_artifacts_reclaimed_bytes_total",
+				Help: "Total bytes of artifact data reclaimed by the pruner by reason",
+			}, []string{"reason"}),
+		}
+	})
+	return artifactGCMetricsInstance
+}
+
+// RecordPruned counts artifacts deleted for reason and the bytes they held.
+func (m *ArtifactGCMetrics) RecordPruned(reason string, count int, bytes int64) {
+	if m == nil {
+		return
+	}
+	if count > 0 {
+		m.Pruned.WithLabelValues(reason).Add(float64(count))
+	}
+	if bytes > 0 {
+		m.ReclaimedBytes.WithLabelValues(reason).Add(float64(bytes))
+	}
+}
@@ -0,0 +1,53 @@
+package observability
+
+import (
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// CrashMetrics counts recovered panics in supervised components.
+type CrashMetrics struct {
+	// Crashes counts recovered panics.
+	// Labels: component
+	Crashes *prometheus.CounterVec
+
+	// Restarts counts restarts of components after a panic.
+	// Labels: component
+	Restarts *prometheus.CounterVec
+}
+
+var (
+	crashMetricsOnce     sync.Once
+	crashMetricsInstance *CrashMetrics
+)
+
+// NewCrashMetrics returns the process-wide crash metrics.
+func NewCrashMetrics() *CrashMetrics {
+	crashMetricsOnce.Do(func() {
+		crashMetricsInstance = &CrashMetrics{
+			Crashes: promauto.NewCounterVec(prometheus.CounterOpts{
+				Name: "nexus_component_crashes_total",
+				Help: "Total number of recovered panics by component",
+			}, []string{"component"}),
+			Restarts: promauto.NewCounterVec(prometheus.CounterOpts{
+				Name: "nexus_component_restarts_total",
+				Help: "Total number of component restarts after a panic",
+			}, []string{"component"}),
+		}
+	})
+	return crashMetricsInstance
+}
+
+// RecordCrash counts a crash and, when restarted is set, the restart that
+// follows it.
+func (m *CrashMetrics) RecordCrash(component string, restarted bool) {
+	if m == nil {
+		return
+	}
+	m.Crashes.WithLabelValues(component).Inc()
+	if restarted {
+		m.Restarts.WithLabelValues(component).Inc()
+	}
+}
@@ -32,6 +32,7 @@ const (
 	EventTypeLaneDequeue         DiagnosticEventType = "queue.lane.dequeue"
 	EventTypeRunAttempt          DiagnosticEventType = "run.attempt"
 	EventTypeDiagnosticHeartbeat DiagnosticEventType = "diagnostic.heartbeat"
+	EventTypeComponentCrash      DiagnosticEventType = "component.crash"
 )
 
 // DiagnosticEvent is the base event structure.
@@ -175,6 +176,15 @@ type DiagnosticHeartbeatEvent struct {
 	Queued   int          `json:"queued"`
 }
 
+// ComponentCrashEvent tracks a recovered panic in a supervised component.
+type ComponentCrashEvent struct {
+	DiagnosticEvent
+	Component string `json:"component"`
+	Panic     string `json:"panic"`
+	Stack     string `json:"stack,omitempty"`
+	Restart   int    `json:"restart,omitempty"`
+}
+
 // WebhookStats contains webhook statistics.
 type WebhookStats struct {
 	Received  int64 `json:"received"`
@@ -365,6 +375,14 @@ func EmitDiagnosticHeartbeat(e *DiagnosticHeartbeatEvent) {
 	emit(e)
 }
 
+// EmitComponentCrash emits a component crash event.
+func EmitComponentCrash(e *ComponentCrashEvent) {
+	e.Type = EventTypeComponentCrash
+	e.Seq = nextSeq()
+	e.Ts = time.Now().UnixMilli()
+	emit(e)
+}
+
 // ResetDiagnosticsForTest resets diagnostic state for testing.
 func ResetDiagnosticsForTest() {
 	globalEmitter.mu.Lock()
@@ -0,0 +1,218 @@
+package observability
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+)
+
+// EventPersistConfig controls which events a PersistingEventStore writes to
+// the database and how writes are batched.
+type EventPersistConfig struct {
+	// SampleRate is the fraction of runs whose events are persisted, from 0
+	// to 1. Sampling is decided per run (per session for events outside a
+	// run) so a persisted timeline is complete. Error events and events with
+	// neither are always persisted, so 0 keeps only those.
+	SampleRate float64
+
+	// Types restricts persistence to the listed event types. An entry such
+	// as "tool.*" matches every type with that prefix. Empty persists all.
+	Types []string
+
+	// BufferSize bounds the events waiting to be written. Events recorded
+	// while the buffer is full are dropped.
+	BufferSize int
+
+	// FlushInterval is how often buffered events are written.
+	FlushInterval time.Duration
+}
+
+// PersistingEventStore records events in a primary store, which serves
+// reads, and writes a sampled copy to a DBEventStore in the background so
+// recording never blocks on the database.
+type PersistingEventStore struct {
+	EventStore
+
+	cfg    EventPersistConfig
+	logger *slog.Logger
+
+	mu      sync.Mutex
+	db      *DBEventStore
+	pending []*Event
+	dropped uint64
+	cancel  context.CancelFunc
+	done    chan struct{}
+}
+
+// NewPersistingEventStore wraps primary. Events are buffered until a
+// database is attached with Attach.
+func NewPersistingEventStore(primary EventStore, cfg EventPersistConfig, logger *slog.Logger) *PersistingEventStore {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	if cfg.SampleRate < 0 || cfg.SampleRate > 1 {
+		cfg.SampleRate = 1
+	}
+	if cfg.BufferSize <= 0 {
+		cfg.BufferSize = 10000
+	}
+	if cfg.FlushInterval <= 0 {
+		cfg.FlushInterval = 5 * time.Second
+	}
+	return &PersistingEventStore{
+		EventStore: primary,
+		cfg:        cfg,
+		logger:     logger.With("component", "event_store"),
+	}
+}
+
+// Record stores the event in the primary store and queues it for the
+// database when it is sampled.
+func (s *PersistingEventStore) Record(event *Event) error {
+	if err := s.EventStore.Record(event); err != nil {
+		return err
+	}
+	if !s.shouldPersist(event) {
+		return nil
+	}
+	s.mu.Lock()
+	if len(s.pending) >= s.cfg.BufferSize {
+		s.dropped++
+	} else {
+		s.pending = append(s.pending, event)
+	}
+	s.mu.Unlock()
+	return nil
+}
+
+// Attach sets the database events are written to.
+func (s *PersistingEventStore) Attach(db *DBEventStore) {
+	s.mu.Lock()
+	s.db = db
+	s.mu.Unlock()
+}
+
+// DB returns the attached database store, or nil.
+func (s *PersistingEventStore) DB() *DBEventStore {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.db
+}
+
+// Dropped returns how many sampled events were discarded because the
+// buffer was full or a write failed.
+func (s *PersistingEventStore) Dropped() uint64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.dropped
+}
+
+// Start flushes buffered events every interval until Shutdown is called or
+// ctx is done.
+func (s *PersistingEventStore) Start(ctx context.Context) {
+	s.mu.Lock()
+	if s.cancel != nil {
+		s.mu.Unlock()
+		return
+	}
+	ctx, s.cancel = context.WithCancel(ctx)
+	s.done = make(chan struct{})
+	s.mu.Unlock()
+
+	go func() {
+		defer close(s.done)
+		ticker := time.NewTicker(s.cfg.FlushInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := s.Flush(ctx); err != nil && ctx.Err() == nil {
+					s.logger.Warn("persist events failed", "error", err)
+				}
+			}
+		}
+	}()
+}
+
+// Shutdown stops the flush loop and writes any buffered events.
+func (s *PersistingEventStore) Shutdown(ctx context.Context) error {
+	s.mu.Lock()
+	cancel, done := s.cancel, s.done
+	s.cancel = nil
+	s.mu.Unlock()
+	if cancel != nil {
+		cancel()
+		<-done
+	}
+	return s.Flush(ctx)
+}
+
+// Flush writes buffered events to the attached database. Without a
+// database the events stay buffered.
+func (s *PersistingEventStore) Flush(ctx context.Context) error {
+	s.mu.Lock()
+	db, batch := s.db, s.pending
+	if db == nil || len(batch) == 0 {
+		s.mu.Unlock()
+		return nil
+	}
+	s.pending = nil
+	s.mu.Unlock()
+
+	if err := db.RecordBatch(ctx, batch); err != nil {
+		s.mu.Lock()
+		s.dropped += uint64(len(batch))
+		s.mu.Unlock()
+		return fmt.Errorf("write event batch: %w", err)
+	}
+	return nil
+}
+
+// shouldPersist applies the type filter and per-run sampling.
+func (s *PersistingEventStore) shouldPersist(event *Event) bool {
+	if event == nil || !matchesEventTypes(event.Type, s.cfg.Types) {
+		return false
+	}
+	if s.cfg.SampleRate >= 1 || isErrorEvent(event) {
+		return true
+	}
+	key := event.RunID
+	if key == "" {
+		key = event.SessionID
+	}
+	if key == "" {
+		return true
+	}
+	return sampleKey(key) < s.cfg.SampleRate
+}
+
+// matchesEventTypes reports whether eventType is listed in types. An empty
+// list matches everything.
+func matchesEventTypes(eventType EventType, types []string) bool {
+	if len(types) == 0 {
+		return true
+	}
+	for _, t := range types {
+		if prefix, ok := strings.CutSuffix(t, "*"); ok {
+			if strings.HasPrefix(string(eventType), prefix) {
+				return true
+			}
+		} else if string(eventType) == t {
+			return true
+		}
+	}
+	return false
+}
+
+// sampleKey maps key to a stable value in [0, 1).
+func sampleKey(key string) float64 {
+	sum := sha256.Sum256([]byte(key))
+	return float64(binary.BigEndian.Uint64(sum[:8])>>11) / float64(1<<53)
+}
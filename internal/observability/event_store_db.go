@@ -0,0 +1,318 @@
+package observability
+
+// This file implements a database-backed event store so agent timelines
+// survive restarts and can be queried for postmortems.
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/haasonsaas/nexus/internal/storage/encryption"
+)
+
+// EventQuery filters persisted events. Zero-valued fields match everything.
+type EventQuery struct {
+	RunID     string
+	SessionID string
+	AgentID   string
+	// Types matches any of the listed event types.
+	Types []EventType
+	// Name matches the event name exactly (for example a tool name).
+	Name string
+	// ErrorsOnly matches only events that carry an error.
+	ErrorsOnly bool
+	Since      time.Time
+	Until      time.Time
+	// Limit caps the number of events returned, keeping the most recent.
+	// Zero means no limit.
+	Limit int
+}
+
+// DBEventStore implements EventStore using the agent_events table.
+type DBEventStore struct {
+	db *sql.DB
+	// keyring encrypts the stored event payload at rest when set.
+	keyring *encryption.Keyring
+}
+
+// NewDBEventStore creates a DB-backed event store.
+func NewDBEventStore(db *sql.DB) (*DBEventStore, error) {
+	if db == nil {
+		return nil, errors.New("db is required")
+	}
+	return &DBEventStore{db: db}, nil
+}
+
+// SetKeyring makes the store encrypt event payloads, which hold tool inputs
+// and outputs, on write and decrypt them on read. Indexed columns stay
+// plaintext so events can still be queried.
+func (s *DBEventStore) SetKeyring(keyring *encryption.Keyring) {
+	s.keyring = keyring
+}
+
+// Record stores a single event.
+func (s *DBEventStore) Record(event *Event) error {
+	if event == nil {
+		return errors.New("event cannot be nil")
+	}
+	return s.RecordBatch(context.Background(), []*Event{event})
+}
+
+// RecordBatch stores events in one transaction. Events that were already
+// stored are skipped.
+func (s *DBEventStore) RecordBatch(ctx context.Context, events []*Event) error {
+	if len(events) == 0 {
+		return nil
+	}
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback() //nolint:errcheck // no-op after commit
+
+	stmt, err := tx.PrepareContext(ctx, `
+		INSERT INTO agent_events (id, type, name, run_id, session_id, agent_id, tool_call_id, is_error, created_at, data)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		ON CONFLICT (id) DO NOTHING
+	`)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	for _, event := range events {
+		if event == nil {
+			continue
+		}
+		if event.ID == "" {
+			event.ID = generateEventID()
+		}
+		if event.Timestamp.IsZero() {
+			event.Timestamp = time.Now()
+		}
+		data, err := s.encodeEvent(event)
+		if err != nil {
+			return fmt.Errorf("encode event %s: %w", event.ID, err)
+		}
+		if _, err := stmt.ExecContext(ctx, event.ID, string(event.Type), event.Name, event.RunID,
+			event.SessionID, event.AgentID, event.ToolCallID, isErrorEvent(event),
+			event.Timestamp.UTC(), data); err != nil {
+			return fmt.Errorf("insert event %s: %w", event.ID, err)
+		}
+	}
+	return tx.Commit()
+}
+
+// Query returns events matching q in chronological order.
+func (s *DBEventStore) Query(ctx context.Context, q EventQuery) ([]*Event, error) {
+	var (
+		where []string
+		args  []any
+	)
+	add := func(clause string, arg any) {
+		args = append(args, arg)
+		where = append(where, fmt.Sprintf(clause, len(args)))
+	}
+	if q.RunID != "" {
+		add("run_id = $%d", q.RunID)
+	}
+	if q.SessionID != "" {
+		add("session_id = $%d", q.SessionID)
+	}
+	if q.AgentID != "" {
+		add("agent_id = $%d", q.AgentID)
+	}
+	if q.Name != "" {
+		add("name = $%d", q.Name)
+	}
+	if len(q.Types) > 0 {
+		placeholders := make([]string, 0, len(q.Types))
+		for _, t := range q.Types {
+			args = append(args, string(t))
+			placeholders = append(placeholders, fmt.Sprintf("$%d", len(args)))
+		}
+		where = append(where, "type IN ("+strings.Join(placeholders, ", ")+")")
+	}
+	if q.ErrorsOnly {
+		add("is_error = $%d", true)
+	}
+	if !q.Since.IsZero() {
+		add("created_at >= $%d", q.Since.UTC())
+	}
+	if !q.Until.IsZero() {
+		add("created_at < $%d", q.Until.UTC())
+	}
+
+	query := "SELECT data FROM agent_events"
+	if len(where) > 0 {
+		query += " WHERE " + strings.Join(where, " AND ")
+	}
+	// Select newest first so the limit keeps the most recent events, then
+	// reverse into timeline order.
+	query += " ORDER BY created_at DESC, id DESC"
+	if q.Limit > 0 {
+		query += fmt.Sprintf(" LIMIT %d", q.Limit)
+	}
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var events []*Event
+	for rows.Next() {
+		var data []byte
+		if err := rows.Scan(&data); err != nil {
+			return nil, err
+		}
+		event, err := s.decodeEvent(data)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, event)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	slices.Reverse(events)
+	return events, nil
+}
+
+// GetByRunID returns all events for a run, sorted by timestamp.
+func (s *DBEventStore) GetByRunID(runID string) ([]*Event, error) {
+	return s.Query(context.Background(), EventQuery{RunID: runID})
+}
+
+// GetBySessionID returns all events for a session, sorted by timestamp.
+func (s *DBEventStore) GetBySessionID(sessionID string) ([]*Event, error) {
+	return s.Query(context.Background(), EventQuery{SessionID: sessionID})
+}
+
+// GetByTimeRange returns events within a time range.
+func (s *DBEventStore) GetByTimeRange(start, end time.Time) ([]*Event, error) {
+	return s.Query(context.Background(), EventQuery{Since: start, Until: end})
+}
+
+// GetByType returns the most recent events of a specific type.
+func (s *DBEventStore) GetByType(eventType EventType, limit int) ([]*Event, error) {
+	return s.Query(context.Background(), EventQuery{Types: []EventType{eventType}, Limit: limit})
+}
+
+// Get returns a single event by ID.
+func (s *DBEventStore) Get(id string) (*Event, error) {
+	var data []byte
+	err := s.db.QueryRowContext(context.Background(), `SELECT data FROM agent_events WHERE id = $1`, id).Scan(&data)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("event not found: %s", id)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return s.decodeEvent(data)
+}
+
+// Delete removes events older than the given duration.
+func (s *DBEventStore) Delete(olderThan time.Duration) (int, error) {
+	return s.Prune(context.Background(), time.Now().Add(-olderThan))
+}
+
+// Prune removes events recorded before cutoff.
+func (s *DBEventStore) Prune(ctx context.Context, cutoff time.Time) (int, error) {
+	result, err := s.db.ExecContext(ctx, `DELETE FROM agent_events WHERE created_at < $1`, cutoff.UTC())
+	if err != nil {
+		return 0, err
+	}
+	n, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+	return int(n), nil
+}
+
+// DeleteSession removes every event recorded for a session.
+func (s *DBEventStore) DeleteSession(ctx context.Context, sessionID string) (int64, error) {
+	if sessionID == "" {
+		return 0, errors.New("session id is required")
+	}
+	return s.deleteWhere(ctx, `DELETE FROM agent_events WHERE session_id = $1`, sessionID)
+}
+
+// DeletePeer removes the events of every session holding a message from the
+// peer identified by channel and channelID. Events do not record who caused
+// them, so this must run before the peer's messages are deleted.
+func (s *DBEventStore) DeletePeer(ctx context.Context, channel, channelID string) (int64, error) {
+	if channel == "" || channelID == "" {
+		return 0, errors.New("channel and channel id are required")
+	}
+	return s.deleteWhere(ctx, `
+		DELETE FROM agent_events WHERE session_id IN (
+			SELECT DISTINCT session_id FROM messages WHERE channel = $1 AND channel_id = $2
+		)
+	`, channel, channelID)
+}
+
+func (s *DBEventStore) deleteWhere(ctx context.Context, query string, args ...any) (int64, error) {
+	result, err := s.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return 0, fmt.Errorf("delete events: %w", err)
+	}
+	return result.RowsAffected()
+}
+
+// encodeEvent returns the JSON stored in the data column. With a keyring the
+// event is sealed and stored as a JSON string so the column stays valid JSON.
+func (s *DBEventStore) encodeEvent(event *Event) (string, error) {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return "", err
+	}
+	if s.keyring == nil {
+		return string(data), nil
+	}
+	sealed, err := s.keyring.Encrypt(string(data))
+	if err != nil {
+		return "", err
+	}
+	quoted, err := json.Marshal(sealed)
+	if err != nil {
+		return "", err
+	}
+	return string(quoted), nil
+}
+
+// decodeEvent reverses encodeEvent. Rows written before encryption was
+// enabled hold the event object itself.
+func (s *DBEventStore) decodeEvent(data []byte) (*Event, error) {
+	var sealed string
+	if err := json.Unmarshal(data, &sealed); err == nil {
+		plaintext, err := s.keyring.Decrypt(sealed)
+		if err != nil {
+			return nil, fmt.Errorf("decrypt event: %w", err)
+		}
+		data = []byte(plaintext)
+	}
+	var event Event
+	if err := json.Unmarshal(data, &event); err != nil {
+		return nil, fmt.Errorf("decode event: %w", err)
+	}
+	return &event, nil
+}
+
+// isErrorEvent reports whether an event represents a failure.
+func isErrorEvent(event *Event) bool {
+	if event.Error != "" {
+		return true
+	}
+	switch event.Type {
+	case EventTypeRunError, EventTypeToolError, EventTypeLLMError:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,198 @@
+package observability
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/haasonsaas/nexus/internal/sessions"
+	"github.com/haasonsaas/nexus/internal/storage/encryption"
+)
+
+func newTestDBEventStore(t *testing.T) *DBEventStore {
+	t.Helper()
+	db, err := sessions.OpenSQLite(context.Background(), "sqlite://"+filepath.Join(t.TempDir(), "nexus.db"))
+	if err != nil {
+		t.Fatalf("OpenSQLite() error = %v", err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+	store, err := NewDBEventStore(db)
+	if err != nil {
+		t.Fatalf("NewDBEventStore() error = %v", err)
+	}
+	return store
+}
+
+func TestDBEventStoreQuery(t *testing.T) {
+	ctx := context.Background()
+	store := newTestDBEventStore(t)
+	now := time.Now()
+	events := []*Event{
+		{ID: "e1", Type: EventTypeRunStart, RunID: "r1", SessionID: "s1", Timestamp: now.Add(-3 * time.Hour)},
+		{ID: "e2", Type: EventTypeToolError, Name: "web_fetch", RunID: "r1", SessionID: "s1", Error: "timeout", Timestamp: now.Add(-30 * time.Minute)},
+		{ID: "e3", Type: EventTypeToolEnd, Name: "exec", RunID: "r2", SessionID: "s1", Timestamp: now.Add(-20 * time.Minute),
+			Data: map[string]interface{}{"exit_code": float64(0)}},
+		{ID: "e4", Type: EventTypeToolEnd, Name: "exec", RunID: "r3", SessionID: "s2", Timestamp: now.Add(-10 * time.Minute)},
+	}
+	if err := store.RecordBatch(ctx, events); err != nil {
+		t.Fatalf("RecordBatch() error = %v", err)
+	}
+	// Re-recording is a no-op.
+	if err := store.Record(events[0]); err != nil {
+		t.Fatalf("Record() error = %v", err)
+	}
+
+	ids := func(events []*Event) string {
+		var out string
+		for _, e := range events {
+			out += e.ID + " "
+		}
+		return out
+	}
+	cases := []struct {
+		name  string
+		query EventQuery
+		want  string
+	}{
+		{"session", EventQuery{SessionID: "s1"}, "e1 e2 e3 "},
+		{"since", EventQuery{SessionID: "s1", Since: now.Add(-time.Hour)}, "e2 e3 "},
+		{"type", EventQuery{Types: []EventType{EventTypeToolEnd}}, "e3 e4 "},
+		{"errors", EventQuery{ErrorsOnly: true}, "e2 "},
+		{"name", EventQuery{Name: "exec", Until: now.Add(-15 * time.Minute)}, "e3 "},
+		{"limit keeps newest", EventQuery{Limit: 2}, "e3 e4 "},
+	}
+	for _, tc := range cases {
+		got, err := store.Query(ctx, tc.query)
+		if err != nil {
+			t.Fatalf("%s: Query() error = %v", tc.name, err)
+		}
+		if ids(got) != tc.want {
+			t.Errorf("%s: got %q, want %q", tc.name, ids(got), tc.want)
+		}
+	}
+
+	got, err := store.Get("e3")
+	if err != nil || got.Name != "exec" || got.Data["exit_code"] != float64(0) {
+		t.Fatalf("Get() = %+v, %v", got, err)
+	}
+	deleted, err := store.Delete(time.Hour)
+	if err != nil || deleted != 1 {
+		t.Fatalf("Delete() = %d, %v", deleted, err)
+	}
+	if _, err := store.Get("e1"); err == nil {
+		t.Fatal("expected pruned event to be gone")
+	}
+}
+
+func TestDBEventStoreEncryptsAndDeletes(t *testing.T) {
+	ctx := context.Background()
+	store := newTestDBEventStore(t)
+	keyring, err := encryption.NewKeyring("k1", map[string][]byte{"k1": bytes.Repeat([]byte{1}, encryption.KeySize)})
+	if err != nil {
+		t.Fatalf("NewKeyring() error = %v", err)
+	}
+
+	// A row written before encryption was enabled stays readable.
+	if err := store.Record(&Event{ID: "plain", Type: EventTypeToolEnd, SessionID: "s1", Name: "exec"}); err != nil {
+		t.Fatal(err)
+	}
+	store.SetKeyring(keyring)
+	if err := store.RecordBatch(ctx, []*Event{
+		{ID: "sealed", Type: EventTypeToolStart, SessionID: "s1", Name: "exec",
+			Data: map[string]interface{}{"input": "secret command"}},
+		{ID: "other", Type: EventTypeToolStart, SessionID: "s2"},
+		{ID: "shared", Type: EventTypeToolStart, SessionID: "s3"},
+	}); err != nil {
+		t.Fatalf("RecordBatch() error = %v", err)
+	}
+
+	var raw string
+	if err := store.db.QueryRowContext(ctx, `SELECT data FROM agent_events WHERE id = 'sealed'`).Scan(&raw); err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(raw, "secret command") || !strings.Contains(raw, encryption.Prefix) {
+		t.Fatalf("stored data = %s, want it encrypted", raw)
+	}
+	got, err := store.Query(ctx, EventQuery{SessionID: "s1", Name: "exec"})
+	if err != nil || len(got) != 2 || got[1].Data["input"] != "secret command" {
+		t.Fatalf("Query() = %+v, %v", got, err)
+	}
+
+	deleted, err := store.DeleteSession(ctx, "s1")
+	if err != nil || deleted != 2 {
+		t.Fatalf("DeleteSession() = %d, %v", deleted, err)
+	}
+
+	// The peer wrote into s3, so its events go; s2 is untouched.
+	now := time.Now()
+	if _, err := store.db.ExecContext(ctx, `INSERT INTO sessions (id, agent_id, channel, channel_id, key, created_at, updated_at)
+		VALUES ('s3', 'main', 'slack', 'C1', 'main:slack:C1', $1, $1)`, now); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := store.db.ExecContext(ctx, `INSERT INTO messages (id, session_id, channel, channel_id, direction, role, content, created_at)
+		VALUES ('m1', 's3', 'slack', 'U1', 'inbound', 'user', 'hi', $1)`, now); err != nil {
+		t.Fatal(err)
+	}
+	deleted, err = store.DeletePeer(ctx, "slack", "U1")
+	if err != nil || deleted != 1 {
+		t.Fatalf("DeletePeer() = %d, %v", deleted, err)
+	}
+	if _, err := store.Get("other"); err != nil {
+		t.Fatalf("Get(other) error = %v", err)
+	}
+}
+
+func TestPersistingEventStoreSamplesRuns(t *testing.T) {
+	ctx := context.Background()
+	db := newTestDBEventStore(t)
+	memory := NewMemoryEventStore(0)
+	store := NewPersistingEventStore(memory, EventPersistConfig{SampleRate: 0.5, Types: []string{"tool.*", "run.error"}}, nil)
+
+	// Events recorded before the database is attached are buffered.
+	for i := 0; i < 40; i++ {
+		runID := fmt.Sprintf("run-%d", i)
+		for _, typ := range []EventType{EventTypeToolStart, EventTypeToolEnd, EventTypeLLMRequest} {
+			if err := store.Record(&Event{Type: typ, RunID: runID}); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	if err := store.Record(&Event{Type: EventTypeRunError, RunID: "run-unsampled", Error: "boom"}); err != nil {
+		t.Fatal(err)
+	}
+	store.Attach(db)
+	if err := store.Shutdown(ctx); err != nil {
+		t.Fatalf("Shutdown() error = %v", err)
+	}
+
+	if all, _ := memory.GetByTimeRange(time.Time{}, time.Now().Add(time.Minute)); len(all) != 121 {
+		t.Fatalf("primary store has %d events, want 121", len(all))
+	}
+	persisted, err := db.Query(ctx, EventQuery{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	perRun := map[string]int{}
+	for _, e := range persisted {
+		if e.Type == EventTypeLLMRequest {
+			t.Fatalf("type filter let through %s", e.Type)
+		}
+		perRun[e.RunID]++
+	}
+	if perRun["run-unsampled"] != 1 {
+		t.Error("error events must always be persisted")
+	}
+	delete(perRun, "run-unsampled")
+	if len(perRun) == 0 || len(perRun) == 40 {
+		t.Fatalf("expected roughly half the runs to be sampled, got %d of 40", len(perRun))
+	}
+	for run, n := range perRun {
+		if n != 2 {
+			t.Errorf("run %s persisted %d events, want the complete run", run, n)
+		}
+	}
+}
@@ -0,0 +1,50 @@
+package observability
+
+import (
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// LLMUsageMetrics counts tokens used by agent runs.
+type LLMUsageMetrics struct {
+	// Tokens counts tokens by kind. Reasoning tokens are also counted in
+	// output, since providers bill them as output.
+	// Labels: provider, model, type (input, output, reasoning)
+	Tokens *prometheus.CounterVec
+}
+
+var (
+	llmUsageMetricsOnce     sync.Once
+	llmUsageMetricsInstance *LLMUsageMetrics
+)
+
+// NewLLMUsageMetrics returns the process-wide LLM usage metrics.
+func NewLLMUsageMetrics() *LLMUsageMetrics {
+	llmUsageMetricsOnce.Do(func() {
+		llmUsageMetricsInstance = &LLMUsageMetrics{
+			Tokens: promauto.NewCounterVec(prometheus.CounterOpts{
+				Name: "nexus_llm_usage_tokens_total",
+				Help: "Total number of tokens used by agent runs by provider, model, and type",
+			}, []string{"provider", "model", "type"}),
+		}
+	})
+	return llmUsageMetricsInstance
+}
+
+// Record counts the tokens of one model call.
+func (m *LLMUsageMetrics) Record(provider, model string, inputTokens, outputTokens, reasoningTokens int) {
+	if m == nil {
+		return
+	}
+	for kind, tokens := range map[string]int{
+		"input":     inputTokens,
+		"output":    outputTokens,
+		"reasoning": reasoningTokens,
+	} {
+		if tokens > 0 {
+			m.Tokens.WithLabelValues(provider, model, kind).Add(float64(tokens))
+		}
+	}
+}
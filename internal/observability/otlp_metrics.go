@@ -0,0 +1,405 @@
+package observability
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"math"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	collectorpb "go.opentelemetry.io/proto/otlp/collector/metrics/v1"
+	commonpb "go.opentelemetry.io/proto/otlp/common/v1"
+	metricspb "go.opentelemetry.io/proto/otlp/metrics/v1"
+	resourcepb "go.opentelemetry.io/proto/otlp/resource/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/proto"
+)
+
+// OTLP metrics transport protocols.
+const (
+	OTLPProtocolGRPC = "grpc"
+	OTLPProtocolHTTP = "http"
+)
+
+// otlpScopeName is the instrumentation scope exported metrics are grouped
+// under.
+const otlpScopeName = "github.com/haasonsaas/nexus"
+
+// OTLPMetricsConfig configures pushing metrics to an OpenTelemetry
+// collector.
+type OTLPMetricsConfig struct {
+	// Endpoint is host:port for gRPC, or a URL for HTTP. HTTP requests go
+	// to /v1/metrics unless the URL has a path.
+	Endpoint string
+
+	// Protocol is OTLPProtocolGRPC (default) or OTLPProtocolHTTP.
+	Protocol string
+
+	// Insecure disables TLS.
+	Insecure bool
+
+	// Interval is how often metrics are pushed (default: 60s).
+	Interval time.Duration
+
+	// Timeout bounds each push (default: 10s).
+	Timeout time.Duration
+
+	// Headers are sent with every push, e.g. an API key for a hosted
+	// collector.
+	Headers map[string]string
+
+	// ResourceAttributes describe the process, e.g. service.name.
+	ResourceAttributes map[string]string
+}
+
+// OTLPMetricsExporter pushes the instruments of a Prometheus registry to an
+// OTLP collector, so the same metrics can be scraped at /metrics, pushed,
+// or both. Counters, histograms, and summaries are sent with cumulative
+// temporality, starting at the exporter's creation.
+type OTLPMetricsExporter struct {
+	cfg      OTLPMetricsConfig
+	gatherer prometheus.Gatherer
+	logger   *slog.Logger
+	start    time.Time
+	now      func() time.Time
+
+	send  func(ctx context.Context, req *collectorpb.ExportMetricsServiceRequest) error
+	close func() error
+
+	mu     sync.Mutex
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// NewOTLPMetricsExporter creates an exporter for gatherer. A nil gatherer
+// reads the default Prometheus registry.
+func NewOTLPMetricsExporter(cfg OTLPMetricsConfig, gatherer prometheus.Gatherer, logger *slog.Logger) (*OTLPMetricsExporter, error) {
+	if strings.TrimSpace(cfg.Endpoint) == "" {
+		return nil, errors.New("otlp metrics endpoint is required")
+	}
+	if gatherer == nil {
+		gatherer = prometheus.DefaultGatherer
+	}
+	if logger == nil {
+		logger = slog.Default()
+	}
+	if cfg.Interval <= 0 {
+		cfg.Interval = 60 * time.Second
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = 10 * time.Second
+	}
+	e := &OTLPMetricsExporter{
+		cfg:      cfg,
+		gatherer: gatherer,
+		logger:   logger.With("component", "otlp_metrics"),
+		start:    time.Now(),
+		now:      time.Now,
+	}
+
+	switch strings.ToLower(strings.TrimSpace(cfg.Protocol)) {
+	case "", OTLPProtocolGRPC:
+		creds := credentials.NewTLS(&tls.Config{MinVersion: tls.VersionTLS12})
+		if cfg.Insecure {
+			creds = insecure.NewCredentials()
+		}
+		conn, err := grpc.NewClient(cfg.Endpoint, grpc.WithTransportCredentials(creds))
+		if err != nil {
+			return nil, fmt.Errorf("otlp metrics: dial %s: %w", cfg.Endpoint, err)
+		}
+		client := collectorpb.NewMetricsServiceClient(conn)
+		e.send = func(ctx context.Context, req *collectorpb.ExportMetricsServiceRequest) error {
+			if len(cfg.Headers) > 0 {
+				ctx = metadata.NewOutgoingContext(ctx, metadata.New(cfg.Headers))
+			}
+			_, err := client.Export(ctx, req)
+			return err
+		}
+		e.close = conn.Close
+	case OTLPProtocolHTTP:
+		target, err := otlpHTTPMetricsURL(cfg.Endpoint, cfg.Insecure)
+		if err != nil {
+			return nil, err
+		}
+		client := &http.Client{}
+		e.send = func(ctx context.Context, req *collectorpb.ExportMetricsServiceRequest) error {
+			return postOTLPMetrics(ctx, client, target, cfg.Headers, req)
+		}
+		e.close = func() error { return nil }
+	default:
+		return nil, fmt.Errorf("otlp metrics: unknown protocol %q (use grpc or http)", cfg.Protocol)
+	}
+	return e, nil
+}
+
+// Start pushes metrics every interval until Shutdown is called or ctx is
+// done.
+func (e *OTLPMetricsExporter) Start(ctx context.Context) {
+	e.mu.Lock()
+	if e.cancel != nil {
+		e.mu.Unlock()
+		return
+	}
+	ctx, e.cancel = context.WithCancel(ctx)
+	e.done = make(chan struct{})
+	e.mu.Unlock()
+
+	go func() {
+		defer close(e.done)
+		ticker := time.NewTicker(e.cfg.Interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := e.Export(ctx); err != nil && ctx.Err() == nil {
+					e.logger.Warn("otlp metrics export failed", "endpoint", e.cfg.Endpoint, "error", err)
+				}
+			}
+		}
+	}()
+}
+
+// Shutdown stops the push loop, sends a final push so the last interval is
+// not lost, and closes the connection.
+func (e *OTLPMetricsExporter) Shutdown(ctx context.Context) error {
+	e.mu.Lock()
+	cancel, done := e.cancel, e.done
+	e.cancel = nil
+	e.mu.Unlock()
+	if cancel != nil {
+		cancel()
+		<-done
+	}
+	err := e.Export(ctx)
+	return errors.Join(err, e.close())
+}
+
+// Export gathers the registry and pushes it once.
+func (e *OTLPMetricsExporter) Export(ctx context.Context) error {
+	families, err := e.gatherer.Gather()
+	if err != nil && len(families) == 0 {
+		return fmt.Errorf("gather metrics: %w", err)
+	}
+	req := e.buildRequest(families, e.now())
+	if len(req.ResourceMetrics[0].ScopeMetrics[0].Metrics) == 0 {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(ctx, e.cfg.Timeout)
+	defer cancel()
+	return e.send(ctx, req)
+}
+
+func (e *OTLPMetricsExporter) buildRequest(families []*dto.MetricFamily, now time.Time) *collectorpb.ExportMetricsServiceRequest {
+	resource := &resourcepb.Resource{Attributes: otlpAttributes(e.cfg.ResourceAttributes)}
+	metrics := make([]*metricspb.Metric, 0, len(families))
+	for _, family := range families {
+		if metric := convertMetricFamily(family, e.start, now); metric != nil {
+			metrics = append(metrics, metric)
+		}
+	}
+	return &collectorpb.ExportMetricsServiceRequest{
+		ResourceMetrics: []*metricspb.ResourceMetrics{{
+			Resource: resource,
+			ScopeMetrics: []*metricspb.ScopeMetrics{{
+				Scope:   &commonpb.InstrumentationScope{Name: otlpScopeName},
+				Metrics: metrics,
+			}},
+		}},
+	}
+}
+
+// convertMetricFamily maps a Prometheus metric family to an OTLP metric.
+// Prometheus histogram buckets are cumulative; OTLP buckets are not, and
+// carry a final overflow bucket in place of +Inf.
+func convertMetricFamily(family *dto.MetricFamily, start, now time.Time) *metricspb.Metric {
+	if family == nil || len(family.GetMetric()) == 0 {
+		return nil
+	}
+	metric := &metricspb.Metric{Name: family.GetName(), Description: family.GetHelp()}
+	startNano, nowNano := uint64(start.UnixNano()), uint64(now.UnixNano())
+	points := func(value func(*dto.Metric) float64) []*metricspb.NumberDataPoint {
+		out := make([]*metricspb.NumberDataPoint, 0, len(family.GetMetric()))
+		for _, m := range family.GetMetric() {
+			out = append(out, &metricspb.NumberDataPoint{
+				Attributes:        otlpLabelAttributes(m.GetLabel()),
+				StartTimeUnixNano: metricStart(m, startNano),
+				TimeUnixNano:      nowNano,
+				Value:             &metricspb.NumberDataPoint_AsDouble{AsDouble: value(m)},
+			})
+		}
+		return out
+	}
+
+	switch family.GetType() {
+	case dto.MetricType_COUNTER:
+		metric.Data = &metricspb.Metric_Sum{Sum: &metricspb.Sum{
+			DataPoints:             points(func(m *dto.Metric) float64 { return m.GetCounter().GetValue() }),
+			AggregationTemporality: metricspb.AggregationTemporality_AGGREGATION_TEMPORALITY_CUMULATIVE,
+			IsMonotonic:            true,
+		}}
+	case dto.MetricType_GAUGE:
+		metric.Data = &metricspb.Metric_Gauge{Gauge: &metricspb.Gauge{
+			DataPoints: points(func(m *dto.Metric) float64 { return m.GetGauge().GetValue() }),
+		}}
+	case dto.MetricType_UNTYPED:
+		metric.Data = &metricspb.Metric_Gauge{Gauge: &metricspb.Gauge{
+			DataPoints: points(func(m *dto.Metric) float64 { return m.GetUntyped().GetValue() }),
+		}}
+	case dto.MetricType_HISTOGRAM:
+		histogram := &metricspb.Histogram{
+			AggregationTemporality: metricspb.AggregationTemporality_AGGREGATION_TEMPORALITY_CUMULATIVE,
+		}
+		for _, m := range family.GetMetric() {
+			h := m.GetHistogram()
+			sum := h.GetSampleSum()
+			point := &metricspb.HistogramDataPoint{
+				Attributes:        otlpLabelAttributes(m.GetLabel()),
+				StartTimeUnixNano: metricStart(m, startNano),
+				TimeUnixNano:      nowNano,
+				Count:             h.GetSampleCount(),
+				Sum:               &sum,
+			}
+			var previous uint64
+			for _, bucket := range h.GetBucket() {
+				if math.IsInf(bucket.GetUpperBound(), 1) {
+					continue
+				}
+				point.ExplicitBounds = append(point.ExplicitBounds, bucket.GetUpperBound())
+				point.BucketCounts = append(point.BucketCounts, bucket.GetCumulativeCount()-previous)
+				previous = bucket.GetCumulativeCount()
+			}
+			point.BucketCounts = append(point.BucketCounts, h.GetSampleCount()-previous)
+			histogram.DataPoints = append(histogram.DataPoints, point)
+		}
+		metric.Data = &metricspb.Metric_Histogram{Histogram: histogram}
+	case dto.MetricType_SUMMARY:
+		summary := &metricspb.Summary{}
+		for _, m := range family.GetMetric() {
+			s := m.GetSummary()
+			point := &metricspb.SummaryDataPoint{
+				Attributes:        otlpLabelAttributes(m.GetLabel()),
+				StartTimeUnixNano: metricStart(m, startNano),
+				TimeUnixNano:      nowNano,
+				Count:             s.GetSampleCount(),
+				Sum:               s.GetSampleSum(),
+			}
+			for _, q := range s.GetQuantile() {
+				point.QuantileValues = append(point.QuantileValues, &metricspb.SummaryDataPoint_ValueAtQuantile{
+					Quantile: q.GetQuantile(),
+					Value:    q.GetValue(),
+				})
+			}
+			summary.DataPoints = append(summary.DataPoints, point)
+		}
+		metric.Data = &metricspb.Metric_Summary{Summary: summary}
+	default:
+		return nil
+	}
+	return metric
+}
+
+// metricStart returns the series' creation time when the registry records
+// one, and the exporter's start otherwise.
+func metricStart(m *dto.Metric, fallback uint64) uint64 {
+	if ts := m.GetCounter().GetCreatedTimestamp(); ts != nil {
+		return uint64(ts.AsTime().UnixNano())
+	}
+	if ts := m.GetHistogram().GetCreatedTimestamp(); ts != nil {
+		return uint64(ts.AsTime().UnixNano())
+	}
+	if ts := m.GetSummary().GetCreatedTimestamp(); ts != nil {
+		return uint64(ts.AsTime().UnixNano())
+	}
+	return fallback
+}
+
+func otlpLabelAttributes(labels []*dto.LabelPair) []*commonpb.KeyValue {
+	if len(labels) == 0 {
+		return nil
+	}
+	out := make([]*commonpb.KeyValue, 0, len(labels))
+	for _, label := range labels {
+		out = append(out, otlpString(label.GetName(), label.GetValue()))
+	}
+	return out
+}
+
+func otlpAttributes(attrs map[string]string) []*commonpb.KeyValue {
+	keys := make([]string, 0, len(attrs))
+	for k := range attrs {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	out := make([]*commonpb.KeyValue, 0, len(keys))
+	for _, k := range keys {
+		out = append(out, otlpString(k, attrs[k]))
+	}
+	return out
+}
+
+func otlpString(key, value string) *commonpb.KeyValue {
+	return &commonpb.KeyValue{Key: key, Value: &commonpb.AnyValue{Value: &commonpb.AnyValue_StringValue{StringValue: value}}}
+}
+
+// otlpHTTPMetricsURL resolves the OTLP/HTTP metrics URL for endpoint, which
+// may be a bare host:port.
+func otlpHTTPMetricsURL(endpoint string, insecure bool) (string, error) {
+	endpoint = strings.TrimSpace(endpoint)
+	if !strings.Contains(endpoint, "://") {
+		scheme := "https://"
+		if insecure {
+			scheme = "http://"
+		}
+		endpoint = scheme + endpoint
+	}
+	u, err := url.Parse(endpoint)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return "", fmt.Errorf("otlp metrics: invalid endpoint %q", endpoint)
+	}
+	if u.Path == "" || u.Path == "/" {
+		u.Path = "/v1/metrics"
+	}
+	return u.String(), nil
+}
+
+func postOTLPMetrics(ctx context.Context, client *http.Client, target string, headers map[string]string, req *collectorpb.ExportMetricsServiceRequest) error {
+	body, err := proto.Marshal(req)
+	if err != nil {
+		return fmt.Errorf("encode metrics: %w", err)
+	}
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, target, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	httpReq.Header.Set("Content-Type", "application/x-protobuf")
+	for k, v := range headers {
+		httpReq.Header.Set(k, v)
+	}
+	resp, err := client.Do(httpReq)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024)) //nolint:errcheck // best effort
+		return fmt.Errorf("collector returned %s: %s", resp.Status, strings.TrimSpace(string(msg)))
+	}
+	_, _ = io.Copy(io.Discard, resp.Body) //nolint:errcheck // drain for connection reuse
+	return nil
+}
@@ -0,0 +1,116 @@
+package observability
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	collectorpb "go.opentelemetry.io/proto/otlp/collector/metrics/v1"
+	metricspb "go.opentelemetry.io/proto/otlp/metrics/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestOTLPMetricsExporter_HTTPPush(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	counter := prometheus.NewCounterVec(prometheus.CounterOpts{Name: "nexus_messages_total", Help: "Messages."}, []string{"channel"})
+	histogram := prometheus.NewHistogram(prometheus.HistogramOpts{Name: "nexus_reply_seconds", Help: "Reply latency.", Buckets: []float64{1, 5}})
+	gauge := prometheus.NewGauge(prometheus.GaugeOpts{Name: "nexus_sessions", Help: "Sessions."})
+	registry.MustRegister(counter, histogram, gauge)
+	counter.WithLabelValues("slack").Add(3)
+	for _, v := range []float64{0.5, 2, 3, 10} {
+		histogram.Observe(v)
+	}
+	gauge.Set(7)
+
+	received := make(chan *collectorpb.ExportMetricsServiceRequest, 2)
+	collector := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/metrics" || r.Header.Get("Content-Type") != "application/x-protobuf" || r.Header.Get("X-Api-Key") != "secret" {
+			t.Errorf("unexpected request %s %v", r.URL.Path, r.Header)
+		}
+		body, _ := io.ReadAll(r.Body)
+		req := &collectorpb.ExportMetricsServiceRequest{}
+		if err := proto.Unmarshal(body, req); err != nil {
+			t.Errorf("decode: %v", err)
+		}
+		received <- req
+	}))
+	defer collector.Close()
+
+	exporter, err := NewOTLPMetricsExporter(OTLPMetricsConfig{
+		Endpoint:           collector.URL,
+		Protocol:           OTLPProtocolHTTP,
+		Headers:            map[string]string{"X-Api-Key": "secret"},
+		ResourceAttributes: map[string]string{"service.name": "nexus"},
+	}, registry, nil)
+	if err != nil {
+		t.Fatalf("NewOTLPMetricsExporter: %v", err)
+	}
+	if err := exporter.Export(context.Background()); err != nil {
+		t.Fatalf("Export: %v", err)
+	}
+
+	req := <-received
+	rm := req.GetResourceMetrics()[0]
+	if attr := rm.GetResource().GetAttributes()[0]; attr.GetKey() != "service.name" || attr.GetValue().GetStringValue() != "nexus" {
+		t.Errorf("resource attribute = %v", attr)
+	}
+	metrics := make(map[string]*metricspb.Metric)
+	for _, m := range rm.GetScopeMetrics()[0].GetMetrics() {
+		metrics[m.GetName()] = m
+	}
+
+	sum := metrics["nexus_messages_total"].GetSum()
+	if sum == nil || !sum.GetIsMonotonic() || sum.GetAggregationTemporality() != metricspb.AggregationTemporality_AGGREGATION_TEMPORALITY_CUMULATIVE {
+		t.Fatalf("counter = %v", metrics["nexus_messages_total"])
+	}
+	point := sum.GetDataPoints()[0]
+	if point.GetAsDouble() != 3 || point.GetAttributes()[0].GetValue().GetStringValue() != "slack" {
+		t.Errorf("counter point = %v", point)
+	}
+
+	hist := metrics["nexus_reply_seconds"].GetHistogram().GetDataPoints()[0]
+	wantCounts := []uint64{1, 2, 1}
+	if hist.GetCount() != 4 || hist.GetSum() != 15.5 || len(hist.GetBucketCounts()) != 3 {
+		t.Fatalf("histogram = %v", hist)
+	}
+	for i, want := range wantCounts {
+		if hist.GetBucketCounts()[i] != want {
+			t.Errorf("bucket %d = %d, want %d", i, hist.GetBucketCounts()[i], want)
+		}
+	}
+	if bounds := hist.GetExplicitBounds(); len(bounds) != 2 || bounds[0] != 1 || bounds[1] != 5 {
+		t.Errorf("bounds = %v", bounds)
+	}
+
+	if g := metrics["nexus_sessions"].GetGauge(); g == nil || g.GetDataPoints()[0].GetAsDouble() != 7 {
+		t.Errorf("gauge = %v", metrics["nexus_sessions"])
+	}
+
+	exporter.Start(context.Background())
+	if err := exporter.Shutdown(context.Background()); err != nil {
+		t.Fatalf("Shutdown: %v", err)
+	}
+	if len(received) != 1 {
+		t.Errorf("expected a final push on shutdown")
+	}
+}
+
+func TestOTLPHTTPMetricsURL(t *testing.T) {
+	cases := map[string]string{
+		"collector:4318":                       "https://collector:4318/v1/metrics",
+		"http://collector:4318/":               "http://collector:4318/v1/metrics",
+		"https://otlp.example.com/otlp/v1/met": "https://otlp.example.com/otlp/v1/met",
+	}
+	for endpoint, want := range cases {
+		got, err := otlpHTTPMetricsURL(endpoint, false)
+		if err != nil || got != want {
+			t.Errorf("otlpHTTPMetricsURL(%q) = %q, %v; want %q", endpoint, got, err, want)
+		}
+	}
+	if _, err := otlpHTTPMetricsURL("ftp://collector", false); err == nil {
+		t.Error("expected an error for a non-HTTP endpoint")
+	}
+}
@@ -0,0 +1,82 @@
+package observability
+
+import (
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// ProviderRaceMetrics measures speculative provider racing.
+type ProviderRaceMetrics struct {
+	// Races counts finished races. Labels: winner, outcome (faster,
+	// loser_failed)
+	Races *prometheus.CounterVec
+
+	// Skipped counts requests that were eligible for racing but went to the
+	// fallback provider. Labels: reason (background, channel, tools, cost,
+	// budget)
+	Skipped *prometheus.CounterVec
+
+	// FirstChunkSeconds observes how long winners took to produce usable
+	// output. Labels: winner
+	FirstChunkSeconds *prometheus.HistogramVec
+
+	// EstimatedCostUSD sums the estimated cost of raced requests on both
+	// contenders.
+	EstimatedCostUSD prometheus.Counter
+}
+
+var (
+	providerRaceMetricsOnce     sync.Once
+	providerRaceMetricsInstance *ProviderRaceMetrics
+)
+
+// NewProviderRaceMetrics returns the process-wide provider race metrics.
+func NewProviderRaceMetrics() *ProviderRaceMetrics {
+	providerRaceMetricsOnce.Do(func() {
+		providerRaceMetricsInstance = &ProviderRaceMetrics{
+			Races: promauto.NewCounterVec(prometheus.CounterOpts{
+				Name: "nexus_llm_race_total",
+				Help: "Total number of raced LLM requests by winning provider",
+			}, []string{"winner", "outcome"}),
+			Skipped: promauto.NewCounterVec(prometheus.CounterOpts{
+				Name: "nexus_llm_race_skipped_total",
+				Help: "Total number of LLM requests not raced, by reason",
+			}, []string{"reason"}),
+			FirstChunkSeconds: promauto.NewHistogramVec(prometheus.HistogramOpts{
+				Name:    "nexus_llm_race_first_chunk_seconds",
+				Help:    "Time until the winning provider produced usable output",
+				Buckets: []float64{0.1, 0.25, 0.5, 1, 2, 3, 5, 10, 30},
+			}, []string{"winner"}),
+			EstimatedCostUSD: promauto.NewCounter(prometheus.CounterOpts{
+				Name: "nexus_llm_race_estimated_cost_usd_total",
+				Help: "Estimated cost in USD of raced LLM requests on both providers",
+			}),
+		}
+	})
+	return providerRaceMetricsInstance
+}
+
+// RecordRace counts a finished race.
+func (m *ProviderRaceMetrics) RecordRace(winner string, loserFailed bool, firstChunk time.Duration, costUSD float64) {
+	if m == nil {
+		return
+	}
+	outcome := "faster"
+	if loserFailed {
+		outcome = "loser_failed"
+	}
+	m.Races.WithLabelValues(winner, outcome).Inc()
+	m.FirstChunkSeconds.WithLabelValues(winner).Observe(firstChunk.Seconds())
+	m.EstimatedCostUSD.Add(costUSD)
+}
+
+// RecordSkip counts a request that was not raced.
+func (m *ProviderRaceMetrics) RecordSkip(reason string) {
+	if m == nil {
+		return
+	}
+	m.Skipped.WithLabelValues(reason).Inc()
+}
@@ -0,0 +1,77 @@
+package observability
+
+import (
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// ProviderThrottleMetrics measures client-side throttling of LLM provider
+// requests.
+type ProviderThrottleMetrics struct {
+	// WaitSeconds observes how long requests queued for capacity.
+	// Labels: provider, reason (concurrency, requests, tokens, retry_after)
+	WaitSeconds *prometheus.HistogramVec
+
+	// Rejected counts requests refused because capacity would not free up
+	// before their deadline. Labels: provider, reason
+	Rejected *prometheus.CounterVec
+
+	// Pauses counts 429 responses that paused a provider for its
+	// Retry-After delay. Labels: provider
+	Pauses *prometheus.CounterVec
+}
+
+var (
+	providerThrottleMetricsOnce     sync.Once
+	providerThrottleMetricsInstance *ProviderThrottleMetrics
+)
+
+// NewProviderThrottleMetrics returns the process-wide provider throttle
+// metrics.
+func NewProviderThrottleMetrics() *ProviderThrottleMetrics {
+	providerThrottleMetricsOnce.Do(func() {
+		providerThrottleMetricsInstance = &ProviderThrottleMetrics{
+			WaitSeconds: promauto.NewHistogramVec(prometheus.HistogramOpts{
+				Name:    "nexus_llm_throttle_wait_seconds",
+				Help:    "Time LLM requests waited for provider rate limit capacity",
+				Buckets: []float64{0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60},
+			}, []string{"provider", "reason"}),
+			Rejected: promauto.NewCounterVec(prometheus.CounterOpts{
+				Name: "nexus_llm_throttle_rejected_total",
+				Help: "Total number of LLM requests refused by provider rate limits",
+			}, []string{"provider", "reason"}),
+			Pauses: promauto.NewCounterVec(prometheus.CounterOpts{
+				Name: "nexus_llm_throttle_pauses_total",
+				Help: "Total number of provider pauses after 429 responses",
+			}, []string{"provider"}),
+		}
+	})
+	return providerThrottleMetricsInstance
+}
+
+// RecordWait observes a request that waited for capacity.
+func (m *ProviderThrottleMetrics) RecordWait(provider, reason string, wait time.Duration) {
+	if m == nil {
+		return
+	}
+	m.WaitSeconds.WithLabelValues(provider, reason).Observe(wait.Seconds())
+}
+
+// RecordReject counts a request refused for lack of capacity.
+func (m *ProviderThrottleMetrics) RecordReject(provider, reason string) {
+	if m == nil {
+		return
+	}
+	m.Rejected.WithLabelValues(provider, reason).Inc()
+}
+
+// RecordPause counts a 429 pause.
+func (m *ProviderThrottleMetrics) RecordPause(provider string) {
+	if m == nil {
+		return
+	}
+	m.Pauses.WithLabelValues(provider).Inc()
+}
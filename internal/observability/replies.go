@@ -0,0 +1,61 @@
+package observability
+
+import (
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// ReplyBuckets are the reply latency histogram buckets. SLO latency
+// thresholds are evaluated against bucket boundaries, so common targets
+// such as 5s, 15s and 30s have their own bucket.
+var ReplyBuckets = []float64{0.5, 1, 2, 5, 10, 15, 30, 60, 120, 300}
+
+// ReplyMetrics tracks how long inbound messages take to answer and how
+// their runs end.
+type ReplyMetrics struct {
+	// Duration measures time from receiving a message to sending the reply.
+	// Labels: channel
+	Duration *prometheus.HistogramVec
+
+	// Runs counts processed messages by outcome (completed, error, cancelled).
+	// Labels: channel, outcome
+	Runs *prometheus.CounterVec
+}
+
+var (
+	replyMetricsOnce     sync.Once
+	replyMetricsInstance *ReplyMetrics
+)
+
+// NewReplyMetrics returns the process-wide reply metrics.
+func NewReplyMetrics() *ReplyMetrics {
+	replyMetricsOnce.Do(func() {
+		replyMetricsInstance = &ReplyMetrics{
+			Duration: promauto.NewHistogramVec(prometheus.HistogramOpts{
+				Name:    "nexus_reply_duration_seconds",
+				Help:    "Time from receiving a message to sending the reply",
+				Buckets: ReplyBuckets,
+			}, []string{"channel"}),
+			Runs: promauto.NewCounterVec(prometheus.CounterOpts{
+				Name: "nexus_runs_total",
+				Help: "Total number of processed messages by outcome",
+			}, []string{"channel", "outcome"}),
+		}
+	})
+	return replyMetricsInstance
+}
+
+// Record counts a processed message. Only completed replies are added to
+// the latency histogram, so failures do not count as fast replies.
+func (m *ReplyMetrics) Record(channel, outcome string, duration time.Duration) {
+	if m == nil {
+		return
+	}
+	m.Runs.WithLabelValues(channel, outcome).Inc()
+	if outcome == "completed" {
+		m.Duration.WithLabelValues(channel).Observe(duration.Seconds())
+	}
+}
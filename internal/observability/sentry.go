@@ -0,0 +1,216 @@
+package observability
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SentryExporter sends component crashes to Sentry using the envelope API.
+// It implements just enough of the protocol for crash reports so the gateway
+// does not need the Sentry SDK.
+type SentryExporter struct {
+	dsn         string
+	endpoint    string
+	auth        string
+	environment string
+	release     string
+	serverName  string
+	client      *http.Client
+}
+
+// NewSentryExporter parses a Sentry DSN of the form
+// https://<key>@<host>/<project>.
+func NewSentryExporter(dsn, environment, release string) (*SentryExporter, error) {
+	parsed, err := url.Parse(strings.TrimSpace(dsn))
+	if err != nil {
+		return nil, fmt.Errorf("parse sentry dsn: %w", err)
+	}
+	if parsed.Scheme != "http" && parsed.Scheme != "https" {
+		return nil, errors.New("sentry dsn must use http or https")
+	}
+	if parsed.User == nil || parsed.User.Username() == "" {
+		return nil, errors.New("sentry dsn is missing the public key")
+	}
+	path := strings.Trim(parsed.Path, "/")
+	projectID := path
+	prefix := ""
+	if idx := strings.LastIndex(path, "/"); idx >= 0 {
+		prefix = "/" + path[:idx]
+		projectID = path[idx+1:]
+	}
+	if _, err := strconv.ParseUint(projectID, 10, 64); err != nil {
+		return nil, fmt.Errorf("sentry dsn has invalid project id %q", projectID)
+	}
+
+	hostname, _ := os.Hostname()
+	return &SentryExporter{
+		dsn:      parsed.String(),
+		endpoint: fmt.Sprintf("%s://%s%s/api/%s/envelope/", parsed.Scheme, parsed.Host, prefix, projectID),
+		auth: fmt.Sprintf("Sentry sentry_version=7, sentry_client=nexus/1.0, sentry_key=%s",
+			parsed.User.Username()),
+		environment: environment,
+		release:     release,
+		serverName:  hostname,
+		client:      &http.Client{Timeout: 10 * time.Second},
+	}, nil
+}
+
+type sentryFrame struct {
+	Function string `json:"function,omitempty"`
+	AbsPath  string `json:"abs_path,omitempty"`
+	Lineno   int    `json:"lineno,omitempty"`
+	InApp    bool   `json:"in_app"`
+}
+
+type sentryException struct {
+	Type       string `json:"type"`
+	Value      string `json:"value"`
+	Module     string `json:"module,omitempty"`
+	Stacktrace *struct {
+		Frames []sentryFrame `json:"frames"`
+	} `json:"stacktrace,omitempty"`
+}
+
+type sentryEvent struct {
+	EventID     string            `json:"event_id"`
+	Timestamp   string            `json:"timestamp"`
+	Platform    string            `json:"platform"`
+	Level       string            `json:"level"`
+	Logger      string            `json:"logger"`
+	ServerName  string            `json:"server_name,omitempty"`
+	Environment string            `json:"environment,omitempty"`
+	Release     string            `json:"release,omitempty"`
+	Tags        map[string]string `json:"tags"`
+	Extra       map[string]any    `json:"extra,omitempty"`
+	Exception   struct {
+		Values []sentryException `json:"values"`
+	} `json:"exception"`
+}
+
+// CaptureCrash sends a crash event to Sentry.
+func (e *SentryExporter) CaptureCrash(ctx context.Context, crash *ComponentCrashEvent) error {
+	if e == nil || crash == nil {
+		return nil
+	}
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return fmt.Errorf("generate event id: %w", err)
+	}
+	at := time.Now()
+	if crash.Ts > 0 {
+		at = time.UnixMilli(crash.Ts)
+	}
+
+	event := sentryEvent{
+		EventID:     hex.EncodeToString(id),
+		Timestamp:   at.UTC().Format(time.RFC3339Nano),
+		Platform:    "go",
+		Level:       "fatal",
+		Logger:      "nexus.supervisor",
+		ServerName:  e.serverName,
+		Environment: e.environment,
+		Release:     e.release,
+		Tags:        map[string]string{"component": crash.Component},
+	}
+	if crash.Restart > 0 {
+		event.Level = "error"
+		event.Extra = map[string]any{"restart": crash.Restart}
+	}
+	exception := sentryException{Type: "panic", Value: crash.Panic, Module: crash.Component}
+	if frames := parseGoStack(crash.Stack); len(frames) > 0 {
+		exception.Stacktrace = &struct {
+			Frames []sentryFrame `json:"frames"`
+		}{Frames: frames}
+	}
+	event.Exception.Values = []sentryException{exception}
+
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	header, err := json.Marshal(map[string]string{
+		"event_id": event.EventID,
+		"sent_at":  time.Now().UTC().Format(time.RFC3339Nano),
+		"dsn":      e.dsn,
+	})
+	if err != nil {
+		return err
+	}
+	var body bytes.Buffer
+	body.Write(header)
+	body.WriteByte('\n')
+	fmt.Fprintf(&body, `{"type":"event","length":%d}`, len(payload))
+	body.WriteByte('\n')
+	body.Write(payload)
+	body.WriteByte('\n')
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.endpoint, &body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-sentry-envelope")
+	req.Header.Set("X-Sentry-Auth", e.auth)
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("send sentry event: %w", err)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("sentry returned %s", resp.Status)
+	}
+	return nil
+}
+
+// parseGoStack converts a runtime/debug stack into Sentry frames, oldest
+// call first. Frames from the runtime and from the panic recovery itself are
+// marked as not in-app.
+func parseGoStack(stack string) []sentryFrame {
+	lines := strings.Split(strings.TrimSpace(stack), "\n")
+	var frames []sentryFrame
+	for i := 0; i+1 < len(lines); i++ {
+		fn := strings.TrimSpace(lines[i])
+		loc := lines[i+1]
+		if fn == "" || strings.HasPrefix(fn, "goroutine ") || !strings.HasPrefix(loc, "\t") {
+			continue
+		}
+		i++
+		if strings.HasPrefix(fn, "created by ") {
+			continue
+		}
+		loc = strings.TrimSpace(loc)
+		if idx := strings.LastIndex(loc, " +0x"); idx >= 0 {
+			loc = loc[:idx]
+		}
+		frame := sentryFrame{AbsPath: loc}
+		if idx := strings.LastIndex(loc, ":"); idx >= 0 {
+			if n, err := strconv.Atoi(loc[idx+1:]); err == nil {
+				frame.AbsPath = loc[:idx]
+				frame.Lineno = n
+			}
+		}
+		if idx := strings.LastIndex(fn, "("); idx > 0 {
+			fn = fn[:idx]
+		}
+		frame.Function = fn
+		frame.InApp = strings.Contains(fn, "haasonsaas/nexus") &&
+			!strings.Contains(fn, "internal/supervisor.")
+		frames = append(frames, frame)
+	}
+	for l, r := 0, len(frames)-1; l < r; l, r = l+1, r-1 {
+		frames[l], frames[r] = frames[r], frames[l]
+	}
+	return frames
+}
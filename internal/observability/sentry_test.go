@@ -0,0 +1,101 @@
+package observability
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestNewSentryExporterParsesDSN(t *testing.T) {
+	exporter, err := NewSentryExporter("https://abc123@o1.ingest.sentry.io/sub/42", "prod", "1.2.3")
+	if err != nil {
+		t.Fatalf("NewSentryExporter() error = %v", err)
+	}
+	if exporter.endpoint != "https://o1.ingest.sentry.io/sub/api/42/envelope/" {
+		t.Fatalf("endpoint = %q", exporter.endpoint)
+	}
+	if !strings.Contains(exporter.auth, "sentry_key=abc123") {
+		t.Fatalf("auth = %q", exporter.auth)
+	}
+
+	for _, dsn := range []string{"", "https://host/42", "https://key@host/project", "ftp://key@host/1"} {
+		if _, err := NewSentryExporter(dsn, "", ""); err == nil {
+			t.Errorf("expected error for dsn %q", dsn)
+		}
+	}
+}
+
+func TestSentryExporterCaptureCrash(t *testing.T) {
+	var (
+		path   string
+		auth   string
+		header map[string]string
+		item   map[string]any
+		event  sentryEvent
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		auth = r.Header.Get("X-Sentry-Auth")
+		scanner := bufio.NewScanner(r.Body)
+		scanner.Buffer(make([]byte, 1<<20), 1<<20)
+		var lines []string
+		for scanner.Scan() {
+			lines = append(lines, scanner.Text())
+		}
+		if len(lines) != 3 {
+			t.Errorf("expected 3 envelope lines, got %d", len(lines))
+			return
+		}
+		_ = json.Unmarshal([]byte(lines[0]), &header)
+		_ = json.Unmarshal([]byte(lines[1]), &item)
+		_ = json.Unmarshal([]byte(lines[2]), &event)
+	}))
+	defer server.Close()
+
+	dsn := strings.Replace(server.URL, "http://", "http://key@", 1) + "/7"
+	exporter, err := NewSentryExporter(dsn, "test", "")
+	if err != nil {
+		t.Fatalf("NewSentryExporter() error = %v", err)
+	}
+	stack := "goroutine 7 [running]:\n" +
+		"github.com/haasonsaas/nexus/internal/supervisor.Recover({0x1, 0x2})\n" +
+		"\t/src/internal/supervisor/supervisor.go:86 +0x3c\n" +
+		"github.com/haasonsaas/nexus/internal/channels/slack.(*Adapter).eventLoop(0xc000)\n" +
+		"\t/src/internal/channels/slack/adapter.go:120 +0x1f\n" +
+		"created by github.com/haasonsaas/nexus/internal/channels/slack.(*Adapter).Start in goroutine 1\n" +
+		"\t/src/internal/channels/slack/adapter.go:204 +0x99\n"
+	err = exporter.CaptureCrash(context.Background(), &ComponentCrashEvent{
+		Component: "channel:slack",
+		Panic:     "nil map",
+		Stack:     stack,
+		Restart:   2,
+	})
+	if err != nil {
+		t.Fatalf("CaptureCrash() error = %v", err)
+	}
+
+	if path != "/api/7/envelope/" || !strings.Contains(auth, "sentry_key=key") {
+		t.Fatalf("unexpected request path %q auth %q", path, auth)
+	}
+	if header["event_id"] != event.EventID || item["type"] != "event" {
+		t.Fatalf("unexpected envelope header %v item %v", header, item)
+	}
+	if event.Level != "error" || event.Tags["component"] != "channel:slack" || event.Environment != "test" {
+		t.Fatalf("unexpected event: %+v", event)
+	}
+	exc := event.Exception.Values[0]
+	if exc.Value != "nil map" || exc.Stacktrace == nil || len(exc.Stacktrace.Frames) != 2 {
+		t.Fatalf("unexpected exception: %+v", exc)
+	}
+	top := exc.Stacktrace.Frames[0]
+	if top.Function != "github.com/haasonsaas/nexus/internal/channels/slack.(*Adapter).eventLoop" || top.Lineno != 120 || !top.InApp {
+		t.Fatalf("unexpected oldest frame: %+v", top)
+	}
+	if exc.Stacktrace.Frames[1].InApp {
+		t.Fatalf("expected supervisor frame to be marked not in-app")
+	}
+}
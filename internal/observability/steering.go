@@ -0,0 +1,41 @@
+package observability
+
+import (
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// SteeringMetrics counts steering rule matches.
+type SteeringMetrics struct {
+	// Hits counts messages a steering rule was injected for.
+	// Labels: rule
+	Hits *prometheus.CounterVec
+}
+
+var (
+	steeringMetricsOnce     sync.Once
+	steeringMetricsInstance *SteeringMetrics
+)
+
+// NewSteeringMetrics returns the process-wide steering metrics.
+func NewSteeringMetrics() *SteeringMetrics {
+	steeringMetricsOnce.Do(func() {
+		steeringMetricsInstance = &SteeringMetrics{
+			Hits: promauto.NewCounterVec(prometheus.CounterOpts{
+				Name: "nexus_steering_rule_hits_total",
+				Help: "Total number of messages each steering rule matched",
+			}, []string{"rule"}),
+		}
+	})
+	return steeringMetricsInstance
+}
+
+// RecordHit counts a match of the given rule.
+func (m *SteeringMetrics) RecordHit(rule string) {
+	if m == nil {
+		return
+	}
+	m.Hits.WithLabelValues(rule).Inc()
+}
@@ -0,0 +1,67 @@
+package observability
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// ToolRetryMetrics counts automatic tool call retries.
+type ToolRetryMetrics struct {
+	// Retries counts retried tool calls.
+	// Labels: tool, error_class
+	Retries *prometheus.CounterVec
+
+	// Skipped counts failed calls that were not retried although attempts
+	// remained. Labels: tool, reason (non_idempotent, error_class)
+	Skipped *prometheus.CounterVec
+}
+
+var (
+	toolRetryMetricsOnce     sync.Once
+	toolRetryMetricsInstance *ToolRetryMetrics
+)
+
+// NewToolRetryMetrics returns the process-wide tool retry metrics.
+func NewToolRetryMetrics() *ToolRetryMetrics {
+	toolRetryMetricsOnce.Do(func() {
+		toolRetryMetricsInstance = &ToolRetryMetrics{
+			Retries: promauto.NewCounterVec(prometheus.CounterOpts{
+				Name: "nexus_tool_retries_total",
+				Help: "Total number of tool call retries by error class",
+			}, []string{"tool", "error_class"}),
+			Skipped: promauto.NewCounterVec(prometheus.CounterOpts{
+				Name: "nexus_tool_retries_skipped_total",
+				Help: "Total number of failed tool calls not retried by the retry policy",
+			}, []string{"tool", "reason"}),
+		}
+	})
+	return toolRetryMetricsInstance
+}
+
+// RecordRetry counts a retry and adds a tool.retry event to the span in ctx.
+func (m *ToolRetryMetrics) RecordRetry(ctx context.Context, tool, errorClass string, attempt int, delay time.Duration) {
+	if m == nil {
+		return
+	}
+	m.Retries.WithLabelValues(tool, errorClass).Inc()
+	trace.SpanFromContext(ctx).AddEvent("tool.retry", trace.WithAttributes(
+		attribute.String("tool.name", tool),
+		attribute.String("tool.error_class", errorClass),
+		attribute.Int("tool.attempt", attempt),
+		attribute.Int64("tool.retry_delay_ms", delay.Milliseconds()),
+	))
+}
+
+// RecordSkipped counts a failed call the retry policy declined to retry.
+func (m *ToolRetryMetrics) RecordSkipped(tool, reason string) {
+	if m == nil {
+		return
+	}
+	m.Skipped.WithLabelValues(tool, reason).Inc()
+}
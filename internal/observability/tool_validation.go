@@ -0,0 +1,44 @@
+package observability
+
+import (
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// ToolValidationMetrics counts tool calls checked against their input schema.
+type ToolValidationMetrics struct {
+	// Calls counts validated tool calls by outcome.
+	// Labels: tool, model, result (valid, repair_requested, rejected, passed_through)
+	Calls *prometheus.CounterVec
+}
+
+var (
+	toolValidationMetricsOnce     sync.Once
+	toolValidationMetricsInstance *ToolValidationMetrics
+)
+
+// NewToolValidationMetrics returns the process-wide tool validation metrics.
+func NewToolValidationMetrics() *ToolValidationMetrics {
+	toolValidationMetricsOnce.Do(func() {
+		toolValidationMetricsInstance = &ToolValidationMetrics{
+			Calls: promauto.NewCounterVec(prometheus.CounterOpts{
+				Name: "nexus_tool_call_validations_total",
+				Help: "Total number of tool calls validated against their input schema, by outcome",
+			}, []string{"tool", "model", "result"}),
+		}
+	})
+	return toolValidationMetricsInstance
+}
+
+// RecordCall counts a validated call to tool made by model.
+func (m *ToolValidationMetrics) RecordCall(tool, model, result string) {
+	if m == nil {
+		return
+	}
+	if model == "" {
+		model = "unknown"
+	}
+	m.Calls.WithLabelValues(tool, model, result).Inc()
+}
@@ -467,6 +467,35 @@ func GetSpanID(ctx context.Context) string {
 	return span.SpanContext().SpanID().String()
 }
 
+// traceContextPropagator carries W3C trace context and baggage across process
+// boundaries. It is used directly rather than through the global propagator
+// so edges and plugin runners can join a trace even when they do not export
+// spans themselves.
+var traceContextPropagator = propagation.NewCompositeTextMapPropagator(
+	propagation.TraceContext{},
+	propagation.Baggage{},
+)
+
+// InjectTraceContext writes the W3C traceparent, tracestate, and baggage of
+// the span in ctx into carrier. Nothing is written when ctx has no valid
+// span.
+func InjectTraceContext(ctx context.Context, carrier map[string]string) {
+	if carrier == nil {
+		return
+	}
+	traceContextPropagator.Inject(ctx, MapCarrier(carrier))
+}
+
+// ExtractTraceContext returns ctx with the remote span described by the W3C
+// trace context in carrier, so spans started from it join the caller's
+// trace as children.
+func ExtractTraceContext(ctx context.Context, carrier map[string]string) context.Context {
+	if len(carrier) == 0 {
+		return ctx
+	}
+	return traceContextPropagator.Extract(ctx, MapCarrier(carrier))
+}
+
 // MapCarrier is a simple map-based carrier for context propagation.
 type MapCarrier map[string]string
 
@@ -302,6 +302,35 @@ func TestInjectExtractContext(t *testing.T) {
 	}
 }
 
+func TestInjectExtractTraceContext(t *testing.T) {
+	traceID, _ := trace.TraceIDFromHex("4bf92f3577b34da6a3ce929d0e0e4736")
+	spanID, _ := trace.SpanIDFromHex("00f067aa0ba902b7")
+	parent := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    traceID,
+		SpanID:     spanID,
+		TraceFlags: trace.FlagsSampled,
+	})
+	ctx := trace.ContextWithSpanContext(context.Background(), parent)
+
+	carrier := map[string]string{"tool": "echo"}
+	InjectTraceContext(ctx, carrier)
+	if got := carrier["traceparent"]; got != "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01" {
+		t.Fatalf("traceparent = %q", got)
+	}
+
+	extracted := trace.SpanContextFromContext(ExtractTraceContext(context.Background(), carrier))
+	if !extracted.IsRemote() || extracted.TraceID() != traceID || extracted.SpanID() != spanID {
+		t.Fatalf("extracted span context = %+v", extracted)
+	}
+
+	empty := map[string]string{}
+	InjectTraceContext(context.Background(), empty)
+	if len(empty) != 0 {
+		t.Fatalf("expected no trace context without a span, got %v", empty)
+	}
+	InjectTraceContext(ctx, nil)
+}
+
 func TestSpanFromContext(t *testing.T) {
 	tracer, shutdown := NewTracer(TraceConfig{
 		ServiceName: "test-service",
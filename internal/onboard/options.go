@@ -18,6 +18,7 @@ type Options struct {
 	JWTSecret      string
 	Provider       string
 	ProviderKey    string
+	Model          string
 	EnableTelegram bool
 	TelegramToken  string
 	EnableDiscord  bool
@@ -30,6 +31,9 @@ type Options struct {
 	WorkspacePath  string
 }
 
+// DefaultDatabaseURL is used when no database URL is given.
+const DefaultDatabaseURL = "postgres://root@localhost:26257/nexus?sslmode=disable"
+
 // BuildConfig builds a config map from options.
 func BuildConfig(opts Options) map[string]any {
 	provider := normalizeProvider(opts.Provider)
@@ -44,7 +48,7 @@ func BuildConfig(opts Options) map[string]any {
 
 	databaseURL := opts.DatabaseURL
 	if strings.TrimSpace(databaseURL) == "" {
-		databaseURL = "postgres://root@localhost:26257/nexus?sslmode=disable"
+		databaseURL = DefaultDatabaseURL
 	}
 
 	cfg := map[string]any{
@@ -69,9 +73,7 @@ func BuildConfig(opts Options) map[string]any {
 		"llm": map[string]any{
 			"default_provider": provider,
 			"providers": map[string]any{
-				provider: map[string]any{
-					"api_key": opts.ProviderKey,
-				},
+				provider: providerEntry(opts),
 			},
 		},
 		"channels": map[string]any{
@@ -103,6 +105,14 @@ func BuildConfig(opts Options) map[string]any {
 	return cfg
 }
 
+func providerEntry(opts Options) map[string]any {
+	entry := map[string]any{"api_key": opts.ProviderKey}
+	if model := strings.TrimSpace(opts.Model); model != "" {
+		entry["default_model"] = model
+	}
+	return entry
+}
+
 // GenerateJWTSecret returns a base64-encoded random secret.
 func GenerateJWTSecret() string {
 	buf := make([]byte, 32)
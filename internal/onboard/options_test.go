@@ -28,3 +28,17 @@ func TestApplyAuthConfigSetsProvider(t *testing.T) {
 		t.Fatalf("expected api key")
 	}
 }
+
+func TestBuildConfigSetsDefaultModel(t *testing.T) {
+	cfg := BuildConfig(Options{Provider: "anthropic", ProviderKey: "key", Model: "claude-sonnet-4"})
+	entry := cfg["llm"].(map[string]any)["providers"].(map[string]any)["anthropic"].(map[string]any)
+	if entry["default_model"] != "claude-sonnet-4" {
+		t.Fatalf("expected default_model, got %v", entry["default_model"])
+	}
+
+	cfg = BuildConfig(Options{Provider: "anthropic", ProviderKey: "key"})
+	entry = cfg["llm"].(map[string]any)["providers"].(map[string]any)["anthropic"].(map[string]any)
+	if _, ok := entry["default_model"]; ok {
+		t.Fatalf("expected no default_model without a model")
+	}
+}
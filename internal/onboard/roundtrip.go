@@ -0,0 +1,195 @@
+package onboard
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/haasonsaas/nexus/internal/agent"
+	"github.com/haasonsaas/nexus/internal/agent/providers"
+)
+
+// TestPrompt is sent to the model during the onboarding round trip.
+const TestPrompt = "You are being connected to Nexus. Reply with one short, friendly sentence confirming you can hear me."
+
+// NewProvider builds an LLM provider for the onboarding round trip.
+func NewProvider(provider, apiKey, model string) (agent.LLMProvider, error) {
+	switch normalizeProvider(provider) {
+	case "anthropic":
+		return providers.NewAnthropicProvider(providers.AnthropicConfig{APIKey: apiKey, DefaultModel: model})
+	case "openai":
+		return providers.NewOpenAIProviderWithConfig(providers.OpenAIConfig{APIKey: apiKey}), nil
+	case "google":
+		return providers.NewGoogleProvider(providers.GoogleConfig{APIKey: apiKey, DefaultModel: model})
+	case "openrouter":
+		return providers.NewOpenRouterProvider(providers.OpenRouterConfig{APIKey: apiKey, DefaultModel: model})
+	default:
+		return nil, fmt.Errorf("unsupported provider %q", provider)
+	}
+}
+
+// Complete sends prompt to the model and returns the full reply text.
+func Complete(ctx context.Context, llm agent.LLMProvider, model, prompt string) (string, error) {
+	chunks, err := llm.Complete(ctx, &agent.CompletionRequest{
+		Model:     model,
+		Messages:  []agent.CompletionMessage{{Role: "user", Content: prompt}},
+		MaxTokens: 200,
+	})
+	if err != nil {
+		return "", err
+	}
+	var reply strings.Builder
+	for chunk := range chunks {
+		if chunk.Error != nil {
+			return "", chunk.Error
+		}
+		reply.WriteString(chunk.Text)
+	}
+	text := strings.TrimSpace(reply.String())
+	if text == "" {
+		return "", errors.New("model returned an empty reply")
+	}
+	return text, nil
+}
+
+// InboundMessage is a message received from a channel during the round trip.
+type InboundMessage struct {
+	ChatID string
+	From   string
+	Text   string
+}
+
+// WaitForTelegramMessage long-polls the bot for the next message sent to it
+// and returns it. Updates that arrived before the call are skipped. The
+// call fails if the bot has a webhook configured, since Telegram then
+// refuses getUpdates.
+func (v *Validator) WaitForTelegramMessage(ctx context.Context, token string) (InboundMessage, error) {
+	type update struct {
+		UpdateID int64 `json:"update_id"`
+		Message  *struct {
+			Text string `json:"text"`
+			Chat struct {
+				ID int64 `json:"id"`
+			} `json:"chat"`
+			From struct {
+				Username  string `json:"username"`
+				FirstName string `json:"first_name"`
+			} `json:"from"`
+		} `json:"message"`
+	}
+	poll := func(offset int64, timeout int) ([]update, error) {
+		query := url.Values{}
+		query.Set("offset", strconv.FormatInt(offset, 10))
+		query.Set("timeout", strconv.Itoa(timeout))
+		query.Set("allowed_updates", `["message"]`)
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, v.telegramURL(token, "getUpdates")+"?"+query.Encode(), nil)
+		if err != nil {
+			return nil, err
+		}
+		var resp struct {
+			OK          bool     `json:"ok"`
+			Result      []update `json:"result"`
+			Description string   `json:"description"`
+		}
+		if err := v.do(req, &resp); err != nil {
+			return nil, err
+		}
+		if !resp.OK {
+			return nil, errors.New(resp.Description)
+		}
+		return resp.Result, nil
+	}
+
+	// offset -1 returns only the newest pending update.
+	pending, err := poll(-1, 0)
+	if err != nil {
+		return InboundMessage{}, err
+	}
+	var offset int64
+	if len(pending) > 0 {
+		offset = pending[len(pending)-1].UpdateID + 1
+	}
+	for {
+		updates, err := poll(offset, 25)
+		if err != nil {
+			if ctx.Err() != nil {
+				return InboundMessage{}, ctx.Err()
+			}
+			return InboundMessage{}, err
+		}
+		for _, u := range updates {
+			offset = u.UpdateID + 1
+			if u.Message == nil || strings.TrimSpace(u.Message.Text) == "" {
+				continue
+			}
+			from := u.Message.From.Username
+			if from == "" {
+				from = u.Message.From.FirstName
+			}
+			return InboundMessage{
+				ChatID: strconv.FormatInt(u.Message.Chat.ID, 10),
+				From:   from,
+				Text:   u.Message.Text,
+			}, nil
+		}
+	}
+}
+
+// SendChannelMessage posts text to a chat or channel using the bot token.
+func (v *Validator) SendChannelMessage(ctx context.Context, channel, token, target, text string) error {
+	if strings.TrimSpace(target) == "" {
+		return errors.New("destination is required")
+	}
+	var (
+		endpoint string
+		payload  map[string]any
+		header   = http.Header{}
+	)
+	switch channel {
+	case "telegram":
+		endpoint = v.telegramURL(token, "sendMessage")
+		payload = map[string]any{"chat_id": target, "text": text}
+	case "discord":
+		endpoint = v.endpoint("discord") + "/channels/" + url.PathEscape(target) + "/messages"
+		payload = map[string]any{"content": text}
+		header.Set("Authorization", "Bot "+token)
+	case "slack":
+		endpoint = v.endpoint("slack") + "/chat.postMessage"
+		payload = map[string]any{"channel": target, "text": text}
+		header.Set("Authorization", "Bearer "+token)
+	default:
+		return fmt.Errorf("unsupported channel %q", channel)
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header = header
+	req.Header.Set("Content-Type", "application/json; charset=utf-8")
+	var resp struct {
+		OK          *bool  `json:"ok"`
+		Error       string `json:"error"`
+		Description string `json:"description"`
+	}
+	if err := v.do(req, &resp); err != nil {
+		return err
+	}
+	// Telegram and Slack report failures in the body with a 200 status.
+	if resp.OK != nil && !*resp.OK {
+		if resp.Error != "" {
+			return errors.New(resp.Error)
+		}
+		return errors.New(resp.Description)
+	}
+	return nil
+}
@@ -0,0 +1,416 @@
+package onboard
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrInvalidCredentials is returned when an API rejects a key or token.
+var ErrInvalidCredentials = errors.New("credentials rejected")
+
+// ErrQuotaExceeded is returned when an API answers 429 for a key.
+var ErrQuotaExceeded = errors.New("rate limited or out of quota")
+
+// defaultEndpoints are the API base URLs used for live validation.
+var defaultEndpoints = map[string]string{
+	"anthropic":  "https://api.anthropic.com",
+	"openai":     "https://api.openai.com",
+	"google":     "https://generativelanguage.googleapis.com",
+	"openrouter": "https://openrouter.ai/api",
+	"telegram":   "https://api.telegram.org",
+	"discord":    "https://discord.com/api/v10",
+	"slack":      "https://slack.com/api",
+}
+
+// Providers lists the LLM providers offered during onboarding.
+var Providers = []string{"anthropic", "openai", "google", "openrouter"}
+
+// Channels lists the channels that can be configured during onboarding.
+var Channels = []string{"telegram", "discord", "slack"}
+
+// Validator checks credentials with test calls against the live provider and
+// channel APIs.
+type Validator struct {
+	Client *http.Client
+
+	// Endpoints overrides API base URLs by provider or channel name.
+	Endpoints map[string]string
+}
+
+// NewValidator returns a validator using the public API endpoints.
+func NewValidator() *Validator {
+	return &Validator{Client: &http.Client{Timeout: 30 * time.Second}}
+}
+
+func (v *Validator) endpoint(service string) string {
+	if v.Endpoints != nil {
+		if base := strings.TrimSpace(v.Endpoints[service]); base != "" {
+			return strings.TrimRight(base, "/")
+		}
+	}
+	return defaultEndpoints[service]
+}
+
+func (v *Validator) client() *http.Client {
+	if v.Client != nil {
+		return v.Client
+	}
+	return http.DefaultClient
+}
+
+// statusError reports a non-2xx API response.
+type statusError struct {
+	Code int
+	Msg  string
+}
+
+func (e *statusError) Error() string {
+	return e.Msg
+}
+
+// do sends a request and decodes a JSON response into out. 401 and 403
+// responses are reported as ErrInvalidCredentials.
+func (v *Validator) do(req *http.Request, out any) error {
+	_, err := v.doHeaders(req, out)
+	return err
+}
+
+// doHeaders is like do but also returns the response headers.
+func (v *Validator) doHeaders(req *http.Request, out any) (http.Header, error) {
+	resp, err := v.client().Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 8<<20))
+	if err != nil {
+		return resp.Header, err
+	}
+	switch {
+	case resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden:
+		return resp.Header, fmt.Errorf("%w (%s)", ErrInvalidCredentials, resp.Status)
+	case resp.StatusCode == http.StatusTooManyRequests:
+		return resp.Header, fmt.Errorf("%w: %s", ErrQuotaExceeded, truncate(strings.TrimSpace(string(body)), 200))
+	case resp.StatusCode >= 300:
+		return resp.Header, &statusError{
+			Code: resp.StatusCode,
+			Msg:  fmt.Sprintf("unexpected status %s: %s", resp.Status, truncate(strings.TrimSpace(string(body)), 200)),
+		}
+	}
+	if out == nil {
+		return resp.Header, nil
+	}
+	if err := json.Unmarshal(body, out); err != nil {
+		return resp.Header, fmt.Errorf("decode response: %w", err)
+	}
+	return resp.Header, nil
+}
+
+// ProviderModels validates apiKey by listing the provider's models. The
+// returned IDs are the chat models the key can use.
+func (v *Validator) ProviderModels(ctx context.Context, provider, apiKey string) ([]string, error) {
+	provider = normalizeProvider(provider)
+	if strings.TrimSpace(apiKey) == "" {
+		return nil, errors.New("api key is required")
+	}
+	base := v.endpoint(provider)
+	if base == "" {
+		return nil, fmt.Errorf("unsupported provider %q", provider)
+	}
+
+	var listURL string
+	header := http.Header{}
+	switch provider {
+	case "anthropic":
+		listURL = base + "/v1/models?limit=100"
+		header.Set("x-api-key", apiKey)
+		header.Set("anthropic-version", "2023-06-01")
+	case "openai":
+		listURL = base + "/v1/models"
+		header.Set("Authorization", "Bearer "+apiKey)
+	case "google":
+		listURL = base + "/v1beta/models?pageSize=1000&key=" + url.QueryEscape(apiKey)
+	case "openrouter":
+		// The model list is public, so check the key separately.
+		header.Set("Authorization", "Bearer "+apiKey)
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, base+"/v1/key", nil)
+		if err != nil {
+			return nil, err
+		}
+		req.Header = header.Clone()
+		if err := v.do(req, nil); err != nil {
+			return nil, err
+		}
+		listURL = base + "/v1/models"
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, listURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header = header
+	var payload struct {
+		Data []struct {
+			ID string `json:"id"`
+		} `json:"data"`
+		Models []struct {
+			Name    string   `json:"name"`
+			Methods []string `json:"supportedGenerationMethods"`
+		} `json:"models"`
+	}
+	if err := v.do(req, &payload); err != nil {
+		return nil, err
+	}
+
+	var ids []string
+	for _, model := range payload.Data {
+		if provider == "openai" && !openAIChatModel(model.ID) {
+			continue
+		}
+		ids = append(ids, model.ID)
+	}
+	for _, model := range payload.Models {
+		for _, method := range model.Methods {
+			if method == "generateContent" {
+				ids = append(ids, strings.TrimPrefix(model.Name, "models/"))
+				break
+			}
+		}
+	}
+	if provider == "openai" {
+		// The OpenAI list is unordered; newest names sort last.
+		sort.Sort(sort.Reverse(sort.StringSlice(ids)))
+	}
+	if len(ids) == 0 {
+		return nil, errors.New("no chat models available for this key")
+	}
+	return ids, nil
+}
+
+// Quota is the remaining allowance an API reported for a key. A zero Limit
+// means the API did not report one.
+type Quota struct {
+	Remaining float64 `json:"remaining"`
+	Limit     float64 `json:"limit"`
+	// Unit names what is counted: "requests", "tokens", or "credits".
+	Unit string `json:"unit,omitempty"`
+}
+
+// Known reports whether the API returned quota information.
+func (q Quota) Known() bool {
+	return q.Limit > 0
+}
+
+// Fraction returns the remaining share of the limit, or 1 when unknown.
+func (q Quota) Fraction() float64 {
+	if !q.Known() {
+		return 1
+	}
+	return q.Remaining / q.Limit
+}
+
+// CheckProvider validates apiKey with the cheapest authenticated call the
+// provider offers and returns any quota the response reported.
+func (v *Validator) CheckProvider(ctx context.Context, provider, apiKey string) (Quota, error) {
+	provider = normalizeProvider(provider)
+	if strings.TrimSpace(apiKey) == "" {
+		return Quota{}, errors.New("api key is required")
+	}
+	base := v.endpoint(provider)
+	if base == "" {
+		return Quota{}, fmt.Errorf("unsupported provider %q", provider)
+	}
+
+	var checkURL string
+	header := http.Header{}
+	switch provider {
+	case "anthropic":
+		checkURL = base + "/v1/models?limit=1"
+		header.Set("x-api-key", apiKey)
+		header.Set("anthropic-version", "2023-06-01")
+	case "openai":
+		checkURL = base + "/v1/models"
+		header.Set("Authorization", "Bearer "+apiKey)
+	case "google":
+		checkURL = base + "/v1beta/models?pageSize=1&key=" + url.QueryEscape(apiKey)
+	case "openrouter":
+		checkURL = base + "/v1/key"
+		header.Set("Authorization", "Bearer "+apiKey)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, checkURL, nil)
+	if err != nil {
+		return Quota{}, err
+	}
+	req.Header = header
+	var payload struct {
+		Data struct {
+			Limit          *float64 `json:"limit"`
+			LimitRemaining *float64 `json:"limit_remaining"`
+		} `json:"data"`
+	}
+	var out any
+	if provider == "openrouter" {
+		out = &payload
+	}
+	respHeader, err := v.doHeaders(req, out)
+	if err != nil {
+		return Quota{}, err
+	}
+	if provider == "openrouter" {
+		if payload.Data.Limit != nil && payload.Data.LimitRemaining != nil {
+			return Quota{Remaining: *payload.Data.LimitRemaining, Limit: *payload.Data.Limit, Unit: "credits"}, nil
+		}
+		return Quota{}, nil
+	}
+	return quotaFromHeaders(respHeader), nil
+}
+
+// rateLimitHeaders lists remaining/limit header pairs by unit, covering the
+// Anthropic and OpenAI naming schemes.
+var rateLimitHeaders = []struct {
+	unit, remaining, limit string
+}{
+	{"requests", "anthropic-ratelimit-requests-remaining", "anthropic-ratelimit-requests-limit"},
+	{"tokens", "anthropic-ratelimit-tokens-remaining", "anthropic-ratelimit-tokens-limit"},
+	{"requests", "x-ratelimit-remaining-requests", "x-ratelimit-limit-requests"},
+	{"tokens", "x-ratelimit-remaining-tokens", "x-ratelimit-limit-tokens"},
+}
+
+// quotaFromHeaders returns the tightest rate limit reported in header.
+func quotaFromHeaders(header http.Header) Quota {
+	var tightest Quota
+	for _, pair := range rateLimitHeaders {
+		remaining, err1 := strconv.ParseFloat(header.Get(pair.remaining), 64)
+		limit, err2 := strconv.ParseFloat(header.Get(pair.limit), 64)
+		if err1 != nil || err2 != nil || limit <= 0 {
+			continue
+		}
+		quota := Quota{Remaining: remaining, Limit: limit, Unit: pair.unit}
+		if !tightest.Known() || quota.Fraction() < tightest.Fraction() {
+			tightest = quota
+		}
+	}
+	return tightest
+}
+
+// openAIChatModel filters embeddings, audio, and image models out of the
+// OpenAI model list.
+func openAIChatModel(id string) bool {
+	if !strings.HasPrefix(id, "gpt-") && !strings.HasPrefix(id, "chatgpt-") &&
+		!(len(id) > 1 && id[0] == 'o' && id[1] >= '0' && id[1] <= '9') {
+		return false
+	}
+	for _, exclude := range []string{"audio", "realtime", "transcribe", "tts", "image", "search", "instruct"} {
+		if strings.Contains(id, exclude) {
+			return false
+		}
+	}
+	return true
+}
+
+// ChannelIdentity describes the bot account behind channel credentials.
+type ChannelIdentity struct {
+	ID       string
+	Username string
+	Team     string
+}
+
+// String formats the identity for display.
+func (c ChannelIdentity) String() string {
+	name := "@" + c.Username
+	if c.Team != "" {
+		name += " in " + c.Team
+	}
+	return name
+}
+
+// ValidateChannel checks a channel bot token and returns the bot identity.
+func (v *Validator) ValidateChannel(ctx context.Context, channel, token string) (ChannelIdentity, error) {
+	if strings.TrimSpace(token) == "" {
+		return ChannelIdentity{}, errors.New("token is required")
+	}
+	switch channel {
+	case "telegram":
+		var resp struct {
+			OK     bool `json:"ok"`
+			Result struct {
+				ID       int64  `json:"id"`
+				Username string `json:"username"`
+			} `json:"result"`
+			Description string `json:"description"`
+		}
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, v.telegramURL(token, "getMe"), nil)
+		if err != nil {
+			return ChannelIdentity{}, err
+		}
+		if err := v.do(req, &resp); err != nil {
+			var statusErr *statusError
+			if errors.As(err, &statusErr) && statusErr.Code == http.StatusNotFound {
+				// Telegram answers malformed tokens with 404.
+				return ChannelIdentity{}, fmt.Errorf("%w (unknown bot token)", ErrInvalidCredentials)
+			}
+			return ChannelIdentity{}, err
+		}
+		if !resp.OK {
+			return ChannelIdentity{}, fmt.Errorf("%w (%s)", ErrInvalidCredentials, resp.Description)
+		}
+		return ChannelIdentity{ID: fmt.Sprint(resp.Result.ID), Username: resp.Result.Username}, nil
+	case "discord":
+		var resp struct {
+			ID       string `json:"id"`
+			Username string `json:"username"`
+		}
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, v.endpoint("discord")+"/users/@me", nil)
+		if err != nil {
+			return ChannelIdentity{}, err
+		}
+		req.Header.Set("Authorization", "Bot "+token)
+		if err := v.do(req, &resp); err != nil {
+			return ChannelIdentity{}, err
+		}
+		return ChannelIdentity{ID: resp.ID, Username: resp.Username}, nil
+	case "slack":
+		var resp struct {
+			OK     bool   `json:"ok"`
+			Error  string `json:"error"`
+			UserID string `json:"user_id"`
+			User   string `json:"user"`
+			Team   string `json:"team"`
+		}
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, v.endpoint("slack")+"/auth.test", nil)
+		if err != nil {
+			return ChannelIdentity{}, err
+		}
+		req.Header.Set("Authorization", "Bearer "+token)
+		if err := v.do(req, &resp); err != nil {
+			return ChannelIdentity{}, err
+		}
+		if !resp.OK {
+			return ChannelIdentity{}, fmt.Errorf("%w (%s)", ErrInvalidCredentials, resp.Error)
+		}
+		return ChannelIdentity{ID: resp.UserID, Username: resp.User, Team: resp.Team}, nil
+	default:
+		return ChannelIdentity{}, fmt.Errorf("unsupported channel %q", channel)
+	}
+}
+
+func (v *Validator) telegramURL(token, method string) string {
+	return v.endpoint("telegram") + "/bot" + token + "/" + method
+}
+
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	return s[:n] + "..."
+}
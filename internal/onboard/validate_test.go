@@ -0,0 +1,203 @@
+package onboard
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func newTestValidator(t *testing.T, service string, handler http.HandlerFunc) *Validator {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	return &Validator{Client: server.Client(), Endpoints: map[string]string{service: server.URL}}
+}
+
+func TestProviderModelsAnthropic(t *testing.T) {
+	v := newTestValidator(t, "anthropic", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/models" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		if r.Header.Get("x-api-key") != "good" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Write([]byte(`{"data":[{"id":"claude-opus-4"},{"id":"claude-sonnet-4"}]}`))
+	})
+
+	models, err := v.ProviderModels(context.Background(), "anthropic", "good")
+	if err != nil {
+		t.Fatalf("ProviderModels() error = %v", err)
+	}
+	if want := []string{"claude-opus-4", "claude-sonnet-4"}; !reflect.DeepEqual(models, want) {
+		t.Fatalf("models = %v, want %v", models, want)
+	}
+
+	if _, err := v.ProviderModels(context.Background(), "anthropic", "bad"); !errors.Is(err, ErrInvalidCredentials) {
+		t.Fatalf("expected ErrInvalidCredentials, got %v", err)
+	}
+}
+
+func TestProviderModelsOpenAIFiltersNonChat(t *testing.T) {
+	v := newTestValidator(t, "openai", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"data":[{"id":"gpt-4o"},{"id":"text-embedding-3-small"},{"id":"gpt-4o-realtime-preview"},{"id":"o3-mini"},{"id":"whisper-1"}]}`))
+	})
+
+	models, err := v.ProviderModels(context.Background(), "openai", "key")
+	if err != nil {
+		t.Fatalf("ProviderModels() error = %v", err)
+	}
+	if want := []string{"o3-mini", "gpt-4o"}; !reflect.DeepEqual(models, want) {
+		t.Fatalf("models = %v, want %v", models, want)
+	}
+}
+
+func TestProviderModelsGoogle(t *testing.T) {
+	v := newTestValidator(t, "google", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("key") != "key" {
+			t.Errorf("expected key query parameter")
+		}
+		w.Write([]byte(`{"models":[{"name":"models/gemini-2.0-flash","supportedGenerationMethods":["generateContent"]},{"name":"models/embedding-001","supportedGenerationMethods":["embedContent"]}]}`))
+	})
+
+	models, err := v.ProviderModels(context.Background(), "google", "key")
+	if err != nil {
+		t.Fatalf("ProviderModels() error = %v", err)
+	}
+	if want := []string{"gemini-2.0-flash"}; !reflect.DeepEqual(models, want) {
+		t.Fatalf("models = %v, want %v", models, want)
+	}
+}
+
+func TestCheckProviderQuotaHeaders(t *testing.T) {
+	v := newTestValidator(t, "openai", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer good" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Header().Set("x-ratelimit-limit-requests", "500")
+		w.Header().Set("x-ratelimit-remaining-requests", "400")
+		w.Header().Set("x-ratelimit-limit-tokens", "10000")
+		w.Header().Set("x-ratelimit-remaining-tokens", "500")
+		w.Write([]byte(`{"data":[]}`))
+	})
+
+	quota, err := v.CheckProvider(context.Background(), "openai", "good")
+	if err != nil {
+		t.Fatalf("CheckProvider() error = %v", err)
+	}
+	if quota.Unit != "tokens" || quota.Fraction() != 0.05 {
+		t.Fatalf("expected tightest token quota, got %+v", quota)
+	}
+
+	if _, err := v.CheckProvider(context.Background(), "openai", "bad"); !errors.Is(err, ErrInvalidCredentials) {
+		t.Fatalf("expected ErrInvalidCredentials, got %v", err)
+	}
+}
+
+func TestCheckProviderOpenRouterCredits(t *testing.T) {
+	v := newTestValidator(t, "openrouter", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/key" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		w.Write([]byte(`{"data":{"limit":20,"limit_remaining":1.5,"usage":18.5}}`))
+	})
+
+	quota, err := v.CheckProvider(context.Background(), "openrouter", "key")
+	if err != nil {
+		t.Fatalf("CheckProvider() error = %v", err)
+	}
+	if quota.Unit != "credits" || quota.Remaining != 1.5 || quota.Limit != 20 {
+		t.Fatalf("unexpected quota %+v", quota)
+	}
+}
+
+func TestCheckProviderRateLimited(t *testing.T) {
+	v := newTestValidator(t, "anthropic", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTooManyRequests)
+	})
+	if _, err := v.CheckProvider(context.Background(), "anthropic", "key"); !errors.Is(err, ErrQuotaExceeded) {
+		t.Fatalf("expected ErrQuotaExceeded, got %v", err)
+	}
+}
+
+func TestValidateChannelTelegram(t *testing.T) {
+	v := newTestValidator(t, "telegram", func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/bot123:abc/getMe":
+			w.Write([]byte(`{"ok":true,"result":{"id":123,"username":"nexus_bot"}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+
+	identity, err := v.ValidateChannel(context.Background(), "telegram", "123:abc")
+	if err != nil {
+		t.Fatalf("ValidateChannel() error = %v", err)
+	}
+	if identity.Username != "nexus_bot" || identity.ID != "123" {
+		t.Fatalf("unexpected identity %+v", identity)
+	}
+
+	if _, err := v.ValidateChannel(context.Background(), "telegram", "bogus"); !errors.Is(err, ErrInvalidCredentials) {
+		t.Fatalf("expected ErrInvalidCredentials, got %v", err)
+	}
+}
+
+func TestValidateChannelSlack(t *testing.T) {
+	v := newTestValidator(t, "slack", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") == "Bearer xoxb-good" {
+			w.Write([]byte(`{"ok":true,"user_id":"U1","user":"nexus","team":"Acme"}`))
+			return
+		}
+		w.Write([]byte(`{"ok":false,"error":"invalid_auth"}`))
+	})
+
+	identity, err := v.ValidateChannel(context.Background(), "slack", "xoxb-good")
+	if err != nil {
+		t.Fatalf("ValidateChannel() error = %v", err)
+	}
+	if got := identity.String(); got != "@nexus in Acme" {
+		t.Fatalf("identity = %q", got)
+	}
+
+	if _, err := v.ValidateChannel(context.Background(), "slack", "xoxb-bad"); !errors.Is(err, ErrInvalidCredentials) {
+		t.Fatalf("expected ErrInvalidCredentials, got %v", err)
+	}
+}
+
+func TestSendChannelMessage(t *testing.T) {
+	var got map[string]any
+	v := newTestValidator(t, "discord", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/channels/42/messages" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		if r.Header.Get("Authorization") != "Bot token" {
+			t.Errorf("unexpected auth header %q", r.Header.Get("Authorization"))
+		}
+		json.NewDecoder(r.Body).Decode(&got)
+		w.Write([]byte(`{"id":"1"}`))
+	})
+
+	if err := v.SendChannelMessage(context.Background(), "discord", "token", "42", "hello"); err != nil {
+		t.Fatalf("SendChannelMessage() error = %v", err)
+	}
+	if got["content"] != "hello" {
+		t.Fatalf("unexpected payload %v", got)
+	}
+}
+
+func TestSendChannelMessageSlackError(t *testing.T) {
+	v := newTestValidator(t, "slack", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"ok":false,"error":"not_in_channel"}`))
+	})
+
+	err := v.SendChannelMessage(context.Background(), "slack", "xoxb", "C1", "hello")
+	if err == nil || err.Error() != "not_in_channel" {
+		t.Fatalf("expected not_in_channel error, got %v", err)
+	}
+}
@@ -0,0 +1,636 @@
+package onboard
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/spinner"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/haasonsaas/nexus/internal/agent"
+)
+
+// ErrAborted is returned when the user quits the wizard.
+var ErrAborted = errors.New("onboarding aborted")
+
+// skipChannel is the channel choice that configures no channel.
+const skipChannel = "skip for now"
+
+// modelListHeight is the number of models shown at once.
+const modelListHeight = 10
+
+// Wizard runs the interactive onboarding flow: it collects settings,
+// validates each credential with a live API call, and finishes with a test
+// message round trip through the configured channel.
+type Wizard struct {
+	Validator *Validator
+
+	// NewProvider builds the LLM provider used for the round trip.
+	NewProvider func(provider, apiKey, model string) (agent.LLMProvider, error)
+}
+
+// NewWizard returns a wizard that talks to the public APIs.
+func NewWizard() *Wizard {
+	return &Wizard{Validator: NewValidator(), NewProvider: NewProvider}
+}
+
+// Run shows the wizard on the terminal and fills opts. It returns
+// ErrAborted if the user quits before the end.
+func (w *Wizard) Run(ctx context.Context, opts *Options, in io.Reader, out io.Writer) error {
+	model := newWizardModel(ctx, w, opts)
+	final, err := tea.NewProgram(model, tea.WithContext(ctx), tea.WithInput(in), tea.WithOutput(out)).Run()
+	if err != nil {
+		return err
+	}
+	if m, ok := final.(*wizardModel); !ok || m.step != stepDone {
+		return ErrAborted
+	}
+	return nil
+}
+
+type wizardStep int
+
+const (
+	stepDatabase wizardStep = iota
+	stepWorkspace
+	stepProvider
+	stepProviderKey
+	stepModel
+	stepChannel
+	stepChannelFields
+	stepDestination
+	stepRoundTrip
+	stepDone
+)
+
+// channelField is one credential prompt for the selected channel. The first
+// field of each channel is the bot token that gets validated.
+type channelField struct {
+	label  string
+	value  *string
+	secret bool
+}
+
+type (
+	modelsMsg struct {
+		models []string
+		err    error
+	}
+	identityMsg struct {
+		identity ChannelIdentity
+		err      error
+	}
+	inboundMsg struct {
+		message InboundMessage
+		err     error
+	}
+	roundTripMsg struct {
+		reply string
+		err   error
+	}
+)
+
+var (
+	titleStyle  = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("12"))
+	doneStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color("10"))
+	errorStyle  = lipgloss.NewStyle().Foreground(lipgloss.Color("9"))
+	hintStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color("8"))
+	cursorStyle = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("13"))
+)
+
+type wizardModel struct {
+	ctx    context.Context
+	wizard *Wizard
+	opts   *Options
+
+	step    wizardStep
+	input   textinput.Model
+	spinner spinner.Model
+	busy    string
+	err     error
+	notes   []string
+
+	choices []string
+	cursor  int
+	filter  string
+	models  []string
+
+	channel    string
+	fields     []channelField
+	fieldIndex int
+	identity   ChannelIdentity
+	target     string
+	inbound    *InboundMessage
+	cancelWait context.CancelFunc
+}
+
+func newWizardModel(ctx context.Context, w *Wizard, opts *Options) *wizardModel {
+	input := textinput.New()
+	input.Prompt = "> "
+	input.CharLimit = 512
+	sp := spinner.New()
+	sp.Spinner = spinner.Dot
+	m := &wizardModel{ctx: ctx, wizard: w, opts: opts, input: input, spinner: sp}
+	m.enter(stepDatabase)
+	return m
+}
+
+func (m *wizardModel) Init() tea.Cmd {
+	return tea.Batch(textinput.Blink, m.spinner.Tick)
+}
+
+// enter switches to step and prepares its input.
+func (m *wizardModel) enter(step wizardStep) {
+	m.step = step
+	m.err = nil
+	m.input.Reset()
+	m.input.EchoMode = textinput.EchoNormal
+	m.input.Placeholder = ""
+	m.input.Focus()
+	m.filter = ""
+	m.cursor = 0
+
+	switch step {
+	case stepDatabase:
+		value := m.opts.DatabaseURL
+		if strings.TrimSpace(value) == "" {
+			value = DefaultDatabaseURL
+		}
+		m.input.SetValue(value)
+	case stepWorkspace:
+		m.input.SetValue(m.opts.WorkspacePath)
+		m.input.Placeholder = "optional, e.g. ~/nexus-workspace"
+	case stepProvider:
+		m.choices = Providers
+		m.cursor = indexOf(Providers, normalizeProvider(m.opts.Provider))
+	case stepProviderKey:
+		m.input.EchoMode = textinput.EchoPassword
+		m.input.SetValue(m.opts.ProviderKey)
+	case stepModel:
+		m.choices = m.models
+		m.cursor = indexOf(m.models, m.opts.Model)
+	case stepChannel:
+		m.choices = append(append([]string{}, Channels...), skipChannel)
+		m.cursor = indexOf(m.choices, m.channel)
+	case stepChannelFields:
+		m.prepareField()
+	case stepDestination:
+		if m.channel == "discord" {
+			m.input.Placeholder = "channel ID (Developer Mode > Copy Channel ID)"
+		} else {
+			m.input.Placeholder = "channel ID, e.g. C0123456789 (invite the bot first)"
+		}
+	}
+	if m.cursor < 0 {
+		m.cursor = 0
+	}
+}
+
+func (m *wizardModel) prepareField() {
+	field := m.fields[m.fieldIndex]
+	m.input.Reset()
+	m.input.SetValue(*field.value)
+	m.input.EchoMode = textinput.EchoNormal
+	if field.secret {
+		m.input.EchoMode = textinput.EchoPassword
+	}
+}
+
+func (m *wizardModel) channelFields() []channelField {
+	switch m.channel {
+	case "telegram":
+		return []channelField{{"Telegram bot token (from @BotFather)", &m.opts.TelegramToken, true}}
+	case "discord":
+		return []channelField{
+			{"Discord bot token", &m.opts.DiscordToken, true},
+			{"Discord application ID", &m.opts.DiscordAppID, false},
+		}
+	case "slack":
+		return []channelField{
+			{"Slack bot token (xoxb-...)", &m.opts.SlackBotToken, true},
+			{"Slack app token (xapp-...)", &m.opts.SlackAppToken, true},
+			{"Slack signing secret", &m.opts.SlackSecret, true},
+		}
+	}
+	return nil
+}
+
+func (m *wizardModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		if msg.Type == tea.KeyCtrlC {
+			m.stopWaiting()
+			return m, tea.Quit
+		}
+		if m.busy != "" {
+			if msg.Type == tea.KeyEsc && m.step == stepDestination {
+				m.stopWaiting()
+			}
+			return m, nil
+		}
+		return m.handleKey(msg)
+	case spinner.TickMsg:
+		var cmd tea.Cmd
+		m.spinner, cmd = m.spinner.Update(msg)
+		return m, cmd
+	case modelsMsg:
+		m.busy = ""
+		if msg.err != nil {
+			m.err = msg.err
+			return m, nil
+		}
+		m.models = msg.models
+		m.note("%s key verified (%d models)", m.opts.Provider, len(msg.models))
+		m.enter(stepModel)
+		return m, nil
+	case identityMsg:
+		m.busy = ""
+		if msg.err != nil {
+			m.err = msg.err
+			m.fieldIndex = 0
+			m.prepareField()
+			return m, nil
+		}
+		m.identity = msg.identity
+		m.note("%s connected as %s", m.channel, msg.identity)
+		m.enter(stepDestination)
+		if m.channel == "telegram" {
+			return m, m.waitForTelegram()
+		}
+		return m, nil
+	case inboundMsg:
+		m.busy = ""
+		m.cancelWait = nil
+		if msg.err != nil {
+			if errors.Is(msg.err, context.Canceled) {
+				m.err = errors.New("stopped waiting; press enter to wait again or s to skip the test")
+			} else {
+				m.err = msg.err
+			}
+			return m, nil
+		}
+		m.inbound = &msg.message
+		m.target = msg.message.ChatID
+		m.note("received %q from %s", truncate(msg.message.Text, 40), msg.message.From)
+		return m, m.startRoundTrip()
+	case roundTripMsg:
+		m.busy = ""
+		if msg.err != nil {
+			m.err = msg.err
+			return m, nil
+		}
+		m.note("round trip OK: %q", truncate(msg.reply, 60))
+		m.step = stepDone
+		return m, tea.Quit
+	}
+
+	var cmd tea.Cmd
+	m.input, cmd = m.input.Update(msg)
+	return m, cmd
+}
+
+func (m *wizardModel) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch m.step {
+	case stepProvider, stepModel, stepChannel:
+		return m.handleSelectKey(msg)
+	case stepDestination:
+		if m.channel == "telegram" {
+			switch msg.String() {
+			case "enter":
+				return m, m.waitForTelegram()
+			case "s":
+				return m.skipRoundTrip()
+			}
+			return m, nil
+		}
+	case stepRoundTrip:
+		switch msg.String() {
+		case "enter", "r":
+			return m, m.startRoundTrip()
+		case "s":
+			return m.skipRoundTrip()
+		}
+		return m, nil
+	}
+	if msg.Type != tea.KeyEnter {
+		var cmd tea.Cmd
+		m.input, cmd = m.input.Update(msg)
+		return m, cmd
+	}
+
+	value := strings.TrimSpace(m.input.Value())
+	switch m.step {
+	case stepDatabase:
+		if value == "" {
+			m.err = errors.New("database URL is required")
+			return m, nil
+		}
+		m.opts.DatabaseURL = value
+		m.note("database: %s", value)
+		m.enter(stepWorkspace)
+	case stepWorkspace:
+		m.opts.WorkspacePath = value
+		if value != "" {
+			m.note("workspace: %s", value)
+		}
+		m.enter(stepProvider)
+	case stepProviderKey:
+		m.opts.ProviderKey = value
+		m.busy = "Checking " + m.opts.Provider + " key..."
+		m.err = nil
+		return m, m.fetchModels()
+	case stepChannelFields:
+		if value == "" {
+			m.err = fmt.Errorf("%s is required", m.fields[m.fieldIndex].label)
+			return m, nil
+		}
+		*m.fields[m.fieldIndex].value = value
+		m.err = nil
+		if m.fieldIndex+1 < len(m.fields) {
+			m.fieldIndex++
+			m.prepareField()
+			return m, nil
+		}
+		m.busy = "Checking " + m.channel + " credentials..."
+		return m, m.validateChannel()
+	case stepDestination:
+		if value == "" {
+			m.err = errors.New("channel ID is required (or press ctrl+c to quit)")
+			return m, nil
+		}
+		m.target = value
+		return m, m.startRoundTrip()
+	}
+	return m, nil
+}
+
+func (m *wizardModel) handleSelectKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	visible := m.visibleChoices()
+	switch msg.Type {
+	case tea.KeyUp:
+		if m.cursor > 0 {
+			m.cursor--
+		}
+		return m, nil
+	case tea.KeyDown:
+		if m.cursor < len(visible)-1 {
+			m.cursor++
+		}
+		return m, nil
+	case tea.KeyBackspace:
+		if m.step == stepModel && m.filter != "" {
+			m.filter = m.filter[:len(m.filter)-1]
+			m.cursor = 0
+		}
+		return m, nil
+	case tea.KeyRunes:
+		if m.step == stepModel {
+			m.filter += string(msg.Runes)
+			m.cursor = 0
+			return m, nil
+		}
+		switch msg.String() {
+		case "k":
+			if m.cursor > 0 {
+				m.cursor--
+			}
+		case "j":
+			if m.cursor < len(visible)-1 {
+				m.cursor++
+			}
+		}
+		return m, nil
+	case tea.KeyEnter:
+	default:
+		return m, nil
+	}
+
+	if len(visible) == 0 {
+		m.err = errors.New("no match; clear the filter with backspace")
+		return m, nil
+	}
+	choice := visible[m.cursor]
+	switch m.step {
+	case stepProvider:
+		m.opts.Provider = choice
+		m.note("provider: %s", choice)
+		m.enter(stepProviderKey)
+	case stepModel:
+		m.opts.Model = choice
+		m.note("model: %s", choice)
+		m.enter(stepChannel)
+	case stepChannel:
+		m.opts.EnableTelegram = choice == "telegram"
+		m.opts.EnableDiscord = choice == "discord"
+		m.opts.EnableSlack = choice == "slack"
+		if choice == skipChannel {
+			m.note("no channel configured")
+			m.step = stepDone
+			return m, tea.Quit
+		}
+		m.channel = choice
+		m.fields = m.channelFields()
+		m.fieldIndex = 0
+		m.enter(stepChannelFields)
+	}
+	return m, nil
+}
+
+// visibleChoices applies the model filter.
+func (m *wizardModel) visibleChoices() []string {
+	if m.step != stepModel || m.filter == "" {
+		return m.choices
+	}
+	var out []string
+	needle := strings.ToLower(m.filter)
+	for _, choice := range m.choices {
+		if strings.Contains(strings.ToLower(choice), needle) {
+			out = append(out, choice)
+		}
+	}
+	return out
+}
+
+func (m *wizardModel) note(format string, args ...any) {
+	m.notes = append(m.notes, fmt.Sprintf(format, args...))
+}
+
+func (m *wizardModel) fetchModels() tea.Cmd {
+	provider, key := m.opts.Provider, m.opts.ProviderKey
+	return func() tea.Msg {
+		models, err := m.wizard.Validator.ProviderModels(m.ctx, provider, key)
+		return modelsMsg{models: models, err: err}
+	}
+}
+
+func (m *wizardModel) validateChannel() tea.Cmd {
+	channel, token := m.channel, *m.fields[0].value
+	return func() tea.Msg {
+		identity, err := m.wizard.Validator.ValidateChannel(m.ctx, channel, token)
+		return identityMsg{identity: identity, err: err}
+	}
+}
+
+func (m *wizardModel) waitForTelegram() tea.Cmd {
+	ctx, cancel := context.WithCancel(m.ctx)
+	m.cancelWait = cancel
+	m.err = nil
+	m.busy = fmt.Sprintf("Send any message to @%s in Telegram...", m.identity.Username)
+	token := m.opts.TelegramToken
+	return func() tea.Msg {
+		message, err := m.wizard.Validator.WaitForTelegramMessage(ctx, token)
+		return inboundMsg{message: message, err: err}
+	}
+}
+
+func (m *wizardModel) stopWaiting() {
+	if m.cancelWait != nil {
+		m.cancelWait()
+		m.cancelWait = nil
+	}
+}
+
+// startRoundTrip asks the model for a reply and delivers it to the channel.
+func (m *wizardModel) startRoundTrip() tea.Cmd {
+	m.step = stepRoundTrip
+	m.err = nil
+	m.busy = "Asking " + m.opts.Model + " and sending the reply..."
+	opts := *m.opts
+	channel, target := m.channel, m.target
+	prompt := TestPrompt
+	if m.inbound != nil {
+		prompt += "\n\nThe user said: " + m.inbound.Text
+	}
+	return func() tea.Msg {
+		llm, err := m.wizard.NewProvider(opts.Provider, opts.ProviderKey, opts.Model)
+		if err != nil {
+			return roundTripMsg{err: err}
+		}
+		reply, err := Complete(m.ctx, llm, opts.Model, prompt)
+		if err != nil {
+			return roundTripMsg{err: fmt.Errorf("model: %w", err)}
+		}
+		token := opts.TelegramToken
+		switch channel {
+		case "discord":
+			token = opts.DiscordToken
+		case "slack":
+			token = opts.SlackBotToken
+		}
+		if err := m.wizard.Validator.SendChannelMessage(m.ctx, channel, token, target, reply); err != nil {
+			return roundTripMsg{err: fmt.Errorf("%s: %w", channel, err)}
+		}
+		return roundTripMsg{reply: reply}
+	}
+}
+
+func (m *wizardModel) skipRoundTrip() (tea.Model, tea.Cmd) {
+	m.stopWaiting()
+	m.note("round trip skipped")
+	m.step = stepDone
+	return m, tea.Quit
+}
+
+func (m *wizardModel) View() string {
+	var b strings.Builder
+	b.WriteString(titleStyle.Render("Nexus onboarding") + "\n\n")
+	for _, note := range m.notes {
+		b.WriteString(doneStyle.Render("✓ "+note) + "\n")
+	}
+	if len(m.notes) > 0 {
+		b.WriteString("\n")
+	}
+	if m.step == stepDone {
+		return b.String()
+	}
+
+	switch m.step {
+	case stepDatabase:
+		b.WriteString("Database URL\n" + m.input.View() + "\n")
+	case stepWorkspace:
+		b.WriteString("Workspace path\n" + m.input.View() + "\n")
+	case stepProvider:
+		b.WriteString("LLM provider\n" + m.renderChoices() + "\n")
+	case stepProviderKey:
+		b.WriteString(m.opts.Provider + " API key\n" + m.input.View() + "\n")
+	case stepModel:
+		b.WriteString("Default model")
+		if m.filter != "" {
+			b.WriteString(hintStyle.Render("  filter: " + m.filter))
+		}
+		b.WriteString("\n" + m.renderChoices() + "\n")
+	case stepChannel:
+		b.WriteString("Channel to connect\n" + m.renderChoices() + "\n")
+	case stepChannelFields:
+		b.WriteString(m.fields[m.fieldIndex].label + "\n" + m.input.View() + "\n")
+	case stepDestination:
+		if m.channel == "telegram" {
+			b.WriteString("Test message\n")
+		} else {
+			b.WriteString(strings.ToUpper(m.channel[:1]) + m.channel[1:] + " channel for the test message\n" + m.input.View() + "\n")
+		}
+	case stepRoundTrip:
+		b.WriteString("Test message round trip\n")
+	}
+
+	if m.busy != "" {
+		b.WriteString("\n" + m.spinner.View() + " " + m.busy + "\n")
+	}
+	if m.err != nil {
+		b.WriteString("\n" + errorStyle.Render("✗ "+m.err.Error()) + "\n")
+	}
+	b.WriteString("\n" + hintStyle.Render(m.help()) + "\n")
+	return b.String()
+}
+
+func (m *wizardModel) renderChoices() string {
+	visible := m.visibleChoices()
+	start := 0
+	if m.cursor >= modelListHeight {
+		start = m.cursor - modelListHeight + 1
+	}
+	end := min(len(visible), start+modelListHeight)
+	var b strings.Builder
+	for i := start; i < end; i++ {
+		if i == m.cursor {
+			b.WriteString(cursorStyle.Render("› "+visible[i]) + "\n")
+		} else {
+			b.WriteString("  " + visible[i] + "\n")
+		}
+	}
+	if len(visible) > end {
+		b.WriteString(hintStyle.Render(fmt.Sprintf("  ... %d more", len(visible)-end)) + "\n")
+	}
+	return strings.TrimRight(b.String(), "\n")
+}
+
+func (m *wizardModel) help() string {
+	switch {
+	case m.step == stepDestination && m.channel == "telegram" && m.busy != "":
+		return "esc stop waiting • ctrl+c quit"
+	case m.step == stepDestination && m.channel == "telegram":
+		return "enter wait for a message • s skip test • ctrl+c quit"
+	case m.step == stepRoundTrip && m.busy == "":
+		return "r retry • s skip test • ctrl+c quit"
+	case m.step == stepModel:
+		return "type to filter • ↑/↓ move • enter select • ctrl+c quit"
+	case m.step == stepProvider || m.step == stepChannel:
+		return "↑/↓ move • enter select • ctrl+c quit"
+	default:
+		return "enter confirm • ctrl+c quit"
+	}
+}
+
+func indexOf(values []string, value string) int {
+	for i, candidate := range values {
+		if candidate == value {
+			return i
+		}
+	}
+	return 0
+}
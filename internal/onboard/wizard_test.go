@@ -0,0 +1,86 @@
+package onboard
+
+import (
+	"context"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func typeText(m *wizardModel, text string) {
+	for _, r := range text {
+		m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+}
+
+func TestWizardModelFilterAndSelect(t *testing.T) {
+	opts := &Options{Provider: "openai"}
+	m := newWizardModel(context.Background(), NewWizard(), opts)
+
+	m.Update(tea.KeyMsg{Type: tea.KeyEnter}) // default database URL
+	if opts.DatabaseURL != DefaultDatabaseURL {
+		t.Fatalf("DatabaseURL = %q", opts.DatabaseURL)
+	}
+	m.Update(tea.KeyMsg{Type: tea.KeyEnter}) // skip workspace
+	if m.step != stepProvider || m.visibleChoices()[m.cursor] != "openai" {
+		t.Fatalf("expected openai preselected, got step %d cursor %d", m.step, m.cursor)
+	}
+	m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if m.step != stepProviderKey {
+		t.Fatalf("expected key step, got %d", m.step)
+	}
+
+	m.Update(modelsMsg{models: []string{"gpt-4o", "gpt-4o-mini", "o3-mini"}})
+	if m.step != stepModel {
+		t.Fatalf("expected model step, got %d", m.step)
+	}
+	typeText(m, "mini")
+	m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if opts.Model != "o3-mini" {
+		t.Fatalf("Model = %q, want o3-mini", opts.Model)
+	}
+
+	if m.step != stepChannel {
+		t.Fatalf("expected channel step, got %d", m.step)
+	}
+	for m.visibleChoices()[m.cursor] != skipChannel {
+		m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	}
+	m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if m.step != stepDone {
+		t.Fatalf("expected done, got %d", m.step)
+	}
+	if opts.EnableTelegram || opts.EnableDiscord || opts.EnableSlack {
+		t.Fatalf("expected no channel enabled")
+	}
+}
+
+func TestWizardModelChannelValidationFailureRetries(t *testing.T) {
+	opts := &Options{}
+	m := newWizardModel(context.Background(), NewWizard(), opts)
+	m.channel = "discord"
+	m.fields = m.channelFields()
+	m.enter(stepChannelFields)
+
+	typeText(m, "token")
+	m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	typeText(m, "app")
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil || m.busy == "" {
+		t.Fatalf("expected validation to start")
+	}
+	if opts.DiscordToken != "token" || opts.DiscordAppID != "app" {
+		t.Fatalf("unexpected options %+v", opts)
+	}
+
+	m.Update(identityMsg{err: ErrInvalidCredentials})
+	if m.step != stepChannelFields || m.fieldIndex != 0 || m.err == nil {
+		t.Fatalf("expected to return to the token prompt with an error")
+	}
+
+	m.Update(identityMsg{identity: ChannelIdentity{Username: "nexus"}})
+	if m.step != stepDestination {
+		t.Fatalf("expected destination step, got %d", m.step)
+	}
+}
@@ -16,6 +16,7 @@ import (
 	"time"
 
 	"github.com/haasonsaas/nexus/internal/config"
+	"github.com/haasonsaas/nexus/internal/observability"
 	"github.com/haasonsaas/nexus/internal/tools/sandbox"
 	"github.com/haasonsaas/nexus/pkg/pluginsdk"
 )
@@ -139,6 +140,8 @@ type daytonaPluginRunner struct {
 	runner         *sandbox.DaytonaRunner
 	runnerPath     string
 	defaultTimeout time.Duration
+	traceEndpoint  string
+	traceInsecure  bool
 }
 
 func newDaytonaPluginRunner(cfg config.PluginIsolationConfig) (*daytonaPluginRunner, error) {
@@ -193,6 +196,8 @@ func newDaytonaPluginRunner(cfg config.PluginIsolationConfig) (*daytonaPluginRun
 		runner:         runner,
 		runnerPath:     runnerPath,
 		defaultTimeout: defaultTimeout,
+		traceEndpoint:  strings.TrimSpace(cfg.TraceEndpoint),
+		traceInsecure:  cfg.TraceInsecure,
 	}, nil
 }
 
@@ -260,6 +265,7 @@ func (r *daytonaPluginRunner) ExecTool(ctx context.Context, pluginPath string, c
 	if paramsRel != "" {
 		command += fmt.Sprintf(" --params-file %s", paramsRel)
 	}
+	command += r.traceArgs(ctx)
 
 	payload, runErr := r.runCommand(ctx, workspace, command, nil)
 
@@ -284,6 +290,26 @@ func (r *daytonaPluginRunner) ExecTool(ctx context.Context, pluginPath string, c
 	return resp.Result, nil
 }
 
+// traceArgs passes the caller's W3C trace context, and the collector the
+// runner should export its execution span to, so isolated tool calls appear
+// as children of the gateway's span.
+func (r *daytonaPluginRunner) traceArgs(ctx context.Context) string {
+	carrier := map[string]string{}
+	observability.InjectTraceContext(ctx, carrier)
+	traceparent := carrier["traceparent"]
+	if traceparent == "" {
+		return ""
+	}
+	args := " --traceparent " + traceparent
+	if r.traceEndpoint != "" {
+		args += " --otlp-endpoint " + r.traceEndpoint
+		if r.traceInsecure {
+			args += " --otlp-insecure"
+		}
+	}
+	return args
+}
+
 func (r *daytonaPluginRunner) runCommand(ctx context.Context, workspace string, command string, params *sandbox.ExecuteParams) ([]byte, error) {
 	if ctx == nil {
 		ctx = context.Background()
@@ -319,7 +345,8 @@ func hasUnsupportedIsolationCapabilities(manifest *pluginsdk.Manifest) bool {
 	return len(manifest.Channels) > 0 ||
 		len(manifest.Commands) > 0 ||
 		len(manifest.Services) > 0 ||
-		len(manifest.Hooks) > 0
+		len(manifest.Hooks) > 0 ||
+		len(manifest.Storage) > 0
 }
 
 func preparePluginWorkspace(pluginPath string, pluginID string, runnerPath string) (string, string, string, func(), error) {
@@ -27,6 +27,7 @@ const (
 	capabilityCLIPrefix     = "cli:"
 	capabilityServicePrefix = "service:"
 	capabilityHookPrefix    = "hook:"
+	capabilityStoragePrefix = "storage:"
 )
 
 type capabilityGate struct {
@@ -77,6 +78,10 @@ func hookCapability(eventType string) string {
 	return capabilityHookPrefix + strings.TrimSpace(eventType)
 }
 
+func storageCapability(name string) string {
+	return capabilityStoragePrefix + strings.TrimSpace(name)
+}
+
 func validateCLICapabilities(gate *capabilityGate, paths []string) error {
 	if gate == nil {
 		return nil
@@ -166,6 +171,10 @@ func (t *pluginTool) Schema() json.RawMessage {
 	return t.definition.Schema
 }
 
+func (t *pluginTool) Idempotent() bool {
+	return t.definition.Idempotent
+}
+
 func (t *pluginTool) Execute(ctx context.Context, params json.RawMessage) (*agent.ToolResult, error) {
 	result, err := t.handler(ctx, params)
 	if err != nil {
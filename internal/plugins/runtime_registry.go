@@ -46,6 +46,8 @@ type runtimeEntry struct {
 	servicesErr  error
 	hooksOnce    sync.Once
 	hooksErr     error
+	storageOnce  sync.Once
+	storageErr   error
 }
 
 // RuntimeRegistry manages runtime plugin loading and registration.
@@ -338,6 +340,57 @@ func (r *RuntimeRegistry) LoadHooks(cfg *config.Config, registry *hooks.Registry
 	return nil
 }
 
+// LoadStorage registers artifact stores and vector memory backends from
+// enabled runtime plugins. It must run before those backends are opened by
+// name.
+func (r *RuntimeRegistry) LoadStorage(cfg *config.Config) error {
+	if cfg == nil {
+		return nil
+	}
+	loader := runtimePluginLoaderForConfig(cfg)
+	for id, entry := range cfg.Plugins.Entries {
+		if !entry.Enabled {
+			continue
+		}
+		pluginEntry := r.ensureEntry(id, entry.Path, loader)
+		plugin, err := pluginEntry.load(entry.Path)
+		if err != nil {
+			if isIsolationUnavailable(err) {
+				continue
+			}
+			return err
+		}
+
+		// Isolated plugins run out of process and cannot provide storage.
+		storagePlugin, ok := plugin.(pluginsdk.StoragePlugin)
+		if !ok {
+			continue
+		}
+
+		pluginEntry.storageOnce.Do(func() {
+			manifest := pluginEntry.manifest
+			if manifest == nil {
+				manifest = plugin.Manifest()
+				pluginEntry.manifest = manifest
+			}
+			var allowedStorage []string
+			if manifest != nil {
+				allowedStorage = manifest.Storage
+			}
+			api := &runtimeStorageRegistry{
+				pluginID:     id,
+				allowed:      allowSet(allowedStorage),
+				capabilities: newCapabilityGate(id, manifest),
+			}
+			pluginEntry.storageErr = storagePlugin.RegisterStorage(api, normalizeConfig(entry.Config))
+		})
+		if pluginEntry.storageErr != nil {
+			return fmt.Errorf("plugin %q storage registration: %w", id, pluginEntry.storageErr)
+		}
+	}
+	return nil
+}
+
 // LoadFullPlugins loads plugins implementing the FullPlugin interface with unified API.
 func (r *RuntimeRegistry) LoadFullPlugins(cfg *config.Config, api *PluginAPIBuilder) error {
 	if cfg == nil || api == nil {
@@ -402,12 +455,14 @@ func (b *PluginAPIBuilder) Build(pluginID string, cfg map[string]any, manifest *
 	var allowedCommands []string
 	var allowedServices []string
 	var allowedHooks []string
+	var allowedStorage []string
 	if manifest != nil {
 		allowedChannels = manifest.Channels
 		allowedTools = manifest.Tools
 		allowedCommands = manifest.Commands
 		allowedServices = manifest.Services
 		allowedHooks = manifest.Hooks
+		allowedStorage = manifest.Storage
 	}
 
 	capabilities := newCapabilityGate(pluginID, manifest)
@@ -418,6 +473,7 @@ func (b *PluginAPIBuilder) Build(pluginID string, cfg map[string]any, manifest *
 		CLI:      &runtimeCLIRegistry{rootCmd: b.RootCmd, pluginID: pluginID, allowed: allowSet(allowedCommands), capabilities: capabilities},
 		Services: &runtimeServiceRegistry{manager: b.ServiceManager, pluginID: pluginID, allowed: allowSet(allowedServices), capabilities: capabilities},
 		Hooks:    &runtimeHookRegistry{registry: b.HookRegistry, pluginID: pluginID, allowed: allowSet(allowedHooks), capabilities: capabilities},
+		Storage:  &runtimeStorageRegistry{pluginID: pluginID, allowed: allowSet(allowedStorage), capabilities: capabilities},
 		Config:   cfg,
 		Logger:   &pluginLoggerAdapter{logger: pluginLogger},
 		ResolvePath: func(path string) string {
@@ -0,0 +1,143 @@
+package plugins
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/haasonsaas/nexus/internal/artifacts"
+	"github.com/haasonsaas/nexus/internal/memory/backend"
+	"github.com/haasonsaas/nexus/pkg/models"
+	"github.com/haasonsaas/nexus/pkg/pluginsdk"
+)
+
+// runtimeStorageRegistry registers plugin storage backends with the
+// process-wide artifact store and vector backend registries.
+type runtimeStorageRegistry struct {
+	pluginID     string
+	allowed      map[string]struct{}
+	capabilities *capabilityGate
+}
+
+func (r *runtimeStorageRegistry) check(name string) error {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return fmt.Errorf("storage backend name is required")
+	}
+	if len(r.allowed) > 0 {
+		if _, ok := r.allowed[name]; !ok {
+			return fmt.Errorf("plugin %q attempted to register undeclared storage backend %q", r.pluginID, name)
+		}
+	}
+	return r.capabilities.require(storageCapability(name))
+}
+
+func (r *runtimeStorageRegistry) RegisterArtifactStore(name string, factory pluginsdk.ArtifactStoreFactory) error {
+	if err := r.check(name); err != nil {
+		return err
+	}
+	if factory == nil {
+		return fmt.Errorf("artifact store factory is nil")
+	}
+	return artifacts.RegisterStore(name, func(ctx context.Context) (artifacts.Store, error) {
+		store, err := factory(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if store == nil {
+			return nil, fmt.Errorf("plugin %q returned a nil artifact store", r.pluginID)
+		}
+		return pluginArtifactStore{store: store}, nil
+	})
+}
+
+func (r *runtimeStorageRegistry) RegisterVectorBackend(name string, factory pluginsdk.VectorBackendFactory) error {
+	if err := r.check(name); err != nil {
+		return err
+	}
+	if factory == nil {
+		return fmt.Errorf("vector backend factory is nil")
+	}
+	return backend.Register(name, func(cfg backend.Config) (backend.Backend, error) {
+		vectors, err := factory(context.Background(), pluginsdk.VectorBackendConfig{Dimension: cfg.Dimension})
+		if err != nil {
+			return nil, err
+		}
+		if vectors == nil {
+			return nil, fmt.Errorf("plugin %q returned a nil vector backend", r.pluginID)
+		}
+		return pluginVectorBackend{backend: vectors}, nil
+	})
+}
+
+// pluginArtifactStore adapts a plugin artifact store to artifacts.Store.
+type pluginArtifactStore struct {
+	store pluginsdk.ArtifactStore
+}
+
+func (p pluginArtifactStore) Put(ctx context.Context, artifactID string, data io.Reader, opts artifacts.PutOptions) (string, error) {
+	return p.store.Put(ctx, artifactID, data, pluginsdk.ArtifactPutOptions{
+		MimeType: opts.MimeType,
+		TTL:      opts.TTL,
+		Metadata: opts.Metadata,
+	})
+}
+
+func (p pluginArtifactStore) Get(ctx context.Context, artifactID string) (io.ReadCloser, error) {
+	return p.store.Get(ctx, artifactID)
+}
+
+func (p pluginArtifactStore) Delete(ctx context.Context, artifactID string) error {
+	return p.store.Delete(ctx, artifactID)
+}
+
+func (p pluginArtifactStore) Exists(ctx context.Context, artifactID string) (bool, error) {
+	return p.store.Exists(ctx, artifactID)
+}
+
+func (p pluginArtifactStore) Close() error {
+	return p.store.Close()
+}
+
+// pluginVectorBackend adapts a plugin vector backend to backend.Backend.
+type pluginVectorBackend struct {
+	backend pluginsdk.VectorBackend
+}
+
+func (p pluginVectorBackend) Index(ctx context.Context, entries []*models.MemoryEntry) error {
+	return p.backend.Index(ctx, entries)
+}
+
+func (p pluginVectorBackend) Search(ctx context.Context, embedding []float32, opts *backend.SearchOptions) ([]*models.SearchResult, error) {
+	var search pluginsdk.VectorSearchOptions
+	if opts != nil {
+		search = pluginsdk.VectorSearchOptions{
+			Scope:       opts.Scope,
+			ScopeID:     opts.ScopeID,
+			Limit:       opts.Limit,
+			Threshold:   opts.Threshold,
+			Filters:     opts.Filters,
+			Mode:        string(opts.SearchMode),
+			HybridAlpha: opts.HybridAlpha,
+			Query:       opts.Query,
+		}
+	}
+	return p.backend.Search(ctx, embedding, search)
+}
+
+func (p pluginVectorBackend) Delete(ctx context.Context, ids []string) error {
+	return p.backend.Delete(ctx, ids)
+}
+
+func (p pluginVectorBackend) Count(ctx context.Context, scope models.MemoryScope, scopeID string) (int64, error) {
+	return p.backend.Count(ctx, scope, scopeID)
+}
+
+func (p pluginVectorBackend) Compact(ctx context.Context) error {
+	return p.backend.Compact(ctx)
+}
+
+func (p pluginVectorBackend) Close() error {
+	return p.backend.Close()
+}
@@ -0,0 +1,168 @@
+package plugins
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/haasonsaas/nexus/internal/artifacts"
+	"github.com/haasonsaas/nexus/internal/config"
+	"github.com/haasonsaas/nexus/internal/memory"
+	"github.com/haasonsaas/nexus/internal/memory/backend"
+	"github.com/haasonsaas/nexus/pkg/models"
+	"github.com/haasonsaas/nexus/pkg/pluginsdk"
+)
+
+// storagePlugin registers one artifact store and one vector backend.
+type storagePlugin struct {
+	stubRuntimePlugin
+	artifactName string
+	vectorName   string
+	storageCalls int
+	lastSearch   pluginsdk.VectorSearchOptions
+}
+
+func (p *storagePlugin) RegisterStorage(registry pluginsdk.StorageRegistry, cfg map[string]any) error {
+	p.storageCalls++
+	if err := registry.RegisterArtifactStore(p.artifactName, func(ctx context.Context) (pluginsdk.ArtifactStore, error) {
+		return &memoryArtifactStore{data: map[string]string{}}, nil
+	}); err != nil {
+		return err
+	}
+	return registry.RegisterVectorBackend(p.vectorName, func(ctx context.Context, cfg pluginsdk.VectorBackendConfig) (pluginsdk.VectorBackend, error) {
+		return &recordingVectorBackend{plugin: p, dimension: cfg.Dimension}, nil
+	})
+}
+
+type memoryArtifactStore struct {
+	data map[string]string
+}
+
+func (s *memoryArtifactStore) Put(_ context.Context, id string, data io.Reader, _ pluginsdk.ArtifactPutOptions) (string, error) {
+	raw, err := io.ReadAll(data)
+	s.data[id] = string(raw)
+	return "mem://" + id, err
+}
+
+func (s *memoryArtifactStore) Get(_ context.Context, id string) (io.ReadCloser, error) {
+	return io.NopCloser(strings.NewReader(s.data[id])), nil
+}
+
+func (s *memoryArtifactStore) Delete(_ context.Context, id string) error {
+	delete(s.data, id)
+	return nil
+}
+
+func (s *memoryArtifactStore) Exists(_ context.Context, id string) (bool, error) {
+	_, ok := s.data[id]
+	return ok, nil
+}
+
+func (s *memoryArtifactStore) Close() error { return nil }
+
+type recordingVectorBackend struct {
+	plugin    *storagePlugin
+	dimension int
+}
+
+func (b *recordingVectorBackend) Index(context.Context, []*models.MemoryEntry) error { return nil }
+
+func (b *recordingVectorBackend) Search(_ context.Context, _ []float32, opts pluginsdk.VectorSearchOptions) ([]*models.SearchResult, error) {
+	b.plugin.lastSearch = opts
+	return nil, nil
+}
+
+func (b *recordingVectorBackend) Delete(context.Context, []string) error { return nil }
+
+func (b *recordingVectorBackend) Count(context.Context, models.MemoryScope, string) (int64, error) {
+	return int64(b.dimension), nil
+}
+
+func (b *recordingVectorBackend) Compact(context.Context) error { return nil }
+func (b *recordingVectorBackend) Close() error                  { return nil }
+
+func storagePluginConfig(id string) *config.Config {
+	return &config.Config{
+		Plugins: config.PluginsConfig{
+			Entries: map[string]config.PluginEntryConfig{
+				id: {Enabled: true, Config: map[string]any{}},
+			},
+		},
+	}
+}
+
+func TestRuntimeRegistryLoadsStorageBackends(t *testing.T) {
+	registry := NewRuntimeRegistry()
+	plugin := &storagePlugin{
+		stubRuntimePlugin: stubRuntimePlugin{id: "storage-plugin"},
+		artifactName:      "test-blob",
+		vectorName:        "test-vectors",
+	}
+	if err := registry.Register(plugin); err != nil {
+		t.Fatalf("Register() error = %v", err)
+	}
+	cfg := storagePluginConfig("storage-plugin")
+	for range 2 {
+		if err := registry.LoadStorage(cfg); err != nil {
+			t.Fatalf("LoadStorage() error = %v", err)
+		}
+	}
+	if plugin.storageCalls != 1 {
+		t.Fatalf("expected storage to register once, got %d", plugin.storageCalls)
+	}
+
+	factory, ok := artifacts.LookupStore("Test-Blob")
+	if !ok {
+		t.Fatal("artifact store was not registered")
+	}
+	store, err := factory(context.Background())
+	if err != nil {
+		t.Fatalf("open artifact store: %v", err)
+	}
+	ref, err := store.Put(context.Background(), "a1", strings.NewReader("png"), artifacts.PutOptions{MimeType: "image/png"})
+	if err != nil || ref != "mem://a1" {
+		t.Fatalf("Put() = %q, %v", ref, err)
+	}
+	if exists, _ := store.Exists(context.Background(), "a1"); !exists {
+		t.Fatal("stored artifact does not exist")
+	}
+
+	vectors, err := memory.NewBackend(&memory.Config{Dimension: 384}, "test-vectors")
+	if err != nil {
+		t.Fatalf("NewBackend() error = %v", err)
+	}
+	if count, _ := vectors.Count(context.Background(), models.ScopeAll, ""); count != 384 {
+		t.Fatalf("backend got dimension %d, want 384", count)
+	}
+	if _, err := vectors.Search(context.Background(), nil, &backend.SearchOptions{Limit: 3, SearchMode: backend.SearchModeHybrid, Query: "q"}); err != nil {
+		t.Fatalf("Search() error = %v", err)
+	}
+	if plugin.lastSearch.Limit != 3 || plugin.lastSearch.Mode != "hybrid" || plugin.lastSearch.Query != "q" {
+		t.Fatalf("search options = %+v", plugin.lastSearch)
+	}
+}
+
+func TestRuntimeRegistryStorageUndeclared(t *testing.T) {
+	registry := NewRuntimeRegistry()
+	plugin := &storagePlugin{
+		stubRuntimePlugin: stubRuntimePlugin{id: "storage-undeclared", manifest: &pluginsdk.Manifest{
+			ID:           "storage-undeclared",
+			ConfigSchema: json.RawMessage(`{"type":"object","properties":{}}`),
+			Storage:      []string{"declared-blob"},
+		}},
+		artifactName: "other-blob",
+		vectorName:   "other-vectors",
+	}
+	if err := registry.Register(plugin); err != nil {
+		t.Fatalf("Register() error = %v", err)
+	}
+	err := registry.LoadStorage(storagePluginConfig("storage-undeclared"))
+	if err == nil || !strings.Contains(err.Error(), `undeclared storage backend "other-blob"`) {
+		t.Fatalf("expected undeclared storage error, got %v", err)
+	}
+	if _, ok := artifacts.LookupStore("other-blob"); ok {
+		t.Fatal("undeclared artifact store was registered")
+	}
+}
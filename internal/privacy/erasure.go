@@ -0,0 +1,269 @@
+package privacy
+
+import (
+	"context"
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/haasonsaas/nexus/internal/sessions"
+	"github.com/haasonsaas/nexus/pkg/models"
+)
+
+// ErasureRecord documents an identity erasure. Records are signed with the
+// gateway's erasure key so they can be shown to be unmodified later.
+type ErasureRecord struct {
+	ID          string    `json:"id"`
+	Peer        string    `json:"peer"`
+	Identities  []string  `json:"identities"`
+	RequestedBy string    `json:"requested_by,omitempty"`
+	StartedAt   time.Time `json:"started_at"`
+	CompletedAt time.Time `json:"completed_at"`
+	SessionIDs  []string  `json:"session_ids"`
+	Removed     Report    `json:"removed"`
+	Errors      []string  `json:"errors,omitempty"`
+	PublicKey   string    `json:"public_key"`
+	Signature   string    `json:"signature,omitempty"`
+}
+
+// signedPayload returns the bytes covered by the signature: the record
+// encoded without its signature.
+func (r *ErasureRecord) signedPayload() ([]byte, error) {
+	unsigned := *r
+	unsigned.Signature = ""
+	return json.Marshal(&unsigned)
+}
+
+// Sign sets the record's public key and signature.
+func (r *ErasureRecord) Sign(key ed25519.PrivateKey) error {
+	r.PublicKey = base64.StdEncoding.EncodeToString(key.Public().(ed25519.PublicKey))
+	payload, err := r.signedPayload()
+	if err != nil {
+		return err
+	}
+	r.Signature = base64.StdEncoding.EncodeToString(ed25519.Sign(key, payload))
+	return nil
+}
+
+// Verify checks the record's signature against its embedded public key and,
+// when trusted is non-nil, that the key is the trusted one.
+func (r *ErasureRecord) Verify(trusted ed25519.PublicKey) error {
+	publicKey, err := base64.StdEncoding.DecodeString(r.PublicKey)
+	if err != nil || len(publicKey) != ed25519.PublicKeySize {
+		return errors.New("erasure record has an invalid public key")
+	}
+	if trusted != nil && !trusted.Equal(ed25519.PublicKey(publicKey)) {
+		return errors.New("erasure record was signed by an untrusted key")
+	}
+	signature, err := base64.StdEncoding.DecodeString(r.Signature)
+	if err != nil || len(signature) != ed25519.SignatureSize {
+		return errors.New("erasure record has an invalid signature")
+	}
+	payload, err := r.signedPayload()
+	if err != nil {
+		return err
+	}
+	if !ed25519.Verify(ed25519.PublicKey(publicKey), payload, signature) {
+		return errors.New("erasure record signature does not match its contents")
+	}
+	return nil
+}
+
+// LoadSigningKey reads the base64 ed25519 private key at path, generating and
+// saving a new key when the file does not exist.
+func LoadSigningKey(path string) (ed25519.PrivateKey, error) {
+	data, err := os.ReadFile(path)
+	if err == nil {
+		key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(data)))
+		if err != nil || len(key) != ed25519.PrivateKeySize {
+			return nil, fmt.Errorf("invalid erasure signing key %s", path)
+		}
+		return ed25519.PrivateKey(key), nil
+	}
+	if !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("read erasure signing key: %w", err)
+	}
+
+	_, key, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		return nil, fmt.Errorf("generate erasure signing key: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return nil, fmt.Errorf("create key directory: %w", err)
+	}
+	encoded := base64.StdEncoding.EncodeToString(key) + "\n"
+	if err := os.WriteFile(path, []byte(encoded), 0o600); err != nil {
+		return nil, fmt.Errorf("write erasure signing key: %w", err)
+	}
+	return key, nil
+}
+
+// ReadRecord loads an erasure record from disk.
+func ReadRecord(path string) (*ErasureRecord, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var record ErasureRecord
+	if err := json.Unmarshal(data, &record); err != nil {
+		return nil, fmt.Errorf("parse erasure record: %w", err)
+	}
+	return &record, nil
+}
+
+// Eraser removes all data held for a peer identity.
+type Eraser struct {
+	identityLinks map[string][]string
+	stores        Stores
+	key           ed25519.PrivateKey
+	auditDir      string
+	now           func() time.Time
+}
+
+// NewEraser creates an eraser that signs records with key and writes them to
+// auditDir.
+func NewEraser(identityLinks map[string][]string, stores Stores, key ed25519.PrivateKey, auditDir string) *Eraser {
+	return &Eraser{
+		identityLinks: identityLinks,
+		stores:        stores,
+		key:           key,
+		auditDir:      auditDir,
+		now:           time.Now,
+	}
+}
+
+// Erase deletes the sessions, messages, artifacts, persisted events, run
+// checkpoints, and session- and conversation-scoped memories of peer and
+// every identity linked to it, then writes a signed record of the erasure.
+// Every deletion finishes before the record is signed, so the record
+// accounts for all of them. The record is written even when some deletions
+// fail; its path is returned alongside any error.
+func (e *Eraser) Erase(ctx context.Context, peer, requestedBy string) (*ErasureRecord, string, error) {
+	if e.stores.Sessions == nil {
+		return nil, "", errors.New("session store is required")
+	}
+	if _, _, err := ParsePeer(peer); err != nil {
+		return nil, "", err
+	}
+	peer = strings.TrimSpace(peer)
+
+	record := &ErasureRecord{
+		ID:          uuid.NewString(),
+		Peer:        peer,
+		Identities:  Identities(peer, e.identityLinks),
+		RequestedBy: requestedBy,
+		StartedAt:   e.now().UTC(),
+		SessionIDs:  []string{},
+	}
+
+	var errs []error
+	if err := e.erase(ctx, record); err != nil {
+		errs = append(errs, err)
+	}
+	record.CompletedAt = e.now().UTC()
+	for _, err := range errs {
+		record.Errors = append(record.Errors, err.Error())
+	}
+
+	path, err := e.writeRecord(record)
+	if err != nil {
+		errs = append(errs, err)
+	}
+	return record, path, errors.Join(errs...)
+}
+
+func (e *Eraser) erase(ctx context.Context, record *ErasureRecord) error {
+	ids := make(map[string]bool, len(record.Identities))
+	for _, id := range record.Identities {
+		ids[id] = true
+	}
+
+	all, err := listSessions(ctx, e.stores.Sessions)
+	if err != nil {
+		return err
+	}
+	var errs []error
+	// Memories kept for a person rather than a session are channel-scoped
+	// to the conversation: the peer's own id on most channels, or the
+	// conversation of a session attributed to them.
+	conversations := make(map[string]bool)
+	for _, session := range all {
+		if !matchesAny(session, ids) {
+			continue
+		}
+		conversations[session.ChannelID] = true
+		removed, err := purgeSession(ctx, e.stores, session.ID)
+		record.Removed.add(removed)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("session %s: %w", session.ID, err))
+			continue
+		}
+		record.SessionIDs = append(record.SessionIDs, session.ID)
+	}
+
+	// Messages the peer sent into shared sessions (such as the main DM
+	// session) carry the peer's conversation id. Events of those sessions
+	// are found through the messages, so they go first.
+	for _, id := range record.Identities {
+		channel, peerID, err := ParsePeer(id)
+		if err != nil {
+			// Canonical identities only appear in session keys.
+			continue
+		}
+		if e.stores.Events != nil {
+			deleted, err := e.stores.Events.DeletePeer(ctx, string(channel), peerID)
+			record.Removed.Events += deleted
+			if err != nil {
+				errs = append(errs, fmt.Errorf("events for %s: %w", id, err))
+			}
+		}
+		deleted, err := e.stores.Sessions.DeleteMessages(ctx, sessions.MessageFilter{Channel: channel, ChannelID: peerID})
+		record.Removed.Messages += deleted
+		if err != nil {
+			errs = append(errs, fmt.Errorf("messages for %s: %w", id, err))
+		}
+		conversations[peerID] = true
+	}
+
+	if e.stores.Memory != nil {
+		for _, conversation := range slices.Sorted(maps.Keys(conversations)) {
+			if conversation == "" {
+				continue
+			}
+			deleted, err := e.stores.Memory.Prune(ctx, models.ScopeChannel, conversation, time.Time{})
+			record.Removed.Memories += deleted
+			if err != nil {
+				errs = append(errs, fmt.Errorf("memories for %s: %w", conversation, err))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (e *Eraser) writeRecord(record *ErasureRecord) (string, error) {
+	if err := record.Sign(e.key); err != nil {
+		return "", fmt.Errorf("sign erasure record: %w", err)
+	}
+	data, err := json.MarshalIndent(record, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(e.auditDir, 0o700); err != nil {
+		return "", fmt.Errorf("create audit directory: %w", err)
+	}
+	name := record.StartedAt.Format("20060102T150405Z") + "-" + record.ID + ".json"
+	path := filepath.Join(e.auditDir, name)
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		return "", fmt.Errorf("write erasure record: %w", err)
+	}
+	return path, nil
+}
@@ -0,0 +1,92 @@
+// Package privacy enforces data retention policies and erases the data held
+// for a peer identity on request.
+package privacy
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/haasonsaas/nexus/pkg/models"
+)
+
+// ParsePeer splits a "provider:peer_id" identity into its parts.
+func ParsePeer(peer string) (models.ChannelType, string, error) {
+	provider, id, ok := strings.Cut(strings.TrimSpace(peer), ":")
+	provider = strings.TrimSpace(provider)
+	id = strings.TrimSpace(id)
+	if !ok || provider == "" || id == "" {
+		return "", "", fmt.Errorf("invalid peer %q, expected provider:peer_id", peer)
+	}
+	return models.ChannelType(strings.ToLower(provider)), id, nil
+}
+
+// Identities expands identity to every identity that refers to the same
+// person under identityLinks: the identity itself, the canonical identity it
+// is linked to, and every peer linked to that canonical identity.
+func Identities(identity string, identityLinks map[string][]string) []string {
+	identity = strings.TrimSpace(identity)
+	if identity == "" {
+		return nil
+	}
+	seen := map[string]bool{identity: true}
+	for canonical, linked := range identityLinks {
+		member := canonical == identity
+		for _, peer := range linked {
+			if peer == identity {
+				member = true
+				break
+			}
+		}
+		if !member {
+			continue
+		}
+		seen[canonical] = true
+		for _, peer := range linked {
+			seen[peer] = true
+		}
+	}
+	out := make([]string, 0, len(seen))
+	for id := range seen {
+		out = append(out, id)
+	}
+	sort.Strings(out)
+	return out
+}
+
+// SessionIdentities returns the identities a session belongs to, derived from
+// its key and conversation. Group sessions and the shared "main" DM session
+// are not attributed to any single identity.
+func SessionIdentities(session *models.Session) []string {
+	if session == nil {
+		return nil
+	}
+	key := session.Key
+	if strings.Contains(key, ":group:") {
+		return nil
+	}
+	if prefix, peer, ok := strings.Cut(key, ":dm:"); ok {
+		if peer == "" || peer == "main" {
+			return nil
+		}
+		// agent:<channel>:dm:<peer> (per-channel-peer) or agent:dm:<identity> (per-peer).
+		if _, channel, scoped := strings.Cut(prefix, ":"); scoped && channel != "" {
+			return []string{channel + ":" + peer}
+		}
+		return []string{peer}
+	}
+	if session.Channel == "" || session.ChannelID == "" {
+		return nil
+	}
+	return []string{string(session.Channel) + ":" + session.ChannelID}
+}
+
+// matchesAny reports whether any of the session's identities is in ids.
+func matchesAny(session *models.Session, ids map[string]bool) bool {
+	for _, id := range SessionIdentities(session) {
+		if ids[id] {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,80 @@
+package privacy
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/haasonsaas/nexus/pkg/models"
+)
+
+func TestParsePeer(t *testing.T) {
+	channel, id, err := ParsePeer(" Telegram:42 ")
+	if err != nil {
+		t.Fatalf("ParsePeer() error = %v", err)
+	}
+	if channel != models.ChannelTelegram || id != "42" {
+		t.Fatalf("unexpected peer %q %q", channel, id)
+	}
+	for _, invalid := range []string{"", "telegram", "telegram:", ":42"} {
+		if _, _, err := ParsePeer(invalid); err == nil {
+			t.Fatalf("expected error for %q", invalid)
+		}
+	}
+}
+
+func TestIdentities(t *testing.T) {
+	links := map[string][]string{
+		"alice": {"telegram:42", "slack:U1"},
+		"bob":   {"discord:7"},
+	}
+
+	got := Identities("slack:U1", links)
+	want := []string{"alice", "slack:U1", "telegram:42"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("Identities() = %v, want %v", got, want)
+	}
+
+	got = Identities("matrix:@carol", links)
+	if !reflect.DeepEqual(got, []string{"matrix:@carol"}) {
+		t.Fatalf("expected unlinked peer only, got %v", got)
+	}
+}
+
+func TestSessionIdentities(t *testing.T) {
+	tests := []struct {
+		name    string
+		session *models.Session
+		want    []string
+	}{
+		{
+			name:    "default key",
+			session: &models.Session{Key: "main:telegram:42", Channel: models.ChannelTelegram, ChannelID: "42"},
+			want:    []string{"telegram:42"},
+		},
+		{
+			name:    "per peer",
+			session: &models.Session{Key: "main:dm:alice", Channel: models.ChannelSlack, ChannelID: "D1"},
+			want:    []string{"alice"},
+		},
+		{
+			name:    "per channel peer",
+			session: &models.Session{Key: "main:slack:dm:U1", Channel: models.ChannelSlack, ChannelID: "D1"},
+			want:    []string{"slack:U1"},
+		},
+		{
+			name:    "shared main dm",
+			session: &models.Session{Key: "main:dm:main", Channel: models.ChannelSlack, ChannelID: "D1"},
+		},
+		{
+			name:    "group",
+			session: &models.Session{Key: "main:discord:group:123", Channel: models.ChannelDiscord, ChannelID: "123"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SessionIdentities(tt.session); !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("SessionIdentities() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,258 @@
+package privacy
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"io"
+	"log/slog"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/haasonsaas/nexus/internal/artifacts"
+	"github.com/haasonsaas/nexus/internal/config"
+	"github.com/haasonsaas/nexus/internal/observability"
+	"github.com/haasonsaas/nexus/internal/sessions"
+	"github.com/haasonsaas/nexus/pkg/models"
+	pb "github.com/haasonsaas/nexus/pkg/proto"
+)
+
+type fakePruner struct {
+	calls []string
+}
+
+func (f *fakePruner) Prune(ctx context.Context, scope models.MemoryScope, scopeID string, before time.Time) (int64, error) {
+	f.calls = append(f.calls, string(scope)+":"+scopeID)
+	return 1, nil
+}
+
+type fakeEvents struct {
+	calls []string
+}
+
+func (f *fakeEvents) DeleteSession(ctx context.Context, sessionID string) (int64, error) {
+	f.calls = append(f.calls, "session:"+sessionID)
+	return 1, nil
+}
+
+func (f *fakeEvents) DeletePeer(ctx context.Context, channel, channelID string) (int64, error) {
+	f.calls = append(f.calls, "peer:"+channel+":"+channelID)
+	return 1, nil
+}
+
+type fakeCheckpoints struct {
+	calls []string
+}
+
+func (f *fakeCheckpoints) PruneSession(ctx context.Context, sessionID string, before time.Time) (int64, error) {
+	call := sessionID
+	if before.IsZero() {
+		call += ":all"
+	}
+	f.calls = append(f.calls, call)
+	return 1, nil
+}
+
+func newTestStores(t *testing.T) (Stores, *sessions.MemoryStore, *fakePruner) {
+	t.Helper()
+	localStore, err := artifacts.NewLocalStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewLocalStore() error = %v", err)
+	}
+	store := sessions.NewMemoryStore()
+	pruner := &fakePruner{}
+	return Stores{
+		Sessions:  store,
+		Artifacts: artifacts.NewMemoryRepository(localStore, slog.New(slog.NewTextHandler(io.Discard, nil))),
+		Memory:    pruner,
+	}, store, pruner
+}
+
+func addSession(t *testing.T, store *sessions.MemoryStore, key string, channel models.ChannelType, channelID string, updated time.Time) *models.Session {
+	t.Helper()
+	session := &models.Session{
+		ID: uuid.NewString(), AgentID: "main", Key: key, Channel: channel, ChannelID: channelID,
+		CreatedAt: updated, UpdatedAt: updated,
+	}
+	if err := store.Create(context.Background(), session); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	return session
+}
+
+func addMessage(t *testing.T, store *sessions.MemoryStore, session *models.Session, channelID string, created time.Time) {
+	t.Helper()
+	msg := &models.Message{
+		SessionID: session.ID, Channel: session.Channel, ChannelID: channelID,
+		Role: models.RoleUser, Content: "hello", CreatedAt: created,
+	}
+	if err := store.AppendMessage(context.Background(), session.ID, msg); err != nil {
+		t.Fatalf("AppendMessage() error = %v", err)
+	}
+}
+
+func addArtifact(t *testing.T, repo artifacts.Repository, sessionID string) {
+	t.Helper()
+	ctx := observability.AddSessionID(context.Background(), sessionID)
+	artifact := &pb.Artifact{Type: "file", MimeType: "text/plain", Filename: "a.txt", Size: 2}
+	if err := repo.StoreArtifact(ctx, artifact, bytes.NewReader([]byte("hi"))); err != nil {
+		t.Fatalf("StoreArtifact() error = %v", err)
+	}
+}
+
+func TestPolicyFor(t *testing.T) {
+	cfg := config.RetentionConfig{
+		Default:  config.RetentionPolicy{Messages: 30 * 24 * time.Hour, Memory: 90 * 24 * time.Hour},
+		Channels: map[string]config.RetentionPolicy{"slack": {Messages: 7 * 24 * time.Hour}},
+		Users:    map[string]config.RetentionPolicy{"alice": {Messages: 24 * time.Hour}},
+	}
+	links := map[string][]string{"alice": {"slack:U1"}}
+
+	policy := PolicyFor(cfg, links, &models.Session{Key: "main:slack:C9", Channel: models.ChannelSlack, ChannelID: "C9"})
+	if policy.Messages != 7*24*time.Hour || policy.Memory != 90*24*time.Hour {
+		t.Fatalf("unexpected channel policy %+v", policy)
+	}
+
+	policy = PolicyFor(cfg, links, &models.Session{Key: "main:slack:dm:U1", Channel: models.ChannelSlack, ChannelID: "D1"})
+	if policy.Messages != 24*time.Hour || policy.Memory != 90*24*time.Hour {
+		t.Fatalf("unexpected user policy %+v", policy)
+	}
+}
+
+func TestEnforcerRun(t *testing.T) {
+	ctx := context.Background()
+	stores, store, pruner := newTestStores(t)
+	now := time.Now()
+
+	stale := addSession(t, store, "main:telegram:1", models.ChannelTelegram, "1", now.Add(-60*24*time.Hour))
+	active := addSession(t, store, "main:telegram:2", models.ChannelTelegram, "2", now)
+	addMessage(t, store, active, "2", now.Add(-10*24*time.Hour))
+	addMessage(t, store, active, "2", now)
+	addArtifact(t, stores.Artifacts, stale.ID)
+	checkpoints := &fakeCheckpoints{}
+	stores.Checkpoints = checkpoints
+
+	enforcer := NewEnforcer(config.RetentionConfig{
+		Default: config.RetentionPolicy{Sessions: 30 * 24 * time.Hour, Messages: 7 * 24 * time.Hour},
+	}, nil, stores, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	report, err := enforcer.Run(ctx)
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if report.Sessions != 1 || report.Messages != 1 || report.Artifacts != 1 {
+		t.Fatalf("unexpected report %+v", report)
+	}
+	if _, err := store.Get(ctx, stale.ID); err == nil {
+		t.Fatalf("expected stale session to be deleted")
+	}
+	history, err := store.GetHistory(ctx, active.ID, 0)
+	if err != nil {
+		t.Fatalf("GetHistory() error = %v", err)
+	}
+	if len(history) != 1 {
+		t.Fatalf("expected 1 retained message, got %d", len(history))
+	}
+	if len(pruner.calls) != 1 || pruner.calls[0] != "session:"+stale.ID {
+		t.Fatalf("unexpected memory prunes %v", pruner.calls)
+	}
+	// The stale session loses every checkpoint, the active one only those
+	// past the message cutoff.
+	slices.Sort(checkpoints.calls)
+	wantCheckpoints := []string{active.ID, stale.ID + ":all"}
+	slices.Sort(wantCheckpoints)
+	if !slices.Equal(checkpoints.calls, wantCheckpoints) || report.Checkpoints != 2 {
+		t.Fatalf("checkpoint prunes = %v (%d), want %v", checkpoints.calls, report.Checkpoints, wantCheckpoints)
+	}
+}
+
+func TestEraserErase(t *testing.T) {
+	ctx := context.Background()
+	stores, store, pruner := newTestStores(t)
+	now := time.Now()
+
+	own := addSession(t, store, "main:dm:alice", models.ChannelSlack, "D1", now)
+	linked := addSession(t, store, "main:telegram:42", models.ChannelTelegram, "42", now)
+	shared := addSession(t, store, "main:dm:main", models.ChannelTelegram, "42", now)
+	other := addSession(t, store, "main:telegram:7", models.ChannelTelegram, "7", now)
+	addMessage(t, store, shared, "42", now)
+	addMessage(t, store, shared, "7", now)
+	addArtifact(t, stores.Artifacts, own.ID)
+	events := &fakeEvents{}
+	stores.Events = events
+
+	key, err := LoadSigningKey(filepath.Join(t.TempDir(), "erasure.key"))
+	if err != nil {
+		t.Fatalf("LoadSigningKey() error = %v", err)
+	}
+	links := map[string][]string{"alice": {"slack:U1", "telegram:42"}}
+	eraser := NewEraser(links, stores, key, t.TempDir())
+
+	record, path, err := eraser.Erase(ctx, "slack:U1", "admin")
+	if err != nil {
+		t.Fatalf("Erase() error = %v", err)
+	}
+	if record.Removed.Sessions != 2 || record.Removed.Artifacts != 1 || record.Removed.Messages != 1 {
+		t.Fatalf("unexpected removal counts %+v", record.Removed)
+	}
+	wantEvents := []string{"session:" + own.ID, "session:" + linked.ID, "peer:slack:U1", "peer:telegram:42"}
+	// Conversation memories go for each erased session and linked peer.
+	wantMemories := []string{"session:" + own.ID, "session:" + linked.ID, "channel:42", "channel:D1", "channel:U1"}
+	slices.Sort(pruner.calls)
+	slices.Sort(wantMemories)
+	if !slices.Equal(pruner.calls, wantMemories) || record.Removed.Memories != 5 {
+		t.Fatalf("memory prunes = %v (%d), want %v", pruner.calls, record.Removed.Memories, wantMemories)
+	}
+	slices.Sort(events.calls)
+	slices.Sort(wantEvents)
+	if !slices.Equal(events.calls, wantEvents) || record.Removed.Events != 4 {
+		t.Fatalf("event deletions = %v (%d), want %v", events.calls, record.Removed.Events, wantEvents)
+	}
+	for _, id := range []string{own.ID, linked.ID} {
+		if _, err := store.Get(ctx, id); err == nil {
+			t.Fatalf("expected session %s to be erased", id)
+		}
+	}
+	for _, id := range []string{shared.ID, other.ID} {
+		if _, err := store.Get(ctx, id); err != nil {
+			t.Fatalf("expected session %s to be kept: %v", id, err)
+		}
+	}
+	history, err := store.GetHistory(ctx, shared.ID, 0)
+	if err != nil {
+		t.Fatalf("GetHistory() error = %v", err)
+	}
+	if len(history) != 1 || history[0].ChannelID != "7" {
+		t.Fatalf("expected only the other peer's message in the shared session, got %+v", history)
+	}
+
+	saved, err := ReadRecord(path)
+	if err != nil {
+		t.Fatalf("ReadRecord() error = %v", err)
+	}
+	if err := saved.Verify(key.Public().(ed25519.PublicKey)); err != nil {
+		t.Fatalf("Verify() error = %v", err)
+	}
+	saved.Removed.Sessions = 0
+	if err := saved.Verify(nil); err == nil {
+		t.Fatalf("expected tampered record to fail verification")
+	}
+}
+
+func TestLoadSigningKeyReusesKey(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "keys", "erasure.key")
+	first, err := LoadSigningKey(path)
+	if err != nil {
+		t.Fatalf("LoadSigningKey() error = %v", err)
+	}
+	second, err := LoadSigningKey(path)
+	if err != nil {
+		t.Fatalf("LoadSigningKey() error = %v", err)
+	}
+	if !first.Equal(second) {
+		t.Fatalf("expected the generated key to be reused")
+	}
+}
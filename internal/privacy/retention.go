@@ -0,0 +1,290 @@
+package privacy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/haasonsaas/nexus/internal/artifacts"
+	"github.com/haasonsaas/nexus/internal/config"
+	"github.com/haasonsaas/nexus/internal/sessions"
+	"github.com/haasonsaas/nexus/pkg/models"
+)
+
+// sessionPageSize bounds how many sessions are loaded per query during a sweep.
+const sessionPageSize = 500
+
+// SessionStore is the session storage a sweep or erasure needs.
+type SessionStore interface {
+	sessions.Store
+	sessions.RetentionStore
+}
+
+// MemoryPruner deletes vector memories by scope and age. *memory.Manager
+// satisfies it.
+type MemoryPruner interface {
+	Prune(ctx context.Context, scope models.MemoryScope, scopeID string, before time.Time) (int64, error)
+}
+
+// EventStore deletes persisted agent events, which hold tool inputs and
+// outputs. *observability.DBEventStore satisfies it.
+type EventStore interface {
+	DeleteSession(ctx context.Context, sessionID string) (int64, error)
+	DeletePeer(ctx context.Context, channel, channelID string) (int64, error)
+}
+
+// CheckpointPruner deletes the run checkpoints of a session last updated
+// before a cutoff, or all of them for a zero cutoff. Checkpoints hold a run's
+// full conversation. *agent.FileCheckpointStore satisfies it.
+type CheckpointPruner interface {
+	PruneSession(ctx context.Context, sessionID string, before time.Time) (int64, error)
+}
+
+// Report counts the records removed by a sweep or erasure.
+type Report struct {
+	Sessions    int64 `json:"sessions"`
+	Messages    int64 `json:"messages"`
+	Artifacts   int64 `json:"artifacts"`
+	Memories    int64 `json:"memories"`
+	Events      int64 `json:"events"`
+	Checkpoints int64 `json:"checkpoints"`
+}
+
+// Total returns the number of records removed.
+func (r Report) Total() int64 {
+	return r.Sessions + r.Messages + r.Artifacts + r.Memories + r.Events + r.Checkpoints
+}
+
+func (r *Report) add(other Report) {
+	r.Sessions += other.Sessions
+	r.Messages += other.Messages
+	r.Artifacts += other.Artifacts
+	r.Memories += other.Memories
+	r.Events += other.Events
+	r.Checkpoints += other.Checkpoints
+}
+
+// Stores bundles the data stores retention and erasure operate on. All but
+// Sessions are optional.
+type Stores struct {
+	Sessions    SessionStore
+	Artifacts   artifacts.Repository
+	Memory      MemoryPruner
+	Events      EventStore
+	Checkpoints CheckpointPruner
+}
+
+// PolicyFor resolves the retention policy for a session. Channel overrides
+// apply on top of the default, and user overrides on top of both; zero
+// durations in an override inherit the broader policy.
+func PolicyFor(cfg config.RetentionConfig, identityLinks map[string][]string, session *models.Session) config.RetentionPolicy {
+	policy := cfg.Default
+	if override, ok := cfg.Channels[string(session.Channel)]; ok {
+		policy = mergePolicy(policy, override)
+	}
+	for _, identity := range SessionIdentities(session) {
+		for _, id := range Identities(identity, identityLinks) {
+			if override, ok := cfg.Users[id]; ok {
+				return mergePolicy(policy, override)
+			}
+		}
+	}
+	return policy
+}
+
+func mergePolicy(base, override config.RetentionPolicy) config.RetentionPolicy {
+	if override.Messages > 0 {
+		base.Messages = override.Messages
+	}
+	if override.Sessions > 0 {
+		base.Sessions = override.Sessions
+	}
+	if override.Artifacts > 0 {
+		base.Artifacts = override.Artifacts
+	}
+	if override.Memory > 0 {
+		base.Memory = override.Memory
+	}
+	return base
+}
+
+// Enforcer applies retention policies to stored data.
+type Enforcer struct {
+	cfg           config.RetentionConfig
+	identityLinks map[string][]string
+	stores        Stores
+	logger        *slog.Logger
+	now           func() time.Time
+}
+
+// NewEnforcer creates a retention enforcer.
+func NewEnforcer(cfg config.RetentionConfig, identityLinks map[string][]string, stores Stores, logger *slog.Logger) *Enforcer {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &Enforcer{
+		cfg:           cfg,
+		identityLinks: identityLinks,
+		stores:        stores,
+		logger:        logger.With("component", "retention"),
+		now:           time.Now,
+	}
+}
+
+// Run performs one retention sweep. Failures for individual sessions are
+// collected and returned together after the sweep completes.
+func (e *Enforcer) Run(ctx context.Context) (Report, error) {
+	var report Report
+	if e.stores.Sessions == nil {
+		return report, errors.New("session store is required")
+	}
+	all, err := listSessions(ctx, e.stores.Sessions)
+	if err != nil {
+		return report, err
+	}
+
+	now := e.now()
+	var errs []error
+	for _, session := range all {
+		if err := ctx.Err(); err != nil {
+			return report, err
+		}
+		policy := PolicyFor(e.cfg, e.identityLinks, session)
+		removed, err := e.enforce(ctx, session, policy, now)
+		report.add(removed)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("session %s: %w", session.ID, err))
+		}
+	}
+	return report, errors.Join(errs...)
+}
+
+func (e *Enforcer) enforce(ctx context.Context, session *models.Session, policy config.RetentionPolicy, now time.Time) (Report, error) {
+	if policy.Sessions > 0 && session.UpdatedAt.Before(now.Add(-policy.Sessions)) {
+		return purgeSession(ctx, e.stores, session.ID)
+	}
+
+	var report Report
+	var errs []error
+	if policy.Messages > 0 {
+		deleted, err := e.stores.Sessions.DeleteMessages(ctx, sessions.MessageFilter{
+			SessionID:     session.ID,
+			CreatedBefore: now.Add(-policy.Messages),
+		})
+		report.Messages += deleted
+		if err != nil {
+			errs = append(errs, err)
+		}
+		// A checkpoint carries the conversation as it stood, so one older
+		// than the message cutoff holds expired messages.
+		if e.stores.Checkpoints != nil {
+			deleted, err := e.stores.Checkpoints.PruneSession(ctx, session.ID, now.Add(-policy.Messages))
+			report.Checkpoints += deleted
+			if err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+	if policy.Artifacts > 0 && e.stores.Artifacts != nil {
+		deleted, err := deleteArtifacts(ctx, e.stores.Artifacts, artifacts.Filter{
+			SessionID:     session.ID,
+			CreatedBefore: now.Add(-policy.Artifacts),
+		})
+		report.Artifacts += deleted
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if policy.Memory > 0 && e.stores.Memory != nil {
+		deleted, err := e.stores.Memory.Prune(ctx, models.ScopeSession, session.ID, now.Add(-policy.Memory))
+		report.Memories += deleted
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return report, errors.Join(errs...)
+}
+
+// purgeSession removes a session with its messages, artifacts, persisted
+// events, run checkpoints, and session-scoped memories.
+func purgeSession(ctx context.Context, stores Stores, sessionID string) (Report, error) {
+	var report Report
+	if stores.Artifacts != nil {
+		deleted, err := deleteArtifacts(ctx, stores.Artifacts, artifacts.Filter{SessionID: sessionID})
+		report.Artifacts += deleted
+		if err != nil {
+			return report, err
+		}
+		// Artifacts this session only referred to go once no other
+		// session does.
+		if tracker, ok := stores.Artifacts.(artifacts.ReferenceTracker); ok {
+			if err := tracker.ReleaseSession(ctx, sessionID); err != nil {
+				return report, err
+			}
+		}
+	}
+	if stores.Memory != nil {
+		deleted, err := stores.Memory.Prune(ctx, models.ScopeSession, sessionID, time.Time{})
+		report.Memories += deleted
+		if err != nil {
+			return report, err
+		}
+	}
+	if stores.Events != nil {
+		deleted, err := stores.Events.DeleteSession(ctx, sessionID)
+		report.Events += deleted
+		if err != nil {
+			return report, err
+		}
+	}
+	if stores.Checkpoints != nil {
+		deleted, err := stores.Checkpoints.PruneSession(ctx, sessionID, time.Time{})
+		report.Checkpoints += deleted
+		if err != nil {
+			return report, err
+		}
+	}
+	deleted, err := stores.Sessions.DeleteMessages(ctx, sessions.MessageFilter{SessionID: sessionID})
+	report.Messages += deleted
+	if err != nil {
+		return report, err
+	}
+	if err := stores.Sessions.Delete(ctx, sessionID); err != nil {
+		return report, err
+	}
+	report.Sessions++
+	return report, nil
+}
+
+func deleteArtifacts(ctx context.Context, repo artifacts.Repository, filter artifacts.Filter) (int64, error) {
+	list, err := repo.ListArtifacts(ctx, filter)
+	if err != nil {
+		return 0, fmt.Errorf("list artifacts: %w", err)
+	}
+	var deleted int64
+	for _, artifact := range list {
+		if err := repo.DeleteArtifact(ctx, artifact.GetId()); err != nil {
+			return deleted, fmt.Errorf("delete artifact %s: %w", artifact.GetId(), err)
+		}
+		deleted++
+	}
+	return deleted, nil
+}
+
+// listSessions loads every session before any are deleted so deletions do
+// not shift later pages.
+func listSessions(ctx context.Context, store sessions.RetentionStore) ([]*models.Session, error) {
+	var all []*models.Session
+	for offset := 0; ; offset += sessionPageSize {
+		page, err := store.FindSessions(ctx, sessions.SessionFilter{Limit: sessionPageSize, Offset: offset})
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, page...)
+		if len(page) < sessionPageSize {
+			return all, nil
+		}
+	}
+}
@@ -0,0 +1,90 @@
+package profile
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// ChangeKind classifies a difference between two configs.
+type ChangeKind string
+
+const (
+	ChangeAdded   ChangeKind = "added"
+	ChangeRemoved ChangeKind = "removed"
+	ChangeChanged ChangeKind = "changed"
+)
+
+// Change is a single differing key, addressed by its dotted path.
+type Change struct {
+	Path string
+	Kind ChangeKind
+	From any
+	To   any
+}
+
+// Diff compares two resolved raw configs and returns the keys that differ,
+// sorted by path. Nested maps are compared key by key; lists and scalars are
+// compared as whole values.
+func Diff(a, b map[string]any) []Change {
+	var changes []Change
+	diffMaps("", a, b, &changes)
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Path < changes[j].Path
+	})
+	return changes
+}
+
+func diffMaps(prefix string, a, b map[string]any, changes *[]Change) {
+	keys := map[string]struct{}{}
+	for key := range a {
+		keys[key] = struct{}{}
+	}
+	for key := range b {
+		keys[key] = struct{}{}
+	}
+	for key := range keys {
+		path := key
+		if prefix != "" {
+			path = prefix + "." + key
+		}
+		av, inA := a[key]
+		bv, inB := b[key]
+		switch {
+		case !inA:
+			*changes = append(*changes, Change{Path: path, Kind: ChangeAdded, To: bv})
+		case !inB:
+			*changes = append(*changes, Change{Path: path, Kind: ChangeRemoved, From: av})
+		default:
+			am, aIsMap := av.(map[string]any)
+			bm, bIsMap := bv.(map[string]any)
+			if aIsMap && bIsMap {
+				diffMaps(path, am, bm, changes)
+				continue
+			}
+			if !reflect.DeepEqual(av, bv) {
+				*changes = append(*changes, Change{Path: path, Kind: ChangeChanged, From: av, To: bv})
+			}
+		}
+	}
+}
+
+// FormatValue renders a diff value on one line. Values under secret-looking
+// keys are masked so diffs can be shared safely.
+func FormatValue(path string, value any) string {
+	if isSensitivePath(path) && value != nil && value != "" {
+		return "***"
+	}
+	return fmt.Sprintf("%v", value)
+}
+
+func isSensitivePath(path string) bool {
+	lower := strings.ToLower(path)
+	for _, needle := range []string{"token", "secret", "api_key", "apikey", "password", "jwt", "private"} {
+		if strings.Contains(lower, needle) {
+			return true
+		}
+	}
+	return false
+}
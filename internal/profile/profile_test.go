@@ -368,3 +368,45 @@ func TestDefaultConfigPath_WithActiveProfile(t *testing.T) {
 		t.Errorf("DefaultConfigPath = %q, should contain 'custom'", path)
 	}
 }
+
+func TestDiff(t *testing.T) {
+	a := map[string]any{
+		"server": map[string]any{"host": "0.0.0.0", "grpc_port": 50051},
+		"llm":    map[string]any{"default_provider": "anthropic"},
+		"tools":  []any{"exec"},
+	}
+	b := map[string]any{
+		"server":  map[string]any{"host": "0.0.0.0", "grpc_port": 443},
+		"tools":   []any{"exec", "browser"},
+		"logging": map[string]any{"level": "debug"},
+	}
+
+	changes := Diff(a, b)
+	want := []Change{
+		{Path: "llm", Kind: ChangeRemoved, From: a["llm"]},
+		{Path: "logging", Kind: ChangeAdded, To: b["logging"]},
+		{Path: "server.grpc_port", Kind: ChangeChanged, From: 50051, To: 443},
+		{Path: "tools", Kind: ChangeChanged, From: a["tools"], To: b["tools"]},
+	}
+	if len(changes) != len(want) {
+		t.Fatalf("Diff() = %+v, want %d changes", changes, len(want))
+	}
+	for i := range want {
+		if changes[i].Path != want[i].Path || changes[i].Kind != want[i].Kind {
+			t.Errorf("change[%d] = %s %s, want %s %s", i, changes[i].Kind, changes[i].Path, want[i].Kind, want[i].Path)
+		}
+	}
+
+	if len(Diff(a, a)) != 0 {
+		t.Error("Diff() of identical configs should be empty")
+	}
+}
+
+func TestFormatValueMasksSecrets(t *testing.T) {
+	if got := FormatValue("llm.providers.anthropic.api_key", "sk-123"); got != "***" {
+		t.Errorf("FormatValue(api_key) = %q, want ***", got)
+	}
+	if got := FormatValue("server.grpc_port", 443); got != "443" {
+		t.Errorf("FormatValue(grpc_port) = %q, want 443", got)
+	}
+}
@@ -19,6 +19,10 @@ const (
 type InstallResult struct {
 	Path         string
 	Instructions []string
+
+	// Registered is set when the service was registered with the OS rather
+	// than written as a file, as on Windows.
+	Registered bool
 }
 
 var commandRunner = func(ctx context.Context, name string, args ...string) error {
@@ -31,6 +35,7 @@ var commandRunner = func(ctx context.Context, name string, args ...string) error
 }
 
 // InstallUserService writes a user-level service file for the current OS.
+// On Windows it registers a system service with sc.exe instead.
 func InstallUserService(configPath string, overwrite bool) (InstallResult, error) {
 	execPath, err := os.Executable()
 	if err != nil {
@@ -43,6 +48,8 @@ func InstallUserService(configPath string, overwrite bool) (InstallResult, error
 		return installSystemdUser(execPath, configPath, overwrite)
 	case "darwin":
 		return installLaunchdUser(execPath, configPath, overwrite)
+	case "windows":
+		return installWindowsService(execPath, configPath, overwrite)
 	default:
 		return InstallResult{}, fmt.Errorf("service install not supported on %s", runtime.GOOS)
 	}
@@ -82,6 +89,8 @@ func RestartUserService(ctx context.Context) ([]string, error) {
 			}
 		}
 		return steps, nil
+	case "windows":
+		return restartWindowsService(ctx)
 	default:
 		return nil, fmt.Errorf("service restart not supported on %s", runtime.GOOS)
 	}
@@ -116,7 +116,7 @@ func TestUserHomeDir(t *testing.T) {
 }
 
 func TestInstallUserService_UnsupportedOS(t *testing.T) {
-	if runtime.GOOS == "linux" || runtime.GOOS == "darwin" {
+	if runtime.GOOS == "linux" || runtime.GOOS == "darwin" || runtime.GOOS == "windows" {
 		t.Skip("skipping unsupported OS test on supported platform")
 	}
 
@@ -130,7 +130,7 @@ func TestInstallUserService_UnsupportedOS(t *testing.T) {
 }
 
 func TestRestartUserService_UnsupportedOS(t *testing.T) {
-	if runtime.GOOS == "linux" || runtime.GOOS == "darwin" {
+	if runtime.GOOS == "linux" || runtime.GOOS == "darwin" || runtime.GOOS == "windows" {
 		t.Skip("skipping unsupported OS test on supported platform")
 	}
 
@@ -0,0 +1,11 @@
+//go:build !windows
+
+package service
+
+import "context"
+
+// RunWindowsService reports false on platforms without a Windows service
+// control manager.
+func RunWindowsService(fn func(ctx context.Context) error) (bool, error) {
+	return false, nil
+}
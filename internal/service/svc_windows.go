@@ -0,0 +1,63 @@
+//go:build windows
+
+package service
+
+import (
+	"context"
+	"time"
+
+	"golang.org/x/sys/windows/svc"
+)
+
+// RunWindowsService runs fn under the service control manager when the
+// process was started as a Windows service. It reports false when running
+// from a console, in which case the caller should run fn itself.
+func RunWindowsService(fn func(ctx context.Context) error) (bool, error) {
+	isService, err := svc.IsWindowsService()
+	if err != nil || !isService {
+		return false, err
+	}
+	handler := &windowsHandler{run: fn}
+	if err := svc.Run(WindowsServiceName, handler); err != nil {
+		return true, err
+	}
+	return true, handler.err
+}
+
+type windowsHandler struct {
+	run func(ctx context.Context) error
+	err error
+}
+
+func (h *windowsHandler) Execute(args []string, requests <-chan svc.ChangeRequest, status chan<- svc.Status) (bool, uint32) {
+	const accepts = svc.AcceptStop | svc.AcceptShutdown
+	status <- svc.Status{State: svc.StartPending}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	done := make(chan error, 1)
+	go func() {
+		done <- h.run(ctx)
+	}()
+	status <- svc.Status{State: svc.Running, Accepts: accepts}
+
+	for {
+		select {
+		case err := <-done:
+			h.err = err
+			if err != nil {
+				return true, 1
+			}
+			return false, 0
+		case req := <-requests:
+			switch req.Cmd {
+			case svc.Interrogate:
+				status <- req.CurrentStatus
+			case svc.Stop, svc.Shutdown:
+				// serve allows 30 seconds for graceful shutdown.
+				status <- svc.Status{State: svc.StopPending, WaitHint: uint32((35 * time.Second).Milliseconds())}
+				cancel()
+			}
+		}
+	}
+}
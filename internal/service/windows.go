@@ -0,0 +1,128 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const (
+	WindowsServiceName        = "nexus"
+	WindowsServiceDisplayName = "Nexus Gateway"
+)
+
+// Restarts poll `sc.exe query` until the service stops or the timeout passes.
+var (
+	windowsStopTimeout  = 45 * time.Second
+	windowsPollInterval = 500 * time.Millisecond
+)
+
+var commandOutput = func(ctx context.Context, name string, args ...string) (string, error) {
+	output, err := exec.CommandContext(ctx, name, args...).CombinedOutput()
+	if err != nil {
+		return string(output), fmt.Errorf("%s %s failed: %w: %s", name, strings.Join(args, " "), err, strings.TrimSpace(string(output)))
+	}
+	return string(output), nil
+}
+
+// GenerateWindowsServiceCommand returns the binPath registered with the
+// service control manager. Services start in System32, so the config path
+// is made absolute.
+func GenerateWindowsServiceCommand(execPath, configPath string) string {
+	if abs, err := filepath.Abs(configPath); err == nil {
+		configPath = abs
+	}
+	return fmt.Sprintf(`"%s" serve --config "%s"`, execPath, configPath)
+}
+
+// installWindowsService registers nexus as an auto-start Windows service
+// with sc.exe. Registering requires an elevated prompt.
+func installWindowsService(execPath, configPath string, overwrite bool) (InstallResult, error) {
+	ctx := context.Background()
+	result := InstallResult{
+		Path:       `HKLM\SYSTEM\CurrentControlSet\Services\` + WindowsServiceName,
+		Registered: true,
+		Instructions: []string{
+			"sc.exe start " + WindowsServiceName,
+		},
+	}
+
+	_, err := commandOutput(ctx, "sc.exe", "query", WindowsServiceName)
+	exists := err == nil
+	if exists && !overwrite {
+		return result, nil
+	}
+
+	binPath := GenerateWindowsServiceCommand(execPath, configPath)
+	args := []string{"create", WindowsServiceName, "binPath=", binPath, "start=", "auto", "DisplayName=", WindowsServiceDisplayName}
+	if exists {
+		args = []string{"config", WindowsServiceName, "binPath=", binPath, "start=", "auto", "DisplayName=", WindowsServiceDisplayName}
+	}
+	if err := commandRunner(ctx, "sc.exe", args...); err != nil {
+		if strings.Contains(err.Error(), "Access is denied") || strings.Contains(err.Error(), "FAILED 5:") {
+			return InstallResult{}, fmt.Errorf("%w (run from an elevated prompt)", err)
+		}
+		return InstallResult{}, err
+	}
+	// Match the systemd unit: restart on failure after 3 seconds.
+	if err := commandRunner(ctx, "sc.exe", "failure", WindowsServiceName, "reset=", "86400", "actions=", "restart/3000/restart/3000/restart/3000"); err != nil {
+		return InstallResult{}, err
+	}
+	return result, nil
+}
+
+// restartWindowsService stops the service, waits for it to report STOPPED,
+// and starts it again.
+func restartWindowsService(ctx context.Context) ([]string, error) {
+	steps := []string{
+		"sc.exe stop " + WindowsServiceName,
+		"sc.exe start " + WindowsServiceName,
+	}
+	// 1062: the service has not been started.
+	if err := commandRunner(ctx, "sc.exe", "stop", WindowsServiceName); err != nil && !strings.Contains(err.Error(), "1062") {
+		return steps, err
+	}
+
+	deadline := time.Now().Add(windowsStopTimeout)
+	for {
+		output, err := commandOutput(ctx, "sc.exe", "query", WindowsServiceName)
+		if err != nil {
+			return steps, err
+		}
+		if parseSCState(output) == "STOPPED" {
+			break
+		}
+		if time.Now().After(deadline) {
+			return steps, fmt.Errorf("service %s did not stop within %s", WindowsServiceName, windowsStopTimeout)
+		}
+		select {
+		case <-ctx.Done():
+			return steps, ctx.Err()
+		case <-time.After(windowsPollInterval):
+		}
+	}
+
+	if err := commandRunner(ctx, "sc.exe", "start", WindowsServiceName); err != nil {
+		return steps, err
+	}
+	return steps, nil
+}
+
+// parseSCState extracts the state name from `sc.exe query` output, e.g.
+// "STATE : 4  RUNNING".
+func parseSCState(output string) string {
+	for _, line := range strings.Split(output, "\n") {
+		key, value, ok := strings.Cut(line, ":")
+		if !ok || strings.TrimSpace(key) != "STATE" {
+			continue
+		}
+		fields := strings.Fields(value)
+		if len(fields) >= 2 {
+			return fields[1]
+		}
+	}
+	return ""
+}
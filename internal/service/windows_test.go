@@ -0,0 +1,131 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func stubWindowsCommands(t *testing.T, states []string) *[]string {
+	t.Helper()
+	origRunner, origOutput := commandRunner, commandOutput
+	origPoll := windowsPollInterval
+	t.Cleanup(func() {
+		commandRunner, commandOutput = origRunner, origOutput
+		windowsPollInterval = origPoll
+	})
+	windowsPollInterval = time.Millisecond
+
+	var calls []string
+	commandRunner = func(ctx context.Context, name string, args ...string) error {
+		calls = append(calls, name+" "+strings.Join(args, " "))
+		return nil
+	}
+	commandOutput = func(ctx context.Context, name string, args ...string) (string, error) {
+		calls = append(calls, name+" "+strings.Join(args, " "))
+		if len(states) == 0 {
+			return "", errors.New("sc.exe query nexus failed: exit status 1060")
+		}
+		state := states[0]
+		if len(states) > 1 {
+			states = states[1:]
+		}
+		return "SERVICE_NAME: nexus\n        STATE              : " + state + "\n", nil
+	}
+	return &calls
+}
+
+func TestGenerateWindowsServiceCommand(t *testing.T) {
+	got := GenerateWindowsServiceCommand(`C:\Program Files\Nexus\nexus.exe`, "/etc/nexus.yaml")
+	if !strings.HasPrefix(got, `"C:\Program Files\Nexus\nexus.exe" serve --config "`) {
+		t.Fatalf("unexpected binPath %q", got)
+	}
+	if !strings.HasSuffix(got, `nexus.yaml"`) {
+		t.Fatalf("expected quoted config path, got %q", got)
+	}
+}
+
+func TestInstallWindowsServiceCreates(t *testing.T) {
+	calls := stubWindowsCommands(t, nil)
+
+	result, err := installWindowsService(`C:\nexus.exe`, "nexus.yaml", false)
+	if err != nil {
+		t.Fatalf("installWindowsService() error = %v", err)
+	}
+	if !result.Registered || !strings.HasSuffix(result.Path, `\Services\nexus`) {
+		t.Fatalf("unexpected result %+v", result)
+	}
+	joined := strings.Join(*calls, "\n")
+	if !containsAll(joined, []string{"sc.exe create nexus binPath= ", "start= auto", "sc.exe failure nexus reset= 86400"}) {
+		t.Fatalf("unexpected calls %v", *calls)
+	}
+}
+
+func TestInstallWindowsServiceExisting(t *testing.T) {
+	calls := stubWindowsCommands(t, []string{"4  RUNNING"})
+
+	if _, err := installWindowsService(`C:\nexus.exe`, "nexus.yaml", false); err != nil {
+		t.Fatalf("installWindowsService() error = %v", err)
+	}
+	if len(*calls) != 1 {
+		t.Fatalf("expected only a query without overwrite, got %v", *calls)
+	}
+
+	*calls = nil
+	if _, err := installWindowsService(`C:\nexus.exe`, "nexus.yaml", true); err != nil {
+		t.Fatalf("installWindowsService() error = %v", err)
+	}
+	if !strings.Contains(strings.Join(*calls, "\n"), "sc.exe config nexus binPath= ") {
+		t.Fatalf("expected sc.exe config on overwrite, got %v", *calls)
+	}
+}
+
+func TestRestartWindowsServiceWaitsForStop(t *testing.T) {
+	calls := stubWindowsCommands(t, []string{"3  STOP_PENDING", "3  STOP_PENDING", "1  STOPPED"})
+
+	steps, err := restartWindowsService(context.Background())
+	if err != nil {
+		t.Fatalf("restartWindowsService() error = %v", err)
+	}
+	if len(steps) != 2 {
+		t.Fatalf("unexpected steps %v", steps)
+	}
+	got := *calls
+	if got[0] != "sc.exe stop nexus" || got[len(got)-1] != "sc.exe start nexus" {
+		t.Fatalf("unexpected calls %v", got)
+	}
+	if queries := len(got) - 2; queries != 3 {
+		t.Fatalf("expected 3 state queries, got %d", queries)
+	}
+}
+
+func TestRestartWindowsServiceNotStarted(t *testing.T) {
+	stubWindowsCommands(t, []string{"1  STOPPED"})
+	commandRunner = func(ctx context.Context, name string, args ...string) error {
+		if args[0] == "stop" {
+			return errors.New("sc.exe stop nexus failed: exit status 1062: The service has not been started.")
+		}
+		return nil
+	}
+
+	if _, err := restartWindowsService(context.Background()); err != nil {
+		t.Fatalf("expected a stopped service to start, got %v", err)
+	}
+}
+
+func TestParseSCState(t *testing.T) {
+	output := `
+SERVICE_NAME: nexus
+        TYPE               : 10  WIN32_OWN_PROCESS
+        STATE              : 4  RUNNING
+                                (STOPPABLE, NOT_PAUSABLE, ACCEPTS_SHUTDOWN)
+`
+	if got := parseSCState(output); got != "RUNNING" {
+		t.Fatalf("parseSCState() = %q, want RUNNING", got)
+	}
+	if got := parseSCState("garbage"); got != "" {
+		t.Fatalf("parseSCState() = %q, want empty", got)
+	}
+}
@@ -9,17 +9,35 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/haasonsaas/nexus/internal/storage/encryption"
 	"github.com/haasonsaas/nexus/pkg/models"
 )
 
 // CockroachBranchStore implements BranchStore using CockroachDB.
 type CockroachBranchStore struct {
-	db *sql.DB
+	db      *sql.DB
+	dialect Dialect
+	keyring *encryption.Keyring
 }
 
 // NewCockroachBranchStore creates a new CockroachDB branch store.
 func NewCockroachBranchStore(db *sql.DB) *CockroachBranchStore {
-	return &CockroachBranchStore{db: db}
+	return &CockroachBranchStore{db: db, dialect: DialectPostgres}
+}
+
+// NewSQLiteBranchStore creates a branch store on an open, migrated SQLite
+// database.
+func NewSQLiteBranchStore(db *sql.DB) *CockroachBranchStore {
+	return &CockroachBranchStore{db: db, dialect: DialectSQLite}
+}
+
+// newIDExpr returns a SQL expression that generates a UUID for each row.
+func (s *CockroachBranchStore) newIDExpr() string {
+	if s.dialect == DialectSQLite {
+		return "lower(hex(randomblob(4)) || '-' || hex(randomblob(2)) || '-4' || substr(hex(randomblob(2)), 2) || '-' || " +
+			"substr('89ab', 1 + (abs(random()) % 4), 1) || substr(hex(randomblob(2)), 2) || '-' || hex(randomblob(6)))"
+	}
+	return "gen_random_uuid()"
 }
 
 // CreateBranch creates a new branch in a session.
@@ -291,9 +309,10 @@ func (s *CockroachBranchStore) GetBranchStats(ctx context.Context, branchID stri
 		stats.TotalMessages = stats.OwnMessages + inherited
 	}
 
-	// Get last message timestamp
+	// Get last message timestamp. Selecting the column rather than
+	// MAX(created_at) keeps its timestamp type on SQLite.
 	var lastMsg sql.NullTime
-	err = s.db.QueryRowContext(ctx, "SELECT MAX(created_at) FROM messages WHERE branch_id = $1", branchID).Scan(&lastMsg)
+	err = s.db.QueryRowContext(ctx, "SELECT created_at FROM messages WHERE branch_id = $1 ORDER BY created_at DESC LIMIT 1", branchID).Scan(&lastMsg)
 	if err == nil && lastMsg.Valid {
 		stats.LastMessageAt = &lastMsg.Time
 	}
@@ -410,7 +429,7 @@ func (s *CockroachBranchStore) MergeBranch(ctx context.Context, sourceBranchID,
 	case models.MergeStrategyReplace, models.MergeStrategyContinue:
 		query := `
 			INSERT INTO messages (id, session_id, branch_id, sequence_num, channel, channel_id, direction, role, content, attachments, tool_calls, tool_results, metadata, created_at)
-			SELECT gen_random_uuid(), session_id, $1, sequence_num + $2, channel, channel_id, direction, role, content, attachments, tool_calls, tool_results, metadata, created_at
+			SELECT ` + s.newIDExpr() + `, session_id, $1, sequence_num + $2, channel, channel_id, direction, role, content, attachments, tool_calls, tool_results, metadata, created_at
 			FROM messages WHERE branch_id = $3 AND sequence_num > $4
 		`
 		result, err := tx.ExecContext(ctx, query, targetBranchID, maxSeq, sourceBranchID, source.BranchPoint)
@@ -552,10 +571,13 @@ func (s *CockroachBranchStore) AppendMessageToBranch(ctx context.Context, sessio
 		}
 	}()
 
-	// Lock the branch row to serialize sequence allocation across concurrent requests
-	_, err = tx.ExecContext(ctx, "SELECT 1 FROM branches WHERE id = $1 FOR UPDATE", branchID)
-	if err != nil {
-		return fmt.Errorf("failed to lock branch: %w", err)
+	// Lock the branch row to serialize sequence allocation across concurrent
+	// requests. SQLite transactions already hold the database write lock.
+	if s.dialect != DialectSQLite {
+		_, err = tx.ExecContext(ctx, "SELECT 1 FROM branches WHERE id = $1 FOR UPDATE", branchID)
+		if err != nil {
+			return fmt.Errorf("failed to lock branch: %w", err)
+		}
 	}
 
 	// Get next sequence number (now safe from race conditions)
@@ -590,6 +612,10 @@ func (s *CockroachBranchStore) AppendMessageToBranch(ctx context.Context, sessio
 	if err != nil {
 		return fmt.Errorf("failed to marshal metadata: %w", err)
 	}
+	content, err := s.keyring.Encrypt(msg.Content)
+	if err != nil {
+		return fmt.Errorf("failed to encrypt content: %w", err)
+	}
 
 	query := `
 		INSERT INTO messages (id, session_id, branch_id, sequence_num, channel, channel_id, direction, role, content, attachments, tool_calls, tool_results, metadata, created_at)
@@ -597,7 +623,7 @@ func (s *CockroachBranchStore) AppendMessageToBranch(ctx context.Context, sessio
 	`
 	_, err = tx.ExecContext(ctx, query,
 		msg.ID, sessionID, branchID, msg.SequenceNum, msg.Channel, msg.ChannelID, msg.Direction, msg.Role,
-		msg.Content, attachments, toolCalls, toolResults, metadata, msg.CreatedAt)
+		content, attachments, toolCalls, toolResults, metadata, msg.CreatedAt)
 	if err != nil {
 		return fmt.Errorf("failed to append message: %w", err)
 	}
@@ -616,24 +642,30 @@ func (s *CockroachBranchStore) GetBranchHistory(ctx context.Context, branchID st
 		limit = 100
 	}
 
-	// Use recursive CTE to get all messages in branch lineage
+	// Use recursive CTE to get all messages in branch lineage. Each ancestor
+	// contributes messages up to the branch point of its child on the path,
+	// and the limit keeps the latest messages.
 	query := `
 		WITH RECURSIVE branch_path AS (
-			SELECT id, parent_branch_id, branch_point, 0 AS depth FROM branches WHERE id = $1
+			SELECT id, parent_branch_id, branch_point, 0 AS depth, CAST(NULL AS BIGINT) AS inherit_until FROM branches WHERE id = $1
 			UNION ALL
-			SELECT b.id, b.parent_branch_id, b.branch_point, bp.depth + 1
+			SELECT b.id, b.parent_branch_id, b.branch_point, bp.depth + 1, bp.branch_point
 			FROM branches b INNER JOIN branch_path bp ON b.id = bp.parent_branch_id
 		),
 		branch_messages AS (
-			SELECT m.*, bp.depth, bp.branch_point AS bp_branch_point
+			SELECT m.*, bp.depth
 			FROM messages m
 			INNER JOIN branch_path bp ON m.branch_id = bp.id
-			WHERE bp.depth = 0 OR m.sequence_num <= bp.branch_point
+			WHERE bp.depth = 0 OR m.sequence_num <= bp.inherit_until
+		),
+		latest AS (
+			SELECT * FROM branch_messages
+			ORDER BY depth ASC, sequence_num DESC
+			LIMIT $2
 		)
 		SELECT id, session_id, branch_id, sequence_num, channel, channel_id, direction, role, content, attachments, tool_calls, tool_results, metadata, created_at
-		FROM branch_messages
+		FROM latest
 		ORDER BY depth DESC, sequence_num ASC
-		LIMIT $2
 	`
 	rows, err := s.db.QueryContext(ctx, query, branchID, limit)
 	if err != nil {
@@ -727,6 +759,9 @@ func (s *CockroachBranchStore) scanMessages(rows *sql.Rows) ([]*models.Message,
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan message: %w", err)
 		}
+		if msg.Content, err = s.keyring.Decrypt(msg.Content); err != nil {
+			return nil, fmt.Errorf("failed to decrypt message %s: %w", msg.ID, err)
+		}
 
 		if len(attachments) > 0 && string(attachments) != "null" {
 			if err := json.Unmarshal(attachments, &msg.Attachments); err != nil {
@@ -720,9 +720,9 @@ func TestCockroachBranchStore_GetBranchStats(t *testing.T) {
 					WillReturnRows(sqlmock.NewRows([]string{"total"}).AddRow(10))
 
 				// Last message timestamp
-				mock.ExpectQuery("SELECT MAX.*FROM messages WHERE branch_id").
+				mock.ExpectQuery("SELECT created_at FROM messages WHERE branch_id .* LIMIT 1").
 					WithArgs("branch-1").
-					WillReturnRows(sqlmock.NewRows([]string{"max"}).AddRow(now))
+					WillReturnRows(sqlmock.NewRows([]string{"created_at"}).AddRow(now))
 			},
 			wantErr: false,
 		},
@@ -461,12 +461,15 @@ func (s *MemoryBranchStore) GetBranchHistory(ctx context.Context, branchID strin
 		}
 		visited[currentBranch.ID] = true
 
-		parentMsgs := s.messages[parentID]
-		for _, msg := range parentMsgs {
+		// Ancestors are visited nearest first, so each one's messages go
+		// before those collected so far.
+		var inherited []*models.Message
+		for _, msg := range s.messages[parentID] {
 			if msg.SequenceNum <= currentBranch.BranchPoint {
-				result = append(result, cloneMessage(msg))
+				inherited = append(inherited, cloneMessage(msg))
 			}
 		}
+		result = append(inherited, result...)
 		var ok bool
 		currentBranch, ok = s.branches[parentID]
 		if !ok {
@@ -0,0 +1,147 @@
+package sessions
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/haasonsaas/nexus/pkg/models"
+)
+
+// MetaKeyActiveBranch is the session metadata key naming the branch new
+// messages go to. Sessions without it use their primary branch.
+const MetaKeyActiveBranch = "active_branch_id"
+
+// MetaKeyUndoOf is the branch metadata key recording which branch an undo
+// branch replaced.
+const MetaKeyUndoOf = "undo_of"
+
+// ErrNothingToUndo is returned by UndoLastExchange when the branch has no
+// user message to roll back.
+var ErrNothingToUndo = errors.New("nothing to undo")
+
+// undoSearchLimit bounds how far back UndoLastExchange looks for the last
+// user message.
+const undoSearchLimit = 200
+
+// ActiveBranch returns the session's active branch, falling back to (and
+// creating, if needed) the primary branch when none is set or the recorded
+// one no longer exists.
+func ActiveBranch(ctx context.Context, store BranchStore, session *models.Session) (*models.Branch, error) {
+	if id := ActiveBranchID(session); id != "" {
+		branch, err := store.GetBranch(ctx, id)
+		if err == nil && branch.SessionID == session.ID {
+			return branch, nil
+		}
+		if err != nil && !errors.Is(err, ErrBranchNotFound) {
+			return nil, err
+		}
+	}
+	return store.EnsurePrimaryBranch(ctx, session.ID)
+}
+
+// ActiveBranchID returns the branch recorded as active in the session
+// metadata, or "" for the primary branch.
+func ActiveBranchID(session *models.Session) string {
+	if session == nil || session.Metadata == nil {
+		return ""
+	}
+	id, _ := session.Metadata[MetaKeyActiveBranch].(string)
+	return strings.TrimSpace(id)
+}
+
+// SetActiveBranch records branch as the session's active branch. The caller
+// persists the session.
+func SetActiveBranch(session *models.Session, branch *models.Branch) {
+	if branch == nil || branch.IsPrimary {
+		delete(session.Metadata, MetaKeyActiveBranch)
+		return
+	}
+	if session.Metadata == nil {
+		session.Metadata = map[string]any{}
+	}
+	session.Metadata[MetaKeyActiveBranch] = branch.ID
+}
+
+// ForkBranchHead forks branch after its latest message, so the new branch
+// starts with the same history.
+func ForkBranchHead(ctx context.Context, store BranchStore, branch *models.Branch, name string) (*models.Branch, error) {
+	last, err := store.GetBranchHistory(ctx, branch.ID, 1)
+	if err != nil {
+		return nil, err
+	}
+	var head int64
+	if len(last) > 0 && last[0].BranchID == branch.ID {
+		head = last[0].SequenceNum
+	}
+	return store.ForkBranch(ctx, branch.ID, head, name)
+}
+
+// UndoLastExchange rolls branch back to before its last user message by
+// forking a branch without that message and everything after it: the
+// reply, tool calls, and tool results. The original branch is kept, so the
+// undone exchange stays in the branch tree. It returns the new branch and
+// the messages it leaves out.
+func UndoLastExchange(ctx context.Context, store BranchStore, branch *models.Branch) (*models.Branch, []*models.Message, error) {
+	history, err := store.GetBranchHistory(ctx, branch.ID, undoSearchLimit)
+	if err != nil {
+		return nil, nil, err
+	}
+	start := -1
+	for i := len(history) - 1; i >= 0; i-- {
+		if history[i].Role == models.RoleUser && len(history[i].ToolResults) == 0 {
+			start = i
+			break
+		}
+	}
+	if start < 0 {
+		return nil, nil, ErrNothingToUndo
+	}
+
+	// Fork the branch that owns the message, which may be an ancestor when
+	// branch has no messages of its own yet.
+	anchor := history[start]
+	owner := anchor.BranchID
+	if owner == "" {
+		owner = branch.ID
+	}
+	undo, err := store.ForkBranch(ctx, owner, anchor.SequenceNum-1, branch.Name)
+	if err != nil {
+		return nil, nil, err
+	}
+	undo.Metadata = map[string]any{MetaKeyUndoOf: branch.ID}
+	if err := store.UpdateBranch(ctx, undo); err != nil {
+		return nil, nil, err
+	}
+	return undo, history[start:], nil
+}
+
+// FindBranch returns the session branch whose ID or name is ref. Names may
+// repeat (undo branches keep their branch's name), so the most recently
+// created active branch with the name wins.
+func FindBranch(ctx context.Context, store BranchStore, sessionID, ref string) (*models.Branch, error) {
+	ref = strings.TrimSpace(ref)
+	if ref == "" {
+		return nil, ErrBranchNotFound
+	}
+	if branch, err := store.GetBranch(ctx, ref); err == nil && branch.SessionID == sessionID {
+		return branch, nil
+	}
+	branches, err := store.ListBranches(ctx, sessionID, BranchListOptions{Limit: 1000})
+	if err != nil {
+		return nil, err
+	}
+	var found *models.Branch
+	for _, branch := range branches {
+		if !strings.EqualFold(branch.Name, ref) && !strings.HasPrefix(branch.ID, ref) {
+			continue
+		}
+		if found == nil || branch.CreatedAt.After(found.CreatedAt) {
+			found = branch
+		}
+	}
+	if found == nil {
+		return nil, ErrBranchNotFound
+	}
+	return found, nil
+}
@@ -0,0 +1,160 @@
+package sessions
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/haasonsaas/nexus/pkg/models"
+)
+
+func appendTurn(t *testing.T, store BranchStore, sessionID, branchID string, msgs ...*models.Message) {
+	t.Helper()
+	for _, msg := range msgs {
+		if err := store.AppendMessageToBranch(context.Background(), sessionID, branchID, msg); err != nil {
+			t.Fatalf("AppendMessageToBranch() error = %v", err)
+		}
+	}
+}
+
+func historyContents(t *testing.T, store BranchStore, branchID string) []string {
+	t.Helper()
+	history, err := store.GetBranchHistory(context.Background(), branchID, 100)
+	if err != nil {
+		t.Fatalf("GetBranchHistory() error = %v", err)
+	}
+	contents := make([]string, 0, len(history))
+	for _, msg := range history {
+		contents = append(contents, msg.Content)
+	}
+	return contents
+}
+
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestUndoLastExchange(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryBranchStore()
+	session := &models.Session{ID: "s1"}
+	main, err := ActiveBranch(ctx, store, session)
+	if err != nil {
+		t.Fatalf("ActiveBranch() error = %v", err)
+	}
+	appendTurn(t, store, session.ID, main.ID,
+		&models.Message{Role: models.RoleUser, Content: "hi"},
+		&models.Message{Role: models.RoleAssistant, Content: "hello"},
+		&models.Message{Role: models.RoleUser, Content: "write the file"},
+		&models.Message{Role: models.RoleAssistant, ToolCalls: []models.ToolCall{{ID: "c1", Name: "write_file"}}},
+		&models.Message{Role: models.RoleTool, ToolResults: []models.ToolResult{{ToolCallID: "c1", Content: "ok"}}},
+		&models.Message{Role: models.RoleAssistant, Content: "done"},
+	)
+
+	undo, undone, err := UndoLastExchange(ctx, store, main)
+	if err != nil {
+		t.Fatalf("UndoLastExchange() error = %v", err)
+	}
+	if len(undone) != 4 || undone[0].Content != "write the file" || undone[1].ToolCalls[0].Name != "write_file" {
+		t.Fatalf("unexpected undone messages: %+v", undone)
+	}
+	if got := historyContents(t, store, undo.ID); !equalStrings(got, []string{"hi", "hello"}) {
+		t.Fatalf("undo branch history = %q", got)
+	}
+	if undo.Name != "main" || undo.Metadata[MetaKeyUndoOf] != main.ID {
+		t.Fatalf("unexpected undo branch: %+v", undo)
+	}
+	if got := historyContents(t, store, main.ID); len(got) != 6 {
+		t.Fatalf("original branch should keep the exchange, got %q", got)
+	}
+
+	SetActiveBranch(session, undo)
+	active, err := ActiveBranch(ctx, store, session)
+	if err != nil || active.ID != undo.ID {
+		t.Fatalf("ActiveBranch() = %v, %v; want undo branch", active, err)
+	}
+
+	// The next undo reaches into the parent branch, since the undo branch
+	// has no messages of its own.
+	second, _, err := UndoLastExchange(ctx, store, undo)
+	if err != nil {
+		t.Fatalf("second UndoLastExchange() error = %v", err)
+	}
+	if got := historyContents(t, store, second.ID); len(got) != 0 {
+		t.Fatalf("second undo history = %q, want empty", got)
+	}
+	if _, _, err := UndoLastExchange(ctx, store, second); !errors.Is(err, ErrNothingToUndo) {
+		t.Fatalf("expected ErrNothingToUndo, got %v", err)
+	}
+}
+
+func TestForkBranchHeadInheritsAcrossGenerations(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryBranchStore()
+	main, err := store.EnsurePrimaryBranch(ctx, "s1")
+	if err != nil {
+		t.Fatalf("EnsurePrimaryBranch() error = %v", err)
+	}
+	appendTurn(t, store, "s1", main.ID, &models.Message{Role: models.RoleUser, Content: "a"})
+
+	child, err := ForkBranchHead(ctx, store, main, "child")
+	if err != nil {
+		t.Fatalf("ForkBranchHead() error = %v", err)
+	}
+	appendTurn(t, store, "s1", main.ID, &models.Message{Role: models.RoleUser, Content: "main only"})
+	appendTurn(t, store, "s1", child.ID, &models.Message{Role: models.RoleUser, Content: "b"})
+
+	grandchild, err := ForkBranchHead(ctx, store, child, "grandchild")
+	if err != nil {
+		t.Fatalf("ForkBranchHead() error = %v", err)
+	}
+	appendTurn(t, store, "s1", grandchild.ID, &models.Message{Role: models.RoleUser, Content: "c"})
+
+	if got := historyContents(t, store, grandchild.ID); !equalStrings(got, []string{"a", "b", "c"}) {
+		t.Fatalf("grandchild history = %q, want [a b c]", got)
+	}
+}
+
+func TestActiveBranchAndFindBranch(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryBranchStore()
+	session := &models.Session{ID: "s1", Metadata: map[string]any{MetaKeyActiveBranch: "missing"}}
+
+	main, err := ActiveBranch(ctx, store, session)
+	if err != nil || !main.IsPrimary {
+		t.Fatalf("ActiveBranch() = %v, %v; want primary for a missing branch", main, err)
+	}
+	alt, err := ForkBranchHead(ctx, store, main, "alt")
+	if err != nil {
+		t.Fatalf("ForkBranchHead() error = %v", err)
+	}
+
+	found, err := FindBranch(ctx, store, session.ID, "ALT")
+	if err != nil || found.ID != alt.ID {
+		t.Fatalf("FindBranch(name) = %v, %v", found, err)
+	}
+	found, err = FindBranch(ctx, store, session.ID, alt.ID[:8])
+	if err != nil || found.ID != alt.ID {
+		t.Fatalf("FindBranch(id prefix) = %v, %v", found, err)
+	}
+	if _, err := FindBranch(ctx, store, "other", "alt"); !errors.Is(err, ErrBranchNotFound) {
+		t.Fatalf("FindBranch() in another session error = %v", err)
+	}
+
+	SetActiveBranch(session, alt)
+	if ActiveBranchID(session) != alt.ID {
+		t.Fatalf("active branch = %q", ActiveBranchID(session))
+	}
+	SetActiveBranch(session, main)
+	if ActiveBranchID(session) != "" {
+		t.Fatalf("primary branch should clear the active branch, got %q", ActiveBranchID(session))
+	}
+}
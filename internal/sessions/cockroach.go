@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/haasonsaas/nexus/internal/storage/encryption"
 	"github.com/haasonsaas/nexus/pkg/models"
 	_ "github.com/lib/pq"
 )
@@ -24,6 +25,9 @@ type CockroachStore struct {
 	stmtGetByKey      *sql.Stmt
 	stmtAppendMessage *sql.Stmt
 	stmtGetHistory    *sql.Stmt
+
+	// keyring encrypts message content at rest when set.
+	keyring *encryption.Keyring
 }
 
 // DB exposes the underlying database connection for related stores.
@@ -518,6 +522,11 @@ func (s *CockroachStore) AppendMessage(ctx context.Context, sessionID string, ms
 		return fmt.Errorf("failed to marshal metadata: %w", err)
 	}
 
+	content, err := s.keyring.Encrypt(msg.Content)
+	if err != nil {
+		return fmt.Errorf("failed to encrypt content: %w", err)
+	}
+
 	// Use transaction to ensure both operations succeed or fail together
 	tx, err := s.db.BeginTx(ctx, nil)
 	if err != nil {
@@ -534,7 +543,7 @@ func (s *CockroachStore) AppendMessage(ctx context.Context, sessionID string, ms
 		msg.ChannelID,
 		msg.Direction,
 		msg.Role,
-		msg.Content,
+		content,
 		attachmentsJSON,
 		toolCallsJSON,
 		toolResultsJSON,
@@ -588,6 +597,9 @@ func (s *CockroachStore) GetHistory(ctx context.Context, sessionID string, limit
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan message: %w", err)
 		}
+		if msg.Content, err = s.keyring.Decrypt(msg.Content); err != nil {
+			return nil, fmt.Errorf("failed to decrypt message %s: %w", msg.ID, err)
+		}
 
 		if len(attachmentsJSON) > 0 && string(attachmentsJSON) != "null" {
 			if err := json.Unmarshal(attachmentsJSON, &msg.Attachments); err != nil {
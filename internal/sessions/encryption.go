@@ -0,0 +1,114 @@
+package sessions
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/haasonsaas/nexus/internal/storage/encryption"
+)
+
+// reencryptBatchSize bounds how many messages are rewritten per query when
+// migrating message content to the primary key.
+const reencryptBatchSize = 500
+
+// EncryptedStore is implemented by stores that can encrypt message content
+// at rest. The in-memory store keeps nothing at rest and does not implement
+// it.
+type EncryptedStore interface {
+	// SetKeyring makes the store encrypt message content on write and
+	// decrypt it on read. A nil keyring disables encryption of new writes
+	// while plaintext rows remain readable.
+	SetKeyring(keyring *encryption.Keyring)
+
+	// ReencryptMessages rewrites every message whose content is plaintext or
+	// encrypted with a retired key so it is encrypted with the primary key.
+	// With dryRun set it only counts them.
+	ReencryptMessages(ctx context.Context, dryRun bool) (int64, error)
+}
+
+// SetKeyring implements EncryptedStore.
+func (s *CockroachStore) SetKeyring(keyring *encryption.Keyring) {
+	s.keyring = keyring
+}
+
+// ReencryptMessages implements EncryptedStore.
+func (s *CockroachStore) ReencryptMessages(ctx context.Context, dryRun bool) (int64, error) {
+	return reencryptMessages(ctx, s.db, s.keyring, dryRun)
+}
+
+// SetKeyring makes the branch store encrypt message content on write and
+// decrypt it on read. It should match the keyring of the session store that
+// shares its database.
+func (s *CockroachBranchStore) SetKeyring(keyring *encryption.Keyring) {
+	s.keyring = keyring
+}
+
+func reencryptMessages(ctx context.Context, db *sql.DB, keyring *encryption.Keyring, dryRun bool) (int64, error) {
+	if keyring == nil {
+		return 0, fmt.Errorf("message encryption is not configured")
+	}
+
+	// Message ids are UUIDs, so the nil UUID sorts before every row on both
+	// CockroachDB and SQLite.
+	var total int64
+	cursor := uuid.Nil.String()
+	for {
+		rows, err := db.QueryContext(ctx,
+			"SELECT id, content FROM messages WHERE id > $1 ORDER BY id LIMIT $2", cursor, reencryptBatchSize)
+		if err != nil {
+			return total, fmt.Errorf("failed to list messages: %w", err)
+		}
+
+		type pending struct{ id, content string }
+		var (
+			stale []pending
+			count int
+		)
+		for rows.Next() {
+			var (
+				id      string
+				content sql.NullString
+			)
+			if err := rows.Scan(&id, &content); err != nil {
+				rows.Close()
+				return total, fmt.Errorf("failed to scan message: %w", err)
+			}
+			count++
+			cursor = id
+			if keyring.NeedsRewrap(content.String) {
+				stale = append(stale, pending{id: id, content: content.String})
+			}
+		}
+		if err := rows.Err(); err != nil {
+			rows.Close()
+			return total, fmt.Errorf("error iterating messages: %w", err)
+		}
+		rows.Close()
+
+		for _, msg := range stale {
+			if dryRun {
+				total++
+				continue
+			}
+			content, _, err := keyring.Rewrap(msg.content)
+			if err != nil {
+				return total, fmt.Errorf("message %s: %w", msg.id, err)
+			}
+			// Matching on the old content skips rows rewritten concurrently.
+			result, err := db.ExecContext(ctx,
+				"UPDATE messages SET content = $1 WHERE id = $2 AND content = $3", content, msg.id, msg.content)
+			if err != nil {
+				return total, fmt.Errorf("failed to update message %s: %w", msg.id, err)
+			}
+			if n, err := result.RowsAffected(); err == nil {
+				total += n
+			}
+		}
+
+		if count < reencryptBatchSize {
+			return total, nil
+		}
+	}
+}
@@ -0,0 +1,103 @@
+package sessions
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/haasonsaas/nexus/internal/storage/encryption"
+	"github.com/haasonsaas/nexus/pkg/models"
+)
+
+func newTestKeyring(t *testing.T, primary string, ids ...string) *encryption.Keyring {
+	t.Helper()
+	keys := make(map[string][]byte, len(ids))
+	for _, id := range ids {
+		keys[id] = bytes.Repeat([]byte{id[len(id)-1]}, encryption.KeySize)
+	}
+	keyring, err := encryption.NewKeyring(primary, keys)
+	if err != nil {
+		t.Fatalf("NewKeyring() error = %v", err)
+	}
+	return keyring
+}
+
+func rawMessageContent(t *testing.T, store *SQLiteStore, id string) string {
+	t.Helper()
+	var content string
+	if err := store.DB().QueryRow("SELECT content FROM messages WHERE id = $1", id).Scan(&content); err != nil {
+		t.Fatalf("query message content: %v", err)
+	}
+	return content
+}
+
+func TestSQLiteStoreEncryptsMessages(t *testing.T) {
+	ctx := context.Background()
+	store := newTestSQLiteStore(t)
+	session := &models.Session{
+		ID: generateID(), AgentID: "agent", Channel: models.ChannelType("api"), ChannelID: "user",
+		Key: "agent:api:user", CreatedAt: time.Now(), UpdatedAt: time.Now(),
+	}
+	if err := store.Create(ctx, session); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	appendMessage := func(content string, at time.Time) *models.Message {
+		msg := &models.Message{
+			ID: generateID(), SessionID: session.ID, Channel: session.Channel, ChannelID: "user",
+			Direction: models.DirectionInbound, Role: models.RoleUser, Content: content, CreatedAt: at,
+		}
+		if err := store.AppendMessage(ctx, session.ID, msg); err != nil {
+			t.Fatalf("AppendMessage() error = %v", err)
+		}
+		return msg
+	}
+
+	legacy := appendMessage("written before encryption", time.Now().Add(-2*time.Minute))
+
+	store.SetKeyring(newTestKeyring(t, "k1", "k1"))
+	sealed := appendMessage("top secret", time.Now().Add(-time.Minute))
+	if raw := rawMessageContent(t, store, sealed.ID); !strings.HasPrefix(raw, encryption.Prefix+"k1:") {
+		t.Fatalf("expected encrypted content at rest, got %q", raw)
+	}
+
+	history, err := store.GetHistory(ctx, session.ID, 0)
+	if err != nil {
+		t.Fatalf("GetHistory() error = %v", err)
+	}
+	if len(history) != 2 || history[0].Content != "written before encryption" || history[1].Content != "top secret" {
+		t.Fatalf("unexpected history %+v", history)
+	}
+
+	// Rotate to k2 and migrate everything, including the legacy plaintext row.
+	store.SetKeyring(newTestKeyring(t, "k2", "k1", "k2"))
+	pending, err := store.ReencryptMessages(ctx, true)
+	if err != nil || pending != 2 {
+		t.Fatalf("ReencryptMessages(dryRun) = %d, %v", pending, err)
+	}
+	if raw := rawMessageContent(t, store, legacy.ID); raw != "written before encryption" {
+		t.Fatalf("dry run changed content to %q", raw)
+	}
+	migrated, err := store.ReencryptMessages(ctx, false)
+	if err != nil || migrated != 2 {
+		t.Fatalf("ReencryptMessages() = %d, %v", migrated, err)
+	}
+	for _, id := range []string{legacy.ID, sealed.ID} {
+		if keyID, _ := encryption.KeyID(rawMessageContent(t, store, id)); keyID != "k2" {
+			t.Fatalf("message %s encrypted with %q, want k2", id, keyID)
+		}
+	}
+	if again, err := store.ReencryptMessages(ctx, false); err != nil || again != 0 {
+		t.Fatalf("second ReencryptMessages() = %d, %v", again, err)
+	}
+
+	store.SetKeyring(newTestKeyring(t, "k2", "k2"))
+	history, err = store.GetHistory(ctx, session.ID, 0)
+	if err != nil {
+		t.Fatalf("GetHistory() after rotation error = %v", err)
+	}
+	if len(history) != 2 || history[1].Content != "top secret" {
+		t.Fatalf("unexpected history after rotation %+v", history)
+	}
+}
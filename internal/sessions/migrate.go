@@ -10,11 +10,39 @@ import (
 	"sort"
 	"strings"
 	"time"
+
+	"github.com/haasonsaas/nexus/internal/storage/sqlite"
 )
 
-//go:embed migrations/*.sql
+//go:embed migrations/*.sql migrations/sqlite/*.sql
 var migrationsFS embed.FS
 
+// Dialect identifies the SQL dialect a migration set is written for.
+type Dialect string
+
+const (
+	// DialectPostgres covers CockroachDB and Postgres.
+	DialectPostgres Dialect = "postgres"
+	// DialectSQLite covers the single-user SQLite backend.
+	DialectSQLite Dialect = "sqlite"
+)
+
+// DialectForURL returns the dialect implied by a database URL.
+func DialectForURL(dsn string) Dialect {
+	if sqlite.IsURL(dsn) {
+		return DialectSQLite
+	}
+	return DialectPostgres
+}
+
+// migrationsDir returns the embedded directory holding the dialect's migrations.
+func (d Dialect) migrationsDir() string {
+	if d == DialectSQLite {
+		return "migrations/sqlite"
+	}
+	return "migrations"
+}
+
 // Migration represents an embedded migration.
 type Migration struct {
 	ID      string
@@ -31,29 +59,62 @@ type AppliedMigration struct {
 // Migrator applies database migrations.
 type Migrator struct {
 	db         *sql.DB
+	dialect    Dialect
 	migrations []Migration
 }
 
-// NewMigrator creates a migrator backed by the given db.
+// NewMigrator creates a migrator backed by the given CockroachDB/Postgres db.
 func NewMigrator(db *sql.DB) (*Migrator, error) {
+	return NewMigratorForDialect(db, DialectPostgres)
+}
+
+// NewMigratorForDialect creates a migrator that applies the migration set
+// written for dialect.
+func NewMigratorForDialect(db *sql.DB, dialect Dialect) (*Migrator, error) {
 	if db == nil {
 		return nil, fmt.Errorf("db is required")
 	}
-	migrations, err := loadMigrations()
+	switch dialect {
+	case "":
+		dialect = DialectPostgres
+	case DialectPostgres, DialectSQLite:
+	default:
+		return nil, fmt.Errorf("unsupported migration dialect %q", dialect)
+	}
+	migrations, err := loadMigrations(dialect.migrationsDir())
 	if err != nil {
 		return nil, err
 	}
-	return &Migrator{db: db, migrations: migrations}, nil
+	return &Migrator{db: db, dialect: dialect, migrations: migrations}, nil
+}
+
+// Dialect returns the SQL dialect the migrator applies.
+func (m *Migrator) Dialect() Dialect {
+	return m.dialect
+}
+
+// Migrations returns the migrations embedded for the migrator's dialect.
+func (m *Migrator) Migrations() []Migration {
+	return append([]Migration(nil), m.migrations...)
 }
 
 // EnsureSchema ensures the schema_migrations table exists.
 func (m *Migrator) EnsureSchema(ctx context.Context) error {
-	_, err := m.db.ExecContext(ctx, `
+	query := `
 		CREATE TABLE IF NOT EXISTS schema_migrations (
 			id STRING PRIMARY KEY,
 			applied_at TIMESTAMPTZ NOT NULL DEFAULT now()
 		)
-	`)
+	`
+	if m.dialect == DialectSQLite {
+		query = `
+		CREATE TABLE IF NOT EXISTS schema_migrations (
+			id TEXT PRIMARY KEY,
+			applied_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+		)
+	`
+	}
+	_, err := m.db.ExecContext(ctx, query)
 	if err != nil {
 		return fmt.Errorf("create schema_migrations: %w", err)
 	}
@@ -235,15 +296,15 @@ func (m *Migrator) migrationByID(id string) (Migration, bool) {
 	return Migration{}, false
 }
 
-func loadMigrations() ([]Migration, error) {
-	paths, err := fs.Glob(migrationsFS, "migrations/*.sql")
+func loadMigrations(dir string) ([]Migration, error) {
+	paths, err := fs.Glob(migrationsFS, dir+"/*.sql")
 	if err != nil {
 		return nil, fmt.Errorf("list migrations: %w", err)
 	}
 
 	entries := map[string]*Migration{}
 	for _, path := range paths {
-		base := strings.TrimPrefix(path, "migrations/")
+		base := strings.TrimPrefix(path, dir+"/")
 		suffix := ""
 		switch {
 		case strings.HasSuffix(base, ".up.sql"):
@@ -3,7 +3,7 @@ package sessions
 import "testing"
 
 func TestLoadMigrations(t *testing.T) {
-	migrations, err := loadMigrations()
+	migrations, err := loadMigrations(DialectPostgres.migrationsDir())
 	if err != nil {
 		t.Fatalf("loadMigrations() error = %v", err)
 	}
@@ -14,3 +14,53 @@ func TestLoadMigrations(t *testing.T) {
 		t.Fatalf("expected first migration to be 001_create_sessions, got %q", migrations[0].ID)
 	}
 }
+
+func TestLoadMigrationsSQLite(t *testing.T) {
+	migrations, err := loadMigrations(DialectSQLite.migrationsDir())
+	if err != nil {
+		t.Fatalf("loadMigrations() error = %v", err)
+	}
+	if len(migrations) == 0 {
+		t.Fatal("expected sqlite migrations")
+	}
+	if migrations[0].ID != "001_create_sessions" {
+		t.Fatalf("expected first migration to be 001_create_sessions, got %q", migrations[0].ID)
+	}
+	for _, migration := range migrations {
+		if migration.UpSQL == "" || migration.DownSQL == "" {
+			t.Fatalf("migration %s is missing up or down SQL", migration.ID)
+		}
+	}
+}
+
+func TestSQLiteMigrationsMatchPostgres(t *testing.T) {
+	postgres, err := loadMigrations(DialectPostgres.migrationsDir())
+	if err != nil {
+		t.Fatalf("loadMigrations(postgres) error = %v", err)
+	}
+	sqlite, err := loadMigrations(DialectSQLite.migrationsDir())
+	if err != nil {
+		t.Fatalf("loadMigrations(sqlite) error = %v", err)
+	}
+	if len(sqlite) != len(postgres) {
+		t.Fatalf("sqlite has %d migrations, postgres has %d", len(sqlite), len(postgres))
+	}
+	for i := range postgres {
+		if sqlite[i].ID != postgres[i].ID {
+			t.Fatalf("migration %d: sqlite %q, postgres %q", i, sqlite[i].ID, postgres[i].ID)
+		}
+	}
+}
+
+func TestDialectForURL(t *testing.T) {
+	tests := map[string]Dialect{
+		"postgres://root@localhost:26257/nexus": DialectPostgres,
+		"sqlite:///~/.nexus/nexus.db":           DialectSQLite,
+		"":                                      DialectPostgres,
+	}
+	for dsn, want := range tests {
+		if got := DialectForURL(dsn); got != want {
+			t.Fatalf("DialectForURL(%q) = %q, want %q", dsn, got, want)
+		}
+	}
+}
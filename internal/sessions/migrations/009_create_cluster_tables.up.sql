@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS cluster_nodes (
+  node_id STRING PRIMARY KEY,
+  address STRING NOT NULL DEFAULT '',
+  started_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  heartbeat_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS cluster_nodes_heartbeat_at_idx
+  ON cluster_nodes (heartbeat_at);
+
+CREATE TABLE IF NOT EXISTS cluster_leases (
+  name STRING PRIMARY KEY,
+  holder_id STRING NOT NULL,
+  acquired_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  expires_at TIMESTAMPTZ NOT NULL
+);
@@ -0,0 +1 @@
+ALTER TABLE canvas_state DROP COLUMN IF EXISTS versions_json;
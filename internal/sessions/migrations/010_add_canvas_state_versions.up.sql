@@ -0,0 +1,2 @@
+-- Version vectors for optimistic canvas state sync
+ALTER TABLE canvas_state ADD COLUMN IF NOT EXISTS versions_json JSONB;
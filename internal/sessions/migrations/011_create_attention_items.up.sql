@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS attention_items (
+  id STRING PRIMARY KEY,
+  status STRING NOT NULL,
+  priority INT NOT NULL DEFAULT 2,
+  received_at TIMESTAMPTZ NOT NULL,
+  data JSONB NOT NULL,
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS attention_items_status_priority_idx
+  ON attention_items (status, priority DESC);
@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS web_watches (
+  id STRING PRIMARY KEY,
+  session_id STRING NOT NULL,
+  next_check_at TIMESTAMPTZ NOT NULL,
+  data JSONB NOT NULL,
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS web_watches_session_idx
+  ON web_watches (session_id);
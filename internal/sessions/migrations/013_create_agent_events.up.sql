@@ -0,0 +1,21 @@
+CREATE TABLE IF NOT EXISTS agent_events (
+  id STRING PRIMARY KEY,
+  type STRING NOT NULL,
+  name STRING NOT NULL DEFAULT '',
+  run_id STRING NOT NULL DEFAULT '',
+  session_id STRING NOT NULL DEFAULT '',
+  agent_id STRING NOT NULL DEFAULT '',
+  tool_call_id STRING NOT NULL DEFAULT '',
+  is_error BOOL NOT NULL DEFAULT false,
+  created_at TIMESTAMPTZ NOT NULL,
+  data JSONB NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS agent_events_session_idx
+  ON agent_events (session_id, created_at);
+
+CREATE INDEX IF NOT EXISTS agent_events_run_idx
+  ON agent_events (run_id, created_at);
+
+CREATE INDEX IF NOT EXISTS agent_events_created_idx
+  ON agent_events (created_at);
@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS access_grants (
+  list STRING NOT NULL,
+  channel STRING NOT NULL,
+  sender STRING NOT NULL,
+  expires_at TIMESTAMPTZ,
+  granted_by STRING NOT NULL DEFAULT '',
+  created_at TIMESTAMPTZ NOT NULL,
+  PRIMARY KEY (list, channel, sender)
+);
@@ -0,0 +1,10 @@
+-- Create artifact references table tracking which sessions use an artifact
+CREATE TABLE IF NOT EXISTS artifact_refs (
+    artifact_id STRING NOT NULL,
+    session_id STRING NOT NULL,
+    message_id STRING NOT NULL DEFAULT '',
+    released_at TIMESTAMPTZ,
+    PRIMARY KEY (artifact_id, session_id, message_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_artifact_refs_session_id ON artifact_refs (session_id);
@@ -0,0 +1,3 @@
+-- Drop tables
+DROP TABLE IF EXISTS messages;
+DROP TABLE IF EXISTS sessions;
@@ -0,0 +1,34 @@
+-- Create sessions table
+CREATE TABLE IF NOT EXISTS sessions (
+    id TEXT PRIMARY KEY,
+    agent_id TEXT NOT NULL,
+    channel TEXT NOT NULL,
+    channel_id TEXT NOT NULL,
+    key TEXT NOT NULL UNIQUE,
+    title TEXT,
+    metadata TEXT,
+    created_at TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_sessions_agent_id ON sessions (agent_id);
+CREATE INDEX IF NOT EXISTS idx_sessions_channel ON sessions (channel);
+
+-- Create messages table
+CREATE TABLE IF NOT EXISTS messages (
+    id TEXT PRIMARY KEY,
+    session_id TEXT NOT NULL REFERENCES sessions(id) ON DELETE CASCADE,
+    channel TEXT NOT NULL,
+    channel_id TEXT,
+    direction TEXT NOT NULL,
+    role TEXT NOT NULL,
+    content TEXT NOT NULL,
+    attachments TEXT,
+    tool_calls TEXT,
+    tool_results TEXT,
+    metadata TEXT,
+    created_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_messages_session_id ON messages (session_id);
+CREATE INDEX IF NOT EXISTS idx_messages_created_at ON messages (created_at);
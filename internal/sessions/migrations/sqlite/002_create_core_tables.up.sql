@@ -0,0 +1,47 @@
+-- Create users table
+CREATE TABLE IF NOT EXISTS users (
+    id TEXT PRIMARY KEY,
+    email TEXT,
+    name TEXT,
+    avatar_url TEXT,
+    provider TEXT,
+    provider_id TEXT,
+    created_at TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP NOT NULL
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_users_email ON users (lower(email)) WHERE email IS NOT NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_users_provider ON users (provider, provider_id) WHERE provider IS NOT NULL AND provider_id IS NOT NULL;
+
+-- Create agents table. user_id carries no foreign key: single-user
+-- deployments create agents without an authenticated user row.
+CREATE TABLE IF NOT EXISTS agents (
+    id TEXT PRIMARY KEY,
+    user_id TEXT,
+    name TEXT NOT NULL,
+    system_prompt TEXT,
+    model TEXT,
+    provider TEXT,
+    tools TEXT,
+    config TEXT,
+    created_at TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_agents_user_id ON agents (user_id);
+
+-- Create channel connections table
+CREATE TABLE IF NOT EXISTS channel_connections (
+    id TEXT PRIMARY KEY,
+    user_id TEXT,
+    channel_type TEXT NOT NULL,
+    channel_id TEXT NOT NULL,
+    status TEXT NOT NULL,
+    config TEXT,
+    connected_at TIMESTAMP NOT NULL,
+    last_activity_at TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_channel_connections_user_id ON channel_connections (user_id);
+CREATE INDEX IF NOT EXISTS idx_channel_connections_type ON channel_connections (channel_type);
+CREATE INDEX IF NOT EXISTS idx_channel_connections_status ON channel_connections (status);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS tool_results;
+DROP TABLE IF EXISTS tool_calls;
@@ -0,0 +1,25 @@
+-- Create tool call and tool result tables
+CREATE TABLE IF NOT EXISTS tool_calls (
+    id TEXT PRIMARY KEY,
+    session_id TEXT NOT NULL,
+    message_id TEXT,
+    tool_name TEXT NOT NULL,
+    input_json TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS tool_calls_session_time_idx ON tool_calls (session_id, created_at);
+CREATE INDEX IF NOT EXISTS tool_calls_tool_name_idx ON tool_calls (tool_name);
+
+CREATE TABLE IF NOT EXISTS tool_results (
+    id TEXT PRIMARY KEY,
+    session_id TEXT NOT NULL,
+    message_id TEXT,
+    tool_call_id TEXT NOT NULL,
+    is_error INTEGER NOT NULL DEFAULT 0,
+    content TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS tool_results_session_time_idx ON tool_results (session_id, created_at);
+CREATE INDEX IF NOT EXISTS tool_results_call_id_idx ON tool_results (tool_call_id);
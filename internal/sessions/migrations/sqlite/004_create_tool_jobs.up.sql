@@ -0,0 +1,15 @@
+-- Create async tool job table
+CREATE TABLE IF NOT EXISTS tool_jobs (
+    id TEXT PRIMARY KEY,
+    tool_name TEXT NOT NULL,
+    tool_call_id TEXT NOT NULL,
+    status TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL,
+    started_at TIMESTAMP,
+    finished_at TIMESTAMP,
+    result TEXT,
+    error_message TEXT
+);
+
+CREATE INDEX IF NOT EXISTS tool_jobs_status_idx ON tool_jobs (status);
+CREATE INDEX IF NOT EXISTS tool_jobs_created_at_idx ON tool_jobs (created_at);
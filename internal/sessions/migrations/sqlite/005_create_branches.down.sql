@@ -0,0 +1,8 @@
+-- Remove branch_id and sequence_num from messages
+DROP INDEX IF EXISTS idx_messages_branch_sequence;
+DROP INDEX IF EXISTS idx_messages_branch_id;
+ALTER TABLE messages DROP COLUMN sequence_num;
+ALTER TABLE messages DROP COLUMN branch_id;
+
+DROP TABLE IF EXISTS branch_merges;
+DROP TABLE IF EXISTS branches;
@@ -0,0 +1,71 @@
+-- Create branches table for conversation branching
+CREATE TABLE IF NOT EXISTS branches (
+    id TEXT PRIMARY KEY,
+    session_id TEXT NOT NULL REFERENCES sessions(id) ON DELETE CASCADE,
+    parent_branch_id TEXT REFERENCES branches(id) ON DELETE SET NULL,
+    name TEXT NOT NULL,
+    description TEXT,
+    branch_point INTEGER NOT NULL DEFAULT 0,
+    status TEXT NOT NULL DEFAULT 'active',
+    is_primary BOOLEAN NOT NULL DEFAULT 0,
+    metadata TEXT,
+    created_at TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP NOT NULL,
+    merged_at TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_branches_session_id ON branches (session_id);
+CREATE INDEX IF NOT EXISTS idx_branches_parent_branch_id ON branches (parent_branch_id);
+CREATE INDEX IF NOT EXISTS idx_branches_status ON branches (status);
+
+-- Only one primary branch per session
+CREATE UNIQUE INDEX IF NOT EXISTS idx_branches_unique_primary
+    ON branches (session_id) WHERE is_primary = 1;
+
+-- Create branch_merges table to track merge history
+CREATE TABLE IF NOT EXISTS branch_merges (
+    id TEXT PRIMARY KEY,
+    source_branch_id TEXT NOT NULL REFERENCES branches(id) ON DELETE CASCADE,
+    target_branch_id TEXT NOT NULL REFERENCES branches(id) ON DELETE CASCADE,
+    strategy TEXT NOT NULL,
+    source_sequence_start INTEGER NOT NULL,
+    source_sequence_end INTEGER NOT NULL,
+    target_sequence_insert INTEGER NOT NULL,
+    message_count INTEGER NOT NULL DEFAULT 0,
+    metadata TEXT,
+    merged_at TIMESTAMP NOT NULL,
+    merged_by TEXT
+);
+
+CREATE INDEX IF NOT EXISTS idx_branch_merges_source ON branch_merges (source_branch_id);
+CREATE INDEX IF NOT EXISTS idx_branch_merges_target ON branch_merges (target_branch_id);
+CREATE INDEX IF NOT EXISTS idx_branch_merges_merged_at ON branch_merges (merged_at DESC);
+
+-- Add branch_id and sequence_num columns to messages table
+ALTER TABLE messages ADD COLUMN branch_id TEXT REFERENCES branches(id) ON DELETE CASCADE;
+ALTER TABLE messages ADD COLUMN sequence_num INTEGER NOT NULL DEFAULT 0;
+
+CREATE INDEX IF NOT EXISTS idx_messages_branch_id ON messages (branch_id);
+CREATE INDEX IF NOT EXISTS idx_messages_branch_sequence ON messages (branch_id, sequence_num);
+
+-- Create primary branches for existing sessions
+INSERT INTO branches (id, session_id, name, description, is_primary, status, created_at, updated_at)
+SELECT lower(hex(randomblob(16))), s.id, 'main', 'Primary conversation branch', 1, 'active', s.created_at, s.updated_at
+FROM sessions s
+WHERE NOT EXISTS (
+    SELECT 1 FROM branches b WHERE b.session_id = s.id AND b.is_primary = 1
+);
+
+-- Assign existing messages to primary branches
+UPDATE messages
+SET branch_id = (
+    SELECT b.id FROM branches b
+    WHERE b.session_id = messages.session_id AND b.is_primary = 1
+    LIMIT 1
+),
+sequence_num = (
+    SELECT COUNT(*) FROM messages m2
+    WHERE m2.session_id = messages.session_id
+    AND m2.created_at <= messages.created_at
+)
+WHERE branch_id IS NULL;
@@ -0,0 +1,3 @@
+-- Drop tables (executions first due to foreign key)
+DROP TABLE IF EXISTS task_executions;
+DROP TABLE IF EXISTS scheduled_tasks;
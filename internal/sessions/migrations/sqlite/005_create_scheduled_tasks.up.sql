@@ -0,0 +1,59 @@
+-- Create scheduled_tasks table for cron-based agent triggers
+CREATE TABLE IF NOT EXISTS scheduled_tasks (
+    id TEXT PRIMARY KEY,
+    name TEXT NOT NULL,
+    description TEXT,
+    agent_id TEXT NOT NULL,
+    schedule TEXT NOT NULL,
+    timezone TEXT,
+    prompt TEXT NOT NULL,
+    config TEXT NOT NULL DEFAULT '{}',
+    status TEXT NOT NULL DEFAULT 'active',
+    next_run_at TIMESTAMP NOT NULL,
+    last_run_at TIMESTAMP,
+    last_execution_id TEXT,
+    created_at TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP NOT NULL,
+    metadata TEXT DEFAULT '{}'
+);
+
+CREATE INDEX IF NOT EXISTS scheduled_tasks_due_idx
+    ON scheduled_tasks (status, next_run_at)
+    WHERE status = 'active';
+
+CREATE INDEX IF NOT EXISTS scheduled_tasks_agent_idx
+    ON scheduled_tasks (agent_id, status);
+
+CREATE INDEX IF NOT EXISTS scheduled_tasks_name_idx
+    ON scheduled_tasks (name);
+
+-- Create task_executions table for execution history
+CREATE TABLE IF NOT EXISTS task_executions (
+    id TEXT PRIMARY KEY,
+    task_id TEXT NOT NULL REFERENCES scheduled_tasks(id) ON DELETE CASCADE,
+    status TEXT NOT NULL DEFAULT 'pending',
+    scheduled_at TIMESTAMP NOT NULL,
+    started_at TIMESTAMP,
+    finished_at TIMESTAMP,
+    session_id TEXT,
+    prompt TEXT NOT NULL,
+    response TEXT,
+    error TEXT,
+    attempt_number INTEGER NOT NULL DEFAULT 1,
+    worker_id TEXT,
+    locked_at TIMESTAMP,
+    locked_until TIMESTAMP,
+    duration INTEGER DEFAULT 0,
+    metadata TEXT DEFAULT '{}'
+);
+
+CREATE INDEX IF NOT EXISTS task_executions_pending_idx
+    ON task_executions (status, scheduled_at, locked_until)
+    WHERE status = 'pending';
+
+CREATE INDEX IF NOT EXISTS task_executions_running_idx
+    ON task_executions (task_id, status)
+    WHERE status = 'running';
+
+CREATE INDEX IF NOT EXISTS task_executions_task_idx
+    ON task_executions (task_id, scheduled_at DESC);
@@ -0,0 +1,20 @@
+-- Create artifacts metadata table
+CREATE TABLE IF NOT EXISTS artifacts (
+    id TEXT PRIMARY KEY,
+    session_id TEXT,
+    edge_id TEXT,
+    type TEXT,
+    mime_type TEXT,
+    filename TEXT,
+    size INTEGER,
+    reference TEXT,
+    ttl_seconds INTEGER,
+    created_at TIMESTAMP NOT NULL,
+    expires_at TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_artifacts_session_id ON artifacts (session_id);
+CREATE INDEX IF NOT EXISTS idx_artifacts_edge_id ON artifacts (edge_id);
+CREATE INDEX IF NOT EXISTS idx_artifacts_type ON artifacts (type);
+CREATE INDEX IF NOT EXISTS idx_artifacts_created_at ON artifacts (created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_artifacts_expires_at ON artifacts (expires_at);
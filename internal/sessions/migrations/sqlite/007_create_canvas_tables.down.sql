@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS canvas_events;
+DROP TABLE IF EXISTS canvas_state;
+DROP TABLE IF EXISTS canvas_sessions;
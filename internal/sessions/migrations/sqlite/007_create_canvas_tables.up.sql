@@ -0,0 +1,35 @@
+-- Create canvas sessions table
+CREATE TABLE IF NOT EXISTS canvas_sessions (
+    id TEXT PRIMARY KEY,
+    key TEXT NOT NULL,
+    workspace_id TEXT NOT NULL,
+    channel_id TEXT NOT NULL,
+    thread_ts TEXT,
+    owner_id TEXT,
+    created_at TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP NOT NULL
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_canvas_sessions_key ON canvas_sessions (key);
+CREATE INDEX IF NOT EXISTS idx_canvas_sessions_workspace ON canvas_sessions (workspace_id);
+CREATE INDEX IF NOT EXISTS idx_canvas_sessions_channel ON canvas_sessions (channel_id);
+CREATE INDEX IF NOT EXISTS idx_canvas_sessions_thread_ts ON canvas_sessions (thread_ts);
+
+-- Canvas state snapshot
+CREATE TABLE IF NOT EXISTS canvas_state (
+    session_id TEXT PRIMARY KEY REFERENCES canvas_sessions(id) ON DELETE CASCADE,
+    state_json TEXT,
+    updated_at TIMESTAMP NOT NULL
+);
+
+-- Canvas event log
+CREATE TABLE IF NOT EXISTS canvas_events (
+    id TEXT PRIMARY KEY,
+    session_id TEXT NOT NULL REFERENCES canvas_sessions(id) ON DELETE CASCADE,
+    type TEXT NOT NULL,
+    payload_json TEXT,
+    created_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_canvas_events_session ON canvas_events (session_id);
+CREATE INDEX IF NOT EXISTS idx_canvas_events_session_created ON canvas_events (session_id, created_at DESC);
@@ -0,0 +1,10 @@
+-- Create session locks table for cluster session ownership
+CREATE TABLE IF NOT EXISTS session_locks (
+    session_id TEXT PRIMARY KEY,
+    owner_id TEXT NOT NULL,
+    acquired_at TIMESTAMP NOT NULL,
+    expires_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS session_locks_expires_at_idx
+    ON session_locks (expires_at);
@@ -0,0 +1,17 @@
+-- Create cluster membership and lease tables
+CREATE TABLE IF NOT EXISTS cluster_nodes (
+    node_id TEXT PRIMARY KEY,
+    address TEXT NOT NULL DEFAULT '',
+    started_at TIMESTAMP NOT NULL,
+    heartbeat_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS cluster_nodes_heartbeat_at_idx
+    ON cluster_nodes (heartbeat_at);
+
+CREATE TABLE IF NOT EXISTS cluster_leases (
+    name TEXT PRIMARY KEY,
+    holder_id TEXT NOT NULL,
+    acquired_at TIMESTAMP NOT NULL,
+    expires_at TIMESTAMP NOT NULL
+);
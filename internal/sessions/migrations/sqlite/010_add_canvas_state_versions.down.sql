@@ -0,0 +1 @@
+ALTER TABLE canvas_state DROP COLUMN versions_json;
@@ -0,0 +1,11 @@
+-- Create attention feed items table
+CREATE TABLE IF NOT EXISTS attention_items (
+    id TEXT PRIMARY KEY,
+    status TEXT NOT NULL,
+    priority INTEGER NOT NULL DEFAULT 2,
+    received_at TIMESTAMP NOT NULL,
+    data TEXT NOT NULL,
+    updated_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_attention_items_status_priority ON attention_items (status, priority DESC);
@@ -0,0 +1,10 @@
+-- Create scheduled web page watches table
+CREATE TABLE IF NOT EXISTS web_watches (
+    id TEXT PRIMARY KEY,
+    session_id TEXT NOT NULL,
+    next_check_at TIMESTAMP NOT NULL,
+    data TEXT NOT NULL,
+    updated_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_web_watches_session ON web_watches (session_id);
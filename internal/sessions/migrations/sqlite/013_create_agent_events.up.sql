@@ -0,0 +1,17 @@
+-- Create persisted agent event timeline table
+CREATE TABLE IF NOT EXISTS agent_events (
+    id TEXT PRIMARY KEY,
+    type TEXT NOT NULL,
+    name TEXT NOT NULL DEFAULT '',
+    run_id TEXT NOT NULL DEFAULT '',
+    session_id TEXT NOT NULL DEFAULT '',
+    agent_id TEXT NOT NULL DEFAULT '',
+    tool_call_id TEXT NOT NULL DEFAULT '',
+    is_error INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMP NOT NULL,
+    data TEXT NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_agent_events_session ON agent_events (session_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_agent_events_run ON agent_events (run_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_agent_events_created ON agent_events (created_at);
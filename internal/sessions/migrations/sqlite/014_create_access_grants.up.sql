@@ -0,0 +1,10 @@
+-- Create runtime access grants table
+CREATE TABLE IF NOT EXISTS access_grants (
+    list TEXT NOT NULL,
+    channel TEXT NOT NULL,
+    sender TEXT NOT NULL,
+    expires_at TIMESTAMP,
+    granted_by TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL,
+    PRIMARY KEY (list, channel, sender)
+);
@@ -0,0 +1,236 @@
+package sessions
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/haasonsaas/nexus/pkg/models"
+)
+
+// RetentionStore is implemented by stores that support retention sweeps and
+// data erasure across all agents.
+type RetentionStore interface {
+	// FindSessions lists sessions of every agent matching the filter, most
+	// recently updated first.
+	FindSessions(ctx context.Context, filter SessionFilter) ([]*models.Session, error)
+
+	// DeleteMessages removes messages matching the filter and returns how
+	// many were deleted.
+	DeleteMessages(ctx context.Context, filter MessageFilter) (int64, error)
+}
+
+// SessionFilter selects sessions for retention and erasure sweeps.
+type SessionFilter struct {
+	Channel       models.ChannelType
+	UpdatedBefore time.Time
+	Limit         int
+	Offset        int
+}
+
+// MessageFilter selects messages for deletion. SessionID or ChannelID must
+// be set so a filter can never match every message.
+type MessageFilter struct {
+	SessionID     string
+	Channel       models.ChannelType
+	ChannelID     string
+	CreatedBefore time.Time
+}
+
+func (f MessageFilter) validate() error {
+	if f.SessionID == "" && f.ChannelID == "" {
+		return errors.New("message filter requires a session id or channel id")
+	}
+	return nil
+}
+
+// FindSessions lists sessions across all agents matching the filter.
+func (m *MemoryStore) FindSessions(ctx context.Context, filter SessionFilter) ([]*models.Session, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var out []*models.Session
+	for _, session := range m.sessions {
+		if filter.Channel != "" && session.Channel != filter.Channel {
+			continue
+		}
+		if !filter.UpdatedBefore.IsZero() && !session.UpdatedAt.Before(filter.UpdatedBefore) {
+			continue
+		}
+		out = append(out, cloneSession(session))
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].UpdatedAt.Equal(out[j].UpdatedAt) {
+			return out[i].ID < out[j].ID
+		}
+		return out[i].UpdatedAt.After(out[j].UpdatedAt)
+	})
+
+	start := filter.Offset
+	if start < 0 {
+		start = 0
+	}
+	if start > len(out) {
+		return []*models.Session{}, nil
+	}
+	end := len(out)
+	if filter.Limit > 0 && start+filter.Limit < end {
+		end = start + filter.Limit
+	}
+	return out[start:end], nil
+}
+
+// DeleteMessages removes messages matching the filter.
+func (m *MemoryStore) DeleteMessages(ctx context.Context, filter MessageFilter) (int64, error) {
+	if err := filter.validate(); err != nil {
+		return 0, err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var deleted int64
+	for sessionID, messages := range m.messages {
+		if filter.SessionID != "" && sessionID != filter.SessionID {
+			continue
+		}
+		kept := messages[:0]
+		for _, msg := range messages {
+			if messageMatches(msg, filter) {
+				deleted++
+				continue
+			}
+			kept = append(kept, msg)
+		}
+		m.messages[sessionID] = kept
+	}
+	return deleted, nil
+}
+
+func messageMatches(msg *models.Message, filter MessageFilter) bool {
+	if filter.Channel != "" && msg.Channel != filter.Channel {
+		return false
+	}
+	if filter.ChannelID != "" && msg.ChannelID != filter.ChannelID {
+		return false
+	}
+	if !filter.CreatedBefore.IsZero() && !msg.CreatedAt.Before(filter.CreatedBefore) {
+		return false
+	}
+	return true
+}
+
+// FindSessions lists sessions across all agents matching the filter.
+func (s *CockroachStore) FindSessions(ctx context.Context, filter SessionFilter) ([]*models.Session, error) {
+	query := `
+		SELECT id, agent_id, channel, channel_id, key, title, metadata, created_at, updated_at
+		FROM sessions
+		WHERE 1=1
+	`
+	var args []interface{}
+	argPos := 1
+
+	if filter.Channel != "" {
+		query += fmt.Sprintf(" AND channel = $%d", argPos)
+		args = append(args, filter.Channel)
+		argPos++
+	}
+	if !filter.UpdatedBefore.IsZero() {
+		query += fmt.Sprintf(" AND updated_at < $%d", argPos)
+		args = append(args, filter.UpdatedBefore)
+		argPos++
+	}
+
+	query += " ORDER BY updated_at DESC, id"
+
+	if filter.Limit > 0 {
+		query += fmt.Sprintf(" LIMIT $%d", argPos)
+		args = append(args, filter.Limit)
+		argPos++
+	}
+	if filter.Offset > 0 {
+		query += fmt.Sprintf(" OFFSET $%d", argPos)
+		args = append(args, filter.Offset)
+	}
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find sessions: %w", err)
+	}
+	defer rows.Close()
+
+	var sessions []*models.Session
+	for rows.Next() {
+		session := &models.Session{}
+		var metadataJSON []byte
+
+		err := rows.Scan(
+			&session.ID,
+			&session.AgentID,
+			&session.Channel,
+			&session.ChannelID,
+			&session.Key,
+			&session.Title,
+			&metadataJSON,
+			&session.CreatedAt,
+			&session.UpdatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan session: %w", err)
+		}
+
+		if len(metadataJSON) > 0 {
+			if err := json.Unmarshal(metadataJSON, &session.Metadata); err != nil {
+				return nil, fmt.Errorf("failed to unmarshal metadata: %w", err)
+			}
+		}
+
+		sessions = append(sessions, session)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating sessions: %w", err)
+	}
+	return sessions, nil
+}
+
+// DeleteMessages removes messages matching the filter.
+func (s *CockroachStore) DeleteMessages(ctx context.Context, filter MessageFilter) (int64, error) {
+	if err := filter.validate(); err != nil {
+		return 0, err
+	}
+	query := "DELETE FROM messages WHERE 1=1"
+	var args []interface{}
+	argPos := 1
+
+	if filter.SessionID != "" {
+		query += fmt.Sprintf(" AND session_id = $%d", argPos)
+		args = append(args, filter.SessionID)
+		argPos++
+	}
+	if filter.Channel != "" {
+		query += fmt.Sprintf(" AND channel = $%d", argPos)
+		args = append(args, filter.Channel)
+		argPos++
+	}
+	if filter.ChannelID != "" {
+		query += fmt.Sprintf(" AND channel_id = $%d", argPos)
+		args = append(args, filter.ChannelID)
+		argPos++
+	}
+	if !filter.CreatedBefore.IsZero() {
+		query += fmt.Sprintf(" AND created_at < $%d", argPos)
+		args = append(args, filter.CreatedBefore)
+	}
+
+	result, err := s.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete messages: %w", err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	return rows, nil
+}
@@ -0,0 +1,106 @@
+package sessions
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/haasonsaas/nexus/pkg/models"
+)
+
+type retentionTestStore interface {
+	Store
+	RetentionStore
+}
+
+func TestRetentionStores(t *testing.T) {
+	stores := map[string]func(t *testing.T) retentionTestStore{
+		"memory": func(t *testing.T) retentionTestStore {
+			return NewMemoryStore()
+		},
+		"sqlite": func(t *testing.T) retentionTestStore {
+			return newTestSQLiteStore(t)
+		},
+	}
+
+	for name, newStore := range stores {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			store := newStore(t)
+			now := time.Now()
+
+			old := &models.Session{
+				ID: generateID(), AgentID: "a", Channel: models.ChannelSlack, ChannelID: "U1",
+				Key: "a:slack:U1", CreatedAt: now.Add(-48 * time.Hour), UpdatedAt: now.Add(-48 * time.Hour),
+			}
+			recent := &models.Session{
+				ID: generateID(), AgentID: "b", Channel: models.ChannelTelegram, ChannelID: "42",
+				Key: "b:telegram:42", CreatedAt: now, UpdatedAt: now,
+			}
+			for _, session := range []*models.Session{old, recent} {
+				if err := store.Create(ctx, session); err != nil {
+					t.Fatalf("Create() error = %v", err)
+				}
+			}
+
+			all, err := store.FindSessions(ctx, SessionFilter{})
+			if err != nil {
+				t.Fatalf("FindSessions() error = %v", err)
+			}
+			if len(all) != 2 || all[0].ID != recent.ID {
+				t.Fatalf("expected both sessions newest first, got %d", len(all))
+			}
+
+			stale, err := store.FindSessions(ctx, SessionFilter{UpdatedBefore: now.Add(-time.Hour)})
+			if err != nil {
+				t.Fatalf("FindSessions() error = %v", err)
+			}
+			if len(stale) != 1 || stale[0].ID != old.ID {
+				t.Fatalf("expected only the stale session, got %+v", stale)
+			}
+
+			slack, err := store.FindSessions(ctx, SessionFilter{Channel: models.ChannelSlack})
+			if err != nil {
+				t.Fatalf("FindSessions() error = %v", err)
+			}
+			if len(slack) != 1 || slack[0].ID != old.ID {
+				t.Fatalf("expected only the slack session, got %+v", slack)
+			}
+
+			for i, created := range []time.Time{now.Add(-72 * time.Hour), now.Add(-time.Minute)} {
+				msg := &models.Message{
+					ID: generateID(), SessionID: recent.ID, Channel: models.ChannelTelegram, ChannelID: "42",
+					Direction: models.DirectionInbound, Role: models.RoleUser, Content: "hi", CreatedAt: created,
+				}
+				if err := store.AppendMessage(ctx, recent.ID, msg); err != nil {
+					t.Fatalf("AppendMessage(%d) error = %v", i, err)
+				}
+			}
+
+			if _, err := store.DeleteMessages(ctx, MessageFilter{}); err == nil {
+				t.Fatalf("expected error for an unscoped message filter")
+			}
+			deleted, err := store.DeleteMessages(ctx, MessageFilter{SessionID: recent.ID, CreatedBefore: now.Add(-24 * time.Hour)})
+			if err != nil {
+				t.Fatalf("DeleteMessages() error = %v", err)
+			}
+			if deleted != 1 {
+				t.Fatalf("expected 1 deleted message, got %d", deleted)
+			}
+			deleted, err = store.DeleteMessages(ctx, MessageFilter{Channel: models.ChannelTelegram, ChannelID: "42"})
+			if err != nil {
+				t.Fatalf("DeleteMessages() error = %v", err)
+			}
+			if deleted != 1 {
+				t.Fatalf("expected 1 deleted message, got %d", deleted)
+			}
+			history, err := store.GetHistory(ctx, recent.ID, 0)
+			if err != nil {
+				t.Fatalf("GetHistory() error = %v", err)
+			}
+			if len(history) != 0 {
+				t.Fatalf("expected empty history, got %d messages", len(history))
+			}
+		})
+	}
+}
@@ -0,0 +1,58 @@
+package sessions
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/haasonsaas/nexus/internal/storage/sqlite"
+)
+
+// SQLiteStore implements the Store interface using a local SQLite database.
+// The session queries are portable and shared with CockroachStore; only the
+// schema differs, and it is migrated when the store is opened.
+type SQLiteStore struct {
+	*CockroachStore
+}
+
+// OpenSQLite opens the SQLite database addressed by dsn (sqlite:///path.db)
+// and applies any pending SQLite migrations.
+func OpenSQLite(ctx context.Context, dsn string) (*sql.DB, error) {
+	db, err := sqlite.Open(dsn)
+	if err != nil {
+		return nil, err
+	}
+	migrator, err := NewMigratorForDialect(db, DialectSQLite)
+	if err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+	if _, err := migrator.Up(ctx, 0); err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("migrate sqlite database: %w", err)
+	}
+	return db, nil
+}
+
+// NewSQLiteStore opens a SQLite-backed session store.
+func NewSQLiteStore(dsn string) (*SQLiteStore, error) {
+	db, err := OpenSQLite(context.Background(), dsn)
+	if err != nil {
+		return nil, err
+	}
+	store := &CockroachStore{db: db}
+	if err := store.prepareStatements(); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to prepare statements: %w", err)
+	}
+	return &SQLiteStore{CockroachStore: store}, nil
+}
+
+// NewStoreFromURL opens the session store selected by a database URL:
+// SQLite for sqlite:// URLs, CockroachDB/Postgres otherwise.
+func NewStoreFromURL(dsn string, config *CockroachConfig) (Store, error) {
+	if sqlite.IsURL(dsn) {
+		return NewSQLiteStore(dsn)
+	}
+	return NewCockroachStoreFromDSN(dsn, config)
+}
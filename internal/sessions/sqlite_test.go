@@ -0,0 +1,228 @@
+package sessions
+
+import (
+	"context"
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/haasonsaas/nexus/pkg/models"
+)
+
+func newTestSQLiteStore(t *testing.T) *SQLiteStore {
+	t.Helper()
+	store, err := NewSQLiteStore("sqlite://" + filepath.Join(t.TempDir(), "nexus.db"))
+	if err != nil {
+		t.Fatalf("NewSQLiteStore() error = %v", err)
+	}
+	t.Cleanup(func() { _ = store.Close() })
+	return store
+}
+
+func TestSQLiteStoreSessionLifecycle(t *testing.T) {
+	ctx := context.Background()
+	store := newTestSQLiteStore(t)
+
+	now := time.Now()
+	session := &models.Session{
+		ID:        generateID(),
+		AgentID:   "agent",
+		Channel:   models.ChannelType("api"),
+		ChannelID: "user",
+		Key:       "agent:api:user",
+		Metadata:  map[string]any{"source": "test"},
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if err := store.Create(ctx, session); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	loaded, err := store.GetByKey(ctx, session.Key)
+	if err != nil {
+		t.Fatalf("GetByKey() error = %v", err)
+	}
+	if loaded.ID != session.ID || loaded.Metadata["source"] != "test" {
+		t.Fatalf("unexpected session %+v", loaded)
+	}
+	if !loaded.CreatedAt.Equal(now) {
+		t.Fatalf("expected created_at %v, got %v", now, loaded.CreatedAt)
+	}
+
+	again, err := store.GetOrCreate(ctx, session.Key, "agent", models.ChannelType("api"), "user")
+	if err != nil {
+		t.Fatalf("GetOrCreate() error = %v", err)
+	}
+	if again.ID != session.ID {
+		t.Fatalf("expected existing session %q, got %q", session.ID, again.ID)
+	}
+
+	for i, content := range []string{"first", "second", "third"} {
+		msg := &models.Message{
+			ID:        generateID(),
+			Channel:   models.ChannelType("api"),
+			Direction: models.DirectionInbound,
+			Role:      models.RoleUser,
+			Content:   content,
+			CreatedAt: now.Add(time.Duration(i) * time.Second),
+		}
+		if err := store.AppendMessage(ctx, session.ID, msg); err != nil {
+			t.Fatalf("AppendMessage() error = %v", err)
+		}
+	}
+	history, err := store.GetHistory(ctx, session.ID, 2)
+	if err != nil {
+		t.Fatalf("GetHistory() error = %v", err)
+	}
+	if len(history) != 2 || history[0].Content != "second" || history[1].Content != "third" {
+		t.Fatalf("unexpected history %+v", history)
+	}
+
+	listed, err := store.List(ctx, "agent", ListOptions{Limit: 10})
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if len(listed) != 1 {
+		t.Fatalf("expected 1 session, got %d", len(listed))
+	}
+
+	if err := store.Delete(ctx, session.ID); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	history, err = store.GetHistory(ctx, session.ID, 10)
+	if err != nil {
+		t.Fatalf("GetHistory() error = %v", err)
+	}
+	if len(history) != 0 {
+		t.Fatalf("expected messages to be deleted with session, got %d", len(history))
+	}
+}
+
+func TestSQLiteMigratorUpDown(t *testing.T) {
+	ctx := context.Background()
+	store := newTestSQLiteStore(t)
+
+	migrator, err := NewMigratorForDialect(store.DB(), DialectSQLite)
+	if err != nil {
+		t.Fatalf("NewMigratorForDialect() error = %v", err)
+	}
+	applied, pending, err := migrator.Status(ctx)
+	if err != nil {
+		t.Fatalf("Status() error = %v", err)
+	}
+	if len(applied) == 0 || len(pending) != 0 {
+		t.Fatalf("expected all migrations applied on open, got %d applied, %d pending", len(applied), len(pending))
+	}
+
+	rolled, err := migrator.Down(ctx, len(applied))
+	if err != nil {
+		t.Fatalf("Down() error = %v", err)
+	}
+	if len(rolled) != len(applied) {
+		t.Fatalf("expected %d rollbacks, got %d", len(applied), len(rolled))
+	}
+	reapplied, err := migrator.Up(ctx, 0)
+	if err != nil {
+		t.Fatalf("Up() error = %v", err)
+	}
+	if len(reapplied) != len(applied) {
+		t.Fatalf("expected %d migrations reapplied, got %d", len(applied), len(reapplied))
+	}
+}
+
+func TestSQLiteBranchStore(t *testing.T) {
+	ctx := context.Background()
+	store := newTestSQLiteStore(t)
+	branches := NewSQLiteBranchStore(store.DB())
+
+	now := time.Now()
+	session := &models.Session{
+		ID:        generateID(),
+		AgentID:   "agent",
+		Channel:   models.ChannelType("api"),
+		ChannelID: "user",
+		Key:       "agent:api:branches",
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if err := store.Create(ctx, session); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	primary, err := branches.EnsurePrimaryBranch(ctx, session.ID)
+	if err != nil {
+		t.Fatalf("EnsurePrimaryBranch() error = %v", err)
+	}
+	appendTo := func(branchID string, contents ...string) {
+		t.Helper()
+		for _, content := range contents {
+			msg := &models.Message{Channel: models.ChannelType("api"), Direction: models.DirectionInbound, Role: models.RoleUser, Content: content}
+			if err := branches.AppendMessageToBranch(ctx, session.ID, branchID, msg); err != nil {
+				t.Fatalf("AppendMessageToBranch() error = %v", err)
+			}
+		}
+	}
+	appendTo(primary.ID, "one", "two", "three")
+
+	fork, err := branches.ForkBranch(ctx, primary.ID, 2, "alt")
+	if err != nil {
+		t.Fatalf("ForkBranch() error = %v", err)
+	}
+	appendTo(fork.ID, "alt-three")
+
+	history, err := branches.GetBranchHistory(ctx, fork.ID, 10)
+	if err != nil {
+		t.Fatalf("GetBranchHistory() error = %v", err)
+	}
+	var got []string
+	for _, msg := range history {
+		got = append(got, msg.Content)
+	}
+	if want := "one two alt-three"; strings.Join(got, " ") != want {
+		t.Fatalf("history = %q, want %q", strings.Join(got, " "), want)
+	}
+
+	if _, err := branches.MergeBranch(ctx, fork.ID, primary.ID, models.MergeStrategyContinue); err != nil {
+		t.Fatalf("MergeBranch() error = %v", err)
+	}
+	stats, err := branches.GetBranchStats(ctx, primary.ID)
+	if err != nil {
+		t.Fatalf("GetBranchStats() error = %v", err)
+	}
+	if stats.OwnMessages != 3 || stats.LastMessageAt == nil {
+		t.Fatalf("unexpected stats %+v", stats)
+	}
+}
+
+func TestSQLiteToolEventStore(t *testing.T) {
+	ctx := context.Background()
+	store := newTestSQLiteStore(t)
+	events := NewSQLToolEventStore(store.DB())
+
+	call := &ToolCall{ID: "call-1", ToolName: "search", InputJSON: json.RawMessage(`{"q":"nexus"}`)}
+	if err := events.AddToolCall(ctx, "session-1", "msg-1", call); err != nil {
+		t.Fatalf("AddToolCall() error = %v", err)
+	}
+	if err := events.AddToolCall(ctx, "session-1", "msg-1", call); err != nil {
+		t.Fatalf("AddToolCall() duplicate error = %v", err)
+	}
+	if err := events.AddToolResult(ctx, "session-1", "", "call-1", &ToolResult{IsError: true, Content: "boom"}); err != nil {
+		t.Fatalf("AddToolResult() error = %v", err)
+	}
+
+	calls, err := events.GetToolCallsByMessage(ctx, "msg-1")
+	if err != nil {
+		t.Fatalf("GetToolCallsByMessage() error = %v", err)
+	}
+	if len(calls) != 1 || calls[0].ToolName != "search" || string(calls[0].InputJSON) != `{"q":"nexus"}` || calls[0].CreatedAt.IsZero() {
+		t.Fatalf("unexpected calls %+v", calls)
+	}
+	results, err := events.GetToolResults(ctx, "session-1", 10)
+	if err != nil {
+		t.Fatalf("GetToolResults() error = %v", err)
+	}
+	if len(results) != 1 || results[0].ID == "" || !results[0].IsError || results[0].ToolCallID != "call-1" || results[0].MessageID != "" {
+		t.Fatalf("unexpected results %+v", results)
+	}
+}
@@ -6,6 +6,8 @@ import (
 	"encoding/json"
 	"sync"
 	"time"
+
+	"github.com/google/uuid"
 )
 
 // ToolCall represents a tool call made by the LLM.
@@ -57,17 +59,18 @@ func NewSQLToolEventStore(db *sql.DB) *SQLToolEventStore {
 	return &SQLToolEventStore{db: db}
 }
 
-// AddToolCall records a tool call.
+// AddToolCall records a tool call. Ids and timestamps are supplied here
+// rather than by column defaults so the same statements run on SQLite.
 func (s *SQLToolEventStore) AddToolCall(ctx context.Context, sessionID, messageID string, call *ToolCall) error {
 	if call == nil {
 		return nil
 	}
 
 	_, err := s.db.ExecContext(ctx, `
-		INSERT INTO tool_calls (id, session_id, message_id, tool_name, input_json)
-		VALUES ($1, $2, NULLIF($3, ''), $4, $5)
+		INSERT INTO tool_calls (id, session_id, message_id, tool_name, input_json, created_at)
+		VALUES ($1, $2, NULLIF($3, ''), $4, $5, $6)
 		ON CONFLICT (id) DO NOTHING
-	`, call.ID, sessionID, messageID, call.ToolName, call.InputJSON)
+	`, call.ID, sessionID, messageID, call.ToolName, call.InputJSON, toolEventTime(call.CreatedAt))
 
 	return err
 }
@@ -78,14 +81,26 @@ func (s *SQLToolEventStore) AddToolResult(ctx context.Context, sessionID, messag
 		return nil
 	}
 
+	id := result.ID
+	if id == "" {
+		id = uuid.NewString()
+	}
 	_, err := s.db.ExecContext(ctx, `
-		INSERT INTO tool_results (session_id, message_id, tool_call_id, is_error, content)
-		VALUES ($1, NULLIF($2, ''), $3, $4, $5)
-	`, sessionID, messageID, callID, result.IsError, result.Content)
+		INSERT INTO tool_results (id, session_id, message_id, tool_call_id, is_error, content, created_at)
+		VALUES ($1, $2, NULLIF($3, ''), $4, $5, $6, $7)
+	`, id, sessionID, messageID, callID, result.IsError, result.Content, toolEventTime(result.CreatedAt))
 
 	return err
 }
 
+// toolEventTime returns t, or the current time when t is unset.
+func toolEventTime(t time.Time) time.Time {
+	if t.IsZero() {
+		return time.Now()
+	}
+	return t
+}
+
 // GetToolCalls retrieves recent tool calls for a session.
 func (s *SQLToolEventStore) GetToolCalls(ctx context.Context, sessionID string, limit int) ([]ToolCall, error) {
 	if limit <= 0 {
@@ -0,0 +1,86 @@
+package slo
+
+import (
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// Metrics exports SLO evaluations in the shape of the usual recording rules,
+// so dashboards and alert rules can use them without recomputing windows.
+type Metrics struct {
+	// Target is the objective's target ratio.
+	// Labels: slo
+	Target *prometheus.GaugeVec
+
+	// Compliance is the share of good events over the compliance window.
+	// Labels: slo
+	Compliance *prometheus.GaugeVec
+
+	// BudgetRemaining is the unspent share of the error budget.
+	// Labels: slo
+	BudgetRemaining *prometheus.GaugeVec
+
+	// BurnRate is how fast the error budget is spent relative to the rate
+	// that exhausts it exactly at the end of the window.
+	// Labels: slo, window (5m|30m|1h|6h)
+	BurnRate *prometheus.GaugeVec
+
+	// AlertLevel is 0 when ok, 1 for warning and 2 for critical.
+	// Labels: slo
+	AlertLevel *prometheus.GaugeVec
+}
+
+var (
+	metricsOnce     sync.Once
+	metricsInstance *Metrics
+)
+
+// NewMetrics returns the process-wide SLO metrics.
+func NewMetrics() *Metrics {
+	metricsOnce.Do(func() {
+		metricsInstance = &Metrics{
+			Target: promauto.NewGaugeVec(prometheus.GaugeOpts{
+				Name: "nexus_slo_target_ratio",
+				Help: "Target share of good events for each SLO",
+			}, []string{"slo"}),
+			Compliance: promauto.NewGaugeVec(prometheus.GaugeOpts{
+				Name: "nexus_slo_compliance_ratio",
+				Help: "Share of good events over the SLO compliance window",
+			}, []string{"slo"}),
+			BudgetRemaining: promauto.NewGaugeVec(prometheus.GaugeOpts{
+				Name: "nexus_slo_error_budget_remaining_ratio",
+				Help: "Unspent share of the SLO error budget",
+			}, []string{"slo"}),
+			BurnRate: promauto.NewGaugeVec(prometheus.GaugeOpts{
+				Name: "nexus_slo_burn_rate",
+				Help: "Error budget burn rate over each lookback window",
+			}, []string{"slo", "window"}),
+			AlertLevel: promauto.NewGaugeVec(prometheus.GaugeOpts{
+				Name: "nexus_slo_alert_level",
+				Help: "SLO burn-rate alert level (0 ok, 1 warning, 2 critical)",
+			}, []string{"slo"}),
+		}
+	})
+	return metricsInstance
+}
+
+func (m *Metrics) record(s Status) {
+	if m == nil {
+		return
+	}
+	m.Compliance.WithLabelValues(s.Name).Set(s.Compliance)
+	m.BudgetRemaining.WithLabelValues(s.Name).Set(s.BudgetRemaining)
+	for window, rate := range s.BurnRates {
+		m.BurnRate.WithLabelValues(s.Name, window).Set(rate)
+	}
+	level := 0.0
+	switch s.Level {
+	case LevelWarning:
+		level = 1
+	case LevelCritical:
+		level = 2
+	}
+	m.AlertLevel.WithLabelValues(s.Name).Set(level)
+}
@@ -0,0 +1,348 @@
+// Package slo evaluates service level objectives against the gateway's
+// Prometheus metrics, exports compliance and burn rates as gauges, and
+// raises multiwindow burn-rate alerts.
+package slo
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+
+	"github.com/haasonsaas/nexus/internal/config"
+)
+
+// Level is the alert level of an objective.
+type Level string
+
+const (
+	LevelOK       Level = "ok"
+	LevelWarning  Level = "warning"
+	LevelCritical Level = "critical"
+)
+
+// burnWindows are the lookbacks burn rates are computed over. Critical
+// alerts pair 1h with 5m and warnings pair 6h with 30m, so an alert needs a
+// sustained burn and clears soon after the burn stops.
+var burnWindows = []struct {
+	Name     string
+	Duration time.Duration
+}{
+	{"5m", 5 * time.Minute},
+	{"30m", 30 * time.Minute},
+	{"1h", time.Hour},
+	{"6h", 6 * time.Hour},
+}
+
+// Status is the result of evaluating an objective.
+type Status struct {
+	Name   string  `json:"name"`
+	Target float64 `json:"target"`
+	// Compliance is the share of good events over the compliance window,
+	// or 1 when there were no events.
+	Compliance float64 `json:"compliance"`
+	// BudgetRemaining is the unspent share of the error budget; it goes
+	// negative once the objective is missed.
+	BudgetRemaining float64            `json:"error_budget_remaining"`
+	BurnRates       map[string]float64 `json:"burn_rates"`
+	Good            float64            `json:"good_events"`
+	Total           float64            `json:"total_events"`
+	Level           Level              `json:"level"`
+	EvaluatedAt     time.Time          `json:"evaluated_at"`
+}
+
+// Alert reports an objective changing alert level.
+type Alert struct {
+	Status
+	Previous Level `json:"previous_level"`
+}
+
+// AlertFunc delivers an alert.
+type AlertFunc func(ctx context.Context, alert Alert) error
+
+// sample is a cumulative reading of an objective's good and total events.
+type sample struct {
+	at    time.Time
+	good  float64
+	total float64
+}
+
+type objective struct {
+	cfg     config.SLOObjectiveConfig
+	samples []sample
+	level   Level
+}
+
+// Tracker periodically samples metrics and evaluates objectives. Samples
+// are kept in memory for the compliance window.
+type Tracker struct {
+	cfg      config.SLOConfig
+	gatherer prometheus.Gatherer
+	logger   *slog.Logger
+	metrics  *Metrics
+	now      func() time.Time
+
+	mu         sync.Mutex
+	objectives []*objective
+	alert      AlertFunc
+}
+
+// NewTracker creates a tracker for the configured objectives. A nil
+// gatherer reads the default Prometheus registry.
+func NewTracker(cfg config.SLOConfig, gatherer prometheus.Gatherer, logger *slog.Logger) *Tracker {
+	if gatherer == nil {
+		gatherer = prometheus.DefaultGatherer
+	}
+	if logger == nil {
+		logger = slog.Default()
+	}
+	t := &Tracker{
+		cfg:      cfg,
+		gatherer: gatherer,
+		logger:   logger.With("component", "slo"),
+		metrics:  NewMetrics(),
+		now:      time.Now,
+	}
+	for _, o := range cfg.Objectives {
+		t.objectives = append(t.objectives, &objective{cfg: o, level: LevelOK})
+		t.metrics.Target.WithLabelValues(o.Name).Set(o.Target)
+	}
+	return t
+}
+
+// SetAlert sets the function called when an objective's level changes.
+func (t *Tracker) SetAlert(fn AlertFunc) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.alert = fn
+}
+
+// Run evaluates objectives every interval until ctx is done.
+func (t *Tracker) Run(ctx context.Context) {
+	interval := t.cfg.Interval
+	if interval <= 0 {
+		interval = 30 * time.Second
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	t.Evaluate(ctx)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			t.Evaluate(ctx)
+		}
+	}
+}
+
+// Evaluate samples the metrics once, updates the SLO gauges and sends
+// alerts for objectives whose level changed.
+func (t *Tracker) Evaluate(ctx context.Context) []Status {
+	families, err := t.gatherer.Gather()
+	if err != nil {
+		// Gather returns what it could collect alongside the error.
+		t.logger.Warn("failed to gather metrics", "error", err)
+	}
+	now := t.now()
+
+	t.mu.Lock()
+	statuses := make([]Status, 0, len(t.objectives))
+	var alerts []Alert
+	for _, o := range t.objectives {
+		good, total := measure(families, o.cfg)
+		o.record(sample{at: now, good: good, total: total}, t.window())
+		status := o.status(now, t.window())
+		status.Level = t.level(status)
+		if status.Level != o.level {
+			alerts = append(alerts, Alert{Status: status, Previous: o.level})
+			o.level = status.Level
+		}
+		t.metrics.record(status)
+		statuses = append(statuses, status)
+	}
+	alert := t.alert
+	t.mu.Unlock()
+
+	for _, a := range alerts {
+		t.logger.Warn("slo alert level changed",
+			"slo", a.Name,
+			"level", a.Level,
+			"previous", a.Previous,
+			"burn_rate_1h", a.BurnRates["1h"],
+			"burn_rate_6h", a.BurnRates["6h"],
+			"error_budget_remaining", a.BudgetRemaining,
+		)
+		if alert == nil {
+			continue
+		}
+		if err := alert(ctx, a); err != nil {
+			t.logger.Warn("failed to send slo alert", "slo", a.Name, "error", err)
+		}
+	}
+	return statuses
+}
+
+func (t *Tracker) window() time.Duration {
+	if t.cfg.Window <= 0 {
+		return 30 * 24 * time.Hour
+	}
+	return t.cfg.Window
+}
+
+// level applies the multiwindow burn-rate thresholds.
+func (t *Tracker) level(s Status) Level {
+	critical, warning := t.cfg.Alerts.CriticalBurnRate, t.cfg.Alerts.WarningBurnRate
+	if critical > 0 && s.BurnRates["1h"] >= critical && s.BurnRates["5m"] >= critical {
+		return LevelCritical
+	}
+	if warning > 0 && s.BurnRates["6h"] >= warning && s.BurnRates["30m"] >= warning {
+		return LevelWarning
+	}
+	return LevelOK
+}
+
+// record appends a sample and drops samples older than the window, keeping
+// the newest one before the cutoff as the window's baseline.
+func (o *objective) record(s sample, window time.Duration) {
+	o.samples = append(o.samples, s)
+	cutoff := s.at.Add(-window)
+	drop := 0
+	for drop+1 < len(o.samples) && !o.samples[drop+1].at.After(cutoff) {
+		drop++
+	}
+	if drop > 0 {
+		o.samples = append(o.samples[:0], o.samples[drop:]...)
+	}
+}
+
+// since returns the good and total events between the newest sample at or
+// before now-d (or the oldest sample) and the latest sample.
+func (o *objective) since(now time.Time, d time.Duration) (good, total float64) {
+	if len(o.samples) == 0 {
+		return 0, 0
+	}
+	latest := o.samples[len(o.samples)-1]
+	base := o.samples[0]
+	cutoff := now.Add(-d)
+	for _, s := range o.samples {
+		if s.at.After(cutoff) {
+			break
+		}
+		base = s
+	}
+	good, total = latest.good-base.good, latest.total-base.total
+	if good < 0 || total < 0 {
+		// The counters were reset, e.g. by re-registering a metric.
+		good, total = latest.good, latest.total
+	}
+	return good, total
+}
+
+func (o *objective) status(now time.Time, window time.Duration) Status {
+	target := o.cfg.Target
+	budget := 1 - target
+	good, total := o.since(now, window)
+	status := Status{
+		Name:        o.cfg.Name,
+		Target:      target,
+		Compliance:  1,
+		Good:        good,
+		Total:       total,
+		BurnRates:   make(map[string]float64, len(burnWindows)),
+		EvaluatedAt: now,
+	}
+	if total > 0 {
+		status.Compliance = good / total
+	}
+	status.BudgetRemaining = 1
+	if budget > 0 {
+		status.BudgetRemaining = 1 - (1-status.Compliance)/budget
+	}
+	for _, w := range burnWindows {
+		good, total := o.since(now, w.Duration)
+		rate := 0.0
+		if total > 0 && budget > 0 {
+			rate = (total - good) / total / budget
+		}
+		status.BurnRates[w.Name] = rate
+	}
+	return status
+}
+
+// measure reads an objective's cumulative good and total events.
+func measure(families []*dto.MetricFamily, o config.SLOObjectiveConfig) (good, total float64) {
+	switch {
+	case o.Latency != nil:
+		threshold := o.Latency.Threshold.Seconds()
+		for _, m := range series(families, o.Latency.Metric, o.Latency.Labels) {
+			h := m.GetHistogram()
+			if h == nil {
+				continue
+			}
+			total += float64(h.GetSampleCount())
+			// Buckets are cumulative; use the largest one within the threshold.
+			var within uint64
+			for _, b := range h.GetBucket() {
+				if b.GetUpperBound() <= threshold {
+					within = b.GetCumulativeCount()
+				}
+			}
+			good += float64(within)
+		}
+	case o.Errors != nil:
+		for _, m := range series(families, o.Errors.Metric, o.Errors.Labels) {
+			c := m.GetCounter()
+			if c == nil {
+				continue
+			}
+			value := c.GetValue()
+			total += value
+			if !hasLabel(m, o.Errors.Label, o.Errors.Values) {
+				good += value
+			}
+		}
+	}
+	return good, total
+}
+
+// series returns the metrics of the named family that carry all labels.
+func series(families []*dto.MetricFamily, name string, labels map[string]string) []*dto.Metric {
+	for _, f := range families {
+		if f.GetName() != name {
+			continue
+		}
+		var out []*dto.Metric
+		for _, m := range f.GetMetric() {
+			matched := 0
+			for _, pair := range m.GetLabel() {
+				if want, ok := labels[pair.GetName()]; ok && want == pair.GetValue() {
+					matched++
+				}
+			}
+			if matched == len(labels) {
+				out = append(out, m)
+			}
+		}
+		return out
+	}
+	return nil
+}
+
+func hasLabel(m *dto.Metric, name string, values []string) bool {
+	for _, pair := range m.GetLabel() {
+		if pair.GetName() != name {
+			continue
+		}
+		for _, v := range values {
+			if pair.GetValue() == v {
+				return true
+			}
+		}
+	}
+	return false
+}
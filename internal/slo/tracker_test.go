@@ -0,0 +1,163 @@
+package slo
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/haasonsaas/nexus/internal/config"
+)
+
+type testMetrics struct {
+	registry *prometheus.Registry
+	duration *prometheus.HistogramVec
+	runs     *prometheus.CounterVec
+}
+
+func newTestMetrics() *testMetrics {
+	m := &testMetrics{
+		registry: prometheus.NewRegistry(),
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "nexus_reply_duration_seconds",
+			Buckets: []float64{1, 10, 15, 30},
+		}, []string{"channel"}),
+		runs: prometheus.NewCounterVec(prometheus.CounterOpts{Name: "nexus_runs_total"}, []string{"channel", "outcome"}),
+	}
+	m.registry.MustRegister(m.duration, m.runs)
+	return m
+}
+
+func (m *testMetrics) replies(channel string, n int, seconds float64) {
+	for i := 0; i < n; i++ {
+		m.duration.WithLabelValues(channel).Observe(seconds)
+	}
+}
+
+func newTestTracker(m *testMetrics, objectives ...config.SLOObjectiveConfig) (*Tracker, *time.Time) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	tracker := NewTracker(config.SLOConfig{
+		Window:     24 * time.Hour,
+		Objectives: objectives,
+		Alerts:     config.SLOAlertConfig{CriticalBurnRate: 14.4, WarningBurnRate: 6},
+	}, m.registry, nil)
+	tracker.now = func() time.Time { return now }
+	return tracker, &now
+}
+
+func approx(a, b float64) bool { return math.Abs(a-b) < 1e-9 }
+
+func TestLatencyObjective(t *testing.T) {
+	m := newTestMetrics()
+	tracker, now := newTestTracker(m, config.SLOObjectiveConfig{
+		Name:    "slack_latency",
+		Target:  0.95,
+		Latency: &config.SLOLatencyConfig{Metric: "nexus_reply_duration_seconds", Threshold: 12 * time.Second, Labels: map[string]string{"channel": "slack"}},
+	})
+	m.replies("slack", 5, 0.5)
+	tracker.Evaluate(context.Background())
+
+	// 12s falls between buckets, so only replies within 10s count as good.
+	m.replies("slack", 90, 2)
+	m.replies("slack", 10, 11)
+	m.replies("discord", 50, 60)
+	*now = now.Add(time.Minute)
+	status := tracker.Evaluate(context.Background())[0]
+
+	if status.Total != 100 || status.Good != 90 || !approx(status.Compliance, 0.9) {
+		t.Fatalf("unexpected counts: %+v", status)
+	}
+	if !approx(status.BudgetRemaining, -1) || !approx(status.BurnRates["5m"], 2) {
+		t.Fatalf("expected the budget to be spent twice over, got %+v", status)
+	}
+	if status.Level != LevelOK {
+		t.Fatalf("expected a burn rate of 2 not to alert, got %s", status.Level)
+	}
+}
+
+func TestErrorObjectiveAlerts(t *testing.T) {
+	m := newTestMetrics()
+	tracker, now := newTestTracker(m, config.SLOObjectiveConfig{
+		Name:   "run_errors",
+		Target: 0.99,
+		Errors: &config.SLOErrorsConfig{Metric: "nexus_runs_total", Label: "outcome", Values: []string{"error"}},
+	})
+	var alerts []Alert
+	tracker.SetAlert(func(_ context.Context, alert Alert) error {
+		alerts = append(alerts, alert)
+		return nil
+	})
+	tracker.Evaluate(context.Background())
+
+	m.runs.WithLabelValues("slack", "completed").Add(80)
+	m.runs.WithLabelValues("slack", "error").Add(20)
+	*now = now.Add(time.Minute)
+	status := tracker.Evaluate(context.Background())[0]
+	if !approx(status.BurnRates["1h"], 20) || status.Level != LevelCritical {
+		t.Fatalf("expected a critical burn, got %+v", status)
+	}
+	if len(alerts) != 1 || alerts[0].Level != LevelCritical || alerts[0].Previous != LevelOK {
+		t.Fatalf("unexpected alerts: %+v", alerts)
+	}
+
+	// Errors stop: the 5m window clears first, while the 6h window still
+	// remembers the burn.
+	*now = now.Add(10 * time.Minute)
+	m.runs.WithLabelValues("slack", "completed").Add(1000)
+	status = tracker.Evaluate(context.Background())[0]
+	if status.BurnRates["5m"] != 0 || status.Level != LevelOK {
+		t.Fatalf("expected the alert to resolve, got %+v", status)
+	}
+	if len(alerts) != 2 || alerts[1].Level != LevelOK || alerts[1].Previous != LevelCritical {
+		t.Fatalf("unexpected alerts: %+v", alerts)
+	}
+}
+
+func TestSamplesArePrunedToWindow(t *testing.T) {
+	m := newTestMetrics()
+	tracker, now := newTestTracker(m, config.SLOObjectiveConfig{
+		Name:   "run_errors",
+		Target: 0.99,
+		Errors: &config.SLOErrorsConfig{Metric: "nexus_runs_total", Label: "outcome", Values: []string{"error"}},
+	})
+	tracker.Evaluate(context.Background())
+	m.runs.WithLabelValues("slack", "error").Add(5)
+	for i := 0; i < 30; i++ {
+		*now = now.Add(time.Hour)
+		m.runs.WithLabelValues("slack", "completed").Inc()
+		tracker.Evaluate(context.Background())
+	}
+	if got := len(tracker.objectives[0].samples); got != 25 {
+		t.Fatalf("expected a day of hourly samples plus a baseline, got %d", got)
+	}
+	status := tracker.Evaluate(context.Background())[0]
+	if status.Total != 24 || status.Compliance != 1 {
+		t.Fatalf("expected errors outside the window to be dropped, got %+v", status)
+	}
+}
+
+func TestWebhookAlert(t *testing.T) {
+	var got Alert
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if err := json.Unmarshal(body, &got); err != nil {
+			t.Errorf("unmarshal: %v", err)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	alert := Alert{Status: Status{Name: "run_errors", Level: LevelCritical, BurnRates: map[string]float64{"1h": 20}}, Previous: LevelOK}
+	if err := WebhookAlert(srv.URL, srv.Client())(context.Background(), alert); err != nil {
+		t.Fatalf("WebhookAlert: %v", err)
+	}
+	if got.Name != "run_errors" || got.Level != LevelCritical || got.BurnRates["1h"] != 20 {
+		t.Fatalf("unexpected payload %+v", got)
+	}
+}
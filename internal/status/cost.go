@@ -6,6 +6,7 @@ import (
 	"strings"
 
 	"github.com/haasonsaas/nexus/internal/config"
+	"github.com/haasonsaas/nexus/internal/models"
 )
 
 // ModelCostConfig contains pricing information per million tokens.
@@ -15,47 +16,8 @@ type ModelCostConfig struct {
 	CachedInputPer1M float64
 }
 
-// DefaultModelCosts contains default pricing for common models.
-// Prices are per million tokens.
-var DefaultModelCosts = map[string]map[string]ModelCostConfig{
-	"anthropic": {
-		"claude-3-5-sonnet-20241022": {InputPer1M: 3.0, OutputPer1M: 15.0, CachedInputPer1M: 0.30},
-		"claude-3-5-sonnet-latest":   {InputPer1M: 3.0, OutputPer1M: 15.0, CachedInputPer1M: 0.30},
-		"claude-sonnet-4-20250514":   {InputPer1M: 3.0, OutputPer1M: 15.0, CachedInputPer1M: 0.30},
-		"claude-3-5-haiku-20241022":  {InputPer1M: 1.0, OutputPer1M: 5.0, CachedInputPer1M: 0.10},
-		"claude-3-5-haiku-latest":    {InputPer1M: 1.0, OutputPer1M: 5.0, CachedInputPer1M: 0.10},
-		"claude-3-opus-20240229":     {InputPer1M: 15.0, OutputPer1M: 75.0, CachedInputPer1M: 1.50},
-		"claude-3-opus-latest":       {InputPer1M: 15.0, OutputPer1M: 75.0, CachedInputPer1M: 1.50},
-		"claude-opus-4-20250514":     {InputPer1M: 15.0, OutputPer1M: 75.0, CachedInputPer1M: 1.50},
-		"claude-3-haiku-20240307":    {InputPer1M: 0.25, OutputPer1M: 1.25, CachedInputPer1M: 0.03},
-	},
-	"openai": {
-		"gpt-4o":            {InputPer1M: 2.50, OutputPer1M: 10.0, CachedInputPer1M: 1.25},
-		"gpt-4o-2024-11-20": {InputPer1M: 2.50, OutputPer1M: 10.0, CachedInputPer1M: 1.25},
-		"gpt-4o-mini":       {InputPer1M: 0.15, OutputPer1M: 0.60, CachedInputPer1M: 0.075},
-		"gpt-4-turbo":       {InputPer1M: 10.0, OutputPer1M: 30.0},
-		"gpt-4":             {InputPer1M: 30.0, OutputPer1M: 60.0},
-		"gpt-3.5-turbo":     {InputPer1M: 0.50, OutputPer1M: 1.50},
-		"o1":                {InputPer1M: 15.0, OutputPer1M: 60.0, CachedInputPer1M: 7.50},
-		"o1-mini":           {InputPer1M: 3.0, OutputPer1M: 12.0, CachedInputPer1M: 1.50},
-		"o1-preview":        {InputPer1M: 15.0, OutputPer1M: 60.0},
-	},
-	"google": {
-		"gemini-1.5-pro":        {InputPer1M: 1.25, OutputPer1M: 5.0},
-		"gemini-1.5-pro-latest": {InputPer1M: 1.25, OutputPer1M: 5.0},
-		"gemini-1.5-flash":      {InputPer1M: 0.075, OutputPer1M: 0.30},
-		"gemini-2.0-flash":      {InputPer1M: 0.10, OutputPer1M: 0.40},
-		"gemini-pro":            {InputPer1M: 0.50, OutputPer1M: 1.50},
-	},
-	"mistral": {
-		"mistral-large":  {InputPer1M: 2.0, OutputPer1M: 6.0},
-		"mistral-medium": {InputPer1M: 2.7, OutputPer1M: 8.1},
-		"mistral-small":  {InputPer1M: 0.2, OutputPer1M: 0.6},
-	},
-}
-
-// ResolveModelCostConfig looks up pricing for a model.
-// It first checks the config for custom pricing, then falls back to defaults.
+// ResolveModelCostConfig looks up pricing for a model in the model catalog.
+// It returns nil when the model is unknown or has no published price.
 func ResolveModelCostConfig(provider, model string, cfg *config.Config) *ModelCostConfig {
 	provider = strings.ToLower(strings.TrimSpace(provider))
 	model = strings.TrimSpace(model)
@@ -64,66 +26,15 @@ func ResolveModelCostConfig(provider, model string, cfg *config.Config) *ModelCo
 		return nil
 	}
 
-	// Check default costs
-	if providerCosts, ok := DefaultModelCosts[provider]; ok {
-		if cost, ok := providerCosts[model]; ok {
-			return &cost
-		}
-
-		// Try partial match for versioned models
-		for modelID, cost := range providerCosts {
-			if strings.HasPrefix(model, modelID) || strings.HasPrefix(modelID, model) {
-				costCopy := cost
-				return &costCopy
-			}
-		}
+	entry, ok := models.Lookup(model)
+	if !ok || (entry.InputPrice == 0 && entry.OutputPrice == 0) {
+		return nil
 	}
-
-	// Check for alias patterns
-	switch provider {
-	case "anthropic":
-		if strings.Contains(model, "sonnet") {
-			if strings.Contains(model, "3-5") || strings.Contains(model, "3.5") || strings.Contains(model, "4") {
-				return &ModelCostConfig{InputPer1M: 3.0, OutputPer1M: 15.0, CachedInputPer1M: 0.30}
-			}
-		}
-		if strings.Contains(model, "haiku") {
-			if strings.Contains(model, "3-5") || strings.Contains(model, "3.5") {
-				return &ModelCostConfig{InputPer1M: 1.0, OutputPer1M: 5.0, CachedInputPer1M: 0.10}
-			}
-			return &ModelCostConfig{InputPer1M: 0.25, OutputPer1M: 1.25, CachedInputPer1M: 0.03}
-		}
-		if strings.Contains(model, "opus") {
-			return &ModelCostConfig{InputPer1M: 15.0, OutputPer1M: 75.0, CachedInputPer1M: 1.50}
-		}
-
-	case "openai":
-		if strings.HasPrefix(model, "gpt-4o-mini") {
-			return &ModelCostConfig{InputPer1M: 0.15, OutputPer1M: 0.60, CachedInputPer1M: 0.075}
-		}
-		if strings.HasPrefix(model, "gpt-4o") {
-			return &ModelCostConfig{InputPer1M: 2.50, OutputPer1M: 10.0, CachedInputPer1M: 1.25}
-		}
-		if strings.HasPrefix(model, "o1-mini") {
-			return &ModelCostConfig{InputPer1M: 3.0, OutputPer1M: 12.0, CachedInputPer1M: 1.50}
-		}
-		if strings.HasPrefix(model, "o1") {
-			return &ModelCostConfig{InputPer1M: 15.0, OutputPer1M: 60.0, CachedInputPer1M: 7.50}
-		}
-
-	case "google":
-		if strings.Contains(model, "gemini-2") && strings.Contains(model, "flash") {
-			return &ModelCostConfig{InputPer1M: 0.10, OutputPer1M: 0.40}
-		}
-		if strings.Contains(model, "gemini-1.5-flash") {
-			return &ModelCostConfig{InputPer1M: 0.075, OutputPer1M: 0.30}
-		}
-		if strings.Contains(model, "gemini-1.5-pro") {
-			return &ModelCostConfig{InputPer1M: 1.25, OutputPer1M: 5.0}
-		}
+	return &ModelCostConfig{
+		InputPer1M:       entry.InputPrice,
+		OutputPer1M:      entry.OutputPrice,
+		CachedInputPer1M: entry.CachedInputPrice,
 	}
-
-	return nil
 }
 
 // EstimateUsageCost calculates estimated cost from token counts.
@@ -44,7 +44,7 @@ func TestResolveModelCostConfig(t *testing.T) {
 			provider:  "anthropic",
 			model:     "claude-3-5-haiku-20241022",
 			wantNil:   false,
-			wantInput: 1.0,
+			wantInput: 0.80,
 		},
 		{
 			name:      "anthropic opus",
@@ -354,36 +354,15 @@ func containsInMiddle(s, substr string) bool {
 	return false
 }
 
-func TestDefaultModelCosts(t *testing.T) {
-	// Verify default costs are populated correctly
-	if len(DefaultModelCosts) == 0 {
-		t.Error("DefaultModelCosts is empty")
+func TestResolveModelCostConfigUsesCatalog(t *testing.T) {
+	cost := ResolveModelCostConfig("openrouter", "anthropic/claude-3-5-sonnet-20241022", nil)
+	if cost == nil || cost.InputPer1M != 3.0 || cost.OutputPer1M != 15.0 || cost.CachedInputPer1M != 0.30 {
+		t.Fatalf("cost = %+v", cost)
 	}
-
-	// Check Anthropic models exist
-	anthropic, ok := DefaultModelCosts["anthropic"]
-	if !ok {
-		t.Error("DefaultModelCosts missing anthropic provider")
-	}
-	if _, ok := anthropic["claude-3-5-sonnet-20241022"]; !ok {
-		t.Error("DefaultModelCosts missing claude-3-5-sonnet-20241022")
-	}
-
-	// Check OpenAI models exist
-	openai, ok := DefaultModelCosts["openai"]
-	if !ok {
-		t.Error("DefaultModelCosts missing openai provider")
-	}
-	if _, ok := openai["gpt-4o"]; !ok {
-		t.Error("DefaultModelCosts missing gpt-4o")
-	}
-
-	// Check Google models exist
-	google, ok := DefaultModelCosts["google"]
-	if !ok {
-		t.Error("DefaultModelCosts missing google provider")
+	if cost := ResolveModelCostConfig("openai", "gpt-4o-2024-08-06", nil); cost == nil || cost.InputPer1M != 2.50 {
+		t.Fatalf("dated gpt-4o cost = %+v", cost)
 	}
-	if _, ok := google["gemini-1.5-pro"]; !ok {
-		t.Error("DefaultModelCosts missing gemini-1.5-pro")
+	if cost := ResolveModelCostConfig("openai", "gpt-4.1", nil); cost != nil {
+		t.Fatalf("gpt-4.1 should not resolve to gpt-4 pricing, got %+v", cost)
 	}
 }
@@ -50,6 +50,20 @@ func NewCockroachStoresFromDSN(dsn string, config *CockroachConfig) (StoreSet, e
 	return stores, nil
 }
 
+// NewSQLiteStores creates stores on an open, migrated SQLite database. The
+// queries are shared with the CockroachDB/Postgres stores.
+func NewSQLiteStores(db *sql.DB) (StoreSet, error) {
+	if db == nil {
+		return StoreSet{}, fmt.Errorf("db is required")
+	}
+	return StoreSet{
+		Agents:   &cockroachAgentStore{db: db},
+		Channels: &cockroachChannelConnectionStore{db: db},
+		Users:    &cockroachUserStore{db: db},
+		closer:   db.Close,
+	}, nil
+}
+
 type cockroachAgentStore struct {
 	db *sql.DB
 }
@@ -77,7 +91,7 @@ func (s *cockroachAgentStore) Create(ctx context.Context, agent *models.Agent) e
 		agent.UpdatedAt,
 	)
 	if err != nil {
-		if strings.Contains(err.Error(), "duplicate") {
+		if isUniqueViolation(err) {
 			return ErrAlreadyExists
 		}
 		return fmt.Errorf("create agent: %w", err)
@@ -275,7 +289,7 @@ func (s *cockroachChannelConnectionStore) Create(ctx context.Context, conn *mode
 		conn.LastActivityAt,
 	)
 	if err != nil {
-		if strings.Contains(err.Error(), "duplicate") {
+		if isUniqueViolation(err) {
 			return ErrAlreadyExists
 		}
 		return fmt.Errorf("create channel connection: %w", err)
@@ -481,7 +495,7 @@ func (s *cockroachUserStore) FindOrCreate(ctx context.Context, info *auth.UserIn
 		// Handle race condition: if insert fails due to duplicate,
 		// another request created the user between our lookup and insert.
 		// Retry the lookup to find the concurrently-created user.
-		if strings.Contains(err.Error(), "duplicate") || strings.Contains(err.Error(), "23505") {
+		if isUniqueViolation(err) {
 			if existing, retryErr := s.findExisting(ctx, provider, providerID, email); retryErr != nil {
 				return nil, retryErr
 			} else if existing != nil {
@@ -644,6 +658,13 @@ func (s *cockroachUserStore) updateFromInfo(ctx context.Context, user *models.Us
 	return user, nil
 }
 
+// isUniqueViolation reports whether err is a unique constraint failure from
+// Postgres/CockroachDB or SQLite.
+func isUniqueViolation(err error) bool {
+	msg := err.Error()
+	return strings.Contains(msg, "duplicate") || strings.Contains(msg, "23505") || strings.Contains(msg, "UNIQUE constraint failed")
+}
+
 func normalizeProvider(provider string) string {
 	return strings.ToLower(strings.TrimSpace(provider))
 }
@@ -0,0 +1,141 @@
+package encryption
+
+import (
+	"bufio"
+	"crypto/hkdf"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// ageIdentityHRP is the bech32 human-readable part of an age X25519 identity.
+const ageIdentityHRP = "age-secret-key-"
+
+// ageKeyInfo separates keys derived for field encryption from any other use
+// of the same identity.
+const ageKeyInfo = "nexus field encryption v1"
+
+// LoadAgeIdentity reads an age identity file (as written by age-keygen) and
+// derives a field encryption key from the first AGE-SECRET-KEY-1 line. The
+// identity is used only as key material: values are still sealed with
+// AES-256-GCM, not with age's file format.
+func LoadAgeIdentity(path string) ([]byte, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("encryption: open age identity: %w", err)
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		return DeriveAgeKey(line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("encryption: read age identity: %w", err)
+	}
+	return nil, fmt.Errorf("encryption: no identity found in %s", path)
+}
+
+// DeriveAgeKey derives a field encryption key from an age X25519 identity
+// string using HKDF-SHA256.
+func DeriveAgeKey(identity string) ([]byte, error) {
+	hrp, secret, err := decodeBech32(strings.TrimSpace(identity))
+	if err != nil {
+		return nil, fmt.Errorf("encryption: invalid age identity: %w", err)
+	}
+	if hrp != ageIdentityHRP {
+		return nil, fmt.Errorf("encryption: not an age secret key (prefix %q)", strings.ToUpper(hrp))
+	}
+	if len(secret) != 32 {
+		return nil, fmt.Errorf("encryption: age secret key must be 32 bytes, got %d", len(secret))
+	}
+	return hkdf.Key(sha256.New, secret, nil, ageKeyInfo, KeySize)
+}
+
+const bech32Charset = "qpzry9x8gf2tvdw0s3jn54khce6mua7l"
+
+// decodeBech32 decodes a BIP 173 bech32 string without the 90 character
+// limit, which age identities exceed.
+func decodeBech32(s string) (string, []byte, error) {
+	if strings.ToLower(s) != s && strings.ToUpper(s) != s {
+		return "", nil, errors.New("mixed case")
+	}
+	s = strings.ToLower(s)
+	pos := strings.LastIndexByte(s, '1')
+	if pos < 1 || pos+7 > len(s) {
+		return "", nil, errors.New("missing separator or checksum")
+	}
+	hrp := s[:pos]
+	data := make([]byte, 0, len(s)-pos-1)
+	for i := pos + 1; i < len(s); i++ {
+		v := strings.IndexByte(bech32Charset, s[i])
+		if v < 0 {
+			return "", nil, fmt.Errorf("invalid character %q", s[i])
+		}
+		data = append(data, byte(v))
+	}
+	if bech32Polymod(append(bech32HRPExpand(hrp), data...)) != 1 {
+		return "", nil, errors.New("invalid checksum")
+	}
+	out, err := convertBits(data[:len(data)-6], 5, 8, false)
+	if err != nil {
+		return "", nil, err
+	}
+	return hrp, out, nil
+}
+
+func bech32Polymod(values []byte) uint32 {
+	gen := [5]uint32{0x3b6a57b2, 0x26508e6d, 0x1ea119fa, 0x3d4233dd, 0x2a1462b3}
+	chk := uint32(1)
+	for _, v := range values {
+		top := chk >> 25
+		chk = (chk&0x1ffffff)<<5 ^ uint32(v)
+		for i := 0; i < 5; i++ {
+			if (top>>uint(i))&1 == 1 {
+				chk ^= gen[i]
+			}
+		}
+	}
+	return chk
+}
+
+func bech32HRPExpand(hrp string) []byte {
+	out := make([]byte, 0, len(hrp)*2+1)
+	for i := 0; i < len(hrp); i++ {
+		out = append(out, hrp[i]>>5)
+	}
+	out = append(out, 0)
+	for i := 0; i < len(hrp); i++ {
+		out = append(out, hrp[i]&31)
+	}
+	return out
+}
+
+func convertBits(data []byte, from, to uint, pad bool) ([]byte, error) {
+	var acc uint32
+	var bits uint
+	maxv := uint32(1)<<to - 1
+	out := make([]byte, 0, len(data)*int(from)/int(to)+1)
+	for _, b := range data {
+		acc = acc<<from | uint32(b)
+		bits += from
+		for bits >= to {
+			bits -= to
+			out = append(out, byte(acc>>bits&maxv))
+		}
+	}
+	if pad {
+		if bits > 0 {
+			out = append(out, byte(acc<<(to-bits)&maxv))
+		}
+	} else if bits >= from || acc<<(to-bits)&maxv != 0 {
+		return nil, errors.New("invalid padding")
+	}
+	return out, nil
+}
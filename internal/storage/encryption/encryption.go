@@ -0,0 +1,220 @@
+// Package encryption provides field-level encryption for data at rest.
+//
+// Values are sealed with AES-256-GCM and stored as self-describing strings of
+// the form "enc:v1:<key-id>:<base64(nonce|ciphertext)>". The key id lets a
+// Keyring hold retired keys alongside the primary key, so data written before
+// a rotation stays readable until it is re-encrypted. Values without the
+// prefix are treated as plaintext, which lets encryption be enabled on an
+// existing database and rows be migrated in place.
+package encryption
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Prefix marks a value encrypted by a Keyring.
+const Prefix = "enc:v1:"
+
+// KeySize is the length in bytes of an AES-256 key.
+const KeySize = 32
+
+// ErrNoKeyring is returned when an encrypted value is read without a keyring.
+var ErrNoKeyring = errors.New("encryption: value is encrypted but no keyring is configured")
+
+// Keyring encrypts values with its primary key and decrypts values written
+// with any of its keys. A nil *Keyring is valid and passes values through
+// unchanged, so stores can hold one unconditionally.
+type Keyring struct {
+	primary string
+	keys    map[string]cipher.AEAD
+}
+
+// NewKeyring creates a keyring from raw 32-byte keys indexed by id. New
+// values are encrypted with the key named by primary.
+func NewKeyring(primary string, keys map[string][]byte) (*Keyring, error) {
+	if len(keys) == 0 {
+		return nil, errors.New("encryption: at least one key is required")
+	}
+	k := &Keyring{primary: primary, keys: make(map[string]cipher.AEAD, len(keys))}
+	for id, key := range keys {
+		if id == "" || strings.Contains(id, ":") {
+			return nil, fmt.Errorf("encryption: invalid key id %q", id)
+		}
+		if len(key) != KeySize {
+			return nil, fmt.Errorf("encryption: key %q must be %d bytes, got %d", id, KeySize, len(key))
+		}
+		block, err := aes.NewCipher(key)
+		if err != nil {
+			return nil, fmt.Errorf("encryption: key %q: %w", id, err)
+		}
+		aead, err := cipher.NewGCM(block)
+		if err != nil {
+			return nil, fmt.Errorf("encryption: key %q: %w", id, err)
+		}
+		k.keys[id] = aead
+	}
+	if _, ok := k.keys[primary]; !ok {
+		return nil, fmt.Errorf("encryption: primary key %q is not in the keyring", primary)
+	}
+	return k, nil
+}
+
+// PrimaryKeyID returns the id of the key used for new values.
+func (k *Keyring) PrimaryKeyID() string {
+	if k == nil {
+		return ""
+	}
+	return k.primary
+}
+
+// KeyIDs returns the ids of all keys in the keyring, sorted.
+func (k *Keyring) KeyIDs() []string {
+	if k == nil {
+		return nil
+	}
+	ids := make([]string, 0, len(k.keys))
+	for id := range k.keys {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// Encrypt seals plaintext with the primary key. Empty strings are returned
+// unchanged, as is everything when the keyring is nil.
+func (k *Keyring) Encrypt(plaintext string) (string, error) {
+	if k == nil || plaintext == "" {
+		return plaintext, nil
+	}
+	aead := k.keys[k.primary]
+	nonce := make([]byte, aead.NonceSize(), aead.NonceSize()+len(plaintext)+aead.Overhead())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", fmt.Errorf("encryption: generate nonce: %w", err)
+	}
+	sealed := aead.Seal(nonce, nonce, []byte(plaintext), []byte(k.primary))
+	return Prefix + k.primary + ":" + base64.RawStdEncoding.EncodeToString(sealed), nil
+}
+
+// Decrypt opens a value produced by Encrypt. Values without the encryption
+// prefix are returned unchanged.
+func (k *Keyring) Decrypt(value string) (string, error) {
+	id, payload, ok := split(value)
+	if !ok {
+		return value, nil
+	}
+	if k == nil {
+		return "", ErrNoKeyring
+	}
+	aead, ok := k.keys[id]
+	if !ok {
+		return "", fmt.Errorf("encryption: unknown key %q", id)
+	}
+	sealed, err := base64.RawStdEncoding.DecodeString(payload)
+	if err != nil {
+		return "", fmt.Errorf("encryption: decode value: %w", err)
+	}
+	if len(sealed) < aead.NonceSize() {
+		return "", errors.New("encryption: value is truncated")
+	}
+	nonce, ciphertext := sealed[:aead.NonceSize()], sealed[aead.NonceSize():]
+	plaintext, err := aead.Open(nil, nonce, ciphertext, []byte(id))
+	if err != nil {
+		return "", fmt.Errorf("encryption: decrypt with key %q: %w", id, err)
+	}
+	return string(plaintext), nil
+}
+
+// NeedsRewrap reports whether value is plaintext or encrypted with a key
+// other than the primary key.
+func (k *Keyring) NeedsRewrap(value string) bool {
+	if k == nil || value == "" {
+		return false
+	}
+	id, ok := KeyID(value)
+	return !ok || id != k.primary
+}
+
+// Rewrap re-encrypts value with the primary key when NeedsRewrap reports it
+// is stale, and reports whether the value changed.
+func (k *Keyring) Rewrap(value string) (string, bool, error) {
+	if !k.NeedsRewrap(value) {
+		return value, false, nil
+	}
+	plaintext, err := k.Decrypt(value)
+	if err != nil {
+		return "", false, err
+	}
+	out, err := k.Encrypt(plaintext)
+	if err != nil {
+		return "", false, err
+	}
+	return out, true, nil
+}
+
+// IsEncrypted reports whether value carries the encryption prefix.
+func IsEncrypted(value string) bool {
+	_, _, ok := split(value)
+	return ok
+}
+
+// KeyID returns the id of the key that encrypted value.
+func KeyID(value string) (string, bool) {
+	id, _, ok := split(value)
+	return id, ok
+}
+
+func split(value string) (id, payload string, ok bool) {
+	rest, found := strings.CutPrefix(value, Prefix)
+	if !found {
+		return "", "", false
+	}
+	id, payload, found = strings.Cut(rest, ":")
+	if !found || id == "" {
+		return "", "", false
+	}
+	return id, payload, true
+}
+
+// ParseKey decodes a 32-byte key given as base64 (standard or URL alphabet,
+// padded or not) or hex.
+func ParseKey(encoded string) ([]byte, error) {
+	encoded = strings.TrimSpace(encoded)
+	if encoded == "" {
+		return nil, errors.New("encryption: key is empty")
+	}
+	if len(encoded) == hex.EncodedLen(KeySize) {
+		if key, err := hex.DecodeString(encoded); err == nil {
+			return key, nil
+		}
+	}
+	for _, enc := range []*base64.Encoding{
+		base64.StdEncoding, base64.RawStdEncoding, base64.URLEncoding, base64.RawURLEncoding,
+	} {
+		key, err := enc.DecodeString(encoded)
+		if err != nil {
+			continue
+		}
+		if len(key) != KeySize {
+			return nil, fmt.Errorf("encryption: key must be %d bytes, got %d", KeySize, len(key))
+		}
+		return key, nil
+	}
+	return nil, errors.New("encryption: key must be base64 or hex encoded")
+}
+
+// GenerateKey returns a new random key encoded as base64.
+func GenerateKey() (string, error) {
+	key := make([]byte, KeySize)
+	if _, err := rand.Read(key); err != nil {
+		return "", fmt.Errorf("encryption: generate key: %w", err)
+	}
+	return base64.StdEncoding.EncodeToString(key), nil
+}
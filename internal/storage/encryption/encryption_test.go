@@ -0,0 +1,188 @@
+package encryption
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func testKeyring(t *testing.T, primary string, ids ...string) *Keyring {
+	t.Helper()
+	keys := make(map[string][]byte, len(ids))
+	for i, id := range ids {
+		keys[id] = bytes.Repeat([]byte{byte(i + 1)}, KeySize)
+	}
+	k, err := NewKeyring(primary, keys)
+	if err != nil {
+		t.Fatalf("NewKeyring() error = %v", err)
+	}
+	return k
+}
+
+func TestKeyringRoundTrip(t *testing.T) {
+	k := testKeyring(t, "k1", "k1")
+
+	sealed, err := k.Encrypt("hello world")
+	if err != nil {
+		t.Fatalf("Encrypt() error = %v", err)
+	}
+	if !strings.HasPrefix(sealed, Prefix+"k1:") {
+		t.Fatalf("unexpected ciphertext %q", sealed)
+	}
+	if strings.Contains(sealed, "hello") {
+		t.Fatalf("ciphertext leaks plaintext: %q", sealed)
+	}
+	opened, err := k.Decrypt(sealed)
+	if err != nil {
+		t.Fatalf("Decrypt() error = %v", err)
+	}
+	if opened != "hello world" {
+		t.Fatalf("Decrypt() = %q", opened)
+	}
+
+	plain, err := k.Decrypt("not encrypted")
+	if err != nil || plain != "not encrypted" {
+		t.Fatalf("Decrypt(plaintext) = %q, %v", plain, err)
+	}
+	if empty, _ := k.Encrypt(""); empty != "" {
+		t.Fatalf("expected empty string to stay empty, got %q", empty)
+	}
+}
+
+func TestKeyringTamperedValue(t *testing.T) {
+	k := testKeyring(t, "k1", "k1")
+	sealed, err := k.Encrypt("secret")
+	if err != nil {
+		t.Fatalf("Encrypt() error = %v", err)
+	}
+	tampered := sealed[:len(sealed)-2] + "AA"
+	if _, err := k.Decrypt(tampered); err == nil {
+		t.Fatalf("expected tampered value to fail decryption")
+	}
+	relabeled := strings.Replace(sealed, "k1:", "k2:", 1)
+	if _, err := testKeyring(t, "k2", "k1", "k2").Decrypt(relabeled); err == nil {
+		t.Fatalf("expected value relabeled to another key to fail decryption")
+	}
+}
+
+func TestNilKeyring(t *testing.T) {
+	var k *Keyring
+	out, err := k.Encrypt("plain")
+	if err != nil || out != "plain" {
+		t.Fatalf("nil Encrypt() = %q, %v", out, err)
+	}
+	sealed, err := testKeyring(t, "k1", "k1").Encrypt("secret")
+	if err != nil {
+		t.Fatalf("Encrypt() error = %v", err)
+	}
+	if _, err := k.Decrypt(sealed); err != ErrNoKeyring {
+		t.Fatalf("nil Decrypt() error = %v, want ErrNoKeyring", err)
+	}
+}
+
+func TestKeyringRotation(t *testing.T) {
+	old := testKeyring(t, "k1", "k1")
+	sealed, err := old.Encrypt("rotate me")
+	if err != nil {
+		t.Fatalf("Encrypt() error = %v", err)
+	}
+
+	rotated := testKeyring(t, "k2", "k1", "k2")
+	if !rotated.NeedsRewrap(sealed) || !rotated.NeedsRewrap("plaintext") {
+		t.Fatalf("expected old and plaintext values to need rewrapping")
+	}
+	rewrapped, changed, err := rotated.Rewrap(sealed)
+	if err != nil || !changed {
+		t.Fatalf("Rewrap() = %v, %v", changed, err)
+	}
+	if id, _ := KeyID(rewrapped); id != "k2" {
+		t.Fatalf("rewrapped key id = %q, want k2", id)
+	}
+	if rotated.NeedsRewrap(rewrapped) {
+		t.Fatalf("expected rewrapped value to be current")
+	}
+	opened, err := rotated.Decrypt(rewrapped)
+	if err != nil || opened != "rotate me" {
+		t.Fatalf("Decrypt() = %q, %v", opened, err)
+	}
+}
+
+func TestNewKeyringRejectsBadInput(t *testing.T) {
+	key := bytes.Repeat([]byte{1}, KeySize)
+	if _, err := NewKeyring("missing", map[string][]byte{"k1": key}); err == nil {
+		t.Fatalf("expected unknown primary key to fail")
+	}
+	if _, err := NewKeyring("k1", map[string][]byte{"k1": key[:16]}); err == nil {
+		t.Fatalf("expected short key to fail")
+	}
+	if _, err := NewKeyring("a:b", map[string][]byte{"a:b": key}); err == nil {
+		t.Fatalf("expected key id with a colon to fail")
+	}
+}
+
+func TestParseKey(t *testing.T) {
+	generated, err := GenerateKey()
+	if err != nil {
+		t.Fatalf("GenerateKey() error = %v", err)
+	}
+	for _, encoded := range []string{generated, strings.Repeat("ab", KeySize)} {
+		key, err := ParseKey(encoded)
+		if err != nil {
+			t.Fatalf("ParseKey(%q) error = %v", encoded, err)
+		}
+		if len(key) != KeySize {
+			t.Fatalf("ParseKey(%q) returned %d bytes", encoded, len(key))
+		}
+	}
+	if _, err := ParseKey("c2hvcnQ="); err == nil {
+		t.Fatalf("expected short key to fail")
+	}
+}
+
+func TestLoadAgeIdentity(t *testing.T) {
+	identity := encodeBech32(t, ageIdentityHRP, bytes.Repeat([]byte{7}, 32))
+	path := filepath.Join(t.TempDir(), "key.txt")
+	content := "# created: 2026-01-01T00:00:00Z\n# public key: age1example\n" + identity + "\n"
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+
+	key, err := LoadAgeIdentity(path)
+	if err != nil {
+		t.Fatalf("LoadAgeIdentity() error = %v", err)
+	}
+	if len(key) != KeySize {
+		t.Fatalf("derived key has %d bytes", len(key))
+	}
+	again, err := DeriveAgeKey(identity)
+	if err != nil || !bytes.Equal(key, again) {
+		t.Fatalf("expected derivation to be deterministic")
+	}
+	if _, err := DeriveAgeKey(identity[:len(identity)-1] + "Q"); err == nil {
+		t.Fatalf("expected bad checksum to fail")
+	}
+	if _, err := DeriveAgeKey(encodeBech32(t, "age", bytes.Repeat([]byte{7}, 32))); err == nil {
+		t.Fatalf("expected public key prefix to fail")
+	}
+}
+
+func encodeBech32(t *testing.T, hrp string, data []byte) string {
+	t.Helper()
+	values, err := convertBits(data, 8, 5, true)
+	if err != nil {
+		t.Fatalf("convertBits() error = %v", err)
+	}
+	polymod := bech32Polymod(append(append(bech32HRPExpand(hrp), values...), 0, 0, 0, 0, 0, 0)) ^ 1
+	for i := 0; i < 6; i++ {
+		values = append(values, byte(polymod>>uint(5*(5-i))&31))
+	}
+	var b strings.Builder
+	b.WriteString(hrp)
+	b.WriteByte('1')
+	for _, v := range values {
+		b.WriteByte(bech32Charset[v])
+	}
+	return strings.ToUpper(b.String())
+}
@@ -0,0 +1,110 @@
+// Package sqlite opens SQLite databases addressed by sqlite:// URLs.
+//
+// SQLite is the single-user storage backend: a database.url such as
+// sqlite:///~/.nexus/nexus.db selects it in place of CockroachDB/Postgres.
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	_ "modernc.org/sqlite" // Pure-Go SQLite driver
+)
+
+// Scheme is the URL scheme that selects the SQLite backend.
+const Scheme = "sqlite"
+
+// DriverName is the database/sql driver used for SQLite.
+const DriverName = "sqlite"
+
+// connectTimeout bounds the initial ping.
+const connectTimeout = 10 * time.Second
+
+// IsURL reports whether dsn addresses a SQLite database.
+func IsURL(dsn string) bool {
+	return strings.HasPrefix(strings.ToLower(strings.TrimSpace(dsn)), Scheme+":")
+}
+
+// Path returns the database file path for a sqlite:// URL. Both
+// sqlite:///abs/path.db and sqlite:///~/path.db are accepted; a leading ~
+// expands to the user's home directory. The special path :memory: is
+// returned unchanged.
+func Path(dsn string) (string, error) {
+	dsn = strings.TrimSpace(dsn)
+	if !IsURL(dsn) {
+		return "", fmt.Errorf("not a sqlite url: %q", dsn)
+	}
+	path := dsn[len(Scheme)+1:]
+	if i := strings.IndexByte(path, '?'); i >= 0 {
+		path = path[:i]
+	}
+	path = strings.TrimPrefix(path, "//")
+	if strings.HasPrefix(path, "/~") {
+		path = path[1:]
+	}
+	if path == "" {
+		return "", errors.New("sqlite url is missing a database path")
+	}
+	if path == ":memory:" {
+		return path, nil
+	}
+	if path == "~" || strings.HasPrefix(path, "~/") {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", fmt.Errorf("resolve home directory: %w", err)
+		}
+		path = filepath.Join(home, strings.TrimPrefix(path[1:], "/"))
+	}
+	return filepath.Clean(path), nil
+}
+
+// Open opens the SQLite database addressed by dsn, creating the file and its
+// parent directory if needed.
+//
+// Connections enable foreign keys and WAL journaling, wait on locks held by
+// other handles, begin transactions with an immediate write lock, and store
+// timestamps as Unix nanoseconds so they compare and sort correctly.
+func Open(dsn string) (*sql.DB, error) {
+	path, err := Path(dsn)
+	if err != nil {
+		return nil, err
+	}
+	if path != ":memory:" {
+		if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+			return nil, fmt.Errorf("create database directory: %w", err)
+		}
+	}
+
+	params := url.Values{}
+	params.Add("_pragma", "busy_timeout(5000)")
+	params.Add("_pragma", "foreign_keys(1)")
+	if path != ":memory:" {
+		params.Add("_pragma", "journal_mode(WAL)")
+	}
+	params.Set("_txlock", "immediate")
+	params.Set("_time_integer_format", "unix_nano")
+	params.Set("_inttotime", "1")
+
+	db, err := sql.Open(DriverName, "file:"+path+"?"+params.Encode())
+	if err != nil {
+		return nil, fmt.Errorf("open database: %w", err)
+	}
+	// SQLite serializes writers; a single connection per handle avoids
+	// lock upgrade failures and keeps :memory: databases shared.
+	db.SetMaxOpenConns(1)
+
+	ctx, cancel := context.WithTimeout(context.Background(), connectTimeout)
+	defer cancel()
+	if err := db.PingContext(ctx); err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("ping database: %w", err)
+	}
+	return db, nil
+}
@@ -0,0 +1,51 @@
+package sqlite
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestPath(t *testing.T) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		t.Skipf("no home directory: %v", err)
+	}
+	tests := map[string]string{
+		"sqlite:///var/lib/nexus/nexus.db":    "/var/lib/nexus/nexus.db",
+		"sqlite:///~/.nexus/nexus.db":         filepath.Join(home, ".nexus", "nexus.db"),
+		"sqlite://~/.nexus/nexus.db":          filepath.Join(home, ".nexus", "nexus.db"),
+		"sqlite://data/nexus.db?cache=shared": "data/nexus.db",
+		"sqlite://:memory:":                   ":memory:",
+	}
+	for dsn, want := range tests {
+		got, err := Path(dsn)
+		if err != nil {
+			t.Fatalf("Path(%q) error = %v", dsn, err)
+		}
+		if got != want {
+			t.Fatalf("Path(%q) = %q, want %q", dsn, got, want)
+		}
+	}
+
+	for _, dsn := range []string{"postgres://localhost/nexus", "sqlite://"} {
+		if _, err := Path(dsn); err == nil {
+			t.Fatalf("Path(%q) expected error", dsn)
+		}
+	}
+}
+
+func TestOpenCreatesDirectory(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nested", "nexus.db")
+	db, err := Open("sqlite://" + path)
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	defer db.Close()
+	if _, err := db.Exec(`CREATE TABLE t (id TEXT PRIMARY KEY)`); err != nil {
+		t.Fatalf("create table: %v", err)
+	}
+	if _, err := os.Stat(path); err != nil {
+		t.Fatalf("expected database file: %v", err)
+	}
+}
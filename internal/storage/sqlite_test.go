@@ -0,0 +1,86 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/haasonsaas/nexus/internal/auth"
+	"github.com/haasonsaas/nexus/internal/sessions"
+	"github.com/haasonsaas/nexus/pkg/models"
+)
+
+func newTestSQLiteStores(t *testing.T) StoreSet {
+	t.Helper()
+	db, err := sessions.OpenSQLite(context.Background(), "sqlite://"+filepath.Join(t.TempDir(), "nexus.db"))
+	if err != nil {
+		t.Fatalf("OpenSQLite() error = %v", err)
+	}
+	stores, err := NewSQLiteStores(db)
+	if err != nil {
+		t.Fatalf("NewSQLiteStores() error = %v", err)
+	}
+	t.Cleanup(func() { _ = stores.Close() })
+	return stores
+}
+
+func TestSQLiteStores(t *testing.T) {
+	ctx := context.Background()
+	stores := newTestSQLiteStores(t)
+	now := time.Now().Truncate(time.Millisecond)
+
+	agent := &models.Agent{
+		ID:        "agent-1",
+		Name:      "Helper",
+		Model:     "model",
+		Tools:     []string{"search", "exec"},
+		Config:    map[string]any{"temperature": 0.5},
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if err := stores.Agents.Create(ctx, agent); err != nil {
+		t.Fatalf("Agents.Create() error = %v", err)
+	}
+	if err := stores.Agents.Create(ctx, agent); !errors.Is(err, ErrAlreadyExists) {
+		t.Fatalf("Agents.Create() duplicate error = %v, want ErrAlreadyExists", err)
+	}
+	got, err := stores.Agents.Get(ctx, agent.ID)
+	if err != nil {
+		t.Fatalf("Agents.Get() error = %v", err)
+	}
+	if len(got.Tools) != 2 || got.Tools[1] != "exec" || got.Config["temperature"] != 0.5 || !got.CreatedAt.Equal(now) {
+		t.Fatalf("unexpected agent %+v", got)
+	}
+
+	conn := &models.ChannelConnection{
+		ID:          "conn-1",
+		ChannelType: models.ChannelType("slack"),
+		ChannelID:   "T1",
+		Status:      models.ConnectionStatusConnected,
+		ConnectedAt: now,
+	}
+	if err := stores.Channels.Create(ctx, conn); err != nil {
+		t.Fatalf("Channels.Create() error = %v", err)
+	}
+	conns, total, err := stores.Channels.List(ctx, "", 10, 0)
+	if err != nil {
+		t.Fatalf("Channels.List() error = %v", err)
+	}
+	if total != 1 || len(conns) != 1 || conns[0].Status != models.ConnectionStatusConnected {
+		t.Fatalf("unexpected connections %d %+v", total, conns)
+	}
+
+	user, err := stores.Users.FindOrCreate(ctx, &auth.UserInfo{ID: "42", Provider: "GitHub", Email: "Ada@example.com"})
+	if err != nil {
+		t.Fatalf("Users.FindOrCreate() error = %v", err)
+	}
+	byEmail, err := stores.Users.FindOrCreate(ctx, &auth.UserInfo{Provider: "google", Email: "ada@example.com"})
+	if err != nil {
+		t.Fatalf("Users.FindOrCreate() by email error = %v", err)
+	}
+	if byEmail.ID != user.ID {
+		t.Fatalf("expected email match to reuse user %s, got %s", user.ID, byEmail.ID)
+	}
+}
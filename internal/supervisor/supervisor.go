@@ -0,0 +1,183 @@
+// Package supervisor recovers panics in long-running components, restarts
+// them with backoff, and reports each crash with its stack trace so that one
+// misbehaving adapter, tool, or worker cannot take down the whole gateway.
+package supervisor
+
+import (
+	"context"
+	"fmt"
+	"runtime/debug"
+	"sync"
+	"time"
+
+	"github.com/haasonsaas/nexus/internal/retry"
+)
+
+// Crash describes a recovered panic.
+type Crash struct {
+	// Component names what panicked, e.g. "channel:telegram" or "tool:exec".
+	Component string `json:"component"`
+	// Panic is the recovered value formatted as a string.
+	Panic string `json:"panic"`
+	// Stack is the goroutine stack at the point of the panic.
+	Stack string `json:"stack"`
+	// Restart is how many times the component has been restarted after this
+	// crash, or 0 when it is not restarted.
+	Restart int `json:"restart,omitempty"`
+	// Time is when the panic was recovered.
+	Time time.Time `json:"time"`
+}
+
+// Handler receives crash reports.
+type Handler func(Crash)
+
+var (
+	handlerMu sync.RWMutex
+	handler   Handler
+)
+
+// SetHandler installs the process-wide crash handler and returns a function
+// that restores the previous one.
+func SetHandler(h Handler) (restore func()) {
+	handlerMu.Lock()
+	prev := handler
+	handler = h
+	handlerMu.Unlock()
+	return func() {
+		handlerMu.Lock()
+		handler = prev
+		handlerMu.Unlock()
+	}
+}
+
+// Report sends a crash to the installed handler. Panics in the handler are
+// swallowed so reporting can never cause a second crash.
+func Report(crash Crash) {
+	if crash.Time.IsZero() {
+		crash.Time = time.Now()
+	}
+	handlerMu.RLock()
+	h := handler
+	handlerMu.RUnlock()
+	if h == nil {
+		return
+	}
+	defer func() { _ = recover() }()
+	h(crash)
+}
+
+// PanicError is returned in place of a recovered panic.
+type PanicError struct {
+	Component string
+	Value     any
+	Stack     []byte
+}
+
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("%s panicked: %v", e.Component, e.Value)
+}
+
+// Recover reports a panic in the calling goroutine and stops it from
+// unwinding further. It must be deferred directly:
+//
+//	defer supervisor.Recover("channel:slack")
+func Recover(component string) {
+	if r := recover(); r != nil {
+		Report(Crash{Component: component, Panic: fmt.Sprint(r), Stack: string(debug.Stack())})
+	}
+}
+
+// Catch runs fn and converts a panic into a *PanicError, reporting it first.
+func Catch(component string, fn func() error) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			stack := debug.Stack()
+			Report(Crash{Component: component, Panic: fmt.Sprint(r), Stack: string(stack)})
+			err = &PanicError{Component: component, Value: r, Stack: stack}
+		}
+	}()
+	return fn()
+}
+
+// Config controls restarts after a panic.
+type Config struct {
+	// InitialBackoff is the delay before the first restart. Default: 1s.
+	InitialBackoff time.Duration
+
+	// MaxBackoff caps the delay between restarts. Default: 1m.
+	MaxBackoff time.Duration
+
+	// MaxRestarts stops restarting after this many consecutive panics.
+	// Zero restarts indefinitely.
+	MaxRestarts int
+
+	// ResetAfter clears the consecutive panic count once the component has
+	// run this long without panicking. Default: 5m.
+	ResetAfter time.Duration
+}
+
+// DefaultConfig returns the default restart policy.
+func DefaultConfig() Config {
+	return Config{
+		InitialBackoff: time.Second,
+		MaxBackoff:     time.Minute,
+		ResetAfter:     5 * time.Minute,
+	}
+}
+
+// Run calls fn and restarts it with exponential backoff each time it
+// panics. It returns when fn returns, ctx is done, or MaxRestarts
+// consecutive panics have occurred, in which case the last *PanicError is
+// returned.
+func Run(ctx context.Context, component string, cfg Config, fn func(context.Context) error) error {
+	defaults := DefaultConfig()
+	if cfg.InitialBackoff <= 0 {
+		cfg.InitialBackoff = defaults.InitialBackoff
+	}
+	if cfg.MaxBackoff <= 0 {
+		cfg.MaxBackoff = defaults.MaxBackoff
+	}
+	if cfg.ResetAfter <= 0 {
+		cfg.ResetAfter = defaults.ResetAfter
+	}
+
+	panics := 0
+	for {
+		started := time.Now()
+		var recovered *PanicError
+		err := func() (err error) {
+			defer func() {
+				if r := recover(); r != nil {
+					recovered = &PanicError{Component: component, Value: r, Stack: debug.Stack()}
+					err = recovered
+				}
+			}()
+			return fn(ctx)
+		}()
+		if recovered == nil {
+			return err
+		}
+
+		if time.Since(started) >= cfg.ResetAfter {
+			panics = 0
+		}
+		panics++
+		crash := Crash{
+			Component: component,
+			Panic:     fmt.Sprint(recovered.Value),
+			Stack:     string(recovered.Stack),
+		}
+		if ctx.Err() != nil || (cfg.MaxRestarts > 0 && panics > cfg.MaxRestarts) {
+			Report(crash)
+			return recovered
+		}
+		crash.Restart = panics
+		Report(crash)
+
+		select {
+		case <-ctx.Done():
+			return recovered
+		case <-time.After(retry.Backoff(panics, cfg.InitialBackoff, cfg.MaxBackoff, 2)):
+		}
+	}
+}
@@ -0,0 +1,133 @@
+package supervisor
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+type crashRecorder struct {
+	mu      sync.Mutex
+	crashes []Crash
+}
+
+func (r *crashRecorder) handle(c Crash) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.crashes = append(r.crashes, c)
+}
+
+func (r *crashRecorder) all() []Crash {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]Crash(nil), r.crashes...)
+}
+
+func TestCatchConvertsPanic(t *testing.T) {
+	rec := &crashRecorder{}
+	defer SetHandler(rec.handle)()
+
+	err := Catch("tool:exec", func() error { panic("boom") })
+	var panicErr *PanicError
+	if !errors.As(err, &panicErr) || panicErr.Value != "boom" {
+		t.Fatalf("expected PanicError, got %v", err)
+	}
+	crashes := rec.all()
+	if len(crashes) != 1 || crashes[0].Component != "tool:exec" || crashes[0].Panic != "boom" {
+		t.Fatalf("unexpected crashes: %+v", crashes)
+	}
+	if !strings.Contains(crashes[0].Stack, "supervisor_test.go") {
+		t.Fatalf("expected stack to include the panicking frame, got %q", crashes[0].Stack)
+	}
+
+	want := errors.New("plain")
+	if err := Catch("tool:exec", func() error { return want }); err != want {
+		t.Fatalf("expected plain error to pass through, got %v", err)
+	}
+}
+
+func TestRecoverStopsPanic(t *testing.T) {
+	rec := &crashRecorder{}
+	defer SetHandler(rec.handle)()
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		defer Recover("channel:slack")
+		panic(errors.New("bad event"))
+	}()
+	<-done
+
+	if crashes := rec.all(); len(crashes) != 1 || crashes[0].Panic != "bad event" {
+		t.Fatalf("unexpected crashes: %+v", crashes)
+	}
+}
+
+func TestRunRestartsAfterPanic(t *testing.T) {
+	rec := &crashRecorder{}
+	defer SetHandler(rec.handle)()
+
+	calls := 0
+	err := Run(context.Background(), "worker:test", Config{InitialBackoff: time.Millisecond}, func(ctx context.Context) error {
+		calls++
+		if calls < 3 {
+			panic("flaky")
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if calls != 3 {
+		t.Fatalf("expected 3 calls, got %d", calls)
+	}
+	crashes := rec.all()
+	if len(crashes) != 2 || crashes[0].Restart != 1 || crashes[1].Restart != 2 {
+		t.Fatalf("expected two restarts, got %+v", crashes)
+	}
+}
+
+func TestRunStopsAfterMaxRestarts(t *testing.T) {
+	rec := &crashRecorder{}
+	defer SetHandler(rec.handle)()
+
+	calls := 0
+	err := Run(context.Background(), "worker:test", Config{InitialBackoff: time.Millisecond, MaxRestarts: 1}, func(ctx context.Context) error {
+		calls++
+		panic("always")
+	})
+	var panicErr *PanicError
+	if !errors.As(err, &panicErr) {
+		t.Fatalf("expected PanicError, got %v", err)
+	}
+	if calls != 2 {
+		t.Fatalf("expected the first run and one restart, got %d calls", calls)
+	}
+	crashes := rec.all()
+	if len(crashes) != 2 || crashes[1].Restart != 0 {
+		t.Fatalf("expected the final crash to report no restart, got %+v", crashes)
+	}
+}
+
+func TestRunStopsOnContextCancel(t *testing.T) {
+	defer SetHandler(nil)()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	calls := 0
+	err := Run(ctx, "worker:test", Config{InitialBackoff: time.Hour}, func(ctx context.Context) error {
+		calls++
+		cancel()
+		panic("during shutdown")
+	})
+	if err == nil || calls != 1 {
+		t.Fatalf("expected Run to stop after cancellation, got %v after %d calls", err, calls)
+	}
+}
+
+func TestReportSwallowsHandlerPanic(t *testing.T) {
+	defer SetHandler(func(Crash) { panic("handler") })()
+	Report(Crash{Component: "x"})
+}
@@ -54,6 +54,15 @@ type SchedulerConfig struct {
 	// Defaults to 30 minutes.
 	StaleTimeout time.Duration
 
+	// Partition reports whether this instance should schedule the given task.
+	// In a cluster it spreads due tasks across nodes so each task is scheduled
+	// by exactly one node. Nil schedules every due task.
+	Partition func(taskID string) bool
+
+	// Leader reports whether this instance should run stale execution cleanup.
+	// Nil always runs cleanup.
+	Leader func() bool
+
 	// Logger for scheduler events.
 	Logger *slog.Logger
 }
@@ -229,6 +238,9 @@ func (s *Scheduler) pollDueTasks(ctx context.Context) {
 	}
 
 	for _, task := range tasks {
+		if s.config.Partition != nil && !s.config.Partition(task.ID) {
+			continue
+		}
 		if err := s.scheduleTask(ctx, task, now); err != nil {
 			s.logger.Error("failed to schedule task",
 				"task_id", task.ID,
@@ -527,6 +539,9 @@ func (s *Scheduler) cleanupLoop(ctx context.Context) {
 
 // cleanupStaleExecutions marks long-running executions as timed out.
 func (s *Scheduler) cleanupStaleExecutions(ctx context.Context) {
+	if s.config.Leader != nil && !s.config.Leader() {
+		return
+	}
 	count, err := s.store.CleanupStaleExecutions(ctx, s.config.StaleTimeout)
 	if err != nil {
 		s.logger.Error("failed to cleanup stale executions", "error", err)
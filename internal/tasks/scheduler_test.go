@@ -522,6 +522,43 @@ func TestScheduler_PollDueTasks(t *testing.T) {
 	}
 }
 
+func TestScheduler_PollDueTasksPartition(t *testing.T) {
+	store := newMockStore()
+	executor := &mockExecutor{}
+	s := NewScheduler(store, executor, SchedulerConfig{
+		WorkerID:  "test",
+		Partition: func(taskID string) bool { return taskID == "task-mine" },
+	})
+
+	ctx := context.Background()
+	now := time.Now()
+	for _, id := range []string{"task-mine", "task-other"} {
+		store.CreateTask(ctx, &ScheduledTask{
+			ID:        id,
+			Name:      id,
+			AgentID:   "agent-1",
+			Schedule:  "*/5 * * * *",
+			Prompt:    "Run test",
+			Status:    TaskStatusActive,
+			NextRunAt: now.Add(-1 * time.Minute),
+			Config:    DefaultTaskConfig(),
+		})
+	}
+
+	s.pollDueTasks(ctx)
+
+	store.mu.Lock()
+	defer store.mu.Unlock()
+	if len(store.executions) != 1 {
+		t.Fatalf("execution count = %d, want 1", len(store.executions))
+	}
+	for _, exec := range store.executions {
+		if exec.TaskID != "task-mine" {
+			t.Errorf("scheduled task %q, want task-mine", exec.TaskID)
+		}
+	}
+}
+
 func TestScheduler_HandleAcquireError(t *testing.T) {
 	store := newMockStore()
 	store.acquireErr = errors.New("database error")
@@ -0,0 +1,132 @@
+package tasks
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// SQLiteStore implements Store using a local SQLite database. Most queries
+// are shared with CockroachStore; execution locking relies on SQLite's
+// database-level write lock instead of SELECT FOR UPDATE SKIP LOCKED.
+type SQLiteStore struct {
+	*CockroachStore
+}
+
+// NewSQLiteStore creates a task store on an open, migrated SQLite database.
+// The store takes ownership of db and closes it on Close.
+func NewSQLiteStore(db *sql.DB) (*SQLiteStore, error) {
+	if db == nil {
+		return nil, fmt.Errorf("db is required")
+	}
+	return &SQLiteStore{CockroachStore: &CockroachStore{db: db}}, nil
+}
+
+// AcquireExecution claims the oldest pending, unlocked execution. The
+// conditional update only succeeds for one worker per execution.
+func (s *SQLiteStore) AcquireExecution(ctx context.Context, workerID string, lockDuration time.Duration) (*TaskExecution, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("begin transaction: %w", err)
+	}
+	defer func() {
+		if err := tx.Rollback(); err != nil && !errors.Is(err, sql.ErrTxDone) {
+			_ = err
+		}
+	}()
+
+	now := time.Now()
+	lockUntil := now.Add(lockDuration)
+
+	row := tx.QueryRowContext(ctx, `
+		SELECT id, task_id, status, scheduled_at, started_at, finished_at,
+			   session_id, prompt, response, error, attempt_number,
+			   worker_id, locked_at, locked_until, duration, metadata
+		FROM task_executions
+		WHERE status = $1
+		  AND (locked_until IS NULL OR locked_until < $2)
+		ORDER BY scheduled_at ASC
+		LIMIT 1
+	`, string(ExecutionStatusPending), now)
+
+	exec, err := scanExecution(row)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("scan execution: %w", err)
+	}
+
+	result, err := tx.ExecContext(ctx, `
+		UPDATE task_executions SET
+			status = $1,
+			worker_id = $2,
+			locked_at = $3,
+			locked_until = $4,
+			started_at = $5
+		WHERE id = $6 AND status = $7
+	`,
+		string(ExecutionStatusRunning),
+		workerID,
+		now,
+		lockUntil,
+		now,
+		exec.ID,
+		string(ExecutionStatusPending),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("update execution lock: %w", err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return nil, fmt.Errorf("update execution lock: %w", err)
+	}
+	if affected == 0 {
+		return nil, nil
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("commit transaction: %w", err)
+	}
+
+	exec.Status = ExecutionStatusRunning
+	exec.WorkerID = workerID
+	exec.LockedAt = &now
+	exec.LockedUntil = &lockUntil
+	exec.StartedAt = &now
+
+	return exec, nil
+}
+
+// CleanupStaleExecutions marks executions running longer than timeout as
+// timed out.
+func (s *SQLiteStore) CleanupStaleExecutions(ctx context.Context, timeout time.Duration) (int, error) {
+	now := time.Now()
+	cutoff := now.Add(-timeout)
+
+	result, err := s.db.ExecContext(ctx, `
+		UPDATE task_executions SET
+			status = $1,
+			finished_at = $2,
+			error = $3
+		WHERE status = $4 AND started_at < $5
+	`,
+		string(ExecutionStatusTimedOut),
+		now,
+		"execution timed out",
+		string(ExecutionStatusRunning),
+		cutoff,
+	)
+	if err != nil {
+		return 0, fmt.Errorf("cleanup stale executions: %w", err)
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("get rows affected: %w", err)
+	}
+
+	return int(count), nil
+}
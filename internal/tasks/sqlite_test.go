@@ -0,0 +1,143 @@
+package tasks
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/haasonsaas/nexus/internal/sessions"
+)
+
+func newTestSQLiteStore(t *testing.T) *SQLiteStore {
+	t.Helper()
+	db, err := sessions.OpenSQLite(context.Background(), "sqlite://"+filepath.Join(t.TempDir(), "nexus.db"))
+	if err != nil {
+		t.Fatalf("OpenSQLite() error = %v", err)
+	}
+	store, err := NewSQLiteStore(db)
+	if err != nil {
+		t.Fatalf("NewSQLiteStore() error = %v", err)
+	}
+	t.Cleanup(func() { _ = store.Close() })
+	return store
+}
+
+func TestSQLiteStoreTaskLifecycle(t *testing.T) {
+	ctx := context.Background()
+	store := newTestSQLiteStore(t)
+	now := time.Now()
+
+	for i, id := range []string{"due", "later"} {
+		task := &ScheduledTask{
+			ID:        id,
+			Name:      id,
+			AgentID:   "agent",
+			Schedule:  "@every 1m",
+			Prompt:    "hello",
+			Config:    TaskConfig{Timeout: time.Minute},
+			Status:    TaskStatusActive,
+			NextRunAt: now.Add(time.Duration(i) * time.Hour).Add(-time.Minute),
+			CreatedAt: now,
+			UpdatedAt: now,
+		}
+		if err := store.CreateTask(ctx, task); err != nil {
+			t.Fatalf("CreateTask() error = %v", err)
+		}
+	}
+
+	due, err := store.GetDueTasks(ctx, now, 10)
+	if err != nil {
+		t.Fatalf("GetDueTasks() error = %v", err)
+	}
+	if len(due) != 1 || due[0].ID != "due" || due[0].Config.Timeout != time.Minute {
+		t.Fatalf("unexpected due tasks %+v", due)
+	}
+
+	exec := &TaskExecution{
+		ID:            "exec-1",
+		TaskID:        "due",
+		Status:        ExecutionStatusPending,
+		ScheduledAt:   now,
+		Prompt:        "hello",
+		AttemptNumber: 1,
+	}
+	if err := store.CreateExecution(ctx, exec); err != nil {
+		t.Fatalf("CreateExecution() error = %v", err)
+	}
+
+	acquired, err := store.AcquireExecution(ctx, "worker-1", time.Minute)
+	if err != nil {
+		t.Fatalf("AcquireExecution() error = %v", err)
+	}
+	if acquired == nil || acquired.ID != "exec-1" || acquired.WorkerID != "worker-1" {
+		t.Fatalf("unexpected acquired execution %+v", acquired)
+	}
+	again, err := store.AcquireExecution(ctx, "worker-2", time.Minute)
+	if err != nil {
+		t.Fatalf("AcquireExecution() error = %v", err)
+	}
+	if again != nil {
+		t.Fatalf("expected no execution for second worker, got %+v", again)
+	}
+
+	if err := store.CompleteExecution(ctx, "exec-1", ExecutionStatusSucceeded, "done", ""); err != nil {
+		t.Fatalf("CompleteExecution() error = %v", err)
+	}
+	completed, err := store.GetExecution(ctx, "exec-1")
+	if err != nil {
+		t.Fatalf("GetExecution() error = %v", err)
+	}
+	if completed.Status != ExecutionStatusSucceeded || completed.Response != "done" || completed.FinishedAt == nil {
+		t.Fatalf("unexpected completed execution %+v", completed)
+	}
+}
+
+func TestSQLiteStoreCleanupStaleExecutions(t *testing.T) {
+	ctx := context.Background()
+	store := newTestSQLiteStore(t)
+	now := time.Now()
+
+	task := &ScheduledTask{
+		ID:        "task",
+		Name:      "task",
+		AgentID:   "agent",
+		Schedule:  "@every 1m",
+		Prompt:    "hello",
+		Status:    TaskStatusActive,
+		NextRunAt: now,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if err := store.CreateTask(ctx, task); err != nil {
+		t.Fatalf("CreateTask() error = %v", err)
+	}
+	started := now.Add(-time.Hour)
+	exec := &TaskExecution{
+		ID:            "stale",
+		TaskID:        "task",
+		Status:        ExecutionStatusRunning,
+		ScheduledAt:   started,
+		StartedAt:     &started,
+		Prompt:        "hello",
+		AttemptNumber: 1,
+	}
+	if err := store.CreateExecution(ctx, exec); err != nil {
+		t.Fatalf("CreateExecution() error = %v", err)
+	}
+
+	count, err := store.CleanupStaleExecutions(ctx, 30*time.Minute)
+	if err != nil {
+		t.Fatalf("CleanupStaleExecutions() error = %v", err)
+	}
+	if count != 1 {
+		t.Fatalf("expected 1 stale execution, got %d", count)
+	}
+	loaded, err := store.GetExecution(ctx, "stale")
+	if err != nil {
+		t.Fatalf("GetExecution() error = %v", err)
+	}
+	if loaded.Status != ExecutionStatusTimedOut {
+		t.Fatalf("expected timed out status, got %s", loaded.Status)
+	}
+}
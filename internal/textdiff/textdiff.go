@@ -0,0 +1,204 @@
+// Package textdiff renders line-based unified diffs, used to preview what a
+// tool call would change before it runs.
+package textdiff
+
+import (
+	"fmt"
+	"strings"
+)
+
+// DefaultContext is the number of unchanged lines shown around each change.
+const DefaultContext = 3
+
+// MaxLines and MaxEdits bound the texts Unified compares and the number of
+// changed lines it looks for. Larger changes are summarized instead of
+// diffed.
+const (
+	MaxLines = 50000
+	MaxEdits = 4000
+)
+
+// Unified returns a unified diff that turns before into after, labelled with
+// fromName and toName and showing context unchanged lines around each
+// change. It returns "" when the texts are equal.
+func Unified(fromName, toName, before, after string, context int) string {
+	if before == after {
+		return ""
+	}
+	if context < 0 {
+		context = DefaultContext
+	}
+	a, b := splitLines(before), splitLines(after)
+
+	var out strings.Builder
+	fmt.Fprintf(&out, "--- %s\n+++ %s\n", fromName, toName)
+	var ops []op
+	ok := len(a) <= MaxLines && len(b) <= MaxLines
+	if ok {
+		ops, ok = diffLines(a, b)
+	}
+	if !ok {
+		fmt.Fprintf(&out, "@@ too large to diff: %d lines -> %d lines @@\n", len(a), len(b))
+		return out.String()
+	}
+	for start := 0; start < len(ops); {
+		// Find the next change and the run of changes close enough to share
+		// a hunk.
+		first := start
+		for first < len(ops) && ops[first].kind == opEqual {
+			first++
+		}
+		if first == len(ops) {
+			break
+		}
+		last := first
+		for i := first + 1; i < len(ops); i++ {
+			if ops[i].kind == opEqual {
+				continue
+			}
+			if i-last-1 > 2*context {
+				break
+			}
+			last = i
+		}
+		from := max(first-context, start)
+		to := min(last+context+1, len(ops))
+		writeHunk(&out, ops[from:to], a, b)
+		start = to
+	}
+	return out.String()
+}
+
+type opKind byte
+
+const (
+	opEqual  opKind = ' '
+	opDelete opKind = '-'
+	opInsert opKind = '+'
+)
+
+// op is one line of an edit script. a and b are the positions in the old
+// and new texts when the op applies.
+type op struct {
+	kind opKind
+	a, b int
+}
+
+func writeHunk(out *strings.Builder, ops []op, a, b []string) {
+	oldCount, newCount := 0, 0
+	for _, o := range ops {
+		if o.kind != opInsert {
+			oldCount++
+		}
+		if o.kind != opDelete {
+			newCount++
+		}
+	}
+	oldStart, newStart := ops[0].a, ops[0].b
+	if oldCount > 0 {
+		oldStart++
+	}
+	if newCount > 0 {
+		newStart++
+	}
+	fmt.Fprintf(out, "@@ -%s +%s @@\n", hunkRange(oldStart, oldCount), hunkRange(newStart, newCount))
+	for _, o := range ops {
+		line := ""
+		switch o.kind {
+		case opInsert:
+			line = b[o.b]
+		default:
+			line = a[o.a]
+		}
+		out.WriteByte(byte(o.kind))
+		out.WriteString(line)
+		out.WriteByte('\n')
+	}
+}
+
+func hunkRange(start, count int) string {
+	if count == 1 {
+		return fmt.Sprintf("%d", start)
+	}
+	return fmt.Sprintf("%d,%d", start, count)
+}
+
+func splitLines(text string) []string {
+	if text == "" {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(text, "\n"), "\n")
+}
+
+// diffLines returns the shortest edit script from a to b (Myers' algorithm),
+// or false when they differ by more than MaxEdits lines.
+func diffLines(a, b []string) ([]op, bool) {
+	n, m := len(a), len(b)
+	limit := min(n+m, MaxEdits)
+	offset := limit + 1
+	v := make([]int, 2*limit+3)
+	// trace[d] holds v[-d..d] as it was before step d.
+	var trace [][]int
+
+	found := false
+search:
+	for d := 0; d <= limit; d++ {
+		trace = append(trace, append([]int(nil), v[offset-d:offset+d+1]...))
+		for k := -d; k <= d; k += 2 {
+			var x int
+			if k == -d || (k != d && v[offset+k-1] < v[offset+k+1]) {
+				x = v[offset+k+1]
+			} else {
+				x = v[offset+k-1] + 1
+			}
+			y := x - k
+			for x < n && y < m && a[x] == b[y] {
+				x++
+				y++
+			}
+			v[offset+k] = x
+			if x >= n && y >= m {
+				found = true
+				break search
+			}
+		}
+	}
+	if !found {
+		return nil, false
+	}
+
+	// Walk the trace back from the end to recover the script.
+	var ops []op
+	x, y := n, m
+	for d := len(trace) - 1; d > 0; d-- {
+		at := func(k int) int { return trace[d][k+d] }
+		k := x - y
+		prevK := k - 1
+		if k == -d || (k != d && at(k-1) < at(k+1)) {
+			prevK = k + 1
+		}
+		prevX := at(prevK)
+		prevY := prevX - prevK
+		for x > prevX && y > prevY {
+			x--
+			y--
+			ops = append(ops, op{kind: opEqual, a: x, b: y})
+		}
+		if x == prevX {
+			y--
+			ops = append(ops, op{kind: opInsert, a: x, b: y})
+		} else {
+			x--
+			ops = append(ops, op{kind: opDelete, a: x, b: y})
+		}
+	}
+	for x > 0 && y > 0 {
+		x--
+		y--
+		ops = append(ops, op{kind: opEqual, a: x, b: y})
+	}
+	for i, j := 0, len(ops)-1; i < j; i, j = i+1, j-1 {
+		ops[i], ops[j] = ops[j], ops[i]
+	}
+	return ops, true
+}
@@ -0,0 +1,145 @@
+package textdiff
+
+import (
+	"math/rand"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestUnified(t *testing.T) {
+	tests := []struct {
+		name          string
+		before, after string
+		want          string
+	}{
+		{
+			name:   "equal",
+			before: "a\nb\n",
+			after:  "a\nb\n",
+			want:   "",
+		},
+		{
+			name:   "new file",
+			before: "",
+			after:  "one\ntwo\n",
+			want:   "--- a/f\n+++ b/f\n@@ -0,0 +1,2 @@\n+one\n+two\n",
+		},
+		{
+			name:   "change in the middle",
+			before: "1\n2\n3\n4\n5\n6\n7\n8\n9\n",
+			after:  "1\n2\n3\n4\nfive\n6\n7\n8\n9\n",
+			want:   "--- a/f\n+++ b/f\n@@ -2,7 +2,7 @@\n 2\n 3\n 4\n-5\n+five\n 6\n 7\n 8\n",
+		},
+		{
+			name:   "separate hunks",
+			before: "a\n1\n2\n3\n4\n5\n6\n7\n8\nb\n",
+			after:  "A\n1\n2\n3\n4\n5\n6\n7\n8\nB\n",
+			want:   "--- a/f\n+++ b/f\n@@ -1,4 +1,4 @@\n-a\n+A\n 1\n 2\n 3\n@@ -7,4 +7,4 @@\n 6\n 7\n 8\n-b\n+B\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Unified("a/f", "b/f", tt.before, tt.after, DefaultContext); got != tt.want {
+				t.Fatalf("Unified() =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUnifiedRoundTrip(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	words := []string{"alpha", "beta", "gamma", "delta", "epsilon"}
+	randomText := func() string {
+		lines := make([]string, rng.Intn(30))
+		for i := range lines {
+			lines[i] = words[rng.Intn(len(words))]
+		}
+		if len(lines) == 0 {
+			return ""
+		}
+		return strings.Join(lines, "\n") + "\n"
+	}
+	for i := 0; i < 200; i++ {
+		before, after := randomText(), randomText()
+		diff := Unified("a", "b", before, after, rng.Intn(4))
+		if got := applyUnified(t, before, diff); got != after {
+			t.Fatalf("round trip %d failed\nbefore:\n%s\nafter:\n%s\ndiff:\n%s\ngot:\n%s", i, before, after, diff, got)
+		}
+	}
+}
+
+func TestUnifiedTooLarge(t *testing.T) {
+	before := strings.Repeat("x\n", MaxLines+1)
+	diff := Unified("a", "b", before, "", DefaultContext)
+	if !strings.Contains(diff, "too large to diff") {
+		t.Fatalf("expected a summary, got %q", diff)
+	}
+}
+
+var hunkHeader = regexp.MustCompile(`^@@ -(\d+)(?:,(\d+))? \+(\d+)(?:,(\d+))? @@$`)
+
+// applyUnified applies a diff from Unified, checking its hunk headers.
+func applyUnified(t *testing.T, before, diff string) string {
+	t.Helper()
+	if diff == "" {
+		return before
+	}
+	old := splitLines(before)
+	var out []string
+	pos := 0
+	lines := strings.Split(strings.TrimSuffix(diff, "\n"), "\n")[2:]
+	for i := 0; i < len(lines); {
+		m := hunkHeader.FindStringSubmatch(lines[i])
+		if m == nil {
+			t.Fatalf("bad hunk header %q", lines[i])
+		}
+		oldStart, _ := strconv.Atoi(m[1])
+		oldCount, newCount := 1, 1
+		if m[2] != "" {
+			oldCount, _ = strconv.Atoi(m[2])
+		}
+		if m[4] != "" {
+			newCount, _ = strconv.Atoi(m[4])
+		}
+		start := oldStart - 1
+		if oldCount == 0 {
+			start = oldStart
+		}
+		out = append(out, old[pos:start]...)
+		pos = start
+		i++
+		seenOld, seenNew := 0, 0
+		for ; i < len(lines) && !strings.HasPrefix(lines[i], "@@"); i++ {
+			line := lines[i]
+			switch line[0] {
+			case ' ':
+				if old[pos] != line[1:] {
+					t.Fatalf("context mismatch at line %d: %q != %q", pos+1, old[pos], line[1:])
+				}
+				out = append(out, line[1:])
+				pos++
+				seenOld++
+				seenNew++
+			case '-':
+				if old[pos] != line[1:] {
+					t.Fatalf("delete mismatch at line %d: %q != %q", pos+1, old[pos], line[1:])
+				}
+				pos++
+				seenOld++
+			case '+':
+				out = append(out, line[1:])
+				seenNew++
+			}
+		}
+		if seenOld != oldCount || seenNew != newCount {
+			t.Fatalf("hunk %q has %d/%d lines", m[0], seenOld, seenNew)
+		}
+	}
+	out = append(out, old[pos:]...)
+	if len(out) == 0 {
+		return ""
+	}
+	return strings.Join(out, "\n") + "\n"
+}
@@ -0,0 +1,293 @@
+// Package broadcast delivers one message to a configured list of channels
+// and peers, formatting it per channel and retrying failed deliveries.
+package broadcast
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/haasonsaas/nexus/internal/channels"
+	channelcontext "github.com/haasonsaas/nexus/internal/channels/context"
+	"github.com/haasonsaas/nexus/internal/config"
+	"github.com/haasonsaas/nexus/pkg/models"
+)
+
+// Output formats for a broadcast target.
+const (
+	FormatAuto     = "auto"
+	FormatMarkdown = "markdown"
+	FormatPlain    = "plain"
+)
+
+// Target is a single broadcast destination.
+type Target struct {
+	Channel models.ChannelType
+	PeerID  string
+	Format  string
+	Label   string
+}
+
+// Name returns the target's label, or channel:peer when unlabelled.
+func (t Target) Name() string {
+	if t.Label != "" {
+		return t.Label
+	}
+	return string(t.Channel) + ":" + t.PeerID
+}
+
+// Result is the delivery outcome for one target.
+type Result struct {
+	Target    Target
+	MessageID string
+	Attempts  int
+	Delivered bool
+	Error     string
+}
+
+// Report summarizes a broadcast.
+type Report struct {
+	ID        string
+	List      string
+	Text      string
+	StartedAt time.Time
+	Results   []Result
+}
+
+// Delivered returns the number of targets the message reached.
+func (r *Report) Delivered() int {
+	n := 0
+	for _, result := range r.Results {
+		if result.Delivered {
+			n++
+		}
+	}
+	return n
+}
+
+// Failed returns the targets the message could not be delivered to.
+func (r *Report) Failed() []Target {
+	var failed []Target
+	for _, result := range r.Results {
+		if !result.Delivered {
+			failed = append(failed, result.Target)
+		}
+	}
+	return failed
+}
+
+// Summary renders the delivery report for a chat reply.
+func (r *Report) Summary() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Broadcast to %s: %d/%d delivered", r.List, r.Delivered(), len(r.Results))
+	for _, result := range r.Results {
+		if result.Delivered {
+			fmt.Fprintf(&sb, "\n- %s: delivered", result.Target.Name())
+			continue
+		}
+		fmt.Fprintf(&sb, "\n- %s: failed after %d attempt(s): %s", result.Target.Name(), result.Attempts, result.Error)
+	}
+	return sb.String()
+}
+
+// Broadcaster delivers messages to configured broadcast lists.
+type Broadcaster struct {
+	channels    *channels.Registry
+	lists       map[string][]Target
+	maxAttempts int
+	retryDelay  time.Duration
+	sleep       func(context.Context, time.Duration) error
+
+	mu      sync.Mutex
+	pending map[string]*Report
+}
+
+// New creates a Broadcaster for the configured outbound lists.
+func New(registry *channels.Registry, cfg config.OutboundBroadcastConfig) *Broadcaster {
+	lists := make(map[string][]Target, len(cfg.Lists))
+	for name, targets := range cfg.Lists {
+		for _, target := range targets {
+			lists[name] = append(lists[name], Target{
+				Channel: models.ChannelType(strings.ToLower(strings.TrimSpace(target.Channel))),
+				PeerID:  strings.TrimSpace(target.PeerID),
+				Format:  strings.ToLower(strings.TrimSpace(target.Format)),
+				Label:   strings.TrimSpace(target.Label),
+			})
+		}
+	}
+	maxAttempts := cfg.MaxAttempts
+	if maxAttempts <= 0 {
+		maxAttempts = 1
+	}
+	return &Broadcaster{
+		channels:    registry,
+		lists:       lists,
+		maxAttempts: maxAttempts,
+		retryDelay:  cfg.RetryDelay,
+		sleep:       sleepContext,
+		pending:     make(map[string]*Report),
+	}
+}
+
+// Lists returns the configured list names in sorted order.
+func (b *Broadcaster) Lists() []string {
+	names := make([]string, 0, len(b.lists))
+	for name := range b.lists {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Targets returns the targets of a list.
+func (b *Broadcaster) Targets(list string) ([]Target, bool) {
+	targets, ok := b.lists[list]
+	return targets, ok
+}
+
+// Send delivers text to every target of list. Failed targets are kept under
+// key (typically the requesting session) so RetryFailed can resend them.
+func (b *Broadcaster) Send(ctx context.Context, key, list, text string) (*Report, error) {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return nil, errors.New("message is required")
+	}
+	targets, ok := b.lists[list]
+	if !ok {
+		return nil, fmt.Errorf("unknown broadcast list %q", list)
+	}
+	report := b.deliver(ctx, uuid.NewString(), list, text, targets)
+	b.remember(key, report)
+	return report, nil
+}
+
+// RetryFailed resends the last broadcast under key to the targets it
+// failed to reach.
+func (b *Broadcaster) RetryFailed(ctx context.Context, key string) (*Report, error) {
+	b.mu.Lock()
+	previous := b.pending[key]
+	b.mu.Unlock()
+	if previous == nil {
+		return nil, errors.New("no failed broadcast deliveries to retry")
+	}
+	report := b.deliver(ctx, previous.ID, previous.List, previous.Text, previous.Failed())
+	b.remember(key, report)
+	return report, nil
+}
+
+func (b *Broadcaster) remember(key string, report *Report) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if len(report.Failed()) == 0 {
+		delete(b.pending, key)
+		return
+	}
+	b.pending[key] = report
+}
+
+func (b *Broadcaster) deliver(ctx context.Context, id, list, text string, targets []Target) *Report {
+	report := &Report{
+		ID:        id,
+		List:      list,
+		Text:      text,
+		StartedAt: time.Now(),
+		Results:   make([]Result, len(targets)),
+	}
+	var wg sync.WaitGroup
+	for i, target := range targets {
+		wg.Add(1)
+		go func(i int, target Target) {
+			defer wg.Done()
+			report.Results[i] = b.deliverTarget(ctx, report, target)
+		}(i, target)
+	}
+	wg.Wait()
+	return report
+}
+
+func (b *Broadcaster) deliverTarget(ctx context.Context, report *Report, target Target) Result {
+	result := Result{Target: target}
+	var adapter channels.OutboundAdapter
+	if b.channels != nil {
+		adapter, _ = b.channels.GetOutbound(target.Channel)
+	}
+	if adapter == nil {
+		result.Error = fmt.Sprintf("channel %s not available", target.Channel)
+		return result
+	}
+
+	content := Format(report.Text, target.Channel, target.Format)
+	delay := b.retryDelay
+	for result.Attempts < b.maxAttempts {
+		if result.Attempts > 0 {
+			if err := b.sleep(ctx, delay); err != nil {
+				result.Error = err.Error()
+				return result
+			}
+			delay *= 2
+		}
+		result.Attempts++
+		msg := &models.Message{
+			ID:        uuid.NewString(),
+			Channel:   target.Channel,
+			ChannelID: target.PeerID,
+			Direction: models.DirectionOutbound,
+			Role:      models.RoleAssistant,
+			Content:   content,
+			Metadata: map[string]any{
+				"broadcast_id":   report.ID,
+				"broadcast_list": report.List,
+			},
+			CreatedAt: time.Now(),
+		}
+		err := adapter.Send(ctx, msg)
+		if err == nil {
+			result.Delivered = true
+			result.MessageID = msg.ID
+			result.Error = ""
+			return result
+		}
+		result.Error = err.Error()
+	}
+	return result
+}
+
+// Format renders markdown text for a channel. FormatAuto converts to Slack
+// mrkdwn on Slack and strips markdown on channels that send plain text.
+func Format(text string, channel models.ChannelType, format string) string {
+	switch format {
+	case FormatMarkdown:
+		return text
+	case FormatPlain:
+		return channelcontext.StripMarkdown(text)
+	}
+	switch channelcontext.GetChannelInfo(string(channel)).MarkdownFlavor {
+	case "slack":
+		return channelcontext.ToSlackMarkdown(text)
+	case "none", "telegram":
+		// The Telegram adapter sends without a parse mode.
+		return channelcontext.StripMarkdown(text)
+	default:
+		return text
+	}
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,160 @@
+package broadcast
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/haasonsaas/nexus/internal/agent"
+	"github.com/haasonsaas/nexus/internal/channels"
+	"github.com/haasonsaas/nexus/internal/config"
+	"github.com/haasonsaas/nexus/pkg/models"
+)
+
+type flakyAdapter struct {
+	channel  models.ChannelType
+	mu       sync.Mutex
+	failures int
+	calls    int
+	sent     []*models.Message
+}
+
+func (a *flakyAdapter) Type() models.ChannelType { return a.channel }
+
+func (a *flakyAdapter) Send(_ context.Context, msg *models.Message) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.calls++
+	if a.failures != 0 {
+		a.failures--
+		return errors.New("rate limited")
+	}
+	a.sent = append(a.sent, msg)
+	return nil
+}
+
+func newTestBroadcaster(t *testing.T, slack, telegram *flakyAdapter) (*Broadcaster, *[]time.Duration) {
+	t.Helper()
+	registry := channels.NewRegistry()
+	registry.Register(slack)
+	registry.Register(telegram)
+	b := New(registry, config.OutboundBroadcastConfig{
+		Lists: map[string][]config.BroadcastTargetConfig{
+			"team": {
+				{Channel: "slack", PeerID: "C1", Label: "#general"},
+				{Channel: "Telegram", PeerID: "42"},
+				{Channel: "discord", PeerID: "D1"},
+			},
+		},
+		MaxAttempts: 3,
+		RetryDelay:  time.Second,
+	})
+	var delays []time.Duration
+	var mu sync.Mutex
+	b.sleep = func(_ context.Context, d time.Duration) error {
+		mu.Lock()
+		defer mu.Unlock()
+		delays = append(delays, d)
+		return nil
+	}
+	return b, &delays
+}
+
+func TestBroadcasterSendFormatsAndRetries(t *testing.T) {
+	slack := &flakyAdapter{channel: models.ChannelSlack}
+	telegram := &flakyAdapter{channel: models.ChannelTelegram, failures: 2}
+	b, delays := newTestBroadcaster(t, slack, telegram)
+
+	report, err := b.Send(context.Background(), "s1", "team", "**Release** is out")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.Delivered() != 2 || len(report.Results) != 3 {
+		t.Fatalf("unexpected report %+v", report)
+	}
+	if got := slack.sent[0].Content; got != "*Release* is out" {
+		t.Fatalf("slack content = %q", got)
+	}
+	if got := telegram.sent[0].Content; got != "Release is out" {
+		t.Fatalf("telegram content = %q", got)
+	}
+	if telegram.calls != 3 || report.Results[1].Attempts != 3 {
+		t.Fatalf("expected 3 telegram attempts, got %d", telegram.calls)
+	}
+	if len(*delays) != 2 || (*delays)[0] != time.Second || (*delays)[1] != 2*time.Second {
+		t.Fatalf("unexpected backoff %v", *delays)
+	}
+	if slack.sent[0].Metadata["broadcast_id"] != report.ID || slack.sent[0].ChannelID != "C1" {
+		t.Fatalf("unexpected message %+v", slack.sent[0])
+	}
+
+	summary := report.Summary()
+	for _, want := range []string{"Broadcast to team: 2/3 delivered", "#general: delivered", "discord:D1: failed after 0 attempt(s): channel discord not available"} {
+		if !strings.Contains(summary, want) {
+			t.Fatalf("summary %q missing %q", summary, want)
+		}
+	}
+
+	if _, err := b.Send(context.Background(), "s1", "nope", "hi"); err == nil {
+		t.Fatal("expected unknown list error")
+	}
+}
+
+func TestBroadcasterRetryFailed(t *testing.T) {
+	slack := &flakyAdapter{channel: models.ChannelSlack}
+	telegram := &flakyAdapter{channel: models.ChannelTelegram, failures: 3}
+	b, _ := newTestBroadcaster(t, slack, telegram)
+	b.lists["team"] = b.lists["team"][:2]
+
+	report, err := b.Send(context.Background(), "s1", "team", "hello")
+	if err != nil || report.Delivered() != 1 {
+		t.Fatalf("Send() = %+v, %v", report, err)
+	}
+	if _, err := b.RetryFailed(context.Background(), "s2"); err == nil {
+		t.Fatal("expected no pending retries for another session")
+	}
+
+	retry, err := b.RetryFailed(context.Background(), "s1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if retry.ID != report.ID || len(retry.Results) != 1 || !retry.Results[0].Delivered {
+		t.Fatalf("unexpected retry report %+v", retry)
+	}
+	if len(slack.sent) != 1 || len(telegram.sent) != 1 {
+		t.Fatalf("expected one delivery per channel, got slack=%d telegram=%d", len(slack.sent), len(telegram.sent))
+	}
+	if _, err := b.RetryFailed(context.Background(), "s1"); err == nil {
+		t.Fatal("expected nothing left to retry")
+	}
+}
+
+func TestToolExecute(t *testing.T) {
+	slack := &flakyAdapter{channel: models.ChannelSlack}
+	telegram := &flakyAdapter{channel: models.ChannelTelegram}
+	b, _ := newTestBroadcaster(t, slack, telegram)
+	tool := NewTool(b)
+	ctx := agent.WithSession(context.Background(), &models.Session{ID: "s1"})
+
+	result, err := tool.Execute(ctx, json.RawMessage(`{"list":"team","message":"hi all"}`))
+	if err != nil || result.IsError {
+		t.Fatalf("Execute() = %+v, %v", result, err)
+	}
+	if !strings.Contains(result.Content, "2/3 delivered") {
+		t.Fatalf("unexpected result %q", result.Content)
+	}
+
+	result, err = tool.Execute(ctx, json.RawMessage(`{"action":"lists"}`))
+	if err != nil || !strings.Contains(result.Content, "team: #general, telegram:42, discord:D1") {
+		t.Fatalf("unexpected lists %+v, %v", result, err)
+	}
+
+	result, err = tool.Execute(ctx, json.RawMessage(`{"list":"team"}`))
+	if err != nil || !result.IsError {
+		t.Fatalf("expected missing message error, got %+v, %v", result, err)
+	}
+}
@@ -0,0 +1,110 @@
+package broadcast
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/haasonsaas/nexus/internal/agent"
+)
+
+// Tool lets the agent deliver a message to a configured broadcast list.
+type Tool struct {
+	broadcaster *Broadcaster
+}
+
+// NewTool creates the broadcast tool.
+func NewTool(broadcaster *Broadcaster) *Tool {
+	return &Tool{broadcaster: broadcaster}
+}
+
+func (t *Tool) Name() string { return "broadcast" }
+
+func (t *Tool) Description() string {
+	lists := ""
+	if t.broadcaster != nil {
+		lists = strings.Join(t.broadcaster.Lists(), ", ")
+	}
+	return "Deliver one message to every channel in a configured broadcast list and report per-target delivery. " +
+		"Write the message in markdown; it is reformatted for each channel. Available lists: " + lists
+}
+
+func (t *Tool) Schema() json.RawMessage {
+	return json.RawMessage(`{
+		"type": "object",
+		"properties": {
+			"action": {
+				"type": "string",
+				"enum": ["send", "retry", "lists"],
+				"description": "send (default) delivers message to list; retry resends the last broadcast to targets that failed; lists shows the configured lists"
+			},
+			"list": {
+				"type": "string",
+				"description": "Name of the broadcast list"
+			},
+			"message": {
+				"type": "string",
+				"description": "The message to deliver"
+			}
+		}
+	}`)
+}
+
+// Execute runs the broadcast action.
+func (t *Tool) Execute(ctx context.Context, params json.RawMessage) (*agent.ToolResult, error) {
+	if t.broadcaster == nil {
+		return &agent.ToolResult{Content: "broadcast is not configured", IsError: true}, nil
+	}
+	var input struct {
+		Action  string `json:"action"`
+		List    string `json:"list"`
+		Message string `json:"message"`
+	}
+	if err := json.Unmarshal(params, &input); err != nil {
+		return nil, fmt.Errorf("parse input: %w", err)
+	}
+
+	key := ""
+	if session := agent.SessionFromContext(ctx); session != nil {
+		key = session.ID
+	}
+
+	var (
+		report *Report
+		err    error
+	)
+	switch strings.TrimSpace(input.Action) {
+	case "lists":
+		return &agent.ToolResult{Content: FormatLists(t.broadcaster)}, nil
+	case "retry":
+		report, err = t.broadcaster.RetryFailed(ctx, key)
+	case "", "send":
+		report, err = t.broadcaster.Send(ctx, key, strings.TrimSpace(input.List), input.Message)
+	default:
+		return &agent.ToolResult{Content: "unsupported action", IsError: true}, nil
+	}
+	if err != nil {
+		return &agent.ToolResult{Content: err.Error(), IsError: true}, nil
+	}
+	return &agent.ToolResult{Content: report.Summary(), IsError: report.Delivered() == 0}, nil
+}
+
+// FormatLists renders the configured broadcast lists and their targets.
+func FormatLists(b *Broadcaster) string {
+	names := b.Lists()
+	if len(names) == 0 {
+		return "No broadcast lists are configured."
+	}
+	var sb strings.Builder
+	sb.WriteString("Broadcast lists:")
+	for _, name := range names {
+		targets, _ := b.Targets(name)
+		labels := make([]string, 0, len(targets))
+		for _, target := range targets {
+			labels = append(labels, target.Name())
+		}
+		fmt.Fprintf(&sb, "\n- %s: %s", name, strings.Join(labels, ", "))
+	}
+	return sb.String()
+}
@@ -155,6 +155,38 @@ func (p *Pool) Release(instance *BrowserInstance) {
 	}
 }
 
+// RenderHTML loads url in a fresh page of a pooled browser and returns the
+// HTML after network activity settles. The instance's main page is left
+// untouched.
+func (p *Pool) RenderHTML(ctx context.Context, url string) (string, error) {
+	instance, err := p.Acquire(ctx)
+	if err != nil {
+		return "", err
+	}
+	defer p.Release(instance)
+
+	page, err := instance.Context.NewPage()
+	if err != nil {
+		return "", fmt.Errorf("open page: %w", err)
+	}
+	defer page.Close()
+
+	timeout := p.config.Timeout
+	if deadline, ok := ctx.Deadline(); ok {
+		if remaining := time.Until(deadline); remaining < timeout || timeout <= 0 {
+			timeout = remaining
+		}
+	}
+	opts := playwright.PageGotoOptions{WaitUntil: playwright.WaitUntilStateNetworkidle}
+	if timeout > 0 {
+		opts.Timeout = playwright.Float(float64(timeout.Milliseconds()))
+	}
+	if _, err := page.Goto(url, opts); err != nil {
+		return "", fmt.Errorf("navigate: %w", err)
+	}
+	return page.Content()
+}
+
 // Close closes all browser instances and shuts down the Playwright runtime.
 // After Close is called, the pool cannot be used.
 func (p *Pool) Close() error {
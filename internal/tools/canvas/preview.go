@@ -0,0 +1,54 @@
+package canvas
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/haasonsaas/nexus/internal/textdiff"
+)
+
+// PreviewChange returns a unified diff of the canvas state a reset would
+// replace. Other actions do not rewrite stored state and preview as "".
+func (t *Tool) PreviewChange(ctx context.Context, params json.RawMessage) (string, error) {
+	var input struct {
+		Action    string          `json:"action"`
+		SessionID string          `json:"session_id"`
+		State     json.RawMessage `json:"state"`
+	}
+	if err := json.Unmarshal(params, &input); err != nil {
+		return "", fmt.Errorf("invalid parameters: %w", err)
+	}
+	if strings.ToLower(strings.TrimSpace(input.Action)) != "reset" || t.manager == nil {
+		return "", nil
+	}
+	sessionID := strings.TrimSpace(input.SessionID)
+	if sessionID == "" || len(input.State) == 0 {
+		return "", nil
+	}
+	current, _, err := t.manager.Snapshot(ctx, sessionID)
+	if err != nil {
+		return "", err
+	}
+	var before json.RawMessage
+	if current != nil {
+		before = current.StateJSON
+	}
+	name := "canvas/" + sessionID + "/state.json"
+	return textdiff.Unified("a/"+name, "b/"+name, indentJSON(before), indentJSON(input.State), textdiff.DefaultContext), nil
+}
+
+// indentJSON formats raw one value per line so diffs stay readable.
+func indentJSON(raw json.RawMessage) string {
+	if len(raw) == 0 {
+		return ""
+	}
+	var out bytes.Buffer
+	if err := json.Indent(&out, raw, "", "  "); err != nil {
+		return string(raw) + "\n"
+	}
+	out.WriteByte('\n')
+	return out.String()
+}
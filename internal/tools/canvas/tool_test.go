@@ -332,3 +332,44 @@ func TestCanvasToolPushSnapshot(t *testing.T) {
 		t.Fatalf("expected 1 event, got %d", len(parsed.Events))
 	}
 }
+
+func TestCanvasToolPreviewReset(t *testing.T) {
+	ctx := context.Background()
+	store := canvascore.NewMemoryStore()
+	manager := canvascore.NewManager(store, nil)
+
+	session := &canvascore.Session{
+		Key:         "slack:workspace:channel",
+		WorkspaceID: "workspace",
+		ChannelID:   "channel",
+	}
+	if err := store.CreateSession(ctx, session); err != nil {
+		t.Fatalf("CreateSession: %v", err)
+	}
+	if _, err := manager.Reset(ctx, session.ID, json.RawMessage(`{"status":"draft"}`)); err != nil {
+		t.Fatalf("Reset: %v", err)
+	}
+
+	tool := NewTool(nil, manager)
+	params, _ := json.Marshal(map[string]interface{}{
+		"action":     "reset",
+		"session_id": session.ID,
+		"state":      map[string]interface{}{"status": "final"},
+	})
+	diff, err := tool.PreviewChange(ctx, params)
+	if err != nil {
+		t.Fatalf("PreviewChange: %v", err)
+	}
+	if !strings.Contains(diff, `-  "status": "draft"`) || !strings.Contains(diff, `+  "status": "final"`) {
+		t.Fatalf("unexpected diff:\n%s", diff)
+	}
+
+	pushParams, _ := json.Marshal(map[string]interface{}{
+		"action":     "push",
+		"session_id": session.ID,
+		"payload":    map[string]interface{}{"status": "ok"},
+	})
+	if diff, err := tool.PreviewChange(ctx, pushParams); err != nil || diff != "" {
+		t.Fatalf("expected no preview for push, got %q, %v", diff, err)
+	}
+}
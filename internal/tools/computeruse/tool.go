@@ -12,6 +12,10 @@ import (
 	"github.com/haasonsaas/nexus/internal/observability"
 )
 
+// RecordingArtifactType is the artifact type of the per-session action
+// recording an edge attaches to every computer use result.
+const RecordingArtifactType = "recording"
+
 // Config controls how the computer use tool selects its target edge.
 type Config struct {
 	EdgeID          string
@@ -89,6 +93,11 @@ func (t *Tool) Execute(ctx context.Context, params json.RawMessage) (*agent.Tool
 
 	artifacts := make([]agent.Artifact, 0, len(result.Artifacts))
 	for _, art := range result.Artifacts {
+		if art.Type == RecordingArtifactType {
+			// The edge manager stores the session recording; it is not
+			// something to send back to the conversation.
+			continue
+		}
 		artifacts = append(artifacts, agent.Artifact{
 			ID:       art.Id,
 			Type:     art.Type,
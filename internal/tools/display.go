@@ -74,6 +74,7 @@ var defaultToolEmojis = map[string]string{
 	"memory_search":        "🧠",
 	"vector_memory_search": "🧠",
 	"vector_memory_write":  "🧠",
+	"vector_memory_forget": "🧠",
 	"message":              "💬",
 	"send_message":         "📤",
 	"spawn_agent":          "🤖",
@@ -161,6 +162,12 @@ func DefaultToolDisplayConfig() *ToolDisplayConfig {
 				Label:      "Writing memory",
 				DetailKeys: []string{"scope", "tags"},
 			},
+			"vector_memory_forget": {
+				Emoji:      "🧠",
+				Title:      "Vector Memory Forget",
+				Label:      "Forgetting",
+				DetailKeys: []string{"query", "ids"},
+			},
 			"send_message": {
 				Emoji:      "📤",
 				Title:      "Send Message",
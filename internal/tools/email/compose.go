@@ -0,0 +1,123 @@
+// Package email provides the email_compose tool for sending new email
+// through the IMAP/SMTP email channel.
+package email
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/haasonsaas/nexus/internal/agent"
+	emailchannel "github.com/haasonsaas/nexus/internal/channels/email"
+)
+
+// ToolName is the name of the compose tool, used by approval policies.
+const ToolName = "email_compose"
+
+// Composer sends composed email.
+type Composer interface {
+	Compose(ctx context.Context, draft emailchannel.Draft) (string, error)
+}
+
+// composeInput holds the email_compose parameters.
+type composeInput struct {
+	To        []string `json:"to"`
+	Cc        []string `json:"cc,omitempty"`
+	Subject   string   `json:"subject"`
+	Body      string   `json:"body"`
+	InReplyTo string   `json:"in_reply_to,omitempty"`
+}
+
+// ComposeTool sends a new email to any recipients. Replies to the current
+// conversation do not need it; the channel threads them itself.
+type ComposeTool struct {
+	composer Composer
+}
+
+// NewComposeTool creates the email_compose tool.
+func NewComposeTool(composer Composer) *ComposeTool {
+	return &ComposeTool{composer: composer}
+}
+
+// Name returns the tool name.
+func (t *ComposeTool) Name() string {
+	return ToolName
+}
+
+// Description describes the tool.
+func (t *ComposeTool) Description() string {
+	return "Composes and sends a new email from the agent's mailbox. Use it to write to people other than the sender " +
+		"of the current email; replies to the current email are sent automatically. Sending may wait for approval."
+}
+
+// Schema defines the tool parameters.
+func (t *ComposeTool) Schema() json.RawMessage {
+	return json.RawMessage(`{
+  "type": "object",
+  "properties": {
+    "to": {"type": "array", "items": {"type": "string"}, "description": "Recipient addresses, e.g. \"Ada <ada@example.com>\""},
+    "cc": {"type": "array", "items": {"type": "string"}, "description": "Copied addresses"},
+    "subject": {"type": "string", "description": "Subject line"},
+    "body": {"type": "string", "description": "Plain text body"},
+    "in_reply_to": {"type": "string", "description": "Optional Message-ID to thread the email under"}
+  },
+  "required": ["to", "subject", "body"]
+}`)
+}
+
+// Execute sends the email and reports its Message-ID.
+func (t *ComposeTool) Execute(ctx context.Context, params json.RawMessage) (*agent.ToolResult, error) {
+	if t.composer == nil {
+		return &agent.ToolResult{Content: "email compose is not configured", IsError: true}, nil
+	}
+	var input composeInput
+	if err := json.Unmarshal(params, &input); err != nil {
+		return nil, fmt.Errorf("parse input: %w", err)
+	}
+	if strings.TrimSpace(input.Subject) == "" || strings.TrimSpace(input.Body) == "" {
+		return &agent.ToolResult{Content: "subject and body are required", IsError: true}, nil
+	}
+
+	messageID, err := t.composer.Compose(ctx, emailchannel.Draft{
+		To:        input.To,
+		Cc:        input.Cc,
+		Subject:   input.Subject,
+		Body:      input.Body,
+		InReplyTo: input.InReplyTo,
+	})
+	if err != nil {
+		return &agent.ToolResult{Content: "send failed: " + err.Error(), IsError: true}, nil
+	}
+	recipients := strings.Join(append(append([]string{}, input.To...), input.Cc...), ", ")
+	return &agent.ToolResult{Content: fmt.Sprintf("Sent %q to %s (Message-ID %s)", input.Subject, recipients, messageID)}, nil
+}
+
+// PreviewDraft shows the email a call would send, for draft approval.
+func (t *ComposeTool) PreviewDraft(params json.RawMessage) (string, error) {
+	var input composeInput
+	if err := json.Unmarshal(params, &input); err != nil {
+		return "", fmt.Errorf("parse input: %w", err)
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "To: %s\n", strings.Join(input.To, ", "))
+	if len(input.Cc) > 0 {
+		fmt.Fprintf(&b, "Cc: %s\n", strings.Join(input.Cc, ", "))
+	}
+	fmt.Fprintf(&b, "Subject: %s\n\n%s", input.Subject, input.Body)
+	return b.String(), nil
+}
+
+// ReviseDraft replaces the body of a drafted email with text.
+func (t *ComposeTool) ReviseDraft(params json.RawMessage, text string) (json.RawMessage, error) {
+	var input composeInput
+	if err := json.Unmarshal(params, &input); err != nil {
+		return nil, fmt.Errorf("parse input: %w", err)
+	}
+	if strings.TrimSpace(text) == "" {
+		return nil, errors.New("body is required")
+	}
+	input.Body = text
+	return json.Marshal(input)
+}
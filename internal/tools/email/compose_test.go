@@ -0,0 +1,82 @@
+package email
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	emailchannel "github.com/haasonsaas/nexus/internal/channels/email"
+)
+
+type recordingComposer struct {
+	drafts []emailchannel.Draft
+	err    error
+}
+
+func (c *recordingComposer) Compose(ctx context.Context, draft emailchannel.Draft) (string, error) {
+	c.drafts = append(c.drafts, draft)
+	return "<id-1@example.com>", c.err
+}
+
+func TestComposeTool(t *testing.T) {
+	composer := &recordingComposer{}
+	tool := NewComposeTool(composer)
+	params, _ := json.Marshal(map[string]any{
+		"to":          []string{"ada@example.com"},
+		"cc":          []string{"bob@example.com"},
+		"subject":     "Notes",
+		"body":        "Attached below.",
+		"in_reply_to": "<root@example.com>",
+	})
+	result, err := tool.Execute(context.Background(), params)
+	if err != nil || result.IsError {
+		t.Fatalf("Execute() = %+v, %v", result, err)
+	}
+	if !strings.Contains(result.Content, "ada@example.com, bob@example.com") || !strings.Contains(result.Content, "<id-1@example.com>") {
+		t.Fatalf("result = %q", result.Content)
+	}
+	if len(composer.drafts) != 1 || composer.drafts[0].InReplyTo != "<root@example.com>" || composer.drafts[0].Cc[0] != "bob@example.com" {
+		t.Fatalf("drafts = %+v", composer.drafts)
+	}
+
+	result, _ = tool.Execute(context.Background(), json.RawMessage(`{"to":["ada@example.com"],"subject":"","body":"x"}`))
+	if !result.IsError || len(composer.drafts) != 1 {
+		t.Fatalf("expected a missing subject to be refused, got %+v", result)
+	}
+
+	composer.err = errors.New("550 mailbox unavailable")
+	result, _ = tool.Execute(context.Background(), params)
+	if !result.IsError || !strings.Contains(result.Content, "550") {
+		t.Fatalf("expected the send error to be reported, got %+v", result)
+	}
+}
+
+func TestComposeToolDraft(t *testing.T) {
+	tool := NewComposeTool(&recordingComposer{})
+	params := json.RawMessage(`{"to":["ada@example.com"],"cc":["bob@example.com"],"subject":"Notes","body":"Draft one."}`)
+
+	preview, err := tool.PreviewDraft(params)
+	if err != nil {
+		t.Fatalf("PreviewDraft() error = %v", err)
+	}
+	if want := "To: ada@example.com\nCc: bob@example.com\nSubject: Notes\n\nDraft one."; preview != want {
+		t.Fatalf("preview = %q, want %q", preview, want)
+	}
+
+	revised, err := tool.ReviseDraft(params, "Draft two.")
+	if err != nil {
+		t.Fatalf("ReviseDraft() error = %v", err)
+	}
+	var input composeInput
+	if err := json.Unmarshal(revised, &input); err != nil {
+		t.Fatalf("unmarshal revised input: %v", err)
+	}
+	if input.Body != "Draft two." || input.Subject != "Notes" || input.To[0] != "ada@example.com" {
+		t.Fatalf("revised input = %+v", input)
+	}
+	if _, err := tool.ReviseDraft(params, " "); err == nil {
+		t.Fatal("expected an empty body to be refused")
+	}
+}
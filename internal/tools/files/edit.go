@@ -3,6 +3,7 @@ package files
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"strings"
@@ -13,11 +14,13 @@ import (
 // EditTool implements in-place text edits on files.
 type EditTool struct {
 	resolver Resolver
+	history  FileHistory
+	review   ChangeReview
 }
 
 // NewEditTool creates an edit tool scoped to the workspace.
 func NewEditTool(cfg Config) *EditTool {
-	return &EditTool{resolver: Resolver{Root: cfg.Workspace}}
+	return &EditTool{resolver: Resolver{Root: cfg.Workspace}, history: cfg.History, review: cfg.Review}
 }
 
 // Name returns the tool name.
@@ -72,15 +75,7 @@ func (t *EditTool) Schema() json.RawMessage {
 
 // Execute applies edits to the file.
 func (t *EditTool) Execute(ctx context.Context, params json.RawMessage) (*agent.ToolResult, error) {
-	_ = ctx
-	var input struct {
-		Path  string `json:"path"`
-		Edits []struct {
-			OldText    string `json:"old_text"`
-			NewText    string `json:"new_text"`
-			ReplaceAll bool   `json:"replace_all"`
-		} `json:"edits"`
-	}
+	var input editInput
 	if err := json.Unmarshal(params, &input); err != nil {
 		return toolError(fmt.Sprintf("Invalid parameters: %v", err)), nil
 	}
@@ -101,28 +96,24 @@ func (t *EditTool) Execute(ctx context.Context, params json.RawMessage) (*agent.
 		return toolError(fmt.Sprintf("read file: %v", err)), nil
 	}
 
-	content := string(data)
-	replacements := 0
-	for _, edit := range input.Edits {
-		if edit.OldText == "" {
-			return toolError("old_text is required"), nil
-		}
-		if !strings.Contains(content, edit.OldText) {
-			return toolError("old_text not found"), nil
-		}
-		if edit.ReplaceAll {
-			count := strings.Count(content, edit.OldText)
-			content = strings.ReplaceAll(content, edit.OldText, edit.NewText)
-			replacements += count
-		} else {
-			content = strings.Replace(content, edit.OldText, edit.NewText, 1)
-			replacements++
-		}
+	content, replacements, err := applyEdits(string(data), input.Edits)
+	if err != nil {
+		return toolError(err.Error()), nil
+	}
+
+	id, err := holdChange(ctx, t.review, resolved, string(data), content)
+	if err != nil {
+		return toolError(fmt.Sprintf("queue for review: %v", err)), nil
+	}
+	if id != "" {
+		return pendingReviewResult(map[string]interface{}{"path": input.Path, "replacements": replacements}, id)
 	}
 
+	recordVersion(ctx, t.history, resolved, "")
 	if err := os.WriteFile(resolved, []byte(content), 0o644); err != nil {
 		return toolError(fmt.Sprintf("write file: %v", err)), nil
 	}
+	recordVersion(ctx, t.history, resolved, t.Name())
 
 	result := map[string]interface{}{
 		"path":         input.Path,
@@ -135,3 +126,37 @@ func (t *EditTool) Execute(ctx context.Context, params json.RawMessage) (*agent.
 
 	return &agent.ToolResult{Content: string(payload)}, nil
 }
+
+type editInput struct {
+	Path  string     `json:"path"`
+	Edits []textEdit `json:"edits"`
+}
+
+type textEdit struct {
+	OldText    string `json:"old_text"`
+	NewText    string `json:"new_text"`
+	ReplaceAll bool   `json:"replace_all"`
+}
+
+// applyEdits applies edits to content in order and returns the result and
+// the number of replacements made.
+func applyEdits(content string, edits []textEdit) (string, int, error) {
+	replacements := 0
+	for _, edit := range edits {
+		if edit.OldText == "" {
+			return "", 0, errors.New("old_text is required")
+		}
+		if !strings.Contains(content, edit.OldText) {
+			return "", 0, errors.New("old_text not found")
+		}
+		if edit.ReplaceAll {
+			count := strings.Count(content, edit.OldText)
+			content = strings.ReplaceAll(content, edit.OldText, edit.NewText)
+			replacements += count
+		} else {
+			content = strings.Replace(content, edit.OldText, edit.NewText, 1)
+			replacements++
+		}
+	}
+	return content, replacements, nil
+}
@@ -100,3 +100,156 @@ func TestApplyPatch(t *testing.T) {
 		t.Fatalf("unexpected content: %s", string(data))
 	}
 }
+
+func TestPreviewChange(t *testing.T) {
+	root := t.TempDir()
+	cfg := Config{Workspace: root}
+	path := filepath.Join(root, "file.txt")
+	if err := os.WriteFile(path, []byte("a\nb\nc\n"), 0o644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+
+	editParams, _ := json.Marshal(map[string]interface{}{
+		"path":  "file.txt",
+		"edits": []map[string]interface{}{{"old_text": "b", "new_text": "bb"}},
+	})
+	diff, err := NewEditTool(cfg).PreviewChange(context.Background(), editParams)
+	if err != nil {
+		t.Fatalf("preview edit: %v", err)
+	}
+	want := "--- a/file.txt\n+++ b/file.txt\n@@ -1,3 +1,3 @@\n a\n-b\n+bb\n c\n"
+	if diff != want {
+		t.Fatalf("unexpected edit diff:\n%s", diff)
+	}
+
+	writeParams, _ := json.Marshal(map[string]interface{}{
+		"path":    "new.txt",
+		"content": "hello\n",
+	})
+	diff, err = NewWriteTool(cfg).PreviewChange(context.Background(), writeParams)
+	if err != nil {
+		t.Fatalf("preview write: %v", err)
+	}
+	if !strings.HasPrefix(diff, "--- /dev/null\n+++ b/new.txt\n") || !strings.Contains(diff, "+hello\n") {
+		t.Fatalf("unexpected write diff:\n%s", diff)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read file: %v", err)
+	}
+	if string(data) != "a\nb\nc\n" {
+		t.Fatalf("preview modified file: %s", string(data))
+	}
+	if _, err := os.Stat(filepath.Join(root, "new.txt")); !os.IsNotExist(err) {
+		t.Fatalf("preview created file: %v", err)
+	}
+}
+
+type recordedVersion struct {
+	path    string
+	source  string
+	content string
+}
+
+type fakeHistory struct {
+	records []recordedVersion
+}
+
+func (h *fakeHistory) Record(ctx context.Context, path, source string) error {
+	data, _ := os.ReadFile(path)
+	h.records = append(h.records, recordedVersion{path: path, source: source, content: string(data)})
+	return nil
+}
+
+func TestToolsRecordHistory(t *testing.T) {
+	root := t.TempDir()
+	history := &fakeHistory{}
+	cfg := Config{Workspace: root, History: history}
+	path := filepath.Join(root, "SOUL.md")
+	if err := os.WriteFile(path, []byte("calm\n"), 0o644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+
+	params, _ := json.Marshal(map[string]interface{}{
+		"path":  "SOUL.md",
+		"edits": []map[string]interface{}{{"old_text": "calm", "new_text": "loud"}},
+	})
+	result, err := NewEditTool(cfg).Execute(context.Background(), params)
+	if err != nil || result.IsError {
+		t.Fatalf("edit failed: %v %+v", err, result)
+	}
+
+	want := []recordedVersion{
+		{path: path, source: "", content: "calm\n"},
+		{path: path, source: "edit", content: "loud\n"},
+	}
+	if len(history.records) != len(want) {
+		t.Fatalf("expected %d records, got %+v", len(want), history.records)
+	}
+	for i := range want {
+		if history.records[i] != want[i] {
+			t.Fatalf("record %d = %+v, want %+v", i, history.records[i], want[i])
+		}
+	}
+}
+
+type fakeReview struct {
+	path          string
+	before, after string
+}
+
+func (r *fakeReview) Hold(ctx context.Context, path, before, after string) (string, error) {
+	if filepath.Base(path) != "MEMORY.md" {
+		return "", nil
+	}
+	r.path, r.before, r.after = path, before, after
+	return "r1", nil
+}
+
+func TestToolsHoldReviewedChanges(t *testing.T) {
+	root := t.TempDir()
+	review := &fakeReview{}
+	cfg := Config{Workspace: root, Review: review}
+	path := filepath.Join(root, "MEMORY.md")
+	if err := os.WriteFile(path, []byte("# Memory\n"), 0o644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+
+	params, _ := json.Marshal(map[string]interface{}{"path": "MEMORY.md", "content": "- likes go\n", "append": true})
+	result, err := NewWriteTool(cfg).Execute(context.Background(), params)
+	if err != nil || result.IsError {
+		t.Fatalf("write failed: %v %+v", err, result)
+	}
+	if !strings.Contains(result.Content, `"review_id": "r1"`) {
+		t.Fatalf("expected pending review result, got %s", result.Content)
+	}
+	if review.before != "# Memory\n" || review.after != "# Memory\n- likes go\n" {
+		t.Fatalf("unexpected held change %q -> %q", review.before, review.after)
+	}
+	if data, _ := os.ReadFile(path); string(data) != "# Memory\n" {
+		t.Fatalf("held change was written: %q", data)
+	}
+
+	// Files outside review are written directly.
+	params, _ = json.Marshal(map[string]interface{}{"path": "notes.md", "content": "hi"})
+	if result, err := NewWriteTool(cfg).Execute(context.Background(), params); err != nil || result.IsError {
+		t.Fatalf("write failed: %v %+v", err, result)
+	}
+	if data, _ := os.ReadFile(filepath.Join(root, "notes.md")); string(data) != "hi" {
+		t.Fatalf("notes.md = %q", data)
+	}
+
+	patch := "--- a/MEMORY.md\n+++ b/MEMORY.md\n@@ -1 +1 @@\n-# Memory\n+# Notes\n"
+	params, _ = json.Marshal(map[string]interface{}{"patch": patch})
+	result, err = NewApplyPatchTool(cfg).Execute(context.Background(), params)
+	if err != nil || result.IsError {
+		t.Fatalf("apply_patch failed: %v %+v", err, result)
+	}
+	if !strings.Contains(result.Content, "pending_review") || review.after != "# Notes\n" {
+		t.Fatalf("expected held patch, got %s (after %q)", result.Content, review.after)
+	}
+	if data, _ := os.ReadFile(path); string(data) != "# Memory\n" {
+		t.Fatalf("held patch was written: %q", data)
+	}
+}
@@ -0,0 +1,20 @@
+package files
+
+import "context"
+
+// FileHistory records versions of the files the write, edit, and
+// apply_patch tools change. The tools call Record with an empty source
+// before a change, to keep edits made outside the tools, and with their own
+// name after it. Record ignores files it does not version.
+type FileHistory interface {
+	Record(ctx context.Context, path, source string) error
+}
+
+// recordVersion saves path to history. Versioning is best effort and never
+// fails the tool call.
+func recordVersion(ctx context.Context, history FileHistory, path, source string) {
+	if history == nil {
+		return
+	}
+	_ = history.Record(ctx, path, source)
+}
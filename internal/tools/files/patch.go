@@ -14,11 +14,13 @@ import (
 // ApplyPatchTool applies unified diffs to workspace files.
 type ApplyPatchTool struct {
 	resolver Resolver
+	history  FileHistory
+	review   ChangeReview
 }
 
 // NewApplyPatchTool creates an apply_patch tool scoped to the workspace.
 func NewApplyPatchTool(cfg Config) *ApplyPatchTool {
-	return &ApplyPatchTool{resolver: Resolver{Root: cfg.Workspace}}
+	return &ApplyPatchTool{resolver: Resolver{Root: cfg.Workspace}, history: cfg.History, review: cfg.Review}
 }
 
 // Name returns the tool name.
@@ -52,7 +54,6 @@ func (t *ApplyPatchTool) Schema() json.RawMessage {
 
 // Execute applies a unified diff patch.
 func (t *ApplyPatchTool) Execute(ctx context.Context, params json.RawMessage) (*agent.ToolResult, error) {
-	_ = ctx
 	var input struct {
 		Patch string `json:"patch"`
 	}
@@ -69,6 +70,7 @@ func (t *ApplyPatchTool) Execute(ctx context.Context, params json.RawMessage) (*
 	}
 
 	results := make([]map[string]interface{}, 0, len(patches))
+	held := false
 	for _, patch := range patches {
 		resolved, err := t.resolver.Resolve(patch.Path)
 		if err != nil {
@@ -82,20 +84,38 @@ func (t *ApplyPatchTool) Execute(ctx context.Context, params json.RawMessage) (*
 		if err != nil {
 			return toolError(fmt.Sprintf("apply patch: %v", err)), nil
 		}
-		if err := os.WriteFile(resolved, []byte(updated.Content), 0o644); err != nil {
-			return toolError(fmt.Sprintf("write file: %v", err)), nil
-		}
-		results = append(results, map[string]interface{}{
+		result := map[string]interface{}{
 			"path":          patch.Path,
 			"hunks":         len(patch.Hunks),
 			"lines_added":   updated.Added,
 			"lines_removed": updated.Removed,
-		})
+		}
+		id, err := holdChange(ctx, t.review, resolved, string(data), updated.Content)
+		if err != nil {
+			return toolError(fmt.Sprintf("queue for review: %v", err)), nil
+		}
+		if id != "" {
+			result["status"] = "pending_review"
+			result["review_id"] = id
+			results = append(results, result)
+			held = true
+			continue
+		}
+		recordVersion(ctx, t.history, resolved, "")
+		if err := os.WriteFile(resolved, []byte(updated.Content), 0o644); err != nil {
+			return toolError(fmt.Sprintf("write file: %v", err)), nil
+		}
+		recordVersion(ctx, t.history, resolved, t.Name())
+		results = append(results, result)
 	}
 
-	payload, err := json.MarshalIndent(map[string]interface{}{
+	output := map[string]interface{}{
 		"applied": results,
-	}, "", "  ")
+	}
+	if held {
+		output["note"] = pendingReviewNote
+	}
+	payload, err := json.MarshalIndent(output, "", "  ")
 	if err != nil {
 		return toolError(fmt.Sprintf("encode result: %v", err)), nil
 	}
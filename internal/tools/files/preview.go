@@ -0,0 +1,121 @@
+package files
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/haasonsaas/nexus/internal/textdiff"
+)
+
+// PreviewChange returns a unified diff of the write described by params.
+func (t *WriteTool) PreviewChange(ctx context.Context, params json.RawMessage) (string, error) {
+	_ = ctx
+	var input struct {
+		Path    string `json:"path"`
+		Content string `json:"content"`
+		Append  bool   `json:"append"`
+	}
+	if err := json.Unmarshal(params, &input); err != nil {
+		return "", fmt.Errorf("invalid parameters: %w", err)
+	}
+	resolved, err := t.resolver.Resolve(input.Path)
+	if err != nil {
+		return "", err
+	}
+	before, exists, err := readExisting(resolved)
+	if err != nil {
+		return "", err
+	}
+	after := input.Content
+	if input.Append {
+		after = before + input.Content
+	}
+	return fileDiff(input.Path, before, after, exists), nil
+}
+
+// PreviewChange returns a unified diff of the edits described by params.
+func (t *EditTool) PreviewChange(ctx context.Context, params json.RawMessage) (string, error) {
+	_ = ctx
+	var input editInput
+	if err := json.Unmarshal(params, &input); err != nil {
+		return "", fmt.Errorf("invalid parameters: %w", err)
+	}
+	resolved, err := t.resolver.Resolve(input.Path)
+	if err != nil {
+		return "", err
+	}
+	data, err := os.ReadFile(resolved)
+	if err != nil {
+		return "", fmt.Errorf("read file: %w", err)
+	}
+	after, _, err := applyEdits(string(data), input.Edits)
+	if err != nil {
+		return "", err
+	}
+	return fileDiff(input.Path, string(data), after, true), nil
+}
+
+// PreviewChange returns a unified diff of the files the patch in params
+// would change, computed from the current file contents.
+func (t *ApplyPatchTool) PreviewChange(ctx context.Context, params json.RawMessage) (string, error) {
+	_ = ctx
+	var input struct {
+		Patch string `json:"patch"`
+	}
+	if err := json.Unmarshal(params, &input); err != nil {
+		return "", fmt.Errorf("invalid parameters: %w", err)
+	}
+	patches, err := parseUnifiedDiff(input.Patch)
+	if err != nil {
+		return "", err
+	}
+	var out strings.Builder
+	for _, patch := range patches {
+		resolved, err := t.resolver.Resolve(patch.Path)
+		if err != nil {
+			return "", err
+		}
+		data, err := os.ReadFile(resolved)
+		if err != nil {
+			return "", fmt.Errorf("read file: %w", err)
+		}
+		updated, err := applyFilePatch(string(data), patch)
+		if err != nil {
+			return "", fmt.Errorf("apply patch: %w", err)
+		}
+		out.WriteString(fileDiff(patch.Path, string(data), updated.Content, true))
+	}
+	return out.String(), nil
+}
+
+// readExisting returns the contents of path and whether it exists.
+func readExisting(path string) (string, bool, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return "", false, nil
+	}
+	if err != nil {
+		return "", false, fmt.Errorf("read file: %w", err)
+	}
+	return string(data), true, nil
+}
+
+func fileDiff(path, before, after string, exists bool) string {
+	from := "a/" + path
+	if !exists {
+		from = "/dev/null"
+	}
+	if before == after && exists {
+		return ""
+	}
+	diff := textdiff.Unified(from, "b/"+path, before, after, textdiff.DefaultContext)
+	if diff == "" {
+		// Creating an empty file.
+		diff = fmt.Sprintf("--- %s\n+++ b/%s\n", from, path)
+	}
+	return diff
+}
@@ -15,6 +15,10 @@ import (
 type Config struct {
 	Workspace    string
 	MaxReadBytes int
+	// History, if set, versions the files the tools change.
+	History FileHistory
+	// Review, if set, holds changes to reviewed files for approval.
+	Review ChangeReview
 }
 
 // ReadTool implements a safe file reader.
@@ -45,6 +49,9 @@ func (t *ReadTool) Description() string {
 	return "Read a file from the workspace with optional offset and byte limit."
 }
 
+// Idempotent reports that reads may be retried.
+func (t *ReadTool) Idempotent() bool { return true }
+
 // Schema returns the JSON schema for the tool parameters.
 func (t *ReadTool) Schema() json.RawMessage {
 	schema := map[string]interface{}{
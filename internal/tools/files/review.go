@@ -0,0 +1,40 @@
+package files
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/haasonsaas/nexus/internal/agent"
+)
+
+// ChangeReview holds file changes that must be approved before they are
+// written, such as changes to the workspace memory file.
+type ChangeReview interface {
+	// Hold queues the change of path from before to after and returns its
+	// ID, or "" when the change may be written directly.
+	Hold(ctx context.Context, path, before, after string) (string, error)
+}
+
+// pendingReviewNote tells the model what happens to a held change.
+const pendingReviewNote = "The change is waiting for the user's review and will be written once approved. Do not retry it."
+
+// holdChange asks review whether the change of path must wait for approval.
+func holdChange(ctx context.Context, review ChangeReview, path, before, after string) (string, error) {
+	if review == nil {
+		return "", nil
+	}
+	return review.Hold(ctx, path, before, after)
+}
+
+// pendingReviewResult reports a held change in result.
+func pendingReviewResult(result map[string]interface{}, id string) (*agent.ToolResult, error) {
+	result["status"] = "pending_review"
+	result["review_id"] = id
+	result["note"] = pendingReviewNote
+	payload, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return toolError(fmt.Sprintf("encode result: %v", err)), nil
+	}
+	return &agent.ToolResult{Content: string(payload)}, nil
+}
@@ -14,11 +14,13 @@ import (
 // WriteTool implements file writes within the workspace.
 type WriteTool struct {
 	resolver Resolver
+	history  FileHistory
+	review   ChangeReview
 }
 
 // NewWriteTool creates a write tool scoped to the workspace.
 func NewWriteTool(cfg Config) *WriteTool {
-	return &WriteTool{resolver: Resolver{Root: cfg.Workspace}}
+	return &WriteTool{resolver: Resolver{Root: cfg.Workspace}, history: cfg.History, review: cfg.Review}
 }
 
 // Name returns the tool name.
@@ -60,7 +62,6 @@ func (t *WriteTool) Schema() json.RawMessage {
 
 // Execute writes file contents.
 func (t *WriteTool) Execute(ctx context.Context, params json.RawMessage) (*agent.ToolResult, error) {
-	_ = ctx
 	var input struct {
 		Path    string `json:"path"`
 		Content string `json:"content"`
@@ -78,10 +79,29 @@ func (t *WriteTool) Execute(ctx context.Context, params json.RawMessage) (*agent
 		return toolError(err.Error()), nil
 	}
 
+	if t.review != nil {
+		before, _, err := readExisting(resolved)
+		if err != nil {
+			return toolError(err.Error()), nil
+		}
+		after := input.Content
+		if input.Append {
+			after = before + input.Content
+		}
+		id, err := holdChange(ctx, t.review, resolved, before, after)
+		if err != nil {
+			return toolError(fmt.Sprintf("queue for review: %v", err)), nil
+		}
+		if id != "" {
+			return pendingReviewResult(map[string]interface{}{"path": input.Path, "append": input.Append}, id)
+		}
+	}
+
 	if err := os.MkdirAll(filepath.Dir(resolved), 0o755); err != nil {
 		return toolError(fmt.Sprintf("create directory: %v", err)), nil
 	}
 
+	recordVersion(ctx, t.history, resolved, "")
 	flags := os.O_CREATE | os.O_WRONLY
 	if input.Append {
 		flags |= os.O_APPEND
@@ -92,12 +112,14 @@ func (t *WriteTool) Execute(ctx context.Context, params json.RawMessage) (*agent
 	if err != nil {
 		return toolError(fmt.Sprintf("open file: %v", err)), nil
 	}
-	defer file.Close()
-
 	n, err := file.WriteString(input.Content)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
 	if err != nil {
 		return toolError(fmt.Sprintf("write file: %v", err)), nil
 	}
+	recordVersion(ctx, t.history, resolved, t.Name())
 
 	result := map[string]interface{}{
 		"path":          input.Path,
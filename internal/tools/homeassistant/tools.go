@@ -76,6 +76,8 @@ func (t *GetStateTool) Description() string {
 	return "Get the current state + attributes for a Home Assistant entity_id."
 }
 
+func (t *GetStateTool) Idempotent() bool { return true }
+
 func (t *GetStateTool) Schema() json.RawMessage {
 	return json.RawMessage(`{
   "type": "object",
@@ -120,6 +122,8 @@ func (t *ListEntitiesTool) Description() string {
 	return "List Home Assistant entities. Optional domain filter (e.g., \"light\")."
 }
 
+func (t *ListEntitiesTool) Idempotent() bool { return true }
+
 func (t *ListEntitiesTool) Schema() json.RawMessage {
 	return json.RawMessage(`{
   "type": "object",
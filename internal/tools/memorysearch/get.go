@@ -31,6 +31,9 @@ func (t *MemoryGetTool) Description() string {
 	return "Read a snippet from MEMORY.md or memory/*.md by line range."
 }
 
+// Idempotent reports that reading memory files has no side effects.
+func (t *MemoryGetTool) Idempotent() bool { return true }
+
 // Schema returns the JSON schema for tool parameters.
 func (t *MemoryGetTool) Schema() json.RawMessage {
 	schema := map[string]interface{}{
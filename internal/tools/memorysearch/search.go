@@ -72,6 +72,9 @@ func (t *MemorySearchTool) Description() string {
 	return "Searches local memory files (MEMORY.md and memory logs) for a query."
 }
 
+// Idempotent reports that searching memory has no side effects.
+func (t *MemorySearchTool) Idempotent() bool { return true }
+
 // Schema defines the parameters for the tool.
 func (t *MemorySearchTool) Schema() json.RawMessage {
 	return json.RawMessage(`{
@@ -9,6 +9,7 @@ import (
 
 	"github.com/haasonsaas/nexus/internal/agent"
 	"github.com/haasonsaas/nexus/internal/edge"
+	"github.com/haasonsaas/nexus/internal/nodes"
 	"github.com/haasonsaas/nexus/internal/observability"
 	pb "github.com/haasonsaas/nexus/pkg/proto"
 	"google.golang.org/protobuf/encoding/protojson"
@@ -29,7 +30,7 @@ func NewTool(manager *edge.Manager, tofuAuth *edge.TOFUAuthenticator) *Tool {
 func (t *Tool) Name() string { return "nodes" }
 
 func (t *Tool) Description() string {
-	return "Inspect and control connected edge nodes (status/describe/pending/approve/reject/route/invoke/invoke_any)."
+	return "Inspect and control connected edge nodes (status/describe/list_nodes/pending/approve/reject/route/invoke/invoke_any). Target a device with target=node:<id> or capability:<name>."
 }
 
 func (t *Tool) Schema() json.RawMessage {
@@ -38,7 +39,15 @@ func (t *Tool) Schema() json.RawMessage {
 		"properties": map[string]interface{}{
 			"action": map[string]interface{}{
 				"type":        "string",
-				"description": "Action: status, describe, pending, approve, reject, route, invoke, invoke_any.",
+				"description": "Action: status, describe, list_nodes, pending, approve, reject, route, invoke, invoke_any.",
+			},
+			"target": map[string]interface{}{
+				"type":        "string",
+				"description": "Node to run on (invoke action): node:<id> (e.g. node:kitchen-pi) or capability:<name> (e.g. capability:camera).",
+			},
+			"capability": map[string]interface{}{
+				"type":        "string",
+				"description": "Node capability filter (list_nodes) or requirement (invoke_any), e.g. camera, screen, shell.",
 			},
 			"edge_id": map[string]interface{}{
 				"type":        "string",
@@ -103,6 +112,8 @@ func (t *Tool) Execute(ctx context.Context, params json.RawMessage) (*agent.Tool
 	var input struct {
 		Action       string          `json:"action"`
 		EdgeID       string          `json:"edge_id"`
+		Target       string          `json:"target"`
+		Capability   string          `json:"capability"`
 		Tool         string          `json:"tool"`
 		Params       json.RawMessage `json:"params"`
 		TimeoutMS    int             `json:"timeout_ms"`
@@ -156,6 +167,18 @@ func (t *Tool) Execute(ctx context.Context, params json.RawMessage) (*agent.Tool
 		return jsonResult(map[string]interface{}{
 			"edge": payload,
 		}), nil
+	case "list_nodes", "nodes":
+		capability := nodes.Capability(strings.ToLower(strings.TrimSpace(input.Capability)))
+		items := make([]*edge.Node, 0)
+		for _, node := range t.manager.ListNodes() {
+			if capability != "" && !node.HasCapability(capability) {
+				continue
+			}
+			items = append(items, node)
+		}
+		return jsonResult(map[string]interface{}{
+			"nodes": items,
+		}), nil
 	case "pending":
 		if t.tofuAuth == nil {
 			return toolError("pending requests unsupported (edge auth mode is not tofu)"), nil
@@ -207,9 +230,16 @@ func (t *Tool) Execute(ctx context.Context, params json.RawMessage) (*agent.Tool
 			"edge_id": edgeID,
 		}), nil
 	case "invoke":
+		if strings.TrimSpace(input.Target) != "" {
+			target, err := edge.ParseNodeTarget(input.Target)
+			if err != nil {
+				return toolError(fmt.Sprintf("invoke: %v", err)), nil
+			}
+			return t.invokeNode(ctx, target, input.Tool, input.Params, input.TimeoutMS, input.Approved), nil
+		}
 		edgeID := strings.TrimSpace(input.EdgeID)
 		if edgeID == "" {
-			return toolError("edge_id is required"), nil
+			return toolError("edge_id or target is required"), nil
 		}
 		toolName := strings.TrimSpace(input.Tool)
 		if toolName == "" {
@@ -276,6 +306,10 @@ func (t *Tool) Execute(ctx context.Context, params json.RawMessage) (*agent.Tool
 		}
 		return jsonResult(payload), nil
 	case "invoke_any":
+		if capability := strings.TrimSpace(input.Capability); capability != "" {
+			target := edge.NodeTarget{Capability: nodes.Capability(strings.ToLower(capability))}
+			return t.invokeNode(ctx, target, input.Tool, input.Params, input.TimeoutMS, input.Approved), nil
+		}
 		toolName := strings.TrimSpace(input.Tool)
 		if toolName == "" {
 			return toolError("tool is required"), nil
@@ -341,6 +375,63 @@ func (t *Tool) Execute(ctx context.Context, params json.RawMessage) (*agent.Tool
 	}
 }
 
+// invokeNode runs a tool on the node selected by target and reports which
+// node ran it.
+func (t *Tool) invokeNode(ctx context.Context, target edge.NodeTarget, toolName string, params json.RawMessage, timeoutMS int, approved bool) *agent.ToolResult {
+	toolName = strings.TrimSpace(toolName)
+	if toolName == "" {
+		return toolError("tool is required")
+	}
+	sessionID := ""
+	if session := agent.SessionFromContext(ctx); session != nil {
+		sessionID = session.ID
+	}
+	if len(params) == 0 {
+		params = []byte("{}")
+	}
+	opts := edge.ExecuteOptions{
+		RunID:     observability.GetRunID(ctx),
+		SessionID: sessionID,
+		Approved:  approved,
+	}
+	if timeoutMS > 0 {
+		opts.Timeout = time.Duration(timeoutMS) * time.Millisecond
+	}
+	if toolCallID := observability.GetToolCallID(ctx); toolCallID != "" {
+		opts.Metadata = map[string]string{"tool_call_id": toolCallID}
+	}
+
+	node, result, err := t.manager.ExecuteNodeTool(ctx, target, toolName, string(params), opts)
+	if err != nil {
+		return toolError(fmt.Sprintf("invoke %s: %v", target, err))
+	}
+
+	artifacts := make([]agent.Artifact, 0, len(result.Artifacts))
+	for _, art := range result.Artifacts {
+		artifacts = append(artifacts, agent.Artifact{
+			ID:       art.Id,
+			Type:     art.Type,
+			MimeType: art.MimeType,
+			Filename: art.Filename,
+			Data:     art.Data,
+		})
+	}
+	content, err := json.MarshalIndent(map[string]interface{}{
+		"node_id":  node.ID,
+		"edge_id":  node.EdgeID,
+		"content":  result.Content,
+		"is_error": result.IsError,
+	}, "", "  ")
+	if err != nil {
+		return toolError(fmt.Sprintf("encode invoke result: %v", err))
+	}
+	return &agent.ToolResult{
+		Content:   string(content),
+		IsError:   result.IsError,
+		Artifacts: artifacts,
+	}
+}
+
 func buildSelectionCriteria(toolName, channelType, strategy string, caps *struct {
 	Tools     bool `json:"tools"`
 	Channels  bool `json:"channels"`
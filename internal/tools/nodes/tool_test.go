@@ -306,3 +306,44 @@ func TestNodesToolActionCaseInsensitive(t *testing.T) {
 		}
 	}
 }
+
+func TestNodesToolListNodesEmpty(t *testing.T) {
+	manager := edge.NewManager(edge.DefaultManagerConfig(), edge.NewDevAuthenticator(), nil)
+	tool := NewTool(manager, nil)
+
+	params, _ := json.Marshal(map[string]interface{}{
+		"action":     "list_nodes",
+		"capability": "camera",
+	})
+	result, err := tool.Execute(context.Background(), params)
+	if err != nil {
+		t.Fatalf("execute: %v", err)
+	}
+	if result.IsError {
+		t.Fatalf("unexpected error: %s", result.Content)
+	}
+	if !strings.Contains(result.Content, "\"nodes\"") {
+		t.Fatalf("expected nodes field, got %s", result.Content)
+	}
+}
+
+func TestNodesToolInvokeUnknownTarget(t *testing.T) {
+	manager := edge.NewManager(edge.DefaultManagerConfig(), edge.NewDevAuthenticator(), nil)
+	tool := NewTool(manager, nil)
+
+	params, _ := json.Marshal(map[string]interface{}{
+		"action": "invoke",
+		"target": "node:kitchen-pi",
+		"tool":   "camera_snap",
+	})
+	result, err := tool.Execute(context.Background(), params)
+	if err != nil {
+		t.Fatalf("execute: %v", err)
+	}
+	if !result.IsError {
+		t.Fatalf("expected error, got %s", result.Content)
+	}
+	if !strings.Contains(result.Content, "kitchen-pi") {
+		t.Errorf("expected node id in error: %s", result.Content)
+	}
+}
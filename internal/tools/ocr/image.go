@@ -0,0 +1,125 @@
+// Package ocr provides the ocr_image tool for reading text from stored
+// artifacts.
+package ocr
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/haasonsaas/nexus/internal/agent"
+	"github.com/haasonsaas/nexus/internal/artifacts"
+	"github.com/haasonsaas/nexus/internal/media"
+	pb "github.com/haasonsaas/nexus/pkg/proto"
+)
+
+// maxImageBytes caps the artifact size read for OCR.
+const maxImageBytes = 20 * 1024 * 1024
+
+// ArtifactGetter reads stored artifacts.
+type ArtifactGetter interface {
+	GetArtifact(ctx context.Context, artifactID string) (*pb.Artifact, io.ReadCloser, error)
+}
+
+// ImageTool extracts text from an image artifact.
+type ImageTool struct {
+	artifacts ArtifactGetter
+	extractor media.TextExtractor
+}
+
+// NewImageTool creates the ocr_image tool.
+func NewImageTool(repo ArtifactGetter, extractor media.TextExtractor) *ImageTool {
+	return &ImageTool{artifacts: repo, extractor: extractor}
+}
+
+// Name returns the tool name.
+func (t *ImageTool) Name() string {
+	return "ocr_image"
+}
+
+// Description describes the tool.
+func (t *ImageTool) Description() string {
+	return "Reads the text in a stored image artifact, such as a screenshot, using OCR."
+}
+
+// Schema defines the tool parameters.
+func (t *ImageTool) Schema() json.RawMessage {
+	return json.RawMessage(`{
+  "type": "object",
+  "properties": {
+    "artifact_id": {"type": "string", "description": "ID of the image artifact to read"}
+  },
+  "required": ["artifact_id"]
+}`)
+}
+
+// Execute returns the artifact's text. Text extracted when the artifact was
+// stored is reused; otherwise OCR runs on the image now.
+func (t *ImageTool) Execute(ctx context.Context, params json.RawMessage) (*agent.ToolResult, error) {
+	var input struct {
+		ArtifactID string `json:"artifact_id"`
+	}
+	if err := json.Unmarshal(params, &input); err != nil {
+		return &agent.ToolResult{Content: fmt.Sprintf("invalid params: %v", err), IsError: true}, nil
+	}
+	id := strings.TrimSpace(input.ArtifactID)
+	if id == "" {
+		return &agent.ToolResult{Content: "artifact_id is required", IsError: true}, nil
+	}
+	if t.artifacts == nil || t.extractor == nil {
+		return &agent.ToolResult{Content: "ocr is not available", IsError: true}, nil
+	}
+
+	text, cached, err := t.extract(ctx, id)
+	if err != nil {
+		return &agent.ToolResult{Content: err.Error(), IsError: true}, nil
+	}
+
+	payload, err := json.MarshalIndent(struct {
+		ArtifactID string `json:"artifact_id"`
+		Text       string `json:"text"`
+		Cached     bool   `json:"cached,omitempty"`
+	}{
+		ArtifactID: id,
+		Text:       text,
+		Cached:     cached,
+	}, "", "  ")
+	if err != nil {
+		return &agent.ToolResult{Content: fmt.Sprintf("failed to encode result: %v", err), IsError: true}, nil
+	}
+	return &agent.ToolResult{Content: string(payload)}, nil
+}
+
+func (t *ImageTool) extract(ctx context.Context, id string) (string, bool, error) {
+	if _, reader, err := t.artifacts.GetArtifact(ctx, artifacts.OCRTextID(id)); err == nil {
+		data, readErr := io.ReadAll(io.LimitReader(reader, maxImageBytes))
+		reader.Close()
+		if readErr == nil {
+			return strings.TrimSpace(string(data)), true, nil
+		}
+	}
+
+	artifact, reader, err := t.artifacts.GetArtifact(ctx, id)
+	if err != nil {
+		return "", false, fmt.Errorf("artifact %s: %w", id, err)
+	}
+	defer reader.Close()
+	if !strings.HasPrefix(strings.ToLower(artifact.MimeType), "image/") {
+		return "", false, fmt.Errorf("artifact %s is %s, not an image", id, artifact.MimeType)
+	}
+	data, err := io.ReadAll(io.LimitReader(reader, maxImageBytes+1))
+	if err != nil {
+		return "", false, fmt.Errorf("read artifact %s: %w", id, err)
+	}
+	if len(data) > maxImageBytes {
+		return "", false, fmt.Errorf("artifact %s is too large for OCR", id)
+	}
+	text, err := t.extractor.ExtractText(ctx, bytes.NewReader(data), artifact.MimeType)
+	if err != nil {
+		return "", false, fmt.Errorf("ocr failed: %w", err)
+	}
+	return strings.TrimSpace(text), false, nil
+}
@@ -0,0 +1,92 @@
+package ocr
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/haasonsaas/nexus/internal/artifacts"
+	pb "github.com/haasonsaas/nexus/pkg/proto"
+)
+
+type countingExtractor struct {
+	text  string
+	calls int
+}
+
+func (e *countingExtractor) ExtractText(context.Context, io.Reader, string) (string, error) {
+	e.calls++
+	return e.text, nil
+}
+
+func newRepo(t *testing.T) artifacts.Repository {
+	t.Helper()
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	store, err := artifacts.NewLocalStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewLocalStore: %v", err)
+	}
+	return artifacts.NewMemoryRepository(store, logger)
+}
+
+func store(t *testing.T, repo artifacts.Repository, artifact *pb.Artifact, data string) {
+	t.Helper()
+	if err := repo.StoreArtifact(context.Background(), artifact, bytes.NewReader([]byte(data))); err != nil {
+		t.Fatalf("StoreArtifact: %v", err)
+	}
+}
+
+func run(t *testing.T, tool *ImageTool, id string) (string, bool) {
+	t.Helper()
+	params, _ := json.Marshal(map[string]string{"artifact_id": id})
+	result, err := tool.Execute(context.Background(), params)
+	if err != nil {
+		t.Fatalf("Execute: %v", err)
+	}
+	return result.Content, result.IsError
+}
+
+func TestImageTool_Execute(t *testing.T) {
+	repo := newRepo(t)
+	store(t, repo, &pb.Artifact{Id: "shot", Type: "screenshot", MimeType: "image/png"}, "png")
+	store(t, repo, &pb.Artifact{Id: "log", Type: "file", MimeType: "text/plain"}, "hello")
+	extractor := &countingExtractor{text: " Sign in \n"}
+	tool := NewImageTool(repo, extractor)
+
+	content, isErr := run(t, tool, "shot")
+	if isErr || !strings.Contains(content, `"text": "Sign in"`) {
+		t.Fatalf("unexpected result %q", content)
+	}
+	if extractor.calls != 1 {
+		t.Fatalf("calls = %d, want 1", extractor.calls)
+	}
+
+	if content, isErr := run(t, tool, "log"); !isErr || !strings.Contains(content, "not an image") {
+		t.Fatalf("expected non-image error, got %q", content)
+	}
+	if _, isErr := run(t, tool, "missing"); !isErr {
+		t.Fatal("expected error for missing artifact")
+	}
+	if _, isErr := run(t, tool, " "); !isErr {
+		t.Fatal("expected error for empty artifact_id")
+	}
+}
+
+func TestImageTool_UsesStoredText(t *testing.T) {
+	repo := newRepo(t)
+	store(t, repo, &pb.Artifact{Id: "shot", Type: "screenshot", MimeType: "image/png"}, "png")
+	store(t, repo, &pb.Artifact{Id: artifacts.OCRTextID("shot"), Type: artifacts.OCRTextType, MimeType: "text/plain"}, "Stored text")
+	extractor := &countingExtractor{text: "fresh"}
+
+	content, isErr := run(t, NewImageTool(repo, extractor), "shot")
+	if isErr || !strings.Contains(content, "Stored text") || !strings.Contains(content, `"cached": true`) {
+		t.Fatalf("unexpected result %q", content)
+	}
+	if extractor.calls != 0 {
+		t.Fatalf("expected stored text to be reused, got %d OCR calls", extractor.calls)
+	}
+}
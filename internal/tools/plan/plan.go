@@ -0,0 +1,108 @@
+// Package plan provides the plan tool, which lets an agent keep a structured
+// to-do list for a long run and report progress on each step.
+package plan
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/haasonsaas/nexus/internal/agent"
+	"github.com/haasonsaas/nexus/pkg/models"
+)
+
+// Tool creates and updates the plan of the current run.
+type Tool struct{}
+
+// NewTool creates a plan tool.
+func NewTool() *Tool {
+	return &Tool{}
+}
+
+// Name returns the tool name.
+func (t *Tool) Name() string {
+	return "plan"
+}
+
+// Description describes the tool.
+func (t *Tool) Description() string {
+	return "Keeps a step-by-step plan for multi-step tasks and shows the user your progress. " +
+		"Call with action \"create\" and the steps before starting, then action \"update\" with a step " +
+		"number and status (in_progress, completed, skipped) as you work through it."
+}
+
+// Schema defines the tool parameters.
+func (t *Tool) Schema() json.RawMessage {
+	return json.RawMessage(`{
+  "type": "object",
+  "properties": {
+    "action": {"type": "string", "enum": ["create", "update", "get"], "description": "create replaces the plan, update changes one step, get returns the plan"},
+    "title": {"type": "string", "description": "Short title of the plan (create)"},
+    "steps": {"type": "array", "items": {"type": "string"}, "description": "Step descriptions in order (create)"},
+    "step": {"type": "integer", "minimum": 1, "description": "1-based step number (update)"},
+    "status": {"type": "string", "enum": ["pending", "in_progress", "completed", "skipped"], "description": "New step status (update)"},
+    "note": {"type": "string", "description": "Short remark on the step's outcome (update)"}
+  },
+  "required": ["action"]
+}`)
+}
+
+// Execute creates, updates, or returns the run's plan.
+func (t *Tool) Execute(ctx context.Context, params json.RawMessage) (*agent.ToolResult, error) {
+	var input struct {
+		Action string   `json:"action"`
+		Title  string   `json:"title"`
+		Steps  []string `json:"steps"`
+		Step   int      `json:"step"`
+		Status string   `json:"status"`
+		Note   string   `json:"note"`
+	}
+	if err := json.Unmarshal(params, &input); err != nil {
+		return &agent.ToolResult{Content: fmt.Sprintf("invalid params: %v", err), IsError: true}, nil
+	}
+	runPlan := agent.RunPlanFromContext(ctx)
+	if runPlan == nil {
+		return &agent.ToolResult{Content: "plans are only available during an agent run", IsError: true}, nil
+	}
+
+	var (
+		plan *models.Plan
+		err  error
+	)
+	switch strings.ToLower(strings.TrimSpace(input.Action)) {
+	case "create":
+		plan, err = runPlan.Create(ctx, input.Title, input.Steps)
+	case "update":
+		plan, err = runPlan.UpdateStep(ctx, input.Step, models.PlanStepStatus(strings.TrimSpace(input.Status)), input.Note)
+	case "get":
+		if plan = runPlan.Current(); plan == nil {
+			err = agent.ErrNoPlan
+		}
+	default:
+		err = errors.New("action must be create, update, or get")
+	}
+	if err != nil {
+		return &agent.ToolResult{Content: err.Error(), IsError: true}, nil
+	}
+	return &agent.ToolResult{Content: Format(plan)}, nil
+}
+
+// Format renders a plan as numbered plain text.
+func Format(plan *models.Plan) string {
+	var b strings.Builder
+	if plan.Title != "" {
+		b.WriteString(plan.Title)
+		b.WriteString("\n")
+	}
+	for i, step := range plan.Steps {
+		fmt.Fprintf(&b, "%d. [%s] %s", i+1, step.Status, step.Title)
+		if step.Note != "" {
+			fmt.Fprintf(&b, " (%s)", step.Note)
+		}
+		b.WriteString("\n")
+	}
+	fmt.Fprintf(&b, "%d/%d steps done", plan.Done(), len(plan.Steps))
+	return b.String()
+}
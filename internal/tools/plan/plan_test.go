@@ -0,0 +1,53 @@
+package plan
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/haasonsaas/nexus/internal/agent"
+)
+
+func TestToolExecute(t *testing.T) {
+	tool := NewTool()
+	ctx := agent.WithRunPlan(context.Background(), agent.NewRunPlan(nil, nil))
+	run := func(params string) *agent.ToolResult {
+		t.Helper()
+		result, err := tool.Execute(ctx, json.RawMessage(params))
+		if err != nil {
+			t.Fatalf("Execute(%s) error = %v", params, err)
+		}
+		return result
+	}
+
+	if result := run(`{"action":"update","step":1,"status":"completed"}`); !result.IsError {
+		t.Fatalf("expected an error before a plan exists, got %q", result.Content)
+	}
+	run(`{"action":"create","title":"Migrate","steps":["dump","restore","verify"]}`)
+	run(`{"action":"update","step":1,"status":"completed"}`)
+	result := run(`{"action":"update","step":2,"status":"in_progress","note":"50%"}`)
+	if result.IsError {
+		t.Fatalf("update failed: %s", result.Content)
+	}
+	want := "Migrate\n1. [completed] dump\n2. [in_progress] restore (50%)\n3. [pending] verify\n1/3 steps done"
+	if result.Content != want {
+		t.Fatalf("Execute() = %q, want %q", result.Content, want)
+	}
+	if result := run(`{"action":"get"}`); result.Content != want {
+		t.Fatalf("get = %q", result.Content)
+	}
+	if result := run(`{"action":"delete"}`); !result.IsError {
+		t.Fatal("expected an error for an unknown action")
+	}
+}
+
+func TestToolExecuteOutsideRun(t *testing.T) {
+	result, err := NewTool().Execute(context.Background(), json.RawMessage(`{"action":"get"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !result.IsError || !strings.Contains(result.Content, "agent run") {
+		t.Fatalf("unexpected result %+v", result)
+	}
+}
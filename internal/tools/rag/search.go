@@ -93,6 +93,9 @@ func (t *SearchTool) Description() string {
 	return "Searches indexed documents for relevant information using semantic similarity. Use this to find information from uploaded documents, knowledge bases, or reference materials."
 }
 
+// Idempotent reports that document searches may be retried.
+func (t *SearchTool) Idempotent() bool { return true }
+
 // Schema returns the JSON schema for tool parameters.
 func (t *SearchTool) Schema() json.RawMessage {
 	return json.RawMessage(`{
@@ -22,7 +22,7 @@ func NewCancelTool(store tasks.Store) *CancelTool {
 func (t *CancelTool) Name() string { return "reminder_cancel" }
 
 func (t *CancelTool) Description() string {
-	return "Cancel a reminder by its ID"
+	return "Cancel a reminder or scheduled message by its ID"
 }
 
 func (t *CancelTool) Schema() json.RawMessage {
@@ -68,11 +68,8 @@ func (t *CancelTool) Execute(ctx context.Context, params json.RawMessage) (*agen
 		return &agent.ToolResult{Content: "reminder not found", IsError: true}, nil
 	}
 
-	// Verify it's a reminder
-	if task.Metadata == nil {
-		return &agent.ToolResult{Content: "not a reminder", IsError: true}, nil
-	}
-	if taskType, ok := task.Metadata["type"].(string); !ok || taskType != "reminder" {
+	// Verify it's a reminder or scheduled message
+	if !IsUserScheduled(task) {
 		return &agent.ToolResult{Content: "not a reminder", IsError: true}, nil
 	}
 
@@ -82,13 +79,7 @@ func (t *CancelTool) Execute(ctx context.Context, params json.RawMessage) (*agen
 	}
 
 	// Update status to disabled (cancelled)
-	task.Status = tasks.TaskStatusDisabled
-	if task.Metadata == nil {
-		task.Metadata = make(map[string]any)
-	}
-	task.Metadata["cancelled_reason"] = "user_request"
-
-	if err := t.store.UpdateTask(ctx, task); err != nil {
+	if err := Cancel(ctx, t.store, task, "user_request"); err != nil {
 		return nil, fmt.Errorf("cancel reminder: %w", err)
 	}
 
@@ -24,7 +24,7 @@ func NewListTool(store tasks.Store) *ListTool {
 func (t *ListTool) Name() string { return "reminder_list" }
 
 func (t *ListTool) Description() string {
-	return "List all active reminders"
+	return "List all active reminders and scheduled messages"
 }
 
 func (t *ListTool) Schema() json.RawMessage {
@@ -80,10 +80,7 @@ func (t *ListTool) Execute(ctx context.Context, params json.RawMessage) (*agent.
 	// Filter to only reminder-type tasks
 	var reminders []*tasks.ScheduledTask
 	for _, task := range taskList {
-		if task.Metadata == nil {
-			continue
-		}
-		if taskType, ok := task.Metadata["type"].(string); ok && taskType == "reminder" {
+		if IsUserScheduled(task) {
 			// Filter by status unless including completed
 			if !input.IncludeCompleted && task.Status != tasks.TaskStatusActive {
 				continue
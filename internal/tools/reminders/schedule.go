@@ -0,0 +1,472 @@
+package reminders
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/haasonsaas/nexus/internal/tasks"
+	"github.com/haasonsaas/nexus/pkg/models"
+	"github.com/robfig/cron/v3"
+)
+
+// Task metadata types for messages scheduled by users.
+const (
+	// TaskTypeReminder sends "Reminder: <text>".
+	TaskTypeReminder = "reminder"
+	// TaskTypeScheduledMessage sends the text as written.
+	TaskTypeScheduledMessage = "scheduled_message"
+)
+
+// defaultRecurringHour is the hour used when a recurring schedule does not
+// name a time of day ("weekly on monday").
+const defaultRecurringHour = 9
+
+// cronParser matches the task scheduler's parser so every expression
+// accepted here also runs there.
+var cronParser = cron.NewParser(
+	cron.SecondOptional | cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow | cron.Descriptor,
+)
+
+// Schedule is a parsed send time: a single At, or a recurring Cron
+// expression evaluated in Location.
+type Schedule struct {
+	At          time.Time
+	Cron        string
+	Location    *time.Location
+	Description string
+}
+
+// Recurring reports whether the schedule repeats.
+func (s *Schedule) Recurring() bool {
+	return s != nil && s.Cron != ""
+}
+
+// Next returns the first send time after the given time, or zero when a
+// one-shot schedule has already passed.
+func (s *Schedule) Next(after time.Time) time.Time {
+	if s == nil {
+		return time.Time{}
+	}
+	if !s.Recurring() {
+		if s.At.After(after) {
+			return s.At
+		}
+		return time.Time{}
+	}
+	sched, err := cronParser.Parse(s.Cron)
+	if err != nil {
+		return time.Time{}
+	}
+	return sched.Next(after.In(s.location()))
+}
+
+// Summary describes the schedule for a confirmation, e.g. "for Mon Jan 2
+// 5:00 PM CET" or "every day at 9:00 AM CET, next Tue Jan 3 9:00 AM CET".
+func (s *Schedule) Summary(next time.Time) string {
+	if !s.Recurring() {
+		return "for " + s.Description
+	}
+	return s.Description + ", next " + next.In(s.location()).Format("Mon Jan 2 3:04 PM MST")
+}
+
+func (s *Schedule) location() *time.Location {
+	if s.Location != nil {
+		return s.Location
+	}
+	return time.Local
+}
+
+var (
+	clockPattern = regexp.MustCompile(`^(\d{1,2})(?::(\d{2}))?\s*(am|pm)?$`)
+	spacePattern = regexp.MustCompile(`\s+`)
+)
+
+// ParseSchedule parses a send-time specification in loc. One-shot forms are
+// "in 2 hours", "at 5pm", "tomorrow at 9:30", "friday 17:00" and absolute
+// timestamps; recurring forms are "hourly", "daily at 9am", "weekdays at
+// 8:30", "weekly on monday at 9am", "every friday at 5pm" and
+// "cron <expr>". Recurring schedules without a time fire at 9:00.
+func ParseSchedule(spec string, loc *time.Location, now time.Time) (*Schedule, error) {
+	if loc == nil {
+		loc = time.Local
+	}
+	now = now.In(loc)
+	raw := strings.TrimSpace(spec)
+	spec = spacePattern.ReplaceAllString(strings.TrimSpace(strings.ToLower(spec)), " ")
+	if spec == "" {
+		return nil, fmt.Errorf("a time is required")
+	}
+
+	if expr, ok := strings.CutPrefix(spec, "cron "); ok {
+		if _, err := cronParser.Parse(expr); err != nil {
+			return nil, fmt.Errorf("invalid cron expression: %w", err)
+		}
+		return &Schedule{Cron: expr, Location: loc, Description: "cron " + expr}, nil
+	}
+	if spec == "hourly" || spec == "every hour" {
+		return &Schedule{Cron: "0 * * * *", Location: loc, Description: "every hour"}, nil
+	}
+	if sched, ok, err := parseRecurring(spec, loc, now); ok || err != nil {
+		return sched, err
+	}
+
+	at, err := parseOneShot(spec, raw, loc, now)
+	if err != nil {
+		return nil, err
+	}
+	if !at.After(now) {
+		return nil, fmt.Errorf("%s is in the past", at.Format("Mon Jan 2 3:04 PM MST"))
+	}
+	return &Schedule{At: at, Location: loc, Description: at.Format("Mon Jan 2 3:04 PM MST")}, nil
+}
+
+// parseRecurring handles daily, weekday and weekly schedules. ok is false
+// when spec does not start with a recurrence keyword.
+func parseRecurring(spec string, loc *time.Location, now time.Time) (*Schedule, bool, error) {
+	var (
+		days, rest string
+		label      string
+	)
+	switch {
+	case hasWordPrefix(spec, "daily"), hasWordPrefix(spec, "every day"):
+		rest = trimWords(spec, "daily", "every day")
+		days, label = "*", "every day"
+	case hasWordPrefix(spec, "weekdays"), hasWordPrefix(spec, "every weekday"):
+		rest = trimWords(spec, "weekdays", "every weekday")
+		days, label = "1-5", "on weekdays"
+	case hasWordPrefix(spec, "weekly"), hasWordPrefix(spec, "every week"), hasWordPrefix(spec, "every"):
+		rest = trimWords(spec, "weekly", "every week", "every")
+		day := now.Weekday()
+		if first, remainder, _ := strings.Cut(strings.TrimPrefix(rest, "on "), " "); first != "" {
+			if wd, ok := parseWeekday(first); ok {
+				day = wd
+				rest = remainder
+			} else if !hasWordPrefix(spec, "weekly") && !hasWordPrefix(spec, "every week") {
+				return nil, true, fmt.Errorf("unknown day %q", first)
+			}
+		}
+		days, label = strconv.Itoa(int(day)), "every "+day.String()
+	default:
+		return nil, false, nil
+	}
+
+	hour, minute := defaultRecurringHour, 0
+	if rest = strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(rest), "at ")); rest != "" {
+		var err error
+		if hour, minute, err = parseClock(rest); err != nil {
+			return nil, true, err
+		}
+	}
+	clock := time.Date(2000, 1, 1, hour, minute, 0, 0, loc)
+	return &Schedule{
+		Cron:        fmt.Sprintf("%d %d * * %s", minute, hour, days),
+		Location:    loc,
+		Description: fmt.Sprintf("%s at %s %s", label, clock.Format("3:04 PM"), loc),
+	}, true, nil
+}
+
+// parseOneShot handles relative offsets, day words with clock times, and
+// absolute timestamps, which are matched against the unnormalized raw spec.
+func parseOneShot(spec, raw string, loc *time.Location, now time.Time) (time.Time, error) {
+	if offset, ok := strings.CutPrefix(spec, "in "); ok {
+		d, err := relativeDuration(offset)
+		if err != nil {
+			return time.Time{}, err
+		}
+		return now.Add(d), nil
+	}
+
+	for _, layout := range []string{time.RFC3339, "2006-01-02T15:04:05", "2006-01-02 15:04:05", "2006-01-02 15:04"} {
+		if t, err := time.ParseInLocation(layout, raw, loc); err == nil {
+			return t, nil
+		}
+	}
+
+	rest := strings.TrimPrefix(spec, "on ")
+	day := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, loc)
+	explicitDay := true
+	first, remainder, _ := strings.Cut(rest, " ")
+	switch first {
+	case "today", "tonight":
+		rest = remainder
+	case "tomorrow":
+		day = day.AddDate(0, 0, 1)
+		rest = remainder
+	default:
+		if wd, ok := parseWeekday(first); ok {
+			ahead := (int(wd) - int(now.Weekday()) + 7) % 7
+			if ahead == 0 {
+				ahead = 7
+			}
+			day = day.AddDate(0, 0, ahead)
+			rest = remainder
+		} else {
+			explicitDay = false
+		}
+	}
+
+	rest = strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(rest), "at "))
+	if rest == "" {
+		if first == "tonight" {
+			rest = "8pm"
+		} else {
+			return time.Time{}, fmt.Errorf("a time of day is required, e.g. %q at 5pm", spec)
+		}
+	}
+	hour, minute, err := parseClock(rest)
+	if err != nil {
+		return time.Time{}, err
+	}
+	at := time.Date(day.Year(), day.Month(), day.Day(), hour, minute, 0, 0, loc)
+	if !explicitDay && !at.After(now) {
+		at = at.AddDate(0, 0, 1)
+	}
+	return at, nil
+}
+
+// parseClock parses "5pm", "5:30 pm", "17:00", "noon" and "midnight".
+func parseClock(value string) (int, int, error) {
+	value = strings.TrimSpace(value)
+	switch value {
+	case "noon":
+		return 12, 0, nil
+	case "midnight":
+		return 0, 0, nil
+	}
+	m := clockPattern.FindStringSubmatch(value)
+	if m == nil {
+		return 0, 0, fmt.Errorf("could not parse time of day %q", value)
+	}
+	hour, _ := strconv.Atoi(m[1]) //nolint:errcheck // regexp guarantees digits
+	minute := 0
+	if m[2] != "" {
+		minute, _ = strconv.Atoi(m[2]) //nolint:errcheck // regexp guarantees digits
+	}
+	if minute > 59 {
+		return 0, 0, fmt.Errorf("could not parse time of day %q", value)
+	}
+	switch m[3] {
+	case "":
+		if hour > 23 {
+			return 0, 0, fmt.Errorf("could not parse time of day %q", value)
+		}
+	default:
+		if hour < 1 || hour > 12 {
+			return 0, 0, fmt.Errorf("could not parse time of day %q", value)
+		}
+		hour %= 12
+		if m[3] == "pm" {
+			hour += 12
+		}
+	}
+	return hour, minute, nil
+}
+
+func parseWeekday(word string) (time.Weekday, bool) {
+	word = strings.TrimSuffix(word, "s") // "mondays"
+	if len(word) < 3 {
+		return 0, false
+	}
+	for day := time.Sunday; day <= time.Saturday; day++ {
+		if strings.HasPrefix(strings.ToLower(day.String()), word) {
+			return day, true
+		}
+	}
+	return 0, false
+}
+
+func hasWordPrefix(spec, prefix string) bool {
+	return spec == prefix || strings.HasPrefix(spec, prefix+" ")
+}
+
+// trimWords removes the first matching prefix word from spec.
+func trimWords(spec string, prefixes ...string) string {
+	for _, prefix := range prefixes {
+		if hasWordPrefix(spec, prefix) {
+			return strings.TrimSpace(strings.TrimPrefix(spec, prefix))
+		}
+	}
+	return spec
+}
+
+// ScheduledMessage describes a message a user asked to have delivered.
+type ScheduledMessage struct {
+	// Type is TaskTypeReminder or TaskTypeScheduledMessage.
+	Type      string
+	Text      string
+	Schedule  *Schedule
+	AgentID   string
+	Channel   models.ChannelType
+	ChannelID string
+	// CreatedBy is the sender that scheduled the message.
+	CreatedBy string
+	// Source is "command" or "tool".
+	Source string
+}
+
+// NewTask builds the scheduled task that delivers msg through the message
+// executor.
+func (msg ScheduledMessage) NewTask(now time.Time) (*tasks.ScheduledTask, error) {
+	if strings.TrimSpace(msg.Text) == "" {
+		return nil, fmt.Errorf("message text is required")
+	}
+	if msg.Schedule == nil {
+		return nil, fmt.Errorf("schedule is required")
+	}
+	if msg.Channel == "" || msg.ChannelID == "" {
+		return nil, fmt.Errorf("a channel and conversation are required")
+	}
+	kind := msg.Type
+	if kind == "" {
+		kind = TaskTypeScheduledMessage
+	}
+	next := msg.Schedule.Next(now)
+	if next.IsZero() {
+		return nil, fmt.Errorf("schedule never fires")
+	}
+
+	schedule := msg.Schedule.Cron
+	if !msg.Schedule.Recurring() {
+		schedule = "@at " + msg.Schedule.At.Format(time.RFC3339)
+	}
+	metadata := map[string]any{
+		"type":     kind,
+		"source":   msg.Source,
+		"schedule": msg.Schedule.Description,
+	}
+	if msg.CreatedBy != "" {
+		metadata["created_by"] = msg.CreatedBy
+	}
+	if !msg.Schedule.Recurring() {
+		metadata["trigger_at"] = msg.Schedule.At.Format(time.RFC3339)
+	}
+
+	name := formatReminderName("", msg.Text)
+	if kind == TaskTypeScheduledMessage {
+		name = "Scheduled message: " + strings.TrimPrefix(name, "Reminder: ")
+	}
+	return &tasks.ScheduledTask{
+		ID:          uuid.NewString(),
+		Name:        name,
+		Description: "User-scheduled message",
+		AgentID:     msg.AgentID,
+		Schedule:    schedule,
+		Timezone:    msg.Schedule.location().String(),
+		Prompt:      msg.Text,
+		Status:      tasks.TaskStatusActive,
+		NextRunAt:   next,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+		Config: tasks.TaskConfig{
+			Channel:       string(msg.Channel),
+			ChannelID:     msg.ChannelID,
+			ExecutionType: tasks.ExecutionTypeMessage,
+			MaxRetries:    2,
+		},
+		Metadata: metadata,
+	}, nil
+}
+
+// IsUserScheduled reports whether task is a reminder or scheduled message.
+func IsUserScheduled(task *tasks.ScheduledTask) bool {
+	if task == nil || task.Metadata == nil {
+		return false
+	}
+	kind, _ := task.Metadata["type"].(string) //nolint:errcheck // type assertion
+	return kind == TaskTypeReminder || kind == TaskTypeScheduledMessage
+}
+
+// ListConversation returns the active reminders and scheduled messages
+// that deliver to one conversation, soonest first.
+func ListConversation(ctx context.Context, store tasks.Store, agentID string, channel models.ChannelType, channelID string) ([]*tasks.ScheduledTask, error) {
+	all, err := store.ListTasks(ctx, tasks.ListTasksOptions{AgentID: agentID})
+	if err != nil {
+		return nil, err
+	}
+	var out []*tasks.ScheduledTask
+	for _, task := range all {
+		if !IsUserScheduled(task) || task.Status != tasks.TaskStatusActive {
+			continue
+		}
+		if task.Config.Channel != string(channel) || task.Config.ChannelID != channelID {
+			continue
+		}
+		out = append(out, task)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].NextRunAt.Before(out[j].NextRunAt) })
+	return out, nil
+}
+
+// FindByID returns the task whose ID equals or starts with id. It fails
+// when the prefix matches more than one task.
+func FindByID(list []*tasks.ScheduledTask, id string) (*tasks.ScheduledTask, error) {
+	id = strings.TrimSpace(id)
+	if id == "" {
+		return nil, fmt.Errorf("an ID is required")
+	}
+	var match *tasks.ScheduledTask
+	for _, task := range list {
+		if task.ID == id {
+			return task, nil
+		}
+		if strings.HasPrefix(task.ID, id) {
+			if match != nil {
+				return nil, fmt.Errorf("%q matches more than one scheduled message", id)
+			}
+			match = task
+		}
+	}
+	if match == nil {
+		return nil, fmt.Errorf("no scheduled message %q", id)
+	}
+	return match, nil
+}
+
+// Cancel disables a reminder or scheduled message.
+func Cancel(ctx context.Context, store tasks.Store, task *tasks.ScheduledTask, reason string) error {
+	task.Status = tasks.TaskStatusDisabled
+	task.UpdatedAt = time.Now()
+	if task.Metadata == nil {
+		task.Metadata = make(map[string]any)
+	}
+	task.Metadata["cancelled_reason"] = reason
+	return store.UpdateTask(ctx, task)
+}
+
+// ShortID is the ID prefix shown in listings and accepted by FindByID.
+func ShortID(id string) string {
+	if len(id) > 8 {
+		return id[:8]
+	}
+	return id
+}
+
+// FormatList renders tasks for a chat reply with times in loc.
+func FormatList(list []*tasks.ScheduledTask, loc *time.Location) string {
+	if len(list) == 0 {
+		return "Nothing is scheduled for this conversation."
+	}
+	if loc == nil {
+		loc = time.Local
+	}
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Scheduled (%d):", len(list))
+	for _, task := range list {
+		kind := "message"
+		if t, _ := task.Metadata["type"].(string); t == TaskTypeReminder { //nolint:errcheck // type assertion
+			kind = "reminder"
+		}
+		when := task.NextRunAt.In(loc).Format("Mon Jan 2 3:04 PM MST")
+		if desc, _ := task.Metadata["schedule"].(string); desc != "" && !strings.HasPrefix(task.Schedule, "@at ") { //nolint:errcheck // type assertion
+			when = desc + ", next " + when
+		}
+		fmt.Fprintf(&sb, "\n%s  %s %q — %s", ShortID(task.ID), kind, task.Prompt, when)
+	}
+	return sb.String()
+}
@@ -0,0 +1,136 @@
+package reminders
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/haasonsaas/nexus/internal/agent"
+	"github.com/haasonsaas/nexus/internal/tasks"
+	"github.com/haasonsaas/nexus/pkg/models"
+)
+
+// TimezoneFunc returns the IANA timezone of the user on a channel, or ""
+// when unknown.
+type TimezoneFunc func(ctx context.Context, channel models.ChannelType, peerID string) string
+
+// ScheduleMessageTool schedules a one-off or recurring message to the
+// current conversation.
+type ScheduleMessageTool struct {
+	store    tasks.Store
+	timezone TimezoneFunc
+	now      func() time.Time
+}
+
+// NewScheduleMessageTool creates a new schedule_message tool. timezone may
+// be nil, in which case times are read in the gateway's local timezone.
+func NewScheduleMessageTool(store tasks.Store, timezone TimezoneFunc) *ScheduleMessageTool {
+	return &ScheduleMessageTool{store: store, timezone: timezone, now: time.Now}
+}
+
+func (t *ScheduleMessageTool) Name() string { return "schedule_message" }
+
+func (t *ScheduleMessageTool) Description() string {
+	return "Send a message to this conversation later or on a recurring schedule. Times are in the user's timezone."
+}
+
+func (t *ScheduleMessageTool) Schema() json.RawMessage {
+	return json.RawMessage(`{
+		"type": "object",
+		"properties": {
+			"message": {
+				"type": "string",
+				"description": "The message to send"
+			},
+			"when": {
+				"type": "string",
+				"description": "When to send: 'in 2 hours', 'at 5pm', 'tomorrow at 9am', 'friday 17:00', an ISO8601 timestamp, or recurring 'daily at 9am', 'weekdays at 8:30', 'weekly on monday at 10am', 'every friday at 5pm', 'hourly', 'cron <expr>'"
+			},
+			"reminder": {
+				"type": "boolean",
+				"description": "Prefix the message with 'Reminder:' when it is delivered"
+			},
+			"timezone": {
+				"type": "string",
+				"description": "Optional IANA timezone overriding the user's timezone"
+			}
+		},
+		"required": ["message", "when"]
+	}`)
+}
+
+// ScheduleMessageInput is the input for the schedule_message tool.
+type ScheduleMessageInput struct {
+	Message  string `json:"message"`
+	When     string `json:"when"`
+	Reminder bool   `json:"reminder"`
+	Timezone string `json:"timezone"`
+}
+
+// Execute schedules the message.
+func (t *ScheduleMessageTool) Execute(ctx context.Context, params json.RawMessage) (*agent.ToolResult, error) {
+	if t.store == nil {
+		return &agent.ToolResult{Content: "scheduler store unavailable", IsError: true}, nil
+	}
+
+	var input ScheduleMessageInput
+	if err := json.Unmarshal(params, &input); err != nil {
+		return nil, fmt.Errorf("parse input: %w", err)
+	}
+	if strings.TrimSpace(input.Message) == "" {
+		return &agent.ToolResult{Content: "message is required", IsError: true}, nil
+	}
+	if strings.TrimSpace(input.When) == "" {
+		return &agent.ToolResult{Content: "when is required", IsError: true}, nil
+	}
+
+	session := agent.SessionFromContext(ctx)
+	if session == nil || session.ChannelID == "" {
+		return &agent.ToolResult{Content: "no conversation to schedule the message in", IsError: true}, nil
+	}
+
+	tz := strings.TrimSpace(input.Timezone)
+	if tz == "" && t.timezone != nil {
+		tz = t.timezone(ctx, session.Channel, session.ChannelID)
+	}
+	loc := time.Local
+	if tz != "" {
+		var err error
+		if loc, err = time.LoadLocation(tz); err != nil {
+			return &agent.ToolResult{Content: fmt.Sprintf("invalid timezone %q", tz), IsError: true}, nil
+		}
+	}
+
+	now := t.now()
+	schedule, err := ParseSchedule(input.When, loc, now)
+	if err != nil {
+		return &agent.ToolResult{Content: fmt.Sprintf("invalid time: %v", err), IsError: true}, nil
+	}
+	kind := TaskTypeScheduledMessage
+	if input.Reminder {
+		kind = TaskTypeReminder
+	}
+	task, err := ScheduledMessage{
+		Type:      kind,
+		Text:      input.Message,
+		Schedule:  schedule,
+		AgentID:   session.AgentID,
+		Channel:   session.Channel,
+		ChannelID: session.ChannelID,
+		Source:    "tool",
+	}.NewTask(now)
+	if err != nil {
+		return &agent.ToolResult{Content: err.Error(), IsError: true}, nil
+	}
+	if err := t.store.CreateTask(ctx, task); err != nil {
+		return nil, fmt.Errorf("schedule message: %w", err)
+	}
+
+	return &agent.ToolResult{Content: fmt.Sprintf("Scheduled %s\nID: %s\nMessage: %s",
+		schedule.Summary(task.NextRunAt),
+		task.ID,
+		input.Message,
+	)}, nil
+}
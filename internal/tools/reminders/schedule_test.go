@@ -0,0 +1,214 @@
+package reminders
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/haasonsaas/nexus/internal/agent"
+	"github.com/haasonsaas/nexus/internal/tasks"
+	"github.com/haasonsaas/nexus/pkg/models"
+)
+
+func TestParseSchedule(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("tzdata unavailable: %v", err)
+	}
+	now := time.Date(2026, 10, 15, 10, 0, 0, 0, loc) // Thursday
+
+	oneShot := []struct {
+		spec string
+		want time.Time
+	}{
+		{"at 5pm", time.Date(2026, 10, 15, 17, 0, 0, 0, loc)},
+		{"9am", time.Date(2026, 10, 16, 9, 0, 0, 0, loc)},
+		{"Tomorrow 9:30", time.Date(2026, 10, 16, 9, 30, 0, 0, loc)},
+		{"friday at noon", time.Date(2026, 10, 16, 12, 0, 0, 0, loc)},
+		{"on thursday 8am", time.Date(2026, 10, 22, 8, 0, 0, 0, loc)},
+		{"tonight", time.Date(2026, 10, 15, 20, 0, 0, 0, loc)},
+		{"in 2 hours", time.Date(2026, 10, 15, 12, 0, 0, 0, loc)},
+		{"2026-10-20 17:00", time.Date(2026, 10, 20, 17, 0, 0, 0, loc)},
+		{"2026-10-20T17:00:00Z", time.Date(2026, 10, 20, 17, 0, 0, 0, time.UTC)},
+	}
+	for _, tt := range oneShot {
+		t.Run(tt.spec, func(t *testing.T) {
+			sched, err := ParseSchedule(tt.spec, loc, now)
+			if err != nil {
+				t.Fatalf("ParseSchedule(%q) error = %v", tt.spec, err)
+			}
+			if sched.Recurring() || !sched.At.Equal(tt.want) {
+				t.Fatalf("ParseSchedule(%q) = %+v, want %v", tt.spec, sched, tt.want)
+			}
+		})
+	}
+
+	recurring := map[string]string{
+		"daily at 8:30":             "30 8 * * *",
+		"every day at 6:15 pm":      "15 18 * * *",
+		"weekdays at 7pm":           "0 19 * * 1-5",
+		"weekly on monday at 10am":  "0 10 * * 1",
+		"weekly":                    "0 9 * * 4",
+		"every friday at 5pm":       "0 17 * * 5",
+		"every tues":                "0 9 * * 2",
+		"hourly":                    "0 * * * *",
+		"cron 0 9 1 * *":            "0 9 1 * *",
+		"weekly at midnight":        "0 0 * * 4",
+		"every Wednesdays at 14:45": "45 14 * * 3",
+	}
+	for spec, want := range recurring {
+		t.Run(spec, func(t *testing.T) {
+			sched, err := ParseSchedule(spec, loc, now)
+			if err != nil {
+				t.Fatalf("ParseSchedule(%q) error = %v", spec, err)
+			}
+			if sched.Cron != want {
+				t.Fatalf("ParseSchedule(%q).Cron = %q, want %q", spec, sched.Cron, want)
+			}
+		})
+	}
+
+	for _, spec := range []string{"", "today at 8am", "every blursday", "at 25:00", "13pm", "sometime", "tomorrow", "cron nope"} {
+		t.Run("invalid "+spec, func(t *testing.T) {
+			if sched, err := ParseSchedule(spec, loc, now); err == nil {
+				t.Fatalf("ParseSchedule(%q) = %+v, want error", spec, sched)
+			}
+		})
+	}
+}
+
+func TestScheduledMessageNewTask(t *testing.T) {
+	loc, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skipf("tzdata unavailable: %v", err)
+	}
+	now := time.Date(2026, 10, 15, 10, 0, 0, 0, loc)
+
+	sched, err := ParseSchedule("weekly on monday at 10am", loc, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	task, err := ScheduledMessage{
+		Type:      TaskTypeReminder,
+		Text:      "standup notes",
+		Schedule:  sched,
+		AgentID:   "main",
+		Channel:   models.ChannelSlack,
+		ChannelID: "C1",
+		Source:    "command",
+	}.NewTask(now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if task.Schedule != "0 10 * * 1" || task.Timezone != "Europe/Berlin" {
+		t.Fatalf("unexpected schedule %q in %q", task.Schedule, task.Timezone)
+	}
+	if want := time.Date(2026, 10, 19, 10, 0, 0, 0, loc); !task.NextRunAt.Equal(want) {
+		t.Fatalf("NextRunAt = %v, want %v", task.NextRunAt, want)
+	}
+	if task.Config.ExecutionType != tasks.ExecutionTypeMessage || task.Config.Channel != "slack" || task.Metadata["type"] != TaskTypeReminder {
+		t.Fatalf("unexpected task %+v", task)
+	}
+
+	sched, err = ParseSchedule("at 5pm", loc, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	task, err = ScheduledMessage{Text: "hi", Schedule: sched, Channel: models.ChannelSlack, ChannelID: "C1"}.NewTask(now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if task.Schedule != "@at 2026-10-15T17:00:00+02:00" || task.Metadata["type"] != TaskTypeScheduledMessage || !strings.HasPrefix(task.Name, "Scheduled message: ") {
+		t.Fatalf("unexpected one-shot task %+v", task)
+	}
+
+	if _, err := (ScheduledMessage{Text: "hi", Schedule: sched}).NewTask(now); err == nil {
+		t.Fatal("expected error without a conversation")
+	}
+}
+
+func TestListConversationAndFindByID(t *testing.T) {
+	ctx := context.Background()
+	store := newAdvancedMockStore()
+	now := time.Now()
+	for _, task := range []*tasks.ScheduledTask{
+		{ID: "abc-2", Status: tasks.TaskStatusActive, NextRunAt: now.Add(2 * time.Hour), Config: tasks.TaskConfig{Channel: "slack", ChannelID: "C1"}, Metadata: map[string]any{"type": TaskTypeScheduledMessage}},
+		{ID: "abc-1", Status: tasks.TaskStatusActive, NextRunAt: now.Add(time.Hour), Config: tasks.TaskConfig{Channel: "slack", ChannelID: "C1"}, Metadata: map[string]any{"type": TaskTypeReminder}},
+		{ID: "other-chat", Status: tasks.TaskStatusActive, Config: tasks.TaskConfig{Channel: "slack", ChannelID: "C2"}, Metadata: map[string]any{"type": TaskTypeReminder}},
+		{ID: "cancelled", Status: tasks.TaskStatusDisabled, Config: tasks.TaskConfig{Channel: "slack", ChannelID: "C1"}, Metadata: map[string]any{"type": TaskTypeReminder}},
+		{ID: "cron-job", Status: tasks.TaskStatusActive, Config: tasks.TaskConfig{Channel: "slack", ChannelID: "C1"}},
+	} {
+		store.tasks[task.ID] = task
+	}
+
+	list, err := ListConversation(ctx, store, "main", models.ChannelSlack, "C1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list) != 2 || list[0].ID != "abc-1" || list[1].ID != "abc-2" {
+		t.Fatalf("unexpected list %+v", list)
+	}
+	if _, err := FindByID(list, "abc"); err == nil || !strings.Contains(err.Error(), "more than one") {
+		t.Fatalf("expected ambiguous prefix error, got %v", err)
+	}
+	task, err := FindByID(list, "abc-2")
+	if err != nil || task.ID != "abc-2" {
+		t.Fatalf("FindByID = %v, %v", task, err)
+	}
+	if _, err := FindByID(list, "other-chat"); err == nil {
+		t.Fatal("expected tasks from other conversations to be unreachable")
+	}
+
+	if err := Cancel(ctx, store, task, "user_command"); err != nil {
+		t.Fatal(err)
+	}
+	if store.tasks["abc-2"].Status != tasks.TaskStatusDisabled {
+		t.Fatal("expected task to be disabled")
+	}
+	if text := FormatList(list[:1], time.UTC); !strings.Contains(text, "abc-1  reminder") {
+		t.Fatalf("unexpected listing %q", text)
+	}
+}
+
+func TestScheduleMessageTool(t *testing.T) {
+	loc, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Skipf("tzdata unavailable: %v", err)
+	}
+	store := newAdvancedMockStore()
+	var gotPeer string
+	tool := NewScheduleMessageTool(store, func(_ context.Context, _ models.ChannelType, peerID string) string {
+		gotPeer = peerID
+		return "Asia/Tokyo"
+	})
+	tool.now = func() time.Time { return time.Date(2026, 10, 15, 10, 0, 0, 0, loc) }
+
+	result, err := tool.Execute(context.Background(), json.RawMessage(`{"message":"hi","when":"at 5pm"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !result.IsError {
+		t.Fatal("expected error without a session")
+	}
+
+	ctx := agent.WithSession(context.Background(), &models.Session{AgentID: "main", Channel: models.ChannelTelegram, ChannelID: "42"})
+	result, err = tool.Execute(ctx, json.RawMessage(`{"message":"hi","when":"at 5pm"}`))
+	if err != nil || result.IsError {
+		t.Fatalf("Execute() = %+v, %v", result, err)
+	}
+	if gotPeer != "42" || len(store.tasks) != 1 {
+		t.Fatalf("peer %q, tasks %d", gotPeer, len(store.tasks))
+	}
+	for _, task := range store.tasks {
+		if want := time.Date(2026, 10, 15, 17, 0, 0, 0, loc); !task.NextRunAt.Equal(want) || task.AgentID != "main" {
+			t.Fatalf("unexpected task %+v", task)
+		}
+	}
+
+	result, err = tool.Execute(ctx, json.RawMessage(`{"message":"hi","when":"at 5pm","timezone":"Mars/Olympus"}`))
+	if err != nil || !result.IsError {
+		t.Fatalf("expected invalid timezone error, got %+v, %v", result, err)
+	}
+}
@@ -184,16 +184,25 @@ func parseWhen(when string) (time.Time, error) {
 var relativeTimePattern = regexp.MustCompile(`^(\d+(?:\.\d+)?)\s*(seconds?|minutes?|mins?|hours?|hrs?|days?|weeks?)$`)
 
 func parseRelativeTime(s string) (time.Time, error) {
+	duration, err := relativeDuration(s)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.Now().Add(duration), nil
+}
+
+// relativeDuration parses offsets like "5 minutes" or "2 hrs".
+func relativeDuration(s string) (time.Duration, error) {
 	s = strings.TrimSpace(strings.ToLower(s))
 
 	matches := relativeTimePattern.FindStringSubmatch(s)
 	if matches == nil {
-		return time.Time{}, fmt.Errorf("invalid relative time: %s", s)
+		return 0, fmt.Errorf("invalid relative time: %s", s)
 	}
 
 	amount, err := strconv.ParseFloat(matches[1], 64)
 	if err != nil {
-		return time.Time{}, fmt.Errorf("invalid number: %s", matches[1])
+		return 0, fmt.Errorf("invalid number: %s", matches[1])
 	}
 
 	unit := matches[2]
@@ -211,10 +220,10 @@ func parseRelativeTime(s string) (time.Time, error) {
 	case strings.HasPrefix(unit, "week"):
 		duration = time.Duration(amount * float64(7*24*time.Hour))
 	default:
-		return time.Time{}, fmt.Errorf("unknown unit: %s", unit)
+		return 0, fmt.Errorf("unknown unit: %s", unit)
 	}
 
-	return time.Now().Add(duration), nil
+	return duration, nil
 }
 
 func formatReminderName(title, message string) string {
@@ -464,11 +464,17 @@ func (d *daytonaExecutor) createSandbox(ctx context.Context, params *ExecutePara
 	}
 
 	if d.config.Daytona != nil {
-		if d.config.Daytona.Snapshot != "" {
+		// A per-language image pin takes precedence over the shared
+		// snapshot and image.
+		image := d.config.Images[d.language]
+		if image == "" && d.config.Daytona.Snapshot == "" {
+			image = d.config.Daytona.Image
+		}
+		if image == "" && d.config.Daytona.Snapshot != "" {
 			createReq.SetSnapshot(d.config.Daytona.Snapshot)
-		} else if d.config.Daytona.Image != "" {
+		} else if image != "" {
 			buildInfo := apiclient.CreateBuildInfo{
-				DockerfileContent: fmt.Sprintf("FROM %s", d.config.Daytona.Image),
+				DockerfileContent: fmt.Sprintf("FROM %s", image),
 			}
 			createReq.SetBuildInfo(buildInfo)
 		}
@@ -18,6 +18,7 @@ import (
 // It supports Python, Node.js, Go, and Bash with configurable resource limits.
 type Executor struct {
 	pool            *Pool
+	wasm            *wasmRunner
 	useFirecracker  bool
 	workspaceRoot   string
 	workspaceAccess WorkspaceAccessMode
@@ -40,7 +41,7 @@ const (
 // ExecuteParams defines the input parameters for code execution including
 // the code, language, optional input, additional files, and resource limits.
 type ExecuteParams struct {
-	Language        string              `json:"language"` // python, nodejs, go, bash
+	Language        string              `json:"language,omitempty"` // python, nodejs, go, bash; detected when empty
 	Code            string              `json:"code"`
 	Stdin           string              `json:"stdin,omitempty"`
 	Files           map[string]string   `json:"files,omitempty"`            // filename -> content
@@ -102,13 +103,24 @@ func NewExecutor(opts ...Option) (*Executor, error) {
 		}
 	}
 
+	var wasm *wasmRunner
+	if config.Wasm != nil {
+		runner, err := newWasmRunner(context.Background(), *config.Wasm)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create wasm backend: %w", err)
+		}
+		wasm = runner
+	}
+
 	pool, err := NewPool(config)
 	if err != nil {
+		_ = wasm.Close(context.Background())
 		return nil, fmt.Errorf("failed to create pool: %w", err)
 	}
 
 	return &Executor{
 		pool:            pool,
+		wasm:            wasm,
 		useFirecracker:  useFirecracker,
 		workspaceRoot:   config.WorkspaceRoot,
 		workspaceAccess: config.WorkspaceAccess,
@@ -133,7 +145,7 @@ func (e *Executor) Schema() json.RawMessage {
 			"language": {
 				"type": "string",
 				"enum": ["python", "nodejs", "go", "bash"],
-				"description": "Programming language to execute"
+				"description": "Programming language to execute. Detected from the code when omitted."
 			},
 			"code": {
 				"type": "string",
@@ -170,7 +182,7 @@ func (e *Executor) Schema() json.RawMessage {
 				"enum": ["ro", "rw", "readonly", "readwrite", "read-only", "read-write", "write", "none", "disabled"]
 			}
 		},
-		"required": ["language", "code"]
+		"required": ["code"]
 	}`
 	return json.RawMessage(schema)
 }
@@ -185,7 +197,19 @@ func (e *Executor) Execute(ctx context.Context, params json.RawMessage) (*agent.
 		}, nil
 	}
 
-	// Validate language
+	// Resolve the language, detecting it when the model left it out
+	hint, code := unwrapCodeFence(execParams.Code)
+	execParams.Code = code
+	execParams.Language = normalizeLanguage(execParams.Language)
+	if execParams.Language == "" {
+		execParams.Language = detectLanguage(code, hint)
+		if execParams.Language == "" {
+			return &agent.ToolResult{
+				Content: "Could not detect the language of the code. Set language to one of: python, nodejs, go, bash",
+				IsError: true,
+			}, nil
+		}
+	}
 	if !isValidLanguage(execParams.Language) {
 		return &agent.ToolResult{
 			Content: fmt.Sprintf("Unsupported language: %s. Supported: python, nodejs, go, bash", execParams.Language),
@@ -232,8 +256,16 @@ func (e *Executor) Execute(ctx context.Context, params json.RawMessage) (*agent.
 	}, nil
 }
 
-// executeCode runs the code using the pool.
+// executeCode runs the code using the pool, or in WASM when the snippet is
+// small enough to skip container or VM startup.
 func (e *Executor) executeCode(ctx context.Context, params *ExecuteParams) (*ExecuteResult, error) {
+	if e.wasm.Eligible(params) {
+		result, err := e.executeWasm(ctx, params)
+		if !errors.Is(err, errWasmUnavailable) {
+			return result, err
+		}
+	}
+
 	// Get an executor from the pool
 	executor, err := e.pool.Get(ctx, params.Language)
 	if err != nil {
@@ -263,6 +295,16 @@ func (e *Executor) executeCode(ctx context.Context, params *ExecuteParams) (*Exe
 	return result, nil
 }
 
+// executeWasm runs the code with the WASM backend.
+func (e *Executor) executeWasm(ctx context.Context, params *ExecuteParams) (*ExecuteResult, error) {
+	workspace, err := prepareWorkspace(params, e.workspaceRoot)
+	if err != nil {
+		return nil, fmt.Errorf("failed to prepare workspace: %w", err)
+	}
+	defer os.RemoveAll(workspace)
+	return e.wasm.Run(ctx, params, workspace)
+}
+
 // prepareWorkspace creates a scratch directory with code and files.
 func prepareWorkspace(params *ExecuteParams, workspaceRoot string) (string, error) {
 	workspaceRoot = strings.TrimSpace(workspaceRoot)
@@ -396,6 +438,7 @@ func formatExecutionResult(result *ExecuteResult) string {
 
 // Close shuts down the executor pool and releases all resources.
 func (e *Executor) Close() error {
+	_ = e.wasm.Close(context.Background())
 	return e.pool.Close()
 }
 
@@ -416,9 +459,8 @@ type dockerExecutor struct {
 	networkEnabled bool
 }
 
-// newDockerExecutor creates a new Docker-based executor.
-func newDockerExecutor(language string, cpuLimit, memLimit int, networkEnabled bool) (*dockerExecutor, error) {
-	image := getDockerImage(language)
+// newDockerExecutor creates a new Docker-based executor running image.
+func newDockerExecutor(language, image string, cpuLimit, memLimit int, networkEnabled bool) (*dockerExecutor, error) {
 	return &dockerExecutor{
 		language:       language,
 		image:          image,
@@ -613,9 +655,24 @@ type Config struct {
 	WorkspaceRoot   string
 	WorkspaceAccess WorkspaceAccessMode
 
+	// Images overrides the container image per language.
+	Images map[string]string
+
+	// Wasm enables the WASM backend for small snippets when set.
+	Wasm *WasmConfig
+
 	daytonaClient *daytonaClient
 }
 
+// dockerImage returns the configured image for a language, falling back to
+// the built-in default.
+func (c *Config) dockerImage(language string) string {
+	if image := c.Images[language]; image != "" {
+		return image
+	}
+	return getDockerImage(language)
+}
+
 // Backend represents the sandbox backend technology (Docker, Firecracker, Daytona).
 type Backend string
 
@@ -697,3 +754,20 @@ func WithDefaultWorkspaceAccess(mode WorkspaceAccessMode) Option {
 		c.WorkspaceAccess = mode
 	}
 }
+
+// WithLanguageImage pins the container image for a language.
+func WithLanguageImage(language, image string) Option {
+	return func(c *Config) {
+		if c.Images == nil {
+			c.Images = make(map[string]string)
+		}
+		c.Images[normalizeLanguage(language)] = image
+	}
+}
+
+// WithWasm routes small Python and JavaScript snippets to the WASM backend.
+func WithWasm(cfg WasmConfig) Option {
+	return func(c *Config) {
+		c.Wasm = &cfg
+	}
+}
@@ -730,7 +730,7 @@ func TestPool_Close(t *testing.T) {
 func TestDockerExecutor_Run(t *testing.T) {
 	requireDocker(t)
 
-	executor, err := newDockerExecutor("python", 1000, 512, false)
+	executor, err := newDockerExecutor("python", getDockerImage("python"), 1000, 512, false)
 	if err != nil {
 		t.Fatalf("Failed to create docker executor: %v", err)
 	}
@@ -1219,6 +1219,14 @@ func TestConfigOptions(t *testing.T) {
 	if cfg.WorkspaceAccess != WorkspaceReadWrite {
 		t.Errorf("WithDefaultWorkspaceAccess: WorkspaceAccess = %q, want %q", cfg.WorkspaceAccess, WorkspaceReadWrite)
 	}
+
+	WithLanguageImage("node", "ghcr.io/acme/sandbox-node:1.2")(cfg)
+	if got := cfg.dockerImage("nodejs"); got != "ghcr.io/acme/sandbox-node:1.2" {
+		t.Errorf("WithLanguageImage: dockerImage(nodejs) = %q", got)
+	}
+	if got := cfg.dockerImage("python"); got != getDockerImage("python") {
+		t.Errorf("dockerImage(python) = %q, want the default image", got)
+	}
 }
 
 func TestParseWorkspaceAccess(t *testing.T) {
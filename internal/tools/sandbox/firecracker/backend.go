@@ -4,16 +4,27 @@ package firecracker
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"sort"
 	"sync"
 	"time"
 
 	"github.com/haasonsaas/nexus/internal/tools/sandbox"
 )
 
+var (
+	// ErrSnapshotsDisabled is returned by snapshot operations when
+	// snapshots are not enabled.
+	ErrSnapshotsDisabled = errors.New("firecracker snapshots are not enabled")
+
+	// ErrSnapshotNotFound is returned when a snapshot ID is unknown.
+	ErrSnapshotNotFound = errors.New("snapshot not found")
+)
+
 // Backend implements the sandbox.RuntimeExecutor interface using Firecracker microVMs.
 type Backend struct {
 	pool            *VMPool
@@ -62,6 +73,10 @@ type BackendConfig struct {
 
 	// SnapshotMaxAge controls when snapshots are considered stale.
 	SnapshotMaxAge time.Duration
+
+	// SnapshotJitter randomizes each refresh interval by up to this
+	// fraction.
+	SnapshotJitter float64
 }
 
 // DefaultBackendConfig returns a BackendConfig with sensible defaults.
@@ -143,6 +158,7 @@ func NewBackend(config *BackendConfig) (*Backend, error) {
 	if config.SnapshotMaxAge > 0 {
 		poolConfig.SnapshotMaxAge = config.SnapshotMaxAge
 	}
+	poolConfig.SnapshotJitter = config.SnapshotJitter
 
 	// Create VM pool
 	pool, err := NewVMPool(poolConfig)
@@ -305,6 +321,78 @@ func (b *Backend) Stats() BackendStats {
 	}
 }
 
+// Snapshots returns copies of the snapshots for language, or for every
+// language when it is empty, newest first. Size is measured on disk.
+func (b *Backend) Snapshots(language string) ([]Snapshot, error) {
+	if b.snapshotManager == nil {
+		return nil, ErrSnapshotsDisabled
+	}
+	snapshots := b.snapshotManager.ListSnapshots(language)
+	out := make([]Snapshot, 0, len(snapshots))
+	for _, snap := range snapshots {
+		copied := *snap
+		copied.Size = snapshotDiskSize(snap)
+		out = append(out, copied)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		return out[i].CreatedAt.After(out[j].CreatedAt)
+	})
+	return out, nil
+}
+
+// CreateSnapshot snapshots a pooled VM for language.
+func (b *Backend) CreateSnapshot(ctx context.Context, language string) (Snapshot, error) {
+	if b.snapshotManager == nil {
+		return Snapshot{}, ErrSnapshotsDisabled
+	}
+	snapshot, err := b.pool.CreateSnapshot(ctx, language)
+	if snapshot == nil {
+		return Snapshot{}, err
+	}
+	copied := *snapshot
+	copied.Size = snapshotDiskSize(snapshot)
+	return copied, err
+}
+
+// RefreshSnapshots replaces the snapshots of language, or of every
+// language when it is empty, and returns the new snapshots.
+func (b *Backend) RefreshSnapshots(ctx context.Context, language string) ([]Snapshot, error) {
+	if b.snapshotManager == nil {
+		return nil, ErrSnapshotsDisabled
+	}
+	snapshots, err := b.pool.RefreshSnapshots(ctx, language)
+	out := make([]Snapshot, 0, len(snapshots))
+	for _, snap := range snapshots {
+		copied := *snap
+		copied.Size = snapshotDiskSize(snap)
+		out = append(out, copied)
+	}
+	return out, err
+}
+
+// DeleteSnapshot removes a snapshot by ID.
+func (b *Backend) DeleteSnapshot(id string) error {
+	if b.snapshotManager == nil {
+		return ErrSnapshotsDisabled
+	}
+	if _, ok := b.snapshotManager.GetSnapshot(id); !ok {
+		return fmt.Errorf("%w: %s", ErrSnapshotNotFound, id)
+	}
+	return b.snapshotManager.DeleteSnapshot(id)
+}
+
+// snapshotDiskSize returns the combined size of a snapshot's memory and
+// state files.
+func snapshotDiskSize(snapshot *Snapshot) int64 {
+	var size int64
+	for _, path := range []string{snapshot.MemoryPath, snapshot.StatePath} {
+		if info, err := os.Stat(path); err == nil {
+			size += info.Size()
+		}
+	}
+	return size
+}
+
 // BackendStats contains backend statistics.
 type BackendStats struct {
 	Pool    PoolStats    `json:"pool"`
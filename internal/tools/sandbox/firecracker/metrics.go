@@ -0,0 +1,101 @@
+//go:build linux
+
+package firecracker
+
+import (
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// Metrics exports VM pool and snapshot behavior.
+type Metrics struct {
+	// PoolRequests counts VM requests by whether a pre-warmed VM served
+	// them. The warm-hit rate is warm / (warm + cold).
+	// Labels: language, result (warm|cold)
+	PoolRequests *prometheus.CounterVec
+
+	// SnapshotRestore is the time taken to boot a VM from a snapshot.
+	// Labels: language, result (success|failure)
+	SnapshotRestore *prometheus.HistogramVec
+
+	// SnapshotRefreshes counts snapshots taken by the pool.
+	// Labels: language, result (success|failure)
+	SnapshotRefreshes *prometheus.CounterVec
+
+	// SnapshotAge is the age of the newest snapshot.
+	// Labels: language
+	SnapshotAge *prometheus.GaugeVec
+}
+
+var (
+	metricsOnce     sync.Once
+	metricsInstance *Metrics
+)
+
+// NewMetrics returns the process-wide Firecracker metrics.
+func NewMetrics() *Metrics {
+	metricsOnce.Do(func() {
+		metricsInstance = &Metrics{
+			PoolRequests: promauto.NewCounterVec(prometheus.CounterOpts{
+				Name: "nexus_sandbox_pool_requests_total",
+				Help: "Sandbox VM requests by whether a pre-warmed VM was available",
+			}, []string{"language", "result"}),
+			SnapshotRestore: promauto.NewHistogramVec(prometheus.HistogramOpts{
+				Name:    "nexus_sandbox_snapshot_restore_seconds",
+				Help:    "Time taken to boot a sandbox VM from a snapshot",
+				Buckets: []float64{0.05, 0.1, 0.25, 0.5, 1, 2, 5, 10, 30},
+			}, []string{"language", "result"}),
+			SnapshotRefreshes: promauto.NewCounterVec(prometheus.CounterOpts{
+				Name: "nexus_sandbox_snapshot_refreshes_total",
+				Help: "Sandbox snapshots taken by the VM pool by result",
+			}, []string{"language", "result"}),
+			SnapshotAge: promauto.NewGaugeVec(prometheus.GaugeOpts{
+				Name: "nexus_sandbox_snapshot_age_seconds",
+				Help: "Age of the newest sandbox snapshot",
+			}, []string{"language"}),
+		}
+	})
+	return metricsInstance
+}
+
+func (m *Metrics) poolRequest(language string, warm bool) {
+	if m == nil {
+		return
+	}
+	result := "cold"
+	if warm {
+		result = "warm"
+	}
+	m.PoolRequests.WithLabelValues(language, result).Inc()
+}
+
+func (m *Metrics) snapshotRestore(language string, elapsed time.Duration, err error) {
+	if m == nil {
+		return
+	}
+	m.SnapshotRestore.WithLabelValues(language, resultLabel(err)).Observe(elapsed.Seconds())
+}
+
+func (m *Metrics) snapshotRefresh(language string, err error) {
+	if m == nil {
+		return
+	}
+	m.SnapshotRefreshes.WithLabelValues(language, resultLabel(err)).Inc()
+}
+
+func (m *Metrics) snapshotAge(language string, snapshot *Snapshot) {
+	if m == nil || snapshot == nil {
+		return
+	}
+	m.SnapshotAge.WithLabelValues(language).Set(time.Since(snapshot.CreatedAt).Seconds())
+}
+
+func resultLabel(err error) string {
+	if err != nil {
+		return "failure"
+	}
+	return "success"
+}
@@ -6,6 +6,8 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math/rand"
+	"sort"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -63,6 +65,10 @@ type PoolConfig struct {
 
 	// SnapshotMaxAge controls when snapshots should be refreshed.
 	SnapshotMaxAge time.Duration
+
+	// SnapshotJitter randomizes each refresh interval by up to this
+	// fraction of SnapshotRefreshInterval.
+	SnapshotJitter float64
 }
 
 // DefaultPoolConfig returns a PoolConfig with sensible defaults.
@@ -92,7 +98,11 @@ type VMPool struct {
 
 	snapshotManager *SnapshotManager
 	snapshotMu      sync.Mutex
-	snapshotRefresh map[string]time.Time
+	// snapshotNext holds the earliest time each language's snapshot may be
+	// refreshed again.
+	snapshotNext map[string]time.Time
+
+	metrics *Metrics
 
 	// pools holds per-language pools of VMs.
 	pools   map[string]*languageVMPool
@@ -151,11 +161,12 @@ func NewVMPool(config *PoolConfig) (*VMPool, error) {
 	}
 
 	pool := &VMPool{
-		config:          config,
-		pools:           make(map[string]*languageVMPool),
-		cidCounter:      3, // CIDs 0, 1, 2 are reserved
-		stopCh:          make(chan struct{}),
-		snapshotRefresh: make(map[string]time.Time),
+		config:       config,
+		pools:        make(map[string]*languageVMPool),
+		cidCounter:   3, // CIDs 0, 1, 2 are reserved
+		stopCh:       make(chan struct{}),
+		snapshotNext: make(map[string]time.Time),
+		metrics:      NewMetrics(),
 	}
 
 	// Initialize per-language pools
@@ -356,36 +367,142 @@ func (p *VMPool) maybeRefreshSnapshot(ctx context.Context, language string, lang
 	if p.snapshotManager == nil || !p.config.SnapshotsEnabled {
 		return
 	}
-	if p.config.SnapshotRefreshInterval > 0 {
-		p.snapshotMu.Lock()
-		last := p.snapshotRefresh[language]
-		if !last.IsZero() && time.Since(last) < p.config.SnapshotRefreshInterval {
-			p.snapshotMu.Unlock()
-			return
-		}
-		p.snapshotMu.Unlock()
+	p.snapshotMu.Lock()
+	next := p.snapshotNext[language]
+	p.snapshotMu.Unlock()
+	if !next.IsZero() && time.Now().Before(next) {
+		return
 	}
 
 	latest := p.latestSnapshot(language)
+	p.metrics.snapshotAge(language, latest)
 	if latest != nil && p.config.SnapshotMaxAge > 0 && time.Since(latest.CreatedAt) < p.config.SnapshotMaxAge {
 		return
 	}
 
+	// Best-effort snapshot refresh; keep serving from existing VMs/snapshots.
+	_, _ = p.snapshot(ctx, language, langPool, false) //nolint:errcheck // recorded in metrics
+}
+
+// snapshot takes a full snapshot of an idle VM for language. When no VM is
+// idle it boots one if create is set and otherwise does nothing, returning
+// a nil snapshot. The next scheduled refresh is pushed back by a jittered
+// SnapshotRefreshInterval.
+func (p *VMPool) snapshot(ctx context.Context, language string, langPool *languageVMPool, create bool) (*Snapshot, error) {
+	var vm *MicroVM
 	select {
-	case vm := <-langPool.available:
+	case vm = <-langPool.available:
 		atomic.AddInt64(&p.stats.IdleVMs, -1)
-		if _, err := p.snapshotManager.CreateSnapshot(ctx, vm, SnapshotTypeFull); err != nil {
-			// Best-effort snapshot refresh; keep serving from existing VMs/snapshots.
-			_ = err
+	default:
+		if !create {
+			return nil, nil
 		}
-		langPool.available <- vm
+		created, err := p.createVM(ctx, language)
+		if err != nil {
+			atomic.AddInt64(&p.stats.CreationErrors, 1)
+			return nil, fmt.Errorf("failed to create VM: %w", err)
+		}
+		vm = created
+	}
+
+	snapshot, err := p.snapshotManager.CreateSnapshot(ctx, vm, SnapshotTypeFull)
+	p.metrics.snapshotRefresh(language, err)
+	select {
+	case langPool.available <- vm:
 		atomic.AddInt64(&p.stats.IdleVMs, 1)
-		p.snapshotMu.Lock()
-		p.snapshotRefresh[language] = time.Now()
-		p.snapshotMu.Unlock()
 	default:
-		return
+		p.stopVM(ctx, vm)
+		atomic.AddInt32(&langPool.total, -1)
+		atomic.AddInt64(&p.stats.TotalDestroyed, 1)
+	}
+
+	p.snapshotMu.Lock()
+	p.snapshotNext[language] = time.Now().Add(jitterInterval(p.config.SnapshotRefreshInterval, p.config.SnapshotJitter, rand.Float64()))
+	p.snapshotMu.Unlock()
+	if snapshot != nil {
+		p.metrics.snapshotAge(language, snapshot)
+	}
+	return snapshot, err
+}
+
+// jitterInterval scales interval by a factor in [1-jitter, 1+jitter), where
+// r is a uniform random number in [0, 1).
+func jitterInterval(interval time.Duration, jitter, r float64) time.Duration {
+	if interval <= 0 || jitter <= 0 {
+		return interval
+	}
+	return time.Duration(float64(interval) * (1 + jitter*(2*r-1)))
+}
+
+// CreateSnapshot snapshots an idle VM for language, booting one if none is
+// idle.
+func (p *VMPool) CreateSnapshot(ctx context.Context, language string) (*Snapshot, error) {
+	langPool, err := p.snapshotPool(language)
+	if err != nil {
+		return nil, err
+	}
+	return p.snapshot(ctx, language, langPool, true)
+}
+
+// RefreshSnapshots replaces the snapshots of language, or of every
+// language when it is empty: a new snapshot is taken and the ones it
+// supersedes are deleted.
+func (p *VMPool) RefreshSnapshots(ctx context.Context, language string) ([]*Snapshot, error) {
+	languages := []string{language}
+	if language == "" {
+		p.poolsMu.RLock()
+		languages = languages[:0]
+		for lang := range p.pools {
+			languages = append(languages, lang)
+		}
+		p.poolsMu.RUnlock()
+		sort.Strings(languages)
+	}
+
+	var created []*Snapshot
+	var errs []error
+	for _, lang := range languages {
+		langPool, err := p.snapshotPool(lang)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		snapshot, err := p.snapshot(ctx, lang, langPool, true)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", lang, err))
+			continue
+		}
+		for _, old := range p.snapshotManager.ListSnapshots(lang) {
+			if old.ID == snapshot.ID {
+				continue
+			}
+			if err := p.snapshotManager.DeleteSnapshot(old.ID); err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", lang, err))
+			}
+		}
+		created = append(created, snapshot)
+	}
+	return created, errors.Join(errs...)
+}
+
+func (p *VMPool) snapshotPool(language string) (*languageVMPool, error) {
+	if p.snapshotManager == nil || !p.config.SnapshotsEnabled {
+		return nil, ErrSnapshotsDisabled
+	}
+	p.closedMu.RLock()
+	closed := p.closed
+	p.closedMu.RUnlock()
+	if closed {
+		return nil, errors.New("pool is closed")
+	}
+
+	p.poolsMu.RLock()
+	langPool, ok := p.pools[language]
+	p.poolsMu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("unsupported language: %s", language)
 	}
+	return langPool, nil
 }
 
 func (p *VMPool) latestSnapshot(language string) *Snapshot {
@@ -427,6 +544,7 @@ func (p *VMPool) Get(ctx context.Context, language string) (*MicroVM, error) {
 	case vm := <-langPool.available:
 		atomic.AddInt64(&p.stats.IdleVMs, -1)
 		atomic.AddInt64(&p.stats.ActiveVMs, 1)
+		p.metrics.poolRequest(language, true)
 		return vm, nil
 	case <-ctx.Done():
 		return nil, ctx.Err()
@@ -444,6 +562,7 @@ func (p *VMPool) Get(ctx context.Context, language string) (*MicroVM, error) {
 		case vm := <-langPool.available:
 			atomic.AddInt64(&p.stats.IdleVMs, -1)
 			atomic.AddInt64(&p.stats.ActiveVMs, 1)
+			p.metrics.poolRequest(language, true)
 			return vm, nil
 		case <-ctx.Done():
 			return nil, ctx.Err()
@@ -453,6 +572,7 @@ func (p *VMPool) Get(ctx context.Context, language string) (*MicroVM, error) {
 	}
 
 	// Create a new VM
+	p.metrics.poolRequest(language, false)
 	atomic.AddInt32(&langPool.creating, 1)
 	defer atomic.AddInt32(&langPool.creating, -1)
 
@@ -578,7 +698,10 @@ func (p *VMPool) createVM(ctx context.Context, language string) (*MicroVM, error
 	// Try to load from snapshot if enabled.
 	if p.snapshotManager != nil && p.config.SnapshotsEnabled {
 		if snapshot := p.latestSnapshot(language); snapshot != nil {
-			if vm, err := p.snapshotManager.LoadSnapshot(ctx, snapshot.ID, vmConfig); err == nil {
+			start := time.Now()
+			vm, err := p.snapshotManager.LoadSnapshot(ctx, snapshot.ID, vmConfig)
+			p.metrics.snapshotRestore(language, time.Since(start), err)
+			if err == nil {
 				p.poolsMu.RLock()
 				langPool, ok := p.pools[language]
 				p.poolsMu.RUnlock()
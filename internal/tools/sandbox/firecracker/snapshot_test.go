@@ -0,0 +1,83 @@
+//go:build linux
+
+package firecracker
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestJitterInterval(t *testing.T) {
+	interval := 30 * time.Minute
+	if got := jitterInterval(interval, 0.1, 0); got != 27*time.Minute {
+		t.Fatalf("jitterInterval(r=0) = %v, want 27m", got)
+	}
+	if got := jitterInterval(interval, 0.1, 0.5); got != interval {
+		t.Fatalf("jitterInterval(r=0.5) = %v, want %v", got, interval)
+	}
+	if got := jitterInterval(interval, 0, 0.9); got != interval {
+		t.Fatalf("jitterInterval without jitter = %v, want %v", got, interval)
+	}
+}
+
+func writeTestSnapshot(t *testing.T, dir, id, language string, createdAt time.Time, size int) {
+	t.Helper()
+	snapshotDir := filepath.Join(dir, id)
+	if err := os.MkdirAll(snapshotDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	memoryPath := filepath.Join(snapshotDir, "memory.snap")
+	if err := os.WriteFile(memoryPath, make([]byte, size), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(Snapshot{ID: id, Type: SnapshotTypeFull, Language: language, MemoryPath: memoryPath, CreatedAt: createdAt})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(snapshotDir, "snapshot.json"), data, 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestBackendSnapshots(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Now()
+	writeTestSnapshot(t, dir, "python-old", "python", now.Add(-2*time.Hour), 16)
+	writeTestSnapshot(t, dir, "python-new", "python", now.Add(-time.Minute), 32)
+	writeTestSnapshot(t, dir, "bash-1", "bash", now, 8)
+
+	manager, err := NewSnapshotManager(dir)
+	if err != nil {
+		t.Fatalf("NewSnapshotManager() error = %v", err)
+	}
+	backend := &Backend{snapshotManager: manager}
+
+	snapshots, err := backend.Snapshots("python")
+	if err != nil {
+		t.Fatalf("Snapshots() error = %v", err)
+	}
+	if len(snapshots) != 2 || snapshots[0].ID != "python-new" || snapshots[0].Size != 32 {
+		t.Fatalf("expected newest python snapshot first with its disk size, got %+v", snapshots)
+	}
+
+	if err := backend.DeleteSnapshot("python-old"); err != nil {
+		t.Fatalf("DeleteSnapshot() error = %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "python-old")); !os.IsNotExist(err) {
+		t.Fatalf("expected snapshot directory to be removed, got %v", err)
+	}
+	if err := backend.DeleteSnapshot("python-old"); !errors.Is(err, ErrSnapshotNotFound) {
+		t.Fatalf("expected ErrSnapshotNotFound, got %v", err)
+	}
+	if all, _ := backend.Snapshots(""); len(all) != 2 {
+		t.Fatalf("expected two remaining snapshots, got %+v", all)
+	}
+
+	if _, err := (&Backend{}).Snapshots(""); !errors.Is(err, ErrSnapshotsDisabled) {
+		t.Fatalf("expected ErrSnapshotsDisabled, got %v", err)
+	}
+}
@@ -15,6 +15,30 @@ import (
 // ErrNotSupported is returned when firecracker operations are attempted on non-Linux platforms.
 var ErrNotSupported = errors.New("firecracker is only supported on Linux")
 
+var (
+	// ErrSnapshotsDisabled is returned by snapshot operations when
+	// snapshots are not enabled.
+	ErrSnapshotsDisabled = errors.New("firecracker snapshots are not enabled")
+
+	// ErrSnapshotNotFound is returned when a snapshot ID is unknown.
+	ErrSnapshotNotFound = errors.New("snapshot not found")
+)
+
+// SnapshotType represents the type of snapshot.
+type SnapshotType string
+
+// Snapshot represents a VM snapshot.
+type Snapshot struct {
+	ID         string       `json:"id"`
+	Type       SnapshotType `json:"type"`
+	Language   string       `json:"language"`
+	MemoryPath string       `json:"memory_path"`
+	StatePath  string       `json:"state_path"`
+	CreatedAt  time.Time    `json:"created_at"`
+	Size       int64        `json:"size"`
+	ParentID   string       `json:"parent_id,omitempty"`
+}
+
 // Backend implements the sandbox.RuntimeExecutor interface using Firecracker microVMs.
 // On non-Linux platforms, all operations return ErrNotSupported.
 type Backend struct{}
@@ -33,6 +57,7 @@ type BackendConfig struct {
 	EnableSnapshots         bool
 	SnapshotRefreshInterval time.Duration
 	SnapshotMaxAge          time.Duration
+	SnapshotJitter          float64
 }
 
 // PoolConfig contains configuration for the VM pool.
@@ -54,6 +79,7 @@ type PoolConfig struct {
 	SnapshotsEnabled        bool
 	SnapshotRefreshInterval time.Duration
 	SnapshotMaxAge          time.Duration
+	SnapshotJitter          float64
 }
 
 // PoolStats contains VM pool statistics.
@@ -165,6 +191,26 @@ func (b *Backend) Stats() BackendStats {
 	return BackendStats{}
 }
 
+// Snapshots returns the snapshots for language.
+func (b *Backend) Snapshots(language string) ([]Snapshot, error) {
+	return nil, ErrNotSupported
+}
+
+// CreateSnapshot snapshots a pooled VM for language.
+func (b *Backend) CreateSnapshot(ctx context.Context, language string) (Snapshot, error) {
+	return Snapshot{}, ErrNotSupported
+}
+
+// RefreshSnapshots replaces the snapshots of language.
+func (b *Backend) RefreshSnapshots(ctx context.Context, language string) ([]Snapshot, error) {
+	return nil, ErrNotSupported
+}
+
+// DeleteSnapshot removes a snapshot by ID.
+func (b *Backend) DeleteSnapshot(id string) error {
+	return ErrNotSupported
+}
+
 // FirecrackerExecutor wraps Backend to implement RuntimeExecutor interface.
 type FirecrackerExecutor struct {
 	backend  *Backend
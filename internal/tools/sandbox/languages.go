@@ -0,0 +1,154 @@
+package sandbox
+
+import (
+	"regexp"
+	"strings"
+)
+
+// languageAliases maps the names models commonly use to supported languages.
+var languageAliases = map[string]string{
+	"python":     "python",
+	"python3":    "python",
+	"py":         "python",
+	"nodejs":     "nodejs",
+	"node":       "nodejs",
+	"js":         "nodejs",
+	"javascript": "nodejs",
+	"go":         "go",
+	"golang":     "go",
+	"bash":       "bash",
+	"sh":         "bash",
+	"shell":      "bash",
+}
+
+// normalizeLanguage maps a language name or alias to a supported language.
+// Unknown names are returned lowercased so validation can report them.
+func normalizeLanguage(language string) string {
+	language = strings.ToLower(strings.TrimSpace(language))
+	if canonical, ok := languageAliases[language]; ok {
+		return canonical
+	}
+	return language
+}
+
+// fencedCodePattern matches code wrapped in a single Markdown code fence.
+var fencedCodePattern = regexp.MustCompile("(?s)^```([A-Za-z0-9_+-]*)[^\\n]*\\n(.*?)\\n?```$")
+
+// unwrapCodeFence strips a Markdown code fence wrapping the whole code and
+// returns the fence's info string as a language hint.
+func unwrapCodeFence(code string) (hint, body string) {
+	match := fencedCodePattern.FindStringSubmatch(strings.TrimSpace(code))
+	if match == nil {
+		return "", code
+	}
+	return match[1], match[2]
+}
+
+// languageSignals are line patterns that suggest a language. Each matching
+// line counts once toward its language.
+var languageSignals = map[string][]*regexp.Regexp{
+	"python": {
+		regexp.MustCompile(`^(def|class) \w+.*:$`),
+		regexp.MustCompile(`^(import \w+(\.\w+)*( as \w+)?|from [\w.]+ import .+)$`),
+		regexp.MustCompile(`^(if|elif|for|while|with|try|except)\b.*:$`),
+		regexp.MustCompile(`\bprint\(`),
+		regexp.MustCompile(`^if __name__ == .__main__.:$`),
+	},
+	"nodejs": {
+		regexp.MustCompile(`\bconsole\.(log|error)\(`),
+		regexp.MustCompile(`\brequire\(['"]`),
+		regexp.MustCompile(`^(const|let|var) \w+\s*=`),
+		regexp.MustCompile(`^(async )?function\b`),
+		regexp.MustCompile(`=>`),
+		regexp.MustCompile(`^import .+ from ['"]`),
+		regexp.MustCompile(`^(module\.)?exports\b`),
+	},
+	"go": {
+		regexp.MustCompile(`^func \w+\(`),
+		regexp.MustCompile(`\bfmt\.\w+\(`),
+		regexp.MustCompile(`:= `),
+		regexp.MustCompile(`^import \($`),
+	},
+	"bash": {
+		regexp.MustCompile(`^(echo|printf|cd|ls|cat|grep|awk|sed|export|set -\w+)\b`),
+		regexp.MustCompile(`^(fi|done|esac)$`),
+		regexp.MustCompile(`; (then|do)$`),
+		regexp.MustCompile(`\$\(|\$\{\w+|\$\w+`),
+	},
+}
+
+// detectLanguage guesses the language of code the model sent without one.
+// It prefers a fence hint, then a shebang or Go package clause, then the
+// language with the most matching lines. It returns "" when unsure.
+func detectLanguage(code, hint string) string {
+	if language := normalizeLanguage(hint); isValidLanguage(language) {
+		return language
+	}
+
+	lines := strings.Split(code, "\n")
+	for _, line := range lines {
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, "#!") {
+			return shebangLanguage(line)
+		}
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, "//") {
+			continue
+		}
+		if strings.HasPrefix(line, "package main") {
+			return "go"
+		}
+		break
+	}
+
+	scores := make(map[string]int, len(languageSignals))
+	for _, line := range lines {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, "//") {
+			continue
+		}
+		for language, patterns := range languageSignals {
+			for _, pattern := range patterns {
+				if pattern.MatchString(line) {
+					scores[language]++
+					break
+				}
+			}
+		}
+	}
+
+	best, bestScore, tied := "", 0, false
+	for _, language := range []string{"python", "nodejs", "go", "bash"} {
+		switch score := scores[language]; {
+		case score > bestScore:
+			best, bestScore, tied = language, score, false
+		case score == bestScore && score > 0:
+			tied = true
+		}
+	}
+	if tied {
+		return ""
+	}
+	return best
+}
+
+// shebangLanguage maps an interpreter line to a language.
+func shebangLanguage(line string) string {
+	fields := strings.Fields(strings.TrimPrefix(line, "#!"))
+	if len(fields) == 0 {
+		return ""
+	}
+	interpreter := fields[0]
+	if strings.HasSuffix(interpreter, "/env") && len(fields) > 1 {
+		interpreter = fields[1]
+	}
+	interpreter = interpreter[strings.LastIndex(interpreter, "/")+1:]
+	switch {
+	case strings.HasPrefix(interpreter, "python"):
+		return "python"
+	case interpreter == "node" || interpreter == "nodejs":
+		return "nodejs"
+	case interpreter == "bash" || interpreter == "sh":
+		return "bash"
+	}
+	return ""
+}
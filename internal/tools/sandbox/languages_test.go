@@ -0,0 +1,79 @@
+package sandbox
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestNormalizeLanguage(t *testing.T) {
+	tests := map[string]string{
+		"Python":     "python",
+		"py":         "python",
+		"JavaScript": "nodejs",
+		"node":       "nodejs",
+		"golang":     "go",
+		" sh ":       "bash",
+		"Ruby":       "ruby",
+	}
+	for input, want := range tests {
+		if got := normalizeLanguage(input); got != want {
+			t.Errorf("normalizeLanguage(%q) = %q, want %q", input, got, want)
+		}
+	}
+}
+
+func TestUnwrapCodeFence(t *testing.T) {
+	hint, body := unwrapCodeFence("```py\nprint(1)\n```\n")
+	if hint != "py" || body != "print(1)" {
+		t.Fatalf("unwrapCodeFence() = %q, %q", hint, body)
+	}
+	hint, body = unwrapCodeFence("echo ok")
+	if hint != "" || body != "echo ok" {
+		t.Fatalf("unwrapCodeFence() without a fence = %q, %q", hint, body)
+	}
+}
+
+func TestDetectLanguage(t *testing.T) {
+	tests := []struct {
+		name string
+		code string
+		hint string
+		want string
+	}{
+		{"fence hint", "x = 1", "javascript", "nodejs"},
+		{"python shebang", "#!/usr/bin/env python3\nx = 1", "", "python"},
+		{"bash shebang", "#!/bin/bash\nfoo", "", "bash"},
+		{"go package", "// demo\npackage main\n\nfunc main() {}", "", "go"},
+		{"python", "import pandas as pd\n\ndef load(path):\n    return pd.read_csv(path)\n\nprint(load('x.csv'))", "", "python"},
+		{"nodejs", "const fs = require('fs');\nconsole.log(fs.readdirSync('.'))", "", "nodejs"},
+		{"go without package", "x := 1\nfmt.Println(x)", "", "go"},
+		{"bash", "for f in *.log; do\n  grep -c ERROR \"$f\"\ndone", "", "bash"},
+		{"unknown", "hello world", "", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := detectLanguage(tt.code, tt.hint); got != tt.want {
+				t.Errorf("detectLanguage() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExecutor_UndetectedLanguage(t *testing.T) {
+	executor, err := NewExecutor()
+	if err != nil {
+		t.Fatalf("Failed to create executor: %v", err)
+	}
+	defer executor.Close()
+
+	paramsJSON, _ := json.Marshal(map[string]string{"code": "hello world"})
+	result, err := executor.Execute(context.Background(), paramsJSON)
+	if err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+	if !result.IsError || !strings.Contains(result.Content, "Could not detect the language") {
+		t.Fatalf("expected a detection error, got: %s", result.Content)
+	}
+}
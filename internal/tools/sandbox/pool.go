@@ -174,9 +174,9 @@ func (p *Pool) Close() error {
 func (p *Pool) createExecutor(language string) (RuntimeExecutor, error) {
 	switch p.config.Backend {
 	case BackendDocker:
-		return newDockerExecutor(language, p.config.DefaultCPU, p.config.DefaultMemory, p.config.NetworkEnabled)
+		return newDockerExecutor(language, p.config.dockerImage(language), p.config.DefaultCPU, p.config.DefaultMemory, p.config.NetworkEnabled)
 	case BackendFirecracker:
-		return newFirecrackerExecutor(language, p.config.DefaultCPU, p.config.DefaultMemory, p.config.NetworkEnabled)
+		return newFirecrackerExecutor(language, p.config.dockerImage(language), p.config.DefaultCPU, p.config.DefaultMemory, p.config.NetworkEnabled)
 	case BackendDaytona:
 		return newDaytonaExecutor(language, p.config)
 	default:
@@ -199,8 +199,9 @@ type firecrackerBackendWrapper struct {
 	}
 }
 
-// newFirecrackerExecutor creates a new Firecracker-based executor.
-func newFirecrackerExecutor(language string, cpuLimit, memLimit int, networkEnabled bool) (RuntimeExecutor, error) {
+// newFirecrackerExecutor creates a new Firecracker-based executor. image is
+// used when it falls back to Docker.
+func newFirecrackerExecutor(language, image string, cpuLimit, memLimit int, networkEnabled bool) (RuntimeExecutor, error) {
 	// Lazy initialization of shared backend
 	firecrackerBackendOnce.Do(func() {
 		// Import the firecracker package at runtime to avoid circular imports
@@ -210,7 +211,7 @@ func newFirecrackerExecutor(language string, cpuLimit, memLimit int, networkEnab
 
 	if firecrackerBackendErr != nil {
 		// Fall back to Docker if Firecracker is not available
-		return newDockerExecutor(language, cpuLimit, memLimit, networkEnabled)
+		return newDockerExecutor(language, image, cpuLimit, memLimit, networkEnabled)
 	}
 
 	return &firecrackerExecutorWrapper{
@@ -0,0 +1,587 @@
+// Package rootfs builds the guest root filesystems and kernel that the
+// Firecracker sandbox backend boots.
+//
+// Each language image starts from a container image exported with Docker,
+// gets the Nexus guest agent installed as its init process, and is packed
+// into an ext4 image with mkfs.ext4. Outputs are versioned; the stable names
+// the backend reads (rootfs-<language>.ext4, vmlinux) are symlinks to the
+// newest build, and every build writes a manifest and a SHA256SUMS file.
+package rootfs
+
+import (
+	"archive/tar"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"time"
+)
+
+// GuestAgentPackage is the Go package of the guest agent, built when no
+// prebuilt agent is supplied.
+const GuestAgentPackage = "github.com/haasonsaas/nexus/internal/tools/sandbox/firecracker/guest-agent"
+
+// guestAgentPath is where the guest agent is installed in the rootfs.
+const guestAgentPath = "/usr/local/bin/nexus-guest-agent"
+
+// DefaultImages are the container images each language rootfs starts from.
+var DefaultImages = map[string]string{
+	"python": "python:3.12-alpine",
+	"nodejs": "node:20-alpine",
+	"go":     "golang:1.24-alpine",
+	"bash":   "bash:5.2",
+}
+
+// initScript boots the guest: the kernel runs /sbin/init, which mounts the
+// pseudo filesystems and hands PID 1 to the guest agent.
+const initScript = `#!/bin/sh
+mount -t proc proc /proc
+mount -t sysfs sysfs /sys
+mount -t devtmpfs devtmpfs /dev 2>/dev/null
+mkdir -p /dev/pts /tmp /workspace
+mount -t devpts devpts /dev/pts
+mount -t tmpfs tmpfs /tmp
+exec ` + guestAgentPath + `
+`
+
+// Options configures a build.
+type Options struct {
+	// Languages to build: python, nodejs (or node), go, bash.
+	Languages []string
+
+	// OutputDir receives the images, manifest, and checksums.
+	OutputDir string
+
+	// Version names the outputs; it defaults to the UTC build time.
+	Version string
+
+	// Arch is the guest architecture (amd64 or arm64); it defaults to the
+	// host architecture.
+	Arch string
+
+	// Images overrides DefaultImages per language.
+	Images map[string]string
+
+	// SizeMB fixes the image size. When zero each image is sized to its
+	// contents plus headroom.
+	SizeMB int
+
+	// GuestAgent is a prebuilt guest agent binary. When empty the agent is
+	// built from source with the Go toolchain.
+	GuestAgent string
+
+	// KernelURL is downloaded as the guest kernel when set.
+	KernelURL string
+
+	// KernelSHA256 verifies the downloaded kernel when set.
+	KernelSHA256 string
+
+	// Progress receives one line per build step.
+	Progress io.Writer
+}
+
+// Artifact is one output file.
+type Artifact struct {
+	Language string `json:"language,omitempty"`
+	Image    string `json:"image,omitempty"`
+	Source   string `json:"source,omitempty"`
+	Path     string `json:"path"`
+	Link     string `json:"link"`
+	SHA256   string `json:"sha256"`
+	Size     int64  `json:"size"`
+}
+
+// Manifest records a build. It is written to manifest-<version>.json.
+type Manifest struct {
+	Version          string     `json:"version"`
+	Arch             string     `json:"arch"`
+	CreatedAt        time.Time  `json:"created_at"`
+	GuestAgentSHA256 string     `json:"guest_agent_sha256"`
+	Kernel           *Artifact  `json:"kernel,omitempty"`
+	RootFS           []Artifact `json:"rootfs"`
+	ManifestPath     string     `json:"-"`
+	ChecksumsPath    string     `json:"-"`
+}
+
+// Builder builds rootfs images.
+type Builder struct {
+	opts Options
+
+	// run executes an external command with extra environment variables
+	// and returns its stdout.
+	run func(ctx context.Context, env []string, name string, args ...string) ([]byte, error)
+
+	client *http.Client
+	now    func() time.Time
+}
+
+// NewBuilder returns a Builder for opts.
+func NewBuilder(opts Options) *Builder {
+	return &Builder{
+		opts:   opts,
+		run:    runCommand,
+		client: &http.Client{Timeout: 10 * time.Minute},
+		now:    time.Now,
+	}
+}
+
+// NormalizeLanguages resolves aliases and removes duplicates. It returns an
+// error for unknown languages.
+func NormalizeLanguages(languages []string) ([]string, error) {
+	seen := make(map[string]bool)
+	var out []string
+	for _, raw := range languages {
+		lang := strings.ToLower(strings.TrimSpace(raw))
+		switch lang {
+		case "":
+			continue
+		case "node", "javascript", "js":
+			lang = "nodejs"
+		case "python3", "py":
+			lang = "python"
+		case "golang":
+			lang = "go"
+		case "sh", "shell":
+			lang = "bash"
+		}
+		if _, ok := DefaultImages[lang]; !ok {
+			return nil, fmt.Errorf("unsupported language %q (supported: bash, go, nodejs, python)", raw)
+		}
+		if !seen[lang] {
+			seen[lang] = true
+			out = append(out, lang)
+		}
+	}
+	if len(out) == 0 {
+		return nil, errors.New("no languages to build")
+	}
+	return out, nil
+}
+
+// Build builds the configured images and kernel and returns the manifest.
+func (b *Builder) Build(ctx context.Context) (*Manifest, error) {
+	languages, err := NormalizeLanguages(b.opts.Languages)
+	if err != nil {
+		return nil, err
+	}
+	if strings.TrimSpace(b.opts.OutputDir) == "" {
+		return nil, errors.New("output directory is required")
+	}
+	arch := b.opts.Arch
+	if arch == "" {
+		arch = runtime.GOARCH
+	}
+	if arch != "amd64" && arch != "arm64" {
+		return nil, fmt.Errorf("unsupported architecture %q (supported: amd64, arm64)", arch)
+	}
+	createdAt := b.now().UTC()
+	version := b.opts.Version
+	if version == "" {
+		version = createdAt.Format("20060102T150405Z")
+	}
+	if strings.ContainsAny(version, `/\`) {
+		return nil, fmt.Errorf("invalid version %q", version)
+	}
+	if err := os.MkdirAll(b.opts.OutputDir, 0o755); err != nil {
+		return nil, fmt.Errorf("create output directory: %w", err)
+	}
+	workDir, err := os.MkdirTemp(b.opts.OutputDir, ".build-"+version+"-")
+	if err != nil {
+		return nil, fmt.Errorf("create work directory: %w", err)
+	}
+	defer os.RemoveAll(workDir)
+
+	manifest := &Manifest{Version: version, Arch: arch, CreatedAt: createdAt}
+
+	agentPath, err := b.guestAgent(ctx, workDir, arch)
+	if err != nil {
+		return nil, err
+	}
+	if manifest.GuestAgentSHA256, _, err = fileSHA256(agentPath); err != nil {
+		return nil, err
+	}
+
+	if b.opts.KernelURL != "" {
+		kernel, err := b.fetchKernel(ctx, version)
+		if err != nil {
+			return nil, err
+		}
+		manifest.Kernel = kernel
+	}
+
+	for _, lang := range languages {
+		artifact, err := b.buildLanguage(ctx, workDir, lang, version, arch, agentPath)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", lang, err)
+		}
+		manifest.RootFS = append(manifest.RootFS, *artifact)
+	}
+
+	if err := b.writeManifest(manifest); err != nil {
+		return nil, err
+	}
+	return manifest, nil
+}
+
+// guestAgent returns the path of the guest agent binary, building it when
+// no prebuilt agent was given.
+func (b *Builder) guestAgent(ctx context.Context, workDir, arch string) (string, error) {
+	if b.opts.GuestAgent != "" {
+		if _, err := os.Stat(b.opts.GuestAgent); err != nil {
+			return "", fmt.Errorf("guest agent: %w", err)
+		}
+		return b.opts.GuestAgent, nil
+	}
+	b.progress("Building guest agent for linux/%s", arch)
+	out := filepath.Join(workDir, "nexus-guest-agent")
+	env := []string{"CGO_ENABLED=0", "GOOS=linux", "GOARCH=" + arch}
+	if _, err := b.run(ctx, env, "go", "build", "-trimpath", "-ldflags", "-s -w", "-o", out, GuestAgentPackage); err != nil {
+		return "", fmt.Errorf("build guest agent (run from a Nexus checkout or pass a prebuilt agent): %w", err)
+	}
+	return out, nil
+}
+
+// fetchKernel downloads the kernel, verifies its checksum, and links
+// vmlinux to it.
+func (b *Builder) fetchKernel(ctx context.Context, version string) (*Artifact, error) {
+	b.progress("Fetching kernel %s", b.opts.KernelURL)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, b.opts.KernelURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("kernel: %w", err)
+	}
+	resp, err := b.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("fetch kernel: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("fetch kernel: %s", resp.Status)
+	}
+
+	path := filepath.Join(b.opts.OutputDir, "vmlinux-"+version)
+	file, err := os.Create(path)
+	if err != nil {
+		return nil, fmt.Errorf("write kernel: %w", err)
+	}
+	hash := sha256.New()
+	size, copyErr := io.Copy(io.MultiWriter(file, hash), resp.Body)
+	if err := errors.Join(copyErr, file.Close()); err != nil {
+		os.Remove(path)
+		return nil, fmt.Errorf("write kernel: %w", err)
+	}
+	sum := hex.EncodeToString(hash.Sum(nil))
+	if want := strings.ToLower(strings.TrimSpace(b.opts.KernelSHA256)); want != "" && want != sum {
+		os.Remove(path)
+		return nil, fmt.Errorf("kernel checksum mismatch: got %s, want %s", sum, want)
+	}
+	link := filepath.Join(b.opts.OutputDir, "vmlinux")
+	if err := replaceSymlink(filepath.Base(path), link); err != nil {
+		return nil, err
+	}
+	return &Artifact{Source: b.opts.KernelURL, Path: path, Link: link, SHA256: sum, Size: size}, nil
+}
+
+// buildLanguage exports the language's container image, installs the guest
+// agent, and packs the tree into an ext4 image.
+func (b *Builder) buildLanguage(ctx context.Context, workDir, lang, version, arch, agentPath string) (*Artifact, error) {
+	image := DefaultImages[lang]
+	if override := strings.TrimSpace(b.opts.Images[lang]); override != "" {
+		image = override
+	}
+	b.progress("Exporting %s for %s", image, lang)
+	out, err := b.run(ctx, nil, "docker", "create", "--platform", "linux/"+arch, image)
+	if err != nil {
+		return nil, fmt.Errorf("docker create %s: %w", image, err)
+	}
+	container := strings.TrimSpace(string(out))
+	tarPath := filepath.Join(workDir, lang+".tar")
+	_, exportErr := b.run(ctx, nil, "docker", "export", "-o", tarPath, container)
+	if _, err := b.run(ctx, nil, "docker", "rm", container); err != nil && exportErr == nil {
+		b.progress("Warning: remove container %s: %v", container, err)
+	}
+	if exportErr != nil {
+		return nil, fmt.Errorf("docker export %s: %w", image, exportErr)
+	}
+
+	tree := filepath.Join(workDir, lang)
+	if err := extractTar(tarPath, tree); err != nil {
+		return nil, fmt.Errorf("extract %s: %w", image, err)
+	}
+	os.Remove(tarPath)
+	if err := installGuest(tree, lang, agentPath); err != nil {
+		return nil, err
+	}
+
+	sizeMB := b.opts.SizeMB
+	if sizeMB <= 0 {
+		used, err := treeSize(tree)
+		if err != nil {
+			return nil, err
+		}
+		// ext4 metadata plus room for compiler caches and workspace files.
+		sizeMB = int(used*3/2/(1<<20)) + 256
+	}
+	path := filepath.Join(b.opts.OutputDir, fmt.Sprintf("rootfs-%s-%s.ext4", lang, version))
+	b.progress("Packing %s (%d MB)", filepath.Base(path), sizeMB)
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	if _, err := b.run(ctx, nil, "mkfs.ext4", "-q", "-F", "-L", "nexus-"+lang, "-E", "root_owner=0:0",
+		"-d", tree, path, fmt.Sprintf("%dM", sizeMB)); err != nil {
+		os.Remove(path)
+		return nil, fmt.Errorf("mkfs.ext4: %w", err)
+	}
+	os.RemoveAll(tree)
+
+	sum, size, err := fileSHA256(path)
+	if err != nil {
+		return nil, err
+	}
+	link := filepath.Join(b.opts.OutputDir, "rootfs-"+lang+".ext4")
+	if err := replaceSymlink(filepath.Base(path), link); err != nil {
+		return nil, err
+	}
+	return &Artifact{Language: lang, Image: image, Path: path, Link: link, SHA256: sum, Size: size}, nil
+}
+
+// installGuest installs the guest agent as the init process of tree.
+func installGuest(tree, lang, agentPath string) error {
+	agent, err := os.ReadFile(agentPath)
+	if err != nil {
+		return fmt.Errorf("read guest agent: %w", err)
+	}
+	files := map[string][]byte{
+		guestAgentPath: agent,
+		"/sbin/init":   []byte(initScript),
+	}
+	for name, data := range files {
+		path := filepath.Join(tree, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			return err
+		}
+		// /sbin/init is usually a symlink to busybox or systemd.
+		os.Remove(path)
+		if err := os.WriteFile(path, data, 0o755); err != nil {
+			return fmt.Errorf("install %s: %w", name, err)
+		}
+	}
+	for _, dir := range []string{"proc", "sys", "dev", "tmp", "workspace"} {
+		if err := os.MkdirAll(filepath.Join(tree, dir), 0o755); err != nil {
+			return err
+		}
+	}
+	// The guest agent runs programs with PATH=/usr/local/bin:/usr/bin:/bin;
+	// the Go toolchain lives in /usr/local/go/bin.
+	if lang == "go" {
+		link := filepath.Join(tree, "usr/local/bin/go")
+		if _, err := os.Lstat(link); os.IsNotExist(err) {
+			if err := os.Symlink("/usr/local/go/bin/go", link); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// writeManifest writes manifest-<version>.json and SHA256SUMS-<version>.
+func (b *Builder) writeManifest(manifest *Manifest) error {
+	artifacts := append([]Artifact(nil), manifest.RootFS...)
+	if manifest.Kernel != nil {
+		artifacts = append(artifacts, *manifest.Kernel)
+	}
+	sort.Slice(artifacts, func(i, j int) bool { return artifacts[i].Path < artifacts[j].Path })
+	var sums strings.Builder
+	for _, artifact := range artifacts {
+		fmt.Fprintf(&sums, "%s  %s\n", artifact.SHA256, filepath.Base(artifact.Path))
+	}
+	manifest.ChecksumsPath = filepath.Join(b.opts.OutputDir, "SHA256SUMS-"+manifest.Version)
+	if err := os.WriteFile(manifest.ChecksumsPath, []byte(sums.String()), 0o644); err != nil {
+		return fmt.Errorf("write checksums: %w", err)
+	}
+
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return err
+	}
+	manifest.ManifestPath = filepath.Join(b.opts.OutputDir, "manifest-"+manifest.Version+".json")
+	if err := os.WriteFile(manifest.ManifestPath, append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("write manifest: %w", err)
+	}
+	return nil
+}
+
+func (b *Builder) progress(format string, args ...any) {
+	if b.opts.Progress != nil {
+		fmt.Fprintf(b.opts.Progress, format+"\n", args...)
+	}
+}
+
+// extractTar unpacks the tarball at src into dir, refusing entries that
+// escape it.
+func extractTar(src, dir string) error {
+	file, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	root, err := filepath.EvalSymlinks(dir)
+	if err != nil {
+		return err
+	}
+	reader := tar.NewReader(file)
+	for {
+		header, err := reader.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		name := filepath.Clean("/" + header.Name)
+		if name == "/" {
+			continue
+		}
+		path := filepath.Join(root, name)
+		if err := checkParent(root, path); err != nil {
+			return fmt.Errorf("%s: %w", header.Name, err)
+		}
+		mode := fs.FileMode(header.Mode).Perm()
+		switch header.Typeflag {
+		case tar.TypeDir:
+			err = os.MkdirAll(path, mode|0o700)
+		case tar.TypeReg:
+			err = writeTarFile(path, reader, mode)
+		case tar.TypeSymlink:
+			if err = os.MkdirAll(filepath.Dir(path), 0o755); err == nil {
+				os.Remove(path)
+				err = os.Symlink(header.Linkname, path)
+			}
+		case tar.TypeLink:
+			target := filepath.Join(root, filepath.Clean("/"+header.Linkname))
+			if err = checkParent(root, target); err != nil {
+				break
+			}
+			if err = os.MkdirAll(filepath.Dir(path), 0o755); err == nil {
+				os.Remove(path)
+				err = os.Link(target, path)
+			}
+		default:
+			// Device nodes and FIFOs need privileges; devtmpfs provides /dev.
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("%s: %w", header.Name, err)
+		}
+	}
+}
+
+// checkParent rejects paths whose parent resolves outside root, which
+// happens when an earlier entry turned an ancestor into a symlink.
+func checkParent(root, path string) error {
+	parent := filepath.Dir(path)
+	for {
+		resolved, err := filepath.EvalSymlinks(parent)
+		if err == nil {
+			if resolved != root && !strings.HasPrefix(resolved, root+string(filepath.Separator)) {
+				return errors.New("path escapes the image through a symlink")
+			}
+			return nil
+		}
+		if !os.IsNotExist(err) {
+			return err
+		}
+		parent = filepath.Dir(parent)
+	}
+}
+
+func writeTarFile(path string, r io.Reader, mode fs.FileMode) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	os.Remove(path)
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, mode|0o200)
+	if err != nil {
+		return err
+	}
+	_, copyErr := io.Copy(file, r)
+	return errors.Join(copyErr, file.Close())
+}
+
+// treeSize returns the total size of the regular files under dir.
+func treeSize(dir string) (int64, error) {
+	var total int64
+	err := filepath.WalkDir(dir, func(_ string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.Type().IsRegular() {
+			info, err := entry.Info()
+			if err != nil {
+				return err
+			}
+			total += info.Size()
+		}
+		return nil
+	})
+	return total, err
+}
+
+// replaceSymlink atomically points link at target.
+func replaceSymlink(target, link string) error {
+	tmp := link + ".tmp"
+	os.Remove(tmp)
+	if err := os.Symlink(target, tmp); err != nil {
+		return fmt.Errorf("link %s: %w", filepath.Base(link), err)
+	}
+	if err := os.Rename(tmp, link); err != nil {
+		os.Remove(tmp)
+		return fmt.Errorf("link %s: %w", filepath.Base(link), err)
+	}
+	return nil
+}
+
+func fileSHA256(path string) (string, int64, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", 0, err
+	}
+	defer file.Close()
+	hash := sha256.New()
+	size, err := io.Copy(hash, file)
+	if err != nil {
+		return "", 0, err
+	}
+	return hex.EncodeToString(hash.Sum(nil)), size, nil
+}
+
+func runCommand(ctx context.Context, env []string, name string, args ...string) ([]byte, error) {
+	cmd := exec.CommandContext(ctx, name, args...)
+	if len(env) > 0 {
+		cmd.Env = append(os.Environ(), env...)
+	}
+	var stderr strings.Builder
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return out, fmt.Errorf("%w: %s", err, msg)
+		}
+		return out, err
+	}
+	return out, nil
+}
@@ -0,0 +1,215 @@
+package rootfs
+
+import (
+	"archive/tar"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fakeTools stands in for docker and mkfs.ext4. Exports contain a busybox
+// style /sbin/init symlink; mkfs writes the file list of the tree it packs.
+type fakeTools struct {
+	t        *testing.T
+	entries  []*tar.Header
+	commands []string
+}
+
+func (f *fakeTools) run(_ context.Context, _ []string, name string, args ...string) ([]byte, error) {
+	f.commands = append(f.commands, name+" "+strings.Join(args, " "))
+	switch {
+	case name == "docker" && args[0] == "create":
+		return []byte("container-1\n"), nil
+	case name == "docker" && args[0] == "export":
+		return nil, f.writeTar(args[2])
+	case name == "docker" && args[0] == "rm":
+		return nil, nil
+	case name == "mkfs.ext4":
+		var tree, image string
+		for i, arg := range args {
+			if arg == "-d" {
+				tree, image = args[i+1], args[i+2]
+			}
+		}
+		var files []string
+		err := filepath.WalkDir(tree, func(path string, _ os.DirEntry, err error) error {
+			if err == nil {
+				rel, _ := filepath.Rel(tree, path)
+				files = append(files, rel)
+			}
+			return err
+		})
+		if err != nil {
+			return nil, err
+		}
+		return nil, os.WriteFile(image, []byte(strings.Join(files, "\n")), 0o644)
+	}
+	return nil, fmt.Errorf("unexpected command %s", name)
+}
+
+func (f *fakeTools) writeTar(path string) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	tw := tar.NewWriter(file)
+	for _, header := range f.entries {
+		if err := tw.WriteHeader(header); err != nil {
+			return err
+		}
+		if header.Typeflag == tar.TypeReg {
+			if _, err := tw.Write(make([]byte, header.Size)); err != nil {
+				return err
+			}
+		}
+	}
+	return tw.Close()
+}
+
+func newTestBuilder(t *testing.T, opts Options, tools *fakeTools) *Builder {
+	t.Helper()
+	agent := filepath.Join(t.TempDir(), "agent")
+	if err := os.WriteFile(agent, []byte("agent-binary"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	opts.GuestAgent = agent
+	builder := NewBuilder(opts)
+	builder.run = tools.run
+	builder.now = func() time.Time { return time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC) }
+	return builder
+}
+
+func TestBuild(t *testing.T) {
+	kernel := []byte("vmlinux-bytes")
+	kernelSum := sha256.Sum256(kernel)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write(kernel) //nolint:errcheck
+	}))
+	defer srv.Close()
+
+	out := t.TempDir()
+	tools := &fakeTools{t: t, entries: []*tar.Header{
+		{Name: "usr/local/bin/", Typeflag: tar.TypeDir, Mode: 0o755},
+		{Name: "usr/local/bin/python3", Typeflag: tar.TypeReg, Mode: 0o755, Size: 4},
+		{Name: "sbin/init", Typeflag: tar.TypeSymlink, Linkname: "/bin/busybox"},
+	}}
+	builder := newTestBuilder(t, Options{
+		Languages:    []string{"python", "node", "python"},
+		OutputDir:    out,
+		Arch:         "amd64",
+		KernelURL:    srv.URL + "/vmlinux",
+		KernelSHA256: hex.EncodeToString(kernelSum[:]),
+	}, tools)
+
+	manifest, err := builder.Build(context.Background())
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+	if manifest.Version != "20261016T120000Z" || len(manifest.RootFS) != 2 || manifest.RootFS[1].Language != "nodejs" {
+		t.Fatalf("unexpected manifest %+v", manifest)
+	}
+	if manifest.RootFS[1].Image != "node:20-alpine" || !strings.Contains(strings.Join(tools.commands, "\n"), "docker create --platform linux/amd64 node:20-alpine") {
+		t.Fatalf("expected the node image to be exported, got %v", tools.commands)
+	}
+
+	target, err := os.Readlink(filepath.Join(out, "rootfs-python.ext4"))
+	if err != nil || target != "rootfs-python-20261016T120000Z.ext4" {
+		t.Fatalf("rootfs-python.ext4 -> %q, %v", target, err)
+	}
+	image, err := os.ReadFile(filepath.Join(out, target))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"usr/local/bin/nexus-guest-agent", "usr/local/bin/python3", "sbin/init", "workspace"} {
+		if !strings.Contains(string(image), want) {
+			t.Fatalf("expected %s in the image, got:\n%s", want, image)
+		}
+	}
+	if target, _ := os.Readlink(filepath.Join(out, "vmlinux")); target != "vmlinux-20261016T120000Z" {
+		t.Fatalf("vmlinux -> %q", target)
+	}
+
+	sums, err := os.ReadFile(filepath.Join(out, "SHA256SUMS-20261016T120000Z"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	imageSum := sha256.Sum256(image)
+	for _, want := range []string{
+		hex.EncodeToString(imageSum[:]) + "  rootfs-python-20261016T120000Z.ext4",
+		hex.EncodeToString(kernelSum[:]) + "  vmlinux-20261016T120000Z",
+	} {
+		if !strings.Contains(string(sums), want) {
+			t.Fatalf("expected %q in SHA256SUMS:\n%s", want, sums)
+		}
+	}
+	data, err := os.ReadFile(manifest.ManifestPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var written Manifest
+	if err := json.Unmarshal(data, &written); err != nil || written.Kernel == nil || len(written.RootFS) != 2 {
+		t.Fatalf("unexpected manifest file %s: %v", data, err)
+	}
+	if entries, _ := filepath.Glob(filepath.Join(out, ".build-*")); len(entries) != 0 {
+		t.Fatalf("expected the work directory to be removed, found %v", entries)
+	}
+}
+
+func TestBuildRejectsBadKernelChecksum(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("tampered")) //nolint:errcheck
+	}))
+	defer srv.Close()
+
+	out := t.TempDir()
+	builder := newTestBuilder(t, Options{
+		Languages:    []string{"bash"},
+		OutputDir:    out,
+		KernelURL:    srv.URL,
+		KernelSHA256: strings.Repeat("0", 64),
+	}, &fakeTools{t: t})
+	if _, err := builder.Build(context.Background()); err == nil || !strings.Contains(err.Error(), "kernel checksum mismatch") {
+		t.Fatalf("expected a checksum error, got %v", err)
+	}
+	if _, err := os.Lstat(filepath.Join(out, "vmlinux")); !os.IsNotExist(err) {
+		t.Fatalf("expected no vmlinux link, got %v", err)
+	}
+}
+
+func TestExtractTarRejectsSymlinkEscape(t *testing.T) {
+	tools := &fakeTools{t: t, entries: []*tar.Header{
+		{Name: "lib", Typeflag: tar.TypeSymlink, Linkname: t.TempDir()},
+		{Name: "lib/evil", Typeflag: tar.TypeReg, Mode: 0o644, Size: 1},
+	}}
+	tarPath := filepath.Join(t.TempDir(), "image.tar")
+	if err := tools.writeTar(tarPath); err != nil {
+		t.Fatal(err)
+	}
+	err := extractTar(tarPath, filepath.Join(t.TempDir(), "tree"))
+	if err == nil || !strings.Contains(err.Error(), "escapes the image") {
+		t.Fatalf("expected an escape error, got %v", err)
+	}
+}
+
+func TestNormalizeLanguages(t *testing.T) {
+	got, err := NormalizeLanguages([]string{"Python", "node", "golang", " ", "sh"})
+	if err != nil || strings.Join(got, ",") != "python,nodejs,go,bash" {
+		t.Fatalf("NormalizeLanguages() = %v, %v", got, err)
+	}
+	if _, err := NormalizeLanguages([]string{"ruby"}); err == nil {
+		t.Fatal("expected an error for ruby")
+	}
+	if _, err := NormalizeLanguages(nil); err == nil {
+		t.Fatal("expected an error without languages")
+	}
+}
@@ -0,0 +1,70 @@
+// Command wasm-interpreter stands in for python.wasm and qjs.wasm in tests.
+// It runs the script named by its last argument, where each line is a
+// directive: "print <text>", "stdin", "write <path>", "loop", "alloc <MB>",
+// "missing", or "exit <code>". Other lines are ignored. Invoked with -c, as
+// by the import probe, it prints the module arguments named missing*.
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+)
+
+var sink [][]byte
+
+func main() {
+	for i, arg := range os.Args {
+		if arg != "-c" || i+1 >= len(os.Args) {
+			continue
+		}
+		var missing []string
+		for _, name := range os.Args[i+2:] {
+			if strings.HasPrefix(name, "missing") {
+				missing = append(missing, name)
+			}
+		}
+		fmt.Println(strings.Join(missing, " "))
+		return
+	}
+	script, err := os.ReadFile(os.Args[len(os.Args)-1])
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+	scanner := bufio.NewScanner(strings.NewReader(string(script)))
+	for scanner.Scan() {
+		directive, arg, _ := strings.Cut(scanner.Text(), " ")
+		switch directive {
+		case "print":
+			fmt.Println(arg)
+		case "stdin":
+			data, _ := io.ReadAll(os.Stdin)
+			fmt.Print(string(data))
+		case "write":
+			if err := os.WriteFile(arg, []byte("x"), 0o644); err != nil {
+				fmt.Println("write failed")
+			} else {
+				fmt.Println("write succeeded")
+			}
+		case "loop":
+			for {
+			}
+		case "alloc":
+			mb, _ := strconv.Atoi(arg)
+			for i := 0; i < mb; i++ {
+				sink = append(sink, make([]byte, 1<<20))
+			}
+			fmt.Println("allocated")
+		case "missing":
+			fmt.Fprintln(os.Stderr, "ModuleNotFoundError: No module named 'numpy'")
+			os.Exit(1)
+		case "exit":
+			code, _ := strconv.Atoi(arg)
+			os.Exit(code)
+		}
+	}
+}
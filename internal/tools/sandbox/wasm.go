@@ -0,0 +1,369 @@
+package sandbox
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/tetratelabs/wazero"
+	"github.com/tetratelabs/wazero/imports/wasi_snapshot_preview1"
+	"github.com/tetratelabs/wazero/sys"
+)
+
+// WasmConfig configures the WASI backend that runs small Python and
+// JavaScript snippets in-process instead of in a container or VM.
+type WasmConfig struct {
+	// PythonModule is a CPython build for WASI (python.wasm).
+	PythonModule string
+
+	// PythonHome is a host directory holding the Python standard library,
+	// mounted read-only at /usr/local. Leave it empty for builds that embed
+	// the standard library.
+	PythonHome string
+
+	// JavaScriptModule is a QuickJS build for WASI (qjs.wasm).
+	JavaScriptModule string
+
+	// MaxCodeBytes is the largest snippet routed to WASM. Larger code runs
+	// on the regular backend.
+	MaxCodeBytes int
+
+	// MemoryMB caps the linear memory of each snippet.
+	MemoryMB int
+
+	// Timeout caps each snippet's run time, below the request timeout.
+	Timeout time.Duration
+}
+
+const (
+	// wasmMaxOutput caps captured stdout and stderr per stream.
+	wasmMaxOutput = 1 << 20
+
+	// wasmPagesPerMB is the number of 64 KiB WebAssembly pages in a MiB.
+	wasmPagesPerMB = 16
+
+	// wasmWorkspace is where the snippet's workspace is mounted in the guest.
+	wasmWorkspace = "/workspace"
+)
+
+// errWasmUnavailable is returned before a snippet runs when it imports
+// something the WASM build lacks, such as a third-party package, and should
+// run on the regular backend instead.
+var errWasmUnavailable = errors.New("snippet needs the full sandbox")
+
+var (
+	// pythonImportRe matches "import a.b, c" and "from a.b import c".
+	pythonImportRe = regexp.MustCompile(`(?m)^[ \t]*(?:from[ \t]+([A-Za-z_][\w.]*)[ \t]+import\b|import[ \t]+([^#;\n]+))`)
+
+	// jsImportRe matches the specifier of static and dynamic imports.
+	jsImportRe = regexp.MustCompile(`(?:\bfrom|\bimport)\s*\(?\s*["']([^"']+)["']`)
+)
+
+// wasmPythonProbe prints the top-level modules named in argv that the
+// interpreter cannot find, without importing any of them.
+const wasmPythonProbe = `import importlib.util, sys
+print(" ".join(m for m in sys.argv[1:] if importlib.util.find_spec(m) is None))`
+
+// wasmRunner runs snippets in wazero. Each run instantiates a fresh module
+// from a precompiled one: no network, a read-only view of the snippet's
+// workspace, and capped memory and time.
+type wasmRunner struct {
+	runtime    wazero.Runtime
+	modules    map[string]wazero.CompiledModule
+	pythonHome string
+	maxCode    int
+	timeout    time.Duration
+
+	// pythonModules caches whether the Python build can find a top-level
+	// module, so each name is probed once.
+	mu            sync.Mutex
+	pythonModules map[string]bool
+}
+
+// newWasmRunner compiles the configured modules.
+func newWasmRunner(ctx context.Context, cfg WasmConfig) (*wasmRunner, error) {
+	if cfg.MaxCodeBytes <= 0 {
+		cfg.MaxCodeBytes = 4096
+	}
+	if cfg.MemoryMB <= 0 {
+		cfg.MemoryMB = 128
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = 5 * time.Second
+	}
+
+	runtimeConfig := wazero.NewRuntimeConfig().
+		WithMemoryLimitPages(uint32(cfg.MemoryMB * wasmPagesPerMB)).
+		WithCloseOnContextDone(true)
+	r := wazero.NewRuntimeWithConfig(ctx, runtimeConfig)
+	if _, err := wasi_snapshot_preview1.Instantiate(ctx, r); err != nil {
+		r.Close(ctx)
+		return nil, fmt.Errorf("instantiate wasi: %w", err)
+	}
+
+	runner := &wasmRunner{
+		runtime:       r,
+		modules:       make(map[string]wazero.CompiledModule),
+		pythonHome:    strings.TrimSpace(cfg.PythonHome),
+		maxCode:       cfg.MaxCodeBytes,
+		timeout:       cfg.Timeout,
+		pythonModules: make(map[string]bool),
+	}
+	for language, path := range map[string]string{"python": cfg.PythonModule, "nodejs": cfg.JavaScriptModule} {
+		path = strings.TrimSpace(path)
+		if path == "" {
+			continue
+		}
+		binary, err := os.ReadFile(path)
+		if err != nil {
+			r.Close(ctx)
+			return nil, fmt.Errorf("read %s wasm module: %w", language, err)
+		}
+		compiled, err := r.CompileModule(ctx, binary)
+		if err != nil {
+			r.Close(ctx)
+			return nil, fmt.Errorf("compile %s wasm module %s: %w", language, path, err)
+		}
+		runner.modules[language] = compiled
+	}
+	if len(runner.modules) == 0 {
+		r.Close(ctx)
+		return nil, errors.New("wasm backend needs a python or javascript module")
+	}
+	return runner, nil
+}
+
+// Eligible reports whether a request is small and self-contained enough to
+// run in WASM.
+func (w *wasmRunner) Eligible(params *ExecuteParams) bool {
+	if w == nil || len(params.Files) > 0 || len(params.Code) > w.maxCode {
+		return false
+	}
+	_, ok := w.modules[params.Language]
+	return ok
+}
+
+// Run executes the snippet in workspace. It returns errWasmUnavailable,
+// before any of the snippet runs, when the snippet imports a module the WASM
+// build lacks. A snippet that still fails on a missing module at run time,
+// for example through a dynamic import, is not retried: its partial output
+// is returned with an error, since running it again elsewhere would repeat
+// whatever it already did.
+func (w *wasmRunner) Run(ctx context.Context, params *ExecuteParams, workspace string) (*ExecuteResult, error) {
+	compiled, ok := w.modules[params.Language]
+	if !ok {
+		return nil, errWasmUnavailable
+	}
+	ctx, cancel := context.WithTimeout(ctx, w.timeout)
+	defer cancel()
+
+	available, err := w.importsAvailable(ctx, compiled, params, workspace)
+	if err != nil {
+		return nil, err
+	}
+	if !available {
+		return nil, errWasmUnavailable
+	}
+
+	mainFile := wasmWorkspace + "/" + getMainFilename(params.Language)
+	var args []string
+	switch params.Language {
+	case "python":
+		args = []string{"python", "-B", mainFile}
+	case "nodejs":
+		args = []string{"qjs", "--std", mainFile}
+	}
+	result, err := w.instantiate(ctx, compiled, params.Language, args, params.Stdin, workspace)
+	if err != nil {
+		return nil, err
+	}
+	if result.ExitCode != 0 && result.Error == "" && missingModule(params.Language, result.Stderr) {
+		result.Error = "a module the snippet imports is not available in the WASM sandbox"
+	}
+	return result, nil
+}
+
+// importsAvailable reports whether every module the snippet imports can be
+// loaded by the WASM build. QuickJS only provides its std and os modules,
+// and the Python build is asked which top-level modules it can find.
+func (w *wasmRunner) importsAvailable(ctx context.Context, compiled wazero.CompiledModule, params *ExecuteParams, workspace string) (bool, error) {
+	switch params.Language {
+	case "nodejs":
+		for _, match := range jsImportRe.FindAllStringSubmatch(params.Code, -1) {
+			if match[1] != "std" && match[1] != "os" {
+				return false, nil
+			}
+		}
+		return true, nil
+	case "python":
+		var unknown []string
+		w.mu.Lock()
+		for _, name := range pythonImports(params.Code) {
+			found, probed := w.pythonModules[name]
+			if probed && !found {
+				w.mu.Unlock()
+				return false, nil
+			}
+			if !probed {
+				unknown = append(unknown, name)
+			}
+		}
+		w.mu.Unlock()
+		if len(unknown) == 0 {
+			return true, nil
+		}
+
+		args := append([]string{"python", "-B", "-c", wasmPythonProbe}, unknown...)
+		probe, err := w.instantiate(ctx, compiled, params.Language, args, "", workspace)
+		if err != nil {
+			return false, err
+		}
+		if probe.ExitCode != 0 || probe.Timeout {
+			// The probe itself failed; let the regular backend run it.
+			return false, nil
+		}
+		missing := make(map[string]bool)
+		for _, name := range strings.Fields(probe.Stdout) {
+			missing[name] = true
+		}
+		w.mu.Lock()
+		for _, name := range unknown {
+			w.pythonModules[name] = !missing[name]
+		}
+		w.mu.Unlock()
+		return len(missing) == 0, nil
+	}
+	return true, nil
+}
+
+// pythonImports returns the distinct top-level modules imported by code.
+// Relative imports are skipped.
+func pythonImports(code string) []string {
+	var names []string
+	seen := make(map[string]bool)
+	add := func(name string) {
+		name, _, _ = strings.Cut(name, ".")
+		if name == "" || seen[name] {
+			return
+		}
+		seen[name] = true
+		names = append(names, name)
+	}
+	for _, match := range pythonImportRe.FindAllStringSubmatch(code, -1) {
+		if match[1] != "" {
+			add(match[1])
+			continue
+		}
+		for _, part := range strings.Split(match[2], ",") {
+			if fields := strings.Fields(strings.Trim(part, "()\\")); len(fields) > 0 {
+				add(fields[0])
+			}
+		}
+	}
+	return names
+}
+
+// instantiate runs the compiled interpreter with args against workspace.
+func (w *wasmRunner) instantiate(ctx context.Context, compiled wazero.CompiledModule, language string, args []string, stdin, workspace string) (*ExecuteResult, error) {
+	fsConfig := wazero.NewFSConfig().WithReadOnlyDirMount(workspace, wasmWorkspace)
+	var env [][2]string
+	if language == "python" {
+		env = append(env, [2]string{"PYTHONDONTWRITEBYTECODE", "1"})
+		if w.pythonHome != "" {
+			fsConfig = fsConfig.WithReadOnlyDirMount(w.pythonHome, "/usr/local")
+			env = append(env, [2]string{"PYTHONHOME", "/usr/local"})
+		}
+	}
+
+	stdout := &limitedBuffer{limit: wasmMaxOutput}
+	stderr := &limitedBuffer{limit: wasmMaxOutput}
+	moduleConfig := wazero.NewModuleConfig().
+		WithName("").
+		WithArgs(args...).
+		WithStdin(strings.NewReader(stdin)).
+		WithStdout(stdout).
+		WithStderr(stderr).
+		WithFSConfig(fsConfig).
+		WithSysWalltime().
+		WithSysNanotime().
+		WithRandSource(rand.Reader)
+	for _, kv := range env {
+		moduleConfig = moduleConfig.WithEnv(kv[0], kv[1])
+	}
+
+	result := &ExecuteResult{}
+	mod, err := w.runtime.InstantiateModule(ctx, compiled, moduleConfig)
+	if mod != nil {
+		_ = mod.Close(context.Background())
+	}
+	result.Stdout, result.Stderr = stdout.String(), stderr.String()
+	if err != nil {
+		var exitErr *sys.ExitError
+		switch {
+		case errors.As(err, &exitErr) && exitErr.ExitCode() == sys.ExitCodeDeadlineExceeded:
+			result.Timeout = true
+			result.Error = "Execution timeout"
+		case errors.As(err, &exitErr) && exitErr.ExitCode() == sys.ExitCodeContextCanceled:
+			return nil, ctx.Err()
+		case errors.As(err, &exitErr):
+			result.ExitCode = int(exitErr.ExitCode())
+		default:
+			// Traps, including running out of memory.
+			result.ExitCode = 1
+			result.Error = err.Error()
+		}
+	}
+	return result, nil
+}
+
+// Close releases the runtime and compiled modules.
+func (w *wasmRunner) Close(ctx context.Context) error {
+	if w == nil {
+		return nil
+	}
+	return w.runtime.Close(ctx)
+}
+
+// missingModule reports whether stderr shows an import the WASM build could
+// not resolve.
+func missingModule(language, stderr string) bool {
+	switch language {
+	case "python":
+		return strings.Contains(stderr, "ModuleNotFoundError")
+	case "nodejs":
+		return strings.Contains(stderr, "could not load module")
+	}
+	return false
+}
+
+// limitedBuffer keeps the first limit bytes written and drops the rest.
+type limitedBuffer struct {
+	buf       bytes.Buffer
+	limit     int
+	truncated bool
+}
+
+func (b *limitedBuffer) Write(p []byte) (int, error) {
+	if remaining := b.limit - b.buf.Len(); remaining < len(p) {
+		b.truncated = true
+		if remaining > 0 {
+			b.buf.Write(p[:remaining])
+		}
+		return len(p), nil
+	}
+	return b.buf.Write(p)
+}
+
+func (b *limitedBuffer) String() string {
+	if b.truncated {
+		return b.buf.String() + "\n[output truncated]"
+	}
+	return b.buf.String()
+}
@@ -0,0 +1,187 @@
+package sandbox
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/tetratelabs/wazero"
+)
+
+// buildTestWasm compiles testdata/wasm-interpreter for WASI.
+func buildTestWasm(t *testing.T) string {
+	t.Helper()
+	out := filepath.Join(t.TempDir(), "interpreter.wasm")
+	cmd := exec.Command("go", "build", "-o", out, "./testdata/wasm-interpreter")
+	cmd.Env = append(os.Environ(), "GOOS=wasip1", "GOARCH=wasm")
+	if output, err := cmd.CombinedOutput(); err != nil {
+		t.Skipf("cannot build wasip1 test module: %v\n%s", err, output)
+	}
+	return out
+}
+
+func TestWasmRunner(t *testing.T) {
+	module := buildTestWasm(t)
+	ctx := context.Background()
+	runner, err := newWasmRunner(ctx, WasmConfig{
+		PythonModule: module,
+		MaxCodeBytes: 256,
+		MemoryMB:     64,
+		Timeout:      2 * time.Second,
+	})
+	if err != nil {
+		t.Fatalf("newWasmRunner() error = %v", err)
+	}
+	defer runner.Close(ctx)
+
+	run := func(t *testing.T, code, stdin string) (*ExecuteResult, error) {
+		t.Helper()
+		params := &ExecuteParams{Language: "python", Code: code, Stdin: stdin}
+		workspace, err := prepareWorkspace(params, "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer os.RemoveAll(workspace)
+		return runner.Run(ctx, params, workspace)
+	}
+
+	t.Run("output and stdin", func(t *testing.T) {
+		result, err := run(t, "print hello\nstdin\nexit 3", "from stdin\n")
+		if err != nil {
+			t.Fatalf("Run() error = %v", err)
+		}
+		if result.Stdout != "hello\nfrom stdin\n" || result.ExitCode != 3 {
+			t.Fatalf("unexpected result %+v", result)
+		}
+	})
+
+	t.Run("read-only workspace", func(t *testing.T) {
+		result, err := run(t, "write /workspace/out.txt", "")
+		if err != nil || !strings.Contains(result.Stdout, "write failed") {
+			t.Fatalf("expected the write to fail, got %+v, %v", result, err)
+		}
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		start := time.Now()
+		result, err := run(t, "loop", "")
+		if err != nil || !result.Timeout {
+			t.Fatalf("expected a timeout, got %+v, %v", result, err)
+		}
+		if elapsed := time.Since(start); elapsed > 10*time.Second {
+			t.Fatalf("timeout took %v", elapsed)
+		}
+	})
+
+	t.Run("memory limit", func(t *testing.T) {
+		result, err := run(t, "alloc 256", "")
+		if err != nil {
+			t.Fatalf("Run() error = %v", err)
+		}
+		if result.ExitCode == 0 || strings.Contains(result.Stdout, "allocated") {
+			t.Fatalf("expected the allocation to fail, got %+v", result)
+		}
+	})
+
+	t.Run("missing import falls back before running", func(t *testing.T) {
+		if _, err := run(t, "import json\nfrom missing_pkg.sub import thing\nprint ran", ""); !errors.Is(err, errWasmUnavailable) {
+			t.Fatalf("expected errWasmUnavailable, got %v", err)
+		}
+		if found, probed := runner.pythonModules["missing_pkg"]; !probed || found {
+			t.Fatalf("expected missing_pkg cached as missing, got found=%v probed=%v", found, probed)
+		}
+	})
+
+	t.Run("available import runs", func(t *testing.T) {
+		result, err := run(t, "import json, os.path as p\nprint ran", "")
+		if err != nil || result.Stdout != "ran\n" {
+			t.Fatalf("unexpected result %+v, %v", result, err)
+		}
+		if !runner.pythonModules["json"] || !runner.pythonModules["os"] {
+			t.Fatalf("expected json and os cached as found, got %v", runner.pythonModules)
+		}
+	})
+
+	t.Run("missing module at run time is not retried", func(t *testing.T) {
+		result, err := run(t, "print partial\nmissing", "")
+		if err != nil {
+			t.Fatalf("Run() error = %v", err)
+		}
+		if result.Stdout != "partial\n" || result.ExitCode == 0 || result.Error == "" {
+			t.Fatalf("expected the partial result with an error, got %+v", result)
+		}
+	})
+}
+
+func TestPythonImports(t *testing.T) {
+	code := "import os, sys as system\nfrom collections.abc import Mapping\nfrom . import local\n  import numpy.linalg\nimport json # comment\nimport os\n"
+	got := strings.Join(pythonImports(code), " ")
+	if want := "os sys collections numpy json"; got != want {
+		t.Fatalf("pythonImports() = %q, want %q", got, want)
+	}
+}
+
+func TestWasmRunnerJavaScriptImports(t *testing.T) {
+	runner := &wasmRunner{}
+	tests := []struct {
+		code string
+		want bool
+	}{
+		{`import * as std from "std";\nstd.printf("hi\n");`, true},
+		{`import { readFile } from 'os'; const m = await import("os");`, true},
+		{`import lodash from "lodash";`, false},
+		{`const fs = await import('./lib.js');`, false},
+		{`console.log("no imports")`, true},
+	}
+	for _, tt := range tests {
+		got, err := runner.importsAvailable(context.Background(), nil, &ExecuteParams{Language: "nodejs", Code: tt.code}, "")
+		if err != nil || got != tt.want {
+			t.Errorf("importsAvailable(%q) = %v, %v, want %v", tt.code, got, err, tt.want)
+		}
+	}
+}
+
+func TestWasmRunnerEligible(t *testing.T) {
+	runner := &wasmRunner{maxCode: 16, modules: map[string]wazero.CompiledModule{"python": nil}}
+	tests := []struct {
+		name   string
+		params ExecuteParams
+		want   bool
+	}{
+		{"small python", ExecuteParams{Language: "python", Code: "print(1)"}, true},
+		{"too large", ExecuteParams{Language: "python", Code: strings.Repeat("x", 17)}, false},
+		{"with files", ExecuteParams{Language: "python", Code: "print(1)", Files: map[string]string{"a.txt": "a"}}, false},
+		{"no module", ExecuteParams{Language: "go", Code: "package main"}, false},
+	}
+	for _, tt := range tests {
+		if got := runner.Eligible(&tt.params); got != tt.want {
+			t.Errorf("%s: Eligible() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+	if (*wasmRunner)(nil).Eligible(&ExecuteParams{Language: "python"}) {
+		t.Error("nil runner should not be eligible")
+	}
+}
+
+func TestNewWasmRunnerRequiresModule(t *testing.T) {
+	if _, err := newWasmRunner(context.Background(), WasmConfig{}); err == nil {
+		t.Fatal("expected an error without modules")
+	}
+	if _, err := newWasmRunner(context.Background(), WasmConfig{JavaScriptModule: filepath.Join(t.TempDir(), "missing.wasm")}); err == nil {
+		t.Fatal("expected an error for a missing module file")
+	}
+}
+
+func TestLimitedBuffer(t *testing.T) {
+	buf := &limitedBuffer{limit: 4}
+	buf.Write([]byte("ab"))
+	buf.Write([]byte("cdef"))
+	if got := buf.String(); got != "abcd\n[output truncated]" {
+		t.Fatalf("String() = %q", got)
+	}
+}
@@ -27,6 +27,8 @@ func (t *ListTicketsTool) Description() string {
 	return "List ServiceNow incidents/tickets. Can filter by state, priority, or assignment."
 }
 
+func (t *ListTicketsTool) Idempotent() bool { return true }
+
 func (t *ListTicketsTool) Schema() json.RawMessage {
 	return json.RawMessage(`{
 		"type": "object",
@@ -147,6 +149,8 @@ func (t *GetTicketTool) Description() string {
 	return "Get details of a specific ServiceNow incident by ticket number (e.g., INC0012345)"
 }
 
+func (t *GetTicketTool) Idempotent() bool { return true }
+
 func (t *GetTicketTool) Schema() json.RawMessage {
 	return json.RawMessage(`{
 		"type": "object",
@@ -0,0 +1,372 @@
+// Package spreadsheet provides tools for reading and writing Google Sheets
+// and for parsing and generating XLSX workbooks as artifacts.
+package spreadsheet
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/google"
+)
+
+const (
+	defaultSheetsURL        = "https://sheets.googleapis.com/v4"
+	defaultDriveURL         = "https://www.googleapis.com/drive/v3"
+	defaultTimeout          = 30 * time.Second
+	defaultMaxResponseBytes = int64(10 << 20) // 10MB
+)
+
+// Scopes are the OAuth scopes the Sheets client needs. drive.file lets it
+// share the spreadsheets it creates.
+var Scopes = []string{
+	"https://www.googleapis.com/auth/spreadsheets",
+	"https://www.googleapis.com/auth/drive.file",
+}
+
+// SheetsConfig configures the Google Sheets client.
+type SheetsConfig struct {
+	// CredentialsFile is a service account key file.
+	CredentialsFile string
+	// ClientID, ClientSecret and RefreshToken authorize as a Google user
+	// when no service account key is configured.
+	ClientID     string
+	ClientSecret string
+	RefreshToken string
+	// Spreadsheets maps names the model can use to spreadsheet IDs.
+	Spreadsheets map[string]string
+	Timeout      time.Duration
+	// BaseURL and DriveURL override the API endpoints.
+	BaseURL  string
+	DriveURL string
+	// HTTPClient is an already authorized client; credentials are ignored
+	// when it is set.
+	HTTPClient *http.Client
+}
+
+// SheetsClient wraps the Google Sheets REST API.
+type SheetsClient struct {
+	baseURL      string
+	driveURL     string
+	client       *http.Client
+	spreadsheets map[string]string
+}
+
+// NewSheetsClient creates a Google Sheets API client.
+func NewSheetsClient(cfg SheetsConfig) (*SheetsClient, error) {
+	timeout := cfg.Timeout
+	if timeout <= 0 {
+		timeout = defaultTimeout
+	}
+	client := cfg.HTTPClient
+	if client == nil {
+		tokens, err := tokenSource(cfg)
+		if err != nil {
+			return nil, err
+		}
+		client = oauth2.NewClient(context.Background(), tokens)
+		client.Timeout = timeout
+	}
+	baseURL := strings.TrimRight(cfg.BaseURL, "/")
+	if baseURL == "" {
+		baseURL = defaultSheetsURL
+	}
+	driveURL := strings.TrimRight(cfg.DriveURL, "/")
+	if driveURL == "" {
+		driveURL = defaultDriveURL
+	}
+	return &SheetsClient{
+		baseURL:      baseURL,
+		driveURL:     driveURL,
+		client:       client,
+		spreadsheets: cfg.Spreadsheets,
+	}, nil
+}
+
+func tokenSource(cfg SheetsConfig) (oauth2.TokenSource, error) {
+	if path := strings.TrimSpace(cfg.CredentialsFile); path != "" {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("sheets: read credentials: %w", err)
+		}
+		jwtCfg, err := google.JWTConfigFromJSON(data, Scopes...)
+		if err != nil {
+			return nil, fmt.Errorf("sheets: parse service account key: %w", err)
+		}
+		return jwtCfg.TokenSource(context.Background()), nil
+	}
+	if strings.TrimSpace(cfg.RefreshToken) == "" {
+		return nil, fmt.Errorf("sheets: credentials_file or refresh_token is required")
+	}
+	oauthCfg := &oauth2.Config{
+		ClientID:     cfg.ClientID,
+		ClientSecret: cfg.ClientSecret,
+		Endpoint:     google.Endpoint,
+		Scopes:       Scopes,
+	}
+	return oauthCfg.TokenSource(context.Background(), &oauth2.Token{RefreshToken: cfg.RefreshToken}), nil
+}
+
+// Names returns the configured spreadsheet names, sorted.
+func (c *SheetsClient) Names() []string {
+	names := make([]string, 0, len(c.spreadsheets))
+	for name := range c.spreadsheets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ResolveSpreadsheet maps a configured name, spreadsheet URL or ID to a
+// spreadsheet ID. An empty reference resolves to the only configured
+// spreadsheet.
+func (c *SheetsClient) ResolveSpreadsheet(ref string) (string, error) {
+	ref = strings.TrimSpace(ref)
+	if ref == "" {
+		if len(c.spreadsheets) == 1 {
+			for _, id := range c.spreadsheets {
+				return id, nil
+			}
+		}
+		return "", fmt.Errorf("spreadsheet is required")
+	}
+	if id, ok := c.spreadsheets[ref]; ok {
+		return id, nil
+	}
+	if _, rest, ok := strings.Cut(ref, "/spreadsheets/d/"); ok {
+		id, _, _ := strings.Cut(rest, "/")
+		return id, nil
+	}
+	return ref, nil
+}
+
+// ValueRange is a block of cell values.
+type ValueRange struct {
+	Range  string  `json:"range"`
+	Values [][]any `json:"values"`
+}
+
+// Spreadsheet describes a spreadsheet.
+type Spreadsheet struct {
+	ID     string   `json:"spreadsheet_id"`
+	URL    string   `json:"url"`
+	Sheets []string `json:"sheets"`
+}
+
+// AppendResult describes rows added by AppendRows.
+type AppendResult struct {
+	UpdatedRange string `json:"updated_range"`
+	UpdatedRows  int    `json:"updated_rows"`
+}
+
+// SheetTitles returns the titles of the sheets (tabs) in a spreadsheet.
+func (c *SheetsClient) SheetTitles(ctx context.Context, spreadsheetID string) ([]string, error) {
+	var resp struct {
+		Sheets []struct {
+			Properties struct {
+				Title string `json:"title"`
+			} `json:"properties"`
+		} `json:"sheets"`
+	}
+	endpoint := c.spreadsheetURL(spreadsheetID) + "?fields=sheets.properties.title"
+	if err := c.doJSON(ctx, http.MethodGet, endpoint, nil, &resp); err != nil {
+		return nil, err
+	}
+	titles := make([]string, 0, len(resp.Sheets))
+	for _, sheet := range resp.Sheets {
+		titles = append(titles, sheet.Properties.Title)
+	}
+	return titles, nil
+}
+
+// ReadRange reads the formatted values in an A1 range. An empty range reads
+// the whole first sheet.
+func (c *SheetsClient) ReadRange(ctx context.Context, spreadsheetID, rng string) (*ValueRange, error) {
+	rng, err := c.defaultRange(ctx, spreadsheetID, rng)
+	if err != nil {
+		return nil, err
+	}
+	var resp ValueRange
+	endpoint := c.spreadsheetURL(spreadsheetID) + "/values/" + url.PathEscape(rng) + "?valueRenderOption=FORMATTED_VALUE"
+	if err := c.doJSON(ctx, http.MethodGet, endpoint, nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// AppendRows appends rows after the table found in rng. With raw set values
+// are stored as given; otherwise they are parsed as if typed by a user, so
+// "=SUM(A1:A3)" becomes a formula and "2024-05-01" a date.
+func (c *SheetsClient) AppendRows(ctx context.Context, spreadsheetID, rng string, rows [][]any, raw bool) (*AppendResult, error) {
+	if len(rows) == 0 {
+		return nil, fmt.Errorf("sheets: rows are required")
+	}
+	rng, err := c.defaultRange(ctx, spreadsheetID, rng)
+	if err != nil {
+		return nil, err
+	}
+	input := "USER_ENTERED"
+	if raw {
+		input = "RAW"
+	}
+	params := url.Values{}
+	params.Set("valueInputOption", input)
+	params.Set("insertDataOption", "INSERT_ROWS")
+	endpoint := c.spreadsheetURL(spreadsheetID) + "/values/" + url.PathEscape(rng) + ":append?" + params.Encode()
+	var resp struct {
+		Updates struct {
+			UpdatedRange string `json:"updatedRange"`
+			UpdatedRows  int    `json:"updatedRows"`
+		} `json:"updates"`
+	}
+	if err := c.doJSON(ctx, http.MethodPost, endpoint, map[string]any{"values": rows}, &resp); err != nil {
+		return nil, err
+	}
+	return &AppendResult{UpdatedRange: resp.Updates.UpdatedRange, UpdatedRows: resp.Updates.UpdatedRows}, nil
+}
+
+// CreateSpreadsheet creates a spreadsheet with the given sheets.
+func (c *SheetsClient) CreateSpreadsheet(ctx context.Context, title string, sheets []string) (*Spreadsheet, error) {
+	title = strings.TrimSpace(title)
+	if title == "" {
+		return nil, fmt.Errorf("sheets: title is required")
+	}
+	body := map[string]any{"properties": map[string]any{"title": title}}
+	if len(sheets) > 0 {
+		body["sheets"] = sheetProperties(sheets)
+	}
+	var resp struct {
+		SpreadsheetID  string `json:"spreadsheetId"`
+		SpreadsheetURL string `json:"spreadsheetUrl"`
+		Sheets         []struct {
+			Properties struct {
+				Title string `json:"title"`
+			} `json:"properties"`
+		} `json:"sheets"`
+	}
+	if err := c.doJSON(ctx, http.MethodPost, c.baseURL+"/spreadsheets", body, &resp); err != nil {
+		return nil, err
+	}
+	created := &Spreadsheet{ID: resp.SpreadsheetID, URL: resp.SpreadsheetURL}
+	for _, sheet := range resp.Sheets {
+		created.Sheets = append(created.Sheets, sheet.Properties.Title)
+	}
+	return created, nil
+}
+
+// AddSheets adds sheets (tabs) to an existing spreadsheet.
+func (c *SheetsClient) AddSheets(ctx context.Context, spreadsheetID string, titles []string) error {
+	if len(titles) == 0 {
+		return fmt.Errorf("sheets: sheet titles are required")
+	}
+	requests := make([]map[string]any, 0, len(titles))
+	for _, props := range sheetProperties(titles) {
+		requests = append(requests, map[string]any{"addSheet": props})
+	}
+	return c.doJSON(ctx, http.MethodPost, c.spreadsheetURL(spreadsheetID)+":batchUpdate", map[string]any{"requests": requests}, nil)
+}
+
+// Share gives an email address writer access to a spreadsheet without
+// sending a notification.
+func (c *SheetsClient) Share(ctx context.Context, spreadsheetID, email string) error {
+	body := map[string]any{"type": "user", "role": "writer", "emailAddress": email}
+	endpoint := c.driveURL + "/files/" + url.PathEscape(spreadsheetID) + "/permissions?sendNotificationEmail=false"
+	return c.doJSON(ctx, http.MethodPost, endpoint, body, nil)
+}
+
+func sheetProperties(titles []string) []map[string]any {
+	props := make([]map[string]any, 0, len(titles))
+	for _, title := range titles {
+		props = append(props, map[string]any{"properties": map[string]any{"title": strings.TrimSpace(title)}})
+	}
+	return props
+}
+
+// defaultRange returns rng, or the title of the first sheet when rng is
+// empty.
+func (c *SheetsClient) defaultRange(ctx context.Context, spreadsheetID, rng string) (string, error) {
+	if rng = strings.TrimSpace(rng); rng != "" {
+		return rng, nil
+	}
+	titles, err := c.SheetTitles(ctx, spreadsheetID)
+	if err != nil {
+		return "", err
+	}
+	if len(titles) == 0 {
+		return "", fmt.Errorf("sheets: spreadsheet has no sheets")
+	}
+	return quoteSheetName(titles[0]), nil
+}
+
+// quoteSheetName quotes a sheet title for use in an A1 range.
+func quoteSheetName(title string) string {
+	return "'" + strings.ReplaceAll(title, "'", "''") + "'"
+}
+
+func (c *SheetsClient) spreadsheetURL(spreadsheetID string) string {
+	return c.baseURL + "/spreadsheets/" + url.PathEscape(spreadsheetID)
+}
+
+func (c *SheetsClient) doJSON(ctx context.Context, method, endpoint string, payload any, out any) error {
+	if c == nil || c.client == nil {
+		return fmt.Errorf("sheets: client not configured")
+	}
+	var body io.Reader
+	if payload != nil {
+		encoded, err := json.Marshal(payload)
+		if err != nil {
+			return fmt.Errorf("sheets: encode request: %w", err)
+		}
+		body = bytes.NewReader(encoded)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, endpoint, body)
+	if err != nil {
+		return fmt.Errorf("sheets: create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("sheets: request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, defaultMaxResponseBytes+1))
+	if err != nil {
+		return fmt.Errorf("sheets: read response: %w", err)
+	}
+	if int64(len(data)) > defaultMaxResponseBytes {
+		return fmt.Errorf("sheets: response too large")
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		var apiErr struct {
+			Error struct {
+				Message string `json:"message"`
+			} `json:"error"`
+		}
+		msg := resp.Status
+		if json.Unmarshal(data, &apiErr) == nil && apiErr.Error.Message != "" {
+			msg = apiErr.Error.Message
+		}
+		return fmt.Errorf("sheets: %s", msg)
+	}
+	if out == nil {
+		return nil
+	}
+	if err := json.Unmarshal(data, out); err != nil {
+		return fmt.Errorf("sheets: decode response: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,153 @@
+package spreadsheet
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+type sheetsRequest struct {
+	Method string
+	Path   string
+	Query  string
+	Body   string
+}
+
+func newTestSheets(t *testing.T, handler func(req sheetsRequest) (int, string)) (*SheetsClient, *[]sheetsRequest) {
+	t.Helper()
+	var (
+		mu       sync.Mutex
+		requests []sheetsRequest
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		req := sheetsRequest{Method: r.Method, Path: r.URL.EscapedPath(), Query: r.URL.RawQuery, Body: string(body)}
+		mu.Lock()
+		requests = append(requests, req)
+		mu.Unlock()
+		status, resp := handler(req)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(resp))
+	}))
+	t.Cleanup(srv.Close)
+
+	client, err := NewSheetsClient(SheetsConfig{
+		Spreadsheets: map[string]string{"expenses": "sheet-123"},
+		BaseURL:      srv.URL + "/v4",
+		DriveURL:     srv.URL + "/drive/v3",
+		HTTPClient:   srv.Client(),
+	})
+	if err != nil {
+		t.Fatalf("NewSheetsClient: %v", err)
+	}
+	return client, &requests
+}
+
+func TestResolveSpreadsheet(t *testing.T) {
+	client, _ := newTestSheets(t, nil)
+	tests := map[string]string{
+		"":         "sheet-123",
+		"expenses": "sheet-123",
+		"https://docs.google.com/spreadsheets/d/abc_DEF-1/edit#gid=0": "abc_DEF-1",
+		"raw-id": "raw-id",
+	}
+	for ref, want := range tests {
+		got, err := client.ResolveSpreadsheet(ref)
+		if err != nil || got != want {
+			t.Errorf("ResolveSpreadsheet(%q) = %q, %v; want %q", ref, got, err, want)
+		}
+	}
+}
+
+func TestSheetsReadDefaultsToFirstSheet(t *testing.T) {
+	client, requests := newTestSheets(t, func(req sheetsRequest) (int, string) {
+		if !strings.Contains(req.Path, "/values/") {
+			return http.StatusOK, `{"sheets":[{"properties":{"title":"Q2 Log"}},{"properties":{"title":"Archive"}}]}`
+		}
+		return http.StatusOK, `{"range":"'Q2 Log'!A1:B3","values":[["Date","Amount"],["2024-05-01","12.50"],["2024-05-02","8"]]}`
+	})
+
+	res, err := NewSheetsReadTool(client, 5).Execute(context.Background(), json.RawMessage(`{"spreadsheet":"expenses"}`))
+	if err != nil || res.IsError {
+		t.Fatalf("sheets_read: %v %+v", err, res)
+	}
+	if got := (*requests)[1].Path; got != "/v4/spreadsheets/sheet-123/values/%27Q2%20Log%27" {
+		t.Fatalf("unexpected values path %s", got)
+	}
+	var out struct {
+		Rows      [][]any `json:"rows"`
+		TotalRows int     `json:"total_rows"`
+		Truncated bool    `json:"truncated"`
+	}
+	if err := json.Unmarshal([]byte(res.Content), &out); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if len(out.Rows) != 2 || out.TotalRows != 3 || !out.Truncated {
+		t.Fatalf("expected the cell limit to drop the last row, got %+v", out)
+	}
+}
+
+func TestSheetsAppend(t *testing.T) {
+	client, requests := newTestSheets(t, func(req sheetsRequest) (int, string) {
+		return http.StatusOK, `{"updates":{"updatedRange":"Log!A7:C7","updatedRows":1}}`
+	})
+
+	res, err := NewSheetsAppendTool(client).Execute(context.Background(), json.RawMessage(`{"range":"Log","rows":[["2024-05-01","Lunch",14.2]]}`))
+	if err != nil || res.IsError {
+		t.Fatalf("sheets_append: %v %+v", err, res)
+	}
+	req := (*requests)[0]
+	if req.Method != http.MethodPost || req.Path != "/v4/spreadsheets/sheet-123/values/Log:append" {
+		t.Fatalf("unexpected request %+v", req)
+	}
+	if !strings.Contains(req.Query, "valueInputOption=USER_ENTERED") || req.Body != `{"values":[["2024-05-01","Lunch",14.2]]}` {
+		t.Fatalf("unexpected request %+v", req)
+	}
+	if !strings.Contains(res.Content, `"updated_range": "Log!A7:C7"`) {
+		t.Fatalf("unexpected result %s", res.Content)
+	}
+}
+
+func TestSheetsCreateAndShare(t *testing.T) {
+	client, requests := newTestSheets(t, func(req sheetsRequest) (int, string) {
+		switch {
+		case strings.HasPrefix(req.Path, "/drive/") && strings.Contains(req.Body, "bad@"):
+			return http.StatusBadRequest, `{"error":{"code":400,"message":"Invalid email"}}`
+		case strings.HasPrefix(req.Path, "/drive/"):
+			return http.StatusOK, `{"id":"perm-1"}`
+		default:
+			return http.StatusOK, `{"spreadsheetId":"new-1","spreadsheetUrl":"https://docs.google.com/spreadsheets/d/new-1/edit","sheets":[{"properties":{"title":"Trips"}}]}`
+		}
+	})
+
+	res, err := NewSheetsCreateTool(client).Execute(context.Background(), json.RawMessage(`{"title":"Travel","sheets":["Trips"],"share_with":["ana@example.com","bad@example"]}`))
+	if err != nil || res.IsError {
+		t.Fatalf("sheets_create: %v %+v", err, res)
+	}
+	var out struct {
+		ID          string   `json:"spreadsheet_id"`
+		Sheets      []string `json:"sheets"`
+		ShareErrors []string `json:"share_errors"`
+	}
+	if err := json.Unmarshal([]byte(res.Content), &out); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if out.ID != "new-1" || len(out.Sheets) != 1 || len(out.ShareErrors) != 1 || !strings.Contains(out.ShareErrors[0], "Invalid email") {
+		t.Fatalf("unexpected result %+v", out)
+	}
+	share := (*requests)[1]
+	if share.Path != "/drive/v3/files/new-1/permissions" || share.Query != "sendNotificationEmail=false" {
+		t.Fatalf("unexpected share request %+v", share)
+	}
+
+	res, _ = NewSheetsCreateTool(client).Execute(context.Background(), json.RawMessage(`{"spreadsheet":"expenses","sheets":["Bad:Name"]}`))
+	if !res.IsError {
+		t.Fatalf("expected an invalid sheet name to be rejected")
+	}
+}
@@ -0,0 +1,256 @@
+package spreadsheet
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/haasonsaas/nexus/internal/agent"
+)
+
+// defaultMaxCells caps the cells a read returns.
+const defaultMaxCells = 2000
+
+// SheetsReadTool reads a range from a Google Sheets spreadsheet.
+type SheetsReadTool struct {
+	client   *SheetsClient
+	maxCells int
+}
+
+// NewSheetsReadTool creates the sheets_read tool. maxCells caps the cells
+// returned per read (default 2000).
+func NewSheetsReadTool(client *SheetsClient, maxCells int) *SheetsReadTool {
+	if maxCells <= 0 {
+		maxCells = defaultMaxCells
+	}
+	return &SheetsReadTool{client: client, maxCells: maxCells}
+}
+
+func (t *SheetsReadTool) Name() string { return "sheets_read" }
+
+func (t *SheetsReadTool) Description() string {
+	return "Read cell values from a Google Sheets range (A1 notation, e.g. Sheet1!A1:D20)." + knownSpreadsheets(t.client)
+}
+
+func (t *SheetsReadTool) Idempotent() bool { return true }
+
+func (t *SheetsReadTool) Schema() json.RawMessage {
+	return json.RawMessage(`{
+  "type": "object",
+  "properties": {
+    "spreadsheet": { "type": "string", "description": "Configured spreadsheet name, spreadsheet URL or ID" },
+    "range": { "type": "string", "description": "A1 range such as Sheet1!A1:D20 or a sheet name (default: the whole first sheet)" }
+  }
+}`)
+}
+
+func (t *SheetsReadTool) Execute(ctx context.Context, params json.RawMessage) (*agent.ToolResult, error) {
+	var input struct {
+		Spreadsheet string `json:"spreadsheet"`
+		Range       string `json:"range"`
+	}
+	if err := json.Unmarshal(params, &input); err != nil {
+		return toolError(fmt.Sprintf("invalid parameters: %v", err)), nil
+	}
+	id, err := t.client.ResolveSpreadsheet(input.Spreadsheet)
+	if err != nil {
+		return toolError(err.Error()), nil
+	}
+	values, err := t.client.ReadRange(ctx, id, input.Range)
+	if err != nil {
+		return toolError(err.Error()), nil
+	}
+	rows, truncated := limitCells(values.Values, t.maxCells)
+	return jsonResult(struct {
+		SpreadsheetID string  `json:"spreadsheet_id"`
+		Range         string  `json:"range"`
+		Rows          [][]any `json:"rows"`
+		TotalRows     int     `json:"total_rows"`
+		Truncated     bool    `json:"truncated,omitempty"`
+	}{
+		SpreadsheetID: id,
+		Range:         values.Range,
+		Rows:          rows,
+		TotalRows:     len(values.Values),
+		Truncated:     truncated,
+	})
+}
+
+// SheetsAppendTool appends rows to a Google Sheets spreadsheet.
+type SheetsAppendTool struct {
+	client *SheetsClient
+}
+
+// NewSheetsAppendTool creates the sheets_append tool.
+func NewSheetsAppendTool(client *SheetsClient) *SheetsAppendTool {
+	return &SheetsAppendTool{client: client}
+}
+
+func (t *SheetsAppendTool) Name() string { return "sheets_append" }
+
+func (t *SheetsAppendTool) Description() string {
+	return "Append rows after the last row of a table in a Google Sheets spreadsheet, e.g. to log an entry." + knownSpreadsheets(t.client)
+}
+
+func (t *SheetsAppendTool) Schema() json.RawMessage {
+	return json.RawMessage(`{
+  "type": "object",
+  "properties": {
+    "spreadsheet": { "type": "string", "description": "Configured spreadsheet name, spreadsheet URL or ID" },
+    "range": { "type": "string", "description": "Sheet name or A1 range of the table to append to (default: the first sheet)" },
+    "rows": {
+      "type": "array",
+      "description": "Rows to append, each an array of cell values",
+      "items": { "type": "array", "items": { "type": ["string", "number", "boolean", "null"] } }
+    },
+    "raw": { "type": "boolean", "description": "Store values as-is instead of parsing them like typed input (formulas, dates)" }
+  },
+  "required": ["rows"]
+}`)
+}
+
+func (t *SheetsAppendTool) Execute(ctx context.Context, params json.RawMessage) (*agent.ToolResult, error) {
+	var input struct {
+		Spreadsheet string  `json:"spreadsheet"`
+		Range       string  `json:"range"`
+		Rows        [][]any `json:"rows"`
+		Raw         bool    `json:"raw"`
+	}
+	if err := json.Unmarshal(params, &input); err != nil {
+		return toolError(fmt.Sprintf("invalid parameters: %v", err)), nil
+	}
+	if len(input.Rows) == 0 {
+		return toolError("rows are required"), nil
+	}
+	id, err := t.client.ResolveSpreadsheet(input.Spreadsheet)
+	if err != nil {
+		return toolError(err.Error()), nil
+	}
+	result, err := t.client.AppendRows(ctx, id, input.Range, input.Rows, input.Raw)
+	if err != nil {
+		return toolError(err.Error()), nil
+	}
+	return jsonResult(result)
+}
+
+// SheetsCreateTool creates spreadsheets or adds sheets to one.
+type SheetsCreateTool struct {
+	client *SheetsClient
+}
+
+// NewSheetsCreateTool creates the sheets_create tool.
+func NewSheetsCreateTool(client *SheetsClient) *SheetsCreateTool {
+	return &SheetsCreateTool{client: client}
+}
+
+func (t *SheetsCreateTool) Name() string { return "sheets_create" }
+
+func (t *SheetsCreateTool) Description() string {
+	return "Create a Google Sheets spreadsheet (with title), or add sheets (tabs) to an existing one (with spreadsheet). New spreadsheets can be shared with email addresses."
+}
+
+func (t *SheetsCreateTool) Schema() json.RawMessage {
+	return json.RawMessage(`{
+  "type": "object",
+  "properties": {
+    "title": { "type": "string", "description": "Title of a new spreadsheet" },
+    "spreadsheet": { "type": "string", "description": "Existing spreadsheet (name, URL or ID) to add sheets to instead" },
+    "sheets": { "type": "array", "items": { "type": "string" }, "description": "Sheet (tab) names to create" },
+    "share_with": { "type": "array", "items": { "type": "string" }, "description": "Email addresses to give edit access to a new spreadsheet" }
+  }
+}`)
+}
+
+func (t *SheetsCreateTool) Execute(ctx context.Context, params json.RawMessage) (*agent.ToolResult, error) {
+	var input struct {
+		Title       string   `json:"title"`
+		Spreadsheet string   `json:"spreadsheet"`
+		Sheets      []string `json:"sheets"`
+		ShareWith   []string `json:"share_with"`
+	}
+	if err := json.Unmarshal(params, &input); err != nil {
+		return toolError(fmt.Sprintf("invalid parameters: %v", err)), nil
+	}
+	for _, name := range input.Sheets {
+		if err := validateSheetName(name); err != nil {
+			return toolError(err.Error()), nil
+		}
+	}
+
+	if strings.TrimSpace(input.Spreadsheet) != "" {
+		if len(input.Sheets) == 0 {
+			return toolError("sheets are required when adding to an existing spreadsheet"), nil
+		}
+		id, err := t.client.ResolveSpreadsheet(input.Spreadsheet)
+		if err != nil {
+			return toolError(err.Error()), nil
+		}
+		if err := t.client.AddSheets(ctx, id, input.Sheets); err != nil {
+			return toolError(err.Error()), nil
+		}
+		return jsonResult(Spreadsheet{ID: id, URL: spreadsheetURL(id), Sheets: input.Sheets})
+	}
+
+	if strings.TrimSpace(input.Title) == "" {
+		return toolError("title or spreadsheet is required"), nil
+	}
+	created, err := t.client.CreateSpreadsheet(ctx, input.Title, input.Sheets)
+	if err != nil {
+		return toolError(err.Error()), nil
+	}
+	var shareErrors []string
+	for _, email := range input.ShareWith {
+		email = strings.TrimSpace(email)
+		if email == "" {
+			continue
+		}
+		if err := t.client.Share(ctx, created.ID, email); err != nil {
+			shareErrors = append(shareErrors, fmt.Sprintf("%s: %v", email, err))
+		}
+	}
+	return jsonResult(struct {
+		*Spreadsheet
+		ShareErrors []string `json:"share_errors,omitempty"`
+	}{created, shareErrors})
+}
+
+func spreadsheetURL(id string) string {
+	return "https://docs.google.com/spreadsheets/d/" + id + "/edit"
+}
+
+func knownSpreadsheets(client *SheetsClient) string {
+	if client == nil {
+		return ""
+	}
+	names := client.Names()
+	if len(names) == 0 {
+		return ""
+	}
+	return " Known spreadsheets: " + strings.Join(names, ", ") + "."
+}
+
+// limitCells returns the leading rows of values holding at most maxCells
+// cells, and whether rows were dropped.
+func limitCells(values [][]any, maxCells int) ([][]any, bool) {
+	cells := 0
+	for i, row := range values {
+		cells += max(len(row), 1)
+		if cells > maxCells {
+			return values[:i], true
+		}
+	}
+	return values, false
+}
+
+func jsonResult(v any) (*agent.ToolResult, error) {
+	payload, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return toolError(fmt.Sprintf("failed to encode result: %v", err)), nil
+	}
+	return &agent.ToolResult{Content: string(payload)}, nil
+}
+
+func toolError(message string) *agent.ToolResult {
+	return &agent.ToolResult{Content: message, IsError: true}
+}
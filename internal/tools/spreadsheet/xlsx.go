@@ -0,0 +1,572 @@
+package spreadsheet
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"math"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// maxPartBytes caps the uncompressed size of a single workbook part.
+	maxPartBytes = 64 << 20
+	// maxWorkbookCells caps the cells materialized when reading, counting
+	// the empty cells that pad sparse rows.
+	maxWorkbookCells = 2_000_000
+	// maxSheetNameLen is Excel's limit on sheet names.
+	maxSheetNameLen = 31
+)
+
+// XLSXMimeType is the MIME type of XLSX workbooks.
+const XLSXMimeType = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+
+// Workbook is the content of an XLSX file.
+type Workbook struct {
+	Sheets []Sheet
+}
+
+// Sheet is a worksheet. Cells hold a string, float64, bool, time.Time or
+// nil for an empty cell. When writing, a string starting with "=" is
+// stored as a formula.
+type Sheet struct {
+	Name string
+	Rows [][]any
+}
+
+type workbookXML struct {
+	Properties struct {
+		Date1904 bool `xml:"date1904,attr"`
+	} `xml:"workbookPr"`
+	Sheets []struct {
+		Name  string     `xml:"name,attr"`
+		Attrs []xml.Attr `xml:",any,attr"`
+	} `xml:"sheets>sheet"`
+}
+
+type relationshipsXML struct {
+	Relationships []struct {
+		ID     string `xml:"Id,attr"`
+		Target string `xml:"Target,attr"`
+	} `xml:"Relationship"`
+}
+
+type richTextXML struct {
+	Text string `xml:"t"`
+	Runs []struct {
+		Text string `xml:"t"`
+	} `xml:"r"`
+}
+
+func (r richTextXML) String() string {
+	if len(r.Runs) == 0 {
+		return r.Text
+	}
+	var b strings.Builder
+	b.WriteString(r.Text)
+	for _, run := range r.Runs {
+		b.WriteString(run.Text)
+	}
+	return b.String()
+}
+
+type worksheetXML struct {
+	Rows []struct {
+		R     int `xml:"r,attr"`
+		Cells []struct {
+			R      string      `xml:"r,attr"`
+			T      string      `xml:"t,attr"`
+			S      int         `xml:"s,attr"`
+			V      string      `xml:"v"`
+			Inline richTextXML `xml:"is"`
+		} `xml:"c"`
+	} `xml:"sheetData>row"`
+}
+
+type stylesXML struct {
+	NumFmts []struct {
+		ID   int    `xml:"numFmtId,attr"`
+		Code string `xml:"formatCode,attr"`
+	} `xml:"numFmts>numFmt"`
+	CellXfs []struct {
+		NumFmtID int `xml:"numFmtId,attr"`
+	} `xml:"cellXfs>xf"`
+}
+
+// ReadXLSX parses an XLSX workbook. Formulas are read as their cached
+// values and cells with a date format as time.Time.
+func ReadXLSX(data []byte) (*Workbook, error) {
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, fmt.Errorf("xlsx: not a valid workbook: %w", err)
+	}
+	parts := make(map[string]*zip.File, len(zr.File))
+	for _, f := range zr.File {
+		parts[strings.TrimPrefix(f.Name, "/")] = f
+	}
+
+	var wb workbookXML
+	if err := decodePart(parts, "xl/workbook.xml", &wb); err != nil {
+		return nil, err
+	}
+	var rels relationshipsXML
+	if err := decodePart(parts, "xl/_rels/workbook.xml.rels", &rels); err != nil {
+		return nil, err
+	}
+	targets := make(map[string]string, len(rels.Relationships))
+	for _, rel := range rels.Relationships {
+		target := rel.Target
+		if strings.HasPrefix(target, "/") {
+			target = strings.TrimPrefix(target, "/")
+		} else {
+			target = path.Join("xl", target)
+		}
+		targets[rel.ID] = target
+	}
+	shared, err := readSharedStrings(parts)
+	if err != nil {
+		return nil, err
+	}
+	dateStyles, err := readDateStyles(parts)
+	if err != nil {
+		return nil, err
+	}
+
+	reader := &sheetReader{shared: shared, dateStyles: dateStyles, date1904: wb.Properties.Date1904}
+	out := &Workbook{}
+	for _, sheet := range wb.Sheets {
+		target := ""
+		for _, attr := range sheet.Attrs {
+			if attr.Name.Local == "id" {
+				target = targets[attr.Value]
+			}
+		}
+		if target == "" {
+			return nil, fmt.Errorf("xlsx: sheet %q has no part", sheet.Name)
+		}
+		var ws worksheetXML
+		if err := decodePart(parts, target, &ws); err != nil {
+			return nil, err
+		}
+		rows, err := reader.rows(&ws)
+		if err != nil {
+			return nil, fmt.Errorf("xlsx: sheet %q: %w", sheet.Name, err)
+		}
+		out.Sheets = append(out.Sheets, Sheet{Name: sheet.Name, Rows: rows})
+	}
+	return out, nil
+}
+
+func decodePart(parts map[string]*zip.File, name string, v any) error {
+	f, ok := parts[name]
+	if !ok {
+		return fmt.Errorf("xlsx: missing %s", name)
+	}
+	rc, err := f.Open()
+	if err != nil {
+		return fmt.Errorf("xlsx: open %s: %w", name, err)
+	}
+	defer rc.Close()
+	data, err := io.ReadAll(io.LimitReader(rc, maxPartBytes+1))
+	if err != nil {
+		return fmt.Errorf("xlsx: read %s: %w", name, err)
+	}
+	if len(data) > maxPartBytes {
+		return fmt.Errorf("xlsx: %s is too large", name)
+	}
+	if err := xml.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("xlsx: parse %s: %w", name, err)
+	}
+	return nil
+}
+
+func readSharedStrings(parts map[string]*zip.File) ([]string, error) {
+	if _, ok := parts["xl/sharedStrings.xml"]; !ok {
+		return nil, nil
+	}
+	var sst struct {
+		Items []richTextXML `xml:"si"`
+	}
+	if err := decodePart(parts, "xl/sharedStrings.xml", &sst); err != nil {
+		return nil, err
+	}
+	shared := make([]string, len(sst.Items))
+	for i, item := range sst.Items {
+		shared[i] = item.String()
+	}
+	return shared, nil
+}
+
+// readDateStyles reports, per cell style index, whether the style formats
+// numbers as dates.
+func readDateStyles(parts map[string]*zip.File) ([]bool, error) {
+	if _, ok := parts["xl/styles.xml"]; !ok {
+		return nil, nil
+	}
+	var styles stylesXML
+	if err := decodePart(parts, "xl/styles.xml", &styles); err != nil {
+		return nil, err
+	}
+	custom := make(map[int]string, len(styles.NumFmts))
+	for _, numFmt := range styles.NumFmts {
+		custom[numFmt.ID] = numFmt.Code
+	}
+	dates := make([]bool, len(styles.CellXfs))
+	for i, xf := range styles.CellXfs {
+		if code, ok := custom[xf.NumFmtID]; ok {
+			dates[i] = isDateFormat(code)
+		} else {
+			dates[i] = isBuiltinDateFormat(xf.NumFmtID)
+		}
+	}
+	return dates, nil
+}
+
+func isBuiltinDateFormat(id int) bool {
+	return (id >= 14 && id <= 22) || (id >= 27 && id <= 36) || (id >= 45 && id <= 47) || (id >= 50 && id <= 58)
+}
+
+// isDateFormat reports whether a custom number format shows a date or time.
+func isDateFormat(code string) bool {
+	var b strings.Builder
+	inQuote, inBracket := false, false
+	for i := 0; i < len(code); i++ {
+		c := code[i]
+		switch {
+		case inQuote:
+			inQuote = c != '"'
+		case inBracket:
+			inBracket = c != ']'
+		case c == '"':
+			inQuote = true
+		case c == '[':
+			inBracket = true
+		case c == '\\':
+			i++
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return strings.ContainsAny(strings.ToLower(b.String()), "ydhs")
+}
+
+type sheetReader struct {
+	shared     []string
+	dateStyles []bool
+	date1904   bool
+	cells      int
+}
+
+func (r *sheetReader) rows(ws *worksheetXML) ([][]any, error) {
+	var rows [][]any
+	for _, row := range ws.Rows {
+		rowIndex := len(rows)
+		if row.R > 0 {
+			rowIndex = row.R - 1
+		}
+		if rowIndex < len(rows) {
+			return nil, fmt.Errorf("row %d is out of order", row.R)
+		}
+		if err := r.grow(rowIndex + 1 - len(rows)); err != nil {
+			return nil, err
+		}
+		for len(rows) <= rowIndex {
+			rows = append(rows, nil)
+		}
+		var cells []any
+		for _, c := range row.Cells {
+			col := len(cells)
+			if c.R != "" {
+				parsed, _, err := parseCellRef(c.R)
+				if err != nil {
+					return nil, err
+				}
+				col = parsed
+			}
+			if col < len(cells) {
+				return nil, fmt.Errorf("cell %s is out of order", c.R)
+			}
+			if err := r.grow(col + 1 - len(cells)); err != nil {
+				return nil, err
+			}
+			for len(cells) < col {
+				cells = append(cells, nil)
+			}
+			value, err := r.value(c.T, c.S, c.V, c.Inline)
+			if err != nil {
+				return nil, fmt.Errorf("cell %s: %w", c.R, err)
+			}
+			cells = append(cells, value)
+		}
+		rows[rowIndex] = cells
+	}
+	return rows, nil
+}
+
+func (r *sheetReader) grow(n int) error {
+	r.cells += n
+	if r.cells > maxWorkbookCells {
+		return fmt.Errorf("workbook has more than %d cells", maxWorkbookCells)
+	}
+	return nil
+}
+
+func (r *sheetReader) value(cellType string, style int, raw string, inline richTextXML) (any, error) {
+	switch cellType {
+	case "s":
+		idx, err := strconv.Atoi(strings.TrimSpace(raw))
+		if err != nil || idx < 0 || idx >= len(r.shared) {
+			return nil, fmt.Errorf("invalid shared string %q", raw)
+		}
+		return r.shared[idx], nil
+	case "inlineStr":
+		return inline.String(), nil
+	case "str", "e":
+		return raw, nil
+	case "b":
+		return strings.TrimSpace(raw) == "1", nil
+	case "d":
+		if t, err := time.Parse(time.RFC3339, raw); err == nil {
+			return t, nil
+		}
+		return raw, nil
+	}
+	if raw == "" {
+		return nil, nil
+	}
+	number, err := strconv.ParseFloat(strings.TrimSpace(raw), 64)
+	if err != nil {
+		return raw, nil
+	}
+	if style >= 0 && style < len(r.dateStyles) && r.dateStyles[style] {
+		return serialToTime(number, r.date1904), nil
+	}
+	return number, nil
+}
+
+// excelEpoch is day zero of the 1900 date system, adjusted for Excel
+// treating 1900 as a leap year.
+var excelEpoch = time.Date(1899, 12, 30, 0, 0, 0, 0, time.UTC)
+
+var excelEpoch1904 = time.Date(1904, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func serialToTime(serial float64, date1904 bool) time.Time {
+	epoch := excelEpoch
+	if date1904 {
+		epoch = excelEpoch1904
+	}
+	days := math.Floor(serial)
+	seconds := math.Round((serial - days) * 86400)
+	return epoch.AddDate(0, 0, int(days)).Add(time.Duration(seconds) * time.Second)
+}
+
+func timeToSerial(t time.Time) float64 {
+	t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), 0, time.UTC)
+	return t.Sub(excelEpoch).Hours() / 24
+}
+
+// parseCellRef parses an A1 reference into zero-based column and row.
+func parseCellRef(ref string) (int, int, error) {
+	col, i := 0, 0
+	for i < len(ref) && ref[i] >= 'A' && ref[i] <= 'Z' {
+		col = col*26 + int(ref[i]-'A'+1)
+		if col > 16384 {
+			return 0, 0, fmt.Errorf("invalid cell reference %q", ref)
+		}
+		i++
+	}
+	row, err := strconv.Atoi(ref[i:])
+	if i == 0 || err != nil || row < 1 {
+		return 0, 0, fmt.Errorf("invalid cell reference %q", ref)
+	}
+	return col - 1, row - 1, nil
+}
+
+// columnName returns the letters of a zero-based column index.
+func columnName(col int) string {
+	name := ""
+	for col++; col > 0; col = (col - 1) / 26 {
+		name = string(rune('A'+(col-1)%26)) + name
+	}
+	return name
+}
+
+// validateSheetName checks a name against Excel's rules for sheet names.
+func validateSheetName(name string) error {
+	if strings.TrimSpace(name) == "" {
+		return fmt.Errorf("sheet name is required")
+	}
+	if len([]rune(name)) > maxSheetNameLen {
+		return fmt.Errorf("sheet name %q is longer than %d characters", name, maxSheetNameLen)
+	}
+	if strings.ContainsAny(name, `[]:*?/\`) {
+		return fmt.Errorf("sheet name %q contains one of []:*?/\\", name)
+	}
+	if strings.HasPrefix(name, "'") || strings.HasSuffix(name, "'") {
+		return fmt.Errorf("sheet name %q starts or ends with an apostrophe", name)
+	}
+	return nil
+}
+
+// Cell style indexes in the generated styles part.
+const (
+	styleDate     = 1
+	styleDateTime = 2
+)
+
+const (
+	xmlHeader     = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>` + "\n"
+	nsMain        = "http://schemas.openxmlformats.org/spreadsheetml/2006/main"
+	nsRelations   = "http://schemas.openxmlformats.org/officeDocument/2006/relationships"
+	nsPackageRels = "http://schemas.openxmlformats.org/package/2006/relationships"
+)
+
+const stylesPart = xmlHeader + `<styleSheet xmlns="` + nsMain + `">` +
+	`<fonts count="1"><font><sz val="11"/><name val="Calibri"/></font></fonts>` +
+	`<fills count="2"><fill><patternFill patternType="none"/></fill><fill><patternFill patternType="gray125"/></fill></fills>` +
+	`<borders count="1"><border><left/><right/><top/><bottom/><diagonal/></border></borders>` +
+	`<cellStyleXfs count="1"><xf numFmtId="0" fontId="0" fillId="0" borderId="0"/></cellStyleXfs>` +
+	`<cellXfs count="3"><xf numFmtId="0" fontId="0" fillId="0" borderId="0" xfId="0"/>` +
+	`<xf numFmtId="14" fontId="0" fillId="0" borderId="0" xfId="0" applyNumberFormat="1"/>` +
+	`<xf numFmtId="22" fontId="0" fillId="0" borderId="0" xfId="0" applyNumberFormat="1"/></cellXfs>` +
+	`<cellStyles count="1"><cellStyle name="Normal" xfId="0" builtinId="0"/></cellStyles>` +
+	`</styleSheet>`
+
+// WriteXLSX encodes a workbook as an XLSX file.
+func WriteXLSX(wb *Workbook) ([]byte, error) {
+	if wb == nil || len(wb.Sheets) == 0 {
+		return nil, fmt.Errorf("xlsx: at least one sheet is required")
+	}
+	seen := make(map[string]bool, len(wb.Sheets))
+	for _, sheet := range wb.Sheets {
+		if err := validateSheetName(sheet.Name); err != nil {
+			return nil, fmt.Errorf("xlsx: %w", err)
+		}
+		key := strings.ToLower(sheet.Name)
+		if seen[key] {
+			return nil, fmt.Errorf("xlsx: duplicate sheet name %q", sheet.Name)
+		}
+		seen[key] = true
+	}
+
+	var contentTypes, workbook, workbookRels strings.Builder
+	contentTypes.WriteString(xmlHeader + `<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+		`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>` +
+		`<Default Extension="xml" ContentType="application/xml"/>` +
+		`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>` +
+		`<Override PartName="/xl/styles.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.styles+xml"/>`)
+	workbook.WriteString(xmlHeader + `<workbook xmlns="` + nsMain + `" xmlns:r="` + nsRelations + `"><sheets>`)
+	workbookRels.WriteString(xmlHeader + `<Relationships xmlns="` + nsPackageRels + `">`)
+
+	files := []struct {
+		name string
+		data string
+	}{}
+	for i, sheet := range wb.Sheets {
+		n := i + 1
+		fmt.Fprintf(&contentTypes, `<Override PartName="/xl/worksheets/sheet%d.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>`, n)
+		fmt.Fprintf(&workbook, `<sheet name="%s" sheetId="%d" r:id="rId%d"/>`, escapeXML(sheet.Name), n, n)
+		fmt.Fprintf(&workbookRels, `<Relationship Id="rId%d" Type="%s/worksheet" Target="worksheets/sheet%d.xml"/>`, n, nsRelations, n)
+		part, err := worksheetPart(sheet)
+		if err != nil {
+			return nil, fmt.Errorf("xlsx: sheet %q: %w", sheet.Name, err)
+		}
+		files = append(files, struct {
+			name string
+			data string
+		}{fmt.Sprintf("xl/worksheets/sheet%d.xml", n), part})
+	}
+	contentTypes.WriteString(`</Types>`)
+	workbook.WriteString(`</sheets></workbook>`)
+	fmt.Fprintf(&workbookRels, `<Relationship Id="rId%d" Type="%s/styles" Target="styles.xml"/></Relationships>`, len(wb.Sheets)+1, nsRelations)
+
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	parts := []struct {
+		name string
+		data string
+	}{
+		{"[Content_Types].xml", contentTypes.String()},
+		{"_rels/.rels", xmlHeader + `<Relationships xmlns="` + nsPackageRels + `"><Relationship Id="rId1" Type="` + nsRelations + `/officeDocument" Target="xl/workbook.xml"/></Relationships>`},
+		{"xl/workbook.xml", workbook.String()},
+		{"xl/_rels/workbook.xml.rels", workbookRels.String()},
+		{"xl/styles.xml", stylesPart},
+	}
+	for _, part := range append(parts, files...) {
+		w, err := zw.Create(part.name)
+		if err != nil {
+			return nil, fmt.Errorf("xlsx: %w", err)
+		}
+		if _, err := io.WriteString(w, part.data); err != nil {
+			return nil, fmt.Errorf("xlsx: %w", err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		return nil, fmt.Errorf("xlsx: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+func worksheetPart(sheet Sheet) (string, error) {
+	var b strings.Builder
+	b.WriteString(xmlHeader + `<worksheet xmlns="` + nsMain + `"><sheetData>`)
+	for r, row := range sheet.Rows {
+		fmt.Fprintf(&b, `<row r="%d">`, r+1)
+		for c, value := range row {
+			ref := columnName(c) + strconv.Itoa(r+1)
+			if err := writeCell(&b, ref, value); err != nil {
+				return "", fmt.Errorf("cell %s: %w", ref, err)
+			}
+		}
+		b.WriteString(`</row>`)
+	}
+	b.WriteString(`</sheetData></worksheet>`)
+	return b.String(), nil
+}
+
+func writeCell(b *strings.Builder, ref string, value any) error {
+	switch v := value.(type) {
+	case nil:
+	case string:
+		if len(v) > 1 && strings.HasPrefix(v, "=") {
+			fmt.Fprintf(b, `<c r="%s"><f>%s</f></c>`, ref, escapeXML(v[1:]))
+		} else {
+			fmt.Fprintf(b, `<c r="%s" t="inlineStr"><is><t xml:space="preserve">%s</t></is></c>`, ref, escapeXML(v))
+		}
+	case bool:
+		flag := "0"
+		if v {
+			flag = "1"
+		}
+		fmt.Fprintf(b, `<c r="%s" t="b"><v>%s</v></c>`, ref, flag)
+	case time.Time:
+		style := styleDateTime
+		if v.Hour() == 0 && v.Minute() == 0 && v.Second() == 0 {
+			style = styleDate
+		}
+		fmt.Fprintf(b, `<c r="%s" s="%d"><v>%s</v></c>`, ref, style, strconv.FormatFloat(timeToSerial(v), 'f', -1, 64))
+	case float64:
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			return fmt.Errorf("%v is not a valid number", v)
+		}
+		fmt.Fprintf(b, `<c r="%s"><v>%s</v></c>`, ref, strconv.FormatFloat(v, 'g', -1, 64))
+	case int:
+		fmt.Fprintf(b, `<c r="%s"><v>%d</v></c>`, ref, v)
+	case int64:
+		fmt.Fprintf(b, `<c r="%s"><v>%d</v></c>`, ref, v)
+	default:
+		return fmt.Errorf("unsupported value type %T", value)
+	}
+	return nil
+}
+
+func escapeXML(s string) string {
+	var b strings.Builder
+	_ = xml.EscapeText(&b, []byte(s))
+	return b.String()
+}
@@ -0,0 +1,186 @@
+package spreadsheet
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	pb "github.com/haasonsaas/nexus/pkg/proto"
+)
+
+func TestWriteReadXLSXRoundTrip(t *testing.T) {
+	due := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	logged := time.Date(2024, 5, 1, 13, 30, 0, 0, time.UTC)
+	data, err := WriteXLSX(&Workbook{Sheets: []Sheet{
+		{Name: "Expenses", Rows: [][]any{
+			{"Item", "Amount", "Paid", "Due"},
+			{"Coffee & <cake>", 4.5, true, due},
+			{"Total", "=SUM(B2:B2)", nil, logged},
+		}},
+		{Name: "Notes", Rows: [][]any{{nil, "  indented"}}},
+	}})
+	if err != nil {
+		t.Fatalf("WriteXLSX: %v", err)
+	}
+
+	wb, err := ReadXLSX(data)
+	if err != nil {
+		t.Fatalf("ReadXLSX: %v", err)
+	}
+	if len(wb.Sheets) != 2 || wb.Sheets[0].Name != "Expenses" || wb.Sheets[1].Name != "Notes" {
+		t.Fatalf("unexpected sheets: %+v", wb.Sheets)
+	}
+	rows := wb.Sheets[0].Rows
+	if rows[1][0] != "Coffee & <cake>" || rows[1][1] != 4.5 || rows[1][2] != true {
+		t.Fatalf("unexpected row: %#v", rows[1])
+	}
+	if got, ok := rows[1][3].(time.Time); !ok || !got.Equal(due) {
+		t.Fatalf("expected date %v, got %#v", due, rows[1][3])
+	}
+	if got, ok := rows[2][3].(time.Time); !ok || !got.Equal(logged) {
+		t.Fatalf("expected date-time %v, got %#v", logged, rows[2][3])
+	}
+	// Formulas have no cached value until a spreadsheet app recalculates.
+	if rows[2][1] != nil {
+		t.Fatalf("expected formula without cached value, got %#v", rows[2][1])
+	}
+	if notes := wb.Sheets[1].Rows; len(notes[0]) != 2 || notes[0][0] != nil || notes[0][1] != "  indented" {
+		t.Fatalf("unexpected notes: %#v", notes)
+	}
+}
+
+func TestWriteXLSXValidatesSheetNames(t *testing.T) {
+	for _, sheets := range [][]Sheet{
+		nil,
+		{{Name: ""}},
+		{{Name: "Q1/Q2"}},
+		{{Name: strings.Repeat("x", 32)}},
+		{{Name: "Data"}, {Name: "data"}},
+	} {
+		if _, err := WriteXLSX(&Workbook{Sheets: sheets}); err == nil {
+			t.Errorf("expected %+v to be rejected", sheets)
+		}
+	}
+}
+
+// excelWorkbook builds a workbook the way spreadsheet apps write them:
+// shared strings, rich text, styles with a custom date format, sparse
+// cells and relationship targets outside the default layout.
+func excelWorkbook(t *testing.T) []byte {
+	t.Helper()
+	parts := map[string]string{
+		"xl/workbook.xml": `<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">
+<sheets><sheet name="Leads" sheetId="1" r:id="rId7"/></sheets></workbook>`,
+		"xl/_rels/workbook.xml.rels": `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+<Relationship Id="rId7" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="/xl/worksheets/leads.xml"/></Relationships>`,
+		"xl/sharedStrings.xml": `<sst xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">
+<si><t>Name</t></si><si><t>Signed up</t></si><si><r><t>Ada </t></r><r><rPr><b/></rPr><t>Lovelace</t></r><rPh><t>ignored</t></rPh></si></sst>`,
+		"xl/styles.xml": `<styleSheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">
+<numFmts><numFmt numFmtId="164" formatCode="dd&quot;/&quot;mm&quot;/&quot;yyyy"/><numFmt numFmtId="165" formatCode="&quot;days&quot; 0.0"/></numFmts>
+<cellXfs><xf numFmtId="0"/><xf numFmtId="164"/><xf numFmtId="165"/></cellXfs></styleSheet>`,
+		"xl/worksheets/leads.xml": `<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>
+<row r="1"><c r="A1" t="s"><v>0</v></c><c r="B1" t="s"><v>1</v></c></row>
+<row r="3"><c r="A3" t="s"><v>2</v></c><c r="B3" s="1"><v>45413</v></c><c r="D3" s="2"><v>3.5</v></c><c r="E3" t="str"><f>A3</f><v>Ada Lovelace</v></c></row>
+</sheetData></worksheet>`,
+	}
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for name, content := range parts {
+		w, err := zw.Create(name)
+		if err != nil {
+			t.Fatalf("zip: %v", err)
+		}
+		if _, err := io.WriteString(w, content); err != nil {
+			t.Fatalf("zip: %v", err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatalf("zip: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func TestReadXLSXSharedStringsAndStyles(t *testing.T) {
+	wb, err := ReadXLSX(excelWorkbook(t))
+	if err != nil {
+		t.Fatalf("ReadXLSX: %v", err)
+	}
+	rows := wb.Sheets[0].Rows
+	if len(rows) != 3 || rows[1] != nil {
+		t.Fatalf("expected an empty second row, got %#v", rows)
+	}
+	row := rows[2]
+	if row[0] != "Ada Lovelace" {
+		t.Fatalf("expected rich text to be joined, got %#v", row[0])
+	}
+	if got, ok := row[1].(time.Time); !ok || !got.Equal(time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Fatalf("expected custom date format to be read as a date, got %#v", row[1])
+	}
+	if row[2] != nil || row[3] != 3.5 || row[4] != "Ada Lovelace" {
+		t.Fatalf("unexpected cells: %#v", row)
+	}
+}
+
+func TestReadXLSXRejectsInvalidFiles(t *testing.T) {
+	if _, err := ReadXLSX([]byte("name,total\n")); err == nil {
+		t.Fatalf("expected a CSV file to be rejected")
+	}
+}
+
+type fakeArtifacts map[string][]byte
+
+func (f fakeArtifacts) GetArtifact(_ context.Context, id string) (*pb.Artifact, io.ReadCloser, error) {
+	data, ok := f[id]
+	if !ok {
+		return nil, nil, io.EOF
+	}
+	return &pb.Artifact{Id: id, MimeType: XLSXMimeType}, io.NopCloser(bytes.NewReader(data)), nil
+}
+
+func TestXLSXTools(t *testing.T) {
+	create := NewXLSXCreateTool()
+	res, err := create.Execute(context.Background(), json.RawMessage(`{
+  "filename": "../report",
+  "sheets": [{"name": "Sales", "rows": [["Region", "Total", "Closed"], ["EMEA", 1200, "2024-05-01"], ["APAC", 800.5, null]]}]
+}`))
+	if err != nil || res.IsError {
+		t.Fatalf("xlsx_create: %v %+v", err, res)
+	}
+	if len(res.Artifacts) != 1 || res.Artifacts[0].Filename != "report.xlsx" || res.Artifacts[0].MimeType != XLSXMimeType {
+		t.Fatalf("unexpected artifacts: %+v", res.Artifacts)
+	}
+
+	read := NewXLSXReadTool(fakeArtifacts{"art-1": res.Artifacts[0].Data}, 0)
+	res, err = read.Execute(context.Background(), json.RawMessage(`{"artifact_id": "art-1", "max_rows": 2}`))
+	if err != nil || res.IsError {
+		t.Fatalf("xlsx_read: %v %+v", err, res)
+	}
+	var out struct {
+		Sheets []struct {
+			Name      string  `json:"name"`
+			Rows      [][]any `json:"rows"`
+			TotalRows int     `json:"total_rows"`
+			Truncated bool    `json:"truncated"`
+		} `json:"sheets"`
+	}
+	if err := json.Unmarshal([]byte(res.Content), &out); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	sheet := out.Sheets[0]
+	if sheet.Name != "Sales" || sheet.TotalRows != 3 || !sheet.Truncated || len(sheet.Rows) != 2 {
+		t.Fatalf("unexpected sheet: %+v", sheet)
+	}
+	if sheet.Rows[1][1] != float64(1200) || sheet.Rows[1][2] != "2024-05-01" {
+		t.Fatalf("unexpected row: %#v", sheet.Rows[1])
+	}
+
+	res, _ = read.Execute(context.Background(), json.RawMessage(`{"artifact_id": "art-1", "sheet": "Costs"}`))
+	if !res.IsError || !strings.Contains(res.Content, "sheets: Sales") {
+		t.Fatalf("expected a missing sheet error, got %+v", res)
+	}
+}
@@ -0,0 +1,263 @@
+package spreadsheet
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/haasonsaas/nexus/internal/agent"
+	pb "github.com/haasonsaas/nexus/pkg/proto"
+)
+
+const (
+	// maxWorkbookBytes caps the artifact size read by xlsx_read.
+	maxWorkbookBytes = 20 * 1024 * 1024
+	defaultReadRows  = 100
+)
+
+// ArtifactGetter reads stored artifacts.
+type ArtifactGetter interface {
+	GetArtifact(ctx context.Context, artifactID string) (*pb.Artifact, io.ReadCloser, error)
+}
+
+// XLSXReadTool reads the sheets of an XLSX artifact.
+type XLSXReadTool struct {
+	artifacts ArtifactGetter
+	maxCells  int
+}
+
+// NewXLSXReadTool creates the xlsx_read tool. maxCells caps the cells
+// returned per sheet (default 2000).
+func NewXLSXReadTool(repo ArtifactGetter, maxCells int) *XLSXReadTool {
+	if maxCells <= 0 {
+		maxCells = defaultMaxCells
+	}
+	return &XLSXReadTool{artifacts: repo, maxCells: maxCells}
+}
+
+func (t *XLSXReadTool) Name() string { return "xlsx_read" }
+
+func (t *XLSXReadTool) Description() string {
+	return "Read the rows of an Excel (.xlsx) file stored as an artifact, such as a spreadsheet a user attached."
+}
+
+func (t *XLSXReadTool) Idempotent() bool { return true }
+
+func (t *XLSXReadTool) Schema() json.RawMessage {
+	return json.RawMessage(`{
+  "type": "object",
+  "properties": {
+    "artifact_id": { "type": "string", "description": "ID of the XLSX artifact to read" },
+    "sheet": { "type": "string", "description": "Sheet to read (default: all sheets)" },
+    "max_rows": { "type": "integer", "minimum": 1, "description": "Maximum rows to return per sheet (default 100)" }
+  },
+  "required": ["artifact_id"]
+}`)
+}
+
+func (t *XLSXReadTool) Execute(ctx context.Context, params json.RawMessage) (*agent.ToolResult, error) {
+	var input struct {
+		ArtifactID string `json:"artifact_id"`
+		Sheet      string `json:"sheet"`
+		MaxRows    int    `json:"max_rows"`
+	}
+	if err := json.Unmarshal(params, &input); err != nil {
+		return toolError(fmt.Sprintf("invalid parameters: %v", err)), nil
+	}
+	id := strings.TrimSpace(input.ArtifactID)
+	if id == "" {
+		return toolError("artifact_id is required"), nil
+	}
+	if t.artifacts == nil {
+		return toolError("artifact storage is not available"), nil
+	}
+	maxRows := input.MaxRows
+	if maxRows <= 0 {
+		maxRows = defaultReadRows
+	}
+
+	_, reader, err := t.artifacts.GetArtifact(ctx, id)
+	if err != nil {
+		return toolError(fmt.Sprintf("artifact %s: %v", id, err)), nil
+	}
+	data, err := io.ReadAll(io.LimitReader(reader, maxWorkbookBytes+1))
+	reader.Close()
+	if err != nil {
+		return toolError(fmt.Sprintf("read artifact %s: %v", id, err)), nil
+	}
+	if len(data) > maxWorkbookBytes {
+		return toolError(fmt.Sprintf("artifact %s is too large", id)), nil
+	}
+	wb, err := ReadXLSX(data)
+	if err != nil {
+		return toolError(err.Error()), nil
+	}
+
+	type sheetOutput struct {
+		Name      string  `json:"name"`
+		Rows      [][]any `json:"rows"`
+		TotalRows int     `json:"total_rows"`
+		Truncated bool    `json:"truncated,omitempty"`
+	}
+	var sheets []sheetOutput
+	var names []string
+	for _, sheet := range wb.Sheets {
+		names = append(names, sheet.Name)
+		if input.Sheet != "" && !strings.EqualFold(sheet.Name, input.Sheet) {
+			continue
+		}
+		rows := sheet.Rows
+		truncated := false
+		if len(rows) > maxRows {
+			rows, truncated = rows[:maxRows], true
+		}
+		rows, cut := limitCells(rows, t.maxCells)
+		sheets = append(sheets, sheetOutput{
+			Name:      sheet.Name,
+			Rows:      jsonRows(rows),
+			TotalRows: len(sheet.Rows),
+			Truncated: truncated || cut,
+		})
+	}
+	if len(sheets) == 0 {
+		return toolError(fmt.Sprintf("sheet %q not found (sheets: %s)", input.Sheet, strings.Join(names, ", "))), nil
+	}
+	return jsonResult(struct {
+		ArtifactID string        `json:"artifact_id"`
+		Sheets     []sheetOutput `json:"sheets"`
+	}{id, sheets})
+}
+
+// jsonRows converts dates to strings for JSON output.
+func jsonRows(rows [][]any) [][]any {
+	out := make([][]any, len(rows))
+	for i, row := range rows {
+		out[i] = make([]any, len(row))
+		for j, value := range row {
+			if t, ok := value.(time.Time); ok {
+				value = formatTime(t)
+			}
+			out[i][j] = value
+		}
+	}
+	return out
+}
+
+func formatTime(t time.Time) string {
+	if t.Hour() == 0 && t.Minute() == 0 && t.Second() == 0 {
+		return t.Format("2006-01-02")
+	}
+	return t.Format("2006-01-02 15:04:05")
+}
+
+// XLSXCreateTool generates an XLSX file and returns it as an artifact.
+type XLSXCreateTool struct{}
+
+// NewXLSXCreateTool creates the xlsx_create tool.
+func NewXLSXCreateTool() *XLSXCreateTool {
+	return &XLSXCreateTool{}
+}
+
+func (t *XLSXCreateTool) Name() string { return "xlsx_create" }
+
+func (t *XLSXCreateTool) Description() string {
+	return "Create an Excel (.xlsx) file from rows of values and attach it to the reply. Strings starting with \"=\" become formulas; dates given as YYYY-MM-DD become date cells."
+}
+
+func (t *XLSXCreateTool) Schema() json.RawMessage {
+	return json.RawMessage(`{
+  "type": "object",
+  "properties": {
+    "filename": { "type": "string", "description": "File name (default: workbook.xlsx)" },
+    "sheets": {
+      "type": "array",
+      "items": {
+        "type": "object",
+        "properties": {
+          "name": { "type": "string", "description": "Sheet name (max 31 characters)" },
+          "rows": {
+            "type": "array",
+            "description": "Rows of cell values; put the header row first",
+            "items": { "type": "array", "items": { "type": ["string", "number", "boolean", "null"] } }
+          }
+        },
+        "required": ["name", "rows"]
+      }
+    }
+  },
+  "required": ["sheets"]
+}`)
+}
+
+func (t *XLSXCreateTool) Execute(ctx context.Context, params json.RawMessage) (*agent.ToolResult, error) {
+	var input struct {
+		Filename string `json:"filename"`
+		Sheets   []struct {
+			Name string  `json:"name"`
+			Rows [][]any `json:"rows"`
+		} `json:"sheets"`
+	}
+	if err := json.Unmarshal(params, &input); err != nil {
+		return toolError(fmt.Sprintf("invalid parameters: %v", err)), nil
+	}
+	if len(input.Sheets) == 0 {
+		return toolError("sheets are required"), nil
+	}
+
+	wb := &Workbook{}
+	summaries := make([]string, 0, len(input.Sheets))
+	for _, sheet := range input.Sheets {
+		rows := make([][]any, len(sheet.Rows))
+		for i, row := range sheet.Rows {
+			rows[i] = make([]any, len(row))
+			for j, value := range row {
+				rows[i][j] = inputCell(value)
+			}
+		}
+		wb.Sheets = append(wb.Sheets, Sheet{Name: sheet.Name, Rows: rows})
+		summaries = append(summaries, fmt.Sprintf("%s (%d rows)", sheet.Name, len(rows)))
+	}
+	data, err := WriteXLSX(wb)
+	if err != nil {
+		return toolError(err.Error()), nil
+	}
+
+	filename := filepath.Base(strings.TrimSpace(input.Filename))
+	if filename == "" || filename == "." || filename == "/" {
+		filename = "workbook.xlsx"
+	}
+	if !strings.EqualFold(filepath.Ext(filename), ".xlsx") {
+		filename += ".xlsx"
+	}
+	return &agent.ToolResult{
+		Content: fmt.Sprintf("Created %s with sheets %s.", filename, strings.Join(summaries, ", ")),
+		Artifacts: []agent.Artifact{{
+			Type:     "file",
+			MimeType: XLSXMimeType,
+			Filename: filename,
+			Data:     data,
+		}},
+	}, nil
+}
+
+// inputCell converts a JSON cell value for writing, turning ISO dates into
+// date cells.
+func inputCell(value any) any {
+	s, ok := value.(string)
+	if !ok {
+		return value
+	}
+	for _, layout := range []string{"2006-01-02", "2006-01-02 15:04:05", "2006-01-02T15:04:05"} {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t
+		}
+	}
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t
+	}
+	return s
+}
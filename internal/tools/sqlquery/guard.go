@@ -0,0 +1,207 @@
+package sqlquery
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/go-sql-driver/mysql"
+)
+
+// ReadOnlyStatements are the leading keywords that may be allowed. Anything
+// else can change data or server state.
+var ReadOnlyStatements = []string{"select", "with", "values", "explain", "show", "describe"}
+
+// DefaultStatements are the leading keywords allowed when none are
+// configured.
+var DefaultStatements = []string{"select", "with"}
+
+// writeKeywords change data, schema, permissions or session state. A query
+// containing any of them outside quotes is rejected even when it starts with
+// an allowed keyword, e.g. a data-modifying CTE or SELECT ... INTO.
+var writeKeywords = map[string]bool{
+	"insert": true, "update": true, "delete": true, "merge": true, "upsert": true,
+	"drop": true, "alter": true, "create": true, "truncate": true, "rename": true,
+	"grant": true, "revoke": true, "copy": true, "call": true, "execute": true,
+	"exec": true, "vacuum": true, "attach": true, "detach": true, "pragma": true,
+	"lock": true, "into": true, "set": true, "reset": true, "analyze": true,
+	"reindex": true, "refresh": true, "listen": true, "notify": true, "load": true,
+	"handler": true, "outfile": true, "dumpfile": true,
+}
+
+// mysqlKeywords are MySQL functions that read files on the server or take
+// locks that outlive the query. They are rejected in MySQL queries only.
+var mysqlKeywords = map[string]bool{
+	"load_file": true, "get_lock": true, "release_lock": true, "release_all_locks": true,
+}
+
+// checkStatement verifies query is a single statement starting with one of
+// allowed and containing no write keywords, parsed with the quoting rules
+// of driver. It returns the query without a trailing semicolon.
+func checkStatement(query string, allowed []string, driver string) (string, error) {
+	words, statements, err := scanSQL(query, driver)
+	if err != nil {
+		return "", err
+	}
+	if len(words) == 0 {
+		return "", fmt.Errorf("query is empty")
+	}
+	if statements > 1 {
+		return "", fmt.Errorf("only a single statement is allowed")
+	}
+	first := words[0]
+	permitted := false
+	for _, keyword := range allowed {
+		if strings.EqualFold(first, keyword) {
+			permitted = true
+			break
+		}
+	}
+	if !permitted {
+		return "", fmt.Errorf("%s statements are not allowed (allowed: %s)", strings.ToUpper(first), strings.ToUpper(strings.Join(allowed, ", ")))
+	}
+	for _, word := range words {
+		if writeKeywords[word] || (driver == DriverMySQL && mysqlKeywords[word]) {
+			return "", fmt.Errorf("%s is not allowed in a read-only query", strings.ToUpper(word))
+		}
+	}
+	return strings.TrimRight(strings.TrimSpace(query), "; \t\r\n"), nil
+}
+
+// readOnlyMySQLDSN turns off the MySQL DSN options that would undo the
+// guard: multiStatements, which runs every statement in a query, and
+// allowAllFiles, which lets the server read any file of the gateway host.
+func readOnlyMySQLDSN(dsn string) (string, error) {
+	cfg, err := mysql.ParseDSN(dsn)
+	if err != nil {
+		return "", err
+	}
+	cfg.MultiStatements = false
+	cfg.AllowAllFiles = false
+	return cfg.FormatDSN(), nil
+}
+
+// scanSQL returns the lowercased keywords and identifiers of query outside
+// comments, quoted strings and quoted identifiers, and the number of
+// statements separated by semicolons. When unsure where a comment or string
+// ends it errs towards scanning more text, never less. MySQL escapes quotes
+// with backslashes in every string; only Postgres has dollar quoting.
+func scanSQL(query string, driver string) ([]string, int, error) {
+	backslashEscapes := driver == DriverMySQL
+	var words []string
+	statements := 0
+	pending := false
+	runes := []rune(query)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case r == '-' && i+2 < len(runes) && runes[i+1] == '-' && !unicode.IsSpace(runes[i+2]):
+			// "--x" is a comment in Postgres and SQLite but arithmetic in
+			// MySQL; scan it as code.
+			pending = true
+			i += 2
+		case r == '-' && i+1 < len(runes) && runes[i+1] == '-':
+			for i < len(runes) && runes[i] != '\n' {
+				i++
+			}
+		case r == '/' && i+1 < len(runes) && runes[i+1] == '*':
+			if i+2 < len(runes) && (runes[i+2] == '!' || runes[i+2] == '+') {
+				return nil, 0, fmt.Errorf("executable comments and optimizer hints are not allowed")
+			}
+			end := indexRunes(runes, i+2, []rune("*/"))
+			if end < 0 {
+				return nil, 0, fmt.Errorf("unterminated comment")
+			}
+			i = end + 2
+		case r == '\'' || r == '"' || r == '`':
+			escapes := backslashEscapes && r != '`'
+			if r == '\'' && i > 0 && (runes[i-1] == 'e' || runes[i-1] == 'E') && len(words) > 0 && words[len(words)-1] == "e" {
+				// Postgres E'...' strings use backslash escapes.
+				escapes = true
+			}
+			end := closingQuote(runes, i, escapes)
+			if end < 0 {
+				return nil, 0, fmt.Errorf("unterminated quoted string")
+			}
+			pending = true
+			i = end + 1
+		case r == '$' && driver == DriverPostgres && dollarTag(runes[i:]) != "":
+			tag := []rune(dollarTag(runes[i:]))
+			end := indexRunes(runes, i+len(tag), tag)
+			if end < 0 {
+				return nil, 0, fmt.Errorf("unterminated dollar-quoted string")
+			}
+			pending = true
+			i = end + len(tag)
+		case r == ';':
+			if pending {
+				statements++
+				pending = false
+			}
+			i++
+		case unicode.IsLetter(r) || r == '_':
+			j := i
+			for j < len(runes) && (unicode.IsLetter(runes[j]) || unicode.IsDigit(runes[j]) || runes[j] == '_' || runes[j] == '$') {
+				j++
+			}
+			words = append(words, strings.ToLower(string(runes[i:j])))
+			pending = true
+			i = j
+		default:
+			if !unicode.IsSpace(r) {
+				pending = true
+			}
+			i++
+		}
+	}
+	if pending {
+		statements++
+	}
+	return words, statements, nil
+}
+
+// closingQuote returns the index of the quote closing the string opened at
+// runes[start], treating a doubled quote (and, with escapes, a backslash)
+// as escaping it, or -1.
+func closingQuote(runes []rune, start int, escapes bool) int {
+	quote := runes[start]
+	for j := start + 1; j < len(runes); j++ {
+		switch {
+		case escapes && runes[j] == '\\':
+			j++
+		case runes[j] == quote:
+			if j+1 < len(runes) && runes[j+1] == quote {
+				j++
+				continue
+			}
+			return j
+		}
+	}
+	return -1
+}
+
+// indexRunes returns the index of the first occurrence of sub in runes at or
+// after start, or -1.
+func indexRunes(runes []rune, start int, sub []rune) int {
+	for i := start; i+len(sub) <= len(runes); i++ {
+		if string(runes[i:i+len(sub)]) == string(sub) {
+			return i
+		}
+	}
+	return -1
+}
+
+// dollarTag returns the Postgres dollar-quote opener ($$ or $tag$) at the
+// start of runes, or "".
+func dollarTag(runes []rune) string {
+	for j := 1; j < len(runes); j++ {
+		switch {
+		case runes[j] == '$':
+			return string(runes[:j+1])
+		case unicode.IsLetter(runes[j]) || runes[j] == '_' || (j > 1 && unicode.IsDigit(runes[j])):
+		default:
+			return ""
+		}
+	}
+	return ""
+}
@@ -0,0 +1,62 @@
+package sqlquery
+
+import (
+	"testing"
+
+	"github.com/go-sql-driver/mysql"
+)
+
+func TestCheckStatement(t *testing.T) {
+	tests := []struct {
+		name    string
+		query   string
+		driver  string
+		wantErr bool
+	}{
+		{name: "select", query: "SELECT * FROM orders;", driver: DriverPostgres},
+		{name: "cte", query: "WITH recent AS (SELECT * FROM orders) SELECT count(*) FROM recent", driver: DriverPostgres},
+		{name: "keyword in string", query: "SELECT * FROM audit WHERE action = 'delete; drop table x'", driver: DriverPostgres},
+		{name: "keyword in comment", query: "SELECT 1 -- update later\n", driver: DriverPostgres},
+		{name: "quoted identifier", query: `SELECT "update" FROM t`, driver: DriverPostgres},
+		{name: "dollar quoted", query: "SELECT $$ drop table x $$", driver: DriverPostgres},
+		{name: "insert", query: "INSERT INTO t VALUES (1)", driver: DriverPostgres, wantErr: true},
+		{name: "two statements", query: "SELECT 1; SELECT 2", driver: DriverPostgres, wantErr: true},
+		{name: "data-modifying cte", query: "WITH x AS (UPDATE t SET a = 1 RETURNING *) SELECT * FROM x", driver: DriverPostgres, wantErr: true},
+		{name: "set", query: "SELECT set_config('a', 'b', false)", driver: DriverPostgres},
+		{name: "lock clause", query: "SELECT * FROM t FOR UPDATE", driver: DriverPostgres, wantErr: true},
+		{name: "explain not allowed", query: "EXPLAIN SELECT 1", driver: DriverPostgres, wantErr: true},
+		{name: "escape string hides statement", query: `SELECT E'\'' , 1; DELETE FROM t; --'`, driver: DriverPostgres, wantErr: true},
+		{name: "backslash is literal in standard strings", query: `SELECT 'a\'; DELETE FROM t; --'`, driver: DriverPostgres, wantErr: true},
+		{name: "mysql backslash escape", query: `SELECT 'it\'s; fine'`, driver: DriverMySQL},
+		{name: "mysql double dash without space", query: "SELECT 1--1\n", driver: DriverMySQL},
+		{name: "executable comment", query: "SELECT /*!50000 1 */", driver: DriverMySQL, wantErr: true},
+		{name: "mysql server file read", query: "SELECT LOAD_FILE('/etc/passwd')", driver: DriverMySQL, wantErr: true},
+		{name: "mysql named lock", query: "SELECT GET_LOCK('deploy', 10)", driver: DriverMySQL, wantErr: true},
+		{name: "lock function name is free elsewhere", query: "SELECT get_lock FROM jobs", driver: DriverPostgres},
+		{name: "dollar is not quoting in sqlite", query: "SELECT $a$; DELETE FROM t; $a$", driver: DriverSQLite, wantErr: true},
+		{name: "unterminated string", query: "SELECT 'oops", driver: DriverSQLite, wantErr: true},
+		{name: "empty", query: " ; ", driver: DriverSQLite, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := checkStatement(tt.query, DefaultStatements, tt.driver)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("checkStatement(%q) error = %v, wantErr %v", tt.query, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestReadOnlyMySQLDSN(t *testing.T) {
+	dsn, err := readOnlyMySQLDSN("reader:secret@tcp(db:3306)/shop?multiStatements=true&allowAllFiles=true&parseTime=true")
+	if err != nil {
+		t.Fatalf("readOnlyMySQLDSN() error = %v", err)
+	}
+	cfg, err := mysql.ParseDSN(dsn)
+	if err != nil {
+		t.Fatalf("ParseDSN(%q) error = %v", dsn, err)
+	}
+	if cfg.MultiStatements || cfg.AllowAllFiles || !cfg.ParseTime || cfg.DBName != "shop" {
+		t.Fatalf("readOnlyMySQLDSN() = %q", dsn)
+	}
+}
@@ -0,0 +1,103 @@
+package sqlquery
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// maxCellChars truncates long values in markdown tables.
+const maxCellChars = 200
+
+func formatValue(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return "NULL"
+	case []byte:
+		if utf8.Valid(v) {
+			return string(v)
+		}
+		return fmt.Sprintf("<%d bytes>", len(v))
+	case string:
+		return v
+	case time.Time:
+		return v.Format(time.RFC3339)
+	case bool:
+		return strconv.FormatBool(v)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+func summary(result *queryResult) string {
+	rows := "rows"
+	if len(result.Rows) == 1 {
+		rows = "row"
+	}
+	text := fmt.Sprintf("%d %s", len(result.Rows), rows)
+	if result.Truncated {
+		text += " (row limit reached, more available)"
+	}
+	if result.Elapsed >= time.Millisecond {
+		text += fmt.Sprintf(" in %s", result.Elapsed.Round(time.Millisecond))
+	}
+	return text
+}
+
+// renderMarkdown renders result as a markdown table, dropping trailing rows
+// that would exceed maxBytes.
+func renderMarkdown(result *queryResult, maxBytes int) string {
+	if len(result.Columns) == 0 {
+		return "Query returned no columns."
+	}
+	header := summary(result)
+	rows := result.Rows
+	table := markdownTable(result.Columns, rows)
+	for len(rows) > 0 && len(header)+len(table)+1 > maxBytes {
+		rows = rows[:len(rows)*3/4]
+		table = markdownTable(result.Columns, rows)
+	}
+	if len(rows) < len(result.Rows) {
+		header += fmt.Sprintf("; showing the first %d to stay under %d bytes, use format \"csv\" for all rows", len(rows), maxBytes)
+	}
+	return header + "\n" + table
+}
+
+func markdownTable(columns []string, rows [][]string) string {
+	var b strings.Builder
+	b.WriteString("| " + strings.Join(escapeCells(columns), " | ") + " |\n")
+	b.WriteString("|" + strings.Repeat(" --- |", len(columns)) + "\n")
+	for _, row := range rows {
+		b.WriteString("| " + strings.Join(escapeCells(row), " | ") + " |\n")
+	}
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+func escapeCells(cells []string) []string {
+	out := make([]string, len(cells))
+	for i, cell := range cells {
+		if utf8.RuneCountInString(cell) > maxCellChars {
+			cell = string([]rune(cell)[:maxCellChars]) + "…"
+		}
+		cell = strings.ReplaceAll(cell, "|", "\\|")
+		cell = strings.ReplaceAll(cell, "\r", "")
+		out[i] = strings.ReplaceAll(cell, "\n", " ")
+	}
+	return out
+}
+
+func renderCSV(result *queryResult) ([]byte, error) {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	if err := w.Write(result.Columns); err != nil {
+		return nil, err
+	}
+	if err := w.WriteAll(result.Rows); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,366 @@
+// Package sqlquery provides the sql_query tool, which runs read-only queries
+// against configured external databases.
+//
+// Queries are held to read-only use in layers: the statement must start
+// with an allowed keyword (SELECT by default) and contain no write keywords
+// outside quotes; it runs as a single prepared statement inside a read-only
+// transaction that is always rolled back; SQLite databases are opened
+// read-only, and MySQL connections never allow multiple statements or local
+// file reads. Connecting as a database user with read-only grants is still
+// recommended.
+package sqlquery
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	_ "github.com/go-sql-driver/mysql" // registers the "mysql" driver
+	_ "github.com/lib/pq"              // registers the "postgres" driver
+	_ "modernc.org/sqlite"             // registers the "sqlite" driver
+
+	"github.com/haasonsaas/nexus/internal/agent"
+)
+
+// Supported drivers.
+const (
+	DriverPostgres = "postgres"
+	DriverMySQL    = "mysql"
+	DriverSQLite   = "sqlite"
+)
+
+const (
+	defaultMaxRows  = 200
+	defaultMaxBytes = 64 * 1024
+	defaultTimeout  = 10 * time.Second
+	// csvPreviewRows is how many rows of a CSV result are shown inline.
+	csvPreviewRows = 5
+)
+
+// Database is an external database the tool can query.
+type Database struct {
+	Name        string
+	Driver      string
+	DSN         string
+	Description string
+}
+
+// Config configures the sql_query tool.
+type Config struct {
+	Databases []Database
+	// Statements are the allowed leading keywords. Defaults to
+	// DefaultStatements.
+	Statements []string
+	MaxRows    int
+	MaxBytes   int
+	Timeout    time.Duration
+}
+
+// Tool runs read-only SQL queries.
+type Tool struct {
+	databases  map[string]*database
+	names      []string
+	statements []string
+	maxRows    int
+	maxBytes   int
+	timeout    time.Duration
+}
+
+type database struct {
+	Database
+	db *sql.DB
+}
+
+// New opens handles for the configured databases. Connections are made on
+// first use.
+func New(cfg Config) (*Tool, error) {
+	if len(cfg.Databases) == 0 {
+		return nil, fmt.Errorf("sql_query: no databases configured")
+	}
+	t := &Tool{
+		databases:  make(map[string]*database, len(cfg.Databases)),
+		statements: cfg.Statements,
+		maxRows:    cfg.MaxRows,
+		maxBytes:   cfg.MaxBytes,
+		timeout:    cfg.Timeout,
+	}
+	if len(t.statements) == 0 {
+		t.statements = DefaultStatements
+	}
+	if t.maxRows <= 0 {
+		t.maxRows = defaultMaxRows
+	}
+	if t.maxBytes <= 0 {
+		t.maxBytes = defaultMaxBytes
+	}
+	if t.timeout <= 0 {
+		t.timeout = defaultTimeout
+	}
+	for _, cfgDB := range cfg.Databases {
+		db, err := open(cfgDB)
+		if err != nil {
+			t.Close()
+			return nil, fmt.Errorf("sql_query: database %q: %w", cfgDB.Name, err)
+		}
+		t.databases[cfgDB.Name] = &database{Database: cfgDB, db: db}
+		t.names = append(t.names, cfgDB.Name)
+	}
+	sort.Strings(t.names)
+	return t, nil
+}
+
+func open(cfg Database) (*sql.DB, error) {
+	driver := strings.ToLower(cfg.Driver)
+	registered := false
+	for _, name := range sql.Drivers() {
+		if name == driver {
+			registered = true
+			break
+		}
+	}
+	if !registered {
+		return nil, fmt.Errorf("driver %q is not available in this build", cfg.Driver)
+	}
+	dsn := cfg.DSN
+	switch driver {
+	case DriverSQLite:
+		dsn = readOnlySQLiteDSN(dsn)
+	case DriverMySQL:
+		var err error
+		if dsn, err = readOnlyMySQLDSN(dsn); err != nil {
+			return nil, fmt.Errorf("invalid mysql dsn: %w", err)
+		}
+	}
+	db, err := sql.Open(driver, dsn)
+	if err != nil {
+		return nil, err
+	}
+	db.SetMaxOpenConns(4)
+	db.SetConnMaxIdleTime(5 * time.Minute)
+	return db, nil
+}
+
+// readOnlySQLiteDSN opens a SQLite database in read-only mode.
+func readOnlySQLiteDSN(dsn string) string {
+	if !strings.HasPrefix(dsn, "file:") {
+		dsn = "file:" + dsn
+	}
+	path, rawQuery, _ := strings.Cut(dsn, "?")
+	params, err := url.ParseQuery(rawQuery)
+	if err != nil {
+		params = url.Values{}
+	}
+	params.Set("mode", "ro")
+	return path + "?" + params.Encode()
+}
+
+// Close closes the database handles.
+func (t *Tool) Close() error {
+	var firstErr error
+	for _, db := range t.databases {
+		if err := db.db.Close(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+// Name returns the tool name.
+func (t *Tool) Name() string {
+	return "sql_query"
+}
+
+// Description describes the tool and the databases it can query.
+func (t *Tool) Description() string {
+	var b strings.Builder
+	b.WriteString("Runs a read-only SQL query (")
+	b.WriteString(strings.ToUpper(strings.Join(t.statements, "/")))
+	b.WriteString(" only) against a configured database and returns the rows as a markdown table, or as a CSV file with format \"csv\". Databases:")
+	for _, name := range t.names {
+		db := t.databases[name]
+		fmt.Fprintf(&b, "\n- %s (%s)", name, db.Driver)
+		if db.Description != "" {
+			b.WriteString(": " + db.Description)
+		}
+	}
+	return b.String()
+}
+
+// Schema defines the tool parameters.
+func (t *Tool) Schema() json.RawMessage {
+	return json.RawMessage(fmt.Sprintf(`{
+  "type": "object",
+  "properties": {
+    "database": {"type": "string", "enum": %s, "description": "Database to query (optional when only one is configured)"},
+    "query": {"type": "string", "description": "A single read-only SQL statement"},
+    "format": {"type": "string", "enum": ["markdown", "csv"], "description": "Result format (default markdown)"},
+    "max_rows": {"type": "integer", "minimum": 1, "description": "Maximum rows to return (capped at %d)"}
+  },
+  "required": ["query"]
+}`, mustJSON(t.names), t.maxRows))
+}
+
+func mustJSON(v any) string {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return "[]"
+	}
+	return string(data)
+}
+
+// Execute runs the query and renders the result.
+func (t *Tool) Execute(ctx context.Context, params json.RawMessage) (*agent.ToolResult, error) {
+	var input struct {
+		Database string `json:"database"`
+		Query    string `json:"query"`
+		Format   string `json:"format"`
+		MaxRows  int    `json:"max_rows"`
+	}
+	if err := json.Unmarshal(params, &input); err != nil {
+		return toolError(fmt.Sprintf("invalid params: %v", err)), nil
+	}
+	name := strings.TrimSpace(input.Database)
+	if name == "" && len(t.names) == 1 {
+		name = t.names[0]
+	}
+	db, ok := t.databases[name]
+	if !ok {
+		return toolError(fmt.Sprintf("unknown database %q (available: %s)", name, strings.Join(t.names, ", "))), nil
+	}
+	format := strings.ToLower(strings.TrimSpace(input.Format))
+	if format == "" {
+		format = "markdown"
+	}
+	if format != "markdown" && format != "csv" {
+		return toolError("format must be \"markdown\" or \"csv\""), nil
+	}
+	maxRows := t.maxRows
+	if input.MaxRows > 0 && input.MaxRows < maxRows {
+		maxRows = input.MaxRows
+	}
+
+	query, err := checkStatement(input.Query, t.statements, strings.ToLower(db.Driver))
+	if err != nil {
+		return toolError("query rejected: " + err.Error()), nil
+	}
+
+	result, err := t.run(ctx, db, query, maxRows)
+	if err != nil {
+		return toolError(fmt.Sprintf("query failed: %v", err)), nil
+	}
+	if format == "csv" {
+		return t.csvResult(db.Name, result)
+	}
+	return &agent.ToolResult{Content: renderMarkdown(result, t.maxBytes)}, nil
+}
+
+// queryResult holds the rows read from a query.
+type queryResult struct {
+	Columns   []string
+	Rows      [][]string
+	Truncated bool
+	Elapsed   time.Duration
+}
+
+func (t *Tool) run(ctx context.Context, db *database, query string, maxRows int) (*queryResult, error) {
+	ctx, cancel := context.WithTimeout(ctx, t.timeout)
+	defer cancel()
+	start := time.Now()
+
+	tx, err := db.db.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		return nil, err
+	}
+	// Nothing a query does is ever committed.
+	defer func() { _ = tx.Rollback() }()
+
+	timeoutMS := t.timeout.Milliseconds()
+	switch strings.ToLower(db.Driver) {
+	case DriverPostgres:
+		if _, err := tx.ExecContext(ctx, fmt.Sprintf("SET LOCAL statement_timeout = %d", timeoutMS)); err != nil {
+			return nil, err
+		}
+	case DriverMySQL:
+		// MySQL has no transaction-scoped timeout; every query on the
+		// connection gets the same limit, so the session setting is safe.
+		if _, err := tx.ExecContext(ctx, fmt.Sprintf("SET SESSION max_execution_time = %d", timeoutMS)); err != nil {
+			return nil, err
+		}
+	}
+
+	// A prepared statement holds exactly one statement on every driver.
+	stmt, err := tx.PrepareContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+	rows, err := stmt.QueryContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+	result := &queryResult{Columns: columns}
+	values := make([]any, len(columns))
+	pointers := make([]any, len(columns))
+	for i := range values {
+		pointers[i] = &values[i]
+	}
+	for rows.Next() {
+		if len(result.Rows) >= maxRows {
+			result.Truncated = true
+			break
+		}
+		if err := rows.Scan(pointers...); err != nil {
+			return nil, err
+		}
+		row := make([]string, len(columns))
+		for i, value := range values {
+			row[i] = formatValue(value)
+		}
+		result.Rows = append(result.Rows, row)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	result.Elapsed = time.Since(start)
+	return result, nil
+}
+
+func (t *Tool) csvResult(dbName string, result *queryResult) (*agent.ToolResult, error) {
+	data, err := renderCSV(result)
+	if err != nil {
+		return toolError(fmt.Sprintf("render csv: %v", err)), nil
+	}
+	filename := fmt.Sprintf("%s-query-%s.csv", dbName, time.Now().UTC().Format("20060102-150405"))
+	preview := &queryResult{Columns: result.Columns, Rows: result.Rows}
+	if len(preview.Rows) > csvPreviewRows {
+		preview.Rows = preview.Rows[:csvPreviewRows]
+	}
+	content := fmt.Sprintf("%s attached as %s.", summary(result), filename)
+	if len(preview.Rows) > 0 {
+		content += "\nPreview:\n" + markdownTable(preview.Columns, preview.Rows)
+	}
+	return &agent.ToolResult{
+		Content: content,
+		Artifacts: []agent.Artifact{{
+			Type:     "file",
+			MimeType: "text/csv",
+			Filename: filename,
+			Data:     data,
+		}},
+	}, nil
+}
+
+func toolError(message string) *agent.ToolResult {
+	return &agent.ToolResult{Content: message, IsError: true}
+}
@@ -0,0 +1,141 @@
+package sqlquery
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func newTestTool(t *testing.T, cfg Config) (*Tool, string) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "shop.db")
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	defer db.Close()
+	statements := []string{
+		"CREATE TABLE orders (id INTEGER PRIMARY KEY, customer TEXT, total REAL, note TEXT)",
+	}
+	for i := 1; i <= 30; i++ {
+		statements = append(statements, fmt.Sprintf("INSERT INTO orders (customer, total, note) VALUES ('cust%d', %d.5, 'a|b')", i, i))
+	}
+	for _, stmt := range statements {
+		if _, err := db.Exec(stmt); err != nil {
+			t.Fatalf("seed %q: %v", stmt, err)
+		}
+	}
+	cfg.Databases = []Database{{Name: "shop", Driver: DriverSQLite, DSN: path, Description: "orders"}}
+	tool, err := New(cfg)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	t.Cleanup(func() { _ = tool.Close() })
+	return tool, path
+}
+
+func execute(t *testing.T, tool *Tool, params map[string]any) (string, bool) {
+	t.Helper()
+	raw, _ := json.Marshal(params)
+	result, err := tool.Execute(context.Background(), raw)
+	if err != nil {
+		t.Fatalf("Execute: %v", err)
+	}
+	return result.Content, result.IsError
+}
+
+func TestToolMarkdown(t *testing.T) {
+	tool, _ := newTestTool(t, Config{MaxRows: 10})
+
+	content, isErr := execute(t, tool, map[string]any{"query": "SELECT customer, total, note FROM orders WHERE id <= 2 ORDER BY id;"})
+	if isErr {
+		t.Fatalf("unexpected error: %s", content)
+	}
+	for _, want := range []string{"2 rows", "| customer | total | note |", "| cust1 | 1.5 | a\\|b |"} {
+		if !strings.Contains(content, want) {
+			t.Fatalf("expected %q in:\n%s", want, content)
+		}
+	}
+
+	content, _ = execute(t, tool, map[string]any{"query": "SELECT id FROM orders", "max_rows": 50})
+	if !strings.Contains(content, "10 rows (row limit reached") {
+		t.Fatalf("expected the configured row limit to cap the result, got:\n%s", content)
+	}
+}
+
+func TestToolCSVArtifact(t *testing.T) {
+	tool, _ := newTestTool(t, Config{})
+
+	raw, _ := json.Marshal(map[string]any{"database": "shop", "query": "SELECT id, customer FROM orders ORDER BY id", "format": "csv"})
+	result, err := tool.Execute(context.Background(), raw)
+	if err != nil || result.IsError {
+		t.Fatalf("Execute: %v %+v", err, result)
+	}
+	if len(result.Artifacts) != 1 || result.Artifacts[0].MimeType != "text/csv" {
+		t.Fatalf("expected a CSV artifact, got %+v", result.Artifacts)
+	}
+	lines := strings.Split(strings.TrimSpace(string(result.Artifacts[0].Data)), "\n")
+	if len(lines) != 31 || lines[0] != "id,customer" || lines[1] != "1,cust1" {
+		t.Fatalf("unexpected CSV (%d lines): %q", len(lines), lines[:2])
+	}
+	if !strings.Contains(result.Content, "30 rows") || !strings.Contains(result.Content, "| 5 | cust5 |") || strings.Contains(result.Content, "cust6") {
+		t.Fatalf("unexpected CSV summary:\n%s", result.Content)
+	}
+}
+
+func TestToolRejectsWrites(t *testing.T) {
+	tool, path := newTestTool(t, Config{})
+
+	for _, query := range []string{
+		"DELETE FROM orders",
+		"SELECT 1; DELETE FROM orders",
+		"WITH gone AS (DELETE FROM orders RETURNING id) SELECT * FROM gone",
+		"SELECT * INTO backup FROM orders",
+		"PRAGMA journal_mode = delete",
+		"/*! DELETE FROM orders */ SELECT 1",
+	} {
+		content, isErr := execute(t, tool, map[string]any{"query": query})
+		if !isErr || !strings.HasPrefix(content, "query rejected") {
+			t.Errorf("expected %q to be rejected, got %q", query, content)
+		}
+	}
+
+	// Writes that got past the statement check still fail on the read-only
+	// connection.
+	if _, err := tool.run(context.Background(), tool.databases["shop"], "UPDATE orders SET customer = 'x'", 10); err == nil {
+		t.Fatalf("expected the read-only database to refuse writes")
+	}
+
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	defer db.Close()
+	var count int
+	if err := db.QueryRow("SELECT COUNT(*) FROM orders WHERE customer = 'x'").Scan(&count); err != nil || count != 0 {
+		t.Fatalf("expected no rows to change, got %d (%v)", count, err)
+	}
+}
+
+func TestNewRequiresAvailableDriver(t *testing.T) {
+	_, err := New(Config{Databases: []Database{{Name: "crm", Driver: "oracle", DSN: "user@/crm"}}})
+	if err == nil || !strings.Contains(err.Error(), "not available") {
+		t.Fatalf("expected an unavailable driver error, got %v", err)
+	}
+}
+
+func TestNewOpensMySQL(t *testing.T) {
+	// sql.Open does not connect, so this only needs the driver registered.
+	tool, err := New(Config{Databases: []Database{{Name: "crm", Driver: DriverMySQL, DSN: "user@tcp(db:3306)/crm?multiStatements=true"}}})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer tool.Close()
+	if _, err := New(Config{Databases: []Database{{Name: "crm", Driver: DriverMySQL, DSN: "not a dsn"}}}); err == nil {
+		t.Fatal("expected an invalid mysql dsn error")
+	}
+}
@@ -0,0 +1,225 @@
+package vectormemory
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/haasonsaas/nexus/internal/agent"
+	"github.com/haasonsaas/nexus/internal/memory"
+	"github.com/haasonsaas/nexus/pkg/models"
+)
+
+// Forgetter defines the subset of memory manager behavior used by the
+// forget tool.
+type Forgetter interface {
+	SearchHierarchical(ctx context.Context, req *memory.HierarchyRequest) (*models.SearchResponse, error)
+	Find(ctx context.Context, filter memory.Filter, limit int) ([]*models.MemoryEntry, error)
+	Forget(ctx context.Context, entries []*models.MemoryEntry, record memory.DeletionRecord) (*memory.DeletionRecord, error)
+}
+
+// ForgetTool deletes entries from vector memory when the user asks the
+// agent to forget something. A call without confirm only lists candidates;
+// the agent deletes them in a second call once the user has agreed.
+type ForgetTool struct {
+	manager         Forgetter
+	config          *memory.Config
+	maxContentChars int
+}
+
+// NewForgetTool creates a new vector memory forget tool.
+func NewForgetTool(manager Forgetter, cfg *memory.Config) *ForgetTool {
+	return &ForgetTool{
+		manager:         manager,
+		config:          cfg,
+		maxContentChars: 500,
+	}
+}
+
+// Name returns the tool name.
+func (t *ForgetTool) Name() string {
+	return "vector_memory_forget"
+}
+
+// Description describes the tool.
+func (t *ForgetTool) Description() string {
+	return "Deletes memories when the user asks to forget something. Call it first with a query (or ids) to list the matching memories, " +
+		"show them to the user, and only after they agree call it again with the ids to delete and confirm set to true. Deletions are recorded in an audit log."
+}
+
+// Schema defines the tool parameters.
+func (t *ForgetTool) Schema() json.RawMessage {
+	return json.RawMessage(`{
+  "type": "object",
+  "properties": {
+    "query": {"type": "string", "description": "What the user wants forgotten, used to find candidate memories"},
+    "ids": {"type": "array", "items": {"type": "string"}, "description": "IDs of the memories to delete"},
+    "confirm": {"type": "boolean", "description": "Delete the listed ids. Set only after the user has confirmed the candidates."},
+    "reason": {"type": "string", "description": "Why the memories are being forgotten, for the audit record"},
+    "limit": {"type": "integer", "description": "Maximum number of candidates to list"}
+  }
+}`)
+}
+
+type forgetInput struct {
+	Query   string   `json:"query"`
+	IDs     []string `json:"ids"`
+	Confirm bool     `json:"confirm"`
+	Reason  string   `json:"reason"`
+	Limit   int      `json:"limit"`
+}
+
+// Execute runs the vector memory forget tool.
+func (t *ForgetTool) Execute(ctx context.Context, params json.RawMessage) (*agent.ToolResult, error) {
+	if t.manager == nil {
+		return &agent.ToolResult{Content: "vector memory is unavailable", IsError: true}, nil
+	}
+
+	var input forgetInput
+	if err := json.Unmarshal(params, &input); err != nil {
+		return &agent.ToolResult{Content: fmt.Sprintf("invalid params: %v", err), IsError: true}, nil
+	}
+	ids := normalizeTags(input.IDs)
+	query := strings.TrimSpace(input.Query)
+	session := agent.SessionFromContext(ctx)
+
+	if input.Confirm {
+		if len(ids) == 0 {
+			return &agent.ToolResult{Content: "ids are required to confirm a deletion; list the candidates first", IsError: true}, nil
+		}
+		return t.forget(ctx, session, ids, strings.TrimSpace(input.Reason))
+	}
+
+	var candidates []searchResult
+	switch {
+	case len(ids) > 0:
+		entries, err := t.manager.Find(ctx, memory.Filter{IDs: ids}, 0)
+		if err != nil {
+			return &agent.ToolResult{Content: fmt.Sprintf("failed to look up memories: %v", err), IsError: true}, nil
+		}
+		resp := &models.SearchResponse{}
+		for _, entry := range visibleEntries(entries, session) {
+			resp.Results = append(resp.Results, &models.SearchResult{Entry: entry, Score: 1})
+		}
+		candidates = buildSearchResults(resp, nil, t.maxContentChars)
+	case query != "":
+		req := &memory.HierarchyRequest{
+			Query:     query,
+			Limit:     input.Limit,
+			Threshold: defaultThresholdFromConfig(t.config),
+		}
+		if req.Limit <= 0 {
+			req.Limit = defaultLimitFromConfig(t.config)
+		}
+		if session != nil {
+			req.SessionID = session.ID
+			req.ChannelID = session.ChannelID
+			req.AgentID = session.AgentID
+		}
+		resp, err := t.manager.SearchHierarchical(ctx, req)
+		if err != nil {
+			return &agent.ToolResult{Content: fmt.Sprintf("search failed: %v", err), IsError: true}, nil
+		}
+		candidates = buildSearchResults(resp, nil, t.maxContentChars)
+	default:
+		return &agent.ToolResult{Content: "query or ids is required", IsError: true}, nil
+	}
+
+	response := struct {
+		Candidates []searchResult `json:"candidates"`
+		Note       string         `json:"note"`
+	}{
+		Candidates: candidates,
+		Note:       "Nothing has been deleted. Show these memories to the user and ask which to forget, then call again with their ids and confirm set to true.",
+	}
+	if len(candidates) == 0 {
+		response.Note = "No matching memories were found; nothing was deleted."
+	}
+	return encodeForgetResponse(response)
+}
+
+func (t *ForgetTool) forget(ctx context.Context, session *models.Session, ids []string, reason string) (*agent.ToolResult, error) {
+	entries, err := t.manager.Find(ctx, memory.Filter{IDs: ids}, 0)
+	if err != nil {
+		return &agent.ToolResult{Content: fmt.Sprintf("failed to look up memories: %v", err), IsError: true}, nil
+	}
+	entries = visibleEntries(entries, session)
+	deleted := make(map[string]bool, len(entries))
+	for _, entry := range entries {
+		deleted[entry.ID] = true
+	}
+	var missing []string
+	for _, id := range ids {
+		if !deleted[id] {
+			missing = append(missing, id)
+		}
+	}
+
+	response := struct {
+		Deleted   []string  `json:"deleted"`
+		NotFound  []string  `json:"not_found,omitempty"`
+		RecordID  string    `json:"record_id,omitempty"`
+		DeletedAt time.Time `json:"deleted_at,omitzero"`
+	}{
+		Deleted:  []string{},
+		NotFound: missing,
+	}
+	if len(entries) > 0 {
+		record := memory.DeletionRecord{By: "tool:" + t.Name(), Reason: reason}
+		if session != nil {
+			record.SessionID = session.ID
+		}
+		written, err := t.manager.Forget(ctx, entries, record)
+		if written == nil {
+			return &agent.ToolResult{Content: fmt.Sprintf("failed to delete memories: %v", err), IsError: true}, nil
+		}
+		for _, entry := range written.Entries {
+			response.Deleted = append(response.Deleted, entry.ID)
+		}
+		response.DeletedAt = written.DeletedAt
+		if err != nil {
+			return &agent.ToolResult{Content: err.Error(), IsError: true}, nil
+		}
+		response.RecordID = written.ID
+	}
+	return encodeForgetResponse(response)
+}
+
+// visibleEntries drops entries stored in another session, channel, or
+// agent scope than the current session's, so the agent cannot delete
+// memories it could not have recalled.
+func visibleEntries(entries []*models.MemoryEntry, session *models.Session) []*models.MemoryEntry {
+	if session == nil {
+		return entries
+	}
+	visible := entries[:0]
+	for _, entry := range entries {
+		scope, scopeID := memory.EntryScope(entry)
+		switch scope {
+		case models.ScopeSession:
+			if scopeID != session.ID {
+				continue
+			}
+		case models.ScopeChannel:
+			if scopeID != session.ChannelID {
+				continue
+			}
+		case models.ScopeAgent:
+			if scopeID != session.AgentID {
+				continue
+			}
+		}
+		visible = append(visible, entry)
+	}
+	return visible
+}
+
+func encodeForgetResponse(response any) (*agent.ToolResult, error) {
+	payload, err := json.MarshalIndent(response, "", "  ")
+	if err != nil {
+		return &agent.ToolResult{Content: fmt.Sprintf("failed to encode response: %v", err), IsError: true}, nil
+	}
+	return &agent.ToolResult{Content: string(payload)}, nil
+}
@@ -0,0 +1,102 @@
+package vectormemory
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/haasonsaas/nexus/internal/agent"
+	"github.com/haasonsaas/nexus/internal/memory"
+	"github.com/haasonsaas/nexus/pkg/models"
+)
+
+type fakeForgetter struct {
+	entries []*models.MemoryEntry
+	records []memory.DeletionRecord
+}
+
+func (f *fakeForgetter) SearchHierarchical(_ context.Context, req *memory.HierarchyRequest) (*models.SearchResponse, error) {
+	resp := &models.SearchResponse{}
+	for _, entry := range f.entries {
+		if strings.Contains(entry.Content, req.Query) {
+			resp.Results = append(resp.Results, &models.SearchResult{Entry: entry, Score: 0.9})
+		}
+	}
+	return resp, nil
+}
+
+func (f *fakeForgetter) Find(_ context.Context, filter memory.Filter, _ int) ([]*models.MemoryEntry, error) {
+	var found []*models.MemoryEntry
+	for _, entry := range f.entries {
+		if slices.Contains(filter.IDs, entry.ID) {
+			found = append(found, entry)
+		}
+	}
+	return found, nil
+}
+
+func (f *fakeForgetter) Forget(_ context.Context, entries []*models.MemoryEntry, record memory.DeletionRecord) (*memory.DeletionRecord, error) {
+	record.ID = "rec-1"
+	for _, entry := range entries {
+		record.Entries = append(record.Entries, memory.DeletedEntry{ID: entry.ID})
+		f.entries = slices.DeleteFunc(f.entries, func(e *models.MemoryEntry) bool { return e.ID == entry.ID })
+	}
+	f.records = append(f.records, record)
+	return &record, nil
+}
+
+func TestForgetTool_ListsThenDeletesOnConfirm(t *testing.T) {
+	manager := &fakeForgetter{entries: []*models.MemoryEntry{
+		{ID: "m1", Content: "user's address is 1 Main St", SessionID: "sess-1"},
+		{ID: "m2", Content: "user's address was 2 Elm St", ChannelID: "chan-other"},
+		{ID: "m3", Content: "prefers dark mode", AgentID: "agent-1"},
+	}}
+	tool := NewForgetTool(manager, &memory.Config{})
+	ctx := agent.WithSession(context.Background(), &models.Session{ID: "sess-1", ChannelID: "chan-1", AgentID: "agent-1"})
+
+	result, err := tool.Execute(ctx, json.RawMessage(`{"query":"address"}`))
+	if err != nil || result.IsError {
+		t.Fatalf("Execute() = %+v, %v", result, err)
+	}
+	if len(manager.records) != 0 {
+		t.Fatal("listing candidates deleted memories")
+	}
+	var listed struct {
+		Candidates []searchResult `json:"candidates"`
+	}
+	if err := json.Unmarshal([]byte(result.Content), &listed); err != nil || len(listed.Candidates) != 2 {
+		t.Fatalf("candidates = %s, %v", result.Content, err)
+	}
+
+	result, err = tool.Execute(ctx, json.RawMessage(`{"ids":["m1"]}`))
+	if err != nil || result.IsError || !strings.Contains(result.Content, `"m1"`) || len(manager.records) != 0 {
+		t.Fatalf("listing by id = %+v, %v", result, err)
+	}
+
+	result, err = tool.Execute(ctx, json.RawMessage(`{"confirm":true}`))
+	if err != nil || !result.IsError {
+		t.Fatalf("confirm without ids = %+v, %v", result, err)
+	}
+
+	result, err = tool.Execute(ctx, json.RawMessage(`{"ids":["m1","m2","m9"],"confirm":true,"reason":"user asked"}`))
+	if err != nil || result.IsError {
+		t.Fatalf("Execute(confirm) = %+v, %v", result, err)
+	}
+	var deleted struct {
+		Deleted  []string `json:"deleted"`
+		NotFound []string `json:"not_found"`
+		RecordID string   `json:"record_id"`
+	}
+	if err := json.Unmarshal([]byte(result.Content), &deleted); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	// m2 belongs to another channel, so the session may not delete it.
+	if !slices.Equal(deleted.Deleted, []string{"m1"}) || !slices.Equal(deleted.NotFound, []string{"m2", "m9"}) || deleted.RecordID != "rec-1" {
+		t.Errorf("response = %s", result.Content)
+	}
+	if len(manager.records) != 1 || manager.records[0].SessionID != "sess-1" || manager.records[0].Reason != "user asked" || manager.records[0].By != "tool:vector_memory_forget" {
+		t.Errorf("records = %+v", manager.records)
+	}
+}
@@ -44,6 +44,9 @@ func (t *SearchTool) Description() string {
 	return "Searches vector memory for relevant context across session, agent, channel, or global scopes."
 }
 
+// Idempotent reports that memory searches may be retried.
+func (t *SearchTool) Idempotent() bool { return true }
+
 // Schema defines the tool parameters.
 func (t *SearchTool) Schema() json.RawMessage {
 	return json.RawMessage(`{
@@ -18,10 +18,19 @@ type Indexer interface {
 	Index(ctx context.Context, entries []*models.MemoryEntry) error
 }
 
+// EntryReview holds memory entries that must be approved before they are
+// stored.
+type EntryReview interface {
+	// HoldEntry queues entry and returns its ID, or "" when entries in
+	// scope are stored directly.
+	HoldEntry(ctx context.Context, entry *models.MemoryEntry, scope string) (string, error)
+}
+
 // WriteTool writes entries into vector memory.
 type WriteTool struct {
 	manager Indexer
 	config  *memory.Config
+	review  EntryReview
 }
 
 // NewWriteTool creates a new vector memory write tool.
@@ -32,6 +41,13 @@ func NewWriteTool(manager Indexer, cfg *memory.Config) *WriteTool {
 	}
 }
 
+// WithReview holds entries for approval with review before they are
+// stored.
+func (t *WriteTool) WithReview(review EntryReview) *WriteTool {
+	t.review = review
+	return t
+}
+
 // Name returns the tool name.
 func (t *WriteTool) Name() string {
 	return "vector_memory_write"
@@ -163,21 +179,40 @@ func (t *WriteTool) Execute(ctx context.Context, params json.RawMessage) (*agent
 		entry.AgentID = scopeID
 	}
 
-	if err := t.manager.Index(ctx, []*models.MemoryEntry{entry}); err != nil {
-		return &agent.ToolResult{Content: fmt.Sprintf("failed to write memory: %v", err), IsError: true}, nil
+	var reviewID string
+	if t.review != nil {
+		id, err := t.review.HoldEntry(ctx, entry, scope)
+		if err != nil {
+			return &agent.ToolResult{Content: fmt.Sprintf("failed to queue memory for review: %v", err), IsError: true}, nil
+		}
+		reviewID = id
+	}
+	if reviewID == "" {
+		if err := t.manager.Index(ctx, []*models.MemoryEntry{entry}); err != nil {
+			return &agent.ToolResult{Content: fmt.Sprintf("failed to write memory: %v", err), IsError: true}, nil
+		}
 	}
 
-	payload, err := json.MarshalIndent(struct {
+	response := struct {
 		ID        string    `json:"id"`
 		Scope     string    `json:"scope"`
 		ScopeID   string    `json:"scope_id,omitempty"`
 		CreatedAt time.Time `json:"created_at"`
+		Status    string    `json:"status,omitempty"`
+		ReviewID  string    `json:"review_id,omitempty"`
+		Note      string    `json:"note,omitempty"`
 	}{
 		ID:        entry.ID,
 		Scope:     scope,
 		ScopeID:   scopeID,
 		CreatedAt: entry.CreatedAt,
-	}, "", "  ")
+	}
+	if reviewID != "" {
+		response.Status = "pending_review"
+		response.ReviewID = reviewID
+		response.Note = "The memory is waiting for the user's review and will be stored once approved. Do not retry it."
+	}
+	payload, err := json.MarshalIndent(response, "", "  ")
 	if err != nil {
 		return &agent.ToolResult{Content: fmt.Sprintf("failed to encode response: %v", err), IsError: true}, nil
 	}
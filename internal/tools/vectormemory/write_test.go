@@ -59,3 +59,45 @@ func TestWriteTool_ChannelScopeUsesSessionContext(t *testing.T) {
 		t.Errorf("source_agent_id = %v, want %q", entry.Metadata.Extra["source_agent_id"], "agent-1")
 	}
 }
+
+type fakeEntryReview struct {
+	held []*models.MemoryEntry
+}
+
+func (r *fakeEntryReview) HoldEntry(_ context.Context, entry *models.MemoryEntry, scope string) (string, error) {
+	if scope == "session" {
+		return "", nil
+	}
+	r.held = append(r.held, entry)
+	return "r1", nil
+}
+
+func TestWriteTool_HoldsEntriesForReview(t *testing.T) {
+	indexer := &fakeIndexer{}
+	review := &fakeEntryReview{}
+	tool := NewWriteTool(indexer, &memory.Config{}).WithReview(review)
+	ctx := agent.WithSession(context.Background(), &models.Session{ID: "sess-1", AgentID: "agent-1"})
+
+	result, err := tool.Execute(ctx, json.RawMessage(`{"content":"prefers tea","scope":"agent"}`))
+	if err != nil || result.IsError {
+		t.Fatalf("Execute = %+v, %v", result, err)
+	}
+	if len(indexer.entries) != 0 || len(review.held) != 1 {
+		t.Fatalf("expected entry held, indexed %d held %d", len(indexer.entries), len(review.held))
+	}
+	var payload map[string]any
+	if err := json.Unmarshal([]byte(result.Content), &payload); err != nil {
+		t.Fatalf("decode result: %v", err)
+	}
+	if payload["status"] != "pending_review" || payload["review_id"] != "r1" {
+		t.Fatalf("unexpected result %v", payload)
+	}
+
+	result, err = tool.Execute(ctx, json.RawMessage(`{"content":"scratch"}`))
+	if err != nil || result.IsError {
+		t.Fatalf("Execute = %+v, %v", result, err)
+	}
+	if len(indexer.entries) != 1 {
+		t.Fatalf("expected session entry to be indexed directly, got %d", len(indexer.entries))
+	}
+}
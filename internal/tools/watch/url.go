@@ -0,0 +1,177 @@
+// Package watch provides the watch_url tool for monitoring web pages.
+package watch
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/haasonsaas/nexus/internal/agent"
+	"github.com/haasonsaas/nexus/internal/webwatch"
+)
+
+// URLTool registers, lists, and removes web watches for the current session.
+type URLTool struct {
+	monitor *webwatch.Monitor
+}
+
+// NewURLTool creates the watch_url tool.
+func NewURLTool(monitor *webwatch.Monitor) *URLTool {
+	return &URLTool{monitor: monitor}
+}
+
+// Name returns the tool name.
+func (t *URLTool) Name() string {
+	return "watch_url"
+}
+
+// Description describes the tool.
+func (t *URLTool) Description() string {
+	return "Watches a web page on a schedule and notifies this conversation when it changes. " +
+		"Optionally narrow the check to a CSS selector and trigger only when a price drops below or rises above a threshold. " +
+		"Use action \"list\" to see watches and \"remove\" to stop one."
+}
+
+// Schema defines the tool parameters.
+func (t *URLTool) Schema() json.RawMessage {
+	return json.RawMessage(`{
+  "type": "object",
+  "properties": {
+    "action": {"type": "string", "enum": ["add", "list", "remove"], "description": "What to do (default: add)"},
+    "url": {"type": "string", "description": "Page to watch (add)"},
+    "selector": {"type": "string", "description": "CSS selector for the watched element, e.g. \"#price\" or \"div.stock span\" (add; default: whole page text)"},
+    "condition": {"type": "string", "enum": ["change", "price_below", "price_above"], "description": "When to notify (add; default: change)"},
+    "threshold": {"type": "number", "description": "Price threshold for price_below and price_above (add)"},
+    "interval": {"type": "string", "description": "How often to check, e.g. \"30m\" or \"6h\" (add)"},
+    "label": {"type": "string", "description": "Short name used in notifications (add)"},
+    "id": {"type": "string", "description": "Watch ID (remove)"}
+  }
+}`)
+}
+
+type urlInput struct {
+	Action    string  `json:"action"`
+	URL       string  `json:"url"`
+	Selector  string  `json:"selector"`
+	Condition string  `json:"condition"`
+	Threshold float64 `json:"threshold"`
+	Interval  string  `json:"interval"`
+	Label     string  `json:"label"`
+	ID        string  `json:"id"`
+}
+
+// watchSummary is the tool's view of a watch.
+type watchSummary struct {
+	ID            string  `json:"id"`
+	URL           string  `json:"url"`
+	Label         string  `json:"label,omitempty"`
+	Selector      string  `json:"selector,omitempty"`
+	Condition     string  `json:"condition"`
+	Threshold     float64 `json:"threshold,omitempty"`
+	Interval      string  `json:"interval"`
+	LastValue     string  `json:"last_value,omitempty"`
+	LastCheckedAt string  `json:"last_checked_at,omitempty"`
+	NextCheckAt   string  `json:"next_check_at"`
+	LastError     string  `json:"last_error,omitempty"`
+}
+
+// Execute runs the requested action against the current session's watches.
+func (t *URLTool) Execute(ctx context.Context, params json.RawMessage) (*agent.ToolResult, error) {
+	var input urlInput
+	if err := json.Unmarshal(params, &input); err != nil {
+		return &agent.ToolResult{Content: fmt.Sprintf("invalid params: %v", err), IsError: true}, nil
+	}
+	if t.monitor == nil {
+		return &agent.ToolResult{Content: "web watches are not available", IsError: true}, nil
+	}
+	session := agent.SessionFromContext(ctx)
+	if session == nil || session.ID == "" {
+		return &agent.ToolResult{Content: "web watches require a session", IsError: true}, nil
+	}
+
+	switch strings.ToLower(strings.TrimSpace(input.Action)) {
+	case "", "add":
+		return t.add(ctx, session.ID, string(session.Channel), session.ChannelID, session.AgentID, input)
+	case "list":
+		watches := t.monitor.List(session.ID)
+		summaries := make([]watchSummary, 0, len(watches))
+		for _, w := range watches {
+			summaries = append(summaries, summarize(w))
+		}
+		return jsonResult(map[string]any{"watches": summaries})
+	case "remove":
+		id := strings.TrimSpace(input.ID)
+		if id == "" {
+			return &agent.ToolResult{Content: "id is required", IsError: true}, nil
+		}
+		if err := t.monitor.Remove(ctx, session.ID, id); err != nil {
+			if errors.Is(err, webwatch.ErrNotFound) {
+				return &agent.ToolResult{Content: fmt.Sprintf("no watch %s in this conversation", id), IsError: true}, nil
+			}
+			return &agent.ToolResult{Content: err.Error(), IsError: true}, nil
+		}
+		return jsonResult(map[string]any{"removed": id})
+	default:
+		return &agent.ToolResult{Content: fmt.Sprintf("unknown action %q", input.Action), IsError: true}, nil
+	}
+}
+
+func (t *URLTool) add(ctx context.Context, sessionID, channel, channelID, agentID string, input urlInput) (*agent.ToolResult, error) {
+	if strings.TrimSpace(input.URL) == "" {
+		return &agent.ToolResult{Content: "url is required", IsError: true}, nil
+	}
+	var interval time.Duration
+	if raw := strings.TrimSpace(input.Interval); raw != "" {
+		parsed, err := time.ParseDuration(raw)
+		if err != nil || parsed <= 0 {
+			return &agent.ToolResult{Content: fmt.Sprintf("invalid interval %q (use e.g. 30m or 6h)", raw), IsError: true}, nil
+		}
+		interval = parsed
+	}
+	watch, err := t.monitor.Add(ctx, &webwatch.Watch{
+		URL:       input.URL,
+		Selector:  input.Selector,
+		Condition: webwatch.Condition(input.Condition),
+		Threshold: input.Threshold,
+		Interval:  interval,
+		Label:     strings.TrimSpace(input.Label),
+		SessionID: sessionID,
+		Channel:   channel,
+		ChannelID: channelID,
+		AgentID:   agentID,
+	})
+	if err != nil {
+		return &agent.ToolResult{Content: err.Error(), IsError: true}, nil
+	}
+	return jsonResult(map[string]any{"watch": summarize(watch)})
+}
+
+func summarize(w *webwatch.Watch) watchSummary {
+	summary := watchSummary{
+		ID:          w.ID,
+		URL:         w.URL,
+		Label:       w.Label,
+		Selector:    w.Selector,
+		Condition:   string(w.Condition),
+		Threshold:   w.Threshold,
+		Interval:    w.Interval.String(),
+		LastValue:   w.LastValue,
+		NextCheckAt: w.NextCheckAt.UTC().Format(time.RFC3339),
+		LastError:   w.LastError,
+	}
+	if !w.LastCheckedAt.IsZero() {
+		summary.LastCheckedAt = w.LastCheckedAt.UTC().Format(time.RFC3339)
+	}
+	return summary
+}
+
+func jsonResult(value any) (*agent.ToolResult, error) {
+	payload, err := json.MarshalIndent(value, "", "  ")
+	if err != nil {
+		return &agent.ToolResult{Content: fmt.Sprintf("failed to encode result: %v", err), IsError: true}, nil
+	}
+	return &agent.ToolResult{Content: string(payload)}, nil
+}
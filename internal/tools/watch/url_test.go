@@ -0,0 +1,83 @@
+package watch
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/haasonsaas/nexus/internal/agent"
+	"github.com/haasonsaas/nexus/internal/webwatch"
+	"github.com/haasonsaas/nexus/pkg/models"
+)
+
+func TestURLTool_AddListRemove(t *testing.T) {
+	monitor := webwatch.NewMonitor(webwatch.Config{MinInterval: time.Minute}, nil)
+	tool := NewURLTool(monitor)
+	ctx := agent.WithSession(context.Background(), &models.Session{
+		ID:        "session-1",
+		Channel:   models.ChannelTelegram,
+		ChannelID: "chat-9",
+		AgentID:   "main",
+	})
+
+	result, err := tool.Execute(ctx, json.RawMessage(`{"url":"https://shop.example.com/item","selector":".price","condition":"price_below","threshold":50,"interval":"2h","label":"Kettle"}`))
+	if err != nil || result.IsError {
+		t.Fatalf("add: %v %+v", err, result)
+	}
+	var added struct {
+		Watch watchSummary `json:"watch"`
+	}
+	if err := json.Unmarshal([]byte(result.Content), &added); err != nil {
+		t.Fatalf("decode add result: %v", err)
+	}
+	if added.Watch.ID == "" || added.Watch.Interval != "2h0m0s" || added.Watch.Condition != "price_below" {
+		t.Fatalf("unexpected watch: %+v", added.Watch)
+	}
+	watches := monitor.List("session-1")
+	if len(watches) != 1 || watches[0].Channel != string(models.ChannelTelegram) || watches[0].ChannelID != "chat-9" {
+		t.Fatalf("watch not bound to session: %+v", watches)
+	}
+
+	result, _ = tool.Execute(ctx, json.RawMessage(`{"action":"list"}`))
+	if result.IsError || !strings.Contains(result.Content, added.Watch.ID) {
+		t.Fatalf("list: %+v", result)
+	}
+
+	other := agent.WithSession(context.Background(), &models.Session{ID: "session-2"})
+	result, _ = tool.Execute(other, json.RawMessage(`{"action":"remove","id":"`+added.Watch.ID+`"}`))
+	if !result.IsError {
+		t.Fatal("expected another session's remove to fail")
+	}
+	result, _ = tool.Execute(ctx, json.RawMessage(`{"action":"remove","id":"`+added.Watch.ID+`"}`))
+	if result.IsError {
+		t.Fatalf("remove: %+v", result)
+	}
+	if len(monitor.List("")) != 0 {
+		t.Fatal("watch not removed")
+	}
+}
+
+func TestURLTool_Errors(t *testing.T) {
+	tool := NewURLTool(webwatch.NewMonitor(webwatch.Config{}, nil))
+	ctx := agent.WithSession(context.Background(), &models.Session{ID: "session-1"})
+
+	for _, params := range []string{
+		`{"action":"add"}`,
+		`{"url":"https://example.com","interval":"soon"}`,
+		`{"url":"https://example.com","selector":"div > p"}`,
+		`{"url":"https://example.com","condition":"price_above"}`,
+		`{"action":"pause"}`,
+	} {
+		result, err := tool.Execute(ctx, json.RawMessage(params))
+		if err != nil || !result.IsError {
+			t.Errorf("Execute(%s) = %+v, %v; want tool error", params, result, err)
+		}
+	}
+
+	result, _ := tool.Execute(context.Background(), json.RawMessage(`{"url":"https://example.com"}`))
+	if !result.IsError {
+		t.Error("expected error without a session")
+	}
+}
@@ -112,6 +112,25 @@ func validateURLForSSRF(rawURL string) error {
 
 // Extract fetches and extracts readable content from a URL.
 func (e *ContentExtractor) Extract(ctx context.Context, targetURL string) (string, error) {
+	body, err := e.FetchHTML(ctx, targetURL)
+	if err != nil {
+		return "", err
+	}
+
+	// Extract content using readability-like algorithm
+	content := e.extractReadableContent(body)
+
+	// Trim to reasonable length (10k chars)
+	if len(content) > 10000 {
+		content = content[:10000] + "..."
+	}
+
+	return content, nil
+}
+
+// FetchHTML fetches the raw HTML (or plain text) of a URL after validating it
+// against SSRF targets.
+func (e *ContentExtractor) FetchHTML(ctx context.Context, targetURL string) (string, error) {
 	// Validate URL to prevent SSRF attacks (skip in test mode)
 	if !e.skipSSRFCheck {
 		if err := validateURLForSSRF(targetURL); err != nil {
@@ -148,16 +167,46 @@ func (e *ContentExtractor) Extract(ctx context.Context, targetURL string) (strin
 	if err != nil {
 		return "", fmt.Errorf("failed to read body: %w", err)
 	}
+	return string(body), nil
+}
 
-	// Extract content using readability-like algorithm
-	content := e.extractReadableContent(string(body))
+// minReadableChars is the extracted length below which a page is assumed to
+// build its content with JavaScript.
+const minReadableChars = 200
 
-	// Trim to reasonable length (10k chars)
-	if len(content) > 10000 {
-		content = content[:10000] + "..."
-	}
+// PageRenderer loads a page in a browser and returns the rendered HTML.
+type PageRenderer interface {
+	RenderHTML(ctx context.Context, url string) (string, error)
+}
 
-	return content, nil
+// ExtractRendered is Extract with a rendering fallback: when the static fetch
+// fails or yields little text, the page is rendered with renderer and
+// extracted again. A nil renderer behaves like Extract.
+func (e *ContentExtractor) ExtractRendered(ctx context.Context, targetURL string, renderer PageRenderer) (string, error) {
+	content, err := e.Extract(ctx, targetURL)
+	if renderer == nil || (err == nil && len(content) >= minReadableChars) {
+		return content, err
+	}
+	if !e.skipSSRFCheck {
+		if verr := validateURLForSSRF(targetURL); verr != nil {
+			return "", fmt.Errorf("URL validation failed: %w", verr)
+		}
+	}
+	html, rerr := renderer.RenderHTML(ctx, targetURL)
+	if rerr != nil {
+		if err != nil {
+			return "", err
+		}
+		return content, nil
+	}
+	rendered := e.extractReadableContent(html)
+	if len(rendered) > 10000 {
+		rendered = rendered[:10000] + "..."
+	}
+	if len(rendered) <= len(content) {
+		return content, err
+	}
+	return rendered, nil
 }
 
 // extractReadableContent implements a simplified readability algorithm.
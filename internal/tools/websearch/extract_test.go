@@ -586,3 +586,37 @@ func TestContentExtractor_RealWorldHTML(t *testing.T) {
 		}
 	}
 }
+
+type fakeRenderer struct {
+	html  string
+	calls int
+}
+
+func (r *fakeRenderer) RenderHTML(ctx context.Context, url string) (string, error) {
+	r.calls++
+	return r.html, nil
+}
+
+func TestContentExtractor_ExtractRendered(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		_, _ = w.Write([]byte(`<html><body><div id="app"></div><script>render()</script></body></html>`))
+	}))
+	defer server.Close()
+
+	extractor := NewContentExtractorForTesting()
+	renderer := &fakeRenderer{html: "<html><body><main><p>" + strings.Repeat("Rendered article text. ", 20) + "</p></main></body></html>"}
+
+	content, err := extractor.ExtractRendered(context.Background(), server.URL, renderer)
+	if err != nil {
+		t.Fatalf("ExtractRendered: %v", err)
+	}
+	if renderer.calls != 1 || !strings.Contains(content, "Rendered article text.") {
+		t.Fatalf("expected rendered content, got %q (calls=%d)", content, renderer.calls)
+	}
+
+	content, err = extractor.ExtractRendered(context.Background(), server.URL, nil)
+	if err != nil || strings.Contains(content, "Rendered") {
+		t.Fatalf("expected static content without renderer, got %q, %v", content, err)
+	}
+}
@@ -18,6 +18,7 @@ type FetchConfig struct {
 type WebFetchTool struct {
 	config    FetchConfig
 	extractor *ContentExtractor
+	renderer  PageRenderer
 }
 
 // WebFetchOption customizes WebFetchTool construction.
@@ -32,6 +33,14 @@ func WithExtractor(extractor *ContentExtractor) WebFetchOption {
 	}
 }
 
+// WithRenderer renders pages in a browser when the static fetch yields little
+// readable text.
+func WithRenderer(renderer PageRenderer) WebFetchOption {
+	return func(tool *WebFetchTool) {
+		tool.renderer = renderer
+	}
+}
+
 // NewWebFetchTool creates a new web_fetch tool with defaults applied.
 func NewWebFetchTool(config *FetchConfig, opts ...WebFetchOption) *WebFetchTool {
 	cfg := FetchConfig{MaxChars: 10000}
@@ -60,6 +69,9 @@ func (t *WebFetchTool) Description() string {
 	return "Fetch and extract readable content from a URL without full browser automation."
 }
 
+// Idempotent reports that fetches are plain GETs and may be retried.
+func (t *WebFetchTool) Idempotent() bool { return true }
+
 // Schema returns the JSON schema for tool parameters.
 func (t *WebFetchTool) Schema() json.RawMessage {
 	schema := map[string]interface{}{
@@ -113,7 +125,7 @@ func (t *WebFetchTool) Execute(ctx context.Context, params json.RawMessage) (*ag
 		limit = maxChars
 	}
 
-	content, err := t.extractor.Extract(ctx, url)
+	content, err := t.extractor.ExtractRendered(ctx, url, t.renderer)
 	if err != nil {
 		return &agent.ToolResult{
 			Content: fmt.Sprintf("Fetch failed: %v", err),
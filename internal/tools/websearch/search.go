@@ -147,6 +147,9 @@ func (t *WebSearchTool) Description() string {
 	return "Search the web for information. Supports web search, image search, and news search. Can optionally extract full content from result URLs."
 }
 
+// Idempotent reports that searches have no side effects and may be retried.
+func (t *WebSearchTool) Idempotent() bool { return true }
+
 // Schema returns the JSON schema for tool parameters used by LLMs.
 func (t *WebSearchTool) Schema() json.RawMessage {
 	schema := map[string]interface{}{
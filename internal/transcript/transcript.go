@@ -0,0 +1,449 @@
+// Package transcript renders stored sessions as Markdown or HTML documents
+// that can be shared outside of Nexus.
+package transcript
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/haasonsaas/nexus/internal/config"
+	"github.com/haasonsaas/nexus/internal/status"
+	"github.com/haasonsaas/nexus/pkg/models"
+)
+
+// Format is an output format for rendered transcripts.
+type Format string
+
+const (
+	FormatMarkdown Format = "markdown"
+	FormatHTML     Format = "html"
+)
+
+// maxToolOutput bounds how much of each tool result is included.
+const maxToolOutput = 4000
+
+// ParseFormat parses a format name. An empty value selects Markdown.
+func ParseFormat(value string) (Format, error) {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "", "md", "markdown":
+		return FormatMarkdown, nil
+	case "html", "htm":
+		return FormatHTML, nil
+	}
+	return "", fmt.Errorf("unknown format %q (expected markdown or html)", value)
+}
+
+// Extension returns the file extension for the format, including the dot.
+func (f Format) Extension() string {
+	if f == FormatHTML {
+		return ".html"
+	}
+	return ".md"
+}
+
+// MimeType returns the MIME type for the format.
+func (f Format) MimeType() string {
+	if f == FormatHTML {
+		return "text/html"
+	}
+	return "text/markdown"
+}
+
+// Summary totals the activity in a transcript. Token counts come from the
+// usage the agent runtime records on assistant messages.
+type Summary struct {
+	Messages     int      `json:"messages"`
+	ToolCalls    int      `json:"tool_calls"`
+	InputTokens  int      `json:"input_tokens"`
+	OutputTokens int      `json:"output_tokens"`
+	CostUSD      float64  `json:"cost_usd"`
+	Models       []string `json:"models,omitempty"`
+}
+
+// Transcript is a session and its messages, ready to render.
+type Transcript struct {
+	Session    *models.Session
+	Messages   []*models.Message
+	Summary    Summary
+	ExportedAt time.Time
+}
+
+// New builds a transcript for session. cfg is used to price token usage and
+// may be nil.
+func New(session *models.Session, messages []*models.Message, cfg *config.Config) *Transcript {
+	return &Transcript{
+		Session:    session,
+		Messages:   messages,
+		Summary:    Summarize(messages, cfg),
+		ExportedAt: time.Now(),
+	}
+}
+
+// Summarize totals messages, tool calls, tokens, and estimated cost.
+func Summarize(messages []*models.Message, cfg *config.Config) Summary {
+	var summary Summary
+	seen := map[string]bool{}
+	for _, msg := range messages {
+		if msg == nil {
+			continue
+		}
+		summary.Messages++
+		summary.ToolCalls += len(msg.ToolCalls)
+		if msg.Role != models.RoleAssistant || msg.Metadata == nil {
+			continue
+		}
+		input := metadataInt(msg.Metadata, "input_tokens")
+		output := metadataInt(msg.Metadata, "output_tokens")
+		summary.InputTokens += input
+		summary.OutputTokens += output
+		provider, _ := msg.Metadata["provider"].(string)
+		model, _ := msg.Metadata["model"].(string)
+		if model != "" && !seen[model] {
+			seen[model] = true
+			summary.Models = append(summary.Models, model)
+		}
+		summary.CostUSD += status.EstimateUsageCost(input, output, status.ResolveModelCostConfig(provider, model, cfg))
+	}
+	sort.Strings(summary.Models)
+	return summary
+}
+
+func metadataInt(meta map[string]any, key string) int {
+	switch v := meta[key].(type) {
+	case int:
+		return v
+	case int64:
+		return int(v)
+	case float64:
+		return int(v)
+	case json.Number:
+		n, _ := v.Int64()
+		return int(n)
+	}
+	return 0
+}
+
+// Filename returns a default file name for the transcript in format.
+func (t *Transcript) Filename(format Format) string {
+	id := "session"
+	if t.Session != nil && t.Session.ID != "" {
+		id = t.Session.ID
+		if len(id) > 8 {
+			id = id[:8]
+		}
+		id = "session-" + id
+	}
+	return fmt.Sprintf("%s-%s%s", id, t.ExportedAt.Format("20060102-150405"), format.Extension())
+}
+
+// Render writes the transcript to w in format.
+func (t *Transcript) Render(w io.Writer, format Format) error {
+	switch format {
+	case FormatMarkdown:
+		_, err := io.WriteString(w, t.markdown())
+		return err
+	case FormatHTML:
+		return htmlTemplate.Execute(w, t.htmlView())
+	}
+	return fmt.Errorf("unsupported format %q", format)
+}
+
+// Bytes renders the transcript into memory.
+func (t *Transcript) Bytes(format Format) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := t.Render(&buf, format); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (t *Transcript) title() string {
+	if t.Session != nil && strings.TrimSpace(t.Session.Title) != "" {
+		return t.Session.Title
+	}
+	return "Session transcript"
+}
+
+func (t *Transcript) details() [][2]string {
+	var rows [][2]string
+	if s := t.Session; s != nil {
+		rows = append(rows, [2]string{"Session", s.ID})
+		if s.AgentID != "" {
+			rows = append(rows, [2]string{"Agent", s.AgentID})
+		}
+		if s.Channel != "" {
+			rows = append(rows, [2]string{"Channel", string(s.Channel)})
+		}
+		if !s.CreatedAt.IsZero() {
+			rows = append(rows, [2]string{"Started", s.CreatedAt.Format(time.RFC3339)})
+		}
+	}
+	rows = append(rows, [2]string{"Exported", t.ExportedAt.Format(time.RFC3339)})
+	return rows
+}
+
+func (t *Transcript) summaryRows() [][2]string {
+	s := t.Summary
+	rows := [][2]string{
+		{"Messages", fmt.Sprintf("%d", s.Messages)},
+		{"Tool calls", fmt.Sprintf("%d", s.ToolCalls)},
+		{"Input tokens", fmt.Sprintf("%d", s.InputTokens)},
+		{"Output tokens", fmt.Sprintf("%d", s.OutputTokens)},
+		{"Estimated cost", fmt.Sprintf("$%.4f", s.CostUSD)},
+	}
+	if len(s.Models) > 0 {
+		rows = append(rows, [2]string{"Models", strings.Join(s.Models, ", ")})
+	}
+	return rows
+}
+
+func (t *Transcript) markdown() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "# %s\n\n", t.title())
+	for _, row := range t.details() {
+		fmt.Fprintf(&sb, "- **%s:** %s\n", row[0], row[1])
+	}
+	sb.WriteString("\n---\n")
+
+	for _, msg := range t.Messages {
+		if msg == nil {
+			continue
+		}
+		fmt.Fprintf(&sb, "\n### %s", roleLabel(msg.Role))
+		if !msg.CreatedAt.IsZero() {
+			fmt.Fprintf(&sb, " · %s", msg.CreatedAt.Format("2006-01-02 15:04:05"))
+		}
+		sb.WriteString("\n\n")
+		if content := strings.TrimSpace(msg.Content); content != "" {
+			sb.WriteString(content)
+			sb.WriteString("\n\n")
+		}
+		for _, call := range msg.ToolCalls {
+			fmt.Fprintf(&sb, "**Tool call:** `%s`\n\n", call.Name)
+			if input := formatToolInput(call.Input); input != "" {
+				fmt.Fprintf(&sb, "%s\n\n", fence("json", input))
+			}
+		}
+		for _, result := range msg.ToolResults {
+			label := "Tool result"
+			if result.IsError {
+				label = "Tool error"
+			}
+			fmt.Fprintf(&sb, "**%s:**\n\n%s\n\n", label, fence("", truncate(result.Content)))
+			writeMarkdownAttachments(&sb, result.Attachments)
+		}
+		writeMarkdownAttachments(&sb, msg.Attachments)
+	}
+
+	sb.WriteString("---\n\n## Summary\n\n| | |\n|---|---|\n")
+	for _, row := range t.summaryRows() {
+		fmt.Fprintf(&sb, "| %s | %s |\n", row[0], row[1])
+	}
+	return sb.String()
+}
+
+func writeMarkdownAttachments(sb *strings.Builder, attachments []models.Attachment) {
+	if len(attachments) == 0 {
+		return
+	}
+	sb.WriteString("**Attachments:**\n\n")
+	for _, att := range attachments {
+		name, url := attachmentLink(att)
+		if url == "" {
+			fmt.Fprintf(sb, "- %s\n", name)
+			continue
+		}
+		fmt.Fprintf(sb, "- [%s](%s)\n", name, url)
+	}
+	sb.WriteString("\n")
+}
+
+// fence wraps text in a code fence long enough not to clash with backticks
+// inside it.
+func fence(lang, text string) string {
+	marker := "```"
+	for strings.Contains(text, marker) {
+		marker += "`"
+	}
+	return marker + lang + "\n" + text + "\n" + marker
+}
+
+func roleLabel(role models.Role) string {
+	switch role {
+	case models.RoleUser:
+		return "User"
+	case models.RoleAssistant:
+		return "Assistant"
+	case models.RoleSystem:
+		return "System"
+	case models.RoleTool:
+		return "Tool"
+	}
+	if role == "" {
+		return "Message"
+	}
+	return string(role)
+}
+
+func formatToolInput(input json.RawMessage) string {
+	if len(bytes.TrimSpace(input)) == 0 {
+		return ""
+	}
+	var out bytes.Buffer
+	if err := json.Indent(&out, input, "", "  "); err != nil {
+		return truncate(string(input))
+	}
+	return truncate(out.String())
+}
+
+func truncate(text string) string {
+	text = strings.TrimSpace(text)
+	if len(text) <= maxToolOutput {
+		return text
+	}
+	return text[:maxToolOutput] + fmt.Sprintf("\n… (%d more bytes)", len(text)-maxToolOutput)
+}
+
+// attachmentLink returns a display name and a link for an attachment. Inline
+// data URLs are omitted to keep transcripts small.
+func attachmentLink(att models.Attachment) (string, string) {
+	name := att.Filename
+	if name == "" {
+		name = att.ID
+	}
+	if name == "" {
+		name = att.Type
+	}
+	if name == "" {
+		name = "attachment"
+	}
+	if strings.HasPrefix(att.URL, "data:") {
+		return name + " (inline, not included)", ""
+	}
+	return name, att.URL
+}
+
+type htmlMessage struct {
+	Role        string
+	RoleClass   string
+	Time        string
+	Content     string
+	ToolCalls   []htmlToolCall
+	ToolResults []htmlToolResult
+	Attachments []htmlAttachment
+}
+
+type htmlToolCall struct {
+	Name  string
+	Input string
+}
+
+type htmlToolResult struct {
+	IsError     bool
+	Content     string
+	Attachments []htmlAttachment
+}
+
+type htmlAttachment struct {
+	Name string
+	URL  template.URL
+}
+
+type htmlView struct {
+	Title    string
+	Details  [][2]string
+	Messages []htmlMessage
+	Summary  [][2]string
+}
+
+func (t *Transcript) htmlView() htmlView {
+	view := htmlView{
+		Title:   t.title(),
+		Details: t.details(),
+		Summary: t.summaryRows(),
+	}
+	for _, msg := range t.Messages {
+		if msg == nil {
+			continue
+		}
+		hm := htmlMessage{
+			Role:        roleLabel(msg.Role),
+			RoleClass:   strings.ToLower(string(msg.Role)),
+			Content:     strings.TrimSpace(msg.Content),
+			Attachments: htmlAttachments(msg.Attachments),
+		}
+		if !msg.CreatedAt.IsZero() {
+			hm.Time = msg.CreatedAt.Format("2006-01-02 15:04:05")
+		}
+		for _, call := range msg.ToolCalls {
+			hm.ToolCalls = append(hm.ToolCalls, htmlToolCall{Name: call.Name, Input: formatToolInput(call.Input)})
+		}
+		for _, result := range msg.ToolResults {
+			hm.ToolResults = append(hm.ToolResults, htmlToolResult{
+				IsError:     result.IsError,
+				Content:     truncate(result.Content),
+				Attachments: htmlAttachments(result.Attachments),
+			})
+		}
+		view.Messages = append(view.Messages, hm)
+	}
+	return view
+}
+
+func htmlAttachments(attachments []models.Attachment) []htmlAttachment {
+	var out []htmlAttachment
+	for _, att := range attachments {
+		name, url := attachmentLink(att)
+		entry := htmlAttachment{Name: name}
+		// Only link to web URLs; anything else is shown as plain text.
+		if strings.HasPrefix(url, "https://") || strings.HasPrefix(url, "http://") {
+			entry.URL = template.URL(url)
+		}
+		out = append(out, entry)
+	}
+	return out
+}
+
+var htmlTemplate = template.Must(template.New("transcript").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>{{.Title}}</title>
+<style>
+body { font-family: -apple-system, BlinkMacSystemFont, "Segoe UI", sans-serif; max-width: 860px; margin: 2rem auto; padding: 0 1rem; color: #1f2328; line-height: 1.5; }
+h1 { margin-bottom: 0.5rem; }
+dl.details { display: grid; grid-template-columns: max-content auto; gap: 0.25rem 1rem; color: #57606a; }
+dl.details dt { font-weight: 600; }
+dl.details dd { margin: 0; }
+.message { border: 1px solid #d0d7de; border-radius: 8px; padding: 0.75rem 1rem; margin: 1rem 0; }
+.message.user { background: #f6f8fa; }
+.message.tool { background: #fbfbf6; }
+.meta { font-size: 0.85rem; color: #57606a; margin-bottom: 0.5rem; }
+.meta strong { color: #1f2328; }
+.content { white-space: pre-wrap; }
+pre { background: #f6f8fa; border-radius: 6px; padding: 0.5rem 0.75rem; overflow-x: auto; white-space: pre-wrap; }
+.tool-error pre { background: #ffebe9; }
+table.summary { border-collapse: collapse; }
+table.summary td { border: 1px solid #d0d7de; padding: 0.25rem 0.75rem; }
+</style>
+</head>
+<body>
+<h1>{{.Title}}</h1>
+<dl class="details">{{range .Details}}<dt>{{index . 0}}</dt><dd>{{index . 1}}</dd>{{end}}</dl>
+{{range .Messages}}<div class="message {{.RoleClass}}">
+<div class="meta"><strong>{{.Role}}</strong>{{if .Time}} · {{.Time}}{{end}}</div>
+{{if .Content}}<div class="content">{{.Content}}</div>{{end}}
+{{range .ToolCalls}}<div class="tool-call">Tool call: <code>{{.Name}}</code>{{if .Input}}<pre>{{.Input}}</pre>{{end}}</div>
+{{end}}{{range .ToolResults}}<div class="tool-result{{if .IsError}} tool-error{{end}}">{{if .IsError}}Tool error{{else}}Tool result{{end}}:<pre>{{.Content}}</pre>{{template "attachments" .Attachments}}</div>
+{{end}}{{template "attachments" .Attachments}}</div>
+{{end}}<h2>Summary</h2>
+<table class="summary">{{range .Summary}}<tr><td>{{index . 0}}</td><td>{{index . 1}}</td></tr>{{end}}</table>
+</body>
+</html>
+{{define "attachments"}}{{if .}}<ul class="attachments">{{range .}}<li>{{if .URL}}<a href="{{.URL}}">{{.Name}}</a>{{else}}{{.Name}}{{end}}</li>{{end}}</ul>{{end}}{{end}}`))
@@ -0,0 +1,110 @@
+package transcript
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/haasonsaas/nexus/pkg/models"
+)
+
+func testTranscript() *Transcript {
+	session := &models.Session{ID: "0123456789abcdef", AgentID: "main", Channel: models.ChannelTelegram}
+	created := time.Date(2026, 10, 1, 9, 30, 0, 0, time.UTC)
+	messages := []*models.Message{
+		{Role: models.RoleUser, Content: "What's the weather <today>?", CreatedAt: created},
+		{
+			Role:      models.RoleAssistant,
+			ToolCalls: []models.ToolCall{{ID: "call_1", Name: "web_search", Input: json.RawMessage(`{"query":"weather"}`)}},
+			Metadata:  map[string]any{"provider": "anthropic", "model": "claude-sonnet-4-20250514", "input_tokens": float64(1000), "output_tokens": 200},
+		},
+		{
+			Role: models.RoleTool,
+			ToolResults: []models.ToolResult{{
+				ToolCallID:  "call_1",
+				Content:     "Sunny, 21C",
+				Attachments: []models.Attachment{{Filename: "chart.png", URL: "https://example.com/chart.png"}},
+			}},
+		},
+		{
+			Role:        models.RoleAssistant,
+			Content:     "It is sunny.",
+			Attachments: []models.Attachment{{Filename: "shot.png", URL: "data:image/png;base64,AAAA"}},
+			Metadata:    map[string]any{"provider": "anthropic", "model": "claude-sonnet-4-20250514", "input_tokens": 1500, "output_tokens": 100},
+		},
+	}
+	doc := New(session, messages, nil)
+	doc.ExportedAt = time.Date(2026, 10, 2, 8, 0, 0, 0, time.UTC)
+	return doc
+}
+
+func TestSummarize(t *testing.T) {
+	summary := testTranscript().Summary
+	if summary.Messages != 4 || summary.ToolCalls != 1 {
+		t.Fatalf("unexpected counts: %+v", summary)
+	}
+	if summary.InputTokens != 2500 || summary.OutputTokens != 300 {
+		t.Fatalf("unexpected tokens: %+v", summary)
+	}
+	// 2500 input at $3/M plus 300 output at $15/M.
+	if want := 0.012; summary.CostUSD < want-1e-9 || summary.CostUSD > want+1e-9 {
+		t.Fatalf("CostUSD = %v, want %v", summary.CostUSD, want)
+	}
+	if len(summary.Models) != 1 {
+		t.Fatalf("Models = %v", summary.Models)
+	}
+}
+
+func TestRenderMarkdown(t *testing.T) {
+	out, err := testTranscript().Bytes(FormatMarkdown)
+	if err != nil {
+		t.Fatalf("Render: %v", err)
+	}
+	text := string(out)
+	for _, want := range []string{
+		"# Session transcript",
+		"- **Session:** 0123456789abcdef",
+		"### User · 2026-10-01 09:30:00",
+		"**Tool call:** `web_search`",
+		"\"query\": \"weather\"",
+		"**Tool result:**",
+		"- [chart.png](https://example.com/chart.png)",
+		"- shot.png (inline, not included)",
+		"| Input tokens | 2500 |",
+		"| Estimated cost | $0.0120 |",
+	} {
+		if !strings.Contains(text, want) {
+			t.Errorf("markdown missing %q:\n%s", want, text)
+		}
+	}
+}
+
+func TestRenderHTMLEscapes(t *testing.T) {
+	out, err := testTranscript().Bytes(FormatHTML)
+	if err != nil {
+		t.Fatalf("Render: %v", err)
+	}
+	text := string(out)
+	if !strings.Contains(text, "What&#39;s the weather &lt;today&gt;?") {
+		t.Errorf("expected escaped user content:\n%s", text)
+	}
+	if !strings.Contains(text, `<a href="https://example.com/chart.png">chart.png</a>`) {
+		t.Errorf("expected attachment link:\n%s", text)
+	}
+	if strings.Contains(text, "data:image/png") {
+		t.Error("inline data URLs should not be embedded")
+	}
+}
+
+func TestParseFormatAndFilename(t *testing.T) {
+	if f, err := ParseFormat("HTML"); err != nil || f != FormatHTML {
+		t.Fatalf("ParseFormat(HTML) = %q, %v", f, err)
+	}
+	if _, err := ParseFormat("pdf"); err == nil {
+		t.Fatal("expected error for pdf")
+	}
+	if got := testTranscript().Filename(FormatMarkdown); got != "session-01234567-20261002-080000.md" {
+		t.Fatalf("Filename = %q", got)
+	}
+}
@@ -49,17 +49,40 @@ func decodeJSONRequest(w http.ResponseWriter, r *http.Request, dst any) (int, er
 
 // SystemStatus holds system health information.
 type SystemStatus struct {
-	Uptime         time.Duration       `json:"uptime"`
-	UptimeString   string              `json:"uptime_string"`
-	GoVersion      string              `json:"go_version"`
-	NumGoroutines  int                 `json:"num_goroutines"`
-	MemAllocMB     float64             `json:"mem_alloc_mb"`
-	MemSysMB       float64             `json:"mem_sys_mb"`
-	NumCPU         int                 `json:"num_cpu"`
-	SessionCount   int                 `json:"session_count"`
-	DatabaseStatus string              `json:"database_status"`
-	Channels       []ChannelStatus     `json:"channels"`
-	HealthChecks   *infra.HealthReport `json:"health_checks,omitempty"`
+	Uptime         time.Duration        `json:"uptime"`
+	UptimeString   string               `json:"uptime_string"`
+	GoVersion      string               `json:"go_version"`
+	NumGoroutines  int                  `json:"num_goroutines"`
+	MemAllocMB     float64              `json:"mem_alloc_mb"`
+	MemSysMB       float64              `json:"mem_sys_mb"`
+	NumCPU         int                  `json:"num_cpu"`
+	SessionCount   int                  `json:"session_count"`
+	DatabaseStatus string               `json:"database_status"`
+	Channels       []ChannelStatus      `json:"channels"`
+	HealthChecks   *infra.HealthReport  `json:"health_checks,omitempty"`
+	Steering       []SteeringRuleStatus `json:"steering,omitempty"`
+	Anomalies      []AnomalyStatus      `json:"anomalies,omitempty"`
+}
+
+// AnomalyStatus describes an active usage anomaly.
+type AnomalyStatus struct {
+	Signal   string    `json:"signal"`
+	Key      string    `json:"key,omitempty"`
+	Value    float64   `json:"value"`
+	Baseline float64   `json:"baseline"`
+	ZScore   float64   `json:"z_score"`
+	Since    time.Time `json:"since"`
+}
+
+// SteeringRuleStatus holds a steering rule's hit count since the gateway
+// started.
+type SteeringRuleStatus struct {
+	ID       string    `json:"id"`
+	Name     string    `json:"name,omitempty"`
+	Enabled  bool      `json:"enabled"`
+	Priority int       `json:"priority,omitempty"`
+	Hits     int64     `json:"hits"`
+	LastHit  time.Time `json:"last_hit,omitempty"`
 }
 
 // ChannelStatus holds channel health information.
@@ -175,6 +198,13 @@ func (h *Handler) getSystemStatus(ctx context.Context) *SystemStatus {
 		status.HealthChecks = &report
 	}
 
+	if h.config.SteeringStatus != nil {
+		status.Steering = h.config.SteeringStatus()
+	}
+	if h.config.AnomalyStatus != nil {
+		status.Anomalies = h.config.AnomalyStatus()
+	}
+
 	return status
 }
 
@@ -1,10 +1,12 @@
 package web
 
 import (
+	"errors"
 	"io"
 	"mime"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/haasonsaas/nexus/internal/artifacts"
 )
@@ -74,12 +76,14 @@ func (h *Handler) apiArtifacts(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
-// apiArtifact handles GET /api/artifacts/{id}.
+// APIArtifactLinkResponse is the JSON response for a signed artifact link.
+type APIArtifactLinkResponse struct {
+	URL       string    `json:"url"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// apiArtifact handles GET /api/artifacts/{id} and POST /api/artifacts/{id}/link.
 func (h *Handler) apiArtifact(w http.ResponseWriter, r *http.Request) {
-	if r.Method != http.MethodGet {
-		h.jsonError(w, "Method not allowed", http.StatusMethodNotAllowed)
-		return
-	}
 	if h.config.ArtifactRepo == nil {
 		h.jsonError(w, "Artifacts not configured (set artifacts.backend)", http.StatusServiceUnavailable)
 		return
@@ -93,6 +97,26 @@ func (h *Handler) apiArtifact(w http.ResponseWriter, r *http.Request) {
 	}
 	artifactID := parts[0]
 
+	if len(parts) > 1 && parts[1] == "link" {
+		h.apiArtifactLink(w, r, artifactID)
+		return
+	}
+	if r.Method != http.MethodGet {
+		h.jsonError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	raw := strings.EqualFold(r.URL.Query().Get("raw"), "1") || strings.EqualFold(r.URL.Query().Get("raw"), "true")
+	download := strings.EqualFold(r.URL.Query().Get("download"), "1") || strings.EqualFold(r.URL.Query().Get("download"), "true")
+
+	// Raw downloads of artifacts in a presigning store go straight to the bucket.
+	if raw {
+		if presigned, _, ok := h.presignArtifact(r, artifactID, artifacts.PresignOptions{Download: download}); ok {
+			http.Redirect(w, r, presigned, http.StatusFound)
+			return
+		}
+	}
+
 	artifact, reader, err := h.config.ArtifactRepo.GetArtifact(r.Context(), artifactID)
 	if err != nil {
 		if strings.Contains(err.Error(), "not found") || strings.Contains(err.Error(), "expired") {
@@ -105,9 +129,6 @@ func (h *Handler) apiArtifact(w http.ResponseWriter, r *http.Request) {
 	}
 	defer reader.Close()
 
-	raw := strings.EqualFold(r.URL.Query().Get("raw"), "1") || strings.EqualFold(r.URL.Query().Get("raw"), "true")
-	download := strings.EqualFold(r.URL.Query().Get("download"), "1") || strings.EqualFold(r.URL.Query().Get("download"), "true")
-
 	if raw {
 		if strings.HasPrefix(artifact.Reference, "redacted://") {
 			http.Error(w, "Artifact redacted", http.StatusGone)
@@ -144,6 +165,68 @@ func (h *Handler) apiArtifact(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
+// apiArtifactLink handles POST /api/artifacts/{id}/link?ttl=1h.
+func (h *Handler) apiArtifactLink(w http.ResponseWriter, r *http.Request, artifactID string) {
+	if r.Method != http.MethodPost {
+		h.jsonError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	var ttl time.Duration
+	if raw := strings.TrimSpace(r.URL.Query().Get("ttl")); raw != "" {
+		parsed, err := time.ParseDuration(raw)
+		if err != nil || parsed <= 0 {
+			h.jsonError(w, "Invalid ttl", http.StatusBadRequest)
+			return
+		}
+		ttl = parsed
+	}
+	if h.config.ArtifactLinks == nil {
+		// Without gateway links, fall back to a presigned bucket URL.
+		if presigned, expires, ok := h.presignArtifact(r, artifactID, artifacts.PresignOptions{TTL: ttl}); ok {
+			h.jsonResponse(w, APIArtifactLinkResponse{URL: presigned, ExpiresAt: expires})
+			return
+		}
+		h.jsonError(w, "Artifact links not configured (set artifacts.links.enabled or artifacts.s3_presign)", http.StatusServiceUnavailable)
+		return
+	}
+
+	artifact, reader, err := h.config.ArtifactRepo.GetArtifact(r.Context(), artifactID)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") || strings.Contains(err.Error(), "expired") {
+			h.jsonError(w, "Artifact not found", http.StatusNotFound)
+		} else {
+			h.config.Logger.Error("failed to get artifact", "id", artifactID, "error", err)
+			h.jsonError(w, "Failed to retrieve artifact", http.StatusInternalServerError)
+		}
+		return
+	}
+	reader.Close()
+	if strings.HasPrefix(artifact.Reference, "redacted://") {
+		h.jsonError(w, "Artifact redacted", http.StatusGone)
+		return
+	}
+
+	link, expires := h.config.ArtifactLinks.URL(artifact.Id, ttl)
+	h.jsonResponse(w, APIArtifactLinkResponse{URL: link, ExpiresAt: expires})
+}
+
+// presignArtifact returns a presigned download URL for the artifact when
+// the repository can issue one.
+func (h *Handler) presignArtifact(r *http.Request, artifactID string, opts artifacts.PresignOptions) (string, time.Time, bool) {
+	presigner, ok := h.config.ArtifactRepo.(artifacts.Presigner)
+	if !ok {
+		return "", time.Time{}, false
+	}
+	presigned, expires, err := presigner.PresignArtifact(r.Context(), artifactID, opts)
+	if err != nil {
+		if !errors.Is(err, artifacts.ErrPresignUnsupported) {
+			h.config.Logger.Debug("artifact presign failed", "id", artifactID, "error", err)
+		}
+		return "", time.Time{}, false
+	}
+	return presigned, expires, true
+}
+
 func sanitizeAttachmentFilename(name string) string {
 	name = strings.ReplaceAll(name, "\r", "")
 	name = strings.ReplaceAll(name, "\n", "")
@@ -1,11 +1,14 @@
 package web
 
 import (
+	"fmt"
 	"log/slog"
 	"net/http"
+	"net/url"
 	"strings"
 	"time"
 
+	"github.com/haasonsaas/nexus/internal/audit"
 	"github.com/haasonsaas/nexus/internal/auth"
 )
 
@@ -34,6 +37,9 @@ func LoggingMiddleware(logger *slog.Logger) func(http.Handler) http.Handler {
 	}
 }
 
+// LoginPath starts a single sign-on login; see auth.OIDCProvider.
+const LoginPath = "/auth/oidc/login"
+
 // AuthMiddleware enforces authentication for HTTP requests.
 func AuthMiddleware(service *auth.Service, logger *slog.Logger) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
@@ -54,9 +60,9 @@ func AuthMiddleware(service *auth.Service, logger *slog.Logger) func(http.Handle
 			authHeader := r.Header.Get("Authorization")
 			if strings.HasPrefix(strings.ToLower(authHeader), "bearer ") {
 				token := strings.TrimSpace(authHeader[7:])
-				user, err := service.ValidateJWT(token)
+				user, scopes, err := service.AuthenticateJWT(token)
 				if err == nil {
-					ctx := auth.WithUser(r.Context(), user)
+					ctx := auth.WithScopes(auth.WithUser(r.Context(), user), scopes)
 					next.ServeHTTP(w, r.WithContext(ctx))
 					return
 				}
@@ -71,9 +77,9 @@ func AuthMiddleware(service *auth.Service, logger *slog.Logger) func(http.Handle
 				apiKey = r.Header.Get("Api-Key")
 			}
 			if apiKey != "" {
-				user, err := service.ValidateAPIKey(apiKey)
+				user, scopes, err := service.AuthenticateAPIKey(apiKey)
 				if err == nil {
-					ctx := auth.WithUser(r.Context(), user)
+					ctx := auth.WithScopes(auth.WithUser(r.Context(), user), scopes)
 					next.ServeHTTP(w, r.WithContext(ctx))
 					return
 				}
@@ -85,9 +91,9 @@ func AuthMiddleware(service *auth.Service, logger *slog.Logger) func(http.Handle
 			// Try cookie-based session
 			cookie, err := r.Cookie("nexus_session")
 			if err == nil && cookie.Value != "" {
-				user, err := service.ValidateJWT(cookie.Value)
+				user, scopes, err := service.AuthenticateJWT(cookie.Value)
 				if err == nil {
-					ctx := auth.WithUser(r.Context(), user)
+					ctx := auth.WithScopes(auth.WithUser(r.Context(), user), scopes)
 					next.ServeHTTP(w, r.WithContext(ctx))
 					return
 				}
@@ -96,9 +102,9 @@ func AuthMiddleware(service *auth.Service, logger *slog.Logger) func(http.Handle
 			// Check for query parameter token (for htmx requests)
 			tokenParam := r.URL.Query().Get("token")
 			if tokenParam != "" {
-				user, err := service.ValidateJWT(tokenParam)
+				user, scopes, err := service.AuthenticateJWT(tokenParam)
 				if err == nil {
-					ctx := auth.WithUser(r.Context(), user)
+					ctx := auth.WithScopes(auth.WithUser(r.Context(), user), scopes)
 					next.ServeHTTP(w, r.WithContext(ctx))
 					return
 				}
@@ -114,6 +120,12 @@ func AuthMiddleware(service *auth.Service, logger *slog.Logger) func(http.Handle
 				return
 			}
 
+			// Send browsers through single sign-on when it is configured
+			if service.OIDC() != nil && r.Method == http.MethodGet {
+				http.Redirect(w, r, LoginPath+"?redirect="+url.QueryEscape(r.URL.RequestURI()), http.StatusFound)
+				return
+			}
+
 			// For browser requests, show 401 page
 			w.Header().Set("Content-Type", "text/html; charset=utf-8")
 			w.WriteHeader(http.StatusUnauthorized)
@@ -131,6 +143,74 @@ func AuthMiddleware(service *auth.Service, logger *slog.Logger) func(http.Handle
 	}
 }
 
+// RouteScope returns the API key scope needed for a request: "<resource>:read"
+// for GET and HEAD, "<resource>:write" otherwise. Config writes need
+// auth.ScopeAdmin. Static files need no scope.
+func RouteScope(method, path string) string {
+	if strings.HasPrefix(path, "/static/") {
+		return ""
+	}
+	resource := "system"
+	switch {
+	case hasPathPrefix(path, "/api/sessions"), hasPathPrefix(path, "/sessions"):
+		resource = "sessions"
+	case hasPathPrefix(path, "/api/usage"), hasPathPrefix(path, "/api/v1/analytics"), hasPathPrefix(path, "/analytics"):
+		resource = "usage"
+	case hasPathPrefix(path, "/api/config"), hasPathPrefix(path, "/config"):
+		resource = "config"
+	case hasPathPrefix(path, "/webchat"), hasPathPrefix(path, "/api/v1/ha"):
+		resource = "messages"
+	}
+	if method == http.MethodGet || method == http.MethodHead {
+		return resource + ":read"
+	}
+	if resource == "config" {
+		return auth.ScopeAdmin
+	}
+	return resource + ":write"
+}
+
+// ScopeMiddleware rejects requests whose API key scopes do not cover
+// RouteScope. Callers without scopes pass through. Attempts at admin-only
+// requests are recorded in auditLogger, which may be nil.
+func ScopeMiddleware(auditLogger *audit.Logger, logger *slog.Logger) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			scope := RouteScope(r.Method, r.URL.Path)
+			if scope == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+			allowed := auth.HasScope(r.Context(), scope)
+			if scope == auth.ScopeAdmin {
+				userID := ""
+				if user, ok := auth.UserFromContext(r.Context()); ok && user != nil {
+					userID = user.ID
+				}
+				auditLogger.LogAdminAction(r.Context(), allowed, userID, "http "+r.Method+" "+r.URL.Path, map[string]any{
+					"remote_addr": r.RemoteAddr,
+				})
+			}
+			if allowed {
+				next.ServeHTTP(w, r)
+				return
+			}
+			if logger != nil {
+				logger.Warn("api key scope denied", "path", r.URL.Path, "method", r.Method, "scope", scope)
+			}
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusForbidden)
+			if _, err := fmt.Fprintf(w, `{"error":"forbidden","required_scope":%q}`, scope); err != nil {
+				return
+			}
+		})
+	}
+}
+
+func hasPathPrefix(path, prefix string) bool {
+	return path == prefix || strings.HasPrefix(path, prefix+"/")
+}
+
 // CORSMiddleware adds CORS headers for API requests.
 func CORSMiddleware(allowedOrigins []string) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
@@ -5,7 +5,10 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"strings"
 	"testing"
+
+	"github.com/haasonsaas/nexus/internal/auth"
 )
 
 func testLogger() *slog.Logger {
@@ -99,6 +102,59 @@ func TestAuthMiddleware(t *testing.T) {
 	})
 }
 
+func TestScopeMiddleware(t *testing.T) {
+	service := auth.NewService(auth.Config{APIKeys: []auth.APIKeyConfig{
+		{Key: "viewer", UserID: "viewer", Role: auth.RoleReadOnly},
+		{Key: "ops", UserID: "ops", Role: auth.RoleOperator},
+		{Key: "root", UserID: "root", Role: auth.RoleAdmin},
+	}})
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	wrapped := AuthMiddleware(service, testLogger())(ScopeMiddleware(nil, testLogger())(handler))
+
+	tests := []struct {
+		key    string
+		method string
+		path   string
+		want   int
+	}{
+		{"viewer", http.MethodGet, "/api/sessions", http.StatusOK},
+		{"viewer", http.MethodPost, "/api/skills/refresh", http.StatusForbidden},
+		{"ops", http.MethodPost, "/api/skills/refresh", http.StatusOK},
+		{"ops", http.MethodGet, "/api/config", http.StatusOK},
+		{"ops", http.MethodPatch, "/api/config", http.StatusForbidden},
+		{"root", http.MethodPatch, "/api/config", http.StatusOK},
+		{"viewer", http.MethodGet, "/static/css/style.css", http.StatusOK},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest(tt.method, tt.path, nil)
+		req.Header.Set("X-API-Key", tt.key)
+		rec := httptest.NewRecorder()
+		wrapped.ServeHTTP(rec, req)
+		if rec.Code != tt.want {
+			t.Errorf("%s %s %s: status = %d, want %d", tt.key, tt.method, tt.path, rec.Code, tt.want)
+		}
+	}
+}
+
+func TestRouteScope(t *testing.T) {
+	tests := map[string]string{
+		"GET /api/usage/costs":         "usage:read",
+		"GET /sessions/abc":            "sessions:read",
+		"POST /api/providers/x/test":   "system:write",
+		"POST /api/config":             auth.ScopeAdmin,
+		"GET /configs":                 "system:read",
+		"POST /api/v1/ha/conversation": "messages:write",
+	}
+	for request, want := range tests {
+		method, path, _ := strings.Cut(request, " ")
+		if got := RouteScope(method, path); got != want {
+			t.Errorf("RouteScope(%s) = %q, want %q", request, got, want)
+		}
+	}
+}
+
 func TestCORSMiddleware(t *testing.T) {
 	t.Run("allows wildcard origin", func(t *testing.T) {
 		handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
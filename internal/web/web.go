@@ -14,6 +14,7 @@ import (
 	"time"
 
 	"github.com/haasonsaas/nexus/internal/artifacts"
+	"github.com/haasonsaas/nexus/internal/audit"
 	"github.com/haasonsaas/nexus/internal/auth"
 	"github.com/haasonsaas/nexus/internal/channels"
 	"github.com/haasonsaas/nexus/internal/config"
@@ -43,6 +44,8 @@ type Config struct {
 	SessionStore sessions.Store
 	// ArtifactRepo for accessing stored artifacts (optional)
 	ArtifactRepo artifacts.Repository
+	// ArtifactLinks signs shareable artifact download links (optional)
+	ArtifactLinks *artifacts.LinkSigner
 	// ChannelRegistry for provider status and QR login
 	ChannelRegistry *channels.Registry
 	// CronScheduler for listing cron jobs
@@ -57,6 +60,11 @@ type Config struct {
 	UsageCache *usage.UsageCache
 	// ToolSummaryProvider supplies core + MCP tool metadata (optional)
 	ToolSummaryProvider ToolSummaryProvider
+	// SteeringStatus reports steering rule hit counts (optional)
+	SteeringStatus func() []SteeringRuleStatus
+
+	// AnomalyStatus reports active usage anomalies (optional)
+	AnomalyStatus func() []AnomalyStatus
 	// GatewayConfig is the active runtime configuration (for summary views)
 	GatewayConfig *config.Config
 	// ConfigManager exposes config control plane operations (optional)
@@ -65,6 +73,8 @@ type Config struct {
 	ConfigPath string
 	// DefaultAgentID is the agent ID used for listing sessions
 	DefaultAgentID string
+	// AuditLogger records admin-only requests such as config writes (optional)
+	AuditLogger *audit.Logger
 	// Logger for request logging
 	Logger *slog.Logger
 	// ServerStartTime for uptime calculation
@@ -81,6 +91,8 @@ type Handler struct {
 	config    *Config
 	templates *template.Template
 	mux       *http.ServeMux
+	// routes is mux behind API key scope checks.
+	routes http.Handler
 
 	qrMu      sync.RWMutex
 	qrCodes   map[models.ChannelType]string
@@ -131,6 +143,7 @@ func NewHandler(cfg *Config) (*Handler, error) {
 	}
 
 	h.setupRoutes()
+	h.routes = ScopeMiddleware(cfg.AuditLogger, cfg.Logger)(h.mux)
 	return h, nil
 }
 
@@ -194,7 +207,7 @@ func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	}
 	r.URL.Path = path
 
-	h.mux.ServeHTTP(w, r)
+	h.routes.ServeHTTP(w, r)
 }
 
 // Mount returns the handler with middleware applied.
@@ -0,0 +1,251 @@
+package webwatch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ErrNotFound is returned when a watch does not exist in the caller's
+// session.
+var ErrNotFound = errors.New("web watch not found")
+
+// Fetcher retrieves the raw HTML of a page.
+type Fetcher interface {
+	FetchHTML(ctx context.Context, url string) (string, error)
+}
+
+// Config bounds what sessions may register.
+type Config struct {
+	// DefaultInterval is used when a watch does not specify one.
+	DefaultInterval time.Duration
+	// MinInterval is the shortest allowed interval; shorter requests are
+	// raised to it.
+	MinInterval time.Duration
+	// MaxPerSession limits watches per session. Zero means unlimited.
+	MaxPerSession int
+}
+
+// Monitor holds the registered watches and checks the ones that are due.
+type Monitor struct {
+	cfg     Config
+	fetcher Fetcher
+
+	mu      sync.Mutex
+	watches map[string]*Watch
+	store   Store
+	onError func(error)
+}
+
+// NewMonitor creates a monitor that fetches pages with fetcher.
+func NewMonitor(cfg Config, fetcher Fetcher) *Monitor {
+	if cfg.DefaultInterval <= 0 {
+		cfg.DefaultInterval = time.Hour
+	}
+	return &Monitor{
+		cfg:     cfg,
+		fetcher: fetcher,
+		watches: make(map[string]*Watch),
+	}
+}
+
+// Load replaces the in-memory watches with those in store and persists
+// subsequent changes to it.
+func (m *Monitor) Load(ctx context.Context, store Store) error {
+	watches, err := store.List(ctx)
+	if err != nil {
+		return err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.store = store
+	m.watches = make(map[string]*Watch, len(watches))
+	for _, watch := range watches {
+		m.watches[watch.ID] = watch
+	}
+	return nil
+}
+
+// OnStoreError registers a callback for failed store writes.
+func (m *Monitor) OnStoreError(fn func(error)) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.onError = fn
+}
+
+// Add registers a watch. The first check runs on the next tick and records
+// the baseline snapshot.
+func (m *Monitor) Add(ctx context.Context, watch *Watch) (*Watch, error) {
+	if watch == nil {
+		return nil, errors.New("watch is required")
+	}
+	condition, err := ParseCondition(string(watch.Condition))
+	if err != nil {
+		return nil, err
+	}
+	watch.Condition = condition
+	watch.URL = strings.TrimSpace(watch.URL)
+	watch.Selector = strings.TrimSpace(watch.Selector)
+	if err := watch.Validate(); err != nil {
+		return nil, err
+	}
+	if watch.Interval <= 0 {
+		watch.Interval = m.cfg.DefaultInterval
+	}
+	if watch.Interval < m.cfg.MinInterval {
+		watch.Interval = m.cfg.MinInterval
+	}
+
+	m.mu.Lock()
+	if m.cfg.MaxPerSession > 0 && m.countLocked(watch.SessionID) >= m.cfg.MaxPerSession {
+		m.mu.Unlock()
+		return nil, fmt.Errorf("session already has %d web watches (limit %d)", m.cfg.MaxPerSession, m.cfg.MaxPerSession)
+	}
+	now := time.Now()
+	if watch.ID == "" {
+		watch.ID = uuid.NewString()
+	}
+	watch.Armed = true
+	watch.CreatedAt = now
+	watch.NextCheckAt = now
+	m.watches[watch.ID] = watch
+	saved := *watch
+	m.mu.Unlock()
+
+	m.save(ctx, &saved)
+	return &saved, nil
+}
+
+// List returns copies of the session's watches, oldest first. An empty
+// session ID lists every watch.
+func (m *Monitor) List(sessionID string) []*Watch {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var out []*Watch
+	for _, watch := range m.watches {
+		if sessionID != "" && watch.SessionID != sessionID {
+			continue
+		}
+		copied := *watch
+		out = append(out, &copied)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].CreatedAt.Before(out[j].CreatedAt) })
+	return out
+}
+
+// Remove deletes a watch owned by sessionID.
+func (m *Monitor) Remove(ctx context.Context, sessionID, id string) error {
+	m.mu.Lock()
+	watch, ok := m.watches[id]
+	if !ok || watch.SessionID != sessionID {
+		m.mu.Unlock()
+		return ErrNotFound
+	}
+	delete(m.watches, id)
+	store, onError := m.store, m.onError
+	m.mu.Unlock()
+
+	if store != nil {
+		if err := store.Delete(ctx, id); err != nil && onError != nil {
+			onError(err)
+		}
+	}
+	return nil
+}
+
+// CheckDue fetches every watch due at now, updates its snapshot, and returns
+// the watches whose condition triggered.
+func (m *Monitor) CheckDue(ctx context.Context, now time.Time) []Event {
+	m.mu.Lock()
+	var due []*Watch
+	for _, watch := range m.watches {
+		if !watch.NextCheckAt.After(now) {
+			copied := *watch
+			due = append(due, &copied)
+		}
+	}
+	m.mu.Unlock()
+	sort.Slice(due, func(i, j int) bool { return due[i].NextCheckAt.Before(due[j].NextCheckAt) })
+
+	var events []Event
+	for _, snapshot := range due {
+		if ctx.Err() != nil {
+			break
+		}
+		value, fetchErr := m.fetchValue(ctx, snapshot)
+
+		m.mu.Lock()
+		watch, ok := m.watches[snapshot.ID]
+		if !ok {
+			// Removed while the page was being fetched.
+			m.mu.Unlock()
+			continue
+		}
+		watch.NextCheckAt = now.Add(watch.Interval)
+		var event Event
+		triggered := false
+		if fetchErr != nil {
+			watch.LastCheckedAt = now
+			watch.LastError = fetchErr.Error()
+			watch.Failures++
+		} else {
+			event, triggered = watch.observe(value, now)
+		}
+		saved := *watch
+		m.mu.Unlock()
+
+		m.save(ctx, &saved)
+		if triggered {
+			event.Watch = &saved
+			events = append(events, event)
+		}
+	}
+	return events
+}
+
+// fetchValue fetches the page and extracts the watched text.
+func (m *Monitor) fetchValue(ctx context.Context, watch *Watch) (string, error) {
+	if m.fetcher == nil {
+		return "", errors.New("no fetcher configured")
+	}
+	page, err := m.fetcher.FetchHTML(ctx, watch.URL)
+	if err != nil {
+		return "", err
+	}
+	value, ok, err := ExtractValue(page, watch.Selector)
+	if err != nil {
+		return "", err
+	}
+	if !ok {
+		return "", fmt.Errorf("selector %q matched nothing", watch.Selector)
+	}
+	return value, nil
+}
+
+func (m *Monitor) countLocked(sessionID string) int {
+	count := 0
+	for _, watch := range m.watches {
+		if watch.SessionID == sessionID {
+			count++
+		}
+	}
+	return count
+}
+
+func (m *Monitor) save(ctx context.Context, watch *Watch) {
+	m.mu.Lock()
+	store, onError := m.store, m.onError
+	m.mu.Unlock()
+	if store == nil {
+		return
+	}
+	if err := store.Save(ctx, watch); err != nil && onError != nil {
+		onError(err)
+	}
+}
@@ -0,0 +1,231 @@
+package webwatch
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// maxValueChars caps the snapshot stored per watch.
+const maxValueChars = 4000
+
+// selectorPart matches a single element: a tag name with optional id,
+// classes, and attribute tests, as in `div#main.price[data-id=42]`.
+type selectorPart struct {
+	tag     string
+	id      string
+	classes []string
+	attrs   []attrTest
+}
+
+type attrTest struct {
+	name  string
+	value string
+	exact bool
+}
+
+// selector is a chain of parts joined by descendant combinators.
+type selector []selectorPart
+
+// parseSelector parses the supported CSS subset: type, #id, .class,
+// [attr] and [attr=value] selectors joined by whitespace. Comma-separated
+// groups are not supported.
+func parseSelector(raw string) (selector, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return nil, errors.New("selector is empty")
+	}
+	if strings.ContainsAny(raw, ",>+~:") {
+		return nil, fmt.Errorf("unsupported selector %q: only tag, #id, .class and [attr] joined by spaces are supported", raw)
+	}
+	var sel selector
+	for _, token := range strings.Fields(raw) {
+		part, err := parseSelectorPart(token)
+		if err != nil {
+			return nil, err
+		}
+		sel = append(sel, part)
+	}
+	return sel, nil
+}
+
+func parseSelectorPart(token string) (selectorPart, error) {
+	var part selectorPart
+	i := 0
+	readName := func() string {
+		start := i
+		for i < len(token) && !strings.ContainsRune("#.[", rune(token[i])) {
+			i++
+		}
+		return token[start:i]
+	}
+	part.tag = strings.ToLower(readName())
+	for i < len(token) {
+		switch token[i] {
+		case '#':
+			i++
+			part.id = readName()
+			if part.id == "" {
+				return part, fmt.Errorf("invalid selector %q: empty id", token)
+			}
+		case '.':
+			i++
+			class := readName()
+			if class == "" {
+				return part, fmt.Errorf("invalid selector %q: empty class", token)
+			}
+			part.classes = append(part.classes, class)
+		case '[':
+			end := strings.IndexByte(token[i:], ']')
+			if end < 0 {
+				return part, fmt.Errorf("invalid selector %q: unclosed [", token)
+			}
+			body := token[i+1 : i+end]
+			i += end + 1
+			test := attrTest{name: strings.ToLower(body)}
+			if name, value, ok := strings.Cut(body, "="); ok {
+				test = attrTest{
+					name:  strings.ToLower(name),
+					value: strings.Trim(value, `"'`),
+					exact: true,
+				}
+			}
+			if test.name == "" {
+				return part, fmt.Errorf("invalid selector %q: empty attribute", token)
+			}
+			part.attrs = append(part.attrs, test)
+		default:
+			return part, fmt.Errorf("invalid selector %q", token)
+		}
+	}
+	return part, nil
+}
+
+func (p selectorPart) matches(n *html.Node) bool {
+	if n.Type != html.ElementNode {
+		return false
+	}
+	if p.tag != "" && p.tag != "*" && n.Data != p.tag {
+		return false
+	}
+	if p.id != "" && attr(n, "id") != p.id {
+		return false
+	}
+	if len(p.classes) > 0 {
+		have := strings.Fields(attr(n, "class"))
+		for _, want := range p.classes {
+			found := false
+			for _, class := range have {
+				if class == want {
+					found = true
+					break
+				}
+			}
+			if !found {
+				return false
+			}
+		}
+	}
+	for _, test := range p.attrs {
+		value, ok := attrValue(n, test.name)
+		if !ok || (test.exact && value != test.value) {
+			return false
+		}
+	}
+	return true
+}
+
+// matches reports whether n matches the last part and its ancestors match
+// the preceding parts in order.
+func (s selector) matches(n *html.Node) bool {
+	if len(s) == 0 || !s[len(s)-1].matches(n) {
+		return false
+	}
+	rest := len(s) - 2
+	for anc := n.Parent; anc != nil && rest >= 0; anc = anc.Parent {
+		if s[rest].matches(anc) {
+			rest--
+		}
+	}
+	return rest < 0
+}
+
+func attr(n *html.Node, name string) string {
+	value, _ := attrValue(n, name)
+	return value
+}
+
+func attrValue(n *html.Node, name string) (string, bool) {
+	for _, a := range n.Attr {
+		if a.Key == name {
+			return a.Val, true
+		}
+	}
+	return "", false
+}
+
+// ExtractValue returns the visible text of the elements matching selector,
+// one line per element. An empty selector selects the page body. ok is false
+// when nothing matched.
+func ExtractValue(page, rawSelector string) (value string, ok bool, err error) {
+	doc, err := html.Parse(strings.NewReader(page))
+	if err != nil {
+		return "", false, fmt.Errorf("parse page: %w", err)
+	}
+	var sel selector
+	if strings.TrimSpace(rawSelector) == "" {
+		sel = selector{{tag: "body"}}
+	} else if sel, err = parseSelector(rawSelector); err != nil {
+		return "", false, err
+	}
+
+	var texts []string
+	var walk func(*html.Node)
+	walk = func(n *html.Node) {
+		if sel.matches(n) {
+			if text := nodeText(n); text != "" {
+				texts = append(texts, text)
+			}
+			return
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			walk(c)
+		}
+	}
+	walk(doc)
+	if len(texts) == 0 {
+		return "", false, nil
+	}
+	value = strings.Join(texts, "\n")
+	if len(value) > maxValueChars {
+		value = value[:maxValueChars]
+	}
+	return value, true, nil
+}
+
+// nodeText collects the whitespace-normalized text under n, skipping
+// scripts and styles.
+func nodeText(n *html.Node) string {
+	var b strings.Builder
+	var walk func(*html.Node)
+	walk = func(n *html.Node) {
+		switch n.Type {
+		case html.TextNode:
+			b.WriteString(n.Data)
+			b.WriteByte(' ')
+			return
+		case html.ElementNode:
+			switch n.Data {
+			case "script", "style", "noscript", "template":
+				return
+			}
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			walk(c)
+		}
+	}
+	walk(n)
+	return strings.Join(strings.Fields(b.String()), " ")
+}
@@ -0,0 +1,87 @@
+package webwatch
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Store persists watches so they survive restarts.
+type Store interface {
+	// Save inserts or replaces a watch.
+	Save(ctx context.Context, watch *Watch) error
+	// Delete removes a watch by ID.
+	Delete(ctx context.Context, id string) error
+	// List returns every stored watch.
+	List(ctx context.Context) ([]*Watch, error)
+}
+
+// DBStore implements Store using the web_watches table.
+type DBStore struct {
+	db *sql.DB
+}
+
+// NewDBStore creates a DB-backed watch store.
+func NewDBStore(db *sql.DB) (*DBStore, error) {
+	if db == nil {
+		return nil, errors.New("db is required")
+	}
+	return &DBStore{db: db}, nil
+}
+
+// Save upserts the watch row.
+func (s *DBStore) Save(ctx context.Context, watch *Watch) error {
+	if watch == nil || watch.ID == "" {
+		return errors.New("watch id is required")
+	}
+	data, err := json.Marshal(watch)
+	if err != nil {
+		return fmt.Errorf("marshal web watch: %w", err)
+	}
+	_, err = s.db.ExecContext(ctx, `
+		INSERT INTO web_watches (id, session_id, next_check_at, data, updated_at)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (id) DO UPDATE
+		SET session_id = EXCLUDED.session_id,
+			next_check_at = EXCLUDED.next_check_at,
+			data = EXCLUDED.data,
+			updated_at = EXCLUDED.updated_at
+	`, watch.ID, watch.SessionID, watch.NextCheckAt, string(data), time.Now())
+	return err
+}
+
+// Delete removes the watch row.
+func (s *DBStore) Delete(ctx context.Context, id string) error {
+	_, err := s.db.ExecContext(ctx, `DELETE FROM web_watches WHERE id = $1`, id)
+	return err
+}
+
+// List returns all watch rows, soonest check first.
+func (s *DBStore) List(ctx context.Context) ([]*Watch, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT data
+		FROM web_watches
+		ORDER BY next_check_at ASC
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var watches []*Watch
+	for rows.Next() {
+		var data []byte
+		if err := rows.Scan(&data); err != nil {
+			return nil, err
+		}
+		var watch Watch
+		if err := json.Unmarshal(data, &watch); err != nil {
+			return nil, fmt.Errorf("decode web watch: %w", err)
+		}
+		watches = append(watches, &watch)
+	}
+	return watches, rows.Err()
+}
@@ -0,0 +1,225 @@
+// Package webwatch monitors web pages on a schedule and reports when a
+// watched value changes or crosses a price threshold.
+package webwatch
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Condition decides when a watch triggers.
+type Condition string
+
+const (
+	// ConditionChange triggers whenever the watched text changes.
+	ConditionChange Condition = "change"
+	// ConditionPriceBelow triggers when the watched price drops below the
+	// threshold.
+	ConditionPriceBelow Condition = "price_below"
+	// ConditionPriceAbove triggers when the watched price rises above the
+	// threshold.
+	ConditionPriceAbove Condition = "price_above"
+)
+
+// ParseCondition normalizes a condition name. Empty means ConditionChange.
+func ParseCondition(raw string) (Condition, error) {
+	switch Condition(strings.ToLower(strings.TrimSpace(raw))) {
+	case "", ConditionChange:
+		return ConditionChange, nil
+	case ConditionPriceBelow:
+		return ConditionPriceBelow, nil
+	case ConditionPriceAbove:
+		return ConditionPriceAbove, nil
+	default:
+		return "", fmt.Errorf("unknown condition %q (use change, price_below, or price_above)", raw)
+	}
+}
+
+// Watch is a page registered for periodic checks. The session fields record
+// where the notification goes.
+type Watch struct {
+	ID        string        `json:"id"`
+	URL       string        `json:"url"`
+	Selector  string        `json:"selector,omitempty"`
+	Condition Condition     `json:"condition"`
+	Threshold float64       `json:"threshold,omitempty"`
+	Interval  time.Duration `json:"interval"`
+	Label     string        `json:"label,omitempty"`
+
+	SessionID string `json:"session_id"`
+	Channel   string `json:"channel,omitempty"`
+	ChannelID string `json:"channel_id,omitempty"`
+	AgentID   string `json:"agent_id,omitempty"`
+
+	// LastValue is the snapshot the next check is compared against.
+	LastValue string `json:"last_value,omitempty"`
+	// Armed is false after a price condition fired, until the price moves
+	// back across the threshold.
+	Armed bool `json:"armed"`
+
+	LastCheckedAt   time.Time `json:"last_checked_at,omitempty"`
+	LastChangedAt   time.Time `json:"last_changed_at,omitempty"`
+	LastTriggeredAt time.Time `json:"last_triggered_at,omitempty"`
+	NextCheckAt     time.Time `json:"next_check_at"`
+	LastError       string    `json:"last_error,omitempty"`
+	Failures        int       `json:"failures,omitempty"`
+	CreatedAt       time.Time `json:"created_at"`
+}
+
+// Name returns the label, or the URL when the watch has none.
+func (w *Watch) Name() string {
+	if label := strings.TrimSpace(w.Label); label != "" {
+		return label
+	}
+	return w.URL
+}
+
+// Validate checks the fields a caller supplies when registering a watch.
+func (w *Watch) Validate() error {
+	parsed, err := url.Parse(strings.TrimSpace(w.URL))
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return fmt.Errorf("url must be an absolute http(s) URL")
+	}
+	if strings.TrimSpace(w.Selector) != "" {
+		if _, err := parseSelector(w.Selector); err != nil {
+			return err
+		}
+	}
+	if _, err := ParseCondition(string(w.Condition)); err != nil {
+		return err
+	}
+	if w.Condition != ConditionChange && w.Threshold <= 0 {
+		return fmt.Errorf("%s requires a positive threshold", w.Condition)
+	}
+	if strings.TrimSpace(w.SessionID) == "" {
+		return fmt.Errorf("session is required")
+	}
+	return nil
+}
+
+// Event describes a triggered watch.
+type Event struct {
+	Watch    *Watch
+	Previous string
+	Current  string
+	// Price is the parsed price for price conditions.
+	Price float64
+}
+
+// Message renders the notification sent to the originating session.
+func (e Event) Message() string {
+	w := e.Watch
+	switch w.Condition {
+	case ConditionPriceBelow:
+		return fmt.Sprintf("Web watch %s: price is now %s, below %s.\n%s",
+			w.Name(), formatPrice(e.Price), formatPrice(w.Threshold), w.URL)
+	case ConditionPriceAbove:
+		return fmt.Sprintf("Web watch %s: price is now %s, above %s.\n%s",
+			w.Name(), formatPrice(e.Price), formatPrice(w.Threshold), w.URL)
+	default:
+		return fmt.Sprintf("Web watch %s changed.\nBefore: %s\nNow: %s\n%s",
+			w.Name(), excerpt(e.Previous), excerpt(e.Current), w.URL)
+	}
+}
+
+// observe records value as the latest snapshot and reports whether the watch
+// triggers. The first observation of a change watch only sets the baseline.
+func (w *Watch) observe(value string, now time.Time) (Event, bool) {
+	previous := w.LastValue
+	first := w.LastCheckedAt.IsZero()
+	w.LastCheckedAt = now
+	w.LastError = ""
+	w.Failures = 0
+	if value != previous {
+		w.LastValue = value
+		if !first {
+			w.LastChangedAt = now
+		}
+	}
+	event := Event{Watch: w, Previous: previous, Current: value}
+
+	switch w.Condition {
+	case ConditionPriceBelow, ConditionPriceAbove:
+		price, ok := ParsePrice(value)
+		if !ok {
+			w.LastError = "no price found in watched text"
+			return event, false
+		}
+		event.Price = price
+		met := price < w.Threshold
+		if w.Condition == ConditionPriceAbove {
+			met = price > w.Threshold
+		}
+		if !met {
+			w.Armed = true
+			return event, false
+		}
+		if !w.Armed {
+			return event, false
+		}
+		w.Armed = false
+	default:
+		if first || value == previous {
+			return event, false
+		}
+	}
+	w.LastTriggeredAt = now
+	return event, true
+}
+
+var priceRe = regexp.MustCompile(`\d[\d.,' ]*\d|\d`)
+
+// ParsePrice returns the first number in text, accepting both 1,234.56 and
+// 1.234,56 grouping.
+func ParsePrice(text string) (float64, bool) {
+	match := priceRe.FindString(text)
+	if match == "" {
+		return 0, false
+	}
+	digits := strings.NewReplacer(" ", "", "'", "").Replace(match)
+	lastDot := strings.LastIndexByte(digits, '.')
+	lastComma := strings.LastIndexByte(digits, ',')
+	switch {
+	case lastDot >= 0 && lastComma >= 0:
+		if lastComma > lastDot {
+			digits = strings.ReplaceAll(digits, ".", "")
+			digits = strings.Replace(digits, ",", ".", 1)
+		} else {
+			digits = strings.ReplaceAll(digits, ",", "")
+		}
+	case lastComma >= 0:
+		if strings.Count(digits, ",") == 1 && len(digits)-lastComma-1 <= 2 {
+			digits = strings.Replace(digits, ",", ".", 1)
+		} else {
+			digits = strings.ReplaceAll(digits, ",", "")
+		}
+	case strings.Count(digits, ".") > 1:
+		digits = strings.ReplaceAll(digits, ".", "")
+	}
+	price, err := strconv.ParseFloat(digits, 64)
+	if err != nil {
+		return 0, false
+	}
+	return price, true
+}
+
+func formatPrice(price float64) string {
+	return strconv.FormatFloat(price, 'f', -1, 64)
+}
+
+// excerpt shortens a snapshot for display.
+func excerpt(text string) string {
+	const limit = 300
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return "(empty)"
+	}
+	if len(text) > limit {
+		return text[:limit] + "…"
+	}
+	return text
+}
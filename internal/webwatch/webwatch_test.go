@@ -0,0 +1,187 @@
+package webwatch
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+const productPage = `<html><body>
+<div id="product"><h1 class="title">Espresso Machine</h1>
+<span class="price sale" data-currency="usd">$1,299.99</span>
+<script>var price = 1;</script></div>
+<ul class="stock"><li>In stock</li><li>Ships tomorrow</li></ul>
+</body></html>`
+
+func TestExtractValue(t *testing.T) {
+	tests := []struct {
+		selector string
+		want     string
+		ok       bool
+	}{
+		{"#product .price", "$1,299.99", true},
+		{"span.price.sale", "$1,299.99", true},
+		{"[data-currency=usd]", "$1,299.99", true},
+		{"ul.stock li", "In stock\nShips tomorrow", true},
+		{"div h1", "Espresso Machine", true},
+		{".missing", "", false},
+	}
+	for _, tt := range tests {
+		got, ok, err := ExtractValue(productPage, tt.selector)
+		if err != nil {
+			t.Fatalf("ExtractValue(%q) error = %v", tt.selector, err)
+		}
+		if ok != tt.ok || got != tt.want {
+			t.Errorf("ExtractValue(%q) = %q, %v; want %q, %v", tt.selector, got, ok, tt.want, tt.ok)
+		}
+	}
+
+	body, ok, err := ExtractValue(productPage, "")
+	if err != nil || !ok {
+		t.Fatalf("ExtractValue(body) = %v, %v", ok, err)
+	}
+	if strings.Contains(body, "var price") || !strings.Contains(body, "Espresso Machine") {
+		t.Errorf("body text = %q", body)
+	}
+
+	if _, _, err := ExtractValue(productPage, "div > span"); err == nil {
+		t.Error("expected error for unsupported combinator")
+	}
+}
+
+func TestParsePrice(t *testing.T) {
+	tests := map[string]float64{
+		"$1,299.99":       1299.99,
+		"1.299,99 €":      1299.99,
+		"EUR 12,50":       12.5,
+		"Now only 45":     45,
+		"£2,000":          2000,
+		"1.000.000":       1000000,
+		"Price: 19.9 USD": 19.9,
+	}
+	for text, want := range tests {
+		got, ok := ParsePrice(text)
+		if !ok || got != want {
+			t.Errorf("ParsePrice(%q) = %v, %v; want %v", text, got, ok, want)
+		}
+	}
+	if _, ok := ParsePrice("sold out"); ok {
+		t.Error("expected no price in text without digits")
+	}
+}
+
+type fakeFetcher struct {
+	pages map[string]string
+	err   error
+}
+
+func (f *fakeFetcher) FetchHTML(_ context.Context, url string) (string, error) {
+	if f.err != nil {
+		return "", f.err
+	}
+	return f.pages[url], nil
+}
+
+func TestMonitor_ChangeCondition(t *testing.T) {
+	fetcher := &fakeFetcher{pages: map[string]string{"https://example.com/": `<p id="status">open</p>`}}
+	monitor := NewMonitor(Config{MinInterval: time.Minute}, fetcher)
+	ctx := context.Background()
+
+	watch, err := monitor.Add(ctx, &Watch{URL: "https://example.com/", Selector: "#status", Interval: time.Second, SessionID: "s1"})
+	if err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+	if watch.Interval != time.Minute {
+		t.Errorf("Interval = %v, want raised to minimum", watch.Interval)
+	}
+
+	now := time.Now()
+	if events := monitor.CheckDue(ctx, now); len(events) != 0 {
+		t.Fatalf("baseline check triggered: %+v", events)
+	}
+	if events := monitor.CheckDue(ctx, now.Add(30*time.Second)); len(events) != 0 {
+		t.Fatalf("check before interval triggered: %+v", events)
+	}
+
+	fetcher.pages["https://example.com/"] = `<p id="status">closed</p>`
+	events := monitor.CheckDue(ctx, now.Add(2*time.Minute))
+	if len(events) != 1 {
+		t.Fatalf("expected 1 event, got %d", len(events))
+	}
+	if events[0].Previous != "open" || events[0].Current != "closed" {
+		t.Errorf("event = %+v", events[0])
+	}
+	if msg := events[0].Message(); !strings.Contains(msg, "Before: open") || !strings.Contains(msg, "Now: closed") {
+		t.Errorf("Message() = %q", msg)
+	}
+}
+
+func TestMonitor_PriceCondition(t *testing.T) {
+	fetcher := &fakeFetcher{pages: map[string]string{"https://shop.test/x": `<span class="price">$120</span>`}}
+	monitor := NewMonitor(Config{}, fetcher)
+	ctx := context.Background()
+
+	if _, err := monitor.Add(ctx, &Watch{URL: "https://shop.test/x", Selector: ".price", Condition: ConditionPriceBelow, SessionID: "s1"}); err == nil {
+		t.Fatal("expected error for price condition without threshold")
+	}
+	_, err := monitor.Add(ctx, &Watch{URL: "https://shop.test/x", Selector: ".price", Condition: ConditionPriceBelow, Threshold: 100, Interval: time.Minute, SessionID: "s1"})
+	if err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+
+	now := time.Now()
+	check := func(page string) []Event {
+		fetcher.pages["https://shop.test/x"] = page
+		now = now.Add(time.Minute)
+		return monitor.CheckDue(ctx, now)
+	}
+	if events := check(`<span class="price">$120</span>`); len(events) != 0 {
+		t.Fatalf("price above threshold triggered")
+	}
+	events := check(`<span class="price">$95.50</span>`)
+	if len(events) != 1 || events[0].Price != 95.5 {
+		t.Fatalf("expected trigger at 95.5, got %+v", events)
+	}
+	if events := check(`<span class="price">$90</span>`); len(events) != 0 {
+		t.Fatal("repeated trigger while price stays below threshold")
+	}
+	check(`<span class="price">$110</span>`)
+	if events := check(`<span class="price">$99</span>`); len(events) != 1 {
+		t.Fatal("expected trigger after price recovered and dropped again")
+	}
+}
+
+func TestMonitor_FetchErrorsAndLimits(t *testing.T) {
+	fetcher := &fakeFetcher{err: errors.New("HTTP 503")}
+	monitor := NewMonitor(Config{MaxPerSession: 1}, fetcher)
+	ctx := context.Background()
+
+	watch, err := monitor.Add(ctx, &Watch{URL: "https://example.com/", SessionID: "s1"})
+	if err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+	if _, err := monitor.Add(ctx, &Watch{URL: "https://example.org/", SessionID: "s1"}); err == nil {
+		t.Fatal("expected per-session limit error")
+	}
+	if _, err := monitor.Add(ctx, &Watch{URL: "ftp://example.org/", SessionID: "s2"}); err == nil {
+		t.Fatal("expected invalid url error")
+	}
+
+	monitor.CheckDue(ctx, time.Now())
+	listed := monitor.List("s1")
+	if len(listed) != 1 || listed[0].Failures != 1 || listed[0].LastError != "HTTP 503" {
+		t.Fatalf("List() = %+v", listed)
+	}
+
+	if err := monitor.Remove(ctx, "s2", watch.ID); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Remove() from other session error = %v, want ErrNotFound", err)
+	}
+	if err := monitor.Remove(ctx, "s1", watch.ID); err != nil {
+		t.Fatalf("Remove() error = %v", err)
+	}
+	if len(monitor.List("")) != 0 {
+		t.Error("watch still listed after Remove")
+	}
+}
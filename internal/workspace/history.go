@@ -0,0 +1,328 @@
+package workspace
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/haasonsaas/nexus/internal/config"
+)
+
+// Version sources other than tool names.
+const (
+	// SourceExternal marks content written outside the file tools, such as
+	// a hand edit, found when the next change is recorded.
+	SourceExternal = "external"
+	// SourceRollback marks content restored from an earlier version.
+	SourceRollback = "rollback"
+)
+
+// ErrUntracked is returned for files the history does not version.
+var ErrUntracked = errors.New("not a versioned workspace file")
+
+// FileVersion describes one saved version of a workspace file.
+type FileVersion struct {
+	Version int       `json:"version"`
+	SavedAt time.Time `json:"saved_at"`
+	Size    int       `json:"size"`
+	SHA256  string    `json:"sha256"`
+	// Source is the tool that wrote the version, SourceRollback, or
+	// SourceExternal.
+	Source string `json:"source"`
+	// RestoredFrom is the version a rollback restored.
+	RestoredFrom int `json:"restored_from,omitempty"`
+}
+
+// History keeps snapshot copies of the workspace bootstrap files. Each file
+// has a directory under the history dir holding an index and one copy per
+// version, numbered from 1.
+type History struct {
+	root        string
+	dir         string
+	maxVersions int
+	tracked     map[string]bool
+
+	mu       sync.Mutex
+	onChange func(ctx context.Context, name string, version FileVersion)
+}
+
+// NewHistory returns the history of the bootstrap files of cfg's workspace,
+// or nil when workspace.history is disabled.
+func NewHistory(cfg *config.Config) *History {
+	if cfg == nil {
+		return nil
+	}
+	historyCfg := cfg.Workspace.History
+	if historyCfg.Enabled != nil && !*historyCfg.Enabled {
+		return nil
+	}
+	root := strings.TrimSpace(cfg.Workspace.Path)
+	if root == "" {
+		root = "."
+	}
+	if abs, err := filepath.Abs(root); err == nil {
+		root = abs
+	}
+	dir := strings.TrimSpace(historyCfg.Dir)
+	if dir == "" {
+		dir = ".nexus/history"
+	}
+	if !filepath.IsAbs(dir) {
+		dir = filepath.Join(root, dir)
+	}
+	maxVersions := historyCfg.MaxVersions
+	if maxVersions <= 0 {
+		maxVersions = 50
+	}
+	tracked := make(map[string]bool)
+	for _, file := range BootstrapFilesForConfig(cfg) {
+		if name := filepath.ToSlash(filepath.Clean(strings.TrimSpace(file.Name))); name != "." {
+			tracked[name] = true
+		}
+	}
+	return &History{root: root, dir: dir, maxVersions: maxVersions, tracked: tracked}
+}
+
+// SetOnChange registers fn to be called after a new version is saved.
+func (h *History) SetOnChange(fn func(ctx context.Context, name string, version FileVersion)) {
+	if h == nil {
+		return
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.onChange = fn
+}
+
+// Files returns the names of the versioned files.
+func (h *History) Files() []string {
+	if h == nil {
+		return nil
+	}
+	names := make([]string, 0, len(h.tracked))
+	for name := range h.tracked {
+		names = append(names, name)
+	}
+	return names
+}
+
+// Record saves the current content of path as a new version when it is a
+// versioned file and differs from its latest version. path is absolute or
+// relative to the workspace. An empty source records SourceExternal.
+func (h *History) Record(ctx context.Context, path, source string) error {
+	if h == nil {
+		return nil
+	}
+	name, err := h.name(path)
+	if errors.Is(err, ErrUntracked) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if source == "" {
+		source = SourceExternal
+	}
+	_, err = h.record(ctx, name, FileVersion{Source: source})
+	return err
+}
+
+// Versions returns the saved versions of a file, oldest first.
+func (h *History) Versions(path string) ([]FileVersion, error) {
+	if h == nil {
+		return nil, errors.New("workspace history is disabled")
+	}
+	name, err := h.name(path)
+	if err != nil {
+		return nil, err
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.loadIndex(name)
+}
+
+// Content returns the content of a saved version of a file.
+func (h *History) Content(path string, version int) ([]byte, error) {
+	if h == nil {
+		return nil, errors.New("workspace history is disabled")
+	}
+	name, err := h.name(path)
+	if err != nil {
+		return nil, err
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.content(name, version)
+}
+
+// Rollback restores a file to a saved version. The current content is saved
+// first, so a rollback can itself be undone. It returns the new version, or
+// nil when the file already has that content.
+func (h *History) Rollback(ctx context.Context, path string, version int) (*FileVersion, error) {
+	if h == nil {
+		return nil, errors.New("workspace history is disabled")
+	}
+	name, err := h.name(path)
+	if err != nil {
+		return nil, err
+	}
+	h.mu.Lock()
+	data, err := h.content(name, version)
+	h.mu.Unlock()
+	if err != nil {
+		return nil, err
+	}
+	if _, err := h.record(ctx, name, FileVersion{Source: SourceExternal}); err != nil {
+		return nil, err
+	}
+	target := filepath.Join(h.root, filepath.FromSlash(name))
+	if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+		return nil, fmt.Errorf("create directory: %w", err)
+	}
+	if err := os.WriteFile(target, data, 0o644); err != nil {
+		return nil, fmt.Errorf("write %s: %w", name, err)
+	}
+	return h.record(ctx, name, FileVersion{Source: SourceRollback, RestoredFrom: version})
+}
+
+// record saves the file's content as a version described by meta unless it
+// matches the latest version.
+func (h *History) record(ctx context.Context, name string, meta FileVersion) (*FileVersion, error) {
+	h.mu.Lock()
+	data, err := os.ReadFile(filepath.Join(h.root, filepath.FromSlash(name)))
+	if errors.Is(err, os.ErrNotExist) {
+		h.mu.Unlock()
+		return nil, nil
+	}
+	if err != nil {
+		h.mu.Unlock()
+		return nil, fmt.Errorf("read %s: %w", name, err)
+	}
+	versions, err := h.loadIndex(name)
+	if err != nil {
+		h.mu.Unlock()
+		return nil, err
+	}
+	sum := sha256.Sum256(data)
+	meta.SHA256 = hex.EncodeToString(sum[:])
+	if n := len(versions); n > 0 && versions[n-1].SHA256 == meta.SHA256 {
+		h.mu.Unlock()
+		return nil, nil
+	}
+	meta.Version = 1
+	if n := len(versions); n > 0 {
+		meta.Version = versions[n-1].Version + 1
+	}
+	meta.SavedAt = time.Now()
+	meta.Size = len(data)
+
+	fileDir := h.fileDir(name)
+	if err := os.MkdirAll(fileDir, 0o700); err != nil {
+		h.mu.Unlock()
+		return nil, fmt.Errorf("create history dir: %w", err)
+	}
+	if err := os.WriteFile(h.versionPath(name, meta.Version), data, 0o600); err != nil {
+		h.mu.Unlock()
+		return nil, fmt.Errorf("save version: %w", err)
+	}
+	versions = append(versions, meta)
+	if excess := len(versions) - h.maxVersions; excess > 0 {
+		for _, old := range versions[:excess] {
+			_ = os.Remove(h.versionPath(name, old.Version))
+		}
+		versions = append([]FileVersion(nil), versions[excess:]...)
+	}
+	err = h.saveIndex(name, versions)
+	onChange := h.onChange
+	h.mu.Unlock()
+	if err != nil {
+		return nil, err
+	}
+	if onChange != nil {
+		onChange(ctx, name, meta)
+	}
+	return &meta, nil
+}
+
+// name returns the slash-separated workspace-relative name of path.
+func (h *History) name(path string) (string, error) {
+	clean := strings.TrimSpace(path)
+	if clean == "" {
+		return "", errors.New("file is required")
+	}
+	if filepath.IsAbs(clean) {
+		rel, err := filepath.Rel(h.root, clean)
+		if err != nil {
+			return "", fmt.Errorf("%s: %w", path, ErrUntracked)
+		}
+		clean = rel
+	}
+	name := filepath.ToSlash(filepath.Clean(clean))
+	if !h.tracked[name] {
+		return "", fmt.Errorf("%s: %w", path, ErrUntracked)
+	}
+	return name, nil
+}
+
+func (h *History) content(name string, version int) ([]byte, error) {
+	versions, err := h.loadIndex(name)
+	if err != nil {
+		return nil, err
+	}
+	for _, v := range versions {
+		if v.Version == version {
+			data, err := os.ReadFile(h.versionPath(name, version))
+			if err != nil {
+				return nil, fmt.Errorf("read version %d of %s: %w", version, name, err)
+			}
+			return data, nil
+		}
+	}
+	return nil, fmt.Errorf("%s has no version %d", name, version)
+}
+
+func (h *History) fileDir(name string) string {
+	return filepath.Join(h.dir, filepath.FromSlash(name))
+}
+
+func (h *History) versionPath(name string, version int) string {
+	return filepath.Join(h.fileDir(name), fmt.Sprintf("v%d%s", version, filepath.Ext(name)))
+}
+
+func (h *History) loadIndex(name string) ([]FileVersion, error) {
+	data, err := os.ReadFile(filepath.Join(h.fileDir(name), "index.json"))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read history of %s: %w", name, err)
+	}
+	var versions []FileVersion
+	if err := json.Unmarshal(data, &versions); err != nil {
+		return nil, fmt.Errorf("decode history of %s: %w", name, err)
+	}
+	return versions, nil
+}
+
+func (h *History) saveIndex(name string, versions []FileVersion) error {
+	data, err := json.MarshalIndent(versions, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode history of %s: %w", name, err)
+	}
+	path := filepath.Join(h.fileDir(name), "index.json")
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return fmt.Errorf("write history of %s: %w", name, err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return fmt.Errorf("write history of %s: %w", name, err)
+	}
+	return nil
+}
@@ -0,0 +1,135 @@
+package workspace
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/haasonsaas/nexus/internal/config"
+)
+
+func newTestHistory(t *testing.T, maxVersions int) (*History, string) {
+	t.Helper()
+	root := t.TempDir()
+	cfg := &config.Config{Workspace: config.DefaultWorkspaceConfig()}
+	cfg.Workspace.Path = root
+	cfg.Workspace.History.MaxVersions = maxVersions
+	return NewHistory(cfg), root
+}
+
+func writeWorkspaceFile(t *testing.T, root, name, content string) {
+	t.Helper()
+	if err := os.WriteFile(filepath.Join(root, name), []byte(content), 0o644); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+}
+
+func TestHistoryRecordAndRollback(t *testing.T) {
+	history, root := newTestHistory(t, 50)
+	ctx := context.Background()
+	soul := filepath.Join(root, "SOUL.md")
+
+	var changes []FileVersion
+	history.SetOnChange(func(ctx context.Context, name string, version FileVersion) {
+		changes = append(changes, version)
+	})
+
+	writeWorkspaceFile(t, root, "SOUL.md", "calm\n")
+	if err := history.Record(ctx, soul, ""); err != nil {
+		t.Fatalf("Record() error = %v", err)
+	}
+	writeWorkspaceFile(t, root, "SOUL.md", "loud\n")
+	if err := history.Record(ctx, soul, "write"); err != nil {
+		t.Fatalf("Record() error = %v", err)
+	}
+	// Unchanged content is not saved again.
+	if err := history.Record(ctx, "SOUL.md", "edit"); err != nil {
+		t.Fatalf("Record() error = %v", err)
+	}
+
+	versions, err := history.Versions("SOUL.md")
+	if err != nil {
+		t.Fatalf("Versions() error = %v", err)
+	}
+	if len(versions) != 2 || versions[0].Source != SourceExternal || versions[1].Source != "write" || versions[1].Version != 2 {
+		t.Fatalf("unexpected versions %+v", versions)
+	}
+	if len(changes) != 2 {
+		t.Fatalf("expected 2 change callbacks, got %d", len(changes))
+	}
+
+	restored, err := history.Rollback(ctx, "SOUL.md", 1)
+	if err != nil {
+		t.Fatalf("Rollback() error = %v", err)
+	}
+	if restored == nil || restored.Version != 3 || restored.Source != SourceRollback || restored.RestoredFrom != 1 {
+		t.Fatalf("unexpected rollback version %+v", restored)
+	}
+	data, err := os.ReadFile(soul)
+	if err != nil {
+		t.Fatalf("ReadFile() error = %v", err)
+	}
+	if string(data) != "calm\n" {
+		t.Fatalf("expected rolled back content, got %q", data)
+	}
+
+	if _, err := history.Rollback(ctx, "SOUL.md", 9); err == nil {
+		t.Fatal("expected error for unknown version")
+	}
+}
+
+func TestHistoryIgnoresUntrackedFiles(t *testing.T) {
+	history, root := newTestHistory(t, 50)
+	writeWorkspaceFile(t, root, "notes.txt", "hello")
+
+	if err := history.Record(context.Background(), filepath.Join(root, "notes.txt"), "write"); err != nil {
+		t.Fatalf("Record() error = %v", err)
+	}
+	if _, err := history.Versions("notes.txt"); !errors.Is(err, ErrUntracked) {
+		t.Fatalf("expected ErrUntracked, got %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(root, ".nexus", "history", "notes.txt")); !os.IsNotExist(err) {
+		t.Fatalf("expected no history for untracked file, got %v", err)
+	}
+}
+
+func TestHistoryPrunesOldVersions(t *testing.T) {
+	history, root := newTestHistory(t, 2)
+	ctx := context.Background()
+	for _, content := range []string{"one", "two", "three"} {
+		writeWorkspaceFile(t, root, "MEMORY.md", content)
+		if err := history.Record(ctx, "MEMORY.md", "write"); err != nil {
+			t.Fatalf("Record() error = %v", err)
+		}
+	}
+
+	versions, err := history.Versions("MEMORY.md")
+	if err != nil {
+		t.Fatalf("Versions() error = %v", err)
+	}
+	if len(versions) != 2 || versions[0].Version != 2 || versions[1].Version != 3 {
+		t.Fatalf("unexpected versions %+v", versions)
+	}
+	if _, err := history.Content("MEMORY.md", 1); err == nil {
+		t.Fatal("expected pruned version to be gone")
+	}
+	data, err := history.Content("MEMORY.md", 2)
+	if err != nil || string(data) != "two" {
+		t.Fatalf("Content() = %q, %v", data, err)
+	}
+}
+
+func TestNewHistoryDisabled(t *testing.T) {
+	disabled := false
+	cfg := &config.Config{Workspace: config.DefaultWorkspaceConfig()}
+	cfg.Workspace.History.Enabled = &disabled
+	history := NewHistory(cfg)
+	if history != nil {
+		t.Fatal("expected nil history when disabled")
+	}
+	if err := history.Record(context.Background(), "SOUL.md", "write"); err != nil {
+		t.Fatalf("Record() on nil history error = %v", err)
+	}
+}
@@ -0,0 +1,164 @@
+package management
+
+import "time"
+
+// ActionsPathPrefix is the path prefix of the REST actions API, a small
+// resource-style surface for no-code automation tools (Zapier, n8n, Make)
+// that cannot speak Connect or gRPC. Each action is a POST of a JSON body to
+// ActionsPathPrefix+<name>; errors use the same Error body as the
+// management service.
+const ActionsPathPrefix = "/api/v1/actions/"
+
+// OpenAPIPath serves the OpenAPI document describing the actions API.
+const OpenAPIPath = "/api/v1/openapi.json"
+
+// Action paths, relative to the gateway's HTTP base URL.
+const (
+	SendMessageActionPath  = ActionsPathPrefix + "send_message"
+	RunAgentActionPath     = ActionsPathPrefix + "run_agent"
+	SearchMemoryActionPath = ActionsPathPrefix + "search_memory"
+	CreateTaskActionPath   = ActionsPathPrefix + "create_task"
+)
+
+// Scopes used only by the actions API.
+const (
+	ScopeMemoryRead = "memory:read"
+	ScopeTasksWrite = "tasks:write"
+)
+
+// Action describes one REST action. Actions drives both routing and the
+// generated OpenAPI document, so the two cannot drift apart.
+type Action struct {
+	Path        string
+	OperationID string
+	Summary     string
+	Description string
+	Scope       string
+	Request     any
+	Response    any
+}
+
+// Actions lists the REST actions in the order they are documented.
+var Actions = []Action{
+	{
+		Path:        SendMessageActionPath,
+		OperationID: "sendMessage",
+		Summary:     "Send a message to a session",
+		Description: "Queues a user message for a session and returns at once. The agent's reply is delivered to the session's channel.",
+		Scope:       ScopeMessagesWrite,
+		Request:     SendMessageActionRequest{},
+		Response:    SendMessageActionResponse{},
+	},
+	{
+		Path:        RunAgentActionPath,
+		OperationID: "runAgent",
+		Summary:     "Run the agent with a prompt",
+		Description: "Runs the agent with a prompt and waits for its reply.",
+		Scope:       ScopeMessagesWrite,
+		Request:     RunAgentActionRequest{},
+		Response:    RunAgentActionResponse{},
+	},
+	{
+		Path:        SearchMemoryActionPath,
+		OperationID: "searchMemory",
+		Summary:     "Search memory",
+		Description: "Searches the agent's vector memory.",
+		Scope:       ScopeMemoryRead,
+		Request:     SearchMemoryActionRequest{},
+		Response:    SearchMemoryActionResponse{},
+	},
+	{
+		Path:        CreateTaskActionPath,
+		OperationID: "createTask",
+		Summary:     "Create a scheduled task",
+		Description: "Creates a task that runs the agent with a prompt on a cron schedule.",
+		Scope:       ScopeTasksWrite,
+		Request:     CreateTaskActionRequest{},
+		Response:    CreateTaskActionResponse{},
+	},
+}
+
+// LookupAction returns the action served at path.
+func LookupAction(path string) (Action, bool) {
+	for _, action := range Actions {
+		if action.Path == path {
+			return action, true
+		}
+	}
+	return Action{}, false
+}
+
+// SendMessageActionRequest addresses a session by ID, or by channel and
+// channel ID for the conversation the agent has there.
+type SendMessageActionRequest struct {
+	SessionID string            `json:"session_id,omitempty" jsonschema:"description=Session to send to. Required unless channel and channel_id are set."`
+	Channel   string            `json:"channel,omitempty" jsonschema:"description=Channel of the conversation\\, for example slack or telegram."`
+	ChannelID string            `json:"channel_id,omitempty" jsonschema:"description=Conversation ID on the channel."`
+	AgentID   string            `json:"agent_id,omitempty" jsonschema:"description=Agent for a channel conversation. Defaults to the gateway's default agent."`
+	Content   string            `json:"content" jsonschema:"description=Message text."`
+	Metadata  map[string]string `json:"metadata,omitempty"`
+}
+
+type SendMessageActionResponse struct {
+	MessageID string `json:"message_id"`
+	Status    string `json:"status" jsonschema:"enum=queued"`
+}
+
+// RunAgentActionRequest runs an agent. Without SessionID the prompt goes to
+// the caller's automation session for the agent.
+type RunAgentActionRequest struct {
+	Prompt    string            `json:"prompt" jsonschema:"description=Prompt for the agent."`
+	SessionID string            `json:"session_id,omitempty" jsonschema:"description=Continue an existing session instead of the caller's automation session."`
+	AgentID   string            `json:"agent_id,omitempty" jsonschema:"description=Agent to run. Defaults to the gateway's default agent."`
+	Metadata  map[string]string `json:"metadata,omitempty"`
+}
+
+type RunAgentActionResponse struct {
+	SessionID string `json:"session_id"`
+	MessageID string `json:"message_id"`
+	Reply     string `json:"reply"`
+}
+
+type SearchMemoryActionRequest struct {
+	Query     string  `json:"query"`
+	Scope     string  `json:"scope,omitempty" jsonschema:"enum=session,enum=channel,enum=agent,enum=global,enum=all,description=Defaults to agent when agent_id is set\\, otherwise all."`
+	ScopeID   string  `json:"scope_id,omitempty" jsonschema:"description=Session\\, channel\\, or agent ID for the scope."`
+	AgentID   string  `json:"agent_id,omitempty"`
+	Limit     int     `json:"limit,omitempty" jsonschema:"minimum=1,maximum=50,default=10"`
+	Threshold float32 `json:"threshold,omitempty" jsonschema:"minimum=0,maximum=1,description=Minimum similarity score."`
+}
+
+type SearchMemoryActionResponse struct {
+	Results []MemoryResult `json:"results"`
+}
+
+// MemoryResult is one memory search hit.
+type MemoryResult struct {
+	ID        string    `json:"id"`
+	Content   string    `json:"content"`
+	Score     float32   `json:"score"`
+	Source    string    `json:"source,omitempty"`
+	SessionID string    `json:"session_id,omitempty"`
+	AgentID   string    `json:"agent_id,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// CreateTaskActionRequest creates a scheduled task. Replies are delivered to
+// Channel and ChannelID when both are set.
+type CreateTaskActionRequest struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	Prompt      string `json:"prompt" jsonschema:"description=Prompt the agent runs on each execution."`
+	Schedule    string `json:"schedule" jsonschema:"description=Cron expression (5 or 6 fields) or descriptor such as @daily."`
+	Timezone    string `json:"timezone,omitempty" jsonschema:"description=IANA time zone for the schedule. Defaults to UTC."`
+	AgentID     string `json:"agent_id,omitempty"`
+	Channel     string `json:"channel,omitempty"`
+	ChannelID   string `json:"channel_id,omitempty"`
+}
+
+type CreateTaskActionResponse struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	Status    string    `json:"status"`
+	NextRunAt time.Time `json:"next_run_at"`
+}
@@ -0,0 +1,191 @@
+package management
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// maxErrorBodyBytes bounds how much of an error response is read.
+const maxErrorBodyBytes = 64 << 10
+
+// Client calls the management API over HTTP.
+type Client struct {
+	baseURL    string
+	apiKey     string
+	token      string
+	httpClient *http.Client
+}
+
+// ClientOption configures a Client.
+type ClientOption func(*Client)
+
+// WithAPIKey authenticates requests with an API key.
+func WithAPIKey(key string) ClientOption {
+	return func(c *Client) { c.apiKey = key }
+}
+
+// WithBearerToken authenticates requests with a JWT.
+func WithBearerToken(token string) ClientOption {
+	return func(c *Client) { c.token = token }
+}
+
+// WithHTTPClient sets the HTTP client used for requests.
+func WithHTTPClient(client *http.Client) ClientOption {
+	return func(c *Client) { c.httpClient = client }
+}
+
+// NewClient creates a client for the gateway HTTP server at baseURL
+// (for example "http://localhost:8080").
+func NewClient(baseURL string, opts ...ClientOption) *Client {
+	c := &Client{
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		httpClient: http.DefaultClient,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+func (c *Client) ListSessions(ctx context.Context, req *ListSessionsRequest) (*ListSessionsResponse, error) {
+	var resp ListSessionsResponse
+	return &resp, c.call(ctx, ListSessionsProcedure, req, &resp)
+}
+
+func (c *Client) GetSession(ctx context.Context, req *GetSessionRequest) (*GetSessionResponse, error) {
+	var resp GetSessionResponse
+	return &resp, c.call(ctx, GetSessionProcedure, req, &resp)
+}
+
+func (c *Client) ListMessages(ctx context.Context, req *ListMessagesRequest) (*ListMessagesResponse, error) {
+	var resp ListMessagesResponse
+	return &resp, c.call(ctx, ListMessagesProcedure, req, &resp)
+}
+
+func (c *Client) SendMessage(ctx context.Context, req *SendMessageRequest) (*SendMessageResponse, error) {
+	var resp SendMessageResponse
+	return &resp, c.call(ctx, SendMessageProcedure, req, &resp)
+}
+
+func (c *Client) ListAgents(ctx context.Context, req *ListAgentsRequest) (*ListAgentsResponse, error) {
+	var resp ListAgentsResponse
+	return &resp, c.call(ctx, ListAgentsProcedure, req, &resp)
+}
+
+func (c *Client) GetAgent(ctx context.Context, req *GetAgentRequest) (*GetAgentResponse, error) {
+	var resp GetAgentResponse
+	return &resp, c.call(ctx, GetAgentProcedure, req, &resp)
+}
+
+func (c *Client) ListApprovals(ctx context.Context, req *ListApprovalsRequest) (*ListApprovalsResponse, error) {
+	var resp ListApprovalsResponse
+	return &resp, c.call(ctx, ListApprovalsProcedure, req, &resp)
+}
+
+func (c *Client) DecideApproval(ctx context.Context, req *DecideApprovalRequest) (*DecideApprovalResponse, error) {
+	var resp DecideApprovalResponse
+	return &resp, c.call(ctx, DecideApprovalProcedure, req, &resp)
+}
+
+func (c *Client) GetUsage(ctx context.Context, req *GetUsageRequest) (*GetUsageResponse, error) {
+	var resp GetUsageResponse
+	return &resp, c.call(ctx, GetUsageProcedure, req, &resp)
+}
+
+func (c *Client) EraseIdentity(ctx context.Context, req *EraseIdentityRequest) (*EraseIdentityResponse, error) {
+	var resp EraseIdentityResponse
+	return &resp, c.call(ctx, EraseIdentityProcedure, req, &resp)
+}
+
+func (c *Client) ListSandboxSnapshots(ctx context.Context, req *ListSandboxSnapshotsRequest) (*ListSandboxSnapshotsResponse, error) {
+	var resp ListSandboxSnapshotsResponse
+	return &resp, c.call(ctx, ListSandboxSnapshotsProcedure, req, &resp)
+}
+
+func (c *Client) CreateSandboxSnapshot(ctx context.Context, req *CreateSandboxSnapshotRequest) (*CreateSandboxSnapshotResponse, error) {
+	var resp CreateSandboxSnapshotResponse
+	return &resp, c.call(ctx, CreateSandboxSnapshotProcedure, req, &resp)
+}
+
+func (c *Client) RefreshSandboxSnapshots(ctx context.Context, req *RefreshSandboxSnapshotsRequest) (*RefreshSandboxSnapshotsResponse, error) {
+	var resp RefreshSandboxSnapshotsResponse
+	return &resp, c.call(ctx, RefreshSandboxSnapshotsProcedure, req, &resp)
+}
+
+func (c *Client) DeleteSandboxSnapshot(ctx context.Context, req *DeleteSandboxSnapshotRequest) (*DeleteSandboxSnapshotResponse, error) {
+	var resp DeleteSandboxSnapshotResponse
+	return &resp, c.call(ctx, DeleteSandboxSnapshotProcedure, req, &resp)
+}
+
+func (c *Client) ListRuns(ctx context.Context, req *ListRunsRequest) (*ListRunsResponse, error) {
+	var resp ListRunsResponse
+	return &resp, c.call(ctx, ListRunsProcedure, req, &resp)
+}
+
+func (c *Client) GetRun(ctx context.Context, req *GetRunRequest) (*GetRunResponse, error) {
+	var resp GetRunResponse
+	return &resp, c.call(ctx, GetRunProcedure, req, &resp)
+}
+
+func (c *Client) ResumeRun(ctx context.Context, req *ResumeRunRequest) (*ResumeRunResponse, error) {
+	var resp ResumeRunResponse
+	return &resp, c.call(ctx, ResumeRunProcedure, req, &resp)
+}
+
+func (c *Client) KillRun(ctx context.Context, req *KillRunRequest) (*KillRunResponse, error) {
+	var resp KillRunResponse
+	return &resp, c.call(ctx, KillRunProcedure, req, &resp)
+}
+
+func (c *Client) call(ctx context.Context, procedure string, req, resp any) error {
+	body, err := json.Marshal(req)
+	if err != nil {
+		return fmt.Errorf("encode request: %w", err)
+	}
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+procedure, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("Connect-Protocol-Version", "1")
+	if c.apiKey != "" {
+		httpReq.Header.Set("X-API-Key", c.apiKey)
+	}
+	if c.token != "" {
+		httpReq.Header.Set("Authorization", "Bearer "+c.token)
+	}
+
+	httpResp, err := c.httpClient.Do(httpReq)
+	if err != nil {
+		return err
+	}
+	defer httpResp.Body.Close()
+
+	if httpResp.StatusCode != http.StatusOK {
+		data, _ := io.ReadAll(io.LimitReader(httpResp.Body, maxErrorBodyBytes)) //nolint:errcheck // best effort
+		apiErr := &Error{}
+		if err := json.Unmarshal(data, apiErr); err != nil || apiErr.Code == "" {
+			apiErr = &Error{Code: codeFromHTTPStatus(httpResp.StatusCode), Message: strings.TrimSpace(string(data))}
+		}
+		return apiErr
+	}
+	if err := json.NewDecoder(httpResp.Body).Decode(resp); err != nil {
+		return fmt.Errorf("decode response: %w", err)
+	}
+	return nil
+}
+
+// CodeOf returns the management error code of err, or CodeUnknown.
+func CodeOf(err error) Code {
+	var apiErr *Error
+	if errors.As(err, &apiErr) {
+		return apiErr.Code
+	}
+	return CodeUnknown
+}
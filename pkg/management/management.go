@@ -0,0 +1,355 @@
+// Package management defines the versioned management API that external
+// services use to automate Nexus: sessions, messages, agents, approvals,
+// usage, identity erasure, sandbox snapshots, and checkpointed runs.
+//
+// The API is served by the gateway's HTTP listener using the Connect unary
+// protocol: each procedure is a POST of a JSON request body to
+// "/nexus.management.v1.ManagementService/<Method>". Callers authenticate with
+// an API key (X-API-Key header) or a JWT (Authorization: Bearer). API keys may
+// be restricted to the scopes listed in ProcedureScopes; approval overrides,
+// identity erasure, sandbox snapshots, and runs need ScopeAdmin.
+//
+// The same listener serves a REST actions API for no-code automation tools;
+// see Actions and OpenAPISpec.
+package management
+
+import (
+	"time"
+
+	"github.com/haasonsaas/nexus/pkg/models"
+)
+
+// ServiceName is the fully qualified, versioned service name.
+const ServiceName = "nexus.management.v1.ManagementService"
+
+// Procedure paths, relative to the gateway's HTTP base URL.
+const (
+	ListSessionsProcedure   = "/" + ServiceName + "/ListSessions"
+	GetSessionProcedure     = "/" + ServiceName + "/GetSession"
+	ListMessagesProcedure   = "/" + ServiceName + "/ListMessages"
+	SendMessageProcedure    = "/" + ServiceName + "/SendMessage"
+	ListAgentsProcedure     = "/" + ServiceName + "/ListAgents"
+	GetAgentProcedure       = "/" + ServiceName + "/GetAgent"
+	ListApprovalsProcedure  = "/" + ServiceName + "/ListApprovals"
+	DecideApprovalProcedure = "/" + ServiceName + "/DecideApproval"
+	GetUsageProcedure       = "/" + ServiceName + "/GetUsage"
+	EraseIdentityProcedure  = "/" + ServiceName + "/EraseIdentity"
+
+	ListSandboxSnapshotsProcedure    = "/" + ServiceName + "/ListSandboxSnapshots"
+	CreateSandboxSnapshotProcedure   = "/" + ServiceName + "/CreateSandboxSnapshot"
+	RefreshSandboxSnapshotsProcedure = "/" + ServiceName + "/RefreshSandboxSnapshots"
+	DeleteSandboxSnapshotProcedure   = "/" + ServiceName + "/DeleteSandboxSnapshot"
+
+	ListRunsProcedure  = "/" + ServiceName + "/ListRuns"
+	GetRunProcedure    = "/" + ServiceName + "/GetRun"
+	ResumeRunProcedure = "/" + ServiceName + "/ResumeRun"
+	KillRunProcedure   = "/" + ServiceName + "/KillRun"
+)
+
+// API key scopes.
+const (
+	ScopeSessionsRead   = "sessions:read"
+	ScopeMessagesRead   = "messages:read"
+	ScopeMessagesWrite  = "messages:write"
+	ScopeAgentsRead     = "agents:read"
+	ScopeApprovalsRead  = "approvals:read"
+	ScopeApprovalsWrite = "approvals:write"
+	ScopeUsageRead      = "usage:read"
+	// ScopeAdmin is held by keys with the admin role or scope, and by
+	// unscoped keys and JWT users.
+	ScopeAdmin = "admin"
+)
+
+// ProcedureScopes maps each procedure to the scope a scoped API key needs.
+var ProcedureScopes = map[string]string{
+	ListSessionsProcedure:   ScopeSessionsRead,
+	GetSessionProcedure:     ScopeSessionsRead,
+	ListMessagesProcedure:   ScopeMessagesRead,
+	SendMessageProcedure:    ScopeMessagesWrite,
+	ListAgentsProcedure:     ScopeAgentsRead,
+	GetAgentProcedure:       ScopeAgentsRead,
+	ListApprovalsProcedure:  ScopeApprovalsRead,
+	DecideApprovalProcedure: ScopeAdmin,
+	GetUsageProcedure:       ScopeUsageRead,
+	EraseIdentityProcedure:  ScopeAdmin,
+
+	ListSandboxSnapshotsProcedure:    ScopeAdmin,
+	CreateSandboxSnapshotProcedure:   ScopeAdmin,
+	RefreshSandboxSnapshotsProcedure: ScopeAdmin,
+	DeleteSandboxSnapshotProcedure:   ScopeAdmin,
+
+	ListRunsProcedure:  ScopeAdmin,
+	GetRunProcedure:    ScopeAdmin,
+	ResumeRunProcedure: ScopeAdmin,
+	KillRunProcedure:   ScopeAdmin,
+}
+
+// ListSessionsRequest lists sessions for an agent.
+type ListSessionsRequest struct {
+	// AgentID defaults to the gateway's default agent.
+	AgentID string `json:"agent_id,omitempty"`
+	Channel string `json:"channel,omitempty"`
+	Limit   int    `json:"limit,omitempty"`
+	Offset  int    `json:"offset,omitempty"`
+}
+
+type ListSessionsResponse struct {
+	Sessions []*models.Session `json:"sessions"`
+}
+
+type GetSessionRequest struct {
+	ID string `json:"id"`
+}
+
+type GetSessionResponse struct {
+	Session *models.Session `json:"session"`
+}
+
+// ListMessagesRequest returns the most recent messages of a session.
+type ListMessagesRequest struct {
+	SessionID string `json:"session_id"`
+	Limit     int    `json:"limit,omitempty"`
+}
+
+type ListMessagesResponse struct {
+	Messages []*models.Message `json:"messages"`
+}
+
+// SendMessageRequest sends a user message and waits for the agent's reply.
+// Without SessionID the message goes to the caller's API session.
+type SendMessageRequest struct {
+	SessionID string            `json:"session_id,omitempty"`
+	Content   string            `json:"content"`
+	Metadata  map[string]string `json:"metadata,omitempty"`
+}
+
+type SendMessageResponse struct {
+	SessionID string          `json:"session_id"`
+	Reply     *models.Message `json:"reply"`
+}
+
+type ListAgentsRequest struct {
+	Limit  int `json:"limit,omitempty"`
+	Offset int `json:"offset,omitempty"`
+}
+
+type ListAgentsResponse struct {
+	Agents []*models.Agent `json:"agents"`
+	Total  int             `json:"total"`
+}
+
+type GetAgentRequest struct {
+	ID string `json:"id"`
+}
+
+type GetAgentResponse struct {
+	Agent *models.Agent `json:"agent"`
+}
+
+// Approval is a tool call waiting for, or resolved by, a human decision.
+type Approval struct {
+	ID         string    `json:"id"`
+	ToolCallID string    `json:"tool_call_id"`
+	ToolName   string    `json:"tool_name"`
+	Input      string    `json:"input,omitempty"`
+	AgentID    string    `json:"agent_id,omitempty"`
+	SessionID  string    `json:"session_id,omitempty"`
+	Reason     string    `json:"reason,omitempty"`
+	Diff       string    `json:"diff,omitempty"`
+	Decision   string    `json:"decision"`
+	CreatedAt  time.Time `json:"created_at"`
+	ExpiresAt  time.Time `json:"expires_at,omitempty"`
+	DecidedAt  time.Time `json:"decided_at,omitempty"`
+	DecidedBy  string    `json:"decided_by,omitempty"`
+}
+
+// ListApprovalsRequest lists pending approvals, optionally for one agent.
+type ListApprovalsRequest struct {
+	AgentID string `json:"agent_id,omitempty"`
+}
+
+type ListApprovalsResponse struct {
+	Approvals []*Approval `json:"approvals"`
+}
+
+// Approval decisions accepted by DecideApproval.
+const (
+	DecisionApprove = "approve"
+	DecisionDeny    = "deny"
+)
+
+type DecideApprovalRequest struct {
+	ID       string `json:"id"`
+	Decision string `json:"decision"`
+}
+
+type DecideApprovalResponse struct {
+	Approval *Approval `json:"approval"`
+}
+
+// GetUsageRequest summarizes LLM usage over the last Days days (default 7,
+// max 90).
+type GetUsageRequest struct {
+	Days int `json:"days,omitempty"`
+}
+
+// ModelUsage aggregates token usage and estimated cost for one model.
+type ModelUsage struct {
+	Provider     string `json:"provider"`
+	Model        string `json:"model"`
+	Requests     int64  `json:"requests"`
+	InputTokens  int64  `json:"input_tokens"`
+	OutputTokens int64  `json:"output_tokens"`
+	// ReasoningTokens is the part of OutputTokens spent on reasoning.
+	ReasoningTokens  int64   `json:"reasoning_tokens,omitempty"`
+	EstimatedCostUSD float64 `json:"estimated_cost_usd"`
+}
+
+type GetUsageResponse struct {
+	Since            time.Time     `json:"since"`
+	InputTokens      int64         `json:"input_tokens"`
+	OutputTokens     int64         `json:"output_tokens"`
+	ReasoningTokens  int64         `json:"reasoning_tokens,omitempty"`
+	EstimatedCostUSD float64       `json:"estimated_cost_usd"`
+	Models           []*ModelUsage `json:"models"`
+}
+
+// EraseIdentityRequest deletes the sessions, messages, artifacts, and
+// memories held for Peer ("channel:id") and every identity linked to it.
+type EraseIdentityRequest struct {
+	Peer string `json:"peer"`
+	// RequestedBy is stored in the signed erasure record; it defaults to the
+	// caller.
+	RequestedBy string `json:"requested_by,omitempty"`
+}
+
+// ErasureCounts counts the records removed by an erasure.
+type ErasureCounts struct {
+	Sessions    int64 `json:"sessions"`
+	Messages    int64 `json:"messages"`
+	Artifacts   int64 `json:"artifacts"`
+	Memories    int64 `json:"memories"`
+	Events      int64 `json:"events"`
+	Checkpoints int64 `json:"checkpoints"`
+}
+
+// EraseIdentityResponse describes a completed erasure. Errors lists the
+// deletions that failed; the signed record is written either way.
+type EraseIdentityResponse struct {
+	RecordID    string        `json:"record_id"`
+	Identities  []string      `json:"identities"`
+	Removed     ErasureCounts `json:"removed"`
+	RecordPath  string        `json:"record_path,omitempty"`
+	Errors      []string      `json:"errors,omitempty"`
+	CompletedAt time.Time     `json:"completed_at"`
+}
+
+// SandboxSnapshot is a Firecracker VM snapshot used to boot sandbox VMs.
+type SandboxSnapshot struct {
+	ID        string    `json:"id"`
+	Language  string    `json:"language"`
+	Type      string    `json:"type"`
+	CreatedAt time.Time `json:"created_at"`
+	// SizeBytes is the size of the snapshot files on disk.
+	SizeBytes int64 `json:"size_bytes"`
+}
+
+// ListSandboxSnapshotsRequest lists snapshots, optionally for one language.
+type ListSandboxSnapshotsRequest struct {
+	Language string `json:"language,omitempty"`
+}
+
+// ListSandboxSnapshotsResponse lists snapshots newest first.
+type ListSandboxSnapshotsResponse struct {
+	Snapshots []*SandboxSnapshot `json:"snapshots"`
+}
+
+// CreateSandboxSnapshotRequest snapshots a pooled VM for Language.
+type CreateSandboxSnapshotRequest struct {
+	Language string `json:"language"`
+}
+
+type CreateSandboxSnapshotResponse struct {
+	Snapshot *SandboxSnapshot `json:"snapshot"`
+}
+
+// RefreshSandboxSnapshotsRequest replaces the snapshots of Language, or of
+// every language when it is empty.
+type RefreshSandboxSnapshotsRequest struct {
+	Language string `json:"language,omitempty"`
+}
+
+type RefreshSandboxSnapshotsResponse struct {
+	Snapshots []*SandboxSnapshot `json:"snapshots"`
+}
+
+type DeleteSandboxSnapshotRequest struct {
+	ID string `json:"id"`
+}
+
+type DeleteSandboxSnapshotResponse struct{}
+
+// Run is a checkpointed agent run that has not finished.
+type Run struct {
+	ID        string `json:"id"`
+	SessionID string `json:"session_id"`
+	AgentID   string `json:"agent_id,omitempty"`
+	Channel   string `json:"channel,omitempty"`
+	// Status is "running", "interrupted", or "failed".
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+	// Request is the message that started the run.
+	Request   string `json:"request,omitempty"`
+	Model     string `json:"model,omitempty"`
+	Iteration int    `json:"iteration"`
+	ToolCalls int    `json:"tool_calls"`
+	// Messages is the length of the checkpointed conversation.
+	Messages int `json:"messages"`
+	// PendingTools names the tool calls that had not finished at the last
+	// checkpoint.
+	PendingTools []string  `json:"pending_tools,omitempty"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+// ListRunsRequest lists checkpointed runs, optionally with one status.
+type ListRunsRequest struct {
+	Status string `json:"status,omitempty"`
+}
+
+// ListRunsResponse lists runs most recently checkpointed first.
+type ListRunsResponse struct {
+	Runs []*Run `json:"runs"`
+}
+
+type GetRunRequest struct {
+	ID string `json:"id"`
+}
+
+type GetRunResponse struct {
+	Run *Run `json:"run"`
+}
+
+// ResumeRunRequest continues a run from its last checkpoint in its original
+// conversation.
+type ResumeRunRequest struct {
+	ID string `json:"id"`
+}
+
+// ResumeRunResponse returns the checkpoint the run resumed from. The run
+// continues in the background.
+type ResumeRunResponse struct {
+	Run *Run `json:"run"`
+}
+
+// KillRunRequest stops an active run. ID is a run ID or the ID of the
+// session the run belongs to. With All, every active run is stopped.
+type KillRunRequest struct {
+	ID  string `json:"id,omitempty"`
+	All bool   `json:"all,omitempty"`
+}
+
+// KillRunResponse lists the sessions whose runs were stopped.
+type KillRunResponse struct {
+	SessionIDs []string `json:"session_ids"`
+}
@@ -0,0 +1,57 @@
+package management
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path"
+	"strings"
+	"testing"
+)
+
+func TestTypeScriptClientCoversProcedures(t *testing.T) {
+	data, err := os.ReadFile("../../sdk/typescript/management.ts")
+	if err != nil {
+		t.Fatalf("read TypeScript client: %v", err)
+	}
+	source := string(data)
+	if !strings.Contains(source, `"`+ServiceName+`"`) {
+		t.Fatalf("TypeScript client does not use service %s", ServiceName)
+	}
+	for procedure, scope := range ProcedureScopes {
+		if scope == "" {
+			t.Errorf("%s has no scope", procedure)
+		}
+		method := path.Base(procedure)
+		if !strings.Contains(source, `this.call("`+method+`"`) {
+			t.Errorf("TypeScript client is missing %s", method)
+		}
+	}
+}
+
+func TestClientErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.Header.Get("X-API-Key") != "k1" || r.URL.Path != GetAgentProcedure {
+			t.Errorf("unexpected request %s %s %v", r.Method, r.URL.Path, r.Header)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(CodeNotFound.HTTPStatus())
+		w.Write([]byte(`{"code":"not_found","message":"agent \"x\" not found"}`)) //nolint:errcheck
+	}))
+	defer server.Close()
+
+	_, err := NewClient(server.URL+"/", WithAPIKey("k1")).GetAgent(context.Background(), &GetAgentRequest{ID: "x"})
+	if CodeOf(err) != CodeNotFound || !strings.Contains(err.Error(), `agent "x" not found`) {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "upstream down", http.StatusBadGateway)
+	}))
+	defer proxy.Close()
+	_, err = NewClient(proxy.URL).ListAgents(context.Background(), &ListAgentsRequest{})
+	if CodeOf(err) != CodeUnavailable || !strings.Contains(err.Error(), "upstream down") {
+		t.Fatalf("unexpected error %v", err)
+	}
+}
@@ -0,0 +1,94 @@
+package management
+
+import (
+	"encoding/json"
+	"reflect"
+
+	"github.com/invopop/jsonschema"
+)
+
+// OpenAPIVersion is the OpenAPI version of the generated document. OpenAPI
+// 3.1 schemas are JSON Schema 2020-12, so request and response types are
+// reflected directly.
+const OpenAPIVersion = "3.1.0"
+
+// OpenAPISpec returns the OpenAPI document for the REST actions API.
+// serverURL, when set, is listed as the document's server so importers
+// such as Zapier and n8n know where to send requests. version is reported
+// as the document version and defaults to "v1".
+func OpenAPISpec(serverURL, version string) ([]byte, error) {
+	if version == "" {
+		version = "v1"
+	}
+	reflector := &jsonschema.Reflector{
+		Anonymous:      true,
+		DoNotReference: true,
+		ExpandedStruct: true,
+		// Clients built from the document should tolerate new fields.
+		AllowAdditionalProperties: true,
+	}
+	schema := func(v any) *jsonschema.Schema {
+		s := reflector.ReflectFromType(reflect.TypeOf(v))
+		s.Version = ""
+		return s
+	}
+
+	jsonContent := func(s any) map[string]any {
+		return map[string]any{"application/json": map[string]any{"schema": s}}
+	}
+	errorResponse := func(description string) map[string]any {
+		return map[string]any{
+			"description": description,
+			"content":     jsonContent(map[string]any{"$ref": "#/components/schemas/Error"}),
+		}
+	}
+
+	paths := make(map[string]any, len(Actions))
+	for _, action := range Actions {
+		paths[action.Path] = map[string]any{
+			"post": map[string]any{
+				"operationId": action.OperationID,
+				"summary":     action.Summary,
+				"description": action.Description + " Requires the " + action.Scope + " scope.",
+				"tags":        []string{"actions"},
+				"requestBody": map[string]any{
+					"required": true,
+					"content":  jsonContent(schema(action.Request)),
+				},
+				"responses": map[string]any{
+					"200": map[string]any{
+						"description": "OK",
+						"content":     jsonContent(schema(action.Response)),
+					},
+					"400": errorResponse("Invalid request"),
+					"401": errorResponse("Missing or invalid credentials"),
+					"403": errorResponse("The API key lacks the required scope"),
+					"404": errorResponse("Session not found"),
+				},
+				"x-nexus-scope": action.Scope,
+			},
+		}
+	}
+
+	doc := map[string]any{
+		"openapi": OpenAPIVersion,
+		"info": map[string]any{
+			"title":       "Nexus Actions API",
+			"version":     version,
+			"description": "Send messages, run agents, search memory, and create scheduled tasks over plain JSON. Authenticate with an API key in the X-API-Key header.",
+		},
+		"paths": paths,
+		"components": map[string]any{
+			"schemas": map[string]any{"Error": schema(Error{})},
+			"securitySchemes": map[string]any{
+				"apiKey": map[string]any{"type": "apiKey", "in": "header", "name": "X-API-Key"},
+				"bearer": map[string]any{"type": "http", "scheme": "bearer", "bearerFormat": "JWT"},
+			},
+		},
+		"security": []map[string][]string{{"apiKey": {}}, {"bearer": {}}},
+	}
+	if serverURL != "" {
+		doc["servers"] = []map[string]string{{"url": serverURL}}
+	}
+	return json.MarshalIndent(doc, "", "  ")
+}
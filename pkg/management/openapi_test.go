@@ -0,0 +1,74 @@
+package management
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestOpenAPISpecCoversActions(t *testing.T) {
+	data, err := OpenAPISpec("https://nexus.example.com", "")
+	if err != nil {
+		t.Fatalf("OpenAPISpec: %v", err)
+	}
+	var doc struct {
+		OpenAPI string `json:"openapi"`
+		Info    struct {
+			Version string `json:"version"`
+		} `json:"info"`
+		Servers []struct {
+			URL string `json:"url"`
+		} `json:"servers"`
+		Paths map[string]struct {
+			Post struct {
+				OperationID string `json:"operationId"`
+				Scope       string `json:"x-nexus-scope"`
+				RequestBody struct {
+					Content map[string]struct {
+						Schema struct {
+							Type       string                     `json:"type"`
+							Properties map[string]json.RawMessage `json:"properties"`
+							Required   []string                   `json:"required"`
+						} `json:"schema"`
+					} `json:"content"`
+				} `json:"requestBody"`
+			} `json:"post"`
+		} `json:"paths"`
+	}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		t.Fatalf("decode spec: %v", err)
+	}
+	if doc.OpenAPI != OpenAPIVersion || doc.Info.Version != "v1" {
+		t.Errorf("openapi = %q, version = %q", doc.OpenAPI, doc.Info.Version)
+	}
+	if len(doc.Servers) != 1 || doc.Servers[0].URL != "https://nexus.example.com" {
+		t.Errorf("servers = %+v", doc.Servers)
+	}
+	if len(doc.Paths) != len(Actions) {
+		t.Fatalf("spec has %d paths, want %d", len(doc.Paths), len(Actions))
+	}
+	for _, action := range Actions {
+		op := doc.Paths[action.Path].Post
+		if op.OperationID != action.OperationID || op.Scope != action.Scope {
+			t.Errorf("%s: operation %q scope %q", action.Path, op.OperationID, op.Scope)
+		}
+		body := op.RequestBody.Content["application/json"].Schema
+		if body.Type != "object" || len(body.Properties) == 0 {
+			t.Errorf("%s: request schema %+v", action.Path, body)
+		}
+	}
+
+	run := doc.Paths[RunAgentActionPath].Post.RequestBody.Content["application/json"].Schema
+	if len(run.Required) != 1 || run.Required[0] != "prompt" {
+		t.Errorf("run_agent required = %v, want [prompt]", run.Required)
+	}
+}
+
+func TestLookupAction(t *testing.T) {
+	action, ok := LookupAction(SearchMemoryActionPath)
+	if !ok || action.Scope != ScopeMemoryRead {
+		t.Fatalf("LookupAction = %+v, %v", action, ok)
+	}
+	if _, ok := LookupAction(ActionsPathPrefix + "missing"); ok {
+		t.Fatal("expected unknown action to be missing")
+	}
+}
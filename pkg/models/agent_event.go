@@ -42,6 +42,7 @@ type AgentEvent struct {
 	Stats    *StatsEventPayload    `json:"stats,omitempty"`
 	Context  *ContextEventPayload  `json:"context,omitempty"`
 	Steering *SteeringEventPayload `json:"steering,omitempty"`
+	Plan     *Plan                 `json:"plan,omitempty"`
 }
 
 // AgentEventType identifies the kind of agent event.
@@ -64,6 +65,7 @@ const (
 	// Model streaming
 	AgentEventModelDelta     AgentEventType = "model.delta"
 	AgentEventModelCompleted AgentEventType = "model.completed"
+	AgentEventModelReasoning AgentEventType = "model.reasoning" // Reasoning text of a model turn, redacted by default
 
 	// Tool execution and streaming IO
 	AgentEventToolStarted  AgentEventType = "tool.started"
@@ -79,6 +81,9 @@ const (
 	AgentEventSteeringInjected AgentEventType = "steering.injected" // Steering message interrupted the run
 	AgentEventToolsSkipped     AgentEventType = "tools.skipped"     // Tools were skipped due to steering
 	AgentEventFollowUpQueued   AgentEventType = "followup.queued"   // Follow-up message queued for later
+
+	// Plan progress
+	AgentEventPlanUpdated AgentEventType = "plan.updated" // The run's plan was created or a step changed
 )
 
 // TextEventPayload is generic human-readable text (logs, status messages).
@@ -101,6 +106,9 @@ type StreamEventPayload struct {
 	// Token counts (optional; not all providers supply them).
 	InputTokens  int `json:"input_tokens,omitempty"`
 	OutputTokens int `json:"output_tokens,omitempty"`
+
+	// ReasoningTokens is the part of OutputTokens spent on reasoning.
+	ReasoningTokens int `json:"reasoning_tokens,omitempty"`
 }
 
 // ToolEventPayload describes tool calls and their streamed outputs.
@@ -164,11 +172,22 @@ type RunStats struct {
 	ToolCalls    int           `json:"tool_calls,omitempty"`
 	ToolWallTime time.Duration `json:"tool_wall_time,omitempty"`
 	ToolTimeouts int           `json:"tool_timeouts,omitempty"`
+	// EarlyToolStarts counts tool calls started while the model was still
+	// streaming its turn; ToolOverlapTime is how long they ran alongside it.
+	EarlyToolStarts int           `json:"early_tool_starts,omitempty"`
+	ToolOverlapTime time.Duration `json:"tool_overlap_time,omitempty"`
 
 	// Model metrics
 	ModelWallTime time.Duration `json:"model_wall_time,omitempty"`
 	InputTokens   int           `json:"input_tokens,omitempty"`
 	OutputTokens  int           `json:"output_tokens,omitempty"`
+	// ReasoningTokens is the part of OutputTokens spent on reasoning.
+	ReasoningTokens int `json:"reasoning_tokens,omitempty"`
+
+	// Plan metrics, from the last plan.updated event
+	PlanSteps     int `json:"plan_steps,omitempty"`
+	PlanStepsDone int `json:"plan_steps_done,omitempty"`
+	PlanUpdates   int `json:"plan_updates,omitempty"`
 
 	// Context packing metrics
 	ContextPacks int `json:"context_packs,omitempty"`
@@ -236,6 +255,9 @@ type ContextPackItem struct {
 
 	// Reason explains why the item was included or dropped.
 	Reason ContextPackReason `json:"reason,omitempty"`
+
+	// CreatedAt is when the message was created.
+	CreatedAt time.Time `json:"created_at,omitzero"`
 }
 
 // ContextItemKind categorizes context items.
@@ -247,6 +269,7 @@ const (
 	ContextItemTool     ContextItemKind = "tool"
 	ContextItemSummary  ContextItemKind = "summary"
 	ContextItemIncoming ContextItemKind = "incoming"
+	ContextItemQuote    ContextItemKind = "quote"
 )
 
 // ContextPackReason explains a packing decision.
@@ -24,6 +24,7 @@ const (
 	ChannelNostr         ChannelType = "nostr"
 	ChannelZalo          ChannelType = "zalo"
 	ChannelBlueBubbles   ChannelType = "bluebubbles"
+	ChannelLoopback      ChannelType = "loopback"
 )
 
 // Direction indicates if a message is inbound or outbound.
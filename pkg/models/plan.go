@@ -0,0 +1,61 @@
+package models
+
+import "time"
+
+// PlanStepStatus is the progress of one plan step.
+type PlanStepStatus string
+
+const (
+	PlanStepPending    PlanStepStatus = "pending"
+	PlanStepInProgress PlanStepStatus = "in_progress"
+	PlanStepCompleted  PlanStepStatus = "completed"
+	PlanStepSkipped    PlanStepStatus = "skipped"
+)
+
+// Valid reports whether s is a known step status.
+func (s PlanStepStatus) Valid() bool {
+	switch s {
+	case PlanStepPending, PlanStepInProgress, PlanStepCompleted, PlanStepSkipped:
+		return true
+	}
+	return false
+}
+
+// PlanStep is one step of a plan.
+type PlanStep struct {
+	Title  string         `json:"title"`
+	Status PlanStepStatus `json:"status"`
+	// Note is a short remark on the step's outcome.
+	Note string `json:"note,omitempty"`
+}
+
+// Plan is the structured to-do list an agent keeps for a long run.
+type Plan struct {
+	Title     string     `json:"title,omitempty"`
+	Steps     []PlanStep `json:"steps"`
+	UpdatedAt time.Time  `json:"updated_at"`
+}
+
+// Done returns the number of steps completed or skipped.
+func (p *Plan) Done() int {
+	if p == nil {
+		return 0
+	}
+	done := 0
+	for _, step := range p.Steps {
+		if step.Status == PlanStepCompleted || step.Status == PlanStepSkipped {
+			done++
+		}
+	}
+	return done
+}
+
+// Clone returns a deep copy of the plan.
+func (p *Plan) Clone() *Plan {
+	if p == nil {
+		return nil
+	}
+	clone := *p
+	clone.Steps = append([]PlanStep(nil), p.Steps...)
+	return &clone
+}
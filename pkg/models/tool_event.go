@@ -28,6 +28,7 @@ type ToolEvent struct {
 	Output       string          `json:"output,omitempty"`
 	Error        string          `json:"error,omitempty"`
 	PolicyReason string          `json:"policy_reason,omitempty"`
+	Diff         string          `json:"diff,omitempty"` // Change preview for approval_required events
 	StartedAt    time.Time       `json:"started_at,omitempty"`
 	FinishedAt   time.Time       `json:"finished_at,omitempty"`
 }
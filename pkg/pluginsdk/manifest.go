@@ -25,6 +25,7 @@ type Manifest struct {
 	Commands     []string        `json:"commands,omitempty"`
 	Services     []string        `json:"services,omitempty"`
 	Hooks        []string        `json:"hooks,omitempty"`
+	Storage      []string        `json:"storage,omitempty"`
 	Capabilities *Capabilities   `json:"capabilities,omitempty"`
 	ConfigSchema json.RawMessage `json:"configSchema"`
 	Metadata     map[string]any  `json:"metadata,omitempty"`
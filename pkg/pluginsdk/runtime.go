@@ -12,6 +12,11 @@ type ToolDefinition struct {
 	Name        string
 	Description string
 	Schema      json.RawMessage
+
+	// Idempotent declares that repeating a call with the same arguments is
+	// safe, so the runtime may retry failed calls. Leave it false for tools
+	// with side effects such as sending messages or writing data.
+	Idempotent bool
 }
 
 // ToolResult contains the output from a plugin tool execution.
@@ -174,6 +179,9 @@ type PluginAPI struct {
 	// Hooks for registering event hooks.
 	Hooks HookRegistry
 
+	// Storage for registering artifact and vector memory backends.
+	Storage StorageRegistry
+
 	// Config contains the plugin's configuration from nexus.yaml.
 	Config map[string]any
 
@@ -0,0 +1,119 @@
+package pluginsdk
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"github.com/haasonsaas/nexus/pkg/models"
+)
+
+// =============================================================================
+// Storage Backends
+// =============================================================================
+
+// ArtifactStore stores the data of artifacts produced by tools. Plugins
+// provide one to keep artifacts in a backend the core does not ship, such as
+// GCS or Azure Blob. Artifact metadata stays in the core.
+type ArtifactStore interface {
+	// Put stores artifact data and returns a reference URL or ID.
+	Put(ctx context.Context, artifactID string, data io.Reader, opts ArtifactPutOptions) (string, error)
+
+	// Get retrieves artifact data by ID.
+	Get(ctx context.Context, artifactID string) (io.ReadCloser, error)
+
+	// Delete removes an artifact from storage.
+	Delete(ctx context.Context, artifactID string) error
+
+	// Exists checks if an artifact exists.
+	Exists(ctx context.Context, artifactID string) (bool, error)
+
+	// Close releases any resources.
+	Close() error
+}
+
+// ArtifactPutOptions configures how an artifact is stored.
+type ArtifactPutOptions struct {
+	MimeType string
+	TTL      time.Duration
+	Metadata map[string]string
+}
+
+// ArtifactStoreFactory opens an artifact store. It is called when the
+// gateway starts with artifacts.backend set to the registered name.
+type ArtifactStoreFactory func(ctx context.Context) (ArtifactStore, error)
+
+// VectorBackend stores vector memory entries and searches them by
+// embedding. Plugins provide one to keep memories in a backend the core does
+// not ship, such as Milvus.
+type VectorBackend interface {
+	// Index stores entries with their embeddings, replacing entries with
+	// the same ID.
+	Index(ctx context.Context, entries []*models.MemoryEntry) error
+
+	// Search finds entries similar to the query embedding.
+	Search(ctx context.Context, embedding []float32, opts VectorSearchOptions) ([]*models.SearchResult, error)
+
+	// Delete removes entries by ID.
+	Delete(ctx context.Context, ids []string) error
+
+	// Count returns the number of entries in the scope.
+	Count(ctx context.Context, scope models.MemoryScope, scopeID string) (int64, error)
+
+	// Compact optimizes the storage. Backends without maintenance can
+	// return nil.
+	Compact(ctx context.Context) error
+
+	// Close releases resources.
+	Close() error
+}
+
+// VectorSearchOptions configures a vector backend search.
+type VectorSearchOptions struct {
+	Scope     models.MemoryScope
+	ScopeID   string
+	Limit     int
+	Threshold float32
+	Filters   map[string]any
+
+	// Mode is "vector" (default), "bm25", or "hybrid". Backends without
+	// full-text search may treat every mode as "vector".
+	Mode string
+
+	// HybridAlpha weights vector similarity against BM25 in hybrid mode,
+	// from 0 (BM25 only) to 1 (vector only).
+	HybridAlpha float32
+
+	// Query is the raw text query, for BM25 and hybrid modes.
+	Query string
+}
+
+// VectorBackendConfig is passed to a VectorBackendFactory.
+type VectorBackendConfig struct {
+	// Dimension is the embedding dimension of vector_memory.
+	Dimension int
+}
+
+// VectorBackendFactory opens a vector backend. It is called when vector
+// memory starts with vector_memory.backend set to the registered name.
+type VectorBackendFactory func(ctx context.Context, cfg VectorBackendConfig) (VectorBackend, error)
+
+// StorageRegistry allows plugins to register storage backends that can be
+// selected by name in the config. Names must not clash with built-in
+// backends or backends registered by other plugins.
+type StorageRegistry interface {
+	// RegisterArtifactStore makes name selectable as artifacts.backend.
+	RegisterArtifactStore(name string, factory ArtifactStoreFactory) error
+
+	// RegisterVectorBackend makes name selectable as vector_memory.backend.
+	RegisterVectorBackend(name string, factory VectorBackendFactory) error
+}
+
+// StoragePlugin is implemented by runtime plugins that provide storage
+// backends. RegisterStorage is called once, before the artifact store and
+// vector memory are opened.
+type StoragePlugin interface {
+	RuntimePlugin
+
+	RegisterStorage(registry StorageRegistry, cfg map[string]any) error
+}
@@ -0,0 +1,436 @@
+// TypeScript client for the Nexus management API
+// (nexus.management.v1.ManagementService). Mirrors pkg/management; keep the
+// two in sync. Uses the Connect unary protocol with JSON bodies and has no
+// dependencies beyond the global fetch.
+
+export const SERVICE_NAME = "nexus.management.v1.ManagementService";
+
+export type ErrorCode =
+  | "canceled"
+  | "unknown"
+  | "invalid_argument"
+  | "deadline_exceeded"
+  | "not_found"
+  | "already_exists"
+  | "permission_denied"
+  | "resource_exhausted"
+  | "failed_precondition"
+  | "aborted"
+  | "unimplemented"
+  | "internal"
+  | "unavailable"
+  | "unauthenticated";
+
+export class ManagementError extends Error {
+  constructor(
+    readonly code: ErrorCode,
+    message: string,
+    readonly status: number,
+  ) {
+    super(message ? `${code}: ${message}` : code);
+    this.name = "ManagementError";
+  }
+}
+
+export interface Session {
+  id: string;
+  agent_id: string;
+  channel: string;
+  channel_id: string;
+  key: string;
+  title?: string;
+  metadata?: Record<string, unknown>;
+  created_at: string;
+  updated_at: string;
+}
+
+export interface ToolResult {
+  tool_call_id: string;
+  content: string;
+  is_error?: boolean;
+}
+
+export interface Message {
+  id: string;
+  session_id: string;
+  channel: string;
+  channel_id: string;
+  direction: string;
+  role: string;
+  content: string;
+  tool_results?: ToolResult[];
+  metadata?: Record<string, unknown>;
+  created_at: string;
+}
+
+export interface Agent {
+  id: string;
+  user_id: string;
+  name: string;
+  system_prompt?: string;
+  model: string;
+  provider: string;
+  tools?: string[];
+  config?: Record<string, unknown>;
+  created_at: string;
+  updated_at: string;
+}
+
+export interface Approval {
+  id: string;
+  tool_call_id: string;
+  tool_name: string;
+  input?: string;
+  agent_id?: string;
+  session_id?: string;
+  reason?: string;
+  /** Unified diff of what the call would change, for file-writing tools. */
+  diff?: string;
+  decision: string;
+  created_at: string;
+  expires_at?: string;
+  decided_at?: string;
+  decided_by?: string;
+}
+
+export interface ModelUsage {
+  provider: string;
+  model: string;
+  requests: number;
+  input_tokens: number;
+  output_tokens: number;
+  estimated_cost_usd: number;
+}
+
+export interface ListSessionsRequest {
+  agent_id?: string;
+  channel?: string;
+  limit?: number;
+  offset?: number;
+}
+
+export interface ListSessionsResponse {
+  sessions: Session[];
+}
+
+export interface GetSessionRequest {
+  id: string;
+}
+
+export interface GetSessionResponse {
+  session: Session;
+}
+
+export interface ListMessagesRequest {
+  session_id: string;
+  limit?: number;
+}
+
+export interface ListMessagesResponse {
+  messages: Message[];
+}
+
+export interface SendMessageRequest {
+  session_id?: string;
+  content: string;
+  metadata?: Record<string, string>;
+}
+
+export interface SendMessageResponse {
+  session_id: string;
+  reply: Message;
+}
+
+export interface ListAgentsRequest {
+  limit?: number;
+  offset?: number;
+}
+
+export interface ListAgentsResponse {
+  agents: Agent[];
+  total: number;
+}
+
+export interface GetAgentRequest {
+  id: string;
+}
+
+export interface GetAgentResponse {
+  agent: Agent;
+}
+
+export interface ListApprovalsRequest {
+  agent_id?: string;
+}
+
+export interface ListApprovalsResponse {
+  approvals: Approval[];
+}
+
+export interface DecideApprovalRequest {
+  id: string;
+  decision: "approve" | "deny";
+}
+
+export interface DecideApprovalResponse {
+  approval: Approval;
+}
+
+export interface GetUsageRequest {
+  days?: number;
+}
+
+export interface GetUsageResponse {
+  since: string;
+  input_tokens: number;
+  output_tokens: number;
+  estimated_cost_usd: number;
+  models: ModelUsage[];
+}
+
+export interface EraseIdentityRequest {
+  /** Peer to erase, as "channel:id". */
+  peer: string;
+  requested_by?: string;
+}
+
+export interface ErasureCounts {
+  sessions: number;
+  messages: number;
+  artifacts: number;
+  memories: number;
+}
+
+export interface EraseIdentityResponse {
+  record_id: string;
+  identities: string[];
+  removed: ErasureCounts;
+  record_path?: string;
+  errors?: string[];
+  completed_at: string;
+}
+
+export interface SandboxSnapshot {
+  id: string;
+  language: string;
+  type: string;
+  created_at: string;
+  /** Size of the snapshot files on disk. */
+  size_bytes: number;
+}
+
+export interface ListSandboxSnapshotsRequest {
+  language?: string;
+}
+
+export interface ListSandboxSnapshotsResponse {
+  snapshots: SandboxSnapshot[];
+}
+
+export interface CreateSandboxSnapshotRequest {
+  language: string;
+}
+
+export interface CreateSandboxSnapshotResponse {
+  snapshot: SandboxSnapshot;
+}
+
+export interface RefreshSandboxSnapshotsRequest {
+  /** Refreshes every language when empty. */
+  language?: string;
+}
+
+export interface RefreshSandboxSnapshotsResponse {
+  snapshots: SandboxSnapshot[];
+}
+
+export interface DeleteSandboxSnapshotRequest {
+  id: string;
+}
+
+export interface Run {
+  id: string;
+  session_id: string;
+  agent_id?: string;
+  channel?: string;
+  /** "running", "interrupted", or "failed". */
+  status: string;
+  error?: string;
+  /** The message that started the run. */
+  request?: string;
+  model?: string;
+  iteration: number;
+  tool_calls: number;
+  /** Length of the checkpointed conversation. */
+  messages: number;
+  /** Tool calls that had not finished at the last checkpoint. */
+  pending_tools?: string[];
+  created_at: string;
+  updated_at: string;
+}
+
+export interface ListRunsRequest {
+  status?: string;
+}
+
+export interface ListRunsResponse {
+  runs: Run[];
+}
+
+export interface GetRunRequest {
+  id: string;
+}
+
+export interface GetRunResponse {
+  run: Run;
+}
+
+export interface ResumeRunRequest {
+  id: string;
+}
+
+export interface ResumeRunResponse {
+  run: Run;
+}
+
+/** Stops the active run with this run or session ID, or every run with `all`. */
+export interface KillRunRequest {
+  id?: string;
+  all?: boolean;
+}
+
+export interface KillRunResponse {
+  session_ids: string[];
+}
+
+export interface ClientOptions {
+  /** API key sent as X-API-Key. */
+  apiKey?: string;
+  /** JWT sent as Authorization: Bearer. */
+  token?: string;
+  fetch?: typeof fetch;
+}
+
+export class ManagementClient {
+  private readonly baseURL: string;
+
+  constructor(
+    baseURL: string,
+    private readonly options: ClientOptions = {},
+  ) {
+    this.baseURL = baseURL.replace(/\/+$/, "");
+  }
+
+  listSessions(req: ListSessionsRequest = {}): Promise<ListSessionsResponse> {
+    return this.call("ListSessions", req);
+  }
+
+  getSession(req: GetSessionRequest): Promise<GetSessionResponse> {
+    return this.call("GetSession", req);
+  }
+
+  listMessages(req: ListMessagesRequest): Promise<ListMessagesResponse> {
+    return this.call("ListMessages", req);
+  }
+
+  sendMessage(req: SendMessageRequest): Promise<SendMessageResponse> {
+    return this.call("SendMessage", req);
+  }
+
+  listAgents(req: ListAgentsRequest = {}): Promise<ListAgentsResponse> {
+    return this.call("ListAgents", req);
+  }
+
+  getAgent(req: GetAgentRequest): Promise<GetAgentResponse> {
+    return this.call("GetAgent", req);
+  }
+
+  listApprovals(req: ListApprovalsRequest = {}): Promise<ListApprovalsResponse> {
+    return this.call("ListApprovals", req);
+  }
+
+  decideApproval(req: DecideApprovalRequest): Promise<DecideApprovalResponse> {
+    return this.call("DecideApproval", req);
+  }
+
+  getUsage(req: GetUsageRequest = {}): Promise<GetUsageResponse> {
+    return this.call("GetUsage", req);
+  }
+
+  /** Requires an admin API key. */
+  eraseIdentity(req: EraseIdentityRequest): Promise<EraseIdentityResponse> {
+    return this.call("EraseIdentity", req);
+  }
+
+  /** Requires an admin API key. */
+  listSandboxSnapshots(req: ListSandboxSnapshotsRequest = {}): Promise<ListSandboxSnapshotsResponse> {
+    return this.call("ListSandboxSnapshots", req);
+  }
+
+  /** Requires an admin API key. */
+  createSandboxSnapshot(req: CreateSandboxSnapshotRequest): Promise<CreateSandboxSnapshotResponse> {
+    return this.call("CreateSandboxSnapshot", req);
+  }
+
+  /** Requires an admin API key. */
+  refreshSandboxSnapshots(req: RefreshSandboxSnapshotsRequest = {}): Promise<RefreshSandboxSnapshotsResponse> {
+    return this.call("RefreshSandboxSnapshots", req);
+  }
+
+  /** Requires an admin API key. */
+  deleteSandboxSnapshot(req: DeleteSandboxSnapshotRequest): Promise<Record<string, never>> {
+    return this.call("DeleteSandboxSnapshot", req);
+  }
+
+  /** Requires an admin API key. */
+  listRuns(req: ListRunsRequest = {}): Promise<ListRunsResponse> {
+    return this.call("ListRuns", req);
+  }
+
+  /** Requires an admin API key. */
+  getRun(req: GetRunRequest): Promise<GetRunResponse> {
+    return this.call("GetRun", req);
+  }
+
+  /** Requires an admin API key. The run continues in the background. */
+  resumeRun(req: ResumeRunRequest): Promise<ResumeRunResponse> {
+    return this.call("ResumeRun", req);
+  }
+
+  /** Requires an admin API key. */
+  killRun(req: KillRunRequest): Promise<KillRunResponse> {
+    return this.call("KillRun", req);
+  }
+
+  private async call<T>(method: string, req: unknown): Promise<T> {
+    const headers: Record<string, string> = {
+      "Content-Type": "application/json",
+      "Connect-Protocol-Version": "1",
+    };
+    if (this.options.apiKey) {
+      headers["X-API-Key"] = this.options.apiKey;
+    }
+    if (this.options.token) {
+      headers["Authorization"] = `Bearer ${this.options.token}`;
+    }
+    const doFetch = this.options.fetch ?? fetch;
+    const resp = await doFetch(`${this.baseURL}/${SERVICE_NAME}/${method}`, {
+      method: "POST",
+      headers,
+      body: JSON.stringify(req ?? {}),
+    });
+    if (!resp.ok) {
+      let code: ErrorCode = "unknown";
+      let message = "";
+      try {
+        const body = await resp.json();
+        code = body.code ?? code;
+        message = body.message ?? "";
+      } catch {
+        message = resp.statusText;
+      }
+      throw new ManagementError(code, message, resp.status);
+    }
+    return (await resp.json()) as T;
+  }
+}